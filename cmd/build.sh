@@ -11,6 +11,9 @@ cd $basepath
 # 更新swag文档
 swag init
 
+# 按swag文档重新生成接口客户端
+go run ./cmd/sdkgen
+
 # 自动化测试并检查结果
 go test -v ./...
 
@@ -36,6 +39,6 @@ CGO_ENABLED=0 GOOS=linux GOARCH=amd64 go build \
     -X 'main.goOS=$(go env GOOS)' \
     -X 'main.goArch=$(go env GOARCH)'
   " \
-  -o bin/artweb main.go
+  -o bin/artweb .
 
 echo "Build success!"
@@ -0,0 +1,109 @@
+// sdkgen 根据swagger文档生成Go和TypeScript接口客户端
+//
+// 在项目根目录执行 go run ./cmd/sdkgen 生成, 加-check时只检查文档是否与接口注释一致、生成的文件是否与文档一致
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gin-artweb/pkg/sdkgen"
+)
+
+func main() {
+	var (
+		spec   = flag.String("spec", "docs/swagger.json", "swagger文档路径")
+		prefix = flag.String("prefix", "/api/v1/", "生成客户端方法的接口路径前缀")
+		goOut  = flag.String("go", "pkg/client/api_gen.go", "Go客户端输出路径")
+		goPkg  = flag.String("go-package", "client", "Go客户端包名")
+		tsOut  = flag.String("ts", "sdk/ts/client.ts", "TypeScript客户端输出路径")
+		src    = flag.String("src", "internal", "检查时扫描@Router注释的源码目录")
+		check  = flag.Bool("check", false, "只检查文档和生成的文件是否为最新, 不一致时返回非零退出码")
+	)
+	flag.Parse()
+
+	if *check {
+		if err := checkDoc(*spec, *src); err != nil {
+			fmt.Fprintln(os.Stderr, "sdkgen:", err)
+			os.Exit(1)
+		}
+	}
+	if err := run(*spec, *prefix, *goOut, *goPkg, *tsOut, *check); err != nil {
+		fmt.Fprintln(os.Stderr, "sdkgen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(spec, prefix, goOut, goPkg, tsOut string, check bool) error {
+	data, err := os.ReadFile(spec)
+	if err != nil {
+		return err
+	}
+	api, err := sdkgen.Load(data, prefix)
+	if err != nil {
+		return err
+	}
+	goSrc, err := sdkgen.GenerateGo(api, goPkg)
+	if err != nil {
+		return err
+	}
+	tsSrc, err := sdkgen.GenerateTS(api)
+	if err != nil {
+		return err
+	}
+
+	outputs := []struct {
+		path string
+		data []byte
+	}{
+		{goOut, goSrc},
+		{tsOut, tsSrc},
+	}
+	var stale []string
+	for _, out := range outputs {
+		if check {
+			current, err := os.ReadFile(out.path)
+			if err != nil || !bytes.Equal(current, out.data) {
+				stale = append(stale, out.path)
+			}
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(out.path), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(out.path, out.data, 0o644); err != nil {
+			return err
+		}
+	}
+	if len(stale) > 0 {
+		return fmt.Errorf("客户端代码与%s不一致, 请执行make sdk重新生成: %v", spec, stale)
+	}
+	if !check {
+		fmt.Printf("已生成%d个接口, %d个类型\n", len(api.Operations), len(api.Types))
+	}
+	return nil
+}
+
+// checkDoc 检查swagger文档中的接口是否与源码中的@Router注释一致, 避免修改接口后忘记更新文档
+func checkDoc(spec, src string) error {
+	data, err := os.ReadFile(spec)
+	if err != nil {
+		return err
+	}
+	documented, err := sdkgen.DocRouters(data)
+	if err != nil {
+		return err
+	}
+	annotated, err := sdkgen.ScanRouters(src)
+	if err != nil {
+		return err
+	}
+	missing, extra := sdkgen.DiffRouters(annotated, documented)
+	if len(missing) > 0 || len(extra) > 0 {
+		return fmt.Errorf("%s与%s中的接口注释不一致, 请执行make sdk重新生成: 缺少%v, 多余%v", spec, src, missing, extra)
+	}
+	return nil
+}
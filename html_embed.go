@@ -0,0 +1,22 @@
+//go:build embedhtml
+
+package main
+
+import (
+	"embed"
+	"io/fs"
+)
+
+// 构建前需要将前端打包文件放到html目录下
+//
+//go:embed all:html
+var embeddedHTML embed.FS
+
+// embeddedFrontend 返回内嵌的前端文件
+func embeddedFrontend() fs.FS {
+	sub, err := fs.Sub(embeddedHTML, "html")
+	if err != nil {
+		panic(err)
+	}
+	return sub
+}
@@ -0,0 +1,10 @@
+//go:build !embedhtml
+
+package main
+
+import "io/fs"
+
+// embeddedFrontend 未使用-tags embedhtml构建时没有内嵌的前端文件
+func embeddedFrontend() fs.FS {
+	return nil
+}
@@ -17,15 +17,19 @@ import (
 type ApiHandler struct {
 	log    *zap.Logger
 	svcApi *custsvc.ApiService
+	routes func() []custmodel.ApiModel
 }
 
+// NewApiHandler 创建API处理器, routes返回当前已注册路由生成的API列表
 func NewApiHandler(
 	logger *zap.Logger,
 	svcApi *custsvc.ApiService,
+	routes func() []custmodel.ApiModel,
 ) *ApiHandler {
 	return &ApiHandler{
 		log:    logger,
 		svcApi: svcApi,
+		routes: routes,
 	}
 }
 
@@ -42,22 +46,18 @@ func NewApiHandler(
 func (h *ApiHandler) CreateApi(ctx *gin.Context) {
 	var req custmodel.CreateApiRequest
 	if err := ctx.ShouldBind(&req); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定创建API请求参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"开始创建API",
 		zap.Object(commodel.RequestModelKey, &req),
-		zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	m, err := h.svcApi.CreateApi(ctx, custmodel.ApiModel{
@@ -70,21 +70,19 @@ func (h *ApiHandler) CreateApi(ctx *gin.Context) {
 		Descr:  req.Descr,
 	})
 	if err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"创建API失败",
 			zap.Error(err),
 			zap.Object(commodel.RequestModelKey, &req),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, err)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"创建API成功",
 		zap.Uint32("api_id", m.ID),
 		zap.Object(commodel.RequestModelKey, &req),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	mo := custmodel.ApiModelToStandardOut(*m)
@@ -109,11 +107,9 @@ func (h *ApiHandler) CreateApi(ctx *gin.Context) {
 func (h *ApiHandler) UpdateApi(ctx *gin.Context) {
 	var uri commodel.IDUri
 	if err := ctx.ShouldBindUri(&uri); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定APIID参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
@@ -122,22 +118,19 @@ func (h *ApiHandler) UpdateApi(ctx *gin.Context) {
 
 	var req custmodel.UpdateApiRequest
 	if err := ctx.ShouldBind(&req); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定更新API请求参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"开始更新API",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
 		zap.Object(commodel.RequestModelKey, &req),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	m, err := h.svcApi.UpdateApiByID(ctx, uri.ID, map[string]any{
@@ -147,21 +140,19 @@ func (h *ApiHandler) UpdateApi(ctx *gin.Context) {
 		"descr":  req.Descr,
 	})
 	if err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"更新API失败",
 			zap.Error(err),
 			zap.Uint32(commodel.RequestIDKey, uri.ID),
 			zap.Object(commodel.RequestModelKey, &req),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, err)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"更新API成功",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	mo := custmodel.ApiModelToStandardOut(*m)
@@ -185,38 +176,33 @@ func (h *ApiHandler) UpdateApi(ctx *gin.Context) {
 func (h *ApiHandler) DeleteApi(ctx *gin.Context) {
 	var uri commodel.IDUri
 	if err := ctx.ShouldBindUri(&uri); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定删除ApiID参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"开始删除API",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	if err := h.svcApi.DeleteApiByID(ctx, uri.ID); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"删除API失败",
 			zap.Error(err),
 			zap.Uint32(commodel.RequestIDKey, uri.ID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, err)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"删除API成功",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	ctx.JSON(commodel.NoDataReply.Code, commodel.NoDataReply)
@@ -236,39 +222,34 @@ func (h *ApiHandler) DeleteApi(ctx *gin.Context) {
 func (h *ApiHandler) GetApi(ctx *gin.Context) {
 	var uri commodel.IDUri
 	if err := ctx.ShouldBindUri(&uri); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定查询ApiID参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"开始查询API详情",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	m, err := h.svcApi.FindApiByID(ctx, uri.ID)
 	if err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"查询API详情失败",
 			zap.Error(err),
 			zap.Uint32(commodel.RequestIDKey, uri.ID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, err)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"查询API详情成功",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	mo := custmodel.ApiModelToStandardOut(*m)
@@ -292,58 +273,93 @@ func (h *ApiHandler) GetApi(ctx *gin.Context) {
 func (h *ApiHandler) ListApi(ctx *gin.Context) {
 	var req custmodel.ListApiRequest
 	if err := ctx.ShouldBindQuery(&req); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定查询API列表参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"开始查询API列表",
-		zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	page, size, query := req.Query()
 	qp := database.QueryParams{
-		IsCount: true,
-		Size:    size,
-		Page:    page,
-		OrderBy: []string{"id ASC"},
-		Query:   query,
+		IsCount:   true,
+		SkipCount: req.SkipCount(),
+		Size:      size,
+		Page:      page,
+		OrderBy:   []string{"id ASC"},
+		Query:     query,
 	}
 	total, ms, err := h.svcApi.ListApi(ctx, qp)
 	if err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"查询API列表失败",
 			zap.Error(err),
 			zap.Object(database.QueryParamsKey, &qp),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, err)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"查询API列表成功",
-		zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	mbs := custmodel.ListApiModelToStandardOut(ms)
 	ctx.JSON(http.StatusOK, &custmodel.PagApiReply{
 		Code: http.StatusOK,
-		Data: commodel.NewPag(page, size, total, mbs),
+		Data: commodel.NewPag(qp, total, mbs),
+	})
+}
+
+// @Summary 同步API目录
+// @Description 本接口用于按已注册的路由同步API目录, 新增缺失的API并标记路由中已不存在的API
+// @Tags API管理
+// @Accept json
+// @Produce json
+// @Param request body custmodel.SyncApiRequest false "同步API请求"
+// @Success 200 {object} custmodel.ApiSyncReply "同步API成功"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/customer/api/sync [post]
+// @Security ApiKeyAuth
+func (h *ApiHandler) SyncApi(ctx *gin.Context) {
+	var req custmodel.SyncApiRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBind(&req); err != nil {
+			ctxutil.Logger(ctx, h.log).Error(
+				"绑定同步API请求参数失败",
+				zap.Error(err),
+			)
+			rErr := errors.ErrValidationFailed.WithCause(err)
+			errors.RespondWithError(ctx, rErr)
+			return
+		}
+	}
+
+	out, rErr := h.svcApi.SyncApis(ctx, h.routes(), req.TagModule)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"同步API目录失败",
+			zap.Error(rErr),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &custmodel.ApiSyncReply{
+		Code: http.StatusOK,
+		Data: out,
 	})
 }
 
 func (h *ApiHandler) LoadRouter(r *gin.RouterGroup) {
+	r.POST("/api/sync", h.SyncApi)
 	r.POST("/api", h.CreateApi)
 	r.PUT("/api/:id", h.UpdateApi)
 	r.DELETE("/api/:id", h.DeleteApi)
@@ -0,0 +1,171 @@
+package customer
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	commodel "gin-artweb/internal/model/common"
+	custmodel "gin-artweb/internal/model/customer"
+	custsvc "gin-artweb/internal/service/customer"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/errors"
+)
+
+type BulkDeleteHandler struct {
+	log           *zap.Logger
+	svcBulkDelete *custsvc.BulkDeleteService
+}
+
+func NewBulkDeleteHandler(
+	log *zap.Logger,
+	svcBulkDelete *custsvc.BulkDeleteService,
+) *BulkDeleteHandler {
+	return &BulkDeleteHandler{
+		log:           log,
+		svcBulkDelete: svcBulkDelete,
+	}
+}
+
+// @Summary 检查删除角色的影响
+// @Description 本接口用于删除角色前检查引用关系, 返回阻止删除的引用、随之解除关联的记录和随之移除的Casbin组策略, 不修改数据
+// @Tags 角色管理
+// @Produce json
+// @Param ids query []uint true "角色编号列表" collectionFormat(multi)
+// @Success 200 {object} custmodel.DeleteDependenciesReply "成功返回检查结果"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 404 {object} errors.Error "角色未找到"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/customer/role/dependencies [get]
+// @Security ApiKeyAuth
+func (h *BulkDeleteHandler) CheckRoleDelete(ctx *gin.Context) {
+	h.check(ctx, "角色", h.svcBulkDelete.CheckRoleDelete)
+}
+
+// @Summary 批量删除角色
+// @Description 本接口用于在一个事务中批量删除角色, 仍是用户主角色的角色不能删除, 用户组关联和Casbin组策略随之清理
+// @Tags 角色管理
+// @Accept json
+// @Produce json
+// @Param request body custmodel.BulkDeleteRequest true "批量删除请求"
+// @Success 200 {object} custmodel.DeleteDependenciesReply "删除成功, 返回受影响的记录"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 404 {object} errors.Error "角色未找到"
+// @Failure 409 {object} errors.Error "角色仍被引用"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/customer/role/bulk-delete [post]
+// @Security ApiKeyAuth
+func (h *BulkDeleteHandler) DeleteRoles(ctx *gin.Context) {
+	h.delete(ctx, "角色", h.svcBulkDelete.DeleteRoles)
+}
+
+// @Summary 检查删除菜单的影响
+// @Description 本接口用于删除菜单前检查引用关系, 返回阻止删除的下级菜单、随之删除的按钮、随之解除授权的角色和随之移除的Casbin组策略, 不修改数据
+// @Tags 菜单管理
+// @Produce json
+// @Param ids query []uint true "菜单编号列表" collectionFormat(multi)
+// @Success 200 {object} custmodel.DeleteDependenciesReply "成功返回检查结果"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 404 {object} errors.Error "菜单未找到"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/customer/menu/dependencies [get]
+// @Security ApiKeyAuth
+func (h *BulkDeleteHandler) CheckMenuDelete(ctx *gin.Context) {
+	h.check(ctx, "菜单", h.svcBulkDelete.CheckMenuDelete)
+}
+
+// @Summary 批量删除菜单
+// @Description 本接口用于在一个事务中批量删除菜单和菜单下的按钮, 存在不在删除范围内的下级菜单时不能删除, 角色授权和Casbin组策略随之清理
+// @Tags 菜单管理
+// @Accept json
+// @Produce json
+// @Param request body custmodel.BulkDeleteRequest true "批量删除请求"
+// @Success 200 {object} custmodel.DeleteDependenciesReply "删除成功, 返回受影响的记录"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 404 {object} errors.Error "菜单未找到"
+// @Failure 409 {object} errors.Error "菜单仍被引用"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/customer/menu/bulk-delete [post]
+// @Security ApiKeyAuth
+func (h *BulkDeleteHandler) DeleteMenus(ctx *gin.Context) {
+	h.delete(ctx, "菜单", h.svcBulkDelete.DeleteMenus)
+}
+
+type bulkDeleteFunc func(ctx context.Context, ids []uint32) (*custmodel.DeleteDependencies, *errors.Error)
+
+func (h *BulkDeleteHandler) check(ctx *gin.Context, name string, fn bulkDeleteFunc) {
+	var req custmodel.BulkDeleteRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定删除检查的"+name+"ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	deps, rErr := fn(ctx, req.IDs)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"检查删除"+name+"的影响失败",
+			zap.Error(rErr),
+			zap.Object(commodel.RequestModelKey, &req),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &custmodel.DeleteDependenciesReply{
+		Code: http.StatusOK,
+		Data: custmodel.DeleteDependenciesToOut(*deps),
+	})
+}
+
+func (h *BulkDeleteHandler) delete(ctx *gin.Context, name string, fn bulkDeleteFunc) {
+	var req custmodel.BulkDeleteRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定批量删除"+name+"参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctxutil.Logger(ctx, h.log).Info(
+		"开始批量删除"+name,
+		zap.Object(commodel.RequestModelKey, &req),
+	)
+
+	deps, rErr := fn(ctx, req.IDs)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"批量删除"+name+"失败",
+			zap.Error(rErr),
+			zap.Object(commodel.RequestModelKey, &req),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctxutil.Logger(ctx, h.log).Info(
+		"批量删除"+name+"成功",
+		zap.Object(commodel.RequestModelKey, &req),
+	)
+
+	ctx.JSON(http.StatusOK, &custmodel.DeleteDependenciesReply{
+		Code: http.StatusOK,
+		Data: custmodel.DeleteDependenciesToOut(*deps),
+	})
+}
+
+func (h *BulkDeleteHandler) LoadRouter(r *gin.RouterGroup) {
+	r.GET("/role/dependencies", h.CheckRoleDelete)
+	r.POST("/role/bulk-delete", h.DeleteRoles)
+	r.GET("/menu/dependencies", h.CheckMenuDelete)
+	r.POST("/menu/bulk-delete", h.DeleteMenus)
+}
@@ -43,22 +43,18 @@ func NewButtonHandler(
 func (h *ButtonHandler) CreateButton(ctx *gin.Context) {
 	var req custmodel.CreateButtonRequest
 	if err := ctx.ShouldBind(&req); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定创建按钮请求参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"开始创建按钮",
 		zap.Object(commodel.RequestModelKey, &req),
-		zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	m, err := h.svcButton.CreateButton(ctx, req.ApiIDs, custmodel.ButtonModel{
@@ -72,22 +68,18 @@ func (h *ButtonHandler) CreateButton(ctx *gin.Context) {
 		MenuID:   req.MenuID,
 	})
 	if err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"创建按钮失败",
 			zap.Error(err),
 			zap.Object(commodel.RequestModelKey, &req),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, err)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"创建按钮成功",
 		zap.Uint32(commodel.RequestIDKey, m.ID),
-		zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	ctx.JSON(http.StatusCreated, &custmodel.ButtonReply{
@@ -112,11 +104,9 @@ func (h *ButtonHandler) CreateButton(ctx *gin.Context) {
 func (h *ButtonHandler) UpdateButton(ctx *gin.Context) {
 	var uri commodel.IDUri
 	if err := ctx.ShouldBindUri(&uri); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定按钮ID参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
@@ -125,22 +115,19 @@ func (h *ButtonHandler) UpdateButton(ctx *gin.Context) {
 
 	var req custmodel.UpdateButtonRequest
 	if err := ctx.ShouldBind(&req); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定更新按钮请求参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"开始更新按钮",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
 		zap.Object(commodel.RequestModelKey, &req),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	m, err := h.svcButton.UpdateButtonByID(ctx, uri.ID, req.ApiIDs, map[string]any{
@@ -151,21 +138,19 @@ func (h *ButtonHandler) UpdateButton(ctx *gin.Context) {
 		"menu_id":   req.MenuID,
 	})
 	if err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"更新按钮失败",
 			zap.Error(err),
 			zap.Uint32(commodel.RequestIDKey, uri.ID),
 			zap.Object(commodel.RequestModelKey, &req),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, err)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"更新按钮成功",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	ctx.JSON(http.StatusOK, &custmodel.ButtonReply{
@@ -189,38 +174,33 @@ func (h *ButtonHandler) UpdateButton(ctx *gin.Context) {
 func (h *ButtonHandler) DeleteButton(ctx *gin.Context) {
 	var uri commodel.IDUri
 	if err := ctx.ShouldBindUri(&uri); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定删除按钮ID参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"开始删除按钮",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	if err := h.svcButton.DeleteButtonByID(ctx, uri.ID); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"删除按钮失败",
 			zap.Error(err),
 			zap.Uint32(commodel.RequestIDKey, uri.ID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, err)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"删除按钮成功",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	ctx.JSON(commodel.NoDataReply.Code, commodel.NoDataReply)
@@ -241,39 +221,34 @@ func (h *ButtonHandler) DeleteButton(ctx *gin.Context) {
 func (h *ButtonHandler) GetButton(ctx *gin.Context) {
 	var uri commodel.IDUri
 	if err := ctx.ShouldBindUri(&uri); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定查询按钮ID参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"开始查询按钮详情",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	m, err := h.svcButton.FindButtonByID(ctx, []string{"Apis", "Menu"}, uri.ID)
 	if err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"查询按钮详情失败",
 			zap.Error(err),
 			zap.Uint32(commodel.RequestIDKey, uri.ID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, err)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"查询按钮详情成功",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	ctx.JSON(http.StatusOK, &custmodel.ButtonReply{
@@ -296,54 +271,47 @@ func (h *ButtonHandler) GetButton(ctx *gin.Context) {
 func (h *ButtonHandler) ListButton(ctx *gin.Context) {
 	var req custmodel.ListButtonRequest
 	if err := ctx.ShouldBindQuery(&req); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定查询按钮列表参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"开始查询按钮列表",
-		zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	page, size, query := req.Query()
 	qp := database.QueryParams{
-		IsCount: true,
-		Size:    size,
-		Page:    page,
-		OrderBy: []string{"id ASC"},
-		Query:   query,
+		IsCount:   true,
+		SkipCount: req.SkipCount(),
+		Size:      size,
+		Page:      page,
+		OrderBy:   []string{"id ASC"},
+		Query:     query,
 	}
 	total, ms, err := h.svcButton.ListButton(ctx, qp)
 	if err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"查询按钮列表失败",
 			zap.Error(err),
 			zap.Object(database.QueryParamsKey, &qp),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, err)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"查询按钮列表成功",
-		zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	mbs := custmodel.ListButtonModelToStandardOut(ms)
 	ctx.JSON(http.StatusOK, &custmodel.PagButtonReply{
 		Code: http.StatusOK,
-		Data: commodel.NewPag(page, size, total, mbs),
+		Data: commodel.NewPag(qp, total, mbs),
 	})
 }
 
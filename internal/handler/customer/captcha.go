@@ -0,0 +1,54 @@
+package customer
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	custmodel "gin-artweb/internal/model/customer"
+	custsvc "gin-artweb/internal/service/customer"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/errors"
+)
+
+type CaptchaHandler struct {
+	log        *zap.Logger
+	svcCaptcha *custsvc.CaptchaService
+}
+
+func NewCaptchaHandler(
+	log *zap.Logger,
+	svcCaptcha *custsvc.CaptchaService,
+) *CaptchaHandler {
+	return &CaptchaHandler{
+		log:        log,
+		svcCaptcha: svcCaptcha,
+	}
+}
+
+// @Summary 获取登录验证码
+// @Description 本接口用于登录失败次数过多时获取验证码, 登录时提交captcha_id和captcha_answer
+// @Tags 用户管理
+// @Produce json
+// @Success 200 {object} custmodel.CaptchaReply "成功返回验证码"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/captcha [get]
+func (h *CaptchaHandler) GetCaptcha(ctx *gin.Context) {
+	out, rErr := h.svcCaptcha.Generate(ctx)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"生成登录验证码失败",
+			zap.Error(rErr),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	// 验证码只能使用一次, 禁止缓存
+	ctx.Header("Cache-Control", "no-store")
+	ctx.JSON(http.StatusOK, &custmodel.CaptchaReply{
+		Code: http.StatusOK,
+		Data: *out,
+	})
+}
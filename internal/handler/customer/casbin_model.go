@@ -0,0 +1,244 @@
+package customer
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	commodel "gin-artweb/internal/model/common"
+	custmodel "gin-artweb/internal/model/customer"
+	custsvc "gin-artweb/internal/service/customer"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+)
+
+type CasbinModelHandler struct {
+	log      *zap.Logger
+	svcModel *custsvc.CasbinModelService
+}
+
+func NewCasbinModelHandler(
+	logger *zap.Logger,
+	svcModel *custsvc.CasbinModelService,
+) *CasbinModelHandler {
+	return &CasbinModelHandler{
+		log:      logger,
+		svcModel: svcModel,
+	}
+}
+
+// @Summary 查询生效的Casbin模型
+// @Description 本接口用于查询当前生效的Casbin模型配置
+// @Tags Casbin模型管理
+// @Accept json
+// @Produce json
+// @Success 200 {object} custmodel.CasbinModelReply "成功返回Casbin模型配置"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/customer/casbin/model [get]
+// @Security ApiKeyAuth
+func (h *CasbinModelHandler) GetActiveModel(ctx *gin.Context) {
+	ctxutil.Logger(ctx, h.log).Info(
+		"开始查询生效的Casbin模型",
+	)
+
+	m, rErr := h.svcModel.FindActiveModel(ctx)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询生效的Casbin模型失败",
+			zap.Error(rErr),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &custmodel.CasbinModelReply{
+		Code: http.StatusOK,
+		Data: *custmodel.CasbinModelToOut(*m),
+	})
+}
+
+// @Summary 查询Casbin模型历史版本
+// @Description 本接口用于分页查询Casbin模型的历史版本
+// @Tags Casbin模型管理
+// @Accept json
+// @Produce json
+// @Param request query custmodel.ListCasbinModelRequest false "查询参数"
+// @Success 200 {object} custmodel.PagCasbinModelReply "成功返回Casbin模型历史版本"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/customer/casbin/model/history [get]
+// @Security ApiKeyAuth
+func (h *CasbinModelHandler) ListModelHistory(ctx *gin.Context) {
+	var req custmodel.ListCasbinModelRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定查询Casbin模型历史版本参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	page, size, query := req.Query()
+	qp := database.QueryParams{
+		IsCount:   true,
+		SkipCount: req.SkipCount(),
+		Size:      size,
+		Page:      page,
+		OrderBy:   []string{"version DESC"},
+		Query:     query,
+	}
+	total, ms, rErr := h.svcModel.ListModel(ctx, qp)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询Casbin模型历史版本失败",
+			zap.Error(rErr),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	mbs := custmodel.ListCasbinModelToOut(ms)
+	ctx.JSON(http.StatusOK, &custmodel.PagCasbinModelReply{
+		Code: http.StatusOK,
+		Data: commodel.NewPag(qp, total, mbs),
+	})
+}
+
+// @Summary 校验Casbin模型
+// @Description 本接口用于在应用前校验Casbin模型配置，使用当前策略对样例请求求值，不会修改生效的模型
+// @Tags Casbin模型管理
+// @Accept json
+// @Produce json
+// @Param request body custmodel.ValidateCasbinModelRequest true "校验Casbin模型请求"
+// @Success 200 {object} custmodel.CasbinModelValidateReply "成功返回校验结果"
+// @Failure 400 {object} errors.Error "请求参数错误或模型无效"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/customer/casbin/model/validate [post]
+// @Security ApiKeyAuth
+func (h *CasbinModelHandler) ValidateModel(ctx *gin.Context) {
+	var req custmodel.ValidateCasbinModelRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定校验Casbin模型参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	results, passed, rErr := h.svcModel.ValidateModel(ctx, req.Content, req.Samples)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"校验Casbin模型失败",
+			zap.Error(rErr),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &custmodel.CasbinModelValidateReply{
+		Code: http.StatusOK,
+		Data: custmodel.CasbinModelValidateOut{
+			Passed:  passed,
+			Results: results,
+		},
+	})
+}
+
+// @Summary 更新Casbin模型
+// @Description 本接口用于更新Casbin模型配置，校验全部通过后立即生效并保存为新版本
+// @Tags Casbin模型管理
+// @Accept json
+// @Produce json
+// @Param request body custmodel.UpdateCasbinModelRequest true "更新Casbin模型请求"
+// @Success 200 {object} custmodel.CasbinModelReply "成功返回新的Casbin模型配置"
+// @Failure 400 {object} errors.Error "请求参数错误或校验未通过"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/customer/casbin/model [put]
+// @Security ApiKeyAuth
+func (h *CasbinModelHandler) UpdateModel(ctx *gin.Context) {
+	var req custmodel.UpdateCasbinModelRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定更新Casbin模型参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	claims, rErr := ctxutil.GetUserClaims(ctx)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"获取个人登录信息失败",
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := h.svcModel.UpdateModel(ctx, req.Content, req.Samples, req.Remark, claims.Username)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"更新Casbin模型失败",
+			zap.Error(rErr),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &custmodel.CasbinModelReply{
+		Code: http.StatusOK,
+		Data: *custmodel.CasbinModelToOut(*m),
+	})
+}
+
+// @Summary 回滚Casbin模型
+// @Description 本接口用于将Casbin模型回滚到上一个版本
+// @Tags Casbin模型管理
+// @Accept json
+// @Produce json
+// @Success 200 {object} custmodel.CasbinModelReply "成功返回回滚后的Casbin模型配置"
+// @Failure 400 {object} errors.Error "没有可回滚的版本"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/customer/casbin/model/rollback [post]
+// @Security ApiKeyAuth
+func (h *CasbinModelHandler) RollbackModel(ctx *gin.Context) {
+	ctxutil.Logger(ctx, h.log).Info(
+		"开始回滚Casbin模型",
+	)
+
+	m, rErr := h.svcModel.RollbackModel(ctx)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"回滚Casbin模型失败",
+			zap.Error(rErr),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctxutil.Logger(ctx, h.log).Info(
+		"回滚Casbin模型成功",
+		zap.Uint32("version", m.Version),
+	)
+
+	ctx.JSON(http.StatusOK, &custmodel.CasbinModelReply{
+		Code: http.StatusOK,
+		Data: *custmodel.CasbinModelToOut(*m),
+	})
+}
+
+func (h *CasbinModelHandler) LoadRouter(r *gin.RouterGroup) {
+	r.GET("/casbin/model", h.GetActiveModel)
+	r.PUT("/casbin/model", h.UpdateModel)
+	r.GET("/casbin/model/history", h.ListModelHistory)
+	r.POST("/casbin/model/validate", h.ValidateModel)
+	r.POST("/casbin/model/rollback", h.RollbackModel)
+}
@@ -0,0 +1,227 @@
+package customer
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	commodel "gin-artweb/internal/model/common"
+	custmodel "gin-artweb/internal/model/customer"
+	custsvc "gin-artweb/internal/service/customer"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/errors"
+)
+
+type DeactivationHandler struct {
+	log           *zap.Logger
+	svcDeactivate *custsvc.DeactivationService
+}
+
+func NewDeactivationHandler(
+	log *zap.Logger,
+	svcDeactivate *custsvc.DeactivationService,
+) *DeactivationHandler {
+	return &DeactivationHandler{
+		log:           log,
+		svcDeactivate: svcDeactivate,
+	}
+}
+
+// @Summary 停用用户
+// @Description 本接口用于停用离职用户, 停用后立即终止用户的全部会话, 宽限期内可以通过更新用户重新启用,
+// @Description 到期后永久停用. 响应中返回用户名下仍有的记录, 需要在永久停用前转移给其他用户. 不能停用当前登录的用户
+// @Tags 用户管理
+// @Accept json
+// @Produce json
+// @Param id path uint true "用户编号"
+// @Param request body custmodel.DeactivateUserRequest false "停用用户请求"
+// @Success 200 {object} custmodel.DeactivateUserReply "停用用户成功"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 404 {object} errors.Error "用户不存在"
+// @Failure 409 {object} errors.Error "用户已永久停用"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/customer/user/{id}/deactivate [post]
+// @Security ApiKeyAuth
+func (h *DeactivationHandler) DeactivateUser(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定用户ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	var req custmodel.DeactivateUserRequest
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBind(&req); err != nil {
+			ctxutil.Logger(ctx, h.log).Error(
+				"绑定停用用户请求参数失败",
+				zap.Error(err),
+			)
+			rErr := errors.ErrValidationFailed.WithCause(err)
+			errors.RespondWithError(ctx, rErr)
+			return
+		}
+	}
+	graceDays := custmodel.DefaultDeactivateGraceDays
+	if req.GraceDays != nil {
+		graceDays = *req.GraceDays
+	}
+
+	ctxutil.Logger(ctx, h.log).Info(
+		"开始停用用户",
+		zap.Uint32(commodel.RequestIDKey, uri.ID),
+		zap.Object(commodel.RequestModelKey, &req),
+	)
+
+	m, revoked, rErr := h.svcDeactivate.DeactivateUser(ctx, uri.ID, graceDays)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"停用用户失败",
+			zap.Error(rErr),
+			zap.Uint32(commodel.RequestIDKey, uri.ID),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	owned, rErr := h.svcDeactivate.ListOwned(ctx, uri.ID)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询停用用户名下的记录失败",
+			zap.Error(rErr),
+			zap.Uint32(commodel.RequestIDKey, uri.ID),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctxutil.Logger(ctx, h.log).Info(
+		"停用用户成功",
+		zap.Uint32(commodel.RequestIDKey, uri.ID),
+	)
+
+	ctx.JSON(http.StatusOK, &custmodel.DeactivateUserReply{
+		Code: http.StatusOK,
+		Data: custmodel.DeactivateUserOut{
+			User:            custmodel.UserModelToStandardOut(*m),
+			RevokedSessions: revoked,
+			Owned:           owned,
+		},
+	})
+}
+
+// @Summary 查询用户名下的记录
+// @Description 本接口用于查询用户创建且仍归属于该用户的计划任务、webhook订阅等记录, 每类最多列出50条
+// @Tags 用户管理
+// @Produce json
+// @Param id path uint true "用户编号"
+// @Success 200 {object} custmodel.OwnedReply "成功返回用户名下的记录"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 404 {object} errors.Error "用户不存在"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/customer/user/{id}/owned [get]
+// @Security ApiKeyAuth
+func (h *DeactivationHandler) ListOwned(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定用户ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	owned, rErr := h.svcDeactivate.ListOwned(ctx, uri.ID)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询用户名下的记录失败",
+			zap.Error(rErr),
+			zap.Uint32(commodel.RequestIDKey, uri.ID),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &custmodel.OwnedReply{
+		Code: http.StatusOK,
+		Data: owned,
+	})
+}
+
+// @Summary 转移用户名下的记录
+// @Description 本接口用于将用户名下的记录转移给其他已启用的用户, 转移后计划任务以接收用户的身份执行
+// @Tags 用户管理
+// @Accept json
+// @Produce json
+// @Param id path uint true "用户编号"
+// @Param request body custmodel.HandoverRequest true "转移记录请求"
+// @Success 200 {object} custmodel.HandoverReply "转移记录成功"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 404 {object} errors.Error "用户不存在"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/customer/user/{id}/handover [post]
+// @Security ApiKeyAuth
+func (h *DeactivationHandler) Handover(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定用户ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	var req custmodel.HandoverRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定转移记录请求参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctxutil.Logger(ctx, h.log).Info(
+		"开始转移用户名下的记录",
+		zap.Uint32(commodel.RequestIDKey, uri.ID),
+		zap.Object(commodel.RequestModelKey, &req),
+	)
+
+	groups, rErr := h.svcDeactivate.Handover(ctx, uri.ID, req.ToUserID, req.Kinds)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"转移用户名下的记录失败",
+			zap.Error(rErr),
+			zap.Uint32(commodel.RequestIDKey, uri.ID),
+			zap.Object(commodel.RequestModelKey, &req),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctxutil.Logger(ctx, h.log).Info(
+		"转移用户名下的记录成功",
+		zap.Uint32(commodel.RequestIDKey, uri.ID),
+	)
+
+	ctx.JSON(http.StatusOK, &custmodel.HandoverReply{
+		Code: http.StatusOK,
+		Data: groups,
+	})
+}
+
+func (h *DeactivationHandler) LoadRouter(r *gin.RouterGroup) {
+	r.POST("/user/:id/deactivate", h.DeactivateUser)
+	r.GET("/user/:id/owned", h.ListOwned)
+	r.POST("/user/:id/handover", h.Handover)
+}
@@ -0,0 +1,460 @@
+package customer
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	commodel "gin-artweb/internal/model/common"
+	custmodel "gin-artweb/internal/model/customer"
+	custsvc "gin-artweb/internal/service/customer"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+)
+
+type DepartmentHandler struct {
+	log     *zap.Logger
+	svcDept *custsvc.DepartmentService
+}
+
+func NewDepartmentHandler(
+	log *zap.Logger,
+	svcDept *custsvc.DepartmentService,
+) *DepartmentHandler {
+	return &DepartmentHandler{
+		log:     log,
+		svcDept: svcDept,
+	}
+}
+
+// @Summary 新增部门
+// @Description 本接口用于新增部门, 未指定父部门时创建根部门
+// @Tags 部门管理
+// @Accept json
+// @Produce json
+// @Param request body custmodel.CreateOrUpdateDepartmentRequest true "创建部门请求"
+// @Success 201 {object} custmodel.DepartmentReply "成功返回部门信息"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 404 {object} errors.Error "父部门未找到"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/customer/department [post]
+// @Security ApiKeyAuth
+func (h *DepartmentHandler) CreateDepartment(ctx *gin.Context) {
+	var req custmodel.CreateOrUpdateDepartmentRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定创建部门请求参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctxutil.Logger(ctx, h.log).Info(
+		"开始创建部门",
+		zap.Object(commodel.RequestModelKey, &req),
+	)
+
+	m, err := h.svcDept.CreateDepartment(ctx, custmodel.DepartmentModel{
+		Name:     req.Name,
+		Sort:     req.Sort,
+		Descr:    req.Descr,
+		ParentID: req.ParentID,
+	})
+	if err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"创建部门失败",
+			zap.Error(err),
+			zap.Object(commodel.RequestModelKey, &req),
+		)
+		errors.RespondWithError(ctx, err)
+		return
+	}
+
+	ctxutil.Logger(ctx, h.log).Info(
+		"创建部门成功",
+		zap.Uint32(commodel.RequestIDKey, m.ID),
+	)
+
+	ctx.JSON(http.StatusCreated, &custmodel.DepartmentReply{
+		Code: http.StatusCreated,
+		Data: custmodel.DepartmentModelToDetailOut(*m),
+	})
+}
+
+// @Summary 更新部门
+// @Description 本接口用于更新指定ID的部门, 请求中的父部门ID不生效, 调整上级部门使用移动部门接口
+// @Tags 部门管理
+// @Accept json
+// @Produce json
+// @Param id path uint true "部门编号"
+// @Param request body custmodel.CreateOrUpdateDepartmentRequest true "更新部门请求"
+// @Success 200 {object} custmodel.DepartmentReply "成功返回部门信息"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 404 {object} errors.Error "部门未找到"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/customer/department/{id} [put]
+// @Security ApiKeyAuth
+func (h *DepartmentHandler) UpdateDepartment(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定部门ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	var req custmodel.CreateOrUpdateDepartmentRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定更新部门请求参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctxutil.Logger(ctx, h.log).Info(
+		"开始更新部门",
+		zap.Uint32(commodel.RequestIDKey, uri.ID),
+		zap.Object(commodel.RequestModelKey, &req),
+	)
+
+	m, err := h.svcDept.UpdateDepartmentByID(ctx, uri.ID, map[string]any{
+		"name":  req.Name,
+		"sort":  req.Sort,
+		"descr": req.Descr,
+	})
+	if err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"更新部门失败",
+			zap.Error(err),
+			zap.Uint32(commodel.RequestIDKey, uri.ID),
+			zap.Object(commodel.RequestModelKey, &req),
+		)
+		errors.RespondWithError(ctx, err)
+		return
+	}
+
+	ctxutil.Logger(ctx, h.log).Info(
+		"更新部门成功",
+		zap.Uint32(commodel.RequestIDKey, uri.ID),
+	)
+
+	ctx.JSON(http.StatusOK, &custmodel.DepartmentReply{
+		Code: http.StatusOK,
+		Data: custmodel.DepartmentModelToDetailOut(*m),
+	})
+}
+
+// @Summary 移动部门
+// @Description 本接口用于将部门及其下级部门整体移动到新的父部门下, 父部门为空或0时移动为根部门,
+// @Description 不能移动到部门自身或其下级部门下
+// @Tags 部门管理
+// @Accept json
+// @Produce json
+// @Param id path uint true "部门编号"
+// @Param request body custmodel.MoveDepartmentRequest true "移动部门请求"
+// @Success 200 {object} custmodel.DepartmentReply "成功返回部门信息"
+// @Failure 400 {object} errors.Error "请求参数错误或不能移动到自身或下级部门下"
+// @Failure 404 {object} errors.Error "部门未找到"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/customer/department/{id}/move [patch]
+// @Security ApiKeyAuth
+func (h *DepartmentHandler) MoveDepartment(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定部门ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	var req custmodel.MoveDepartmentRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定移动部门请求参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctxutil.Logger(ctx, h.log).Info(
+		"开始移动部门",
+		zap.Uint32(commodel.RequestIDKey, uri.ID),
+		zap.Uint32p("parent_id", req.ParentID),
+	)
+
+	m, err := h.svcDept.MoveDepartment(ctx, uri.ID, req.ParentID)
+	if err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"移动部门失败",
+			zap.Error(err),
+			zap.Uint32(commodel.RequestIDKey, uri.ID),
+			zap.Uint32p("parent_id", req.ParentID),
+		)
+		errors.RespondWithError(ctx, err)
+		return
+	}
+
+	ctxutil.Logger(ctx, h.log).Info(
+		"移动部门成功",
+		zap.Uint32(commodel.RequestIDKey, uri.ID),
+	)
+
+	ctx.JSON(http.StatusOK, &custmodel.DepartmentReply{
+		Code: http.StatusOK,
+		Data: custmodel.DepartmentModelToDetailOut(*m),
+	})
+}
+
+// @Summary 删除部门
+// @Description 本接口用于删除指定ID的部门, 部门下存在下级部门或用户时不能删除
+// @Tags 部门管理
+// @Accept json
+// @Produce json
+// @Param id path uint true "部门编号"
+// @Success 200 {object} commodel.MapAPIReply "删除成功"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 404 {object} errors.Error "部门未找到"
+// @Failure 409 {object} errors.Error "部门下存在下级部门或用户"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/customer/department/{id} [delete]
+// @Security ApiKeyAuth
+func (h *DepartmentHandler) DeleteDepartment(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定删除部门ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctxutil.Logger(ctx, h.log).Info(
+		"开始删除部门",
+		zap.Uint32(commodel.RequestIDKey, uri.ID),
+	)
+
+	if err := h.svcDept.DeleteDepartmentByID(ctx, uri.ID); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"删除部门失败",
+			zap.Error(err),
+			zap.Uint32(commodel.RequestIDKey, uri.ID),
+		)
+		errors.RespondWithError(ctx, err)
+		return
+	}
+
+	ctxutil.Logger(ctx, h.log).Info(
+		"删除部门成功",
+		zap.Uint32(commodel.RequestIDKey, uri.ID),
+	)
+
+	ctx.JSON(commodel.NoDataReply.Code, commodel.NoDataReply)
+}
+
+// @Summary 查询部门
+// @Description 本接口用于查询指定ID的部门
+// @Tags 部门管理
+// @Accept json
+// @Produce json
+// @Param id path uint true "部门编号"
+// @Success 200 {object} custmodel.DepartmentReply "成功返回部门信息"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 404 {object} errors.Error "部门未找到"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/customer/department/{id} [get]
+// @Security ApiKeyAuth
+func (h *DepartmentHandler) GetDepartment(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定查询部门ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, err := h.svcDept.FindDepartmentByID(ctx, []string{"Parent"}, uri.ID)
+	if err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询部门详情失败",
+			zap.Error(err),
+			zap.Uint32(commodel.RequestIDKey, uri.ID),
+		)
+		errors.RespondWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &custmodel.DepartmentReply{
+		Code: http.StatusOK,
+		Data: custmodel.DepartmentModelToDetailOut(*m),
+	})
+}
+
+// @Summary 查询部门列表
+// @Description 本接口用于查询部门列表
+// @Tags 部门管理
+// @Accept json
+// @Produce json
+// @Param request query custmodel.ListDepartmentRequest false "查询参数"
+// @Success 200 {object} custmodel.PagDepartmentReply "成功返回部门列表"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/customer/department [get]
+// @Security ApiKeyAuth
+func (h *DepartmentHandler) ListDepartment(ctx *gin.Context) {
+	var req custmodel.ListDepartmentRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定查询部门列表参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	page, size, query := req.Query()
+	qp := database.QueryParams{
+		IsCount:   true,
+		SkipCount: req.SkipCount(),
+		Size:      size,
+		Page:      page,
+		OrderBy:   []string{"sort ASC", "id ASC"},
+		Query:     query,
+	}
+	total, ms, err := h.svcDept.ListDepartment(ctx, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询部门列表失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		errors.RespondWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &custmodel.PagDepartmentReply{
+		Code: http.StatusOK,
+		Data: commodel.NewPag(qp, total, custmodel.ListDepartmentModelToStandardOut(ms)),
+	})
+}
+
+// @Summary 查询组织架构树
+// @Description 本接口用于查询完整的部门树, 同级部门按排序字段排序
+// @Tags 部门管理
+// @Produce json
+// @Success 200 {object} custmodel.DepartmentTreeReply "成功返回部门树"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/customer/department/tree [get]
+// @Security ApiKeyAuth
+func (h *DepartmentHandler) GetDepartmentTree(ctx *gin.Context) {
+	tree, err := h.svcDept.GetDepartmentTree(ctx)
+	if err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询组织架构树失败",
+			zap.Error(err),
+		)
+		errors.RespondWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &custmodel.DepartmentTreeReply{
+		Code: http.StatusOK,
+		Data: &tree,
+	})
+}
+
+// @Summary 设置用户所属部门
+// @Description 本接口用于设置指定用户所属的部门, 部门ID为空或0时移出部门
+// @Tags 部门管理
+// @Accept json
+// @Produce json
+// @Param id path uint true "用户编号"
+// @Param request body custmodel.AssignDepartmentRequest true "设置用户所属部门请求"
+// @Success 200 {object} custmodel.UserReply "成功返回用户信息"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 404 {object} errors.Error "用户或部门未找到"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/customer/user/{id}/department [put]
+// @Security ApiKeyAuth
+func (h *DepartmentHandler) AssignUserDepartment(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定用户ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	var req custmodel.AssignDepartmentRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定设置用户所属部门请求参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctxutil.Logger(ctx, h.log).Info(
+		"开始设置用户所属部门",
+		zap.Uint32(commodel.RequestIDKey, uri.ID),
+		zap.Uint32p("department_id", req.DepartmentID),
+	)
+
+	m, err := h.svcDept.AssignUserDepartment(ctx, uri.ID, req.DepartmentID)
+	if err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"设置用户所属部门失败",
+			zap.Error(err),
+			zap.Uint32(commodel.RequestIDKey, uri.ID),
+			zap.Uint32p("department_id", req.DepartmentID),
+		)
+		errors.RespondWithError(ctx, err)
+		return
+	}
+
+	ctxutil.Logger(ctx, h.log).Info(
+		"设置用户所属部门成功",
+		zap.Uint32(commodel.RequestIDKey, uri.ID),
+	)
+
+	ctx.JSON(http.StatusOK, &custmodel.UserReply{
+		Code: http.StatusOK,
+		Data: custmodel.UserModelToDetailOut(*m),
+	})
+}
+
+func (h *DepartmentHandler) LoadRouter(r *gin.RouterGroup) {
+	r.POST("/department", h.CreateDepartment)
+	r.GET("/department/tree", h.GetDepartmentTree)
+	r.PUT("/department/:id", h.UpdateDepartment)
+	r.PATCH("/department/:id/move", h.MoveDepartment)
+	r.DELETE("/department/:id", h.DeleteDepartment)
+	r.GET("/department/:id", h.GetDepartment)
+	r.GET("/department", h.ListDepartment)
+	r.PUT("/user/:id/department", h.AssignUserDepartment)
+}
@@ -0,0 +1,81 @@
+package customer
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	commodel "gin-artweb/internal/model/common"
+	custmodel "gin-artweb/internal/model/customer"
+	custsvc "gin-artweb/internal/service/customer"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/errors"
+)
+
+type ImpersonationHandler struct {
+	log            *zap.Logger
+	svcImpersonate *custsvc.ImpersonationService
+}
+
+func NewImpersonationHandler(
+	log *zap.Logger,
+	svcImpersonate *custsvc.ImpersonationService,
+) *ImpersonationHandler {
+	return &ImpersonationHandler{
+		log:            log,
+		svcImpersonate: svcImpersonate,
+	}
+}
+
+// @Summary 代为登录用户
+// @Description 本接口用于工作人员代为登录指定用户以复现权限问题, 签发的访问令牌有效期较短且不能刷新,
+// @Description 令牌中携带管理员身份, 使用该令牌的响应带有X-Impersonated-By响应头, 全部请求记录审计记录.
+// @Description 不能代为登录自己、其他工作人员和未激活的用户
+// @Tags 用户管理
+// @Produce json
+// @Param id path uint true "用户编号"
+// @Success 200 {object} custmodel.ImpersonateReply "成功返回代登录令牌"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 403 {object} errors.Error "不允许代为登录该用户"
+// @Failure 404 {object} errors.Error "用户不存在"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/customer/user/{id}/impersonate [post]
+// @Security ApiKeyAuth
+func (h *ImpersonationHandler) Impersonate(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定用户ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	claims, rErr := ctxutil.GetUserClaims(ctx)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	out, rErr := h.svcImpersonate.Impersonate(ctx, claims, uri.ID, ctx.ClientIP(), ctx.Request.UserAgent())
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"代为登录用户失败",
+			zap.Error(rErr),
+			zap.Uint32("user_id", uri.ID),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+	ctx.JSON(http.StatusOK, &custmodel.ImpersonateReply{
+		Code: http.StatusOK,
+		Data: *out,
+	})
+}
+
+func (h *ImpersonationHandler) LoadRouter(r *gin.RouterGroup) {
+	r.POST("/user/:id/impersonate", h.Impersonate)
+}
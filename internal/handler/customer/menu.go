@@ -43,11 +43,9 @@ func NewMenuHandler(
 func (h *MenuHandler) CreateMenu(ctx *gin.Context) {
 	var req custmodel.CreateMenuRequest
 	if err := ctx.ShouldBind(&req); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定创建菜单请求参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
@@ -58,11 +56,9 @@ func (h *MenuHandler) CreateMenu(ctx *gin.Context) {
 		req.ParentID = nil
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"开始创建菜单",
 		zap.Object(commodel.RequestModelKey, &req),
-		zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	m, err := h.svcMenu.CreateMenu(ctx, req.ApiIDs, custmodel.MenuModel{
@@ -82,22 +78,18 @@ func (h *MenuHandler) CreateMenu(ctx *gin.Context) {
 		ParentID: req.ParentID,
 	})
 	if err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"创建菜单失败",
 			zap.Error(err),
 			zap.Object(commodel.RequestModelKey, &req),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, err)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"创建菜单成功",
 		zap.Uint32(commodel.RequestIDKey, m.ID),
-		zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	mo := custmodel.MenuModelToDetailOut(*m)
@@ -123,11 +115,9 @@ func (h *MenuHandler) CreateMenu(ctx *gin.Context) {
 func (h *MenuHandler) UpdateMenu(ctx *gin.Context) {
 	var uri commodel.IDUri
 	if err := ctx.ShouldBindUri(&uri); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定菜单ID参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
@@ -136,22 +126,19 @@ func (h *MenuHandler) UpdateMenu(ctx *gin.Context) {
 
 	var req custmodel.UpdateMenuRequest
 	if err := ctx.ShouldBind(&req); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定更新菜单请求参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"开始更新菜单",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
 		zap.Object(commodel.RequestModelKey, &req),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	data := map[string]any{
@@ -169,21 +156,19 @@ func (h *MenuHandler) UpdateMenu(ctx *gin.Context) {
 
 	m, err := h.svcMenu.UpdateMenuByID(ctx, uri.ID, req.ApiIDs, data)
 	if err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"更新菜单失败",
 			zap.Error(err),
 			zap.Uint32(commodel.RequestIDKey, uri.ID),
 			zap.Object(commodel.RequestModelKey, &req),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, err)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"更新菜单成功",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	mo := custmodel.MenuModelToDetailOut(*m)
@@ -208,38 +193,33 @@ func (h *MenuHandler) UpdateMenu(ctx *gin.Context) {
 func (h *MenuHandler) DeleteMenu(ctx *gin.Context) {
 	var uri commodel.IDUri
 	if err := ctx.ShouldBindUri(&uri); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定删除菜单ID参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"开始删除菜单",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	if err := h.svcMenu.DeleteMenuByID(ctx, uri.ID); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"删除菜单失败",
 			zap.Error(err),
 			zap.Uint32(commodel.RequestIDKey, uri.ID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, err)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"删除菜单成功",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	ctx.JSON(commodel.NoDataReply.Code, commodel.NoDataReply)
@@ -260,39 +240,34 @@ func (h *MenuHandler) DeleteMenu(ctx *gin.Context) {
 func (h *MenuHandler) GetMenu(ctx *gin.Context) {
 	var uri commodel.IDUri
 	if err := ctx.ShouldBindUri(&uri); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定查询菜单ID参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"开始查询菜单详情",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	m, err := h.svcMenu.FindMenuByID(ctx, []string{"Parent", "Apis"}, uri.ID)
 	if err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"查询菜单详情失败",
 			zap.Error(err),
 			zap.Uint32(commodel.RequestIDKey, uri.ID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, err)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"查询菜单详情成功",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	mo := custmodel.MenuModelToDetailOut(*m)
@@ -316,54 +291,47 @@ func (h *MenuHandler) GetMenu(ctx *gin.Context) {
 func (h *MenuHandler) ListMenu(ctx *gin.Context) {
 	var req custmodel.ListMenuRequest
 	if err := ctx.ShouldBindQuery(&req); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定查询菜单列表参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"开始查询菜单列表",
-		zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	page, size, query := req.Query()
 	qp := database.QueryParams{
-		IsCount: true,
-		Size:    size,
-		Page:    page,
-		OrderBy: []string{"id ASC"},
-		Query:   query,
+		IsCount:   true,
+		SkipCount: req.SkipCount(),
+		Size:      size,
+		Page:      page,
+		OrderBy:   []string{"id ASC"},
+		Query:     query,
 	}
 	total, ms, err := h.svcMenu.ListMenu(ctx, qp)
 	if err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"查询菜单列表失败",
 			zap.Error(err),
 			zap.Object(database.QueryParamsKey, &qp),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, err)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"查询菜单列表成功",
-		zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	mbs := custmodel.ListMenuModelToStandardOut(ms)
 	ctx.JSON(http.StatusOK, &custmodel.PagMenuReply{
 		Code: http.StatusOK,
-		Data: commodel.NewPag(page, size, total, mbs),
+		Data: commodel.NewPag(qp, total, mbs),
 	})
 }
 
@@ -0,0 +1,268 @@
+package customer
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	commodel "gin-artweb/internal/model/common"
+	custmodel "gin-artweb/internal/model/customer"
+	custsvc "gin-artweb/internal/service/customer"
+	"gin-artweb/internal/shared/auth"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/errors"
+)
+
+const (
+	// oidcStateCookie 发起登录时写入state的Cookie, 回调时校验是同一个浏览器发起的登录
+	oidcStateCookie = "artweb_oidc_state"
+	// oidcStateCookiePath Cookie只在单点登录接口下发送
+	oidcStateCookiePath = "/api/v1/auth/oidc"
+)
+
+type OidcHandler struct {
+	log     *zap.Logger
+	svcOidc *custsvc.OidcService
+	cookie  *auth.CookieAuth
+}
+
+func NewOidcHandler(
+	log *zap.Logger,
+	svcOidc *custsvc.OidcService,
+	cookie *auth.CookieAuth,
+) *OidcHandler {
+	return &OidcHandler{
+		log:     log,
+		svcOidc: svcOidc,
+		cookie:  cookie,
+	}
+}
+
+// @Summary 查询登录方式
+// @Description 本接口用于登录页查询可用的身份提供方以及是否允许本地用户名密码登录
+// @Tags 单点登录
+// @Produce json
+// @Success 200 {object} custmodel.OidcProvidersReply "成功返回登录方式"
+// @Router /api/v1/auth/oidc/providers [get]
+func (h *OidcHandler) ListProviders(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, &custmodel.OidcProvidersReply{
+		Code: http.StatusOK,
+		Data: h.svcOidc.ListProviders(),
+	})
+}
+
+// @Summary 发起单点登录
+// @Description 本接口用于生成身份提供方的授权地址, 前端跳转到该地址完成登录
+// @Tags 单点登录
+// @Produce json
+// @Param provider query string true "身份提供方标识"
+// @Success 200 {object} custmodel.OidcLoginReply "成功返回授权地址"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 404 {object} errors.Error "身份提供方不存在"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/auth/oidc/login [get]
+func (h *OidcHandler) Login(ctx *gin.Context) {
+	h.authURL(ctx, 0)
+}
+
+// @Summary 授权回调
+// @Description 本接口用于前端将身份提供方回调地址中的参数原样转交, 校验通过后返回令牌
+// @Tags 单点登录
+// @Produce json
+// @Param request query custmodel.OidcCallbackRequest true "回调参数"
+// @Success 200 {object} custmodel.LoginReply "登录成功"
+// @Failure 400 {object} errors.Error "请求参数错误或登录状态无效"
+// @Failure 401 {object} errors.Error "单点登录失败"
+// @Failure 403 {object} errors.Error "外部身份未关联用户或没有匹配的角色"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/auth/oidc/callback [get]
+func (h *OidcHandler) Callback(ctx *gin.Context) {
+	var req custmodel.OidcCallbackRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定单点登录回调参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	// state只能使用一次, 无论成功与否都清除Cookie
+	cookieState, _ := ctx.Cookie(oidcStateCookie)
+	h.setStateCookie(ctx, "", -1)
+	if subtle.ConstantTimeCompare([]byte(cookieState), []byte(req.State)) != 1 {
+		ctxutil.Logger(ctx, h.log).Warn(
+			"单点登录回调的state与发起登录的浏览器不一致",
+		)
+		errors.RespondWithError(ctx, errors.ErrOidcStateInvalid)
+		return
+	}
+	if req.Error != "" {
+		ctxutil.Logger(ctx, h.log).Warn(
+			"身份提供方拒绝授权",
+			zap.Object(commodel.RequestModelKey, &req),
+		)
+		errors.RespondWithError(ctx, errors.ErrOidcLoginFailed.WithFields(map[string]any{
+			"error":             req.Error,
+			"error_description": req.ErrorDescription,
+		}))
+		return
+	}
+
+	accessToken, refreshToken, rErr := h.svcOidc.Callback(
+		ctx,
+		req.State,
+		req.Code,
+		ctx.ClientIP(),
+		ctx.Request.UserAgent(),
+	)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"单点登录失败",
+			zap.Error(rErr),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+	setAuthCookies(ctx, h.log, h.cookie, accessToken, refreshToken)
+
+	ctx.JSON(http.StatusOK, &custmodel.LoginReply{
+		Code: http.StatusOK,
+		Data: custmodel.LoginOut{
+			AccessToken:  accessToken,
+			RefreshToken: refreshToken,
+		},
+	})
+}
+
+// @Summary 查询个人关联的外部身份
+// @Description 本接口用于查询当前用户关联的外部身份
+// @Tags 单点登录
+// @Produce json
+// @Success 200 {object} custmodel.UserIdentitiesReply "成功返回外部身份列表"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/customer/me/oidc [get]
+// @Security ApiKeyAuth
+func (h *OidcHandler) ListMyIdentity(ctx *gin.Context) {
+	claims, rErr := ctxutil.GetUserClaims(ctx)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ms, rErr := h.svcOidc.ListUserIdentity(ctx, claims.UserID)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询个人关联的外部身份失败",
+			zap.Error(rErr),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &custmodel.UserIdentitiesReply{
+		Code: http.StatusOK,
+		Data: custmodel.ListUserIdentityToOut(ms),
+	})
+}
+
+// @Summary 关联外部身份
+// @Description 本接口用于生成授权地址, 授权回调成功后将外部身份关联到当前用户
+// @Tags 单点登录
+// @Produce json
+// @Param provider query string true "身份提供方标识"
+// @Success 200 {object} custmodel.OidcLoginReply "成功返回授权地址"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 404 {object} errors.Error "身份提供方不存在"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/customer/me/oidc/link [get]
+// @Security ApiKeyAuth
+func (h *OidcHandler) LinkMyIdentity(ctx *gin.Context) {
+	claims, rErr := ctxutil.GetUserClaims(ctx)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+	h.authURL(ctx, claims.UserID)
+}
+
+// @Summary 解除外部身份关联
+// @Description 本接口用于解除当前用户与指定身份提供方的关联
+// @Tags 单点登录
+// @Produce json
+// @Param provider path string true "身份提供方标识"
+// @Success 200 {object} commodel.MapAPIReply "解除关联成功"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 404 {object} errors.Error "未关联该身份提供方"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/customer/me/oidc/{provider} [delete]
+// @Security ApiKeyAuth
+func (h *OidcHandler) UnlinkMyIdentity(ctx *gin.Context) {
+	var uri custmodel.ProviderUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定身份提供方参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	claims, rErr := ctxutil.GetUserClaims(ctx)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	if rErr := h.svcOidc.UnlinkIdentity(ctx, claims.UserID, uri.Provider); rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"解除外部身份关联失败",
+			zap.Error(rErr),
+			zap.String("provider", uri.Provider),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(commodel.NoDataReply.Code, commodel.NoDataReply)
+}
+
+// authURL 生成授权地址并将state写入Cookie
+func (h *OidcHandler) authURL(ctx *gin.Context, linkUserID uint32) {
+	var req custmodel.OidcLoginRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定发起单点登录参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	authURL, state, rErr := h.svcOidc.AuthURL(ctx, req.Provider, linkUserID)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"生成单点登录授权地址失败",
+			zap.Error(rErr),
+			zap.Object(commodel.RequestModelKey, &req),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+	h.setStateCookie(ctx, state, 0)
+
+	ctx.JSON(http.StatusOK, &custmodel.OidcLoginReply{
+		Code: http.StatusOK,
+		Data: custmodel.OidcLoginOut{AuthURL: authURL},
+	})
+}
+
+func (h *OidcHandler) setStateCookie(ctx *gin.Context, state string, maxAge int) {
+	ctx.SetSameSite(http.SameSiteLaxMode)
+	ctx.SetCookie(oidcStateCookie, state, maxAge, oidcStateCookiePath, "", ctx.Request.TLS != nil, true)
+}
@@ -0,0 +1,58 @@
+package customer
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	custmodel "gin-artweb/internal/model/customer"
+	custsvc "gin-artweb/internal/service/customer"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/errors"
+)
+
+type PasswordHashHandler struct {
+	log          *zap.Logger
+	svcPwdHasher *custsvc.PasswordHashService
+}
+
+func NewPasswordHashHandler(
+	log *zap.Logger,
+	svcPwdHasher *custsvc.PasswordHashService,
+) *PasswordHashHandler {
+	return &PasswordHashHandler{
+		log:          log,
+		svcPwdHasher: svcPwdHasher,
+	}
+}
+
+// @Summary 统计密码哈希算法
+// @Description 本接口用于统计用户密码哈希使用的算法, 以及仍使用旧算法或旧参数的用户数量,
+// @Description 这些用户下次登录成功时按当前配置的算法和参数重新哈希
+// @Tags 用户管理
+// @Produce json
+// @Success 200 {object} custmodel.PasswordHashReportReply "成功返回密码哈希统计"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/customer/user/password/report [get]
+// @Security ApiKeyAuth
+func (h *PasswordHashHandler) Report(ctx *gin.Context) {
+	out, rErr := h.svcPwdHasher.Report(ctx)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"统计密码哈希算法失败",
+			zap.Error(rErr),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &custmodel.PasswordHashReportReply{
+		Code: http.StatusOK,
+		Data: *out,
+	})
+}
+
+func (h *PasswordHashHandler) LoadRouter(r *gin.RouterGroup) {
+	r.GET("/user/password/report", h.Report)
+}
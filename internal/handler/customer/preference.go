@@ -0,0 +1,199 @@
+package customer
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	commodel "gin-artweb/internal/model/common"
+	custmodel "gin-artweb/internal/model/customer"
+	custsvc "gin-artweb/internal/service/customer"
+	"gin-artweb/internal/shared/auth"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/errors"
+)
+
+type PreferenceHandler struct {
+	log           *zap.Logger
+	svcPreference *custsvc.PreferenceService
+}
+
+func NewPreferenceHandler(
+	log *zap.Logger,
+	svcPreference *custsvc.PreferenceService,
+) *PreferenceHandler {
+	return &PreferenceHandler{
+		log:           log,
+		svcPreference: svcPreference,
+	}
+}
+
+// @Summary 查询个人全部偏好
+// @Description 本接口用于查询当前用户保存的全部界面偏好, 按命名空间排序
+// @Tags 用户偏好
+// @Produce json
+// @Success 200 {object} custmodel.PreferencesReply "成功返回偏好列表"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/customer/me/preferences [get]
+// @Security ApiKeyAuth
+func (h *PreferenceHandler) ListMyPreference(ctx *gin.Context) {
+	claims, rErr := ctxutil.GetUserClaims(ctx)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ms, rErr := h.svcPreference.ListPreference(ctx, claims.UserID)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询用户偏好列表失败",
+			zap.Error(rErr),
+			zap.Uint32("user_id", claims.UserID),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &custmodel.PreferencesReply{
+		Code: http.StatusOK,
+		Data: custmodel.ListPreferenceToOut(ms),
+	})
+}
+
+// @Summary 查询个人偏好
+// @ID GetCustomerMePreference
+// @Description 本接口用于查询当前用户在指定命名空间下的界面偏好
+// @Tags 用户偏好
+// @Produce json
+// @Param namespace path string true "命名空间, 如theme、colony_filter、table.mds_colony"
+// @Success 200 {object} custmodel.PreferenceReply "成功返回偏好"
+// @Failure 400 {object} errors.Error "命名空间不支持"
+// @Failure 404 {object} errors.Error "偏好不存在"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/customer/me/preferences/{namespace} [get]
+// @Security ApiKeyAuth
+func (h *PreferenceHandler) GetMyPreference(ctx *gin.Context) {
+	uri, claims, ok := h.bindUri(ctx)
+	if !ok {
+		return
+	}
+
+	m, rErr := h.svcPreference.FindPreference(ctx, claims.UserID, uri.Namespace)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询用户偏好失败",
+			zap.Error(rErr),
+			zap.Uint32("user_id", claims.UserID),
+			zap.String("namespace", uri.Namespace),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &custmodel.PreferenceReply{
+		Code: http.StatusOK,
+		Data: *custmodel.PreferenceToOut(*m),
+	})
+}
+
+// @Summary 保存个人偏好
+// @Description 本接口用于保存当前用户在指定命名空间下的界面偏好, 已有时整体替换。
+// @Description 请求体为JSON对象, 按命名空间的格式校验, 不接受未定义的字段, 大小和命名空间数量受配置限制
+// @Tags 用户偏好
+// @Accept json
+// @Produce json
+// @Param namespace path string true "命名空间, 如theme、colony_filter、table.mds_colony"
+// @Param request body object true "偏好内容"
+// @Success 200 {object} custmodel.PreferenceReply "保存成功"
+// @Failure 400 {object} errors.Error "命名空间不支持"
+// @Failure 409 {object} errors.Error "命名空间数量超过上限"
+// @Failure 413 {object} errors.Error "偏好内容过大"
+// @Failure 422 {object} errors.Error "偏好内容格式错误"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/customer/me/preferences/{namespace} [put]
+// @Security ApiKeyAuth
+func (h *PreferenceHandler) SaveMyPreference(ctx *gin.Context) {
+	uri, claims, ok := h.bindUri(ctx)
+	if !ok {
+		return
+	}
+
+	raw, err := ctx.GetRawData()
+	if err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"读取用户偏好内容失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := h.svcPreference.SavePreference(ctx, claims.UserID, uri.Namespace, raw)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"保存用户偏好失败",
+			zap.Error(rErr),
+			zap.Uint32("user_id", claims.UserID),
+			zap.String("namespace", uri.Namespace),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &custmodel.PreferenceReply{
+		Code: http.StatusOK,
+		Data: *custmodel.PreferenceToOut(*m),
+	})
+}
+
+// @Summary 删除个人偏好
+// @Description 本接口用于删除当前用户在指定命名空间下的界面偏好, 恢复为默认设置
+// @Tags 用户偏好
+// @Produce json
+// @Param namespace path string true "命名空间, 如theme、colony_filter、table.mds_colony"
+// @Success 200 {object} commodel.MapAPIReply "删除成功"
+// @Failure 400 {object} errors.Error "命名空间不支持"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/customer/me/preferences/{namespace} [delete]
+// @Security ApiKeyAuth
+func (h *PreferenceHandler) DeleteMyPreference(ctx *gin.Context) {
+	uri, claims, ok := h.bindUri(ctx)
+	if !ok {
+		return
+	}
+
+	if rErr := h.svcPreference.DeletePreference(ctx, claims.UserID, uri.Namespace); rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"删除用户偏好失败",
+			zap.Error(rErr),
+			zap.Uint32("user_id", claims.UserID),
+			zap.String("namespace", uri.Namespace),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+	ctx.JSON(commodel.NoDataReply.Code, commodel.NoDataReply)
+}
+
+// bindUri 绑定命名空间参数并获取当前用户, 失败时已经写入响应
+func (h *PreferenceHandler) bindUri(ctx *gin.Context) (custmodel.PreferenceUri, *auth.UserClaims, bool) {
+	var uri custmodel.PreferenceUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定偏好命名空间参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return uri, nil, false
+	}
+
+	claims, rErr := ctxutil.GetUserClaims(ctx)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return uri, nil, false
+	}
+	return uri, claims, true
+}
@@ -0,0 +1,164 @@
+package customer
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/goccy/go-yaml"
+	"go.uber.org/zap"
+
+	commodel "gin-artweb/internal/model/common"
+	custmodel "gin-artweb/internal/model/customer"
+	custsvc "gin-artweb/internal/service/customer"
+	"gin-artweb/internal/shared/common"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/errors"
+)
+
+// rbacDocumentMaxSize 导入的权限配置文档大小上限
+const rbacDocumentMaxSize = 4 << 20
+
+type RbacHandler struct {
+	log     *zap.Logger
+	svcRbac *custsvc.RbacService
+}
+
+func NewRbacHandler(
+	logger *zap.Logger,
+	svcRbac *custsvc.RbacService,
+) *RbacHandler {
+	return &RbacHandler{
+		log:     logger,
+		svcRbac: svcRbac,
+	}
+}
+
+// @Summary 导出权限配置
+// @Description 本接口用于将角色、菜单、按钮、API、Casbin模型和策略导出为一个YAML文档，对象之间使用名称引用，可导入到其他环境
+// @Tags 权限配置
+// @Produce application/x-yaml
+// @Success 200 {file} file "成功返回YAML文档"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/customer/rbac/export [get]
+// @Security ApiKeyAuth
+func (h *RbacHandler) ExportRbac(ctx *gin.Context) {
+	ctxutil.Logger(ctx, h.log).Info(
+		"开始导出权限配置",
+	)
+
+	doc, rErr := h.svcRbac.ExportRbac(ctx)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"导出权限配置失败",
+			zap.Error(rErr),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	data, err := yaml.Marshal(doc)
+	if err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"序列化权限配置失败",
+			zap.Error(err),
+		)
+		errors.RespondWithError(ctx, errors.FromError(err))
+		return
+	}
+
+	now := time.Now()
+	filename := "rbac-" + now.Format("20060102150405") + ".yaml"
+	common.StreamFile(ctx, bytes.NewReader(data), int64(len(data)), filename, now)
+}
+
+// @Summary 导入权限配置
+// @Description 本接口用于导入导出接口生成的YAML文档，dry_run为true时只返回差异；与已有数据不一致的对象按conflict处理(fail:不导入任何数据, skip:保留已有数据, overwrite:覆盖)，文档未包含的对象保持不变
+// @Tags 权限配置
+// @Accept application/x-yaml
+// @Produce json
+// @Param dry_run query bool false "是否只比较差异"
+// @Param conflict query string false "冲突处理方式" Enums(fail, skip, overwrite)
+// @Param request body string true "YAML文档"
+// @Success 200 {object} custmodel.RbacImportReply "成功返回导入结果"
+// @Failure 400 {object} errors.Error "请求参数错误或文档无效"
+// @Failure 409 {object} errors.Error "与已有数据冲突"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/customer/rbac/import [post]
+// @Security ApiKeyAuth
+func (h *RbacHandler) ImportRbac(ctx *gin.Context) {
+	var req custmodel.ImportRbacRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定导入权限配置参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	claims, rErr := ctxutil.GetUserClaims(ctx)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"获取个人登录信息失败",
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	body := http.MaxBytesReader(ctx.Writer, ctx.Request.Body, rbacDocumentMaxSize)
+	data, err := io.ReadAll(body)
+	if err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"读取权限配置文档失败",
+			zap.Error(err),
+		)
+		errors.RespondWithError(ctx, errors.ErrRbacDocumentInvalid.WithCause(err))
+		return
+	}
+
+	doc, rErr := custsvc.ParseRbacDocument(data)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"解析权限配置文档失败",
+			zap.Error(rErr),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctxutil.Logger(ctx, h.log).Info(
+		"开始导入权限配置",
+		zap.Object(commodel.RequestModelKey, &req),
+	)
+
+	out, rErr := h.svcRbac.ImportRbac(ctx, *doc, req.DryRun, req.Conflict, claims.Username)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"导入权限配置失败",
+			zap.Error(rErr),
+			zap.Object(commodel.RequestModelKey, &req),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctxutil.Logger(ctx, h.log).Info(
+		"导入权限配置成功",
+		zap.Object(commodel.RequestModelKey, &req),
+		zap.Bool("applied", out.Applied),
+	)
+
+	ctx.JSON(http.StatusOK, &custmodel.RbacImportReply{
+		Code: http.StatusOK,
+		Data: out,
+	})
+}
+
+func (h *RbacHandler) LoadRouter(r *gin.RouterGroup) {
+	r.GET("/rbac/export", h.ExportRbac)
+	r.POST("/rbac/import", h.ImportRbac)
+}
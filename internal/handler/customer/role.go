@@ -15,17 +15,20 @@ import (
 )
 
 type RoleHandler struct {
-	log     *zap.Logger
-	svcRole *custsvc.RoleService
+	log      *zap.Logger
+	svcRole  *custsvc.RoleService
+	svcGroup *custsvc.UserGroupService
 }
 
 func NewRoleHandler(
 	logger *zap.Logger,
 	svcRole *custsvc.RoleService,
+	svcGroup *custsvc.UserGroupService,
 ) *RoleHandler {
 	return &RoleHandler{
-		log:     logger,
-		svcRole: svcRole,
+		log:      logger,
+		svcRole:  svcRole,
+		svcGroup: svcGroup,
 	}
 }
 
@@ -43,22 +46,18 @@ func NewRoleHandler(
 func (h *RoleHandler) CreateRole(ctx *gin.Context) {
 	var req custmodel.CreateOrUpdateRoleRequest
 	if err := ctx.ShouldBind(&req); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定创建角色请求参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"开始创建角色",
 		zap.Object(commodel.RequestModelKey, &req),
-		zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	m, err := h.svcRole.CreateRole(
@@ -67,27 +66,24 @@ func (h *RoleHandler) CreateRole(ctx *gin.Context) {
 		req.MenuIDs,
 		req.ButtonIDs,
 		custmodel.RoleModel{
-			Name:  req.Name,
-			Descr: req.Descr,
+			Name:      req.Name,
+			Descr:     req.Descr,
+			DataScope: req.DataScope,
 		},
 	)
 	if err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"创建角色失败",
 			zap.Error(err),
 			zap.Object(commodel.RequestModelKey, &req),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, err)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"创建角色成功",
 		zap.Uint32(commodel.RequestIDKey, m.ID),
-		zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	ctx.JSON(http.StatusCreated, &custmodel.RoleReply{
@@ -103,20 +99,21 @@ func (h *RoleHandler) CreateRole(ctx *gin.Context) {
 // @Produce json
 // @Param id path uint true "角色编号"
 // @Param request body custmodel.CreateOrUpdateRoleRequest true "更新角色请求"
+// @Param If-Match header string false "版本号, 未提供时使用请求体中的version"
 // @Success 200 {object} custmodel.RoleReply "成功返回角色信息"
 // @Failure 400 {object} errors.Error "请求参数错误"
 // @Failure 404 {object} errors.Error "角色未找到"
+// @Failure 409 {object} errors.Error "角色已被其他用户修改, data.current为当前数据"
+// @Failure 428 {object} errors.Error "缺少版本号"
 // @Failure 500 {object} errors.Error "服务器内部错误"
 // @Router /api/v1/customer/role/{id} [put]
 // @Security ApiKeyAuth
 func (h *RoleHandler) UpdateRole(ctx *gin.Context) {
 	var uri commodel.IDUri
 	if err := ctx.ShouldBindUri(&uri); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定角色ID参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
@@ -124,50 +121,57 @@ func (h *RoleHandler) UpdateRole(ctx *gin.Context) {
 	}
 	var req custmodel.CreateOrUpdateRoleRequest
 	if err := ctx.ShouldBind(&req); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定更新角色请求参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	h.log.Info(
+	version, ok := commodel.RequestVersion(ctx.GetHeader(commodel.IfMatchHeader), req.Version)
+	if !ok {
+		errors.RespondWithError(ctx, errors.ErrVersionRequired)
+		return
+	}
+
+	ctxutil.Logger(ctx, h.log).Info(
 		"开始更新角色",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
 		zap.Object(commodel.RequestModelKey, &req),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
+	data := map[string]any{
+		"name":              req.Name,
+		"descr":             req.Descr,
+		database.VersionKey: version,
+	}
+	if req.DataScope != "" {
+		data["data_scope"] = req.DataScope
+	}
+
 	m, err := h.svcRole.UpdateRoleByID(
 		ctx, uri.ID,
 		req.ApiIDs,
 		req.MenuIDs,
 		req.ButtonIDs,
-		map[string]any{
-			"name":  req.Name,
-			"descr": req.Descr,
-		},
+		data,
 	)
 	if err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"更新角色失败",
 			zap.Error(err),
 			zap.Uint32(commodel.RequestIDKey, uri.ID),
 			zap.Object(commodel.RequestModelKey, &req),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, err)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"更新角色成功",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	ctx.JSON(http.StatusOK, &custmodel.RoleReply{
@@ -191,38 +195,33 @@ func (h *RoleHandler) UpdateRole(ctx *gin.Context) {
 func (h *RoleHandler) DeleteRole(ctx *gin.Context) {
 	var uri commodel.IDUri
 	if err := ctx.ShouldBindUri(&uri); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定删除角色ID参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"开始删除角色",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	if err := h.svcRole.DeleteRoleByID(ctx, uri.ID); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"删除角色失败",
 			zap.Error(err),
 			zap.Uint32(commodel.RequestIDKey, uri.ID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, err)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"删除角色成功",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	ctx.JSON(commodel.NoDataReply.Code, commodel.NoDataReply)
@@ -243,39 +242,34 @@ func (h *RoleHandler) DeleteRole(ctx *gin.Context) {
 func (h *RoleHandler) GetRole(ctx *gin.Context) {
 	var uri commodel.IDUri
 	if err := ctx.ShouldBindUri(&uri); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定查询角色ID参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"开始查询角色详情",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	m, err := h.svcRole.FindRoleByID(ctx, []string{"Apis", "Menus", "Buttons"}, uri.ID)
 	if err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"查询角色详情失败",
 			zap.Error(err),
 			zap.Uint32(commodel.RequestIDKey, uri.ID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, err)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"查询角色详情成功",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	ctx.JSON(http.StatusOK, &custmodel.RoleReply{
@@ -298,59 +292,52 @@ func (h *RoleHandler) GetRole(ctx *gin.Context) {
 func (h *RoleHandler) ListRole(ctx *gin.Context) {
 	var req custmodel.ListRoleRequest
 	if err := ctx.ShouldBindQuery(&req); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定查询角色列表参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"开始查询角色列表",
-		zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	page, size, query := req.Query()
 	qp := database.QueryParams{
-		IsCount: true,
-		Size:    size,
-		Page:    page,
-		OrderBy: []string{"id ASC"},
-		Query:   query,
+		IsCount:   true,
+		SkipCount: req.SkipCount(),
+		Size:      size,
+		Page:      page,
+		OrderBy:   []string{"id ASC"},
+		Query:     query,
 	}
 	total, ms, err := h.svcRole.ListRole(ctx, qp)
 	if err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"查询角色列表失败",
 			zap.Error(err),
 			zap.Object(database.QueryParamsKey, &qp),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, err)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"查询角色列表成功",
-		zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	mbs := custmodel.ListRoleModelToStandardOut(ms)
 	ctx.JSON(http.StatusOK, &custmodel.PagRoleReply{
 		Code: http.StatusOK,
-		Data: commodel.NewPag(page, size, total, mbs),
+		Data: commodel.NewPag(qp, total, mbs),
 	})
 }
 
 // @Summary 获取当前用户菜单树
-// @Description 本接口用于获取当前登录用户的菜单权限树
+// @Description 本接口用于获取当前登录用户的菜单权限树, 合并直属角色和所属用户组角色的菜单
 // @Tags 角色管理
 // @Accept json
 // @Produce json
@@ -362,36 +349,34 @@ func (h *RoleHandler) ListRole(ctx *gin.Context) {
 func (h *RoleHandler) GetRoleMenuTree(ctx *gin.Context) {
 	claims, rErr := ctxutil.GetUserClaims(ctx)
 	if rErr != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"获取个人登录信息失败",
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"开始获取当前用户菜单树",
-		zap.Uint32(ctxutil.UserIDKey, claims.UserID),
-		zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
-	menuTrees, err := h.svcRole.GetRoleMenuTree(ctx, claims.RoleID)
+	roleIDs, err := h.svcGroup.EffectiveRoleIDs(ctx, claims.UserID, claims.RoleID)
 	if err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
+			"获取当前用户有效角色失败",
+			zap.Error(err),
+		)
+		errors.RespondWithError(ctx, err)
+		return
+	}
+	menuTrees, err := h.svcRole.GetRoleMenuTree(ctx, roleIDs...)
+	if err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
 			"获取当前用户菜单树失败",
 			zap.Error(err),
-			zap.Uint32(ctxutil.UserIDKey, claims.UserID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, err)
 		return
 	}
-	h.log.Info(
-		"当前用户菜单树获取成功",
-		zap.Uint32(ctxutil.UserIDKey, claims.UserID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
-	)
+	ctxutil.Logger(ctx, h.log).Info("当前用户菜单树获取成功")
 	ctx.JSON(http.StatusOK, &custmodel.RoleMenuTreeReply{
 		Code: http.StatusOK,
 		Data: &menuTrees,
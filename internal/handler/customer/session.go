@@ -0,0 +1,239 @@
+package customer
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	commodel "gin-artweb/internal/model/common"
+	custmodel "gin-artweb/internal/model/customer"
+	custsvc "gin-artweb/internal/service/customer"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/errors"
+)
+
+type SessionHandler struct {
+	log        *zap.Logger
+	svcSession *custsvc.SessionService
+}
+
+func NewSessionHandler(
+	log *zap.Logger,
+	svcSession *custsvc.SessionService,
+) *SessionHandler {
+	return &SessionHandler{
+		log:        log,
+		svcSession: svcSession,
+	}
+}
+
+// @Summary 查询个人登录会话
+// @Description 本接口用于查询当前用户未过期的登录会话, current标记当前请求所属的会话
+// @Tags 登录会话
+// @Produce json
+// @Success 200 {object} custmodel.UserSessionsReply "成功返回会话列表"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/customer/me/sessions [get]
+// @Security ApiKeyAuth
+func (h *SessionHandler) ListMySession(ctx *gin.Context) {
+	claims, rErr := ctxutil.GetUserClaims(ctx)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+	h.listSession(ctx, claims.UserID, claims.SessionID, true)
+}
+
+// @Summary 终止个人登录会话
+// @ID DeleteCustomerMeSession
+// @Description 本接口用于终止当前用户的指定会话, 会话下的令牌立即失效
+// @Tags 登录会话
+// @Produce json
+// @Param session_id path string true "会话ID"
+// @Success 200 {object} commodel.MapAPIReply "终止成功"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 404 {object} errors.Error "会话不存在"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/customer/me/sessions/{session_id} [delete]
+// @Security ApiKeyAuth
+func (h *SessionHandler) DeleteMySession(ctx *gin.Context) {
+	var uri custmodel.SessionUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定会话ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	claims, rErr := ctxutil.GetUserClaims(ctx)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+	h.deleteSession(ctx, claims.UserID, uri.SessionID)
+}
+
+// @Summary 终止个人其他登录会话
+// @Description 本接口用于终止当前用户除当前会话以外的所有会话
+// @Tags 登录会话
+// @Produce json
+// @Success 200 {object} custmodel.DeleteSessionReply "返回终止的会话数量"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/customer/me/sessions [delete]
+// @Security ApiKeyAuth
+func (h *SessionHandler) DeleteMyOtherSession(ctx *gin.Context) {
+	claims, rErr := ctxutil.GetUserClaims(ctx)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+	h.deleteOtherSession(ctx, claims.UserID, claims.SessionID)
+}
+
+// @Summary 查询用户登录会话
+// @Description 本接口用于管理员查询指定用户未过期的登录会话
+// @Tags 登录会话
+// @Produce json
+// @Param id path uint true "用户编号"
+// @Success 200 {object} custmodel.UserSessionsReply "成功返回会话列表"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/customer/user/{id}/sessions [get]
+// @Security ApiKeyAuth
+func (h *SessionHandler) ListUserSession(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定用户ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	claims, rErr := ctxutil.GetUserClaims(ctx)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+	h.listSession(ctx, uri.ID, claims.SessionID, uri.ID == claims.UserID || ctxutil.CanViewSensitive(ctx))
+}
+
+// @Summary 终止用户登录会话
+// @ID DeleteCustomerUserSession
+// @Description 本接口用于管理员终止指定用户的指定会话
+// @Tags 登录会话
+// @Produce json
+// @Param id path uint true "用户编号"
+// @Param session_id path string true "会话ID"
+// @Success 200 {object} commodel.MapAPIReply "终止成功"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 404 {object} errors.Error "会话不存在"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/customer/user/{id}/sessions/{session_id} [delete]
+// @Security ApiKeyAuth
+func (h *SessionHandler) DeleteUserSession(ctx *gin.Context) {
+	var uri custmodel.UserSessionUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定用户会话参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+	h.deleteSession(ctx, uri.ID, uri.SessionID)
+}
+
+// @Summary 终止用户全部登录会话
+// @Description 本接口用于管理员终止指定用户的全部会话, 管理员自己的当前会话除外
+// @Tags 登录会话
+// @Produce json
+// @Param id path uint true "用户编号"
+// @Success 200 {object} custmodel.DeleteSessionReply "返回终止的会话数量"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/customer/user/{id}/sessions [delete]
+// @Security ApiKeyAuth
+func (h *SessionHandler) DeleteUserAllSession(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定用户ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	claims, rErr := ctxutil.GetUserClaims(ctx)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+	h.deleteOtherSession(ctx, uri.ID, claims.SessionID)
+}
+
+// listSession 查询用户的会话, reveal为false时掩码会话的IP地址
+func (h *SessionHandler) listSession(ctx *gin.Context, userID uint32, currentSessionID string, reveal bool) {
+	ms, rErr := h.svcSession.ListUserSession(ctx, userID)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询用户会话失败",
+			zap.Error(rErr),
+			zap.Uint32("user_id", userID),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &custmodel.UserSessionsReply{
+		Code: http.StatusOK,
+		Data: custmodel.ListUserSessionToOut(ms, currentSessionID, reveal),
+	})
+}
+
+func (h *SessionHandler) deleteSession(ctx *gin.Context, userID uint32, sessionID string) {
+	if rErr := h.svcSession.DeleteUserSession(ctx, userID, sessionID); rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"终止用户会话失败",
+			zap.Error(rErr),
+			zap.Uint32("user_id", userID),
+			zap.String("session_id", sessionID),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+	ctx.JSON(commodel.NoDataReply.Code, commodel.NoDataReply)
+}
+
+func (h *SessionHandler) deleteOtherSession(ctx *gin.Context, userID uint32, keepSessionID string) {
+	deleted, rErr := h.svcSession.DeleteOtherUserSession(ctx, userID, keepSessionID)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"终止用户的其他会话失败",
+			zap.Error(rErr),
+			zap.Uint32("user_id", userID),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &custmodel.DeleteSessionReply{
+		Code: http.StatusOK,
+		Data: custmodel.DeleteSessionOut{Deleted: deleted},
+	})
+}
+
+func (h *SessionHandler) LoadRouter(r *gin.RouterGroup) {
+	r.GET("/user/:id/sessions", h.ListUserSession)
+	r.DELETE("/user/:id/sessions", h.DeleteUserAllSession)
+	r.DELETE("/user/:id/sessions/:session_id", h.DeleteUserSession)
+}
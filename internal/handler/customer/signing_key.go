@@ -0,0 +1,142 @@
+package customer
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	commodel "gin-artweb/internal/model/common"
+	custmodel "gin-artweb/internal/model/customer"
+	custsvc "gin-artweb/internal/service/customer"
+	"gin-artweb/internal/shared/auth"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+)
+
+type SigningKeyHandler struct {
+	log    *zap.Logger
+	svcKey *custsvc.SigningKeyService
+}
+
+func NewSigningKeyHandler(
+	logger *zap.Logger,
+	svcKey *custsvc.SigningKeyService,
+) *SigningKeyHandler {
+	return &SigningKeyHandler{
+		log:    logger,
+		svcKey: svcKey,
+	}
+}
+
+// @Summary 查询签名密钥
+// @Description 本接口用于分页查询JWT签名密钥, 不返回私钥和HMAC密钥
+// @Tags 签名密钥管理
+// @Accept json
+// @Produce json
+// @Param request query custmodel.ListSigningKeyRequest false "查询参数"
+// @Success 200 {object} custmodel.PagSigningKeyReply "成功返回签名密钥列表"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/customer/signing-key [get]
+// @Security ApiKeyAuth
+func (h *SigningKeyHandler) ListSigningKey(ctx *gin.Context) {
+	var req custmodel.ListSigningKeyRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定查询签名密钥参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	page, size, query := req.Query()
+	qp := database.QueryParams{
+		IsCount:   true,
+		SkipCount: req.SkipCount(),
+		Size:      size,
+		Page:      page,
+		OrderBy:   []string{"id DESC"},
+		Query:     query,
+	}
+	total, ms, rErr := h.svcKey.ListSigningKey(ctx, qp)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询签名密钥失败",
+			zap.Error(rErr),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	mbs := custmodel.ListSigningKeyToOut(ms)
+	ctx.JSON(http.StatusOK, &custmodel.PagSigningKeyReply{
+		Code: http.StatusOK,
+		Data: commodel.NewPag(qp, total, mbs),
+	})
+}
+
+// @Summary 轮换签名密钥
+// @Description 本接口用于生成新的JWT签名密钥, 旧密钥保留到令牌有效期结束, 密钥泄露时可立即停用旧密钥
+// @Tags 签名密钥管理
+// @Accept json
+// @Produce json
+// @Param request body custmodel.RotateSigningKeyRequest true "轮换签名密钥请求"
+// @Success 200 {object} custmodel.SigningKeyRotateReply "成功返回新生成的密钥"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/customer/signing-key/rotate [post]
+// @Security ApiKeyAuth
+func (h *SigningKeyHandler) RotateSigningKey(ctx *gin.Context) {
+	var req custmodel.RotateSigningKeyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定轮换签名密钥参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	claims, rErr := ctxutil.GetUserClaims(ctx)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"获取个人登录信息失败",
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	tokenTypes := []auth.TokenType{auth.TokenTypeAccess, auth.TokenTypeRefresh}
+	if req.TokenType != "" {
+		tokenTypes = []auth.TokenType{auth.TokenType(req.TokenType)}
+	}
+	ms, rErr := h.svcKey.RotateKeys(ctx, tokenTypes, req.RevokePrevious, claims.Username)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"轮换签名密钥失败",
+			zap.Error(rErr),
+			zap.String("token_type", req.TokenType),
+			zap.Bool("revoke_previous", req.RevokePrevious),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &custmodel.SigningKeyRotateReply{
+		Code: http.StatusOK,
+		Data: custmodel.SigningKeyRotateOut{
+			Keys: *custmodel.ListSigningKeyToOut(&ms),
+		},
+	})
+}
+
+func (h *SigningKeyHandler) LoadRouter(r *gin.RouterGroup) {
+	r.GET("/signing-key", h.ListSigningKey)
+	r.POST("/signing-key/rotate", h.RotateSigningKey)
+}
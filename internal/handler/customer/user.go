@@ -1,6 +1,7 @@
 package customer
 
 import (
+	"io"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -9,6 +10,8 @@ import (
 	commodel "gin-artweb/internal/model/common"
 	custmodel "gin-artweb/internal/model/customer"
 	custsvc "gin-artweb/internal/service/customer"
+	"gin-artweb/internal/shared/auth"
+	"gin-artweb/internal/shared/common"
 	"gin-artweb/internal/shared/ctxutil"
 	"gin-artweb/internal/shared/database"
 	"gin-artweb/internal/shared/errors"
@@ -17,15 +20,21 @@ import (
 type UserHandler struct {
 	log     *zap.Logger
 	svcUser *custsvc.UserService
+	svcDept *custsvc.DepartmentService
+	cookie  *auth.CookieAuth
 }
 
 func NewUserHandler(
 	log *zap.Logger,
 	svcUser *custsvc.UserService,
+	svcDept *custsvc.DepartmentService,
+	cookie *auth.CookieAuth,
 ) *UserHandler {
 	return &UserHandler{
 		log:     log,
 		svcUser: svcUser,
+		svcDept: svcDept,
+		cookie:  cookie,
 	}
 }
 
@@ -43,48 +52,45 @@ func NewUserHandler(
 func (h *UserHandler) CreateUser(ctx *gin.Context) {
 	var req custmodel.CreateUserRequest
 	if err := ctx.ShouldBind(&req); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定创建用户请求参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"开始创建用户",
 		zap.Object(commodel.RequestModelKey, &req),
-		zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
+	if req.DepartmentID != nil && *req.DepartmentID == 0 {
+		req.DepartmentID = nil
+	}
+
 	m, err := h.svcUser.CreateUser(ctx, custmodel.UserModel{
-		Username: req.Username,
-		Password: req.Password,
-		IsActive: req.IsActive,
-		IsStaff:  req.IsStaff,
-		RoleID:   req.RoleID,
+		Username:     req.Username,
+		Password:     req.Password,
+		IsActive:     req.IsActive,
+		IsStaff:      req.IsStaff,
+		RoleID:       req.RoleID,
+		DepartmentID: req.DepartmentID,
 	})
 	if err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"创建用户失败",
 			zap.Error(err),
 			zap.Object(commodel.RequestModelKey, &req),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, err)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"创建用户成功",
 		zap.Uint32(commodel.RequestIDKey, m.ID),
-		zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	ctx.JSON(http.StatusCreated, &custmodel.UserReply{
@@ -109,11 +115,9 @@ func (h *UserHandler) CreateUser(ctx *gin.Context) {
 func (h *UserHandler) UpdateUser(ctx *gin.Context) {
 	var uri commodel.IDUri
 	if err := ctx.ShouldBindUri(&uri); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定用户ID参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
@@ -122,22 +126,19 @@ func (h *UserHandler) UpdateUser(ctx *gin.Context) {
 
 	var req custmodel.UpdateUserRequest
 	if err := ctx.ShouldBind(&req); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定更新用户请求参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"开始更新用户",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
 		zap.Object(commodel.RequestModelKey, &req),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	if err := h.svcUser.UpdateUserByID(ctx, uri.ID, map[string]any{
@@ -146,30 +147,27 @@ func (h *UserHandler) UpdateUser(ctx *gin.Context) {
 		"is_staff":  req.IsStaff,
 		"role_id":   req.RoleID,
 	}); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"更新用户失败",
 			zap.Error(err),
 			zap.Uint32(commodel.RequestIDKey, uri.ID),
 			zap.Object(commodel.RequestModelKey, &req),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, err)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"更新用户成功",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
-	m, err := h.svcUser.FindUserByID(ctx, []string{"Role"}, uri.ID)
+	m, err := h.svcUser.FindUserByID(ctx, []string{"Role", "Department"}, uri.ID)
 	if err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"查询更新后的用户信息失败",
 			zap.Error(err),
 			zap.Uint32(commodel.RequestIDKey, uri.ID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, err)
 		return
@@ -196,38 +194,33 @@ func (h *UserHandler) UpdateUser(ctx *gin.Context) {
 func (h *UserHandler) DeleteUser(ctx *gin.Context) {
 	var uri commodel.IDUri
 	if err := ctx.ShouldBindUri(&uri); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定删除用户ID参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"开始删除用户",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	if err := h.svcUser.DeleteUserByID(ctx, uri.ID); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"删除用户失败",
 			zap.Error(err),
 			zap.Uint32(commodel.RequestIDKey, uri.ID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, err)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"删除用户成功",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	ctx.JSON(commodel.NoDataReply.Code, commodel.NoDataReply)
@@ -248,39 +241,34 @@ func (h *UserHandler) DeleteUser(ctx *gin.Context) {
 func (h *UserHandler) GetUser(ctx *gin.Context) {
 	var uri commodel.IDUri
 	if err := ctx.ShouldBindUri(&uri); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定查询用户ID参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"开始查询用户详情",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
-	m, err := h.svcUser.FindUserByID(ctx, []string{"Role"}, uri.ID)
+	m, err := h.svcUser.FindUserByID(ctx, []string{"Role", "Department"}, uri.ID)
 	if err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"查询用户详情失败",
 			zap.Error(err),
 			zap.Uint32(commodel.RequestIDKey, uri.ID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, err)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"查询用户详情成功",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	ctx.JSON(http.StatusOK, &custmodel.UserReply{
@@ -290,68 +278,86 @@ func (h *UserHandler) GetUser(ctx *gin.Context) {
 }
 
 // @Summary 查询用户列表
-// @Description 本接口用于查询用户列表
+// @Description 本接口用于查询用户列表, 支持按部门筛选(include_sub包含下级部门), 非工作人员只能查看角色数据范围内的用户
 // @Tags 用户管理
 // @Accept json
 // @Produce json
 // @Param request query custmodel.ListUserRequest false "查询参数"
 // @Success 200 {object} custmodel.PagUserReply "成功返回用户列表"
 // @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 404 {object} errors.Error "部门未找到"
 // @Failure 500 {object} errors.Error "服务器内部错误"
 // @Router /api/v1/customer/user [get]
 // @Security ApiKeyAuth
 func (h *UserHandler) ListUser(ctx *gin.Context) {
 	var req custmodel.ListUserRequest
 	if err := ctx.ShouldBindQuery(&req); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定查询用户列表参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"开始查询用户列表",
-		zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	page, size, query := req.Query()
+	if req.DepartmentID != 0 && req.IncludeSub {
+		ids, rErr := h.svcDept.SubtreeIDs(ctx, req.DepartmentID)
+		if rErr != nil {
+			errors.RespondWithError(ctx, rErr)
+			return
+		}
+		query["department_id IN ?"] = ids
+	}
+
+	// 非工作人员只能查看角色数据范围内的用户
+	claims, rErr := ctxutil.GetUserClaims(ctx)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+	scope, rErr := h.svcDept.UserDataScope(ctx, claims)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+	for k, v := range scope {
+		query[k] = v
+	}
+
 	qp := database.QueryParams{
-		IsCount:  true,
-		Size:     size,
-		Page:     page,
-		OrderBy:  []string{"id ASC"},
-		Query:    query,
-		Preloads: []string{"Role"},
+		IsCount:   true,
+		SkipCount: req.SkipCount(),
+		Size:      size,
+		Page:      page,
+		OrderBy:   []string{"id ASC"},
+		Query:     query,
+		Preloads:  []string{"Role", "Department"},
 	}
 	total, ms, err := h.svcUser.ListUser(ctx, qp)
 	if err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"查询用户列表失败",
 			zap.Error(err),
 			zap.Object(database.QueryParamsKey, &qp),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, err)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"查询用户列表成功",
-		zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	mbs := custmodel.ListUserModelToDetailOut(ms)
 	ctx.JSON(http.StatusOK, &custmodel.PagUserReply{
 		Code: http.StatusOK,
-		Data: commodel.NewPag(page, size, total, mbs),
+		Data: commodel.NewPag(qp, total, mbs),
 	})
 }
 
@@ -371,11 +377,9 @@ func (h *UserHandler) ListUser(ctx *gin.Context) {
 func (h *UserHandler) ResetPassword(ctx *gin.Context) {
 	var uri commodel.IDUri
 	if err := ctx.ShouldBindUri(&uri); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定查询用户ID参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
@@ -383,38 +387,33 @@ func (h *UserHandler) ResetPassword(ctx *gin.Context) {
 	}
 	var req custmodel.ResetPasswordRequest
 	if err := ctx.ShouldBind(&req); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定更新重置用户密码参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"开始重置用户密码",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	if err := h.svcUser.UpdateUserByID(ctx, uri.ID, map[string]any{
 		"password": req.NewPassword,
 	}); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"重置用户密码失败",
 			zap.Uint32(commodel.RequestIDKey, uri.ID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, err)
 		return
 	}
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"重置用户密码成功",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	ctx.JSON(commodel.NoDataReply.Code, commodel.NoDataReply)
 }
@@ -434,11 +433,9 @@ func (h *UserHandler) ResetPassword(ctx *gin.Context) {
 func (h *UserHandler) PatchPassword(ctx *gin.Context) {
 	var req custmodel.PatchPasswordRequest
 	if err := ctx.ShouldBind(&req); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定更新个人密码参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
@@ -447,35 +444,25 @@ func (h *UserHandler) PatchPassword(ctx *gin.Context) {
 
 	claims, rErr := ctxutil.GetUserClaims(ctx)
 	if rErr != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"获取个人登录信息失败",
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
 	if rErr = h.svcUser.PatchPassword(ctx, claims.UserID, req.OldPassword, req.NewPassword); rErr != nil {
-		h.log.Error(
-			"修改用户密码失败",
-			zap.Uint32(ctxutil.UserIDKey, claims.UserID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
-		)
+		ctxutil.Logger(ctx, h.log).Error("修改用户密码失败")
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	h.log.Info(
-		"修改用户密码成功",
-		zap.Uint32(ctxutil.UserIDKey, claims.UserID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
-	)
+	ctxutil.Logger(ctx, h.log).Info("修改用户密码成功")
 	ctx.JSON(commodel.NoDataReply.Code, commodel.NoDataReply)
 }
 
 // @Summary 登陆接口
-// @Description 本接口用于登陆
+// @Description 本接口用于登陆, 开启Cookie认证时令牌同时写入HttpOnly Cookie
 // @Tags 用户管理
 // @Accept json
 // @Produce json
@@ -488,22 +475,18 @@ func (h *UserHandler) PatchPassword(ctx *gin.Context) {
 func (h *UserHandler) Login(ctx *gin.Context) {
 	var req custmodel.LoginRequest
 	if err := ctx.ShouldBind(&req); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定用户登录参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"开始用户登录验证",
 		zap.String("username", req.Username),
-		zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	accessToken, refreshToken, rErr := h.svcUser.Login(
@@ -512,26 +495,25 @@ func (h *UserHandler) Login(ctx *gin.Context) {
 		req.Password,
 		ctx.ClientIP(),
 		ctx.Request.UserAgent(),
+		req.CaptchaID,
+		req.CaptchaAnswer,
 	)
 
 	if rErr != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"用户登录验证失败",
 			zap.Error(rErr),
 			zap.String("username", req.Username),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"用户登录成功",
 		zap.String("username", req.Username),
-		zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
+	setAuthCookies(ctx, h.log, h.cookie, accessToken, refreshToken)
 
 	ctx.JSON(http.StatusOK, &custmodel.LoginReply{
 		Code: http.StatusOK,
@@ -543,7 +525,7 @@ func (h *UserHandler) Login(ctx *gin.Context) {
 }
 
 // @Summary 刷新令牌接口
-// @Description 本接口用于刷新令牌
+// @Description 本接口用于刷新令牌, 开启Cookie认证时请求参数可以为空, 从Cookie中读取刷新令牌
 // @Tags 用户管理
 // @Accept json
 // @Produce json
@@ -555,28 +537,33 @@ func (h *UserHandler) Login(ctx *gin.Context) {
 // @Router /api/v1/refresh/token [post]
 func (h *UserHandler) RefreshToken(ctx *gin.Context) {
 	var req custmodel.RefreshTokenRequest
-	if err := ctx.ShouldBind(&req); err != nil {
-		h.log.Error(
+	// Cookie认证时请求体可以为空
+	if err := ctx.ShouldBind(&req); err != nil && (h.cookie == nil || err != io.EOF) {
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定刷新令牌参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
-	accessToken, refreshToken, rErr := h.svcUser.RefreshTokens(ctx, req.RefreshToken)
+	if req.RefreshToken == "" && h.cookie != nil {
+		req.RefreshToken = auth.CookieValue(ctx.Request, auth.RefreshCookieName)
+	}
+	if req.RefreshToken == "" {
+		errors.RespondWithError(ctx, errors.ErrValidationFailed.WithField("refresh_token", "不能为空"))
+		return
+	}
+	accessToken, refreshToken, rErr := h.svcUser.RefreshTokens(ctx, req.RefreshToken, ctx.ClientIP(), ctx.Request.UserAgent())
 	if rErr != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"刷新令牌失败",
 			zap.Error(rErr),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
+	setAuthCookies(ctx, h.log, h.cookie, accessToken, refreshToken)
 	ctx.JSON(http.StatusOK, &custmodel.LoginReply{
 		Code: http.StatusOK,
 		Data: custmodel.LoginOut{
@@ -586,8 +573,76 @@ func (h *UserHandler) RefreshToken(ctx *gin.Context) {
 	})
 }
 
+// @Summary 退出登录接口
+// @Description 本接口用于退出登录, 终止刷新令牌或访问令牌所属的会话, 会话的令牌立即失效; 开启Cookie认证时请求参数可以为空, 从Cookie中读取令牌, 并删除令牌和CSRF令牌Cookie
+// @Tags 用户管理
+// @Accept json
+// @Produce json
+// @Param request body custmodel.RefreshTokenRequest false "刷新令牌请求参数"
+// @Success 200 {object} commodel.MapAPIReply "退出登录成功"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/logout [post]
+func (h *UserHandler) Logout(ctx *gin.Context) {
+	var req custmodel.RefreshTokenRequest
+	// 请求体可以为空, 只使用访问令牌确定会话
+	if err := ctx.ShouldBind(&req); err != nil && err != io.EOF {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定退出登录参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+	accessToken := ctx.GetHeader("Authorization")
+	if h.cookie != nil {
+		if req.RefreshToken == "" {
+			req.RefreshToken = auth.CookieValue(ctx.Request, auth.RefreshCookieName)
+		}
+		if accessToken == "" {
+			accessToken = auth.CookieValue(ctx.Request, auth.AccessCookieName)
+		}
+	}
+	if rErr := h.svcUser.Logout(ctx, req.RefreshToken, accessToken); rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"退出登录失败",
+			zap.Error(rErr),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+	if h.cookie != nil {
+		h.cookie.Clear(ctx.Writer)
+	}
+	ctx.JSON(commodel.NoDataReply.Code, commodel.NoDataReply)
+}
+
+// setAuthCookies 开启Cookie认证时将令牌写入Cookie, 写入失败时只记录日志, 客户端仍可使用响应中的令牌
+func setAuthCookies(ctx *gin.Context, log *zap.Logger, cookie *auth.CookieAuth, accessToken, refreshToken string) {
+	if cookie == nil {
+		return
+	}
+	if err := cookie.SetTokens(ctx.Writer, accessToken, refreshToken); err != nil {
+		ctxutil.Logger(ctx, log).Error(
+			"写入令牌Cookie失败",
+			zap.Error(err),
+		)
+	}
+}
+
+// loginRecordExportColumns 用户登录记录列表导出的列
+var loginRecordExportColumns = []common.ExportColumn[custmodel.LoginRecordStandardOut]{
+	{Key: "id", Header: "ID", Value: func(o custmodel.LoginRecordStandardOut) any { return o.ID }},
+	{Key: "username", Header: "用户名", Value: func(o custmodel.LoginRecordStandardOut) any { return o.Username }},
+	{Key: "login_at", Header: "登录时间", Value: func(o custmodel.LoginRecordStandardOut) any { return o.LoginAt }},
+	{Key: "ip_address", Header: "IP地址", Value: func(o custmodel.LoginRecordStandardOut) any { return o.IPAddress }},
+	{Key: "user_agent", Header: "客户端信息", Value: func(o custmodel.LoginRecordStandardOut) any { return o.UserAgent }},
+	{Key: "is_active", Header: "是否登录成功", Value: func(o custmodel.LoginRecordStandardOut) any { return o.Status }},
+}
+
 // @Summary 查询用户的登录记录列表
-// @Description 本接口用于查询用户登录记录列表
+// @Description 本接口用于查询用户登录记录列表, 指定export参数时按columns选择的列导出为csv或xlsx文件
 // @Tags 用户管理
 // @Accept json
 // @Produce json
@@ -600,54 +655,69 @@ func (h *UserHandler) RefreshToken(ctx *gin.Context) {
 func (h *UserHandler) ListLoginRecord(ctx *gin.Context) {
 	var req custmodel.ListLoginRecordRequest
 	if err := ctx.ShouldBindQuery(&req); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定查询用户登录记录列表参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"开始查询用户登录记录列表",
-		zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	page, size, query := req.Query()
 	qp := database.QueryParams{
-		IsCount: true,
-		Size:    size,
-		Page:    page,
-		OrderBy: []string{"id DESC"},
-		Query:   query,
+		IsCount:   true,
+		SkipCount: req.SkipCount(),
+		Size:      size,
+		Page:      page,
+		OrderBy:   []string{"id DESC"},
+		Query:     query,
+	}
+
+	if req.Export != "" {
+		rErr := common.ExportList(ctx, h.log, "登录记录", req.Export, req.Columns, loginRecordExportColumns,
+			func(page, size int) ([]custmodel.LoginRecordStandardOut, *errors.Error) {
+				qp.Page, qp.Size, qp.IsCount = page, size, false
+				_, ms, rErr := h.svcUser.ListLoginRecord(ctx, qp)
+				if rErr != nil {
+					return nil, rErr
+				}
+				return *custmodel.ListLoginRecordModelToStandardOut(ms, ctxutil.CanViewSensitive(ctx)), nil
+			},
+		)
+		if rErr != nil {
+			ctxutil.Logger(ctx, h.log).Error(
+				"导出用户登录记录列表失败",
+				zap.Error(rErr),
+				zap.Object(database.QueryParamsKey, &qp),
+			)
+			errors.RespondWithError(ctx, rErr)
+		}
+		return
 	}
 	total, ms, rErr := h.svcUser.ListLoginRecord(ctx, qp)
 	if rErr != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"查询用户登录记录列表失败",
 			zap.Error(rErr),
 			zap.Object(database.QueryParamsKey, &qp),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"查询用户登录记录列表成功",
-		zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
-	mbs := custmodel.ListLoginRecordModelToStandardOut(ms)
+	mbs := custmodel.ListLoginRecordModelToStandardOut(ms, ctxutil.CanViewSensitive(ctx))
 	ctx.JSON(http.StatusOK, &custmodel.PagLoginRecordReply{
 		Code: http.StatusOK,
-		Data: commodel.NewPag(page, size, total, mbs),
+		Data: commodel.NewPag(qp, total, mbs),
 	})
 }
 
@@ -666,11 +736,9 @@ func (h *UserHandler) ListLoginRecord(ctx *gin.Context) {
 func (h *UserHandler) ListMeLoginRecord(ctx *gin.Context) {
 	var req custmodel.ListLoginRecordRequest
 	if err := ctx.ShouldBindQuery(&req); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定查询个人登录记录列表参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
@@ -679,59 +747,63 @@ func (h *UserHandler) ListMeLoginRecord(ctx *gin.Context) {
 
 	claims, rErr := ctxutil.GetUserClaims(ctx)
 	if rErr != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"获取个人登录信息失败",
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 	req.Username = claims.Subject
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"开始查询个人登录记录列表",
-		zap.Uint32(ctxutil.UserIDKey, claims.UserID),
-		zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	page, size, query := req.Query()
 	qp := database.QueryParams{
-		IsCount: true,
-		Size:    size,
-		Page:    page,
-		OrderBy: []string{"id DESC"},
-		Query:   query,
+		IsCount:   true,
+		SkipCount: req.SkipCount(),
+		Size:      size,
+		Page:      page,
+		OrderBy:   []string{"id DESC"},
+		Query:     query,
 	}
 	total, ms, err := h.svcUser.ListLoginRecord(ctx, qp)
 	if err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"查询个人登录记录列表失败",
 			zap.Error(err),
-			zap.Uint32(ctxutil.UserIDKey, claims.UserID),
 			zap.Object(database.QueryParamsKey, &qp),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, err)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"查询个人登录记录列表成功",
-		zap.Uint32(ctxutil.UserIDKey, claims.UserID),
-		zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
-	mbs := custmodel.ListLoginRecordModelToStandardOut(ms)
+	// 个人的登录记录不需要掩码
+	mbs := custmodel.ListLoginRecordModelToStandardOut(ms, true)
 	ctx.JSON(http.StatusOK, &custmodel.PagLoginRecordReply{
 		Code: http.StatusOK,
-		Data: commodel.NewPag(page, size, total, mbs),
+		Data: commodel.NewPag(qp, total, mbs),
 	})
 }
 
+// @Summary 校验查看敏感字段的权限
+// @Description 本接口用于校验当前用户是否可以查看敏感字段明文, 授予此接口权限的角色在查询结果中看到完整的IP地址、主机用户名等敏感字段
+// @Tags 用户管理
+// @Produce json
+// @Success 200 {object} commodel.MapAPIReply "拥有查看敏感字段的权限"
+// @Failure 401 {object} errors.Error "未授权访问"
+// @Failure 403 {object} errors.Error "没有查看敏感字段的权限"
+// @Router /api/v1/customer/sensitive/reveal [get]
+// @Security ApiKeyAuth
+func (h *UserHandler) CheckSensitive(ctx *gin.Context) {
+	ctx.JSON(commodel.NoDataReply.Code, commodel.NoDataReply)
+}
+
 func (h *UserHandler) LoadRouter(r *gin.RouterGroup) {
 	r.POST("/user", h.CreateUser)
 	r.PUT("/user/:id", h.UpdateUser)
@@ -741,4 +813,6 @@ func (h *UserHandler) LoadRouter(r *gin.RouterGroup) {
 	r.PATCH("/user/password/:id", h.ResetPassword)
 	r.GET("/user/record/login", h.ListLoginRecord)
 	r.GET("/me/record/login", h.ListMeLoginRecord)
+	// 路径与auth.SensitiveObj一致, 此接口的权限决定查询结果是否掩码敏感字段
+	r.GET("/sensitive"+commodel.RevealPathSuffix, h.CheckSensitive)
 }
@@ -0,0 +1,427 @@
+package customer
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	commodel "gin-artweb/internal/model/common"
+	custmodel "gin-artweb/internal/model/customer"
+	custsvc "gin-artweb/internal/service/customer"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+)
+
+type UserGroupHandler struct {
+	log      *zap.Logger
+	svcGroup *custsvc.UserGroupService
+}
+
+func NewUserGroupHandler(
+	log *zap.Logger,
+	svcGroup *custsvc.UserGroupService,
+) *UserGroupHandler {
+	return &UserGroupHandler{
+		log:      log,
+		svcGroup: svcGroup,
+	}
+}
+
+// @Summary 新增用户组
+// @Description 本接口用于新增用户组, 组内用户继承用户组关联的全部角色
+// @Tags 用户组管理
+// @Accept json
+// @Produce json
+// @Param request body custmodel.CreateOrUpdateUserGroupRequest true "创建用户组请求"
+// @Success 201 {object} custmodel.UserGroupReply "成功返回用户组信息"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/customer/group [post]
+// @Security ApiKeyAuth
+func (h *UserGroupHandler) CreateUserGroup(ctx *gin.Context) {
+	var req custmodel.CreateOrUpdateUserGroupRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定创建用户组请求参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctxutil.Logger(ctx, h.log).Info(
+		"开始创建用户组",
+		zap.Object(commodel.RequestModelKey, &req),
+	)
+
+	m, err := h.svcGroup.CreateUserGroup(ctx, req.RoleIDs, req.UserIDs, custmodel.UserGroupModel{
+		Name:  req.Name,
+		Descr: req.Descr,
+	})
+	if err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"创建用户组失败",
+			zap.Error(err),
+			zap.Object(commodel.RequestModelKey, &req),
+		)
+		errors.RespondWithError(ctx, err)
+		return
+	}
+
+	ctxutil.Logger(ctx, h.log).Info(
+		"创建用户组成功",
+		zap.Uint32(commodel.RequestIDKey, m.ID),
+	)
+
+	ctx.JSON(http.StatusCreated, &custmodel.UserGroupReply{
+		Code: http.StatusCreated,
+		Data: custmodel.UserGroupModelToDetailOut(*m),
+	})
+}
+
+// @Summary 更新用户组
+// @Description 本接口用于更新指定ID的用户组, 角色和成员按请求整体替换
+// @Tags 用户组管理
+// @Accept json
+// @Produce json
+// @Param id path uint true "用户组编号"
+// @Param request body custmodel.CreateOrUpdateUserGroupRequest true "更新用户组请求"
+// @Success 200 {object} custmodel.UserGroupReply "成功返回用户组信息"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 404 {object} errors.Error "用户组未找到"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/customer/group/{id} [put]
+// @Security ApiKeyAuth
+func (h *UserGroupHandler) UpdateUserGroup(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定用户组ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	var req custmodel.CreateOrUpdateUserGroupRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定更新用户组请求参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctxutil.Logger(ctx, h.log).Info(
+		"开始更新用户组",
+		zap.Uint32(commodel.RequestIDKey, uri.ID),
+		zap.Object(commodel.RequestModelKey, &req),
+	)
+
+	m, err := h.svcGroup.UpdateUserGroupByID(ctx, uri.ID, req.RoleIDs, req.UserIDs, map[string]any{
+		"name":  req.Name,
+		"descr": req.Descr,
+	})
+	if err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"更新用户组失败",
+			zap.Error(err),
+			zap.Uint32(commodel.RequestIDKey, uri.ID),
+			zap.Object(commodel.RequestModelKey, &req),
+		)
+		errors.RespondWithError(ctx, err)
+		return
+	}
+
+	ctxutil.Logger(ctx, h.log).Info(
+		"更新用户组成功",
+		zap.Uint32(commodel.RequestIDKey, uri.ID),
+	)
+
+	ctx.JSON(http.StatusOK, &custmodel.UserGroupReply{
+		Code: http.StatusOK,
+		Data: custmodel.UserGroupModelToDetailOut(*m),
+	})
+}
+
+// @Summary 添加用户组成员
+// @Description 本接口用于将用户加入指定的用户组, 已在组内的用户保持不变
+// @Tags 用户组管理
+// @Accept json
+// @Produce json
+// @Param id path uint true "用户组编号"
+// @Param request body custmodel.UserGroupMemberRequest true "用户组成员请求"
+// @Success 200 {object} custmodel.UserGroupReply "成功返回用户组信息"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 404 {object} errors.Error "用户组未找到"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/customer/group/{id}/members [post]
+// @Security ApiKeyAuth
+func (h *UserGroupHandler) AddUserGroupMembers(ctx *gin.Context) {
+	h.updateMembers(ctx, true)
+}
+
+// @Summary 移除用户组成员
+// @Description 本接口用于将用户移出指定的用户组
+// @Tags 用户组管理
+// @Accept json
+// @Produce json
+// @Param id path uint true "用户组编号"
+// @Param request body custmodel.UserGroupMemberRequest true "用户组成员请求"
+// @Success 200 {object} custmodel.UserGroupReply "成功返回用户组信息"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 404 {object} errors.Error "用户组未找到"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/customer/group/{id}/members [delete]
+// @Security ApiKeyAuth
+func (h *UserGroupHandler) RemoveUserGroupMembers(ctx *gin.Context) {
+	h.updateMembers(ctx, false)
+}
+
+func (h *UserGroupHandler) updateMembers(ctx *gin.Context, add bool) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定用户组ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	var req custmodel.UserGroupMemberRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定用户组成员请求参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctxutil.Logger(ctx, h.log).Info(
+		"开始更新用户组成员",
+		zap.Uint32(commodel.RequestIDKey, uri.ID),
+		zap.Object(commodel.RequestModelKey, &req),
+		zap.Bool("add", add),
+	)
+
+	var (
+		m   *custmodel.UserGroupModel
+		err *errors.Error
+	)
+	if add {
+		m, err = h.svcGroup.AddUserGroupMembers(ctx, uri.ID, req.UserIDs)
+	} else {
+		m, err = h.svcGroup.RemoveUserGroupMembers(ctx, uri.ID, req.UserIDs)
+	}
+	if err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"更新用户组成员失败",
+			zap.Error(err),
+			zap.Uint32(commodel.RequestIDKey, uri.ID),
+			zap.Object(commodel.RequestModelKey, &req),
+		)
+		errors.RespondWithError(ctx, err)
+		return
+	}
+
+	ctxutil.Logger(ctx, h.log).Info(
+		"更新用户组成员成功",
+		zap.Uint32(commodel.RequestIDKey, uri.ID),
+	)
+
+	ctx.JSON(http.StatusOK, &custmodel.UserGroupReply{
+		Code: http.StatusOK,
+		Data: custmodel.UserGroupModelToDetailOut(*m),
+	})
+}
+
+// @Summary 删除用户组
+// @Description 本接口用于删除指定ID的用户组, 组内用户不再继承该用户组的角色
+// @Tags 用户组管理
+// @Accept json
+// @Produce json
+// @Param id path uint true "用户组编号"
+// @Success 200 {object} commodel.MapAPIReply "删除成功"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 404 {object} errors.Error "用户组未找到"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/customer/group/{id} [delete]
+// @Security ApiKeyAuth
+func (h *UserGroupHandler) DeleteUserGroup(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定删除用户组ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctxutil.Logger(ctx, h.log).Info(
+		"开始删除用户组",
+		zap.Uint32(commodel.RequestIDKey, uri.ID),
+	)
+
+	if err := h.svcGroup.DeleteUserGroupByID(ctx, uri.ID); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"删除用户组失败",
+			zap.Error(err),
+			zap.Uint32(commodel.RequestIDKey, uri.ID),
+		)
+		errors.RespondWithError(ctx, err)
+		return
+	}
+
+	ctxutil.Logger(ctx, h.log).Info(
+		"删除用户组成功",
+		zap.Uint32(commodel.RequestIDKey, uri.ID),
+	)
+
+	ctx.JSON(commodel.NoDataReply.Code, commodel.NoDataReply)
+}
+
+// @Summary 查询用户组
+// @Description 本接口用于查询指定ID的用户组及其角色和成员
+// @Tags 用户组管理
+// @Accept json
+// @Produce json
+// @Param id path uint true "用户组编号"
+// @Success 200 {object} custmodel.UserGroupReply "成功返回用户组信息"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 404 {object} errors.Error "用户组未找到"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/customer/group/{id} [get]
+// @Security ApiKeyAuth
+func (h *UserGroupHandler) GetUserGroup(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定查询用户组ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, err := h.svcGroup.FindUserGroupByID(ctx, []string{"Roles", "Users"}, uri.ID)
+	if err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询用户组详情失败",
+			zap.Error(err),
+			zap.Uint32(commodel.RequestIDKey, uri.ID),
+		)
+		errors.RespondWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &custmodel.UserGroupReply{
+		Code: http.StatusOK,
+		Data: custmodel.UserGroupModelToDetailOut(*m),
+	})
+}
+
+// @Summary 查询用户组列表
+// @Description 本接口用于查询用户组列表
+// @Tags 用户组管理
+// @Accept json
+// @Produce json
+// @Param request query custmodel.ListUserGroupRequest false "查询参数"
+// @Success 200 {object} custmodel.PagUserGroupReply "成功返回用户组列表"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/customer/group [get]
+// @Security ApiKeyAuth
+func (h *UserGroupHandler) ListUserGroup(ctx *gin.Context) {
+	var req custmodel.ListUserGroupRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定查询用户组列表参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	page, size, query := req.Query()
+	qp := database.QueryParams{
+		IsCount:   true,
+		SkipCount: req.SkipCount(),
+		Size:      size,
+		Page:      page,
+		Query:     query,
+	}
+	total, ms, err := h.svcGroup.ListUserGroup(ctx, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询用户组列表失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		errors.RespondWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &custmodel.PagUserGroupReply{
+		Code: http.StatusOK,
+		Data: commodel.NewPag(qp, total, custmodel.ListUserGroupModelToStandardOut(ms)),
+	})
+}
+
+// @Summary 获取当前用户的有效角色
+// @Description 本接口用于获取当前登录用户的有效角色, 包括直属角色和所属用户组的角色
+// @Tags 用户组管理
+// @Produce json
+// @Success 200 {object} custmodel.EffectiveRoleReply "成功返回有效角色列表"
+// @Failure 401 {object} errors.Error "用户未认证"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/customer/me/roles [get]
+// @Security ApiKeyAuth
+func (h *UserGroupHandler) GetMyRoles(ctx *gin.Context) {
+	claims, rErr := ctxutil.GetUserClaims(ctx)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"获取个人登录信息失败",
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	roles, err := h.svcGroup.EffectiveRoles(ctx, claims.UserID, claims.RoleID)
+	if err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"获取当前用户有效角色失败",
+			zap.Error(err),
+		)
+		errors.RespondWithError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &custmodel.EffectiveRoleReply{
+		Code: http.StatusOK,
+		Data: custmodel.ListRoleModelToBaseOut(roles),
+	})
+}
+
+func (h *UserGroupHandler) LoadRouter(r *gin.RouterGroup) {
+	r.POST("/group", h.CreateUserGroup)
+	r.PUT("/group/:id", h.UpdateUserGroup)
+	r.POST("/group/:id/members", h.AddUserGroupMembers)
+	r.DELETE("/group/:id/members", h.RemoveUserGroupMembers)
+	r.DELETE("/group/:id", h.DeleteUserGroup)
+	r.GET("/group/:id", h.GetUserGroup)
+	r.GET("/group", h.ListUserGroup)
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"net/http"
+	"path"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	commodel "gin-artweb/internal/model/common"
+	jobsmodel "gin-artweb/internal/model/jobs"
+	jobsvc "gin-artweb/internal/service/jobs"
+	"gin-artweb/internal/shared/common"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/errors"
+)
+
+type ArtifactHandler struct {
+	log         *zap.Logger
+	svcRecord   *jobsvc.RecordService
+	svcArtifact *jobsvc.ArtifactService
+}
+
+func NewArtifactHandler(
+	logger *zap.Logger,
+	svcRecord *jobsvc.RecordService,
+	svcArtifact *jobsvc.ArtifactService,
+) *ArtifactHandler {
+	return &ArtifactHandler{
+		log:         logger,
+		svcRecord:   svcRecord,
+		svcArtifact: svcArtifact,
+	}
+}
+
+// @Summary 查询执行产物列表
+// @Description 本接口用于查询指定执行记录收集的产物文件
+// @Tags 脚本执行记录
+// @Produce json
+// @Param id path uint true "执行记录编号"
+// @Success 200 {object} jobsmodel.ListArtifactReply "成功返回执行产物列表"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 404 {object} errors.Error "执行记录未找到"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/jobs/record/{id}/artifacts [get]
+// @Security ApiKeyAuth
+func (h *ArtifactHandler) ListArtifacts(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定脚本执行记录ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	// 执行记录不存在时返回404, 而不是空列表
+	if _, rErr := h.svcRecord.FindScriptRecordByID(ctx, nil, uri.ID); rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询脚本执行记录详情失败",
+			zap.Error(rErr),
+			zap.Uint32(commodel.RequestIDKey, uri.ID),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ms, rErr := h.svcArtifact.ListArtifacts(ctx, uri.ID)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询执行产物列表失败",
+			zap.Error(rErr),
+			zap.Uint32(commodel.RequestIDKey, uri.ID),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &jobsmodel.ListArtifactReply{
+		Code: http.StatusOK,
+		Data: jobsmodel.ListArtifactToOut(ms),
+	})
+}
+
+// @Summary 下载执行产物
+// @Description 本接口用于下载指定执行记录收集的产物文件
+// @Tags 脚本执行记录
+// @Produce application/octet-stream
+// @Param id path uint true "执行记录编号"
+// @Param artifact_id path uint true "执行产物编号"
+// @Success 200 {file} file "成功下载产物文件"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 404 {object} errors.Error "执行产物未找到或文件不存在"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/jobs/record/{id}/artifacts/{artifact_id} [get]
+// @Security ApiKeyAuth
+func (h *ArtifactHandler) DownloadArtifact(ctx *gin.Context) {
+	var uri jobsmodel.ArtifactUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定执行产物ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := h.svcArtifact.FindArtifact(ctx, uri.ID, uri.ArtifactID)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询执行产物失败",
+			zap.Error(rErr),
+			zap.Uint32(commodel.RequestIDKey, uri.ID),
+			zap.Uint32("artifact_id", uri.ArtifactID),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	rc, rErr := h.svcArtifact.OpenArtifact(ctx, *m)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+	defer rc.Close()
+
+	if m.Checksum != "" {
+		ctx.Header("X-Checksum-Sha256", m.Checksum)
+	}
+	common.StreamFile(ctx, rc, m.Size, path.Base(m.Name), m.CreatedAt)
+}
+
+func (h *ArtifactHandler) LoadRouter(r *gin.RouterGroup) {
+	r.GET("/record/:id/artifacts", h.ListArtifacts)
+	r.GET("/record/:id/artifacts/:artifact_id", h.DownloadArtifact)
+}
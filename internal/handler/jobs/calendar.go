@@ -0,0 +1,279 @@
+package service
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	commodel "gin-artweb/internal/model/common"
+	jobsmodel "gin-artweb/internal/model/jobs"
+	jobsvc "gin-artweb/internal/service/jobs"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+)
+
+type CalendarHandler struct {
+	log         *zap.Logger
+	svcCalendar *jobsvc.CalendarService
+}
+
+func NewCalendarHandler(
+	logger *zap.Logger,
+	svcCalendar *jobsvc.CalendarService,
+) *CalendarHandler {
+	return &CalendarHandler{
+		log:         logger,
+		svcCalendar: svcCalendar,
+	}
+}
+
+// @Summary 导入交易日历
+// @Description 本接口用于导入交易所发布的年度休市安排, 导入时整体替换该年份的节假日
+// @Tags 交易日历管理
+// @Accept json
+// @Produce json
+// @Param request body jobsmodel.ImportTradingCalendarRequest true "导入交易日历请求"
+// @Success 200 {object} commodel.MapAPIReply "导入成功"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 422 {object} errors.Error "交易日历数据无效"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/jobs/calendar/import [post]
+// @Security ApiKeyAuth
+func (h *CalendarHandler) ImportTradingCalendar(ctx *gin.Context) {
+	var req jobsmodel.ImportTradingCalendarRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定导入交易日历参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	claims, rErr := ctxutil.GetUserClaims(ctx)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"获取个人登录信息失败",
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	count, rErr := h.svcCalendar.ImportTradingCalendar(ctx, req.Year, req.Holidays, claims.Username)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"导入交易日历失败",
+			zap.Error(rErr),
+			zap.Int("year", req.Year),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &commodel.MapAPIReply{
+		Code: http.StatusOK,
+		Data: map[string]any{
+			"year":  req.Year,
+			"count": count,
+		},
+	})
+}
+
+// @Summary 删除交易日历节假日
+// @Description 本接口用于删除指定ID的节假日
+// @Tags 交易日历管理
+// @Accept json
+// @Produce json
+// @Param id path uint true "节假日编号"
+// @Success 200 {object} commodel.MapAPIReply "删除成功"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/jobs/calendar/holiday/{id} [delete]
+// @Security ApiKeyAuth
+func (h *CalendarHandler) DeleteTradingHoliday(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定删除交易日历节假日ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	if rErr := h.svcCalendar.DeleteTradingHolidayByID(ctx, uri.ID); rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"删除交易日历节假日失败",
+			zap.Error(rErr),
+			zap.Uint32(commodel.RequestIDKey, uri.ID),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(commodel.NoDataReply.Code, commodel.NoDataReply)
+}
+
+// @Summary 查询交易日历节假日列表
+// @Description 本接口用于分页查询交易日历中的节假日
+// @Tags 交易日历管理
+// @Accept json
+// @Produce json
+// @Param request query jobsmodel.ListTradingHolidayRequest false "查询参数"
+// @Success 200 {object} jobsmodel.PagTradingHolidayReply "成功返回节假日列表"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/jobs/calendar/holiday [get]
+// @Security ApiKeyAuth
+func (h *CalendarHandler) ListTradingHoliday(ctx *gin.Context) {
+	var req jobsmodel.ListTradingHolidayRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定查询交易日历节假日列表参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	page, size, query := req.Query()
+	qp := database.QueryParams{
+		IsCount:   true,
+		SkipCount: req.SkipCount(),
+		Size:      size,
+		Page:      page,
+		OrderBy:   []string{"date ASC"},
+		Query:     query,
+	}
+	total, ms, rErr := h.svcCalendar.ListTradingHoliday(ctx, qp)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询交易日历节假日列表失败",
+			zap.Error(rErr),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	mbs := jobsmodel.ListTradingHolidayToOut(ms)
+	ctx.JSON(http.StatusOK, &jobsmodel.PagTradingHolidayReply{
+		Code: http.StatusOK,
+		Data: commodel.NewPag(qp, total, mbs),
+	})
+}
+
+// @Summary 查询是否为交易日
+// @Description 本接口用于查询指定日期是否为交易日, 未指定日期时查询当天
+// @Tags 交易日历管理
+// @Accept json
+// @Produce json
+// @Param request query jobsmodel.CheckTradingDayRequest false "查询参数"
+// @Success 200 {object} jobsmodel.TradingDayReply "成功返回交易日信息"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/jobs/calendar/check [get]
+// @Security ApiKeyAuth
+func (h *CalendarHandler) CheckTradingDay(ctx *gin.Context) {
+	var req jobsmodel.CheckTradingDayRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定查询交易日参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	day := time.Now()
+	if req.Date != "" {
+		t, err := time.ParseInLocation(jobsmodel.CalendarDateLayout, req.Date, time.Local)
+		if err != nil {
+			rErr := errors.ErrValidationFailed.WithCause(err)
+			errors.RespondWithError(ctx, rErr)
+			return
+		}
+		day = t
+	}
+
+	out, rErr := h.svcCalendar.CheckTradingDay(ctx, day)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询交易日失败",
+			zap.Error(rErr),
+			zap.String("date", req.Date),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &jobsmodel.TradingDayReply{
+		Code: http.StatusOK,
+		Data: *out,
+	})
+}
+
+// @Summary 查询计划任务跳过记录
+// @Description 本接口用于分页查询计划任务因非交易日被跳过的记录
+// @Tags 交易日历管理
+// @Accept json
+// @Produce json
+// @Param request query jobsmodel.ListScheduleSkipRequest false "查询参数"
+// @Success 200 {object} jobsmodel.PagScheduleSkipReply "成功返回跳过记录列表"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/jobs/calendar/skip [get]
+// @Security ApiKeyAuth
+func (h *CalendarHandler) ListScheduleSkip(ctx *gin.Context) {
+	var req jobsmodel.ListScheduleSkipRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定查询计划任务跳过记录参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	page, size, query := req.Query()
+	qp := database.QueryParams{
+		IsCount:   true,
+		SkipCount: req.SkipCount(),
+		Size:      size,
+		Page:      page,
+		OrderBy:   []string{"id DESC"},
+		Query:     query,
+	}
+	total, ms, rErr := h.svcCalendar.ListScheduleSkip(ctx, qp)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询计划任务跳过记录失败",
+			zap.Error(rErr),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	mbs := jobsmodel.ListScheduleSkipToOut(ms)
+	ctx.JSON(http.StatusOK, &jobsmodel.PagScheduleSkipReply{
+		Code: http.StatusOK,
+		Data: commodel.NewPag(qp, total, mbs),
+	})
+}
+
+func (h *CalendarHandler) LoadRouter(r *gin.RouterGroup) {
+	r.POST("/calendar/import", h.ImportTradingCalendar)
+	r.GET("/calendar/holiday", h.ListTradingHoliday)
+	r.DELETE("/calendar/holiday/:id", h.DeleteTradingHoliday)
+	r.GET("/calendar/check", h.CheckTradingDay)
+	r.GET("/calendar/skip", h.ListScheduleSkip)
+}
@@ -49,11 +49,9 @@ func NewScriptRecordHandler(
 func (h *ScriptRecordHandler) ExecScriptRecord(ctx *gin.Context) {
 	var req jobsmodel.CreateScriptRecordRequest
 	if err := ctx.ShouldBind(&req); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定执行脚本参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
@@ -62,10 +60,8 @@ func (h *ScriptRecordHandler) ExecScriptRecord(ctx *gin.Context) {
 
 	claims, rErr := ctxutil.GetUserClaims(ctx)
 	if rErr != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"获取个人登录信息失败",
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, rErr)
 		return
@@ -75,17 +71,16 @@ func (h *ScriptRecordHandler) ExecScriptRecord(ctx *gin.Context) {
 		ScriptID:    req.ScriptID,
 		CommandArgs: req.CommandArgs,
 		EnvVars:     req.EnvVars,
+		Params:      req.Params,
 		Timeout:     req.Timeout,
 		WorkDir:     req.WorkDir,
 		TriggerType: "api",
 		Username:    claims.Subject,
 	})
 	if rErr != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"执行脚本失败",
 			zap.Error(rErr),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, rErr)
 		return
@@ -111,39 +106,34 @@ func (h *ScriptRecordHandler) ExecScriptRecord(ctx *gin.Context) {
 func (h *ScriptRecordHandler) GetScriptRecord(ctx *gin.Context) {
 	var uri commodel.IDUri
 	if err := ctx.ShouldBindUri(&uri); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定查询脚本执行记录ID参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"开始查询脚本执行记录详情",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	m, err := h.svcRecord.FindScriptRecordByID(ctx, []string{"Script"}, uri.ID)
 	if err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"查询脚本执行记录详情失败",
 			zap.Error(err),
 			zap.Uint32(commodel.RequestIDKey, uri.ID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, err)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"查询脚本执行记录详情成功",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	mo := jobsmodel.ScriptRecordToDetailOut(*m)
@@ -153,8 +143,23 @@ func (h *ScriptRecordHandler) GetScriptRecord(ctx *gin.Context) {
 	})
 }
 
+// scriptRecordExportColumns 脚本执行记录列表导出的列
+var scriptRecordExportColumns = []common.ExportColumn[jobsmodel.ScriptRecordDetailOut]{
+	{Key: "id", Header: "ID", Value: func(o jobsmodel.ScriptRecordDetailOut) any { return o.ID }},
+	{Key: "script", Header: "脚本", Value: func(o jobsmodel.ScriptRecordDetailOut) any { return o.Script.Name }},
+	{Key: "trigger_type", Header: "触发类型", Value: func(o jobsmodel.ScriptRecordDetailOut) any { return o.TriggerType }},
+	{Key: "status", Header: "执行状态", Value: func(o jobsmodel.ScriptRecordDetailOut) any { return o.Status }},
+	{Key: "exit_code", Header: "退出码", Value: func(o jobsmodel.ScriptRecordDetailOut) any { return o.ExitCode }},
+	{Key: "command_args", Header: "命令行参数", Value: func(o jobsmodel.ScriptRecordDetailOut) any { return o.CommandArgs }},
+	{Key: "timeout", Header: "超时时间(秒)", Value: func(o jobsmodel.ScriptRecordDetailOut) any { return o.Timeout }},
+	{Key: "error_message", Header: "错误信息", Value: func(o jobsmodel.ScriptRecordDetailOut) any { return o.ErrorMessage }},
+	{Key: "username", Header: "用户名", Value: func(o jobsmodel.ScriptRecordDetailOut) any { return o.Username }},
+	{Key: "created_at", Header: "创建时间", Value: func(o jobsmodel.ScriptRecordDetailOut) any { return o.CreatedAt }},
+	{Key: "updated_at", Header: "更新时间", Value: func(o jobsmodel.ScriptRecordDetailOut) any { return o.UpdatedAt }},
+}
+
 // @Summary 查询脚本执行记录列表
-// @Description 本接口用于查询脚本执行记录列表
+// @Description 本接口用于查询脚本执行记录列表, 支持通过filter参数按字段组合过滤, 指定export参数时按columns选择的列导出为csv或xlsx文件
 // @Tags 脚本执行记录
 // @Accept json
 // @Produce json
@@ -167,55 +172,83 @@ func (h *ScriptRecordHandler) GetScriptRecord(ctx *gin.Context) {
 func (h *ScriptRecordHandler) ListScriptRecord(ctx *gin.Context) {
 	var req jobsmodel.ListScriptRecordRequest
 	if err := ctx.ShouldBindQuery(&req); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定查询脚本执行记录列表参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"开始查询脚本执行记录列表",
-		zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
+	filter, pErr := req.FilterParams(jobsmodel.ScriptRecordFilterFields)
+	if pErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"解析脚本执行记录列表过滤条件失败",
+			zap.Error(pErr),
+			zap.String("filter", req.Filter),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(pErr)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
 	page, size, query := req.Query()
 	qp := database.QueryParams{
-		Preloads: []string{"Script"},
-		IsCount:  true,
-		Size:     size,
-		Page:     page,
-		OrderBy:  []string{"id DESC"},
-		Query:    query,
+		Preloads:  []string{"Script"},
+		IsCount:   true,
+		SkipCount: req.SkipCount(),
+		Size:      size,
+		Page:      page,
+		OrderBy:   []string{"id DESC"},
+		Query:     query,
+		Filter:    filter,
+	}
+
+	if req.Export != "" {
+		rErr := common.ExportList(ctx, h.log, "脚本执行记录", req.Export, req.Columns, scriptRecordExportColumns,
+			func(page, size int) ([]jobsmodel.ScriptRecordDetailOut, *errors.Error) {
+				qp.Page, qp.Size, qp.IsCount = page, size, false
+				_, ms, rErr := h.svcRecord.ListcriptRecord(ctx, qp)
+				if rErr != nil {
+					return nil, rErr
+				}
+				return *jobsmodel.ListScriptRecordToDetailOut(ms), nil
+			},
+		)
+		if rErr != nil {
+			ctxutil.Logger(ctx, h.log).Error(
+				"导出脚本执行记录列表失败",
+				zap.Error(rErr),
+				zap.Object(database.QueryParamsKey, &qp),
+			)
+			errors.RespondWithError(ctx, rErr)
+		}
+		return
 	}
 	total, ms, err := h.svcRecord.ListcriptRecord(ctx, qp)
 	if err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"查询脚本执行记录列表失败",
 			zap.Error(err),
 			zap.Object(database.QueryParamsKey, &qp),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, err)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"查询脚本执行记录列表成功",
-		zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	mbs := jobsmodel.ListScriptRecordToDetailOut(ms)
 	ctx.JSON(http.StatusOK, &jobsmodel.PagScriptRecordReply{
 		Code: http.StatusOK,
-		Data: commodel.NewPag(page, size, total, mbs),
+		Data: commodel.NewPag(qp, total, mbs),
 	})
 }
 
@@ -234,11 +267,9 @@ func (h *ScriptRecordHandler) ListScriptRecord(ctx *gin.Context) {
 func (h *ScriptRecordHandler) DownloadScriptRecordLog(ctx *gin.Context) {
 	var uri commodel.IDUri
 	if err := ctx.ShouldBindUri(&uri); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定脚本执行记录ID参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
@@ -247,11 +278,10 @@ func (h *ScriptRecordHandler) DownloadScriptRecordLog(ctx *gin.Context) {
 
 	m, err := h.svcRecord.FindScriptRecordByID(ctx, []string{"Script"}, uri.ID)
 	if err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"查询脚本执行记录详情失败",
 			zap.Error(err),
 			zap.Uint32(commodel.RequestIDKey, uri.ID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, err)
 		return
@@ -278,11 +308,9 @@ func (h *ScriptRecordHandler) DownloadScriptRecordLog(ctx *gin.Context) {
 func (h *ScriptRecordHandler) StreamScriptRecordLog(ctx *gin.Context) {
 	var uri commodel.IDUri
 	if err := ctx.ShouldBindUri(&uri); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定脚本执行记录ID参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
@@ -291,11 +319,10 @@ func (h *ScriptRecordHandler) StreamScriptRecordLog(ctx *gin.Context) {
 
 	m, rErr := h.svcRecord.FindScriptRecordByID(ctx, []string{}, uri.ID)
 	if rErr != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"查询脚本执行记录详情失败",
 			zap.Error(rErr),
 			zap.Uint32(commodel.RequestIDKey, uri.ID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, rErr)
 		return
@@ -305,11 +332,10 @@ func (h *ScriptRecordHandler) StreamScriptRecordLog(ctx *gin.Context) {
 
 	// 检查日志文件是否存在
 	if _, err := os.Stat(logPath); os.IsNotExist(err) {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"日志文件不存在",
 			zap.String("log_path", logPath),
 			zap.Uint32("script_record_id", uri.ID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrScriptNotFound.WithField("script_record_id", uri.ID)
 		errors.RespondWithError(ctx, rErr)
@@ -319,11 +345,10 @@ func (h *ScriptRecordHandler) StreamScriptRecordLog(ctx *gin.Context) {
 	// 初始化文件信息
 	file, err := os.Open(logPath)
 	if err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"打开日志文件失败",
 			zap.Error(err),
 			zap.String("log_path", logPath),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.FromError(err)
 		errors.RespondWithError(ctx, rErr)
@@ -337,11 +362,10 @@ func (h *ScriptRecordHandler) StreamScriptRecordLog(ctx *gin.Context) {
 	// 移动到文件末尾，准备读取新内容
 	fileInfo, err := file.Stat()
 	if err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"获取日志文件状态失败",
 			zap.Error(err),
 			zap.String("log_path", logPath),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.FromError(err)
 		errors.RespondWithError(ctx, rErr)
@@ -354,11 +378,10 @@ func (h *ScriptRecordHandler) StreamScriptRecordLog(ctx *gin.Context) {
 	initialBytes := make([]byte, currentSize)
 	n, err := file.Read(initialBytes)
 	if err != nil && err != io.EOF {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"读取日志文件失败",
 			zap.Error(err),
 			zap.String("log_path", logPath),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.FromError(err)
 		errors.RespondWithError(ctx, rErr)
@@ -396,11 +419,10 @@ func (h *ScriptRecordHandler) StreamScriptRecordLog(ctx *gin.Context) {
 			// 检查文件大小是否有变化
 			fileInfo, err := os.Stat(logPath)
 			if err != nil {
-				h.log.Error(
+				ctxutil.Logger(ctx, h.log).Error(
 					"获取日志文件状态失败",
 					zap.Error(err),
 					zap.String("log_path", logPath),
-					zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 				)
 				return
 			}
@@ -412,11 +434,10 @@ func (h *ScriptRecordHandler) StreamScriptRecordLog(ctx *gin.Context) {
 				// 移动到上次读取的位置
 				_, err := file.Seek(currentSize, 0)
 				if err != nil {
-					h.log.Error(
+					ctxutil.Logger(ctx, h.log).Error(
 						"移动文件指针失败",
 						zap.Error(err),
 						zap.String("log_path", logPath),
-						zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 					)
 					return
 				}
@@ -425,11 +446,10 @@ func (h *ScriptRecordHandler) StreamScriptRecordLog(ctx *gin.Context) {
 				buf := make([]byte, newSize-currentSize)
 				n, err := file.Read(buf)
 				if err != nil && err != io.EOF {
-					h.log.Error(
+					ctxutil.Logger(ctx, h.log).Error(
 						"读取日志文件失败",
 						zap.Error(err),
 						zap.String("log_path", logPath),
-						zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 					)
 					return
 				}
@@ -451,11 +471,10 @@ func (h *ScriptRecordHandler) StreamScriptRecordLog(ctx *gin.Context) {
 				// 文件修改时间更新了，可能有追加内容
 				_, err := file.Seek(currentSize, 0)
 				if err != nil {
-					h.log.Error(
+					ctxutil.Logger(ctx, h.log).Error(
 						"移动文件指针失败",
 						zap.Error(err),
 						zap.String("log_path", logPath),
-						zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 					)
 					return
 				}
@@ -463,11 +482,10 @@ func (h *ScriptRecordHandler) StreamScriptRecordLog(ctx *gin.Context) {
 				for {
 					n, err := file.Read(buf)
 					if err != nil && err != io.EOF {
-						h.log.Error(
+						ctxutil.Logger(ctx, h.log).Error(
 							"读取日志文件失败",
 							zap.Error(err),
 							zap.String("log_path", logPath),
-							zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 						)
 						return
 					}
@@ -515,11 +533,9 @@ func (h *ScriptRecordHandler) StreamScriptRecordLog(ctx *gin.Context) {
 func (h *ScriptRecordHandler) CancelScriptRecord(ctx *gin.Context) {
 	var uri commodel.IDUri
 	if err := ctx.ShouldBindUri(&uri); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定查询脚本执行记录ID参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
@@ -530,9 +546,67 @@ func (h *ScriptRecordHandler) CancelScriptRecord(ctx *gin.Context) {
 	ctx.JSON(commodel.NoDataReply.Code, commodel.NoDataReply)
 }
 
+// @Summary 查看脚本执行记录环境变量
+// @Description 本接口用于查看指定ID的脚本执行记录环境变量明文, 需要单独授权
+// @Tags 脚本执行记录
+// @Accept json
+// @Produce json
+// @Param id path uint true "执行记录编号"
+// @Success 200 {object} jobsmodel.EnvVarsReply "成功返回环境变量明文"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 404 {object} errors.Error "脚本执行记录未找到"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/jobs/record/{id}/reveal [get]
+// @Security ApiKeyAuth
+func (h *ScriptRecordHandler) RevealScriptRecordEnvVars(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定查看脚本执行记录环境变量ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	claims, rErr := ctxutil.GetUserClaims(ctx)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"获取个人登录信息失败",
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := h.svcRecord.FindScriptRecordByID(ctx, nil, uri.ID)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查看脚本执行记录环境变量失败",
+			zap.Error(rErr),
+			zap.Uint32(commodel.RequestIDKey, uri.ID),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	// 查看明文属于敏感操作, 记录操作人
+	ctxutil.Logger(ctx, h.log).Info(
+		"查看脚本执行记录环境变量明文",
+		zap.Uint32(commodel.RequestIDKey, uri.ID),
+		zap.String("username", claims.Subject),
+	)
+
+	ctx.JSON(http.StatusOK, &jobsmodel.EnvVarsReply{
+		Code: http.StatusOK,
+		Data: jobsmodel.EnvVarsOut{EnvVars: m.EnvVars},
+	})
+}
+
 func (h *ScriptRecordHandler) LoadRouter(r *gin.RouterGroup) {
 	r.POST("/record", h.ExecScriptRecord)
 	r.GET("/record/:id", h.GetScriptRecord)
+	r.GET("/record/:id"+commodel.RevealPathSuffix, h.RevealScriptRecordEnvVars)
 	r.GET("/record", h.ListScriptRecord)
 	r.GET("/record/:id/log", h.DownloadScriptRecordLog)
 	r.GET("/record/:id/log/stream", h.StreamScriptRecordLog)
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	jobsmodel "gin-artweb/internal/model/jobs"
+	jobsvc "gin-artweb/internal/service/jobs"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/errors"
+	"gin-artweb/internal/shared/rpc"
+	"gin-artweb/pkg/rpc/artwebv1"
+)
+
+type JobsRPCHandler struct {
+	artwebv1.UnimplementedJobsServiceServer
+	log       *zap.Logger
+	svcRecord *jobsvc.RecordService
+}
+
+func NewJobsRPCHandler(
+	logger *zap.Logger,
+	svcRecord *jobsvc.RecordService,
+) *JobsRPCHandler {
+	return &JobsRPCHandler{
+		log:       logger,
+		svcRecord: svcRecord,
+	}
+}
+
+// LoadRPC 注册脚本执行的gRPC服务, 与REST接口使用相同的执行和查询权限
+func (h *JobsRPCHandler) LoadRPC(s *rpc.Server) {
+	s.Register(&artwebv1.JobsService_ServiceDesc, h, map[string]string{
+		"TriggerScript":   "POST /api/v1/jobs/record",
+		"GetScriptRecord": "GET /api/v1/jobs/record/:id",
+	})
+}
+
+func (h *JobsRPCHandler) TriggerScript(
+	ctx context.Context,
+	req *artwebv1.TriggerScriptRequest,
+) (*artwebv1.ScriptRecord, error) {
+	if req.GetScriptId() == 0 || req.GetTimeout() <= 0 {
+		return nil, errors.ErrValidationFailed.WithFields(map[string]any{
+			"script_id": req.GetScriptId(),
+			"timeout":   req.GetTimeout(),
+		})
+	}
+
+	claims, rErr := ctxutil.GetUserClaims(ctx)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"获取个人登录信息失败",
+		)
+		return nil, rErr
+	}
+
+	m, rErr := h.svcRecord.AsyncExecuteScript(ctx, jobsmodel.ExecuteRequest{
+		ScriptID:    req.GetScriptId(),
+		CommandArgs: req.GetCommandArgs(),
+		EnvVars:     req.GetEnvVars(),
+		Params:      req.GetParams(),
+		Timeout:     int(req.GetTimeout()),
+		WorkDir:     req.GetWorkDir(),
+		TriggerType: "api",
+		Username:    claims.Subject,
+	})
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"gRPC执行脚本失败",
+			zap.Error(rErr),
+			zap.Uint32("script_id", req.GetScriptId()),
+		)
+		return nil, rErr
+	}
+	return rpcScriptRecord(*m), nil
+}
+
+func (h *JobsRPCHandler) GetScriptRecord(
+	ctx context.Context,
+	req *artwebv1.GetScriptRecordRequest,
+) (*artwebv1.ScriptRecord, error) {
+	if req.GetId() == 0 {
+		return nil, errors.ErrValidationFailed.WithField("id", req.GetId())
+	}
+	m, rErr := h.svcRecord.FindScriptRecordByID(ctx, nil, req.GetId())
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"gRPC查询脚本执行记录失败",
+			zap.Error(rErr),
+			zap.Uint32("script_record_id", req.GetId()),
+		)
+		return nil, rErr
+	}
+	return rpcScriptRecord(*m), nil
+}
+
+// rpcScriptRecord 与REST接口相同, 环境变量的值始终掩码
+func rpcScriptRecord(m jobsmodel.ScriptRecordModel) *artwebv1.ScriptRecord {
+	mo := jobsmodel.ScriptRecordToStandardOut(m)
+	return &artwebv1.ScriptRecord{
+		Id:            mo.ID,
+		ScriptId:      m.ScriptID,
+		TriggerType:   mo.TriggerType,
+		Status:        int32(mo.Status),
+		ExitCode:      int32(mo.ExitCode),
+		EnvVars:       mo.EnvVars,
+		CommandArgs:   mo.CommandArgs,
+		WorkDir:       mo.WorkDir,
+		Timeout:       int32(mo.Timeout),
+		ErrorMessage:  mo.ErrorMessage,
+		FailureReason: mo.FailureReason,
+		Username:      mo.Username,
+		CreatedAt:     mo.CreatedAt,
+		UpdatedAt:     mo.UpdatedAt,
+	}
+}
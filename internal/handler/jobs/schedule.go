@@ -43,11 +43,9 @@ func NewScheduleHandler(
 func (h *ScheduleHandler) CreateSchedule(ctx *gin.Context) {
 	var req jobsmodel.CreateScheduleRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定创建计划任务参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
@@ -56,10 +54,8 @@ func (h *ScheduleHandler) CreateSchedule(ctx *gin.Context) {
 
 	claims, rErr := ctxutil.GetUserClaims(ctx)
 	if rErr != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"获取个人登录信息失败",
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, rErr)
 		return
@@ -71,22 +67,24 @@ func (h *ScheduleHandler) CreateSchedule(ctx *gin.Context) {
 		IsEnabled:     req.IsEnabled,
 		EnvVars:       req.EnvVars,
 		CommandArgs:   req.CommandArgs,
+		Params:        jobsmodel.EncodeParamValues(req.Params),
 		WorkDir:       req.WorkDir,
 		Timeout:       req.Timeout,
 		IsRetry:       req.IsRetry,
 		RetryInterval: req.RetryInterval,
 		MaxRetries:    req.MaxRetries,
+		CalendarMode:  req.CalendarMode,
+		Isolation:     jobsmodel.EncodeIsolation(req.Isolation),
+		Colony:        req.Colony,
 		Username:      claims.Subject,
 		ScriptID:      req.ScriptID,
 	}
 
 	m, rErr := h.svcSchedule.CreateSchedule(ctx, schedule)
 	if rErr != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"创建计划任务失败",
 			zap.Error(rErr),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, rErr)
 		return
@@ -114,11 +112,9 @@ func (h *ScheduleHandler) CreateSchedule(ctx *gin.Context) {
 func (h *ScheduleHandler) UpdateSchedule(ctx *gin.Context) {
 	var uri commodel.IDUri
 	if err := ctx.ShouldBindUri(&uri); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定更新计划任务ID参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
@@ -127,11 +123,9 @@ func (h *ScheduleHandler) UpdateSchedule(ctx *gin.Context) {
 
 	var req jobsmodel.UpdateScheduleRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定更新计划任务参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
@@ -140,10 +134,8 @@ func (h *ScheduleHandler) UpdateSchedule(ctx *gin.Context) {
 
 	claims, rErr := ctxutil.GetUserClaims(ctx)
 	if rErr != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"获取个人登录信息失败",
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, rErr)
 		return
@@ -155,23 +147,25 @@ func (h *ScheduleHandler) UpdateSchedule(ctx *gin.Context) {
 		"is_enabled":     req.IsEnabled,
 		"env_vars":       req.EnvVars,
 		"command_args":   req.CommandArgs,
+		"params":         jobsmodel.EncodeParamValues(req.Params),
 		"work_dir":       req.WorkDir,
 		"timeout":        req.Timeout,
 		"is_retry":       req.IsRetry,
 		"retry_interval": req.RetryInterval,
 		"max_retries":    req.MaxRetries,
+		"calendar_mode":  req.CalendarMode,
+		"isolation":      jobsmodel.EncodeIsolation(req.Isolation),
+		"colony":         req.Colony,
 		"username":       claims.Subject,
 		"script_id":      req.ScriptID,
 	}
 
 	m, err := h.svcSchedule.UpdateScheduleByID(ctx, uri.ID, data)
 	if err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"更新计划任务失败",
 			zap.Error(err),
 			zap.Uint32(commodel.RequestIDKey, uri.ID),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, err)
 		return
@@ -198,38 +192,33 @@ func (h *ScheduleHandler) UpdateSchedule(ctx *gin.Context) {
 func (h *ScheduleHandler) DeleteSchedule(ctx *gin.Context) {
 	var uri commodel.IDUri
 	if err := ctx.ShouldBindUri(&uri); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定删除计划任务ID参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"开始删除计划任务",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	if err := h.svcSchedule.DeleteScheduleByID(ctx, uri.ID); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"删除计划任务失败",
 			zap.Error(err),
 			zap.Uint32(commodel.RequestIDKey, uri.ID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, err)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"删除计划任务成功",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	ctx.JSON(commodel.NoDataReply.Code, commodel.NoDataReply)
@@ -250,39 +239,34 @@ func (h *ScheduleHandler) DeleteSchedule(ctx *gin.Context) {
 func (h *ScheduleHandler) GetSchedule(ctx *gin.Context) {
 	var uri commodel.IDUri
 	if err := ctx.ShouldBindUri(&uri); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定查询计划任务ID参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"开始查询计划任务详情",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	m, err := h.svcSchedule.FindScheduleByID(ctx, []string{"Script"}, uri.ID)
 	if err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"查询计划任务详情失败",
 			zap.Error(err),
 			zap.Uint32(commodel.RequestIDKey, uri.ID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, err)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"查询计划任务详情成功",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	mo := jobsmodel.ScheduleToDetailOut(*m)
@@ -306,55 +290,48 @@ func (h *ScheduleHandler) GetSchedule(ctx *gin.Context) {
 func (h *ScheduleHandler) ListSchedule(ctx *gin.Context) {
 	var req jobsmodel.ListScheduleRequest
 	if err := ctx.ShouldBindQuery(&req); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定查询计划任务列表参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"开始查询计划任务列表",
-		zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	page, size, query := req.Query()
 	qp := database.QueryParams{
-		Preloads: []string{"Script"},
-		IsCount:  true,
-		Size:     size,
-		Page:     page,
-		OrderBy:  []string{"id DESC"},
-		Query:    query,
+		Preloads:  []string{"Script"},
+		IsCount:   true,
+		SkipCount: req.SkipCount(),
+		Size:      size,
+		Page:      page,
+		OrderBy:   []string{"id DESC"},
+		Query:     query,
 	}
 	total, ms, err := h.svcSchedule.ListSchedule(ctx, qp)
 	if err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"查询计划任务列表失败",
 			zap.Error(err),
 			zap.Object(database.QueryParamsKey, &qp),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, err)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"查询计划任务列表成功",
-		zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	mbs := jobsmodel.ListScheduledToDetailOut(ms)
 	ctx.JSON(http.StatusOK, &jobsmodel.PagScheduleReply{
 		Code: http.StatusOK,
-		Data: commodel.NewPag(page, size, total, mbs),
+		Data: commodel.NewPag(qp, total, mbs),
 	})
 }
 
@@ -364,11 +341,110 @@ func (h *ScheduleHandler) ListSchedule(ctx *gin.Context) {
 // func (s *ScheduleService) ReoloadScheduleJobs(ctx *gin.Context) {
 // }
 
+// @Summary 查看计划任务环境变量
+// @Description 本接口用于查看指定ID的计划任务环境变量明文, 需要单独授权
+// @Tags 计划任务管理
+// @Accept json
+// @Produce json
+// @Param id path uint true "计划任务编号"
+// @Success 200 {object} jobsmodel.EnvVarsReply "成功返回环境变量明文"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 404 {object} errors.Error "计划任务未找到"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/jobs/schedule/{id}/reveal [get]
+// @Security ApiKeyAuth
+func (h *ScheduleHandler) RevealScheduleEnvVars(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定查看计划任务环境变量ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	claims, rErr := ctxutil.GetUserClaims(ctx)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"获取个人登录信息失败",
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := h.svcSchedule.FindScheduleByID(ctx, nil, uri.ID)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查看计划任务环境变量失败",
+			zap.Error(rErr),
+			zap.Uint32(commodel.RequestIDKey, uri.ID),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	// 查看明文属于敏感操作, 记录操作人
+	ctxutil.Logger(ctx, h.log).Info(
+		"查看计划任务环境变量明文",
+		zap.Uint32(commodel.RequestIDKey, uri.ID),
+		zap.String("username", claims.Subject),
+	)
+
+	ctx.JSON(http.StatusOK, &jobsmodel.EnvVarsReply{
+		Code: http.StatusOK,
+		Data: jobsmodel.EnvVarsOut{EnvVars: m.EnvVars},
+	})
+}
+
+// @Summary 预览计划任务的环境变量
+// @Description 本接口用于预览计划任务触发时注入的环境变量, 绑定oes集群时按当前的集群数据解析集群上下文变量
+// @Tags 计划任务管理
+// @Produce json
+// @Param id path uint true "计划任务编号"
+// @Success 200 {object} jobsmodel.EnvPreviewReply "成功返回环境变量预览"
+// @Failure 400 {object} errors.Error "请求参数错误或集群上下文无法解析"
+// @Failure 404 {object} errors.Error "计划任务或绑定的集群未找到"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/jobs/schedule/{id}/env/preview [get]
+// @Security ApiKeyAuth
+func (h *ScheduleHandler) PreviewScheduleEnv(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定计划任务ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	out, rErr := h.svcSchedule.PreviewEnv(ctx, uri.ID)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"预览计划任务环境变量失败",
+			zap.Error(rErr),
+			zap.Uint32(commodel.RequestIDKey, uri.ID),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &jobsmodel.EnvPreviewReply{
+		Code: http.StatusOK,
+		Data: *out,
+	})
+}
+
 func (h *ScheduleHandler) LoadRouter(r *gin.RouterGroup) {
 	r.POST("/schedule", h.CreateSchedule)
 	r.PUT("/schedule/:id", h.UpdateSchedule)
 	r.DELETE("/schedule/:id", h.DeleteSchedule)
 	r.GET("/schedule/:id", h.GetSchedule)
+	r.GET("/schedule/:id"+commodel.RevealPathSuffix, h.RevealScheduleEnvVars)
+	r.GET("/schedule/:id/env/preview", h.PreviewScheduleEnv)
 	r.GET("/schedule", h.ListSchedule)
 	// r.GET("/schedulejob", s.ListScheduleJobs)
 	// r.POST("/schedule/reload", s.ReoloadScheduleJobs)
@@ -44,6 +44,9 @@ func NewScriptHandler(
 // @Param label formData string false "标签"
 // @Param language formData string true "脚本语言"
 // @Param status formData bool true "脚本状态"
+// @Param params formData string false "参数定义(JSON数组)"
+// @Param isolation formData string false "隔离执行配置(JSON对象)"
+// @Param artifacts formData string false "产物文件匹配规则(JSON数组)"
 // @Success 200 {object} jobsmodel.ScriptReply "成功返回脚本信息"
 // @Failure 400 {object} errors.Error "请求参数错误"
 // @Failure 413 {object} errors.Error "文件过大"
@@ -53,11 +56,38 @@ func NewScriptHandler(
 func (h *ScriptHandler) CreateScript(ctx *gin.Context) {
 	var req jobsmodel.UploadScriptRequest
 	if err := ctx.ShouldBind(&req); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定上传脚本参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+	if _, err := jobsvc.ParseScriptParams(req.Params); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"脚本参数定义无效",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+	isolation, err := jobsvc.ParseIsolation(req.Isolation)
+	if err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"脚本隔离执行配置无效",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+	artifacts, err := jobsvc.ParseArtifactPatterns(req.Artifacts)
+	if err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"脚本产物文件匹配规则无效",
+			zap.Error(err),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
@@ -66,10 +96,8 @@ func (h *ScriptHandler) CreateScript(ctx *gin.Context) {
 
 	claims, rErr := ctxutil.GetUserClaims(ctx)
 	if rErr != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"获取个人登录信息失败",
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, rErr)
 		return
@@ -83,6 +111,10 @@ func (h *ScriptHandler) CreateScript(ctx *gin.Context) {
 		Language:  req.Language,
 		Status:    req.Status,
 		IsBuiltin: false,
+		Resumable: req.Resumable,
+		Params:    req.Params,
+		Isolation: jobsmodel.EncodeIsolation(isolation),
+		Artifacts: jobsmodel.EncodeArtifactPatterns(artifacts),
 		Username:  claims.Subject,
 	}
 
@@ -94,10 +126,9 @@ func (h *ScriptHandler) CreateScript(ctx *gin.Context) {
 
 	m, rErr := h.svcScript.CreateScript(ctx, script)
 	if rErr != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"创建脚本失败",
 			zap.Error(rErr),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, rErr)
 		return
@@ -121,6 +152,9 @@ func (h *ScriptHandler) CreateScript(ctx *gin.Context) {
 // @Param label formData string false "标签"
 // @Param language formData string true "脚本语言"
 // @Param status formData bool true "脚本状态"
+// @Param params formData string false "参数定义(JSON数组)"
+// @Param isolation formData string false "隔离执行配置(JSON对象)"
+// @Param artifacts formData string false "产物文件匹配规则(JSON数组)"
 // @Success 200 {object} jobsmodel.ScriptReply "成功返回脚本信息"
 // @Failure 400 {object} errors.Error "请求参数错误"
 // @Failure 404 {object} errors.Error "脚本未找到"
@@ -131,11 +165,9 @@ func (h *ScriptHandler) CreateScript(ctx *gin.Context) {
 func (h *ScriptHandler) UpdateScript(ctx *gin.Context) {
 	var uri commodel.IDUri
 	if err := ctx.ShouldBindUri(&uri); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定更新脚本ID参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
@@ -144,11 +176,38 @@ func (h *ScriptHandler) UpdateScript(ctx *gin.Context) {
 
 	var req jobsmodel.UploadScriptRequest
 	if err := ctx.ShouldBind(&req); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定上传脚本参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+	if _, err := jobsvc.ParseScriptParams(req.Params); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"脚本参数定义无效",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+	isolation, err := jobsvc.ParseIsolation(req.Isolation)
+	if err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"脚本隔离执行配置无效",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+	artifacts, err := jobsvc.ParseArtifactPatterns(req.Artifacts)
+	if err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"脚本产物文件匹配规则无效",
+			zap.Error(err),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
@@ -157,21 +216,19 @@ func (h *ScriptHandler) UpdateScript(ctx *gin.Context) {
 
 	om, rErr := h.svcScript.FindScriptByID(ctx, uri.ID)
 	if rErr != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"查询脚本失败",
 			zap.Error(rErr),
 			zap.Uint32("script_id", uri.ID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 	if rErr := h.svcScript.RemoveScript(ctx, *om); rErr != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"删除原脚本文件失败",
 			zap.Error(rErr),
 			zap.Uint32("script_id", uri.ID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, rErr)
 		return
@@ -179,10 +236,8 @@ func (h *ScriptHandler) UpdateScript(ctx *gin.Context) {
 
 	claims, rErr := ctxutil.GetUserClaims(ctx)
 	if rErr != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"获取个人登录信息失败",
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, rErr)
 		return
@@ -196,6 +251,10 @@ func (h *ScriptHandler) UpdateScript(ctx *gin.Context) {
 		Language:  req.Language,
 		Status:    req.Status,
 		IsBuiltin: false,
+		Resumable: req.Resumable,
+		Params:    req.Params,
+		Isolation: jobsmodel.EncodeIsolation(isolation),
+		Artifacts: jobsmodel.EncodeArtifactPatterns(artifacts),
 		Username:  claims.Subject,
 	}
 	nm.ID = uri.ID
@@ -213,14 +272,17 @@ func (h *ScriptHandler) UpdateScript(ctx *gin.Context) {
 		"language":   req.Language,
 		"status":     req.Status,
 		"is_builtin": false,
+		"resumable":  req.Resumable,
+		"params":     req.Params,
+		"isolation":  jobsmodel.EncodeIsolation(isolation),
+		"artifacts":  jobsmodel.EncodeArtifactPatterns(artifacts),
 		"username":   claims.Subject,
 	})
 	if rErr != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"更新脚本失败",
 			zap.Error(rErr),
 			zap.Uint32("script_id", uri.ID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, rErr)
 		return
@@ -247,38 +309,33 @@ func (h *ScriptHandler) UpdateScript(ctx *gin.Context) {
 func (h *ScriptHandler) DeleteScript(ctx *gin.Context) {
 	var uri commodel.IDUri
 	if err := ctx.ShouldBindUri(&uri); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定删除脚本ID参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"开始删除脚本",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	if err := h.svcScript.DeleteScriptByID(ctx, uri.ID); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"删除脚本失败",
 			zap.Error(err),
 			zap.Uint32(commodel.RequestIDKey, uri.ID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, err)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"删除脚本成功",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	ctx.JSON(commodel.NoDataReply.Code, commodel.NoDataReply)
 }
@@ -298,39 +355,34 @@ func (h *ScriptHandler) DeleteScript(ctx *gin.Context) {
 func (h *ScriptHandler) GetScript(ctx *gin.Context) {
 	var uri commodel.IDUri
 	if err := ctx.ShouldBindUri(&uri); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定查询脚本ID参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"开始查询脚本详情",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	m, err := h.svcScript.FindScriptByID(ctx, uri.ID)
 	if err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"查询脚本详情失败",
 			zap.Error(err),
 			zap.Uint32(commodel.RequestIDKey, uri.ID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, err)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"查询脚本详情成功",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	mo := jobsmodel.ScriptModelToStandardOut(*m)
@@ -354,54 +406,114 @@ func (h *ScriptHandler) GetScript(ctx *gin.Context) {
 func (h *ScriptHandler) ListScript(ctx *gin.Context) {
 	var req jobsmodel.ListScriptRequest
 	if err := ctx.ShouldBindQuery(&req); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定查询脚本列表参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"开始查询脚本列表",
-		zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	page, size, query := req.Query()
 	qp := database.QueryParams{
-		IsCount: true,
-		Size:    size,
-		Page:    page,
-		OrderBy: []string{"id DESC"},
-		Query:   query,
+		IsCount:   true,
+		SkipCount: req.SkipCount(),
+		Size:      size,
+		Page:      page,
+		OrderBy:   []string{"id DESC"},
+		Query:     query,
 	}
 	total, ms, err := h.svcScript.ListScript(ctx, qp)
 	if err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"查询脚本列表失败",
 			zap.Error(err),
 			zap.Object(database.QueryParamsKey, &qp),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, err)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"查询脚本列表成功",
-		zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	mbs := jobsmodel.ListScriptModelToOutBase(ms)
 	ctx.JSON(http.StatusOK, &jobsmodel.PagScriptReply{
 		Code: http.StatusOK,
-		Data: commodel.NewPag(page, size, total, mbs),
+		Data: commodel.NewPag(qp, total, mbs),
+	})
+}
+
+// @Summary 检查脚本
+// @Description 本接口用于在调度到生产环境之前检查脚本, 包括解释器行、换行符、解释器语法检查和shellcheck(已安装时)
+// @Description dry_run为true时在配置的沙箱中试运行脚本, 试运行设置环境变量JOBS_DRY_RUN=1, 未配置沙箱时返回错误
+// @Tags 脚本管理
+// @Accept json
+// @Produce json
+// @Param id path uint true "脚本编号"
+// @Param request body jobsmodel.ValidateScriptRequest false "检查参数"
+// @Success 200 {object} jobsmodel.ScriptValidateReply "成功返回检查结果"
+// @Failure 400 {object} errors.Error "请求参数错误或未配置试运行沙箱"
+// @Failure 404 {object} errors.Error "脚本未找到"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/jobs/script/{id}/validate [post]
+// @Security ApiKeyAuth
+func (h *ScriptHandler) ValidateScript(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定检查脚本ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	var req jobsmodel.ValidateScriptRequest
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctxutil.Logger(ctx, h.log).Error(
+				"绑定检查脚本参数失败",
+				zap.Error(err),
+			)
+			rErr := errors.ErrValidationFailed.WithCause(err)
+			errors.RespondWithError(ctx, rErr)
+			return
+		}
+	}
+
+	m, rErr := h.svcScript.FindScriptByID(ctx, uri.ID)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询脚本详情失败",
+			zap.Error(rErr),
+			zap.Uint32("script_id", uri.ID),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	out, rErr := h.svcScript.ValidateScript(ctx, *m, req.DryRun, req.CommandArgs)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"检查脚本失败",
+			zap.Error(rErr),
+			zap.Uint32("script_id", uri.ID),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &jobsmodel.ScriptValidateReply{
+		Code: http.StatusOK,
+		Data: *out,
 	})
 }
 
@@ -420,11 +532,9 @@ func (h *ScriptHandler) ListScript(ctx *gin.Context) {
 func (h *ScriptHandler) DownloadScript(ctx *gin.Context) {
 	var uri commodel.IDUri
 	if err := ctx.ShouldBindUri(&uri); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定下载脚本ID参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
@@ -433,11 +543,10 @@ func (h *ScriptHandler) DownloadScript(ctx *gin.Context) {
 
 	m, err := h.svcScript.FindScriptByID(ctx, uri.ID)
 	if err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"查询脚本详情失败",
 			zap.Error(err),
 			zap.Uint32(commodel.RequestIDKey, uri.ID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, err)
 		return
@@ -463,41 +572,34 @@ func (h *ScriptHandler) DownloadScript(ctx *gin.Context) {
 func (h *ScriptHandler) ListProject(ctx *gin.Context) {
 	var req jobsmodel.ListScriptRequest
 	if err := ctx.ShouldBindQuery(&req); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定查询脚本列表参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"开始查询项目列表",
-		zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	_, _, query := req.Query()
 
 	projects, err := h.svcScript.ListProjects(ctx, query)
 	if err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"查询项目列表失败",
 			zap.Error(err),
 			zap.Any(database.QueryParamsKey, query),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, err)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"查询项目列表成功",
-		zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	ctx.JSON(http.StatusOK, &jobsmodel.ListProjectReply{
@@ -520,41 +622,34 @@ func (h *ScriptHandler) ListProject(ctx *gin.Context) {
 func (h *ScriptHandler) ListLabel(ctx *gin.Context) {
 	var req jobsmodel.ListScriptRequest
 	if err := ctx.ShouldBindQuery(&req); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定查询脚本列表参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"开始查询标签列表",
-		zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	_, _, query := req.Query()
 
 	labels, err := h.svcScript.ListLabels(ctx, query)
 	if err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"查询标签列表失败",
 			zap.Error(err),
 			zap.Any(database.QueryParamsKey, query),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, err)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"查询项目列表成功",
-		zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	ctx.JSON(http.StatusOK, &jobsmodel.ListProjectReply{
@@ -570,6 +665,7 @@ func (h *ScriptHandler) LoadRouter(r *gin.RouterGroup) {
 	r.GET("/script/:id", h.GetScript)
 	r.GET("/script", h.ListScript)
 	r.GET("/script/:id/download", h.DownloadScript)
+	r.POST("/script/:id/validate", h.ValidateScript)
 	r.GET("/script/project", h.ListProject)
 	r.GET("/script/label", h.ListLabel)
 }
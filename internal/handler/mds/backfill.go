@@ -0,0 +1,262 @@
+package service
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	commodel "gin-artweb/internal/model/common"
+	mdsmodel "gin-artweb/internal/model/mds"
+	mdssvc "gin-artweb/internal/service/mds"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+)
+
+type MdsBackfillHandler struct {
+	log        *zap.Logger
+	ucBackfill *mdssvc.MdsBackfillService
+}
+
+func NewMdsBackfillHandler(
+	logger *zap.Logger,
+	ucBackfill *mdssvc.MdsBackfillService,
+) *MdsBackfillHandler {
+	return &MdsBackfillHandler{
+		log:        logger,
+		ucBackfill: ucBackfill,
+	}
+}
+
+// @Summary 发起mds数据回补
+// @Description 本接口用于按日期范围为集群发起数据回补, 日期范围内的每个交易日执行一次回放脚本, 回补在后台执行
+// @Tags mds数据回补
+// @Accept json
+// @Produce json
+// @Param request body mdsmodel.CreateMdsBackfillRequest true "发起数据回补请求"
+// @Success 202 {object} mdsmodel.MdsBackfillRunReply "成功返回数据回补详情"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 401 {object} errors.Error "未授权"
+// @Failure 404 {object} errors.Error "mds集群或回放脚本未找到"
+// @Failure 409 {object} errors.Error "集群已有正在执行的数据回补"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/mds/backfill [post]
+// @Security ApiKeyAuth
+func (h *MdsBackfillHandler) StartBackfill(ctx *gin.Context) {
+	var req mdsmodel.CreateMdsBackfillRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定发起mds数据回补参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	claims, rErr := ctxutil.GetUserClaims(ctx)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"获取个人登录信息失败",
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := h.ucBackfill.StartBackfill(ctx, req, claims.Username)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"发起mds数据回补失败",
+			zap.Error(rErr),
+			zap.Object(commodel.RequestModelKey, &req),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, &mdsmodel.MdsBackfillRunReply{
+		Code: http.StatusAccepted,
+		Data: mdsmodel.MdsBackfillRunToDetailOut(*m),
+	})
+}
+
+// @Summary 取消mds数据回补
+// @Description 本接口用于取消正在执行的数据回补, 正在执行的回放脚本会被终止, 未开始的交易日标记为已取消
+// @Tags mds数据回补
+// @Accept json
+// @Produce json
+// @Param id path uint true "数据回补编号"
+// @Success 200 {object} commodel.MapAPIReply "取消成功"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 404 {object} errors.Error "数据回补未找到"
+// @Failure 409 {object} errors.Error "数据回补未在执行"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/mds/backfill/{id}/cancel [post]
+// @Security ApiKeyAuth
+func (h *MdsBackfillHandler) CancelBackfill(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定取消mds数据回补ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	if rErr := h.ucBackfill.CancelBackfill(ctx, uri.ID); rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"取消mds数据回补失败",
+			zap.Error(rErr),
+			zap.Uint32(commodel.RequestIDKey, uri.ID),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(commodel.NoDataReply.Code, commodel.NoDataReply)
+}
+
+// @Summary 重试mds数据回补
+// @Description 本接口用于重新执行已结束的数据回补中失败、跳过或取消的交易日, 已成功的交易日不再执行
+// @Tags mds数据回补
+// @Accept json
+// @Produce json
+// @Param id path uint true "数据回补编号"
+// @Success 202 {object} mdsmodel.MdsBackfillRunReply "成功返回数据回补详情"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 404 {object} errors.Error "数据回补未找到"
+// @Failure 409 {object} errors.Error "数据回补未结束或集群已有正在执行的数据回补"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/mds/backfill/{id}/retry [post]
+// @Security ApiKeyAuth
+func (h *MdsBackfillHandler) RetryBackfill(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定重试mds数据回补ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := h.ucBackfill.RetryBackfill(ctx, uri.ID)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"重试mds数据回补失败",
+			zap.Error(rErr),
+			zap.Uint32(commodel.RequestIDKey, uri.ID),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, &mdsmodel.MdsBackfillRunReply{
+		Code: http.StatusAccepted,
+		Data: mdsmodel.MdsBackfillRunToDetailOut(*m),
+	})
+}
+
+// @Summary 查询mds数据回补详情
+// @Description 本接口用于查询数据回补的汇总状态及每个交易日的回放状态
+// @Tags mds数据回补
+// @Accept json
+// @Produce json
+// @Param id path uint true "数据回补编号"
+// @Success 200 {object} mdsmodel.MdsBackfillRunReply "成功返回数据回补详情"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 404 {object} errors.Error "数据回补未找到"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/mds/backfill/{id} [get]
+// @Security ApiKeyAuth
+func (h *MdsBackfillHandler) GetBackfill(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定查询mds数据回补ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := h.ucBackfill.FindBackfillRunByID(ctx, uri.ID)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询mds数据回补详情失败",
+			zap.Error(rErr),
+			zap.Uint32(commodel.RequestIDKey, uri.ID),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &mdsmodel.MdsBackfillRunReply{
+		Code: http.StatusOK,
+		Data: mdsmodel.MdsBackfillRunToDetailOut(*m),
+	})
+}
+
+// @Summary 查询mds数据回补列表
+// @Description 本接口用于分页查询数据回补及各状态的交易日数
+// @Tags mds数据回补
+// @Accept json
+// @Produce json
+// @Param request query mdsmodel.ListMdsBackfillRunRequest false "查询参数"
+// @Success 200 {object} mdsmodel.PagMdsBackfillRunReply "成功返回数据回补列表"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/mds/backfill [get]
+// @Security ApiKeyAuth
+func (h *MdsBackfillHandler) ListBackfill(ctx *gin.Context) {
+	var req mdsmodel.ListMdsBackfillRunRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定查询mds数据回补列表参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	page, size, query := req.Query()
+	qp := database.QueryParams{
+		Preloads:  []string{"Days"},
+		IsCount:   true,
+		SkipCount: req.SkipCount(),
+		Size:      size,
+		Page:      page,
+		OrderBy:   []string{"id DESC"},
+		Query:     query,
+	}
+	total, ms, rErr := h.ucBackfill.ListBackfillRun(ctx, qp)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询mds数据回补列表失败",
+			zap.Error(rErr),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	mbs := mdsmodel.ListMdsBackfillRunToOut(ms)
+	ctx.JSON(http.StatusOK, &mdsmodel.PagMdsBackfillRunReply{
+		Code: http.StatusOK,
+		Data: commodel.NewPag(qp, total, mbs),
+	})
+}
+
+func (h *MdsBackfillHandler) LoadRouter(r *gin.RouterGroup) {
+	r.POST("/backfill", h.StartBackfill)
+	r.GET("/backfill", h.ListBackfill)
+	r.GET("/backfill/:id", h.GetBackfill)
+	r.POST("/backfill/:id/cancel", h.CancelBackfill)
+	r.POST("/backfill/:id/retry", h.RetryBackfill)
+}
@@ -11,6 +11,7 @@ import (
 	jobsmodel "gin-artweb/internal/model/jobs"
 	mdsmodel "gin-artweb/internal/model/mds"
 	mdssvc "gin-artweb/internal/service/mds"
+	"gin-artweb/internal/shared/common"
 	"gin-artweb/internal/shared/ctxutil"
 	"gin-artweb/internal/shared/database"
 	"gin-artweb/internal/shared/errors"
@@ -48,11 +49,9 @@ func NewMdsColonyService(
 func (s *MdsColonyService) CreateMdsColony(ctx *gin.Context) {
 	var req mdsmodel.CreateOrUpdateMdsColonyRequest
 	if err := ctx.ShouldBind(&req); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"绑定创建mds集群参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
@@ -69,11 +68,9 @@ func (s *MdsColonyService) CreateMdsColony(ctx *gin.Context) {
 
 	m, rErr := s.ucColony.CreateMdsColony(ctx, colony)
 	if rErr != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"创建mds集群失败",
 			zap.Error(rErr),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, rErr)
 		return
@@ -92,20 +89,21 @@ func (s *MdsColonyService) CreateMdsColony(ctx *gin.Context) {
 // @Produce json
 // @Param id path uint true "mds集群编号"
 // @Param request body mdsmodel.CreateOrUpdateMdsColonyRequest true "更新mds集群请求"
+// @Param If-Match header string false "版本号, 未提供时使用请求体中的version"
 // @Success 200 {object} mdsmodel.MdsColonyReply "成功返回mds集群信息"
 // @Failure 400 {object} errors.Error "请求参数错误"
 // @Failure 404 {object} errors.Error "mds集群未找到"
+// @Failure 409 {object} errors.Error "mds集群已被其他用户修改, data.current为当前数据"
+// @Failure 428 {object} errors.Error "缺少版本号"
 // @Failure 500 {object} errors.Error "服务器内部错误"
 // @Router /api/v1/mds/colony/{id} [put]
 // @Security ApiKeyAuth
 func (s *MdsColonyService) UpdateMdsColony(ctx *gin.Context) {
 	var uri commodel.IDUri
 	if err := ctx.ShouldBindUri(&uri); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"绑定更新mds集群ID参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
@@ -114,33 +112,36 @@ func (s *MdsColonyService) UpdateMdsColony(ctx *gin.Context) {
 
 	var req mdsmodel.CreateOrUpdateMdsColonyRequest
 	if err := ctx.ShouldBind(&req); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"绑定更新mds集群参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
+	version, ok := commodel.RequestVersion(ctx.GetHeader(commodel.IfMatchHeader), req.Version)
+	if !ok {
+		errors.RespondWithError(ctx, errors.ErrVersionRequired)
+		return
+	}
+
 	data := map[string]any{
-		"colony_num":     req.ColonyNum,
-		"extracted_name": req.ExtractedName,
-		"is_enable":      req.IsEnable,
-		"package_id":     req.PackageID,
-		"mon_node_id":    req.MonNodeID,
+		"colony_num":        req.ColonyNum,
+		"extracted_name":    req.ExtractedName,
+		"is_enable":         req.IsEnable,
+		"package_id":        req.PackageID,
+		"mon_node_id":       req.MonNodeID,
+		database.VersionKey: version,
 	}
 
 	m, err := s.ucColony.UpdateMdsColonyByID(ctx, uri.ID, data)
 	if err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"更新mds集群失败",
 			zap.Error(err),
 			zap.Uint32(commodel.RequestIDKey, uri.ID),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, err)
 		return
@@ -167,38 +168,33 @@ func (s *MdsColonyService) UpdateMdsColony(ctx *gin.Context) {
 func (s *MdsColonyService) DeleteMdsColony(ctx *gin.Context) {
 	var uri commodel.IDUri
 	if err := ctx.ShouldBindUri(&uri); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"绑定删除mds集群ID参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始删除mds集群",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	if err := s.ucColony.DeleteMdsColonyByID(ctx, uri.ID); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"删除mds集群失败",
 			zap.Error(err),
 			zap.Uint32(commodel.RequestIDKey, uri.ID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, err)
 		return
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"删除mds集群成功",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	ctx.JSON(commodel.NoDataReply.Code, commodel.NoDataReply)
@@ -219,39 +215,34 @@ func (s *MdsColonyService) DeleteMdsColony(ctx *gin.Context) {
 func (s *MdsColonyService) GetMdsColony(ctx *gin.Context) {
 	var uri commodel.IDUri
 	if err := ctx.ShouldBindUri(&uri); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"绑定查询mds集群ID参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始查询mds集群详情",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	m, err := s.ucColony.FindMdsColonyByID(ctx, []string{"Package", "MonNode"}, uri.ID)
 	if err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"查询mds集群详情失败",
 			zap.Error(err),
 			zap.Uint32(commodel.RequestIDKey, uri.ID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, err)
 		return
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"查询mds集群详情成功",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	mo := mdsmodel.MdsColonyToDetailOut(*m)
@@ -261,8 +252,30 @@ func (s *MdsColonyService) GetMdsColony(ctx *gin.Context) {
 	})
 }
 
+// mdsColonyExportColumns mds集群列表导出的列
+var mdsColonyExportColumns = []common.ExportColumn[mdsmodel.MdsColonyDetailOut]{
+	{Key: "id", Header: "ID", Value: func(o mdsmodel.MdsColonyDetailOut) any { return o.ID }},
+	{Key: "colony_num", Header: "集群号", Value: func(o mdsmodel.MdsColonyDetailOut) any { return o.ColonyNum }},
+	{Key: "extracted_name", Header: "解压后名称", Value: func(o mdsmodel.MdsColonyDetailOut) any { return o.ExtractedName }},
+	{Key: "is_enable", Header: "是否启用", Value: func(o mdsmodel.MdsColonyDetailOut) any { return o.IsEnable }},
+	{Key: "package", Header: "程序包", Value: func(o mdsmodel.MdsColonyDetailOut) any {
+		if o.Package == nil {
+			return ""
+		}
+		return o.Package.Filename + " " + o.Package.Version
+	}},
+	{Key: "mon_node", Header: "mon节点", Value: func(o mdsmodel.MdsColonyDetailOut) any {
+		if o.MonNode == nil {
+			return ""
+		}
+		return o.MonNode.Name
+	}},
+	{Key: "created_at", Header: "创建时间", Value: func(o mdsmodel.MdsColonyDetailOut) any { return o.CreatedAt }},
+	{Key: "updated_at", Header: "更新时间", Value: func(o mdsmodel.MdsColonyDetailOut) any { return o.UpdatedAt }},
+}
+
 // @Summary 查询mds集群列表
-// @Description 本接口用于查询mds集群列表
+// @Description 本接口用于查询mds集群列表, 指定export参数时按columns选择的列导出为csv或xlsx文件
 // @Tags mds集群管理
 // @Accept json
 // @Produce json
@@ -271,6 +284,8 @@ func (s *MdsColonyService) GetMdsColony(ctx *gin.Context) {
 // @Param name query string false "mds集群名称"
 // @Param is_enabled query bool false "是否启用"
 // @Param username query string false "创建用户名"
+// @Param export query string false "导出格式" Enums(csv, xlsx)
+// @Param columns query string false "导出的列(多个用,隔开)"
 // @Success 200 {object} mdsmodel.PagMdsColonyReply "成功返回mds集群列表"
 // @Failure 400 {object} errors.Error "请求参数错误"
 // @Failure 500 {object} errors.Error "服务器内部错误"
@@ -279,55 +294,70 @@ func (s *MdsColonyService) GetMdsColony(ctx *gin.Context) {
 func (s *MdsColonyService) ListMdsColony(ctx *gin.Context) {
 	var req mdsmodel.ListMdsColonyRequest
 	if err := ctx.ShouldBindQuery(&req); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"绑定查询mds集群列表参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始查询mds集群列表",
-		zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	page, size, query := req.Query()
 	qp := database.QueryParams{
-		Preloads: []string{"Package", "MonNode"},
-		IsCount:  true,
-		Size:     size,
-		Page:     page,
-		OrderBy:  []string{"id DESC"},
-		Query:    query,
+		Preloads:  []string{"Package", "MonNode"},
+		IsCount:   true,
+		SkipCount: req.SkipCount(),
+		Size:      size,
+		Page:      page,
+		OrderBy:   []string{"id DESC"},
+		Query:     query,
+	}
+
+	if req.Export != "" {
+		rErr := common.ExportList(ctx, s.log, "mds集群", req.Export, req.Columns, mdsColonyExportColumns,
+			func(page, size int) ([]mdsmodel.MdsColonyDetailOut, *errors.Error) {
+				qp.Page, qp.Size, qp.IsCount = page, size, false
+				_, ms, rErr := s.ucColony.ListMdsColony(ctx, qp)
+				if rErr != nil {
+					return nil, rErr
+				}
+				return *mdsmodel.ListMdsColonyToDetailOut(ms), nil
+			},
+		)
+		if rErr != nil {
+			ctxutil.Logger(ctx, s.log).Error(
+				"导出mds集群列表失败",
+				zap.Error(rErr),
+				zap.Object(database.QueryParamsKey, &qp),
+			)
+			errors.RespondWithError(ctx, rErr)
+		}
+		return
 	}
 	total, ms, err := s.ucColony.ListMdsColony(ctx, qp)
 	if err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"查询mds集群列表失败",
 			zap.Error(err),
 			zap.Object(database.QueryParamsKey, &qp),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, err)
 		return
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"查询mds集群列表成功",
-		zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	mbs := mdsmodel.ListMdsColonyToDetailOut(ms)
 	ctx.JSON(http.StatusOK, &mdsmodel.PagMdsColonyReply{
 		Code: http.StatusOK,
-		Data: commodel.NewPag(page, size, total, mbs),
+		Data: commodel.NewPag(qp, total, mbs),
 	})
 }
 
@@ -345,11 +375,9 @@ func (s *MdsColonyService) ListMdsColony(ctx *gin.Context) {
 func (s *MdsColonyService) ListMdsTaskStatus(ctx *gin.Context) {
 	var req mdsmodel.ListMdsColonyRequest
 	if err := ctx.ShouldBindQuery(&req); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"绑定查询mds集群列表参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
@@ -369,12 +397,10 @@ func (s *MdsColonyService) ListMdsTaskStatus(ctx *gin.Context) {
 
 	_, ms, err := s.ucColony.ListMdsColony(ctx, qp)
 	if err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"查询mds集群列表失败",
 			zap.Error(err),
 			zap.Object(database.QueryParamsKey, &qp),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, err)
 		return
@@ -383,11 +409,9 @@ func (s *MdsColonyService) ListMdsTaskStatus(ctx *gin.Context) {
 	mdsModels := *ms
 	tasks, rErr := s.ucTask.BuildTaskExecutionInfos(ctx, mdsModels)
 	if rErr != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"构建mds集群任务信息失败",
 			zap.Error(rErr),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, rErr)
 		return
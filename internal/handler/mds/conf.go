@@ -2,6 +2,7 @@ package service
 
 import (
 	"net/http"
+	"os"
 	"path/filepath"
 
 	"github.com/gin-gonic/gin"
@@ -49,11 +50,9 @@ func (s *MdsConfService) UploadMdsConf(ctx *gin.Context) {
 	// 1. 绑定URL路径参数
 	var pathReq mdsmodel.GetMdsConfRequest
 	if err := ctx.ShouldBindUri(&pathReq); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"绑定上传的mds配置文件路径参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
@@ -63,11 +62,9 @@ func (s *MdsConfService) UploadMdsConf(ctx *gin.Context) {
 	// 2. 绑定表单数据（包含文件）
 	var formReq mdsmodel.UploadMdsConfRequest
 	if err := ctx.ShouldBind(&formReq); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"绑定上传的mds配置文件表单参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
@@ -102,11 +99,9 @@ func (s *MdsConfService) UploadMdsConf(ctx *gin.Context) {
 func (s *MdsConfService) DownloadMdsConf(ctx *gin.Context) {
 	var req mdsmodel.DownloadOrDeleteMdsConfRequest
 	if err := ctx.ShouldBindUri(&req); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"绑定删除的mds配置文件路径参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
@@ -138,11 +133,9 @@ func (s *MdsConfService) DownloadMdsConf(ctx *gin.Context) {
 func (s *MdsConfService) DeleteMdsConf(ctx *gin.Context) {
 	var req mdsmodel.DownloadOrDeleteMdsConfRequest
 	if err := ctx.ShouldBindUri(&req); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"绑定删除的mds配置文件路径参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
@@ -152,11 +145,9 @@ func (s *MdsConfService) DeleteMdsConf(ctx *gin.Context) {
 	dirName := common.GetMdsColonyConfigDir(req.ColonyNum)
 	savePath := filepath.Join(dirName, req.DirName, req.Filename)
 	if err := fileutil.Remove(ctx, savePath); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"删除mds配置文件失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.FromError(err)
 		errors.RespondWithError(ctx, rErr)
@@ -181,11 +172,9 @@ func (s *MdsConfService) DeleteMdsConf(ctx *gin.Context) {
 func (s *MdsConfService) ListMdsConf(ctx *gin.Context) {
 	var req mdsmodel.ListMdsConfRequest
 	if err := ctx.ShouldBindUri(&req); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"绑定mds配置文件路径参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
@@ -193,14 +182,21 @@ func (s *MdsConfService) ListMdsConf(ctx *gin.Context) {
 	}
 
 	dirName := common.GetMdsColonyConfigDir(req.ColonyNum)
+	if _, err := os.Stat(dirName); os.IsNotExist(err) {
+		ctxutil.Logger(ctx, s.log).Error(
+			"mds配置文件目录不存在",
+			zap.String("dir_name", dirName),
+		)
+		rErr := errors.ErrDownloadFileNotFound.WithField("colony_num", req.ColonyNum)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
 	info, err := fileutil.ListFileInfo(ctx, dirName)
 	if err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"获取mds配置文件列表失败",
 			zap.Error(err),
 			zap.String("dirname", dirName),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.FromError(err)
 		errors.RespondWithError(ctx, rErr)
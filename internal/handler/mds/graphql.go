@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"go.uber.org/zap"
+
+	commodel "gin-artweb/internal/model/common"
+	mdsmodel "gin-artweb/internal/model/mds"
+	mdssvc "gin-artweb/internal/service/mds"
+	syssvc "gin-artweb/internal/service/system"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+)
+
+type MdsGraphQLHandler struct {
+	log      *zap.Logger
+	ucColony *mdssvc.MdsColonyService
+	ucTask   *mdssvc.MdsTaskExecutionInfoUsecase
+}
+
+func NewMdsGraphQLHandler(
+	logger *zap.Logger,
+	ucColony *mdssvc.MdsColonyService,
+	ucTask *mdssvc.MdsTaskExecutionInfoUsecase,
+) *MdsGraphQLHandler {
+	return &MdsGraphQLHandler{
+		log:      logger,
+		ucColony: ucColony,
+		ucTask:   ucTask,
+	}
+}
+
+// LoadSchema 注册mds集群的对象类型和查询字段, 集群的程序包和mon节点在对应模块启用时才能查询
+func (h *MdsGraphQLHandler) LoadSchema(g *syssvc.GraphQLService) {
+	taskType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "MdsTask",
+		Description: "mds集群日常任务状态",
+		Fields: graphql.Fields{
+			"task_name":    syssvc.GraphQLValue(graphql.String, "任务名称", func(t commodel.TaskInfo) any { return t.TaskName }),
+			"record_id":    syssvc.GraphQLValue(graphql.Int, "执行记录ID, 0表示未执行", func(t commodel.TaskInfo) any { return t.RecordID }),
+			"status":       syssvc.GraphQLValue(graphql.Int, "执行状态", func(t commodel.TaskInfo) any { return t.Status }),
+			"start_time":   syssvc.GraphQLValue(graphql.String, "开始时间", func(t commodel.TaskInfo) any { return t.StartTime }),
+			"end_time":     syssvc.GraphQLValue(graphql.String, "结束时间", func(t commodel.TaskInfo) any { return t.EndTime }),
+			"trigger_type": syssvc.GraphQLValue(graphql.String, "触发类型", func(t commodel.TaskInfo) any { return t.TriggerType }),
+		},
+	})
+	colonyType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "MdsColony",
+		Description: "mds集群",
+		Fields: syssvc.GraphQLFields(func() graphql.Fields {
+			return graphql.Fields{
+				"id":             syssvc.GraphQLValue(graphql.Int, "mds集群ID", func(m *mdsmodel.MdsColonyModel) any { return m.ID }),
+				"colony_num":     syssvc.GraphQLValue(graphql.String, "集群号", func(m *mdsmodel.MdsColonyModel) any { return m.ColonyNum }),
+				"extracted_name": syssvc.GraphQLValue(graphql.String, "解压后名称", func(m *mdsmodel.MdsColonyModel) any { return m.ExtractedName }),
+				"is_enable":      syssvc.GraphQLValue(graphql.Boolean, "是否启用", func(m *mdsmodel.MdsColonyModel) any { return m.IsEnable }),
+				"created_at":     syssvc.GraphQLValue(graphql.String, "创建时间", func(m *mdsmodel.MdsColonyModel) any { return m.CreatedAt.Format(time.DateTime) }),
+				"updated_at":     syssvc.GraphQLValue(graphql.String, "更新时间", func(m *mdsmodel.MdsColonyModel) any { return m.UpdatedAt.Format(time.DateTime) }),
+				"version":        syssvc.GraphQLValue(graphql.Int, "版本号", func(m *mdsmodel.MdsColonyModel) any { return m.Version }),
+				"package":        syssvc.GraphQLRef(g, "Package", "mds程序包", func(m *mdsmodel.MdsColonyModel) uint32 { return m.PackageID }),
+				"mon_node":       syssvc.GraphQLRef(g, "MonNode", "mon节点", func(m *mdsmodel.MdsColonyModel) uint32 { return m.MonNodeID }),
+				"tasks": {
+					Type:        graphql.NewList(taskType),
+					Description: "日常任务状态",
+					Resolve: g.Authorize("GET /api/v1/mds/colony/status", func(p graphql.ResolveParams) (any, error) {
+						m, ok := p.Source.(*mdsmodel.MdsColonyModel)
+						if !ok {
+							return nil, nil
+						}
+						thunk := syssvc.GraphQLLoader(p.Context, "mds_task", h.loadTasks).Load(p.Context, m.ColonyNum)
+						return func() (any, error) { return thunk() }, nil
+					}),
+				},
+			}
+		}),
+	})
+
+	g.RegisterQuery("mds_colonies", "GET /api/v1/mds/colony", &graphql.Field{
+		Type:        graphql.NewList(colonyType),
+		Description: "mds集群列表",
+		Args: syssvc.GraphQLArgs(graphql.FieldConfigArgument{
+			"is_enable": &graphql.ArgumentConfig{Type: graphql.Boolean, Description: "是否启用"},
+		}),
+		Resolve: h.listColony,
+	})
+}
+
+func (h *MdsGraphQLHandler) listColony(p graphql.ResolveParams) (any, error) {
+	page, size, rErr := syssvc.GraphQLPage(p)
+	if rErr != nil {
+		return nil, rErr
+	}
+	query := make(map[string]any)
+	if isEnable, ok := p.Args["is_enable"].(bool); ok {
+		query["is_enable = ?"] = isEnable
+	}
+	qp := database.QueryParams{
+		Size:    size,
+		Page:    page,
+		OrderBy: []string{"colony_num ASC"},
+		Query:   query,
+	}
+	_, ms, rErr := h.ucColony.ListMdsColony(p.Context, qp)
+	if rErr != nil {
+		ctxutil.Logger(p.Context, h.log).Error(
+			"GraphQL查询mds集群列表失败",
+			zap.Error(rErr),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		return nil, rErr
+	}
+	results := make([]*mdsmodel.MdsColonyModel, len(*ms))
+	for i := range *ms {
+		results[i] = &(*ms)[i]
+	}
+	return results, nil
+}
+
+// loadTasks 批量查询集群的日常任务状态, key为集群号
+func (h *MdsGraphQLHandler) loadTasks(
+	ctx context.Context,
+	colonyNums []string,
+) (map[string][]commodel.TaskInfo, error) {
+	ms := make([]mdsmodel.MdsColonyModel, len(colonyNums))
+	for i, colonyNum := range colonyNums {
+		ms[i] = mdsmodel.MdsColonyModel{ColonyNum: colonyNum}
+	}
+	infos, rErr := h.ucTask.BuildTaskExecutionInfos(ctx, ms)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"GraphQL构建mds集群任务信息失败",
+			zap.Error(rErr),
+		)
+		return nil, rErr
+	}
+	// 没有任务标识文件的集群返回的集群号为空, 按顺序对应
+	results := make(map[string][]commodel.TaskInfo, len(colonyNums))
+	for i, info := range *infos {
+		results[colonyNums[i]] = BuildMdsColonyTaskInfo(info).Tasks
+	}
+	return results, nil
+}
@@ -0,0 +1,466 @@
+package service
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	commodel "gin-artweb/internal/model/common"
+	mdsmodel "gin-artweb/internal/model/mds"
+	mdssvc "gin-artweb/internal/service/mds"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+)
+
+type MdsIngestHandler struct {
+	log      *zap.Logger
+	ucIngest *mdssvc.MdsIngestService
+}
+
+func NewMdsIngestHandler(
+	logger *zap.Logger,
+	ucIngest *mdssvc.MdsIngestService,
+) *MdsIngestHandler {
+	return &MdsIngestHandler{
+		log:      logger,
+		ucIngest: ucIngest,
+	}
+}
+
+// @Summary 创建行情数据源
+// @Description 本接口用于创建行情数据源, 设置定时采集表达式后按计划采集每个交易日的行情文件
+// @Tags 行情数据采集
+// @Accept json
+// @Produce json
+// @Param request body mdsmodel.CreateOrUpdateIngestSourceRequest true "创建行情数据源请求"
+// @Success 200 {object} mdsmodel.IngestSourceReply "成功返回行情数据源信息"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/mds/ingest/source [post]
+// @Security ApiKeyAuth
+func (h *MdsIngestHandler) CreateIngestSource(ctx *gin.Context) {
+	var req mdsmodel.CreateOrUpdateIngestSourceRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定创建行情数据源参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := h.ucIngest.CreateIngestSource(ctx, req.ToModel())
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"创建行情数据源失败",
+			zap.Error(rErr),
+			zap.Object(commodel.RequestModelKey, &req),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &mdsmodel.IngestSourceReply{
+		Code: http.StatusOK,
+		Data: mdsmodel.IngestSourceModelToOut(*m),
+	})
+}
+
+// @Summary 更新行情数据源
+// @Description 本接口用于更新指定ID的行情数据源, 并重新注册定时采集任务
+// @Tags 行情数据采集
+// @Accept json
+// @Produce json
+// @Param id path uint true "数据源编号"
+// @Param request body mdsmodel.CreateOrUpdateIngestSourceRequest true "更新行情数据源请求"
+// @Success 200 {object} mdsmodel.IngestSourceReply "成功返回行情数据源信息"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 404 {object} errors.Error "数据源未找到"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/mds/ingest/source/{id} [put]
+// @Security ApiKeyAuth
+func (h *MdsIngestHandler) UpdateIngestSource(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定更新行情数据源ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	var req mdsmodel.CreateOrUpdateIngestSourceRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定更新行情数据源参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := h.ucIngest.UpdateIngestSourceByID(ctx, uri.ID, req.ToModel())
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"更新行情数据源失败",
+			zap.Error(rErr),
+			zap.Uint32(commodel.RequestIDKey, uri.ID),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &mdsmodel.IngestSourceReply{
+		Code: http.StatusOK,
+		Data: mdsmodel.IngestSourceModelToOut(*m),
+	})
+}
+
+// @Summary 删除行情数据源
+// @Description 本接口用于删除指定ID的行情数据源及其采集记录
+// @Tags 行情数据采集
+// @Accept json
+// @Produce json
+// @Param id path uint true "数据源编号"
+// @Success 200 {object} commodel.MapAPIReply "删除成功"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 404 {object} errors.Error "数据源未找到"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/mds/ingest/source/{id} [delete]
+// @Security ApiKeyAuth
+func (h *MdsIngestHandler) DeleteIngestSource(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定删除行情数据源ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	if rErr := h.ucIngest.DeleteIngestSourceByID(ctx, uri.ID); rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"删除行情数据源失败",
+			zap.Error(rErr),
+			zap.Uint32(commodel.RequestIDKey, uri.ID),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(commodel.NoDataReply.Code, commodel.NoDataReply)
+}
+
+// @Summary 查询行情数据源详情
+// @Description 本接口用于查询指定ID的行情数据源详情
+// @Tags 行情数据采集
+// @Accept json
+// @Produce json
+// @Param id path uint true "数据源编号"
+// @Success 200 {object} mdsmodel.IngestSourceReply "成功返回行情数据源信息"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 404 {object} errors.Error "数据源未找到"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/mds/ingest/source/{id} [get]
+// @Security ApiKeyAuth
+func (h *MdsIngestHandler) GetIngestSource(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定查询行情数据源ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := h.ucIngest.FindIngestSourceByID(ctx, uri.ID)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询行情数据源详情失败",
+			zap.Error(rErr),
+			zap.Uint32(commodel.RequestIDKey, uri.ID),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &mdsmodel.IngestSourceReply{
+		Code: http.StatusOK,
+		Data: mdsmodel.IngestSourceModelToOut(*m),
+	})
+}
+
+// @Summary 查询行情数据源列表
+// @Description 本接口用于查询行情数据源列表
+// @Tags 行情数据采集
+// @Accept json
+// @Produce json
+// @Param request query mdsmodel.ListIngestSourceRequest false "查询参数"
+// @Success 200 {object} mdsmodel.PagIngestSourceReply "成功返回行情数据源列表"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/mds/ingest/source [get]
+// @Security ApiKeyAuth
+func (h *MdsIngestHandler) ListIngestSource(ctx *gin.Context) {
+	var req mdsmodel.ListIngestSourceRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定查询行情数据源列表参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	page, size, query := req.Query()
+	qp := database.QueryParams{
+		IsCount:   true,
+		SkipCount: req.SkipCount(),
+		Size:      size,
+		Page:      page,
+		OrderBy:   []string{"id DESC"},
+		Query:     query,
+	}
+	total, ms, rErr := h.ucIngest.ListIngestSource(ctx, qp)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询行情数据源列表失败",
+			zap.Error(rErr),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	mbs := mdsmodel.ListIngestSourceModelToOut(ms)
+	ctx.JSON(http.StatusOK, &mdsmodel.PagIngestSourceReply{
+		Code: http.StatusOK,
+		Data: commodel.NewPag(qp, total, mbs),
+	})
+}
+
+// @Summary 采集行情数据
+// @Description 本接口用于手动采集或重新采集数据源指定交易日的行情文件, 采集在后台进行, 通过采集记录查询结果
+// @Tags 行情数据采集
+// @Accept json
+// @Produce json
+// @Param id path uint true "数据源编号"
+// @Param request body mdsmodel.RunIngestRequest true "采集请求"
+// @Success 202 {object} mdsmodel.IngestRecordReply "成功返回采集中的记录"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 401 {object} errors.Error "未授权"
+// @Failure 404 {object} errors.Error "数据源未找到"
+// @Failure 409 {object} errors.Error "该数据源当日的采集正在进行"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/mds/ingest/source/{id}/run [post]
+// @Security ApiKeyAuth
+func (h *MdsIngestHandler) RunIngest(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定采集行情数据ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	var req mdsmodel.RunIngestRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定采集行情数据参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+	tradingDay, err := time.ParseInLocation(mdsmodel.IngestDateLayout, req.TradingDay, time.Local)
+	if err != nil {
+		errors.RespondWithError(ctx, errors.ErrValidationFailed.WithCause(err))
+		return
+	}
+
+	claims, rErr := ctxutil.GetUserClaims(ctx)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"获取个人登录信息失败",
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := h.ucIngest.RunIngest(ctx, uri.ID, tradingDay, claims.Subject)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"采集行情数据失败",
+			zap.Error(rErr),
+			zap.Uint32(commodel.RequestIDKey, uri.ID),
+			zap.String("trading_day", req.TradingDay),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, &mdsmodel.IngestRecordReply{
+		Code: http.StatusAccepted,
+		Data: mdsmodel.IngestRecordModelToOut(*m),
+	})
+}
+
+// @Summary 查询采集记录详情
+// @Description 本接口用于查询指定ID的采集记录, 包含校验错误和核对结果
+// @Tags 行情数据采集
+// @Accept json
+// @Produce json
+// @Param id path uint true "采集记录编号"
+// @Success 200 {object} mdsmodel.IngestRecordReply "成功返回采集记录"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 404 {object} errors.Error "采集记录未找到"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/mds/ingest/record/{id} [get]
+// @Security ApiKeyAuth
+func (h *MdsIngestHandler) GetIngestRecord(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定查询采集记录ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := h.ucIngest.FindIngestRecordByID(ctx, uri.ID)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询采集记录详情失败",
+			zap.Error(rErr),
+			zap.Uint32(commodel.RequestIDKey, uri.ID),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &mdsmodel.IngestRecordReply{
+		Code: http.StatusOK,
+		Data: mdsmodel.IngestRecordModelToOut(*m),
+	})
+}
+
+// @Summary 查询采集记录列表
+// @Description 本接口用于按数据源、交易日和状态查询采集记录列表
+// @Tags 行情数据采集
+// @Accept json
+// @Produce json
+// @Param request query mdsmodel.ListIngestRecordRequest false "查询参数"
+// @Success 200 {object} mdsmodel.PagIngestRecordReply "成功返回采集记录列表"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/mds/ingest/record [get]
+// @Security ApiKeyAuth
+func (h *MdsIngestHandler) ListIngestRecord(ctx *gin.Context) {
+	var req mdsmodel.ListIngestRecordRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定查询采集记录列表参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	page, size, query := req.Query()
+	qp := database.QueryParams{
+		Preloads:  []string{"Source"},
+		IsCount:   true,
+		SkipCount: req.SkipCount(),
+		Size:      size,
+		Page:      page,
+		OrderBy:   []string{"trading_day DESC", "id DESC"},
+		Query:     query,
+	}
+	total, ms, rErr := h.ucIngest.ListIngestRecord(ctx, qp)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询采集记录列表失败",
+			zap.Error(rErr),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	mbs := mdsmodel.ListIngestRecordModelToOut(ms)
+	ctx.JSON(http.StatusOK, &mdsmodel.PagIngestRecordReply{
+		Code: http.StatusOK,
+		Data: commodel.NewPag(qp, total, mbs),
+	})
+}
+
+// @Summary 查询交易日采集状态
+// @Description 本接口用于查询指定交易日每个启用的数据源的采集状态, 尚未采集的数据源状态为pending
+// @Tags 行情数据采集
+// @Accept json
+// @Produce json
+// @Param trading_day path string true "交易日, 格式为2006-01-02"
+// @Success 200 {object} mdsmodel.IngestDayReply "成功返回交易日采集状态"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/mds/ingest/day/{trading_day} [get]
+// @Security ApiKeyAuth
+func (h *MdsIngestHandler) GetIngestDay(ctx *gin.Context) {
+	var uri mdsmodel.IngestDayRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定查询交易日采集状态参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ms, rErr := h.ucIngest.ListIngestDay(ctx, uri.TradingDay)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询交易日采集状态失败",
+			zap.Error(rErr),
+			zap.String("trading_day", uri.TradingDay),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &mdsmodel.IngestDayReply{
+		Code: http.StatusOK,
+		Data: mdsmodel.ListIngestRecordModelToOut(&ms),
+	})
+}
+
+func (h *MdsIngestHandler) LoadRouter(r *gin.RouterGroup) {
+	r.POST("/ingest/source", h.CreateIngestSource)
+	r.PUT("/ingest/source/:id", h.UpdateIngestSource)
+	r.DELETE("/ingest/source/:id", h.DeleteIngestSource)
+	r.GET("/ingest/source/:id", h.GetIngestSource)
+	r.GET("/ingest/source", h.ListIngestSource)
+	r.POST("/ingest/source/:id/run", h.RunIngest)
+	r.GET("/ingest/record/:id", h.GetIngestRecord)
+	r.GET("/ingest/record", h.ListIngestRecord)
+	r.GET("/ingest/day/:trading_day", h.GetIngestDay)
+}
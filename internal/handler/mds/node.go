@@ -43,11 +43,9 @@ func NewMdsNodeService(
 func (s *MdsNodeService) CreateMdsNode(ctx *gin.Context) {
 	var req mdsmodel.CreateOrUpdateMdsNodeRequest
 	if err := ctx.ShouldBind(&req); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"绑定创建mds节点参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
@@ -63,11 +61,9 @@ func (s *MdsNodeService) CreateMdsNode(ctx *gin.Context) {
 
 	m, rErr := s.ucNode.CreateMdsNode(ctx, colony)
 	if rErr != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"创建mds节点失败",
 			zap.Error(rErr),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, rErr)
 		return
@@ -95,11 +91,9 @@ func (s *MdsNodeService) CreateMdsNode(ctx *gin.Context) {
 func (s *MdsNodeService) UpdateMdsNode(ctx *gin.Context) {
 	var uri commodel.IDUri
 	if err := ctx.ShouldBindUri(&uri); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"绑定更新mds节点ID参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
@@ -108,11 +102,9 @@ func (s *MdsNodeService) UpdateMdsNode(ctx *gin.Context) {
 
 	var req mdsmodel.CreateOrUpdateMdsNodeRequest
 	if err := ctx.ShouldBind(&req); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"绑定更新mds节点参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
@@ -128,12 +120,10 @@ func (s *MdsNodeService) UpdateMdsNode(ctx *gin.Context) {
 
 	m, rErr := s.ucNode.UpdateMdsNodeByID(ctx, uri.ID, data)
 	if rErr != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"更新mds节点失败",
 			zap.Error(rErr),
 			zap.Uint32(commodel.RequestIDKey, uri.ID),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, rErr)
 		return
@@ -160,38 +150,33 @@ func (s *MdsNodeService) UpdateMdsNode(ctx *gin.Context) {
 func (s *MdsNodeService) DeleteMdsNode(ctx *gin.Context) {
 	var uri commodel.IDUri
 	if err := ctx.ShouldBindUri(&uri); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"绑定删除mds节点ID参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始删除mds节点",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	if rErr := s.ucNode.DeleteMdsNodeByID(ctx, uri.ID); rErr != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"删除mds节点失败",
 			zap.Error(rErr),
 			zap.Uint32(commodel.RequestIDKey, uri.ID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"删除mds节点成功",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	ctx.JSON(commodel.NoDataReply.Code, commodel.NoDataReply)
@@ -212,39 +197,34 @@ func (s *MdsNodeService) DeleteMdsNode(ctx *gin.Context) {
 func (s *MdsNodeService) GetMdsNode(ctx *gin.Context) {
 	var uri commodel.IDUri
 	if err := ctx.ShouldBindUri(&uri); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"绑定查询mds节点ID参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始查询mds节点详情",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	m, rErr := s.ucNode.FindMdsNodeByID(ctx, []string{"MdsColony", "Host"}, uri.ID)
 	if rErr != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"查询mds节点详情失败",
 			zap.Error(rErr),
 			zap.Uint32(commodel.RequestIDKey, uri.ID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"查询mds节点详情成功",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	mo := mdsmodel.MdsNodeToDetailOut(*m)
@@ -268,55 +248,48 @@ func (s *MdsNodeService) GetMdsNode(ctx *gin.Context) {
 func (s *MdsNodeService) ListMdsNode(ctx *gin.Context) {
 	var req mdsmodel.ListMdsNodeRequest
 	if err := ctx.ShouldBindQuery(&req); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"绑定查询mds节点列表参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始查询mds节点列表",
-		zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	page, size, query := req.Query()
 	qp := database.QueryParams{
-		Preloads: []string{"MdsColony", "Host"},
-		IsCount:  true,
-		Size:     size,
-		Page:     page,
-		OrderBy:  []string{"id DESC"},
-		Query:    query,
+		Preloads:  []string{"MdsColony", "Host"},
+		IsCount:   true,
+		SkipCount: req.SkipCount(),
+		Size:      size,
+		Page:      page,
+		OrderBy:   []string{"id DESC"},
+		Query:     query,
 	}
 	total, ms, rErr := s.ucNode.ListMdsNode(ctx, qp)
 	if rErr != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"查询mds节点列表失败",
 			zap.Error(rErr),
 			zap.Object(database.QueryParamsKey, &qp),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"查询mds节点列表成功",
-		zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	mbs := mdsmodel.ListMdsNodeToDetailOut(ms)
 	ctx.JSON(http.StatusOK, &mdsmodel.PagMdsNodeReply{
 		Code: http.StatusOK,
-		Data: commodel.NewPag(page, size, total, mbs),
+		Data: commodel.NewPag(qp, total, mbs),
 	})
 }
 
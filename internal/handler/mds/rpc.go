@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	mdsmodel "gin-artweb/internal/model/mds"
+	mdssvc "gin-artweb/internal/service/mds"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/rpc"
+	"gin-artweb/pkg/rpc/artwebv1"
+)
+
+type MdsRPCHandler struct {
+	artwebv1.UnimplementedMdsServiceServer
+	log      *zap.Logger
+	ucColony *mdssvc.MdsColonyService
+	ucTask   *mdssvc.MdsTaskExecutionInfoUsecase
+}
+
+func NewMdsRPCHandler(
+	logger *zap.Logger,
+	ucColony *mdssvc.MdsColonyService,
+	ucTask *mdssvc.MdsTaskExecutionInfoUsecase,
+) *MdsRPCHandler {
+	return &MdsRPCHandler{
+		log:      logger,
+		ucColony: ucColony,
+		ucTask:   ucTask,
+	}
+}
+
+// LoadRPC 注册mds集群的gRPC服务
+func (h *MdsRPCHandler) LoadRPC(s *rpc.Server) {
+	s.Register(&artwebv1.MdsService_ServiceDesc, h, map[string]string{
+		"ListColonies":    "GET /api/v1/mds/colony",
+		"ListColonyTasks": "GET /api/v1/mds/colony/status",
+	})
+}
+
+func (h *MdsRPCHandler) ListColonies(
+	ctx context.Context,
+	req *artwebv1.ListMdsColoniesRequest,
+) (*artwebv1.ListMdsColoniesResponse, error) {
+	page, size, rErr := rpc.Page(req.GetPage(), req.GetSize())
+	if rErr != nil {
+		return nil, rErr
+	}
+	query := make(map[string]any)
+	if req.IsEnable != nil {
+		query["is_enable = ?"] = req.GetIsEnable()
+	}
+	qp := database.QueryParams{
+		Size:    size,
+		Page:    page,
+		IsCount: true,
+		OrderBy: []string{"colony_num ASC"},
+		Query:   query,
+	}
+	total, ms, rErr := h.ucColony.ListMdsColony(ctx, qp)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"gRPC查询mds集群列表失败",
+			zap.Error(rErr),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		return nil, rErr
+	}
+	colonies := make([]*artwebv1.MdsColony, len(*ms))
+	for i, m := range *ms {
+		colonies[i] = &artwebv1.MdsColony{
+			Id:            m.ID,
+			ColonyNum:     m.ColonyNum,
+			ExtractedName: m.ExtractedName,
+			IsEnable:      m.IsEnable,
+			PackageId:     m.PackageID,
+			MonNodeId:     m.MonNodeID,
+			CreatedAt:     m.CreatedAt.Format(time.DateTime),
+			UpdatedAt:     m.UpdatedAt.Format(time.DateTime),
+		}
+	}
+	return &artwebv1.ListMdsColoniesResponse{Total: total, Colonies: colonies}, nil
+}
+
+func (h *MdsRPCHandler) ListColonyTasks(
+	ctx context.Context,
+	req *artwebv1.ListMdsColonyTasksRequest,
+) (*artwebv1.ListColonyTasksResponse, error) {
+	page, size, rErr := rpc.Page(req.GetPage(), req.GetSize())
+	if rErr != nil {
+		return nil, rErr
+	}
+	qp := database.QueryParams{
+		Size:    size,
+		Page:    page,
+		OrderBy: []string{"colony_num ASC"},
+		Query:   map[string]any{"is_enable = ?": true},
+	}
+	_, ms, rErr := h.ucColony.ListMdsColony(ctx, qp)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"gRPC查询mds集群列表失败",
+			zap.Error(rErr),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		return nil, rErr
+	}
+	infos, rErr := h.ucTask.BuildTaskExecutionInfos(ctx, *ms)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"gRPC构建mds集群任务信息失败",
+			zap.Error(rErr),
+		)
+		return nil, rErr
+	}
+	colonies := make([]*artwebv1.ColonyTasks, 0, len(*ms))
+	if infos != nil {
+		for _, info := range *infos {
+			colonies = append(colonies, rpcColonyTasks(BuildMdsColonyTaskInfo(info)))
+		}
+	}
+	return &artwebv1.ListColonyTasksResponse{Colonies: colonies}, nil
+}
+
+func rpcColonyTasks(info mdsmodel.MdsColonyTaskInfo) *artwebv1.ColonyTasks {
+	tasks := make([]*artwebv1.TaskInfo, len(info.Tasks))
+	for i, t := range info.Tasks {
+		tasks[i] = &artwebv1.TaskInfo{
+			TaskName:    t.TaskName,
+			RecordId:    t.RecordID,
+			Status:      int32(t.Status),
+			StartTime:   t.StartTime,
+			EndTime:     t.EndTime,
+			TriggerType: t.TriggerType,
+		}
+	}
+	return &artwebv1.ColonyTasks{ColonyNum: info.ColonyNum, Tasks: tasks}
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"go.uber.org/zap"
+
+	monmodel "gin-artweb/internal/model/mon"
+	monsvc "gin-artweb/internal/service/mon"
+	syssvc "gin-artweb/internal/service/system"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+)
+
+type MonGraphQLHandler struct {
+	log     *zap.Logger
+	svcNode *monsvc.MonNodeService
+}
+
+func NewMonGraphQLHandler(
+	logger *zap.Logger,
+	svcNode *monsvc.MonNodeService,
+) *MonGraphQLHandler {
+	return &MonGraphQLHandler{
+		log:     logger,
+		svcNode: svcNode,
+	}
+}
+
+// LoadSchema 注册mon节点的对象类型和查询字段, 其他模块通过"MonNode"引用, 节点主机在资源模块启用时才能查询
+func (h *MonGraphQLHandler) LoadSchema(g *syssvc.GraphQLService) {
+	nodeType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "MonNode",
+		Description: "mon节点",
+		Fields: syssvc.GraphQLFields(func() graphql.Fields {
+			return graphql.Fields{
+				"id":                syssvc.GraphQLValue(graphql.Int, "mon节点ID", func(m *monmodel.MonNodeModel) any { return m.ID }),
+				"name":              syssvc.GraphQLValue(graphql.String, "名称", func(m *monmodel.MonNodeModel) any { return m.Name }),
+				"deploy_path":       syssvc.GraphQLValue(graphql.String, "部署路径", func(m *monmodel.MonNodeModel) any { return m.DeployPath }),
+				"outport_path":      syssvc.GraphQLValue(graphql.String, "导出路径", func(m *monmodel.MonNodeModel) any { return m.OutportPath }),
+				"java_home":         syssvc.GraphQLValue(graphql.String, "JAVA_HOME", func(m *monmodel.MonNodeModel) any { return m.JavaHome }),
+				"url":               syssvc.GraphQLValue(graphql.String, "URL地址", func(m *monmodel.MonNodeModel) any { return m.URL }),
+				"prom_url":          syssvc.GraphQLValue(graphql.String, "Prometheus地址", func(m *monmodel.MonNodeModel) any { return m.PromURL }),
+				"health":            syssvc.GraphQLValue(graphql.String, "健康状态", func(m *monmodel.MonNodeModel) any { return m.Health }),
+				"health_message":    syssvc.GraphQLValue(graphql.String, "健康检查信息", func(m *monmodel.MonNodeModel) any { return m.HealthMessage }),
+				"last_scrape_at":    syssvc.GraphQLValue(graphql.String, "最近一次采集时间", func(m *monmodel.MonNodeModel) any { return formatTime(m.LastScrapeAt) }),
+				"health_checked_at": syssvc.GraphQLValue(graphql.String, "最近一次健康检查时间", func(m *monmodel.MonNodeModel) any { return formatTime(m.HealthCheckedAt) }),
+				"created_at":        syssvc.GraphQLValue(graphql.String, "创建时间", func(m *monmodel.MonNodeModel) any { return m.CreatedAt.Format(time.DateTime) }),
+				"updated_at":        syssvc.GraphQLValue(graphql.String, "更新时间", func(m *monmodel.MonNodeModel) any { return m.UpdatedAt.Format(time.DateTime) }),
+				"host":              syssvc.GraphQLRef(g, "Host", "主机", func(m *monmodel.MonNodeModel) uint32 { return m.HostID }),
+			}
+		}),
+	})
+
+	g.RegisterObject(nodeType, syssvc.GraphQLLoadByID("mon_node", syssvc.GraphQLByID(
+		h.svcNode.ListMonNode,
+		func(m monmodel.MonNodeModel) uint32 { return m.ID },
+		func(_ context.Context, m monmodel.MonNodeModel) *monmodel.MonNodeModel { return &m },
+	)))
+
+	g.RegisterQuery("mon_nodes", "GET /api/v1/mon/node", &graphql.Field{
+		Type:        graphql.NewList(nodeType),
+		Description: "mon节点列表",
+		Args: syssvc.GraphQLArgs(graphql.FieldConfigArgument{
+			"health": &graphql.ArgumentConfig{Type: graphql.String, Description: "健康状态"},
+		}),
+		Resolve: h.listNode,
+	})
+}
+
+func (h *MonGraphQLHandler) listNode(p graphql.ResolveParams) (any, error) {
+	page, size, rErr := syssvc.GraphQLPage(p)
+	if rErr != nil {
+		return nil, rErr
+	}
+	query := make(map[string]any)
+	if health, ok := p.Args["health"].(string); ok && health != "" {
+		query["health = ?"] = health
+	}
+	qp := database.QueryParams{
+		Size:    size,
+		Page:    page,
+		OrderBy: []string{"id DESC"},
+		Query:   query,
+	}
+	_, ms, rErr := h.svcNode.ListMonNode(p.Context, qp)
+	if rErr != nil {
+		ctxutil.Logger(p.Context, h.log).Error(
+			"GraphQL查询mon节点列表失败",
+			zap.Error(rErr),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		return nil, rErr
+	}
+	results := make([]*monmodel.MonNodeModel, len(*ms))
+	for i := range *ms {
+		results[i] = &(*ms)[i]
+	}
+	return results, nil
+}
+
+// formatTime 未采集或未检查时返回null
+func formatTime(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+	return t.Format(time.DateTime)
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	commodel "gin-artweb/internal/model/common"
+	resomodel "gin-artweb/internal/model/resource"
+	resosvc "gin-artweb/internal/service/resource"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+)
+
+// HostAgentHandler 资源主机的代理在线状态, 主机代理由资源模块注册
+type HostAgentHandler struct {
+	log      *zap.Logger
+	svcAgent *resosvc.HostAgentService
+}
+
+func NewHostAgentHandler(
+	logger *zap.Logger,
+	svcAgent *resosvc.HostAgentService,
+) *HostAgentHandler {
+	return &HostAgentHandler{
+		log:      logger,
+		svcAgent: svcAgent,
+	}
+}
+
+// @Summary 查询主机代理列表
+// @Description 本接口用于查询资源主机的代理上报信息和在线状态, 可按status=offline查询失联的主机
+// @Tags mon主机代理
+// @Accept json
+// @Produce json
+// @Param request query resomodel.ListHostAgentRequest false "查询参数"
+// @Success 200 {object} resomodel.PagHostAgentReply "成功返回主机代理列表"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/mon/host/agent [get]
+// @Security ApiKeyAuth
+func (h *HostAgentHandler) ListHostAgent(ctx *gin.Context) {
+	var req resomodel.ListHostAgentRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定查询主机代理列表参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	page, size, query := req.Query()
+	qp := database.QueryParams{
+		Preloads:  []string{"Host"},
+		IsCount:   true,
+		SkipCount: req.SkipCount(),
+		Size:      size,
+		Page:      page,
+		OrderBy:   []string{"id DESC"},
+		Query:     query,
+	}
+	total, ms, rErr := h.svcAgent.ListHostAgent(ctx, qp)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询主机代理列表失败",
+			zap.Error(rErr),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	mbs := resomodel.ListHostAgentToOut(ms)
+	ctx.JSON(http.StatusOK, &resomodel.PagHostAgentReply{
+		Code: http.StatusOK,
+		Data: commodel.NewPag(qp, total, mbs),
+	})
+}
+
+func (h *HostAgentHandler) LoadRouter(r *gin.RouterGroup) {
+	r.GET("/host/agent", h.ListHostAgent)
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	commodel "gin-artweb/internal/model/common"
+	monmodel "gin-artweb/internal/model/mon"
+	monsvc "gin-artweb/internal/service/mon"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/errors"
+)
+
+type HostMetricHandler struct {
+	log       *zap.Logger
+	svcMetric *monsvc.HostMetricService
+}
+
+func NewHostMetricHandler(
+	logger *zap.Logger,
+	svcMetric *monsvc.HostMetricService,
+) *HostMetricHandler {
+	return &HostMetricHandler{
+		log:       logger,
+		svcMetric: svcMetric,
+	}
+}
+
+// @Summary 查询mon节点主机指标
+// @Description 本接口用于查询mon节点所在主机的CPU、内存、磁盘使用率和平均负载, 用于绘制图表; 指标由主机代理上报或通过SSH采集, 不需要配置Prometheus数据源
+// @Tags mon节点管理
+// @Accept json
+// @Produce json
+// @Param id path uint true "mon节点编号"
+// @Param request query monmodel.QueryHostMetricRequest false "查询参数"
+// @Success 200 {object} monmodel.HostMetricSeriesReply "成功返回主机指标"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 404 {object} errors.Error "mon节点未找到"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/mon/node/{id}/metrics [get]
+// @Security ApiKeyAuth
+func (h *HostMetricHandler) QueryNodeMetrics(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定查询mon节点主机指标ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	var req monmodel.QueryHostMetricRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定查询mon节点主机指标参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	data, rErr := h.svcMetric.QueryNodeMetrics(ctx, uri.ID, req.Range)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询mon节点主机指标失败",
+			zap.Error(rErr),
+			zap.Uint32(commodel.RequestIDKey, uri.ID),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &monmodel.HostMetricSeriesReply{
+		Code: http.StatusOK,
+		Data: *data,
+	})
+}
+
+func (h *HostMetricHandler) LoadRouter(r *gin.RouterGroup) {
+	r.GET("/node/:id/metrics", h.QueryNodeMetrics)
+}
@@ -43,11 +43,9 @@ func NewNodeHandler(
 func (h *NodeHandler) CreateMonNode(ctx *gin.Context) {
 	var req monmodel.CreateOrUpdateMonNodeRequest
 	if err := ctx.ShouldBind(&req); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定创建mon节点参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
@@ -55,21 +53,23 @@ func (h *NodeHandler) CreateMonNode(ctx *gin.Context) {
 	}
 
 	node := monmodel.MonNodeModel{
-		Name:        req.Name,
-		DeployPath:  req.DeployPath,
-		OutportPath: req.OutportPath,
-		JavaHome:    req.JavaHome,
-		URL:         req.URL,
-		HostID:      req.HostID,
+		Name:            req.Name,
+		DeployPath:      req.DeployPath,
+		OutportPath:     req.OutportPath,
+		JavaHome:        req.JavaHome,
+		URL:             req.URL,
+		HostID:          req.HostID,
+		PromURL:         req.PromURL,
+		PromUsername:    req.PromUsername,
+		PromPassword:    req.PromPassword,
+		PromBearerToken: req.PromBearerToken,
 	}
 
 	m, rErr := h.svcNode.CreateMonNode(ctx, node)
 	if rErr != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"创建mon节点失败",
 			zap.Error(rErr),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, rErr)
 		return
@@ -97,11 +97,9 @@ func (h *NodeHandler) CreateMonNode(ctx *gin.Context) {
 func (h *NodeHandler) UpdateMonNode(ctx *gin.Context) {
 	var uri commodel.IDUri
 	if err := ctx.ShouldBindUri(&uri); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定更新mon节点ID参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
@@ -110,11 +108,9 @@ func (h *NodeHandler) UpdateMonNode(ctx *gin.Context) {
 
 	var req monmodel.CreateOrUpdateMonNodeRequest
 	if err := ctx.ShouldBind(&req); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定更新mon节点参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
@@ -122,22 +118,29 @@ func (h *NodeHandler) UpdateMonNode(ctx *gin.Context) {
 	}
 
 	data := map[string]any{
-		"name":         req.Name,
-		"deploy_path":  req.DeployPath,
-		"outport_path": req.OutportPath,
-		"java_home":    req.JavaHome,
-		"url":          req.URL,
-		"host_id":      req.HostID,
+		"name":          req.Name,
+		"deploy_path":   req.DeployPath,
+		"outport_path":  req.OutportPath,
+		"java_home":     req.JavaHome,
+		"url":           req.URL,
+		"host_id":       req.HostID,
+		"prom_url":      req.PromURL,
+		"prom_username": req.PromUsername,
+	}
+	// 认证信息不会返回给前端, 为空时保留原值
+	if req.PromPassword != "" {
+		data["prom_password"] = req.PromPassword
+	}
+	if req.PromBearerToken != "" {
+		data["prom_bearer_token"] = req.PromBearerToken
 	}
 
 	m, rErr := h.svcNode.UpdateMonNodeByID(ctx, uri.ID, data)
 	if rErr != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"更新mon节点失败",
 			zap.Error(rErr),
 			zap.Uint32(commodel.RequestIDKey, uri.ID),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, rErr)
 		return
@@ -164,38 +167,33 @@ func (h *NodeHandler) UpdateMonNode(ctx *gin.Context) {
 func (h *NodeHandler) DeleteMonNode(ctx *gin.Context) {
 	var uri commodel.IDUri
 	if err := ctx.ShouldBindUri(&uri); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定删除mon节点ID参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"开始删除mon节点",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	if rErr := h.svcNode.DeleteMonNodeByID(ctx, uri.ID); rErr != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"删除mon节点失败",
 			zap.Error(rErr),
 			zap.Uint32(commodel.RequestIDKey, uri.ID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"删除mon节点成功",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	ctx.JSON(commodel.NoDataReply.Code, commodel.NoDataReply)
@@ -216,39 +214,34 @@ func (h *NodeHandler) DeleteMonNode(ctx *gin.Context) {
 func (h *NodeHandler) GetMonNode(ctx *gin.Context) {
 	var uri commodel.IDUri
 	if err := ctx.ShouldBindUri(&uri); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定查询mon节点ID参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"开始查询mon节点详情",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	m, rErr := h.svcNode.FindMonNodeByID(ctx, []string{"Host"}, uri.ID)
 	if rErr != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"查询mon节点详情失败",
 			zap.Error(rErr),
 			zap.Uint32(commodel.RequestIDKey, uri.ID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"查询mon节点详情成功",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	mo := monmodel.MonNodeToDetailOut(*m)
@@ -272,55 +265,48 @@ func (h *NodeHandler) GetMonNode(ctx *gin.Context) {
 func (h *NodeHandler) ListMonNode(ctx *gin.Context) {
 	var req monmodel.ListMonNodeRequest
 	if err := ctx.ShouldBindQuery(&req); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定查询mon节点列表参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"开始查询mon节点列表",
-		zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	page, size, query := req.Query()
 	qp := database.QueryParams{
-		Preloads: []string{"Host"},
-		IsCount:  true,
-		Size:     size,
-		Page:     page,
-		OrderBy:  []string{"id DESC"},
-		Query:    query,
+		Preloads:  []string{"Host"},
+		IsCount:   true,
+		SkipCount: req.SkipCount(),
+		Size:      size,
+		Page:      page,
+		OrderBy:   []string{"id DESC"},
+		Query:     query,
 	}
 	total, ms, rErr := h.svcNode.ListMonNode(ctx, qp)
 	if rErr != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"查询mon节点列表失败",
 			zap.Error(rErr),
 			zap.Object(database.QueryParamsKey, &qp),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"查询mon节点列表成功",
-		zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	mbs := monmodel.ListMonNodeToDetailOut(ms)
 	ctx.JSON(http.StatusOK, &monmodel.PagMonNodeReply{
 		Code: http.StatusOK,
-		Data: commodel.NewPag(page, size, total, mbs),
+		Data: commodel.NewPag(qp, total, mbs),
 	})
 }
 
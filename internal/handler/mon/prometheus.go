@@ -0,0 +1,87 @@
+package service
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	commodel "gin-artweb/internal/model/common"
+	monmodel "gin-artweb/internal/model/mon"
+	monsvc "gin-artweb/internal/service/mon"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/errors"
+)
+
+type PromHandler struct {
+	log     *zap.Logger
+	svcProm *monsvc.MonPromService
+}
+
+func NewPromHandler(
+	logger *zap.Logger,
+	svcProm *monsvc.MonPromService,
+) *PromHandler {
+	return &PromHandler{
+		log:     logger,
+		svcProm: svcProm,
+	}
+}
+
+// @Summary 查询mon节点监控数据
+// @Description 本接口通过mon节点配置的Prometheus数据源执行PromQL查询，提供start、end、step时执行范围查询
+// @Tags mon节点管理
+// @Accept json
+// @Produce json
+// @Param id path uint true "mon节点编号"
+// @Param request query monmodel.QueryMonNodeRequest true "查询参数"
+// @Success 200 {object} monmodel.MonNodeQueryReply "成功返回Prometheus查询结果"
+// @Failure 400 {object} errors.Error "请求参数错误或未配置数据源"
+// @Failure 404 {object} errors.Error "mon节点未找到"
+// @Failure 502 {object} errors.Error "Prometheus查询失败"
+// @Failure 503 {object} errors.Error "数据源熔断中"
+// @Router /api/v1/mon/node/{id}/query [get]
+// @Security ApiKeyAuth
+func (h *PromHandler) QueryMonNode(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定查询mon节点监控数据ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	var req monmodel.QueryMonNodeRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定查询mon节点监控数据参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	data, rErr := h.svcProm.QueryNode(ctx, uri.ID, req)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询mon节点监控数据失败",
+			zap.Error(rErr),
+			zap.Uint32(commodel.RequestIDKey, uri.ID),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &monmodel.MonNodeQueryReply{
+		Code: http.StatusOK,
+		Data: data,
+	})
+}
+
+func (h *PromHandler) LoadRouter(r *gin.RouterGroup) {
+	r.GET("/node/:id/query", h.QueryMonNode)
+}
@@ -10,8 +10,8 @@ import (
 	commodel "gin-artweb/internal/model/common"
 	jobsmodel "gin-artweb/internal/model/jobs"
 	oesmodel "gin-artweb/internal/model/oes"
-	biz "gin-artweb/internal/service/oes"
 	oessvc "gin-artweb/internal/service/oes"
+	"gin-artweb/internal/shared/common"
 	"gin-artweb/internal/shared/ctxutil"
 	"gin-artweb/internal/shared/database"
 	"gin-artweb/internal/shared/errors"
@@ -20,24 +20,21 @@ import (
 type OesColonyService struct {
 	log      *zap.Logger
 	ucColony *oessvc.OesColonyService
-	ucStk    *oessvc.StkTaskExecutionInfoUsecase
-	ucCrd    *oessvc.CrdTaskExecutionInfoUsecase
-	ucOpt    *oessvc.OptTaskExecutionInfoUsecase
+	ucNode   *oessvc.OesNodeService
+	ucTask   *oessvc.ColonyTaskExecutionInfoUsecase
 }
 
 func NewOesColonyService(
 	logger *zap.Logger,
 	ucColony *oessvc.OesColonyService,
-	ucStk *oessvc.StkTaskExecutionInfoUsecase,
-	ucCrd *oessvc.CrdTaskExecutionInfoUsecase,
-	ucOpt *oessvc.OptTaskExecutionInfoUsecase,
+	ucNode *oessvc.OesNodeService,
+	ucTask *oessvc.ColonyTaskExecutionInfoUsecase,
 ) *OesColonyService {
 	return &OesColonyService{
 		log:      logger,
 		ucColony: ucColony,
-		ucStk:    ucStk,
-		ucCrd:    ucCrd,
-		ucOpt:    ucOpt,
+		ucNode:   ucNode,
+		ucTask:   ucTask,
 	}
 }
 
@@ -55,11 +52,9 @@ func NewOesColonyService(
 func (s *OesColonyService) CreateOesColony(ctx *gin.Context) {
 	var req oesmodel.CreateOrUpdateOesColonyRequest
 	if err := ctx.ShouldBind(&req); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"绑定创建oes集群参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
@@ -78,11 +73,9 @@ func (s *OesColonyService) CreateOesColony(ctx *gin.Context) {
 
 	m, rErr := s.ucColony.CreateOesColony(ctx, colony)
 	if rErr != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"创建oes集群失败",
 			zap.Error(rErr),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, rErr)
 		return
@@ -101,20 +94,21 @@ func (s *OesColonyService) CreateOesColony(ctx *gin.Context) {
 // @Produce json
 // @Param id path uint true "oes集群编号"
 // @Param request body oesmodel.CreateOrUpdateOesColonyRequest true "更新oes集群请求"
+// @Param If-Match header string false "版本号, 未提供时使用请求体中的version"
 // @Success 200 {object} oesmodel.OesColonyReply "成功返回oes集群信息"
 // @Failure 400 {object} errors.Error "请求参数错误"
 // @Failure 404 {object} errors.Error "oes集群未找到"
+// @Failure 409 {object} errors.Error "oes集群已被其他用户修改, data.current为当前数据"
+// @Failure 428 {object} errors.Error "缺少版本号"
 // @Failure 500 {object} errors.Error "服务器内部错误"
 // @Router /api/v1/oes/colony/{id} [put]
 // @Security ApiKeyAuth
 func (s *OesColonyService) UpdateOesColony(ctx *gin.Context) {
 	var uri commodel.IDUri
 	if err := ctx.ShouldBindUri(&uri); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"绑定更新oes集群ID参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
@@ -123,35 +117,38 @@ func (s *OesColonyService) UpdateOesColony(ctx *gin.Context) {
 
 	var req oesmodel.CreateOrUpdateOesColonyRequest
 	if err := ctx.ShouldBind(&req); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"绑定更新oes集群参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
+	version, ok := commodel.RequestVersion(ctx.GetHeader(commodel.IfMatchHeader), req.Version)
+	if !ok {
+		errors.RespondWithError(ctx, errors.ErrVersionRequired)
+		return
+	}
+
 	data := map[string]any{
-		"system_type":    req.SystemType,
-		"colony_num":     req.ColonyNum,
-		"extracted_name": req.ExtractedName,
-		"is_enable":      req.IsEnable,
-		"package_id":     req.PackageID,
-		"xcounter_id":    req.XCounterID,
-		"mon_node_id":    req.MonNodeID,
+		"system_type":       req.SystemType,
+		"colony_num":        req.ColonyNum,
+		"extracted_name":    req.ExtractedName,
+		"is_enable":         req.IsEnable,
+		"package_id":        req.PackageID,
+		"xcounter_id":       req.XCounterID,
+		"mon_node_id":       req.MonNodeID,
+		database.VersionKey: version,
 	}
 
 	m, rErr := s.ucColony.UpdateOesColonyByID(ctx, uri.ID, data)
 	if rErr != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"更新oes集群失败",
 			zap.Error(rErr),
 			zap.Uint32(commodel.RequestIDKey, uri.ID),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, rErr)
 		return
@@ -163,6 +160,62 @@ func (s *OesColonyService) UpdateOesColony(ctx *gin.Context) {
 	})
 }
 
+// @Summary 批量更新oes集群
+// @Description 本接口用于在同一个事务中批量更新oes集群的启用状态、程序包或mon节点，返回每个集群的执行结果并记录审计日志
+// @Tags oes集群管理
+// @Accept json
+// @Produce json
+// @Param request body oesmodel.BatchUpdateOesColonyRequest true "批量更新oes集群请求"
+// @Success 200 {object} oesmodel.BatchOesColonyReply "成功返回每个集群的执行结果"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/oes/colony/batch [patch]
+// @Security ApiKeyAuth
+func (s *OesColonyService) BatchUpdateOesColony(ctx *gin.Context) {
+	var req oesmodel.BatchUpdateOesColonyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"绑定批量更新oes集群参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	data := req.ToMap()
+	if len(data) == 0 {
+		rErr := errors.ErrValidationFailed.WithField("reason", "至少需要提供一个更新字段")
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	claims, rErr := ctxutil.GetUserClaims(ctx)
+	if rErr != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"获取个人登录信息失败",
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	results, rErr := s.ucColony.BatchUpdateOesColony(ctx, req.IDs, data, claims.Username)
+	if rErr != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"批量更新oes集群失败",
+			zap.Error(rErr),
+			zap.Uint32s("ids", req.IDs),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &oesmodel.BatchOesColonyReply{
+		Code: http.StatusOK,
+		Data: results,
+	})
+}
+
 // @Summary 删除oes集群
 // @Description 本接口用于删除指定ID的oes集群
 // @Tags oes集群管理
@@ -178,39 +231,34 @@ func (s *OesColonyService) UpdateOesColony(ctx *gin.Context) {
 func (s *OesColonyService) DeleteOesColony(ctx *gin.Context) {
 	var uri commodel.IDUri
 	if err := ctx.ShouldBindUri(&uri); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"绑定删除oes集群ID参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始删除oes集群",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	rErr := s.ucColony.DeleteOesColonyByID(ctx, uri.ID)
 	if rErr != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"删除oes集群失败",
 			zap.Error(rErr),
 			zap.Uint32(commodel.RequestIDKey, uri.ID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"删除oes集群成功",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	ctx.JSON(commodel.NoDataReply.Code, commodel.NoDataReply)
@@ -231,39 +279,34 @@ func (s *OesColonyService) DeleteOesColony(ctx *gin.Context) {
 func (s *OesColonyService) GetOesColony(ctx *gin.Context) {
 	var uri commodel.IDUri
 	if err := ctx.ShouldBindUri(&uri); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"绑定查询oes集群ID参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始查询oes集群详情",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	m, rErr := s.ucColony.FindOesColonyByID(ctx, []string{"Package", "XCounter", "MonNode"}, uri.ID)
 	if rErr != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"查询oes集群详情失败",
 			zap.Error(rErr),
 			zap.Uint32(commodel.RequestIDKey, uri.ID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"查询oes集群详情成功",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	mo := oesmodel.OesColonyToDetailOut(*m)
@@ -273,8 +316,37 @@ func (s *OesColonyService) GetOesColony(ctx *gin.Context) {
 	})
 }
 
+// oesColonyExportColumns oes集群列表导出的列
+var oesColonyExportColumns = []common.ExportColumn[oesmodel.OesColonyDetailOut]{
+	{Key: "id", Header: "ID", Value: func(o oesmodel.OesColonyDetailOut) any { return o.ID }},
+	{Key: "system_type", Header: "系统类型", Value: func(o oesmodel.OesColonyDetailOut) any { return o.SystemType }},
+	{Key: "colony_num", Header: "集群号", Value: func(o oesmodel.OesColonyDetailOut) any { return o.ColonyNum }},
+	{Key: "extracted_name", Header: "解压后名称", Value: func(o oesmodel.OesColonyDetailOut) any { return o.ExtractedName }},
+	{Key: "is_enable", Header: "是否启用", Value: func(o oesmodel.OesColonyDetailOut) any { return o.IsEnable }},
+	{Key: "package", Header: "程序包", Value: func(o oesmodel.OesColonyDetailOut) any {
+		if o.Package == nil {
+			return ""
+		}
+		return o.Package.Filename + " " + o.Package.Version
+	}},
+	{Key: "xcounter", Header: "xcounter程序包", Value: func(o oesmodel.OesColonyDetailOut) any {
+		if o.XCounter == nil {
+			return ""
+		}
+		return o.XCounter.Filename + " " + o.XCounter.Version
+	}},
+	{Key: "mon_node", Header: "mon节点", Value: func(o oesmodel.OesColonyDetailOut) any {
+		if o.MonNode == nil {
+			return ""
+		}
+		return o.MonNode.Name
+	}},
+	{Key: "created_at", Header: "创建时间", Value: func(o oesmodel.OesColonyDetailOut) any { return o.CreatedAt }},
+	{Key: "updated_at", Header: "更新时间", Value: func(o oesmodel.OesColonyDetailOut) any { return o.UpdatedAt }},
+}
+
 // @Summary 查询oes集群列表
-// @Description 本接口用于查询oes集群列表
+// @Description 本接口用于查询oes集群列表, 指定export参数时按columns选择的列导出为csv或xlsx文件
 // @Tags oes集群管理
 // @Accept json
 // @Produce json
@@ -287,55 +359,70 @@ func (s *OesColonyService) GetOesColony(ctx *gin.Context) {
 func (s *OesColonyService) ListOesColony(ctx *gin.Context) {
 	var req oesmodel.ListOesColonyRequest
 	if err := ctx.ShouldBindQuery(&req); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"绑定查询oes集群列表参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始查询oes集群列表",
-		zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	page, size, query := req.Query()
 	qp := database.QueryParams{
-		Preloads: []string{"Package", "XCounter", "MonNode"},
-		IsCount:  true,
-		Size:     size,
-		Page:     page,
-		OrderBy:  []string{"id DESC"},
-		Query:    query,
+		Preloads:  []string{"Package", "XCounter", "MonNode"},
+		IsCount:   true,
+		SkipCount: req.SkipCount(),
+		Size:      size,
+		Page:      page,
+		OrderBy:   []string{"id DESC"},
+		Query:     query,
+	}
+
+	if req.Export != "" {
+		rErr := common.ExportList(ctx, s.log, "oes集群", req.Export, req.Columns, oesColonyExportColumns,
+			func(page, size int) ([]oesmodel.OesColonyDetailOut, *errors.Error) {
+				qp.Page, qp.Size, qp.IsCount = page, size, false
+				_, ms, rErr := s.ucColony.ListOesColony(ctx, qp)
+				if rErr != nil {
+					return nil, rErr
+				}
+				return *oesmodel.ListOesColonyToDetailOut(ms), nil
+			},
+		)
+		if rErr != nil {
+			ctxutil.Logger(ctx, s.log).Error(
+				"导出oes集群列表失败",
+				zap.Error(rErr),
+				zap.Object(database.QueryParamsKey, &qp),
+			)
+			errors.RespondWithError(ctx, rErr)
+		}
+		return
 	}
 	total, ms, err := s.ucColony.ListOesColony(ctx, qp)
 	if err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"查询oes集群列表失败",
 			zap.Error(err),
 			zap.Object(database.QueryParamsKey, &qp),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, err)
 		return
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"查询oes集群列表成功",
-		zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	mbs := oesmodel.ListOesColonyToDetailOut(ms)
 	ctx.JSON(http.StatusOK, &oesmodel.PagOesColonyReply{
 		Code: http.StatusOK,
-		Data: commodel.NewPag(page, size, total, mbs),
+		Data: commodel.NewPag(qp, total, mbs),
 	})
 }
 
@@ -353,11 +440,9 @@ func (s *OesColonyService) ListOesColony(ctx *gin.Context) {
 func (s *OesColonyService) ListStkTaskStatus(ctx *gin.Context) {
 	var req oesmodel.ListOesColonyRequest
 	if err := ctx.ShouldBindQuery(&req); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"绑定查询oes集群列表参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
@@ -378,24 +463,20 @@ func (s *OesColonyService) ListStkTaskStatus(ctx *gin.Context) {
 
 	_, ms, err := s.ucColony.ListOesColony(ctx, qp)
 	if err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"查询oes现货集群列表失败",
 			zap.Error(err),
 			zap.Object(database.QueryParamsKey, &qp),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, err)
 		return
 	}
 
-	tasks, rErr := s.ucStk.BuildTaskExecutionInfos(ctx, *ms)
+	tasks, rErr := s.ucTask.BuildTaskExecutionInfos(ctx, "STK", *ms)
 	if rErr != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"构建oes现货集群任务信息失败",
 			zap.Error(rErr),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, rErr)
 		return
@@ -411,7 +492,7 @@ func (s *OesColonyService) ListStkTaskStatus(ctx *gin.Context) {
 	infos := *tasks
 	results := make([]oesmodel.OesColonyTaskInfo, len(infos))
 	for i, info := range infos {
-		results[i] = BuildStkColonyTaskInfo(info)
+		results[i] = BuildColonyTaskInfo(info)
 	}
 
 	ctx.JSON(http.StatusOK, &oesmodel.ListOesTasksInfoReply{
@@ -434,11 +515,9 @@ func (s *OesColonyService) ListStkTaskStatus(ctx *gin.Context) {
 func (s *OesColonyService) ListCrdTaskStatus(ctx *gin.Context) {
 	var req oesmodel.ListOesColonyRequest
 	if err := ctx.ShouldBindQuery(&req); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"绑定查询oes集群列表参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
@@ -459,24 +538,20 @@ func (s *OesColonyService) ListCrdTaskStatus(ctx *gin.Context) {
 
 	_, ms, err := s.ucColony.ListOesColony(ctx, qp)
 	if err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"查询oes两融集群列表失败",
 			zap.Error(err),
 			zap.Object(database.QueryParamsKey, &qp),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, err)
 		return
 	}
 
-	tasks, rErr := s.ucCrd.BuildTaskExecutionInfos(ctx, *ms)
+	tasks, rErr := s.ucTask.BuildTaskExecutionInfos(ctx, "CRD", *ms)
 	if rErr != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"构建oes两融集群任务信息失败",
 			zap.Error(rErr),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, rErr)
 		return
@@ -492,7 +567,7 @@ func (s *OesColonyService) ListCrdTaskStatus(ctx *gin.Context) {
 	infos := *tasks
 	results := make([]oesmodel.OesColonyTaskInfo, len(infos))
 	for i, info := range infos {
-		results[i] = BuildCrdColonyTaskInfo(info)
+		results[i] = BuildColonyTaskInfo(info)
 	}
 
 	ctx.JSON(http.StatusOK, &oesmodel.ListOesTasksInfoReply{
@@ -515,11 +590,9 @@ func (s *OesColonyService) ListCrdTaskStatus(ctx *gin.Context) {
 func (s *OesColonyService) ListOptTaskStatus(ctx *gin.Context) {
 	var req oesmodel.ListOesColonyRequest
 	if err := ctx.ShouldBindQuery(&req); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"绑定查询oes集群列表参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
@@ -540,24 +613,20 @@ func (s *OesColonyService) ListOptTaskStatus(ctx *gin.Context) {
 
 	_, ms, err := s.ucColony.ListOesColony(ctx, qp)
 	if err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"查询oes期权集群列表失败",
 			zap.Error(err),
 			zap.Object(database.QueryParamsKey, &qp),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, err)
 		return
 	}
 
-	tasks, rErr := s.ucOpt.BuildTaskExecutionInfos(ctx, *ms)
+	tasks, rErr := s.ucTask.BuildTaskExecutionInfos(ctx, "OPT", *ms)
 	if rErr != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"构建oes期权集群任务信息失败",
 			zap.Error(rErr),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, rErr)
 		return
@@ -573,7 +642,7 @@ func (s *OesColonyService) ListOptTaskStatus(ctx *gin.Context) {
 	infos := *tasks
 	results := make([]oesmodel.OesColonyTaskInfo, len(infos))
 	for i, info := range infos {
-		results[i] = BuildOptColonyTaskInfo(info)
+		results[i] = BuildColonyTaskInfo(info)
 	}
 
 	ctx.JSON(http.StatusOK, &oesmodel.ListOesTasksInfoReply{
@@ -582,55 +651,117 @@ func (s *OesColonyService) ListOptTaskStatus(ctx *gin.Context) {
 	})
 }
 
+// @Summary 查询oes集群拓扑图
+// @Description 本接口用于一次性查询oes集群及其程序包、xcounter、mon节点、oes节点、主机和最近任务状态，以节点和连线的形式返回
+// @Tags oes集群管理
+// @Accept json
+// @Produce json
+// @Param id path uint true "oes集群编号"
+// @Success 200 {object} oesmodel.OesColonyTopologyReply "成功返回oes集群拓扑图"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 404 {object} errors.Error "oes集群未找到"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/oes/colony/{id}/topology [get]
+// @Security ApiKeyAuth
+func (s *OesColonyService) GetOesColonyTopology(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"绑定查询oes集群拓扑图ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始查询oes集群拓扑图",
+		zap.Uint32(commodel.RequestIDKey, uri.ID),
+	)
+
+	m, rErr := s.ucColony.FindOesColonyByID(ctx, []string{"Package", "XCounter", "MonNode", "MonNode.Host"}, uri.ID)
+	if rErr != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询oes集群详情失败",
+			zap.Error(rErr),
+			zap.Uint32(commodel.RequestIDKey, uri.ID),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	qp := database.QueryParams{
+		Preloads: []string{"Host"},
+		OrderBy:  []string{"id ASC"},
+		Query:    map[string]any{"oes_colony_id = ?": m.ID},
+	}
+	_, nodes, rErr := s.ucNode.ListOesNode(ctx, qp)
+	if rErr != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询oes集群节点列表失败",
+			zap.Error(rErr),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	tasks, rErr := s.buildColonyTasks(ctx, *m)
+	if rErr != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"构建oes集群任务信息失败",
+			zap.Error(rErr),
+			zap.Uint32(commodel.RequestIDKey, uri.ID),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"查询oes集群拓扑图成功",
+		zap.Uint32(commodel.RequestIDKey, uri.ID),
+	)
+
+	ctx.JSON(http.StatusOK, &oesmodel.OesColonyTopologyReply{
+		Code: http.StatusOK,
+		Data: *oesmodel.BuildOesColonyTopology(*m, *nodes, tasks),
+	})
+}
+
+// buildColonyTasks 按任务目录获取单个集群的最近任务状态
+func (s *OesColonyService) buildColonyTasks(
+	ctx *gin.Context,
+	m oesmodel.OesColonyModel,
+) ([]commodel.TaskInfo, *errors.Error) {
+	infos, rErr := s.ucTask.BuildTaskExecutionInfos(ctx, m.SystemType, []oesmodel.OesColonyModel{m})
+	if rErr != nil || infos == nil || len(*infos) == 0 {
+		return nil, rErr
+	}
+	return BuildColonyTaskInfo((*infos)[0]).Tasks, nil
+}
+
 func (s *OesColonyService) LoadRouter(r *gin.RouterGroup) {
 	r.POST("/colony", s.CreateOesColony)
 	r.PUT("/colony/:id", s.UpdateOesColony)
+	r.PATCH("/colony/batch", s.BatchUpdateOesColony)
 	r.DELETE("/colony/:id", s.DeleteOesColony)
 	r.GET("/colony/:id", s.GetOesColony)
+	r.GET("/colony/:id/topology", s.GetOesColonyTopology)
 	r.GET("/colony", s.ListOesColony)
 	r.GET("/colony/status/stk", s.ListStkTaskStatus)
 	r.GET("/colony/status/crd", s.ListCrdTaskStatus)
 	r.GET("/colony/status/opt", s.ListOptTaskStatus)
 }
 
-func BuildStkColonyTaskInfo(t oessvc.StkTaskExecutionInfo) oesmodel.OesColonyTaskInfo {
-	mon := BuildTaskInfoFromScriptRecord("mon", t.Mon)
-	conterFetch := BuildTaskInfoFromScriptRecord("counter_fetch", t.CounterFetch)
-	counterDistribute := BuildTaskInfoFromScriptRecord("counter_distribute", t.CounterDistribute)
-	bse := BuildTaskInfoFromScriptRecord("bse", t.Bse)
-	sse := BuildTaskInfoFromScriptRecord("sse", t.Sse)
-	szse := BuildTaskInfoFromScriptRecord("szse", t.Szse)
-	csdc := BuildTaskInfoFromScriptRecord("csdc", t.Csdc)
-	return oesmodel.OesColonyTaskInfo{
-		ColonyNum: t.ColonyNum,
-		Tasks:     []commodel.TaskInfo{mon, conterFetch, counterDistribute, bse, sse, szse, csdc},
+func BuildColonyTaskInfo(t oessvc.ColonyTaskExecutionInfo) oesmodel.OesColonyTaskInfo {
+	tasks := make([]commodel.TaskInfo, len(t.Tasks))
+	for i, task := range t.Tasks {
+		tasks[i] = BuildTaskInfoFromScriptRecord(task.TaskName, task.Record)
 	}
-}
-
-func BuildCrdColonyTaskInfo(t biz.CrdTaskExecutionInfo) oesmodel.OesColonyTaskInfo {
-	mon := BuildTaskInfoFromScriptRecord("mon", t.Mon)
-	conterFetch := BuildTaskInfoFromScriptRecord("counter_fetch", t.CounterFetch)
-	counterDistribute := BuildTaskInfoFromScriptRecord("counter_distribute", t.CounterDistribute)
-	sse := BuildTaskInfoFromScriptRecord("sse", t.Sse)
-	szse := BuildTaskInfoFromScriptRecord("szse", t.Szse)
-	csdc := BuildTaskInfoFromScriptRecord("csdc", t.Csdc)
-	sseLate := BuildTaskInfoFromScriptRecord("sse_late", t.SseLate)
-	szseLate := BuildTaskInfoFromScriptRecord("szse_late", t.SzseLate)
-	return oesmodel.OesColonyTaskInfo{
-		ColonyNum: t.ColonyNum,
-		Tasks:     []commodel.TaskInfo{mon, conterFetch, counterDistribute, sse, szse, csdc, sseLate, szseLate},
-	}
-}
-
-func BuildOptColonyTaskInfo(t biz.OptTaskExecutionInfo) oesmodel.OesColonyTaskInfo {
-	mon := BuildTaskInfoFromScriptRecord("mon", t.Mon)
-	conterFetch := BuildTaskInfoFromScriptRecord("counter_fetch", t.CounterFetch)
-	counterDistribute := BuildTaskInfoFromScriptRecord("counter_distribute", t.CounterDistribute)
-	sse := BuildTaskInfoFromScriptRecord("sse", t.Sse)
-	szse := BuildTaskInfoFromScriptRecord("szse", t.Szse)
 	return oesmodel.OesColonyTaskInfo{
 		ColonyNum: t.ColonyNum,
-		Tasks:     []commodel.TaskInfo{mon, conterFetch, counterDistribute, sse, szse},
+		Tasks:     tasks,
 	}
 }
 
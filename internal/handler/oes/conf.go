@@ -2,6 +2,7 @@ package service
 
 import (
 	"net/http"
+	"os"
 	"path/filepath"
 
 	"github.com/gin-gonic/gin"
@@ -48,11 +49,9 @@ func (s *OesConfService) UploadOesConf(ctx *gin.Context) {
 	// 1. 绑定URL路径参数
 	var pathReq oesmodel.GetOesConfRequest
 	if err := ctx.ShouldBindUri(&pathReq); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"绑定上传的oes配置文件路径参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
@@ -62,11 +61,9 @@ func (s *OesConfService) UploadOesConf(ctx *gin.Context) {
 	// 2. 绑定表单数据（包含文件）
 	var formReq oesmodel.UploadOesConfRequest
 	if err := ctx.ShouldBind(&formReq); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"绑定上传的oes配置文件表单参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
@@ -101,11 +98,9 @@ func (s *OesConfService) UploadOesConf(ctx *gin.Context) {
 func (s *OesConfService) DownloadOesConf(ctx *gin.Context) {
 	var req oesmodel.DownloadOrDeleteOesConfRequest
 	if err := ctx.ShouldBindUri(&req); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"绑定删除的oes配置文件路径参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
@@ -136,11 +131,9 @@ func (s *OesConfService) DownloadOesConf(ctx *gin.Context) {
 func (s *OesConfService) DeleteOesConf(ctx *gin.Context) {
 	var req oesmodel.DownloadOrDeleteOesConfRequest
 	if err := ctx.ShouldBindUri(&req); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"绑定删除的oes配置文件路径参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
@@ -150,11 +143,9 @@ func (s *OesConfService) DeleteOesConf(ctx *gin.Context) {
 	dirName := common.GetOesColonyConfigDir(req.ColonyNum)
 	savePath := filepath.Join(dirName, req.DirName, req.Filename)
 	if err := fileutil.Remove(ctx, savePath); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"删除oes配置文件失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.FromError(err)
 		errors.RespondWithError(ctx, rErr)
@@ -179,11 +170,9 @@ func (s *OesConfService) DeleteOesConf(ctx *gin.Context) {
 func (s *OesConfService) ListOesConf(ctx *gin.Context) {
 	var req oesmodel.ListOesConfRequest
 	if err := ctx.ShouldBindUri(&req); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"绑定oes配置文件路径参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
@@ -191,13 +180,20 @@ func (s *OesConfService) ListOesConf(ctx *gin.Context) {
 	}
 
 	dirName := common.GetOesColonyConfigDir(req.ColonyNum)
+	if _, err := os.Stat(dirName); os.IsNotExist(err) {
+		ctxutil.Logger(ctx, s.log).Error(
+			"oes配置文件目录不存在",
+			zap.String("dir_name", dirName),
+		)
+		rErr := errors.ErrDownloadFileNotFound.WithField("colony_num", req.ColonyNum)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
 	info, err := fileutil.ListFileInfo(ctx, dirName)
 	if err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"获取oes配置文件列表失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.FromError(err)
 		errors.RespondWithError(ctx, rErr)
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	commodel "gin-artweb/internal/model/common"
+	oesmodel "gin-artweb/internal/model/oes"
+	oessvc "gin-artweb/internal/service/oes"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/errors"
+)
+
+type OesColonyDriftHandler struct {
+	log      *zap.Logger
+	svcDrift *oessvc.OesColonyDriftService
+}
+
+func NewOesColonyDriftHandler(
+	logger *zap.Logger,
+	svcDrift *oessvc.OesColonyDriftService,
+) *OesColonyDriftHandler {
+	return &OesColonyDriftHandler{
+		log:      logger,
+		svcDrift: svcDrift,
+	}
+}
+
+// @Summary      查询集群配置漂移
+// @Description  本接口返回集群最近一次检测发现的配置漂移，包括配置文件与模板渲染结果不一致以及程序包版本与集群记录不一致
+// @Tags         oes集群管理
+// @Produce      json
+// @Param        id path uint32 true "oes集群ID"
+// @Success      200  {object} oesmodel.OesColonyDriftReply "成功返回配置漂移列表"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      404  {object} errors.Error "集群不存在"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/oes/colony/{id}/drift [get]
+// @Security ApiKeyAuth
+func (h *OesColonyDriftHandler) GetColonyDrift(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定oes集群ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ms, rErr := h.svcDrift.ListColonyDrift(ctx, uri.ID)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &oesmodel.OesColonyDriftReply{
+		Code: http.StatusOK,
+		Data: oesmodel.ListOesColonyDriftToOut(ms),
+	})
+}
+
+// @Summary      立即检测集群配置漂移
+// @Description  本接口立即检测集群的配置漂移并保存结果，出现新的漂移时发出告警
+// @Tags         oes集群管理
+// @Produce      json
+// @Param        id path uint32 true "oes集群ID"
+// @Success      200  {object} oesmodel.OesColonyDriftReply "成功返回配置漂移列表"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      404  {object} errors.Error "集群不存在"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/oes/colony/{id}/drift/check [post]
+// @Security ApiKeyAuth
+func (h *OesColonyDriftHandler) CheckColonyDrift(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定oes集群ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ms, rErr := h.svcDrift.CheckColony(ctx, uri.ID)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &oesmodel.OesColonyDriftReply{
+		Code: http.StatusOK,
+		Data: oesmodel.ListOesColonyDriftToOut(&ms),
+	})
+}
+
+func (h *OesColonyDriftHandler) LoadRouter(r *gin.RouterGroup) {
+	r.GET("/colony/:id/drift", h.GetColonyDrift)
+	r.POST("/colony/:id/drift/check", h.CheckColonyDrift)
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	commodel "gin-artweb/internal/model/common"
+	oesmodel "gin-artweb/internal/model/oes"
+	oessvc "gin-artweb/internal/service/oes"
+	"gin-artweb/internal/shared/common"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/errors"
+)
+
+type OesColonyExportHandler struct {
+	log      *zap.Logger
+	ucExport *oessvc.OesColonyExportService
+}
+
+func NewOesColonyExportHandler(
+	logger *zap.Logger,
+	ucExport *oessvc.OesColonyExportService,
+) *OesColonyExportHandler {
+	return &OesColonyExportHandler{
+		log:      logger,
+		ucExport: ucExport,
+	}
+}
+
+// @Summary 导出oes集群运维历史
+// @Description 本接口用于异步导出oes集群的配置、节点部署、配置文件、任务执行记录及日志，返回导出记录，完成后通过下载地址获取归档
+// @Tags oes集群管理
+// @Accept json
+// @Produce json
+// @Param id path uint true "oes集群编号"
+// @Success 200 {object} oesmodel.OesColonyExportReply "成功返回导出记录"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 404 {object} errors.Error "oes集群未找到"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/oes/colony/{id}/export [post]
+// @Security ApiKeyAuth
+func (h *OesColonyExportHandler) CreateExport(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定导出oes集群ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	claims, rErr := ctxutil.GetUserClaims(ctx)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"获取个人登录信息失败",
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := h.ucExport.CreateExport(ctx, uri.ID, claims.Username)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"创建oes集群导出失败",
+			zap.Error(rErr),
+			zap.Uint32(commodel.RequestIDKey, uri.ID),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &oesmodel.OesColonyExportReply{
+		Code: http.StatusOK,
+		Data: *oesmodel.OesColonyExportToOut(*m),
+	})
+}
+
+// @Summary 查询oes集群导出状态
+// @Description 本接口用于查询oes集群导出记录的生成状态
+// @Tags oes集群管理
+// @Accept json
+// @Produce json
+// @Param id path uint true "导出记录编号"
+// @Success 200 {object} oesmodel.OesColonyExportReply "成功返回导出记录"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 404 {object} errors.Error "导出记录未找到"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/oes/colony/export/{id} [get]
+// @Security ApiKeyAuth
+func (h *OesColonyExportHandler) GetExport(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定查询oes集群导出ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := h.ucExport.FindExportByID(ctx, uri.ID)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询oes集群导出记录失败",
+			zap.Error(rErr),
+			zap.Uint32(commodel.RequestIDKey, uri.ID),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &oesmodel.OesColonyExportReply{
+		Code: http.StatusOK,
+		Data: *oesmodel.OesColonyExportToOut(*m),
+	})
+}
+
+// @Summary 下载oes集群导出归档
+// @Description 本接口用于下载已生成的oes集群导出归档
+// @Tags oes集群管理
+// @Produce application/octet-stream
+// @Param id path uint true "导出记录编号"
+// @Success 200 {file} file "成功下载导出归档"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 404 {object} errors.Error "导出记录未找到"
+// @Failure 409 {object} errors.Error "导出尚未完成"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/oes/colony/export/{id}/download [get]
+// @Security ApiKeyAuth
+func (h *OesColonyExportHandler) DownloadExport(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定下载oes集群导出ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, filePath, rErr := h.ucExport.FindExportFile(ctx, uri.ID)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询oes集群导出归档失败",
+			zap.Error(rErr),
+			zap.Uint32(commodel.RequestIDKey, uri.ID),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	if err := common.DownloadFile(ctx, h.log, filePath, m.Filename); err != nil {
+		errors.RespondWithError(ctx, err)
+	}
+}
+
+func (h *OesColonyExportHandler) LoadRouter(r *gin.RouterGroup) {
+	r.POST("/colony/:id/export", h.CreateExport)
+	r.GET("/colony/export/:id", h.GetExport)
+	r.GET("/colony/export/:id/download", h.DownloadExport)
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"go.uber.org/zap"
+
+	commodel "gin-artweb/internal/model/common"
+	oesmodel "gin-artweb/internal/model/oes"
+	oessvc "gin-artweb/internal/service/oes"
+	syssvc "gin-artweb/internal/service/system"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+)
+
+type OesGraphQLHandler struct {
+	log      *zap.Logger
+	ucColony *oessvc.OesColonyService
+	ucTask   *oessvc.ColonyTaskExecutionInfoUsecase
+}
+
+func NewOesGraphQLHandler(
+	logger *zap.Logger,
+	ucColony *oessvc.OesColonyService,
+	ucTask *oessvc.ColonyTaskExecutionInfoUsecase,
+) *OesGraphQLHandler {
+	return &OesGraphQLHandler{
+		log:      logger,
+		ucColony: ucColony,
+		ucTask:   ucTask,
+	}
+}
+
+// oesTaskKey 查询任务状态的集群
+type oesTaskKey struct {
+	systemType string
+	colonyNum  string
+}
+
+// LoadSchema 注册oes集群的对象类型和查询字段
+//
+// 集群的程序包和mon节点在对应模块启用时才能查询, 任务状态按集群系统类型对应的任务状态接口鉴权
+func (h *OesGraphQLHandler) LoadSchema(g *syssvc.GraphQLService) {
+	taskType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "OesTask",
+		Description: "oes集群日常任务状态",
+		Fields: graphql.Fields{
+			"task_name":    syssvc.GraphQLValue(graphql.String, "任务名称", func(t commodel.TaskInfo) any { return t.TaskName }),
+			"record_id":    syssvc.GraphQLValue(graphql.Int, "执行记录ID, 0表示未执行", func(t commodel.TaskInfo) any { return t.RecordID }),
+			"status":       syssvc.GraphQLValue(graphql.Int, "执行状态", func(t commodel.TaskInfo) any { return t.Status }),
+			"start_time":   syssvc.GraphQLValue(graphql.String, "开始时间", func(t commodel.TaskInfo) any { return t.StartTime }),
+			"end_time":     syssvc.GraphQLValue(graphql.String, "结束时间", func(t commodel.TaskInfo) any { return t.EndTime }),
+			"trigger_type": syssvc.GraphQLValue(graphql.String, "触发类型", func(t commodel.TaskInfo) any { return t.TriggerType }),
+		},
+	})
+	colonyType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "OesColony",
+		Description: "oes集群",
+		Fields: syssvc.GraphQLFields(func() graphql.Fields {
+			return graphql.Fields{
+				"id":             syssvc.GraphQLValue(graphql.Int, "oes集群ID", func(m *oesmodel.OesColonyModel) any { return m.ID }),
+				"system_type":    syssvc.GraphQLValue(graphql.String, "系统类型", func(m *oesmodel.OesColonyModel) any { return m.SystemType }),
+				"colony_num":     syssvc.GraphQLValue(graphql.String, "集群号", func(m *oesmodel.OesColonyModel) any { return m.ColonyNum }),
+				"extracted_name": syssvc.GraphQLValue(graphql.String, "解压后名称", func(m *oesmodel.OesColonyModel) any { return m.ExtractedName }),
+				"is_enable":      syssvc.GraphQLValue(graphql.Boolean, "是否启用", func(m *oesmodel.OesColonyModel) any { return m.IsEnable }),
+				"created_at":     syssvc.GraphQLValue(graphql.String, "创建时间", func(m *oesmodel.OesColonyModel) any { return m.CreatedAt.Format(time.DateTime) }),
+				"updated_at":     syssvc.GraphQLValue(graphql.String, "更新时间", func(m *oesmodel.OesColonyModel) any { return m.UpdatedAt.Format(time.DateTime) }),
+				"version":        syssvc.GraphQLValue(graphql.Int, "版本号", func(m *oesmodel.OesColonyModel) any { return m.Version }),
+				"package":        syssvc.GraphQLRef(g, "Package", "oes程序包", func(m *oesmodel.OesColonyModel) uint32 { return m.PackageID }),
+				"xcounter":       syssvc.GraphQLRef(g, "Package", "xcounter程序包", func(m *oesmodel.OesColonyModel) uint32 { return m.XCounterID }),
+				"mon_node":       syssvc.GraphQLRef(g, "MonNode", "mon节点", func(m *oesmodel.OesColonyModel) uint32 { return m.MonNodeID }),
+				"tasks": {
+					Type:        graphql.NewList(taskType),
+					Description: "日常任务状态",
+					Resolve: func(p graphql.ResolveParams) (any, error) {
+						m, ok := p.Source.(*oesmodel.OesColonyModel)
+						if !ok {
+							return nil, nil
+						}
+						api := "GET /api/v1/oes/colony/status/" + strings.ToLower(m.SystemType)
+						return g.Authorize(api, func(p graphql.ResolveParams) (any, error) {
+							key := oesTaskKey{systemType: m.SystemType, colonyNum: m.ColonyNum}
+							thunk := syssvc.GraphQLLoader(p.Context, "oes_task", h.loadTasks).Load(p.Context, key)
+							return func() (any, error) { return thunk() }, nil
+						})(p)
+					},
+				},
+			}
+		}),
+	})
+
+	g.RegisterQuery("oes_colonies", "GET /api/v1/oes/colony", &graphql.Field{
+		Type:        graphql.NewList(colonyType),
+		Description: "oes集群列表",
+		Args: syssvc.GraphQLArgs(graphql.FieldConfigArgument{
+			"system_type": &graphql.ArgumentConfig{Type: graphql.String, Description: "系统类型"},
+			"is_enable":   &graphql.ArgumentConfig{Type: graphql.Boolean, Description: "是否启用"},
+		}),
+		Resolve: h.listColony,
+	})
+}
+
+func (h *OesGraphQLHandler) listColony(p graphql.ResolveParams) (any, error) {
+	page, size, rErr := syssvc.GraphQLPage(p)
+	if rErr != nil {
+		return nil, rErr
+	}
+	query := make(map[string]any)
+	if systemType, ok := p.Args["system_type"].(string); ok && systemType != "" {
+		query["system_type = ?"] = systemType
+	}
+	if isEnable, ok := p.Args["is_enable"].(bool); ok {
+		query["is_enable = ?"] = isEnable
+	}
+	qp := database.QueryParams{
+		Size:    size,
+		Page:    page,
+		OrderBy: []string{"colony_num ASC"},
+		Query:   query,
+	}
+	_, ms, rErr := h.ucColony.ListOesColony(p.Context, qp)
+	if rErr != nil {
+		ctxutil.Logger(p.Context, h.log).Error(
+			"GraphQL查询oes集群列表失败",
+			zap.Error(rErr),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		return nil, rErr
+	}
+	results := make([]*oesmodel.OesColonyModel, len(*ms))
+	for i := range *ms {
+		results[i] = &(*ms)[i]
+	}
+	return results, nil
+}
+
+// loadTasks 按系统类型批量查询集群的日常任务状态
+func (h *OesGraphQLHandler) loadTasks(
+	ctx context.Context,
+	keys []oesTaskKey,
+) (map[oesTaskKey][]commodel.TaskInfo, error) {
+	colonies := make(map[string][]oesmodel.OesColonyModel)
+	for _, key := range keys {
+		m := oesmodel.OesColonyModel{SystemType: key.systemType, ColonyNum: key.colonyNum}
+		colonies[key.systemType] = append(colonies[key.systemType], m)
+	}
+	results := make(map[oesTaskKey][]commodel.TaskInfo, len(keys))
+	for systemType, ms := range colonies {
+		infos, rErr := h.ucTask.BuildTaskExecutionInfos(ctx, systemType, ms)
+		if rErr != nil {
+			ctxutil.Logger(ctx, h.log).Error(
+				"GraphQL构建oes集群任务信息失败",
+				zap.Error(rErr),
+				zap.String("system_type", systemType),
+			)
+			return nil, rErr
+		}
+		for _, info := range *infos {
+			key := oesTaskKey{systemType: systemType, colonyNum: info.ColonyNum}
+			results[key] = BuildColonyTaskInfo(info).Tasks
+		}
+	}
+	return results, nil
+}
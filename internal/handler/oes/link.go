@@ -0,0 +1,366 @@
+package service
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	commodel "gin-artweb/internal/model/common"
+	oesmodel "gin-artweb/internal/model/oes"
+	oessvc "gin-artweb/internal/service/oes"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+)
+
+type OesLinkHandler struct {
+	log     *zap.Logger
+	svcLink *oessvc.OesLinkService
+}
+
+func NewOesLinkHandler(
+	logger *zap.Logger,
+	svcLink *oessvc.OesLinkService,
+) *OesLinkHandler {
+	return &OesLinkHandler{
+		log:     logger,
+		svcLink: svcLink,
+	}
+}
+
+// @Summary      创建链路
+// @Description  本接口用于定义集群组件之间期望可达的TCP链路，例如节点到XCounter端口、节点到交易所网关，源主机ID为0时由平台探测
+// @Tags         oes链路探测
+// @Accept       json
+// @Produce      json
+// @Param        request body oesmodel.OesLinkRequest true "链路"
+// @Success      201  {object} oesmodel.OesLinkReply "成功返回链路"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      404  {object} errors.Error "集群或源主机不存在"
+// @Failure      409  {object} errors.Error "名称已存在"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/oes/link [post]
+// @Security ApiKeyAuth
+func (h *OesLinkHandler) CreateLink(ctx *gin.Context) {
+	var req oesmodel.OesLinkRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定创建链路参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := h.svcLink.CreateLink(ctx, req.ToModel())
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, &oesmodel.OesLinkReply{
+		Code: http.StatusCreated,
+		Data: oesmodel.OesLinkToOut(*m),
+	})
+}
+
+// @Summary      更新链路
+// @Description  本接口用于更新指定ID的链路，源主机或目标变化时探测状态重置为未探测
+// @Tags         oes链路探测
+// @Accept       json
+// @Produce      json
+// @Param        id path uint32 true "链路ID"
+// @Param        request body oesmodel.OesLinkRequest true "链路"
+// @Success      200  {object} oesmodel.OesLinkReply "成功返回链路"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      404  {object} errors.Error "链路、集群或源主机不存在"
+// @Failure      409  {object} errors.Error "名称已存在"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/oes/link/{id} [put]
+// @Security ApiKeyAuth
+func (h *OesLinkHandler) UpdateLink(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定链路ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	var req oesmodel.OesLinkRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定更新链路参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	lm := req.ToModel()
+	lm.ID = uri.ID
+	m, rErr := h.svcLink.UpdateLinkByID(ctx, lm)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &oesmodel.OesLinkReply{
+		Code: http.StatusOK,
+		Data: oesmodel.OesLinkToOut(*m),
+	})
+}
+
+// @Summary      删除链路
+// @Description  本接口用于删除指定ID的链路及其探测记录
+// @Tags         oes链路探测
+// @Produce      json
+// @Param        id path uint32 true "链路ID"
+// @Success      200  {object} commodel.MapAPIReply "删除成功"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      404  {object} errors.Error "链路不存在"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/oes/link/{id} [delete]
+// @Security ApiKeyAuth
+func (h *OesLinkHandler) DeleteLink(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定链路ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	if rErr := h.svcLink.DeleteLinkByID(ctx, uri.ID); rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(commodel.NoDataReply.Code, commodel.NoDataReply)
+}
+
+// @Summary      查询链路详情
+// @Description  本接口用于查询指定ID的链路和最近一次探测结果
+// @Tags         oes链路探测
+// @Produce      json
+// @Param        id path uint32 true "链路ID"
+// @Success      200  {object} oesmodel.OesLinkReply "成功返回链路"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      404  {object} errors.Error "链路不存在"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/oes/link/{id} [get]
+// @Security ApiKeyAuth
+func (h *OesLinkHandler) GetLink(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定链路ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := h.svcLink.FindLinkByID(ctx, uri.ID)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &oesmodel.OesLinkReply{
+		Code: http.StatusOK,
+		Data: oesmodel.OesLinkToOut(*m),
+	})
+}
+
+// @Summary      查询链路列表
+// @Description  本接口用于查询链路列表，可按status=unreachable查询不可达的链路
+// @Tags         oes链路探测
+// @Produce      json
+// @Param        request query oesmodel.ListOesLinkRequest false "查询参数"
+// @Success      200  {object} oesmodel.PagOesLinkReply "成功返回链路列表"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/oes/link [get]
+// @Security ApiKeyAuth
+func (h *OesLinkHandler) ListLink(ctx *gin.Context) {
+	var req oesmodel.ListOesLinkRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定查询链路列表参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	page, size, query := req.Query()
+	qp := database.QueryParams{
+		Preloads:  []string{"SourceHost"},
+		IsCount:   true,
+		SkipCount: req.SkipCount(),
+		Size:      size,
+		Page:      page,
+		OrderBy:   []string{"id ASC"},
+		Query:     query,
+	}
+	total, ms, rErr := h.svcLink.ListLink(ctx, qp)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	mbs := oesmodel.ListOesLinkToOut(ms)
+	ctx.JSON(http.StatusOK, &oesmodel.PagOesLinkReply{
+		Code: http.StatusOK,
+		Data: commodel.NewPag(qp, total, mbs),
+	})
+}
+
+// @Summary      立即探测链路
+// @Description  本接口用于立即探测指定ID的链路，与定时探测相同，变为不可达时发出告警
+// @Tags         oes链路探测
+// @Produce      json
+// @Param        id path uint32 true "链路ID"
+// @Success      200  {object} oesmodel.OesLinkReply "成功返回探测后的链路"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      404  {object} errors.Error "链路不存在"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/oes/link/{id}/probe [post]
+// @Security ApiKeyAuth
+func (h *OesLinkHandler) ProbeLink(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定链路ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := h.svcLink.ProbeLinkByID(ctx, uri.ID)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &oesmodel.OesLinkReply{
+		Code: http.StatusOK,
+		Data: oesmodel.OesLinkToOut(*m),
+	})
+}
+
+// @Summary      查询链路探测记录
+// @Description  本接口用于查询指定ID的链路的探测记录和连接耗时，按时间倒序
+// @Tags         oes链路探测
+// @Produce      json
+// @Param        id path uint32 true "链路ID"
+// @Param        request query oesmodel.ListOesLinkProbeRequest false "查询参数"
+// @Success      200  {object} oesmodel.PagOesLinkProbeReply "成功返回链路探测记录"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      404  {object} errors.Error "链路不存在"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/oes/link/{id}/probe [get]
+// @Security ApiKeyAuth
+func (h *OesLinkHandler) ListLinkProbe(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定链路ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	var req oesmodel.ListOesLinkProbeRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定查询链路探测记录参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	page, size, query := req.Query()
+	qp := database.QueryParams{
+		IsCount:   true,
+		SkipCount: req.SkipCount(),
+		Size:      size,
+		Page:      page,
+		OrderBy:   []string{"id DESC"},
+		Query:     query,
+	}
+	total, ms, rErr := h.svcLink.ListLinkProbe(ctx, uri.ID, qp)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	mbs := oesmodel.ListOesLinkProbeToOut(ms)
+	ctx.JSON(http.StatusOK, &oesmodel.PagOesLinkProbeReply{
+		Code: http.StatusOK,
+		Data: commodel.NewPag(qp, total, mbs),
+	})
+}
+
+// @Summary      查询链路矩阵
+// @Description  本接口用于按探测发起方(行)和目标(列)汇总启用链路的最近一次探测结果和连接耗时
+// @Tags         oes链路探测
+// @Produce      json
+// @Param        request query oesmodel.OesLinkMatrixRequest false "查询参数"
+// @Success      200  {object} oesmodel.OesLinkMatrixReply "成功返回链路矩阵"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/oes/link/matrix [get]
+// @Security ApiKeyAuth
+func (h *OesLinkHandler) GetLinkMatrix(ctx *gin.Context) {
+	var req oesmodel.OesLinkMatrixRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定查询链路矩阵参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	out, rErr := h.svcLink.Matrix(ctx, req.OesColonyID)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &oesmodel.OesLinkMatrixReply{
+		Code: http.StatusOK,
+		Data: out,
+	})
+}
+
+func (h *OesLinkHandler) LoadRouter(r *gin.RouterGroup) {
+	r.POST("/link", h.CreateLink)
+	r.GET("/link", h.ListLink)
+	r.GET("/link/matrix", h.GetLinkMatrix)
+	r.GET("/link/:id", h.GetLink)
+	r.PUT("/link/:id", h.UpdateLink)
+	r.DELETE("/link/:id", h.DeleteLink)
+	r.POST("/link/:id/probe", h.ProbeLink)
+	r.GET("/link/:id/probe", h.ListLinkProbe)
+}
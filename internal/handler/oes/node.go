@@ -43,11 +43,9 @@ func NewOesNodeService(
 func (s *OesNodeService) CreateOesNode(ctx *gin.Context) {
 	var req oesmodel.CreateOrUpdateOesNodeRequest
 	if err := ctx.ShouldBind(&req); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"绑定创建oes节点参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
@@ -63,11 +61,9 @@ func (s *OesNodeService) CreateOesNode(ctx *gin.Context) {
 
 	m, rErr := s.ucNode.CreateOesNode(ctx, colony)
 	if rErr != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"创建oes节点失败",
 			zap.Error(rErr),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, rErr)
 		return
@@ -95,11 +91,9 @@ func (s *OesNodeService) CreateOesNode(ctx *gin.Context) {
 func (s *OesNodeService) UpdateOesNode(ctx *gin.Context) {
 	var uri commodel.IDUri
 	if err := ctx.ShouldBindUri(&uri); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"绑定更新oes节点ID参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
@@ -108,11 +102,9 @@ func (s *OesNodeService) UpdateOesNode(ctx *gin.Context) {
 
 	var req oesmodel.CreateOrUpdateOesNodeRequest
 	if err := ctx.ShouldBind(&req); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"绑定更新oes节点参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
@@ -128,12 +120,10 @@ func (s *OesNodeService) UpdateOesNode(ctx *gin.Context) {
 
 	m, rErr := s.ucNode.UpdateOesNodeByID(ctx, uri.ID, data)
 	if rErr != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"更新oes节点失败",
 			zap.Error(rErr),
 			zap.Uint32(commodel.RequestIDKey, uri.ID),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, rErr)
 		return
@@ -160,39 +150,34 @@ func (s *OesNodeService) UpdateOesNode(ctx *gin.Context) {
 func (s *OesNodeService) DeleteOesNode(ctx *gin.Context) {
 	var uri commodel.IDUri
 	if err := ctx.ShouldBindUri(&uri); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"绑定删除oes节点ID参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始删除oes节点",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	rErr := s.ucNode.DeleteOesNodeByID(ctx, uri.ID)
 	if rErr != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"删除oes节点失败",
 			zap.Error(rErr),
 			zap.Uint32(commodel.RequestIDKey, uri.ID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"删除oes节点成功",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	ctx.JSON(commodel.NoDataReply.Code, commodel.NoDataReply)
@@ -213,39 +198,34 @@ func (s *OesNodeService) DeleteOesNode(ctx *gin.Context) {
 func (s *OesNodeService) GetOesNode(ctx *gin.Context) {
 	var uri commodel.IDUri
 	if err := ctx.ShouldBindUri(&uri); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"绑定查询oes节点ID参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始查询oes节点详情",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	m, rErr := s.ucNode.FindOesNodeByID(ctx, []string{"OesColony", "Host"}, uri.ID)
 	if rErr != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"查询oes节点详情失败",
 			zap.Error(rErr),
 			zap.Uint32(commodel.RequestIDKey, uri.ID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"查询oes节点详情成功",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	mo := oesmodel.OesNodeToDetailOut(*m)
@@ -269,55 +249,48 @@ func (s *OesNodeService) GetOesNode(ctx *gin.Context) {
 func (s *OesNodeService) ListOesNode(ctx *gin.Context) {
 	var req oesmodel.ListOesNodeRequest
 	if err := ctx.ShouldBindQuery(&req); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"绑定查询oes节点列表参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始查询oes节点列表",
-		zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	page, size, query := req.Query()
 	qp := database.QueryParams{
-		Preloads: []string{"OesColony", "Host"},
-		IsCount:  true,
-		Size:     size,
-		Page:     page,
-		OrderBy:  []string{"id DESC"},
-		Query:    query,
+		Preloads:  []string{"OesColony", "Host"},
+		IsCount:   true,
+		SkipCount: req.SkipCount(),
+		Size:      size,
+		Page:      page,
+		OrderBy:   []string{"id DESC"},
+		Query:     query,
 	}
 	total, ms, rErr := s.ucNode.ListOesNode(ctx, qp)
 	if rErr != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"查询oes节点列表失败",
 			zap.Error(rErr),
 			zap.Object(database.QueryParamsKey, &qp),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"查询oes节点列表成功",
-		zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	mbs := oesmodel.ListOesNodeToDetailOut(ms)
 	ctx.JSON(http.StatusOK, &oesmodel.PagOesNodeReply{
 		Code: http.StatusOK,
-		Data: commodel.NewPag(page, size, total, mbs),
+		Data: commodel.NewPag(qp, total, mbs),
 	})
 }
 
@@ -0,0 +1,204 @@
+package service
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	commodel "gin-artweb/internal/model/common"
+	oesmodel "gin-artweb/internal/model/oes"
+	oessvc "gin-artweb/internal/service/oes"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+)
+
+type OesReconcileHandler struct {
+	log          *zap.Logger
+	svcReconcile *oessvc.OesReconcileService
+}
+
+func NewOesReconcileHandler(
+	logger *zap.Logger,
+	svcReconcile *oessvc.OesReconcileService,
+) *OesReconcileHandler {
+	return &OesReconcileHandler{
+		log:          logger,
+		svcReconcile: svcReconcile,
+	}
+}
+
+// @Summary      查询集群柜台数据对账报告列表
+// @Description  本接口分页查询集群每个交易日的柜台数据对账报告，报告统计下发到各节点的数据相对柜台数据缺少、多余和内容不一致的记录数
+// @Tags         oes集群管理
+// @Produce      json
+// @Param        id path uint32 true "oes集群ID"
+// @Param        request query oesmodel.ListOesReconcileReportRequest false "查询参数"
+// @Success      200  {object} oesmodel.PagOesReconcileReportReply "成功返回对账报告列表"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      404  {object} errors.Error "集群不存在"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/oes/colony/{id}/reconciliation [get]
+// @Security ApiKeyAuth
+func (h *OesReconcileHandler) ListReconcileReport(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定oes集群ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	var req oesmodel.ListOesReconcileReportRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定查询oes柜台数据对账报告列表参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	page, size, query := req.Query(uri.ID)
+	qp := database.QueryParams{
+		IsCount:   true,
+		SkipCount: req.SkipCount(),
+		Size:      size,
+		Page:      page,
+		OrderBy:   []string{"trading_day DESC"},
+		Query:     query,
+	}
+	total, ms, rErr := h.svcReconcile.ListReconcileReport(ctx, uri.ID, qp)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询oes柜台数据对账报告列表失败",
+			zap.Error(rErr),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	mbs := oesmodel.ListOesReconcileReportToOut(ms)
+	ctx.JSON(http.StatusOK, &oesmodel.PagOesReconcileReportReply{
+		Code: http.StatusOK,
+		Data: commodel.NewPag(qp, total, mbs),
+	})
+}
+
+// @Summary      查询集群指定交易日的柜台数据对账报告
+// @Description  本接口查询集群指定交易日的柜台数据对账报告及每个节点上每个数据文件的对账结果
+// @Tags         oes集群管理
+// @Produce      json
+// @Param        id path uint32 true "oes集群ID"
+// @Param        trading_day path string true "交易日, 格式为2006-01-02"
+// @Success      200  {object} oesmodel.OesReconcileReportReply "成功返回对账报告详情"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      404  {object} errors.Error "对账报告不存在"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/oes/colony/{id}/reconciliation/{trading_day} [get]
+// @Security ApiKeyAuth
+func (h *OesReconcileHandler) GetReconcileReport(ctx *gin.Context) {
+	var uri oesmodel.ReconcileDayUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定oes柜台数据对账报告参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := h.svcReconcile.FindReconcileReport(ctx, uri.ID, uri.TradingDay)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &oesmodel.OesReconcileReportReply{
+		Code: http.StatusOK,
+		Data: oesmodel.OesReconcileReportToDetailOut(*m),
+	})
+}
+
+// @Summary      立即对账集群柜台数据
+// @Description  本接口立即比较counter_fetch.sh获取的柜台数据与counter_distribute.sh下发到各节点的数据并保存报告，替换该交易日已有的报告
+// @Tags         oes集群管理
+// @Accept       json
+// @Produce      json
+// @Param        id path uint32 true "oes集群ID"
+// @Param        request body oesmodel.ReconcileColonyRequest false "对账请求"
+// @Success      200  {object} oesmodel.OesReconcileReportReply "成功返回对账报告详情"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      401  {object} errors.Error "未授权"
+// @Failure      404  {object} errors.Error "集群不存在"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/oes/colony/{id}/reconciliation [post]
+// @Security ApiKeyAuth
+func (h *OesReconcileHandler) ReconcileColony(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定oes集群ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	var req oesmodel.ReconcileColonyRequest
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctxutil.Logger(ctx, h.log).Error(
+				"绑定oes柜台数据对账参数失败",
+				zap.Error(err),
+			)
+			rErr := errors.ErrValidationFailed.WithCause(err)
+			errors.RespondWithError(ctx, rErr)
+			return
+		}
+	}
+	if req.TradingDay == "" {
+		req.TradingDay = time.Now().Format(time.DateOnly)
+	}
+
+	claims, rErr := ctxutil.GetUserClaims(ctx)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"获取个人登录信息失败",
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := h.svcReconcile.ReconcileColony(ctx, uri.ID, req.TradingDay, claims.Username)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"oes柜台数据对账失败",
+			zap.Error(rErr),
+			zap.Uint32(commodel.RequestIDKey, uri.ID),
+			zap.Object(commodel.RequestModelKey, &req),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &oesmodel.OesReconcileReportReply{
+		Code: http.StatusOK,
+		Data: oesmodel.OesReconcileReportToDetailOut(*m),
+	})
+}
+
+func (h *OesReconcileHandler) LoadRouter(r *gin.RouterGroup) {
+	r.GET("/colony/:id/reconciliation", h.ListReconcileReport)
+	r.POST("/colony/:id/reconciliation", h.ReconcileColony)
+	r.GET("/colony/:id/reconciliation/:trading_day", h.GetReconcileReport)
+}
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	oesmodel "gin-artweb/internal/model/oes"
+	oessvc "gin-artweb/internal/service/oes"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+	"gin-artweb/internal/shared/rpc"
+	"gin-artweb/pkg/rpc/artwebv1"
+)
+
+// oesSystemTypes oes集群的系统类型
+var oesSystemTypes = []string{"STK", "CRD", "OPT"}
+
+type OesRPCHandler struct {
+	artwebv1.UnimplementedOesServiceServer
+	log      *zap.Logger
+	server   *rpc.Server
+	ucColony *oessvc.OesColonyService
+	ucTask   *oessvc.ColonyTaskExecutionInfoUsecase
+}
+
+func NewOesRPCHandler(
+	logger *zap.Logger,
+	ucColony *oessvc.OesColonyService,
+	ucTask *oessvc.ColonyTaskExecutionInfoUsecase,
+) *OesRPCHandler {
+	return &OesRPCHandler{
+		log:      logger,
+		ucColony: ucColony,
+		ucTask:   ucTask,
+	}
+}
+
+// LoadRPC 注册oes集群的gRPC服务, 任务状态按请求的系统类型对应的REST任务状态接口鉴权
+func (h *OesRPCHandler) LoadRPC(s *rpc.Server) {
+	h.server = s
+	s.Register(&artwebv1.OesService_ServiceDesc, h, map[string]string{
+		"ListColonies":    "GET /api/v1/oes/colony",
+		"ListColonyTasks": "",
+	})
+}
+
+func (h *OesRPCHandler) ListColonies(
+	ctx context.Context,
+	req *artwebv1.ListOesColoniesRequest,
+) (*artwebv1.ListOesColoniesResponse, error) {
+	page, size, rErr := rpc.Page(req.GetPage(), req.GetSize())
+	if rErr != nil {
+		return nil, rErr
+	}
+	query := make(map[string]any)
+	if req.GetSystemType() != "" {
+		query["system_type = ?"] = req.GetSystemType()
+	}
+	if req.IsEnable != nil {
+		query["is_enable = ?"] = req.GetIsEnable()
+	}
+	qp := database.QueryParams{
+		Size:    size,
+		Page:    page,
+		IsCount: true,
+		OrderBy: []string{"colony_num ASC"},
+		Query:   query,
+	}
+	total, ms, rErr := h.ucColony.ListOesColony(ctx, qp)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"gRPC查询oes集群列表失败",
+			zap.Error(rErr),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		return nil, rErr
+	}
+	colonies := make([]*artwebv1.OesColony, len(*ms))
+	for i, m := range *ms {
+		colonies[i] = &artwebv1.OesColony{
+			Id:            m.ID,
+			SystemType:    m.SystemType,
+			ColonyNum:     m.ColonyNum,
+			ExtractedName: m.ExtractedName,
+			IsEnable:      m.IsEnable,
+			PackageId:     m.PackageID,
+			XcounterId:    m.XCounterID,
+			MonNodeId:     m.MonNodeID,
+			CreatedAt:     m.CreatedAt.Format(time.DateTime),
+			UpdatedAt:     m.UpdatedAt.Format(time.DateTime),
+		}
+	}
+	return &artwebv1.ListOesColoniesResponse{Total: total, Colonies: colonies}, nil
+}
+
+func (h *OesRPCHandler) ListColonyTasks(
+	ctx context.Context,
+	req *artwebv1.ListOesColonyTasksRequest,
+) (*artwebv1.ListColonyTasksResponse, error) {
+	systemType := strings.ToUpper(req.GetSystemType())
+	if !slices.Contains(oesSystemTypes, systemType) {
+		return nil, errors.ErrValidationFailed.WithField("system_type", req.GetSystemType())
+	}
+	if rErr := h.server.Authorize(ctx, "GET /api/v1/oes/colony/status/"+strings.ToLower(systemType)); rErr != nil {
+		return nil, rErr
+	}
+	page, size, rErr := rpc.Page(req.GetPage(), req.GetSize())
+	if rErr != nil {
+		return nil, rErr
+	}
+	qp := database.QueryParams{
+		Size:    size,
+		Page:    page,
+		OrderBy: []string{"colony_num ASC"},
+		Query:   map[string]any{"system_type = ?": systemType, "is_enable = ?": true},
+	}
+	_, ms, rErr := h.ucColony.ListOesColony(ctx, qp)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"gRPC查询oes集群列表失败",
+			zap.Error(rErr),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		return nil, rErr
+	}
+	infos, rErr := h.ucTask.BuildTaskExecutionInfos(ctx, systemType, *ms)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"gRPC构建oes集群任务信息失败",
+			zap.Error(rErr),
+			zap.String("system_type", systemType),
+		)
+		return nil, rErr
+	}
+	colonies := make([]*artwebv1.ColonyTasks, 0, len(*ms))
+	if infos != nil {
+		for _, info := range *infos {
+			colonies = append(colonies, rpcColonyTasks(BuildColonyTaskInfo(info)))
+		}
+	}
+	return &artwebv1.ListColonyTasksResponse{Colonies: colonies}, nil
+}
+
+func rpcColonyTasks(info oesmodel.OesColonyTaskInfo) *artwebv1.ColonyTasks {
+	tasks := make([]*artwebv1.TaskInfo, len(info.Tasks))
+	for i, t := range info.Tasks {
+		tasks[i] = &artwebv1.TaskInfo{
+			TaskName:    t.TaskName,
+			RecordId:    t.RecordID,
+			Status:      int32(t.Status),
+			StartTime:   t.StartTime,
+			EndTime:     t.EndTime,
+			TriggerType: t.TriggerType,
+		}
+	}
+	return &artwebv1.ColonyTasks{ColonyNum: info.ColonyNum, Tasks: tasks}
+}
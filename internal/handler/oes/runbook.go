@@ -0,0 +1,537 @@
+package service
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	commodel "gin-artweb/internal/model/common"
+	oesmodel "gin-artweb/internal/model/oes"
+	oessvc "gin-artweb/internal/service/oes"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+)
+
+type OesRunbookHandler struct {
+	log        *zap.Logger
+	svcRunbook *oessvc.OesRunbookService
+}
+
+func NewOesRunbookHandler(
+	logger *zap.Logger,
+	svcRunbook *oessvc.OesRunbookService,
+) *OesRunbookHandler {
+	return &OesRunbookHandler{
+		log:        logger,
+		svcRunbook: svcRunbook,
+	}
+}
+
+// @Summary      创建检查单
+// @Description  本接口用于创建oes运维检查单，步骤按顺序执行，人工步骤由操作员确认完成，脚本步骤以集群号为参数执行oes的内置命令脚本
+// @Tags         oes运维检查单
+// @Accept       json
+// @Produce      json
+// @Param        request body oesmodel.OesRunbookRequest true "检查单"
+// @Success      201  {object} oesmodel.OesRunbookReply "成功返回检查单"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      404  {object} errors.Error "脚本不存在"
+// @Failure      409  {object} errors.Error "检查单名称已存在"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/oes/runbook [post]
+// @Security ApiKeyAuth
+func (h *OesRunbookHandler) CreateRunbook(ctx *gin.Context) {
+	var req oesmodel.OesRunbookRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定创建检查单参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := h.svcRunbook.CreateRunbook(ctx, req.ToModel())
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, &oesmodel.OesRunbookReply{
+		Code: http.StatusCreated,
+		Data: oesmodel.OesRunbookToOut(*m),
+	})
+}
+
+// @Summary      更新检查单
+// @Description  本接口用于更新指定ID的检查单并替换全部步骤，不影响进行中的执行
+// @Tags         oes运维检查单
+// @Accept       json
+// @Produce      json
+// @Param        id path uint32 true "检查单ID"
+// @Param        request body oesmodel.OesRunbookRequest true "检查单"
+// @Success      200  {object} oesmodel.OesRunbookReply "成功返回检查单"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      404  {object} errors.Error "检查单或脚本不存在"
+// @Failure      409  {object} errors.Error "检查单名称已存在"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/oes/runbook/{id} [put]
+// @Security ApiKeyAuth
+func (h *OesRunbookHandler) UpdateRunbook(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定检查单ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	var req oesmodel.OesRunbookRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定更新检查单参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	rm := req.ToModel()
+	rm.ID = uri.ID
+	m, rErr := h.svcRunbook.UpdateRunbookByID(ctx, rm)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &oesmodel.OesRunbookReply{
+		Code: http.StatusOK,
+		Data: oesmodel.OesRunbookToOut(*m),
+	})
+}
+
+// @Summary      删除检查单
+// @Description  本接口用于删除指定ID的检查单，不影响已发起的执行
+// @Tags         oes运维检查单
+// @Produce      json
+// @Param        id path uint32 true "检查单ID"
+// @Success      200  {object} commodel.MapAPIReply "删除成功"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      404  {object} errors.Error "检查单不存在"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/oes/runbook/{id} [delete]
+// @Security ApiKeyAuth
+func (h *OesRunbookHandler) DeleteRunbook(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定检查单ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	if rErr := h.svcRunbook.DeleteRunbookByID(ctx, uri.ID); rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(commodel.NoDataReply.Code, commodel.NoDataReply)
+}
+
+// @Summary      查询检查单详情
+// @Description  本接口用于查询指定ID的检查单及其步骤
+// @Tags         oes运维检查单
+// @Produce      json
+// @Param        id path uint32 true "检查单ID"
+// @Success      200  {object} oesmodel.OesRunbookReply "成功返回检查单"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      404  {object} errors.Error "检查单不存在"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/oes/runbook/{id} [get]
+// @Security ApiKeyAuth
+func (h *OesRunbookHandler) GetRunbook(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定检查单ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := h.svcRunbook.FindRunbookByID(ctx, uri.ID)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &oesmodel.OesRunbookReply{
+		Code: http.StatusOK,
+		Data: oesmodel.OesRunbookToOut(*m),
+	})
+}
+
+// @Summary      查询检查单列表
+// @Description  本接口用于查询检查单列表，支持按名称、适用的系统类型和启用状态过滤
+// @Tags         oes运维检查单
+// @Produce      json
+// @Param        request query oesmodel.ListOesRunbookRequest false "查询参数"
+// @Success      200  {object} oesmodel.PagOesRunbookReply "成功返回检查单列表"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/oes/runbook [get]
+// @Security ApiKeyAuth
+func (h *OesRunbookHandler) ListRunbook(ctx *gin.Context) {
+	var req oesmodel.ListOesRunbookRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定查询检查单列表参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	page, size, query := req.Query()
+	qp := database.QueryParams{
+		Preloads:  []string{"Steps"},
+		IsCount:   true,
+		SkipCount: req.SkipCount(),
+		Size:      size,
+		Page:      page,
+		OrderBy:   []string{"id ASC"},
+		Query:     query,
+	}
+	total, ms, rErr := h.svcRunbook.ListRunbook(ctx, qp)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	mbs := oesmodel.ListOesRunbookToOut(ms)
+	ctx.JSON(http.StatusOK, &oesmodel.PagOesRunbookReply{
+		Code: http.StatusOK,
+		Data: commodel.NewPag(qp, total, mbs),
+	})
+}
+
+// @Summary      为集群发起检查单
+// @Description  本接口用于为集群发起检查单，第一个步骤立即开始，同一集群同一检查单同时只允许一个未结束的执行
+// @Tags         oes运维检查单
+// @Accept       json
+// @Produce      json
+// @Param        id path uint32 true "oes集群ID"
+// @Param        request body oesmodel.StartOesRunbookRequest true "发起检查单请求"
+// @Success      201  {object} oesmodel.OesRunbookExecReply "成功返回检查单执行详情"
+// @Failure      400  {object} errors.Error "请求参数错误或检查单不适用该集群"
+// @Failure      401  {object} errors.Error "未授权"
+// @Failure      404  {object} errors.Error "集群、检查单或脚本不存在"
+// @Failure      409  {object} errors.Error "集群已有正在执行的该检查单"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/oes/colony/{id}/runbook [post]
+// @Security ApiKeyAuth
+func (h *OesRunbookHandler) StartRunbook(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定oes集群ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	var req oesmodel.StartOesRunbookRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定发起检查单参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	claims, rErr := ctxutil.GetUserClaims(ctx)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"获取个人登录信息失败",
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := h.svcRunbook.StartRunbook(ctx, uri.ID, req.RunbookID, claims.Username)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"发起检查单失败",
+			zap.Error(rErr),
+			zap.Uint32(commodel.RequestIDKey, uri.ID),
+			zap.Uint32("runbook_id", req.RunbookID),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, &oesmodel.OesRunbookExecReply{
+		Code: http.StatusCreated,
+		Data: oesmodel.OesRunbookExecToDetailOut(*m),
+	})
+}
+
+// @Summary      查询检查单执行详情
+// @Description  本接口用于查询检查单执行的进度及每个步骤的状态、操作员签核和脚本执行记录
+// @Tags         oes运维检查单
+// @Produce      json
+// @Param        id path uint32 true "检查单执行ID"
+// @Success      200  {object} oesmodel.OesRunbookExecReply "成功返回检查单执行详情"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      404  {object} errors.Error "检查单执行不存在"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/oes/runbook/exec/{id} [get]
+// @Security ApiKeyAuth
+func (h *OesRunbookHandler) GetExec(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定检查单执行ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := h.svcRunbook.FindExecByID(ctx, uri.ID)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &oesmodel.OesRunbookExecReply{
+		Code: http.StatusOK,
+		Data: oesmodel.OesRunbookExecToDetailOut(*m),
+	})
+}
+
+// @Summary      查询检查单执行列表
+// @Description  本接口用于分页查询检查单执行及其进度
+// @Tags         oes运维检查单
+// @Produce      json
+// @Param        request query oesmodel.ListOesRunbookExecRequest false "查询参数"
+// @Success      200  {object} oesmodel.PagOesRunbookExecReply "成功返回检查单执行列表"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/oes/runbook/exec [get]
+// @Security ApiKeyAuth
+func (h *OesRunbookHandler) ListExec(ctx *gin.Context) {
+	var req oesmodel.ListOesRunbookExecRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定查询检查单执行列表参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	page, size, query := req.Query()
+	qp := database.QueryParams{
+		Preloads:  []string{"Steps"},
+		IsCount:   true,
+		SkipCount: req.SkipCount(),
+		Size:      size,
+		Page:      page,
+		OrderBy:   []string{"id DESC"},
+		Query:     query,
+	}
+	total, ms, rErr := h.svcRunbook.ListExec(ctx, qp)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	mbs := oesmodel.ListOesRunbookExecToOut(ms)
+	ctx.JSON(http.StatusOK, &oesmodel.PagOesRunbookExecReply{
+		Code: http.StatusOK,
+		Data: commodel.NewPag(qp, total, mbs),
+	})
+}
+
+// @Summary      确认检查单人工步骤
+// @Description  本接口用于操作员签核当前等待确认的人工步骤，确认后检查单进入下一步
+// @Tags         oes运维检查单
+// @Accept       json
+// @Produce      json
+// @Param        id path uint32 true "检查单执行ID"
+// @Param        step_id path uint32 true "步骤ID"
+// @Param        request body oesmodel.SignOffRunbookStepRequest false "签核备注"
+// @Success      200  {object} oesmodel.OesRunbookExecReply "成功返回检查单执行详情"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      401  {object} errors.Error "未授权"
+// @Failure      404  {object} errors.Error "检查单执行不存在"
+// @Failure      409  {object} errors.Error "步骤不是等待确认的当前步骤"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/oes/runbook/exec/{id}/step/{step_id}/confirm [post]
+// @Security ApiKeyAuth
+func (h *OesRunbookHandler) ConfirmStep(ctx *gin.Context) {
+	h.signOffStep(ctx, "确认检查单人工步骤失败", h.svcRunbook.ConfirmStep)
+}
+
+// @Summary      重试检查单脚本步骤
+// @Description  本接口用于操作员重新执行失败的脚本步骤，脚本在后台执行，成功后检查单进入下一步
+// @Tags         oes运维检查单
+// @Accept       json
+// @Produce      json
+// @Param        id path uint32 true "检查单执行ID"
+// @Param        step_id path uint32 true "步骤ID"
+// @Param        request body oesmodel.SignOffRunbookStepRequest false "重试备注"
+// @Success      200  {object} oesmodel.OesRunbookExecReply "成功返回检查单执行详情"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      401  {object} errors.Error "未授权"
+// @Failure      404  {object} errors.Error "检查单执行不存在"
+// @Failure      409  {object} errors.Error "步骤不是执行失败的当前步骤"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/oes/runbook/exec/{id}/step/{step_id}/retry [post]
+// @Security ApiKeyAuth
+func (h *OesRunbookHandler) RetryStep(ctx *gin.Context) {
+	h.signOffStep(ctx, "重试检查单脚本步骤失败", h.svcRunbook.RetryStep)
+}
+
+// signOffStep 绑定步骤参数和签核备注, 以当前用户作为操作员执行步骤操作
+func (h *OesRunbookHandler) signOffStep(
+	ctx *gin.Context,
+	failedMsg string,
+	action func(ctx context.Context, execID, stepID uint32, username, remark string) (*oesmodel.OesRunbookExecModel, *errors.Error),
+) {
+	var uri oesmodel.RunbookStepUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定检查单步骤参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	var req oesmodel.SignOffRunbookStepRequest
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctxutil.Logger(ctx, h.log).Error(
+				"绑定检查单步骤签核参数失败",
+				zap.Error(err),
+			)
+			rErr := errors.ErrValidationFailed.WithCause(err)
+			errors.RespondWithError(ctx, rErr)
+			return
+		}
+	}
+
+	claims, rErr := ctxutil.GetUserClaims(ctx)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"获取个人登录信息失败",
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := action(ctx, uri.ID, uri.StepID, claims.Username, req.Remark)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			failedMsg,
+			zap.Error(rErr),
+			zap.Uint32(commodel.RequestIDKey, uri.ID),
+			zap.Uint32("step_id", uri.StepID),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &oesmodel.OesRunbookExecReply{
+		Code: http.StatusOK,
+		Data: oesmodel.OesRunbookExecToDetailOut(*m),
+	})
+}
+
+// @Summary      终止检查单执行
+// @Description  本接口用于终止未结束的检查单执行，未完成的步骤标记为跳过，脚本步骤执行中时不能终止
+// @Tags         oes运维检查单
+// @Produce      json
+// @Param        id path uint32 true "检查单执行ID"
+// @Success      200  {object} oesmodel.OesRunbookExecReply "成功返回检查单执行详情"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      401  {object} errors.Error "未授权"
+// @Failure      404  {object} errors.Error "检查单执行不存在"
+// @Failure      409  {object} errors.Error "检查单已结束或脚本步骤执行中"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/oes/runbook/exec/{id}/abort [post]
+// @Security ApiKeyAuth
+func (h *OesRunbookHandler) AbortExec(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定检查单执行ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	claims, rErr := ctxutil.GetUserClaims(ctx)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"获取个人登录信息失败",
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := h.svcRunbook.AbortExec(ctx, uri.ID, claims.Username)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"终止检查单执行失败",
+			zap.Error(rErr),
+			zap.Uint32(commodel.RequestIDKey, uri.ID),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &oesmodel.OesRunbookExecReply{
+		Code: http.StatusOK,
+		Data: oesmodel.OesRunbookExecToDetailOut(*m),
+	})
+}
+
+func (h *OesRunbookHandler) LoadRouter(r *gin.RouterGroup) {
+	r.POST("/runbook", h.CreateRunbook)
+	r.GET("/runbook", h.ListRunbook)
+	r.GET("/runbook/exec", h.ListExec)
+	r.GET("/runbook/exec/:id", h.GetExec)
+	r.POST("/runbook/exec/:id/abort", h.AbortExec)
+	r.POST("/runbook/exec/:id/step/:step_id/confirm", h.ConfirmStep)
+	r.POST("/runbook/exec/:id/step/:step_id/retry", h.RetryStep)
+	r.GET("/runbook/:id", h.GetRunbook)
+	r.PUT("/runbook/:id", h.UpdateRunbook)
+	r.DELETE("/runbook/:id", h.DeleteRunbook)
+	r.POST("/colony/:id/runbook", h.StartRunbook)
+}
@@ -0,0 +1,306 @@
+package service
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	commodel "gin-artweb/internal/model/common"
+	oesmodel "gin-artweb/internal/model/oes"
+	oessvc "gin-artweb/internal/service/oes"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+)
+
+type OesTaskSlaHandler struct {
+	log    *zap.Logger
+	svcSla *oessvc.OesTaskSlaService
+}
+
+func NewOesTaskSlaHandler(
+	logger *zap.Logger,
+	svcSla *oessvc.OesTaskSlaService,
+) *OesTaskSlaHandler {
+	return &OesTaskSlaHandler{
+		log:    logger,
+		svcSla: svcSla,
+	}
+}
+
+// @Summary      创建任务SLA
+// @Description  本接口用于创建oes日常任务的SLA，定义任务每个交易日的完成截止时间，集群ID为0时适用全部集群
+// @Tags         oes任务SLA
+// @Accept       json
+// @Produce      json
+// @Param        request body oesmodel.OesTaskSlaRequest true "任务SLA"
+// @Success      201  {object} oesmodel.OesTaskSlaReply "成功返回任务SLA"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      404  {object} errors.Error "集群不存在"
+// @Failure      409  {object} errors.Error "该任务在集群上已定义SLA"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/oes/sla [post]
+// @Security ApiKeyAuth
+func (h *OesTaskSlaHandler) CreateTaskSla(ctx *gin.Context) {
+	var req oesmodel.OesTaskSlaRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定创建任务SLA参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := h.svcSla.CreateTaskSla(ctx, req.ToModel())
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, &oesmodel.OesTaskSlaReply{
+		Code: http.StatusCreated,
+		Data: oesmodel.OesTaskSlaToOut(*m),
+	})
+}
+
+// @Summary      更新任务SLA
+// @Description  本接口用于更新指定ID的任务SLA，下次检查时生效
+// @Tags         oes任务SLA
+// @Accept       json
+// @Produce      json
+// @Param        id path uint32 true "SLA ID"
+// @Param        request body oesmodel.OesTaskSlaRequest true "任务SLA"
+// @Success      200  {object} oesmodel.OesTaskSlaReply "成功返回任务SLA"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      404  {object} errors.Error "任务SLA或集群不存在"
+// @Failure      409  {object} errors.Error "该任务在集群上已定义SLA"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/oes/sla/{id} [put]
+// @Security ApiKeyAuth
+func (h *OesTaskSlaHandler) UpdateTaskSla(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定SLA ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	var req oesmodel.OesTaskSlaRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定更新任务SLA参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	rm := req.ToModel()
+	rm.ID = uri.ID
+	m, rErr := h.svcSla.UpdateTaskSlaByID(ctx, rm)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &oesmodel.OesTaskSlaReply{
+		Code: http.StatusOK,
+		Data: oesmodel.OesTaskSlaToOut(*m),
+	})
+}
+
+// @Summary      删除任务SLA
+// @Description  本接口用于删除指定ID的任务SLA，已保存的检查结果保留
+// @Tags         oes任务SLA
+// @Produce      json
+// @Param        id path uint32 true "SLA ID"
+// @Success      200  {object} commodel.MapAPIReply "删除成功"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      404  {object} errors.Error "任务SLA不存在"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/oes/sla/{id} [delete]
+// @Security ApiKeyAuth
+func (h *OesTaskSlaHandler) DeleteTaskSla(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定SLA ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	if rErr := h.svcSla.DeleteTaskSlaByID(ctx, uri.ID); rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(commodel.NoDataReply.Code, commodel.NoDataReply)
+}
+
+// @Summary      查询任务SLA详情
+// @Description  本接口用于查询指定ID的任务SLA
+// @Tags         oes任务SLA
+// @Produce      json
+// @Param        id path uint32 true "SLA ID"
+// @Success      200  {object} oesmodel.OesTaskSlaReply "成功返回任务SLA"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      404  {object} errors.Error "任务SLA不存在"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/oes/sla/{id} [get]
+// @Security ApiKeyAuth
+func (h *OesTaskSlaHandler) GetTaskSla(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定SLA ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := h.svcSla.FindTaskSlaByID(ctx, uri.ID)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &oesmodel.OesTaskSlaReply{
+		Code: http.StatusOK,
+		Data: oesmodel.OesTaskSlaToOut(*m),
+	})
+}
+
+// @Summary      查询任务SLA列表
+// @Description  本接口用于查询任务SLA列表，支持按任务名称、集群和启用状态过滤
+// @Tags         oes任务SLA
+// @Produce      json
+// @Param        request query oesmodel.ListOesTaskSlaRequest false "查询参数"
+// @Success      200  {object} oesmodel.PagOesTaskSlaReply "成功返回任务SLA列表"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/oes/sla [get]
+// @Security ApiKeyAuth
+func (h *OesTaskSlaHandler) ListTaskSla(ctx *gin.Context) {
+	var req oesmodel.ListOesTaskSlaRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定查询任务SLA列表参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	page, size, query := req.Query()
+	qp := database.QueryParams{
+		IsCount:   true,
+		SkipCount: req.SkipCount(),
+		Size:      size,
+		Page:      page,
+		OrderBy:   []string{"id ASC"},
+		Query:     query,
+	}
+	total, ms, rErr := h.svcSla.ListTaskSla(ctx, qp)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	mbs := oesmodel.ListOesTaskSlaToOut(ms)
+	ctx.JSON(http.StatusOK, &oesmodel.PagOesTaskSlaReply{
+		Code: http.StatusOK,
+		Data: commodel.NewPag(qp, total, mbs),
+	})
+}
+
+// @Summary      查询每日SLA达成情况
+// @Description  本接口用于查询交易日各集群日常任务的SLA检查结果，统计按时完成、延迟完成、已超时和即将超时的任务数及达成率
+// @Tags         oes任务SLA
+// @Produce      json
+// @Param        request query oesmodel.TaskSlaComplianceRequest false "查询参数"
+// @Success      200  {object} oesmodel.TaskSlaComplianceReply "成功返回SLA达成情况"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/oes/sla/compliance [get]
+// @Security ApiKeyAuth
+func (h *OesTaskSlaHandler) GetCompliance(ctx *gin.Context) {
+	var req oesmodel.TaskSlaComplianceRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定查询SLA达成情况参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+	if req.TradingDay == "" {
+		req.TradingDay = time.Now().Format(time.DateOnly)
+	}
+
+	out, rErr := h.svcSla.Compliance(ctx, req.TradingDay, req.OesColonyID)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &oesmodel.TaskSlaComplianceReply{
+		Code: http.StatusOK,
+		Data: out,
+	})
+}
+
+// @Summary      立即检查任务SLA
+// @Description  本接口用于立即检查全部启用集群当天日常任务的SLA并返回当天的达成情况，非交易日不检查
+// @Tags         oes任务SLA
+// @Produce      json
+// @Success      200  {object} oesmodel.TaskSlaComplianceReply "成功返回SLA达成情况"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/oes/sla/check [post]
+// @Security ApiKeyAuth
+func (h *OesTaskSlaHandler) CheckTaskSla(ctx *gin.Context) {
+	if rErr := h.svcSla.CheckAllColonies(ctx); rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"检查oes任务SLA失败",
+			zap.Error(rErr),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	out, rErr := h.svcSla.Compliance(ctx, time.Now().Format(time.DateOnly), 0)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &oesmodel.TaskSlaComplianceReply{
+		Code: http.StatusOK,
+		Data: out,
+	})
+}
+
+func (h *OesTaskSlaHandler) LoadRouter(r *gin.RouterGroup) {
+	r.POST("/sla", h.CreateTaskSla)
+	r.GET("/sla", h.ListTaskSla)
+	r.GET("/sla/compliance", h.GetCompliance)
+	r.POST("/sla/check", h.CheckTaskSla)
+	r.GET("/sla/:id", h.GetTaskSla)
+	r.PUT("/sla/:id", h.UpdateTaskSla)
+	r.DELETE("/sla/:id", h.DeleteTaskSla)
+}
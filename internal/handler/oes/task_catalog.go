@@ -0,0 +1,235 @@
+package service
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	commodel "gin-artweb/internal/model/common"
+	oesmodel "gin-artweb/internal/model/oes"
+	oessvc "gin-artweb/internal/service/oes"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+)
+
+type OesTaskCatalogHandler struct {
+	log        *zap.Logger
+	svcCatalog *oessvc.OesTaskCatalogService
+}
+
+func NewOesTaskCatalogHandler(
+	logger *zap.Logger,
+	svcCatalog *oessvc.OesTaskCatalogService,
+) *OesTaskCatalogHandler {
+	return &OesTaskCatalogHandler{
+		log:        logger,
+		svcCatalog: svcCatalog,
+	}
+}
+
+// @Summary      创建日常任务
+// @Description  本接口用于在日常任务目录中新增系统类型的集群需要执行的任务，任务名称同时是集群任务标识文件的名称
+// @Tags         oes日常任务目录
+// @Accept       json
+// @Produce      json
+// @Param        request body oesmodel.OesTaskCatalogRequest true "日常任务"
+// @Success      201  {object} oesmodel.OesTaskCatalogReply "成功返回日常任务"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      409  {object} errors.Error "该系统类型已存在同名任务"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/oes/task/catalog [post]
+// @Security ApiKeyAuth
+func (h *OesTaskCatalogHandler) CreateTaskCatalog(ctx *gin.Context) {
+	var req oesmodel.OesTaskCatalogRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定创建日常任务参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := h.svcCatalog.CreateTaskCatalog(ctx, req.ToModel())
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, &oesmodel.OesTaskCatalogReply{
+		Code: http.StatusCreated,
+		Data: oesmodel.OesTaskCatalogToOut(*m),
+	})
+}
+
+// @Summary      更新日常任务
+// @Description  本接口用于更新日常任务目录中指定ID的任务，下次查询任务状态和检查SLA时生效
+// @Tags         oes日常任务目录
+// @Accept       json
+// @Produce      json
+// @Param        id path uint32 true "日常任务ID"
+// @Param        request body oesmodel.OesTaskCatalogRequest true "日常任务"
+// @Success      200  {object} oesmodel.OesTaskCatalogReply "成功返回日常任务"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      404  {object} errors.Error "日常任务不存在"
+// @Failure      409  {object} errors.Error "该系统类型已存在同名任务"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/oes/task/catalog/{id} [put]
+// @Security ApiKeyAuth
+func (h *OesTaskCatalogHandler) UpdateTaskCatalog(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定日常任务ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	var req oesmodel.OesTaskCatalogRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定更新日常任务参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	rm := req.ToModel()
+	rm.ID = uri.ID
+	m, rErr := h.svcCatalog.UpdateTaskCatalogByID(ctx, rm)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &oesmodel.OesTaskCatalogReply{
+		Code: http.StatusOK,
+		Data: oesmodel.OesTaskCatalogToOut(*m),
+	})
+}
+
+// @Summary      删除日常任务
+// @Description  本接口用于从日常任务目录中删除指定ID的任务，已定义的SLA不再检查
+// @Tags         oes日常任务目录
+// @Produce      json
+// @Param        id path uint32 true "日常任务ID"
+// @Success      200  {object} commodel.MapAPIReply "删除成功"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      404  {object} errors.Error "日常任务不存在"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/oes/task/catalog/{id} [delete]
+// @Security ApiKeyAuth
+func (h *OesTaskCatalogHandler) DeleteTaskCatalog(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定日常任务ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	if rErr := h.svcCatalog.DeleteTaskCatalogByID(ctx, uri.ID); rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(commodel.NoDataReply.Code, commodel.NoDataReply)
+}
+
+// @Summary      查询日常任务详情
+// @Description  本接口用于查询日常任务目录中指定ID的任务
+// @Tags         oes日常任务目录
+// @Produce      json
+// @Param        id path uint32 true "日常任务ID"
+// @Success      200  {object} oesmodel.OesTaskCatalogReply "成功返回日常任务"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      404  {object} errors.Error "日常任务不存在"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/oes/task/catalog/{id} [get]
+// @Security ApiKeyAuth
+func (h *OesTaskCatalogHandler) GetTaskCatalog(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定日常任务ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := h.svcCatalog.FindTaskCatalogByID(ctx, uri.ID)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &oesmodel.OesTaskCatalogReply{
+		Code: http.StatusOK,
+		Data: oesmodel.OesTaskCatalogToOut(*m),
+	})
+}
+
+// @Summary      查询日常任务目录
+// @Description  本接口用于查询日常任务目录，支持按系统类型、任务名称和启用状态过滤，按系统类型和排序顺序返回
+// @Tags         oes日常任务目录
+// @Produce      json
+// @Param        request query oesmodel.ListOesTaskCatalogRequest false "查询参数"
+// @Success      200  {object} oesmodel.PagOesTaskCatalogReply "成功返回日常任务目录"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/oes/task/catalog [get]
+// @Security ApiKeyAuth
+func (h *OesTaskCatalogHandler) ListTaskCatalog(ctx *gin.Context) {
+	var req oesmodel.ListOesTaskCatalogRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定查询日常任务目录参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	page, size, query := req.Query()
+	qp := database.QueryParams{
+		IsCount:   true,
+		SkipCount: req.SkipCount(),
+		Size:      size,
+		Page:      page,
+		OrderBy:   []string{"system_type ASC", "sort ASC", "id ASC"},
+		Query:     query,
+	}
+	total, ms, rErr := h.svcCatalog.ListTaskCatalog(ctx, qp)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	mbs := oesmodel.ListOesTaskCatalogToOut(ms)
+	ctx.JSON(http.StatusOK, &oesmodel.PagOesTaskCatalogReply{
+		Code: http.StatusOK,
+		Data: commodel.NewPag(qp, total, mbs),
+	})
+}
+
+func (h *OesTaskCatalogHandler) LoadRouter(r *gin.RouterGroup) {
+	r.POST("/task/catalog", h.CreateTaskCatalog)
+	r.GET("/task/catalog", h.ListTaskCatalog)
+	r.GET("/task/catalog/:id", h.GetTaskCatalog)
+	r.PUT("/task/catalog/:id", h.UpdateTaskCatalog)
+	r.DELETE("/task/catalog/:id", h.DeleteTaskCatalog)
+}
@@ -0,0 +1,360 @@
+package service
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	commodel "gin-artweb/internal/model/common"
+	oesmodel "gin-artweb/internal/model/oes"
+	oessvc "gin-artweb/internal/service/oes"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+)
+
+type OesConfTemplateHandler struct {
+	log         *zap.Logger
+	svcTemplate *oessvc.OesConfTemplateService
+}
+
+func NewOesConfTemplateHandler(
+	logger *zap.Logger,
+	svcTemplate *oessvc.OesConfTemplateService,
+) *OesConfTemplateHandler {
+	return &OesConfTemplateHandler{
+		log:         logger,
+		svcTemplate: svcTemplate,
+	}
+}
+
+// @Summary      创建配置模板
+// @Description  本接口用于创建oes配置文件模板，内容和目标路径使用Go模板语法。
+// @Description  可用变量：.Colony(ID/SystemType/ColonyNum/ExtractedName/Version/XCounterVersion/MonNodeName/MonNodeURL)，
+// @Description  .Node(ID/NodeRole/Specdir/HostID/HostName/SSHIP/SSHPort/SSHUser)，.Nodes为集群全部启用节点；可用函数add、mul、int。
+// @Tags         oes配置管理
+// @Accept       json
+// @Produce      json
+// @Param        request body oesmodel.OesConfTemplateRequest true "配置模板"
+// @Success      201  {object} oesmodel.OesConfTemplateReply "成功返回配置模板"
+// @Failure      400  {object} errors.Error "请求参数错误或模板语法错误"
+// @Failure      409  {object} errors.Error "模板名称已存在"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/oes/conf/template [post]
+// @Security ApiKeyAuth
+func (h *OesConfTemplateHandler) CreateOesConfTemplate(ctx *gin.Context) {
+	var req oesmodel.OesConfTemplateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定创建配置模板参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := h.svcTemplate.CreateOesConfTemplate(ctx, oesmodel.OesConfTemplateModel{
+		Name:       req.Name,
+		DirName:    req.DirName,
+		RemotePath: req.RemotePath,
+		Content:    req.Content,
+		Remark:     req.Remark,
+	})
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, &oesmodel.OesConfTemplateReply{
+		Code: http.StatusCreated,
+		Data: *oesmodel.OesConfTemplateToOut(*m),
+	})
+}
+
+// @Summary      更新配置模板
+// @Description  本接口用于更新指定ID的oes配置文件模板
+// @Tags         oes配置管理
+// @Accept       json
+// @Produce      json
+// @Param        id path uint32 true "模板ID"
+// @Param        request body oesmodel.OesConfTemplateRequest true "配置模板"
+// @Success      200  {object} oesmodel.OesConfTemplateReply "成功返回配置模板"
+// @Failure      400  {object} errors.Error "请求参数错误或模板语法错误"
+// @Failure      404  {object} errors.Error "模板不存在"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/oes/conf/template/{id} [put]
+// @Security ApiKeyAuth
+func (h *OesConfTemplateHandler) UpdateOesConfTemplate(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定配置模板ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	var req oesmodel.OesConfTemplateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定更新配置模板参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := h.svcTemplate.UpdateOesConfTemplateById(ctx, oesmodel.OesConfTemplateModel{
+		StandardModel: database.StandardModel{
+			BaseModel: database.BaseModel{ID: uri.ID},
+		},
+		Name:       req.Name,
+		DirName:    req.DirName,
+		RemotePath: req.RemotePath,
+		Content:    req.Content,
+		Remark:     req.Remark,
+	})
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &oesmodel.OesConfTemplateReply{
+		Code: http.StatusOK,
+		Data: *oesmodel.OesConfTemplateToOut(*m),
+	})
+}
+
+// @Summary      删除配置模板
+// @Description  本接口用于删除指定ID的oes配置文件模板，不影响已下发的文件
+// @Tags         oes配置管理
+// @Produce      json
+// @Param        id path uint32 true "模板ID"
+// @Success      200  {object} commodel.MapAPIReply "删除成功"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      404  {object} errors.Error "模板不存在"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/oes/conf/template/{id} [delete]
+// @Security ApiKeyAuth
+func (h *OesConfTemplateHandler) DeleteOesConfTemplate(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定配置模板ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	if rErr := h.svcTemplate.DeleteOesConfTemplateById(ctx, uri.ID); rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(commodel.NoDataReply.Code, commodel.NoDataReply)
+}
+
+// @Summary      查询配置模板详情
+// @Description  本接口用于查询指定ID的oes配置文件模板
+// @Tags         oes配置管理
+// @Produce      json
+// @Param        id path uint32 true "模板ID"
+// @Success      200  {object} oesmodel.OesConfTemplateReply "成功返回配置模板"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      404  {object} errors.Error "模板不存在"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/oes/conf/template/{id} [get]
+// @Security ApiKeyAuth
+func (h *OesConfTemplateHandler) GetOesConfTemplate(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定配置模板ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := h.svcTemplate.FindOesConfTemplateById(ctx, uri.ID)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &oesmodel.OesConfTemplateReply{
+		Code: http.StatusOK,
+		Data: *oesmodel.OesConfTemplateToOut(*m),
+	})
+}
+
+// @Summary      查询配置模板列表
+// @Description  本接口用于查询oes配置文件模板列表，支持按名称和适用节点目录过滤
+// @Tags         oes配置管理
+// @Produce      json
+// @Param        page query int false "页码" minimum(1)
+// @Param        size query int false "每页数量" minimum(1) maximum(100)
+// @Param        name query string false "名称"
+// @Param        dir_name query string false "适用节点目录"
+// @Success      200  {object} oesmodel.PagOesConfTemplateReply "成功返回配置模板列表"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/oes/conf/template [get]
+// @Security ApiKeyAuth
+func (h *OesConfTemplateHandler) ListOesConfTemplate(ctx *gin.Context) {
+	var req oesmodel.ListOesConfTemplateRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定查询配置模板列表参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	page, size, query := req.Query()
+	qp := database.QueryParams{
+		IsCount:   true,
+		SkipCount: req.SkipCount(),
+		Size:      size,
+		Page:      page,
+		OrderBy:   []string{"id ASC"},
+		Query:     query,
+	}
+	total, ms, rErr := h.svcTemplate.ListOesConfTemplate(ctx, qp)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	mbs := oesmodel.ListOesConfTemplateToOut(ms)
+	ctx.JSON(http.StatusOK, &oesmodel.PagOesConfTemplateReply{
+		Code: http.StatusOK,
+		Data: commodel.NewPag(qp, total, mbs),
+	})
+}
+
+// @Summary      预览配置模板渲染结果
+// @Description  本接口使用指定集群的记录渲染模板，返回每个目标节点的渲染内容及与主机上当前文件的差异(unified格式)，不修改主机文件
+// @Tags         oes配置管理
+// @Accept       json
+// @Produce      json
+// @Param        id path uint32 true "模板ID"
+// @Param        request body oesmodel.RenderOesConfTemplateRequest true "目标集群"
+// @Success      200  {object} oesmodel.OesConfRenderReply "成功返回渲染结果"
+// @Failure      400  {object} errors.Error "请求参数错误或模板渲染失败"
+// @Failure      404  {object} errors.Error "模板或集群不存在"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/oes/conf/template/{id}/render [post]
+// @Security ApiKeyAuth
+func (h *OesConfTemplateHandler) RenderOesConfTemplate(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定配置模板ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	var req oesmodel.RenderOesConfTemplateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定预览配置模板参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	outs, rErr := h.svcTemplate.RenderOesConfTemplate(ctx, uri.ID, req.OesColonyID)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &oesmodel.OesConfRenderReply{
+		Code: http.StatusOK,
+		Data: outs,
+	})
+}
+
+// @Summary      下发配置文件
+// @Description  本接口使用指定集群的记录渲染模板并通过SFTP写入目标节点主机，内容未变化的节点不写入。
+// @Description  全部节点渲染成功后才开始下发，单个节点下发失败时在结果中返回错误，不影响其他节点。
+// @Tags         oes配置管理
+// @Accept       json
+// @Produce      json
+// @Param        id path uint32 true "模板ID"
+// @Param        request body oesmodel.DeployOesConfTemplateRequest true "目标集群和节点"
+// @Success      200  {object} oesmodel.OesConfDeployReply "成功返回各节点的下发结果"
+// @Failure      400  {object} errors.Error "请求参数错误或模板渲染失败"
+// @Failure      404  {object} errors.Error "模板或集群不存在"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/oes/conf/template/{id}/deploy [post]
+// @Security ApiKeyAuth
+func (h *OesConfTemplateHandler) DeployOesConfTemplate(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定配置模板ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	var req oesmodel.DeployOesConfTemplateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定下发配置文件参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	claims, rErr := ctxutil.GetUserClaims(ctx)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"获取个人登录信息失败",
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	outs, rErr := h.svcTemplate.DeployOesConfTemplate(ctx, uri.ID, req.OesColonyID, req.NodeIDs, claims.Username)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &oesmodel.OesConfDeployReply{
+		Code: http.StatusOK,
+		Data: outs,
+	})
+}
+
+func (h *OesConfTemplateHandler) LoadRouter(r *gin.RouterGroup) {
+	r.POST("/conf/template", h.CreateOesConfTemplate)
+	r.PUT("/conf/template/:id", h.UpdateOesConfTemplate)
+	r.DELETE("/conf/template/:id", h.DeleteOesConfTemplate)
+	r.GET("/conf/template/:id", h.GetOesConfTemplate)
+	r.GET("/conf/template", h.ListOesConfTemplate)
+	r.POST("/conf/template/:id/render", h.RenderOesConfTemplate)
+	r.POST("/conf/template/:id/deploy", h.DeployOesConfTemplate)
+}
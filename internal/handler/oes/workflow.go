@@ -0,0 +1,220 @@
+package service
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	commodel "gin-artweb/internal/model/common"
+	oesmodel "gin-artweb/internal/model/oes"
+	oessvc "gin-artweb/internal/service/oes"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+)
+
+type OesWorkflowHandler struct {
+	log        *zap.Logger
+	ucWorkflow *oessvc.OesWorkflowService
+}
+
+func NewOesWorkflowHandler(
+	logger *zap.Logger,
+	ucWorkflow *oessvc.OesWorkflowService,
+) *OesWorkflowHandler {
+	return &OesWorkflowHandler{
+		log:        logger,
+		ucWorkflow: ucWorkflow,
+	}
+}
+
+// @Summary 查询oes任务编排定义
+// @Description 本接口用于查询指定系统类型的日常任务步骤及依赖关系
+// @Tags oes任务编排
+// @Accept json
+// @Produce json
+// @Param system_type path string true "系统类型(STK/CRD/OPT)"
+// @Success 200 {object} oesmodel.OesWorkflowDefinitionReply "成功返回编排定义"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 404 {object} errors.Error "未配置该系统类型的任务编排"
+// @Failure 422 {object} errors.Error "任务编排配置无效"
+// @Router /api/v1/oes/workflow/{system_type} [get]
+// @Security ApiKeyAuth
+func (h *OesWorkflowHandler) GetWorkflowDefinition(ctx *gin.Context) {
+	var uri oesmodel.WorkflowSystemTypeUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定oes任务编排系统类型参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	steps, _, rErr := h.ucWorkflow.LoadWorkflowDefinition(ctx, uri.SystemType)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询oes任务编排定义失败",
+			zap.Error(rErr),
+			zap.String("system_type", uri.SystemType),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &oesmodel.OesWorkflowDefinitionReply{
+		Code: http.StatusOK,
+		Data: steps,
+	})
+}
+
+// @Summary 发起oes任务编排
+// @Description 本接口用于按集群系统类型的编排定义发起日常任务, 依赖步骤成功后自动执行下游步骤
+// @Tags oes任务编排
+// @Accept json
+// @Produce json
+// @Param id path uint true "oes集群编号"
+// @Success 200 {object} oesmodel.OesWorkflowRunReply "成功返回编排执行记录"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 404 {object} errors.Error "oes集群或任务脚本未找到"
+// @Failure 409 {object} errors.Error "集群已有正在执行的任务编排"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/oes/colony/{id}/workflow [post]
+// @Security ApiKeyAuth
+func (h *OesWorkflowHandler) StartWorkflow(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定发起oes任务编排ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	claims, rErr := ctxutil.GetUserClaims(ctx)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"获取个人登录信息失败",
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := h.ucWorkflow.StartWorkflow(ctx, uri.ID, claims.Username)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"发起oes任务编排失败",
+			zap.Error(rErr),
+			zap.Uint32(commodel.RequestIDKey, uri.ID),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &oesmodel.OesWorkflowRunReply{
+		Code: http.StatusOK,
+		Data: *oesmodel.OesWorkflowRunToDetailOut(*m),
+	})
+}
+
+// @Summary 查询oes任务编排执行详情
+// @Description 本接口用于查询编排执行记录及各步骤的状态和耗时
+// @Tags oes任务编排
+// @Accept json
+// @Produce json
+// @Param id path uint true "编排执行记录编号"
+// @Success 200 {object} oesmodel.OesWorkflowRunReply "成功返回编排执行详情"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 404 {object} errors.Error "编排执行记录未找到"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/oes/workflow/run/{id} [get]
+// @Security ApiKeyAuth
+func (h *OesWorkflowHandler) GetWorkflowRun(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定查询oes任务编排ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := h.ucWorkflow.FindWorkflowRunByID(ctx, uri.ID)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询oes任务编排执行详情失败",
+			zap.Error(rErr),
+			zap.Uint32(commodel.RequestIDKey, uri.ID),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &oesmodel.OesWorkflowRunReply{
+		Code: http.StatusOK,
+		Data: *oesmodel.OesWorkflowRunToDetailOut(*m),
+	})
+}
+
+// @Summary 查询oes任务编排执行记录列表
+// @Description 本接口用于分页查询oes任务编排执行记录
+// @Tags oes任务编排
+// @Accept json
+// @Produce json
+// @Param request query oesmodel.ListOesWorkflowRunRequest false "查询参数"
+// @Success 200 {object} oesmodel.PagOesWorkflowRunReply "成功返回编排执行记录列表"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/oes/workflow/run [get]
+// @Security ApiKeyAuth
+func (h *OesWorkflowHandler) ListWorkflowRun(ctx *gin.Context) {
+	var req oesmodel.ListOesWorkflowRunRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定查询oes任务编排列表参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	page, size, query := req.Query()
+	qp := database.QueryParams{
+		IsCount:   true,
+		SkipCount: req.SkipCount(),
+		Size:      size,
+		Page:      page,
+		OrderBy:   []string{"id DESC"},
+		Query:     query,
+	}
+	total, ms, rErr := h.ucWorkflow.ListWorkflowRun(ctx, qp)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询oes任务编排列表失败",
+			zap.Error(rErr),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	mbs := oesmodel.ListOesWorkflowRunToOut(ms)
+	ctx.JSON(http.StatusOK, &oesmodel.PagOesWorkflowRunReply{
+		Code: http.StatusOK,
+		Data: commodel.NewPag(qp, total, mbs),
+	})
+}
+
+func (h *OesWorkflowHandler) LoadRouter(r *gin.RouterGroup) {
+	r.POST("/colony/:id/workflow", h.StartWorkflow)
+	r.GET("/workflow/run", h.ListWorkflowRun)
+	r.GET("/workflow/run/:id", h.GetWorkflowRun)
+	r.GET("/workflow/:system_type", h.GetWorkflowDefinition)
+}
@@ -0,0 +1,113 @@
+package resource
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	commodel "gin-artweb/internal/model/common"
+	resomodel "gin-artweb/internal/model/resource"
+	resosvc "gin-artweb/internal/service/resource"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/errors"
+)
+
+type HostAgentHandler struct {
+	log      *zap.Logger
+	svcAgent *resosvc.HostAgentService
+}
+
+func NewHostAgentHandler(
+	logger *zap.Logger,
+	svcAgent *resosvc.HostAgentService,
+) *HostAgentHandler {
+	return &HostAgentHandler{
+		log:      logger,
+		svcAgent: svcAgent,
+	}
+}
+
+// @Summary 注册主机代理
+// @Description 本接口供主机代理启动时调用, 上报主机信息, 按SSH地址、端口和用户匹配已有主机, 不存在时自动创建主机
+// @Tags 主机代理
+// @Accept json
+// @Produce json
+// @Param X-Agent-Token header string true "主机代理令牌"
+// @Param request body resomodel.RegisterHostAgentRequest true "注册主机代理请求"
+// @Success 200 {object} resomodel.HostAgentReply "成功返回主机代理信息, 心跳使用其中的主机ID"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 401 {object} errors.Error "主机代理令牌无效"
+// @Failure 409 {object} errors.Error "主机名称已被其他主机使用"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/agent/register [post]
+func (h *HostAgentHandler) RegisterHostAgent(ctx *gin.Context) {
+	var req resomodel.RegisterHostAgentRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定注册主机代理参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := h.svcAgent.Register(ctx, req, ctx.ClientIP())
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"注册主机代理失败",
+			zap.Error(rErr),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &resomodel.HostAgentReply{
+		Code: http.StatusOK,
+		Data: *resomodel.HostAgentToOut(*m),
+	})
+}
+
+// @Summary 主机代理心跳
+// @Description 本接口供主机代理定时调用, 超过失联时间未上报心跳的主机标记为离线, 主机不存在时代理需要重新注册; 可同时上报主机基础指标供mon模块绘制图表
+// @Tags 主机代理
+// @Accept json
+// @Produce json
+// @Param X-Agent-Token header string true "主机代理令牌"
+// @Param request body resomodel.HostAgentHeartbeatRequest true "心跳请求"
+// @Success 200 {object} commodel.MapAPIReply "上报成功"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 401 {object} errors.Error "主机代理令牌无效"
+// @Failure 404 {object} errors.Error "主机代理未注册"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/agent/heartbeat [post]
+func (h *HostAgentHandler) Heartbeat(ctx *gin.Context) {
+	var req resomodel.HostAgentHeartbeatRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定主机代理心跳参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	if rErr := h.svcAgent.Heartbeat(ctx, req.HostID, req.Metrics); rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"更新主机代理心跳失败",
+			zap.Error(rErr),
+			zap.Uint32("host_id", req.HostID),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(commodel.NoDataReply.Code, commodel.NoDataReply)
+}
+
+func (h *HostAgentHandler) LoadRouter(r *gin.RouterGroup) {
+	r.POST("/register", h.RegisterHostAgent)
+	r.POST("/heartbeat", h.Heartbeat)
+}
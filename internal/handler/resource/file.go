@@ -0,0 +1,431 @@
+package resource
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	commodel "gin-artweb/internal/model/common"
+	resomodel "gin-artweb/internal/model/resource"
+	resosvc "gin-artweb/internal/service/resource"
+	"gin-artweb/internal/shared/auth"
+	"gin-artweb/internal/shared/common"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+)
+
+type HostFileHandler struct {
+	log     *zap.Logger
+	svcFile *resosvc.HostFileService
+	maxSize int64
+}
+
+func NewHostFileHandler(
+	logger *zap.Logger,
+	svcFile *resosvc.HostFileService,
+	maxSize int64,
+) *HostFileHandler {
+	return &HostFileHandler{
+		log:     logger,
+		svcFile: svcFile,
+		maxSize: maxSize,
+	}
+}
+
+// @Summary      创建主机路径规则
+// @Description  本接口用于为角色配置允许通过文件管理访问的主机目录，规则对全部主机生效
+// @Tags         主机文件管理
+// @Accept       json
+// @Produce      json
+// @Param        request body resomodel.HostPathRuleRequest true "主机路径规则"
+// @Success      201  {object} resomodel.HostPathRuleReply "成功返回主机路径规则"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      409  {object} errors.Error "规则已存在"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/resource/file/rule [post]
+// @Security ApiKeyAuth
+func (h *HostFileHandler) CreateHostPathRule(ctx *gin.Context) {
+	var req resomodel.HostPathRuleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定创建主机路径规则参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := h.svcFile.CreateHostPathRule(ctx, resomodel.HostPathRuleModel{
+		RoleID:   req.RoleID,
+		Path:     req.Path,
+		Writable: req.Writable,
+		Remark:   req.Remark,
+	})
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, &resomodel.HostPathRuleReply{
+		Code: http.StatusCreated,
+		Data: *resomodel.HostPathRuleToOut(*m),
+	})
+}
+
+// @Summary      更新主机路径规则
+// @Description  本接口用于更新指定ID的主机路径规则
+// @Tags         主机文件管理
+// @Accept       json
+// @Produce      json
+// @Param        id path uint32 true "规则ID"
+// @Param        request body resomodel.HostPathRuleRequest true "主机路径规则"
+// @Success      200  {object} resomodel.HostPathRuleReply "成功返回主机路径规则"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      404  {object} errors.Error "规则不存在"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/resource/file/rule/{id} [put]
+// @Security ApiKeyAuth
+func (h *HostFileHandler) UpdateHostPathRule(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定主机路径规则ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	var req resomodel.HostPathRuleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定更新主机路径规则参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := h.svcFile.UpdateHostPathRuleById(ctx, resomodel.HostPathRuleModel{
+		StandardModel: database.StandardModel{
+			BaseModel: database.BaseModel{ID: uri.ID},
+		},
+		RoleID:   req.RoleID,
+		Path:     req.Path,
+		Writable: req.Writable,
+		Remark:   req.Remark,
+	})
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &resomodel.HostPathRuleReply{
+		Code: http.StatusOK,
+		Data: *resomodel.HostPathRuleToOut(*m),
+	})
+}
+
+// @Summary      删除主机路径规则
+// @Description  本接口用于删除指定ID的主机路径规则
+// @Tags         主机文件管理
+// @Produce      json
+// @Param        id path uint32 true "规则ID"
+// @Success      200  {object} commodel.MapAPIReply "删除成功"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      404  {object} errors.Error "规则不存在"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/resource/file/rule/{id} [delete]
+// @Security ApiKeyAuth
+func (h *HostFileHandler) DeleteHostPathRule(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定主机路径规则ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	if rErr := h.svcFile.DeleteHostPathRuleById(ctx, uri.ID); rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(commodel.NoDataReply.Code, commodel.NoDataReply)
+}
+
+// @Summary      查询主机路径规则列表
+// @Description  本接口用于查询主机路径规则列表，支持按角色过滤
+// @Tags         主机文件管理
+// @Produce      json
+// @Param        page query int false "页码" minimum(1)
+// @Param        size query int false "每页数量" minimum(1) maximum(100)
+// @Param        role_id query uint32 false "角色ID"
+// @Success      200  {object} resomodel.PagHostPathRuleReply "成功返回主机路径规则列表"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/resource/file/rule [get]
+// @Security ApiKeyAuth
+func (h *HostFileHandler) ListHostPathRule(ctx *gin.Context) {
+	var req resomodel.ListHostPathRuleRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定查询主机路径规则列表参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	page, size, query := req.Query()
+	qp := database.QueryParams{
+		IsCount:   true,
+		SkipCount: req.SkipCount(),
+		Size:      size,
+		Page:      page,
+		OrderBy:   []string{"id ASC"},
+		Query:     query,
+	}
+	total, ms, rErr := h.svcFile.ListHostPathRule(ctx, qp)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	mbs := resomodel.ListHostPathRuleToOut(ms)
+	ctx.JSON(http.StatusOK, &resomodel.PagHostPathRuleReply{
+		Code: http.StatusOK,
+		Data: commodel.NewPag(qp, total, mbs),
+	})
+}
+
+// bindHostFile 绑定主机ID和路径参数并获取当前用户, 失败时写入错误响应
+func (h *HostFileHandler) bindHostFile(ctx *gin.Context) (uint32, string, *auth.UserClaims, bool) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定主机ID参数失败",
+			zap.Error(err),
+		)
+		errors.RespondWithError(ctx, errors.ErrValidationFailed.WithCause(err))
+		return 0, "", nil, false
+	}
+
+	var req resomodel.HostFileRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定主机文件路径参数失败",
+			zap.Error(err),
+		)
+		errors.RespondWithError(ctx, errors.ErrValidationFailed.WithCause(err))
+		return 0, "", nil, false
+	}
+
+	claims, rErr := ctxutil.GetUserClaims(ctx)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"获取个人登录信息失败",
+		)
+		errors.RespondWithError(ctx, rErr)
+		return 0, "", nil, false
+	}
+	return uri.ID, req.Path, claims, true
+}
+
+// @Summary      列出主机目录
+// @Description  本接口用于列出主机上指定目录的内容，目录须在当前角色的主机路径规则允许的范围内
+// @Tags         主机文件管理
+// @Produce      json
+// @Param        id path uint32 true "主机唯一标识符"
+// @Param        path query string true "目录的绝对路径"
+// @Success      200  {object} resomodel.ListHostFileReply "成功返回目录内容"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      403  {object} errors.Error "路径不在允许访问的范围内"
+// @Failure      404  {object} errors.Error "主机或目录不存在"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/resource/host/{id}/file [get]
+// @Security ApiKeyAuth
+func (h *HostFileHandler) ListHostDir(ctx *gin.Context) {
+	hostId, p, claims, ok := h.bindHostFile(ctx)
+	if !ok {
+		return
+	}
+
+	dir, fis, rErr := h.svcFile.ListHostDir(ctx, hostId, claims.UserID, claims.RoleID, p)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &resomodel.ListHostFileReply{
+		Code: http.StatusOK,
+		Data: resomodel.ListHostFileToOut(dir, fis),
+	})
+}
+
+// @Summary      查询主机文件信息
+// @Description  本接口用于查询主机上指定文件或目录的信息，路径中的符号链接会被解析
+// @Tags         主机文件管理
+// @Produce      json
+// @Param        id path uint32 true "主机唯一标识符"
+// @Param        path query string true "文件或目录的绝对路径"
+// @Success      200  {object} resomodel.HostFileReply "成功返回文件信息"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      403  {object} errors.Error "路径不在允许访问的范围内"
+// @Failure      404  {object} errors.Error "主机或文件不存在"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/resource/host/{id}/file/stat [get]
+// @Security ApiKeyAuth
+func (h *HostFileHandler) StatHostFile(ctx *gin.Context) {
+	hostId, p, claims, ok := h.bindHostFile(ctx)
+	if !ok {
+		return
+	}
+
+	real, fi, rErr := h.svcFile.StatHostFile(ctx, hostId, claims.UserID, claims.RoleID, p)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &resomodel.HostFileReply{
+		Code: http.StatusOK,
+		Data: *resomodel.HostFileToOut(real, fi),
+	})
+}
+
+// @Summary      下载主机文件
+// @Description  本接口用于下载主机上的文件，下载操作会记录审计日志
+// @Tags         主机文件管理
+// @Produce      application/octet-stream
+// @Param        id path uint32 true "主机唯一标识符"
+// @Param        path query string true "文件的绝对路径"
+// @Success      200  {file} file "文件内容"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      403  {object} errors.Error "路径不在允许访问的范围内"
+// @Failure      404  {object} errors.Error "主机或文件不存在"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/resource/host/{id}/file/download [get]
+// @Security ApiKeyAuth
+func (h *HostFileHandler) DownloadHostFile(ctx *gin.Context) {
+	hostId, p, claims, ok := h.bindHostFile(ctx)
+	if !ok {
+		return
+	}
+
+	rc, fi, rErr := h.svcFile.OpenHostFile(ctx, hostId, claims.UserID, claims.RoleID, p, claims.Username)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+	defer rc.Close()
+
+	common.StreamFile(ctx, rc, fi.Size(), fi.Name(), fi.ModTime())
+}
+
+// @Summary      上传文件到主机
+// @Description  本接口用于上传文件到主机的指定目录，目录须在当前角色允许上传的主机路径规则范围内，上传操作会记录审计日志
+// @Tags         主机文件管理
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        id path uint32 true "主机唯一标识符"
+// @Param        path formData string true "目标目录的绝对路径"
+// @Param        overwrite formData bool false "目标文件已存在时是否覆盖"
+// @Param        file formData file true "上传的文件"
+// @Success      200  {object} resomodel.HostFileReply "成功返回上传后的文件信息"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      403  {object} errors.Error "路径不在允许上传的范围内"
+// @Failure      409  {object} errors.Error "文件已存在"
+// @Failure      413  {object} errors.Error "文件过大"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/resource/host/{id}/file/upload [post]
+// @Security ApiKeyAuth
+func (h *HostFileHandler) UploadHostFile(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定主机ID参数失败",
+			zap.Error(err),
+		)
+		errors.RespondWithError(ctx, errors.ErrValidationFailed.WithCause(err))
+		return
+	}
+
+	var req resomodel.UploadHostFileRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定上传主机文件参数失败",
+			zap.Error(err),
+		)
+		errors.RespondWithError(ctx, errors.ErrValidationFailed.WithCause(err))
+		return
+	}
+
+	if req.File.Size > h.maxSize {
+		ctxutil.Logger(ctx, h.log).Error(
+			"上传的主机文件过大",
+			zap.Int64("file_size", req.File.Size),
+			zap.Int64("max_size", h.maxSize),
+		)
+		errors.RespondWithError(ctx, errors.ErrUploadFileTooLarge.WithFields(
+			map[string]any{
+				"file_size": req.File.Size,
+				"max_size":  h.maxSize,
+			},
+		))
+		return
+	}
+
+	claims, rErr := ctxutil.GetUserClaims(ctx)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"获取个人登录信息失败",
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	f, err := req.File.Open()
+	if err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"打开上传的主机文件失败",
+			zap.Error(err),
+		)
+		errors.RespondWithError(ctx, errors.ErrSaveUploadFileFailed.WithCause(err))
+		return
+	}
+	defer f.Close()
+
+	target, fi, rErr := h.svcFile.UploadHostFile(
+		ctx, uri.ID, claims.UserID, claims.RoleID, req.Path, req.File.Filename, f, req.Overwrite, claims.Username,
+	)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &resomodel.HostFileReply{
+		Code: http.StatusOK,
+		Data: *resomodel.HostFileToOut(target, fi),
+	})
+}
+
+func (h *HostFileHandler) LoadRouter(r *gin.RouterGroup) {
+	r.POST("/file/rule", h.CreateHostPathRule)
+	r.PUT("/file/rule/:id", h.UpdateHostPathRule)
+	r.DELETE("/file/rule/:id", h.DeleteHostPathRule)
+	r.GET("/file/rule", h.ListHostPathRule)
+	r.GET("/host/:id/file", h.ListHostDir)
+	r.GET("/host/:id/file/stat", h.StatHostFile)
+	r.GET("/host/:id/file/download", h.DownloadHostFile)
+	r.POST("/host/:id/file/upload", h.UploadHostFile)
+}
@@ -0,0 +1,154 @@
+package resource
+
+import (
+	"context"
+
+	"github.com/graphql-go/graphql"
+	"go.uber.org/zap"
+
+	resomodel "gin-artweb/internal/model/resource"
+	resosvc "gin-artweb/internal/service/resource"
+	syssvc "gin-artweb/internal/service/system"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+)
+
+type ResourceGraphQLHandler struct {
+	log     *zap.Logger
+	svcHost *resosvc.HostService
+	svcPkg  *resosvc.PackageService
+}
+
+func NewResourceGraphQLHandler(
+	logger *zap.Logger,
+	svcHost *resosvc.HostService,
+	svcPkg *resosvc.PackageService,
+) *ResourceGraphQLHandler {
+	return &ResourceGraphQLHandler{
+		log:     logger,
+		svcHost: svcHost,
+		svcPkg:  svcPkg,
+	}
+}
+
+// LoadSchema 注册主机和程序包的对象类型和查询字段, 其他模块通过"Host"和"Package"引用
+func (h *ResourceGraphQLHandler) LoadSchema(g *syssvc.GraphQLService) {
+	hostType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Host",
+		Description: "主机",
+		Fields: graphql.Fields{
+			"id":         syssvc.GraphQLValue(graphql.Int, "主机ID", func(m *resomodel.HostStandardOut) any { return m.ID }),
+			"name":       syssvc.GraphQLValue(graphql.String, "名称", func(m *resomodel.HostStandardOut) any { return m.Name }),
+			"label":      syssvc.GraphQLValue(graphql.String, "标签", func(m *resomodel.HostStandardOut) any { return m.Label }),
+			"ssh_ip":     syssvc.GraphQLValue(graphql.String, "IP地址", func(m *resomodel.HostStandardOut) any { return m.SSHIP }),
+			"ssh_port":   syssvc.GraphQLValue(graphql.Int, "端口", func(m *resomodel.HostStandardOut) any { return m.SSHPort }),
+			"ssh_user":   syssvc.GraphQLValue(graphql.String, "用户名, 没有查看敏感字段权限或嵌套在其他对象中时掩码", func(m *resomodel.HostStandardOut) any { return m.SSHUser }),
+			"py_path":    syssvc.GraphQLValue(graphql.String, "Python路径", func(m *resomodel.HostStandardOut) any { return m.PyPath }),
+			"remark":     syssvc.GraphQLValue(graphql.String, "备注", func(m *resomodel.HostStandardOut) any { return m.Remark }),
+			"created_at": syssvc.GraphQLValue(graphql.String, "创建时间", func(m *resomodel.HostStandardOut) any { return m.CreatedAt }),
+			"updated_at": syssvc.GraphQLValue(graphql.String, "更新时间", func(m *resomodel.HostStandardOut) any { return m.UpdatedAt }),
+		},
+	})
+	pkgType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Package",
+		Description: "程序包",
+		Fields: graphql.Fields{
+			"id":          syssvc.GraphQLValue(graphql.Int, "程序包ID", func(m *resomodel.PackageStandardOut) any { return m.ID }),
+			"filename":    syssvc.GraphQLValue(graphql.String, "名称", func(m *resomodel.PackageStandardOut) any { return m.Filename }),
+			"label":       syssvc.GraphQLValue(graphql.String, "标签", func(m *resomodel.PackageStandardOut) any { return m.Label }),
+			"version":     syssvc.GraphQLValue(graphql.String, "版本", func(m *resomodel.PackageStandardOut) any { return m.Version }),
+			"size":        syssvc.GraphQLValue(graphql.Float, "文件大小(字节)", func(m *resomodel.PackageStandardOut) any { return m.Size }),
+			"checksum":    syssvc.GraphQLValue(graphql.String, "文件SHA256校验和", func(m *resomodel.PackageStandardOut) any { return m.Checksum }),
+			"uploaded_at": syssvc.GraphQLValue(graphql.String, "上传时间", func(m *resomodel.PackageStandardOut) any { return m.UploadedAt }),
+		},
+	})
+
+	// 嵌套在其他对象中的主机与REST接口一致, 敏感字段始终掩码
+	g.RegisterObject(hostType, syssvc.GraphQLLoadByID("host", syssvc.GraphQLByID(
+		h.svcHost.ListHost,
+		func(m resomodel.HostModel) uint32 { return m.ID },
+		func(_ context.Context, m resomodel.HostModel) *resomodel.HostStandardOut {
+			return resomodel.HostModelToStandardOut(m, false)
+		},
+	)))
+	g.RegisterObject(pkgType, syssvc.GraphQLLoadByID("package", syssvc.GraphQLByID(
+		h.svcPkg.ListPackage,
+		func(m resomodel.PackageModel) uint32 { return m.ID },
+		func(_ context.Context, m resomodel.PackageModel) *resomodel.PackageStandardOut {
+			return resomodel.PackageModelToOutBase(m)
+		},
+	)))
+
+	g.RegisterQuery("hosts", "GET /api/v1/resource/host", &graphql.Field{
+		Type:        graphql.NewList(hostType),
+		Description: "主机列表",
+		Args:        syssvc.GraphQLArgs(nil),
+		Resolve:     h.listHost,
+	})
+	g.RegisterQuery("packages", "GET /api/v1/resource/package", &graphql.Field{
+		Type:        graphql.NewList(pkgType),
+		Description: "程序包列表",
+		Args: syssvc.GraphQLArgs(graphql.FieldConfigArgument{
+			"label": &graphql.ArgumentConfig{Type: graphql.String, Description: "标签"},
+		}),
+		Resolve: h.listPackage,
+	})
+}
+
+func (h *ResourceGraphQLHandler) listHost(p graphql.ResolveParams) (any, error) {
+	page, size, rErr := syssvc.GraphQLPage(p)
+	if rErr != nil {
+		return nil, rErr
+	}
+	qp := database.QueryParams{
+		Size:    size,
+		Page:    page,
+		OrderBy: []string{"id ASC"},
+	}
+	_, ms, rErr := h.svcHost.ListHost(p.Context, qp)
+	if rErr != nil {
+		ctxutil.Logger(p.Context, h.log).Error(
+			"GraphQL查询主机列表失败",
+			zap.Error(rErr),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		return nil, rErr
+	}
+	reveal := ctxutil.CanViewSensitive(p.Context)
+	results := make([]*resomodel.HostStandardOut, len(*ms))
+	for i, m := range *ms {
+		results[i] = resomodel.HostModelToStandardOut(m, reveal)
+	}
+	return results, nil
+}
+
+func (h *ResourceGraphQLHandler) listPackage(p graphql.ResolveParams) (any, error) {
+	page, size, rErr := syssvc.GraphQLPage(p)
+	if rErr != nil {
+		return nil, rErr
+	}
+	query := make(map[string]any)
+	if label, ok := p.Args["label"].(string); ok && label != "" {
+		query["label = ?"] = label
+	}
+	qp := database.QueryParams{
+		Size:    size,
+		Page:    page,
+		OrderBy: []string{"uploaded_at DESC"},
+		Query:   query,
+	}
+	_, ms, rErr := h.svcPkg.ListPackage(p.Context, qp)
+	if rErr != nil {
+		ctxutil.Logger(p.Context, h.log).Error(
+			"GraphQL查询程序包列表失败",
+			zap.Error(rErr),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		return nil, rErr
+	}
+	results := make([]*resomodel.PackageStandardOut, len(*ms))
+	for i, m := range *ms {
+		results[i] = resomodel.PackageModelToOutBase(m)
+	}
+	return results, nil
+}
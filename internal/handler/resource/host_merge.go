@@ -0,0 +1,122 @@
+package resource
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	commodel "gin-artweb/internal/model/common"
+	resomodel "gin-artweb/internal/model/resource"
+	resosvc "gin-artweb/internal/service/resource"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/errors"
+)
+
+type HostMergeHandler struct {
+	log      *zap.Logger
+	svcMerge *resosvc.HostMergeService
+}
+
+func NewHostMergeHandler(
+	logger *zap.Logger,
+	svcMerge *resosvc.HostMergeService,
+) *HostMergeHandler {
+	return &HostMergeHandler{
+		log:      logger,
+		svcMerge: svcMerge,
+	}
+}
+
+// @Summary 查询疑似重复的主机
+// @Description 本接口用于查找重复登记的主机, 按机器序列号、SSH地址、代理上报的IP地址和主机名匹配, 回环地址和链路本地地址不作为匹配依据
+// @Tags 主机管理
+// @Produce json
+// @Success 200 {object} resomodel.HostDuplicateReply "成功返回疑似重复的主机分组"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/resource/host/duplicates [get]
+// @Security ApiKeyAuth
+func (h *HostMergeHandler) ListHostDuplicates(ctx *gin.Context) {
+	gs, rErr := h.svcMerge.FindDuplicates(ctx)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询疑似重复主机失败",
+			zap.Error(rErr),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &resomodel.HostDuplicateReply{
+		Code: http.StatusOK,
+		Data: resomodel.ListHostDuplicateGroupToOut(gs, ctxutil.CanViewSensitive(ctx)),
+	})
+}
+
+// @Summary 合并重复的主机
+// @Description 本接口用于将重复主机的集群节点、进程守护、主机代理和历史记录改为引用路径中的主机, 然后删除重复主机, 合并在同一事务中执行并记录审计
+// @Tags 主机管理
+// @Accept json
+// @Produce json
+// @Param id path uint true "保留的主机编号"
+// @Param request body resomodel.MergeHostRequest true "合并主机请求"
+// @Success 200 {object} resomodel.HostMergeReply "合并主机成功"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 404 {object} errors.Error "主机未找到"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/resource/host/{id}/merge [post]
+// @Security ApiKeyAuth
+func (h *HostMergeHandler) MergeHost(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定合并主机ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	var req resomodel.MergeHostRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定合并主机参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	claims, rErr := ctxutil.GetUserClaims(ctx)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"获取个人登录信息失败",
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	result, rErr := h.svcMerge.MergeHost(ctx, uri.ID, req.DuplicateID, claims.Username)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"合并主机失败",
+			zap.Error(rErr),
+			zap.Uint32(commodel.RequestIDKey, uri.ID),
+			zap.Uint32("duplicate_id", req.DuplicateID),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &resomodel.HostMergeReply{
+		Code: http.StatusOK,
+		Data: *resomodel.HostMergeResultToOut(*result),
+	})
+}
+
+func (h *HostMergeHandler) LoadRouter(r *gin.RouterGroup) {
+	r.GET("/host/duplicates", h.ListHostDuplicates)
+	r.POST("/host/:id/merge", h.MergeHost)
+}
@@ -3,6 +3,8 @@ package resource
 import (
 	"net/http"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -43,6 +45,7 @@ func NewPackageHandler(
 // @Param        label formData string true "程序包标签，长度限制：1-50个字符"
 // @Param        version formData string true "程序包版本，长度限制：1-50个字符"
 // @Param        file formData file true "程序包文件"
+// @Param        checksum formData string false "文件SHA256校验和，不为空时校验上传的文件"
 // @Success      201  {object} resomodel.PackageReply "成功返回程序包信息"
 // @Failure      400  {object} errors.Error "请求参数错误"
 // @Failure      500  {object} errors.Error "服务器内部错误"
@@ -51,35 +54,51 @@ func NewPackageHandler(
 func (h *PackageHandler) UploadPackage(ctx *gin.Context) {
 	var req resomodel.UploadPackageRequest
 	if err := ctx.ShouldBind(&req); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定上传程序包参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	newFileNameWithExt := uuid.NewString() + filepath.Ext(req.File.Filename)
-	savePath := common.GetPackageStoragePath(newFileNameWithExt)
-	if rErr := common.UploadFile(ctx, h.log, h.maxSize, savePath, req.File, 0o644); rErr != nil {
-		errors.RespondWithError(ctx, rErr)
+	if req.File.Size > h.maxSize {
+		ctxutil.Logger(ctx, h.log).Error(
+			"上传的程序包文件过大",
+			zap.Int64("file_size", req.File.Size),
+			zap.Int64("max_size", h.maxSize),
+		)
+		errors.RespondWithError(ctx, errors.ErrUploadFileTooLarge.WithFields(
+			map[string]any{
+				"file_size": req.File.Size,
+				"max_size":  h.maxSize,
+			},
+		))
 		return
 	}
 
-	pkg, rErr := h.svcPackage.CreatePackage(ctx, resomodel.PackageModel{
+	f, err := req.File.Open()
+	if err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"打开上传的程序包文件失败",
+			zap.Error(err),
+		)
+		errors.RespondWithError(ctx, errors.ErrSaveUploadFileFailed.WithCause(err))
+		return
+	}
+	defer f.Close()
+
+	pkg, rErr := h.svcPackage.UploadPackage(ctx, resomodel.PackageModel{
 		Label:           req.Label,
 		Version:         req.Version,
-		StorageFilename: newFileNameWithExt,
+		StorageFilename: uuid.NewString() + filepath.Ext(req.File.Filename),
 		OriginFilename:  req.File.Filename,
-	})
+	}, f, req.File.Size, req.Checksum)
 	if rErr != nil {
-		h.log.Error(
-			"创建 Package 记录失败",
+		ctxutil.Logger(ctx, h.log).Error(
+			"上传程序包失败",
 			zap.Error(rErr),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, rErr)
 		return
@@ -104,38 +123,33 @@ func (h *PackageHandler) UploadPackage(ctx *gin.Context) {
 func (h *PackageHandler) DeletePackage(ctx *gin.Context) {
 	var uri commodel.IDUri
 	if err := ctx.ShouldBindUri(&uri); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定删除程序包ID参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"开始删除程序包",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	if err := h.svcPackage.DeletePackageById(ctx, uri.ID); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"删除程序包失败",
 			zap.Error(err),
 			zap.Uint32(commodel.RequestIDKey, uri.ID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, err)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"删除程序包成功",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	ctx.JSON(commodel.NoDataReply.Code, commodel.NoDataReply)
 }
@@ -153,39 +167,34 @@ func (h *PackageHandler) DeletePackage(ctx *gin.Context) {
 func (h *PackageHandler) GetPackage(ctx *gin.Context) {
 	var uri commodel.IDUri
 	if err := ctx.ShouldBindUri(&uri); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定查询程序包ID参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"开始查询程序包详情",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	m, err := h.svcPackage.FindPackageById(ctx, uri.ID)
 	if err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"查询程序包详情失败",
 			zap.Error(err),
 			zap.Uint32(commodel.RequestIDKey, uri.ID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, err)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"查询程序包详情成功",
 		zap.Uint32(commodel.RequestIDKey, uri.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	mo := resomodel.PackageModelToOutBase(*m)
@@ -208,54 +217,47 @@ func (h *PackageHandler) GetPackage(ctx *gin.Context) {
 func (h *PackageHandler) ListPackage(ctx *gin.Context) {
 	var req resomodel.ListPackageRequest
 	if err := ctx.ShouldBindQuery(&req); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定查询程序包列表参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"开始查询程序包列表",
-		zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	page, size, query := req.Query()
 	qp := database.QueryParams{
-		IsCount: true,
-		Size:    size,
-		Page:    page,
-		OrderBy: []string{"uploaded_at DESC"},
-		Query:   query,
+		IsCount:   true,
+		SkipCount: req.SkipCount(),
+		Size:      size,
+		Page:      page,
+		OrderBy:   []string{"uploaded_at DESC"},
+		Query:     query,
 	}
 	total, ms, err := h.svcPackage.ListPackage(ctx, qp)
 	if err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"查询程序包列表失败",
 			zap.Error(err),
 			zap.Object(database.QueryParamsKey, &qp),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, err)
 		return
 	}
 
-	h.log.Info(
+	ctxutil.Logger(ctx, h.log).Info(
 		"查询程序包列表成功",
-		zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	mbs := resomodel.ListPkgModelToOut(ms)
 	ctx.JSON(http.StatusOK, &resomodel.PagPackageReply{
 		Code: http.StatusOK,
-		Data: commodel.NewPag(page, size, total, mbs),
+		Data: commodel.NewPag(qp, total, mbs),
 	})
 }
 
@@ -273,11 +275,9 @@ func (h *PackageHandler) ListPackage(ctx *gin.Context) {
 func (h *PackageHandler) DownloadPackage(ctx *gin.Context) {
 	var uri commodel.IDUri
 	if err := ctx.ShouldBindUri(&uri); err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
 			"绑定下载程序包ID参数失败",
 			zap.Error(err),
-			zap.String(commodel.RequestURIKey, ctx.Request.RequestURI),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rErr := errors.ErrValidationFailed.WithCause(err)
 		errors.RespondWithError(ctx, rErr)
@@ -287,21 +287,87 @@ func (h *PackageHandler) DownloadPackage(ctx *gin.Context) {
 	// 获取包信息
 	pkg, err := h.svcPackage.FindPackageById(ctx, uri.ID)
 	if err != nil {
-		h.log.Error(
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询程序包详情失败",
+			zap.Error(err),
+			zap.Uint32(commodel.RequestIDKey, uri.ID),
+		)
+		errors.RespondWithError(ctx, err)
+		return
+	}
+
+	rc, err := h.svcPackage.OpenPackage(ctx, *pkg)
+	if err != nil {
+		errors.RespondWithError(ctx, err)
+		return
+	}
+	defer rc.Close()
+
+	if pkg.Checksum != "" {
+		ctx.Header("X-Checksum-Sha256", pkg.Checksum)
+	}
+	size := pkg.Size
+	if size <= 0 {
+		size = -1
+	}
+	common.StreamFile(ctx, rc, size, pkg.OriginFilename, pkg.UploadedAt)
+}
+
+// @Summary      获取程序包下载地址
+// @Description  本接口用于获取指定ID的程序包下载地址，存储后端支持时返回预签名地址
+// @Tags         程序包管理
+// @Produce      json
+// @Param        id path uint32 true "程序包唯一标识符"
+// @Success      200  {object} resomodel.PackageDownloadURLReply "成功返回下载地址"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      404  {object} errors.Error "程序包未找到"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/resource/package/{id}/download-url [get]
+// @Security ApiKeyAuth
+func (h *PackageHandler) GetPackageDownloadURL(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定程序包下载地址ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	pkg, err := h.svcPackage.FindPackageById(ctx, uri.ID)
+	if err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
 			"查询程序包详情失败",
 			zap.Error(err),
 			zap.Uint32(commodel.RequestIDKey, uri.ID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		errors.RespondWithError(ctx, err)
 		return
 	}
 
-	// 构建文件路径
-	filePath := common.GetPackageStoragePath(pkg.StorageFilename)
-	if err := common.DownloadFile(ctx, h.log, filePath, pkg.OriginFilename); err != nil {
+	u, expiresAt, err := h.svcPackage.PresignPackage(ctx, *pkg)
+	if err != nil {
 		errors.RespondWithError(ctx, err)
+		return
+	}
+
+	out := resomodel.PackageDownloadURLOut{
+		URL:      u,
+		Checksum: pkg.Checksum,
 	}
+	if u != "" {
+		out.Presigned = true
+		out.ExpiresAt = expiresAt.Format(time.DateTime)
+	} else {
+		// 不支持预签名时通过接口下载
+		out.URL = strings.TrimSuffix(ctx.Request.URL.Path, "-url")
+	}
+	ctx.JSON(http.StatusOK, &resomodel.PackageDownloadURLReply{
+		Code: http.StatusOK,
+		Data: out,
+	})
 }
 
 func (h *PackageHandler) LoadRouter(r *gin.RouterGroup) {
@@ -310,4 +376,5 @@ func (h *PackageHandler) LoadRouter(r *gin.RouterGroup) {
 	r.GET("/package/:id", h.GetPackage)
 	r.GET("/package", h.ListPackage)
 	r.GET("/package/:id/download", h.DownloadPackage)
+	r.GET("/package/:id/download-url", h.GetPackageDownloadURL)
 }
@@ -0,0 +1,133 @@
+package resource
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	resomodel "gin-artweb/internal/model/resource"
+	resosvc "gin-artweb/internal/service/resource"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/rpc"
+	"gin-artweb/pkg/rpc/artwebv1"
+)
+
+type ResourceRPCHandler struct {
+	artwebv1.UnimplementedResourceServiceServer
+	log     *zap.Logger
+	svcHost *resosvc.HostService
+	svcPkg  *resosvc.PackageService
+}
+
+func NewResourceRPCHandler(
+	logger *zap.Logger,
+	svcHost *resosvc.HostService,
+	svcPkg *resosvc.PackageService,
+) *ResourceRPCHandler {
+	return &ResourceRPCHandler{
+		log:     logger,
+		svcHost: svcHost,
+		svcPkg:  svcPkg,
+	}
+}
+
+// LoadRPC 注册主机和程序包的gRPC服务
+func (h *ResourceRPCHandler) LoadRPC(s *rpc.Server) {
+	s.Register(&artwebv1.ResourceService_ServiceDesc, h, map[string]string{
+		"ListHosts":    "GET /api/v1/resource/host",
+		"ListPackages": "GET /api/v1/resource/package",
+	})
+}
+
+func (h *ResourceRPCHandler) ListHosts(
+	ctx context.Context,
+	req *artwebv1.ListHostsRequest,
+) (*artwebv1.ListHostsResponse, error) {
+	page, size, rErr := rpc.Page(req.GetPage(), req.GetSize())
+	if rErr != nil {
+		return nil, rErr
+	}
+	query := make(map[string]any)
+	if req.GetLabel() != "" {
+		query["label = ?"] = req.GetLabel()
+	}
+	qp := database.QueryParams{
+		Size:    size,
+		Page:    page,
+		IsCount: true,
+		OrderBy: []string{"id ASC"},
+		Query:   query,
+	}
+	total, ms, rErr := h.svcHost.ListHost(ctx, qp)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"gRPC查询主机列表失败",
+			zap.Error(rErr),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		return nil, rErr
+	}
+	reveal := ctxutil.CanViewSensitive(ctx)
+	hosts := make([]*artwebv1.Host, len(*ms))
+	for i, m := range *ms {
+		mo := resomodel.HostModelToStandardOut(m, reveal)
+		hosts[i] = &artwebv1.Host{
+			Id:        mo.ID,
+			Name:      mo.Name,
+			Label:     mo.Label,
+			SshIp:     mo.SSHIP,
+			SshPort:   uint32(mo.SSHPort),
+			SshUser:   mo.SSHUser,
+			PyPath:    mo.PyPath,
+			Remark:    mo.Remark,
+			CreatedAt: mo.CreatedAt,
+			UpdatedAt: mo.UpdatedAt,
+		}
+	}
+	return &artwebv1.ListHostsResponse{Total: total, Hosts: hosts}, nil
+}
+
+func (h *ResourceRPCHandler) ListPackages(
+	ctx context.Context,
+	req *artwebv1.ListPackagesRequest,
+) (*artwebv1.ListPackagesResponse, error) {
+	page, size, rErr := rpc.Page(req.GetPage(), req.GetSize())
+	if rErr != nil {
+		return nil, rErr
+	}
+	query := make(map[string]any)
+	if req.GetLabel() != "" {
+		query["label = ?"] = req.GetLabel()
+	}
+	qp := database.QueryParams{
+		Size:    size,
+		Page:    page,
+		IsCount: true,
+		OrderBy: []string{"uploaded_at DESC"},
+		Query:   query,
+	}
+	total, ms, rErr := h.svcPkg.ListPackage(ctx, qp)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"gRPC查询程序包列表失败",
+			zap.Error(rErr),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		return nil, rErr
+	}
+	pkgs := make([]*artwebv1.Package, len(*ms))
+	for i, m := range *ms {
+		mo := resomodel.PackageModelToOutBase(m)
+		pkgs[i] = &artwebv1.Package{
+			Id:         mo.ID,
+			Filename:   mo.Filename,
+			Label:      mo.Label,
+			Version:    mo.Version,
+			Size:       mo.Size,
+			Checksum:   mo.Checksum,
+			UploadedAt: mo.UploadedAt,
+		}
+	}
+	return &artwebv1.ListPackagesResponse{Total: total, Packages: pkgs}, nil
+}
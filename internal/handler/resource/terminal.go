@@ -0,0 +1,283 @@
+package resource
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"golang.org/x/net/websocket"
+
+	commodel "gin-artweb/internal/model/common"
+	resomodel "gin-artweb/internal/model/resource"
+	resosvc "gin-artweb/internal/service/resource"
+	"gin-artweb/internal/shared/common"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+)
+
+// terminalMaxMessageBytes 客户端单条终端消息的大小上限
+const terminalMaxMessageBytes = 64 * 1024
+
+type TerminalHandler struct {
+	log         *zap.Logger
+	svcTerminal *resosvc.TerminalService
+}
+
+func NewTerminalHandler(
+	logger *zap.Logger,
+	svcTerminal *resosvc.TerminalService,
+) *TerminalHandler {
+	return &TerminalHandler{
+		log:         logger,
+		svcTerminal: svcTerminal,
+	}
+}
+
+// @Summary      打开网页终端
+// @Description  本接口通过WebSocket打开指定主机的交互式终端，使用平台SSH密钥登录。
+// @Description  浏览器可通过查询参数Authorization或Sec-WebSocket-Protocol传递令牌。
+// @Description  服务端以二进制帧发送终端输出；客户端发送JSON文本帧，{"type":"input","data":"ls\r"}为键盘输入，{"type":"resize","cols":120,"rows":40}为调整终端大小。
+// @Description  超过空闲时间无输入或超过最长会话时间后服务端主动断开连接。
+// @Tags         主机管理
+// @Param        id path uint32 true "主机唯一标识符"
+// @Param        cols query int false "终端列数"
+// @Param        rows query int false "终端行数"
+// @Success      101  "切换为WebSocket协议"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      403  {object} errors.Error "功能未启用"
+// @Failure      404  {object} errors.Error "主机未找到"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/resource/host/{id}/terminal [get]
+// @Security ApiKeyAuth
+func (h *TerminalHandler) OpenTerminal(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定打开终端主机ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	var req resomodel.OpenTerminalRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定打开终端参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	// 先校验升级请求再连接主机, 避免普通请求建立无用的ssh连接
+	if !ctx.IsWebsocket() {
+		rErr := errors.ErrValidationFailed.WithField("upgrade", ctx.GetHeader("Upgrade"))
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	claims, rErr := ctxutil.GetUserClaims(ctx)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"获取个人登录信息失败",
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	term, rErr := h.svcTerminal.OpenTerminal(ctx, uri.ID, req.Cols, req.Rows, claims.Username, ctx.ClientIP())
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"打开网页终端失败",
+			zap.Error(rErr),
+			zap.Uint32(commodel.RequestIDKey, uri.ID),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+	// 握手失败时Handler不会被调用, 在此兜底关闭会话
+	defer term.Close(resomodel.TerminalCloseClient)
+
+	server := websocket.Server{
+		// 浏览器通过子协议传递令牌时, 响应必须选择其中一个子协议, 否则浏览器会断开连接
+		Handshake: func(config *websocket.Config, r *http.Request) error {
+			if len(config.Protocol) > 1 {
+				config.Protocol = config.Protocol[:1]
+			}
+			return nil
+		},
+		Handler: func(ws *websocket.Conn) {
+			h.serveTerminal(ws, term)
+		},
+	}
+	server.ServeHTTP(ctx.Writer, ctx.Request)
+}
+
+// serveTerminal 在WebSocket连接和终端会话之间转发数据, 任一方结束时结束会话
+func (h *TerminalHandler) serveTerminal(ws *websocket.Conn, term *resosvc.Terminal) {
+	ws.PayloadType = websocket.BinaryFrame
+	ws.MaxPayloadBytes = terminalMaxMessageBytes
+
+	go func() {
+		buf := make([]byte, 32*1024)
+		for {
+			n, err := term.Read(buf)
+			if n > 0 {
+				if _, wErr := ws.Write(buf[:n]); wErr != nil {
+					term.Close(resomodel.TerminalCloseClient)
+					return
+				}
+			}
+			if err != nil {
+				term.Close(resomodel.TerminalCloseExit)
+				return
+			}
+		}
+	}()
+	go func() {
+		// 会话因超时、shell退出或服务关闭结束时断开客户端连接
+		<-term.Done()
+		ws.Close()
+	}()
+
+	for {
+		var data []byte
+		if err := websocket.Message.Receive(ws, &data); err != nil {
+			break
+		}
+		var msg resomodel.TerminalMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			h.log.Warn(
+				"解析终端消息失败",
+				zap.Error(err),
+				zap.String("session_id", term.SessionID()),
+			)
+			continue
+		}
+		switch msg.Type {
+		case resomodel.TerminalMessageInput:
+			if _, err := term.Write([]byte(msg.Data)); err != nil {
+				h.log.Warn(
+					"发送终端输入失败",
+					zap.Error(err),
+					zap.String("session_id", term.SessionID()),
+				)
+			}
+		case resomodel.TerminalMessageResize:
+			if err := term.Resize(msg.Cols, msg.Rows); err != nil {
+				h.log.Warn(
+					"调整终端大小失败",
+					zap.Error(err),
+					zap.String("session_id", term.SessionID()),
+				)
+			}
+		}
+	}
+	term.Close(resomodel.TerminalCloseClient)
+}
+
+// @Summary      查询终端会话记录列表
+// @Description  本接口用于查询网页终端会话记录列表，支持按主机和操作用户过滤
+// @Tags         主机管理
+// @Accept       json
+// @Produce      json
+// @Param        page query int false "页码" minimum(1)
+// @Param        size query int false "每页数量" minimum(1) maximum(100)
+// @Param        host_id query uint32 false "主机ID"
+// @Param        username query string false "操作用户"
+// @Success      200  {object} resomodel.PagTerminalSessionReply "成功返回终端会话记录列表"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/resource/terminal [get]
+// @Security ApiKeyAuth
+func (h *TerminalHandler) ListTerminalSession(ctx *gin.Context) {
+	var req resomodel.ListTerminalSessionRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定查询终端会话记录列表参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctxutil.Logger(ctx, h.log).Info(
+		"开始查询终端会话记录列表",
+	)
+
+	page, size, query := req.Query()
+	qp := database.QueryParams{
+		IsCount:   true,
+		SkipCount: req.SkipCount(),
+		Size:      size,
+		Page:      page,
+		OrderBy:   []string{"id DESC"},
+		Query:     query,
+	}
+	total, ms, err := h.svcTerminal.ListTerminalSession(ctx, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询终端会话记录列表失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		errors.RespondWithError(ctx, err)
+		return
+	}
+
+	ctxutil.Logger(ctx, h.log).Info(
+		"查询终端会话记录列表成功",
+	)
+
+	mbs := resomodel.ListTerminalSessionToOut(ms)
+	ctx.JSON(http.StatusOK, &resomodel.PagTerminalSessionReply{
+		Code: http.StatusOK,
+		Data: commodel.NewPag(qp, total, mbs),
+	})
+}
+
+// @Summary      下载终端会话录像
+// @Description  本接口用于下载网页终端会话录像，格式为asciinema v2，可使用asciinema play回放
+// @Tags         主机管理
+// @Produce      application/octet-stream
+// @Param        id path uint32 true "终端会话记录ID"
+// @Success      200  {file} file "录像文件"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      404  {object} errors.Error "会话或录像不存在"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/resource/terminal/{id}/record [get]
+// @Security ApiKeyAuth
+func (h *TerminalHandler) DownloadTerminalRecord(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定下载终端录像ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	path, rErr := h.svcTerminal.TerminalRecordPath(ctx, uri.ID)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+	if rErr := common.DownloadFile(ctx, h.log, path, ""); rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+}
+
+func (h *TerminalHandler) LoadRouter(r *gin.RouterGroup) {
+	r.GET("/host/:id/terminal", h.OpenTerminal)
+	r.GET("/terminal", h.ListTerminalSession)
+	r.GET("/terminal/:id/record", h.DownloadTerminalRecord)
+}
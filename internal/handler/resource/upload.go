@@ -0,0 +1,246 @@
+package resource
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	commodel "gin-artweb/internal/model/common"
+	resomodel "gin-artweb/internal/model/resource"
+	resosvc "gin-artweb/internal/service/resource"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/errors"
+)
+
+type PackageUploadHandler struct {
+	log       *zap.Logger
+	svcUpload *resosvc.PackageUploadService
+}
+
+func NewPackageUploadHandler(
+	logger *zap.Logger,
+	svcUpload *resosvc.PackageUploadService,
+) *PackageUploadHandler {
+	return &PackageUploadHandler{
+		log:       logger,
+		svcUpload: svcUpload,
+	}
+}
+
+// @Summary      创建分片上传会话
+// @Description  本接口用于创建程序包分片上传会话，适用于大文件上传和断点续传
+// @Tags         程序包管理
+// @Accept       json
+// @Produce      json
+// @Param        request body resomodel.InitPackageUploadRequest true "上传会话参数"
+// @Success      201  {object} resomodel.PackageUploadReply "成功返回上传会话"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      413  {object} errors.Error "文件过大"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/resource/package/upload [post]
+// @Security ApiKeyAuth
+func (h *PackageUploadHandler) InitUpload(ctx *gin.Context) {
+	var req resomodel.InitPackageUploadRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定创建分片上传会话参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	claims, rErr := ctxutil.GetUserClaims(ctx)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"获取个人登录信息失败",
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := h.svcUpload.InitUpload(ctx, resomodel.PackageUploadModel{
+		Label:          req.Label,
+		PkgVersion:     req.Version,
+		OriginFilename: req.Filename,
+		TotalSize:      req.Size,
+		Checksum:       req.Checksum,
+		Username:       claims.Username,
+	})
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, &resomodel.PackageUploadReply{
+		Code: http.StatusCreated,
+		Data: *resomodel.PackageUploadModelToOut(*m, h.svcUpload.ChunkSize()),
+	})
+}
+
+// @Summary      查询分片上传会话
+// @Description  本接口用于查询分片上传会话，续传时以返回的已接收字节数作为下一个分片的起始位置
+// @Tags         程序包管理
+// @Produce      json
+// @Param        upload_id path string true "上传会话标识"
+// @Success      200  {object} resomodel.PackageUploadReply "成功返回上传会话"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      404  {object} errors.Error "上传会话不存在或已过期"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/resource/package/upload/{upload_id} [get]
+// @Security ApiKeyAuth
+func (h *PackageUploadHandler) GetUpload(ctx *gin.Context) {
+	var uri resomodel.UploadIDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定分片上传会话参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := h.svcUpload.GetUpload(ctx, uri.UploadID)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &resomodel.PackageUploadReply{
+		Code: http.StatusOK,
+		Data: *resomodel.PackageUploadModelToOut(*m, h.svcUpload.ChunkSize()),
+	})
+}
+
+// @Summary      上传分片
+// @Description  本接口用于上传一个分片，请求体为分片内容，offset必须等于已接收的字节数
+// @Tags         程序包管理
+// @Accept       application/octet-stream
+// @Produce      json
+// @Param        upload_id path string true "上传会话标识"
+// @Param        offset query int true "分片在文件中的起始位置"
+// @Param        checksum query string false "分片SHA256校验和"
+// @Success      200  {object} resomodel.PackageUploadReply "成功返回上传进度"
+// @Failure      400  {object} errors.Error "请求参数错误或分片校验和不一致"
+// @Failure      404  {object} errors.Error "上传会话不存在或已过期"
+// @Failure      409  {object} errors.Error "分片起始位置与已接收的字节数不一致"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/resource/package/upload/{upload_id} [put]
+// @Security ApiKeyAuth
+func (h *PackageUploadHandler) UploadChunk(ctx *gin.Context) {
+	var uri resomodel.UploadIDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定分片上传会话参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+	var req resomodel.UploadChunkRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定上传分片参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	// 分片长度以Content-Length为准, 读取时不超过分片大小上限
+	body := http.MaxBytesReader(ctx.Writer, ctx.Request.Body, h.svcUpload.ChunkSize())
+	m, rErr := h.svcUpload.UploadChunk(ctx, uri.UploadID, req.Offset, body, ctx.Request.ContentLength, req.Checksum)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &resomodel.PackageUploadReply{
+		Code: http.StatusOK,
+		Data: *resomodel.PackageUploadModelToOut(*m, h.svcUpload.ChunkSize()),
+	})
+}
+
+// @Summary      完成分片上传
+// @Description  本接口用于完成分片上传，校验压缩包结构和校验和后创建程序包
+// @Tags         程序包管理
+// @Produce      json
+// @Param        upload_id path string true "上传会话标识"
+// @Success      200  {object} resomodel.PackageReply "成功返回程序包信息"
+// @Failure      400  {object} errors.Error "压缩包无效或校验和不一致"
+// @Failure      404  {object} errors.Error "上传会话不存在或已过期"
+// @Failure      409  {object} errors.Error "文件尚未全部上传"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/resource/package/upload/{upload_id}/complete [post]
+// @Security ApiKeyAuth
+func (h *PackageUploadHandler) CompleteUpload(ctx *gin.Context) {
+	var uri resomodel.UploadIDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定分片上传会话参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	pkg, rErr := h.svcUpload.CompleteUpload(ctx, uri.UploadID)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"完成分片上传失败",
+			zap.Error(rErr),
+			zap.String("upload_id", uri.UploadID),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &resomodel.PackageReply{
+		Code: http.StatusOK,
+		Data: *resomodel.PackageModelToOutBase(*pkg),
+	})
+}
+
+// @Summary      取消分片上传
+// @Description  本接口用于取消分片上传，删除上传会话和已上传的分片
+// @Tags         程序包管理
+// @Produce      json
+// @Param        upload_id path string true "上传会话标识"
+// @Success      200  {object} commodel.MapAPIReply "取消成功"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      404  {object} errors.Error "上传会话不存在或已过期"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/resource/package/upload/{upload_id} [delete]
+// @Security ApiKeyAuth
+func (h *PackageUploadHandler) AbortUpload(ctx *gin.Context) {
+	var uri resomodel.UploadIDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定分片上传会话参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	if rErr := h.svcUpload.AbortUpload(ctx, uri.UploadID); rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+	ctx.JSON(commodel.NoDataReply.Code, commodel.NoDataReply)
+}
+
+func (h *PackageUploadHandler) LoadRouter(r *gin.RouterGroup) {
+	r.POST("/package/upload", h.InitUpload)
+	r.GET("/package/upload/:upload_id", h.GetUpload)
+	r.PUT("/package/upload/:upload_id", h.UploadChunk)
+	r.POST("/package/upload/:upload_id/complete", h.CompleteUpload)
+	r.DELETE("/package/upload/:upload_id", h.AbortUpload)
+}
@@ -0,0 +1,332 @@
+package resource
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	commodel "gin-artweb/internal/model/common"
+	resomodel "gin-artweb/internal/model/resource"
+	resosvc "gin-artweb/internal/service/resource"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+)
+
+type WatchdogHandler struct {
+	log         *zap.Logger
+	svcWatchdog *resosvc.WatchdogService
+}
+
+func NewWatchdogHandler(
+	logger *zap.Logger,
+	svcWatchdog *resosvc.WatchdogService,
+) *WatchdogHandler {
+	return &WatchdogHandler{
+		log:         logger,
+		svcWatchdog: svcWatchdog,
+	}
+}
+
+// @Summary      创建进程守护
+// @Description  本接口用于为主机配置需要守护的进程或systemd服务，定时检查是否处于期望状态，期望运行但未运行时按重启策略重启并告警
+// @Tags         进程守护
+// @Accept       json
+// @Produce      json
+// @Param        request body resomodel.WatchdogRequest true "进程守护"
+// @Success      201  {object} resomodel.WatchdogReply "成功返回进程守护"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      409  {object} errors.Error "名称已存在"
+// @Failure      422  {object} errors.Error "进程守护定义无效"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/resource/watchdog [post]
+// @Security ApiKeyAuth
+func (h *WatchdogHandler) CreateWatchdog(ctx *gin.Context) {
+	var req resomodel.WatchdogRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定创建进程守护参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := h.svcWatchdog.CreateWatchdog(ctx, req.ToModel())
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, &resomodel.WatchdogReply{
+		Code: http.StatusCreated,
+		Data: *resomodel.WatchdogToOut(*m),
+	})
+}
+
+// @Summary      更新进程守护
+// @Description  本接口用于更新指定ID的进程守护，检查状态保留到下一次检查
+// @Tags         进程守护
+// @Accept       json
+// @Produce      json
+// @Param        id path uint32 true "进程守护ID"
+// @Param        request body resomodel.WatchdogRequest true "进程守护"
+// @Success      200  {object} resomodel.WatchdogReply "成功返回进程守护"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      404  {object} errors.Error "进程守护不存在"
+// @Failure      409  {object} errors.Error "名称已存在"
+// @Failure      422  {object} errors.Error "进程守护定义无效"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/resource/watchdog/{id} [put]
+// @Security ApiKeyAuth
+func (h *WatchdogHandler) UpdateWatchdog(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定进程守护ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	var req resomodel.WatchdogRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定更新进程守护参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	wm := req.ToModel()
+	wm.ID = uri.ID
+	m, rErr := h.svcWatchdog.UpdateWatchdogById(ctx, wm)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &resomodel.WatchdogReply{
+		Code: http.StatusOK,
+		Data: *resomodel.WatchdogToOut(*m),
+	})
+}
+
+// @Summary      删除进程守护
+// @Description  本接口用于删除指定ID的进程守护及其历史
+// @Tags         进程守护
+// @Produce      json
+// @Param        id path uint32 true "进程守护ID"
+// @Success      200  {object} commodel.MapAPIReply "删除成功"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      404  {object} errors.Error "进程守护不存在"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/resource/watchdog/{id} [delete]
+// @Security ApiKeyAuth
+func (h *WatchdogHandler) DeleteWatchdog(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定进程守护ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	if rErr := h.svcWatchdog.DeleteWatchdogById(ctx, uri.ID); rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(commodel.NoDataReply.Code, commodel.NoDataReply)
+}
+
+// @Summary      查询进程守护详情
+// @Description  本接口用于查询指定ID的进程守护和最近一次检查结果
+// @Tags         进程守护
+// @Produce      json
+// @Param        id path uint32 true "进程守护ID"
+// @Success      200  {object} resomodel.WatchdogReply "成功返回进程守护"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      404  {object} errors.Error "进程守护不存在"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/resource/watchdog/{id} [get]
+// @Security ApiKeyAuth
+func (h *WatchdogHandler) GetWatchdog(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定进程守护ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := h.svcWatchdog.FindWatchdogById(ctx, uri.ID)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &resomodel.WatchdogReply{
+		Code: http.StatusOK,
+		Data: *resomodel.WatchdogToOut(*m),
+	})
+}
+
+// @Summary      查询进程守护列表
+// @Description  本接口用于查询进程守护列表，可按status=failed查询不符合期望状态的进程
+// @Tags         进程守护
+// @Produce      json
+// @Param        request query resomodel.ListWatchdogRequest false "查询参数"
+// @Success      200  {object} resomodel.PagWatchdogReply "成功返回进程守护列表"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/resource/watchdog [get]
+// @Security ApiKeyAuth
+func (h *WatchdogHandler) ListWatchdog(ctx *gin.Context) {
+	var req resomodel.ListWatchdogRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定查询进程守护列表参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	page, size, query := req.Query()
+	qp := database.QueryParams{
+		Preloads:  []string{"Host"},
+		IsCount:   true,
+		SkipCount: req.SkipCount(),
+		Size:      size,
+		Page:      page,
+		OrderBy:   []string{"id ASC"},
+		Query:     query,
+	}
+	total, ms, rErr := h.svcWatchdog.ListWatchdog(ctx, qp)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	mbs := resomodel.ListWatchdogToOut(ms)
+	ctx.JSON(http.StatusOK, &resomodel.PagWatchdogReply{
+		Code: http.StatusOK,
+		Data: commodel.NewPag(qp, total, mbs),
+	})
+}
+
+// @Summary      立即检查进程守护
+// @Description  本接口用于立即检查指定ID的进程守护，与定时检查相同，不符合期望状态时按重启策略重启
+// @Tags         进程守护
+// @Produce      json
+// @Param        id path uint32 true "进程守护ID"
+// @Success      200  {object} resomodel.WatchdogReply "成功返回检查后的进程守护"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      404  {object} errors.Error "进程守护不存在"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/resource/watchdog/{id}/check [post]
+// @Security ApiKeyAuth
+func (h *WatchdogHandler) CheckWatchdog(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定进程守护ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := h.svcWatchdog.CheckWatchdogById(ctx, uri.ID)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &resomodel.WatchdogReply{
+		Code: http.StatusOK,
+		Data: *resomodel.WatchdogToOut(*m),
+	})
+}
+
+// @Summary      查询进程守护历史
+// @Description  本接口用于查询指定ID的进程守护的状态变化和重启记录，按时间倒序
+// @Tags         进程守护
+// @Produce      json
+// @Param        id path uint32 true "进程守护ID"
+// @Param        request query resomodel.ListWatchdogEventRequest false "查询参数"
+// @Success      200  {object} resomodel.PagWatchdogEventReply "成功返回进程守护历史"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      404  {object} errors.Error "进程守护不存在"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/resource/watchdog/{id}/event [get]
+// @Security ApiKeyAuth
+func (h *WatchdogHandler) ListWatchdogEvent(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定进程守护ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	var req resomodel.ListWatchdogEventRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定查询进程守护历史参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	page, size, query := req.Query()
+	qp := database.QueryParams{
+		IsCount:   true,
+		SkipCount: req.SkipCount(),
+		Size:      size,
+		Page:      page,
+		OrderBy:   []string{"id DESC"},
+		Query:     query,
+	}
+	total, ms, rErr := h.svcWatchdog.ListWatchdogEvent(ctx, uri.ID, qp)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	mbs := resomodel.ListWatchdogEventToOut(ms)
+	ctx.JSON(http.StatusOK, &resomodel.PagWatchdogEventReply{
+		Code: http.StatusOK,
+		Data: commodel.NewPag(qp, total, mbs),
+	})
+}
+
+func (h *WatchdogHandler) LoadRouter(r *gin.RouterGroup) {
+	r.POST("/watchdog", h.CreateWatchdog)
+	r.PUT("/watchdog/:id", h.UpdateWatchdog)
+	r.DELETE("/watchdog/:id", h.DeleteWatchdog)
+	r.GET("/watchdog/:id", h.GetWatchdog)
+	r.GET("/watchdog", h.ListWatchdog)
+	r.POST("/watchdog/:id/check", h.CheckWatchdog)
+	r.GET("/watchdog/:id/event", h.ListWatchdogEvent)
+}
@@ -0,0 +1,115 @@
+package system
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	commodel "gin-artweb/internal/model/common"
+	sysmodel "gin-artweb/internal/model/system"
+	syssvc "gin-artweb/internal/service/system"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/errors"
+)
+
+type AnalyticsHandler struct {
+	log          *zap.Logger
+	svcAnalytics *syssvc.AnalyticsService
+}
+
+func NewAnalyticsHandler(
+	logger *zap.Logger,
+	svcAnalytics *syssvc.AnalyticsService,
+) *AnalyticsHandler {
+	return &AnalyticsHandler{
+		log:          logger,
+		svcAnalytics: svcAnalytics,
+	}
+}
+
+// @Summary 查询功能使用统计
+// @Description 本接口用于按功能、模块或角色聚合查询平台使用情况，不返回用户身份信息
+// @Tags 平台使用统计
+// @Accept json
+// @Produce json
+// @Param request query sysmodel.ListAnalyticsUsageRequest false "查询参数"
+// @Success 200 {object} sysmodel.AnalyticsUsageReply "成功返回功能使用统计"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/system/analytics/usage [get]
+// @Security ApiKeyAuth
+func (h *AnalyticsHandler) ListUsage(ctx *gin.Context) {
+	var req sysmodel.ListAnalyticsUsageRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定查询功能使用统计参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctxutil.Logger(ctx, h.log).Info(
+		"开始查询功能使用统计",
+	)
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = 100
+	}
+	us, rErr := h.svcAnalytics.ListUsage(ctx, req.GroupColumns(), req.Query(), limit)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询功能使用统计失败",
+			zap.Error(rErr),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctxutil.Logger(ctx, h.log).Info(
+		"查询功能使用统计成功",
+	)
+
+	ctx.JSON(http.StatusOK, &sysmodel.AnalyticsUsageReply{
+		Code: http.StatusOK,
+		Data: *sysmodel.ListAnalyticsUsageToOut(us),
+	})
+}
+
+// @Summary 清理过期统计事件
+// @Description 本接口用于立即清理超过保留天数的统计事件
+// @Tags 平台使用统计
+// @Accept json
+// @Produce json
+// @Success 200 {object} commodel.MapAPIReply "清理成功"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/system/analytics/purge [post]
+// @Security ApiKeyAuth
+func (h *AnalyticsHandler) PurgeExpired(ctx *gin.Context) {
+	ctxutil.Logger(ctx, h.log).Info(
+		"开始清理过期统计事件",
+	)
+
+	if rErr := h.svcAnalytics.PurgeExpiredEvents(ctx); rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"清理过期统计事件失败",
+			zap.Error(rErr),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctxutil.Logger(ctx, h.log).Info(
+		"清理过期统计事件成功",
+	)
+
+	ctx.JSON(commodel.NoDataReply.Code, commodel.NoDataReply)
+}
+
+func (h *AnalyticsHandler) LoadRouter(r *gin.RouterGroup) {
+	r.GET("/analytics/usage", h.ListUsage)
+	r.POST("/analytics/purge", h.PurgeExpired)
+}
@@ -0,0 +1,97 @@
+package system
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	commodel "gin-artweb/internal/model/common"
+	sysmodel "gin-artweb/internal/model/system"
+	syssvc "gin-artweb/internal/service/system"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+)
+
+type AuditHandler struct {
+	log      *zap.Logger
+	svcAudit *syssvc.AuditService
+}
+
+func NewAuditHandler(
+	logger *zap.Logger,
+	svcAudit *syssvc.AuditService,
+) *AuditHandler {
+	return &AuditHandler{
+		log:      logger,
+		svcAudit: svcAudit,
+	}
+}
+
+// @Summary 查询审计记录列表
+// @Description 本接口用于分页查询数据变更审计记录, 支持通过filter参数按字段组合过滤
+// @Tags 审计记录
+// @Accept json
+// @Produce json
+// @Param request query sysmodel.ListAuditRecordRequest false "查询参数"
+// @Success 200 {object} sysmodel.PagAuditRecordReply "成功返回审计记录列表"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/system/audit [get]
+// @Security ApiKeyAuth
+func (h *AuditHandler) ListAuditRecord(ctx *gin.Context) {
+	var req sysmodel.ListAuditRecordRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定查询审计记录列表参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	filter, pErr := req.FilterParams(sysmodel.AuditRecordFilterFields)
+	if pErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"解析审计记录列表过滤条件失败",
+			zap.Error(pErr),
+			zap.String("filter", req.Filter),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(pErr)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	page, size, query := req.Query()
+	qp := database.QueryParams{
+		IsCount:   true,
+		SkipCount: req.SkipCount(),
+		Size:      size,
+		Page:      page,
+		OrderBy:   []string{"id DESC"},
+		Query:     query,
+		Filter:    filter,
+	}
+	total, ms, rErr := h.svcAudit.ListAuditRecord(ctx, qp)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询审计记录列表失败",
+			zap.Error(rErr),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	mbs := sysmodel.ListAuditRecordToOut(ms)
+	ctx.JSON(http.StatusOK, &sysmodel.PagAuditRecordReply{
+		Code: http.StatusOK,
+		Data: commodel.NewPag(qp, total, mbs),
+	})
+}
+
+func (h *AuditHandler) LoadRouter(r *gin.RouterGroup) {
+	r.GET("/audit", h.ListAuditRecord)
+}
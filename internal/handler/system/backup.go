@@ -0,0 +1,241 @@
+package system
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	commodel "gin-artweb/internal/model/common"
+	sysmodel "gin-artweb/internal/model/system"
+	syssvc "gin-artweb/internal/service/system"
+	"gin-artweb/internal/shared/common"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+)
+
+type BackupHandler struct {
+	log       *zap.Logger
+	svcBackup *syssvc.BackupService
+	svcTask   *syssvc.TaskService
+}
+
+func NewBackupHandler(
+	logger *zap.Logger,
+	svcBackup *syssvc.BackupService,
+	svcTask *syssvc.TaskService,
+) *BackupHandler {
+	return &BackupHandler{
+		log:       logger,
+		svcBackup: svcBackup,
+		svcTask:   svcTask,
+	}
+}
+
+// @Summary      立即备份
+// @Description  本接口用于提交备份数据的后台任务, 备份数据库全部数据和上传文件, 通过/api/v1/tasks/{id}查询进度, 任务成功后结果中的backup_id为备份ID
+// @Tags         数据备份
+// @Produce      json
+// @Success      200  {object} sysmodel.TaskReply "成功返回后台任务"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/admin/backup [post]
+// @Security ApiKeyAuth
+func (h *BackupHandler) CreateBackup(ctx *gin.Context) {
+	claims, rErr := ctxutil.GetUserClaims(ctx)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	task := &sysmodel.TaskModel{
+		Kind:     sysmodel.TaskKindBackup,
+		Name:     "备份数据",
+		UserID:   claims.UserID,
+		Username: claims.Username,
+	}
+	m, rErr := h.svcTask.Submit(ctx, task, func(tctx context.Context, p *syssvc.TaskProgress) (any, error) {
+		bm, rErr := h.svcBackup.Backup(tctx, sysmodel.BackupTriggerManual, claims.Username, p.Report)
+		if rErr != nil {
+			return nil, rErr
+		}
+		return map[string]any{"backup_id": bm.ID, "name": bm.Name}, nil
+	})
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"提交备份任务失败",
+			zap.Error(rErr),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &sysmodel.TaskReply{
+		Code: http.StatusOK,
+		Data: *sysmodel.TaskToOut(*m),
+	})
+}
+
+// @Summary      查询备份列表
+// @Description  本接口用于查询备份列表, 按备份时间倒序
+// @Tags         数据备份
+// @Produce      json
+// @Param        request query sysmodel.ListBackupRequest false "查询参数"
+// @Success      200  {object} sysmodel.PagBackupReply "成功返回备份列表"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/admin/backup [get]
+// @Security ApiKeyAuth
+func (h *BackupHandler) ListBackup(ctx *gin.Context) {
+	var req sysmodel.ListBackupRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定查询备份列表参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	page, size, query := req.Query()
+	qp := database.QueryParams{
+		IsCount:   true,
+		SkipCount: req.SkipCount(),
+		Size:      size,
+		Page:      page,
+		OrderBy:   []string{"id DESC"},
+		Query:     query,
+	}
+	total, ms, rErr := h.svcBackup.ListBackup(ctx, qp)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	mbs := sysmodel.ListBackupToOut(ms)
+	ctx.JSON(http.StatusOK, &sysmodel.PagBackupReply{
+		Code: http.StatusOK,
+		Data: commodel.NewPag(qp, total, mbs),
+	})
+}
+
+// @Summary      查询备份详情
+// @Description  本接口用于查询指定ID的备份
+// @Tags         数据备份
+// @Produce      json
+// @Param        id path uint32 true "备份ID"
+// @Success      200  {object} sysmodel.BackupReply "成功返回备份"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      404  {object} errors.Error "备份不存在"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/admin/backup/{id} [get]
+// @Security ApiKeyAuth
+func (h *BackupHandler) GetBackup(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定备份ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := h.svcBackup.FindBackupByID(ctx, uri.ID)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &sysmodel.BackupReply{
+		Code: http.StatusOK,
+		Data: *sysmodel.BackupToOut(*m),
+	})
+}
+
+// @Summary      下载备份文件
+// @Description  本接口用于下载指定ID的备份文件, 恢复方法见程序的 -restore 参数
+// @Tags         数据备份
+// @Produce      application/octet-stream
+// @Param        id path uint32 true "备份ID"
+// @Success      200  {file} file "成功下载备份文件"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      404  {object} errors.Error "备份或备份文件不存在"
+// @Failure      409  {object} errors.Error "备份未成功"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/admin/backup/{id}/download [get]
+// @Security ApiKeyAuth
+func (h *BackupHandler) DownloadBackup(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定下载备份ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := h.svcBackup.FindBackupByID(ctx, uri.ID)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	rc, rErr := h.svcBackup.OpenBackup(ctx, *m)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+	defer rc.Close()
+
+	size := m.Size
+	if size <= 0 {
+		size = -1
+	}
+	common.StreamFile(ctx, rc, size, m.Name, m.CreatedAt)
+}
+
+// @Summary      删除备份
+// @Description  本接口用于删除指定ID的备份文件和备份记录, 不能删除正在执行的备份
+// @Tags         数据备份
+// @Produce      json
+// @Param        id path uint32 true "备份ID"
+// @Success      200  {object} commodel.MapAPIReply "删除成功"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      404  {object} errors.Error "备份不存在"
+// @Failure      409  {object} errors.Error "备份正在执行"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/admin/backup/{id} [delete]
+// @Security ApiKeyAuth
+func (h *BackupHandler) DeleteBackup(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定备份ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	if rErr := h.svcBackup.DeleteBackupByID(ctx, uri.ID); rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(commodel.NoDataReply.Code, commodel.NoDataReply)
+}
+
+func (h *BackupHandler) LoadRouter(r *gin.RouterGroup) {
+	r.POST("/backup", h.CreateBackup)
+	r.GET("/backup", h.ListBackup)
+	r.GET("/backup/:id", h.GetBackup)
+	r.GET("/backup/:id/download", h.DownloadBackup)
+	r.DELETE("/backup/:id", h.DeleteBackup)
+}
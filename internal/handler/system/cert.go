@@ -0,0 +1,271 @@
+package system
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	commodel "gin-artweb/internal/model/common"
+	sysmodel "gin-artweb/internal/model/system"
+	syssvc "gin-artweb/internal/service/system"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+)
+
+type CertMonitorHandler struct {
+	log     *zap.Logger
+	svcCert *syssvc.CertMonitorService
+}
+
+func NewCertMonitorHandler(
+	logger *zap.Logger,
+	svcCert *syssvc.CertMonitorService,
+) *CertMonitorHandler {
+	return &CertMonitorHandler{
+		log:     logger,
+		svcCert: svcCert,
+	}
+}
+
+// @Summary      创建证书监控
+// @Description  本接口用于新增需要监控的TLS端点或证书文件，定时检查证书有效期和证书链，剩余天数到达告警阈值时告警
+// @Tags         证书监控
+// @Accept       json
+// @Produce      json
+// @Param        request body sysmodel.CertMonitorRequest true "证书监控"
+// @Success      201  {object} sysmodel.CertMonitorReply "成功返回证书监控"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      409  {object} errors.Error "名称已存在"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/system/cert [post]
+// @Security ApiKeyAuth
+func (h *CertMonitorHandler) CreateCertMonitor(ctx *gin.Context) {
+	var req sysmodel.CertMonitorRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定创建证书监控参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := h.svcCert.CreateCertMonitor(ctx, req.ToModel())
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, &sysmodel.CertMonitorReply{
+		Code: http.StatusCreated,
+		Data: sysmodel.CertMonitorToOut(*m),
+	})
+}
+
+// @Summary      更新证书监控
+// @Description  本接口用于更新指定ID的证书监控，更换地址或证书文件时清空上一次的检查结果
+// @Tags         证书监控
+// @Accept       json
+// @Produce      json
+// @Param        id path uint32 true "证书监控ID"
+// @Param        request body sysmodel.CertMonitorRequest true "证书监控"
+// @Success      200  {object} sysmodel.CertMonitorReply "成功返回证书监控"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      404  {object} errors.Error "证书监控不存在"
+// @Failure      409  {object} errors.Error "名称已存在"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/system/cert/{id} [put]
+// @Security ApiKeyAuth
+func (h *CertMonitorHandler) UpdateCertMonitor(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定证书监控ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	var req sysmodel.CertMonitorRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定更新证书监控参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	cm := req.ToModel()
+	cm.ID = uri.ID
+	m, rErr := h.svcCert.UpdateCertMonitorByID(ctx, cm)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &sysmodel.CertMonitorReply{
+		Code: http.StatusOK,
+		Data: sysmodel.CertMonitorToOut(*m),
+	})
+}
+
+// @Summary      删除证书监控
+// @Description  本接口用于删除指定ID的证书监控
+// @Tags         证书监控
+// @Produce      json
+// @Param        id path uint32 true "证书监控ID"
+// @Success      200  {object} commodel.MapAPIReply "删除成功"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      404  {object} errors.Error "证书监控不存在"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/system/cert/{id} [delete]
+// @Security ApiKeyAuth
+func (h *CertMonitorHandler) DeleteCertMonitor(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定证书监控ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	if rErr := h.svcCert.DeleteCertMonitorByID(ctx, uri.ID); rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(commodel.NoDataReply.Code, commodel.NoDataReply)
+}
+
+// @Summary      查询证书监控详情
+// @Description  本接口用于查询指定ID的证书监控和最近一次检查结果
+// @Tags         证书监控
+// @Produce      json
+// @Param        id path uint32 true "证书监控ID"
+// @Success      200  {object} sysmodel.CertMonitorReply "成功返回证书监控"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      404  {object} errors.Error "证书监控不存在"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/system/cert/{id} [get]
+// @Security ApiKeyAuth
+func (h *CertMonitorHandler) GetCertMonitor(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定证书监控ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := h.svcCert.FindCertMonitorByID(ctx, uri.ID)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &sysmodel.CertMonitorReply{
+		Code: http.StatusOK,
+		Data: sysmodel.CertMonitorToOut(*m),
+	})
+}
+
+// @Summary      查询证书监控列表
+// @Description  本接口用于查询证书监控列表，可按expire_within_days查询即将过期的证书，结果按过期时间升序排列
+// @Tags         证书监控
+// @Produce      json
+// @Param        request query sysmodel.ListCertMonitorRequest false "查询参数"
+// @Success      200  {object} sysmodel.PagCertMonitorReply "成功返回证书监控列表"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/system/cert [get]
+// @Security ApiKeyAuth
+func (h *CertMonitorHandler) ListCertMonitor(ctx *gin.Context) {
+	var req sysmodel.ListCertMonitorRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定查询证书监控列表参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	page, size, query := req.Query()
+	qp := database.QueryParams{
+		IsCount:   true,
+		SkipCount: req.SkipCount(),
+		Size:      size,
+		Page:      page,
+		OrderBy:   []string{"not_after IS NULL", "not_after ASC", "id ASC"},
+		Query:     query,
+	}
+	total, ms, rErr := h.svcCert.ListCertMonitor(ctx, qp)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	mbs := sysmodel.ListCertMonitorToOut(ms)
+	ctx.JSON(http.StatusOK, &sysmodel.PagCertMonitorReply{
+		Code: http.StatusOK,
+		Data: commodel.NewPag(qp, total, mbs),
+	})
+}
+
+// @Summary      立即检查证书监控
+// @Description  本接口用于立即检查指定ID的证书监控，与定时检查相同，到达告警阈值时告警
+// @Tags         证书监控
+// @Produce      json
+// @Param        id path uint32 true "证书监控ID"
+// @Success      200  {object} sysmodel.CertMonitorReply "成功返回检查后的证书监控"
+// @Failure      400  {object} errors.Error "请求参数错误"
+// @Failure      404  {object} errors.Error "证书监控不存在"
+// @Failure      500  {object} errors.Error "服务器内部错误"
+// @Router       /api/v1/system/cert/{id}/check [post]
+// @Security ApiKeyAuth
+func (h *CertMonitorHandler) CheckCertMonitor(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定证书监控ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := h.svcCert.CheckCertMonitorByID(ctx, uri.ID)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &sysmodel.CertMonitorReply{
+		Code: http.StatusOK,
+		Data: sysmodel.CertMonitorToOut(*m),
+	})
+}
+
+func (h *CertMonitorHandler) LoadRouter(r *gin.RouterGroup) {
+	r.POST("/cert", h.CreateCertMonitor)
+	r.PUT("/cert/:id", h.UpdateCertMonitor)
+	r.DELETE("/cert/:id", h.DeleteCertMonitor)
+	r.GET("/cert/:id", h.GetCertMonitor)
+	r.GET("/cert", h.ListCertMonitor)
+	r.POST("/cert/:id/check", h.CheckCertMonitor)
+}
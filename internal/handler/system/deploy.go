@@ -0,0 +1,45 @@
+package system
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	sysmodel "gin-artweb/internal/model/system"
+	"gin-artweb/internal/shared/config"
+)
+
+type DeployHandler struct {
+	log  *zap.Logger
+	conf *config.DeployConfig
+}
+
+func NewDeployHandler(
+	logger *zap.Logger,
+	conf *config.DeployConfig,
+) *DeployHandler {
+	return &DeployHandler{
+		log:  logger,
+		conf: conf,
+	}
+}
+
+// @Summary 查询部署模式
+// @Description 本接口用于查询当前部署模式及各功能的启用状态
+// @Tags 系统信息
+// @Accept json
+// @Produce json
+// @Success 200 {object} sysmodel.DeployInfoReply "成功返回部署模式信息"
+// @Router /api/v1/system/deploy [get]
+// @Security ApiKeyAuth
+func (h *DeployHandler) GetDeployInfo(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, &sysmodel.DeployInfoReply{
+		Code: http.StatusOK,
+		Data: *sysmodel.DeployInfoToOut(h.conf),
+	})
+}
+
+func (h *DeployHandler) LoadRouter(r *gin.RouterGroup) {
+	r.GET("/deploy", h.GetDeployInfo)
+}
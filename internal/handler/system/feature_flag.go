@@ -0,0 +1,303 @@
+package system
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	commodel "gin-artweb/internal/model/common"
+	sysmodel "gin-artweb/internal/model/system"
+	syssvc "gin-artweb/internal/service/system"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+)
+
+type FeatureFlagHandler struct {
+	log     *zap.Logger
+	svcFlag *syssvc.FeatureFlagService
+}
+
+func NewFeatureFlagHandler(
+	logger *zap.Logger,
+	svcFlag *syssvc.FeatureFlagService,
+) *FeatureFlagHandler {
+	return &FeatureFlagHandler{
+		log:     logger,
+		svcFlag: svcFlag,
+	}
+}
+
+// bindFeatureFlag 绑定功能开关请求参数并转换为模型
+func (h *FeatureFlagHandler) bindFeatureFlag(ctx *gin.Context) (*sysmodel.FeatureFlagModel, *errors.Error) {
+	var req sysmodel.FeatureFlagRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		return nil, errors.ErrValidationFailed.WithCause(err)
+	}
+
+	claims, rErr := ctxutil.GetUserClaims(ctx)
+	if rErr != nil {
+		return nil, rErr
+	}
+	ids := make([]string, 0, len(req.RoleIDs))
+	for _, id := range req.RoleIDs {
+		ids = append(ids, strconv.FormatUint(uint64(id), 10))
+	}
+	roleIDs := strings.Join(ids, ",")
+	if len(roleIDs) > 512 {
+		return nil, errors.ErrValidationFailed.WithField("role_ids", "开放的角色过多")
+	}
+	return &sysmodel.FeatureFlagModel{
+		Name:        req.Name,
+		IsEnabled:   req.IsEnabled,
+		RoleIDs:     roleIDs,
+		Percentage:  req.Percentage,
+		Description: req.Description,
+		Username:    claims.Username,
+	}, nil
+}
+
+// @Summary 创建功能开关
+// @Description 本接口用于创建功能开关, 开关名称不存在时功能对所有用户关闭
+// @Tags 功能开关
+// @Accept json
+// @Produce json
+// @Param request body sysmodel.FeatureFlagRequest true "创建功能开关请求"
+// @Success 200 {object} sysmodel.FeatureFlagReply "成功返回功能开关信息"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/admin/feature-flag [post]
+// @Security ApiKeyAuth
+func (h *FeatureFlagHandler) CreateFeatureFlag(ctx *gin.Context) {
+	sub, rErr := h.bindFeatureFlag(ctx)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定创建功能开关参数失败",
+			zap.Error(rErr),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := h.svcFlag.CreateFeatureFlag(ctx, *sub)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"创建功能开关失败",
+			zap.Error(rErr),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &sysmodel.FeatureFlagReply{
+		Code: http.StatusOK,
+		Data: *sysmodel.FeatureFlagToOut(*m),
+	})
+}
+
+// @Summary 更新功能开关
+// @Description 本接口用于更新指定ID的功能开关, 修改后本实例立即生效, 其他实例在1分钟内生效
+// @Tags 功能开关
+// @Accept json
+// @Produce json
+// @Param id path uint true "功能开关编号"
+// @Param request body sysmodel.FeatureFlagRequest true "更新功能开关请求"
+// @Success 200 {object} sysmodel.FeatureFlagReply "成功返回功能开关信息"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 404 {object} errors.Error "功能开关未找到"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/admin/feature-flag/{id} [put]
+// @Security ApiKeyAuth
+func (h *FeatureFlagHandler) UpdateFeatureFlag(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定更新功能开关ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	sub, rErr := h.bindFeatureFlag(ctx)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定更新功能开关参数失败",
+			zap.Error(rErr),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := h.svcFlag.UpdateFeatureFlagByID(ctx, uri.ID, *sub)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"更新功能开关失败",
+			zap.Error(rErr),
+			zap.Uint32(commodel.RequestIDKey, uri.ID),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &sysmodel.FeatureFlagReply{
+		Code: http.StatusOK,
+		Data: *sysmodel.FeatureFlagToOut(*m),
+	})
+}
+
+// @Summary 删除功能开关
+// @Description 本接口用于删除指定ID的功能开关, 删除后功能对所有用户关闭
+// @Tags 功能开关
+// @Accept json
+// @Produce json
+// @Param id path uint true "功能开关编号"
+// @Success 200 {object} commodel.MapAPIReply "删除成功"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 404 {object} errors.Error "功能开关未找到"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/admin/feature-flag/{id} [delete]
+// @Security ApiKeyAuth
+func (h *FeatureFlagHandler) DeleteFeatureFlag(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定删除功能开关ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	if rErr := h.svcFlag.DeleteFeatureFlagByID(ctx, uri.ID); rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"删除功能开关失败",
+			zap.Error(rErr),
+			zap.Uint32(commodel.RequestIDKey, uri.ID),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(commodel.NoDataReply.Code, commodel.NoDataReply)
+}
+
+// @Summary 查询功能开关详情
+// @Description 本接口用于查询指定ID的功能开关
+// @Tags 功能开关
+// @Accept json
+// @Produce json
+// @Param id path uint true "功能开关编号"
+// @Success 200 {object} sysmodel.FeatureFlagReply "成功返回功能开关信息"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 404 {object} errors.Error "功能开关未找到"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/admin/feature-flag/{id} [get]
+// @Security ApiKeyAuth
+func (h *FeatureFlagHandler) GetFeatureFlag(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定查询功能开关ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := h.svcFlag.FindFeatureFlagByID(ctx, uri.ID)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询功能开关失败",
+			zap.Error(rErr),
+			zap.Uint32(commodel.RequestIDKey, uri.ID),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &sysmodel.FeatureFlagReply{
+		Code: http.StatusOK,
+		Data: *sysmodel.FeatureFlagToOut(*m),
+	})
+}
+
+// @Summary 查询功能开关列表
+// @Description 本接口用于分页查询功能开关
+// @Tags 功能开关
+// @Accept json
+// @Produce json
+// @Param request query sysmodel.ListFeatureFlagRequest false "查询参数"
+// @Success 200 {object} sysmodel.PagFeatureFlagReply "成功返回功能开关列表"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/admin/feature-flag [get]
+// @Security ApiKeyAuth
+func (h *FeatureFlagHandler) ListFeatureFlag(ctx *gin.Context) {
+	var req sysmodel.ListFeatureFlagRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定查询功能开关列表参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	page, size, query := req.Query()
+	qp := database.QueryParams{
+		IsCount:   true,
+		SkipCount: req.SkipCount(),
+		Size:      size,
+		Page:      page,
+		OrderBy:   []string{"id DESC"},
+		Query:     query,
+	}
+	total, ms, rErr := h.svcFlag.ListFeatureFlag(ctx, qp)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询功能开关列表失败",
+			zap.Error(rErr),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	mbs := sysmodel.ListFeatureFlagToOut(ms)
+	ctx.JSON(http.StatusOK, &sysmodel.PagFeatureFlagReply{
+		Code: http.StatusOK,
+		Data: commodel.NewPag(qp, total, mbs),
+	})
+}
+
+// @Summary 查询当前用户可用的功能
+// @Description 本接口用于查询全部功能开关对当前用户是否开放, 前端据此决定是否展示功能入口
+// @Tags 功能开关
+// @Accept json
+// @Produce json
+// @Success 200 {object} sysmodel.MyFeatureFlagReply "成功返回开关名称和是否开放"
+// @Failure 401 {object} errors.Error "未登录"
+// @Router /api/v1/system/me/feature-flag [get]
+// @Security ApiKeyAuth
+func (h *FeatureFlagHandler) GetMyFeatureFlag(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, &sysmodel.MyFeatureFlagReply{
+		Code: http.StatusOK,
+		Data: h.svcFlag.EnabledFeatures(ctx),
+	})
+}
+
+func (h *FeatureFlagHandler) LoadRouter(r *gin.RouterGroup) {
+	r.POST("/feature-flag", h.CreateFeatureFlag)
+	r.PUT("/feature-flag/:id", h.UpdateFeatureFlag)
+	r.DELETE("/feature-flag/:id", h.DeleteFeatureFlag)
+	r.GET("/feature-flag/:id", h.GetFeatureFlag)
+	r.GET("/feature-flag", h.ListFeatureFlag)
+}
@@ -0,0 +1,316 @@
+package system
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	commodel "gin-artweb/internal/model/common"
+	sysmodel "gin-artweb/internal/model/system"
+	syssvc "gin-artweb/internal/service/system"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+)
+
+type ChangeFreezeHandler struct {
+	log       *zap.Logger
+	svcFreeze *syssvc.ChangeFreezeService
+}
+
+func NewChangeFreezeHandler(
+	logger *zap.Logger,
+	svcFreeze *syssvc.ChangeFreezeService,
+) *ChangeFreezeHandler {
+	return &ChangeFreezeHandler{
+		log:       logger,
+		svcFreeze: svcFreeze,
+	}
+}
+
+// bindChangeFreeze 绑定变更冻结请求参数并转换为模型
+func (h *ChangeFreezeHandler) bindChangeFreeze(ctx *gin.Context) (*sysmodel.ChangeFreezeModel, *errors.Error) {
+	var req sysmodel.ChangeFreezeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		return nil, errors.ErrValidationFailed.WithCause(err)
+	}
+	startAt, err := time.ParseInLocation(time.DateTime, req.StartAt, time.Local)
+	if err != nil {
+		return nil, errors.ErrValidationFailed.WithCause(err)
+	}
+	endAt, err := time.ParseInLocation(time.DateTime, req.EndAt, time.Local)
+	if err != nil {
+		return nil, errors.ErrValidationFailed.WithCause(err)
+	}
+
+	claims, rErr := ctxutil.GetUserClaims(ctx)
+	if rErr != nil {
+		return nil, rErr
+	}
+	return &sysmodel.ChangeFreezeModel{
+		Name:      req.Name,
+		Scope:     req.Scope,
+		Module:    req.Module,
+		StartAt:   startAt,
+		EndAt:     endAt,
+		IsEnabled: req.IsEnabled,
+		Reason:    req.Reason,
+		Username:  claims.Username,
+	}, nil
+}
+
+// @Summary 创建变更冻结
+// @Description 本接口用于创建变更冻结, 生效期间拒绝覆盖范围内的修改接口
+// @Tags 变更冻结
+// @Accept json
+// @Produce json
+// @Param request body sysmodel.ChangeFreezeRequest true "创建变更冻结请求"
+// @Success 200 {object} sysmodel.ChangeFreezeReply "成功返回变更冻结信息"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 422 {object} errors.Error "变更冻结配置无效"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/system/freeze [post]
+// @Security ApiKeyAuth
+func (h *ChangeFreezeHandler) CreateChangeFreeze(ctx *gin.Context) {
+	window, rErr := h.bindChangeFreeze(ctx)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定创建变更冻结参数失败",
+			zap.Error(rErr),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := h.svcFreeze.CreateChangeFreeze(ctx, *window)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"创建变更冻结失败",
+			zap.Error(rErr),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &sysmodel.ChangeFreezeReply{
+		Code: http.StatusOK,
+		Data: *sysmodel.ChangeFreezeToOut(*m),
+	})
+}
+
+// @Summary 更新变更冻结
+// @Description 本接口用于更新指定ID的变更冻结
+// @Tags 变更冻结
+// @Accept json
+// @Produce json
+// @Param id path uint true "变更冻结编号"
+// @Param request body sysmodel.ChangeFreezeRequest true "更新变更冻结请求"
+// @Success 200 {object} sysmodel.ChangeFreezeReply "成功返回变更冻结信息"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 404 {object} errors.Error "变更冻结未找到"
+// @Failure 422 {object} errors.Error "变更冻结配置无效"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/system/freeze/{id} [put]
+// @Security ApiKeyAuth
+func (h *ChangeFreezeHandler) UpdateChangeFreeze(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定更新变更冻结ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	window, rErr := h.bindChangeFreeze(ctx)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定更新变更冻结参数失败",
+			zap.Error(rErr),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := h.svcFreeze.UpdateChangeFreezeByID(ctx, uri.ID, *window)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"更新变更冻结失败",
+			zap.Error(rErr),
+			zap.Uint32(commodel.RequestIDKey, uri.ID),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &sysmodel.ChangeFreezeReply{
+		Code: http.StatusOK,
+		Data: *sysmodel.ChangeFreezeToOut(*m),
+	})
+}
+
+// @Summary 删除变更冻结
+// @Description 本接口用于删除指定ID的变更冻结
+// @Tags 变更冻结
+// @Accept json
+// @Produce json
+// @Param id path uint true "变更冻结编号"
+// @Success 200 {object} commodel.MapAPIReply "删除成功"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 404 {object} errors.Error "变更冻结未找到"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/system/freeze/{id} [delete]
+// @Security ApiKeyAuth
+func (h *ChangeFreezeHandler) DeleteChangeFreeze(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定删除变更冻结ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	claims, rErr := ctxutil.GetUserClaims(ctx)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"获取个人登录信息失败",
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	if rErr := h.svcFreeze.DeleteChangeFreezeByID(ctx, uri.ID, claims.Username); rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"删除变更冻结失败",
+			zap.Error(rErr),
+			zap.Uint32(commodel.RequestIDKey, uri.ID),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(commodel.NoDataReply.Code, commodel.NoDataReply)
+}
+
+// @Summary 查询变更冻结详情
+// @Description 本接口用于查询指定ID的变更冻结
+// @Tags 变更冻结
+// @Accept json
+// @Produce json
+// @Param id path uint true "变更冻结编号"
+// @Success 200 {object} sysmodel.ChangeFreezeReply "成功返回变更冻结信息"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 404 {object} errors.Error "变更冻结未找到"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/system/freeze/{id} [get]
+// @Security ApiKeyAuth
+func (h *ChangeFreezeHandler) GetChangeFreeze(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定查询变更冻结ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := h.svcFreeze.FindChangeFreezeByID(ctx, uri.ID)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询变更冻结失败",
+			zap.Error(rErr),
+			zap.Uint32(commodel.RequestIDKey, uri.ID),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &sysmodel.ChangeFreezeReply{
+		Code: http.StatusOK,
+		Data: *sysmodel.ChangeFreezeToOut(*m),
+	})
+}
+
+// @Summary 查询变更冻结列表
+// @Description 本接口用于分页查询变更冻结
+// @Tags 变更冻结
+// @Accept json
+// @Produce json
+// @Param request query sysmodel.ListChangeFreezeRequest false "查询参数"
+// @Success 200 {object} sysmodel.PagChangeFreezeReply "成功返回变更冻结列表"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/system/freeze [get]
+// @Security ApiKeyAuth
+func (h *ChangeFreezeHandler) ListChangeFreeze(ctx *gin.Context) {
+	var req sysmodel.ListChangeFreezeRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定查询变更冻结列表参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	page, size, query := req.Query()
+	qp := database.QueryParams{
+		IsCount:   true,
+		SkipCount: req.SkipCount(),
+		Size:      size,
+		Page:      page,
+		OrderBy:   []string{"id DESC"},
+		Query:     query,
+	}
+	total, ms, rErr := h.svcFreeze.ListChangeFreeze(ctx, qp)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询变更冻结列表失败",
+			zap.Error(rErr),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	mbs := sysmodel.ListChangeFreezeToOut(ms)
+	ctx.JSON(http.StatusOK, &sysmodel.PagChangeFreezeReply{
+		Code: http.StatusOK,
+		Data: commodel.NewPag(qp, total, mbs),
+	})
+}
+
+// @Summary 查询当前生效的变更冻结
+// @Description 本接口用于查询当前时间生效的全部变更冻结
+// @Tags 变更冻结
+// @Accept json
+// @Produce json
+// @Success 200 {object} sysmodel.ListChangeFreezeReply "成功返回生效的变更冻结"
+// @Router /api/v1/system/freeze/active [get]
+// @Security ApiKeyAuth
+func (h *ChangeFreezeHandler) ListActiveChangeFreeze(ctx *gin.Context) {
+	ms := h.svcFreeze.ActiveFreezes(time.Now())
+	ctx.JSON(http.StatusOK, &sysmodel.ListChangeFreezeReply{
+		Code: http.StatusOK,
+		Data: *sysmodel.ListChangeFreezeToOut(&ms),
+	})
+}
+
+func (h *ChangeFreezeHandler) LoadRouter(r *gin.RouterGroup) {
+	r.POST("/freeze", h.CreateChangeFreeze)
+	r.GET("/freeze/active", h.ListActiveChangeFreeze)
+	r.PUT("/freeze/:id", h.UpdateChangeFreeze)
+	r.DELETE("/freeze/:id", h.DeleteChangeFreeze)
+	r.GET("/freeze/:id", h.GetChangeFreeze)
+	r.GET("/freeze", h.ListChangeFreeze)
+}
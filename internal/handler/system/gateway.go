@@ -0,0 +1,171 @@
+package system
+
+import (
+	"encoding/json"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	commodel "gin-artweb/internal/model/common"
+	sysmodel "gin-artweb/internal/model/system"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/errors"
+)
+
+// 同时执行的子查询数量
+const gatewayConcurrency = 4
+
+// 子查询沿用原请求的请求头, 鉴权和防重放中间件依赖这些请求头
+var gatewayForwardHeaders = []string{
+	"Authorization",
+	"Cookie",
+	"X-Timestamp",
+	"X-Forwarded-For",
+	"X-Real-IP",
+	"Accept-Language",
+	"User-Agent",
+}
+
+// GatewayHandler 只读查询网关
+//
+// 仪表盘等页面需要同时查询多个接口时, 将多个GET接口合并为一次请求.
+// 子查询在进程内按原请求的身份重新经过完整的中间件链, 鉴权、限流和审计与直接调用接口一致,
+// 相同路径和参数的子查询只执行一次
+type GatewayHandler struct {
+	log    *zap.Logger
+	engine http.Handler
+}
+
+func NewGatewayHandler(
+	logger *zap.Logger,
+	engine http.Handler,
+) *GatewayHandler {
+	return &GatewayHandler{
+		log:    logger,
+		engine: engine,
+	}
+}
+
+// @Summary 批量查询接口
+// @Description 本接口用于在一次请求中查询多个GET接口, 各子查询分别鉴权, 子查询失败不影响其他子查询
+// @Tags 查询网关
+// @Accept json
+// @Produce json
+// @Param request body sysmodel.GatewayRequest true "子查询"
+// @Success 200 {object} sysmodel.GatewayReply "成功返回各子查询的响应"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Router /api/v1/system/gateway/query [post]
+// @Security ApiKeyAuth
+func (h *GatewayHandler) Query(ctx *gin.Context) {
+	var req sysmodel.GatewayRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定批量查询参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctxutil.Logger(ctx, h.log).Info(
+		"开始批量查询",
+		zap.Object(commodel.RequestModelKey, &req),
+	)
+
+	// 相同的子查询只执行一次
+	keys := make(map[string]sysmodel.GatewayQuery, len(req.Queries))
+	for _, q := range req.Queries {
+		keys[q.Key()] = q
+	}
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		sem     = make(chan struct{}, gatewayConcurrency)
+		results = make(map[string]sysmodel.GatewayResultOut, len(keys))
+		seq     int
+	)
+	for key, q := range keys {
+		seq++
+		wg.Add(1)
+		go func(key string, q sysmodel.GatewayQuery, seq int) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			out := h.dispatch(ctx, q, seq)
+			mu.Lock()
+			results[key] = out
+			mu.Unlock()
+		}(key, q, seq)
+	}
+	wg.Wait()
+
+	data := make(map[string]sysmodel.GatewayResultOut, len(req.Queries))
+	for name, q := range req.Queries {
+		data[name] = results[q.Key()]
+	}
+
+	ctxutil.Logger(ctx, h.log).Info(
+		"批量查询成功",
+		zap.Int("queries", len(req.Queries)),
+		zap.Int("dispatched", len(keys)),
+	)
+
+	ctx.JSON(http.StatusOK, &sysmodel.GatewayReply{
+		Code: http.StatusOK,
+		Data: data,
+	})
+}
+
+// dispatch 在进程内执行单个子查询
+func (h *GatewayHandler) dispatch(ctx *gin.Context, q sysmodel.GatewayQuery, seq int) sysmodel.GatewayResultOut {
+	target := q.Path
+	if len(q.Query) > 0 {
+		target += "?" + q.Values().Encode()
+	}
+	subReq := httptest.NewRequest(http.MethodGet, target, nil).WithContext(ctx.Request.Context())
+	subReq.RemoteAddr = ctx.Request.RemoteAddr
+	for _, name := range gatewayForwardHeaders {
+		if v := ctx.GetHeader(name); v != "" {
+			subReq.Header.Set(name, v)
+		}
+	}
+	// 防重放中间件不允许重复的随机数, 每个子查询使用不同的随机数
+	if nonce := ctx.GetHeader("X-Nonce"); nonce != "" {
+		subReq.Header.Set("X-Nonce", nonce+"-"+strconv.Itoa(seq))
+	}
+
+	rec := httptest.NewRecorder()
+	h.engine.ServeHTTP(rec, subReq)
+
+	body := rec.Body.Bytes()
+	mediaType, _, _ := mime.ParseMediaType(rec.Header().Get("Content-Type"))
+	if mediaType != "application/json" || !json.Valid(body) {
+		ctxutil.Logger(ctx, h.log).Warn(
+			"子查询的响应不是JSON",
+			zap.String("path", q.Path),
+			zap.Int("status", rec.Code),
+			zap.String("content_type", rec.Header().Get("Content-Type")),
+		)
+		// 接口不存在等错误响应保留原状态码
+		rErr := errors.ErrGatewayResponseNotJSON.WithField("path", q.Path)
+		status := errors.GetHTTPStatus(rErr.Reason)
+		if rec.Code >= http.StatusBadRequest {
+			status = rec.Code
+		}
+		body, _ = json.Marshal(errors.ErrorResponseWithCode(status, rErr))
+		return sysmodel.GatewayResultOut{Status: status, Body: body}
+	}
+	return sysmodel.GatewayResultOut{Status: rec.Code, Body: body}
+}
+
+func (h *GatewayHandler) LoadRouter(r *gin.RouterGroup) {
+	r.POST("/gateway/query", h.Query)
+}
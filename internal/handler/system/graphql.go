@@ -0,0 +1,94 @@
+package system
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	commodel "gin-artweb/internal/model/common"
+	sysmodel "gin-artweb/internal/model/system"
+	syssvc "gin-artweb/internal/service/system"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/errors"
+)
+
+type GraphQLHandler struct {
+	log        *zap.Logger
+	svcGraphQL *syssvc.GraphQLService
+}
+
+func NewGraphQLHandler(
+	logger *zap.Logger,
+	svcGraphQL *syssvc.GraphQLService,
+) *GraphQLHandler {
+	return &GraphQLHandler{
+		log:        logger,
+		svcGraphQL: svcGraphQL,
+	}
+}
+
+// @Summary GraphQL查询
+// @Description 本接口用于在一次请求中查询集群、任务状态、mon节点、主机、程序包和脚本执行记录, 只支持query操作.
+// @Description 各查询字段按对应列表接口的权限鉴权, 无权访问的字段返回null并在errors中返回原因, 关联数据在单次查询内合并为批量查询
+// @Tags 查询网关
+// @Accept json
+// @Produce json
+// @Param request body sysmodel.GraphQLRequest true "查询语句"
+// @Success 200 {object} sysmodel.GraphQLReply "成功返回查询结果, 字段错误在errors中返回"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Router /api/graphql [post]
+// @Security ApiKeyAuth
+func (h *GraphQLHandler) Query(ctx *gin.Context) {
+	var req sysmodel.GraphQLRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定GraphQL查询参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctxutil.Logger(ctx, h.log).Info(
+		"开始GraphQL查询",
+		zap.Object(commodel.RequestModelKey, &req),
+	)
+
+	result, rErr := h.svcGraphQL.Execute(ctx, req)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"GraphQL查询失败",
+			zap.Error(rErr),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	data, err := json.Marshal(result.Data)
+	if err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"序列化GraphQL查询结果失败",
+			zap.Error(err),
+		)
+		errors.RespondWithError(ctx, errors.FromError(err))
+		return
+	}
+	reply := sysmodel.GraphQLReply{Data: data}
+	for _, e := range result.Errors {
+		out := sysmodel.GraphQLErrorOut{Message: e.Message, Path: e.Path, Extensions: e.Extensions}
+		reply.Errors = append(reply.Errors, out)
+	}
+
+	ctxutil.Logger(ctx, h.log).Info(
+		"GraphQL查询成功",
+		zap.Int("errors", len(reply.Errors)),
+	)
+	ctx.JSON(http.StatusOK, &reply)
+}
+
+func (h *GraphQLHandler) LoadRouter(r *gin.RouterGroup) {
+	r.POST("/graphql", h.Query)
+}
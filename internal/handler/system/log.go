@@ -0,0 +1,78 @@
+package system
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	sysmodel "gin-artweb/internal/model/system"
+	syssvc "gin-artweb/internal/service/system"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/errors"
+)
+
+type LogHandler struct {
+	log    *zap.Logger
+	svcLog *syssvc.LogQueryService
+}
+
+func NewLogHandler(
+	logger *zap.Logger,
+	svcLog *syssvc.LogQueryService,
+) *LogHandler {
+	return &LogHandler{
+		log:    logger,
+		svcLog: svcLog,
+	}
+}
+
+// @Summary 查询运行日志
+// @Description 本接口用于按时间范围、日志级别、链路ID和关键字检索服务运行日志, 结果按时间倒序返回
+// @Tags 运行日志
+// @Accept json
+// @Produce json
+// @Param request query sysmodel.ListLogRequest false "查询参数"
+// @Success 200 {object} sysmodel.ListLogEntryReply "成功返回日志列表"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 500 {object} errors.Error "日志查询失败"
+// @Router /api/v1/admin/logs [get]
+// @Security ApiKeyAuth
+func (h *LogHandler) ListLog(ctx *gin.Context) {
+	var req sysmodel.ListLogRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定查询运行日志参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	q, err := req.LogQuery()
+	if err != nil {
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	entries, rErr := h.svcLog.Search(ctx, req.Sources(), q)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询运行日志失败",
+			zap.Error(rErr),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &sysmodel.ListLogEntryReply{
+		Code: http.StatusOK,
+		Data: *sysmodel.ListLogEntryToOut(entries),
+	})
+}
+
+func (h *LogHandler) LoadRouter(r *gin.RouterGroup) {
+	r.GET("/logs", h.ListLog)
+}
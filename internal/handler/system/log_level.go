@@ -0,0 +1,130 @@
+package system
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	sysmodel "gin-artweb/internal/model/system"
+	syssvc "gin-artweb/internal/service/system"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/errors"
+)
+
+type LogLevelHandler struct {
+	log         *zap.Logger
+	svcLogLevel *syssvc.LogLevelService
+}
+
+func NewLogLevelHandler(
+	logger *zap.Logger,
+	svcLogLevel *syssvc.LogLevelService,
+) *LogLevelHandler {
+	return &LogLevelHandler{
+		log:         logger,
+		svcLogLevel: svcLogLevel,
+	}
+}
+
+// @Summary 查询日志级别
+// @Description 本接口用于查询各日志记录器的当前级别和启动时的级别, 仅限工作人员访问
+// @Tags 运行日志
+// @Accept json
+// @Produce json
+// @Success 200 {object} sysmodel.ListLogLevelReply "成功返回日志级别列表"
+// @Failure 403 {object} errors.Error "仅限工作人员访问"
+// @Router /api/v1/admin/log-level [get]
+// @Security ApiKeyAuth
+func (h *LogLevelHandler) ListLogLevel(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, &sysmodel.ListLogLevelReply{
+		Code: http.StatusOK,
+		Data: h.svcLogLevel.ListLevels(ctx),
+	})
+}
+
+// @Summary 调整日志级别
+// @Description 本接口用于在运行时调整日志级别, 立即生效, 只对当前实例生效且重启后恢复配置文件中的级别, 仅限工作人员访问
+// @Tags 运行日志
+// @Accept json
+// @Produce json
+// @Param request body sysmodel.SetLogLevelRequest true "调整日志级别请求"
+// @Success 200 {object} sysmodel.ListLogLevelReply "成功返回调整后的日志级别列表"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 403 {object} errors.Error "仅限工作人员访问"
+// @Failure 404 {object} errors.Error "日志记录器不存在"
+// @Router /api/v1/admin/log-level [put]
+// @Security ApiKeyAuth
+func (h *LogLevelHandler) SetLogLevel(ctx *gin.Context) {
+	var req sysmodel.SetLogLevelRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定调整日志级别参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	levels, rErr := h.svcLogLevel.SetLevel(ctx, req.Logger, req.Level)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"调整日志级别失败",
+			zap.Error(rErr),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &sysmodel.ListLogLevelReply{
+		Code: http.StatusOK,
+		Data: levels,
+	})
+}
+
+// @Summary 恢复日志级别
+// @Description 本接口用于将日志级别恢复为启动时的级别, 仅限工作人员访问
+// @Tags 运行日志
+// @Accept json
+// @Produce json
+// @Param request query sysmodel.ResetLogLevelRequest false "恢复日志级别请求"
+// @Success 200 {object} sysmodel.ListLogLevelReply "成功返回恢复后的日志级别列表"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 403 {object} errors.Error "仅限工作人员访问"
+// @Failure 404 {object} errors.Error "日志记录器不存在"
+// @Router /api/v1/admin/log-level [delete]
+// @Security ApiKeyAuth
+func (h *LogLevelHandler) ResetLogLevel(ctx *gin.Context) {
+	var req sysmodel.ResetLogLevelRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定恢复日志级别参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	levels, rErr := h.svcLogLevel.ResetLevel(ctx, req.Logger)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"恢复日志级别失败",
+			zap.Error(rErr),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &sysmodel.ListLogLevelReply{
+		Code: http.StatusOK,
+		Data: levels,
+	})
+}
+
+func (h *LogLevelHandler) LoadRouter(r *gin.RouterGroup) {
+	r.GET("/log-level", h.ListLogLevel)
+	r.PUT("/log-level", h.SetLogLevel)
+	r.DELETE("/log-level", h.ResetLogLevel)
+}
@@ -0,0 +1,328 @@
+package system
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	commodel "gin-artweb/internal/model/common"
+	sysmodel "gin-artweb/internal/model/system"
+	syssvc "gin-artweb/internal/service/system"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+)
+
+type MaintenanceHandler struct {
+	log            *zap.Logger
+	svcMaintenance *syssvc.MaintenanceService
+}
+
+func NewMaintenanceHandler(
+	logger *zap.Logger,
+	svcMaintenance *syssvc.MaintenanceService,
+) *MaintenanceHandler {
+	return &MaintenanceHandler{
+		log:            logger,
+		svcMaintenance: svcMaintenance,
+	}
+}
+
+// bindMaintenanceWindow 绑定维护窗口请求参数并转换为模型
+func (h *MaintenanceHandler) bindMaintenanceWindow(ctx *gin.Context) (*sysmodel.MaintenanceWindowModel, *errors.Error) {
+	var req sysmodel.MaintenanceWindowRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		return nil, errors.ErrValidationFailed.WithCause(err)
+	}
+	startAt, err := time.ParseInLocation(time.DateTime, req.StartAt, time.Local)
+	if err != nil {
+		return nil, errors.ErrValidationFailed.WithCause(err)
+	}
+	endAt, err := time.ParseInLocation(time.DateTime, req.EndAt, time.Local)
+	if err != nil {
+		return nil, errors.ErrValidationFailed.WithCause(err)
+	}
+
+	claims, rErr := ctxutil.GetUserClaims(ctx)
+	if rErr != nil {
+		return nil, rErr
+	}
+	return &sysmodel.MaintenanceWindowModel{
+		Name:        req.Name,
+		Scope:       req.Scope,
+		Module:      req.Module,
+		TargetID:    req.TargetID,
+		StartAt:     startAt,
+		EndAt:       endAt,
+		Recurrence:  req.Recurrence,
+		IsEnabled:   req.IsEnabled,
+		Description: req.Description,
+		Username:    claims.Username,
+	}, nil
+}
+
+// @Summary 创建维护窗口
+// @Description 本接口用于创建维护窗口, 生效期间暂停命中的计划任务并屏蔽告警
+// @Tags 维护窗口
+// @Accept json
+// @Produce json
+// @Param request body sysmodel.MaintenanceWindowRequest true "创建维护窗口请求"
+// @Success 200 {object} sysmodel.MaintenanceWindowReply "成功返回维护窗口信息"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 422 {object} errors.Error "维护窗口配置无效"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/system/maintenance [post]
+// @Security ApiKeyAuth
+func (h *MaintenanceHandler) CreateMaintenanceWindow(ctx *gin.Context) {
+	window, rErr := h.bindMaintenanceWindow(ctx)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定创建维护窗口参数失败",
+			zap.Error(rErr),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := h.svcMaintenance.CreateMaintenanceWindow(ctx, *window)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"创建维护窗口失败",
+			zap.Error(rErr),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &sysmodel.MaintenanceWindowReply{
+		Code: http.StatusOK,
+		Data: *sysmodel.MaintenanceWindowToOut(*m),
+	})
+}
+
+// @Summary 更新维护窗口
+// @Description 本接口用于更新指定ID的维护窗口
+// @Tags 维护窗口
+// @Accept json
+// @Produce json
+// @Param id path uint true "维护窗口编号"
+// @Param request body sysmodel.MaintenanceWindowRequest true "更新维护窗口请求"
+// @Success 200 {object} sysmodel.MaintenanceWindowReply "成功返回维护窗口信息"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 404 {object} errors.Error "维护窗口未找到"
+// @Failure 422 {object} errors.Error "维护窗口配置无效"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/system/maintenance/{id} [put]
+// @Security ApiKeyAuth
+func (h *MaintenanceHandler) UpdateMaintenanceWindow(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定更新维护窗口ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	window, rErr := h.bindMaintenanceWindow(ctx)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定更新维护窗口参数失败",
+			zap.Error(rErr),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := h.svcMaintenance.UpdateMaintenanceWindowByID(ctx, uri.ID, *window)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"更新维护窗口失败",
+			zap.Error(rErr),
+			zap.Uint32(commodel.RequestIDKey, uri.ID),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &sysmodel.MaintenanceWindowReply{
+		Code: http.StatusOK,
+		Data: *sysmodel.MaintenanceWindowToOut(*m),
+	})
+}
+
+// @Summary 删除维护窗口
+// @Description 本接口用于删除指定ID的维护窗口
+// @Tags 维护窗口
+// @Accept json
+// @Produce json
+// @Param id path uint true "维护窗口编号"
+// @Success 200 {object} commodel.MapAPIReply "删除成功"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 404 {object} errors.Error "维护窗口未找到"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/system/maintenance/{id} [delete]
+// @Security ApiKeyAuth
+func (h *MaintenanceHandler) DeleteMaintenanceWindow(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定删除维护窗口ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	claims, rErr := ctxutil.GetUserClaims(ctx)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"获取个人登录信息失败",
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	if rErr := h.svcMaintenance.DeleteMaintenanceWindowByID(ctx, uri.ID, claims.Username); rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"删除维护窗口失败",
+			zap.Error(rErr),
+			zap.Uint32(commodel.RequestIDKey, uri.ID),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(commodel.NoDataReply.Code, commodel.NoDataReply)
+}
+
+// @Summary 查询维护窗口详情
+// @Description 本接口用于查询指定ID的维护窗口
+// @Tags 维护窗口
+// @Accept json
+// @Produce json
+// @Param id path uint true "维护窗口编号"
+// @Success 200 {object} sysmodel.MaintenanceWindowReply "成功返回维护窗口信息"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 404 {object} errors.Error "维护窗口未找到"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/system/maintenance/{id} [get]
+// @Security ApiKeyAuth
+func (h *MaintenanceHandler) GetMaintenanceWindow(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定查询维护窗口ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := h.svcMaintenance.FindMaintenanceWindowByID(ctx, uri.ID)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询维护窗口失败",
+			zap.Error(rErr),
+			zap.Uint32(commodel.RequestIDKey, uri.ID),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &sysmodel.MaintenanceWindowReply{
+		Code: http.StatusOK,
+		Data: *sysmodel.MaintenanceWindowToOut(*m),
+	})
+}
+
+// @Summary 查询维护窗口列表
+// @Description 本接口用于分页查询维护窗口
+// @Tags 维护窗口
+// @Accept json
+// @Produce json
+// @Param request query sysmodel.ListMaintenanceWindowRequest false "查询参数"
+// @Success 200 {object} sysmodel.PagMaintenanceWindowReply "成功返回维护窗口列表"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/system/maintenance [get]
+// @Security ApiKeyAuth
+func (h *MaintenanceHandler) ListMaintenanceWindow(ctx *gin.Context) {
+	var req sysmodel.ListMaintenanceWindowRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定查询维护窗口列表参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	page, size, query := req.Query()
+	qp := database.QueryParams{
+		IsCount:   true,
+		SkipCount: req.SkipCount(),
+		Size:      size,
+		Page:      page,
+		OrderBy:   []string{"id DESC"},
+		Query:     query,
+	}
+	total, ms, rErr := h.svcMaintenance.ListMaintenanceWindow(ctx, qp)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询维护窗口列表失败",
+			zap.Error(rErr),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	mbs := sysmodel.ListMaintenanceWindowToOut(ms)
+	ctx.JSON(http.StatusOK, &sysmodel.PagMaintenanceWindowReply{
+		Code: http.StatusOK,
+		Data: commodel.NewPag(qp, total, mbs),
+	})
+}
+
+// @Summary 查询当前生效的维护窗口
+// @Description 本接口用于查询当前时间生效的全部维护窗口
+// @Tags 维护窗口
+// @Accept json
+// @Produce json
+// @Success 200 {object} sysmodel.ListMaintenanceWindowReply "成功返回生效的维护窗口"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/system/maintenance/active [get]
+// @Security ApiKeyAuth
+func (h *MaintenanceHandler) ListActiveMaintenanceWindow(ctx *gin.Context) {
+	ms, rErr := h.svcMaintenance.ActiveWindows(ctx, time.Now())
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询生效的维护窗口失败",
+			zap.Error(rErr),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &sysmodel.ListMaintenanceWindowReply{
+		Code: http.StatusOK,
+		Data: *sysmodel.ListMaintenanceWindowToOut(&ms),
+	})
+}
+
+func (h *MaintenanceHandler) LoadRouter(r *gin.RouterGroup) {
+	r.POST("/maintenance", h.CreateMaintenanceWindow)
+	r.GET("/maintenance/active", h.ListActiveMaintenanceWindow)
+	r.PUT("/maintenance/:id", h.UpdateMaintenanceWindow)
+	r.DELETE("/maintenance/:id", h.DeleteMaintenanceWindow)
+	r.GET("/maintenance/:id", h.GetMaintenanceWindow)
+	r.GET("/maintenance", h.ListMaintenanceWindow)
+}
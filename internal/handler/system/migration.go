@@ -0,0 +1,58 @@
+package system
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	sysmodel "gin-artweb/internal/model/system"
+	syssvc "gin-artweb/internal/service/system"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/errors"
+)
+
+type MigrationHandler struct {
+	log          *zap.Logger
+	svcMigration *syssvc.MigrationService
+}
+
+func NewMigrationHandler(
+	logger *zap.Logger,
+	svcMigration *syssvc.MigrationService,
+) *MigrationHandler {
+	return &MigrationHandler{
+		log:          logger,
+		svcMigration: svcMigration,
+	}
+}
+
+// @Summary 查询数据库迁移状态
+// @Description 本接口用于查询数据库结构版本以及各版本迁移的执行情况
+// @Tags 数据库迁移
+// @Accept json
+// @Produce json
+// @Success 200 {object} sysmodel.SchemaStatusReply "成功返回迁移状态"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/admin/migrations [get]
+// @Security ApiKeyAuth
+func (h *MigrationHandler) GetSchemaStatus(ctx *gin.Context) {
+	out, rErr := h.svcMigration.SchemaStatus(ctx)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询数据库迁移状态失败",
+			zap.Error(rErr),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &sysmodel.SchemaStatusReply{
+		Code: http.StatusOK,
+		Data: *out,
+	})
+}
+
+func (h *MigrationHandler) LoadRouter(r *gin.RouterGroup) {
+	r.GET("/migrations", h.GetSchemaStatus)
+}
@@ -0,0 +1,260 @@
+package system
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	commodel "gin-artweb/internal/model/common"
+	sysmodel "gin-artweb/internal/model/system"
+	syssvc "gin-artweb/internal/service/system"
+	"gin-artweb/internal/shared/common"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/errors"
+)
+
+type ReportHandler struct {
+	log       *zap.Logger
+	svcReport *syssvc.ReportService
+	svcTask   *syssvc.TaskService
+}
+
+func NewReportHandler(
+	logger *zap.Logger,
+	svcReport *syssvc.ReportService,
+	svcTask *syssvc.TaskService,
+) *ReportHandler {
+	return &ReportHandler{
+		log:       logger,
+		svcReport: svcReport,
+		svcTask:   svcTask,
+	}
+}
+
+// @Summary 生成报表
+// @Description 本接口用于生成日报或周报, 统计各集群任务的执行成功率和登录情况
+// @Tags 报表
+// @Accept json
+// @Produce json
+// @Param request query sysmodel.ReportRequest true "查询参数"
+// @Success 200 {object} sysmodel.ReportReply "成功返回报表"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/system/report [get]
+// @Security ApiKeyAuth
+func (h *ReportHandler) GetReport(ctx *gin.Context) {
+	var req sysmodel.ReportRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定生成报表参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	report, rErr := h.svcReport.Generate(ctx, req.Period, req.Date)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"生成报表失败",
+			zap.Error(rErr),
+			zap.Object(commodel.RequestModelKey, &req),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &sysmodel.ReportReply{
+		Code: http.StatusOK,
+		Data: *report,
+	})
+}
+
+// @Summary 导出报表
+// @Description 本接口用于生成日报或周报并导出为csv或xlsx文件
+// @Tags 报表
+// @Produce application/octet-stream
+// @Param request query sysmodel.ExportReportRequest true "查询参数"
+// @Success 200 {file} file "成功下载报表文件"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/system/report/export [get]
+// @Security ApiKeyAuth
+func (h *ReportHandler) ExportReport(ctx *gin.Context) {
+	var req sysmodel.ExportReportRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定导出报表参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	report, rErr := h.svcReport.Generate(ctx, req.Period, req.Date)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"生成报表失败",
+			zap.Error(rErr),
+			zap.Object(commodel.RequestModelKey, &req.ReportRequest),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	data, filename, rErr := h.svcReport.Export(ctx, report, req.Format)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"导出报表失败",
+			zap.Error(rErr),
+			zap.String("format", req.Format),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	common.StreamFile(ctx, bytes.NewReader(data), int64(len(data)), filename, time.Now())
+}
+
+// @Summary 后台生成报表文件
+// @Description 本接口用于提交生成报表文件的后台任务, 通过/api/v1/tasks/{id}查询进度, 任务成功后结果中的name为报表文件名
+// @Tags 报表
+// @Accept json
+// @Produce json
+// @Param request body sysmodel.ExportReportRequest true "生成报表请求"
+// @Success 200 {object} sysmodel.TaskReply "成功返回后台任务"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/system/report/task [post]
+// @Security ApiKeyAuth
+func (h *ReportHandler) CreateReportTask(ctx *gin.Context) {
+	var req sysmodel.ExportReportRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定生成报表文件参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	claims, rErr := ctxutil.GetUserClaims(ctx)
+	if rErr != nil {
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	name := "生成报表 " + req.Period
+	if req.Date != "" {
+		name += " " + req.Date
+	}
+	task := &sysmodel.TaskModel{
+		Kind:     sysmodel.TaskKindReport,
+		Name:     name + "." + req.Format,
+		UserID:   claims.UserID,
+		Username: claims.Username,
+	}
+	m, rErr := h.svcTask.Submit(ctx, task, func(tctx context.Context, p *syssvc.TaskProgress) (any, error) {
+		prefix := fmt.Sprintf("task%d", p.TaskID())
+		path, rErr := h.svcReport.SaveFile(tctx, prefix, req.Period, req.Date, req.Format, p.Report)
+		if rErr != nil {
+			return nil, rErr
+		}
+		return map[string]any{"name": filepath.Base(path)}, nil
+	})
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"提交生成报表文件任务失败",
+			zap.Error(rErr),
+			zap.Object(commodel.RequestModelKey, &req.ReportRequest),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &sysmodel.TaskReply{
+		Code: http.StatusOK,
+		Data: *sysmodel.TaskToOut(*m),
+	})
+}
+
+// @Summary 查询定时报表文件
+// @Description 本接口用于查询定时任务生成的报表文件, 按生成时间倒序
+// @Tags 报表
+// @Produce json
+// @Success 200 {object} sysmodel.ListReportFileReply "成功返回报表文件列表"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/system/report/file [get]
+// @Security ApiKeyAuth
+func (h *ReportHandler) ListReportFile(ctx *gin.Context) {
+	files, rErr := h.svcReport.ListFiles(ctx)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询定时报表文件失败",
+			zap.Error(rErr),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &sysmodel.ListReportFileReply{
+		Code: http.StatusOK,
+		Data: files,
+	})
+}
+
+// @Summary 下载定时报表文件
+// @Description 本接口用于下载定时任务生成的报表文件
+// @Tags 报表
+// @Produce application/octet-stream
+// @Param name path string true "文件名"
+// @Success 200 {file} file "成功下载报表文件"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 404 {object} errors.Error "文件不存在"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/system/report/file/{name} [get]
+// @Security ApiKeyAuth
+func (h *ReportHandler) DownloadReportFile(ctx *gin.Context) {
+	var uri sysmodel.ReportFileUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定下载报表文件参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	filePath, rErr := h.svcReport.FilePath(ctx, uri.Name)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询定时报表文件失败",
+			zap.Error(rErr),
+			zap.String("name", uri.Name),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	if err := common.DownloadFile(ctx, h.log, filePath, uri.Name); err != nil {
+		errors.RespondWithError(ctx, err)
+	}
+}
+
+func (h *ReportHandler) LoadRouter(r *gin.RouterGroup) {
+	r.GET("/report", h.GetReport)
+	r.GET("/report/export", h.ExportReport)
+	r.GET("/report/file", h.ListReportFile)
+	r.GET("/report/file/:name", h.DownloadReportFile)
+	r.POST("/report/task", h.CreateReportTask)
+}
@@ -0,0 +1,72 @@
+package system
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	sysmodel "gin-artweb/internal/model/system"
+	syssvc "gin-artweb/internal/service/system"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/errors"
+)
+
+type SearchHandler struct {
+	log       *zap.Logger
+	svcSearch *syssvc.SearchService
+}
+
+func NewSearchHandler(
+	logger *zap.Logger,
+	svcSearch *syssvc.SearchService,
+) *SearchHandler {
+	return &SearchHandler{
+		log:       logger,
+		svcSearch: svcSearch,
+	}
+}
+
+// @Summary 跨模块搜索
+// @Description 本接口用于按名称和描述同时搜索用户、集群、程序包、脚本和菜单, 结果按资源类型分组, 只返回当前角色有权查看列表的资源
+// @Tags 搜索
+// @Accept json
+// @Produce json
+// @Param request query sysmodel.SearchRequest true "查询参数"
+// @Success 200 {object} sysmodel.SearchReply "成功返回搜索结果"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/search [get]
+// @Security ApiKeyAuth
+func (h *SearchHandler) Search(ctx *gin.Context) {
+	var req sysmodel.SearchRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定搜索参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	groups, rErr := h.svcSearch.Search(ctx, req.Q, req.Limit)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"搜索失败",
+			zap.Error(rErr),
+			zap.String("q", req.Q),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &sysmodel.SearchReply{
+		Code: http.StatusOK,
+		Data: groups,
+	})
+}
+
+func (h *SearchHandler) LoadRouter(r *gin.RouterGroup) {
+	r.GET("", h.Search)
+}
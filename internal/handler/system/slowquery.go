@@ -0,0 +1,84 @@
+package system
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	commodel "gin-artweb/internal/model/common"
+	sysmodel "gin-artweb/internal/model/system"
+	syssvc "gin-artweb/internal/service/system"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+)
+
+type SlowQueryHandler struct {
+	log          *zap.Logger
+	svcSlowQuery *syssvc.SlowQueryService
+}
+
+func NewSlowQueryHandler(
+	logger *zap.Logger,
+	svcSlowQuery *syssvc.SlowQueryService,
+) *SlowQueryHandler {
+	return &SlowQueryHandler{
+		log:          logger,
+		svcSlowQuery: svcSlowQuery,
+	}
+}
+
+// @Summary 查询慢查询统计
+// @Description 本接口用于分页查询按语句指纹汇总的慢查询, 统计数据每分钟写入一次
+// @Tags 慢查询
+// @Accept json
+// @Produce json
+// @Param request query sysmodel.ListSlowQueryRequest false "查询参数"
+// @Success 200 {object} sysmodel.PagSlowQueryReply "成功返回慢查询统计列表"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/admin/db/slow-queries [get]
+// @Security ApiKeyAuth
+func (h *SlowQueryHandler) ListSlowQuery(ctx *gin.Context) {
+	var req sysmodel.ListSlowQueryRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定查询慢查询统计参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	page, size, query := req.Query()
+	qp := database.QueryParams{
+		IsCount:   true,
+		SkipCount: req.SkipCount(),
+		Size:      size,
+		Page:      page,
+		OrderBy:   req.Order(),
+		Query:     query,
+	}
+	total, ms, rErr := h.svcSlowQuery.ListSlowQuery(ctx, qp)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询慢查询统计失败",
+			zap.Error(rErr),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	mbs := sysmodel.ListSlowQueryToOut(ms)
+	ctx.JSON(http.StatusOK, &sysmodel.PagSlowQueryReply{
+		Code: http.StatusOK,
+		Data: commodel.NewPag(qp, total, mbs),
+	})
+}
+
+func (h *SlowQueryHandler) LoadRouter(r *gin.RouterGroup) {
+	r.GET("/db/slow-queries", h.ListSlowQuery)
+}
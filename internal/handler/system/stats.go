@@ -0,0 +1,165 @@
+package system
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	sysmodel "gin-artweb/internal/model/system"
+	syssvc "gin-artweb/internal/service/system"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/errors"
+)
+
+type StatsHandler struct {
+	log      *zap.Logger
+	svcStats *syssvc.StatsService
+}
+
+func NewStatsHandler(
+	logger *zap.Logger,
+	svcStats *syssvc.StatsService,
+) *StatsHandler {
+	return &StatsHandler{
+		log:      logger,
+		svcStats: svcStats,
+	}
+}
+
+// @Summary 任务执行统计
+// @Description 本接口用于查询最近若干天每天的脚本执行成功和失败数, 结果会缓存一段时间
+// @Tags 首页统计
+// @Accept json
+// @Produce json
+// @Param request query sysmodel.StatsJobRequest false "查询参数"
+// @Success 200 {object} sysmodel.StatsJobReply "成功返回任务执行统计"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/stats/jobs [get]
+// @Security ApiKeyAuth
+func (h *StatsHandler) GetJobStats(ctx *gin.Context) {
+	var req sysmodel.StatsJobRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定任务执行统计参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	outs, rErr := h.svcStats.JobStats(ctx, req.Days)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询任务执行统计失败",
+			zap.Error(rErr),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &sysmodel.StatsJobReply{
+		Code: http.StatusOK,
+		Data: outs,
+	})
+}
+
+// @Summary 集群启用统计
+// @Description 本接口用于查询oes和mds项目启用和停用的集群数, 结果会缓存一段时间
+// @Tags 首页统计
+// @Accept json
+// @Produce json
+// @Success 200 {object} sysmodel.StatsColonyReply "成功返回集群启用统计"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/stats/colonies [get]
+// @Security ApiKeyAuth
+func (h *StatsHandler) GetColonyStats(ctx *gin.Context) {
+	outs, rErr := h.svcStats.ColonyStats(ctx)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询集群启用统计失败",
+			zap.Error(rErr),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &sysmodel.StatsColonyReply{
+		Code: http.StatusOK,
+		Data: outs,
+	})
+}
+
+// @Summary 用户统计
+// @Description 本接口用于查询用户总数、已激活用户数和最近活跃的用户数, 活跃天数由配置指定, 结果会缓存一段时间
+// @Tags 首页统计
+// @Accept json
+// @Produce json
+// @Success 200 {object} sysmodel.StatsUserReply "成功返回用户统计"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/stats/users [get]
+// @Security ApiKeyAuth
+func (h *StatsHandler) GetUserStats(ctx *gin.Context) {
+	out, rErr := h.svcStats.UserStats(ctx)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询用户统计失败",
+			zap.Error(rErr),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &sysmodel.StatsUserReply{
+		Code: http.StatusOK,
+		Data: *out,
+	})
+}
+
+// @Summary 最近告警
+// @Description 本接口用于查询最近发出的告警, 包括监控节点状态变化和集群配置漂移, 结果会缓存一段时间
+// @Tags 首页统计
+// @Accept json
+// @Produce json
+// @Param request query sysmodel.StatsAlertRequest false "查询参数"
+// @Success 200 {object} sysmodel.StatsAlertReply "成功返回最近告警"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/stats/alerts [get]
+// @Security ApiKeyAuth
+func (h *StatsHandler) GetRecentAlerts(ctx *gin.Context) {
+	var req sysmodel.StatsAlertRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定最近告警参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	outs, rErr := h.svcStats.RecentAlerts(ctx, req.Limit)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询最近告警失败",
+			zap.Error(rErr),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &sysmodel.StatsAlertReply{
+		Code: http.StatusOK,
+		Data: outs,
+	})
+}
+
+func (h *StatsHandler) LoadRouter(r *gin.RouterGroup) {
+	r.GET("/jobs", h.GetJobStats)
+	r.GET("/colonies", h.GetColonyStats)
+	r.GET("/users", h.GetUserStats)
+	r.GET("/alerts", h.GetRecentAlerts)
+}
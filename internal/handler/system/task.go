@@ -0,0 +1,177 @@
+package system
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	commodel "gin-artweb/internal/model/common"
+	sysmodel "gin-artweb/internal/model/system"
+	syssvc "gin-artweb/internal/service/system"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+)
+
+type TaskHandler struct {
+	log     *zap.Logger
+	svcTask *syssvc.TaskService
+}
+
+func NewTaskHandler(
+	logger *zap.Logger,
+	svcTask *syssvc.TaskService,
+) *TaskHandler {
+	return &TaskHandler{
+		log:     logger,
+		svcTask: svcTask,
+	}
+}
+
+// @Summary 查询后台任务详情
+// @Description 本接口用于查询后台任务的状态、进度和结果, 任务结束前可轮询本接口
+// @Tags 后台任务
+// @Accept json
+// @Produce json
+// @Param id path uint true "任务编号"
+// @Success 200 {object} sysmodel.TaskReply "成功返回任务信息"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 404 {object} errors.Error "任务未找到"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/tasks/{id} [get]
+// @Security ApiKeyAuth
+func (h *TaskHandler) GetTask(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定查询后台任务ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := h.svcTask.FindTaskByID(ctx, uri.ID)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询后台任务失败",
+			zap.Error(rErr),
+			zap.Uint32(commodel.RequestIDKey, uri.ID),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &sysmodel.TaskReply{
+		Code: http.StatusOK,
+		Data: *sysmodel.TaskToOut(*m),
+	})
+}
+
+// @Summary 查询后台任务列表
+// @Description 本接口用于分页查询后台任务, 支持通过filter参数按字段组合过滤
+// @Tags 后台任务
+// @Accept json
+// @Produce json
+// @Param request query sysmodel.ListTaskRequest false "查询参数"
+// @Success 200 {object} sysmodel.PagTaskReply "成功返回任务列表"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/tasks [get]
+// @Security ApiKeyAuth
+func (h *TaskHandler) ListTask(ctx *gin.Context) {
+	var req sysmodel.ListTaskRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定查询后台任务列表参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	filter, pErr := req.FilterParams(sysmodel.TaskFilterFields)
+	if pErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"解析后台任务列表过滤条件失败",
+			zap.Error(pErr),
+			zap.String("filter", req.Filter),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(pErr)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	page, size, query := req.Query()
+	qp := database.QueryParams{
+		IsCount:   true,
+		SkipCount: req.SkipCount(),
+		Size:      size,
+		Page:      page,
+		OrderBy:   []string{"id DESC"},
+		Query:     query,
+		Filter:    filter,
+	}
+	total, ms, rErr := h.svcTask.ListTask(ctx, qp)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询后台任务列表失败",
+			zap.Error(rErr),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	mbs := sysmodel.ListTaskToOut(ms)
+	ctx.JSON(http.StatusOK, &sysmodel.PagTaskReply{
+		Code: http.StatusOK,
+		Data: commodel.NewPag(qp, total, mbs),
+	})
+}
+
+// @Summary 取消后台任务
+// @Description 本接口用于取消未结束的后台任务, 执行中的任务在下一个检查点退出后状态变为canceled
+// @Tags 后台任务
+// @Accept json
+// @Produce json
+// @Param id path uint true "任务编号"
+// @Success 200 {object} commodel.MapAPIReply "已通知任务取消"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 404 {object} errors.Error "任务未找到"
+// @Failure 409 {object} errors.Error "任务已结束"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/tasks/{id}/cancel [post]
+// @Security ApiKeyAuth
+func (h *TaskHandler) CancelTask(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定取消后台任务ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	if rErr := h.svcTask.CancelTask(ctx, uri.ID); rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"取消后台任务失败",
+			zap.Error(rErr),
+			zap.Uint32(commodel.RequestIDKey, uri.ID),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+	ctx.JSON(commodel.NoDataReply.Code, commodel.NoDataReply)
+}
+
+func (h *TaskHandler) LoadRouter(r *gin.RouterGroup) {
+	r.GET("", h.ListTask)
+	r.GET("/:id", h.GetTask)
+	r.POST("/:id/cancel", h.CancelTask)
+}
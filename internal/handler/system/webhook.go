@@ -0,0 +1,394 @@
+package system
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	commodel "gin-artweb/internal/model/common"
+	sysmodel "gin-artweb/internal/model/system"
+	syssvc "gin-artweb/internal/service/system"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+)
+
+type WebhookHandler struct {
+	log        *zap.Logger
+	svcWebhook *syssvc.WebhookService
+}
+
+func NewWebhookHandler(
+	logger *zap.Logger,
+	svcWebhook *syssvc.WebhookService,
+) *WebhookHandler {
+	return &WebhookHandler{
+		log:        logger,
+		svcWebhook: svcWebhook,
+	}
+}
+
+// bindWebhookSubscription 绑定webhook订阅请求参数并转换为模型
+func (h *WebhookHandler) bindWebhookSubscription(ctx *gin.Context) (*sysmodel.WebhookSubscriptionModel, *errors.Error) {
+	var req sysmodel.WebhookSubscriptionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		return nil, errors.ErrValidationFailed.WithCause(err)
+	}
+
+	claims, rErr := ctxutil.GetUserClaims(ctx)
+	if rErr != nil {
+		return nil, rErr
+	}
+	return &sysmodel.WebhookSubscriptionModel{
+		Name:        req.Name,
+		URL:         req.URL,
+		Secret:      req.Secret,
+		Events:      strings.Join(req.Events, ","),
+		IsEnabled:   req.IsEnabled,
+		Description: req.Description,
+		Username:    claims.Username,
+	}, nil
+}
+
+// @Summary 创建webhook订阅
+// @Description 本接口用于创建webhook订阅, 订阅的事件发生时向推送地址发送带签名的POST请求
+// @Tags webhook
+// @Accept json
+// @Produce json
+// @Param request body sysmodel.WebhookSubscriptionRequest true "创建webhook订阅请求"
+// @Success 200 {object} sysmodel.WebhookSubscriptionReply "成功返回webhook订阅信息"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/system/webhook [post]
+// @Security ApiKeyAuth
+func (h *WebhookHandler) CreateWebhookSubscription(ctx *gin.Context) {
+	sub, rErr := h.bindWebhookSubscription(ctx)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定创建webhook订阅参数失败",
+			zap.Error(rErr),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := h.svcWebhook.CreateWebhookSubscription(ctx, *sub)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"创建webhook订阅失败",
+			zap.Error(rErr),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &sysmodel.WebhookSubscriptionReply{
+		Code: http.StatusOK,
+		Data: *sysmodel.WebhookSubscriptionToOut(*m),
+	})
+}
+
+// @Summary 更新webhook订阅
+// @Description 本接口用于更新指定ID的webhook订阅, 签名密钥为空时不修改
+// @Tags webhook
+// @Accept json
+// @Produce json
+// @Param id path uint true "webhook订阅编号"
+// @Param request body sysmodel.WebhookSubscriptionRequest true "更新webhook订阅请求"
+// @Success 200 {object} sysmodel.WebhookSubscriptionReply "成功返回webhook订阅信息"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 404 {object} errors.Error "webhook订阅未找到"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/system/webhook/{id} [put]
+// @Security ApiKeyAuth
+func (h *WebhookHandler) UpdateWebhookSubscription(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定更新webhook订阅ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	sub, rErr := h.bindWebhookSubscription(ctx)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定更新webhook订阅参数失败",
+			zap.Error(rErr),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := h.svcWebhook.UpdateWebhookSubscriptionByID(ctx, uri.ID, *sub)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"更新webhook订阅失败",
+			zap.Error(rErr),
+			zap.Uint32(commodel.RequestIDKey, uri.ID),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &sysmodel.WebhookSubscriptionReply{
+		Code: http.StatusOK,
+		Data: *sysmodel.WebhookSubscriptionToOut(*m),
+	})
+}
+
+// @Summary 删除webhook订阅
+// @Description 本接口用于删除指定ID的webhook订阅及其推送记录
+// @Tags webhook
+// @Accept json
+// @Produce json
+// @Param id path uint true "webhook订阅编号"
+// @Success 200 {object} commodel.MapAPIReply "删除成功"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 404 {object} errors.Error "webhook订阅未找到"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/system/webhook/{id} [delete]
+// @Security ApiKeyAuth
+func (h *WebhookHandler) DeleteWebhookSubscription(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定删除webhook订阅ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	if rErr := h.svcWebhook.DeleteWebhookSubscriptionByID(ctx, uri.ID); rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"删除webhook订阅失败",
+			zap.Error(rErr),
+			zap.Uint32(commodel.RequestIDKey, uri.ID),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(commodel.NoDataReply.Code, commodel.NoDataReply)
+}
+
+// @Summary 查询webhook订阅详情
+// @Description 本接口用于查询指定ID的webhook订阅
+// @Tags webhook
+// @Accept json
+// @Produce json
+// @Param id path uint true "webhook订阅编号"
+// @Success 200 {object} sysmodel.WebhookSubscriptionReply "成功返回webhook订阅信息"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 404 {object} errors.Error "webhook订阅未找到"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/system/webhook/{id} [get]
+// @Security ApiKeyAuth
+func (h *WebhookHandler) GetWebhookSubscription(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定查询webhook订阅ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := h.svcWebhook.FindWebhookSubscriptionByID(ctx, uri.ID)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询webhook订阅失败",
+			zap.Error(rErr),
+			zap.Uint32(commodel.RequestIDKey, uri.ID),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &sysmodel.WebhookSubscriptionReply{
+		Code: http.StatusOK,
+		Data: *sysmodel.WebhookSubscriptionToOut(*m),
+	})
+}
+
+// @Summary 查询webhook订阅列表
+// @Description 本接口用于分页查询webhook订阅
+// @Tags webhook
+// @Accept json
+// @Produce json
+// @Param request query sysmodel.ListWebhookSubscriptionRequest false "查询参数"
+// @Success 200 {object} sysmodel.PagWebhookSubscriptionReply "成功返回webhook订阅列表"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/system/webhook [get]
+// @Security ApiKeyAuth
+func (h *WebhookHandler) ListWebhookSubscription(ctx *gin.Context) {
+	var req sysmodel.ListWebhookSubscriptionRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定查询webhook订阅列表参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	page, size, query := req.Query()
+	qp := database.QueryParams{
+		IsCount:   true,
+		SkipCount: req.SkipCount(),
+		Size:      size,
+		Page:      page,
+		OrderBy:   []string{"id DESC"},
+		Query:     query,
+	}
+	total, ms, rErr := h.svcWebhook.ListWebhookSubscription(ctx, qp)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询webhook订阅列表失败",
+			zap.Error(rErr),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	mbs := sysmodel.ListWebhookSubscriptionToOut(ms)
+	ctx.JSON(http.StatusOK, &sysmodel.PagWebhookSubscriptionReply{
+		Code: http.StatusOK,
+		Data: commodel.NewPag(qp, total, mbs),
+	})
+}
+
+// @Summary 测试webhook订阅
+// @Description 本接口用于向指定ID的webhook推送一条测试事件并返回推送结果, 测试推送失败时不重试
+// @Tags webhook
+// @Accept json
+// @Produce json
+// @Param id path uint true "webhook订阅编号"
+// @Success 200 {object} sysmodel.WebhookDeliveryReply "成功返回推送记录"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 404 {object} errors.Error "webhook订阅未找到"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/system/webhook/{id}/test [post]
+// @Security ApiKeyAuth
+func (h *WebhookHandler) TestWebhookSubscription(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定测试webhook订阅ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	claims, rErr := ctxutil.GetUserClaims(ctx)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"获取个人登录信息失败",
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	m, rErr := h.svcWebhook.TestWebhookSubscription(ctx, uri.ID, claims.Username)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"测试webhook订阅失败",
+			zap.Error(rErr),
+			zap.Uint32(commodel.RequestIDKey, uri.ID),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &sysmodel.WebhookDeliveryReply{
+		Code: http.StatusOK,
+		Data: *sysmodel.WebhookDeliveryToOut(*m),
+	})
+}
+
+// @Summary 查询webhook推送记录
+// @Description 本接口用于分页查询指定ID的webhook订阅的推送记录
+// @Tags webhook
+// @Accept json
+// @Produce json
+// @Param id path uint true "webhook订阅编号"
+// @Param request query sysmodel.ListWebhookDeliveryRequest false "查询参数"
+// @Success 200 {object} sysmodel.PagWebhookDeliveryReply "成功返回推送记录列表"
+// @Failure 400 {object} errors.Error "请求参数错误"
+// @Failure 500 {object} errors.Error "服务器内部错误"
+// @Router /api/v1/system/webhook/{id}/delivery [get]
+// @Security ApiKeyAuth
+func (h *WebhookHandler) ListWebhookDelivery(ctx *gin.Context) {
+	var uri commodel.IDUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定查询webhook推送记录ID参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	var req sysmodel.ListWebhookDeliveryRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"绑定查询webhook推送记录参数失败",
+			zap.Error(err),
+		)
+		rErr := errors.ErrValidationFailed.WithCause(err)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	page, size, query := req.Query()
+	query["subscription_id = ?"] = uri.ID
+	qp := database.QueryParams{
+		IsCount:   true,
+		SkipCount: req.SkipCount(),
+		Size:      size,
+		Page:      page,
+		OrderBy:   []string{"id DESC"},
+		Query:     query,
+	}
+	total, ms, rErr := h.svcWebhook.ListWebhookDelivery(ctx, qp)
+	if rErr != nil {
+		ctxutil.Logger(ctx, h.log).Error(
+			"查询webhook推送记录失败",
+			zap.Error(rErr),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		errors.RespondWithError(ctx, rErr)
+		return
+	}
+
+	mbs := sysmodel.ListWebhookDeliveryToOut(ms)
+	ctx.JSON(http.StatusOK, &sysmodel.PagWebhookDeliveryReply{
+		Code: http.StatusOK,
+		Data: commodel.NewPag(qp, total, mbs),
+	})
+}
+
+func (h *WebhookHandler) LoadRouter(r *gin.RouterGroup) {
+	r.POST("/webhook", h.CreateWebhookSubscription)
+	r.PUT("/webhook/:id", h.UpdateWebhookSubscription)
+	r.DELETE("/webhook/:id", h.DeleteWebhookSubscription)
+	r.GET("/webhook/:id", h.GetWebhookSubscription)
+	r.GET("/webhook", h.ListWebhookSubscription)
+	r.POST("/webhook/:id/test", h.TestWebhookSubscription)
+	r.GET("/webhook/:id/delivery", h.ListWebhookDelivery)
+}
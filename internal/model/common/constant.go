@@ -3,6 +3,5 @@ package common
 const (
 	RequestIDKey    = "request_id"
 	RequestBodyKey  = "request_body"
-	RequestURIKey   = "request_uri"
 	RequestModelKey = "request_model"
 )
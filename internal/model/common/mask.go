@@ -0,0 +1,137 @@
+package common
+
+import (
+	"encoding/json"
+	"net"
+	"reflect"
+	"strings"
+)
+
+// MaskedValue 敏感字段在接口响应中的掩码
+const MaskedValue = "******"
+
+// RevealPathSuffix 查看敏感字段明文的接口路径后缀
+//
+// 此类接口需要单独授权, 默认角色只有管理员拥有权限
+const RevealPathSuffix = "/reveal"
+
+// MaskTag 标记敏感字段的结构体标签, 值为掩码方式, 如`mask:"ip"`
+const MaskTag = "mask"
+
+// 敏感字段的掩码方式
+const (
+	MaskIP     = "ip"     // IP地址, IPv4保留前两段, IPv6保留第一段
+	MaskSecret = "secret" // 整体替换为MaskedValue
+)
+
+// MaskString 按掩码方式掩码字段值, 空值保持为空
+func MaskString(kind, value string) string {
+	if value == "" {
+		return ""
+	}
+	switch kind {
+	case MaskIP:
+		ip := net.ParseIP(value)
+		if ip == nil {
+			return MaskedValue
+		}
+		if ip.To4() != nil {
+			parts := strings.Split(ip.To4().String(), ".")
+			return parts[0] + "." + parts[1] + ".*.*"
+		}
+		first, _, _ := strings.Cut(ip.String(), ":")
+		return first + ":*"
+	}
+	return MaskedValue
+}
+
+// MaskSensitive 掩码结构体中带有mask标签的字符串字段, reveal为true时保持原值
+//
+// v需要是指针, 递归处理嵌套的结构体、指针和切片, 用于Out转换函数在返回前掩码敏感字段
+func MaskSensitive(v any, reveal bool) {
+	if reveal || v == nil {
+		return
+	}
+	maskValue(reflect.ValueOf(v))
+}
+
+func maskValue(rv reflect.Value) {
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !rv.IsNil() {
+			maskValue(rv.Elem())
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			maskValue(rv.Index(i))
+		}
+	case reflect.Struct:
+		rt := rv.Type()
+		for i := 0; i < rv.NumField(); i++ {
+			field := rv.Field(i)
+			if !field.CanSet() {
+				continue
+			}
+			if kind, ok := rt.Field(i).Tag.Lookup(MaskTag); ok && field.Kind() == reflect.String {
+				field.SetString(MaskString(kind, field.String()))
+				continue
+			}
+			maskValue(field)
+		}
+	}
+}
+
+// MaskEnvVars 掩码环境变量的值, 保留变量名; 不是JSON对象时整体掩码
+func MaskEnvVars(envVars string) string {
+	if envVars == "" {
+		return ""
+	}
+	var vars map[string]any
+	if err := json.Unmarshal([]byte(envVars), &vars); err != nil || vars == nil {
+		return MaskedValue
+	}
+	for k := range vars {
+		vars[k] = MaskedValue
+	}
+	masked, err := json.Marshal(vars)
+	if err != nil {
+		return MaskedValue
+	}
+	return string(masked)
+}
+
+// UnmaskEnvVars 将仍为掩码的环境变量值还原为原值
+//
+// 编辑时前端会回传查询到的掩码, 未修改的变量保持原值, 原值中不存在的变量被忽略
+func UnmaskEnvVars(envVars, original string) string {
+	if envVars == MaskedValue {
+		return original
+	}
+	var vars map[string]any
+	if err := json.Unmarshal([]byte(envVars), &vars); err != nil || vars == nil {
+		return envVars
+	}
+	var originalVars map[string]any
+	_ = json.Unmarshal([]byte(original), &originalVars)
+
+	changed := false
+	for k, v := range vars {
+		if v != MaskedValue {
+			continue
+		}
+		changed = true
+		if ov, ok := originalVars[k]; ok {
+			vars[k] = ov
+		} else {
+			delete(vars, k)
+		}
+	}
+	if !changed {
+		return envVars
+	}
+	unmasked, err := json.Marshal(vars)
+	if err != nil {
+		return envVars
+	}
+	return string(unmasked)
+}
@@ -0,0 +1,92 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaskString(t *testing.T) {
+	tests := []struct {
+		name  string
+		kind  string
+		value string
+		want  string
+	}{
+		{"IPv4", MaskIP, "192.168.1.10", "192.168.*.*"},
+		{"IPv4映射的IPv6", MaskIP, "::ffff:10.0.0.1", "10.0.*.*"},
+		{"IPv6", MaskIP, "fe80::1", "fe80:*"},
+		{"无效的IP", MaskIP, "not-an-ip", MaskedValue},
+		{"密钥", MaskSecret, "root", MaskedValue},
+		{"未知的掩码方式", "unknown", "value", MaskedValue},
+		{"空值", MaskIP, "", ""},
+		{"空的密钥", MaskSecret, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, MaskString(tt.kind, tt.value))
+		})
+	}
+}
+
+type maskInner struct {
+	IP string `mask:"ip"`
+}
+
+type maskOuter struct {
+	Name     string
+	User     string `mask:"secret"`
+	Port     int    `mask:"secret"`
+	Inner    maskInner
+	Ptr      *maskInner
+	Nil      *maskInner
+	Items    []maskInner
+	Array    [1]maskInner
+	Any      any
+	internal string `mask:"secret"`
+}
+
+func newMaskOuter() *maskOuter {
+	return &maskOuter{
+		Name:     "host",
+		User:     "root",
+		Port:     22,
+		Inner:    maskInner{IP: "10.0.0.1"},
+		Ptr:      &maskInner{IP: "10.0.0.2"},
+		Items:    []maskInner{{IP: "10.0.0.3"}, {IP: ""}},
+		Array:    [1]maskInner{{IP: "10.0.0.4"}},
+		Any:      &maskInner{IP: "10.0.0.5"},
+		internal: "secret",
+	}
+}
+
+func TestMaskSensitive(t *testing.T) {
+	v := newMaskOuter()
+	MaskSensitive(v, false)
+	assert.Equal(t, "host", v.Name, "没有标签的字段保持原值")
+	assert.Equal(t, MaskedValue, v.User)
+	assert.Equal(t, 22, v.Port, "非字符串字段忽略标签")
+	assert.Equal(t, "10.0.*.*", v.Inner.IP, "嵌套结构体")
+	assert.Equal(t, "10.0.*.*", v.Ptr.IP, "结构体指针")
+	assert.Nil(t, v.Nil)
+	assert.Equal(t, "10.0.*.*", v.Items[0].IP, "切片")
+	assert.Empty(t, v.Items[1].IP, "空值保持为空")
+	assert.Equal(t, "10.0.*.*", v.Array[0].IP, "数组")
+	assert.Equal(t, "10.0.*.*", v.Any.(*maskInner).IP, "接口")
+	assert.Equal(t, "secret", v.internal, "未导出字段无法修改")
+
+	items := []maskOuter{*newMaskOuter()}
+	MaskSensitive(&items, false)
+	assert.Equal(t, MaskedValue, items[0].User, "结构体切片")
+
+	revealed := newMaskOuter()
+	MaskSensitive(revealed, true)
+	assert.Equal(t, newMaskOuter(), revealed, "可以查看明文时保持原值")
+
+	value := *newMaskOuter()
+	MaskSensitive(value, false)
+	assert.Equal(t, "root", value.User, "非指针无法修改")
+
+	assert.NotPanics(t, func() { MaskSensitive(nil, false) })
+	assert.NotPanics(t, func() { MaskSensitive((*maskOuter)(nil), false) })
+}
@@ -4,6 +4,8 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"gin-artweb/internal/shared/database"
 )
 
 type IDUri struct {
@@ -32,6 +34,20 @@ type BaseModelQuery struct {
 
 	// "唯一标识列表(多个用,隔开)"
 	IDs string `form:"ids" binding:"omitempty,max=100"`
+
+	// 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+	// 运算符: eq ne gt gte lt lte contains in between is_null not_null
+	// example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+	Filter string `form:"filter" binding:"omitempty,max=1000"`
+
+	// 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表
+	WithTotal *bool `form:"with_total" binding:"omitempty"`
+
+	// 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据
+	Export string `form:"export" binding:"omitempty,oneof=csv xlsx"`
+
+	// 导出的列(多个用,隔开), 默认导出全部列
+	Columns string `form:"columns" binding:"omitempty,max=1000"`
 }
 
 func (q *BaseModelQuery) QueryMap(l int) (int, int, map[string]any) {
@@ -58,6 +74,16 @@ func (q *BaseModelQuery) QueryMap(l int) (int, int, map[string]any) {
 	return page, size, query
 }
 
+// SkipCount 客户端请求with_total=false时分页查询跳过总数
+func (q *BaseModelQuery) SkipCount() bool {
+	return q.WithTotal != nil && !*q.WithTotal
+}
+
+// FilterParams 按允许过滤的字段解析过滤条件, 未提交过滤条件时返回nil
+func (q *BaseModelQuery) FilterParams(fields database.FilterFields) (*database.Filter, error) {
+	return database.ParseFilter(q.Filter, fields)
+}
+
 type StandardModelQuery struct {
 	BaseModelQuery
 
@@ -1,6 +1,10 @@
 package common
 
-import "net/http"
+import (
+	"net/http"
+
+	"gin-artweb/internal/shared/database"
+)
 
 // APIReply 通用响应结构体
 // 用于封装API返回的数据格式
@@ -29,31 +33,43 @@ type Pag[T any] struct {
 	// 每页数量
 	// Example: 10
 	Size int `json:"size" example:"10"`
-	// 总记录数
+	// 总记录数, 请求with_total=false跳过总数时不返回
 	// Example: 100
-	Total int64 `json:"total" example:"100"`
-	// 总页数
+	Total *int64 `json:"total,omitempty" example:"100"`
+	// 总页数, 请求with_total=false跳过总数时不返回
 	// Example: 10
-	Pages int64 `json:"pages" example:"10"`
+	Pages *int64 `json:"pages,omitempty" example:"10"`
+	// 是否有下一页
+	// Example: true
+	HasNext bool `json:"has_next" example:"true"`
 	// 对象数组
 	Items *[]T `json:"items"`
 }
 
-func NewPag[T any](page, size int, total int64, items *[]T) *Pag[T] {
+// NewPag 按分页查询参数构建分页响应
+//
+// total为列表查询返回的记录总数, 跳过总数时为当前页之前的记录数加上本页多查询一条后的记录数,
+// 两种情况下都以total是否超过当前页的最后一条记录判断是否有下一页
+func NewPag[T any](qp database.QueryParams, total int64, items *[]T) *Pag[T] {
+	pag := &Pag[T]{
+		Page:    qp.Page,
+		Size:    qp.Size,
+		HasNext: qp.Size > 0 && total > int64(max(qp.Page, 1)*qp.Size),
+		Items:   items,
+	}
+	if qp.SkipCount {
+		return pag
+	}
 	var pages int64
-	if total == 0 || size <= 0 {
+	if total == 0 || qp.Size <= 0 {
 		pages = 1
 	} else {
-		s := int64(size)
+		s := int64(qp.Size)
 		pages = (total + s - 1) / s
 	}
-	return &Pag[T]{
-		Page:  page + 1,
-		Size:  size,
-		Total: total,
-		Pages: pages,
-		Items: items,
-	}
+	pag.Total = &total
+	pag.Pages = &pages
+	return pag
 }
 
 type MapAPIReply struct {
@@ -0,0 +1,27 @@
+package common
+
+import (
+	"strconv"
+	"strings"
+)
+
+// IfMatchHeader 乐观锁版本号请求头
+const IfMatchHeader = "If-Match"
+
+// RequestVersion 获取更新请求携带的版本号
+//
+// 优先使用If-Match请求头, 支持 3、"3"、W/"3" 三种格式, 未提供请求头时使用请求体中的版本号。
+// 版本号缺失或无效时返回false
+func RequestVersion(ifMatch string, bodyVersion uint32) (uint32, bool) {
+	ifMatch = strings.TrimSpace(ifMatch)
+	if ifMatch == "" {
+		return bodyVersion, bodyVersion > 0
+	}
+	ifMatch = strings.TrimPrefix(ifMatch, "W/")
+	ifMatch = strings.Trim(ifMatch, `"`)
+	v, err := strconv.ParseUint(ifMatch, 10, 32)
+	if err != nil || v == 0 {
+		return 0, false
+	}
+	return uint32(v), true
+}
@@ -15,6 +15,9 @@ type ApiModel struct {
 	Method string `gorm:"column:method;type:varchar(10);not null;uniqueIndex:idx_api_url_method;comment:请求方法" json:"method"`
 	Label  string `gorm:"column:label;type:varchar(50);not null;index:label;comment:标签" json:"label"`
 	Descr  string `gorm:"column:descr;type:varchar(254);comment:描述" json:"descr"`
+
+	// 路由中已不存在的接口, 保留记录等待人工确认后删除
+	IsOrphan bool `gorm:"column:is_orphan;type:boolean;not null;default:false;index;comment:是否失效" json:"is_orphan"`
 }
 
 func (m *ApiModel) TableName() string {
@@ -32,6 +35,7 @@ func (m *ApiModel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 	enc.AddString("method", m.Method)
 	enc.AddString("label", m.Label)
 	enc.AddString("descr", m.Descr)
+	enc.AddBool("is_orphan", m.IsOrphan)
 	return nil
 }
 
@@ -108,6 +112,9 @@ type ListApiRequest struct {
 
 	// 描述信息
 	Descr string `form:"descr" binding:"omitempty,max=254"`
+
+	// 是否失效
+	IsOrphan *bool `form:"is_orphan" binding:"omitempty"`
 }
 
 func (req *ListApiRequest) Query() (int, int, map[string]any) {
@@ -124,9 +131,20 @@ func (req *ListApiRequest) Query() (int, int, map[string]any) {
 	if req.Descr != "" {
 		query["descr like ?"] = "%" + req.Descr + "%"
 	}
+	if req.IsOrphan != nil {
+		query["is_orphan = ?"] = *req.IsOrphan
+	}
 	return page, size, query
 }
 
+// SyncApiRequest 用于按已注册路由同步API的请求结构体
+//
+// swagger:model SyncApiRequest
+type SyncApiRequest struct {
+	// 是否按模块名重新设置已有API的标签
+	TagModule bool `json:"tag_module" form:"tag_module"`
+}
+
 // ApiStandardOut API基础信息
 type ApiStandardOut struct {
 	// 唯一标识
@@ -149,6 +167,9 @@ type ApiStandardOut struct {
 
 	// 描述
 	Descr string `json:"descr" example:"用户管理权限"`
+
+	// 是否失效
+	IsOrphan bool `json:"is_orphan" example:"false"`
 }
 
 // ApiReply 权限响应结构
@@ -166,6 +187,7 @@ func ApiModelToStandardOut(m ApiModel) *ApiStandardOut {
 		Method:    m.Method,
 		Label:     m.Label,
 		Descr:     m.Descr,
+		IsOrphan:  m.IsOrphan,
 	}
 }
 
@@ -182,3 +204,21 @@ func ListApiModelToStandardOut(pms *[]ApiModel) *[]ApiStandardOut {
 	}
 	return &mso
 }
+
+// ApiSyncOut API同步结果
+type ApiSyncOut struct {
+	// 新增的API
+	Created []ApiStandardOut `json:"created"`
+
+	// 路由中已不存在的API
+	Orphans []ApiStandardOut `json:"orphans"`
+
+	// 重新出现在路由中的失效API数量
+	Restored int `json:"restored" example:"0"`
+
+	// 重新设置标签的API数量
+	Retagged int `json:"retagged" example:"0"`
+}
+
+// ApiSyncReply API同步响应结构
+type ApiSyncReply = common.APIReply[*ApiSyncOut]
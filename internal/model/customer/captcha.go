@@ -0,0 +1,20 @@
+package customer
+
+import (
+	"gin-artweb/internal/model/common"
+)
+
+// CaptchaOut 登录验证码
+type CaptchaOut struct {
+	// 挑战ID, 登录时随答案一起提交
+	CaptchaID string `json:"captcha_id" example:"0b6f7c4e-3f5a-4d8e-9a51-6c2c3f1b2d4e"`
+
+	// PNG图片的data URI
+	Image string `json:"image" example:"data:image/png;base64,iVBORw0KGgo="`
+
+	// 有效期(秒)
+	ExpiresIn int `json:"expires_in" example:"120"`
+}
+
+// CaptchaReply 登录验证码响应结构
+type CaptchaReply = common.APIReply[CaptchaOut]
@@ -0,0 +1,144 @@
+package customer
+
+import (
+	"time"
+
+	"go.uber.org/zap/zapcore"
+
+	"gin-artweb/internal/model/common"
+	"gin-artweb/internal/shared/auth"
+	"gin-artweb/internal/shared/database"
+)
+
+type CasbinModelModel struct {
+	database.StandardModel
+	Version  uint32 `gorm:"column:version;not null;uniqueIndex;comment:版本号" json:"version"`
+	Content  string `gorm:"column:content;type:text;not null;comment:模型配置" json:"content"`
+	Remark   string `gorm:"column:remark;type:varchar(254);comment:变更说明" json:"remark"`
+	Operator string `gorm:"column:operator;type:varchar(50);comment:操作人" json:"operator"`
+	IsActive bool   `gorm:"column:is_active;type:boolean;index;comment:是否生效" json:"is_active"`
+}
+
+func (m *CasbinModelModel) TableName() string {
+	return "customer_casbin_model"
+}
+
+func (m *CasbinModelModel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if m == nil {
+		return nil
+	}
+	if err := m.StandardModel.MarshalLogObject(enc); err != nil {
+		return err
+	}
+	enc.AddUint32("version", m.Version)
+	enc.AddString("remark", m.Remark)
+	enc.AddString("operator", m.Operator)
+	enc.AddBool("is_active", m.IsActive)
+	return nil
+}
+
+// ValidateCasbinModelRequest 用于校验Casbin模型的请求结构体
+//
+// swagger:model ValidateCasbinModelRequest
+type ValidateCasbinModelRequest struct {
+	// 模型配置
+	Content string `json:"content" binding:"required,max=65535"`
+
+	// 样例请求，使用当前策略和新模型求值并与期望结果比对
+	Samples []auth.CasbinSample `json:"samples" binding:"omitempty,max=100,dive"`
+}
+
+// UpdateCasbinModelRequest 用于更新Casbin模型的请求结构体
+// 更新前会先执行与校验接口相同的校验，全部样例通过后才会生效
+//
+// swagger:model UpdateCasbinModelRequest
+type UpdateCasbinModelRequest struct {
+	ValidateCasbinModelRequest
+
+	// 变更说明
+	Remark string `json:"remark" binding:"omitempty,max=254"`
+}
+
+// ListCasbinModelRequest 用于查询Casbin模型历史版本的请求结构体
+//
+// swagger:model ListCasbinModelRequest
+type ListCasbinModelRequest struct {
+	common.StandardModelQuery
+}
+
+func (req *ListCasbinModelRequest) Query() (int, int, map[string]any) {
+	return req.StandardModelQuery.QueryMap(0)
+}
+
+type CasbinModelOut struct {
+	// 唯一标识, 内置模型为0
+	ID uint32 `json:"id" example:"1"`
+
+	// 版本号, 内置模型为0
+	Version uint32 `json:"version" example:"1"`
+
+	// 模型配置
+	Content string `json:"content" example:"[request_definition]\nr = sub, obj, act"`
+
+	// 变更说明
+	Remark string `json:"remark" example:"调整匹配器"`
+
+	// 操作人
+	Operator string `json:"operator" example:"admin"`
+
+	// 是否生效
+	IsActive bool `json:"is_active" example:"true"`
+
+	// 创建时间
+	CreatedAt string `json:"created_at" example:"2023-01-01 12:00:00"`
+}
+
+type CasbinModelValidateOut struct {
+	// 是否全部通过
+	Passed bool `json:"passed" example:"true"`
+
+	// 样例请求结果
+	Results []auth.CasbinSampleResult `json:"results"`
+}
+
+// CasbinModelReply Casbin模型响应结构
+type CasbinModelReply = common.APIReply[CasbinModelOut]
+
+// PagCasbinModelReply Casbin模型历史的分页响应结构
+type PagCasbinModelReply = common.APIReply[*common.Pag[CasbinModelOut]]
+
+// CasbinModelValidateReply Casbin模型校验响应结构
+type CasbinModelValidateReply = common.APIReply[CasbinModelValidateOut]
+
+func CasbinModelToOut(
+	m CasbinModelModel,
+) *CasbinModelOut {
+	mo := &CasbinModelOut{
+		ID:       m.ID,
+		Version:  m.Version,
+		Content:  m.Content,
+		Remark:   m.Remark,
+		Operator: m.Operator,
+		IsActive: m.IsActive,
+	}
+	if !m.CreatedAt.IsZero() {
+		mo.CreatedAt = m.CreatedAt.Format(time.DateTime)
+	}
+	return mo
+}
+
+func ListCasbinModelToOut(
+	rms *[]CasbinModelModel,
+) *[]CasbinModelOut {
+	if rms == nil {
+		return &[]CasbinModelOut{}
+	}
+
+	ms := *rms
+	mso := make([]CasbinModelOut, 0, len(ms))
+	for _, m := range ms {
+		mo := CasbinModelToOut(m)
+		mso = append(mso, *mo)
+	}
+	return &mso
+}
@@ -0,0 +1,104 @@
+package customer
+
+import (
+	"go.uber.org/zap/zapcore"
+
+	"gin-artweb/internal/model/common"
+)
+
+// DefaultDeactivateGraceDays 停用用户的默认宽限天数, 宽限期内可以重新启用, 到期后永久停用
+const DefaultDeactivateGraceDays = 30
+
+// DeactivateUserRequest 停用用户
+//
+// swagger:model DeactivateUserRequest
+type DeactivateUserRequest struct {
+	// 宽限天数, 为空时使用默认的30天, 为0时在下一次定时检查时永久停用
+	GraceDays *int `json:"grace_days" form:"grace_days" binding:"omitempty,min=0,max=365"`
+}
+
+func (req *DeactivateUserRequest) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if req.GraceDays != nil {
+		enc.AddInt("grace_days", *req.GraceDays)
+	}
+	return nil
+}
+
+// HandoverRequest 将用户名下的记录转移给其他用户
+//
+// swagger:model HandoverRequest
+type HandoverRequest struct {
+	// 接收记录的用户ID
+	ToUserID uint32 `json:"to_user_id" form:"to_user_id" binding:"required"`
+
+	// 转移的记录类型, 为空时转移全部类型
+	Kinds []string `json:"kinds" form:"kinds" binding:"omitempty,max=20,dive,required,max=50"`
+}
+
+func (req *HandoverRequest) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddUint32("to_user_id", req.ToUserID)
+	enc.AddArray("kinds", zapcore.ArrayMarshalerFunc(func(ae zapcore.ArrayEncoder) error {
+		for _, kind := range req.Kinds {
+			ae.AppendString(kind)
+		}
+		return nil
+	}))
+	return nil
+}
+
+// OwnedObjectOut 用户名下的一条记录
+type OwnedObjectOut struct {
+	// 记录ID
+	ID uint32 `json:"id" example:"1"`
+
+	// 记录名称
+	Name string `json:"name" example:"日终清算"`
+}
+
+// OwnedGroupOut 用户名下的一类记录
+type OwnedGroupOut struct {
+	// 记录类型
+	Kind string `json:"kind" example:"schedule"`
+
+	// 记录类型名称
+	Label string `json:"label" example:"计划任务"`
+
+	// 记录总数
+	Total int64 `json:"total" example:"3"`
+
+	// 记录列表, 最多列出50条
+	Items []OwnedObjectOut `json:"items"`
+}
+
+// OwnedReply 用户名下记录的响应结构
+type OwnedReply = common.APIReply[[]OwnedGroupOut]
+
+// DeactivateUserOut 停用用户的结果
+type DeactivateUserOut struct {
+	// 停用后的用户信息
+	User *UserStandardOut `json:"user"`
+
+	// 终止的会话数量
+	RevokedSessions int64 `json:"revoked_sessions" example:"2"`
+
+	// 用户名下仍有的记录, 需要在永久停用前转移给其他用户
+	Owned []OwnedGroupOut `json:"owned"`
+}
+
+// DeactivateUserReply 停用用户的响应结构
+type DeactivateUserReply = common.APIReply[DeactivateUserOut]
+
+// HandoverGroupOut 一类记录的转移结果
+type HandoverGroupOut struct {
+	// 记录类型
+	Kind string `json:"kind" example:"schedule"`
+
+	// 记录类型名称
+	Label string `json:"label" example:"计划任务"`
+
+	// 转移的记录数量
+	Reassigned int64 `json:"reassigned" example:"3"`
+}
+
+// HandoverReply 转移记录的响应结构
+type HandoverReply = common.APIReply[[]HandoverGroupOut]
@@ -0,0 +1,272 @@
+package customer
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+
+	"gin-artweb/internal/model/common"
+	"gin-artweb/internal/shared/database"
+)
+
+// 角色的数据范围, 限制非工作人员可以查看的用户数据
+const (
+	DataScopeAll        = "all"        // 全部数据
+	DataScopeDepartment = "department" // 本部门及下级部门的数据
+	DataScopeSelf       = "self"       // 仅本人的数据
+)
+
+// DepartmentModel 部门, 通过父部门ID组成组织架构树
+//
+// Path为从根部门到当前部门的ID路径, 例如/1/3/7/, 用于按前缀查询子树
+type DepartmentModel struct {
+	database.StandardModel
+	Name     string           `gorm:"column:name;type:varchar(50);not null;comment:名称" json:"name"`
+	Sort     uint32           `gorm:"column:sort;type:integer;comment:排序" json:"sort"`
+	Descr    string           `gorm:"column:descr;type:varchar(254);comment:描述" json:"descr"`
+	Path     string           `gorm:"column:path;type:varchar(255);not null;default:'';index;comment:部门路径" json:"path"`
+	ParentID *uint32          `gorm:"column:parent_id;index;comment:父部门ID" json:"parent_id"`
+	Parent   *DepartmentModel `gorm:"foreignKey:ParentID;references:ID;constraint:OnDelete:RESTRICT" json:"parent"`
+}
+
+func (m *DepartmentModel) TableName() string {
+	return "customer_department"
+}
+
+func (m *DepartmentModel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if m == nil {
+		return nil
+	}
+	if err := m.StandardModel.MarshalLogObject(enc); err != nil {
+		return err
+	}
+	enc.AddString("name", m.Name)
+	enc.AddUint32("sort", m.Sort)
+	enc.AddString("descr", m.Descr)
+	enc.AddString("path", m.Path)
+	if m.ParentID != nil {
+		enc.AddUint32("parent_id", *m.ParentID)
+	}
+	return nil
+}
+
+// DepartmentPath 计算部门路径, 父部门为空时为根部门
+func DepartmentPath(parent *DepartmentModel, id uint32) string {
+	prefix := "/"
+	if parent != nil {
+		prefix = parent.Path
+	}
+	return prefix + strconv.FormatUint(uint64(id), 10) + "/"
+}
+
+// IsDescendantOf 判断部门是否为指定部门或其下级部门
+func (m *DepartmentModel) IsDescendantOf(ancestor *DepartmentModel) bool {
+	return ancestor != nil && ancestor.Path != "" && strings.HasPrefix(m.Path, ancestor.Path)
+}
+
+// CreateOrUpdateDepartmentRequest 用于创建或更新部门的请求结构体
+//
+// swagger:model CreateOrUpdateDepartmentRequest
+type CreateOrUpdateDepartmentRequest struct {
+	// 名称
+	Name string `json:"name" form:"name" binding:"required,max=50"`
+
+	// 排序字段
+	Sort uint32 `json:"sort" form:"sort" binding:"omitempty"`
+
+	// 描述信息
+	Descr string `json:"descr" form:"descr" binding:"omitempty,max=254"`
+
+	// 父部门ID, 仅创建时生效, 调整上级部门使用移动接口
+	ParentID *uint32 `json:"parent_id" form:"parent_id" binding:"omitempty"`
+}
+
+func (req *CreateOrUpdateDepartmentRequest) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("name", req.Name)
+	enc.AddUint32("sort", req.Sort)
+	enc.AddString("descr", req.Descr)
+	if req.ParentID != nil {
+		enc.AddUint32("parent_id", *req.ParentID)
+	}
+	return nil
+}
+
+// MoveDepartmentRequest 用于移动部门的请求结构体, 部门及其下级部门整体移动
+//
+// swagger:model MoveDepartmentRequest
+type MoveDepartmentRequest struct {
+	// 新的父部门ID, 为空或0时移动为根部门
+	ParentID *uint32 `json:"parent_id" form:"parent_id" binding:"omitempty"`
+}
+
+// AssignDepartmentRequest 用于设置用户所属部门的请求结构体
+//
+// swagger:model AssignDepartmentRequest
+type AssignDepartmentRequest struct {
+	// 部门ID, 为空或0时移出部门
+	DepartmentID *uint32 `json:"department_id" form:"department_id" binding:"omitempty"`
+}
+
+// ListDepartmentRequest 用于获取部门列表的请求结构体
+//
+// swagger:model ListDepartmentRequest
+type ListDepartmentRequest struct {
+	common.StandardModelQuery
+
+	// 名称
+	Name string `form:"name" binding:"omitempty,max=50"`
+
+	// 父部门ID
+	ParentID *uint32 `form:"parent_id" binding:"omitempty"`
+}
+
+func (req *ListDepartmentRequest) Query() (int, int, map[string]any) {
+	page, size, query := req.StandardModelQuery.QueryMap(6)
+	if req.Name != "" {
+		query["name like ?"] = "%" + req.Name + "%"
+	}
+	if req.ParentID != nil {
+		query["parent_id = ?"] = *req.ParentID
+	}
+	return page, size, query
+}
+
+type DepartmentBaseOut struct {
+	// 唯一标识
+	ID uint32 `json:"id" example:"1"`
+
+	// 名称
+	Name string `json:"name" example:"运维部"`
+
+	// 排序字段
+	Sort uint32 `json:"sort" example:"1"`
+
+	// 描述
+	Descr string `json:"descr" example:"负责生产环境运维"`
+
+	// 父部门ID
+	ParentID *uint32 `json:"parent_id" example:"1"`
+}
+
+// DepartmentStandardOut 部门标准输出结构体
+type DepartmentStandardOut struct {
+	DepartmentBaseOut
+
+	// 部门路径
+	Path string `json:"path" example:"/1/3/"`
+
+	// 创建时间
+	CreatedAt string `json:"created_at" example:"2023-01-01 12:00:00"`
+
+	// 更新时间
+	UpdatedAt string `json:"updated_at" example:"2023-01-01 12:00:00"`
+}
+
+// DepartmentDetailOut 部门详情输出结构体
+type DepartmentDetailOut struct {
+	DepartmentStandardOut
+
+	// 父部门
+	Parent *DepartmentBaseOut `json:"parent"`
+}
+
+// DepartmentTreeNode 部门树结点
+type DepartmentTreeNode struct {
+	DepartmentBaseOut
+
+	// 下级部门
+	Children []DepartmentTreeNode `json:"children"`
+}
+
+// DepartmentReply 部门响应结构
+type DepartmentReply = common.APIReply[*DepartmentDetailOut]
+
+// PagDepartmentReply 部门的分页响应结构
+type PagDepartmentReply = common.APIReply[*common.Pag[DepartmentStandardOut]]
+
+// DepartmentTreeReply 部门树响应结构
+type DepartmentTreeReply = common.APIReply[*[]DepartmentTreeNode]
+
+func DepartmentModelToBaseOut(
+	m DepartmentModel,
+) *DepartmentBaseOut {
+	return &DepartmentBaseOut{
+		ID:       m.ID,
+		Name:     m.Name,
+		Sort:     m.Sort,
+		Descr:    m.Descr,
+		ParentID: m.ParentID,
+	}
+}
+
+func DepartmentModelToStandardOut(
+	m DepartmentModel,
+) *DepartmentStandardOut {
+	return &DepartmentStandardOut{
+		DepartmentBaseOut: *DepartmentModelToBaseOut(m),
+		Path:              m.Path,
+		CreatedAt:         m.CreatedAt.Format(time.DateTime),
+		UpdatedAt:         m.UpdatedAt.Format(time.DateTime),
+	}
+}
+
+func DepartmentModelToDetailOut(
+	m DepartmentModel,
+) *DepartmentDetailOut {
+	var parent *DepartmentBaseOut
+	if m.Parent != nil {
+		parent = DepartmentModelToBaseOut(*m.Parent)
+	}
+	return &DepartmentDetailOut{
+		DepartmentStandardOut: *DepartmentModelToStandardOut(m),
+		Parent:                parent,
+	}
+}
+
+func ListDepartmentModelToStandardOut(
+	dms *[]DepartmentModel,
+) *[]DepartmentStandardOut {
+	if dms == nil {
+		return &[]DepartmentStandardOut{}
+	}
+	ms := *dms
+	mso := make([]DepartmentStandardOut, 0, len(ms))
+	for _, m := range ms {
+		mso = append(mso, *DepartmentModelToStandardOut(m))
+	}
+	return &mso
+}
+
+// BuildDepartmentTree 将部门列表组装为树, 父部门不在列表中的部门作为根结点, 调用方负责排序
+func BuildDepartmentTree(ms []DepartmentModel) []DepartmentTreeNode {
+	ids := make(map[uint32]struct{}, len(ms))
+	children := make(map[uint32][]DepartmentModel, len(ms))
+	for _, m := range ms {
+		ids[m.ID] = struct{}{}
+	}
+	roots := []DepartmentModel{}
+	for _, m := range ms {
+		if m.ParentID != nil {
+			if _, ok := ids[*m.ParentID]; ok {
+				children[*m.ParentID] = append(children[*m.ParentID], m)
+				continue
+			}
+		}
+		roots = append(roots, m)
+	}
+
+	var build func(nodes []DepartmentModel) []DepartmentTreeNode
+	build = func(nodes []DepartmentModel) []DepartmentTreeNode {
+		out := make([]DepartmentTreeNode, 0, len(nodes))
+		for _, n := range nodes {
+			out = append(out, DepartmentTreeNode{
+				DepartmentBaseOut: *DepartmentModelToBaseOut(n),
+				Children:          build(children[n.ID]),
+			})
+		}
+		return out
+	}
+	return build(roots)
+}
@@ -0,0 +1,136 @@
+package customer
+
+import (
+	"go.uber.org/zap/zapcore"
+
+	"gin-artweb/internal/model/common"
+)
+
+// 删除检查中引用记录的类型
+const (
+	DependencyUser      = "user"       // 用户
+	DependencyUserGroup = "user_group" // 用户组
+	DependencyRole      = "role"       // 角色
+	DependencyMenu      = "menu"       // 菜单
+	DependencyButton    = "button"     // 按钮
+)
+
+// DeleteDependency 删除记录时受影响的一条引用记录
+type DeleteDependency struct {
+	Kind   string // 引用记录类型
+	ID     uint32 // 引用记录ID
+	Name   string // 引用记录名称
+	Reason string // 受影响的原因
+}
+
+// DeleteDependencies 删除检查结果
+//
+// Blocking中的引用会被数据库级联删除但不在本次删除的范围内, 存在时拒绝删除;
+// Cascades中的记录随本次删除一起删除或解除关联, Policies为随之移除的Casbin组策略
+type DeleteDependencies struct {
+	IDs      []uint32
+	Blocking []DeleteDependency
+	Cascades []DeleteDependency
+	Policies [][]string
+}
+
+// Deletable 没有阻止删除的引用
+func (d *DeleteDependencies) Deletable() bool {
+	return len(d.Blocking) == 0
+}
+
+func (d *DeleteDependencies) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if d == nil {
+		return nil
+	}
+	enc.AddArray("ids", zapcore.ArrayMarshalerFunc(func(ae zapcore.ArrayEncoder) error {
+		for _, id := range d.IDs {
+			ae.AppendUint32(id)
+		}
+		return nil
+	}))
+	enc.AddInt("blocking", len(d.Blocking))
+	enc.AddInt("cascades", len(d.Cascades))
+	enc.AddInt("policies", len(d.Policies))
+	return nil
+}
+
+// BulkDeleteRequest 批量删除请求
+//
+// swagger:model BulkDeleteRequest
+type BulkDeleteRequest struct {
+	// 要删除的记录ID列表
+	IDs []uint32 `json:"ids" form:"ids" binding:"required,min=1,max=100,dive,gt=0" example:"1"`
+}
+
+func (req *BulkDeleteRequest) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddArray("ids", zapcore.ArrayMarshalerFunc(func(ae zapcore.ArrayEncoder) error {
+		for _, id := range req.IDs {
+			ae.AppendUint32(id)
+		}
+		return nil
+	}))
+	return nil
+}
+
+type DeleteDependencyOut struct {
+	// 引用记录类型, user:用户, user_group:用户组, role:角色, menu:菜单, button:按钮
+	Kind string `json:"kind" example:"user"`
+
+	// 引用记录ID
+	ID uint32 `json:"id" example:"1"`
+
+	// 引用记录名称
+	Name string `json:"name" example:"admin"`
+
+	// 受影响的原因
+	Reason string `json:"reason" example:"用户的角色被删除时用户会被一起删除"`
+}
+
+type DeleteDependenciesOut struct {
+	// 是否可以删除
+	Deletable bool `json:"deletable" example:"false"`
+
+	// 要删除的记录ID列表
+	IDs []uint32 `json:"ids" example:"1"`
+
+	// 阻止删除的引用, 需要先解除引用或一起删除
+	Blocking []DeleteDependencyOut `json:"blocking"`
+
+	// 随之删除或解除关联的记录
+	Cascades []DeleteDependencyOut `json:"cascades"`
+
+	// 随之移除的Casbin组策略
+	Policies [][]string `json:"policies"`
+}
+
+// DeleteDependenciesReply 删除检查响应结构
+type DeleteDependenciesReply = common.APIReply[*DeleteDependenciesOut]
+
+func DeleteDependenciesToOut(
+	d DeleteDependencies,
+) *DeleteDependenciesOut {
+	toOut := func(ds []DeleteDependency) []DeleteDependencyOut {
+		out := make([]DeleteDependencyOut, 0, len(ds))
+		for _, d := range ds {
+			out = append(out, DeleteDependencyOut{
+				Kind:   d.Kind,
+				ID:     d.ID,
+				Name:   d.Name,
+				Reason: d.Reason,
+			})
+		}
+		return out
+	}
+	policies := d.Policies
+	if policies == nil {
+		policies = [][]string{}
+	}
+	return &DeleteDependenciesOut{
+		Deletable: d.Deletable(),
+		IDs:       d.IDs,
+		Blocking:  toOut(d.Blocking),
+		Cascades:  toOut(d.Cascades),
+		Policies:  policies,
+	}
+}
@@ -0,0 +1,29 @@
+package customer
+
+import "gin-artweb/internal/shared/events"
+
+// UserCreatedEvent 用户创建事件
+type UserCreatedEvent struct {
+	ID       uint32 `json:"id"`
+	Username string `json:"username"`
+	RoleID   uint32 `json:"role_id"`
+}
+
+func (e UserCreatedEvent) EventType() string {
+	return events.UserCreated
+}
+
+// RefreshTokenReplayEvent 检测到已轮换的刷新令牌被再次使用时发出的告警事件
+type RefreshTokenReplayEvent struct {
+	Kind       string `json:"kind"`
+	SessionID  string `json:"session_id"`
+	UserID     uint32 `json:"user_id"`
+	Username   string `json:"username"`
+	IPAddress  string `json:"ip_address"`
+	UserAgent  string `json:"user_agent"`
+	DetectedAt string `json:"detected_at"`
+}
+
+func (e RefreshTokenReplayEvent) EventType() string {
+	return events.AlertFired
+}
@@ -0,0 +1,170 @@
+package customer
+
+import (
+	"time"
+
+	"go.uber.org/zap/zapcore"
+
+	"gin-artweb/internal/model/common"
+	"gin-artweb/internal/shared/database"
+)
+
+// UserIdentityModel 用户关联的外部身份
+//
+// 同一个外部身份只能关联一个用户, 同一个用户在每个身份提供方只能关联一个身份
+type UserIdentityModel struct {
+	database.StandardModel
+	UserID      uint32     `gorm:"column:user_id;not null;uniqueIndex:idx_identity_user_provider;comment:用户ID" json:"user_id"`
+	User        UserModel  `gorm:"foreignKey:UserID;references:ID;constraint:OnDelete:CASCADE" json:"user"`
+	Provider    string     `gorm:"column:provider;type:varchar(50);not null;uniqueIndex:idx_identity_provider_subject;uniqueIndex:idx_identity_user_provider;comment:身份提供方" json:"provider"`
+	Subject     string     `gorm:"column:subject;type:varchar(255);not null;uniqueIndex:idx_identity_provider_subject;comment:外部身份标识" json:"subject"`
+	Email       string     `gorm:"column:email;type:varchar(254);comment:邮箱" json:"email"`
+	LastLoginAt *time.Time `gorm:"column:last_login_at;comment:最近登录时间" json:"last_login_at"`
+}
+
+func (m *UserIdentityModel) TableName() string {
+	return "customer_user_identity"
+}
+
+func (m *UserIdentityModel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if m == nil {
+		return nil
+	}
+	if err := m.StandardModel.MarshalLogObject(enc); err != nil {
+		return err
+	}
+	enc.AddUint32("user_id", m.UserID)
+	enc.AddString("provider", m.Provider)
+	enc.AddString("subject", m.Subject)
+	enc.AddString("email", m.Email)
+	if m.LastLoginAt != nil {
+		enc.AddTime("last_login_at", *m.LastLoginAt)
+	}
+	return nil
+}
+
+// OidcLoginRequest 发起OIDC登录的请求参数
+//
+// swagger:model OidcLoginRequest
+type OidcLoginRequest struct {
+	// 身份提供方标识
+	Provider string `form:"provider" binding:"required,max=50"`
+}
+
+func (req *OidcLoginRequest) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("provider", req.Provider)
+	return nil
+}
+
+// OidcCallbackRequest OIDC授权回调的请求参数, 由前端从回调地址中原样转交
+//
+// swagger:model OidcCallbackRequest
+type OidcCallbackRequest struct {
+	// 授权码
+	Code string `form:"code" binding:"required_without=Error,max=2048"`
+
+	// 发起登录时生成的state
+	State string `form:"state" binding:"required,max=128"`
+
+	// 身份提供方返回的错误
+	Error string `form:"error" binding:"omitempty,max=254"`
+
+	// 身份提供方返回的错误描述
+	ErrorDescription string `form:"error_description" binding:"omitempty,max=1024"`
+}
+
+func (req *OidcCallbackRequest) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("state", req.State)
+	enc.AddString("error", req.Error)
+	enc.AddString("error_description", req.ErrorDescription)
+	return nil
+}
+
+// ProviderUri 身份提供方路径参数
+type ProviderUri struct {
+	Provider string `uri:"provider" binding:"required,max=50"`
+}
+
+// OidcProviderOut 身份提供方
+type OidcProviderOut struct {
+	// 标识
+	Name string `json:"name" example:"keycloak"`
+
+	// 显示名称
+	DisplayName string `json:"display_name" example:"统一身份认证"`
+}
+
+// OidcProvidersOut 登录方式
+type OidcProvidersOut struct {
+	// 是否允许本地用户名密码登录
+	LocalLogin bool `json:"local_login" example:"true"`
+
+	// 身份提供方
+	Providers []OidcProviderOut `json:"providers"`
+}
+
+// OidcLoginOut 发起OIDC登录的结果, 前端跳转到授权地址
+type OidcLoginOut struct {
+	// 授权地址
+	AuthURL string `json:"auth_url" example:"https://sso.example.com/auth?client_id=artweb"`
+}
+
+// UserIdentityOut 用户关联的外部身份
+type UserIdentityOut struct {
+	// 身份提供方
+	Provider string `json:"provider" example:"keycloak"`
+
+	// 外部身份标识
+	Subject string `json:"subject" example:"8f2b6c1e"`
+
+	// 邮箱
+	Email string `json:"email" example:"alice@example.com"`
+
+	// 关联时间
+	CreatedAt string `json:"created_at" example:"2023-01-01 12:00:00"`
+
+	// 最近登录时间
+	LastLoginAt string `json:"last_login_at" example:"2023-01-01 12:00:00"`
+}
+
+// OidcProvidersReply 登录方式响应结构
+type OidcProvidersReply = common.APIReply[OidcProvidersOut]
+
+// OidcLoginReply 发起OIDC登录响应结构
+type OidcLoginReply = common.APIReply[OidcLoginOut]
+
+// UserIdentitiesReply 用户关联的外部身份响应结构
+type UserIdentitiesReply = common.APIReply[*[]UserIdentityOut]
+
+func UserIdentityToOut(
+	m UserIdentityModel,
+) *UserIdentityOut {
+	mo := &UserIdentityOut{
+		Provider: m.Provider,
+		Subject:  m.Subject,
+		Email:    m.Email,
+	}
+	if !m.CreatedAt.IsZero() {
+		mo.CreatedAt = m.CreatedAt.Format(time.DateTime)
+	}
+	if m.LastLoginAt != nil {
+		mo.LastLoginAt = m.LastLoginAt.Format(time.DateTime)
+	}
+	return mo
+}
+
+func ListUserIdentityToOut(
+	ims *[]UserIdentityModel,
+) *[]UserIdentityOut {
+	if ims == nil {
+		return &[]UserIdentityOut{}
+	}
+
+	ms := *ims
+	mso := make([]UserIdentityOut, 0, len(ms))
+	for _, m := range ms {
+		mo := UserIdentityToOut(m)
+		mso = append(mso, *mo)
+	}
+	return &mso
+}
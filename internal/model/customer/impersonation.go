@@ -0,0 +1,24 @@
+package customer
+
+import "gin-artweb/internal/model/common"
+
+// ImpersonateOut 代为登录的结果
+type ImpersonateOut struct {
+	// 代登录访问令牌, 到期后不能刷新
+	AccessToken string `json:"access_token"`
+
+	// 代登录会话ID, 可通过终止会话提前结束代登录
+	SessionID string `json:"session_id" example:"0b6f7c4e-3f5a-4d8e-9a51-6c2c3f1b2d4e"`
+
+	// 被代登录的用户名
+	Username string `json:"username" example:"alice"`
+
+	// 代为登录的管理员用户名
+	Impersonator string `json:"impersonator" example:"admin"`
+
+	// 令牌过期时间
+	ExpiresAt string `json:"expires_at" example:"2023-01-01 12:15:00"`
+}
+
+// ImpersonateReply 代为登录响应结构
+type ImpersonateReply = common.APIReply[ImpersonateOut]
@@ -99,8 +99,8 @@ type LoginRecordStandardOut struct {
 	// 登录时间
 	LoginAt string `json:"login_at" example:"2023-01-01 12:00:00"`
 
-	// IP地址
-	IPAddress string `json:"ip_address" example:"192.168.1.1"`
+	// IP地址, 没有查看敏感字段权限时掩码
+	IPAddress string `json:"ip_address" mask:"ip" example:"192.168.1.1"`
 
 	// 用户浏览器信息
 	UserAgent string `json:"user_agent" example:"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/96.0.4664.110 Safari/537.36"`
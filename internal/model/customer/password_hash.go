@@ -0,0 +1,36 @@
+package customer
+
+import "gin-artweb/internal/model/common"
+
+// PasswordHashCountOut 使用同一算法的密码哈希数量
+type PasswordHashCountOut struct {
+	// 哈希算法, 无法识别时为unknown
+	Algorithm string `json:"algorithm" example:"bcrypt"`
+
+	// 用户数量
+	Total int64 `json:"total" example:"10"`
+
+	// 未使用当前算法和参数的用户数量
+	Legacy int64 `json:"legacy" example:"2"`
+}
+
+// PasswordHashReportOut 密码哈希的算法和参数统计
+type PasswordHashReportOut struct {
+	// 当前配置的哈希算法
+	Algorithm string `json:"algorithm" example:"argon2id"`
+
+	// 用户总数
+	Total int64 `json:"total" example:"12"`
+
+	// 已使用当前算法和参数的用户数量
+	Current int64 `json:"current" example:"10"`
+
+	// 仍使用旧算法或旧参数的用户数量, 这些用户下次登录成功时重新哈希
+	Legacy int64 `json:"legacy" example:"2"`
+
+	// 按算法分组的统计
+	Algorithms []PasswordHashCountOut `json:"algorithms"`
+}
+
+// PasswordHashReportReply 密码哈希统计的响应结构
+type PasswordHashReportReply = common.APIReply[PasswordHashReportOut]
@@ -0,0 +1,167 @@
+package customer
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+
+	"gin-artweb/internal/model/common"
+	"gin-artweb/internal/shared/database"
+)
+
+// 偏好命名空间, 每个命名空间的偏好内容有固定的格式
+const (
+	PreferenceTheme        = "theme"         // 界面主题
+	PreferenceColonyFilter = "colony_filter" // 默认集群筛选
+	PreferenceTablePrefix  = "table."        // 表格列布局, 后接表格标识, 如table.mds_colony
+)
+
+// preferenceTablePattern 表格标识的格式
+var preferenceTablePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]{0,47}$`)
+
+// UserPreferenceModel 用户界面偏好
+//
+// 每个用户的每个命名空间保存一份JSON格式的偏好内容, 保存前按命名空间的格式校验
+type UserPreferenceModel struct {
+	database.StandardModel
+	UserID    uint32    `gorm:"column:user_id;not null;uniqueIndex:idx_user_preference;comment:用户ID" json:"user_id"`
+	User      UserModel `gorm:"foreignKey:UserID;references:ID;constraint:OnDelete:CASCADE" json:"user"`
+	Namespace string    `gorm:"column:namespace;type:varchar(64);not null;uniqueIndex:idx_user_preference;comment:命名空间" json:"namespace"`
+	Value     string    `gorm:"column:value;type:text;not null;comment:偏好内容(JSON)" json:"value"`
+}
+
+func (m *UserPreferenceModel) TableName() string {
+	return "customer_user_preference"
+}
+
+func (m *UserPreferenceModel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if m == nil {
+		return nil
+	}
+	if err := m.StandardModel.MarshalLogObject(enc); err != nil {
+		return err
+	}
+	enc.AddUint32("user_id", m.UserID)
+	enc.AddString("namespace", m.Namespace)
+	enc.AddInt("size", len(m.Value))
+	return nil
+}
+
+// ThemePreference 界面主题偏好
+type ThemePreference struct {
+	// 主题模式
+	Mode string `json:"mode" binding:"omitempty,oneof=light dark system" example:"dark"`
+
+	// 主题色
+	PrimaryColor string `json:"primary_color" binding:"omitempty,hexcolor" example:"#1677ff"`
+
+	// 紧凑布局
+	Compact bool `json:"compact" example:"false"`
+
+	// 界面语言
+	Language string `json:"language" binding:"omitempty,oneof=zh-CN en-US" example:"zh-CN"`
+}
+
+// ColonyFilterPreference 默认集群筛选偏好
+type ColonyFilterPreference struct {
+	// 模块
+	Module string `json:"module" binding:"omitempty,oneof=mds oes" example:"mds"`
+
+	// 集群编号
+	ColonyNums []string `json:"colony_nums" binding:"max=100,dive,required,max=64" example:"mds01"`
+}
+
+// TableColumnPreference 表格中一列的显示设置
+type TableColumnPreference struct {
+	// 列标识
+	Key string `json:"key" binding:"required,max=64" example:"name"`
+
+	// 列宽(像素), 0表示自动
+	Width int `json:"width" binding:"min=0,max=2000" example:"120"`
+
+	// 是否隐藏
+	Hidden bool `json:"hidden" example:"false"`
+
+	// 固定位置
+	Fixed string `json:"fixed" binding:"omitempty,oneof=left right" example:"left"`
+}
+
+// TablePreference 表格列布局偏好, 列按显示顺序排列
+type TablePreference struct {
+	// 列设置
+	Columns []TableColumnPreference `json:"columns" binding:"max=200,dive"`
+
+	// 每页条数
+	PageSize int `json:"page_size" binding:"omitempty,min=1,max=1000" example:"20"`
+
+	// 排序字段
+	SortField string `json:"sort_field" binding:"omitempty,max=64" example:"created_at"`
+
+	// 排序方向
+	SortOrder string `json:"sort_order" binding:"omitempty,oneof=asc desc" example:"desc"`
+}
+
+// PreferenceSchema 返回命名空间对应的偏好结构体指针, 用于解析和校验偏好内容, 不支持的命名空间返回false
+func PreferenceSchema(namespace string) (any, bool) {
+	switch {
+	case namespace == PreferenceTheme:
+		return &ThemePreference{}, true
+	case namespace == PreferenceColonyFilter:
+		return &ColonyFilterPreference{}, true
+	case strings.HasPrefix(namespace, PreferenceTablePrefix) &&
+		preferenceTablePattern.MatchString(strings.TrimPrefix(namespace, PreferenceTablePrefix)):
+		return &TablePreference{}, true
+	default:
+		return nil, false
+	}
+}
+
+// PreferenceUri 偏好命名空间路径参数
+type PreferenceUri struct {
+	Namespace string `uri:"namespace" binding:"required,max=64"`
+}
+
+type PreferenceOut struct {
+	// 命名空间
+	Namespace string `json:"namespace" example:"theme"`
+
+	// 偏好内容
+	Value json.RawMessage `json:"value" swaggertype:"object"`
+
+	// 更新时间
+	UpdatedAt string `json:"updated_at" example:"2023-01-01 12:00:00"`
+}
+
+// PreferenceReply 用户偏好响应结构
+type PreferenceReply = common.APIReply[PreferenceOut]
+
+// PreferencesReply 用户全部偏好响应结构
+type PreferencesReply = common.APIReply[*[]PreferenceOut]
+
+func PreferenceToOut(
+	m UserPreferenceModel,
+) *PreferenceOut {
+	return &PreferenceOut{
+		Namespace: m.Namespace,
+		Value:     json.RawMessage(m.Value),
+		UpdatedAt: m.UpdatedAt.Format(time.DateTime),
+	}
+}
+
+func ListPreferenceToOut(
+	rms *[]UserPreferenceModel,
+) *[]PreferenceOut {
+	if rms == nil {
+		return &[]PreferenceOut{}
+	}
+
+	ms := *rms
+	mso := make([]PreferenceOut, 0, len(ms))
+	for _, m := range ms {
+		mso = append(mso, *PreferenceToOut(m))
+	}
+	return &mso
+}
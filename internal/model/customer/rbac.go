@@ -0,0 +1,163 @@
+package customer
+
+import (
+	"go.uber.org/zap/zapcore"
+
+	"gin-artweb/internal/model/common"
+)
+
+// RbacDocumentVersion 权限配置文档的格式版本
+const RbacDocumentVersion = 1
+
+// 导入时与目标环境已有数据冲突的处理方式
+const (
+	RbacConflictFail      = "fail"      // 存在冲突时不导入任何数据
+	RbacConflictSkip      = "skip"      // 保留目标环境的数据
+	RbacConflictOverwrite = "overwrite" // 使用文档中的数据覆盖
+)
+
+// 导入条目的处理动作
+const (
+	RbacActionCreate    = "create"
+	RbacActionUpdate    = "update"
+	RbacActionUnchanged = "unchanged"
+	RbacActionSkip      = "skip"
+	RbacActionConflict  = "conflict"
+)
+
+// 导入条目的类型
+const (
+	RbacKindCasbinModel = "casbin_model"
+	RbacKindApi         = "api"
+	RbacKindMenu        = "menu"
+	RbacKindButton      = "button"
+	RbacKindRole        = "role"
+)
+
+// RbacDocument 权限配置文档
+// 各对象之间使用名称等业务标识引用, 不包含数据库ID, 以便在不同环境间迁移
+type RbacDocument struct {
+	// 格式版本
+	Version int `yaml:"version" json:"version"`
+
+	// 导出时间
+	ExportedAt string `yaml:"exported_at" json:"exported_at"`
+
+	// 生效的Casbin模型配置, 为空时导入不修改模型
+	CasbinModel string `yaml:"casbin_model,omitempty" json:"casbin_model,omitempty"`
+
+	Apis    []RbacApiItem    `yaml:"apis" json:"apis"`
+	Menus   []RbacMenuItem   `yaml:"menus" json:"menus"`
+	Buttons []RbacButtonItem `yaml:"buttons" json:"buttons"`
+	Roles   []RbacRoleItem   `yaml:"roles" json:"roles"`
+
+	// Casbin策略, 由上面的关联关系生成, 仅供查看, 导入时按关联关系重新生成
+	Policies []string `yaml:"policies,omitempty" json:"policies,omitempty"`
+}
+
+// RbacApiItem 文档中的API, 使用"请求方法 URL"作为引用标识
+type RbacApiItem struct {
+	URL    string `yaml:"url" json:"url"`
+	Method string `yaml:"method" json:"method"`
+	Label  string `yaml:"label" json:"label"`
+	Descr  string `yaml:"descr,omitempty" json:"descr,omitempty"`
+}
+
+// Key API在文档中的引用标识
+func (i RbacApiItem) Key() string {
+	return i.Method + " " + i.URL
+}
+
+// RbacMenuItem 文档中的菜单, 使用名称作为引用标识
+type RbacMenuItem struct {
+	Name      string       `yaml:"name" json:"name"`
+	Path      string       `yaml:"path" json:"path"`
+	Component string       `yaml:"component" json:"component"`
+	Meta      RbacMenuMeta `yaml:"meta" json:"meta"`
+	Sort      uint32       `yaml:"sort" json:"sort"`
+	IsActive  bool         `yaml:"is_active" json:"is_active"`
+	Descr     string       `yaml:"descr,omitempty" json:"descr,omitempty"`
+	Parent    string       `yaml:"parent,omitempty" json:"parent,omitempty"`
+	Apis      []string     `yaml:"apis,omitempty" json:"apis,omitempty"`
+}
+
+// RbacMenuMeta 文档中的菜单信息
+type RbacMenuMeta struct {
+	Title string `yaml:"title" json:"title"`
+	Icon  string `yaml:"icon,omitempty" json:"icon,omitempty"`
+}
+
+// RbacButtonItem 文档中的按钮, 使用名称作为引用标识
+type RbacButtonItem struct {
+	Name     string   `yaml:"name" json:"name"`
+	Menu     string   `yaml:"menu" json:"menu"`
+	Sort     uint32   `yaml:"sort" json:"sort"`
+	IsActive bool     `yaml:"is_active" json:"is_active"`
+	Descr    string   `yaml:"descr,omitempty" json:"descr,omitempty"`
+	Apis     []string `yaml:"apis,omitempty" json:"apis,omitempty"`
+}
+
+// RbacRoleItem 文档中的角色, 使用名称作为引用标识
+type RbacRoleItem struct {
+	Name    string   `yaml:"name" json:"name"`
+	Descr   string   `yaml:"descr,omitempty" json:"descr,omitempty"`
+	Apis    []string `yaml:"apis,omitempty" json:"apis,omitempty"`
+	Menus   []string `yaml:"menus,omitempty" json:"menus,omitempty"`
+	Buttons []string `yaml:"buttons,omitempty" json:"buttons,omitempty"`
+}
+
+// ImportRbacRequest 导入权限配置的请求参数, 文档内容通过请求体提交
+//
+// swagger:model ImportRbacRequest
+type ImportRbacRequest struct {
+	// 是否只比较差异而不导入
+	DryRun bool `form:"dry_run" binding:"omitempty"`
+
+	// 冲突处理方式(fail:存在冲突时不导入, skip:保留已有数据, overwrite:覆盖已有数据), 默认为fail
+	Conflict string `form:"conflict" binding:"omitempty,oneof=fail skip overwrite"`
+}
+
+func (req *ImportRbacRequest) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddBool("dry_run", req.DryRun)
+	enc.AddString("conflict", req.Conflict)
+	return nil
+}
+
+// RbacImportItemOut 导入条目的处理结果
+type RbacImportItemOut struct {
+	// 类型(casbin_model/api/menu/button/role)
+	Kind string `json:"kind" example:"role"`
+
+	// 引用标识
+	Key string `json:"key" example:"运维"`
+
+	// 处理动作(create/update/unchanged/skip/conflict)
+	Action string `json:"action" example:"update"`
+
+	// 与已有数据不同的字段
+	Fields []string `json:"fields,omitempty" example:"descr,apis"`
+}
+
+// RbacImportOut 导入权限配置的结果
+type RbacImportOut struct {
+	// 是否只比较差异
+	DryRun bool `json:"dry_run" example:"true"`
+
+	// 冲突处理方式
+	Conflict string `json:"conflict" example:"fail"`
+
+	// 是否已写入
+	Applied bool `json:"applied" example:"false"`
+
+	// 各处理动作的条目数
+	Summary map[string]int `json:"summary"`
+
+	// 各条目的处理结果, 不包含未变化的条目
+	Items []RbacImportItemOut `json:"items"`
+
+	// 导入前后Casbin策略的差异(unified diff)
+	PolicyDiff string `json:"policy_diff"`
+}
+
+// RbacImportReply 导入权限配置的响应结构
+type RbacImportReply = common.APIReply[*RbacImportOut]
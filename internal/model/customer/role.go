@@ -11,17 +11,26 @@ import (
 
 type RoleModel struct {
 	database.StandardModel
-	Name    string        `gorm:"column:name;type:varchar(50);not null;uniqueIndex;comment:名称" json:"name"`
-	Descr   string        `gorm:"column:descr;type:varchar(254);comment:描述" json:"descr"`
-	Apis    []ApiModel    `gorm:"many2many:customer_role_api;joinForeignKey:role_id;joinReferences:api_id;constraint:OnDelete:CASCADE"`
-	Menus   []MenuModel   `gorm:"many2many:customer_role_menu;joinForeignKey:role_id;joinReferences:menu_id;constraint:OnDelete:CASCADE"`
-	Buttons []ButtonModel `gorm:"many2many:customer_role_button;joinForeignKey:role_id;joinReferences:button_id;constraint:OnDelete:CASCADE"`
+	Name      string        `gorm:"column:name;type:varchar(50);not null;uniqueIndex;comment:名称" json:"name"`
+	Descr     string        `gorm:"column:descr;type:varchar(254);comment:描述" json:"descr"`
+	DataScope string        `gorm:"column:data_scope;type:varchar(20);not null;default:all;comment:数据范围" json:"data_scope"`
+	Apis      []ApiModel    `gorm:"many2many:customer_role_api;joinForeignKey:role_id;joinReferences:api_id;constraint:OnDelete:CASCADE"`
+	Menus     []MenuModel   `gorm:"many2many:customer_role_menu;joinForeignKey:role_id;joinReferences:menu_id;constraint:OnDelete:CASCADE"`
+	Buttons   []ButtonModel `gorm:"many2many:customer_role_button;joinForeignKey:role_id;joinReferences:button_id;constraint:OnDelete:CASCADE"`
 }
 
 func (m *RoleModel) TableName() string {
 	return "customer_role"
 }
 
+// EffectiveDataScope 角色的数据范围, 未设置时为全部数据
+func (m *RoleModel) EffectiveDataScope() string {
+	if m.DataScope == "" {
+		return DataScopeAll
+	}
+	return m.DataScope
+}
+
 func (m *RoleModel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 	if m == nil {
 		return nil
@@ -31,6 +40,7 @@ func (m *RoleModel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 	}
 	enc.AddString("name", m.Name)
 	enc.AddString("descr", m.Descr)
+	enc.AddString("data_scope", m.DataScope)
 	enc.AddArray("apis", zapcore.ArrayMarshalerFunc(func(ae zapcore.ArrayEncoder) error {
 		for _, api := range m.Apis {
 			ae.AppendUint32(api.ID)
@@ -68,6 +78,9 @@ type CreateOrUpdateRoleRequest struct {
 	// 描述信息
 	Descr string `json:"descr" form:"descr" binding:"omitempty,max=254"`
 
+	// 数据范围, all:全部数据, department:本部门及下级部门, self:仅本人, 为空时为all
+	DataScope string `json:"data_scope" form:"data_scope" binding:"omitempty,oneof=all department self"`
+
 	// APIID列表
 	ApiIDs []uint32 `json:"api_ids" form:"api_ids" binding:"omitempty"`
 
@@ -76,11 +89,15 @@ type CreateOrUpdateRoleRequest struct {
 
 	// 按钮ID列表
 	ButtonIDs []uint32 `json:"button_ids" form:"button_ids" binding:"omitempty"`
+
+	// 版本号, 更新时必填(也可通过If-Match请求头传递)
+	Version uint32 `json:"version" form:"version" binding:"omitempty"`
 }
 
 func (req *CreateOrUpdateRoleRequest) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 	enc.AddString("name", req.Name)
 	enc.AddString("descr", req.Descr)
+	enc.AddString("data_scope", req.DataScope)
 	enc.AddArray("api_ids", zapcore.ArrayMarshalerFunc(func(ae zapcore.ArrayEncoder) error {
 		for _, id := range req.ApiIDs {
 			ae.AppendUint32(id)
@@ -136,6 +153,9 @@ type RoleBaseOut struct {
 
 	// 描述
 	Descr string `json:"descr" example:"用户管理"`
+
+	// 数据范围
+	DataScope string `json:"data_scope" example:"all"`
 }
 
 // RoleStandardOut 角色基础信息
@@ -147,6 +167,9 @@ type RoleStandardOut struct {
 
 	// 更新时间
 	UpdatedAt string `json:"updated_at" example:"2023-01-01 12:00:00"`
+
+	// 版本号
+	Version uint32 `json:"version" example:"1"`
 }
 
 type RoleDetailOut struct {
@@ -186,9 +209,10 @@ func RoleModelToBaseOut(
 	m RoleModel,
 ) *RoleBaseOut {
 	return &RoleBaseOut{
-		ID:    m.ID,
-		Name:  m.Name,
-		Descr: m.Descr,
+		ID:        m.ID,
+		Name:      m.Name,
+		Descr:     m.Descr,
+		DataScope: m.DataScope,
 	}
 }
 
@@ -199,6 +223,7 @@ func RoleModelToStandardOut(
 		RoleBaseOut: *RoleModelToBaseOut(m),
 		CreatedAt:   m.CreatedAt.Format(time.DateTime),
 		UpdatedAt:   m.UpdatedAt.Format(time.DateTime),
+		Version:     m.Version,
 	}
 }
 
@@ -0,0 +1,166 @@
+package customer
+
+import (
+	"time"
+
+	"go.uber.org/zap/zapcore"
+
+	"gin-artweb/internal/model/common"
+	"gin-artweb/internal/shared/database"
+)
+
+const (
+	SessionMethodPassword    = "password"     // 用户名密码登录
+	SessionMethodOidc        = "oidc:"        // 单点登录, 后接身份提供方标识
+	SessionMethodImpersonate = "impersonate:" // 管理员代为登录, 后接管理员用户名
+)
+
+// UserSessionModel 用户登录会话, 同一次登录签发和刷新的令牌属于同一个会话
+//
+// 删除会话后, 会话下的访问令牌和刷新令牌立即失效
+type UserSessionModel struct {
+	database.StandardModel
+	SessionID       string    `gorm:"column:session_id;type:varchar(36);not null;uniqueIndex;comment:会话ID" json:"session_id"`
+	UserID          uint32    `gorm:"column:user_id;not null;index;comment:用户ID" json:"user_id"`
+	User            UserModel `gorm:"foreignKey:UserID;references:ID;constraint:OnDelete:CASCADE" json:"user"`
+	Method          string    `gorm:"column:method;type:varchar(50);not null;comment:登录方式" json:"method"`
+	Device          string    `gorm:"column:device;type:varchar(100);comment:设备" json:"device"`
+	IPAddress       string    `gorm:"column:ip_address;type:varchar(108);comment:IP地址" json:"ip_address"`
+	UserAgent       string    `gorm:"column:user_agent;type:varchar(254);comment:客户端信息" json:"user_agent"`
+	AccessID        string    `gorm:"column:access_id;type:varchar(36);not null;comment:访问令牌ID" json:"access_id"`
+	RefreshID       string    `gorm:"column:refresh_id;type:varchar(36);not null;comment:刷新令牌ID" json:"refresh_id"`
+	IssuedAt        time.Time `gorm:"column:issued_at;not null;comment:登录时间" json:"issued_at"`
+	RefreshedAt     time.Time `gorm:"column:refreshed_at;not null;comment:最近签发令牌时间" json:"refreshed_at"`
+	AccessExpiresAt time.Time `gorm:"column:access_expires_at;not null;comment:访问令牌过期时间" json:"access_expires_at"`
+	ExpiresAt       time.Time `gorm:"column:expires_at;not null;index;comment:会话过期时间" json:"expires_at"`
+}
+
+func (m *UserSessionModel) TableName() string {
+	return "customer_user_session"
+}
+
+func (m *UserSessionModel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if m == nil {
+		return nil
+	}
+	if err := m.StandardModel.MarshalLogObject(enc); err != nil {
+		return err
+	}
+	enc.AddString("session_id", m.SessionID)
+	enc.AddUint32("user_id", m.UserID)
+	enc.AddString("method", m.Method)
+	enc.AddString("device", m.Device)
+	enc.AddString("ip_address", m.IPAddress)
+	enc.AddString("user_agent", m.UserAgent)
+	enc.AddString("access_id", m.AccessID)
+	enc.AddString("refresh_id", m.RefreshID)
+	enc.AddTime("issued_at", m.IssuedAt)
+	enc.AddTime("refreshed_at", m.RefreshedAt)
+	enc.AddTime("access_expires_at", m.AccessExpiresAt)
+	enc.AddTime("expires_at", m.ExpiresAt)
+	return nil
+}
+
+// SessionUri 会话路径参数
+type SessionUri struct {
+	SessionID string `uri:"session_id" binding:"required,uuid"`
+}
+
+// UserSessionUri 用户会话路径参数
+type UserSessionUri struct {
+	ID        uint32 `uri:"id" binding:"required,gt=0"`
+	SessionID string `uri:"session_id" binding:"required,uuid"`
+}
+
+// UserSessionOut 用户登录会话
+type UserSessionOut struct {
+	// 会话ID
+	SessionID string `json:"session_id" example:"0b6f7c4e-3f5a-4d8e-9a51-6c2c3f1b2d4e"`
+
+	// 是否为当前请求所属的会话
+	Current bool `json:"current" example:"true"`
+
+	// 登录方式
+	Method string `json:"method" example:"password"`
+
+	// 设备
+	Device string `json:"device" example:"Chrome / Windows"`
+
+	// IP地址, 没有查看敏感字段权限时掩码
+	IPAddress string `json:"ip_address" mask:"ip" example:"192.168.1.100"`
+
+	// 客户端信息
+	UserAgent string `json:"user_agent" example:"Mozilla/5.0 (Windows NT 10.0; Win64; x64)"`
+
+	// 访问令牌ID
+	AccessID string `json:"access_id" example:"5f0c3c1e-9d2b-4b7a-8f61-2a1d0e4c7b93"`
+
+	// 刷新令牌ID
+	RefreshID string `json:"refresh_id" example:"c2a7e5d4-1b8f-4e6a-9c3d-7f0b2e5a8d16"`
+
+	// 登录时间
+	IssuedAt string `json:"issued_at" example:"2023-01-01 12:00:00"`
+
+	// 最近签发令牌时间
+	RefreshedAt string `json:"refreshed_at" example:"2023-01-01 12:30:00"`
+
+	// 访问令牌过期时间
+	AccessExpiresAt string `json:"access_expires_at" example:"2023-01-01 12:45:00"`
+
+	// 会话过期时间
+	ExpiresAt string `json:"expires_at" example:"2023-01-02 12:30:00"`
+}
+
+// DeleteSessionOut 终止会话的结果
+type DeleteSessionOut struct {
+	// 终止的会话数量
+	Deleted int64 `json:"deleted" example:"2"`
+}
+
+// UserSessionsReply 用户登录会话响应结构
+type UserSessionsReply = common.APIReply[*[]UserSessionOut]
+
+// DeleteSessionReply 终止会话响应结构
+type DeleteSessionReply = common.APIReply[DeleteSessionOut]
+
+// UserSessionToOut currentSessionID为当前请求所属的会话, 用于标记当前会话; reveal为false时掩码IP地址
+func UserSessionToOut(
+	m UserSessionModel,
+	currentSessionID string,
+	reveal bool,
+) *UserSessionOut {
+	mo := &UserSessionOut{
+		SessionID:       m.SessionID,
+		Current:         currentSessionID != "" && m.SessionID == currentSessionID,
+		Method:          m.Method,
+		Device:          m.Device,
+		IPAddress:       m.IPAddress,
+		UserAgent:       m.UserAgent,
+		AccessID:        m.AccessID,
+		RefreshID:       m.RefreshID,
+		IssuedAt:        m.IssuedAt.Format(time.DateTime),
+		RefreshedAt:     m.RefreshedAt.Format(time.DateTime),
+		AccessExpiresAt: m.AccessExpiresAt.Format(time.DateTime),
+		ExpiresAt:       m.ExpiresAt.Format(time.DateTime),
+	}
+	common.MaskSensitive(mo, reveal)
+	return mo
+}
+
+func ListUserSessionToOut(
+	sms *[]UserSessionModel,
+	currentSessionID string,
+	reveal bool,
+) *[]UserSessionOut {
+	if sms == nil {
+		return &[]UserSessionOut{}
+	}
+
+	ms := *sms
+	mso := make([]UserSessionOut, 0, len(ms))
+	for _, m := range ms {
+		mo := UserSessionToOut(m, currentSessionID, reveal)
+		mso = append(mso, *mo)
+	}
+	return &mso
+}
@@ -0,0 +1,23 @@
+package customer
+
+// 初始化时创建的默认角色, 权限依次递减
+const (
+	RoleNameAdmin    = "超级管理员"
+	RoleNameOperator = "运维人员"
+	RoleNameViewer   = "只读用户"
+)
+
+// BootstrapOut 系统初始化结果
+type BootstrapOut struct {
+	// 新导入的API数量
+	ApiCount int `json:"api_count" example:"120"`
+
+	// 新创建的角色
+	Roles []string `json:"roles" example:"超级管理员,运维人员,只读用户"`
+
+	// 管理员用户名
+	Username string `json:"username" example:"admin"`
+
+	// 自动生成的管理员初始密码, 指定密码时为空
+	Password string `json:"password,omitempty" example:""`
+}
@@ -0,0 +1,170 @@
+package customer
+
+import (
+	"time"
+
+	"go.uber.org/zap/zapcore"
+
+	"gin-artweb/internal/model/common"
+	"gin-artweb/internal/shared/database"
+)
+
+// 签名密钥状态
+const (
+	SigningKeyStatusCurrent  = "current"  // 当前用于签发令牌
+	SigningKeyStatusRetiring = "retiring" // 已轮换, 仅用于验证轮换前签发的令牌
+	SigningKeyStatusRetired  = "retired"  // 已停用
+)
+
+// SigningKeyModel JWT签名密钥
+//
+// 环境变量配置的密钥只保存标识, 不保存密钥内容
+type SigningKeyModel struct {
+	database.StandardModel
+	KeyID      string     `gorm:"column:kid;type:varchar(64);not null;uniqueIndex;comment:密钥标识" json:"kid"`
+	TokenType  string     `gorm:"column:token_type;type:varchar(10);not null;index;comment:令牌类型" json:"token_type"`
+	Algorithm  string     `gorm:"column:algorithm;type:varchar(10);not null;comment:签名方法" json:"algorithm"`
+	PrivateKey string     `gorm:"column:private_key;type:text;serializer:encrypted;comment:私钥或HMAC密钥" json:"-"`
+	PublicKey  string     `gorm:"column:public_key;type:text;comment:公钥" json:"public_key"`
+	IsCurrent  bool       `gorm:"column:is_current;type:boolean;index;comment:是否为当前密钥" json:"is_current"`
+	RetireAt   *time.Time `gorm:"column:retire_at;index;comment:停用时间" json:"retire_at"`
+	Operator   string     `gorm:"column:operator;type:varchar(50);comment:操作人" json:"operator"`
+}
+
+func (m *SigningKeyModel) TableName() string {
+	return "customer_signing_key"
+}
+
+func (m *SigningKeyModel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if m == nil {
+		return nil
+	}
+	if err := m.StandardModel.MarshalLogObject(enc); err != nil {
+		return err
+	}
+	enc.AddString("kid", m.KeyID)
+	enc.AddString("token_type", m.TokenType)
+	enc.AddString("algorithm", m.Algorithm)
+	enc.AddBool("is_current", m.IsCurrent)
+	if m.RetireAt != nil {
+		enc.AddTime("retire_at", *m.RetireAt)
+	}
+	enc.AddString("operator", m.Operator)
+	return nil
+}
+
+// Status 密钥在指定时间的状态
+func (m *SigningKeyModel) Status(t time.Time) string {
+	switch {
+	case m.IsCurrent:
+		return SigningKeyStatusCurrent
+	case m.RetireAt != nil && !t.Before(*m.RetireAt):
+		return SigningKeyStatusRetired
+	default:
+		return SigningKeyStatusRetiring
+	}
+}
+
+// RotateSigningKeyRequest 用于轮换签名密钥的请求结构体
+//
+// swagger:model RotateSigningKeyRequest
+type RotateSigningKeyRequest struct {
+	// 令牌类型(access/refresh), 为空时同时轮换两种令牌的密钥
+	TokenType string `json:"token_type" binding:"omitempty,oneof=access refresh"`
+
+	// 是否立即停用旧密钥, 用于密钥泄露的场景, 旧密钥签发的令牌将立即失效
+	RevokePrevious bool `json:"revoke_previous"`
+}
+
+// ListSigningKeyRequest 用于查询签名密钥的请求结构体
+//
+// swagger:model ListSigningKeyRequest
+type ListSigningKeyRequest struct {
+	common.StandardModelQuery
+
+	// 令牌类型
+	TokenType string `form:"token_type" binding:"omitempty,oneof=access refresh"`
+}
+
+func (req *ListSigningKeyRequest) Query() (int, int, map[string]any) {
+	page, size, query := req.StandardModelQuery.QueryMap(10)
+	if req.TokenType != "" {
+		query["token_type = ?"] = req.TokenType
+	}
+	return page, size, query
+}
+
+type SigningKeyOut struct {
+	// ID
+	ID uint32 `json:"id" example:"1"`
+
+	// 密钥标识
+	KeyID string `json:"kid" example:"9f86d081884c7d65"`
+
+	// 令牌类型
+	TokenType string `json:"token_type" example:"access"`
+
+	// 签名方法
+	Algorithm string `json:"algorithm" example:"RS256"`
+
+	// 公钥, HMAC密钥为空
+	PublicKey string `json:"public_key" example:"-----BEGIN PUBLIC KEY-----"`
+
+	// 状态(current/retiring/retired)
+	Status string `json:"status" example:"current"`
+
+	// 停用时间
+	RetireAt string `json:"retire_at" example:"2023-01-01 15:00:00"`
+
+	// 操作人
+	Operator string `json:"operator" example:"admin"`
+
+	// 创建时间
+	CreatedAt string `json:"created_at" example:"2023-01-01 12:00:00"`
+}
+
+// SigningKeyRotateOut 轮换签名密钥的结果
+type SigningKeyRotateOut struct {
+	// 新生成的密钥
+	Keys []SigningKeyOut `json:"keys"`
+}
+
+// PagSigningKeyReply 签名密钥的分页响应结构
+type PagSigningKeyReply = common.APIReply[*common.Pag[SigningKeyOut]]
+
+// SigningKeyRotateReply 轮换签名密钥的响应结构
+type SigningKeyRotateReply = common.APIReply[SigningKeyRotateOut]
+
+func SigningKeyToOut(
+	m SigningKeyModel,
+) *SigningKeyOut {
+	mo := &SigningKeyOut{
+		ID:        m.ID,
+		KeyID:     m.KeyID,
+		TokenType: m.TokenType,
+		Algorithm: m.Algorithm,
+		PublicKey: m.PublicKey,
+		Status:    m.Status(time.Now()),
+		Operator:  m.Operator,
+		CreatedAt: m.CreatedAt.Format(time.DateTime),
+	}
+	if m.RetireAt != nil {
+		mo.RetireAt = m.RetireAt.Format(time.DateTime)
+	}
+	return mo
+}
+
+func ListSigningKeyToOut(
+	rms *[]SigningKeyModel,
+) *[]SigningKeyOut {
+	if rms == nil {
+		return &[]SigningKeyOut{}
+	}
+
+	ms := *rms
+	mso := make([]SigningKeyOut, 0, len(ms))
+	for _, m := range ms {
+		mso = append(mso, *SigningKeyToOut(m))
+	}
+	return &mso
+}
@@ -11,12 +11,18 @@ import (
 
 type UserModel struct {
 	database.StandardModel
-	Username string    `gorm:"column:username;type:varchar(50);not null;uniqueIndex;comment:用户名" json:"username"`
-	Password string    `gorm:"column:password;type:varchar(150);not null;comment:密码" json:"password"`
-	IsActive bool      `gorm:"column:is_active;type:boolean;comment:是否激活" json:"is_active"`
-	IsStaff  bool      `gorm:"column:is_staff;type:boolean;comment:是否是工作人员" json:"is_staff"`
-	RoleID   uint32    `gorm:"column:role_id;not null;comment:角色ID" json:"role_id"`
-	Role     RoleModel `gorm:"foreignKey:RoleID;references:ID;constraint:OnDelete:CASCADE" json:"role"`
+	Username           string           `gorm:"column:username;type:varchar(50);not null;uniqueIndex;comment:用户名" json:"username"`
+	Password           string           `gorm:"column:password;type:varchar(150);not null;comment:密码" json:"password"`
+	IsActive           bool             `gorm:"column:is_active;type:boolean;comment:是否激活" json:"is_active"`
+	IsStaff            bool             `gorm:"column:is_staff;type:boolean;comment:是否是工作人员" json:"is_staff"`
+	RoleID             uint32           `gorm:"column:role_id;not null;comment:角色ID" json:"role_id"`
+	Role               RoleModel        `gorm:"foreignKey:RoleID;references:ID;constraint:OnDelete:CASCADE" json:"role"`
+	MustChangePassword bool             `gorm:"column:must_change_password;type:boolean;not null;default:false;comment:是否需要修改密码" json:"must_change_password"`
+	DepartmentID       *uint32          `gorm:"column:department_id;index;comment:部门ID" json:"department_id"`
+	Department         *DepartmentModel `gorm:"foreignKey:DepartmentID;references:ID;constraint:OnDelete:SET NULL" json:"department"`
+	DeactivatedAt      *time.Time       `gorm:"column:deactivated_at;comment:停用时间" json:"deactivated_at"`
+	DisableAt          *time.Time       `gorm:"column:disable_at;index;comment:计划永久停用时间" json:"disable_at"`
+	DisabledAt         *time.Time       `gorm:"column:disabled_at;comment:永久停用时间" json:"disabled_at"`
 }
 
 func (m *UserModel) TableName() string {
@@ -34,6 +40,16 @@ func (m *UserModel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 	enc.AddBool("is_active", m.IsActive)
 	enc.AddBool("is_staff", m.IsStaff)
 	enc.AddUint32("role_id", m.RoleID)
+	enc.AddBool("must_change_password", m.MustChangePassword)
+	if m.DepartmentID != nil {
+		enc.AddUint32("department_id", *m.DepartmentID)
+	}
+	if m.DisableAt != nil {
+		enc.AddTime("disable_at", *m.DisableAt)
+	}
+	if m.DisabledAt != nil {
+		enc.AddTime("disabled_at", *m.DisabledAt)
+	}
 	return nil
 }
 
@@ -55,6 +71,9 @@ type CreateUserRequest struct {
 
 	// 角色ID
 	RoleID uint32 `json:"role_id" form:"role_id" binding:"required"`
+
+	// 部门ID
+	DepartmentID *uint32 `json:"department_id" form:"department_id" binding:"omitempty"`
 }
 
 func (req *CreateUserRequest) MarshalLogObject(enc zapcore.ObjectEncoder) error {
@@ -62,6 +81,9 @@ func (req *CreateUserRequest) MarshalLogObject(enc zapcore.ObjectEncoder) error
 	enc.AddBool("is_active", req.IsActive)
 	enc.AddBool("is_staff", req.IsStaff)
 	enc.AddUint32("role_id", req.RoleID)
+	if req.DepartmentID != nil {
+		enc.AddUint32("department_id", *req.DepartmentID)
+	}
 	return nil
 }
 
@@ -109,6 +131,12 @@ type ListUserRequest struct {
 
 	// 角色ID
 	RoleID uint32 `form:"role_id" binding:"omitempty"`
+
+	// 部门ID
+	DepartmentID uint32 `form:"department_id" binding:"omitempty"`
+
+	// 是否包含下级部门的用户, 由服务层展开为部门ID列表
+	IncludeSub bool `form:"include_sub" binding:"omitempty"`
 }
 
 func (req *ListUserRequest) Query() (int, int, map[string]any) {
@@ -125,6 +153,9 @@ func (req *ListUserRequest) Query() (int, int, map[string]any) {
 	if req.RoleID != 0 {
 		query["role_id = ?"] = req.RoleID
 	}
+	if req.DepartmentID != 0 && !req.IncludeSub {
+		query["department_id = ?"] = req.DepartmentID
+	}
 	return page, size, query
 }
 
@@ -159,11 +190,17 @@ type LoginRequest struct {
 
 	// 密码
 	Password string `json:"password" form:"password" binding:"required,max=20"`
+
+	// 验证码挑战ID, 登录失败次数过多时必填
+	CaptchaID string `json:"captcha_id" form:"captcha_id" binding:"omitempty,uuid"`
+
+	// 验证码答案
+	CaptchaAnswer string `json:"captcha_answer" form:"captcha_answer" binding:"omitempty,max=10"`
 }
 
 type RefreshTokenRequest struct {
-	// 刷新令牌
-	RefreshToken string `json:"refresh_token" form:"refresh_token" binding:"required"`
+	// 刷新令牌, 开启Cookie认证时可以为空, 从Cookie中读取
+	RefreshToken string `json:"refresh_token" form:"refresh_token"`
 }
 
 type UserBaseOut struct {
@@ -178,6 +215,12 @@ type UserBaseOut struct {
 
 	// 是否是工作人员
 	IsStaff bool `json:"is_staff" example:"false"`
+
+	// 是否需要修改密码
+	MustChangePassword bool `json:"must_change_password" example:"false"`
+
+	// 部门ID
+	DepartmentID *uint32 `json:"department_id" example:"1"`
 }
 
 // UserStandardOut用户基础信息
@@ -189,6 +232,15 @@ type UserStandardOut struct {
 
 	// 更新时间
 	UpdatedAt string `json:"updated_at" example:"2023-01-01 12:00:00"`
+
+	// 停用时间, 未停用时为空
+	DeactivatedAt string `json:"deactivated_at" example:"2023-01-01 12:00:00"`
+
+	// 计划永久停用时间, 此前可以重新启用用户
+	DisableAt string `json:"disable_at" example:"2023-01-31 12:00:00"`
+
+	// 永久停用时间, 永久停用后不能重新启用
+	DisabledAt string `json:"disabled_at" example:"2023-01-31 12:00:00"`
 }
 
 type UserDetailOut struct {
@@ -196,6 +248,9 @@ type UserDetailOut struct {
 
 	// 角色
 	Role *RoleBaseOut `json:"role"`
+
+	// 部门
+	Department *DepartmentBaseOut `json:"department"`
 }
 
 // UserBaseReply 用户响应结构
@@ -217,10 +272,12 @@ func UserModelToBaseOut(
 	m UserModel,
 ) *UserBaseOut {
 	return &UserBaseOut{
-		ID:       m.ID,
-		Username: m.Username,
-		IsActive: m.IsActive,
-		IsStaff:  m.IsStaff,
+		ID:                 m.ID,
+		Username:           m.Username,
+		IsActive:           m.IsActive,
+		IsStaff:            m.IsStaff,
+		MustChangePassword: m.MustChangePassword,
+		DepartmentID:       m.DepartmentID,
 	}
 }
 
@@ -228,12 +285,22 @@ func UserModelToStandardOut(
 	m UserModel,
 ) *UserStandardOut {
 	return &UserStandardOut{
-		UserBaseOut: *UserModelToBaseOut(m),
-		CreatedAt:   m.CreatedAt.Format(time.DateTime),
-		UpdatedAt:   m.UpdatedAt.Format(time.DateTime),
+		UserBaseOut:   *UserModelToBaseOut(m),
+		CreatedAt:     m.CreatedAt.Format(time.DateTime),
+		UpdatedAt:     m.UpdatedAt.Format(time.DateTime),
+		DeactivatedAt: formatTimePtr(m.DeactivatedAt),
+		DisableAt:     formatTimePtr(m.DisableAt),
+		DisabledAt:    formatTimePtr(m.DisabledAt),
 	}
 }
 
+func formatTimePtr(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.DateTime)
+}
+
 func UserModelToDetailOut(
 	m UserModel,
 ) *UserDetailOut {
@@ -241,9 +308,14 @@ func UserModelToDetailOut(
 	if m.Role.ID != 0 {
 		role = RoleModelToBaseOut(m.Role)
 	}
+	var department *DepartmentBaseOut
+	if m.Department != nil {
+		department = DepartmentModelToBaseOut(*m.Department)
+	}
 	return &UserDetailOut{
 		UserStandardOut: *UserModelToStandardOut(m),
 		Role:            role,
+		Department:      department,
 	}
 }
 
@@ -264,10 +336,12 @@ func ListUserModelToDetailOut(
 	return &mso
 }
 
+// LoginRecordModelToStandardOut reveal为false时掩码IP地址
 func LoginRecordModelToStandardOut(
 	m LoginRecordModel,
+	reveal bool,
 ) *LoginRecordStandardOut {
-	return &LoginRecordStandardOut{
+	mo := &LoginRecordStandardOut{
 		ID:        m.ID,
 		Username:  m.Username,
 		LoginAt:   m.LoginAt.Format(time.DateTime),
@@ -275,10 +349,13 @@ func LoginRecordModelToStandardOut(
 		IPAddress: m.IPAddress,
 		UserAgent: m.UserAgent,
 	}
+	common.MaskSensitive(mo, reveal)
+	return mo
 }
 
 func ListLoginRecordModelToStandardOut(
 	lms *[]LoginRecordModel,
+	reveal bool,
 ) *[]LoginRecordStandardOut {
 	if lms == nil {
 		return &[]LoginRecordStandardOut{}
@@ -287,7 +364,7 @@ func ListLoginRecordModelToStandardOut(
 	mso := make([]LoginRecordStandardOut, 0, len(ms))
 	if len(ms) > 0 {
 		for _, m := range ms {
-			mo := LoginRecordModelToStandardOut(m)
+			mo := LoginRecordModelToStandardOut(m, reveal)
 			mso = append(mso, *mo)
 		}
 	}
@@ -0,0 +1,235 @@
+package customer
+
+import (
+	"time"
+
+	"go.uber.org/zap/zapcore"
+
+	"gin-artweb/internal/model/common"
+	"gin-artweb/internal/shared/database"
+)
+
+// UserGroupModel 用户组, 组内用户继承用户组关联的全部角色
+type UserGroupModel struct {
+	database.StandardModel
+	Name  string      `gorm:"column:name;type:varchar(50);not null;uniqueIndex;comment:名称" json:"name"`
+	Descr string      `gorm:"column:descr;type:varchar(254);comment:描述" json:"descr"`
+	Roles []RoleModel `gorm:"many2many:customer_user_group_role;joinForeignKey:group_id;joinReferences:role_id;constraint:OnDelete:CASCADE"`
+	Users []UserModel `gorm:"many2many:customer_user_group_user;joinForeignKey:group_id;joinReferences:user_id;constraint:OnDelete:CASCADE"`
+}
+
+func (m *UserGroupModel) TableName() string {
+	return "customer_user_group"
+}
+
+func (m *UserGroupModel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if m == nil {
+		return nil
+	}
+	if err := m.StandardModel.MarshalLogObject(enc); err != nil {
+		return err
+	}
+	enc.AddString("name", m.Name)
+	enc.AddString("descr", m.Descr)
+	enc.AddArray("roles", zapcore.ArrayMarshalerFunc(func(ae zapcore.ArrayEncoder) error {
+		for _, role := range m.Roles {
+			ae.AppendUint32(role.ID)
+		}
+		return nil
+	}))
+	enc.AddArray("users", zapcore.ArrayMarshalerFunc(func(ae zapcore.ArrayEncoder) error {
+		for _, user := range m.Users {
+			ae.AppendUint32(user.ID)
+		}
+		return nil
+	}))
+	return nil
+}
+
+// CreateOrUpdateUserGroupRequest 用于创建或更新用户组的请求结构体
+//
+// swagger:model CreateOrUpdateUserGroupRequest
+type CreateOrUpdateUserGroupRequest struct {
+	// 名称
+	Name string `json:"name" form:"name" binding:"required,max=50"`
+
+	// 描述信息
+	Descr string `json:"descr" form:"descr" binding:"omitempty,max=254"`
+
+	// 角色ID列表
+	RoleIDs []uint32 `json:"role_ids" form:"role_ids" binding:"omitempty"`
+
+	// 用户ID列表
+	UserIDs []uint32 `json:"user_ids" form:"user_ids" binding:"omitempty"`
+}
+
+func (req *CreateOrUpdateUserGroupRequest) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("name", req.Name)
+	enc.AddString("descr", req.Descr)
+	enc.AddArray("role_ids", zapcore.ArrayMarshalerFunc(func(ae zapcore.ArrayEncoder) error {
+		for _, id := range req.RoleIDs {
+			ae.AppendUint32(id)
+		}
+		return nil
+	}))
+	enc.AddArray("user_ids", zapcore.ArrayMarshalerFunc(func(ae zapcore.ArrayEncoder) error {
+		for _, id := range req.UserIDs {
+			ae.AppendUint32(id)
+		}
+		return nil
+	}))
+	return nil
+}
+
+// UserGroupMemberRequest 用于添加或移除用户组成员的请求结构体
+//
+// swagger:model UserGroupMemberRequest
+type UserGroupMemberRequest struct {
+	// 用户ID列表
+	UserIDs []uint32 `json:"user_ids" form:"user_ids" binding:"required,min=1"`
+}
+
+func (req *UserGroupMemberRequest) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddArray("user_ids", zapcore.ArrayMarshalerFunc(func(ae zapcore.ArrayEncoder) error {
+		for _, id := range req.UserIDs {
+			ae.AppendUint32(id)
+		}
+		return nil
+	}))
+	return nil
+}
+
+// ListUserGroupRequest 用于获取用户组列表的请求结构体
+// 支持分页查询和多种筛选条件
+//
+// swagger:model ListUserGroupRequest
+type ListUserGroupRequest struct {
+	common.StandardModelQuery
+
+	// 名称
+	Name string `form:"name" binding:"omitempty,max=50"`
+
+	// 描述信息
+	Descr string `form:"descr" binding:"omitempty,max=254"`
+}
+
+func (req *ListUserGroupRequest) Query() (int, int, map[string]any) {
+	page, size, query := req.StandardModelQuery.QueryMap(8)
+	if req.Name != "" {
+		query["name like ?"] = "%" + req.Name + "%"
+	}
+	if req.Descr != "" {
+		query["descr like ?"] = "%" + req.Descr + "%"
+	}
+	return page, size, query
+}
+
+type UserGroupBaseOut struct {
+	// 唯一标识
+	ID uint32 `json:"id" example:"1"`
+
+	// 名称
+	Name string `json:"name" example:"运维组"`
+
+	// 描述
+	Descr string `json:"descr" example:"运维组"`
+}
+
+// UserGroupStandardOut 用户组基础信息
+type UserGroupStandardOut struct {
+	UserGroupBaseOut
+
+	// 创建时间
+	CreatedAt string `json:"created_at" example:"2023-01-01 12:00:00"`
+
+	// 更新时间
+	UpdatedAt string `json:"updated_at" example:"2023-01-01 12:00:00"`
+}
+
+type UserGroupDetailOut struct {
+	UserGroupStandardOut
+
+	// 角色ID列表
+	RoleIDs []uint32 `json:"role_ids"`
+
+	// 用户ID列表
+	UserIDs []uint32 `json:"user_ids"`
+}
+
+// UserGroupReply 用户组响应结构
+type UserGroupReply = common.APIReply[*UserGroupDetailOut]
+
+// PagUserGroupReply 用户组的分页响应结构
+type PagUserGroupReply = common.APIReply[*common.Pag[UserGroupStandardOut]]
+
+// EffectiveRoleReply 用户有效角色响应结构
+type EffectiveRoleReply = common.APIReply[*[]RoleBaseOut]
+
+func UserGroupModelToBaseOut(
+	m UserGroupModel,
+) *UserGroupBaseOut {
+	return &UserGroupBaseOut{
+		ID:    m.ID,
+		Name:  m.Name,
+		Descr: m.Descr,
+	}
+}
+
+func UserGroupModelToStandardOut(
+	m UserGroupModel,
+) *UserGroupStandardOut {
+	return &UserGroupStandardOut{
+		UserGroupBaseOut: *UserGroupModelToBaseOut(m),
+		CreatedAt:        m.CreatedAt.Format(time.DateTime),
+		UpdatedAt:        m.UpdatedAt.Format(time.DateTime),
+	}
+}
+
+func UserGroupModelToDetailOut(
+	m UserGroupModel,
+) *UserGroupDetailOut {
+	var roleIDs = []uint32{}
+	if len(m.Roles) > 0 {
+		roleIDs = make([]uint32, len(m.Roles))
+		for i, r := range m.Roles {
+			roleIDs[i] = r.ID
+		}
+	}
+
+	var userIDs = []uint32{}
+	if len(m.Users) > 0 {
+		userIDs = make([]uint32, len(m.Users))
+		for i, u := range m.Users {
+			userIDs[i] = u.ID
+		}
+	}
+	return &UserGroupDetailOut{
+		UserGroupStandardOut: *UserGroupModelToStandardOut(m),
+		RoleIDs:              roleIDs,
+		UserIDs:              userIDs,
+	}
+}
+
+func ListUserGroupModelToStandardOut(
+	ums *[]UserGroupModel,
+) *[]UserGroupStandardOut {
+	if ums == nil {
+		return &[]UserGroupStandardOut{}
+	}
+	ms := *ums
+	mso := make([]UserGroupStandardOut, 0, len(ms))
+	for _, m := range ms {
+		mso = append(mso, *UserGroupModelToStandardOut(m))
+	}
+	return &mso
+}
+
+func ListRoleModelToBaseOut(
+	rms []RoleModel,
+) *[]RoleBaseOut {
+	mso := make([]RoleBaseOut, 0, len(rms))
+	for _, m := range rms {
+		mso = append(mso, *RoleModelToBaseOut(m))
+	}
+	return &mso
+}
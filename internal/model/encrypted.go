@@ -0,0 +1,40 @@
+package model
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"gin-artweb/internal/model/customer"
+	"gin-artweb/internal/model/jobs"
+	"gin-artweb/internal/model/mon"
+	"gin-artweb/internal/model/system"
+	"gin-artweb/internal/shared/database"
+)
+
+// encryptedFields 使用加密序列化器的模型字段
+var encryptedFields = []struct {
+	model  any
+	fields []string
+}{
+	{&jobs.ScriptRecordModel{}, []string{"EnvVars"}},
+	{&jobs.ScheduleModel{}, []string{"EnvVars"}},
+	{&mon.MonNodeModel{}, []string{"PromPassword", "PromBearerToken"}},
+	{&customer.SigningKeyModel{}, []string{"PrivateKey"}},
+	{&system.WebhookSubscriptionModel{}, []string{"Secret"}},
+}
+
+// EncryptFields 使用当前字段加密密钥加密全部敏感字段, 返回更新的行数
+//
+// 用于开启字段加密后加密存量数据, 以及轮换字段加密密钥后使用新密钥重新加密
+func EncryptFields(ctx context.Context, db *gorm.DB) (int64, error) {
+	var total int64
+	for _, ef := range encryptedFields {
+		n, err := database.EncryptColumns(ctx, db, ef.model, ef.fields...)
+		total += n
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
@@ -0,0 +1,117 @@
+package jobs
+
+import (
+	"encoding/json"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+
+	"gin-artweb/internal/model/common"
+	"gin-artweb/internal/shared/database"
+)
+
+// ArtifactModel 脚本执行结束后收集的产物文件
+type ArtifactModel struct {
+	database.BaseModel
+	RecordID   uint32    `gorm:"column:record_id;not null;index;comment:执行记录ID" json:"record_id"`
+	Name       string    `gorm:"column:name;type:varchar(255);not null;comment:相对于工作目录的文件路径" json:"name"`
+	StorageKey string    `gorm:"column:storage_key;type:varchar(512);not null;comment:存储key" json:"storage_key"`
+	Size       int64     `gorm:"column:size;comment:文件大小(字节)" json:"size"`
+	Checksum   string    `gorm:"column:checksum;type:varchar(64);comment:SHA256校验和" json:"checksum"`
+	CreatedAt  time.Time `gorm:"column:created_at;index;comment:收集时间" json:"created_at"`
+}
+
+func (m *ArtifactModel) TableName() string {
+	return "jobs_artifact"
+}
+
+func (m *ArtifactModel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if m == nil {
+		return nil
+	}
+	if err := m.BaseModel.MarshalLogObject(enc); err != nil {
+		return err
+	}
+	enc.AddUint32("record_id", m.RecordID)
+	enc.AddString("name", m.Name)
+	enc.AddString("storage_key", m.StorageKey)
+	enc.AddInt64("size", m.Size)
+	enc.AddString("checksum", m.Checksum)
+	enc.AddTime("created_at", m.CreatedAt)
+	return nil
+}
+
+// ArtifactUri 执行产物路径参数
+type ArtifactUri struct {
+	ID         uint32 `uri:"id" binding:"required,gt=0"`
+	ArtifactID uint32 `uri:"artifact_id" binding:"required,gt=0"`
+}
+
+type ArtifactOut struct {
+	// ID
+	ID uint32 `json:"id" example:"1"`
+
+	// 执行记录ID
+	RecordID uint32 `json:"record_id" example:"1"`
+
+	// 相对于工作目录的文件路径
+	Name string `json:"name" example:"reports/recon_20250101.csv"`
+
+	// 文件大小(字节)
+	Size int64 `json:"size" example:"1024"`
+
+	// SHA256校验和
+	Checksum string `json:"checksum" example:"e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"`
+
+	// 收集时间
+	CreatedAt string `json:"created_at" example:"2025-01-01 08:00:00"`
+}
+
+// ListArtifactReply 执行产物列表响应结构
+type ListArtifactReply = common.APIReply[[]ArtifactOut]
+
+func ArtifactToOut(
+	m ArtifactModel,
+) *ArtifactOut {
+	return &ArtifactOut{
+		ID:        m.ID,
+		RecordID:  m.RecordID,
+		Name:      m.Name,
+		Size:      m.Size,
+		Checksum:  m.Checksum,
+		CreatedAt: m.CreatedAt.Format(time.DateTime),
+	}
+}
+
+func ListArtifactToOut(
+	rms *[]ArtifactModel,
+) []ArtifactOut {
+	if rms == nil {
+		return []ArtifactOut{}
+	}
+
+	ms := *rms
+	mso := make([]ArtifactOut, 0, len(ms))
+	for _, m := range ms {
+		mso = append(mso, *ArtifactToOut(m))
+	}
+	return mso
+}
+
+// DecodeArtifactPatterns 解析产物文件匹配规则, 为空或内容无效时返回nil
+func DecodeArtifactPatterns(s string) []string {
+	var patterns []string
+	if s == "" || json.Unmarshal([]byte(s), &patterns) != nil || len(patterns) == 0 {
+		return nil
+	}
+	return patterns
+}
+
+// EncodeArtifactPatterns 产物文件匹配规则编码为JSON数组, 没有规则时返回空字符串
+func EncodeArtifactPatterns(patterns []string) string {
+	if len(patterns) == 0 {
+		return ""
+	}
+	b, _ := json.Marshal(patterns)
+	return string(b)
+}
@@ -0,0 +1,280 @@
+package jobs
+
+import (
+	"time"
+
+	"go.uber.org/zap/zapcore"
+
+	"gin-artweb/internal/model/common"
+	"gin-artweb/internal/shared/database"
+)
+
+// 计划任务的交易日历选项
+const (
+	CalendarModeNone        = ""             // 不受交易日历限制
+	CalendarModeTradingDay  = "trading_day"  // 仅交易日执行(排除周末和节假日)
+	CalendarModeSkipHoliday = "skip_holiday" // 跳过节假日, 周末照常执行
+)
+
+// 计划任务被跳过的原因
+const (
+	SkipReasonWeekend = "weekend" // 周末
+	SkipReasonHoliday = "holiday" // 节假日
+)
+
+// CalendarDateLayout 交易日历中日期的格式
+const CalendarDateLayout = time.DateOnly
+
+// TradingHolidayModel 交易日历中的节假日(休市日)
+type TradingHolidayModel struct {
+	database.StandardModel
+	Date     string `gorm:"column:date;type:varchar(10);not null;uniqueIndex;comment:日期" json:"date"`
+	Year     int    `gorm:"column:year;not null;index;comment:年份" json:"year"`
+	Name     string `gorm:"column:name;type:varchar(50);comment:节假日名称" json:"name"`
+	Username string `gorm:"column:username;type:varchar(50);comment:用户名" json:"username"`
+}
+
+func (m *TradingHolidayModel) TableName() string {
+	return "jobs_trading_holiday"
+}
+
+func (m *TradingHolidayModel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if m == nil {
+		return nil
+	}
+	if err := m.StandardModel.MarshalLogObject(enc); err != nil {
+		return err
+	}
+	enc.AddString("date", m.Date)
+	enc.AddInt("year", m.Year)
+	enc.AddString("name", m.Name)
+	enc.AddString("username", m.Username)
+	return nil
+}
+
+// ScheduleSkipModel 计划任务因交易日历被跳过的记录
+type ScheduleSkipModel struct {
+	database.BaseModel
+	ScheduleID   uint32    `gorm:"column:schedule_id;not null;index;comment:计划任务ID" json:"schedule_id"`
+	ScheduleName string    `gorm:"column:schedule_name;type:varchar(50);comment:计划任务名称" json:"schedule_name"`
+	CalendarMode string    `gorm:"column:calendar_mode;type:varchar(20);comment:交易日历选项" json:"calendar_mode"`
+	Reason       string    `gorm:"column:reason;type:varchar(20);comment:跳过原因" json:"reason"`
+	Detail       string    `gorm:"column:detail;type:varchar(50);comment:跳过说明" json:"detail"`
+	SkippedAt    time.Time `gorm:"column:skipped_at;index;comment:跳过时间" json:"skipped_at"`
+}
+
+func (m *ScheduleSkipModel) TableName() string {
+	return "jobs_schedule_skip"
+}
+
+func (m *ScheduleSkipModel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if m == nil {
+		return nil
+	}
+	if err := m.BaseModel.MarshalLogObject(enc); err != nil {
+		return err
+	}
+	enc.AddUint32("schedule_id", m.ScheduleID)
+	enc.AddString("schedule_name", m.ScheduleName)
+	enc.AddString("calendar_mode", m.CalendarMode)
+	enc.AddString("reason", m.Reason)
+	enc.AddString("detail", m.Detail)
+	enc.AddTime("skipped_at", m.SkippedAt)
+	return nil
+}
+
+// TradingHolidayItem 导入的单个节假日
+type TradingHolidayItem struct {
+	// 日期(YYYY-MM-DD)
+	Date string `json:"date" binding:"required,datetime=2006-01-02" example:"2025-10-01"`
+
+	// 节假日名称
+	Name string `json:"name" binding:"omitempty,max=50" example:"国庆节"`
+}
+
+// ImportTradingCalendarRequest 用于导入交易日历的请求结构体
+// 导入时整体替换该年份的节假日
+//
+// swagger:model ImportTradingCalendarRequest
+type ImportTradingCalendarRequest struct {
+	// 年份
+	Year int `json:"year" binding:"required,gte=2000,lte=2100" example:"2025"`
+
+	// 节假日列表
+	Holidays []TradingHolidayItem `json:"holidays" binding:"required,max=366,dive"`
+}
+
+// ListTradingHolidayRequest 用于查询交易日历节假日的请求结构体
+//
+// swagger:model ListTradingHolidayRequest
+type ListTradingHolidayRequest struct {
+	common.BaseModelQuery
+
+	// 年份
+	Year int `form:"year" binding:"omitempty,gte=2000,lte=2100"`
+
+	// 节假日名称
+	Name string `form:"name" binding:"omitempty,max=50"`
+}
+
+func (req *ListTradingHolidayRequest) Query() (int, int, map[string]any) {
+	page, size, query := req.BaseModelQuery.QueryMap(10)
+	if req.Year != 0 {
+		query["year = ?"] = req.Year
+	}
+	if req.Name != "" {
+		query["name like ?"] = "%" + req.Name + "%"
+	}
+	return page, size, query
+}
+
+// CheckTradingDayRequest 用于查询指定日期是否为交易日的请求结构体
+//
+// swagger:model CheckTradingDayRequest
+type CheckTradingDayRequest struct {
+	// 日期(YYYY-MM-DD), 默认当天
+	Date string `form:"date" binding:"omitempty,datetime=2006-01-02"`
+}
+
+// ListScheduleSkipRequest 用于查询计划任务跳过记录的请求结构体
+//
+// swagger:model ListScheduleSkipRequest
+type ListScheduleSkipRequest struct {
+	common.BaseModelQuery
+
+	// 计划任务ID
+	ScheduleID uint32 `form:"schedule_id" binding:"omitempty,gt=0"`
+
+	// 跳过原因
+	Reason string `form:"reason" binding:"omitempty,oneof=weekend holiday"`
+}
+
+func (req *ListScheduleSkipRequest) Query() (int, int, map[string]any) {
+	page, size, query := req.BaseModelQuery.QueryMap(10)
+	if req.ScheduleID != 0 {
+		query["schedule_id = ?"] = req.ScheduleID
+	}
+	if req.Reason != "" {
+		query["reason = ?"] = req.Reason
+	}
+	return page, size, query
+}
+
+type TradingHolidayOut struct {
+	// ID
+	ID uint32 `json:"id" example:"1"`
+
+	// 日期
+	Date string `json:"date" example:"2025-10-01"`
+
+	// 年份
+	Year int `json:"year" example:"2025"`
+
+	// 节假日名称
+	Name string `json:"name" example:"国庆节"`
+
+	// 用户名
+	Username string `json:"username" example:"admin"`
+}
+
+type TradingDayOut struct {
+	// 日期
+	Date string `json:"date" example:"2025-10-01"`
+
+	// 是否为交易日
+	IsTradingDay bool `json:"is_trading_day" example:"false"`
+
+	// 是否为节假日
+	IsHoliday bool `json:"is_holiday" example:"true"`
+
+	// 节假日名称
+	HolidayName string `json:"holiday_name" example:"国庆节"`
+}
+
+type ScheduleSkipOut struct {
+	// ID
+	ID uint32 `json:"id" example:"1"`
+
+	// 计划任务ID
+	ScheduleID uint32 `json:"schedule_id" example:"1"`
+
+	// 计划任务名称
+	ScheduleName string `json:"schedule_name" example:"oes日初"`
+
+	// 交易日历选项
+	CalendarMode string `json:"calendar_mode" example:"trading_day"`
+
+	// 跳过原因(weekend/holiday)
+	Reason string `json:"reason" example:"holiday"`
+
+	// 跳过说明
+	Detail string `json:"detail" example:"国庆节"`
+
+	// 跳过时间
+	SkippedAt string `json:"skipped_at" example:"2025-10-01 08:00:00"`
+}
+
+// PagTradingHolidayReply 交易日历节假日的分页响应结构
+type PagTradingHolidayReply = common.APIReply[*common.Pag[TradingHolidayOut]]
+
+// TradingDayReply 交易日查询响应结构
+type TradingDayReply = common.APIReply[TradingDayOut]
+
+// PagScheduleSkipReply 计划任务跳过记录的分页响应结构
+type PagScheduleSkipReply = common.APIReply[*common.Pag[ScheduleSkipOut]]
+
+func TradingHolidayToOut(
+	m TradingHolidayModel,
+) *TradingHolidayOut {
+	return &TradingHolidayOut{
+		ID:       m.ID,
+		Date:     m.Date,
+		Year:     m.Year,
+		Name:     m.Name,
+		Username: m.Username,
+	}
+}
+
+func ListTradingHolidayToOut(
+	rms *[]TradingHolidayModel,
+) *[]TradingHolidayOut {
+	if rms == nil {
+		return &[]TradingHolidayOut{}
+	}
+
+	ms := *rms
+	mso := make([]TradingHolidayOut, 0, len(ms))
+	for _, m := range ms {
+		mso = append(mso, *TradingHolidayToOut(m))
+	}
+	return &mso
+}
+
+func ScheduleSkipToOut(
+	m ScheduleSkipModel,
+) *ScheduleSkipOut {
+	return &ScheduleSkipOut{
+		ID:           m.ID,
+		ScheduleID:   m.ScheduleID,
+		ScheduleName: m.ScheduleName,
+		CalendarMode: m.CalendarMode,
+		Reason:       m.Reason,
+		Detail:       m.Detail,
+		SkippedAt:    m.SkippedAt.Format(time.DateTime),
+	}
+}
+
+func ListScheduleSkipToOut(
+	rms *[]ScheduleSkipModel,
+) *[]ScheduleSkipOut {
+	if rms == nil {
+		return &[]ScheduleSkipOut{}
+	}
+
+	ms := *rms
+	mso := make([]ScheduleSkipOut, 0, len(ms))
+	for _, m := range ms {
+		mso = append(mso, *ScheduleSkipToOut(m))
+	}
+	return &mso
+}
@@ -0,0 +1,45 @@
+package jobs
+
+import "gin-artweb/internal/model/common"
+
+// 绑定oes集群的计划任务在触发时注入的集群上下文环境变量
+const (
+	EnvColonyNum      = "COLONY_NUM"      // 集群号
+	EnvSystemType     = "SYSTEM_TYPE"     // 系统类型
+	EnvMonNodeIP      = "MON_NODE_IP"     // 集群关联的mon节点所在主机的IP地址
+	EnvPackageVersion = "PACKAGE_VERSION" // 集群使用的程序包版本号
+)
+
+// 预览环境变量的来源
+const (
+	EnvSourceColony   = "colony"   // 集群上下文
+	EnvSourceSchedule = "schedule" // 计划任务配置的环境变量
+)
+
+type EnvPreviewItemOut struct {
+	// 变量名
+	Name string `json:"name" example:"COLONY_NUM"`
+
+	// 变量值, 计划任务配置的变量值已掩码
+	Value string `json:"value" example:"01"`
+
+	// 来源(colony: 集群上下文, schedule: 计划任务配置)
+	Source string `json:"source" example:"colony"`
+
+	// 计划任务配置的变量值是否引用了集群上下文变量(${NAME})
+	Templated bool `json:"templated" example:"false"`
+}
+
+type EnvPreviewOut struct {
+	// 计划任务ID
+	ScheduleID uint32 `json:"schedule_id" example:"1"`
+
+	// 绑定的oes集群号
+	Colony string `json:"colony" example:"01"`
+
+	// 触发时注入的环境变量, 按变量名排序
+	Env []EnvPreviewItemOut `json:"env"`
+}
+
+// EnvPreviewReply 计划任务环境变量预览响应结构
+type EnvPreviewReply = common.APIReply[EnvPreviewOut]
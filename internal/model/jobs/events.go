@@ -0,0 +1,21 @@
+package jobs
+
+import "gin-artweb/internal/shared/events"
+
+// JobFinishedEvent 脚本执行结束事件
+type JobFinishedEvent struct {
+	RecordID    uint32 `json:"record_id"`
+	ScriptID    uint32 `json:"script_id"`
+	Project     string `json:"project"`
+	Label       string `json:"label"`
+	Name        string `json:"name"`
+	CommandArgs string `json:"command_args"`
+	TriggerType string `json:"trigger_type"`
+	Status      string `json:"status"`
+	ExitCode    int    `json:"exit_code"`
+	Username    string `json:"username"`
+}
+
+func (e JobFinishedEvent) EventType() string {
+	return events.JobFinished
+}
@@ -0,0 +1,60 @@
+package jobs
+
+import "encoding/json"
+
+// 脚本执行的隔离方式
+const (
+	IsolationNone      = ""          // 不隔离, 以服务的权限直接执行
+	IsolationContainer = "container" // 在容器中执行
+	IsolationCgroup    = "cgroup"    // 在限制CPU和内存的cgroup中执行
+)
+
+// 脚本执行失败的原因, 区分脚本自身出错和超出资源限制被终止
+const (
+	FailureReasonOOMKilled   = "oom_killed"   // 超出内存限制被终止
+	FailureReasonLimitKilled = "limit_killed" // 超出进程数等其他资源限制被终止
+)
+
+// IsolationSpec 脚本执行的隔离配置, 可以在脚本和计划任务上配置, 计划任务的配置优先
+type IsolationSpec struct {
+	// 隔离方式(container: 容器, cgroup: 限制资源), 为空时不隔离
+	Mode string `json:"mode" binding:"omitempty,oneof=container cgroup"`
+
+	// 容器镜像, 为空时使用配置的默认镜像, 仅container方式
+	Image string `json:"image,omitempty" binding:"omitempty,max=200"`
+
+	// CPU核数上限, 为0时不限制
+	CPUs float64 `json:"cpus,omitempty" binding:"omitempty,gt=0,max=256"`
+
+	// 内存上限(MB), 为0时不限制
+	MemoryMB int `json:"memory_mb,omitempty" binding:"omitempty,min=4,max=1048576"`
+
+	// 进程数上限, 为0时不限制
+	PidsLimit int `json:"pids_limit,omitempty" binding:"omitempty,min=1,max=65535"`
+
+	// 执行用户, 为空时使用配置的默认用户, 只能使用配置允许的用户
+	User string `json:"user,omitempty" binding:"omitempty,max=50"`
+}
+
+// Enabled 是否隔离执行
+func (s *IsolationSpec) Enabled() bool {
+	return s != nil && s.Mode != IsolationNone
+}
+
+// DecodeIsolation 解析隔离配置, 为空或内容无效时返回nil
+func DecodeIsolation(s string) *IsolationSpec {
+	var spec IsolationSpec
+	if s == "" || json.Unmarshal([]byte(s), &spec) != nil || !spec.Enabled() {
+		return nil
+	}
+	return &spec
+}
+
+// EncodeIsolation 隔离配置编码为JSON对象, 不隔离时返回空字符串
+func EncodeIsolation(spec *IsolationSpec) string {
+	if !spec.Enabled() {
+		return ""
+	}
+	b, _ := json.Marshal(spec)
+	return string(b)
+}
@@ -0,0 +1,93 @@
+package jobs
+
+import "encoding/json"
+
+// 脚本参数类型
+const (
+	ParamTypeString = "string" // 字符串
+	ParamTypeInt    = "int"    // 整数
+	ParamTypeBool   = "bool"   // 布尔值, 取值true或false
+	ParamTypeDate   = "date"   // 日期, 格式为2006-01-02, 默认值可以为today表示触发当天
+	ParamTypeEnum   = "enum"   // 枚举, 取值必须在enum中
+)
+
+// 脚本参数传入方式
+const (
+	ParamInjectEnv = "env" // 环境变量PARAM_<参数名大写>
+	ParamInjectArg = "arg" // 追加在命令行参数之后的--<参数名>=<值>
+)
+
+// ParamEnvPrefix 以环境变量传入的参数名前缀, 避免覆盖PATH等系统环境变量
+const ParamEnvPrefix = "PARAM_"
+
+// ScriptParam 脚本参数定义
+type ScriptParam struct {
+	// 参数名, 小写字母开头, 只能包含小写字母、数字和下划线
+	Name string `json:"name" example:"colony_num"`
+
+	// 显示名称
+	Label string `json:"label,omitempty" example:"集群号"`
+
+	// 类型(string, int, bool, date, enum)
+	Type string `json:"type" example:"enum"`
+
+	// 是否必填
+	Required bool `json:"required" example:"true"`
+
+	// 默认值
+	Default string `json:"default,omitempty" example:"01"`
+
+	// 可选值, 类型为enum时必填
+	Enum []string `json:"enum,omitempty" example:"01,02"`
+
+	// 传入方式(env, arg), 默认为env
+	Inject string `json:"inject,omitempty" example:"env"`
+}
+
+// ScriptParamValue 触发时解析后的参数值, 记录在执行记录中用于审计
+type ScriptParamValue struct {
+	// 参数名
+	Name string `json:"name" example:"colony_num"`
+
+	// 参数值
+	Value string `json:"value" example:"01"`
+
+	// 传入方式(env, arg)
+	Inject string `json:"inject" example:"env"`
+}
+
+// DecodeScriptParams 解析脚本的参数定义, 内容无效时返回nil
+func DecodeScriptParams(s string) []ScriptParam {
+	var params []ScriptParam
+	if s == "" || json.Unmarshal([]byte(s), &params) != nil {
+		return nil
+	}
+	return params
+}
+
+// DecodeScriptParamValues 解析执行记录的参数值, 内容无效时返回nil
+func DecodeScriptParamValues(s string) []ScriptParamValue {
+	var values []ScriptParamValue
+	if s == "" || json.Unmarshal([]byte(s), &values) != nil {
+		return nil
+	}
+	return values
+}
+
+// DecodeParamValues 解析计划任务的参数值(JSON对象), 内容无效时返回nil
+func DecodeParamValues(s string) map[string]string {
+	var values map[string]string
+	if s == "" || json.Unmarshal([]byte(s), &values) != nil {
+		return nil
+	}
+	return values
+}
+
+// EncodeParamValues 参数值编码为JSON对象, 没有参数时返回空字符串
+func EncodeParamValues(values map[string]string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	b, _ := json.Marshal(values)
+	return string(b)
+}
@@ -10,20 +10,56 @@ import (
 	"gin-artweb/internal/shared/database"
 )
 
+// 脚本执行记录状态
+const (
+	RecordStatusPending     = 0 // 待执行
+	RecordStatusRunning     = 1 // 执行中
+	RecordStatusSuccess     = 2 // 成功
+	RecordStatusFailed      = 3 // 失败
+	RecordStatusTimeout     = 4 // 超时
+	RecordStatusCrashed     = 5 // 崩溃
+	RecordStatusInterrupted = 6 // 服务关闭时被中断
+)
+
+var recordStatusTexts = map[int]string{
+	RecordStatusPending:     "pending",
+	RecordStatusRunning:     "running",
+	RecordStatusSuccess:     "success",
+	RecordStatusFailed:      "failed",
+	RecordStatusTimeout:     "timeout",
+	RecordStatusCrashed:     "crashed",
+	RecordStatusInterrupted: "interrupted",
+}
+
+// RecordStatusText 返回执行记录状态的英文标识, 用于指标标签
+func RecordStatusText(status int) string {
+	if text, ok := recordStatusTexts[status]; ok {
+		return text
+	}
+	return "unknown"
+}
+
 type ScriptRecordModel struct {
 	database.StandardModel
-	TriggerType  string      `gorm:"column:trigger_type;type:varchar(20);comment:触发类型(cron/api)" json:"trigger_type"`
-	Status       int         `gorm:"column:status;type:tinyint;not null;default:0;comment:执行状态(0-待执行,1-执行中,2-成功,3-失败,4-超时,5-崩溃)" json:"status"`
-	ExitCode     int         `gorm:"column:exit_code;comment:退出码" json:"exit_code"`
-	EnvVars      string      `gorm:"column:env_vars;type:json;comment:环境变量(JSON对象)" json:"env_vars"`
-	CommandArgs  string      `gorm:"column:command_args;type:varchar(254);comment:命令行参数(JSON数组)" json:"command_args"`
-	WorkDir      string      `gorm:"column:work_dir;type:varchar(255);comment:工作目录" json:"work_dir"`
-	Timeout      int         `gorm:"column:timeout;type:int;not null;default:300;comment:超时时间(秒)" json:"timeout"`
-	LogName      string      `gorm:"column:log_name;type:varchar(255);comment:日志文件路径" json:"log_name"`
-	ErrorMessage string      `gorm:"column:error_message;type:text;comment:错误信息" json:"error_message"`
-	Username     string      `gorm:"column:username;type:varchar(50);comment:用户名" json:"username"`
-	ScriptID     uint32      `gorm:"column:script_id;not null;index;comment:脚本ID" json:"script_id"`
-	Script       ScriptModel `gorm:"foreignKey:ScriptID;references:ID;constraint:OnDelete:CASCADE" json:"script"`
+	TriggerType   string      `gorm:"column:trigger_type;type:varchar(20);comment:触发类型(cron/api)" json:"trigger_type"`
+	Status        int         `gorm:"column:status;type:tinyint;not null;default:0;comment:执行状态(0-待执行,1-执行中,2-成功,3-失败,4-超时,5-崩溃,6-中断)" json:"status"`
+	ExitCode      int         `gorm:"column:exit_code;comment:退出码" json:"exit_code"`
+	EnvVars       string      `gorm:"column:env_vars;type:text;serializer:encrypted;comment:环境变量(JSON对象)" json:"env_vars"`
+	CommandArgs   string      `gorm:"column:command_args;type:varchar(254);comment:命令行参数(JSON数组)" json:"command_args"`
+	Params        string      `gorm:"column:params;type:text;comment:解析后的脚本参数(JSON数组)" json:"params"`
+	WorkDir       string      `gorm:"column:work_dir;type:varchar(255);comment:工作目录" json:"work_dir"`
+	Timeout       int         `gorm:"column:timeout;type:int;not null;default:300;comment:超时时间(秒)" json:"timeout"`
+	LogName       string      `gorm:"column:log_name;type:varchar(255);comment:日志文件路径" json:"log_name"`
+	ErrorMessage  string      `gorm:"column:error_message;type:text;comment:错误信息" json:"error_message"`
+	Username      string      `gorm:"column:username;type:varchar(50);comment:用户名" json:"username"`
+	ScriptID      uint32      `gorm:"column:script_id;not null;index;comment:脚本ID" json:"script_id"`
+	InterruptedAt *time.Time  `gorm:"column:interrupted_at;comment:中断时间" json:"interrupted_at"`
+	ResumeOf      uint32      `gorm:"column:resume_of;index;comment:续跑的原执行记录ID" json:"resume_of"`
+	ResumeCount   int         `gorm:"column:resume_count;not null;default:0;comment:续跑次数" json:"resume_count"`
+	ResumedID     uint32      `gorm:"column:resumed_id;comment:续跑生成的执行记录ID" json:"resumed_id"`
+	Isolation     string      `gorm:"column:isolation;type:text;comment:实际使用的隔离执行配置(JSON对象)" json:"isolation"`
+	FailureReason string      `gorm:"column:failure_reason;type:varchar(20);comment:失败原因(oom_killed/limit_killed)" json:"failure_reason"`
+	Script        ScriptModel `gorm:"foreignKey:ScriptID;references:ID;constraint:OnDelete:CASCADE" json:"script"`
 }
 
 func (m *ScriptRecordModel) TableName() string {
@@ -40,46 +76,63 @@ func (m *ScriptRecordModel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 	enc.AddString("trigger_type", m.TriggerType)
 	enc.AddInt("status", m.Status)
 	enc.AddInt("exit_code", m.ExitCode)
-	enc.AddString("env_vars", m.EnvVars)
+	enc.AddString("env_vars", common.MaskEnvVars(m.EnvVars))
 	enc.AddString("command_args", m.CommandArgs)
+	enc.AddString("params", m.Params)
 	enc.AddString("work_dir", m.WorkDir)
 	enc.AddString("log_path", m.LogName)
 	enc.AddString("username", m.Username)
 	enc.AddUint32("script_id", m.ScriptID)
+	enc.AddUint32("resume_of", m.ResumeOf)
+	enc.AddInt("resume_count", m.ResumeCount)
+	enc.AddString("isolation", m.Isolation)
+	enc.AddString("failure_reason", m.FailureReason)
 	return nil
 }
 
 type ExecuteRequest struct {
-	TriggerType string `json:"trigger_type"`
-	ScriptID    uint32 `json:"script_id"`
-	CommandArgs string `json:"command_args"`
-	EnvVars     string `json:"env_vars"`
-	Timeout     int    `json:"timeout"`
-	WorkDir     string `json:"work_dir"`
-	Username    string `json:"username"`
+	TriggerType string            `json:"trigger_type"`
+	ScriptID    uint32            `json:"script_id"`
+	CommandArgs string            `json:"command_args"`
+	EnvVars     string            `json:"env_vars"`
+	Params      map[string]string `json:"params"`
+	Timeout     int               `json:"timeout"`
+	WorkDir     string            `json:"work_dir"`
+	Username    string            `json:"username"`
+	ResumeOf    uint32            `json:"resume_of"`
+	ResumeCount int               `json:"resume_count"`
+	Isolation   string            `json:"isolation"` // 计划任务的隔离执行配置, 为空时使用脚本的配置
 }
 
 type TaskInfo struct {
-	Status   int
-	ExitCode int
-	ErrMSG   string
-	Error    error
-	LogFile  *os.File
+	Status        int
+	ExitCode      int
+	ErrMSG        string
+	FailureReason string
+	Error         error
+	LogFile       *os.File
+	InterruptedAt *time.Time
 }
 
 func (t *TaskInfo) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 	enc.AddInt("status", t.Status)
 	enc.AddInt("exit_code", t.ExitCode)
 	enc.AddString("error_message", t.ErrMSG)
+	enc.AddString("failure_reason", t.FailureReason)
 	return nil
 }
 
 func (t *TaskInfo) ToMap() map[string]any {
-	return map[string]any{
-		"status":        t.Status,
-		"exit_code":     t.ExitCode,
-		"error_message": t.ErrMSG,
+	data := map[string]any{
+		"status":         t.Status,
+		"exit_code":      t.ExitCode,
+		"error_message":  t.ErrMSG,
+		"failure_reason": t.FailureReason,
+	}
+	if t.InterruptedAt != nil {
+		data["interrupted_at"] = *t.InterruptedAt
 	}
+	return data
 }
 
 // CreateScriptRecordRequest 用于创建计划任务的请求结构体
@@ -95,6 +148,9 @@ type CreateScriptRecordRequest struct {
 	// 环境变量 (JSON对象)
 	EnvVars string `json:"env_vars" form:"env_vars" binding:"omitempty"`
 
+	// 脚本参数, 按脚本的参数定义校验, 未提交的参数使用默认值
+	Params map[string]string `json:"params" binding:"omitempty"`
+
 	// 超时时间(秒)
 	Timeout int `json:"timeout" form:"timeout" binding:"required"`
 
@@ -145,6 +201,21 @@ func (req *ListScriptRecordRequest) Query() (int, int, map[string]any) {
 	return page, size, query
 }
 
+// ScriptRecordFilterFields 脚本执行记录列表允许使用filter参数过滤的字段
+var ScriptRecordFilterFields = database.FilterFields{
+	"id":             {Column: "id", Type: database.FilterInt},
+	"trigger_type":   {Column: "trigger_type", Type: database.FilterString},
+	"status":         {Column: "status", Type: database.FilterInt},
+	"exit_code":      {Column: "exit_code", Type: database.FilterInt},
+	"username":       {Column: "username", Type: database.FilterString},
+	"script_id":      {Column: "script_id", Type: database.FilterInt},
+	"interrupted_at": {Column: "interrupted_at", Type: database.FilterTime},
+	"resume_of":      {Column: "resume_of", Type: database.FilterInt},
+	"failure_reason": {Column: "failure_reason", Type: database.FilterString},
+	"created_at":     {Column: "created_at", Type: database.FilterTime},
+	"updated_at":     {Column: "updated_at", Type: database.FilterTime},
+}
+
 type ScriptRecordStandardOut struct {
 	// 脚本执行记录ID
 	ID uint32 `json:"id" example:"1"`
@@ -158,18 +229,21 @@ type ScriptRecordStandardOut struct {
 	// 触发类型(cron/api)
 	TriggerType string `json:"trigger_type" example:"cron"`
 
-	// 执行状态(0-待执行,1-执行中,2-成功,3-失败,4-超时,5-崩溃)
+	// 执行状态(0-待执行,1-执行中,2-成功,3-失败,4-超时,5-崩溃,6-中断)
 	Status int `json:"status" example:"2"`
 
 	// 退出码
 	ExitCode int `json:"exit_code" example:"0"`
 
-	// 环境变量(JSON对象)
-	EnvVars string `json:"env_vars,omitempty" example:"{\"ENV\":\"production\"}"`
+	// 环境变量(JSON对象), 变量值已掩码
+	EnvVars string `json:"env_vars,omitempty" example:"{\"ENV\":\"******\"}"`
 
 	// 命令行参数
 	CommandArgs string `json:"command_args,omitempty" example:"[\"--verbose\"]"`
 
+	// 解析后的脚本参数
+	Params []ScriptParamValue `json:"params,omitempty"`
+
 	// 工作目录
 	WorkDir string `json:"work_dir,omitempty" example:"/home/user/work"`
 
@@ -181,6 +255,21 @@ type ScriptRecordStandardOut struct {
 
 	// 用户名
 	Username string `json:"username" example:"admin"`
+
+	// 续跑的原执行记录ID
+	ResumeOf uint32 `json:"resume_of,omitempty" example:"0"`
+
+	// 续跑次数
+	ResumeCount int `json:"resume_count" example:"0"`
+
+	// 续跑生成的执行记录ID
+	ResumedID uint32 `json:"resumed_id,omitempty" example:"0"`
+
+	// 实际使用的隔离执行配置, 不隔离时为空
+	Isolation *IsolationSpec `json:"isolation,omitempty"`
+
+	// 失败原因(oom_killed: 超出内存限制, limit_killed: 超出其他资源限制), 不是因资源限制失败时为空
+	FailureReason string `json:"failure_reason,omitempty" example:"oom_killed"`
 }
 
 type ScriptRecordDetailOut struct {
@@ -196,6 +285,15 @@ type ScriptRecordReply = common.APIReply[ScriptRecordDetailOut]
 // PagScriptRecordReply 程序包的分页响应结构
 type PagScriptRecordReply = common.APIReply[*common.Pag[ScriptRecordDetailOut]]
 
+// EnvVarsOut 环境变量明文
+type EnvVarsOut struct {
+	// 环境变量(JSON对象)
+	EnvVars string `json:"env_vars" example:"{\"ENV\":\"production\"}"`
+}
+
+// EnvVarsReply 环境变量明文的响应结构
+type EnvVarsReply = common.APIReply[EnvVarsOut]
+
 // 实时日志流响应结构
 type RealTimeLogResponse struct {
 	Line string `json:"line"`
@@ -205,18 +303,24 @@ func ScriptRecordToStandardOut(
 	m ScriptRecordModel,
 ) *ScriptRecordStandardOut {
 	return &ScriptRecordStandardOut{
-		ID:           m.ID,
-		CreatedAt:    m.CreatedAt.Format(time.DateTime),
-		UpdatedAt:    m.UpdatedAt.Format(time.DateTime),
-		TriggerType:  m.TriggerType,
-		Status:       m.Status,
-		ExitCode:     m.ExitCode,
-		EnvVars:      m.EnvVars,
-		CommandArgs:  m.CommandArgs,
-		Timeout:      m.Timeout,
-		WorkDir:      m.WorkDir,
-		ErrorMessage: m.ErrorMessage,
-		Username:     m.Username,
+		ID:            m.ID,
+		CreatedAt:     m.CreatedAt.Format(time.DateTime),
+		UpdatedAt:     m.UpdatedAt.Format(time.DateTime),
+		TriggerType:   m.TriggerType,
+		Status:        m.Status,
+		ExitCode:      m.ExitCode,
+		EnvVars:       common.MaskEnvVars(m.EnvVars),
+		CommandArgs:   m.CommandArgs,
+		Params:        DecodeScriptParamValues(m.Params),
+		Timeout:       m.Timeout,
+		WorkDir:       m.WorkDir,
+		ErrorMessage:  m.ErrorMessage,
+		Username:      m.Username,
+		ResumeOf:      m.ResumeOf,
+		ResumeCount:   m.ResumeCount,
+		ResumedID:     m.ResumedID,
+		Isolation:     DecodeIsolation(m.Isolation),
+		FailureReason: m.FailureReason,
 	}
 }
 
@@ -15,13 +15,17 @@ type ScheduleModel struct {
 	Name          string      `gorm:"column:name;type:varchar(50);not null;uniqueIndex;comment:名称" json:"name"`
 	Specification string      `gorm:"column:specification;type:text;comment:条件" json:"specification"`
 	IsEnabled     bool        `gorm:"column:is_enabled;type:boolean;comment:是否启用" json:"is_enabled"`
-	EnvVars       string      `gorm:"column:env_vars;type:json;comment:环境变量(JSON对象)" json:"env_vars"`
+	EnvVars       string      `gorm:"column:env_vars;type:text;serializer:encrypted;comment:环境变量(JSON对象)" json:"env_vars"`
 	CommandArgs   string      `gorm:"column:command_args;type:varchar(254);comment:命令行参数" json:"command_args"`
+	Params        string      `gorm:"column:params;type:text;comment:脚本参数(JSON对象)" json:"params"`
 	WorkDir       string      `gorm:"column:work_dir;type:varchar(255);comment:工作目录" json:"work_dir"`
 	Timeout       int         `gorm:"column:timeout;type:int;not null;default:300;comment:超时时间(秒)" json:"timeout"`
 	IsRetry       bool        `gorm:"column:is_retry;type:boolean;default:false;comment:是否启用重试" json:"is_retry"`
 	RetryInterval int         `gorm:"column:retry_interval;type:int;default:60;comment:重试间隔(秒)" json:"retry_interval"`
 	MaxRetries    int         `gorm:"column:max_retries;type:int;default:3;comment:最大重试次数" json:"max_retries"`
+	CalendarMode  string      `gorm:"column:calendar_mode;type:varchar(20);default:'';comment:交易日历选项" json:"calendar_mode"`
+	Isolation     string      `gorm:"column:isolation;type:text;comment:隔离执行配置(JSON对象), 为空时使用脚本的配置" json:"isolation"`
+	Colony        string      `gorm:"column:colony;type:varchar(2);index;comment:绑定的oes集群号" json:"colony"`
 	Username      string      `gorm:"column:username;type:varchar(50);comment:用户名" json:"username"`
 	ScriptID      uint32      `gorm:"column:script_id;not null;index;comment:计划任务ID" json:"script_id"`
 	Script        ScriptModel `gorm:"foreignKey:ScriptID;references:ID" json:"script"`
@@ -41,10 +45,14 @@ func (m *ScheduleModel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 	enc.AddString("name", m.Name)
 	enc.AddString("specification", m.Specification)
 	enc.AddBool("is_enabled", m.IsEnabled)
-	enc.AddString("env_vars", m.EnvVars)
+	enc.AddString("env_vars", common.MaskEnvVars(m.EnvVars))
 	enc.AddString("command_args", m.CommandArgs)
+	enc.AddString("params", m.Params)
 	enc.AddString("work_dir", m.WorkDir)
 	enc.AddInt("timeout", m.Timeout)
+	enc.AddString("calendar_mode", m.CalendarMode)
+	enc.AddString("isolation", m.Isolation)
+	enc.AddString("colony", m.Colony)
 	enc.AddString("username", m.Username)
 	enc.AddUint32("script_id", m.ScriptID)
 	return nil
@@ -76,6 +84,9 @@ type CreateScheduleRequest struct {
 	// 命令行参数
 	CommandArgs string `json:"command_args,omitempty"`
 
+	// 脚本参数, 按脚本的参数定义校验, 日期参数的默认值today在每次触发时取当天
+	Params map[string]string `json:"params,omitempty"`
+
 	// 工作目录
 	WorkDir string `json:"work_dir,omitempty"`
 
@@ -91,6 +102,15 @@ type CreateScheduleRequest struct {
 	// 最大重试次数
 	MaxRetries int `json:"max_retries"`
 
+	// 交易日历选项(trading_day: 仅交易日执行, skip_holiday: 跳过节假日), 为空时不受限制
+	CalendarMode string `json:"calendar_mode,omitempty" binding:"omitempty,oneof=trading_day skip_holiday"`
+
+	// 隔离执行配置, 为空时使用脚本的配置
+	Isolation *IsolationSpec `json:"isolation,omitempty"`
+
+	// 绑定的oes集群号, 触发时注入集群上下文的环境变量(COLONY_NUM、SYSTEM_TYPE、MON_NODE_IP、PACKAGE_VERSION)
+	Colony string `json:"colony,omitempty" binding:"omitempty,max=2"`
+
 	// 脚本ID
 	ScriptID uint32 `json:"script_id" binding:"required"`
 }
@@ -114,6 +134,9 @@ type UpdateScheduleRequest struct {
 	// 命令行参数
 	CommandArgs string `json:"command_args,omitempty"`
 
+	// 脚本参数, 按脚本的参数定义校验, 日期参数的默认值today在每次触发时取当天
+	Params map[string]string `json:"params,omitempty"`
+
 	// 工作目录
 	WorkDir string `json:"work_dir,omitempty"`
 
@@ -129,6 +152,15 @@ type UpdateScheduleRequest struct {
 	// 最大重试次数
 	MaxRetries int `json:"max_retries"`
 
+	// 交易日历选项(trading_day: 仅交易日执行, skip_holiday: 跳过节假日), 为空时不受限制
+	CalendarMode string `json:"calendar_mode,omitempty" binding:"omitempty,oneof=trading_day skip_holiday"`
+
+	// 隔离执行配置, 为空时使用脚本的配置
+	Isolation *IsolationSpec `json:"isolation,omitempty"`
+
+	// 绑定的oes集群号, 触发时注入集群上下文的环境变量(COLONY_NUM、SYSTEM_TYPE、MON_NODE_IP、PACKAGE_VERSION)
+	Colony string `json:"colony,omitempty" binding:"omitempty,max=2"`
+
 	// 脚本ID
 	ScriptID uint32 `json:"script_id" binding:"required"`
 }
@@ -189,12 +221,15 @@ type ScheduleStandardOut struct {
 	// 是否启用
 	IsEnabled bool `json:"is_enabled" example:"true"`
 
-	// 环境变量(JSON对象)
-	EnvVars string `json:"env_vars" example:"{}"`
+	// 环境变量(JSON对象), 变量值已掩码
+	EnvVars string `json:"env_vars" example:"{\"ENV\":\"******\"}"`
 
 	// 命令行参数
 	CommandArgs string `json:"command_args" example:""`
 
+	// 脚本参数
+	Params map[string]string `json:"params,omitempty"`
+
 	// 工作目录
 	WorkDir string `json:"work_dir" example:""`
 
@@ -210,6 +245,15 @@ type ScheduleStandardOut struct {
 	// 最大重试次数
 	MaxRetries int `json:"max_retries"`
 
+	// 交易日历选项
+	CalendarMode string `json:"calendar_mode" example:"trading_day"`
+
+	// 隔离执行配置, 为空时使用脚本的配置
+	Isolation *IsolationSpec `json:"isolation,omitempty"`
+
+	// 绑定的oes集群号
+	Colony string `json:"colony" example:"01"`
+
 	// 用户名
 	Username string `json:"username" example:"admin"`
 }
@@ -237,13 +281,17 @@ func ScheduleToStandardOut(
 		Name:          m.Name,
 		Specification: m.Specification,
 		IsEnabled:     m.IsEnabled,
-		EnvVars:       m.EnvVars,
+		EnvVars:       common.MaskEnvVars(m.EnvVars),
 		CommandArgs:   m.CommandArgs,
+		Params:        DecodeParamValues(m.Params),
 		WorkDir:       m.WorkDir,
 		Timeout:       m.Timeout,
 		IsRetry:       m.IsRetry,
 		MaxRetries:    m.MaxRetries,
 		RetryInterval: m.RetryInterval,
+		CalendarMode:  m.CalendarMode,
+		Isolation:     DecodeIsolation(m.Isolation),
+		Colony:        m.Colony,
 		Username:      m.Username,
 	}
 }
@@ -20,6 +20,10 @@ type ScriptModel struct {
 	Language  string `gorm:"column:language;type:varchar(50);comment:脚本语言" json:"language"`
 	Status    bool   `gorm:"column:status;type:boolean;comment:是否启用" json:"status"`
 	IsBuiltin bool   `gorm:"column:is_builtin;type:boolean;comment:是否是内置脚本" json:"is_builtin"`
+	Resumable bool   `gorm:"column:resumable;type:boolean;comment:服务关闭中断后是否在下次启动时续跑" json:"resumable"`
+	Params    string `gorm:"column:params;type:text;comment:参数定义(JSON数组)" json:"params"`
+	Isolation string `gorm:"column:isolation;type:text;comment:隔离执行配置(JSON对象)" json:"isolation"`
+	Artifacts string `gorm:"column:artifacts;type:text;comment:产物文件匹配规则(JSON数组)" json:"artifacts"`
 	Username  string `gorm:"column:username;type:varchar(50);comment:用户名" json:"username"`
 }
 
@@ -41,6 +45,10 @@ func (m *ScriptModel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 	enc.AddString("language", m.Language)
 	enc.AddBool("status", m.Status)
 	enc.AddBool("is_builtin", m.IsBuiltin)
+	enc.AddBool("resumable", m.Resumable)
+	enc.AddString("params", m.Params)
+	enc.AddString("isolation", m.Isolation)
+	enc.AddString("artifacts", m.Artifacts)
 	enc.AddString("username", m.Username)
 	return nil
 }
@@ -63,6 +71,21 @@ type UploadScriptRequest struct {
 
 	// 状态
 	Status bool `form:"status"`
+
+	// 服务关闭中断后是否在下次启动时续跑, 仅适用于可以安全重复执行的脚本
+	Resumable bool `form:"resumable"`
+
+	// 参数定义(JSON数组), 触发执行时按定义校验参数值
+	// example: [{"name":"colony_num","type":"enum","required":true,"enum":["01","02"]},{"name":"trade_date","type":"date","default":"today","inject":"arg"}]
+	Params string `form:"params" binding:"omitempty,max=10000"`
+
+	// 隔离执行配置(JSON对象), 为空时不隔离
+	// example: {"mode":"container","image":"bash:5","cpus":1,"memory_mb":512,"user":"jobs"}
+	Isolation string `form:"isolation" binding:"omitempty,max=1000"`
+
+	// 产物文件匹配规则(JSON数组), 相对于工作目录, 执行结束后收集匹配的文件保存为执行产物
+	// example: ["reports/*.csv","recon_*.xlsx"]
+	Artifacts string `form:"artifacts" binding:"omitempty,max=2000"`
 }
 
 func (req *UploadScriptRequest) MarshalLogObject(enc zapcore.ObjectEncoder) error {
@@ -75,6 +98,9 @@ func (req *UploadScriptRequest) MarshalLogObject(enc zapcore.ObjectEncoder) erro
 	enc.AddString("label", req.Label)
 	enc.AddString("language", req.Language)
 	enc.AddBool("status", req.Status)
+	enc.AddBool("resumable", req.Resumable)
+	enc.AddString("isolation", req.Isolation)
+	enc.AddString("artifacts", req.Artifacts)
 	return nil
 }
 
@@ -167,6 +193,18 @@ type ScriptStandardOut struct {
 	// 是否是内置脚本
 	IsBuiltin bool `json:"is_builtin" example:"true"`
 
+	// 中断后是否续跑
+	Resumable bool `json:"resumable" example:"false"`
+
+	// 参数定义, 用于生成触发执行时的参数表单
+	Params []ScriptParam `json:"params"`
+
+	// 隔离执行配置, 不隔离时为空
+	Isolation *IsolationSpec `json:"isolation,omitempty"`
+
+	// 产物文件匹配规则
+	Artifacts []string `json:"artifacts"`
+
 	// 用户名
 	Username string `json:"username" example:"admin"`
 }
@@ -197,6 +235,10 @@ func ScriptModelToStandardOut(
 		Language:  m.Language,
 		Status:    m.Status,
 		IsBuiltin: m.IsBuiltin,
+		Resumable: m.Resumable,
+		Params:    DecodeScriptParams(m.Params),
+		Isolation: DecodeIsolation(m.Isolation),
+		Artifacts: DecodeArtifactPatterns(m.Artifacts),
 		Username:  m.Username,
 	}
 }
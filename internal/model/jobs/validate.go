@@ -0,0 +1,65 @@
+package jobs
+
+import "gin-artweb/internal/model/common"
+
+// ValidateScriptRequest 用于检查脚本的请求结构体
+//
+// swagger:model ValidateScriptRequest
+type ValidateScriptRequest struct {
+	// 是否在沙箱中试运行, 需要配置试运行沙箱
+	DryRun bool `json:"dry_run"`
+
+	// 试运行的命令参数
+	CommandArgs string `json:"command_args" binding:"omitempty,max=254"`
+}
+
+type ScriptDiagnosticOut struct {
+	// 行号, 0表示与具体行无关
+	Line int `json:"line" example:"3"`
+
+	// 列号, 0表示未知
+	Column int `json:"column" example:"6"`
+
+	// 级别(error, warning, info)
+	Level string `json:"level" example:"warning"`
+
+	// 规则编号
+	Code string `json:"code" example:"SC2086"`
+
+	// 说明
+	Message string `json:"message" example:"Double quote to prevent globbing and word splitting."`
+
+	// 检查来源(builtin, bash, sh, python3, shellcheck)
+	Source string `json:"source" example:"shellcheck"`
+}
+
+type ScriptDryRunOut struct {
+	// 退出码, 超时时为-1
+	ExitCode int `json:"exit_code" example:"0"`
+
+	// 标准输出和标准错误
+	Output string `json:"output" example:"ok"`
+
+	// 输出是否被截断
+	Truncated bool `json:"truncated" example:"false"`
+
+	// 是否超时
+	TimedOut bool `json:"timed_out" example:"false"`
+
+	// 耗时(秒)
+	Duration float64 `json:"duration" example:"0.012"`
+}
+
+type ScriptValidateOut struct {
+	// 是否通过检查, 存在error级别的问题或试运行失败时为false
+	Valid bool `json:"valid" example:"true"`
+
+	// 发现的问题
+	Diagnostics []ScriptDiagnosticOut `json:"diagnostics"`
+
+	// 试运行结果, 未试运行时为空
+	DryRun *ScriptDryRunOut `json:"dry_run,omitempty"`
+}
+
+// ScriptValidateReply 脚本检查响应结构
+type ScriptValidateReply = common.APIReply[ScriptValidateOut]
@@ -0,0 +1,376 @@
+package mds
+
+import (
+	"sort"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+
+	"gin-artweb/internal/model/common"
+	"gin-artweb/internal/shared/database"
+)
+
+// 数据回补及每日回放任务的状态
+const (
+	BackfillStatusPending   = "pending"   // 等待执行
+	BackfillStatusRunning   = "running"   // 执行中
+	BackfillStatusSuccess   = "success"   // 执行成功
+	BackfillStatusFailed    = "failed"    // 执行失败
+	BackfillStatusSkipped   = "skipped"   // 依赖的交易日失败, 已跳过
+	BackfillStatusCancelled = "cancelled" // 已取消
+)
+
+const (
+	// BackfillMaxDays 单次数据回补的最大自然日跨度
+	BackfillMaxDays = 366
+	// BackfillMaxConcurrency 单次数据回补同时回放的最大交易日数
+	BackfillMaxConcurrency = 8
+)
+
+// MdsBackfillRunModel mds数据回补, 按日期范围内的每个交易日回放一次数据
+type MdsBackfillRunModel struct {
+	database.StandardModel
+	MdsColonyID uint32                `gorm:"column:mds_colony_id;not null;index;comment:mds集群ID" json:"mds_colony_id"`
+	ColonyNum   string                `gorm:"column:colony_num;type:varchar(2);comment:集群号" json:"colony_num"`
+	Script      string                `gorm:"column:script;type:varchar(254);comment:回放脚本名称" json:"script"`
+	ScriptID    uint32                `gorm:"column:script_id;comment:回放脚本ID" json:"script_id"`
+	StartDate   string                `gorm:"column:start_date;type:varchar(10);comment:开始日期" json:"start_date"`
+	EndDate     string                `gorm:"column:end_date;type:varchar(10);comment:结束日期" json:"end_date"`
+	Sequential  bool                  `gorm:"column:sequential;type:boolean;comment:是否按日期顺序回放" json:"sequential"`
+	Concurrency int                   `gorm:"column:concurrency;comment:并发数" json:"concurrency"`
+	Timeout     int                   `gorm:"column:timeout;comment:每日回放超时时间(秒)" json:"timeout"`
+	Status      string                `gorm:"column:status;type:varchar(10);not null;default:pending;comment:执行状态" json:"status"`
+	StartedAt   *time.Time            `gorm:"column:started_at;comment:开始时间" json:"started_at"`
+	FinishedAt  *time.Time            `gorm:"column:finished_at;comment:结束时间" json:"finished_at"`
+	Username    string                `gorm:"column:username;type:varchar(50);comment:用户名" json:"username"`
+	Days        []MdsBackfillDayModel `gorm:"foreignKey:RunID;constraint:OnDelete:CASCADE" json:"days"`
+}
+
+func (m *MdsBackfillRunModel) TableName() string {
+	return "mds_backfill_run"
+}
+
+func (m *MdsBackfillRunModel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if m == nil {
+		return nil
+	}
+	if err := m.StandardModel.MarshalLogObject(enc); err != nil {
+		return err
+	}
+	enc.AddUint32("mds_colony_id", m.MdsColonyID)
+	enc.AddString("colony_num", m.ColonyNum)
+	enc.AddString("script", m.Script)
+	enc.AddString("start_date", m.StartDate)
+	enc.AddString("end_date", m.EndDate)
+	enc.AddBool("sequential", m.Sequential)
+	enc.AddInt("concurrency", m.Concurrency)
+	enc.AddString("status", m.Status)
+	enc.AddString("username", m.Username)
+	return nil
+}
+
+// MdsBackfillDayModel 数据回补中单个交易日的回放任务
+type MdsBackfillDayModel struct {
+	database.BaseModel
+	RunID        uint32     `gorm:"column:run_id;not null;index;comment:数据回补ID" json:"run_id"`
+	TradingDay   string     `gorm:"column:trading_day;type:varchar(10);not null;comment:交易日" json:"trading_day"`
+	Sort         int        `gorm:"column:sort;comment:排序" json:"sort"`
+	DependsOn    string     `gorm:"column:depends_on;type:varchar(10);comment:依赖的交易日" json:"depends_on"`
+	Status       string     `gorm:"column:status;type:varchar(10);not null;default:pending;comment:执行状态" json:"status"`
+	RecordID     uint32     `gorm:"column:record_id;comment:脚本执行记录ID" json:"record_id"`
+	Attempts     int        `gorm:"column:attempts;comment:执行次数" json:"attempts"`
+	ErrorMessage string     `gorm:"column:error_message;type:text;comment:错误信息" json:"error_message"`
+	StartedAt    *time.Time `gorm:"column:started_at;comment:开始时间" json:"started_at"`
+	FinishedAt   *time.Time `gorm:"column:finished_at;comment:结束时间" json:"finished_at"`
+}
+
+func (m *MdsBackfillDayModel) TableName() string {
+	return "mds_backfill_day"
+}
+
+func (m *MdsBackfillDayModel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if m == nil {
+		return nil
+	}
+	if err := m.BaseModel.MarshalLogObject(enc); err != nil {
+		return err
+	}
+	enc.AddUint32("run_id", m.RunID)
+	enc.AddString("trading_day", m.TradingDay)
+	enc.AddString("depends_on", m.DependsOn)
+	enc.AddString("status", m.Status)
+	enc.AddUint32("record_id", m.RecordID)
+	enc.AddInt("attempts", m.Attempts)
+	return nil
+}
+
+// CreateMdsBackfillRequest 用于发起数据回补的请求结构体
+//
+// swagger:model CreateMdsBackfillRequest
+type CreateMdsBackfillRequest struct {
+	// mds集群ID
+	MdsColonyID uint32 `json:"mds_colony_id" binding:"required,gt=0"`
+
+	// 开始日期, 格式为2006-01-02
+	StartDate string `json:"start_date" binding:"required,datetime=2006-01-02"`
+
+	// 结束日期, 格式为2006-01-02
+	EndDate string `json:"end_date" binding:"required,datetime=2006-01-02"`
+
+	// 回放脚本名称, 需为mds的内置命令脚本, 执行参数为集群号和交易日
+	Script string `json:"script" binding:"required,max=254"`
+
+	// 是否按日期顺序回放, 开启后每个交易日依赖前一个交易日回放成功
+	Sequential bool `json:"sequential"`
+
+	// 同时回放的交易日数, 默认为1
+	Concurrency int `json:"concurrency" binding:"omitempty,min=1,max=8"`
+
+	// 每日回放的超时时间(秒), 默认为3600
+	Timeout int `json:"timeout" binding:"omitempty,gt=0"`
+}
+
+func (req *CreateMdsBackfillRequest) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if req == nil {
+		return nil
+	}
+	enc.AddUint32("mds_colony_id", req.MdsColonyID)
+	enc.AddString("start_date", req.StartDate)
+	enc.AddString("end_date", req.EndDate)
+	enc.AddString("script", req.Script)
+	enc.AddBool("sequential", req.Sequential)
+	enc.AddInt("concurrency", req.Concurrency)
+	enc.AddInt("timeout", req.Timeout)
+	return nil
+}
+
+// ListMdsBackfillRunRequest 用于查询数据回补列表的请求结构体
+//
+// swagger:model ListMdsBackfillRunRequest
+type ListMdsBackfillRunRequest struct {
+	common.BaseModelQuery
+
+	// mds集群ID
+	MdsColonyID uint32 `form:"mds_colony_id" binding:"omitempty,gt=0"`
+
+	// 集群号
+	ColonyNum string `form:"colony_num" binding:"omitempty,max=2"`
+
+	// 执行状态
+	Status string `form:"status" binding:"omitempty,oneof=pending running success failed cancelled"`
+}
+
+func (req *ListMdsBackfillRunRequest) Query() (int, int, map[string]any) {
+	page, size, query := req.BaseModelQuery.QueryMap(10)
+	if req.MdsColonyID != 0 {
+		query["mds_colony_id = ?"] = req.MdsColonyID
+	}
+	if req.ColonyNum != "" {
+		query["colony_num = ?"] = req.ColonyNum
+	}
+	if req.Status != "" {
+		query["status = ?"] = req.Status
+	}
+	return page, size, query
+}
+
+type MdsBackfillDayOut struct {
+	// 交易日
+	TradingDay string `json:"trading_day" example:"2024-01-02"`
+
+	// 依赖的交易日
+	DependsOn string `json:"depends_on" example:"2023-12-29"`
+
+	// 执行状态(pending/running/success/failed/skipped/cancelled)
+	Status string `json:"status" example:"success"`
+
+	// 脚本执行记录ID
+	RecordID uint32 `json:"record_id" example:"1"`
+
+	// 执行次数
+	Attempts int `json:"attempts" example:"1"`
+
+	// 错误信息
+	ErrorMessage string `json:"error_message" example:""`
+
+	// 开始时间
+	StartedAt string `json:"started_at" example:"2023-01-01 12:00:00"`
+
+	// 结束时间
+	FinishedAt string `json:"finished_at" example:"2023-01-01 12:00:10"`
+}
+
+// MdsBackfillSummaryOut 数据回补中各状态的交易日数
+type MdsBackfillSummaryOut struct {
+	// 交易日总数
+	Total int `json:"total" example:"20"`
+
+	// 等待执行
+	Pending int `json:"pending" example:"10"`
+
+	// 执行中
+	Running int `json:"running" example:"2"`
+
+	// 执行成功
+	Success int `json:"success" example:"8"`
+
+	// 执行失败
+	Failed int `json:"failed" example:"0"`
+
+	// 已跳过
+	Skipped int `json:"skipped" example:"0"`
+
+	// 已取消
+	Cancelled int `json:"cancelled" example:"0"`
+}
+
+type MdsBackfillRunOut struct {
+	// ID
+	ID uint32 `json:"id" example:"1"`
+
+	// mds集群ID
+	MdsColonyID uint32 `json:"mds_colony_id" example:"1"`
+
+	// 集群号
+	ColonyNum string `json:"colony_num" example:"01"`
+
+	// 回放脚本名称
+	Script string `json:"script" example:"mds_replay.sh"`
+
+	// 开始日期
+	StartDate string `json:"start_date" example:"2024-01-01"`
+
+	// 结束日期
+	EndDate string `json:"end_date" example:"2024-01-31"`
+
+	// 是否按日期顺序回放
+	Sequential bool `json:"sequential" example:"true"`
+
+	// 并发数
+	Concurrency int `json:"concurrency" example:"1"`
+
+	// 每日回放超时时间(秒)
+	Timeout int `json:"timeout" example:"3600"`
+
+	// 执行状态(pending/running/success/failed/cancelled)
+	Status string `json:"status" example:"running"`
+
+	// 各状态的交易日数
+	Summary MdsBackfillSummaryOut `json:"summary"`
+
+	// 开始时间
+	StartedAt string `json:"started_at" example:"2023-01-01 12:00:00"`
+
+	// 结束时间
+	FinishedAt string `json:"finished_at" example:"2023-01-01 12:30:00"`
+
+	// 用户名
+	Username string `json:"username" example:"admin"`
+
+	// 创建时间
+	CreatedAt string `json:"created_at" example:"2023-01-01 12:00:00"`
+}
+
+type MdsBackfillRunDetailOut struct {
+	MdsBackfillRunOut
+
+	// 每个交易日的回放状态
+	Days []MdsBackfillDayOut `json:"days"`
+}
+
+// MdsBackfillRunReply 数据回补详情响应结构
+type MdsBackfillRunReply = common.APIReply[*MdsBackfillRunDetailOut]
+
+// PagMdsBackfillRunReply 数据回补的分页响应结构
+type PagMdsBackfillRunReply = common.APIReply[*common.Pag[MdsBackfillRunOut]]
+
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.DateTime)
+}
+
+func MdsBackfillDayToOut(
+	m MdsBackfillDayModel,
+) *MdsBackfillDayOut {
+	return &MdsBackfillDayOut{
+		TradingDay:   m.TradingDay,
+		DependsOn:    m.DependsOn,
+		Status:       m.Status,
+		RecordID:     m.RecordID,
+		Attempts:     m.Attempts,
+		ErrorMessage: m.ErrorMessage,
+		StartedAt:    formatOptionalTime(m.StartedAt),
+		FinishedAt:   formatOptionalTime(m.FinishedAt),
+	}
+}
+
+func MdsBackfillRunToOut(
+	m MdsBackfillRunModel,
+) *MdsBackfillRunOut {
+	summary := MdsBackfillSummaryOut{Total: len(m.Days)}
+	for _, d := range m.Days {
+		switch d.Status {
+		case BackfillStatusPending:
+			summary.Pending++
+		case BackfillStatusRunning:
+			summary.Running++
+		case BackfillStatusSuccess:
+			summary.Success++
+		case BackfillStatusFailed:
+			summary.Failed++
+		case BackfillStatusSkipped:
+			summary.Skipped++
+		case BackfillStatusCancelled:
+			summary.Cancelled++
+		}
+	}
+	return &MdsBackfillRunOut{
+		ID:          m.ID,
+		MdsColonyID: m.MdsColonyID,
+		ColonyNum:   m.ColonyNum,
+		Script:      m.Script,
+		StartDate:   m.StartDate,
+		EndDate:     m.EndDate,
+		Sequential:  m.Sequential,
+		Concurrency: m.Concurrency,
+		Timeout:     m.Timeout,
+		Status:      m.Status,
+		Summary:     summary,
+		StartedAt:   formatOptionalTime(m.StartedAt),
+		FinishedAt:  formatOptionalTime(m.FinishedAt),
+		Username:    m.Username,
+		CreatedAt:   m.CreatedAt.Format(time.DateTime),
+	}
+}
+
+func MdsBackfillRunToDetailOut(
+	m MdsBackfillRunModel,
+) *MdsBackfillRunDetailOut {
+	dms := append([]MdsBackfillDayModel(nil), m.Days...)
+	sort.Slice(dms, func(i, j int) bool { return dms[i].Sort < dms[j].Sort })
+	days := make([]MdsBackfillDayOut, 0, len(dms))
+	for _, d := range dms {
+		days = append(days, *MdsBackfillDayToOut(d))
+	}
+	return &MdsBackfillRunDetailOut{
+		MdsBackfillRunOut: *MdsBackfillRunToOut(m),
+		Days:              days,
+	}
+}
+
+func ListMdsBackfillRunToOut(
+	rms *[]MdsBackfillRunModel,
+) *[]MdsBackfillRunOut {
+	if rms == nil {
+		return &[]MdsBackfillRunOut{}
+	}
+
+	ms := *rms
+	mso := make([]MdsBackfillRunOut, 0, len(ms))
+	for _, m := range ms {
+		mso = append(mso, *MdsBackfillRunToOut(m))
+	}
+	return &mso
+}
@@ -80,6 +80,9 @@ type CreateOrUpdateMdsColonyRequest struct {
 
 	// mon节点ID
 	MonNodeID uint32 `json:"mon_node_id" form:"mon_node_id" binding:"required"`
+
+	// 版本号, 更新时必填(也可通过If-Match请求头传递)
+	Version uint32 `json:"version" form:"version" binding:"omitempty"`
 }
 
 // ListMdsColonyRequest 用于获取mon节点列表的请求结构体
@@ -147,6 +150,9 @@ type MdsColonyStandardOut struct {
 
 	// 更新时间
 	UpdatedAt string `json:"updated_at" example:"2023-01-01 12:00:00"`
+
+	// 版本号
+	Version uint32 `json:"version" example:"1"`
 }
 
 type MdsColonyDetailOut struct {
@@ -195,6 +201,7 @@ func MdsColonyToStandardOut(
 		MdsColonyBaseOut: *MdsColonyToBaseOut(m),
 		CreatedAt:        m.CreatedAt.Format(time.DateTime),
 		UpdatedAt:        m.UpdatedAt.Format(time.DateTime),
+		Version:          m.Version,
 	}
 }
 
@@ -0,0 +1,18 @@
+package mds
+
+import "gin-artweb/internal/shared/events"
+
+// ColonyUpdatedEvent mds集群更新事件, 与oes集群更新事件使用相同的事件类型, 通过project区分
+type ColonyUpdatedEvent struct {
+	Project   string `json:"project"`
+	ID        uint32 `json:"id"`
+	ColonyNum string `json:"colony_num"`
+}
+
+func NewColonyUpdatedEvent(id uint32, colonyNum string) ColonyUpdatedEvent {
+	return ColonyUpdatedEvent{Project: "mds", ID: id, ColonyNum: colonyNum}
+}
+
+func (e ColonyUpdatedEvent) EventType() string {
+	return events.ColonyUpdated
+}
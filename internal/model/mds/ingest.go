@@ -0,0 +1,513 @@
+package mds
+
+import (
+	"strings"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+
+	"gin-artweb/internal/model/common"
+	"gin-artweb/internal/shared/database"
+)
+
+const (
+	IngestSourceSFTP  = "sftp"  // 通过平台SSH密钥从主机读取
+	IngestSourceLocal = "local" // 读取平台所在服务器的本地文件
+	IngestSourceHTTP  = "http"  // 通过HTTP(S) GET下载
+)
+
+const (
+	IngestStatusRunning = "running" // 采集中
+	IngestStatusSuccess = "success" // 采集成功且校验通过
+	IngestStatusFailed  = "failed"  // 获取文件失败或校验未通过
+	IngestStatusPending = "pending" // 当日尚未采集, 仅用于按交易日汇总
+)
+
+// IngestDateLayout 交易日的格式
+const IngestDateLayout = time.DateOnly
+
+// MdsIngestSourceModel 行情数据源, 按计划任务定时拉取每个交易日的行情文件并校验
+//
+// 文件位置中的{date}替换为20060102格式的交易日, {day}替换为2006-01-02格式的交易日;
+// 设置了校验文件后缀时同时拉取"文件位置+后缀"的校验文件, 内容为"md5 [行数]"
+type MdsIngestSourceModel struct {
+	database.StandardModel
+	Name           string `gorm:"column:name;type:varchar(50);not null;uniqueIndex;comment:名称" json:"name"`
+	Kind           string `gorm:"column:kind;type:varchar(10);not null;comment:数据源类型" json:"kind"`
+	HostID         uint32 `gorm:"column:host_id;comment:SFTP主机ID" json:"host_id"`
+	Location       string `gorm:"column:location;type:varchar(500);not null;comment:文件位置" json:"location"`
+	ChecksumSuffix string `gorm:"column:checksum_suffix;type:varchar(20);comment:校验文件后缀" json:"checksum_suffix"`
+	Delimiter      string `gorm:"column:delimiter;type:varchar(4);not null;default:',';comment:字段分隔符" json:"delimiter"`
+	HasHeader      bool   `gorm:"column:has_header;type:boolean;comment:是否包含表头" json:"has_header"`
+	Columns        string `gorm:"column:columns;type:text;comment:字段定义" json:"columns"`
+	Specification  string `gorm:"column:specification;type:varchar(100);comment:定时采集表达式" json:"specification"`
+	TradingDayOnly bool   `gorm:"column:trading_day_only;type:boolean;comment:仅交易日采集" json:"trading_day_only"`
+	MaxSize        int64  `gorm:"column:max_size;comment:文件大小上限(MB)" json:"max_size"`
+	IsEnabled      bool   `gorm:"column:is_enabled;type:boolean;comment:是否启用" json:"is_enabled"`
+}
+
+func (m *MdsIngestSourceModel) TableName() string {
+	return "mds_ingest_source"
+}
+
+// ResolveLocation 返回指定交易日的文件位置
+func (m *MdsIngestSourceModel) ResolveLocation(day time.Time) string {
+	return strings.NewReplacer(
+		"{date}", day.Format("20060102"),
+		"{day}", day.Format(IngestDateLayout),
+	).Replace(m.Location)
+}
+
+// ColumnSpecs 解析字段定义, 每行一个字段, 格式为"名称:类型", 类型可选string/int/float/date, 省略时为string
+func (m *MdsIngestSourceModel) ColumnSpecs() []IngestColumn {
+	var cols []IngestColumn
+	for _, line := range strings.Split(m.Columns, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		name, typ, _ := strings.Cut(line, ":")
+		typ = strings.TrimSpace(typ)
+		if typ == "" {
+			typ = IngestColumnString
+		}
+		cols = append(cols, IngestColumn{Name: strings.TrimSpace(name), Type: typ})
+	}
+	return cols
+}
+
+func (m *MdsIngestSourceModel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if m == nil {
+		return nil
+	}
+	if err := m.StandardModel.MarshalLogObject(enc); err != nil {
+		return err
+	}
+	enc.AddString("name", m.Name)
+	enc.AddString("kind", m.Kind)
+	enc.AddUint32("host_id", m.HostID)
+	enc.AddString("location", m.Location)
+	enc.AddString("checksum_suffix", m.ChecksumSuffix)
+	enc.AddString("specification", m.Specification)
+	enc.AddBool("trading_day_only", m.TradingDayOnly)
+	enc.AddBool("is_enabled", m.IsEnabled)
+	return nil
+}
+
+const (
+	IngestColumnString = "string"
+	IngestColumnInt    = "int"
+	IngestColumnFloat  = "float"
+	IngestColumnDate   = "date"
+)
+
+// IngestColumn 行情文件的字段定义
+type IngestColumn struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// MdsIngestRecordModel 数据源每个交易日的采集记录, 重新采集时覆盖同一条记录
+type MdsIngestRecordModel struct {
+	database.StandardModel
+	SourceID         uint32               `gorm:"column:source_id;not null;uniqueIndex:idx_mds_ingest_source_day;comment:数据源ID" json:"source_id"`
+	Source           MdsIngestSourceModel `gorm:"foreignKey:SourceID;references:ID;constraint:OnDelete:CASCADE" json:"source"`
+	TradingDay       string               `gorm:"column:trading_day;type:varchar(10);not null;uniqueIndex:idx_mds_ingest_source_day;index;comment:交易日" json:"trading_day"`
+	Status           string               `gorm:"column:status;type:varchar(10);not null;comment:状态" json:"status"`
+	Location         string               `gorm:"column:location;type:varchar(500);comment:文件位置" json:"location"`
+	FileSize         int64                `gorm:"column:file_size;comment:文件大小" json:"file_size"`
+	RowCount         int64                `gorm:"column:row_count;comment:数据行数" json:"row_count"`
+	ExpectedRows     int64                `gorm:"column:expected_rows;comment:校验文件中的行数" json:"expected_rows"`
+	Checksum         string               `gorm:"column:checksum;type:varchar(64);comment:文件MD5" json:"checksum"`
+	ExpectedChecksum string               `gorm:"column:expected_checksum;type:varchar(64);comment:校验文件中的MD5" json:"expected_checksum"`
+	Errors           string               `gorm:"column:errors;type:text;comment:校验错误" json:"errors"`
+	Attempts         int                  `gorm:"column:attempts;not null;default:0;comment:采集次数" json:"attempts"`
+	TriggerType      string               `gorm:"column:trigger_type;type:varchar(10);comment:触发方式" json:"trigger_type"`
+	Username         string               `gorm:"column:username;type:varchar(50);comment:操作用户" json:"username"`
+	StartedAt        *time.Time           `gorm:"column:started_at;comment:开始时间" json:"started_at"`
+	FinishedAt       *time.Time           `gorm:"column:finished_at;comment:结束时间" json:"finished_at"`
+}
+
+func (m *MdsIngestRecordModel) TableName() string {
+	return "mds_ingest_record"
+}
+
+// ErrorList 校验错误列表
+func (m *MdsIngestRecordModel) ErrorList() []string {
+	if m.Errors == "" {
+		return []string{}
+	}
+	return strings.Split(m.Errors, "\n")
+}
+
+func (m *MdsIngestRecordModel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if m == nil {
+		return nil
+	}
+	if err := m.StandardModel.MarshalLogObject(enc); err != nil {
+		return err
+	}
+	enc.AddUint32("source_id", m.SourceID)
+	enc.AddString("trading_day", m.TradingDay)
+	enc.AddString("status", m.Status)
+	enc.AddString("location", m.Location)
+	enc.AddInt64("file_size", m.FileSize)
+	enc.AddInt64("row_count", m.RowCount)
+	enc.AddString("checksum", m.Checksum)
+	enc.AddInt("attempts", m.Attempts)
+	return nil
+}
+
+// CreateOrUpdateIngestSourceRequest 用于创建或更新行情数据源的请求结构体
+//
+// swagger:model CreateOrUpdateIngestSourceRequest
+type CreateOrUpdateIngestSourceRequest struct {
+	// 名称
+	Name string `json:"name" form:"name" binding:"required,max=50"`
+
+	// 数据源类型, sftp:主机文件, local:本地文件, http:HTTP下载
+	Kind string `json:"kind" form:"kind" binding:"required,oneof=sftp local http"`
+
+	// SFTP主机ID, 类型为sftp时必填
+	HostID uint32 `json:"host_id" form:"host_id" binding:"required_if=Kind sftp"`
+
+	// 文件位置, 路径或URL, 支持{date}和{day}占位符
+	Location string `json:"location" form:"location" binding:"required,max=500"`
+
+	// 校验文件后缀, 如.md5, 为空时不核对校验文件
+	ChecksumSuffix string `json:"checksum_suffix" form:"checksum_suffix" binding:"omitempty,max=20"`
+
+	// 字段分隔符, 为空时为逗号
+	Delimiter string `json:"delimiter" form:"delimiter" binding:"omitempty,max=4"`
+
+	// 是否包含表头
+	HasHeader bool `json:"has_header" form:"has_header"`
+
+	// 字段定义, 每行一个"名称:类型"
+	Columns string `json:"columns" form:"columns" binding:"required"`
+
+	// 定时采集的crontab表达式, 为空时仅手动采集
+	Specification string `json:"specification" form:"specification" binding:"omitempty,max=100"`
+
+	// 仅交易日采集
+	TradingDayOnly bool `json:"trading_day_only" form:"trading_day_only"`
+
+	// 文件大小上限(MB), 为0时为100
+	MaxSize int64 `json:"max_size" form:"max_size" binding:"omitempty,min=1,max=2048"`
+
+	// 是否启用
+	IsEnabled bool `json:"is_enabled" form:"is_enabled"`
+}
+
+func (req *CreateOrUpdateIngestSourceRequest) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("name", req.Name)
+	enc.AddString("kind", req.Kind)
+	enc.AddUint32("host_id", req.HostID)
+	enc.AddString("location", req.Location)
+	enc.AddString("checksum_suffix", req.ChecksumSuffix)
+	enc.AddString("specification", req.Specification)
+	enc.AddBool("trading_day_only", req.TradingDayOnly)
+	enc.AddBool("is_enabled", req.IsEnabled)
+	return nil
+}
+
+// ToModel 转换为数据源模型, 未设置的分隔符和大小上限使用默认值
+func (req *CreateOrUpdateIngestSourceRequest) ToModel() MdsIngestSourceModel {
+	m := MdsIngestSourceModel{
+		Name:           req.Name,
+		Kind:           req.Kind,
+		Location:       req.Location,
+		ChecksumSuffix: req.ChecksumSuffix,
+		Delimiter:      req.Delimiter,
+		HasHeader:      req.HasHeader,
+		Columns:        req.Columns,
+		Specification:  req.Specification,
+		TradingDayOnly: req.TradingDayOnly,
+		MaxSize:        req.MaxSize,
+		IsEnabled:      req.IsEnabled,
+	}
+	if req.Kind == IngestSourceSFTP {
+		m.HostID = req.HostID
+	}
+	if m.Delimiter == "" {
+		m.Delimiter = ","
+	}
+	if m.MaxSize == 0 {
+		m.MaxSize = 100
+	}
+	return m
+}
+
+// ListIngestSourceRequest 用于获取行情数据源列表的请求结构体
+//
+// swagger:model ListIngestSourceRequest
+type ListIngestSourceRequest struct {
+	common.StandardModelQuery
+
+	// 名称
+	Name string `form:"name" binding:"omitempty,max=50"`
+
+	// 数据源类型
+	Kind string `form:"kind" binding:"omitempty,oneof=sftp local http"`
+
+	// 是否启用
+	IsEnabled *bool `form:"is_enabled"`
+}
+
+func (req *ListIngestSourceRequest) Query() (int, int, map[string]any) {
+	page, size, query := req.StandardModelQuery.QueryMap(8)
+	if req.Name != "" {
+		query["name like ?"] = "%" + req.Name + "%"
+	}
+	if req.Kind != "" {
+		query["kind = ?"] = req.Kind
+	}
+	if req.IsEnabled != nil {
+		query["is_enabled = ?"] = *req.IsEnabled
+	}
+	return page, size, query
+}
+
+// RunIngestRequest 用于手动采集或重新采集的请求结构体
+//
+// swagger:model RunIngestRequest
+type RunIngestRequest struct {
+	// 交易日, 格式为2006-01-02
+	TradingDay string `json:"trading_day" form:"trading_day" binding:"required,datetime=2006-01-02"`
+}
+
+// ListIngestRecordRequest 用于获取采集记录列表的请求结构体
+//
+// swagger:model ListIngestRecordRequest
+type ListIngestRecordRequest struct {
+	common.StandardModelQuery
+
+	// 数据源ID
+	SourceID uint32 `form:"source_id"`
+
+	// 交易日
+	TradingDay string `form:"trading_day" binding:"omitempty,datetime=2006-01-02"`
+
+	// 状态
+	Status string `form:"status" binding:"omitempty,oneof=running success failed"`
+}
+
+func (req *ListIngestRecordRequest) Query() (int, int, map[string]any) {
+	page, size, query := req.StandardModelQuery.QueryMap(8)
+	if req.SourceID != 0 {
+		query["source_id = ?"] = req.SourceID
+	}
+	if req.TradingDay != "" {
+		query["trading_day = ?"] = req.TradingDay
+	}
+	if req.Status != "" {
+		query["status = ?"] = req.Status
+	}
+	return page, size, query
+}
+
+// IngestDayRequest 用于按交易日查询采集状态的请求结构体
+//
+// swagger:model IngestDayRequest
+type IngestDayRequest struct {
+	// 交易日, 格式为2006-01-02
+	TradingDay string `uri:"trading_day" binding:"required,datetime=2006-01-02"`
+}
+
+type IngestSourceOut struct {
+	// 唯一标识
+	ID uint32 `json:"id" example:"1"`
+
+	// 名称
+	Name string `json:"name" example:"上交所逐笔成交"`
+
+	// 数据源类型
+	Kind string `json:"kind" example:"sftp"`
+
+	// SFTP主机ID
+	HostID uint32 `json:"host_id" example:"1"`
+
+	// 文件位置
+	Location string `json:"location" example:"/data/mds/{date}/trade.csv"`
+
+	// 校验文件后缀
+	ChecksumSuffix string `json:"checksum_suffix" example:".md5"`
+
+	// 字段分隔符
+	Delimiter string `json:"delimiter" example:","`
+
+	// 是否包含表头
+	HasHeader bool `json:"has_header" example:"true"`
+
+	// 字段定义
+	Columns []IngestColumn `json:"columns"`
+
+	// 定时采集表达式
+	Specification string `json:"specification" example:"30 16 * * 1-5"`
+
+	// 仅交易日采集
+	TradingDayOnly bool `json:"trading_day_only" example:"true"`
+
+	// 文件大小上限(MB)
+	MaxSize int64 `json:"max_size" example:"100"`
+
+	// 是否启用
+	IsEnabled bool `json:"is_enabled" example:"true"`
+
+	// 创建时间
+	CreatedAt string `json:"created_at" example:"2023-01-01 12:00:00"`
+
+	// 更新时间
+	UpdatedAt string `json:"updated_at" example:"2023-01-01 12:00:00"`
+}
+
+type IngestRecordOut struct {
+	// 唯一标识
+	ID uint32 `json:"id" example:"1"`
+
+	// 数据源ID
+	SourceID uint32 `json:"source_id" example:"1"`
+
+	// 数据源名称
+	SourceName string `json:"source_name" example:"上交所逐笔成交"`
+
+	// 交易日
+	TradingDay string `json:"trading_day" example:"2024-01-02"`
+
+	// 状态, running/success/failed/pending
+	Status string `json:"status" example:"success"`
+
+	// 文件位置
+	Location string `json:"location" example:"/data/mds/20240102/trade.csv"`
+
+	// 文件大小
+	FileSize int64 `json:"file_size" example:"1024"`
+
+	// 数据行数
+	RowCount int64 `json:"row_count" example:"100"`
+
+	// 校验文件中的行数
+	ExpectedRows int64 `json:"expected_rows" example:"100"`
+
+	// 文件MD5
+	Checksum string `json:"checksum" example:"d41d8cd98f00b204e9800998ecf8427e"`
+
+	// 校验文件中的MD5
+	ExpectedChecksum string `json:"expected_checksum" example:"d41d8cd98f00b204e9800998ecf8427e"`
+
+	// 校验错误
+	Errors []string `json:"errors"`
+
+	// 采集次数
+	Attempts int `json:"attempts" example:"1"`
+
+	// 触发方式, cron/manual
+	TriggerType string `json:"trigger_type" example:"cron"`
+
+	// 操作用户
+	Username string `json:"username" example:"admin"`
+
+	// 开始时间
+	StartedAt string `json:"started_at" example:"2023-01-01 12:00:00"`
+
+	// 结束时间
+	FinishedAt string `json:"finished_at" example:"2023-01-01 12:00:00"`
+}
+
+// IngestSourceReply 行情数据源响应结构
+type IngestSourceReply = common.APIReply[*IngestSourceOut]
+
+// PagIngestSourceReply 行情数据源的分页响应结构
+type PagIngestSourceReply = common.APIReply[*common.Pag[IngestSourceOut]]
+
+// IngestRecordReply 采集记录响应结构
+type IngestRecordReply = common.APIReply[*IngestRecordOut]
+
+// PagIngestRecordReply 采集记录的分页响应结构
+type PagIngestRecordReply = common.APIReply[*common.Pag[IngestRecordOut]]
+
+// IngestDayReply 交易日采集状态响应结构
+type IngestDayReply = common.APIReply[*[]IngestRecordOut]
+
+func IngestSourceModelToOut(
+	m MdsIngestSourceModel,
+) *IngestSourceOut {
+	columns := m.ColumnSpecs()
+	if columns == nil {
+		columns = []IngestColumn{}
+	}
+	return &IngestSourceOut{
+		ID:             m.ID,
+		Name:           m.Name,
+		Kind:           m.Kind,
+		HostID:         m.HostID,
+		Location:       m.Location,
+		ChecksumSuffix: m.ChecksumSuffix,
+		Delimiter:      m.Delimiter,
+		HasHeader:      m.HasHeader,
+		Columns:        columns,
+		Specification:  m.Specification,
+		TradingDayOnly: m.TradingDayOnly,
+		MaxSize:        m.MaxSize,
+		IsEnabled:      m.IsEnabled,
+		CreatedAt:      m.CreatedAt.Format(time.DateTime),
+		UpdatedAt:      m.UpdatedAt.Format(time.DateTime),
+	}
+}
+
+func ListIngestSourceModelToOut(
+	sms *[]MdsIngestSourceModel,
+) *[]IngestSourceOut {
+	if sms == nil {
+		return &[]IngestSourceOut{}
+	}
+	ms := *sms
+	mso := make([]IngestSourceOut, 0, len(ms))
+	for _, m := range ms {
+		mso = append(mso, *IngestSourceModelToOut(m))
+	}
+	return &mso
+}
+
+func IngestRecordModelToOut(
+	m MdsIngestRecordModel,
+) *IngestRecordOut {
+	out := &IngestRecordOut{
+		ID:               m.ID,
+		SourceID:         m.SourceID,
+		SourceName:       m.Source.Name,
+		TradingDay:       m.TradingDay,
+		Status:           m.Status,
+		Location:         m.Location,
+		FileSize:         m.FileSize,
+		RowCount:         m.RowCount,
+		ExpectedRows:     m.ExpectedRows,
+		Checksum:         m.Checksum,
+		ExpectedChecksum: m.ExpectedChecksum,
+		Errors:           m.ErrorList(),
+		Attempts:         m.Attempts,
+		TriggerType:      m.TriggerType,
+		Username:         m.Username,
+	}
+	if m.StartedAt != nil {
+		out.StartedAt = m.StartedAt.Format(time.DateTime)
+	}
+	if m.FinishedAt != nil {
+		out.FinishedAt = m.FinishedAt.Format(time.DateTime)
+	}
+	return out
+}
+
+func ListIngestRecordModelToOut(
+	rms *[]MdsIngestRecordModel,
+) *[]IngestRecordOut {
+	if rms == nil {
+		return &[]IngestRecordOut{}
+	}
+	ms := *rms
+	mso := make([]IngestRecordOut, 0, len(ms))
+	for _, m := range ms {
+		mso = append(mso, *IngestRecordModelToOut(m))
+	}
+	return &mso
+}
@@ -95,7 +95,7 @@ type ListMdsNodeRequest struct {
 func (req *ListMdsNodeRequest) Query() (int, int, map[string]any) {
 	page, size, query := req.StandardModelQuery.QueryMap(12)
 	if req.NodeRole != "" {
-		query["NodeRole = ?"] = req.NodeRole
+		query["node_role = ?"] = req.NodeRole
 	}
 	if req.IsEnable != nil {
 		query["is_enable = ?"] = *req.IsEnable
@@ -0,0 +1,730 @@
+package model
+
+import (
+	"gorm.io/gorm"
+
+	"gin-artweb/internal/model/customer"
+	"gin-artweb/internal/model/jobs"
+	"gin-artweb/internal/model/mds"
+	"gin-artweb/internal/model/mon"
+	"gin-artweb/internal/model/oes"
+	"gin-artweb/internal/model/resource"
+	"gin-artweb/internal/model/system"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/events"
+)
+
+// Migrations 版本化的数据库迁移, 按版本号顺序执行
+//
+// 已发布的迁移不能修改, 表结构变化时新增一个版本号更大的迁移,
+// 在其中只迁移发生变化的模型
+var Migrations = []database.Migration{
+	{
+		ID:          "000001",
+		Description: "初始化数据库结构",
+		Migrate: func(tx *gorm.DB) error {
+			return tx.AutoMigrate(baselineModels()...)
+		},
+	},
+	{
+		ID:          "000002",
+		Description: "用户新增强制修改密码标记",
+		Migrate: func(tx *gorm.DB) error {
+			return addColumnIfMissing(tx, &customer.UserModel{}, "MustChangePassword")
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropColumn(&customer.UserModel{}, "MustChangePassword")
+		},
+	},
+	{
+		ID:          "000003",
+		Description: "API新增失效标记",
+		Migrate: func(tx *gorm.DB) error {
+			if err := addColumnIfMissing(tx, &customer.ApiModel{}, "IsOrphan"); err != nil {
+				return err
+			}
+			if tx.Migrator().HasIndex(&customer.ApiModel{}, "IsOrphan") {
+				return nil
+			}
+			return tx.Migrator().CreateIndex(&customer.ApiModel{}, "IsOrphan")
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropColumn(&customer.ApiModel{}, "IsOrphan")
+		},
+	},
+	{
+		ID:          "000004",
+		Description: "新增慢查询统计表",
+		Migrate: func(tx *gorm.DB) error {
+			if tx.Migrator().HasTable(&system.SlowQueryModel{}) {
+				return nil
+			}
+			return tx.Migrator().CreateTable(&system.SlowQueryModel{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&system.SlowQueryModel{})
+		},
+	},
+	{
+		ID:          "000005",
+		Description: "新增JWT签名密钥表",
+		Migrate: func(tx *gorm.DB) error {
+			if tx.Migrator().HasTable(&customer.SigningKeyModel{}) {
+				return nil
+			}
+			return tx.Migrator().CreateTable(&customer.SigningKeyModel{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&customer.SigningKeyModel{})
+		},
+	},
+	{
+		ID:          "000006",
+		Description: "敏感字段加密存储",
+		Migrate: func(tx *gorm.DB) error {
+			// 密文不是合法的JSON且长度大于明文, 调整字段类型和长度
+			for _, c := range []struct {
+				model any
+				field string
+			}{
+				{&jobs.ScriptRecordModel{}, "EnvVars"},
+				{&jobs.ScheduleModel{}, "EnvVars"},
+				{&mon.MonNodeModel{}, "PromPassword"},
+				{&mon.MonNodeModel{}, "PromBearerToken"},
+			} {
+				if err := tx.Migrator().AlterColumn(c.model, c.field); err != nil {
+					return err
+				}
+			}
+			// 未配置字段加密密钥时跳过, 开启加密后通过 -encrypt-fields 加密存量数据
+			if database.GetFieldEncryptor() == nil {
+				return nil
+			}
+			_, err := EncryptFields(tx.Statement.Context, tx)
+			return err
+		},
+	},
+	{
+		ID:          "000007",
+		Description: "程序包新增文件大小和校验和",
+		Migrate: func(tx *gorm.DB) error {
+			for _, field := range []string{"Size", "Checksum"} {
+				if err := addColumnIfMissing(tx, &resource.PackageModel{}, field); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			for _, field := range []string{"Size", "Checksum"} {
+				if err := tx.Migrator().DropColumn(&resource.PackageModel{}, field); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		ID:          "000008",
+		Description: "新增程序包分片上传会话表",
+		Migrate: func(tx *gorm.DB) error {
+			if tx.Migrator().HasTable(&resource.PackageUploadModel{}) {
+				return nil
+			}
+			return tx.Migrator().CreateTable(&resource.PackageUploadModel{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&resource.PackageUploadModel{})
+		},
+	},
+	{
+		ID:          "000009",
+		Description: "新增webhook订阅和推送记录表",
+		Migrate: func(tx *gorm.DB) error {
+			for _, m := range []any{&system.WebhookSubscriptionModel{}, &system.WebhookDeliveryModel{}} {
+				if tx.Migrator().HasTable(m) {
+					continue
+				}
+				if err := tx.Migrator().CreateTable(m); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&system.WebhookDeliveryModel{}, &system.WebhookSubscriptionModel{})
+		},
+	},
+	{
+		ID:          "000010",
+		Description: "新增事件发件箱表",
+		Migrate: func(tx *gorm.DB) error {
+			if tx.Migrator().HasTable(&events.OutboxModel{}) {
+				return nil
+			}
+			return tx.Migrator().CreateTable(&events.OutboxModel{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&events.OutboxModel{})
+		},
+	},
+	{
+		ID:          "000011",
+		Description: "新增网页终端会话表",
+		Migrate: func(tx *gorm.DB) error {
+			if tx.Migrator().HasTable(&resource.TerminalSessionModel{}) {
+				return nil
+			}
+			return tx.Migrator().CreateTable(&resource.TerminalSessionModel{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&resource.TerminalSessionModel{})
+		},
+	},
+	{
+		ID:          "000012",
+		Description: "新增主机路径规则表",
+		Migrate: func(tx *gorm.DB) error {
+			if tx.Migrator().HasTable(&resource.HostPathRuleModel{}) {
+				return nil
+			}
+			return tx.Migrator().CreateTable(&resource.HostPathRuleModel{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&resource.HostPathRuleModel{})
+		},
+	},
+	{
+		ID:          "000013",
+		Description: "新增oes配置模板表",
+		Migrate: func(tx *gorm.DB) error {
+			if tx.Migrator().HasTable(&oes.OesConfTemplateModel{}) {
+				return nil
+			}
+			return tx.Migrator().CreateTable(&oes.OesConfTemplateModel{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&oes.OesConfTemplateModel{})
+		},
+	},
+	{
+		ID:          "000014",
+		Description: "新增oes集群配置漂移表",
+		Migrate: func(tx *gorm.DB) error {
+			if tx.Migrator().HasTable(&oes.OesColonyDriftModel{}) {
+				return nil
+			}
+			return tx.Migrator().CreateTable(&oes.OesColonyDriftModel{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&oes.OesColonyDriftModel{})
+		},
+	},
+	{
+		ID:          "000015",
+		Description: "新增用户外部身份表",
+		Migrate: func(tx *gorm.DB) error {
+			if tx.Migrator().HasTable(&customer.UserIdentityModel{}) {
+				return nil
+			}
+			return tx.Migrator().CreateTable(&customer.UserIdentityModel{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&customer.UserIdentityModel{})
+		},
+	},
+	{
+		ID:          "000016",
+		Description: "新增用户登录会话表",
+		Migrate: func(tx *gorm.DB) error {
+			if tx.Migrator().HasTable(&customer.UserSessionModel{}) {
+				return nil
+			}
+			return tx.Migrator().CreateTable(&customer.UserSessionModel{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&customer.UserSessionModel{})
+		},
+	},
+	{
+		ID:          "000017",
+		Description: "新增后台任务表",
+		Migrate: func(tx *gorm.DB) error {
+			if tx.Migrator().HasTable(&system.TaskModel{}) {
+				return nil
+			}
+			return tx.Migrator().CreateTable(&system.TaskModel{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&system.TaskModel{})
+		},
+	},
+	{
+		ID:          "000018",
+		Description: "新增功能开关表",
+		Migrate: func(tx *gorm.DB) error {
+			if tx.Migrator().HasTable(&system.FeatureFlagModel{}) {
+				return nil
+			}
+			return tx.Migrator().CreateTable(&system.FeatureFlagModel{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&system.FeatureFlagModel{})
+		},
+	},
+	{
+		ID:          "000019",
+		Description: "脚本、计划任务和执行记录新增脚本参数",
+		Migrate: func(tx *gorm.DB) error {
+			for _, m := range []any{&jobs.ScriptModel{}, &jobs.ScheduleModel{}, &jobs.ScriptRecordModel{}} {
+				if err := addColumnIfMissing(tx, m, "Params"); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			for _, m := range []any{&jobs.ScriptModel{}, &jobs.ScheduleModel{}, &jobs.ScriptRecordModel{}} {
+				if err := tx.Migrator().DropColumn(m, "Params"); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		ID:          "000020",
+		Description: "新增部门表, 用户新增所属部门, 角色新增数据范围",
+		Migrate: func(tx *gorm.DB) error {
+			if !tx.Migrator().HasTable(&customer.DepartmentModel{}) {
+				if err := tx.Migrator().CreateTable(&customer.DepartmentModel{}); err != nil {
+					return err
+				}
+			}
+			if err := addColumnIfMissing(tx, &customer.UserModel{}, "DepartmentID"); err != nil {
+				return err
+			}
+			if !tx.Migrator().HasIndex(&customer.UserModel{}, "DepartmentID") {
+				if err := tx.Migrator().CreateIndex(&customer.UserModel{}, "DepartmentID"); err != nil {
+					return err
+				}
+			}
+			return addColumnIfMissing(tx, &customer.RoleModel{}, "DataScope")
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := tx.Migrator().DropColumn(&customer.RoleModel{}, "DataScope"); err != nil {
+				return err
+			}
+			if err := tx.Migrator().DropColumn(&customer.UserModel{}, "DepartmentID"); err != nil {
+				return err
+			}
+			return tx.Migrator().DropTable(&customer.DepartmentModel{})
+		},
+	},
+	{
+		ID:          "000021",
+		Description: "新增用户组表及用户组与角色、用户的关联表",
+		Migrate: func(tx *gorm.DB) error {
+			if tx.Migrator().HasTable(&customer.UserGroupModel{}) {
+				return nil
+			}
+			return tx.Migrator().CreateTable(&customer.UserGroupModel{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(
+				"customer_user_group_user",
+				"customer_user_group_role",
+				&customer.UserGroupModel{},
+			)
+		},
+	},
+	{
+		ID:          "000022",
+		Description: "新增行情数据源和行情数据采集记录表",
+		Migrate: func(tx *gorm.DB) error {
+			for _, m := range []any{&mds.MdsIngestSourceModel{}, &mds.MdsIngestRecordModel{}} {
+				if tx.Migrator().HasTable(m) {
+					continue
+				}
+				if err := tx.Migrator().CreateTable(m); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&mds.MdsIngestRecordModel{}, &mds.MdsIngestSourceModel{})
+		},
+	},
+	{
+		ID:          "000023",
+		Description: "新增mds数据回补及回补交易日表",
+		Migrate: func(tx *gorm.DB) error {
+			for _, m := range []any{&mds.MdsBackfillRunModel{}, &mds.MdsBackfillDayModel{}} {
+				if tx.Migrator().HasTable(m) {
+					continue
+				}
+				if err := tx.Migrator().CreateTable(m); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&mds.MdsBackfillDayModel{}, &mds.MdsBackfillRunModel{})
+		},
+	},
+	{
+		ID:          "000024",
+		Description: "新增oes柜台数据对账报告及文件对账结果表",
+		Migrate: func(tx *gorm.DB) error {
+			for _, m := range []any{&oes.OesReconcileReportModel{}, &oes.OesReconcileFileModel{}} {
+				if tx.Migrator().HasTable(m) {
+					continue
+				}
+				if err := tx.Migrator().CreateTable(m); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&oes.OesReconcileFileModel{}, &oes.OesReconcileReportModel{})
+		},
+	},
+	{
+		ID:          "000025",
+		Description: "新增oes运维检查单及检查单执行表",
+		Migrate: func(tx *gorm.DB) error {
+			for _, m := range []any{
+				&oes.OesRunbookModel{}, &oes.OesRunbookStepModel{},
+				&oes.OesRunbookExecModel{}, &oes.OesRunbookExecStepModel{},
+			} {
+				if tx.Migrator().HasTable(m) {
+					continue
+				}
+				if err := tx.Migrator().CreateTable(m); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(
+				&oes.OesRunbookExecStepModel{}, &oes.OesRunbookExecModel{},
+				&oes.OesRunbookStepModel{}, &oes.OesRunbookModel{},
+			)
+		},
+	},
+	{
+		ID:          "000026",
+		Description: "新增oes日常任务SLA及SLA检查结果表",
+		Migrate: func(tx *gorm.DB) error {
+			for _, m := range []any{&oes.OesTaskSlaModel{}, &oes.OesTaskSlaResultModel{}} {
+				if tx.Migrator().HasTable(m) {
+					continue
+				}
+				if err := tx.Migrator().CreateTable(m); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&oes.OesTaskSlaResultModel{}, &oes.OesTaskSlaModel{})
+		},
+	},
+	{
+		ID:          "000027",
+		Description: "新增变更冻结表",
+		Migrate: func(tx *gorm.DB) error {
+			if tx.Migrator().HasTable(&system.ChangeFreezeModel{}) {
+				return nil
+			}
+			return tx.Migrator().CreateTable(&system.ChangeFreezeModel{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&system.ChangeFreezeModel{})
+		},
+	},
+	{
+		ID:          "000028",
+		Description: "新增主机代理表",
+		Migrate: func(tx *gorm.DB) error {
+			if tx.Migrator().HasTable(&resource.HostAgentModel{}) {
+				return nil
+			}
+			return tx.Migrator().CreateTable(&resource.HostAgentModel{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&resource.HostAgentModel{})
+		},
+	},
+	{
+		ID:          "000029",
+		Description: "新增主机指标表",
+		Migrate: func(tx *gorm.DB) error {
+			if tx.Migrator().HasTable(&mon.HostMetricModel{}) {
+				return nil
+			}
+			return tx.Migrator().CreateTable(&mon.HostMetricModel{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&mon.HostMetricModel{})
+		},
+	},
+	{
+		ID:          "000030",
+		Description: "新增进程守护和守护记录表",
+		Migrate: func(tx *gorm.DB) error {
+			for _, m := range []any{&resource.WatchdogModel{}, &resource.WatchdogEventModel{}} {
+				if tx.Migrator().HasTable(m) {
+					continue
+				}
+				if err := tx.Migrator().CreateTable(m); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&resource.WatchdogEventModel{}, &resource.WatchdogModel{})
+		},
+	},
+	{
+		ID:          "000031",
+		Description: "新增oes链路和链路探测记录表",
+		Migrate: func(tx *gorm.DB) error {
+			for _, m := range []any{&oes.OesLinkModel{}, &oes.OesLinkProbeModel{}} {
+				if tx.Migrator().HasTable(m) {
+					continue
+				}
+				if err := tx.Migrator().CreateTable(m); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&oes.OesLinkProbeModel{}, &oes.OesLinkModel{})
+		},
+	},
+	{
+		ID:          "000032",
+		Description: "新增证书监控表",
+		Migrate: func(tx *gorm.DB) error {
+			if tx.Migrator().HasTable(&system.CertMonitorModel{}) {
+				return nil
+			}
+			return tx.Migrator().CreateTable(&system.CertMonitorModel{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&system.CertMonitorModel{})
+		},
+	},
+	{
+		ID:          "000033",
+		Description: "新增备份记录表",
+		Migrate: func(tx *gorm.DB) error {
+			if tx.Migrator().HasTable(&system.BackupModel{}) {
+				return nil
+			}
+			return tx.Migrator().CreateTable(&system.BackupModel{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&system.BackupModel{})
+		},
+	},
+	{
+		ID:          "000034",
+		Description: "新增用户偏好表",
+		Migrate: func(tx *gorm.DB) error {
+			if tx.Migrator().HasTable(&customer.UserPreferenceModel{}) {
+				return nil
+			}
+			return tx.Migrator().CreateTable(&customer.UserPreferenceModel{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&customer.UserPreferenceModel{})
+		},
+	},
+	{
+		ID:          "000035",
+		Description: "用户新增停用时间和计划永久停用时间",
+		Migrate: func(tx *gorm.DB) error {
+			for _, field := range []string{"DeactivatedAt", "DisableAt", "DisabledAt"} {
+				if err := addColumnIfMissing(tx, &customer.UserModel{}, field); err != nil {
+					return err
+				}
+			}
+			if tx.Migrator().HasIndex(&customer.UserModel{}, "DisableAt") {
+				return nil
+			}
+			return tx.Migrator().CreateIndex(&customer.UserModel{}, "DisableAt")
+		},
+		Rollback: func(tx *gorm.DB) error {
+			for _, field := range []string{"DeactivatedAt", "DisableAt", "DisabledAt"} {
+				if err := tx.Migrator().DropColumn(&customer.UserModel{}, field); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		ID:          "000036",
+		Description: "脚本、计划任务和执行记录新增隔离执行配置, 执行记录新增失败原因",
+		Migrate: func(tx *gorm.DB) error {
+			for _, m := range []any{&jobs.ScriptModel{}, &jobs.ScheduleModel{}, &jobs.ScriptRecordModel{}} {
+				if err := addColumnIfMissing(tx, m, "Isolation"); err != nil {
+					return err
+				}
+			}
+			return addColumnIfMissing(tx, &jobs.ScriptRecordModel{}, "FailureReason")
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := tx.Migrator().DropColumn(&jobs.ScriptRecordModel{}, "FailureReason"); err != nil {
+				return err
+			}
+			for _, m := range []any{&jobs.ScriptModel{}, &jobs.ScheduleModel{}, &jobs.ScriptRecordModel{}} {
+				if err := tx.Migrator().DropColumn(m, "Isolation"); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		ID:          "000037",
+		Description: "脚本新增产物文件匹配规则, 新增执行产物表",
+		Migrate: func(tx *gorm.DB) error {
+			if err := addColumnIfMissing(tx, &jobs.ScriptModel{}, "Artifacts"); err != nil {
+				return err
+			}
+			if tx.Migrator().HasTable(&jobs.ArtifactModel{}) {
+				return nil
+			}
+			return tx.Migrator().CreateTable(&jobs.ArtifactModel{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := tx.Migrator().DropTable(&jobs.ArtifactModel{}); err != nil {
+				return err
+			}
+			return tx.Migrator().DropColumn(&jobs.ScriptModel{}, "Artifacts")
+		},
+	},
+	{
+		ID:          "000038",
+		Description: "计划任务新增绑定的oes集群",
+		Migrate: func(tx *gorm.DB) error {
+			if err := addColumnIfMissing(tx, &jobs.ScheduleModel{}, "Colony"); err != nil {
+				return err
+			}
+			if tx.Migrator().HasIndex(&jobs.ScheduleModel{}, "Colony") {
+				return nil
+			}
+			return tx.Migrator().CreateIndex(&jobs.ScheduleModel{}, "Colony")
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropColumn(&jobs.ScheduleModel{}, "Colony")
+		},
+	},
+	{
+		ID:          "000039",
+		Description: "新增oes日常任务目录表, 写入内置的任务目录",
+		Migrate: func(tx *gorm.DB) error {
+			if !tx.Migrator().HasTable(&oes.OesTaskCatalogModel{}) {
+				if err := tx.Migrator().CreateTable(&oes.OesTaskCatalogModel{}); err != nil {
+					return err
+				}
+			}
+			var count int64
+			if err := tx.Model(&oes.OesTaskCatalogModel{}).Count(&count).Error; err != nil {
+				return err
+			}
+			if count > 0 {
+				return nil
+			}
+			ms := oes.DefaultOesTaskCatalog()
+			return tx.Create(&ms).Error
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable(&oes.OesTaskCatalogModel{})
+		},
+	},
+	{
+		ID:          "000040",
+		Description: "主机代理新增机器序列号, 用于识别重复登记的主机",
+		Migrate: func(tx *gorm.DB) error {
+			if err := addColumnIfMissing(tx, &resource.HostAgentModel{}, "Serial"); err != nil {
+				return err
+			}
+			if tx.Migrator().HasIndex(&resource.HostAgentModel{}, "Serial") {
+				return nil
+			}
+			return tx.Migrator().CreateIndex(&resource.HostAgentModel{}, "Serial")
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropColumn(&resource.HostAgentModel{}, "Serial")
+		},
+	},
+}
+
+// baselineModels 初始迁移创建的数据表, 即引入版本化迁移时已有的模型
+//
+// 该列表已固定, 不随Models()变化; 之后新增的数据表只能通过新的迁移创建
+func baselineModels() []any {
+	return []any{
+		// 客户模型
+		&customer.ApiModel{},
+		&customer.MenuModel{},
+		&customer.ButtonModel{},
+		&customer.RoleModel{},
+		&customer.UserModel{},
+		&customer.LoginRecordModel{},
+		&customer.CasbinModelModel{},
+
+		// 任务模型
+		&jobs.ScriptModel{},
+		&jobs.ScriptRecordModel{},
+		&jobs.ScheduleModel{},
+		&jobs.TradingHolidayModel{},
+		&jobs.ScheduleSkipModel{},
+
+		// 资源模型
+		&resource.HostModel{},
+		&resource.PackageModel{},
+
+		// mon模型
+		&mon.MonNodeModel{},
+
+		// mds模型
+		&mds.MdsColonyModel{},
+		&mds.MdsNodeModel{},
+
+		// oes模型
+		&oes.OesColonyModel{},
+		&oes.OesNodeModel{},
+		&oes.OesColonyExportModel{},
+		&oes.OesWorkflowRunModel{},
+		&oes.OesWorkflowRunStepModel{},
+
+		// 系统模型
+		&system.AnalyticsEventModel{},
+		&system.AuditRecordModel{},
+		&system.MaintenanceWindowModel{},
+	}
+}
+
+// addColumnIfMissing 新增字段, 新部署的数据库已由初始迁移按最新模型建表时跳过
+func addColumnIfMissing(tx *gorm.DB, m any, field string) error {
+	if tx.Migrator().HasColumn(m, field) {
+		return nil
+	}
+	return tx.Migrator().AddColumn(m, field)
+}
+
+// NewMigrator 创建程序内置迁移的执行器
+func NewMigrator(db *gorm.DB) *database.Migrator {
+	return database.NewMigrator(db, Migrations)
+}
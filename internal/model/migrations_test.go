@@ -0,0 +1,32 @@
+package model
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gin-artweb/internal/shared/test"
+)
+
+// TestMigrationsCreateAllModels 新部署时按顺序执行全部迁移后, 每个模型都应该有对应的数据表和字段
+func TestMigrationsCreateAllModels(t *testing.T) {
+	db := test.NewTestGormDBWithConfig(nil)
+	_, err := NewMigrator(db).Up(context.Background())
+	require.NoError(t, err)
+
+	for _, m := range Models() {
+		stmt := db.Model(m).Statement
+		require.NoError(t, stmt.Parse(m))
+		table := stmt.Schema.Table
+		if !assert.True(t, db.Migrator().HasTable(m), "数据表%s应该由迁移创建", table) {
+			continue
+		}
+		for _, f := range stmt.Schema.Fields {
+			if f.DBName != "" {
+				assert.True(t, db.Migrator().HasColumn(m, f.DBName), "数据表%s应该包含字段%s", table, f.DBName)
+			}
+		}
+	}
+}
@@ -9,26 +9,40 @@ import (
 	"gin-artweb/internal/model/mon"
 	"gin-artweb/internal/model/oes"
 	"gin-artweb/internal/model/resource"
+	"gin-artweb/internal/model/system"
+	"gin-artweb/internal/shared/events"
 )
 
-func DBAutoMigrate(db *gorm.DB) error {
-	return db.AutoMigrate(
+// Models 全部数据库模型, 备份时按模型之间的外键依赖排序导出
+func Models() []any {
+	return []any{
 		// 客户模型
 		&customer.ApiModel{},
 		&customer.MenuModel{},
 		&customer.ButtonModel{},
+		&customer.DepartmentModel{},
 		&customer.RoleModel{},
 		&customer.UserModel{},
+		&customer.UserGroupModel{},
 		&customer.LoginRecordModel{},
+		&customer.CasbinModelModel{},
+		&customer.SigningKeyModel{},
+		&customer.UserIdentityModel{},
+		&customer.UserSessionModel{},
+		&customer.UserPreferenceModel{},
 
 		// 任务模型
 		&jobs.ScriptModel{},
 		&jobs.ScriptRecordModel{},
 		&jobs.ScheduleModel{},
+		&jobs.TradingHolidayModel{},
+		&jobs.ScheduleSkipModel{},
+		&jobs.ArtifactModel{},
 
 		// 资源模型
 		&resource.HostModel{},
 		&resource.PackageModel{},
+		&resource.PackageUploadModel{},
 
 		// mon模型
 		&mon.MonNodeModel{},
@@ -36,9 +50,53 @@ func DBAutoMigrate(db *gorm.DB) error {
 		// mds模型
 		&mds.MdsColonyModel{},
 		&mds.MdsNodeModel{},
+		&mds.MdsIngestSourceModel{},
+		&mds.MdsIngestRecordModel{},
+		&mds.MdsBackfillRunModel{},
+		&mds.MdsBackfillDayModel{},
 
 		// oes模型
 		&oes.OesColonyModel{},
 		&oes.OesNodeModel{},
-	)
+		&oes.OesColonyExportModel{},
+		&oes.OesWorkflowRunModel{},
+		&oes.OesWorkflowRunStepModel{},
+		&oes.OesConfTemplateModel{},
+		&oes.OesColonyDriftModel{},
+		&oes.OesReconcileReportModel{},
+		&oes.OesReconcileFileModel{},
+		&oes.OesRunbookModel{},
+		&oes.OesRunbookStepModel{},
+		&oes.OesRunbookExecModel{},
+		&oes.OesRunbookExecStepModel{},
+		&oes.OesTaskSlaModel{},
+		&oes.OesTaskSlaResultModel{},
+		&oes.OesTaskCatalogModel{},
+
+		// 系统模型
+		&system.AnalyticsEventModel{},
+		&system.AuditRecordModel{},
+		&system.MaintenanceWindowModel{},
+		&system.ChangeFreezeModel{},
+		&system.SlowQueryModel{},
+		&system.WebhookSubscriptionModel{},
+		&system.WebhookDeliveryModel{},
+		&system.TaskModel{},
+		&system.FeatureFlagModel{},
+		&events.OutboxModel{},
+		&resource.TerminalSessionModel{},
+		&resource.HostPathRuleModel{},
+		&resource.HostAgentModel{},
+		&mon.HostMetricModel{},
+		&resource.WatchdogModel{},
+		&resource.WatchdogEventModel{},
+		&oes.OesLinkModel{},
+		&oes.OesLinkProbeModel{},
+		&system.CertMonitorModel{},
+		&system.BackupModel{},
+	}
+}
+
+func DBAutoMigrate(db *gorm.DB) error {
+	return db.AutoMigrate(Models()...)
 }
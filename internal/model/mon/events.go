@@ -0,0 +1,17 @@
+package mon
+
+import "gin-artweb/internal/shared/events"
+
+// AlertFiredEvent 告警发出事件
+type AlertFiredEvent struct {
+	Kind      string `json:"kind"`
+	MonNodeID uint32 `json:"mon_node_id"`
+	From      string `json:"from"`
+	To        string `json:"to"`
+	Message   string `json:"message"`
+	CheckedAt string `json:"checked_at"`
+}
+
+func (e AlertFiredEvent) EventType() string {
+	return events.AlertFired
+}
@@ -0,0 +1,204 @@
+package mon
+
+import (
+	"time"
+
+	"go.uber.org/zap/zapcore"
+
+	"gin-artweb/internal/model/common"
+	"gin-artweb/internal/shared/database"
+)
+
+// 主机指标的聚合粒度(秒)
+const (
+	HostMetricStepRaw  uint32 = 0    // 原始采集数据
+	HostMetricStep5Min uint32 = 300  // 5分钟平均值
+	HostMetricStepHour uint32 = 3600 // 1小时平均值
+)
+
+// HostMetricModel 主机基础指标的时序数据
+//
+// 原始数据由主机代理随心跳上报或通过SSH采集, 后台任务按5分钟和1小时聚合为平均值,
+// 各粒度按配置的保留时间清理
+type HostMetricModel struct {
+	database.BaseModel
+	HostID      uint32  `gorm:"column:host_id;not null;index:idx_host_metric_point;comment:主机ID" json:"host_id"`
+	Step        uint32  `gorm:"column:step;not null;default:0;index:idx_host_metric_point;comment:聚合粒度(秒, 0表示原始数据)" json:"step"`
+	Ts          int64   `gorm:"column:ts;not null;index:idx_host_metric_point;comment:时间(Unix秒)" json:"ts"`
+	CPUPercent  float64 `gorm:"column:cpu_percent;comment:CPU使用率(%)" json:"cpu_percent"`
+	MemPercent  float64 `gorm:"column:mem_percent;comment:内存使用率(%)" json:"mem_percent"`
+	DiskPercent float64 `gorm:"column:disk_percent;comment:磁盘使用率(%)" json:"disk_percent"`
+	Load1       float64 `gorm:"column:load1;comment:1分钟平均负载" json:"load1"`
+	Load5       float64 `gorm:"column:load5;comment:5分钟平均负载" json:"load5"`
+	Load15      float64 `gorm:"column:load15;comment:15分钟平均负载" json:"load15"`
+	Samples     uint32  `gorm:"column:samples;not null;default:1;comment:聚合的原始数据条数" json:"samples"`
+}
+
+func (m *HostMetricModel) TableName() string {
+	return "mon_host_metric"
+}
+
+func (m *HostMetricModel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if m == nil {
+		return nil
+	}
+	if err := m.BaseModel.MarshalLogObject(enc); err != nil {
+		return err
+	}
+	enc.AddUint32("host_id", m.HostID)
+	enc.AddUint32("step", m.Step)
+	enc.AddInt64("ts", m.Ts)
+	enc.AddFloat64("cpu_percent", m.CPUPercent)
+	enc.AddFloat64("mem_percent", m.MemPercent)
+	enc.AddFloat64("disk_percent", m.DiskPercent)
+	enc.AddFloat64("load1", m.Load1)
+	return nil
+}
+
+// DownsampleHostMetrics 按目标粒度将指标分桶并计算平均值, 每个桶的时间为桶的开始时间
+//
+// 源数据已是聚合数据时按其聚合的原始数据条数加权
+func DownsampleHostMetrics(ms []HostMetricModel, step uint32) []HostMetricModel {
+	if step == 0 {
+		return nil
+	}
+	type bucketKey struct {
+		hostID uint32
+		ts     int64
+	}
+	var keys []bucketKey
+	sums := make(map[bucketKey]*HostMetricModel)
+	for _, m := range ms {
+		weight := float64(max(m.Samples, 1))
+		key := bucketKey{hostID: m.HostID, ts: m.Ts - m.Ts%int64(step)}
+		sum, ok := sums[key]
+		if !ok {
+			sum = &HostMetricModel{HostID: key.hostID, Step: step, Ts: key.ts}
+			sums[key] = sum
+			keys = append(keys, key)
+		}
+		sum.CPUPercent += m.CPUPercent * weight
+		sum.MemPercent += m.MemPercent * weight
+		sum.DiskPercent += m.DiskPercent * weight
+		sum.Load1 += m.Load1 * weight
+		sum.Load5 += m.Load5 * weight
+		sum.Load15 += m.Load15 * weight
+		sum.Samples += max(m.Samples, 1)
+	}
+
+	out := make([]HostMetricModel, 0, len(keys))
+	for _, key := range keys {
+		sum := sums[key]
+		n := float64(sum.Samples)
+		sum.CPUPercent /= n
+		sum.MemPercent /= n
+		sum.DiskPercent /= n
+		sum.Load1 /= n
+		sum.Load5 /= n
+		sum.Load15 /= n
+		out = append(out, *sum)
+	}
+	return out
+}
+
+// HostMetricRange 指标查询的时间范围和使用的聚合粒度
+type HostMetricRange struct {
+	Duration time.Duration
+	Step     uint32
+}
+
+// HostMetricRanges 支持的指标查询时间范围
+var HostMetricRanges = map[string]HostMetricRange{
+	"1h":  {Duration: time.Hour, Step: HostMetricStepRaw},
+	"6h":  {Duration: 6 * time.Hour, Step: HostMetricStepRaw},
+	"24h": {Duration: 24 * time.Hour, Step: HostMetricStep5Min},
+	"7d":  {Duration: 7 * 24 * time.Hour, Step: HostMetricStep5Min},
+	"30d": {Duration: 30 * 24 * time.Hour, Step: HostMetricStepHour},
+	"90d": {Duration: 90 * 24 * time.Hour, Step: HostMetricStepHour},
+}
+
+// QueryHostMetricRequest 用于查询mon节点主机指标的请求结构体
+//
+// swagger:model QueryHostMetricRequest
+type QueryHostMetricRequest struct {
+	// 时间范围(1h、6h使用原始数据, 24h、7d使用5分钟平均值, 30d、90d使用1小时平均值), 默认1h
+	Range string `form:"range" binding:"omitempty,oneof=1h 6h 24h 7d 30d 90d"`
+}
+
+type HostMetricPointOut struct {
+	// 时间(Unix秒)
+	Ts int64 `json:"ts" example:"1700000000"`
+
+	// CPU使用率(%)
+	CPUPercent float64 `json:"cpu_percent" example:"12.5"`
+
+	// 内存使用率(%)
+	MemPercent float64 `json:"mem_percent" example:"45.2"`
+
+	// 磁盘使用率(%)
+	DiskPercent float64 `json:"disk_percent" example:"60.1"`
+
+	// 1分钟平均负载
+	Load1 float64 `json:"load1" example:"0.5"`
+
+	// 5分钟平均负载
+	Load5 float64 `json:"load5" example:"0.4"`
+
+	// 15分钟平均负载
+	Load15 float64 `json:"load15" example:"0.3"`
+}
+
+type HostMetricSeriesOut struct {
+	// mon节点ID
+	MonNodeID uint32 `json:"mon_node_id" example:"1"`
+
+	// 主机ID
+	HostID uint32 `json:"host_id" example:"1"`
+
+	// 时间范围
+	Range string `json:"range" example:"1h"`
+
+	// 聚合粒度(秒, 0表示原始数据)
+	Step uint32 `json:"step" example:"0"`
+
+	// 开始时间(Unix秒)
+	Start int64 `json:"start" example:"1700000000"`
+
+	// 结束时间(Unix秒)
+	End int64 `json:"end" example:"1700003600"`
+
+	// 数据点, 按时间升序
+	Points []HostMetricPointOut `json:"points"`
+}
+
+// HostMetricSeriesReply 主机指标响应结构
+type HostMetricSeriesReply = common.APIReply[HostMetricSeriesOut]
+
+func HostMetricToPointOut(
+	m HostMetricModel,
+) *HostMetricPointOut {
+	return &HostMetricPointOut{
+		Ts:          m.Ts,
+		CPUPercent:  m.CPUPercent,
+		MemPercent:  m.MemPercent,
+		DiskPercent: m.DiskPercent,
+		Load1:       m.Load1,
+		Load5:       m.Load5,
+		Load15:      m.Load15,
+	}
+}
+
+func ListHostMetricToPointOut(
+	rms *[]HostMetricModel,
+) []HostMetricPointOut {
+	if rms == nil {
+		return []HostMetricPointOut{}
+	}
+
+	ms := *rms
+	mso := make([]HostMetricPointOut, 0, len(ms))
+	for _, m := range ms {
+		mso = append(mso, *HostMetricToPointOut(m))
+	}
+	return mso
+}
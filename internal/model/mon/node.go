@@ -19,8 +19,27 @@ type MonNodeModel struct {
 	URL         string             `gorm:"column:url;type:varchar(150);not null;uniqueIndex;comment:URL地址" json:"url"`
 	HostID      uint32             `gorm:"column:host_id;not null;comment:主机ID" json:"host_id"`
 	Host        resource.HostModel `gorm:"foreignKey:HostID;references:ID;constraint:OnDelete:CASCADE" json:"host"`
+
+	// Prometheus数据源
+	PromURL         string `gorm:"column:prom_url;type:varchar(255);comment:Prometheus地址" json:"prom_url"`
+	PromUsername    string `gorm:"column:prom_username;type:varchar(50);comment:Prometheus认证用户名" json:"prom_username"`
+	PromPassword    string `gorm:"column:prom_password;type:varchar(512);serializer:encrypted;comment:Prometheus认证密码" json:"-"`
+	PromBearerToken string `gorm:"column:prom_bearer_token;type:varchar(2048);serializer:encrypted;comment:Prometheus认证令牌" json:"-"`
+
+	// 健康状态, 由后台任务从Prometheus同步
+	Health          string     `gorm:"column:health;type:varchar(10);default:unknown;comment:健康状态" json:"health"`
+	HealthMessage   string     `gorm:"column:health_message;type:varchar(254);comment:健康检查信息" json:"health_message"`
+	LastScrapeAt    *time.Time `gorm:"column:last_scrape_at;comment:最近一次采集时间" json:"last_scrape_at"`
+	HealthCheckedAt *time.Time `gorm:"column:health_checked_at;comment:最近一次健康检查时间" json:"health_checked_at"`
 }
 
+// 健康状态
+const (
+	HealthUnknown = "unknown" // 未配置数据源或尚未检查
+	HealthUp      = "up"      // 采集目标正常
+	HealthDown    = "down"    // 采集目标异常或数据源不可达
+)
+
 func (m *MonNodeModel) TableName() string {
 	return "mon_node"
 }
@@ -35,6 +54,9 @@ func (m *MonNodeModel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 	enc.AddString("java_home", m.JavaHome)
 	enc.AddString("url", m.URL)
 	enc.AddUint32("host_id", m.HostID)
+	enc.AddString("prom_url", m.PromURL)
+	enc.AddString("prom_username", m.PromUsername)
+	enc.AddString("health", m.Health)
 	return nil
 }
 
@@ -80,6 +102,44 @@ type CreateOrUpdateMonNodeRequest struct {
 
 	// 主机ID
 	HostID uint32 `json:"host_id" form:"host_id" binding:"required"`
+
+	// Prometheus地址
+	PromURL string `json:"prom_url" form:"prom_url" binding:"omitempty,url,max=255"`
+
+	// Prometheus认证用户名
+	PromUsername string `json:"prom_username" form:"prom_username" binding:"omitempty,max=50"`
+
+	// Prometheus认证密码, 更新时为空表示不修改
+	PromPassword string `json:"prom_password" form:"prom_password" binding:"omitempty,max=255"`
+
+	// Prometheus认证令牌, 更新时为空表示不修改
+	PromBearerToken string `json:"prom_bearer_token" form:"prom_bearer_token" binding:"omitempty,max=1024"`
+}
+
+// QueryMonNodeRequest 用于通过mon节点数据源执行PromQL查询的请求结构体
+// 同时提供start、end、step时执行范围查询, 否则执行即时查询
+//
+// swagger:model QueryMonNodeRequest
+type QueryMonNodeRequest struct {
+	// PromQL查询语句
+	Query string `form:"query" binding:"required,max=4096"`
+
+	// 即时查询时间(RFC3339或Unix时间戳)
+	Time string `form:"time" binding:"omitempty,max=64"`
+
+	// 范围查询开始时间(RFC3339或Unix时间戳)
+	Start string `form:"start" binding:"omitempty,max=64"`
+
+	// 范围查询结束时间(RFC3339或Unix时间戳)
+	End string `form:"end" binding:"omitempty,max=64"`
+
+	// 范围查询步长, 如 15s
+	Step string `form:"step" binding:"omitempty,max=32"`
+}
+
+// IsRange 是否为范围查询
+func (req *QueryMonNodeRequest) IsRange() bool {
+	return req.Start != "" && req.End != "" && req.Step != ""
 }
 
 // ListMonNodeRequest 用于获取mon节点列表的请求结构体
@@ -125,6 +185,21 @@ type MonNodeBaseOut struct {
 
 	// URL地址
 	URL string `json:"url" example:"http://192.168.11.189:8080"`
+
+	// Prometheus地址
+	PromURL string `json:"prom_url" example:"http://192.168.11.189:9090"`
+
+	// 健康状态
+	Health string `json:"health" example:"up"`
+
+	// 健康检查信息
+	HealthMessage string `json:"health_message" example:""`
+
+	// 最近一次采集时间
+	LastScrapeAt string `json:"last_scrape_at" example:"2023-01-01 12:00:00"`
+
+	// 最近一次健康检查时间
+	HealthCheckedAt string `json:"health_checked_at" example:"2023-01-01 12:00:00"`
 }
 
 type MonNodeStandardOut struct {
@@ -150,17 +225,32 @@ type MonNodeReply = common.APIReply[MonNodeDetailOut]
 // PagMonNodeReply 程序包的分页响应结构
 type PagMonNodeReply = common.APIReply[*common.Pag[MonNodeDetailOut]]
 
+// MonNodeQueryReply PromQL查询响应结构, 数据为Prometheus返回的原始data字段
+type MonNodeQueryReply = common.APIReply[map[string]any]
+
 func MonNodeToBaseOut(
 	m MonNodeModel,
 ) *MonNodeBaseOut {
 	return &MonNodeBaseOut{
-		ID:          m.ID,
-		Name:        m.Name,
-		DeployPath:  m.DeployPath,
-		OutportPath: m.OutportPath,
-		JavaHome:    m.JavaHome,
-		URL:         m.URL,
+		ID:              m.ID,
+		Name:            m.Name,
+		DeployPath:      m.DeployPath,
+		OutportPath:     m.OutportPath,
+		JavaHome:        m.JavaHome,
+		URL:             m.URL,
+		PromURL:         m.PromURL,
+		Health:          m.Health,
+		HealthMessage:   m.HealthMessage,
+		LastScrapeAt:    formatTimePtr(m.LastScrapeAt),
+		HealthCheckedAt: formatTimePtr(m.HealthCheckedAt),
+	}
+}
+
+func formatTimePtr(t *time.Time) string {
+	if t == nil {
+		return ""
 	}
+	return t.Format(time.DateTime)
 }
 
 func MonNodeToStandardOut(
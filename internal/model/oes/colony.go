@@ -92,6 +92,42 @@ type CreateOrUpdateOesColonyRequest struct {
 
 	// mon节点ID
 	MonNodeID uint32 `json:"mon_node_id" form:"mon_node_id" binding:"required"`
+
+	// 版本号, 更新时必填(也可通过If-Match请求头传递)
+	Version uint32 `json:"version" form:"version" binding:"omitempty"`
+}
+
+// BatchUpdateOesColonyRequest 用于批量更新oes集群的请求结构体
+// 仅更新请求中提供的字段
+//
+// swagger:model BatchUpdateOesColonyRequest
+type BatchUpdateOesColonyRequest struct {
+	// oes集群ID列表
+	IDs []uint32 `json:"ids" binding:"required,min=1,max=200,dive,gt=0"`
+
+	// 是否启用
+	IsEnable *bool `json:"is_enable"`
+
+	// 程序包ID
+	PackageID *uint32 `json:"package_id" binding:"omitempty,gt=0"`
+
+	// mon节点ID
+	MonNodeID *uint32 `json:"mon_node_id" binding:"omitempty,gt=0"`
+}
+
+// ToMap 返回需要更新的字段
+func (req *BatchUpdateOesColonyRequest) ToMap() map[string]any {
+	data := make(map[string]any, 3)
+	if req.IsEnable != nil {
+		data["is_enable"] = *req.IsEnable
+	}
+	if req.PackageID != nil {
+		data["package_id"] = *req.PackageID
+	}
+	if req.MonNodeID != nil {
+		data["mon_node_id"] = *req.MonNodeID
+	}
+	return data
 }
 
 // ListOesColonyRequest 用于获取mon节点列表的请求结构体
@@ -174,6 +210,9 @@ type OesColonyStandardOut struct {
 
 	// 更新时间
 	UpdatedAt string `json:"updated_at" example:"2023-01-01 12:00:00"`
+
+	// 版本号
+	Version uint32 `json:"version" example:"1"`
 }
 
 type OesColonyDetailOut struct {
@@ -195,6 +234,21 @@ type OesColonyReply = common.APIReply[OesColonyDetailOut]
 // PagOesColonyReply 程序包的分页响应结构
 type PagOesColonyReply = common.APIReply[*common.Pag[OesColonyDetailOut]]
 
+// OesColonyBatchResultOut 批量操作中单个集群的执行结果
+type OesColonyBatchResultOut struct {
+	// oes集群ID
+	ID uint32 `json:"id" example:"1"`
+
+	// 是否成功
+	Success bool `json:"success" example:"true"`
+
+	// 失败原因
+	Message string `json:"message" example:""`
+}
+
+// BatchOesColonyReply 批量操作oes集群的响应结构
+type BatchOesColonyReply = common.APIReply[[]OesColonyBatchResultOut]
+
 // oes 任务状态
 type OesColonyTaskInfo struct {
 	// 集群号
@@ -226,6 +280,7 @@ func OesColonyToStandardOut(
 		OesColonyBaseOut: *OesColonyToBaseOut(m),
 		CreatedAt:        m.CreatedAt.Format(time.DateTime),
 		UpdatedAt:        m.UpdatedAt.Format(time.DateTime),
+		Version:          m.Version,
 	}
 }
 
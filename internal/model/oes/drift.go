@@ -0,0 +1,152 @@
+package oes
+
+import (
+	"fmt"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+
+	"gin-artweb/internal/model/common"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/events"
+)
+
+// 配置漂移类型
+const (
+	DriftKindConf    = "conf"    // 配置文件与模板渲染结果不一致
+	DriftKindPackage = "package" // 程序包版本与集群记录不一致
+)
+
+// 配置漂移的实际值, 无法读取时代替校验和或版本号
+const (
+	DriftActualMissing = "missing" // 文件不存在
+	DriftActualError   = "error"   // 渲染或读取失败, 详见Message
+)
+
+// AlertKindColonyDrift 集群配置漂移告警
+const AlertKindColonyDrift = "colony_drift"
+
+// OesColonyDriftModel oes集群配置漂移
+//
+// 每次检测后替换集群的全部漂移记录, 持续存在的漂移保留首次发现时间
+type OesColonyDriftModel struct {
+	database.StandardModel
+	OesColonyID uint32    `gorm:"column:oes_colony_id;not null;index;comment:oes集群ID" json:"oes_colony_id"`
+	NodeID      uint32    `gorm:"column:node_id;not null;comment:oes节点ID" json:"node_id"`
+	HostID      uint32    `gorm:"column:host_id;not null;comment:主机ID" json:"host_id"`
+	Kind        string    `gorm:"column:kind;type:varchar(10);not null;comment:漂移类型" json:"kind"`
+	Name        string    `gorm:"column:name;type:varchar(50);comment:配置模板名称或程序包标签" json:"name"`
+	RemotePath  string    `gorm:"column:remote_path;type:varchar(512);comment:目标文件路径" json:"remote_path"`
+	Expected    string    `gorm:"column:expected;type:varchar(64);comment:期望的校验和或版本号" json:"expected"`
+	Actual      string    `gorm:"column:actual;type:varchar(64);comment:实际的校验和或版本号" json:"actual"`
+	Message     string    `gorm:"column:message;type:varchar(254);comment:说明" json:"message"`
+	DetectedAt  time.Time `gorm:"column:detected_at;not null;comment:首次发现时间" json:"detected_at"`
+}
+
+func (m *OesColonyDriftModel) TableName() string {
+	return "oes_colony_drift"
+}
+
+func (m *OesColonyDriftModel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if m == nil {
+		return nil
+	}
+	if err := m.StandardModel.MarshalLogObject(enc); err != nil {
+		return err
+	}
+	enc.AddUint32("oes_colony_id", m.OesColonyID)
+	enc.AddUint32("node_id", m.NodeID)
+	enc.AddUint32("host_id", m.HostID)
+	enc.AddString("kind", m.Kind)
+	enc.AddString("name", m.Name)
+	enc.AddString("remote_path", m.RemotePath)
+	enc.AddString("expected", m.Expected)
+	enc.AddString("actual", m.Actual)
+	return nil
+}
+
+// Key 区分同一集群内不同漂移项的键, 用于判断漂移是否为新出现
+func (m *OesColonyDriftModel) Key() string {
+	return fmt.Sprintf("%s|%d|%s|%s", m.Kind, m.NodeID, m.Name, m.RemotePath)
+}
+
+type OesColonyDriftOut struct {
+	// 漂移类型(conf/package)
+	Kind string `json:"kind" example:"conf"`
+
+	// 节点ID
+	NodeID uint32 `json:"node_id" example:"1"`
+
+	// 主机ID
+	HostID uint32 `json:"host_id" example:"1"`
+
+	// 配置模板名称或程序包标签
+	Name string `json:"name" example:"oes.conf"`
+
+	// 目标文件路径
+	RemotePath string `json:"remote_path" example:"/home/quant/oes_01/conf/oes.conf"`
+
+	// 期望的校验和或版本号
+	Expected string `json:"expected" example:"0.17.5"`
+
+	// 实际的校验和或版本号, missing表示文件不存在, error表示检测失败
+	Actual string `json:"actual" example:"0.17.4"`
+
+	// 说明
+	Message string `json:"message" example:""`
+
+	// 首次发现时间
+	DetectedAt string `json:"detected_at" example:"2023-01-01 12:00:00"`
+
+	// 最近检测时间
+	CheckedAt string `json:"checked_at" example:"2023-01-01 12:30:00"`
+}
+
+// OesColonyDriftReply 集群配置漂移响应结构
+type OesColonyDriftReply = common.APIReply[*[]OesColonyDriftOut]
+
+// ColonyDriftEvent 集群出现新的配置漂移时发出的告警事件
+type ColonyDriftEvent struct {
+	Kind      string              `json:"kind"`
+	Project   string              `json:"project"`
+	ID        uint32              `json:"id"`
+	ColonyNum string              `json:"colony_num"`
+	Drifts    []OesColonyDriftOut `json:"drifts"`
+	CheckedAt string              `json:"checked_at"`
+}
+
+func (e ColonyDriftEvent) EventType() string {
+	return events.AlertFired
+}
+
+func OesColonyDriftToOut(
+	m OesColonyDriftModel,
+) *OesColonyDriftOut {
+	return &OesColonyDriftOut{
+		Kind:       m.Kind,
+		NodeID:     m.NodeID,
+		HostID:     m.HostID,
+		Name:       m.Name,
+		RemotePath: m.RemotePath,
+		Expected:   m.Expected,
+		Actual:     m.Actual,
+		Message:    m.Message,
+		DetectedAt: m.DetectedAt.Format(time.DateTime),
+		CheckedAt:  m.CreatedAt.Format(time.DateTime),
+	}
+}
+
+func ListOesColonyDriftToOut(
+	rms *[]OesColonyDriftModel,
+) *[]OesColonyDriftOut {
+	if rms == nil {
+		return &[]OesColonyDriftOut{}
+	}
+
+	ms := *rms
+	mso := make([]OesColonyDriftOut, 0, len(ms))
+	for _, m := range ms {
+		mso = append(mso, *OesColonyDriftToOut(m))
+	}
+	return &mso
+}
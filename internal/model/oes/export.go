@@ -0,0 +1,120 @@
+package oes
+
+import (
+	"fmt"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+
+	"gin-artweb/internal/model/common"
+	"gin-artweb/internal/shared/database"
+)
+
+// 集群导出状态
+const (
+	ExportStatusPending = "pending" // 等待生成
+	ExportStatusRunning = "running" // 生成中
+	ExportStatusSuccess = "success" // 生成成功
+	ExportStatusFailed  = "failed"  // 生成失败
+)
+
+// OesColonyExportModel oes集群运维历史导出记录
+type OesColonyExportModel struct {
+	database.StandardModel
+	OesColonyID  uint32 `gorm:"column:oes_colony_id;not null;index;comment:oes集群ID" json:"oes_colony_id"`
+	ColonyNum    string `gorm:"column:colony_num;type:varchar(2);comment:集群号" json:"colony_num"`
+	Status       string `gorm:"column:status;type:varchar(10);not null;default:pending;comment:导出状态" json:"status"`
+	Filename     string `gorm:"column:filename;type:varchar(255);comment:归档文件名" json:"filename"`
+	Size         int64  `gorm:"column:size;comment:归档文件大小(字节)" json:"size"`
+	ErrorMessage string `gorm:"column:error_message;type:text;comment:错误信息" json:"error_message"`
+	Username     string `gorm:"column:username;type:varchar(50);comment:用户名" json:"username"`
+}
+
+func (m *OesColonyExportModel) TableName() string {
+	return "oes_colony_export"
+}
+
+func (m *OesColonyExportModel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if m == nil {
+		return nil
+	}
+	if err := m.StandardModel.MarshalLogObject(enc); err != nil {
+		return err
+	}
+	enc.AddUint32("oes_colony_id", m.OesColonyID)
+	enc.AddString("colony_num", m.ColonyNum)
+	enc.AddString("status", m.Status)
+	enc.AddString("filename", m.Filename)
+	enc.AddInt64("size", m.Size)
+	enc.AddString("username", m.Username)
+	return nil
+}
+
+type OesColonyExportOut struct {
+	// ID
+	ID uint32 `json:"id" example:"1"`
+
+	// oes集群ID
+	OesColonyID uint32 `json:"oes_colony_id" example:"1"`
+
+	// 集群号
+	ColonyNum string `json:"colony_num" example:"01"`
+
+	// 导出状态(pending/running/success/failed)
+	Status string `json:"status" example:"success"`
+
+	// 归档文件名
+	Filename string `json:"filename" example:"oes_colony_01_20230101120000.tar.gz"`
+
+	// 归档文件大小(字节)
+	Size int64 `json:"size" example:"1024"`
+
+	// 错误信息
+	ErrorMessage string `json:"error_message" example:""`
+
+	// 用户名
+	Username string `json:"username" example:"admin"`
+
+	// 下载地址, 导出成功后返回
+	DownloadURL string `json:"download_url" example:"/api/v1/oes/colony/export/1/download"`
+
+	// 创建时间
+	CreatedAt string `json:"created_at" example:"2023-01-01 12:00:00"`
+
+	// 更新时间
+	UpdatedAt string `json:"updated_at" example:"2023-01-01 12:00:00"`
+}
+
+// OesColonyExportReply 集群导出响应结构
+type OesColonyExportReply = common.APIReply[OesColonyExportOut]
+
+// OesColonyExportManifest 导出归档中的清单文件
+type OesColonyExportManifest struct {
+	ExportID    uint32            `json:"export_id"`
+	ColonyID    uint32            `json:"colony_id"`
+	ColonyNum   string            `json:"colony_num"`
+	GeneratedAt string            `json:"generated_at"`
+	Username    string            `json:"username"`
+	Sections    map[string]string `json:"sections"`
+}
+
+func OesColonyExportToOut(
+	m OesColonyExportModel,
+) *OesColonyExportOut {
+	out := &OesColonyExportOut{
+		ID:           m.ID,
+		OesColonyID:  m.OesColonyID,
+		ColonyNum:    m.ColonyNum,
+		Status:       m.Status,
+		Filename:     m.Filename,
+		Size:         m.Size,
+		ErrorMessage: m.ErrorMessage,
+		Username:     m.Username,
+		CreatedAt:    m.CreatedAt.Format(time.DateTime),
+		UpdatedAt:    m.UpdatedAt.Format(time.DateTime),
+	}
+	if m.Status == ExportStatusSuccess {
+		out.DownloadURL = fmt.Sprintf("/api/v1/oes/colony/export/%d/download", m.ID)
+	}
+	return out
+}
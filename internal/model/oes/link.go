@@ -0,0 +1,529 @@
+package oes
+
+import (
+	"cmp"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+
+	"gin-artweb/internal/model/common"
+	"gin-artweb/internal/model/resource"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/events"
+)
+
+// AlertKindLinkUnreachable 集群组件之间的网络链路不可达告警
+const AlertKindLinkUnreachable = "link_unreachable"
+
+// LinkSourcePlatform 源主机为空时由平台所在服务器发起探测
+const LinkSourcePlatform = "platform"
+
+// 链路的探测状态
+const (
+	LinkUnknown     = "unknown"     // 尚未探测或源主机无法连接
+	LinkReachable   = "reachable"   // 目标端口可以建立TCP连接
+	LinkUnreachable = "unreachable" // 目标端口无法建立TCP连接
+)
+
+// OesLinkModel 集群组件之间期望可达的TCP链路, 例如节点到XCounter端口、节点到交易所网关
+//
+// 源主机为空时由平台所在服务器探测, 否则通过ssh在源主机上探测
+type OesLinkModel struct {
+	database.StandardModel
+	Name          string              `gorm:"column:name;type:varchar(50);not null;uniqueIndex;comment:名称" json:"name"`
+	OesColonyID   uint32              `gorm:"column:oes_colony_id;not null;default:0;index;comment:oes集群ID, 0表示不属于集群" json:"oes_colony_id"`
+	SourceHostID  *uint32             `gorm:"column:source_host_id;index;comment:源主机ID, 为空表示平台" json:"source_host_id"`
+	SourceHost    *resource.HostModel `gorm:"foreignKey:SourceHostID;references:ID;constraint:OnDelete:CASCADE" json:"source_host"`
+	TargetName    string              `gorm:"column:target_name;type:varchar(50);not null;comment:目标名称" json:"target_name"`
+	TargetHost    string              `gorm:"column:target_host;type:varchar(255);not null;comment:目标地址" json:"target_host"`
+	TargetPort    uint16              `gorm:"column:target_port;not null;comment:目标端口" json:"target_port"`
+	TimeoutMs     int                 `gorm:"column:timeout_ms;not null;default:3000;comment:连接超时(毫秒)" json:"timeout_ms"`
+	IsEnabled     bool                `gorm:"column:is_enabled;type:boolean;index;comment:是否启用" json:"is_enabled"`
+	Status        string              `gorm:"column:status;type:varchar(20);not null;default:unknown;comment:探测状态" json:"status"`
+	LatencyMs     float64             `gorm:"column:latency_ms;comment:最近一次建立连接的耗时(毫秒)" json:"latency_ms"`
+	LastError     string              `gorm:"column:last_error;type:varchar(1024);comment:最近一次探测失败原因" json:"last_error"`
+	LastCheckedAt *time.Time          `gorm:"column:last_checked_at;comment:最近一次探测时间" json:"last_checked_at"`
+	Remark        string              `gorm:"column:remark;type:varchar(254);comment:备注" json:"remark"`
+}
+
+func (m *OesLinkModel) TableName() string {
+	return "oes_link"
+}
+
+func (m *OesLinkModel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if m == nil {
+		return nil
+	}
+	if err := m.StandardModel.MarshalLogObject(enc); err != nil {
+		return err
+	}
+	enc.AddString("name", m.Name)
+	enc.AddUint32("oes_colony_id", m.OesColonyID)
+	if m.SourceHostID != nil {
+		enc.AddUint32("source_host_id", *m.SourceHostID)
+	}
+	enc.AddString("target_name", m.TargetName)
+	enc.AddString("target", m.Target())
+	enc.AddInt("timeout_ms", m.TimeoutMs)
+	enc.AddBool("is_enabled", m.IsEnabled)
+	enc.AddString("status", m.Status)
+	return nil
+}
+
+// Source 返回探测发起方的名称, 需要预加载SourceHost
+func (m *OesLinkModel) Source() string {
+	if m.SourceHost == nil {
+		return LinkSourcePlatform
+	}
+	return m.SourceHost.Name
+}
+
+// Target 返回目标的host:port
+func (m *OesLinkModel) Target() string {
+	return net.JoinHostPort(m.TargetHost, strconv.Itoa(int(m.TargetPort)))
+}
+
+// ProbeCommand 返回在源主机上探测目标端口的命令, 成功时输出建立连接的耗时(微秒)
+//
+// 目标地址已校验为主机名或IP, 可以直接拼接到命令中
+func (m *OesLinkModel) ProbeCommand() string {
+	return fmt.Sprintf(
+		`timeout %.1f bash -c 's=$(date +%%s%%N); </dev/tcp/%s/%d && echo $(( ($(date +%%s%%N)-s)/1000 ))'`,
+		float64(m.TimeoutMs)/1000, m.TargetHost, m.TargetPort,
+	)
+}
+
+// OesLinkProbeModel 链路的探测记录
+type OesLinkProbeModel struct {
+	database.BaseModel
+	LinkID    uint32       `gorm:"column:link_id;not null;index;comment:链路ID" json:"link_id"`
+	Link      OesLinkModel `gorm:"foreignKey:LinkID;references:ID;constraint:OnDelete:CASCADE" json:"-"`
+	Status    string       `gorm:"column:status;type:varchar(20);not null;comment:探测状态" json:"status"`
+	LatencyMs float64      `gorm:"column:latency_ms;comment:建立连接的耗时(毫秒)" json:"latency_ms"`
+	Error     string       `gorm:"column:error;type:varchar(1024);comment:探测失败原因" json:"error"`
+	CreatedAt time.Time    `gorm:"column:created_at;autoCreateTime;index;comment:探测时间" json:"created_at"`
+}
+
+func (m *OesLinkProbeModel) TableName() string {
+	return "oes_link_probe"
+}
+
+func (m *OesLinkProbeModel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if m == nil {
+		return nil
+	}
+	if err := m.BaseModel.MarshalLogObject(enc); err != nil {
+		return err
+	}
+	enc.AddUint32("link_id", m.LinkID)
+	enc.AddString("status", m.Status)
+	enc.AddFloat64("latency_ms", m.LatencyMs)
+	enc.AddString("error", m.Error)
+	return nil
+}
+
+// LinkUnreachableEvent 链路不可达的告警事件, 开盘前检查时对仍不可达的链路再次告警
+type LinkUnreachableEvent struct {
+	Kind       string `json:"kind"`
+	Project    string `json:"project"`
+	ID         uint32 `json:"id"`
+	LinkID     uint32 `json:"link_id"`
+	Name       string `json:"name"`
+	Source     string `json:"source"`
+	TargetName string `json:"target_name"`
+	Target     string `json:"target"`
+	Error      string `json:"error"`
+	PreOpen    bool   `json:"pre_open"`
+	CheckedAt  string `json:"checked_at"`
+}
+
+func (e LinkUnreachableEvent) EventType() string {
+	return events.AlertFired
+}
+
+// OesLinkRequest 用于创建或更新链路的请求结构体
+//
+// swagger:model OesLinkRequest
+type OesLinkRequest struct {
+	// 名称
+	Name string `json:"name" binding:"required,max=50"`
+
+	// oes集群ID, 0表示不属于集群
+	OesColonyID uint32 `json:"oes_colony_id"`
+
+	// 源主机ID, 0表示由平台探测
+	SourceHostID uint32 `json:"source_host_id"`
+
+	// 目标名称, 例如XCounter、上交所网关
+	TargetName string `json:"target_name" binding:"required,max=50"`
+
+	// 目标地址, 主机名或IP
+	TargetHost string `json:"target_host" binding:"required,max=255,hostname_rfc1123|ip"`
+
+	// 目标端口
+	TargetPort uint16 `json:"target_port" binding:"required,gt=0"`
+
+	// 连接超时(毫秒), 默认3000
+	TimeoutMs int `json:"timeout_ms" binding:"omitempty,gte=100,lte=30000"`
+
+	// 是否启用
+	IsEnabled bool `json:"is_enabled"`
+
+	// 备注
+	Remark string `json:"remark" binding:"omitempty,max=254"`
+}
+
+func (req *OesLinkRequest) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if req == nil {
+		return nil
+	}
+	enc.AddString("name", req.Name)
+	enc.AddUint32("oes_colony_id", req.OesColonyID)
+	enc.AddUint32("source_host_id", req.SourceHostID)
+	enc.AddString("target_name", req.TargetName)
+	enc.AddString("target_host", req.TargetHost)
+	enc.AddUint16("target_port", req.TargetPort)
+	enc.AddInt("timeout_ms", req.TimeoutMs)
+	enc.AddBool("is_enabled", req.IsEnabled)
+	return nil
+}
+
+func (req *OesLinkRequest) ToModel() OesLinkModel {
+	m := OesLinkModel{
+		Name:        req.Name,
+		OesColonyID: req.OesColonyID,
+		TargetName:  req.TargetName,
+		TargetHost:  req.TargetHost,
+		TargetPort:  req.TargetPort,
+		TimeoutMs:   cmp.Or(req.TimeoutMs, 3000),
+		IsEnabled:   req.IsEnabled,
+		Remark:      req.Remark,
+	}
+	if req.SourceHostID != 0 {
+		m.SourceHostID = &req.SourceHostID
+	}
+	return m
+}
+
+// ListOesLinkRequest 用于查询链路列表的请求结构体
+//
+// swagger:model ListOesLinkRequest
+type ListOesLinkRequest struct {
+	common.StandardModelQuery
+
+	// 名称
+	Name string `form:"name"`
+
+	// oes集群ID
+	OesColonyID *uint32 `form:"oes_colony_id"`
+
+	// 源主机ID, 0表示平台
+	SourceHostID *uint32 `form:"source_host_id"`
+
+	// 探测状态
+	Status string `form:"status" binding:"omitempty,oneof=unknown reachable unreachable"`
+
+	// 是否启用
+	IsEnabled *bool `form:"is_enabled"`
+}
+
+func (req *ListOesLinkRequest) Query() (int, int, map[string]any) {
+	page, size, query := req.StandardModelQuery.QueryMap(10)
+	if req.Name != "" {
+		query["name like ?"] = "%" + req.Name + "%"
+	}
+	if req.OesColonyID != nil {
+		query["oes_colony_id = ?"] = *req.OesColonyID
+	}
+	if req.SourceHostID != nil {
+		query["COALESCE(source_host_id, 0) = ?"] = *req.SourceHostID
+	}
+	if req.Status != "" {
+		query["status = ?"] = req.Status
+	}
+	if req.IsEnabled != nil {
+		query["is_enabled = ?"] = *req.IsEnabled
+	}
+	return page, size, query
+}
+
+// ListOesLinkProbeRequest 用于查询链路探测记录的请求结构体
+//
+// swagger:model ListOesLinkProbeRequest
+type ListOesLinkProbeRequest struct {
+	common.BaseModelQuery
+
+	// 探测状态
+	Status string `form:"status" binding:"omitempty,oneof=unknown reachable unreachable"`
+}
+
+func (req *ListOesLinkProbeRequest) Query() (int, int, map[string]any) {
+	page, size, query := req.BaseModelQuery.QueryMap(20)
+	if req.Status != "" {
+		query["status = ?"] = req.Status
+	}
+	return page, size, query
+}
+
+// OesLinkMatrixRequest 用于查询链路矩阵的请求结构体
+//
+// swagger:model OesLinkMatrixRequest
+type OesLinkMatrixRequest struct {
+	// oes集群ID, 为空时返回全部链路
+	OesColonyID *uint32 `form:"oes_colony_id"`
+}
+
+type OesLinkOut struct {
+	// ID
+	ID uint32 `json:"id" example:"1"`
+
+	// 名称
+	Name string `json:"name" example:"01集群节点1-XCounter"`
+
+	// oes集群ID
+	OesColonyID uint32 `json:"oes_colony_id" example:"1"`
+
+	// 源主机ID, 0表示平台
+	SourceHostID uint32 `json:"source_host_id" example:"1"`
+
+	// 探测发起方, 主机名称或platform
+	Source string `json:"source" example:"oes-node01"`
+
+	// 目标名称
+	TargetName string `json:"target_name" example:"XCounter"`
+
+	// 目标地址
+	TargetHost string `json:"target_host" example:"192.168.1.20"`
+
+	// 目标端口
+	TargetPort uint16 `json:"target_port" example:"6101"`
+
+	// 连接超时(毫秒)
+	TimeoutMs int `json:"timeout_ms" example:"3000"`
+
+	// 是否启用
+	IsEnabled bool `json:"is_enabled" example:"true"`
+
+	// 探测状态(unknown/reachable/unreachable)
+	Status string `json:"status" example:"reachable"`
+
+	// 最近一次建立连接的耗时(毫秒)
+	LatencyMs float64 `json:"latency_ms" example:"0.42"`
+
+	// 最近一次探测失败原因
+	LastError string `json:"last_error" example:""`
+
+	// 最近一次探测时间
+	LastCheckedAt string `json:"last_checked_at" example:"2024-01-02 09:00:00"`
+
+	// 备注
+	Remark string `json:"remark" example:""`
+
+	// 创建时间
+	CreatedAt string `json:"created_at" example:"2023-01-01 12:00:00"`
+
+	// 更新时间
+	UpdatedAt string `json:"updated_at" example:"2023-01-01 12:00:00"`
+}
+
+type OesLinkProbeOut struct {
+	// ID
+	ID uint32 `json:"id" example:"1"`
+
+	// 链路ID
+	LinkID uint32 `json:"link_id" example:"1"`
+
+	// 探测状态
+	Status string `json:"status" example:"unreachable"`
+
+	// 建立连接的耗时(毫秒)
+	LatencyMs float64 `json:"latency_ms" example:"0"`
+
+	// 探测失败原因
+	Error string `json:"error" example:"connect: Connection refused"`
+
+	// 探测时间
+	CreatedAt string `json:"created_at" example:"2024-01-02 09:00:00"`
+}
+
+type OesLinkMatrixCellOut struct {
+	// 链路ID
+	LinkID uint32 `json:"link_id" example:"1"`
+
+	// 名称
+	Name string `json:"name" example:"01集群节点1-XCounter"`
+
+	// 探测发起方, 对应矩阵的行
+	Source string `json:"source" example:"oes-node01"`
+
+	// 目标名称和地址, 对应矩阵的列
+	Target string `json:"target" example:"XCounter(192.168.1.20:6101)"`
+
+	// 探测状态
+	Status string `json:"status" example:"reachable"`
+
+	// 建立连接的耗时(毫秒)
+	LatencyMs float64 `json:"latency_ms" example:"0.42"`
+
+	// 探测失败原因
+	LastError string `json:"last_error" example:""`
+
+	// 探测时间
+	LastCheckedAt string `json:"last_checked_at" example:"2024-01-02 09:00:00"`
+}
+
+type OesLinkMatrixOut struct {
+	// 矩阵的行, 探测发起方
+	Sources []string `json:"sources"`
+
+	// 矩阵的列, 目标名称和地址
+	Targets []string `json:"targets"`
+
+	// 链路数
+	Total int `json:"total" example:"12"`
+
+	// 可达的链路数
+	Reachable int `json:"reachable" example:"11"`
+
+	// 不可达的链路数
+	Unreachable int `json:"unreachable" example:"1"`
+
+	// 未探测的链路数
+	Unknown int `json:"unknown" example:"0"`
+
+	// 矩阵中有链路的单元格
+	Cells []OesLinkMatrixCellOut `json:"cells"`
+}
+
+// OesLinkReply 链路响应结构
+type OesLinkReply = common.APIReply[*OesLinkOut]
+
+// PagOesLinkReply 链路的分页响应结构
+type PagOesLinkReply = common.APIReply[*common.Pag[OesLinkOut]]
+
+// PagOesLinkProbeReply 链路探测记录的分页响应结构
+type PagOesLinkProbeReply = common.APIReply[*common.Pag[OesLinkProbeOut]]
+
+// OesLinkMatrixReply 链路矩阵响应结构
+type OesLinkMatrixReply = common.APIReply[*OesLinkMatrixOut]
+
+func OesLinkToOut(
+	m OesLinkModel,
+) *OesLinkOut {
+	var sourceHostID uint32
+	if m.SourceHostID != nil {
+		sourceHostID = *m.SourceHostID
+	}
+	return &OesLinkOut{
+		ID:            m.ID,
+		Name:          m.Name,
+		OesColonyID:   m.OesColonyID,
+		SourceHostID:  sourceHostID,
+		Source:        m.Source(),
+		TargetName:    m.TargetName,
+		TargetHost:    m.TargetHost,
+		TargetPort:    m.TargetPort,
+		TimeoutMs:     m.TimeoutMs,
+		IsEnabled:     m.IsEnabled,
+		Status:        m.Status,
+		LatencyMs:     m.LatencyMs,
+		LastError:     m.LastError,
+		LastCheckedAt: formatOptionalTime(m.LastCheckedAt),
+		Remark:        m.Remark,
+		CreatedAt:     m.CreatedAt.Format(time.DateTime),
+		UpdatedAt:     m.UpdatedAt.Format(time.DateTime),
+	}
+}
+
+func ListOesLinkToOut(
+	rms *[]OesLinkModel,
+) *[]OesLinkOut {
+	if rms == nil {
+		return &[]OesLinkOut{}
+	}
+
+	ms := *rms
+	mso := make([]OesLinkOut, 0, len(ms))
+	for _, m := range ms {
+		mso = append(mso, *OesLinkToOut(m))
+	}
+	return &mso
+}
+
+func OesLinkProbeToOut(
+	m OesLinkProbeModel,
+) *OesLinkProbeOut {
+	return &OesLinkProbeOut{
+		ID:        m.ID,
+		LinkID:    m.LinkID,
+		Status:    m.Status,
+		LatencyMs: m.LatencyMs,
+		Error:     m.Error,
+		CreatedAt: m.CreatedAt.Format(time.DateTime),
+	}
+}
+
+func ListOesLinkProbeToOut(
+	rms *[]OesLinkProbeModel,
+) *[]OesLinkProbeOut {
+	if rms == nil {
+		return &[]OesLinkProbeOut{}
+	}
+
+	ms := *rms
+	mso := make([]OesLinkProbeOut, 0, len(ms))
+	for _, m := range ms {
+		mso = append(mso, *OesLinkProbeToOut(m))
+	}
+	return &mso
+}
+
+// NewOesLinkMatrixOut 按探测发起方和目标汇总链路, 链路需要预加载SourceHost
+func NewOesLinkMatrixOut(
+	rms *[]OesLinkModel,
+) *OesLinkMatrixOut {
+	out := &OesLinkMatrixOut{
+		Sources: []string{},
+		Targets: []string{},
+		Cells:   []OesLinkMatrixCellOut{},
+	}
+	if rms == nil {
+		return out
+	}
+	for _, m := range *rms {
+		source := m.Source()
+		target := fmt.Sprintf("%s(%s)", m.TargetName, m.Target())
+		if !slices.Contains(out.Sources, source) {
+			out.Sources = append(out.Sources, source)
+		}
+		if !slices.Contains(out.Targets, target) {
+			out.Targets = append(out.Targets, target)
+		}
+		switch m.Status {
+		case LinkReachable:
+			out.Reachable++
+		case LinkUnreachable:
+			out.Unreachable++
+		default:
+			out.Unknown++
+		}
+		out.Cells = append(out.Cells, OesLinkMatrixCellOut{
+			LinkID:        m.ID,
+			Name:          m.Name,
+			Source:        source,
+			Target:        target,
+			Status:        m.Status,
+			LatencyMs:     m.LatencyMs,
+			LastError:     m.LastError,
+			LastCheckedAt: formatOptionalTime(m.LastCheckedAt),
+		})
+	}
+	out.Total = len(out.Cells)
+	slices.Sort(out.Sources)
+	slices.Sort(out.Targets)
+	return out
+}
@@ -38,6 +38,18 @@ func (m *OesNodeModel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 	return nil
 }
 
+// NodeSpecdir 返回节点角色对应的配置目录
+func NodeSpecdir(role string) string {
+	switch role {
+	case "master":
+		return "host_01"
+	case "follow":
+		return "host_02"
+	default:
+		return "host_03"
+	}
+}
+
 type OesNodeVars struct {
 	ID       uint32 `json:"id" yaml:"id"`
 	NodeRole string `json:"node_role" yaml:"node_role"`
@@ -110,7 +122,7 @@ type ListOesNodeRequest struct {
 func (req *ListOesNodeRequest) Query() (int, int, map[string]any) {
 	page, size, query := req.StandardModelQuery.QueryMap(12)
 	if req.NodeRole != "" {
-		query["NodeRole = ?"] = req.NodeRole
+		query["node_role = ?"] = req.NodeRole
 	}
 	if req.IsEnable != nil {
 		query["is_enable = ?"] = *req.IsEnable
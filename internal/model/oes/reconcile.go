@@ -0,0 +1,304 @@
+package oes
+
+import (
+	"time"
+
+	"go.uber.org/zap/zapcore"
+
+	"gin-artweb/internal/model/common"
+	"gin-artweb/internal/shared/database"
+)
+
+// 柜台数据对账状态
+const (
+	ReconcileStatusMatched    = "matched"    // 下发的数据与柜台数据一致
+	ReconcileStatusMismatched = "mismatched" // 存在缺少、多余或不一致的记录
+	ReconcileStatusFailed     = "failed"     // 数据文件缺失或读取失败, 详见Message
+)
+
+// OesReconcileReportModel oes柜台数据对账报告
+//
+// 比较counter_fetch.sh从柜台获取的数据文件与counter_distribute.sh下发到各节点的数据文件,
+// 每个集群每个交易日保留一份报告, 重新对账时替换
+type OesReconcileReportModel struct {
+	database.StandardModel
+	OesColonyID uint32                  `gorm:"column:oes_colony_id;not null;uniqueIndex:idx_oes_reconcile_colony_day;comment:oes集群ID" json:"oes_colony_id"`
+	ColonyNum   string                  `gorm:"column:colony_num;type:varchar(2);comment:集群号" json:"colony_num"`
+	TradingDay  string                  `gorm:"column:trading_day;type:varchar(10);not null;uniqueIndex:idx_oes_reconcile_colony_day;comment:交易日" json:"trading_day"`
+	Status      string                  `gorm:"column:status;type:varchar(10);not null;comment:对账状态" json:"status"`
+	FileCount   int                     `gorm:"column:file_count;comment:柜台数据文件数" json:"file_count"`
+	NodeCount   int                     `gorm:"column:node_count;comment:对账节点数" json:"node_count"`
+	Missing     int64                   `gorm:"column:missing;comment:节点缺少的记录数" json:"missing"`
+	Extra       int64                   `gorm:"column:extra;comment:节点多余的记录数" json:"extra"`
+	Mismatched  int64                   `gorm:"column:mismatched;comment:内容不一致的记录数" json:"mismatched"`
+	Message     string                  `gorm:"column:message;type:varchar(254);comment:说明" json:"message"`
+	Username    string                  `gorm:"column:username;type:varchar(50);comment:发起对账的用户名, 定时对账为空" json:"username"`
+	Files       []OesReconcileFileModel `gorm:"foreignKey:ReportID;constraint:OnDelete:CASCADE" json:"files"`
+}
+
+func (m *OesReconcileReportModel) TableName() string {
+	return "oes_reconcile_report"
+}
+
+func (m *OesReconcileReportModel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if m == nil {
+		return nil
+	}
+	if err := m.StandardModel.MarshalLogObject(enc); err != nil {
+		return err
+	}
+	enc.AddUint32("oes_colony_id", m.OesColonyID)
+	enc.AddString("colony_num", m.ColonyNum)
+	enc.AddString("trading_day", m.TradingDay)
+	enc.AddString("status", m.Status)
+	enc.AddInt("file_count", m.FileCount)
+	enc.AddInt("node_count", m.NodeCount)
+	enc.AddInt64("missing", m.Missing)
+	enc.AddInt64("extra", m.Extra)
+	enc.AddInt64("mismatched", m.Mismatched)
+	enc.AddString("username", m.Username)
+	return nil
+}
+
+// OesReconcileFileModel 对账报告中单个节点上单个数据文件的对账结果
+type OesReconcileFileModel struct {
+	database.BaseModel
+	ReportID       uint32 `gorm:"column:report_id;not null;index;comment:对账报告ID" json:"report_id"`
+	NodeID         uint32 `gorm:"column:node_id;not null;comment:oes节点ID" json:"node_id"`
+	HostID         uint32 `gorm:"column:host_id;not null;comment:主机ID" json:"host_id"`
+	FileName       string `gorm:"column:file_name;type:varchar(128);not null;comment:数据文件名称" json:"file_name"`
+	RemotePath     string `gorm:"column:remote_path;type:varchar(512);comment:节点上的文件路径" json:"remote_path"`
+	FetchRows      int64  `gorm:"column:fetch_rows;comment:柜台数据记录数" json:"fetch_rows"`
+	DistributeRows int64  `gorm:"column:distribute_rows;comment:下发数据记录数" json:"distribute_rows"`
+	Missing        int64  `gorm:"column:missing;comment:节点缺少的记录数" json:"missing"`
+	Extra          int64  `gorm:"column:extra;comment:节点多余的记录数" json:"extra"`
+	Mismatched     int64  `gorm:"column:mismatched;comment:内容不一致的记录数" json:"mismatched"`
+	Status         string `gorm:"column:status;type:varchar(10);not null;comment:对账状态" json:"status"`
+	Message        string `gorm:"column:message;type:varchar(254);comment:说明" json:"message"`
+}
+
+func (m *OesReconcileFileModel) TableName() string {
+	return "oes_reconcile_file"
+}
+
+func (m *OesReconcileFileModel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if m == nil {
+		return nil
+	}
+	if err := m.BaseModel.MarshalLogObject(enc); err != nil {
+		return err
+	}
+	enc.AddUint32("report_id", m.ReportID)
+	enc.AddUint32("node_id", m.NodeID)
+	enc.AddString("file_name", m.FileName)
+	enc.AddString("remote_path", m.RemotePath)
+	enc.AddInt64("fetch_rows", m.FetchRows)
+	enc.AddInt64("distribute_rows", m.DistributeRows)
+	enc.AddString("status", m.Status)
+	return nil
+}
+
+// ReconcileColonyRequest 用于立即对账的请求结构体
+//
+// swagger:model ReconcileColonyRequest
+type ReconcileColonyRequest struct {
+	// 交易日, 格式为2006-01-02, 默认为当天
+	TradingDay string `json:"trading_day" binding:"omitempty,datetime=2006-01-02"`
+}
+
+func (req *ReconcileColonyRequest) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if req == nil {
+		return nil
+	}
+	enc.AddString("trading_day", req.TradingDay)
+	return nil
+}
+
+// ListOesReconcileReportRequest 用于查询集群对账报告列表的请求结构体
+//
+// swagger:model ListOesReconcileReportRequest
+type ListOesReconcileReportRequest struct {
+	common.BaseModelQuery
+
+	// 交易日, 格式为2006-01-02
+	TradingDay string `form:"trading_day" binding:"omitempty,datetime=2006-01-02"`
+
+	// 对账状态
+	Status string `form:"status" binding:"omitempty,oneof=matched mismatched failed"`
+}
+
+func (req *ListOesReconcileReportRequest) Query(colonyId uint32) (int, int, map[string]any) {
+	page, size, query := req.BaseModelQuery.QueryMap(10)
+	query["oes_colony_id = ?"] = colonyId
+	if req.TradingDay != "" {
+		query["trading_day = ?"] = req.TradingDay
+	}
+	if req.Status != "" {
+		query["status = ?"] = req.Status
+	}
+	return page, size, query
+}
+
+// ReconcileDayUri 集群指定交易日的对账报告路径参数
+type ReconcileDayUri struct {
+	// oes集群ID
+	ID uint32 `uri:"id" binding:"required,gt=0"`
+
+	// 交易日, 格式为2006-01-02
+	TradingDay string `uri:"trading_day" binding:"required,datetime=2006-01-02"`
+}
+
+type OesReconcileFileOut struct {
+	// 节点ID
+	NodeID uint32 `json:"node_id" example:"1"`
+
+	// 主机ID
+	HostID uint32 `json:"host_id" example:"1"`
+
+	// 数据文件名称
+	FileName string `json:"file_name" example:"CustInfo0102.csv"`
+
+	// 节点上的文件路径
+	RemotePath string `json:"remote_path" example:"/home/quant/oes_01/data/broker/CustInfo0102.csv"`
+
+	// 柜台数据记录数
+	FetchRows int64 `json:"fetch_rows" example:"100"`
+
+	// 下发数据记录数
+	DistributeRows int64 `json:"distribute_rows" example:"99"`
+
+	// 节点缺少的记录数
+	Missing int64 `json:"missing" example:"1"`
+
+	// 节点多余的记录数
+	Extra int64 `json:"extra" example:"0"`
+
+	// 内容不一致的记录数
+	Mismatched int64 `json:"mismatched" example:"0"`
+
+	// 对账状态(matched/mismatched/failed)
+	Status string `json:"status" example:"mismatched"`
+
+	// 说明
+	Message string `json:"message" example:"缺少: 000001"`
+}
+
+type OesReconcileReportOut struct {
+	// ID
+	ID uint32 `json:"id" example:"1"`
+
+	// oes集群ID
+	OesColonyID uint32 `json:"oes_colony_id" example:"1"`
+
+	// 集群号
+	ColonyNum string `json:"colony_num" example:"01"`
+
+	// 交易日
+	TradingDay string `json:"trading_day" example:"2024-01-02"`
+
+	// 对账状态(matched/mismatched/failed)
+	Status string `json:"status" example:"mismatched"`
+
+	// 柜台数据文件数
+	FileCount int `json:"file_count" example:"6"`
+
+	// 对账节点数
+	NodeCount int `json:"node_count" example:"2"`
+
+	// 节点缺少的记录数
+	Missing int64 `json:"missing" example:"1"`
+
+	// 节点多余的记录数
+	Extra int64 `json:"extra" example:"0"`
+
+	// 内容不一致的记录数
+	Mismatched int64 `json:"mismatched" example:"0"`
+
+	// 说明
+	Message string `json:"message" example:""`
+
+	// 发起对账的用户名, 定时对账为空
+	Username string `json:"username" example:"admin"`
+
+	// 对账时间
+	CheckedAt string `json:"checked_at" example:"2023-01-01 08:00:00"`
+}
+
+type OesReconcileReportDetailOut struct {
+	OesReconcileReportOut
+
+	// 每个节点上每个数据文件的对账结果
+	Files []OesReconcileFileOut `json:"files"`
+}
+
+// OesReconcileReportReply 柜台数据对账报告详情响应结构
+type OesReconcileReportReply = common.APIReply[*OesReconcileReportDetailOut]
+
+// PagOesReconcileReportReply 柜台数据对账报告的分页响应结构
+type PagOesReconcileReportReply = common.APIReply[*common.Pag[OesReconcileReportOut]]
+
+func OesReconcileFileToOut(
+	m OesReconcileFileModel,
+) *OesReconcileFileOut {
+	return &OesReconcileFileOut{
+		NodeID:         m.NodeID,
+		HostID:         m.HostID,
+		FileName:       m.FileName,
+		RemotePath:     m.RemotePath,
+		FetchRows:      m.FetchRows,
+		DistributeRows: m.DistributeRows,
+		Missing:        m.Missing,
+		Extra:          m.Extra,
+		Mismatched:     m.Mismatched,
+		Status:         m.Status,
+		Message:        m.Message,
+	}
+}
+
+func OesReconcileReportToOut(
+	m OesReconcileReportModel,
+) *OesReconcileReportOut {
+	return &OesReconcileReportOut{
+		ID:          m.ID,
+		OesColonyID: m.OesColonyID,
+		ColonyNum:   m.ColonyNum,
+		TradingDay:  m.TradingDay,
+		Status:      m.Status,
+		FileCount:   m.FileCount,
+		NodeCount:   m.NodeCount,
+		Missing:     m.Missing,
+		Extra:       m.Extra,
+		Mismatched:  m.Mismatched,
+		Message:     m.Message,
+		Username:    m.Username,
+		CheckedAt:   m.CreatedAt.Format(time.DateTime),
+	}
+}
+
+func OesReconcileReportToDetailOut(
+	m OesReconcileReportModel,
+) *OesReconcileReportDetailOut {
+	files := make([]OesReconcileFileOut, 0, len(m.Files))
+	for _, f := range m.Files {
+		files = append(files, *OesReconcileFileToOut(f))
+	}
+	return &OesReconcileReportDetailOut{
+		OesReconcileReportOut: *OesReconcileReportToOut(m),
+		Files:                 files,
+	}
+}
+
+func ListOesReconcileReportToOut(
+	rms *[]OesReconcileReportModel,
+) *[]OesReconcileReportOut {
+	if rms == nil {
+		return &[]OesReconcileReportOut{}
+	}
+
+	ms := *rms
+	mso := make([]OesReconcileReportOut, 0, len(ms))
+	for _, m := range ms {
+		mso = append(mso, *OesReconcileReportToOut(m))
+	}
+	return &mso
+}
@@ -0,0 +1,609 @@
+package oes
+
+import (
+	"sort"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+
+	"gin-artweb/internal/model/common"
+	"gin-artweb/internal/shared/database"
+)
+
+// 检查单步骤类型
+const (
+	RunbookStepManual = "manual" // 人工操作, 由操作员确认完成
+	RunbookStepScript = "script" // 执行内置脚本, 脚本执行成功后自动完成
+)
+
+// 检查单执行状态
+const (
+	RunbookExecRunning = "running" // 执行中, 等待操作员确认或脚本执行
+	RunbookExecBlocked = "blocked" // 脚本步骤执行失败, 等待重试或终止
+	RunbookExecSuccess = "success" // 全部步骤已完成
+	RunbookExecAborted = "aborted" // 已终止
+)
+
+// 检查单步骤执行状态
+const (
+	RunbookStepPending = "pending" // 等待前序步骤完成
+	RunbookStepWaiting = "waiting" // 等待操作员确认
+	RunbookStepRunning = "running" // 脚本执行中
+	RunbookStepSuccess = "success" // 已完成
+	RunbookStepFailed  = "failed"  // 脚本执行失败
+	RunbookStepSkipped = "skipped" // 检查单终止, 未执行
+)
+
+// OesRunbookModel oes运维检查单, 按顺序执行的人工确认或脚本步骤
+type OesRunbookModel struct {
+	database.StandardModel
+	Name        string                `gorm:"column:name;type:varchar(50);not null;uniqueIndex;comment:名称" json:"name"`
+	SystemType  string                `gorm:"column:system_type;type:varchar(20);comment:适用的系统类型, 为空时适用全部集群" json:"system_type"`
+	IsEnabled   bool                  `gorm:"column:is_enabled;type:boolean;comment:是否启用" json:"is_enabled"`
+	Description string                `gorm:"column:description;type:varchar(254);comment:说明" json:"description"`
+	Steps       []OesRunbookStepModel `gorm:"foreignKey:RunbookID;constraint:OnDelete:CASCADE" json:"steps"`
+}
+
+func (m *OesRunbookModel) TableName() string {
+	return "oes_runbook"
+}
+
+func (m *OesRunbookModel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if m == nil {
+		return nil
+	}
+	if err := m.StandardModel.MarshalLogObject(enc); err != nil {
+		return err
+	}
+	enc.AddString("name", m.Name)
+	enc.AddString("system_type", m.SystemType)
+	enc.AddBool("is_enabled", m.IsEnabled)
+	enc.AddInt("step_count", len(m.Steps))
+	return nil
+}
+
+// OesRunbookStepModel 检查单中的单个步骤
+type OesRunbookStepModel struct {
+	database.BaseModel
+	RunbookID   uint32 `gorm:"column:runbook_id;not null;index;comment:检查单ID" json:"runbook_id"`
+	Sort        int    `gorm:"column:sort;comment:排序" json:"sort"`
+	Name        string `gorm:"column:name;type:varchar(50);not null;comment:步骤名称" json:"name"`
+	Kind        string `gorm:"column:kind;type:varchar(10);not null;comment:步骤类型" json:"kind"`
+	Script      string `gorm:"column:script;type:varchar(254);comment:脚本名称" json:"script"`
+	Timeout     int    `gorm:"column:timeout;comment:脚本超时时间(秒)" json:"timeout"`
+	Instruction string `gorm:"column:instruction;type:text;comment:操作说明" json:"instruction"`
+}
+
+func (m *OesRunbookStepModel) TableName() string {
+	return "oes_runbook_step"
+}
+
+func (m *OesRunbookStepModel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if m == nil {
+		return nil
+	}
+	if err := m.BaseModel.MarshalLogObject(enc); err != nil {
+		return err
+	}
+	enc.AddUint32("runbook_id", m.RunbookID)
+	enc.AddInt("sort", m.Sort)
+	enc.AddString("name", m.Name)
+	enc.AddString("kind", m.Kind)
+	enc.AddString("script", m.Script)
+	return nil
+}
+
+// OesRunbookExecModel 检查单在集群上的一次执行
+//
+// 步骤按顺序执行, 步骤定义在发起时复制, 之后修改检查单不影响进行中的执行
+type OesRunbookExecModel struct {
+	database.StandardModel
+	RunbookID   uint32                    `gorm:"column:runbook_id;not null;index;comment:检查单ID" json:"runbook_id"`
+	RunbookName string                    `gorm:"column:runbook_name;type:varchar(50);comment:检查单名称" json:"runbook_name"`
+	OesColonyID uint32                    `gorm:"column:oes_colony_id;not null;index;comment:oes集群ID" json:"oes_colony_id"`
+	ColonyNum   string                    `gorm:"column:colony_num;type:varchar(2);comment:集群号" json:"colony_num"`
+	Status      string                    `gorm:"column:status;type:varchar(10);not null;default:running;comment:执行状态" json:"status"`
+	FinishedAt  *time.Time                `gorm:"column:finished_at;comment:结束时间" json:"finished_at"`
+	Username    string                    `gorm:"column:username;type:varchar(50);comment:发起人" json:"username"`
+	Steps       []OesRunbookExecStepModel `gorm:"foreignKey:ExecID;constraint:OnDelete:CASCADE" json:"steps"`
+}
+
+func (m *OesRunbookExecModel) TableName() string {
+	return "oes_runbook_exec"
+}
+
+func (m *OesRunbookExecModel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if m == nil {
+		return nil
+	}
+	if err := m.StandardModel.MarshalLogObject(enc); err != nil {
+		return err
+	}
+	enc.AddUint32("runbook_id", m.RunbookID)
+	enc.AddString("runbook_name", m.RunbookName)
+	enc.AddUint32("oes_colony_id", m.OesColonyID)
+	enc.AddString("colony_num", m.ColonyNum)
+	enc.AddString("status", m.Status)
+	enc.AddString("username", m.Username)
+	return nil
+}
+
+// OesRunbookExecStepModel 检查单执行中单个步骤的状态及操作员签核
+type OesRunbookExecStepModel struct {
+	database.BaseModel
+	ExecID       uint32     `gorm:"column:exec_id;not null;index;comment:检查单执行ID" json:"exec_id"`
+	Sort         int        `gorm:"column:sort;comment:排序" json:"sort"`
+	Name         string     `gorm:"column:name;type:varchar(50);not null;comment:步骤名称" json:"name"`
+	Kind         string     `gorm:"column:kind;type:varchar(10);not null;comment:步骤类型" json:"kind"`
+	ScriptID     uint32     `gorm:"column:script_id;comment:脚本ID" json:"script_id"`
+	Timeout      int        `gorm:"column:timeout;comment:脚本超时时间(秒)" json:"timeout"`
+	Instruction  string     `gorm:"column:instruction;type:text;comment:操作说明" json:"instruction"`
+	Status       string     `gorm:"column:status;type:varchar(10);not null;default:pending;comment:执行状态" json:"status"`
+	RecordID     uint32     `gorm:"column:record_id;comment:脚本执行记录ID" json:"record_id"`
+	Attempts     int        `gorm:"column:attempts;comment:脚本执行次数" json:"attempts"`
+	ErrorMessage string     `gorm:"column:error_message;type:text;comment:错误信息" json:"error_message"`
+	Operator     string     `gorm:"column:operator;type:varchar(50);comment:确认或执行步骤的操作员" json:"operator"`
+	Remark       string     `gorm:"column:remark;type:varchar(254);comment:操作员备注" json:"remark"`
+	StartedAt    *time.Time `gorm:"column:started_at;comment:开始时间" json:"started_at"`
+	FinishedAt   *time.Time `gorm:"column:finished_at;comment:完成时间" json:"finished_at"`
+}
+
+func (m *OesRunbookExecStepModel) TableName() string {
+	return "oes_runbook_exec_step"
+}
+
+func (m *OesRunbookExecStepModel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if m == nil {
+		return nil
+	}
+	if err := m.BaseModel.MarshalLogObject(enc); err != nil {
+		return err
+	}
+	enc.AddUint32("exec_id", m.ExecID)
+	enc.AddInt("sort", m.Sort)
+	enc.AddString("name", m.Name)
+	enc.AddString("kind", m.Kind)
+	enc.AddString("status", m.Status)
+	enc.AddUint32("record_id", m.RecordID)
+	enc.AddString("operator", m.Operator)
+	return nil
+}
+
+// OesRunbookStepRequest 检查单步骤定义
+//
+// swagger:model OesRunbookStepRequest
+type OesRunbookStepRequest struct {
+	// 步骤名称
+	Name string `json:"name" binding:"required,max=50"`
+
+	// 步骤类型, manual为人工确认, script为执行内置脚本
+	Kind string `json:"kind" binding:"required,oneof=manual script"`
+
+	// 脚本名称, 需为oes的内置命令脚本, 执行参数为集群号
+	Script string `json:"script" binding:"required_if=Kind script,omitempty,max=254"`
+
+	// 脚本超时时间(秒), 默认为3600
+	Timeout int `json:"timeout" binding:"omitempty,gt=0"`
+
+	// 操作说明
+	Instruction string `json:"instruction" binding:"omitempty,max=4096"`
+}
+
+// OesRunbookRequest 用于创建和更新检查单的请求结构体
+//
+// swagger:model OesRunbookRequest
+type OesRunbookRequest struct {
+	// 名称
+	Name string `json:"name" binding:"required,max=50"`
+
+	// 适用的系统类型, 为空时适用全部集群
+	SystemType string `json:"system_type" binding:"omitempty,oneof=STK CRD OPT"`
+
+	// 是否启用
+	IsEnabled bool `json:"is_enabled"`
+
+	// 说明
+	Description string `json:"description" binding:"omitempty,max=254"`
+
+	// 按顺序执行的步骤
+	Steps []OesRunbookStepRequest `json:"steps" binding:"required,min=1,max=100,dive"`
+}
+
+func (req *OesRunbookRequest) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if req == nil {
+		return nil
+	}
+	enc.AddString("name", req.Name)
+	enc.AddString("system_type", req.SystemType)
+	enc.AddBool("is_enabled", req.IsEnabled)
+	enc.AddInt("step_count", len(req.Steps))
+	return nil
+}
+
+// ToModel 转换为检查单模型, 步骤按请求中的顺序排序
+func (req *OesRunbookRequest) ToModel() OesRunbookModel {
+	m := OesRunbookModel{
+		Name:        req.Name,
+		SystemType:  req.SystemType,
+		IsEnabled:   req.IsEnabled,
+		Description: req.Description,
+	}
+	for i, step := range req.Steps {
+		script := ""
+		if step.Kind == RunbookStepScript {
+			script = step.Script
+		}
+		m.Steps = append(m.Steps, OesRunbookStepModel{
+			Sort:        i,
+			Name:        step.Name,
+			Kind:        step.Kind,
+			Script:      script,
+			Timeout:     step.Timeout,
+			Instruction: step.Instruction,
+		})
+	}
+	return m
+}
+
+// ListOesRunbookRequest 用于查询检查单列表的请求结构体
+//
+// swagger:model ListOesRunbookRequest
+type ListOesRunbookRequest struct {
+	common.BaseModelQuery
+
+	// 名称
+	Name string `form:"name"`
+
+	// 适用的系统类型
+	SystemType string `form:"system_type" binding:"omitempty,oneof=STK CRD OPT"`
+
+	// 是否启用
+	IsEnabled *bool `form:"is_enabled"`
+}
+
+func (req *ListOesRunbookRequest) Query() (int, int, map[string]any) {
+	page, size, query := req.BaseModelQuery.QueryMap(10)
+	if req.Name != "" {
+		query["name like ?"] = "%" + req.Name + "%"
+	}
+	if req.SystemType != "" {
+		query["system_type = ?"] = req.SystemType
+	}
+	if req.IsEnabled != nil {
+		query["is_enabled = ?"] = *req.IsEnabled
+	}
+	return page, size, query
+}
+
+// StartOesRunbookRequest 用于为集群发起检查单的请求结构体
+//
+// swagger:model StartOesRunbookRequest
+type StartOesRunbookRequest struct {
+	// 检查单ID
+	RunbookID uint32 `json:"runbook_id" binding:"required,gt=0"`
+}
+
+// SignOffRunbookStepRequest 用于操作员确认或重试检查单步骤的请求结构体
+//
+// swagger:model SignOffRunbookStepRequest
+type SignOffRunbookStepRequest struct {
+	// 备注
+	Remark string `json:"remark" binding:"omitempty,max=254"`
+}
+
+// RunbookStepUri 检查单执行中步骤的路径参数
+type RunbookStepUri struct {
+	// 检查单执行ID
+	ID uint32 `uri:"id" binding:"required,gt=0"`
+
+	// 步骤ID
+	StepID uint32 `uri:"step_id" binding:"required,gt=0"`
+}
+
+// ListOesRunbookExecRequest 用于查询检查单执行列表的请求结构体
+//
+// swagger:model ListOesRunbookExecRequest
+type ListOesRunbookExecRequest struct {
+	common.BaseModelQuery
+
+	// 检查单ID
+	RunbookID uint32 `form:"runbook_id" binding:"omitempty,gt=0"`
+
+	// oes集群ID
+	OesColonyID uint32 `form:"oes_colony_id" binding:"omitempty,gt=0"`
+
+	// 执行状态
+	Status string `form:"status" binding:"omitempty,oneof=running blocked success aborted"`
+}
+
+func (req *ListOesRunbookExecRequest) Query() (int, int, map[string]any) {
+	page, size, query := req.BaseModelQuery.QueryMap(10)
+	if req.RunbookID != 0 {
+		query["runbook_id = ?"] = req.RunbookID
+	}
+	if req.OesColonyID != 0 {
+		query["oes_colony_id = ?"] = req.OesColonyID
+	}
+	if req.Status != "" {
+		query["status = ?"] = req.Status
+	}
+	return page, size, query
+}
+
+type OesRunbookStepOut struct {
+	// 步骤名称
+	Name string `json:"name" example:"检查柜台数据"`
+
+	// 步骤类型(manual/script)
+	Kind string `json:"kind" example:"script"`
+
+	// 脚本名称
+	Script string `json:"script" example:"counter_fetch.sh"`
+
+	// 脚本超时时间(秒)
+	Timeout int `json:"timeout" example:"3600"`
+
+	// 操作说明
+	Instruction string `json:"instruction" example:"确认柜台数据已获取"`
+}
+
+type OesRunbookOut struct {
+	// ID
+	ID uint32 `json:"id" example:"1"`
+
+	// 名称
+	Name string `json:"name" example:"盘前检查"`
+
+	// 适用的系统类型
+	SystemType string `json:"system_type" example:"STK"`
+
+	// 是否启用
+	IsEnabled bool `json:"is_enabled" example:"true"`
+
+	// 说明
+	Description string `json:"description" example:""`
+
+	// 按顺序执行的步骤
+	Steps []OesRunbookStepOut `json:"steps"`
+
+	// 创建时间
+	CreatedAt string `json:"created_at" example:"2023-01-01 12:00:00"`
+
+	// 更新时间
+	UpdatedAt string `json:"updated_at" example:"2023-01-01 12:00:00"`
+}
+
+type OesRunbookExecStepOut struct {
+	// 步骤ID
+	ID uint32 `json:"id" example:"1"`
+
+	// 步骤名称
+	Name string `json:"name" example:"检查柜台数据"`
+
+	// 步骤类型(manual/script)
+	Kind string `json:"kind" example:"manual"`
+
+	// 脚本ID
+	ScriptID uint32 `json:"script_id" example:"0"`
+
+	// 操作说明
+	Instruction string `json:"instruction" example:"确认柜台数据已获取"`
+
+	// 执行状态(pending/waiting/running/success/failed/skipped)
+	Status string `json:"status" example:"success"`
+
+	// 脚本执行记录ID
+	RecordID uint32 `json:"record_id" example:"0"`
+
+	// 脚本执行次数
+	Attempts int `json:"attempts" example:"0"`
+
+	// 错误信息
+	ErrorMessage string `json:"error_message" example:""`
+
+	// 确认或执行步骤的操作员
+	Operator string `json:"operator" example:"admin"`
+
+	// 操作员备注
+	Remark string `json:"remark" example:"已核对"`
+
+	// 开始时间
+	StartedAt string `json:"started_at" example:"2023-01-01 12:00:00"`
+
+	// 完成时间
+	FinishedAt string `json:"finished_at" example:"2023-01-01 12:05:00"`
+}
+
+// OesRunbookProgressOut 检查单执行进度
+type OesRunbookProgressOut struct {
+	// 步骤总数
+	Total int `json:"total" example:"5"`
+
+	// 已完成的步骤数
+	Done int `json:"done" example:"2"`
+
+	// 当前步骤名称
+	Current string `json:"current" example:"检查柜台数据"`
+}
+
+type OesRunbookExecOut struct {
+	// ID
+	ID uint32 `json:"id" example:"1"`
+
+	// 检查单ID
+	RunbookID uint32 `json:"runbook_id" example:"1"`
+
+	// 检查单名称
+	RunbookName string `json:"runbook_name" example:"盘前检查"`
+
+	// oes集群ID
+	OesColonyID uint32 `json:"oes_colony_id" example:"1"`
+
+	// 集群号
+	ColonyNum string `json:"colony_num" example:"01"`
+
+	// 执行状态(running/blocked/success/aborted)
+	Status string `json:"status" example:"running"`
+
+	// 执行进度
+	Progress OesRunbookProgressOut `json:"progress"`
+
+	// 发起人
+	Username string `json:"username" example:"admin"`
+
+	// 发起时间
+	CreatedAt string `json:"created_at" example:"2023-01-01 12:00:00"`
+
+	// 结束时间
+	FinishedAt string `json:"finished_at" example:"2023-01-01 12:30:00"`
+}
+
+type OesRunbookExecDetailOut struct {
+	OesRunbookExecOut
+
+	// 步骤执行详情
+	Steps []OesRunbookExecStepOut `json:"steps"`
+}
+
+// OesRunbookReply 检查单响应结构
+type OesRunbookReply = common.APIReply[*OesRunbookOut]
+
+// PagOesRunbookReply 检查单的分页响应结构
+type PagOesRunbookReply = common.APIReply[*common.Pag[OesRunbookOut]]
+
+// OesRunbookExecReply 检查单执行详情响应结构
+type OesRunbookExecReply = common.APIReply[*OesRunbookExecDetailOut]
+
+// PagOesRunbookExecReply 检查单执行的分页响应结构
+type PagOesRunbookExecReply = common.APIReply[*common.Pag[OesRunbookExecOut]]
+
+// SortedSteps 返回按顺序排列的检查单步骤
+func (m *OesRunbookModel) SortedSteps() []OesRunbookStepModel {
+	steps := append([]OesRunbookStepModel(nil), m.Steps...)
+	sort.Slice(steps, func(i, j int) bool { return steps[i].Sort < steps[j].Sort })
+	return steps
+}
+
+// SortedSteps 返回按顺序排列的检查单执行步骤
+func (m *OesRunbookExecModel) SortedSteps() []OesRunbookExecStepModel {
+	steps := append([]OesRunbookExecStepModel(nil), m.Steps...)
+	sort.Slice(steps, func(i, j int) bool { return steps[i].Sort < steps[j].Sort })
+	return steps
+}
+
+func OesRunbookToOut(
+	m OesRunbookModel,
+) *OesRunbookOut {
+	steps := make([]OesRunbookStepOut, 0, len(m.Steps))
+	for _, s := range m.SortedSteps() {
+		steps = append(steps, OesRunbookStepOut{
+			Name:        s.Name,
+			Kind:        s.Kind,
+			Script:      s.Script,
+			Timeout:     s.Timeout,
+			Instruction: s.Instruction,
+		})
+	}
+	return &OesRunbookOut{
+		ID:          m.ID,
+		Name:        m.Name,
+		SystemType:  m.SystemType,
+		IsEnabled:   m.IsEnabled,
+		Description: m.Description,
+		Steps:       steps,
+		CreatedAt:   m.CreatedAt.Format(time.DateTime),
+		UpdatedAt:   m.UpdatedAt.Format(time.DateTime),
+	}
+}
+
+func ListOesRunbookToOut(
+	rms *[]OesRunbookModel,
+) *[]OesRunbookOut {
+	if rms == nil {
+		return &[]OesRunbookOut{}
+	}
+
+	ms := *rms
+	mso := make([]OesRunbookOut, 0, len(ms))
+	for _, m := range ms {
+		mso = append(mso, *OesRunbookToOut(m))
+	}
+	return &mso
+}
+
+func OesRunbookExecStepToOut(
+	m OesRunbookExecStepModel,
+) *OesRunbookExecStepOut {
+	return &OesRunbookExecStepOut{
+		ID:           m.ID,
+		Name:         m.Name,
+		Kind:         m.Kind,
+		ScriptID:     m.ScriptID,
+		Instruction:  m.Instruction,
+		Status:       m.Status,
+		RecordID:     m.RecordID,
+		Attempts:     m.Attempts,
+		ErrorMessage: m.ErrorMessage,
+		Operator:     m.Operator,
+		Remark:       m.Remark,
+		StartedAt:    formatOptionalTime(m.StartedAt),
+		FinishedAt:   formatOptionalTime(m.FinishedAt),
+	}
+}
+
+func OesRunbookExecToOut(
+	m OesRunbookExecModel,
+) *OesRunbookExecOut {
+	progress := OesRunbookProgressOut{Total: len(m.Steps)}
+	for _, s := range m.SortedSteps() {
+		switch s.Status {
+		case RunbookStepSuccess:
+			progress.Done++
+		case RunbookStepWaiting, RunbookStepRunning, RunbookStepFailed:
+			if progress.Current == "" {
+				progress.Current = s.Name
+			}
+		}
+	}
+	return &OesRunbookExecOut{
+		ID:          m.ID,
+		RunbookID:   m.RunbookID,
+		RunbookName: m.RunbookName,
+		OesColonyID: m.OesColonyID,
+		ColonyNum:   m.ColonyNum,
+		Status:      m.Status,
+		Progress:    progress,
+		Username:    m.Username,
+		CreatedAt:   m.CreatedAt.Format(time.DateTime),
+		FinishedAt:  formatOptionalTime(m.FinishedAt),
+	}
+}
+
+func OesRunbookExecToDetailOut(
+	m OesRunbookExecModel,
+) *OesRunbookExecDetailOut {
+	sms := m.SortedSteps()
+	steps := make([]OesRunbookExecStepOut, 0, len(sms))
+	for _, s := range sms {
+		steps = append(steps, *OesRunbookExecStepToOut(s))
+	}
+	return &OesRunbookExecDetailOut{
+		OesRunbookExecOut: *OesRunbookExecToOut(m),
+		Steps:             steps,
+	}
+}
+
+func ListOesRunbookExecToOut(
+	rms *[]OesRunbookExecModel,
+) *[]OesRunbookExecOut {
+	if rms == nil {
+		return &[]OesRunbookExecOut{}
+	}
+
+	ms := *rms
+	mso := make([]OesRunbookExecOut, 0, len(ms))
+	for _, m := range ms {
+		mso = append(mso, *OesRunbookExecToOut(m))
+	}
+	return &mso
+}
@@ -0,0 +1,386 @@
+package oes
+
+import (
+	"time"
+
+	"go.uber.org/zap/zapcore"
+
+	"gin-artweb/internal/model/common"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/events"
+)
+
+// AlertKindTaskSla oes日常任务SLA告警
+const AlertKindTaskSla = "task_sla"
+
+// oes日常任务的SLA状态
+const (
+	TaskSlaPending  = "pending"  // 未到截止时间, 任务尚未完成
+	TaskSlaAtRisk   = "at_risk"  // 临近截止时间, 任务尚未完成
+	TaskSlaMet      = "met"      // 任务在截止时间前执行成功
+	TaskSlaLate     = "late"     // 任务在截止时间后才执行成功
+	TaskSlaBreached = "breached" // 已过截止时间, 任务尚未完成
+)
+
+// OesTaskSlaModel oes日常任务的SLA, 定义任务每个交易日的完成截止时间
+//
+// 集群ID为0时适用全部集群, 同一任务存在集群单独定义的SLA时以集群的为准
+type OesTaskSlaModel struct {
+	database.StandardModel
+	TaskName    string `gorm:"column:task_name;type:varchar(30);not null;uniqueIndex:idx_oes_task_sla;comment:任务名称" json:"task_name"`
+	OesColonyID uint32 `gorm:"column:oes_colony_id;not null;default:0;uniqueIndex:idx_oes_task_sla;comment:oes集群ID, 0表示全部集群" json:"oes_colony_id"`
+	Deadline    string `gorm:"column:deadline;type:varchar(5);not null;comment:完成截止时间(HH:MM)" json:"deadline"`
+	WarnMinutes int    `gorm:"column:warn_minutes;not null;default:0;comment:截止前多少分钟发出即将超时告警, 0表示不告警" json:"warn_minutes"`
+	IsEnabled   bool   `gorm:"column:is_enabled;type:boolean;comment:是否启用" json:"is_enabled"`
+	Description string `gorm:"column:description;type:varchar(254);comment:说明" json:"description"`
+}
+
+func (m *OesTaskSlaModel) TableName() string {
+	return "oes_task_sla"
+}
+
+func (m *OesTaskSlaModel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if m == nil {
+		return nil
+	}
+	if err := m.StandardModel.MarshalLogObject(enc); err != nil {
+		return err
+	}
+	enc.AddString("task_name", m.TaskName)
+	enc.AddUint32("oes_colony_id", m.OesColonyID)
+	enc.AddString("deadline", m.Deadline)
+	enc.AddInt("warn_minutes", m.WarnMinutes)
+	enc.AddBool("is_enabled", m.IsEnabled)
+	return nil
+}
+
+// OesTaskSlaResultModel 集群每个交易日每个任务的SLA检查结果
+type OesTaskSlaResultModel struct {
+	database.StandardModel
+	SlaID        uint32     `gorm:"column:sla_id;not null;index;comment:SLA ID" json:"sla_id"`
+	OesColonyID  uint32     `gorm:"column:oes_colony_id;not null;uniqueIndex:idx_oes_task_sla_result;comment:oes集群ID" json:"oes_colony_id"`
+	ColonyNum    string     `gorm:"column:colony_num;type:varchar(2);comment:集群号" json:"colony_num"`
+	TaskName     string     `gorm:"column:task_name;type:varchar(30);not null;uniqueIndex:idx_oes_task_sla_result;comment:任务名称" json:"task_name"`
+	TradingDay   string     `gorm:"column:trading_day;type:varchar(10);not null;uniqueIndex:idx_oes_task_sla_result;comment:交易日" json:"trading_day"`
+	Deadline     string     `gorm:"column:deadline;type:varchar(5);not null;comment:完成截止时间(HH:MM)" json:"deadline"`
+	Status       string     `gorm:"column:status;type:varchar(10);not null;comment:SLA状态" json:"status"`
+	RecordID     uint32     `gorm:"column:record_id;comment:任务的执行记录ID" json:"record_id"`
+	RecordStatus int        `gorm:"column:record_status;comment:任务的执行状态" json:"record_status"`
+	FinishedAt   *time.Time `gorm:"column:finished_at;comment:任务执行成功的时间" json:"finished_at"`
+	AlertLevel   string     `gorm:"column:alert_level;type:varchar(10);comment:已发出告警的最高级别" json:"alert_level"`
+}
+
+func (m *OesTaskSlaResultModel) TableName() string {
+	return "oes_task_sla_result"
+}
+
+func (m *OesTaskSlaResultModel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if m == nil {
+		return nil
+	}
+	if err := m.StandardModel.MarshalLogObject(enc); err != nil {
+		return err
+	}
+	enc.AddUint32("sla_id", m.SlaID)
+	enc.AddUint32("oes_colony_id", m.OesColonyID)
+	enc.AddString("task_name", m.TaskName)
+	enc.AddString("trading_day", m.TradingDay)
+	enc.AddString("deadline", m.Deadline)
+	enc.AddString("status", m.Status)
+	enc.AddUint32("record_id", m.RecordID)
+	enc.AddString("alert_level", m.AlertLevel)
+	return nil
+}
+
+// TaskSlaEvent oes日常任务即将超时或已超时的告警事件
+type TaskSlaEvent struct {
+	Kind       string `json:"kind"`
+	Project    string `json:"project"`
+	ID         uint32 `json:"id"`
+	ColonyNum  string `json:"colony_num"`
+	TaskName   string `json:"task_name"`
+	TradingDay string `json:"trading_day"`
+	Deadline   string `json:"deadline"`
+	Status     string `json:"status"`
+	RecordID   uint32 `json:"record_id"`
+	CheckedAt  string `json:"checked_at"`
+}
+
+func (e TaskSlaEvent) EventType() string {
+	return events.AlertFired
+}
+
+// OesTaskSlaRequest 用于创建或更新oes日常任务SLA的请求结构体
+//
+// swagger:model OesTaskSlaRequest
+type OesTaskSlaRequest struct {
+	// 任务名称, 需要在日常任务目录中
+	TaskName string `json:"task_name" binding:"required,max=30"`
+
+	// oes集群ID, 0表示全部集群
+	OesColonyID uint32 `json:"oes_colony_id"`
+
+	// 完成截止时间, 格式为15:04
+	Deadline string `json:"deadline" binding:"required,datetime=15:04"`
+
+	// 截止前多少分钟发出即将超时告警, 0表示不告警
+	WarnMinutes int `json:"warn_minutes" binding:"omitempty,gte=0,lte=720"`
+
+	// 是否启用
+	IsEnabled bool `json:"is_enabled"`
+
+	// 说明
+	Description string `json:"description" binding:"omitempty,max=254"`
+}
+
+func (req *OesTaskSlaRequest) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if req == nil {
+		return nil
+	}
+	enc.AddString("task_name", req.TaskName)
+	enc.AddUint32("oes_colony_id", req.OesColonyID)
+	enc.AddString("deadline", req.Deadline)
+	enc.AddInt("warn_minutes", req.WarnMinutes)
+	enc.AddBool("is_enabled", req.IsEnabled)
+	return nil
+}
+
+func (req *OesTaskSlaRequest) ToModel() OesTaskSlaModel {
+	return OesTaskSlaModel{
+		TaskName:    req.TaskName,
+		OesColonyID: req.OesColonyID,
+		Deadline:    req.Deadline,
+		WarnMinutes: req.WarnMinutes,
+		IsEnabled:   req.IsEnabled,
+		Description: req.Description,
+	}
+}
+
+// ListOesTaskSlaRequest 用于查询oes日常任务SLA列表的请求结构体
+//
+// swagger:model ListOesTaskSlaRequest
+type ListOesTaskSlaRequest struct {
+	common.BaseModelQuery
+
+	// 任务名称
+	TaskName string `form:"task_name"`
+
+	// oes集群ID, 0表示全部集群
+	OesColonyID *uint32 `form:"oes_colony_id"`
+
+	// 是否启用
+	IsEnabled *bool `form:"is_enabled"`
+}
+
+func (req *ListOesTaskSlaRequest) Query() (int, int, map[string]any) {
+	page, size, query := req.BaseModelQuery.QueryMap(10)
+	if req.TaskName != "" {
+		query["task_name = ?"] = req.TaskName
+	}
+	if req.OesColonyID != nil {
+		query["oes_colony_id = ?"] = *req.OesColonyID
+	}
+	if req.IsEnabled != nil {
+		query["is_enabled = ?"] = *req.IsEnabled
+	}
+	return page, size, query
+}
+
+// TaskSlaComplianceRequest 用于查询每日SLA达成情况的请求结构体
+//
+// swagger:model TaskSlaComplianceRequest
+type TaskSlaComplianceRequest struct {
+	// 交易日, 格式为2006-01-02, 默认为当天
+	TradingDay string `form:"trading_day" binding:"omitempty,datetime=2006-01-02"`
+
+	// oes集群ID
+	OesColonyID uint32 `form:"oes_colony_id"`
+}
+
+func (req *TaskSlaComplianceRequest) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if req == nil {
+		return nil
+	}
+	enc.AddString("trading_day", req.TradingDay)
+	enc.AddUint32("oes_colony_id", req.OesColonyID)
+	return nil
+}
+
+type OesTaskSlaOut struct {
+	// ID
+	ID uint32 `json:"id" example:"1"`
+
+	// 任务名称
+	TaskName string `json:"task_name" example:"counter_distribute"`
+
+	// oes集群ID, 0表示全部集群
+	OesColonyID uint32 `json:"oes_colony_id" example:"0"`
+
+	// 完成截止时间
+	Deadline string `json:"deadline" example:"07:30"`
+
+	// 截止前多少分钟发出即将超时告警
+	WarnMinutes int `json:"warn_minutes" example:"15"`
+
+	// 是否启用
+	IsEnabled bool `json:"is_enabled" example:"true"`
+
+	// 说明
+	Description string `json:"description" example:"柜台数据需在开盘前下发"`
+
+	// 创建时间
+	CreatedAt string `json:"created_at" example:"2023-01-01 12:00:00"`
+
+	// 更新时间
+	UpdatedAt string `json:"updated_at" example:"2023-01-01 12:00:00"`
+}
+
+type OesTaskSlaResultOut struct {
+	// oes集群ID
+	OesColonyID uint32 `json:"oes_colony_id" example:"1"`
+
+	// 集群号
+	ColonyNum string `json:"colony_num" example:"01"`
+
+	// 任务名称
+	TaskName string `json:"task_name" example:"counter_distribute"`
+
+	// 完成截止时间
+	Deadline string `json:"deadline" example:"07:30"`
+
+	// SLA状态(pending/at_risk/met/late/breached)
+	Status string `json:"status" example:"met"`
+
+	// 任务的执行记录ID, 0表示当天未执行
+	RecordID uint32 `json:"record_id" example:"1"`
+
+	// 任务的执行状态(0-待执行,1-执行中,2-成功,3-失败,4-超时,5-崩溃,6-中断)
+	RecordStatus int `json:"record_status" example:"2"`
+
+	// 任务执行成功的时间
+	FinishedAt string `json:"finished_at" example:"2024-01-02 07:20:00"`
+
+	// 检查时间
+	CheckedAt string `json:"checked_at" example:"2024-01-02 07:21:00"`
+}
+
+type TaskSlaComplianceOut struct {
+	// 交易日
+	TradingDay string `json:"trading_day" example:"2024-01-02"`
+
+	// 任务数
+	Total int `json:"total" example:"12"`
+
+	// 截止前完成的任务数
+	Met int `json:"met" example:"10"`
+
+	// 截止后才完成的任务数
+	Late int `json:"late" example:"1"`
+
+	// 已超时未完成的任务数
+	Breached int `json:"breached" example:"1"`
+
+	// 即将超时的任务数
+	AtRisk int `json:"at_risk" example:"0"`
+
+	// 未到截止时间的任务数
+	Pending int `json:"pending" example:"0"`
+
+	// 达成率(%), 截止前完成的任务数占已到截止时间的任务数的比例, 没有已到截止时间的任务时为100
+	ComplianceRate float64 `json:"compliance_rate" example:"83.33"`
+
+	// 每个集群每个任务的SLA检查结果
+	Results []OesTaskSlaResultOut `json:"results"`
+}
+
+// OesTaskSlaReply oes日常任务SLA响应结构
+type OesTaskSlaReply = common.APIReply[*OesTaskSlaOut]
+
+// PagOesTaskSlaReply oes日常任务SLA的分页响应结构
+type PagOesTaskSlaReply = common.APIReply[*common.Pag[OesTaskSlaOut]]
+
+// TaskSlaComplianceReply 每日SLA达成情况响应结构
+type TaskSlaComplianceReply = common.APIReply[*TaskSlaComplianceOut]
+
+func OesTaskSlaToOut(
+	m OesTaskSlaModel,
+) *OesTaskSlaOut {
+	return &OesTaskSlaOut{
+		ID:          m.ID,
+		TaskName:    m.TaskName,
+		OesColonyID: m.OesColonyID,
+		Deadline:    m.Deadline,
+		WarnMinutes: m.WarnMinutes,
+		IsEnabled:   m.IsEnabled,
+		Description: m.Description,
+		CreatedAt:   m.CreatedAt.Format(time.DateTime),
+		UpdatedAt:   m.UpdatedAt.Format(time.DateTime),
+	}
+}
+
+func ListOesTaskSlaToOut(
+	rms *[]OesTaskSlaModel,
+) *[]OesTaskSlaOut {
+	if rms == nil {
+		return &[]OesTaskSlaOut{}
+	}
+
+	ms := *rms
+	mso := make([]OesTaskSlaOut, 0, len(ms))
+	for _, m := range ms {
+		mso = append(mso, *OesTaskSlaToOut(m))
+	}
+	return &mso
+}
+
+func OesTaskSlaResultToOut(
+	m OesTaskSlaResultModel,
+) *OesTaskSlaResultOut {
+	return &OesTaskSlaResultOut{
+		OesColonyID:  m.OesColonyID,
+		ColonyNum:    m.ColonyNum,
+		TaskName:     m.TaskName,
+		Deadline:     m.Deadline,
+		Status:       m.Status,
+		RecordID:     m.RecordID,
+		RecordStatus: m.RecordStatus,
+		FinishedAt:   formatOptionalTime(m.FinishedAt),
+		CheckedAt:    m.UpdatedAt.Format(time.DateTime),
+	}
+}
+
+// NewTaskSlaComplianceOut 统计交易日的SLA检查结果
+func NewTaskSlaComplianceOut(
+	tradingDay string,
+	rms *[]OesTaskSlaResultModel,
+) *TaskSlaComplianceOut {
+	out := &TaskSlaComplianceOut{
+		TradingDay:     tradingDay,
+		ComplianceRate: 100,
+		Results:        []OesTaskSlaResultOut{},
+	}
+	if rms == nil {
+		return out
+	}
+	for _, m := range *rms {
+		switch m.Status {
+		case TaskSlaMet:
+			out.Met++
+		case TaskSlaLate:
+			out.Late++
+		case TaskSlaBreached:
+			out.Breached++
+		case TaskSlaAtRisk:
+			out.AtRisk++
+		default:
+			out.Pending++
+		}
+		out.Results = append(out.Results, *OesTaskSlaResultToOut(m))
+	}
+	out.Total = len(out.Results)
+	if due := out.Met + out.Late + out.Breached; due > 0 {
+		rate := float64(out.Met) * 100 / float64(due)
+		out.ComplianceRate = float64(int(rate*100+0.5)) / 100
+	}
+	return out
+}
@@ -0,0 +1,197 @@
+package oes
+
+import (
+	"time"
+
+	"go.uber.org/zap/zapcore"
+
+	"gin-artweb/internal/model/common"
+	"gin-artweb/internal/shared/database"
+)
+
+// OesTaskCatalogModel 各系统类型的oes集群需要执行的日常任务目录
+//
+// 任务名称同时是集群任务标识文件的名称, 任务标识文件记录了任务最近一次的执行记录ID
+type OesTaskCatalogModel struct {
+	database.StandardModel
+	SystemType  string `gorm:"column:system_type;type:varchar(20);not null;uniqueIndex:idx_oes_task_catalog;comment:系统类型" json:"system_type"`
+	TaskName    string `gorm:"column:task_name;type:varchar(30);not null;uniqueIndex:idx_oes_task_catalog;comment:任务名称" json:"task_name"`
+	Sort        int    `gorm:"column:sort;not null;default:0;comment:排序, 越小越靠前" json:"sort"`
+	IsEnabled   bool   `gorm:"column:is_enabled;type:boolean;comment:是否启用" json:"is_enabled"`
+	Description string `gorm:"column:description;type:varchar(254);comment:说明" json:"description"`
+}
+
+func (m *OesTaskCatalogModel) TableName() string {
+	return "oes_task_catalog"
+}
+
+func (m *OesTaskCatalogModel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if m == nil {
+		return nil
+	}
+	if err := m.StandardModel.MarshalLogObject(enc); err != nil {
+		return err
+	}
+	enc.AddString("system_type", m.SystemType)
+	enc.AddString("task_name", m.TaskName)
+	enc.AddInt("sort", m.Sort)
+	enc.AddBool("is_enabled", m.IsEnabled)
+	return nil
+}
+
+// DefaultOesTaskCatalog 内置的日常任务目录, 初始化任务目录表时写入
+func DefaultOesTaskCatalog() []OesTaskCatalogModel {
+	defaults := []struct {
+		systemType string
+		tasks      []string
+	}{
+		{"STK", []string{"mon", "counter_fetch", "counter_distribute", "bse", "sse", "szse", "csde"}},
+		{"CRD", []string{"mon", "counter_fetch", "counter_distribute", "sse", "szse", "csde", "sse_late", "szse_late"}},
+		{"OPT", []string{"mon", "counter_fetch", "counter_distribute", "sse", "szse"}},
+	}
+	var ms []OesTaskCatalogModel
+	for _, d := range defaults {
+		for i, task := range d.tasks {
+			ms = append(ms, OesTaskCatalogModel{
+				SystemType: d.systemType,
+				TaskName:   task,
+				Sort:       (i + 1) * 10,
+				IsEnabled:  true,
+			})
+		}
+	}
+	return ms
+}
+
+// OesTaskCatalogRequest 用于创建或更新日常任务目录的请求结构体
+//
+// swagger:model OesTaskCatalogRequest
+type OesTaskCatalogRequest struct {
+	// 系统类型
+	SystemType string `json:"system_type" binding:"required,oneof=STK CRD OPT"`
+
+	// 任务名称, 只能包含小写字母、数字和下划线, 以小写字母开头
+	TaskName string `json:"task_name" binding:"required,max=30"`
+
+	// 排序, 越小越靠前
+	Sort int `json:"sort" binding:"omitempty,gte=0"`
+
+	// 是否启用
+	IsEnabled bool `json:"is_enabled"`
+
+	// 说明
+	Description string `json:"description" binding:"omitempty,max=254"`
+}
+
+func (req *OesTaskCatalogRequest) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if req == nil {
+		return nil
+	}
+	enc.AddString("system_type", req.SystemType)
+	enc.AddString("task_name", req.TaskName)
+	enc.AddInt("sort", req.Sort)
+	enc.AddBool("is_enabled", req.IsEnabled)
+	return nil
+}
+
+func (req *OesTaskCatalogRequest) ToModel() OesTaskCatalogModel {
+	return OesTaskCatalogModel{
+		SystemType:  req.SystemType,
+		TaskName:    req.TaskName,
+		Sort:        req.Sort,
+		IsEnabled:   req.IsEnabled,
+		Description: req.Description,
+	}
+}
+
+// ListOesTaskCatalogRequest 用于查询日常任务目录列表的请求结构体
+//
+// swagger:model ListOesTaskCatalogRequest
+type ListOesTaskCatalogRequest struct {
+	common.BaseModelQuery
+
+	// 系统类型
+	SystemType string `form:"system_type"`
+
+	// 任务名称
+	TaskName string `form:"task_name"`
+
+	// 是否启用
+	IsEnabled *bool `form:"is_enabled"`
+}
+
+func (req *ListOesTaskCatalogRequest) Query() (int, int, map[string]any) {
+	page, size, query := req.BaseModelQuery.QueryMap(50)
+	if req.SystemType != "" {
+		query["system_type = ?"] = req.SystemType
+	}
+	if req.TaskName != "" {
+		query["task_name = ?"] = req.TaskName
+	}
+	if req.IsEnabled != nil {
+		query["is_enabled = ?"] = *req.IsEnabled
+	}
+	return page, size, query
+}
+
+type OesTaskCatalogOut struct {
+	// ID
+	ID uint32 `json:"id" example:"1"`
+
+	// 系统类型
+	SystemType string `json:"system_type" example:"STK"`
+
+	// 任务名称
+	TaskName string `json:"task_name" example:"counter_fetch"`
+
+	// 排序
+	Sort int `json:"sort" example:"10"`
+
+	// 是否启用
+	IsEnabled bool `json:"is_enabled" example:"true"`
+
+	// 说明
+	Description string `json:"description" example:"获取柜台数据"`
+
+	// 创建时间
+	CreatedAt string `json:"created_at" example:"2023-01-01 12:00:00"`
+
+	// 更新时间
+	UpdatedAt string `json:"updated_at" example:"2023-01-01 12:00:00"`
+}
+
+// OesTaskCatalogReply 日常任务目录响应结构
+type OesTaskCatalogReply = common.APIReply[*OesTaskCatalogOut]
+
+// PagOesTaskCatalogReply 日常任务目录的分页响应结构
+type PagOesTaskCatalogReply = common.APIReply[*common.Pag[OesTaskCatalogOut]]
+
+func OesTaskCatalogToOut(
+	m OesTaskCatalogModel,
+) *OesTaskCatalogOut {
+	return &OesTaskCatalogOut{
+		ID:          m.ID,
+		SystemType:  m.SystemType,
+		TaskName:    m.TaskName,
+		Sort:        m.Sort,
+		IsEnabled:   m.IsEnabled,
+		Description: m.Description,
+		CreatedAt:   m.CreatedAt.Format(time.DateTime),
+		UpdatedAt:   m.UpdatedAt.Format(time.DateTime),
+	}
+}
+
+func ListOesTaskCatalogToOut(
+	rms *[]OesTaskCatalogModel,
+) *[]OesTaskCatalogOut {
+	if rms == nil {
+		return &[]OesTaskCatalogOut{}
+	}
+
+	ms := *rms
+	mso := make([]OesTaskCatalogOut, 0, len(ms))
+	for _, m := range ms {
+		mso = append(mso, *OesTaskCatalogToOut(m))
+	}
+	return &mso
+}
@@ -0,0 +1,284 @@
+package oes
+
+import (
+	"time"
+
+	"go.uber.org/zap/zapcore"
+
+	"gin-artweb/internal/model/common"
+	"gin-artweb/internal/shared/database"
+)
+
+// 配置下发审计资源和动作
+const (
+	ConfTemplateAuditResource = "colony"
+	ConfTemplateAuditDeploy   = "conf_deploy"
+)
+
+// ConfTemplateDirAll 模板下发到集群的全部启用节点
+const ConfTemplateDirAll = "all"
+
+// OesConfTemplateModel oes配置文件模板
+//
+// Content和RemotePath使用Go模板语法, 变量来自集群、节点和主机记录, 见OesConfTemplateVars
+type OesConfTemplateModel struct {
+	database.StandardModel
+	Name       string `gorm:"column:name;type:varchar(50);not null;uniqueIndex;comment:名称" json:"name"`
+	DirName    string `gorm:"column:dir_name;type:varchar(10);not null;comment:适用节点目录" json:"dir_name"`
+	RemotePath string `gorm:"column:remote_path;type:varchar(512);not null;comment:目标文件路径模板" json:"remote_path"`
+	Content    string `gorm:"column:content;type:text;comment:模板内容" json:"content"`
+	Remark     string `gorm:"column:remark;type:varchar(254);comment:备注" json:"remark"`
+}
+
+func (m *OesConfTemplateModel) TableName() string {
+	return "oes_conf_template"
+}
+
+func (m *OesConfTemplateModel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if m == nil {
+		return nil
+	}
+	if err := m.StandardModel.MarshalLogObject(enc); err != nil {
+		return err
+	}
+	enc.AddString("name", m.Name)
+	enc.AddString("dir_name", m.DirName)
+	enc.AddString("remote_path", m.RemotePath)
+	enc.AddInt("content_size", len(m.Content))
+	enc.AddString("remark", m.Remark)
+	return nil
+}
+
+// OesConfTemplateVars 渲染配置模板时可用的变量
+type OesConfTemplateVars struct {
+	// 集群信息
+	Colony OesConfColonyVars
+	// 当前渲染的节点
+	Node OesConfNodeVars
+	// 集群的全部启用节点
+	Nodes []OesConfNodeVars
+}
+
+type OesConfColonyVars struct {
+	ID              uint32
+	SystemType      string
+	ColonyNum       string
+	ExtractedName   string
+	Version         string
+	XCounterVersion string
+	MonNodeName     string
+	MonNodeURL      string
+}
+
+type OesConfNodeVars struct {
+	ID       uint32
+	NodeRole string
+	Specdir  string
+	HostID   uint32
+	HostName string
+	SSHIP    string
+	SSHPort  uint16
+	SSHUser  string
+}
+
+// NewOesConfColonyVars 从集群记录生成模板变量, 需预加载Package、XCounter和MonNode
+func NewOesConfColonyVars(m OesColonyModel) OesConfColonyVars {
+	return OesConfColonyVars{
+		ID:              m.ID,
+		SystemType:      m.SystemType,
+		ColonyNum:       m.ColonyNum,
+		ExtractedName:   m.ExtractedName,
+		Version:         m.Package.Version,
+		XCounterVersion: m.XCounter.Version,
+		MonNodeName:     m.MonNode.Name,
+		MonNodeURL:      m.MonNode.URL,
+	}
+}
+
+// NewOesConfNodeVars 从节点记录生成模板变量, 需预加载Host
+func NewOesConfNodeVars(m OesNodeModel) OesConfNodeVars {
+	return OesConfNodeVars{
+		ID:       m.ID,
+		NodeRole: m.NodeRole,
+		Specdir:  NodeSpecdir(m.NodeRole),
+		HostID:   m.HostID,
+		HostName: m.Host.Name,
+		SSHIP:    m.Host.SSHIP,
+		SSHPort:  m.Host.SSHPort,
+		SSHUser:  m.Host.SSHUser,
+	}
+}
+
+// OesConfTemplateRequest 用于创建和更新配置模板的请求结构体
+//
+// swagger:model OesConfTemplateRequest
+type OesConfTemplateRequest struct {
+	// 名称
+	Name string `json:"name" binding:"required,max=50"`
+
+	// 适用节点目录, all表示集群的全部启用节点
+	DirName string `json:"dir_name" binding:"required,oneof=all host_01 host_02 host_03"`
+
+	// 目标文件路径模板, 渲染后必须为绝对路径
+	// example: /home/quant/oes_{{ .Colony.ColonyNum }}/conf/oes.conf
+	RemotePath string `json:"remote_path" binding:"required,max=512"`
+
+	// 模板内容
+	Content string `json:"content" binding:"max=1048576"`
+
+	// 备注
+	Remark string `json:"remark" binding:"omitempty,max=254"`
+}
+
+// ListOesConfTemplateRequest 用于查询配置模板列表的请求结构体
+//
+// swagger:model ListOesConfTemplateRequest
+type ListOesConfTemplateRequest struct {
+	common.BaseModelQuery
+
+	// 名称
+	Name string `form:"name"`
+
+	// 适用节点目录
+	DirName string `form:"dir_name"`
+}
+
+func (req *ListOesConfTemplateRequest) Query() (int, int, map[string]any) {
+	page, size, query := req.BaseModelQuery.QueryMap(10)
+	if req.Name != "" {
+		query["name like ?"] = "%" + req.Name + "%"
+	}
+	if req.DirName != "" {
+		query["dir_name = ?"] = req.DirName
+	}
+	return page, size, query
+}
+
+// RenderOesConfTemplateRequest 用于预览配置模板渲染结果的请求结构体
+//
+// swagger:model RenderOesConfTemplateRequest
+type RenderOesConfTemplateRequest struct {
+	// oes集群ID
+	OesColonyID uint32 `json:"oes_colony_id" binding:"required"`
+}
+
+// DeployOesConfTemplateRequest 用于下发配置文件的请求结构体
+//
+// swagger:model DeployOesConfTemplateRequest
+type DeployOesConfTemplateRequest struct {
+	// oes集群ID
+	OesColonyID uint32 `json:"oes_colony_id" binding:"required"`
+
+	// 下发的节点ID, 为空时下发到模板适用的全部节点
+	NodeIDs []uint32 `json:"node_ids" binding:"omitempty,max=50,dive,gt=0"`
+}
+
+type OesConfTemplateOut struct {
+	// ID
+	ID uint32 `json:"id" example:"1"`
+
+	// 名称
+	Name string `json:"name" example:"oes.conf"`
+
+	// 适用节点目录
+	DirName string `json:"dir_name" example:"all"`
+
+	// 目标文件路径模板
+	RemotePath string `json:"remote_path" example:"/home/quant/oes_{{ .Colony.ColonyNum }}/conf/oes.conf"`
+
+	// 模板内容
+	Content string `json:"content" example:"colony_num = {{ .Colony.ColonyNum }}"`
+
+	// 备注
+	Remark string `json:"remark" example:"主配置文件"`
+
+	// 创建时间
+	CreatedAt string `json:"created_at" example:"2023-01-01 12:00:00"`
+
+	// 更新时间
+	UpdatedAt string `json:"updated_at" example:"2023-01-01 12:00:00"`
+}
+
+// OesConfRenderOut 配置模板在一个节点上的渲染结果
+type OesConfRenderOut struct {
+	// 节点ID
+	NodeID uint32 `json:"node_id" example:"1"`
+
+	// 主机ID
+	HostID uint32 `json:"host_id" example:"1"`
+
+	// 主机名称
+	HostName string `json:"host_name" example:"oes-01"`
+
+	// 目标文件路径
+	RemotePath string `json:"remote_path" example:"/home/quant/oes_01/conf/oes.conf"`
+
+	// 渲染后的内容
+	Content string `json:"content" example:"colony_num = 01"`
+
+	// 目标文件是否已存在
+	Exists bool `json:"exists" example:"true"`
+
+	// 与目标文件当前内容的差异(unified格式), 内容相同时为空
+	Diff string `json:"diff" example:"@@ -1 +1 @@"`
+}
+
+// OesConfDeployOut 配置文件在一个节点上的下发结果
+type OesConfDeployOut struct {
+	// 节点ID
+	NodeID uint32 `json:"node_id" example:"1"`
+
+	// 主机ID
+	HostID uint32 `json:"host_id" example:"1"`
+
+	// 目标文件路径
+	RemotePath string `json:"remote_path" example:"/home/quant/oes_01/conf/oes.conf"`
+
+	// 文件内容是否发生变化, 未变化时不写入
+	Changed bool `json:"changed" example:"true"`
+
+	// 下发失败的原因, 成功时为空
+	Error string `json:"error" example:""`
+}
+
+// OesConfTemplateReply 配置模板响应结构
+type OesConfTemplateReply = common.APIReply[OesConfTemplateOut]
+
+// PagOesConfTemplateReply 配置模板的分页响应结构
+type PagOesConfTemplateReply = common.APIReply[*common.Pag[OesConfTemplateOut]]
+
+// OesConfRenderReply 配置模板渲染预览响应结构
+type OesConfRenderReply = common.APIReply[[]OesConfRenderOut]
+
+// OesConfDeployReply 配置文件下发响应结构
+type OesConfDeployReply = common.APIReply[[]OesConfDeployOut]
+
+func OesConfTemplateToOut(
+	m OesConfTemplateModel,
+) *OesConfTemplateOut {
+	return &OesConfTemplateOut{
+		ID:         m.ID,
+		Name:       m.Name,
+		DirName:    m.DirName,
+		RemotePath: m.RemotePath,
+		Content:    m.Content,
+		Remark:     m.Remark,
+		CreatedAt:  m.CreatedAt.Format(time.DateTime),
+		UpdatedAt:  m.UpdatedAt.Format(time.DateTime),
+	}
+}
+
+func ListOesConfTemplateToOut(
+	rms *[]OesConfTemplateModel,
+) *[]OesConfTemplateOut {
+	if rms == nil {
+		return &[]OesConfTemplateOut{}
+	}
+
+	ms := *rms
+	mso := make([]OesConfTemplateOut, 0, len(ms))
+	for _, m := range ms {
+		mso = append(mso, *OesConfTemplateToOut(m))
+	}
+	return &mso
+}
@@ -0,0 +1,138 @@
+package oes
+
+import (
+	"fmt"
+
+	"gin-artweb/internal/model/common"
+	"gin-artweb/internal/model/mon"
+	"gin-artweb/internal/model/resource"
+)
+
+// 拓扑节点类型
+const (
+	TopologyNodeColony   = "colony"
+	TopologyNodePackage  = "package"
+	TopologyNodeXCounter = "xcounter"
+	TopologyNodeMonNode  = "mon_node"
+	TopologyNodeOesNode  = "oes_node"
+	TopologyNodeHost     = "host"
+	TopologyNodeTask     = "task"
+)
+
+// 拓扑连线类型
+const (
+	TopologyEdgeUsesPackage  = "uses_package"
+	TopologyEdgeUsesXCounter = "uses_xcounter"
+	TopologyEdgeMonitoredBy  = "monitored_by"
+	TopologyEdgeContains     = "contains"
+	TopologyEdgeDeployedOn   = "deployed_on"
+	TopologyEdgeRuns         = "runs"
+)
+
+// TopologyNodeOut 拓扑节点
+type TopologyNodeOut struct {
+	// 节点标识, 格式为 类型:ID
+	ID string `json:"id" example:"colony:1"`
+
+	// 节点类型(colony/package/xcounter/mon_node/oes_node/host/task)
+	Type string `json:"type" example:"colony"`
+
+	// 显示名称
+	Label string `json:"label" example:"01"`
+
+	// 节点详情
+	Data any `json:"data"`
+}
+
+// TopologyEdgeOut 拓扑连线
+type TopologyEdgeOut struct {
+	// 起点节点标识
+	Source string `json:"source" example:"colony:1"`
+
+	// 终点节点标识
+	Target string `json:"target" example:"package:1"`
+
+	// 连线类型
+	Type string `json:"type" example:"uses_package"`
+}
+
+// OesColonyTopologyOut oes集群拓扑图
+type OesColonyTopologyOut struct {
+	// 节点列表
+	Nodes []TopologyNodeOut `json:"nodes"`
+
+	// 连线列表
+	Edges []TopologyEdgeOut `json:"edges"`
+}
+
+// OesColonyTopologyReply oes集群拓扑图响应结构
+type OesColonyTopologyReply = common.APIReply[OesColonyTopologyOut]
+
+func topologyNodeID(typ string, id any) string {
+	return fmt.Sprintf("%s:%v", typ, id)
+}
+
+// BuildOesColonyTopology 根据集群、节点和任务状态构建拓扑图
+// 集群需要预加载Package、XCounter、MonNode.Host, 节点需要预加载Host
+// 同一主机被多个节点引用时只生成一个主机节点
+func BuildOesColonyTopology(
+	colony OesColonyModel,
+	nodes []OesNodeModel,
+	tasks []common.TaskInfo,
+) *OesColonyTopologyOut {
+	out := &OesColonyTopologyOut{
+		Nodes: []TopologyNodeOut{},
+		Edges: []TopologyEdgeOut{},
+	}
+	hosts := map[uint32]bool{}
+	addNode := func(typ string, id any, label string, data any) string {
+		nid := topologyNodeID(typ, id)
+		out.Nodes = append(out.Nodes, TopologyNodeOut{ID: nid, Type: typ, Label: label, Data: data})
+		return nid
+	}
+	addEdge := func(source, target, typ string) {
+		out.Edges = append(out.Edges, TopologyEdgeOut{Source: source, Target: target, Type: typ})
+	}
+	addHost := func(h resource.HostModel) string {
+		if !hosts[h.ID] {
+			hosts[h.ID] = true
+			addNode(TopologyNodeHost, h.ID, h.Name, resource.HostModelToBaseOut(h))
+		}
+		return topologyNodeID(TopologyNodeHost, h.ID)
+	}
+
+	colonyID := addNode(TopologyNodeColony, colony.ID, colony.ColonyNum, OesColonyToStandardOut(colony))
+
+	if colony.PackageID != 0 {
+		pid := addNode(TopologyNodePackage, colony.PackageID, colony.Package.OriginFilename,
+			resource.PackageModelToOutBase(colony.Package))
+		addEdge(colonyID, pid, TopologyEdgeUsesPackage)
+	}
+	if colony.XCounterID != 0 {
+		xid := addNode(TopologyNodeXCounter, colony.XCounterID, colony.XCounter.OriginFilename,
+			resource.PackageModelToOutBase(colony.XCounter))
+		addEdge(colonyID, xid, TopologyEdgeUsesXCounter)
+	}
+	if colony.MonNodeID != 0 {
+		mid := addNode(TopologyNodeMonNode, colony.MonNodeID, colony.MonNode.Name,
+			mon.MonNodeToBaseOut(colony.MonNode))
+		addEdge(colonyID, mid, TopologyEdgeMonitoredBy)
+		if colony.MonNode.HostID != 0 {
+			addEdge(mid, addHost(colony.MonNode.Host), TopologyEdgeDeployedOn)
+		}
+	}
+
+	for _, n := range nodes {
+		nid := addNode(TopologyNodeOesNode, n.ID, n.NodeRole, OesNodeToStandardOut(n))
+		addEdge(colonyID, nid, TopologyEdgeContains)
+		if n.HostID != 0 {
+			addEdge(nid, addHost(n.Host), TopologyEdgeDeployedOn)
+		}
+	}
+
+	for _, t := range tasks {
+		tid := addNode(TopologyNodeTask, t.TaskName, t.TaskName, t)
+		addEdge(colonyID, tid, TopologyEdgeRuns)
+	}
+	return out
+}
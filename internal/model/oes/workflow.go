@@ -0,0 +1,310 @@
+package oes
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+
+	"gin-artweb/internal/model/common"
+	"gin-artweb/internal/shared/database"
+)
+
+// 编排及步骤的执行状态
+const (
+	WorkflowStatusPending = "pending" // 等待执行
+	WorkflowStatusRunning = "running" // 执行中
+	WorkflowStatusSuccess = "success" // 执行成功
+	WorkflowStatusFailed  = "failed"  // 执行失败
+	WorkflowStatusSkipped = "skipped" // 依赖步骤失败, 已跳过
+)
+
+// OesWorkflowStepConf 编排配置文件中的步骤定义
+type OesWorkflowStepConf struct {
+	Name      string   `yaml:"name" json:"name"`
+	Script    string   `yaml:"script" json:"script"`
+	DependsOn []string `yaml:"depends_on" json:"depends_on"`
+	Timeout   int      `yaml:"timeout" json:"timeout"`
+}
+
+// OesWorkflowConf 编排配置文件, 按系统类型(小写)配置步骤
+type OesWorkflowConf map[string][]OesWorkflowStepConf
+
+// OesWorkflowRunModel oes日常任务编排执行记录
+type OesWorkflowRunModel struct {
+	database.StandardModel
+	OesColonyID uint32                    `gorm:"column:oes_colony_id;not null;index;comment:oes集群ID" json:"oes_colony_id"`
+	ColonyNum   string                    `gorm:"column:colony_num;type:varchar(2);comment:集群号" json:"colony_num"`
+	SystemType  string                    `gorm:"column:system_type;type:varchar(20);comment:系统类型" json:"system_type"`
+	Status      string                    `gorm:"column:status;type:varchar(10);not null;default:pending;comment:执行状态" json:"status"`
+	StartedAt   *time.Time                `gorm:"column:started_at;comment:开始时间" json:"started_at"`
+	FinishedAt  *time.Time                `gorm:"column:finished_at;comment:结束时间" json:"finished_at"`
+	Username    string                    `gorm:"column:username;type:varchar(50);comment:用户名" json:"username"`
+	Steps       []OesWorkflowRunStepModel `gorm:"foreignKey:RunID;constraint:OnDelete:CASCADE" json:"steps"`
+}
+
+func (m *OesWorkflowRunModel) TableName() string {
+	return "oes_workflow_run"
+}
+
+func (m *OesWorkflowRunModel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if m == nil {
+		return nil
+	}
+	if err := m.StandardModel.MarshalLogObject(enc); err != nil {
+		return err
+	}
+	enc.AddUint32("oes_colony_id", m.OesColonyID)
+	enc.AddString("colony_num", m.ColonyNum)
+	enc.AddString("system_type", m.SystemType)
+	enc.AddString("status", m.Status)
+	enc.AddString("username", m.Username)
+	return nil
+}
+
+// OesWorkflowRunStepModel oes日常任务编排中单个步骤的执行记录
+type OesWorkflowRunStepModel struct {
+	database.BaseModel
+	RunID        uint32     `gorm:"column:run_id;not null;index;comment:编排执行记录ID" json:"run_id"`
+	Name         string     `gorm:"column:name;type:varchar(50);not null;comment:步骤名称" json:"name"`
+	ScriptID     uint32     `gorm:"column:script_id;comment:脚本ID" json:"script_id"`
+	DependsOn    string     `gorm:"column:depends_on;type:varchar(254);comment:依赖的步骤(逗号分隔)" json:"depends_on"`
+	Timeout      int        `gorm:"column:timeout;comment:超时时间(秒)" json:"timeout"`
+	Sort         int        `gorm:"column:sort;comment:排序" json:"sort"`
+	Status       string     `gorm:"column:status;type:varchar(10);not null;default:pending;comment:执行状态" json:"status"`
+	RecordID     uint32     `gorm:"column:record_id;comment:脚本执行记录ID" json:"record_id"`
+	ErrorMessage string     `gorm:"column:error_message;type:text;comment:错误信息" json:"error_message"`
+	StartedAt    *time.Time `gorm:"column:started_at;comment:开始时间" json:"started_at"`
+	FinishedAt   *time.Time `gorm:"column:finished_at;comment:结束时间" json:"finished_at"`
+}
+
+func (m *OesWorkflowRunStepModel) TableName() string {
+	return "oes_workflow_run_step"
+}
+
+func (m *OesWorkflowRunStepModel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if m == nil {
+		return nil
+	}
+	if err := m.BaseModel.MarshalLogObject(enc); err != nil {
+		return err
+	}
+	enc.AddUint32("run_id", m.RunID)
+	enc.AddString("name", m.Name)
+	enc.AddUint32("script_id", m.ScriptID)
+	enc.AddString("depends_on", m.DependsOn)
+	enc.AddString("status", m.Status)
+	enc.AddUint32("record_id", m.RecordID)
+	return nil
+}
+
+// DependsOnList 返回步骤依赖的步骤名称列表
+func (m *OesWorkflowRunStepModel) DependsOnList() []string {
+	if m.DependsOn == "" {
+		return nil
+	}
+	return strings.Split(m.DependsOn, ",")
+}
+
+// ListOesWorkflowRunRequest 用于查询编排执行记录的请求结构体
+//
+// swagger:model ListOesWorkflowRunRequest
+type ListOesWorkflowRunRequest struct {
+	common.BaseModelQuery
+
+	// oes集群ID
+	OesColonyID uint32 `form:"oes_colony_id" binding:"omitempty,gt=0"`
+
+	// 集群号
+	ColonyNum string `form:"colony_num" binding:"omitempty,max=2"`
+
+	// 系统类型
+	SystemType string `form:"system_type" binding:"omitempty,oneof=STK CRD OPT"`
+
+	// 执行状态
+	Status string `form:"status" binding:"omitempty,oneof=pending running success failed"`
+}
+
+func (req *ListOesWorkflowRunRequest) Query() (int, int, map[string]any) {
+	page, size, query := req.BaseModelQuery.QueryMap(10)
+	if req.OesColonyID != 0 {
+		query["oes_colony_id = ?"] = req.OesColonyID
+	}
+	if req.ColonyNum != "" {
+		query["colony_num = ?"] = req.ColonyNum
+	}
+	if req.SystemType != "" {
+		query["system_type = ?"] = req.SystemType
+	}
+	if req.Status != "" {
+		query["status = ?"] = req.Status
+	}
+	return page, size, query
+}
+
+// WorkflowSystemTypeUri 按系统类型查询编排定义的路径参数
+type WorkflowSystemTypeUri struct {
+	SystemType string `uri:"system_type" binding:"required,oneof=STK CRD OPT"`
+}
+
+type OesWorkflowRunStepOut struct {
+	// 步骤名称
+	Name string `json:"name" example:"counter_fetch"`
+
+	// 脚本ID
+	ScriptID uint32 `json:"script_id" example:"1"`
+
+	// 依赖的步骤
+	DependsOn []string `json:"depends_on" example:"mon"`
+
+	// 执行状态(pending/running/success/failed/skipped)
+	Status string `json:"status" example:"success"`
+
+	// 脚本执行记录ID
+	RecordID uint32 `json:"record_id" example:"1"`
+
+	// 错误信息
+	ErrorMessage string `json:"error_message" example:""`
+
+	// 开始时间
+	StartedAt string `json:"started_at" example:"2023-01-01 12:00:00"`
+
+	// 结束时间
+	FinishedAt string `json:"finished_at" example:"2023-01-01 12:00:10"`
+
+	// 耗时(秒)
+	Duration float64 `json:"duration" example:"10"`
+}
+
+type OesWorkflowRunOut struct {
+	// ID
+	ID uint32 `json:"id" example:"1"`
+
+	// oes集群ID
+	OesColonyID uint32 `json:"oes_colony_id" example:"1"`
+
+	// 集群号
+	ColonyNum string `json:"colony_num" example:"01"`
+
+	// 系统类型
+	SystemType string `json:"system_type" example:"STK"`
+
+	// 执行状态(pending/running/success/failed)
+	Status string `json:"status" example:"success"`
+
+	// 开始时间
+	StartedAt string `json:"started_at" example:"2023-01-01 12:00:00"`
+
+	// 结束时间
+	FinishedAt string `json:"finished_at" example:"2023-01-01 12:30:00"`
+
+	// 耗时(秒)
+	Duration float64 `json:"duration" example:"1800"`
+
+	// 用户名
+	Username string `json:"username" example:"admin"`
+
+	// 创建时间
+	CreatedAt string `json:"created_at" example:"2023-01-01 12:00:00"`
+}
+
+type OesWorkflowRunDetailOut struct {
+	OesWorkflowRunOut
+
+	// 步骤执行详情
+	Steps []OesWorkflowRunStepOut `json:"steps"`
+}
+
+// OesWorkflowRunReply 编排执行详情响应结构
+type OesWorkflowRunReply = common.APIReply[OesWorkflowRunDetailOut]
+
+// PagOesWorkflowRunReply 编排执行记录的分页响应结构
+type PagOesWorkflowRunReply = common.APIReply[*common.Pag[OesWorkflowRunOut]]
+
+// OesWorkflowDefinitionReply 编排定义响应结构
+type OesWorkflowDefinitionReply = common.APIReply[[]OesWorkflowStepConf]
+
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.DateTime)
+}
+
+func durationSeconds(start, end *time.Time) float64 {
+	if start == nil {
+		return 0
+	}
+	if end == nil {
+		return time.Since(*start).Seconds()
+	}
+	return end.Sub(*start).Seconds()
+}
+
+func OesWorkflowRunStepToOut(
+	m OesWorkflowRunStepModel,
+) *OesWorkflowRunStepOut {
+	deps := m.DependsOnList()
+	if deps == nil {
+		deps = []string{}
+	}
+	return &OesWorkflowRunStepOut{
+		Name:         m.Name,
+		ScriptID:     m.ScriptID,
+		DependsOn:    deps,
+		Status:       m.Status,
+		RecordID:     m.RecordID,
+		ErrorMessage: m.ErrorMessage,
+		StartedAt:    formatOptionalTime(m.StartedAt),
+		FinishedAt:   formatOptionalTime(m.FinishedAt),
+		Duration:     durationSeconds(m.StartedAt, m.FinishedAt),
+	}
+}
+
+func OesWorkflowRunToOut(
+	m OesWorkflowRunModel,
+) *OesWorkflowRunOut {
+	return &OesWorkflowRunOut{
+		ID:          m.ID,
+		OesColonyID: m.OesColonyID,
+		ColonyNum:   m.ColonyNum,
+		SystemType:  m.SystemType,
+		Status:      m.Status,
+		StartedAt:   formatOptionalTime(m.StartedAt),
+		FinishedAt:  formatOptionalTime(m.FinishedAt),
+		Duration:    durationSeconds(m.StartedAt, m.FinishedAt),
+		Username:    m.Username,
+		CreatedAt:   m.CreatedAt.Format(time.DateTime),
+	}
+}
+
+func OesWorkflowRunToDetailOut(
+	m OesWorkflowRunModel,
+) *OesWorkflowRunDetailOut {
+	rms := append([]OesWorkflowRunStepModel(nil), m.Steps...)
+	sort.Slice(rms, func(i, j int) bool { return rms[i].Sort < rms[j].Sort })
+	steps := make([]OesWorkflowRunStepOut, 0, len(rms))
+	for _, s := range rms {
+		steps = append(steps, *OesWorkflowRunStepToOut(s))
+	}
+	return &OesWorkflowRunDetailOut{
+		OesWorkflowRunOut: *OesWorkflowRunToOut(m),
+		Steps:             steps,
+	}
+}
+
+func ListOesWorkflowRunToOut(
+	rms *[]OesWorkflowRunModel,
+) *[]OesWorkflowRunOut {
+	if rms == nil {
+		return &[]OesWorkflowRunOut{}
+	}
+
+	ms := *rms
+	mso := make([]OesWorkflowRunOut, 0, len(ms))
+	for _, m := range ms {
+		mso = append(mso, *OesWorkflowRunToOut(m))
+	}
+	return &mso
+}
@@ -0,0 +1,294 @@
+package resource
+
+import (
+	"time"
+
+	"go.uber.org/zap/zapcore"
+
+	"gin-artweb/internal/model/common"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/events"
+)
+
+// 主机代理的在线状态
+const (
+	HostAgentOnline  = "online"  // 按时上报心跳
+	HostAgentOffline = "offline" // 超过失联时间未上报心跳
+)
+
+// AlertKindHostAgent 主机代理在线状态变化告警
+const AlertKindHostAgent = "host_agent"
+
+// HostAgentDisk 代理上报的磁盘信息
+type HostAgentDisk struct {
+	Mount   string `json:"mount" binding:"required,max=254"`
+	FsType  string `json:"fs_type" binding:"omitempty,max=20"`
+	TotalMB uint64 `json:"total_mb"`
+	UsedMB  uint64 `json:"used_mb"`
+}
+
+// HostAgentMetrics 代理随心跳上报的主机基础指标
+type HostAgentMetrics struct {
+	// CPU使用率(%)
+	CPUPercent float64 `json:"cpu_percent" binding:"gte=0,lte=100"`
+
+	// 内存使用率(%)
+	MemPercent float64 `json:"mem_percent" binding:"gte=0,lte=100"`
+
+	// 根文件系统使用率(%)
+	DiskPercent float64 `json:"disk_percent" binding:"gte=0,lte=100"`
+
+	// 1分钟平均负载
+	Load1 float64 `json:"load1" binding:"gte=0"`
+
+	// 5分钟平均负载
+	Load5 float64 `json:"load5" binding:"gte=0"`
+
+	// 15分钟平均负载
+	Load15 float64 `json:"load15" binding:"gte=0"`
+}
+
+// HostAgentModel 主机代理上报的主机信息和心跳状态, 每台主机一条记录
+type HostAgentModel struct {
+	database.StandardModel
+	HostID          uint32          `gorm:"column:host_id;not null;uniqueIndex;comment:主机ID" json:"host_id"`
+	Host            HostModel       `gorm:"foreignKey:HostID;references:ID;constraint:OnDelete:CASCADE" json:"host"`
+	Hostname        string          `gorm:"column:hostname;type:varchar(254);comment:主机名" json:"hostname"`
+	Serial          string          `gorm:"column:serial;type:varchar(100);index;comment:机器序列号" json:"serial"`
+	OS              string          `gorm:"column:os;type:varchar(100);comment:操作系统" json:"os"`
+	Kernel          string          `gorm:"column:kernel;type:varchar(100);comment:内核版本" json:"kernel"`
+	Arch            string          `gorm:"column:arch;type:varchar(20);comment:CPU架构" json:"arch"`
+	CPUCores        int             `gorm:"column:cpu_cores;comment:CPU核数" json:"cpu_cores"`
+	MemoryMB        uint64          `gorm:"column:memory_mb;comment:内存(MB)" json:"memory_mb"`
+	Disks           []HostAgentDisk `gorm:"column:disks;serializer:json;comment:磁盘" json:"disks"`
+	IPs             []string        `gorm:"column:ips;serializer:json;comment:IP地址" json:"ips"`
+	AgentVersion    string          `gorm:"column:agent_version;type:varchar(50);comment:代理版本" json:"agent_version"`
+	Status          string          `gorm:"column:status;type:varchar(10);not null;index;comment:在线状态" json:"status"`
+	LastHeartbeatAt time.Time       `gorm:"column:last_heartbeat_at;index;comment:最近心跳时间" json:"last_heartbeat_at"`
+}
+
+func (m *HostAgentModel) TableName() string {
+	return "resource_host_agent"
+}
+
+func (m *HostAgentModel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if m == nil {
+		return nil
+	}
+	if err := m.StandardModel.MarshalLogObject(enc); err != nil {
+		return err
+	}
+	enc.AddUint32("host_id", m.HostID)
+	enc.AddString("hostname", m.Hostname)
+	enc.AddString("serial", m.Serial)
+	enc.AddString("os", m.OS)
+	enc.AddString("arch", m.Arch)
+	enc.AddInt("cpu_cores", m.CPUCores)
+	enc.AddUint64("memory_mb", m.MemoryMB)
+	enc.AddString("agent_version", m.AgentVersion)
+	enc.AddString("status", m.Status)
+	enc.AddTime("last_heartbeat_at", m.LastHeartbeatAt)
+	return nil
+}
+
+// HostAgentStatusEvent 主机代理失联或恢复在线事件
+type HostAgentStatusEvent struct {
+	Kind            string `json:"kind"`
+	HostID          uint32 `json:"host_id"`
+	HostName        string `json:"host_name"`
+	SSHIP           string `json:"ssh_ip"`
+	From            string `json:"from"`
+	To              string `json:"to"`
+	LastHeartbeatAt string `json:"last_heartbeat_at"`
+	CheckedAt       string `json:"checked_at"`
+}
+
+func (e HostAgentStatusEvent) EventType() string {
+	return events.AlertFired
+}
+
+// RegisterHostAgentRequest 主机代理注册的请求结构体
+// 按SSH地址、端口和用户匹配已有主机, 不存在时自动创建主机
+//
+// swagger:model RegisterHostAgentRequest
+type RegisterHostAgentRequest struct {
+	// 主机名, 自动创建主机时作为主机名称
+	Hostname string `json:"hostname" binding:"required,max=50"`
+
+	// SSH地址, 为空时使用请求的来源地址
+	SSHIP string `json:"ssh_ip" binding:"omitempty,ip"`
+
+	// SSH端口, 为空时使用配置的默认端口
+	SSHPort uint16 `json:"ssh_port"`
+
+	// SSH用户, 为空时使用配置的默认用户
+	SSHUser string `json:"ssh_user" binding:"omitempty,max=50"`
+
+	// 机器序列号, 用于识别重复登记的主机
+	Serial string `json:"serial" binding:"omitempty,max=100"`
+
+	// 操作系统
+	OS string `json:"os" binding:"omitempty,max=100"`
+
+	// 内核版本
+	Kernel string `json:"kernel" binding:"omitempty,max=100"`
+
+	// CPU架构
+	Arch string `json:"arch" binding:"omitempty,max=20"`
+
+	// CPU核数
+	CPUCores int `json:"cpu_cores" binding:"gte=0"`
+
+	// 内存(MB)
+	MemoryMB uint64 `json:"memory_mb"`
+
+	// 磁盘
+	Disks []HostAgentDisk `json:"disks" binding:"omitempty,max=64,dive"`
+
+	// IP地址
+	IPs []string `json:"ips" binding:"omitempty,max=64,dive,ip"`
+
+	// 代理版本
+	AgentVersion string `json:"agent_version" binding:"omitempty,max=50"`
+}
+
+// HostAgentHeartbeatRequest 主机代理心跳的请求结构体
+//
+// swagger:model HostAgentHeartbeatRequest
+type HostAgentHeartbeatRequest struct {
+	// 注册时返回的主机ID
+	HostID uint32 `json:"host_id" binding:"required,gt=0"`
+
+	// 主机基础指标, 上报后mon模块不再通过SSH采集该主机的指标
+	Metrics *HostAgentMetrics `json:"metrics" binding:"omitempty"`
+}
+
+// ListHostAgentRequest 用于查询主机代理列表的请求结构体
+//
+// swagger:model ListHostAgentRequest
+type ListHostAgentRequest struct {
+	common.BaseModelQuery
+
+	// 主机ID
+	HostID uint32 `form:"host_id" binding:"omitempty"`
+
+	// 在线状态
+	Status string `form:"status" binding:"omitempty,oneof=online offline"`
+}
+
+func (req *ListHostAgentRequest) Query() (int, int, map[string]any) {
+	page, size, query := req.BaseModelQuery.QueryMap(10)
+	if req.HostID != 0 {
+		query["host_id = ?"] = req.HostID
+	}
+	if req.Status != "" {
+		query["status = ?"] = req.Status
+	}
+	return page, size, query
+}
+
+type HostAgentOut struct {
+	// ID
+	ID uint32 `json:"id" example:"1"`
+
+	// 主机
+	Host *HostBaseOut `json:"host"`
+
+	// 主机名
+	Hostname string `json:"hostname" example:"node01"`
+
+	// 机器序列号
+	Serial string `json:"serial" example:"VMware-42 1a 2b 3c"`
+
+	// 操作系统
+	OS string `json:"os" example:"CentOS Linux 7"`
+
+	// 内核版本
+	Kernel string `json:"kernel" example:"3.10.0-1160.el7.x86_64"`
+
+	// CPU架构
+	Arch string `json:"arch" example:"x86_64"`
+
+	// CPU核数
+	CPUCores int `json:"cpu_cores" example:"16"`
+
+	// 内存(MB)
+	MemoryMB uint64 `json:"memory_mb" example:"65536"`
+
+	// 磁盘
+	Disks []HostAgentDisk `json:"disks"`
+
+	// IP地址
+	IPs []string `json:"ips"`
+
+	// 代理版本
+	AgentVersion string `json:"agent_version" example:"1.0.0"`
+
+	// 在线状态
+	Status string `json:"status" example:"online"`
+
+	// 最近心跳时间
+	LastHeartbeatAt string `json:"last_heartbeat_at" example:"2023-01-01 12:00:00"`
+
+	// 注册时间
+	CreatedAt string `json:"created_at" example:"2023-01-01 12:00:00"`
+
+	// 更新时间
+	UpdatedAt string `json:"updated_at" example:"2023-01-01 12:00:00"`
+}
+
+// HostAgentReply 主机代理响应结构
+type HostAgentReply = common.APIReply[HostAgentOut]
+
+// PagHostAgentReply 主机代理的分页响应结构
+type PagHostAgentReply = common.APIReply[*common.Pag[HostAgentOut]]
+
+func HostAgentToOut(
+	m HostAgentModel,
+) *HostAgentOut {
+	var host *HostBaseOut
+	if m.Host.ID != 0 {
+		host = HostModelToBaseOut(m.Host)
+	}
+	disks := m.Disks
+	if disks == nil {
+		disks = []HostAgentDisk{}
+	}
+	ips := m.IPs
+	if ips == nil {
+		ips = []string{}
+	}
+	return &HostAgentOut{
+		ID:              m.ID,
+		Host:            host,
+		Hostname:        m.Hostname,
+		Serial:          m.Serial,
+		OS:              m.OS,
+		Kernel:          m.Kernel,
+		Arch:            m.Arch,
+		CPUCores:        m.CPUCores,
+		MemoryMB:        m.MemoryMB,
+		Disks:           disks,
+		IPs:             ips,
+		AgentVersion:    m.AgentVersion,
+		Status:          m.Status,
+		LastHeartbeatAt: m.LastHeartbeatAt.Format(time.DateTime),
+		CreatedAt:       m.CreatedAt.Format(time.DateTime),
+		UpdatedAt:       m.UpdatedAt.Format(time.DateTime),
+	}
+}
+
+func ListHostAgentToOut(
+	rms *[]HostAgentModel,
+) *[]HostAgentOut {
+	if rms == nil {
+		return &[]HostAgentOut{}
+	}
+
+	ms := *rms
+	mso := make([]HostAgentOut, 0, len(ms))
+	for _, m := range ms {
+		mso = append(mso, *HostAgentToOut(m))
+	}
+	return &mso
+}
@@ -0,0 +1,228 @@
+package resource
+
+import (
+	"mime/multipart"
+	"os"
+	"path"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+
+	"gin-artweb/internal/model/common"
+	"gin-artweb/internal/shared/database"
+)
+
+// 主机文件审计资源和动作
+const (
+	HostFileAuditResource = "host"
+	HostFileAuditDownload = "file_download"
+	HostFileAuditUpload   = "file_upload"
+)
+
+// HostPathRuleModel 角色允许访问的主机路径
+//
+// 路径规则对全部主机生效, 允许访问该目录及其子目录, Writable为true时允许上传文件
+type HostPathRuleModel struct {
+	database.StandardModel
+	RoleID   uint32 `gorm:"column:role_id;not null;uniqueIndex:idx_host_path_rule_role_path;comment:角色ID" json:"role_id"`
+	Path     string `gorm:"column:path;type:varchar(512);not null;uniqueIndex:idx_host_path_rule_role_path;comment:允许访问的目录" json:"path"`
+	Writable bool   `gorm:"column:writable;type:boolean;comment:是否允许上传" json:"writable"`
+	Remark   string `gorm:"column:remark;type:varchar(254);comment:备注" json:"remark"`
+}
+
+func (m *HostPathRuleModel) TableName() string {
+	return "resource_host_path_rule"
+}
+
+func (m *HostPathRuleModel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if m == nil {
+		return nil
+	}
+	if err := m.StandardModel.MarshalLogObject(enc); err != nil {
+		return err
+	}
+	enc.AddUint32("role_id", m.RoleID)
+	enc.AddString("path", m.Path)
+	enc.AddBool("writable", m.Writable)
+	enc.AddString("remark", m.Remark)
+	return nil
+}
+
+// Allows 判断规则是否允许访问指定的绝对路径(已清理)
+func (m *HostPathRuleModel) Allows(p string) bool {
+	root := path.Clean(m.Path)
+	if root == "/" || p == root {
+		return true
+	}
+	return len(p) > len(root) && p[:len(root)] == root && p[len(root)] == '/'
+}
+
+// HostPathRuleRequest 用于创建和更新主机路径规则的请求结构体
+//
+// swagger:model HostPathRuleRequest
+type HostPathRuleRequest struct {
+	// 角色ID
+	RoleID uint32 `json:"role_id" binding:"required"`
+
+	// 允许访问的目录, 必须为绝对路径
+	Path string `json:"path" binding:"required,startswith=/,max=512"`
+
+	// 是否允许上传
+	Writable bool `json:"writable"`
+
+	// 备注
+	Remark string `json:"remark" binding:"omitempty,max=254"`
+}
+
+// ListHostPathRuleRequest 用于查询主机路径规则列表的请求结构体
+//
+// swagger:model ListHostPathRuleRequest
+type ListHostPathRuleRequest struct {
+	common.BaseModelQuery
+
+	// 角色ID
+	RoleID uint32 `form:"role_id" binding:"omitempty"`
+}
+
+func (req *ListHostPathRuleRequest) Query() (int, int, map[string]any) {
+	page, size, query := req.BaseModelQuery.QueryMap(10)
+	if req.RoleID != 0 {
+		query["role_id = ?"] = req.RoleID
+	}
+	return page, size, query
+}
+
+// HostFileRequest 用于访问主机文件的请求参数
+//
+// swagger:model HostFileRequest
+type HostFileRequest struct {
+	// 文件或目录的绝对路径
+	Path string `form:"path" binding:"required,startswith=/,max=1024"`
+}
+
+// UploadHostFileRequest 用于上传主机文件的请求参数
+//
+// swagger:model UploadHostFileRequest
+type UploadHostFileRequest struct {
+	// 目标目录的绝对路径
+	Path string `form:"path" binding:"required,startswith=/,max=1024"`
+
+	// 目标文件已存在时是否覆盖
+	Overwrite bool `form:"overwrite"`
+
+	// 上传的文件
+	File *multipart.FileHeader `form:"file" binding:"required"`
+}
+
+type HostPathRuleOut struct {
+	// ID
+	ID uint32 `json:"id" example:"1"`
+
+	// 角色ID
+	RoleID uint32 `json:"role_id" example:"2"`
+
+	// 允许访问的目录
+	Path string `json:"path" example:"/home/quant/data"`
+
+	// 是否允许上传
+	Writable bool `json:"writable" example:"false"`
+
+	// 备注
+	Remark string `json:"remark" example:"计数器数据文件"`
+
+	// 创建时间
+	CreatedAt string `json:"created_at" example:"2023-01-01 12:00:00"`
+
+	// 更新时间
+	UpdatedAt string `json:"updated_at" example:"2023-01-01 12:00:00"`
+}
+
+type HostFileOut struct {
+	// 文件名
+	Name string `json:"name" example:"counter.csv"`
+
+	// 绝对路径
+	Path string `json:"path" example:"/home/quant/data/counter.csv"`
+
+	// 是否为目录
+	IsDir bool `json:"is_dir" example:"false"`
+
+	// 是否为符号链接
+	IsLink bool `json:"is_link" example:"false"`
+
+	// 文件大小(字节)
+	Size int64 `json:"size" example:"1024"`
+
+	// 权限
+	Mode string `json:"mode" example:"-rw-r--r--"`
+
+	// 修改时间
+	ModTime string `json:"mod_time" example:"2023-01-01 12:00:00"`
+}
+
+// HostPathRuleReply 主机路径规则响应结构
+type HostPathRuleReply = common.APIReply[HostPathRuleOut]
+
+// PagHostPathRuleReply 主机路径规则的分页响应结构
+type PagHostPathRuleReply = common.APIReply[*common.Pag[HostPathRuleOut]]
+
+// HostFileReply 主机文件信息响应结构
+type HostFileReply = common.APIReply[HostFileOut]
+
+// ListHostFileReply 主机目录内容响应结构
+type ListHostFileReply = common.APIReply[*[]HostFileOut]
+
+func HostPathRuleToOut(
+	m HostPathRuleModel,
+) *HostPathRuleOut {
+	return &HostPathRuleOut{
+		ID:        m.ID,
+		RoleID:    m.RoleID,
+		Path:      m.Path,
+		Writable:  m.Writable,
+		Remark:    m.Remark,
+		CreatedAt: m.CreatedAt.Format(time.DateTime),
+		UpdatedAt: m.UpdatedAt.Format(time.DateTime),
+	}
+}
+
+func ListHostPathRuleToOut(
+	rms *[]HostPathRuleModel,
+) *[]HostPathRuleOut {
+	if rms == nil {
+		return &[]HostPathRuleOut{}
+	}
+
+	ms := *rms
+	mso := make([]HostPathRuleOut, 0, len(ms))
+	for _, m := range ms {
+		mso = append(mso, *HostPathRuleToOut(m))
+	}
+	return &mso
+}
+
+func HostFileToOut(
+	p string,
+	fi os.FileInfo,
+) *HostFileOut {
+	return &HostFileOut{
+		Name:    fi.Name(),
+		Path:    p,
+		IsDir:   fi.IsDir(),
+		IsLink:  fi.Mode()&os.ModeSymlink != 0,
+		Size:    fi.Size(),
+		Mode:    fi.Mode().String(),
+		ModTime: fi.ModTime().Format(time.DateTime),
+	}
+}
+
+func ListHostFileToOut(
+	dir string,
+	fis []os.FileInfo,
+) *[]HostFileOut {
+	mso := make([]HostFileOut, 0, len(fis))
+	for _, fi := range fis {
+		mso = append(mso, *HostFileToOut(path.Join(dir, fi.Name()), fi))
+	}
+	return &mso
+}
@@ -136,7 +136,7 @@ func (req *ListHostRequest) Query() (int, int, map[string]any) {
 		query["label = ?"] = req.Label
 	}
 	if req.SSHIP != "" {
-		query["ip_addr = ?"] = req.SSHIP
+		query["ssh_ip = ?"] = req.SSHIP
 	}
 	if req.SSHPort != nil {
 		query["ssh_port = ?"] = *req.SSHPort
@@ -153,6 +153,19 @@ func (req *ListHostRequest) Query() (int, int, map[string]any) {
 	return page, size, query
 }
 
+// HostFilterFields 主机列表允许使用filter参数过滤的字段
+var HostFilterFields = database.FilterFields{
+	"id":         {Column: "id", Type: database.FilterInt},
+	"name":       {Column: "name", Type: database.FilterString},
+	"label":      {Column: "label", Type: database.FilterString},
+	"ssh_ip":     {Column: "ssh_ip", Type: database.FilterString},
+	"ssh_port":   {Column: "ssh_port", Type: database.FilterInt},
+	"ssh_user":   {Column: "ssh_user", Type: database.FilterString},
+	"remark":     {Column: "remark", Type: database.FilterString},
+	"created_at": {Column: "created_at", Type: database.FilterTime},
+	"updated_at": {Column: "updated_at", Type: database.FilterTime},
+}
+
 type HostBaseOut struct {
 	// 主机ID
 	ID uint32 `json:"id" example:"1"`
@@ -169,8 +182,8 @@ type HostBaseOut struct {
 	// 端口
 	SSHPort uint16 `json:"ssh_port" example:"22"`
 
-	// 用户名
-	SSHUser string `json:"ssh_user" example:"root"`
+	// 用户名, 没有查看敏感字段权限时掩码
+	SSHUser string `json:"ssh_user" mask:"secret" example:"root"`
 
 	// Python路径
 	PyPath string `json:"py_path" example:"/usr/bin/python3"`
@@ -196,8 +209,17 @@ type HostReply = common.APIReply[HostStandardOut]
 // PagHostReply 主机的分页响应结构
 type PagHostReply = common.APIReply[*common.Pag[HostStandardOut]]
 
+// HostModelToBaseOut 嵌套在其他资源中的主机信息, 敏感字段始终掩码, 完整信息通过主机接口查询
 func HostModelToBaseOut(
 	m HostModel,
+) *HostBaseOut {
+	mo := hostModelToBaseOut(m)
+	common.MaskSensitive(mo, false)
+	return mo
+}
+
+func hostModelToBaseOut(
+	m HostModel,
 ) *HostBaseOut {
 	return &HostBaseOut{
 		ID:      m.ID,
@@ -211,18 +233,23 @@ func HostModelToBaseOut(
 	}
 }
 
+// HostModelToStandardOut reveal为false时掩码登录用户名
 func HostModelToStandardOut(
 	m HostModel,
+	reveal bool,
 ) *HostStandardOut {
-	return &HostStandardOut{
-		HostBaseOut: *HostModelToBaseOut(m),
+	mo := &HostStandardOut{
+		HostBaseOut: *hostModelToBaseOut(m),
 		CreatedAt:   m.CreatedAt.Format(time.DateTime),
 		UpdatedAt:   m.UpdatedAt.Format(time.DateTime),
 	}
+	common.MaskSensitive(mo, reveal)
+	return mo
 }
 
 func ListHostModelToStandardOut(
 	hms *[]HostModel,
+	reveal bool,
 ) *[]HostStandardOut {
 	if hms == nil {
 		return &[]HostStandardOut{}
@@ -231,7 +258,7 @@ func ListHostModelToStandardOut(
 	ms := *hms
 	mso := make([]HostStandardOut, 0, len(ms))
 	for _, m := range ms {
-		mo := HostModelToStandardOut(m)
+		mo := HostModelToStandardOut(m, reveal)
 		mso = append(mso, *mo)
 	}
 	return &mso
@@ -0,0 +1,121 @@
+package resource
+
+import (
+	"go.uber.org/zap/zapcore"
+
+	"gin-artweb/internal/model/common"
+)
+
+// 疑似重复主机的匹配依据
+const (
+	HostDuplicateSSHIP    = "ssh_ip"   // SSH地址相同, 端口或用户不同
+	HostDuplicateAgentIP  = "agent_ip" // 代理上报的IP地址与其他主机的SSH地址或上报的IP地址相同
+	HostDuplicateHostname = "hostname" // 代理上报的主机名与其他主机的名称或上报的主机名相同
+	HostDuplicateSerial   = "serial"   // 代理上报的机器序列号相同
+)
+
+// HostMergeAuditAction 合并重复主机的审计动作, 审计资源与主机文件审计相同
+const HostMergeAuditAction = "merge"
+
+// HostReference 引用主机ID的数据表字段, 合并主机时改为引用保留的主机
+type HostReference struct {
+	Table  string
+	Column string
+}
+
+// HostDuplicateGroup 按同一匹配依据和匹配值找到的疑似重复主机, 按主机ID排序
+type HostDuplicateGroup struct {
+	Reason string
+	Value  string
+	Hosts  []HostModel
+}
+
+// HostMergeResult 合并主机的结果, 记录每个数据表改为引用保留主机的记录数
+type HostMergeResult struct {
+	HostID      uint32
+	DuplicateID uint32
+	References  map[string]int64
+}
+
+func (r *HostMergeResult) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if r == nil {
+		return nil
+	}
+	enc.AddUint32("host_id", r.HostID)
+	enc.AddUint32("duplicate_id", r.DuplicateID)
+	for table, n := range r.References {
+		enc.AddInt64(table, n)
+	}
+	return nil
+}
+
+// MergeHostRequest 合并重复主机的请求结构体
+// 重复主机的引用全部改为路径中的保留主机, 然后删除重复主机
+//
+// swagger:model MergeHostRequest
+type MergeHostRequest struct {
+	// 重复的主机ID
+	DuplicateID uint32 `json:"duplicate_id" binding:"required,gt=0"`
+}
+
+type HostDuplicateGroupOut struct {
+	// 匹配依据
+	Reason string `json:"reason" example:"ssh_ip"`
+
+	// 匹配值
+	Value string `json:"value" example:"192.168.1.1"`
+
+	// 疑似重复的主机
+	Hosts []HostStandardOut `json:"hosts"`
+}
+
+type HostMergeOut struct {
+	// 保留的主机ID
+	HostID uint32 `json:"host_id" example:"1"`
+
+	// 已删除的重复主机ID
+	DuplicateID uint32 `json:"duplicate_id" example:"2"`
+
+	// 各数据表改为引用保留主机的记录数
+	References map[string]int64 `json:"references"`
+}
+
+// HostDuplicateReply 疑似重复主机的响应结构
+type HostDuplicateReply = common.APIReply[*[]HostDuplicateGroupOut]
+
+// HostMergeReply 合并主机的响应结构
+type HostMergeReply = common.APIReply[HostMergeOut]
+
+// ListHostDuplicateGroupToOut reveal为false时掩码登录用户名
+func ListHostDuplicateGroupToOut(
+	gs *[]HostDuplicateGroup,
+	reveal bool,
+) *[]HostDuplicateGroupOut {
+	if gs == nil {
+		return &[]HostDuplicateGroupOut{}
+	}
+
+	mso := make([]HostDuplicateGroupOut, 0, len(*gs))
+	for _, g := range *gs {
+		mso = append(mso, HostDuplicateGroupOut{
+			Reason: g.Reason,
+			Value:  g.Value,
+			Hosts:  *ListHostModelToStandardOut(&g.Hosts, reveal),
+		})
+	}
+	return &mso
+}
+
+func HostMergeResultToOut(
+	r HostMergeResult,
+) *HostMergeOut {
+	refs := r.References
+	if refs == nil {
+		refs = map[string]int64{}
+	}
+	return &HostMergeOut{
+		HostID:      r.HostID,
+		DuplicateID: r.DuplicateID,
+		References:  refs,
+	}
+}
@@ -17,6 +17,8 @@ type PackageModel struct {
 	StorageFilename string    `gorm:"column:storage_filename;type:varchar(50);not null;uniqueIndex;comment:磁盘存储文件名" json:"storage_filename"`
 	OriginFilename  string    `gorm:"column:origin_filename;type:varchar(255);comment:原始文件名" json:"origin_filename"`
 	Version         string    `gorm:"column:version;type:varchar(50);uniqueIndex:idx_package_label_version;comment:版本号" json:"version"`
+	Size            int64     `gorm:"column:size;not null;default:0;comment:文件大小(字节)" json:"size"`
+	Checksum        string    `gorm:"column:checksum;type:varchar(64);comment:文件SHA256校验和" json:"checksum"`
 	UploadedAt      time.Time `gorm:"column:uploaded_at;autoCreateTime;comment:上传时间" json:"uploaded_at"`
 }
 
@@ -35,6 +37,8 @@ func (m *PackageModel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 	enc.AddString("storage_filename", m.StorageFilename)
 	enc.AddString("origin_filename", m.OriginFilename)
 	enc.AddString("version", m.Version)
+	enc.AddInt64("size", m.Size)
+	enc.AddString("checksum", m.Checksum)
 	enc.AddTime("uploaded_at", m.UploadedAt)
 	return nil
 }
@@ -48,11 +52,15 @@ type UploadPackageRequest struct {
 
 	// 上传的程序包文件
 	File *multipart.FileHeader `form:"file" binding:"required"`
+
+	// 文件SHA256校验和(十六进制), 不为空时校验上传的文件
+	Checksum string `form:"checksum" binding:"omitempty,len=64,hexadecimal"`
 }
 
 func (req *UploadPackageRequest) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 	enc.AddString("label", req.Label)
 	enc.AddString("version", req.Version)
+	enc.AddString("checksum", req.Checksum)
 	return nil
 }
 
@@ -133,10 +141,34 @@ type PackageStandardOut struct {
 	// IP地址
 	Version string `json:"version" example:"0.17.0.0.1"`
 
+	// 文件大小(字节)
+	Size int64 `json:"size" example:"1048576"`
+
+	// 文件SHA256校验和
+	Checksum string `json:"checksum" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+
 	// 上传时间
 	UploadedAt string `json:"uploaded_at" example:"2023-01-01 12:00:00"`
 }
 
+// PackageDownloadURLOut 程序包下载地址
+type PackageDownloadURLOut struct {
+	// 下载地址
+	URL string `json:"url" example:"/api/v1/resource/package/1/download"`
+
+	// 是否为对象存储的预签名地址, 预签名地址无需携带令牌
+	Presigned bool `json:"presigned" example:"false"`
+
+	// 预签名地址的过期时间
+	ExpiresAt string `json:"expires_at,omitempty" example:"2023-01-01 12:10:00"`
+
+	// 文件SHA256校验和
+	Checksum string `json:"checksum" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+}
+
+// PackageDownloadURLReply 程序包下载地址响应结构
+type PackageDownloadURLReply = common.APIReply[PackageDownloadURLOut]
+
 // PackageReply 程序包响应结构
 type PackageReply = common.APIReply[PackageStandardOut]
 
@@ -151,6 +183,8 @@ func PackageModelToOutBase(
 		Filename:   m.OriginFilename,
 		Label:      m.Label,
 		Version:    m.Version,
+		Size:       m.Size,
+		Checksum:   m.Checksum,
 		UploadedAt: m.UploadedAt.Format(time.DateTime),
 	}
 }
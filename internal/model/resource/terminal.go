@@ -0,0 +1,199 @@
+package resource
+
+import (
+	"time"
+
+	"go.uber.org/zap/zapcore"
+
+	"gin-artweb/internal/model/common"
+	"gin-artweb/internal/shared/database"
+)
+
+// 终端会话审计资源和动作
+const (
+	TerminalAuditResource = "host"
+	TerminalAuditOpen     = "terminal_open"
+	TerminalAuditClose    = "terminal_close"
+)
+
+// 终端会话结束原因
+const (
+	TerminalCloseClient   = "client"       // 客户端断开连接
+	TerminalCloseExit     = "exit"         // 远程shell退出
+	TerminalCloseIdle     = "idle_timeout" // 超过空闲时间无输入
+	TerminalCloseDuration = "max_duration" // 超过最长会话时间
+	TerminalCloseShutdown = "shutdown"     // 服务关闭
+)
+
+// 客户端发送的终端消息类型
+const (
+	TerminalMessageInput  = "input"
+	TerminalMessageResize = "resize"
+)
+
+// TerminalSessionModel 网页终端会话记录
+type TerminalSessionModel struct {
+	database.StandardModel
+	SessionID   string     `gorm:"column:session_id;type:varchar(36);not null;uniqueIndex;comment:会话标识" json:"session_id"`
+	HostID      uint32     `gorm:"column:host_id;not null;index;comment:主机ID" json:"host_id"`
+	HostName    string     `gorm:"column:host_name;type:varchar(50);comment:主机名称" json:"host_name"`
+	SSHIP       string     `gorm:"column:ssh_ip;type:varchar(108);comment:IP地址" json:"ssh_ip"`
+	SSHUser     string     `gorm:"column:ssh_user;type:varchar(50);comment:用户名" json:"ssh_user"`
+	Username    string     `gorm:"column:username;type:varchar(50);index;comment:操作用户" json:"username"`
+	ClientIP    string     `gorm:"column:client_ip;type:varchar(108);comment:客户端IP" json:"client_ip"`
+	StartedAt   time.Time  `gorm:"column:started_at;index;comment:开始时间" json:"started_at"`
+	EndedAt     *time.Time `gorm:"column:ended_at;comment:结束时间" json:"ended_at"`
+	CloseReason string     `gorm:"column:close_reason;type:varchar(20);comment:结束原因" json:"close_reason"`
+	RecordFile  string     `gorm:"column:record_file;type:varchar(255);comment:录像文件名" json:"record_file"`
+	BytesOut    int64      `gorm:"column:bytes_out;not null;default:0;comment:输出字节数" json:"bytes_out"`
+}
+
+func (m *TerminalSessionModel) TableName() string {
+	return "resource_terminal_session"
+}
+
+func (m *TerminalSessionModel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if m == nil {
+		return nil
+	}
+	if err := m.StandardModel.MarshalLogObject(enc); err != nil {
+		return err
+	}
+	enc.AddString("session_id", m.SessionID)
+	enc.AddUint32("host_id", m.HostID)
+	enc.AddString("ssh_ip", m.SSHIP)
+	enc.AddString("ssh_user", m.SSHUser)
+	enc.AddString("username", m.Username)
+	enc.AddString("client_ip", m.ClientIP)
+	enc.AddString("close_reason", m.CloseReason)
+	enc.AddInt64("bytes_out", m.BytesOut)
+	return nil
+}
+
+// TerminalMessage 客户端通过WebSocket发送的终端消息
+//
+// input消息的Data为键盘输入, resize消息的Cols和Rows为新的终端大小
+type TerminalMessage struct {
+	Type string `json:"type"`
+	Data string `json:"data"`
+	Cols int    `json:"cols"`
+	Rows int    `json:"rows"`
+}
+
+// OpenTerminalRequest 用于打开网页终端的请求参数
+//
+// swagger:model OpenTerminalRequest
+type OpenTerminalRequest struct {
+	// 终端列数
+	Cols int `form:"cols" binding:"omitempty,min=10,max=1000"`
+
+	// 终端行数
+	Rows int `form:"rows" binding:"omitempty,min=5,max=500"`
+}
+
+// ListTerminalSessionRequest 用于查询终端会话记录的请求结构体
+//
+// swagger:model ListTerminalSessionRequest
+type ListTerminalSessionRequest struct {
+	common.BaseModelQuery
+
+	// 主机ID
+	HostID uint32 `form:"host_id" binding:"omitempty"`
+
+	// 操作用户
+	Username string `form:"username" binding:"omitempty,max=50"`
+}
+
+func (req *ListTerminalSessionRequest) Query() (int, int, map[string]any) {
+	page, size, query := req.BaseModelQuery.QueryMap(10)
+	if req.HostID != 0 {
+		query["host_id = ?"] = req.HostID
+	}
+	if req.Username != "" {
+		query["username = ?"] = req.Username
+	}
+	return page, size, query
+}
+
+type TerminalSessionOut struct {
+	// ID
+	ID uint32 `json:"id" example:"1"`
+
+	// 会话标识
+	SessionID string `json:"session_id" example:"0b6c3f2e-5d4a-4f7b-9a1e-2c8d7e6f5a4b"`
+
+	// 主机ID
+	HostID uint32 `json:"host_id" example:"1"`
+
+	// 主机名称
+	HostName string `json:"host_name" example:"host-01"`
+
+	// IP地址
+	SSHIP string `json:"ssh_ip" example:"192.168.1.100"`
+
+	// 主机用户名
+	SSHUser string `json:"ssh_user" example:"root"`
+
+	// 操作用户
+	Username string `json:"username" example:"admin"`
+
+	// 客户端IP
+	ClientIP string `json:"client_ip" example:"192.168.1.10"`
+
+	// 开始时间
+	StartedAt string `json:"started_at" example:"2023-01-01 12:00:00"`
+
+	// 结束时间, 为空表示会话进行中
+	EndedAt string `json:"ended_at" example:"2023-01-01 12:30:00"`
+
+	// 结束原因(client/exit/idle_timeout/max_duration/shutdown)
+	CloseReason string `json:"close_reason" example:"client"`
+
+	// 是否有录像
+	Recorded bool `json:"recorded" example:"true"`
+
+	// 输出字节数
+	BytesOut int64 `json:"bytes_out" example:"10240"`
+}
+
+// PagTerminalSessionReply 终端会话记录的分页响应结构
+type PagTerminalSessionReply = common.APIReply[*common.Pag[TerminalSessionOut]]
+
+func TerminalSessionToOut(
+	m TerminalSessionModel,
+) *TerminalSessionOut {
+	var endedAt string
+	if m.EndedAt != nil {
+		endedAt = m.EndedAt.Format(time.DateTime)
+	}
+	return &TerminalSessionOut{
+		ID:          m.ID,
+		SessionID:   m.SessionID,
+		HostID:      m.HostID,
+		HostName:    m.HostName,
+		SSHIP:       m.SSHIP,
+		SSHUser:     m.SSHUser,
+		Username:    m.Username,
+		ClientIP:    m.ClientIP,
+		StartedAt:   m.StartedAt.Format(time.DateTime),
+		EndedAt:     endedAt,
+		CloseReason: m.CloseReason,
+		Recorded:    m.RecordFile != "",
+		BytesOut:    m.BytesOut,
+	}
+}
+
+func ListTerminalSessionToOut(
+	rms *[]TerminalSessionModel,
+) *[]TerminalSessionOut {
+	if rms == nil {
+		return &[]TerminalSessionOut{}
+	}
+
+	ms := *rms
+	mso := make([]TerminalSessionOut, 0, len(ms))
+	for _, m := range ms {
+		mso = append(mso, *TerminalSessionToOut(m))
+	}
+	return &mso
+}
@@ -0,0 +1,137 @@
+package resource
+
+import (
+	"time"
+
+	"go.uber.org/zap/zapcore"
+
+	"gin-artweb/internal/model/common"
+	"gin-artweb/internal/shared/database"
+)
+
+// PackageUploadModel 程序包分片上传会话
+//
+// 分片按顺序追加到本地临时文件, 全部上传后校验并写入程序包存储
+type PackageUploadModel struct {
+	database.StandardModel
+	UploadID       string    `gorm:"column:upload_id;type:varchar(36);not null;uniqueIndex;comment:上传会话标识" json:"upload_id"`
+	Label          string    `gorm:"column:label;type:varchar(50);not null;comment:标签" json:"label"`
+	PkgVersion     string    `gorm:"column:pkg_version;type:varchar(50);not null;comment:程序包版本号" json:"pkg_version"`
+	OriginFilename string    `gorm:"column:origin_filename;type:varchar(255);not null;comment:原始文件名" json:"origin_filename"`
+	TotalSize      int64     `gorm:"column:total_size;not null;comment:文件大小(字节)" json:"total_size"`
+	Received       int64     `gorm:"column:received;not null;default:0;comment:已接收字节数" json:"received"`
+	Checksum       string    `gorm:"column:checksum;type:varchar(64);comment:文件SHA256校验和" json:"checksum"`
+	Username       string    `gorm:"column:username;type:varchar(50);comment:上传用户" json:"username"`
+	ExpiresAt      time.Time `gorm:"column:expires_at;index;comment:过期时间" json:"expires_at"`
+}
+
+func (m *PackageUploadModel) TableName() string {
+	return "resource_package_upload"
+}
+
+func (m *PackageUploadModel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if m == nil {
+		return nil
+	}
+	if err := m.StandardModel.MarshalLogObject(enc); err != nil {
+		return err
+	}
+	enc.AddString("upload_id", m.UploadID)
+	enc.AddString("label", m.Label)
+	enc.AddString("pkg_version", m.PkgVersion)
+	enc.AddString("origin_filename", m.OriginFilename)
+	enc.AddInt64("total_size", m.TotalSize)
+	enc.AddInt64("received", m.Received)
+	enc.AddString("checksum", m.Checksum)
+	enc.AddString("username", m.Username)
+	enc.AddTime("expires_at", m.ExpiresAt)
+	return nil
+}
+
+// InitPackageUploadRequest 用于创建分片上传会话的请求结构体
+//
+// swagger:model InitPackageUploadRequest
+type InitPackageUploadRequest struct {
+	// 程序包标签
+	Label string `json:"label" binding:"required,max=50"`
+
+	// 程序包版本
+	Version string `json:"version" binding:"required,max=50"`
+
+	// 原始文件名
+	Filename string `json:"filename" binding:"required,max=255"`
+
+	// 文件大小(字节)
+	Size int64 `json:"size" binding:"required,gt=0"`
+
+	// 文件SHA256校验和(十六进制), 不为空时完成上传时校验整个文件
+	Checksum string `json:"checksum" binding:"omitempty,len=64,hexadecimal"`
+}
+
+func (req *InitPackageUploadRequest) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("label", req.Label)
+	enc.AddString("version", req.Version)
+	enc.AddString("filename", req.Filename)
+	enc.AddInt64("size", req.Size)
+	enc.AddString("checksum", req.Checksum)
+	return nil
+}
+
+// UploadIDUri 分片上传会话路径参数
+type UploadIDUri struct {
+	UploadID string `uri:"upload_id" binding:"required,uuid"`
+}
+
+// UploadChunkRequest 用于上传分片的请求参数, 分片内容为请求体
+//
+// swagger:model UploadChunkRequest
+type UploadChunkRequest struct {
+	// 分片在文件中的起始位置, 必须等于已接收的字节数
+	Offset int64 `form:"offset" binding:"min=0"`
+
+	// 分片SHA256校验和(十六进制), 不为空时校验分片内容
+	Checksum string `form:"checksum" binding:"omitempty,len=64,hexadecimal"`
+}
+
+// PackageUploadOut 分片上传会话信息
+type PackageUploadOut struct {
+	// 上传会话标识
+	UploadID string `json:"upload_id" example:"0b6c3f2e-5d4a-4f7b-9a1e-2c8d7e6f5a4b"`
+
+	// 程序包标签
+	Label string `json:"label" example:"oes"`
+
+	// 程序包版本
+	Version string `json:"version" example:"0.17.0.0.1"`
+
+	// 原始文件名
+	Filename string `json:"filename" example:"oes-0.17.0.0.1.tar.gz"`
+
+	// 文件大小(字节)
+	Size int64 `json:"size" example:"4294967296"`
+
+	// 已接收字节数, 续传时作为下一个分片的起始位置
+	Received int64 `json:"received" example:"1073741824"`
+
+	// 分片大小上限(字节)
+	ChunkSize int64 `json:"chunk_size" example:"16777216"`
+
+	// 过期时间, 过期后会话和已上传的分片会被清理
+	ExpiresAt string `json:"expires_at" example:"2023-01-02 12:00:00"`
+}
+
+// PackageUploadReply 分片上传会话响应结构
+type PackageUploadReply = common.APIReply[PackageUploadOut]
+
+func PackageUploadModelToOut(m PackageUploadModel, chunkSize int64) *PackageUploadOut {
+	return &PackageUploadOut{
+		UploadID:  m.UploadID,
+		Label:     m.Label,
+		Version:   m.PkgVersion,
+		Filename:  m.OriginFilename,
+		Size:      m.TotalSize,
+		Received:  m.Received,
+		ChunkSize: chunkSize,
+		ExpiresAt: m.ExpiresAt.Format(time.DateTime),
+	}
+}
@@ -0,0 +1,450 @@
+package resource
+
+import (
+	"cmp"
+	"fmt"
+	"regexp"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+
+	"gin-artweb/internal/model/common"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/events"
+)
+
+// 进程守护的检查对象类型
+const (
+	WatchdogKindSystemd = "systemd" // systemd服务单元
+	WatchdogKindProcess = "process" // 进程名
+)
+
+// 进程守护的期望状态
+const (
+	WatchdogExpectRunning = "running"
+	WatchdogExpectStopped = "stopped"
+)
+
+// 进程守护的重启策略
+const (
+	WatchdogRestartNever     = "never"      // 只告警不重启
+	WatchdogRestartOnFailure = "on_failure" // 期望运行但未运行时重启
+)
+
+// 进程守护的检查状态
+const (
+	WatchdogUnknown = "unknown" // 尚未检查或主机不可达
+	WatchdogOK      = "ok"      // 符合期望状态
+	WatchdogFailed  = "failed"  // 不符合期望状态
+)
+
+// 进程守护的历史动作
+const (
+	WatchdogActionStateChange    = "state_change"    // 检查状态变化
+	WatchdogActionRestart        = "restart"         // 重启成功
+	WatchdogActionRestartFailed  = "restart_failed"  // 重启后仍未运行
+	WatchdogActionRestartLimited = "restart_limited" // 重启次数达到上限, 不再重启
+)
+
+// AlertKindWatchdog 进程守护告警
+const AlertKindWatchdog = "watchdog"
+
+// watchdogTargetPattern 服务单元和进程名允许的字符, 拼接到检查命令中不需要转义
+var watchdogTargetPattern = regexp.MustCompile(`^[A-Za-z0-9_.@:+-]+$`)
+
+// WatchdogModel 主机上进程或systemd服务的守护定义
+//
+// 后台任务定时检查是否处于期望状态, 期望运行但未运行时按重启策略重启,
+// 重启窗口内的重启次数达到上限后只告警不再重启
+type WatchdogModel struct {
+	database.StandardModel
+	Name           string     `gorm:"column:name;type:varchar(50);not null;uniqueIndex;comment:名称" json:"name"`
+	HostID         uint32     `gorm:"column:host_id;not null;index;comment:主机ID" json:"host_id"`
+	Host           HostModel  `gorm:"foreignKey:HostID;references:ID;constraint:OnDelete:CASCADE" json:"host"`
+	Kind           string     `gorm:"column:kind;type:varchar(10);not null;comment:检查对象类型" json:"kind"`
+	Target         string     `gorm:"column:target;type:varchar(100);not null;comment:服务单元或进程名" json:"target"`
+	ExpectedState  string     `gorm:"column:expected_state;type:varchar(10);not null;comment:期望状态" json:"expected_state"`
+	RestartPolicy  string     `gorm:"column:restart_policy;type:varchar(20);not null;comment:重启策略" json:"restart_policy"`
+	RestartCommand string     `gorm:"column:restart_command;type:varchar(1024);comment:重启命令" json:"restart_command"`
+	MaxRestarts    int        `gorm:"column:max_restarts;not null;default:3;comment:重启窗口内最多重启次数" json:"max_restarts"`
+	RestartWindow  int        `gorm:"column:restart_window;not null;default:60;comment:重启窗口(分钟)" json:"restart_window"`
+	IsEnabled      bool       `gorm:"column:is_enabled;type:boolean;index;comment:是否启用" json:"is_enabled"`
+	Status         string     `gorm:"column:status;type:varchar(10);not null;default:unknown;comment:检查状态" json:"status"`
+	LastAction     string     `gorm:"column:last_action;type:varchar(20);comment:最近一次动作" json:"last_action"`
+	LastMessage    string     `gorm:"column:last_message;type:varchar(1024);comment:最近一次检查信息" json:"last_message"`
+	LastCheckedAt  *time.Time `gorm:"column:last_checked_at;comment:最近一次检查时间" json:"last_checked_at"`
+	Remark         string     `gorm:"column:remark;type:varchar(254);comment:备注" json:"remark"`
+}
+
+func (m *WatchdogModel) TableName() string {
+	return "resource_watchdog"
+}
+
+func (m *WatchdogModel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if m == nil {
+		return nil
+	}
+	if err := m.StandardModel.MarshalLogObject(enc); err != nil {
+		return err
+	}
+	enc.AddString("name", m.Name)
+	enc.AddUint32("host_id", m.HostID)
+	enc.AddString("kind", m.Kind)
+	enc.AddString("target", m.Target)
+	enc.AddString("expected_state", m.ExpectedState)
+	enc.AddString("restart_policy", m.RestartPolicy)
+	enc.AddInt("max_restarts", m.MaxRestarts)
+	enc.AddInt("restart_window", m.RestartWindow)
+	enc.AddBool("is_enabled", m.IsEnabled)
+	enc.AddString("status", m.Status)
+	return nil
+}
+
+// Validate 检查守护定义, 返回不符合要求的原因
+func (m *WatchdogModel) Validate() error {
+	if !watchdogTargetPattern.MatchString(m.Target) {
+		return fmt.Errorf("服务单元或进程名只能包含字母、数字和_.@:+-")
+	}
+	if m.RestartPolicy == WatchdogRestartOnFailure {
+		if m.ExpectedState != WatchdogExpectRunning {
+			return fmt.Errorf("期望状态为running时才能自动重启")
+		}
+		if m.Kind == WatchdogKindProcess && m.RestartCommand == "" {
+			return fmt.Errorf("进程类型的自动重启需要提供重启命令")
+		}
+	}
+	return nil
+}
+
+// CheckCommand 检查对象是否正在运行的命令, 退出码为0表示正在运行
+func (m *WatchdogModel) CheckCommand() string {
+	if m.Kind == WatchdogKindSystemd {
+		return fmt.Sprintf("systemctl is-active --quiet '%s'", m.Target)
+	}
+	return fmt.Sprintf("pgrep -x '%s' >/dev/null", m.Target)
+}
+
+// StartCommand 重启命令, systemd服务未提供时使用systemctl restart
+func (m *WatchdogModel) StartCommand() string {
+	if m.RestartCommand == "" && m.Kind == WatchdogKindSystemd {
+		return fmt.Sprintf("systemctl restart '%s'", m.Target)
+	}
+	return m.RestartCommand
+}
+
+// WatchdogEventModel 进程守护的状态变化和重启记录
+type WatchdogEventModel struct {
+	database.BaseModel
+	WatchdogID uint32        `gorm:"column:watchdog_id;not null;index;comment:进程守护ID" json:"watchdog_id"`
+	Watchdog   WatchdogModel `gorm:"foreignKey:WatchdogID;references:ID;constraint:OnDelete:CASCADE" json:"-"`
+	Status     string        `gorm:"column:status;type:varchar(10);not null;comment:检查状态" json:"status"`
+	Action     string        `gorm:"column:action;type:varchar(20);not null;index;comment:动作" json:"action"`
+	Message    string        `gorm:"column:message;type:varchar(1024);comment:信息" json:"message"`
+	CreatedAt  time.Time     `gorm:"column:created_at;autoCreateTime;index;comment:创建时间" json:"created_at"`
+}
+
+func (m *WatchdogEventModel) TableName() string {
+	return "resource_watchdog_event"
+}
+
+func (m *WatchdogEventModel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if m == nil {
+		return nil
+	}
+	if err := m.BaseModel.MarshalLogObject(enc); err != nil {
+		return err
+	}
+	enc.AddUint32("watchdog_id", m.WatchdogID)
+	enc.AddString("status", m.Status)
+	enc.AddString("action", m.Action)
+	enc.AddString("message", m.Message)
+	return nil
+}
+
+// WatchdogAlertEvent 进程守护失败、重启或恢复事件
+type WatchdogAlertEvent struct {
+	Kind       string `json:"kind"`
+	WatchdogID uint32 `json:"watchdog_id"`
+	Name       string `json:"name"`
+	HostID     uint32 `json:"host_id"`
+	HostName   string `json:"host_name"`
+	Target     string `json:"target"`
+	From       string `json:"from"`
+	To         string `json:"to"`
+	Action     string `json:"action"`
+	Message    string `json:"message"`
+	CheckedAt  string `json:"checked_at"`
+}
+
+func (e WatchdogAlertEvent) EventType() string {
+	return events.AlertFired
+}
+
+// WatchdogRequest 用于创建和更新进程守护的请求结构体
+//
+// swagger:model WatchdogRequest
+type WatchdogRequest struct {
+	// 名称
+	Name string `json:"name" binding:"required,max=50"`
+
+	// 主机ID
+	HostID uint32 `json:"host_id" binding:"required"`
+
+	// 检查对象类型(systemd:服务单元, process:进程名)
+	Kind string `json:"kind" binding:"required,oneof=systemd process"`
+
+	// 服务单元或进程名
+	Target string `json:"target" binding:"required,max=100"`
+
+	// 期望状态
+	ExpectedState string `json:"expected_state" binding:"required,oneof=running stopped"`
+
+	// 重启策略(never:只告警, on_failure:期望运行但未运行时重启)
+	RestartPolicy string `json:"restart_policy" binding:"required,oneof=never on_failure"`
+
+	// 重启命令, systemd服务为空时使用systemctl restart, 进程自动重启时必填
+	RestartCommand string `json:"restart_command" binding:"omitempty,max=1024"`
+
+	// 重启窗口内最多重启次数, 默认3
+	MaxRestarts int `json:"max_restarts" binding:"omitempty,gte=1,lte=100"`
+
+	// 重启窗口(分钟), 默认60
+	RestartWindow int `json:"restart_window" binding:"omitempty,gte=1,lte=10080"`
+
+	// 是否启用
+	IsEnabled bool `json:"is_enabled"`
+
+	// 备注
+	Remark string `json:"remark" binding:"omitempty,max=254"`
+}
+
+func (req *WatchdogRequest) ToModel() WatchdogModel {
+	return WatchdogModel{
+		Name:           req.Name,
+		HostID:         req.HostID,
+		Kind:           req.Kind,
+		Target:         req.Target,
+		ExpectedState:  req.ExpectedState,
+		RestartPolicy:  req.RestartPolicy,
+		RestartCommand: req.RestartCommand,
+		MaxRestarts:    cmp.Or(req.MaxRestarts, 3),
+		RestartWindow:  cmp.Or(req.RestartWindow, 60),
+		IsEnabled:      req.IsEnabled,
+		Remark:         req.Remark,
+	}
+}
+
+// ListWatchdogRequest 用于查询进程守护列表的请求结构体
+//
+// swagger:model ListWatchdogRequest
+type ListWatchdogRequest struct {
+	common.StandardModelQuery
+
+	// 名称
+	Name string `form:"name" binding:"omitempty,max=50"`
+
+	// 主机ID
+	HostID uint32 `form:"host_id" binding:"omitempty"`
+
+	// 检查状态
+	Status string `form:"status" binding:"omitempty,oneof=unknown ok failed"`
+
+	// 是否启用
+	IsEnabled *bool `form:"is_enabled" binding:"omitempty"`
+}
+
+func (req *ListWatchdogRequest) Query() (int, int, map[string]any) {
+	page, size, query := req.StandardModelQuery.QueryMap(10)
+	if req.Name != "" {
+		query["name like ?"] = "%" + req.Name + "%"
+	}
+	if req.HostID != 0 {
+		query["host_id = ?"] = req.HostID
+	}
+	if req.Status != "" {
+		query["status = ?"] = req.Status
+	}
+	if req.IsEnabled != nil {
+		query["is_enabled = ?"] = *req.IsEnabled
+	}
+	return page, size, query
+}
+
+// ListWatchdogEventRequest 用于查询进程守护历史的请求结构体
+//
+// swagger:model ListWatchdogEventRequest
+type ListWatchdogEventRequest struct {
+	common.BaseModelQuery
+
+	// 动作
+	Action string `form:"action" binding:"omitempty,oneof=state_change restart restart_failed restart_limited"`
+}
+
+func (req *ListWatchdogEventRequest) Query() (int, int, map[string]any) {
+	page, size, query := req.BaseModelQuery.QueryMap(10)
+	if req.Action != "" {
+		query["action = ?"] = req.Action
+	}
+	return page, size, query
+}
+
+type WatchdogOut struct {
+	// ID
+	ID uint32 `json:"id" example:"1"`
+
+	// 名称
+	Name string `json:"name" example:"oes-counter"`
+
+	// 主机
+	Host *HostBaseOut `json:"host"`
+
+	// 检查对象类型
+	Kind string `json:"kind" example:"systemd"`
+
+	// 服务单元或进程名
+	Target string `json:"target" example:"oes-counter.service"`
+
+	// 期望状态
+	ExpectedState string `json:"expected_state" example:"running"`
+
+	// 重启策略
+	RestartPolicy string `json:"restart_policy" example:"on_failure"`
+
+	// 重启命令
+	RestartCommand string `json:"restart_command" example:""`
+
+	// 重启窗口内最多重启次数
+	MaxRestarts int `json:"max_restarts" example:"3"`
+
+	// 重启窗口(分钟)
+	RestartWindow int `json:"restart_window" example:"60"`
+
+	// 是否启用
+	IsEnabled bool `json:"is_enabled" example:"true"`
+
+	// 检查状态
+	Status string `json:"status" example:"ok"`
+
+	// 最近一次动作
+	LastAction string `json:"last_action" example:"restart"`
+
+	// 最近一次检查信息
+	LastMessage string `json:"last_message" example:""`
+
+	// 最近一次检查时间
+	LastCheckedAt string `json:"last_checked_at" example:"2023-01-01 12:00:00"`
+
+	// 备注
+	Remark string `json:"remark" example:""`
+
+	// 创建时间
+	CreatedAt string `json:"created_at" example:"2023-01-01 12:00:00"`
+
+	// 更新时间
+	UpdatedAt string `json:"updated_at" example:"2023-01-01 12:00:00"`
+}
+
+type WatchdogEventOut struct {
+	// ID
+	ID uint32 `json:"id" example:"1"`
+
+	// 进程守护ID
+	WatchdogID uint32 `json:"watchdog_id" example:"1"`
+
+	// 检查状态
+	Status string `json:"status" example:"ok"`
+
+	// 动作
+	Action string `json:"action" example:"restart"`
+
+	// 信息
+	Message string `json:"message" example:""`
+
+	// 创建时间
+	CreatedAt string `json:"created_at" example:"2023-01-01 12:00:00"`
+}
+
+// WatchdogReply 进程守护响应结构
+type WatchdogReply = common.APIReply[WatchdogOut]
+
+// PagWatchdogReply 进程守护的分页响应结构
+type PagWatchdogReply = common.APIReply[*common.Pag[WatchdogOut]]
+
+// PagWatchdogEventReply 进程守护历史的分页响应结构
+type PagWatchdogEventReply = common.APIReply[*common.Pag[WatchdogEventOut]]
+
+func WatchdogToOut(
+	m WatchdogModel,
+) *WatchdogOut {
+	var host *HostBaseOut
+	if m.Host.ID != 0 {
+		host = HostModelToBaseOut(m.Host)
+	}
+	var lastCheckedAt string
+	if m.LastCheckedAt != nil {
+		lastCheckedAt = m.LastCheckedAt.Format(time.DateTime)
+	}
+	return &WatchdogOut{
+		ID:             m.ID,
+		Name:           m.Name,
+		Host:           host,
+		Kind:           m.Kind,
+		Target:         m.Target,
+		ExpectedState:  m.ExpectedState,
+		RestartPolicy:  m.RestartPolicy,
+		RestartCommand: m.RestartCommand,
+		MaxRestarts:    m.MaxRestarts,
+		RestartWindow:  m.RestartWindow,
+		IsEnabled:      m.IsEnabled,
+		Status:         m.Status,
+		LastAction:     m.LastAction,
+		LastMessage:    m.LastMessage,
+		LastCheckedAt:  lastCheckedAt,
+		Remark:         m.Remark,
+		CreatedAt:      m.CreatedAt.Format(time.DateTime),
+		UpdatedAt:      m.UpdatedAt.Format(time.DateTime),
+	}
+}
+
+func ListWatchdogToOut(
+	rms *[]WatchdogModel,
+) *[]WatchdogOut {
+	if rms == nil {
+		return &[]WatchdogOut{}
+	}
+
+	ms := *rms
+	mso := make([]WatchdogOut, 0, len(ms))
+	for _, m := range ms {
+		mso = append(mso, *WatchdogToOut(m))
+	}
+	return &mso
+}
+
+func WatchdogEventToOut(
+	m WatchdogEventModel,
+) *WatchdogEventOut {
+	return &WatchdogEventOut{
+		ID:         m.ID,
+		WatchdogID: m.WatchdogID,
+		Status:     m.Status,
+		Action:     m.Action,
+		Message:    m.Message,
+		CreatedAt:  m.CreatedAt.Format(time.DateTime),
+	}
+}
+
+func ListWatchdogEventToOut(
+	rms *[]WatchdogEventModel,
+) *[]WatchdogEventOut {
+	if rms == nil {
+		return &[]WatchdogEventOut{}
+	}
+
+	ms := *rms
+	mso := make([]WatchdogEventOut, 0, len(ms))
+	for _, m := range ms {
+		mso = append(mso, *WatchdogEventToOut(m))
+	}
+	return &mso
+}
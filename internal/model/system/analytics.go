@@ -0,0 +1,159 @@
+package system
+
+import (
+	"time"
+
+	"go.uber.org/zap/zapcore"
+
+	"gin-artweb/internal/model/common"
+	"gin-artweb/internal/shared/database"
+)
+
+// 用户身份记录方式
+const (
+	IdentityModeNone  = "none"  // 不记录用户身份，仅记录角色
+	IdentityModeHash  = "hash"  // 记录哈希匿名后的用户标识
+	IdentityModePlain = "plain" // 记录明文用户名
+)
+
+type AnalyticsEventModel struct {
+	database.BaseModel
+	Feature    string    `gorm:"column:feature;type:varchar(254);not null;index;comment:功能标识" json:"feature"`
+	Method     string    `gorm:"column:method;type:varchar(10);not null;comment:请求方法" json:"method"`
+	Module     string    `gorm:"column:module;type:varchar(50);index;comment:所属模块" json:"module"`
+	RoleID     uint32    `gorm:"column:role_id;index;comment:角色ID" json:"role_id"`
+	Subject    string    `gorm:"column:subject;type:varchar(64);comment:用户标识(按配置匿名化)" json:"subject"`
+	StatusCode int       `gorm:"column:status_code;comment:响应状态码" json:"status_code"`
+	OccurredAt time.Time `gorm:"column:occurred_at;not null;index;comment:发生时间" json:"occurred_at"`
+}
+
+func (m *AnalyticsEventModel) TableName() string {
+	return "system_analytics_event"
+}
+
+func (m *AnalyticsEventModel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if m == nil {
+		return nil
+	}
+	if err := m.BaseModel.MarshalLogObject(enc); err != nil {
+		return err
+	}
+	enc.AddString("feature", m.Feature)
+	enc.AddString("method", m.Method)
+	enc.AddString("module", m.Module)
+	enc.AddUint32("role_id", m.RoleID)
+	enc.AddString("subject", m.Subject)
+	enc.AddInt("status_code", m.StatusCode)
+	enc.AddTime("occurred_at", m.OccurredAt)
+	return nil
+}
+
+// AnalyticsUsage 功能使用聚合结果
+type AnalyticsUsage struct {
+	Feature string `gorm:"column:feature"`
+	Method  string `gorm:"column:method"`
+	Module  string `gorm:"column:module"`
+	RoleID  uint32 `gorm:"column:role_id"`
+	Count   int64  `gorm:"column:count"`
+}
+
+// ListAnalyticsUsageRequest 用于查询功能使用聚合统计的请求结构体
+//
+// swagger:model ListAnalyticsUsageRequest
+type ListAnalyticsUsageRequest struct {
+	// 聚合维度(feature:按功能, module:按模块, role:按角色, feature_role:按功能和角色)
+	GroupBy string `form:"group_by" binding:"omitempty,oneof=feature module role feature_role"`
+
+	// 所属模块
+	Module string `form:"module" binding:"omitempty,max=50"`
+
+	// 角色ID
+	RoleID uint32 `form:"role_id" binding:"omitempty,gt=0"`
+
+	// 开始时间 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	StartAt string `form:"start_at"`
+
+	// 结束时间 (RFC3339格式)
+	// example: 2023-01-02T00:00:00Z
+	EndAt string `form:"end_at"`
+
+	// 返回条数上限
+	Limit int `form:"limit" binding:"omitempty,gt=0,lte=1000"`
+}
+
+// GroupColumns 根据聚合维度返回分组字段
+func (req *ListAnalyticsUsageRequest) GroupColumns() []string {
+	switch req.GroupBy {
+	case "module":
+		return []string{"module"}
+	case "role":
+		return []string{"role_id"}
+	case "feature_role":
+		return []string{"feature", "method", "module", "role_id"}
+	default:
+		return []string{"feature", "method", "module"}
+	}
+}
+
+func (req *ListAnalyticsUsageRequest) Query() map[string]any {
+	query := make(map[string]any, 4)
+	if req.Module != "" {
+		query["module = ?"] = req.Module
+	}
+	if req.RoleID > 0 {
+		query["role_id = ?"] = req.RoleID
+	}
+	if req.StartAt != "" {
+		if st, err := time.Parse(time.RFC3339, req.StartAt); err == nil {
+			query["occurred_at >= ?"] = st
+		}
+	}
+	if req.EndAt != "" {
+		if et, err := time.Parse(time.RFC3339, req.EndAt); err == nil {
+			query["occurred_at < ?"] = et
+		}
+	}
+	return query
+}
+
+type AnalyticsUsageOut struct {
+	// 功能标识
+	Feature string `json:"feature,omitempty" example:"/api/v1/mon/node"`
+
+	// 请求方法
+	Method string `json:"method,omitempty" example:"GET"`
+
+	// 所属模块
+	Module string `json:"module,omitempty" example:"mon"`
+
+	// 角色ID
+	RoleID uint32 `json:"role_id,omitempty" example:"1"`
+
+	// 使用次数
+	Count int64 `json:"count" example:"10"`
+}
+
+// AnalyticsUsageReply 功能使用统计响应结构
+type AnalyticsUsageReply = common.APIReply[[]AnalyticsUsageOut]
+
+func ListAnalyticsUsageToOut(
+	rus *[]AnalyticsUsage,
+) *[]AnalyticsUsageOut {
+	if rus == nil {
+		return &[]AnalyticsUsageOut{}
+	}
+
+	us := *rus
+	uso := make([]AnalyticsUsageOut, 0, len(us))
+	for _, u := range us {
+		uso = append(uso, AnalyticsUsageOut{
+			Feature: u.Feature,
+			Method:  u.Method,
+			Module:  u.Module,
+			RoleID:  u.RoleID,
+			Count:   u.Count,
+		})
+	}
+	return &uso
+}
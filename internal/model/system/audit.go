@@ -0,0 +1,225 @@
+package system
+
+import (
+	"encoding/json"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+
+	"gin-artweb/internal/model/common"
+	"gin-artweb/internal/shared/database"
+)
+
+// 管理员代为登录的审计记录, 操作用户为管理员, 资源ID为被代登录的用户ID,
+// 操作类型为start(签发代登录令牌)或使用代登录令牌请求的"方法 路由"
+const (
+	ImpersonationAuditModule   = "customer"
+	ImpersonationAuditResource = "impersonation"
+	ImpersonationAuditStart    = "start"
+)
+
+// AuditRecordModel 数据变更审计记录
+type AuditRecordModel struct {
+	database.BaseModel
+	Module     string    `gorm:"column:module;type:varchar(50);not null;index:idx_audit_resource;comment:所属模块" json:"module"`
+	Resource   string    `gorm:"column:resource;type:varchar(50);not null;index:idx_audit_resource;comment:资源类型" json:"resource"`
+	ResourceID uint32    `gorm:"column:resource_id;index:idx_audit_resource;comment:资源ID" json:"resource_id"`
+	Action     string    `gorm:"column:action;type:varchar(50);not null;comment:操作类型" json:"action"`
+	Before     string    `gorm:"column:before;type:text;comment:变更前数据(JSON)" json:"before"`
+	After      string    `gorm:"column:after;type:text;comment:变更后数据(JSON)" json:"after"`
+	Username   string    `gorm:"column:username;type:varchar(50);index;comment:操作用户" json:"username"`
+	TraceID    string    `gorm:"column:trace_id;type:varchar(64);comment:链路ID" json:"trace_id"`
+	CreatedAt  time.Time `gorm:"column:created_at;autoCreateTime;index;comment:创建时间" json:"created_at"`
+}
+
+func (m *AuditRecordModel) TableName() string {
+	return "system_audit_record"
+}
+
+func (m *AuditRecordModel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if m == nil {
+		return nil
+	}
+	if err := m.BaseModel.MarshalLogObject(enc); err != nil {
+		return err
+	}
+	enc.AddString("module", m.Module)
+	enc.AddString("resource", m.Resource)
+	enc.AddUint32("resource_id", m.ResourceID)
+	enc.AddString("action", m.Action)
+	enc.AddString("username", m.Username)
+	enc.AddString("trace_id", m.TraceID)
+	return nil
+}
+
+// NewAuditRecord 创建审计记录, 变更前后的数据序列化为JSON保存
+func NewAuditRecord(
+	module, resource string,
+	resourceID uint32,
+	action string,
+	before, after any,
+	username, traceID string,
+) (*AuditRecordModel, error) {
+	m := &AuditRecordModel{
+		Module:     module,
+		Resource:   resource,
+		ResourceID: resourceID,
+		Action:     action,
+		Username:   username,
+		TraceID:    traceID,
+	}
+	if before != nil {
+		bs, err := json.Marshal(before)
+		if err != nil {
+			return nil, err
+		}
+		m.Before = string(bs)
+	}
+	if after != nil {
+		bs, err := json.Marshal(after)
+		if err != nil {
+			return nil, err
+		}
+		m.After = string(bs)
+	}
+	return m, nil
+}
+
+// ListAuditRecordRequest 用于查询审计记录的请求结构体
+//
+// swagger:model ListAuditRecordRequest
+type ListAuditRecordRequest struct {
+	common.BaseModelQuery
+
+	// 所属模块
+	Module string `form:"module" binding:"omitempty,max=50"`
+
+	// 资源类型
+	Resource string `form:"resource" binding:"omitempty,max=50"`
+
+	// 资源ID
+	ResourceID uint32 `form:"resource_id" binding:"omitempty,gt=0"`
+
+	// 操作类型
+	Action string `form:"action" binding:"omitempty,max=50"`
+
+	// 操作用户
+	Username string `form:"username" binding:"omitempty,max=50"`
+
+	// 开始时间 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	StartAt string `form:"start_at"`
+
+	// 结束时间 (RFC3339格式)
+	// example: 2023-01-02T00:00:00Z
+	EndAt string `form:"end_at"`
+}
+
+func (req *ListAuditRecordRequest) Query() (int, int, map[string]any) {
+	page, size, query := req.BaseModelQuery.QueryMap(10)
+	if req.Module != "" {
+		query["module = ?"] = req.Module
+	}
+	if req.Resource != "" {
+		query["resource = ?"] = req.Resource
+	}
+	if req.ResourceID > 0 {
+		query["resource_id = ?"] = req.ResourceID
+	}
+	if req.Action != "" {
+		query["action = ?"] = req.Action
+	}
+	if req.Username != "" {
+		query["username = ?"] = req.Username
+	}
+	if req.StartAt != "" {
+		if st, err := time.Parse(time.RFC3339, req.StartAt); err == nil {
+			query["created_at >= ?"] = st
+		}
+	}
+	if req.EndAt != "" {
+		if et, err := time.Parse(time.RFC3339, req.EndAt); err == nil {
+			query["created_at < ?"] = et
+		}
+	}
+	return page, size, query
+}
+
+// AuditRecordFilterFields 审计记录列表允许使用filter参数过滤的字段
+var AuditRecordFilterFields = database.FilterFields{
+	"id":          {Column: "id", Type: database.FilterInt},
+	"module":      {Column: "module", Type: database.FilterString},
+	"resource":    {Column: "resource", Type: database.FilterString},
+	"resource_id": {Column: "resource_id", Type: database.FilterInt},
+	"action":      {Column: "action", Type: database.FilterString},
+	"username":    {Column: "username", Type: database.FilterString},
+	"trace_id":    {Column: "trace_id", Type: database.FilterString},
+	"created_at":  {Column: "created_at", Type: database.FilterTime},
+}
+
+type AuditRecordOut struct {
+	// ID
+	ID uint32 `json:"id" example:"1"`
+
+	// 所属模块
+	Module string `json:"module" example:"oes"`
+
+	// 资源类型
+	Resource string `json:"resource" example:"colony"`
+
+	// 资源ID
+	ResourceID uint32 `json:"resource_id" example:"1"`
+
+	// 操作类型
+	Action string `json:"action" example:"batch_update"`
+
+	// 变更前数据(JSON)
+	Before string `json:"before" example:"{\"is_enable\":true}"`
+
+	// 变更后数据(JSON)
+	After string `json:"after" example:"{\"is_enable\":false}"`
+
+	// 操作用户
+	Username string `json:"username" example:"admin"`
+
+	// 链路ID
+	TraceID string `json:"trace_id" example:""`
+
+	// 创建时间
+	CreatedAt string `json:"created_at" example:"2023-01-01 12:00:00"`
+}
+
+// PagAuditRecordReply 审计记录的分页响应结构
+type PagAuditRecordReply = common.APIReply[*common.Pag[AuditRecordOut]]
+
+func AuditRecordToOut(
+	m AuditRecordModel,
+) *AuditRecordOut {
+	return &AuditRecordOut{
+		ID:         m.ID,
+		Module:     m.Module,
+		Resource:   m.Resource,
+		ResourceID: m.ResourceID,
+		Action:     m.Action,
+		Before:     m.Before,
+		After:      m.After,
+		Username:   m.Username,
+		TraceID:    m.TraceID,
+		CreatedAt:  m.CreatedAt.Format(time.DateTime),
+	}
+}
+
+func ListAuditRecordToOut(
+	rms *[]AuditRecordModel,
+) *[]AuditRecordOut {
+	if rms == nil {
+		return &[]AuditRecordOut{}
+	}
+
+	ms := *rms
+	mso := make([]AuditRecordOut, 0, len(ms))
+	for _, m := range ms {
+		mso = append(mso, *AuditRecordToOut(m))
+	}
+	return &mso
+}
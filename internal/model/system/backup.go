@@ -0,0 +1,211 @@
+package system
+
+import (
+	"time"
+
+	"go.uber.org/zap/zapcore"
+
+	"gin-artweb/internal/model/common"
+	"gin-artweb/internal/shared/database"
+)
+
+// 备份状态
+const (
+	BackupRunning   = "running"   // 备份中
+	BackupSucceeded = "succeeded" // 备份成功
+	BackupFailed    = "failed"    // 备份失败
+)
+
+// 备份触发方式
+const (
+	BackupTriggerManual    = "manual"    // 管理员手动备份
+	BackupTriggerScheduled = "scheduled" // 定时备份
+)
+
+// TaskKindBackup 备份数据的后台任务
+const TaskKindBackup = "backup"
+
+// BackupManifestVersion 备份文件格式版本, 恢复时只接受相同版本的备份
+const BackupManifestVersion = 1
+
+// BackupModel 数据备份记录
+//
+// 备份文件为tar.gz格式, 包含manifest.json、db目录下每张表的数据和files目录下的上传文件,
+// 保存在配置的存储后端中, storage_key为备份文件在存储中的key
+type BackupModel struct {
+	database.StandardModel
+	Name          string     `gorm:"column:name;type:varchar(100);not null;uniqueIndex;comment:备份文件名" json:"name"`
+	StorageKey    string     `gorm:"column:storage_key;type:varchar(254);comment:存储key" json:"storage_key"`
+	StorageType   string     `gorm:"column:storage_type;type:varchar(20);comment:存储类型" json:"storage_type"`
+	Trigger       string     `gorm:"column:trigger_type;type:varchar(20);not null;comment:触发方式" json:"trigger"`
+	Status        string     `gorm:"column:status;type:varchar(20);not null;index;comment:状态" json:"status"`
+	SchemaVersion string     `gorm:"column:schema_version;type:varchar(64);comment:数据库结构版本" json:"schema_version"`
+	Size          int64      `gorm:"column:size;not null;default:0;comment:文件大小(字节)" json:"size"`
+	TableCount    int        `gorm:"column:table_count;not null;default:0;comment:表数量" json:"table_count"`
+	RowCount      int64      `gorm:"column:row_count;not null;default:0;comment:记录数量" json:"row_count"`
+	FileCount     int        `gorm:"column:file_count;not null;default:0;comment:文件数量" json:"file_count"`
+	Error         string     `gorm:"column:error;type:varchar(1024);comment:失败原因" json:"error"`
+	Username      string     `gorm:"column:username;type:varchar(50);comment:操作用户名" json:"username"`
+	FinishedAt    *time.Time `gorm:"column:finished_at;comment:结束时间" json:"finished_at"`
+}
+
+func (m *BackupModel) TableName() string {
+	return "system_backup"
+}
+
+func (m *BackupModel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if m == nil {
+		return nil
+	}
+	if err := m.StandardModel.MarshalLogObject(enc); err != nil {
+		return err
+	}
+	enc.AddString("name", m.Name)
+	enc.AddString("storage_key", m.StorageKey)
+	enc.AddString("trigger", m.Trigger)
+	enc.AddString("status", m.Status)
+	enc.AddString("schema_version", m.SchemaVersion)
+	enc.AddInt64("size", m.Size)
+	enc.AddString("username", m.Username)
+	return nil
+}
+
+// BackupManifest 备份文件中的manifest.json, 记录备份内容, 恢复时按其中的顺序导入
+type BackupManifest struct {
+	Version       int               `json:"version"`
+	SchemaVersion string            `json:"schema_version"`
+	Dialect       string            `json:"dialect"`
+	CreatedAt     time.Time         `json:"created_at"`
+	Tables        []BackupTableInfo `json:"tables"`
+	Dirs          []string          `json:"dirs"`
+	FileCount     int               `json:"file_count"`
+}
+
+// RowCount 备份的总记录数
+func (m *BackupManifest) RowCount() int64 {
+	var total int64
+	for _, t := range m.Tables {
+		total += t.Rows
+	}
+	return total
+}
+
+// BackupTableInfo 备份中的一张表, 数据保存在db/{name}.gob
+type BackupTableInfo struct {
+	Name string `json:"name"`
+	Rows int64  `json:"rows"`
+}
+
+// ListBackupRequest 用于查询备份列表的请求结构体
+//
+// swagger:model ListBackupRequest
+type ListBackupRequest struct {
+	common.StandardModelQuery
+
+	// 触发方式
+	Trigger string `form:"trigger" binding:"omitempty,oneof=manual scheduled"`
+
+	// 状态
+	Status string `form:"status" binding:"omitempty,oneof=running succeeded failed"`
+}
+
+func (req *ListBackupRequest) Query() (int, int, map[string]any) {
+	page, size, query := req.StandardModelQuery.QueryMap(10)
+	if req.Trigger != "" {
+		query["trigger_type = ?"] = req.Trigger
+	}
+	if req.Status != "" {
+		query["status = ?"] = req.Status
+	}
+	return page, size, query
+}
+
+type BackupOut struct {
+	// ID
+	ID uint32 `json:"id" example:"1"`
+
+	// 备份文件名
+	Name string `json:"name" example:"backup-20230101-020000.tar.gz"`
+
+	// 存储类型
+	StorageType string `json:"storage_type" example:"local"`
+
+	// 触发方式(manual-手动,scheduled-定时)
+	Trigger string `json:"trigger" example:"scheduled"`
+
+	// 状态(running-备份中,succeeded-成功,failed-失败)
+	Status string `json:"status" example:"succeeded"`
+
+	// 数据库结构版本
+	SchemaVersion string `json:"schema_version" example:"000033"`
+
+	// 文件大小(字节)
+	Size int64 `json:"size" example:"1048576"`
+
+	// 表数量
+	TableCount int `json:"table_count" example:"60"`
+
+	// 记录数量
+	RowCount int64 `json:"row_count" example:"12000"`
+
+	// 文件数量
+	FileCount int `json:"file_count" example:"35"`
+
+	// 失败原因
+	Error string `json:"error" example:""`
+
+	// 操作用户名, 定时备份为空
+	Username string `json:"username" example:"admin"`
+
+	// 创建时间
+	CreatedAt string `json:"created_at" example:"2023-01-01 02:00:00"`
+
+	// 结束时间
+	FinishedAt string `json:"finished_at" example:"2023-01-01 02:00:30"`
+}
+
+// BackupReply 备份响应结构
+type BackupReply = common.APIReply[BackupOut]
+
+// PagBackupReply 备份的分页响应结构
+type PagBackupReply = common.APIReply[*common.Pag[BackupOut]]
+
+func BackupToOut(
+	m BackupModel,
+) *BackupOut {
+	var finishedAt string
+	if m.FinishedAt != nil {
+		finishedAt = m.FinishedAt.Format(time.DateTime)
+	}
+	return &BackupOut{
+		ID:            m.ID,
+		Name:          m.Name,
+		StorageType:   m.StorageType,
+		Trigger:       m.Trigger,
+		Status:        m.Status,
+		SchemaVersion: m.SchemaVersion,
+		Size:          m.Size,
+		TableCount:    m.TableCount,
+		RowCount:      m.RowCount,
+		FileCount:     m.FileCount,
+		Error:         m.Error,
+		Username:      m.Username,
+		CreatedAt:     m.CreatedAt.Format(time.DateTime),
+		FinishedAt:    finishedAt,
+	}
+}
+
+func ListBackupToOut(
+	rms *[]BackupModel,
+) *[]BackupOut {
+	if rms == nil {
+		return &[]BackupOut{}
+	}
+
+	ms := *rms
+	mso := make([]BackupOut, 0, len(ms))
+	for _, m := range ms {
+		mso = append(mso, *BackupToOut(m))
+	}
+	return &mso
+}
@@ -0,0 +1,334 @@
+package system
+
+import (
+	"math"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+
+	"gin-artweb/internal/model/common"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/events"
+)
+
+// AlertKindCertExpiry 证书即将过期、已过期或证书链无效告警
+const AlertKindCertExpiry = "cert_expiry"
+
+// 证书的来源
+const (
+	CertKindEndpoint = "endpoint" // TLS服务地址, 连接后读取服务端证书
+	CertKindFile     = "file"     // 平台服务器上的PEM证书文件
+)
+
+// 证书的检查状态
+const (
+	CertUnknown  = "unknown"  // 尚未检查
+	CertOK       = "ok"       // 证书有效且未到告警阈值
+	CertExpiring = "expiring" // 剩余天数已到告警阈值
+	CertExpired  = "expired"  // 证书已过期
+	CertInvalid  = "invalid"  // 证书链校验失败
+	CertError    = "error"    // 无法读取证书
+)
+
+// CertMonitorModel 证书过期监控, 定时检查TLS服务或证书文件的过期时间和证书链
+type CertMonitorModel struct {
+	database.StandardModel
+	Name             string     `gorm:"column:name;type:varchar(50);not null;uniqueIndex;comment:名称" json:"name"`
+	Kind             string     `gorm:"column:kind;type:varchar(10);not null;comment:证书来源" json:"kind"`
+	Address          string     `gorm:"column:address;type:varchar(255);comment:TLS服务地址(host:port)" json:"address"`
+	ServerName       string     `gorm:"column:server_name;type:varchar(255);comment:校验证书的域名" json:"server_name"`
+	FilePath         string     `gorm:"column:file_path;type:varchar(512);comment:证书文件路径" json:"file_path"`
+	CAFile           string     `gorm:"column:ca_file;type:varchar(512);comment:根证书文件路径, 为空时使用系统根证书" json:"ca_file"`
+	IsEnabled        bool       `gorm:"column:is_enabled;type:boolean;index;comment:是否启用" json:"is_enabled"`
+	Status           string     `gorm:"column:status;type:varchar(10);not null;default:unknown;comment:检查状态" json:"status"`
+	Subject          string     `gorm:"column:subject;type:varchar(512);comment:证书主题" json:"subject"`
+	Issuer           string     `gorm:"column:issuer;type:varchar(512);comment:证书签发者" json:"issuer"`
+	NotBefore        *time.Time `gorm:"column:not_before;comment:生效时间" json:"not_before"`
+	NotAfter         *time.Time `gorm:"column:not_after;index;comment:过期时间" json:"not_after"`
+	ChainError       string     `gorm:"column:chain_error;type:varchar(1024);comment:证书链校验失败原因" json:"chain_error"`
+	LastError        string     `gorm:"column:last_error;type:varchar(1024);comment:最近一次读取证书失败原因" json:"last_error"`
+	LastCheckedAt    *time.Time `gorm:"column:last_checked_at;comment:最近一次检查时间" json:"last_checked_at"`
+	AlertedThreshold int        `gorm:"column:alerted_threshold;not null;default:0;comment:已告警的最小阈值(天), 0表示未告警, -1表示已告警过期" json:"alerted_threshold"`
+	Remark           string     `gorm:"column:remark;type:varchar(254);comment:备注" json:"remark"`
+}
+
+func (m *CertMonitorModel) TableName() string {
+	return "system_cert_monitor"
+}
+
+func (m *CertMonitorModel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if m == nil {
+		return nil
+	}
+	if err := m.StandardModel.MarshalLogObject(enc); err != nil {
+		return err
+	}
+	enc.AddString("name", m.Name)
+	enc.AddString("kind", m.Kind)
+	enc.AddString("address", m.Address)
+	enc.AddString("file_path", m.FilePath)
+	enc.AddBool("is_enabled", m.IsEnabled)
+	enc.AddString("status", m.Status)
+	return nil
+}
+
+// DaysRemaining 返回证书距离过期的天数, 不足一天按0天计算, 已过期时为负数, 未读取到证书时返回nil
+func (m *CertMonitorModel) DaysRemaining(now time.Time) *int {
+	if m.NotAfter == nil {
+		return nil
+	}
+	days := int(math.Floor(m.NotAfter.Sub(now).Hours() / 24))
+	return &days
+}
+
+// CertAlertThreshold 返回剩余天数命中的最小告警阈值, 已过期时返回-1, 未命中任何阈值时返回0
+func CertAlertThreshold(days int, thresholds []int) int {
+	if days < 0 {
+		return -1
+	}
+	level := 0
+	for _, t := range thresholds {
+		if t > 0 && days <= t && (level == 0 || t < level) {
+			level = t
+		}
+	}
+	return level
+}
+
+// CertExpiryEvent 证书即将过期、已过期或证书链无效的告警事件
+type CertExpiryEvent struct {
+	Kind          string `json:"kind"`
+	MonitorID     uint32 `json:"monitor_id"`
+	Name          string `json:"name"`
+	Source        string `json:"source"`
+	Status        string `json:"status"`
+	Subject       string `json:"subject"`
+	NotAfter      string `json:"not_after"`
+	DaysRemaining int    `json:"days_remaining"`
+	Threshold     int    `json:"threshold"`
+	ChainError    string `json:"chain_error"`
+	CheckedAt     string `json:"checked_at"`
+}
+
+func (e CertExpiryEvent) EventType() string {
+	return events.AlertFired
+}
+
+// CertMonitorRequest 用于创建或更新证书监控的请求结构体
+//
+// swagger:model CertMonitorRequest
+type CertMonitorRequest struct {
+	// 名称
+	Name string `json:"name" binding:"required,max=50"`
+
+	// 证书来源(endpoint/file)
+	Kind string `json:"kind" binding:"required,oneof=endpoint file"`
+
+	// TLS服务地址(host:port), 证书来源为endpoint时必填
+	Address string `json:"address" binding:"required_if=Kind endpoint,omitempty,hostname_port"`
+
+	// 校验证书的域名, 为空时使用服务地址中的主机名
+	ServerName string `json:"server_name" binding:"omitempty,max=255"`
+
+	// 证书文件路径, 相对路径基于配置目录, 证书来源为file时必填
+	FilePath string `json:"file_path" binding:"required_if=Kind file,omitempty,max=512"`
+
+	// 根证书文件路径, 相对路径基于配置目录, 为空时使用系统根证书
+	CAFile string `json:"ca_file" binding:"omitempty,max=512"`
+
+	// 是否启用
+	IsEnabled bool `json:"is_enabled"`
+
+	// 备注
+	Remark string `json:"remark" binding:"omitempty,max=254"`
+}
+
+func (req *CertMonitorRequest) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if req == nil {
+		return nil
+	}
+	enc.AddString("name", req.Name)
+	enc.AddString("kind", req.Kind)
+	enc.AddString("address", req.Address)
+	enc.AddString("server_name", req.ServerName)
+	enc.AddString("file_path", req.FilePath)
+	enc.AddString("ca_file", req.CAFile)
+	enc.AddBool("is_enabled", req.IsEnabled)
+	return nil
+}
+
+func (req *CertMonitorRequest) ToModel() CertMonitorModel {
+	m := CertMonitorModel{
+		Name:       req.Name,
+		Kind:       req.Kind,
+		CAFile:     req.CAFile,
+		IsEnabled:  req.IsEnabled,
+		Remark:     req.Remark,
+		ServerName: req.ServerName,
+	}
+	if req.Kind == CertKindEndpoint {
+		m.Address = req.Address
+	} else {
+		m.FilePath = req.FilePath
+	}
+	return m
+}
+
+// ListCertMonitorRequest 用于查询证书监控列表的请求结构体
+//
+// swagger:model ListCertMonitorRequest
+type ListCertMonitorRequest struct {
+	common.StandardModelQuery
+
+	// 名称
+	Name string `form:"name"`
+
+	// 证书来源
+	Kind string `form:"kind" binding:"omitempty,oneof=endpoint file"`
+
+	// 检查状态
+	Status string `form:"status" binding:"omitempty,oneof=unknown ok expiring expired invalid error"`
+
+	// 是否启用
+	IsEnabled *bool `form:"is_enabled"`
+
+	// 剩余天数不超过该值, 按过期时间筛选
+	ExpireWithinDays *int `form:"expire_within_days" binding:"omitempty,gte=0"`
+}
+
+func (req *ListCertMonitorRequest) Query() (int, int, map[string]any) {
+	page, size, query := req.StandardModelQuery.QueryMap(10)
+	if req.Name != "" {
+		query["name like ?"] = "%" + req.Name + "%"
+	}
+	if req.Kind != "" {
+		query["kind = ?"] = req.Kind
+	}
+	if req.Status != "" {
+		query["status = ?"] = req.Status
+	}
+	if req.IsEnabled != nil {
+		query["is_enabled = ?"] = *req.IsEnabled
+	}
+	if req.ExpireWithinDays != nil {
+		query["not_after < ?"] = time.Now().AddDate(0, 0, *req.ExpireWithinDays+1)
+	}
+	return page, size, query
+}
+
+type CertMonitorOut struct {
+	// ID
+	ID uint32 `json:"id" example:"1"`
+
+	// 名称
+	Name string `json:"name" example:"柜台网关证书"`
+
+	// 证书来源(endpoint/file)
+	Kind string `json:"kind" example:"endpoint"`
+
+	// TLS服务地址
+	Address string `json:"address" example:"gateway.example.com:443"`
+
+	// 校验证书的域名
+	ServerName string `json:"server_name" example:""`
+
+	// 证书文件路径
+	FilePath string `json:"file_path" example:""`
+
+	// 根证书文件路径
+	CAFile string `json:"ca_file" example:""`
+
+	// 是否启用
+	IsEnabled bool `json:"is_enabled" example:"true"`
+
+	// 检查状态(unknown/ok/expiring/expired/invalid/error)
+	Status string `json:"status" example:"expiring"`
+
+	// 证书主题
+	Subject string `json:"subject" example:"CN=gateway.example.com"`
+
+	// 证书签发者
+	Issuer string `json:"issuer" example:"CN=Example CA"`
+
+	// 生效时间
+	NotBefore string `json:"not_before" example:"2024-01-01 00:00:00"`
+
+	// 过期时间
+	NotAfter string `json:"not_after" example:"2025-01-01 00:00:00"`
+
+	// 距离过期的天数, 已过期时为负数, 未读取到证书时为空
+	DaysRemaining *int `json:"days_remaining" example:"12"`
+
+	// 证书链校验失败原因
+	ChainError string `json:"chain_error" example:""`
+
+	// 最近一次读取证书失败原因
+	LastError string `json:"last_error" example:""`
+
+	// 最近一次检查时间
+	LastCheckedAt string `json:"last_checked_at" example:"2024-12-20 08:00:00"`
+
+	// 备注
+	Remark string `json:"remark" example:""`
+
+	// 创建时间
+	CreatedAt string `json:"created_at" example:"2023-01-01 12:00:00"`
+
+	// 更新时间
+	UpdatedAt string `json:"updated_at" example:"2023-01-01 12:00:00"`
+}
+
+// CertMonitorReply 证书监控响应结构
+type CertMonitorReply = common.APIReply[*CertMonitorOut]
+
+// PagCertMonitorReply 证书监控的分页响应结构
+type PagCertMonitorReply = common.APIReply[*common.Pag[CertMonitorOut]]
+
+func CertMonitorToOut(
+	m CertMonitorModel,
+) *CertMonitorOut {
+	out := &CertMonitorOut{
+		ID:            m.ID,
+		Name:          m.Name,
+		Kind:          m.Kind,
+		Address:       m.Address,
+		ServerName:    m.ServerName,
+		FilePath:      m.FilePath,
+		CAFile:        m.CAFile,
+		IsEnabled:     m.IsEnabled,
+		Status:        m.Status,
+		Subject:       m.Subject,
+		Issuer:        m.Issuer,
+		DaysRemaining: m.DaysRemaining(time.Now()),
+		ChainError:    m.ChainError,
+		LastError:     m.LastError,
+		Remark:        m.Remark,
+		CreatedAt:     m.CreatedAt.Format(time.DateTime),
+		UpdatedAt:     m.UpdatedAt.Format(time.DateTime),
+	}
+	if m.NotBefore != nil {
+		out.NotBefore = m.NotBefore.Format(time.DateTime)
+	}
+	if m.NotAfter != nil {
+		out.NotAfter = m.NotAfter.Format(time.DateTime)
+	}
+	if m.LastCheckedAt != nil {
+		out.LastCheckedAt = m.LastCheckedAt.Format(time.DateTime)
+	}
+	return out
+}
+
+func ListCertMonitorToOut(
+	rms *[]CertMonitorModel,
+) *[]CertMonitorOut {
+	if rms == nil {
+		return &[]CertMonitorOut{}
+	}
+
+	ms := *rms
+	mso := make([]CertMonitorOut, 0, len(ms))
+	for _, m := range ms {
+		mso = append(mso, *CertMonitorToOut(m))
+	}
+	return &mso
+}
@@ -0,0 +1,24 @@
+package system
+
+import (
+	"gin-artweb/internal/model/common"
+	"gin-artweb/internal/shared/config"
+)
+
+type DeployInfoOut struct {
+	// 部署模式(standard/embedded)
+	Mode string `json:"mode" example:"standard"`
+
+	// 功能启用状态, 前端据此隐藏不可用的功能入口
+	Features map[string]bool `json:"features"`
+}
+
+// DeployInfoReply 部署模式信息响应结构
+type DeployInfoReply = common.APIReply[DeployInfoOut]
+
+func DeployInfoToOut(c *config.DeployConfig) *DeployInfoOut {
+	return &DeployInfoOut{
+		Mode:     c.Mode,
+		Features: c.Features(),
+	}
+}
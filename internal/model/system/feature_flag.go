@@ -0,0 +1,206 @@
+package system
+
+import (
+	"hash/fnv"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+
+	"gin-artweb/internal/model/common"
+	"gin-artweb/internal/shared/database"
+)
+
+// FeatureFlagModel 功能开关
+//
+// 新功能上线时默认关闭, 通过开关逐步开放给指定角色或按比例开放给用户, 无需重新部署
+type FeatureFlagModel struct {
+	database.StandardModel
+	Name        string `gorm:"column:name;type:varchar(100);not null;uniqueIndex;comment:开关名称" json:"name"`
+	IsEnabled   bool   `gorm:"column:is_enabled;type:boolean;comment:是否启用" json:"is_enabled"`
+	RoleIDs     string `gorm:"column:role_ids;type:varchar(512);comment:开放的角色ID(逗号分隔)" json:"role_ids"`
+	Percentage  int    `gorm:"column:percentage;not null;default:0;comment:按用户开放的百分比" json:"percentage"`
+	Description string `gorm:"column:description;type:varchar(254);comment:说明" json:"description"`
+	Username    string `gorm:"column:username;type:varchar(50);comment:用户名" json:"username"`
+}
+
+func (m *FeatureFlagModel) TableName() string {
+	return "system_feature_flag"
+}
+
+func (m *FeatureFlagModel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if m == nil {
+		return nil
+	}
+	if err := m.StandardModel.MarshalLogObject(enc); err != nil {
+		return err
+	}
+	enc.AddString("name", m.Name)
+	enc.AddBool("is_enabled", m.IsEnabled)
+	enc.AddString("role_ids", m.RoleIDs)
+	enc.AddInt("percentage", m.Percentage)
+	enc.AddString("username", m.Username)
+	return nil
+}
+
+// RoleIDList 返回开放的角色ID
+func (m *FeatureFlagModel) RoleIDList() []uint32 {
+	if m.RoleIDs == "" {
+		return []uint32{}
+	}
+	parts := strings.Split(m.RoleIDs, ",")
+	ids := make([]uint32, 0, len(parts))
+	for _, p := range parts {
+		id, err := strconv.ParseUint(p, 10, 32)
+		if err != nil {
+			continue
+		}
+		ids = append(ids, uint32(id))
+	}
+	return ids
+}
+
+// EnabledFor 判断功能是否对用户开放
+//
+// 开关关闭时对所有用户关闭; 开放的角色始终可用; 其他用户按用户ID分桶,
+// 同一用户对同一开关的结果固定, 比例调大时已开放的用户不会被关闭.
+// userID为0表示未登录, 仅在开放比例为100时可用
+func (m *FeatureFlagModel) EnabledFor(userID, roleID uint32) bool {
+	if !m.IsEnabled {
+		return false
+	}
+	if m.Percentage >= 100 {
+		return true
+	}
+	if userID == 0 {
+		return false
+	}
+	if slices.Contains(m.RoleIDList(), roleID) {
+		return true
+	}
+	return featureBucket(m.Name, userID) < m.Percentage
+}
+
+// featureBucket 用户在开关中的分桶, 范围为0~99
+func featureBucket(name string, userID uint32) int {
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	h.Write([]byte{':'})
+	h.Write([]byte(strconv.FormatUint(uint64(userID), 10)))
+	return int(h.Sum32() % 100)
+}
+
+// FeatureFlagRequest 用于创建和更新功能开关的请求结构体
+//
+// swagger:model FeatureFlagRequest
+type FeatureFlagRequest struct {
+	// 开关名称, 如oes.workflow.dag
+	Name string `json:"name" binding:"required,max=100"`
+
+	// 是否启用, 关闭时对所有用户关闭
+	IsEnabled bool `json:"is_enabled"`
+
+	// 开放的角色ID
+	RoleIDs []uint32 `json:"role_ids" binding:"omitempty,max=100"`
+
+	// 按用户开放的百分比(0~100)
+	Percentage int `json:"percentage" binding:"gte=0,lte=100"`
+
+	// 说明
+	Description string `json:"description" binding:"omitempty,max=254"`
+}
+
+// ListFeatureFlagRequest 用于查询功能开关列表的请求结构体
+//
+// swagger:model ListFeatureFlagRequest
+type ListFeatureFlagRequest struct {
+	common.BaseModelQuery
+
+	// 开关名称
+	Name string `form:"name" binding:"omitempty,max=100"`
+
+	// 是否启用
+	IsEnabled *bool `form:"is_enabled"`
+}
+
+func (req *ListFeatureFlagRequest) Query() (int, int, map[string]any) {
+	page, size, query := req.BaseModelQuery.QueryMap(10)
+	if req.Name != "" {
+		query["name like ?"] = "%" + req.Name + "%"
+	}
+	if req.IsEnabled != nil {
+		query["is_enabled = ?"] = *req.IsEnabled
+	}
+	return page, size, query
+}
+
+type FeatureFlagOut struct {
+	// ID
+	ID uint32 `json:"id" example:"1"`
+
+	// 开关名称
+	Name string `json:"name" example:"oes.workflow.dag"`
+
+	// 是否启用
+	IsEnabled bool `json:"is_enabled" example:"true"`
+
+	// 开放的角色ID
+	RoleIDs []uint32 `json:"role_ids" example:"1,2"`
+
+	// 按用户开放的百分比
+	Percentage int `json:"percentage" example:"20"`
+
+	// 说明
+	Description string `json:"description" example:"DAG编排"`
+
+	// 用户名
+	Username string `json:"username" example:"admin"`
+
+	// 创建时间
+	CreatedAt string `json:"created_at" example:"2023-01-01 12:00:00"`
+
+	// 更新时间
+	UpdatedAt string `json:"updated_at" example:"2023-01-01 12:00:00"`
+}
+
+// FeatureFlagReply 功能开关响应结构
+type FeatureFlagReply = common.APIReply[FeatureFlagOut]
+
+// PagFeatureFlagReply 功能开关的分页响应结构
+type PagFeatureFlagReply = common.APIReply[*common.Pag[FeatureFlagOut]]
+
+// MyFeatureFlagReply 当前用户可用功能的响应结构, 键为开关名称
+type MyFeatureFlagReply = common.APIReply[map[string]bool]
+
+func FeatureFlagToOut(
+	m FeatureFlagModel,
+) *FeatureFlagOut {
+	return &FeatureFlagOut{
+		ID:          m.ID,
+		Name:        m.Name,
+		IsEnabled:   m.IsEnabled,
+		RoleIDs:     m.RoleIDList(),
+		Percentage:  m.Percentage,
+		Description: m.Description,
+		Username:    m.Username,
+		CreatedAt:   m.CreatedAt.Format(time.DateTime),
+		UpdatedAt:   m.UpdatedAt.Format(time.DateTime),
+	}
+}
+
+func ListFeatureFlagToOut(
+	rms *[]FeatureFlagModel,
+) *[]FeatureFlagOut {
+	if rms == nil {
+		return &[]FeatureFlagOut{}
+	}
+
+	ms := *rms
+	mso := make([]FeatureFlagOut, 0, len(ms))
+	for _, m := range ms {
+		mso = append(mso, *FeatureFlagToOut(m))
+	}
+	return &mso
+}
@@ -0,0 +1,230 @@
+package system
+
+import (
+	"strings"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+
+	"gin-artweb/internal/model/common"
+	"gin-artweb/internal/shared/database"
+)
+
+// 变更冻结的作用范围
+const (
+	ChangeFreezeScopeGlobal = "global" // 全局, 冻结全部配置的修改接口
+	ChangeFreezeScopeModule = "module" // 模块, 仅冻结该模块的修改接口
+)
+
+// 变更冻结审计记录
+const (
+	ChangeFreezeAuditResource  = "change_freeze"
+	ChangeFreezeActionOverride = "override" // 工作人员越过冻结修改配置
+)
+
+// ChangeFreezeModel 变更冻结, 用于期权到期日等敏感时段禁止修改配置
+type ChangeFreezeModel struct {
+	database.StandardModel
+	Name      string    `gorm:"column:name;type:varchar(50);not null;uniqueIndex;comment:名称" json:"name"`
+	Scope     string    `gorm:"column:scope;type:varchar(10);not null;index;comment:作用范围" json:"scope"`
+	Module    string    `gorm:"column:module;type:varchar(20);comment:冻结的模块" json:"module"`
+	StartAt   time.Time `gorm:"column:start_at;not null;comment:开始时间" json:"start_at"`
+	EndAt     time.Time `gorm:"column:end_at;not null;index;comment:结束时间" json:"end_at"`
+	IsEnabled bool      `gorm:"column:is_enabled;type:boolean;comment:是否启用" json:"is_enabled"`
+	Reason    string    `gorm:"column:reason;type:varchar(254);comment:冻结原因" json:"reason"`
+	Username  string    `gorm:"column:username;type:varchar(50);comment:用户名" json:"username"`
+}
+
+func (m *ChangeFreezeModel) TableName() string {
+	return "system_change_freeze"
+}
+
+func (m *ChangeFreezeModel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if m == nil {
+		return nil
+	}
+	if err := m.StandardModel.MarshalLogObject(enc); err != nil {
+		return err
+	}
+	enc.AddString("name", m.Name)
+	enc.AddString("scope", m.Scope)
+	enc.AddString("module", m.Module)
+	enc.AddTime("start_at", m.StartAt)
+	enc.AddTime("end_at", m.EndAt)
+	enc.AddBool("is_enabled", m.IsEnabled)
+	enc.AddString("username", m.Username)
+	return nil
+}
+
+// ActiveAt 判断变更冻结在指定时间是否生效
+func (m *ChangeFreezeModel) ActiveAt(t time.Time) bool {
+	return m.IsEnabled && !t.Before(m.StartAt) && t.Before(m.EndAt)
+}
+
+// Covers 判断变更冻结是否覆盖请求路径, 模块范围只覆盖/api/v1/<模块>下的接口
+func (m *ChangeFreezeModel) Covers(path string) bool {
+	switch m.Scope {
+	case ChangeFreezeScopeGlobal:
+		return true
+	case ChangeFreezeScopeModule:
+		prefix := "/api/v1/" + m.Module
+		return path == prefix || strings.HasPrefix(path, prefix+"/")
+	}
+	return false
+}
+
+// ChangeFreezeAuditSnapshot 审计记录中保存的变更冻结字段
+func ChangeFreezeAuditSnapshot(m ChangeFreezeModel) map[string]any {
+	return map[string]any{
+		"name":       m.Name,
+		"scope":      m.Scope,
+		"module":     m.Module,
+		"start_at":   m.StartAt.Format(time.DateTime),
+		"end_at":     m.EndAt.Format(time.DateTime),
+		"is_enabled": m.IsEnabled,
+		"reason":     m.Reason,
+	}
+}
+
+// ChangeFreezeRequest 用于创建和更新变更冻结的请求结构体
+//
+// swagger:model ChangeFreezeRequest
+type ChangeFreezeRequest struct {
+	// 名称
+	Name string `json:"name" binding:"required,max=50"`
+
+	// 作用范围(global/module)
+	Scope string `json:"scope" binding:"required,oneof=global module"`
+
+	// 冻结的模块, 模块范围时必填
+	Module string `json:"module" binding:"omitempty,oneof=oes mds mon jobs resource customer"`
+
+	// 开始时间
+	StartAt string `json:"start_at" binding:"required,datetime=2006-01-02 15:04:05" example:"2025-01-22 00:00:00"`
+
+	// 结束时间
+	EndAt string `json:"end_at" binding:"required,datetime=2006-01-02 15:04:05" example:"2025-01-22 23:59:59"`
+
+	// 是否启用
+	IsEnabled bool `json:"is_enabled"`
+
+	// 冻结原因
+	Reason string `json:"reason" binding:"omitempty,max=254"`
+}
+
+// ListChangeFreezeRequest 用于查询变更冻结列表的请求结构体
+//
+// swagger:model ListChangeFreezeRequest
+type ListChangeFreezeRequest struct {
+	common.BaseModelQuery
+
+	// 名称
+	Name string `form:"name" binding:"omitempty,max=50"`
+
+	// 作用范围
+	Scope string `form:"scope" binding:"omitempty,oneof=global module"`
+
+	// 冻结的模块
+	Module string `form:"module" binding:"omitempty,oneof=oes mds mon jobs resource customer"`
+
+	// 是否启用
+	IsEnabled *bool `form:"is_enabled"`
+}
+
+func (req *ListChangeFreezeRequest) Query() (int, int, map[string]any) {
+	page, size, query := req.BaseModelQuery.QueryMap(10)
+	if req.Name != "" {
+		query["name like ?"] = "%" + req.Name + "%"
+	}
+	if req.Scope != "" {
+		query["scope = ?"] = req.Scope
+	}
+	if req.Module != "" {
+		query["module = ?"] = req.Module
+	}
+	if req.IsEnabled != nil {
+		query["is_enabled = ?"] = *req.IsEnabled
+	}
+	return page, size, query
+}
+
+type ChangeFreezeOut struct {
+	// ID
+	ID uint32 `json:"id" example:"1"`
+
+	// 名称
+	Name string `json:"name" example:"期权到期日"`
+
+	// 作用范围
+	Scope string `json:"scope" example:"module"`
+
+	// 冻结的模块
+	Module string `json:"module" example:"oes"`
+
+	// 开始时间
+	StartAt string `json:"start_at" example:"2025-01-22 00:00:00"`
+
+	// 结束时间
+	EndAt string `json:"end_at" example:"2025-01-22 23:59:59"`
+
+	// 是否启用
+	IsEnabled bool `json:"is_enabled" example:"true"`
+
+	// 当前是否生效
+	IsActive bool `json:"is_active" example:"false"`
+
+	// 冻结原因
+	Reason string `json:"reason" example:""`
+
+	// 用户名
+	Username string `json:"username" example:"admin"`
+
+	// 创建时间
+	CreatedAt string `json:"created_at" example:"2023-01-01 12:00:00"`
+
+	// 更新时间
+	UpdatedAt string `json:"updated_at" example:"2023-01-01 12:00:00"`
+}
+
+// ChangeFreezeReply 变更冻结响应结构
+type ChangeFreezeReply = common.APIReply[ChangeFreezeOut]
+
+// ListChangeFreezeReply 变更冻结列表响应结构
+type ListChangeFreezeReply = common.APIReply[[]ChangeFreezeOut]
+
+// PagChangeFreezeReply 变更冻结的分页响应结构
+type PagChangeFreezeReply = common.APIReply[*common.Pag[ChangeFreezeOut]]
+
+func ChangeFreezeToOut(
+	m ChangeFreezeModel,
+) *ChangeFreezeOut {
+	return &ChangeFreezeOut{
+		ID:        m.ID,
+		Name:      m.Name,
+		Scope:     m.Scope,
+		Module:    m.Module,
+		StartAt:   m.StartAt.Format(time.DateTime),
+		EndAt:     m.EndAt.Format(time.DateTime),
+		IsEnabled: m.IsEnabled,
+		IsActive:  m.ActiveAt(time.Now()),
+		Reason:    m.Reason,
+		Username:  m.Username,
+		CreatedAt: m.CreatedAt.Format(time.DateTime),
+		UpdatedAt: m.UpdatedAt.Format(time.DateTime),
+	}
+}
+
+func ListChangeFreezeToOut(
+	rms *[]ChangeFreezeModel,
+) *[]ChangeFreezeOut {
+	if rms == nil {
+		return &[]ChangeFreezeOut{}
+	}
+
+	ms := *rms
+	mso := make([]ChangeFreezeOut, 0, len(ms))
+	for _, m := range ms {
+		mso = append(mso, *ChangeFreezeToOut(m))
+	}
+	return &mso
+}
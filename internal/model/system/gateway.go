@@ -0,0 +1,69 @@
+package system
+
+import (
+	"encoding/json"
+	"net/url"
+	"slices"
+
+	"go.uber.org/zap/zapcore"
+
+	"gin-artweb/internal/model/common"
+)
+
+// GatewayQuery 查询网关中的单个子查询, 对应一个GET接口
+type GatewayQuery struct {
+	// 接口路径, 如/api/v1/oes/colony
+	Path string `json:"path" binding:"required,startswith=/api/v1/,max=255"`
+
+	// 查询参数
+	Query map[string]string `json:"query" binding:"omitempty,max=32"`
+}
+
+// Key 返回子查询的唯一标识, 相同路径和参数的子查询只执行一次
+func (q *GatewayQuery) Key() string {
+	return q.Path + "?" + q.Values().Encode()
+}
+
+// Values 返回子查询的查询参数
+func (q *GatewayQuery) Values() url.Values {
+	values := make(url.Values, len(q.Query))
+	for k, v := range q.Query {
+		values.Set(k, v)
+	}
+	return values
+}
+
+// GatewayRequest 用于查询网关的请求结构体
+//
+// swagger:model GatewayRequest
+type GatewayRequest struct {
+	// 子查询, 键为结果中的名称
+	Queries map[string]GatewayQuery `json:"queries" binding:"required,min=1,max=16,dive"`
+}
+
+func (req *GatewayRequest) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if req == nil {
+		return nil
+	}
+	names := make([]string, 0, len(req.Queries))
+	for name := range req.Queries {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		q := req.Queries[name]
+		enc.AddString(name, q.Key())
+	}
+	return nil
+}
+
+type GatewayResultOut struct {
+	// 子查询的响应状态码
+	Status int `json:"status" example:"200"`
+
+	// 子查询的响应内容, 与直接调用对应接口的响应相同
+	Body json.RawMessage `json:"body" swaggertype:"object"`
+}
+
+// GatewayReply 查询网关响应结构, 键为子查询名称
+type GatewayReply = common.APIReply[map[string]GatewayResultOut]
@@ -0,0 +1,50 @@
+package system
+
+import (
+	"encoding/json"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// GraphQLRequest GraphQL查询请求, 与GraphQL over HTTP的POST请求格式一致
+//
+// swagger:model GraphQLRequest
+type GraphQLRequest struct {
+	// 查询语句, 只支持query操作
+	Query string `json:"query" binding:"required,max=16384"`
+
+	// 操作名称, 查询语句包含多个操作时必填
+	OperationName string `json:"operationName" binding:"omitempty,max=100"`
+
+	// 查询变量
+	Variables map[string]any `json:"variables"`
+}
+
+func (req *GraphQLRequest) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if req == nil {
+		return nil
+	}
+	enc.AddString("operation_name", req.OperationName)
+	enc.AddString("query", req.Query)
+	return nil
+}
+
+type GraphQLErrorOut struct {
+	// 错误信息
+	Message string `json:"message" example:"禁止访问"`
+
+	// 出错字段的路径
+	Path []any `json:"path,omitempty" swaggertype:"array,string" example:"oes_colonies"`
+
+	// 错误原因和数据, 与REST接口的错误响应相同
+	Extensions map[string]any `json:"extensions,omitempty"`
+}
+
+// GraphQLReply GraphQL查询响应, 无权访问或查询失败的字段为null并在errors中返回原因
+type GraphQLReply struct {
+	// 查询结果
+	Data json.RawMessage `json:"data" swaggertype:"object"`
+
+	// 查询错误
+	Errors []GraphQLErrorOut `json:"errors,omitempty"`
+}
@@ -0,0 +1,153 @@
+package system
+
+import (
+	"time"
+
+	"gin-artweb/internal/model/common"
+	"gin-artweb/pkg/logsearch"
+)
+
+// LogSources 可以查询的日志源, 对应logs目录下的同名日志文件
+var LogSources = []string{"service", "biz", "data", "server"}
+
+// ListLogRequest 用于查询运行日志的请求结构体
+//
+// swagger:model ListLogRequest
+type ListLogRequest struct {
+	// 日志源(service/biz/data/server), 为空时查询service、biz和data
+	Source string `form:"source" binding:"omitempty,oneof=service biz data server"`
+
+	// 开始时间
+	StartTime string `form:"start_time" binding:"omitempty,datetime=2006-01-02 15:04:05" example:"2025-01-01 09:00:00"`
+
+	// 结束时间
+	EndTime string `form:"end_time" binding:"omitempty,datetime=2006-01-02 15:04:05" example:"2025-01-01 18:00:00"`
+
+	// 最低日志级别
+	Level string `form:"level" binding:"omitempty,oneof=debug info warn error dpanic panic fatal"`
+
+	// 链路ID
+	TraceID string `form:"trace_id" binding:"omitempty,max=64"`
+
+	// 关键字
+	Keyword string `form:"keyword" binding:"omitempty,max=100"`
+
+	// 返回条数
+	Size int `form:"size" binding:"omitempty,gt=0,lte=1000"`
+}
+
+// Sources 返回需要查询的日志源
+func (req *ListLogRequest) Sources() []string {
+	if req.Source != "" {
+		return []string{req.Source}
+	}
+	return LogSources[:3]
+}
+
+// LogQuery 转换为日志检索条件, 时间按服务器本地时区解析
+func (req *ListLogRequest) LogQuery() (logsearch.Query, error) {
+	q := logsearch.Query{
+		Level:   req.Level,
+		TraceID: req.TraceID,
+		Keyword: req.Keyword,
+		Limit:   req.Size,
+	}
+	if req.StartTime != "" {
+		t, err := time.ParseInLocation(time.DateTime, req.StartTime, time.Local)
+		if err != nil {
+			return q, err
+		}
+		q.Start = t
+	}
+	if req.EndTime != "" {
+		t, err := time.ParseInLocation(time.DateTime, req.EndTime, time.Local)
+		if err != nil {
+			return q, err
+		}
+		q.End = t
+	}
+	return q, nil
+}
+
+type LogEntryOut struct {
+	// 日志源
+	Source string `json:"source" example:"service"`
+
+	// 时间
+	Time string `json:"time" example:"2025-01-01 12:00:00.000"`
+
+	// 日志级别
+	Level string `json:"level" example:"error"`
+
+	// 调用位置
+	Caller string `json:"caller" example:"handler/role.go:120"`
+
+	// 日志内容
+	Msg string `json:"msg" example:"更新角色失败"`
+
+	// 链路ID
+	TraceID string `json:"trace_id" example:"5f0c6a3e-1b2d-4c8f-9a7e-2d3c4b5a6f7e"`
+
+	// 其他字段
+	Fields map[string]any `json:"fields"`
+}
+
+// ListLogEntryReply 日志列表响应结构
+type ListLogEntryReply = common.APIReply[[]LogEntryOut]
+
+func LogEntryToOut(
+	e logsearch.Entry,
+) *LogEntryOut {
+	return &LogEntryOut{
+		Source:  e.Source,
+		Time:    e.Time.Local().Format(time.DateTime + ".000"),
+		Level:   e.Level,
+		Caller:  e.Caller,
+		Msg:     e.Msg,
+		TraceID: e.TraceID,
+		Fields:  e.Fields,
+	}
+}
+
+func ListLogEntryToOut(
+	es []logsearch.Entry,
+) *[]LogEntryOut {
+	mso := make([]LogEntryOut, 0, len(es))
+	for _, e := range es {
+		mso = append(mso, *LogEntryToOut(e))
+	}
+	return &mso
+}
+
+// SetLogLevelRequest 用于调整日志级别的请求结构体
+//
+// swagger:model SetLogLevelRequest
+type SetLogLevelRequest struct {
+	// 日志记录器(server/service/biz/data/cron), 为空时调整全部日志记录器
+	Logger string `json:"logger" binding:"omitempty,max=20" example:"biz"`
+
+	// 日志级别
+	Level string `json:"level" binding:"required,oneof=debug info warn error" example:"debug"`
+}
+
+// ResetLogLevelRequest 用于恢复日志级别的请求结构体
+//
+// swagger:model ResetLogLevelRequest
+type ResetLogLevelRequest struct {
+	// 日志记录器(server/service/biz/data/cron), 为空时恢复全部日志记录器
+	Logger string `form:"logger" binding:"omitempty,max=20" example:"biz"`
+}
+
+type LogLevelOut struct {
+	// 日志记录器
+	Logger string `json:"logger" example:"biz"`
+
+	// 当前日志级别
+	Level string `json:"level" example:"debug"`
+
+	// 启动时的日志级别
+	DefaultLevel string `json:"default_level" example:"info"`
+}
+
+// ListLogLevelReply 日志级别列表响应结构
+type ListLogLevelReply = common.APIReply[[]LogLevelOut]
@@ -0,0 +1,281 @@
+package system
+
+import (
+	"time"
+
+	"go.uber.org/zap/zapcore"
+
+	"gin-artweb/internal/model/common"
+	"gin-artweb/internal/shared/database"
+)
+
+// 维护窗口的作用范围
+const (
+	MaintenanceScopeGlobal = "global" // 全局, 暂停全部计划任务并屏蔽全部告警
+	MaintenanceScopeColony = "colony" // 集群, 暂停该集群的计划任务并屏蔽其mon节点告警
+	MaintenanceScopeNode   = "node"   // mon节点, 仅屏蔽该节点告警
+)
+
+// 维护窗口的重复周期
+const (
+	MaintenanceRecurrenceNone   = "none"   // 不重复
+	MaintenanceRecurrenceDaily  = "daily"  // 每天同一时段
+	MaintenanceRecurrenceWeekly = "weekly" // 每周同一时段
+)
+
+// 维护窗口审计记录
+const (
+	MaintenanceAuditResource      = "maintenance_window"
+	MaintenanceActionSkipSchedule = "skip_schedule" // 暂停计划任务
+	MaintenanceActionMuteAlert    = "mute_alert"    // 屏蔽告警
+)
+
+// MaintenanceWindowModel 维护窗口
+type MaintenanceWindowModel struct {
+	database.StandardModel
+	Name        string    `gorm:"column:name;type:varchar(50);not null;uniqueIndex;comment:名称" json:"name"`
+	Scope       string    `gorm:"column:scope;type:varchar(10);not null;index;comment:作用范围" json:"scope"`
+	Module      string    `gorm:"column:module;type:varchar(10);comment:所属模块" json:"module"`
+	TargetID    uint32    `gorm:"column:target_id;comment:集群或节点ID" json:"target_id"`
+	StartAt     time.Time `gorm:"column:start_at;not null;comment:开始时间" json:"start_at"`
+	EndAt       time.Time `gorm:"column:end_at;not null;comment:结束时间" json:"end_at"`
+	Recurrence  string    `gorm:"column:recurrence;type:varchar(10);not null;default:none;comment:重复周期" json:"recurrence"`
+	IsEnabled   bool      `gorm:"column:is_enabled;type:boolean;comment:是否启用" json:"is_enabled"`
+	Description string    `gorm:"column:description;type:varchar(254);comment:说明" json:"description"`
+	Username    string    `gorm:"column:username;type:varchar(50);comment:用户名" json:"username"`
+}
+
+func (m *MaintenanceWindowModel) TableName() string {
+	return "system_maintenance_window"
+}
+
+func (m *MaintenanceWindowModel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if m == nil {
+		return nil
+	}
+	if err := m.StandardModel.MarshalLogObject(enc); err != nil {
+		return err
+	}
+	enc.AddString("name", m.Name)
+	enc.AddString("scope", m.Scope)
+	enc.AddString("module", m.Module)
+	enc.AddUint32("target_id", m.TargetID)
+	enc.AddTime("start_at", m.StartAt)
+	enc.AddTime("end_at", m.EndAt)
+	enc.AddString("recurrence", m.Recurrence)
+	enc.AddBool("is_enabled", m.IsEnabled)
+	enc.AddString("username", m.Username)
+	return nil
+}
+
+// period 返回重复周期的间隔, 不重复时返回0
+func (m *MaintenanceWindowModel) period() time.Duration {
+	switch m.Recurrence {
+	case MaintenanceRecurrenceDaily:
+		return 24 * time.Hour
+	case MaintenanceRecurrenceWeekly:
+		return 7 * 24 * time.Hour
+	default:
+		return 0
+	}
+}
+
+// ActiveAt 判断维护窗口在指定时间是否生效
+//
+// 重复的窗口从StartAt开始, 每个周期在同一时段内生效, EndAt-StartAt为每次的持续时间
+func (m *MaintenanceWindowModel) ActiveAt(t time.Time) bool {
+	if !m.IsEnabled || t.Before(m.StartAt) {
+		return false
+	}
+	duration := m.EndAt.Sub(m.StartAt)
+	period := m.period()
+	if period == 0 {
+		return t.Before(m.EndAt)
+	}
+	offset := t.Sub(m.StartAt) % period
+	return offset < duration
+}
+
+// ValidRange 校验窗口时间范围, 重复窗口的持续时间不能超过重复周期
+func (m *MaintenanceWindowModel) ValidRange() bool {
+	if !m.EndAt.After(m.StartAt) {
+		return false
+	}
+	period := m.period()
+	return period == 0 || m.EndAt.Sub(m.StartAt) <= period
+}
+
+// MaintenanceAuditSnapshot 审计记录中保存的维护窗口字段
+func MaintenanceAuditSnapshot(m MaintenanceWindowModel) map[string]any {
+	return map[string]any{
+		"name":       m.Name,
+		"scope":      m.Scope,
+		"module":     m.Module,
+		"target_id":  m.TargetID,
+		"start_at":   m.StartAt.Format(time.DateTime),
+		"end_at":     m.EndAt.Format(time.DateTime),
+		"recurrence": m.Recurrence,
+		"is_enabled": m.IsEnabled,
+	}
+}
+
+// MaintenanceWindowRequest 用于创建和更新维护窗口的请求结构体
+//
+// swagger:model MaintenanceWindowRequest
+type MaintenanceWindowRequest struct {
+	// 名称
+	Name string `json:"name" binding:"required,max=50"`
+
+	// 作用范围(global/colony/node)
+	Scope string `json:"scope" binding:"required,oneof=global colony node"`
+
+	// 所属模块, 集群范围为oes/mds, 节点范围为mon
+	Module string `json:"module" binding:"omitempty,oneof=oes mds mon"`
+
+	// 集群或节点ID
+	TargetID uint32 `json:"target_id"`
+
+	// 开始时间
+	StartAt string `json:"start_at" binding:"required,datetime=2006-01-02 15:04:05" example:"2025-01-01 20:00:00"`
+
+	// 结束时间
+	EndAt string `json:"end_at" binding:"required,datetime=2006-01-02 15:04:05" example:"2025-01-01 22:00:00"`
+
+	// 重复周期(none/daily/weekly)
+	Recurrence string `json:"recurrence" binding:"omitempty,oneof=none daily weekly"`
+
+	// 是否启用
+	IsEnabled bool `json:"is_enabled"`
+
+	// 说明
+	Description string `json:"description" binding:"omitempty,max=254"`
+}
+
+// ListMaintenanceWindowRequest 用于查询维护窗口列表的请求结构体
+//
+// swagger:model ListMaintenanceWindowRequest
+type ListMaintenanceWindowRequest struct {
+	common.BaseModelQuery
+
+	// 名称
+	Name string `form:"name" binding:"omitempty,max=50"`
+
+	// 作用范围
+	Scope string `form:"scope" binding:"omitempty,oneof=global colony node"`
+
+	// 所属模块
+	Module string `form:"module" binding:"omitempty,oneof=oes mds mon"`
+
+	// 是否启用
+	IsEnabled *bool `form:"is_enabled"`
+}
+
+func (req *ListMaintenanceWindowRequest) Query() (int, int, map[string]any) {
+	page, size, query := req.BaseModelQuery.QueryMap(10)
+	if req.Name != "" {
+		query["name like ?"] = "%" + req.Name + "%"
+	}
+	if req.Scope != "" {
+		query["scope = ?"] = req.Scope
+	}
+	if req.Module != "" {
+		query["module = ?"] = req.Module
+	}
+	if req.IsEnabled != nil {
+		query["is_enabled = ?"] = *req.IsEnabled
+	}
+	return page, size, query
+}
+
+type MaintenanceWindowOut struct {
+	// ID
+	ID uint32 `json:"id" example:"1"`
+
+	// 名称
+	Name string `json:"name" example:"周末维护"`
+
+	// 作用范围
+	Scope string `json:"scope" example:"colony"`
+
+	// 所属模块
+	Module string `json:"module" example:"oes"`
+
+	// 集群或节点ID
+	TargetID uint32 `json:"target_id" example:"1"`
+
+	// 开始时间
+	StartAt string `json:"start_at" example:"2025-01-01 20:00:00"`
+
+	// 结束时间
+	EndAt string `json:"end_at" example:"2025-01-01 22:00:00"`
+
+	// 重复周期
+	Recurrence string `json:"recurrence" example:"weekly"`
+
+	// 是否启用
+	IsEnabled bool `json:"is_enabled" example:"true"`
+
+	// 当前是否生效
+	IsActive bool `json:"is_active" example:"false"`
+
+	// 说明
+	Description string `json:"description" example:""`
+
+	// 用户名
+	Username string `json:"username" example:"admin"`
+
+	// 创建时间
+	CreatedAt string `json:"created_at" example:"2023-01-01 12:00:00"`
+
+	// 更新时间
+	UpdatedAt string `json:"updated_at" example:"2023-01-01 12:00:00"`
+}
+
+// MaintenanceWindowReply 维护窗口响应结构
+type MaintenanceWindowReply = common.APIReply[MaintenanceWindowOut]
+
+// ListMaintenanceWindowReply 维护窗口列表响应结构
+type ListMaintenanceWindowReply = common.APIReply[[]MaintenanceWindowOut]
+
+// PagMaintenanceWindowReply 维护窗口的分页响应结构
+type PagMaintenanceWindowReply = common.APIReply[*common.Pag[MaintenanceWindowOut]]
+
+func MaintenanceWindowToOut(
+	m MaintenanceWindowModel,
+) *MaintenanceWindowOut {
+	recurrence := m.Recurrence
+	if recurrence == "" {
+		recurrence = MaintenanceRecurrenceNone
+	}
+	return &MaintenanceWindowOut{
+		ID:          m.ID,
+		Name:        m.Name,
+		Scope:       m.Scope,
+		Module:      m.Module,
+		TargetID:    m.TargetID,
+		StartAt:     m.StartAt.Format(time.DateTime),
+		EndAt:       m.EndAt.Format(time.DateTime),
+		Recurrence:  recurrence,
+		IsEnabled:   m.IsEnabled,
+		IsActive:    m.ActiveAt(time.Now()),
+		Description: m.Description,
+		Username:    m.Username,
+		CreatedAt:   m.CreatedAt.Format(time.DateTime),
+		UpdatedAt:   m.UpdatedAt.Format(time.DateTime),
+	}
+}
+
+func ListMaintenanceWindowToOut(
+	rms *[]MaintenanceWindowModel,
+) *[]MaintenanceWindowOut {
+	if rms == nil {
+		return &[]MaintenanceWindowOut{}
+	}
+
+	ms := *rms
+	mso := make([]MaintenanceWindowOut, 0, len(ms))
+	for _, m := range ms {
+		mso = append(mso, *MaintenanceWindowToOut(m))
+	}
+	return &mso
+}
@@ -0,0 +1,77 @@
+package system
+
+import (
+	"time"
+
+	"gin-artweb/internal/model/common"
+	"gin-artweb/internal/shared/database"
+)
+
+type MigrationOut struct {
+	// 迁移版本号
+	ID string `json:"id" example:"000001"`
+
+	// 说明
+	Description string `json:"description" example:"初始化数据库结构"`
+
+	// 是否已执行
+	Applied bool `json:"applied" example:"true"`
+
+	// 执行时间
+	AppliedAt string `json:"applied_at" example:"2023-01-01 12:00:00"`
+
+	// 是否为程序未知的迁移
+	Unknown bool `json:"unknown" example:"false"`
+}
+
+type SchemaStatusOut struct {
+	// 程序要求的数据库版本
+	Latest string `json:"latest" example:"000001"`
+
+	// 数据库当前版本
+	Current string `json:"current" example:"000001"`
+
+	// 未执行的迁移数量
+	Pending int `json:"pending" example:"0"`
+
+	// 数据库结构版本是否与程序一致
+	UpToDate bool `json:"up_to_date" example:"true"`
+
+	// 迁移列表
+	Migrations []MigrationOut `json:"migrations"`
+}
+
+// SchemaStatusReply 数据库结构版本响应结构
+type SchemaStatusReply = common.APIReply[SchemaStatusOut]
+
+func SchemaStatusToOut(
+	latest string,
+	statuses []database.MigrationStatus,
+) *SchemaStatusOut {
+	out := &SchemaStatusOut{
+		Latest:     latest,
+		UpToDate:   true,
+		Migrations: make([]MigrationOut, 0, len(statuses)),
+	}
+	for _, st := range statuses {
+		mo := MigrationOut{
+			ID:          st.ID,
+			Description: st.Description,
+			Applied:     st.Applied,
+			Unknown:     st.Unknown,
+		}
+		if st.AppliedAt != nil {
+			mo.AppliedAt = st.AppliedAt.Format(time.DateTime)
+		}
+		if st.Applied {
+			out.Current = st.ID
+		} else {
+			out.Pending++
+		}
+		if st.Unknown || !st.Applied {
+			out.UpToDate = false
+		}
+		out.Migrations = append(out.Migrations, mo)
+	}
+	return out
+}
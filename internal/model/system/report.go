@@ -0,0 +1,196 @@
+package system
+
+import (
+	"go.uber.org/zap/zapcore"
+
+	"gin-artweb/internal/model/common"
+	"gin-artweb/internal/shared/events"
+)
+
+// 报表周期
+const (
+	ReportPeriodDaily  = "daily"  // 日报, 统计一个自然日
+	ReportPeriodWeekly = "weekly" // 周报, 统计周一到周日
+)
+
+// 报表导出格式
+const (
+	ReportFormatCSV  = "csv"
+	ReportFormatXLSX = "xlsx"
+)
+
+// ReportJobCount 按集群和执行状态分组的脚本执行记录数
+type ReportJobCount struct {
+	Project   string `gorm:"column:project"`
+	ColonyNum string `gorm:"column:colony_num"`
+	Status    int    `gorm:"column:status"`
+	Count     int64  `gorm:"column:count"`
+}
+
+// ReportLoginCount 登录统计
+type ReportLoginCount struct {
+	Total   int64
+	Success int64
+	Failure int64
+	Users   int64 // 登录成功的去重用户数
+}
+
+func (m *ReportLoginCount) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if m == nil {
+		return nil
+	}
+	enc.AddInt64("total", m.Total)
+	enc.AddInt64("success", m.Success)
+	enc.AddInt64("failure", m.Failure)
+	enc.AddInt64("users", m.Users)
+	return nil
+}
+
+// ReportAlertRecord 统计期间内的告警, 发出的告警为发件箱中的告警事件内容,
+// 被维护窗口屏蔽的告警为屏蔽告警的审计详情, 两者都包含告警类型kind
+type ReportAlertRecord struct {
+	Payload string
+	Muted   bool
+}
+
+// ReportRequest 用于生成报表的请求结构体
+//
+// swagger:model ReportRequest
+type ReportRequest struct {
+	// 报表周期(daily:日报, weekly:周报)
+	Period string `form:"period" json:"period" binding:"required,oneof=daily weekly"`
+
+	// 统计日期, 日报为当天, 周报为所在的周, 为空时日报统计昨天, 周报统计上周
+	Date string `form:"date" json:"date" binding:"omitempty,datetime=2006-01-02"`
+}
+
+func (req *ReportRequest) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if req == nil {
+		return nil
+	}
+	enc.AddString("period", req.Period)
+	enc.AddString("date", req.Date)
+	return nil
+}
+
+// ExportReportRequest 用于导出报表的请求结构体
+//
+// swagger:model ExportReportRequest
+type ExportReportRequest struct {
+	ReportRequest
+
+	// 导出格式(csv, xlsx)
+	Format string `form:"format" json:"format" binding:"required,oneof=csv xlsx"`
+}
+
+// ReportFileUri 报表文件名路径参数
+type ReportFileUri struct {
+	// 文件名
+	Name string `uri:"name" binding:"required,max=255"`
+}
+
+type ReportJobOut struct {
+	// 项目(oes/mds)
+	Project string `json:"project" example:"oes"`
+
+	// 集群号
+	ColonyNum string `json:"colony_num" example:"01"`
+
+	// 执行总数
+	Total int64 `json:"total" example:"20"`
+
+	// 成功数
+	Success int64 `json:"success" example:"19"`
+
+	// 失败数, 包括失败、超时、崩溃和中断
+	Failed int64 `json:"failed" example:"1"`
+
+	// 成功率, 按已结束的执行计算, 没有已结束的执行时为0
+	SuccessRate float64 `json:"success_rate" example:"0.95"`
+}
+
+type ReportLoginOut struct {
+	// 登录总次数
+	Total int64 `json:"total" example:"120"`
+
+	// 登录成功次数
+	Success int64 `json:"success" example:"115"`
+
+	// 登录失败次数
+	Failure int64 `json:"failure" example:"5"`
+
+	// 登录成功的用户数
+	Users int64 `json:"users" example:"12"`
+}
+
+type ReportAlertOut struct {
+	// 告警类型
+	Kind string `json:"kind" example:"mon_node_health"`
+
+	// 发出次数
+	Fired int64 `json:"fired" example:"3"`
+
+	// 被维护窗口屏蔽的次数
+	Muted int64 `json:"muted" example:"1"`
+}
+
+type ReportOut struct {
+	// 报表周期
+	Period string `json:"period" example:"daily"`
+
+	// 统计开始时间(包含)
+	Start string `json:"start" example:"2023-01-01 00:00:00"`
+
+	// 统计结束时间(不包含)
+	End string `json:"end" example:"2023-01-02 00:00:00"`
+
+	// 生成时间
+	GeneratedAt string `json:"generated_at" example:"2023-01-02 08:00:00"`
+
+	// 各集群的任务执行统计
+	Jobs []ReportJobOut `json:"jobs"`
+
+	// 登录统计
+	Login ReportLoginOut `json:"login"`
+
+	// 各类型的告警统计
+	Alerts []ReportAlertOut `json:"alerts"`
+}
+
+// ReportReply 报表响应结构
+type ReportReply = common.APIReply[ReportOut]
+
+type ReportFileOut struct {
+	// 文件名
+	Name string `json:"name" example:"daily-20230101.xlsx"`
+
+	// 文件大小(字节)
+	Size int64 `json:"size" example:"6144"`
+
+	// 修改时间
+	ModTime string `json:"mod_time" example:"2023-01-02 08:00:00"`
+}
+
+// ListReportFileReply 定时报表文件列表响应结构
+type ListReportFileReply = common.APIReply[[]ReportFileOut]
+
+// ReportGeneratedEvent 定时报表生成事件, 通过webhook推送报表统计数据和报表文件名
+type ReportGeneratedEvent struct {
+	Name   string    `json:"name"`
+	Format string    `json:"format"`
+	File   string    `json:"file"`
+	Report ReportOut `json:"report"`
+}
+
+func (e ReportGeneratedEvent) EventType() string {
+	return events.ReportGenerated
+}
+
+func ReportLoginCountToOut(m ReportLoginCount) ReportLoginOut {
+	return ReportLoginOut{
+		Total:   m.Total,
+		Success: m.Success,
+		Failure: m.Failure,
+		Users:   m.Users,
+	}
+}
@@ -0,0 +1,52 @@
+package system
+
+import (
+	"gin-artweb/internal/model/common"
+)
+
+// SearchRequest 跨模块搜索的请求结构体
+//
+// swagger:model SearchRequest
+type SearchRequest struct {
+	// 关键字, 匹配名称和描述
+	Q string `form:"q" binding:"required,max=100"`
+
+	// 每组最多返回的结果数, 默认5
+	Limit int `form:"limit" binding:"omitempty,gt=0,lte=20"`
+}
+
+// SearchHitOut 单条搜索结果
+type SearchHitOut struct {
+	// 记录ID
+	ID uint32 `json:"id" example:"1"`
+
+	// 标题
+	Title string `json:"title" example:"日终清算.sh"`
+
+	// 描述
+	Description string `json:"description" example:"日终清算脚本"`
+
+	// 高亮的标题, 已转义HTML, 匹配部分用<em>标记
+	TitleHighlight string `json:"title_highlight" example:"<em>日终</em>清算.sh"`
+
+	// 高亮的描述, 已转义HTML, 匹配部分用<em>标记
+	DescriptionHighlight string `json:"description_highlight" example:"<em>日终</em>清算脚本"`
+}
+
+// SearchGroupOut 按资源类型分组的搜索结果
+type SearchGroupOut struct {
+	// 资源类型, 如user、script
+	Kind string `json:"kind" example:"script"`
+
+	// 资源名称
+	Label string `json:"label" example:"脚本"`
+
+	// 匹配的总数
+	Total int64 `json:"total" example:"12"`
+
+	// 搜索结果
+	Hits []SearchHitOut `json:"hits"`
+}
+
+// SearchReply 搜索的响应结构
+type SearchReply = common.APIReply[[]SearchGroupOut]
@@ -0,0 +1,163 @@
+package system
+
+import (
+	"time"
+
+	"go.uber.org/zap/zapcore"
+
+	"gin-artweb/internal/model/common"
+	"gin-artweb/internal/shared/database"
+)
+
+// SlowQueryModel 按语句指纹聚合的慢查询统计
+type SlowQueryModel struct {
+	database.BaseModel
+	Fingerprint string    `gorm:"column:fingerprint;type:varchar(40);not null;uniqueIndex;comment:语句指纹" json:"fingerprint"`
+	Statement   string    `gorm:"column:statement;type:text;comment:归一化后的语句" json:"statement"`
+	Table       string    `gorm:"column:table_name;type:varchar(64);index;comment:表名" json:"table_name"`
+	Count       int64     `gorm:"column:count;not null;default:0;comment:次数" json:"count"`
+	TotalMs     int64     `gorm:"column:total_ms;not null;default:0;comment:累计耗时(毫秒)" json:"total_ms"`
+	MaxMs       int64     `gorm:"column:max_ms;not null;default:0;comment:最大耗时(毫秒)" json:"max_ms"`
+	LastTraceID string    `gorm:"column:last_trace_id;type:varchar(64);comment:最近一次的链路ID" json:"last_trace_id"`
+	FirstSeenAt time.Time `gorm:"column:first_seen_at;comment:首次出现时间" json:"first_seen_at"`
+	LastSeenAt  time.Time `gorm:"column:last_seen_at;index;comment:最近出现时间" json:"last_seen_at"`
+}
+
+func (m *SlowQueryModel) TableName() string {
+	return "system_slow_query"
+}
+
+func (m *SlowQueryModel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if m == nil {
+		return nil
+	}
+	if err := m.BaseModel.MarshalLogObject(enc); err != nil {
+		return err
+	}
+	enc.AddString("fingerprint", m.Fingerprint)
+	enc.AddString("table_name", m.Table)
+	enc.AddInt64("count", m.Count)
+	enc.AddInt64("total_ms", m.TotalMs)
+	enc.AddInt64("max_ms", m.MaxMs)
+	enc.AddString("last_trace_id", m.LastTraceID)
+	enc.AddTime("last_seen_at", m.LastSeenAt)
+	return nil
+}
+
+// AvgMs 平均耗时(毫秒)
+func (m *SlowQueryModel) AvgMs() int64 {
+	if m.Count == 0 {
+		return 0
+	}
+	return m.TotalMs / m.Count
+}
+
+// 慢查询列表的排序方式
+var slowQueryOrderBy = map[string]string{
+	"max":   "max_ms DESC",
+	"count": "count DESC",
+	"avg":   "total_ms / count DESC",
+	"last":  "last_seen_at DESC",
+}
+
+// ListSlowQueryRequest 用于查询慢查询列表的请求结构体
+//
+// swagger:model ListSlowQueryRequest
+type ListSlowQueryRequest struct {
+	common.BaseModelQuery
+
+	// 表名
+	TableName string `form:"table_name" binding:"omitempty,max=64"`
+
+	// 最小的最大耗时(毫秒)
+	MinMs int64 `form:"min_ms" binding:"omitempty,gte=0"`
+
+	// 排序方式(max:最大耗时, count:次数, avg:平均耗时, last:最近出现)
+	OrderBy string `form:"order_by" binding:"omitempty,oneof=max count avg last"`
+}
+
+func (req *ListSlowQueryRequest) Query() (int, int, map[string]any) {
+	page, size, query := req.BaseModelQuery.QueryMap(10)
+	if req.TableName != "" {
+		query["table_name = ?"] = req.TableName
+	}
+	if req.MinMs > 0 {
+		query["max_ms >= ?"] = req.MinMs
+	}
+	return page, size, query
+}
+
+// Order 返回排序字段, 默认按最大耗时倒序
+func (req *ListSlowQueryRequest) Order() []string {
+	if order, ok := slowQueryOrderBy[req.OrderBy]; ok {
+		return []string{order}
+	}
+	return []string{slowQueryOrderBy["max"]}
+}
+
+type SlowQueryOut struct {
+	// ID
+	ID uint32 `json:"id" example:"1"`
+
+	// 语句指纹
+	Fingerprint string `json:"fingerprint" example:"5f1b0c3e9a7d2f4c6b8e0a1d3c5e7f9b1a2c4d6e"`
+
+	// 归一化后的语句
+	Statement string `json:"statement" example:"SELECT * FROM jobs_script_record WHERE status = ?"`
+
+	// 表名
+	TableName string `json:"table_name" example:"jobs_script_record"`
+
+	// 次数
+	Count int64 `json:"count" example:"12"`
+
+	// 最大耗时(毫秒)
+	MaxMs int64 `json:"max_ms" example:"1800"`
+
+	// 平均耗时(毫秒)
+	AvgMs int64 `json:"avg_ms" example:"760"`
+
+	// 最近一次的链路ID
+	LastTraceID string `json:"last_trace_id" example:"4bf92f3577b34da6a3ce929d0e0e4736"`
+
+	// 首次出现时间
+	FirstSeenAt string `json:"first_seen_at" example:"2023-01-01 12:00:00"`
+
+	// 最近出现时间
+	LastSeenAt string `json:"last_seen_at" example:"2023-01-01 12:00:00"`
+}
+
+// PagSlowQueryReply 慢查询的分页响应结构
+type PagSlowQueryReply = common.APIReply[*common.Pag[SlowQueryOut]]
+
+func SlowQueryToOut(
+	m SlowQueryModel,
+) *SlowQueryOut {
+	return &SlowQueryOut{
+		ID:          m.ID,
+		Fingerprint: m.Fingerprint,
+		Statement:   m.Statement,
+		TableName:   m.Table,
+		Count:       m.Count,
+		MaxMs:       m.MaxMs,
+		AvgMs:       m.AvgMs(),
+		LastTraceID: m.LastTraceID,
+		FirstSeenAt: m.FirstSeenAt.Format(time.DateTime),
+		LastSeenAt:  m.LastSeenAt.Format(time.DateTime),
+	}
+}
+
+func ListSlowQueryToOut(
+	rms *[]SlowQueryModel,
+) *[]SlowQueryOut {
+	if rms == nil {
+		return &[]SlowQueryOut{}
+	}
+
+	ms := *rms
+	mso := make([]SlowQueryOut, 0, len(ms))
+	for _, m := range ms {
+		mso = append(mso, *SlowQueryToOut(m))
+	}
+	return &mso
+}
@@ -0,0 +1,109 @@
+package system
+
+import (
+	"encoding/json"
+
+	"gin-artweb/internal/model/common"
+)
+
+// StatsJobDayCount 按日期和执行状态分组的脚本执行记录数
+type StatsJobDayCount struct {
+	Day    string `gorm:"column:day"`
+	Status int    `gorm:"column:status"`
+	Count  int64  `gorm:"column:count"`
+}
+
+// StatsColonyCount 按项目和启用状态分组的集群数
+type StatsColonyCount struct {
+	Project  string
+	IsEnable bool  `gorm:"column:is_enable"`
+	Count    int64 `gorm:"column:count"`
+}
+
+// StatsUserCount 用户数统计
+type StatsUserCount struct {
+	Total  int64 // 用户总数
+	Active int64 // 已激活的用户数
+	Login  int64 // 统计期间内登录成功的去重用户数
+}
+
+// StatsJobRequest 用于查询任务执行统计的请求结构体
+//
+// swagger:model StatsJobRequest
+type StatsJobRequest struct {
+	// 统计最近多少天(含今天), 默认7天
+	Days int `form:"days" binding:"omitempty,gt=0,lte=90"`
+}
+
+// StatsAlertRequest 用于查询最近告警的请求结构体
+//
+// swagger:model StatsAlertRequest
+type StatsAlertRequest struct {
+	// 返回的告警数, 默认10条
+	Limit int `form:"limit" binding:"omitempty,gt=0,lte=100"`
+}
+
+type StatsJobDayOut struct {
+	// 日期
+	Date string `json:"date" example:"2024-01-01"`
+
+	// 成功数
+	Success int64 `json:"success" example:"120"`
+
+	// 失败数, 包括失败、超时、崩溃和中断
+	Failure int64 `json:"failure" example:"3"`
+
+	// 待执行和执行中的数量
+	Running int64 `json:"running" example:"1"`
+}
+
+type StatsColonyOut struct {
+	// 项目(oes, mds)
+	Project string `json:"project" example:"oes"`
+
+	// 启用的集群数
+	Enabled int64 `json:"enabled" example:"8"`
+
+	// 停用的集群数
+	Disabled int64 `json:"disabled" example:"2"`
+}
+
+type StatsUserOut struct {
+	// 用户总数
+	Total int64 `json:"total" example:"50"`
+
+	// 已激活的用户数
+	Active int64 `json:"active" example:"45"`
+
+	// 统计期间内登录成功的用户数
+	Login int64 `json:"login" example:"20"`
+
+	// 统计天数
+	Days int `json:"days" example:"7"`
+}
+
+type StatsAlertOut struct {
+	// 事件ID
+	EventID string `json:"event_id" example:"0b7f7a6e-3c8e-4d6c-9a0e-1f2d3c4b5a69"`
+
+	// 告警类型
+	Kind string `json:"kind" example:"mon_node_health"`
+
+	// 发生时间
+	OccurredAt string `json:"occurred_at" example:"2024-01-01 12:00:00"`
+
+	// 告警内容, 不同类型的告警字段不同
+	Data json.RawMessage `json:"data" swaggertype:"object"`
+}
+
+// StatsJobReply 任务执行统计的响应结构
+type StatsJobReply = common.APIReply[[]StatsJobDayOut]
+
+// StatsColonyReply 集群启用统计的响应结构
+type StatsColonyReply = common.APIReply[[]StatsColonyOut]
+
+// StatsUserReply 用户统计的响应结构
+type StatsUserReply = common.APIReply[StatsUserOut]
+
+// StatsAlertReply 最近告警的响应结构
+type StatsAlertReply = common.APIReply[[]StatsAlertOut]
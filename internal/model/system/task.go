@@ -0,0 +1,215 @@
+package system
+
+import (
+	"encoding/json"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+
+	"gin-artweb/internal/model/common"
+	"gin-artweb/internal/shared/database"
+)
+
+// 后台任务状态
+const (
+	TaskStatusPending   = "pending"   // 等待执行
+	TaskStatusRunning   = "running"   // 执行中
+	TaskStatusSucceeded = "succeeded" // 执行成功
+	TaskStatusFailed    = "failed"    // 执行失败
+	TaskStatusCanceled  = "canceled"  // 已取消
+)
+
+// 后台任务类型
+const (
+	TaskKindReport = "report" // 生成报表文件
+)
+
+// TaskModel 后台任务, 记录耗时操作的进度和结果
+//
+// 任务在提交它的进程内执行, 取消请求通过取消任务上下文通知执行方, 执行方在检查点主动退出
+type TaskModel struct {
+	database.StandardModel
+	Kind       string     `gorm:"column:kind;type:varchar(50);not null;index;comment:任务类型" json:"kind"`
+	Name       string     `gorm:"column:name;type:varchar(254);not null;comment:任务名称" json:"name"`
+	Status     string     `gorm:"column:status;type:varchar(20);not null;index;comment:状态" json:"status"`
+	Progress   int        `gorm:"column:progress;not null;default:0;comment:进度百分比" json:"progress"`
+	Message    string     `gorm:"column:message;type:varchar(254);comment:当前步骤" json:"message"`
+	Result     string     `gorm:"column:result;type:text;comment:执行结果(JSON)" json:"result"`
+	Error      string     `gorm:"column:error;type:text;comment:错误信息(JSON)" json:"error"`
+	UserID     uint32     `gorm:"column:user_id;index;comment:提交用户ID" json:"user_id"`
+	Username   string     `gorm:"column:username;type:varchar(50);comment:提交用户名" json:"username"`
+	StartedAt  *time.Time `gorm:"column:started_at;comment:开始执行时间" json:"started_at"`
+	FinishedAt *time.Time `gorm:"column:finished_at;comment:结束时间" json:"finished_at"`
+}
+
+func (m *TaskModel) TableName() string {
+	return "system_task"
+}
+
+func (m *TaskModel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if m == nil {
+		return nil
+	}
+	if err := m.StandardModel.MarshalLogObject(enc); err != nil {
+		return err
+	}
+	enc.AddString("kind", m.Kind)
+	enc.AddString("name", m.Name)
+	enc.AddString("status", m.Status)
+	enc.AddInt("progress", m.Progress)
+	enc.AddString("message", m.Message)
+	enc.AddUint32("user_id", m.UserID)
+	enc.AddString("username", m.Username)
+	return nil
+}
+
+// Finished 任务是否已结束
+func (m *TaskModel) Finished() bool {
+	switch m.Status {
+	case TaskStatusSucceeded, TaskStatusFailed, TaskStatusCanceled:
+		return true
+	default:
+		return false
+	}
+}
+
+// ListTaskRequest 用于查询后台任务列表的请求结构体
+//
+// swagger:model ListTaskRequest
+type ListTaskRequest struct {
+	common.BaseModelQuery
+
+	// 任务类型
+	Kind string `form:"kind" binding:"omitempty,max=50"`
+
+	// 状态
+	Status string `form:"status" binding:"omitempty,oneof=pending running succeeded failed canceled"`
+
+	// 提交用户名
+	Username string `form:"username" binding:"omitempty,max=50"`
+}
+
+func (req *ListTaskRequest) Query() (int, int, map[string]any) {
+	page, size, query := req.BaseModelQuery.QueryMap(10)
+	if req.Kind != "" {
+		query["kind = ?"] = req.Kind
+	}
+	if req.Status != "" {
+		query["status = ?"] = req.Status
+	}
+	if req.Username != "" {
+		query["username = ?"] = req.Username
+	}
+	return page, size, query
+}
+
+// TaskFilterFields 后台任务列表允许使用filter参数过滤的字段
+var TaskFilterFields = database.FilterFields{
+	"id":          {Column: "id", Type: database.FilterInt},
+	"kind":        {Column: "kind", Type: database.FilterString},
+	"name":        {Column: "name", Type: database.FilterString},
+	"status":      {Column: "status", Type: database.FilterString},
+	"progress":    {Column: "progress", Type: database.FilterInt},
+	"user_id":     {Column: "user_id", Type: database.FilterInt},
+	"username":    {Column: "username", Type: database.FilterString},
+	"created_at":  {Column: "created_at", Type: database.FilterTime},
+	"started_at":  {Column: "started_at", Type: database.FilterTime},
+	"finished_at": {Column: "finished_at", Type: database.FilterTime},
+}
+
+type TaskOut struct {
+	// ID
+	ID uint32 `json:"id" example:"1"`
+
+	// 任务类型
+	Kind string `json:"kind" example:"report"`
+
+	// 任务名称
+	Name string `json:"name" example:"生成日报 2023-01-01"`
+
+	// 状态(pending-等待执行,running-执行中,succeeded-成功,failed-失败,canceled-已取消)
+	Status string `json:"status" example:"running"`
+
+	// 进度百分比
+	Progress int `json:"progress" example:"60"`
+
+	// 当前步骤
+	Message string `json:"message" example:"导出报表文件"`
+
+	// 执行结果
+	Result json.RawMessage `json:"result" swaggertype:"object"`
+
+	// 错误信息
+	Error json.RawMessage `json:"error" swaggertype:"object"`
+
+	// 提交用户名
+	Username string `json:"username" example:"admin"`
+
+	// 开始执行时间
+	StartedAt string `json:"started_at" example:"2023-01-01 12:00:00"`
+
+	// 结束时间
+	FinishedAt string `json:"finished_at" example:""`
+
+	// 创建时间
+	CreatedAt string `json:"created_at" example:"2023-01-01 12:00:00"`
+}
+
+// TaskReply 后台任务响应结构
+type TaskReply = common.APIReply[TaskOut]
+
+// PagTaskReply 后台任务的分页响应结构
+type PagTaskReply = common.APIReply[*common.Pag[TaskOut]]
+
+func TaskToOut(
+	m TaskModel,
+) *TaskOut {
+	var startedAt, finishedAt string
+	if m.StartedAt != nil {
+		startedAt = m.StartedAt.Format(time.DateTime)
+	}
+	if m.FinishedAt != nil {
+		finishedAt = m.FinishedAt.Format(time.DateTime)
+	}
+	return &TaskOut{
+		ID:         m.ID,
+		Kind:       m.Kind,
+		Name:       m.Name,
+		Status:     m.Status,
+		Progress:   m.Progress,
+		Message:    m.Message,
+		Result:     rawJSON(m.Result),
+		Error:      rawJSON(m.Error),
+		Username:   m.Username,
+		StartedAt:  startedAt,
+		FinishedAt: finishedAt,
+		CreatedAt:  m.CreatedAt.Format(time.DateTime),
+	}
+}
+
+func ListTaskToOut(
+	rms *[]TaskModel,
+) *[]TaskOut {
+	if rms == nil {
+		return &[]TaskOut{}
+	}
+
+	ms := *rms
+	mso := make([]TaskOut, 0, len(ms))
+	for _, m := range ms {
+		mso = append(mso, *TaskToOut(m))
+	}
+	return &mso
+}
+
+// rawJSON 空字符串转为null, 保存的结果不是合法JSON时按字符串返回
+func rawJSON(s string) json.RawMessage {
+	if s == "" {
+		return json.RawMessage("null")
+	}
+	if json.Valid([]byte(s)) {
+		return json.RawMessage(s)
+	}
+	b, _ := json.Marshal(s)
+	return b
+}
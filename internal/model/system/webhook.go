@@ -0,0 +1,339 @@
+package system
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+
+	"gin-artweb/internal/model/common"
+	"gin-artweb/internal/shared/database"
+)
+
+// WebhookEventAll 订阅全部事件
+const WebhookEventAll = "*"
+
+// WebhookEventTest 测试推送使用的事件类型
+const WebhookEventTest = "webhook.test"
+
+// WebhookTestEvent 测试推送的事件内容
+type WebhookTestEvent struct {
+	WebhookID uint32 `json:"webhook_id"`
+	Name      string `json:"name"`
+	Username  string `json:"username"`
+}
+
+func (e WebhookTestEvent) EventType() string {
+	return WebhookEventTest
+}
+
+// webhook推送状态
+const (
+	WebhookDeliveryPending = 0 // 待推送, 包括等待重试
+	WebhookDeliverySuccess = 1 // 推送成功
+	WebhookDeliveryFailed  = 2 // 重试次数用尽后推送失败
+)
+
+// WebhookSubscriptionModel webhook订阅
+type WebhookSubscriptionModel struct {
+	database.StandardModel
+	Name        string `gorm:"column:name;type:varchar(50);not null;uniqueIndex;comment:名称" json:"name"`
+	URL         string `gorm:"column:url;type:varchar(512);not null;comment:推送地址" json:"url"`
+	Secret      string `gorm:"column:secret;type:varchar(512);serializer:encrypted;comment:签名密钥" json:"-"`
+	Events      string `gorm:"column:events;type:varchar(512);not null;comment:订阅的事件类型(逗号分隔,*表示全部)" json:"events"`
+	IsEnabled   bool   `gorm:"column:is_enabled;type:boolean;comment:是否启用" json:"is_enabled"`
+	Description string `gorm:"column:description;type:varchar(254);comment:说明" json:"description"`
+	Username    string `gorm:"column:username;type:varchar(50);comment:用户名" json:"username"`
+}
+
+func (m *WebhookSubscriptionModel) TableName() string {
+	return "system_webhook_subscription"
+}
+
+func (m *WebhookSubscriptionModel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if m == nil {
+		return nil
+	}
+	if err := m.StandardModel.MarshalLogObject(enc); err != nil {
+		return err
+	}
+	enc.AddString("name", m.Name)
+	enc.AddString("url", m.URL)
+	enc.AddString("events", m.Events)
+	enc.AddBool("is_enabled", m.IsEnabled)
+	enc.AddString("username", m.Username)
+	return nil
+}
+
+// EventList 返回订阅的事件类型
+func (m *WebhookSubscriptionModel) EventList() []string {
+	if m.Events == "" {
+		return []string{}
+	}
+	return strings.Split(m.Events, ",")
+}
+
+// Subscribes 判断是否订阅了指定类型的事件
+func (m *WebhookSubscriptionModel) Subscribes(eventType string) bool {
+	events := m.EventList()
+	return slices.Contains(events, WebhookEventAll) || slices.Contains(events, eventType)
+}
+
+// WebhookDeliveryModel webhook推送记录
+type WebhookDeliveryModel struct {
+	database.StandardModel
+	SubscriptionID uint32     `gorm:"column:subscription_id;not null;index;comment:订阅ID" json:"subscription_id"`
+	EventID        string     `gorm:"column:event_id;type:varchar(36);not null;index;comment:事件ID" json:"event_id"`
+	EventType      string     `gorm:"column:event_type;type:varchar(50);not null;comment:事件类型" json:"event_type"`
+	Payload        string     `gorm:"column:payload;type:text;comment:推送内容" json:"payload"`
+	Status         int        `gorm:"column:status;type:tinyint;not null;default:0;index:idx_webhook_delivery_status_retry;comment:推送状态(0-待推送,1-成功,2-失败)" json:"status"`
+	Attempts       int        `gorm:"column:attempts;not null;default:0;comment:已推送次数" json:"attempts"`
+	ResponseCode   int        `gorm:"column:response_code;comment:最近一次响应状态码" json:"response_code"`
+	ErrorMessage   string     `gorm:"column:error_message;type:varchar(512);comment:最近一次错误信息" json:"error_message"`
+	NextRetryAt    *time.Time `gorm:"column:next_retry_at;index:idx_webhook_delivery_status_retry;comment:下次推送时间" json:"next_retry_at"`
+	DeliveredAt    *time.Time `gorm:"column:delivered_at;comment:推送成功时间" json:"delivered_at"`
+}
+
+func (m *WebhookDeliveryModel) TableName() string {
+	return "system_webhook_delivery"
+}
+
+func (m *WebhookDeliveryModel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if m == nil {
+		return nil
+	}
+	if err := m.StandardModel.MarshalLogObject(enc); err != nil {
+		return err
+	}
+	enc.AddUint32("subscription_id", m.SubscriptionID)
+	enc.AddString("event_id", m.EventID)
+	enc.AddString("event_type", m.EventType)
+	enc.AddInt("status", m.Status)
+	enc.AddInt("attempts", m.Attempts)
+	enc.AddInt("response_code", m.ResponseCode)
+	enc.AddString("error_message", m.ErrorMessage)
+	return nil
+}
+
+// WebhookSubscriptionRequest 用于创建和更新webhook订阅的请求结构体
+//
+// swagger:model WebhookSubscriptionRequest
+type WebhookSubscriptionRequest struct {
+	// 名称
+	Name string `json:"name" binding:"required,max=50"`
+
+	// 推送地址
+	URL string `json:"url" binding:"required,url,max=512"`
+
+	// 签名密钥, 更新时为空表示不修改
+	Secret string `json:"secret" binding:"omitempty,min=16,max=256"`
+
+	// 订阅的事件类型, *表示全部
+	Events []string `json:"events" binding:"required,min=1,dive,oneof=* user.created colony.updated job.finished alert.fired report.generated"`
+
+	// 是否启用
+	IsEnabled bool `json:"is_enabled"`
+
+	// 说明
+	Description string `json:"description" binding:"omitempty,max=254"`
+}
+
+// ListWebhookSubscriptionRequest 用于查询webhook订阅列表的请求结构体
+//
+// swagger:model ListWebhookSubscriptionRequest
+type ListWebhookSubscriptionRequest struct {
+	common.BaseModelQuery
+
+	// 名称
+	Name string `form:"name" binding:"omitempty,max=50"`
+
+	// 是否启用
+	IsEnabled *bool `form:"is_enabled"`
+}
+
+func (req *ListWebhookSubscriptionRequest) Query() (int, int, map[string]any) {
+	page, size, query := req.BaseModelQuery.QueryMap(10)
+	if req.Name != "" {
+		query["name like ?"] = "%" + req.Name + "%"
+	}
+	if req.IsEnabled != nil {
+		query["is_enabled = ?"] = *req.IsEnabled
+	}
+	return page, size, query
+}
+
+// ListWebhookDeliveryRequest 用于查询webhook推送记录的请求结构体
+//
+// swagger:model ListWebhookDeliveryRequest
+type ListWebhookDeliveryRequest struct {
+	common.BaseModelQuery
+
+	// 事件类型
+	EventType string `form:"event_type" binding:"omitempty,max=50"`
+
+	// 推送状态(0-待推送,1-成功,2-失败)
+	Status *int `form:"status" binding:"omitempty,oneof=0 1 2"`
+}
+
+func (req *ListWebhookDeliveryRequest) Query() (int, int, map[string]any) {
+	page, size, query := req.BaseModelQuery.QueryMap(10)
+	if req.EventType != "" {
+		query["event_type = ?"] = req.EventType
+	}
+	if req.Status != nil {
+		query["status = ?"] = *req.Status
+	}
+	return page, size, query
+}
+
+type WebhookSubscriptionOut struct {
+	// ID
+	ID uint32 `json:"id" example:"1"`
+
+	// 名称
+	Name string `json:"name" example:"运维告警"`
+
+	// 推送地址
+	URL string `json:"url" example:"https://hooks.example.com/artweb"`
+
+	// 订阅的事件类型
+	Events []string `json:"events" example:"job.finished,alert.fired"`
+
+	// 是否启用
+	IsEnabled bool `json:"is_enabled" example:"true"`
+
+	// 说明
+	Description string `json:"description" example:""`
+
+	// 用户名
+	Username string `json:"username" example:"admin"`
+
+	// 创建时间
+	CreatedAt string `json:"created_at" example:"2023-01-01 12:00:00"`
+
+	// 更新时间
+	UpdatedAt string `json:"updated_at" example:"2023-01-01 12:00:00"`
+}
+
+type WebhookDeliveryOut struct {
+	// ID
+	ID uint32 `json:"id" example:"1"`
+
+	// 订阅ID
+	SubscriptionID uint32 `json:"subscription_id" example:"1"`
+
+	// 事件ID
+	EventID string `json:"event_id" example:"0b9c5a3e-6f1d-4c2b-9a8e-7d6f5e4c3b2a"`
+
+	// 事件类型
+	EventType string `json:"event_type" example:"job.finished"`
+
+	// 推送内容
+	Payload string `json:"payload" example:"{}"`
+
+	// 推送状态(0-待推送,1-成功,2-失败)
+	Status int `json:"status" example:"1"`
+
+	// 已推送次数
+	Attempts int `json:"attempts" example:"1"`
+
+	// 最近一次响应状态码
+	ResponseCode int `json:"response_code" example:"200"`
+
+	// 最近一次错误信息
+	ErrorMessage string `json:"error_message" example:""`
+
+	// 下次推送时间
+	NextRetryAt string `json:"next_retry_at" example:""`
+
+	// 推送成功时间
+	DeliveredAt string `json:"delivered_at" example:"2023-01-01 12:00:00"`
+
+	// 创建时间
+	CreatedAt string `json:"created_at" example:"2023-01-01 12:00:00"`
+}
+
+// WebhookSubscriptionReply webhook订阅响应结构
+type WebhookSubscriptionReply = common.APIReply[WebhookSubscriptionOut]
+
+// PagWebhookSubscriptionReply webhook订阅的分页响应结构
+type PagWebhookSubscriptionReply = common.APIReply[*common.Pag[WebhookSubscriptionOut]]
+
+// WebhookDeliveryReply webhook推送记录响应结构
+type WebhookDeliveryReply = common.APIReply[WebhookDeliveryOut]
+
+// PagWebhookDeliveryReply webhook推送记录的分页响应结构
+type PagWebhookDeliveryReply = common.APIReply[*common.Pag[WebhookDeliveryOut]]
+
+func WebhookSubscriptionToOut(
+	m WebhookSubscriptionModel,
+) *WebhookSubscriptionOut {
+	return &WebhookSubscriptionOut{
+		ID:          m.ID,
+		Name:        m.Name,
+		URL:         m.URL,
+		Events:      m.EventList(),
+		IsEnabled:   m.IsEnabled,
+		Description: m.Description,
+		Username:    m.Username,
+		CreatedAt:   m.CreatedAt.Format(time.DateTime),
+		UpdatedAt:   m.UpdatedAt.Format(time.DateTime),
+	}
+}
+
+func ListWebhookSubscriptionToOut(
+	rms *[]WebhookSubscriptionModel,
+) *[]WebhookSubscriptionOut {
+	if rms == nil {
+		return &[]WebhookSubscriptionOut{}
+	}
+
+	ms := *rms
+	mso := make([]WebhookSubscriptionOut, 0, len(ms))
+	for _, m := range ms {
+		mso = append(mso, *WebhookSubscriptionToOut(m))
+	}
+	return &mso
+}
+
+func WebhookDeliveryToOut(
+	m WebhookDeliveryModel,
+) *WebhookDeliveryOut {
+	var nextRetryAt, deliveredAt string
+	if m.NextRetryAt != nil {
+		nextRetryAt = m.NextRetryAt.Format(time.DateTime)
+	}
+	if m.DeliveredAt != nil {
+		deliveredAt = m.DeliveredAt.Format(time.DateTime)
+	}
+	return &WebhookDeliveryOut{
+		ID:             m.ID,
+		SubscriptionID: m.SubscriptionID,
+		EventID:        m.EventID,
+		EventType:      m.EventType,
+		Payload:        m.Payload,
+		Status:         m.Status,
+		Attempts:       m.Attempts,
+		ResponseCode:   m.ResponseCode,
+		ErrorMessage:   m.ErrorMessage,
+		NextRetryAt:    nextRetryAt,
+		DeliveredAt:    deliveredAt,
+		CreatedAt:      m.CreatedAt.Format(time.DateTime),
+	}
+}
+
+func ListWebhookDeliveryToOut(
+	rms *[]WebhookDeliveryModel,
+) *[]WebhookDeliveryOut {
+	if rms == nil {
+		return &[]WebhookDeliveryOut{}
+	}
+
+	ms := *rms
+	mso := make([]WebhookDeliveryOut, 0, len(ms))
+	for _, m := range ms {
+		mso = append(mso, *WebhookDeliveryToOut(m))
+	}
+	return &mso
+}
@@ -72,40 +72,36 @@ func NewApiRepo(
 func (r *ApiRepo) CreateModel(ctx context.Context, m *custmodel.ApiModel) error {
 	if m == nil {
 		err := errors.New("创建API模型失败: 模型为空")
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"创建API模型失败: 模型为空",
 			zap.Error(err),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return err
 	}
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"开始创建API模型",
 		zap.Object(database.ModelKey, m),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	now := time.Now()
 	m.CreatedAt = now
 	m.UpdatedAt = now
 
-	dbCtx, cancel := context.WithTimeout(ctx, r.timeouts.WriteTimeout)
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
 	defer cancel()
 	if err := database.DBCreate(dbCtx, r.gormDB, &custmodel.ApiModel{}, m, nil); err != nil {
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"创建API模型失败",
 			zap.Error(err),
 			zap.Object(database.ModelKey, m),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			zap.Duration(log.DurationKey, time.Since(now)),
 		)
 		return errors.WrapIf(err, "创建API模型失败")
 	}
 
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"创建API模型成功",
 		zap.Object(database.ModelKey, m),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		zap.Duration(log.DurationKey, time.Since(now)),
 	)
 	return nil
@@ -131,43 +127,39 @@ func (r *ApiRepo) CreateModel(ctx context.Context, m *custmodel.ApiModel) error
 func (r *ApiRepo) UpdateModel(ctx context.Context, data map[string]any, conds ...any) error {
 	if len(data) == 0 {
 		err := errors.New("更新API模型失败: 更新数据为空")
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"更新API模型失败: 更新数据为空",
 			zap.Error(err),
 			zap.Any(database.UpdateDataKey, data),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return err
 	}
 
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"开始更新API模型",
 		zap.Any(database.UpdateDataKey, data),
 		zap.Any(database.ConditionsKey, conds),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	now := time.Now()
 	data["updated_at"] = now
-	dbCtx, cancel := context.WithTimeout(ctx, r.timeouts.WriteTimeout)
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
 	defer cancel()
 	if err := database.DBUpdate(dbCtx, r.gormDB, &custmodel.ApiModel{}, data, nil, conds...); err != nil {
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"更新API模型失败",
 			zap.Error(err),
 			zap.Any(database.UpdateDataKey, data),
 			zap.Any(database.ConditionsKey, conds),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			zap.Duration(log.DurationKey, time.Since(now)),
 		)
 		return errors.WrapIf(err, "更新API模型失败")
 	}
 
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"更新API模型成功",
 		zap.Any(database.UpdateDataKey, data),
 		zap.Any(database.ConditionsKey, conds),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		zap.Duration(log.DurationKey, time.Since(now)),
 	)
 	return nil
@@ -188,30 +180,27 @@ func (r *ApiRepo) UpdateModel(ctx context.Context, data map[string]any, conds ..
 //  1. 执行数据库删除操作
 //  2. 记录操作日志
 func (r *ApiRepo) DeleteModel(ctx context.Context, conds ...any) error {
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"开始删除API模型",
 		zap.Any(database.ConditionsKey, conds),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	now := time.Now()
-	dbCtx, cancel := context.WithTimeout(ctx, r.timeouts.WriteTimeout)
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
 	defer cancel()
 	if err := database.DBDelete(dbCtx, r.gormDB, &custmodel.ApiModel{}, conds...); err != nil {
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"删除API模型失败",
 			zap.Error(err),
 			zap.Any(database.ConditionsKey, conds),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			zap.Duration(log.DurationKey, time.Since(now)),
 		)
 		return errors.WrapIf(err, "删除API模型失败")
 	}
 
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"删除API模型成功",
 		zap.Any(database.ConditionsKey, conds),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		zap.Duration(log.DurationKey, time.Since(now)),
 	)
 	return nil
@@ -237,32 +226,29 @@ func (r *ApiRepo) GetModel(
 	ctx context.Context,
 	conds ...any,
 ) (*custmodel.ApiModel, error) {
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"开始获取API模型",
 		zap.Any(database.ConditionsKey, conds),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	now := time.Now()
 	var m custmodel.ApiModel
-	dbCtx, cancel := context.WithTimeout(ctx, r.timeouts.ReadTimeout)
+	dbCtx, cancel := database.ReadContext(ctx, r.timeouts)
 	defer cancel()
 	if err := database.DBGet(dbCtx, r.gormDB, nil, &m, conds...); err != nil {
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"获取API模型失败",
 			zap.Error(err),
 			zap.Any(database.ConditionsKey, conds),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			zap.Duration(log.DurationKey, time.Since(now)),
 		)
 		return nil, errors.WrapIf(err, "获取API模型失败")
 	}
 
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"获取API模型成功",
 		zap.Object(database.ModelKey, &m),
 		zap.Any(database.ConditionsKey, conds),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		zap.Duration(log.DurationKey, time.Since(now)),
 	)
 	return &m, nil
@@ -290,32 +276,29 @@ func (r *ApiRepo) ListModel(
 	ctx context.Context,
 	qp database.QueryParams,
 ) (int64, *[]custmodel.ApiModel, error) {
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"开始查询API模型列表",
 		zap.Object(database.QueryParamsKey, &qp),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	now := time.Now()
 	var ms []custmodel.ApiModel
-	dbCtx, cancel := context.WithTimeout(ctx, r.timeouts.ListTimeout)
+	dbCtx, cancel := database.ListContext(database.WithQueryTimeout(ctx, qp.Timeout), r.timeouts)
 	defer cancel()
 	count, err := database.DBList(dbCtx, r.gormDB, &custmodel.ApiModel{}, &ms, qp)
 	if err != nil {
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"查询API模型列表失败",
 			zap.Error(err),
 			zap.Object(database.QueryParamsKey, &qp),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			zap.Duration(log.DurationKey, time.Since(now)),
 		)
 		return 0, nil, errors.WrapIf(err, "查询API模型列表失败")
 	}
 
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"查询API模型列表成功",
 		zap.Object(database.QueryParamsKey, &qp),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		zap.Duration(log.DurationKey, time.Since(now)),
 	)
 	return count, &ms, nil
@@ -347,10 +330,9 @@ func (r *ApiRepo) AddPolicy(
 		return errors.WrapIf(ctx.Err(), "AddPolicy操作失败: 上下文错误")
 	}
 
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"AddPolicy: 传入参数",
 		zap.Object(database.ModelKey, &m),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	// 检查API模型的有效性
@@ -364,29 +346,26 @@ func (r *ApiRepo) AddPolicy(
 		return errors.New("添加API策略失败: 请求方法不能为空")
 	}
 
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"开始添加API策略",
 		zap.Object(database.ModelKey, &m),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	now := time.Now()
 	sub := auth.ApiToSubject(m.ID)
 	rules := [][]string{{sub, m.URL, m.Method}}
 	if err := auth.AddPolicies(ctx, r.enforcer, rules); err != nil {
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"添加API策略失败",
 			zap.Error(err),
 			zap.Object(database.ModelKey, &m),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			zap.Duration(log.DurationKey, time.Since(now)),
 		)
 		return errors.WrapIf(err, "添加API策略失败")
 	}
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"添加API策略成功",
 		zap.Object(database.ModelKey, &m),
 		zap.String(auth.SubKey, sub),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		zap.Duration(log.DurationKey, time.Since(now)),
 	)
 	return nil
@@ -421,11 +400,10 @@ func (r *ApiRepo) RemovePolicy(
 		return errors.WrapIf(ctx.Err(), "RemovePolicy操作失败: 上下文错误")
 	}
 
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"RemovePolicy: 传入参数",
 		zap.Object(database.ModelKey, &m),
 		zap.Bool("removeInherited", removeInherited),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	// 检查API模型的有效性
@@ -439,61 +417,55 @@ func (r *ApiRepo) RemovePolicy(
 		return errors.New("删除API策略失败: 请求方法不能为空")
 	}
 
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"开始删除API策略",
 		zap.Object(database.ModelKey, &m),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	now := time.Now()
 	sub := auth.ApiToSubject(m.ID)
 	rules := [][]string{{sub, m.URL, m.Method}}
 	if err := auth.RemovePolicies(ctx, r.enforcer, rules); err != nil {
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"删除API策略失败",
 			zap.Error(err),
 			zap.Object(database.ModelKey, &m),
 			zap.String(auth.SubKey, sub),
 			zap.String(auth.ObjKey, m.URL),
 			zap.String(auth.ActKey, m.Method),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			zap.Duration(log.DurationKey, time.Since(now)),
 		)
 		return errors.WrapIf(err, "删除API策略失败")
 	}
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"删除API策略成功",
 		zap.Object(database.ModelKey, &m),
 		zap.String(auth.SubKey, sub),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		zap.Duration(log.DurationKey, time.Since(now)),
 	)
 
 	// 如果需要删除继承该API的组策略
 	if removeInherited {
 		now = time.Now()
-		r.log.Debug(
+		ctxutil.Logger(ctx, r.log).Debug(
 			"开始删除继承该API的组策略",
 			zap.Object(database.ModelKey, &m),
 			zap.String(auth.GroupObjKey, sub),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			zap.Duration(log.DurationKey, time.Since(now)),
 		)
 		if err := auth.RemoveFilteredGroupingPolicy(ctx, r.enforcer, 1, sub); err != nil {
-			r.log.Error(
+			ctxutil.Logger(ctx, r.log).Error(
 				"删除继承该API的组策略失败",
 				zap.Error(err),
 				zap.Object(database.ModelKey, &m),
 				zap.String(auth.GroupObjKey, sub),
-				zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 				zap.Duration(log.DurationKey, time.Since(now)),
 			)
 			return errors.WrapIf(err, "删除继承该API的组策略失败")
 		}
-		r.log.Debug(
+		ctxutil.Logger(ctx, r.log).Debug(
 			"删除继承该API的组策略成功",
 			zap.Object(database.ModelKey, &m),
 			zap.String(auth.GroupObjKey, sub),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			zap.Duration(log.DurationKey, time.Since(now)),
 		)
 	}
@@ -79,18 +79,16 @@ func (r *ButtonRepo) CreateModel(
 	// 检查参数
 	if m == nil {
 		err := errors.New("创建按钮模型失败: 模型为空")
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"创建按钮模型失败: 模型为空",
 			zap.Error(err),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return err
 	}
 
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"开始创建按钮模型",
 		zap.Object(database.ModelKey, m),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	now := time.Now()
@@ -104,21 +102,21 @@ func (r *ButtonRepo) CreateModel(
 		}
 	}
 
-	if err := database.DBCreate(ctx, r.gormDB, &custmodel.ButtonModel{}, m, upmap); err != nil {
-		r.log.Error(
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBCreate(dbCtx, r.gormDB, &custmodel.ButtonModel{}, m, upmap); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
 			"创建按钮模型失败",
 			zap.Error(err),
 			zap.Object(database.ModelKey, m),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			zap.Duration(log.DurationKey, time.Since(now)),
 		)
 		return errors.WrapIf(err, "创建按钮模型失败")
 	}
 
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"创建按钮模型成功",
 		zap.Object(database.ModelKey, m),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		zap.Duration(log.DurationKey, time.Since(now)),
 	)
 	return nil
@@ -150,20 +148,18 @@ func (r *ButtonRepo) UpdateModel(
 ) error {
 	if len(data) == 0 {
 		err := errors.New("更新按钮模型失败: 更新数据为空")
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"更新按钮模型失败: 更新数据为空",
 			zap.Error(err),
 			zap.Any(database.UpdateDataKey, data),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return err
 	}
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"开始更新按钮模型",
 		zap.Any(database.UpdateDataKey, data),
 		zap.Any(database.ConditionsKey, conds),
 		zap.Uint32s("apis", ListApiModelToUint32s(apis)),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	now := time.Now()
@@ -176,25 +172,25 @@ func (r *ButtonRepo) UpdateModel(
 		}
 	}
 
-	if err := database.DBUpdate(ctx, r.gormDB, &custmodel.ButtonModel{}, data, upmap, conds...); err != nil {
-		r.log.Error(
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBUpdate(dbCtx, r.gormDB, &custmodel.ButtonModel{}, data, upmap, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
 			"更新按钮模型失败",
 			zap.Error(err),
 			zap.Any(database.UpdateDataKey, data),
 			zap.Uint32s("apis", ListApiModelToUint32s(apis)),
 			zap.Any(database.ConditionsKey, conds),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			zap.Duration(log.DurationKey, time.Since(now)),
 		)
 		return errors.WrapIf(err, "更新按钮模型失败")
 	}
 
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"更新按钮模型成功",
 		zap.Any(database.UpdateDataKey, data),
 		zap.Uint32s("apis", ListApiModelToUint32s(apis)),
 		zap.Any(database.ConditionsKey, conds),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		zap.Duration(log.DurationKey, time.Since(now)),
 	)
 	return nil
@@ -215,28 +211,27 @@ func (r *ButtonRepo) UpdateModel(
 //  1. 执行数据库删除操作
 //  2. 记录操作日志
 func (r *ButtonRepo) DeleteModel(ctx context.Context, conds ...any) error {
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"开始删除按钮模型",
 		zap.Any(database.ConditionsKey, conds),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	now := time.Now()
-	if err := database.DBDelete(ctx, r.gormDB, &custmodel.ButtonModel{}, conds...); err != nil {
-		r.log.Error(
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBDelete(dbCtx, r.gormDB, &custmodel.ButtonModel{}, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
 			"删除按钮模型失败",
 			zap.Error(err),
 			zap.Any(database.ConditionsKey, conds),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			zap.Duration(log.DurationKey, time.Since(now)),
 		)
 		return errors.WrapIf(err, "删除按钮模型失败")
 	}
 
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"删除按钮模型成功",
 		zap.Any(database.ConditionsKey, conds),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		zap.Duration(log.DurationKey, time.Since(now)),
 	)
 	return nil
@@ -265,33 +260,32 @@ func (r *ButtonRepo) GetModel(
 	preloads []string,
 	conds ...any,
 ) (*custmodel.ButtonModel, error) {
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"开始查询按钮模型",
 		zap.Strings(database.PreloadKey, preloads),
 		zap.Any(database.ConditionsKey, conds),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	now := time.Now()
 	var m custmodel.ButtonModel
-	if err := database.DBGet(ctx, r.gormDB, preloads, &m, conds...); err != nil {
-		r.log.Error(
+	dbCtx, cancel := database.ReadContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBGet(dbCtx, r.gormDB, preloads, &m, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
 			"查询按钮模型失败",
 			zap.Error(err),
 			zap.Strings(database.PreloadKey, preloads),
 			zap.Any(database.ConditionsKey, conds),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			zap.Duration(log.DurationKey, time.Since(now)),
 		)
 		return nil, errors.WrapIf(err, "查询按钮模型失败")
 	}
 
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"查询按钮模型成功",
 		zap.Object(database.ModelKey, &m),
 		zap.Strings(database.PreloadKey, preloads),
 		zap.Any(database.ConditionsKey, conds),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		zap.Duration(log.DurationKey, time.Since(now)),
 	)
 	return &m, nil
@@ -319,30 +313,29 @@ func (r *ButtonRepo) ListModel(
 	ctx context.Context,
 	qp database.QueryParams,
 ) (int64, *[]custmodel.ButtonModel, error) {
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"开始查询按钮模型列表",
 		zap.Object(database.QueryParamsKey, &qp),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	now := time.Now()
 	var ms []custmodel.ButtonModel
-	count, err := database.DBList(ctx, r.gormDB, &custmodel.ButtonModel{}, &ms, qp)
+	dbCtx, cancel := database.ListContext(database.WithQueryTimeout(ctx, qp.Timeout), r.timeouts)
+	defer cancel()
+	count, err := database.DBList(dbCtx, r.gormDB, &custmodel.ButtonModel{}, &ms, qp)
 	if err != nil {
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"查询按钮模型列表失败",
 			zap.Error(err),
 			zap.Object(database.QueryParamsKey, &qp),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			zap.Duration(log.DurationKey, time.Since(now)),
 		)
 		return 0, nil, errors.WrapIf(err, "查询按钮模型列表失败")
 	}
 
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"查询按钮模型列表成功",
 		zap.Object(database.QueryParamsKey, &qp),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		zap.Duration(log.DurationKey, time.Since(now)),
 	)
 	return count, &ms, nil
@@ -390,10 +383,9 @@ func (r *ButtonRepo) AddGroupPolicy(
 		return errors.New("AddGroupPolicy操作失败: 菜单ID不能为0")
 	}
 
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"开始添加按钮关联策略",
 		zap.Object(database.ModelKey, button),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	now := time.Now()
@@ -403,11 +395,10 @@ func (r *ButtonRepo) AddGroupPolicy(
 	for i, o := range m.Apis {
 		// 检查API模型的有效性
 		if o.ID == 0 {
-			r.log.Warn(
+			ctxutil.Logger(ctx, r.log).Warn(
 				"跳过无效API",
 				zap.Object(database.ModelKey, button),
 				zap.Int("Api_index", i),
-				zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			)
 			continue
 		}
@@ -416,23 +407,21 @@ func (r *ButtonRepo) AddGroupPolicy(
 		rules = append(rules, []string{sub, obj})
 	}
 	if err := auth.AddGroupPolicies(ctx, r.enforcer, rules); err != nil {
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"添加按钮关联策略失败",
 			zap.Error(err),
 			zap.Object(database.ModelKey, button),
 			zap.String(auth.GroupSubKey, sub),
 			zap.String(auth.GroupObjKey, menuObj),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			zap.Duration(log.DurationKey, time.Since(now)),
 		)
 		return errors.WrapIf(err, "添加按钮关联策略失败")
 	}
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"添加按钮关联策略成功",
 		zap.Object(database.ModelKey, button),
 		zap.String(auth.GroupSubKey, sub),
 		zap.String(auth.GroupObjKey, menuObj),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		zap.Duration(log.DurationKey, time.Since(now)),
 	)
 	return nil
@@ -479,59 +468,53 @@ func (r *ButtonRepo) RemoveGroupPolicy(
 		return errors.New("RemoveGroupPolicy操作失败: 按钮ID不能为0")
 	}
 
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"开始删除该按钮作为子级的组策略",
 		zap.Object(database.ModelKey, button),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	rmSubStartTime := time.Now()
 	sub := auth.ButtonToSubject(m.ID)
 	// 删除该按钮作为子级的策略（被其他策略继承）
 	if err := auth.RemoveFilteredGroupingPolicy(ctx, r.enforcer, 0, sub); err != nil {
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"删除按钮作为子级策略失败(该策略继承自其他策略)",
 			zap.Error(err),
 			zap.Object(database.ModelKey, button),
 			zap.String(auth.GroupSubKey, sub),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			zap.Duration(log.DurationKey, time.Since(rmSubStartTime)),
 		)
 		return errors.WrapIf(err, "删除按钮作为子级策略失败(该策略继承自其他策略)")
 	}
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"删除该按钮作为子级的组策略成功",
 		zap.Object(database.ModelKey, button),
 		zap.String(auth.GroupSubKey, sub),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		zap.Duration(log.DurationKey, time.Since(rmSubStartTime)),
 	)
 
 	// 删除该按钮作为父级的策略（被其他菜单或权限继承）
 	if removeInherited {
-		r.log.Debug(
+		ctxutil.Logger(ctx, r.log).Debug(
 			"开始删除该按钮作为父级的组策略",
 			zap.Object(database.ModelKey, button),
 			zap.String(auth.GroupObjKey, sub),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		rmObjStartTime := time.Now()
 		if err := auth.RemoveFilteredGroupingPolicy(ctx, r.enforcer, 1, sub); err != nil {
-			r.log.Error(
+			ctxutil.Logger(ctx, r.log).Error(
 				"删除按钮作为父级策略失败(该策略被其他策略继承)",
 				zap.Error(err),
 				zap.Object(database.ModelKey, button),
 				zap.String(auth.GroupObjKey, sub),
-				zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 				zap.Duration(log.DurationKey, time.Since(rmObjStartTime)),
 			)
 			return errors.WrapIf(err, "删除按钮作为父级策略失败(该策略被其他策略继承)")
 		}
-		r.log.Debug(
+		ctxutil.Logger(ctx, r.log).Debug(
 			"删除该按钮作为父级的组策略成功",
 			zap.Object(database.ModelKey, button),
 			zap.String(auth.GroupObjKey, sub),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			zap.Duration(log.DurationKey, time.Since(rmObjStartTime)),
 		)
 	}
@@ -0,0 +1,193 @@
+package customer
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	custmodel "gin-artweb/internal/model/customer"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/log"
+)
+
+// CasbinModelRepo Casbin模型配置仓库实现
+// 每次修改都会保存为新的版本，同一时刻只有一个版本处于生效状态
+type CasbinModelRepo struct {
+	log      *zap.Logger       // 日志记录器
+	gormDB   *gorm.DB          // GORM数据库连接
+	timeouts *config.DBTimeout // 数据库操作超时配置
+}
+
+// NewCasbinModelRepo 创建Casbin模型配置仓库实例
+func NewCasbinModelRepo(
+	log *zap.Logger,
+	gormDB *gorm.DB,
+	timeouts *config.DBTimeout,
+) *CasbinModelRepo {
+	return &CasbinModelRepo{
+		log:      log,
+		gormDB:   gormDB,
+		timeouts: timeouts,
+	}
+}
+
+// CreateActiveModel 保存新版本的模型配置并将其设置为生效版本
+// 版本号在事务内按当前最大版本号递增生成
+func (r *CasbinModelRepo) CreateActiveModel(ctx context.Context, m *custmodel.CasbinModelModel) error {
+	// 检查参数
+	if m == nil {
+		err := errors.New("创建Casbin模型配置失败: 模型为空")
+		ctxutil.Logger(ctx, r.log).Error(
+			"创建Casbin模型配置失败: 模型为空",
+			zap.Error(err),
+		)
+		return err
+	}
+
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始创建Casbin模型配置",
+		zap.Object(database.ModelKey, m),
+	)
+	startTime := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	err := database.Conn(dbCtx, r.gormDB).Transaction(func(tx *gorm.DB) error {
+		var maxVersion uint32
+		if err := tx.Model(&custmodel.CasbinModelModel{}).
+			Select("COALESCE(MAX(version), 0)").
+			Scan(&maxVersion).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&custmodel.CasbinModelModel{}).
+			Where("is_active = ?", true).
+			Update("is_active", false).Error; err != nil {
+			return err
+		}
+		m.Version = maxVersion + 1
+		m.IsActive = true
+		return tx.Create(m).Error
+	})
+	if err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"创建Casbin模型配置失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, m),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return errors.WrapIf(err, "创建Casbin模型配置失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"创建Casbin模型配置成功",
+		zap.Object(database.ModelKey, m),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return nil
+}
+
+// ActivateModel 将指定ID的版本设置为生效版本
+func (r *CasbinModelRepo) ActivateModel(ctx context.Context, pk uint32) error {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始切换Casbin模型配置生效版本",
+		zap.Uint32("pk", pk),
+	)
+	startTime := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	err := database.Conn(dbCtx, r.gormDB).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&custmodel.CasbinModelModel{}).
+			Where("is_active = ?", true).
+			Update("is_active", false).Error; err != nil {
+			return err
+		}
+		result := tx.Model(&custmodel.CasbinModelModel{}).
+			Where("id = ?", pk).
+			Update("is_active", true)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"切换Casbin模型配置生效版本失败",
+			zap.Error(err),
+			zap.Uint32("pk", pk),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return errors.WrapIf(err, "切换Casbin模型配置生效版本失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"切换Casbin模型配置生效版本成功",
+		zap.Uint32("pk", pk),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return nil
+}
+
+func (r *CasbinModelRepo) GetModel(
+	ctx context.Context,
+	conds ...any,
+) (*custmodel.CasbinModelModel, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始查询Casbin模型配置",
+		zap.Any(database.ConditionsKey, conds),
+	)
+	startTime := time.Now()
+	var m custmodel.CasbinModelModel
+	dbCtx, cancel := database.ReadContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBGet(dbCtx, r.gormDB, nil, &m, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"查询Casbin模型配置失败",
+			zap.Error(err),
+			zap.Any(database.ConditionsKey, conds),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return nil, errors.WrapIf(err, "查询Casbin模型配置失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"查询Casbin模型配置成功",
+		zap.Object(database.ModelKey, &m),
+		zap.Any(database.ConditionsKey, conds),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return &m, nil
+}
+
+func (r *CasbinModelRepo) ListModel(
+	ctx context.Context,
+	qp database.QueryParams,
+) (int64, *[]custmodel.CasbinModelModel, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始查询Casbin模型配置列表",
+		zap.Object(database.QueryParamsKey, &qp),
+	)
+	startTime := time.Now()
+	var ms []custmodel.CasbinModelModel
+	dbCtx, cancel := database.ListContext(database.WithQueryTimeout(ctx, qp.Timeout), r.timeouts)
+	defer cancel()
+	count, err := database.DBList(dbCtx, r.gormDB, &custmodel.CasbinModelModel{}, &ms, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"查询Casbin模型配置列表失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return 0, nil, errors.WrapIf(err, "查询Casbin模型配置列表失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"查询Casbin模型配置列表成功",
+		zap.Object(database.QueryParamsKey, &qp),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return count, &ms, nil
+}
@@ -0,0 +1,102 @@
+package customer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	custmodel "gin-artweb/internal/model/customer"
+	"gin-artweb/internal/shared/auth"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/test"
+)
+
+func CreateTestCasbinModelModel(remark string) *custmodel.CasbinModelModel {
+	return &custmodel.CasbinModelModel{
+		Content:  auth.DefaultCasbinModel,
+		Remark:   remark,
+		Operator: "admin",
+	}
+}
+
+type CasbinModelTestSuite struct {
+	suite.Suite
+	modelRepo *CasbinModelRepo
+}
+
+func (suite *CasbinModelTestSuite) SetupTest() {
+	db := test.NewTestGormDBWithConfig(nil)
+	db.AutoMigrate(&custmodel.CasbinModelModel{})
+	dbTimeout := test.NewTestDBTimeouts()
+	logger := test.NewTestZapLogger()
+	suite.modelRepo = &CasbinModelRepo{
+		log:      logger,
+		gormDB:   db,
+		timeouts: dbTimeout,
+	}
+}
+
+func (suite *CasbinModelTestSuite) TestCreateActiveModel() {
+	m1 := CreateTestCasbinModelModel("v1")
+	err := suite.modelRepo.CreateActiveModel(context.Background(), m1)
+	suite.NoError(err, "创建Casbin模型配置应该成功")
+	suite.Equal(uint32(1), m1.Version, "第一个版本号应该为1")
+
+	m2 := CreateTestCasbinModelModel("v2")
+	err = suite.modelRepo.CreateActiveModel(context.Background(), m2)
+	suite.NoError(err, "创建第二个Casbin模型配置应该成功")
+	suite.Equal(uint32(2), m2.Version, "版本号应该递增")
+
+	active, err := suite.modelRepo.GetModel(context.Background(), "is_active = ?", true)
+	suite.NoError(err, "查询生效版本应该成功")
+	suite.Equal(m2.ID, active.ID, "最新创建的版本应该生效")
+
+	old, err := suite.modelRepo.GetModel(context.Background(), m1.ID)
+	suite.NoError(err)
+	suite.False(old.IsActive, "旧版本应该失效")
+
+	// 测试边界情况：创建空模型
+	err = suite.modelRepo.CreateActiveModel(context.Background(), nil)
+	suite.Error(err, "创建空模型应该返回错误")
+}
+
+func (suite *CasbinModelTestSuite) TestActivateModel() {
+	m1 := CreateTestCasbinModelModel("v1")
+	suite.NoError(suite.modelRepo.CreateActiveModel(context.Background(), m1))
+	m2 := CreateTestCasbinModelModel("v2")
+	suite.NoError(suite.modelRepo.CreateActiveModel(context.Background(), m2))
+
+	err := suite.modelRepo.ActivateModel(context.Background(), m1.ID)
+	suite.NoError(err, "切换生效版本应该成功")
+
+	active, err := suite.modelRepo.GetModel(context.Background(), "is_active = ?", true)
+	suite.NoError(err)
+	suite.Equal(m1.ID, active.ID, "指定版本应该生效")
+
+	// 测试边界情况：版本不存在
+	err = suite.modelRepo.ActivateModel(context.Background(), 99999)
+	suite.Error(err, "切换到不存在的版本应该返回错误")
+}
+
+func (suite *CasbinModelTestSuite) TestListModel() {
+	for _, remark := range []string{"v1", "v2", "v3"} {
+		suite.NoError(suite.modelRepo.CreateActiveModel(context.Background(), CreateTestCasbinModelModel(remark)))
+	}
+
+	qp := database.QueryParams{
+		IsCount: true,
+		Size:    2,
+		Page:    1,
+		OrderBy: []string{"version DESC"},
+	}
+	total, ms, err := suite.modelRepo.ListModel(context.Background(), qp)
+	suite.NoError(err, "查询Casbin模型配置列表应该成功")
+	suite.Equal(int64(3), total)
+	suite.Len(*ms, 2)
+	suite.Equal(uint32(3), (*ms)[0].Version, "应该按版本号倒序")
+}
+
+func TestCasbinModelTestSuite(t *testing.T) {
+	suite.Run(t, new(CasbinModelTestSuite))
+}
@@ -0,0 +1,419 @@
+package customer
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	custmodel "gin-artweb/internal/model/customer"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/log"
+)
+
+// DepartmentRepo 部门仓库实现
+// 负责部门模型的CRUD操作以及部门路径的维护
+// 使用GORM进行数据库操作
+type DepartmentRepo struct {
+	log      *zap.Logger       // 日志记录器
+	gormDB   *gorm.DB          // GORM数据库连接
+	timeouts *config.DBTimeout // 数据库操作超时配置
+}
+
+// NewDepartmentRepo 创建部门仓库实例
+//
+// 参数：
+//
+//	log: 日志记录器，用于记录操作日志
+//	gormDB: GORM数据库连接，用于执行数据库操作
+//	timeouts: 数据库操作超时配置，控制各类数据库操作的超时时间
+//
+// 返回值：
+//
+//	DepartmentRepo: 部门仓库接口实现
+func NewDepartmentRepo(
+	log *zap.Logger,
+	gormDB *gorm.DB,
+	timeouts *config.DBTimeout,
+) *DepartmentRepo {
+	return &DepartmentRepo{
+		log:      log,
+		gormDB:   gormDB,
+		timeouts: timeouts,
+	}
+}
+
+// CreateModel 创建部门模型
+//
+// 参数：
+//
+//	ctx: 上下文，用于传递请求信息和控制超时
+//	m: 部门模型，Parent为父部门，为空时创建根部门
+//
+// 返回值：
+//
+//	error: 操作错误信息，成功则返回nil
+//
+// 功能：
+//  1. 检查部门模型是否为空
+//  2. 在同一事务中创建部门并根据父部门路径和新部门ID写入部门路径
+//  3. 记录操作日志
+func (r *DepartmentRepo) CreateModel(ctx context.Context, m *custmodel.DepartmentModel) error {
+	// 检查参数
+	if m == nil {
+		err := errors.New("创建部门模型失败: 模型为空")
+		ctxutil.Logger(ctx, r.log).Error(
+			"创建部门模型失败: 模型为空",
+			zap.Error(err),
+		)
+		return err
+	}
+
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始创建部门模型",
+		zap.Object(database.ModelKey, m),
+	)
+	now := time.Now()
+	m.CreatedAt = now
+	m.UpdatedAt = now
+
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	err := database.Conn(dbCtx, r.gormDB).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(m).Error; err != nil {
+			return err
+		}
+		m.Path = custmodel.DepartmentPath(m.Parent, m.ID)
+		return tx.Model(&custmodel.DepartmentModel{}).Where("id = ?", m.ID).Update("path", m.Path).Error
+	})
+	if err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"创建部门模型失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, m),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return errors.WrapIf(err, "创建部门模型失败")
+	}
+
+	ctxutil.Logger(ctx, r.log).Debug(
+		"创建部门模型成功",
+		zap.Object(database.ModelKey, m),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return nil
+}
+
+// UpdateModel 更新部门模型
+//
+// 参数：
+//
+//	ctx: 上下文，用于传递请求信息和控制超时
+//	data: 更新数据映射，不应包含父部门ID和部门路径，调整上级部门使用MoveModel
+//	conds: 查询条件
+//
+// 返回值：
+//
+//	error: 操作错误信息，成功则返回nil
+func (r *DepartmentRepo) UpdateModel(ctx context.Context, data map[string]any, conds ...any) error {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始更新部门模型",
+		zap.Any(database.UpdateDataKey, data),
+		zap.Any(database.ConditionsKey, conds),
+	)
+
+	now := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBUpdate(dbCtx, r.gormDB, &custmodel.DepartmentModel{}, data, nil, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"更新部门模型失败",
+			zap.Error(err),
+			zap.Any(database.UpdateDataKey, data),
+			zap.Any(database.ConditionsKey, conds),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return errors.WrapIf(err, "更新部门模型失败")
+	}
+
+	ctxutil.Logger(ctx, r.log).Debug(
+		"更新部门模型成功",
+		zap.Any(database.UpdateDataKey, data),
+		zap.Any(database.ConditionsKey, conds),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return nil
+}
+
+// MoveModel 将部门及其下级部门整体移动到新的父部门下
+//
+// 参数：
+//
+//	ctx: 上下文，用于传递请求信息和控制超时
+//	m: 需要移动的部门，Path为移动前的部门路径
+//	parent: 新的父部门，为空时移动为根部门
+//
+// 返回值：
+//
+//	error: 操作错误信息，成功则返回nil
+//
+// 功能：
+//  1. 在同一事务中更新部门的父部门ID
+//  2. 将部门及其下级部门路径中的原前缀替换为新前缀
+//  3. 移动成功后更新m的父部门ID和部门路径
+func (r *DepartmentRepo) MoveModel(
+	ctx context.Context,
+	m *custmodel.DepartmentModel,
+	parent *custmodel.DepartmentModel,
+) error {
+	// 检查参数
+	if m == nil || m.Path == "" {
+		err := errors.New("移动部门失败: 模型为空或部门路径为空")
+		ctxutil.Logger(ctx, r.log).Error(
+			"移动部门失败: 模型为空或部门路径为空",
+			zap.Error(err),
+		)
+		return err
+	}
+
+	oldPath := m.Path
+	newPath := custmodel.DepartmentPath(parent, m.ID)
+	var parentID *uint32
+	if parent != nil {
+		parentID = &parent.ID
+	}
+
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始移动部门",
+		zap.Object(database.ModelKey, m),
+		zap.String("old_path", oldPath),
+		zap.String("new_path", newPath),
+	)
+
+	now := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	err := database.Conn(dbCtx, r.gormDB).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&custmodel.DepartmentModel{}).Where("id = ?", m.ID).Updates(map[string]any{
+			"parent_id":  parentID,
+			"updated_at": now,
+		}).Error; err != nil {
+			return err
+		}
+
+		// 不同数据库的字符串拼接函数不同, 逐个更新子树中的部门路径
+		var subtree []custmodel.DepartmentModel
+		if err := tx.Select("id", "path").Where("path LIKE ?", oldPath+"%").Find(&subtree).Error; err != nil {
+			return err
+		}
+		for _, d := range subtree {
+			path := newPath + strings.TrimPrefix(d.Path, oldPath)
+			if err := tx.Model(&custmodel.DepartmentModel{}).Where("id = ?", d.ID).Update("path", path).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"移动部门失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, m),
+			zap.String("old_path", oldPath),
+			zap.String("new_path", newPath),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return errors.WrapIf(err, "移动部门失败")
+	}
+
+	m.ParentID = parentID
+	m.Parent = parent
+	m.Path = newPath
+	ctxutil.Logger(ctx, r.log).Debug(
+		"移动部门成功",
+		zap.Object(database.ModelKey, m),
+		zap.String("old_path", oldPath),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return nil
+}
+
+// DeleteModel 删除部门模型
+//
+// 参数：
+//
+//	ctx: 上下文，用于传递请求信息和控制超时
+//	conds: 查询条件
+//
+// 返回值：
+//
+//	error: 操作错误信息，成功则返回nil
+func (r *DepartmentRepo) DeleteModel(ctx context.Context, conds ...any) error {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始删除部门模型",
+		zap.Any(database.ConditionsKey, conds),
+	)
+
+	now := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBDelete(dbCtx, r.gormDB, &custmodel.DepartmentModel{}, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"删除部门模型失败",
+			zap.Error(err),
+			zap.Any(database.ConditionsKey, conds),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return errors.WrapIf(err, "删除部门模型失败")
+	}
+
+	ctxutil.Logger(ctx, r.log).Debug(
+		"删除部门模型成功",
+		zap.Any(database.ConditionsKey, conds),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return nil
+}
+
+// GetModel 查询单个部门模型
+//
+// 参数：
+//
+//	ctx: 上下文，用于传递请求信息和控制超时
+//	preloads: 需要预加载的关联关系
+//	conds: 查询条件
+//
+// 返回值：
+//
+//	*custmodel.DepartmentModel: 查询到的部门模型指针
+//	error: 操作错误信息，成功则返回nil
+func (r *DepartmentRepo) GetModel(
+	ctx context.Context,
+	preloads []string,
+	conds ...any,
+) (*custmodel.DepartmentModel, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始查询部门模型",
+		zap.Strings(database.PreloadKey, preloads),
+		zap.Any(database.ConditionsKey, conds),
+	)
+
+	now := time.Now()
+	var m custmodel.DepartmentModel
+	dbCtx, cancel := database.ReadContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBGet(dbCtx, r.gormDB, preloads, &m, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"查询部门模型失败",
+			zap.Error(err),
+			zap.Strings(database.PreloadKey, preloads),
+			zap.Any(database.ConditionsKey, conds),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return nil, errors.WrapIf(err, "查询部门模型失败")
+	}
+
+	ctxutil.Logger(ctx, r.log).Debug(
+		"查询部门模型成功",
+		zap.Object(database.ModelKey, &m),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return &m, nil
+}
+
+// ListModel 查询部门模型列表
+//
+// 参数：
+//
+//	ctx: 上下文，用于传递请求信息和控制超时
+//	qp: 查询参数，包含分页、排序、过滤条件等
+//
+// 返回值：
+//
+//	int64: 总记录数
+//	*[]custmodel.DepartmentModel: 部门模型列表指针
+//	error: 操作错误信息，成功则返回nil
+func (r *DepartmentRepo) ListModel(
+	ctx context.Context,
+	qp database.QueryParams,
+) (int64, *[]custmodel.DepartmentModel, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始查询部门模型列表",
+		zap.Object(database.QueryParamsKey, &qp),
+	)
+
+	now := time.Now()
+	var ms []custmodel.DepartmentModel
+	dbCtx, cancel := database.ListContext(database.WithQueryTimeout(ctx, qp.Timeout), r.timeouts)
+	defer cancel()
+	count, err := database.DBList(dbCtx, r.gormDB, &custmodel.DepartmentModel{}, &ms, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"查询部门模型列表失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return 0, nil, errors.WrapIf(err, "查询部门模型列表失败")
+	}
+
+	ctxutil.Logger(ctx, r.log).Debug(
+		"查询部门模型列表成功",
+		zap.Object(database.QueryParamsKey, &qp),
+		zap.Int64("count", count),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return count, &ms, nil
+}
+
+// SubtreeIDs 查询部门及其全部下级部门的ID
+//
+// 参数：
+//
+//	ctx: 上下文，用于传递请求信息和控制超时
+//	path: 部门路径
+//
+// 返回值：
+//
+//	[]uint32: 部门ID列表，包含部门本身
+//	error: 操作错误信息，成功则返回nil
+func (r *DepartmentRepo) SubtreeIDs(ctx context.Context, path string) ([]uint32, error) {
+	if path == "" {
+		return nil, errors.New("查询下级部门失败: 部门路径为空")
+	}
+
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始查询下级部门ID",
+		zap.String("path", path),
+	)
+
+	now := time.Now()
+	var ids []uint32
+	dbCtx, cancel := database.ReadContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.Conn(dbCtx, r.gormDB).Model(&custmodel.DepartmentModel{}).
+		Where("path LIKE ?", path+"%").
+		Pluck("id", &ids).Error; err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"查询下级部门ID失败",
+			zap.Error(err),
+			zap.String("path", path),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return nil, errors.WrapIf(err, "查询下级部门ID失败")
+	}
+
+	ctxutil.Logger(ctx, r.log).Debug(
+		"查询下级部门ID成功",
+		zap.String("path", path),
+		zap.Int("count", len(ids)),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return ids, nil
+}
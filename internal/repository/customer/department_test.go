@@ -0,0 +1,118 @@
+package customer
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	custmodel "gin-artweb/internal/model/customer"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/test"
+)
+
+// CreateTestDepartmentModel 创建测试用的部门模型
+func CreateTestDepartmentModel(parent *custmodel.DepartmentModel) *custmodel.DepartmentModel {
+	m := &custmodel.DepartmentModel{
+		Name:   uuid.NewString()[:20],
+		Sort:   1,
+		Descr:  "这是一个测试部门",
+		Parent: parent,
+	}
+	if parent != nil {
+		m.ParentID = &parent.ID
+	}
+	return m
+}
+
+type DepartmentTestSuite struct {
+	suite.Suite
+	deptRepo *DepartmentRepo
+}
+
+func (suite *DepartmentTestSuite) SetupSuite() {
+	db := test.NewTestGormDBWithConfig(nil)
+	db.AutoMigrate(&custmodel.DepartmentModel{})
+	suite.deptRepo = NewDepartmentRepo(test.NewTestZapLogger(), db, test.NewTestDBTimeouts())
+}
+
+func TestDepartmentTestSuite(t *testing.T) {
+	suite.Run(t, new(DepartmentTestSuite))
+}
+
+func (suite *DepartmentTestSuite) TestCreateSetsPath() {
+	ctx := context.Background()
+	root := CreateTestDepartmentModel(nil)
+	suite.NoError(suite.deptRepo.CreateModel(ctx, root))
+	suite.Equal(fmt.Sprintf("/%d/", root.ID), root.Path)
+
+	child := CreateTestDepartmentModel(root)
+	suite.NoError(suite.deptRepo.CreateModel(ctx, child))
+	suite.Equal(fmt.Sprintf("/%d/%d/", root.ID, child.ID), child.Path)
+
+	fm, err := suite.deptRepo.GetModel(ctx, []string{"Parent"}, child.ID)
+	suite.NoError(err)
+	suite.Equal(child.Path, fm.Path)
+	suite.Require().NotNil(fm.Parent)
+	suite.Equal(root.ID, fm.Parent.ID)
+}
+
+func (suite *DepartmentTestSuite) TestMoveSubtree() {
+	ctx := context.Background()
+	a := CreateTestDepartmentModel(nil)
+	suite.NoError(suite.deptRepo.CreateModel(ctx, a))
+	b := CreateTestDepartmentModel(a)
+	suite.NoError(suite.deptRepo.CreateModel(ctx, b))
+	c := CreateTestDepartmentModel(b)
+	suite.NoError(suite.deptRepo.CreateModel(ctx, c))
+	target := CreateTestDepartmentModel(nil)
+	suite.NoError(suite.deptRepo.CreateModel(ctx, target))
+
+	// 将b及其下级部门c移动到target下
+	suite.NoError(suite.deptRepo.MoveModel(ctx, b, target))
+	suite.Equal(fmt.Sprintf("/%d/%d/", target.ID, b.ID), b.Path)
+
+	fb, err := suite.deptRepo.GetModel(ctx, nil, b.ID)
+	suite.NoError(err)
+	suite.Require().NotNil(fb.ParentID)
+	suite.Equal(target.ID, *fb.ParentID)
+
+	fc, err := suite.deptRepo.GetModel(ctx, nil, c.ID)
+	suite.NoError(err)
+	suite.Equal(fmt.Sprintf("/%d/%d/%d/", target.ID, b.ID, c.ID), fc.Path)
+
+	ids, err := suite.deptRepo.SubtreeIDs(ctx, a.Path)
+	suite.NoError(err)
+	suite.ElementsMatch([]uint32{a.ID}, ids)
+
+	// 移动为根部门
+	suite.NoError(suite.deptRepo.MoveModel(ctx, b, nil))
+	fb, err = suite.deptRepo.GetModel(ctx, nil, b.ID)
+	suite.NoError(err)
+	suite.Nil(fb.ParentID)
+	suite.Equal(fmt.Sprintf("/%d/", b.ID), fb.Path)
+	ids, err = suite.deptRepo.SubtreeIDs(ctx, fb.Path)
+	suite.NoError(err)
+	suite.ElementsMatch([]uint32{b.ID, c.ID}, ids)
+}
+
+func (suite *DepartmentTestSuite) TestUpdateDeleteAndList() {
+	ctx := context.Background()
+	m := CreateTestDepartmentModel(nil)
+	suite.NoError(suite.deptRepo.CreateModel(ctx, m))
+
+	suite.NoError(suite.deptRepo.UpdateModel(ctx, map[string]any{"name": "运维部"}, "id = ?", m.ID))
+	count, ms, err := suite.deptRepo.ListModel(ctx, database.QueryParams{
+		IsCount: true,
+		Query:   map[string]any{"name = ?": "运维部"},
+	})
+	suite.NoError(err)
+	suite.GreaterOrEqual(count, int64(1))
+	suite.NotEmpty(*ms)
+
+	suite.NoError(suite.deptRepo.DeleteModel(ctx, m.ID))
+	_, err = suite.deptRepo.GetModel(ctx, nil, m.ID)
+	suite.Error(err)
+}
@@ -0,0 +1,81 @@
+package customer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	custmodel "gin-artweb/internal/model/customer"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/test"
+)
+
+// CreateTestUserIdentityModel 创建测试用的用户身份模型
+func CreateTestUserIdentityModel(userID uint32, provider string) *custmodel.UserIdentityModel {
+	return &custmodel.UserIdentityModel{
+		UserID:   userID,
+		Provider: provider,
+		Subject:  uuid.NewString(),
+		Email:    "alice@example.com",
+	}
+}
+
+type UserIdentityTestSuite struct {
+	suite.Suite
+	identityRepo *UserIdentityRepo
+}
+
+func (suite *UserIdentityTestSuite) SetupSuite() {
+	db := test.NewTestGormDBWithConfig(nil)
+	db.AutoMigrate(&custmodel.UserIdentityModel{})
+	suite.identityRepo = NewUserIdentityRepo(test.NewTestZapLogger(), db, test.NewTestDBTimeouts())
+}
+
+func TestUserIdentityTestSuite(t *testing.T) {
+	suite.Run(t, new(UserIdentityTestSuite))
+}
+
+func (suite *UserIdentityTestSuite) TestCreateAndGet() {
+	ctx := context.Background()
+	m := CreateTestUserIdentityModel(1, "keycloak")
+	suite.NoError(suite.identityRepo.CreateModel(ctx, m))
+
+	fm, err := suite.identityRepo.GetModel(ctx, nil, "provider = ? AND subject = ?", m.Provider, m.Subject)
+	suite.NoError(err)
+	suite.Equal(m.UserID, fm.UserID)
+	suite.Equal(m.Email, fm.Email)
+	suite.Nil(fm.LastLoginAt)
+
+	now := time.Now()
+	suite.NoError(suite.identityRepo.UpdateModel(ctx, map[string]any{"last_login_at": now}, "id = ?", m.ID))
+	fm, err = suite.identityRepo.GetModel(ctx, nil, "id = ?", m.ID)
+	suite.NoError(err)
+	suite.NotNil(fm.LastLoginAt)
+}
+
+func (suite *UserIdentityTestSuite) TestUniqueIdentity() {
+	ctx := context.Background()
+	m := CreateTestUserIdentityModel(2, "keycloak")
+	suite.NoError(suite.identityRepo.CreateModel(ctx, m))
+
+	// 同一个外部身份不能关联多个用户
+	dup := CreateTestUserIdentityModel(3, "keycloak")
+	dup.Subject = m.Subject
+	suite.Error(suite.identityRepo.CreateModel(ctx, dup))
+
+	// 同一个用户在同一个身份提供方只能关联一个身份
+	suite.Error(suite.identityRepo.CreateModel(ctx, CreateTestUserIdentityModel(2, "keycloak")))
+	suite.NoError(suite.identityRepo.CreateModel(ctx, CreateTestUserIdentityModel(2, "github")))
+
+	_, ms, err := suite.identityRepo.ListModel(ctx, database.QueryParams{Query: map[string]any{"user_id = ?": 2}})
+	suite.NoError(err)
+	suite.Len(*ms, 2)
+
+	suite.NoError(suite.identityRepo.DeleteModel(ctx, "user_id = ? AND provider = ?", 2, "github"))
+	_, ms, err = suite.identityRepo.ListModel(ctx, database.QueryParams{Query: map[string]any{"user_id = ?": 2}})
+	suite.NoError(err)
+	suite.Len(*ms, 1)
+}
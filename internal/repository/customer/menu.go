@@ -71,18 +71,16 @@ func (r *MenuRepo) CreateModel(
 	// 检查参数
 	if m == nil {
 		err := errors.New("创建菜单模型失败: 模型为空")
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"创建菜单模型失败: 模型为空",
 			zap.Error(err),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return err
 	}
 
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"开始创建菜单模型",
 		zap.Object(database.ModelKey, m),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	now := time.Now()
 	m.CreatedAt = now
@@ -98,23 +96,21 @@ func (r *MenuRepo) CreateModel(
 		}
 	}
 
-	dbCtx, cancel := context.WithTimeout(ctx, r.timeouts.WriteTimeout)
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
 	defer cancel()
 	if err := database.DBCreate(dbCtx, r.gormDB, &custmodel.MenuModel{}, m, upmap); err != nil {
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"创建菜单模型失败",
 			zap.Error(err),
 			zap.Object(database.ModelKey, m),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			zap.Duration(log.DurationKey, time.Since(now)),
 		)
 		return errors.WrapIf(err, "创建菜单模型失败")
 	}
 
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"创建菜单模型成功",
 		zap.Object(database.ModelKey, m),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		zap.Duration(log.DurationKey, time.Since(now)),
 	)
 	return nil
@@ -138,12 +134,11 @@ func (r *MenuRepo) UpdateModel(
 	apis *[]custmodel.ApiModel,
 	conds ...any,
 ) error {
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"开始更新菜单模型",
 		zap.Any(database.UpdateDataKey, data),
 		zap.Uint32s("apis", ListApiModelToUint32s(apis)),
 		zap.Any(database.ConditionsKey, conds),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	now := time.Now()
@@ -157,27 +152,25 @@ func (r *MenuRepo) UpdateModel(
 		}
 	}
 
-	dbCtx, cancel := context.WithTimeout(ctx, r.timeouts.WriteTimeout)
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
 	defer cancel()
 	if err := database.DBUpdate(dbCtx, r.gormDB, &custmodel.MenuModel{}, data, upmap, conds...); err != nil {
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"更新菜单模型失败",
 			zap.Error(err),
 			zap.Any(database.UpdateDataKey, data),
 			zap.Uint32s("apis", ListApiModelToUint32s(apis)),
 			zap.Any(database.ConditionsKey, conds),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			zap.Duration(log.DurationKey, time.Since(now)),
 		)
 		return errors.WrapIf(err, "更新菜单模型失败")
 	}
 
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"更新菜单模型成功",
 		zap.Any(database.UpdateDataKey, data),
 		zap.Any(database.ConditionsKey, conds),
 		zap.Uint32s("apis", ListApiModelToUint32s(apis)),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		zap.Duration(log.DurationKey, time.Since(now)),
 	)
 	return nil
@@ -194,30 +187,27 @@ func (r *MenuRepo) UpdateModel(
 //
 //	error: 操作过程中的错误
 func (r *MenuRepo) DeleteModel(ctx context.Context, conds ...any) error {
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"开始删除菜单模型",
 		zap.Any(database.ConditionsKey, conds),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	now := time.Now()
-	dbCtx, cancel := context.WithTimeout(ctx, r.timeouts.WriteTimeout)
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
 	defer cancel()
 	if err := database.DBDelete(dbCtx, r.gormDB, &custmodel.MenuModel{}, conds...); err != nil {
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"删除菜单模型失败",
 			zap.Error(err),
 			zap.Any(database.ConditionsKey, conds),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			zap.Duration(log.DurationKey, time.Since(now)),
 		)
 		return errors.WrapIf(err, "删除菜单模型失败")
 	}
 
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"删除菜单模型成功",
 		zap.Any(database.ConditionsKey, conds),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		zap.Duration(log.DurationKey, time.Since(now)),
 	)
 	return nil
@@ -240,35 +230,32 @@ func (r *MenuRepo) GetModel(
 	preloads []string,
 	conds ...any,
 ) (*custmodel.MenuModel, error) {
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"开始获取菜单模型",
 		zap.Strings(database.PreloadKey, preloads),
 		zap.Any(database.ConditionsKey, conds),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	now := time.Now()
 	var m custmodel.MenuModel
-	dbCtx, cancel := context.WithTimeout(ctx, r.timeouts.ReadTimeout)
+	dbCtx, cancel := database.ReadContext(ctx, r.timeouts)
 	defer cancel()
 	if err := database.DBGet(dbCtx, r.gormDB, preloads, &m, conds...); err != nil {
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"获取菜单模型失败",
 			zap.Error(err),
 			zap.Strings(database.PreloadKey, preloads),
 			zap.Any(database.ConditionsKey, conds),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			zap.Duration(log.DurationKey, time.Since(now)),
 		)
 		return nil, errors.WrapIf(err, "获取菜单模型失败")
 	}
 
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"获取菜单模型成功",
 		zap.Object(database.ModelKey, &m),
 		zap.Strings(database.PreloadKey, preloads),
 		zap.Any(database.ConditionsKey, conds),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		zap.Duration(log.DurationKey, time.Since(now)),
 	)
 	return &m, nil
@@ -290,32 +277,29 @@ func (r *MenuRepo) ListModel(
 	ctx context.Context,
 	qp database.QueryParams,
 ) (int64, *[]custmodel.MenuModel, error) {
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"开始查询菜单模型列表",
 		zap.Object(database.QueryParamsKey, &qp),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	now := time.Now()
 	var ms []custmodel.MenuModel
-	dbCtx, cancel := context.WithTimeout(ctx, r.timeouts.ListTimeout)
+	dbCtx, cancel := database.ListContext(database.WithQueryTimeout(ctx, qp.Timeout), r.timeouts)
 	defer cancel()
 	count, err := database.DBList(dbCtx, r.gormDB, &custmodel.MenuModel{}, &ms, qp)
 	if err != nil {
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"查询菜单模型列表失败",
 			zap.Error(err),
 			zap.Object(database.QueryParamsKey, &qp),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			zap.Duration(log.DurationKey, time.Since(now)),
 		)
 		return 0, nil, errors.WrapIf(err, "查询菜单模型列表失败")
 	}
 
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"查询菜单模型列表成功",
 		zap.Object(database.QueryParamsKey, &qp),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		zap.Duration(log.DurationKey, time.Since(now)),
 	)
 	return count, &ms, nil
@@ -359,11 +343,10 @@ func (r *MenuRepo) AddGroupPolicy(
 		return errors.New("AddGroupPolicy操作时菜单ID不能为0")
 	}
 
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"开始添加菜单关联策略",
 		zap.Object(database.ModelKey, menu),
 		zap.Uint32s("apis", ListApiModelToUint32s(&m.Apis)),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	now := time.Now()
@@ -380,10 +363,9 @@ func (r *MenuRepo) AddGroupPolicy(
 	for _, o := range m.Apis {
 		// 检查API模型的有效性
 		if o.ID == 0 {
-			r.log.Warn(
+			ctxutil.Logger(ctx, r.log).Warn(
 				"跳过无效API",
 				zap.Object(database.ModelKey, menu),
-				zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			)
 			continue
 		}
@@ -393,22 +375,20 @@ func (r *MenuRepo) AddGroupPolicy(
 
 	}
 	if err := auth.AddGroupPolicies(ctx, r.enforcer, rules); err != nil {
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"添加菜单关联策略失败",
 			zap.Error(err),
 			zap.Object(database.ModelKey, menu),
 			zap.Any("rules", rules),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			zap.Duration(log.DurationKey, time.Since(now)),
 		)
 		return errors.WrapIf(err, "添加菜单关联策略失败")
 	}
 
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"添加菜单关联策略成功",
 		zap.Object(database.ModelKey, menu),
 		zap.Uint32s("apis", ListApiModelToUint32s(&m.Apis)),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		zap.Duration(log.DurationKey, time.Since(now)),
 	)
 	return nil
@@ -454,53 +434,48 @@ func (r *MenuRepo) RemoveGroupPolicy(
 		return errors.New("RemoveGroupPolicy操作时菜单ID不能为0")
 	}
 
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"开始删除该菜单作为子级的组策略",
 		zap.Object(database.ModelKey, &m),
 		zap.Bool("removeInherited", removeInherited),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	rmSubStartTime := time.Now()
 	sub := auth.MenuToSubject(m.ID)
 	if err := auth.RemoveFilteredGroupingPolicy(ctx, r.enforcer, 0, sub); err != nil {
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"删除该菜单作为子级的组策略失败",
 			zap.Error(err),
 			zap.Object(database.ModelKey, menu),
 			zap.String(auth.GroupSubKey, sub),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			zap.Duration(log.DurationKey, time.Since(rmSubStartTime)),
 		)
 		return errors.WrapIf(err, "删除该菜单作为子级的组策略失败")
 	}
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"删除该菜单作为子级的组策略成功",
 		zap.Object(database.ModelKey, menu),
 		zap.String(auth.GroupSubKey, sub),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		zap.Duration(log.DurationKey, time.Since(rmSubStartTime)),
 	)
 
 	if removeInherited {
 		rmObjStartTime := time.Now()
 		if err := auth.RemoveFilteredGroupingPolicy(ctx, r.enforcer, 1, sub); err != nil {
-			r.log.Error(
+			ctxutil.Logger(ctx, r.log).Error(
 				"删除该菜单作为父级的组策略失败",
 				zap.Error(err),
 				zap.Object(database.ModelKey, menu),
 				zap.String(auth.GroupObjKey, sub),
-				zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 				zap.Duration(log.DurationKey, time.Since(rmObjStartTime)),
 			)
 			return errors.WrapIf(err, "删除该菜单作为父级的组策略失败")
 		}
 
-		r.log.Debug(
+		ctxutil.Logger(ctx, r.log).Debug(
 			"删除该菜单作为父级的组策略成功",
 			zap.Object(database.ModelKey, menu),
 			zap.String(auth.GroupObjKey, sub),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			zap.Duration(log.DurationKey, time.Since(rmObjStartTime)),
 		)
 	}
@@ -0,0 +1,247 @@
+package customer
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	custmodel "gin-artweb/internal/model/customer"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/log"
+)
+
+// UserPreferenceRepo 用户偏好仓库实现
+// 负责用户偏好模型的保存、查询和删除, 每个用户的每个命名空间只有一条记录
+// 使用GORM进行数据库操作
+type UserPreferenceRepo struct {
+	log      *zap.Logger       // 日志记录器
+	gormDB   *gorm.DB          // GORM数据库连接
+	timeouts *config.DBTimeout // 数据库操作超时配置
+}
+
+// NewUserPreferenceRepo 创建用户偏好仓库实例
+//
+// 参数：
+//
+//	log: 日志记录器，用于记录操作日志
+//	gormDB: GORM数据库连接，用于执行数据库操作
+//	timeouts: 数据库操作超时配置，控制各类数据库操作的超时时间
+//
+// 返回值：
+//
+//	UserPreferenceRepo: 用户偏好仓库接口实现
+func NewUserPreferenceRepo(
+	log *zap.Logger,
+	gormDB *gorm.DB,
+	timeouts *config.DBTimeout,
+) *UserPreferenceRepo {
+	return &UserPreferenceRepo{
+		log:      log,
+		gormDB:   gormDB,
+		timeouts: timeouts,
+	}
+}
+
+// SaveModel 保存用户在一个命名空间下的偏好, 已有记录时替换偏好内容
+//
+// 参数：
+//
+//	ctx: 上下文，用于传递请求信息和控制超时
+//	m: 用户偏好模型，保存后回填ID和时间
+//
+// 返回值：
+//
+//	error: 操作错误信息，成功则返回nil
+func (r *UserPreferenceRepo) SaveModel(ctx context.Context, m *custmodel.UserPreferenceModel) error {
+	if m == nil {
+		err := errors.New("保存用户偏好模型失败: 模型为空")
+		ctxutil.Logger(ctx, r.log).Error(
+			"保存用户偏好模型失败: 模型为空",
+			zap.Error(err),
+		)
+		return err
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始保存用户偏好模型",
+		zap.Object(database.ModelKey, m),
+	)
+	now := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	err := database.Conn(dbCtx, r.gormDB).Transaction(func(tx *gorm.DB) error {
+		var existing custmodel.UserPreferenceModel
+		err := tx.Where("user_id = ? AND namespace = ?", m.UserID, m.Namespace).Take(&existing).Error
+		switch {
+		case err == nil:
+			m.ID, m.CreatedAt, m.UpdatedAt = existing.ID, existing.CreatedAt, now
+			return tx.Model(&existing).Updates(map[string]any{
+				"value":      m.Value,
+				"updated_at": now,
+			}).Error
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			m.CreatedAt, m.UpdatedAt = now, now
+			return tx.Create(m).Error
+		default:
+			return err
+		}
+	})
+	if err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"保存用户偏好模型失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, m),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return errors.WrapIf(err, "保存用户偏好模型失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"保存用户偏好模型成功",
+		zap.Object(database.ModelKey, m),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return nil
+}
+
+// DeleteModel 删除用户偏好模型
+//
+// 参数：
+//
+//	ctx: 上下文，用于传递请求信息和控制超时
+//	conds: 查询条件，用于指定要删除的记录
+//
+// 返回值：
+//
+//	error: 操作错误信息，成功则返回nil
+//
+// 功能：
+//  1. 执行数据库删除操作
+//  2. 记录操作日志
+func (r *UserPreferenceRepo) DeleteModel(ctx context.Context, conds ...any) error {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始删除用户偏好模型",
+		zap.Any(database.ConditionsKey, conds),
+	)
+	now := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBDelete(dbCtx, r.gormDB, &custmodel.UserPreferenceModel{}, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"删除用户偏好模型失败",
+			zap.Error(err),
+			zap.Any(database.ConditionsKey, conds),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return errors.WrapIf(err, "删除用户偏好模型失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"删除用户偏好模型成功",
+		zap.Any(database.ConditionsKey, conds),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return nil
+}
+
+// GetModel 查询单个用户偏好模型
+//
+// 参数：
+//
+//	ctx: 上下文，用于传递请求信息和控制超时
+//	preloads: 需要预加载的关联关系
+//	conds: 查询条件，用于指定要查询的记录
+//
+// 返回值：
+//
+//	*custmodel.UserPreferenceModel: 用户偏好模型指针，包含用户偏好的详细信息
+//	error: 操作错误信息，成功则返回nil
+//
+// 功能：
+//  1. 执行数据库查询操作
+//  2. 预加载关联字段
+//  3. 获取单个用户偏好模型
+//  4. 记录操作日志
+func (r *UserPreferenceRepo) GetModel(
+	ctx context.Context,
+	preloads []string,
+	conds ...any,
+) (*custmodel.UserPreferenceModel, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始查询用户偏好模型",
+		zap.Strings(database.PreloadKey, preloads),
+		zap.Any(database.ConditionsKey, conds),
+	)
+	now := time.Now()
+	var m custmodel.UserPreferenceModel
+	dbCtx, cancel := database.ReadContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBGet(dbCtx, r.gormDB, preloads, &m, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"查询用户偏好模型失败",
+			zap.Error(err),
+			zap.Strings(database.PreloadKey, preloads),
+			zap.Any(database.ConditionsKey, conds),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return nil, errors.WrapIf(err, "查询用户偏好模型失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"查询用户偏好模型成功",
+		zap.Object(database.ModelKey, &m),
+		zap.Strings(database.PreloadKey, preloads),
+		zap.Any(database.ConditionsKey, conds),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return &m, nil
+}
+
+// ListModel 查询用户偏好模型列表
+//
+// 参数：
+//
+//	ctx: 上下文，用于传递请求信息和控制超时
+//	qp: 查询参数，包含分页、排序等查询条件
+//
+// 返回值：
+//
+//	int64: 总记录数
+//	*[]custmodel.UserPreferenceModel: 用户偏好模型列表指针，包含符合条件的用户偏好模型
+//	error: 操作错误信息，成功则返回nil
+//
+// 功能：
+//  1. 执行数据库查询操作
+//  2. 获取用户偏好模型列表
+//  3. 返回总记录数和模型列表
+//  4. 记录操作日志
+func (r *UserPreferenceRepo) ListModel(
+	ctx context.Context,
+	qp database.QueryParams,
+) (int64, *[]custmodel.UserPreferenceModel, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始查询用户偏好模型列表",
+		zap.Object(database.QueryParamsKey, &qp),
+	)
+	now := time.Now()
+	var ms []custmodel.UserPreferenceModel
+	dbCtx, cancel := database.ListContext(database.WithQueryTimeout(ctx, qp.Timeout), r.timeouts)
+	defer cancel()
+	count, err := database.DBList(dbCtx, r.gormDB, &custmodel.UserPreferenceModel{}, &ms, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"查询用户偏好列表失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return 0, nil, errors.WrapIf(err, "查询用户偏好列表失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"查询用户偏好模型列表成功",
+		zap.Object(database.QueryParamsKey, &qp),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return count, &ms, nil
+}
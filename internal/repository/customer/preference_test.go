@@ -0,0 +1,62 @@
+package customer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	custmodel "gin-artweb/internal/model/customer"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/test"
+)
+
+type UserPreferenceTestSuite struct {
+	suite.Suite
+	prefRepo *UserPreferenceRepo
+}
+
+func (suite *UserPreferenceTestSuite) SetupSuite() {
+	db := test.NewTestGormDBWithConfig(nil)
+	db.AutoMigrate(&custmodel.UserPreferenceModel{})
+	suite.prefRepo = NewUserPreferenceRepo(test.NewTestZapLogger(), db, test.NewTestDBTimeouts())
+}
+
+func TestUserPreferenceTestSuite(t *testing.T) {
+	suite.Run(t, new(UserPreferenceTestSuite))
+}
+
+func (suite *UserPreferenceTestSuite) TestSaveModel() {
+	ctx := context.Background()
+	m := &custmodel.UserPreferenceModel{UserID: 1, Namespace: custmodel.PreferenceTheme, Value: `{"mode":"dark"}`}
+	suite.Require().NoError(suite.prefRepo.SaveModel(ctx, m))
+	suite.NotZero(m.ID)
+
+	// 同一用户同一命名空间再次保存时更新原记录
+	again := &custmodel.UserPreferenceModel{UserID: 1, Namespace: custmodel.PreferenceTheme, Value: `{"mode":"light"}`}
+	suite.Require().NoError(suite.prefRepo.SaveModel(ctx, again))
+	suite.Equal(m.ID, again.ID)
+
+	other := &custmodel.UserPreferenceModel{UserID: 2, Namespace: custmodel.PreferenceTheme, Value: `{}`}
+	suite.Require().NoError(suite.prefRepo.SaveModel(ctx, other))
+	suite.NotEqual(m.ID, other.ID, "不同用户的偏好应该分开保存")
+
+	fm, err := suite.prefRepo.GetModel(ctx, nil, "user_id = ? AND namespace = ?", 1, custmodel.PreferenceTheme)
+	suite.Require().NoError(err)
+	suite.Equal(`{"mode":"light"}`, fm.Value)
+
+	_, ms, err := suite.prefRepo.ListModel(ctx, database.QueryParams{Query: map[string]any{"user_id = ?": 1}})
+	suite.Require().NoError(err)
+	suite.Len(*ms, 1)
+}
+
+func (suite *UserPreferenceTestSuite) TestPreferenceSchema() {
+	for _, ns := range []string{"theme", "colony_filter", "table.mds_colony", "table.oes-node2"} {
+		_, ok := custmodel.PreferenceSchema(ns)
+		suite.True(ok, ns)
+	}
+	for _, ns := range []string{"", "Theme", "table.", "table.Mds", "table.a/b", "layout"} {
+		_, ok := custmodel.PreferenceSchema(ns)
+		suite.False(ok, ns)
+	}
+}
@@ -81,35 +81,33 @@ func (r *LoginRecordRepo) CreateModel(ctx context.Context, m *custmodel.LoginRec
 	// 检查参数
 	if m == nil {
 		err := errors.New("创建登录记录模型失败: 模型为空")
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"创建登录记录模型失败: 模型为空",
 			zap.Error(err),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return err
 	}
 
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"开始创建登录记录模型",
 		zap.Object(database.ModelKey, m),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	now := time.Now()
 	m.LoginAt = now
-	if err := database.DBCreate(ctx, r.gormDB, &custmodel.LoginRecordModel{}, m, nil); err != nil {
-		r.log.Error(
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBCreate(dbCtx, r.gormDB, &custmodel.LoginRecordModel{}, m, nil); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
 			"创建登录记录模型失败",
 			zap.Object(database.ModelKey, m),
 			zap.Error(err),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			zap.Duration(log.DurationKey, time.Since(now)),
 		)
 		return errors.WrapIf(err, "创建登录记录模型失败")
 	}
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"创建登录记录模型成功",
 		zap.Object(database.ModelKey, m),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		zap.Duration(log.DurationKey, time.Since(now)),
 	)
 	return nil
@@ -137,28 +135,27 @@ func (r *LoginRecordRepo) ListModel(
 	ctx context.Context,
 	qp database.QueryParams,
 ) (int64, *[]custmodel.LoginRecordModel, error) {
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"开始查询登录记录模型列表",
 		zap.Object(database.QueryParamsKey, &qp),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	now := time.Now()
 	var ms []custmodel.LoginRecordModel
-	count, err := database.DBList(ctx, r.gormDB, &custmodel.LoginRecordModel{}, &ms, qp)
+	dbCtx, cancel := database.ListContext(database.WithQueryTimeout(ctx, qp.Timeout), r.timeouts)
+	defer cancel()
+	count, err := database.DBList(dbCtx, r.gormDB, &custmodel.LoginRecordModel{}, &ms, qp)
 	if err != nil {
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"查询登录记录列表失败",
 			zap.Error(err),
 			zap.Object(database.QueryParamsKey, &qp),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			zap.Duration(log.DurationKey, time.Since(now)),
 		)
 		return 0, nil, errors.WrapIf(err, "查询登录记录列表失败")
 	}
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"查询登录记录模型列表成功",
 		zap.Object(database.QueryParamsKey, &qp),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		zap.Duration(log.DurationKey, time.Since(now)),
 	)
 	return count, &ms, nil
@@ -193,30 +190,27 @@ func (r *LoginRecordRepo) GetLoginFailNum(ctx context.Context, ip string) (int,
 		return 0, errors.New("获取登录失败次数失败: IP地址不能为空")
 	}
 
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"开始获取登录失败次数",
 		zap.String("ip", ip),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	// 获取缓存的登录失败次数，不存在返回允许失败次数的最大值
 	num, exists := r.cache.Get(ip)
 	if !exists {
-		r.log.Debug(
+		ctxutil.Logger(ctx, r.log).Debug(
 			"未找到IP的登录失败记录, 返回最大允许失败次数",
 			zap.String("ip", ip),
 			zap.Int("max_fail_num", r.maxNum),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return r.maxNum, nil
 	}
 
 	n, _ := num.(int)
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"获取到IP的登录失败次数",
 		zap.String("ip", ip),
 		zap.Int("fail_num", n),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return n, nil
 }
@@ -249,22 +243,20 @@ func (r *LoginRecordRepo) SetLoginFailNum(ctx context.Context, ip string, num in
 		return errors.New("设置登录失败次数失败: IP地址不能为空")
 	}
 
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"开始设置登录失败次数",
 		zap.String("ip", ip),
 		zap.Int("fail_num", num),
 		zap.Duration("ttl", r.ttl),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	// 设置缓存的登录失败次数
 	r.cache.Set(ip, num, r.ttl)
 
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"设置登录失败次数成功",
 		zap.String("ip", ip),
 		zap.Int("fail_num", num),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return nil
 }
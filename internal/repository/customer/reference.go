@@ -0,0 +1,289 @@
+package customer
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/casbin/casbin/v2"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	custmodel "gin-artweb/internal/model/customer"
+	"gin-artweb/internal/shared/auth"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/log"
+)
+
+// ErrStillReferenced 删除事务中发现新增的引用, 删除已回滚
+var ErrStillReferenced = errors.Sentinel("记录仍被引用, 删除已回滚")
+
+// ReferenceRepo 引用关系仓库实现
+// 负责查询角色、菜单被用户、用户组、角色、按钮和Casbin组策略引用的情况,
+// 并在一个事务中删除记录和关联表中的数据
+type ReferenceRepo struct {
+	log      *zap.Logger       // 日志记录器
+	gormDB   *gorm.DB          // GORM数据库连接
+	timeouts *config.DBTimeout // 数据库操作超时配置
+	enforcer *casbin.Enforcer  // Casbin权限管理器
+}
+
+// NewReferenceRepo 创建引用关系仓库实例
+//
+// 参数：
+//
+//	log: 日志记录器，用于记录操作日志
+//	gormDB: GORM数据库连接，用于执行数据库操作
+//	timeouts: 数据库操作超时配置，控制各类数据库操作的超时时间
+//	enforcer: Casbin权限管理器，用于查询和移除组策略
+//
+// 返回值：
+//
+//	ReferenceRepo: 引用关系仓库实现
+func NewReferenceRepo(
+	log *zap.Logger,
+	gormDB *gorm.DB,
+	timeouts *config.DBTimeout,
+	enforcer *casbin.Enforcer,
+) *ReferenceRepo {
+	return &ReferenceRepo{
+		log:      log,
+		gormDB:   gormDB,
+		timeouts: timeouts,
+		enforcer: enforcer,
+	}
+}
+
+// referenceRow 引用查询的结果行
+type referenceRow struct {
+	ID   uint32
+	Name string
+}
+
+// listReferences 执行引用查询, 结果按ID排序并转换为引用记录
+func (r *ReferenceRepo) listReferences(
+	ctx context.Context,
+	kind, reason string,
+	query func(db *gorm.DB) *gorm.DB,
+) ([]custmodel.DeleteDependency, error) {
+	dbCtx, cancel := database.ListContext(ctx, r.timeouts)
+	defer cancel()
+
+	var rows []referenceRow
+	if err := query(database.Conn(dbCtx, r.gormDB)).Distinct().Order("id").Scan(&rows).Error; err != nil {
+		return nil, errors.WrapIfWithDetails(err, "查询引用记录失败", "kind", kind)
+	}
+	deps := make([]custmodel.DeleteDependency, 0, len(rows))
+	for _, row := range rows {
+		deps = append(deps, custmodel.DeleteDependency{Kind: kind, ID: row.ID, Name: row.Name, Reason: reason})
+	}
+	return deps, nil
+}
+
+// UsersByRole 查询主角色为指定角色的用户
+func (r *ReferenceRepo) UsersByRole(ctx context.Context, roleIDs []uint32, reason string) ([]custmodel.DeleteDependency, error) {
+	return r.listReferences(ctx, custmodel.DependencyUser, reason, func(db *gorm.DB) *gorm.DB {
+		return db.Model(&custmodel.UserModel{}).
+			Select("id, username AS name").
+			Where("role_id IN ?", roleIDs)
+	})
+}
+
+// GroupsByRole 查询关联了指定角色的用户组
+func (r *ReferenceRepo) GroupsByRole(ctx context.Context, roleIDs []uint32, reason string) ([]custmodel.DeleteDependency, error) {
+	return r.listReferences(ctx, custmodel.DependencyUserGroup, reason, func(db *gorm.DB) *gorm.DB {
+		return db.Table("customer_user_group AS g").
+			Select("g.id AS id, g.name AS name").
+			Joins("JOIN customer_user_group_role AS gr ON gr.group_id = g.id").
+			Where("gr.role_id IN ?", roleIDs)
+	})
+}
+
+// RolesByMenu 查询授权了指定菜单的角色
+func (r *ReferenceRepo) RolesByMenu(ctx context.Context, menuIDs []uint32, reason string) ([]custmodel.DeleteDependency, error) {
+	return r.listReferences(ctx, custmodel.DependencyRole, reason, func(db *gorm.DB) *gorm.DB {
+		return db.Table("customer_role AS r").
+			Select("r.id AS id, r.name AS name").
+			Joins("JOIN customer_role_menu AS rm ON rm.role_id = r.id").
+			Where("rm.menu_id IN ?", menuIDs)
+	})
+}
+
+// RolesByButton 查询授权了指定按钮的角色
+func (r *ReferenceRepo) RolesByButton(ctx context.Context, buttonIDs []uint32, reason string) ([]custmodel.DeleteDependency, error) {
+	return r.listReferences(ctx, custmodel.DependencyRole, reason, func(db *gorm.DB) *gorm.DB {
+		return db.Table("customer_role AS r").
+			Select("r.id AS id, r.name AS name").
+			Joins("JOIN customer_role_button AS rb ON rb.role_id = r.id").
+			Where("rb.button_id IN ?", buttonIDs)
+	})
+}
+
+// ChildMenus 查询指定菜单的直接子菜单
+func (r *ReferenceRepo) ChildMenus(ctx context.Context, menuIDs []uint32, reason string) ([]custmodel.DeleteDependency, error) {
+	return r.listReferences(ctx, custmodel.DependencyMenu, reason, func(db *gorm.DB) *gorm.DB {
+		return db.Model(&custmodel.MenuModel{}).
+			Select("id, name").
+			Where("parent_id IN ?", menuIDs)
+	})
+}
+
+// ButtonsByMenu 查询指定菜单下的按钮
+func (r *ReferenceRepo) ButtonsByMenu(ctx context.Context, menuIDs []uint32, reason string) ([]custmodel.DeleteDependency, error) {
+	return r.listReferences(ctx, custmodel.DependencyButton, reason, func(db *gorm.DB) *gorm.DB {
+		return db.Model(&custmodel.ButtonModel{}).
+			Select("id, name").
+			Where("menu_id IN ?", menuIDs)
+	})
+}
+
+// GroupingPolicies 查询以指定主体为子级或父级的Casbin组策略
+func (r *ReferenceRepo) GroupingPolicies(subjects []string) ([][]string, error) {
+	var rules [][]string
+	for _, sub := range subjects {
+		for index := range 2 {
+			rs, err := r.enforcer.GetFilteredGroupingPolicy(index, sub)
+			if err != nil {
+				return nil, errors.WrapIfWithDetails(err, "查询Casbin组策略失败", "subject", sub)
+			}
+			for _, rule := range rs {
+				if !slices.ContainsFunc(rules, func(o []string) bool { return slices.Equal(o, rule) }) {
+					rules = append(rules, rule)
+				}
+			}
+		}
+	}
+	return rules, nil
+}
+
+// RemoveGroupingPolicies 移除以指定主体为子级或父级的Casbin组策略
+func (r *ReferenceRepo) RemoveGroupingPolicies(ctx context.Context, subjects []string) error {
+	for _, sub := range subjects {
+		for index := range 2 {
+			if err := auth.RemoveFilteredGroupingPolicy(ctx, r.enforcer, index, sub); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// DeleteRoles 在一个事务中删除角色及其关联表中的数据
+//
+// 事务中再次检查主角色为这些角色的用户, 存在时回滚并返回ErrStillReferenced,
+// 避免检查之后新分配的用户被数据库级联删除
+func (r *ReferenceRepo) DeleteRoles(ctx context.Context, roleIDs []uint32) error {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始批量删除角色",
+		zap.Uint32s("role_ids", roleIDs),
+	)
+
+	now := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	err := database.Conn(dbCtx, r.gormDB).Transaction(func(tx *gorm.DB) error {
+		var users int64
+		if err := tx.Model(&custmodel.UserModel{}).Where("role_id IN ?", roleIDs).Count(&users).Error; err != nil {
+			return err
+		}
+		if users > 0 {
+			return ErrStillReferenced
+		}
+		for _, table := range []string{
+			"customer_role_api",
+			"customer_role_menu",
+			"customer_role_button",
+			"customer_user_group_role",
+		} {
+			if err := tx.Exec("DELETE FROM "+table+" WHERE role_id IN ?", roleIDs).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Delete(&custmodel.RoleModel{}, "id IN ?", roleIDs).Error
+	})
+	if err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"批量删除角色失败",
+			zap.Error(err),
+			zap.Uint32s("role_ids", roleIDs),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return errors.WrapIf(err, "批量删除角色失败")
+	}
+
+	ctxutil.Logger(ctx, r.log).Debug(
+		"批量删除角色成功",
+		zap.Uint32s("role_ids", roleIDs),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return nil
+}
+
+// DeleteMenus 在一个事务中删除菜单、菜单下的按钮及其关联表中的数据, 返回删除的按钮ID
+//
+// 事务中再次检查不在删除范围内的子菜单, 存在时回滚并返回ErrStillReferenced
+func (r *ReferenceRepo) DeleteMenus(ctx context.Context, menuIDs []uint32) ([]uint32, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始批量删除菜单",
+		zap.Uint32s("menu_ids", menuIDs),
+	)
+
+	now := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	var buttonIDs []uint32
+	err := database.Conn(dbCtx, r.gormDB).Transaction(func(tx *gorm.DB) error {
+		var children int64
+		if err := tx.Model(&custmodel.MenuModel{}).
+			Where("parent_id IN ? AND id NOT IN ?", menuIDs, menuIDs).
+			Count(&children).Error; err != nil {
+			return err
+		}
+		if children > 0 {
+			return ErrStillReferenced
+		}
+		if err := tx.Model(&custmodel.ButtonModel{}).Where("menu_id IN ?", menuIDs).Pluck("id", &buttonIDs).Error; err != nil {
+			return err
+		}
+		if len(buttonIDs) > 0 {
+			for _, table := range []string{"customer_button_api", "customer_role_button"} {
+				if err := tx.Exec("DELETE FROM "+table+" WHERE button_id IN ?", buttonIDs).Error; err != nil {
+					return err
+				}
+			}
+			if err := tx.Delete(&custmodel.ButtonModel{}, "id IN ?", buttonIDs).Error; err != nil {
+				return err
+			}
+		}
+		for _, table := range []string{"customer_menu_api", "customer_role_menu"} {
+			if err := tx.Exec("DELETE FROM "+table+" WHERE menu_id IN ?", menuIDs).Error; err != nil {
+				return err
+			}
+		}
+		// 先解除删除范围内的父子关系, 避免按顺序删除时违反外键约束
+		if err := tx.Model(&custmodel.MenuModel{}).Where("id IN ?", menuIDs).Update("parent_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&custmodel.MenuModel{}, "id IN ?", menuIDs).Error
+	})
+	if err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"批量删除菜单失败",
+			zap.Error(err),
+			zap.Uint32s("menu_ids", menuIDs),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return nil, errors.WrapIf(err, "批量删除菜单失败")
+	}
+
+	ctxutil.Logger(ctx, r.log).Debug(
+		"批量删除菜单成功",
+		zap.Uint32s("menu_ids", menuIDs),
+		zap.Uint32s("button_ids", buttonIDs),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return buttonIDs, nil
+}
@@ -0,0 +1,72 @@
+package customer
+
+import (
+	"context"
+	"testing"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	custmodel "gin-artweb/internal/model/customer"
+	"gin-artweb/internal/shared/auth"
+	"gin-artweb/internal/shared/test"
+)
+
+type ReferenceTestSuite struct {
+	suite.Suite
+	db      *gorm.DB
+	refRepo *ReferenceRepo
+}
+
+func (suite *ReferenceTestSuite) SetupTest() {
+	suite.db = test.NewTestGormDBWithConfig(nil)
+	suite.Require().NoError(suite.db.AutoMigrate(
+		&custmodel.ApiModel{},
+		&custmodel.MenuModel{},
+		&custmodel.ButtonModel{},
+		&custmodel.RoleModel{},
+		&custmodel.UserModel{},
+	))
+	enforcer, err := auth.NewCasbinEnforcer()
+	suite.Require().NoError(err)
+	suite.refRepo = NewReferenceRepo(test.NewTestZapLogger(), suite.db, test.NewTestDBTimeouts(), enforcer)
+}
+
+func TestReferenceTestSuite(t *testing.T) {
+	suite.Run(t, new(ReferenceTestSuite))
+}
+
+func (suite *ReferenceTestSuite) TestDeleteRolesStillReferenced() {
+	ctx := context.Background()
+	role := CreateTestRoleModel()
+	suite.Require().NoError(suite.db.Create(role).Error)
+	suite.Require().NoError(suite.db.Create(CreateTestUserModel(role.ID)).Error)
+
+	err := suite.refRepo.DeleteRoles(ctx, []uint32{role.ID})
+	suite.True(errors.Is(err, ErrStillReferenced))
+	var count int64
+	suite.Require().NoError(suite.db.Model(&custmodel.RoleModel{}).Count(&count).Error)
+	suite.Equal(int64(1), count, "事务应该回滚")
+}
+
+func (suite *ReferenceTestSuite) TestDeleteMenusStillReferenced() {
+	ctx := context.Background()
+	parent := CreateTestMenuModel(nil)
+	suite.Require().NoError(suite.db.Create(parent).Error)
+	child := CreateTestMenuModel(&parent.ID)
+	suite.Require().NoError(suite.db.Create(child).Error)
+
+	_, err := suite.refRepo.DeleteMenus(ctx, []uint32{parent.ID})
+	suite.True(errors.Is(err, ErrStillReferenced))
+
+	button := CreateTestButtonModel(child.ID)
+	suite.Require().NoError(suite.db.Create(button).Error)
+	buttonIDs, err := suite.refRepo.DeleteMenus(ctx, []uint32{parent.ID, child.ID})
+	suite.Require().NoError(err)
+	suite.Equal([]uint32{button.ID}, buttonIDs)
+
+	deps, err := suite.refRepo.ChildMenus(ctx, []uint32{parent.ID}, "")
+	suite.Require().NoError(err)
+	suite.Empty(deps)
+}
@@ -83,18 +83,16 @@ func (r *RoleRepo) CreateModel(
 	// 检查参数
 	if m == nil {
 		err := errors.New("创建角色模型失败: 模型为空")
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"创建角色模型失败: 模型为空",
 			zap.Error(err),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return err
 	}
 
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"开始创建角色模型",
 		zap.Object(database.ModelKey, m),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	now := time.Now()
@@ -118,21 +116,21 @@ func (r *RoleRepo) CreateModel(
 		}
 	}
 
-	if err := database.DBCreate(ctx, r.gormDB, &custmodel.RoleModel{}, m, upmap); err != nil {
-		r.log.Error(
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBCreate(dbCtx, r.gormDB, &custmodel.RoleModel{}, m, upmap); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
 			"创建角色模型失败",
 			zap.Error(err),
 			zap.Object(database.ModelKey, m),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			zap.Duration(log.DurationKey, time.Since(now)),
 		)
 		return errors.WrapIf(err, "创建角色模型失败")
 	}
 
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"创建角色模型成功",
 		zap.Object(database.ModelKey, m),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		zap.Duration(log.DurationKey, time.Since(now)),
 	)
 	return nil
@@ -168,22 +166,20 @@ func (r *RoleRepo) UpdateModel(
 ) error {
 	if len(data) == 0 {
 		err := errors.New("更新角色模型失败: 更新数据为空")
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"更新角色模型失败: 更新数据为空",
 			zap.Error(err),
 			zap.Any(database.UpdateDataKey, data),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return err
 	}
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"开始更新角色模型",
 		zap.Any(database.UpdateDataKey, data),
 		zap.Any(database.ConditionsKey, conds),
 		zap.Uint32s("apis", ListApiModelToUint32s(apis)),
 		zap.Uint32s("menus", ListMenuModelToUint32s(menus)),
 		zap.Uint32s("buttons", ListButtonModelToUint32s(buttons)),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	now := time.Now()
 	upmap := make(map[string]any, 3)
@@ -208,8 +204,10 @@ func (r *RoleRepo) UpdateModel(
 			upmap["Buttons"] = []custmodel.ButtonModel{}
 		}
 	}
-	if err := database.DBUpdate(ctx, r.gormDB, &custmodel.RoleModel{}, data, upmap, conds...); err != nil {
-		r.log.Error(
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBUpdate(dbCtx, r.gormDB, &custmodel.RoleModel{}, data, upmap, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
 			"更新角色模型失败",
 			zap.Error(err),
 			zap.Any(database.UpdateDataKey, data),
@@ -217,19 +215,17 @@ func (r *RoleRepo) UpdateModel(
 			zap.Uint32s("menus", ListMenuModelToUint32s(menus)),
 			zap.Uint32s("buttons", ListButtonModelToUint32s(buttons)),
 			zap.Any(database.ConditionsKey, conds),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			zap.Duration(log.DurationKey, time.Since(now)),
 		)
 		return errors.WrapIf(err, "更新角色模型失败")
 	}
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"更新角色模型成功",
 		zap.Any(database.UpdateDataKey, data),
 		zap.Uint32s("apis", ListApiModelToUint32s(apis)),
 		zap.Uint32s("menus", ListMenuModelToUint32s(menus)),
 		zap.Uint32s("buttons", ListButtonModelToUint32s(buttons)),
 		zap.Any(database.ConditionsKey, conds),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		zap.Duration(log.DurationKey, time.Since(now)),
 	)
 	return nil
@@ -250,26 +246,25 @@ func (r *RoleRepo) UpdateModel(
 //  1. 执行数据库删除操作
 //  2. 记录操作日志
 func (r *RoleRepo) DeleteModel(ctx context.Context, conds ...any) error {
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"开始删除角色模型",
 		zap.Any(database.ConditionsKey, conds),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	now := time.Now()
-	if err := database.DBDelete(ctx, r.gormDB, &custmodel.RoleModel{}, conds...); err != nil {
-		r.log.Error(
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBDelete(dbCtx, r.gormDB, &custmodel.RoleModel{}, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
 			"删除角色模型失败",
 			zap.Error(err),
 			zap.Any(database.ConditionsKey, conds),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			zap.Duration(log.DurationKey, time.Since(now)),
 		)
 		return errors.WrapIf(err, "删除角色模型失败")
 	}
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"删除角色模型成功",
 		zap.Any(database.ConditionsKey, conds),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		zap.Duration(log.DurationKey, time.Since(now)),
 	)
 	return nil
@@ -298,31 +293,30 @@ func (r *RoleRepo) GetModel(
 	preloads []string,
 	conds ...any,
 ) (*custmodel.RoleModel, error) {
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"开始查询角色模型",
 		zap.Strings(database.PreloadKey, preloads),
 		zap.Any(database.ConditionsKey, conds),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	now := time.Now()
 	var m custmodel.RoleModel
-	if err := database.DBGet(ctx, r.gormDB, preloads, &m, conds...); err != nil {
-		r.log.Error(
+	dbCtx, cancel := database.ReadContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBGet(dbCtx, r.gormDB, preloads, &m, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
 			"查询角色模型失败",
 			zap.Error(err),
 			zap.Strings(database.PreloadKey, preloads),
 			zap.Any(database.ConditionsKey, conds),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			zap.Duration(log.DurationKey, time.Since(now)),
 		)
 		return nil, errors.WrapIf(err, "查询角色模型失败")
 	}
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"查询角色模型成功",
 		zap.Object(database.ModelKey, &m),
 		zap.Strings(database.PreloadKey, preloads),
 		zap.Any(database.ConditionsKey, conds),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		zap.Duration(log.DurationKey, time.Since(now)),
 	)
 	return &m, nil
@@ -350,28 +344,27 @@ func (r *RoleRepo) ListModel(
 	ctx context.Context,
 	qp database.QueryParams,
 ) (int64, *[]custmodel.RoleModel, error) {
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"开始查询角色模型列表",
 		zap.Object(database.QueryParamsKey, &qp),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	now := time.Now()
 	var ms []custmodel.RoleModel
-	count, err := database.DBList(ctx, r.gormDB, &custmodel.RoleModel{}, &ms, qp)
+	dbCtx, cancel := database.ListContext(database.WithQueryTimeout(ctx, qp.Timeout), r.timeouts)
+	defer cancel()
+	count, err := database.DBList(dbCtx, r.gormDB, &custmodel.RoleModel{}, &ms, qp)
 	if err != nil {
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"查询角色模型列表失败",
 			zap.Error(err),
 			zap.Object(database.QueryParamsKey, &qp),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			zap.Duration(log.DurationKey, time.Since(now)),
 		)
 		return 0, nil, errors.WrapIf(err, "查询角色模型列表失败")
 	}
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"查询角色模型列表成功",
 		zap.Object(database.QueryParamsKey, &qp),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		zap.Duration(log.DurationKey, time.Since(now)),
 	)
 	return count, &ms, nil
@@ -412,10 +405,9 @@ func (r *RoleRepo) AddGroupPolicy(
 		return errors.New("AddGroupPolicy操作失败: 角色ID不能为0")
 	}
 
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"开始添加角色关联策略",
 		zap.Object(database.ModelKey, role),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	now := time.Now()
@@ -425,11 +417,10 @@ func (r *RoleRepo) AddGroupPolicy(
 	for i, o := range m.Apis {
 		// 检查API模型的有效性
 		if o.ID == 0 {
-			r.log.Warn(
+			ctxutil.Logger(ctx, r.log).Warn(
 				"跳过无效权限",
 				zap.Object(database.ModelKey, role),
 				zap.Int("api_index", i),
-				zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			)
 			continue
 		}
@@ -441,11 +432,10 @@ func (r *RoleRepo) AddGroupPolicy(
 	for i, o := range m.Menus {
 		// 检查菜单模型的有效性
 		if o.ID == 0 {
-			r.log.Warn(
+			ctxutil.Logger(ctx, r.log).Warn(
 				"跳过无效菜单",
 				zap.Object(database.ModelKey, role),
 				zap.Int("menu_index", i),
-				zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			)
 			continue
 		}
@@ -457,11 +447,10 @@ func (r *RoleRepo) AddGroupPolicy(
 	for i, o := range m.Buttons {
 		// 检查按钮模型的有效性
 		if o.ID == 0 {
-			r.log.Warn(
+			ctxutil.Logger(ctx, r.log).Warn(
 				"跳过无效按钮",
 				zap.Object(database.ModelKey, role),
 				zap.Int("button_index", i),
-				zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			)
 			continue
 		}
@@ -469,20 +458,18 @@ func (r *RoleRepo) AddGroupPolicy(
 		rules = append(rules, []string{sub, obj})
 	}
 	if err := auth.AddGroupPolicies(ctx, r.enforcer, rules); err != nil {
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"添加角色关联策略失败",
 			zap.Error(err),
 			zap.Object(database.ModelKey, role),
 			zap.Any("rules", rules),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			zap.Duration(log.DurationKey, time.Since(now)),
 		)
 		return errors.WrapIf(err, "添加角色关联策略失败")
 	}
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"添加角色关联策略成功",
 		zap.Object(database.ModelKey, role),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		zap.Duration(log.DurationKey, time.Since(now)),
 	)
 	return nil
@@ -519,10 +506,9 @@ func (r *RoleRepo) RemoveGroupPolicy(
 		return errors.New("RemoveGroupPolicy操作失败: 角色ID不能为0")
 	}
 
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"开始删除该角色作为子级的组策略",
 		zap.Object(database.ModelKey, role),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	rmSubStartTime := time.Now()
@@ -530,21 +516,19 @@ func (r *RoleRepo) RemoveGroupPolicy(
 
 	// 删除该角色作为子级的策略（被其他策略继承）
 	if err := auth.RemoveFilteredGroupingPolicy(ctx, r.enforcer, 0, sub); err != nil {
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"删除角色作为子级策略失败(该策略继承自其他策略)",
 			zap.Error(err),
 			zap.Object(database.ModelKey, role),
 			zap.String(auth.GroupSubKey, sub),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			zap.Duration(log.DurationKey, time.Since(rmSubStartTime)),
 		)
 		return errors.WrapIf(err, "删除角色作为子级策略失败(该策略继承自其他策略)")
 	}
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"删除该角色作为子级的组策略成功",
 		zap.Object(database.ModelKey, role),
 		zap.String(auth.GroupSubKey, sub),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		zap.Duration(log.DurationKey, time.Since(rmSubStartTime)),
 	)
 	return nil
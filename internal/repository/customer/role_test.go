@@ -108,6 +108,39 @@ func (suite *RoleTestSuite) TestUpdateRole() {
 	suite.Greater(fm.UpdatedAt, role.UpdatedAt)
 }
 
+func (suite *RoleTestSuite) TestUpdateRoleWithVersion() {
+	role := CreateTestRoleModel()
+	err := suite.roleRepo.CreateModel(context.Background(), role, nil, nil, nil)
+	suite.NoError(err, "创建角色应该成功")
+
+	fm, err := suite.roleRepo.GetModel(context.Background(), []string{}, "id = ?", role.ID)
+	suite.NoError(err)
+	suite.Equal(uint32(1), fm.Version, "新建角色的版本号应该为1")
+
+	// 使用当前版本号更新成功, 版本号递增
+	err = suite.roleRepo.UpdateModel(context.Background(), map[string]any{
+		"descr":             "第一次修改",
+		database.VersionKey: fm.Version,
+	}, nil, nil, nil, "id = ?", role.ID)
+	suite.NoError(err, "版本号一致时更新角色应该成功")
+
+	fm, err = suite.roleRepo.GetModel(context.Background(), []string{}, "id = ?", role.ID)
+	suite.NoError(err)
+	suite.Equal(uint32(2), fm.Version, "更新后版本号应该递增")
+
+	// 使用过期的版本号更新失败, 数据保持不变
+	err = suite.roleRepo.UpdateModel(context.Background(), map[string]any{
+		"descr":             "第二次修改",
+		database.VersionKey: uint32(1),
+	}, nil, nil, nil, "id = ?", role.ID)
+	suite.True(database.IsVersionConflict(err), "版本号过期时应该返回版本冲突错误")
+
+	fm, err = suite.roleRepo.GetModel(context.Background(), []string{}, "id = ?", role.ID)
+	suite.NoError(err)
+	suite.Equal("第一次修改", fm.Descr)
+	suite.Equal(uint32(2), fm.Version)
+}
+
 func (suite *RoleTestSuite) TestDeleteRole() {
 	// 创建角色
 	role := CreateTestRoleModel()
@@ -0,0 +1,332 @@
+package customer
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	custmodel "gin-artweb/internal/model/customer"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/log"
+)
+
+// UserSessionRepo 用户会话仓库实现
+// 负责用户会话模型的CRUD操作
+// 使用GORM进行数据库操作
+type UserSessionRepo struct {
+	log      *zap.Logger       // 日志记录器
+	gormDB   *gorm.DB          // GORM数据库连接
+	timeouts *config.DBTimeout // 数据库操作超时配置
+}
+
+// NewUserSessionRepo 创建用户会话仓库实例
+//
+// 参数：
+//
+//	log: 日志记录器，用于记录操作日志
+//	gormDB: GORM数据库连接，用于执行数据库操作
+//	timeouts: 数据库操作超时配置，控制各类数据库操作的超时时间
+//
+// 返回值：
+//
+//	UserSessionRepo: 用户会话仓库接口实现
+func NewUserSessionRepo(
+	log *zap.Logger,
+	gormDB *gorm.DB,
+	timeouts *config.DBTimeout,
+) *UserSessionRepo {
+	return &UserSessionRepo{
+		log:      log,
+		gormDB:   gormDB,
+		timeouts: timeouts,
+	}
+}
+
+// CreateModel 创建用户会话模型
+//
+// 参数：
+//
+//	ctx: 上下文，用于传递请求信息和控制超时
+//	m: 用户会话模型，包含用户会话的详细信息
+//
+// 返回值：
+//
+//	error: 操作错误信息，成功则返回nil
+//
+// 功能：
+//  1. 检查用户会话模型是否为空
+//  2. 设置创建时间和更新时间
+//  3. 执行数据库创建操作
+//  4. 记录操作日志
+func (r *UserSessionRepo) CreateModel(ctx context.Context, m *custmodel.UserSessionModel) error {
+	// 检查参数
+	if m == nil {
+		err := errors.New("创建用户会话模型失败: 模型为空")
+		ctxutil.Logger(ctx, r.log).Error(
+			"创建用户会话模型失败: 模型为空",
+			zap.Error(err),
+		)
+		return err
+	}
+
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始创建用户会话模型",
+		zap.Object(database.ModelKey, m),
+	)
+	now := time.Now()
+	m.CreatedAt = now
+	m.UpdatedAt = now
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBCreate(dbCtx, r.gormDB, &custmodel.UserSessionModel{}, m, nil); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"创建用户会话模型失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, m),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return errors.WrapIf(err, "创建用户会话模型失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"创建用户会话模型成功",
+		zap.Object(database.ModelKey, m),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return nil
+}
+
+// UpdateModel 更新用户会话模型
+//
+// 参数：
+//
+//	ctx: 上下文，用于传递请求信息和控制超时
+//	data: 更新数据，包含要更新的字段和值
+//	conds: 查询条件，用于指定要更新的记录
+//
+// 返回值：
+//
+//	error: 操作错误信息，成功则返回nil
+//
+// 功能：
+//  1. 检查更新数据是否为空
+//  2. 执行数据库更新操作
+//  3. 记录操作日志
+func (r *UserSessionRepo) UpdateModel(ctx context.Context, data map[string]any, conds ...any) error {
+	if len(data) == 0 {
+		err := errors.New("更新用户会话模型失败: 更新数据为空")
+		ctxutil.Logger(ctx, r.log).Error(
+			"更新用户会话模型失败: 更新数据为空",
+			zap.Error(err),
+			zap.Any(database.UpdateDataKey, data),
+		)
+		return err
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始更新用户会话模型",
+		zap.Any(database.UpdateDataKey, data),
+		zap.Any(database.ConditionsKey, conds),
+	)
+	now := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBUpdate(dbCtx, r.gormDB, &custmodel.UserSessionModel{}, data, nil, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"更新用户会话模型失败",
+			zap.Error(err),
+			zap.Any(database.UpdateDataKey, data),
+			zap.Any(database.ConditionsKey, conds),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return errors.WrapIf(err, "更新用户会话模型失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"更新用户会话模型成功",
+		zap.Any(database.UpdateDataKey, data),
+		zap.Any(database.ConditionsKey, conds),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return nil
+}
+
+// RotateRefreshID 轮换会话的刷新令牌
+//
+// 只有会话当前的刷新令牌ID为refreshID时才更新, 并发刷新同一个令牌时只有一个请求成功;
+// 返回false表示会话不存在或刷新令牌已被轮换
+func (r *UserSessionRepo) RotateRefreshID(
+	ctx context.Context,
+	id uint32,
+	refreshID string,
+	data map[string]any,
+) (bool, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始轮换用户会话刷新令牌",
+		zap.Uint32("id", id),
+		zap.String("refresh_id", refreshID),
+		zap.Any(database.UpdateDataKey, data),
+	)
+	now := time.Now()
+	result := database.Conn(ctx, r.gormDB).
+		Model(&custmodel.UserSessionModel{}).
+		Where("id = ? AND refresh_id = ?", id, refreshID).
+		Updates(data)
+	if result.Error != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"轮换用户会话刷新令牌失败",
+			zap.Error(result.Error),
+			zap.Uint32("id", id),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return false, errors.WrapIf(result.Error, "轮换用户会话刷新令牌失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"轮换用户会话刷新令牌完成",
+		zap.Uint32("id", id),
+		zap.Int64("rows_affected", result.RowsAffected),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return result.RowsAffected > 0, nil
+}
+
+// DeleteModel 删除用户会话模型
+//
+// 参数：
+//
+//	ctx: 上下文，用于传递请求信息和控制超时
+//	conds: 查询条件，用于指定要删除的记录
+//
+// 返回值：
+//
+//	error: 操作错误信息，成功则返回nil
+//
+// 功能：
+//  1. 执行数据库删除操作
+//  2. 记录操作日志
+func (r *UserSessionRepo) DeleteModel(ctx context.Context, conds ...any) error {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始删除用户会话模型",
+		zap.Any(database.ConditionsKey, conds),
+	)
+	now := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBDelete(dbCtx, r.gormDB, &custmodel.UserSessionModel{}, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"删除用户会话模型失败",
+			zap.Error(err),
+			zap.Any(database.ConditionsKey, conds),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return errors.WrapIf(err, "删除用户会话模型失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"删除用户会话模型成功",
+		zap.Any(database.ConditionsKey, conds),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return nil
+}
+
+// GetModel 查询单个用户会话模型
+//
+// 参数：
+//
+//	ctx: 上下文，用于传递请求信息和控制超时
+//	preloads: 需要预加载的关联关系
+//	conds: 查询条件，用于指定要查询的记录
+//
+// 返回值：
+//
+//	*custmodel.UserSessionModel: 用户会话模型指针，包含用户会话的详细信息
+//	error: 操作错误信息，成功则返回nil
+//
+// 功能：
+//  1. 执行数据库查询操作
+//  2. 预加载关联字段
+//  3. 获取单个用户会话模型
+//  4. 记录操作日志
+func (r *UserSessionRepo) GetModel(
+	ctx context.Context,
+	preloads []string,
+	conds ...any,
+) (*custmodel.UserSessionModel, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始查询用户会话模型",
+		zap.Strings(database.PreloadKey, preloads),
+		zap.Any(database.ConditionsKey, conds),
+	)
+	now := time.Now()
+	var m custmodel.UserSessionModel
+	dbCtx, cancel := database.ReadContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBGet(dbCtx, r.gormDB, preloads, &m, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"查询用户会话模型失败",
+			zap.Error(err),
+			zap.Strings(database.PreloadKey, preloads),
+			zap.Any(database.ConditionsKey, conds),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return nil, errors.WrapIf(err, "查询用户会话模型失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"查询用户会话模型成功",
+		zap.Object(database.ModelKey, &m),
+		zap.Strings(database.PreloadKey, preloads),
+		zap.Any(database.ConditionsKey, conds),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return &m, nil
+}
+
+// ListModel 查询用户会话模型列表
+//
+// 参数：
+//
+//	ctx: 上下文，用于传递请求信息和控制超时
+//	qp: 查询参数，包含分页、排序等查询条件
+//
+// 返回值：
+//
+//	int64: 总记录数
+//	*[]custmodel.UserSessionModel: 用户会话模型列表指针，包含符合条件的用户会话模型
+//	error: 操作错误信息，成功则返回nil
+//
+// 功能：
+//  1. 执行数据库查询操作
+//  2. 获取用户会话模型列表
+//  3. 返回总记录数和模型列表
+//  4. 记录操作日志
+func (r *UserSessionRepo) ListModel(
+	ctx context.Context,
+	qp database.QueryParams,
+) (int64, *[]custmodel.UserSessionModel, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始查询用户会话模型列表",
+		zap.Object(database.QueryParamsKey, &qp),
+	)
+	now := time.Now()
+	var ms []custmodel.UserSessionModel
+	dbCtx, cancel := database.ListContext(database.WithQueryTimeout(ctx, qp.Timeout), r.timeouts)
+	defer cancel()
+	count, err := database.DBList(dbCtx, r.gormDB, &custmodel.UserSessionModel{}, &ms, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"查询用户会话列表失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return 0, nil, errors.WrapIf(err, "查询用户会话列表失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"查询用户会话模型列表成功",
+		zap.Object(database.QueryParamsKey, &qp),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return count, &ms, nil
+}
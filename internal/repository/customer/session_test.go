@@ -0,0 +1,95 @@
+package customer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	custmodel "gin-artweb/internal/model/customer"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/test"
+)
+
+// CreateTestUserSessionModel 创建测试用的用户会话模型
+func CreateTestUserSessionModel(userID uint32) *custmodel.UserSessionModel {
+	now := time.Now()
+	return &custmodel.UserSessionModel{
+		SessionID:       uuid.NewString(),
+		UserID:          userID,
+		Method:          custmodel.SessionMethodPassword,
+		IPAddress:       "127.0.0.1",
+		AccessID:        uuid.NewString(),
+		RefreshID:       uuid.NewString(),
+		IssuedAt:        now,
+		RefreshedAt:     now,
+		AccessExpiresAt: now.Add(time.Minute),
+		ExpiresAt:       now.Add(time.Hour),
+	}
+}
+
+type UserSessionTestSuite struct {
+	suite.Suite
+	sessionRepo *UserSessionRepo
+}
+
+func (suite *UserSessionTestSuite) SetupSuite() {
+	db := test.NewTestGormDBWithConfig(nil)
+	db.AutoMigrate(&custmodel.UserSessionModel{})
+	suite.sessionRepo = NewUserSessionRepo(test.NewTestZapLogger(), db, test.NewTestDBTimeouts())
+}
+
+func TestUserSessionTestSuite(t *testing.T) {
+	suite.Run(t, new(UserSessionTestSuite))
+}
+
+func (suite *UserSessionTestSuite) TestCreateAndGet() {
+	ctx := context.Background()
+	m := CreateTestUserSessionModel(1)
+	suite.NoError(suite.sessionRepo.CreateModel(ctx, m))
+
+	fm, err := suite.sessionRepo.GetModel(ctx, nil, "session_id = ?", m.SessionID)
+	suite.NoError(err)
+	suite.Equal(m.UserID, fm.UserID)
+	suite.Equal(m.RefreshID, fm.RefreshID)
+
+	// 会话ID唯一
+	dup := CreateTestUserSessionModel(2)
+	dup.SessionID = m.SessionID
+	suite.Error(suite.sessionRepo.CreateModel(ctx, dup))
+}
+
+func (suite *UserSessionTestSuite) TestDeleteExpired() {
+	ctx := context.Background()
+	expired := CreateTestUserSessionModel(3)
+	expired.ExpiresAt = time.Now().Add(-time.Minute)
+	suite.NoError(suite.sessionRepo.CreateModel(ctx, expired))
+	suite.NoError(suite.sessionRepo.CreateModel(ctx, CreateTestUserSessionModel(3)))
+
+	suite.NoError(suite.sessionRepo.DeleteModel(ctx, "user_id = ? AND expires_at < ?", 3, time.Now()))
+	_, ms, err := suite.sessionRepo.ListModel(ctx, database.QueryParams{Query: map[string]any{"user_id = ?": 3}})
+	suite.NoError(err)
+	suite.Len(*ms, 1)
+}
+
+func (suite *UserSessionTestSuite) TestRotateRefreshID() {
+	ctx := context.Background()
+	m := CreateTestUserSessionModel(4)
+	suite.NoError(suite.sessionRepo.CreateModel(ctx, m))
+
+	newID := uuid.NewString()
+	ok, err := suite.sessionRepo.RotateRefreshID(ctx, m.ID, m.RefreshID, map[string]any{"refresh_id": newID})
+	suite.NoError(err)
+	suite.True(ok)
+
+	// 旧的刷新令牌ID不能再次轮换
+	ok, err = suite.sessionRepo.RotateRefreshID(ctx, m.ID, m.RefreshID, map[string]any{"refresh_id": uuid.NewString()})
+	suite.NoError(err)
+	suite.False(ok)
+
+	fm, err := suite.sessionRepo.GetModel(ctx, nil, "id = ?", m.ID)
+	suite.NoError(err)
+	suite.Equal(newID, fm.RefreshID)
+}
@@ -0,0 +1,180 @@
+package customer
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	custmodel "gin-artweb/internal/model/customer"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/log"
+)
+
+// SigningKeyRepo JWT签名密钥仓库实现
+// 每种令牌类型同一时刻只有一个当前密钥
+type SigningKeyRepo struct {
+	log      *zap.Logger       // 日志记录器
+	gormDB   *gorm.DB          // GORM数据库连接
+	timeouts *config.DBTimeout // 数据库操作超时配置
+}
+
+// NewSigningKeyRepo 创建JWT签名密钥仓库实例
+func NewSigningKeyRepo(
+	log *zap.Logger,
+	gormDB *gorm.DB,
+	timeouts *config.DBTimeout,
+) *SigningKeyRepo {
+	return &SigningKeyRepo{
+		log:      log,
+		gormDB:   gormDB,
+		timeouts: timeouts,
+	}
+}
+
+func (r *SigningKeyRepo) CreateModel(ctx context.Context, m *custmodel.SigningKeyModel) error {
+	// 检查参数
+	if m == nil {
+		err := errors.New("创建签名密钥失败: 模型为空")
+		ctxutil.Logger(ctx, r.log).Error(
+			"创建签名密钥失败: 模型为空",
+			zap.Error(err),
+		)
+		return err
+	}
+
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始创建签名密钥",
+		zap.Object(database.ModelKey, m),
+	)
+	startTime := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBCreate(dbCtx, r.gormDB, &custmodel.SigningKeyModel{}, m, nil); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"创建签名密钥失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, m),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return errors.WrapIf(err, "创建签名密钥失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"创建签名密钥成功",
+		zap.Object(database.ModelKey, m),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return nil
+}
+
+// RotateModel 保存新密钥并将其设置为当前密钥
+// 同类型的原当前密钥以及停用时间晚于retireAt的密钥在retireAt停用
+func (r *SigningKeyRepo) RotateModel(ctx context.Context, m *custmodel.SigningKeyModel, retireAt time.Time) error {
+	// 检查参数
+	if m == nil {
+		err := errors.New("轮换签名密钥失败: 模型为空")
+		ctxutil.Logger(ctx, r.log).Error(
+			"轮换签名密钥失败: 模型为空",
+			zap.Error(err),
+		)
+		return err
+	}
+
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始轮换签名密钥",
+		zap.Object(database.ModelKey, m),
+		zap.Time("retire_at", retireAt),
+	)
+	startTime := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	err := database.Conn(dbCtx, r.gormDB).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&custmodel.SigningKeyModel{}).
+			Where("token_type = ?", m.TokenType).
+			Where("is_current = ? OR retire_at IS NULL OR retire_at > ?", true, retireAt).
+			Updates(map[string]any{
+				"is_current": false,
+				"retire_at":  retireAt,
+			}).Error; err != nil {
+			return err
+		}
+		m.IsCurrent = true
+		return tx.Create(m).Error
+	})
+	if err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"轮换签名密钥失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, m),
+			zap.Time("retire_at", retireAt),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return errors.WrapIf(err, "轮换签名密钥失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"轮换签名密钥成功",
+		zap.Object(database.ModelKey, m),
+		zap.Time("retire_at", retireAt),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return nil
+}
+
+func (r *SigningKeyRepo) DeleteModel(ctx context.Context, conds ...any) error {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始删除签名密钥",
+		zap.Any(database.ConditionsKey, conds),
+	)
+	startTime := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBDelete(dbCtx, r.gormDB, &custmodel.SigningKeyModel{}, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"删除签名密钥失败",
+			zap.Error(err),
+			zap.Any(database.ConditionsKey, conds),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return errors.WrapIf(err, "删除签名密钥失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"删除签名密钥成功",
+		zap.Any(database.ConditionsKey, conds),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return nil
+}
+
+func (r *SigningKeyRepo) ListModel(
+	ctx context.Context,
+	qp database.QueryParams,
+) (int64, *[]custmodel.SigningKeyModel, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始查询签名密钥列表",
+		zap.Object(database.QueryParamsKey, &qp),
+	)
+	startTime := time.Now()
+	var ms []custmodel.SigningKeyModel
+	dbCtx, cancel := database.ListContext(database.WithQueryTimeout(ctx, qp.Timeout), r.timeouts)
+	defer cancel()
+	count, err := database.DBList(dbCtx, r.gormDB, &custmodel.SigningKeyModel{}, &ms, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"查询签名密钥列表失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return 0, nil, errors.WrapIf(err, "查询签名密钥列表失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"查询签名密钥列表成功",
+		zap.Object(database.QueryParamsKey, &qp),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return count, &ms, nil
+}
@@ -0,0 +1,97 @@
+package customer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	custmodel "gin-artweb/internal/model/customer"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/test"
+)
+
+func CreateTestSigningKeyModel(tokenType string) *custmodel.SigningKeyModel {
+	return &custmodel.SigningKeyModel{
+		KeyID:      uuid.NewString(),
+		TokenType:  tokenType,
+		Algorithm:  "HS256",
+		PrivateKey: "c2VjcmV0",
+		Operator:   "admin",
+	}
+}
+
+type SigningKeyTestSuite struct {
+	suite.Suite
+	keyRepo *SigningKeyRepo
+}
+
+func (suite *SigningKeyTestSuite) SetupTest() {
+	db := test.NewTestGormDBWithConfig(nil)
+	db.AutoMigrate(&custmodel.SigningKeyModel{})
+	dbTimeout := test.NewTestDBTimeouts()
+	logger := test.NewTestZapLogger()
+	suite.keyRepo = NewSigningKeyRepo(logger, db, dbTimeout)
+}
+
+func (suite *SigningKeyTestSuite) listByKid(kid string) custmodel.SigningKeyModel {
+	_, ms, err := suite.keyRepo.ListModel(context.Background(), database.QueryParams{
+		Query: map[string]any{"kid = ?": kid},
+	})
+	suite.NoError(err)
+	suite.Len(*ms, 1)
+	return (*ms)[0]
+}
+
+func (suite *SigningKeyTestSuite) TestRotateModel() {
+	ctx := context.Background()
+	now := time.Now()
+
+	k1 := CreateTestSigningKeyModel("access")
+	suite.NoError(suite.keyRepo.RotateModel(ctx, k1, now.Add(time.Hour)), "轮换签名密钥应该成功")
+	suite.True(k1.IsCurrent)
+
+	other := CreateTestSigningKeyModel("refresh")
+	suite.NoError(suite.keyRepo.RotateModel(ctx, other, now.Add(time.Hour)))
+
+	k2 := CreateTestSigningKeyModel("access")
+	suite.NoError(suite.keyRepo.RotateModel(ctx, k2, now.Add(time.Hour)))
+	fk1 := suite.listByKid(k1.KeyID)
+	suite.False(fk1.IsCurrent, "原当前密钥应该不再是当前密钥")
+	suite.NotNil(fk1.RetireAt)
+	suite.Equal(custmodel.SigningKeyStatusRetiring, fk1.Status(now))
+	suite.True(suite.listByKid(other.KeyID).IsCurrent, "其他令牌类型的密钥不应该受影响")
+
+	// 立即停用时全部旧密钥提前到当前时间停用
+	k3 := CreateTestSigningKeyModel("access")
+	suite.NoError(suite.keyRepo.RotateModel(ctx, k3, now))
+	for kid, status := range map[string]string{
+		k1.KeyID: custmodel.SigningKeyStatusRetired,
+		k2.KeyID: custmodel.SigningKeyStatusRetired,
+		k3.KeyID: custmodel.SigningKeyStatusCurrent,
+	} {
+		fk := suite.listByKid(kid)
+		suite.Equal(status, fk.Status(now))
+	}
+
+	suite.Error(suite.keyRepo.RotateModel(ctx, nil, now), "轮换空密钥应该返回错误")
+}
+
+func (suite *SigningKeyTestSuite) TestDeleteModel() {
+	ctx := context.Background()
+	k1 := CreateTestSigningKeyModel("access")
+	suite.NoError(suite.keyRepo.RotateModel(ctx, k1, time.Now()))
+	k2 := CreateTestSigningKeyModel("access")
+	suite.NoError(suite.keyRepo.RotateModel(ctx, k2, time.Now().Add(-time.Second)))
+
+	suite.NoError(suite.keyRepo.DeleteModel(ctx, "retire_at <= ?", time.Now()), "删除已停用的密钥应该成功")
+	count, _, err := suite.keyRepo.ListModel(ctx, database.QueryParams{IsCount: true})
+	suite.NoError(err)
+	suite.Equal(int64(1), count, "只应该保留当前密钥")
+}
+
+func TestSigningKeyTestSuite(t *testing.T) {
+	suite.Run(t, new(SigningKeyTestSuite))
+}
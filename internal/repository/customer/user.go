@@ -67,36 +67,34 @@ func (r *UserRepo) CreateModel(ctx context.Context, m *custmodel.UserModel) erro
 	// 检查参数
 	if m == nil {
 		err := errors.New("创建用户模型失败: 模型为空")
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"创建用户模型失败: 模型为空",
 			zap.Error(err),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return err
 	}
 
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"开始创建用户模型",
 		zap.Object(database.ModelKey, m),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	now := time.Now()
 	m.CreatedAt = now
 	m.UpdatedAt = now
-	if err := database.DBCreate(ctx, r.gormDB, &custmodel.UserModel{}, m, nil); err != nil {
-		r.log.Error(
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBCreate(dbCtx, r.gormDB, &custmodel.UserModel{}, m, nil); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
 			"创建用户模型失败",
 			zap.Error(err),
 			zap.Object(database.ModelKey, m),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			zap.Duration(log.DurationKey, time.Since(now)),
 		)
 		return errors.WrapIf(err, "创建用户模型失败")
 	}
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"创建用户模型成功",
 		zap.Object(database.ModelKey, m),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		zap.Duration(log.DurationKey, time.Since(now)),
 	)
 	return nil
@@ -121,37 +119,35 @@ func (r *UserRepo) CreateModel(ctx context.Context, m *custmodel.UserModel) erro
 func (r *UserRepo) UpdateModel(ctx context.Context, data map[string]any, conds ...any) error {
 	if len(data) == 0 {
 		err := errors.New("更新用户模型失败: 更新数据为空")
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"更新用户模型失败: 更新数据为空",
 			zap.Error(err),
 			zap.Any(database.UpdateDataKey, data),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return err
 	}
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"开始更新用户模型",
 		zap.Any(database.UpdateDataKey, data),
 		zap.Any(database.ConditionsKey, conds),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	now := time.Now()
-	if err := database.DBUpdate(ctx, r.gormDB, &custmodel.UserModel{}, data, nil, conds...); err != nil {
-		r.log.Error(
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBUpdate(dbCtx, r.gormDB, &custmodel.UserModel{}, data, nil, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
 			"更新用户模型失败",
 			zap.Error(err),
 			zap.Any(database.UpdateDataKey, data),
 			zap.Any(database.ConditionsKey, conds),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			zap.Duration(log.DurationKey, time.Since(now)),
 		)
 		return errors.WrapIf(err, "更新用户模型失败")
 	}
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"更新用户模型成功",
 		zap.Any(database.UpdateDataKey, data),
 		zap.Any(database.ConditionsKey, conds),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		zap.Duration(log.DurationKey, time.Since(now)),
 	)
 	return nil
@@ -172,26 +168,25 @@ func (r *UserRepo) UpdateModel(ctx context.Context, data map[string]any, conds .
 //  1. 执行数据库删除操作
 //  2. 记录操作日志
 func (r *UserRepo) DeleteModel(ctx context.Context, conds ...any) error {
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"开始删除用户模型",
 		zap.Any(database.ConditionsKey, conds),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	now := time.Now()
-	if err := database.DBDelete(ctx, r.gormDB, &custmodel.UserModel{}, conds...); err != nil {
-		r.log.Error(
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBDelete(dbCtx, r.gormDB, &custmodel.UserModel{}, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
 			"删除用户模型失败",
 			zap.Error(err),
 			zap.Any(database.ConditionsKey, conds),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			zap.Duration(log.DurationKey, time.Since(now)),
 		)
 		return errors.WrapIf(err, "删除用户模型失败")
 	}
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"删除用户模型成功",
 		zap.Any(database.ConditionsKey, conds),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		zap.Duration(log.DurationKey, time.Since(now)),
 	)
 	return nil
@@ -220,31 +215,30 @@ func (r *UserRepo) GetModel(
 	preloads []string,
 	conds ...any,
 ) (*custmodel.UserModel, error) {
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"开始查询用户模型",
 		zap.Strings(database.PreloadKey, preloads),
 		zap.Any(database.ConditionsKey, conds),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	now := time.Now()
 	var m custmodel.UserModel
-	if err := database.DBGet(ctx, r.gormDB, preloads, &m, conds...); err != nil {
-		r.log.Error(
+	dbCtx, cancel := database.ReadContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBGet(dbCtx, r.gormDB, preloads, &m, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
 			"查询用户模型失败",
 			zap.Error(err),
 			zap.Strings(database.PreloadKey, preloads),
 			zap.Any(database.ConditionsKey, conds),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			zap.Duration(log.DurationKey, time.Since(now)),
 		)
 		return nil, errors.WrapIf(err, "查询用户模型失败")
 	}
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"查询用户模型成功",
 		zap.Object(database.ModelKey, &m),
 		zap.Strings(database.PreloadKey, preloads),
 		zap.Any(database.ConditionsKey, conds),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		zap.Duration(log.DurationKey, time.Since(now)),
 	)
 	return &m, nil
@@ -272,28 +266,27 @@ func (r *UserRepo) ListModel(
 	ctx context.Context,
 	qp database.QueryParams,
 ) (int64, *[]custmodel.UserModel, error) {
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"开始查询用户模型列表",
 		zap.Object(database.QueryParamsKey, &qp),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	now := time.Now()
 	var ms []custmodel.UserModel
-	count, err := database.DBList(ctx, r.gormDB, &custmodel.UserModel{}, &ms, qp)
+	dbCtx, cancel := database.ListContext(database.WithQueryTimeout(ctx, qp.Timeout), r.timeouts)
+	defer cancel()
+	count, err := database.DBList(dbCtx, r.gormDB, &custmodel.UserModel{}, &ms, qp)
 	if err != nil {
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"查询用户列表失败",
 			zap.Error(err),
 			zap.Object(database.QueryParamsKey, &qp),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			zap.Duration(log.DurationKey, time.Since(now)),
 		)
 		return 0, nil, errors.WrapIf(err, "查询用户列表失败")
 	}
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"查询用户模型列表成功",
 		zap.Object(database.QueryParamsKey, &qp),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		zap.Duration(log.DurationKey, time.Since(now)),
 	)
 	return count, &ms, nil
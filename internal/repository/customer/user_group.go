@@ -0,0 +1,579 @@
+package customer
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/casbin/casbin/v2"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	custmodel "gin-artweb/internal/model/customer"
+	"gin-artweb/internal/shared/auth"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/log"
+)
+
+// UserGroupRepo 用户组仓库实现
+// 负责用户组模型的CRUD操作、组成员维护和用户组组策略的管理
+type UserGroupRepo struct {
+	log      *zap.Logger       // 日志记录器
+	gormDB   *gorm.DB          // GORM数据库连接
+	timeouts *config.DBTimeout // 数据库操作超时配置
+	enforcer *casbin.Enforcer  // Casbin权限管理器
+}
+
+// NewUserGroupRepo 创建用户组仓库实例
+//
+// 参数：
+//
+//	log: 日志记录器，用于记录操作日志
+//	gormDB: GORM数据库连接，用于执行数据库操作
+//	timeouts: 数据库操作超时配置，控制各类数据库操作的超时时间
+//	enforcer: Casbin权限管理器，用于管理用户组的组策略
+//
+// 返回值：
+//
+//	*UserGroupRepo: 用户组仓库实例
+func NewUserGroupRepo(
+	log *zap.Logger,
+	gormDB *gorm.DB,
+	timeouts *config.DBTimeout,
+	enforcer *casbin.Enforcer,
+) *UserGroupRepo {
+	return &UserGroupRepo{
+		log:      log,
+		gormDB:   gormDB,
+		timeouts: timeouts,
+		enforcer: enforcer,
+	}
+}
+
+// CreateModel 创建用户组模型
+//
+// 参数：
+//
+//	ctx: 上下文，用于传递请求信息和控制超时
+//	m: 用户组模型
+//	roles: 用户组关联的角色列表
+//	users: 用户组成员列表
+//
+// 返回值：
+//
+//	error: 操作错误信息，成功则返回nil
+//
+// 功能：
+//  1. 检查用户组模型是否为空
+//  2. 在同一事务中创建用户组及其角色、成员关联
+//  3. 记录操作日志
+func (r *UserGroupRepo) CreateModel(
+	ctx context.Context,
+	m *custmodel.UserGroupModel,
+	roles *[]custmodel.RoleModel,
+	users *[]custmodel.UserModel,
+) error {
+	if m == nil {
+		err := errors.New("创建用户组模型失败: 模型为空")
+		ctxutil.Logger(ctx, r.log).Error(
+			"创建用户组模型失败: 模型为空",
+			zap.Error(err),
+		)
+		return err
+	}
+
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始创建用户组模型",
+		zap.Object(database.ModelKey, m),
+	)
+
+	now := time.Now()
+	m.CreatedAt = now
+	m.UpdatedAt = now
+
+	upmap := make(map[string]any, 2)
+	if roles != nil && len(*roles) > 0 {
+		upmap["Roles"] = *roles
+	}
+	if users != nil && len(*users) > 0 {
+		upmap["Users"] = *users
+	}
+
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBCreate(dbCtx, r.gormDB, &custmodel.UserGroupModel{}, m, upmap); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"创建用户组模型失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, m),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return errors.WrapIf(err, "创建用户组模型失败")
+	}
+
+	ctxutil.Logger(ctx, r.log).Debug(
+		"创建用户组模型成功",
+		zap.Object(database.ModelKey, m),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return nil
+}
+
+// UpdateModel 更新用户组模型
+//
+// 参数：
+//
+//	ctx: 上下文，用于传递请求信息和控制超时
+//	data: 更新数据，包含要更新的字段和值
+//	roles: 用户组关联的角色列表，为nil时不更新
+//	users: 用户组成员列表，为nil时不更新
+//	conds: 查询条件，用于指定要更新的记录
+//
+// 返回值：
+//
+//	error: 操作错误信息，成功则返回nil
+//
+// 功能：
+//  1. 检查更新数据是否为空
+//  2. 在同一事务中更新用户组并替换其角色、成员关联
+//  3. 记录操作日志
+func (r *UserGroupRepo) UpdateModel(
+	ctx context.Context,
+	data map[string]any,
+	roles *[]custmodel.RoleModel,
+	users *[]custmodel.UserModel,
+	conds ...any,
+) error {
+	if len(data) == 0 {
+		err := errors.New("更新用户组模型失败: 更新数据为空")
+		ctxutil.Logger(ctx, r.log).Error(
+			"更新用户组模型失败: 更新数据为空",
+			zap.Error(err),
+			zap.Any(database.UpdateDataKey, data),
+		)
+		return err
+	}
+
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始更新用户组模型",
+		zap.Any(database.UpdateDataKey, data),
+		zap.Any(database.ConditionsKey, conds),
+	)
+
+	now := time.Now()
+	upmap := make(map[string]any, 2)
+	if roles != nil {
+		upmap["Roles"] = *roles
+	}
+	if users != nil {
+		upmap["Users"] = *users
+	}
+
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBUpdate(dbCtx, r.gormDB, &custmodel.UserGroupModel{}, data, upmap, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"更新用户组模型失败",
+			zap.Error(err),
+			zap.Any(database.UpdateDataKey, data),
+			zap.Any(database.ConditionsKey, conds),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return errors.WrapIf(err, "更新用户组模型失败")
+	}
+
+	ctxutil.Logger(ctx, r.log).Debug(
+		"更新用户组模型成功",
+		zap.Any(database.UpdateDataKey, data),
+		zap.Any(database.ConditionsKey, conds),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return nil
+}
+
+// DeleteModel 删除用户组模型
+//
+// 参数：
+//
+//	ctx: 上下文，用于传递请求信息和控制超时
+//	conds: 查询条件，用于指定要删除的记录
+//
+// 返回值：
+//
+//	error: 操作错误信息，成功则返回nil
+func (r *UserGroupRepo) DeleteModel(ctx context.Context, conds ...any) error {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始删除用户组模型",
+		zap.Any(database.ConditionsKey, conds),
+	)
+
+	now := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBDelete(dbCtx, r.gormDB, &custmodel.UserGroupModel{}, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"删除用户组模型失败",
+			zap.Error(err),
+			zap.Any(database.ConditionsKey, conds),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return errors.WrapIf(err, "删除用户组模型失败")
+	}
+
+	ctxutil.Logger(ctx, r.log).Debug(
+		"删除用户组模型成功",
+		zap.Any(database.ConditionsKey, conds),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return nil
+}
+
+// GetModel 获取单个用户组模型
+//
+// 参数：
+//
+//	ctx: 上下文，用于传递请求信息和控制超时
+//	preloads: 预加载的关联字段列表
+//	conds: 查询条件，用于指定要获取的记录
+//
+// 返回值：
+//
+//	*custmodel.UserGroupModel: 用户组模型指针
+//	error: 操作错误信息，成功则返回nil
+func (r *UserGroupRepo) GetModel(
+	ctx context.Context,
+	preloads []string,
+	conds ...any,
+) (*custmodel.UserGroupModel, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始查询用户组模型",
+		zap.Strings(database.PreloadKey, preloads),
+		zap.Any(database.ConditionsKey, conds),
+	)
+
+	now := time.Now()
+	var m custmodel.UserGroupModel
+	dbCtx, cancel := database.ReadContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBGet(dbCtx, r.gormDB, preloads, &m, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"查询用户组模型失败",
+			zap.Error(err),
+			zap.Strings(database.PreloadKey, preloads),
+			zap.Any(database.ConditionsKey, conds),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return nil, errors.WrapIf(err, "查询用户组模型失败")
+	}
+
+	ctxutil.Logger(ctx, r.log).Debug(
+		"查询用户组模型成功",
+		zap.Object(database.ModelKey, &m),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return &m, nil
+}
+
+// ListModel 获取用户组模型列表
+//
+// 参数：
+//
+//	ctx: 上下文，用于传递请求信息和控制超时
+//	qp: 查询参数，包含分页、排序等查询条件
+//
+// 返回值：
+//
+//	int64: 总记录数
+//	*[]custmodel.UserGroupModel: 用户组模型列表指针
+//	error: 操作错误信息，成功则返回nil
+func (r *UserGroupRepo) ListModel(
+	ctx context.Context,
+	qp database.QueryParams,
+) (int64, *[]custmodel.UserGroupModel, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始查询用户组模型列表",
+		zap.Object(database.QueryParamsKey, &qp),
+	)
+
+	now := time.Now()
+	var ms []custmodel.UserGroupModel
+	dbCtx, cancel := database.ListContext(database.WithQueryTimeout(ctx, qp.Timeout), r.timeouts)
+	defer cancel()
+	count, err := database.DBList(dbCtx, r.gormDB, &custmodel.UserGroupModel{}, &ms, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"查询用户组模型列表失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return 0, nil, errors.WrapIf(err, "查询用户组模型列表失败")
+	}
+
+	ctxutil.Logger(ctx, r.log).Debug(
+		"查询用户组模型列表成功",
+		zap.Object(database.QueryParamsKey, &qp),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return count, &ms, nil
+}
+
+// ListModelByUserID 获取用户所属的用户组列表
+//
+// 参数：
+//
+//	ctx: 上下文，用于传递请求信息和控制超时
+//	preloads: 预加载的关联字段列表
+//	userID: 用户ID
+//
+// 返回值：
+//
+//	[]custmodel.UserGroupModel: 用户所属的用户组列表
+//	error: 操作错误信息，成功则返回nil
+func (r *UserGroupRepo) ListModelByUserID(
+	ctx context.Context,
+	preloads []string,
+	userID uint32,
+) ([]custmodel.UserGroupModel, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始查询用户所属用户组",
+		zap.Uint32(ctxutil.UserIDKey, userID),
+		zap.Strings(database.PreloadKey, preloads),
+	)
+
+	now := time.Now()
+	var ms []custmodel.UserGroupModel
+	dbCtx, cancel := database.ListContext(ctx, r.timeouts)
+	defer cancel()
+	query := database.Conn(dbCtx, r.gormDB)
+	for _, preload := range preloads {
+		query = query.Preload(preload)
+	}
+	if err := query.
+		Joins("JOIN customer_user_group_user ON customer_user_group_user.group_id = customer_user_group.id").
+		Where("customer_user_group_user.user_id = ?", userID).
+		Find(&ms).Error; err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"查询用户所属用户组失败",
+			zap.Error(err),
+			zap.Uint32(ctxutil.UserIDKey, userID),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return nil, errors.WrapIf(err, "查询用户所属用户组失败")
+	}
+
+	ctxutil.Logger(ctx, r.log).Debug(
+		"查询用户所属用户组成功",
+		zap.Uint32(ctxutil.UserIDKey, userID),
+		zap.Int("count", len(ms)),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return ms, nil
+}
+
+// AddMembers 添加用户组成员
+//
+// 参数：
+//
+//	ctx: 上下文，用于传递请求信息和控制超时
+//	m: 用户组模型，ID不能为0
+//	users: 要加入用户组的用户列表
+//
+// 返回值：
+//
+//	error: 操作错误信息，成功则返回nil
+func (r *UserGroupRepo) AddMembers(
+	ctx context.Context,
+	m *custmodel.UserGroupModel,
+	users []custmodel.UserModel,
+) error {
+	return r.updateMembers(ctx, m, users, true)
+}
+
+// RemoveMembers 移除用户组成员
+//
+// 参数：
+//
+//	ctx: 上下文，用于传递请求信息和控制超时
+//	m: 用户组模型，ID不能为0
+//	users: 要移出用户组的用户列表
+//
+// 返回值：
+//
+//	error: 操作错误信息，成功则返回nil
+func (r *UserGroupRepo) RemoveMembers(
+	ctx context.Context,
+	m *custmodel.UserGroupModel,
+	users []custmodel.UserModel,
+) error {
+	return r.updateMembers(ctx, m, users, false)
+}
+
+func (r *UserGroupRepo) updateMembers(
+	ctx context.Context,
+	m *custmodel.UserGroupModel,
+	users []custmodel.UserModel,
+	add bool,
+) error {
+	if m == nil || m.ID == 0 {
+		return errors.New("更新用户组成员失败: 用户组ID不能为0")
+	}
+	if len(users) == 0 {
+		return nil
+	}
+
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始更新用户组成员",
+		zap.Uint32("group_id", m.ID),
+		zap.Bool("add", add),
+		zap.Int("count", len(users)),
+	)
+
+	now := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	association := database.Conn(dbCtx, r.gormDB).Model(m).Association("Users")
+	var err error
+	if add {
+		err = association.Append(&users)
+	} else {
+		err = association.Delete(&users)
+	}
+	if err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"更新用户组成员失败",
+			zap.Error(err),
+			zap.Uint32("group_id", m.ID),
+			zap.Bool("add", add),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return errors.WrapIf(err, "更新用户组成员失败")
+	}
+
+	ctxutil.Logger(ctx, r.log).Debug(
+		"更新用户组成员成功",
+		zap.Uint32("group_id", m.ID),
+		zap.Bool("add", add),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return nil
+}
+
+// AddGroupPolicy 添加用户组组策略
+//
+// 参数：
+//
+//	ctx: 上下文，用于传递请求信息和控制超时
+//	group: 用户组模型，需预加载Roles和Users
+//
+// 返回值：
+//
+//	error: 操作错误信息，成功则返回nil
+//
+// 功能：
+//  1. 用户组继承关联角色: g, group_X, role_Y
+//  2. 成员用户继承用户组: g, user_Z, group_X
+func (r *UserGroupRepo) AddGroupPolicy(
+	ctx context.Context,
+	group *custmodel.UserGroupModel,
+) error {
+	if group == nil {
+		return errors.New("AddGroupPolicy操作失败: 用户组模型不能为空")
+	}
+	if group.ID == 0 {
+		return errors.New("AddGroupPolicy操作失败: 用户组ID不能为0")
+	}
+
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始添加用户组关联策略",
+		zap.Object(database.ModelKey, group),
+	)
+
+	now := time.Now()
+	sub := auth.UserGroupToSubject(group.ID)
+	rules := make([][]string, 0, len(group.Roles)+len(group.Users))
+	for _, role := range group.Roles {
+		if role.ID == 0 {
+			continue
+		}
+		rules = append(rules, []string{sub, auth.RoleToSubject(role.ID)})
+	}
+	for _, user := range group.Users {
+		if user.ID == 0 {
+			continue
+		}
+		rules = append(rules, []string{auth.UserToSubject(user.ID), sub})
+	}
+	if err := auth.AddGroupPolicies(ctx, r.enforcer, rules); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"添加用户组关联策略失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, group),
+			zap.Any("rules", rules),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return errors.WrapIf(err, "添加用户组关联策略失败")
+	}
+
+	ctxutil.Logger(ctx, r.log).Debug(
+		"添加用户组关联策略成功",
+		zap.Object(database.ModelKey, group),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return nil
+}
+
+// RemoveGroupPolicy 删除用户组组策略
+//
+// 参数：
+//
+//	ctx: 上下文，用于传递请求信息和控制超时
+//	group: 用户组模型
+//
+// 返回值：
+//
+//	error: 操作错误信息，成功则返回nil
+//
+// 功能：
+//  1. 删除用户组继承角色的策略
+//  2. 删除成员用户继承该用户组的策略
+func (r *UserGroupRepo) RemoveGroupPolicy(
+	ctx context.Context,
+	group *custmodel.UserGroupModel,
+) error {
+	if group == nil {
+		return errors.New("RemoveGroupPolicy操作失败: 用户组模型不能为空")
+	}
+	if group.ID == 0 {
+		return errors.New("RemoveGroupPolicy操作失败: 用户组ID不能为0")
+	}
+
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始删除用户组关联策略",
+		zap.Object(database.ModelKey, group),
+	)
+
+	now := time.Now()
+	sub := auth.UserGroupToSubject(group.ID)
+	for _, index := range []int{0, 1} {
+		if err := auth.RemoveFilteredGroupingPolicy(ctx, r.enforcer, index, sub); err != nil {
+			ctxutil.Logger(ctx, r.log).Error(
+				"删除用户组关联策略失败",
+				zap.Error(err),
+				zap.Int("index", index),
+				zap.String(auth.GroupSubKey, sub),
+				zap.Duration(log.DurationKey, time.Since(now)),
+			)
+			return errors.WrapIf(err, "删除用户组关联策略失败")
+		}
+	}
+
+	ctxutil.Logger(ctx, r.log).Debug(
+		"删除用户组关联策略成功",
+		zap.String(auth.GroupSubKey, sub),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return nil
+}
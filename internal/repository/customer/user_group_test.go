@@ -0,0 +1,113 @@
+package customer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/casbin/casbin/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	custmodel "gin-artweb/internal/model/customer"
+	"gin-artweb/internal/shared/auth"
+	"gin-artweb/internal/shared/test"
+)
+
+type UserGroupTestSuite struct {
+	suite.Suite
+	enforcer  *casbin.Enforcer
+	roleRepo  *RoleRepo
+	userRepo  *UserRepo
+	groupRepo *UserGroupRepo
+}
+
+func (suite *UserGroupTestSuite) SetupSuite() {
+	db := test.NewTestGormDBWithConfig(nil)
+	db.AutoMigrate(
+		&custmodel.RoleModel{},
+		&custmodel.UserModel{},
+		&custmodel.UserGroupModel{},
+	)
+	dbTimeout := test.NewTestDBTimeouts()
+	logger := test.NewTestZapLogger()
+	suite.enforcer, _ = auth.NewCasbinEnforcer()
+	suite.roleRepo = NewRoleRepo(logger, db, dbTimeout, suite.enforcer)
+	suite.userRepo = NewUserRepo(logger, db, dbTimeout)
+	suite.groupRepo = NewUserGroupRepo(logger, db, dbTimeout, suite.enforcer)
+}
+
+func TestUserGroupTestSuite(t *testing.T) {
+	suite.Run(t, new(UserGroupTestSuite))
+}
+
+func (suite *UserGroupTestSuite) createRoleAndUser() (*custmodel.RoleModel, *custmodel.UserModel) {
+	ctx := context.Background()
+	role := CreateTestRoleModel()
+	suite.Require().NoError(suite.roleRepo.CreateModel(ctx, role, nil, nil, nil))
+	user := CreateTestUserModel(role.ID)
+	suite.Require().NoError(suite.userRepo.CreateModel(ctx, user))
+	return role, user
+}
+
+func (suite *UserGroupTestSuite) TestCreateAndMembers() {
+	ctx := context.Background()
+	role, user := suite.createRoleAndUser()
+	_, other := suite.createRoleAndUser()
+
+	m := &custmodel.UserGroupModel{Name: uuid.NewString()[:20]}
+	suite.NoError(suite.groupRepo.CreateModel(ctx, m,
+		&[]custmodel.RoleModel{*role}, &[]custmodel.UserModel{*user}))
+
+	fm, err := suite.groupRepo.GetModel(ctx, []string{"Roles", "Users"}, m.ID)
+	suite.NoError(err)
+	suite.Len(fm.Roles, 1)
+	suite.Len(fm.Users, 1)
+
+	suite.NoError(suite.groupRepo.AddMembers(ctx, fm, []custmodel.UserModel{*other}))
+	groups, err := suite.groupRepo.ListModelByUserID(ctx, []string{"Roles"}, other.ID)
+	suite.NoError(err)
+	suite.Require().Len(groups, 1)
+	suite.Equal(m.ID, groups[0].ID)
+	suite.Len(groups[0].Roles, 1)
+
+	suite.NoError(suite.groupRepo.RemoveMembers(ctx, fm, []custmodel.UserModel{*other}))
+	groups, err = suite.groupRepo.ListModelByUserID(ctx, nil, other.ID)
+	suite.NoError(err)
+	suite.Empty(groups)
+
+	// 更新时整体替换角色
+	suite.NoError(suite.groupRepo.UpdateModel(ctx, map[string]any{"id": m.ID, "descr": "运维"},
+		&[]custmodel.RoleModel{}, nil, "id = ?", m.ID))
+	fm, err = suite.groupRepo.GetModel(ctx, []string{"Roles", "Users"}, m.ID)
+	suite.NoError(err)
+	suite.Equal("运维", fm.Descr)
+	suite.Empty(fm.Roles)
+	suite.Len(fm.Users, 1)
+
+	suite.NoError(suite.groupRepo.DeleteModel(ctx, m.ID))
+	_, err = suite.groupRepo.GetModel(ctx, nil, m.ID)
+	suite.Error(err)
+}
+
+func (suite *UserGroupTestSuite) TestGroupPolicy() {
+	ctx := context.Background()
+	role, user := suite.createRoleAndUser()
+	m := &custmodel.UserGroupModel{Name: uuid.NewString()[:20]}
+	suite.NoError(suite.groupRepo.CreateModel(ctx, m, nil, nil))
+	m.Roles = []custmodel.RoleModel{*role}
+	m.Users = []custmodel.UserModel{*user}
+
+	suite.NoError(suite.groupRepo.AddGroupPolicy(ctx, m))
+	roles, err := suite.enforcer.GetImplicitRolesForUser(auth.UserToSubject(user.ID))
+	suite.NoError(err)
+	suite.Contains(roles, auth.UserGroupToSubject(m.ID))
+	suite.Contains(roles, auth.RoleToSubject(role.ID))
+
+	suite.NoError(suite.groupRepo.RemoveGroupPolicy(ctx, m))
+	roles, err = suite.enforcer.GetImplicitRolesForUser(auth.UserToSubject(user.ID))
+	suite.NoError(err)
+	suite.Empty(roles)
+
+	suite.Error(suite.groupRepo.AddGroupPolicy(ctx, nil))
+	suite.Error(suite.groupRepo.RemoveGroupPolicy(ctx, &custmodel.UserGroupModel{}))
+}
@@ -0,0 +1,159 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	jobsmodel "gin-artweb/internal/model/jobs"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/log"
+)
+
+// ArtifactRepo 执行产物仓库实现
+// 负责执行产物记录的创建、查询和删除, 产物文件保存在文件存储中
+type ArtifactRepo struct {
+	log      *zap.Logger       // 日志记录器
+	gormDB   *gorm.DB          // GORM数据库连接
+	timeouts *config.DBTimeout // 数据库操作超时配置
+}
+
+// NewArtifactRepo 创建执行产物仓库实例
+func NewArtifactRepo(
+	log *zap.Logger,
+	gormDB *gorm.DB,
+	timeouts *config.DBTimeout,
+) *ArtifactRepo {
+	return &ArtifactRepo{
+		log:      log,
+		gormDB:   gormDB,
+		timeouts: timeouts,
+	}
+}
+
+// CreateModel 创建执行产物记录
+func (r *ArtifactRepo) CreateModel(ctx context.Context, m *jobsmodel.ArtifactModel) error {
+	// 检查参数
+	if m == nil {
+		err := errors.New("创建执行产物记录失败: 模型为空")
+		ctxutil.Logger(ctx, r.log).Error(
+			"创建执行产物记录失败: 模型为空",
+			zap.Error(err),
+		)
+		return err
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始创建执行产物记录",
+		zap.Object(database.ModelKey, m),
+	)
+	startTime := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBCreate(dbCtx, r.gormDB, &jobsmodel.ArtifactModel{}, m, nil); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"创建执行产物记录失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, m),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return errors.WrapIf(err, "创建执行产物记录失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"创建执行产物记录成功",
+		zap.Object(database.ModelKey, m),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return nil
+}
+
+// DeleteModel 删除执行产物记录
+func (r *ArtifactRepo) DeleteModel(ctx context.Context, conds ...any) error {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始删除执行产物记录",
+		zap.Any(database.ConditionsKey, conds),
+	)
+	startTime := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBDelete(dbCtx, r.gormDB, &jobsmodel.ArtifactModel{}, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"删除执行产物记录失败",
+			zap.Error(err),
+			zap.Any(database.ConditionsKey, conds),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return errors.WrapIf(err, "删除执行产物记录失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"删除执行产物记录成功",
+		zap.Any(database.ConditionsKey, conds),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return nil
+}
+
+// GetModel 查询单个执行产物记录
+func (r *ArtifactRepo) GetModel(
+	ctx context.Context,
+	conds ...any,
+) (*jobsmodel.ArtifactModel, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始查询执行产物记录",
+		zap.Any(database.ConditionsKey, conds),
+	)
+	startTime := time.Now()
+	var m jobsmodel.ArtifactModel
+	dbCtx, cancel := database.ReadContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBGet(dbCtx, r.gormDB, nil, &m, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"查询执行产物记录失败",
+			zap.Error(err),
+			zap.Any(database.ConditionsKey, conds),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return nil, errors.WrapIf(err, "查询执行产物记录失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"查询执行产物记录成功",
+		zap.Object(database.ModelKey, &m),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return &m, nil
+}
+
+// ListModel 查询执行产物记录列表
+func (r *ArtifactRepo) ListModel(
+	ctx context.Context,
+	qp database.QueryParams,
+) (int64, *[]jobsmodel.ArtifactModel, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始查询执行产物记录列表",
+		zap.Object(database.QueryParamsKey, &qp),
+	)
+	startTime := time.Now()
+	var ms []jobsmodel.ArtifactModel
+	dbCtx, cancel := database.ListContext(database.WithQueryTimeout(ctx, qp.Timeout), r.timeouts)
+	defer cancel()
+	count, err := database.DBList(dbCtx, r.gormDB, &jobsmodel.ArtifactModel{}, &ms, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"查询执行产物记录列表失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return 0, nil, errors.WrapIf(err, "查询执行产物记录列表失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"查询执行产物记录列表成功",
+		zap.Object(database.QueryParamsKey, &qp),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return count, &ms, nil
+}
@@ -0,0 +1,67 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	jobsmodel "gin-artweb/internal/model/jobs"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/test"
+)
+
+func CreateTestArtifactModel(recordID uint32, name string) *jobsmodel.ArtifactModel {
+	return &jobsmodel.ArtifactModel{
+		RecordID:   recordID,
+		Name:       name,
+		StorageKey: fmt.Sprintf("artifacts/%d/%s", recordID, name),
+		Size:       1024,
+		CreatedAt:  time.Now(),
+	}
+}
+
+type ArtifactTestSuite struct {
+	suite.Suite
+	artifactRepo *ArtifactRepo
+}
+
+func (suite *ArtifactTestSuite) SetupSuite() {
+	db := test.NewTestGormDBWithConfig(nil)
+	db.AutoMigrate(&jobsmodel.ArtifactModel{})
+	suite.artifactRepo = NewArtifactRepo(test.NewTestZapLogger(), db, test.NewTestDBTimeouts())
+}
+
+// 创建、查询和删除执行产物记录测试
+func (suite *ArtifactTestSuite) TestArtifactCRUD() {
+	ctx := context.Background()
+	for _, name := range []string{"reports/a.csv", "reports/b.csv"} {
+		suite.Require().NoError(suite.artifactRepo.CreateModel(ctx, CreateTestArtifactModel(7, name)))
+	}
+	suite.Require().NoError(suite.artifactRepo.CreateModel(ctx, CreateTestArtifactModel(8, "c.csv")))
+	suite.Error(suite.artifactRepo.CreateModel(ctx, nil), "模型为空应该返回错误")
+
+	count, ms, err := suite.artifactRepo.ListModel(ctx, database.QueryParams{
+		IsCount: true,
+		Query:   map[string]any{"record_id = ?": 7},
+		OrderBy: []string{"name ASC"},
+	})
+	suite.Require().NoError(err)
+	suite.Equal(int64(2), count)
+	suite.Equal("reports/a.csv", (*ms)[0].Name)
+
+	m, err := suite.artifactRepo.GetModel(ctx, "record_id = ? AND id = ?", 7, (*ms)[1].ID)
+	suite.Require().NoError(err)
+	suite.Equal("artifacts/7/reports/b.csv", m.StorageKey)
+
+	suite.NoError(suite.artifactRepo.DeleteModel(ctx, "record_id = ?", 7))
+	count, _, err = suite.artifactRepo.ListModel(ctx, database.QueryParams{IsCount: true})
+	suite.NoError(err)
+	suite.Equal(int64(1), count, "只删除指定执行记录的产物")
+}
+
+func TestArtifactTestSuite(t *testing.T) {
+	suite.Run(t, new(ArtifactTestSuite))
+}
@@ -0,0 +1,265 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	jobsmodel "gin-artweb/internal/model/jobs"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/log"
+)
+
+// TradingHolidayRepo 交易日历节假日仓库实现
+// 负责节假日模型的导入、查询和删除
+type TradingHolidayRepo struct {
+	log      *zap.Logger       // 日志记录器
+	gormDB   *gorm.DB          // GORM数据库连接
+	timeouts *config.DBTimeout // 数据库操作超时配置
+}
+
+// NewTradingHolidayRepo 创建交易日历节假日仓库实例
+func NewTradingHolidayRepo(
+	log *zap.Logger,
+	gormDB *gorm.DB,
+	timeouts *config.DBTimeout,
+) *TradingHolidayRepo {
+	return &TradingHolidayRepo{
+		log:      log,
+		gormDB:   gormDB,
+		timeouts: timeouts,
+	}
+}
+
+// ReplaceYear 整体替换指定年份的节假日
+//
+// 参数：
+//
+//	ctx: 上下文，用于传递请求信息和控制超时
+//	year: 年份
+//	ms: 该年份的全部节假日, 为空时清空该年份
+//
+// 返回值：
+//
+//	error: 操作错误信息，成功则返回nil
+//
+// 功能：
+//  1. 在同一事务中删除该年份原有节假日并写入新的节假日
+//  2. 任一操作失败时整体回滚
+func (r *TradingHolidayRepo) ReplaceYear(
+	ctx context.Context,
+	year int,
+	ms []jobsmodel.TradingHolidayModel,
+) error {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始导入交易日历节假日",
+		zap.Int("year", year),
+		zap.Int("count", len(ms)),
+	)
+	startTime := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	err := database.Conn(dbCtx, r.gormDB).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("year = ?", year).Delete(&jobsmodel.TradingHolidayModel{}).Error; err != nil {
+			return err
+		}
+		if len(ms) == 0 {
+			return nil
+		}
+		return tx.Create(&ms).Error
+	})
+	if err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"导入交易日历节假日失败",
+			zap.Error(err),
+			zap.Int("year", year),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return errors.WrapIf(err, "导入交易日历节假日失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"导入交易日历节假日成功",
+		zap.Int("year", year),
+		zap.Int("count", len(ms)),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return nil
+}
+
+// DeleteModel 删除交易日历节假日模型
+func (r *TradingHolidayRepo) DeleteModel(ctx context.Context, conds ...any) error {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始删除交易日历节假日模型",
+		zap.Any(database.ConditionsKey, conds),
+	)
+	startTime := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBDelete(dbCtx, r.gormDB, &jobsmodel.TradingHolidayModel{}, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"删除交易日历节假日模型失败",
+			zap.Error(err),
+			zap.Any(database.ConditionsKey, conds),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return errors.WrapIf(err, "删除交易日历节假日模型失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"删除交易日历节假日模型成功",
+		zap.Any(database.ConditionsKey, conds),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return nil
+}
+
+// GetModel 查询单个交易日历节假日模型
+func (r *TradingHolidayRepo) GetModel(
+	ctx context.Context,
+	conds ...any,
+) (*jobsmodel.TradingHolidayModel, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始查询交易日历节假日模型",
+		zap.Any(database.ConditionsKey, conds),
+	)
+	startTime := time.Now()
+	var m jobsmodel.TradingHolidayModel
+	dbCtx, cancel := database.ReadContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBGet(dbCtx, r.gormDB, nil, &m, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"查询交易日历节假日模型失败",
+			zap.Error(err),
+			zap.Any(database.ConditionsKey, conds),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return nil, errors.WrapIf(err, "查询交易日历节假日模型失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"查询交易日历节假日模型成功",
+		zap.Object(database.ModelKey, &m),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return &m, nil
+}
+
+// ListModel 查询交易日历节假日模型列表
+func (r *TradingHolidayRepo) ListModel(
+	ctx context.Context,
+	qp database.QueryParams,
+) (int64, *[]jobsmodel.TradingHolidayModel, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始查询交易日历节假日模型列表",
+		zap.Object(database.QueryParamsKey, &qp),
+	)
+	startTime := time.Now()
+	var ms []jobsmodel.TradingHolidayModel
+	dbCtx, cancel := database.ListContext(database.WithQueryTimeout(ctx, qp.Timeout), r.timeouts)
+	defer cancel()
+	count, err := database.DBList(dbCtx, r.gormDB, &jobsmodel.TradingHolidayModel{}, &ms, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"查询交易日历节假日模型列表失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return 0, nil, errors.WrapIf(err, "查询交易日历节假日模型列表失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"查询交易日历节假日模型列表成功",
+		zap.Object(database.QueryParamsKey, &qp),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return count, &ms, nil
+}
+
+// ScheduleSkipRepo 计划任务跳过记录仓库实现
+type ScheduleSkipRepo struct {
+	log      *zap.Logger       // 日志记录器
+	gormDB   *gorm.DB          // GORM数据库连接
+	timeouts *config.DBTimeout // 数据库操作超时配置
+}
+
+// NewScheduleSkipRepo 创建计划任务跳过记录仓库实例
+func NewScheduleSkipRepo(
+	log *zap.Logger,
+	gormDB *gorm.DB,
+	timeouts *config.DBTimeout,
+) *ScheduleSkipRepo {
+	return &ScheduleSkipRepo{
+		log:      log,
+		gormDB:   gormDB,
+		timeouts: timeouts,
+	}
+}
+
+// CreateModel 创建计划任务跳过记录
+func (r *ScheduleSkipRepo) CreateModel(ctx context.Context, m *jobsmodel.ScheduleSkipModel) error {
+	// 检查参数
+	if m == nil {
+		err := errors.New("创建计划任务跳过记录失败: 模型为空")
+		ctxutil.Logger(ctx, r.log).Error(
+			"创建计划任务跳过记录失败: 模型为空",
+			zap.Error(err),
+		)
+		return err
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始创建计划任务跳过记录",
+		zap.Object(database.ModelKey, m),
+	)
+	startTime := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBCreate(dbCtx, r.gormDB, &jobsmodel.ScheduleSkipModel{}, m, nil); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"创建计划任务跳过记录失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, m),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return errors.WrapIf(err, "创建计划任务跳过记录失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"创建计划任务跳过记录成功",
+		zap.Object(database.ModelKey, m),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return nil
+}
+
+// ListModel 查询计划任务跳过记录列表
+func (r *ScheduleSkipRepo) ListModel(
+	ctx context.Context,
+	qp database.QueryParams,
+) (int64, *[]jobsmodel.ScheduleSkipModel, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始查询计划任务跳过记录列表",
+		zap.Object(database.QueryParamsKey, &qp),
+	)
+	startTime := time.Now()
+	var ms []jobsmodel.ScheduleSkipModel
+	dbCtx, cancel := database.ListContext(database.WithQueryTimeout(ctx, qp.Timeout), r.timeouts)
+	defer cancel()
+	count, err := database.DBList(dbCtx, r.gormDB, &jobsmodel.ScheduleSkipModel{}, &ms, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"查询计划任务跳过记录列表失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return 0, nil, errors.WrapIf(err, "查询计划任务跳过记录列表失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"查询计划任务跳过记录列表成功",
+		zap.Object(database.QueryParamsKey, &qp),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return count, &ms, nil
+}
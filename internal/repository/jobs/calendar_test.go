@@ -0,0 +1,129 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	jobsmodel "gin-artweb/internal/model/jobs"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/test"
+)
+
+func CreateTestTradingHolidayModels(year int, dates ...string) []jobsmodel.TradingHolidayModel {
+	ms := make([]jobsmodel.TradingHolidayModel, 0, len(dates))
+	for _, d := range dates {
+		ms = append(ms, jobsmodel.TradingHolidayModel{
+			Date:     d,
+			Year:     year,
+			Name:     "节假日",
+			Username: "admin",
+		})
+	}
+	return ms
+}
+
+type CalendarTestSuite struct {
+	suite.Suite
+	holidayRepo *TradingHolidayRepo
+	skipRepo    *ScheduleSkipRepo
+}
+
+func (suite *CalendarTestSuite) SetupSuite() {
+	db := test.NewTestGormDBWithConfig(nil)
+	db.AutoMigrate(
+		&jobsmodel.TradingHolidayModel{},
+		&jobsmodel.ScheduleSkipModel{},
+	)
+	dbTimeout := test.NewTestDBTimeouts()
+	logger := test.NewTestZapLogger()
+	suite.holidayRepo = NewTradingHolidayRepo(logger, db, dbTimeout)
+	suite.skipRepo = NewScheduleSkipRepo(logger, db, dbTimeout)
+}
+
+// ReplaceYear 导入交易日历测试
+func (suite *CalendarTestSuite) TestReplaceYear() {
+	ms := CreateTestTradingHolidayModels(2030, "2030-01-01", "2030-10-01", "2030-10-02")
+	err := suite.holidayRepo.ReplaceYear(context.Background(), 2030, ms)
+	suite.NoError(err, "导入交易日历应该成功")
+
+	count, _, err := suite.holidayRepo.ListModel(context.Background(), database.QueryParams{
+		IsCount: true,
+		Query:   map[string]any{"year = ?": 2030},
+	})
+	suite.NoError(err)
+	suite.Equal(int64(3), count)
+
+	// 再次导入时整体替换该年份
+	ms = CreateTestTradingHolidayModels(2030, "2030-10-01")
+	err = suite.holidayRepo.ReplaceYear(context.Background(), 2030, ms)
+	suite.NoError(err, "重新导入交易日历应该成功")
+
+	count, hs, err := suite.holidayRepo.ListModel(context.Background(), database.QueryParams{
+		IsCount: true,
+		Query:   map[string]any{"year = ?": 2030},
+	})
+	suite.NoError(err)
+	suite.Equal(int64(1), count, "原有节假日应该被替换")
+	suite.Equal("2030-10-01", (*hs)[0].Date)
+
+	// 同一次导入中的重复日期违反唯一约束, 应该整体回滚
+	ms = CreateTestTradingHolidayModels(2030, "2030-05-01", "2030-05-01")
+	err = suite.holidayRepo.ReplaceYear(context.Background(), 2030, ms)
+	suite.Error(err, "重复日期应该导入失败")
+
+	_, err = suite.holidayRepo.GetModel(context.Background(), "date = ?", "2030-10-01")
+	suite.NoError(err, "导入失败时原有节假日应该保留")
+}
+
+// ReplaceYear 不影响其他年份
+func (suite *CalendarTestSuite) TestReplaceYearIsolation() {
+	suite.NoError(suite.holidayRepo.ReplaceYear(context.Background(), 2031, CreateTestTradingHolidayModels(2031, "2031-01-01")))
+	suite.NoError(suite.holidayRepo.ReplaceYear(context.Background(), 2032, CreateTestTradingHolidayModels(2032, "2032-01-01")))
+	suite.NoError(suite.holidayRepo.ReplaceYear(context.Background(), 2032, nil), "清空年份应该成功")
+
+	_, err := suite.holidayRepo.GetModel(context.Background(), "date = ?", "2031-01-01")
+	suite.NoError(err, "其他年份的节假日不应该被删除")
+	_, err = suite.holidayRepo.GetModel(context.Background(), "date = ?", "2032-01-01")
+	suite.Error(err, "清空后不应该再查询到节假日")
+}
+
+// DeleteModel 删除节假日测试
+func (suite *CalendarTestSuite) TestDeleteModel() {
+	suite.NoError(suite.holidayRepo.ReplaceYear(context.Background(), 2033, CreateTestTradingHolidayModels(2033, "2033-01-01")))
+	m, err := suite.holidayRepo.GetModel(context.Background(), "date = ?", "2033-01-01")
+	suite.NoError(err)
+
+	suite.NoError(suite.holidayRepo.DeleteModel(context.Background(), m.ID), "删除节假日应该成功")
+	_, err = suite.holidayRepo.GetModel(context.Background(), m.ID)
+	suite.Error(err, "删除后不应该再查询到节假日")
+}
+
+// ScheduleSkip 跳过记录测试
+func (suite *CalendarTestSuite) TestScheduleSkip() {
+	for _, reason := range []string{jobsmodel.SkipReasonWeekend, jobsmodel.SkipReasonHoliday, jobsmodel.SkipReasonHoliday} {
+		err := suite.skipRepo.CreateModel(context.Background(), &jobsmodel.ScheduleSkipModel{
+			ScheduleID:   99,
+			ScheduleName: "test",
+			CalendarMode: jobsmodel.CalendarModeTradingDay,
+			Reason:       reason,
+			SkippedAt:    time.Now(),
+		})
+		suite.NoError(err, "创建跳过记录应该成功")
+	}
+	suite.Error(suite.skipRepo.CreateModel(context.Background(), nil), "创建空跳过记录应该返回错误")
+
+	count, ms, err := suite.skipRepo.ListModel(context.Background(), database.QueryParams{
+		IsCount: true,
+		Query:   map[string]any{"schedule_id = ?": 99, "reason = ?": jobsmodel.SkipReasonHoliday},
+	})
+	suite.NoError(err)
+	suite.Equal(int64(2), count)
+	suite.Len(*ms, 2)
+}
+
+func TestCalendarTestSuite(t *testing.T) {
+	suite.Run(t, new(CalendarTestSuite))
+}
@@ -66,35 +66,31 @@ func (r *RecordRepo) CreateModel(ctx context.Context, m *jobsmodel.ScriptRecordM
 	// 检查参数
 	if m == nil {
 		err := errors.New("创建脚本执行记录模型失败: 模型为空")
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"创建脚本执行记录模型失败: 模型为空",
 			zap.Error(err),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return err
 	}
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"开始创建脚本执行记录模型",
 		zap.Object(database.ModelKey, m),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	now := time.Now()
-	dbCtx, cancel := context.WithTimeout(ctx, r.timeouts.WriteTimeout)
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
 	defer cancel()
 	if err := database.DBCreate(dbCtx, r.gormDB, &jobsmodel.ScriptRecordModel{}, m, nil); err != nil {
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"创建脚本执行记录模型失败",
 			zap.Error(err),
 			zap.Object(database.ModelKey, m),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			zap.Duration(log.DurationKey, time.Since(now)),
 		)
 		return errors.WrapIf(err, "创建脚本执行记录模型失败")
 	}
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"创建脚本执行记录模型成功",
 		zap.Object(database.ModelKey, m),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		zap.Duration(log.DurationKey, time.Since(now)),
 	)
 	return nil
@@ -120,40 +116,36 @@ func (r *RecordRepo) UpdateModel(ctx context.Context, data map[string]any, conds
 	// 检查参数
 	if len(data) == 0 {
 		err := errors.New("更新脚本执行记录模型失败: 更新数据为空")
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"更新脚本执行记录模型失败: 更新数据为空",
 			zap.Error(err),
 			zap.Any(database.UpdateDataKey, data),
 			zap.Any(database.ConditionsKey, conds),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return err
 	}
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"开始更新脚本执行记录模型",
 		zap.Any(database.UpdateDataKey, data),
 		zap.Any(database.ConditionsKey, conds),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	startTime := time.Now()
-	dbCtx, cancel := context.WithTimeout(ctx, r.timeouts.WriteTimeout)
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
 	defer cancel()
 	if err := database.DBUpdate(dbCtx, r.gormDB, &jobsmodel.ScriptRecordModel{}, data, nil, conds...); err != nil {
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"更新脚本执行记录模型失败",
 			zap.Error(err),
 			zap.Any(database.UpdateDataKey, data),
 			zap.Any(database.ConditionsKey, conds),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			zap.Duration(log.DurationKey, time.Since(startTime)),
 		)
 		return errors.WrapIf(err, "更新脚本执行记录模型失败")
 	}
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"更新脚本执行记录模型成功",
 		zap.Any(database.UpdateDataKey, data),
 		zap.Any(database.ConditionsKey, conds),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		zap.Duration(log.DurationKey, time.Since(startTime)),
 	)
 	return nil
@@ -174,28 +166,25 @@ func (r *RecordRepo) UpdateModel(ctx context.Context, data map[string]any, conds
 //  1. 执行数据库删除操作
 //  2. 记录操作日志
 func (r *RecordRepo) DeleteModel(ctx context.Context, conds ...any) error {
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"开始删除脚本执行记录模型",
 		zap.Any(database.ConditionsKey, conds),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	startTime := time.Now()
-	dbCtx, cancel := context.WithTimeout(ctx, r.timeouts.WriteTimeout)
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
 	defer cancel()
 	if err := database.DBDelete(dbCtx, r.gormDB, &jobsmodel.ScriptRecordModel{}, conds...); err != nil {
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"删除脚本执行记录模型失败",
 			zap.Error(err),
 			zap.Any(database.ConditionsKey, conds),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			zap.Duration(log.DurationKey, time.Since(startTime)),
 		)
 		return errors.WrapIf(err, "删除脚本执行记录模型失败")
 	}
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"删除脚本执行记录模型成功",
 		zap.Any(database.ConditionsKey, conds),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		zap.Duration(log.DurationKey, time.Since(startTime)),
 	)
 	return nil
@@ -224,30 +213,27 @@ func (r *RecordRepo) GetModel(
 	preloads []string,
 	conds ...any,
 ) (*jobsmodel.ScriptRecordModel, error) {
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"开始查询脚本执行记录模型",
 		zap.Any(database.ConditionsKey, conds),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	startTime := time.Now()
 	var m jobsmodel.ScriptRecordModel
-	dbCtx, cancel := context.WithTimeout(ctx, r.timeouts.ReadTimeout)
+	dbCtx, cancel := database.ReadContext(ctx, r.timeouts)
 	defer cancel()
 	if err := database.DBGet(dbCtx, r.gormDB, preloads, &m, conds...); err != nil {
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"查询脚本执行记录模型失败",
 			zap.Error(err),
 			zap.Any(database.ConditionsKey, conds),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			zap.Duration(log.DurationKey, time.Since(startTime)),
 		)
 		return nil, errors.WrapIf(err, "查询脚本执行记录模型失败")
 	}
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"查询脚本执行记录模型成功",
 		zap.Object(database.ModelKey, &m),
 		zap.Any(database.ConditionsKey, conds),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		zap.Duration(log.DurationKey, time.Since(startTime)),
 	)
 	return &m, nil
@@ -275,30 +261,27 @@ func (r *RecordRepo) ListModel(
 	ctx context.Context,
 	qp database.QueryParams,
 ) (int64, *[]jobsmodel.ScriptRecordModel, error) {
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"开始查询脚本执行记录模型列表",
 		zap.Object(database.QueryParamsKey, &qp),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	startTime := time.Now()
 	var ms []jobsmodel.ScriptRecordModel
-	dbCtx, cancel := context.WithTimeout(ctx, r.timeouts.ListTimeout)
+	dbCtx, cancel := database.ListContext(database.WithQueryTimeout(ctx, qp.Timeout), r.timeouts)
 	defer cancel()
 	count, err := database.DBList(dbCtx, r.gormDB, &jobsmodel.ScriptRecordModel{}, &ms, qp)
 	if err != nil {
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"查询脚本执行记录模型列表失败",
 			zap.Error(err),
 			zap.Object(database.QueryParamsKey, &qp),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			zap.Duration(log.DurationKey, time.Since(startTime)),
 		)
 		return 0, nil, errors.WrapIf(err, "查询脚本执行记录模型列表失败")
 	}
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"查询脚本执行记录模型列表成功",
 		zap.Object(database.QueryParamsKey, &qp),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		zap.Duration(log.DurationKey, time.Since(startTime)),
 	)
 	return count, &ms, nil
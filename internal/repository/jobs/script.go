@@ -67,38 +67,34 @@ func (r *ScriptRepo) CreateModel(ctx context.Context, m *jobsmodel.ScriptModel)
 	// 检查参数
 	if m == nil {
 		err := errors.New("创建脚本模型失败: 模型为空")
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"创建脚本模型失败: 模型为空",
 			zap.Error(err),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return err
 	}
 
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"开始创建脚本模型",
 		zap.Object(database.ModelKey, m),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	now := time.Now()
 	m.CreatedAt = now
 	m.UpdatedAt = now
-	dbCtx, cancel := context.WithTimeout(ctx, r.timeouts.WriteTimeout)
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
 	defer cancel()
 	if err := database.DBCreate(dbCtx, r.gormDB, &jobsmodel.ScriptModel{}, m, nil); err != nil {
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"创建脚本模型失败",
 			zap.Error(err),
 			zap.Object(database.ModelKey, m),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			zap.Duration(log.DurationKey, time.Since(now)),
 		)
 		return errors.WrapIf(err, "创建脚本模型失败")
 	}
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"创建脚本模型成功",
 		zap.Object(database.ModelKey, m),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		zap.Duration(log.DurationKey, time.Since(now)),
 	)
 	return nil
@@ -124,40 +120,36 @@ func (r *ScriptRepo) UpdateModel(ctx context.Context, data map[string]any, conds
 	// 检查参数
 	if len(data) == 0 {
 		err := errors.New("更新脚本模型失败: 更新数据为空")
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"更新脚本模型失败: 更新数据为空",
 			zap.Error(err),
 			zap.Any(database.UpdateDataKey, data),
 			zap.Any(database.ConditionsKey, conds),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return err
 	}
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"开始更新脚本模型",
 		zap.Any(database.UpdateDataKey, data),
 		zap.Any(database.ConditionsKey, conds),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	startTime := time.Now()
-	dbCtx, cancel := context.WithTimeout(ctx, r.timeouts.WriteTimeout)
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
 	defer cancel()
 	if err := database.DBUpdate(dbCtx, r.gormDB, &jobsmodel.ScriptModel{}, data, nil, conds...); err != nil {
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"更新脚本模型失败",
 			zap.Error(err),
 			zap.Any(database.UpdateDataKey, data),
 			zap.Any(database.ConditionsKey, conds),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			zap.Duration(log.DurationKey, time.Since(startTime)),
 		)
 		return errors.WrapIf(err, "更新脚本模型失败")
 	}
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"更新脚本模型成功",
 		zap.Any(database.UpdateDataKey, data),
 		zap.Any(database.ConditionsKey, conds),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		zap.Duration(log.DurationKey, time.Since(startTime)),
 	)
 	return nil
@@ -178,28 +170,25 @@ func (r *ScriptRepo) UpdateModel(ctx context.Context, data map[string]any, conds
 //  1. 执行数据库删除操作
 //  2. 记录操作日志
 func (r *ScriptRepo) DeleteModel(ctx context.Context, conds ...any) error {
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"开始删除脚本模型",
 		zap.Any(database.ConditionsKey, conds),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	startTime := time.Now()
-	dbCtx, cancel := context.WithTimeout(ctx, r.timeouts.WriteTimeout)
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
 	defer cancel()
 	if err := database.DBDelete(dbCtx, r.gormDB, &jobsmodel.ScriptModel{}, conds...); err != nil {
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"删除脚本模型失败",
 			zap.Error(err),
 			zap.Any(database.ConditionsKey, conds),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			zap.Duration(log.DurationKey, time.Since(startTime)),
 		)
 		return errors.WrapIf(err, "删除脚本模型失败")
 	}
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"删除脚本模型成功",
 		zap.Any(database.ConditionsKey, conds),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		zap.Duration(log.DurationKey, time.Since(startTime)),
 	)
 	return nil
@@ -225,30 +214,27 @@ func (r *ScriptRepo) GetModel(
 	ctx context.Context,
 	conds ...any,
 ) (*jobsmodel.ScriptModel, error) {
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"开始查询脚本模型",
 		zap.Any(database.ConditionsKey, conds),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	startTime := time.Now()
 	var m jobsmodel.ScriptModel
-	dbCtx, cancel := context.WithTimeout(ctx, r.timeouts.ReadTimeout)
+	dbCtx, cancel := database.ReadContext(ctx, r.timeouts)
 	defer cancel()
 	if err := database.DBGet(dbCtx, r.gormDB, nil, &m, conds...); err != nil {
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"查询脚本模型失败",
 			zap.Error(err),
 			zap.Any(database.ConditionsKey, conds),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			zap.Duration(log.DurationKey, time.Since(startTime)),
 		)
 		return nil, errors.WrapIf(err, "查询脚本模型失败")
 	}
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"查询脚本模型成功",
 		zap.Object(database.ModelKey, &m),
 		zap.Any(database.ConditionsKey, conds),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		zap.Duration(log.DurationKey, time.Since(startTime)),
 	)
 	return &m, nil
@@ -276,30 +262,27 @@ func (r *ScriptRepo) ListModel(
 	ctx context.Context,
 	qp database.QueryParams,
 ) (int64, *[]jobsmodel.ScriptModel, error) {
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"开始查询脚本模型列表",
 		zap.Object(database.QueryParamsKey, &qp),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	startTime := time.Now()
 	var ms []jobsmodel.ScriptModel
-	dbCtx, cancel := context.WithTimeout(ctx, r.timeouts.ListTimeout)
+	dbCtx, cancel := database.ListContext(database.WithQueryTimeout(ctx, qp.Timeout), r.timeouts)
 	defer cancel()
 	count, err := database.DBList(dbCtx, r.gormDB, &jobsmodel.ScriptModel{}, &ms, qp)
 	if err != nil {
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"查询脚本模型列表失败",
 			zap.Error(err),
 			zap.Object(database.QueryParamsKey, &qp),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			zap.Duration(log.DurationKey, time.Since(startTime)),
 		)
 		return 0, nil, errors.WrapIf(err, "查询脚本模型列表失败")
 	}
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"查询脚本模型列表成功",
 		zap.Object(database.QueryParamsKey, &qp),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		zap.Duration(log.DurationKey, time.Since(startTime)),
 	)
 	return count, &ms, nil
@@ -324,31 +307,32 @@ func (r *ScriptRepo) ListProjects(
 	ctx context.Context,
 	query map[string]any,
 ) ([]string, error) {
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"开始查询脚本所有的项目名称",
 		zap.Any(database.QueryParamsKey, query),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
-	dbCtx, cancel := context.WithTimeout(ctx, r.timeouts.ReadTimeout)
+	dbCtx, cancel := database.ReadContext(ctx, r.timeouts)
 	defer cancel()
 
 	var projects []string
-	if err := r.gormDB.WithContext(dbCtx).Model(&jobsmodel.ScriptModel{}).Where(query).Distinct("project").Pluck("project", &projects).Error; err != nil {
-		r.log.Error(
+	mdb := database.Conn(dbCtx, r.gormDB).Model(&jobsmodel.ScriptModel{})
+	for k, v := range query {
+		mdb = mdb.Where(k, v)
+	}
+	if err := mdb.Distinct("project").Pluck("project", &projects).Error; err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
 			"查询项目名称失败",
 			zap.Error(err),
 			zap.Any(database.QueryParamsKey, query),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.WrapIf(err, "查询项目名称失败")
 	}
 
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"查询项目名称成功",
 		zap.Any("projects", projects),
 		zap.Any(database.QueryParamsKey, query),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	return projects, nil
@@ -373,31 +357,32 @@ func (r *ScriptRepo) ListLabels(
 	ctx context.Context,
 	query map[string]any,
 ) ([]string, error) {
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"开始查询所有标签名称",
 		zap.Any(database.QueryParamsKey, query),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	var labels []string
-	dbCtx, cancel := context.WithTimeout(ctx, r.timeouts.ReadTimeout)
+	dbCtx, cancel := database.ReadContext(ctx, r.timeouts)
 	defer cancel()
 
 	// 查询所有唯一的标签名称
-	if err := r.gormDB.WithContext(dbCtx).Model(&jobsmodel.ScriptModel{}).Where(query).Distinct("label").Pluck("label", &labels).Error; err != nil {
-		r.log.Error(
+	mdb := database.Conn(dbCtx, r.gormDB).Model(&jobsmodel.ScriptModel{})
+	for k, v := range query {
+		mdb = mdb.Where(k, v)
+	}
+	if err := mdb.Distinct("label").Pluck("label", &labels).Error; err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
 			"查询标签名称失败",
 			zap.Error(err),
 			zap.Any(database.QueryParamsKey, query),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.WrapIf(err, "查询标签名称失败")
 	}
 
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"查询标签名称成功",
 		zap.Any("labels", labels),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	return labels, nil
@@ -0,0 +1,334 @@
+package mds
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	mdsmodel "gin-artweb/internal/model/mds"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/log"
+)
+
+type MdsBackfillRunRepo struct {
+	log      *zap.Logger
+	gormDB   *gorm.DB
+	timeouts *config.DBTimeout
+}
+
+func NewMdsBackfillRunRepo(
+	log *zap.Logger,
+	gormDB *gorm.DB,
+	timeouts *config.DBTimeout,
+) *MdsBackfillRunRepo {
+	return &MdsBackfillRunRepo{
+		log:      log,
+		gormDB:   gormDB,
+		timeouts: timeouts,
+	}
+}
+
+func (r *MdsBackfillRunRepo) CreateModel(ctx context.Context, m *mdsmodel.MdsBackfillRunModel) error {
+	// 检查参数
+	if m == nil {
+		err := errors.New("创建mds数据回补失败: 模型为空")
+		ctxutil.Logger(ctx, r.log).Error(
+			"创建mds数据回补失败: 模型为空",
+			zap.Error(err),
+		)
+		return err
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始创建mds数据回补",
+		zap.Object(database.ModelKey, m),
+	)
+	now := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBCreate(dbCtx, r.gormDB, &mdsmodel.MdsBackfillRunModel{}, m, nil); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"创建mds数据回补失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, m),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return errors.WrapIf(err, "创建mds数据回补失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"创建mds数据回补成功",
+		zap.Object(database.ModelKey, m),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return nil
+}
+
+func (r *MdsBackfillRunRepo) UpdateModel(ctx context.Context, data map[string]any, conds ...any) error {
+	// 检查参数
+	if len(data) == 0 {
+		err := errors.New("更新mds数据回补失败: 更新数据为空")
+		ctxutil.Logger(ctx, r.log).Error(
+			"更新mds数据回补失败: 更新数据为空",
+			zap.Error(err),
+			zap.Any(database.UpdateDataKey, data),
+			zap.Any(database.ConditionsKey, conds),
+		)
+		return err
+	}
+
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始更新mds数据回补",
+		zap.Any(database.UpdateDataKey, data),
+		zap.Any(database.ConditionsKey, conds),
+	)
+	startTime := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBUpdate(dbCtx, r.gormDB, &mdsmodel.MdsBackfillRunModel{}, data, nil, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"更新mds数据回补失败",
+			zap.Error(err),
+			zap.Any(database.UpdateDataKey, data),
+			zap.Any(database.ConditionsKey, conds),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return errors.WrapIf(err, "更新mds数据回补失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"更新mds数据回补成功",
+		zap.Any(database.UpdateDataKey, data),
+		zap.Any(database.ConditionsKey, conds),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return nil
+}
+
+func (r *MdsBackfillRunRepo) DeleteModel(ctx context.Context, conds ...any) error {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始删除mds数据回补",
+		zap.Any(database.ConditionsKey, conds),
+	)
+	startTime := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBDelete(dbCtx, r.gormDB, &mdsmodel.MdsBackfillRunModel{}, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"删除mds数据回补失败",
+			zap.Error(err),
+			zap.Any(database.ConditionsKey, conds),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return errors.WrapIf(err, "删除mds数据回补失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"删除mds数据回补成功",
+		zap.Any(database.ConditionsKey, conds),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return nil
+}
+
+func (r *MdsBackfillRunRepo) GetModel(
+	ctx context.Context,
+	preloads []string,
+	conds ...any,
+) (*mdsmodel.MdsBackfillRunModel, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始查询mds数据回补",
+		zap.Any(database.ConditionsKey, conds),
+	)
+	startTime := time.Now()
+	var m mdsmodel.MdsBackfillRunModel
+	dbCtx, cancel := database.ReadContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBGet(dbCtx, r.gormDB, preloads, &m, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"查询mds数据回补失败",
+			zap.Error(err),
+			zap.Any(database.ConditionsKey, conds),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return nil, errors.WrapIf(err, "查询mds数据回补失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"查询mds数据回补成功",
+		zap.Object(database.ModelKey, &m),
+		zap.Any(database.ConditionsKey, conds),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return &m, nil
+}
+
+func (r *MdsBackfillRunRepo) ListModel(
+	ctx context.Context,
+	qp database.QueryParams,
+) (int64, *[]mdsmodel.MdsBackfillRunModel, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始查询mds数据回补列表",
+		zap.Object(database.QueryParamsKey, &qp),
+	)
+	startTime := time.Now()
+	var ms []mdsmodel.MdsBackfillRunModel
+	dbCtx, cancel := database.ListContext(database.WithQueryTimeout(ctx, qp.Timeout), r.timeouts)
+	defer cancel()
+	count, err := database.DBList(dbCtx, r.gormDB, &mdsmodel.MdsBackfillRunModel{}, &ms, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"查询mds数据回补列表失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return 0, nil, errors.WrapIf(err, "查询mds数据回补列表失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"查询mds数据回补列表成功",
+		zap.Object(database.QueryParamsKey, &qp),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return count, &ms, nil
+}
+
+// UpdateDayModel 更新数据回补中单个交易日的执行状态
+func (r *MdsBackfillRunRepo) UpdateDayModel(ctx context.Context, data map[string]any, conds ...any) error {
+	// 检查参数
+	if len(data) == 0 {
+		err := errors.New("更新mds数据回补的交易日失败: 更新数据为空")
+		ctxutil.Logger(ctx, r.log).Error(
+			"更新mds数据回补的交易日失败: 更新数据为空",
+			zap.Error(err),
+			zap.Any(database.UpdateDataKey, data),
+			zap.Any(database.ConditionsKey, conds),
+		)
+		return err
+	}
+
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始更新mds数据回补的交易日",
+		zap.Any(database.UpdateDataKey, data),
+		zap.Any(database.ConditionsKey, conds),
+	)
+	startTime := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBUpdate(dbCtx, r.gormDB, &mdsmodel.MdsBackfillDayModel{}, data, nil, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"更新mds数据回补的交易日失败",
+			zap.Error(err),
+			zap.Any(database.UpdateDataKey, data),
+			zap.Any(database.ConditionsKey, conds),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return errors.WrapIf(err, "更新mds数据回补的交易日失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"更新mds数据回补的交易日成功",
+		zap.Any(database.UpdateDataKey, data),
+		zap.Any(database.ConditionsKey, conds),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return nil
+}
+
+// ResetForRetry 将数据回补中未成功的交易日重置为等待执行, 成功的交易日保持不变
+// 仅已结束(失败或已取消)的数据回补可以重试, 否则返回gorm.ErrRecordNotFound
+func (r *MdsBackfillRunRepo) ResetForRetry(ctx context.Context, runID uint32) error {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始重置mds数据回补",
+		zap.Uint32("backfill_run_id", runID),
+	)
+	startTime := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	err := database.Conn(dbCtx, r.gormDB).Transaction(func(tx *gorm.DB) error {
+		// 以当前状态作为条件, 并发重试时只有一个能更新成功
+		result := tx.Model(&mdsmodel.MdsBackfillRunModel{}).
+			Where("id = ? AND status IN ?", runID, []string{
+				mdsmodel.BackfillStatusFailed,
+				mdsmodel.BackfillStatusCancelled,
+			}).
+			Updates(map[string]any{
+				"status":      mdsmodel.BackfillStatusPending,
+				"finished_at": nil,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return tx.Model(&mdsmodel.MdsBackfillDayModel{}).
+			Where("run_id = ? AND status <> ?", runID, mdsmodel.BackfillStatusSuccess).
+			Updates(map[string]any{
+				"status":        mdsmodel.BackfillStatusPending,
+				"error_message": "",
+				"started_at":    nil,
+				"finished_at":   nil,
+			}).Error
+	})
+	if err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"重置mds数据回补失败",
+			zap.Error(err),
+			zap.Uint32("backfill_run_id", runID),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return errors.WrapIf(err, "重置mds数据回补失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"重置mds数据回补成功",
+		zap.Uint32("backfill_run_id", runID),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return nil
+}
+
+// FailRunning 将未结束的数据回补及其交易日标记为失败, 用于服务重启后处理中断的回补
+func (r *MdsBackfillRunRepo) FailRunning(ctx context.Context, reason string) (int64, error) {
+	startTime := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	var count int64
+	unfinished := []string{mdsmodel.BackfillStatusPending, mdsmodel.BackfillStatusRunning}
+	err := database.Conn(dbCtx, r.gormDB).Transaction(func(tx *gorm.DB) error {
+		var runIDs []uint32
+		if err := tx.Model(&mdsmodel.MdsBackfillRunModel{}).
+			Where("status IN ?", unfinished).
+			Pluck("id", &runIDs).Error; err != nil {
+			return err
+		}
+		count = int64(len(runIDs))
+		if count == 0 {
+			return nil
+		}
+		now := time.Now()
+		if err := tx.Model(&mdsmodel.MdsBackfillDayModel{}).
+			Where("run_id IN ? AND status IN ?", runIDs, unfinished).
+			Updates(map[string]any{
+				"status":        mdsmodel.BackfillStatusFailed,
+				"error_message": reason,
+				"finished_at":   now,
+			}).Error; err != nil {
+			return err
+		}
+		return tx.Model(&mdsmodel.MdsBackfillRunModel{}).
+			Where("id IN ?", runIDs).
+			Updates(map[string]any{
+				"status":      mdsmodel.BackfillStatusFailed,
+				"finished_at": now,
+			}).Error
+	})
+	if err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"标记中断的mds数据回补失败",
+			zap.Error(err),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return 0, errors.WrapIf(err, "标记中断的mds数据回补失败")
+	}
+	return count, nil
+}
@@ -0,0 +1,138 @@
+package mds
+
+import (
+	"context"
+	"testing"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	mdsmodel "gin-artweb/internal/model/mds"
+	"gin-artweb/internal/shared/test"
+)
+
+func CreateTestMdsBackfillRunModel(colonyID uint32) *mdsmodel.MdsBackfillRunModel {
+	return &mdsmodel.MdsBackfillRunModel{
+		MdsColonyID: colonyID,
+		ColonyNum:   "01",
+		Script:      "mds_replay.sh",
+		ScriptID:    1,
+		StartDate:   "2024-01-02",
+		EndDate:     "2024-01-04",
+		Sequential:  true,
+		Concurrency: 1,
+		Timeout:     3600,
+		Status:      mdsmodel.BackfillStatusPending,
+		Username:    "admin",
+		Days: []mdsmodel.MdsBackfillDayModel{
+			{TradingDay: "2024-01-02", Sort: 0, Status: mdsmodel.BackfillStatusPending},
+			{TradingDay: "2024-01-03", Sort: 1, DependsOn: "2024-01-02", Status: mdsmodel.BackfillStatusPending},
+			{TradingDay: "2024-01-04", Sort: 2, DependsOn: "2024-01-03", Status: mdsmodel.BackfillStatusPending},
+		},
+	}
+}
+
+type MdsBackfillRunTestSuite struct {
+	suite.Suite
+	runRepo *MdsBackfillRunRepo
+}
+
+func (suite *MdsBackfillRunTestSuite) SetupSuite() {
+	db := test.NewTestGormDBWithConfig(nil)
+	db.AutoMigrate(&mdsmodel.MdsBackfillRunModel{}, &mdsmodel.MdsBackfillDayModel{})
+
+	dbTimeout := test.NewTestDBTimeouts()
+	logger := test.NewTestZapLogger()
+	suite.runRepo = NewMdsBackfillRunRepo(logger, db, dbTimeout)
+}
+
+func TestMdsBackfillRunTestSuite(t *testing.T) {
+	suite.Run(t, new(MdsBackfillRunTestSuite))
+}
+
+func (suite *MdsBackfillRunTestSuite) dayStatus(runID uint32) map[string]string {
+	fm, err := suite.runRepo.GetModel(context.Background(), []string{"Days"}, runID)
+	suite.Require().NoError(err)
+	result := make(map[string]string, len(fm.Days))
+	for _, d := range fm.Days {
+		result[d.TradingDay] = d.Status
+	}
+	return result
+}
+
+func (suite *MdsBackfillRunTestSuite) TestCreateModel() {
+	rm := CreateTestMdsBackfillRunModel(1)
+	suite.NoError(suite.runRepo.CreateModel(context.Background(), rm), "创建MdsBackfillRun应该成功")
+	suite.NotZero(rm.ID)
+	for _, d := range rm.Days {
+		suite.Equal(rm.ID, d.RunID, "交易日应该关联到数据回补")
+	}
+
+	fm, err := suite.runRepo.GetModel(context.Background(), []string{"Days"}, rm.ID)
+	suite.NoError(err)
+	suite.Len(fm.Days, 3, "应该预加载全部交易日")
+
+	suite.Error(suite.runRepo.CreateModel(context.Background(), nil), "创建空模型应该返回错误")
+}
+
+func (suite *MdsBackfillRunTestSuite) TestResetForRetry() {
+	ctx := context.Background()
+	rm := CreateTestMdsBackfillRunModel(2)
+	suite.Require().NoError(suite.runRepo.CreateModel(ctx, rm))
+
+	// 未结束的数据回补不能重试
+	err := suite.runRepo.ResetForRetry(ctx, rm.ID)
+	suite.True(errors.Is(err, gorm.ErrRecordNotFound))
+
+	suite.NoError(suite.runRepo.UpdateDayModel(ctx, map[string]any{"status": mdsmodel.BackfillStatusSuccess},
+		"run_id = ? AND trading_day = ?", rm.ID, "2024-01-02"))
+	suite.NoError(suite.runRepo.UpdateDayModel(ctx, map[string]any{
+		"status":        mdsmodel.BackfillStatusFailed,
+		"error_message": "exit 1",
+	}, "run_id = ? AND trading_day = ?", rm.ID, "2024-01-03"))
+	suite.NoError(suite.runRepo.UpdateDayModel(ctx, map[string]any{"status": mdsmodel.BackfillStatusSkipped},
+		"run_id = ? AND trading_day = ?", rm.ID, "2024-01-04"))
+	suite.NoError(suite.runRepo.UpdateModel(ctx, map[string]any{"status": mdsmodel.BackfillStatusFailed}, "id = ?", rm.ID))
+
+	suite.NoError(suite.runRepo.ResetForRetry(ctx, rm.ID))
+	suite.Equal(map[string]string{
+		"2024-01-02": mdsmodel.BackfillStatusSuccess,
+		"2024-01-03": mdsmodel.BackfillStatusPending,
+		"2024-01-04": mdsmodel.BackfillStatusPending,
+	}, suite.dayStatus(rm.ID), "只重置未成功的交易日")
+
+	fm, err := suite.runRepo.GetModel(ctx, []string{"Days"}, rm.ID)
+	suite.NoError(err)
+	suite.Equal(mdsmodel.BackfillStatusPending, fm.Status)
+	for _, d := range fm.Days {
+		suite.Empty(d.ErrorMessage)
+	}
+
+	// 已重置的数据回补不能重复重试
+	err = suite.runRepo.ResetForRetry(ctx, rm.ID)
+	suite.True(errors.Is(err, gorm.ErrRecordNotFound))
+}
+
+func (suite *MdsBackfillRunTestSuite) TestFailRunning() {
+	ctx := context.Background()
+	rm := CreateTestMdsBackfillRunModel(3)
+	rm.Status = mdsmodel.BackfillStatusRunning
+	rm.Days[0].Status = mdsmodel.BackfillStatusSuccess
+	rm.Days[1].Status = mdsmodel.BackfillStatusRunning
+	suite.Require().NoError(suite.runRepo.CreateModel(ctx, rm))
+
+	n, err := suite.runRepo.FailRunning(ctx, "服务重启")
+	suite.NoError(err)
+	suite.GreaterOrEqual(n, int64(1))
+
+	fm, err := suite.runRepo.GetModel(ctx, nil, rm.ID)
+	suite.NoError(err)
+	suite.Equal(mdsmodel.BackfillStatusFailed, fm.Status)
+	suite.NotNil(fm.FinishedAt)
+	suite.Equal(map[string]string{
+		"2024-01-02": mdsmodel.BackfillStatusSuccess,
+		"2024-01-03": mdsmodel.BackfillStatusFailed,
+		"2024-01-04": mdsmodel.BackfillStatusFailed,
+	}, suite.dayStatus(rm.ID))
+}
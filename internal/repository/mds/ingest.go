@@ -0,0 +1,422 @@
+package mds
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	mdsmodel "gin-artweb/internal/model/mds"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/log"
+)
+
+// ErrIngestRunning 数据源当日的采集正在进行
+var ErrIngestRunning = errors.Sentinel("行情数据采集正在进行")
+
+type MdsIngestSourceRepo struct {
+	log      *zap.Logger
+	gormDB   *gorm.DB
+	timeouts *config.DBTimeout
+}
+
+func NewMdsIngestSourceRepo(
+	log *zap.Logger,
+	gormDB *gorm.DB,
+	timeouts *config.DBTimeout,
+) *MdsIngestSourceRepo {
+	return &MdsIngestSourceRepo{
+		log:      log,
+		gormDB:   gormDB,
+		timeouts: timeouts,
+	}
+}
+
+func (r *MdsIngestSourceRepo) CreateModel(ctx context.Context, m *mdsmodel.MdsIngestSourceModel) error {
+	if m == nil {
+		err := errors.New("创建行情数据源失败: 模型为空")
+		ctxutil.Logger(ctx, r.log).Error(
+			"创建行情数据源失败: 模型为空",
+			zap.Error(err),
+		)
+		return err
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始创建行情数据源",
+		zap.Object(database.ModelKey, m),
+	)
+	now := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBCreate(dbCtx, r.gormDB, &mdsmodel.MdsIngestSourceModel{}, m, nil); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"创建行情数据源失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, m),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return errors.WrapIf(err, "创建行情数据源失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"创建行情数据源成功",
+		zap.Object(database.ModelKey, m),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return nil
+}
+
+func (r *MdsIngestSourceRepo) UpdateModel(ctx context.Context, data map[string]any, conds ...any) error {
+	if len(data) == 0 {
+		err := errors.New("更新行情数据源失败: 更新数据为空")
+		ctxutil.Logger(ctx, r.log).Error(
+			"更新行情数据源失败: 更新数据为空",
+			zap.Error(err),
+			zap.Any(database.ConditionsKey, conds),
+		)
+		return err
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始更新行情数据源",
+		zap.Any(database.UpdateDataKey, data),
+		zap.Any(database.ConditionsKey, conds),
+	)
+	now := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBUpdate(dbCtx, r.gormDB, &mdsmodel.MdsIngestSourceModel{}, data, nil, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"更新行情数据源失败",
+			zap.Error(err),
+			zap.Any(database.UpdateDataKey, data),
+			zap.Any(database.ConditionsKey, conds),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return errors.WrapIf(err, "更新行情数据源失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"更新行情数据源成功",
+		zap.Any(database.UpdateDataKey, data),
+		zap.Any(database.ConditionsKey, conds),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return nil
+}
+
+func (r *MdsIngestSourceRepo) DeleteModel(ctx context.Context, conds ...any) error {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始删除行情数据源",
+		zap.Any(database.ConditionsKey, conds),
+	)
+	now := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBDelete(dbCtx, r.gormDB, &mdsmodel.MdsIngestSourceModel{}, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"删除行情数据源失败",
+			zap.Error(err),
+			zap.Any(database.ConditionsKey, conds),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return errors.WrapIf(err, "删除行情数据源失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"删除行情数据源成功",
+		zap.Any(database.ConditionsKey, conds),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return nil
+}
+
+func (r *MdsIngestSourceRepo) GetModel(
+	ctx context.Context,
+	preloads []string,
+	conds ...any,
+) (*mdsmodel.MdsIngestSourceModel, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始查询行情数据源",
+		zap.Strings(database.PreloadKey, preloads),
+		zap.Any(database.ConditionsKey, conds),
+	)
+	now := time.Now()
+	var m mdsmodel.MdsIngestSourceModel
+	dbCtx, cancel := database.ReadContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBGet(dbCtx, r.gormDB, preloads, &m, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"查询行情数据源失败",
+			zap.Error(err),
+			zap.Any(database.ConditionsKey, conds),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return nil, errors.WrapIf(err, "查询行情数据源失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"查询行情数据源成功",
+		zap.Object(database.ModelKey, &m),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return &m, nil
+}
+
+func (r *MdsIngestSourceRepo) ListModel(
+	ctx context.Context,
+	qp database.QueryParams,
+) (int64, *[]mdsmodel.MdsIngestSourceModel, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始查询行情数据源列表",
+		zap.Object(database.QueryParamsKey, &qp),
+	)
+	now := time.Now()
+	var ms []mdsmodel.MdsIngestSourceModel
+	dbCtx, cancel := database.ListContext(database.WithQueryTimeout(ctx, qp.Timeout), r.timeouts)
+	defer cancel()
+	count, err := database.DBList(dbCtx, r.gormDB, &mdsmodel.MdsIngestSourceModel{}, &ms, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"查询行情数据源列表失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return 0, nil, errors.WrapIf(err, "查询行情数据源列表失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"查询行情数据源列表成功",
+		zap.Object(database.QueryParamsKey, &qp),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return count, &ms, nil
+}
+
+type MdsIngestRecordRepo struct {
+	log      *zap.Logger
+	gormDB   *gorm.DB
+	timeouts *config.DBTimeout
+}
+
+func NewMdsIngestRecordRepo(
+	log *zap.Logger,
+	gormDB *gorm.DB,
+	timeouts *config.DBTimeout,
+) *MdsIngestRecordRepo {
+	return &MdsIngestRecordRepo{
+		log:      log,
+		gormDB:   gormDB,
+		timeouts: timeouts,
+	}
+}
+
+// StartRun 标记数据源指定交易日的采集开始, 首次采集时创建记录, 重新采集时清空上次的结果并增加采集次数
+//
+// 同一数据源同一交易日的采集正在进行时返回ErrIngestRunning
+func (r *MdsIngestRecordRepo) StartRun(
+	ctx context.Context,
+	sourceID uint32,
+	tradingDay string,
+	location, triggerType, username string,
+) (*mdsmodel.MdsIngestRecordModel, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始标记行情数据采集开始",
+		zap.Uint32("source_id", sourceID),
+		zap.String("trading_day", tradingDay),
+	)
+	now := time.Now()
+	var m mdsmodel.MdsIngestRecordModel
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	err := database.Conn(dbCtx, r.gormDB).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("source_id = ? AND trading_day = ?", sourceID, tradingDay).Limit(1).Find(&m)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			m = mdsmodel.MdsIngestRecordModel{
+				SourceID:    sourceID,
+				TradingDay:  tradingDay,
+				Status:      mdsmodel.IngestStatusRunning,
+				Location:    location,
+				Attempts:    1,
+				TriggerType: triggerType,
+				Username:    username,
+				StartedAt:   &now,
+			}
+			return tx.Create(&m).Error
+		}
+		if m.Status == mdsmodel.IngestStatusRunning {
+			return errors.WithStack(ErrIngestRunning)
+		}
+		// 以当前状态作为条件, 并发重新采集时只有一个能更新成功
+		result = tx.Model(&mdsmodel.MdsIngestRecordModel{}).
+			Where("id = ? AND status = ?", m.ID, m.Status).
+			Updates(map[string]any{
+				"status":            mdsmodel.IngestStatusRunning,
+				"location":          location,
+				"file_size":         0,
+				"row_count":         0,
+				"expected_rows":     0,
+				"checksum":          "",
+				"expected_checksum": "",
+				"errors":            "",
+				"attempts":          gorm.Expr("attempts + 1"),
+				"trigger_type":      triggerType,
+				"username":          username,
+				"started_at":        now,
+				"finished_at":       nil,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errors.WithStack(ErrIngestRunning)
+		}
+		// 重新加载前清空, 避免已置空的字段保留旧值
+		id := m.ID
+		m = mdsmodel.MdsIngestRecordModel{}
+		return tx.First(&m, id).Error
+	})
+	if err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"标记行情数据采集开始失败",
+			zap.Error(err),
+			zap.Uint32("source_id", sourceID),
+			zap.String("trading_day", tradingDay),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return nil, errors.WrapIf(err, "标记行情数据采集开始失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"标记行情数据采集开始成功",
+		zap.Object(database.ModelKey, &m),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return &m, nil
+}
+
+// FinishRun 保存采集结果
+func (r *MdsIngestRecordRepo) FinishRun(ctx context.Context, m *mdsmodel.MdsIngestRecordModel) error {
+	if m == nil || m.ID == 0 {
+		return errors.New("保存行情数据采集结果失败: 记录ID不能为0")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始保存行情数据采集结果",
+		zap.Object(database.ModelKey, m),
+	)
+	now := time.Now()
+	m.FinishedAt = &now
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.Conn(dbCtx, r.gormDB).Model(&mdsmodel.MdsIngestRecordModel{}).
+		Where("id = ?", m.ID).
+		Updates(map[string]any{
+			"status":            m.Status,
+			"file_size":         m.FileSize,
+			"row_count":         m.RowCount,
+			"expected_rows":     m.ExpectedRows,
+			"checksum":          m.Checksum,
+			"expected_checksum": m.ExpectedChecksum,
+			"errors":            m.Errors,
+			"finished_at":       now,
+		}).Error; err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"保存行情数据采集结果失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, m),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return errors.WrapIf(err, "保存行情数据采集结果失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"保存行情数据采集结果成功",
+		zap.Object(database.ModelKey, m),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return nil
+}
+
+// FailRunning 将所有采集中的记录标记为失败, 用于服务重启后处理上次中断的采集
+func (r *MdsIngestRecordRepo) FailRunning(ctx context.Context, reason string) (int64, error) {
+	now := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	result := database.Conn(dbCtx, r.gormDB).Model(&mdsmodel.MdsIngestRecordModel{}).
+		Where("status = ?", mdsmodel.IngestStatusRunning).
+		Updates(map[string]any{
+			"status":      mdsmodel.IngestStatusFailed,
+			"errors":      reason,
+			"finished_at": now,
+		})
+	if result.Error != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"处理中断的行情数据采集记录失败",
+			zap.Error(result.Error),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return 0, errors.WrapIf(result.Error, "处理中断的行情数据采集记录失败")
+	}
+	return result.RowsAffected, nil
+}
+
+func (r *MdsIngestRecordRepo) GetModel(
+	ctx context.Context,
+	preloads []string,
+	conds ...any,
+) (*mdsmodel.MdsIngestRecordModel, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始查询行情数据采集记录",
+		zap.Strings(database.PreloadKey, preloads),
+		zap.Any(database.ConditionsKey, conds),
+	)
+	now := time.Now()
+	var m mdsmodel.MdsIngestRecordModel
+	dbCtx, cancel := database.ReadContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBGet(dbCtx, r.gormDB, preloads, &m, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"查询行情数据采集记录失败",
+			zap.Error(err),
+			zap.Any(database.ConditionsKey, conds),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return nil, errors.WrapIf(err, "查询行情数据采集记录失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"查询行情数据采集记录成功",
+		zap.Object(database.ModelKey, &m),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return &m, nil
+}
+
+func (r *MdsIngestRecordRepo) ListModel(
+	ctx context.Context,
+	qp database.QueryParams,
+) (int64, *[]mdsmodel.MdsIngestRecordModel, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始查询行情数据采集记录列表",
+		zap.Object(database.QueryParamsKey, &qp),
+	)
+	now := time.Now()
+	var ms []mdsmodel.MdsIngestRecordModel
+	dbCtx, cancel := database.ListContext(database.WithQueryTimeout(ctx, qp.Timeout), r.timeouts)
+	defer cancel()
+	count, err := database.DBList(dbCtx, r.gormDB, &mdsmodel.MdsIngestRecordModel{}, &ms, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"查询行情数据采集记录列表失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return 0, nil, errors.WrapIf(err, "查询行情数据采集记录列表失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"查询行情数据采集记录列表成功",
+		zap.Object(database.QueryParamsKey, &qp),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return count, &ms, nil
+}
@@ -0,0 +1,127 @@
+package mds
+
+import (
+	"context"
+	"testing"
+
+	"emperror.dev/errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	mdsmodel "gin-artweb/internal/model/mds"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/test"
+)
+
+func CreateTestMdsIngestSourceModel() *mdsmodel.MdsIngestSourceModel {
+	return &mdsmodel.MdsIngestSourceModel{
+		Name:      uuid.NewString(),
+		Kind:      mdsmodel.IngestSourceLocal,
+		Location:  "/data/mds/{date}/quote.csv",
+		Delimiter: ",",
+		HasHeader: true,
+		Columns:   "code:string\nprice:float\nvolume:int",
+		MaxSize:   100,
+		IsEnabled: true,
+	}
+}
+
+type MdsIngestTestSuite struct {
+	suite.Suite
+	sourceRepo *MdsIngestSourceRepo
+	recordRepo *MdsIngestRecordRepo
+}
+
+func (suite *MdsIngestTestSuite) SetupSuite() {
+	db := test.NewTestGormDBWithConfig(nil)
+	db.AutoMigrate(&mdsmodel.MdsIngestSourceModel{}, &mdsmodel.MdsIngestRecordModel{})
+	dbTimeout := test.NewTestDBTimeouts()
+	logger := test.NewTestZapLogger()
+	suite.sourceRepo = NewMdsIngestSourceRepo(logger, db, dbTimeout)
+	suite.recordRepo = NewMdsIngestRecordRepo(logger, db, dbTimeout)
+}
+
+func TestMdsIngestTestSuite(t *testing.T) {
+	suite.Run(t, new(MdsIngestTestSuite))
+}
+
+func (suite *MdsIngestTestSuite) createSource() *mdsmodel.MdsIngestSourceModel {
+	m := CreateTestMdsIngestSourceModel()
+	suite.Require().NoError(suite.sourceRepo.CreateModel(context.Background(), m))
+	return m
+}
+
+func (suite *MdsIngestTestSuite) TestSourceCRUD() {
+	ctx := context.Background()
+	m := suite.createSource()
+
+	suite.NoError(suite.sourceRepo.UpdateModel(ctx, map[string]any{"specification": "30 16 * * 1-5"}, "id = ?", m.ID))
+	fm, err := suite.sourceRepo.GetModel(ctx, nil, m.ID)
+	suite.Require().NoError(err)
+	suite.Equal("30 16 * * 1-5", fm.Specification)
+	suite.Len(fm.ColumnSpecs(), 3)
+
+	total, ms, err := suite.sourceRepo.ListModel(ctx, database.QueryParams{
+		Query:   map[string]any{"name = ?": m.Name},
+		IsCount: true,
+	})
+	suite.NoError(err)
+	suite.Equal(int64(1), total)
+	suite.Len(*ms, 1)
+
+	suite.NoError(suite.sourceRepo.DeleteModel(ctx, m.ID))
+	_, err = suite.sourceRepo.GetModel(ctx, nil, m.ID)
+	suite.Error(err)
+}
+
+func (suite *MdsIngestTestSuite) TestStartAndFinishRun() {
+	ctx := context.Background()
+	source := suite.createSource()
+
+	m, err := suite.recordRepo.StartRun(ctx, source.ID, "2024-01-02", "/data/a.csv", "cron", "")
+	suite.Require().NoError(err)
+	suite.Equal(mdsmodel.IngestStatusRunning, m.Status)
+	suite.Equal(1, m.Attempts)
+
+	// 采集进行中时不能重复采集
+	_, err = suite.recordRepo.StartRun(ctx, source.ID, "2024-01-02", "/data/a.csv", "manual", "admin")
+	suite.True(errors.Is(err, ErrIngestRunning))
+
+	m.Status = mdsmodel.IngestStatusFailed
+	m.RowCount = 10
+	m.Errors = "行数不一致\nMD5不一致"
+	suite.Require().NoError(suite.recordRepo.FinishRun(ctx, m))
+	fm, err := suite.recordRepo.GetModel(ctx, []string{"Source"}, m.ID)
+	suite.Require().NoError(err)
+	suite.Equal(mdsmodel.IngestStatusFailed, fm.Status)
+	suite.Equal([]string{"行数不一致", "MD5不一致"}, fm.ErrorList())
+	suite.NotNil(fm.FinishedAt)
+	suite.Equal(source.Name, fm.Source.Name)
+
+	// 重新采集复用同一条记录并清空上次的结果
+	rm, err := suite.recordRepo.StartRun(ctx, source.ID, "2024-01-02", "/data/b.csv", "manual", "admin")
+	suite.Require().NoError(err)
+	suite.Equal(m.ID, rm.ID)
+	suite.Equal(2, rm.Attempts)
+	suite.Equal(mdsmodel.IngestStatusRunning, rm.Status)
+	suite.Equal("/data/b.csv", rm.Location)
+	suite.Equal(int64(0), rm.RowCount)
+	suite.Empty(rm.Errors)
+	suite.Nil(rm.FinishedAt)
+
+	// 服务重启时将采集中的记录标记为失败
+	n, err := suite.recordRepo.FailRunning(ctx, "服务重启")
+	suite.NoError(err)
+	suite.GreaterOrEqual(n, int64(1))
+	fm, err = suite.recordRepo.GetModel(ctx, nil, m.ID)
+	suite.Require().NoError(err)
+	suite.Equal(mdsmodel.IngestStatusFailed, fm.Status)
+	suite.Equal([]string{"服务重启"}, fm.ErrorList())
+
+	total, _, err := suite.recordRepo.ListModel(ctx, database.QueryParams{
+		Query:   map[string]any{"trading_day = ?": "2024-01-02", "source_id = ?": source.ID},
+		IsCount: true,
+	})
+	suite.NoError(err)
+	suite.Equal(int64(1), total)
+}
@@ -0,0 +1,207 @@
+package mon
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	monmodel "gin-artweb/internal/model/mon"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/log"
+)
+
+// hostMetricBatchSize 批量写入主机指标时每批的条数
+const hostMetricBatchSize = 200
+
+type HostMetricRepo struct {
+	log      *zap.Logger
+	gormDB   *gorm.DB
+	timeouts *config.DBTimeout
+}
+
+func NewHostMetricRepo(
+	log *zap.Logger,
+	gormDB *gorm.DB,
+	timeouts *config.DBTimeout,
+) *HostMetricRepo {
+	return &HostMetricRepo{
+		log:      log,
+		gormDB:   gormDB,
+		timeouts: timeouts,
+	}
+}
+
+// CreateModels 批量写入主机指标
+func (r *HostMetricRepo) CreateModels(ctx context.Context, ms []monmodel.HostMetricModel) error {
+	// 检查参数
+	if len(ms) == 0 {
+		return nil
+	}
+
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始写入主机指标",
+		zap.Int("count", len(ms)),
+	)
+	startTime := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.Conn(dbCtx, r.gormDB).CreateInBatches(&ms, hostMetricBatchSize).Error; err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"写入主机指标失败",
+			zap.Error(err),
+			zap.Int("count", len(ms)),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return errors.WrapIf(err, "写入主机指标失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"写入主机指标成功",
+		zap.Int("count", len(ms)),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return nil
+}
+
+// ReplaceBuckets 替换聚合粒度为step且时间在[start, end)内的聚合数据, 重复聚合同一时间段时结果不变
+func (r *HostMetricRepo) ReplaceBuckets(
+	ctx context.Context,
+	step uint32,
+	start, end int64,
+	ms []monmodel.HostMetricModel,
+) error {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始替换主机指标聚合数据",
+		zap.Uint32("step", step),
+		zap.Int64("start", start),
+		zap.Int64("end", end),
+		zap.Int("count", len(ms)),
+	)
+	startTime := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	err := database.Conn(dbCtx, r.gormDB).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("step = ? AND ts >= ? AND ts < ?", step, start, end).
+			Delete(&monmodel.HostMetricModel{}).Error; err != nil {
+			return err
+		}
+		if len(ms) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(&ms, hostMetricBatchSize).Error
+	})
+	if err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"替换主机指标聚合数据失败",
+			zap.Error(err),
+			zap.Uint32("step", step),
+			zap.Int64("start", start),
+			zap.Int64("end", end),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return errors.WrapIf(err, "替换主机指标聚合数据失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"替换主机指标聚合数据成功",
+		zap.Uint32("step", step),
+		zap.Int("count", len(ms)),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return nil
+}
+
+// DeleteBefore 删除聚合粒度为step且时间早于ts的数据, 返回删除的条数
+func (r *HostMetricRepo) DeleteBefore(ctx context.Context, step uint32, ts int64) (int64, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始清理主机指标",
+		zap.Uint32("step", step),
+		zap.Int64("before", ts),
+	)
+	startTime := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	result := database.Conn(dbCtx, r.gormDB).
+		Where("step = ? AND ts < ?", step, ts).
+		Delete(&monmodel.HostMetricModel{})
+	if result.Error != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"清理主机指标失败",
+			zap.Error(result.Error),
+			zap.Uint32("step", step),
+			zap.Int64("before", ts),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return 0, errors.WrapIf(result.Error, "清理主机指标失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"清理主机指标成功",
+		zap.Uint32("step", step),
+		zap.Int64("deleted", result.RowsAffected),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return result.RowsAffected, nil
+}
+
+// ListReportedHostIDs 查询时间不早于since的原始数据所属的主机ID
+func (r *HostMetricRepo) ListReportedHostIDs(ctx context.Context, since int64) ([]uint32, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始查询已上报指标的主机",
+		zap.Int64("since", since),
+	)
+	startTime := time.Now()
+	var ids []uint32
+	dbCtx, cancel := database.ReadContext(ctx, r.timeouts)
+	defer cancel()
+	err := database.Conn(dbCtx, r.gormDB).Model(&monmodel.HostMetricModel{}).
+		Where("step = ? AND ts >= ?", monmodel.HostMetricStepRaw, since).
+		Distinct().Pluck("host_id", &ids).Error
+	if err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"查询已上报指标的主机失败",
+			zap.Error(err),
+			zap.Int64("since", since),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return nil, errors.WrapIf(err, "查询已上报指标的主机失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"查询已上报指标的主机成功",
+		zap.Int("count", len(ids)),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return ids, nil
+}
+
+func (r *HostMetricRepo) ListModel(
+	ctx context.Context,
+	qp database.QueryParams,
+) (int64, *[]monmodel.HostMetricModel, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始查询主机指标列表",
+		zap.Object(database.QueryParamsKey, &qp),
+	)
+	startTime := time.Now()
+	var ms []monmodel.HostMetricModel
+	dbCtx, cancel := database.ListContext(database.WithQueryTimeout(ctx, qp.Timeout), r.timeouts)
+	defer cancel()
+	count, err := database.DBList(dbCtx, r.gormDB, &monmodel.HostMetricModel{}, &ms, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"查询主机指标列表失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return 0, nil, errors.WrapIf(err, "查询主机指标列表失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"查询主机指标列表成功",
+		zap.Object(database.QueryParamsKey, &qp),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return count, &ms, nil
+}
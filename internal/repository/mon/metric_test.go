@@ -0,0 +1,117 @@
+package mon
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	monmodel "gin-artweb/internal/model/mon"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/test"
+)
+
+func CreateTestHostMetricModel(hostID uint32, ts int64, cpu float64) monmodel.HostMetricModel {
+	return monmodel.HostMetricModel{
+		HostID:      hostID,
+		Step:        monmodel.HostMetricStepRaw,
+		Ts:          ts,
+		CPUPercent:  cpu,
+		MemPercent:  50,
+		DiskPercent: 40,
+		Load1:       1,
+		Load5:       0.5,
+		Load15:      0.25,
+		Samples:     1,
+	}
+}
+
+type HostMetricTestSuite struct {
+	suite.Suite
+	metricRepo *HostMetricRepo
+}
+
+func (suite *HostMetricTestSuite) SetupTest() {
+	db := test.NewTestGormDBWithConfig(nil)
+	db.AutoMigrate(&monmodel.HostMetricModel{})
+	dbTimeout := test.NewTestDBTimeouts()
+	logger := test.NewTestZapLogger()
+	suite.metricRepo = NewHostMetricRepo(logger, db, dbTimeout)
+}
+
+func (suite *HostMetricTestSuite) listModel(step uint32) []monmodel.HostMetricModel {
+	_, ms, err := suite.metricRepo.ListModel(context.Background(), database.QueryParams{
+		Query:   map[string]any{"step = ?": step},
+		OrderBy: []string{"host_id ASC", "ts ASC"},
+	})
+	suite.NoError(err)
+	return *ms
+}
+
+func (suite *HostMetricTestSuite) TestDownsample() {
+	ms := []monmodel.HostMetricModel{
+		CreateTestHostMetricModel(1, 600, 10),
+		CreateTestHostMetricModel(1, 660, 20),
+		CreateTestHostMetricModel(1, 960, 60),
+		CreateTestHostMetricModel(2, 700, 30),
+	}
+	buckets := monmodel.DownsampleHostMetrics(ms, monmodel.HostMetricStep5Min)
+	suite.Len(buckets, 3)
+	suite.Equal(int64(600), buckets[0].Ts, "桶的时间应该为桶的开始时间")
+	suite.InDelta(15, buckets[0].CPUPercent, 0.001)
+	suite.Equal(uint32(2), buckets[0].Samples)
+	suite.Equal(int64(900), buckets[1].Ts)
+	suite.Equal(uint32(2), buckets[2].HostID)
+
+	hours := monmodel.DownsampleHostMetrics(buckets[:2], monmodel.HostMetricStepHour)
+	suite.Len(hours, 1)
+	suite.InDelta(30, hours[0].CPUPercent, 0.001, "聚合数据应该按原始数据条数加权")
+	suite.Equal(uint32(3), hours[0].Samples)
+}
+
+func (suite *HostMetricTestSuite) TestReplaceBuckets() {
+	ctx := context.Background()
+	raw := []monmodel.HostMetricModel{
+		CreateTestHostMetricModel(1, 600, 10),
+		CreateTestHostMetricModel(1, 660, 20),
+	}
+	suite.NoError(suite.metricRepo.CreateModels(ctx, raw))
+
+	buckets := monmodel.DownsampleHostMetrics(raw, monmodel.HostMetricStep5Min)
+	suite.NoError(suite.metricRepo.ReplaceBuckets(ctx, monmodel.HostMetricStep5Min, 600, 900, buckets))
+	suite.NoError(suite.metricRepo.ReplaceBuckets(ctx, monmodel.HostMetricStep5Min, 600, 900, buckets))
+
+	ms := suite.listModel(monmodel.HostMetricStep5Min)
+	suite.Len(ms, 1, "重复聚合同一时间段不应该产生重复数据")
+	suite.InDelta(15, ms[0].CPUPercent, 0.001)
+	suite.Len(suite.listModel(monmodel.HostMetricStepRaw), 2, "聚合不应该影响原始数据")
+}
+
+func (suite *HostMetricTestSuite) TestDeleteBefore() {
+	ctx := context.Background()
+	suite.NoError(suite.metricRepo.CreateModels(ctx, []monmodel.HostMetricModel{
+		CreateTestHostMetricModel(1, 100, 10),
+		CreateTestHostMetricModel(1, 200, 20),
+		CreateTestHostMetricModel(2, 300, 30),
+	}))
+
+	ids, err := suite.metricRepo.ListReportedHostIDs(ctx, 200)
+	suite.NoError(err)
+	suite.ElementsMatch([]uint32{1, 2}, ids)
+
+	deleted, err := suite.metricRepo.DeleteBefore(ctx, monmodel.HostMetricStepRaw, 250)
+	suite.NoError(err)
+	suite.Equal(int64(2), deleted)
+
+	deleted, err = suite.metricRepo.DeleteBefore(ctx, monmodel.HostMetricStep5Min, 1000)
+	suite.NoError(err)
+	suite.Zero(deleted, "只应该清理指定粒度的数据")
+
+	ids, err = suite.metricRepo.ListReportedHostIDs(ctx, 0)
+	suite.NoError(err)
+	suite.Equal([]uint32{2}, ids)
+}
+
+func TestHostMetricTestSuite(t *testing.T) {
+	suite.Run(t, new(HostMetricTestSuite))
+}
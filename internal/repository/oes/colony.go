@@ -9,6 +9,7 @@ import (
 	"gorm.io/gorm"
 
 	oesmodel "gin-artweb/internal/model/oes"
+	sysmodel "gin-artweb/internal/model/system"
 	"gin-artweb/internal/shared/config"
 	"gin-artweb/internal/shared/ctxutil"
 	"gin-artweb/internal/shared/database"
@@ -37,35 +38,31 @@ func (r *OesColonyRepo) CreateModel(ctx context.Context, m *oesmodel.OesColonyMo
 	// 检查参数
 	if m == nil {
 		err := errors.New("创建oes集群失败: 模型为空")
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"创建oes集群失败: 模型为空",
 			zap.Error(err),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return err
 	}
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"开始创建oes集群",
 		zap.Object(database.ModelKey, m),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	now := time.Now()
-	dbCtx, cancel := context.WithTimeout(ctx, r.timeouts.WriteTimeout)
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
 	defer cancel()
 	if err := database.DBCreate(dbCtx, r.gormDB, &oesmodel.OesColonyModel{}, m, nil); err != nil {
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"创建oes集群失败",
 			zap.Error(err),
 			zap.Object(database.ModelKey, m),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			zap.Duration(log.DurationKey, time.Since(now)),
 		)
 		return errors.WrapIf(err, "创建oes集群失败")
 	}
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"创建oes集群成功",
 		zap.Object(database.ModelKey, m),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		zap.Duration(log.DurationKey, time.Since(now)),
 	)
 	return nil
@@ -75,69 +72,62 @@ func (r *OesColonyRepo) UpdateModel(ctx context.Context, data map[string]any, co
 	// 检查参数
 	if len(data) == 0 {
 		err := errors.New("更新mds集群失败: 更新数据为空")
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"更新mds集群失败: 更新数据为空",
 			zap.Error(err),
 			zap.Any(database.UpdateDataKey, data),
 			zap.Any(database.ConditionsKey, conds),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return err
 	}
 
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"开始更新oes集群",
 		zap.Any(database.UpdateDataKey, data),
 		zap.Any(database.ConditionsKey, conds),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	startTime := time.Now()
-	dbCtx, cancel := context.WithTimeout(ctx, r.timeouts.WriteTimeout)
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
 	defer cancel()
 	if err := database.DBUpdate(dbCtx, r.gormDB, &oesmodel.OesColonyModel{}, data, nil, conds...); err != nil {
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"更新oes集群失败",
 			zap.Error(err),
 			zap.Any(database.UpdateDataKey, data),
 			zap.Any(database.ConditionsKey, conds),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			zap.Duration(log.DurationKey, time.Since(startTime)),
 		)
 		return errors.WrapIf(err, "更新oes集群失败")
 	}
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"更新oes集群成功",
 		zap.Any(database.UpdateDataKey, data),
 		zap.Any(database.ConditionsKey, conds),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		zap.Duration(log.DurationKey, time.Since(startTime)),
 	)
 	return nil
 }
 
 func (r *OesColonyRepo) DeleteModel(ctx context.Context, conds ...any) error {
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"开始删除oes集群",
 		zap.Any(database.ConditionsKey, conds),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	startTime := time.Now()
-	dbCtx, cancel := context.WithTimeout(ctx, r.timeouts.WriteTimeout)
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
 	defer cancel()
 	if err := database.DBDelete(dbCtx, r.gormDB, &oesmodel.OesColonyModel{}, conds...); err != nil {
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"删除oes集群失败",
 			zap.Error(err),
 			zap.Any(database.ConditionsKey, conds),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			zap.Duration(log.DurationKey, time.Since(startTime)),
 		)
 		return errors.WrapIf(err, "删除oes集群失败")
 	}
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"删除oes集群成功",
 		zap.Any(database.ConditionsKey, conds),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		zap.Duration(log.DurationKey, time.Since(startTime)),
 	)
 	return nil
@@ -148,30 +138,27 @@ func (r *OesColonyRepo) GetModel(
 	preloads []string,
 	conds ...any,
 ) (*oesmodel.OesColonyModel, error) {
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"开始查询oes集群",
 		zap.Any(database.ConditionsKey, conds),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	startTime := time.Now()
 	var m oesmodel.OesColonyModel
-	dbCtx, cancel := context.WithTimeout(ctx, r.timeouts.ReadTimeout)
+	dbCtx, cancel := database.ReadContext(ctx, r.timeouts)
 	defer cancel()
 	if err := database.DBGet(dbCtx, r.gormDB, preloads, &m, conds...); err != nil {
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"查询oes集群失败",
 			zap.Error(err),
 			zap.Any(database.ConditionsKey, conds),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			zap.Duration(log.DurationKey, time.Since(startTime)),
 		)
 		return nil, errors.WrapIf(err, "查询oes集群失败")
 	}
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"查询oes集群成功",
 		zap.Object(database.ModelKey, &m),
 		zap.Any(database.ConditionsKey, conds),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		zap.Duration(log.DurationKey, time.Since(startTime)),
 	)
 	return &m, nil
@@ -181,31 +168,118 @@ func (r *OesColonyRepo) ListModel(
 	ctx context.Context,
 	qp database.QueryParams,
 ) (int64, *[]oesmodel.OesColonyModel, error) {
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"开始查询oes集群列表",
 		zap.Object(database.QueryParamsKey, &qp),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	startTime := time.Now()
 	var ms []oesmodel.OesColonyModel
-	dbCtx, cancel := context.WithTimeout(ctx, r.timeouts.ListTimeout)
+	dbCtx, cancel := database.ListContext(database.WithQueryTimeout(ctx, qp.Timeout), r.timeouts)
 	defer cancel()
 	count, err := database.DBList(dbCtx, r.gormDB, &oesmodel.OesColonyModel{}, &ms, qp)
 	if err != nil {
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"查询oes集群列表失败",
 			zap.Error(err),
 			zap.Object(database.QueryParamsKey, &qp),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			zap.Duration(log.DurationKey, time.Since(startTime)),
 		)
 		return 0, nil, errors.WrapIf(err, "查询oes集群列表失败")
 	}
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"查询oes集群列表成功",
 		zap.Object(database.QueryParamsKey, &qp),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		zap.Duration(log.DurationKey, time.Since(startTime)),
 	)
 	return count, &ms, nil
 }
+
+// BatchUpdateModel 在同一个事务中批量更新oes集群并写入审计记录
+// 返回未找到的集群ID, 任一更新失败时整体回滚
+func (r *OesColonyRepo) BatchUpdateModel(
+	ctx context.Context,
+	ids []uint32,
+	data map[string]any,
+	username string,
+) ([]uint32, error) {
+	// 检查参数
+	if len(ids) == 0 || len(data) == 0 {
+		err := errors.New("批量更新oes集群失败: 集群ID或更新数据为空")
+		ctxutil.Logger(ctx, r.log).Error(
+			"批量更新oes集群失败: 集群ID或更新数据为空",
+			zap.Error(err),
+			zap.Uint32s("ids", ids),
+			zap.Any(database.UpdateDataKey, data),
+		)
+		return nil, err
+	}
+
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始批量更新oes集群",
+		zap.Uint32s("ids", ids),
+		zap.Any(database.UpdateDataKey, data),
+	)
+	startTime := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+
+	var missing []uint32
+	traceID := ctxutil.GetTraceID(ctx)
+	err := database.Conn(dbCtx, r.gormDB).Transaction(func(tx *gorm.DB) error {
+		for _, id := range ids {
+			var before oesmodel.OesColonyModel
+			if err := tx.First(&before, id).Error; err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					missing = append(missing, id)
+					continue
+				}
+				return err
+			}
+			if err := tx.Model(&oesmodel.OesColonyModel{}).Where("id = ?", id).Updates(data).Error; err != nil {
+				return err
+			}
+			var after oesmodel.OesColonyModel
+			if err := tx.First(&after, id).Error; err != nil {
+				return err
+			}
+			audit, err := sysmodel.NewAuditRecord("oes", "colony", id, "batch_update", colonyAuditSnapshot(before), colonyAuditSnapshot(after), username, traceID)
+			if err != nil {
+				return err
+			}
+			if err := tx.Create(audit).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"批量更新oes集群失败",
+			zap.Error(err),
+			zap.Uint32s("ids", ids),
+			zap.Any(database.UpdateDataKey, data),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return nil, errors.WrapIf(err, "批量更新oes集群失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"批量更新oes集群成功",
+		zap.Uint32s("ids", ids),
+		zap.Uint32s("missing_ids", missing),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return missing, nil
+}
+
+// colonyAuditSnapshot 审计记录中保存的oes集群字段
+func colonyAuditSnapshot(m oesmodel.OesColonyModel) map[string]any {
+	return map[string]any{
+		"system_type":    m.SystemType,
+		"colony_num":     m.ColonyNum,
+		"extracted_name": m.ExtractedName,
+		"is_enable":      m.IsEnable,
+		"package_id":     m.PackageID,
+		"xcounter_id":    m.XCounterID,
+		"mon_node_id":    m.MonNodeID,
+	}
+}
@@ -8,10 +8,12 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
 
 	monmodel "gin-artweb/internal/model/mon"
 	oesmodel "gin-artweb/internal/model/oes"
 	resomodel "gin-artweb/internal/model/resource"
+	sysmodel "gin-artweb/internal/model/system"
 	"gin-artweb/internal/shared/database"
 	"gin-artweb/internal/shared/test"
 )
@@ -33,6 +35,7 @@ func CreateTestOesColonyModel() *oesmodel.OesColonyModel {
 
 type OesColonyTestSuite struct {
 	suite.Suite
+	db         *gorm.DB
 	colonyRepo *OesColonyRepo
 }
 
@@ -43,6 +46,7 @@ func (suite *OesColonyTestSuite) SetupSuite() {
 		&monmodel.MonNodeModel{},
 		&resomodel.PackageModel{},
 		&oesmodel.OesColonyModel{},
+		&sysmodel.AuditRecordModel{},
 	)
 
 	// 创建测试数据：主机
@@ -88,6 +92,7 @@ func (suite *OesColonyTestSuite) SetupSuite() {
 
 	dbTimeout := test.NewTestDBTimeouts()
 	logger := test.NewTestZapLogger()
+	suite.db = db
 	suite.colonyRepo = &OesColonyRepo{
 		log:      logger,
 		gormDB:   db,
@@ -137,6 +142,38 @@ func (suite *OesColonyTestSuite) TestUpdateModel() {
 	suite.NoError(err, "更新不存在的OesColony应该成功（无操作）")
 }
 
+func (suite *OesColonyTestSuite) TestUpdateModelWithVersion() {
+	cm := CreateTestOesColonyModel()
+	err := suite.colonyRepo.CreateModel(context.Background(), cm)
+	suite.NoError(err, "创建OesColony用于版本号测试应该成功")
+
+	// 两个用户读取到同一个版本号, 先提交的更新成功
+	err = suite.colonyRepo.UpdateModel(context.Background(), map[string]any{
+		"extracted_name":    "first-oes",
+		database.VersionKey: uint32(1),
+	}, "id = ?", cm.ID)
+	suite.NoError(err, "版本号一致时更新OesColony应该成功")
+
+	// 后提交的更新因版本号过期而失败
+	err = suite.colonyRepo.UpdateModel(context.Background(), map[string]any{
+		"extracted_name":    "second-oes",
+		database.VersionKey: uint32(1),
+	}, "id = ?", cm.ID)
+	suite.True(database.IsVersionConflict(err), "版本号过期时应该返回版本冲突错误")
+
+	fm, err := suite.colonyRepo.GetModel(context.Background(), nil, "id = ?", cm.ID)
+	suite.NoError(err)
+	suite.Equal("first-oes", fm.ExtractedName, "版本冲突时不应该覆盖数据")
+	suite.Equal(uint32(2), fm.Version)
+
+	// 不携带版本号的更新同样会递增版本号
+	err = suite.colonyRepo.UpdateModel(context.Background(), map[string]any{"is_enable": false}, "id = ?", cm.ID)
+	suite.NoError(err)
+	fm, err = suite.colonyRepo.GetModel(context.Background(), nil, "id = ?", cm.ID)
+	suite.NoError(err)
+	suite.Equal(uint32(3), fm.Version)
+}
+
 func (suite *OesColonyTestSuite) TestDeleteModel() {
 	// 创建测试数据
 	cm := CreateTestOesColonyModel()
@@ -231,6 +268,47 @@ func (suite *OesColonyTestSuite) TestContextTimeout() {
 	suite.Error(err, "上下文超时后查询OesColony应该返回错误")
 }
 
+func (suite *OesColonyTestSuite) TestBatchUpdateModel() {
+	// 创建测试数据
+	var ids []uint32
+	for i := 0; i < 3; i++ {
+		cm := CreateTestOesColonyModel()
+		err := suite.colonyRepo.CreateModel(context.Background(), cm)
+		suite.NoError(err, "创建OesColony用于批量更新测试应该成功")
+		ids = append(ids, cm.ID)
+	}
+
+	// 测试正常批量更新, 包含一个不存在的ID
+	missing, err := suite.colonyRepo.BatchUpdateModel(
+		context.Background(),
+		append(ids, 999999),
+		map[string]any{"is_enable": false},
+		"admin",
+	)
+	suite.NoError(err, "批量更新OesColony应该成功")
+	suite.Equal([]uint32{999999}, missing, "应该返回不存在的ID")
+
+	for _, id := range ids {
+		fm, err := suite.colonyRepo.GetModel(context.Background(), nil, "id = ?", id)
+		suite.NoError(err)
+		suite.False(fm.IsEnable, "IsEnable应该被批量更新为false")
+	}
+
+	// 每个更新的集群都应该有一条审计记录
+	var audits []sysmodel.AuditRecordModel
+	suite.db.Where("module = ? AND resource = ? AND resource_id in ?", "oes", "colony", ids).Find(&audits)
+	suite.Len(audits, len(ids), "每个更新的集群都应该生成审计记录")
+	for _, a := range audits {
+		suite.Equal("admin", a.Username)
+		suite.Contains(a.Before, `"is_enable":true`)
+		suite.Contains(a.After, `"is_enable":false`)
+	}
+
+	// 测试边界情况：更新数据为空
+	_, err = suite.colonyRepo.BatchUpdateModel(context.Background(), ids, map[string]any{}, "admin")
+	suite.Error(err, "更新数据为空时应该返回错误")
+}
+
 func TestOesColonyTestSuite(t *testing.T) {
 	pts := &OesColonyTestSuite{}
 	suite.Run(t, pts)
@@ -0,0 +1,228 @@
+package data
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	oesmodel "gin-artweb/internal/model/oes"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/log"
+)
+
+// OesColonyDriftRepo oes集群配置漂移仓库实现
+type OesColonyDriftRepo struct {
+	log      *zap.Logger       // 日志记录器
+	gormDB   *gorm.DB          // GORM数据库连接
+	timeouts *config.DBTimeout // 数据库操作超时配置
+}
+
+// NewOesColonyDriftRepo 创建oes集群配置漂移仓库实例
+func NewOesColonyDriftRepo(
+	log *zap.Logger,
+	gormDB *gorm.DB,
+	timeouts *config.DBTimeout,
+) *OesColonyDriftRepo {
+	return &OesColonyDriftRepo{
+		log:      log,
+		gormDB:   gormDB,
+		timeouts: timeouts,
+	}
+}
+
+func (r *OesColonyDriftRepo) CreateModel(ctx context.Context, m *oesmodel.OesColonyDriftModel) error {
+	// 检查参数
+	if m == nil {
+		err := errors.New("创建oes集群配置漂移失败: 模型为空")
+		ctxutil.Logger(ctx, r.log).Error(
+			"创建oes集群配置漂移失败: 模型为空",
+			zap.Error(err),
+		)
+		return err
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始创建oes集群配置漂移",
+		zap.Object(database.ModelKey, m),
+	)
+	now := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBCreate(dbCtx, r.gormDB, &oesmodel.OesColonyDriftModel{}, m, nil); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"创建oes集群配置漂移失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, m),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return errors.WrapIf(err, "创建oes集群配置漂移失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"创建oes集群配置漂移成功",
+		zap.Object(database.ModelKey, m),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return nil
+}
+
+func (r *OesColonyDriftRepo) UpdateModel(ctx context.Context, data map[string]any, conds ...any) error {
+	if len(data) == 0 {
+		err := errors.New("更新oes集群配置漂移失败: 更新数据为空")
+		ctxutil.Logger(ctx, r.log).Error(
+			"更新oes集群配置漂移失败: 更新数据为空",
+			zap.Error(err),
+		)
+		return err
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始更新oes集群配置漂移",
+		zap.Any(database.UpdateDataKey, data),
+		zap.Any(database.ConditionsKey, conds),
+	)
+	now := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBUpdate(dbCtx, r.gormDB, &oesmodel.OesColonyDriftModel{}, data, nil, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"更新oes集群配置漂移失败",
+			zap.Error(err),
+			zap.Any(database.UpdateDataKey, data),
+			zap.Any(database.ConditionsKey, conds),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return errors.WrapIf(err, "更新oes集群配置漂移失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"更新oes集群配置漂移成功",
+		zap.Any(database.UpdateDataKey, data),
+		zap.Any(database.ConditionsKey, conds),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return nil
+}
+
+func (r *OesColonyDriftRepo) DeleteModel(ctx context.Context, conds ...any) error {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始删除oes集群配置漂移",
+		zap.Any(database.ConditionsKey, conds),
+	)
+	now := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBDelete(dbCtx, r.gormDB, &oesmodel.OesColonyDriftModel{}, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"删除oes集群配置漂移失败",
+			zap.Error(err),
+			zap.Any(database.ConditionsKey, conds),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return errors.WrapIf(err, "删除oes集群配置漂移失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"删除oes集群配置漂移成功",
+		zap.Any(database.ConditionsKey, conds),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return nil
+}
+
+func (r *OesColonyDriftRepo) GetModel(ctx context.Context, conds ...any) (*oesmodel.OesColonyDriftModel, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始查询oes集群配置漂移",
+		zap.Any(database.ConditionsKey, conds),
+	)
+	now := time.Now()
+	var m oesmodel.OesColonyDriftModel
+	dbCtx, cancel := database.ReadContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBGet(dbCtx, r.gormDB, nil, &m, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"查询oes集群配置漂移失败",
+			zap.Error(err),
+			zap.Any(database.ConditionsKey, conds),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return nil, errors.WrapIf(err, "查询oes集群配置漂移失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"查询oes集群配置漂移成功",
+		zap.Object(database.ModelKey, &m),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return &m, nil
+}
+
+func (r *OesColonyDriftRepo) ListModel(
+	ctx context.Context,
+	qp database.QueryParams,
+) (int64, *[]oesmodel.OesColonyDriftModel, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始查询oes集群配置漂移列表",
+		zap.Object(database.QueryParamsKey, &qp),
+	)
+	now := time.Now()
+	var ms []oesmodel.OesColonyDriftModel
+	dbCtx, cancel := database.ListContext(database.WithQueryTimeout(ctx, qp.Timeout), r.timeouts)
+	defer cancel()
+	count, err := database.DBList(dbCtx, r.gormDB, &oesmodel.OesColonyDriftModel{}, &ms, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"查询oes集群配置漂移列表失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return 0, nil, errors.WrapIf(err, "查询oes集群配置漂移列表失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"查询oes集群配置漂移列表成功",
+		zap.Object(database.QueryParamsKey, &qp),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return count, &ms, nil
+}
+
+// ReplaceColonyModels 在同一个事务中替换集群的全部配置漂移记录
+func (r *OesColonyDriftRepo) ReplaceColonyModels(
+	ctx context.Context,
+	colonyId uint32,
+	ms []oesmodel.OesColonyDriftModel,
+) error {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始替换oes集群配置漂移",
+		zap.Uint32("oes_colony_id", colonyId),
+		zap.Int("count", len(ms)),
+	)
+	startTime := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	err := database.Conn(dbCtx, r.gormDB).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("oes_colony_id = ?", colonyId).Delete(&oesmodel.OesColonyDriftModel{}).Error; err != nil {
+			return err
+		}
+		if len(ms) == 0 {
+			return nil
+		}
+		return tx.Create(&ms).Error
+	})
+	if err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"替换oes集群配置漂移失败",
+			zap.Error(err),
+			zap.Uint32("oes_colony_id", colonyId),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return errors.WrapIf(err, "替换oes集群配置漂移失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"替换oes集群配置漂移成功",
+		zap.Uint32("oes_colony_id", colonyId),
+		zap.Int("count", len(ms)),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return nil
+}
@@ -0,0 +1,81 @@
+package data
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	oesmodel "gin-artweb/internal/model/oes"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/test"
+)
+
+func CreateTestOesColonyDriftModel(colonyID, nodeID uint32) oesmodel.OesColonyDriftModel {
+	return oesmodel.OesColonyDriftModel{
+		OesColonyID: colonyID,
+		NodeID:      nodeID,
+		HostID:      nodeID,
+		Kind:        oesmodel.DriftKindConf,
+		Name:        "oes.conf",
+		RemotePath:  "/home/quant/oes_01/conf/oes.conf",
+		Expected:    "expected",
+		Actual:      oesmodel.DriftActualMissing,
+		DetectedAt:  time.Now(),
+	}
+}
+
+type OesColonyDriftTestSuite struct {
+	suite.Suite
+	driftRepo *OesColonyDriftRepo
+}
+
+func (suite *OesColonyDriftTestSuite) SetupTest() {
+	db := test.NewTestGormDBWithConfig(nil)
+	db.AutoMigrate(&oesmodel.OesColonyDriftModel{})
+
+	dbTimeout := test.NewTestDBTimeouts()
+	logger := test.NewTestZapLogger()
+	suite.driftRepo = NewOesColonyDriftRepo(logger, db, dbTimeout)
+}
+
+func (suite *OesColonyDriftTestSuite) TestReplaceColonyModels() {
+	ctx := context.Background()
+	err := suite.driftRepo.ReplaceColonyModels(ctx, 1, []oesmodel.OesColonyDriftModel{
+		CreateTestOesColonyDriftModel(1, 1),
+		CreateTestOesColonyDriftModel(1, 2),
+	})
+	suite.NoError(err, "写入集群1的配置漂移应该成功")
+	err = suite.driftRepo.ReplaceColonyModels(ctx, 2, []oesmodel.OesColonyDriftModel{
+		CreateTestOesColonyDriftModel(2, 3),
+	})
+	suite.NoError(err, "写入集群2的配置漂移应该成功")
+
+	err = suite.driftRepo.ReplaceColonyModels(ctx, 1, []oesmodel.OesColonyDriftModel{
+		CreateTestOesColonyDriftModel(1, 2),
+	})
+	suite.NoError(err, "替换集群1的配置漂移应该成功")
+
+	qp := database.QueryParams{IsCount: true, Query: map[string]any{"oes_colony_id = ?": uint32(1)}}
+	total, ms, err := suite.driftRepo.ListModel(ctx, qp)
+	suite.NoError(err)
+	suite.Equal(int64(1), total, "替换后集群1只保留最新的漂移")
+	suite.Equal(uint32(2), (*ms)[0].NodeID)
+
+	qp.Query = map[string]any{"oes_colony_id = ?": uint32(2)}
+	total, _, err = suite.driftRepo.ListModel(ctx, qp)
+	suite.NoError(err)
+	suite.Equal(int64(1), total, "替换集群1不影响其他集群")
+
+	err = suite.driftRepo.ReplaceColonyModels(ctx, 1, nil)
+	suite.NoError(err, "漂移消失后清空集群的记录应该成功")
+	qp.Query = map[string]any{"oes_colony_id = ?": uint32(1)}
+	total, _, err = suite.driftRepo.ListModel(ctx, qp)
+	suite.NoError(err)
+	suite.Zero(total)
+}
+
+func TestOesColonyDriftTestSuite(t *testing.T) {
+	suite.Run(t, new(OesColonyDriftTestSuite))
+}
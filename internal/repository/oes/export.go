@@ -0,0 +1,194 @@
+package data
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	oesmodel "gin-artweb/internal/model/oes"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/log"
+)
+
+type OesColonyExportRepo struct {
+	log      *zap.Logger
+	gormDB   *gorm.DB
+	timeouts *config.DBTimeout
+}
+
+func NewOesColonyExportRepo(
+	log *zap.Logger,
+	gormDB *gorm.DB,
+	timeouts *config.DBTimeout,
+) *OesColonyExportRepo {
+	return &OesColonyExportRepo{
+		log:      log,
+		gormDB:   gormDB,
+		timeouts: timeouts,
+	}
+}
+
+func (r *OesColonyExportRepo) CreateModel(ctx context.Context, m *oesmodel.OesColonyExportModel) error {
+	// 检查参数
+	if m == nil {
+		err := errors.New("创建oes集群导出记录失败: 模型为空")
+		ctxutil.Logger(ctx, r.log).Error(
+			"创建oes集群导出记录失败: 模型为空",
+			zap.Error(err),
+		)
+		return err
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始创建oes集群导出记录",
+		zap.Object(database.ModelKey, m),
+	)
+	now := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBCreate(dbCtx, r.gormDB, &oesmodel.OesColonyExportModel{}, m, nil); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"创建oes集群导出记录失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, m),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return errors.WrapIf(err, "创建oes集群导出记录失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"创建oes集群导出记录成功",
+		zap.Object(database.ModelKey, m),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return nil
+}
+
+func (r *OesColonyExportRepo) UpdateModel(ctx context.Context, data map[string]any, conds ...any) error {
+	// 检查参数
+	if len(data) == 0 {
+		err := errors.New("更新oes集群导出记录失败: 更新数据为空")
+		ctxutil.Logger(ctx, r.log).Error(
+			"更新oes集群导出记录失败: 更新数据为空",
+			zap.Error(err),
+			zap.Any(database.UpdateDataKey, data),
+			zap.Any(database.ConditionsKey, conds),
+		)
+		return err
+	}
+
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始更新oes集群导出记录",
+		zap.Any(database.UpdateDataKey, data),
+		zap.Any(database.ConditionsKey, conds),
+	)
+	startTime := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBUpdate(dbCtx, r.gormDB, &oesmodel.OesColonyExportModel{}, data, nil, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"更新oes集群导出记录失败",
+			zap.Error(err),
+			zap.Any(database.UpdateDataKey, data),
+			zap.Any(database.ConditionsKey, conds),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return errors.WrapIf(err, "更新oes集群导出记录失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"更新oes集群导出记录成功",
+		zap.Any(database.UpdateDataKey, data),
+		zap.Any(database.ConditionsKey, conds),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return nil
+}
+
+func (r *OesColonyExportRepo) DeleteModel(ctx context.Context, conds ...any) error {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始删除oes集群导出记录",
+		zap.Any(database.ConditionsKey, conds),
+	)
+	startTime := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBDelete(dbCtx, r.gormDB, &oesmodel.OesColonyExportModel{}, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"删除oes集群导出记录失败",
+			zap.Error(err),
+			zap.Any(database.ConditionsKey, conds),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return errors.WrapIf(err, "删除oes集群导出记录失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"删除oes集群导出记录成功",
+		zap.Any(database.ConditionsKey, conds),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return nil
+}
+
+func (r *OesColonyExportRepo) GetModel(
+	ctx context.Context,
+	preloads []string,
+	conds ...any,
+) (*oesmodel.OesColonyExportModel, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始查询oes集群导出记录",
+		zap.Any(database.ConditionsKey, conds),
+	)
+	startTime := time.Now()
+	var m oesmodel.OesColonyExportModel
+	dbCtx, cancel := database.ReadContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBGet(dbCtx, r.gormDB, preloads, &m, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"查询oes集群导出记录失败",
+			zap.Error(err),
+			zap.Any(database.ConditionsKey, conds),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return nil, errors.WrapIf(err, "查询oes集群导出记录失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"查询oes集群导出记录成功",
+		zap.Object(database.ModelKey, &m),
+		zap.Any(database.ConditionsKey, conds),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return &m, nil
+}
+
+func (r *OesColonyExportRepo) ListModel(
+	ctx context.Context,
+	qp database.QueryParams,
+) (int64, *[]oesmodel.OesColonyExportModel, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始查询oes集群导出记录列表",
+		zap.Object(database.QueryParamsKey, &qp),
+	)
+	startTime := time.Now()
+	var ms []oesmodel.OesColonyExportModel
+	dbCtx, cancel := database.ListContext(database.WithQueryTimeout(ctx, qp.Timeout), r.timeouts)
+	defer cancel()
+	count, err := database.DBList(dbCtx, r.gormDB, &oesmodel.OesColonyExportModel{}, &ms, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"查询oes集群导出记录列表失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return 0, nil, errors.WrapIf(err, "查询oes集群导出记录列表失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"查询oes集群导出记录列表成功",
+		zap.Object(database.QueryParamsKey, &qp),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return count, &ms, nil
+}
@@ -0,0 +1,100 @@
+package data
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	oesmodel "gin-artweb/internal/model/oes"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/test"
+)
+
+func CreateTestOesColonyExportModel(colonyID uint32) *oesmodel.OesColonyExportModel {
+	return &oesmodel.OesColonyExportModel{
+		OesColonyID: colonyID,
+		ColonyNum:   "01",
+		Status:      oesmodel.ExportStatusPending,
+		Username:    "admin",
+	}
+}
+
+type OesColonyExportTestSuite struct {
+	suite.Suite
+	exportRepo *OesColonyExportRepo
+}
+
+func (suite *OesColonyExportTestSuite) SetupSuite() {
+	db := test.NewTestGormDBWithConfig(nil)
+	db.AutoMigrate(&oesmodel.OesColonyExportModel{})
+
+	dbTimeout := test.NewTestDBTimeouts()
+	logger := test.NewTestZapLogger()
+	suite.exportRepo = NewOesColonyExportRepo(logger, db, dbTimeout)
+}
+
+func (suite *OesColonyExportTestSuite) TestCreateModel() {
+	em := CreateTestOesColonyExportModel(1)
+	err := suite.exportRepo.CreateModel(context.Background(), em)
+	suite.NoError(err, "创建OesColonyExport应该成功")
+	suite.NotZero(em.ID, "OesColonyExport ID应该不为零")
+
+	err = suite.exportRepo.CreateModel(context.Background(), nil)
+	suite.Error(err, "创建空OesColonyExport模型应该返回错误")
+}
+
+func (suite *OesColonyExportTestSuite) TestUpdateModel() {
+	em := CreateTestOesColonyExportModel(1)
+	err := suite.exportRepo.CreateModel(context.Background(), em)
+	suite.NoError(err, "创建OesColonyExport用于更新测试应该成功")
+
+	updateData := map[string]any{
+		"status":   oesmodel.ExportStatusSuccess,
+		"filename": "oes_colony_01.tar.gz",
+		"size":     int64(1024),
+	}
+	err = suite.exportRepo.UpdateModel(context.Background(), updateData, "id = ?", em.ID)
+	suite.NoError(err, "更新OesColonyExport应该成功")
+
+	fm, err := suite.exportRepo.GetModel(context.Background(), nil, "id = ?", em.ID)
+	suite.NoError(err, "查询更新后的OesColonyExport应该成功")
+	suite.Equal(oesmodel.ExportStatusSuccess, fm.Status)
+	suite.Equal("oes_colony_01.tar.gz", fm.Filename)
+	suite.Equal(int64(1024), fm.Size)
+
+	err = suite.exportRepo.UpdateModel(context.Background(), map[string]any{}, "id = ?", em.ID)
+	suite.Error(err, "更新数据为空时应该返回错误")
+}
+
+func (suite *OesColonyExportTestSuite) TestListModel() {
+	for i := 0; i < 3; i++ {
+		em := CreateTestOesColonyExportModel(2)
+		suite.NoError(suite.exportRepo.CreateModel(context.Background(), em))
+	}
+
+	qp := database.QueryParams{
+		IsCount: true,
+		Query:   map[string]any{"oes_colony_id = ?": 2},
+	}
+	count, ms, err := suite.exportRepo.ListModel(context.Background(), qp)
+	suite.NoError(err, "查询OesColonyExport列表应该成功")
+	suite.Equal(int64(3), count)
+	suite.Len(*ms, 3)
+}
+
+func (suite *OesColonyExportTestSuite) TestDeleteModel() {
+	em := CreateTestOesColonyExportModel(3)
+	suite.NoError(suite.exportRepo.CreateModel(context.Background(), em))
+
+	err := suite.exportRepo.DeleteModel(context.Background(), "id = ?", em.ID)
+	suite.NoError(err, "删除OesColonyExport应该成功")
+
+	fm, err := suite.exportRepo.GetModel(context.Background(), nil, "id = ?", em.ID)
+	suite.Error(err, "查询已删除的OesColonyExport应该返回错误")
+	suite.Nil(fm)
+}
+
+func TestOesColonyExportTestSuite(t *testing.T) {
+	suite.Run(t, new(OesColonyExportTestSuite))
+}
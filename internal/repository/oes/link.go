@@ -0,0 +1,306 @@
+package data
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	oesmodel "gin-artweb/internal/model/oes"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/log"
+)
+
+type OesLinkRepo struct {
+	log      *zap.Logger
+	gormDB   *gorm.DB
+	timeouts *config.DBTimeout
+}
+
+func NewOesLinkRepo(
+	log *zap.Logger,
+	gormDB *gorm.DB,
+	timeouts *config.DBTimeout,
+) *OesLinkRepo {
+	return &OesLinkRepo{
+		log:      log,
+		gormDB:   gormDB,
+		timeouts: timeouts,
+	}
+}
+
+func (r *OesLinkRepo) CreateModel(ctx context.Context, m *oesmodel.OesLinkModel) error {
+	// 检查参数
+	if m == nil {
+		err := errors.New("创建oes链路失败: 模型为空")
+		ctxutil.Logger(ctx, r.log).Error(
+			"创建oes链路失败: 模型为空",
+			zap.Error(err),
+		)
+		return err
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始创建oes链路",
+		zap.Object(database.ModelKey, m),
+	)
+	now := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBCreate(dbCtx, r.gormDB, &oesmodel.OesLinkModel{}, m, nil); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"创建oes链路失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, m),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return errors.WrapIf(err, "创建oes链路失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"创建oes链路成功",
+		zap.Object(database.ModelKey, m),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return nil
+}
+
+func (r *OesLinkRepo) UpdateModel(ctx context.Context, data map[string]any, conds ...any) error {
+	// 检查参数
+	if len(data) == 0 {
+		err := errors.New("更新oes链路失败: 更新数据为空")
+		ctxutil.Logger(ctx, r.log).Error(
+			"更新oes链路失败: 更新数据为空",
+			zap.Error(err),
+			zap.Any(database.UpdateDataKey, data),
+			zap.Any(database.ConditionsKey, conds),
+		)
+		return err
+	}
+
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始更新oes链路",
+		zap.Any(database.UpdateDataKey, data),
+		zap.Any(database.ConditionsKey, conds),
+	)
+	startTime := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBUpdate(dbCtx, r.gormDB, &oesmodel.OesLinkModel{}, data, nil, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"更新oes链路失败",
+			zap.Error(err),
+			zap.Any(database.UpdateDataKey, data),
+			zap.Any(database.ConditionsKey, conds),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return errors.WrapIf(err, "更新oes链路失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"更新oes链路成功",
+		zap.Any(database.UpdateDataKey, data),
+		zap.Any(database.ConditionsKey, conds),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return nil
+}
+
+func (r *OesLinkRepo) DeleteModel(ctx context.Context, conds ...any) error {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始删除oes链路",
+		zap.Any(database.ConditionsKey, conds),
+	)
+	startTime := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBDelete(dbCtx, r.gormDB, &oesmodel.OesLinkModel{}, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"删除oes链路失败",
+			zap.Error(err),
+			zap.Any(database.ConditionsKey, conds),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return errors.WrapIf(err, "删除oes链路失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"删除oes链路成功",
+		zap.Any(database.ConditionsKey, conds),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return nil
+}
+
+func (r *OesLinkRepo) GetModel(
+	ctx context.Context,
+	preloads []string,
+	conds ...any,
+) (*oesmodel.OesLinkModel, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始查询oes链路",
+		zap.Any(database.ConditionsKey, conds),
+	)
+	startTime := time.Now()
+	var m oesmodel.OesLinkModel
+	dbCtx, cancel := database.ReadContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBGet(dbCtx, r.gormDB, preloads, &m, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"查询oes链路失败",
+			zap.Error(err),
+			zap.Any(database.ConditionsKey, conds),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return nil, errors.WrapIf(err, "查询oes链路失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"查询oes链路成功",
+		zap.Object(database.ModelKey, &m),
+		zap.Any(database.ConditionsKey, conds),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return &m, nil
+}
+
+func (r *OesLinkRepo) ListModel(
+	ctx context.Context,
+	qp database.QueryParams,
+) (int64, *[]oesmodel.OesLinkModel, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始查询oes链路列表",
+		zap.Object(database.QueryParamsKey, &qp),
+	)
+	startTime := time.Now()
+	var ms []oesmodel.OesLinkModel
+	dbCtx, cancel := database.ListContext(database.WithQueryTimeout(ctx, qp.Timeout), r.timeouts)
+	defer cancel()
+	count, err := database.DBList(dbCtx, r.gormDB, &oesmodel.OesLinkModel{}, &ms, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"查询oes链路列表失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return 0, nil, errors.WrapIf(err, "查询oes链路列表失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"查询oes链路列表成功",
+		zap.Object(database.QueryParamsKey, &qp),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return count, &ms, nil
+}
+
+type OesLinkProbeRepo struct {
+	log      *zap.Logger
+	gormDB   *gorm.DB
+	timeouts *config.DBTimeout
+}
+
+func NewOesLinkProbeRepo(
+	log *zap.Logger,
+	gormDB *gorm.DB,
+	timeouts *config.DBTimeout,
+) *OesLinkProbeRepo {
+	return &OesLinkProbeRepo{
+		log:      log,
+		gormDB:   gormDB,
+		timeouts: timeouts,
+	}
+}
+
+func (r *OesLinkProbeRepo) CreateModel(ctx context.Context, m *oesmodel.OesLinkProbeModel) error {
+	// 检查参数
+	if m == nil {
+		err := errors.New("创建oes链路探测记录失败: 模型为空")
+		ctxutil.Logger(ctx, r.log).Error(
+			"创建oes链路探测记录失败: 模型为空",
+			zap.Error(err),
+		)
+		return err
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始创建oes链路探测记录",
+		zap.Object(database.ModelKey, m),
+	)
+	now := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBCreate(dbCtx, r.gormDB, &oesmodel.OesLinkProbeModel{}, m, nil); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"创建oes链路探测记录失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, m),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return errors.WrapIf(err, "创建oes链路探测记录失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"创建oes链路探测记录成功",
+		zap.Object(database.ModelKey, m),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return nil
+}
+
+func (r *OesLinkProbeRepo) ListModel(
+	ctx context.Context,
+	qp database.QueryParams,
+) (int64, *[]oesmodel.OesLinkProbeModel, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始查询oes链路探测记录列表",
+		zap.Object(database.QueryParamsKey, &qp),
+	)
+	startTime := time.Now()
+	var ms []oesmodel.OesLinkProbeModel
+	dbCtx, cancel := database.ListContext(database.WithQueryTimeout(ctx, qp.Timeout), r.timeouts)
+	defer cancel()
+	count, err := database.DBList(dbCtx, r.gormDB, &oesmodel.OesLinkProbeModel{}, &ms, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"查询oes链路探测记录列表失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return 0, nil, errors.WrapIf(err, "查询oes链路探测记录列表失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"查询oes链路探测记录列表成功",
+		zap.Object(database.QueryParamsKey, &qp),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return count, &ms, nil
+}
+
+// DeleteBefore 删除探测时间早于before的探测记录, 返回删除的条数
+func (r *OesLinkProbeRepo) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始清理oes链路探测记录",
+		zap.Time("before", before),
+	)
+	startTime := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	result := database.Conn(dbCtx, r.gormDB).
+		Where("created_at < ?", before).
+		Delete(&oesmodel.OesLinkProbeModel{})
+	if result.Error != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"清理oes链路探测记录失败",
+			zap.Error(result.Error),
+			zap.Time("before", before),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return 0, errors.WrapIf(result.Error, "清理oes链路探测记录失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"清理oes链路探测记录成功",
+		zap.Time("before", before),
+		zap.Int64("deleted", result.RowsAffected),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return result.RowsAffected, nil
+}
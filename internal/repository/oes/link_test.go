@@ -0,0 +1,135 @@
+package data
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	oesmodel "gin-artweb/internal/model/oes"
+	"gin-artweb/internal/model/resource"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/test"
+)
+
+func CreateTestOesLinkModel(name string, sourceHostID *uint32) *oesmodel.OesLinkModel {
+	return &oesmodel.OesLinkModel{
+		Name:         name,
+		OesColonyID:  1,
+		SourceHostID: sourceHostID,
+		TargetName:   "XCounter",
+		TargetHost:   "192.168.1.20",
+		TargetPort:   6101,
+		TimeoutMs:    3000,
+		IsEnabled:    true,
+		Status:       oesmodel.LinkUnknown,
+	}
+}
+
+type OesLinkTestSuite struct {
+	suite.Suite
+	linkRepo  *OesLinkRepo
+	probeRepo *OesLinkProbeRepo
+	host      resource.HostModel
+}
+
+func (suite *OesLinkTestSuite) SetupTest() {
+	db := test.NewTestGormDBWithConfig(nil)
+	db.AutoMigrate(&resource.HostModel{}, &oesmodel.OesLinkModel{}, &oesmodel.OesLinkProbeModel{})
+	suite.host = resource.HostModel{
+		Name:    "oes-node01",
+		SSHIP:   "192.168.1.10",
+		SSHPort: 22,
+		SSHUser: "oes",
+	}
+	suite.Require().NoError(db.Create(&suite.host).Error)
+
+	dbTimeout := test.NewTestDBTimeouts()
+	logger := test.NewTestZapLogger()
+	suite.linkRepo = NewOesLinkRepo(logger, db, dbTimeout)
+	suite.probeRepo = NewOesLinkProbeRepo(logger, db, dbTimeout)
+}
+
+func (suite *OesLinkTestSuite) TestCreateAndUpdateModel() {
+	ctx := context.Background()
+	m := CreateTestOesLinkModel("node01-xcounter", &suite.host.ID)
+	suite.NoError(suite.linkRepo.CreateModel(ctx, m))
+	suite.NoError(suite.linkRepo.CreateModel(ctx, CreateTestOesLinkModel("platform-xcounter", nil)))
+	suite.Error(suite.linkRepo.CreateModel(ctx, CreateTestOesLinkModel("node01-xcounter", nil)), "链路名称不能重复")
+
+	fm, err := suite.linkRepo.GetModel(ctx, []string{"SourceHost"}, m.ID)
+	suite.NoError(err)
+	suite.Equal("oes-node01", fm.Source())
+	suite.Equal("192.168.1.20:6101", fm.Target())
+
+	var noHost *uint32
+	suite.NoError(suite.linkRepo.UpdateModel(ctx, map[string]any{"source_host_id": noHost}, "id = ?", m.ID))
+	fm, err = suite.linkRepo.GetModel(ctx, []string{"SourceHost"}, m.ID)
+	suite.NoError(err)
+	suite.Nil(fm.SourceHostID, "源主机可以改为由平台探测")
+	suite.Equal(oesmodel.LinkSourcePlatform, fm.Source())
+
+	req := oesmodel.ListOesLinkRequest{SourceHostID: new(uint32)}
+	_, _, query := req.Query()
+	total, _, err := suite.linkRepo.ListModel(ctx, database.QueryParams{IsCount: true, Query: query})
+	suite.NoError(err)
+	suite.Equal(int64(2), total, "源主机ID为0时查询由平台探测的链路")
+}
+
+func (suite *OesLinkTestSuite) TestProbeDeleteBefore() {
+	ctx := context.Background()
+	m := CreateTestOesLinkModel("node01-xcounter", &suite.host.ID)
+	suite.Require().NoError(suite.linkRepo.CreateModel(ctx, m))
+	for _, status := range []string{oesmodel.LinkReachable, oesmodel.LinkUnreachable} {
+		suite.NoError(suite.probeRepo.CreateModel(ctx, &oesmodel.OesLinkProbeModel{LinkID: m.ID, Status: status}))
+	}
+
+	deleted, err := suite.probeRepo.DeleteBefore(ctx, time.Now().Add(-time.Hour))
+	suite.NoError(err)
+	suite.Zero(deleted, "保留期内的探测记录不应该删除")
+
+	deleted, err = suite.probeRepo.DeleteBefore(ctx, time.Now().Add(time.Second))
+	suite.NoError(err)
+	suite.Equal(int64(2), deleted)
+}
+
+func (suite *OesLinkTestSuite) TestProbeCommand() {
+	m := CreateTestOesLinkModel("node01-xcounter", nil)
+	m.TimeoutMs = 1500
+	suite.Equal(
+		`timeout 1.5 bash -c 's=$(date +%s%N); </dev/tcp/192.168.1.20/6101 && echo $(( ($(date +%s%N)-s)/1000 ))'`,
+		m.ProbeCommand(),
+	)
+}
+
+func (suite *OesLinkTestSuite) TestNewOesLinkMatrixOut() {
+	host := suite.host
+	a := *CreateTestOesLinkModel("node01-xcounter", &host.ID)
+	a.SourceHost = &host
+	a.Status = oesmodel.LinkReachable
+	b := *CreateTestOesLinkModel("node01-sse", &host.ID)
+	b.SourceHost = &host
+	b.TargetName = "上交所网关"
+	b.TargetHost = "10.0.0.1"
+	b.Status = oesmodel.LinkUnreachable
+	c := *CreateTestOesLinkModel("platform-xcounter", nil)
+
+	out := oesmodel.NewOesLinkMatrixOut(&[]oesmodel.OesLinkModel{a, b, c})
+	suite.Equal([]string{"oes-node01", oesmodel.LinkSourcePlatform}, out.Sources)
+	suite.Equal([]string{"XCounter(192.168.1.20:6101)", "上交所网关(10.0.0.1:6101)"}, out.Targets,
+		"相同目标只占一列")
+	suite.Equal(3, out.Total)
+	suite.Equal(1, out.Reachable)
+	suite.Equal(1, out.Unreachable)
+	suite.Equal(1, out.Unknown)
+	suite.Len(out.Cells, 3)
+
+	empty := oesmodel.NewOesLinkMatrixOut(nil)
+	suite.Empty(empty.Cells)
+	suite.NotNil(empty.Sources)
+}
+
+func TestOesLinkTestSuite(t *testing.T) {
+	suite.Run(t, new(OesLinkTestSuite))
+}
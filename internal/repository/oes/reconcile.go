@@ -0,0 +1,150 @@
+package data
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	oesmodel "gin-artweb/internal/model/oes"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/log"
+)
+
+// OesReconcileReportRepo oes柜台数据对账报告仓库实现
+type OesReconcileReportRepo struct {
+	log      *zap.Logger       // 日志记录器
+	gormDB   *gorm.DB          // GORM数据库连接
+	timeouts *config.DBTimeout // 数据库操作超时配置
+}
+
+// NewOesReconcileReportRepo 创建oes柜台数据对账报告仓库实例
+func NewOesReconcileReportRepo(
+	log *zap.Logger,
+	gormDB *gorm.DB,
+	timeouts *config.DBTimeout,
+) *OesReconcileReportRepo {
+	return &OesReconcileReportRepo{
+		log:      log,
+		gormDB:   gormDB,
+		timeouts: timeouts,
+	}
+}
+
+func (r *OesReconcileReportRepo) GetModel(
+	ctx context.Context,
+	preloads []string,
+	conds ...any,
+) (*oesmodel.OesReconcileReportModel, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始查询oes柜台数据对账报告",
+		zap.Any(database.ConditionsKey, conds),
+	)
+	startTime := time.Now()
+	var m oesmodel.OesReconcileReportModel
+	dbCtx, cancel := database.ReadContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBGet(dbCtx, r.gormDB, preloads, &m, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"查询oes柜台数据对账报告失败",
+			zap.Error(err),
+			zap.Any(database.ConditionsKey, conds),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return nil, errors.WrapIf(err, "查询oes柜台数据对账报告失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"查询oes柜台数据对账报告成功",
+		zap.Object(database.ModelKey, &m),
+		zap.Any(database.ConditionsKey, conds),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return &m, nil
+}
+
+func (r *OesReconcileReportRepo) ListModel(
+	ctx context.Context,
+	qp database.QueryParams,
+) (int64, *[]oesmodel.OesReconcileReportModel, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始查询oes柜台数据对账报告列表",
+		zap.Object(database.QueryParamsKey, &qp),
+	)
+	startTime := time.Now()
+	var ms []oesmodel.OesReconcileReportModel
+	dbCtx, cancel := database.ListContext(database.WithQueryTimeout(ctx, qp.Timeout), r.timeouts)
+	defer cancel()
+	count, err := database.DBList(dbCtx, r.gormDB, &oesmodel.OesReconcileReportModel{}, &ms, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"查询oes柜台数据对账报告列表失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return 0, nil, errors.WrapIf(err, "查询oes柜台数据对账报告列表失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"查询oes柜台数据对账报告列表成功",
+		zap.Object(database.QueryParamsKey, &qp),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return count, &ms, nil
+}
+
+// ReplaceColonyDay 在同一个事务中替换集群指定交易日的对账报告及其文件对账结果
+func (r *OesReconcileReportRepo) ReplaceColonyDay(ctx context.Context, m *oesmodel.OesReconcileReportModel) error {
+	// 检查参数
+	if m == nil {
+		err := errors.New("替换oes柜台数据对账报告失败: 模型为空")
+		ctxutil.Logger(ctx, r.log).Error(
+			"替换oes柜台数据对账报告失败: 模型为空",
+			zap.Error(err),
+		)
+		return err
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始替换oes柜台数据对账报告",
+		zap.Object(database.ModelKey, m),
+		zap.Int("file_count", len(m.Files)),
+	)
+	startTime := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	err := database.Conn(dbCtx, r.gormDB).Transaction(func(tx *gorm.DB) error {
+		var ids []uint32
+		if err := tx.Model(&oesmodel.OesReconcileReportModel{}).
+			Where("oes_colony_id = ? AND trading_day = ?", m.OesColonyID, m.TradingDay).
+			Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		if len(ids) > 0 {
+			if err := tx.Where("report_id IN ?", ids).Delete(&oesmodel.OesReconcileFileModel{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Where("id IN ?", ids).Delete(&oesmodel.OesReconcileReportModel{}).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Create(m).Error
+	})
+	if err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"替换oes柜台数据对账报告失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, m),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return errors.WrapIf(err, "替换oes柜台数据对账报告失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"替换oes柜台数据对账报告成功",
+		zap.Object(database.ModelKey, m),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return nil
+}
@@ -0,0 +1,102 @@
+package data
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	oesmodel "gin-artweb/internal/model/oes"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/test"
+)
+
+func CreateTestOesReconcileReportModel(colonyID uint32, tradingDay string) *oesmodel.OesReconcileReportModel {
+	return &oesmodel.OesReconcileReportModel{
+		OesColonyID: colonyID,
+		ColonyNum:   "01",
+		TradingDay:  tradingDay,
+		Status:      oesmodel.ReconcileStatusMismatched,
+		FileCount:   1,
+		NodeCount:   2,
+		Missing:     1,
+		Files: []oesmodel.OesReconcileFileModel{
+			{
+				NodeID:         1,
+				HostID:         1,
+				FileName:       "CustInfo0102.csv",
+				FetchRows:      10,
+				DistributeRows: 10,
+				Status:         oesmodel.ReconcileStatusMatched,
+			},
+			{
+				NodeID:         2,
+				HostID:         2,
+				FileName:       "CustInfo0102.csv",
+				FetchRows:      10,
+				DistributeRows: 9,
+				Missing:        1,
+				Status:         oesmodel.ReconcileStatusMismatched,
+			},
+		},
+	}
+}
+
+type OesReconcileReportTestSuite struct {
+	suite.Suite
+	reportRepo *OesReconcileReportRepo
+}
+
+func (suite *OesReconcileReportTestSuite) SetupTest() {
+	db := test.NewTestGormDBWithConfig(nil)
+	db.AutoMigrate(&oesmodel.OesReconcileReportModel{}, &oesmodel.OesReconcileFileModel{})
+
+	dbTimeout := test.NewTestDBTimeouts()
+	logger := test.NewTestZapLogger()
+	suite.reportRepo = NewOesReconcileReportRepo(logger, db, dbTimeout)
+}
+
+func (suite *OesReconcileReportTestSuite) TestReplaceColonyDay() {
+	ctx := context.Background()
+	first := CreateTestOesReconcileReportModel(1, "2024-01-02")
+	suite.NoError(suite.reportRepo.ReplaceColonyDay(ctx, first), "写入集群1的对账报告应该成功")
+	suite.NotZero(first.ID)
+	suite.NoError(suite.reportRepo.ReplaceColonyDay(ctx, CreateTestOesReconcileReportModel(1, "2024-01-03")))
+	suite.NoError(suite.reportRepo.ReplaceColonyDay(ctx, CreateTestOesReconcileReportModel(2, "2024-01-02")))
+
+	second := CreateTestOesReconcileReportModel(1, "2024-01-02")
+	second.Status = oesmodel.ReconcileStatusMatched
+	second.Missing = 0
+	second.Files = second.Files[:1]
+	suite.NoError(suite.reportRepo.ReplaceColonyDay(ctx, second), "重新对账应该替换原报告")
+
+	fm, err := suite.reportRepo.GetModel(ctx, []string{"Files"},
+		"oes_colony_id = ? AND trading_day = ?", uint32(1), "2024-01-02")
+	suite.Require().NoError(err)
+	suite.Equal(second.ID, fm.ID)
+	suite.Equal(oesmodel.ReconcileStatusMatched, fm.Status)
+	suite.Len(fm.Files, 1, "原报告的文件对账结果应该被删除")
+
+	_, err = suite.reportRepo.GetModel(ctx, nil, first.ID)
+	suite.Error(err, "原报告应该被删除")
+
+	total, _, err := suite.reportRepo.ListModel(ctx, database.QueryParams{
+		IsCount: true,
+		Query:   map[string]any{"oes_colony_id = ?": uint32(1)},
+	})
+	suite.NoError(err)
+	suite.Equal(int64(2), total, "替换不影响其他交易日的报告")
+
+	total, _, err = suite.reportRepo.ListModel(ctx, database.QueryParams{
+		IsCount: true,
+		Query:   map[string]any{"oes_colony_id = ?": uint32(2)},
+	})
+	suite.NoError(err)
+	suite.Equal(int64(1), total, "替换不影响其他集群的报告")
+
+	suite.Error(suite.reportRepo.ReplaceColonyDay(ctx, nil), "替换空模型应该返回错误")
+}
+
+func TestOesReconcileReportTestSuite(t *testing.T) {
+	suite.Run(t, new(OesReconcileReportTestSuite))
+}
@@ -0,0 +1,248 @@
+package data
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	oesmodel "gin-artweb/internal/model/oes"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/log"
+)
+
+type OesRunbookRepo struct {
+	log      *zap.Logger
+	gormDB   *gorm.DB
+	timeouts *config.DBTimeout
+}
+
+func NewOesRunbookRepo(
+	log *zap.Logger,
+	gormDB *gorm.DB,
+	timeouts *config.DBTimeout,
+) *OesRunbookRepo {
+	return &OesRunbookRepo{
+		log:      log,
+		gormDB:   gormDB,
+		timeouts: timeouts,
+	}
+}
+
+func (r *OesRunbookRepo) CreateModel(ctx context.Context, m *oesmodel.OesRunbookModel) error {
+	// 检查参数
+	if m == nil {
+		err := errors.New("创建oes检查单失败: 模型为空")
+		ctxutil.Logger(ctx, r.log).Error(
+			"创建oes检查单失败: 模型为空",
+			zap.Error(err),
+		)
+		return err
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始创建oes检查单",
+		zap.Object(database.ModelKey, m),
+	)
+	now := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBCreate(dbCtx, r.gormDB, &oesmodel.OesRunbookModel{}, m, nil); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"创建oes检查单失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, m),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return errors.WrapIf(err, "创建oes检查单失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"创建oes检查单成功",
+		zap.Object(database.ModelKey, m),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return nil
+}
+
+func (r *OesRunbookRepo) UpdateModel(ctx context.Context, data map[string]any, conds ...any) error {
+	// 检查参数
+	if len(data) == 0 {
+		err := errors.New("更新oes检查单失败: 更新数据为空")
+		ctxutil.Logger(ctx, r.log).Error(
+			"更新oes检查单失败: 更新数据为空",
+			zap.Error(err),
+			zap.Any(database.UpdateDataKey, data),
+			zap.Any(database.ConditionsKey, conds),
+		)
+		return err
+	}
+
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始更新oes检查单",
+		zap.Any(database.UpdateDataKey, data),
+		zap.Any(database.ConditionsKey, conds),
+	)
+	startTime := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBUpdate(dbCtx, r.gormDB, &oesmodel.OesRunbookModel{}, data, nil, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"更新oes检查单失败",
+			zap.Error(err),
+			zap.Any(database.UpdateDataKey, data),
+			zap.Any(database.ConditionsKey, conds),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return errors.WrapIf(err, "更新oes检查单失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"更新oes检查单成功",
+		zap.Any(database.UpdateDataKey, data),
+		zap.Any(database.ConditionsKey, conds),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return nil
+}
+
+func (r *OesRunbookRepo) DeleteModel(ctx context.Context, conds ...any) error {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始删除oes检查单",
+		zap.Any(database.ConditionsKey, conds),
+	)
+	startTime := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBDelete(dbCtx, r.gormDB, &oesmodel.OesRunbookModel{}, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"删除oes检查单失败",
+			zap.Error(err),
+			zap.Any(database.ConditionsKey, conds),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return errors.WrapIf(err, "删除oes检查单失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"删除oes检查单成功",
+		zap.Any(database.ConditionsKey, conds),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return nil
+}
+
+func (r *OesRunbookRepo) GetModel(
+	ctx context.Context,
+	preloads []string,
+	conds ...any,
+) (*oesmodel.OesRunbookModel, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始查询oes检查单",
+		zap.Any(database.ConditionsKey, conds),
+	)
+	startTime := time.Now()
+	var m oesmodel.OesRunbookModel
+	dbCtx, cancel := database.ReadContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBGet(dbCtx, r.gormDB, preloads, &m, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"查询oes检查单失败",
+			zap.Error(err),
+			zap.Any(database.ConditionsKey, conds),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return nil, errors.WrapIf(err, "查询oes检查单失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"查询oes检查单成功",
+		zap.Object(database.ModelKey, &m),
+		zap.Any(database.ConditionsKey, conds),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return &m, nil
+}
+
+func (r *OesRunbookRepo) ListModel(
+	ctx context.Context,
+	qp database.QueryParams,
+) (int64, *[]oesmodel.OesRunbookModel, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始查询oes检查单列表",
+		zap.Object(database.QueryParamsKey, &qp),
+	)
+	startTime := time.Now()
+	var ms []oesmodel.OesRunbookModel
+	dbCtx, cancel := database.ListContext(database.WithQueryTimeout(ctx, qp.Timeout), r.timeouts)
+	defer cancel()
+	count, err := database.DBList(dbCtx, r.gormDB, &oesmodel.OesRunbookModel{}, &ms, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"查询oes检查单列表失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return 0, nil, errors.WrapIf(err, "查询oes检查单列表失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"查询oes检查单列表成功",
+		zap.Object(database.QueryParamsKey, &qp),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return count, &ms, nil
+}
+
+// UpdateModelWithSteps 在同一个事务中更新检查单并替换其全部步骤
+func (r *OesRunbookRepo) UpdateModelWithSteps(
+	ctx context.Context,
+	runbookID uint32,
+	data map[string]any,
+	steps []oesmodel.OesRunbookStepModel,
+) error {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始更新oes检查单及步骤",
+		zap.Uint32("runbook_id", runbookID),
+		zap.Any(database.UpdateDataKey, data),
+		zap.Int("step_count", len(steps)),
+	)
+	startTime := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	err := database.Conn(dbCtx, r.gormDB).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&oesmodel.OesRunbookModel{}).Where("id = ?", runbookID).Updates(data)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		if err := tx.Where("runbook_id = ?", runbookID).Delete(&oesmodel.OesRunbookStepModel{}).Error; err != nil {
+			return err
+		}
+		if len(steps) == 0 {
+			return nil
+		}
+		for i := range steps {
+			steps[i].ID = 0
+			steps[i].RunbookID = runbookID
+		}
+		return tx.Create(&steps).Error
+	})
+	if err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"更新oes检查单及步骤失败",
+			zap.Error(err),
+			zap.Uint32("runbook_id", runbookID),
+			zap.Any(database.UpdateDataKey, data),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return errors.WrapIf(err, "更新oes检查单及步骤失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"更新oes检查单及步骤成功",
+		zap.Uint32("runbook_id", runbookID),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return nil
+}
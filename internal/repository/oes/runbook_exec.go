@@ -0,0 +1,277 @@
+package data
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	oesmodel "gin-artweb/internal/model/oes"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/log"
+)
+
+type OesRunbookExecRepo struct {
+	log      *zap.Logger
+	gormDB   *gorm.DB
+	timeouts *config.DBTimeout
+}
+
+func NewOesRunbookExecRepo(
+	log *zap.Logger,
+	gormDB *gorm.DB,
+	timeouts *config.DBTimeout,
+) *OesRunbookExecRepo {
+	return &OesRunbookExecRepo{
+		log:      log,
+		gormDB:   gormDB,
+		timeouts: timeouts,
+	}
+}
+
+func (r *OesRunbookExecRepo) CreateModel(ctx context.Context, m *oesmodel.OesRunbookExecModel) error {
+	// 检查参数
+	if m == nil {
+		err := errors.New("创建oes检查单执行失败: 模型为空")
+		ctxutil.Logger(ctx, r.log).Error(
+			"创建oes检查单执行失败: 模型为空",
+			zap.Error(err),
+		)
+		return err
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始创建oes检查单执行",
+		zap.Object(database.ModelKey, m),
+	)
+	now := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBCreate(dbCtx, r.gormDB, &oesmodel.OesRunbookExecModel{}, m, nil); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"创建oes检查单执行失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, m),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return errors.WrapIf(err, "创建oes检查单执行失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"创建oes检查单执行成功",
+		zap.Object(database.ModelKey, m),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return nil
+}
+
+func (r *OesRunbookExecRepo) UpdateModel(ctx context.Context, data map[string]any, conds ...any) error {
+	// 检查参数
+	if len(data) == 0 {
+		err := errors.New("更新oes检查单执行失败: 更新数据为空")
+		ctxutil.Logger(ctx, r.log).Error(
+			"更新oes检查单执行失败: 更新数据为空",
+			zap.Error(err),
+			zap.Any(database.UpdateDataKey, data),
+			zap.Any(database.ConditionsKey, conds),
+		)
+		return err
+	}
+
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始更新oes检查单执行",
+		zap.Any(database.UpdateDataKey, data),
+		zap.Any(database.ConditionsKey, conds),
+	)
+	startTime := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBUpdate(dbCtx, r.gormDB, &oesmodel.OesRunbookExecModel{}, data, nil, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"更新oes检查单执行失败",
+			zap.Error(err),
+			zap.Any(database.UpdateDataKey, data),
+			zap.Any(database.ConditionsKey, conds),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return errors.WrapIf(err, "更新oes检查单执行失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"更新oes检查单执行成功",
+		zap.Any(database.UpdateDataKey, data),
+		zap.Any(database.ConditionsKey, conds),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return nil
+}
+
+func (r *OesRunbookExecRepo) DeleteModel(ctx context.Context, conds ...any) error {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始删除oes检查单执行",
+		zap.Any(database.ConditionsKey, conds),
+	)
+	startTime := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBDelete(dbCtx, r.gormDB, &oesmodel.OesRunbookExecModel{}, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"删除oes检查单执行失败",
+			zap.Error(err),
+			zap.Any(database.ConditionsKey, conds),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return errors.WrapIf(err, "删除oes检查单执行失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"删除oes检查单执行成功",
+		zap.Any(database.ConditionsKey, conds),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return nil
+}
+
+func (r *OesRunbookExecRepo) GetModel(
+	ctx context.Context,
+	preloads []string,
+	conds ...any,
+) (*oesmodel.OesRunbookExecModel, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始查询oes检查单执行",
+		zap.Any(database.ConditionsKey, conds),
+	)
+	startTime := time.Now()
+	var m oesmodel.OesRunbookExecModel
+	dbCtx, cancel := database.ReadContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBGet(dbCtx, r.gormDB, preloads, &m, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"查询oes检查单执行失败",
+			zap.Error(err),
+			zap.Any(database.ConditionsKey, conds),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return nil, errors.WrapIf(err, "查询oes检查单执行失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"查询oes检查单执行成功",
+		zap.Object(database.ModelKey, &m),
+		zap.Any(database.ConditionsKey, conds),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return &m, nil
+}
+
+func (r *OesRunbookExecRepo) ListModel(
+	ctx context.Context,
+	qp database.QueryParams,
+) (int64, *[]oesmodel.OesRunbookExecModel, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始查询oes检查单执行列表",
+		zap.Object(database.QueryParamsKey, &qp),
+	)
+	startTime := time.Now()
+	var ms []oesmodel.OesRunbookExecModel
+	dbCtx, cancel := database.ListContext(database.WithQueryTimeout(ctx, qp.Timeout), r.timeouts)
+	defer cancel()
+	count, err := database.DBList(dbCtx, r.gormDB, &oesmodel.OesRunbookExecModel{}, &ms, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"查询oes检查单执行列表失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return 0, nil, errors.WrapIf(err, "查询oes检查单执行列表失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"查询oes检查单执行列表成功",
+		zap.Object(database.QueryParamsKey, &qp),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return count, &ms, nil
+}
+
+// UpdateStepModel 更新检查单执行中单个步骤的状态
+func (r *OesRunbookExecRepo) UpdateStepModel(ctx context.Context, data map[string]any, conds ...any) error {
+	// 检查参数
+	if len(data) == 0 {
+		err := errors.New("更新oes检查单执行步骤失败: 更新数据为空")
+		ctxutil.Logger(ctx, r.log).Error(
+			"更新oes检查单执行步骤失败: 更新数据为空",
+			zap.Error(err),
+			zap.Any(database.UpdateDataKey, data),
+			zap.Any(database.ConditionsKey, conds),
+		)
+		return err
+	}
+
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始更新oes检查单执行步骤",
+		zap.Any(database.UpdateDataKey, data),
+		zap.Any(database.ConditionsKey, conds),
+	)
+	startTime := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBUpdate(dbCtx, r.gormDB, &oesmodel.OesRunbookExecStepModel{}, data, nil, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"更新oes检查单执行步骤失败",
+			zap.Error(err),
+			zap.Any(database.UpdateDataKey, data),
+			zap.Any(database.ConditionsKey, conds),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return errors.WrapIf(err, "更新oes检查单执行步骤失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"更新oes检查单执行步骤成功",
+		zap.Any(database.UpdateDataKey, data),
+		zap.Any(database.ConditionsKey, conds),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return nil
+}
+
+// FailRunning 将执行中的脚本步骤标记为失败并阻塞其检查单执行, 用于服务重启后处理中断的脚本步骤
+func (r *OesRunbookExecRepo) FailRunning(ctx context.Context, reason string) (int64, error) {
+	startTime := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	var count int64
+	err := database.Conn(dbCtx, r.gormDB).Transaction(func(tx *gorm.DB) error {
+		var execIDs []uint32
+		if err := tx.Model(&oesmodel.OesRunbookExecStepModel{}).
+			Where("status = ?", oesmodel.RunbookStepRunning).
+			Distinct().
+			Pluck("exec_id", &execIDs).Error; err != nil {
+			return err
+		}
+		count = int64(len(execIDs))
+		if count == 0 {
+			return nil
+		}
+		if err := tx.Model(&oesmodel.OesRunbookExecStepModel{}).
+			Where("exec_id IN ? AND status = ?", execIDs, oesmodel.RunbookStepRunning).
+			Updates(map[string]any{
+				"status":        oesmodel.RunbookStepFailed,
+				"error_message": reason,
+				"finished_at":   time.Now(),
+			}).Error; err != nil {
+			return err
+		}
+		return tx.Model(&oesmodel.OesRunbookExecModel{}).
+			Where("id IN ? AND status = ?", execIDs, oesmodel.RunbookExecRunning).
+			Update("status", oesmodel.RunbookExecBlocked).Error
+	})
+	if err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"标记中断的oes检查单脚本步骤失败",
+			zap.Error(err),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return 0, errors.WrapIf(err, "标记中断的oes检查单脚本步骤失败")
+	}
+	return count, nil
+}
@@ -0,0 +1,114 @@
+package data
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	oesmodel "gin-artweb/internal/model/oes"
+	"gin-artweb/internal/shared/test"
+)
+
+func CreateTestOesRunbookModel(name string) *oesmodel.OesRunbookModel {
+	return &oesmodel.OesRunbookModel{
+		Name:      name,
+		IsEnabled: true,
+		Steps: []oesmodel.OesRunbookStepModel{
+			{Sort: 0, Name: "确认行情已停止", Kind: oesmodel.RunbookStepManual},
+			{Sort: 1, Name: "日终清算", Kind: oesmodel.RunbookStepScript, Script: "oes_settle.sh", Timeout: 600},
+		},
+	}
+}
+
+func CreateTestOesRunbookExecModel(runbookID, colonyID uint32, status string) *oesmodel.OesRunbookExecModel {
+	return &oesmodel.OesRunbookExecModel{
+		RunbookID:   runbookID,
+		OesColonyID: colonyID,
+		ColonyNum:   "01",
+		Status:      status,
+		Steps: []oesmodel.OesRunbookExecStepModel{
+			{Sort: 0, Name: "确认行情已停止", Kind: oesmodel.RunbookStepManual, Status: oesmodel.RunbookStepSuccess},
+			{Sort: 1, Name: "日终清算", Kind: oesmodel.RunbookStepScript, Status: oesmodel.RunbookStepRunning},
+		},
+	}
+}
+
+type OesRunbookTestSuite struct {
+	suite.Suite
+	runbookRepo *OesRunbookRepo
+	execRepo    *OesRunbookExecRepo
+}
+
+func (suite *OesRunbookTestSuite) SetupTest() {
+	db := test.NewTestGormDBWithConfig(nil)
+	db.AutoMigrate(
+		&oesmodel.OesRunbookModel{}, &oesmodel.OesRunbookStepModel{},
+		&oesmodel.OesRunbookExecModel{}, &oesmodel.OesRunbookExecStepModel{},
+	)
+
+	dbTimeout := test.NewTestDBTimeouts()
+	logger := test.NewTestZapLogger()
+	suite.runbookRepo = NewOesRunbookRepo(logger, db, dbTimeout)
+	suite.execRepo = NewOesRunbookExecRepo(logger, db, dbTimeout)
+}
+
+func (suite *OesRunbookTestSuite) TestUpdateModelWithSteps() {
+	ctx := context.Background()
+	m := CreateTestOesRunbookModel("日终检查")
+	suite.Require().NoError(suite.runbookRepo.CreateModel(ctx, m))
+	suite.Require().Len(m.Steps, 2)
+
+	// 沿用原步骤ID时应该重新分配
+	steps := []oesmodel.OesRunbookStepModel{
+		{Sort: 0, Name: "检查委托已撤销", Kind: oesmodel.RunbookStepManual},
+	}
+	steps[0].ID = m.Steps[0].ID
+	err := suite.runbookRepo.UpdateModelWithSteps(ctx, m.ID, map[string]any{"description": "收市后执行"}, steps)
+	suite.Require().NoError(err)
+
+	fm, err := suite.runbookRepo.GetModel(ctx, []string{"Steps"}, m.ID)
+	suite.Require().NoError(err)
+	suite.Equal("收市后执行", fm.Description)
+	suite.Require().Len(fm.Steps, 1, "原有步骤应该被替换")
+	suite.Equal("检查委托已撤销", fm.Steps[0].Name)
+	suite.Equal(m.ID, fm.Steps[0].RunbookID)
+
+	err = suite.runbookRepo.UpdateModelWithSteps(ctx, 999, map[string]any{"description": "x"}, steps)
+	suite.Error(err, "更新不存在的检查单应该失败")
+}
+
+func (suite *OesRunbookTestSuite) TestFailRunning() {
+	ctx := context.Background()
+	running := CreateTestOesRunbookExecModel(1, 1, oesmodel.RunbookExecRunning)
+	suite.Require().NoError(suite.execRepo.CreateModel(ctx, running))
+	waiting := CreateTestOesRunbookExecModel(1, 2, oesmodel.RunbookExecRunning)
+	waiting.Steps[1].Status = oesmodel.RunbookStepPending
+	waiting.Steps[0].Status = oesmodel.RunbookStepWaiting
+	suite.Require().NoError(suite.execRepo.CreateModel(ctx, waiting))
+
+	n, err := suite.execRepo.FailRunning(ctx, "服务重启")
+	suite.Require().NoError(err)
+	suite.Equal(int64(1), n, "只有执行中脚本步骤的检查单应该被阻塞")
+
+	fm, err := suite.execRepo.GetModel(ctx, []string{"Steps"}, running.ID)
+	suite.Require().NoError(err)
+	suite.Equal(oesmodel.RunbookExecBlocked, fm.Status)
+	steps := fm.SortedSteps()
+	suite.Equal(oesmodel.RunbookStepSuccess, steps[0].Status)
+	suite.Equal(oesmodel.RunbookStepFailed, steps[1].Status)
+	suite.Equal("服务重启", steps[1].ErrorMessage)
+	suite.NotNil(steps[1].FinishedAt)
+
+	fm, err = suite.execRepo.GetModel(ctx, []string{"Steps"}, waiting.ID)
+	suite.Require().NoError(err)
+	suite.Equal(oesmodel.RunbookExecRunning, fm.Status, "等待人工确认的检查单不受影响")
+
+	n, err = suite.execRepo.FailRunning(ctx, "服务重启")
+	suite.NoError(err)
+	suite.Zero(n)
+}
+
+func TestOesRunbookTestSuite(t *testing.T) {
+	suite.Run(t, new(OesRunbookTestSuite))
+}
@@ -0,0 +1,89 @@
+package data
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	oesmodel "gin-artweb/internal/model/oes"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/test"
+)
+
+func CreateTestOesTaskSlaModel(taskName string, colonyID uint32) *oesmodel.OesTaskSlaModel {
+	return &oesmodel.OesTaskSlaModel{
+		TaskName:    taskName,
+		OesColonyID: colonyID,
+		Deadline:    "07:30",
+		WarnMinutes: 15,
+		IsEnabled:   true,
+	}
+}
+
+type OesTaskSlaTestSuite struct {
+	suite.Suite
+	slaRepo    *OesTaskSlaRepo
+	resultRepo *OesTaskSlaResultRepo
+}
+
+func (suite *OesTaskSlaTestSuite) SetupTest() {
+	db := test.NewTestGormDBWithConfig(nil)
+	db.AutoMigrate(&oesmodel.OesTaskSlaModel{}, &oesmodel.OesTaskSlaResultModel{})
+
+	dbTimeout := test.NewTestDBTimeouts()
+	logger := test.NewTestZapLogger()
+	suite.slaRepo = NewOesTaskSlaRepo(logger, db, dbTimeout)
+	suite.resultRepo = NewOesTaskSlaResultRepo(logger, db, dbTimeout)
+}
+
+func (suite *OesTaskSlaTestSuite) TestCreateModel() {
+	ctx := context.Background()
+	suite.NoError(suite.slaRepo.CreateModel(ctx, CreateTestOesTaskSlaModel("counter_distribute", 0)))
+	suite.NoError(suite.slaRepo.CreateModel(ctx, CreateTestOesTaskSlaModel("counter_distribute", 1)),
+		"同一任务可以为集群单独定义SLA")
+	suite.Error(suite.slaRepo.CreateModel(ctx, CreateTestOesTaskSlaModel("counter_distribute", 1)),
+		"同一任务在同一集群上只能定义一个SLA")
+
+	total, ms, err := suite.slaRepo.ListModel(ctx, database.QueryParams{
+		IsCount: true,
+		Query:   map[string]any{"task_name = ?": "counter_distribute"},
+	})
+	suite.NoError(err)
+	suite.Equal(int64(2), total)
+	suite.Len(*ms, 2)
+}
+
+func (suite *OesTaskSlaTestSuite) TestResultUniquePerDay() {
+	ctx := context.Background()
+	result := &oesmodel.OesTaskSlaResultModel{
+		SlaID:       1,
+		OesColonyID: 1,
+		ColonyNum:   "01",
+		TaskName:    "counter_distribute",
+		TradingDay:  "2024-01-02",
+		Deadline:    "07:30",
+		Status:      oesmodel.TaskSlaPending,
+	}
+	suite.Require().NoError(suite.resultRepo.CreateModel(ctx, result))
+	dup := *result
+	dup.ID = 0
+	suite.Error(suite.resultRepo.CreateModel(ctx, &dup), "同一集群同一任务每个交易日只保留一个检查结果")
+	dup.TradingDay = "2024-01-03"
+	suite.NoError(suite.resultRepo.CreateModel(ctx, &dup))
+
+	err := suite.resultRepo.UpdateModel(ctx, map[string]any{
+		"status":      oesmodel.TaskSlaBreached,
+		"alert_level": "breached",
+	}, "id = ?", result.ID)
+	suite.Require().NoError(err)
+	fm, err := suite.resultRepo.GetModel(ctx, nil,
+		"oes_colony_id = ? AND task_name = ? AND trading_day = ?", uint32(1), "counter_distribute", "2024-01-02")
+	suite.Require().NoError(err)
+	suite.Equal(oesmodel.TaskSlaBreached, fm.Status)
+	suite.Equal("breached", fm.AlertLevel)
+}
+
+func TestOesTaskSlaTestSuite(t *testing.T) {
+	suite.Run(t, new(OesTaskSlaTestSuite))
+}
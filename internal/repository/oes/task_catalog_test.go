@@ -0,0 +1,62 @@
+package data
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	oesmodel "gin-artweb/internal/model/oes"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/test"
+)
+
+type OesTaskCatalogTestSuite struct {
+	suite.Suite
+	catalogRepo *OesTaskCatalogRepo
+}
+
+func (suite *OesTaskCatalogTestSuite) SetupTest() {
+	db := test.NewTestGormDBWithConfig(nil)
+	db.AutoMigrate(&oesmodel.OesTaskCatalogModel{})
+	suite.catalogRepo = NewOesTaskCatalogRepo(test.NewTestZapLogger(), db, test.NewTestDBTimeouts())
+}
+
+func (suite *OesTaskCatalogTestSuite) TestCreateModel() {
+	ctx := context.Background()
+	for _, m := range oesmodel.DefaultOesTaskCatalog() {
+		suite.Require().NoError(suite.catalogRepo.CreateModel(ctx, &m))
+	}
+	suite.Error(suite.catalogRepo.CreateModel(ctx, &oesmodel.OesTaskCatalogModel{SystemType: "STK", TaskName: "mon"}),
+		"同一系统类型的任务名称不能重复")
+	suite.NoError(suite.catalogRepo.CreateModel(ctx, &oesmodel.OesTaskCatalogModel{SystemType: "OPT", TaskName: "bse"}))
+
+	total, ms, err := suite.catalogRepo.ListModel(ctx, database.QueryParams{
+		IsCount: true,
+		OrderBy: []string{"sort ASC", "id ASC"},
+		Query:   map[string]any{"system_type = ?": "CRD"},
+	})
+	suite.NoError(err)
+	suite.Equal(int64(8), total)
+	suite.Equal("mon", (*ms)[0].TaskName)
+	suite.Equal("szse_late", (*ms)[7].TaskName)
+}
+
+func (suite *OesTaskCatalogTestSuite) TestUpdateAndDelete() {
+	ctx := context.Background()
+	m := &oesmodel.OesTaskCatalogModel{SystemType: "STK", TaskName: "bj_fetch", Sort: 80, IsEnabled: true}
+	suite.Require().NoError(suite.catalogRepo.CreateModel(ctx, m))
+
+	suite.Require().NoError(suite.catalogRepo.UpdateModel(ctx, map[string]any{"is_enabled": false}, "id = ?", m.ID))
+	fm, err := suite.catalogRepo.GetModel(ctx, nil, m.ID)
+	suite.Require().NoError(err)
+	suite.False(fm.IsEnabled)
+
+	suite.Require().NoError(suite.catalogRepo.DeleteModel(ctx, m.ID))
+	_, err = suite.catalogRepo.GetModel(ctx, nil, m.ID)
+	suite.Error(err)
+}
+
+func TestOesTaskCatalogTestSuite(t *testing.T) {
+	suite.Run(t, new(OesTaskCatalogTestSuite))
+}
@@ -0,0 +1,119 @@
+package data
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	oesmodel "gin-artweb/internal/model/oes"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/test"
+)
+
+func CreateTestOesWorkflowRunModel(colonyID uint32) *oesmodel.OesWorkflowRunModel {
+	return &oesmodel.OesWorkflowRunModel{
+		OesColonyID: colonyID,
+		ColonyNum:   "01",
+		SystemType:  "STK",
+		Status:      oesmodel.WorkflowStatusPending,
+		Username:    "admin",
+		Steps: []oesmodel.OesWorkflowRunStepModel{
+			{Name: "mon", ScriptID: 1, Sort: 0, Status: oesmodel.WorkflowStatusPending},
+			{Name: "counter_fetch", ScriptID: 2, DependsOn: "mon", Sort: 1, Status: oesmodel.WorkflowStatusPending},
+		},
+	}
+}
+
+type OesWorkflowRunTestSuite struct {
+	suite.Suite
+	runRepo *OesWorkflowRunRepo
+}
+
+func (suite *OesWorkflowRunTestSuite) SetupSuite() {
+	db := test.NewTestGormDBWithConfig(nil)
+	db.AutoMigrate(&oesmodel.OesWorkflowRunModel{}, &oesmodel.OesWorkflowRunStepModel{})
+
+	dbTimeout := test.NewTestDBTimeouts()
+	logger := test.NewTestZapLogger()
+	suite.runRepo = NewOesWorkflowRunRepo(logger, db, dbTimeout)
+}
+
+func (suite *OesWorkflowRunTestSuite) TestCreateModel() {
+	rm := CreateTestOesWorkflowRunModel(1)
+	err := suite.runRepo.CreateModel(context.Background(), rm)
+	suite.NoError(err, "创建OesWorkflowRun应该成功")
+	suite.NotZero(rm.ID, "OesWorkflowRun ID应该不为零")
+	for _, s := range rm.Steps {
+		suite.Equal(rm.ID, s.RunID, "步骤应该关联到编排执行记录")
+		suite.NotZero(s.ID, "步骤ID应该不为零")
+	}
+
+	fm, err := suite.runRepo.GetModel(context.Background(), []string{"Steps"}, rm.ID)
+	suite.NoError(err, "查询OesWorkflowRun应该成功")
+	suite.Len(fm.Steps, 2, "应该预加载全部步骤")
+
+	err = suite.runRepo.CreateModel(context.Background(), nil)
+	suite.Error(err, "创建空OesWorkflowRun模型应该返回错误")
+}
+
+func (suite *OesWorkflowRunTestSuite) TestUpdateStepModel() {
+	rm := CreateTestOesWorkflowRunModel(2)
+	suite.NoError(suite.runRepo.CreateModel(context.Background(), rm))
+
+	now := time.Now()
+	err := suite.runRepo.UpdateStepModel(context.Background(), map[string]any{
+		"status":     oesmodel.WorkflowStatusSuccess,
+		"record_id":  uint32(10),
+		"started_at": now,
+	}, "run_id = ? AND name = ?", rm.ID, "mon")
+	suite.NoError(err, "更新编排步骤应该成功")
+
+	fm, err := suite.runRepo.GetModel(context.Background(), []string{"Steps"}, rm.ID)
+	suite.NoError(err)
+	for _, s := range fm.Steps {
+		if s.Name == "mon" {
+			suite.Equal(oesmodel.WorkflowStatusSuccess, s.Status)
+			suite.Equal(uint32(10), s.RecordID)
+			suite.NotNil(s.StartedAt)
+		} else {
+			suite.Equal(oesmodel.WorkflowStatusPending, s.Status, "其他步骤不应该被更新")
+		}
+	}
+
+	err = suite.runRepo.UpdateStepModel(context.Background(), map[string]any{}, "run_id = ?", rm.ID)
+	suite.Error(err, "更新数据为空时应该返回错误")
+}
+
+func (suite *OesWorkflowRunTestSuite) TestUpdateModel() {
+	rm := CreateTestOesWorkflowRunModel(3)
+	suite.NoError(suite.runRepo.CreateModel(context.Background(), rm))
+
+	err := suite.runRepo.UpdateModel(context.Background(), map[string]any{
+		"status": oesmodel.WorkflowStatusFailed,
+	}, "id = ?", rm.ID)
+	suite.NoError(err, "更新OesWorkflowRun应该成功")
+
+	fm, err := suite.runRepo.GetModel(context.Background(), nil, rm.ID)
+	suite.NoError(err)
+	suite.Equal(oesmodel.WorkflowStatusFailed, fm.Status)
+}
+
+func (suite *OesWorkflowRunTestSuite) TestListModel() {
+	for i := 0; i < 3; i++ {
+		suite.NoError(suite.runRepo.CreateModel(context.Background(), CreateTestOesWorkflowRunModel(4)))
+	}
+
+	count, ms, err := suite.runRepo.ListModel(context.Background(), database.QueryParams{
+		IsCount: true,
+		Query:   map[string]any{"oes_colony_id = ?": 4},
+	})
+	suite.NoError(err, "查询OesWorkflowRun列表应该成功")
+	suite.Equal(int64(3), count)
+	suite.Len(*ms, 3)
+}
+
+func TestOesWorkflowRunTestSuite(t *testing.T) {
+	suite.Run(t, new(OesWorkflowRunTestSuite))
+}
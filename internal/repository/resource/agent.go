@@ -0,0 +1,268 @@
+package resource
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	resomodel "gin-artweb/internal/model/resource"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/log"
+)
+
+// HostAgentRepo 主机代理仓库实现
+type HostAgentRepo struct {
+	log      *zap.Logger       // 日志记录器
+	gormDB   *gorm.DB          // GORM数据库连接
+	timeouts *config.DBTimeout // 数据库操作超时配置
+}
+
+// NewHostAgentRepo 创建主机代理仓库实例
+func NewHostAgentRepo(
+	log *zap.Logger,
+	gormDB *gorm.DB,
+	timeouts *config.DBTimeout,
+) *HostAgentRepo {
+	return &HostAgentRepo{
+		log:      log,
+		gormDB:   gormDB,
+		timeouts: timeouts,
+	}
+}
+
+func (r *HostAgentRepo) CreateModel(ctx context.Context, m *resomodel.HostAgentModel) error {
+	// 检查参数
+	if m == nil {
+		err := errors.New("创建主机代理失败: 模型为空")
+		ctxutil.Logger(ctx, r.log).Error(
+			"创建主机代理失败: 模型为空",
+			zap.Error(err),
+		)
+		return err
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始创建主机代理",
+		zap.Object(database.ModelKey, m),
+	)
+	now := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBCreate(dbCtx, r.gormDB, &resomodel.HostAgentModel{}, m, nil); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"创建主机代理失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, m),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return errors.WrapIf(err, "创建主机代理失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"创建主机代理成功",
+		zap.Object(database.ModelKey, m),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return nil
+}
+
+func (r *HostAgentRepo) UpdateModel(ctx context.Context, data map[string]any, conds ...any) error {
+	if len(data) == 0 {
+		err := errors.New("更新主机代理失败: 更新数据为空")
+		ctxutil.Logger(ctx, r.log).Error(
+			"更新主机代理失败: 更新数据为空",
+			zap.Error(err),
+		)
+		return err
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始更新主机代理",
+		zap.Any(database.UpdateDataKey, data),
+		zap.Any(database.ConditionsKey, conds),
+	)
+	now := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBUpdate(dbCtx, r.gormDB, &resomodel.HostAgentModel{}, data, nil, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"更新主机代理失败",
+			zap.Error(err),
+			zap.Any(database.UpdateDataKey, data),
+			zap.Any(database.ConditionsKey, conds),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return errors.WrapIf(err, "更新主机代理失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"更新主机代理成功",
+		zap.Any(database.UpdateDataKey, data),
+		zap.Any(database.ConditionsKey, conds),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return nil
+}
+
+func (r *HostAgentRepo) GetModel(
+	ctx context.Context,
+	preloads []string,
+	conds ...any,
+) (*resomodel.HostAgentModel, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始查询主机代理",
+		zap.Any(database.ConditionsKey, conds),
+	)
+	now := time.Now()
+	var m resomodel.HostAgentModel
+	dbCtx, cancel := database.ReadContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBGet(dbCtx, r.gormDB, preloads, &m, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"查询主机代理失败",
+			zap.Error(err),
+			zap.Any(database.ConditionsKey, conds),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return nil, errors.WrapIf(err, "查询主机代理失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"查询主机代理成功",
+		zap.Object(database.ModelKey, &m),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return &m, nil
+}
+
+func (r *HostAgentRepo) ListModel(
+	ctx context.Context,
+	qp database.QueryParams,
+) (int64, *[]resomodel.HostAgentModel, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始查询主机代理列表",
+		zap.Object(database.QueryParamsKey, &qp),
+	)
+	now := time.Now()
+	var ms []resomodel.HostAgentModel
+	dbCtx, cancel := database.ListContext(database.WithQueryTimeout(ctx, qp.Timeout), r.timeouts)
+	defer cancel()
+	count, err := database.DBList(dbCtx, r.gormDB, &resomodel.HostAgentModel{}, &ms, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"查询主机代理列表失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return 0, nil, errors.WrapIf(err, "查询主机代理列表失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"查询主机代理列表成功",
+		zap.Object(database.QueryParamsKey, &qp),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return count, &ms, nil
+}
+
+// Register 在一个事务中注册主机代理
+//
+// 按SSH地址、端口和用户匹配已有主机, 不存在时创建主机; 已注册的代理更新上报的主机信息,
+// 否则创建代理记录。返回的代理记录包含关联的主机, created表示是否新建了主机
+func (r *HostAgentRepo) Register(
+	ctx context.Context,
+	host resomodel.HostModel,
+	m resomodel.HostAgentModel,
+) (*resomodel.HostAgentModel, bool, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始注册主机代理",
+		zap.Object(database.ModelKey, &host),
+	)
+	now := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	created := false
+	err := database.Conn(dbCtx, r.gormDB).Transaction(func(tx *gorm.DB) error {
+		var existing resomodel.HostModel
+		err := tx.Where("ssh_ip = ? AND ssh_port = ? AND ssh_user = ?", host.SSHIP, host.SSHPort, host.SSHUser).
+			Take(&existing).Error
+		switch {
+		case err == nil:
+			host = existing
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			if err := tx.Create(&host).Error; err != nil {
+				return err
+			}
+			created = true
+		default:
+			return err
+		}
+
+		var agent resomodel.HostAgentModel
+		err = tx.Where("host_id = ?", host.ID).Take(&agent).Error
+		switch {
+		case err == nil:
+			m.ID = agent.ID
+			m.HostID = host.ID
+			// 使用结构体更新才会按serializer序列化磁盘和IP地址
+			return tx.Model(&agent).Select(
+				"hostname", "serial", "os", "kernel", "arch", "cpu_cores", "memory_mb",
+				"disks", "ips", "agent_version", "status", "last_heartbeat_at",
+			).Updates(&m).Error
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			m.HostID = host.ID
+			return tx.Omit("Host").Create(&m).Error
+		default:
+			return err
+		}
+	})
+	if err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"注册主机代理失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, &host),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return nil, false, errors.WrapIf(err, "注册主机代理失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"注册主机代理成功",
+		zap.Uint32("host_id", host.ID),
+		zap.Bool("created", created),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	agent, err := r.GetModel(ctx, []string{"Host"}, "host_id = ?", host.ID)
+	if err != nil {
+		return nil, false, err
+	}
+	return agent, created, nil
+}
+
+// UpdateStatus 按当前状态更新代理的在线状态, 返回是否更新成功
+//
+// 多个实例同时检测时只有一个实例能完成状态变更, 避免重复告警
+func (r *HostAgentRepo) UpdateStatus(
+	ctx context.Context,
+	agentID uint32,
+	from string,
+	data map[string]any,
+) (bool, error) {
+	now := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	result := database.Conn(dbCtx, r.gormDB).Model(&resomodel.HostAgentModel{}).
+		Where("id = ? AND status = ?", agentID, from).
+		Updates(data)
+	if result.Error != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"更新主机代理状态失败",
+			zap.Error(result.Error),
+			zap.Uint32("agent_id", agentID),
+			zap.String("from", from),
+			zap.Any(database.UpdateDataKey, data),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return false, errors.WrapIf(result.Error, "更新主机代理状态失败")
+	}
+	return result.RowsAffected > 0, nil
+}
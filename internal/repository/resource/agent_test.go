@@ -0,0 +1,85 @@
+package resource
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	resomodel "gin-artweb/internal/model/resource"
+	"gin-artweb/internal/shared/test"
+)
+
+func CreateTestHostAgentModel() resomodel.HostAgentModel {
+	return resomodel.HostAgentModel{
+		Hostname:        "node01",
+		OS:              "CentOS Linux 7",
+		Arch:            "x86_64",
+		CPUCores:        8,
+		MemoryMB:        16384,
+		Disks:           []resomodel.HostAgentDisk{{Mount: "/", TotalMB: 102400, UsedMB: 2048}},
+		IPs:             []string{"192.168.1.10"},
+		AgentVersion:    "1.0.0",
+		Status:          resomodel.HostAgentOnline,
+		LastHeartbeatAt: time.Now(),
+	}
+}
+
+type HostAgentTestSuite struct {
+	suite.Suite
+	agentRepo *HostAgentRepo
+}
+
+func (suite *HostAgentTestSuite) SetupTest() {
+	db := test.NewTestGormDBWithConfig(nil)
+	db.AutoMigrate(&resomodel.HostModel{}, &resomodel.HostAgentModel{})
+	dbTimeout := test.NewTestDBTimeouts()
+	logger := test.NewTestZapLogger()
+	suite.agentRepo = NewHostAgentRepo(logger, db, dbTimeout)
+}
+
+func (suite *HostAgentTestSuite) TestRegister() {
+	host := *CreateTestHostModel()
+	agent, created, err := suite.agentRepo.Register(context.Background(), host, CreateTestHostAgentModel())
+	suite.NoError(err, "注册主机代理应该成功")
+	suite.True(created, "主机不存在时应该创建主机")
+	suite.NotZero(agent.HostID)
+	suite.Equal(host.Name, agent.Host.Name)
+	suite.Len(agent.Disks, 1)
+	suite.Equal([]string{"192.168.1.10"}, agent.IPs)
+
+	m := CreateTestHostAgentModel()
+	m.MemoryMB = 32768
+	m.IPs = []string{"192.168.1.10", "10.0.0.10"}
+	again, created, err := suite.agentRepo.Register(context.Background(), host, m)
+	suite.NoError(err, "重复注册主机代理应该成功")
+	suite.False(created, "主机已存在时不应该重复创建")
+	suite.Equal(agent.ID, again.ID)
+	suite.Equal(agent.HostID, again.HostID)
+	suite.Equal(uint64(32768), again.MemoryMB, "重复注册应该更新主机信息")
+	suite.Len(again.IPs, 2)
+}
+
+func (suite *HostAgentTestSuite) TestUpdateStatus() {
+	agent, _, err := suite.agentRepo.Register(context.Background(), *CreateTestHostModel(), CreateTestHostAgentModel())
+	suite.NoError(err)
+
+	ok, err := suite.agentRepo.UpdateStatus(context.Background(), agent.ID, resomodel.HostAgentOnline,
+		map[string]any{"status": resomodel.HostAgentOffline})
+	suite.NoError(err)
+	suite.True(ok, "在线的代理应该能标记为离线")
+
+	ok, err = suite.agentRepo.UpdateStatus(context.Background(), agent.ID, resomodel.HostAgentOnline,
+		map[string]any{"status": resomodel.HostAgentOffline})
+	suite.NoError(err)
+	suite.False(ok, "已离线的代理不应该重复标记")
+
+	fm, err := suite.agentRepo.GetModel(context.Background(), nil, agent.ID)
+	suite.NoError(err)
+	suite.Equal(resomodel.HostAgentOffline, fm.Status)
+}
+
+func TestHostAgentTestSuite(t *testing.T) {
+	suite.Run(t, new(HostAgentTestSuite))
+}
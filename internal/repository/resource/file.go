@@ -0,0 +1,187 @@
+package resource
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	resomodel "gin-artweb/internal/model/resource"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/log"
+)
+
+// HostPathRuleRepo 主机路径规则仓库实现
+type HostPathRuleRepo struct {
+	log      *zap.Logger       // 日志记录器
+	gormDB   *gorm.DB          // GORM数据库连接
+	timeouts *config.DBTimeout // 数据库操作超时配置
+}
+
+// NewHostPathRuleRepo 创建主机路径规则仓库实例
+func NewHostPathRuleRepo(
+	log *zap.Logger,
+	gormDB *gorm.DB,
+	timeouts *config.DBTimeout,
+) *HostPathRuleRepo {
+	return &HostPathRuleRepo{
+		log:      log,
+		gormDB:   gormDB,
+		timeouts: timeouts,
+	}
+}
+
+func (r *HostPathRuleRepo) CreateModel(ctx context.Context, m *resomodel.HostPathRuleModel) error {
+	// 检查参数
+	if m == nil {
+		err := errors.New("创建主机路径规则失败: 模型为空")
+		ctxutil.Logger(ctx, r.log).Error(
+			"创建主机路径规则失败: 模型为空",
+			zap.Error(err),
+		)
+		return err
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始创建主机路径规则",
+		zap.Object(database.ModelKey, m),
+	)
+	now := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBCreate(dbCtx, r.gormDB, &resomodel.HostPathRuleModel{}, m, nil); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"创建主机路径规则失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, m),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return errors.WrapIf(err, "创建主机路径规则失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"创建主机路径规则成功",
+		zap.Object(database.ModelKey, m),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return nil
+}
+
+func (r *HostPathRuleRepo) UpdateModel(ctx context.Context, data map[string]any, conds ...any) error {
+	if len(data) == 0 {
+		err := errors.New("更新主机路径规则失败: 更新数据为空")
+		ctxutil.Logger(ctx, r.log).Error(
+			"更新主机路径规则失败: 更新数据为空",
+			zap.Error(err),
+		)
+		return err
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始更新主机路径规则",
+		zap.Any(database.UpdateDataKey, data),
+		zap.Any(database.ConditionsKey, conds),
+	)
+	now := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBUpdate(dbCtx, r.gormDB, &resomodel.HostPathRuleModel{}, data, nil, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"更新主机路径规则失败",
+			zap.Error(err),
+			zap.Any(database.UpdateDataKey, data),
+			zap.Any(database.ConditionsKey, conds),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return errors.WrapIf(err, "更新主机路径规则失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"更新主机路径规则成功",
+		zap.Any(database.UpdateDataKey, data),
+		zap.Any(database.ConditionsKey, conds),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return nil
+}
+
+func (r *HostPathRuleRepo) DeleteModel(ctx context.Context, conds ...any) error {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始删除主机路径规则",
+		zap.Any(database.ConditionsKey, conds),
+	)
+	now := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBDelete(dbCtx, r.gormDB, &resomodel.HostPathRuleModel{}, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"删除主机路径规则失败",
+			zap.Error(err),
+			zap.Any(database.ConditionsKey, conds),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return errors.WrapIf(err, "删除主机路径规则失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"删除主机路径规则成功",
+		zap.Any(database.ConditionsKey, conds),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return nil
+}
+
+func (r *HostPathRuleRepo) GetModel(ctx context.Context, conds ...any) (*resomodel.HostPathRuleModel, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始查询主机路径规则",
+		zap.Any(database.ConditionsKey, conds),
+	)
+	now := time.Now()
+	var m resomodel.HostPathRuleModel
+	dbCtx, cancel := database.ReadContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBGet(dbCtx, r.gormDB, nil, &m, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"查询主机路径规则失败",
+			zap.Error(err),
+			zap.Any(database.ConditionsKey, conds),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return nil, errors.WrapIf(err, "查询主机路径规则失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"查询主机路径规则成功",
+		zap.Object(database.ModelKey, &m),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return &m, nil
+}
+
+func (r *HostPathRuleRepo) ListModel(
+	ctx context.Context,
+	qp database.QueryParams,
+) (int64, *[]resomodel.HostPathRuleModel, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始查询主机路径规则列表",
+		zap.Object(database.QueryParamsKey, &qp),
+	)
+	now := time.Now()
+	var ms []resomodel.HostPathRuleModel
+	dbCtx, cancel := database.ListContext(database.WithQueryTimeout(ctx, qp.Timeout), r.timeouts)
+	defer cancel()
+	count, err := database.DBList(dbCtx, r.gormDB, &resomodel.HostPathRuleModel{}, &ms, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"查询主机路径规则列表失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return 0, nil, errors.WrapIf(err, "查询主机路径规则列表失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"查询主机路径规则列表成功",
+		zap.Object(database.QueryParamsKey, &qp),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return count, &ms, nil
+}
@@ -1,7 +1,10 @@
 package resource
 
 import (
+	"cmp"
 	"context"
+	"net"
+	"strconv"
 	"time"
 
 	"emperror.dev/errors"
@@ -13,8 +16,10 @@ import (
 	"gin-artweb/internal/shared/config"
 	"gin-artweb/internal/shared/ctxutil"
 	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/deadline"
 	"gin-artweb/internal/shared/log"
 	"gin-artweb/internal/shared/shell"
+	"gin-artweb/pkg/breaker"
 )
 
 // HostRepo 主机仓库实现
@@ -24,6 +29,7 @@ type HostRepo struct {
 	log      *zap.Logger       // 日志记录器
 	gormDB   *gorm.DB          // GORM数据库连接
 	timeouts *config.DBTimeout // 数据库操作超时配置
+	breakers *breaker.Group    // SSH连接熔断器, 每个主机地址一个
 }
 
 // NewHostRepo 创建主机仓库实例
@@ -33,6 +39,7 @@ type HostRepo struct {
 //	log: 日志记录器，用于记录操作日志
 //	gormDB: GORM数据库连接，用于执行数据库操作
 //	timeouts: 数据库操作超时配置，控制各类数据库操作的超时时间
+//	breakers: SSH连接熔断器分组，为nil时不熔断
 //
 // 返回值：
 //
@@ -41,11 +48,13 @@ func NewHostRepo(
 	log *zap.Logger,
 	gormDB *gorm.DB,
 	timeouts *config.DBTimeout,
+	breakers *breaker.Group,
 ) *HostRepo {
 	return &HostRepo{
 		log:      log,
 		gormDB:   gormDB,
 		timeouts: timeouts,
+		breakers: breakers,
 	}
 }
 
@@ -69,37 +78,33 @@ func (r *HostRepo) CreateModel(ctx context.Context, m *resomodel.HostModel) erro
 	// 检查参数
 	if m == nil {
 		err := errors.New("创建主机模型失败: 模型为空")
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"创建主机模型失败: 模型为空",
 			zap.Error(err),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return err
 	}
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"开始创建主机模型",
 		zap.Object(database.ModelKey, m),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	now := time.Now()
 	m.CreatedAt = now
 	m.UpdatedAt = now
-	dbCtx, cancel := context.WithTimeout(ctx, r.timeouts.WriteTimeout)
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
 	defer cancel()
 	if err := database.DBCreate(dbCtx, r.gormDB, &resomodel.HostModel{}, m, nil); err != nil {
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"创建主机模型失败",
 			zap.Error(err),
 			zap.Object(database.ModelKey, m),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			zap.Duration(log.DurationKey, time.Since(now)),
 		)
 		return errors.WrapIf(err, "创建主机模型失败")
 	}
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"创建主机模型成功",
 		zap.Object(database.ModelKey, m),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		zap.Duration(log.DurationKey, time.Since(now)),
 	)
 	return nil
@@ -124,40 +129,36 @@ func (r *HostRepo) CreateModel(ctx context.Context, m *resomodel.HostModel) erro
 func (r *HostRepo) UpdateModel(ctx context.Context, data map[string]any, conds ...any) error {
 	if len(data) == 0 {
 		err := errors.New("更新主机模型失败: 更新数据为空")
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"更新主机模型失败: 更新数据为空",
 			zap.Error(err),
 			zap.Any(database.UpdateDataKey, data),
 			zap.Any(database.ConditionsKey, conds),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return err
 	}
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"开始更新主机模型",
 		zap.Any(database.UpdateDataKey, data),
 		zap.Any(database.ConditionsKey, conds),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	now := time.Now()
-	dbCtx, cancel := context.WithTimeout(ctx, r.timeouts.WriteTimeout)
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
 	defer cancel()
 	if err := database.DBUpdate(dbCtx, r.gormDB, &resomodel.HostModel{}, data, nil, conds...); err != nil {
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"更新主机模型失败",
 			zap.Error(err),
 			zap.Any(database.UpdateDataKey, data),
 			zap.Any(database.ConditionsKey, conds),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			zap.Duration(log.DurationKey, time.Since(now)),
 		)
 		return errors.WrapIf(err, "更新主机模型失败")
 	}
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"更新主机模型成功",
 		zap.Any(database.UpdateDataKey, data),
 		zap.Any(database.ConditionsKey, conds),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		zap.Duration(log.DurationKey, time.Since(now)),
 	)
 	return nil
@@ -178,28 +179,25 @@ func (r *HostRepo) UpdateModel(ctx context.Context, data map[string]any, conds .
 //  1. 执行数据库删除操作
 //  2. 记录操作日志
 func (r *HostRepo) DeleteModel(ctx context.Context, conds ...any) error {
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"开始删除主机模型",
 		zap.Any(database.ConditionsKey, conds),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	now := time.Now()
-	dbCtx, cancel := context.WithTimeout(ctx, r.timeouts.WriteTimeout)
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
 	defer cancel()
 	if err := database.DBDelete(dbCtx, r.gormDB, &resomodel.HostModel{}, conds...); err != nil {
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"删除主机模型失败",
 			zap.Error(err),
 			zap.Any(database.ConditionsKey, conds),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			zap.Duration(log.DurationKey, time.Since(now)),
 		)
 		return errors.WrapIf(err, "删除主机模型失败")
 	}
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"删除主机模型成功",
 		zap.Any(database.ConditionsKey, conds),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		zap.Duration(log.DurationKey, time.Since(now)),
 	)
 	return nil
@@ -228,30 +226,27 @@ func (r *HostRepo) GetModel(
 	preloads []string,
 	conds ...any,
 ) (*resomodel.HostModel, error) {
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"开始查询主机模型",
 		zap.Any(database.ConditionsKey, conds),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	now := time.Now()
 	var m resomodel.HostModel
-	dbCtx, cancel := context.WithTimeout(ctx, r.timeouts.ReadTimeout)
+	dbCtx, cancel := database.ReadContext(ctx, r.timeouts)
 	defer cancel()
 	if err := database.DBGet(dbCtx, r.gormDB, preloads, &m, conds...); err != nil {
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"查询主机模型失败",
 			zap.Error(err),
 			zap.Any(database.ConditionsKey, conds),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			zap.Duration(log.DurationKey, time.Since(now)),
 		)
 		return nil, errors.WrapIf(err, "查询主机模型失败")
 	}
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"查询主机模型成功",
 		zap.Object(database.ModelKey, &m),
 		zap.Any(database.ConditionsKey, conds),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		zap.Duration(log.DurationKey, time.Since(now)),
 	)
 	return &m, nil
@@ -279,35 +274,112 @@ func (r *HostRepo) ListModel(
 	ctx context.Context,
 	qp database.QueryParams,
 ) (int64, *[]resomodel.HostModel, error) {
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"开始查询主机模型列表",
 		zap.Object(database.QueryParamsKey, &qp),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	now := time.Now()
 	var ms []resomodel.HostModel
-	dbCtx, cancel := context.WithTimeout(ctx, r.timeouts.ListTimeout)
+	dbCtx, cancel := database.ListContext(database.WithQueryTimeout(ctx, qp.Timeout), r.timeouts)
 	defer cancel()
 	count, err := database.DBList(dbCtx, r.gormDB, &resomodel.HostModel{}, &ms, qp)
 	if err != nil {
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"查询主机模型列表失败",
 			zap.Error(err),
 			zap.Object(database.QueryParamsKey, &qp),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			zap.Duration(log.DurationKey, time.Since(now)),
 		)
 		return 0, nil, errors.WrapIf(err, "查询主机模型列表失败")
 	}
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"查询主机模型列表成功",
 		zap.Object(database.QueryParamsKey, &qp),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		zap.Duration(log.DurationKey, time.Since(now)),
 	)
 	return count, &ms, nil
 }
 
+// MergeReferences 将引用重复主机的记录改为引用保留的主机
+//
+// 参数：
+//
+//	ctx: 上下文，用于传递请求信息和控制超时，上下文中有事务时在该事务中执行
+//	duplicateID: 重复的主机ID
+//	hostID: 保留的主机ID
+//	refs: 引用主机ID的数据表字段
+//
+// 返回值：
+//
+//	map[string]int64: 每个数据表改为引用保留主机的记录数
+//	error: 操作错误信息，成功则返回nil
+//
+// 功能：
+//  1. 逐个数据表将引用重复主机的记录改为引用保留的主机
+//  2. 每台主机只有一条代理记录，保留的主机已有代理时删除重复主机的代理，否则改为保留主机的代理
+//  3. 记录操作日志
+func (r *HostRepo) MergeReferences(
+	ctx context.Context,
+	duplicateID, hostID uint32,
+	refs []resomodel.HostReference,
+) (map[string]int64, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始合并主机引用",
+		zap.Uint32("duplicate_id", duplicateID),
+		zap.Uint32("host_id", hostID),
+	)
+	now := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	counts := make(map[string]int64, len(refs)+1)
+	err := database.Conn(dbCtx, r.gormDB).Transaction(func(tx *gorm.DB) error {
+		for _, ref := range refs {
+			result := tx.Table(ref.Table).
+				Where(ref.Column+" = ?", duplicateID).
+				Update(ref.Column, hostID)
+			if result.Error != nil {
+				return errors.WrapIff(result.Error, "更新数据表%s失败", ref.Table)
+			}
+			counts[ref.Table] += result.RowsAffected
+		}
+
+		agentTable := (&resomodel.HostAgentModel{}).TableName()
+		var n int64
+		if err := tx.Model(&resomodel.HostAgentModel{}).Where("host_id = ?", hostID).Count(&n).Error; err != nil {
+			return err
+		}
+		if n > 0 {
+			return tx.Where("host_id = ?", duplicateID).Delete(&resomodel.HostAgentModel{}).Error
+		}
+		result := tx.Model(&resomodel.HostAgentModel{}).
+			Where("host_id = ?", duplicateID).
+			Update("host_id", hostID)
+		if result.Error != nil {
+			return errors.WrapIff(result.Error, "更新数据表%s失败", agentTable)
+		}
+		counts[agentTable] = result.RowsAffected
+		return nil
+	})
+	if err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"合并主机引用失败",
+			zap.Error(err),
+			zap.Uint32("duplicate_id", duplicateID),
+			zap.Uint32("host_id", hostID),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return nil, errors.WrapIf(err, "合并主机引用失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"合并主机引用成功",
+		zap.Uint32("duplicate_id", duplicateID),
+		zap.Uint32("host_id", hostID),
+		zap.Any("references", counts),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return counts, nil
+}
+
 // NewSSHClient 创建SSH客户端连接
 //
 // 参数：
@@ -326,7 +398,7 @@ func (r *HostRepo) ListModel(
 //
 // 功能：
 //  1. 检查上下文是否有效
-//  2. 创建SSH客户端连接
+//  2. 通过主机地址的熔断器创建SSH客户端连接，熔断期间返回breaker.ErrOpen
 //  3. 记录操作日志
 func (r *HostRepo) NewSSHClient(
 	ctx context.Context,
@@ -339,32 +411,36 @@ func (r *HostRepo) NewSSHClient(
 	if ctx.Err() != nil {
 		return nil, errors.WrapIf(ctx.Err(), "上下文已取消")
 	}
-	r.log.Debug(
+	defer deadline.Enter(ctx, deadline.StageSSH)()
+	ctxutil.Logger(ctx, r.log).Debug(
 		"开始创建ssh连接",
 		zap.String("ssh_ip", sshIP),
 		zap.Uint16("ssh_port", sshPort),
 		zap.String("ssh_user", sshUser),
 	)
 	now := time.Now()
-	client, err := shell.NewSSHClient(ctx, sshIP, sshPort, sshUser, sshAuths, false, timeout)
+	var client *ssh.Client
+	addr := net.JoinHostPort(sshIP, strconv.FormatUint(uint64(cmp.Or(sshPort, 22)), 10))
+	err := r.breakers.Get(addr).Execute(func() (err error) {
+		client, err = shell.NewSSHClient(ctx, sshIP, sshPort, sshUser, sshAuths, false, timeout)
+		return err
+	})
 	if err != nil {
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"创建ssh连接失败",
 			zap.Error(err),
 			zap.String("ssh_ip", sshIP),
 			zap.Uint16("ssh_port", sshPort),
 			zap.String("ssh_user", sshUser),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			zap.Duration(log.DurationKey, time.Since(now)),
 		)
 		return nil, errors.WrapIf(err, "创建ssh连接失败")
 	}
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"创建ssh连接成功",
 		zap.String("ssh_ip", sshIP),
 		zap.Uint16("ssh_port", sshPort),
 		zap.String("ssh_user", sshUser),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		zap.Duration(log.DurationKey, time.Since(now)),
 	)
 	return client, nil
@@ -394,29 +470,27 @@ func (r *HostRepo) ExecuteCommand(
 	if ctx.Err() != nil {
 		return errors.WrapIf(ctx.Err(), "上下文已取消")
 	}
+	defer deadline.Enter(ctx, deadline.StageSSH)()
 
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"开始执行命令",
 		zap.String("command", command),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	now := time.Now()
 	if err := session.Run(command); err != nil {
-		r.log.Error(
+		ctxutil.Logger(ctx, r.log).Error(
 			"执行命令失败",
 			zap.Error(err),
 			zap.String("command", command),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			zap.Duration(log.DurationKey, time.Since(now)),
 		)
 		return errors.WrapIf(err, "执行命令失败")
 	}
 
-	r.log.Debug(
+	ctxutil.Logger(ctx, r.log).Debug(
 		"执行命令成功",
 		zap.String("command", command),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		zap.Duration(log.DurationKey, time.Since(now)),
 	)
 
@@ -0,0 +1,162 @@
+package resource
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	resomodel "gin-artweb/internal/model/resource"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/log"
+)
+
+// TerminalSessionRepo 网页终端会话仓库实现
+type TerminalSessionRepo struct {
+	log      *zap.Logger       // 日志记录器
+	gormDB   *gorm.DB          // GORM数据库连接
+	timeouts *config.DBTimeout // 数据库操作超时配置
+}
+
+// NewTerminalSessionRepo 创建网页终端会话仓库实例
+func NewTerminalSessionRepo(
+	log *zap.Logger,
+	gormDB *gorm.DB,
+	timeouts *config.DBTimeout,
+) *TerminalSessionRepo {
+	return &TerminalSessionRepo{
+		log:      log,
+		gormDB:   gormDB,
+		timeouts: timeouts,
+	}
+}
+
+func (r *TerminalSessionRepo) CreateModel(ctx context.Context, m *resomodel.TerminalSessionModel) error {
+	// 检查参数
+	if m == nil {
+		err := errors.New("创建终端会话失败: 模型为空")
+		ctxutil.Logger(ctx, r.log).Error(
+			"创建终端会话失败: 模型为空",
+			zap.Error(err),
+		)
+		return err
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始创建终端会话",
+		zap.Object(database.ModelKey, m),
+	)
+	now := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBCreate(dbCtx, r.gormDB, &resomodel.TerminalSessionModel{}, m, nil); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"创建终端会话失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, m),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return errors.WrapIf(err, "创建终端会话失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"创建终端会话成功",
+		zap.Object(database.ModelKey, m),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return nil
+}
+
+func (r *TerminalSessionRepo) UpdateModel(ctx context.Context, data map[string]any, conds ...any) error {
+	if len(data) == 0 {
+		err := errors.New("更新终端会话失败: 更新数据为空")
+		ctxutil.Logger(ctx, r.log).Error(
+			"更新终端会话失败: 更新数据为空",
+			zap.Error(err),
+		)
+		return err
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始更新终端会话",
+		zap.Any(database.UpdateDataKey, data),
+		zap.Any(database.ConditionsKey, conds),
+	)
+	now := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBUpdate(dbCtx, r.gormDB, &resomodel.TerminalSessionModel{}, data, nil, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"更新终端会话失败",
+			zap.Error(err),
+			zap.Any(database.UpdateDataKey, data),
+			zap.Any(database.ConditionsKey, conds),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return errors.WrapIf(err, "更新终端会话失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"更新终端会话成功",
+		zap.Any(database.UpdateDataKey, data),
+		zap.Any(database.ConditionsKey, conds),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return nil
+}
+
+func (r *TerminalSessionRepo) GetModel(ctx context.Context, conds ...any) (*resomodel.TerminalSessionModel, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始查询终端会话",
+		zap.Any(database.ConditionsKey, conds),
+	)
+	now := time.Now()
+	var m resomodel.TerminalSessionModel
+	dbCtx, cancel := database.ReadContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBGet(dbCtx, r.gormDB, nil, &m, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"查询终端会话失败",
+			zap.Error(err),
+			zap.Any(database.ConditionsKey, conds),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return nil, errors.WrapIf(err, "查询终端会话失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"查询终端会话成功",
+		zap.Object(database.ModelKey, &m),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return &m, nil
+}
+
+func (r *TerminalSessionRepo) ListModel(
+	ctx context.Context,
+	qp database.QueryParams,
+) (int64, *[]resomodel.TerminalSessionModel, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始查询终端会话列表",
+		zap.Object(database.QueryParamsKey, &qp),
+	)
+	now := time.Now()
+	var ms []resomodel.TerminalSessionModel
+	dbCtx, cancel := database.ListContext(database.WithQueryTimeout(ctx, qp.Timeout), r.timeouts)
+	defer cancel()
+	count, err := database.DBList(dbCtx, r.gormDB, &resomodel.TerminalSessionModel{}, &ms, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"查询终端会话列表失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return 0, nil, errors.WrapIf(err, "查询终端会话列表失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"查询终端会话列表成功",
+		zap.Object(database.QueryParamsKey, &qp),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return count, &ms, nil
+}
@@ -0,0 +1,308 @@
+package resource
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	resomodel "gin-artweb/internal/model/resource"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/log"
+)
+
+// WatchdogRepo 进程守护仓库实现
+type WatchdogRepo struct {
+	log      *zap.Logger       // 日志记录器
+	gormDB   *gorm.DB          // GORM数据库连接
+	timeouts *config.DBTimeout // 数据库操作超时配置
+}
+
+// NewWatchdogRepo 创建进程守护仓库实例
+func NewWatchdogRepo(
+	log *zap.Logger,
+	gormDB *gorm.DB,
+	timeouts *config.DBTimeout,
+) *WatchdogRepo {
+	return &WatchdogRepo{
+		log:      log,
+		gormDB:   gormDB,
+		timeouts: timeouts,
+	}
+}
+
+func (r *WatchdogRepo) CreateModel(ctx context.Context, m *resomodel.WatchdogModel) error {
+	// 检查参数
+	if m == nil {
+		err := errors.New("创建进程守护失败: 模型为空")
+		ctxutil.Logger(ctx, r.log).Error(
+			"创建进程守护失败: 模型为空",
+			zap.Error(err),
+		)
+		return err
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始创建进程守护",
+		zap.Object(database.ModelKey, m),
+	)
+	now := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBCreate(dbCtx, r.gormDB, &resomodel.WatchdogModel{}, m, nil); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"创建进程守护失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, m),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return errors.WrapIf(err, "创建进程守护失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"创建进程守护成功",
+		zap.Object(database.ModelKey, m),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return nil
+}
+
+func (r *WatchdogRepo) UpdateModel(ctx context.Context, data map[string]any, conds ...any) error {
+	if len(data) == 0 {
+		err := errors.New("更新进程守护失败: 更新数据为空")
+		ctxutil.Logger(ctx, r.log).Error(
+			"更新进程守护失败: 更新数据为空",
+			zap.Error(err),
+		)
+		return err
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始更新进程守护",
+		zap.Any(database.UpdateDataKey, data),
+		zap.Any(database.ConditionsKey, conds),
+	)
+	now := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBUpdate(dbCtx, r.gormDB, &resomodel.WatchdogModel{}, data, nil, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"更新进程守护失败",
+			zap.Error(err),
+			zap.Any(database.UpdateDataKey, data),
+			zap.Any(database.ConditionsKey, conds),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return errors.WrapIf(err, "更新进程守护失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"更新进程守护成功",
+		zap.Any(database.UpdateDataKey, data),
+		zap.Any(database.ConditionsKey, conds),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return nil
+}
+
+func (r *WatchdogRepo) GetModel(
+	ctx context.Context,
+	preloads []string,
+	conds ...any,
+) (*resomodel.WatchdogModel, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始查询进程守护",
+		zap.Any(database.ConditionsKey, conds),
+	)
+	now := time.Now()
+	var m resomodel.WatchdogModel
+	dbCtx, cancel := database.ReadContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBGet(dbCtx, r.gormDB, preloads, &m, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"查询进程守护失败",
+			zap.Error(err),
+			zap.Any(database.ConditionsKey, conds),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return nil, errors.WrapIf(err, "查询进程守护失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"查询进程守护成功",
+		zap.Object(database.ModelKey, &m),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return &m, nil
+}
+
+func (r *WatchdogRepo) ListModel(
+	ctx context.Context,
+	qp database.QueryParams,
+) (int64, *[]resomodel.WatchdogModel, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始查询进程守护列表",
+		zap.Object(database.QueryParamsKey, &qp),
+	)
+	now := time.Now()
+	var ms []resomodel.WatchdogModel
+	dbCtx, cancel := database.ListContext(database.WithQueryTimeout(ctx, qp.Timeout), r.timeouts)
+	defer cancel()
+	count, err := database.DBList(dbCtx, r.gormDB, &resomodel.WatchdogModel{}, &ms, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"查询进程守护列表失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return 0, nil, errors.WrapIf(err, "查询进程守护列表失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"查询进程守护列表成功",
+		zap.Object(database.QueryParamsKey, &qp),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return count, &ms, nil
+}
+
+func (r *WatchdogRepo) DeleteModel(ctx context.Context, conds ...any) error {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始删除进程守护",
+		zap.Any(database.ConditionsKey, conds),
+	)
+	now := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBDelete(dbCtx, r.gormDB, &resomodel.WatchdogModel{}, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"删除进程守护失败",
+			zap.Error(err),
+			zap.Any(database.ConditionsKey, conds),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return errors.WrapIf(err, "删除进程守护失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"删除进程守护成功",
+		zap.Any(database.ConditionsKey, conds),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return nil
+}
+
+// WatchdogEventRepo 进程守护历史仓库实现
+type WatchdogEventRepo struct {
+	log      *zap.Logger       // 日志记录器
+	gormDB   *gorm.DB          // GORM数据库连接
+	timeouts *config.DBTimeout // 数据库操作超时配置
+}
+
+// NewWatchdogEventRepo 创建进程守护历史仓库实例
+func NewWatchdogEventRepo(
+	log *zap.Logger,
+	gormDB *gorm.DB,
+	timeouts *config.DBTimeout,
+) *WatchdogEventRepo {
+	return &WatchdogEventRepo{
+		log:      log,
+		gormDB:   gormDB,
+		timeouts: timeouts,
+	}
+}
+
+func (r *WatchdogEventRepo) CreateModel(ctx context.Context, m *resomodel.WatchdogEventModel) error {
+	// 检查参数
+	if m == nil {
+		err := errors.New("创建进程守护历史失败: 模型为空")
+		ctxutil.Logger(ctx, r.log).Error(
+			"创建进程守护历史失败: 模型为空",
+			zap.Error(err),
+		)
+		return err
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始创建进程守护历史",
+		zap.Object(database.ModelKey, m),
+	)
+	now := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBCreate(dbCtx, r.gormDB, &resomodel.WatchdogEventModel{}, m, nil); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"创建进程守护历史失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, m),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return errors.WrapIf(err, "创建进程守护历史失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"创建进程守护历史成功",
+		zap.Object(database.ModelKey, m),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return nil
+}
+
+// CountRestarts 统计进程守护自since以来尝试重启的次数, 重启失败也计入
+func (r *WatchdogEventRepo) CountRestarts(ctx context.Context, watchdogID uint32, since time.Time) (int64, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始统计进程守护重启次数",
+		zap.Uint32("watchdog_id", watchdogID),
+		zap.Time("since", since),
+	)
+	now := time.Now()
+	var count int64
+	dbCtx, cancel := database.ReadContext(ctx, r.timeouts)
+	defer cancel()
+	err := database.Conn(dbCtx, r.gormDB).Model(&resomodel.WatchdogEventModel{}).
+		Where("watchdog_id = ? AND action IN ? AND created_at >= ?", watchdogID,
+			[]string{resomodel.WatchdogActionRestart, resomodel.WatchdogActionRestartFailed}, since).
+		Count(&count).Error
+	if err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"统计进程守护重启次数失败",
+			zap.Error(err),
+			zap.Uint32("watchdog_id", watchdogID),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return 0, errors.WrapIf(err, "统计进程守护重启次数失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"统计进程守护重启次数成功",
+		zap.Uint32("watchdog_id", watchdogID),
+		zap.Int64("count", count),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return count, nil
+}
+
+func (r *WatchdogEventRepo) ListModel(
+	ctx context.Context,
+	qp database.QueryParams,
+) (int64, *[]resomodel.WatchdogEventModel, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始查询进程守护历史列表",
+		zap.Object(database.QueryParamsKey, &qp),
+	)
+	now := time.Now()
+	var ms []resomodel.WatchdogEventModel
+	dbCtx, cancel := database.ListContext(database.WithQueryTimeout(ctx, qp.Timeout), r.timeouts)
+	defer cancel()
+	count, err := database.DBList(dbCtx, r.gormDB, &resomodel.WatchdogEventModel{}, &ms, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"查询进程守护历史列表失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return 0, nil, errors.WrapIf(err, "查询进程守护历史列表失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"查询进程守护历史列表成功",
+		zap.Object(database.QueryParamsKey, &qp),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return count, &ms, nil
+}
@@ -0,0 +1,127 @@
+package resource
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	resomodel "gin-artweb/internal/model/resource"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/test"
+)
+
+type WatchdogTestSuite struct {
+	suite.Suite
+	hostRepo     *HostRepo
+	watchdogRepo *WatchdogRepo
+	eventRepo    *WatchdogEventRepo
+}
+
+func (suite *WatchdogTestSuite) SetupTest() {
+	db := test.NewTestGormDBWithConfig(nil)
+	db.AutoMigrate(&resomodel.HostModel{}, &resomodel.WatchdogModel{}, &resomodel.WatchdogEventModel{})
+	dbTimeout := test.NewTestDBTimeouts()
+	logger := test.NewTestZapLogger()
+	suite.hostRepo = NewHostRepo(logger, db, dbTimeout, nil)
+	suite.watchdogRepo = NewWatchdogRepo(logger, db, dbTimeout)
+	suite.eventRepo = NewWatchdogEventRepo(logger, db, dbTimeout)
+}
+
+func (suite *WatchdogTestSuite) createWatchdog() *resomodel.WatchdogModel {
+	hm := CreateTestHostModel()
+	suite.Require().NoError(suite.hostRepo.CreateModel(context.Background(), hm))
+	m := &resomodel.WatchdogModel{
+		Name:          "nginx-" + uuid.NewString()[:8],
+		HostID:        hm.ID,
+		Kind:          resomodel.WatchdogKindSystemd,
+		Target:        "nginx.service",
+		ExpectedState: resomodel.WatchdogExpectRunning,
+		RestartPolicy: resomodel.WatchdogRestartOnFailure,
+		MaxRestarts:   3,
+		RestartWindow: 60,
+		IsEnabled:     true,
+		Status:        resomodel.WatchdogUnknown,
+	}
+	suite.Require().NoError(suite.watchdogRepo.CreateModel(context.Background(), m))
+	return m
+}
+
+func (suite *WatchdogTestSuite) TestCRUD() {
+	m := suite.createWatchdog()
+
+	fm, err := suite.watchdogRepo.GetModel(context.Background(), []string{"Host"}, m.ID)
+	suite.NoError(err, "查询刚创建的进程守护应该成功")
+	suite.Equal(m.Target, fm.Target)
+	suite.Equal(m.HostID, fm.Host.ID)
+
+	err = suite.watchdogRepo.UpdateModel(context.Background(),
+		map[string]any{"status": resomodel.WatchdogFailed}, "id = ?", m.ID)
+	suite.NoError(err)
+	total, ms, err := suite.watchdogRepo.ListModel(context.Background(), database.QueryParams{
+		IsCount: true,
+		Query:   map[string]any{"status = ?": resomodel.WatchdogFailed},
+	})
+	suite.NoError(err)
+	suite.Equal(int64(1), total)
+	suite.Len(*ms, 1)
+
+	suite.NoError(suite.watchdogRepo.DeleteModel(context.Background(), m.ID))
+	_, err = suite.watchdogRepo.GetModel(context.Background(), nil, m.ID)
+	suite.Error(err, "删除后查询进程守护应该失败")
+}
+
+func (suite *WatchdogTestSuite) TestCountRestarts() {
+	m := suite.createWatchdog()
+	for _, action := range []string{
+		resomodel.WatchdogActionStateChange,
+		resomodel.WatchdogActionRestart,
+		resomodel.WatchdogActionRestartFailed,
+		resomodel.WatchdogActionRestartLimited,
+	} {
+		err := suite.eventRepo.CreateModel(context.Background(), &resomodel.WatchdogEventModel{
+			WatchdogID: m.ID, Status: resomodel.WatchdogFailed, Action: action,
+		})
+		suite.NoError(err)
+	}
+
+	count, err := suite.eventRepo.CountRestarts(context.Background(), m.ID, time.Now().Add(-time.Minute))
+	suite.NoError(err)
+	suite.Equal(int64(2), count, "只统计重启和重启失败记录")
+
+	count, err = suite.eventRepo.CountRestarts(context.Background(), m.ID, time.Now().Add(time.Minute))
+	suite.NoError(err)
+	suite.Zero(count, "重启窗口之前的记录不应该统计")
+}
+
+func (suite *WatchdogTestSuite) TestValidate() {
+	m := resomodel.WatchdogModel{
+		Kind:          resomodel.WatchdogKindSystemd,
+		Target:        "nginx.service",
+		ExpectedState: resomodel.WatchdogExpectRunning,
+		RestartPolicy: resomodel.WatchdogRestartOnFailure,
+	}
+	suite.NoError(m.Validate())
+	suite.Equal("systemctl is-active --quiet 'nginx.service'", m.CheckCommand())
+	suite.Equal("systemctl restart 'nginx.service'", m.StartCommand())
+
+	m.Target = "nginx; rm -rf /"
+	suite.Error(m.Validate(), "目标名称不能包含shell字符")
+
+	m.Kind = resomodel.WatchdogKindProcess
+	m.Target = "redis-server"
+	suite.Error(m.Validate(), "进程类型自动重启需要重启命令")
+	m.RestartCommand = "/opt/redis/start.sh"
+	suite.NoError(m.Validate())
+	suite.Equal("pgrep -x 'redis-server' >/dev/null", m.CheckCommand())
+	suite.Equal("/opt/redis/start.sh", m.StartCommand())
+
+	m.ExpectedState = resomodel.WatchdogExpectStopped
+	suite.Error(m.Validate(), "期望停止的进程不能自动重启")
+}
+
+func TestWatchdogTestSuite(t *testing.T) {
+	suite.Run(t, new(WatchdogTestSuite))
+}
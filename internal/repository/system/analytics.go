@@ -0,0 +1,142 @@
+package system
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	sysmodel "gin-artweb/internal/model/system"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/log"
+)
+
+type AnalyticsEventRepo struct {
+	log      *zap.Logger
+	gormDB   *gorm.DB
+	timeouts *config.DBTimeout
+}
+
+func NewAnalyticsEventRepo(
+	log *zap.Logger,
+	gormDB *gorm.DB,
+	timeouts *config.DBTimeout,
+) *AnalyticsEventRepo {
+	return &AnalyticsEventRepo{
+		log:      log,
+		gormDB:   gormDB,
+		timeouts: timeouts,
+	}
+}
+
+func (r *AnalyticsEventRepo) CreateModels(ctx context.Context, ms []sysmodel.AnalyticsEventModel) error {
+	// 检查参数
+	if len(ms) == 0 {
+		return nil
+	}
+
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始批量创建统计事件模型",
+		zap.Int("count", len(ms)),
+	)
+	startTime := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.Conn(dbCtx, r.gormDB).CreateInBatches(&ms, 100).Error; err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"批量创建统计事件模型失败",
+			zap.Error(err),
+			zap.Int("count", len(ms)),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return errors.WrapIf(err, "批量创建统计事件模型失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"批量创建统计事件模型成功",
+		zap.Int("count", len(ms)),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return nil
+}
+
+func (r *AnalyticsEventRepo) DeleteModel(ctx context.Context, conds ...any) error {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始删除统计事件模型",
+		zap.Any(database.ConditionsKey, conds),
+	)
+	startTime := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBDelete(dbCtx, r.gormDB, &sysmodel.AnalyticsEventModel{}, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"删除统计事件模型失败",
+			zap.Error(err),
+			zap.Any(database.ConditionsKey, conds),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return errors.WrapIf(err, "删除统计事件模型失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"删除统计事件模型成功",
+		zap.Any(database.ConditionsKey, conds),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return nil
+}
+
+// AggregateModel 按指定字段分组统计事件数量
+func (r *AnalyticsEventRepo) AggregateModel(
+	ctx context.Context,
+	groupBy []string,
+	query map[string]any,
+	limit int,
+) (*[]sysmodel.AnalyticsUsage, error) {
+	if len(groupBy) == 0 {
+		return nil, errors.New("聚合统计事件失败: 分组字段为空")
+	}
+
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始聚合统计事件",
+		zap.Strings("group_by", groupBy),
+		zap.Any(database.ConditionsKey, query),
+	)
+	startTime := time.Now()
+	dbCtx, cancel := database.ListContext(ctx, r.timeouts)
+	defer cancel()
+
+	columns := strings.Join(groupBy, ", ")
+	mdb := database.Conn(dbCtx, r.gormDB).
+		Model(&sysmodel.AnalyticsEventModel{}).
+		Select(columns + ", COUNT(*) AS count")
+	for k, v := range query {
+		mdb = mdb.Where(k, v)
+	}
+	mdb = mdb.Group(columns).Order("count DESC")
+	if limit > 0 {
+		mdb = mdb.Limit(limit)
+	}
+
+	var us []sysmodel.AnalyticsUsage
+	if err := mdb.Scan(&us).Error; err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"聚合统计事件失败",
+			zap.Error(err),
+			zap.Strings("group_by", groupBy),
+			zap.Any(database.ConditionsKey, query),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return nil, errors.WrapIf(err, "聚合统计事件失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"聚合统计事件成功",
+		zap.Strings("group_by", groupBy),
+		zap.Any(database.ConditionsKey, query),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return &us, nil
+}
@@ -0,0 +1,109 @@
+package system
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	sysmodel "gin-artweb/internal/model/system"
+	"gin-artweb/internal/shared/test"
+)
+
+func CreateTestAnalyticsEventModel(feature, module string, roleID uint32, at time.Time) sysmodel.AnalyticsEventModel {
+	return sysmodel.AnalyticsEventModel{
+		Feature:    feature,
+		Method:     "GET",
+		Module:     module,
+		RoleID:     roleID,
+		StatusCode: 200,
+		OccurredAt: at,
+	}
+}
+
+type AnalyticsEventTestSuite struct {
+	suite.Suite
+	eventRepo *AnalyticsEventRepo
+}
+
+func (suite *AnalyticsEventTestSuite) SetupTest() {
+	db := test.NewTestGormDBWithConfig(nil)
+	db.AutoMigrate(&sysmodel.AnalyticsEventModel{})
+	dbTimeout := test.NewTestDBTimeouts()
+	logger := test.NewTestZapLogger()
+	suite.eventRepo = &AnalyticsEventRepo{
+		log:      logger,
+		gormDB:   db,
+		timeouts: dbTimeout,
+	}
+}
+
+func (suite *AnalyticsEventTestSuite) TestCreateModels() {
+	now := time.Now()
+	ms := []sysmodel.AnalyticsEventModel{
+		CreateTestAnalyticsEventModel("/api/v1/mon/node", "mon", 1, now),
+		CreateTestAnalyticsEventModel("/api/v1/mon/node", "mon", 2, now),
+	}
+	err := suite.eventRepo.CreateModels(context.Background(), ms)
+	suite.NoError(err, "批量创建统计事件应该成功")
+
+	// 测试边界情况：空列表
+	err = suite.eventRepo.CreateModels(context.Background(), nil)
+	suite.NoError(err, "创建空列表应该直接返回")
+}
+
+func (suite *AnalyticsEventTestSuite) TestAggregateModel() {
+	now := time.Now()
+	ms := []sysmodel.AnalyticsEventModel{
+		CreateTestAnalyticsEventModel("/api/v1/mon/node", "mon", 1, now),
+		CreateTestAnalyticsEventModel("/api/v1/mon/node", "mon", 2, now),
+		CreateTestAnalyticsEventModel("/api/v1/mon/node", "mon", 2, now),
+		CreateTestAnalyticsEventModel("/api/v1/oes/colony", "oes", 1, now),
+	}
+	suite.NoError(suite.eventRepo.CreateModels(context.Background(), ms))
+
+	// 按功能聚合
+	us, err := suite.eventRepo.AggregateModel(context.Background(), []string{"feature", "method", "module"}, nil, 0)
+	suite.NoError(err, "按功能聚合应该成功")
+	suite.Len(*us, 2, "应该有2个功能")
+	suite.Equal("/api/v1/mon/node", (*us)[0].Feature, "使用最多的功能应该排在最前")
+	suite.Equal(int64(3), (*us)[0].Count)
+
+	// 按角色聚合并过滤模块
+	us, err = suite.eventRepo.AggregateModel(
+		context.Background(),
+		[]string{"role_id"},
+		map[string]any{"module = ?": "mon"},
+		0,
+	)
+	suite.NoError(err, "按角色聚合应该成功")
+	suite.Len(*us, 2, "mon模块应该有2个角色")
+	suite.Equal(uint32(2), (*us)[0].RoleID)
+	suite.Equal(int64(2), (*us)[0].Count)
+
+	// 测试边界情况：分组字段为空
+	_, err = suite.eventRepo.AggregateModel(context.Background(), nil, nil, 0)
+	suite.Error(err, "分组字段为空应该返回错误")
+}
+
+func (suite *AnalyticsEventTestSuite) TestDeleteModel() {
+	now := time.Now()
+	ms := []sysmodel.AnalyticsEventModel{
+		CreateTestAnalyticsEventModel("/api/v1/mon/node", "mon", 1, now.AddDate(0, 0, -10)),
+		CreateTestAnalyticsEventModel("/api/v1/mon/node", "mon", 1, now),
+	}
+	suite.NoError(suite.eventRepo.CreateModels(context.Background(), ms))
+
+	err := suite.eventRepo.DeleteModel(context.Background(), "occurred_at < ?", now.AddDate(0, 0, -1))
+	suite.NoError(err, "清理过期统计事件应该成功")
+
+	us, err := suite.eventRepo.AggregateModel(context.Background(), []string{"feature"}, nil, 0)
+	suite.NoError(err)
+	suite.Len(*us, 1)
+	suite.Equal(int64(1), (*us)[0].Count, "应该只保留未过期的事件")
+}
+
+func TestAnalyticsEventTestSuite(t *testing.T) {
+	suite.Run(t, new(AnalyticsEventTestSuite))
+}
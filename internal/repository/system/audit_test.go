@@ -0,0 +1,55 @@
+package system
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	sysmodel "gin-artweb/internal/model/system"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/test"
+)
+
+type AuditRecordTestSuite struct {
+	suite.Suite
+	auditRepo *AuditRecordRepo
+}
+
+func (suite *AuditRecordTestSuite) SetupTest() {
+	db := test.NewTestGormDBWithConfig(nil)
+	db.AutoMigrate(&sysmodel.AuditRecordModel{})
+	dbTimeout := test.NewTestDBTimeouts()
+	logger := test.NewTestZapLogger()
+	suite.auditRepo = NewAuditRecordRepo(logger, db, dbTimeout)
+}
+
+func (suite *AuditRecordTestSuite) TestCreateAndListModel() {
+	for i := uint32(1); i <= 3; i++ {
+		m, err := sysmodel.NewAuditRecord(
+			"oes", "colony", i, "batch_update",
+			map[string]any{"is_enable": true},
+			map[string]any{"is_enable": false},
+			"admin", "",
+		)
+		suite.NoError(err, "创建审计记录模型应该成功")
+		suite.NoError(suite.auditRepo.CreateModel(context.Background(), m), "保存审计记录应该成功")
+		suite.NotZero(m.ID)
+	}
+
+	err := suite.auditRepo.CreateModel(context.Background(), nil)
+	suite.Error(err, "保存空审计记录应该返回错误")
+
+	count, ms, err := suite.auditRepo.ListModel(context.Background(), database.QueryParams{
+		IsCount: true,
+		Query:   map[string]any{"resource_id = ?": 2},
+	})
+	suite.NoError(err, "查询审计记录应该成功")
+	suite.Equal(int64(1), count)
+	suite.Equal(`{"is_enable":true}`, (*ms)[0].Before)
+	suite.Equal(`{"is_enable":false}`, (*ms)[0].After)
+}
+
+func TestAuditRecordTestSuite(t *testing.T) {
+	suite.Run(t, new(AuditRecordTestSuite))
+}
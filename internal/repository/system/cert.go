@@ -0,0 +1,189 @@
+package system
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	sysmodel "gin-artweb/internal/model/system"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/log"
+)
+
+type CertMonitorRepo struct {
+	log      *zap.Logger
+	gormDB   *gorm.DB
+	timeouts *config.DBTimeout
+}
+
+func NewCertMonitorRepo(
+	log *zap.Logger,
+	gormDB *gorm.DB,
+	timeouts *config.DBTimeout,
+) *CertMonitorRepo {
+	return &CertMonitorRepo{
+		log:      log,
+		gormDB:   gormDB,
+		timeouts: timeouts,
+	}
+}
+
+func (r *CertMonitorRepo) CreateModel(ctx context.Context, m *sysmodel.CertMonitorModel) error {
+	// 检查参数
+	if m == nil {
+		err := errors.New("创建证书监控失败: 模型为空")
+		ctxutil.Logger(ctx, r.log).Error(
+			"创建证书监控失败: 模型为空",
+			zap.Error(err),
+		)
+		return err
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始创建证书监控",
+		zap.Object(database.ModelKey, m),
+	)
+	now := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBCreate(dbCtx, r.gormDB, &sysmodel.CertMonitorModel{}, m, nil); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"创建证书监控失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, m),
+			zap.Duration(log.DurationKey, time.Since(now)),
+		)
+		return errors.WrapIf(err, "创建证书监控失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"创建证书监控成功",
+		zap.Object(database.ModelKey, m),
+		zap.Duration(log.DurationKey, time.Since(now)),
+	)
+	return nil
+}
+
+func (r *CertMonitorRepo) UpdateModel(ctx context.Context, data map[string]any, conds ...any) error {
+	// 检查参数
+	if len(data) == 0 {
+		err := errors.New("更新证书监控失败: 更新数据为空")
+		ctxutil.Logger(ctx, r.log).Error(
+			"更新证书监控失败: 更新数据为空",
+			zap.Error(err),
+			zap.Any(database.ConditionsKey, conds),
+		)
+		return err
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始更新证书监控",
+		zap.Any(database.UpdateDataKey, data),
+		zap.Any(database.ConditionsKey, conds),
+	)
+	startTime := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBUpdate(dbCtx, r.gormDB, &sysmodel.CertMonitorModel{}, data, nil, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"更新证书监控失败",
+			zap.Error(err),
+			zap.Any(database.UpdateDataKey, data),
+			zap.Any(database.ConditionsKey, conds),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return errors.WrapIf(err, "更新证书监控失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"更新证书监控成功",
+		zap.Any(database.ConditionsKey, conds),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return nil
+}
+
+func (r *CertMonitorRepo) DeleteModel(ctx context.Context, conds ...any) error {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始删除证书监控",
+		zap.Any(database.ConditionsKey, conds),
+	)
+	startTime := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBDelete(dbCtx, r.gormDB, &sysmodel.CertMonitorModel{}, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"删除证书监控失败",
+			zap.Error(err),
+			zap.Any(database.ConditionsKey, conds),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return errors.WrapIf(err, "删除证书监控失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"删除证书监控成功",
+		zap.Any(database.ConditionsKey, conds),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return nil
+}
+
+func (r *CertMonitorRepo) GetModel(
+	ctx context.Context,
+	conds ...any,
+) (*sysmodel.CertMonitorModel, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始查询证书监控",
+		zap.Any(database.ConditionsKey, conds),
+	)
+	startTime := time.Now()
+	var m sysmodel.CertMonitorModel
+	dbCtx, cancel := database.ReadContext(ctx, r.timeouts)
+	defer cancel()
+	if err := database.DBGet(dbCtx, r.gormDB, nil, &m, conds...); err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"查询证书监控失败",
+			zap.Error(err),
+			zap.Any(database.ConditionsKey, conds),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return nil, errors.WrapIf(err, "查询证书监控失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"查询证书监控成功",
+		zap.Object(database.ModelKey, &m),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return &m, nil
+}
+
+func (r *CertMonitorRepo) ListModel(
+	ctx context.Context,
+	qp database.QueryParams,
+) (int64, *[]sysmodel.CertMonitorModel, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始查询证书监控列表",
+		zap.Object(database.QueryParamsKey, &qp),
+	)
+	startTime := time.Now()
+	var ms []sysmodel.CertMonitorModel
+	dbCtx, cancel := database.ListContext(database.WithQueryTimeout(ctx, qp.Timeout), r.timeouts)
+	defer cancel()
+	count, err := database.DBList(dbCtx, r.gormDB, &sysmodel.CertMonitorModel{}, &ms, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"查询证书监控列表失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return 0, nil, errors.WrapIf(err, "查询证书监控列表失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"查询证书监控列表成功",
+		zap.Object(database.QueryParamsKey, &qp),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return count, &ms, nil
+}
@@ -0,0 +1,117 @@
+package system
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	sysmodel "gin-artweb/internal/model/system"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/test"
+)
+
+func CreateTestCertMonitorModel() *sysmodel.CertMonitorModel {
+	return &sysmodel.CertMonitorModel{
+		Name:      uuid.NewString(),
+		Kind:      sysmodel.CertKindEndpoint,
+		Address:   "example.com:443",
+		IsEnabled: true,
+		Status:    sysmodel.CertUnknown,
+	}
+}
+
+type CertMonitorTestSuite struct {
+	suite.Suite
+	certRepo *CertMonitorRepo
+}
+
+func (suite *CertMonitorTestSuite) SetupTest() {
+	db := test.NewTestGormDBWithConfig(nil)
+	db.AutoMigrate(&sysmodel.CertMonitorModel{})
+	dbTimeout := test.NewTestDBTimeouts()
+	logger := test.NewTestZapLogger()
+	suite.certRepo = NewCertMonitorRepo(logger, db, dbTimeout)
+}
+
+func (suite *CertMonitorTestSuite) TestCRUD() {
+	m := CreateTestCertMonitorModel()
+	suite.Require().NoError(suite.certRepo.CreateModel(context.Background(), m))
+
+	dup := CreateTestCertMonitorModel()
+	dup.Name = m.Name
+	suite.Error(suite.certRepo.CreateModel(context.Background(), dup), "名称重复应该创建失败")
+
+	notAfter := time.Now().AddDate(0, 0, 10)
+	err := suite.certRepo.UpdateModel(context.Background(), map[string]any{
+		"status":    sysmodel.CertExpiring,
+		"not_after": notAfter,
+	}, "id = ?", m.ID)
+	suite.NoError(err)
+
+	fm, err := suite.certRepo.GetModel(context.Background(), nil, m.ID)
+	suite.NoError(err, "查询刚更新的证书监控应该成功")
+	suite.Equal(sysmodel.CertExpiring, fm.Status)
+	suite.Require().NotNil(fm.NotAfter)
+
+	req := sysmodel.ListCertMonitorRequest{ExpireWithinDays: new(int)}
+	*req.ExpireWithinDays = 30
+	_, _, query := req.Query()
+	total, ms, err := suite.certRepo.ListModel(context.Background(), database.QueryParams{
+		IsCount: true,
+		Query:   query,
+	})
+	suite.NoError(err)
+	suite.Equal(int64(1), total)
+	suite.Len(*ms, 1)
+
+	*req.ExpireWithinDays = 5
+	_, _, query = req.Query()
+	total, _, err = suite.certRepo.ListModel(context.Background(), database.QueryParams{
+		IsCount: true,
+		Query:   query,
+	})
+	suite.NoError(err)
+	suite.Zero(total, "剩余天数超过查询天数的证书不应该返回")
+
+	suite.NoError(suite.certRepo.DeleteModel(context.Background(), m.ID))
+	_, err = suite.certRepo.GetModel(context.Background(), nil, m.ID)
+	suite.Error(err, "删除后查询证书监控应该失败")
+}
+
+func (suite *CertMonitorTestSuite) TestDaysRemaining() {
+	now := time.Date(2025, 1, 22, 12, 0, 0, 0, time.Local)
+	m := CreateTestCertMonitorModel()
+	suite.Nil(m.DaysRemaining(now), "未读取到证书时剩余天数应该为空")
+
+	for _, tc := range []struct {
+		notAfter time.Time
+		days     int
+	}{
+		{now.AddDate(0, 0, 30), 30},
+		{now.Add(36 * time.Hour), 1},
+		{now.Add(time.Hour), 0},
+		{now.Add(-time.Hour), -1},
+	} {
+		m.NotAfter = &tc.notAfter
+		days := m.DaysRemaining(now)
+		suite.Require().NotNil(days)
+		suite.Equal(tc.days, *days, tc.notAfter.String())
+	}
+}
+
+func (suite *CertMonitorTestSuite) TestCertAlertThreshold() {
+	thresholds := []int{30, 14, 3}
+	suite.Equal(0, sysmodel.CertAlertThreshold(31, thresholds))
+	suite.Equal(30, sysmodel.CertAlertThreshold(30, thresholds))
+	suite.Equal(14, sysmodel.CertAlertThreshold(10, thresholds))
+	suite.Equal(3, sysmodel.CertAlertThreshold(0, thresholds))
+	suite.Equal(-1, sysmodel.CertAlertThreshold(-1, thresholds), "已过期时应该返回-1")
+	suite.Equal(0, sysmodel.CertAlertThreshold(10, nil), "未配置阈值时不应该告警")
+}
+
+func TestCertMonitorTestSuite(t *testing.T) {
+	suite.Run(t, new(CertMonitorTestSuite))
+}
@@ -0,0 +1,247 @@
+package system
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/log"
+)
+
+// modelReorderer GORM迁移器按外键依赖排序模型的方法, 各数据库的迁移器都内嵌了gorm.io/gorm/migrator.Migrator
+type modelReorderer interface {
+	ReorderModels(values []any, autoAdd bool) []any
+}
+
+// DumpRepo 数据备份仓库实现
+//
+// 按表名整表导出和导入数据, 表名来自模型定义或数据库, 不能直接使用外部输入;
+// 导出和导入的耗时与数据量有关, 不使用数据库超时配置, 由调用方的上下文控制
+type DumpRepo struct {
+	log      *zap.Logger       // 日志记录器
+	gormDB   *gorm.DB          // GORM数据库连接
+	timeouts *config.DBTimeout // 数据库操作超时配置
+}
+
+// NewDumpRepo 创建数据备份仓库实例
+func NewDumpRepo(
+	log *zap.Logger,
+	gormDB *gorm.DB,
+	timeouts *config.DBTimeout,
+) *DumpRepo {
+	return &DumpRepo{
+		log:      log,
+		gormDB:   gormDB,
+		timeouts: timeouts,
+	}
+}
+
+// Dialect 数据库类型
+func (r *DumpRepo) Dialect() string {
+	return r.gormDB.Dialector.Name()
+}
+
+// Tables 返回需要备份的表, 被引用的表排在前面, 恢复时按此顺序导入
+//
+// 先是按外键依赖排序的模型表, 然后是多对多关联表, 最后是数据库中不属于任何模型的表,
+// 不包含迁移记录表和数据库的内部表, 迁移记录恢复时由迁移重新生成
+func (r *DumpRepo) Tables(ctx context.Context, models []any) ([]string, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始查询备份的表",
+		zap.Int("models", len(models)),
+	)
+	startTime := time.Now()
+	db := database.Conn(ctx, r.gormDB)
+	migrator := db.Migrator()
+
+	existing, err := migrator.GetTables()
+	if err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"查询数据库中的表失败",
+			zap.Error(err),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return nil, errors.WrapIf(err, "查询数据库中的表失败")
+	}
+
+	if mr, ok := migrator.(modelReorderer); ok {
+		// 同时加入依赖的模型和关联表, 按依赖排序
+		models = mr.ReorderModels(slices.Clone(models), true)
+	}
+	seen := map[string]bool{(&database.SchemaMigrationModel{}).TableName(): true}
+	tables := make([]string, 0, len(existing))
+	add := func(name string) {
+		// SQLite的内部表由数据库维护
+		if !seen[name] && !strings.HasPrefix(name, "sqlite_") && slices.Contains(existing, name) {
+			tables = append(tables, name)
+		}
+		seen[name] = true
+	}
+	var joinTables []string
+	for _, m := range models {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(m); err != nil {
+			return nil, errors.WrapIff(err, "解析模型%T失败", m)
+		}
+		add(stmt.Schema.Table)
+		for _, rel := range stmt.Schema.Relationships.Relations {
+			if rel.JoinTable != nil {
+				joinTables = append(joinTables, rel.JoinTable.Table)
+			}
+		}
+	}
+	for _, name := range joinTables {
+		add(name)
+	}
+	slices.Sort(existing)
+	for _, name := range existing {
+		add(name)
+	}
+
+	ctxutil.Logger(ctx, r.log).Debug(
+		"查询备份的表成功",
+		zap.Strings("tables", tables),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return tables, nil
+}
+
+// Count 查询表中的记录数
+func (r *DumpRepo) Count(ctx context.Context, table string) (int64, error) {
+	dbCtx, cancel := database.ReadContext(ctx, r.timeouts)
+	defer cancel()
+
+	var count int64
+	if err := database.Conn(dbCtx, r.gormDB).Table(table).Count(&count).Error; err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"查询表的记录数失败",
+			zap.Error(err),
+			zap.String("table", table),
+		)
+		return 0, errors.WrapIff(err, "查询表%s的记录数失败", table)
+	}
+	return count, nil
+}
+
+// Dump 逐行读取表中的全部记录, 每batchSize行调用一次fn, 返回导出的记录数
+//
+// 记录保持数据库中的原始值, 加密字段导出的是密文
+func (r *DumpRepo) Dump(
+	ctx context.Context,
+	table string,
+	batchSize int,
+	fn func(rows []map[string]any) error,
+) (int64, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始导出表数据",
+		zap.String("table", table),
+	)
+	startTime := time.Now()
+	db := database.Conn(ctx, r.gormDB)
+	rows, err := db.Table(table).Rows()
+	if err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"导出表数据失败",
+			zap.Error(err),
+			zap.String("table", table),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return 0, errors.WrapIff(err, "导出表%s的数据失败", table)
+	}
+	defer rows.Close()
+
+	var total int64
+	batch := make([]map[string]any, 0, batchSize)
+	for rows.Next() {
+		row := map[string]any{}
+		if err := db.ScanRows(rows, &row); err != nil {
+			return total, errors.WrapIff(err, "读取表%s的数据失败", table)
+		}
+		batch = append(batch, row)
+		if len(batch) >= batchSize {
+			if err := fn(batch); err != nil {
+				return total, err
+			}
+			total += int64(len(batch))
+			batch = make([]map[string]any, 0, batchSize)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return total, errors.WrapIff(err, "读取表%s的数据失败", table)
+	}
+	if len(batch) > 0 {
+		if err := fn(batch); err != nil {
+			return total, err
+		}
+		total += int64(len(batch))
+	}
+
+	ctxutil.Logger(ctx, r.log).Debug(
+		"导出表数据成功",
+		zap.String("table", table),
+		zap.Int64("rows", total),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return total, nil
+}
+
+// Restore 导入一批记录, 保留记录原有的主键
+func (r *DumpRepo) Restore(ctx context.Context, table string, rows []map[string]any) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	startTime := time.Now()
+	if err := database.Conn(dbCtx, r.gormDB).Table(table).Create(&rows).Error; err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"导入表数据失败",
+			zap.Error(err),
+			zap.String("table", table),
+			zap.Int("rows", len(rows)),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return errors.WrapIff(err, "导入表%s的数据失败", table)
+	}
+	return nil
+}
+
+// ResetSequence 导入记录后将id列的自增序列设置为表中的最大ID
+//
+// 只有PostgreSQL和openGauss的序列不会随显式写入的ID推进, 其他数据库不需要处理
+func (r *DumpRepo) ResetSequence(ctx context.Context, table string) error {
+	switch r.Dialect() {
+	case database.DialectPostgres, database.DialectOpenGauss:
+	default:
+		return nil
+	}
+	db := database.Conn(ctx, r.gormDB)
+	if !db.Migrator().HasColumn(table, "id") {
+		return nil
+	}
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	sql := fmt.Sprintf(
+		"SELECT setval(pg_get_serial_sequence('%s', 'id'), COALESCE((SELECT MAX(id) FROM %s), 0) + 1, false) "+
+			"WHERE pg_get_serial_sequence('%s', 'id') IS NOT NULL",
+		table, db.Statement.Quote(table), table,
+	)
+	if err := database.Conn(dbCtx, r.gormDB).Exec(sql).Error; err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"重置自增序列失败",
+			zap.Error(err),
+			zap.String("table", table),
+		)
+		return errors.WrapIff(err, "重置表%s的自增序列失败", table)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package system
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	"gin-artweb/internal/model/customer"
+	sysmodel "gin-artweb/internal/model/system"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/test"
+)
+
+type DumpTestSuite struct {
+	suite.Suite
+	db       *gorm.DB
+	dumpRepo *DumpRepo
+}
+
+func (suite *DumpTestSuite) SetupTest() {
+	suite.db = test.NewTestGormDBWithConfig(nil)
+	suite.db.AutoMigrate(
+		&customer.ApiModel{},
+		&customer.MenuModel{},
+		&customer.ButtonModel{},
+		&sysmodel.CertMonitorModel{},
+		&database.SchemaMigrationModel{},
+	)
+	suite.dumpRepo = NewDumpRepo(test.NewTestZapLogger(), suite.db, test.NewTestDBTimeouts())
+}
+
+func (suite *DumpTestSuite) TestTables() {
+	suite.Require().NoError(suite.db.Exec("CREATE TABLE extra_data (id integer)").Error)
+
+	tables, err := suite.dumpRepo.Tables(context.Background(), []any{
+		&customer.ButtonModel{},
+		&customer.MenuModel{},
+		&customer.ApiModel{},
+		&sysmodel.BackupModel{},
+	})
+	suite.Require().NoError(err)
+	suite.NotContains(tables, "schema_migrations", "迁移记录表不应该备份")
+	suite.NotContains(tables, "system_backup", "数据库中不存在的表不应该备份")
+	suite.Contains(tables, "system_cert_monitor", "不属于模型的表也应该备份")
+	suite.Equal("extra_data", tables[len(tables)-2], "不属于模型的表应该按表名排在最后")
+
+	index := func(name string) int {
+		for i, t := range tables {
+			if t == name {
+				return i
+			}
+		}
+		suite.Failf("缺少表", name)
+		return -1
+	}
+	suite.Less(index("customer_menu"), index("customer_button"), "被引用的表应该排在前面")
+	suite.Less(index("customer_api"), index("customer_button_api"))
+	suite.Less(index("customer_button"), index("customer_button_api"), "关联表应该排在模型表后面")
+}
+
+func (suite *DumpTestSuite) TestDumpAndRestore() {
+	ctx := context.Background()
+	for range 5 {
+		m := CreateTestCertMonitorModel()
+		suite.Require().NoError(suite.db.Create(m).Error)
+	}
+	table := (&sysmodel.CertMonitorModel{}).TableName()
+
+	var batches []int
+	var rows []map[string]any
+	total, err := suite.dumpRepo.Dump(ctx, table, 2, func(batch []map[string]any) error {
+		batches = append(batches, len(batch))
+		rows = append(rows, batch...)
+		return nil
+	})
+	suite.Require().NoError(err)
+	suite.Equal(int64(5), total)
+	suite.Equal([]int{2, 2, 1}, batches)
+	suite.Nil(rows[0]["not_after"], "空字段应该导出为nil")
+
+	suite.Require().NoError(suite.db.Exec("DELETE FROM " + table).Error)
+	count, err := suite.dumpRepo.Count(ctx, table)
+	suite.Require().NoError(err)
+	suite.Zero(count)
+
+	suite.Require().NoError(suite.dumpRepo.Restore(ctx, table, rows))
+	suite.Require().NoError(suite.dumpRepo.ResetSequence(ctx, table))
+	count, err = suite.dumpRepo.Count(ctx, table)
+	suite.Require().NoError(err)
+	suite.Equal(int64(5), count)
+
+	var ms []sysmodel.CertMonitorModel
+	suite.Require().NoError(suite.db.Order("id").Find(&ms).Error)
+	suite.Equal(rows[0]["name"], ms[0].Name, "导入应该保留原有的主键")
+	suite.EqualValues(rows[0]["id"], ms[0].ID)
+
+	m := CreateTestCertMonitorModel()
+	suite.Require().NoError(suite.db.Create(m).Error, "导入后新建记录不应该主键冲突")
+	suite.Greater(m.ID, ms[len(ms)-1].ID)
+}
+
+func TestDumpTestSuite(t *testing.T) {
+	suite.Run(t, new(DumpTestSuite))
+}
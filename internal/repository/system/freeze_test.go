@@ -0,0 +1,94 @@
+package system
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	sysmodel "gin-artweb/internal/model/system"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/test"
+)
+
+func CreateTestChangeFreezeModel(scope, module string) *sysmodel.ChangeFreezeModel {
+	start := time.Date(2025, 1, 22, 0, 0, 0, 0, time.Local)
+	return &sysmodel.ChangeFreezeModel{
+		Name:      uuid.NewString(),
+		Scope:     scope,
+		Module:    module,
+		StartAt:   start,
+		EndAt:     start.Add(24 * time.Hour),
+		IsEnabled: true,
+		Username:  "admin",
+	}
+}
+
+type ChangeFreezeTestSuite struct {
+	suite.Suite
+	freezeRepo *ChangeFreezeRepo
+}
+
+func (suite *ChangeFreezeTestSuite) SetupTest() {
+	db := test.NewTestGormDBWithConfig(nil)
+	db.AutoMigrate(&sysmodel.ChangeFreezeModel{})
+	dbTimeout := test.NewTestDBTimeouts()
+	logger := test.NewTestZapLogger()
+	suite.freezeRepo = NewChangeFreezeRepo(logger, db, dbTimeout)
+}
+
+func (suite *ChangeFreezeTestSuite) TestCreateAndGetModel() {
+	m := CreateTestChangeFreezeModel(sysmodel.ChangeFreezeScopeGlobal, "")
+	suite.NoError(suite.freezeRepo.CreateModel(context.Background(), m), "创建变更冻结应该成功")
+	suite.NotZero(m.ID)
+
+	fm, err := suite.freezeRepo.GetModel(context.Background(), m.ID)
+	suite.NoError(err, "查询变更冻结应该成功")
+	suite.Equal(m.Name, fm.Name)
+	suite.True(fm.EndAt.Equal(m.EndAt))
+
+	suite.Error(suite.freezeRepo.CreateModel(context.Background(), nil), "创建空变更冻结应该返回错误")
+}
+
+func (suite *ChangeFreezeTestSuite) TestListModel() {
+	suite.NoError(suite.freezeRepo.CreateModel(context.Background(), CreateTestChangeFreezeModel(sysmodel.ChangeFreezeScopeGlobal, "")))
+	for i := 0; i < 2; i++ {
+		m := CreateTestChangeFreezeModel(sysmodel.ChangeFreezeScopeModule, "oes")
+		suite.NoError(suite.freezeRepo.CreateModel(context.Background(), m))
+	}
+
+	count, ms, err := suite.freezeRepo.ListModel(context.Background(), database.QueryParams{
+		IsCount: true,
+		Query:   map[string]any{"module = ?": "oes"},
+	})
+	suite.NoError(err, "查询变更冻结列表应该成功")
+	suite.Equal(int64(2), count)
+	suite.Len(*ms, 2)
+}
+
+func (suite *ChangeFreezeTestSuite) TestActiveAt() {
+	m := CreateTestChangeFreezeModel(sysmodel.ChangeFreezeScopeGlobal, "")
+
+	suite.False(m.ActiveAt(m.StartAt.Add(-time.Second)), "开始前不应该生效")
+	suite.True(m.ActiveAt(m.StartAt), "开始时应该生效")
+	suite.False(m.ActiveAt(m.EndAt), "结束时不应该生效")
+
+	m.IsEnabled = false
+	suite.False(m.ActiveAt(m.StartAt), "未启用的冻结不应该生效")
+}
+
+func (suite *ChangeFreezeTestSuite) TestCovers() {
+	m := CreateTestChangeFreezeModel(sysmodel.ChangeFreezeScopeGlobal, "")
+	suite.True(m.Covers("/api/v1/mds/colony/1"), "全局冻结应该覆盖全部接口")
+
+	m = CreateTestChangeFreezeModel(sysmodel.ChangeFreezeScopeModule, "oes")
+	suite.True(m.Covers("/api/v1/oes/colony/1"))
+	suite.False(m.Covers("/api/v1/mds/colony/1"), "模块冻结不应该覆盖其他模块")
+	suite.False(m.Covers("/api/v1/oesx/colony"), "模块前缀必须完整匹配")
+}
+
+func TestChangeFreezeTestSuite(t *testing.T) {
+	suite.Run(t, new(ChangeFreezeTestSuite))
+}
@@ -0,0 +1,129 @@
+package system
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	sysmodel "gin-artweb/internal/model/system"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/test"
+)
+
+func CreateTestMaintenanceWindowModel(scope string) *sysmodel.MaintenanceWindowModel {
+	start := time.Date(2025, 1, 4, 20, 0, 0, 0, time.Local)
+	return &sysmodel.MaintenanceWindowModel{
+		Name:       uuid.NewString(),
+		Scope:      scope,
+		StartAt:    start,
+		EndAt:      start.Add(2 * time.Hour),
+		Recurrence: sysmodel.MaintenanceRecurrenceNone,
+		IsEnabled:  true,
+		Username:   "admin",
+	}
+}
+
+type MaintenanceWindowTestSuite struct {
+	suite.Suite
+	windowRepo *MaintenanceWindowRepo
+}
+
+func (suite *MaintenanceWindowTestSuite) SetupTest() {
+	db := test.NewTestGormDBWithConfig(nil)
+	db.AutoMigrate(&sysmodel.MaintenanceWindowModel{})
+	dbTimeout := test.NewTestDBTimeouts()
+	logger := test.NewTestZapLogger()
+	suite.windowRepo = NewMaintenanceWindowRepo(logger, db, dbTimeout)
+}
+
+func (suite *MaintenanceWindowTestSuite) TestCreateAndGetModel() {
+	m := CreateTestMaintenanceWindowModel(sysmodel.MaintenanceScopeGlobal)
+	suite.NoError(suite.windowRepo.CreateModel(context.Background(), m), "创建维护窗口应该成功")
+	suite.NotZero(m.ID)
+
+	fm, err := suite.windowRepo.GetModel(context.Background(), m.ID)
+	suite.NoError(err, "查询维护窗口应该成功")
+	suite.Equal(m.Name, fm.Name)
+	suite.True(fm.StartAt.Equal(m.StartAt))
+
+	suite.Error(suite.windowRepo.CreateModel(context.Background(), nil), "创建空维护窗口应该返回错误")
+}
+
+func (suite *MaintenanceWindowTestSuite) TestUpdateAndDeleteModel() {
+	m := CreateTestMaintenanceWindowModel(sysmodel.MaintenanceScopeGlobal)
+	suite.NoError(suite.windowRepo.CreateModel(context.Background(), m))
+
+	err := suite.windowRepo.UpdateModel(context.Background(), map[string]any{"is_enabled": false}, "id = ?", m.ID)
+	suite.NoError(err, "更新维护窗口应该成功")
+	fm, err := suite.windowRepo.GetModel(context.Background(), m.ID)
+	suite.NoError(err)
+	suite.False(fm.IsEnabled)
+
+	suite.Error(suite.windowRepo.UpdateModel(context.Background(), nil, "id = ?", m.ID), "更新数据为空时应该返回错误")
+
+	suite.NoError(suite.windowRepo.DeleteModel(context.Background(), m.ID), "删除维护窗口应该成功")
+	_, err = suite.windowRepo.GetModel(context.Background(), m.ID)
+	suite.Error(err, "删除后不应该再查询到维护窗口")
+}
+
+func (suite *MaintenanceWindowTestSuite) TestListModel() {
+	suite.NoError(suite.windowRepo.CreateModel(context.Background(), CreateTestMaintenanceWindowModel(sysmodel.MaintenanceScopeGlobal)))
+	for i := 0; i < 2; i++ {
+		m := CreateTestMaintenanceWindowModel(sysmodel.MaintenanceScopeNode)
+		m.Module = "mon"
+		m.TargetID = 1
+		suite.NoError(suite.windowRepo.CreateModel(context.Background(), m))
+	}
+
+	count, ms, err := suite.windowRepo.ListModel(context.Background(), database.QueryParams{
+		IsCount: true,
+		Query:   map[string]any{"scope = ?": sysmodel.MaintenanceScopeNode},
+	})
+	suite.NoError(err, "查询维护窗口列表应该成功")
+	suite.Equal(int64(2), count)
+	suite.Len(*ms, 2)
+}
+
+func (suite *MaintenanceWindowTestSuite) TestActiveAt() {
+	m := CreateTestMaintenanceWindowModel(sysmodel.MaintenanceScopeGlobal)
+	start := m.StartAt
+
+	suite.False(m.ActiveAt(start.Add(-time.Minute)), "开始前不应该生效")
+	suite.True(m.ActiveAt(start), "开始时应该生效")
+	suite.True(m.ActiveAt(start.Add(119 * time.Minute)))
+	suite.False(m.ActiveAt(start.Add(2*time.Hour)), "结束时不应该生效")
+	suite.False(m.ActiveAt(start.Add(24*time.Hour)), "不重复的窗口次日不应该生效")
+
+	m.Recurrence = sysmodel.MaintenanceRecurrenceDaily
+	suite.True(m.ActiveAt(start.Add(24*time.Hour+time.Hour)), "每日窗口次日同一时段应该生效")
+	suite.False(m.ActiveAt(start.Add(24*time.Hour + 3*time.Hour)))
+
+	m.Recurrence = sysmodel.MaintenanceRecurrenceWeekly
+	suite.False(m.ActiveAt(start.Add(24*time.Hour+time.Hour)), "每周窗口次日不应该生效")
+	suite.True(m.ActiveAt(start.Add(14*24*time.Hour+time.Hour)), "每周窗口两周后同一时段应该生效")
+
+	m.IsEnabled = false
+	suite.False(m.ActiveAt(start), "未启用的窗口不应该生效")
+}
+
+func (suite *MaintenanceWindowTestSuite) TestValidRange() {
+	m := CreateTestMaintenanceWindowModel(sysmodel.MaintenanceScopeGlobal)
+	suite.True(m.ValidRange())
+
+	m.EndAt = m.StartAt
+	suite.False(m.ValidRange(), "结束时间必须晚于开始时间")
+
+	m.EndAt = m.StartAt.Add(25 * time.Hour)
+	m.Recurrence = sysmodel.MaintenanceRecurrenceDaily
+	suite.False(m.ValidRange(), "每日窗口持续时间不能超过一天")
+
+	m.Recurrence = sysmodel.MaintenanceRecurrenceWeekly
+	suite.True(m.ValidRange())
+}
+
+func TestMaintenanceWindowTestSuite(t *testing.T) {
+	suite.Run(t, new(MaintenanceWindowTestSuite))
+}
@@ -0,0 +1,184 @@
+package system
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	sysmodel "gin-artweb/internal/model/system"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/events"
+	"gin-artweb/internal/shared/log"
+)
+
+// 报表统计的集群项目, 集群任务的执行记录以集群号作为命令行参数
+var reportProjects = []string{"oes", "mds"}
+
+// ReportRepo 报表统计仓库实现
+type ReportRepo struct {
+	log      *zap.Logger       // 日志记录器
+	gormDB   *gorm.DB          // GORM数据库连接
+	timeouts *config.DBTimeout // 数据库操作超时配置
+}
+
+// NewReportRepo 创建报表统计仓库实例
+func NewReportRepo(
+	log *zap.Logger,
+	gormDB *gorm.DB,
+	timeouts *config.DBTimeout,
+) *ReportRepo {
+	return &ReportRepo{
+		log:      log,
+		gormDB:   gormDB,
+		timeouts: timeouts,
+	}
+}
+
+// CountJobs 统计时间范围内各集群任务的执行记录数, 按项目、集群号和执行状态分组
+func (r *ReportRepo) CountJobs(ctx context.Context, start, end time.Time) ([]sysmodel.ReportJobCount, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始统计集群任务执行记录",
+		zap.Time("start", start),
+		zap.Time("end", end),
+	)
+	startTime := time.Now()
+	dbCtx, cancel := database.ListContext(ctx, r.timeouts)
+	defer cancel()
+
+	var counts []sysmodel.ReportJobCount
+	if err := database.Conn(dbCtx, r.gormDB).
+		Table("jobs_script_record AS r").
+		Select("s.project AS project, r.command_args AS colony_num, r.status AS status, COUNT(*) AS count").
+		Joins("JOIN jobs_script AS s ON s.id = r.script_id").
+		Where("s.project IN ? AND r.created_at >= ? AND r.created_at < ?", reportProjects, start, end).
+		Group("s.project, r.command_args, r.status").
+		Order("s.project, r.command_args").
+		Scan(&counts).Error; err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"统计集群任务执行记录失败",
+			zap.Error(err),
+			zap.Time("start", start),
+			zap.Time("end", end),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return nil, errors.WrapIf(err, "统计集群任务执行记录失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"统计集群任务执行记录成功",
+		zap.Int("count", len(counts)),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return counts, nil
+}
+
+// CountLogins 统计时间范围内的登录次数和登录成功的用户数
+func (r *ReportRepo) CountLogins(ctx context.Context, start, end time.Time) (*sysmodel.ReportLoginCount, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始统计登录记录",
+		zap.Time("start", start),
+		zap.Time("end", end),
+	)
+	startTime := time.Now()
+	dbCtx, cancel := database.ReadContext(ctx, r.timeouts)
+	defer cancel()
+
+	var rows []struct {
+		Status bool
+		Count  int64
+	}
+	m := &sysmodel.ReportLoginCount{}
+	err := database.Conn(dbCtx, r.gormDB).
+		Table("customer_login_record").
+		Select("status, COUNT(*) AS count").
+		Where("login_at >= ? AND login_at < ?", start, end).
+		Group("status").
+		Scan(&rows).Error
+	if err == nil {
+		err = database.Conn(dbCtx, r.gormDB).
+			Table("customer_login_record").
+			Where("login_at >= ? AND login_at < ? AND status = ?", start, end, true).
+			Distinct("username").
+			Count(&m.Users).Error
+	}
+	if err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"统计登录记录失败",
+			zap.Error(err),
+			zap.Time("start", start),
+			zap.Time("end", end),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return nil, errors.WrapIf(err, "统计登录记录失败")
+	}
+
+	for _, row := range rows {
+		m.Total += row.Count
+		if row.Status {
+			m.Success += row.Count
+		} else {
+			m.Failure += row.Count
+		}
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"统计登录记录成功",
+		zap.Object("login", m),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return m, nil
+}
+
+// ListAlerts 查询时间范围内发出的告警事件和被维护窗口屏蔽的告警
+func (r *ReportRepo) ListAlerts(ctx context.Context, start, end time.Time) ([]sysmodel.ReportAlertRecord, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始统计告警",
+		zap.Time("start", start),
+		zap.Time("end", end),
+	)
+	startTime := time.Now()
+	dbCtx, cancel := database.ListContext(ctx, r.timeouts)
+	defer cancel()
+
+	var fired, muted []string
+	err := database.Conn(dbCtx, r.gormDB).
+		Model(&events.OutboxModel{}).
+		Where("event_type = ? AND occurred_at >= ? AND occurred_at < ?", events.AlertFired, start, end).
+		Pluck("payload", &fired).Error
+	if err == nil {
+		err = database.Conn(dbCtx, r.gormDB).
+			Model(&sysmodel.AuditRecordModel{}).
+			Where("module = ? AND resource = ? AND action = ?",
+				"system", sysmodel.MaintenanceAuditResource, sysmodel.MaintenanceActionMuteAlert).
+			Where("created_at >= ? AND created_at < ?", start, end).
+			Pluck("after", &muted).Error
+	}
+	if err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"统计告警失败",
+			zap.Error(err),
+			zap.Time("start", start),
+			zap.Time("end", end),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return nil, errors.WrapIf(err, "统计告警失败")
+	}
+
+	records := make([]sysmodel.ReportAlertRecord, 0, len(fired)+len(muted))
+	for _, payload := range fired {
+		records = append(records, sysmodel.ReportAlertRecord{Payload: payload})
+	}
+	for _, payload := range muted {
+		records = append(records, sysmodel.ReportAlertRecord{Payload: payload, Muted: true})
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"统计告警成功",
+		zap.Int("fired", len(fired)),
+		zap.Int("muted", len(muted)),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return records, nil
+}
@@ -0,0 +1,120 @@
+package system
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	custmodel "gin-artweb/internal/model/customer"
+	jobsmodel "gin-artweb/internal/model/jobs"
+	sysmodel "gin-artweb/internal/model/system"
+	"gin-artweb/internal/shared/events"
+	"gin-artweb/internal/shared/test"
+)
+
+type ReportTestSuite struct {
+	suite.Suite
+	db         *gorm.DB
+	reportRepo *ReportRepo
+}
+
+func (suite *ReportTestSuite) SetupTest() {
+	suite.db = test.NewTestGormDBWithConfig(nil)
+	suite.db.AutoMigrate(
+		&jobsmodel.ScriptModel{}, &jobsmodel.ScriptRecordModel{}, &custmodel.LoginRecordModel{},
+		&sysmodel.AuditRecordModel{}, &events.OutboxModel{},
+	)
+	suite.reportRepo = NewReportRepo(test.NewTestZapLogger(), suite.db, test.NewTestDBTimeouts())
+}
+
+func (suite *ReportTestSuite) createScript(project string) uint32 {
+	m := jobsmodel.ScriptModel{Name: project + ".sh", Project: project, Label: "cron"}
+	suite.Require().NoError(suite.db.Create(&m).Error)
+	return m.ID
+}
+
+func (suite *ReportTestSuite) createRecord(scriptID uint32, colonyNum string, status int, at time.Time) {
+	m := jobsmodel.ScriptRecordModel{ScriptID: scriptID, CommandArgs: colonyNum, Status: status}
+	m.CreatedAt = at
+	suite.Require().NoError(suite.db.Omit("Script").Create(&m).Error)
+}
+
+func (suite *ReportTestSuite) TestCountJobs() {
+	ctx := context.Background()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)
+	end := start.AddDate(0, 0, 1)
+
+	oesID := suite.createScript("oes")
+	mdsID := suite.createScript("mds")
+	otherID := suite.createScript("ops")
+	suite.createRecord(oesID, "01", jobsmodel.RecordStatusSuccess, start.Add(time.Hour))
+	suite.createRecord(oesID, "01", jobsmodel.RecordStatusSuccess, start.Add(2*time.Hour))
+	suite.createRecord(oesID, "01", jobsmodel.RecordStatusFailed, start.Add(3*time.Hour))
+	suite.createRecord(mdsID, "02", jobsmodel.RecordStatusTimeout, start.Add(time.Hour))
+	suite.createRecord(otherID, "01", jobsmodel.RecordStatusSuccess, start.Add(time.Hour))
+	suite.createRecord(oesID, "01", jobsmodel.RecordStatusSuccess, end.Add(time.Hour))
+
+	counts, err := suite.reportRepo.CountJobs(ctx, start, end)
+	suite.NoError(err)
+	suite.ElementsMatch([]sysmodel.ReportJobCount{
+		{Project: "mds", ColonyNum: "02", Status: jobsmodel.RecordStatusTimeout, Count: 1},
+		{Project: "oes", ColonyNum: "01", Status: jobsmodel.RecordStatusSuccess, Count: 2},
+		{Project: "oes", ColonyNum: "01", Status: jobsmodel.RecordStatusFailed, Count: 1},
+	}, counts, "只统计时间范围内oes和mds集群的执行记录")
+}
+
+func (suite *ReportTestSuite) TestCountLogins() {
+	ctx := context.Background()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)
+	end := start.AddDate(0, 0, 1)
+
+	records := []custmodel.LoginRecordModel{
+		{Username: "admin", Status: true, LoginAt: start.Add(time.Hour)},
+		{Username: "admin", Status: true, LoginAt: start.Add(2 * time.Hour)},
+		{Username: "guest", Status: true, LoginAt: start.Add(3 * time.Hour)},
+		{Username: "guest", Status: false, LoginAt: start.Add(4 * time.Hour)},
+		{Username: "hacker", Status: false, LoginAt: start.Add(5 * time.Hour)},
+		{Username: "admin", Status: true, LoginAt: end.Add(time.Hour)},
+	}
+	suite.Require().NoError(suite.db.Create(&records).Error)
+
+	m, err := suite.reportRepo.CountLogins(ctx, start, end)
+	suite.NoError(err)
+	suite.Equal(&sysmodel.ReportLoginCount{Total: 5, Success: 3, Failure: 2, Users: 2}, m)
+
+	m, err = suite.reportRepo.CountLogins(ctx, end.AddDate(0, 0, 1), end.AddDate(0, 0, 2))
+	suite.NoError(err)
+	suite.Equal(&sysmodel.ReportLoginCount{}, m, "没有登录记录时统计结果为0")
+}
+
+func (suite *ReportTestSuite) TestListAlerts() {
+	ctx := context.Background()
+	start := time.Date(2024, 1, 3, 0, 0, 0, 0, time.Local)
+	end := start.AddDate(0, 0, 1)
+
+	suite.Require().NoError(suite.db.Create(&[]events.OutboxModel{
+		{EventID: "e1", EventType: events.AlertFired, OccurredAt: start, Payload: `{"kind":"a"}`},
+		{EventID: "e2", EventType: events.AlertFired, OccurredAt: end, Payload: `{"kind":"b"}`},
+		{EventID: "e3", EventType: events.UserCreated, OccurredAt: start, Payload: `{}`},
+	}).Error)
+	suite.Require().NoError(suite.db.Create(&[]sysmodel.AuditRecordModel{
+		{Module: "system", Resource: sysmodel.MaintenanceAuditResource, Action: sysmodel.MaintenanceActionMuteAlert,
+			After: `{"kind":"c"}`, CreatedAt: start.Add(time.Hour)},
+		{Module: "system", Resource: sysmodel.MaintenanceAuditResource, Action: sysmodel.MaintenanceActionSkipSchedule,
+			After: `{}`, CreatedAt: start.Add(time.Hour)},
+	}).Error)
+
+	records, err := suite.reportRepo.ListAlerts(ctx, start, end)
+	suite.Require().NoError(err)
+	suite.Equal([]sysmodel.ReportAlertRecord{
+		{Payload: `{"kind":"a"}`},
+		{Payload: `{"kind":"c"}`, Muted: true},
+	}, records, "只统计时间范围内发出和屏蔽的告警")
+}
+
+func TestReportTestSuite(t *testing.T) {
+	suite.Run(t, new(ReportTestSuite))
+}
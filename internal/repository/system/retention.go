@@ -0,0 +1,187 @@
+package system
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/log"
+)
+
+// RetentionRepo 数据保留策略仓库实现
+//
+// 按表名操作数据, 表名由服务层从白名单中选取, 不能直接使用外部输入
+type RetentionRepo struct {
+	log      *zap.Logger       // 日志记录器
+	gormDB   *gorm.DB          // GORM数据库连接
+	timeouts *config.DBTimeout // 数据库操作超时配置
+}
+
+// NewRetentionRepo 创建数据保留策略仓库实例
+func NewRetentionRepo(
+	log *zap.Logger,
+	gormDB *gorm.DB,
+	timeouts *config.DBTimeout,
+) *RetentionRepo {
+	return &RetentionRepo{
+		log:      log,
+		gormDB:   gormDB,
+		timeouts: timeouts,
+	}
+}
+
+// CutoffID 查询保留最近keepRows行时需要保留的最小ID, 总行数不超过keepRows时返回0
+func (r *RetentionRepo) CutoffID(ctx context.Context, table string, keepRows int) (uint32, error) {
+	if keepRows <= 0 {
+		return 0, errors.New("查询保留行数的起始ID失败: 保留行数必须大于0")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始查询保留行数的起始ID",
+		zap.String("table", table),
+		zap.Int("keep_rows", keepRows),
+	)
+	startTime := time.Now()
+	dbCtx, cancel := database.ReadContext(ctx, r.timeouts)
+	defer cancel()
+
+	var ids []uint32
+	if err := database.Conn(dbCtx, r.gormDB).
+		Table(table).
+		Order("id DESC").
+		Offset(keepRows-1).
+		Limit(1).
+		Pluck("id", &ids).Error; err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"查询保留行数的起始ID失败",
+			zap.Error(err),
+			zap.String("table", table),
+			zap.Int("keep_rows", keepRows),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return 0, errors.WrapIf(err, "查询保留行数的起始ID失败")
+	}
+
+	var cutoff uint32
+	if len(ids) > 0 {
+		cutoff = ids[0]
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"查询保留行数的起始ID成功",
+		zap.String("table", table),
+		zap.Uint32("cutoff_id", cutoff),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return cutoff, nil
+}
+
+// ListBatch 按ID升序查询一批满足条件的记录
+func (r *RetentionRepo) ListBatch(ctx context.Context, table string, limit int, conds ...any) ([]map[string]any, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始查询待清理的记录",
+		zap.String("table", table),
+		zap.Int("limit", limit),
+		zap.Any(database.ConditionsKey, conds),
+	)
+	startTime := time.Now()
+	dbCtx, cancel := database.ListContext(ctx, r.timeouts)
+	defer cancel()
+
+	var rows []map[string]any
+	if err := r.batchQuery(dbCtx, table, limit, conds...).Find(&rows).Error; err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"查询待清理的记录失败",
+			zap.Error(err),
+			zap.String("table", table),
+			zap.Any(database.ConditionsKey, conds),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return nil, errors.WrapIf(err, "查询待清理的记录失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"查询待清理的记录成功",
+		zap.String("table", table),
+		zap.Int("count", len(rows)),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return rows, nil
+}
+
+// ListBatchIDs 按ID升序查询一批满足条件的记录ID, 不归档时只需要ID
+func (r *RetentionRepo) ListBatchIDs(ctx context.Context, table string, limit int, conds ...any) ([]uint32, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始查询待清理的记录ID",
+		zap.String("table", table),
+		zap.Int("limit", limit),
+		zap.Any(database.ConditionsKey, conds),
+	)
+	startTime := time.Now()
+	dbCtx, cancel := database.ListContext(ctx, r.timeouts)
+	defer cancel()
+
+	var ids []uint32
+	if err := r.batchQuery(dbCtx, table, limit, conds...).Pluck("id", &ids).Error; err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"查询待清理的记录ID失败",
+			zap.Error(err),
+			zap.String("table", table),
+			zap.Any(database.ConditionsKey, conds),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return nil, errors.WrapIf(err, "查询待清理的记录ID失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"查询待清理的记录ID成功",
+		zap.String("table", table),
+		zap.Int("count", len(ids)),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return ids, nil
+}
+
+// DeleteByIDs 按ID批量删除记录, 返回删除的行数
+func (r *RetentionRepo) DeleteByIDs(ctx context.Context, table string, ids []uint32) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始删除过期记录",
+		zap.String("table", table),
+		zap.Int("count", len(ids)),
+	)
+	startTime := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+
+	result := database.Conn(dbCtx, r.gormDB).Table(table).Where("id IN ?", ids).Delete(nil)
+	if result.Error != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"删除过期记录失败",
+			zap.Error(result.Error),
+			zap.String("table", table),
+			zap.Int("count", len(ids)),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return 0, errors.WrapIf(result.Error, "删除过期记录失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"删除过期记录成功",
+		zap.String("table", table),
+		zap.Int64("rows_affected", result.RowsAffected),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return result.RowsAffected, nil
+}
+
+func (r *RetentionRepo) batchQuery(ctx context.Context, table string, limit int, conds ...any) *gorm.DB {
+	mdb := database.Conn(ctx, r.gormDB).Table(table)
+	if len(conds) > 0 {
+		mdb = mdb.Where(conds[0], conds[1:]...)
+	}
+	return mdb.Order("id").Limit(limit)
+}
@@ -0,0 +1,80 @@
+package system
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	sysmodel "gin-artweb/internal/model/system"
+	"gin-artweb/internal/shared/test"
+)
+
+type RetentionTestSuite struct {
+	suite.Suite
+	auditRepo     *AuditRecordRepo
+	retentionRepo *RetentionRepo
+}
+
+func (suite *RetentionTestSuite) SetupTest() {
+	db := test.NewTestGormDBWithConfig(nil)
+	db.AutoMigrate(&sysmodel.AuditRecordModel{})
+	logger := test.NewTestZapLogger()
+	suite.auditRepo = NewAuditRecordRepo(logger, db, test.NewTestDBTimeouts())
+	suite.retentionRepo = NewRetentionRepo(logger, db, test.NewTestDBTimeouts())
+}
+
+func (suite *RetentionTestSuite) createRecords(n int, at time.Time) {
+	for range n {
+		m := sysmodel.AuditRecordModel{Module: "mon", Resource: "node", Action: "create", CreatedAt: at}
+		suite.Require().NoError(suite.auditRepo.CreateModel(context.Background(), &m))
+	}
+}
+
+func (suite *RetentionTestSuite) TestCutoffID() {
+	ctx := context.Background()
+	cutoff, err := suite.retentionRepo.CutoffID(ctx, "system_audit_record", 3)
+	suite.NoError(err)
+	suite.Zero(cutoff, "没有记录时不需要清理")
+
+	suite.createRecords(5, time.Now())
+	cutoff, err = suite.retentionRepo.CutoffID(ctx, "system_audit_record", 3)
+	suite.NoError(err)
+	suite.Equal(uint32(3), cutoff, "保留最近3条时最小保留ID应该为3")
+
+	_, err = suite.retentionRepo.CutoffID(ctx, "system_audit_record", 0)
+	suite.Error(err)
+}
+
+func (suite *RetentionTestSuite) TestListAndDeleteBatch() {
+	ctx := context.Background()
+	suite.createRecords(3, time.Now().AddDate(0, 0, -10))
+	suite.createRecords(2, time.Now())
+	before := time.Now().AddDate(0, 0, -1)
+
+	ids, err := suite.retentionRepo.ListBatchIDs(ctx, "system_audit_record", 2, "created_at < ?", before)
+	suite.NoError(err)
+	suite.Equal([]uint32{1, 2}, ids, "应该按ID升序分批查询")
+
+	rows, err := suite.retentionRepo.ListBatch(ctx, "system_audit_record", 10, "created_at < ?", before)
+	suite.NoError(err)
+	suite.Len(rows, 3)
+	suite.Equal("mon", rows[0]["module"])
+
+	n, err := suite.retentionRepo.DeleteByIDs(ctx, "system_audit_record", []uint32{1, 2, 3})
+	suite.NoError(err)
+	suite.Equal(int64(3), n)
+
+	n, err = suite.retentionRepo.DeleteByIDs(ctx, "system_audit_record", nil)
+	suite.NoError(err)
+	suite.Zero(n)
+
+	ids, err = suite.retentionRepo.ListBatchIDs(ctx, "system_audit_record", 10)
+	suite.NoError(err)
+	suite.Equal([]uint32{4, 5}, ids)
+}
+
+func TestRetentionTestSuite(t *testing.T) {
+	suite.Run(t, new(RetentionTestSuite))
+}
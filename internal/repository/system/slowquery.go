@@ -0,0 +1,124 @@
+package system
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	sysmodel "gin-artweb/internal/model/system"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/log"
+)
+
+type SlowQueryRepo struct {
+	log      *zap.Logger
+	gormDB   *gorm.DB
+	timeouts *config.DBTimeout
+}
+
+func NewSlowQueryRepo(
+	log *zap.Logger,
+	gormDB *gorm.DB,
+	timeouts *config.DBTimeout,
+) *SlowQueryRepo {
+	return &SlowQueryRepo{
+		log:      log,
+		gormDB:   gormDB,
+		timeouts: timeouts,
+	}
+}
+
+// MergeModels 将一批慢查询统计合并到数据库中
+//
+// 指纹已存在时累加次数和耗时并更新最大耗时, 不存在时新增
+func (r *SlowQueryRepo) MergeModels(ctx context.Context, ms []sysmodel.SlowQueryModel) error {
+	// 检查参数
+	if len(ms) == 0 {
+		return nil
+	}
+
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始合并慢查询统计",
+		zap.Int("count", len(ms)),
+	)
+	startTime := time.Now()
+	dbCtx, cancel := database.WriteContext(ctx, r.timeouts)
+	defer cancel()
+	err := database.Conn(dbCtx, r.gormDB).Transaction(func(tx *gorm.DB) error {
+		for i := range ms {
+			m := &ms[i]
+			var om sysmodel.SlowQueryModel
+			err := tx.Where("fingerprint = ?", m.Fingerprint).Limit(1).Find(&om).Error
+			if err != nil {
+				return err
+			}
+			if om.ID == 0 {
+				if err := tx.Create(m).Error; err != nil {
+					return err
+				}
+				continue
+			}
+			data := map[string]any{
+				"count":         om.Count + m.Count,
+				"total_ms":      om.TotalMs + m.TotalMs,
+				"max_ms":        max(om.MaxMs, m.MaxMs),
+				"last_trace_id": m.LastTraceID,
+				"last_seen_at":  m.LastSeenAt,
+			}
+			if err := tx.Model(&om).Updates(data).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"合并慢查询统计失败",
+			zap.Error(err),
+			zap.Int("count", len(ms)),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return errors.WrapIf(err, "合并慢查询统计失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"合并慢查询统计成功",
+		zap.Int("count", len(ms)),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return nil
+}
+
+func (r *SlowQueryRepo) ListModel(
+	ctx context.Context,
+	qp database.QueryParams,
+) (int64, *[]sysmodel.SlowQueryModel, error) {
+	ctxutil.Logger(ctx, r.log).Debug(
+		"开始查询慢查询统计列表",
+		zap.Object(database.QueryParamsKey, &qp),
+	)
+	startTime := time.Now()
+	var ms []sysmodel.SlowQueryModel
+	dbCtx, cancel := database.ListContext(database.WithQueryTimeout(ctx, qp.Timeout), r.timeouts)
+	defer cancel()
+	count, err := database.DBList(dbCtx, r.gormDB, &sysmodel.SlowQueryModel{}, &ms, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"查询慢查询统计列表失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return 0, nil, errors.WrapIf(err, "查询慢查询统计列表失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"查询慢查询统计列表成功",
+		zap.Object(database.QueryParamsKey, &qp),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return count, &ms, nil
+}
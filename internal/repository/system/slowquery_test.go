@@ -0,0 +1,91 @@
+package system
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	sysmodel "gin-artweb/internal/model/system"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/test"
+)
+
+func CreateTestSlowQueryModel(ms int64) sysmodel.SlowQueryModel {
+	now := time.Now()
+	return sysmodel.SlowQueryModel{
+		Fingerprint: database.SQLFingerprint(uuid.NewString()),
+		Statement:   "SELECT * FROM jobs_script_record WHERE status = ?",
+		Table:       "jobs_script_record",
+		Count:       1,
+		TotalMs:     ms,
+		MaxMs:       ms,
+		LastTraceID: uuid.NewString(),
+		FirstSeenAt: now,
+		LastSeenAt:  now,
+	}
+}
+
+type SlowQueryTestSuite struct {
+	suite.Suite
+	slowQueryRepo *SlowQueryRepo
+}
+
+func (suite *SlowQueryTestSuite) SetupTest() {
+	db := test.NewTestGormDBWithConfig(nil)
+	db.AutoMigrate(&sysmodel.SlowQueryModel{})
+	dbTimeout := test.NewTestDBTimeouts()
+	logger := test.NewTestZapLogger()
+	suite.slowQueryRepo = NewSlowQueryRepo(logger, db, dbTimeout)
+}
+
+func (suite *SlowQueryTestSuite) TestMergeModels() {
+	suite.NoError(suite.slowQueryRepo.MergeModels(context.Background(), nil), "合并空列表应该直接返回")
+
+	m := CreateTestSlowQueryModel(300)
+	suite.NoError(suite.slowQueryRepo.MergeModels(context.Background(), []sysmodel.SlowQueryModel{m}), "首次合并应该新增统计")
+
+	again := m
+	again.Count = 2
+	again.TotalMs = 1000
+	again.MaxMs = 800
+	again.LastTraceID = "trace-2"
+	suite.NoError(suite.slowQueryRepo.MergeModels(context.Background(), []sysmodel.SlowQueryModel{again}), "再次合并应该累加统计")
+
+	count, ms, err := suite.slowQueryRepo.ListModel(context.Background(), database.QueryParams{
+		IsCount: true,
+		Query:   map[string]any{"fingerprint = ?": m.Fingerprint},
+	})
+	suite.NoError(err)
+	suite.Equal(int64(1), count, "同一指纹只应该保存一条统计")
+	fm := (*ms)[0]
+	suite.Equal(int64(3), fm.Count)
+	suite.Equal(int64(1300), fm.TotalMs)
+	suite.Equal(int64(800), fm.MaxMs)
+	suite.Equal(int64(433), fm.AvgMs())
+	suite.Equal("trace-2", fm.LastTraceID)
+}
+
+func (suite *SlowQueryTestSuite) TestListModelOrder() {
+	for _, ms := range []int64{200, 900, 500} {
+		m := CreateTestSlowQueryModel(ms)
+		suite.NoError(suite.slowQueryRepo.MergeModels(context.Background(), []sysmodel.SlowQueryModel{m}))
+	}
+
+	req := sysmodel.ListSlowQueryRequest{MinMs: 300}
+	_, _, query := req.Query()
+	count, ms, err := suite.slowQueryRepo.ListModel(context.Background(), database.QueryParams{
+		IsCount: true,
+		Query:   query,
+		OrderBy: req.Order(),
+	})
+	suite.NoError(err, "查询慢查询统计列表应该成功")
+	suite.Equal(int64(2), count)
+	suite.Equal(int64(900), (*ms)[0].MaxMs, "默认应该按最大耗时倒序")
+}
+
+func TestSlowQueryTestSuite(t *testing.T) {
+	suite.Run(t, new(SlowQueryTestSuite))
+}
@@ -0,0 +1,169 @@
+package system
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	sysmodel "gin-artweb/internal/model/system"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/events"
+	"gin-artweb/internal/shared/log"
+)
+
+// 统计启用情况的集群表, 键为项目
+var statsColonyTables = map[string]string{
+	"oes": "oes_colony",
+	"mds": "mds_colony",
+}
+
+// StatsRepo 首页统计仓库实现, 全部使用分组聚合查询
+type StatsRepo struct {
+	log      *zap.Logger       // 日志记录器
+	gormDB   *gorm.DB          // GORM数据库连接
+	timeouts *config.DBTimeout // 数据库操作超时配置
+}
+
+// NewStatsRepo 创建首页统计仓库实例
+func NewStatsRepo(
+	log *zap.Logger,
+	gormDB *gorm.DB,
+	timeouts *config.DBTimeout,
+) *StatsRepo {
+	return &StatsRepo{
+		log:      log,
+		gormDB:   gormDB,
+		timeouts: timeouts,
+	}
+}
+
+// CountJobsByDay 统计start之后的脚本执行记录数, 按日期和执行状态分组
+func (r *StatsRepo) CountJobsByDay(ctx context.Context, start time.Time) ([]sysmodel.StatsJobDayCount, error) {
+	startTime := time.Now()
+	dbCtx, cancel := database.ListContext(ctx, r.timeouts)
+	defer cancel()
+
+	var counts []sysmodel.StatsJobDayCount
+	day := database.DateExpr(r.gormDB, "created_at")
+	if err := database.Conn(dbCtx, r.gormDB).
+		Table("jobs_script_record").
+		Select(day+" AS day, status, COUNT(*) AS count").
+		Where("created_at >= ?", start).
+		Group(day + ", status").
+		Order("day").
+		Scan(&counts).Error; err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"按日统计脚本执行记录失败",
+			zap.Error(err),
+			zap.Time("start", start),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return nil, errors.WrapIf(err, "按日统计脚本执行记录失败")
+	}
+	ctxutil.Logger(ctx, r.log).Debug(
+		"按日统计脚本执行记录成功",
+		zap.Int("count", len(counts)),
+		zap.Duration(log.DurationKey, time.Since(startTime)),
+	)
+	return counts, nil
+}
+
+// CountColonies 统计各项目启用和停用的集群数
+func (r *StatsRepo) CountColonies(ctx context.Context) ([]sysmodel.StatsColonyCount, error) {
+	startTime := time.Now()
+	dbCtx, cancel := database.ReadContext(ctx, r.timeouts)
+	defer cancel()
+
+	var counts []sysmodel.StatsColonyCount
+	for _, project := range reportProjects {
+		var rows []sysmodel.StatsColonyCount
+		if err := database.Conn(dbCtx, r.gormDB).
+			Table(statsColonyTables[project]).
+			Select("is_enable, COUNT(*) AS count").
+			Group("is_enable").
+			Scan(&rows).Error; err != nil {
+			ctxutil.Logger(ctx, r.log).Error(
+				"统计集群启用情况失败",
+				zap.Error(err),
+				zap.String("project", project),
+				zap.Duration(log.DurationKey, time.Since(startTime)),
+			)
+			return nil, errors.WrapIf(err, "统计集群启用情况失败")
+		}
+		for _, row := range rows {
+			row.Project = project
+			counts = append(counts, row)
+		}
+	}
+	return counts, nil
+}
+
+// CountUsers 统计用户数和since之后登录成功的去重用户数
+func (r *StatsRepo) CountUsers(ctx context.Context, since time.Time) (*sysmodel.StatsUserCount, error) {
+	startTime := time.Now()
+	dbCtx, cancel := database.ReadContext(ctx, r.timeouts)
+	defer cancel()
+
+	var rows []struct {
+		IsActive bool
+		Count    int64
+	}
+	m := &sysmodel.StatsUserCount{}
+	err := database.Conn(dbCtx, r.gormDB).
+		Table("customer_user").
+		Select("is_active, COUNT(*) AS count").
+		Group("is_active").
+		Scan(&rows).Error
+	if err == nil {
+		err = database.Conn(dbCtx, r.gormDB).
+			Table("customer_login_record").
+			Where("login_at >= ? AND status = ?", since, true).
+			Distinct("username").
+			Count(&m.Login).Error
+	}
+	if err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"统计用户数失败",
+			zap.Error(err),
+			zap.Time("since", since),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return nil, errors.WrapIf(err, "统计用户数失败")
+	}
+
+	for _, row := range rows {
+		m.Total += row.Count
+		if row.IsActive {
+			m.Active += row.Count
+		}
+	}
+	return m, nil
+}
+
+// ListAlerts 查询发件箱中最近的告警事件
+func (r *StatsRepo) ListAlerts(ctx context.Context, limit int) ([]events.OutboxModel, error) {
+	startTime := time.Now()
+	dbCtx, cancel := database.ListContext(ctx, r.timeouts)
+	defer cancel()
+
+	var ms []events.OutboxModel
+	if err := database.Conn(dbCtx, r.gormDB).
+		Where("event_type = ?", events.AlertFired).
+		Order("occurred_at DESC").
+		Limit(limit).
+		Find(&ms).Error; err != nil {
+		ctxutil.Logger(ctx, r.log).Error(
+			"查询最近告警失败",
+			zap.Error(err),
+			zap.Int("limit", limit),
+			zap.Duration(log.DurationKey, time.Since(startTime)),
+		)
+		return nil, errors.WrapIf(err, "查询最近告警失败")
+	}
+	return ms, nil
+}
@@ -0,0 +1,131 @@
+package system
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	custmodel "gin-artweb/internal/model/customer"
+	jobsmodel "gin-artweb/internal/model/jobs"
+	mdsmodel "gin-artweb/internal/model/mds"
+	oesmodel "gin-artweb/internal/model/oes"
+	sysmodel "gin-artweb/internal/model/system"
+	"gin-artweb/internal/shared/events"
+	"gin-artweb/internal/shared/test"
+)
+
+type StatsTestSuite struct {
+	suite.Suite
+	db        *gorm.DB
+	statsRepo *StatsRepo
+}
+
+func (suite *StatsTestSuite) SetupTest() {
+	suite.db = test.NewTestGormDBWithConfig(nil)
+	suite.db.AutoMigrate(
+		&jobsmodel.ScriptRecordModel{},
+		&oesmodel.OesColonyModel{},
+		&mdsmodel.MdsColonyModel{},
+		&custmodel.UserModel{},
+		&custmodel.LoginRecordModel{},
+		&events.OutboxModel{},
+	)
+	suite.statsRepo = NewStatsRepo(test.NewTestZapLogger(), suite.db, test.NewTestDBTimeouts())
+}
+
+func (suite *StatsTestSuite) createRecord(status int, at time.Time) {
+	m := jobsmodel.ScriptRecordModel{ScriptID: 1, Status: status}
+	m.CreatedAt = at
+	suite.Require().NoError(suite.db.Omit("Script").Create(&m).Error)
+}
+
+func (suite *StatsTestSuite) TestCountJobsByDay() {
+	ctx := context.Background()
+	// 使用中午的时间, 避免时区换算后跨日
+	day1 := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	day2 := day1.AddDate(0, 0, 1)
+
+	suite.createRecord(jobsmodel.RecordStatusSuccess, day1.AddDate(0, 0, -1))
+	suite.createRecord(jobsmodel.RecordStatusSuccess, day1)
+	suite.createRecord(jobsmodel.RecordStatusSuccess, day1.Add(time.Hour))
+	suite.createRecord(jobsmodel.RecordStatusFailed, day1)
+	suite.createRecord(jobsmodel.RecordStatusRunning, day2)
+
+	counts, err := suite.statsRepo.CountJobsByDay(ctx, day1.Add(-time.Hour))
+	suite.NoError(err)
+	suite.ElementsMatch([]sysmodel.StatsJobDayCount{
+		{Day: "2024-01-01", Status: jobsmodel.RecordStatusSuccess, Count: 2},
+		{Day: "2024-01-01", Status: jobsmodel.RecordStatusFailed, Count: 1},
+		{Day: "2024-01-02", Status: jobsmodel.RecordStatusRunning, Count: 1},
+	}, counts, "只统计开始时间之后的执行记录")
+}
+
+func (suite *StatsTestSuite) TestCountColonies() {
+	ctx := context.Background()
+	oesColonies := []oesmodel.OesColonyModel{
+		{ColonyNum: "01", IsEnable: true},
+		{ColonyNum: "02", IsEnable: true},
+		{ColonyNum: "03", IsEnable: false},
+	}
+	suite.Require().NoError(suite.db.Omit("Package", "XCounter", "MonNode").Create(&oesColonies).Error)
+	mdsColony := mdsmodel.MdsColonyModel{ColonyNum: "01", IsEnable: false}
+	suite.Require().NoError(suite.db.Omit("Package", "MonNode").Create(&mdsColony).Error)
+
+	counts, err := suite.statsRepo.CountColonies(ctx)
+	suite.NoError(err)
+	suite.ElementsMatch([]sysmodel.StatsColonyCount{
+		{Project: "oes", IsEnable: true, Count: 2},
+		{Project: "oes", IsEnable: false, Count: 1},
+		{Project: "mds", IsEnable: false, Count: 1},
+	}, counts)
+}
+
+func (suite *StatsTestSuite) TestCountUsers() {
+	ctx := context.Background()
+	since := time.Now().AddDate(0, 0, -7)
+
+	users := []custmodel.UserModel{
+		{Username: "admin", Password: "x", IsActive: true, RoleID: 1},
+		{Username: "guest", Password: "x", IsActive: true, RoleID: 1},
+		{Username: "locked", Password: "x", IsActive: false, RoleID: 1},
+	}
+	suite.Require().NoError(suite.db.Omit("Role").Create(&users).Error)
+	records := []custmodel.LoginRecordModel{
+		{Username: "admin", Status: true, LoginAt: time.Now()},
+		{Username: "admin", Status: true, LoginAt: time.Now().Add(-time.Hour)},
+		{Username: "guest", Status: false, LoginAt: time.Now()},
+		{Username: "guest", Status: true, LoginAt: since.Add(-time.Hour)},
+	}
+	suite.Require().NoError(suite.db.Create(&records).Error)
+
+	m, err := suite.statsRepo.CountUsers(ctx, since)
+	suite.NoError(err)
+	suite.Equal(&sysmodel.StatsUserCount{Total: 3, Active: 2, Login: 1}, m,
+		"活跃用户只统计期间内登录成功的去重用户")
+}
+
+func (suite *StatsTestSuite) TestListAlerts() {
+	ctx := context.Background()
+	now := time.Now()
+
+	ms := []events.OutboxModel{
+		{EventID: "e1", EventType: events.AlertFired, OccurredAt: now.Add(-2 * time.Hour), Payload: `{"kind":"a"}`},
+		{EventID: "e2", EventType: events.AlertFired, OccurredAt: now.Add(-time.Hour), Payload: `{"kind":"b"}`},
+		{EventID: "e3", EventType: events.UserCreated, OccurredAt: now, Payload: `{}`},
+		{EventID: "e4", EventType: events.AlertFired, OccurredAt: now.Add(-3 * time.Hour), Payload: `{"kind":"c"}`},
+	}
+	suite.Require().NoError(suite.db.Create(&ms).Error)
+
+	alerts, err := suite.statsRepo.ListAlerts(ctx, 2)
+	suite.NoError(err)
+	suite.Require().Len(alerts, 2)
+	suite.Equal("e2", alerts[0].EventID, "按发生时间倒序")
+	suite.Equal("e1", alerts[1].EventID)
+}
+
+func TestStatsTestSuite(t *testing.T) {
+	suite.Run(t, new(StatsTestSuite))
+}
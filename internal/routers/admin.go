@@ -0,0 +1,61 @@
+package routers
+
+import (
+	"net/http"
+	"net/http/pprof"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"gin-artweb/internal/shared/common"
+	"gin-artweb/internal/shared/log"
+	"gin-artweb/internal/shared/middleware"
+)
+
+// NewAdminRouter 创建管理端口的路由引擎
+//
+// 管理端口只应绑定内网地址, 提供监控指标、性能分析和健康检查, 不经过公共端口的鉴权和限流;
+// 只面向运维的管理接口应注册在这里, 而不是公共端口上
+func NewAdminRouter(loggers *log.Loggers, init *common.Initialize, version string) *gin.Engine {
+	r := gin.New()
+	r.ContextWithFallback = true
+	r.Use(middleware.ErrorMiddleware(loggers.Service))
+
+	// 存活检查, 进程能处理请求即返回200
+	r.GET("/healthz", healthHandler(init.DBHealth))
+
+	// 就绪检查, 数据库不可用时返回503
+	r.GET("/readyz", readyHandler(init.DBHealth))
+
+	r.GET("/version", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{
+			"code": http.StatusOK,
+			"msg":  version,
+			"data": nil,
+		})
+	})
+
+	r.GET("/metrics", gin.WrapH(promhttp.Handler()))
+
+	// /debug/pprof/返回概要页面, 其余路径按名称返回对应的性能分析数据, 如heap、goroutine
+	r.GET("/debug/pprof/*name", pprofHandler)
+	r.POST("/debug/pprof/*name", pprofHandler)
+	return r
+}
+
+func pprofHandler(c *gin.Context) {
+	switch strings.TrimPrefix(c.Param("name"), "/") {
+	case "cmdline":
+		pprof.Cmdline(c.Writer, c.Request)
+	case "profile":
+		pprof.Profile(c.Writer, c.Request)
+	case "symbol":
+		pprof.Symbol(c.Writer, c.Request)
+	case "trace":
+		pprof.Trace(c.Writer, c.Request)
+	default:
+		// Index根据请求路径中/debug/pprof/之后的部分返回概要页面或指定的性能分析数据
+		pprof.Index(c.Writer, c.Request)
+	}
+}
@@ -0,0 +1,306 @@
+//go:build contract
+
+// 契约测试: 分别以演示模式(SQLite内存数据库)和生产使用的MySQL或PostgreSQL启动服务并写入相同的演示数据,
+// 逐个请求全部查询接口, 断言两者返回的状态码、内容类型和JSON结构完全相同,
+// 保证前端和其他项目基于演示模式开发的代码可以直接对接真实的服务
+//
+// 真实服务的数据库与仓库层测试相同, 通过TEST_DB_TYPE和TEST_DB_DNS指定, 在其中新建独立的schema或database;
+// 未设置时跳过, 与同为SQLite的数据库比较无法发现方言差异导致的响应结构不一致
+//
+// 运行方式: TEST_DB_TYPE=postgres TEST_DB_DNS="..." go test -tags contract -run TestContract ./internal/routers/
+package routers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/robfig/cron/v3"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"gin-artweb/internal/shared/auth"
+	"gin-artweb/internal/shared/common"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/log"
+	"gin-artweb/internal/shared/test"
+)
+
+// contractRequestTimeout 单个请求的超时时间, 流式接口和需要连接外部系统的接口在超时后返回
+const contractRequestTimeout = 3 * time.Second
+
+// contractSkipped 持续推送数据的流式接口, 响应内容与请求时机有关, 不参与比较
+var contractSkipped = []string{"/stream", "/ws", "/sse", "/tail", "/terminal", "/watch"}
+
+// contractPathValues 路径参数的取值, 对应演示数据中的记录, 未列出的参数取1
+var contractPathValues = map[string]string{
+	"colony_num": "01",
+}
+
+// contractServer 用于比较的一组服务
+type contractServer struct {
+	engine *gin.Engine
+	token  string
+}
+
+type ContractTestSuite struct {
+	suite.Suite
+	fake contractServer
+	real contractServer
+}
+
+func (suite *ContractTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+
+	typ := os.Getenv(test.TestDBTypeEnv)
+	if typ != database.DialectMySQL && typ != database.DialectPostgres {
+		suite.T().Skipf("契约测试需要通过%s和%s指定MySQL或PostgreSQL数据库", test.TestDBTypeEnv, test.TestDBDnsEnv)
+	}
+	realDB := test.NewTestGormDBWithConfig(database.NewGormConfig(nil))
+	suite.T().Cleanup(func() { test.CloseTestGormDB(realDB) })
+	real := suite.newConf(&config.DBConf{Type: typ, ReadTimeout: 5, WriteTimeout: 5, ListTimeout: 10})
+	suite.real = suite.newServer(real, realDB)
+
+	fake := suite.newConf(&config.DBConf{ReadTimeout: 5, WriteTimeout: 5, ListTimeout: 10})
+	FakeConfig(fake)
+	fakeDB, err := database.NewGormDB(fake.Database, database.NewGormConfig(nil))
+	suite.Require().NoError(err, "初始化数据库应该成功")
+	suite.T().Cleanup(func() { database.CloseGormDB(fakeDB) })
+	suite.fake = suite.newServer(fake, fakeDB)
+}
+
+func (suite *ContractTestSuite) newConf(db *config.DBConf) *config.SystemConf {
+	return &config.SystemConf{
+		Server: &config.ServerConfig{
+			Host:    "127.0.0.1",
+			Port:    8621,
+			Rate:    config.RateLimitConfig{RPS: math.MaxFloat64, Burst: math.MaxInt32},
+			Timeout: config.TimeoutConfig{Request: 30, Shutdown: 5},
+		},
+		Database: db,
+		Log:      &config.LogConfig{Level: "error"},
+		CORS:     &config.AllowConfig{AllowOrigins: []string{"*"}},
+		Security: &config.SecurityConfig{
+			Token: config.TokenConfig{
+				AccessMinutes:  10,
+				RefreshMinutes: 10,
+				AccessMethod:   "HS256",
+				RefreshMethod:  "HS512",
+			},
+			Login:    config.LoginSecurityConfig{MaxFailedAttempts: 5, LockMinutes: 30},
+			Password: config.PasswordConfig{StrengthLevel: 3},
+		},
+		SSH:       &config.SSHConfig{Timeout: 1},
+		Upload:    &config.UploadConfig{MaxPkgSize: 1, MaxScriptSize: 1, MaxConfSize: 1},
+		Analytics: &config.AnalyticsConfig{},
+		Monitor:   &config.MonitorConfig{QueryTimeout: 1},
+		Deploy:    &config.DeployConfig{Mode: config.DeployModeEmbedded},
+	}
+}
+
+// newServer 按配置启动服务, 在空数据库中执行版本化迁移并写入演示数据, 使用管理员账号登录
+func (suite *ContractTestSuite) newServer(conf *config.SystemConf, db *gorm.DB) contractServer {
+	ctx := context.Background()
+
+	_, err := NewMigrator(db).Up(ctx)
+	suite.Require().NoError(err, "迁移数据库应该成功")
+
+	enf, err := auth.NewCasbinEnforcer()
+	suite.Require().NoError(err, "初始化Casbin应该成功")
+
+	nop := zap.NewNop()
+	loggers := &log.Loggers{Server: nop, Service: nop, Biz: nop, Data: nop}
+	init := &common.Initialize{
+		Conf: conf,
+		DB:   db,
+		DBTimeout: &config.DBTimeout{
+			ReadTimeout:  5 * time.Second,
+			WriteTimeout: 5 * time.Second,
+			ListTimeout:  10 * time.Second,
+		},
+		Enforcer: enf,
+		Crontab:  cron.New(),
+		JwtConf: auth.NewJWTConfig(
+			10*time.Minute, 10*time.Minute, "HS256", "HS512",
+			[]byte("contract-access"), []byte("contract-refresh"),
+		),
+	}
+	engine := NewRouter(loggers, init, "contract", os.DirFS(suite.T().TempDir()))
+	suite.Require().NoError(SeedFake(ctx, engine, init, loggers), "写入演示数据应该成功")
+
+	token := suite.login(engine, FakeAdmin)
+	return contractServer{engine: engine, token: token}
+}
+
+// login 使用演示账号登录, 返回访问令牌
+func (suite *ContractTestSuite) login(engine *gin.Engine, username string) string {
+	body, _ := json.Marshal(map[string]string{"username": username, "password": FakePassword})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/login", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+	suite.Require().Equal(http.StatusOK, w.Code, "演示账号%s登录应该成功: %s", username, w.Body.String())
+
+	var resp struct {
+		Data struct {
+			AccessToken string `json:"access_token"`
+		} `json:"data"`
+	}
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+	suite.Require().NotEmpty(resp.Data.AccessToken, "登录响应应该包含访问令牌")
+	return resp.Data.AccessToken
+}
+
+func (suite *ContractTestSuite) TestFakeUsers() {
+	for _, fu := range FakeUsers {
+		token := suite.login(suite.fake.engine, fu.Username)
+
+		// 演示账号不需要修改初始密码即可访问查询接口
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/resource/host", nil)
+		req.Header.Set("Authorization", token)
+		w := httptest.NewRecorder()
+		suite.fake.engine.ServeHTTP(w, req)
+		suite.Equal(http.StatusOK, w.Code, "演示账号%s应该可以查询主机列表: %s", fu.Username, w.Body.String())
+	}
+}
+
+func (suite *ContractTestSuite) TestResponseShapes() {
+	routes := suite.real.engine.Routes()
+	sort.Slice(routes, func(i, j int) bool { return routes[i].Path < routes[j].Path })
+
+	fakeRoutes := make(map[string]bool)
+	for _, ri := range suite.fake.engine.Routes() {
+		fakeRoutes[ri.Method+" "+ri.Path] = true
+	}
+
+	total := 0
+	for _, ri := range routes {
+		suite.True(fakeRoutes[ri.Method+" "+ri.Path], "演示模式应该注册接口%s %s", ri.Method, ri.Path)
+		if ri.Method != http.MethodGet || !strings.HasPrefix(ri.Path, "/api/") {
+			continue
+		}
+		path, ok := contractPath(ri.Path)
+		if !ok {
+			continue
+		}
+		total++
+
+		fakeResp := suite.do(suite.fake, path)
+		realResp := suite.do(suite.real, path)
+		name := "GET " + path
+		if !suite.Equal(realResp.Code, fakeResp.Code, "%s 状态码应该相同", name) {
+			continue
+		}
+		fakeType, _ := responseType(fakeResp)
+		realType, realJSON := responseType(realResp)
+		if !suite.Equal(realType, fakeType, "%s 内容类型应该相同", name) || !realJSON {
+			continue
+		}
+		suite.Equal(jsonShape(realResp.Body.Bytes()), jsonShape(fakeResp.Body.Bytes()), "%s JSON结构应该相同", name)
+	}
+	suite.Greater(total, 50, "应该比较足够数量的查询接口")
+	suite.T().Logf("共比较%d个查询接口", total)
+}
+
+func (suite *ContractTestSuite) do(s contractServer, path string) *httptest.ResponseRecorder {
+	ctx, cancel := context.WithTimeout(context.Background(), contractRequestTimeout)
+	defer cancel()
+	req := httptest.NewRequestWithContext(ctx, http.MethodGet, path, nil)
+	req.Header.Set("Authorization", s.token)
+	req.Header.Set("Accept", "application/json")
+	w := httptest.NewRecorder()
+	s.engine.ServeHTTP(w, req)
+	return w
+}
+
+// contractPath 将路由中的路径参数替换为演示数据对应的取值, 流式接口和通配路由返回false
+func contractPath(route string) (string, bool) {
+	for _, s := range contractSkipped {
+		if strings.Contains(route, s) {
+			return "", false
+		}
+	}
+	parts := strings.Split(route, "/")
+	for i, p := range parts {
+		switch {
+		case strings.HasPrefix(p, "*"):
+			return "", false
+		case strings.HasPrefix(p, ":"):
+			v, ok := contractPathValues[p[1:]]
+			if !ok {
+				v = "1"
+			}
+			parts[i] = v
+		}
+	}
+	return strings.Join(parts, "/"), true
+}
+
+// responseType 返回响应的媒体类型以及是否为JSON
+func responseType(w *httptest.ResponseRecorder) (string, bool) {
+	mt, _, _ := mime.ParseMediaType(w.Header().Get("Content-Type"))
+	return mt, mt == "application/json" && w.Body.Len() > 0
+}
+
+// jsonShape 返回JSON的结构描述, 只保留字段名和值的类型, 数组合并全部元素的结构
+func jsonShape(data []byte) string {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return "invalid: " + err.Error()
+	}
+	return shapeOf(v)
+}
+
+func shapeOf(v any) string {
+	switch x := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "bool"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		seen := make(map[string]bool)
+		var elems []string
+		for _, e := range x {
+			s := shapeOf(e)
+			if !seen[s] {
+				seen[s] = true
+				elems = append(elems, s)
+			}
+		}
+		sort.Strings(elems)
+		return "[" + strings.Join(elems, "|") + "]"
+	case map[string]any:
+		keys := make([]string, 0, len(x))
+		for k := range x {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		fields := make([]string, len(keys))
+		for i, k := range keys {
+			fields[i] = fmt.Sprintf("%q:%s", k, shapeOf(x[k]))
+		}
+		return "{" + strings.Join(fields, ",") + "}"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+func TestContractTestSuite(t *testing.T) {
+	suite.Run(t, new(ContractTestSuite))
+}
@@ -6,49 +6,156 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+	"gorm.io/gorm"
 
 	handler "gin-artweb/internal/handler/customer"
+	custmodel "gin-artweb/internal/model/customer"
+	sysmodel "gin-artweb/internal/model/system"
 	custrepo "gin-artweb/internal/repository/customer"
+	sysrepo "gin-artweb/internal/repository/system"
 	custsvc "gin-artweb/internal/service/customer"
-	"gin-artweb/internal/shared/common"
+	syssvc "gin-artweb/internal/service/system"
+	"gin-artweb/internal/shared/auth"
+	"gin-artweb/internal/shared/config"
 	"gin-artweb/internal/shared/log"
 	"gin-artweb/internal/shared/middleware"
+	"gin-artweb/pkg/captcha"
 	"gin-artweb/pkg/crypto"
 )
 
-func newCustomerRouter(
-	router *gin.RouterGroup,
-	init *common.Initialize,
-	loggers *log.Loggers,
-) {
+type CustomerRouter struct {
+	Api          *custsvc.ApiService
+	Preference   *custsvc.PreferenceService
+	Deactivation *custsvc.DeactivationService // 各模块通过Register注册归属于用户的记录, 停用用户时报告和转移
+}
+
+// customerModule 用户权限模块
+type customerModule struct{ baseModule }
+
+func (*customerModule) Name() string { return "customer" }
+
+func (*customerModule) Requires() []string { return []string{"system"} }
+
+// Permissions 登录和刷新令牌时还没有有效的访问令牌
+func (*customerModule) Permissions() []string {
+	return []string{
+		"POST /api/v1/login",
+		"POST /api/v1/refresh/token",
+	}
+}
+
+func (*customerModule) Routes(mc *ModuleContext) { mc.Customer = newCustomerRouter(mc) }
+
+// newCustomerRouter 加载用户权限模块
+func newCustomerRouter(mc *ModuleContext) *CustomerRouter {
+	router, init, loggers := mc.Router, mc.Init, mc.Loggers
+	// 返回已注册路由生成的API列表, 供按需同步API目录使用
+	routes := func() []custmodel.ApiModel { return RouteApis(mc.Engine.Routes()) }
 	secSettings := custsvc.SecuritySettings{
 		MaxFailedAttempts: init.Conf.Security.Login.MaxFailedAttempts,
 		LockDuration:      time.Duration(init.Conf.Security.Login.LockMinutes) * time.Minute,
 		PasswordStrength:  init.Conf.Security.Password.StrengthLevel,
+		DisableLocalLogin: init.Conf.Security.OIDC != nil && init.Conf.Security.OIDC.DisableLocalLogin,
+		CaptchaThreshold:  init.Conf.Security.Login.CaptchaThreshold,
+	}
+	captchaTTL := time.Duration(init.Conf.Security.Login.CaptchaSeconds) * time.Second
+	if captchaTTL <= 0 {
+		captchaTTL = 2 * time.Minute
 	}
 
 	apiRepo := custrepo.NewApiRepo(loggers.Data, init.DB, init.DBTimeout, init.Enforcer)
 	menuRepo := custrepo.NewMenuRepo(loggers.Data, init.DB, init.DBTimeout, init.Enforcer)
 	buttonRepo := custrepo.NewButtonRepo(loggers.Data, init.DB, init.DBTimeout, init.Enforcer)
 	roleRepo := custrepo.NewRoleRepo(loggers.Data, init.DB, init.DBTimeout, init.Enforcer)
+	refRepo := custrepo.NewReferenceRepo(loggers.Data, init.DB, init.DBTimeout, init.Enforcer)
 	userRepo := custrepo.NewUserRepo(loggers.Data, init.DB, init.DBTimeout)
 	recordRepo := custrepo.NewLoginRecordRepo(loggers.Data, init.DB, init.DBTimeout,
 		time.Duration(init.Conf.Security.Login.LockMinutes)*time.Minute,
 		time.Duration(init.Conf.Security.Token.AccessMinutes*2)*time.Minute,
 		init.Conf.Security.Login.MaxFailedAttempts,
 	)
+	casbinModelRepo := custrepo.NewCasbinModelRepo(loggers.Data, init.DB, init.DBTimeout)
+	signingKeyRepo := custrepo.NewSigningKeyRepo(loggers.Data, init.DB, init.DBTimeout)
+	identityRepo := custrepo.NewUserIdentityRepo(loggers.Data, init.DB, init.DBTimeout)
+	sessionRepo := custrepo.NewUserSessionRepo(loggers.Data, init.DB, init.DBTimeout)
+	preferenceRepo := custrepo.NewUserPreferenceRepo(loggers.Data, init.DB, init.DBTimeout)
+	deptRepo := custrepo.NewDepartmentRepo(loggers.Data, init.DB, init.DBTimeout)
+	groupRepo := custrepo.NewUserGroupRepo(loggers.Data, init.DB, init.DBTimeout, init.Enforcer)
+
+	mc.System.Search.Register(
+		syssvc.NewDBSearchSource("user", "用户", "GET /api/v1/customer/user",
+			[]string{"username"}, userRepo.ListModel,
+			func(m custmodel.UserModel) syssvc.SearchDocument {
+				return syssvc.SearchDocument{ID: m.ID, Title: m.Username}
+			},
+		),
+		syssvc.NewDBSearchSource("menu", "菜单", "GET /api/v1/customer/menu",
+			[]string{"name", "path", "descr"}, menuRepo.ListModel,
+			func(m custmodel.MenuModel) syssvc.SearchDocument {
+				return syssvc.SearchDocument{ID: m.ID, Title: m.Name, Description: m.Descr}
+			},
+		),
+	)
 
 	apiService := custsvc.NewApiService(loggers.Biz, apiRepo)
 	menuService := custsvc.NewMenuService(loggers.Biz, apiRepo, menuRepo)
 	buttonService := custsvc.NewButtonService(loggers.Biz, apiRepo, menuRepo, buttonRepo)
-	roleService := custsvc.NewRoleService(loggers.Biz, apiRepo, menuRepo, buttonRepo, roleRepo)
+	roleService := custsvc.NewRoleService(loggers.Biz, apiRepo, menuRepo, buttonRepo, roleRepo, init.Tx)
+	bulkDeleteService := custsvc.NewBulkDeleteService(loggers.Biz, roleRepo, menuRepo, refRepo)
+	sessionService := custsvc.NewSessionService(loggers.Biz, sessionRepo, init.JwtConf, mc.System.Alerts)
+	preferenceService := custsvc.NewPreferenceService(loggers.Biz, preferenceRepo, init.Conf.Preference)
+	captchaService := custsvc.NewCaptchaService(loggers.Biz, captcha.NewMemoryStore(captchaTTL), captchaTTL)
+	hasher, hErr := newPasswordHasher(init.Conf.Security.Password.Hash)
+	if hErr != nil {
+		loggers.Server.Error("系统初始化创建密码哈希失败", zap.Error(hErr))
+		panic(hErr)
+	}
 	userService := custsvc.NewUserService(
 		loggers.Biz,
 		roleRepo, userRepo,
 		recordRepo,
-		crypto.NewBcryptHasher(12), init.JwtConf, secSettings)
+		hasher, init.JwtConf, secSettings, init.Outbox,
+		sessionService, captchaService, init.Tx)
+	passwordHashService := custsvc.NewPasswordHashService(loggers.Biz, userRepo, hasher)
+	impersonationService := custsvc.NewImpersonationService(
+		loggers.Biz, userRepo,
+		sysrepo.NewAuditRecordRepo(loggers.Data, init.DB, init.DBTimeout),
+		sessionService, time.Duration(init.Conf.Security.Token.ImpersonateMinutes)*time.Minute)
+	deactivationService := custsvc.NewDeactivationService(loggers.Biz, userRepo, sessionService, init.Tx)
+	webhookRepo := sysrepo.NewWebhookSubscriptionRepo(loggers.Data, init.DB, init.DBTimeout)
+	deactivationService.Register(custsvc.NewDBOwnershipSource("webhook", "webhook订阅",
+		webhookRepo.ListModel, webhookRepo.UpdateModel,
+		func(m sysmodel.WebhookSubscriptionModel) custsvc.OwnedObject {
+			return custsvc.OwnedObject{ID: m.ID, Name: m.Name}
+		},
+	))
+	deptService := custsvc.NewDepartmentService(loggers.Biz, deptRepo, userRepo, roleRepo)
+	groupService := custsvc.NewUserGroupService(loggers.Biz, groupRepo, roleRepo, userRepo)
+	casbinModelService := custsvc.NewCasbinModelService(loggers.Biz, casbinModelRepo, init.Enforcer)
+	signingKeyService := custsvc.NewSigningKeyService(loggers.Biz, signingKeyRepo, init.JwtConf)
+	rbacService := custsvc.NewRbacService(
+		loggers.Biz,
+		apiService, menuService, buttonService, roleService,
+		casbinModelService)
+	oidcService, oErr := custsvc.NewOidcService(
+		loggers.Biz,
+		userService, roleRepo, userRepo, identityRepo,
+		init.Conf.Security.OIDC)
+	if oErr != nil {
+		loggers.Server.Error("系统初始化加载OIDC身份提供方失败", zap.Error(oErr))
+		panic(oErr)
+	}
 
 	ctx := context.Background()
+	if pErr := signingKeyService.LoadKeys(ctx); pErr != nil {
+		loggers.Server.Error("系统初始化加载JWT签名密钥时失败", zap.Error(pErr))
+		panic(pErr)
+	}
+	if pErr := casbinModelService.LoadActiveModel(ctx); pErr != nil {
+		loggers.Server.Error("系统初始化加载Casbin模型时失败", zap.Error(pErr))
+		panic(pErr)
+	}
 	if pErr := apiService.LoadApiPolicy(ctx); pErr != nil {
 		loggers.Server.Error("系统初始化加载API策略时失败", zap.Error(pErr))
 		panic(pErr)
@@ -65,6 +172,10 @@ func newCustomerRouter(
 		loggers.Server.Error("系统初始化加载角色策略时失败", zap.Error(pErr))
 		panic(pErr)
 	}
+	if pErr := groupService.LoadUserGroupPolicy(ctx); pErr != nil {
+		loggers.Server.Error("系统初始化加载用户组策略时失败", zap.Error(pErr))
+		panic(pErr)
+	}
 
 	pPolicies, pErr := init.Enforcer.GetPolicy()
 	if pErr != nil {
@@ -80,24 +191,137 @@ func newCustomerRouter(
 	}
 	loggers.Service.Debug("已加载所有g策略", zap.Any("gPolicies", gPolicies))
 
-	apiHandler := handler.NewApiHandler(loggers.Service, apiService)
+	// 访问令牌校验时确认所属会话没有被终止
+	init.JwtConf.Sessions = sessionService
+	mc.AddCronJob("登录会话清理", "@every 1h", func() {
+		if rErr := sessionService.DeleteExpiredSession(context.Background()); rErr != nil {
+			loggers.Server.Error("定时清理已过期的登录会话失败", zap.Error(rErr))
+		}
+	})
+
+	mc.AddCronJob("停用用户到期永久停用", "@every 1h", func() {
+		if rErr := deactivationService.DisableExpired(context.Background()); rErr != nil {
+			loggers.Server.Error("定时永久停用宽限期已到的用户失败", zap.Error(rErr))
+		}
+	})
+
+	// 每分钟删除已停用的签名密钥并同步其他实例轮换的密钥
+	mc.AddCronJob("JWT签名密钥刷新", "@every 1m", func() {
+		if rErr := signingKeyService.RefreshKeys(context.Background()); rErr != nil {
+			loggers.Server.Error("定时刷新JWT签名密钥失败", zap.Error(rErr))
+		}
+	})
+	if spec := init.Conf.Security.Token.RotateCron; spec != "" {
+		mc.AddCronJob("JWT签名密钥轮换", spec, func() {
+			tokenTypes := []auth.TokenType{auth.TokenTypeAccess, auth.TokenTypeRefresh}
+			if _, rErr := signingKeyService.RotateKeys(context.Background(), tokenTypes, false, custsvc.SigningKeyOperatorSystem); rErr != nil {
+				loggers.Server.Error("定时轮换JWT签名密钥失败", zap.Error(rErr))
+			}
+		})
+	}
+
+	apiHandler := handler.NewApiHandler(loggers.Service, apiService, routes)
 	menuHandler := handler.NewMenuHandler(loggers.Service, menuService)
 	buttonHandler := handler.NewButtonHandler(loggers.Service, buttonService)
-	roleHandler := handler.NewRoleHandler(loggers.Service, roleService)
-	userHandler := handler.NewUserHandler(loggers.Service, userService)
+	bulkDeleteHandler := handler.NewBulkDeleteHandler(loggers.Service, bulkDeleteService)
+	roleHandler := handler.NewRoleHandler(loggers.Service, roleService, groupService)
+	userHandler := handler.NewUserHandler(loggers.Service, userService, deptService, init.JwtConf.Cookie)
+	casbinModelHandler := handler.NewCasbinModelHandler(loggers.Service, casbinModelService)
+	signingKeyHandler := handler.NewSigningKeyHandler(loggers.Service, signingKeyService)
+	rbacHandler := handler.NewRbacHandler(loggers.Service, rbacService)
+	oidcHandler := handler.NewOidcHandler(loggers.Service, oidcService, init.JwtConf.Cookie)
+	sessionHandler := handler.NewSessionHandler(loggers.Service, sessionService)
+	preferenceHandler := handler.NewPreferenceHandler(loggers.Service, preferenceService)
+	impersonationHandler := handler.NewImpersonationHandler(loggers.Service, impersonationService)
+	deactivationHandler := handler.NewDeactivationHandler(loggers.Service, deactivationService)
+	passwordHashHandler := handler.NewPasswordHashHandler(loggers.Service, passwordHashService)
+	deptHandler := handler.NewDepartmentHandler(loggers.Service, deptService)
+	groupHandler := handler.NewUserGroupHandler(loggers.Service, groupService)
+	captchaHandler := handler.NewCaptchaHandler(loggers.Service, captchaService)
 
 	router.POST("/v1/login", userHandler.Login)
-	router.POST("/v1/refresh/token", userHandler.RefreshToken)
+	// 刷新令牌接口单独按IP限流, 防止使用窃取的刷新令牌暴力尝试
+	refreshHandlers := []gin.HandlerFunc{userHandler.RefreshToken}
+	if tokenConf := init.Conf.Security.Token; tokenConf.RefreshRPS > 0 {
+		refreshHandlers = append([]gin.HandlerFunc{
+			middleware.IPBasedRateLimiterMiddleware(rate.Limit(tokenConf.RefreshRPS), max(tokenConf.RefreshBurst, 1)),
+		}, refreshHandlers...)
+	}
+	router.POST("/v1/refresh/token", refreshHandlers...)
+	router.POST("/v1/logout", userHandler.Logout)
+	router.GET("/v1/captcha", captchaHandler.GetCaptcha)
+	router.GET("/v1/auth/oidc/providers", oidcHandler.ListProviders)
+	router.GET("/v1/auth/oidc/login", oidcHandler.Login)
+	router.GET("/v1/auth/oidc/callback", oidcHandler.Callback)
 	appRouter := router.Group("/v1/customer")
-
-	appRouter.Use(middleware.JWTAuthMiddleware(init.JwtConf, loggers.Service))
+	// 个人接口只需登录, 见builtinLoginRoutes
 	appRouter.GET("/me/menu/tree", roleHandler.GetRoleMenuTree)
+	appRouter.GET("/me/roles", groupHandler.GetMyRoles)
 	appRouter.PATCH("/me/password", userHandler.PatchPassword)
+	appRouter.GET("/me/oidc", oidcHandler.ListMyIdentity)
+	appRouter.GET("/me/oidc/link", oidcHandler.LinkMyIdentity)
+	appRouter.DELETE("/me/oidc/:provider", oidcHandler.UnlinkMyIdentity)
+	appRouter.GET("/me/sessions", sessionHandler.ListMySession)
+	appRouter.DELETE("/me/sessions", sessionHandler.DeleteMyOtherSession)
+	appRouter.DELETE("/me/sessions/:session_id", sessionHandler.DeleteMySession)
+	appRouter.GET("/me/preferences", preferenceHandler.ListMyPreference)
+	appRouter.GET("/me/preferences/:namespace", preferenceHandler.GetMyPreference)
+	appRouter.PUT("/me/preferences/:namespace", preferenceHandler.SaveMyPreference)
+	appRouter.DELETE("/me/preferences/:namespace", preferenceHandler.DeleteMyPreference)
 
-	appRouter.Use(middleware.CasbinAuthMiddleware(init.Enforcer, loggers.Service))
 	apiHandler.LoadRouter(appRouter)
 	menuHandler.LoadRouter(appRouter)
 	buttonHandler.LoadRouter(appRouter)
 	roleHandler.LoadRouter(appRouter)
+	bulkDeleteHandler.LoadRouter(appRouter)
 	userHandler.LoadRouter(appRouter)
+	casbinModelHandler.LoadRouter(appRouter)
+	signingKeyHandler.LoadRouter(appRouter)
+	rbacHandler.LoadRouter(appRouter)
+	sessionHandler.LoadRouter(appRouter)
+	impersonationHandler.LoadRouter(appRouter)
+	deactivationHandler.LoadRouter(appRouter)
+	passwordHashHandler.LoadRouter(appRouter)
+	deptHandler.LoadRouter(appRouter)
+	groupHandler.LoadRouter(appRouter)
+
+	return &CustomerRouter{
+		Api:          apiService,
+		Preference:   preferenceService,
+		Deactivation: deactivationService,
+	}
+}
+
+// newPasswordHasher 按配置创建密码哈希
+func newPasswordHasher(conf config.PasswordHashConfig) (*crypto.PasswordHasher, error) {
+	return crypto.NewPasswordHasher(crypto.PasswordHashOptions{
+		Algorithm:     conf.Algorithm,
+		BcryptCost:    conf.BcryptCost,
+		Argon2Memory:  conf.Argon2Memory,
+		Argon2Time:    conf.Argon2Time,
+		Argon2Threads: conf.Argon2Threads,
+		ScryptLogN:    conf.ScryptLogN,
+		ScryptR:       conf.ScryptR,
+		ScryptP:       conf.ScryptP,
+	})
+}
+
+// PasswordHashReport 统计用户密码哈希使用的算法, 用于命令行查看仍使用旧算法或旧参数的用户数量
+func PasswordHashReport(
+	ctx context.Context,
+	db *gorm.DB,
+	timeouts *config.DBTimeout,
+	loggers *log.Loggers,
+	conf *config.SystemConf,
+) (*custmodel.PasswordHashReportOut, error) {
+	hasher, err := newPasswordHasher(conf.Security.Password.Hash)
+	if err != nil {
+		return nil, err
+	}
+	svc := custsvc.NewPasswordHashService(loggers.Biz, custrepo.NewUserRepo(loggers.Data, db, timeouts), hasher)
+	out, rErr := svc.Report(ctx)
+	if rErr != nil {
+		return nil, rErr
+	}
+	return out, nil
 }
@@ -0,0 +1,215 @@
+package routers
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	custmodel "gin-artweb/internal/model/customer"
+	mdsmodel "gin-artweb/internal/model/mds"
+	monmodel "gin-artweb/internal/model/mon"
+	oesmodel "gin-artweb/internal/model/oes"
+	resomodel "gin-artweb/internal/model/resource"
+	custrepo "gin-artweb/internal/repository/customer"
+	mdsrepo "gin-artweb/internal/repository/mds"
+	monrepo "gin-artweb/internal/repository/mon"
+	oesrepo "gin-artweb/internal/repository/oes"
+	resorepo "gin-artweb/internal/repository/resource"
+	"gin-artweb/internal/shared/common"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/log"
+	"gin-artweb/pkg/crypto"
+)
+
+// 演示模式使用的内存数据库和固定账号
+//
+// 共享缓存的内存数据库在最后一个连接关闭后释放, 调用方需要在服务运行期间保持至少一个连接
+const (
+	FakeDNS      = "file:gin-artweb-fake?mode=memory&cache=shared"
+	FakeAdmin    = "admin"
+	FakePassword = "Artweb@fake123"
+)
+
+// fakeTime 演示数据的创建和修改时间, 保证每次启动返回的数据完全相同
+var fakeTime = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// FakeUser 演示模式创建的用户, 密码均为FakePassword
+type FakeUser struct {
+	Username string
+	Role     string
+}
+
+// FakeUsers 演示模式创建的用户, 分别对应默认的三个角色
+var FakeUsers = []FakeUser{
+	{Username: FakeAdmin, Role: custmodel.RoleNameAdmin},
+	{Username: "operator", Role: custmodel.RoleNameOperator},
+	{Username: "viewer", Role: custmodel.RoleNameViewer},
+}
+
+// FakeConfig 将配置调整为演示模式
+//
+// 数据库替换为共享缓存的内存数据库, 单个连接串行执行避免内存数据库的表锁冲突;
+// 关闭敏感字段加密和单点登录, 不依赖外部的密钥和身份提供方
+func FakeConfig(conf *config.SystemConf) {
+	conf.Fake = true
+
+	db := *conf.Database
+	db.Type = database.DialectSQLite
+	db.Dns = FakeDNS
+	db.MaxIdleConns = 1
+	db.MaxOpenConns = 1
+	db.ConnMaxLifetime = 0
+	db.ConnMaxIdleTime = 0
+	db.Retry = config.DBRetryConfig{}
+	conf.Database = &db
+
+	conf.Security.Encryption.Enable = false
+	conf.Security.OIDC = nil
+}
+
+// SeedFake 初始化演示模式的数据
+//
+// 需要在NewRouter注册全部路由之后调用, 数据库为空时按固定顺序创建角色、用户、部门、主机、
+// 程序包和集群, 每次启动的记录ID和内容都相同; 用户不需要修改初始密码即可访问全部接口
+func SeedFake(
+	ctx context.Context,
+	r *gin.Engine,
+	init *common.Initialize,
+	loggers *log.Loggers,
+) error {
+	if _, rErr := Bootstrap(ctx, r, init, loggers, FakeAdmin, FakePassword); rErr != nil {
+		return rErr
+	}
+
+	userRepo := custrepo.NewUserRepo(loggers.Data, init.DB, init.DBTimeout)
+	roleRepo := custrepo.NewRoleRepo(loggers.Data, init.DB, init.DBTimeout, init.Enforcer)
+	deptRepo := custrepo.NewDepartmentRepo(loggers.Data, init.DB, init.DBTimeout)
+
+	dept := custmodel.DepartmentModel{Name: "运维部", Sort: 1, Descr: "演示部门"}
+	if err := deptRepo.CreateModel(ctx, &dept); err != nil {
+		return err
+	}
+	team := custmodel.DepartmentModel{Name: "交易运维组", Sort: 1, Descr: "演示部门", ParentID: &dept.ID, Parent: &dept}
+	if err := deptRepo.CreateModel(ctx, &team); err != nil {
+		return err
+	}
+
+	hashed, err := crypto.NewBcryptHasher(12).Hash(ctx, FakePassword)
+	if err != nil {
+		return err
+	}
+	for _, fu := range FakeUsers {
+		if fu.Username == FakeAdmin {
+			data := map[string]any{"must_change_password": false, "department_id": dept.ID}
+			if err := userRepo.UpdateModel(ctx, data, "username = ?", FakeAdmin); err != nil {
+				return err
+			}
+			continue
+		}
+		role, err := roleRepo.GetModel(ctx, nil, "name = ?", fu.Role)
+		if err != nil {
+			return errors.WrapIfWithDetails(err, "查询演示用户的角色失败", "role", fu.Role)
+		}
+		um := custmodel.UserModel{
+			Username:     fu.Username,
+			Password:     hashed,
+			IsActive:     true,
+			IsStaff:      true,
+			RoleID:       role.ID,
+			DepartmentID: &team.ID,
+		}
+		if err := userRepo.CreateModel(ctx, &um); err != nil {
+			return err
+		}
+	}
+
+	// 主机地址使用文档保留网段, 演示模式下不会连接到真实的机器
+	hostRepo := resorepo.NewHostRepo(loggers.Data, init.DB, init.DBTimeout, nil)
+	hosts := []resomodel.HostModel{
+		{Name: "mon-01", Label: "mon", SSHIP: "192.0.2.11", SSHPort: 22, SSHUser: "artweb", PyPath: "/usr/bin/python3", Remark: "演示主机"},
+		{Name: "mds-01", Label: "mds", SSHIP: "192.0.2.21", SSHPort: 22, SSHUser: "artweb", PyPath: "/usr/bin/python3", Remark: "演示主机"},
+		{Name: "oes-01", Label: "oes", SSHIP: "192.0.2.31", SSHPort: 22, SSHUser: "artweb", PyPath: "/usr/bin/python3", Remark: "演示主机"},
+	}
+	for i := range hosts {
+		if err := hostRepo.CreateModel(ctx, &hosts[i]); err != nil {
+			return err
+		}
+	}
+
+	monNode := monmodel.MonNodeModel{
+		Name:        "mon-01",
+		DeployPath:  "/opt/artweb/mon",
+		OutportPath: "/opt/artweb/mon/outport",
+		JavaHome:    "/usr/lib/jvm/java-17",
+		URL:         "http://192.0.2.11:8080",
+		HostID:      hosts[0].ID,
+		Health:      "unknown",
+	}
+	if err := monrepo.NewMonNodeRepo(loggers.Data, init.DB, init.DBTimeout).CreateModel(ctx, &monNode); err != nil {
+		return err
+	}
+
+	pkgRepo := resorepo.NewPackageRepo(loggers.Data, init.DB, init.DBTimeout)
+	pkgs := []resomodel.PackageModel{
+		{Label: "mds", StorageFilename: "fake-mds-1.0.0.tar.gz", OriginFilename: "mds-1.0.0.tar.gz", Version: "1.0.0"},
+		{Label: "oes", StorageFilename: "fake-oes-1.0.0.tar.gz", OriginFilename: "oes-1.0.0.tar.gz", Version: "1.0.0"},
+		{Label: "xcounter", StorageFilename: "fake-xcounter-1.0.0.tar.gz", OriginFilename: "xcounter-1.0.0.tar.gz", Version: "1.0.0"},
+	}
+	for i := range pkgs {
+		pkgs[i].UploadedAt = fakeTime
+		if err := pkgRepo.CreateModel(ctx, &pkgs[i]); err != nil {
+			return err
+		}
+	}
+
+	mdsColony := mdsmodel.MdsColonyModel{
+		ColonyNum:     "01",
+		ExtractedName: "mds-1.0.0",
+		IsEnable:      true,
+		PackageID:     pkgs[0].ID,
+		MonNodeID:     monNode.ID,
+	}
+	if err := mdsrepo.NewMdsColonyRepo(loggers.Data, init.DB, init.DBTimeout).CreateModel(ctx, &mdsColony); err != nil {
+		return err
+	}
+	oesColony := oesmodel.OesColonyModel{
+		SystemType:    "STK",
+		ColonyNum:     "02",
+		ExtractedName: "oes-1.0.0",
+		IsEnable:      true,
+		PackageID:     pkgs[1].ID,
+		XCounterID:    pkgs[2].ID,
+		MonNodeID:     monNode.ID,
+	}
+	if err := oesrepo.NewOesColonyRepo(loggers.Data, init.DB, init.DBTimeout).CreateModel(ctx, &oesColony); err != nil {
+		return err
+	}
+
+	return fixFakeTime(ctx, init.DB)
+}
+
+// fixFakeTime 将演示数据的创建和修改时间统一为fakeTime
+func fixFakeTime(ctx context.Context, db *gorm.DB) error {
+	for _, m := range []any{
+		&custmodel.CasbinModelModel{},
+		&custmodel.ApiModel{},
+		&custmodel.RoleModel{},
+		&custmodel.UserModel{},
+		&custmodel.DepartmentModel{},
+		&resomodel.HostModel{},
+		&monmodel.MonNodeModel{},
+		&mdsmodel.MdsColonyModel{},
+		&oesmodel.OesColonyModel{},
+	} {
+		err := db.WithContext(ctx).Model(m).Where("1 = 1").
+			UpdateColumns(map[string]any{"created_at": fakeTime, "updated_at": fakeTime}).Error
+		if err != nil {
+			return errors.WrapIf(err, "更新演示数据时间失败")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,520 @@
+//go:build hardening
+
+// 加固测试: 根据注册的路由和swagger文档中的参数定义生成异常输入(超长字符串、错误类型、边界数值、畸形JSON)
+// 逐个请求全部接口, 断言服务端只返回结构化的4xx错误, 不出现5xx和panic
+//
+// 运行方式: go test -tags hardening -run TestHardening ./internal/routers/
+package routers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/robfig/cron/v3"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"gin-artweb/docs"
+	"gin-artweb/internal/model"
+	"gin-artweb/internal/shared/auth"
+	"gin-artweb/internal/shared/common"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/log"
+	"gin-artweb/internal/shared/rpc"
+)
+
+const (
+	hardeningRoleID   = 1
+	hardeningValidID  = "1"
+	hardeningLongSize = 64 * 1024
+)
+
+// swagger文档中加固测试需要的部分
+type swaggerDoc struct {
+	Paths       map[string]map[string]swaggerOperation `json:"paths"`
+	Definitions map[string]swaggerSchema               `json:"definitions"`
+}
+
+type swaggerOperation struct {
+	Consumes   []string           `json:"consumes"`
+	Parameters []swaggerParameter `json:"parameters"`
+}
+
+type swaggerParameter struct {
+	Name   string         `json:"name"`
+	In     string         `json:"in"`
+	Type   string         `json:"type"`
+	Schema *swaggerSchema `json:"schema"`
+}
+
+type swaggerSchema struct {
+	Ref        string                   `json:"$ref"`
+	Type       string                   `json:"type"`
+	Properties map[string]swaggerSchema `json:"properties"`
+	Items      *swaggerSchema           `json:"items"`
+	AllOf      []swaggerSchema          `json:"allOf"`
+}
+
+// fuzzCase 单个异常输入用例
+type fuzzCase struct {
+	name        string
+	method      string
+	path        string
+	query       url.Values
+	contentType string
+	body        []byte
+}
+
+var swaggerPathParam = regexp.MustCompile(`\{([^}]+)\}`)
+
+type HardeningTestSuite struct {
+	suite.Suite
+	engine   *gin.Engine
+	jwtConf  *auth.JWTConfig
+	token    string
+	observed *observer.ObservedLogs
+	doc      swaggerDoc
+	rpc      *rpc.Server
+}
+
+func (suite *HardeningTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+
+	conf := &config.SystemConf{
+		Server: &config.ServerConfig{
+			Host:    "127.0.0.1",
+			Port:    8621,
+			Rate:    config.RateLimitConfig{RPS: math.MaxFloat64, Burst: math.MaxInt32},
+			Timeout: config.TimeoutConfig{Request: 30, Shutdown: 5},
+		},
+		Database: &config.DBConf{
+			Type:         "sqlite",
+			Dns:          filepath.Join(suite.T().TempDir(), "hardening.db"),
+			ReadTimeout:  5,
+			WriteTimeout: 5,
+			ListTimeout:  10,
+		},
+		Log:  &config.LogConfig{Level: "error"},
+		CORS: &config.AllowConfig{AllowOrigins: []string{"*"}},
+		Security: &config.SecurityConfig{
+			Token: config.TokenConfig{
+				AccessMinutes:  10,
+				RefreshMinutes: 10,
+				AccessMethod:   "HS256",
+				RefreshMethod:  "HS512",
+			},
+			Login:    config.LoginSecurityConfig{MaxFailedAttempts: 5, LockMinutes: 30},
+			Password: config.PasswordConfig{StrengthLevel: 3},
+		},
+		SSH:       &config.SSHConfig{Timeout: 1},
+		Upload:    &config.UploadConfig{MaxPkgSize: 1, MaxScriptSize: 1, MaxConfSize: 1},
+		Analytics: &config.AnalyticsConfig{},
+		Monitor:   &config.MonitorConfig{QueryTimeout: 1},
+		Deploy:    &config.DeployConfig{Mode: config.DeployModeEmbedded},
+	}
+
+	db, err := database.NewGormDB(conf.Database, database.NewGormConfig(nil))
+	suite.Require().NoError(err, "初始化数据库应该成功")
+	suite.Require().NoError(model.DBAutoMigrate(db), "迁移数据库应该成功")
+
+	enf, err := auth.NewCasbinEnforcer()
+	suite.Require().NoError(err, "初始化Casbin应该成功")
+
+	suite.jwtConf = auth.NewJWTConfig(
+		10*time.Minute, 10*time.Minute, "HS256", "HS512",
+		[]byte("hardening-access"), []byte("hardening-refresh"),
+	)
+
+	// 服务层日志记录panic信息, 用于断言请求过程中未发生panic
+	core, observed := observer.New(zapcore.ErrorLevel)
+	suite.observed = observed
+	nop := zap.NewNop()
+	loggers := &log.Loggers{
+		Server:  nop,
+		Service: zap.New(core),
+		Biz:     nop,
+		Data:    nop,
+	}
+
+	init := &common.Initialize{
+		Conf: conf,
+		DB:   db,
+		DBTimeout: &config.DBTimeout{
+			ReadTimeout:  5 * time.Second,
+			WriteTimeout: 5 * time.Second,
+			ListTimeout:  10 * time.Second,
+		},
+		Enforcer: enf,
+		Crontab:  cron.New(),
+		JwtConf:  suite.jwtConf,
+		RPC:      rpc.NewServer(nop, suite.jwtConf, enf),
+	}
+	suite.rpc = init.RPC
+	suite.engine = NewRouter(loggers, init, "hardening", os.DirFS(suite.T().TempDir()))
+
+	// 授予测试角色全部接口的访问权限
+	role := auth.RoleToSubject(hardeningRoleID)
+	rules := make([][]string, 0)
+	for _, ri := range suite.engine.Routes() {
+		rules = append(rules, []string{role, ri.Path, ri.Method})
+	}
+	suite.Require().NoError(auth.AddPolicies(context.Background(), enf, rules))
+
+	suite.token, err = auth.NewAccessJWT(context.Background(), suite.jwtConf, auth.UserInfo{
+		UserID:   1,
+		Username: "hardening",
+		RoleID:   hardeningRoleID,
+		IsStaff:  true,
+	})
+	suite.Require().NoError(err, "生成访问令牌应该成功")
+
+	suite.Require().NoError(json.Unmarshal([]byte(docs.SwaggerInfo.ReadDoc()), &suite.doc), "解析swagger文档应该成功")
+	suite.Require().NotEmpty(suite.doc.Paths, "swagger文档应该包含接口")
+}
+
+// TestRouteAuth 模块路由只通过api路由组统一认证和鉴权
+func (suite *HardeningTestSuite) TestRouteAuth() {
+	// 没有授予任何接口权限的角色
+	noPermToken, err := auth.NewAccessJWT(context.Background(), suite.jwtConf, auth.UserInfo{
+		UserID:   2,
+		Username: "no-perm",
+		RoleID:   hardeningRoleID + 1,
+	})
+	suite.Require().NoError(err)
+	mustChangeToken, err := auth.NewAccessJWT(context.Background(), suite.jwtConf, auth.UserInfo{
+		UserID:             3,
+		Username:           "must-change",
+		RoleID:             hardeningRoleID,
+		MustChangePassword: true,
+	})
+	suite.Require().NoError(err)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		token  string
+		check  func(code int) bool
+	}{
+		{"公开接口无需令牌", http.MethodGet, "/api/v1/captcha", "", func(code int) bool { return code == http.StatusOK }},
+		{"登录接口无需令牌", http.MethodPost, "/api/v1/login", "", func(code int) bool { return code == http.StatusBadRequest }},
+		{"未登录访问模块接口", http.MethodGet, "/api/v1/resource/host", "", func(code int) bool { return code == http.StatusUnauthorized }},
+		{"未登录访问个人接口", http.MethodGet, "/api/v1/customer/me/roles", "", func(code int) bool { return code == http.StatusUnauthorized }},
+		{"无效令牌", http.MethodGet, "/api/v1/jobs/script", "invalid", func(code int) bool { return code == http.StatusUnauthorized }},
+		{"没有权限", http.MethodGet, "/api/v1/resource/host", noPermToken, func(code int) bool { return code == http.StatusForbidden }},
+		{"没有权限访问管理接口", http.MethodGet, "/api/v1/admin/migrations", noPermToken, func(code int) bool { return code == http.StatusForbidden }},
+		{"个人接口只需登录", http.MethodGet, "/api/v1/customer/me/roles", noPermToken, func(code int) bool {
+			return code != http.StatusUnauthorized && code != http.StatusForbidden
+		}},
+		{"修改初始密码前不能访问模块接口", http.MethodGet, "/api/v1/oes/colony", mustChangeToken, func(code int) bool { return code == http.StatusForbidden }},
+		{"修改初始密码前可以访问个人接口", http.MethodGet, "/api/v1/customer/me/roles", mustChangeToken, func(code int) bool {
+			return code != http.StatusUnauthorized && code != http.StatusForbidden
+		}},
+		{"有权限", http.MethodGet, "/api/v1/resource/host", suite.token, func(code int) bool { return code == http.StatusOK }},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.path, nil)
+		if tt.token != "" {
+			req.Header.Set("Authorization", tt.token)
+		}
+		w := httptest.NewRecorder()
+		suite.engine.ServeHTTP(w, req)
+		suite.True(tt.check(w.Code), "%s: %s %s 响应: %d %s", tt.name, tt.method, tt.path, w.Code, w.Body.String())
+	}
+}
+
+// TestRPCAPIs gRPC方法按对应的REST接口鉴权, 对应的接口必须是实际注册的路由
+func (suite *HardeningTestSuite) TestRPCAPIs() {
+	routes := make(map[string]bool)
+	for _, ri := range suite.engine.Routes() {
+		routes[ri.Method+" "+ri.Path] = true
+	}
+	apis := suite.rpc.APIs()
+	suite.Len(apis, 8, "全部模块启用时应该注册oes、mds、资源和脚本执行的gRPC方法")
+	for method, api := range apis {
+		if api == "" {
+			continue
+		}
+		suite.True(routes[api], "gRPC方法%s对应的REST接口%s未注册", method, api)
+	}
+}
+
+func (suite *HardeningTestSuite) TestFuzzEndpoints() {
+	// 以实际注册的路由为准, swagger文档只用于补充参数定义, 文档缺少的接口也会被测试
+	routes := suite.engine.Routes()
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Path != routes[j].Path {
+			return routes[i].Path < routes[j].Path
+		}
+		return routes[i].Method < routes[j].Method
+	})
+
+	total := 0
+	for _, ri := range routes {
+		if !strings.HasPrefix(ri.Path, "/api/") {
+			continue
+		}
+		swaggerPath := ginPathToSwagger(ri.Path)
+		op, ok := suite.doc.Paths[swaggerPath][strings.ToLower(ri.Method)]
+		if !ok {
+			op = routeOperation(ri.Method, swaggerPath)
+		}
+		for _, fc := range suite.buildCases(ri.Method, swaggerPath, op) {
+			total++
+			suite.assertStructured4xx(fc)
+		}
+	}
+	suite.T().Logf("共执行%d个异常输入用例", total)
+
+	for _, entry := range suite.observed.FilterMessage("panic recovered").All() {
+		suite.Failf("请求过程中发生panic", "%v", entry.ContextMap())
+	}
+}
+
+// ginPathToSwagger 将gin路由的:name和*name参数转换为swagger文档的{name}形式
+func ginPathToSwagger(p string) string {
+	segs := strings.Split(p, "/")
+	for i, seg := range segs {
+		if strings.HasPrefix(seg, ":") || strings.HasPrefix(seg, "*") {
+			segs[i] = "{" + seg[1:] + "}"
+		}
+	}
+	return strings.Join(segs, "/")
+}
+
+// routeOperation 文档中没有的接口按路由生成参数定义, 路径参数按ID处理, 写操作附加任意JSON请求体
+func routeOperation(method, swaggerPath string) swaggerOperation {
+	var op swaggerOperation
+	for _, m := range swaggerPathParam.FindAllStringSubmatch(swaggerPath, -1) {
+		op.Parameters = append(op.Parameters, swaggerParameter{Name: m[1], In: "path", Type: "integer"})
+	}
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
+		op.Parameters = append(op.Parameters, swaggerParameter{Name: "body", In: "body", Schema: &swaggerSchema{Type: "object"}})
+	}
+	return op
+}
+
+// buildCases 根据接口定义生成异常输入用例
+func (suite *HardeningTestSuite) buildCases(method, swaggerPath string, op swaggerOperation) []fuzzCase {
+	var cases []fuzzCase
+	newCase := func(name string) fuzzCase {
+		return fuzzCase{
+			name:   fmt.Sprintf("%s %s %s", method, swaggerPath, name),
+			method: method,
+			path:   fillPathParams(swaggerPath, nil),
+			query:  url.Values{},
+		}
+	}
+
+	var body *swaggerSchema
+	for _, param := range op.Parameters {
+		switch param.In {
+		case "path":
+			for name, v := range invalidValues(param.Type) {
+				fc := newCase(fmt.Sprintf("path[%s]=%s", param.Name, name))
+				fc.path = fillPathParams(swaggerPath, map[string]string{param.Name: v})
+				cases = append(cases, fc)
+			}
+		case "query", "formData":
+			for name, v := range invalidValues(param.Type) {
+				fc := newCase(fmt.Sprintf("%s[%s]=%s", param.In, param.Name, name))
+				if param.In == "query" {
+					fc.query.Set(param.Name, v)
+				} else {
+					form := url.Values{}
+					form.Set(param.Name, v)
+					fc.contentType = "application/x-www-form-urlencoded"
+					fc.body = []byte(form.Encode())
+				}
+				cases = append(cases, fc)
+			}
+		case "body":
+			body = suite.resolve(param.Schema)
+		}
+	}
+
+	if body == nil {
+		return cases
+	}
+
+	// 畸形JSON
+	for name, raw := range map[string]string{
+		"malformed": `{"name":`,
+		"array":     `[1,2,3]`,
+		"string":    `"hardening"`,
+		"null":      `null`,
+		"deep":      strings.Repeat("[", 10000) + strings.Repeat("]", 10000),
+	} {
+		fc := newCase("body=" + name)
+		fc.contentType = "application/json"
+		fc.body = []byte(raw)
+		cases = append(cases, fc)
+	}
+
+	// 逐个字段替换为异常值
+	fields := make([]string, 0, len(body.Properties))
+	for f := range body.Properties {
+		fields = append(fields, f)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		prop := suite.resolve(ptr(body.Properties[field]))
+		for name, v := range invalidJSONValues(prop.Type) {
+			bs, err := json.Marshal(map[string]any{field: v})
+			suite.Require().NoError(err)
+			fc := newCase(fmt.Sprintf("body[%s]=%s", field, name))
+			fc.contentType = "application/json"
+			fc.body = bs
+			cases = append(cases, fc)
+		}
+	}
+	return cases
+}
+
+// assertStructured4xx 发送请求并断言响应为结构化的4xx错误
+func (suite *HardeningTestSuite) assertStructured4xx(fc fuzzCase) {
+	target := fc.path
+	if len(fc.query) > 0 {
+		target += "?" + fc.query.Encode()
+	}
+	req := httptest.NewRequest(fc.method, target, bytes.NewReader(fc.body))
+	req.Header.Set("Authorization", suite.token)
+	if fc.contentType != "" {
+		req.Header.Set("Content-Type", fc.contentType)
+	}
+	w := httptest.NewRecorder()
+
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				suite.Failf("请求发生panic", "%s: %v", fc.name, r)
+			}
+		}()
+		suite.engine.ServeHTTP(w, req)
+	}()
+
+	suite.Less(w.Code, http.StatusInternalServerError, "%s: 不应返回5xx, 响应: %s", fc.name, w.Body.String())
+	if w.Code < http.StatusBadRequest {
+		return
+	}
+
+	var resp map[string]any
+	if !suite.NoError(json.Unmarshal(w.Body.Bytes(), &resp), "%s: 错误响应应为JSON, 响应: %s", fc.name, w.Body.String()) {
+		return
+	}
+	suite.Contains(resp, "code", "%s: 错误响应应包含code", fc.name)
+	suite.Contains(resp, "reason", "%s: 错误响应应包含reason", fc.name)
+	suite.Contains(resp, "msg", "%s: 错误响应应包含msg", fc.name)
+}
+
+// resolve 展开$ref与allOf引用
+func (suite *HardeningTestSuite) resolve(s *swaggerSchema) *swaggerSchema {
+	for depth := 0; s != nil && depth < 10; depth++ {
+		switch {
+		case s.Ref != "":
+			def, ok := suite.doc.Definitions[strings.TrimPrefix(s.Ref, "#/definitions/")]
+			if !ok {
+				return &swaggerSchema{}
+			}
+			s = &def
+		case len(s.AllOf) > 0:
+			s = &s.AllOf[0]
+		default:
+			return s
+		}
+	}
+	if s == nil {
+		return &swaggerSchema{}
+	}
+	return s
+}
+
+// fillPathParams 替换路径参数, 未指定的参数使用合法ID
+func fillPathParams(swaggerPath string, values map[string]string) string {
+	return swaggerPathParam.ReplaceAllStringFunc(swaggerPath, func(m string) string {
+		name := m[1 : len(m)-1]
+		if v, ok := values[name]; ok {
+			return url.PathEscape(v)
+		}
+		return hardeningValidID
+	})
+}
+
+// invalidValues 生成路径、查询及表单参数的异常值
+func invalidValues(typ string) map[string]string {
+	values := map[string]string{
+		"oversized": strings.Repeat("a", hardeningLongSize),
+		"control":   "\x00\x01\x02",
+		"unicode":   "�\U0001F600",
+	}
+	switch typ {
+	case "integer", "number":
+		values["string"] = "abc"
+		values["negative"] = "-1"
+		values["zero"] = "0"
+		values["uint32_overflow"] = "4294967296"
+		values["int64_overflow"] = "99999999999999999999999"
+		values["float"] = "1.5"
+	case "boolean":
+		values["string"] = "abc"
+		values["number"] = "2"
+	}
+	return values
+}
+
+// invalidJSONValues 生成请求体字段的异常值
+func invalidJSONValues(typ string) map[string]any {
+	values := map[string]any{
+		"object": map[string]any{"a": 1},
+	}
+	switch typ {
+	case "string":
+		values["oversized"] = strings.Repeat("a", hardeningLongSize)
+		values["number"] = 12345
+		values["bool"] = true
+		values["array"] = []string{"a"}
+	case "integer", "number":
+		values["string"] = "abc"
+		values["negative"] = -1
+		values["uint32_overflow"] = uint64(math.MaxUint32) + 1
+		values["huge"] = 1e300
+		values["float"] = 1.5
+		values["bool"] = true
+	case "boolean":
+		values["string"] = "abc"
+		values["number"] = 2
+	case "array":
+		values["string"] = "abc"
+		values["oversized"] = make([]int, hardeningLongSize)
+	default:
+		values["string"] = strings.Repeat("a", hardeningLongSize)
+		values["number"] = -1
+	}
+	return values
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestHardeningTestSuite(t *testing.T) {
+	suite.Run(t, new(HardeningTestSuite))
+}
@@ -2,35 +2,87 @@ package routers
 
 import (
 	"context"
+	"path/filepath"
 
-	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
 
 	handler "gin-artweb/internal/handler/jobs"
+	jobsmodel "gin-artweb/internal/model/jobs"
 	jobsrepo "gin-artweb/internal/repository/jobs"
+	custsvc "gin-artweb/internal/service/customer"
 	jobsvc "gin-artweb/internal/service/jobs"
-	"gin-artweb/internal/shared/common"
-	"gin-artweb/internal/shared/log"
-	"gin-artweb/internal/shared/middleware"
+	syssvc "gin-artweb/internal/service/system"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/pkg/storage"
 )
 
 type JobsRouter struct {
 	Script   *jobsvc.ScriptService
 	Record   *jobsvc.RecordService
+	Artifact *jobsvc.ArtifactService
 	Schedule *jobsvc.ScheduleService
+	Calendar *jobsvc.CalendarService
 }
 
-func NewJobsRouter(
-	router *gin.RouterGroup,
-	init *common.Initialize,
-	loggers *log.Loggers,
-) *JobsRouter {
+// jobsModule 脚本作业模块
+type jobsModule struct{ baseModule }
+
+func (*jobsModule) Name() string { return "jobs" }
+
+func (*jobsModule) Requires() []string { return []string{"system"} }
+
+func (*jobsModule) Routes(mc *ModuleContext) { mc.Jobs = NewJobsRouter(mc) }
+
+func NewJobsRouter(mc *ModuleContext) *JobsRouter {
+	router, init, loggers := mc.Router, mc.Init, mc.Loggers
 	scriptRepo := jobsrepo.NewScriptRepo(loggers.Data, init.DB, init.DBTimeout)
 	recordRepo := jobsrepo.NewRecordRepo(loggers.Data, init.DB, init.DBTimeout)
 	scheduleRepo := jobsrepo.NewScheduleRepo(loggers.Data, init.DB, init.DBTimeout)
+	holidayRepo := jobsrepo.NewTradingHolidayRepo(loggers.Data, init.DB, init.DBTimeout)
+	skipRepo := jobsrepo.NewScheduleSkipRepo(loggers.Data, init.DB, init.DBTimeout)
+	artifactRepo := jobsrepo.NewArtifactRepo(loggers.Data, init.DB, init.DBTimeout)
+
+	mc.System.Search.Register(syssvc.NewDBSearchSource("script", "脚本", "GET /api/v1/jobs/script",
+		[]string{"name", "descr", "project", "label"}, scriptRepo.ListModel,
+		func(m jobsmodel.ScriptModel) syssvc.SearchDocument {
+			return syssvc.SearchDocument{ID: m.ID, Title: m.Name, Description: m.Descr}
+		},
+	))
+
+	scriptService := jobsvc.NewScriptService(loggers.Biz, scriptRepo, init.Conf.Jobs)
+	recordService := jobsvc.NewScriptRecordService(loggers.Biz, scriptRepo, recordRepo, init.Conf.Jobs, init.Outbox)
+	var artifactConf config.ArtifactConfig
+	if init.Conf.Jobs != nil {
+		artifactConf = init.Conf.Jobs.Artifacts
+	}
+	artifactService := newArtifactService(mc, artifactRepo, artifactConf)
+	recordService.SetArtifactService(artifactService)
+	if artifactConf.KeepDays > 0 {
+		mc.AddCronJob("执行产物清理", "0 4 * * *", func() {
+			artifactService.CleanupExpired(context.Background())
+		})
+	}
+	calendarService := jobsvc.NewCalendarService(loggers.Biz, holidayRepo, skipRepo)
+	scheduleService := jobsvc.NewScheduleService(loggers.Biz, scriptRepo, scheduleRepo, recordService, calendarService, mc.System.Maintenance, init.Crontab)
 
-	scriptService := jobsvc.NewScriptService(loggers.Biz, scriptRepo)
-	recordService := jobsvc.NewScriptRecordService(loggers.Biz, scriptRepo, recordRepo)
-	scheduleService := jobsvc.NewScheduleService(loggers.Biz, scriptRepo, scheduleRepo, recordService, init.Crontab)
+	// 计划任务以创建用户的身份执行, 转移后重新加载接收用户的计划任务
+	mc.Customer.Deactivation.Register(custsvc.NewDBOwnershipSource("schedule", "计划任务",
+		scheduleRepo.ListModel, scheduleRepo.UpdateModel,
+		func(m jobsmodel.ScheduleModel) custsvc.OwnedObject {
+			return custsvc.OwnedObject{ID: m.ID, Name: m.Name}
+		},
+	).AfterReassign(func(ctx context.Context, to string) error {
+		if rErr := scheduleService.ReloadScheduleJobs(ctx, map[string]any{"username = ?": to}); rErr != nil {
+			return rErr
+		}
+		return nil
+	}))
+
+	// 处理上次运行中断的脚本, 需要在加载计划任务之前完成
+	if rErr := recordService.RecoverInterrupted(context.Background()); rErr != nil {
+		loggers.Server.Error("处理中断的脚本执行记录失败", zap.Error(rErr))
+	}
+	init.OnShutdown(recordService.Shutdown)
 
 	// 加载计划任务
 	scheduleService.ReloadScheduleJobs(context.Background(), nil)
@@ -38,18 +90,42 @@ func NewJobsRouter(
 	scriptHandler := handler.NewScriptHandler(loggers.Service, scriptService, int64(init.Conf.Upload.MaxScriptSize)*1024*1024)
 	recordHandler := handler.NewScriptRecordHandler(loggers.Service, recordService)
 	scheduleHandler := handler.NewScheduleHandler(loggers.Service, scheduleService)
+	calendarHandler := handler.NewCalendarHandler(loggers.Service, calendarService)
+	artifactHandler := handler.NewArtifactHandler(loggers.Service, recordService, artifactService)
 
 	appRouter := router.Group("/v1/jobs")
-	appRouter.Use(middleware.JWTAuthMiddleware(init.JwtConf, loggers.Service))
-	appRouter.Use(middleware.CasbinAuthMiddleware(init.Enforcer, loggers.Service))
-
 	scriptHandler.LoadRouter(appRouter)
 	recordHandler.LoadRouter(appRouter)
 	scheduleHandler.LoadRouter(appRouter)
+	calendarHandler.LoadRouter(appRouter)
+	artifactHandler.LoadRouter(appRouter)
+	if init.RPC != nil {
+		handler.NewJobsRPCHandler(loggers.Service, recordService).LoadRPC(init.RPC)
+	}
 
 	return &JobsRouter{
 		Script:   scriptService,
 		Record:   recordService,
+		Artifact: artifactService,
 		Schedule: scheduleService,
+		Calendar: calendarService,
+	}
+}
+
+// newArtifactService 创建执行产物服务
+//
+// 程序包使用S3或SFTP存储时执行产物保存到同一存储, 否则保存在本地storage/artifacts目录
+func newArtifactService(
+	mc *ModuleContext,
+	artifactRepo *jobsrepo.ArtifactRepo,
+	conf config.ArtifactConfig,
+) *jobsvc.ArtifactService {
+	var store storage.Storage = storage.NewLocalStorage(filepath.Join(config.StorageDir, "artifacts"))
+	keyPrefix := ""
+	if mc.Resource != nil {
+		if _, ok := mc.Resource.Store.(storage.LocalPather); !ok {
+			store, keyPrefix = mc.Resource.Store, "artifacts"
+		}
 	}
+	return jobsvc.NewArtifactService(mc.Loggers.Biz, artifactRepo, store, keyPrefix, conf)
 }
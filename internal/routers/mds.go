@@ -1,39 +1,73 @@
 package routers
 
 import (
-	"github.com/gin-gonic/gin"
+	"context"
+
+	"go.uber.org/zap"
 
 	handler "gin-artweb/internal/handler/mds"
+	mdsmodel "gin-artweb/internal/model/mds"
 	mdsrepo "gin-artweb/internal/repository/mds"
 	mdssvc "gin-artweb/internal/service/mds"
-	"gin-artweb/internal/shared/common"
-	"gin-artweb/internal/shared/log"
-	"gin-artweb/internal/shared/middleware"
+	syssvc "gin-artweb/internal/service/system"
 )
 
-func newMdsRouter(
-	router *gin.RouterGroup,
-	init *common.Initialize,
-	loggers *log.Loggers,
-	jobsvc *JobsRouter,
-) {
+// mdsModule mds集群管理模块
+type mdsModule struct{ baseModule }
+
+func (*mdsModule) Name() string { return "mds" }
+
+func (*mdsModule) Requires() []string { return []string{"system", "resource", "jobs"} }
+
+func (*mdsModule) Routes(mc *ModuleContext) { newMdsRouter(mc) }
+
+func newMdsRouter(mc *ModuleContext) {
+	router, init, loggers := mc.Router, mc.Init, mc.Loggers
+	jobsvc, resosvc := mc.Jobs, mc.Resource
 	colonyRepo := mdsrepo.NewMdsColonyRepo(loggers.Data, init.DB, init.DBTimeout)
 	nodeRepo := mdsrepo.NewMdsNodeRepo(loggers.Data, init.DB, init.DBTimeout)
+	ingestSourceRepo := mdsrepo.NewMdsIngestSourceRepo(loggers.Data, init.DB, init.DBTimeout)
+	ingestRecordRepo := mdsrepo.NewMdsIngestRecordRepo(loggers.Data, init.DB, init.DBTimeout)
+	backfillRepo := mdsrepo.NewMdsBackfillRunRepo(loggers.Data, init.DB, init.DBTimeout)
 
-	colonyService := mdssvc.NewMdsColonyService(loggers.Biz, colonyRepo)
+	mc.System.Search.Register(syssvc.NewDBSearchSource("mds_colony", "MDS集群", "GET /api/v1/mds/colony",
+		[]string{"colony_num", "extracted_name"}, colonyRepo.ListModel,
+		func(m mdsmodel.MdsColonyModel) syssvc.SearchDocument {
+			return syssvc.SearchDocument{ID: m.ID, Title: m.ColonyNum, Description: m.ExtractedName}
+		},
+	))
+
+	colonyService := mdssvc.NewMdsColonyService(loggers.Biz, colonyRepo, resosvc.Pkg, init.Outbox)
 	nodeService := mdssvc.NewMdsNodeService(loggers.Biz, nodeRepo)
 	recordService := mdssvc.NewJobsService(loggers.Biz, jobsvc.Script, jobsvc.Record, jobsvc.Schedule)
 	taskService := mdssvc.NewMdsTaskExecutionInfoUsecase(loggers.Biz, recordService)
+	ingestService := mdssvc.NewMdsIngestService(loggers.Biz, ingestSourceRepo, ingestRecordRepo, resosvc.File, jobsvc.Calendar, init.Crontab)
+
+	// 处理中断的采集并加载定时采集任务
+	if rErr := ingestService.LoadIngestJobs(context.Background()); rErr != nil {
+		loggers.Server.Error("加载行情数据采集定时任务失败", zap.Error(rErr))
+	}
+	backfillService := mdssvc.NewMdsBackfillService(loggers.Biz, backfillRepo, colonyRepo, recordService, jobsvc.Calendar)
+
+	// 处理上次运行中断的数据回补
+	if rErr := backfillService.RecoverInterrupted(context.Background()); rErr != nil {
+		loggers.Server.Error("处理中断的mds数据回补失败", zap.Error(rErr))
+	}
 
 	colonyHandler := handler.NewMdsColonyService(loggers.Service, colonyService, taskService)
 	nodeHandler := handler.NewMdsNodeService(loggers.Service, nodeService)
 	confHandler := handler.NewMdsConfService(loggers.Service, int64(init.Conf.Upload.MaxConfSize)*1024*1024)
+	ingestHandler := handler.NewMdsIngestHandler(loggers.Service, ingestService)
+	backfillHandler := handler.NewMdsBackfillHandler(loggers.Service, backfillService)
 
 	appRouter := router.Group("/v1/mds")
-	appRouter.Use(middleware.JWTAuthMiddleware(init.JwtConf, loggers.Service))
-	appRouter.Use(middleware.CasbinAuthMiddleware(init.Enforcer, loggers.Service))
-
 	colonyHandler.LoadRouter(appRouter)
 	nodeHandler.LoadRouter(appRouter)
 	confHandler.LoadRouter(appRouter)
+	ingestHandler.LoadRouter(appRouter)
+	backfillHandler.LoadRouter(appRouter)
+	handler.NewMdsGraphQLHandler(loggers.Service, colonyService, taskService).LoadSchema(mc.System.GraphQL)
+	if init.RPC != nil {
+		handler.NewMdsRPCHandler(loggers.Service, colonyService, taskService).LoadRPC(init.RPC)
+	}
 }
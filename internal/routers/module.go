@@ -0,0 +1,190 @@
+package routers
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"gin-artweb/internal/model"
+	"gin-artweb/internal/shared/common"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/log"
+)
+
+// Module 业务模块
+//
+// 模块在modules中注册, NewRouter按注册顺序加载启用的模块,
+// system.yaml的modules.disabled中列出的模块不加载, 其接口和定时任务都不会注册
+type Module interface {
+	// Name 模块名称, 与modules.disabled的配置项对应
+	Name() string
+
+	// Requires 依赖的模块, 依赖的模块必须先注册, 依赖被停用时本模块不能启用
+	Requires() []string
+
+	// Migrations 模块自带的数据库迁移, 与程序内置迁移合并后按版本号执行
+	// 不论模块是否启用都会执行, 避免启用模块时数据库结构与程序不一致
+	Migrations() []database.Migration
+
+	// Permissions 模块中无需权限校验的接口, 格式为"方法 路径", 不导入API目录
+	Permissions() []string
+
+	// Routes 创建模块的服务并注册路由, 定时任务通过mc.AddCronJob注册
+	Routes(mc *ModuleContext)
+}
+
+// CronJob 模块注册的定时任务
+type CronJob struct {
+	Module string
+	Name   string
+	Spec   string
+}
+
+// ModuleContext 加载模块时的上下文
+type ModuleContext struct {
+	Router  *gin.RouterGroup // /api路由组
+	Engine  *gin.Engine
+	Init    *common.Initialize
+	Loggers *log.Loggers
+
+	// 模块提供给其他模块的服务, 模块未启用时为nil
+	System   *SystemRouter
+	Customer *CustomerRouter
+	Resource *ResourceRouter
+	Jobs     *JobsRouter
+
+	module   string
+	cronJobs []CronJob
+}
+
+// AddCronJob 注册定时任务, spec不合法时panic
+func (mc *ModuleContext) AddCronJob(name, spec string, run func()) {
+	if _, err := mc.Init.Crontab.AddFunc(spec, run); err != nil {
+		mc.Loggers.Server.Error(
+			"注册定时任务失败",
+			zap.String("module", mc.module),
+			zap.String("name", name),
+			zap.String("spec", spec),
+			zap.Error(err),
+		)
+		panic(err)
+	}
+	mc.cronJobs = append(mc.cronJobs, CronJob{Module: mc.module, Name: name, Spec: spec})
+}
+
+// CronJobs 已注册的定时任务
+func (mc *ModuleContext) CronJobs() []CronJob {
+	return slices.Clone(mc.cronJobs)
+}
+
+// modules 注册的业务模块, 被依赖的模块需要排在前面
+//
+// 系统模块的使用统计中间件注册在api路由组上, 因此必须最先加载
+var modules = []Module{
+	&systemModule{},
+	&customerModule{},
+	&resourceModule{},
+	&jobsModule{},
+	&monModule{},
+	&mdsModule{},
+	&oesModule{},
+}
+
+// coreModules 不能停用的模块
+var coreModules = []string{"system", "customer"}
+
+// enabledModules 按注册顺序返回启用的模块
+func enabledModules(conf *config.ModulesConfig) ([]Module, error) {
+	var disabled []string
+	if conf != nil {
+		disabled = conf.Disabled
+	}
+
+	names := make([]string, 0, len(modules))
+	for _, m := range modules {
+		names = append(names, m.Name())
+	}
+	for _, name := range disabled {
+		if !slices.Contains(names, name) {
+			return nil, fmt.Errorf("未知的模块: %s", name)
+		}
+		if slices.Contains(coreModules, name) {
+			return nil, fmt.Errorf("核心模块不能停用: %s", name)
+		}
+	}
+
+	enabled := make([]Module, 0, len(modules))
+	loaded := make(map[string]struct{}, len(modules))
+	for _, m := range modules {
+		if slices.Contains(disabled, m.Name()) {
+			continue
+		}
+		for _, dep := range m.Requires() {
+			if _, ok := loaded[dep]; !ok {
+				return nil, fmt.Errorf("模块%s依赖的模块%s未启用", m.Name(), dep)
+			}
+		}
+		enabled = append(enabled, m)
+		loaded[m.Name()] = struct{}{}
+	}
+	return enabled, nil
+}
+
+// loadModules 加载启用的模块
+func loadModules(mc *ModuleContext) {
+	enabled, err := enabledModules(mc.Init.Conf.Modules)
+	if err != nil {
+		mc.Loggers.Server.Error("模块配置错误", zap.Error(err))
+		panic(err)
+	}
+	names := make([]string, 0, len(enabled))
+	for _, m := range enabled {
+		mc.module = m.Name()
+		m.Routes(mc)
+		names = append(names, m.Name())
+	}
+	mc.module = ""
+	mc.Loggers.Server.Info(
+		"业务模块加载完成",
+		zap.Strings("modules", names),
+		zap.Int("cron_jobs", len(mc.cronJobs)),
+	)
+}
+
+// Migrations 程序内置迁移和全部模块自带的迁移
+func Migrations() []database.Migration {
+	ms := slices.Clone(model.Migrations)
+	for _, m := range modules {
+		ms = append(ms, m.Migrations()...)
+	}
+	return ms
+}
+
+// NewMigrator 创建全部迁移的执行器
+func NewMigrator(db *gorm.DB) *database.Migrator {
+	return database.NewMigrator(db, Migrations())
+}
+
+// modulePermissions 全部模块中无需权限校验的接口
+func modulePermissions() map[string]struct{} {
+	apis := make(map[string]struct{})
+	for _, m := range modules {
+		for _, api := range m.Permissions() {
+			apis[api] = struct{}{}
+		}
+	}
+	return apis
+}
+
+// baseModule 模块的默认实现, 没有依赖、迁移和公开接口
+type baseModule struct{}
+
+func (baseModule) Requires() []string { return nil }
+
+func (baseModule) Migrations() []database.Migration { return nil }
+
+func (baseModule) Permissions() []string { return nil }
@@ -1,30 +1,95 @@
 package routers
 
 import (
-	"github.com/gin-gonic/gin"
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
 
 	handler "gin-artweb/internal/handler/mon"
 	monrepo "gin-artweb/internal/repository/mon"
 	monsvc "gin-artweb/internal/service/mon"
-	"gin-artweb/internal/shared/common"
-	"gin-artweb/internal/shared/log"
-	"gin-artweb/internal/shared/middleware"
+	resosvc "gin-artweb/internal/service/resource"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/metrics"
 )
 
-func newMonRouter(
-	router *gin.RouterGroup,
-	init *common.Initialize,
-	loggers *log.Loggers,
-) {
+// monModule 监控模块
+type monModule struct{ baseModule }
+
+func (*monModule) Name() string { return "mon" }
+
+func (*monModule) Requires() []string { return []string{"system"} }
+
+func (*monModule) Routes(mc *ModuleContext) { newMonRouter(mc) }
+
+func newMonRouter(mc *ModuleContext) {
+	router, init, loggers := mc.Router, mc.Init, mc.Loggers
 	nodeRepo := monrepo.NewMonNodeRepo(loggers.Data, init.DB, init.DBTimeout)
 
 	nodeService := monsvc.NewMonNodeService(loggers.Biz, nodeRepo)
+	promService := monsvc.NewMonPromService(
+		loggers.Biz, nodeRepo, mc.System.Alerts,
+		time.Duration(init.Conf.Monitor.QueryTimeout)*time.Second,
+		newBreakerGroup(init, loggers, metrics.BreakerPrometheus),
+	)
+
+	// 定时同步mon节点健康状态
+	interval := init.Conf.Monitor.HealthSyncInterval
+	if interval > 0 && init.Conf.Deploy.FeatureEnabled(config.FeatureMonitorSync) {
+		mc.AddCronJob("mon节点健康状态同步", fmt.Sprintf("@every %ds", interval), func() {
+			if rErr := promService.SyncNodeHealth(context.Background()); rErr != nil {
+				loggers.Server.Error("同步mon节点健康状态失败", zap.Error(rErr))
+			}
+		})
+	}
+
+	// 主机指标由主机代理上报或通过SFTP采集, 资源模块未启用时只能查询已保存的指标
+	metricRepo := monrepo.NewHostMetricRepo(loggers.Data, init.DB, init.DBTimeout)
+	var fileService *resosvc.HostFileService
+	if mc.Resource != nil {
+		fileService = mc.Resource.File
+	}
+	metricService := monsvc.NewHostMetricService(loggers.Biz, metricRepo, nodeRepo, fileService, init.Conf.Monitor)
+	if conf := init.Conf.Monitor; conf != nil && conf.MetricInterval > 0 && fileService != nil {
+		mc.AddCronJob("mon节点主机指标采集", fmt.Sprintf("@every %ds", conf.MetricInterval), func() {
+			if rErr := metricService.CollectNodeHosts(context.Background()); rErr != nil {
+				loggers.Server.Error("采集mon节点主机指标失败", zap.Error(rErr))
+			}
+		})
+	}
+	mc.AddCronJob("主机指标聚合清理", "@every 5m", func() {
+		if rErr := metricService.Downsample(context.Background()); rErr != nil {
+			loggers.Server.Error("聚合清理主机指标失败", zap.Error(rErr))
+		}
+	})
+
+	// 主机代理由资源模块注册, 资源模块未启用时不检测
+	var agentHandler *handler.HostAgentHandler
+	if mc.Resource != nil {
+		agentService := mc.Resource.Agent
+		agentService.RegisterMetricRecorder(metricService)
+		agentHandler = handler.NewHostAgentHandler(loggers.Service, agentService)
+		if conf := init.Conf.Agent; conf != nil && conf.Enable {
+			mc.AddCronJob("主机代理失联检测", "@every 1m", func() {
+				if rErr := agentService.CheckStale(context.Background()); rErr != nil {
+					loggers.Server.Error("检测失联的主机代理失败", zap.Error(rErr))
+				}
+			})
+		}
+	}
 
 	nodeHandler := handler.NewNodeHandler(loggers.Service, nodeService)
+	promHandler := handler.NewPromHandler(loggers.Service, promService)
+	metricHandler := handler.NewHostMetricHandler(loggers.Service, metricService)
 
 	appRouter := router.Group("/v1/mon")
-	appRouter.Use(middleware.JWTAuthMiddleware(init.JwtConf, loggers.Service))
-	appRouter.Use(middleware.CasbinAuthMiddleware(init.Enforcer, loggers.Service))
-
 	nodeHandler.LoadRouter(appRouter)
+	promHandler.LoadRouter(appRouter)
+	metricHandler.LoadRouter(appRouter)
+	handler.NewMonGraphQLHandler(loggers.Service, nodeService).LoadSchema(mc.System.GraphQL)
+	if agentHandler != nil {
+		agentHandler.LoadRouter(appRouter)
+	}
 }
@@ -1,41 +1,166 @@
 package routers
 
 import (
-	"github.com/gin-gonic/gin"
+	"cmp"
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
 
 	handler "gin-artweb/internal/handler/oes"
+	oesmodel "gin-artweb/internal/model/oes"
 	oesrepo "gin-artweb/internal/repository/oes"
+	sysrepo "gin-artweb/internal/repository/system"
 	oessvc "gin-artweb/internal/service/oes"
-	"gin-artweb/internal/shared/common"
-	"gin-artweb/internal/shared/log"
-	"gin-artweb/internal/shared/middleware"
+	syssvc "gin-artweb/internal/service/system"
 )
 
-func newOesRouter(
-	router *gin.RouterGroup,
-	init *common.Initialize,
-	loggers *log.Loggers,
-	jobsvc *JobsRouter,
-) {
+// oesModule oes集群管理模块
+type oesModule struct{ baseModule }
+
+func (*oesModule) Name() string { return "oes" }
+
+func (*oesModule) Requires() []string { return []string{"system", "resource", "jobs"} }
+
+func (*oesModule) Routes(mc *ModuleContext) { newOesRouter(mc) }
+
+func newOesRouter(mc *ModuleContext) {
+	router, init, loggers := mc.Router, mc.Init, mc.Loggers
+	jobsvc, resosvc := mc.Jobs, mc.Resource
 	colonyRepo := oesrepo.NewOesColonyRepo(loggers.Data, init.DB, init.DBTimeout)
 	nodeRepo := oesrepo.NewOesNodeRepo(loggers.Data, init.DB, init.DBTimeout)
+	exportRepo := oesrepo.NewOesColonyExportRepo(loggers.Data, init.DB, init.DBTimeout)
+	workflowRepo := oesrepo.NewOesWorkflowRunRepo(loggers.Data, init.DB, init.DBTimeout)
+	templateRepo := oesrepo.NewOesConfTemplateRepo(loggers.Data, init.DB, init.DBTimeout)
+	driftRepo := oesrepo.NewOesColonyDriftRepo(loggers.Data, init.DB, init.DBTimeout)
+	reconcileRepo := oesrepo.NewOesReconcileReportRepo(loggers.Data, init.DB, init.DBTimeout)
+	runbookRepo := oesrepo.NewOesRunbookRepo(loggers.Data, init.DB, init.DBTimeout)
+	runbookExecRepo := oesrepo.NewOesRunbookExecRepo(loggers.Data, init.DB, init.DBTimeout)
+	slaRepo := oesrepo.NewOesTaskSlaRepo(loggers.Data, init.DB, init.DBTimeout)
+	slaResultRepo := oesrepo.NewOesTaskSlaResultRepo(loggers.Data, init.DB, init.DBTimeout)
+	catalogRepo := oesrepo.NewOesTaskCatalogRepo(loggers.Data, init.DB, init.DBTimeout)
+	linkRepo := oesrepo.NewOesLinkRepo(loggers.Data, init.DB, init.DBTimeout)
+	linkProbeRepo := oesrepo.NewOesLinkProbeRepo(loggers.Data, init.DB, init.DBTimeout)
+	auditRepo := sysrepo.NewAuditRecordRepo(loggers.Data, init.DB, init.DBTimeout)
+
+	mc.System.Search.Register(syssvc.NewDBSearchSource("oes_colony", "OES集群", "GET /api/v1/oes/colony",
+		[]string{"colony_num", "extracted_name", "system_type"}, colonyRepo.ListModel,
+		func(m oesmodel.OesColonyModel) syssvc.SearchDocument {
+			return syssvc.SearchDocument{ID: m.ID, Title: m.ColonyNum, Description: m.ExtractedName}
+		},
+	))
 
-	colonyService := oessvc.NewOesColonyService(loggers.Biz, colonyRepo)
+	colonyService := oessvc.NewOesColonyService(loggers.Biz, colonyRepo, resosvc.Pkg, init.Outbox)
+	// 绑定oes集群的计划任务在触发时注入集群上下文的环境变量
+	jobsvc.Schedule.SetColonyEnvResolver(colonyService.ColonyEnv)
 	nodeService := oessvc.NewOesNodeService(loggers.Biz, nodeRepo)
 	recordService := oessvc.NewRecordService(loggers.Biz, jobsvc.Script, jobsvc.Record, jobsvc.Schedule)
-	stkTaskUsecase := oessvc.NewStkTaskExecutionInfoUsecase(loggers.Biz, recordService)
-	crdaskUsecase := oessvc.NewCrdTaskExecutionInfoUsecase(loggers.Biz, recordService)
-	optTaskUsecase := oessvc.NewOptTaskExecutionInfoUsecase(loggers.Biz, recordService)
+	catalogService := oessvc.NewOesTaskCatalogService(loggers.Biz, catalogRepo)
+	taskUsecase := oessvc.NewColonyTaskExecutionInfoUsecase(loggers.Biz, recordService, catalogService)
+	exportService := oessvc.NewOesColonyExportService(loggers.Biz, exportRepo, colonyRepo, nodeRepo, recordService)
+	workflowService := oessvc.NewOesWorkflowService(loggers.Biz, workflowRepo, colonyRepo, recordService)
+	templateService := oessvc.NewOesConfTemplateService(loggers.Biz, templateRepo, colonyRepo, nodeRepo, auditRepo, resosvc.File)
+	driftService := oessvc.NewOesColonyDriftService(
+		loggers.Biz, driftRepo, colonyRepo, templateRepo, templateService, resosvc.File,
+		mc.System.Alerts, init.Conf.Drift,
+	)
+	reconcileService := oessvc.NewOesReconcileService(
+		loggers.Biz, reconcileRepo, colonyRepo, templateService, resosvc.File, jobsvc.Calendar, init.Conf.Reconcile,
+	)
+
+	runbookService := oessvc.NewOesRunbookService(loggers.Biz, runbookRepo, runbookExecRepo, colonyRepo, recordService)
+	slaService := oessvc.NewOesTaskSlaService(
+		loggers.Biz, slaRepo, slaResultRepo, colonyRepo, recordService, catalogService, jobsvc.Calendar,
+		mc.System.Alerts,
+	)
+	linkService := oessvc.NewOesLinkService(
+		loggers.Biz, linkRepo, linkProbeRepo, colonyRepo, resosvc.Host, jobsvc.Calendar,
+		mc.System.Alerts, init.Conf.Connectivity,
+	)
 
-	colonyHandler := handler.NewOesColonyService(loggers.Service, colonyService, stkTaskUsecase, crdaskUsecase, optTaskUsecase)
+	// 处理上次运行中断的检查单脚本步骤
+	if rErr := runbookService.RecoverInterrupted(context.Background()); rErr != nil {
+		loggers.Server.Error("处理中断的oes检查单失败", zap.Error(rErr))
+	}
+
+	// 定时检测集群配置漂移
+	if driftConf := init.Conf.Drift; driftConf != nil && driftConf.Enable {
+		mc.AddCronJob("oes集群配置漂移检测", cmp.Or(driftConf.Cron, "*/30 * * * *"), func() {
+			if rErr := driftService.CheckAllColonies(context.Background()); rErr != nil {
+				loggers.Server.Error("检测oes集群配置漂移失败", zap.Error(rErr))
+			}
+		})
+	}
+
+	// 定时对账柜台数据
+	if reconcileConf := init.Conf.Reconcile; reconcileConf != nil && reconcileConf.Enable {
+		mc.AddCronJob("oes柜台数据对账", cmp.Or(reconcileConf.Cron, "0 8 * * 1-5"), func() {
+			if rErr := reconcileService.ReconcileAllColonies(context.Background()); rErr != nil {
+				loggers.Server.Error("oes柜台数据对账失败", zap.Error(rErr))
+			}
+		})
+	}
+
+	// 定时检查日常任务SLA
+	if slaConf := init.Conf.Sla; slaConf != nil && slaConf.Enable {
+		mc.AddCronJob("oes任务SLA检查", cmp.Or(slaConf.Cron, "* * * * 1-5"), func() {
+			if rErr := slaService.CheckAllColonies(context.Background()); rErr != nil {
+				loggers.Server.Error("检查oes任务SLA失败", zap.Error(rErr))
+			}
+		})
+	}
+
+	// 定时探测集群组件之间的网络链路, 交易日开盘前对仍不可达的链路再次告警
+	if linkConf := init.Conf.Connectivity; linkConf != nil {
+		if linkConf.Interval > 0 {
+			mc.AddCronJob("oes链路探测", fmt.Sprintf("@every %ds", linkConf.Interval), func() {
+				if rErr := linkService.ProbeAll(context.Background()); rErr != nil {
+					loggers.Server.Error("探测oes链路失败", zap.Error(rErr))
+				}
+			})
+		}
+		if linkConf.PreOpenCron != "" {
+			mc.AddCronJob("oes开盘前链路检查", linkConf.PreOpenCron, func() {
+				if rErr := linkService.PreOpenCheck(context.Background()); rErr != nil {
+					loggers.Server.Error("开盘前检查oes链路失败", zap.Error(rErr))
+				}
+			})
+		}
+	}
+	mc.AddCronJob("oes链路探测记录清理", "@every 1h", func() {
+		if rErr := linkService.Cleanup(context.Background()); rErr != nil {
+			loggers.Server.Error("清理oes链路探测记录失败", zap.Error(rErr))
+		}
+	})
+
+	colonyHandler := handler.NewOesColonyService(loggers.Service, colonyService, nodeService, taskUsecase)
 	nodeHandler := handler.NewOesNodeService(loggers.Service, nodeService)
+	exportHandler := handler.NewOesColonyExportHandler(loggers.Service, exportService)
+	workflowHandler := handler.NewOesWorkflowHandler(loggers.Service, workflowService)
 	confHandler := handler.NewOesConfService(loggers.Service, int64(init.Conf.Upload.MaxConfSize)*1024*1024)
+	templateHandler := handler.NewOesConfTemplateHandler(loggers.Service, templateService)
+	driftHandler := handler.NewOesColonyDriftHandler(loggers.Service, driftService)
+	reconcileHandler := handler.NewOesReconcileHandler(loggers.Service, reconcileService)
+	runbookHandler := handler.NewOesRunbookHandler(loggers.Service, runbookService)
+	slaHandler := handler.NewOesTaskSlaHandler(loggers.Service, slaService)
+	catalogHandler := handler.NewOesTaskCatalogHandler(loggers.Service, catalogService)
+	linkHandler := handler.NewOesLinkHandler(loggers.Service, linkService)
 
 	appRouter := router.Group("/v1/oes")
-	appRouter.Use(middleware.JWTAuthMiddleware(init.JwtConf, loggers.Service))
-	appRouter.Use(middleware.CasbinAuthMiddleware(init.Enforcer, loggers.Service))
-
 	colonyHandler.LoadRouter(appRouter)
 	nodeHandler.LoadRouter(appRouter)
 	confHandler.LoadRouter(appRouter)
+	templateHandler.LoadRouter(appRouter)
+	driftHandler.LoadRouter(appRouter)
+	reconcileHandler.LoadRouter(appRouter)
+	exportHandler.LoadRouter(appRouter)
+	workflowHandler.LoadRouter(appRouter)
+	runbookHandler.LoadRouter(appRouter)
+	slaHandler.LoadRouter(appRouter)
+	catalogHandler.LoadRouter(appRouter)
+	linkHandler.LoadRouter(appRouter)
+	handler.NewOesGraphQLHandler(loggers.Service, colonyService, taskUsecase).LoadSchema(mc.System.GraphQL)
+	if init.RPC != nil {
+		handler.NewOesRPCHandler(loggers.Service, colonyService, taskUsecase).LoadRPC(init.RPC)
+	}
 }
@@ -1,40 +1,82 @@
 package routers
 
 import (
+	"cmp"
+	"context"
 	"encoding/base64"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
 	"time"
 
-	"github.com/gin-gonic/gin"
+	"github.com/pkg/sftp"
 	"go.uber.org/zap"
 	"golang.org/x/crypto/ssh"
 
 	handler "gin-artweb/internal/handler/resource"
+	resomodel "gin-artweb/internal/model/resource"
 	resorepo "gin-artweb/internal/repository/resource"
+	sysrepo "gin-artweb/internal/repository/system"
 	resosvc "gin-artweb/internal/service/resource"
+	syssvc "gin-artweb/internal/service/system"
 	"gin-artweb/internal/shared/common"
+	"gin-artweb/internal/shared/config"
 	"gin-artweb/internal/shared/log"
+	"gin-artweb/internal/shared/metrics"
 	"gin-artweb/internal/shared/middleware"
 	"gin-artweb/internal/shared/shell"
+	"gin-artweb/pkg/breaker"
+	"gin-artweb/pkg/storage"
 )
 
 type ResourceRouter struct {
-	Host *resosvc.HostService
-	Pkg  *resosvc.PackageService
+	Host  *resosvc.HostService
+	Pkg   *resosvc.PackageService
+	File  *resosvc.HostFileService
+	Agent *resosvc.HostAgentService // mon模块定时检测失联的主机
+	Store storage.Storage           // 程序包文件存储, jobs模块在使用S3或SFTP时保存执行产物
 }
 
-func newResourceRouter(
-	router *gin.RouterGroup,
-	init *common.Initialize,
-	loggers *log.Loggers,
-) *ResourceRouter {
+// resourceModule 主机和程序包资源模块
+type resourceModule struct{ baseModule }
+
+func (*resourceModule) Name() string { return "resource" }
+
+func (*resourceModule) Requires() []string { return []string{"system"} }
+
+// Permissions 主机代理接口使用代理令牌认证
+func (*resourceModule) Permissions() []string {
+	return []string{
+		"POST /api/v1/agent/register",
+		"POST /api/v1/agent/heartbeat",
+	}
+}
+
+func (*resourceModule) Routes(mc *ModuleContext) { mc.Resource = newResourceRouter(mc) }
+
+func newResourceRouter(mc *ModuleContext) *ResourceRouter {
+	router, init, loggers := mc.Router, mc.Init, mc.Loggers
+	// 单主机内嵌部署和演示模式下缺少ssh密钥不影响平台启动, 仅在连接主机时报错
+	deploy := init.Conf.Deploy
+	requireKeys := !deploy.IsEmbedded() && !init.Conf.Fake
 	signers, err := shell.GetSignersFromDefaultKeys()
 	if err != nil {
-		loggers.Server.Error("初始化加载ssh密钥失败", zap.Error(err))
-		panic("初始化加载ssh密钥失败")
+		if requireKeys {
+			loggers.Server.Error("初始化加载ssh密钥失败", zap.Error(err))
+			panic("初始化加载ssh密钥失败")
+		}
+		loggers.Server.Warn("初始化加载ssh密钥失败", zap.Error(err))
 	}
 	if len(signers) == 0 {
-		loggers.Server.Error("没有可用的SSH密钥")
-		panic("没有可用的SSH密钥")
+		if requireKeys {
+			loggers.Server.Error("没有可用的SSH密钥")
+			panic("没有可用的SSH密钥")
+		}
+		loggers.Server.Warn("没有可用的SSH密钥")
 	}
 	pubKeys := make([]string, len(signers))
 	for i, signer := range signers {
@@ -43,24 +85,198 @@ func newResourceRouter(
 	}
 
 	sshTimeout := time.Duration(init.Conf.SSH.Timeout) * time.Second
-	hostRepo := resorepo.NewHostRepo(loggers.Data, init.DB, init.DBTimeout)
+	sshBreakers := newBreakerGroup(init, loggers, metrics.BreakerSSH)
+	hostRepo := resorepo.NewHostRepo(loggers.Data, init.DB, init.DBTimeout, sshBreakers)
 	pkgRepo := resorepo.NewPackageRepo(loggers.Data, init.DB, init.DBTimeout)
 
-	hostService := resosvc.NewHostService(loggers.Biz, hostRepo, sshTimeout, ssh.PublicKeys(signers...), pubKeys)
-	pkgService := resosvc.NewPackageService(loggers.Biz, pkgRepo)
+	mc.System.Search.Register(syssvc.NewDBSearchSource("package", "程序包", "GET /api/v1/resource/package",
+		[]string{"origin_filename", "label", "version"}, pkgRepo.ListModel,
+		func(m resomodel.PackageModel) syssvc.SearchDocument {
+			return syssvc.SearchDocument{ID: m.ID, Title: m.OriginFilename, Description: m.Label + " " + m.Version}
+		},
+	))
+
+	hostService := resosvc.NewHostService(
+		loggers.Biz, hostRepo, sshTimeout, ssh.PublicKeys(signers...), pubKeys,
+		deploy.FeatureEnabled(config.FeatureRemoteHost),
+	)
+	storageConf := init.Conf.Storage
+	if storageConf == nil {
+		storageConf = &config.StorageConfig{Type: storage.TypeLocal}
+	}
+	pkgStore := newPackageStorage(init, loggers, storageConf, ssh.PublicKeys(signers...), sshTimeout, sshBreakers)
+	// 程序包使用S3或SFTP存储时备份文件保存到同一存储
+	if _, ok := pkgStore.(storage.LocalPather); !ok {
+		mc.System.Backup.SetStore(pkgStore, "backups")
+	}
+	pkgService := resosvc.NewPackageService(
+		loggers.Biz, pkgRepo, pkgStore,
+		time.Duration(storageConf.PresignMinutes)*time.Minute,
+	)
+
+	uploadConf := init.Conf.Upload
+	uploadRepo := resorepo.NewPackageUploadRepo(loggers.Data, init.DB, init.DBTimeout)
+	uploadService := resosvc.NewPackageUploadService(
+		loggers.Biz, uploadRepo, pkgService,
+		filepath.Join(config.StorageDir, "uploads"),
+		int64(max(uploadConf.MaxChunkedPkgSize, uploadConf.MaxPkgSize))*1024*1024,
+		int64(cmp.Or(uploadConf.ChunkSize, 16))*1024*1024,
+		time.Duration(cmp.Or(uploadConf.SessionTTL, 24))*time.Hour,
+	)
+	mc.AddCronJob("分片上传清理", "@every 10m", func() {
+		uploadService.CleanupExpired(context.Background())
+	})
+
+	sessionRepo := resorepo.NewTerminalSessionRepo(loggers.Data, init.DB, init.DBTimeout)
+	auditRepo := sysrepo.NewAuditRecordRepo(loggers.Data, init.DB, init.DBTimeout)
+	terminalService := resosvc.NewTerminalService(
+		loggers.Biz, hostRepo, sessionRepo, auditRepo, sshTimeout,
+		ssh.PublicKeys(signers...), init.Conf.Terminal,
+		filepath.Join(config.StorageDir, "terminal"),
+	)
+	init.OnShutdown(terminalService.Shutdown)
+	ruleRepo := resorepo.NewHostPathRuleRepo(loggers.Data, init.DB, init.DBTimeout)
+	fileService := resosvc.NewHostFileService(
+		loggers.Biz, hostRepo, ruleRepo, init.Enforcer, auditRepo, sshTimeout, ssh.PublicKeys(signers...),
+	)
+
+	agentRepo := resorepo.NewHostAgentRepo(loggers.Data, init.DB, init.DBTimeout)
+	agentService := resosvc.NewHostAgentService(
+		loggers.Biz, agentRepo, hostService, mc.System.Alerts, init.Conf.Agent,
+	)
+
+	mergeService := resosvc.NewHostMergeService(loggers.Biz, hostRepo, agentRepo, auditRepo, init.Tx)
+
+	watchdogRepo := resorepo.NewWatchdogRepo(loggers.Data, init.DB, init.DBTimeout)
+	watchdogEventRepo := resorepo.NewWatchdogEventRepo(loggers.Data, init.DB, init.DBTimeout)
+	watchdogService := resosvc.NewWatchdogService(
+		loggers.Biz, watchdogRepo, watchdogEventRepo, agentRepo, hostService,
+		mc.System.Alerts, init.Conf.Watchdog,
+	)
+	if conf := init.Conf.Watchdog; conf != nil && conf.Interval > 0 {
+		mc.AddCronJob("进程守护检查", fmt.Sprintf("@every %ds", conf.Interval), func() {
+			if rErr := watchdogService.CheckAll(context.Background()); rErr != nil {
+				loggers.Server.Error("检查进程守护失败", zap.Error(rErr))
+			}
+		})
+	}
 
 	hostHandler := handler.NewHostHandler(loggers.Service, hostService)
-	pkgHandler := handler.NewPackageHandler(loggers.Service, pkgService, int64(init.Conf.Upload.MaxPkgSize)*1024*1024)
+	mergeHandler := handler.NewHostMergeHandler(loggers.Service, mergeService)
+	pkgHandler := handler.NewPackageHandler(loggers.Service, pkgService, int64(uploadConf.MaxPkgSize)*1024*1024)
+	uploadHandler := handler.NewPackageUploadHandler(loggers.Service, uploadService)
+	terminalHandler := handler.NewTerminalHandler(loggers.Service, terminalService)
+	fileHandler := handler.NewHostFileHandler(
+		loggers.Service, fileService, int64(cmp.Or(uploadConf.MaxHostFileSize, 100))*1024*1024,
+	)
+	watchdogHandler := handler.NewWatchdogHandler(loggers.Service, watchdogService)
 
 	appRouter := router.Group("/v1/resource")
-	appRouter.Use(middleware.JWTAuthMiddleware(init.JwtConf, loggers.Service))
-	appRouter.Use(middleware.CasbinAuthMiddleware(init.Enforcer, loggers.Service))
-
 	hostHandler.LoadRouter(appRouter)
+	mergeHandler.LoadRouter(appRouter)
 	pkgHandler.LoadRouter(appRouter)
+	uploadHandler.LoadRouter(appRouter)
+	terminalHandler.LoadRouter(appRouter)
+	fileHandler.LoadRouter(appRouter)
+	watchdogHandler.LoadRouter(appRouter)
+	handler.NewResourceGraphQLHandler(loggers.Service, hostService, pkgService).LoadSchema(mc.System.GraphQL)
+	if init.RPC != nil {
+		handler.NewResourceRPCHandler(loggers.Service, hostService, pkgService).LoadRPC(init.RPC)
+	}
+
+	if conf := init.Conf.Agent; conf != nil && conf.Enable {
+		tokenEnv := cmp.Or(conf.TokenEnv, "AGENT_TOKEN")
+		token := os.Getenv(tokenEnv)
+		if token == "" {
+			loggers.Server.Warn("未设置主机代理令牌, 拒绝全部代理请求", zap.String("env", tokenEnv))
+		}
+		agentHandler := handler.NewHostAgentHandler(loggers.Service, agentService)
+		// 代理接口不使用用户令牌, 见builtinPublicRoutes
+		agentRouter := router.Group("/v1/agent")
+		agentRouter.Use(middleware.AgentTokenMiddleware(token))
+		agentHandler.LoadRouter(agentRouter)
+	}
 
 	return &ResourceRouter{
-		Host: hostService,
-		Pkg:  pkgService,
+		Host:  hostService,
+		Pkg:   pkgService,
+		File:  fileService,
+		Agent: agentService,
+		Store: pkgStore,
+	}
+}
+
+// newPackageStorage 根据配置创建程序包文件存储
+func newPackageStorage(
+	init *common.Initialize,
+	loggers *log.Loggers,
+	conf *config.StorageConfig,
+	keyAuth ssh.AuthMethod,
+	sshTimeout time.Duration,
+	sshBreakers *breaker.Group,
+) storage.Storage {
+	switch conf.Type {
+	case "", storage.TypeLocal:
+		return storage.NewLocalStorage(filepath.Join(config.StorageDir, "packages"))
+	case storage.TypeS3:
+		store, err := storage.NewS3Storage(storage.S3Config{
+			Endpoint:  conf.S3.Endpoint,
+			Region:    conf.S3.Region,
+			Bucket:    conf.S3.Bucket,
+			Prefix:    conf.S3.Prefix,
+			AccessKey: os.Getenv("S3_ACCESS_KEY"),
+			SecretKey: os.Getenv("S3_SECRET_KEY"),
+			PathStyle: conf.S3.PathStyle,
+		}, &http.Client{Timeout: 30 * time.Minute})
+		if err != nil {
+			loggers.Server.Error("初始化S3存储失败", zap.Error(err))
+			panic(err)
+		}
+		loggers.Server.Info(
+			"程序包使用S3存储",
+			zap.String("endpoint", conf.S3.Endpoint),
+			zap.String("bucket", conf.S3.Bucket),
+		)
+		return store
+	case storage.TypeSFTP:
+		auths := []ssh.AuthMethod{keyAuth}
+		if password := os.Getenv("SFTP_PASSWORD"); password != "" {
+			auths = append(auths, ssh.Password(password))
+		}
+		sftpConf := conf.SFTP
+		sftpBreaker := sshBreakers.Get(net.JoinHostPort(sftpConf.Host, strconv.Itoa(cmp.Or(sftpConf.Port, 22))))
+		store := storage.NewSFTPStorage(sftpConf.Root, func(ctx context.Context) (*sftp.Client, io.Closer, error) {
+			var sshClient *ssh.Client
+			err := sftpBreaker.Execute(func() (err error) {
+				sshClient, err = shell.NewSSHClient(
+					ctx, sftpConf.Host, uint16(sftpConf.Port), sftpConf.User,
+					auths, sftpConf.UseKnownHosts, sshTimeout,
+				)
+				return err
+			})
+			if err != nil {
+				return nil, nil, err
+			}
+			client, err := sftp.NewClient(sshClient)
+			if err != nil {
+				sshClient.Close()
+				return nil, nil, err
+			}
+			return client, sshClient, nil
+		})
+		init.OnShutdown(func(context.Context) {
+			if err := store.Close(); err != nil {
+				loggers.Server.Warn("关闭SFTP存储连接失败", zap.Error(err))
+			}
+		})
+		loggers.Server.Info(
+			"程序包使用SFTP存储",
+			zap.String("host", sftpConf.Host),
+			zap.String("root", sftpConf.Root),
+		)
+		return store
+	default:
+		loggers.Server.Error("不支持的存储类型", zap.String("type", conf.Type))
+		panic("不支持的存储类型: " + conf.Type)
 	}
 }
@@ -1,31 +1,86 @@
 package routers
 
 import (
+	"context"
 	"fmt"
+	"io/fs"
 	"net/http"
 	"net/http/pprof"
-	"path/filepath"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
 	"github.com/patrickmn/go-cache"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"go.uber.org/zap"
 	"golang.org/x/time/rate"
 
 	"gin-artweb/docs"
+	"gin-artweb/internal/shared/auth"
 	"gin-artweb/internal/shared/common"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/i18n"
 	"gin-artweb/internal/shared/log"
+	"gin-artweb/internal/shared/metrics"
 	"gin-artweb/internal/shared/middleware"
+	"gin-artweb/pkg/breaker"
 )
 
-func NewRouter(loggers *log.Loggers, init *common.Initialize, version, htmlDir string) *gin.Engine {
+// builtinPublicRoutes 登录前访问或使用其他方式认证的接口, 始终无需鉴权
+var builtinPublicRoutes = []string{
+	"POST /api/v1/login",
+	"POST /api/v1/refresh/token",
+	"POST /api/v1/logout", // 访问令牌过期后仍需退出, 由接口自行解析令牌终止会话
+	"GET /api/v1/captcha",
+	"/api/v1/auth/oidc/*",
+	"/api/v1/agent/*", // 使用主机代理令牌认证
+}
+
+// builtinLoginRoutes 个人接口, 只需登录不校验接口权限
+var builtinLoginRoutes = []string{
+	"/api/v1/customer/me/*",
+}
+
+// NewRouter 创建公共端口的路由引擎, htmlFS为前端文件, 根目录下包含index.html、favicon.ico和static目录
+func NewRouter(loggers *log.Loggers, init *common.Initialize, version string, htmlFS fs.FS) *gin.Engine {
 	r := gin.New()
 
+	// gin.Context作为context.Context使用时返回请求的取消信号和截止时间,
+	// 客户端断开后传入服务和仓库的上下文随之取消, 进行中的数据库操作不再继续执行
+	r.ContextWithFallback = true
+
 	// 注册链路追踪处理中间件
 	r.Use(middleware.TracingMiddleware(loggers.Service))
 
+	// 注册请求日志中间件, 各层日志自动附加trace_id、路由、请求URI和用户ID
+	r.Use(middleware.RequestLogMiddleware(loggers.Server, loggers.Service, loggers.Biz, loggers.Data))
+
+	// 注册接口指标中间件
+	r.Use(middleware.MetricsMiddleware())
+
+	// 注册请求语言中间件, 已登录用户优先使用界面偏好中选择的语言
+	i18nValidator, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		panic("gin默认校验器不是validator.Validate")
+	}
+	if err := i18n.RegisterValidator(i18nValidator); err != nil {
+		loggers.Server.Error("注册校验消息翻译失败", zap.Error(err))
+		panic(err)
+	}
+	var userLang func(ctx context.Context, userID uint32) string
+	r.Use(middleware.LanguageMiddleware(func(ctx context.Context, userID uint32) string {
+		if userLang == nil {
+			return ""
+		}
+		return userLang(ctx, userID)
+	}))
+
 	// host请求头防护中间件
 	if init.Conf.Security.HostGuard.Enable {
 		r.Use(middleware.HostGuard(loggers.Service, init.Conf.Security.HostGuard.TrustedHosts...))
@@ -37,6 +92,17 @@ func NewRouter(loggers *log.Loggers, init *common.Initialize, version, htmlDir s
 	// 注册跨域请求处理中间件
 	r.Use(middleware.CorsMiddleware(init.Conf.CORS))
 
+	// 注册响应压缩中间件
+	if compressConf := init.Conf.Server.Compress; compressConf.Enable {
+		r.Use(middleware.CompressMiddleware(middleware.CompressOptions{
+			Encodings: compressConf.Encodings,
+			MinSize:   compressConf.MinSize,
+			Level:     compressConf.Level,
+			Types:     compressConf.Types,
+			Exclude:   compressConf.Exclude,
+		}))
+	}
+
 	// 注册时间戳处理中间件,用于防御重放攻击
 	if init.Conf.Security.Timestamp.CheckTimestamp {
 		// 从配置中获取时间戳容差参数，如果没有配置则使用默认值
@@ -66,29 +132,42 @@ func NewRouter(loggers *log.Loggers, init *common.Initialize, version, htmlDir s
 	// 注册统一异常处理中间件
 	r.Use(middleware.ErrorMiddleware(loggers.Service))
 
+	// 限制请求体大小, 上传表单超出内存缓冲上限的部分直接写入临时文件, 避免超大请求耗尽内存
+	bodyConf := init.Conf.Server.Body
+	r.MaxMultipartMemory = megabytes(bodyConf.MaxMemory, 8)
+	r.Use(middleware.BodyLimitMiddleware(megabytes(bodyConf.Default, 4), uploadBodyLimit(init.Conf)))
+
 	// 注册超时处理中间件
-	r.Use(middleware.TimeoutMiddleware(time.Duration(init.Conf.Server.Timeout.Request) * time.Second))
+	timeoutConf := init.Conf.Server.Timeout
+	routeTimeouts := make([]middleware.RouteTimeout, 0, len(timeoutConf.Routes))
+	for _, rt := range timeoutConf.Routes {
+		routeTimeouts = append(routeTimeouts, middleware.RouteTimeout{
+			Prefix:  rt.Prefix,
+			Timeout: time.Duration(rt.Request) * time.Second,
+		})
+	}
+	r.Use(middleware.TimeoutMiddleware(time.Duration(timeoutConf.Request)*time.Second, routeTimeouts...))
 
-	// 配置静态文件处理
-	htmlPath := filepath.Join(htmlDir, "index.html")
-	r.GET("/", func(c *gin.Context) {
-		c.File(htmlPath)
-	})
-	faviconPath := filepath.Join(htmlDir, "favicon.ico")
-	r.GET("/favicon.ico", func(c *gin.Context) {
-		c.File(faviconPath)
-	})
-	staticPath := filepath.Join(htmlDir, "static")
-	r.Static("/static", staticPath)
+	// 前端页面、静态文件和Swagger文档设置安全响应头
+	pageRouter := r.Group("")
+	var pageMiddleware []gin.HandlerFunc
+	headersConf := init.Conf.Security.Headers
+	if headersConf.Enable {
+		pageMiddleware = append(pageMiddleware, middleware.SecurityHeadersMiddleware(headersConf))
+		pageRouter.Use(pageMiddleware...)
+		if strings.HasPrefix(headersConf.CSPReportURI, "/") {
+			r.POST(headersConf.CSPReportURI, middleware.CSPReportHandler(loggers.Service))
+		}
+	}
 
-	// 健康检查接口
-	r.GET("/health", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{
-			"code": http.StatusOK,
-			"msg":  time.Now().Format(time.DateTime),
-			"data": nil,
-		})
-	})
+	// 配置前端页面和静态文件, 未匹配的前端路由返回入口页面
+	registerFrontend(r, pageRouter, htmlFS, init.Conf.Server.Static, pageMiddleware...)
+
+	// 健康检查接口, 数据库不可用时返回降级状态
+	r.GET("/health", healthHandler(init.DBHealth))
+
+	// 就绪检查接口, 数据库不可用时返回503, 供负载均衡摘除流量
+	r.GET("/ready", readyHandler(init.DBHealth))
 
 	// 版本信息接口
 	r.GET("/version", func(c *gin.Context) {
@@ -106,23 +185,174 @@ func NewRouter(loggers *log.Loggers, init *common.Initialize, version, htmlDir s
 		docs.SwaggerInfo.Version = version
 		docs.SwaggerInfo.Host = fmt.Sprintf("%s:%d", init.Conf.Server.Host, init.Conf.Server.Port)
 		docs.SwaggerInfo.Schemes = []string{"http", "https"}
-		r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+		pageRouter.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 	}
 
-	r.GET("/metrics", gin.WrapH(promhttp.Handler()))
-	r.GET("/debug/pprof/cmdline", gin.WrapF(pprof.Cmdline))
-	r.GET("/debug/pprof/profile", gin.WrapF(pprof.Profile))
-	r.GET("/debug/pprof/symbol", gin.WrapF(pprof.Symbol))
-	r.GET("/debug/pprof/trace", gin.WrapF(pprof.Trace))
+	// 启用管理端口时指标和性能分析接口只在管理端口上提供
+	if !init.Conf.Server.Admin.Enable {
+		r.GET("/metrics", gin.WrapH(promhttp.Handler()))
+		r.GET("/debug/pprof/cmdline", gin.WrapF(pprof.Cmdline))
+		r.GET("/debug/pprof/profile", gin.WrapF(pprof.Profile))
+		r.GET("/debug/pprof/symbol", gin.WrapF(pprof.Symbol))
+		r.GET("/debug/pprof/trace", gin.WrapF(pprof.Trace))
+	}
 
 	apiRouter := r.Group("/api")
 
+	// 使用Cookie认证的写请求校验CSRF令牌
+	if init.JwtConf.Cookie != nil {
+		apiRouter.Use(middleware.CSRFMiddleware(init.JwtConf.Cookie, init.Conf.Security.Cookie.CSRFExempt))
+	}
+
+	// 对全部/api接口统一认证和鉴权, 模块路由不再单独注册认证中间件
+	authzConf := init.Conf.Security.Authz
+	authzConf.PublicRoutes = append(slices.Clone(builtinPublicRoutes), authzConf.PublicRoutes...)
+	authzConf.LoginRoutes = append(slices.Clone(builtinLoginRoutes), authzConf.LoginRoutes...)
+	apiRouter.Use(middleware.RouteAuthMiddleware(init.Enforcer, init.JwtConf, loggers.Service, authzConf))
+
+	// 数据库连接失败或超时达到阈值后熔断
+	if dbBreakers := newBreakerGroup(init, loggers, metrics.BreakerDatabase); dbBreakers != nil {
+		if err := init.DB.Use(database.NewBreakerPlugin(dbBreakers.Get(init.Conf.Database.Type))); err != nil {
+			loggers.Server.Error("注册数据库熔断插件失败", zap.Error(err))
+			panic(err)
+		}
+	}
+
+	// 数据库不可用时拒绝写操作, 配置的查询接口返回缓存的响应
+	if outage := init.Conf.Database.Outage; init.DBHealth != nil && outage.ProbeInterval > 0 {
+		ttl := time.Duration(outage.CacheSeconds) * time.Second
+		if ttl <= 0 {
+			ttl = 10 * time.Minute
+		}
+		apiRouter.Use(middleware.DegradedMiddleware(init.DBHealth, cache.New(ttl, ttl), outage.CacheRoutes))
+	}
+
+	// 列表和详情接口支持ETag条件请求, 内容未变化时返回304
+	if cacheConf := init.Conf.Server.Cache; cacheConf.Enable {
+		etagRoutes := make([]middleware.ETagRoute, 0, len(cacheConf.Routes))
+		for _, rt := range cacheConf.Routes {
+			etagRoutes = append(etagRoutes, middleware.ETagRoute{
+				Prefix:       rt.Prefix,
+				CacheControl: rt.CacheControl,
+			})
+		}
+		apiRouter.Use(middleware.ETagMiddleware(etagRoutes, cacheConf.MaxBody<<10))
+	}
+
 	// 初始化加载业务模块
-	newCustomerRouter(apiRouter, init, loggers)
-	newResourceRouter(apiRouter, init, loggers)
-	jobsRouter := NewJobsRouter(apiRouter, init, loggers)
-	newMonRouter(apiRouter, init, loggers)
-	newMdsRouter(apiRouter, init, loggers, jobsRouter)
-	newOesRouter(apiRouter, init, loggers, jobsRouter)
+	mc := &ModuleContext{Router: apiRouter, Engine: r, Init: init, Loggers: loggers}
+	loadModules(mc)
+	if err := mc.System.GraphQL.Build(); err != nil {
+		loggers.Server.Error("生成GraphQL schema失败", zap.Error(err))
+		panic(err)
+	}
+	if mc.Customer != nil && mc.Customer.Preference != nil {
+		userLang = mc.Customer.Preference.UserLanguage
+	}
+
+	// 生效的Casbin模型在用户模块中加载, 加载后再检查是否支持按请求路径匹配
+	if authzConf.MatchPath && !auth.UsesKeyMatch(init.Enforcer) {
+		loggers.Server.Warn("当前Casbin模型未使用keyMatch2匹配对象, 带路径参数的接口将无法通过鉴权")
+	}
+
+	// 全部模块加载完成后按路由同步API目录
+	if init.Conf.Security.ApiSync.OnStartup {
+		out, rErr := mc.Customer.Api.SyncApis(context.Background(), RouteApis(r.Routes()), init.Conf.Security.ApiSync.TagModule)
+		if rErr != nil {
+			loggers.Server.Error("系统初始化同步API目录失败", zap.Error(rErr))
+			panic(rErr)
+		}
+		if len(out.Orphans) > 0 {
+			loggers.Server.Warn("存在路由中已不存在的API, 请确认后删除", zap.Int("count", len(out.Orphans)))
+		}
+	}
 	return r
 }
+
+// newBreakerGroup 创建下游调用的熔断器分组, 状态通过/metrics暴露, 未启用熔断时返回nil
+func newBreakerGroup(init *common.Initialize, loggers *log.Loggers, kind string) *breaker.Group {
+	conf := init.Conf.Breaker
+	if conf == nil || !conf.Enable {
+		return nil
+	}
+	g := breaker.NewGroup(breaker.Settings{
+		FailureThreshold: conf.FailureThreshold,
+		OpenTimeout:      conf.OpenTimeout(),
+		HalfOpenRequests: conf.HalfOpenRequests,
+		IsFailure:        breaker.IsUnavailable,
+		OnStateChange: func(name string, from, to breaker.State) {
+			metrics.CircuitBreakerTransitionsTotal.WithLabelValues(kind, name, to.String()).Inc()
+			loggers.Server.Warn(
+				"熔断器状态变化",
+				zap.String("kind", kind),
+				zap.String("name", name),
+				zap.Stringer("from", from),
+				zap.Stringer("to", to),
+			)
+		},
+		OnReject: func(name string) {
+			metrics.CircuitBreakerRejectedTotal.WithLabelValues(kind, name).Inc()
+		},
+	})
+	metrics.RegisterBreakerGroup(kind, g)
+	return g
+}
+
+// healthHandler 健康检查, 数据库不可用时返回降级状态
+func healthHandler(h *database.Health) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{
+			"code": http.StatusOK,
+			"msg":  time.Now().Format(time.DateTime),
+			"data": dbHealthData(h),
+		})
+	}
+}
+
+// readyHandler 就绪检查, 数据库不可用时返回503
+func readyHandler(h *database.Health) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		code := http.StatusOK
+		if h.Degraded() {
+			code = http.StatusServiceUnavailable
+		}
+		c.JSON(code, gin.H{
+			"code": code,
+			"msg":  time.Now().Format(time.DateTime),
+			"data": dbHealthData(h),
+		})
+	}
+}
+
+// dbHealthData 健康检查返回的数据库状态
+func dbHealthData(h *database.Health) gin.H {
+	status := "ok"
+	if h.Degraded() {
+		status = "degraded"
+	}
+	data := gin.H{"status": status}
+	if since := h.Since(); !since.IsZero() {
+		data["since"] = since.Format(time.DateTime)
+	}
+	return data
+}
+
+// megabytes 将以MB为单位的配置转换为字节数, 小于等于0时使用默认值
+func megabytes(size, defaultSize int) int64 {
+	if size <= 0 {
+		size = defaultSize
+	}
+	return int64(size) << 20
+}
+
+// uploadBodyLimit 上传请求的请求体大小上限, 未配置时取各类上传文件大小上限的最大值再加1MB用于表单的其他字段
+func uploadBodyLimit(conf *config.SystemConf) int64 {
+	if conf.Server.Body.Upload > 0 {
+		return int64(conf.Server.Body.Upload) << 20
+	}
+	size := 0
+	if u := conf.Upload; u != nil {
+		size = max(u.MaxPkgSize, u.MaxScriptSize, u.MaxConfSize, u.MaxHostFileSize, u.ChunkSize)
+	}
+	return int64(size+1) << 20
+}
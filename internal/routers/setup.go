@@ -0,0 +1,86 @@
+package routers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	custmodel "gin-artweb/internal/model/customer"
+	custrepo "gin-artweb/internal/repository/customer"
+	custsvc "gin-artweb/internal/service/customer"
+	"gin-artweb/internal/shared/common"
+	"gin-artweb/internal/shared/errors"
+	"gin-artweb/internal/shared/log"
+)
+
+// publicApis 各模块声明的无需权限校验的接口, 不导入API目录
+var publicApis = modulePermissions()
+
+// RouteApis 将已注册的路由转换为API目录, 只包含/api下需要权限校验的接口
+func RouteApis(routes gin.RoutesInfo) []custmodel.ApiModel {
+	apis := make([]custmodel.ApiModel, 0, len(routes))
+	for _, route := range routes {
+		if !strings.HasPrefix(route.Path, "/api/") {
+			continue
+		}
+		if _, ok := publicApis[route.Method+" "+route.Path]; ok {
+			continue
+		}
+		apis = append(apis, custmodel.ApiModel{
+			URL:    route.Path,
+			Method: route.Method,
+			Label:  routeLabel(route.Path),
+			Descr:  routeDescr(route.Handler),
+		})
+	}
+	return apis
+}
+
+// routeLabel 使用路径中的模块名作为标签, 如/api/v1/customer/role为customer
+func routeLabel(path string) string {
+	parts := strings.Split(strings.TrimPrefix(path, "/api/"), "/")
+	if len(parts) > 1 {
+		return parts[1]
+	}
+	return parts[0]
+}
+
+// routeDescr 使用处理函数名作为描述, 如RoleHandler.CreateRole
+func routeDescr(handler string) string {
+	name := handler[strings.LastIndex(handler, "/")+1:]
+	if i := strings.Index(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+	name = strings.TrimSuffix(name, "-fm")
+	name = strings.NewReplacer("(*", "", ")", "").Replace(name)
+	if len(name) > 254 {
+		name = name[:254]
+	}
+	return name
+}
+
+// Bootstrap 首次部署时初始化默认角色、API目录和管理员
+// 需要在NewRouter注册全部路由之后调用
+func Bootstrap(
+	ctx context.Context,
+	r *gin.Engine,
+	init *common.Initialize,
+	loggers *log.Loggers,
+	username string,
+	password string,
+) (*custmodel.BootstrapOut, *errors.Error) {
+	hasher, err := newPasswordHasher(init.Conf.Security.Password.Hash)
+	if err != nil {
+		return nil, errors.FromError(err)
+	}
+	setupService := custsvc.NewSetupService(
+		loggers.Biz,
+		custsvc.NewApiService(loggers.Biz, custrepo.NewApiRepo(loggers.Data, init.DB, init.DBTimeout, init.Enforcer)),
+		custrepo.NewRoleRepo(loggers.Data, init.DB, init.DBTimeout, init.Enforcer),
+		custrepo.NewUserRepo(loggers.Data, init.DB, init.DBTimeout),
+		custrepo.NewCasbinModelRepo(loggers.Data, init.DB, init.DBTimeout),
+		hasher,
+	)
+	return setupService.Bootstrap(ctx, RouteApis(r.Routes()), username, password)
+}
@@ -0,0 +1,79 @@
+package routers
+
+import (
+	"io"
+	"io/fs"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"gin-artweb/internal/shared/config"
+)
+
+const (
+	// 入口页面每次都向服务器确认是否有更新, 保证发布后客户端加载新版本的资源
+	indexCacheControl   = "no-cache"
+	faviconCacheControl = "public, max-age=86400"
+	defaultStaticMaxAge = 365 * 24 * time.Hour
+)
+
+// registerFrontend 注册前端页面和静态文件路由
+//
+// 入口页面不缓存, /static下的打包文件长期缓存; 未匹配的非/api的GET请求返回入口页面,
+// 由前端路由处理, 其余未匹配的请求(包括带扩展名的路径, 视为缺失的文件)仍返回gin默认的404
+func registerFrontend(r *gin.Engine, page gin.IRoutes, fsys fs.FS, conf config.StaticConfig, pageMiddleware ...gin.HandlerFunc) {
+	maxAge := time.Duration(conf.MaxAge) * time.Second
+	if maxAge <= 0 {
+		maxAge = defaultStaticMaxAge
+	}
+	staticCacheControl := "public, max-age=" + strconv.Itoa(int(maxAge.Seconds())) + ", immutable"
+
+	index := func(c *gin.Context) {
+		serveFrontendFile(c, fsys, "index.html", indexCacheControl)
+	}
+	page.GET("/", index)
+	page.GET("/favicon.ico", func(c *gin.Context) {
+		serveFrontendFile(c, fsys, "favicon.ico", faviconCacheControl)
+	})
+	page.GET("/static/*filepath", func(c *gin.Context) {
+		serveFrontendFile(c, fsys, path.Join("static", path.Clean("/"+c.Param("filepath"))), staticCacheControl)
+	})
+
+	r.NoRoute(append(pageMiddleware, func(c *gin.Context) {
+		p := c.Request.URL.Path
+		if (c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead) ||
+			p == "/api" || strings.HasPrefix(p, "/api/") || path.Ext(p) != "" {
+			return
+		}
+		index(c)
+	})...)
+}
+
+// serveFrontendFile 返回前端文件, 文件不存在或是目录时返回404
+//
+// 不使用http.FileServer, 避免请求index.html时被重定向以及列出目录
+func serveFrontendFile(c *gin.Context, fsys fs.FS, name, cacheControl string) {
+	f, err := fsys.Open(name)
+	if err != nil {
+		c.Status(http.StatusNotFound)
+		return
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil || info.IsDir() {
+		c.Status(http.StatusNotFound)
+		return
+	}
+	rs, ok := f.(io.ReadSeeker)
+	if !ok {
+		c.Status(http.StatusInternalServerError)
+		return
+	}
+	c.Header("Cache-Control", cacheControl)
+	http.ServeContent(c.Writer, c.Request, info.Name(), info.ModTime(), rs)
+}
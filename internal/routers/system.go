@@ -0,0 +1,319 @@
+package routers
+
+import (
+	"cmp"
+	"context"
+	"path/filepath"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	handler "gin-artweb/internal/handler/system"
+	"gin-artweb/internal/model"
+	sysmodel "gin-artweb/internal/model/system"
+	mdsrepo "gin-artweb/internal/repository/mds"
+	oesrepo "gin-artweb/internal/repository/oes"
+	sysrepo "gin-artweb/internal/repository/system"
+	syssvc "gin-artweb/internal/service/system"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/log"
+	"gin-artweb/internal/shared/middleware"
+	"gin-artweb/pkg/storage"
+)
+
+type SystemRouter struct {
+	Maintenance  *syssvc.MaintenanceService
+	Alerts       *syssvc.AlertPublisher     // 各模块的巡检告警通过同一发布者匹配维护窗口并写入发件箱
+	FeatureFlags *syssvc.FeatureFlagService // 新接口通过middleware.FeatureFlagMiddleware按开关开放
+	Search       *syssvc.SearchService      // 各模块通过Register注册搜索源
+	Backup       *syssvc.BackupService      // 程序包使用远程存储时资源模块通过SetStore将备份文件保存到同一存储
+	GraphQL      *syssvc.GraphQLService     // 各模块通过LoadSchema注册对象类型和查询字段, 全部模块加载后生成schema
+}
+
+// systemModule 系统模块
+type systemModule struct{ baseModule }
+
+func (*systemModule) Name() string { return "system" }
+
+func (*systemModule) Routes(mc *ModuleContext) { mc.System = newSystemRouter(mc) }
+
+// newSystemRouter 加载系统模块
+// 使用统计中间件注册在api路由组上，因此必须先于其他业务模块加载
+// 查询网关使用mc.Engine在进程内分发子查询, 子查询在请求时才匹配路由, 因此不要求其他模块已加载
+func newSystemRouter(mc *ModuleContext) *SystemRouter {
+	router, engine, init, loggers := mc.Router, mc.Engine, mc.Init, mc.Loggers
+	eventRepo := sysrepo.NewAnalyticsEventRepo(loggers.Data, init.DB, init.DBTimeout)
+	auditRepo := sysrepo.NewAuditRecordRepo(loggers.Data, init.DB, init.DBTimeout)
+	windowRepo := sysrepo.NewMaintenanceWindowRepo(loggers.Data, init.DB, init.DBTimeout)
+	slowQueryRepo := sysrepo.NewSlowQueryRepo(loggers.Data, init.DB, init.DBTimeout)
+	oesColonyRepo := oesrepo.NewOesColonyRepo(loggers.Data, init.DB, init.DBTimeout)
+	mdsColonyRepo := mdsrepo.NewMdsColonyRepo(loggers.Data, init.DB, init.DBTimeout)
+
+	analyticsService := syssvc.NewAnalyticsService(loggers.Biz, eventRepo, init.Conf.Analytics)
+	init.OnShutdown(analyticsService.Stop)
+	auditService := syssvc.NewAuditService(loggers.Biz, auditRepo)
+	maintenanceService := syssvc.NewMaintenanceService(loggers.Biz, windowRepo, auditRepo, oesColonyRepo, mdsColonyRepo)
+	alertPublisher := syssvc.NewAlertPublisher(loggers.Biz, maintenanceService, init.Outbox)
+	logService, err := syssvc.NewLogQueryService(loggers.Biz, init.Conf.Log.Query, config.LogDir)
+	if err != nil {
+		loggers.Server.Error("初始化日志查询服务失败", zap.Error(err))
+		panic(err)
+	}
+
+	migrationService := syssvc.NewMigrationService(loggers.Biz, NewMigrator(init.DB))
+	slowQueryService := syssvc.NewSlowQueryService(loggers.Biz, slowQueryRepo)
+
+	if threshold := init.Conf.Database.SlowThreshold; threshold > 0 {
+		plugin := database.NewSlowQueryPlugin(time.Duration(threshold)*time.Millisecond, slowQueryService)
+		if err := init.DB.Use(plugin); err != nil {
+			loggers.Server.Error("注册慢查询插件失败", zap.Error(err))
+			panic(err)
+		}
+
+		// 每分钟将汇总的慢查询写入数据库
+		mc.AddCronJob("慢查询写入", "@every 1m", func() {
+			slowQueryService.Flush(context.Background())
+		})
+		init.OnShutdown(slowQueryService.Flush)
+	}
+
+	// 记录使用代登录令牌的请求, 与使用统计中间件一样需要先于其他业务模块注册
+	router.Use(middleware.ImpersonationAuditMiddleware(auditService))
+
+	freezeRepo := sysrepo.NewChangeFreezeRepo(loggers.Data, init.DB, init.DBTimeout)
+	freezeService := syssvc.NewChangeFreezeService(loggers.Biz, freezeRepo, auditRepo)
+	if rErr := freezeService.Reload(context.Background()); rErr != nil {
+		loggers.Server.Error("加载变更冻结失败", zap.Error(rErr))
+	}
+	// 同步其他实例修改的变更冻结, 同时清理已结束的冻结
+	mc.AddCronJob("变更冻结同步", "@every 1m", func() {
+		if rErr := freezeService.Reload(context.Background()); rErr != nil {
+			loggers.Server.Error("同步变更冻结失败", zap.Error(rErr))
+		}
+	})
+	// 冻结期间拒绝修改接口, 需要先于其他业务模块注册
+	if conf := init.Conf.Security.Freeze; conf.Enable {
+		router.Use(middleware.ChangeFreezeMiddleware(freezeService, loggers.Service, conf))
+	}
+
+	if analyticsService.Enabled() {
+		router.Use(middleware.AnalyticsMiddleware(analyticsService))
+
+		// 每日清理过期统计事件
+		mc.AddCronJob("统计事件清理", "@daily", func() {
+			if rErr := analyticsService.PurgeExpiredEvents(context.Background()); rErr != nil {
+				loggers.Server.Error("定时清理过期统计事件失败", zap.Error(rErr))
+			}
+		})
+	}
+
+	if conf := init.Conf.Retention; conf != nil && conf.Enable {
+		retentionRepo := sysrepo.NewRetentionRepo(loggers.Data, init.DB, init.DBTimeout)
+		retentionService, err := syssvc.NewRetentionService(loggers.Biz, retentionRepo, conf, filepath.Join(config.StorageDir, "retention"))
+		if err != nil {
+			loggers.Server.Error("初始化数据保留策略服务失败", zap.Error(err))
+			panic(err)
+		}
+
+		// 按配置的时间清理各数据表的过期记录
+		mc.AddCronJob("过期数据清理", cmp.Or(conf.Cron, "@daily"), func() {
+			if rErr := retentionService.Run(context.Background()); rErr != nil {
+				loggers.Server.Error("定时清理过期数据失败", zap.Error(rErr))
+			}
+		})
+	}
+
+	reportRepo := sysrepo.NewReportRepo(loggers.Data, init.DB, init.DBTimeout)
+	reportService, err := syssvc.NewReportService(
+		loggers.Biz, reportRepo, init.Conf.Report, filepath.Join(config.StorageDir, "reports"), init.Outbox,
+	)
+	if err != nil {
+		loggers.Server.Error("初始化报表服务失败", zap.Error(err))
+		panic(err)
+	}
+	for _, schedule := range reportService.Schedules() {
+		// 按配置的时间生成上一个周期的报表
+		mc.AddCronJob("定时报表"+schedule.Name, schedule.Cron, func() {
+			if _, rErr := reportService.RunSchedule(context.Background(), schedule); rErr != nil {
+				loggers.Server.Error("生成定时报表失败", zap.String("name", schedule.Name), zap.Error(rErr))
+			}
+		})
+	}
+
+	taskRepo := sysrepo.NewTaskRepo(loggers.Data, init.DB, init.DBTimeout)
+	taskService := syssvc.NewTaskService(loggers.Biz, taskRepo)
+	// 上次服务关闭时未结束的任务不会再执行, 标记为失败
+	if rErr := taskService.FailInterrupted(context.Background()); rErr != nil {
+		loggers.Server.Error("标记中断的后台任务失败", zap.Error(rErr))
+	}
+	init.OnShutdown(taskService.Stop)
+
+	flagRepo := sysrepo.NewFeatureFlagRepo(loggers.Data, init.DB, init.DBTimeout)
+	flagService := syssvc.NewFeatureFlagService(loggers.Biz, flagRepo)
+	if rErr := flagService.Reload(context.Background()); rErr != nil {
+		loggers.Server.Error("加载功能开关失败", zap.Error(rErr))
+	}
+	// 同步其他实例修改的开关
+	mc.AddCronJob("功能开关同步", "@every 1m", func() {
+		if rErr := flagService.Reload(context.Background()); rErr != nil {
+			loggers.Server.Error("同步功能开关失败", zap.Error(rErr))
+		}
+	})
+
+	subscriptionRepo := sysrepo.NewWebhookSubscriptionRepo(loggers.Data, init.DB, init.DBTimeout)
+	deliveryRepo := sysrepo.NewWebhookDeliveryRepo(loggers.Data, init.DB, init.DBTimeout)
+	webhookService := syssvc.NewWebhookService(loggers.Biz, subscriptionRepo, deliveryRepo, init.Conf.Webhook)
+	// 业务事件发布时为匹配的订阅创建推送记录, 由后台协程推送
+	init.Events.Subscribe(webhookService.HandleEvent)
+	webhookService.Start()
+	init.OnShutdown(webhookService.Stop)
+
+	searchService := syssvc.NewSearchService(loggers.Biz, init.Enforcer)
+	graphQLService := syssvc.NewGraphQLService(loggers.Biz, init.Enforcer)
+
+	statsRepo := sysrepo.NewStatsRepo(loggers.Data, init.DB, init.DBTimeout)
+	statsService := syssvc.NewStatsService(loggers.Biz, statsRepo, init.Conf.Stats)
+
+	certRepo := sysrepo.NewCertMonitorRepo(loggers.Data, init.DB, init.DBTimeout)
+	certService := syssvc.NewCertMonitorService(loggers.Biz, certRepo, alertPublisher, init.Conf.CertMonitor)
+	// 启用HTTPS时自动监控本服务的证书
+	if ssl := init.Conf.Server.SSL; ssl.Enable {
+		crtPath := filepath.Join(config.ConfigDir, ssl.CrtPath)
+		if rErr := certService.EnsureFileMonitor(context.Background(), "本服务HTTPS证书", crtPath); rErr != nil {
+			loggers.Server.Error("创建本服务证书监控失败", zap.Error(rErr))
+		}
+	}
+	if conf := init.Conf.CertMonitor; conf != nil && conf.Cron != "" {
+		mc.AddCronJob("证书过期检查", conf.Cron, func() {
+			if rErr := certService.CheckAll(context.Background()); rErr != nil {
+				loggers.Server.Error("证书过期检查失败", zap.Error(rErr))
+			}
+		})
+	}
+
+	backupService := newBackupService(init.DB, init.DBTimeout, loggers, init.Conf)
+	if rErr := backupService.FailInterrupted(context.Background()); rErr != nil {
+		loggers.Server.Error("标记未完成的备份失败", zap.Error(rErr))
+	}
+	if conf := init.Conf.Backup; conf != nil && conf.Cron != "" {
+		mc.AddCronJob("定时备份", conf.Cron, func() {
+			if _, rErr := backupService.Backup(context.Background(), sysmodel.BackupTriggerScheduled, "", nil); rErr != nil {
+				loggers.Server.Error("定时备份失败", zap.Error(rErr))
+			}
+		})
+	}
+
+	analyticsHandler := handler.NewAnalyticsHandler(loggers.Service, analyticsService)
+	auditHandler := handler.NewAuditHandler(loggers.Service, auditService)
+	deployHandler := handler.NewDeployHandler(loggers.Service, init.Conf.Deploy)
+	maintenanceHandler := handler.NewMaintenanceHandler(loggers.Service, maintenanceService)
+	freezeHandler := handler.NewChangeFreezeHandler(loggers.Service, freezeService)
+	certHandler := handler.NewCertMonitorHandler(loggers.Service, certService)
+	logHandler := handler.NewLogHandler(loggers.Service, logService)
+	gatewayHandler := handler.NewGatewayHandler(loggers.Service, engine)
+	reportHandler := handler.NewReportHandler(loggers.Service, reportService, taskService)
+	migrationHandler := handler.NewMigrationHandler(loggers.Service, migrationService)
+	backupHandler := handler.NewBackupHandler(loggers.Service, backupService, taskService)
+	slowQueryHandler := handler.NewSlowQueryHandler(loggers.Service, slowQueryService)
+	webhookHandler := handler.NewWebhookHandler(loggers.Service, webhookService)
+	taskHandler := handler.NewTaskHandler(loggers.Service, taskService)
+	flagHandler := handler.NewFeatureFlagHandler(loggers.Service, flagService)
+	searchHandler := handler.NewSearchHandler(loggers.Service, searchService)
+	statsHandler := handler.NewStatsHandler(loggers.Service, statsService)
+	graphQLHandler := handler.NewGraphQLHandler(loggers.Service, graphQLService)
+
+	appRouter := router.Group("/v1/system")
+	analyticsHandler.LoadRouter(appRouter)
+	auditHandler.LoadRouter(appRouter)
+	deployHandler.LoadRouter(appRouter)
+	gatewayHandler.LoadRouter(appRouter)
+	maintenanceHandler.LoadRouter(appRouter)
+	freezeHandler.LoadRouter(appRouter)
+	certHandler.LoadRouter(appRouter)
+	reportHandler.LoadRouter(appRouter)
+	webhookHandler.LoadRouter(appRouter)
+	appRouter.GET("/me/feature-flag", flagHandler.GetMyFeatureFlag)
+
+	adminRouter := router.Group("/v1/admin")
+	logHandler.LoadRouter(adminRouter)
+	migrationHandler.LoadRouter(adminRouter)
+	backupHandler.LoadRouter(adminRouter)
+	slowQueryHandler.LoadRouter(adminRouter)
+	flagHandler.LoadRouter(adminRouter)
+
+	// 日志级别只允许工作人员调整, 不能通过授权角色开放给其他用户
+	if loggers.Levels != nil {
+		logLevelHandler := handler.NewLogLevelHandler(loggers.Service, syssvc.NewLogLevelService(loggers.Biz, loggers.Levels))
+		logLevelRouter := adminRouter.Group("", middleware.StaffOnlyMiddleware(loggers.Service))
+		logLevelHandler.LoadRouter(logLevelRouter)
+	}
+
+	taskRouter := router.Group("/v1/tasks")
+	taskHandler.LoadRouter(taskRouter)
+
+	searchRouter := router.Group("/v1/search")
+	searchHandler.LoadRouter(searchRouter)
+
+	statsRouter := router.Group("/v1/stats")
+	statsHandler.LoadRouter(statsRouter)
+
+	graphQLHandler.LoadRouter(router)
+
+	return &SystemRouter{
+		Maintenance:  maintenanceService,
+		Alerts:       alertPublisher,
+		FeatureFlags: flagService,
+		Search:       searchService,
+		Backup:       backupService,
+		GraphQL:      graphQLService,
+	}
+}
+
+// newBackupService 创建备份服务, 备份文件默认保存在存储目录的backups目录下
+//
+// 备份存储目录下的上传文件, 程序包使用S3或SFTP存储时本地的packages目录只是缓存, 不需要备份
+func newBackupService(
+	db *gorm.DB,
+	timeouts *config.DBTimeout,
+	loggers *log.Loggers,
+	conf *config.SystemConf,
+) *syssvc.BackupService {
+	dirs := []string{"script", "host_vars"}
+	if conf.Storage == nil || conf.Storage.Type == "" || conf.Storage.Type == storage.TypeLocal {
+		dirs = append(dirs, "packages")
+	}
+	return syssvc.NewBackupService(
+		loggers.Biz,
+		sysrepo.NewBackupRepo(loggers.Data, db, timeouts),
+		sysrepo.NewDumpRepo(loggers.Data, db, timeouts),
+		model.Models(),
+		NewMigrator(db).Latest(),
+		storage.NewLocalStorage(filepath.Join(config.StorageDir, "backups")),
+		config.StorageDir,
+		dirs,
+		conf.Backup,
+		database.NewTxManager(db),
+	)
+}
+
+// RestoreBackup 将备份文件恢复到没有数据的数据库, 调用前需要执行数据库迁移
+//
+// 恢复步骤:
+//  1. 通过/api/v1/admin/backup/{id}/download下载备份文件, 停止服务
+//  2. 创建空数据库, 使用备份时的程序版本和相同的字段加密密钥执行 -restore 备份文件路径
+//  3. 程序执行数据库迁移后导入数据, 并将上传文件复制到存储目录, 完成后正常启动服务
+//
+// 导入失败时回滚已导入的数据, 排查后可以直接重新执行, 完整说明见README的数据备份恢复部分.
+// 程序包使用S3或SFTP存储时程序包文件不在备份中, 由存储后端自行备份
+func RestoreBackup(
+	ctx context.Context,
+	db *gorm.DB,
+	timeouts *config.DBTimeout,
+	loggers *log.Loggers,
+	conf *config.SystemConf,
+	filename string,
+) (*sysmodel.BackupManifest, error) {
+	return newBackupService(db, timeouts, loggers, conf).Restore(ctx, filename)
+}
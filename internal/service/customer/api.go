@@ -35,37 +35,33 @@ func (s *ApiService) CreateApi(
 		return nil, errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始创建api",
 		zap.Object(database.ModelKey, &m),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	if err := s.apiRepo.CreateModel(ctx, &m); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"创建api失败",
 			zap.Error(err),
 			zap.Object(database.ModelKey, &m),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 
 		return nil, errors.NewGormError(err, nil)
 	}
 
 	if err := s.apiRepo.AddPolicy(ctx, m); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"添加api策略失败",
 			zap.Error(err),
 			zap.Object(database.ModelKey, &m),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.FromError(err)
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"创建api成功",
 		zap.Object(database.ModelKey, &m),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return &m, nil
 }
@@ -79,59 +75,53 @@ func (s *ApiService) UpdateApiByID(
 		return nil, errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始更新api",
 		zap.Uint32("api_id", apiID),
 		zap.Any(database.UpdateDataKey, data),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	if err := s.apiRepo.UpdateModel(ctx, data, "id = ?", apiID); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"更新api失败",
 			zap.Error(err),
 			zap.Uint32("api_id", apiID),
 			zap.Any(database.UpdateDataKey, data),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.NewGormError(err, data)
 	}
 
 	m, rErr := s.FindApiByID(ctx, apiID)
 	if rErr != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"查询更新后的api失败",
 			zap.Error(rErr),
 			zap.Uint32("api_id", apiID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, rErr
 	}
 
 	if err := s.apiRepo.RemovePolicy(ctx, *m, false); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"移除旧api策略失败",
 			zap.Error(err),
 			zap.Uint32("api_id", apiID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.FromError(err)
 	}
 
 	if err := s.apiRepo.AddPolicy(ctx, *m); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"添加新api策略失败",
 			zap.Error(err),
 			zap.Uint32("api_id", apiID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.FromError(err)
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"更新api成功",
 		zap.Uint32("api_id", apiID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return m, nil
 }
@@ -144,47 +134,42 @@ func (s *ApiService) DeleteApiByID(
 		return errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始删除api",
 		zap.Uint32("api_id", apiID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	m, rErr := s.FindApiByID(ctx, apiID)
 	if rErr != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"查询待删除api失败",
 			zap.Error(rErr),
 			zap.Uint32("api_id", apiID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return rErr
 	}
 
 	if err := s.apiRepo.DeleteModel(ctx, apiID); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"删除api失败",
 			zap.Error(err),
 			zap.Uint32("api_id", apiID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return errors.NewGormError(err, map[string]any{"id": apiID})
 	}
 
 	if err := s.apiRepo.RemovePolicy(ctx, *m, true); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"移除api策略失败",
 			zap.Error(err),
 			zap.Uint32("api_id", apiID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return errors.FromError(err)
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"删除api成功",
 		zap.Uint32("api_id", apiID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return nil
 }
@@ -197,27 +182,24 @@ func (s *ApiService) FindApiByID(
 		return nil, errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始查询api",
 		zap.Uint32("api_id", apiID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	m, err := s.apiRepo.GetModel(ctx, apiID)
 	if err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"查询api失败",
 			zap.Error(err),
 			zap.Uint32("api_id", apiID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.NewGormError(err, map[string]any{"id": apiID})
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"查询api成功",
 		zap.Uint32("api_id", apiID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return m, nil
 }
@@ -230,27 +212,24 @@ func (s *ApiService) ListApi(
 		return 0, nil, errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始查询api列表",
 		zap.Object(database.QueryParamsKey, &qp),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	count, ms, err := s.apiRepo.ListModel(ctx, qp)
 	if err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"查询api列表失败",
 			zap.Error(err),
 			zap.Object(database.QueryParamsKey, &qp),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return 0, nil, errors.NewGormError(err, nil)
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"查询api列表成功",
 		zap.Object(database.QueryParamsKey, &qp),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return count, ms, nil
 }
@@ -260,9 +239,8 @@ func (s *ApiService) LoadApiPolicy(ctx context.Context) *errors.Error {
 		return errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始加载api策略",
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	qp := database.QueryParams{
@@ -271,10 +249,9 @@ func (s *ApiService) LoadApiPolicy(ctx context.Context) *errors.Error {
 
 	_, pms, rErr := s.ListApi(ctx, qp)
 	if rErr != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"加载api策略时查询api列表失败",
 			zap.Error(rErr),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return rErr
 	}
@@ -285,21 +262,136 @@ func (s *ApiService) LoadApiPolicy(ctx context.Context) *errors.Error {
 		policyCount = len(ms)
 		for i := range ms {
 			if err := s.apiRepo.AddPolicy(ctx, ms[i]); err != nil {
-				s.log.Error(
+				ctxutil.Logger(ctx, s.log).Error(
 					"加载api策略失败",
 					zap.Error(err),
 					zap.Uint32("api_id", ms[i].ID),
-					zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 				)
 				return errors.FromError(err)
 			}
 		}
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"加载api策略成功",
 		zap.Int("policy_count", policyCount),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return nil
 }
+
+// SyncApis 按已注册的路由同步API目录
+//
+// 路由中存在但数据库中没有的API会被新增并添加策略, 数据库中存在但路由中已不存在的API
+// 只标记为失效等待人工确认, tagModule为true时按路由所属模块重新设置已有API的标签
+func (s *ApiService) SyncApis(
+	ctx context.Context,
+	routes []custmodel.ApiModel,
+	tagModule bool,
+) (*custmodel.ApiSyncOut, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始同步api目录",
+		zap.Int("route_count", len(routes)),
+		zap.Bool("tag_module", tagModule),
+	)
+
+	_, pms, rErr := s.ListApi(ctx, database.QueryParams{})
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	routeMap := make(map[string]custmodel.ApiModel, len(routes))
+	for _, r := range routes {
+		routeMap[apiKey(r)] = r
+	}
+
+	var (
+		created  []custmodel.ApiModel
+		orphans  []custmodel.ApiModel
+		restored int
+		retagged int
+	)
+	existing := make(map[string]struct{}, len(*pms))
+	for _, m := range *pms {
+		key := apiKey(m)
+		existing[key] = struct{}{}
+
+		route, ok := routeMap[key]
+		data := map[string]any{}
+		switch {
+		case !ok:
+			if !m.IsOrphan {
+				data["is_orphan"] = true
+			}
+			m.IsOrphan = true
+			orphans = append(orphans, m)
+		case m.IsOrphan:
+			data["is_orphan"] = false
+			restored++
+		}
+		if ok && tagModule && route.Label != "" && m.Label != route.Label {
+			data["label"] = route.Label
+			retagged++
+		}
+		if len(data) == 0 {
+			continue
+		}
+		if err := s.apiRepo.UpdateModel(ctx, data, "id = ?", m.ID); err != nil {
+			ctxutil.Logger(ctx, s.log).Error(
+				"同步api目录时更新api失败",
+				zap.Error(err),
+				zap.Uint32("api_id", m.ID),
+				zap.Any(database.UpdateDataKey, data),
+			)
+			return nil, errors.NewGormError(err, data)
+		}
+	}
+
+	for _, r := range routes {
+		key := apiKey(r)
+		if _, ok := existing[key]; ok {
+			continue
+		}
+		existing[key] = struct{}{}
+		m := r
+		if err := s.apiRepo.CreateModel(ctx, &m); err != nil {
+			ctxutil.Logger(ctx, s.log).Error(
+				"同步api目录时新增api失败",
+				zap.Error(err),
+				zap.Object(database.ModelKey, &m),
+			)
+			return nil, errors.NewGormError(err, nil)
+		}
+		if err := s.apiRepo.AddPolicy(ctx, m); err != nil {
+			ctxutil.Logger(ctx, s.log).Error(
+				"同步api目录时添加api策略失败",
+				zap.Error(err),
+				zap.Object(database.ModelKey, &m),
+			)
+			return nil, errors.FromError(err)
+		}
+		created = append(created, m)
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"同步api目录成功",
+		zap.Int("created", len(created)),
+		zap.Int("orphans", len(orphans)),
+		zap.Int("restored", restored),
+		zap.Int("retagged", retagged),
+	)
+	return &custmodel.ApiSyncOut{
+		Created:  *custmodel.ListApiModelToStandardOut(&created),
+		Orphans:  *custmodel.ListApiModelToStandardOut(&orphans),
+		Restored: restored,
+		Retagged: retagged,
+	}, nil
+}
+
+// apiKey API在目录中的唯一标识
+func apiKey(m custmodel.ApiModel) string {
+	return m.Method + " " + m.URL
+}
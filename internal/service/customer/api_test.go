@@ -243,6 +243,42 @@ func (suite *ApiTestSuite) TestLoadApiPolicy_ContextError() {
 	suite.NotNil(err, "上下文错误时加载API策略应该失败")
 }
 
+func (suite *ApiTestSuite) TestSyncApis() {
+	ctx := context.Background()
+	kept := CreateTestApiModel()
+	removed := CreateTestApiModel()
+	_, rErr := suite.apiservice.CreateApi(ctx, *kept)
+	suite.Nil(rErr)
+	fr, rErr := suite.apiservice.CreateApi(ctx, *removed)
+	suite.Nil(rErr)
+
+	added := CreateTestApiModel()
+	added.Method = "POST"
+	retag := *kept
+	retag.Label = "jobs"
+	out, rErr := suite.apiservice.SyncApis(ctx, []custmodel.ApiModel{retag, *added}, true)
+	suite.Nil(rErr, "同步API目录应该成功")
+	suite.Len(out.Created, 1)
+	suite.Equal(added.URL, out.Created[0].URL)
+	suite.Equal(1, out.Retagged, "已有API的标签应该按模块重新设置")
+
+	fm, rErr := suite.apiservice.FindApiByID(ctx, fr.ID)
+	suite.Nil(rErr)
+	suite.True(fm.IsOrphan, "路由中不存在的API应该标记为失效")
+	allowed, err := suite.enforcer.Enforce(auth.ApiToSubject(out.Created[0].ID), added.URL, added.Method)
+	suite.NoError(err)
+	suite.True(allowed, "新增的API应该添加策略")
+
+	// 路由恢复后取消失效标记
+	out, rErr = suite.apiservice.SyncApis(ctx, []custmodel.ApiModel{retag, *added, *removed}, false)
+	suite.Nil(rErr)
+	suite.Empty(out.Created)
+	suite.Equal(1, out.Restored)
+	fm, rErr = suite.apiservice.FindApiByID(ctx, fr.ID)
+	suite.Nil(rErr)
+	suite.False(fm.IsOrphan)
+}
+
 // 每个测试文件都需要这个入口函数
 func TestApiTestSuite(t *testing.T) {
 	pts := &ApiTestSuite{}
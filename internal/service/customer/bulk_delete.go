@@ -0,0 +1,310 @@
+package customer
+
+import (
+	"context"
+	"slices"
+
+	emperror "emperror.dev/errors"
+	"go.uber.org/zap"
+
+	custmodel "gin-artweb/internal/model/customer"
+	custsvc "gin-artweb/internal/repository/customer"
+	"gin-artweb/internal/shared/auth"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+)
+
+// BulkDeleteService 角色和菜单的批量删除服务
+//
+// 删除前检查引用关系: 会被数据库级联删除但不在删除范围内的记录阻止删除,
+// 关联表中的数据和Casbin组策略随删除一起清理
+type BulkDeleteService struct {
+	log      *zap.Logger
+	roleRepo *custsvc.RoleRepo
+	menuRepo *custsvc.MenuRepo
+	refRepo  *custsvc.ReferenceRepo
+}
+
+func NewBulkDeleteService(
+	log *zap.Logger,
+	roleRepo *custsvc.RoleRepo,
+	menuRepo *custsvc.MenuRepo,
+	refRepo *custsvc.ReferenceRepo,
+) *BulkDeleteService {
+	return &BulkDeleteService{
+		log:      log,
+		roleRepo: roleRepo,
+		menuRepo: menuRepo,
+		refRepo:  refRepo,
+	}
+}
+
+// CheckRoleDelete 检查删除角色的影响, 不修改数据
+func (s *BulkDeleteService) CheckRoleDelete(
+	ctx context.Context,
+	roleIDs []uint32,
+) (*custmodel.DeleteDependencies, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	ids := compactIDs(roleIDs)
+	_, ms, err := s.roleRepo.ListModel(ctx, database.QueryParams{Query: map[string]any{"id IN ?": ids}})
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询要删除的角色失败",
+			zap.Error(err),
+			zap.Uint32s("role_ids", ids),
+		)
+		return nil, errors.NewGormError(err, nil)
+	}
+	found := make([]uint32, 0, len(*ms))
+	for _, m := range *ms {
+		found = append(found, m.ID)
+	}
+	if rErr := checkMissingIDs(ids, found); rErr != nil {
+		return nil, rErr
+	}
+
+	deps := &custmodel.DeleteDependencies{IDs: ids}
+	users, err := s.refRepo.UsersByRole(ctx, ids, "用户的主角色被删除时用户会被一起删除, 请先为用户更换角色")
+	if err != nil {
+		return nil, s.referenceError(ctx, err, ids)
+	}
+	deps.Blocking = append(deps.Blocking, users...)
+
+	groups, err := s.refRepo.GroupsByRole(ctx, ids, "解除用户组与角色的关联")
+	if err != nil {
+		return nil, s.referenceError(ctx, err, ids)
+	}
+	deps.Cascades = append(deps.Cascades, groups...)
+
+	subjects := make([]string, 0, len(ids))
+	for _, id := range ids {
+		subjects = append(subjects, auth.RoleToSubject(id))
+	}
+	if deps.Policies, err = s.refRepo.GroupingPolicies(subjects); err != nil {
+		return nil, s.referenceError(ctx, err, ids)
+	}
+	return deps, nil
+}
+
+// DeleteRoles 检查引用关系后在一个事务中删除角色, 存在阻止删除的引用时返回ErrDeleteBlocked
+func (s *BulkDeleteService) DeleteRoles(
+	ctx context.Context,
+	roleIDs []uint32,
+) (*custmodel.DeleteDependencies, *errors.Error) {
+	deps, rErr := s.CheckRoleDelete(ctx, roleIDs)
+	if rErr != nil {
+		return nil, rErr
+	}
+	if !deps.Deletable() {
+		return nil, errors.ErrDeleteBlocked.WithField("dependencies", custmodel.DeleteDependenciesToOut(*deps))
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始批量删除角色",
+		zap.Object("dependencies", deps),
+	)
+
+	if err := s.refRepo.DeleteRoles(ctx, deps.IDs); err != nil {
+		return nil, s.deleteError(ctx, err, deps)
+	}
+
+	subjects := make([]string, 0, len(deps.IDs))
+	for _, id := range deps.IDs {
+		subjects = append(subjects, auth.RoleToSubject(id))
+	}
+	if err := s.refRepo.RemoveGroupingPolicies(ctx, subjects); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"移除角色组策略失败",
+			zap.Error(err),
+			zap.Uint32s("role_ids", deps.IDs),
+		)
+		return nil, errors.FromError(err)
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"批量删除角色成功",
+		zap.Object("dependencies", deps),
+	)
+	return deps, nil
+}
+
+// CheckMenuDelete 检查删除菜单的影响, 不修改数据
+//
+// 子菜单会随父菜单被数据库级联删除, 不在删除范围内的下级菜单阻止删除;
+// 菜单下的按钮随菜单一起删除, 角色对这些菜单和按钮的授权随之解除
+func (s *BulkDeleteService) CheckMenuDelete(
+	ctx context.Context,
+	menuIDs []uint32,
+) (*custmodel.DeleteDependencies, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	ids := compactIDs(menuIDs)
+	_, ms, err := s.menuRepo.ListModel(ctx, database.QueryParams{Query: map[string]any{"id IN ?": ids}})
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询要删除的菜单失败",
+			zap.Error(err),
+			zap.Uint32s("menu_ids", ids),
+		)
+		return nil, errors.NewGormError(err, nil)
+	}
+	found := make([]uint32, 0, len(*ms))
+	for _, m := range *ms {
+		found = append(found, m.ID)
+	}
+	if rErr := checkMissingIDs(ids, found); rErr != nil {
+		return nil, rErr
+	}
+
+	deps := &custmodel.DeleteDependencies{IDs: ids}
+	// 逐层查找下级菜单, 不在删除范围内的下级菜单都会被级联删除
+	visited := slices.Clone(ids)
+	for level := ids; len(level) > 0; {
+		children, err := s.refRepo.ChildMenus(ctx, level, "上级菜单被删除时下级菜单会被一起删除, 请一起删除或先调整上级菜单")
+		if err != nil {
+			return nil, s.referenceError(ctx, err, ids)
+		}
+		level = nil
+		for _, child := range children {
+			if slices.Contains(visited, child.ID) {
+				continue
+			}
+			visited = append(visited, child.ID)
+			level = append(level, child.ID)
+			deps.Blocking = append(deps.Blocking, child)
+		}
+	}
+
+	buttons, err := s.refRepo.ButtonsByMenu(ctx, ids, "菜单下的按钮随菜单一起删除")
+	if err != nil {
+		return nil, s.referenceError(ctx, err, ids)
+	}
+	deps.Cascades = append(deps.Cascades, buttons...)
+
+	roles, err := s.refRepo.RolesByMenu(ctx, ids, "解除角色的菜单授权")
+	if err != nil {
+		return nil, s.referenceError(ctx, err, ids)
+	}
+	deps.Cascades = append(deps.Cascades, roles...)
+
+	subjects := make([]string, 0, len(ids)+len(buttons))
+	for _, id := range ids {
+		subjects = append(subjects, auth.MenuToSubject(id))
+	}
+	if len(buttons) > 0 {
+		buttonIDs := make([]uint32, 0, len(buttons))
+		for _, b := range buttons {
+			buttonIDs = append(buttonIDs, b.ID)
+			subjects = append(subjects, auth.ButtonToSubject(b.ID))
+		}
+		roles, err := s.refRepo.RolesByButton(ctx, buttonIDs, "解除角色的按钮授权")
+		if err != nil {
+			return nil, s.referenceError(ctx, err, ids)
+		}
+		deps.Cascades = append(deps.Cascades, roles...)
+	}
+	if deps.Policies, err = s.refRepo.GroupingPolicies(subjects); err != nil {
+		return nil, s.referenceError(ctx, err, ids)
+	}
+	return deps, nil
+}
+
+// DeleteMenus 检查引用关系后在一个事务中删除菜单和菜单下的按钮, 存在阻止删除的引用时返回ErrDeleteBlocked
+func (s *BulkDeleteService) DeleteMenus(
+	ctx context.Context,
+	menuIDs []uint32,
+) (*custmodel.DeleteDependencies, *errors.Error) {
+	deps, rErr := s.CheckMenuDelete(ctx, menuIDs)
+	if rErr != nil {
+		return nil, rErr
+	}
+	if !deps.Deletable() {
+		return nil, errors.ErrDeleteBlocked.WithField("dependencies", custmodel.DeleteDependenciesToOut(*deps))
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始批量删除菜单",
+		zap.Object("dependencies", deps),
+	)
+
+	buttonIDs, err := s.refRepo.DeleteMenus(ctx, deps.IDs)
+	if err != nil {
+		return nil, s.deleteError(ctx, err, deps)
+	}
+
+	subjects := make([]string, 0, len(deps.IDs)+len(buttonIDs))
+	for _, id := range deps.IDs {
+		subjects = append(subjects, auth.MenuToSubject(id))
+	}
+	for _, id := range buttonIDs {
+		subjects = append(subjects, auth.ButtonToSubject(id))
+	}
+	if err := s.refRepo.RemoveGroupingPolicies(ctx, subjects); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"移除菜单组策略失败",
+			zap.Error(err),
+			zap.Uint32s("menu_ids", deps.IDs),
+			zap.Uint32s("button_ids", buttonIDs),
+		)
+		return nil, errors.FromError(err)
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"批量删除菜单成功",
+		zap.Object("dependencies", deps),
+		zap.Uint32s("button_ids", buttonIDs),
+	)
+	return deps, nil
+}
+
+func (s *BulkDeleteService) referenceError(ctx context.Context, err error, ids []uint32) *errors.Error {
+	ctxutil.Logger(ctx, s.log).Error(
+		"查询引用关系失败",
+		zap.Error(err),
+		zap.Uint32s("ids", ids),
+	)
+	return errors.NewGormError(err, nil)
+}
+
+func (s *BulkDeleteService) deleteError(ctx context.Context, err error, deps *custmodel.DeleteDependencies) *errors.Error {
+	if emperror.Is(err, custsvc.ErrStillReferenced) {
+		ctxutil.Logger(ctx, s.log).Warn(
+			"批量删除时发现新增的引用, 已回滚",
+			zap.Object("dependencies", deps),
+		)
+		return errors.ErrDeleteBlocked.WithCause(err)
+	}
+	ctxutil.Logger(ctx, s.log).Error(
+		"批量删除失败",
+		zap.Error(err),
+		zap.Object("dependencies", deps),
+	)
+	return errors.NewGormError(err, map[string]any{"ids": deps.IDs})
+}
+
+// compactIDs 排序并去除重复的ID
+func compactIDs(ids []uint32) []uint32 {
+	out := slices.Clone(ids)
+	slices.Sort(out)
+	return slices.Compact(out)
+}
+
+// checkMissingIDs 检查要删除的记录是否都存在
+func checkMissingIDs(ids, found []uint32) *errors.Error {
+	var missing []uint32
+	for _, id := range ids {
+		if !slices.Contains(found, id) {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) > 0 {
+		return errors.ErrDeleteTargetNotFound.WithField("ids", missing)
+	}
+	return nil
+}
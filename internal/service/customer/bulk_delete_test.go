@@ -0,0 +1,176 @@
+package customer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/casbin/casbin/v2"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	custmodel "gin-artweb/internal/model/customer"
+	custsvc "gin-artweb/internal/repository/customer"
+	"gin-artweb/internal/shared/auth"
+	"gin-artweb/internal/shared/errors"
+	"gin-artweb/internal/shared/test"
+)
+
+type BulkDeleteTestSuite struct {
+	suite.Suite
+	db       *gorm.DB
+	enforcer *casbin.Enforcer
+	bs       *BulkDeleteService
+}
+
+func (suite *BulkDeleteTestSuite) SetupTest() {
+	suite.db = test.NewTestGormDBWithConfig(nil)
+	suite.Require().NoError(suite.db.AutoMigrate(
+		&custmodel.ApiModel{},
+		&custmodel.MenuModel{},
+		&custmodel.ButtonModel{},
+		&custmodel.RoleModel{},
+		&custmodel.UserModel{},
+		&custmodel.UserGroupModel{},
+	))
+	dbTimeout := test.NewTestDBTimeouts()
+	logger := test.NewTestZapLogger()
+	enforcer, err := auth.NewCasbinEnforcer()
+	suite.Require().NoError(err)
+	suite.enforcer = enforcer
+	suite.bs = NewBulkDeleteService(
+		logger,
+		custsvc.NewRoleRepo(logger, suite.db, dbTimeout, enforcer),
+		custsvc.NewMenuRepo(logger, suite.db, dbTimeout, enforcer),
+		custsvc.NewReferenceRepo(logger, suite.db, dbTimeout, enforcer),
+	)
+}
+
+func TestBulkDeleteTestSuite(t *testing.T) {
+	suite.Run(t, new(BulkDeleteTestSuite))
+}
+
+func (suite *BulkDeleteTestSuite) createMenu(parentID *uint32) *custmodel.MenuModel {
+	m := CreateTestMenuModel(parentID)
+	suite.Require().NoError(suite.db.Create(m).Error)
+	return m
+}
+
+func (suite *BulkDeleteTestSuite) hasGroupingPolicy(sub, obj string) bool {
+	ok, err := suite.enforcer.HasGroupingPolicy(sub, obj)
+	suite.Require().NoError(err)
+	return ok
+}
+
+func (suite *BulkDeleteTestSuite) TestDeleteRoles() {
+	ctx := context.Background()
+	menu := suite.createMenu(nil)
+	used := CreateTestRoleModel()
+	used.Menus = []custmodel.MenuModel{*menu}
+	free := CreateTestRoleModel()
+	free.Menus = []custmodel.MenuModel{*menu}
+	suite.Require().NoError(suite.db.Create(used).Error)
+	suite.Require().NoError(suite.db.Create(free).Error)
+	user := CreateTestUserModel(used.ID)
+	suite.Require().NoError(suite.db.Create(user).Error)
+	group := &custmodel.UserGroupModel{Name: "运维组", Roles: []custmodel.RoleModel{*free}}
+	suite.Require().NoError(suite.db.Create(group).Error)
+
+	freeSub := auth.RoleToSubject(free.ID)
+	groupSub := auth.UserGroupToSubject(group.ID)
+	suite.Require().NoError(auth.AddGroupPolicies(ctx, suite.enforcer, [][]string{
+		{freeSub, auth.MenuToSubject(menu.ID)},
+		{groupSub, freeSub},
+	}))
+
+	deps, rErr := suite.bs.CheckRoleDelete(ctx, []uint32{used.ID, free.ID, free.ID})
+	suite.Require().Nil(rErr)
+	suite.Equal([]uint32{used.ID, free.ID}, deps.IDs, "重复的ID应该去除")
+	suite.False(deps.Deletable())
+	suite.Require().Len(deps.Blocking, 1)
+	suite.Equal(custmodel.DependencyUser, deps.Blocking[0].Kind)
+	suite.Equal(user.Username, deps.Blocking[0].Name)
+	suite.Require().Len(deps.Cascades, 1)
+	suite.Equal(custmodel.DependencyUserGroup, deps.Cascades[0].Kind)
+	suite.Len(deps.Policies, 2, "应该包含角色作为子级和父级的组策略")
+
+	_, rErr = suite.bs.DeleteRoles(ctx, []uint32{used.ID, free.ID})
+	suite.Require().NotNil(rErr)
+	suite.Equal(errors.ErrDeleteBlocked.Reason, rErr.Reason)
+	var count int64
+	suite.Require().NoError(suite.db.Model(&custmodel.RoleModel{}).Count(&count).Error)
+	suite.Equal(int64(2), count, "存在阻止删除的引用时不应该删除任何角色")
+
+	_, rErr = suite.bs.DeleteRoles(ctx, []uint32{free.ID, 9999})
+	suite.Require().NotNil(rErr)
+	suite.Equal(errors.ErrDeleteTargetNotFound.Reason, rErr.Reason)
+
+	_, rErr = suite.bs.DeleteRoles(ctx, []uint32{free.ID})
+	suite.Require().Nil(rErr)
+	suite.Require().NoError(suite.db.Model(&custmodel.RoleModel{}).Count(&count).Error)
+	suite.Equal(int64(1), count)
+	suite.Require().NoError(suite.db.Table("customer_user_group_role").Count(&count).Error)
+	suite.Zero(count, "用户组与角色的关联应该一起删除")
+	suite.Require().NoError(suite.db.Table("customer_role_menu").Where("role_id = ?", free.ID).Count(&count).Error)
+	suite.Zero(count, "角色的菜单授权应该一起删除")
+	suite.False(suite.hasGroupingPolicy(freeSub, auth.MenuToSubject(menu.ID)))
+	suite.False(suite.hasGroupingPolicy(groupSub, freeSub), "用户组继承角色的组策略应该一起移除")
+}
+
+func (suite *BulkDeleteTestSuite) TestDeleteMenus() {
+	ctx := context.Background()
+	parent := suite.createMenu(nil)
+	child := suite.createMenu(&parent.ID)
+	grandchild := suite.createMenu(&child.ID)
+	other := suite.createMenu(nil)
+	button := CreateTestButtonModel(child.ID)
+	suite.Require().NoError(suite.db.Create(button).Error)
+	role := CreateTestRoleModel()
+	role.Menus = []custmodel.MenuModel{*parent, *other}
+	role.Buttons = []custmodel.ButtonModel{*button}
+	suite.Require().NoError(suite.db.Create(role).Error)
+
+	roleSub := auth.RoleToSubject(role.ID)
+	buttonSub := auth.ButtonToSubject(button.ID)
+	suite.Require().NoError(auth.AddGroupPolicies(ctx, suite.enforcer, [][]string{
+		{auth.MenuToSubject(child.ID), auth.MenuToSubject(parent.ID)},
+		{buttonSub, auth.MenuToSubject(child.ID)},
+		{roleSub, auth.MenuToSubject(parent.ID)},
+		{roleSub, auth.MenuToSubject(other.ID)},
+		{roleSub, buttonSub},
+	}))
+
+	deps, rErr := suite.bs.CheckMenuDelete(ctx, []uint32{parent.ID})
+	suite.Require().Nil(rErr)
+	suite.Require().Len(deps.Blocking, 2, "不在删除范围内的各级下级菜单都应该阻止删除")
+	suite.Equal(child.ID, deps.Blocking[0].ID)
+	suite.Equal(grandchild.ID, deps.Blocking[1].ID)
+
+	_, rErr = suite.bs.DeleteMenus(ctx, []uint32{parent.ID, child.ID})
+	suite.Require().NotNil(rErr)
+	suite.Equal(errors.ErrDeleteBlocked.Reason, rErr.Reason)
+
+	deps, rErr = suite.bs.DeleteMenus(ctx, []uint32{grandchild.ID, child.ID, parent.ID})
+	suite.Require().Nil(rErr)
+	suite.True(deps.Deletable())
+	kinds := map[string]int{}
+	for _, d := range deps.Cascades {
+		kinds[d.Kind]++
+	}
+	suite.Equal(map[string]int{custmodel.DependencyButton: 1, custmodel.DependencyRole: 2}, kinds)
+
+	var menus []custmodel.MenuModel
+	suite.Require().NoError(suite.db.Find(&menus).Error)
+	suite.Require().Len(menus, 1)
+	suite.Equal(other.ID, menus[0].ID)
+	var count int64
+	suite.Require().NoError(suite.db.Model(&custmodel.ButtonModel{}).Count(&count).Error)
+	suite.Zero(count, "菜单下的按钮应该一起删除")
+	suite.Require().NoError(suite.db.Table("customer_role_button").Count(&count).Error)
+	suite.Zero(count)
+	suite.Require().NoError(suite.db.Table("customer_role_menu").Count(&count).Error)
+	suite.Equal(int64(1), count, "其他菜单的授权不应该受影响")
+
+	suite.False(suite.hasGroupingPolicy(roleSub, buttonSub))
+	suite.False(suite.hasGroupingPolicy(roleSub, auth.MenuToSubject(parent.ID)))
+	suite.True(suite.hasGroupingPolicy(roleSub, auth.MenuToSubject(other.ID)))
+}
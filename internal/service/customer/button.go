@@ -41,27 +41,24 @@ func (s *ButtonService) GetMenu(
 		return nil, errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始查询按钮关联的菜单",
 		zap.Uint32("menu_id", menuID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	m, err := s.menuRepo.GetModel(ctx, nil, menuID)
 	if err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"查询按钮关联的菜单失败",
 			zap.Error(err),
 			zap.Uint32("menu_id", menuID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.NewGormError(err, map[string]any{"menu_id": menuID})
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"查询按钮关联的菜单成功",
 		zap.Uint32("menu_id", menuID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return m, nil
 }
@@ -78,10 +75,9 @@ func (s *ButtonService) GetApis(
 		return &[]custmodel.ApiModel{}, nil
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始查询按钮关联的API列表",
 		zap.Uint32s("api_ids", apiIDs),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	qp := database.QueryParams{
@@ -89,19 +85,17 @@ func (s *ButtonService) GetApis(
 	}
 	_, ms, err := s.apiRepo.ListModel(ctx, qp)
 	if err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"查询按钮关联的API列表失败",
 			zap.Error(err),
 			zap.Uint32s("api_ids", apiIDs),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.NewGormError(err, nil)
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"查询按钮关联的API列表成功",
 		zap.Uint32s("api_ids", apiIDs),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return ms, nil
 }
@@ -115,11 +109,10 @@ func (s *ButtonService) CreateButton(
 		return nil, errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始创建按钮",
 		zap.Uint32s("api_ids", apiIDs),
 		zap.Object(database.ModelKey, &m),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	var (
@@ -140,11 +133,10 @@ func (s *ButtonService) CreateButton(
 	}
 
 	if err := s.buttonRepo.CreateModel(ctx, &m, apis); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"创建按钮失败",
 			zap.Error(err),
 			zap.Object(database.ModelKey, &m),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.NewGormError(err, nil)
 	}
@@ -154,19 +146,17 @@ func (s *ButtonService) CreateButton(
 	}
 
 	if err := s.buttonRepo.AddGroupPolicy(ctx, &m); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"添加按钮组策略失败",
 			zap.Error(err),
 			zap.Object(database.ModelKey, &m),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.FromError(err)
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"创建按钮成功",
 		zap.Object(database.ModelKey, &m),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return &m, nil
 }
@@ -181,12 +171,11 @@ func (s *ButtonService) UpdateButtonByID(
 		return nil, errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始更新按钮",
 		zap.Uint32("button_id", buttonID),
 		zap.Uint32s("api_ids", apiIDs),
 		zap.Any(database.UpdateDataKey, data),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	var (
@@ -201,12 +190,11 @@ func (s *ButtonService) UpdateButtonByID(
 
 	data["id"] = buttonID
 	if err := s.buttonRepo.UpdateModel(ctx, data, apis, "id = ?", buttonID); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"更新按钮失败",
 			zap.Error(err),
 			zap.Uint32("button_id", buttonID),
 			zap.Any(database.UpdateDataKey, data),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.NewGormError(err, data)
 	}
@@ -218,29 +206,26 @@ func (s *ButtonService) UpdateButtonByID(
 	}
 
 	if err := s.buttonRepo.RemoveGroupPolicy(ctx, m, false); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"移除旧按钮组策略失败",
 			zap.Error(err),
 			zap.Uint32("button_id", buttonID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.FromError(err)
 	}
 
 	if err := s.buttonRepo.AddGroupPolicy(ctx, m); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"添加新按钮组策略失败",
 			zap.Error(err),
 			zap.Uint32("button_id", buttonID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.FromError(err)
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"更新按钮成功",
 		zap.Uint32("button_id", buttonID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return m, nil
 }
@@ -253,10 +238,9 @@ func (s *ButtonService) DeleteButtonByID(
 		return errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始删除按钮",
 		zap.Uint32("button_id", buttonID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	m, rErr := s.FindButtonByID(ctx, []string{"Menu", "Apis"}, buttonID)
@@ -265,29 +249,26 @@ func (s *ButtonService) DeleteButtonByID(
 	}
 
 	if err := s.buttonRepo.DeleteModel(ctx, buttonID); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"删除按钮失败",
 			zap.Error(err),
 			zap.Uint32("button_id", buttonID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return errors.NewGormError(err, map[string]any{"id": buttonID})
 	}
 
 	if err := s.buttonRepo.RemoveGroupPolicy(ctx, m, true); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"移除按钮组策略失败",
 			zap.Error(err),
 			zap.Uint32("button_id", buttonID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return errors.FromError(err)
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"删除按钮成功",
 		zap.Uint32("button_id", buttonID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return nil
 }
@@ -301,28 +282,25 @@ func (s *ButtonService) FindButtonByID(
 		return nil, errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始查询按钮",
 		zap.Strings(database.PreloadKey, preloads),
 		zap.Uint32("button_id", buttonID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	m, err := s.buttonRepo.GetModel(ctx, preloads, buttonID)
 	if err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"查询按钮失败",
 			zap.Error(err),
 			zap.Uint32("button_id", buttonID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.NewGormError(err, map[string]any{"id": buttonID})
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"查询按钮成功",
 		zap.Uint32("button_id", buttonID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return m, nil
 }
@@ -335,27 +313,24 @@ func (s *ButtonService) ListButton(
 		return 0, nil, errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始查询按钮列表",
 		zap.Object(database.QueryParamsKey, &qp),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	count, ms, err := s.buttonRepo.ListModel(ctx, qp)
 	if err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"查询按钮列表失败",
 			zap.Error(err),
 			zap.Object(database.QueryParamsKey, &qp),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return 0, nil, errors.NewGormError(err, nil)
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"查询按钮列表成功",
 		zap.Object(database.QueryParamsKey, &qp),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return count, ms, nil
 }
@@ -365,9 +340,8 @@ func (s *ButtonService) LoadButtonPolicy(ctx context.Context) *errors.Error {
 		return errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始加载按钮策略",
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	qp := database.QueryParams{
@@ -377,10 +351,9 @@ func (s *ButtonService) LoadButtonPolicy(ctx context.Context) *errors.Error {
 
 	_, bms, rErr := s.ListButton(ctx, qp)
 	if rErr != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"加载按钮策略时查询按钮列表失败",
 			zap.Error(rErr),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return rErr
 	}
@@ -391,21 +364,19 @@ func (s *ButtonService) LoadButtonPolicy(ctx context.Context) *errors.Error {
 		policyCount = len(ms)
 		for i := range ms {
 			if err := s.buttonRepo.AddGroupPolicy(ctx, &ms[i]); err != nil {
-				s.log.Error(
+				ctxutil.Logger(ctx, s.log).Error(
 					"加载按钮策略失败",
 					zap.Error(err),
 					zap.Uint32("menu_id", ms[i].ID),
-					zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 				)
 				return errors.FromError(err)
 			}
 		}
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"加载按钮策略成功",
 		zap.Int("policy_count", policyCount),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return nil
 }
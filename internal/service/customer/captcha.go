@@ -0,0 +1,89 @@
+package customer
+
+import (
+	"context"
+	"encoding/base64"
+	"time"
+
+	"go.uber.org/zap"
+
+	custmodel "gin-artweb/internal/model/customer"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/errors"
+	"gin-artweb/pkg/captcha"
+)
+
+// captchaLength 验证码位数
+const captchaLength = 4
+
+type CaptchaService struct {
+	log   *zap.Logger
+	store captcha.Store
+	ttl   time.Duration
+}
+
+func NewCaptchaService(
+	log *zap.Logger,
+	store captcha.Store,
+	ttl time.Duration,
+) *CaptchaService {
+	return &CaptchaService{
+		log:   log,
+		store: store,
+		ttl:   ttl,
+	}
+}
+
+// Generate 生成验证码挑战, 答案只保存在服务端
+func (s *CaptchaService) Generate(ctx context.Context) (*custmodel.CaptchaOut, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	c, err := captcha.New(captchaLength)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"生成验证码失败",
+			zap.Error(err),
+		)
+		return nil, errors.FromError(err)
+	}
+	if err := s.store.Set(c.ID, c.Answer, s.ttl); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"保存验证码失败",
+			zap.Error(err),
+		)
+		return nil, errors.FromError(err)
+	}
+
+	return &custmodel.CaptchaOut{
+		CaptchaID: c.ID,
+		Image:     "data:image/png;base64," + base64.StdEncoding.EncodeToString(c.Image),
+		ExpiresIn: int(s.ttl / time.Second),
+	}, nil
+}
+
+// Verify 校验验证码答案, 挑战无论是否通过都只能使用一次
+func (s *CaptchaService) Verify(ctx context.Context, id, answer string) *errors.Error {
+	if ctx.Err() != nil {
+		return errors.FromError(ctx.Err())
+	}
+
+	ok, err := s.store.Verify(id, answer)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"校验验证码失败",
+			zap.Error(err),
+			zap.String("captcha_id", id),
+		)
+		return errors.FromError(err)
+	}
+	if !ok {
+		ctxutil.Logger(ctx, s.log).Warn(
+			"验证码错误或已过期",
+			zap.String("captcha_id", id),
+		)
+		return errors.ErrCaptchaInvalid
+	}
+	return nil
+}
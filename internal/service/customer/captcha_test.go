@@ -0,0 +1,113 @@
+package customer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	custmodel "gin-artweb/internal/model/customer"
+	custsvc "gin-artweb/internal/repository/customer"
+	"gin-artweb/internal/shared/auth"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+	"gin-artweb/internal/shared/test"
+	"gin-artweb/pkg/captcha"
+	"gin-artweb/pkg/crypto"
+)
+
+type CaptchaTestSuite struct {
+	suite.Suite
+	store *captcha.MemoryStore
+	cs    *CaptchaService
+	uc    *UserService
+}
+
+func (suite *CaptchaTestSuite) SetupSuite() {
+	db := test.NewTestGormDBWithConfig(nil)
+	db.AutoMigrate(
+		&custmodel.MenuModel{},
+		&custmodel.ApiModel{},
+		&custmodel.ButtonModel{},
+		&custmodel.RoleModel{},
+		&custmodel.UserModel{},
+		&custmodel.LoginRecordModel{},
+		&custmodel.UserSessionModel{},
+	)
+	dbTimeout := test.NewTestDBTimeouts()
+	logger := test.NewTestZapLogger()
+	enforcer, _ := auth.NewCasbinEnforcer()
+	jwtConf := auth.NewJWTConfig(
+		time.Duration(10)*time.Second,
+		time.Duration(10)*time.Minute,
+		"HS256",
+		"HS256",
+		[]byte("test_access_secret"),
+		[]byte("test_refresh_secret"),
+	)
+
+	suite.store = captcha.NewMemoryStore(time.Minute)
+	suite.cs = NewCaptchaService(logger, suite.store, time.Minute)
+	suite.uc = NewUserService(
+		logger,
+		custsvc.NewRoleRepo(logger, db, dbTimeout, enforcer),
+		custsvc.NewUserRepo(logger, db, dbTimeout),
+		custsvc.NewLoginRecordRepo(logger, db, dbTimeout, time.Minute, time.Minute, 5),
+		crypto.NewBcryptHasher(4),
+		jwtConf,
+		SecuritySettings{MaxFailedAttempts: 5, PasswordStrength: 3, CaptchaThreshold: 2},
+		nil,
+		NewSessionService(logger, custsvc.NewUserSessionRepo(logger, db, dbTimeout), jwtConf, nil),
+		suite.cs,
+		database.NewTxManager(db),
+	)
+}
+
+func TestCaptchaTestSuite(t *testing.T) {
+	suite.Run(t, new(CaptchaTestSuite))
+}
+
+func (suite *CaptchaTestSuite) TestGenerate() {
+	out, rErr := suite.cs.Generate(context.Background())
+	suite.Require().Nil(rErr)
+	suite.NotEmpty(out.CaptchaID)
+	suite.Contains(out.Image, "data:image/png;base64,")
+	suite.Equal(60, out.ExpiresIn)
+
+	// 错误答案也会使挑战失效
+	suite.Equal(errors.ErrCaptchaInvalid.Reason, suite.cs.Verify(context.Background(), out.CaptchaID, "").Reason)
+}
+
+func (suite *CaptchaTestSuite) TestLoginRequiresCaptcha() {
+	ctx := context.Background()
+	role := CreateTestRoleModel()
+	suite.Require().NoError(suite.uc.roleRepo.CreateModel(ctx, role, nil, nil, nil))
+	user, rErr := suite.uc.CreateUser(ctx, *CreateTestUserModel(role.ID))
+	suite.Require().Nil(rErr)
+	ip := "10.1.1.1"
+
+	_, _, rErr = suite.uc.Login(ctx, user.Username, "wrong", ip, "test", "", "")
+	suite.Equal(false, rErr.Data["captcha_required"])
+	_, _, rErr = suite.uc.Login(ctx, user.Username, "wrong", ip, "test", "", "")
+	suite.Equal(true, rErr.Data["captcha_required"])
+
+	// 达到阈值后不提交验证码时拒绝登录, 不计入失败次数
+	_, _, rErr = suite.uc.Login(ctx, user.Username, "Test123!@#$%", ip, "test", "", "")
+	suite.Equal(errors.ErrCaptchaRequired.Reason, rErr.Reason)
+	suite.Equal(3, rErr.Data["remaining_attempts"])
+
+	id := uuid.NewString()
+	suite.Require().NoError(suite.store.Set(id, "1234", time.Minute))
+	_, _, rErr = suite.uc.Login(ctx, user.Username, "Test123!@#$%", ip, "test", id, "4321")
+	suite.Equal(errors.ErrCaptchaInvalid.Reason, rErr.Reason)
+
+	suite.Require().NoError(suite.store.Set(id, "1234", time.Minute))
+	_, _, rErr = suite.uc.Login(ctx, user.Username, "Test123!@#$%", ip, "test", id, "1234")
+	suite.Nil(rErr)
+
+	// 其他IP不受影响
+	_, _, rErr = suite.uc.Login(ctx, user.Username, "Test123!@#$%", "10.1.1.2", "test", "", "")
+	suite.Nil(rErr)
+}
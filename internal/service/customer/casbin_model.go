@@ -0,0 +1,302 @@
+package customer
+
+import (
+	"context"
+	"sync"
+
+	"github.com/casbin/casbin/v2"
+	"go.uber.org/zap"
+
+	custmodel "gin-artweb/internal/model/customer"
+	custrepo "gin-artweb/internal/repository/customer"
+	"gin-artweb/internal/shared/auth"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+)
+
+type CasbinModelService struct {
+	log       *zap.Logger
+	modelRepo *custrepo.CasbinModelRepo
+	enforcer  *casbin.Enforcer
+	mu        sync.Mutex
+}
+
+func NewCasbinModelService(
+	log *zap.Logger,
+	modelRepo *custrepo.CasbinModelRepo,
+	enforcer *casbin.Enforcer,
+) *CasbinModelService {
+	return &CasbinModelService{
+		log:       log,
+		modelRepo: modelRepo,
+		enforcer:  enforcer,
+	}
+}
+
+// FindActiveModel 查询当前生效的模型配置，数据库中没有记录时返回内置模型
+func (s *CasbinModelService) FindActiveModel(ctx context.Context) (*custmodel.CasbinModelModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始查询生效的Casbin模型配置",
+	)
+
+	m, err := s.modelRepo.GetModel(ctx, "is_active = ?", true)
+	if err != nil {
+		rErr := errors.NewGormError(err, nil)
+		if !rErr.Is(errors.ErrRecordNotFound) {
+			ctxutil.Logger(ctx, s.log).Error(
+				"查询生效的Casbin模型配置失败",
+				zap.Error(err),
+			)
+			return nil, rErr
+		}
+		m = &custmodel.CasbinModelModel{
+			Content:  auth.DefaultCasbinModel,
+			Remark:   "内置模型",
+			IsActive: true,
+		}
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"查询生效的Casbin模型配置成功",
+		zap.Object(database.ModelKey, m),
+	)
+	return m, nil
+}
+
+func (s *CasbinModelService) ListModel(
+	ctx context.Context,
+	qp database.QueryParams,
+) (int64, *[]custmodel.CasbinModelModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return 0, nil, errors.FromError(ctx.Err())
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始查询Casbin模型历史版本",
+		zap.Object(database.QueryParamsKey, &qp),
+	)
+
+	total, ms, err := s.modelRepo.ListModel(ctx, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询Casbin模型历史版本失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		return 0, nil, errors.NewGormError(err, nil)
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"查询Casbin模型历史版本成功",
+		zap.Object(database.QueryParamsKey, &qp),
+	)
+	return total, ms, nil
+}
+
+// ValidateModel 解析模型配置，并使用当前策略对样例请求求值
+// 返回的错误仅表示模型无法解析，样例结果是否通过由返回值passed表示
+func (s *CasbinModelService) ValidateModel(
+	ctx context.Context,
+	content string,
+	samples []auth.CasbinSample,
+) ([]auth.CasbinSampleResult, bool, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, false, errors.FromError(ctx.Err())
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始校验Casbin模型配置",
+		zap.Int("samples", len(samples)),
+	)
+
+	results, err := auth.EvaluateCasbinModel(ctx, s.enforcer, content, samples)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"校验Casbin模型配置失败",
+			zap.Error(err),
+		)
+		return nil, false, errors.ErrCasbinModelInvalid.WithCause(err)
+	}
+
+	passed := true
+	for _, r := range results {
+		if !r.Passed {
+			passed = false
+			break
+		}
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"校验Casbin模型配置完成",
+		zap.Bool("passed", passed),
+	)
+	return results, passed, nil
+}
+
+// UpdateModel 校验并应用新的模型配置，成功后保存为新的生效版本
+func (s *CasbinModelService) UpdateModel(
+	ctx context.Context,
+	content string,
+	samples []auth.CasbinSample,
+	remark, operator string,
+) (*custmodel.CasbinModelModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	results, passed, rErr := s.ValidateModel(ctx, content, samples)
+	if rErr != nil {
+		return nil, rErr
+	}
+	if !passed {
+		return nil, errors.ErrCasbinModelSampleFailed.WithField("results", results)
+	}
+
+	current, rErr := s.FindActiveModel(ctx)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	m := custmodel.CasbinModelModel{
+		Content:  content,
+		Remark:   remark,
+		Operator: operator,
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始更新Casbin模型配置",
+		zap.Object(database.ModelKey, &m),
+	)
+
+	if err := auth.ApplyCasbinModel(ctx, s.enforcer, content); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"应用Casbin模型配置失败",
+			zap.Error(err),
+		)
+		s.restoreModel(ctx, current.Content)
+		return nil, errors.ErrCasbinModelApplyFailed.WithCause(err)
+	}
+
+	if err := s.modelRepo.CreateActiveModel(ctx, &m); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"保存Casbin模型配置失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, &m),
+		)
+		s.restoreModel(ctx, current.Content)
+		return nil, errors.NewGormError(err, nil)
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"更新Casbin模型配置成功",
+		zap.Object(database.ModelKey, &m),
+	)
+	return &m, nil
+}
+
+// RollbackModel 回滚到当前生效版本的上一个版本
+func (s *CasbinModelService) RollbackModel(ctx context.Context) (*custmodel.CasbinModelModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current, rErr := s.FindActiveModel(ctx)
+	if rErr != nil {
+		return nil, rErr
+	}
+	if current.ID == 0 {
+		return nil, errors.ErrCasbinModelNoHistory
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始回滚Casbin模型配置",
+		zap.Object(database.ModelKey, current),
+	)
+
+	qp := database.QueryParams{
+		Size:    1,
+		Page:    1,
+		OrderBy: []string{"version DESC"},
+		Query:   map[string]any{"version < ?": current.Version},
+	}
+	_, ms, err := s.modelRepo.ListModel(ctx, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询上一个Casbin模型版本失败",
+			zap.Error(err),
+		)
+		return nil, errors.NewGormError(err, nil)
+	}
+	if ms == nil || len(*ms) == 0 {
+		return nil, errors.ErrCasbinModelNoHistory
+	}
+	previous := (*ms)[0]
+
+	if err := auth.ApplyCasbinModel(ctx, s.enforcer, previous.Content); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"应用上一个Casbin模型版本失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, &previous),
+		)
+		s.restoreModel(ctx, current.Content)
+		return nil, errors.ErrCasbinModelApplyFailed.WithCause(err)
+	}
+
+	if err := s.modelRepo.ActivateModel(ctx, previous.ID); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"切换Casbin模型生效版本失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, &previous),
+		)
+		s.restoreModel(ctx, current.Content)
+		return nil, errors.NewGormError(err, nil)
+	}
+	previous.IsActive = true
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"回滚Casbin模型配置成功",
+		zap.Object(database.ModelKey, &previous),
+	)
+	return &previous, nil
+}
+
+// LoadActiveModel 系统启动时加载数据库中生效的模型配置，需在加载策略前调用
+func (s *CasbinModelService) LoadActiveModel(ctx context.Context) *errors.Error {
+	m, rErr := s.FindActiveModel(ctx)
+	if rErr != nil {
+		return rErr
+	}
+	if m.ID == 0 {
+		return nil
+	}
+	if err := auth.ApplyCasbinModel(ctx, s.enforcer, m.Content); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"加载Casbin模型配置失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, m),
+		)
+		return errors.ErrCasbinModelApplyFailed.WithCause(err)
+	}
+	return nil
+}
+
+// restoreModel 应用新模型失败后恢复原有模型
+func (s *CasbinModelService) restoreModel(ctx context.Context, content string) {
+	if err := auth.ApplyCasbinModel(ctx, s.enforcer, content); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"恢复Casbin模型配置失败",
+			zap.Error(err),
+		)
+	}
+}
@@ -0,0 +1,111 @@
+package customer
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/casbin/casbin/v2"
+	"github.com/stretchr/testify/suite"
+
+	custmodel "gin-artweb/internal/model/customer"
+	custrepo "gin-artweb/internal/repository/customer"
+	"gin-artweb/internal/shared/auth"
+	"gin-artweb/internal/shared/errors"
+	"gin-artweb/internal/shared/test"
+)
+
+// keyMatchModel 使用keyMatch2匹配路径参数的测试模型
+var keyMatchModel = strings.Replace(
+	auth.DefaultCasbinModel,
+	"r.obj == p.obj",
+	"keyMatch2(r.obj, p.obj)",
+	1,
+)
+
+type CasbinModelServiceTestSuite struct {
+	suite.Suite
+	enforcer     *casbin.Enforcer
+	modelService *CasbinModelService
+}
+
+func (suite *CasbinModelServiceTestSuite) SetupTest() {
+	db := test.NewTestGormDBWithConfig(nil)
+	db.AutoMigrate(&custmodel.CasbinModelModel{})
+	dbTimeout := test.NewTestDBTimeouts()
+	logger := test.NewTestZapLogger()
+	enforcer, _ := auth.NewCasbinEnforcer()
+	enforcer.AddPolicy("api_1", "/api/v1/mon/node/:id", "GET")
+	enforcer.AddGroupingPolicy("role_1", "api_1")
+	suite.enforcer = enforcer
+	suite.modelService = NewCasbinModelService(
+		logger,
+		custrepo.NewCasbinModelRepo(logger, db, dbTimeout),
+		enforcer,
+	)
+}
+
+func (suite *CasbinModelServiceTestSuite) TestFindActiveModelDefault() {
+	m, err := suite.modelService.FindActiveModel(context.Background())
+	suite.Nil(err, "没有保存的模型时应该返回内置模型")
+	suite.Equal(uint32(0), m.ID)
+	suite.Equal(auth.DefaultCasbinModel, m.Content)
+}
+
+func (suite *CasbinModelServiceTestSuite) TestValidateModel() {
+	samples := []auth.CasbinSample{
+		{Sub: "role_1", Obj: "/api/v1/mon/node/1", Act: "GET", Expect: true},
+		{Sub: "role_1", Obj: "/api/v1/mon/node/1", Act: "DELETE", Expect: false},
+	}
+	results, passed, err := suite.modelService.ValidateModel(context.Background(), keyMatchModel, samples)
+	suite.Nil(err, "校验合法模型应该成功")
+	suite.True(passed, "样例请求应该全部通过")
+	suite.Len(results, 2)
+
+	// 内置模型不支持路径参数, 第一个样例应该不通过
+	_, passed, err = suite.modelService.ValidateModel(context.Background(), auth.DefaultCasbinModel, samples)
+	suite.Nil(err)
+	suite.False(passed, "内置模型不应该匹配路径参数")
+
+	// 非法模型
+	_, _, err = suite.modelService.ValidateModel(context.Background(), "[request_definition]\nr = sub, obj\n", nil)
+	suite.NotNil(err, "非法模型应该返回错误")
+	suite.True(err.Is(errors.ErrCasbinModelInvalid))
+}
+
+func (suite *CasbinModelServiceTestSuite) TestUpdateAndRollbackModel() {
+	samples := []auth.CasbinSample{
+		{Sub: "role_1", Obj: "/api/v1/mon/node/1", Act: "GET", Expect: true},
+	}
+
+	// 样例不通过时不应该生效
+	_, err := suite.modelService.UpdateModel(context.Background(), auth.DefaultCasbinModel, samples, "v0", "admin")
+	suite.NotNil(err, "样例不通过时更新应该失败")
+	suite.True(err.Is(errors.ErrCasbinModelSampleFailed))
+
+	m1, err := suite.modelService.UpdateModel(context.Background(), auth.DefaultCasbinModel, nil, "v1", "admin")
+	suite.Nil(err, "更新模型应该成功")
+	suite.Equal(uint32(1), m1.Version)
+
+	m2, err := suite.modelService.UpdateModel(context.Background(), keyMatchModel, samples, "v2", "admin")
+	suite.Nil(err, "更新模型应该成功")
+	suite.Equal(uint32(2), m2.Version)
+	ok, _ := suite.enforcer.Enforce("role_1", "/api/v1/mon/node/1", "GET")
+	suite.True(ok, "新模型应该立即生效且保留原有策略")
+
+	rm, err := suite.modelService.RollbackModel(context.Background())
+	suite.Nil(err, "回滚模型应该成功")
+	suite.Equal(m1.ID, rm.ID, "应该回滚到上一个版本")
+	ok, _ = suite.enforcer.Enforce("role_1", "/api/v1/mon/node/1", "GET")
+	suite.False(ok, "回滚后应该恢复内置匹配规则")
+	ok, _ = suite.enforcer.Enforce("role_1", "/api/v1/mon/node/:id", "GET")
+	suite.True(ok, "回滚后应该保留原有策略")
+
+	_, err = suite.modelService.RollbackModel(context.Background())
+	suite.NotNil(err, "没有更早的版本时回滚应该失败")
+	suite.True(err.Is(errors.ErrCasbinModelNoHistory))
+}
+
+func TestCasbinModelServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(CasbinModelServiceTestSuite))
+}
@@ -0,0 +1,411 @@
+package customer
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"go.uber.org/zap"
+
+	custmodel "gin-artweb/internal/model/customer"
+	custrepo "gin-artweb/internal/repository/customer"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+)
+
+// ownedItemsLimit 名下记录报告中每类记录最多列出的条数
+const ownedItemsLimit = 50
+
+// OwnedObject 归属于用户的一条记录
+type OwnedObject struct {
+	ID   uint32
+	Name string
+}
+
+// OwnershipSource 一类归属于用户的记录
+//
+// 记录通过用户名关联创建人, 删除用户后关联会失效, 因此停用用户时需要报告并转移给其他用户
+type OwnershipSource interface {
+	// Kind 记录类型, 交接时用于指定转移的记录
+	Kind() string
+
+	// Label 记录类型名称
+	Label() string
+
+	// ListOwned 返回用户名下记录的总数和前limit条记录
+	ListOwned(ctx context.Context, username string, limit int) (int64, []OwnedObject, error)
+
+	// Reassign 将from名下的全部记录转移给to, 返回转移的记录数量
+	//
+	// 交接的全部类型在同一个事务中转移, 需要使用传入的上下文调用仓库
+	Reassign(ctx context.Context, from, to string) (int64, error)
+
+	// Reassigned 交接的事务提交后执行的操作, 如重新加载内存中仍使用原用户名的定时任务
+	Reassigned(ctx context.Context, to string) error
+}
+
+// DBOwnershipSource 通过username字段归属于用户的数据库记录
+type DBOwnershipSource[T any] struct {
+	kind   string
+	label  string
+	list   func(context.Context, database.QueryParams) (int64, *[]T, error)
+	update func(context.Context, map[string]any, ...any) error
+	toObj  func(T) OwnedObject
+	after  func(context.Context, string) error
+}
+
+// NewDBOwnershipSource 创建数据库记录的归属来源
+// list: 记录仓库的ListModel
+// update: 记录仓库的UpdateModel
+func NewDBOwnershipSource[T any](
+	kind, label string,
+	list func(context.Context, database.QueryParams) (int64, *[]T, error),
+	update func(context.Context, map[string]any, ...any) error,
+	toObj func(T) OwnedObject,
+) *DBOwnershipSource[T] {
+	return &DBOwnershipSource[T]{
+		kind:   kind,
+		label:  label,
+		list:   list,
+		update: update,
+		toObj:  toObj,
+	}
+}
+
+// AfterReassign 设置交接的事务提交后执行的操作, 如重新加载内存中仍使用原用户名的定时任务
+func (s *DBOwnershipSource[T]) AfterReassign(fn func(ctx context.Context, to string) error) *DBOwnershipSource[T] {
+	s.after = fn
+	return s
+}
+
+func (s *DBOwnershipSource[T]) Kind() string { return s.kind }
+
+func (s *DBOwnershipSource[T]) Label() string { return s.label }
+
+func (s *DBOwnershipSource[T]) ListOwned(ctx context.Context, username string, limit int) (int64, []OwnedObject, error) {
+	qp := database.QueryParams{
+		Query:   map[string]any{"username = ?": username},
+		OrderBy: []string{"id ASC"},
+		Size:    limit,
+		IsCount: true,
+	}
+	total, ms, err := s.list(ctx, qp)
+	if err != nil {
+		return 0, nil, err
+	}
+	objs := make([]OwnedObject, 0, len(*ms))
+	for _, m := range *ms {
+		objs = append(objs, s.toObj(m))
+	}
+	return total, objs, nil
+}
+
+func (s *DBOwnershipSource[T]) Reassign(ctx context.Context, from, to string) (int64, error) {
+	total, _, err := s.ListOwned(ctx, from, 1)
+	if err != nil || total == 0 {
+		return 0, err
+	}
+	if err := s.update(ctx, map[string]any{"username": to}, "username = ?", from); err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+func (s *DBOwnershipSource[T]) Reassigned(ctx context.Context, to string) error {
+	if s.after == nil {
+		return nil
+	}
+	return s.after(ctx, to)
+}
+
+// DeactivationService 用户停用服务
+//
+// 离职用户先停用并终止全部会话, 宽限期内可以重新启用, 到期后由定时任务永久停用;
+// 停用期间可以查询用户名下仍有的记录并转移给其他用户, 避免直接删除用户导致历史记录的关联失效
+type DeactivationService struct {
+	log      *zap.Logger
+	userRepo *custrepo.UserRepo
+	sessions *SessionService
+	tx       *database.TxManager
+	sources  []OwnershipSource
+}
+
+func NewDeactivationService(
+	log *zap.Logger,
+	userRepo *custrepo.UserRepo,
+	sessions *SessionService,
+	tx *database.TxManager,
+) *DeactivationService {
+	return &DeactivationService{
+		log:      log,
+		userRepo: userRepo,
+		sessions: sessions,
+		tx:       tx,
+	}
+}
+
+// Register 注册归属来源, 只能在服务启动时调用, 报告按注册顺序分组
+func (s *DeactivationService) Register(sources ...OwnershipSource) {
+	s.sources = append(s.sources, sources...)
+}
+
+// DeactivateUser 停用用户, graceDays天后永久停用, 同时终止用户的全部会话
+//
+// 停用和终止会话在同一个事务中执行, 终止会话失败时用户保持启用; 返回停用后的用户和终止的会话数量
+func (s *DeactivationService) DeactivateUser(
+	ctx context.Context,
+	userID uint32,
+	graceDays int,
+) (*custmodel.UserModel, int64, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, 0, errors.FromError(ctx.Err())
+	}
+
+	if claims, rErr := ctxutil.GetUserClaims(ctx); rErr == nil && claims.UserID == userID {
+		return nil, 0, errors.ErrUserDeactivateSelf
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始停用用户",
+		zap.Uint32("user_id", userID),
+		zap.Int("grace_days", graceDays),
+	)
+
+	m, rErr := s.getUser(ctx, userID)
+	if rErr != nil {
+		return nil, 0, rErr
+	}
+	if m.DisabledAt != nil {
+		return nil, 0, errors.ErrUserDisabled
+	}
+
+	now := time.Now()
+	data := map[string]any{
+		"is_active":      false,
+		"deactivated_at": now,
+		"disable_at":     now.AddDate(0, 0, graceDays),
+	}
+	var revoked int64
+	rErr = database.Transactional(ctx, s.tx, func(ctx context.Context) *errors.Error {
+		if err := s.userRepo.UpdateModel(ctx, data, "id = ?", userID); err != nil {
+			ctxutil.Logger(ctx, s.log).Error(
+				"停用用户失败",
+				zap.Error(err),
+				zap.Uint32("user_id", userID),
+				zap.Any(database.UpdateDataKey, data),
+			)
+			return errors.NewGormError(err, data)
+		}
+		var rErr *errors.Error
+		revoked, rErr = s.sessions.DeleteOtherUserSession(ctx, userID, "")
+		return rErr
+	}, func(err error) *errors.Error { return errors.NewGormError(err, data) })
+	if rErr != nil {
+		return nil, 0, rErr
+	}
+
+	m, rErr = s.getUser(ctx, userID)
+	if rErr != nil {
+		return nil, 0, rErr
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"停用用户成功",
+		zap.Uint32("user_id", userID),
+		zap.String("username", m.Username),
+		zap.Int64("revoked_sessions", revoked),
+	)
+	return m, revoked, nil
+}
+
+// ListOwned 查询用户名下的记录, 没有记录的类型不返回
+func (s *DeactivationService) ListOwned(
+	ctx context.Context,
+	userID uint32,
+) ([]custmodel.OwnedGroupOut, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	m, rErr := s.getUser(ctx, userID)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	groups := make([]custmodel.OwnedGroupOut, 0, len(s.sources))
+	for _, src := range s.sources {
+		total, objs, err := src.ListOwned(ctx, m.Username, ownedItemsLimit)
+		if err != nil {
+			ctxutil.Logger(ctx, s.log).Error(
+				"查询用户名下的记录失败",
+				zap.Error(err),
+				zap.Uint32("user_id", userID),
+				zap.String("kind", src.Kind()),
+			)
+			return nil, errors.NewGormError(err, nil)
+		}
+		if total == 0 {
+			continue
+		}
+		items := make([]custmodel.OwnedObjectOut, 0, len(objs))
+		for _, obj := range objs {
+			items = append(items, custmodel.OwnedObjectOut{ID: obj.ID, Name: obj.Name})
+		}
+		groups = append(groups, custmodel.OwnedGroupOut{
+			Kind:  src.Kind(),
+			Label: src.Label(),
+			Total: total,
+			Items: items,
+		})
+	}
+	return groups, nil
+}
+
+// Handover 将用户名下的记录转移给其他已启用的用户, kinds为空时转移全部类型
+//
+// 全部类型在同一个事务中转移, 任一类型失败时全部回滚; 提交后再执行各类型转移后的操作
+func (s *DeactivationService) Handover(
+	ctx context.Context,
+	fromID, toID uint32,
+	kinds []string,
+) ([]custmodel.HandoverGroupOut, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	sources := s.sources
+	if len(kinds) > 0 {
+		kinds = slices.Compact(slices.Sorted(slices.Values(kinds)))
+		sources = make([]OwnershipSource, 0, len(kinds))
+		for _, src := range s.sources {
+			if slices.Contains(kinds, src.Kind()) {
+				sources = append(sources, src)
+			}
+		}
+		if len(sources) != len(kinds) {
+			return nil, errors.ErrHandoverKindInvalid.WithField("kinds", kinds)
+		}
+	}
+
+	if fromID == toID {
+		return nil, errors.ErrHandoverTargetInvalid
+	}
+	from, rErr := s.getUser(ctx, fromID)
+	if rErr != nil {
+		return nil, rErr
+	}
+	to, rErr := s.getUser(ctx, toID)
+	if rErr != nil {
+		return nil, rErr
+	}
+	if !to.IsActive || to.DeactivatedAt != nil {
+		return nil, errors.ErrHandoverTargetInvalid
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始转移用户名下的记录",
+		zap.String("from", from.Username),
+		zap.String("to", to.Username),
+		zap.Strings("kinds", kinds),
+	)
+
+	var groups []custmodel.HandoverGroupOut
+	rErr = database.Transactional(ctx, s.tx, func(ctx context.Context) *errors.Error {
+		groups = make([]custmodel.HandoverGroupOut, 0, len(sources))
+		for _, src := range sources {
+			n, err := src.Reassign(ctx, from.Username, to.Username)
+			if err != nil {
+				ctxutil.Logger(ctx, s.log).Error(
+					"转移用户名下的记录失败, 回滚已转移的记录",
+					zap.Error(err),
+					zap.String("kind", src.Kind()),
+					zap.String("from", from.Username),
+					zap.String("to", to.Username),
+				)
+				return errors.NewGormError(err, nil)
+			}
+			groups = append(groups, custmodel.HandoverGroupOut{
+				Kind:       src.Kind(),
+				Label:      src.Label(),
+				Reassigned: n,
+			})
+		}
+		return nil
+	}, func(err error) *errors.Error { return errors.NewGormError(err, nil) })
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	// 记录已经转移, 转移后的操作失败只记录日志, 不影响交接结果
+	for i, src := range sources {
+		if groups[i].Reassigned == 0 {
+			continue
+		}
+		if err := src.Reassigned(ctx, to.Username); err != nil {
+			ctxutil.Logger(ctx, s.log).Error(
+				"执行转移后的操作失败",
+				zap.Error(err),
+				zap.String("kind", src.Kind()),
+				zap.String("to", to.Username),
+			)
+		}
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"转移用户名下的记录成功",
+		zap.String("from", from.Username),
+		zap.String("to", to.Username),
+	)
+	return groups, nil
+}
+
+// DisableExpired 永久停用宽限期已到的用户
+func (s *DeactivationService) DisableExpired(ctx context.Context) *errors.Error {
+	if ctx.Err() != nil {
+		return errors.FromError(ctx.Err())
+	}
+
+	now := time.Now()
+	_, ms, err := s.userRepo.ListModel(ctx, database.QueryParams{
+		Query: map[string]any{"disabled_at IS NULL AND disable_at <= ?": now},
+	})
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询宽限期已到的用户失败",
+			zap.Error(err),
+		)
+		return errors.NewGormError(err, nil)
+	}
+
+	for _, m := range *ms {
+		data := map[string]any{"is_active": false, "disabled_at": now}
+		if err := s.userRepo.UpdateModel(ctx, data, "id = ?", m.ID); err != nil {
+			ctxutil.Logger(ctx, s.log).Error(
+				"永久停用用户失败",
+				zap.Error(err),
+				zap.Uint32("user_id", m.ID),
+			)
+			return errors.NewGormError(err, data)
+		}
+		ctxutil.Logger(ctx, s.log).Info(
+			"永久停用用户成功",
+			zap.Uint32("user_id", m.ID),
+			zap.String("username", m.Username),
+		)
+	}
+	return nil
+}
+
+func (s *DeactivationService) getUser(ctx context.Context, userID uint32) (*custmodel.UserModel, *errors.Error) {
+	m, err := s.userRepo.GetModel(ctx, nil, userID)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询用户失败",
+			zap.Error(err),
+			zap.Uint32("user_id", userID),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"id": userID})
+	}
+	return m, nil
+}
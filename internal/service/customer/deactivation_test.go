@@ -0,0 +1,279 @@
+package customer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	custmodel "gin-artweb/internal/model/customer"
+	custrepo "gin-artweb/internal/repository/customer"
+	"gin-artweb/internal/shared/auth"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+	"gin-artweb/internal/shared/test"
+)
+
+// memOwnershipSource 保存在内存中的归属来源, 键为记录名称, 值为所属用户名
+type memOwnershipSource struct {
+	kind       string
+	owner      map[string]string
+	err        error    // 不为nil时转移失败
+	reassigned []string // 转移后的操作收到的接收用户
+}
+
+func (s *memOwnershipSource) Kind() string { return s.kind }
+
+func (s *memOwnershipSource) Label() string { return s.kind }
+
+func (s *memOwnershipSource) ListOwned(_ context.Context, username string, limit int) (int64, []OwnedObject, error) {
+	var total int64
+	var objs []OwnedObject
+	for name, owner := range s.owner {
+		if owner != username {
+			continue
+		}
+		total++
+		if len(objs) < limit {
+			objs = append(objs, OwnedObject{ID: uint32(total), Name: name})
+		}
+	}
+	return total, objs, nil
+}
+
+func (s *memOwnershipSource) Reassign(_ context.Context, from, to string) (int64, error) {
+	if s.err != nil {
+		return 0, s.err
+	}
+	var n int64
+	for name, owner := range s.owner {
+		if owner == from {
+			s.owner[name] = to
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (s *memOwnershipSource) Reassigned(_ context.Context, to string) error {
+	s.reassigned = append(s.reassigned, to)
+	return nil
+}
+
+// handoverTestModel 通过username字段归属于用户的测试记录
+type handoverTestModel struct {
+	ID       uint32 `gorm:"primaryKey"`
+	Name     string
+	Username string
+}
+
+type DeactivationTestSuite struct {
+	suite.Suite
+	db       *gorm.DB
+	userRepo *custrepo.UserRepo
+	sessions *SessionService
+	ds       *DeactivationService
+}
+
+func (suite *DeactivationTestSuite) SetupSuite() {
+	db := test.NewTestGormDBWithConfig(nil)
+	db.AutoMigrate(
+		&custmodel.RoleModel{},
+		&custmodel.UserModel{},
+		&custmodel.UserSessionModel{},
+		&handoverTestModel{},
+	)
+	suite.db = db
+	dbTimeout := test.NewTestDBTimeouts()
+	logger := test.NewTestZapLogger()
+	jwt := auth.NewJWTConfig(
+		time.Duration(10)*time.Minute,
+		time.Duration(1)*time.Hour,
+		"HS256",
+		"HS256",
+		[]byte("test_access_secret"),
+		[]byte("test_refresh_secret"),
+	)
+	suite.sessions = NewSessionService(logger, custrepo.NewUserSessionRepo(logger, db, dbTimeout), jwt, nil)
+	jwt.Sessions = suite.sessions
+	suite.userRepo = custrepo.NewUserRepo(logger, db, dbTimeout)
+	suite.ds = NewDeactivationService(logger, suite.userRepo, suite.sessions, database.NewTxManager(db))
+}
+
+// dbSource 保存在handoverTestModel表中的归属来源
+func (suite *DeactivationTestSuite) dbSource() *DBOwnershipSource[handoverTestModel] {
+	return NewDBOwnershipSource("db", "测试记录",
+		func(ctx context.Context, qp database.QueryParams) (int64, *[]handoverTestModel, error) {
+			var ms []handoverTestModel
+			total, err := database.DBList(ctx, suite.db, &handoverTestModel{}, &ms, qp)
+			return total, &ms, err
+		},
+		func(ctx context.Context, data map[string]any, conds ...any) error {
+			return database.DBUpdate(ctx, suite.db, &handoverTestModel{}, data, nil, conds...)
+		},
+		func(m handoverTestModel) OwnedObject { return OwnedObject{ID: m.ID, Name: m.Name} },
+	)
+}
+
+func TestDeactivationTestSuite(t *testing.T) {
+	suite.Run(t, new(DeactivationTestSuite))
+}
+
+func (suite *DeactivationTestSuite) createUser() *custmodel.UserModel {
+	m := CreateTestUserModel(1)
+	m.IsActive = true
+	suite.Require().NoError(suite.userRepo.CreateModel(context.Background(), m))
+	return m
+}
+
+func (suite *DeactivationTestSuite) TestDeactivateUser() {
+	ctx := context.Background()
+	user := suite.createUser()
+	ui := auth.UserInfo{UserID: user.ID, Username: user.Username}
+	_, rErr := suite.sessions.Issue(ctx, ui, custmodel.SessionMethodPassword, "10.0.0.1", "curl/8.0")
+	suite.Require().Nil(rErr)
+
+	m, revoked, rErr := suite.ds.DeactivateUser(ctx, user.ID, 7)
+	suite.Require().Nil(rErr)
+	suite.False(m.IsActive)
+	suite.Require().NotNil(m.DeactivatedAt)
+	suite.Require().NotNil(m.DisableAt)
+	suite.WithinDuration(time.Now().AddDate(0, 0, 7), *m.DisableAt, 5*time.Second)
+	suite.Nil(m.DisabledAt)
+	suite.Equal(int64(1), revoked, "停用用户应该终止全部会话")
+
+	// 宽限期未到, 不会永久停用
+	suite.Require().Nil(suite.ds.DisableExpired(ctx))
+	m, err := suite.userRepo.GetModel(ctx, nil, user.ID)
+	suite.Require().NoError(err)
+	suite.Nil(m.DisabledAt)
+}
+
+func (suite *DeactivationTestSuite) TestDeactivateSelf() {
+	user := suite.createUser()
+	claims := &auth.UserClaims{UserInfo: auth.UserInfo{UserID: user.ID, Username: user.Username}}
+	ctx := context.WithValue(context.Background(), ctxutil.UserClaimsKey, claims)
+
+	_, _, rErr := suite.ds.DeactivateUser(ctx, user.ID, 7)
+	suite.Require().NotNil(rErr)
+	suite.True(rErr.Is(errors.ErrUserDeactivateSelf))
+}
+
+func (suite *DeactivationTestSuite) TestDisableExpired() {
+	ctx := context.Background()
+	user := suite.createUser()
+	_, _, rErr := suite.ds.DeactivateUser(ctx, user.ID, 0)
+	suite.Require().Nil(rErr)
+
+	suite.Require().Nil(suite.ds.DisableExpired(ctx))
+	m, err := suite.userRepo.GetModel(ctx, nil, user.ID)
+	suite.Require().NoError(err)
+	suite.NotNil(m.DisabledAt, "宽限期已到的用户应该永久停用")
+
+	_, _, rErr = suite.ds.DeactivateUser(ctx, user.ID, 7)
+	suite.Require().NotNil(rErr)
+	suite.True(rErr.Is(errors.ErrUserDisabled))
+}
+
+func (suite *DeactivationTestSuite) TestHandover() {
+	ctx := context.Background()
+	from := suite.createUser()
+	to := suite.createUser()
+	inactive := suite.createUser()
+	_, _, rErr := suite.ds.DeactivateUser(ctx, inactive.ID, 7)
+	suite.Require().Nil(rErr)
+
+	src := &memOwnershipSource{kind: "mem", owner: map[string]string{
+		"a": from.Username,
+		"b": from.Username,
+		"c": to.Username,
+	}}
+	ds := NewDeactivationService(suite.ds.log, suite.userRepo, suite.sessions, suite.ds.tx)
+	ds.Register(src)
+
+	owned, rErr := ds.ListOwned(ctx, from.ID)
+	suite.Require().Nil(rErr)
+	suite.Require().Len(owned, 1)
+	suite.Equal(int64(2), owned[0].Total)
+	suite.Len(owned[0].Items, 2)
+
+	_, rErr = ds.Handover(ctx, from.ID, to.ID, []string{"unknown"})
+	suite.Require().NotNil(rErr)
+	suite.True(rErr.Is(errors.ErrHandoverKindInvalid))
+
+	_, rErr = ds.Handover(ctx, from.ID, inactive.ID, nil)
+	suite.Require().NotNil(rErr)
+	suite.True(rErr.Is(errors.ErrHandoverTargetInvalid), "不能转移给已停用的用户")
+
+	_, rErr = ds.Handover(ctx, from.ID, from.ID, nil)
+	suite.Require().NotNil(rErr)
+	suite.True(rErr.Is(errors.ErrHandoverTargetInvalid), "不能转移给自己")
+
+	groups, rErr := ds.Handover(ctx, from.ID, to.ID, []string{"mem", "mem"})
+	suite.Require().Nil(rErr)
+	suite.Require().Len(groups, 1)
+	suite.Equal(int64(2), groups[0].Reassigned)
+
+	owned, rErr = ds.ListOwned(ctx, from.ID)
+	suite.Require().Nil(rErr)
+	suite.Empty(owned, "转移后用户名下没有记录")
+	suite.Equal([]string{to.Username}, src.reassigned, "转移提交后执行转移后的操作")
+}
+
+func (suite *DeactivationTestSuite) TestHandoverRollback() {
+	ctx := context.Background()
+	from := suite.createUser()
+	to := suite.createUser()
+	suite.Require().NoError(suite.db.Create(&handoverTestModel{Name: "a", Username: from.Username}).Error)
+
+	var reloaded []string
+	dbSrc := suite.dbSource().AfterReassign(func(_ context.Context, to string) error {
+		reloaded = append(reloaded, to)
+		return nil
+	})
+	failing := &memOwnershipSource{kind: "mem", owner: map[string]string{"b": from.Username}, err: gorm.ErrInvalidDB}
+	ds := NewDeactivationService(suite.ds.log, suite.userRepo, suite.sessions, suite.ds.tx)
+	ds.Register(dbSrc, failing)
+
+	_, rErr := ds.Handover(ctx, from.ID, to.ID, nil)
+	suite.Require().NotNil(rErr)
+	owned, rErr := ds.ListOwned(ctx, from.ID)
+	suite.Require().Nil(rErr)
+	suite.Require().Len(owned, 2, "任一类型转移失败时全部回滚")
+	suite.Equal("db", owned[0].Kind)
+	suite.Empty(reloaded, "回滚时不执行转移后的操作")
+	suite.Empty(failing.reassigned)
+
+	failing.err = nil
+	groups, rErr := ds.Handover(ctx, from.ID, to.ID, nil)
+	suite.Require().Nil(rErr)
+	suite.Require().Len(groups, 2)
+	suite.Equal(int64(1), groups[0].Reassigned)
+	suite.Equal([]string{to.Username}, reloaded)
+}
+
+func (suite *DeactivationTestSuite) TestDeactivateRollback() {
+	ctx := context.Background()
+	db := test.NewTestGormDBWithConfig(nil)
+	suite.Require().NoError(db.AutoMigrate(&custmodel.RoleModel{}, &custmodel.UserModel{}))
+	logger := test.NewTestZapLogger()
+	dbTimeout := test.NewTestDBTimeouts()
+	userRepo := custrepo.NewUserRepo(logger, db, dbTimeout)
+	// 会话表不存在, 终止会话失败
+	sessions := NewSessionService(logger, custrepo.NewUserSessionRepo(logger, db, dbTimeout), suite.sessions.jwt, nil)
+	ds := NewDeactivationService(logger, userRepo, sessions, database.NewTxManager(db))
+
+	m := CreateTestUserModel(1)
+	m.IsActive = true
+	suite.Require().NoError(userRepo.CreateModel(ctx, m))
+
+	_, _, rErr := ds.DeactivateUser(ctx, m.ID, 7)
+	suite.Require().NotNil(rErr)
+	m, err := userRepo.GetModel(ctx, nil, m.ID)
+	suite.Require().NoError(err)
+	suite.True(m.IsActive, "终止会话失败时用户保持启用")
+	suite.Nil(m.DeactivatedAt)
+}
@@ -0,0 +1,457 @@
+package customer
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	custmodel "gin-artweb/internal/model/customer"
+	custrepo "gin-artweb/internal/repository/customer"
+	"gin-artweb/internal/shared/auth"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+)
+
+// DepartmentService 部门管理, 部门组成的组织架构树用于按部门筛选用户和限制角色的数据范围
+type DepartmentService struct {
+	log      *zap.Logger
+	deptRepo *custrepo.DepartmentRepo
+	userRepo *custrepo.UserRepo
+	roleRepo *custrepo.RoleRepo
+}
+
+func NewDepartmentService(
+	log *zap.Logger,
+	deptRepo *custrepo.DepartmentRepo,
+	userRepo *custrepo.UserRepo,
+	roleRepo *custrepo.RoleRepo,
+) *DepartmentService {
+	return &DepartmentService{
+		log:      log,
+		deptRepo: deptRepo,
+		userRepo: userRepo,
+		roleRepo: roleRepo,
+	}
+}
+
+// GetParentDepartment 查询父部门, 父部门ID为空或0时返回nil
+func (s *DepartmentService) GetParentDepartment(
+	ctx context.Context,
+	parentID *uint32,
+) (*custmodel.DepartmentModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	if parentID == nil || *parentID == 0 {
+		return nil, nil
+	}
+
+	m, err := s.deptRepo.GetModel(ctx, nil, *parentID)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询父部门失败",
+			zap.Error(err),
+			zap.Uint32("parent_id", *parentID),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"parent_id": *parentID})
+	}
+	return m, nil
+}
+
+func (s *DepartmentService) CreateDepartment(
+	ctx context.Context,
+	m custmodel.DepartmentModel,
+) (*custmodel.DepartmentModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始创建部门",
+		zap.Object(database.ModelKey, &m),
+	)
+
+	parent, rErr := s.GetParentDepartment(ctx, m.ParentID)
+	if rErr != nil {
+		return nil, rErr
+	}
+	m.Parent = parent
+	if parent == nil {
+		m.ParentID = nil
+	}
+
+	if err := s.deptRepo.CreateModel(ctx, &m); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"创建部门失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, &m),
+		)
+		return nil, errors.NewGormError(err, nil)
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"创建部门成功",
+		zap.Object(database.ModelKey, &m),
+	)
+	return &m, nil
+}
+
+func (s *DepartmentService) UpdateDepartmentByID(
+	ctx context.Context,
+	deptID uint32,
+	data map[string]any,
+) (*custmodel.DepartmentModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始更新部门",
+		zap.Uint32("department_id", deptID),
+		zap.Any(database.UpdateDataKey, data),
+	)
+
+	// 父部门和部门路径只能通过移动部门修改
+	delete(data, "parent_id")
+	delete(data, "path")
+	if err := s.deptRepo.UpdateModel(ctx, data, "id = ?", deptID); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"更新部门失败",
+			zap.Error(err),
+			zap.Uint32("department_id", deptID),
+			zap.Any(database.UpdateDataKey, data),
+		)
+		return nil, errors.NewGormError(err, data)
+	}
+
+	m, rErr := s.FindDepartmentByID(ctx, []string{"Parent"}, deptID)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"更新部门成功",
+		zap.Uint32("department_id", deptID),
+	)
+	return m, nil
+}
+
+// MoveDepartment 将部门及其下级部门整体移动到新的父部门下, 父部门为空时移动为根部门
+//
+// 不能移动到部门自身或其下级部门下, 否则组织架构会形成环
+func (s *DepartmentService) MoveDepartment(
+	ctx context.Context,
+	deptID uint32,
+	parentID *uint32,
+) (*custmodel.DepartmentModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始移动部门",
+		zap.Uint32("department_id", deptID),
+		zap.Uint32p("parent_id", parentID),
+	)
+
+	m, rErr := s.FindDepartmentByID(ctx, nil, deptID)
+	if rErr != nil {
+		return nil, rErr
+	}
+	parent, rErr := s.GetParentDepartment(ctx, parentID)
+	if rErr != nil {
+		return nil, rErr
+	}
+	if parent != nil && parent.IsDescendantOf(m) {
+		ctxutil.Logger(ctx, s.log).Warn(
+			"不能将部门移动到自身或下级部门下",
+			zap.Uint32("department_id", deptID),
+			zap.Uint32("parent_id", parent.ID),
+		)
+		return nil, errors.ErrDepartmentMoveInvalid.WithFields(map[string]any{
+			"department_id": deptID,
+			"parent_id":     parent.ID,
+		})
+	}
+
+	if err := s.deptRepo.MoveModel(ctx, m, parent); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"移动部门失败",
+			zap.Error(err),
+			zap.Uint32("department_id", deptID),
+			zap.Uint32p("parent_id", parentID),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"id": deptID})
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"移动部门成功",
+		zap.Uint32("department_id", deptID),
+		zap.String("path", m.Path),
+	)
+	return m, nil
+}
+
+// DeleteDepartmentByID 删除部门, 存在下级部门或用户时拒绝删除
+func (s *DepartmentService) DeleteDepartmentByID(
+	ctx context.Context,
+	deptID uint32,
+) *errors.Error {
+	if ctx.Err() != nil {
+		return errors.FromError(ctx.Err())
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始删除部门",
+		zap.Uint32("department_id", deptID),
+	)
+
+	if _, rErr := s.FindDepartmentByID(ctx, nil, deptID); rErr != nil {
+		return rErr
+	}
+
+	children, _, err := s.deptRepo.ListModel(ctx, database.QueryParams{
+		IsCount: true,
+		Size:    1,
+		Query:   map[string]any{"parent_id = ?": deptID},
+	})
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询下级部门数量失败",
+			zap.Error(err),
+			zap.Uint32("department_id", deptID),
+		)
+		return errors.NewGormError(err, nil)
+	}
+	users, _, err := s.userRepo.ListModel(ctx, database.QueryParams{
+		IsCount: true,
+		Size:    1,
+		Columns: []string{"id"},
+		Query:   map[string]any{"department_id = ?": deptID},
+	})
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询部门用户数量失败",
+			zap.Error(err),
+			zap.Uint32("department_id", deptID),
+		)
+		return errors.NewGormError(err, nil)
+	}
+	if children > 0 || users > 0 {
+		ctxutil.Logger(ctx, s.log).Warn(
+			"部门下存在下级部门或用户, 不能删除",
+			zap.Uint32("department_id", deptID),
+			zap.Int64("children", children),
+			zap.Int64("users", users),
+		)
+		return errors.ErrDepartmentNotEmpty.WithFields(map[string]any{
+			"children": children,
+			"users":    users,
+		})
+	}
+
+	if err := s.deptRepo.DeleteModel(ctx, deptID); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"删除部门失败",
+			zap.Error(err),
+			zap.Uint32("department_id", deptID),
+		)
+		return errors.NewGormError(err, map[string]any{"id": deptID})
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"删除部门成功",
+		zap.Uint32("department_id", deptID),
+	)
+	return nil
+}
+
+func (s *DepartmentService) FindDepartmentByID(
+	ctx context.Context,
+	preloads []string,
+	deptID uint32,
+) (*custmodel.DepartmentModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	m, err := s.deptRepo.GetModel(ctx, preloads, deptID)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询部门失败",
+			zap.Error(err),
+			zap.Uint32("department_id", deptID),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"id": deptID})
+	}
+	return m, nil
+}
+
+func (s *DepartmentService) ListDepartment(
+	ctx context.Context,
+	qp database.QueryParams,
+) (int64, *[]custmodel.DepartmentModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return 0, nil, errors.FromError(ctx.Err())
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始查询部门列表",
+		zap.Object(database.QueryParamsKey, &qp),
+	)
+
+	count, ms, err := s.deptRepo.ListModel(ctx, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询部门列表失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		return 0, nil, errors.NewGormError(err, nil)
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"查询部门列表成功",
+		zap.Int64("total_count", count),
+	)
+	return count, ms, nil
+}
+
+// GetDepartmentTree 查询完整的组织架构树, 同级部门按排序字段和ID排序
+func (s *DepartmentService) GetDepartmentTree(ctx context.Context) ([]custmodel.DepartmentTreeNode, *errors.Error) {
+	_, ms, rErr := s.ListDepartment(ctx, database.QueryParams{
+		OrderBy: []string{"sort ASC", "id ASC"},
+	})
+	if rErr != nil {
+		return nil, rErr
+	}
+	return custmodel.BuildDepartmentTree(*ms), nil
+}
+
+// SubtreeIDs 查询部门及其全部下级部门的ID
+func (s *DepartmentService) SubtreeIDs(
+	ctx context.Context,
+	deptID uint32,
+) ([]uint32, *errors.Error) {
+	m, rErr := s.FindDepartmentByID(ctx, nil, deptID)
+	if rErr != nil {
+		return nil, rErr
+	}
+	ids, err := s.deptRepo.SubtreeIDs(ctx, m.Path)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询下级部门失败",
+			zap.Error(err),
+			zap.Uint32("department_id", deptID),
+		)
+		return nil, errors.NewGormError(err, nil)
+	}
+	return ids, nil
+}
+
+// AssignUserDepartment 设置用户所属部门, 部门ID为空或0时移出部门
+func (s *DepartmentService) AssignUserDepartment(
+	ctx context.Context,
+	userID uint32,
+	deptID *uint32,
+) (*custmodel.UserModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始设置用户所属部门",
+		zap.Uint32("user_id", userID),
+		zap.Uint32p("department_id", deptID),
+	)
+
+	dept, rErr := s.GetParentDepartment(ctx, deptID)
+	if rErr != nil {
+		return nil, rErr
+	}
+	var value any
+	if dept != nil {
+		value = dept.ID
+	}
+
+	if err := s.userRepo.UpdateModel(ctx, map[string]any{"department_id": value}, "id = ?", userID); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"设置用户所属部门失败",
+			zap.Error(err),
+			zap.Uint32("user_id", userID),
+			zap.Uint32p("department_id", deptID),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"id": userID})
+	}
+
+	m, err := s.userRepo.GetModel(ctx, []string{"Role", "Department"}, userID)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询用户失败",
+			zap.Error(err),
+			zap.Uint32("user_id", userID),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"id": userID})
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"设置用户所属部门成功",
+		zap.Uint32("user_id", userID),
+		zap.Uint32p("department_id", m.DepartmentID),
+	)
+	return m, nil
+}
+
+// UserDataScope 按当前用户角色的数据范围生成用户列表的查询条件, 返回nil表示不限制
+//
+// 工作人员不受数据范围限制; 数据范围为本部门时可以查看本部门及下级部门的用户,
+// 未分配部门时只能查看本人. 查询条件带表名前缀, 不会覆盖请求中按同名字段筛选的条件
+func (s *DepartmentService) UserDataScope(
+	ctx context.Context,
+	claims *auth.UserClaims,
+) (map[string]any, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+	if claims == nil || claims.IsStaff {
+		return nil, nil
+	}
+
+	role, err := s.roleRepo.GetModel(ctx, nil, claims.RoleID)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询角色数据范围失败",
+			zap.Error(err),
+			zap.Uint32("role_id", claims.RoleID),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"role_id": claims.RoleID})
+	}
+
+	self := map[string]any{"customer_user.id = ?": claims.UserID}
+	switch role.EffectiveDataScope() {
+	case custmodel.DataScopeSelf:
+		return self, nil
+	case custmodel.DataScopeDepartment:
+		user, err := s.userRepo.GetModel(ctx, nil, claims.UserID)
+		if err != nil {
+			ctxutil.Logger(ctx, s.log).Error(
+				"查询用户所属部门失败",
+				zap.Error(err),
+				zap.Uint32("user_id", claims.UserID),
+			)
+			return nil, errors.NewGormError(err, map[string]any{"id": claims.UserID})
+		}
+		if user.DepartmentID == nil {
+			return self, nil
+		}
+		ids, rErr := s.SubtreeIDs(ctx, *user.DepartmentID)
+		if rErr != nil {
+			return nil, rErr
+		}
+		return map[string]any{"customer_user.department_id IN ?": ids}, nil
+	default:
+		return nil, nil
+	}
+}
@@ -0,0 +1,155 @@
+package customer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	custmodel "gin-artweb/internal/model/customer"
+	custsvc "gin-artweb/internal/repository/customer"
+	"gin-artweb/internal/shared/auth"
+	"gin-artweb/internal/shared/errors"
+	"gin-artweb/internal/shared/test"
+)
+
+type DepartmentTestSuite struct {
+	suite.Suite
+	roleRepo *custsvc.RoleRepo
+	userRepo *custsvc.UserRepo
+	ds       *DepartmentService
+}
+
+func (suite *DepartmentTestSuite) SetupSuite() {
+	db := test.NewTestGormDBWithConfig(nil)
+	db.AutoMigrate(
+		&custmodel.DepartmentModel{},
+		&custmodel.RoleModel{},
+		&custmodel.UserModel{},
+	)
+	dbTimeout := test.NewTestDBTimeouts()
+	logger := test.NewTestZapLogger()
+	enforcer, _ := auth.NewCasbinEnforcer()
+	suite.roleRepo = custsvc.NewRoleRepo(logger, db, dbTimeout, enforcer)
+	suite.userRepo = custsvc.NewUserRepo(logger, db, dbTimeout)
+	suite.ds = NewDepartmentService(logger, custsvc.NewDepartmentRepo(logger, db, dbTimeout), suite.userRepo, suite.roleRepo)
+}
+
+func TestDepartmentTestSuite(t *testing.T) {
+	suite.Run(t, new(DepartmentTestSuite))
+}
+
+func (suite *DepartmentTestSuite) createDepartment(parentID *uint32) *custmodel.DepartmentModel {
+	m, rErr := suite.ds.CreateDepartment(context.Background(), custmodel.DepartmentModel{
+		Name:     uuid.NewString()[:20],
+		ParentID: parentID,
+	})
+	suite.Require().Nil(rErr)
+	return m
+}
+
+func (suite *DepartmentTestSuite) createUser(roleScope string, deptID *uint32) *custmodel.UserModel {
+	ctx := context.Background()
+	role := CreateTestRoleModel()
+	role.DataScope = roleScope
+	suite.Require().NoError(suite.roleRepo.CreateModel(ctx, role, nil, nil, nil))
+	user := CreateTestUserModel(role.ID)
+	user.DepartmentID = deptID
+	suite.Require().NoError(suite.userRepo.CreateModel(ctx, user))
+	return user
+}
+
+func (suite *DepartmentTestSuite) TestMoveDepartment() {
+	ctx := context.Background()
+	root := suite.createDepartment(nil)
+	child := suite.createDepartment(&root.ID)
+	grandchild := suite.createDepartment(&child.ID)
+
+	// 不能移动到自身或下级部门下
+	_, rErr := suite.ds.MoveDepartment(ctx, root.ID, &grandchild.ID)
+	suite.Require().NotNil(rErr)
+	suite.Equal(errors.ErrDepartmentMoveInvalid.Reason, rErr.Reason)
+	_, rErr = suite.ds.MoveDepartment(ctx, child.ID, &child.ID)
+	suite.Require().NotNil(rErr)
+	suite.Equal(errors.ErrDepartmentMoveInvalid.Reason, rErr.Reason)
+
+	// 将下级部门移动为根部门后, 子树随之移动
+	m, rErr := suite.ds.MoveDepartment(ctx, child.ID, nil)
+	suite.Require().Nil(rErr)
+	suite.Nil(m.ParentID)
+	ids, rErr := suite.ds.SubtreeIDs(ctx, root.ID)
+	suite.Require().Nil(rErr)
+	suite.ElementsMatch([]uint32{root.ID}, ids)
+	ids, rErr = suite.ds.SubtreeIDs(ctx, child.ID)
+	suite.Require().Nil(rErr)
+	suite.ElementsMatch([]uint32{child.ID, grandchild.ID}, ids)
+
+	tree, rErr := suite.ds.GetDepartmentTree(ctx)
+	suite.Require().Nil(rErr)
+	for _, n := range tree {
+		if n.ID == child.ID {
+			suite.Require().Len(n.Children, 1)
+			suite.Equal(grandchild.ID, n.Children[0].ID)
+		}
+	}
+}
+
+func (suite *DepartmentTestSuite) TestDeleteNotEmpty() {
+	ctx := context.Background()
+	root := suite.createDepartment(nil)
+	child := suite.createDepartment(&root.ID)
+
+	rErr := suite.ds.DeleteDepartmentByID(ctx, root.ID)
+	suite.Require().NotNil(rErr)
+	suite.Equal(errors.ErrDepartmentNotEmpty.Reason, rErr.Reason)
+
+	user := suite.createUser(custmodel.DataScopeAll, &child.ID)
+	rErr = suite.ds.DeleteDepartmentByID(ctx, child.ID)
+	suite.Require().NotNil(rErr)
+	suite.Equal(errors.ErrDepartmentNotEmpty.Reason, rErr.Reason)
+
+	// 移出部门后可以删除
+	m, rErr := suite.ds.AssignUserDepartment(ctx, user.ID, nil)
+	suite.Require().Nil(rErr)
+	suite.Nil(m.DepartmentID)
+	suite.Nil(suite.ds.DeleteDepartmentByID(ctx, child.ID))
+	suite.Nil(suite.ds.DeleteDepartmentByID(ctx, root.ID))
+}
+
+func (suite *DepartmentTestSuite) TestUserDataScope() {
+	ctx := context.Background()
+	root := suite.createDepartment(nil)
+	child := suite.createDepartment(&root.ID)
+
+	claimsOf := func(u *custmodel.UserModel, isStaff bool) *auth.UserClaims {
+		return &auth.UserClaims{UserInfo: auth.UserInfo{UserID: u.ID, RoleID: u.RoleID, IsStaff: isStaff}}
+	}
+
+	// 全部数据和工作人员不限制
+	all := suite.createUser(custmodel.DataScopeAll, &root.ID)
+	scope, rErr := suite.ds.UserDataScope(ctx, claimsOf(all, false))
+	suite.Require().Nil(rErr)
+	suite.Nil(scope)
+	self := suite.createUser(custmodel.DataScopeSelf, &root.ID)
+	scope, rErr = suite.ds.UserDataScope(ctx, claimsOf(self, true))
+	suite.Require().Nil(rErr)
+	suite.Nil(scope)
+
+	// 仅本人
+	scope, rErr = suite.ds.UserDataScope(ctx, claimsOf(self, false))
+	suite.Require().Nil(rErr)
+	suite.Equal(map[string]any{"customer_user.id = ?": self.ID}, scope)
+
+	// 本部门及下级部门
+	dept := suite.createUser(custmodel.DataScopeDepartment, &root.ID)
+	scope, rErr = suite.ds.UserDataScope(ctx, claimsOf(dept, false))
+	suite.Require().Nil(rErr)
+	suite.ElementsMatch([]uint32{root.ID, child.ID}, scope["customer_user.department_id IN ?"])
+
+	// 未分配部门时仅本人
+	noDept := suite.createUser(custmodel.DataScopeDepartment, nil)
+	scope, rErr = suite.ds.UserDataScope(ctx, claimsOf(noDept, false))
+	suite.Require().Nil(rErr)
+	suite.Equal(map[string]any{"customer_user.id = ?": noDept.ID}, scope)
+}
@@ -0,0 +1,159 @@
+package customer
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	custmodel "gin-artweb/internal/model/customer"
+	sysmodel "gin-artweb/internal/model/system"
+	custrepo "gin-artweb/internal/repository/customer"
+	sysrepo "gin-artweb/internal/repository/system"
+	"gin-artweb/internal/shared/auth"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/errors"
+)
+
+// defaultImpersonateTTL 代登录令牌的默认有效期
+const defaultImpersonateTTL = 15 * time.Minute
+
+// ImpersonationService 管理员代为登录, 用于复现用户的权限问题
+//
+// 代登录令牌同时携带管理员和被代登录用户的身份, 签发代登录令牌时记录审计记录,
+// 使用代登录令牌的请求由系统模块的审计服务记录, 审计记录的操作用户均为管理员
+type ImpersonationService struct {
+	log       *zap.Logger
+	userRepo  *custrepo.UserRepo
+	auditRepo *sysrepo.AuditRecordRepo
+	sessions  *SessionService
+	ttl       time.Duration
+}
+
+func NewImpersonationService(
+	log *zap.Logger,
+	userRepo *custrepo.UserRepo,
+	auditRepo *sysrepo.AuditRecordRepo,
+	sessions *SessionService,
+	ttl time.Duration,
+) *ImpersonationService {
+	if ttl <= 0 {
+		ttl = defaultImpersonateTTL
+	}
+	return &ImpersonationService{
+		log:       log,
+		userRepo:  userRepo,
+		auditRepo: auditRepo,
+		sessions:  sessions,
+		ttl:       ttl,
+	}
+}
+
+// Impersonate 管理员代为登录指定用户, 签发短期访问令牌
+//
+// 只有工作人员可以代为登录, 不能代为登录自己、其他工作人员和未激活的用户, 代登录令牌不能再次代为登录
+func (s *ImpersonationService) Impersonate(
+	ctx context.Context,
+	actor *auth.UserClaims,
+	userID uint32,
+	ipAddress string,
+	userAgent string,
+) (*custmodel.ImpersonateOut, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始代为登录用户",
+		zap.String("impersonator", actor.Username),
+		zap.Uint32("user_id", userID),
+		zap.String("ip_address", ipAddress),
+	)
+
+	if !actor.IsStaff || actor.Impersonated() || actor.UserID == userID {
+		ctxutil.Logger(ctx, s.log).Warn(
+			"不允许代为登录用户",
+			zap.String("impersonator", actor.Username),
+			zap.Bool("is_staff", actor.IsStaff),
+			zap.Bool("impersonated", actor.Impersonated()),
+			zap.Uint32("user_id", userID),
+		)
+		return nil, errors.ErrImpersonationForbidden
+	}
+
+	m, err := s.userRepo.GetModel(ctx, nil, "id = ?", userID)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询被代登录的用户失败",
+			zap.Error(err),
+			zap.Uint32("user_id", userID),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"id": userID})
+	}
+	if m.IsStaff || !m.IsActive {
+		ctxutil.Logger(ctx, s.log).Warn(
+			"被代登录的用户是工作人员或未激活",
+			zap.String("impersonator", actor.Username),
+			zap.Uint32("user_id", userID),
+			zap.Bool("is_staff", m.IsStaff),
+			zap.Bool("is_active", m.IsActive),
+		)
+		return nil, errors.ErrImpersonationForbidden.WithField("user_id", userID)
+	}
+
+	ui := auth.UserInfo{
+		UserID:           m.ID,
+		Username:         m.Username,
+		RoleID:           m.RoleID,
+		IsStaff:          false,
+		ImpersonatorID:   actor.UserID,
+		ImpersonatorName: actor.Username,
+	}
+	pair, rErr := s.sessions.IssueImpersonation(ctx, ui, s.ttl, ipAddress, userAgent)
+	if rErr != nil {
+		return nil, rErr
+	}
+	ui.SessionID = pair.SessionID
+
+	s.audit(ctx, &ui, map[string]any{
+		"session_id": pair.SessionID,
+		"expires_at": pair.AccessExpiresAt.Format(time.DateTime),
+		"ip_address": ipAddress,
+	})
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"代为登录用户成功",
+		zap.String("impersonator", actor.Username),
+		zap.Uint32("user_id", m.ID),
+		zap.String("username", m.Username),
+		zap.String("session_id", pair.SessionID),
+		zap.Time("expires_at", pair.AccessExpiresAt),
+	)
+	return &custmodel.ImpersonateOut{
+		AccessToken:  pair.AccessToken,
+		SessionID:    pair.SessionID,
+		Username:     m.Username,
+		Impersonator: actor.Username,
+		ExpiresAt:    pair.AccessExpiresAt.Format(time.DateTime),
+	}, nil
+}
+
+// audit 记录签发代登录令牌的审计记录, 审计失败不影响代登录
+func (s *ImpersonationService) audit(ctx context.Context, ui *auth.UserInfo, detail map[string]any) {
+	detail["username"] = ui.Username
+	record, err := sysmodel.NewAuditRecord(
+		sysmodel.ImpersonationAuditModule, sysmodel.ImpersonationAuditResource, ui.UserID, sysmodel.ImpersonationAuditStart,
+		nil, detail, ui.ImpersonatorName, ctxutil.GetTraceID(ctx),
+	)
+	if err == nil {
+		err = s.auditRepo.CreateModel(ctx, record)
+	}
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"记录代登录审计记录失败",
+			zap.Error(err),
+			zap.String("impersonator", ui.ImpersonatorName),
+			zap.Uint32("user_id", ui.UserID),
+		)
+	}
+}
@@ -0,0 +1,124 @@
+package customer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	custmodel "gin-artweb/internal/model/customer"
+	sysmodel "gin-artweb/internal/model/system"
+	custsvc "gin-artweb/internal/repository/customer"
+	sysrepo "gin-artweb/internal/repository/system"
+	"gin-artweb/internal/shared/auth"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+	"gin-artweb/internal/shared/test"
+)
+
+type ImpersonationTestSuite struct {
+	suite.Suite
+	jwt       *auth.JWTConfig
+	userRepo  *custsvc.UserRepo
+	auditRepo *sysrepo.AuditRecordRepo
+	is        *ImpersonationService
+}
+
+func (suite *ImpersonationTestSuite) SetupSuite() {
+	db := test.NewTestGormDBWithConfig(nil)
+	db.AutoMigrate(
+		&custmodel.RoleModel{},
+		&custmodel.UserModel{},
+		&custmodel.UserSessionModel{},
+		&sysmodel.AuditRecordModel{},
+	)
+	dbTimeout := test.NewTestDBTimeouts()
+	logger := test.NewTestZapLogger()
+	suite.jwt = auth.NewJWTConfig(
+		time.Duration(10)*time.Minute,
+		time.Duration(1)*time.Hour,
+		"HS256",
+		"HS256",
+		[]byte("test_access_secret"),
+		[]byte("test_refresh_secret"),
+	)
+	sessions := NewSessionService(logger, custsvc.NewUserSessionRepo(logger, db, dbTimeout), suite.jwt, nil)
+	suite.jwt.Sessions = sessions
+	suite.userRepo = custsvc.NewUserRepo(logger, db, dbTimeout)
+	suite.auditRepo = sysrepo.NewAuditRecordRepo(logger, db, dbTimeout)
+	suite.is = NewImpersonationService(logger, suite.userRepo, suite.auditRepo, sessions, 5*time.Minute)
+}
+
+func TestImpersonationTestSuite(t *testing.T) {
+	suite.Run(t, new(ImpersonationTestSuite))
+}
+
+func (suite *ImpersonationTestSuite) createUser(isStaff, isActive bool) *custmodel.UserModel {
+	m := CreateTestUserModel(1)
+	m.IsStaff = isStaff
+	m.IsActive = isActive
+	suite.Require().NoError(suite.userRepo.CreateModel(context.Background(), m))
+	return m
+}
+
+func (suite *ImpersonationTestSuite) TestImpersonate() {
+	ctx := context.Background()
+	admin := suite.createUser(true, true)
+	user := suite.createUser(false, true)
+	actor := &auth.UserClaims{UserInfo: auth.UserInfo{UserID: admin.ID, Username: admin.Username, IsStaff: true}}
+
+	out, rErr := suite.is.Impersonate(ctx, actor, user.ID, "10.0.0.1", "curl/8.0")
+	suite.Require().Nil(rErr)
+	suite.Equal(user.Username, out.Username)
+	suite.Equal(admin.Username, out.Impersonator)
+
+	claims, rErr := auth.ParseAccessToken(ctx, suite.jwt, out.AccessToken)
+	suite.Require().Nil(rErr)
+	suite.True(claims.Impersonated())
+	suite.Equal(user.ID, claims.UserID)
+	suite.Equal(admin.ID, claims.ImpersonatorID)
+	suite.Equal(out.SessionID, claims.SessionID)
+	suite.False(claims.IsStaff)
+	suite.WithinDuration(time.Now().Add(5*time.Minute), claims.ExpiresAt.Time, 5*time.Second, "代登录令牌使用配置的有效期")
+
+	_, ms, err := suite.auditRepo.ListModel(ctx, database.QueryParams{Query: map[string]any{
+		"resource = ?":    sysmodel.ImpersonationAuditResource,
+		"resource_id = ?": user.ID,
+	}})
+	suite.Require().NoError(err)
+	suite.Require().Len(*ms, 1)
+	suite.Equal(sysmodel.ImpersonationAuditStart, (*ms)[0].Action)
+	suite.Equal(admin.Username, (*ms)[0].Username, "审计记录的操作用户为管理员")
+
+	// 代登录令牌不能再次代为登录
+	_, rErr = suite.is.Impersonate(ctx, claims, user.ID, "10.0.0.1", "curl/8.0")
+	suite.Require().NotNil(rErr)
+	suite.True(rErr.Is(errors.ErrImpersonationForbidden))
+}
+
+func (suite *ImpersonationTestSuite) TestImpersonateForbidden() {
+	ctx := context.Background()
+	admin := suite.createUser(true, true)
+	staff := suite.createUser(true, true)
+	inactive := suite.createUser(false, false)
+	user := suite.createUser(false, true)
+	actor := &auth.UserClaims{UserInfo: auth.UserInfo{UserID: admin.ID, Username: admin.Username, IsStaff: true}}
+	nonStaff := &auth.UserClaims{UserInfo: auth.UserInfo{UserID: user.ID, Username: user.Username}}
+
+	cases := []struct {
+		name   string
+		actor  *auth.UserClaims
+		userID uint32
+	}{
+		{"非工作人员", nonStaff, inactive.ID},
+		{"代为登录自己", actor, admin.ID},
+		{"代为登录工作人员", actor, staff.ID},
+		{"代为登录未激活用户", actor, inactive.ID},
+	}
+	for _, c := range cases {
+		_, rErr := suite.is.Impersonate(ctx, c.actor, c.userID, "10.0.0.1", "curl/8.0")
+		suite.Require().NotNil(rErr, c.name)
+		suite.True(rErr.Is(errors.ErrImpersonationForbidden), c.name)
+	}
+}
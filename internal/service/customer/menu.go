@@ -42,28 +42,25 @@ func (s *MenuService) GetParentMenu(
 		return nil, nil
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始查询父菜单",
 		zap.Uint32("parent_id", *parentID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	m, err := s.menuRepo.GetModel(ctx, nil, *parentID)
 	if err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"查询父菜单失败",
 			zap.Error(err),
 			zap.Uint32("parent_id", *parentID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.NewGormError(err, map[string]any{"parent_id": *parentID})
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"查询父菜单成功",
 		zap.Uint32("parent_id", *parentID),
 		zap.Object(database.ModelKey, m),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return m, nil
 }
@@ -80,10 +77,9 @@ func (s *MenuService) GetApis(
 		return &[]custmodel.ApiModel{}, nil
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始查询菜单关联的权限列表",
 		zap.Uint32s("api_ids", apiIDs),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	qp := database.QueryParams{
@@ -91,19 +87,17 @@ func (s *MenuService) GetApis(
 	}
 	_, ms, err := s.apiRepo.ListModel(ctx, qp)
 	if err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"查询菜单关联的权限列表失败",
 			zap.Error(err),
 			zap.Uint32s("api_ids", apiIDs),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.NewGormError(err, nil)
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"查询菜单关联的权限列表成功",
 		zap.Uint32s("api_ids", apiIDs),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return ms, nil
 }
@@ -117,11 +111,10 @@ func (s *MenuService) CreateMenu(
 		return nil, errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始创建菜单",
 		zap.Uint32s("api_ids", apiIDs),
 		zap.Object(database.ModelKey, &m),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	var (
@@ -144,11 +137,10 @@ func (s *MenuService) CreateMenu(
 	}
 
 	if err := s.menuRepo.CreateModel(ctx, &m, apis); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"创建菜单失败",
 			zap.Error(err),
 			zap.Object(database.ModelKey, &m),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.NewGormError(err, nil)
 	}
@@ -158,19 +150,17 @@ func (s *MenuService) CreateMenu(
 	}
 
 	if err := s.menuRepo.AddGroupPolicy(ctx, &m); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"添加菜单组策略失败",
 			zap.Error(err),
 			zap.Object(database.ModelKey, &m),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.FromError(err)
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"创建菜单成功",
 		zap.Object(database.ModelKey, &m),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return &m, nil
 }
@@ -185,12 +175,11 @@ func (s *MenuService) UpdateMenuByID(
 		return nil, errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始更新菜单",
 		zap.Uint32("menu_id", menuID),
 		zap.Uint32s("api_ids", apiIDs),
 		zap.Any(database.UpdateDataKey, data),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	var (
@@ -205,12 +194,11 @@ func (s *MenuService) UpdateMenuByID(
 
 	data["id"] = menuID
 	if err := s.menuRepo.UpdateModel(ctx, data, apis, "id = ?", menuID); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"更新菜单失败",
 			zap.Error(err),
 			zap.Uint32("menu_id", menuID),
 			zap.Any(database.UpdateDataKey, data),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.NewGormError(err, data)
 	}
@@ -221,29 +209,26 @@ func (s *MenuService) UpdateMenuByID(
 		return nil, rErr
 	}
 	if err := s.menuRepo.RemoveGroupPolicy(ctx, m, false); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"移除旧菜单组策略失败",
 			zap.Error(err),
 			zap.Uint32("menu_id", menuID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.FromError(err)
 	}
 
 	if err := s.menuRepo.AddGroupPolicy(ctx, m); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"添加新菜单组策略失败",
 			zap.Error(err),
 			zap.Uint32("menu_id", menuID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.FromError(err)
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"更新菜单成功",
 		zap.Uint32("menu_id", menuID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return m, nil
 }
@@ -256,10 +241,9 @@ func (s *MenuService) DeleteMenuByID(
 		return errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始删除菜单",
 		zap.Uint32("menu_id", menuID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	m, rErr := s.FindMenuByID(ctx, []string{"Parent", "Apis"}, menuID)
@@ -268,29 +252,26 @@ func (s *MenuService) DeleteMenuByID(
 	}
 
 	if err := s.menuRepo.DeleteModel(ctx, menuID); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"删除菜单失败",
 			zap.Error(err),
 			zap.Uint32("menu_id", menuID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return errors.NewGormError(err, map[string]any{"id": menuID})
 	}
 
 	if err := s.menuRepo.RemoveGroupPolicy(ctx, m, true); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"移除菜单组策略失败",
 			zap.Error(err),
 			zap.Uint32("menu_id", menuID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return errors.FromError(err)
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"删除菜单成功",
 		zap.Uint32("menu_id", menuID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return nil
 }
@@ -304,28 +285,25 @@ func (s *MenuService) FindMenuByID(
 		return nil, errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始查询菜单",
 		zap.Strings(database.PreloadKey, preloads),
 		zap.Uint32("menu_id", menuID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	m, err := s.menuRepo.GetModel(ctx, preloads, menuID)
 	if err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"查询菜单失败",
 			zap.Error(err),
 			zap.Uint32("menu_id", menuID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.NewGormError(err, map[string]any{"id": menuID})
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"查询菜单成功",
 		zap.Uint32("menu_id", menuID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return m, nil
 }
@@ -338,27 +316,24 @@ func (s *MenuService) ListMenu(
 		return 0, nil, errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始查询菜单列表",
 		zap.Object(database.QueryParamsKey, &qp),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	count, ms, err := s.menuRepo.ListModel(ctx, qp)
 	if err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"查询菜单列表失败",
 			zap.Error(err),
 			zap.Object(database.QueryParamsKey, &qp),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return 0, nil, errors.NewGormError(err, nil)
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"查询菜单列表成功",
 		zap.Object(database.QueryParamsKey, &qp),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return count, ms, nil
 }
@@ -368,9 +343,8 @@ func (s *MenuService) LoadMenuPolicy(ctx context.Context) *errors.Error {
 		return errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始加载菜单策略",
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	qp := database.QueryParams{
@@ -379,10 +353,9 @@ func (s *MenuService) LoadMenuPolicy(ctx context.Context) *errors.Error {
 	}
 	_, mms, err := s.ListMenu(ctx, qp)
 	if err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"加载菜单策略时查询菜单列表失败",
 			zap.Error(err),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return err
 	}
@@ -393,20 +366,18 @@ func (s *MenuService) LoadMenuPolicy(ctx context.Context) *errors.Error {
 		policyCount = len(ms)
 		for i := range ms {
 			if err := s.menuRepo.AddGroupPolicy(ctx, &ms[i]); err != nil {
-				s.log.Error(
+				ctxutil.Logger(ctx, s.log).Error(
 					"加载菜单策略失败",
 					zap.Error(err),
 					zap.Uint32("menu_id", ms[i].ID),
-					zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 				)
 				return errors.FromError(err)
 			}
 		}
 	}
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"加载菜单策略成功",
 		zap.Int("policy_count", policyCount),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return nil
 }
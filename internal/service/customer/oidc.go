@@ -0,0 +1,546 @@
+package customer
+
+import (
+	"context"
+	"os"
+	"slices"
+	"time"
+
+	emperror "emperror.dev/errors"
+	"github.com/patrickmn/go-cache"
+	"go.uber.org/zap"
+
+	custmodel "gin-artweb/internal/model/customer"
+	custsvc "gin-artweb/internal/repository/customer"
+	"gin-artweb/internal/shared/auth"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+	"gin-artweb/internal/shared/metrics"
+	"gin-artweb/pkg/oidc"
+)
+
+const (
+	// defaultOidcStateMinutes 发起登录后完成授权的默认有效期(分钟)
+	defaultOidcStateMinutes = 10
+	// defaultOidcTimeout 请求身份提供方的默认超时时间(秒)
+	defaultOidcTimeout = 10
+	// defaultOidcUsernameClaim 默认作为用户名的声明
+	defaultOidcUsernameClaim = "preferred_username"
+)
+
+// oidcState 发起登录时保存的一次性状态, 回调时按state取出
+type oidcState struct {
+	Provider   string
+	Verifier   string
+	Nonce      string
+	LinkUserID uint32 // 不为0时表示将外部身份关联到该用户
+}
+
+type oidcProvider struct {
+	conf   config.OIDCProviderConfig
+	client *oidc.Provider
+}
+
+type OidcService struct {
+	log          *zap.Logger
+	svcUser      *UserService
+	roleRepo     *custsvc.RoleRepo
+	userRepo     *custsvc.UserRepo
+	identityRepo *custsvc.UserIdentityRepo
+	localLogin   bool
+	names        []string
+	providers    map[string]oidcProvider
+	states       *cache.Cache
+}
+
+// NewOidcService 按配置创建身份提供方客户端, conf为空时不启用单点登录
+func NewOidcService(
+	log *zap.Logger,
+	svcUser *UserService,
+	roleRepo *custsvc.RoleRepo,
+	userRepo *custsvc.UserRepo,
+	identityRepo *custsvc.UserIdentityRepo,
+	conf *config.OIDCConfig,
+) (*OidcService, error) {
+	if conf == nil {
+		conf = &config.OIDCConfig{}
+	}
+	stateMinutes := conf.StateMinutes
+	if stateMinutes <= 0 {
+		stateMinutes = defaultOidcStateMinutes
+	}
+	timeout := conf.Timeout
+	if timeout <= 0 {
+		timeout = defaultOidcTimeout
+	}
+
+	s := &OidcService{
+		log:          log,
+		svcUser:      svcUser,
+		roleRepo:     roleRepo,
+		userRepo:     userRepo,
+		identityRepo: identityRepo,
+		localLogin:   !conf.DisableLocalLogin,
+		providers:    make(map[string]oidcProvider, len(conf.Providers)),
+		states:       cache.New(time.Duration(stateMinutes)*time.Minute, time.Duration(stateMinutes)*time.Minute),
+	}
+	for _, pc := range conf.Providers {
+		if pc.Name == "" {
+			return nil, emperror.New("OIDC身份提供方标识不能为空")
+		}
+		if _, ok := s.providers[pc.Name]; ok {
+			return nil, emperror.Errorf("OIDC身份提供方标识重复: %s", pc.Name)
+		}
+		var secret string
+		if pc.ClientSecretEnv != "" {
+			secret = os.Getenv(pc.ClientSecretEnv)
+		}
+		client, err := oidc.NewProvider(oidc.Config{
+			Issuer:       pc.Issuer,
+			ClientID:     pc.ClientID,
+			ClientSecret: secret,
+			RedirectURL:  pc.RedirectURL,
+			Scopes:       pc.Scopes,
+		}, time.Duration(timeout)*time.Second)
+		if err != nil {
+			return nil, emperror.WrapIff(err, "创建OIDC身份提供方%s失败", pc.Name)
+		}
+		if pc.UsernameClaim == "" {
+			pc.UsernameClaim = defaultOidcUsernameClaim
+		}
+		s.providers[pc.Name] = oidcProvider{conf: pc, client: client}
+		s.names = append(s.names, pc.Name)
+	}
+	return s, nil
+}
+
+// ListProviders 返回登录页可用的登录方式
+func (s *OidcService) ListProviders() custmodel.OidcProvidersOut {
+	out := custmodel.OidcProvidersOut{
+		LocalLogin: s.localLogin,
+		Providers:  make([]custmodel.OidcProviderOut, 0, len(s.names)),
+	}
+	for _, name := range s.names {
+		p := s.providers[name]
+		displayName := p.conf.DisplayName
+		if displayName == "" {
+			displayName = name
+		}
+		out.Providers = append(out.Providers, custmodel.OidcProviderOut{Name: name, DisplayName: displayName})
+	}
+	return out
+}
+
+// AuthURL 生成授权地址和一次性state, linkUserID不为0时回调成功后将外部身份关联到该用户
+func (s *OidcService) AuthURL(
+	ctx context.Context,
+	provider string,
+	linkUserID uint32,
+) (string, string, *errors.Error) {
+	if ctx.Err() != nil {
+		return "", "", errors.FromError(ctx.Err())
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始生成OIDC授权地址",
+		zap.String("provider", provider),
+		zap.Uint32("link_user_id", linkUserID),
+	)
+
+	p, ok := s.providers[provider]
+	if !ok {
+		return "", "", errors.ErrOidcProviderNotFound.WithField("provider", provider)
+	}
+
+	state, err := oidc.RandomString(32)
+	if err != nil {
+		return "", "", errors.FromError(err)
+	}
+	nonce, err := oidc.RandomString(32)
+	if err != nil {
+		return "", "", errors.FromError(err)
+	}
+	verifier, challenge, err := oidc.NewPKCE()
+	if err != nil {
+		return "", "", errors.FromError(err)
+	}
+
+	authURL, err := p.client.AuthCodeURL(ctx, state, nonce, challenge)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"生成OIDC授权地址失败",
+			zap.Error(err),
+			zap.String("provider", provider),
+		)
+		return "", "", errors.ErrOidcLoginFailed.WithField("provider", provider).WithCause(err)
+	}
+	s.states.SetDefault(state, oidcState{
+		Provider:   provider,
+		Verifier:   verifier,
+		Nonce:      nonce,
+		LinkUserID: linkUserID,
+	})
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"生成OIDC授权地址成功",
+		zap.String("provider", provider),
+	)
+	return authURL, state, nil
+}
+
+// Callback 校验授权回调并登录, 返回访问令牌和刷新令牌
+func (s *OidcService) Callback(
+	ctx context.Context,
+	state string,
+	code string,
+	ipAddress string,
+	userAgent string,
+) (string, string, *errors.Error) {
+	if ctx.Err() != nil {
+		return "", "", errors.FromError(ctx.Err())
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始处理OIDC授权回调",
+		zap.String("ip_address", ipAddress),
+		zap.String("user_agent", userAgent),
+	)
+
+	// state只能使用一次
+	v, ok := s.states.Get(state)
+	if !ok {
+		ctxutil.Logger(ctx, s.log).Warn(
+			"OIDC登录状态无效或已过期",
+			zap.String("ip_address", ipAddress),
+		)
+		return "", "", errors.ErrOidcStateInvalid
+	}
+	s.states.Delete(state)
+	st := v.(oidcState)
+	p, ok := s.providers[st.Provider]
+	if !ok {
+		return "", "", errors.ErrOidcProviderNotFound.WithField("provider", st.Provider)
+	}
+
+	m, rErr := s.authenticate(ctx, p, st, code)
+	if rErr != nil {
+		metrics.LoginTotal.WithLabelValues(metrics.LoginFailure).Inc()
+		return "", "", rErr
+	}
+	metrics.LoginTotal.WithLabelValues(metrics.LoginSuccess).Inc()
+
+	if _, err := s.svcUser.createLoginRecord(ctx, custmodel.LoginRecordModel{
+		Username:  m.Username,
+		LoginAt:   time.Now(),
+		IPAddress: ipAddress,
+		UserAgent: userAgent,
+		Status:    true,
+	}); err != nil {
+		return "", "", err
+	}
+
+	userinfo := auth.UserInfo{
+		Username:           m.Username,
+		UserID:             m.ID,
+		RoleID:             m.RoleID,
+		IsStaff:            m.IsStaff,
+		MustChangePassword: m.MustChangePassword,
+	}
+	pair, rErr := s.svcUser.sessions.Issue(ctx, userinfo, custmodel.SessionMethodOidc+st.Provider, ipAddress, userAgent)
+	if rErr != nil {
+		return "", "", rErr
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"OIDC登录成功",
+		zap.String("provider", st.Provider),
+		zap.String("username", m.Username),
+		zap.Uint32("user_id", m.ID),
+		zap.String("ip_address", ipAddress),
+	)
+	return pair.AccessToken, pair.RefreshToken, nil
+}
+
+// authenticate 使用授权码换取并校验ID令牌, 返回外部身份关联的用户
+func (s *OidcService) authenticate(
+	ctx context.Context,
+	p oidcProvider,
+	st oidcState,
+	code string,
+) (*custmodel.UserModel, *errors.Error) {
+	token, err := p.client.Exchange(ctx, code, st.Verifier)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Warn(
+			"OIDC授权码换取令牌失败",
+			zap.Error(err),
+			zap.String("provider", p.conf.Name),
+		)
+		return nil, errors.ErrOidcLoginFailed.WithField("provider", p.conf.Name).WithCause(err)
+	}
+	claims, err := p.client.VerifyIDToken(ctx, token.IDToken, st.Nonce)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Warn(
+			"OIDC身份令牌校验失败",
+			zap.Error(err),
+			zap.String("provider", p.conf.Name),
+		)
+		return nil, errors.ErrOidcLoginFailed.WithField("provider", p.conf.Name).WithCause(err)
+	}
+	subject := claims.String("sub")
+
+	var (
+		m    *custmodel.UserModel
+		rErr *errors.Error
+	)
+	im, err := s.identityRepo.GetModel(ctx, nil, "provider = ? AND subject = ?", p.conf.Name, subject)
+	switch {
+	case err == nil:
+		if st.LinkUserID != 0 && st.LinkUserID != im.UserID {
+			return nil, errors.ErrOidcIdentityLinked.WithField("provider", p.conf.Name)
+		}
+		if m, rErr = s.svcUser.FindUserByID(ctx, nil, im.UserID); rErr != nil {
+			return nil, rErr
+		}
+	case !errors.NewGormError(err, nil).Is(errors.ErrRecordNotFound):
+		return nil, errors.NewGormError(err, nil)
+	case st.LinkUserID != 0:
+		if m, rErr = s.svcUser.FindUserByID(ctx, nil, st.LinkUserID); rErr != nil {
+			return nil, rErr
+		}
+		// 同一个用户在每个身份提供方只能关联一个身份, 需要先解除原有关联
+		if _, err := s.identityRepo.GetModel(ctx, nil, "user_id = ? AND provider = ?", m.ID, p.conf.Name); err == nil {
+			return nil, errors.ErrOidcIdentityLinked.WithField("provider", p.conf.Name)
+		}
+		if im, rErr = s.createIdentity(ctx, m.ID, p.conf.Name, claims); rErr != nil {
+			return nil, rErr
+		}
+	case p.conf.AutoCreate:
+		if m, rErr = s.provisionUser(ctx, p.conf, claims); rErr != nil {
+			return nil, rErr
+		}
+		if im, rErr = s.createIdentity(ctx, m.ID, p.conf.Name, claims); rErr != nil {
+			return nil, rErr
+		}
+	default:
+		ctxutil.Logger(ctx, s.log).Warn(
+			"外部身份未关联用户",
+			zap.String("provider", p.conf.Name),
+			zap.String("subject", subject),
+		)
+		return nil, errors.ErrOidcUserNotLinked.WithField("provider", p.conf.Name)
+	}
+
+	if !m.IsActive {
+		ctxutil.Logger(ctx, s.log).Warn(
+			"用户账户被锁定",
+			zap.String("username", m.Username),
+			zap.Uint32("user_id", m.ID),
+		)
+		return nil, errors.ErrAccountLocked
+	}
+
+	now := time.Now()
+	data := map[string]any{"last_login_at": now}
+	if claims.String("email") != "" {
+		data["email"] = claims.String("email")
+	}
+	if err := s.identityRepo.UpdateModel(ctx, data, "id = ?", im.ID); err != nil {
+		return nil, errors.NewGormError(err, data)
+	}
+
+	// 已关联的用户按映射规则同步角色
+	if p.conf.SyncRole && st.LinkUserID == 0 {
+		rm, rErr := s.mapRole(ctx, p.conf, claims)
+		if rErr != nil {
+			return nil, rErr
+		}
+		if rm.ID != m.RoleID {
+			if err := s.userRepo.UpdateModel(ctx, map[string]any{"role_id": rm.ID}, "id = ?", m.ID); err != nil {
+				return nil, errors.NewGormError(err, nil)
+			}
+			ctxutil.Logger(ctx, s.log).Info(
+				"已按映射规则同步用户角色",
+				zap.Uint32("user_id", m.ID),
+				zap.Uint32("old_role_id", m.RoleID),
+				zap.Uint32("role_id", rm.ID),
+			)
+			m.RoleID = rm.ID
+		}
+	}
+	return m, nil
+}
+
+// mapRole 按顺序使用第一条匹配的映射规则, 都不匹配时使用默认角色
+func (s *OidcService) mapRole(
+	ctx context.Context,
+	conf config.OIDCProviderConfig,
+	claims oidc.Claims,
+) (*custmodel.RoleModel, *errors.Error) {
+	name := conf.DefaultRole
+	for _, rule := range conf.RoleMappings {
+		if slices.Contains(claims.Strings(rule.Claim), rule.Value) {
+			name = rule.Role
+			break
+		}
+	}
+	if name == "" {
+		ctxutil.Logger(ctx, s.log).Warn(
+			"没有匹配的角色映射规则",
+			zap.String("provider", conf.Name),
+			zap.String("subject", claims.String("sub")),
+		)
+		return nil, errors.ErrOidcRoleNotMapped.WithField("provider", conf.Name)
+	}
+	rm, err := s.roleRepo.GetModel(ctx, nil, "name = ?", name)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询映射的角色失败",
+			zap.Error(err),
+			zap.String("role", name),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"role": name})
+	}
+	return rm, nil
+}
+
+// provisionUser 首次登录时创建用户, 用户只能通过单点登录, 密码为随机值
+func (s *OidcService) provisionUser(
+	ctx context.Context,
+	conf config.OIDCProviderConfig,
+	claims oidc.Claims,
+) (*custmodel.UserModel, *errors.Error) {
+	username := claims.String(conf.UsernameClaim)
+	if username == "" || len(username) > 50 {
+		ctxutil.Logger(ctx, s.log).Warn(
+			"ID令牌中的用户名无效",
+			zap.String("provider", conf.Name),
+			zap.String("username_claim", conf.UsernameClaim),
+			zap.String("username", username),
+		)
+		return nil, errors.ErrOidcLoginFailed.WithFields(map[string]any{
+			"provider":       conf.Name,
+			"username_claim": conf.UsernameClaim,
+		})
+	}
+
+	// 同名的本地用户需要登录后主动关联, 避免外部身份接管本地账号
+	if _, err := s.userRepo.GetModel(ctx, nil, "username = ?", username); err == nil {
+		ctxutil.Logger(ctx, s.log).Warn(
+			"已存在同名的本地用户",
+			zap.String("provider", conf.Name),
+			zap.String("username", username),
+		)
+		return nil, errors.ErrOidcUserNotLinked.WithFields(map[string]any{
+			"provider": conf.Name,
+			"username": username,
+		})
+	} else if rErr := errors.NewGormError(err, nil); !rErr.Is(errors.ErrRecordNotFound) {
+		return nil, rErr
+	}
+
+	rm, rErr := s.mapRole(ctx, conf, claims)
+	if rErr != nil {
+		return nil, rErr
+	}
+	password, err := oidc.RandomString(32)
+	if err != nil {
+		return nil, errors.FromError(err)
+	}
+	return s.svcUser.createUser(ctx, custmodel.UserModel{
+		Username: username,
+		Password: password,
+		IsActive: true,
+		RoleID:   rm.ID,
+	})
+}
+
+func (s *OidcService) createIdentity(
+	ctx context.Context,
+	userID uint32,
+	provider string,
+	claims oidc.Claims,
+) (*custmodel.UserIdentityModel, *errors.Error) {
+	im := custmodel.UserIdentityModel{
+		UserID:   userID,
+		Provider: provider,
+		Subject:  claims.String("sub"),
+		Email:    claims.String("email"),
+	}
+	if err := s.identityRepo.CreateModel(ctx, &im); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"关联外部身份失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, &im),
+		)
+		return nil, errors.NewGormError(err, nil)
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"关联外部身份成功",
+		zap.Object(database.ModelKey, &im),
+	)
+	return &im, nil
+}
+
+// ListUserIdentity 查询用户关联的外部身份
+func (s *OidcService) ListUserIdentity(
+	ctx context.Context,
+	userID uint32,
+) (*[]custmodel.UserIdentityModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	_, ms, err := s.identityRepo.ListModel(ctx, database.QueryParams{
+		Query:   map[string]any{"user_id = ?": userID},
+		OrderBy: []string{"id ASC"},
+	})
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询用户关联的外部身份失败",
+			zap.Error(err),
+			zap.Uint32("user_id", userID),
+		)
+		return nil, errors.NewGormError(err, nil)
+	}
+	return ms, nil
+}
+
+// UnlinkIdentity 解除用户与外部身份的关联
+func (s *OidcService) UnlinkIdentity(
+	ctx context.Context,
+	userID uint32,
+	provider string,
+) *errors.Error {
+	if ctx.Err() != nil {
+		return errors.FromError(ctx.Err())
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始解除外部身份关联",
+		zap.Uint32("user_id", userID),
+		zap.String("provider", provider),
+	)
+
+	if _, err := s.identityRepo.GetModel(ctx, nil, "user_id = ? AND provider = ?", userID, provider); err != nil {
+		return errors.NewGormError(err, map[string]any{"provider": provider})
+	}
+	if err := s.identityRepo.DeleteModel(ctx, "user_id = ? AND provider = ?", userID, provider); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"解除外部身份关联失败",
+			zap.Error(err),
+			zap.Uint32("user_id", userID),
+			zap.String("provider", provider),
+		)
+		return errors.NewGormError(err, nil)
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"解除外部身份关联成功",
+		zap.Uint32("user_id", userID),
+		zap.String("provider", provider),
+	)
+	return nil
+}
@@ -0,0 +1,216 @@
+package customer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	custmodel "gin-artweb/internal/model/customer"
+	custsvc "gin-artweb/internal/repository/customer"
+	"gin-artweb/internal/shared/auth"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/errors"
+	"gin-artweb/internal/shared/test"
+	"gin-artweb/pkg/crypto"
+	"gin-artweb/pkg/oidc/oidctest"
+)
+
+type OidcTestSuite struct {
+	suite.Suite
+	srv       *oidctest.Server
+	uc        *UserService
+	oc        *OidcService
+	opsRole   *custmodel.RoleModel
+	guestRole *custmodel.RoleModel
+}
+
+func (suite *OidcTestSuite) SetupSuite() {
+	db := test.NewTestGormDBWithConfig(nil)
+	db.AutoMigrate(
+		&custmodel.MenuModel{},
+		&custmodel.ApiModel{},
+		&custmodel.ButtonModel{},
+		&custmodel.RoleModel{},
+		&custmodel.UserModel{},
+		&custmodel.LoginRecordModel{},
+		&custmodel.UserSessionModel{},
+		&custmodel.UserIdentityModel{},
+	)
+	dbTimeout := test.NewTestDBTimeouts()
+	logger := test.NewTestZapLogger()
+	enforcer, _ := auth.NewCasbinEnforcer()
+	jwtConf := auth.NewJWTConfig(
+		time.Duration(10)*time.Second,
+		time.Duration(10)*time.Minute,
+		"HS256",
+		"HS256",
+		[]byte("test_access_secret"),
+		[]byte("test_refresh_secret"),
+	)
+
+	roleRepo := custsvc.NewRoleRepo(logger, db, dbTimeout, enforcer)
+	userRepo := custsvc.NewUserRepo(logger, db, dbTimeout)
+	suite.uc = &UserService{
+		log:      logger,
+		roleRepo: roleRepo,
+		userRepo: userRepo,
+		recordRepo: custsvc.NewLoginRecordRepo(
+			logger, db, dbTimeout,
+			time.Duration(10)*time.Minute,
+			time.Duration(10)*time.Minute,
+			2,
+		),
+		hasher:   crypto.NewBcryptHasher(4),
+		jwt:      jwtConf,
+		sessions: NewSessionService(logger, custsvc.NewUserSessionRepo(logger, db, dbTimeout), jwtConf, nil),
+		sec:      SecuritySettings{PasswordStrength: 3, DisableLocalLogin: true},
+	}
+
+	suite.opsRole = CreateTestRoleModel()
+	suite.Require().NoError(roleRepo.CreateModel(context.Background(), suite.opsRole, nil, nil, nil))
+	suite.guestRole = CreateTestRoleModel()
+	suite.Require().NoError(roleRepo.CreateModel(context.Background(), suite.guestRole, nil, nil, nil))
+
+	suite.srv = oidctest.NewServer("artweb")
+	provider := func(name string, autoCreate bool) config.OIDCProviderConfig {
+		return config.OIDCProviderConfig{
+			Name:         name,
+			Issuer:       suite.srv.URL,
+			ClientID:     "artweb",
+			RedirectURL:  "http://127.0.0.1/login/oidc",
+			AutoCreate:   autoCreate,
+			SyncRole:     true,
+			DefaultRole:  suite.guestRole.Name,
+			RoleMappings: []config.OIDCRoleMapping{{Claim: "groups", Value: "ops", Role: suite.opsRole.Name}},
+		}
+	}
+	oc, err := NewOidcService(
+		logger, suite.uc, roleRepo, userRepo,
+		custsvc.NewUserIdentityRepo(logger, db, dbTimeout),
+		&config.OIDCConfig{
+			DisableLocalLogin: true,
+			Providers:         []config.OIDCProviderConfig{provider("sso", true), provider("manual", false)},
+		},
+	)
+	suite.Require().NoError(err)
+	suite.oc = oc
+}
+
+func (suite *OidcTestSuite) TearDownSuite() {
+	suite.srv.Close()
+}
+
+func TestOidcTestSuite(t *testing.T) {
+	suite.Run(t, new(OidcTestSuite))
+}
+
+// login 模拟浏览器完成一次授权并回调
+func (suite *OidcTestSuite) login(provider string, linkUserID uint32, claims map[string]any) (string, *errors.Error) {
+	ctx := context.Background()
+	authURL, state, rErr := suite.oc.AuthURL(ctx, provider, linkUserID)
+	suite.Require().Nil(rErr)
+	suite.srv.SetClaims(claims)
+	code, gotState, err := suite.srv.Authorize(authURL)
+	suite.Require().NoError(err)
+	suite.Require().Equal(state, gotState)
+	access, _, rErr := suite.oc.Callback(ctx, state, code, "127.0.0.1", "test")
+	return access, rErr
+}
+
+func (suite *OidcTestSuite) TestListProviders() {
+	out := suite.oc.ListProviders()
+	suite.False(out.LocalLogin)
+	suite.Len(out.Providers, 2)
+	suite.Equal("sso", out.Providers[0].Name)
+	suite.Equal("sso", out.Providers[0].DisplayName)
+
+	_, _, rErr := suite.oc.AuthURL(context.Background(), "unknown", 0)
+	suite.Equal(errors.ErrOidcProviderNotFound.Reason, rErr.Reason)
+}
+
+func (suite *OidcTestSuite) TestLocalLoginDisabled() {
+	_, _, rErr := suite.uc.Login(context.Background(), "admin", "Test123!@#$%", "127.0.0.1", "test", "", "")
+	suite.Equal(errors.ErrLocalLoginDisabled.Reason, rErr.Reason)
+}
+
+func (suite *OidcTestSuite) TestAutoCreateAndSyncRole() {
+	ctx := context.Background()
+	sub, username := uuid.NewString(), uuid.NewString()[:20]
+
+	access, rErr := suite.login("sso", 0, map[string]any{"sub": sub, "preferred_username": username, "groups": []string{"dev", "ops"}})
+	suite.Require().Nil(rErr)
+	suite.NotEmpty(access)
+	m, rErr := suite.uc.FindUserByName(ctx, nil, username)
+	suite.Require().Nil(rErr)
+	suite.Equal(suite.opsRole.ID, m.RoleID)
+
+	// 再次登录时使用已关联的用户, 并按映射规则同步角色
+	_, rErr = suite.login("sso", 0, map[string]any{"sub": sub, "preferred_username": "renamed", "email": "a@example.com"})
+	suite.Require().Nil(rErr)
+	m, rErr = suite.uc.FindUserByName(ctx, nil, username)
+	suite.Require().Nil(rErr)
+	suite.Equal(suite.guestRole.ID, m.RoleID)
+
+	ims, rErr := suite.oc.ListUserIdentity(ctx, m.ID)
+	suite.Require().Nil(rErr)
+	suite.Require().Len(*ims, 1)
+	suite.Equal("a@example.com", (*ims)[0].Email)
+	suite.NotNil((*ims)[0].LastLoginAt)
+}
+
+func (suite *OidcTestSuite) TestLocalUserNotTakenOver() {
+	ctx := context.Background()
+	local, rErr := suite.uc.CreateUser(ctx, *CreateTestUserModel(suite.guestRole.ID))
+	suite.Require().Nil(rErr)
+
+	// 同名的本地用户不会被外部身份接管
+	_, rErr = suite.login("sso", 0, map[string]any{"sub": uuid.NewString(), "preferred_username": local.Username})
+	suite.Equal(errors.ErrOidcUserNotLinked.Reason, rErr.Reason)
+
+	// 未开启自动创建时需要先关联
+	sub := uuid.NewString()
+	_, rErr = suite.login("manual", 0, map[string]any{"sub": sub})
+	suite.Equal(errors.ErrOidcUserNotLinked.Reason, rErr.Reason)
+
+	// 登录后关联外部身份, 之后可以使用该身份登录
+	_, rErr = suite.login("manual", local.ID, map[string]any{"sub": sub})
+	suite.Require().Nil(rErr)
+	_, rErr = suite.login("manual", 0, map[string]any{"sub": sub, "groups": "ops"})
+	suite.Require().Nil(rErr)
+	m, rErr := suite.uc.FindUserByID(ctx, nil, local.ID)
+	suite.Require().Nil(rErr)
+	suite.Equal(suite.opsRole.ID, m.RoleID)
+
+	// 外部身份不能再关联到其他用户, 同一个提供方也不能关联第二个身份
+	other, rErr := suite.uc.CreateUser(ctx, *CreateTestUserModel(suite.guestRole.ID))
+	suite.Require().Nil(rErr)
+	_, rErr = suite.login("manual", other.ID, map[string]any{"sub": sub})
+	suite.Equal(errors.ErrOidcIdentityLinked.Reason, rErr.Reason)
+	_, rErr = suite.login("manual", local.ID, map[string]any{"sub": uuid.NewString()})
+	suite.Equal(errors.ErrOidcIdentityLinked.Reason, rErr.Reason)
+
+	// 解除关联后不能再使用该身份登录
+	suite.Nil(suite.oc.UnlinkIdentity(ctx, local.ID, "manual"))
+	_, rErr = suite.login("manual", 0, map[string]any{"sub": sub})
+	suite.Equal(errors.ErrOidcUserNotLinked.Reason, rErr.Reason)
+	suite.NotNil(suite.oc.UnlinkIdentity(ctx, local.ID, "manual"))
+}
+
+func (suite *OidcTestSuite) TestStateUsedOnce() {
+	ctx := context.Background()
+	authURL, state, rErr := suite.oc.AuthURL(ctx, "sso", 0)
+	suite.Require().Nil(rErr)
+	suite.srv.SetClaims(map[string]any{"sub": uuid.NewString(), "preferred_username": uuid.NewString()[:20]})
+	code, _, err := suite.srv.Authorize(authURL)
+	suite.Require().NoError(err)
+
+	_, _, rErr = suite.oc.Callback(ctx, "forged", code, "127.0.0.1", "test")
+	suite.Equal(errors.ErrOidcStateInvalid.Reason, rErr.Reason)
+	_, _, rErr = suite.oc.Callback(ctx, state, code, "127.0.0.1", "test")
+	suite.Nil(rErr)
+	_, _, rErr = suite.oc.Callback(ctx, state, code, "127.0.0.1", "test")
+	suite.Equal(errors.ErrOidcStateInvalid.Reason, rErr.Reason)
+}
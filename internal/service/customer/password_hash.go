@@ -0,0 +1,85 @@
+package customer
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	custmodel "gin-artweb/internal/model/customer"
+	custrepo "gin-artweb/internal/repository/customer"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+	"gin-artweb/pkg/crypto"
+)
+
+// PasswordHashService 密码哈希统计服务
+//
+// 修改哈希算法或参数后, 已有的密码在用户下次登录成功时重新哈希, 通过统计了解仍使用旧算法或旧参数的用户数量
+type PasswordHashService struct {
+	log      *zap.Logger
+	userRepo *custrepo.UserRepo
+	hasher   *crypto.PasswordHasher
+}
+
+func NewPasswordHashService(
+	log *zap.Logger,
+	userRepo *custrepo.UserRepo,
+	hasher *crypto.PasswordHasher,
+) *PasswordHashService {
+	return &PasswordHashService{
+		log:      log,
+		userRepo: userRepo,
+		hasher:   hasher,
+	}
+}
+
+// Report 按算法统计用户的密码哈希, 区分是否使用当前的算法和参数
+func (s *PasswordHashService) Report(ctx context.Context) (*custmodel.PasswordHashReportOut, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	_, ms, err := s.userRepo.ListModel(ctx, database.QueryParams{
+		Columns: []string{"id", "password"},
+		OrderBy: []string{"id ASC"},
+	})
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询用户密码哈希失败",
+			zap.Error(err),
+		)
+		return nil, errors.NewGormError(err, nil)
+	}
+
+	out := &custmodel.PasswordHashReportOut{
+		Algorithm:  s.hasher.Algorithm(),
+		Algorithms: []custmodel.PasswordHashCountOut{},
+	}
+	index := make(map[string]int)
+	for _, m := range *ms {
+		algorithm, current := s.hasher.Inspect(m.Password)
+		i, ok := index[algorithm]
+		if !ok {
+			i = len(out.Algorithms)
+			index[algorithm] = i
+			out.Algorithms = append(out.Algorithms, custmodel.PasswordHashCountOut{Algorithm: algorithm})
+		}
+		out.Total++
+		out.Algorithms[i].Total++
+		if current {
+			out.Current++
+		} else {
+			out.Legacy++
+			out.Algorithms[i].Legacy++
+		}
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"统计用户密码哈希成功",
+		zap.String("algorithm", out.Algorithm),
+		zap.Int64("total", out.Total),
+		zap.Int64("legacy", out.Legacy),
+	)
+	return out, nil
+}
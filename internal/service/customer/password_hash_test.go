@@ -0,0 +1,72 @@
+package customer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	custmodel "gin-artweb/internal/model/customer"
+	custrepo "gin-artweb/internal/repository/customer"
+	"gin-artweb/internal/shared/test"
+	"gin-artweb/pkg/crypto"
+)
+
+type PasswordHashTestSuite struct {
+	suite.Suite
+	userRepo *custrepo.UserRepo
+	ps       *PasswordHashService
+}
+
+func (suite *PasswordHashTestSuite) SetupSuite() {
+	db := test.NewTestGormDBWithConfig(nil)
+	db.AutoMigrate(
+		&custmodel.RoleModel{},
+		&custmodel.UserModel{},
+	)
+	logger := test.NewTestZapLogger()
+	hasher, err := crypto.NewPasswordHasher(crypto.PasswordHashOptions{
+		Algorithm:    crypto.AlgorithmArgon2id,
+		Argon2Memory: 1024,
+		Argon2Time:   1,
+	})
+	suite.Require().NoError(err)
+	suite.userRepo = custrepo.NewUserRepo(logger, db, test.NewTestDBTimeouts())
+	suite.ps = NewPasswordHashService(logger, suite.userRepo, hasher)
+}
+
+func TestPasswordHashTestSuite(t *testing.T) {
+	suite.Run(t, new(PasswordHashTestSuite))
+}
+
+func (suite *PasswordHashTestSuite) TestReport() {
+	ctx := context.Background()
+	legacy, err := crypto.NewPasswordHasher(crypto.PasswordHashOptions{BcryptCost: 4})
+	suite.Require().NoError(err)
+	oldArgon, err := crypto.NewPasswordHasher(crypto.PasswordHashOptions{
+		Algorithm:    crypto.AlgorithmArgon2id,
+		Argon2Memory: 2048,
+		Argon2Time:   1,
+	})
+	suite.Require().NoError(err)
+
+	hashers := []crypto.Hasher{legacy, legacy, oldArgon, suite.ps.hasher}
+	for _, h := range hashers {
+		m := CreateTestUserModel(1)
+		hashed, err := h.Hash(ctx, m.Password)
+		suite.Require().NoError(err)
+		m.Password = hashed
+		suite.Require().NoError(suite.userRepo.CreateModel(ctx, m))
+	}
+
+	out, rErr := suite.ps.Report(ctx)
+	suite.Require().Nil(rErr)
+	suite.Equal(crypto.AlgorithmArgon2id, out.Algorithm)
+	suite.Equal(int64(4), out.Total)
+	suite.Equal(int64(1), out.Current)
+	suite.Equal(int64(3), out.Legacy)
+	suite.Equal([]custmodel.PasswordHashCountOut{
+		{Algorithm: crypto.AlgorithmBcrypt, Total: 2, Legacy: 2},
+		{Algorithm: crypto.AlgorithmArgon2id, Total: 2, Legacy: 1},
+	}, out.Algorithms)
+}
@@ -0,0 +1,259 @@
+package customer
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"io"
+	"slices"
+	"strconv"
+	"time"
+
+	emperror "emperror.dev/errors"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/patrickmn/go-cache"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	custmodel "gin-artweb/internal/model/customer"
+	custrepo "gin-artweb/internal/repository/customer"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+)
+
+const (
+	defaultPreferenceMaxSize       = 16 * 1024
+	defaultPreferenceMaxNamespaces = 50
+
+	// preferenceLanguageTTL 用户界面语言的缓存时间, 每个请求确定响应语言时都会读取
+	preferenceLanguageTTL = 5 * time.Minute
+)
+
+// PreferenceService 用户界面偏好服务
+//
+// 偏好按命名空间保存, 保存前按命名空间的格式严格解析和校验, 不接受未定义的字段,
+// 保存的是重新编码后的内容
+type PreferenceService struct {
+	log           *zap.Logger
+	prefRepo      *custrepo.UserPreferenceRepo
+	maxSize       int
+	maxNamespaces int
+	langCache     *cache.Cache
+}
+
+func NewPreferenceService(
+	log *zap.Logger,
+	prefRepo *custrepo.UserPreferenceRepo,
+	conf *config.PreferenceConfig,
+) *PreferenceService {
+	var c config.PreferenceConfig
+	if conf != nil {
+		c = *conf
+	}
+	return &PreferenceService{
+		log:           log,
+		prefRepo:      prefRepo,
+		maxSize:       cmp.Or(c.MaxSize, defaultPreferenceMaxSize),
+		maxNamespaces: cmp.Or(c.MaxNamespaces, defaultPreferenceMaxNamespaces),
+		langCache:     cache.New(preferenceLanguageTTL, 2*preferenceLanguageTTL),
+	}
+}
+
+// ListPreference 查询用户的全部偏好, 按命名空间排序
+func (s *PreferenceService) ListPreference(
+	ctx context.Context,
+	userID uint32,
+) (*[]custmodel.UserPreferenceModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	qp := database.QueryParams{
+		OrderBy: []string{"namespace ASC"},
+		Query:   map[string]any{"user_id = ?": userID},
+	}
+	_, ms, err := s.prefRepo.ListModel(ctx, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询用户偏好列表失败",
+			zap.Error(err),
+			zap.Uint32(ctxutil.UserIDKey, userID),
+		)
+		return nil, errors.NewGormError(err, nil)
+	}
+	return ms, nil
+}
+
+// FindPreference 查询用户在指定命名空间下的偏好
+func (s *PreferenceService) FindPreference(
+	ctx context.Context,
+	userID uint32,
+	namespace string,
+) (*custmodel.UserPreferenceModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+	if _, ok := custmodel.PreferenceSchema(namespace); !ok {
+		return nil, errors.ErrPreferenceNamespaceInvalid.WithField("namespace", namespace)
+	}
+
+	m, err := s.prefRepo.GetModel(ctx, nil, "user_id = ? AND namespace = ?", userID, namespace)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询用户偏好失败",
+			zap.Error(err),
+			zap.Uint32(ctxutil.UserIDKey, userID),
+			zap.String("namespace", namespace),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"namespace": namespace})
+	}
+	return m, nil
+}
+
+// SavePreference 保存用户在指定命名空间下的偏好, 已有时整体替换
+func (s *PreferenceService) SavePreference(
+	ctx context.Context,
+	userID uint32,
+	namespace string,
+	raw []byte,
+) (*custmodel.UserPreferenceModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	value, rErr := s.normalize(namespace, raw)
+	if rErr != nil {
+		ctxutil.Logger(ctx, s.log).Warn(
+			"用户偏好内容校验失败",
+			zap.Error(rErr),
+			zap.Uint32(ctxutil.UserIDKey, userID),
+			zap.String("namespace", namespace),
+			zap.Int("size", len(raw)),
+		)
+		return nil, rErr
+	}
+
+	// 新增命名空间时检查数量上限
+	ms, rErr := s.ListPreference(ctx, userID)
+	if rErr != nil {
+		return nil, rErr
+	}
+	exists := slices.ContainsFunc(*ms, func(m custmodel.UserPreferenceModel) bool {
+		return m.Namespace == namespace
+	})
+	if !exists && len(*ms) >= s.maxNamespaces {
+		return nil, errors.ErrPreferenceLimitExceeded.WithField("max_namespaces", s.maxNamespaces)
+	}
+
+	m := &custmodel.UserPreferenceModel{
+		UserID:    userID,
+		Namespace: namespace,
+		Value:     string(value),
+	}
+	if err := s.prefRepo.SaveModel(ctx, m); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"保存用户偏好失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, m),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"namespace": namespace})
+	}
+	if namespace == custmodel.PreferenceTheme {
+		s.langCache.Delete(strconv.FormatUint(uint64(userID), 10))
+	}
+	return m, nil
+}
+
+// UserLanguage 返回用户在界面主题偏好中选择的语言, 未设置或查询失败时返回空字符串
+func (s *PreferenceService) UserLanguage(ctx context.Context, userID uint32) string {
+	key := strconv.FormatUint(uint64(userID), 10)
+	if lang, ok := s.langCache.Get(key); ok {
+		return lang.(string)
+	}
+
+	m, err := s.prefRepo.GetModel(ctx, nil, "user_id = ? AND namespace = ?", userID, custmodel.PreferenceTheme)
+	if err != nil {
+		if !emperror.Is(err, gorm.ErrRecordNotFound) {
+			ctxutil.Logger(ctx, s.log).Warn(
+				"查询用户界面语言失败",
+				zap.Error(err),
+				zap.Uint32(ctxutil.UserIDKey, userID),
+			)
+			return ""
+		}
+		s.langCache.SetDefault(key, "")
+		return ""
+	}
+	var theme custmodel.ThemePreference
+	if err := json.Unmarshal([]byte(m.Value), &theme); err != nil {
+		ctxutil.Logger(ctx, s.log).Warn(
+			"解析用户界面主题偏好失败",
+			zap.Error(err),
+			zap.Uint32(ctxutil.UserIDKey, userID),
+		)
+	}
+	s.langCache.SetDefault(key, theme.Language)
+	return theme.Language
+}
+
+// normalize 按命名空间的格式解析偏好内容, 返回重新编码的JSON
+func (s *PreferenceService) normalize(namespace string, raw []byte) ([]byte, *errors.Error) {
+	schema, ok := custmodel.PreferenceSchema(namespace)
+	if !ok {
+		return nil, errors.ErrPreferenceNamespaceInvalid.WithField("namespace", namespace)
+	}
+	if len(raw) > s.maxSize {
+		return nil, errors.ErrPreferenceTooLarge.WithField("max_size", s.maxSize)
+	}
+	if !bytes.HasPrefix(bytes.TrimSpace(raw), []byte("{")) {
+		return nil, errors.ErrPreferenceInvalid.WithCause(emperror.New("偏好内容必须是JSON对象"))
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(schema); err != nil {
+		return nil, errors.ErrPreferenceInvalid.WithCause(err)
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, errors.ErrPreferenceInvalid.WithCause(emperror.New("偏好内容只能包含一个JSON对象"))
+	}
+	if err := binding.Validator.ValidateStruct(schema); err != nil {
+		return nil, errors.ErrPreferenceInvalid.WithCause(err)
+	}
+	value, err := json.Marshal(schema)
+	if err != nil {
+		return nil, errors.ErrPreferenceInvalid.WithCause(err)
+	}
+	return value, nil
+}
+
+// DeletePreference 删除用户在指定命名空间下的偏好, 不存在时不报错
+func (s *PreferenceService) DeletePreference(
+	ctx context.Context,
+	userID uint32,
+	namespace string,
+) *errors.Error {
+	if ctx.Err() != nil {
+		return errors.FromError(ctx.Err())
+	}
+	if _, ok := custmodel.PreferenceSchema(namespace); !ok {
+		return errors.ErrPreferenceNamespaceInvalid.WithField("namespace", namespace)
+	}
+
+	if err := s.prefRepo.DeleteModel(ctx, "user_id = ? AND namespace = ?", userID, namespace); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"删除用户偏好失败",
+			zap.Error(err),
+			zap.Uint32(ctxutil.UserIDKey, userID),
+			zap.String("namespace", namespace),
+		)
+		return errors.NewGormError(err, map[string]any{"namespace": namespace})
+	}
+	if namespace == custmodel.PreferenceTheme {
+		s.langCache.Delete(strconv.FormatUint(uint64(userID), 10))
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package customer
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	custmodel "gin-artweb/internal/model/customer"
+	custsvc "gin-artweb/internal/repository/customer"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/errors"
+	"gin-artweb/internal/shared/test"
+)
+
+type PreferenceTestSuite struct {
+	suite.Suite
+	ps *PreferenceService
+}
+
+func (suite *PreferenceTestSuite) SetupTest() {
+	db := test.NewTestGormDBWithConfig(nil)
+	db.AutoMigrate(&custmodel.UserPreferenceModel{})
+	logger := test.NewTestZapLogger()
+	suite.ps = NewPreferenceService(
+		logger,
+		custsvc.NewUserPreferenceRepo(logger, db, test.NewTestDBTimeouts()),
+		&config.PreferenceConfig{MaxSize: 256, MaxNamespaces: 2},
+	)
+}
+
+func TestPreferenceTestSuite(t *testing.T) {
+	suite.Run(t, new(PreferenceTestSuite))
+}
+
+func (suite *PreferenceTestSuite) TestSaveAndFind() {
+	ctx := context.Background()
+	m, rErr := suite.ps.SavePreference(ctx, 1, custmodel.PreferenceTheme, []byte(`{ "mode": "dark", "primary_color": "#1677ff" }`))
+	suite.Require().Nil(rErr)
+	suite.JSONEq(`{"mode":"dark","primary_color":"#1677ff","compact":false,"language":""}`, m.Value, "应该保存重新编码后的内容")
+
+	fm, rErr := suite.ps.FindPreference(ctx, 1, custmodel.PreferenceTheme)
+	suite.Require().Nil(rErr)
+	suite.Equal(m.Value, fm.Value)
+
+	_, rErr = suite.ps.FindPreference(ctx, 2, custmodel.PreferenceTheme)
+	suite.Require().NotNil(rErr)
+	suite.Equal(errors.ErrRecordNotFound.Reason, rErr.Reason)
+
+	_, rErr = suite.ps.SavePreference(ctx, 1, "table.mds_colony", []byte(`{"columns":[{"key":"name","width":120}],"sort_order":"desc"}`))
+	suite.Require().Nil(rErr)
+	ms, rErr := suite.ps.ListPreference(ctx, 1)
+	suite.Require().Nil(rErr)
+	suite.Require().Len(*ms, 2)
+	suite.Equal("table.mds_colony", (*ms)[0].Namespace, "应该按命名空间排序")
+
+	suite.Require().Nil(suite.ps.DeletePreference(ctx, 1, "table.mds_colony"))
+	ms, rErr = suite.ps.ListPreference(ctx, 1)
+	suite.Require().Nil(rErr)
+	suite.Len(*ms, 1)
+}
+
+func (suite *PreferenceTestSuite) TestValidate() {
+	ctx := context.Background()
+	cases := []struct {
+		name      string
+		namespace string
+		raw       string
+		reason    errors.ErrorReason
+	}{
+		{"不支持的命名空间", "layout", `{}`, errors.ErrPreferenceNamespaceInvalid.Reason},
+		{"不合法的表格标识", "table.A/B", `{}`, errors.ErrPreferenceNamespaceInvalid.Reason},
+		{"内容过大", custmodel.PreferenceTheme, `{"mode":"` + strings.Repeat("a", 300) + `"}`, errors.ErrPreferenceTooLarge.Reason},
+		{"不是JSON对象", custmodel.PreferenceTheme, `["dark"]`, errors.ErrPreferenceInvalid.Reason},
+		{"JSON格式错误", custmodel.PreferenceTheme, `{"mode":`, errors.ErrPreferenceInvalid.Reason},
+		{"未定义的字段", custmodel.PreferenceTheme, `{"mode":"dark","font":"mono"}`, errors.ErrPreferenceInvalid.Reason},
+		{"字段类型错误", custmodel.PreferenceTheme, `{"compact":"yes"}`, errors.ErrPreferenceInvalid.Reason},
+		{"字段取值错误", custmodel.PreferenceTheme, `{"mode":"blue"}`, errors.ErrPreferenceInvalid.Reason},
+		{"多个JSON对象", custmodel.PreferenceTheme, `{"mode":"dark"}{}`, errors.ErrPreferenceInvalid.Reason},
+		{"列设置缺少标识", "table.mds_colony", `{"columns":[{"width":10}]}`, errors.ErrPreferenceInvalid.Reason},
+	}
+	for _, c := range cases {
+		_, rErr := suite.ps.SavePreference(ctx, 1, c.namespace, []byte(c.raw))
+		suite.Require().NotNil(rErr, c.name)
+		suite.Equal(c.reason, rErr.Reason, c.name)
+	}
+	ms, rErr := suite.ps.ListPreference(ctx, 1)
+	suite.Require().Nil(rErr)
+	suite.Empty(*ms, "校验失败时不应该保存")
+}
+
+func (suite *PreferenceTestSuite) TestNamespaceLimit() {
+	ctx := context.Background()
+	_, rErr := suite.ps.SavePreference(ctx, 1, "table.a", []byte(`{}`))
+	suite.Require().Nil(rErr)
+	_, rErr = suite.ps.SavePreference(ctx, 1, "table.b", []byte(`{}`))
+	suite.Require().Nil(rErr)
+
+	_, rErr = suite.ps.SavePreference(ctx, 1, "table.c", []byte(`{}`))
+	suite.Require().NotNil(rErr)
+	suite.Equal(errors.ErrPreferenceLimitExceeded.Reason, rErr.Reason)
+
+	_, rErr = suite.ps.SavePreference(ctx, 1, "table.b", []byte(`{"page_size":50}`))
+	suite.Nil(rErr, "更新已有的命名空间不受数量限制")
+	_, rErr = suite.ps.SavePreference(ctx, 2, "table.c", []byte(`{}`))
+	suite.Nil(rErr, "数量限制按用户计算")
+}
@@ -0,0 +1,845 @@
+package customer
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"go.uber.org/zap"
+
+	custmodel "gin-artweb/internal/model/customer"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+	"gin-artweb/pkg/textdiff"
+)
+
+// rbacCasbinModelKey 文档中Casbin模型条目的引用标识
+const rbacCasbinModelKey = "active"
+
+// rbacApiMethods 文档中API允许的请求方法, 与API接口的校验规则一致
+var rbacApiMethods = []string{"GET", "POST", "PUT", "DELETE", "PATCH", "WS"}
+
+// RbacService 导出和导入完整的权限配置
+// 导入时按API、菜单、按钮、角色的顺序调用各对象的服务写入, 由各服务同步更新Casbin策略
+// 目标环境中文档未包含的对象保持不变
+type RbacService struct {
+	log       *zap.Logger
+	svcApi    *ApiService
+	svcMenu   *MenuService
+	svcButton *ButtonService
+	svcRole   *RoleService
+	svcModel  *CasbinModelService
+}
+
+func NewRbacService(
+	log *zap.Logger,
+	svcApi *ApiService,
+	svcMenu *MenuService,
+	svcButton *ButtonService,
+	svcRole *RoleService,
+	svcModel *CasbinModelService,
+) *RbacService {
+	return &RbacService{
+		log:       log,
+		svcApi:    svcApi,
+		svcMenu:   svcMenu,
+		svcButton: svcButton,
+		svcRole:   svcRole,
+		svcModel:  svcModel,
+	}
+}
+
+// rbacState 当前环境的权限配置, 以及业务标识到数据库ID的映射
+type rbacState struct {
+	doc          custmodel.RbacDocument
+	apis         map[string]uint32
+	menus        map[string]uint32
+	buttons      map[string]uint32
+	roles        map[string]uint32
+	roleVersions map[string]uint32
+}
+
+// rbacPlan 导入计划, 记录文档中每个条目的处理动作和导入后的权限配置
+type rbacPlan struct {
+	conflict string
+	out      *custmodel.RbacImportOut
+	actions  map[string]string
+	merged   custmodel.RbacDocument
+}
+
+func (p *rbacPlan) resolve(fields []string) string {
+	if len(fields) == 0 {
+		return custmodel.RbacActionUnchanged
+	}
+	switch p.conflict {
+	case custmodel.RbacConflictSkip:
+		return custmodel.RbacActionSkip
+	case custmodel.RbacConflictOverwrite:
+		return custmodel.RbacActionUpdate
+	default:
+		return custmodel.RbacActionConflict
+	}
+}
+
+func (p *rbacPlan) add(kind, key, action string, fields []string) {
+	p.actions[kind+"|"+key] = action
+	p.out.Summary[action]++
+	if action == custmodel.RbacActionUnchanged {
+		return
+	}
+	p.out.Items = append(p.out.Items, custmodel.RbacImportItemOut{
+		Kind:   kind,
+		Key:    key,
+		Action: action,
+		Fields: fields,
+	})
+}
+
+// action 返回条目的处理动作, 需要写入时返回true
+func (p *rbacPlan) action(kind, key string) (string, bool) {
+	action := p.actions[kind+"|"+key]
+	return action, action == custmodel.RbacActionCreate || action == custmodel.RbacActionUpdate
+}
+
+// ExportRbac 导出当前环境的权限配置
+func (s *RbacService) ExportRbac(ctx context.Context) (*custmodel.RbacDocument, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始导出权限配置",
+	)
+
+	state, rErr := s.loadRbacState(ctx)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	doc := state.doc
+	doc.ExportedAt = time.Now().Format(time.DateTime)
+	doc.Policies = rbacPolicies(&doc)
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"导出权限配置成功",
+		zap.Int("apis", len(doc.Apis)),
+		zap.Int("menus", len(doc.Menus)),
+		zap.Int("buttons", len(doc.Buttons)),
+		zap.Int("roles", len(doc.Roles)),
+	)
+	return &doc, nil
+}
+
+// ParseRbacDocument 解析YAML格式的权限配置文档, 不允许出现未定义的字段
+func ParseRbacDocument(data []byte) (*custmodel.RbacDocument, *errors.Error) {
+	var doc custmodel.RbacDocument
+	if err := yaml.UnmarshalWithOptions(data, &doc, yaml.Strict()); err != nil {
+		return nil, errors.ErrRbacDocumentInvalid.WithCause(err)
+	}
+	return &doc, nil
+}
+
+// ImportRbac 导入权限配置
+// dryRun为true时只返回与当前环境的差异; 否则存在冲突且冲突处理方式为fail时不写入任何数据
+func (s *RbacService) ImportRbac(
+	ctx context.Context,
+	doc custmodel.RbacDocument,
+	dryRun bool,
+	conflict string,
+	operator string,
+) (*custmodel.RbacImportOut, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+	if conflict == "" {
+		conflict = custmodel.RbacConflictFail
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始导入权限配置",
+		zap.Bool("dry_run", dryRun),
+		zap.String("conflict", conflict),
+		zap.Int("apis", len(doc.Apis)),
+		zap.Int("menus", len(doc.Menus)),
+		zap.Int("buttons", len(doc.Buttons)),
+		zap.Int("roles", len(doc.Roles)),
+	)
+
+	state, rErr := s.loadRbacState(ctx)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	if rErr := validateRbacDocument(&doc, &state.doc); rErr != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"导入权限配置失败: 文档无效",
+			zap.Error(rErr),
+		)
+		return nil, rErr
+	}
+
+	plan := planRbacImport(&doc, &state.doc, dryRun, conflict)
+	if rErr := validateRbacMerged(&plan.merged); rErr != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"导入权限配置失败: 导入后的权限配置无效",
+			zap.Error(rErr),
+		)
+		return nil, rErr
+	}
+	if dryRun {
+		ctxutil.Logger(ctx, s.log).Info(
+			"比较权限配置差异成功",
+			zap.Any("summary", plan.out.Summary),
+		)
+		return plan.out, nil
+	}
+	if plan.out.Summary[custmodel.RbacActionConflict] > 0 {
+		ctxutil.Logger(ctx, s.log).Warn(
+			"导入权限配置失败: 与已有数据冲突",
+			zap.Any("summary", plan.out.Summary),
+		)
+		return nil, errors.ErrRbacImportConflict.WithField("result", plan.out)
+	}
+
+	if rErr := s.applyRbacImport(ctx, &doc, state, plan, operator); rErr != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"导入权限配置失败",
+			zap.Error(rErr),
+		)
+		return nil, rErr
+	}
+	plan.out.Applied = true
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"导入权限配置成功",
+		zap.Any("summary", plan.out.Summary),
+	)
+	return plan.out, nil
+}
+
+// loadRbacState 查询当前环境的权限配置
+func (s *RbacService) loadRbacState(ctx context.Context) (*rbacState, *errors.Error) {
+	cm, rErr := s.svcModel.FindActiveModel(ctx)
+	if rErr != nil {
+		return nil, rErr
+	}
+	_, apis, rErr := s.svcApi.ListApi(ctx, database.QueryParams{})
+	if rErr != nil {
+		return nil, rErr
+	}
+	_, menus, rErr := s.svcMenu.ListMenu(ctx, database.QueryParams{Preloads: []string{"Apis"}})
+	if rErr != nil {
+		return nil, rErr
+	}
+	_, buttons, rErr := s.svcButton.ListButton(ctx, database.QueryParams{Preloads: []string{"Apis"}})
+	if rErr != nil {
+		return nil, rErr
+	}
+	_, roles, rErr := s.svcRole.ListRole(ctx, database.QueryParams{Preloads: []string{"Apis", "Menus", "Buttons"}})
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	state := &rbacState{
+		doc: custmodel.RbacDocument{
+			Version:     custmodel.RbacDocumentVersion,
+			CasbinModel: cm.Content,
+		},
+		apis:         make(map[string]uint32),
+		menus:        make(map[string]uint32),
+		buttons:      make(map[string]uint32),
+		roles:        make(map[string]uint32),
+		roleVersions: make(map[string]uint32),
+	}
+
+	apiKeys := make(map[uint32]string)
+	for _, m := range derefSlice(apis) {
+		item := custmodel.RbacApiItem{URL: m.URL, Method: m.Method, Label: m.Label, Descr: m.Descr}
+		apiKeys[m.ID] = item.Key()
+		state.apis[item.Key()] = m.ID
+		state.doc.Apis = append(state.doc.Apis, item)
+	}
+	apiNames := func(ms []custmodel.ApiModel) []string {
+		names := make([]string, 0, len(ms))
+		for _, m := range ms {
+			names = append(names, apiKeys[m.ID])
+		}
+		return normalizeRbacRefs(names)
+	}
+
+	menuNames := make(map[uint32]string)
+	for _, m := range derefSlice(menus) {
+		menuNames[m.ID] = m.Name
+		state.menus[m.Name] = m.ID
+	}
+	for _, m := range derefSlice(menus) {
+		item := custmodel.RbacMenuItem{
+			Name:      m.Name,
+			Path:      m.Path,
+			Component: m.Component,
+			Meta:      custmodel.RbacMenuMeta{Title: m.Meta.Title, Icon: m.Meta.Icon},
+			Sort:      m.Sort,
+			IsActive:  m.IsActive,
+			Descr:     m.Descr,
+			Apis:      apiNames(m.Apis),
+		}
+		if m.ParentID != nil {
+			item.Parent = menuNames[*m.ParentID]
+		}
+		state.doc.Menus = append(state.doc.Menus, item)
+	}
+
+	for _, m := range derefSlice(buttons) {
+		state.buttons[m.Name] = m.ID
+		state.doc.Buttons = append(state.doc.Buttons, custmodel.RbacButtonItem{
+			Name:     m.Name,
+			Menu:     menuNames[m.MenuID],
+			Sort:     m.Sort,
+			IsActive: m.IsActive,
+			Descr:    m.Descr,
+			Apis:     apiNames(m.Apis),
+		})
+	}
+
+	for _, m := range derefSlice(roles) {
+		state.roles[m.Name] = m.ID
+		state.roleVersions[m.Name] = m.Version
+		item := custmodel.RbacRoleItem{
+			Name:  m.Name,
+			Descr: m.Descr,
+			Apis:  apiNames(m.Apis),
+		}
+		for _, menu := range m.Menus {
+			item.Menus = append(item.Menus, menu.Name)
+		}
+		for _, button := range m.Buttons {
+			item.Buttons = append(item.Buttons, button.Name)
+		}
+		item.Menus = normalizeRbacRefs(item.Menus)
+		item.Buttons = normalizeRbacRefs(item.Buttons)
+		state.doc.Roles = append(state.doc.Roles, item)
+	}
+
+	sortRbacDocument(&state.doc)
+	return state, nil
+}
+
+// applyRbacImport 按依赖顺序写入需要新增或更新的条目
+func (s *RbacService) applyRbacImport(
+	ctx context.Context,
+	doc *custmodel.RbacDocument,
+	state *rbacState,
+	plan *rbacPlan,
+	operator string,
+) *errors.Error {
+	if _, ok := plan.action(custmodel.RbacKindCasbinModel, rbacCasbinModelKey); ok {
+		if _, rErr := s.svcModel.UpdateModel(ctx, doc.CasbinModel, nil, "导入权限配置", operator); rErr != nil {
+			return rErr
+		}
+	}
+
+	ids := func(refs []string, m map[string]uint32) []uint32 {
+		pks := make([]uint32, 0, len(refs))
+		for _, ref := range refs {
+			pks = append(pks, m[ref])
+		}
+		return pks
+	}
+
+	for _, item := range doc.Apis {
+		action, ok := plan.action(custmodel.RbacKindApi, item.Key())
+		if !ok {
+			continue
+		}
+		if action == custmodel.RbacActionCreate {
+			m, rErr := s.svcApi.CreateApi(ctx, custmodel.ApiModel{
+				URL:    item.URL,
+				Method: item.Method,
+				Label:  item.Label,
+				Descr:  item.Descr,
+			})
+			if rErr != nil {
+				return rErr
+			}
+			state.apis[item.Key()] = m.ID
+			continue
+		}
+		data := map[string]any{"label": item.Label, "descr": item.Descr}
+		if _, rErr := s.svcApi.UpdateApiByID(ctx, state.apis[item.Key()], data); rErr != nil {
+			return rErr
+		}
+	}
+
+	for _, item := range rbacMenuOrder(doc.Menus) {
+		action, ok := plan.action(custmodel.RbacKindMenu, item.Name)
+		if !ok {
+			continue
+		}
+		var parentID *uint32
+		if item.Parent != "" {
+			pk := state.menus[item.Parent]
+			parentID = &pk
+		}
+		meta := custmodel.MetaSchemas{Title: item.Meta.Title, Icon: item.Meta.Icon}
+		apiIDs := ids(item.Apis, state.apis)
+		if action == custmodel.RbacActionCreate {
+			m, rErr := s.svcMenu.CreateMenu(ctx, apiIDs, custmodel.MenuModel{
+				Path:      item.Path,
+				Component: item.Component,
+				Name:      item.Name,
+				Meta:      meta,
+				Sort:      item.Sort,
+				IsActive:  item.IsActive,
+				Descr:     item.Descr,
+				ParentID:  parentID,
+			})
+			if rErr != nil {
+				return rErr
+			}
+			state.menus[item.Name] = m.ID
+			continue
+		}
+		data := map[string]any{
+			"path":      item.Path,
+			"component": item.Component,
+			"name":      item.Name,
+			"meta":      meta.Json(),
+			"sort":      item.Sort,
+			"is_active": item.IsActive,
+			"descr":     item.Descr,
+			"parent_id": nil,
+		}
+		if parentID != nil {
+			data["parent_id"] = *parentID
+		}
+		if _, rErr := s.svcMenu.UpdateMenuByID(ctx, state.menus[item.Name], apiIDs, data); rErr != nil {
+			return rErr
+		}
+	}
+
+	for _, item := range doc.Buttons {
+		action, ok := plan.action(custmodel.RbacKindButton, item.Name)
+		if !ok {
+			continue
+		}
+		apiIDs := ids(item.Apis, state.apis)
+		if action == custmodel.RbacActionCreate {
+			m, rErr := s.svcButton.CreateButton(ctx, apiIDs, custmodel.ButtonModel{
+				Name:     item.Name,
+				Sort:     item.Sort,
+				IsActive: item.IsActive,
+				Descr:    item.Descr,
+				MenuID:   state.menus[item.Menu],
+			})
+			if rErr != nil {
+				return rErr
+			}
+			state.buttons[item.Name] = m.ID
+			continue
+		}
+		data := map[string]any{
+			"sort":      item.Sort,
+			"is_active": item.IsActive,
+			"descr":     item.Descr,
+			"menu_id":   state.menus[item.Menu],
+		}
+		if _, rErr := s.svcButton.UpdateButtonByID(ctx, state.buttons[item.Name], apiIDs, data); rErr != nil {
+			return rErr
+		}
+	}
+
+	for _, item := range doc.Roles {
+		action, ok := plan.action(custmodel.RbacKindRole, item.Name)
+		if !ok {
+			continue
+		}
+		apiIDs := ids(item.Apis, state.apis)
+		menuIDs := ids(item.Menus, state.menus)
+		buttonIDs := ids(item.Buttons, state.buttons)
+		if action == custmodel.RbacActionCreate {
+			m, rErr := s.svcRole.CreateRole(ctx, apiIDs, menuIDs, buttonIDs, custmodel.RoleModel{
+				Name:  item.Name,
+				Descr: item.Descr,
+			})
+			if rErr != nil {
+				return rErr
+			}
+			state.roles[item.Name] = m.ID
+			continue
+		}
+		data := map[string]any{
+			"name":              item.Name,
+			"descr":             item.Descr,
+			database.VersionKey: state.roleVersions[item.Name],
+		}
+		if _, rErr := s.svcRole.UpdateRoleByID(ctx, state.roles[item.Name], apiIDs, menuIDs, buttonIDs, data); rErr != nil {
+			return rErr
+		}
+	}
+	return nil
+}
+
+// planRbacImport 比较文档与当前环境的权限配置, 生成导入计划
+func planRbacImport(doc, current *custmodel.RbacDocument, dryRun bool, conflict string) *rbacPlan {
+	plan := &rbacPlan{
+		conflict: conflict,
+		out: &custmodel.RbacImportOut{
+			DryRun:   dryRun,
+			Conflict: conflict,
+			Summary:  make(map[string]int),
+			Items:    []custmodel.RbacImportItemOut{},
+		},
+		actions: make(map[string]string),
+		merged: custmodel.RbacDocument{
+			Version:     current.Version,
+			CasbinModel: current.CasbinModel,
+		},
+	}
+
+	if doc.CasbinModel != "" {
+		var fields []string
+		if strings.TrimSpace(doc.CasbinModel) != strings.TrimSpace(current.CasbinModel) {
+			fields = []string{"content"}
+		}
+		action := plan.resolve(fields)
+		plan.add(custmodel.RbacKindCasbinModel, rbacCasbinModelKey, action, fields)
+		if action == custmodel.RbacActionUpdate {
+			plan.merged.CasbinModel = doc.CasbinModel
+		}
+	}
+
+	plan.merged.Apis = planRbacItems(plan, custmodel.RbacKindApi, current.Apis, doc.Apis,
+		custmodel.RbacApiItem.Key,
+		func(a, b custmodel.RbacApiItem) []string {
+			var fields []string
+			fields = appendRbacField(fields, "label", a.Label != b.Label)
+			fields = appendRbacField(fields, "descr", a.Descr != b.Descr)
+			return fields
+		},
+	)
+	plan.merged.Menus = planRbacItems(plan, custmodel.RbacKindMenu, current.Menus, doc.Menus,
+		func(i custmodel.RbacMenuItem) string { return i.Name },
+		func(a, b custmodel.RbacMenuItem) []string {
+			var fields []string
+			fields = appendRbacField(fields, "path", a.Path != b.Path)
+			fields = appendRbacField(fields, "component", a.Component != b.Component)
+			fields = appendRbacField(fields, "meta", a.Meta != b.Meta)
+			fields = appendRbacField(fields, "sort", a.Sort != b.Sort)
+			fields = appendRbacField(fields, "is_active", a.IsActive != b.IsActive)
+			fields = appendRbacField(fields, "descr", a.Descr != b.Descr)
+			fields = appendRbacField(fields, "parent", a.Parent != b.Parent)
+			fields = appendRbacField(fields, "apis", !slices.Equal(a.Apis, b.Apis))
+			return fields
+		},
+	)
+	plan.merged.Buttons = planRbacItems(plan, custmodel.RbacKindButton, current.Buttons, doc.Buttons,
+		func(i custmodel.RbacButtonItem) string { return i.Name },
+		func(a, b custmodel.RbacButtonItem) []string {
+			var fields []string
+			fields = appendRbacField(fields, "menu", a.Menu != b.Menu)
+			fields = appendRbacField(fields, "sort", a.Sort != b.Sort)
+			fields = appendRbacField(fields, "is_active", a.IsActive != b.IsActive)
+			fields = appendRbacField(fields, "descr", a.Descr != b.Descr)
+			fields = appendRbacField(fields, "apis", !slices.Equal(a.Apis, b.Apis))
+			return fields
+		},
+	)
+	plan.merged.Roles = planRbacItems(plan, custmodel.RbacKindRole, current.Roles, doc.Roles,
+		func(i custmodel.RbacRoleItem) string { return i.Name },
+		func(a, b custmodel.RbacRoleItem) []string {
+			var fields []string
+			fields = appendRbacField(fields, "descr", a.Descr != b.Descr)
+			fields = appendRbacField(fields, "apis", !slices.Equal(a.Apis, b.Apis))
+			fields = appendRbacField(fields, "menus", !slices.Equal(a.Menus, b.Menus))
+			fields = appendRbacField(fields, "buttons", !slices.Equal(a.Buttons, b.Buttons))
+			return fields
+		},
+	)
+
+	sortRbacDocument(&plan.merged)
+	before := strings.Join(rbacPolicies(current), "\n")
+	after := strings.Join(rbacPolicies(&plan.merged), "\n")
+	if before != after {
+		plan.out.PolicyDiff = textdiff.Unified("current", "import", before+"\n", after+"\n", 0)
+	}
+	return plan
+}
+
+// planRbacItems 比较同一类型的条目, 返回按处理动作合并后的条目
+func planRbacItems[T any](
+	plan *rbacPlan,
+	kind string,
+	current, incoming []T,
+	key func(T) string,
+	diff func(a, b T) []string,
+) []T {
+	merged := slices.Clone(current)
+	index := make(map[string]int, len(current))
+	for i, item := range current {
+		index[key(item)] = i
+	}
+	for _, item := range incoming {
+		k := key(item)
+		i, ok := index[k]
+		if !ok {
+			plan.add(kind, k, custmodel.RbacActionCreate, nil)
+			merged = append(merged, item)
+			index[k] = len(merged) - 1
+			continue
+		}
+		fields := diff(merged[i], item)
+		action := plan.resolve(fields)
+		plan.add(kind, k, action, fields)
+		if action == custmodel.RbacActionUpdate {
+			merged[i] = item
+		}
+	}
+	return merged
+}
+
+func appendRbacField(fields []string, name string, changed bool) []string {
+	if changed {
+		return append(fields, name)
+	}
+	return fields
+}
+
+// validateRbacDocument 校验文档格式和引用关系, 并对引用列表排序去重
+// 引用的对象可以在文档中定义, 也可以是目标环境已有的对象
+func validateRbacDocument(doc, current *custmodel.RbacDocument) *errors.Error {
+	if doc.Version != custmodel.RbacDocumentVersion {
+		return errors.ErrRbacDocumentInvalid.WithField("version", doc.Version)
+	}
+
+	apis := make(map[string]bool)
+	for _, item := range current.Apis {
+		apis[item.Key()] = true
+	}
+	seen := make(map[string]bool)
+	for _, item := range doc.Apis {
+		if item.URL == "" || !slices.Contains(rbacApiMethods, item.Method) {
+			return rbacInvalid(custmodel.RbacKindApi, item.Key(), "URL不能为空且请求方法必须为"+strings.Join(rbacApiMethods, "/"))
+		}
+		if seen[item.Key()] {
+			return rbacInvalid(custmodel.RbacKindApi, item.Key(), "重复定义")
+		}
+		seen[item.Key()] = true
+		apis[item.Key()] = true
+	}
+
+	parents := make(map[string]string)
+	for _, item := range current.Menus {
+		parents[item.Name] = item.Parent
+	}
+	seen = make(map[string]bool)
+	for i := range doc.Menus {
+		item := &doc.Menus[i]
+		if item.Name == "" || item.Path == "" {
+			return rbacInvalid(custmodel.RbacKindMenu, item.Name, "名称和前端路由不能为空")
+		}
+		if seen[item.Name] {
+			return rbacInvalid(custmodel.RbacKindMenu, item.Name, "重复定义")
+		}
+		seen[item.Name] = true
+		parents[item.Name] = item.Parent
+		item.Apis = normalizeRbacRefs(item.Apis)
+		if ref := missingRbacRef(item.Apis, apis); ref != "" {
+			return rbacInvalid(custmodel.RbacKindMenu, item.Name, "引用的API不存在: "+ref)
+		}
+	}
+	for _, item := range doc.Menus {
+		if item.Parent == "" {
+			continue
+		}
+		if _, ok := parents[item.Parent]; !ok {
+			return rbacInvalid(custmodel.RbacKindMenu, item.Name, "父菜单不存在: "+item.Parent)
+		}
+		// 沿父菜单向上查找, 超过菜单总数仍未到达顶层说明存在循环
+		parent := item.Parent
+		for depth := 0; parent != ""; depth++ {
+			if parent == item.Name || depth > len(parents) {
+				return rbacInvalid(custmodel.RbacKindMenu, item.Name, "父菜单存在循环引用")
+			}
+			parent = parents[parent]
+		}
+	}
+
+	buttons := make(map[string]bool)
+	for _, item := range current.Buttons {
+		buttons[item.Name] = true
+	}
+	seen = make(map[string]bool)
+	for i := range doc.Buttons {
+		item := &doc.Buttons[i]
+		if item.Name == "" {
+			return rbacInvalid(custmodel.RbacKindButton, item.Name, "名称不能为空")
+		}
+		if seen[item.Name] {
+			return rbacInvalid(custmodel.RbacKindButton, item.Name, "重复定义")
+		}
+		seen[item.Name] = true
+		buttons[item.Name] = true
+		if _, ok := parents[item.Menu]; !ok {
+			return rbacInvalid(custmodel.RbacKindButton, item.Name, "所属菜单不存在: "+item.Menu)
+		}
+		item.Apis = normalizeRbacRefs(item.Apis)
+		if ref := missingRbacRef(item.Apis, apis); ref != "" {
+			return rbacInvalid(custmodel.RbacKindButton, item.Name, "引用的API不存在: "+ref)
+		}
+	}
+
+	menus := make(map[string]bool, len(parents))
+	for name := range parents {
+		menus[name] = true
+	}
+	seen = make(map[string]bool)
+	for i := range doc.Roles {
+		item := &doc.Roles[i]
+		if item.Name == "" {
+			return rbacInvalid(custmodel.RbacKindRole, item.Name, "名称不能为空")
+		}
+		if seen[item.Name] {
+			return rbacInvalid(custmodel.RbacKindRole, item.Name, "重复定义")
+		}
+		seen[item.Name] = true
+		item.Apis = normalizeRbacRefs(item.Apis)
+		item.Menus = normalizeRbacRefs(item.Menus)
+		item.Buttons = normalizeRbacRefs(item.Buttons)
+		if ref := missingRbacRef(item.Apis, apis); ref != "" {
+			return rbacInvalid(custmodel.RbacKindRole, item.Name, "引用的API不存在: "+ref)
+		}
+		if ref := missingRbacRef(item.Menus, menus); ref != "" {
+			return rbacInvalid(custmodel.RbacKindRole, item.Name, "引用的菜单不存在: "+ref)
+		}
+		if ref := missingRbacRef(item.Buttons, buttons); ref != "" {
+			return rbacInvalid(custmodel.RbacKindRole, item.Name, "引用的按钮不存在: "+ref)
+		}
+	}
+	return nil
+}
+
+// validateRbacMerged 校验导入后菜单的前端路由不重复, 避免写入到一半时违反唯一约束
+func validateRbacMerged(doc *custmodel.RbacDocument) *errors.Error {
+	paths := make(map[string]string, len(doc.Menus))
+	for _, item := range doc.Menus {
+		if other, ok := paths[item.Path]; ok {
+			return rbacInvalid(custmodel.RbacKindMenu, item.Name, "前端路由与菜单"+other+"重复: "+item.Path)
+		}
+		paths[item.Path] = item.Name
+	}
+	return nil
+}
+
+func rbacInvalid(kind, key, reason string) *errors.Error {
+	return errors.ErrRbacDocumentInvalid.WithFields(map[string]any{
+		"kind":   kind,
+		"key":    key,
+		"reason": reason,
+	})
+}
+
+func missingRbacRef(refs []string, defined map[string]bool) string {
+	for _, ref := range refs {
+		if !defined[ref] {
+			return ref
+		}
+	}
+	return ""
+}
+
+// normalizeRbacRefs 对引用列表排序去重, 使比较结果与书写顺序无关
+func normalizeRbacRefs(refs []string) []string {
+	if len(refs) == 0 {
+		return nil
+	}
+	refs = slices.Clone(refs)
+	slices.Sort(refs)
+	return slices.Compact(refs)
+}
+
+// rbacMenuOrder 按父菜单在前的顺序返回菜单, 父菜单不在文档中时视为已存在
+func rbacMenuOrder(items []custmodel.RbacMenuItem) []custmodel.RbacMenuItem {
+	index := make(map[string]int, len(items))
+	for i, item := range items {
+		index[item.Name] = i
+	}
+	ordered := make([]custmodel.RbacMenuItem, 0, len(items))
+	visited := make(map[string]bool, len(items))
+	var visit func(i int)
+	visit = func(i int) {
+		item := items[i]
+		if visited[item.Name] {
+			return
+		}
+		visited[item.Name] = true
+		if p, ok := index[item.Parent]; ok {
+			visit(p)
+		}
+		ordered = append(ordered, item)
+	}
+	for i := range items {
+		visit(i)
+	}
+	return ordered
+}
+
+func sortRbacDocument(doc *custmodel.RbacDocument) {
+	slices.SortFunc(doc.Apis, func(a, b custmodel.RbacApiItem) int {
+		return strings.Compare(a.URL+" "+a.Method, b.URL+" "+b.Method)
+	})
+	slices.SortFunc(doc.Menus, func(a, b custmodel.RbacMenuItem) int { return strings.Compare(a.Name, b.Name) })
+	doc.Menus = rbacMenuOrder(doc.Menus)
+	slices.SortFunc(doc.Buttons, func(a, b custmodel.RbacButtonItem) int { return strings.Compare(a.Name, b.Name) })
+	slices.SortFunc(doc.Roles, func(a, b custmodel.RbacRoleItem) int { return strings.Compare(a.Name, b.Name) })
+}
+
+// rbacPolicies 按关联关系生成与各对象服务一致的Casbin策略, 主体使用业务标识代替数据库ID
+func rbacPolicies(doc *custmodel.RbacDocument) []string {
+	var policies []string
+	for _, item := range doc.Apis {
+		policies = append(policies, fmt.Sprintf("p, api:%s, %s, %s", item.Key(), item.URL, item.Method))
+	}
+	group := func(sub string, objs ...string) {
+		for _, obj := range objs {
+			policies = append(policies, fmt.Sprintf("g, %s, %s", sub, obj))
+		}
+	}
+	prefixed := func(prefix string, refs []string) []string {
+		objs := make([]string, 0, len(refs))
+		for _, ref := range refs {
+			objs = append(objs, prefix+ref)
+		}
+		return objs
+	}
+	for _, item := range doc.Menus {
+		sub := "menu:" + item.Name
+		if item.Parent != "" {
+			group(sub, "menu:"+item.Parent)
+		}
+		group(sub, prefixed("api:", item.Apis)...)
+	}
+	for _, item := range doc.Buttons {
+		sub := "button:" + item.Name
+		group(sub, "menu:"+item.Menu)
+		group(sub, prefixed("api:", item.Apis)...)
+	}
+	for _, item := range doc.Roles {
+		sub := "role:" + item.Name
+		group(sub, prefixed("api:", item.Apis)...)
+		group(sub, prefixed("menu:", item.Menus)...)
+		group(sub, prefixed("button:", item.Buttons)...)
+	}
+	slices.Sort(policies)
+	return policies
+}
+
+func derefSlice[T any](ms *[]T) []T {
+	if ms == nil {
+		return nil
+	}
+	return *ms
+}
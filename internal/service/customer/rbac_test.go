@@ -0,0 +1,180 @@
+package customer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/casbin/casbin/v2"
+	"github.com/goccy/go-yaml"
+	"github.com/stretchr/testify/suite"
+
+	custmodel "gin-artweb/internal/model/customer"
+	custrepo "gin-artweb/internal/repository/customer"
+	"gin-artweb/internal/shared/auth"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+	"gin-artweb/internal/shared/test"
+)
+
+type RbacServiceTestSuite struct {
+	suite.Suite
+	enforcer    *casbin.Enforcer
+	rbacService *RbacService
+}
+
+func (suite *RbacServiceTestSuite) SetupTest() {
+	db := test.NewTestGormDBWithConfig(nil)
+	db.AutoMigrate(
+		&custmodel.MenuModel{},
+		&custmodel.ApiModel{},
+		&custmodel.ButtonModel{},
+		&custmodel.RoleModel{},
+		&custmodel.CasbinModelModel{},
+	)
+	dbTimeout := test.NewTestDBTimeouts()
+	logger := test.NewTestZapLogger()
+	enforcer, _ := auth.NewCasbinEnforcer()
+	suite.enforcer = enforcer
+
+	apiRepo := custrepo.NewApiRepo(logger, db, dbTimeout, enforcer)
+	menuRepo := custrepo.NewMenuRepo(logger, db, dbTimeout, enforcer)
+	buttonRepo := custrepo.NewButtonRepo(logger, db, dbTimeout, enforcer)
+	roleRepo := custrepo.NewRoleRepo(logger, db, dbTimeout, enforcer)
+	suite.rbacService = NewRbacService(
+		logger,
+		NewApiService(logger, apiRepo),
+		NewMenuService(logger, apiRepo, menuRepo),
+		NewButtonService(logger, apiRepo, menuRepo, buttonRepo),
+		NewRoleService(logger, apiRepo, menuRepo, buttonRepo, roleRepo, database.NewTxManager(db)),
+		NewCasbinModelService(logger, custrepo.NewCasbinModelRepo(logger, db, dbTimeout), enforcer),
+	)
+}
+
+func TestRbacServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(RbacServiceTestSuite))
+}
+
+// testRbacDocument 包含父子菜单、按钮和角色的权限配置文档
+func testRbacDocument() custmodel.RbacDocument {
+	return custmodel.RbacDocument{
+		Version: custmodel.RbacDocumentVersion,
+		Apis: []custmodel.RbacApiItem{
+			{URL: "/api/v1/mon/node", Method: "GET", Label: "mon", Descr: "查询mon节点"},
+			{URL: "/api/v1/mon/node", Method: "POST", Label: "mon", Descr: "创建mon节点"},
+		},
+		Menus: []custmodel.RbacMenuItem{
+			{
+				Name: "节点", Path: "/mon/node", Component: "mon/node",
+				Meta: custmodel.RbacMenuMeta{Title: "节点"}, IsActive: true, Parent: "监控",
+				Apis: []string{"GET /api/v1/mon/node"},
+			},
+			{Name: "监控", Path: "/mon", Component: "layout", Meta: custmodel.RbacMenuMeta{Title: "监控"}, IsActive: true},
+		},
+		Buttons: []custmodel.RbacButtonItem{
+			{Name: "新增节点", Menu: "节点", IsActive: true, Apis: []string{"POST /api/v1/mon/node"}},
+		},
+		Roles: []custmodel.RbacRoleItem{
+			{Name: "运维", Descr: "运维人员", Menus: []string{"节点"}, Buttons: []string{"新增节点"}},
+		},
+	}
+}
+
+func (suite *RbacServiceTestSuite) TestImportAndExport() {
+	ctx := context.Background()
+
+	out, rErr := suite.rbacService.ImportRbac(ctx, testRbacDocument(), true, "", "admin")
+	suite.Nil(rErr)
+	suite.False(out.Applied, "只比较差异时不应该写入")
+	suite.Equal(6, out.Summary[custmodel.RbacActionCreate])
+	suite.Contains(out.PolicyDiff, "+g, role:运维, menu:节点")
+
+	out, rErr = suite.rbacService.ImportRbac(ctx, testRbacDocument(), false, "", "admin")
+	suite.Nil(rErr)
+	suite.True(out.Applied)
+
+	doc, rErr := suite.rbacService.ExportRbac(ctx)
+	suite.Nil(rErr)
+	suite.Len(doc.Menus, 2)
+	suite.Equal("监控", doc.Menus[0].Name, "父菜单应该排在子菜单之前")
+	suite.Equal("监控", doc.Menus[1].Parent)
+	suite.Contains(doc.Policies, "g, button:新增节点, api:POST /api/v1/mon/node")
+
+	// 导入后的策略应该能按角色授权访问菜单和按钮关联的API
+	_, roles, rErr := suite.rbacService.svcRole.ListRole(ctx, database.QueryParams{})
+	suite.Nil(rErr)
+	suite.Len(*roles, 1)
+	sub := auth.RoleToSubject((*roles)[0].ID)
+	ok, err := suite.enforcer.Enforce(sub, "/api/v1/mon/node", "GET")
+	suite.Nil(err)
+	suite.True(ok)
+	ok, err = suite.enforcer.Enforce(sub, "/api/v1/mon/node", "POST")
+	suite.Nil(err)
+	suite.True(ok)
+
+	// 导出的文档经过YAML序列化后再导入应该没有任何变化
+	data, err := yaml.Marshal(doc)
+	suite.Nil(err)
+	parsed, rErr := ParseRbacDocument(data)
+	suite.Nil(rErr)
+	out, rErr = suite.rbacService.ImportRbac(ctx, *parsed, true, "", "admin")
+	suite.Nil(rErr)
+	suite.Empty(out.Items)
+	suite.Empty(out.PolicyDiff)
+}
+
+func (suite *RbacServiceTestSuite) TestImportConflict() {
+	ctx := context.Background()
+	_, rErr := suite.rbacService.ImportRbac(ctx, testRbacDocument(), false, "", "admin")
+	suite.Nil(rErr)
+
+	doc := testRbacDocument()
+	doc.Roles[0].Descr = "值班人员"
+	doc.Roles[0].Buttons = nil
+	doc.Roles = append(doc.Roles, custmodel.RbacRoleItem{Name: "访客", Apis: []string{"GET /api/v1/mon/node"}})
+
+	_, rErr = suite.rbacService.ImportRbac(ctx, doc, false, custmodel.RbacConflictFail, "admin")
+	suite.NotNil(rErr)
+	suite.Equal(errors.ErrRbacImportConflict.Reason, rErr.Reason)
+	_, roles, _ := suite.rbacService.svcRole.ListRole(ctx, database.QueryParams{})
+	suite.Len(*roles, 1, "存在冲突时不应该写入任何数据")
+
+	out, rErr := suite.rbacService.ImportRbac(ctx, doc, false, custmodel.RbacConflictSkip, "admin")
+	suite.Nil(rErr)
+	suite.Equal(1, out.Summary[custmodel.RbacActionSkip])
+	suite.Equal(1, out.Summary[custmodel.RbacActionCreate])
+	exported, _ := suite.rbacService.ExportRbac(ctx)
+	suite.Equal("运维人员", exported.Roles[1].Descr, "skip时应该保留已有数据")
+
+	out, rErr = suite.rbacService.ImportRbac(ctx, doc, false, custmodel.RbacConflictOverwrite, "admin")
+	suite.Nil(rErr)
+	suite.Equal([]custmodel.RbacImportItemOut{
+		{Kind: custmodel.RbacKindRole, Key: "运维", Action: custmodel.RbacActionUpdate, Fields: []string{"descr", "buttons"}},
+	}, out.Items)
+	exported, _ = suite.rbacService.ExportRbac(ctx)
+	suite.Equal("值班人员", exported.Roles[1].Descr)
+	suite.Empty(exported.Roles[1].Buttons)
+}
+
+func (suite *RbacServiceTestSuite) TestImportInvalid() {
+	ctx := context.Background()
+
+	doc := testRbacDocument()
+	doc.Menus[1].Parent = "节点"
+	_, rErr := suite.rbacService.ImportRbac(ctx, doc, true, "", "admin")
+	suite.NotNil(rErr)
+	suite.Equal(errors.ErrRbacDocumentInvalid.Reason, rErr.Reason, "父菜单循环引用应该返回错误")
+
+	doc = testRbacDocument()
+	doc.Roles[0].Apis = []string{"DELETE /api/v1/mon/node"}
+	_, rErr = suite.rbacService.ImportRbac(ctx, doc, true, "", "admin")
+	suite.NotNil(rErr)
+	suite.Equal(errors.ErrRbacDocumentInvalid.Reason, rErr.Reason, "引用不存在的API应该返回错误")
+
+	doc = testRbacDocument()
+	doc.Version = 2
+	_, rErr = suite.rbacService.ImportRbac(ctx, doc, true, "", "admin")
+	suite.NotNil(rErr)
+
+	_, rErr = ParseRbacDocument([]byte("version: 1\nunknown: true\n"))
+	suite.NotNil(rErr, "未定义的字段应该返回错误")
+}
@@ -18,6 +18,7 @@ type RoleService struct {
 	menuRepo   *custsvc.MenuRepo
 	buttonRepo *custsvc.ButtonRepo
 	roleRepo   *custsvc.RoleRepo
+	tx         *database.TxManager
 }
 
 func NewRoleService(
@@ -26,6 +27,7 @@ func NewRoleService(
 	menuRepo *custsvc.MenuRepo,
 	buttonRepo *custsvc.ButtonRepo,
 	roleRepo *custsvc.RoleRepo,
+	tx *database.TxManager,
 ) *RoleService {
 	return &RoleService{
 		log:        log,
@@ -33,6 +35,7 @@ func NewRoleService(
 		menuRepo:   menuRepo,
 		buttonRepo: buttonRepo,
 		roleRepo:   roleRepo,
+		tx:         tx,
 	}
 }
 
@@ -48,10 +51,9 @@ func (s *RoleService) GetApis(
 		return &[]custmodel.ApiModel{}, nil
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始查询角色关联的API列表",
 		zap.Uint32s("api_ids", apiIDs),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	qp := database.QueryParams{
@@ -59,19 +61,17 @@ func (s *RoleService) GetApis(
 	}
 	_, ms, err := s.apiRepo.ListModel(ctx, qp)
 	if err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"查询角色关联的API列表失败",
 			zap.Error(err),
 			zap.Uint32s("api_ids", apiIDs),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.NewGormError(err, nil)
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"查询角色关联的API列表成功",
 		zap.Uint32s("api_ids", apiIDs),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return ms, nil
 }
@@ -88,10 +88,9 @@ func (s *RoleService) GetMenus(
 		return &[]custmodel.MenuModel{}, nil
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始角色关联的菜单列表",
 		zap.Uint32s("menu_ids", menuIDs),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	qp := database.QueryParams{
@@ -99,19 +98,17 @@ func (s *RoleService) GetMenus(
 	}
 	_, ms, err := s.menuRepo.ListModel(ctx, qp)
 	if err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"查询角色关联的菜单列表失败",
 			zap.Error(err),
 			zap.Uint32s("menu_ids", menuIDs),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.NewGormError(err, nil)
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"查询角色关联的菜单列表成功",
 		zap.Uint32s("menu_ids", menuIDs),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return ms, nil
 }
@@ -128,10 +125,9 @@ func (s *RoleService) GetButtons(
 		return &[]custmodel.ButtonModel{}, nil
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始查询角色关联的按钮列表",
 		zap.Uint32s("button_ids", buttonIDs),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	qp := database.QueryParams{
@@ -139,19 +135,17 @@ func (s *RoleService) GetButtons(
 	}
 	_, ms, err := s.buttonRepo.ListModel(ctx, qp)
 	if err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"查询角色关联的按钮列表失败",
 			zap.Error(err),
 			zap.Uint32s("button_ids", buttonIDs),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.NewGormError(err, nil)
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"查询角色关联的按钮列表成功",
 		zap.Uint32s("button_ids", buttonIDs),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return ms, nil
 }
@@ -167,45 +161,47 @@ func (s *RoleService) CreateRole(
 		return nil, errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始创建角色",
 		zap.Uint32s("api_ids", apiIDs),
 		zap.Object(database.ModelKey, &m),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	var (
 		apis    *[]custmodel.ApiModel
 		menus   *[]custmodel.MenuModel
 		buttons *[]custmodel.ButtonModel
-		rErr    *errors.Error
 	)
 
-	apis, rErr = s.GetApis(ctx, apiIDs)
-	if rErr != nil {
-		return nil, rErr
-	}
-
-	menus, rErr = s.GetMenus(ctx, menuIDs)
-	if rErr != nil {
-		return nil, rErr
-	}
+	// 查询关联数据和创建角色在同一事务中执行
+	rErr := database.Transactional(ctx, s.tx, func(ctx context.Context) *errors.Error {
+		var rErr *errors.Error
+		if apis, rErr = s.GetApis(ctx, apiIDs); rErr != nil {
+			return rErr
+		}
+		if menus, rErr = s.GetMenus(ctx, menuIDs); rErr != nil {
+			return rErr
+		}
+		if buttons, rErr = s.GetButtons(ctx, buttonIDs); rErr != nil {
+			return rErr
+		}
 
-	buttons, rErr = s.GetButtons(ctx, buttonIDs)
+		if err := s.roleRepo.CreateModel(ctx, &m, apis, menus, buttons); err != nil {
+			ctxutil.Logger(ctx, s.log).Error(
+				"创建角色失败",
+				zap.Error(err),
+				zap.Object(database.ModelKey, &m),
+			)
+			return errors.NewGormError(err, nil)
+		}
+		return nil
+	}, func(err error) *errors.Error {
+		return errors.NewGormError(err, nil)
+	})
 	if rErr != nil {
 		return nil, rErr
 	}
 
-	if err := s.roleRepo.CreateModel(ctx, &m, apis, menus, buttons); err != nil {
-		s.log.Error(
-			"创建角色失败",
-			zap.Error(err),
-			zap.Object(database.ModelKey, &m),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
-		)
-		return nil, errors.NewGormError(err, nil)
-	}
-
 	if apis != nil {
 		if len(*apis) > 0 {
 			m.Apis = *apis
@@ -223,19 +219,17 @@ func (s *RoleService) CreateRole(
 	}
 
 	if err := s.roleRepo.AddGroupPolicy(ctx, &m); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"添加角色组策略失败",
 			zap.Error(err),
 			zap.Object(database.ModelKey, &m),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.FromError(err)
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"创建角色成功",
 		zap.Object(database.ModelKey, &m),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return &m, nil
 }
@@ -252,84 +246,92 @@ func (s *RoleService) UpdateRoleByID(
 		return nil, errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始更新角色",
 		zap.Uint32("role_id", roleID),
 		zap.Uint32s("api_ids", apiIDs),
 		zap.Uint32s("menu_ids", menuIDs),
 		zap.Uint32s("button_ids", buttonIDs),
 		zap.Any(database.UpdateDataKey, data),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
-	var (
-		apis    *[]custmodel.ApiModel
-		menus   *[]custmodel.MenuModel
-		buttons *[]custmodel.ButtonModel
-		rErr    *errors.Error
-	)
-
-	apis, rErr = s.GetApis(ctx, apiIDs)
-	if rErr != nil {
-		return nil, rErr
-	}
-
-	menus, rErr = s.GetMenus(ctx, menuIDs)
-	if rErr != nil {
-		return nil, rErr
-	}
-
-	buttons, rErr = s.GetButtons(ctx, buttonIDs)
-	if rErr != nil {
-		return nil, rErr
-	}
+	var m *custmodel.RoleModel
+	// 查询关联数据、更新角色和读取更新后的角色在同一事务中执行
+	rErr := database.Transactional(ctx, s.tx, func(ctx context.Context) *errors.Error {
+		apis, rErr := s.GetApis(ctx, apiIDs)
+		if rErr != nil {
+			return rErr
+		}
+		menus, rErr := s.GetMenus(ctx, menuIDs)
+		if rErr != nil {
+			return rErr
+		}
+		buttons, rErr := s.GetButtons(ctx, buttonIDs)
+		if rErr != nil {
+			return rErr
+		}
 
-	data["id"] = roleID
-	if err := s.roleRepo.UpdateModel(ctx, data, apis, menus, buttons, "id = ?", roleID); err != nil {
-		s.log.Error(
-			"更新角色失败",
-			zap.Error(err),
-			zap.Uint32("role_id", roleID),
-			zap.Any(database.UpdateDataKey, data),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
-		)
-		return nil, errors.NewGormError(err, data)
-	}
+		data["id"] = roleID
+		if err := s.roleRepo.UpdateModel(ctx, data, apis, menus, buttons, "id = ?", roleID); err != nil {
+			if database.IsVersionConflict(err) {
+				return s.roleVersionConflict(ctx, roleID)
+			}
+			ctxutil.Logger(ctx, s.log).Error(
+				"更新角色失败",
+				zap.Error(err),
+				zap.Uint32("role_id", roleID),
+				zap.Any(database.UpdateDataKey, data),
+			)
+			return errors.NewGormError(err, data)
+		}
 
-	var m *custmodel.RoleModel
-	m, rErr = s.FindRoleByID(ctx, []string{"Apis", "Menus", "Buttons"}, roleID)
+		m, rErr = s.FindRoleByID(ctx, []string{"Apis", "Menus", "Buttons"}, roleID)
+		return rErr
+	}, func(err error) *errors.Error {
+		return errors.NewGormError(err, data)
+	})
 	if rErr != nil {
 		return nil, rErr
 	}
 
 	if err := s.roleRepo.RemoveGroupPolicy(ctx, m); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"移除旧角色组策略失败",
 			zap.Error(err),
 			zap.Uint32("role_id", roleID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.FromError(err)
 	}
 
 	if err := s.roleRepo.AddGroupPolicy(ctx, m); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"添加新角色组策略失败",
 			zap.Error(err),
 			zap.Uint32("role_id", roleID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.FromError(err)
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"更新角色成功",
 		zap.Uint32("role_id", roleID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return m, nil
 }
 
+// roleVersionConflict 版本冲突时返回携带当前角色数据的错误
+func (s *RoleService) roleVersionConflict(ctx context.Context, roleID uint32) *errors.Error {
+	ctxutil.Logger(ctx, s.log).Warn(
+		"更新角色失败: 角色已被其他用户修改",
+		zap.Uint32("role_id", roleID),
+	)
+	m, rErr := s.FindRoleByID(ctx, []string{"Apis", "Menus", "Buttons"}, roleID)
+	if rErr != nil {
+		return rErr
+	}
+	return errors.ErrVersionConflict.WithField("current", custmodel.RoleModelToDetailOut(*m))
+}
+
 func (s *RoleService) DeleteRoleByID(
 	ctx context.Context,
 	roleID uint32,
@@ -338,10 +340,9 @@ func (s *RoleService) DeleteRoleByID(
 		return errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始删除角色",
 		zap.Uint32("role_id", roleID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	m, rErr := s.FindRoleByID(ctx, []string{"Apis", "Menus", "Buttons"}, roleID)
@@ -350,29 +351,26 @@ func (s *RoleService) DeleteRoleByID(
 	}
 
 	if err := s.roleRepo.DeleteModel(ctx, roleID); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"删除角色失败",
 			zap.Error(err),
 			zap.Uint32("role_id", roleID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return errors.NewGormError(err, map[string]any{"id": roleID})
 	}
 
 	if err := s.roleRepo.RemoveGroupPolicy(ctx, m); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"移除角色组策略失败",
 			zap.Error(err),
 			zap.Uint32("role_id", roleID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return errors.FromError(err)
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"删除角色成功",
 		zap.Uint32("role_id", roleID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return nil
 }
@@ -386,28 +384,25 @@ func (s *RoleService) FindRoleByID(
 		return nil, errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始查询角色",
 		zap.Strings(database.PreloadKey, preloads),
 		zap.Uint32("role_id", roleID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	m, err := s.roleRepo.GetModel(ctx, preloads, roleID)
 	if err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"查询角色失败",
 			zap.Error(err),
 			zap.Uint32("role_id", roleID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.NewGormError(err, map[string]any{"id": roleID})
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"查询角色成功",
 		zap.Uint32("role_id", roleID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return m, nil
 }
@@ -420,26 +415,23 @@ func (s *RoleService) ListRole(
 		return 0, nil, errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始查询角色列表",
 		zap.Object(database.QueryParamsKey, &qp),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	count, ms, err := s.roleRepo.ListModel(ctx, qp)
 	if err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"查询角色列表失败",
 			zap.Error(err),
 			zap.Object(database.QueryParamsKey, &qp),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return 0, nil, errors.NewGormError(err, nil)
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"查询角色列表成功",
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return count, ms, nil
 }
@@ -449,9 +441,8 @@ func (s *RoleService) LoadRolePolicy(ctx context.Context) *errors.Error {
 		return errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始加载角色策略",
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	qp := database.QueryParams{
@@ -461,10 +452,9 @@ func (s *RoleService) LoadRolePolicy(ctx context.Context) *errors.Error {
 
 	_, rms, rErr := s.ListRole(ctx, qp)
 	if rErr != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"加载角色策略时查询角色列表失败",
 			zap.Error(rErr),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return rErr
 	}
@@ -475,44 +465,52 @@ func (s *RoleService) LoadRolePolicy(ctx context.Context) *errors.Error {
 		policyCount = len(ms)
 		for i := range ms {
 			if err := s.roleRepo.AddGroupPolicy(ctx, &ms[i]); err != nil {
-				s.log.Error(
+				ctxutil.Logger(ctx, s.log).Error(
 					"加载角色策略失败",
 					zap.Error(err),
 					zap.Uint32("role_id", ms[i].ID),
-					zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 				)
 				return errors.FromError(err)
 			}
 		}
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"加载角色策略成功",
 		zap.Int("policy_count", policyCount),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return nil
 }
 
+// GetRoleMenuTree 获取角色的菜单树, 传入多个角色时合并各角色的菜单和按钮
 func (s *RoleService) GetRoleMenuTree(
 	ctx context.Context,
-	roleID uint32,
+	roleIDs ...uint32,
 ) ([]custmodel.MenuTreeNode, *errors.Error) {
-	m, rErr := s.FindRoleByID(ctx, []string{"Apis", "Menus", "Buttons"}, roleID)
-	if rErr != nil {
-		return nil, rErr
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
 	}
+
 	var topMenus []custmodel.MenuModel
 	roleMenuMap := make(map[uint32]custmodel.MenuModel)
-	for _, menu := range m.Menus {
-		roleMenuMap[menu.ID] = menu
-		if menu.ParentID == nil {
-			topMenus = append(topMenus, menu)
-		}
-	}
 	roleButtonMap := make(map[uint32]custmodel.ButtonModel)
-	for _, button := range m.Buttons {
-		roleButtonMap[button.ID] = button
+	for _, roleID := range roleIDs {
+		m, rErr := s.FindRoleByID(ctx, []string{"Apis", "Menus", "Buttons"}, roleID)
+		if rErr != nil {
+			return nil, rErr
+		}
+		for _, menu := range m.Menus {
+			if _, ok := roleMenuMap[menu.ID]; ok {
+				continue
+			}
+			roleMenuMap[menu.ID] = menu
+			if menu.ParentID == nil {
+				topMenus = append(topMenus, menu)
+			}
+		}
+		for _, button := range m.Buttons {
+			roleButtonMap[button.ID] = button
+		}
 	}
 	var result []custmodel.MenuTreeNode
 	for _, menu := range topMenus {
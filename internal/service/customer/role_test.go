@@ -67,6 +67,7 @@ func (suite *RoleTestSuite) SetupSuite() {
 			dbTimeout,
 			enforcer,
 		),
+		tx: database.NewTxManager(db),
 	}
 }
 
@@ -0,0 +1,486 @@
+package customer
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/patrickmn/go-cache"
+	"go.uber.org/zap"
+
+	custmodel "gin-artweb/internal/model/customer"
+	custsvc "gin-artweb/internal/repository/customer"
+	syssvc "gin-artweb/internal/service/system"
+	"gin-artweb/internal/shared/auth"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+)
+
+// sessionCacheTTL 有效会话的本地缓存时间, 其他实例终止的会话最多延迟该时长失效
+const sessionCacheTTL = 10 * time.Second
+
+// alertKindRefreshReplay 刷新令牌重放告警类型
+const alertKindRefreshReplay = "refresh_token_replay"
+
+type SessionService struct {
+	log         *zap.Logger
+	sessionRepo *custsvc.UserSessionRepo
+	jwt         *auth.JWTConfig
+	alerts      *syssvc.AlertPublisher
+	cache       *cache.Cache
+}
+
+func NewSessionService(
+	log *zap.Logger,
+	sessionRepo *custsvc.UserSessionRepo,
+	jwt *auth.JWTConfig,
+	alerts *syssvc.AlertPublisher,
+) *SessionService {
+	return &SessionService{
+		log:         log,
+		sessionRepo: sessionRepo,
+		jwt:         jwt,
+		alerts:      alerts,
+		cache:       cache.New(sessionCacheTTL, 2*sessionCacheTTL),
+	}
+}
+
+// Issue 创建会话并签发令牌
+func (s *SessionService) Issue(
+	ctx context.Context,
+	ui auth.UserInfo,
+	method string,
+	ipAddress string,
+	userAgent string,
+) (*auth.TokenPair, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	ui.SessionID = uuid.NewString()
+	pair, err := auth.NewTokenPair(ctx, s.jwt, ui)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"生成JWT token失败",
+			zap.Error(err),
+		)
+		return nil, errors.FromError(err)
+	}
+	return s.createSession(ctx, ui, method, ipAddress, userAgent, pair)
+}
+
+// IssueImpersonation 创建管理员代为登录的会话并签发有效期为ttl的访问令牌, 不签发刷新令牌
+//
+// 会话与普通会话一样可以被查询和终止, 终止后代登录令牌立即失效
+func (s *SessionService) IssueImpersonation(
+	ctx context.Context,
+	ui auth.UserInfo,
+	ttl time.Duration,
+	ipAddress string,
+	userAgent string,
+) (*auth.TokenPair, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	ui.SessionID = uuid.NewString()
+	pair, err := auth.NewImpersonationToken(ctx, s.jwt, ui, ttl)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"生成代登录JWT token失败",
+			zap.Error(err),
+		)
+		return nil, errors.FromError(err)
+	}
+	return s.createSession(ctx, ui, custmodel.SessionMethodImpersonate+ui.ImpersonatorName, ipAddress, userAgent, pair)
+}
+
+func (s *SessionService) createSession(
+	ctx context.Context,
+	ui auth.UserInfo,
+	method string,
+	ipAddress string,
+	userAgent string,
+	pair *auth.TokenPair,
+) (*auth.TokenPair, *errors.Error) {
+	m := custmodel.UserSessionModel{
+		SessionID:       ui.SessionID,
+		UserID:          ui.UserID,
+		Method:          method,
+		Device:          deviceFromUserAgent(userAgent),
+		IPAddress:       ipAddress,
+		UserAgent:       truncate(userAgent, 254),
+		AccessID:        pair.AccessID,
+		RefreshID:       pair.RefreshID,
+		IssuedAt:        pair.IssuedAt,
+		RefreshedAt:     pair.IssuedAt,
+		AccessExpiresAt: pair.AccessExpiresAt,
+		ExpiresAt:       pair.RefreshExpiresAt,
+	}
+	if err := s.sessionRepo.CreateModel(ctx, &m); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"创建登录会话失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, &m),
+		)
+		return nil, errors.NewGormError(err, nil)
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"创建登录会话成功",
+		zap.String("session_id", m.SessionID),
+		zap.Uint32("user_id", m.UserID),
+		zap.String("method", method),
+	)
+	return pair, nil
+}
+
+// Renew 使用刷新令牌为会话重新签发令牌, 会话已终止时拒绝刷新
+//
+// 同一会话的刷新令牌构成一个令牌族, 每次刷新后之前的刷新令牌立即失效;
+// 再次使用已失效的刷新令牌视为令牌被盗用后的重放, 终止整个会话并发出告警.
+// 升级前签发的令牌不属于任何会话, 刷新时创建新会话
+func (s *SessionService) Renew(
+	ctx context.Context,
+	claims *auth.UserClaims,
+	ipAddress string,
+	userAgent string,
+) (*auth.TokenPair, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	if claims.SessionID == "" {
+		return s.Issue(ctx, claims.UserInfo, custmodel.SessionMethodPassword, ipAddress, userAgent)
+	}
+
+	m, err := s.sessionRepo.GetModel(ctx, nil, "session_id = ?", claims.SessionID)
+	if err != nil {
+		if rErr := errors.NewGormError(err, nil); !rErr.Is(errors.ErrRecordNotFound) {
+			return nil, rErr
+		}
+		ctxutil.Logger(ctx, s.log).Warn(
+			"刷新令牌所属的会话已终止",
+			zap.String("session_id", claims.SessionID),
+			zap.Uint32("user_id", claims.UserID),
+		)
+		return nil, errors.ErrSessionRevoked
+	}
+	if m.RefreshID != claims.ID {
+		return nil, s.revokeReplayedSession(ctx, m, claims, ipAddress, userAgent)
+	}
+
+	pair, gErr := auth.NewTokenPair(ctx, s.jwt, claims.UserInfo)
+	if gErr != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"生成JWT token失败",
+			zap.Error(gErr),
+		)
+		return nil, errors.FromError(gErr)
+	}
+
+	data := map[string]any{
+		"access_id":         pair.AccessID,
+		"refresh_id":        pair.RefreshID,
+		"refreshed_at":      pair.IssuedAt,
+		"access_expires_at": pair.AccessExpiresAt,
+		"expires_at":        pair.RefreshExpiresAt,
+	}
+	if ipAddress != "" {
+		data["ip_address"] = ipAddress
+	}
+	ok, err := s.sessionRepo.RotateRefreshID(ctx, m.ID, claims.ID, data)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"更新登录会话失败",
+			zap.Error(err),
+			zap.String("session_id", m.SessionID),
+		)
+		return nil, errors.NewGormError(err, data)
+	}
+	// 并发请求已经使用同一个刷新令牌完成了刷新
+	if !ok {
+		return nil, s.revokeReplayedSession(ctx, m, claims, ipAddress, userAgent)
+	}
+	s.cache.Delete(m.SessionID)
+	return pair, nil
+}
+
+// revokeReplayedSession 已轮换的刷新令牌被再次使用, 终止会话并发出告警
+func (s *SessionService) revokeReplayedSession(
+	ctx context.Context,
+	m *custmodel.UserSessionModel,
+	claims *auth.UserClaims,
+	ipAddress string,
+	userAgent string,
+) *errors.Error {
+	ctxutil.Logger(ctx, s.log).Warn(
+		"检测到刷新令牌重放, 终止会话",
+		zap.String("session_id", m.SessionID),
+		zap.Uint32("user_id", m.UserID),
+		zap.String("refresh_id", claims.ID),
+		zap.String("current_refresh_id", m.RefreshID),
+		zap.String("ip_address", ipAddress),
+		zap.String("user_agent", userAgent),
+	)
+	if err := s.sessionRepo.DeleteModel(ctx, "id = ?", m.ID); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"终止重放刷新令牌的会话失败",
+			zap.Error(err),
+			zap.String("session_id", m.SessionID),
+		)
+		return errors.NewGormError(err, nil)
+	}
+	s.cache.Delete(m.SessionID)
+
+	// 刷新令牌重放是安全告警, 不受维护窗口屏蔽
+	s.alerts.Publish(ctx, syssvc.Alert{
+		Kind:     alertKindRefreshReplay,
+		Status:   "revoked",
+		Scope:    syssvc.AlertScopeNone,
+		TargetID: m.UserID,
+		At:       time.Now(),
+		Event: custmodel.RefreshTokenReplayEvent{
+			Kind:       alertKindRefreshReplay,
+			SessionID:  m.SessionID,
+			UserID:     m.UserID,
+			Username:   claims.Username,
+			IPAddress:  ipAddress,
+			UserAgent:  truncate(userAgent, 254),
+			DetectedAt: time.Now().Format(time.DateTime),
+		},
+	})
+	return errors.ErrRefreshTokenReused
+}
+
+// ValidateSession 校验访问令牌所属的会话没有被终止, 实现auth.SessionValidator
+func (s *SessionService) ValidateSession(ctx context.Context, claims *auth.UserClaims) *errors.Error {
+	// 升级前签发的令牌不属于任何会话, 按令牌有效期自然失效
+	if claims.SessionID == "" {
+		return nil
+	}
+	if _, ok := s.cache.Get(claims.SessionID); ok {
+		return nil
+	}
+
+	m, err := s.sessionRepo.GetModel(ctx, nil, "session_id = ?", claims.SessionID)
+	if err != nil {
+		if rErr := errors.NewGormError(err, nil); !rErr.Is(errors.ErrRecordNotFound) {
+			return rErr
+		}
+		return errors.ErrSessionRevoked
+	}
+	if m.UserID != claims.UserID || time.Now().After(m.ExpiresAt) {
+		return errors.ErrSessionRevoked
+	}
+	s.cache.SetDefault(claims.SessionID, struct{}{})
+	return nil
+}
+
+// Revoke 终止令牌所属的会话, 会话的访问令牌和刷新令牌随之失效, 会话不存在时不做处理
+func (s *SessionService) Revoke(ctx context.Context, claims *auth.UserClaims) *errors.Error {
+	if ctx.Err() != nil {
+		return errors.FromError(ctx.Err())
+	}
+	// 升级前签发的令牌不属于任何会话, 按令牌有效期自然失效
+	if claims.SessionID == "" {
+		return nil
+	}
+
+	if err := s.sessionRepo.DeleteModel(ctx, "session_id = ? AND user_id = ?", claims.SessionID, claims.UserID); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"终止会话失败",
+			zap.Error(err),
+			zap.Uint32("user_id", claims.UserID),
+			zap.String("session_id", claims.SessionID),
+		)
+		return errors.NewGormError(err, nil)
+	}
+	s.cache.Delete(claims.SessionID)
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"终止会话成功",
+		zap.Uint32("user_id", claims.UserID),
+		zap.String("session_id", claims.SessionID),
+	)
+	return nil
+}
+
+// ListUserSession 查询用户未过期的会话, 按最近签发令牌时间倒序
+func (s *SessionService) ListUserSession(
+	ctx context.Context,
+	userID uint32,
+) (*[]custmodel.UserSessionModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	_, ms, err := s.sessionRepo.ListModel(ctx, database.QueryParams{
+		Query: map[string]any{
+			"user_id = ?":    userID,
+			"expires_at > ?": time.Now(),
+		},
+		OrderBy: []string{"refreshed_at DESC"},
+	})
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询用户会话失败",
+			zap.Error(err),
+			zap.Uint32("user_id", userID),
+		)
+		return nil, errors.NewGormError(err, nil)
+	}
+	return ms, nil
+}
+
+// DeleteUserSession 终止用户的指定会话
+func (s *SessionService) DeleteUserSession(
+	ctx context.Context,
+	userID uint32,
+	sessionID string,
+) *errors.Error {
+	if ctx.Err() != nil {
+		return errors.FromError(ctx.Err())
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始终止用户会话",
+		zap.Uint32("user_id", userID),
+		zap.String("session_id", sessionID),
+	)
+
+	if _, err := s.sessionRepo.GetModel(ctx, nil, "session_id = ? AND user_id = ?", sessionID, userID); err != nil {
+		if rErr := errors.NewGormError(err, nil); !rErr.Is(errors.ErrRecordNotFound) {
+			return rErr
+		}
+		return errors.ErrSessionNotFound.WithField("session_id", sessionID)
+	}
+	if err := s.sessionRepo.DeleteModel(ctx, "session_id = ? AND user_id = ?", sessionID, userID); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"终止用户会话失败",
+			zap.Error(err),
+			zap.Uint32("user_id", userID),
+			zap.String("session_id", sessionID),
+		)
+		return errors.NewGormError(err, nil)
+	}
+	s.cache.Delete(sessionID)
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"终止用户会话成功",
+		zap.Uint32("user_id", userID),
+		zap.String("session_id", sessionID),
+	)
+	return nil
+}
+
+// DeleteOtherUserSession 终止用户除keepSessionID以外的所有会话, keepSessionID为空时终止全部会话
+func (s *SessionService) DeleteOtherUserSession(
+	ctx context.Context,
+	userID uint32,
+	keepSessionID string,
+) (int64, *errors.Error) {
+	if ctx.Err() != nil {
+		return 0, errors.FromError(ctx.Err())
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始终止用户的其他会话",
+		zap.Uint32("user_id", userID),
+		zap.String("keep_session_id", keepSessionID),
+	)
+
+	_, ms, err := s.sessionRepo.ListModel(ctx, database.QueryParams{
+		Query: map[string]any{
+			"user_id = ?":     userID,
+			"session_id <> ?": keepSessionID,
+		},
+	})
+	if err != nil {
+		return 0, errors.NewGormError(err, nil)
+	}
+	if len(*ms) == 0 {
+		return 0, nil
+	}
+	sessionIDs := make([]string, 0, len(*ms))
+	for _, m := range *ms {
+		sessionIDs = append(sessionIDs, m.SessionID)
+	}
+	if err := s.sessionRepo.DeleteModel(ctx, "user_id = ? AND session_id IN ?", userID, sessionIDs); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"终止用户的其他会话失败",
+			zap.Error(err),
+			zap.Uint32("user_id", userID),
+		)
+		return 0, errors.NewGormError(err, nil)
+	}
+	for _, sessionID := range sessionIDs {
+		s.cache.Delete(sessionID)
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"终止用户的其他会话成功",
+		zap.Uint32("user_id", userID),
+		zap.Int("deleted", len(sessionIDs)),
+	)
+	return int64(len(sessionIDs)), nil
+}
+
+// DeleteExpiredSession 清理已过期的会话
+func (s *SessionService) DeleteExpiredSession(ctx context.Context) *errors.Error {
+	if ctx.Err() != nil {
+		return errors.FromError(ctx.Err())
+	}
+	if err := s.sessionRepo.DeleteModel(ctx, "expires_at < ?", time.Now()); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"清理已过期的会话失败",
+			zap.Error(err),
+		)
+		return errors.NewGormError(err, nil)
+	}
+	return nil
+}
+
+// deviceFromUserAgent 从客户端信息中识别浏览器和操作系统, 无法识别时返回空字符串
+func deviceFromUserAgent(ua string) string {
+	browsers := []struct{ token, name string }{
+		{"Edg/", "Edge"},
+		{"OPR/", "Opera"},
+		{"Firefox/", "Firefox"},
+		{"Chrome/", "Chrome"},
+		{"Safari/", "Safari"},
+		{"curl/", "curl"},
+	}
+	systems := []struct{ token, name string }{
+		{"Windows", "Windows"},
+		{"Android", "Android"},
+		{"iPhone", "iOS"},
+		{"iPad", "iOS"},
+		{"Mac OS X", "macOS"},
+		{"Linux", "Linux"},
+	}
+
+	var parts []string
+	for _, b := range browsers {
+		if strings.Contains(ua, b.token) {
+			parts = append(parts, b.name)
+			break
+		}
+	}
+	for _, o := range systems {
+		if strings.Contains(ua, o.token) {
+			parts = append(parts, o.name)
+			break
+		}
+	}
+	return strings.Join(parts, " / ")
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n]
+}
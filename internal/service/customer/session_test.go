@@ -0,0 +1,151 @@
+package customer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	custmodel "gin-artweb/internal/model/customer"
+	custsvc "gin-artweb/internal/repository/customer"
+	"gin-artweb/internal/shared/auth"
+	"gin-artweb/internal/shared/errors"
+	"gin-artweb/internal/shared/test"
+)
+
+type SessionTestSuite struct {
+	suite.Suite
+	jwt *auth.JWTConfig
+	ss  *SessionService
+}
+
+func (suite *SessionTestSuite) SetupSuite() {
+	db := test.NewTestGormDBWithConfig(nil)
+	db.AutoMigrate(&custmodel.UserSessionModel{})
+	logger := test.NewTestZapLogger()
+	suite.jwt = auth.NewJWTConfig(
+		time.Duration(10)*time.Minute,
+		time.Duration(1)*time.Hour,
+		"HS256",
+		"HS256",
+		[]byte("test_access_secret"),
+		[]byte("test_refresh_secret"),
+	)
+	suite.ss = NewSessionService(logger, custsvc.NewUserSessionRepo(logger, db, test.NewTestDBTimeouts()), suite.jwt, nil)
+	suite.jwt.Sessions = suite.ss
+}
+
+func TestSessionTestSuite(t *testing.T) {
+	suite.Run(t, new(SessionTestSuite))
+}
+
+func (suite *SessionTestSuite) TestIssueAndRenew() {
+	ctx := context.Background()
+	ua := "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0 Safari/537.36"
+	pair, rErr := suite.ss.Issue(ctx, auth.UserInfo{UserID: 1, Username: "alice"}, custmodel.SessionMethodPassword, "10.0.0.1", ua)
+	suite.Require().Nil(rErr)
+	suite.True(pair.RefreshExpiresAt.After(pair.AccessExpiresAt), "刷新令牌的有效期应该长于访问令牌")
+
+	claims, rErr := auth.ParseAccessToken(ctx, suite.jwt, pair.AccessToken)
+	suite.Require().Nil(rErr)
+	suite.NotEmpty(claims.SessionID)
+
+	ms, rErr := suite.ss.ListUserSession(ctx, 1)
+	suite.Require().Nil(rErr)
+	suite.Require().Len(*ms, 1)
+	suite.Equal("Chrome / Windows", (*ms)[0].Device)
+	suite.Equal(pair.AccessID, (*ms)[0].AccessID)
+
+	refreshClaims, rErr := auth.ParseRefreshToken(ctx, suite.jwt, pair.RefreshToken)
+	suite.Require().Nil(rErr)
+	renewed, rErr := suite.ss.Renew(ctx, refreshClaims, "10.0.0.2", ua)
+	suite.Require().Nil(rErr)
+	ms, rErr = suite.ss.ListUserSession(ctx, 1)
+	suite.Require().Nil(rErr)
+	suite.Require().Len(*ms, 1, "刷新令牌不应该创建新会话")
+	suite.Equal(renewed.RefreshID, (*ms)[0].RefreshID)
+	suite.Equal("10.0.0.2", (*ms)[0].IPAddress)
+}
+
+func (suite *SessionTestSuite) TestDeleteSession() {
+	ctx := context.Background()
+	ui := auth.UserInfo{UserID: 2, Username: "bob"}
+	current, rErr := suite.ss.Issue(ctx, ui, custmodel.SessionMethodPassword, "10.0.0.1", "curl/8.0")
+	suite.Require().Nil(rErr)
+	other, rErr := suite.ss.Issue(ctx, ui, custmodel.SessionMethodPassword, "10.0.0.2", "curl/8.0")
+	suite.Require().Nil(rErr)
+	third, rErr := suite.ss.Issue(ctx, ui, custmodel.SessionMethodPassword, "10.0.0.3", "curl/8.0")
+	suite.Require().Nil(rErr)
+
+	currentClaims, rErr := auth.ParseAccessToken(ctx, suite.jwt, current.AccessToken)
+	suite.Require().Nil(rErr)
+	otherClaims, rErr := auth.ParseAccessToken(ctx, suite.jwt, other.AccessToken)
+	suite.Require().Nil(rErr)
+
+	// 其他用户不能终止该会话
+	suite.Equal(errors.ErrSessionNotFound.Reason, suite.ss.DeleteUserSession(ctx, 1, otherClaims.SessionID).Reason)
+
+	// 终止后访问令牌和刷新令牌立即失效
+	suite.Nil(suite.ss.DeleteUserSession(ctx, 2, otherClaims.SessionID))
+	_, rErr = auth.ParseAccessToken(ctx, suite.jwt, other.AccessToken)
+	suite.Equal(errors.ErrSessionRevoked.Reason, rErr.Reason)
+	refreshClaims, rErr := auth.ParseRefreshToken(ctx, suite.jwt, other.RefreshToken)
+	suite.Require().Nil(rErr)
+	_, rErr = suite.ss.Renew(ctx, refreshClaims, "", "")
+	suite.Equal(errors.ErrSessionRevoked.Reason, rErr.Reason)
+
+	// 终止除当前会话以外的所有会话
+	deleted, rErr := suite.ss.DeleteOtherUserSession(ctx, 2, currentClaims.SessionID)
+	suite.Require().Nil(rErr)
+	suite.Equal(int64(1), deleted)
+	_, rErr = auth.ParseAccessToken(ctx, suite.jwt, third.AccessToken)
+	suite.Equal(errors.ErrSessionRevoked.Reason, rErr.Reason)
+	_, rErr = auth.ParseAccessToken(ctx, suite.jwt, current.AccessToken)
+	suite.Nil(rErr)
+}
+
+func (suite *SessionTestSuite) TestLegacyToken() {
+	ctx := context.Background()
+	// 升级前签发的令牌不属于任何会话, 刷新时创建会话
+	token, err := auth.NewRefreshJWT(ctx, suite.jwt, auth.UserInfo{UserID: 3, Username: "carol"})
+	suite.Require().NoError(err)
+	claims, rErr := auth.ParseRefreshToken(ctx, suite.jwt, token)
+	suite.Require().Nil(rErr)
+	suite.Nil(suite.ss.ValidateSession(ctx, claims))
+
+	pair, rErr := suite.ss.Renew(ctx, claims, "10.0.0.1", "")
+	suite.Require().Nil(rErr)
+	ms, rErr := suite.ss.ListUserSession(ctx, 3)
+	suite.Require().Nil(rErr)
+	suite.Require().Len(*ms, 1)
+	suite.Equal(pair.RefreshID, (*ms)[0].RefreshID)
+}
+
+func (suite *SessionTestSuite) TestDeviceFromUserAgent() {
+	suite.Equal("Edge / Windows", deviceFromUserAgent("Mozilla/5.0 (Windows NT 10.0) Chrome/120.0 Safari/537.36 Edg/120.0"))
+	suite.Equal("Safari / iOS", deviceFromUserAgent("Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) Version/17.0 Safari/604.1"))
+	suite.Equal("", deviceFromUserAgent(""))
+}
+
+func (suite *SessionTestSuite) TestRenewReplay() {
+	ctx := context.Background()
+	pair, rErr := suite.ss.Issue(ctx, auth.UserInfo{UserID: 5, Username: "eve"}, custmodel.SessionMethodPassword, "10.0.0.1", "curl/8.0")
+	suite.Require().Nil(rErr)
+	oldClaims, rErr := auth.ParseRefreshToken(ctx, suite.jwt, pair.RefreshToken)
+	suite.Require().Nil(rErr)
+
+	renewed, rErr := suite.ss.Renew(ctx, oldClaims, "10.0.0.1", "curl/8.0")
+	suite.Require().Nil(rErr)
+
+	// 再次使用已轮换的刷新令牌, 整个会话被终止
+	_, rErr = suite.ss.Renew(ctx, oldClaims, "10.0.0.9", "curl/8.0")
+	suite.Require().NotNil(rErr)
+	suite.True(rErr.Is(errors.ErrRefreshTokenReused))
+
+	newClaims, rErr := auth.ParseRefreshToken(ctx, suite.jwt, renewed.RefreshToken)
+	suite.Require().Nil(rErr)
+	_, rErr = suite.ss.Renew(ctx, newClaims, "10.0.0.1", "curl/8.0")
+	suite.Require().NotNil(rErr)
+	suite.True(rErr.Is(errors.ErrSessionRevoked), "会话终止后最新的刷新令牌也应该失效")
+}
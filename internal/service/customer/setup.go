@@ -0,0 +1,312 @@
+package customer
+
+import (
+	"context"
+	"crypto/rand"
+	"math/big"
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap"
+
+	commodel "gin-artweb/internal/model/common"
+	custmodel "gin-artweb/internal/model/customer"
+	custrepo "gin-artweb/internal/repository/customer"
+	"gin-artweb/internal/shared/auth"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+	"gin-artweb/pkg/crypto"
+)
+
+// bootstrapOperator 初始化数据的操作人
+const bootstrapOperator = "bootstrap"
+
+// defaultRole 默认角色及其包含的API
+type defaultRole struct {
+	name   string
+	descr  string
+	permit func(api custmodel.ApiModel) bool
+}
+
+// isAdminApi 用户权限管理和系统管理类接口
+func isAdminApi(api custmodel.ApiModel) bool {
+	return strings.HasPrefix(api.URL, "/api/v1/customer/") || strings.HasPrefix(api.URL, "/api/v1/admin/")
+}
+
+// isQueryApi 查询接口, GraphQL查询接口只读, 各字段按对应查询接口的权限鉴权
+func isQueryApi(api custmodel.ApiModel) bool {
+	return api.Method == http.MethodGet || (api.Method == http.MethodPost && api.URL == "/api/graphql")
+}
+
+// isRevealApi 查看敏感字段明文的接口
+func isRevealApi(api custmodel.ApiModel) bool {
+	return strings.HasSuffix(api.URL, commodel.RevealPathSuffix)
+}
+
+var defaultRoles = []defaultRole{
+	{
+		name:   custmodel.RoleNameAdmin,
+		descr:  "拥有全部接口权限",
+		permit: func(custmodel.ApiModel) bool { return true },
+	},
+	{
+		name:  custmodel.RoleNameOperator,
+		descr: "拥有除用户权限管理、系统管理写操作和查看敏感信息明文外的全部接口权限",
+		permit: func(api custmodel.ApiModel) bool {
+			return (api.Method == http.MethodGet || !isAdminApi(api)) && !isRevealApi(api)
+		},
+	},
+	{
+		name:  custmodel.RoleNameViewer,
+		descr: "仅拥有查询接口权限",
+		permit: func(api custmodel.ApiModel) bool {
+			return isQueryApi(api) && !isAdminApi(api) && !isRevealApi(api)
+		},
+	},
+}
+
+// SetupService 首次部署的初始化服务
+// 仅在数据库中没有任何用户时执行, 创建默认角色、导入API并创建初始管理员
+type SetupService struct {
+	log        *zap.Logger
+	apiService *ApiService
+	roleRepo   *custrepo.RoleRepo
+	userRepo   *custrepo.UserRepo
+	modelRepo  *custrepo.CasbinModelRepo
+	hasher     crypto.Hasher
+}
+
+func NewSetupService(
+	log *zap.Logger,
+	apiService *ApiService,
+	roleRepo *custrepo.RoleRepo,
+	userRepo *custrepo.UserRepo,
+	modelRepo *custrepo.CasbinModelRepo,
+	hasher crypto.Hasher,
+) *SetupService {
+	return &SetupService{
+		log:        log,
+		apiService: apiService,
+		roleRepo:   roleRepo,
+		userRepo:   userRepo,
+		modelRepo:  modelRepo,
+		hasher:     hasher,
+	}
+}
+
+// Initialized 判断系统是否已完成初始化, 存在任意用户即视为已初始化
+func (s *SetupService) Initialized(ctx context.Context) (bool, *errors.Error) {
+	if ctx.Err() != nil {
+		return false, errors.FromError(ctx.Err())
+	}
+
+	total, _, err := s.userRepo.ListModel(ctx, database.QueryParams{
+		IsCount: true,
+		Size:    1,
+		Columns: []string{"id"},
+	})
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询用户数量失败",
+			zap.Error(err),
+		)
+		return false, errors.NewGormError(err, nil)
+	}
+	return total > 0, nil
+}
+
+// Bootstrap 初始化系统基础数据
+//
+// apis为当前注册的路由生成的API列表, password为空时自动生成初始密码,
+// 管理员首次登录后必须修改密码才能访问其他接口
+func (s *SetupService) Bootstrap(
+	ctx context.Context,
+	apis []custmodel.ApiModel,
+	username string,
+	password string,
+) (*custmodel.BootstrapOut, *errors.Error) {
+	initialized, rErr := s.Initialized(ctx)
+	if rErr != nil {
+		return nil, rErr
+	}
+	if initialized {
+		ctxutil.Logger(ctx, s.log).Warn(
+			"系统已完成初始化, 拒绝重复执行",
+		)
+		return nil, errors.ErrSystemInitialized
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始初始化系统基础数据",
+		zap.Int("api_count", len(apis)),
+		zap.String("username", username),
+	)
+
+	out := &custmodel.BootstrapOut{Username: username}
+	if password == "" {
+		generated, err := generatePassword(18)
+		if err != nil {
+			return nil, errors.FromError(err)
+		}
+		password = generated
+		out.Password = generated
+	}
+
+	if rErr := s.ensureCasbinModel(ctx); rErr != nil {
+		return nil, rErr
+	}
+
+	synced, rErr := s.apiService.SyncApis(ctx, apis, false)
+	if rErr != nil {
+		return nil, rErr
+	}
+	out.ApiCount = len(synced.Created)
+
+	_, all, rErr := s.apiService.ListApi(ctx, database.QueryParams{
+		Query: map[string]any{"is_orphan = ?": false},
+	})
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	var adminRoleID uint32
+	for _, dr := range defaultRoles {
+		m, isNew, rErr := s.ensureRole(ctx, dr, *all)
+		if rErr != nil {
+			return nil, rErr
+		}
+		if isNew {
+			out.Roles = append(out.Roles, m.Name)
+		}
+		if dr.name == custmodel.RoleNameAdmin {
+			adminRoleID = m.ID
+		}
+	}
+
+	hashed, err := s.hasher.Hash(ctx, password)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"管理员密码哈希失败",
+			zap.Error(err),
+		)
+		return nil, errors.FromError(err)
+	}
+	um := custmodel.UserModel{
+		Username:           username,
+		Password:           hashed,
+		IsActive:           true,
+		IsStaff:            true,
+		RoleID:             adminRoleID,
+		MustChangePassword: true,
+	}
+	if err := s.userRepo.CreateModel(ctx, &um); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"创建初始管理员失败",
+			zap.Error(err),
+			zap.String("username", username),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"username": username})
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"初始化系统基础数据成功",
+		zap.Int("api_count", out.ApiCount),
+		zap.Strings("roles", out.Roles),
+		zap.String("username", username),
+	)
+	return out, nil
+}
+
+// ensureCasbinModel 数据库中没有模型配置时将内置模型保存为第一个版本
+func (s *SetupService) ensureCasbinModel(ctx context.Context) *errors.Error {
+	if _, err := s.modelRepo.GetModel(ctx, "is_active = ?", true); err == nil {
+		return nil
+	} else if rErr := errors.NewGormError(err, nil); !rErr.Is(errors.ErrRecordNotFound) {
+		return rErr
+	}
+
+	m := custmodel.CasbinModelModel{
+		Content:  auth.DefaultCasbinModel,
+		Remark:   "系统初始化",
+		Operator: bootstrapOperator,
+	}
+	if err := s.modelRepo.CreateActiveModel(ctx, &m); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"保存内置Casbin模型失败",
+			zap.Error(err),
+		)
+		return errors.NewGormError(err, nil)
+	}
+	return nil
+}
+
+// ensureRole 创建默认角色并关联对应的API, 同名角色已存在时保持不变
+func (s *SetupService) ensureRole(
+	ctx context.Context,
+	dr defaultRole,
+	apis []custmodel.ApiModel,
+) (*custmodel.RoleModel, bool, *errors.Error) {
+	if m, err := s.roleRepo.GetModel(ctx, nil, "name = ?", dr.name); err == nil {
+		return m, false, nil
+	} else if rErr := errors.NewGormError(err, nil); !rErr.Is(errors.ErrRecordNotFound) {
+		return nil, false, rErr
+	}
+
+	permitted := make([]custmodel.ApiModel, 0, len(apis))
+	for _, api := range apis {
+		if dr.permit(api) {
+			permitted = append(permitted, api)
+		}
+	}
+
+	m := custmodel.RoleModel{Name: dr.name, Descr: dr.descr}
+	if err := s.roleRepo.CreateModel(ctx, &m, &permitted, nil, nil); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"创建默认角色失败",
+			zap.Error(err),
+			zap.String("name", dr.name),
+		)
+		return nil, false, errors.NewGormError(err, nil)
+	}
+	m.Apis = permitted
+	if err := s.roleRepo.AddGroupPolicy(ctx, &m); err != nil {
+		return nil, false, errors.FromError(err)
+	}
+	return &m, true, nil
+}
+
+const (
+	passwordLower  = "abcdefghijkmnpqrstuvwxyz"
+	passwordUpper  = "ABCDEFGHJKLMNPQRSTUVWXYZ"
+	passwordDigit  = "23456789"
+	passwordSymbol = "!@#$%^&*"
+)
+
+// generatePassword 生成包含大小写字母、数字和符号的随机密码
+func generatePassword(n int) (string, error) {
+	sets := []string{passwordLower, passwordUpper, passwordDigit, passwordSymbol}
+	all := strings.Join(sets, "")
+	buf := make([]byte, n)
+	for i := range buf {
+		// 前几位保证每类字符至少出现一次
+		charset := all
+		if i < len(sets) {
+			charset = sets[i]
+		}
+		idx, err := rand.Int(rand.Reader, big.NewInt(int64(len(charset))))
+		if err != nil {
+			return "", err
+		}
+		buf[i] = charset[idx.Int64()]
+	}
+	// 打乱顺序
+	for i := len(buf) - 1; i > 0; i-- {
+		j, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return "", err
+		}
+		buf[i], buf[j.Int64()] = buf[j.Int64()], buf[i]
+	}
+	return string(buf), nil
+}
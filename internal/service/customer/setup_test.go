@@ -0,0 +1,144 @@
+package customer
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/casbin/casbin/v2"
+	"github.com/stretchr/testify/suite"
+
+	custmodel "gin-artweb/internal/model/customer"
+	custrepo "gin-artweb/internal/repository/customer"
+	"gin-artweb/internal/shared/auth"
+	"gin-artweb/internal/shared/errors"
+	"gin-artweb/internal/shared/test"
+	"gin-artweb/pkg/crypto"
+)
+
+type SetupTestSuite struct {
+	suite.Suite
+	enforcer     *casbin.Enforcer
+	setupService *SetupService
+	hasher       crypto.Hasher
+}
+
+func (suite *SetupTestSuite) SetupTest() {
+	db := test.NewTestGormDBWithConfig(nil)
+	db.AutoMigrate(
+		&custmodel.MenuModel{},
+		&custmodel.ApiModel{},
+		&custmodel.ButtonModel{},
+		&custmodel.RoleModel{},
+		&custmodel.UserModel{},
+		&custmodel.CasbinModelModel{},
+	)
+	dbTimeout := test.NewTestDBTimeouts()
+	logger := test.NewTestZapLogger()
+	suite.enforcer, _ = auth.NewCasbinEnforcer()
+	suite.hasher = crypto.NewBcryptHasher(4)
+	suite.setupService = NewSetupService(
+		logger,
+		NewApiService(logger, custrepo.NewApiRepo(logger, db, dbTimeout, suite.enforcer)),
+		custrepo.NewRoleRepo(logger, db, dbTimeout, suite.enforcer),
+		custrepo.NewUserRepo(logger, db, dbTimeout),
+		custrepo.NewCasbinModelRepo(logger, db, dbTimeout),
+		suite.hasher,
+	)
+}
+
+func TestSetupTestSuite(t *testing.T) {
+	suite.Run(t, new(SetupTestSuite))
+}
+
+func testSetupApis() []custmodel.ApiModel {
+	return []custmodel.ApiModel{
+		{URL: "/api/v1/customer/user", Method: http.MethodGet, Label: "customer"},
+		{URL: "/api/v1/customer/user", Method: http.MethodPost, Label: "customer"},
+		{URL: "/api/v1/jobs/script", Method: http.MethodGet, Label: "jobs"},
+		{URL: "/api/v1/jobs/script", Method: http.MethodPost, Label: "jobs"},
+	}
+}
+
+func (suite *SetupTestSuite) TestBootstrapQueryApi() {
+	ctx := context.Background()
+	apis := append(testSetupApis(), custmodel.ApiModel{URL: "/api/graphql", Method: http.MethodPost, Label: "system"})
+	_, rErr := suite.setupService.Bootstrap(ctx, apis, "admin", "")
+	suite.Require().Nil(rErr)
+
+	rm, err := suite.setupService.roleRepo.GetModel(ctx, nil, "name = ?", custmodel.RoleNameViewer)
+	suite.Require().NoError(err)
+	allowed, err := suite.enforcer.Enforce(auth.RoleToSubject(rm.ID), "/api/graphql", http.MethodPost)
+	suite.NoError(err)
+	suite.True(allowed, "只读用户应该可以使用GraphQL查询接口")
+}
+
+func (suite *SetupTestSuite) TestBootstrap() {
+	ctx := context.Background()
+	out, rErr := suite.setupService.Bootstrap(ctx, testSetupApis(), "admin", "")
+	suite.Nil(rErr)
+	suite.Equal(4, out.ApiCount)
+	suite.Equal([]string{custmodel.RoleNameAdmin, custmodel.RoleNameOperator, custmodel.RoleNameViewer}, out.Roles)
+	suite.Len(out.Password, 18, "未指定密码时应该自动生成")
+
+	um, err := suite.setupService.userRepo.GetModel(ctx, nil, "username = ?", "admin")
+	suite.NoError(err)
+	suite.True(um.MustChangePassword, "初始管理员需要修改密码")
+	ok, err := suite.hasher.Verify(ctx, out.Password, um.Password)
+	suite.NoError(err)
+	suite.True(ok)
+
+	enforce := func(roleName, url, method string) bool {
+		rm, err := suite.setupService.roleRepo.GetModel(ctx, nil, "name = ?", roleName)
+		suite.NoError(err)
+		allowed, err := suite.enforcer.Enforce(auth.RoleToSubject(rm.ID), url, method)
+		suite.NoError(err)
+		return allowed
+	}
+	suite.True(enforce(custmodel.RoleNameAdmin, "/api/v1/customer/user", http.MethodPost))
+	suite.False(enforce(custmodel.RoleNameOperator, "/api/v1/customer/user", http.MethodPost))
+	suite.True(enforce(custmodel.RoleNameOperator, "/api/v1/jobs/script", http.MethodPost))
+	suite.False(enforce(custmodel.RoleNameViewer, "/api/v1/jobs/script", http.MethodPost))
+	suite.True(enforce(custmodel.RoleNameViewer, "/api/v1/jobs/script", http.MethodGet))
+
+	cm, err := suite.setupService.modelRepo.GetModel(ctx, "is_active = ?", true)
+	suite.NoError(err, "应该保存内置Casbin模型")
+	suite.Equal(auth.DefaultCasbinModel, cm.Content)
+}
+
+func (suite *SetupTestSuite) TestBootstrapRevealApi() {
+	ctx := context.Background()
+	apis := append(testSetupApis(), custmodel.ApiModel{
+		URL: "/api/v1/jobs/schedule/:id/reveal", Method: http.MethodGet, Label: "jobs",
+	})
+	_, rErr := suite.setupService.Bootstrap(ctx, apis, "admin", "")
+	suite.Nil(rErr)
+
+	enforce := func(roleName string) bool {
+		rm, err := suite.setupService.roleRepo.GetModel(ctx, nil, "name = ?", roleName)
+		suite.NoError(err)
+		allowed, err := suite.enforcer.Enforce(auth.RoleToSubject(rm.ID), "/api/v1/jobs/schedule/:id/reveal", http.MethodGet)
+		suite.NoError(err)
+		return allowed
+	}
+	suite.True(enforce(custmodel.RoleNameAdmin))
+	suite.False(enforce(custmodel.RoleNameOperator), "查看明文的接口需要单独授权")
+	suite.False(enforce(custmodel.RoleNameViewer), "查看明文的接口需要单独授权")
+}
+
+func (suite *SetupTestSuite) TestBootstrapOnlyOnce() {
+	ctx := context.Background()
+	_, rErr := suite.setupService.Bootstrap(ctx, testSetupApis(), "admin", "Admin@123456")
+	suite.Nil(rErr)
+
+	_, rErr = suite.setupService.Bootstrap(ctx, testSetupApis(), "admin2", "")
+	suite.NotNil(rErr)
+	suite.True(rErr.Is(errors.ErrSystemInitialized), "已初始化时应该拒绝执行")
+}
+
+func (suite *SetupTestSuite) TestGeneratePassword() {
+	pwd, err := generatePassword(18)
+	suite.NoError(err)
+	suite.Len(pwd, 18)
+	suite.Equal(StrengthVeryStrong, GetPasswordStrength(pwd), "生成的密码应该满足最高强度要求")
+}
@@ -0,0 +1,395 @@
+package customer
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	custmodel "gin-artweb/internal/model/customer"
+	custrepo "gin-artweb/internal/repository/customer"
+	"gin-artweb/internal/shared/auth"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+)
+
+// SigningKeyOperatorSystem 系统自动生成或定时轮换密钥时记录的操作人
+const SigningKeyOperatorSystem = "system"
+
+// SigningKeyService JWT签名密钥管理
+//
+// 密钥保存在数据库中, 启动时和定时任务中加载到JWT配置的密钥集合。
+// 轮换后旧密钥保留到该类型令牌的有效期结束, 已签发的令牌在此期间仍然有效
+type SigningKeyService struct {
+	log     *zap.Logger
+	keyRepo *custrepo.SigningKeyRepo
+	jwtConf *auth.JWTConfig
+	mu      sync.Mutex
+}
+
+func NewSigningKeyService(
+	log *zap.Logger,
+	keyRepo *custrepo.SigningKeyRepo,
+	jwtConf *auth.JWTConfig,
+) *SigningKeyService {
+	return &SigningKeyService{
+		log:     log,
+		keyRepo: keyRepo,
+		jwtConf: jwtConf,
+	}
+}
+
+// LoadKeys 从数据库加载两种令牌的签名密钥
+// 没有可用密钥或当前密钥的签名方法与配置不一致时自动轮换生成新密钥
+func (s *SigningKeyService) LoadKeys(ctx context.Context) *errors.Error {
+	if ctx.Err() != nil {
+		return errors.FromError(ctx.Err())
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, tt := range []auth.TokenType{auth.TokenTypeAccess, auth.TokenTypeRefresh} {
+		if rErr := s.loadKeys(ctx, tt); rErr != nil {
+			return rErr
+		}
+		current := s.jwtConf.Keys(tt).Current()
+		method := s.jwtConf.Method(tt)
+		if current != nil && current.Method.Alg() == method.Alg() {
+			continue
+		}
+		ctxutil.Logger(ctx, s.log).Warn(
+			"没有与配置一致的签名密钥, 自动生成新密钥",
+			zap.String("token_type", string(tt)),
+			zap.String("algorithm", method.Alg()),
+		)
+		if _, rErr := s.rotate(ctx, tt, false, SigningKeyOperatorSystem); rErr != nil {
+			return rErr
+		}
+	}
+	return nil
+}
+
+// loadKeys 加载指定令牌类型未停用的密钥
+func (s *SigningKeyService) loadKeys(ctx context.Context, tt auth.TokenType) *errors.Error {
+	now := time.Now()
+	ms, rErr := s.listKeys(ctx, tt, now)
+	if rErr != nil {
+		return rErr
+	}
+
+	ks := s.jwtConf.Keys(tt)
+	secret := ks.Secret()
+	if len(ms) == 0 {
+		s.trackKeys(ctx, tt, ks.Replace(secret, nil))
+		return nil
+	}
+
+	var current *auth.SigningKey
+	keys := make([]*auth.SigningKey, 0, len(ms))
+	for _, m := range ms {
+		k := s.decodeKey(ctx, m, secret)
+		if k == nil {
+			continue
+		}
+		if m.IsCurrent {
+			current = k
+		} else {
+			keys = append(keys, k)
+		}
+	}
+	if current == nil && secret != nil {
+		ctxutil.Logger(ctx, s.log).Warn(
+			"数据库中没有可用的当前签名密钥, 使用环境变量配置的密钥",
+			zap.String("token_type", string(tt)),
+		)
+		current = secret
+	}
+	s.trackKeys(ctx, tt, ks.Replace(current, keys))
+
+	ctxutil.Logger(ctx, s.log).Debug(
+		"加载签名密钥成功",
+		zap.String("token_type", string(tt)),
+		zap.Int("count", len(keys)+1),
+	)
+	return nil
+}
+
+// trackKeys 登记集合中的对称密钥, 用于日志脱敏, 并释放已从集合中移除的密钥
+func (s *SigningKeyService) trackKeys(ctx context.Context, tt auth.TokenType, removed []string) {
+	secrets := s.jwtConf.Secrets
+	for _, kid := range removed {
+		secrets.Release(signingKeySecretName(kid))
+	}
+	ks := s.jwtConf.Keys(tt)
+	for _, k := range ks.Keys() {
+		if secret := ks.Secret(); secret != nil && secret.ID == k.ID {
+			continue
+		}
+		if b, ok := k.SignKey.([]byte); ok {
+			secrets.Track(signingKeySecretName(k.ID), b)
+		}
+	}
+	if len(removed) > 0 {
+		ctxutil.Logger(ctx, s.log).Info(
+			"已清除移除的签名密钥",
+			zap.String("token_type", string(tt)),
+			zap.Strings("kids", removed),
+		)
+	}
+}
+
+// signingKeySecretName 数据库中的签名密钥在密钥管理中的名称
+func signingKeySecretName(kid string) string {
+	return "jwt." + kid
+}
+
+// decodeKey 还原数据库中保存的密钥, 只保存了标识的密钥使用环境变量配置的密钥
+func (s *SigningKeyService) decodeKey(
+	ctx context.Context,
+	m custmodel.SigningKeyModel,
+	secret *auth.SigningKey,
+) *auth.SigningKey {
+	var k *auth.SigningKey
+	if m.PrivateKey == "" {
+		if secret == nil || secret.ID != m.KeyID {
+			ctxutil.Logger(ctx, s.log).Warn(
+				"签名密钥对应的环境变量密钥已变更, 忽略该密钥",
+				zap.Object(database.ModelKey, &m),
+			)
+			return nil
+		}
+		copied := *secret
+		k = &copied
+	} else {
+		decoded, err := auth.DecodeSigningKey(m.KeyID, m.Algorithm, m.PrivateKey)
+		if err != nil {
+			ctxutil.Logger(ctx, s.log).Error(
+				"还原签名密钥失败, 忽略该密钥",
+				zap.Error(err),
+				zap.Object(database.ModelKey, &m),
+			)
+			return nil
+		}
+		k = decoded
+	}
+	if m.RetireAt != nil {
+		k.RetireAt = *m.RetireAt
+	}
+	return k
+}
+
+func (s *SigningKeyService) listKeys(
+	ctx context.Context,
+	tt auth.TokenType,
+	now time.Time,
+) ([]custmodel.SigningKeyModel, *errors.Error) {
+	qp := database.QueryParams{
+		OrderBy: []string{"id ASC"},
+		Query: map[string]any{
+			"token_type = ?":                     string(tt),
+			"retire_at IS NULL OR retire_at > ?": now,
+		},
+	}
+	_, ms, err := s.keyRepo.ListModel(ctx, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询签名密钥失败",
+			zap.Error(err),
+			zap.String("token_type", string(tt)),
+		)
+		return nil, errors.NewGormError(err, nil)
+	}
+	return *ms, nil
+}
+
+// RotateKeys 为指定令牌类型生成新的签名密钥并立即用于签发令牌
+// revoke为true时旧密钥立即停用, 否则保留到该类型令牌的有效期结束
+func (s *SigningKeyService) RotateKeys(
+	ctx context.Context,
+	tokenTypes []auth.TokenType,
+	revoke bool,
+	operator string,
+) ([]custmodel.SigningKeyModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ms := make([]custmodel.SigningKeyModel, 0, len(tokenTypes))
+	for _, tt := range tokenTypes {
+		m, rErr := s.rotate(ctx, tt, revoke, operator)
+		if rErr != nil {
+			return nil, rErr
+		}
+		ms = append(ms, *m)
+	}
+	return ms, nil
+}
+
+func (s *SigningKeyService) rotate(
+	ctx context.Context,
+	tt auth.TokenType,
+	revoke bool,
+	operator string,
+) (*custmodel.SigningKeyModel, *errors.Error) {
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始轮换签名密钥",
+		zap.String("token_type", string(tt)),
+		zap.Bool("revoke", revoke),
+		zap.String("operator", operator),
+	)
+
+	// 环境变量配置的密钥不在数据库中时先登记其标识, 以便轮换后按停用时间失效
+	if rErr := s.registerSecret(ctx, tt); rErr != nil {
+		return nil, rErr
+	}
+
+	k, err := auth.GenerateSigningKey(s.jwtConf.Method(tt))
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"生成签名密钥失败",
+			zap.Error(err),
+			zap.String("token_type", string(tt)),
+		)
+		return nil, errors.ErrSigningKeyGenerateFailed.WithCause(err)
+	}
+	// 保存后从数据库重新加载, 生成的密钥不再使用
+	defer k.Zeroize()
+	private, public, err := auth.EncodeSigningKey(k)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"编码签名密钥失败",
+			zap.Error(err),
+			zap.String("token_type", string(tt)),
+		)
+		return nil, errors.ErrSigningKeyGenerateFailed.WithCause(err)
+	}
+
+	retireAt := time.Now()
+	if !revoke {
+		retireAt = retireAt.Add(s.jwtConf.Expiration(tt))
+	}
+	m := &custmodel.SigningKeyModel{
+		KeyID:      k.ID,
+		TokenType:  string(tt),
+		Algorithm:  k.Method.Alg(),
+		PrivateKey: private,
+		PublicKey:  public,
+		Operator:   operator,
+	}
+	if err := s.keyRepo.RotateModel(ctx, m, retireAt); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"保存签名密钥失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, m),
+		)
+		return nil, errors.NewGormError(err, nil)
+	}
+
+	if rErr := s.loadKeys(ctx, tt); rErr != nil {
+		return nil, rErr
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"轮换签名密钥成功",
+		zap.Object(database.ModelKey, m),
+		zap.Time("retire_at", retireAt),
+	)
+	return m, nil
+}
+
+// registerSecret 登记环境变量配置的密钥, 只保存标识不保存密钥内容
+func (s *SigningKeyService) registerSecret(ctx context.Context, tt auth.TokenType) *errors.Error {
+	secret := s.jwtConf.Keys(tt).Secret()
+	if secret == nil {
+		return nil
+	}
+	qp := database.QueryParams{
+		IsCount: true,
+		Size:    1,
+		Page:    1,
+		Query:   map[string]any{"kid = ?": secret.ID},
+	}
+	count, _, err := s.keyRepo.ListModel(ctx, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询环境变量密钥登记记录失败",
+			zap.Error(err),
+		)
+		return errors.NewGormError(err, nil)
+	}
+	if count > 0 {
+		return nil
+	}
+
+	// 数据库中已有其他当前密钥时环境变量密钥已不再使用
+	current := s.jwtConf.Keys(tt).Current()
+	if current == nil || current.ID != secret.ID {
+		return nil
+	}
+	m := &custmodel.SigningKeyModel{
+		KeyID:     secret.ID,
+		TokenType: string(tt),
+		Algorithm: secret.Method.Alg(),
+		IsCurrent: true,
+		Operator:  SigningKeyOperatorSystem,
+	}
+	if err := s.keyRepo.CreateModel(ctx, m); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"登记环境变量密钥失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, m),
+		)
+		return errors.NewGormError(err, nil)
+	}
+	return nil
+}
+
+// RefreshKeys 删除已停用的密钥并重新加载, 用于定时同步其他实例轮换的密钥
+func (s *SigningKeyService) RefreshKeys(ctx context.Context) *errors.Error {
+	if ctx.Err() != nil {
+		return errors.FromError(ctx.Err())
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.keyRepo.DeleteModel(ctx, "retire_at <= ?", time.Now()); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"删除已停用的签名密钥失败",
+			zap.Error(err),
+		)
+		return errors.NewGormError(err, nil)
+	}
+	for _, tt := range []auth.TokenType{auth.TokenTypeAccess, auth.TokenTypeRefresh} {
+		if rErr := s.loadKeys(ctx, tt); rErr != nil {
+			return rErr
+		}
+	}
+	return nil
+}
+
+func (s *SigningKeyService) ListSigningKey(
+	ctx context.Context,
+	qp database.QueryParams,
+) (int64, *[]custmodel.SigningKeyModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return 0, nil, errors.FromError(ctx.Err())
+	}
+
+	count, ms, err := s.keyRepo.ListModel(ctx, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询签名密钥列表失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		return 0, nil, errors.NewGormError(err, nil)
+	}
+	return count, ms, nil
+}
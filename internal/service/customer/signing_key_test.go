@@ -0,0 +1,203 @@
+package customer
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	custmodel "gin-artweb/internal/model/customer"
+	custrepo "gin-artweb/internal/repository/customer"
+	"gin-artweb/internal/shared/auth"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+	"gin-artweb/internal/shared/test"
+)
+
+type SigningKeyServiceTestSuite struct {
+	suite.Suite
+	keyRepo *custrepo.SigningKeyRepo
+}
+
+func (suite *SigningKeyServiceTestSuite) SetupTest() {
+	db := test.NewTestGormDBWithConfig(nil)
+	db.AutoMigrate(&custmodel.SigningKeyModel{})
+	suite.keyRepo = custrepo.NewSigningKeyRepo(test.NewTestZapLogger(), db, test.NewTestDBTimeouts())
+}
+
+func (suite *SigningKeyServiceTestSuite) newService(method string) (*SigningKeyService, *auth.JWTConfig) {
+	jwtConf := auth.NewJWTConfig(
+		time.Minute,
+		time.Hour,
+		method,
+		method,
+		[]byte("test_access_secret"),
+		[]byte("test_refresh_secret"),
+	)
+	return NewSigningKeyService(test.NewTestZapLogger(), suite.keyRepo, jwtConf), jwtConf
+}
+
+func (suite *SigningKeyServiceTestSuite) TestLoadKeysWithSecret() {
+	svc, jwtConf := suite.newService("HS256")
+	suite.Nil(svc.LoadKeys(context.Background()), "加载密钥应该成功")
+	suite.Equal(jwtConf.AccessKeys.Secret(), jwtConf.AccessKeys.Current(), "数据库中没有密钥时应该使用环境变量密钥")
+
+	_, ms, err := suite.keyRepo.ListModel(context.Background(), database.QueryParams{})
+	suite.NoError(err)
+	suite.Empty(*ms, "使用环境变量密钥时不应该生成新密钥")
+}
+
+func (suite *SigningKeyServiceTestSuite) TestRotateKeepsPreviousUntilExpiration() {
+	svc, jwtConf := suite.newService("HS256")
+	suite.Nil(svc.LoadKeys(context.Background()))
+	ctx := context.Background()
+	u := auth.UserInfo{UserID: 1, Username: "admin", RoleID: 1}
+
+	before, err := auth.NewAccessJWT(ctx, jwtConf, u)
+	suite.NoError(err)
+
+	ms, rErr := svc.RotateKeys(ctx, []auth.TokenType{auth.TokenTypeAccess}, false, "admin")
+	suite.Nil(rErr, "轮换密钥应该成功")
+	suite.Len(ms, 1)
+	suite.Equal(ms[0].KeyID, jwtConf.AccessKeys.Current().ID, "新密钥应该用于签发令牌")
+	suite.Equal(jwtConf.RefreshKeys.Secret(), jwtConf.RefreshKeys.Current(), "未轮换的令牌类型不应该受影响")
+
+	after, err := auth.NewAccessJWT(ctx, jwtConf, u)
+	suite.NoError(err)
+	token, _, err := jwt.NewParser().ParseUnverified(after, &auth.UserClaims{})
+	suite.NoError(err)
+	suite.Equal(ms[0].KeyID, token.Header[auth.KeyIDHeader])
+
+	_, rErr = auth.ParseAccessToken(ctx, jwtConf, before)
+	suite.Nil(rErr, "轮换前签发的令牌在有效期内应该仍然有效")
+	_, rErr = auth.ParseAccessToken(ctx, jwtConf, after)
+	suite.Nil(rErr)
+
+	// 重新加载后仍然保留旧密钥
+	suite.Nil(svc.RefreshKeys(ctx))
+	_, rErr = auth.ParseAccessToken(ctx, jwtConf, before)
+	suite.Nil(rErr, "重新加载后轮换前签发的令牌应该仍然有效")
+}
+
+func (suite *SigningKeyServiceTestSuite) TestRotateRevokePrevious() {
+	svc, jwtConf := suite.newService("HS256")
+	suite.Nil(svc.LoadKeys(context.Background()))
+	ctx := context.Background()
+	u := auth.UserInfo{UserID: 1, Username: "admin", RoleID: 1}
+
+	before, err := auth.NewAccessJWT(ctx, jwtConf, u)
+	suite.NoError(err)
+	_, rErr := svc.RotateKeys(ctx, []auth.TokenType{auth.TokenTypeAccess}, true, "admin")
+	suite.Nil(rErr)
+
+	_, rErr = auth.ParseAccessToken(ctx, jwtConf, before)
+	suite.NotNil(rErr, "立即停用旧密钥后轮换前签发的令牌应该失效")
+	suite.True(rErr.Is(errors.ErrTokenInvalid))
+
+	// 停用的环境变量密钥重启后也不应该再生效
+	restarted, restartedConf := suite.newService("HS256")
+	suite.Nil(restarted.LoadKeys(ctx))
+	_, rErr = auth.ParseAccessToken(ctx, restartedConf, before)
+	suite.NotNil(rErr, "重启后已停用的环境变量密钥不应该恢复")
+	suite.NotEqual(restartedConf.AccessKeys.Secret().ID, restartedConf.AccessKeys.Current().ID)
+}
+
+func (suite *SigningKeyServiceTestSuite) TestAsymmetricKeys() {
+	for _, method := range []string{"RS256", "EdDSA"} {
+		suite.SetupTest()
+		svc, jwtConf := suite.newService(method)
+		suite.Nil(jwtConf.AccessKeys.Current(), "非对称签名方法不应该使用环境变量密钥")
+		suite.Nil(svc.LoadKeys(context.Background()), "没有密钥时应该自动生成")
+		suite.Equal(method, jwtConf.AccessKeys.Current().Method.Alg())
+
+		ctx := context.Background()
+		u := auth.UserInfo{UserID: 1, Username: "admin", RoleID: 1}
+		access, err := auth.NewAccessJWT(ctx, jwtConf, u)
+		suite.NoError(err)
+		refresh, err := auth.NewRefreshJWT(ctx, jwtConf, u)
+		suite.NoError(err)
+
+		// 其他实例从数据库加载同样的密钥后可以验证令牌
+		other, otherConf := suite.newService(method)
+		suite.Nil(other.LoadKeys(ctx))
+		claims, rErr := auth.ParseAccessToken(ctx, otherConf, access)
+		suite.Nil(rErr, "%s签发的访问令牌应该可以验证", method)
+		suite.Equal("admin", claims.Username)
+		_, rErr = auth.ParseRefreshToken(ctx, otherConf, refresh)
+		suite.Nil(rErr, "%s签发的刷新令牌应该可以验证", method)
+
+		_, ms, lErr := suite.keyRepo.ListModel(ctx, database.QueryParams{})
+		suite.NoError(lErr)
+		suite.Len(*ms, 2, "两种令牌各生成一个密钥")
+		suite.Contains((*ms)[0].PublicKey, "PUBLIC KEY")
+	}
+}
+
+func (suite *SigningKeyServiceTestSuite) TestAlgorithmMismatch() {
+	svc, jwtConf := suite.newService("HS256")
+	suite.Nil(svc.LoadKeys(context.Background()))
+	token, err := auth.NewAccessJWT(context.Background(), jwtConf, auth.UserInfo{Username: "admin"})
+	suite.NoError(err)
+
+	// 使用同一密钥但不同签名方法的令牌不应该通过验证
+	claims := auth.UserClaims{Type: auth.TokenTypeAccess}
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS512, claims)
+	forged.Header[auth.KeyIDHeader] = jwtConf.AccessKeys.Current().ID
+	forgedString, err := forged.SignedString([]byte("test_access_secret"))
+	suite.NoError(err)
+	_, rErr := auth.ParseAccessToken(context.Background(), jwtConf, forgedString)
+	suite.NotNil(rErr, "签名方法与密钥不一致的令牌应该被拒绝")
+
+	_, rErr = auth.ParseAccessToken(context.Background(), jwtConf, token)
+	suite.Nil(rErr)
+}
+
+func (suite *SigningKeyServiceTestSuite) TestSecretLifecycle() {
+	ctx := context.Background()
+	svc, jwtConf := suite.newService("HS256")
+	secrets := config.NewSecretManager()
+	jwtConf.Secrets = secrets
+	suite.Nil(svc.LoadKeys(ctx))
+
+	ms, rErr := svc.RotateKeys(ctx, []auth.TokenType{auth.TokenTypeAccess}, false, "admin")
+	suite.Require().Nil(rErr)
+	kid := ms[0].KeyID
+	suite.Contains(secrets.ActiveIDs(), "jwt."+kid, "新密钥应该登记到密钥管理")
+	material := jwtConf.AccessKeys.Current().SignKey.([]byte)
+	value := string(material)
+
+	// 日志中出现的密钥应该被替换
+	core, logs := observer.New(zap.InfoLevel)
+	logger := zap.New(secrets.WrapCore(core))
+	logger.Info("密钥"+value, zap.String("key", value), zap.Error(fmt.Errorf("签名失败: %s", value)))
+	entry := logs.All()[0]
+	suite.NotContains(entry.Message, value)
+	for _, v := range entry.ContextMap() {
+		suite.NotContains(fmt.Sprint(v), value, "日志字段不应该包含密钥")
+	}
+	secret, ok := secrets.Get("jwt." + kid)
+	suite.Require().True(ok)
+	suite.NotContains(fmt.Sprintf("%v %+v %#v", secret, secret, secret), value, "格式化输出不应该包含密钥")
+
+	// 重新加载时沿用已有的密钥内容
+	suite.Nil(svc.RefreshKeys(ctx))
+	reloaded := jwtConf.AccessKeys.Current().SignKey.([]byte)
+	suite.Same(&material[0], &reloaded[0], "重新加载后不应该保留同一密钥的多份副本")
+
+	// 立即停用后旧密钥内容应该清零
+	_, rErr = svc.RotateKeys(ctx, []auth.TokenType{auth.TokenTypeAccess}, true, "admin")
+	suite.Require().Nil(rErr)
+	suite.Equal(make([]byte, len(material)), material, "移除的密钥应该清零")
+	suite.NotContains(secrets.ActiveIDs(), "jwt."+kid)
+	suite.Contains(secrets.ActiveIDs(), "jwt."+jwtConf.AccessKeys.Current().ID)
+}
+
+func TestSigningKeyServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(SigningKeyServiceTestSuite))
+}
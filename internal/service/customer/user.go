@@ -12,6 +12,8 @@ import (
 	"gin-artweb/internal/shared/ctxutil"
 	"gin-artweb/internal/shared/database"
 	"gin-artweb/internal/shared/errors"
+	"gin-artweb/internal/shared/events"
+	"gin-artweb/internal/shared/metrics"
 	"gin-artweb/pkg/crypto"
 )
 
@@ -19,6 +21,8 @@ type SecuritySettings struct {
 	MaxFailedAttempts int           `yaml:"max_failed_attempts"` // 最大登录失败次数
 	LockDuration      time.Duration `yaml:"lock_minutes"`        // 锁定时长(分钟)
 	PasswordStrength  int           `yaml:"password_strength"`   // 密码强度等级
+	DisableLocalLogin bool          `yaml:"disable_local_login"` // 是否关闭本地用户名密码登录
+	CaptchaThreshold  int           `yaml:"captcha_threshold"`   // 登录失败达到该次数后需要验证码, 为0时不启用
 }
 
 type UserService struct {
@@ -29,6 +33,10 @@ type UserService struct {
 	hasher     crypto.Hasher
 	jwt        *auth.JWTConfig
 	sec        SecuritySettings
+	outbox     *events.Outbox
+	sessions   *SessionService
+	captcha    *CaptchaService
+	tx         *database.TxManager
 }
 
 func NewUserService(
@@ -39,6 +47,10 @@ func NewUserService(
 	hasher crypto.Hasher,
 	jwt *auth.JWTConfig,
 	sec SecuritySettings,
+	outbox *events.Outbox,
+	sessions *SessionService,
+	captcha *CaptchaService,
+	tx *database.TxManager,
 ) *UserService {
 	return &UserService{
 		log:        log,
@@ -48,6 +60,10 @@ func NewUserService(
 		hasher:     hasher,
 		jwt:        jwt,
 		sec:        sec,
+		outbox:     outbox,
+		sessions:   sessions,
+		captcha:    captcha,
+		tx:         tx,
 	}
 }
 
@@ -59,27 +75,24 @@ func (s *UserService) GetRole(
 		return nil, errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始查询用户关联的角色",
 		zap.Uint32("role_id", roleID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	m, err := s.roleRepo.GetModel(ctx, nil, roleID)
 	if err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"查询用户关联的角色失败",
 			zap.Error(err),
 			zap.Uint32("role_id", roleID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.NewGormError(err, map[string]any{"role_id": roleID})
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"查询用户关联的角色成功",
 		zap.Uint32("role_id", roleID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return m, nil
 }
@@ -92,17 +105,23 @@ func (s *UserService) CreateUser(
 		return nil, errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始创建用户",
 		zap.Object(database.ModelKey, &m),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	// 检查密码强度
 	if err := s.validatePasswordStrength(ctx, m.Password); err != nil {
 		return nil, err
 	}
+	return s.createUser(ctx, m)
+}
 
+// createUser 哈希密码并创建用户, 调用方负责校验密码强度
+func (s *UserService) createUser(
+	ctx context.Context,
+	m custmodel.UserModel,
+) (*custmodel.UserModel, *errors.Error) {
 	// 密码哈希
 	if password, err := s.hashPassword(ctx, m.Password); err != nil {
 		return nil, err
@@ -110,29 +129,39 @@ func (s *UserService) CreateUser(
 		m.Password = password
 	}
 
-	// 获取角色信息
-	if rm, err := s.GetRole(ctx, m.RoleID); err != nil {
-		return nil, err
-	} else {
+	// 查询角色和创建用户在同一事务中执行, 用户创建事件在同一事务中写入发件箱
+	rErr := database.Transactional(ctx, s.tx, func(ctx context.Context) *errors.Error {
+		rm, rErr := s.GetRole(ctx, m.RoleID)
+		if rErr != nil {
+			return rErr
+		}
 		m.Role = *rm
-	}
 
-	// 创建用户
-	if err := s.userRepo.CreateModel(ctx, &m); err != nil {
-		s.log.Error(
-			"创建用户失败",
-			zap.Error(err),
-			zap.String("username", m.Username),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
-		)
-		return nil, errors.NewGormError(err, nil)
+		txCtx := s.outbox.Stage(ctx, func() events.Payload {
+			return custmodel.UserCreatedEvent{ID: m.ID, Username: m.Username, RoleID: m.RoleID}
+		})
+		if err := s.userRepo.CreateModel(txCtx, &m); err != nil {
+			ctxutil.Logger(ctx, s.log).Error(
+				"创建用户失败",
+				zap.Error(err),
+				zap.String("username", m.Username),
+			)
+			return errors.NewGormError(err, nil)
+		}
+		return nil
+	}, func(err error) *errors.Error {
+		return errors.NewGormError(err, nil)
+	})
+	if rErr != nil {
+		return nil, rErr
 	}
 
-	s.log.Info(
+	s.outbox.Notify()
+
+	ctxutil.Logger(ctx, s.log).Info(
 		"创建用户成功",
 		zap.String("username", m.Username),
 		zap.Uint32("user_id", m.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return &m, nil
 }
@@ -146,75 +175,82 @@ func (s *UserService) UpdateUserByID(
 		return errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始更新用户",
 		zap.Uint32("user_id", userID),
 		zap.Any(database.UpdateDataKey, data),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	// 处理密码更新
 	if password, exists := data["password"]; exists {
 		if pwdStr, ok := password.(string); ok {
-			s.log.Info(
+			ctxutil.Logger(ctx, s.log).Info(
 				"检测到密码更新，开始验证密码强度",
 				zap.Uint32("user_id", userID),
-				zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			)
 
 			if err := s.validatePasswordStrength(ctx, pwdStr); err != nil {
-				s.log.Warn(
+				ctxutil.Logger(ctx, s.log).Warn(
 					"密码强度不足",
 					zap.Uint32("user_id", userID),
-					zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 				)
 				return err
 			}
 
 			hashed, err := s.hashPassword(ctx, pwdStr)
 			if err != nil {
-				s.log.Error(
+				ctxutil.Logger(ctx, s.log).Error(
 					"密码哈希失败",
 					zap.Error(err),
 					zap.Uint32("user_id", userID),
-					zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 				)
 				return err
 			}
 			data["password"] = hashed
 
-			s.log.Info(
+			ctxutil.Logger(ctx, s.log).Info(
 				"密码哈希处理完成",
 				zap.Uint32("user_id", userID),
-				zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			)
 		} else {
-			s.log.Warn(
+			ctxutil.Logger(ctx, s.log).Warn(
 				"密码不是字符串类型，已删除",
 				zap.Any("password", password),
 				zap.Uint32("user_id", userID),
-				zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			)
 			delete(data, "password")
 		}
 	}
 
+	// 宽限期内重新启用时取消计划的永久停用, 永久停用的用户不能重新启用
+	if active, ok := data["is_active"].(bool); ok && active {
+		m, err := s.userRepo.GetModel(ctx, nil, userID)
+		if err != nil {
+			return errors.NewGormError(err, map[string]any{"id": userID})
+		}
+		if m.DisabledAt != nil {
+			return errors.ErrUserDisabled
+		}
+		if m.DeactivatedAt != nil {
+			data["deactivated_at"] = nil
+			data["disable_at"] = nil
+		}
+	}
+
 	// 更新用户信息
 	if err := s.userRepo.UpdateModel(ctx, data, "id = ?", userID); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"更新用户失败",
 			zap.Error(err),
 			zap.Uint32("user_id", userID),
 			zap.Any(database.UpdateDataKey, data),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return errors.NewGormError(err, data)
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"更新用户成功",
 		zap.Uint32("user_id", userID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return nil
 }
@@ -227,26 +263,23 @@ func (s *UserService) DeleteUserByID(
 		return errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始删除用户",
 		zap.Uint32("user_id", userID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	if err := s.userRepo.DeleteModel(ctx, userID); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"删除用户失败",
 			zap.Error(err),
 			zap.Uint32("user_id", userID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return errors.NewGormError(err, map[string]any{"id": userID})
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"删除用户成功",
 		zap.Uint32("user_id", userID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return nil
 }
@@ -260,29 +293,26 @@ func (s *UserService) FindUserByID(
 		return nil, errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始根据ID查询用户",
 		zap.Uint32("user_id", userID),
 		zap.Strings(database.PreloadKey, preloads),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	m, err := s.userRepo.GetModel(ctx, preloads, userID)
 	if err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"根据ID查询用户失败",
 			zap.Error(err),
 			zap.Uint32("user_id", userID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.NewGormError(err, map[string]any{"id": userID})
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"根据ID查询用户成功",
 		zap.Uint32("user_id", userID),
 		zap.String("username", m.Username),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return m, nil
 }
@@ -296,29 +326,26 @@ func (s *UserService) FindUserByName(
 		return nil, errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始根据用户名查询用户",
 		zap.String("username", username),
 		zap.Strings(database.PreloadKey, preloads),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	m, err := s.userRepo.GetModel(ctx, preloads, map[string]any{"username": username})
 	if err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"根据用户名查询用户失败",
 			zap.Error(err),
 			zap.String("username", username),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.NewGormError(err, map[string]any{"username": username})
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"根据用户名查询用户成功",
 		zap.String("username", username),
 		zap.Uint32("user_id", m.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return m, nil
 }
@@ -331,28 +358,25 @@ func (s *UserService) ListUser(
 		return 0, nil, errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始查询用户列表",
 		zap.Object(database.QueryParamsKey, &qp),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	count, ms, err := s.userRepo.ListModel(ctx, qp)
 	if err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"查询用户列表失败",
 			zap.Error(err),
 			zap.Object(database.QueryParamsKey, &qp),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return 0, nil, errors.NewGormError(err, nil)
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"查询用户列表成功",
 		zap.Int64("total_count", count),
 		zap.Int("result_count", len(*ms)),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return count, ms, nil
 }
@@ -365,27 +389,24 @@ func (s *UserService) ListLoginRecord(
 		return 0, nil, errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始查询用户登录记录列表",
 		zap.Object(database.QueryParamsKey, &qp),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	count, ms, err := s.recordRepo.ListModel(ctx, qp)
 	if err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"查询用户登录记录列表失败",
 			zap.Error(err),
 			zap.Object(database.QueryParamsKey, &qp),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return 0, nil, errors.NewGormError(err, nil)
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"查询用户登录记录列表成功",
 		zap.Object(database.QueryParamsKey, &qp),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return count, ms, nil
 }
@@ -398,26 +419,23 @@ func (s *UserService) createLoginRecord(
 		return nil, errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始创建用户登录记录",
 		zap.Object(database.ModelKey, &m),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	if err := s.recordRepo.CreateModel(ctx, &m); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"创建用户登录记录失败",
 			zap.Error(err),
 			zap.Object(database.ModelKey, &m),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.NewGormError(err, nil)
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"创建用户登录记录成功",
 		zap.Object(database.ModelKey, &m),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return &m, nil
 }
@@ -428,23 +446,32 @@ func (s *UserService) Login(
 	password string,
 	ipAddress string,
 	userAgent string,
+	captchaID string,
+	captchaAnswer string,
 ) (string, string, *errors.Error) {
 	if ctx.Err() != nil {
 		return "", "", errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始刷新令牌",
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"用户登录请求",
 		zap.String("username", username),
 		zap.String("ip_address", ipAddress),
 		zap.String("user_agent", userAgent),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
+
+	if s.sec.DisableLocalLogin {
+		ctxutil.Logger(ctx, s.log).Warn(
+			"本地用户名密码登录已关闭",
+			zap.String("username", username),
+		)
+		return "", "", errors.ErrLocalLoginDisabled
+	}
+
 	lrm := custmodel.LoginRecordModel{
 		Username:  username,
 		LoginAt:   time.Now(),
@@ -454,11 +481,13 @@ func (s *UserService) Login(
 	}
 
 	// 验证登录信息
-	m, rErr := s.validateLogin(ctx, username, password, ipAddress)
+	m, rErr := s.validateLogin(ctx, username, password, ipAddress, captchaID, captchaAnswer)
 	if rErr != nil {
+		metrics.LoginTotal.WithLabelValues(metrics.LoginFailure).Inc()
 		s.createLoginRecord(ctx, lrm)
 		return "", "", rErr
 	}
+	metrics.LoginTotal.WithLabelValues(metrics.LoginSuccess).Inc()
 
 	// 登录认证成功
 	lrm.Status = true
@@ -468,31 +497,26 @@ func (s *UserService) Login(
 	s.setLoginFailNum(ctx, ipAddress, s.sec.MaxFailedAttempts)
 
 	userinfo := auth.UserInfo{
-		Username: username,
-		UserID:   m.ID,
-		RoleID:   m.RoleID,
-		IsStaff:  m.IsStaff,
+		Username:           username,
+		UserID:             m.ID,
+		RoleID:             m.RoleID,
+		IsStaff:            m.IsStaff,
+		MustChangePassword: m.MustChangePassword,
 	}
 
-	// 生成JWT token
-	accessToken, rErr := s.newAccessJWT(ctx, userinfo)
+	// 创建会话并生成JWT token
+	pair, rErr := s.sessions.Issue(ctx, userinfo, custmodel.SessionMethodPassword, ipAddress, userAgent)
 	if rErr != nil {
 		return "", "", rErr
 	}
 
-	refreshToken, rErr := s.newRefreshJWT(ctx, userinfo)
-	if rErr != nil {
-		return "", "", rErr
-	}
-
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"用户登录成功",
 		zap.String("username", username),
 		zap.Uint32("user_id", m.ID),
 		zap.String("ip_address", ipAddress),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
-	return accessToken, refreshToken, nil
+	return pair.AccessToken, pair.RefreshToken, nil
 }
 
 func (s *UserService) validateLogin(
@@ -500,148 +524,193 @@ func (s *UserService) validateLogin(
 	username string,
 	password string,
 	ipAddress string,
+	captchaID string,
+	captchaAnswer string,
 ) (*custmodel.UserModel, *errors.Error) {
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始验证用户登录信息",
 		zap.String("username", username),
 		zap.String("ip_address", ipAddress),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	// 检查登录失败次数
-	s.log.Debug(
+	ctxutil.Logger(ctx, s.log).Debug(
 		"检查登录失败次数",
 		zap.String("username", username),
 		zap.String("ip_address", ipAddress),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	num, rErr := s.getLoginFailNum(ctx, username, ipAddress)
 	if rErr != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"获取登录失败次数失败",
 			zap.Error(rErr),
 			zap.String("username", username),
 			zap.String("ip_address", ipAddress),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, rErr
 	}
 
-	s.log.Debug(
+	ctxutil.Logger(ctx, s.log).Debug(
 		"获取登录失败次数成功",
 		zap.String("username", username),
 		zap.String("ip_address", ipAddress),
 		zap.Int("remaining_attempts", num),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	if num == 0 {
-		s.log.Warn(
+		ctxutil.Logger(ctx, s.log).Warn(
 			"登录尝试次数用尽，账户被锁定",
 			zap.String("username", username),
 			zap.String("ip_address", ipAddress),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.ErrAccountLocked
 	}
 
+	// 失败次数达到阈值后需要先通过验证码, 验证码错误不计入失败次数
+	if s.captchaRequired(num) {
+		if captchaID == "" {
+			ctxutil.Logger(ctx, s.log).Warn(
+				"登录失败次数过多, 需要验证码",
+				zap.String("username", username),
+				zap.String("ip_address", ipAddress),
+				zap.Int("remaining_attempts", num),
+			)
+			return nil, errors.ErrCaptchaRequired.WithField("remaining_attempts", num)
+		}
+		if rErr := s.captcha.Verify(ctx, captchaID, captchaAnswer); rErr != nil {
+			return nil, rErr.WithFields(map[string]any{
+				"remaining_attempts": num,
+				"captcha_required":   true,
+			})
+		}
+	}
+
 	// 查找用户
-	s.log.Debug(
+	ctxutil.Logger(ctx, s.log).Debug(
 		"开始查找用户",
 		zap.String("username", username),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	m, rErr := s.FindUserByName(ctx, []string{"Role"}, username)
 	if rErr != nil {
-		s.log.Warn(
+		ctxutil.Logger(ctx, s.log).Warn(
 			"用户不存在或查找失败",
 			zap.Error(rErr),
 			zap.String("username", username),
 			zap.String("ip_address", ipAddress),
 			zap.Int("remaining_attempts", num-1),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		// 更新失败次数
 		s.setLoginFailNum(ctx, ipAddress, num-1)
-		return nil, errors.ErrAuthFailed
+		return nil, errors.ErrAuthFailed.WithField("captcha_required", s.captchaRequired(num-1))
 	}
 
-	s.log.Debug(
+	ctxutil.Logger(ctx, s.log).Debug(
 		"用户查找成功",
 		zap.String("username", username),
 		zap.Uint32("user_id", m.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	// 检查用户状态
 	if !m.IsActive {
-		s.log.Warn(
+		ctxutil.Logger(ctx, s.log).Warn(
 			"用户账户被锁定",
 			zap.String("username", username),
 			zap.Uint32("user_id", m.ID),
 			zap.String("ip_address", ipAddress),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.ErrAccountLocked
 	}
 
 	// 验证密码
-	s.log.Debug(
+	ctxutil.Logger(ctx, s.log).Debug(
 		"开始验证用户密码",
 		zap.String("username", username),
 		zap.Uint32("user_id", m.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	if rErr = s.verifyPassword(ctx, password, m.Password); rErr != nil {
-		s.log.Warn(
+		ctxutil.Logger(ctx, s.log).Warn(
 			"用户密码验证失败",
 			zap.Error(rErr),
 			zap.String("username", username),
 			zap.Uint32("user_id", m.ID),
 			zap.String("ip_address", ipAddress),
 			zap.Int("remaining_attempts", num-1),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		s.setLoginFailNum(ctx, ipAddress, num-1)
-		return nil, rErr.WithField("remaining_attempts", num-1)
+		return nil, rErr.WithFields(map[string]any{
+			"remaining_attempts": num - 1,
+			"captcha_required":   s.captchaRequired(num - 1),
+		})
 	}
 
-	s.log.Info(
+	s.rehashPassword(ctx, m, password)
+
+	ctxutil.Logger(ctx, s.log).Info(
 		"用户登录验证成功",
 		zap.String("username", username),
 		zap.Uint32("user_id", m.ID),
 		zap.String("ip_address", ipAddress),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	return m, nil
 }
 
+// rehashPassword 登录成功后, 密码哈希不是使用当前的算法和参数生成时重新哈希
+//
+// 重新哈希失败不影响登录, 下次登录时再次尝试
+func (s *UserService) rehashPassword(ctx context.Context, m *custmodel.UserModel, password string) {
+	rehasher, ok := s.hasher.(crypto.Rehasher)
+	if !ok || !rehasher.NeedsRehash(m.Password) {
+		return
+	}
+	hashed, rErr := s.hashPassword(ctx, password)
+	if rErr != nil {
+		return
+	}
+	data := map[string]any{"password": hashed}
+	if err := s.userRepo.UpdateModel(ctx, data, "id = ?", m.ID); err != nil {
+		ctxutil.Logger(ctx, s.log).Warn(
+			"更新用户密码哈希失败",
+			zap.Error(err),
+			zap.Uint32("user_id", m.ID),
+		)
+		return
+	}
+	m.Password = hashed
+	ctxutil.Logger(ctx, s.log).Info(
+		"用户密码已按当前算法重新哈希",
+		zap.Uint32("user_id", m.ID),
+	)
+}
+
+// captchaRequired 剩余登录次数为remaining时是否需要验证码
+func (s *UserService) captchaRequired(remaining int) bool {
+	return s.sec.CaptchaThreshold > 0 && s.sec.MaxFailedAttempts-remaining >= s.sec.CaptchaThreshold
+}
+
 func (s *UserService) getLoginFailNum(ctx context.Context, username string, ipAddress string) (int, *errors.Error) {
 	if ctx.Err() != nil {
 		return 0, errors.FromError(ctx.Err())
 	}
 	num, err := s.recordRepo.GetLoginFailNum(ctx, ipAddress)
 	if err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"获取登录失败次数失败",
 			zap.Error(err),
 			zap.String("username", username),
 			zap.String("ip_address", ipAddress),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return 0, errors.FromError(err)
 	}
 
 	if num <= 0 {
-		s.log.Warn(
+		ctxutil.Logger(ctx, s.log).Warn(
 			"登录失败次数超限，账户被锁定",
 			zap.String("username", username),
 			zap.String("ip_address", ipAddress),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return 0, nil
 	}
@@ -657,85 +726,47 @@ func (s *UserService) setLoginFailNum(
 		return errors.FromError(ctx.Err())
 	}
 	if err := s.recordRepo.SetLoginFailNum(ctx, ipAddress, num); err != nil {
-		s.log.Warn(
+		ctxutil.Logger(ctx, s.log).Warn(
 			"重置登录失败次数失败",
 			zap.Error(err),
 			zap.String("ip_address", ipAddress),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return errors.FromError(err)
 	}
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"登录失败次数已重置",
 		zap.String("ip_address", ipAddress),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return nil
 }
 
-func (s *UserService) newAccessJWT(ctx context.Context, ui auth.UserInfo) (string, *errors.Error) {
-	if ctx.Err() != nil {
-		return "", errors.FromError(ctx.Err())
-	}
-	token, err := auth.NewAccessJWT(ctx, s.jwt, ui)
-	if err != nil {
-		s.log.Error(
-			"生成JWT token失败",
-			zap.Error(err),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
-		)
-		return "", errors.FromError(err)
-	}
-	return token, nil
-}
-
-func (s *UserService) newRefreshJWT(ctx context.Context, ui auth.UserInfo) (string, *errors.Error) {
-	if ctx.Err() != nil {
-		return "", errors.FromError(ctx.Err())
-	}
-	token, err := auth.NewRefreshJWT(ctx, s.jwt, ui)
-	if err != nil {
-		s.log.Error(
-			"生成JWT token失败",
-			zap.Error(err),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
-		)
-		return "", errors.FromError(err)
-	}
-	return token, nil
-}
-
 func (s *UserService) verifyPassword(ctx context.Context, pwd, hash string) *errors.Error {
 	if ctx.Err() != nil {
 		return errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始密码验证",
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	verified, err := s.hasher.Verify(ctx, pwd, hash)
 	if err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"密码验证过程中发生错误",
 			zap.Error(err),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return errors.ErrAuthFailed
 	}
 
 	if !verified {
-		s.log.Warn(
+		ctxutil.Logger(ctx, s.log).Warn(
 			"密码验证失败",
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return errors.ErrAuthFailed
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"密码验证通过",
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return nil
 }
@@ -745,24 +776,21 @@ func (s *UserService) hashPassword(ctx context.Context, pwd string) (string, *er
 		return "", errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始密码哈希处理",
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	verified, err := s.hasher.Hash(ctx, pwd)
 	if err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"密码哈希失败",
 			zap.Error(err),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return "", errors.FromError(err)
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"密码哈希处理完成",
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return verified, nil
 }
@@ -772,25 +800,22 @@ func (s *UserService) validatePasswordStrength(ctx context.Context, pwd string)
 		return errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始检查密码强度",
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	strength := GetPasswordStrength(pwd)
 	if strength < s.sec.PasswordStrength {
-		s.log.Warn(
+		ctxutil.Logger(ctx, s.log).Warn(
 			"密码强度不足",
 			zap.Int("password_strength", strength),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return errors.ErrPasswordStrengthFailed
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"密码强度检查通过",
 		zap.Int("password_strength", strength),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return nil
 }
@@ -807,56 +832,79 @@ func (s *UserService) PatchPassword(
 	// 检查旧密码是否正确
 	m, rErr := s.FindUserByID(ctx, []string{"Role"}, userID)
 	if rErr != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"获取用户信息失败",
 			zap.Error(rErr),
 			zap.Uint32("user_id", userID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return rErr
 	}
 	if rErr = s.verifyPassword(ctx, oldPassword, m.Password); rErr != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"旧密码验证失败",
 			zap.Error(rErr),
 			zap.Uint32("user_id", userID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return rErr
 	}
-	return s.UpdateUserByID(ctx, userID, map[string]any{"password": newPassword})
+	return s.UpdateUserByID(ctx, userID, map[string]any{
+		"password":             newPassword,
+		"must_change_password": false,
+	})
 }
 
 func (s *UserService) RefreshTokens(
 	ctx context.Context,
 	refresh string,
+	ipAddress string,
+	userAgent string,
 ) (string, string, *errors.Error) {
 	if ctx.Err() != nil {
 		return "", "", errors.FromError(ctx.Err())
 	}
 
-	var (
-		accessToken  string
-		refreshToken string
-		rErr         *errors.Error
-	)
-
 	claims, err := auth.ParseRefreshToken(ctx, s.jwt, refresh)
 	if err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"解析刷新令牌失败",
 			zap.Error(err),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return "", "", errors.ErrTokenInvalid
 	}
-	accessToken, rErr = s.newAccessJWT(ctx, claims.UserInfo)
-	if rErr != nil {
-		return "", "", rErr
+	// 修改初始密码后通过刷新令牌解除限制
+	if claims.MustChangePassword {
+		m, rErr := s.FindUserByID(ctx, nil, claims.UserID)
+		if rErr != nil {
+			return "", "", rErr
+		}
+		claims.MustChangePassword = m.MustChangePassword
 	}
-	refreshToken, rErr = s.newRefreshJWT(ctx, claims.UserInfo)
+	pair, rErr := s.sessions.Renew(ctx, claims, ipAddress, userAgent)
 	if rErr != nil {
 		return "", "", rErr
 	}
-	return accessToken, refreshToken, nil
+	return pair.AccessToken, pair.RefreshToken, nil
+}
+
+// Logout 退出登录, 终止令牌所属的会话
+//
+// 优先按刷新令牌确定会话, 刷新令牌为空或无效时使用访问令牌;
+// 两个令牌都无效时会话已无法使用, 不做处理
+func (s *UserService) Logout(ctx context.Context, refresh, access string) *errors.Error {
+	if ctx.Err() != nil {
+		return errors.FromError(ctx.Err())
+	}
+
+	var claims *auth.UserClaims
+	if refresh != "" {
+		claims, _ = auth.ParseRefreshToken(ctx, s.jwt, refresh)
+	}
+	if claims == nil && access != "" {
+		claims, _ = auth.ParseAccessToken(ctx, s.jwt, access)
+	}
+	if claims == nil {
+		ctxutil.Logger(ctx, s.log).Info("退出登录时令牌已失效, 不需要终止会话")
+		return nil
+	}
+	return s.sessions.Revoke(ctx, claims)
 }
@@ -0,0 +1,506 @@
+package customer
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	custmodel "gin-artweb/internal/model/customer"
+	custrepo "gin-artweb/internal/repository/customer"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+)
+
+// UserGroupService 用户组管理, 用户组关联多个角色, 组内用户在直属角色之外继承用户组的全部角色
+type UserGroupService struct {
+	log       *zap.Logger
+	groupRepo *custrepo.UserGroupRepo
+	roleRepo  *custrepo.RoleRepo
+	userRepo  *custrepo.UserRepo
+}
+
+func NewUserGroupService(
+	log *zap.Logger,
+	groupRepo *custrepo.UserGroupRepo,
+	roleRepo *custrepo.RoleRepo,
+	userRepo *custrepo.UserRepo,
+) *UserGroupService {
+	return &UserGroupService{
+		log:       log,
+		groupRepo: groupRepo,
+		roleRepo:  roleRepo,
+		userRepo:  userRepo,
+	}
+}
+
+// GetRoles 按ID列表查询用户组关联的角色
+func (s *UserGroupService) GetRoles(
+	ctx context.Context,
+	roleIDs []uint32,
+) (*[]custmodel.RoleModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	if len(roleIDs) == 0 {
+		return &[]custmodel.RoleModel{}, nil
+	}
+
+	_, ms, err := s.roleRepo.ListModel(ctx, database.QueryParams{
+		Query: map[string]any{"id in ?": roleIDs},
+	})
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询用户组关联的角色列表失败",
+			zap.Error(err),
+			zap.Uint32s("role_ids", roleIDs),
+		)
+		return nil, errors.NewGormError(err, nil)
+	}
+	return ms, nil
+}
+
+// GetUsers 按ID列表查询用户组成员
+func (s *UserGroupService) GetUsers(
+	ctx context.Context,
+	userIDs []uint32,
+) (*[]custmodel.UserModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	if len(userIDs) == 0 {
+		return &[]custmodel.UserModel{}, nil
+	}
+
+	_, ms, err := s.userRepo.ListModel(ctx, database.QueryParams{
+		Query: map[string]any{"id in ?": userIDs},
+	})
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询用户组成员列表失败",
+			zap.Error(err),
+			zap.Uint32s("user_ids", userIDs),
+		)
+		return nil, errors.NewGormError(err, nil)
+	}
+	return ms, nil
+}
+
+func (s *UserGroupService) CreateUserGroup(
+	ctx context.Context,
+	roleIDs []uint32,
+	userIDs []uint32,
+	m custmodel.UserGroupModel,
+) (*custmodel.UserGroupModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始创建用户组",
+		zap.Uint32s("role_ids", roleIDs),
+		zap.Uint32s("user_ids", userIDs),
+		zap.Object(database.ModelKey, &m),
+	)
+
+	roles, rErr := s.GetRoles(ctx, roleIDs)
+	if rErr != nil {
+		return nil, rErr
+	}
+	users, rErr := s.GetUsers(ctx, userIDs)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	if err := s.groupRepo.CreateModel(ctx, &m, roles, users); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"创建用户组失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, &m),
+		)
+		return nil, errors.NewGormError(err, nil)
+	}
+	m.Roles = *roles
+	m.Users = *users
+
+	if err := s.groupRepo.AddGroupPolicy(ctx, &m); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"添加用户组组策略失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, &m),
+		)
+		return nil, errors.FromError(err)
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"创建用户组成功",
+		zap.Object(database.ModelKey, &m),
+	)
+	return &m, nil
+}
+
+func (s *UserGroupService) UpdateUserGroupByID(
+	ctx context.Context,
+	groupID uint32,
+	roleIDs []uint32,
+	userIDs []uint32,
+	data map[string]any,
+) (*custmodel.UserGroupModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始更新用户组",
+		zap.Uint32("group_id", groupID),
+		zap.Uint32s("role_ids", roleIDs),
+		zap.Uint32s("user_ids", userIDs),
+		zap.Any(database.UpdateDataKey, data),
+	)
+
+	roles, rErr := s.GetRoles(ctx, roleIDs)
+	if rErr != nil {
+		return nil, rErr
+	}
+	users, rErr := s.GetUsers(ctx, userIDs)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	data["id"] = groupID
+	if err := s.groupRepo.UpdateModel(ctx, data, roles, users, "id = ?", groupID); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"更新用户组失败",
+			zap.Error(err),
+			zap.Uint32("group_id", groupID),
+			zap.Any(database.UpdateDataKey, data),
+		)
+		return nil, errors.NewGormError(err, data)
+	}
+
+	m, rErr := s.reloadGroupPolicy(ctx, groupID)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"更新用户组成功",
+		zap.Uint32("group_id", groupID),
+	)
+	return m, nil
+}
+
+// AddUserGroupMembers 将用户加入用户组, 已在组内的用户保持不变
+func (s *UserGroupService) AddUserGroupMembers(
+	ctx context.Context,
+	groupID uint32,
+	userIDs []uint32,
+) (*custmodel.UserGroupModel, *errors.Error) {
+	return s.updateUserGroupMembers(ctx, groupID, userIDs, true)
+}
+
+// RemoveUserGroupMembers 将用户移出用户组
+func (s *UserGroupService) RemoveUserGroupMembers(
+	ctx context.Context,
+	groupID uint32,
+	userIDs []uint32,
+) (*custmodel.UserGroupModel, *errors.Error) {
+	return s.updateUserGroupMembers(ctx, groupID, userIDs, false)
+}
+
+func (s *UserGroupService) updateUserGroupMembers(
+	ctx context.Context,
+	groupID uint32,
+	userIDs []uint32,
+	add bool,
+) (*custmodel.UserGroupModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始更新用户组成员",
+		zap.Uint32("group_id", groupID),
+		zap.Uint32s("user_ids", userIDs),
+		zap.Bool("add", add),
+	)
+
+	m, rErr := s.FindUserGroupByID(ctx, nil, groupID)
+	if rErr != nil {
+		return nil, rErr
+	}
+	users, rErr := s.GetUsers(ctx, userIDs)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	var err error
+	if add {
+		err = s.groupRepo.AddMembers(ctx, m, *users)
+	} else {
+		err = s.groupRepo.RemoveMembers(ctx, m, *users)
+	}
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"更新用户组成员失败",
+			zap.Error(err),
+			zap.Uint32("group_id", groupID),
+			zap.Uint32s("user_ids", userIDs),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"id": groupID})
+	}
+
+	m, rErr = s.reloadGroupPolicy(ctx, groupID)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"更新用户组成员成功",
+		zap.Uint32("group_id", groupID),
+		zap.Int("member_count", len(m.Users)),
+	)
+	return m, nil
+}
+
+// reloadGroupPolicy 用户组的角色或成员变更后按最新数据重建其组策略
+func (s *UserGroupService) reloadGroupPolicy(
+	ctx context.Context,
+	groupID uint32,
+) (*custmodel.UserGroupModel, *errors.Error) {
+	m, rErr := s.FindUserGroupByID(ctx, []string{"Roles", "Users"}, groupID)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	if err := s.groupRepo.RemoveGroupPolicy(ctx, m); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"移除旧用户组组策略失败",
+			zap.Error(err),
+			zap.Uint32("group_id", groupID),
+		)
+		return nil, errors.FromError(err)
+	}
+
+	if err := s.groupRepo.AddGroupPolicy(ctx, m); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"添加新用户组组策略失败",
+			zap.Error(err),
+			zap.Uint32("group_id", groupID),
+		)
+		return nil, errors.FromError(err)
+	}
+	return m, nil
+}
+
+func (s *UserGroupService) DeleteUserGroupByID(
+	ctx context.Context,
+	groupID uint32,
+) *errors.Error {
+	if ctx.Err() != nil {
+		return errors.FromError(ctx.Err())
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始删除用户组",
+		zap.Uint32("group_id", groupID),
+	)
+
+	m, rErr := s.FindUserGroupByID(ctx, nil, groupID)
+	if rErr != nil {
+		return rErr
+	}
+
+	if err := s.groupRepo.DeleteModel(ctx, groupID); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"删除用户组失败",
+			zap.Error(err),
+			zap.Uint32("group_id", groupID),
+		)
+		return errors.NewGormError(err, map[string]any{"id": groupID})
+	}
+
+	if err := s.groupRepo.RemoveGroupPolicy(ctx, m); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"移除用户组组策略失败",
+			zap.Error(err),
+			zap.Uint32("group_id", groupID),
+		)
+		return errors.FromError(err)
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"删除用户组成功",
+		zap.Uint32("group_id", groupID),
+	)
+	return nil
+}
+
+func (s *UserGroupService) FindUserGroupByID(
+	ctx context.Context,
+	preloads []string,
+	groupID uint32,
+) (*custmodel.UserGroupModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始查询用户组",
+		zap.Strings(database.PreloadKey, preloads),
+		zap.Uint32("group_id", groupID),
+	)
+
+	m, err := s.groupRepo.GetModel(ctx, preloads, groupID)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询用户组失败",
+			zap.Error(err),
+			zap.Uint32("group_id", groupID),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"id": groupID})
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"查询用户组成功",
+		zap.Uint32("group_id", groupID),
+	)
+	return m, nil
+}
+
+func (s *UserGroupService) ListUserGroup(
+	ctx context.Context,
+	qp database.QueryParams,
+) (int64, *[]custmodel.UserGroupModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return 0, nil, errors.FromError(ctx.Err())
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始查询用户组列表",
+		zap.Object(database.QueryParamsKey, &qp),
+	)
+
+	count, ms, err := s.groupRepo.ListModel(ctx, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询用户组列表失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		return 0, nil, errors.NewGormError(err, nil)
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"查询用户组列表成功",
+	)
+	return count, ms, nil
+}
+
+// EffectiveRoles 解析用户的有效角色, 合并直属角色和所属用户组的角色并去重, 直属角色排在首位
+func (s *UserGroupService) EffectiveRoles(
+	ctx context.Context,
+	userID uint32,
+	roleID uint32,
+) ([]custmodel.RoleModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	direct, err := s.roleRepo.GetModel(ctx, nil, roleID)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询用户直属角色失败",
+			zap.Error(err),
+			zap.Uint32(ctxutil.UserIDKey, userID),
+			zap.Uint32("role_id", roleID),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"id": roleID})
+	}
+
+	groups, err := s.groupRepo.ListModelByUserID(ctx, []string{"Roles"}, userID)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询用户所属用户组失败",
+			zap.Error(err),
+			zap.Uint32(ctxutil.UserIDKey, userID),
+		)
+		return nil, errors.NewGormError(err, nil)
+	}
+
+	roles := []custmodel.RoleModel{*direct}
+	seen := map[uint32]struct{}{direct.ID: {}}
+	for _, group := range groups {
+		for _, role := range group.Roles {
+			if _, ok := seen[role.ID]; ok {
+				continue
+			}
+			seen[role.ID] = struct{}{}
+			roles = append(roles, role)
+		}
+	}
+	return roles, nil
+}
+
+// EffectiveRoleIDs 解析用户的有效角色ID
+func (s *UserGroupService) EffectiveRoleIDs(
+	ctx context.Context,
+	userID uint32,
+	roleID uint32,
+) ([]uint32, *errors.Error) {
+	roles, rErr := s.EffectiveRoles(ctx, userID, roleID)
+	if rErr != nil {
+		return nil, rErr
+	}
+	ids := make([]uint32, len(roles))
+	for i, role := range roles {
+		ids[i] = role.ID
+	}
+	return ids, nil
+}
+
+func (s *UserGroupService) LoadUserGroupPolicy(ctx context.Context) *errors.Error {
+	if ctx.Err() != nil {
+		return errors.FromError(ctx.Err())
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始加载用户组策略",
+	)
+
+	_, gms, rErr := s.ListUserGroup(ctx, database.QueryParams{
+		Preloads: []string{"Roles", "Users"},
+		Columns:  []string{"id"},
+	})
+	if rErr != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"加载用户组策略时查询用户组列表失败",
+			zap.Error(rErr),
+		)
+		return rErr
+	}
+
+	var policyCount int
+	if gms != nil {
+		ms := *gms
+		policyCount = len(ms)
+		for i := range ms {
+			if err := s.groupRepo.AddGroupPolicy(ctx, &ms[i]); err != nil {
+				ctxutil.Logger(ctx, s.log).Error(
+					"加载用户组策略失败",
+					zap.Error(err),
+					zap.Uint32("group_id", ms[i].ID),
+				)
+				return errors.FromError(err)
+			}
+		}
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"加载用户组策略成功",
+		zap.Int("policy_count", policyCount),
+	)
+	return nil
+}
@@ -0,0 +1,122 @@
+package customer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/casbin/casbin/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	custmodel "gin-artweb/internal/model/customer"
+	custsvc "gin-artweb/internal/repository/customer"
+	"gin-artweb/internal/shared/auth"
+	"gin-artweb/internal/shared/test"
+)
+
+type UserGroupTestSuite struct {
+	suite.Suite
+	enforcer *casbin.Enforcer
+	roleRepo *custsvc.RoleRepo
+	userRepo *custsvc.UserRepo
+	gs       *UserGroupService
+}
+
+func (suite *UserGroupTestSuite) SetupSuite() {
+	db := test.NewTestGormDBWithConfig(nil)
+	db.AutoMigrate(
+		&custmodel.RoleModel{},
+		&custmodel.UserModel{},
+		&custmodel.UserGroupModel{},
+	)
+	dbTimeout := test.NewTestDBTimeouts()
+	logger := test.NewTestZapLogger()
+	suite.enforcer, _ = auth.NewCasbinEnforcer()
+	suite.roleRepo = custsvc.NewRoleRepo(logger, db, dbTimeout, suite.enforcer)
+	suite.userRepo = custsvc.NewUserRepo(logger, db, dbTimeout)
+	suite.gs = NewUserGroupService(
+		logger,
+		custsvc.NewUserGroupRepo(logger, db, dbTimeout, suite.enforcer),
+		suite.roleRepo, suite.userRepo,
+	)
+}
+
+func TestUserGroupTestSuite(t *testing.T) {
+	suite.Run(t, new(UserGroupTestSuite))
+}
+
+func (suite *UserGroupTestSuite) createRole() *custmodel.RoleModel {
+	role := CreateTestRoleModel()
+	suite.Require().NoError(suite.roleRepo.CreateModel(context.Background(), role, nil, nil, nil))
+	return role
+}
+
+func (suite *UserGroupTestSuite) TestEffectiveRoles() {
+	ctx := context.Background()
+	direct := suite.createRole()
+	ops := suite.createRole()
+	audit := suite.createRole()
+	user := CreateTestUserModel(direct.ID)
+	suite.Require().NoError(suite.userRepo.CreateModel(ctx, user))
+
+	// 组角色与直属角色重复时去重
+	_, rErr := suite.gs.CreateUserGroup(ctx, []uint32{ops.ID, direct.ID}, []uint32{user.ID},
+		custmodel.UserGroupModel{Name: uuid.NewString()[:20]})
+	suite.Require().Nil(rErr)
+	g2, rErr := suite.gs.CreateUserGroup(ctx, []uint32{audit.ID}, nil,
+		custmodel.UserGroupModel{Name: uuid.NewString()[:20]})
+	suite.Require().Nil(rErr)
+
+	ids, rErr := suite.gs.EffectiveRoleIDs(ctx, user.ID, direct.ID)
+	suite.Require().Nil(rErr)
+	suite.Equal([]uint32{direct.ID, ops.ID}, ids)
+
+	// 加入用户组后继承其角色
+	_, rErr = suite.gs.AddUserGroupMembers(ctx, g2.ID, []uint32{user.ID})
+	suite.Require().Nil(rErr)
+	ids, rErr = suite.gs.EffectiveRoleIDs(ctx, user.ID, direct.ID)
+	suite.Require().Nil(rErr)
+	suite.ElementsMatch([]uint32{direct.ID, ops.ID, audit.ID}, ids)
+
+	_, rErr = suite.gs.RemoveUserGroupMembers(ctx, g2.ID, []uint32{user.ID})
+	suite.Require().Nil(rErr)
+	ids, rErr = suite.gs.EffectiveRoleIDs(ctx, user.ID, direct.ID)
+	suite.Require().Nil(rErr)
+	suite.ElementsMatch([]uint32{direct.ID, ops.ID}, ids)
+}
+
+func (suite *UserGroupTestSuite) TestGroupPolicyFollowsMembership() {
+	ctx := context.Background()
+	direct := suite.createRole()
+	granted := suite.createRole()
+	user := CreateTestUserModel(direct.ID)
+	suite.Require().NoError(suite.userRepo.CreateModel(ctx, user))
+
+	obj, act := "/api/v1/customer/group", "GET"
+	_, err := suite.enforcer.AddPolicy(auth.RoleToSubject(granted.ID), obj, act)
+	suite.Require().NoError(err)
+
+	enforce := func() bool {
+		ok, err := auth.EnforceUser(suite.enforcer, user.ID, direct.ID, obj, act)
+		suite.Require().NoError(err)
+		return ok
+	}
+	suite.False(enforce())
+
+	g, rErr := suite.gs.CreateUserGroup(ctx, []uint32{granted.ID}, []uint32{user.ID},
+		custmodel.UserGroupModel{Name: uuid.NewString()[:20]})
+	suite.Require().Nil(rErr)
+	suite.True(enforce())
+
+	_, rErr = suite.gs.RemoveUserGroupMembers(ctx, g.ID, []uint32{user.ID})
+	suite.Require().Nil(rErr)
+	suite.False(enforce())
+
+	_, rErr = suite.gs.UpdateUserGroupByID(ctx, g.ID, []uint32{granted.ID}, []uint32{user.ID},
+		map[string]any{"name": g.Name})
+	suite.Require().Nil(rErr)
+	suite.True(enforce())
+
+	suite.Require().Nil(suite.gs.DeleteUserGroupByID(ctx, g.ID))
+	suite.False(enforce())
+}
@@ -2,6 +2,7 @@ package customer
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 	custsvc "gin-artweb/internal/repository/customer"
 	"gin-artweb/internal/shared/auth"
 	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
 	"gin-artweb/internal/shared/test"
 	"gin-artweb/pkg/crypto"
 )
@@ -51,10 +53,19 @@ func (suite *UserTestSuite) SetupSuite() {
 		&custmodel.RoleModel{},
 		&custmodel.UserModel{},
 		&custmodel.LoginRecordModel{},
+		&custmodel.UserSessionModel{},
 	)
 	dbTimeout := test.NewTestDBTimeouts()
 	logger := test.NewTestZapLogger()
 	enforcer, _ := auth.NewCasbinEnforcer()
+	jwtConf := auth.NewJWTConfig(
+		time.Duration(10)*time.Second,
+		time.Duration(10)*time.Minute,
+		"HS256",
+		"HS256",
+		[]byte("test_access_secret"),
+		[]byte("test_refresh_secret"),
+	)
 
 	suite.uc = &UserService{
 		log: logger,
@@ -77,20 +88,15 @@ func (suite *UserTestSuite) SetupSuite() {
 			time.Duration(10)*time.Minute,
 			2,
 		),
-		hasher: crypto.NewBcryptHasher(12),
-		jwt: auth.NewJWTConfig(
-			time.Duration(10)*time.Second,
-			time.Duration(10)*time.Minute,
-			"HS256",
-			"HS256",
-			[]byte("test_access_secret"),
-			[]byte("test_refresh_secret"),
-		),
+		hasher:   crypto.NewBcryptHasher(12),
+		jwt:      jwtConf,
+		sessions: NewSessionService(logger, custsvc.NewUserSessionRepo(logger, db, dbTimeout), jwtConf, nil),
 		sec: SecuritySettings{
 			MaxFailedAttempts: 2,
 			LockDuration:      time.Duration(5) * time.Second,
 			PasswordStrength:  3,
 		},
+		tx: database.NewTxManager(db),
 	}
 }
 
@@ -191,7 +197,7 @@ func (suite *UserTestSuite) TestListLoginRecord() {
 	suite.Nil(err, "创建用户应该成功")
 
 	// 测试登录，生成登录记录
-	_, _, err = suite.uc.Login(context.Background(), createdUser.Username, "Test123!@#$%", "127.0.0.1", "test_user_agent")
+	_, _, err = suite.uc.Login(context.Background(), createdUser.Username, "Test123!@#$%", "127.0.0.1", "test_user_agent", "", "")
 	suite.Nil(err, "登录应该成功")
 
 	// 测试查询登录记录列表
@@ -279,12 +285,46 @@ func (suite *UserTestSuite) TestLogin() {
 	suite.Nil(err, "创建用户应该成功")
 
 	// 测试登录
-	accessToken, refreshToken, err := suite.uc.Login(context.Background(), createdUser.Username, "Test123!@#$%", "127.0.0.1", "test_user_agent")
+	accessToken, refreshToken, err := suite.uc.Login(context.Background(), createdUser.Username, "Test123!@#$%", "127.0.0.1", "test_user_agent", "", "")
 	suite.Nil(err, "登录应该成功")
 	suite.NotEmpty(accessToken, "访问令牌不应该为空")
 	suite.NotEmpty(refreshToken, "刷新令牌不应该为空")
 }
 
+// TestLoginRehash 测试登录成功后按当前算法重新哈希密码
+func (suite *UserTestSuite) TestLoginRehash() {
+	testRole := CreateTestRoleModel()
+	err := suite.uc.roleRepo.CreateModel(context.Background(), testRole, nil, nil, nil)
+	suite.Nil(err, "创建角色应该成功")
+
+	testUser := CreateTestUserModel(testRole.ID)
+	createdUser, rErr := suite.uc.CreateUser(context.Background(), *testUser)
+	suite.Require().Nil(rErr, "创建用户应该成功")
+	suite.True(strings.HasPrefix(createdUser.Password, "$2a$"), "创建用户时使用bcrypt")
+
+	// 切换为argon2id后, 旧的bcrypt哈希仍然可以登录, 登录成功后重新哈希
+	hasher, hErr := crypto.NewPasswordHasher(crypto.PasswordHashOptions{
+		Algorithm:    crypto.AlgorithmArgon2id,
+		Argon2Memory: 1024,
+		Argon2Time:   1,
+	})
+	suite.Require().NoError(hErr)
+	prev := suite.uc.hasher
+	suite.uc.hasher = hasher
+	defer func() { suite.uc.hasher = prev }()
+
+	_, _, rErr = suite.uc.Login(context.Background(), createdUser.Username, "Test123!@#$%", "127.0.0.1", "test_user_agent", "", "")
+	suite.Require().Nil(rErr, "使用旧的哈希值登录应该成功")
+
+	m, rErr := suite.uc.FindUserByID(context.Background(), nil, createdUser.ID)
+	suite.Require().Nil(rErr)
+	suite.True(strings.HasPrefix(m.Password, "$argon2id$"), "登录成功后应该使用argon2id重新哈希")
+	suite.False(hasher.NeedsRehash(m.Password))
+
+	_, _, rErr = suite.uc.Login(context.Background(), createdUser.Username, "Test123!@#$%", "127.0.0.1", "test_user_agent", "", "")
+	suite.Nil(rErr, "重新哈希后登录应该成功")
+}
+
 // TestLoginWithFailedPassword 测试用户登录（密码失败场景）
 func (suite *UserTestSuite) TestLoginWithFailedPassword() {
 	// 创建测试角色
@@ -298,7 +338,7 @@ func (suite *UserTestSuite) TestLoginWithFailedPassword() {
 	suite.Nil(err, "创建用户应该成功")
 
 	// 测试登录（密码错误）
-	_, _, err = suite.uc.Login(context.Background(), createdUser.Username, "wrong_password", "127.0.0.1", "test_user_agent")
+	_, _, err = suite.uc.Login(context.Background(), createdUser.Username, "wrong_password", "127.0.0.1", "test_user_agent", "", "")
 	suite.NotNil(err, "登录应该失败")
 }
 
@@ -320,7 +360,7 @@ func (suite *UserTestSuite) TestPatchPassword() {
 	suite.Nil(err, "修改密码应该成功")
 
 	// 验证新密码可以登录
-	_, _, err = suite.uc.Login(context.Background(), createdUser.Username, newPassword, "127.0.0.1", "test_user_agent")
+	_, _, err = suite.uc.Login(context.Background(), createdUser.Username, newPassword, "127.0.0.1", "test_user_agent", "", "")
 	suite.Nil(err, "使用新密码登录应该成功")
 }
 
@@ -337,11 +377,11 @@ func (suite *UserTestSuite) TestRefreshTokens() {
 	suite.Nil(err, "创建用户应该成功")
 
 	// 登录获取令牌
-	_, refreshToken, err := suite.uc.Login(context.Background(), createdUser.Username, "Test123!@#$%", "127.0.0.1", "test_user_agent")
+	_, refreshToken, err := suite.uc.Login(context.Background(), createdUser.Username, "Test123!@#$%", "127.0.0.1", "test_user_agent", "", "")
 	suite.Nil(err, "登录应该成功")
 
 	// 刷新令牌
-	newAccessToken, newRefreshToken, err := suite.uc.RefreshTokens(context.Background(), refreshToken)
+	newAccessToken, newRefreshToken, err := suite.uc.RefreshTokens(context.Background(), refreshToken, "127.0.0.1", "test_user_agent")
 	suite.Nil(err, "刷新令牌应该成功")
 	suite.NotEmpty(newAccessToken, "新访问令牌不应该为空")
 	suite.NotEmpty(newRefreshToken, "新刷新令牌不应该为空")
@@ -443,7 +483,7 @@ func (suite *UserTestSuite) TestLoginWithContextError() {
 	cancel()
 
 	// 测试上下文错误
-	_, _, err := suite.uc.Login(ctx, "test", "test", "127.0.0.1", "test")
+	_, _, err := suite.uc.Login(ctx, "test", "test", "127.0.0.1", "test", "", "")
 	suite.NotNil(err, "上下文错误应该返回错误")
 }
 
@@ -465,6 +505,40 @@ func (suite *UserTestSuite) TestRefreshTokensWithContextError() {
 	cancel()
 
 	// 测试上下文错误
-	_, _, err := suite.uc.RefreshTokens(ctx, "test")
+	_, _, err := suite.uc.RefreshTokens(ctx, "test", "127.0.0.1", "test_user_agent")
 	suite.NotNil(err, "上下文错误应该返回错误")
 }
+
+// TestLogout 测试退出登录终止会话
+func (suite *UserTestSuite) TestLogout() {
+	ctx := context.Background()
+	ui := auth.UserInfo{UserID: 100, Username: "logout"}
+	count := func() int {
+		ms, rErr := suite.uc.sessions.ListUserSession(ctx, ui.UserID)
+		suite.Require().Nil(rErr)
+		return len(*ms)
+	}
+
+	// 按刷新令牌终止会话, 之后不能再刷新
+	pair, rErr := suite.uc.sessions.Issue(ctx, ui, custmodel.SessionMethodPassword, "127.0.0.1", "")
+	suite.Require().Nil(rErr)
+	suite.Nil(suite.uc.Logout(ctx, pair.RefreshToken, ""))
+	suite.Zero(count(), "退出登录后会话应该被终止")
+	_, _, rErr = suite.uc.RefreshTokens(ctx, pair.RefreshToken, "127.0.0.1", "")
+	suite.Require().NotNil(rErr)
+	suite.Equal(errors.ErrSessionRevoked.Reason, rErr.Reason, "退出登录后刷新令牌应该失效")
+
+	// 刷新令牌无效时按访问令牌终止会话
+	pair, rErr = suite.uc.sessions.Issue(ctx, ui, custmodel.SessionMethodPassword, "127.0.0.1", "")
+	suite.Require().Nil(rErr)
+	other, rErr := suite.uc.sessions.Issue(ctx, ui, custmodel.SessionMethodPassword, "127.0.0.1", "")
+	suite.Require().Nil(rErr)
+	suite.Nil(suite.uc.Logout(ctx, "invalid", pair.AccessToken))
+	suite.Equal(1, count(), "只终止访问令牌所属的会话")
+	suite.Nil(suite.uc.Logout(ctx, other.RefreshToken, pair.AccessToken))
+	suite.Zero(count())
+
+	// 令牌都无效时不做处理
+	suite.Nil(suite.uc.Logout(ctx, "", ""))
+	suite.Nil(suite.uc.Logout(ctx, "invalid", "invalid"))
+}
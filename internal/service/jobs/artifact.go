@@ -0,0 +1,411 @@
+package jobs
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	emperror "emperror.dev/errors"
+	"go.uber.org/zap"
+
+	jobsmodel "gin-artweb/internal/model/jobs"
+	jobsrepo "gin-artweb/internal/repository/jobs"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+	"gin-artweb/pkg/storage"
+)
+
+const (
+	defaultArtifactMaxFileSize  = 100 // MB
+	defaultArtifactMaxTotalSize = 500 // MB
+	defaultArtifactMaxFiles     = 100
+
+	// maxArtifactPatterns 单个脚本最多配置的产物文件匹配规则数
+	maxArtifactPatterns = 20
+	// artifactCleanupBatch 每批清理的过期产物数
+	artifactCleanupBatch = 500
+)
+
+// ParseArtifactPatterns 解析并校验脚本的产物文件匹配规则(JSON数组), 为空时返回nil
+//
+// 匹配规则使用filepath.Match的语法, 必须是相对于工作目录的路径且不能包含..
+func ParseArtifactPatterns(s string) ([]string, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	var patterns []string
+	if err := json.Unmarshal([]byte(s), &patterns); err != nil {
+		return nil, fmt.Errorf("产物文件匹配规则不是有效的JSON数组: %w", err)
+	}
+	if len(patterns) > maxArtifactPatterns {
+		return nil, fmt.Errorf("产物文件匹配规则不能超过%d条", maxArtifactPatterns)
+	}
+	seen := make(map[string]struct{}, len(patterns))
+	result := make([]string, 0, len(patterns))
+	for _, p := range patterns {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			return nil, fmt.Errorf("产物文件匹配规则不能为空")
+		}
+		if path.IsAbs(p) || filepath.IsAbs(p) || strings.Contains(p, "\\") {
+			return nil, fmt.Errorf("产物文件匹配规则%s必须是相对于工作目录的路径", p)
+		}
+		if slices.Contains(strings.Split(p, "/"), "..") {
+			return nil, fmt.Errorf("产物文件匹配规则%s不能包含..", p)
+		}
+		if _, err := path.Match(p, ""); err != nil {
+			return nil, fmt.Errorf("产物文件匹配规则%s无效: %w", p, err)
+		}
+		if _, ok := seen[p]; ok {
+			continue
+		}
+		seen[p] = struct{}{}
+		result = append(result, p)
+	}
+	if len(result) == 0 {
+		return nil, nil
+	}
+	return result, nil
+}
+
+// ArtifactService 执行产物服务
+//
+// 脚本执行结束后在工作目录中收集匹配的文件保存到文件存储, 提供产物的查询、下载和过期清理
+type ArtifactService struct {
+	log          *zap.Logger
+	artifactRepo *jobsrepo.ArtifactRepo
+	store        storage.Storage
+	keyPrefix    string
+	conf         config.ArtifactConfig
+}
+
+// NewArtifactService 创建执行产物服务, keyPrefix为产物文件key的前缀
+func NewArtifactService(
+	log *zap.Logger,
+	artifactRepo *jobsrepo.ArtifactRepo,
+	store storage.Storage,
+	keyPrefix string,
+	conf config.ArtifactConfig,
+) *ArtifactService {
+	return &ArtifactService{
+		log:          log,
+		artifactRepo: artifactRepo,
+		store:        store,
+		keyPrefix:    keyPrefix,
+		conf:         conf,
+	}
+}
+
+// artifactFile 待收集的产物文件
+type artifactFile struct {
+	name string // 相对于工作目录的路径, 以/分隔
+	path string // 本地路径
+	info os.FileInfo
+}
+
+// Collect 按脚本的匹配规则收集执行产物, 收集过程写入执行日志
+//
+// 只收集工作目录中的普通文件, 不跟随符号链接; 超出单个文件大小、总大小或数量限制的文件跳过,
+// 单个文件保存失败时继续收集其他文件, 返回收集的产物数
+func (s *ArtifactService) Collect(
+	ctx context.Context,
+	record *jobsmodel.ScriptRecordModel,
+	w io.Writer,
+) int {
+	patterns := jobsmodel.DecodeArtifactPatterns(record.Script.Artifacts)
+	if len(patterns) == 0 {
+		return 0
+	}
+	if record.WorkDir == "" {
+		fmt.Fprintf(w, "[%s] 未设置工作目录, 不收集执行产物\n", time.Now().Format(time.RFC3339))
+		return 0
+	}
+
+	files, err := matchArtifactFiles(record.WorkDir, patterns)
+	if err != nil {
+		fmt.Fprintf(w, "[%s] 查找执行产物失败: %s\n", time.Now().Format(time.RFC3339), err)
+		ctxutil.Logger(ctx, s.log).Error(
+			"查找执行产物失败",
+			zap.Error(err),
+			zap.Uint32("script_record_id", record.ID),
+			zap.String("work_dir", record.WorkDir),
+		)
+		return 0
+	}
+
+	maxFiles := cmp.Or(s.conf.MaxFiles, defaultArtifactMaxFiles)
+	maxFileSize := int64(cmp.Or(s.conf.MaxFileSize, defaultArtifactMaxFileSize)) * 1024 * 1024
+	maxTotalSize := int64(cmp.Or(s.conf.MaxTotalSize, defaultArtifactMaxTotalSize)) * 1024 * 1024
+
+	var collected int
+	var total int64
+	for _, f := range files {
+		now := time.Now().Format(time.RFC3339)
+		size := f.info.Size()
+		switch {
+		case collected >= maxFiles:
+			fmt.Fprintf(w, "[%s] 执行产物超过%d个, 未收集: %s\n", now, maxFiles, f.name)
+			continue
+		case size > maxFileSize:
+			fmt.Fprintf(w, "[%s] 执行产物超出单个文件大小限制, 未收集: %s (%d字节)\n", now, f.name, size)
+			continue
+		case total+size > maxTotalSize:
+			fmt.Fprintf(w, "[%s] 执行产物超出总大小限制, 未收集: %s (%d字节)\n", now, f.name, size)
+			continue
+		}
+
+		m, err := s.save(ctx, record, f)
+		if err != nil {
+			fmt.Fprintf(w, "[%s] 保存执行产物失败: %s: %s\n", now, f.name, err)
+			ctxutil.Logger(ctx, s.log).Error(
+				"保存执行产物失败",
+				zap.Error(err),
+				zap.Uint32("script_record_id", record.ID),
+				zap.String("name", f.name),
+				zap.String("storage", s.store.Type()),
+			)
+			continue
+		}
+		collected++
+		total += m.Size
+		fmt.Fprintf(w, "[%s] 收集执行产物: %s (%d字节)\n", now, m.Name, m.Size)
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"收集执行产物完成",
+		zap.Uint32("script_record_id", record.ID),
+		zap.Int("matched", len(files)),
+		zap.Int("collected", collected),
+		zap.Int64("size", total),
+	)
+	return collected
+}
+
+// matchArtifactFiles 查找工作目录中匹配的普通文件, 按匹配规则的顺序返回且不重复
+func matchArtifactFiles(workDir string, patterns []string) ([]artifactFile, error) {
+	root, err := filepath.Abs(workDir)
+	if err != nil {
+		return nil, err
+	}
+	if root, err = filepath.EvalSymlinks(root); err != nil {
+		return nil, err
+	}
+	var files []artifactFile
+	seen := make(map[string]struct{})
+	for _, p := range patterns {
+		matches, err := filepath.Glob(filepath.Join(root, filepath.FromSlash(p)))
+		if err != nil {
+			return nil, err
+		}
+		for _, match := range matches {
+			name, err := filepath.Rel(root, match)
+			if err != nil {
+				continue
+			}
+			name = filepath.ToSlash(name)
+			if _, ok := seen[name]; ok || len(name) > 255 {
+				continue
+			}
+			// 使用Lstat不跟随符号链接, 并且上级目录也不能是指向工作目录以外的符号链接
+			info, err := os.Lstat(match)
+			if err != nil || !info.Mode().IsRegular() {
+				continue
+			}
+			if real, err := filepath.EvalSymlinks(match); err != nil || !isWithinDir(root, real) {
+				continue
+			}
+			seen[name] = struct{}{}
+			files = append(files, artifactFile{name: name, path: match, info: info})
+		}
+	}
+	return files, nil
+}
+
+// isWithinDir p是否在目录dir中
+func isWithinDir(dir, p string) bool {
+	rel, err := filepath.Rel(dir, p)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// save 保存单个产物文件并创建产物记录
+func (s *ArtifactService) save(
+	ctx context.Context,
+	record *jobsmodel.ScriptRecordModel,
+	f artifactFile,
+) (*jobsmodel.ArtifactModel, error) {
+	file, err := os.Open(f.path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	key := path.Join(s.keyPrefix, record.CreatedAt.Format("20060102"), strconv.FormatUint(uint64(record.ID), 10), f.name)
+	size := f.info.Size()
+	hash := sha256.New()
+	// 只读取收集时的文件大小, 避免脚本的后台进程仍在写入时超出限制
+	if err := s.store.Put(ctx, key, io.TeeReader(io.LimitReader(file, size), hash), size); err != nil {
+		return nil, err
+	}
+
+	m := &jobsmodel.ArtifactModel{
+		RecordID:   record.ID,
+		Name:       f.name,
+		StorageKey: key,
+		Size:       size,
+		Checksum:   hex.EncodeToString(hash.Sum(nil)),
+		CreatedAt:  time.Now(),
+	}
+	if err := s.artifactRepo.CreateModel(ctx, m); err != nil {
+		if dErr := s.store.Delete(ctx, key); dErr != nil {
+			ctxutil.Logger(ctx, s.log).Warn(
+				"删除未保存记录的执行产物失败",
+				zap.Error(dErr),
+				zap.String("key", key),
+			)
+		}
+		return nil, err
+	}
+	return m, nil
+}
+
+// ListArtifacts 查询执行记录的产物列表
+func (s *ArtifactService) ListArtifacts(
+	ctx context.Context,
+	recordID uint32,
+) (*[]jobsmodel.ArtifactModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	_, ms, err := s.artifactRepo.ListModel(ctx, database.QueryParams{
+		Query:   map[string]any{"record_id = ?": recordID},
+		OrderBy: []string{"id ASC"},
+	})
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询执行产物列表失败",
+			zap.Error(err),
+			zap.Uint32("script_record_id", recordID),
+		)
+		return nil, errors.NewGormError(err, nil)
+	}
+	return ms, nil
+}
+
+// FindArtifact 查询执行记录的单个产物
+func (s *ArtifactService) FindArtifact(
+	ctx context.Context,
+	recordID uint32,
+	artifactID uint32,
+) (*jobsmodel.ArtifactModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	m, err := s.artifactRepo.GetModel(ctx, "id = ? AND record_id = ?", artifactID, recordID)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询执行产物失败",
+			zap.Error(err),
+			zap.Uint32("script_record_id", recordID),
+			zap.Uint32("artifact_id", artifactID),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"id": artifactID})
+	}
+	return m, nil
+}
+
+// OpenArtifact 读取产物文件, 调用方负责关闭
+func (s *ArtifactService) OpenArtifact(
+	ctx context.Context,
+	m jobsmodel.ArtifactModel,
+) (io.ReadCloser, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	rc, err := s.store.Get(ctx, m.StorageKey)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"读取执行产物文件失败",
+			zap.Error(err),
+			zap.String("storage", s.store.Type()),
+			zap.String("key", m.StorageKey),
+			zap.Uint32("artifact_id", m.ID),
+		)
+		if emperror.Is(err, storage.ErrNotExist) {
+			return nil, errors.ErrDownloadFileNotFound.WithField("key", m.StorageKey)
+		}
+		return nil, errors.ErrStorageUnavailable.WithCause(err)
+	}
+	return rc, nil
+}
+
+// CleanupExpired 删除超过保留天数的产物文件和记录, 未配置保留天数时不清理
+func (s *ArtifactService) CleanupExpired(ctx context.Context) {
+	if s.conf.KeepDays <= 0 {
+		return
+	}
+	before := time.Now().AddDate(0, 0, -s.conf.KeepDays)
+
+	var deleted int
+	for ctx.Err() == nil {
+		_, ms, err := s.artifactRepo.ListModel(ctx, database.QueryParams{
+			Query:   map[string]any{"created_at < ?": before},
+			OrderBy: []string{"id ASC"},
+			Size:    artifactCleanupBatch,
+		})
+		if err != nil {
+			ctxutil.Logger(ctx, s.log).Error(
+				"查询过期执行产物失败",
+				zap.Error(err),
+				zap.Time("before", before),
+			)
+			return
+		}
+		if len(*ms) == 0 {
+			break
+		}
+		for _, m := range *ms {
+			if err := s.store.Delete(ctx, m.StorageKey); err != nil {
+				// 文件删除失败时保留记录, 下次清理时重试
+				ctxutil.Logger(ctx, s.log).Error(
+					"删除过期执行产物文件失败",
+					zap.Error(err),
+					zap.String("key", m.StorageKey),
+				)
+				return
+			}
+			if err := s.artifactRepo.DeleteModel(ctx, m.ID); err != nil {
+				ctxutil.Logger(ctx, s.log).Error(
+					"删除过期执行产物记录失败",
+					zap.Error(err),
+					zap.Uint32("artifact_id", m.ID),
+				)
+				return
+			}
+			deleted++
+		}
+	}
+
+	if deleted > 0 {
+		ctxutil.Logger(ctx, s.log).Info(
+			"清理过期执行产物完成",
+			zap.Int("deleted", deleted),
+			zap.Time("before", before),
+		)
+	}
+}
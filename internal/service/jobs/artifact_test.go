@@ -0,0 +1,156 @@
+package jobs
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	jobsmodel "gin-artweb/internal/model/jobs"
+	jobsrepo "gin-artweb/internal/repository/jobs"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/test"
+	"gin-artweb/pkg/storage"
+)
+
+type ArtifactTestSuite struct {
+	suite.Suite
+	workDir         string
+	artifactRepo    *jobsrepo.ArtifactRepo
+	artifactService *ArtifactService
+}
+
+func (suite *ArtifactTestSuite) SetupTest() {
+	db := test.NewTestGormDBWithConfig(nil)
+	db.AutoMigrate(&jobsmodel.ArtifactModel{})
+	suite.artifactRepo = jobsrepo.NewArtifactRepo(test.NewTestZapLogger(), db, test.NewTestDBTimeouts())
+	suite.workDir = suite.T().TempDir()
+	suite.artifactService = NewArtifactService(
+		test.NewTestZapLogger(), suite.artifactRepo,
+		storage.NewLocalStorage(suite.T().TempDir()), "artifacts",
+		config.ArtifactConfig{MaxFileSize: 1, MaxFiles: 3, KeepDays: 7},
+	)
+}
+
+func (suite *ArtifactTestSuite) writeFile(name string, size int) {
+	p := filepath.Join(suite.workDir, filepath.FromSlash(name))
+	suite.Require().NoError(os.MkdirAll(filepath.Dir(p), 0o755))
+	suite.Require().NoError(os.WriteFile(p, bytes.Repeat([]byte("a"), size), 0o644))
+}
+
+func (suite *ArtifactTestSuite) newRecord(id uint32, patterns ...string) *jobsmodel.ScriptRecordModel {
+	return &jobsmodel.ScriptRecordModel{
+		StandardModel: database.StandardModel{BaseModel: database.BaseModel{ID: id}, CreatedAt: time.Now()},
+		WorkDir:       suite.workDir,
+		Script:        jobsmodel.ScriptModel{Artifacts: jobsmodel.EncodeArtifactPatterns(patterns)},
+	}
+}
+
+func (suite *ArtifactTestSuite) TestParseArtifactPatterns() {
+	patterns, err := ParseArtifactPatterns(`["reports/*.csv", "recon_*.xlsx", "reports/*.csv"]`)
+	suite.Require().NoError(err)
+	suite.Equal([]string{"reports/*.csv", "recon_*.xlsx"}, patterns, "重复的规则只保留一条")
+
+	patterns, err = ParseArtifactPatterns("")
+	suite.NoError(err)
+	suite.Nil(patterns)
+
+	for _, s := range []string{
+		`"*.csv"`,
+		`[""]`,
+		`["/etc/*"]`,
+		`["../*.csv"]`,
+		`["out/../../*.csv"]`,
+		`["out\\*.csv"]`,
+		`["[a-"]`,
+	} {
+		_, err = ParseArtifactPatterns(s)
+		suite.Error(err, "无效的产物文件匹配规则应该返回错误: %s", s)
+	}
+}
+
+func (suite *ArtifactTestSuite) TestCollect() {
+	suite.writeFile("reports/a.csv", 10)
+	suite.writeFile("reports/b.csv", 20)
+	suite.writeFile("reports/big.csv", 2*1024*1024)
+	suite.writeFile("recon.xlsx", 30)
+	suite.writeFile("other.txt", 40)
+	suite.Require().NoError(os.Symlink("/etc/hostname", filepath.Join(suite.workDir, "reports/link.csv")))
+
+	var log bytes.Buffer
+	record := suite.newRecord(9, "reports/*.csv", "*.xlsx", "reports/a.csv")
+	collected := suite.artifactService.Collect(context.Background(), record, &log)
+	suite.Equal(3, collected)
+	suite.Contains(log.String(), "超出单个文件大小限制, 未收集: reports/big.csv")
+	suite.NotContains(log.String(), "link.csv", "符号链接不应该被收集")
+
+	ms, rErr := suite.artifactService.ListArtifacts(context.Background(), 9)
+	suite.Require().Nil(rErr)
+	suite.Require().Len(*ms, 3)
+	names := []string{(*ms)[0].Name, (*ms)[1].Name, (*ms)[2].Name}
+	suite.Equal([]string{"reports/a.csv", "reports/b.csv", "recon.xlsx"}, names)
+	suite.True(strings.HasPrefix((*ms)[0].StorageKey, "artifacts/"), "产物key使用配置的前缀")
+	suite.Len((*ms)[0].Checksum, 64)
+
+	m, rErr := suite.artifactService.FindArtifact(context.Background(), 9, (*ms)[1].ID)
+	suite.Require().Nil(rErr)
+	rc, rErr := suite.artifactService.OpenArtifact(context.Background(), *m)
+	suite.Require().Nil(rErr)
+	data, err := io.ReadAll(rc)
+	rc.Close()
+	suite.NoError(err)
+	suite.Len(data, 20)
+
+	_, rErr = suite.artifactService.FindArtifact(context.Background(), 10, (*ms)[1].ID)
+	suite.NotNil(rErr, "不能通过其他执行记录查询产物")
+}
+
+func (suite *ArtifactTestSuite) TestCollectLimits() {
+	for _, name := range []string{"a.csv", "b.csv", "c.csv", "d.csv"} {
+		suite.writeFile(name, 10)
+	}
+	var log bytes.Buffer
+	collected := suite.artifactService.Collect(context.Background(), suite.newRecord(11, "*.csv"), &log)
+	suite.Equal(3, collected)
+	suite.Contains(log.String(), "执行产物超过3个, 未收集: d.csv")
+
+	// 未设置工作目录时不收集
+	record := suite.newRecord(12, "*.csv")
+	record.WorkDir = ""
+	suite.Equal(0, suite.artifactService.Collect(context.Background(), record, &log))
+
+	// 脚本没有配置匹配规则时不收集
+	suite.Equal(0, suite.artifactService.Collect(context.Background(), suite.newRecord(13), &log))
+}
+
+func (suite *ArtifactTestSuite) TestCleanupExpired() {
+	suite.writeFile("a.csv", 10)
+	suite.writeFile("b.csv", 10)
+	suite.Require().Equal(2, suite.artifactService.Collect(context.Background(), suite.newRecord(14, "*.csv"), io.Discard))
+
+	ms, _ := suite.artifactService.ListArtifacts(context.Background(), 14)
+	expired := (*ms)[0]
+	suite.Require().NoError(suite.artifactRepo.DeleteModel(context.Background(), expired.ID))
+	expired.ID = 0
+	expired.CreatedAt = time.Now().AddDate(0, 0, -8)
+	suite.Require().NoError(suite.artifactRepo.CreateModel(context.Background(), &expired))
+
+	suite.artifactService.CleanupExpired(context.Background())
+
+	ms, _ = suite.artifactService.ListArtifacts(context.Background(), 14)
+	suite.Require().Len(*ms, 1, "只清理超过保留天数的产物")
+	suite.Equal("b.csv", (*ms)[0].Name)
+	_, rErr := suite.artifactService.OpenArtifact(context.Background(), expired)
+	suite.NotNil(rErr, "过期产物的文件应该被删除")
+}
+
+func TestArtifactTestSuite(t *testing.T) {
+	suite.Run(t, new(ArtifactTestSuite))
+}
@@ -0,0 +1,255 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	jobsmodel "gin-artweb/internal/model/jobs"
+	jobsrepo "gin-artweb/internal/repository/jobs"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+)
+
+type CalendarService struct {
+	log         *zap.Logger
+	holidayRepo *jobsrepo.TradingHolidayRepo
+	skipRepo    *jobsrepo.ScheduleSkipRepo
+}
+
+func NewCalendarService(
+	log *zap.Logger,
+	holidayRepo *jobsrepo.TradingHolidayRepo,
+	skipRepo *jobsrepo.ScheduleSkipRepo,
+) *CalendarService {
+	return &CalendarService{
+		log:         log,
+		holidayRepo: holidayRepo,
+		skipRepo:    skipRepo,
+	}
+}
+
+// ImportTradingCalendar 导入交易所发布的年度休市安排, 整体替换该年份的节假日
+func (s *CalendarService) ImportTradingCalendar(
+	ctx context.Context,
+	year int,
+	items []jobsmodel.TradingHolidayItem,
+	username string,
+) (int, *errors.Error) {
+	if ctx.Err() != nil {
+		return 0, errors.FromError(ctx.Err())
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始导入交易日历",
+		zap.Int("year", year),
+		zap.Int("count", len(items)),
+	)
+
+	ms := make([]jobsmodel.TradingHolidayModel, 0, len(items))
+	seen := make(map[string]struct{}, len(items))
+	for _, item := range items {
+		d, err := time.Parse(jobsmodel.CalendarDateLayout, item.Date)
+		if err != nil {
+			return 0, errors.ErrTradingCalendarInvalid.WithCause(err)
+		}
+		if d.Year() != year {
+			return 0, errors.ErrTradingCalendarInvalid.WithFields(map[string]any{
+				"year": year,
+				"date": item.Date,
+			})
+		}
+		if _, ok := seen[item.Date]; ok {
+			return 0, errors.ErrTradingCalendarInvalid.WithField("duplicate_date", item.Date)
+		}
+		seen[item.Date] = struct{}{}
+		ms = append(ms, jobsmodel.TradingHolidayModel{
+			Date:     item.Date,
+			Year:     year,
+			Name:     item.Name,
+			Username: username,
+		})
+	}
+
+	if err := s.holidayRepo.ReplaceYear(ctx, year, ms); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"导入交易日历失败",
+			zap.Error(err),
+			zap.Int("year", year),
+		)
+		return 0, errors.NewGormError(err, map[string]any{"year": year})
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"导入交易日历成功",
+		zap.Int("year", year),
+		zap.Int("count", len(ms)),
+	)
+	return len(ms), nil
+}
+
+func (s *CalendarService) DeleteTradingHolidayByID(
+	ctx context.Context,
+	holidayID uint32,
+) *errors.Error {
+	if ctx.Err() != nil {
+		return errors.FromError(ctx.Err())
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始删除交易日历节假日",
+		zap.Uint32("holiday_id", holidayID),
+	)
+
+	if err := s.holidayRepo.DeleteModel(ctx, holidayID); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"删除交易日历节假日失败",
+			zap.Error(err),
+			zap.Uint32("holiday_id", holidayID),
+		)
+		return errors.NewGormError(err, map[string]any{"id": holidayID})
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"删除交易日历节假日成功",
+		zap.Uint32("holiday_id", holidayID),
+	)
+	return nil
+}
+
+func (s *CalendarService) ListTradingHoliday(
+	ctx context.Context,
+	qp database.QueryParams,
+) (int64, *[]jobsmodel.TradingHolidayModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return 0, nil, errors.FromError(ctx.Err())
+	}
+
+	count, ms, err := s.holidayRepo.ListModel(ctx, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询交易日历节假日列表失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		return 0, nil, errors.NewGormError(err, nil)
+	}
+	return count, ms, nil
+}
+
+// FindHoliday 查询指定日期的节假日, 非节假日时返回nil
+func (s *CalendarService) FindHoliday(
+	ctx context.Context,
+	t time.Time,
+) (*jobsmodel.TradingHolidayModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	date := t.Format(jobsmodel.CalendarDateLayout)
+	m, err := s.holidayRepo.GetModel(ctx, "date = ?", date)
+	if err != nil {
+		rErr := errors.NewGormError(err, map[string]any{"date": date})
+		if rErr.Is(errors.ErrRecordNotFound) {
+			return nil, nil
+		}
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询交易日历节假日失败",
+			zap.Error(err),
+			zap.String("date", date),
+		)
+		return nil, rErr
+	}
+	return m, nil
+}
+
+// CheckTradingDay 查询指定日期是否为交易日
+func (s *CalendarService) CheckTradingDay(
+	ctx context.Context,
+	t time.Time,
+) (*jobsmodel.TradingDayOut, *errors.Error) {
+	holiday, rErr := s.FindHoliday(ctx, t)
+	if rErr != nil {
+		return nil, rErr
+	}
+	out := &jobsmodel.TradingDayOut{
+		Date:         t.Format(jobsmodel.CalendarDateLayout),
+		IsTradingDay: holiday == nil && !isWeekend(t),
+		IsHoliday:    holiday != nil,
+	}
+	if holiday != nil {
+		out.HolidayName = holiday.Name
+	}
+	return out, nil
+}
+
+// SkipReason 按计划任务的交易日历选项判断指定时间是否需要跳过执行
+//
+// 返回跳过原因和说明, 无需跳过时原因为空
+func (s *CalendarService) SkipReason(
+	ctx context.Context,
+	mode string,
+	t time.Time,
+) (string, string, *errors.Error) {
+	switch mode {
+	case jobsmodel.CalendarModeTradingDay:
+		if isWeekend(t) {
+			return jobsmodel.SkipReasonWeekend, t.Weekday().String(), nil
+		}
+	case jobsmodel.CalendarModeSkipHoliday:
+	default:
+		return "", "", nil
+	}
+
+	holiday, rErr := s.FindHoliday(ctx, t)
+	if rErr != nil {
+		return "", "", rErr
+	}
+	if holiday != nil {
+		return jobsmodel.SkipReasonHoliday, holiday.Name, nil
+	}
+	return "", "", nil
+}
+
+// RecordSkip 记录计划任务被跳过的执行
+func (s *CalendarService) RecordSkip(
+	ctx context.Context,
+	m *jobsmodel.ScheduleSkipModel,
+) *errors.Error {
+	if err := s.skipRepo.CreateModel(ctx, m); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"记录计划任务跳过执行失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, m),
+		)
+		return errors.NewGormError(err, nil)
+	}
+	return nil
+}
+
+func (s *CalendarService) ListScheduleSkip(
+	ctx context.Context,
+	qp database.QueryParams,
+) (int64, *[]jobsmodel.ScheduleSkipModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return 0, nil, errors.FromError(ctx.Err())
+	}
+
+	count, ms, err := s.skipRepo.ListModel(ctx, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询计划任务跳过记录列表失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		return 0, nil, errors.NewGormError(err, nil)
+	}
+	return count, ms, nil
+}
+
+func isWeekend(t time.Time) bool {
+	wd := t.Weekday()
+	return wd == time.Saturday || wd == time.Sunday
+}
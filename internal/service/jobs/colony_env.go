@@ -0,0 +1,155 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"go.uber.org/zap"
+
+	commodel "gin-artweb/internal/model/common"
+	jobsmodel "gin-artweb/internal/model/jobs"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/errors"
+)
+
+// colonyEnvRef 计划任务环境变量值中引用集群上下文变量的模板, 如${COLONY_NUM}
+var colonyEnvRef = regexp.MustCompile(`\$\{([A-Z_][A-Z0-9_]*)\}`)
+
+// ColonyEnvResolver 按集群号解析集群上下文的环境变量, 由oes模块提供
+type ColonyEnvResolver func(ctx context.Context, colonyNum string) (map[string]string, *errors.Error)
+
+// SetColonyEnvResolver 设置集群上下文环境变量的解析, 未设置时不能绑定集群, 需要在服务启动前调用
+func (s *ScheduleService) SetColonyEnvResolver(resolver ColonyEnvResolver) {
+	s.colonyEnv = resolver
+}
+
+// colonyVars 解析计划任务绑定集群的环境变量, 未绑定集群时返回nil
+func (s *ScheduleService) colonyVars(ctx context.Context, colony string) (map[string]string, *errors.Error) {
+	if colony == "" {
+		return nil, nil
+	}
+	if s.colonyEnv == nil {
+		return nil, errors.ErrValidationFailed.WithCause(fmt.Errorf("未启用oes模块, 无法解析集群%s的环境变量", colony))
+	}
+	vars, rErr := s.colonyEnv(ctx, colony)
+	if rErr != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"解析集群上下文环境变量失败",
+			zap.Error(rErr),
+			zap.String("colony", colony),
+		)
+		return nil, rErr
+	}
+	return vars, nil
+}
+
+// checkColony 创建或更新计划任务时检查绑定的集群可以解析环境变量
+func (s *ScheduleService) checkColony(ctx context.Context, colony string) *errors.Error {
+	if _, rErr := s.colonyVars(ctx, colony); rErr != nil {
+		return errors.ErrValidationFailed.WithField("colony", colony).WithCause(rErr)
+	}
+	return nil
+}
+
+// triggerEnvVars 计划任务触发时的环境变量(JSON对象), 绑定集群时注入触发时解析的集群上下文变量
+func (s *ScheduleService) triggerEnvVars(ctx context.Context, m *jobsmodel.ScheduleModel) (string, *errors.Error) {
+	vars, rErr := s.colonyVars(ctx, m.Colony)
+	if rErr != nil {
+		return "", rErr
+	}
+	if len(vars) == 0 {
+		return m.EnvVars, nil
+	}
+	env, _ := expandColonyEnv(m.EnvVars, vars)
+	b, err := json.Marshal(env)
+	if err != nil {
+		return "", errors.FromError(err)
+	}
+	return string(b), nil
+}
+
+// expandColonyEnv 合并集群上下文变量和计划任务配置的环境变量
+//
+// 计划任务变量值中的${NAME}替换为集群上下文变量的值, 未知的变量保持原样; 同名变量以计划任务的配置为准.
+// 返回合并后的环境变量和引用了集群上下文变量的计划任务变量名
+func expandColonyEnv(envVars string, vars map[string]string) (map[string]string, map[string]bool) {
+	env := make(map[string]string, len(vars))
+	for k, v := range vars {
+		env[k] = v
+	}
+	var own map[string]string
+	if envVars != "" {
+		_ = json.Unmarshal([]byte(envVars), &own)
+	}
+	templated := make(map[string]bool)
+	for k, v := range own {
+		if k == "" {
+			continue
+		}
+		env[k] = colonyEnvRef.ReplaceAllStringFunc(v, func(ref string) string {
+			name := colonyEnvRef.FindStringSubmatch(ref)[1]
+			if value, ok := vars[name]; ok {
+				templated[k] = true
+				return value
+			}
+			return ref
+		})
+	}
+	return env, templated
+}
+
+// PreviewEnv 预览计划任务触发时注入的环境变量
+//
+// 集群上下文变量按当前的集群数据解析, 计划任务配置的变量值已掩码
+func (s *ScheduleService) PreviewEnv(
+	ctx context.Context,
+	scheduleID uint32,
+) (*jobsmodel.EnvPreviewOut, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	m, rErr := s.FindScheduleByID(ctx, nil, scheduleID)
+	if rErr != nil {
+		return nil, rErr
+	}
+	vars, rErr := s.colonyVars(ctx, m.Colony)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	env, templated := expandColonyEnv(m.EnvVars, vars)
+	var own map[string]string
+	if m.EnvVars != "" {
+		_ = json.Unmarshal([]byte(m.EnvVars), &own)
+	}
+	out := &jobsmodel.EnvPreviewOut{
+		ScheduleID: m.ID,
+		Colony:     m.Colony,
+		Env:        make([]jobsmodel.EnvPreviewItemOut, 0, len(env)),
+	}
+	for name, value := range env {
+		item := jobsmodel.EnvPreviewItemOut{Name: name, Value: value, Source: jobsmodel.EnvSourceColony}
+		if _, ok := own[name]; ok {
+			item.Value = commodel.MaskedValue
+			item.Source = jobsmodel.EnvSourceSchedule
+			item.Templated = templated[name]
+		}
+		out.Env = append(out.Env, item)
+	}
+	slices.SortFunc(out.Env, func(a, b jobsmodel.EnvPreviewItemOut) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"预览计划任务环境变量成功",
+		zap.Uint32("schedule_id", scheduleID),
+		zap.String("colony", m.Colony),
+		zap.Int("count", len(out.Env)),
+	)
+	return out, nil
+}
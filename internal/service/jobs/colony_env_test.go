@@ -0,0 +1,149 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/robfig/cron/v3"
+	"github.com/stretchr/testify/suite"
+
+	commodel "gin-artweb/internal/model/common"
+	jobsmodel "gin-artweb/internal/model/jobs"
+	jobsrepo "gin-artweb/internal/repository/jobs"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/errors"
+	"gin-artweb/internal/shared/test"
+)
+
+type ColonyEnvTestSuite struct {
+	suite.Suite
+	script          *jobsmodel.ScriptModel
+	scheduleService *ScheduleService
+}
+
+func (suite *ColonyEnvTestSuite) SetupTest() {
+	db := test.NewTestGormDBWithConfig(nil)
+	db.AutoMigrate(&jobsmodel.ScriptModel{}, &jobsmodel.ScriptRecordModel{}, &jobsmodel.ScheduleModel{})
+	dbTimeout := test.NewTestDBTimeouts()
+	logger := test.NewTestZapLogger()
+	scriptRepo := jobsrepo.NewScriptRepo(logger, db, dbTimeout)
+	recordRepo := jobsrepo.NewRecordRepo(logger, db, dbTimeout)
+	scheduleRepo := jobsrepo.NewScheduleRepo(logger, db, dbTimeout)
+	recordService := NewScriptRecordService(logger, scriptRepo, recordRepo, &config.JobsConfig{}, nil)
+	suite.scheduleService = NewScheduleService(logger, scriptRepo, scheduleRepo, recordService, nil, nil, cron.New())
+
+	suite.script = &jobsmodel.ScriptModel{Name: uuid.NewString(), Project: "oes", Label: "cmd", Status: true}
+	suite.Require().NoError(scriptRepo.CreateModel(context.Background(), suite.script))
+}
+
+// setResolver 设置只包含集群01的集群上下文解析
+func (suite *ColonyEnvTestSuite) setResolver() {
+	suite.scheduleService.SetColonyEnvResolver(func(_ context.Context, colonyNum string) (map[string]string, *errors.Error) {
+		if colonyNum != "01" {
+			return nil, errors.ErrRecordNotFound
+		}
+		return map[string]string{
+			jobsmodel.EnvColonyNum:      "01",
+			jobsmodel.EnvSystemType:     "STK",
+			jobsmodel.EnvMonNodeIP:      "10.0.0.8",
+			jobsmodel.EnvPackageVersion: "1.2.3",
+		}, nil
+	})
+}
+
+func (suite *ColonyEnvTestSuite) createSchedule(colony, envVars string) (*jobsmodel.ScheduleModel, *errors.Error) {
+	return suite.scheduleService.CreateSchedule(context.Background(), jobsmodel.ScheduleModel{
+		Name:          uuid.NewString()[:20],
+		Specification: "0 8 * * *",
+		EnvVars:       envVars,
+		Colony:        colony,
+		ScriptID:      suite.script.ID,
+	})
+}
+
+func (suite *ColonyEnvTestSuite) TestExpandColonyEnv() {
+	vars := map[string]string{"COLONY_NUM": "01", "MON_NODE_IP": "10.0.0.8"}
+	env, templated := expandColonyEnv(`{"URL":"http://${MON_NODE_IP}:8080","LOG":"${LOG_DIR}/a","COLONY_NUM":"02"}`, vars)
+	suite.Equal(map[string]string{
+		"COLONY_NUM":  "02",
+		"MON_NODE_IP": "10.0.0.8",
+		"URL":         "http://10.0.0.8:8080",
+		"LOG":         "${LOG_DIR}/a",
+	}, env, "计划任务的同名变量优先, 未知的变量引用保持原样")
+	suite.Equal(map[string]bool{"URL": true}, templated)
+
+	env, _ = expandColonyEnv("", vars)
+	suite.Equal(vars, env)
+}
+
+func (suite *ColonyEnvTestSuite) TestCheckColony() {
+	_, rErr := suite.createSchedule("01", "")
+	suite.Require().NotNil(rErr, "未设置集群上下文解析时不能绑定集群")
+	suite.Equal(errors.ErrValidationFailed.Reason, rErr.Reason)
+
+	suite.setResolver()
+	_, rErr = suite.createSchedule("02", "")
+	suite.NotNil(rErr, "不存在的集群不能绑定")
+
+	m, rErr := suite.createSchedule("01", "")
+	suite.Require().Nil(rErr)
+	suite.Equal("01", m.Colony)
+
+	_, rErr = suite.scheduleService.UpdateScheduleByID(context.Background(), m.ID, map[string]any{"colony": "03"})
+	suite.NotNil(rErr, "更新为不存在的集群应该失败")
+}
+
+func (suite *ColonyEnvTestSuite) TestTriggerEnvVars() {
+	suite.setResolver()
+	m, rErr := suite.createSchedule("01", `{"TOKEN":"secret","URL":"http://${MON_NODE_IP}"}`)
+	suite.Require().Nil(rErr)
+
+	envVars, rErr := suite.scheduleService.triggerEnvVars(context.Background(), m)
+	suite.Require().Nil(rErr)
+	var env map[string]string
+	suite.Require().NoError(json.Unmarshal([]byte(envVars), &env))
+	suite.Equal("1.2.3", env[jobsmodel.EnvPackageVersion])
+	suite.Equal("STK", env[jobsmodel.EnvSystemType])
+	suite.Equal("http://10.0.0.8", env["URL"])
+	suite.Equal("secret", env["TOKEN"])
+
+	// 未绑定集群时保持原有的环境变量
+	m, rErr = suite.createSchedule("", `{"A":"${COLONY_NUM}"}`)
+	suite.Require().Nil(rErr)
+	envVars, rErr = suite.scheduleService.triggerEnvVars(context.Background(), m)
+	suite.Nil(rErr)
+	suite.Equal(`{"A":"${COLONY_NUM}"}`, envVars)
+}
+
+func (suite *ColonyEnvTestSuite) TestPreviewEnv() {
+	suite.setResolver()
+	m, rErr := suite.createSchedule("01", `{"TOKEN":"secret","URL":"http://${MON_NODE_IP}"}`)
+	suite.Require().Nil(rErr)
+
+	out, rErr := suite.scheduleService.PreviewEnv(context.Background(), m.ID)
+	suite.Require().Nil(rErr)
+	suite.Equal("01", out.Colony)
+	suite.Require().Len(out.Env, 6)
+	suite.Equal(jobsmodel.EnvPreviewItemOut{
+		Name: jobsmodel.EnvColonyNum, Value: "01", Source: jobsmodel.EnvSourceColony,
+	}, out.Env[0], "按变量名排序")
+	for _, item := range out.Env {
+		switch item.Name {
+		case "TOKEN":
+			suite.Equal(commodel.MaskedValue, item.Value, "计划任务配置的变量值应该掩码")
+			suite.False(item.Templated)
+		case "URL":
+			suite.Equal(jobsmodel.EnvSourceSchedule, item.Source)
+			suite.True(item.Templated)
+		}
+	}
+
+	_, rErr = suite.scheduleService.PreviewEnv(context.Background(), m.ID+100)
+	suite.NotNil(rErr, "计划任务不存在时应该返回错误")
+}
+
+func TestColonyEnvTestSuite(t *testing.T) {
+	suite.Run(t, new(ColonyEnvTestSuite))
+}
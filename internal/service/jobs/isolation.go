@@ -0,0 +1,244 @@
+package jobs
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/gin-gonic/gin/binding"
+
+	jobsmodel "gin-artweb/internal/model/jobs"
+	"gin-artweb/internal/shared/config"
+)
+
+const (
+	defaultContainerRuntime = "docker"
+	defaultContainerNetwork = "none"
+	defaultCgroupRoot       = "/sys/fs/cgroup/gin-artweb"
+
+	// containerScriptDir 容器中挂载脚本的目录
+	containerScriptDir = "/job"
+	// containerWorkDir 容器中挂载工作目录的路径
+	containerWorkDir = "/workspace"
+	// isolationWaitDelay 取消执行后等待容器或进程退出的最长时间
+	isolationWaitDelay = 10 * time.Second
+)
+
+// imageRefPattern 容器镜像引用, 格式为[仓库地址[:端口]/]名称[:标签][@sha256:摘要], 不能以-开头
+var imageRefPattern = regexp.MustCompile(
+	`^[a-zA-Z0-9]+(?:[._-][a-zA-Z0-9]+)*(?::[0-9]+)?` +
+		`(?:/[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*)*` +
+		`(?::[a-zA-Z0-9_][a-zA-Z0-9_.-]{0,127})?` +
+		`(?:@sha256:[a-f0-9]{64})?$`,
+)
+
+// isolatedEnvKeys 隔离执行时从服务继承的环境变量, 服务的其他环境变量(如数据库密码、密钥)不传给脚本
+var isolatedEnvKeys = []string{"PATH", "HOME", "LANG"}
+
+// failureReasonMessages 资源限制导致失败时记录的错误信息
+var failureReasonMessages = map[string]string{
+	jobsmodel.FailureReasonOOMKilled:   "脚本超出内存限制被终止",
+	jobsmodel.FailureReasonLimitKilled: "脚本超出进程数限制被终止",
+}
+
+// ParseIsolation 解析并校验脚本的隔离执行配置(JSON对象), 为空或不隔离时返回nil
+func ParseIsolation(s string) (*jobsmodel.IsolationSpec, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	var spec jobsmodel.IsolationSpec
+	if err := json.Unmarshal([]byte(s), &spec); err != nil {
+		return nil, fmt.Errorf("隔离执行配置不是有效的JSON对象: %w", err)
+	}
+	if err := binding.Validator.ValidateStruct(&spec); err != nil {
+		return nil, err
+	}
+	if !spec.Enabled() {
+		return nil, nil
+	}
+	if spec.Image != "" {
+		if err := validateImage(spec.Image); err != nil {
+			return nil, err
+		}
+	}
+	return &spec, nil
+}
+
+// jobCommand 脚本执行的命令
+type jobCommand struct {
+	recordID uint32
+	script   string   // 脚本路径
+	args     []string // 命令行参数
+	env      []string // 脚本的环境变量, 不含服务自身的环境变量
+	dir      string   // 工作目录
+}
+
+// isolatedCmd 按隔离配置创建的命令
+type isolatedCmd struct {
+	*exec.Cmd
+
+	// cleanup 执行结束后删除容器或控制组
+	cleanup func()
+
+	// reason 执行失败后判断是否因超出资源限制被终止, 返回失败原因, 需要在cleanup之前调用
+	reason func() string
+}
+
+// command 按隔离配置创建脚本执行的命令, 不隔离时以服务的权限直接执行
+func (s *RecordService) command(
+	ctx context.Context,
+	job jobCommand,
+	spec *jobsmodel.IsolationSpec,
+) (*isolatedCmd, error) {
+	if !spec.Enabled() {
+		cmd := exec.CommandContext(ctx, job.script, job.args...)
+		cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+		cmd.Cancel = killProcessGroup(cmd)
+		cmd.Dir = job.dir
+		cmd.Env = append(os.Environ(), job.env...)
+		return &isolatedCmd{Cmd: cmd, cleanup: func() {}, reason: func() string { return "" }}, nil
+	}
+
+	conf := s.conf.Isolation
+	if conf == nil {
+		return nil, fmt.Errorf("未开启隔离执行, 请先配置jobs.isolation")
+	}
+	user := cmp.Or(spec.User, conf.DefaultUser)
+	if spec.User != "" && spec.User != conf.DefaultUser && !slices.Contains(conf.AllowedUsers, spec.User) {
+		return nil, fmt.Errorf("执行用户%s不在允许的范围内", spec.User)
+	}
+
+	switch spec.Mode {
+	case jobsmodel.IsolationContainer:
+		return containerCommand(ctx, conf, job, spec, user)
+	case jobsmodel.IsolationCgroup:
+		return cgroupCommand(ctx, conf, job, spec, user)
+	default:
+		return nil, fmt.Errorf("不支持的隔离方式: %s", spec.Mode)
+	}
+}
+
+// containerCommand 在容器中执行脚本
+//
+// 脚本以只读方式挂载到容器中, 工作目录挂载到/workspace, 容器中只有脚本的环境变量;
+// 环境变量的值可能是解密后的敏感信息, 命令行参数只传变量名, 值通过容器运行时进程的环境变量传入,
+// 避免其他本地用户通过ps或/proc/<pid>/cmdline看到; 容器执行结束后先检查是否因超出内存限制被终止再删除
+func containerCommand(
+	ctx context.Context,
+	conf *config.IsolationConfig,
+	job jobCommand,
+	spec *jobsmodel.IsolationSpec,
+	user string,
+) (*isolatedCmd, error) {
+	runtime := cmp.Or(conf.Runtime, defaultContainerRuntime)
+	image := cmp.Or(spec.Image, conf.DefaultImage)
+	if image == "" {
+		return nil, fmt.Errorf("未指定容器镜像, 请在隔离执行配置中指定image或配置jobs.isolation.default_image")
+	}
+	if err := validateImage(image); err != nil {
+		return nil, err
+	}
+	name := fmt.Sprintf("gin-artweb-job-%d", job.recordID)
+	script := containerScriptDir + "/" + filepath.Base(job.script)
+
+	args := []string{
+		"run", "--name", name,
+		"--network", cmp.Or(conf.Network, defaultContainerNetwork),
+		"-v", job.script + ":" + script + ":ro",
+	}
+	if job.dir != "" {
+		args = append(args, "-v", job.dir+":"+containerWorkDir, "-w", containerWorkDir)
+	}
+	if spec.CPUs > 0 {
+		args = append(args, "--cpus", strconv.FormatFloat(spec.CPUs, 'f', -1, 64))
+	}
+	if spec.MemoryMB > 0 {
+		// 内存和交换分区使用相同的上限, 即不允许使用交换分区
+		memory := fmt.Sprintf("%dm", spec.MemoryMB)
+		args = append(args, "--memory", memory, "--memory-swap", memory)
+	}
+	if spec.PidsLimit > 0 {
+		args = append(args, "--pids-limit", strconv.Itoa(spec.PidsLimit))
+	}
+	if user != "" {
+		args = append(args, "--user", user)
+	}
+	for _, kv := range job.env {
+		key, _, _ := strings.Cut(kv, "=")
+		args = append(args, "-e", key)
+	}
+	// --之后的参数不再作为容器运行时的选项解析
+	args = append(args, "--", image, script)
+	args = append(args, job.args...)
+
+	cmd := exec.CommandContext(ctx, runtime, args...)
+	cmd.Env = append(os.Environ(), job.env...)
+	cmd.Cancel = func() error {
+		return exec.Command(runtime, "kill", name).Run()
+	}
+	cmd.WaitDelay = isolationWaitDelay
+
+	return &isolatedCmd{
+		Cmd: cmd,
+		cleanup: func() {
+			_ = exec.Command(runtime, "rm", "-f", name).Run()
+		},
+		reason: func() string {
+			out, err := exec.Command(runtime, "inspect", "-f", "{{.State.OOMKilled}}", name).Output()
+			if err == nil && strings.TrimSpace(string(out)) == "true" {
+				return jobsmodel.FailureReasonOOMKilled
+			}
+			return ""
+		},
+	}, nil
+}
+
+// validateImage 校验容器镜像引用, 防止镜像名称被容器运行时当作选项解析
+func validateImage(image string) error {
+	if strings.HasPrefix(image, "-") || !imageRefPattern.MatchString(image) {
+		return fmt.Errorf("无效的容器镜像: %s", image)
+	}
+	return nil
+}
+
+// isolatedEnv 隔离执行的环境变量, 只包含isolatedEnvKeys和脚本的环境变量, home不为空时作为执行用户的HOME
+func isolatedEnv(env []string, home string) []string {
+	out := make([]string, 0, len(isolatedEnvKeys)+len(env))
+	for _, key := range isolatedEnvKeys {
+		value, ok := os.LookupEnv(key)
+		if key == "HOME" && home != "" {
+			value, ok = home, true
+		}
+		if ok {
+			out = append(out, key+"="+value)
+		}
+	}
+	return append(out, env...)
+}
+
+// killProcessGroup 取消执行时终止脚本的整个进程组
+func killProcessGroup(cmd *exec.Cmd) func() error {
+	return func() error {
+		if cmd.Process == nil {
+			return nil
+		}
+
+		// 获取进程组ID
+		pgid, err := syscall.Getpgid(cmd.Process.Pid)
+		if err == nil {
+			return syscall.Kill(-pgid, syscall.SIGKILL)
+		} else {
+			return cmd.Process.Kill()
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package jobs
+
+import (
+	"bufio"
+	"cmp"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+
+	jobsmodel "gin-artweb/internal/model/jobs"
+	"gin-artweb/internal/shared/config"
+)
+
+// cgroupCPUPeriod cpu.max的统计周期(微秒)
+const cgroupCPUPeriod = 100000
+
+// cgroupCommand 在限制资源的cgroup中执行脚本
+//
+// 每次执行在cgroup_root下创建独立的控制组, 脚本进程启动时直接加入该控制组,
+// 执行结束后根据memory.events和pids.events判断是否因超出资源限制被终止, 然后删除控制组
+func cgroupCommand(
+	ctx context.Context,
+	conf *config.IsolationConfig,
+	job jobCommand,
+	spec *jobsmodel.IsolationSpec,
+	username string,
+) (*isolatedCmd, error) {
+	var (
+		cred *syscall.Credential
+		home string
+	)
+	if username != "" {
+		u, err := user.Lookup(username)
+		if err != nil {
+			return nil, fmt.Errorf("查询执行用户%s失败: %w", username, err)
+		}
+		uid, _ := strconv.ParseUint(u.Uid, 10, 32)
+		gid, _ := strconv.ParseUint(u.Gid, 10, 32)
+		cred = &syscall.Credential{Uid: uint32(uid), Gid: uint32(gid)}
+		home = u.HomeDir
+	}
+
+	root := cmp.Or(conf.CgroupRoot, defaultCgroupRoot)
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, fmt.Errorf("创建cgroup目录失败: %w", err)
+	}
+	// 子控制组需要父目录启用对应的控制器, 已启用时写入不会报错, 未能启用时在写入限制时报错
+	_ = os.WriteFile(filepath.Join(root, "cgroup.subtree_control"), []byte("+cpu +memory +pids"), 0)
+
+	dir := filepath.Join(root, fmt.Sprintf("job-%d", job.recordID))
+	if err := os.Mkdir(dir, 0o755); err != nil && !os.IsExist(err) {
+		return nil, fmt.Errorf("创建cgroup目录失败: %w", err)
+	}
+	removeDir := func() {
+		// 终止控制组中残留的进程后才能删除
+		_ = os.WriteFile(filepath.Join(dir, "cgroup.kill"), []byte("1"), 0)
+		_ = os.Remove(dir)
+	}
+
+	limits := [][2]string{}
+	if spec.CPUs > 0 {
+		limits = append(limits, [2]string{"cpu.max", fmt.Sprintf("%d %d", int64(spec.CPUs*cgroupCPUPeriod), cgroupCPUPeriod)})
+	}
+	if spec.MemoryMB > 0 {
+		limits = append(limits, [2]string{"memory.max", strconv.FormatInt(int64(spec.MemoryMB)<<20, 10)})
+	}
+	if spec.PidsLimit > 0 {
+		limits = append(limits, [2]string{"pids.max", strconv.Itoa(spec.PidsLimit)})
+	}
+	for _, l := range limits {
+		if err := os.WriteFile(filepath.Join(dir, l[0]), []byte(l[1]), 0); err != nil {
+			removeDir()
+			return nil, fmt.Errorf("设置cgroup限制%s失败: %w", l[0], err)
+		}
+	}
+	if spec.MemoryMB > 0 {
+		// 未开启交换分区统计时没有该文件
+		_ = os.WriteFile(filepath.Join(dir, "memory.swap.max"), []byte("0"), 0)
+	}
+
+	fd, err := syscall.Open(dir, syscall.O_DIRECTORY|syscall.O_RDONLY|syscall.O_CLOEXEC, 0)
+	if err != nil {
+		removeDir()
+		return nil, fmt.Errorf("打开cgroup目录失败: %w", err)
+	}
+
+	cmd := exec.CommandContext(ctx, job.script, job.args...)
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Setpgid:     true,
+		UseCgroupFD: true,
+		CgroupFD:    fd,
+		Credential:  cred,
+	}
+	cmd.Cancel = killProcessGroup(cmd)
+	cmd.WaitDelay = isolationWaitDelay
+	cmd.Dir = job.dir
+	// 与容器方式一致, 不继承服务的环境变量
+	cmd.Env = isolatedEnv(job.env, home)
+
+	return &isolatedCmd{
+		Cmd: cmd,
+		cleanup: func() {
+			_ = syscall.Close(fd)
+			removeDir()
+		},
+		reason: func() string {
+			if cgroupEventCount(filepath.Join(dir, "memory.events"), "oom_kill") > 0 {
+				return jobsmodel.FailureReasonOOMKilled
+			}
+			if cgroupEventCount(filepath.Join(dir, "pids.events"), "max") > 0 {
+				return jobsmodel.FailureReasonLimitKilled
+			}
+			return ""
+		},
+	}, nil
+}
+
+// cgroupEventCount 读取cgroup事件文件中指定事件的次数, 文件不存在时返回0
+func cgroupEventCount(path, event string) int64 {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		name, value, ok := strings.Cut(scanner.Text(), " ")
+		if ok && name == event {
+			n, _ := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+			return n
+		}
+	}
+	return 0
+}
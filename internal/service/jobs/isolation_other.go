@@ -0,0 +1,22 @@
+//go:build !linux
+
+package jobs
+
+import (
+	"context"
+	"fmt"
+
+	jobsmodel "gin-artweb/internal/model/jobs"
+	"gin-artweb/internal/shared/config"
+)
+
+// cgroupCommand cgroup隔离依赖Linux的cgroup v2
+func cgroupCommand(
+	ctx context.Context,
+	conf *config.IsolationConfig,
+	job jobCommand,
+	spec *jobsmodel.IsolationSpec,
+	username string,
+) (*isolatedCmd, error) {
+	return nil, fmt.Errorf("cgroup隔离执行仅支持Linux")
+}
@@ -0,0 +1,154 @@
+package jobs
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	jobsmodel "gin-artweb/internal/model/jobs"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/test"
+)
+
+type IsolationTestSuite struct {
+	suite.Suite
+	conf          *config.JobsConfig
+	recordService *RecordService
+}
+
+func (suite *IsolationTestSuite) SetupTest() {
+	suite.conf = &config.JobsConfig{
+		Isolation: &config.IsolationConfig{
+			Runtime:      "podman",
+			DefaultImage: "alpine:3",
+			DefaultUser:  "nobody",
+			AllowedUsers: []string{"ops"},
+		},
+	}
+	suite.recordService = NewScriptRecordService(test.NewTestZapLogger(), nil, nil, suite.conf, nil)
+}
+
+func (suite *IsolationTestSuite) TestParseIsolation() {
+	spec, err := ParseIsolation(`{"mode":"container","cpus":0.5,"memory_mb":256,"pids_limit":64}`)
+	suite.Require().NoError(err)
+	suite.Equal(jobsmodel.IsolationContainer, spec.Mode)
+	suite.Equal(256, spec.MemoryMB)
+
+	for _, image := range []string{
+		"alpine",
+		"alpine:3.19",
+		"registry.example.com:5000/ops/runner:v1.2_3",
+		"docker.io/library/busybox@sha256:" + strings.Repeat("a", 64),
+	} {
+		_, err = ParseIsolation(`{"mode":"container","image":"` + image + `"}`)
+		suite.NoError(err, "有效的镜像引用: %s", image)
+	}
+
+	for _, s := range []string{"", `{}`, `{"mode":""}`} {
+		spec, err = ParseIsolation(s)
+		suite.NoError(err)
+		suite.Nil(spec, "为空或不隔离时返回nil: %s", s)
+	}
+
+	for _, s := range []string{
+		`[]`,
+		`{"mode":"vm"}`,
+		`{"mode":"cgroup","memory_mb":1}`,
+		`{"mode":"cgroup","cpus":-1}`,
+		`{"mode":"cgroup","pids_limit":70000}`,
+		`{"mode":"container","image":"--privileged"}`,
+		`{"mode":"container","image":"alpine --rm"}`,
+		`{"mode":"container","image":"alpine:3;id"}`,
+	} {
+		_, err = ParseIsolation(s)
+		suite.Error(err, "无效的隔离执行配置应该返回错误: %s", s)
+	}
+}
+
+func (suite *IsolationTestSuite) TestContainerCommand() {
+	job := jobCommand{
+		recordID: 12,
+		script:   "/data/scripts/demo/run.sh",
+		args:     []string{"-n", "1"},
+		env:      []string{"COLONY_NUM=01", "DB_PASSWORD=secret"},
+		dir:      "/data/workspace/12",
+	}
+	cmd, err := suite.recordService.command(context.Background(), job, &jobsmodel.IsolationSpec{
+		Mode:      jobsmodel.IsolationContainer,
+		CPUs:      1.5,
+		MemoryMB:  128,
+		PidsLimit: 32,
+	})
+	suite.Require().NoError(err)
+	suite.Equal([]string{
+		"podman", "run", "--name", "gin-artweb-job-12",
+		"--network", "none",
+		"-v", "/data/scripts/demo/run.sh:/job/run.sh:ro",
+		"-v", "/data/workspace/12:/workspace", "-w", "/workspace",
+		"--cpus", "1.5",
+		"--memory", "128m", "--memory-swap", "128m",
+		"--pids-limit", "32",
+		"--user", "nobody",
+		"-e", "COLONY_NUM", "-e", "DB_PASSWORD",
+		"--", "alpine:3", "/job/run.sh", "-n", "1",
+	}, cmd.Args, "命令行参数只包含环境变量名")
+	suite.Subset(cmd.Env, job.env, "环境变量的值通过容器运行时进程的环境变量传入")
+}
+
+func (suite *IsolationTestSuite) TestCommandRejected() {
+	job := jobCommand{recordID: 1, script: "/data/scripts/run.sh"}
+
+	_, err := suite.recordService.command(context.Background(), job, &jobsmodel.IsolationSpec{
+		Mode: jobsmodel.IsolationContainer,
+		User: "root",
+	})
+	suite.Error(err, "不在允许范围内的用户应该返回错误")
+
+	suite.conf.Isolation.DefaultImage = "-v=/:/host"
+	_, err = suite.recordService.command(context.Background(), job, &jobsmodel.IsolationSpec{
+		Mode: jobsmodel.IsolationContainer,
+	})
+	suite.Error(err, "配置的默认镜像同样需要校验")
+	suite.conf.Isolation.DefaultImage = "alpine:3"
+
+	cmd, err := suite.recordService.command(context.Background(), job, &jobsmodel.IsolationSpec{
+		Mode: jobsmodel.IsolationContainer,
+		User: "ops",
+	})
+	suite.Require().NoError(err)
+	suite.Contains(cmd.Args, "ops")
+
+	suite.conf.Isolation = nil
+	_, err = suite.recordService.command(context.Background(), job, &jobsmodel.IsolationSpec{
+		Mode: jobsmodel.IsolationCgroup,
+	})
+	suite.Error(err, "未配置隔离执行时应该返回错误")
+
+	cmd, err = suite.recordService.command(context.Background(), job, nil)
+	suite.Require().NoError(err, "不隔离时直接执行")
+	suite.Equal([]string{"/data/scripts/run.sh"}, cmd.Args)
+}
+
+func (suite *IsolationTestSuite) TestIsolatedEnv() {
+	suite.T().Setenv("PATH", "/usr/bin:/bin")
+	suite.T().Setenv("HOME", "/root")
+	suite.T().Setenv("LANG", "C.UTF-8")
+	suite.T().Setenv("GIN_ARTWEB__DATABASE__DNS", "root:secret@tcp(db)/app")
+
+	suite.Equal(
+		[]string{"PATH=/usr/bin:/bin", "HOME=/root", "LANG=C.UTF-8", "COLONY_NUM=01"},
+		isolatedEnv([]string{"COLONY_NUM=01"}, ""),
+		"不继承服务的其他环境变量",
+	)
+	suite.Equal(
+		[]string{"PATH=/usr/bin:/bin", "HOME=/home/ops", "LANG=C.UTF-8"},
+		isolatedEnv(nil, "/home/ops"),
+		"指定执行用户时使用该用户的HOME",
+	)
+}
+
+func TestIsolationTestSuite(t *testing.T) {
+	suite.Run(t, new(IsolationTestSuite))
+}
@@ -0,0 +1,238 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	jobsmodel "gin-artweb/internal/model/jobs"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+)
+
+const (
+	// resumeTriggerType 续跑生成的执行记录的触发类型
+	resumeTriggerType = "resume"
+
+	// interruptWait 中断脚本后等待执行记录写回状态的最长时间
+	interruptWait = 10 * time.Second
+)
+
+// startRunning 登记执行中的脚本, 返回脚本结束时关闭的通道
+func (s *RecordService) startRunning(id uint32) chan struct{} {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	done := make(chan struct{})
+	s.running[id] = done
+	return done
+}
+
+// finishRunning 脚本执行结束(含执行记录写回)后注销登记
+func (s *RecordService) finishRunning(id uint32, done chan struct{}) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.running, id)
+	delete(s.interrupted, id)
+	close(done)
+}
+
+func (s *RecordService) isInterrupted(id uint32) bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	_, ok := s.interrupted[id]
+	return ok
+}
+
+// runningJobs 返回当前执行中脚本的快照
+func (s *RecordService) runningJobs() map[uint32]chan struct{} {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	jobs := make(map[uint32]chan struct{}, len(s.running))
+	for id, done := range s.running {
+		jobs[id] = done
+	}
+	return jobs
+}
+
+// waitJobs 等待脚本执行结束, 超时或上下文取消时返回仍未结束的脚本
+func waitJobs(ctx context.Context, jobs map[uint32]chan struct{}, timeout time.Duration) []uint32 {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for id, done := range jobs {
+		select {
+		case <-done:
+			delete(jobs, id)
+		case <-timer.C:
+			return pendingJobs(jobs)
+		case <-ctx.Done():
+			return pendingJobs(jobs)
+		}
+	}
+	return nil
+}
+
+func pendingJobs(jobs map[uint32]chan struct{}) []uint32 {
+	ids := make([]uint32, 0, len(jobs))
+	for id, done := range jobs {
+		select {
+		case <-done:
+		default:
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// Shutdown 服务关闭时处理执行中的脚本
+//
+// 先拒绝新的脚本执行, 在配置的等待时间内等待执行中的脚本自然结束,
+// 超时后中断剩余脚本并将执行记录标记为中断状态, 供下次启动时续跑
+func (s *RecordService) Shutdown(ctx context.Context) {
+	s.closing.Store(true)
+
+	jobs := s.runningJobs()
+	if len(jobs) == 0 {
+		return
+	}
+
+	wait := time.Duration(s.conf.ShutdownWait) * time.Second
+	ctxutil.Logger(ctx, s.log).Info(
+		"服务关闭, 等待执行中的脚本结束",
+		zap.Int("running", len(jobs)),
+		zap.Duration("wait", wait),
+	)
+
+	pending := pendingJobs(jobs)
+	if wait > 0 {
+		pending = waitJobs(ctx, jobs, wait)
+	}
+	if len(pending) == 0 {
+		ctxutil.Logger(ctx, s.log).Info("执行中的脚本已全部结束")
+		return
+	}
+
+	ctxutil.Logger(ctx, s.log).Warn(
+		"等待超时, 开始中断执行中的脚本",
+		zap.Uint32s("script_record_ids", pending),
+	)
+	s.mutex.Lock()
+	for _, id := range pending {
+		s.interrupted[id] = struct{}{}
+	}
+	s.mutex.Unlock()
+	for _, id := range pending {
+		if cancel := s.GetCancel(id); cancel != nil {
+			cancel()
+		}
+	}
+
+	if remain := waitJobs(ctx, s.runningJobs(), interruptWait); len(remain) > 0 {
+		ctxutil.Logger(ctx, s.log).Error(
+			"中断脚本后等待执行记录写回超时",
+			zap.Uint32s("script_record_ids", remain),
+		)
+		return
+	}
+	ctxutil.Logger(ctx, s.log).Info(
+		"已中断执行中的脚本",
+		zap.Uint32s("script_record_ids", pending),
+	)
+}
+
+// RecoverInterrupted 启动时处理上次运行遗留的脚本执行记录
+//
+// 仍为执行中状态的记录说明服务异常退出, 标记为中断状态;
+// 开启续跑时, 为脚本允许续跑且未超过续跑次数的中断记录重新执行
+func (s *RecordService) RecoverInterrupted(ctx context.Context) *errors.Error {
+	if ctx.Err() != nil {
+		return errors.FromError(ctx.Err())
+	}
+
+	now := time.Now()
+	if err := s.recordRepo.UpdateModel(ctx, map[string]any{
+		"status":         jobsmodel.RecordStatusInterrupted,
+		"error_message":  "服务异常退出, 脚本执行被中断",
+		"interrupted_at": now,
+	}, "status = ?", jobsmodel.RecordStatusRunning); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"标记遗留的执行中记录为中断状态失败",
+			zap.Error(err),
+		)
+		return errors.NewGormError(err, nil)
+	}
+
+	if !s.conf.ResumeOnStartup {
+		return nil
+	}
+
+	query := map[string]any{
+		"status = ?":     jobsmodel.RecordStatusInterrupted,
+		"resumed_id = ?": 0,
+	}
+	if s.conf.MaxResume > 0 {
+		query["resume_count < ?"] = s.conf.MaxResume
+	}
+	_, ms, err := s.recordRepo.ListModel(ctx, database.QueryParams{
+		Preloads: []string{"Script"},
+		Query:    query,
+		OrderBy:  []string{"id ASC"},
+	})
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询待续跑的执行记录失败",
+			zap.Error(err),
+		)
+		return errors.NewGormError(err, nil)
+	}
+
+	for _, m := range *ms {
+		if !m.Script.Resumable || !m.Script.Status {
+			continue
+		}
+		s.resume(ctx, m)
+	}
+	return nil
+}
+
+// resume 为中断的执行记录创建续跑记录并异步执行
+func (s *RecordService) resume(ctx context.Context, m jobsmodel.ScriptRecordModel) {
+	record, rErr := s.CreateScriptRecord(ctx, jobsmodel.ExecuteRequest{
+		TriggerType: resumeTriggerType,
+		ScriptID:    m.ScriptID,
+		CommandArgs: m.CommandArgs,
+		EnvVars:     m.EnvVars,
+		Params:      paramValueMap(jobsmodel.DecodeScriptParamValues(m.Params)),
+		Timeout:     m.Timeout,
+		WorkDir:     m.WorkDir,
+		Username:    m.Username,
+		ResumeOf:    m.ID,
+		ResumeCount: m.ResumeCount + 1,
+		Isolation:   m.Isolation,
+	})
+	if rErr != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"创建续跑执行记录失败",
+			zap.Error(rErr),
+			zap.Uint32("script_record_id", m.ID),
+		)
+		return
+	}
+	if err := s.recordRepo.UpdateModel(ctx, map[string]any{"resumed_id": record.ID}, "id = ?", m.ID); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"更新中断记录的续跑记录ID失败",
+			zap.Error(err),
+			zap.Uint32("script_record_id", m.ID),
+			zap.Uint32("resumed_id", record.ID),
+		)
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始续跑中断的脚本",
+		zap.Uint32("script_record_id", m.ID),
+		zap.Uint32("resumed_id", record.ID),
+		zap.Int("resume_count", record.ResumeCount),
+	)
+	go s.Execute(record)
+}
@@ -0,0 +1,120 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	jobsmodel "gin-artweb/internal/model/jobs"
+	jobsrepo "gin-artweb/internal/repository/jobs"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/errors"
+	"gin-artweb/internal/shared/test"
+)
+
+type LifecycleTestSuite struct {
+	suite.Suite
+	scriptRepo    *jobsrepo.ScriptRepo
+	recordRepo    *jobsrepo.RecordRepo
+	recordService *RecordService
+}
+
+func (suite *LifecycleTestSuite) SetupTest() {
+	db := test.NewTestGormDBWithConfig(nil)
+	db.AutoMigrate(&jobsmodel.ScriptModel{}, &jobsmodel.ScriptRecordModel{})
+	dbTimeout := test.NewTestDBTimeouts()
+	logger := test.NewTestZapLogger()
+	suite.scriptRepo = jobsrepo.NewScriptRepo(logger, db, dbTimeout)
+	suite.recordRepo = jobsrepo.NewRecordRepo(logger, db, dbTimeout)
+	suite.recordService = NewScriptRecordService(logger, suite.scriptRepo, suite.recordRepo, &config.JobsConfig{}, nil)
+}
+
+func (suite *LifecycleTestSuite) createRecord(status int) *jobsmodel.ScriptRecordModel {
+	script := &jobsmodel.ScriptModel{
+		Name:      uuid.NewString(),
+		Project:   "test",
+		Label:     "cmd",
+		Language:  "bash",
+		Status:    true,
+		Resumable: true,
+	}
+	suite.Require().NoError(suite.scriptRepo.CreateModel(context.Background(), script))
+	record := &jobsmodel.ScriptRecordModel{
+		TriggerType: "cron",
+		Status:      status,
+		Timeout:     300,
+		LogName:     fmt.Sprintf("test-%s.log", uuid.NewString()),
+		ScriptID:    script.ID,
+	}
+	suite.Require().NoError(suite.recordRepo.CreateModel(context.Background(), record))
+	return record
+}
+
+func (suite *LifecycleTestSuite) TestRecoverInterrupted() {
+	running := suite.createRecord(jobsmodel.RecordStatusRunning)
+	success := suite.createRecord(jobsmodel.RecordStatusSuccess)
+
+	suite.Nil(suite.recordService.RecoverInterrupted(context.Background()))
+
+	m, err := suite.recordRepo.GetModel(context.Background(), nil, running.ID)
+	suite.NoError(err)
+	suite.Equal(jobsmodel.RecordStatusInterrupted, m.Status, "遗留的执行中记录应该标记为中断")
+	suite.NotNil(m.InterruptedAt)
+	suite.Zero(m.ResumedID, "未开启续跑时不应该创建续跑记录")
+
+	m, err = suite.recordRepo.GetModel(context.Background(), nil, success.ID)
+	suite.NoError(err)
+	suite.Equal(jobsmodel.RecordStatusSuccess, m.Status, "已结束的记录不应该被修改")
+}
+
+func (suite *LifecycleTestSuite) TestShutdownRejectsNewExecution() {
+	record := suite.createRecord(jobsmodel.RecordStatusSuccess)
+	suite.recordService.Shutdown(context.Background())
+
+	_, rErr := suite.recordService.CreateScriptRecord(context.Background(), jobsmodel.ExecuteRequest{
+		TriggerType: "api",
+		ScriptID:    record.ScriptID,
+	})
+	suite.NotNil(rErr)
+	suite.True(rErr.Is(errors.ErrJobsShuttingDown), "服务关闭后应该拒绝新的脚本执行")
+}
+
+func (suite *LifecycleTestSuite) TestShutdownInterruptsRunningJobs() {
+	s := suite.recordService
+	s.conf.ShutdownWait = 0
+
+	// 模拟一个只有被取消后才结束的执行中脚本
+	ctx, cancel := context.WithCancel(context.Background())
+	s.StoreCancel(1, cancel)
+	done := s.startRunning(1)
+	interrupted := make(chan bool, 1)
+	go func() {
+		<-ctx.Done()
+		interrupted <- s.isInterrupted(1)
+		s.finishRunning(1, done)
+	}()
+
+	s.Shutdown(context.Background())
+
+	suite.True(<-interrupted, "超过等待时间的脚本应该被标记为中断")
+	suite.Empty(s.runningJobs())
+}
+
+func (suite *LifecycleTestSuite) TestWaitJobs() {
+	finished := make(chan struct{})
+	close(finished)
+	jobs := map[uint32]chan struct{}{
+		1: finished,
+		2: make(chan struct{}),
+	}
+	pending := waitJobs(context.Background(), jobs, 50*time.Millisecond)
+	suite.Equal([]uint32{2}, pending)
+}
+
+func TestLifecycleTestSuite(t *testing.T) {
+	suite.Run(t, new(LifecycleTestSuite))
+}
@@ -0,0 +1,188 @@
+package jobs
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	jobsmodel "gin-artweb/internal/model/jobs"
+)
+
+const (
+	maxScriptParams     = 20  // 单个脚本最多定义的参数数
+	maxScriptParamValue = 256 // 字符串参数值的最大长度
+)
+
+var paramNameRe = regexp.MustCompile(`^[a-z][a-z0-9_]{0,31}$`)
+
+// ParseScriptParams 解析并校验脚本的参数定义(JSON数组), 为空时返回nil
+func ParseScriptParams(s string) ([]jobsmodel.ScriptParam, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	var params []jobsmodel.ScriptParam
+	if err := json.Unmarshal([]byte(s), &params); err != nil {
+		return nil, fmt.Errorf("参数定义不是有效的JSON数组: %w", err)
+	}
+	if len(params) > maxScriptParams {
+		return nil, fmt.Errorf("最多定义%d个参数", maxScriptParams)
+	}
+
+	names := make(map[string]bool, len(params))
+	for i := range params {
+		p := &params[i]
+		if !paramNameRe.MatchString(p.Name) {
+			return nil, fmt.Errorf("参数名%q无效, 只能包含小写字母、数字和下划线且以字母开头", p.Name)
+		}
+		if names[p.Name] {
+			return nil, fmt.Errorf("参数%s重复定义", p.Name)
+		}
+		names[p.Name] = true
+
+		switch p.Type {
+		case jobsmodel.ParamTypeString, jobsmodel.ParamTypeInt, jobsmodel.ParamTypeBool, jobsmodel.ParamTypeDate:
+		case jobsmodel.ParamTypeEnum:
+			if len(p.Enum) == 0 {
+				return nil, fmt.Errorf("枚举参数%s缺少可选值", p.Name)
+			}
+			for _, v := range p.Enum {
+				if err := checkParamText(v); err != nil {
+					return nil, fmt.Errorf("参数%s的可选值%q无效: %w", p.Name, v, err)
+				}
+			}
+		default:
+			return nil, fmt.Errorf("参数%s的类型%q无效", p.Name, p.Type)
+		}
+
+		switch p.Inject {
+		case "":
+			p.Inject = jobsmodel.ParamInjectEnv
+		case jobsmodel.ParamInjectEnv, jobsmodel.ParamInjectArg:
+		default:
+			return nil, fmt.Errorf("参数%s的传入方式%q无效", p.Name, p.Inject)
+		}
+
+		if p.Default != "" && !(p.Type == jobsmodel.ParamTypeDate && p.Default == "today") {
+			if _, err := normalizeParamValue(*p, p.Default); err != nil {
+				return nil, fmt.Errorf("参数%s的默认值无效: %w", p.Name, err)
+			}
+		}
+	}
+	return params, nil
+}
+
+// ResolveScriptParams 按参数定义校验触发时提交的参数值, 未提交的参数使用默认值
+//
+// now用于解析日期参数的默认值today; 提交了未定义的参数或必填参数没有值时返回错误
+func ResolveScriptParams(
+	params []jobsmodel.ScriptParam,
+	values map[string]string,
+	now time.Time,
+) ([]jobsmodel.ScriptParamValue, error) {
+	for name := range values {
+		if !slices.ContainsFunc(params, func(p jobsmodel.ScriptParam) bool { return p.Name == name }) {
+			return nil, fmt.Errorf("脚本未定义参数%s", name)
+		}
+	}
+
+	resolved := make([]jobsmodel.ScriptParamValue, 0, len(params))
+	for _, p := range params {
+		value, ok := values[p.Name]
+		if !ok || value == "" {
+			value = p.Default
+			if p.Type == jobsmodel.ParamTypeDate && value == "today" {
+				value = now.Format(time.DateOnly)
+			}
+		}
+		if value == "" {
+			if p.Required {
+				return nil, fmt.Errorf("缺少必填参数%s", p.Name)
+			}
+			continue
+		}
+		normalized, err := normalizeParamValue(p, value)
+		if err != nil {
+			return nil, fmt.Errorf("参数%s的值无效: %w", p.Name, err)
+		}
+		inject := p.Inject
+		if inject == "" {
+			inject = jobsmodel.ParamInjectEnv
+		}
+		resolved = append(resolved, jobsmodel.ScriptParamValue{Name: p.Name, Value: normalized, Inject: inject})
+	}
+	return resolved, nil
+}
+
+// normalizeParamValue 按参数类型校验并规范化参数值
+func normalizeParamValue(p jobsmodel.ScriptParam, value string) (string, error) {
+	switch p.Type {
+	case jobsmodel.ParamTypeInt:
+		n, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("%q不是整数", value)
+		}
+		return strconv.FormatInt(n, 10), nil
+	case jobsmodel.ParamTypeBool:
+		b, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return "", fmt.Errorf("%q不是布尔值", value)
+		}
+		return strconv.FormatBool(b), nil
+	case jobsmodel.ParamTypeDate:
+		t, err := time.Parse(time.DateOnly, strings.TrimSpace(value))
+		if err != nil {
+			return "", fmt.Errorf("%q不是日期, 格式应为2006-01-02", value)
+		}
+		return t.Format(time.DateOnly), nil
+	case jobsmodel.ParamTypeEnum:
+		if !slices.Contains(p.Enum, value) {
+			return "", fmt.Errorf("%q不在可选值中", value)
+		}
+		return value, nil
+	default:
+		if err := checkParamText(value); err != nil {
+			return "", err
+		}
+		return value, nil
+	}
+}
+
+// checkParamText 限制文本参数的长度并拒绝控制字符, 参数值不经过shell, 其余字符原样传入
+func checkParamText(value string) error {
+	if len(value) > maxScriptParamValue {
+		return fmt.Errorf("长度不能超过%d", maxScriptParamValue)
+	}
+	if strings.ContainsFunc(value, unicode.IsControl) {
+		return fmt.Errorf("不能包含控制字符")
+	}
+	return nil
+}
+
+// paramEnvAndArgs 返回参数对应的环境变量和命令行参数
+func paramEnvAndArgs(values []jobsmodel.ScriptParamValue) (env, args []string) {
+	for _, v := range values {
+		if v.Inject == jobsmodel.ParamInjectArg {
+			args = append(args, fmt.Sprintf("--%s=%s", v.Name, v.Value))
+		} else {
+			env = append(env, fmt.Sprintf("%s%s=%s", jobsmodel.ParamEnvPrefix, strings.ToUpper(v.Name), v.Value))
+		}
+	}
+	return env, args
+}
+
+// paramValueMap 参数值转换为参数名到值的映射, 用于续跑时重新提交相同的参数
+func paramValueMap(values []jobsmodel.ScriptParamValue) map[string]string {
+	if len(values) == 0 {
+		return nil
+	}
+	m := make(map[string]string, len(values))
+	for _, v := range values {
+		m[v.Name] = v.Value
+	}
+	return m
+}
@@ -0,0 +1,130 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	jobsmodel "gin-artweb/internal/model/jobs"
+	jobsrepo "gin-artweb/internal/repository/jobs"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/errors"
+	"gin-artweb/internal/shared/test"
+)
+
+const testParamsDefinition = `[
+	{"name":"colony_num","type":"enum","required":true,"enum":["01","02"]},
+	{"name":"trade_date","type":"date","default":"today","inject":"arg"},
+	{"name":"batch","type":"int","default":"100"},
+	{"name":"dry","type":"bool"},
+	{"name":"note","type":"string"}
+]`
+
+type ParamsTestSuite struct {
+	suite.Suite
+	scriptRepo    *jobsrepo.ScriptRepo
+	recordService *RecordService
+}
+
+func (suite *ParamsTestSuite) SetupTest() {
+	db := test.NewTestGormDBWithConfig(nil)
+	db.AutoMigrate(&jobsmodel.ScriptModel{}, &jobsmodel.ScriptRecordModel{})
+	dbTimeout := test.NewTestDBTimeouts()
+	logger := test.NewTestZapLogger()
+	suite.scriptRepo = jobsrepo.NewScriptRepo(logger, db, dbTimeout)
+	recordRepo := jobsrepo.NewRecordRepo(logger, db, dbTimeout)
+	suite.recordService = NewScriptRecordService(logger, suite.scriptRepo, recordRepo, &config.JobsConfig{}, nil)
+}
+
+func (suite *ParamsTestSuite) TestParseScriptParams() {
+	params, err := ParseScriptParams(testParamsDefinition)
+	suite.Require().NoError(err)
+	suite.Len(params, 5)
+	suite.Equal(jobsmodel.ParamInjectEnv, params[0].Inject, "默认以环境变量传入")
+
+	params, err = ParseScriptParams("")
+	suite.NoError(err)
+	suite.Nil(params)
+
+	invalid := []string{
+		`{"name":"a"}`,
+		`[{"name":"Colony","type":"string"}]`,
+		`[{"name":"a","type":"string"},{"name":"a","type":"int"}]`,
+		`[{"name":"a","type":"float"}]`,
+		`[{"name":"a","type":"enum"}]`,
+		`[{"name":"a","type":"string","inject":"stdin"}]`,
+		`[{"name":"a","type":"int","default":"x"}]`,
+		`[{"name":"a","type":"date","default":"yesterday"}]`,
+	}
+	for _, s := range invalid {
+		_, err := ParseScriptParams(s)
+		suite.Error(err, s)
+	}
+}
+
+func (suite *ParamsTestSuite) TestResolveScriptParams() {
+	params, err := ParseScriptParams(testParamsDefinition)
+	suite.Require().NoError(err)
+	now := time.Date(2024, 3, 1, 9, 0, 0, 0, time.Local)
+
+	values, err := ResolveScriptParams(params, map[string]string{"colony_num": "02", "dry": "1", "note": "a b;c"}, now)
+	suite.Require().NoError(err)
+	suite.Equal([]jobsmodel.ScriptParamValue{
+		{Name: "colony_num", Value: "02", Inject: "env"},
+		{Name: "trade_date", Value: "2024-03-01", Inject: "arg"},
+		{Name: "batch", Value: "100", Inject: "env"},
+		{Name: "dry", Value: "true", Inject: "env"},
+		{Name: "note", Value: "a b;c", Inject: "env"},
+	}, values)
+
+	env, args := paramEnvAndArgs(values)
+	suite.Equal([]string{"PARAM_COLONY_NUM=02", "PARAM_BATCH=100", "PARAM_DRY=true", "PARAM_NOTE=a b;c"}, env)
+	suite.Equal([]string{"--trade_date=2024-03-01"}, args, "参数值作为单个参数传入, 不拆分")
+
+	invalid := []map[string]string{
+		{},
+		{"colony_num": "03"},
+		{"colony_num": "01", "batch": "1.5"},
+		{"colony_num": "01", "trade_date": "20240301"},
+		{"colony_num": "01", "note": "a\nb"},
+		{"colony_num": "01", "unknown": "1"},
+	}
+	for _, v := range invalid {
+		_, err := ResolveScriptParams(params, v, now)
+		suite.Error(err, v)
+	}
+}
+
+func (suite *ParamsTestSuite) TestCreateScriptRecordWithParams() {
+	ctx := context.Background()
+	script := &jobsmodel.ScriptModel{
+		Name:     "params.sh",
+		Project:  "test",
+		Label:    "cmd",
+		Language: "bash",
+		Status:   true,
+		Params:   testParamsDefinition,
+	}
+	suite.Require().NoError(suite.scriptRepo.CreateModel(ctx, script))
+
+	_, rErr := suite.recordService.CreateScriptRecord(ctx, jobsmodel.ExecuteRequest{ScriptID: script.ID, Timeout: 60})
+	suite.Require().NotNil(rErr, "缺少必填参数")
+	suite.Equal(errors.ErrValidationFailed.Reason, rErr.Reason)
+
+	record, rErr := suite.recordService.CreateScriptRecord(ctx, jobsmodel.ExecuteRequest{
+		ScriptID: script.ID,
+		Timeout:  60,
+		Params:   map[string]string{"colony_num": "01", "trade_date": "2024-03-01"},
+	})
+	suite.Require().Nil(rErr)
+	values := jobsmodel.DecodeScriptParamValues(record.Params)
+	suite.Require().Len(values, 3)
+	suite.Equal(map[string]string{"colony_num": "01", "trade_date": "2024-03-01", "batch": "100"}, paramValueMap(values),
+		"执行记录保存解析后的参数用于审计")
+}
+
+func TestParamsTestSuite(t *testing.T) {
+	suite.Run(t, new(ParamsTestSuite))
+}
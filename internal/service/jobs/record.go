@@ -1,6 +1,7 @@
 package jobs
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -10,7 +11,7 @@ import (
 	"runtime/debug"
 	"strings"
 	"sync"
-	"syscall"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
@@ -23,29 +24,53 @@ import (
 	"gin-artweb/internal/shared/ctxutil"
 	"gin-artweb/internal/shared/database"
 	"gin-artweb/internal/shared/errors"
+	"gin-artweb/internal/shared/events"
+	"gin-artweb/internal/shared/metrics"
 )
 
 type RecordService struct {
 	log        *zap.Logger
 	scriptRepo *jobsrepo.ScriptRepo
 	recordRepo *jobsrepo.RecordRepo
+	conf       *config.JobsConfig
+	outbox     *events.Outbox
+	artifacts  *ArtifactService
 	contexts   map[uint32]context.CancelFunc
 	mutex      sync.RWMutex
+
+	// 执行生命周期, 用于服务关闭时等待或中断执行中的脚本
+	running     map[uint32]chan struct{}
+	interrupted map[uint32]struct{}
+	closing     atomic.Bool
 }
 
 func NewScriptRecordService(
 	log *zap.Logger,
 	scriptRepo *jobsrepo.ScriptRepo,
 	recordRepo *jobsrepo.RecordRepo,
+	conf *config.JobsConfig,
+	outbox *events.Outbox,
 ) *RecordService {
+	if conf == nil {
+		conf = &config.JobsConfig{}
+	}
 	return &RecordService{
-		log:        log,
-		scriptRepo: scriptRepo,
-		recordRepo: recordRepo,
-		contexts:   make(map[uint32]context.CancelFunc),
+		log:         log,
+		scriptRepo:  scriptRepo,
+		recordRepo:  recordRepo,
+		conf:        conf,
+		outbox:      outbox,
+		contexts:    make(map[uint32]context.CancelFunc),
+		running:     make(map[uint32]chan struct{}),
+		interrupted: make(map[uint32]struct{}),
 	}
 }
 
+// SetArtifactService 设置执行产物服务, 设置后脚本执行结束时按脚本配置的匹配规则收集产物, 需要在服务启动前调用
+func (s *RecordService) SetArtifactService(artifacts *ArtifactService) {
+	s.artifacts = artifacts
+}
+
 // 存储上下文
 func (s *RecordService) StoreCancel(id uint32, cancel context.CancelFunc) {
 	s.mutex.Lock()
@@ -75,6 +100,8 @@ func (s *RecordService) Execute(record *jobsmodel.ScriptRecordModel) *jobsmodel.
 	// 初始化执行任务
 	ctx, cancel := context.WithCancel(context.Background())
 	s.StoreCancel(record.ID, cancel)
+	done := s.startRunning(record.ID)
+	defer s.finishRunning(record.ID, done)
 
 	// 创建带超时的上下文
 	timeout := time.Duration(record.Timeout) * time.Second
@@ -85,6 +112,7 @@ func (s *RecordService) Execute(record *jobsmodel.ScriptRecordModel) *jobsmodel.
 	ctx, timeoutCancel = context.WithTimeout(ctx, timeout)
 	defer timeoutCancel()
 
+	runStart := time.Now()
 	taskinfo := &jobsmodel.TaskInfo{
 		ExitCode: -1,
 		Status:   3,
@@ -126,18 +154,48 @@ func (s *RecordService) Execute(record *jobsmodel.ScriptRecordModel) *jobsmodel.
 			taskinfo.Status = 5
 		}
 
-		// 更新记录状态
-		if err := s.UpdateScriptRecord(context.Background(), record.ID, taskinfo); err != nil {
+		// 服务关闭时被中断的脚本标记为中断状态, 以便下次启动时续跑
+		if s.isInterrupted(record.ID) {
+			now := time.Now()
+			taskinfo.Status = jobsmodel.RecordStatusInterrupted
+			taskinfo.ErrMSG = "服务关闭, 脚本执行被中断"
+			taskinfo.InterruptedAt = &now
+			if taskinfo.LogFile != nil {
+				fmt.Fprintf(taskinfo.LogFile, "[%s] 服务关闭, 脚本执行被中断\n", now.Format(time.RFC3339))
+			}
+		}
+
+		// 更新记录状态, 脚本执行结束事件在同一事务中写入发件箱
+		status := jobsmodel.RecordStatusText(taskinfo.Status)
+		txCtx := s.outbox.Stage(context.Background(), func() events.Payload {
+			return jobsmodel.JobFinishedEvent{
+				RecordID:    record.ID,
+				ScriptID:    record.ScriptID,
+				Project:     record.Script.Project,
+				Label:       record.Script.Label,
+				Name:        record.Script.Name,
+				CommandArgs: record.CommandArgs,
+				TriggerType: record.TriggerType,
+				Status:      status,
+				ExitCode:    taskinfo.ExitCode,
+				Username:    record.Username,
+			}
+		})
+		if err := s.UpdateScriptRecord(txCtx, record.ID, taskinfo); err != nil {
 			if taskinfo.LogFile != nil {
 				fmt.Fprintf(taskinfo.LogFile, "[%s] [ERROR] 更新脚本记录状态失败: %s\n", time.Now().Format(time.RFC3339), err.Error())
 			}
 		}
+		s.outbox.Notify()
 
 		// 关闭日志文件句柄
 		if taskinfo.LogFile != nil {
 			taskinfo.LogFile.Close()
 		}
 
+		metrics.JobRunsTotal.WithLabelValues(record.TriggerType, status).Inc()
+		metrics.JobRunDuration.WithLabelValues(record.TriggerType, status).Observe(time.Since(runStart).Seconds())
+
 		// 清理执行完成的上下文
 		s.DeleteCancel(record.ID)
 
@@ -154,11 +212,10 @@ func (s *RecordService) Execute(record *jobsmodel.ScriptRecordModel) *jobsmodel.
 	if taskinfo.Error = os.MkdirAll(logDir, 0755); taskinfo.Error != nil {
 		taskinfo.Status = 5
 		taskinfo.ErrMSG = fmt.Sprintf("创建日志目录失败: %v", taskinfo.Error)
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"创建日志目录失败",
 			zap.Error(taskinfo.Error),
 			zap.String("path", logDir),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return taskinfo
 	}
@@ -168,11 +225,10 @@ func (s *RecordService) Execute(record *jobsmodel.ScriptRecordModel) *jobsmodel.
 	if taskinfo.Error != nil {
 		taskinfo.Status = 5
 		taskinfo.ErrMSG = fmt.Sprintf("创建日志文件失败: %v", taskinfo.Error)
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"创建日志文件失败",
 			zap.Error(taskinfo.Error),
 			zap.String("path", logPath),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return taskinfo
 	}
@@ -194,25 +250,9 @@ func (s *RecordService) Execute(record *jobsmodel.ScriptRecordModel) *jobsmodel.
 	if record.CommandArgs != "" {
 		cmdArgs = strings.Fields(record.CommandArgs)
 	}
-
-	cmd := exec.CommandContext(ctx, scriptPath, cmdArgs...)
-	cmd.SysProcAttr = &syscall.SysProcAttr{
-		Setpgid: true,
-		Pgid:    0,
-	}
-	cmd.Cancel = func() error {
-		if cmd.Process == nil {
-			return nil
-		}
-
-		// 获取进程组ID
-		pgid, err := syscall.Getpgid(cmd.Process.Pid)
-		if err == nil {
-			return syscall.Kill(-pgid, syscall.SIGKILL)
-		} else {
-			return cmd.Process.Kill()
-		}
-	}
+	// 脚本参数作为独立的参数和环境变量传入, 不经过shell解析
+	paramEnv, paramArgs := paramEnvAndArgs(jobsmodel.DecodeScriptParamValues(record.Params))
+	cmdArgs = append(cmdArgs, paramArgs...)
 
 	// 设置工作目录
 	if record.WorkDir != "" {
@@ -223,23 +263,45 @@ func (s *RecordService) Execute(record *jobsmodel.ScriptRecordModel) *jobsmodel.
 				return taskinfo
 			}
 		}
-		cmd.Dir = record.WorkDir
 	}
 
-	cmd.Env = os.Environ()
-	cmd.Env = append(cmd.Env, fmt.Sprintf("JOBS_RECORD_ID=%d", record.ID))
-	cmd.Env = append(cmd.Env, fmt.Sprintf("JOBS_LOG_PATH=%s", logPath))
-	cmd.Env = append(cmd.Env, fmt.Sprintf("JOBS_BASE_DIR=%s", config.BaseDir))
+	env := []string{
+		fmt.Sprintf("JOBS_RECORD_ID=%d", record.ID),
+		fmt.Sprintf("JOBS_LOG_PATH=%s", logPath),
+		fmt.Sprintf("JOBS_BASE_DIR=%s", config.BaseDir),
+	}
 	if record.EnvVars != "" {
 		var envMap map[string]string
 		if err := json.Unmarshal([]byte(record.EnvVars), &envMap); err == nil {
 			for k, v := range envMap {
 				if k != "" {
-					cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", k, v))
+					env = append(env, fmt.Sprintf("%s=%s", k, v))
 				}
 			}
 		}
 	}
+	env = append(env, paramEnv...)
+
+	// 按隔离配置创建命令, 隔离执行环境创建失败时标记为崩溃
+	spec := jobsmodel.DecodeIsolation(record.Isolation)
+	cmd, err := s.command(ctx, jobCommand{
+		recordID: record.ID,
+		script:   scriptPath,
+		args:     cmdArgs,
+		env:      env,
+		dir:      record.WorkDir,
+	}, spec)
+	if err != nil {
+		taskinfo.Error = err
+		taskinfo.Status = 5
+		taskinfo.ErrMSG = fmt.Sprintf("创建隔离执行环境失败: %v", err)
+		fmt.Fprintf(taskinfo.LogFile, "[%s] %s\n", time.Now().Format(time.RFC3339), taskinfo.ErrMSG)
+		return taskinfo
+	}
+	defer cmd.cleanup()
+	if spec.Enabled() {
+		fmt.Fprintf(taskinfo.LogFile, "隔离执行: %s\n", record.Isolation)
+	}
 
 	// 重定向输出到日志文件
 	cmd.Stdout = taskinfo.LogFile
@@ -259,35 +321,42 @@ func (s *RecordService) Execute(record *jobsmodel.ScriptRecordModel) *jobsmodel.
 		}
 		fmt.Fprintf(taskinfo.LogFile, "[%s] 脚本执行失败 (退出码: %d, 耗时: %.3fs): %s\n",
 			endTime.Format(time.RFC3339), taskinfo.ExitCode, duration, taskinfo.Error)
+		if reason := cmd.reason(); reason != "" {
+			taskinfo.FailureReason = reason
+			taskinfo.ErrMSG = failureReasonMessages[reason]
+			fmt.Fprintf(taskinfo.LogFile, "[%s] %s\n", endTime.Format(time.RFC3339), taskinfo.ErrMSG)
+		}
 	} else {
 		taskinfo.ExitCode = 0
 		taskinfo.Status = 2 // 成功状态
 		fmt.Fprintf(taskinfo.LogFile, "[%s] 脚本执行成功 (耗时: %.3fs)\n",
 			endTime.Format(time.RFC3339), duration)
 	}
+
+	// 执行失败时也收集产物, 便于排查; 执行可能已超时或被取消, 收集不使用执行的上下文
+	if s.artifacts != nil {
+		s.artifacts.Collect(context.Background(), record, taskinfo.LogFile)
+	}
 	return taskinfo
 }
 
 func (s *RecordService) Cancel(ctx context.Context, recordID uint32) {
 	cancel := s.GetCancel(recordID)
 	if cancel == nil {
-		s.log.Warn(
+		ctxutil.Logger(ctx, s.log).Warn(
 			"未找到要取消的脚本任务",
 			zap.Uint32("script_record_id", recordID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 	} else {
-		s.log.Info(
+		ctxutil.Logger(ctx, s.log).Info(
 			"开始取消脚本执行",
 			zap.Uint32("script_record_id", recordID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		cancel()
 		s.DeleteCancel(recordID)
-		s.log.Info(
+		ctxutil.Logger(ctx, s.log).Info(
 			"取消脚本执行成功",
 			zap.Uint32("script_record_id", recordID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 	}
 }
@@ -299,28 +368,49 @@ func (s *RecordService) CreateScriptRecord(
 	if ctx.Err() != nil {
 		return nil, errors.FromError(ctx.Err())
 	}
+	if s.closing.Load() {
+		return nil, errors.ErrJobsShuttingDown.WithField("script_id", req.ScriptID)
+	}
 
 	script, err := s.scriptRepo.GetModel(ctx, req.ScriptID)
 	if err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"查询脚本失败",
 			zap.Error(err),
 			zap.Uint32("script_id", req.ScriptID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.NewGormError(err, map[string]any{"id": req.ScriptID})
 	}
 
 	if !script.Status {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"脚本已禁用",
 			zap.Uint32("script_id", req.ScriptID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.FromReason(errors.ReasonScriptIsDisabled).WithField("script_id", req.ScriptID)
 	}
 
 	now := time.Now()
+	params, err := ParseScriptParams(script.Params)
+	var resolved []jobsmodel.ScriptParamValue
+	if err == nil {
+		resolved, err = ResolveScriptParams(params, req.Params, now)
+	}
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"脚本参数无效",
+			zap.Error(err),
+			zap.Uint32("script_id", req.ScriptID),
+			zap.Any("params", req.Params),
+		)
+		return nil, errors.ErrValidationFailed.WithCause(err)
+	}
+	var paramsJSON string
+	if len(resolved) > 0 {
+		b, _ := json.Marshal(resolved)
+		paramsJSON = string(b)
+	}
+
 	record := &jobsmodel.ScriptRecordModel{
 		StandardModel: database.StandardModel{
 			CreatedAt: now,
@@ -331,20 +421,23 @@ func (s *RecordService) CreateScriptRecord(
 		ExitCode:     -1,
 		EnvVars:      req.EnvVars,
 		CommandArgs:  req.CommandArgs,
+		Params:       paramsJSON,
 		WorkDir:      req.WorkDir,
 		Timeout:      req.Timeout,
 		LogName:      fmt.Sprintf("%s.log", uuid.NewString()),
 		ErrorMessage: "",
 		Username:     req.Username,
 		ScriptID:     req.ScriptID,
+		ResumeOf:     req.ResumeOf,
+		ResumeCount:  req.ResumeCount,
+		Isolation:    cmp.Or(req.Isolation, script.Isolation),
 	}
 
 	if err := s.recordRepo.CreateModel(ctx, record); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"创建执行记录失败",
 			zap.Error(err),
 			zap.Uint32("script_id", req.ScriptID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.NewGormError(err, nil)
 	}
@@ -358,11 +451,11 @@ func (s *RecordService) UpdateScriptRecord(
 	recordID uint32,
 	taskinfo *jobsmodel.TaskInfo,
 ) *errors.Error {
-	s.log.Debug(
+	ctxutil.Logger(ctx, s.log).Debug(
 		"开始更新执行记录状态",
 	)
 	if err := s.recordRepo.UpdateModel(ctx, taskinfo.ToMap(), "id = ?", recordID); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"更新脚本记录失败",
 			zap.Error(err),
 			zap.Uint32("script_record_id", recordID),
@@ -382,28 +475,25 @@ func (s *RecordService) FindScriptRecordByID(
 		return nil, errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始查询脚本执行记录",
 		zap.Uint32("script_record_id", recordID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	m, err := s.recordRepo.GetModel(ctx, preloads, recordID)
 	if err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"查询脚本执行记录失败",
 			zap.Error(err),
 			zap.Uint32("script_record_id", recordID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.NewGormError(err, map[string]any{"id": recordID})
 
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"查询脚本执行记录成功",
 		zap.Uint32("script_record_id", recordID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return m, nil
 }
@@ -416,27 +506,24 @@ func (s *RecordService) ListcriptRecord(
 		return 0, nil, errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始查询脚本执行记录列表",
 		zap.Object(database.QueryParamsKey, &qp),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	count, ms, err := s.recordRepo.ListModel(ctx, qp)
 	if err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"查询脚本执行记录列表失败",
 			zap.Error(err),
 			zap.Object(database.QueryParamsKey, &qp),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return 0, nil, errors.NewGormError(err, nil)
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"查询脚本执行记录列表成功",
 		zap.Object(database.QueryParamsKey, &qp),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return count, ms, nil
 }
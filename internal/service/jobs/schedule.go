@@ -2,14 +2,18 @@ package jobs
 
 import (
 	"context"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/robfig/cron/v3"
 	"go.uber.org/zap"
 
+	commodel "gin-artweb/internal/model/common"
 	jobsmodel "gin-artweb/internal/model/jobs"
+	sysmodel "gin-artweb/internal/model/system"
 	jobsrepo "gin-artweb/internal/repository/jobs"
+	syssvc "gin-artweb/internal/service/system"
 	"gin-artweb/internal/shared/ctxutil"
 	"gin-artweb/internal/shared/database"
 	"gin-artweb/internal/shared/errors"
@@ -20,7 +24,10 @@ type ScheduleService struct {
 	scriptRepo    *jobsrepo.ScriptRepo
 	scheduleRepo  *jobsrepo.ScheduleRepo
 	recordService *RecordService
+	calendar      *CalendarService
+	maintenance   *syssvc.MaintenanceService
 	crontab       *cron.Cron
+	colonyEnv     ColonyEnvResolver
 	entryMap      map[uint32]cron.EntryID
 	mutex         sync.RWMutex
 }
@@ -30,6 +37,8 @@ func NewScheduleService(
 	scriptRepo *jobsrepo.ScriptRepo,
 	scheduleRepo *jobsrepo.ScheduleRepo,
 	recordService *RecordService,
+	calendar *CalendarService,
+	maintenance *syssvc.MaintenanceService,
 	crontab *cron.Cron,
 ) *ScheduleService {
 	return &ScheduleService{
@@ -37,6 +46,8 @@ func NewScheduleService(
 		scriptRepo:    scriptRepo,
 		scheduleRepo:  scheduleRepo,
 		recordService: recordService,
+		calendar:      calendar,
+		maintenance:   maintenance,
 		crontab:       crontab,
 		entryMap:      make(map[uint32]cron.EntryID),
 	}
@@ -51,14 +62,33 @@ func (s *ScheduleService) addJob(ctx context.Context, m *jobsmodel.ScheduleModel
 	defer s.mutex.Unlock()
 
 	entryID, err := s.crontab.AddJob(m.Specification, cron.FuncJob(func() {
+		now := time.Now()
+		if s.skipByCalendar(m, now) || s.skipByMaintenance(m, now) {
+			return
+		}
+
+		// 绑定集群的计划任务在触发时解析集群上下文变量, 解析失败时不执行, 避免使用错误的集群信息
+		envVars, rErr := s.triggerEnvVars(context.Background(), m)
+		if rErr != nil {
+			ctxutil.Logger(ctx, s.log).Error(
+				"解析计划任务的环境变量失败, 跳过本次执行",
+				zap.Error(rErr),
+				zap.Uint32("schedule_id", m.ID),
+				zap.String("colony", m.Colony),
+			)
+			return
+		}
+
 		execReq := jobsmodel.ExecuteRequest{
 			CommandArgs: m.CommandArgs,
-			EnvVars:     m.EnvVars,
+			EnvVars:     envVars,
+			Params:      jobsmodel.DecodeParamValues(m.Params),
 			ScriptID:    m.ScriptID,
 			Timeout:     m.Timeout,
 			TriggerType: "cron",
 			WorkDir:     m.WorkDir,
 			Username:    m.Username,
+			Isolation:   m.Isolation,
 		}
 
 		var retryCount int
@@ -70,7 +100,7 @@ func (s *ScheduleService) addJob(ctx context.Context, m *jobsmodel.ScheduleModel
 		for retryCount < maxRetryCount {
 			taskinfo, err := s.recordService.SyncExecuteScript(context.Background(), execReq)
 			if err == nil && taskinfo.Status == 2 {
-				s.log.Info(
+				ctxutil.Logger(ctx, s.log).Info(
 					"计划任务执行成功",
 					zap.Uint32("schedule_id", m.ID),
 					zap.Int("attempt", retryCount+1),
@@ -81,7 +111,7 @@ func (s *ScheduleService) addJob(ctx context.Context, m *jobsmodel.ScheduleModel
 				retryCount++
 				if retryCount < maxRetryCount {
 					waitTime := time.Duration(m.RetryInterval) * time.Second
-					s.log.Error(
+					ctxutil.Logger(ctx, s.log).Error(
 						"计划任务执行失败，准备重试",
 						zap.Uint32("schedule_id", m.ID),
 						zap.Int("attempt", retryCount),
@@ -90,7 +120,7 @@ func (s *ScheduleService) addJob(ctx context.Context, m *jobsmodel.ScheduleModel
 					)
 					time.Sleep(waitTime)
 				} else {
-					s.log.Error(
+					ctxutil.Logger(ctx, s.log).Error(
 						"计划任务最终执行失败，已达到最大重试次数",
 						zap.Uint32("schedule_id", m.ID),
 						zap.Int("attempt", retryCount),
@@ -101,11 +131,10 @@ func (s *ScheduleService) addJob(ctx context.Context, m *jobsmodel.ScheduleModel
 		}
 	}))
 	if err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"添加计划任务到调度器中失败",
 			zap.Error(err),
 			zap.Object(database.ModelKey, m),
-			zap.String(string(ctxutil.TraceIDKey), ctxutil.GetTraceID(ctx)),
 		)
 		return errors.FromReason(errors.ReasonUnknown).WithCause(err)
 	}
@@ -114,6 +143,100 @@ func (s *ScheduleService) addJob(ctx context.Context, m *jobsmodel.ScheduleModel
 	return nil
 }
 
+// skipByCalendar 按计划任务的交易日历选项判断本次触发是否跳过, 跳过时记录跳过日志
+//
+// 交易日历查询失败时不跳过, 避免因数据库异常漏跑日常任务
+func (s *ScheduleService) skipByCalendar(m *jobsmodel.ScheduleModel, now time.Time) bool {
+	if m.CalendarMode == jobsmodel.CalendarModeNone || s.calendar == nil {
+		return false
+	}
+
+	ctx := context.Background()
+	reason, detail, rErr := s.calendar.SkipReason(ctx, m.CalendarMode, now)
+	if rErr != nil {
+		s.log.Error(
+			"查询交易日历失败, 计划任务照常执行",
+			zap.Error(rErr),
+			zap.Uint32("schedule_id", m.ID),
+			zap.String("calendar_mode", m.CalendarMode),
+		)
+		return false
+	}
+	if reason == "" {
+		return false
+	}
+
+	s.log.Info(
+		"非交易日, 跳过计划任务执行",
+		zap.Uint32("schedule_id", m.ID),
+		zap.String("schedule_name", m.Name),
+		zap.String("calendar_mode", m.CalendarMode),
+		zap.String("reason", reason),
+		zap.String("detail", detail),
+	)
+	s.calendar.RecordSkip(ctx, &jobsmodel.ScheduleSkipModel{
+		ScheduleID:   m.ID,
+		ScheduleName: m.Name,
+		CalendarMode: m.CalendarMode,
+		Reason:       reason,
+		Detail:       detail,
+		SkippedAt:    now,
+	})
+	return true
+}
+
+// skipByMaintenance 判断本次触发是否处于命中的维护窗口内, 命中时暂停执行并记录审计
+//
+// 维护窗口查询失败时不跳过
+func (s *ScheduleService) skipByMaintenance(m *jobsmodel.ScheduleModel, now time.Time) bool {
+	if s.maintenance == nil {
+		return false
+	}
+
+	ctx := context.Background()
+	project := m.Script.Project
+	if m.Script.ID == 0 {
+		script, err := s.scriptRepo.GetModel(ctx, "id = ?", m.ScriptID)
+		if err != nil {
+			s.log.Error(
+				"查询计划任务脚本失败, 无法匹配集群维护窗口",
+				zap.Error(err),
+				zap.Uint32("schedule_id", m.ID),
+				zap.Uint32("script_id", m.ScriptID),
+			)
+		} else {
+			project = script.Project
+		}
+	}
+
+	window, rErr := s.maintenance.MatchSchedule(ctx, project, m.CommandArgs, now)
+	if rErr != nil {
+		s.log.Error(
+			"查询维护窗口失败, 计划任务照常执行",
+			zap.Error(rErr),
+			zap.Uint32("schedule_id", m.ID),
+		)
+		return false
+	}
+	if window == nil {
+		return false
+	}
+
+	s.log.Info(
+		"维护窗口生效中, 暂停计划任务执行",
+		zap.Uint32("schedule_id", m.ID),
+		zap.String("schedule_name", m.Name),
+		zap.Uint32("window_id", window.ID),
+		zap.String("window_name", window.Name),
+	)
+	s.maintenance.RecordSuppression(ctx, window, sysmodel.MaintenanceActionSkipSchedule, map[string]any{
+		"schedule_id":   m.ID,
+		"schedule_name": m.Name,
+		"triggered_at":  now.Format(time.DateTime),
+	})
+	return true
+}
+
 func (s *ScheduleService) removeJob(ctx context.Context, scheduleID uint32) *errors.Error {
 	if ctx.Err() != nil {
 		return errors.FromError(ctx.Err())
@@ -122,26 +245,23 @@ func (s *ScheduleService) removeJob(ctx context.Context, scheduleID uint32) *err
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始从调度器中移除计划任务",
 		zap.Uint32("schedule_id", scheduleID),
-		zap.String(string(ctxutil.TraceIDKey), ctxutil.GetTraceID(ctx)),
 	)
 	if entryID, exists := s.entryMap[scheduleID]; exists {
 		s.crontab.Remove(entryID)
 		delete(s.entryMap, scheduleID)
 
-		s.log.Info(
+		ctxutil.Logger(ctx, s.log).Info(
 			"计划任务从调度器中移除成功",
 			zap.Uint32("schedule_id", scheduleID),
 			zap.Int64("entry_id", int64(entryID)),
-			zap.String(string(ctxutil.TraceIDKey), ctxutil.GetTraceID(ctx)),
 		)
 	} else {
-		s.log.Info(
+		ctxutil.Logger(ctx, s.log).Info(
 			"计划任务在调度器中不存在, 无需移除",
 			zap.Uint32("schedule_id", scheduleID),
-			zap.String(string(ctxutil.TraceIDKey), ctxutil.GetTraceID(ctx)),
 		)
 	}
 	return nil
@@ -155,30 +275,33 @@ func (s *ScheduleService) CreateSchedule(
 		return nil, errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始创建计划任务",
 		zap.Object(database.ModelKey, &m),
-		zap.String(string(ctxutil.TraceIDKey), ctxutil.GetTraceID(ctx)),
 	)
 
 	script, err := s.scriptRepo.GetModel(ctx, "id = ?", m.ScriptID)
 	if err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"查询脚本失败",
 			zap.Error(err),
 			zap.Uint32("script_id", m.ScriptID),
-			zap.String(string(ctxutil.TraceIDKey), ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.NewGormError(err, map[string]any{"id": m.ScriptID})
 	}
 	m.Script = *script
+	if rErr := s.checkParams(ctx, script, m.Params); rErr != nil {
+		return nil, rErr
+	}
+	if rErr := s.checkColony(ctx, m.Colony); rErr != nil {
+		return nil, rErr
+	}
 
 	if err := s.scheduleRepo.CreateModel(ctx, &m); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"创建计划任务失败",
 			zap.Error(err),
 			zap.Object(database.ModelKey, &m),
-			zap.String(string(ctxutil.TraceIDKey), ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.NewGormError(err, nil)
 	}
@@ -190,10 +313,9 @@ func (s *ScheduleService) CreateSchedule(
 		}
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"创建计划任务成功",
 		zap.Object(database.ModelKey, &m),
-		zap.String(string(ctxutil.TraceIDKey), ctxutil.GetTraceID(ctx)),
 	)
 	return &m, nil
 }
@@ -207,20 +329,49 @@ func (s *ScheduleService) UpdateScheduleByID(
 		return nil, errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始更新计划任务",
 		zap.Uint32("schedule_id", scheduleID),
 		zap.Any(database.UpdateDataKey, data),
-		zap.String(string(ctxutil.TraceIDKey), ctxutil.GetTraceID(ctx)),
 	)
 
+	// 查询接口返回的环境变量已掩码, 编辑时回传的掩码保持原值
+	if envVars, ok := data["env_vars"].(string); ok && strings.Contains(envVars, commodel.MaskedValue) {
+		om, rErr := s.FindScheduleByID(ctx, nil, scheduleID)
+		if rErr != nil {
+			return nil, rErr
+		}
+		data["env_vars"] = commodel.UnmaskEnvVars(envVars, om.EnvVars)
+	}
+
+	if params, ok := data["params"].(string); ok {
+		scriptID, _ := data["script_id"].(uint32)
+		script, err := s.scriptRepo.GetModel(ctx, "id = ?", scriptID)
+		if err != nil {
+			ctxutil.Logger(ctx, s.log).Error(
+				"查询脚本失败",
+				zap.Error(err),
+				zap.Uint32("script_id", scriptID),
+			)
+			return nil, errors.NewGormError(err, map[string]any{"id": scriptID})
+		}
+		if rErr := s.checkParams(ctx, script, params); rErr != nil {
+			return nil, rErr
+		}
+	}
+
+	if colony, ok := data["colony"].(string); ok {
+		if rErr := s.checkColony(ctx, colony); rErr != nil {
+			return nil, rErr
+		}
+	}
+
 	if err := s.scheduleRepo.UpdateModel(ctx, data, "id = ?", scheduleID); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"更新计划任务失败",
 			zap.Error(err),
 			zap.Uint32("schedule_id", scheduleID),
 			zap.Any(database.UpdateDataKey, data),
-			zap.String(string(ctxutil.TraceIDKey), ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.NewGormError(err, data)
 	}
@@ -249,14 +400,31 @@ func (s *ScheduleService) UpdateScheduleByID(
 		s.crontab.Remove(entryID)
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"更新计划任务成功",
 		zap.Uint32("schedule_id", scheduleID),
-		zap.String(string(ctxutil.TraceIDKey), ctxutil.GetTraceID(ctx)),
 	)
 	return m, nil
 }
 
+// checkParams 按脚本的参数定义校验计划任务的参数值, 触发时还会按当天日期重新解析
+func (s *ScheduleService) checkParams(ctx context.Context, script *jobsmodel.ScriptModel, params string) *errors.Error {
+	defs, err := ParseScriptParams(script.Params)
+	if err == nil {
+		_, err = ResolveScriptParams(defs, jobsmodel.DecodeParamValues(params), time.Now())
+	}
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"计划任务的脚本参数无效",
+			zap.Error(err),
+			zap.Uint32("script_id", script.ID),
+			zap.String("params", params),
+		)
+		return errors.ErrValidationFailed.WithCause(err)
+	}
+	return nil
+}
+
 func (s *ScheduleService) DeleteScheduleByID(
 	ctx context.Context,
 	scheduleID uint32,
@@ -265,18 +433,16 @@ func (s *ScheduleService) DeleteScheduleByID(
 		return errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始删除计划任务",
 		zap.Uint32("schedule_id", scheduleID),
-		zap.String(string(ctxutil.TraceIDKey), ctxutil.GetTraceID(ctx)),
 	)
 
 	if err := s.scheduleRepo.DeleteModel(ctx, scheduleID); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"删除计划任务失败",
 			zap.Error(err),
 			zap.Uint32("schedule_id", scheduleID),
-			zap.String(string(ctxutil.TraceIDKey), ctxutil.GetTraceID(ctx)),
 		)
 		return errors.NewGormError(err, map[string]any{"id": scheduleID})
 	}
@@ -285,10 +451,9 @@ func (s *ScheduleService) DeleteScheduleByID(
 		return err
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"计划任务删除成功",
 		zap.Uint32("schedule_id", scheduleID),
-		zap.String(string(ctxutil.TraceIDKey), ctxutil.GetTraceID(ctx)),
 	)
 	return nil
 }
@@ -302,27 +467,24 @@ func (s *ScheduleService) FindScheduleByID(
 		return nil, errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始查询计划任务",
 		zap.Uint32("schedule_id", scheduleID),
-		zap.String(string(ctxutil.TraceIDKey), ctxutil.GetTraceID(ctx)),
 	)
 
 	m, err := s.scheduleRepo.GetModel(ctx, preloads, scheduleID)
 	if err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"查询计划任务失败",
 			zap.Error(err),
 			zap.Uint32("schedule_id", scheduleID),
-			zap.String(string(ctxutil.TraceIDKey), ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.NewGormError(err, map[string]any{"id": scheduleID})
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"查询计划任务成功",
 		zap.Uint32("schedule_id", scheduleID),
-		zap.String(string(ctxutil.TraceIDKey), ctxutil.GetTraceID(ctx)),
 	)
 	return m, nil
 }
@@ -335,27 +497,24 @@ func (s *ScheduleService) ListSchedule(
 		return 0, nil, errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始查询计划任务列表",
 		zap.Object(database.QueryParamsKey, &qp),
-		zap.String(string(ctxutil.TraceIDKey), ctxutil.GetTraceID(ctx)),
 	)
 
 	count, ms, err := s.scheduleRepo.ListModel(ctx, qp)
 	if err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"查询计划任务列表失败",
 			zap.Error(err),
 			zap.Object(database.QueryParamsKey, &qp),
-			zap.String(string(ctxutil.TraceIDKey), ctxutil.GetTraceID(ctx)),
 		)
 		return 0, nil, errors.NewGormError(err, nil)
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"查询计划任务列表成功",
 		zap.Object(database.QueryParamsKey, &qp),
-		zap.String(string(ctxutil.TraceIDKey), ctxutil.GetTraceID(ctx)),
 	)
 	return count, ms, nil
 }
@@ -424,10 +583,9 @@ func (s *ScheduleService) ListScheduleJob(
 		jobs = append(jobs, jobInfo)
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"获取调度器任务列表成功",
 		zap.Int("job_count", len(jobs)),
-		zap.String(string(ctxutil.TraceIDKey), ctxutil.GetTraceID(ctx)),
 	)
 
 	return &jobs, nil
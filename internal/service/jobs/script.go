@@ -9,6 +9,7 @@ import (
 	jobsmodel "gin-artweb/internal/model/jobs"
 	jobsrepo "gin-artweb/internal/repository/jobs"
 	"gin-artweb/internal/shared/common"
+	"gin-artweb/internal/shared/config"
 	"gin-artweb/internal/shared/ctxutil"
 	"gin-artweb/internal/shared/database"
 	"gin-artweb/internal/shared/errors"
@@ -17,15 +18,22 @@ import (
 type ScriptService struct {
 	log        *zap.Logger
 	scriptRepo *jobsrepo.ScriptRepo
+	validate   *config.ScriptValidateConfig
 }
 
 func NewScriptService(
 	log *zap.Logger,
 	scriptRepo *jobsrepo.ScriptRepo,
+	conf *config.JobsConfig,
 ) *ScriptService {
+	validate := &config.ScriptValidateConfig{}
+	if conf != nil && conf.Validate != nil {
+		validate = conf.Validate
+	}
 	return &ScriptService{
 		log:        log,
 		scriptRepo: scriptRepo,
+		validate:   validate,
 	}
 }
 
@@ -37,26 +45,23 @@ func (s *ScriptService) CreateScript(
 		return nil, errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始创建脚本",
 		zap.Object(database.ModelKey, &m),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	if err := s.scriptRepo.CreateModel(ctx, &m); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"创建脚本失败",
 			zap.Error(err),
 			zap.Object(database.ModelKey, &m),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.NewGormError(err, nil)
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"创建脚本成功",
 		zap.Object(database.ModelKey, &m),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return &m, nil
 }
@@ -75,36 +80,32 @@ func (s *ScriptService) UpdateScriptByID(
 		return nil, rErr
 	}
 	if om.IsBuiltin {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"内置脚本不能修改",
 			zap.Uint32("script_id", scriptID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.FromReason(errors.ReasonScriptIsBuiltin).WithField("script_id", scriptID)
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始更新脚本",
 		zap.Uint32("script_id", scriptID),
 		zap.Any(database.UpdateDataKey, data),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	if err := s.scriptRepo.UpdateModel(ctx, data, "id = ?", scriptID); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"更新脚本失败",
 			zap.Error(err),
 			zap.Uint32("script_id", scriptID),
 			zap.Any(database.UpdateDataKey, data),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.NewGormError(err, data)
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"更新脚本成功",
 		zap.Uint32("script_id", scriptID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return s.FindScriptByID(ctx, scriptID)
 }
@@ -122,26 +123,23 @@ func (s *ScriptService) DeleteScriptByID(
 		return rErr
 	}
 	if m.IsBuiltin {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"内置脚本不能删除",
 			zap.Uint32("script_id", scriptID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return errors.FromReason(errors.ReasonScriptIsBuiltin).WithField("script_id", scriptID)
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始删除脚本",
 		zap.Uint32("script_id", scriptID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	if err := s.scriptRepo.DeleteModel(ctx, scriptID); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"删除脚本失败",
 			zap.Error(err),
 			zap.Uint32("script_id", scriptID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return errors.NewGormError(err, map[string]any{"id": scriptID})
 	}
@@ -150,10 +148,9 @@ func (s *ScriptService) DeleteScriptByID(
 		return rErr
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"删除脚本成功",
 		zap.Uint32("script_id", scriptID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return nil
 }
@@ -166,27 +163,24 @@ func (s *ScriptService) FindScriptByID(
 		return nil, errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始查询脚本",
 		zap.Uint32("script_id", scriptID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	m, err := s.scriptRepo.GetModel(ctx, scriptID)
 	if err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"查询脚本失败",
 			zap.Error(err),
 			zap.Uint32("script_id", scriptID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.NewGormError(err, map[string]any{"id": scriptID})
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"查询脚本成功",
 		zap.Uint32("script_id", scriptID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return m, nil
 }
@@ -199,27 +193,24 @@ func (s *ScriptService) ListScript(
 		return 0, nil, errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始查询脚本列表",
 		zap.Object(database.QueryParamsKey, &qp),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	count, ms, err := s.scriptRepo.ListModel(ctx, qp)
 	if err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"查询脚本列表失败",
 			zap.Error(err),
 			zap.Object(database.QueryParamsKey, &qp),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return 0, nil, errors.NewGormError(err, nil)
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"查询脚本列表成功",
 		zap.Object(database.QueryParamsKey, &qp),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return count, ms, nil
 }
@@ -231,52 +222,47 @@ func (s *ScriptService) RemoveScript(ctx context.Context, m jobsmodel.ScriptMode
 
 	savePath := common.GetScriptStoragePath(m.Project, m.Label, m.Name, m.IsBuiltin)
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始删除脚本文件",
 		zap.String("path", savePath),
 		zap.Uint32("script_id", m.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	// 检查文件是否存在
 	if _, statErr := os.Stat(savePath); os.IsNotExist(statErr) {
 		// 文件不存在，视为删除成功
-		s.log.Warn(
+		ctxutil.Logger(ctx, s.log).Warn(
 			"脚本文件不存在，无需删除",
 			zap.String("path", savePath),
 			zap.Uint32("script_id", m.ID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil
 	} else if statErr != nil {
 		// 其他 stat 错误
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"检查脚本文件状态失败",
 			zap.Error(statErr),
 			zap.String("path", savePath),
 			zap.Uint32("script_id", m.ID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return errors.FromError(statErr)
 	}
 
 	// 执行删除操作
 	if rmErr := os.Remove(savePath); rmErr != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"删除脚本文件失败",
 			zap.Error(rmErr),
 			zap.String("path", savePath),
 			zap.Uint32("script_id", m.ID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return errors.FromError(rmErr)
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"删除脚本文件成功",
 		zap.String("path", savePath),
 		zap.Uint32("script_id", m.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return nil
 }
@@ -287,11 +273,10 @@ func (s *ScriptService) ListProjects(ctx context.Context, query map[string]any)
 	}
 	projects, err := s.scriptRepo.ListProjects(ctx, query)
 	if err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"查询项目名称失败",
 			zap.Error(err),
 			zap.Any(database.QueryParamsKey, query),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.NewGormError(err, nil)
 	}
@@ -304,11 +289,10 @@ func (s *ScriptService) ListLabels(ctx context.Context, query map[string]any) ([
 	}
 	labels, err := s.scriptRepo.ListLabels(ctx, query)
 	if err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"查询标签名称失败",
 			zap.Error(err),
 			zap.Any(database.QueryParamsKey, query),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.NewGormError(err, nil)
 	}
@@ -0,0 +1,123 @@
+package jobs
+
+import (
+	"context"
+	"os"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	jobsmodel "gin-artweb/internal/model/jobs"
+	"gin-artweb/internal/shared/common"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/errors"
+	"gin-artweb/pkg/scriptcheck"
+)
+
+const (
+	defaultValidateTimeout   = 10 * time.Second
+	defaultValidateMaxOutput = 64 * 1024
+)
+
+// ValidateScript 检查脚本语法, dryRun为true时在配置的沙箱中试运行
+//
+// 试运行只继承PATH环境变量, 并设置JOBS_DRY_RUN=1, 脚本可据此跳过有副作用的操作
+func (s *ScriptService) ValidateScript(
+	ctx context.Context,
+	m jobsmodel.ScriptModel,
+	dryRun bool,
+	commandArgs string,
+) (*jobsmodel.ScriptValidateOut, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+	if dryRun && len(s.validate.Sandbox) == 0 {
+		return nil, errors.ErrScriptDryRunDisabled.WithField("script_id", m.ID)
+	}
+
+	timeout := defaultValidateTimeout
+	if s.validate.Timeout > 0 {
+		timeout = time.Duration(s.validate.Timeout) * time.Second
+	}
+	scriptPath := common.GetScriptStoragePath(m.Project, m.Label, m.Name, m.IsBuiltin)
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始检查脚本",
+		zap.Uint32("script_id", m.ID),
+		zap.String("path", scriptPath),
+		zap.Bool("dry_run", dryRun),
+	)
+
+	ds, err := scriptcheck.Check(ctx, scriptPath, m.Language, scriptcheck.Options{
+		Timeout:    timeout,
+		Shellcheck: s.validate.Shellcheck,
+	})
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"读取脚本文件失败",
+			zap.Error(err),
+			zap.Uint32("script_id", m.ID),
+			zap.String("path", scriptPath),
+		)
+		if os.IsNotExist(err) {
+			return nil, errors.ErrScriptNotFound.WithField("script_id", m.ID)
+		}
+		return nil, errors.FromError(err)
+	}
+
+	out := &jobsmodel.ScriptValidateOut{
+		Valid:       !scriptcheck.HasError(ds),
+		Diagnostics: make([]jobsmodel.ScriptDiagnosticOut, 0, len(ds)),
+	}
+	for _, d := range ds {
+		out.Diagnostics = append(out.Diagnostics, jobsmodel.ScriptDiagnosticOut{
+			Line:    d.Line,
+			Column:  d.Column,
+			Level:   d.Level,
+			Code:    d.Code,
+			Message: d.Message,
+			Source:  d.Source,
+		})
+	}
+
+	// 存在语法错误时试运行没有意义
+	if dryRun && out.Valid {
+		maxOutput := defaultValidateMaxOutput
+		if s.validate.MaxOutput > 0 {
+			maxOutput = s.validate.MaxOutput * 1024
+		}
+		result, err := scriptcheck.DryRun(ctx, scriptPath, scriptcheck.DryRunOptions{
+			Sandbox:   s.validate.Sandbox,
+			Args:      strings.Fields(commandArgs),
+			Env:       []string{"PATH=" + os.Getenv("PATH"), "JOBS_DRY_RUN=1"},
+			Timeout:   timeout,
+			MaxOutput: maxOutput,
+		})
+		if err != nil {
+			ctxutil.Logger(ctx, s.log).Error(
+				"启动试运行沙箱失败",
+				zap.Error(err),
+				zap.Uint32("script_id", m.ID),
+				zap.Strings("sandbox", s.validate.Sandbox),
+			)
+			return nil, errors.FromError(err)
+		}
+		out.DryRun = &jobsmodel.ScriptDryRunOut{
+			ExitCode:  result.ExitCode,
+			Output:    result.Output,
+			Truncated: result.Truncated,
+			TimedOut:  result.TimedOut,
+			Duration:  result.Duration.Seconds(),
+		}
+		out.Valid = result.ExitCode == 0
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"检查脚本完成",
+		zap.Uint32("script_id", m.ID),
+		zap.Bool("valid", out.Valid),
+		zap.Int("diagnostics", len(out.Diagnostics)),
+	)
+	return out, nil
+}
@@ -0,0 +1,622 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	emperror "emperror.dev/errors"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	jobsmodel "gin-artweb/internal/model/jobs"
+	mdsmodel "gin-artweb/internal/model/mds"
+	mdsrepo "gin-artweb/internal/repository/mds"
+	jobsvc "gin-artweb/internal/service/jobs"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+	"gin-artweb/pkg/dag"
+)
+
+const (
+	// 每日回放的默认超时时间(秒)
+	backfillDefaultTimeout = 3600
+	// 脚本执行记录的触发类型
+	backfillTriggerType = "backfill"
+)
+
+// backfillDayResult 单个交易日的回放结果
+type backfillDayResult struct {
+	day     string
+	success bool
+}
+
+// backfillControl 正在执行的数据回补, 用于取消回补和正在执行的回放脚本
+type backfillControl struct {
+	runID   uint32
+	ctx     context.Context
+	cancel  context.CancelFunc
+	mu      sync.Mutex
+	records map[string]uint32
+}
+
+func (c *backfillControl) setRecord(day string, recordID uint32) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.records[day] = recordID
+}
+
+func (c *backfillControl) deleteRecord(day string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.records, day)
+}
+
+func (c *backfillControl) recordIDs() []uint32 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ids := make([]uint32, 0, len(c.records))
+	for _, id := range c.records {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// MdsBackfillService mds数据回补, 按日期范围内的交易日逐日执行回放脚本
+// 按日期顺序回放时每个交易日依赖前一个交易日回放成功, 同一集群同时只允许一个回补执行
+type MdsBackfillService struct {
+	log        *zap.Logger
+	runRepo    *mdsrepo.MdsBackfillRunRepo
+	colonyRepo *mdsrepo.MdsColonyRepo
+	ucRecord   *JobsService
+	calendar   *jobsvc.CalendarService
+	mutex      sync.Mutex
+	colonies   map[uint32]*backfillControl
+}
+
+func NewMdsBackfillService(
+	log *zap.Logger,
+	runRepo *mdsrepo.MdsBackfillRunRepo,
+	colonyRepo *mdsrepo.MdsColonyRepo,
+	ucRecord *JobsService,
+	calendar *jobsvc.CalendarService,
+) *MdsBackfillService {
+	return &MdsBackfillService{
+		log:        log,
+		runRepo:    runRepo,
+		colonyRepo: colonyRepo,
+		ucRecord:   ucRecord,
+		calendar:   calendar,
+		colonies:   make(map[uint32]*backfillControl),
+	}
+}
+
+// claim 占用集群, 集群已有正在执行的回补时返回nil
+func (s *MdsBackfillService) claim(colonyID, runID uint32) *backfillControl {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, ok := s.colonies[colonyID]; ok {
+		return nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	ctrl := &backfillControl{runID: runID, ctx: ctx, cancel: cancel, records: make(map[string]uint32)}
+	s.colonies[colonyID] = ctrl
+	return ctrl
+}
+
+func (s *MdsBackfillService) release(colonyID uint32) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if ctrl, ok := s.colonies[colonyID]; ok {
+		ctrl.cancel()
+		delete(s.colonies, colonyID)
+	}
+}
+
+func (s *MdsBackfillService) control(runID uint32) *backfillControl {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for _, ctrl := range s.colonies {
+		if ctrl.runID == runID {
+			return ctrl
+		}
+	}
+	return nil
+}
+
+// tradingDays 返回日期范围内的交易日
+func (s *MdsBackfillService) tradingDays(ctx context.Context, start, end time.Time) ([]string, *errors.Error) {
+	var days []string
+	for t := start; !t.After(end); t = t.AddDate(0, 0, 1) {
+		if s.calendar != nil {
+			day, rErr := s.calendar.CheckTradingDay(ctx, t)
+			if rErr != nil {
+				return nil, rErr
+			}
+			if !day.IsTradingDay {
+				continue
+			}
+		}
+		days = append(days, t.Format(time.DateOnly))
+	}
+	return days, nil
+}
+
+// StartBackfill 为集群发起一次数据回补, 回补在后台执行
+func (s *MdsBackfillService) StartBackfill(
+	ctx context.Context,
+	req mdsmodel.CreateMdsBackfillRequest,
+	username string,
+) (*mdsmodel.MdsBackfillRunModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始发起mds数据回补",
+		zap.Object("request", &req),
+	)
+
+	start, err := time.ParseInLocation(time.DateOnly, req.StartDate, time.Local)
+	if err != nil {
+		return nil, errors.ErrValidationFailed.WithField("start_date", err.Error())
+	}
+	end, err := time.ParseInLocation(time.DateOnly, req.EndDate, time.Local)
+	if err != nil {
+		return nil, errors.ErrValidationFailed.WithField("end_date", err.Error())
+	}
+	if end.Before(start) {
+		return nil, errors.ErrValidationFailed.WithField("end_date", "结束日期不能早于开始日期")
+	}
+	if end.Sub(start) >= mdsmodel.BackfillMaxDays*24*time.Hour {
+		return nil, errors.ErrValidationFailed.WithField("end_date", fmt.Sprintf("日期范围不能超过%d天", mdsmodel.BackfillMaxDays))
+	}
+
+	colony, err := s.colonyRepo.GetModel(ctx, nil, req.MdsColonyID)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询mds集群失败",
+			zap.Error(err),
+			zap.Uint32("mds_colony_id", req.MdsColonyID),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"id": req.MdsColonyID})
+	}
+
+	scripts, rErr := s.ucRecord.FindCmdScripts(ctx, []string{req.Script})
+	if rErr != nil {
+		return nil, rErr
+	}
+	script, ok := scripts[req.Script]
+	if !ok {
+		return nil, errors.ErrScriptNotFound.WithField("script", req.Script)
+	}
+
+	days, rErr := s.tradingDays(ctx, start, end)
+	if rErr != nil {
+		return nil, rErr
+	}
+	if len(days) == 0 {
+		return nil, errors.ErrValidationFailed.WithField("end_date", "日期范围内没有交易日")
+	}
+
+	concurrency := req.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	timeout := req.Timeout
+	if timeout <= 0 {
+		timeout = backfillDefaultTimeout
+	}
+	run := &mdsmodel.MdsBackfillRunModel{
+		MdsColonyID: colony.ID,
+		ColonyNum:   colony.ColonyNum,
+		Script:      script.Name,
+		ScriptID:    script.ID,
+		StartDate:   req.StartDate,
+		EndDate:     req.EndDate,
+		Sequential:  req.Sequential,
+		Concurrency: concurrency,
+		Timeout:     timeout,
+		Status:      mdsmodel.BackfillStatusPending,
+		Username:    username,
+	}
+	for i, day := range days {
+		m := mdsmodel.MdsBackfillDayModel{
+			TradingDay: day,
+			Sort:       i,
+			Status:     mdsmodel.BackfillStatusPending,
+		}
+		if req.Sequential && i > 0 {
+			m.DependsOn = days[i-1]
+		}
+		run.Days = append(run.Days, m)
+	}
+
+	ctrl := s.claim(colony.ID, 0)
+	if ctrl == nil {
+		return nil, errors.ErrMdsBackfillRunning.WithField("mds_colony_id", colony.ID)
+	}
+	if err := s.runRepo.CreateModel(ctx, run); err != nil {
+		s.release(colony.ID)
+		ctxutil.Logger(ctx, s.log).Error(
+			"创建mds数据回补失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, run),
+		)
+		return nil, errors.NewGormError(err, nil)
+	}
+	s.mutex.Lock()
+	ctrl.runID = run.ID
+	s.mutex.Unlock()
+
+	go s.runBackfill(ctrl, *run)
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"发起mds数据回补成功",
+		zap.Uint32("backfill_run_id", run.ID),
+		zap.Int("days", len(days)),
+	)
+	return run, nil
+}
+
+// CancelBackfill 取消正在执行的数据回补, 正在执行的回放脚本会被终止, 未开始的交易日标记为已取消
+func (s *MdsBackfillService) CancelBackfill(
+	ctx context.Context,
+	runID uint32,
+) *errors.Error {
+	if ctx.Err() != nil {
+		return errors.FromError(ctx.Err())
+	}
+
+	m, rErr := s.FindBackfillRunByID(ctx, runID)
+	if rErr != nil {
+		return rErr
+	}
+	ctrl := s.control(m.ID)
+	if ctrl == nil {
+		return errors.ErrMdsBackfillState.WithFields(map[string]any{
+			"id":     runID,
+			"status": m.Status,
+		})
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始取消mds数据回补",
+		zap.Uint32("backfill_run_id", runID),
+	)
+	ctrl.cancel()
+	for _, recordID := range ctrl.recordIDs() {
+		s.ucRecord.CancelRecord(ctx, recordID)
+	}
+	return nil
+}
+
+// RetryBackfill 重新执行已结束的数据回补中未成功的交易日
+func (s *MdsBackfillService) RetryBackfill(
+	ctx context.Context,
+	runID uint32,
+) (*mdsmodel.MdsBackfillRunModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	m, rErr := s.FindBackfillRunByID(ctx, runID)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始重试mds数据回补",
+		zap.Uint32("backfill_run_id", runID),
+	)
+
+	ctrl := s.claim(m.MdsColonyID, m.ID)
+	if ctrl == nil {
+		return nil, errors.ErrMdsBackfillRunning.WithField("mds_colony_id", m.MdsColonyID)
+	}
+	if err := s.runRepo.ResetForRetry(ctx, m.ID); err != nil {
+		s.release(m.MdsColonyID)
+		if emperror.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.ErrMdsBackfillState.WithFields(map[string]any{
+				"id":     runID,
+				"status": m.Status,
+			})
+		}
+		return nil, errors.NewGormError(err, nil)
+	}
+
+	nm, rErr := s.FindBackfillRunByID(ctx, runID)
+	if rErr != nil {
+		s.release(m.MdsColonyID)
+		return nil, rErr
+	}
+	go s.runBackfill(ctrl, *nm)
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"重试mds数据回补成功",
+		zap.Uint32("backfill_run_id", runID),
+	)
+	return nm, nil
+}
+
+// FindBackfillRunByID 查询数据回补详情
+func (s *MdsBackfillService) FindBackfillRunByID(
+	ctx context.Context,
+	runID uint32,
+) (*mdsmodel.MdsBackfillRunModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	m, err := s.runRepo.GetModel(ctx, []string{"Days"}, runID)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询mds数据回补失败",
+			zap.Error(err),
+			zap.Uint32("backfill_run_id", runID),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"id": runID})
+	}
+	return m, nil
+}
+
+// ListBackfillRun 查询数据回补列表
+func (s *MdsBackfillService) ListBackfillRun(
+	ctx context.Context,
+	qp database.QueryParams,
+) (int64, *[]mdsmodel.MdsBackfillRunModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return 0, nil, errors.FromError(ctx.Err())
+	}
+
+	count, ms, err := s.runRepo.ListModel(ctx, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询mds数据回补列表失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		return 0, nil, errors.NewGormError(err, nil)
+	}
+	return count, ms, nil
+}
+
+// RecoverInterrupted 将服务重启前未结束的数据回补标记为失败, 之后可以重试
+func (s *MdsBackfillService) RecoverInterrupted(ctx context.Context) *errors.Error {
+	if ctx.Err() != nil {
+		return errors.FromError(ctx.Err())
+	}
+
+	n, err := s.runRepo.FailRunning(ctx, "服务重启, 数据回补中断")
+	if err != nil {
+		return errors.NewGormError(err, nil)
+	}
+	if n > 0 {
+		ctxutil.Logger(ctx, s.log).Warn(
+			"已将中断的mds数据回补标记为失败",
+			zap.Int64("count", n),
+		)
+	}
+	return nil
+}
+
+// runBackfill 按依赖关系调度回放任务, 同时执行的交易日数不超过回补的并发数
+func (s *MdsBackfillService) runBackfill(ctrl *backfillControl, run mdsmodel.MdsBackfillRunModel) {
+	ctx := context.Background()
+	defer s.release(run.MdsColonyID)
+
+	defer func() {
+		if r := recover(); r != nil {
+			s.log.Error(
+				"mds数据回补发生panic",
+				zap.Any("panic", r),
+				zap.String("stack", string(debug.Stack())),
+				zap.Uint32("backfill_run_id", run.ID),
+			)
+			s.updateRun(ctx, run.ID, map[string]any{
+				"status":      mdsmodel.BackfillStatusFailed,
+				"finished_at": time.Now(),
+			})
+		}
+	}()
+
+	nodes := make(map[string][]string, len(run.Days))
+	days := make(map[string]mdsmodel.MdsBackfillDayModel, len(run.Days))
+	done := make(map[string]bool, len(run.Days))
+	started := make(map[string]bool, len(run.Days))
+	for _, day := range run.Days {
+		days[day.TradingDay] = day
+		nodes[day.TradingDay] = nil
+		if day.DependsOn != "" {
+			nodes[day.TradingDay] = []string{day.DependsOn}
+		}
+		// 重试时已成功的交易日不再执行
+		if day.Status == mdsmodel.BackfillStatusSuccess {
+			done[day.TradingDay] = true
+			started[day.TradingDay] = true
+		}
+	}
+	graph, err := dag.New(nodes)
+	if err != nil {
+		s.log.Error(
+			"mds数据回补依赖关系无效",
+			zap.Error(err),
+			zap.Uint32("backfill_run_id", run.ID),
+		)
+		s.updateRun(ctx, run.ID, map[string]any{
+			"status":      mdsmodel.BackfillStatusFailed,
+			"finished_at": time.Now(),
+		})
+		return
+	}
+
+	s.updateRun(ctx, run.ID, map[string]any{
+		"status":     mdsmodel.BackfillStatusRunning,
+		"started_at": time.Now(),
+	})
+
+	concurrency := max(run.Concurrency, 1)
+	results := make(chan backfillDayResult)
+	inflight := 0
+	failed := false
+	for {
+		if ctrl.ctx.Err() == nil {
+			for _, day := range graph.Ready(done, started) {
+				if inflight >= concurrency {
+					break
+				}
+				started[day] = true
+				inflight++
+				go func(m mdsmodel.MdsBackfillDayModel) {
+					results <- backfillDayResult{day: m.TradingDay, success: s.runDay(ctrl, run, m)}
+				}(days[day])
+			}
+		}
+		if inflight == 0 {
+			break
+		}
+
+		r := <-results
+		inflight--
+		if r.success {
+			done[r.day] = true
+			continue
+		}
+
+		// 按日期顺序回放时跳过后续的全部交易日
+		failed = true
+		if ctrl.ctx.Err() != nil {
+			continue
+		}
+		for _, day := range graph.Downstream(r.day) {
+			if started[day] {
+				continue
+			}
+			started[day] = true
+			s.updateDay(ctx, run.ID, day, map[string]any{
+				"status":        mdsmodel.BackfillStatusSkipped,
+				"error_message": fmt.Sprintf("依赖的交易日%s回放失败", r.day),
+			})
+		}
+	}
+
+	status := mdsmodel.BackfillStatusSuccess
+	if ctrl.ctx.Err() != nil {
+		status = mdsmodel.BackfillStatusCancelled
+		for _, day := range graph.Order() {
+			if started[day] {
+				continue
+			}
+			s.updateDay(ctx, run.ID, day, map[string]any{
+				"status": mdsmodel.BackfillStatusCancelled,
+			})
+		}
+	} else if failed {
+		status = mdsmodel.BackfillStatusFailed
+	}
+	s.updateRun(ctx, run.ID, map[string]any{
+		"status":      status,
+		"finished_at": time.Now(),
+	})
+	s.log.Info(
+		"mds数据回补执行结束",
+		zap.Uint32("backfill_run_id", run.ID),
+		zap.String("colony_num", run.ColonyNum),
+		zap.String("status", status),
+	)
+}
+
+// runDay 执行单个交易日的回放脚本并记录执行状态, 返回是否执行成功
+func (s *MdsBackfillService) runDay(
+	ctrl *backfillControl,
+	run mdsmodel.MdsBackfillRunModel,
+	day mdsmodel.MdsBackfillDayModel,
+) bool {
+	ctx := context.Background()
+	s.updateDay(ctx, run.ID, day.TradingDay, map[string]any{
+		"status":        mdsmodel.BackfillStatusRunning,
+		"attempts":      gorm.Expr("attempts + 1"),
+		"error_message": "",
+		"started_at":    time.Now(),
+	})
+
+	record, rErr := s.ucRecord.CreateRecord(ctx, jobsmodel.ExecuteRequest{
+		TriggerType: backfillTriggerType,
+		ScriptID:    run.ScriptID,
+		CommandArgs: fmt.Sprintf("%s %s", run.ColonyNum, day.TradingDay),
+		EnvVars:     "{}",
+		Timeout:     run.Timeout,
+		Username:    run.Username,
+	})
+	if rErr != nil {
+		s.log.Error(
+			"创建mds数据回补的执行记录失败",
+			zap.Error(rErr),
+			zap.Uint32("backfill_run_id", run.ID),
+			zap.String("trading_day", day.TradingDay),
+		)
+		s.updateDay(ctx, run.ID, day.TradingDay, map[string]any{
+			"status":        mdsmodel.BackfillStatusFailed,
+			"error_message": rErr.Msg,
+			"finished_at":   time.Now(),
+		})
+		return false
+	}
+	s.updateDay(ctx, run.ID, day.TradingDay, map[string]any{"record_id": record.ID})
+
+	// 创建执行记录期间回补已被取消时不再执行
+	if ctrl.ctx.Err() != nil {
+		s.updateDay(ctx, run.ID, day.TradingDay, map[string]any{
+			"status":      mdsmodel.BackfillStatusCancelled,
+			"finished_at": time.Now(),
+		})
+		return false
+	}
+	ctrl.setRecord(day.TradingDay, record.ID)
+	taskinfo := s.ucRecord.ExecuteRecord(record)
+	ctrl.deleteRecord(day.TradingDay)
+
+	success := taskinfo.Status == jobsmodel.RecordStatusSuccess
+	data := map[string]any{
+		"status":      mdsmodel.BackfillStatusSuccess,
+		"finished_at": time.Now(),
+	}
+	if !success {
+		data["status"] = mdsmodel.BackfillStatusFailed
+		if ctrl.ctx.Err() != nil {
+			data["status"] = mdsmodel.BackfillStatusCancelled
+		}
+		data["error_message"] = taskinfo.ErrMSG
+		if taskinfo.ErrMSG == "" && taskinfo.Error != nil {
+			data["error_message"] = taskinfo.Error.Error()
+		}
+	}
+	s.updateDay(ctx, run.ID, day.TradingDay, data)
+	return success
+}
+
+func (s *MdsBackfillService) updateRun(ctx context.Context, runID uint32, data map[string]any) {
+	if err := s.runRepo.UpdateModel(ctx, data, "id = ?", runID); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"更新mds数据回补失败",
+			zap.Error(err),
+			zap.Uint32("backfill_run_id", runID),
+			zap.Any(database.UpdateDataKey, data),
+		)
+	}
+}
+
+func (s *MdsBackfillService) updateDay(ctx context.Context, runID uint32, day string, data map[string]any) {
+	if err := s.runRepo.UpdateDayModel(ctx, data, "run_id = ? AND trading_day = ?", runID, day); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"更新mds数据回补的交易日失败",
+			zap.Error(err),
+			zap.Uint32("backfill_run_id", runID),
+			zap.String("trading_day", day),
+			zap.Any(database.UpdateDataKey, data),
+		)
+	}
+}
@@ -0,0 +1,763 @@
+package biz
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/csv"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	emperror "emperror.dev/errors"
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+
+	mdsmodel "gin-artweb/internal/model/mds"
+	mdsrepo "gin-artweb/internal/repository/mds"
+	jobsvc "gin-artweb/internal/service/jobs"
+	resosvc "gin-artweb/internal/service/resource"
+	"gin-artweb/internal/shared/crontab"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+)
+
+const (
+	// ingestFetchTimeout 单次采集拉取文件的超时时间
+	ingestFetchTimeout = 10 * time.Minute
+
+	// maxIngestErrors 每次采集最多记录的校验错误数
+	maxIngestErrors = 20
+
+	IngestTriggerCron   = "cron"
+	IngestTriggerManual = "manual"
+)
+
+// MdsIngestService 行情数据采集, 按数据源的计划定时拉取每个交易日的行情文件,
+// 校验文件格式并与校验文件核对MD5和行数, 每个数据源每个交易日保留一条采集记录
+type MdsIngestService struct {
+	log        *zap.Logger
+	sourceRepo *mdsrepo.MdsIngestSourceRepo
+	recordRepo *mdsrepo.MdsIngestRecordRepo
+	files      *resosvc.HostFileService
+	calendar   *jobsvc.CalendarService
+	crontab    *cron.Cron
+	httpClient *http.Client
+	entryMap   map[uint32]cron.EntryID
+	mutex      sync.Mutex
+}
+
+func NewMdsIngestService(
+	log *zap.Logger,
+	sourceRepo *mdsrepo.MdsIngestSourceRepo,
+	recordRepo *mdsrepo.MdsIngestRecordRepo,
+	files *resosvc.HostFileService,
+	calendar *jobsvc.CalendarService,
+	crontab *cron.Cron,
+) *MdsIngestService {
+	return &MdsIngestService{
+		log:        log,
+		sourceRepo: sourceRepo,
+		recordRepo: recordRepo,
+		files:      files,
+		calendar:   calendar,
+		crontab:    crontab,
+		httpClient: &http.Client{},
+		entryMap:   make(map[uint32]cron.EntryID),
+	}
+}
+
+// validateSource 校验数据源的字段定义和定时采集表达式
+func (s *MdsIngestService) validateSource(m *mdsmodel.MdsIngestSourceModel) *errors.Error {
+	columns := m.ColumnSpecs()
+	if len(columns) == 0 {
+		return errors.ErrValidationFailed.WithField("columns", "字段定义不能为空")
+	}
+	for _, col := range columns {
+		switch col.Type {
+		case mdsmodel.IngestColumnString, mdsmodel.IngestColumnInt,
+			mdsmodel.IngestColumnFloat, mdsmodel.IngestColumnDate:
+		default:
+			return errors.ErrValidationFailed.WithField("columns", fmt.Sprintf("字段%s的类型%s不支持", col.Name, col.Type))
+		}
+	}
+	if _, err := ingestDelimiter(m.Delimiter); err != nil {
+		return errors.ErrValidationFailed.WithField("delimiter", err.Error())
+	}
+	if m.Specification != "" {
+		if _, err := crontab.ValidateCronExpression(m.Specification, false); err != nil {
+			return errors.ErrValidationFailed.WithField("specification", err.Error())
+		}
+	}
+	return nil
+}
+
+func (s *MdsIngestService) CreateIngestSource(
+	ctx context.Context,
+	m mdsmodel.MdsIngestSourceModel,
+) (*mdsmodel.MdsIngestSourceModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始创建行情数据源",
+		zap.Object(database.ModelKey, &m),
+	)
+
+	if rErr := s.validateSource(&m); rErr != nil {
+		return nil, rErr
+	}
+	if err := s.sourceRepo.CreateModel(ctx, &m); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"创建行情数据源失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, &m),
+		)
+		return nil, errors.NewGormError(err, nil)
+	}
+	if rErr := s.scheduleSource(ctx, &m); rErr != nil {
+		return nil, rErr
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"创建行情数据源成功",
+		zap.Object(database.ModelKey, &m),
+	)
+	return &m, nil
+}
+
+func (s *MdsIngestService) UpdateIngestSourceByID(
+	ctx context.Context,
+	sourceID uint32,
+	m mdsmodel.MdsIngestSourceModel,
+) (*mdsmodel.MdsIngestSourceModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始更新行情数据源",
+		zap.Uint32("source_id", sourceID),
+		zap.Object(database.ModelKey, &m),
+	)
+
+	if rErr := s.validateSource(&m); rErr != nil {
+		return nil, rErr
+	}
+	data := map[string]any{
+		"name":             m.Name,
+		"kind":             m.Kind,
+		"host_id":          m.HostID,
+		"location":         m.Location,
+		"checksum_suffix":  m.ChecksumSuffix,
+		"delimiter":        m.Delimiter,
+		"has_header":       m.HasHeader,
+		"columns":          m.Columns,
+		"specification":    m.Specification,
+		"trading_day_only": m.TradingDayOnly,
+		"max_size":         m.MaxSize,
+		"is_enabled":       m.IsEnabled,
+	}
+	if err := s.sourceRepo.UpdateModel(ctx, data, "id = ?", sourceID); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"更新行情数据源失败",
+			zap.Error(err),
+			zap.Uint32("source_id", sourceID),
+		)
+		return nil, errors.NewGormError(err, data)
+	}
+
+	nm, rErr := s.FindIngestSourceByID(ctx, sourceID)
+	if rErr != nil {
+		return nil, rErr
+	}
+	if rErr := s.scheduleSource(ctx, nm); rErr != nil {
+		return nil, rErr
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"更新行情数据源成功",
+		zap.Uint32("source_id", sourceID),
+	)
+	return nm, nil
+}
+
+func (s *MdsIngestService) DeleteIngestSourceByID(
+	ctx context.Context,
+	sourceID uint32,
+) *errors.Error {
+	if ctx.Err() != nil {
+		return errors.FromError(ctx.Err())
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始删除行情数据源",
+		zap.Uint32("source_id", sourceID),
+	)
+
+	if _, rErr := s.FindIngestSourceByID(ctx, sourceID); rErr != nil {
+		return rErr
+	}
+	if err := s.sourceRepo.DeleteModel(ctx, sourceID); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"删除行情数据源失败",
+			zap.Error(err),
+			zap.Uint32("source_id", sourceID),
+		)
+		return errors.NewGormError(err, map[string]any{"id": sourceID})
+	}
+	s.unscheduleSource(sourceID)
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"删除行情数据源成功",
+		zap.Uint32("source_id", sourceID),
+	)
+	return nil
+}
+
+func (s *MdsIngestService) FindIngestSourceByID(
+	ctx context.Context,
+	sourceID uint32,
+) (*mdsmodel.MdsIngestSourceModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	m, err := s.sourceRepo.GetModel(ctx, nil, sourceID)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询行情数据源失败",
+			zap.Error(err),
+			zap.Uint32("source_id", sourceID),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"id": sourceID})
+	}
+	return m, nil
+}
+
+func (s *MdsIngestService) ListIngestSource(
+	ctx context.Context,
+	qp database.QueryParams,
+) (int64, *[]mdsmodel.MdsIngestSourceModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return 0, nil, errors.FromError(ctx.Err())
+	}
+
+	count, ms, err := s.sourceRepo.ListModel(ctx, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询行情数据源列表失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		return 0, nil, errors.NewGormError(err, nil)
+	}
+	return count, ms, nil
+}
+
+func (s *MdsIngestService) FindIngestRecordByID(
+	ctx context.Context,
+	recordID uint32,
+) (*mdsmodel.MdsIngestRecordModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	m, err := s.recordRepo.GetModel(ctx, []string{"Source"}, recordID)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询行情数据采集记录失败",
+			zap.Error(err),
+			zap.Uint32("record_id", recordID),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"id": recordID})
+	}
+	return m, nil
+}
+
+func (s *MdsIngestService) ListIngestRecord(
+	ctx context.Context,
+	qp database.QueryParams,
+) (int64, *[]mdsmodel.MdsIngestRecordModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return 0, nil, errors.FromError(ctx.Err())
+	}
+
+	count, ms, err := s.recordRepo.ListModel(ctx, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询行情数据采集记录列表失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		return 0, nil, errors.NewGormError(err, nil)
+	}
+	return count, ms, nil
+}
+
+// ListIngestDay 查询指定交易日所有启用的数据源的采集状态, 尚未采集的数据源状态为pending
+func (s *MdsIngestService) ListIngestDay(
+	ctx context.Context,
+	tradingDay string,
+) ([]mdsmodel.MdsIngestRecordModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	_, sources, rErr := s.ListIngestSource(ctx, database.QueryParams{
+		Query:   map[string]any{"is_enabled = ?": true},
+		OrderBy: []string{"id ASC"},
+	})
+	if rErr != nil {
+		return nil, rErr
+	}
+	_, records, rErr := s.ListIngestRecord(ctx, database.QueryParams{
+		Query: map[string]any{"trading_day = ?": tradingDay},
+	})
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	bySource := make(map[uint32]mdsmodel.MdsIngestRecordModel, len(*records))
+	for _, r := range *records {
+		bySource[r.SourceID] = r
+	}
+	result := make([]mdsmodel.MdsIngestRecordModel, 0, len(*sources))
+	for _, source := range *sources {
+		r, ok := bySource[source.ID]
+		if !ok {
+			r = mdsmodel.MdsIngestRecordModel{
+				SourceID:   source.ID,
+				TradingDay: tradingDay,
+				Status:     mdsmodel.IngestStatusPending,
+			}
+		}
+		r.Source = source
+		result = append(result, r)
+	}
+	return result, nil
+}
+
+// RunIngest 手动采集或重新采集数据源指定交易日的行情文件, 采集在后台进行, 返回采集中的记录
+func (s *MdsIngestService) RunIngest(
+	ctx context.Context,
+	sourceID uint32,
+	tradingDay time.Time,
+	username string,
+) (*mdsmodel.MdsIngestRecordModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	source, rErr := s.FindIngestSourceByID(ctx, sourceID)
+	if rErr != nil {
+		return nil, rErr
+	}
+	record, rErr := s.startIngest(ctx, source, tradingDay, IngestTriggerManual, username)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	go s.ingest(context.WithoutCancel(ctx), source, record, tradingDay)
+	record.Source = *source
+	return record, nil
+}
+
+func (s *MdsIngestService) startIngest(
+	ctx context.Context,
+	source *mdsmodel.MdsIngestSourceModel,
+	tradingDay time.Time,
+	triggerType, username string,
+) (*mdsmodel.MdsIngestRecordModel, *errors.Error) {
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始采集行情数据",
+		zap.Uint32("source_id", source.ID),
+		zap.String("trading_day", tradingDay.Format(mdsmodel.IngestDateLayout)),
+		zap.String("trigger_type", triggerType),
+		zap.String("username", username),
+	)
+
+	record, err := s.recordRepo.StartRun(
+		ctx, source.ID, tradingDay.Format(mdsmodel.IngestDateLayout),
+		source.ResolveLocation(tradingDay), triggerType, username,
+	)
+	if err != nil {
+		if emperror.Is(err, mdsrepo.ErrIngestRunning) {
+			return nil, errors.ErrMdsIngestRunning.WithFields(map[string]any{
+				"source_id":   source.ID,
+				"trading_day": tradingDay.Format(mdsmodel.IngestDateLayout),
+			})
+		}
+		return nil, errors.NewGormError(err, nil)
+	}
+	return record, nil
+}
+
+// ingest 拉取行情文件和校验文件, 校验后保存采集结果
+func (s *MdsIngestService) ingest(
+	ctx context.Context,
+	source *mdsmodel.MdsIngestSourceModel,
+	record *mdsmodel.MdsIngestRecordModel,
+	tradingDay time.Time,
+) {
+	fetchCtx, cancel := context.WithTimeout(ctx, ingestFetchTimeout)
+	defer cancel()
+
+	var errs []string
+	data, err := s.fetch(fetchCtx, source, record.Location)
+	if err != nil {
+		errs = append(errs, fmt.Sprintf("获取行情文件失败: %s", err))
+	} else {
+		sum := md5.Sum(data)
+		record.FileSize = int64(len(data))
+		record.Checksum = hex.EncodeToString(sum[:])
+		record.RowCount, errs = validateIngestFile(data, source)
+	}
+
+	if source.ChecksumSuffix != "" {
+		control, cErr := s.fetch(fetchCtx, source, record.Location+source.ChecksumSuffix)
+		if cErr != nil {
+			errs = append(errs, fmt.Sprintf("获取校验文件失败: %s", cErr))
+		} else {
+			record.ExpectedChecksum, record.ExpectedRows, cErr = parseIngestControl(control)
+			if cErr != nil {
+				errs = append(errs, cErr.Error())
+			}
+		}
+		if err == nil && record.ExpectedChecksum != "" && record.ExpectedChecksum != record.Checksum {
+			errs = append(errs, fmt.Sprintf("MD5不一致: 文件为%s, 校验文件为%s", record.Checksum, record.ExpectedChecksum))
+		}
+		if err == nil && record.ExpectedRows > 0 && record.ExpectedRows != record.RowCount {
+			errs = append(errs, fmt.Sprintf("行数不一致: 文件为%d, 校验文件为%d", record.RowCount, record.ExpectedRows))
+		}
+	}
+
+	record.Status = mdsmodel.IngestStatusSuccess
+	if len(errs) > 0 {
+		record.Status = mdsmodel.IngestStatusFailed
+		record.Errors = strings.Join(errs, "\n")
+	}
+	if err := s.recordRepo.FinishRun(ctx, record); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"保存行情数据采集结果失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, record),
+		)
+		return
+	}
+
+	if record.Status == mdsmodel.IngestStatusSuccess {
+		ctxutil.Logger(ctx, s.log).Info(
+			"采集行情数据成功",
+			zap.Object(database.ModelKey, record),
+		)
+	} else {
+		ctxutil.Logger(ctx, s.log).Warn(
+			"采集行情数据失败",
+			zap.Object(database.ModelKey, record),
+			zap.Strings("errors", errs),
+		)
+	}
+}
+
+// fetch 按数据源类型读取文件, 超过数据源的文件大小上限时返回错误
+func (s *MdsIngestService) fetch(
+	ctx context.Context,
+	source *mdsmodel.MdsIngestSourceModel,
+	location string,
+) ([]byte, error) {
+	maxSize := source.MaxSize * 1024 * 1024
+	var r io.Reader
+	switch source.Kind {
+	case mdsmodel.IngestSourceSFTP:
+		data, ok, rErr := s.files.ReadFile(ctx, source.HostID, location, maxSize)
+		if rErr != nil {
+			return nil, rErr
+		}
+		if !ok {
+			return nil, fmt.Errorf("文件不存在: %s", location)
+		}
+		return data, nil
+	case mdsmodel.IngestSourceLocal:
+		f, err := os.Open(location)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	case mdsmodel.IngestSourceHTTP:
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := s.httpClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("HTTP状态码%d: %s", resp.StatusCode, location)
+		}
+		r = resp.Body
+	default:
+		return nil, fmt.Errorf("不支持的数据源类型: %s", source.Kind)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxSize {
+		return nil, fmt.Errorf("文件超过大小上限%dMB", source.MaxSize)
+	}
+	return data, nil
+}
+
+// ingestDelimiter 解析字段分隔符, 支持用\t表示制表符
+func ingestDelimiter(delimiter string) (rune, error) {
+	switch delimiter {
+	case "", ",":
+		return ',', nil
+	case `\t`, "\t":
+		return '\t', nil
+	}
+	runes := []rune(delimiter)
+	if len(runes) != 1 || runes[0] == '"' || runes[0] == '\r' || runes[0] == '\n' {
+		return 0, fmt.Errorf("字段分隔符必须为单个字符: %q", delimiter)
+	}
+	return runes[0], nil
+}
+
+// validateIngestFile 按数据源的字段定义校验行情文件, 返回数据行数(不含表头)和校验错误
+func validateIngestFile(data []byte, source *mdsmodel.MdsIngestSourceModel) (int64, []string) {
+	columns := source.ColumnSpecs()
+	comma, err := ingestDelimiter(source.Delimiter)
+	if err != nil {
+		return 0, []string{err.Error()}
+	}
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comma = comma
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+
+	var (
+		rows     int64
+		errs     []string
+		errCount int
+	)
+	addErr := func(format string, args ...any) {
+		errCount++
+		if errCount <= maxIngestErrors {
+			errs = append(errs, fmt.Sprintf(format, args...))
+		}
+	}
+
+	first := true
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		line, _ := reader.FieldPos(0)
+		if err != nil {
+			addErr("第%d行: %s", line, err)
+			// 引号不匹配等错误后续内容无法可靠解析
+			if !emperror.Is(err, csv.ErrFieldCount) {
+				break
+			}
+			continue
+		}
+		if first && source.HasHeader {
+			first = false
+			for i, col := range columns {
+				if i >= len(record) || strings.TrimSpace(record[i]) != col.Name {
+					addErr("表头与字段定义不一致: 第%d列应为%s", i+1, col.Name)
+					break
+				}
+			}
+			continue
+		}
+		first = false
+		rows++
+		if len(record) != len(columns) {
+			addErr("第%d行: 字段数为%d, 应为%d", line, len(record), len(columns))
+			continue
+		}
+		for i, col := range columns {
+			if msg := checkIngestValue(col.Type, record[i]); msg != "" {
+				addErr("第%d行第%d列(%s): %s", line, i+1, col.Name, msg)
+			}
+		}
+	}
+	if rows == 0 && errCount == 0 {
+		addErr("文件中没有数据行")
+	}
+	if errCount > maxIngestErrors {
+		errs = append(errs, fmt.Sprintf("共%d处错误, 仅显示前%d处", errCount, maxIngestErrors))
+	}
+	return rows, errs
+}
+
+// checkIngestValue 校验字段值是否符合字段类型, 空值视为合法
+func checkIngestValue(typ, value string) string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return ""
+	}
+	switch typ {
+	case mdsmodel.IngestColumnInt:
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			return fmt.Sprintf("%q不是整数", value)
+		}
+	case mdsmodel.IngestColumnFloat:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return fmt.Sprintf("%q不是数字", value)
+		}
+	case mdsmodel.IngestColumnDate:
+		if _, err := time.Parse("20060102", value); err != nil {
+			if _, err := time.Parse(time.DateOnly, value); err != nil {
+				return fmt.Sprintf("%q不是日期", value)
+			}
+		}
+	}
+	return ""
+}
+
+// parseIngestControl 解析校验文件, 内容为"md5 [行数]", md5后可以带有文件名(md5sum的输出格式)
+func parseIngestControl(data []byte) (string, int64, error) {
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 {
+		return "", 0, fmt.Errorf("校验文件为空")
+	}
+	checksum := strings.ToLower(fields[0])
+	if _, err := hex.DecodeString(checksum); err != nil || len(checksum) != md5.Size*2 {
+		return "", 0, fmt.Errorf("校验文件中的MD5格式错误: %s", fields[0])
+	}
+	for _, field := range fields[1:] {
+		if rows, err := strconv.ParseInt(field, 10, 64); err == nil {
+			return checksum, rows, nil
+		}
+	}
+	return checksum, 0, nil
+}
+
+// scheduleSource 按数据源的定时采集表达式重新注册定时任务, 未启用或未设置表达式时仅移除
+func (s *MdsIngestService) scheduleSource(
+	ctx context.Context,
+	m *mdsmodel.MdsIngestSourceModel,
+) *errors.Error {
+	s.unscheduleSource(m.ID)
+	if !m.IsEnabled || m.Specification == "" {
+		return nil
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	sourceID := m.ID
+	entryID, err := s.crontab.AddFunc(m.Specification, func() {
+		s.runScheduled(sourceID)
+	})
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"添加行情数据采集定时任务失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, m),
+		)
+		return errors.ErrValidationFailed.WithField("specification", err.Error())
+	}
+	s.entryMap[sourceID] = entryID
+	return nil
+}
+
+func (s *MdsIngestService) unscheduleSource(sourceID uint32) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if entryID, ok := s.entryMap[sourceID]; ok {
+		s.crontab.Remove(entryID)
+		delete(s.entryMap, sourceID)
+	}
+}
+
+// runScheduled 定时采集当天的行情文件, 设置了仅交易日采集时非交易日跳过
+func (s *MdsIngestService) runScheduled(sourceID uint32) {
+	ctx := context.Background()
+	now := time.Now()
+	source, rErr := s.FindIngestSourceByID(ctx, sourceID)
+	if rErr != nil {
+		return
+	}
+
+	if source.TradingDayOnly && s.calendar != nil {
+		day, rErr := s.calendar.CheckTradingDay(ctx, now)
+		if rErr != nil {
+			s.log.Error(
+				"查询交易日历失败, 行情数据照常采集",
+				zap.Error(rErr),
+				zap.Uint32("source_id", sourceID),
+			)
+		} else if !day.IsTradingDay {
+			s.log.Info(
+				"非交易日, 跳过行情数据采集",
+				zap.Uint32("source_id", sourceID),
+				zap.String("date", day.Date),
+			)
+			return
+		}
+	}
+
+	record, rErr := s.startIngest(ctx, source, now, IngestTriggerCron, "")
+	if rErr != nil {
+		s.log.Error(
+			"定时采集行情数据失败",
+			zap.Error(rErr),
+			zap.Uint32("source_id", sourceID),
+		)
+		return
+	}
+	s.ingest(ctx, source, record, now)
+}
+
+// LoadIngestJobs 处理上次中断的采集并注册所有启用的数据源的定时采集任务
+func (s *MdsIngestService) LoadIngestJobs(ctx context.Context) *errors.Error {
+	if ctx.Err() != nil {
+		return errors.FromError(ctx.Err())
+	}
+
+	if n, err := s.recordRepo.FailRunning(ctx, "服务重启, 采集中断"); err != nil {
+		return errors.NewGormError(err, nil)
+	} else if n > 0 {
+		ctxutil.Logger(ctx, s.log).Warn(
+			"已将中断的行情数据采集记录标记为失败",
+			zap.Int64("count", n),
+		)
+	}
+
+	_, ms, rErr := s.ListIngestSource(ctx, database.QueryParams{
+		Query: map[string]any{"is_enabled = ?": true},
+	})
+	if rErr != nil {
+		return rErr
+	}
+	for i := range *ms {
+		if rErr := s.scheduleSource(ctx, &(*ms)[i]); rErr != nil {
+			return rErr
+		}
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"加载行情数据采集定时任务成功",
+		zap.Int("count", len(*ms)),
+	)
+	return nil
+}
@@ -11,6 +11,7 @@ import (
 	mdsmodel "gin-artweb/internal/model/mds"
 	"gin-artweb/internal/shared/common"
 	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/ctxutil"
 	"gin-artweb/internal/shared/errors"
 )
 
@@ -197,7 +198,7 @@ func (uc *MdsTaskExecutionInfoUsecase) LoadMdsTaskRecordCacheFromFiles(
 		Sse:       sse,
 		Szse:      szse,
 	}
-	uc.log.Debug(
+	ctxutil.Logger(ctx, uc.log).Debug(
 		"查询mds任务状态对应的执行记录id成功",
 		zap.Object("mds_task_record_ids", &mc),
 	)
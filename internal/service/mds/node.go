@@ -38,18 +38,16 @@ func (s *MdsNodeService) CreateMdsNode(
 		return nil, errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始创建mds节点",
 		zap.Object(database.ModelKey, &m),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	if err := s.nodeRepo.CreateModel(ctx, &m); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"创建mds节点失败",
 			zap.Error(err),
 			zap.Object(database.ModelKey, &m),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.NewGormError(err, nil)
 	}
@@ -65,10 +63,9 @@ func (s *MdsNodeService) CreateMdsNode(
 		return nil, err
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"创建mds节点成功",
 		zap.Object(database.ModelKey, nm),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return nm, nil
 }
@@ -82,21 +79,19 @@ func (s *MdsNodeService) UpdateMdsNodeByID(
 		return nil, errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始更新mds节点",
 		zap.Uint32("mds_node_id", mdsNodeID),
 		zap.Any(database.UpdateDataKey, data),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	data["id"] = mdsNodeID
 	if err := s.nodeRepo.UpdateModel(ctx, data, "id = ?", mdsNodeID); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"更新mds节点失败",
 			zap.Error(err),
 			zap.Uint32("mds_node_id", mdsNodeID),
 			zap.Any(database.UpdateDataKey, data),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.NewGormError(err, data)
 	}
@@ -112,10 +107,9 @@ func (s *MdsNodeService) UpdateMdsNodeByID(
 		return nil, err
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"更新mds节点成功",
 		zap.Uint32("mds_node_id", mdsNodeID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return m, nil
 }
@@ -128,26 +122,23 @@ func (s *MdsNodeService) DeleteMdsNodeByID(
 		return errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始删除mds节点",
 		zap.Uint32("mds_node_id", mdsNodeID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	if err := s.nodeRepo.DeleteModel(ctx, mdsNodeID); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"删除mds节点失败",
 			zap.Error(err),
 			zap.Uint32("mds_node_id", mdsNodeID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return errors.NewGormError(err, map[string]any{"id": mdsNodeID})
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"删除mds节点成功",
 		zap.Uint32("mds_node_id", mdsNodeID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return nil
 }
@@ -161,28 +152,25 @@ func (s *MdsNodeService) FindMdsNodeByID(
 		return nil, errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始查询mds节点",
 		zap.Strings(database.PreloadKey, preloads),
 		zap.Uint32("mds_node_id", mdsNodeID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	m, err := s.nodeRepo.GetModel(ctx, preloads, mdsNodeID)
 	if err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"查询mds节点失败",
 			zap.Error(err),
 			zap.Uint32("mds_node_id", mdsNodeID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.NewGormError(err, map[string]any{"id": mdsNodeID})
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"查询mds节点成功",
 		zap.Uint32("mds_node_id", mdsNodeID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return m, nil
 }
@@ -195,27 +183,24 @@ func (s *MdsNodeService) ListMdsNode(
 		return 0, nil, errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始查询mds节点列表",
 		zap.Object(database.QueryParamsKey, &qp),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	count, ms, err := s.nodeRepo.ListModel(ctx, qp)
 	if err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"查询mds节点列表失败",
 			zap.Error(err),
 			zap.Object(database.QueryParamsKey, &qp),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return 0, nil, errors.NewGormError(err, nil)
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"查询mds节点列表成功",
 		zap.Object(database.QueryParamsKey, &qp),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return count, ms, nil
 }
@@ -225,10 +210,9 @@ func (s *MdsNodeService) OutPortMdsNodeData(ctx context.Context, m *mdsmodel.Mds
 		return errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始导出mds节点变量文件",
 		zap.Object(database.ModelKey, m),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	var specdir string
 	switch m.NodeRole {
@@ -250,23 +234,21 @@ func (s *MdsNodeService) OutPortMdsNodeData(ctx context.Context, m *mdsmodel.Mds
 	confDir := common.GetMdsColonyConfigDir(m.MdsColony.ColonyNum)
 	mdsColonyConf := filepath.Join(confDir, specdir, "node.yaml")
 	if _, err := serializer.WriteYAML(mdsColonyConf, mdsVars); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"导出mds节点变量文件失败",
 			zap.Error(err),
 			zap.Uint32("mds_node_id", m.ID),
 			zap.String("colony_num", m.MdsColony.ColonyNum),
 			zap.String("path", mdsColonyConf),
 			zap.Object("mds_node_vars", &mdsVars),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return errors.ErrExportCacheFileFailed.WithCause(err)
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"导出mds节点变量文件失败",
 		zap.String("path", mdsColonyConf),
 		zap.Object("mds_colony_vars", &mdsVars),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return nil
 }
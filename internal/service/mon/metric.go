@@ -0,0 +1,445 @@
+package biz
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/sftp"
+	"go.uber.org/zap"
+
+	monmodel "gin-artweb/internal/model/mon"
+	resomodel "gin-artweb/internal/model/resource"
+	monrepo "gin-artweb/internal/repository/mon"
+	resosvc "gin-artweb/internal/service/resource"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+)
+
+// procFileMaxSize 读取/proc下文件的最大长度
+const procFileMaxSize = 1 << 16
+
+// HostMetricService mon节点主机指标服务
+//
+// 原始指标由主机代理随心跳上报, 或对未上报指标的mon节点主机通过SFTP读取/proc采集;
+// 后台任务将原始数据聚合为5分钟和1小时平均值, 并按各粒度的保留时间清理.
+// 查询时按时间范围选择粒度, 不依赖单独部署的Prometheus
+type HostMetricService struct {
+	log        *zap.Logger
+	metricRepo *monrepo.HostMetricRepo
+	nodeRepo   *monrepo.MonNodeRepo
+	ucFile     *resosvc.HostFileService
+	interval   time.Duration
+	diskPath   string
+	retentions map[uint32]time.Duration
+
+	// 上一次采集的CPU时间, 用于计算两次采集之间的CPU使用率
+	mu      sync.Mutex
+	cpuLast map[uint32]cpuTimes
+}
+
+func NewHostMetricService(
+	log *zap.Logger,
+	metricRepo *monrepo.HostMetricRepo,
+	nodeRepo *monrepo.MonNodeRepo,
+	ucFile *resosvc.HostFileService,
+	conf *config.MonitorConfig,
+) *HostMetricService {
+	var c config.MonitorConfig
+	if conf != nil {
+		c = *conf
+	}
+	return &HostMetricService{
+		log:        log,
+		metricRepo: metricRepo,
+		nodeRepo:   nodeRepo,
+		ucFile:     ucFile,
+		interval:   time.Duration(c.MetricInterval) * time.Second,
+		diskPath:   cmp.Or(c.MetricDiskPath, "/"),
+		retentions: map[uint32]time.Duration{
+			monmodel.HostMetricStepRaw:  time.Duration(cmp.Or(c.MetricRawHours, 24)) * time.Hour,
+			monmodel.HostMetricStep5Min: time.Duration(cmp.Or(c.Metric5MinDays, 7)) * 24 * time.Hour,
+			monmodel.HostMetricStepHour: time.Duration(cmp.Or(c.MetricHourDays, 90)) * 24 * time.Hour,
+		},
+		cpuLast: make(map[uint32]cpuTimes),
+	}
+}
+
+// RecordHostMetric 保存主机代理随心跳上报的指标
+func (s *HostMetricService) RecordHostMetric(
+	ctx context.Context,
+	hostID uint32,
+	metrics resomodel.HostAgentMetrics,
+	at time.Time,
+) *errors.Error {
+	if ctx.Err() != nil {
+		return errors.FromError(ctx.Err())
+	}
+
+	m := monmodel.HostMetricModel{
+		HostID:      hostID,
+		Step:        monmodel.HostMetricStepRaw,
+		Ts:          at.Unix(),
+		CPUPercent:  metrics.CPUPercent,
+		MemPercent:  metrics.MemPercent,
+		DiskPercent: metrics.DiskPercent,
+		Load1:       metrics.Load1,
+		Load5:       metrics.Load5,
+		Load15:      metrics.Load15,
+		Samples:     1,
+	}
+	if err := s.metricRepo.CreateModels(ctx, []monmodel.HostMetricModel{m}); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"保存主机指标失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, &m),
+		)
+		return errors.NewGormError(err, nil)
+	}
+	return nil
+}
+
+// QueryNodeMetrics 查询mon节点所在主机在时间范围内的指标
+func (s *HostMetricService) QueryNodeMetrics(
+	ctx context.Context,
+	nodeID uint32,
+	rangeName string,
+) (*monmodel.HostMetricSeriesOut, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	rangeName = cmp.Or(rangeName, "1h")
+	r, ok := monmodel.HostMetricRanges[rangeName]
+	if !ok {
+		return nil, errors.ErrValidationFailed.WithField("range", rangeName)
+	}
+
+	node, err := s.nodeRepo.GetModel(ctx, nil, nodeID)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询mon节点失败",
+			zap.Error(err),
+			zap.Uint32("mon_node_id", nodeID),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"id": nodeID})
+	}
+
+	end := time.Now().Unix()
+	start := end - int64(r.Duration/time.Second)
+	qp := database.QueryParams{
+		Query: map[string]any{
+			"host_id = ?": node.HostID,
+			"step = ?":    r.Step,
+			"ts >= ?":     start,
+		},
+		OrderBy: []string{"ts ASC"},
+	}
+	_, ms, err := s.metricRepo.ListModel(ctx, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询主机指标失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		return nil, errors.NewGormError(err, nil)
+	}
+
+	return &monmodel.HostMetricSeriesOut{
+		MonNodeID: node.ID,
+		HostID:    node.HostID,
+		Range:     rangeName,
+		Step:      r.Step,
+		Start:     start,
+		End:       end,
+		Points:    monmodel.ListHostMetricToPointOut(ms),
+	}, nil
+}
+
+// CollectNodeHosts 通过SFTP采集mon节点所在主机的指标, 最近一个采集间隔内已有指标的主机跳过
+func (s *HostMetricService) CollectNodeHosts(ctx context.Context) *errors.Error {
+	if ctx.Err() != nil {
+		return errors.FromError(ctx.Err())
+	}
+	if s.ucFile == nil || s.interval <= 0 {
+		return nil
+	}
+
+	_, nodes, err := s.nodeRepo.ListModel(ctx, database.QueryParams{OrderBy: []string{"id ASC"}})
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询待采集指标的mon节点失败",
+			zap.Error(err),
+		)
+		return errors.NewGormError(err, nil)
+	}
+
+	now := time.Now()
+	// 留出少量余量, 避免与采集间隔相同的代理心跳被重复采集
+	reported, err := s.metricRepo.ListReportedHostIDs(ctx, now.Add(-s.interval+5*time.Second).Unix())
+	if err != nil {
+		return errors.NewGormError(err, nil)
+	}
+	skip := make(map[uint32]bool, len(reported))
+	for _, id := range reported {
+		skip[id] = true
+	}
+
+	var ms []monmodel.HostMetricModel
+	for _, node := range *nodes {
+		if skip[node.HostID] {
+			continue
+		}
+		skip[node.HostID] = true
+		m, rErr := s.collectHost(ctx, node.HostID)
+		if rErr != nil {
+			ctxutil.Logger(ctx, s.log).Warn(
+				"采集主机指标失败",
+				zap.Error(rErr),
+				zap.Uint32("host_id", node.HostID),
+				zap.Uint32("mon_node_id", node.ID),
+			)
+			continue
+		}
+		ms = append(ms, *m)
+	}
+	if err := s.metricRepo.CreateModels(ctx, ms); err != nil {
+		return errors.NewGormError(err, nil)
+	}
+	return nil
+}
+
+// collectHost 在同一个SFTP会话中读取主机的/proc文件和磁盘用量
+//
+// 首次采集的主机没有上一次的CPU时间, 间隔1秒读取两次/proc/stat计算CPU使用率
+func (s *HostMetricService) collectHost(ctx context.Context, hostID uint32) (*monmodel.HostMetricModel, *errors.Error) {
+	m := &monmodel.HostMetricModel{
+		HostID:  hostID,
+		Step:    monmodel.HostMetricStepRaw,
+		Samples: 1,
+	}
+	rErr := s.ucFile.WithSFTP(ctx, hostID, func(client *sftp.Client) error {
+		s.mu.Lock()
+		prev, ok := s.cpuLast[hostID]
+		s.mu.Unlock()
+		if !ok {
+			data, err := readProcFile(client, "/proc/stat")
+			if err != nil {
+				return err
+			}
+			if prev, err = parseCPUTimes(data); err != nil {
+				return err
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(time.Second):
+			}
+		}
+
+		data, err := readProcFile(client, "/proc/stat")
+		if err != nil {
+			return err
+		}
+		cur, err := parseCPUTimes(data)
+		if err != nil {
+			return err
+		}
+		if data, err = readProcFile(client, "/proc/meminfo"); err != nil {
+			return err
+		}
+		if m.MemPercent, err = parseMemPercent(data); err != nil {
+			return err
+		}
+		if data, err = readProcFile(client, "/proc/loadavg"); err != nil {
+			return err
+		}
+		if m.Load1, m.Load5, m.Load15, err = parseLoadAvg(data); err != nil {
+			return err
+		}
+		vfs, err := client.StatVFS(s.diskPath)
+		if err != nil {
+			return err
+		}
+
+		m.CPUPercent = cur.percentSince(prev)
+		m.DiskPercent = diskPercent(vfs)
+		m.Ts = time.Now().Unix()
+		s.mu.Lock()
+		s.cpuLast[hostID] = cur
+		s.mu.Unlock()
+		return nil
+	})
+	if rErr != nil {
+		return nil, rErr
+	}
+	return m, nil
+}
+
+// Downsample 将最近两个完整周期的数据聚合为5分钟和1小时平均值, 并清理超过保留时间的数据
+//
+// 重复聚合同一周期时结果不变, 可以在多个实例上执行
+func (s *HostMetricService) Downsample(ctx context.Context) *errors.Error {
+	if ctx.Err() != nil {
+		return errors.FromError(ctx.Err())
+	}
+
+	now := time.Now().Unix()
+	levels := []struct{ src, dst uint32 }{
+		{monmodel.HostMetricStepRaw, monmodel.HostMetricStep5Min},
+		{monmodel.HostMetricStep5Min, monmodel.HostMetricStepHour},
+	}
+	for _, level := range levels {
+		end := now - now%int64(level.dst)
+		start := end - 2*int64(level.dst)
+		qp := database.QueryParams{
+			Query: map[string]any{
+				"step = ?": level.src,
+				"ts >= ?":  start,
+				"ts < ?":   end,
+			},
+			OrderBy: []string{"host_id ASC", "ts ASC"},
+		}
+		_, ms, err := s.metricRepo.ListModel(ctx, qp)
+		if err != nil {
+			return errors.NewGormError(err, nil)
+		}
+		buckets := monmodel.DownsampleHostMetrics(*ms, level.dst)
+		if err := s.metricRepo.ReplaceBuckets(ctx, level.dst, start, end, buckets); err != nil {
+			return errors.NewGormError(err, nil)
+		}
+	}
+
+	for step, retention := range s.retentions {
+		deleted, err := s.metricRepo.DeleteBefore(ctx, step, now-int64(retention/time.Second))
+		if err != nil {
+			return errors.NewGormError(err, nil)
+		}
+		if deleted > 0 {
+			ctxutil.Logger(ctx, s.log).Info(
+				"清理过期的主机指标",
+				zap.Uint32("step", step),
+				zap.Int64("deleted", deleted),
+			)
+		}
+	}
+	return nil
+}
+
+func readProcFile(client *sftp.Client, p string) ([]byte, error) {
+	f, err := client.Open(p)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	// /proc下的文件大小为0, 只能读取到文件结束
+	return io.ReadAll(io.LimitReader(f, procFileMaxSize))
+}
+
+// cpuTimes /proc/stat中全部CPU的累计时间
+type cpuTimes struct {
+	total uint64
+	idle  uint64
+}
+
+// percentSince 与上一次的累计时间相比的CPU使用率(%)
+func (t cpuTimes) percentSince(prev cpuTimes) float64 {
+	if t.total <= prev.total || t.idle < prev.idle {
+		return 0
+	}
+	total := t.total - prev.total
+	idle := t.idle - prev.idle
+	return float64(total-min(idle, total)) * 100 / float64(total)
+}
+
+// parseCPUTimes 解析/proc/stat的cpu行, 空闲时间包括iowait
+func parseCPUTimes(data []byte) (cpuTimes, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 5 || fields[0] != "cpu" {
+			continue
+		}
+		var t cpuTimes
+		for i, field := range fields[1:] {
+			v, err := strconv.ParseUint(field, 10, 64)
+			if err != nil {
+				return cpuTimes{}, fmt.Errorf("解析/proc/stat失败: %w", err)
+			}
+			// guest和guest_nice已计入user和nice
+			if i < 8 {
+				t.total += v
+			}
+			if i == 3 || i == 4 {
+				t.idle += v
+			}
+		}
+		return t, nil
+	}
+	return cpuTimes{}, fmt.Errorf("解析/proc/stat失败: 缺少cpu行")
+}
+
+// parseMemPercent 解析/proc/meminfo, 按可用内存计算内存使用率(%)
+//
+// 内核不提供MemAvailable时使用MemFree、Buffers和Cached之和
+func parseMemPercent(data []byte) (float64, error) {
+	values := make(map[string]uint64)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		name, rest, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(rest)
+		if len(fields) == 0 {
+			continue
+		}
+		if v, err := strconv.ParseUint(fields[0], 10, 64); err == nil {
+			values[name] = v
+		}
+	}
+	total := values["MemTotal"]
+	if total == 0 {
+		return 0, fmt.Errorf("解析/proc/meminfo失败: 缺少MemTotal")
+	}
+	avail, ok := values["MemAvailable"]
+	if !ok {
+		avail = values["MemFree"] + values["Buffers"] + values["Cached"]
+	}
+	return float64(total-min(avail, total)) * 100 / float64(total), nil
+}
+
+// parseLoadAvg 解析/proc/loadavg的1、5、15分钟平均负载
+func parseLoadAvg(data []byte) (float64, float64, float64, error) {
+	fields := strings.Fields(string(data))
+	if len(fields) < 3 {
+		return 0, 0, 0, fmt.Errorf("解析/proc/loadavg失败: %q", data)
+	}
+	var loads [3]float64
+	for i := range loads {
+		v, err := strconv.ParseFloat(fields[i], 64)
+		if err != nil {
+			return 0, 0, 0, fmt.Errorf("解析/proc/loadavg失败: %w", err)
+		}
+		loads[i] = v
+	}
+	return loads[0], loads[1], loads[2], nil
+}
+
+// diskPercent 与df相同, 按已用空间占已用和普通用户可用空间之和的比例计算磁盘使用率(%)
+func diskPercent(vfs *sftp.StatVFS) float64 {
+	used := vfs.Blocks - min(vfs.Bfree, vfs.Blocks)
+	if used+vfs.Bavail == 0 {
+		return 0
+	}
+	return float64(used) * 100 / float64(used+vfs.Bavail)
+}
@@ -0,0 +1,58 @@
+package biz
+
+import (
+	"testing"
+
+	"github.com/pkg/sftp"
+	"github.com/stretchr/testify/suite"
+)
+
+type HostMetricParseTestSuite struct {
+	suite.Suite
+}
+
+func (suite *HostMetricParseTestSuite) TestParseCPUTimes() {
+	prev, err := parseCPUTimes([]byte("cpu  100 0 100 700 100 0 0 0 0 0\ncpu0 50 0 50 350 50 0 0 0 0 0\n"))
+	suite.NoError(err)
+	suite.Equal(cpuTimes{total: 1000, idle: 800}, prev, "空闲时间应该包括iowait")
+
+	cur, err := parseCPUTimes([]byte("cpu  200 0 200 1300 300 0 0 0 50 0\n"))
+	suite.NoError(err)
+	suite.InDelta(20, cur.percentSince(prev), 0.001, "guest时间不应该重复计算")
+	suite.Zero(prev.percentSince(cur), "累计时间回退时使用率应该为0")
+
+	_, err = parseCPUTimes([]byte("intr 1 2 3\n"))
+	suite.Error(err)
+}
+
+func (suite *HostMetricParseTestSuite) TestParseMemPercent() {
+	pct, err := parseMemPercent([]byte("MemTotal:       1000 kB\nMemFree:         100 kB\nMemAvailable:    250 kB\n"))
+	suite.NoError(err)
+	suite.InDelta(75, pct, 0.001)
+
+	pct, err = parseMemPercent([]byte("MemTotal: 1000 kB\nMemFree: 100 kB\nBuffers: 100 kB\nCached: 300 kB\n"))
+	suite.NoError(err)
+	suite.InDelta(50, pct, 0.001, "缺少MemAvailable时应该按空闲、缓冲和缓存计算")
+
+	_, err = parseMemPercent([]byte("MemFree: 100 kB\n"))
+	suite.Error(err)
+}
+
+func (suite *HostMetricParseTestSuite) TestParseLoadAvg() {
+	l1, l5, l15, err := parseLoadAvg([]byte("0.52 0.41 0.30 2/512 12345\n"))
+	suite.NoError(err)
+	suite.Equal([]float64{0.52, 0.41, 0.30}, []float64{l1, l5, l15})
+
+	_, _, _, err = parseLoadAvg([]byte("0.52\n"))
+	suite.Error(err)
+}
+
+func (suite *HostMetricParseTestSuite) TestDiskPercent() {
+	// 已用60块, 普通用户可用20块, 保留块不计入
+	suite.InDelta(75, diskPercent(&sftp.StatVFS{Blocks: 100, Bfree: 40, Bavail: 20}), 0.001)
+	suite.Zero(diskPercent(&sftp.StatVFS{}))
+}
+
+func TestHostMetricParseTestSuite(t *testing.T) {
+	suite.Run(t, new(HostMetricParseTestSuite))
+}
@@ -38,18 +38,16 @@ func (s *MonNodeService) CreateMonNode(
 		return nil, errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始创建mon节点",
 		zap.Object(database.ModelKey, &m),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	if err := s.nodeRepo.CreateModel(ctx, &m); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"创建mon节点失败",
 			zap.Error(err),
 			zap.Object(database.ModelKey, &m),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.NewGormError(err, nil)
 	}
@@ -58,10 +56,9 @@ func (s *MonNodeService) CreateMonNode(
 		return nil, err
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"创建mon节点成功",
 		zap.Object(database.ModelKey, &m),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return s.FindMonNodeByID(ctx, []string{"Host"}, m.ID)
 }
@@ -75,20 +72,18 @@ func (s *MonNodeService) UpdateMonNodeByID(
 		return nil, errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始更新mon节点",
 		zap.Uint32("mon_node_id", nodeID),
 		zap.Any(database.UpdateDataKey, data),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	if err := s.nodeRepo.UpdateModel(ctx, data, "id = ?", nodeID); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"更新mon节点失败",
 			zap.Error(err),
 			zap.Uint32("mon_node_id", nodeID),
 			zap.Any(database.UpdateDataKey, data),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.NewGormError(err, data)
 	}
@@ -102,10 +97,9 @@ func (s *MonNodeService) UpdateMonNodeByID(
 		return nil, rErr
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"更新mon节点成功",
 		zap.Uint32("mon_node_id", nodeID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return m, nil
 }
@@ -118,38 +112,34 @@ func (s *MonNodeService) DeleteMonNodeByID(
 		return errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始删除mon",
 		zap.Uint32("mon_node_id", nodeID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	if err := s.nodeRepo.DeleteModel(ctx, nodeID); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"删除mon失败",
 			zap.Error(err),
 			zap.Uint32("mon_node_id", nodeID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return errors.NewGormError(err, map[string]any{"id": nodeID})
 	}
 
 	path := common.GetMonNodeExportPath(nodeID)
 	if err := os.RemoveAll(path); err != nil && !os.IsNotExist(err) {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"删除mon节点文件失败",
 			zap.Error(err),
 			zap.String("path", path),
 			zap.Uint32("mon_node_id", nodeID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return errors.ErrDeleteCacheFileFailed.WithCause(err)
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"mon删除成功",
 		zap.Uint32("mon_node_id", nodeID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return nil
 }
@@ -163,27 +153,24 @@ func (s *MonNodeService) FindMonNodeByID(
 		return nil, errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始查询mon",
 		zap.Uint32("mon_node_id", nodeID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	m, err := s.nodeRepo.GetModel(ctx, preloads, nodeID)
 	if err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"查询mon失败",
 			zap.Error(err),
 			zap.Uint32("mon_node_id", nodeID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.NewGormError(err, map[string]any{"id": nodeID})
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"查询mon成功",
 		zap.Uint32("mon_node_id", nodeID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return m, nil
 }
@@ -196,27 +183,24 @@ func (s *MonNodeService) ListMonNode(
 		return 0, nil, errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始查询mon列表",
 		zap.Object(database.QueryParamsKey, &qp),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	count, ms, err := s.nodeRepo.ListModel(ctx, qp)
 	if err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"查询mon列表失败",
 			zap.Error(err),
 			zap.Object(database.QueryParamsKey, &qp),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return 0, nil, errors.NewGormError(err, nil)
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"查询mon列表成功",
 		zap.Object(database.QueryParamsKey, &qp),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return count, ms, nil
 }
@@ -226,10 +210,9 @@ func (s *MonNodeService) ExportMonNode(ctx context.Context, m monmodel.MonNodeMo
 		return errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始导出mon节点文件",
 		zap.Object(database.ModelKey, &m),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	monNode := monmodel.MonNodeVars{
@@ -244,21 +227,19 @@ func (s *MonNodeService) ExportMonNode(ctx context.Context, m monmodel.MonNodeMo
 
 	path := common.GetMonNodeExportPath(m.ID)
 	if _, err := serializer.WriteYAML(path, monNode); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"导出mon节点文件失败",
 			zap.Error(err),
 			zap.String("path", path),
 			zap.Object("mon_node", &monNode),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return errors.ErrExportCacheFileFailed.WithCause(err)
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"导出mon节点文件成功",
 		zap.String("path", path),
 		zap.Object("mon_node", &monNode),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return nil
 }
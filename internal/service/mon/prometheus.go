@@ -0,0 +1,274 @@
+package biz
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	emperror "emperror.dev/errors"
+	"go.uber.org/zap"
+
+	monmodel "gin-artweb/internal/model/mon"
+	monrepo "gin-artweb/internal/repository/mon"
+	syssvc "gin-artweb/internal/service/system"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/deadline"
+	"gin-artweb/internal/shared/errors"
+	"gin-artweb/pkg/breaker"
+	"gin-artweb/pkg/promclient"
+)
+
+// MonPromService mon节点Prometheus数据源服务
+// 负责代理PromQL查询以及将采集目标的健康状态同步到mon节点
+// 每个数据源地址使用独立的熔断器, 数据源不可用时不再等待请求超时
+type MonPromService struct {
+	log      *zap.Logger
+	nodeRepo *monrepo.MonNodeRepo
+	alerts   *syssvc.AlertPublisher
+	timeout  time.Duration
+	breakers *breaker.Group
+}
+
+func NewMonPromService(
+	log *zap.Logger,
+	nodeRepo *monrepo.MonNodeRepo,
+	alerts *syssvc.AlertPublisher,
+	timeout time.Duration,
+	breakers *breaker.Group,
+) *MonPromService {
+	return &MonPromService{
+		log:      log,
+		nodeRepo: nodeRepo,
+		alerts:   alerts,
+		timeout:  timeout,
+		breakers: breakers,
+	}
+}
+
+func (s *MonPromService) newClient(m *monmodel.MonNodeModel) (*promclient.Client, error) {
+	return promclient.NewClient(m.PromURL, promclient.Auth{
+		Username:    m.PromUsername,
+		Password:    m.PromPassword,
+		BearerToken: m.PromBearerToken,
+	}, s.timeout)
+}
+
+// QueryNode 通过mon节点配置的数据源执行PromQL查询
+func (s *MonPromService) QueryNode(
+	ctx context.Context,
+	nodeID uint32,
+	req monmodel.QueryMonNodeRequest,
+) (map[string]any, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始执行mon节点PromQL查询",
+		zap.Uint32("mon_node_id", nodeID),
+		zap.String("query", req.Query),
+	)
+
+	m, err := s.nodeRepo.GetModel(ctx, nil, nodeID)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询mon节点失败",
+			zap.Error(err),
+			zap.Uint32("mon_node_id", nodeID),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"id": nodeID})
+	}
+	if m.PromURL == "" {
+		return nil, errors.ErrPromDatasourceNotConfigured.WithField("id", nodeID)
+	}
+
+	client, err := s.newClient(m)
+	if err != nil {
+		return nil, errors.ErrPromDatasourceNotConfigured.WithCause(err)
+	}
+
+	var resp *promclient.Response
+	leave := deadline.Enter(ctx, deadline.StagePrometheus)
+	err = s.breakers.Get(m.PromURL).Execute(func() (err error) {
+		if req.IsRange() {
+			resp, err = client.QueryRange(ctx, req.Query, req.Start, req.End, req.Step)
+		} else {
+			resp, err = client.Query(ctx, req.Query, req.Time)
+		}
+		return err
+	})
+	leave()
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"执行mon节点PromQL查询失败",
+			zap.Error(err),
+			zap.Uint32("mon_node_id", nodeID),
+			zap.String("query", req.Query),
+		)
+		if emperror.Is(err, breaker.ErrOpen) {
+			return nil, errors.ErrCircuitOpen.WithField("id", nodeID)
+		}
+		rErr := errors.ErrPromQueryFailed.WithCause(err)
+		if resp != nil && resp.Error != "" {
+			rErr = rErr.WithFields(map[string]any{
+				"error_type": resp.ErrorType,
+				"error":      resp.Error,
+			})
+		}
+		return nil, rErr
+	}
+
+	data := map[string]any{}
+	if err := json.Unmarshal(resp.Data, &data); err != nil {
+		return nil, errors.ErrPromQueryFailed.WithCause(err)
+	}
+	if len(resp.Warnings) > 0 {
+		data["warnings"] = resp.Warnings
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"执行mon节点PromQL查询成功",
+		zap.Uint32("mon_node_id", nodeID),
+	)
+	return data, nil
+}
+
+// SyncNodeHealth 同步所有配置了数据源的mon节点健康状态
+func (s *MonPromService) SyncNodeHealth(ctx context.Context) *errors.Error {
+	if ctx.Err() != nil {
+		return errors.FromError(ctx.Err())
+	}
+
+	qp := database.QueryParams{
+		Query: map[string]any{"prom_url <> ?": ""},
+	}
+	_, ms, err := s.nodeRepo.ListModel(ctx, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询待同步健康状态的mon节点失败",
+			zap.Error(err),
+		)
+		return errors.NewGormError(err, nil)
+	}
+
+	for _, m := range *ms {
+		health, message, lastScrape := s.checkNode(ctx, &m)
+		now := time.Now()
+		data := map[string]any{
+			"health":            health,
+			"health_message":    message,
+			"health_checked_at": now,
+		}
+		if lastScrape != nil {
+			data["last_scrape_at"] = *lastScrape
+		}
+		if err := s.nodeRepo.UpdateModel(ctx, data, "id = ?", m.ID); err != nil {
+			ctxutil.Logger(ctx, s.log).Error(
+				"更新mon节点健康状态失败",
+				zap.Error(err),
+				zap.Uint32("mon_node_id", m.ID),
+			)
+			continue
+		}
+		if health != m.Health {
+			s.notifyHealthChange(ctx, &m, health, message, now)
+		}
+	}
+	return nil
+}
+
+// alertKindNodeHealth mon节点健康状态变化告警
+const alertKindNodeHealth = "mon_node_health"
+
+// notifyHealthChange 发出mon节点健康状态变化告警, 处于维护窗口内时屏蔽告警并记录审计
+func (s *MonPromService) notifyHealthChange(
+	ctx context.Context,
+	m *monmodel.MonNodeModel,
+	health, message string,
+	now time.Time,
+) {
+	if !s.alerts.Publish(ctx, syssvc.Alert{
+		Kind:     alertKindNodeHealth,
+		Status:   health,
+		Scope:    syssvc.AlertScopeMonNode,
+		TargetID: m.ID,
+		At:       now,
+		Event: monmodel.AlertFiredEvent{
+			Kind:      alertKindNodeHealth,
+			MonNodeID: m.ID,
+			From:      m.Health,
+			To:        health,
+			Message:   message,
+			CheckedAt: now.Format(time.DateTime),
+		},
+		Detail: map[string]any{
+			"mon_node_id": m.ID,
+			"from":        m.Health,
+			"to":          health,
+			"message":     message,
+			"checked_at":  now.Format(time.DateTime),
+		},
+	}) {
+		return
+	}
+	ctxutil.Logger(ctx, s.log).Info(
+		"mon节点健康状态变化",
+		zap.Uint32("mon_node_id", m.ID),
+		zap.String("from", m.Health),
+		zap.String("to", health),
+		zap.String("message", message),
+	)
+}
+
+// checkNode 通过采集目标判断节点健康状态
+// 任一采集目标为up即视为节点正常, 最近采集时间取所有目标中最新的一次
+func (s *MonPromService) checkNode(
+	ctx context.Context,
+	m *monmodel.MonNodeModel,
+) (string, string, *time.Time) {
+	client, err := s.newClient(m)
+	if err != nil {
+		return monmodel.HealthDown, truncateMessage(err.Error()), nil
+	}
+	var targets []promclient.Target
+	err = s.breakers.Get(m.PromURL).Execute(func() (err error) {
+		targets, err = client.Targets(ctx)
+		return err
+	})
+	if err != nil {
+		return monmodel.HealthDown, truncateMessage(err.Error()), nil
+	}
+	if len(targets) == 0 {
+		return monmodel.HealthDown, "没有活跃的采集目标", nil
+	}
+
+	var (
+		lastScrape *time.Time
+		lastError  string
+		up         bool
+	)
+	for _, t := range targets {
+		if t.Health == monmodel.HealthUp {
+			up = true
+		} else if t.LastError != "" {
+			lastError = t.LastError
+		}
+		if !t.LastScrape.IsZero() && (lastScrape == nil || t.LastScrape.After(*lastScrape)) {
+			ls := t.LastScrape
+			lastScrape = &ls
+		}
+	}
+	if up {
+		return monmodel.HealthUp, "", lastScrape
+	}
+	return monmodel.HealthDown, truncateMessage(lastError), lastScrape
+}
+
+func truncateMessage(msg string) string {
+	r := []rune(msg)
+	if len(r) > 254 {
+		return string(r[:254])
+	}
+	return msg
+}
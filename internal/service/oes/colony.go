@@ -8,13 +8,16 @@ import (
 
 	"go.uber.org/zap"
 
+	jobsmodel "gin-artweb/internal/model/jobs"
 	oesmodel "gin-artweb/internal/model/oes"
 	oesrepo "gin-artweb/internal/repository/oes"
+	resosvc "gin-artweb/internal/service/resource"
 	"gin-artweb/internal/shared/common"
 	"gin-artweb/internal/shared/config"
 	"gin-artweb/internal/shared/ctxutil"
 	"gin-artweb/internal/shared/database"
 	"gin-artweb/internal/shared/errors"
+	"gin-artweb/internal/shared/events"
 	"gin-artweb/pkg/archive"
 	"gin-artweb/pkg/fileutil"
 	"gin-artweb/pkg/serializer"
@@ -23,15 +26,21 @@ import (
 type OesColonyService struct {
 	log        *zap.Logger
 	colonyRepo *oesrepo.OesColonyRepo
+	pkgService *resosvc.PackageService
+	outbox     *events.Outbox
 }
 
 func NewOesColonyService(
 	log *zap.Logger,
 	colonyRepo *oesrepo.OesColonyRepo,
+	pkgService *resosvc.PackageService,
+	outbox *events.Outbox,
 ) *OesColonyService {
 	return &OesColonyService{
 		log:        log,
 		colonyRepo: colonyRepo,
+		pkgService: pkgService,
+		outbox:     outbox,
 	}
 }
 
@@ -43,18 +52,16 @@ func (s *OesColonyService) CreateOesColony(
 		return nil, errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始创建oes集群",
 		zap.Object(database.ModelKey, &m),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	if err := s.colonyRepo.CreateModel(ctx, &m); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"创建oes集群失败",
 			zap.Error(err),
 			zap.Object(database.ModelKey, &m),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.NewGormError(err, nil)
 	}
@@ -70,10 +77,9 @@ func (s *OesColonyService) CreateOesColony(
 		return nil, err
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"创建oes集群成功",
 		zap.Object(database.ModelKey, nm),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return nm, nil
 }
@@ -87,24 +93,31 @@ func (s *OesColonyService) UpdateOesColonyByID(
 		return nil, errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始更新oes集群",
 		zap.Uint32("oes_colony_id", oesColonyID),
 		zap.Any(database.UpdateDataKey, data),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	data["id"] = oesColonyID
-	if err := s.colonyRepo.UpdateModel(ctx, data, "id = ?", oesColonyID); err != nil {
-		s.log.Error(
+	// 集群更新事件在同一事务中写入发件箱
+	colonyNum, _ := data["colony_num"].(string)
+	txCtx := s.outbox.Stage(ctx, func() events.Payload {
+		return oesmodel.NewColonyUpdatedEvent(oesColonyID, colonyNum)
+	})
+	if err := s.colonyRepo.UpdateModel(txCtx, data, "id = ?", oesColonyID); err != nil {
+		if database.IsVersionConflict(err) {
+			return nil, s.oesColonyVersionConflict(ctx, oesColonyID)
+		}
+		ctxutil.Logger(ctx, s.log).Error(
 			"更新oes集群失败",
 			zap.Error(err),
 			zap.Uint32("oes_colony_id", oesColonyID),
 			zap.Any(database.UpdateDataKey, data),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.NewGormError(err, data)
 	}
+	s.outbox.Notify()
 
 	// 查询关联数据
 	m, rErr := s.FindOesColonyByID(ctx, []string{"Package", "XCounter", "MonNode"}, oesColonyID)
@@ -117,14 +130,100 @@ func (s *OesColonyService) UpdateOesColonyByID(
 		return nil, err
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"更新oes集群成功",
 		zap.Uint32("oes_colony_id", oesColonyID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return m, nil
 }
 
+// oesColonyVersionConflict 版本冲突时返回携带当前oes集群数据的错误
+func (s *OesColonyService) oesColonyVersionConflict(ctx context.Context, oesColonyID uint32) *errors.Error {
+	ctxutil.Logger(ctx, s.log).Warn(
+		"更新oes集群失败: oes集群已被其他用户修改",
+		zap.Uint32("oes_colony_id", oesColonyID),
+	)
+	m, rErr := s.FindOesColonyByID(ctx, []string{"Package", "XCounter", "MonNode"}, oesColonyID)
+	if rErr != nil {
+		return rErr
+	}
+	return errors.ErrVersionConflict.WithField("current", oesmodel.OesColonyToDetailOut(*m))
+}
+
+// BatchUpdateOesColony 在同一个事务中批量更新oes集群, 并为每个集群写入审计记录
+// 事务提交后逐个刷新集群缓存数据, 返回每个集群的执行结果
+func (s *OesColonyService) BatchUpdateOesColony(
+	ctx context.Context,
+	ids []uint32,
+	data map[string]any,
+	username string,
+) ([]oesmodel.OesColonyBatchResultOut, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始批量更新oes集群",
+		zap.Uint32s("oes_colony_ids", ids),
+		zap.Any(database.UpdateDataKey, data),
+	)
+
+	// 去重并保持请求顺序
+	seen := make(map[uint32]bool, len(ids))
+	uniq := make([]uint32, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			uniq = append(uniq, id)
+		}
+	}
+
+	missing, err := s.colonyRepo.BatchUpdateModel(ctx, uniq, data, username)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"批量更新oes集群失败",
+			zap.Error(err),
+			zap.Uint32s("oes_colony_ids", uniq),
+			zap.Any(database.UpdateDataKey, data),
+		)
+		return nil, errors.NewGormError(err, data)
+	}
+	notFound := make(map[uint32]bool, len(missing))
+	for _, id := range missing {
+		notFound[id] = true
+	}
+
+	results := make([]oesmodel.OesColonyBatchResultOut, 0, len(uniq))
+	for _, id := range uniq {
+		if notFound[id] {
+			results = append(results, oesmodel.OesColonyBatchResultOut{
+				ID:      id,
+				Message: errors.ErrRecordNotFound.Msg,
+			})
+			continue
+		}
+		m, rErr := s.FindOesColonyByID(ctx, []string{"Package", "XCounter", "MonNode"}, id)
+		if rErr == nil {
+			rErr = s.OutportOesColonyData(ctx, m)
+		}
+		if rErr != nil {
+			results = append(results, oesmodel.OesColonyBatchResultOut{
+				ID:      id,
+				Message: rErr.Msg,
+			})
+			continue
+		}
+		results = append(results, oesmodel.OesColonyBatchResultOut{ID: id, Success: true})
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"批量更新oes集群成功",
+		zap.Uint32s("oes_colony_ids", uniq),
+		zap.Uint32s("missing_ids", missing),
+	)
+	return results, nil
+}
+
 func (s *OesColonyService) DeleteOesColonyByID(
 	ctx context.Context,
 	oesColonyID uint32,
@@ -133,26 +232,23 @@ func (s *OesColonyService) DeleteOesColonyByID(
 		return errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始删除oes集群",
 		zap.Uint32("oes_colony_id", oesColonyID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	if err := s.colonyRepo.DeleteModel(ctx, oesColonyID); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"删除oes集群失败",
 			zap.Error(err),
 			zap.Uint32("oes_colony_id", oesColonyID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return errors.NewGormError(err, map[string]any{"id": oesColonyID})
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"删除oes集群成功",
 		zap.Uint32("oes_colony_id", oesColonyID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return nil
 }
@@ -166,32 +262,55 @@ func (s *OesColonyService) FindOesColonyByID(
 		return nil, errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始查询oes集群",
 		zap.Strings(database.PreloadKey, preloads),
 		zap.Uint32("oes_colony_id", oesColonyID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	m, err := s.colonyRepo.GetModel(ctx, preloads, oesColonyID)
 	if err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"查询oes集群失败",
 			zap.Error(err),
 			zap.Uint32("oes_colony_id", oesColonyID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.NewGormError(err, map[string]any{"id": oesColonyID})
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"查询oes集群成功",
 		zap.Uint32("oes_colony_id", oesColonyID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return m, nil
 }
 
+// ColonyEnv 按集群号解析集群上下文的环境变量, 绑定该集群的计划任务在触发时注入
+func (s *OesColonyService) ColonyEnv(
+	ctx context.Context,
+	colonyNum string,
+) (map[string]string, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	m, err := s.colonyRepo.GetModel(ctx, []string{"Package", "MonNode", "MonNode.Host"}, "colony_num = ?", colonyNum)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询oes集群失败",
+			zap.Error(err),
+			zap.String("colony_num", colonyNum),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"colony_num": colonyNum})
+	}
+	return map[string]string{
+		jobsmodel.EnvColonyNum:      m.ColonyNum,
+		jobsmodel.EnvSystemType:     m.SystemType,
+		jobsmodel.EnvMonNodeIP:      m.MonNode.Host.SSHIP,
+		jobsmodel.EnvPackageVersion: m.Package.Version,
+	}, nil
+}
+
 func (s *OesColonyService) ListOesColony(
 	ctx context.Context,
 	qp database.QueryParams,
@@ -200,27 +319,24 @@ func (s *OesColonyService) ListOesColony(
 		return 0, nil, errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始查询角色列表",
 		zap.Object(database.QueryParamsKey, &qp),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	count, ms, err := s.colonyRepo.ListModel(ctx, qp)
 	if err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"查询oes集群列表失败",
 			zap.Error(err),
 			zap.Object(database.QueryParamsKey, &qp),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return 0, nil, errors.NewGormError(err, nil)
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"查询oes集群列表成功",
 		zap.Object(database.QueryParamsKey, &qp),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return count, ms, nil
 }
@@ -233,10 +349,9 @@ func (s *OesColonyService) OutportOesColonyData(
 		return errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始解压oes程序包并初始化集群配置文件",
 		zap.Object(database.ModelKey, m),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	colonyBinDir := common.GetOesColonyBinDir(m.ColonyNum)
@@ -244,7 +359,7 @@ func (s *OesColonyService) OutportOesColonyData(
 
 	if _, err := os.Stat(colonyBinDir); !os.IsNotExist(err) {
 		if err := os.RemoveAll(colonyBinDir); err != nil {
-			s.log.Error(
+			ctxutil.Logger(ctx, s.log).Error(
 				"清理原oes集群配置文件失败",
 				zap.Error(err),
 				zap.String("path", colonyBinDir),
@@ -255,16 +370,15 @@ func (s *OesColonyService) OutportOesColonyData(
 
 	tmpDir, mErr := os.MkdirTemp("/tmp", "oes-")
 	if mErr != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"创建oes程序包解压的tmp文件夹失败",
 			zap.Error(mErr),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return errors.FromError(mErr)
 	}
 	defer func() {
 		if err := os.RemoveAll(tmpDir); err != nil {
-			s.log.Error(
+			ctxutil.Logger(ctx, s.log).Error(
 				"删除oes程序包解压的tmp文件夹失败",
 				zap.Error(err),
 				zap.String("path", tmpDir),
@@ -272,71 +386,71 @@ func (s *OesColonyService) OutportOesColonyData(
 		}
 	}()
 
-	oesPkgPath := common.GetPackageStoragePath(m.Package.StorageFilename)
+	oesPkgPath, rErr := s.pkgService.LocalPackagePath(ctx, m.Package)
+	if rErr != nil {
+		return rErr
+	}
 	oesUnTarDirName, valiErr := archive.ValidateSingleDirTarGz(oesPkgPath)
 	if valiErr != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"oes程序包校验失败",
 			zap.Error(valiErr),
 			zap.String("path", oesPkgPath),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return errors.ErrZIPFileIsNotValid.WithCause(valiErr)
 	}
 
 	if err := archive.UntarGz(oesPkgPath, tmpDir, archive.WithContext(ctx)); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"解压oes程序包失败",
 			zap.Error(err),
 			zap.String("src_path", oesPkgPath),
 			zap.String("dst_path", colonyBinDir),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return errors.ErrUnZIPFailed.WithCause(err)
 	}
 
 	oesTmpDir := filepath.Join(tmpDir, oesUnTarDirName)
 	if err := fileutil.CopyDir(ctx, oesTmpDir, colonyBinDir, true); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"复制oes程序包解压目录失败",
 			zap.Error(err),
 			zap.String("src_path", oesTmpDir),
 			zap.String("dst_path", colonyBinDir),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return errors.FromError(err)
 	}
 
-	xcterPkgPath := common.GetPackageStoragePath(m.XCounter.StorageFilename)
+	xcterPkgPath, rErr := s.pkgService.LocalPackagePath(ctx, m.XCounter)
+	if rErr != nil {
+		return rErr
+	}
 	xcterUnTarDirName, valiErr := archive.ValidateSingleDirTarGz(xcterPkgPath)
 	if valiErr != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"xcounter程序包校验失败",
 			zap.Error(valiErr),
 			zap.String("path", xcterPkgPath),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return errors.ErrZIPFileIsNotValid.WithCause(valiErr)
 	}
 	if err := archive.UntarGz(xcterPkgPath, tmpDir); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"压缩xcounter程序包失败",
 			zap.Error(err),
 			zap.String("src_path", xcterPkgPath),
 			zap.String("dst_path", tmpDir),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return errors.ErrUnZIPFailed.WithCause(err)
 	}
 	xcterTmpDir := filepath.Join(tmpDir, xcterUnTarDirName, "bin")
 	oesBinDir := filepath.Join(colonyBinDir, "bin")
 	if err := fileutil.CopyDir(ctx, xcterTmpDir, oesBinDir, true); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"复制xcounter程序包解压目录失败",
 			zap.Error(err),
 			zap.String("src_path", xcterTmpDir),
 			zap.String("dst_path", oesBinDir),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return errors.FromError(err)
 	}
@@ -345,24 +459,22 @@ func (s *OesColonyService) OutportOesColonyData(
 	if _, err := os.Stat(colonyConfAll); os.IsNotExist(err) {
 		colonyBinConf := filepath.Join(colonyBinDir, "conf")
 		if err := fileutil.CopyDir(ctx, colonyBinConf, colonyConfAll, true); err != nil {
-			s.log.Error(
+			ctxutil.Logger(ctx, s.log).Error(
 				"复制oes集群配置文件失败",
 				zap.Error(err),
 				zap.String("src_path", colonyBinConf),
 				zap.String("dst_path", colonyConfAll),
-				zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			)
 			return errors.FromError(err)
 		}
 		srcPath := filepath.Join(config.ConfigDir, fmt.Sprintf("automatic_oes_%s.yaml", m.SystemType))
 		dstPath := filepath.Join(colonyConfAll, "automatic.yaml")
 		if err := fileutil.CopyFile(ctx, srcPath, dstPath); err != nil {
-			s.log.Error(
+			ctxutil.Logger(ctx, s.log).Error(
 				"复制oes的automatic配置文件失败",
 				zap.Error(err),
 				zap.String("src_path", colonyConfDir),
 				zap.String("dst_path", dstPath),
-				zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			)
 			return errors.FromError(err)
 		}
@@ -378,21 +490,19 @@ func (s *OesColonyService) OutportOesColonyData(
 	}
 	oesColonyConf := filepath.Join(colonyConfAll, "colony.yaml")
 	if _, err := serializer.WriteYAML(oesColonyConf, oesVars); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"导出oes集群配置变量文件失败",
 			zap.Error(err),
 			zap.String("path", oesColonyConf),
 			zap.Object("oes_colony_vars", &oesVars),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return errors.ErrExportCacheFileFailed.WithCause(err)
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"解压oes程序包并初始化集群配置文件成功",
 		zap.String("path", oesColonyConf),
 		zap.Object("oes_colony_vars", &oesVars),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return nil
 }
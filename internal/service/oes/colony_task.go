@@ -0,0 +1,146 @@
+package biz
+
+import (
+	"context"
+	"path/filepath"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	jobsmodel "gin-artweb/internal/model/jobs"
+	oesmodel "gin-artweb/internal/model/oes"
+	"gin-artweb/internal/shared/common"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/errors"
+)
+
+// ColonyTaskRecordCache 集群任务标识文件记录的每个日常任务最近一次的执行记录ID
+type ColonyTaskRecordCache struct {
+	ColonyNum string
+	TaskNames []string
+	RecordIDs []uint32
+}
+
+func (mc ColonyTaskRecordCache) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("colony_num", mc.ColonyNum)
+	for i, task := range mc.TaskNames {
+		enc.AddUint32(task, mc.RecordIDs[i])
+	}
+	return nil
+}
+
+// ColonyTaskRecord 日常任务最近一次的执行记录, 未执行时执行记录为nil
+type ColonyTaskRecord struct {
+	TaskName string
+	Record   *jobsmodel.ScriptRecordModel
+}
+
+// ColonyTaskExecutionInfo 集群日常任务的执行情况, 任务按任务目录的顺序排列
+type ColonyTaskExecutionInfo struct {
+	ColonyNum string
+	Tasks     []ColonyTaskRecord
+}
+
+type ColonyTaskExecutionInfoUsecase struct {
+	log       *zap.Logger
+	ucRecord  *JobsService
+	ucCatalog *OesTaskCatalogService
+}
+
+func NewColonyTaskExecutionInfoUsecase(
+	log *zap.Logger,
+	ucRecord *JobsService,
+	ucCatalog *OesTaskCatalogService,
+) *ColonyTaskExecutionInfoUsecase {
+	return &ColonyTaskExecutionInfoUsecase{
+		log:       log,
+		ucRecord:  ucRecord,
+		ucCatalog: ucCatalog,
+	}
+}
+
+// BuildTaskExecutionInfos 按任务目录获取集群日常任务的执行情况, 只返回指定系统类型的集群
+func (uc *ColonyTaskExecutionInfoUsecase) BuildTaskExecutionInfos(
+	ctx context.Context,
+	systemType string,
+	ms []oesmodel.OesColonyModel,
+) (*[]ColonyTaskExecutionInfo, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	taskNames, rErr := uc.ucCatalog.TaskNames(ctx, systemType)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	// 获取集群的执行记录,统计执行记录id
+	var trs []ColonyTaskRecordCache
+	var recordIDs []uint32
+	for _, m := range ms {
+		if m.SystemType != systemType {
+			continue
+		}
+		tr, rErr := uc.LoadTaskRecordCacheFromFiles(ctx, m.ColonyNum, taskNames)
+		if rErr != nil {
+			return nil, rErr
+		}
+		trs = append(trs, *tr)
+		for _, id := range tr.RecordIDs {
+			if id != 0 {
+				recordIDs = append(recordIDs, id)
+			}
+		}
+	}
+
+	// 执行数据库查询，获取集群对应的执行记录
+	cache, rErr := uc.ucRecord.FindRecordsByIDs(ctx, recordIDs)
+	if rErr != nil {
+		return nil, rErr
+	}
+	tasks := make([]ColonyTaskExecutionInfo, len(trs))
+	for i, tr := range trs {
+		info := ColonyTaskExecutionInfo{
+			ColonyNum: tr.ColonyNum,
+			Tasks:     make([]ColonyTaskRecord, len(tr.TaskNames)),
+		}
+		for j, task := range tr.TaskNames {
+			info.Tasks[j] = ColonyTaskRecord{
+				TaskName: task,
+				Record:   uc.ucRecord.FindRecordsByMap(ctx, cache, tr.RecordIDs[j], tr.ColonyNum, task),
+			}
+		}
+		tasks[i] = info
+	}
+	return &tasks, nil
+}
+
+// LoadTaskRecordCacheFromFiles 读取集群每个日常任务的标识文件
+func (uc *ColonyTaskExecutionInfoUsecase) LoadTaskRecordCacheFromFiles(
+	ctx context.Context,
+	colonyNum string,
+	taskNames []string,
+) (*ColonyTaskRecordCache, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+	flagDir := filepath.Join(config.StorageDir, "oes", "flags", colonyNum)
+	mc := ColonyTaskRecordCache{
+		ColonyNum: colonyNum,
+		TaskNames: taskNames,
+		RecordIDs: make([]uint32, len(taskNames)),
+	}
+	for i, task := range taskNames {
+		id, err := common.ReadUint32FromFile(filepath.Join(flagDir, "."+task))
+		if err != nil {
+			return nil, errors.FromError(err)
+		}
+		mc.RecordIDs[i] = id
+	}
+	ctxutil.Logger(ctx, uc.log).Debug(
+		"查询oes任务状态对应的执行记录id成功",
+		zap.Object("task_record", mc),
+	)
+	return &mc, nil
+}
@@ -0,0 +1,352 @@
+package biz
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	oesmodel "gin-artweb/internal/model/oes"
+	oesrepo "gin-artweb/internal/repository/oes"
+	resosvc "gin-artweb/internal/service/resource"
+	syssvc "gin-artweb/internal/service/system"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+)
+
+// OesColonyDriftService oes集群配置漂移检测服务
+//
+// 按配置模板渲染各节点的期望配置, 与节点主机上实际文件的校验和比较;
+// 配置了版本文件时同时比较节点上的程序包版本与集群记录的版本.
+// 集群出现新的漂移时发出告警事件, 处于维护窗口内时屏蔽告警
+type OesColonyDriftService struct {
+	log          *zap.Logger
+	driftRepo    *oesrepo.OesColonyDriftRepo
+	colonyRepo   *oesrepo.OesColonyRepo
+	templateRepo *oesrepo.OesConfTemplateRepo
+	ucTemplate   *OesConfTemplateService
+	ucFile       *resosvc.HostFileService
+	alerts       *syssvc.AlertPublisher
+	versionFile  string
+}
+
+func NewOesColonyDriftService(
+	log *zap.Logger,
+	driftRepo *oesrepo.OesColonyDriftRepo,
+	colonyRepo *oesrepo.OesColonyRepo,
+	templateRepo *oesrepo.OesConfTemplateRepo,
+	ucTemplate *OesConfTemplateService,
+	ucFile *resosvc.HostFileService,
+	alerts *syssvc.AlertPublisher,
+	conf *config.DriftConfig,
+) *OesColonyDriftService {
+	s := &OesColonyDriftService{
+		log:          log,
+		driftRepo:    driftRepo,
+		colonyRepo:   colonyRepo,
+		templateRepo: templateRepo,
+		ucTemplate:   ucTemplate,
+		ucFile:       ucFile,
+		alerts:       alerts,
+	}
+	if conf != nil {
+		s.versionFile = conf.VersionFile
+	}
+	return s
+}
+
+// ListColonyDrift 查询集群最近一次检测发现的配置漂移
+func (s *OesColonyDriftService) ListColonyDrift(
+	ctx context.Context,
+	colonyId uint32,
+) (*[]oesmodel.OesColonyDriftModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	if _, err := s.colonyRepo.GetModel(ctx, nil, colonyId); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询oes集群失败",
+			zap.Error(err),
+			zap.Uint32("oes_colony_id", colonyId),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"id": colonyId})
+	}
+	_, ms, err := s.driftRepo.ListModel(ctx, database.QueryParams{
+		OrderBy: []string{"id ASC"},
+		Query:   map[string]any{"oes_colony_id = ?": colonyId},
+	})
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询oes集群配置漂移失败",
+			zap.Error(err),
+			zap.Uint32("oes_colony_id", colonyId),
+		)
+		return nil, errors.NewGormError(err, nil)
+	}
+	return ms, nil
+}
+
+// CheckAllColonies 检测全部启用集群的配置漂移, 单个集群检测失败不影响其他集群
+func (s *OesColonyDriftService) CheckAllColonies(ctx context.Context) *errors.Error {
+	if ctx.Err() != nil {
+		return errors.FromError(ctx.Err())
+	}
+
+	_, colonies, err := s.colonyRepo.ListModel(ctx, database.QueryParams{
+		OrderBy: []string{"id ASC"},
+		Query:   map[string]any{"is_enable = ?": true},
+	})
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询oes集群列表失败",
+			zap.Error(err),
+		)
+		return errors.NewGormError(err, nil)
+	}
+	for _, colony := range *colonies {
+		if _, rErr := s.CheckColony(ctx, colony.ID); rErr != nil {
+			ctxutil.Logger(ctx, s.log).Error(
+				"检测oes集群配置漂移失败",
+				zap.Error(rErr),
+				zap.Uint32("oes_colony_id", colony.ID),
+			)
+		}
+	}
+	return nil
+}
+
+// CheckColony 检测集群的配置漂移并保存结果, 返回当前存在的漂移
+func (s *OesColonyDriftService) CheckColony(
+	ctx context.Context,
+	colonyId uint32,
+) ([]oesmodel.OesColonyDriftModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始检测oes集群配置漂移",
+		zap.Uint32("oes_colony_id", colonyId),
+	)
+
+	colony, nodes, rErr := s.ucTemplate.loadColony(ctx, colonyId)
+	if rErr != nil {
+		return nil, rErr
+	}
+	_, templates, err := s.templateRepo.ListModel(ctx, database.QueryParams{OrderBy: []string{"id ASC"}})
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询oes配置模板列表失败",
+			zap.Error(err),
+		)
+		return nil, errors.NewGormError(err, nil)
+	}
+
+	var drifts []oesmodel.OesColonyDriftModel
+	for _, tmpl := range *templates {
+		drifts = append(drifts, s.checkConf(ctx, &tmpl, colony, nodes)...)
+	}
+	if s.versionFile != "" {
+		drifts = append(drifts, s.checkPackage(ctx, colony, nodes)...)
+	}
+
+	_, previous, err := s.driftRepo.ListModel(ctx, database.QueryParams{
+		Query: map[string]any{"oes_colony_id = ?": colony.ID},
+	})
+	if err != nil {
+		return nil, errors.NewGormError(err, nil)
+	}
+	detected := make(map[string]time.Time, len(*previous))
+	for _, m := range *previous {
+		detected[m.Key()] = m.DetectedAt
+	}
+	now := time.Now()
+	var appeared []oesmodel.OesColonyDriftOut
+	for i := range drifts {
+		drifts[i].OesColonyID = colony.ID
+		if t, ok := detected[drifts[i].Key()]; ok {
+			drifts[i].DetectedAt = t
+			continue
+		}
+		drifts[i].DetectedAt = now
+		drifts[i].CreatedAt = now
+		appeared = append(appeared, *oesmodel.OesColonyDriftToOut(drifts[i]))
+	}
+	if err := s.driftRepo.ReplaceColonyModels(ctx, colony.ID, drifts); err != nil {
+		return nil, errors.NewGormError(err, nil)
+	}
+	if len(appeared) > 0 {
+		s.notifyDrift(ctx, colony, appeared, now)
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"检测oes集群配置漂移完成",
+		zap.Uint32("oes_colony_id", colony.ID),
+		zap.Int("drift_count", len(drifts)),
+		zap.Int("new_count", len(appeared)),
+	)
+	return drifts, nil
+}
+
+// checkConf 比较模板在各节点上的渲染结果与实际文件的校验和
+func (s *OesColonyDriftService) checkConf(
+	ctx context.Context,
+	tmpl *oesmodel.OesConfTemplateModel,
+	colony *oesmodel.OesColonyModel,
+	nodes []oesmodel.OesNodeModel,
+) []oesmodel.OesColonyDriftModel {
+	results, rErr := renderNodes(tmpl, colony, nodes, nil)
+	if rErr != nil {
+		return []oesmodel.OesColonyDriftModel{{
+			Kind:    oesmodel.DriftKindConf,
+			Name:    tmpl.Name,
+			Actual:  oesmodel.DriftActualError,
+			Message: truncateMessage(rErr.Error()),
+		}}
+	}
+
+	var drifts []oesmodel.OesColonyDriftModel
+	for _, r := range results {
+		expected := confChecksum([]byte(r.content))
+		actual := s.readChecksum(ctx, r.node.HostID, r.remotePath, confChecksum)
+		if actual.value == expected {
+			continue
+		}
+		drifts = append(drifts, oesmodel.OesColonyDriftModel{
+			NodeID:     r.node.ID,
+			HostID:     r.node.HostID,
+			Kind:       oesmodel.DriftKindConf,
+			Name:       tmpl.Name,
+			RemotePath: r.remotePath,
+			Expected:   expected,
+			Actual:     actual.value,
+			Message:    actual.message,
+		})
+	}
+	return drifts
+}
+
+// checkPackage 比较各节点版本文件中的程序包版本与集群记录的版本
+func (s *OesColonyDriftService) checkPackage(
+	ctx context.Context,
+	colony *oesmodel.OesColonyModel,
+	nodes []oesmodel.OesNodeModel,
+) []oesmodel.OesColonyDriftModel {
+	tmpl := &oesmodel.OesConfTemplateModel{
+		Name:       colony.Package.Label,
+		DirName:    oesmodel.ConfTemplateDirAll,
+		RemotePath: s.versionFile,
+	}
+	results, rErr := renderNodes(tmpl, colony, nodes, nil)
+	if rErr != nil {
+		return []oesmodel.OesColonyDriftModel{{
+			Kind:    oesmodel.DriftKindPackage,
+			Name:    colony.Package.Label,
+			Actual:  oesmodel.DriftActualError,
+			Message: truncateMessage(rErr.Error()),
+		}}
+	}
+
+	var drifts []oesmodel.OesColonyDriftModel
+	for _, r := range results {
+		actual := s.readChecksum(ctx, r.node.HostID, r.remotePath, func(data []byte) string {
+			return strings.TrimSpace(string(data))
+		})
+		if actual.value == colony.Package.Version {
+			continue
+		}
+		drifts = append(drifts, oesmodel.OesColonyDriftModel{
+			NodeID:     r.node.ID,
+			HostID:     r.node.HostID,
+			Kind:       oesmodel.DriftKindPackage,
+			Name:       colony.Package.Label,
+			RemotePath: r.remotePath,
+			Expected:   colony.Package.Version,
+			Actual:     truncateValue(actual.value),
+			Message:    actual.message,
+		})
+	}
+	return drifts
+}
+
+type driftActual struct {
+	value   string
+	message string
+}
+
+// readChecksum 读取主机文件并计算比较值, 文件不存在或读取失败时返回对应的标记
+func (s *OesColonyDriftService) readChecksum(
+	ctx context.Context,
+	hostId uint32,
+	p string,
+	sum func([]byte) string,
+) driftActual {
+	data, exists, rErr := s.ucFile.ReadFile(ctx, hostId, p, confTemplateMaxFileSize)
+	if rErr != nil {
+		return driftActual{value: oesmodel.DriftActualError, message: truncateMessage(rErr.Error())}
+	}
+	if !exists {
+		return driftActual{value: oesmodel.DriftActualMissing}
+	}
+	return driftActual{value: sum(data)}
+}
+
+// notifyDrift 发出集群配置漂移告警, 处于维护窗口内时屏蔽告警并记录审计
+func (s *OesColonyDriftService) notifyDrift(
+	ctx context.Context,
+	colony *oesmodel.OesColonyModel,
+	drifts []oesmodel.OesColonyDriftOut,
+	now time.Time,
+) {
+	if !s.alerts.Publish(ctx, syssvc.Alert{
+		Kind:     oesmodel.AlertKindColonyDrift,
+		Status:   "drift",
+		Scope:    syssvc.AlertScopeColony,
+		Module:   "oes",
+		TargetID: colony.ID,
+		At:       now,
+		Event: oesmodel.ColonyDriftEvent{
+			Kind:      oesmodel.AlertKindColonyDrift,
+			Project:   "oes",
+			ID:        colony.ID,
+			ColonyNum: colony.ColonyNum,
+			Drifts:    drifts,
+			CheckedAt: now.Format(time.DateTime),
+		},
+		Detail: map[string]any{
+			"oes_colony_id": colony.ID,
+			"drift_count":   len(drifts),
+			"checked_at":    now.Format(time.DateTime),
+		},
+	}) {
+		return
+	}
+	ctxutil.Logger(ctx, s.log).Warn(
+		"oes集群出现配置漂移",
+		zap.Uint32("oes_colony_id", colony.ID),
+		zap.String("colony_num", colony.ColonyNum),
+		zap.Int("drift_count", len(drifts)),
+	)
+}
+
+// truncateMessage 截断说明使其不超过数据库字段长度
+func truncateMessage(s string) string {
+	return truncateRunes(s, 254)
+}
+
+// truncateValue 截断实际值使其不超过数据库字段长度
+func truncateValue(s string) string {
+	return truncateRunes(s, 64)
+}
+
+func truncateRunes(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n])
+}
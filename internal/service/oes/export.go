@@ -0,0 +1,304 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"time"
+
+	"go.uber.org/zap"
+
+	jobsmodel "gin-artweb/internal/model/jobs"
+	oesmodel "gin-artweb/internal/model/oes"
+	oesrepo "gin-artweb/internal/repository/oes"
+	"gin-artweb/internal/shared/common"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+	"gin-artweb/pkg/archive"
+	"gin-artweb/pkg/fileutil"
+	"gin-artweb/pkg/serializer"
+)
+
+const (
+	// 导出任务的最长执行时间
+	colonyExportTimeout = 30 * time.Minute
+	// 导出的最大任务执行记录数
+	colonyExportMaxRecords = 1000
+)
+
+// OesColonyExportService oes集群运维历史导出服务
+// 导出内容包括集群配置、节点部署、配置文件、任务执行记录及其日志
+type OesColonyExportService struct {
+	log        *zap.Logger
+	exportRepo *oesrepo.OesColonyExportRepo
+	colonyRepo *oesrepo.OesColonyRepo
+	nodeRepo   *oesrepo.OesNodeRepo
+	ucRecord   *JobsService
+}
+
+func NewOesColonyExportService(
+	log *zap.Logger,
+	exportRepo *oesrepo.OesColonyExportRepo,
+	colonyRepo *oesrepo.OesColonyRepo,
+	nodeRepo *oesrepo.OesNodeRepo,
+	ucRecord *JobsService,
+) *OesColonyExportService {
+	return &OesColonyExportService{
+		log:        log,
+		exportRepo: exportRepo,
+		colonyRepo: colonyRepo,
+		nodeRepo:   nodeRepo,
+		ucRecord:   ucRecord,
+	}
+}
+
+// CreateExport 创建集群导出记录并在后台生成归档
+func (s *OesColonyExportService) CreateExport(
+	ctx context.Context,
+	oesColonyID uint32,
+	username string,
+) (*oesmodel.OesColonyExportModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始创建oes集群导出",
+		zap.Uint32("oes_colony_id", oesColonyID),
+	)
+
+	colony, err := s.colonyRepo.GetModel(ctx, nil, oesColonyID)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询oes集群失败",
+			zap.Error(err),
+			zap.Uint32("oes_colony_id", oesColonyID),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"id": oesColonyID})
+	}
+
+	m := &oesmodel.OesColonyExportModel{
+		OesColonyID: colony.ID,
+		ColonyNum:   colony.ColonyNum,
+		Status:      oesmodel.ExportStatusPending,
+		Username:    username,
+	}
+	if err := s.exportRepo.CreateModel(ctx, m); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"创建oes集群导出记录失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, m),
+		)
+		return nil, errors.NewGormError(err, nil)
+	}
+
+	go s.runExport(*m)
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"创建oes集群导出成功",
+		zap.Uint32("oes_colony_id", oesColonyID),
+		zap.Uint32("export_id", m.ID),
+	)
+	return m, nil
+}
+
+// FindExportByID 查询集群导出记录
+func (s *OesColonyExportService) FindExportByID(
+	ctx context.Context,
+	exportID uint32,
+) (*oesmodel.OesColonyExportModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	m, err := s.exportRepo.GetModel(ctx, nil, exportID)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询oes集群导出记录失败",
+			zap.Error(err),
+			zap.Uint32("export_id", exportID),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"id": exportID})
+	}
+	return m, nil
+}
+
+// FindExportFile 查询已生成的归档文件路径
+func (s *OesColonyExportService) FindExportFile(
+	ctx context.Context,
+	exportID uint32,
+) (*oesmodel.OesColonyExportModel, string, *errors.Error) {
+	m, rErr := s.FindExportByID(ctx, exportID)
+	if rErr != nil {
+		return nil, "", rErr
+	}
+	if m.Status != oesmodel.ExportStatusSuccess {
+		return nil, "", errors.ErrColonyExportNotReady.WithField("status", m.Status)
+	}
+	return m, common.GetOesColonyExportPath(m.Filename), nil
+}
+
+func (s *OesColonyExportService) runExport(m oesmodel.OesColonyExportModel) {
+	ctx, cancel := context.WithTimeout(context.Background(), colonyExportTimeout)
+	defer cancel()
+
+	defer func() {
+		if r := recover(); r != nil {
+			s.log.Error(
+				"oes集群导出发生panic",
+				zap.Any("panic", r),
+				zap.String("stack", string(debug.Stack())),
+				zap.Uint32("export_id", m.ID),
+			)
+			s.updateExport(ctx, m.ID, map[string]any{
+				"status":        oesmodel.ExportStatusFailed,
+				"error_message": fmt.Sprintf("%v", r),
+			})
+		}
+	}()
+
+	s.updateExport(ctx, m.ID, map[string]any{"status": oesmodel.ExportStatusRunning})
+
+	filename, size, err := s.buildArchive(ctx, m)
+	if err != nil {
+		s.log.Error(
+			"生成oes集群导出归档失败",
+			zap.Error(err),
+			zap.Uint32("export_id", m.ID),
+			zap.String("colony_num", m.ColonyNum),
+		)
+		s.updateExport(ctx, m.ID, map[string]any{
+			"status":        oesmodel.ExportStatusFailed,
+			"error_message": err.Error(),
+		})
+		return
+	}
+
+	s.updateExport(ctx, m.ID, map[string]any{
+		"status":   oesmodel.ExportStatusSuccess,
+		"filename": filename,
+		"size":     size,
+	})
+	s.log.Info(
+		"生成oes集群导出归档成功",
+		zap.Uint32("export_id", m.ID),
+		zap.String("colony_num", m.ColonyNum),
+		zap.String("filename", filename),
+		zap.Int64("size", size),
+	)
+}
+
+func (s *OesColonyExportService) updateExport(ctx context.Context, exportID uint32, data map[string]any) {
+	// 导出超时后仍需记录最终状态
+	if ctx.Err() != nil {
+		ctx = context.Background()
+	}
+	if err := s.exportRepo.UpdateModel(ctx, data, "id = ?", exportID); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"更新oes集群导出记录失败",
+			zap.Error(err),
+			zap.Uint32("export_id", exportID),
+			zap.Any(database.UpdateDataKey, data),
+		)
+	}
+}
+
+// buildArchive 汇总集群运维数据并打包为tar.gz归档, 返回归档文件名和大小
+func (s *OesColonyExportService) buildArchive(
+	ctx context.Context,
+	m oesmodel.OesColonyExportModel,
+) (string, int64, error) {
+	colony, err := s.colonyRepo.GetModel(ctx, []string{"Package", "XCounter", "MonNode"}, m.OesColonyID)
+	if err != nil {
+		return "", 0, err
+	}
+	_, nodes, err := s.nodeRepo.ListModel(ctx, database.QueryParams{
+		Preloads: []string{"Host"},
+		OrderBy:  []string{"id ASC"},
+		Query:    map[string]any{"oes_colony_id = ?": colony.ID},
+	})
+	if err != nil {
+		return "", 0, err
+	}
+	records, rErr := s.ucRecord.ListRecordsByColony(ctx, colony.ColonyNum, colonyExportMaxRecords)
+	if rErr != nil {
+		return "", 0, rErr
+	}
+
+	now := time.Now()
+	name := fmt.Sprintf("oes_colony_%s_%s", colony.ColonyNum, now.Format("20060102150405"))
+	tmpDir, err := os.MkdirTemp("", "oes-export-")
+	if err != nil {
+		return "", 0, err
+	}
+	defer os.RemoveAll(tmpDir)
+	workDir := filepath.Join(tmpDir, name)
+
+	manifest := oesmodel.OesColonyExportManifest{
+		ExportID:    m.ID,
+		ColonyID:    colony.ID,
+		ColonyNum:   colony.ColonyNum,
+		GeneratedAt: now.Format(time.DateTime),
+		Username:    m.Username,
+		Sections: map[string]string{
+			"colony":   "colony.json",
+			"nodes":    "nodes.json",
+			"records":  "records.json",
+			"logs":     "logs/",
+			"config":   "config/",
+			"alerts":   "平台未记录告警数据",
+			"comments": "平台未记录备注数据",
+		},
+	}
+
+	files := map[string]any{
+		"manifest.json": manifest,
+		"colony.json":   oesmodel.OesColonyToDetailOut(*colony),
+		"nodes.json":    oesmodel.ListOesNodeToDetailOut(nodes),
+		"records.json":  jobsmodel.ListScriptRecordToDetailOut(records),
+	}
+	for fn, data := range files {
+		if _, err := serializer.WriteJSON(
+			filepath.Join(workDir, fn), data,
+			serializer.WithContext(ctx), serializer.WithIndent(2),
+		); err != nil {
+			return "", 0, err
+		}
+	}
+
+	confDir := common.GetOesColonyConfigDir(colony.ColonyNum)
+	if _, err := os.Stat(confDir); err == nil {
+		if err := fileutil.CopyDir(ctx, confDir, filepath.Join(workDir, "config"), true); err != nil {
+			return "", 0, err
+		}
+	}
+
+	logDir := filepath.Join(workDir, "logs")
+	if err := os.MkdirAll(logDir, 0o755); err != nil {
+		return "", 0, err
+	}
+	for _, r := range *records {
+		logPath := common.GetScriptLogStoragePath(r.CreatedAt, r.LogName)
+		if _, err := os.Stat(logPath); err != nil {
+			continue
+		}
+		dst := filepath.Join(logDir, fmt.Sprintf("%d_%s", r.ID, r.LogName))
+		if err := fileutil.CopyFile(ctx, logPath, dst); err != nil {
+			return "", 0, err
+		}
+	}
+
+	filename := name + ".tar.gz"
+	dst := common.GetOesColonyExportPath(filename)
+	if err := archive.TarGz(workDir, dst, archive.WithContext(ctx)); err != nil {
+		return "", 0, err
+	}
+	info, err := os.Stat(dst)
+	if err != nil {
+		return "", 0, err
+	}
+	return filename, info.Size(), nil
+}
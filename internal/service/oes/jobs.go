@@ -72,22 +72,20 @@ func (uc *JobsService) FindRecordsByMap(
 ) *jobsmodel.ScriptRecordModel {
 	task, exists := cache[recordID]
 	if !exists {
-		uc.log.Debug(
+		ctxutil.Logger(ctx, uc.log).Debug(
 			"未找到oes的任务状态",
 			zap.String("colony_num", colonyNum),
 			zap.String("task_name", taskName),
 			zap.Uint32("script_record_id", recordID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil
 	}
-	uc.log.Debug(
+	ctxutil.Logger(ctx, uc.log).Debug(
 		"获取oes的任务状态成功",
 		zap.String("colony_num", colonyNum),
 		zap.String("task_name", taskName),
 		zap.Uint32("script_record_id", recordID),
 		zap.Object("task", &task),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return &task
 }
@@ -103,10 +101,9 @@ func (uc *JobsService) InitCron(
 
 	claims, cErr := ctxutil.GetUserClaims(ctx)
 	if cErr != nil {
-		uc.log.Error(
+		ctxutil.Logger(ctx, uc.log).Error(
 			"获取用户信息失败",
 			zap.Error(cErr),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return cErr
 	}
@@ -121,10 +118,9 @@ func (uc *JobsService) InitCron(
 		Columns: []string{"id", "name"},
 	})
 	if rErr != nil {
-		uc.log.Error(
+		ctxutil.Logger(ctx, uc.log).Error(
 			"获取mds的任务脚本失败",
 			zap.Error(rErr),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return rErr
 	}
@@ -147,13 +143,88 @@ func (uc *JobsService) InitCron(
 			Username:      claims.Username,
 		})
 		if err != nil {
-			uc.log.Error(
+			ctxutil.Logger(ctx, uc.log).Error(
 				"创建mds的任务失败",
 				zap.Error(err),
-				zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			)
 			return err
 		}
 	}
 	return nil
 }
+
+// ListRecordsByColony 查询集群最近的oes任务执行记录
+// 内置任务以集群号作为命令行参数执行, 按参数和脚本所属项目筛选
+func (uc *JobsService) ListRecordsByColony(
+	ctx context.Context,
+	colonyNum string,
+	limit int,
+) (*[]jobsmodel.ScriptRecordModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	_, ms, rErr := uc.ucRecord.ListcriptRecord(ctx, database.QueryParams{
+		Preloads: []string{"Script"},
+		Size:     limit,
+		OrderBy:  []string{"id DESC"},
+		Query: map[string]any{
+			"command_args = ?": colonyNum,
+		},
+	})
+	if rErr != nil {
+		return nil, rErr
+	}
+	rms := make([]jobsmodel.ScriptRecordModel, 0, len(*ms))
+	for _, m := range *ms {
+		if m.Script.Project == "oes" {
+			rms = append(rms, m)
+		}
+	}
+	return &rms, nil
+}
+
+// FindCmdScripts 按名称查询oes项目cmd标签下的内置脚本
+func (uc *JobsService) FindCmdScripts(
+	ctx context.Context,
+	names []string,
+) (map[string]jobsmodel.ScriptModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	_, ms, rErr := uc.ucScript.ListScript(ctx, database.QueryParams{
+		Query: map[string]any{
+			"is_builtin = ?": true,
+			"project = ?":    "oes",
+			"label = ?":      "cmd",
+			"name in ?":      names,
+		},
+	})
+	if rErr != nil {
+		ctxutil.Logger(ctx, uc.log).Error(
+			"获取oes的任务脚本失败",
+			zap.Error(rErr),
+			zap.Strings("names", names),
+		)
+		return nil, rErr
+	}
+	result := make(map[string]jobsmodel.ScriptModel, len(*ms))
+	for _, m := range *ms {
+		result[m.Name] = m
+	}
+	return result, nil
+}
+
+// CreateRecord 创建脚本执行记录, 由调用方决定何时执行
+func (uc *JobsService) CreateRecord(
+	ctx context.Context,
+	req jobsmodel.ExecuteRequest,
+) (*jobsmodel.ScriptRecordModel, *errors.Error) {
+	return uc.ucRecord.CreateScriptRecord(ctx, req)
+}
+
+// ExecuteRecord 同步执行脚本执行记录, 返回执行结果
+func (uc *JobsService) ExecuteRecord(record *jobsmodel.ScriptRecordModel) *jobsmodel.TaskInfo {
+	return uc.ucRecord.Execute(record)
+}
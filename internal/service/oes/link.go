@@ -0,0 +1,528 @@
+package biz
+
+import (
+	"cmp"
+	"context"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	oesmodel "gin-artweb/internal/model/oes"
+	oesrepo "gin-artweb/internal/repository/oes"
+	jobsvc "gin-artweb/internal/service/jobs"
+	resosvc "gin-artweb/internal/service/resource"
+	syssvc "gin-artweb/internal/service/system"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+)
+
+// linkProbeSSHMargin 通过ssh探测时在链路超时之外为建立ssh连接预留的时间
+const linkProbeSSHMargin = 10 * time.Second
+
+// OesLinkService oes集群组件之间的网络链路探测服务
+//
+// 定时探测每条链路的目标端口能否建立TCP连接并记录耗时, 源主机为空时由平台探测,
+// 否则通过ssh在源主机上探测. 链路变为不可达时发出告警, 交易日开盘前对仍不可达的链路再次告警,
+// 处于维护窗口内时屏蔽告警
+type OesLinkService struct {
+	log         *zap.Logger
+	linkRepo    *oesrepo.OesLinkRepo
+	probeRepo   *oesrepo.OesLinkProbeRepo
+	colonyRepo  *oesrepo.OesColonyRepo
+	ucHost      *resosvc.HostService
+	calendar    *jobsvc.CalendarService
+	alerts      *syssvc.AlertPublisher
+	concurrency int
+	retention   time.Duration
+	// mu 串行化定时探测和开盘前检查
+	mu sync.Mutex
+}
+
+func NewOesLinkService(
+	log *zap.Logger,
+	linkRepo *oesrepo.OesLinkRepo,
+	probeRepo *oesrepo.OesLinkProbeRepo,
+	colonyRepo *oesrepo.OesColonyRepo,
+	ucHost *resosvc.HostService,
+	calendar *jobsvc.CalendarService,
+	alerts *syssvc.AlertPublisher,
+	conf *config.ConnectivityConfig,
+) *OesLinkService {
+	var c config.ConnectivityConfig
+	if conf != nil {
+		c = *conf
+	}
+	return &OesLinkService{
+		log:         log,
+		linkRepo:    linkRepo,
+		probeRepo:   probeRepo,
+		colonyRepo:  colonyRepo,
+		ucHost:      ucHost,
+		calendar:    calendar,
+		alerts:      alerts,
+		concurrency: max(c.Concurrency, 1),
+		retention:   time.Duration(cmp.Or(c.RetentionDays, 7)) * 24 * time.Hour,
+	}
+}
+
+// checkLink 校验链路指定的集群和源主机存在
+func (s *OesLinkService) checkLink(ctx context.Context, m oesmodel.OesLinkModel) *errors.Error {
+	if m.OesColonyID != 0 {
+		if _, err := s.colonyRepo.GetModel(ctx, nil, m.OesColonyID); err != nil {
+			ctxutil.Logger(ctx, s.log).Error(
+				"查询oes集群失败",
+				zap.Error(err),
+				zap.Uint32("oes_colony_id", m.OesColonyID),
+			)
+			return errors.NewGormError(err, map[string]any{"oes_colony_id": m.OesColonyID})
+		}
+	}
+	if m.SourceHostID != nil {
+		if _, rErr := s.ucHost.FindHostById(ctx, *m.SourceHostID); rErr != nil {
+			return rErr
+		}
+	}
+	return nil
+}
+
+func (s *OesLinkService) CreateLink(
+	ctx context.Context,
+	m oesmodel.OesLinkModel,
+) (*oesmodel.OesLinkModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	if rErr := s.checkLink(ctx, m); rErr != nil {
+		return nil, rErr
+	}
+	m.Status = oesmodel.LinkUnknown
+	if err := s.linkRepo.CreateModel(ctx, &m); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"创建oes链路失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, &m),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"name": m.Name})
+	}
+	return s.FindLinkByID(ctx, m.ID)
+}
+
+// UpdateLinkByID 更新链路, 源主机或目标变化时探测状态重置为未探测
+func (s *OesLinkService) UpdateLinkByID(
+	ctx context.Context,
+	m oesmodel.OesLinkModel,
+) (*oesmodel.OesLinkModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	old, rErr := s.FindLinkByID(ctx, m.ID)
+	if rErr != nil {
+		return nil, rErr
+	}
+	if rErr := s.checkLink(ctx, m); rErr != nil {
+		return nil, rErr
+	}
+	data := map[string]any{
+		"name":           m.Name,
+		"oes_colony_id":  m.OesColonyID,
+		"source_host_id": m.SourceHostID,
+		"target_name":    m.TargetName,
+		"target_host":    m.TargetHost,
+		"target_port":    m.TargetPort,
+		"timeout_ms":     m.TimeoutMs,
+		"is_enabled":     m.IsEnabled,
+		"remark":         m.Remark,
+	}
+	if old.Target() != m.Target() || !equalHostID(old.SourceHostID, m.SourceHostID) {
+		data["status"] = oesmodel.LinkUnknown
+		data["latency_ms"] = 0
+		data["last_error"] = ""
+	}
+	if err := s.linkRepo.UpdateModel(ctx, data, "id = ?", m.ID); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"更新oes链路失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, &m),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"id": m.ID})
+	}
+	return s.FindLinkByID(ctx, m.ID)
+}
+
+func (s *OesLinkService) DeleteLinkByID(
+	ctx context.Context,
+	linkID uint32,
+) *errors.Error {
+	if ctx.Err() != nil {
+		return errors.FromError(ctx.Err())
+	}
+
+	if _, rErr := s.FindLinkByID(ctx, linkID); rErr != nil {
+		return rErr
+	}
+	if err := s.linkRepo.DeleteModel(ctx, linkID); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"删除oes链路失败",
+			zap.Error(err),
+			zap.Uint32("link_id", linkID),
+		)
+		return errors.NewGormError(err, map[string]any{"id": linkID})
+	}
+	return nil
+}
+
+func (s *OesLinkService) FindLinkByID(
+	ctx context.Context,
+	linkID uint32,
+) (*oesmodel.OesLinkModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	m, err := s.linkRepo.GetModel(ctx, []string{"SourceHost"}, linkID)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询oes链路失败",
+			zap.Error(err),
+			zap.Uint32("link_id", linkID),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"id": linkID})
+	}
+	return m, nil
+}
+
+func (s *OesLinkService) ListLink(
+	ctx context.Context,
+	qp database.QueryParams,
+) (int64, *[]oesmodel.OesLinkModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return 0, nil, errors.FromError(ctx.Err())
+	}
+
+	count, ms, err := s.linkRepo.ListModel(ctx, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询oes链路列表失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		return 0, nil, errors.NewGormError(err, nil)
+	}
+	return count, ms, nil
+}
+
+// ListLinkProbe 查询链路的探测记录
+func (s *OesLinkService) ListLinkProbe(
+	ctx context.Context,
+	linkID uint32,
+	qp database.QueryParams,
+) (int64, *[]oesmodel.OesLinkProbeModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return 0, nil, errors.FromError(ctx.Err())
+	}
+
+	if _, rErr := s.FindLinkByID(ctx, linkID); rErr != nil {
+		return 0, nil, rErr
+	}
+	if qp.Query == nil {
+		qp.Query = map[string]any{}
+	}
+	qp.Query["link_id = ?"] = linkID
+	count, ms, err := s.probeRepo.ListModel(ctx, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询oes链路探测记录失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		return 0, nil, errors.NewGormError(err, nil)
+	}
+	return count, ms, nil
+}
+
+// Matrix 按探测发起方和目标汇总启用链路的最近一次探测结果, 集群ID为空时汇总全部链路
+func (s *OesLinkService) Matrix(
+	ctx context.Context,
+	colonyID *uint32,
+) (*oesmodel.OesLinkMatrixOut, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	query := map[string]any{"is_enabled = ?": true}
+	if colonyID != nil {
+		query["oes_colony_id = ?"] = *colonyID
+	}
+	_, ms, rErr := s.ListLink(ctx, database.QueryParams{
+		Preloads: []string{"SourceHost"},
+		OrderBy:  []string{"id ASC"},
+		Query:    query,
+	})
+	if rErr != nil {
+		return nil, rErr
+	}
+	return oesmodel.NewOesLinkMatrixOut(ms), nil
+}
+
+// ProbeLinkByID 立即探测链路, 与定时探测相同, 变为不可达时发出告警
+func (s *OesLinkService) ProbeLinkByID(
+	ctx context.Context,
+	linkID uint32,
+) (*oesmodel.OesLinkModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	m, rErr := s.FindLinkByID(ctx, linkID)
+	if rErr != nil {
+		return nil, rErr
+	}
+	if rErr := s.probe(ctx, m, false); rErr != nil {
+		return nil, rErr
+	}
+	return s.FindLinkByID(ctx, linkID)
+}
+
+// ProbeAll 探测全部启用的链路, 单条链路探测失败不影响其他链路
+func (s *OesLinkService) ProbeAll(ctx context.Context) *errors.Error {
+	if ctx.Err() != nil {
+		return errors.FromError(ctx.Err())
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.probeAll(ctx, false)
+}
+
+// PreOpenCheck 交易日开盘前探测全部启用的链路, 对不可达的链路全部发出告警, 非交易日跳过
+func (s *OesLinkService) PreOpenCheck(ctx context.Context) *errors.Error {
+	if ctx.Err() != nil {
+		return errors.FromError(ctx.Err())
+	}
+
+	if s.calendar != nil {
+		day, rErr := s.calendar.CheckTradingDay(ctx, time.Now())
+		if rErr != nil {
+			ctxutil.Logger(ctx, s.log).Error(
+				"查询交易日历失败, 照常进行开盘前链路检查",
+				zap.Error(rErr),
+			)
+		} else if !day.IsTradingDay {
+			ctxutil.Logger(ctx, s.log).Debug(
+				"非交易日, 跳过开盘前链路检查",
+				zap.String("date", day.Date),
+			)
+			return nil
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.probeAll(ctx, true)
+}
+
+func (s *OesLinkService) probeAll(ctx context.Context, preOpen bool) *errors.Error {
+	_, ms, rErr := s.ListLink(ctx, database.QueryParams{
+		Preloads: []string{"SourceHost"},
+		OrderBy:  []string{"id ASC"},
+		Query:    map[string]any{"is_enabled = ?": true},
+	})
+	if rErr != nil {
+		return rErr
+	}
+
+	sem := make(chan struct{}, s.concurrency)
+	var wg sync.WaitGroup
+	for i := range *ms {
+		m := &(*ms)[i]
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			if rErr := s.probe(ctx, m, preOpen); rErr != nil {
+				ctxutil.Logger(ctx, s.log).Error(
+					"探测oes链路失败",
+					zap.Error(rErr),
+					zap.Uint32("link_id", m.ID),
+				)
+			}
+		}()
+	}
+	wg.Wait()
+	return nil
+}
+
+// probe 探测链路并保存结果, 变为不可达或开盘前检查仍不可达时发出告警
+func (s *OesLinkService) probe(ctx context.Context, m *oesmodel.OesLinkModel, preOpen bool) *errors.Error {
+	status, latency, message := s.dial(ctx, m)
+	now := time.Now()
+
+	if err := s.probeRepo.CreateModel(ctx, &oesmodel.OesLinkProbeModel{
+		LinkID:    m.ID,
+		Status:    status,
+		LatencyMs: latency,
+		Error:     message,
+	}); err != nil {
+		return errors.NewGormError(err, map[string]any{"link_id": m.ID})
+	}
+	if err := s.linkRepo.UpdateModel(ctx, map[string]any{
+		"status":          status,
+		"latency_ms":      latency,
+		"last_error":      message,
+		"last_checked_at": now,
+	}, "id = ?", m.ID); err != nil {
+		return errors.NewGormError(err, map[string]any{"id": m.ID})
+	}
+
+	if status == oesmodel.LinkUnreachable && (preOpen || m.Status != oesmodel.LinkUnreachable) {
+		s.notify(ctx, m, message, preOpen, now)
+	} else if status == oesmodel.LinkReachable && m.Status == oesmodel.LinkUnreachable {
+		ctxutil.Logger(ctx, s.log).Info(
+			"oes链路已恢复可达",
+			zap.Uint32("link_id", m.ID),
+			zap.String("source", m.Source()),
+			zap.String("target", m.Target()),
+		)
+	}
+	return nil
+}
+
+// dial 尝试与目标端口建立TCP连接, 返回探测状态、建立连接的耗时(毫秒)和失败原因
+func (s *OesLinkService) dial(ctx context.Context, m *oesmodel.OesLinkModel) (string, float64, string) {
+	timeout := time.Duration(m.TimeoutMs) * time.Millisecond
+	if m.SourceHost == nil {
+		dialer := net.Dialer{Timeout: timeout}
+		start := time.Now()
+		conn, err := dialer.DialContext(ctx, "tcp", m.Target())
+		if err != nil {
+			return oesmodel.LinkUnreachable, 0, truncateLinkMessage(err.Error())
+		}
+		latency := time.Since(start)
+		conn.Close()
+		return oesmodel.LinkReachable, roundLatency(float64(latency.Microseconds())), ""
+	}
+
+	probeCtx, cancel := context.WithTimeout(ctx, timeout+linkProbeSSHMargin)
+	defer cancel()
+	code, output, rErr := s.ucHost.RunCommand(probeCtx, *m.SourceHost, m.ProbeCommand())
+	if rErr != nil {
+		return oesmodel.LinkUnknown, 0, truncateLinkMessage("无法在源主机上探测: " + rErr.Error())
+	}
+	return parseProbeOutput(code, output)
+}
+
+// notify 发出链路不可达告警, 处于维护窗口内时屏蔽告警并记录审计
+func (s *OesLinkService) notify(
+	ctx context.Context,
+	m *oesmodel.OesLinkModel,
+	message string,
+	preOpen bool,
+	now time.Time,
+) {
+	if !s.alerts.Publish(ctx, syssvc.Alert{
+		Kind:     oesmodel.AlertKindLinkUnreachable,
+		Status:   oesmodel.LinkUnreachable,
+		Scope:    syssvc.AlertScopeColony,
+		Module:   "oes",
+		TargetID: m.OesColonyID,
+		At:       now,
+		Event: oesmodel.LinkUnreachableEvent{
+			Kind:       oesmodel.AlertKindLinkUnreachable,
+			Project:    "oes",
+			ID:         m.OesColonyID,
+			LinkID:     m.ID,
+			Name:       m.Name,
+			Source:     m.Source(),
+			TargetName: m.TargetName,
+			Target:     m.Target(),
+			Error:      message,
+			PreOpen:    preOpen,
+			CheckedAt:  now.Format(time.DateTime),
+		},
+		Detail: map[string]any{
+			"oes_colony_id": m.OesColonyID,
+			"link_id":       m.ID,
+			"target":        m.Target(),
+			"checked_at":    now.Format(time.DateTime),
+		},
+	}) {
+		return
+	}
+	ctxutil.Logger(ctx, s.log).Warn(
+		"oes链路不可达",
+		zap.Uint32("link_id", m.ID),
+		zap.String("source", m.Source()),
+		zap.String("target", m.Target()),
+		zap.String("error", message),
+		zap.Bool("pre_open", preOpen),
+	)
+}
+
+// Cleanup 删除超过保留天数的探测记录
+func (s *OesLinkService) Cleanup(ctx context.Context) *errors.Error {
+	if ctx.Err() != nil {
+		return errors.FromError(ctx.Err())
+	}
+
+	if _, err := s.probeRepo.DeleteBefore(ctx, time.Now().Add(-s.retention)); err != nil {
+		return errors.NewGormError(err, nil)
+	}
+	return nil
+}
+
+// parseProbeOutput 解析在源主机上执行探测命令的结果
+//
+// 退出码124表示连接超时, 127表示源主机缺少bash或timeout命令, 其余非0退出码表示连接失败
+func parseProbeOutput(code int, output string) (string, float64, string) {
+	output = strings.TrimSpace(output)
+	switch code {
+	case 0:
+		lines := strings.Split(output, "\n")
+		micros, err := strconv.ParseFloat(strings.TrimSpace(lines[len(lines)-1]), 64)
+		if err != nil || micros < 0 {
+			return oesmodel.LinkReachable, 0, ""
+		}
+		return oesmodel.LinkReachable, roundLatency(micros), ""
+	case 124:
+		return oesmodel.LinkUnreachable, 0, "连接超时"
+	case 127:
+		return oesmodel.LinkUnknown, 0, truncateLinkMessage("源主机缺少探测命令: " + output)
+	default:
+		if output == "" {
+			output = "连接失败, 退出码" + strconv.Itoa(code)
+		}
+		return oesmodel.LinkUnreachable, 0, truncateLinkMessage(output)
+	}
+}
+
+// roundLatency 将微秒转换为毫秒并保留两位小数
+func roundLatency(micros float64) float64 {
+	return math.Round(micros/10) / 100
+}
+
+func truncateLinkMessage(s string) string {
+	r := []rune(s)
+	if len(r) > 1024 {
+		return string(r[:1024])
+	}
+	return s
+}
+
+func equalHostID(a, b *uint32) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,52 @@
+package biz
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	oesmodel "gin-artweb/internal/model/oes"
+)
+
+type OesLinkTestSuite struct {
+	suite.Suite
+}
+
+func (suite *OesLinkTestSuite) TestParseProbeOutput() {
+	status, latency, message := parseProbeOutput(0, "1234\n")
+	suite.Equal(oesmodel.LinkReachable, status)
+	suite.Equal(1.23, latency, "耗时从微秒转换为毫秒并保留两位小数")
+	suite.Empty(message)
+
+	status, latency, _ = parseProbeOutput(0, "")
+	suite.Equal(oesmodel.LinkReachable, status, "连接成功但没有输出耗时时仍然可达")
+	suite.Zero(latency)
+
+	status, _, message = parseProbeOutput(124, "")
+	suite.Equal(oesmodel.LinkUnreachable, status)
+	suite.Equal("连接超时", message)
+
+	status, _, message = parseProbeOutput(1, "bash: connect: Connection refused\n")
+	suite.Equal(oesmodel.LinkUnreachable, status)
+	suite.Equal("bash: connect: Connection refused", message)
+
+	status, _, message = parseProbeOutput(1, "")
+	suite.Equal(oesmodel.LinkUnreachable, status)
+	suite.Equal("连接失败, 退出码1", message)
+
+	status, _, _ = parseProbeOutput(127, "timeout: command not found")
+	suite.Equal(oesmodel.LinkUnknown, status, "源主机缺少命令时无法判断链路状态")
+}
+
+func (suite *OesLinkTestSuite) TestEqualHostID() {
+	a, b := uint32(1), uint32(1)
+	c := uint32(2)
+	suite.True(equalHostID(nil, nil))
+	suite.True(equalHostID(&a, &b))
+	suite.False(equalHostID(&a, &c))
+	suite.False(equalHostID(&a, nil))
+}
+
+func TestOesLinkTestSuite(t *testing.T) {
+	suite.Run(t, new(OesLinkTestSuite))
+}
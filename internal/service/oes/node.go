@@ -38,18 +38,16 @@ func (s *OesNodeService) CreateOesNode(
 		return nil, errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始创建oes节点",
 		zap.Object(database.ModelKey, &m),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	if err := s.nodeRepo.CreateModel(ctx, &m); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"创建oes节点失败",
 			zap.Error(err),
 			zap.Object(database.ModelKey, &m),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.NewGormError(err, nil)
 	}
@@ -65,10 +63,9 @@ func (s *OesNodeService) CreateOesNode(
 		return nil, err
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"创建oes节点成功",
 		zap.Object(database.ModelKey, nm),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return nm, nil
 }
@@ -82,21 +79,19 @@ func (s *OesNodeService) UpdateOesNodeByID(
 		return nil, errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始更新oes节点",
 		zap.Uint32("oes_node_id", oesNodeID),
 		zap.Any(database.UpdateDataKey, data),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	data["id"] = oesNodeID
 	if err := s.nodeRepo.UpdateModel(ctx, data, "id = ?", oesNodeID); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"更新oes节点失败",
 			zap.Error(err),
 			zap.Uint32("oes_node_id", oesNodeID),
 			zap.Any(database.UpdateDataKey, data),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.NewGormError(err, data)
 	}
@@ -112,10 +107,9 @@ func (s *OesNodeService) UpdateOesNodeByID(
 		return nil, err
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"更新oes节点成功",
 		zap.Uint32("oes_node_id", oesNodeID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return m, nil
 }
@@ -128,26 +122,23 @@ func (s *OesNodeService) DeleteOesNodeByID(
 		return errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始删除oes节点",
 		zap.Uint32("oes_node_id", oesNodeID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	if err := s.nodeRepo.DeleteModel(ctx, oesNodeID); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"删除oes节点失败",
 			zap.Error(err),
 			zap.Uint32("oes_node_id", oesNodeID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return errors.NewGormError(err, map[string]any{"id": oesNodeID})
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"删除oes节点成功",
 		zap.Uint32("oes_node_id", oesNodeID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return nil
 }
@@ -161,28 +152,25 @@ func (s *OesNodeService) FindOesNodeByID(
 		return nil, errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始查询oes节点",
 		zap.Strings(database.PreloadKey, preloads),
 		zap.Uint32("oes_node_id", oesNodeID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	m, err := s.nodeRepo.GetModel(ctx, preloads, oesNodeID)
 	if err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"查询oes节点失败",
 			zap.Error(err),
 			zap.Uint32("oes_node_id", oesNodeID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.NewGormError(err, map[string]any{"id": oesNodeID})
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"查询oes节点成功",
 		zap.Uint32("oes_node_id", oesNodeID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return m, nil
 }
@@ -195,27 +183,24 @@ func (s *OesNodeService) ListOesNode(
 		return 0, nil, errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始查询oes节点列表",
 		zap.Object(database.QueryParamsKey, &qp),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	count, ms, err := s.nodeRepo.ListModel(ctx, qp)
 	if err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"查询oes节点列表失败",
 			zap.Error(err),
 			zap.Object(database.QueryParamsKey, &qp),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return 0, nil, errors.NewGormError(err, nil)
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"查询oes节点列表成功",
 		zap.Object(database.QueryParamsKey, &qp),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return count, ms, nil
 }
@@ -225,20 +210,11 @@ func (s *OesNodeService) OutPortOesNodeData(ctx context.Context, m *oesmodel.Oes
 		return errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始导出oes节点变量文件",
 		zap.Object(database.ModelKey, m),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
-	var specdir string
-	switch m.NodeRole {
-	case "master":
-		specdir = "host_01"
-	case "follow":
-		specdir = "host_02"
-	default:
-		specdir = "host_03"
-	}
+	specdir := oesmodel.NodeSpecdir(m.NodeRole)
 	oesVars := oesmodel.OesNodeVars{
 		ID:       m.ID,
 		NodeRole: m.NodeRole,
@@ -249,23 +225,21 @@ func (s *OesNodeService) OutPortOesNodeData(ctx context.Context, m *oesmodel.Oes
 	confDir := common.GetOesColonyConfigDir(m.OesColony.ColonyNum)
 	oesColonyConf := filepath.Join(confDir, specdir, "node.yaml")
 	if _, err := serializer.WriteYAML(oesColonyConf, oesVars); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"导出oes节点变量文件失败",
 			zap.Error(err),
 			zap.Uint32("oes_node_id", m.ID),
 			zap.String("colony_num", m.OesColony.ColonyNum),
 			zap.String("path", oesColonyConf),
 			zap.Object("oes_node_vars", &oesVars),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return errors.ErrExportCacheFileFailed.WithCause(err)
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"导出oes节点变量文件失败",
 		zap.String("path", oesColonyConf),
 		zap.Object("oes_colony_vars", &oesVars),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return nil
 }
@@ -0,0 +1,465 @@
+package biz
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	oesmodel "gin-artweb/internal/model/oes"
+	oesrepo "gin-artweb/internal/repository/oes"
+	jobsvc "gin-artweb/internal/service/jobs"
+	resosvc "gin-artweb/internal/service/resource"
+	"gin-artweb/internal/shared/common"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+)
+
+const (
+	// reconcileMaxFileSize 对账时读取的单个柜台数据文件的最大字节数
+	reconcileMaxFileSize = 128 * 1024 * 1024
+	// reconcileSampleKeys 说明中列出的差异记录主键数
+	reconcileSampleKeys = 3
+	// defaultReconcileRemoteDir 未配置时节点上柜台数据文件的下发目录
+	defaultReconcileRemoteDir = "/home/{{ .Node.SSHUser }}/{{ .Colony.ExtractedName }}/data/broker"
+)
+
+// OesReconcileService oes柜台数据对账服务
+//
+// 以counter_fetch.sh从柜台获取到本地的数据文件为准, 逐个比较counter_distribute.sh下发到集群各节点的同名文件,
+// 按每行第一列作为记录主键统计节点缺少、多余和内容不一致的记录数, 结果按集群和交易日保存
+type OesReconcileService struct {
+	log        *zap.Logger
+	reportRepo *oesrepo.OesReconcileReportRepo
+	colonyRepo *oesrepo.OesColonyRepo
+	ucTemplate *OesConfTemplateService
+	ucFile     *resosvc.HostFileService
+	calendar   *jobsvc.CalendarService
+	remoteDir  string
+}
+
+func NewOesReconcileService(
+	log *zap.Logger,
+	reportRepo *oesrepo.OesReconcileReportRepo,
+	colonyRepo *oesrepo.OesColonyRepo,
+	ucTemplate *OesConfTemplateService,
+	ucFile *resosvc.HostFileService,
+	calendar *jobsvc.CalendarService,
+	conf *config.ReconcileConfig,
+) *OesReconcileService {
+	s := &OesReconcileService{
+		log:        log,
+		reportRepo: reportRepo,
+		colonyRepo: colonyRepo,
+		ucTemplate: ucTemplate,
+		ucFile:     ucFile,
+		calendar:   calendar,
+		remoteDir:  defaultReconcileRemoteDir,
+	}
+	if conf != nil && conf.RemoteDir != "" {
+		s.remoteDir = conf.RemoteDir
+	}
+	return s
+}
+
+// ListReconcileReport 分页查询集群的对账报告
+func (s *OesReconcileService) ListReconcileReport(
+	ctx context.Context,
+	colonyId uint32,
+	qp database.QueryParams,
+) (int64, *[]oesmodel.OesReconcileReportModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return 0, nil, errors.FromError(ctx.Err())
+	}
+
+	if _, err := s.colonyRepo.GetModel(ctx, nil, colonyId); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询oes集群失败",
+			zap.Error(err),
+			zap.Uint32("oes_colony_id", colonyId),
+		)
+		return 0, nil, errors.NewGormError(err, map[string]any{"id": colonyId})
+	}
+	total, ms, err := s.reportRepo.ListModel(ctx, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询oes柜台数据对账报告列表失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		return 0, nil, errors.NewGormError(err, nil)
+	}
+	return total, ms, nil
+}
+
+// FindReconcileReport 查询集群指定交易日的对账报告及每个文件的对账结果
+func (s *OesReconcileService) FindReconcileReport(
+	ctx context.Context,
+	colonyId uint32,
+	tradingDay string,
+) (*oesmodel.OesReconcileReportModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	m, err := s.reportRepo.GetModel(ctx, []string{"Files"},
+		"oes_colony_id = ? AND trading_day = ?", colonyId, tradingDay)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询oes柜台数据对账报告失败",
+			zap.Error(err),
+			zap.Uint32("oes_colony_id", colonyId),
+			zap.String("trading_day", tradingDay),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"oes_colony_id": colonyId, "trading_day": tradingDay})
+	}
+	return m, nil
+}
+
+// ReconcileAllColonies 对账全部启用集群当天的柜台数据, 非交易日跳过, 单个集群对账失败不影响其他集群
+func (s *OesReconcileService) ReconcileAllColonies(ctx context.Context) *errors.Error {
+	if ctx.Err() != nil {
+		return errors.FromError(ctx.Err())
+	}
+
+	now := time.Now()
+	if s.calendar != nil {
+		day, rErr := s.calendar.CheckTradingDay(ctx, now)
+		if rErr != nil {
+			ctxutil.Logger(ctx, s.log).Error(
+				"查询交易日历失败, 柜台数据照常对账",
+				zap.Error(rErr),
+			)
+		} else if !day.IsTradingDay {
+			ctxutil.Logger(ctx, s.log).Info(
+				"非交易日, 跳过柜台数据对账",
+				zap.String("date", day.Date),
+			)
+			return nil
+		}
+	}
+
+	_, colonies, err := s.colonyRepo.ListModel(ctx, database.QueryParams{
+		OrderBy: []string{"id ASC"},
+		Query:   map[string]any{"is_enable = ?": true},
+	})
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询oes集群列表失败",
+			zap.Error(err),
+		)
+		return errors.NewGormError(err, nil)
+	}
+	for _, colony := range *colonies {
+		if _, rErr := s.ReconcileColony(ctx, colony.ID, now.Format(time.DateOnly), ""); rErr != nil {
+			ctxutil.Logger(ctx, s.log).Error(
+				"oes柜台数据对账失败",
+				zap.Error(rErr),
+				zap.Uint32("oes_colony_id", colony.ID),
+			)
+		}
+	}
+	return nil
+}
+
+// ReconcileColony 对账集群指定交易日的柜台数据并保存报告, 替换该交易日已有的报告
+func (s *OesReconcileService) ReconcileColony(
+	ctx context.Context,
+	colonyId uint32,
+	tradingDay string,
+	username string,
+) (*oesmodel.OesReconcileReportModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	day, err := time.ParseInLocation(time.DateOnly, tradingDay, time.Local)
+	if err != nil {
+		return nil, errors.ErrValidationFailed.WithField("trading_day", tradingDay).WithCause(err)
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始oes柜台数据对账",
+		zap.Uint32("oes_colony_id", colonyId),
+		zap.String("trading_day", tradingDay),
+	)
+
+	colony, nodes, rErr := s.ucTemplate.loadColony(ctx, colonyId)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	report := &oesmodel.OesReconcileReportModel{
+		OesColonyID: colony.ID,
+		ColonyNum:   colony.ColonyNum,
+		TradingDay:  tradingDay,
+		NodeCount:   len(nodes),
+		Username:    username,
+	}
+	if message := s.reconcile(ctx, report, colony, nodes, day); message != "" {
+		report.Status = oesmodel.ReconcileStatusFailed
+		report.Message = truncateMessage(message)
+	} else {
+		report.Status = summarizeReconcile(report)
+	}
+
+	if err := s.reportRepo.ReplaceColonyDay(ctx, report); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"保存oes柜台数据对账报告失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, report),
+		)
+		return nil, errors.NewGormError(err, nil)
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"oes柜台数据对账完成",
+		zap.Object(database.ModelKey, report),
+	)
+	return report, nil
+}
+
+// reconcile 逐个节点比较柜台数据文件, 将结果写入报告, 无法对账时返回原因
+func (s *OesReconcileService) reconcile(
+	ctx context.Context,
+	report *oesmodel.OesReconcileReportModel,
+	colony *oesmodel.OesColonyModel,
+	nodes []oesmodel.OesNodeModel,
+	day time.Time,
+) string {
+	localDir := common.GetOesColonyCounterDir(colony.ColonyNum)
+	names, err := listCounterFiles(localDir, day)
+	if err != nil {
+		return fmt.Sprintf("读取柜台数据目录失败: %s", err)
+	}
+	report.FileCount = len(names)
+	if len(names) == 0 {
+		return fmt.Sprintf("未找到交易日的柜台数据文件, 请检查counter_fetch.sh是否执行成功: %s", localDir)
+	}
+	if len(nodes) == 0 {
+		return "集群没有启用的节点"
+	}
+
+	tmpl := &oesmodel.OesConfTemplateModel{
+		Name:       "counter",
+		DirName:    oesmodel.ConfTemplateDirAll,
+		RemotePath: s.remoteDir,
+	}
+	targets, rErr := renderNodes(tmpl, colony, nodes, nil)
+	if rErr != nil {
+		return fmt.Sprintf("渲染节点下发目录失败: %s", rErr.Error())
+	}
+
+	for _, name := range names {
+		data, err := os.ReadFile(filepath.Join(localDir, name))
+		if err != nil {
+			report.Files = append(report.Files, oesmodel.OesReconcileFileModel{
+				FileName: name,
+				Status:   oesmodel.ReconcileStatusFailed,
+				Message:  truncateMessage(fmt.Sprintf("读取柜台数据文件失败: %s", err)),
+			})
+			continue
+		}
+		fetched, err := parseCounterRecords(data)
+		if err != nil {
+			report.Files = append(report.Files, oesmodel.OesReconcileFileModel{
+				FileName: name,
+				Status:   oesmodel.ReconcileStatusFailed,
+				Message:  truncateMessage(fmt.Sprintf("解析柜台数据文件失败: %s", err)),
+			})
+			continue
+		}
+		for _, t := range targets {
+			f := s.reconcileFile(ctx, t.node, path.Join(t.remotePath, name), fetched)
+			f.FileName = name
+			report.Files = append(report.Files, f)
+		}
+	}
+	return ""
+}
+
+// reconcileFile 读取节点上下发的数据文件并与柜台数据比较
+func (s *OesReconcileService) reconcileFile(
+	ctx context.Context,
+	node oesmodel.OesNodeModel,
+	remotePath string,
+	fetched counterRecords,
+) oesmodel.OesReconcileFileModel {
+	f := oesmodel.OesReconcileFileModel{
+		NodeID:     node.ID,
+		HostID:     node.HostID,
+		RemotePath: remotePath,
+		FetchRows:  int64(len(fetched)),
+	}
+	data, exists, rErr := s.ucFile.ReadFile(ctx, node.HostID, remotePath, reconcileMaxFileSize)
+	if rErr != nil {
+		f.Status = oesmodel.ReconcileStatusFailed
+		f.Message = truncateMessage(rErr.Error())
+		return f
+	}
+	if !exists {
+		f.Status = oesmodel.ReconcileStatusMismatched
+		f.Missing = f.FetchRows
+		f.Message = "节点上不存在该文件"
+		return f
+	}
+	distributed, err := parseCounterRecords(data)
+	if err != nil {
+		f.Status = oesmodel.ReconcileStatusFailed
+		f.Message = truncateMessage(fmt.Sprintf("解析下发数据文件失败: %s", err))
+		return f
+	}
+
+	diff := diffCounterRecords(fetched, distributed)
+	f.DistributeRows = int64(len(distributed))
+	f.Missing = int64(len(diff.missing))
+	f.Extra = int64(len(diff.extra))
+	f.Mismatched = int64(len(diff.mismatched))
+	f.Status = oesmodel.ReconcileStatusMatched
+	if f.Missing+f.Extra+f.Mismatched > 0 {
+		f.Status = oesmodel.ReconcileStatusMismatched
+		f.Message = truncateMessage(diff.String())
+	}
+	return f
+}
+
+// summarizeReconcile 汇总各文件的差异记录数并返回报告的对账状态
+func summarizeReconcile(report *oesmodel.OesReconcileReportModel) string {
+	status := oesmodel.ReconcileStatusMatched
+	failed := 0
+	for _, f := range report.Files {
+		report.Missing += f.Missing
+		report.Extra += f.Extra
+		report.Mismatched += f.Mismatched
+		switch f.Status {
+		case oesmodel.ReconcileStatusFailed:
+			failed++
+		case oesmodel.ReconcileStatusMismatched:
+			if status == oesmodel.ReconcileStatusMatched {
+				status = oesmodel.ReconcileStatusMismatched
+			}
+		}
+	}
+	if failed > 0 {
+		report.Message = fmt.Sprintf("%d个文件对账失败", failed)
+		return oesmodel.ReconcileStatusFailed
+	}
+	return status
+}
+
+// listCounterFiles 列出本地柜台数据目录中交易日的数据文件, 文件名以MMDD.csv结尾
+func listCounterFiles(dir string, day time.Time) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	suffix := day.Format("0102") + ".csv"
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), suffix) {
+			continue
+		}
+		names = append(names, e.Name())
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// counterRecords 柜台数据文件中的记录, 键为记录主键, 值为整行内容
+type counterRecords map[string]string
+
+// parseCounterRecords 解析csv格式的柜台数据文件
+//
+// 以每行第一列作为记录主键, 同一主键出现多次时按出现顺序追加序号区分
+func parseCounterRecords(data []byte) (counterRecords, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+	r.ReuseRecord = true
+
+	records := make(counterRecords)
+	seen := make(map[string]int)
+	for {
+		fields, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		key := strings.TrimSpace(fields[0])
+		seen[key]++
+		if n := seen[key]; n > 1 {
+			key = fmt.Sprintf("%s#%d", key, n)
+		}
+		records[key] = strings.Join(fields, ",")
+	}
+	return records, nil
+}
+
+// counterDiff 下发数据与柜台数据的差异记录主键
+type counterDiff struct {
+	missing    []string
+	extra      []string
+	mismatched []string
+}
+
+// diffCounterRecords 以柜台数据为准比较下发数据
+func diffCounterRecords(fetched, distributed counterRecords) counterDiff {
+	var diff counterDiff
+	for key, row := range fetched {
+		other, ok := distributed[key]
+		switch {
+		case !ok:
+			diff.missing = append(diff.missing, key)
+		case other != row:
+			diff.mismatched = append(diff.mismatched, key)
+		}
+	}
+	for key := range distributed {
+		if _, ok := fetched[key]; !ok {
+			diff.extra = append(diff.extra, key)
+		}
+	}
+	sort.Strings(diff.missing)
+	sort.Strings(diff.extra)
+	sort.Strings(diff.mismatched)
+	return diff
+}
+
+// String 返回差异记录主键的示例, 每类最多列出reconcileSampleKeys个
+func (d counterDiff) String() string {
+	var parts []string
+	for _, item := range []struct {
+		label string
+		keys  []string
+	}{
+		{"缺少", d.missing},
+		{"多余", d.extra},
+		{"不一致", d.mismatched},
+	} {
+		if len(item.keys) == 0 {
+			continue
+		}
+		keys := item.keys
+		if len(keys) > reconcileSampleKeys {
+			keys = append(keys[:reconcileSampleKeys:reconcileSampleKeys], "...")
+		}
+		parts = append(parts, fmt.Sprintf("%s: %s", item.label, strings.Join(keys, ", ")))
+	}
+	return strings.Join(parts, "; ")
+}
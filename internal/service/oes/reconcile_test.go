@@ -0,0 +1,50 @@
+package biz
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ReconcileTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ReconcileTestSuite) TestParseCounterRecords() {
+	records, err := parseCounterRecords([]byte("id,name\n1,a\n2,\"b,c\"\n2,d\n\n"))
+	suite.Require().NoError(err)
+	suite.Equal(counterRecords{
+		"id":  "id,name",
+		"1":   "1,a",
+		"2":   "2,b,c",
+		"2#2": "2,d",
+	}, records, "重复主键应该按出现顺序追加序号")
+}
+
+func (suite *ReconcileTestSuite) TestDiffCounterRecords() {
+	fetched, err := parseCounterRecords([]byte("1,a\n2,b\n3,c\n4,d\n"))
+	suite.Require().NoError(err)
+	distributed, err := parseCounterRecords([]byte("1,a\n2,x\n4,d\n5,e\n"))
+	suite.Require().NoError(err)
+
+	diff := diffCounterRecords(fetched, distributed)
+	suite.Equal([]string{"3"}, diff.missing)
+	suite.Equal([]string{"5"}, diff.extra)
+	suite.Equal([]string{"2"}, diff.mismatched)
+	suite.Equal("缺少: 3; 多余: 5; 不一致: 2", diff.String())
+
+	suite.Empty(diffCounterRecords(fetched, fetched).String(), "相同的数据没有差异")
+}
+
+func (suite *ReconcileTestSuite) TestDiffSampleKeys() {
+	fetched, err := parseCounterRecords([]byte("1\n2\n3\n4\n5\n"))
+	suite.Require().NoError(err)
+
+	diff := diffCounterRecords(fetched, counterRecords{})
+	suite.Len(diff.missing, 5)
+	suite.Equal("缺少: 1, 2, 3, ...", diff.String(), "说明中最多列出3个主键")
+}
+
+func TestReconcileTestSuite(t *testing.T) {
+	suite.Run(t, new(ReconcileTestSuite))
+}
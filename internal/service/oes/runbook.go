@@ -0,0 +1,701 @@
+package biz
+
+import (
+	"context"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	jobsmodel "gin-artweb/internal/model/jobs"
+	oesmodel "gin-artweb/internal/model/oes"
+	oesrepo "gin-artweb/internal/repository/oes"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+)
+
+// 检查单脚本步骤执行记录的触发类型
+const runbookTriggerType = "runbook"
+
+// OesRunbookService oes运维检查单服务
+//
+// 检查单的步骤按顺序执行: 人工步骤等待操作员确认, 脚本步骤以集群号为参数执行内置脚本,
+// 脚本执行成功后自动进入下一步, 失败时阻塞执行直到操作员重试或终止.
+// 执行状态全部保存在数据库中, 人工步骤可以跨越服务重启
+type OesRunbookService struct {
+	log         *zap.Logger
+	runbookRepo *oesrepo.OesRunbookRepo
+	execRepo    *oesrepo.OesRunbookExecRepo
+	colonyRepo  *oesrepo.OesColonyRepo
+	ucRecord    *JobsService
+	// mu 串行化检查单执行的状态变更
+	mu sync.Mutex
+}
+
+func NewOesRunbookService(
+	log *zap.Logger,
+	runbookRepo *oesrepo.OesRunbookRepo,
+	execRepo *oesrepo.OesRunbookExecRepo,
+	colonyRepo *oesrepo.OesColonyRepo,
+	ucRecord *JobsService,
+) *OesRunbookService {
+	return &OesRunbookService{
+		log:         log,
+		runbookRepo: runbookRepo,
+		execRepo:    execRepo,
+		colonyRepo:  colonyRepo,
+		ucRecord:    ucRecord,
+	}
+}
+
+// resolveScripts 查询检查单中脚本步骤引用的内置脚本, 脚本不存在时返回错误
+func (s *OesRunbookService) resolveScripts(
+	ctx context.Context,
+	steps []oesmodel.OesRunbookStepModel,
+) (map[string]jobsmodel.ScriptModel, *errors.Error) {
+	var names []string
+	for _, step := range steps {
+		if step.Kind == oesmodel.RunbookStepScript {
+			names = append(names, step.Script)
+		}
+	}
+	if len(names) == 0 {
+		return map[string]jobsmodel.ScriptModel{}, nil
+	}
+	scripts, rErr := s.ucRecord.FindCmdScripts(ctx, names)
+	if rErr != nil {
+		return nil, rErr
+	}
+	for _, name := range names {
+		if _, ok := scripts[name]; !ok {
+			return nil, errors.ErrScriptNotFound.WithField("script", name)
+		}
+	}
+	return scripts, nil
+}
+
+func (s *OesRunbookService) CreateRunbook(
+	ctx context.Context,
+	m oesmodel.OesRunbookModel,
+) (*oesmodel.OesRunbookModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	if _, rErr := s.resolveScripts(ctx, m.Steps); rErr != nil {
+		return nil, rErr
+	}
+	if err := s.runbookRepo.CreateModel(ctx, &m); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"创建oes检查单失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, &m),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"name": m.Name})
+	}
+	return &m, nil
+}
+
+// UpdateRunbookByID 更新检查单并替换全部步骤, 不影响进行中的执行
+func (s *OesRunbookService) UpdateRunbookByID(
+	ctx context.Context,
+	m oesmodel.OesRunbookModel,
+) (*oesmodel.OesRunbookModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	if _, rErr := s.resolveScripts(ctx, m.Steps); rErr != nil {
+		return nil, rErr
+	}
+	data := map[string]any{
+		"name":        m.Name,
+		"system_type": m.SystemType,
+		"is_enabled":  m.IsEnabled,
+		"description": m.Description,
+	}
+	if err := s.runbookRepo.UpdateModelWithSteps(ctx, m.ID, data, m.Steps); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"更新oes检查单失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, &m),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"id": m.ID, "name": m.Name})
+	}
+	return s.FindRunbookByID(ctx, m.ID)
+}
+
+func (s *OesRunbookService) DeleteRunbookByID(
+	ctx context.Context,
+	runbookID uint32,
+) *errors.Error {
+	if ctx.Err() != nil {
+		return errors.FromError(ctx.Err())
+	}
+
+	if _, rErr := s.FindRunbookByID(ctx, runbookID); rErr != nil {
+		return rErr
+	}
+	if err := s.runbookRepo.DeleteModel(ctx, runbookID); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"删除oes检查单失败",
+			zap.Error(err),
+			zap.Uint32("runbook_id", runbookID),
+		)
+		return errors.NewGormError(err, map[string]any{"id": runbookID})
+	}
+	return nil
+}
+
+func (s *OesRunbookService) FindRunbookByID(
+	ctx context.Context,
+	runbookID uint32,
+) (*oesmodel.OesRunbookModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	m, err := s.runbookRepo.GetModel(ctx, []string{"Steps"}, runbookID)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询oes检查单失败",
+			zap.Error(err),
+			zap.Uint32("runbook_id", runbookID),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"id": runbookID})
+	}
+	return m, nil
+}
+
+func (s *OesRunbookService) ListRunbook(
+	ctx context.Context,
+	qp database.QueryParams,
+) (int64, *[]oesmodel.OesRunbookModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return 0, nil, errors.FromError(ctx.Err())
+	}
+
+	count, ms, err := s.runbookRepo.ListModel(ctx, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询oes检查单列表失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		return 0, nil, errors.NewGormError(err, nil)
+	}
+	return count, ms, nil
+}
+
+// StartRunbook 为集群发起检查单, 同一集群同一检查单同时只允许一个未结束的执行
+func (s *OesRunbookService) StartRunbook(
+	ctx context.Context,
+	oesColonyID uint32,
+	runbookID uint32,
+	username string,
+) (*oesmodel.OesRunbookExecModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始发起oes检查单",
+		zap.Uint32("oes_colony_id", oesColonyID),
+		zap.Uint32("runbook_id", runbookID),
+	)
+
+	colony, err := s.colonyRepo.GetModel(ctx, nil, oesColonyID)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询oes集群失败",
+			zap.Error(err),
+			zap.Uint32("oes_colony_id", oesColonyID),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"id": oesColonyID})
+	}
+	runbook, rErr := s.FindRunbookByID(ctx, runbookID)
+	if rErr != nil {
+		return nil, rErr
+	}
+	if !runbook.IsEnabled {
+		return nil, errors.ErrValidationFailed.WithFields(map[string]any{
+			"runbook_id": runbookID,
+			"is_enabled": false,
+		})
+	}
+	if runbook.SystemType != "" && runbook.SystemType != colony.SystemType {
+		return nil, errors.ErrValidationFailed.WithFields(map[string]any{
+			"runbook_id":  runbookID,
+			"system_type": runbook.SystemType,
+		})
+	}
+	steps := runbook.SortedSteps()
+	scripts, rErr := s.resolveScripts(ctx, steps)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	exec := &oesmodel.OesRunbookExecModel{
+		RunbookID:   runbook.ID,
+		RunbookName: runbook.Name,
+		OesColonyID: colony.ID,
+		ColonyNum:   colony.ColonyNum,
+		Status:      oesmodel.RunbookExecRunning,
+		Username:    username,
+	}
+	for i, step := range steps {
+		timeout := step.Timeout
+		if timeout <= 0 {
+			timeout = workflowStepDefaultTimeout
+		}
+		exec.Steps = append(exec.Steps, oesmodel.OesRunbookExecStepModel{
+			Sort:        i,
+			Name:        step.Name,
+			Kind:        step.Kind,
+			ScriptID:    scripts[step.Script].ID,
+			Timeout:     timeout,
+			Instruction: step.Instruction,
+			Status:      oesmodel.RunbookStepPending,
+		})
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	total, _, err := s.execRepo.ListModel(ctx, database.QueryParams{
+		IsCount: true,
+		Size:    1,
+		Query: map[string]any{
+			"oes_colony_id = ?": colony.ID,
+			"runbook_id = ?":    runbook.ID,
+			"status IN ?":       []string{oesmodel.RunbookExecRunning, oesmodel.RunbookExecBlocked},
+		},
+	})
+	if err != nil {
+		return nil, errors.NewGormError(err, nil)
+	}
+	if total > 0 {
+		return nil, errors.ErrRunbookRunning.WithFields(map[string]any{
+			"oes_colony_id": colony.ID,
+			"runbook_id":    runbook.ID,
+		})
+	}
+
+	if err := s.execRepo.CreateModel(ctx, exec); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"创建oes检查单执行失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, exec),
+		)
+		return nil, errors.NewGormError(err, nil)
+	}
+	if rErr := s.advance(ctx, exec.ID, username); rErr != nil {
+		return nil, rErr
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"发起oes检查单成功",
+		zap.Uint32("oes_colony_id", colony.ID),
+		zap.Uint32("runbook_exec_id", exec.ID),
+	)
+	return s.findExec(ctx, exec.ID)
+}
+
+// ConfirmStep 操作员确认完成当前等待确认的人工步骤
+func (s *OesRunbookService) ConfirmStep(
+	ctx context.Context,
+	execID uint32,
+	stepID uint32,
+	username string,
+	remark string,
+) (*oesmodel.OesRunbookExecModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	exec, step, rErr := s.currentStep(ctx, execID, stepID)
+	if rErr != nil {
+		return nil, rErr
+	}
+	if exec.Status != oesmodel.RunbookExecRunning || step.Kind != oesmodel.RunbookStepManual ||
+		step.Status != oesmodel.RunbookStepWaiting {
+		return nil, errors.ErrRunbookStepState.WithFields(map[string]any{
+			"step_id": stepID,
+			"kind":    step.Kind,
+			"status":  step.Status,
+		})
+	}
+
+	if rErr := s.updateStep(ctx, step.ID, map[string]any{
+		"status":      oesmodel.RunbookStepSuccess,
+		"operator":    username,
+		"remark":      remark,
+		"finished_at": time.Now(),
+	}); rErr != nil {
+		return nil, rErr
+	}
+	ctxutil.Logger(ctx, s.log).Info(
+		"oes检查单人工步骤已确认",
+		zap.Uint32("runbook_exec_id", execID),
+		zap.String("step", step.Name),
+		zap.String("operator", username),
+	)
+	if rErr := s.advance(ctx, execID, username); rErr != nil {
+		return nil, rErr
+	}
+	return s.findExec(ctx, execID)
+}
+
+// RetryStep 操作员重新执行失败的脚本步骤
+func (s *OesRunbookService) RetryStep(
+	ctx context.Context,
+	execID uint32,
+	stepID uint32,
+	username string,
+	remark string,
+) (*oesmodel.OesRunbookExecModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	exec, step, rErr := s.currentStep(ctx, execID, stepID)
+	if rErr != nil {
+		return nil, rErr
+	}
+	if exec.Status != oesmodel.RunbookExecBlocked || step.Status != oesmodel.RunbookStepFailed {
+		return nil, errors.ErrRunbookStepState.WithFields(map[string]any{
+			"step_id": stepID,
+			"kind":    step.Kind,
+			"status":  step.Status,
+		})
+	}
+
+	if rErr := s.updateStep(ctx, step.ID, map[string]any{
+		"status":        oesmodel.RunbookStepPending,
+		"error_message": "",
+		"remark":        remark,
+		"finished_at":   nil,
+	}); rErr != nil {
+		return nil, rErr
+	}
+	if rErr := s.updateExec(ctx, execID, map[string]any{"status": oesmodel.RunbookExecRunning}); rErr != nil {
+		return nil, rErr
+	}
+	if rErr := s.advance(ctx, execID, username); rErr != nil {
+		return nil, rErr
+	}
+	return s.findExec(ctx, execID)
+}
+
+// AbortExec 终止检查单执行, 未完成的步骤标记为跳过, 脚本执行中时不能终止
+func (s *OesRunbookService) AbortExec(
+	ctx context.Context,
+	execID uint32,
+	username string,
+) (*oesmodel.OesRunbookExecModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	exec, rErr := s.findExec(ctx, execID)
+	if rErr != nil {
+		return nil, rErr
+	}
+	if exec.Status != oesmodel.RunbookExecRunning && exec.Status != oesmodel.RunbookExecBlocked {
+		return nil, errors.ErrRunbookStepState.WithField("status", exec.Status)
+	}
+	for _, step := range exec.Steps {
+		if step.Status == oesmodel.RunbookStepRunning {
+			return nil, errors.ErrRunbookStepState.WithFields(map[string]any{
+				"step_id": step.ID,
+				"status":  step.Status,
+			})
+		}
+	}
+
+	now := time.Now()
+	for _, step := range exec.Steps {
+		if step.Status == oesmodel.RunbookStepSuccess {
+			continue
+		}
+		if rErr := s.updateStep(ctx, step.ID, map[string]any{
+			"status":      oesmodel.RunbookStepSkipped,
+			"finished_at": now,
+		}); rErr != nil {
+			return nil, rErr
+		}
+	}
+	if rErr := s.updateExec(ctx, execID, map[string]any{
+		"status":      oesmodel.RunbookExecAborted,
+		"finished_at": now,
+	}); rErr != nil {
+		return nil, rErr
+	}
+	ctxutil.Logger(ctx, s.log).Info(
+		"oes检查单已终止",
+		zap.Uint32("runbook_exec_id", execID),
+		zap.String("operator", username),
+	)
+	return s.findExec(ctx, execID)
+}
+
+// FindExecByID 查询检查单执行详情及每个步骤的状态
+func (s *OesRunbookService) FindExecByID(
+	ctx context.Context,
+	execID uint32,
+) (*oesmodel.OesRunbookExecModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+	return s.findExec(ctx, execID)
+}
+
+func (s *OesRunbookService) ListExec(
+	ctx context.Context,
+	qp database.QueryParams,
+) (int64, *[]oesmodel.OesRunbookExecModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return 0, nil, errors.FromError(ctx.Err())
+	}
+
+	count, ms, err := s.execRepo.ListModel(ctx, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询oes检查单执行列表失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		return 0, nil, errors.NewGormError(err, nil)
+	}
+	return count, ms, nil
+}
+
+// RecoverInterrupted 将服务重启前执行中的脚本步骤标记为失败, 由操作员决定重试或终止
+func (s *OesRunbookService) RecoverInterrupted(ctx context.Context) *errors.Error {
+	n, err := s.execRepo.FailRunning(ctx, "服务重启, 脚本执行中断")
+	if err != nil {
+		return errors.NewGormError(err, nil)
+	}
+	if n > 0 {
+		ctxutil.Logger(ctx, s.log).Warn("已阻塞服务重启前执行中的oes检查单", zap.Int64("count", n))
+	}
+	return nil
+}
+
+func (s *OesRunbookService) findExec(
+	ctx context.Context,
+	execID uint32,
+) (*oesmodel.OesRunbookExecModel, *errors.Error) {
+	m, err := s.execRepo.GetModel(ctx, []string{"Steps"}, execID)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询oes检查单执行失败",
+			zap.Error(err),
+			zap.Uint32("runbook_exec_id", execID),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"id": execID})
+	}
+	return m, nil
+}
+
+// currentStep 查询检查单执行及其当前步骤, 指定的步骤不是当前步骤时返回错误
+func (s *OesRunbookService) currentStep(
+	ctx context.Context,
+	execID uint32,
+	stepID uint32,
+) (*oesmodel.OesRunbookExecModel, *oesmodel.OesRunbookExecStepModel, *errors.Error) {
+	exec, rErr := s.findExec(ctx, execID)
+	if rErr != nil {
+		return nil, nil, rErr
+	}
+	for _, step := range exec.SortedSteps() {
+		if step.Status == oesmodel.RunbookStepSuccess {
+			continue
+		}
+		if step.ID != stepID {
+			break
+		}
+		return exec, &step, nil
+	}
+	return nil, nil, errors.ErrRunbookStepState.WithFields(map[string]any{
+		"runbook_exec_id": execID,
+		"step_id":         stepID,
+	})
+}
+
+// advance 推进检查单执行到下一个未完成的步骤, 调用方需持有s.mu
+//
+// 全部步骤完成时结束执行; 人工步骤进入等待确认; 脚本步骤在后台执行
+func (s *OesRunbookService) advance(ctx context.Context, execID uint32, username string) *errors.Error {
+	exec, rErr := s.findExec(ctx, execID)
+	if rErr != nil {
+		return rErr
+	}
+	if exec.Status != oesmodel.RunbookExecRunning {
+		return nil
+	}
+
+	for _, step := range exec.SortedSteps() {
+		if step.Status == oesmodel.RunbookStepSuccess {
+			continue
+		}
+		if step.Status != oesmodel.RunbookStepPending {
+			return nil
+		}
+		now := time.Now()
+		if step.Kind == oesmodel.RunbookStepManual {
+			return s.updateStep(ctx, step.ID, map[string]any{
+				"status":     oesmodel.RunbookStepWaiting,
+				"started_at": now,
+			})
+		}
+		if rErr := s.updateStep(ctx, step.ID, map[string]any{
+			"status":     oesmodel.RunbookStepRunning,
+			"operator":   username,
+			"attempts":   step.Attempts + 1,
+			"record_id":  0,
+			"started_at": now,
+		}); rErr != nil {
+			return rErr
+		}
+		go s.runScriptStep(*exec, step, username)
+		return nil
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"oes检查单执行完成",
+		zap.Uint32("runbook_exec_id", execID),
+		zap.String("colony_num", exec.ColonyNum),
+	)
+	return s.updateExec(ctx, execID, map[string]any{
+		"status":      oesmodel.RunbookExecSuccess,
+		"finished_at": time.Now(),
+	})
+}
+
+// runScriptStep 执行脚本步骤, 成功后推进到下一步, 失败时阻塞检查单执行
+func (s *OesRunbookService) runScriptStep(
+	exec oesmodel.OesRunbookExecModel,
+	step oesmodel.OesRunbookExecStepModel,
+	username string,
+) {
+	ctx := context.Background()
+	data := map[string]any{
+		"status":      oesmodel.RunbookStepFailed,
+		"finished_at": time.Now(),
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			s.log.Error(
+				"oes检查单脚本步骤发生panic",
+				zap.Any("panic", r),
+				zap.String("stack", string(debug.Stack())),
+				zap.Uint32("runbook_exec_id", exec.ID),
+				zap.String("step", step.Name),
+			)
+			data["error_message"] = "脚本步骤执行异常"
+		}
+		s.finishScriptStep(ctx, exec.ID, step, data, username)
+	}()
+
+	record, rErr := s.ucRecord.CreateRecord(ctx, jobsmodel.ExecuteRequest{
+		TriggerType: runbookTriggerType,
+		ScriptID:    step.ScriptID,
+		CommandArgs: exec.ColonyNum,
+		EnvVars:     "{}",
+		Timeout:     step.Timeout,
+		Username:    username,
+	})
+	if rErr != nil {
+		s.log.Error(
+			"创建oes检查单脚本步骤的执行记录失败",
+			zap.Error(rErr),
+			zap.Uint32("runbook_exec_id", exec.ID),
+			zap.String("step", step.Name),
+		)
+		data["error_message"] = rErr.Msg
+		return
+	}
+	_ = s.updateStep(ctx, step.ID, map[string]any{"record_id": record.ID})
+
+	taskinfo := s.ucRecord.ExecuteRecord(record)
+	data["finished_at"] = time.Now()
+	if taskinfo.Status == workflowRecordSuccess {
+		data["status"] = oesmodel.RunbookStepSuccess
+		return
+	}
+	data["error_message"] = taskinfo.ErrMSG
+	if taskinfo.ErrMSG == "" && taskinfo.Error != nil {
+		data["error_message"] = taskinfo.Error.Error()
+	}
+}
+
+// finishScriptStep 保存脚本步骤的执行结果并推进或阻塞检查单执行
+func (s *OesRunbookService) finishScriptStep(
+	ctx context.Context,
+	execID uint32,
+	step oesmodel.OesRunbookExecStepModel,
+	data map[string]any,
+	username string,
+) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if rErr := s.updateStep(ctx, step.ID, data); rErr != nil {
+		return
+	}
+	if data["status"] != oesmodel.RunbookStepSuccess {
+		ctxutil.Logger(ctx, s.log).Warn(
+			"oes检查单脚本步骤执行失败, 等待重试或终止",
+			zap.Uint32("runbook_exec_id", execID),
+			zap.String("step", step.Name),
+			zap.Any("error_message", data["error_message"]),
+		)
+		_ = s.updateExec(ctx, execID, map[string]any{"status": oesmodel.RunbookExecBlocked})
+		return
+	}
+	if rErr := s.advance(ctx, execID, username); rErr != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"推进oes检查单执行失败",
+			zap.Error(rErr),
+			zap.Uint32("runbook_exec_id", execID),
+		)
+	}
+}
+
+func (s *OesRunbookService) updateExec(ctx context.Context, execID uint32, data map[string]any) *errors.Error {
+	if err := s.execRepo.UpdateModel(ctx, data, "id = ?", execID); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"更新oes检查单执行失败",
+			zap.Error(err),
+			zap.Uint32("runbook_exec_id", execID),
+			zap.Any(database.UpdateDataKey, data),
+		)
+		return errors.NewGormError(err, map[string]any{"id": execID})
+	}
+	return nil
+}
+
+func (s *OesRunbookService) updateStep(ctx context.Context, stepID uint32, data map[string]any) *errors.Error {
+	if err := s.execRepo.UpdateStepModel(ctx, data, "id = ?", stepID); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"更新oes检查单执行步骤失败",
+			zap.Error(err),
+			zap.Uint32("step_id", stepID),
+			zap.Any(database.UpdateDataKey, data),
+		)
+		return errors.NewGormError(err, map[string]any{"id": stepID})
+	}
+	return nil
+}
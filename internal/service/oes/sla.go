@@ -0,0 +1,554 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	jobsmodel "gin-artweb/internal/model/jobs"
+	oesmodel "gin-artweb/internal/model/oes"
+	oesrepo "gin-artweb/internal/repository/oes"
+	jobsvc "gin-artweb/internal/service/jobs"
+	syssvc "gin-artweb/internal/service/system"
+	"gin-artweb/internal/shared/common"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+)
+
+// SLA告警级别, 即将超时告警之后只会再发出一次已超时告警
+const (
+	taskSlaAlertNone     = ""
+	taskSlaAlertAtRisk   = "at_risk"
+	taskSlaAlertBreached = "breached"
+)
+
+// OesTaskSlaService oes日常任务SLA服务
+//
+// 按集群的系统类型确定需要执行的日常任务, 读取任务标识文件中记录的最近一次执行记录,
+// 与任务的完成截止时间比较得到每个交易日的SLA状态, 即将超时或已超时时发出告警事件,
+// 处于维护窗口内时屏蔽告警
+type OesTaskSlaService struct {
+	log        *zap.Logger
+	slaRepo    *oesrepo.OesTaskSlaRepo
+	resultRepo *oesrepo.OesTaskSlaResultRepo
+	colonyRepo *oesrepo.OesColonyRepo
+	ucRecord   *JobsService
+	ucCatalog  *OesTaskCatalogService
+	calendar   *jobsvc.CalendarService
+	alerts     *syssvc.AlertPublisher
+	// mu 串行化定时检查和立即检查
+	mu sync.Mutex
+}
+
+func NewOesTaskSlaService(
+	log *zap.Logger,
+	slaRepo *oesrepo.OesTaskSlaRepo,
+	resultRepo *oesrepo.OesTaskSlaResultRepo,
+	colonyRepo *oesrepo.OesColonyRepo,
+	ucRecord *JobsService,
+	ucCatalog *OesTaskCatalogService,
+	calendar *jobsvc.CalendarService,
+	alerts *syssvc.AlertPublisher,
+) *OesTaskSlaService {
+	return &OesTaskSlaService{
+		log:        log,
+		slaRepo:    slaRepo,
+		resultRepo: resultRepo,
+		colonyRepo: colonyRepo,
+		ucRecord:   ucRecord,
+		ucCatalog:  ucCatalog,
+		calendar:   calendar,
+		alerts:     alerts,
+	}
+}
+
+// checkColony 校验SLA指定的集群存在, 集群ID为0时适用全部集群
+func (s *OesTaskSlaService) checkColony(ctx context.Context, colonyID uint32) *errors.Error {
+	if colonyID == 0 {
+		return nil
+	}
+	if _, err := s.colonyRepo.GetModel(ctx, nil, colonyID); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询oes集群失败",
+			zap.Error(err),
+			zap.Uint32("oes_colony_id", colonyID),
+		)
+		return errors.NewGormError(err, map[string]any{"oes_colony_id": colonyID})
+	}
+	return nil
+}
+
+// checkTaskName 校验SLA的任务在日常任务目录中
+func (s *OesTaskSlaService) checkTaskName(ctx context.Context, taskName string) *errors.Error {
+	_, ms, rErr := s.ucCatalog.ListTaskCatalog(ctx, database.QueryParams{
+		Size:  1,
+		Query: map[string]any{"task_name = ?": taskName},
+	})
+	if rErr != nil {
+		return rErr
+	}
+	if len(*ms) == 0 {
+		return errors.ErrValidationFailed.WithField("task_name", taskName).WithCause(
+			fmt.Errorf("日常任务目录中不存在任务: %s", taskName),
+		)
+	}
+	return nil
+}
+
+func (s *OesTaskSlaService) CreateTaskSla(
+	ctx context.Context,
+	m oesmodel.OesTaskSlaModel,
+) (*oesmodel.OesTaskSlaModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	if rErr := s.checkTaskName(ctx, m.TaskName); rErr != nil {
+		return nil, rErr
+	}
+	if rErr := s.checkColony(ctx, m.OesColonyID); rErr != nil {
+		return nil, rErr
+	}
+	if err := s.slaRepo.CreateModel(ctx, &m); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"创建oes任务SLA失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, &m),
+		)
+		return nil, errors.NewGormError(err, map[string]any{
+			"task_name":     m.TaskName,
+			"oes_colony_id": m.OesColonyID,
+		})
+	}
+	return &m, nil
+}
+
+func (s *OesTaskSlaService) UpdateTaskSlaByID(
+	ctx context.Context,
+	m oesmodel.OesTaskSlaModel,
+) (*oesmodel.OesTaskSlaModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	if rErr := s.checkTaskName(ctx, m.TaskName); rErr != nil {
+		return nil, rErr
+	}
+	if rErr := s.checkColony(ctx, m.OesColonyID); rErr != nil {
+		return nil, rErr
+	}
+	data := map[string]any{
+		"task_name":     m.TaskName,
+		"oes_colony_id": m.OesColonyID,
+		"deadline":      m.Deadline,
+		"warn_minutes":  m.WarnMinutes,
+		"is_enabled":    m.IsEnabled,
+		"description":   m.Description,
+	}
+	if err := s.slaRepo.UpdateModel(ctx, data, "id = ?", m.ID); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"更新oes任务SLA失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, &m),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"id": m.ID})
+	}
+	return s.FindTaskSlaByID(ctx, m.ID)
+}
+
+func (s *OesTaskSlaService) DeleteTaskSlaByID(
+	ctx context.Context,
+	slaID uint32,
+) *errors.Error {
+	if ctx.Err() != nil {
+		return errors.FromError(ctx.Err())
+	}
+
+	if _, rErr := s.FindTaskSlaByID(ctx, slaID); rErr != nil {
+		return rErr
+	}
+	if err := s.slaRepo.DeleteModel(ctx, slaID); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"删除oes任务SLA失败",
+			zap.Error(err),
+			zap.Uint32("sla_id", slaID),
+		)
+		return errors.NewGormError(err, map[string]any{"id": slaID})
+	}
+	return nil
+}
+
+func (s *OesTaskSlaService) FindTaskSlaByID(
+	ctx context.Context,
+	slaID uint32,
+) (*oesmodel.OesTaskSlaModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	m, err := s.slaRepo.GetModel(ctx, nil, slaID)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询oes任务SLA失败",
+			zap.Error(err),
+			zap.Uint32("sla_id", slaID),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"id": slaID})
+	}
+	return m, nil
+}
+
+func (s *OesTaskSlaService) ListTaskSla(
+	ctx context.Context,
+	qp database.QueryParams,
+) (int64, *[]oesmodel.OesTaskSlaModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return 0, nil, errors.FromError(ctx.Err())
+	}
+
+	count, ms, err := s.slaRepo.ListModel(ctx, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询oes任务SLA列表失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		return 0, nil, errors.NewGormError(err, nil)
+	}
+	return count, ms, nil
+}
+
+// Compliance 统计交易日的SLA达成情况, 集群ID为0时统计全部集群
+func (s *OesTaskSlaService) Compliance(
+	ctx context.Context,
+	tradingDay string,
+	colonyID uint32,
+) (*oesmodel.TaskSlaComplianceOut, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	query := map[string]any{"trading_day = ?": tradingDay}
+	if colonyID != 0 {
+		query["oes_colony_id = ?"] = colonyID
+	}
+	qp := database.QueryParams{
+		OrderBy: []string{"oes_colony_id ASC", "deadline ASC", "task_name ASC"},
+		Query:   query,
+	}
+	_, ms, err := s.resultRepo.ListModel(ctx, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询oes任务SLA检查结果失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		return nil, errors.NewGormError(err, nil)
+	}
+	return oesmodel.NewTaskSlaComplianceOut(tradingDay, ms), nil
+}
+
+// CheckAllColonies 检查全部启用集群当天日常任务的SLA, 非交易日跳过, 单个集群检查失败不影响其他集群
+func (s *OesTaskSlaService) CheckAllColonies(ctx context.Context) *errors.Error {
+	if ctx.Err() != nil {
+		return errors.FromError(ctx.Err())
+	}
+
+	now := time.Now()
+	if s.calendar != nil {
+		day, rErr := s.calendar.CheckTradingDay(ctx, now)
+		if rErr != nil {
+			ctxutil.Logger(ctx, s.log).Error(
+				"查询交易日历失败, 照常检查oes任务SLA",
+				zap.Error(rErr),
+			)
+		} else if !day.IsTradingDay {
+			ctxutil.Logger(ctx, s.log).Debug(
+				"非交易日, 跳过oes任务SLA检查",
+				zap.String("date", day.Date),
+			)
+			return nil
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, slas, err := s.slaRepo.ListModel(ctx, database.QueryParams{
+		Query: map[string]any{"is_enabled = ?": true},
+	})
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询oes任务SLA列表失败",
+			zap.Error(err),
+		)
+		return errors.NewGormError(err, nil)
+	}
+	if len(*slas) == 0 {
+		return nil
+	}
+	_, colonies, err := s.colonyRepo.ListModel(ctx, database.QueryParams{
+		OrderBy: []string{"id ASC"},
+		Query:   map[string]any{"is_enable = ?": true},
+	})
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询oes集群列表失败",
+			zap.Error(err),
+		)
+		return errors.NewGormError(err, nil)
+	}
+	tasks, rErr := s.ucCatalog.EnabledTasks(ctx)
+	if rErr != nil {
+		return rErr
+	}
+	for _, colony := range *colonies {
+		if rErr := s.checkColonySla(ctx, colony, *slas, tasks[colony.SystemType], now); rErr != nil {
+			ctxutil.Logger(ctx, s.log).Error(
+				"检查oes集群任务SLA失败",
+				zap.Error(rErr),
+				zap.Uint32("oes_colony_id", colony.ID),
+			)
+		}
+	}
+	return nil
+}
+
+// checkColonySla 检查集群当天每个定义了SLA的日常任务并保存结果
+func (s *OesTaskSlaService) checkColonySla(
+	ctx context.Context,
+	colony oesmodel.OesColonyModel,
+	slas []oesmodel.OesTaskSlaModel,
+	taskNames []string,
+	now time.Time,
+) *errors.Error {
+	colonySlas := resolveColonySlas(slas, colony.ID, taskNames)
+	if len(colonySlas) == 0 {
+		return nil
+	}
+
+	// 任务标识文件记录了任务最近一次的执行记录ID
+	flagDir := filepath.Join(config.StorageDir, "oes", "flags", colony.ColonyNum)
+	recordIDs := make(map[string]uint32, len(colonySlas))
+	ids := make([]uint32, 0, len(colonySlas))
+	for _, sla := range colonySlas {
+		id, err := common.ReadUint32FromFile(filepath.Join(flagDir, "."+sla.TaskName))
+		if err != nil {
+			return errors.FromError(err)
+		}
+		if id != 0 {
+			recordIDs[sla.TaskName] = id
+			ids = append(ids, id)
+		}
+	}
+	records, rErr := s.ucRecord.FindRecordsByIDs(ctx, ids)
+	if rErr != nil {
+		return rErr
+	}
+
+	tradingDay := now.Format(time.DateOnly)
+	for _, sla := range colonySlas {
+		deadline, err := time.ParseInLocation(time.DateOnly+" 15:04", tradingDay+" "+sla.Deadline, time.Local)
+		if err != nil {
+			ctxutil.Logger(ctx, s.log).Error(
+				"oes任务SLA的截止时间格式错误",
+				zap.Error(err),
+				zap.Object(database.ModelKey, &sla),
+			)
+			continue
+		}
+		// 只统计当天的执行记录, 标识文件中前一交易日的记录视为当天未执行
+		var record *jobsmodel.ScriptRecordModel
+		if r, ok := records[recordIDs[sla.TaskName]]; ok && r.CreatedAt.Format(time.DateOnly) == tradingDay {
+			record = &r
+		}
+		status, finishedAt := evaluateTaskSla(deadline, time.Duration(sla.WarnMinutes)*time.Minute, record, now)
+
+		result := oesmodel.OesTaskSlaResultModel{
+			SlaID:       sla.ID,
+			OesColonyID: colony.ID,
+			ColonyNum:   colony.ColonyNum,
+			TaskName:    sla.TaskName,
+			TradingDay:  tradingDay,
+			Deadline:    sla.Deadline,
+			Status:      status,
+			FinishedAt:  finishedAt,
+		}
+		if record != nil {
+			result.RecordID = record.ID
+			result.RecordStatus = record.Status
+		}
+		saved, rErr := s.saveResult(ctx, result)
+		if rErr != nil {
+			return rErr
+		}
+		if level := taskSlaAlertLevel(status); taskSlaAlertRank(level) > taskSlaAlertRank(saved.AlertLevel) {
+			s.notifyTaskSla(ctx, saved, now)
+			if err := s.resultRepo.UpdateModel(ctx, map[string]any{"alert_level": level}, "id = ?", saved.ID); err != nil {
+				return errors.NewGormError(err, map[string]any{"id": saved.ID})
+			}
+		}
+	}
+	return nil
+}
+
+// saveResult 保存集群当天任务的SLA检查结果, 返回保存前已发出的告警级别
+func (s *OesTaskSlaService) saveResult(
+	ctx context.Context,
+	m oesmodel.OesTaskSlaResultModel,
+) (*oesmodel.OesTaskSlaResultModel, *errors.Error) {
+	old, err := s.resultRepo.GetModel(ctx, nil,
+		"oes_colony_id = ? AND task_name = ? AND trading_day = ?", m.OesColonyID, m.TaskName, m.TradingDay)
+	if err != nil {
+		if rErr := errors.NewGormError(err, nil); !rErr.Is(errors.ErrRecordNotFound) {
+			return nil, rErr
+		}
+		if err := s.resultRepo.CreateModel(ctx, &m); err != nil {
+			ctxutil.Logger(ctx, s.log).Error(
+				"保存oes任务SLA检查结果失败",
+				zap.Error(err),
+				zap.Object(database.ModelKey, &m),
+			)
+			return nil, errors.NewGormError(err, nil)
+		}
+		return &m, nil
+	}
+
+	if err := s.resultRepo.UpdateModel(ctx, map[string]any{
+		"sla_id":        m.SlaID,
+		"deadline":      m.Deadline,
+		"status":        m.Status,
+		"record_id":     m.RecordID,
+		"record_status": m.RecordStatus,
+		"finished_at":   m.FinishedAt,
+	}, "id = ?", old.ID); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"保存oes任务SLA检查结果失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, &m),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"id": old.ID})
+	}
+	m.ID = old.ID
+	m.AlertLevel = old.AlertLevel
+	return &m, nil
+}
+
+// notifyTaskSla 发出任务即将超时或已超时告警, 处于维护窗口内时屏蔽告警并记录审计
+func (s *OesTaskSlaService) notifyTaskSla(
+	ctx context.Context,
+	m *oesmodel.OesTaskSlaResultModel,
+	now time.Time,
+) {
+	if !s.alerts.Publish(ctx, syssvc.Alert{
+		Kind:     oesmodel.AlertKindTaskSla,
+		Status:   m.Status,
+		Scope:    syssvc.AlertScopeColony,
+		Module:   "oes",
+		TargetID: m.OesColonyID,
+		At:       now,
+		Event: oesmodel.TaskSlaEvent{
+			Kind:       oesmodel.AlertKindTaskSla,
+			Project:    "oes",
+			ID:         m.OesColonyID,
+			ColonyNum:  m.ColonyNum,
+			TaskName:   m.TaskName,
+			TradingDay: m.TradingDay,
+			Deadline:   m.Deadline,
+			Status:     m.Status,
+			RecordID:   m.RecordID,
+			CheckedAt:  now.Format(time.DateTime),
+		},
+		Detail: map[string]any{
+			"oes_colony_id": m.OesColonyID,
+			"task_name":     m.TaskName,
+			"status":        m.Status,
+			"checked_at":    now.Format(time.DateTime),
+		},
+	}) {
+		return
+	}
+	ctxutil.Logger(ctx, s.log).Warn(
+		"oes日常任务未按时完成",
+		zap.Uint32("oes_colony_id", m.OesColonyID),
+		zap.String("colony_num", m.ColonyNum),
+		zap.String("task_name", m.TaskName),
+		zap.String("deadline", m.Deadline),
+		zap.String("status", m.Status),
+	)
+}
+
+// resolveColonySlas 返回集群每个日常任务生效的SLA, 集群单独定义的SLA优先于适用全部集群的SLA
+func resolveColonySlas(
+	slas []oesmodel.OesTaskSlaModel,
+	colonyID uint32,
+	taskNames []string,
+) []oesmodel.OesTaskSlaModel {
+	byTask := make(map[string]oesmodel.OesTaskSlaModel)
+	for _, sla := range slas {
+		if sla.OesColonyID != 0 && sla.OesColonyID != colonyID {
+			continue
+		}
+		if old, ok := byTask[sla.TaskName]; ok && old.OesColonyID != 0 {
+			continue
+		}
+		byTask[sla.TaskName] = sla
+	}
+	result := make([]oesmodel.OesTaskSlaModel, 0, len(byTask))
+	for _, task := range taskNames {
+		if sla, ok := byTask[task]; ok {
+			result = append(result, sla)
+		}
+	}
+	return result
+}
+
+// evaluateTaskSla 根据任务当天的执行记录计算SLA状态, 返回状态和任务执行成功的时间
+func evaluateTaskSla(
+	deadline time.Time,
+	warn time.Duration,
+	record *jobsmodel.ScriptRecordModel,
+	now time.Time,
+) (string, *time.Time) {
+	if record != nil && record.Status == workflowRecordSuccess {
+		finishedAt := record.UpdatedAt
+		if finishedAt.After(deadline) {
+			return oesmodel.TaskSlaLate, &finishedAt
+		}
+		return oesmodel.TaskSlaMet, &finishedAt
+	}
+	switch {
+	case now.After(deadline):
+		return oesmodel.TaskSlaBreached, nil
+	case warn > 0 && !now.Before(deadline.Add(-warn)):
+		return oesmodel.TaskSlaAtRisk, nil
+	}
+	return oesmodel.TaskSlaPending, nil
+}
+
+// taskSlaAlertLevel 返回SLA状态对应的告警级别, 截止后才完成的任务视为已超时
+func taskSlaAlertLevel(status string) string {
+	switch status {
+	case oesmodel.TaskSlaAtRisk:
+		return taskSlaAlertAtRisk
+	case oesmodel.TaskSlaBreached, oesmodel.TaskSlaLate:
+		return taskSlaAlertBreached
+	}
+	return taskSlaAlertNone
+}
+
+func taskSlaAlertRank(level string) int {
+	switch level {
+	case taskSlaAlertAtRisk:
+		return 1
+	case taskSlaAlertBreached:
+		return 2
+	}
+	return 0
+}
@@ -0,0 +1,82 @@
+package biz
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	jobsmodel "gin-artweb/internal/model/jobs"
+	oesmodel "gin-artweb/internal/model/oes"
+	"gin-artweb/internal/shared/database"
+)
+
+type TaskSlaTestSuite struct {
+	suite.Suite
+}
+
+func (suite *TaskSlaTestSuite) TestEvaluateTaskSla() {
+	deadline := time.Date(2024, 1, 2, 7, 30, 0, 0, time.Local)
+	warn := 15 * time.Minute
+	record := func(status int, finishedAt time.Time) *jobsmodel.ScriptRecordModel {
+		return &jobsmodel.ScriptRecordModel{
+			StandardModel: database.StandardModel{UpdatedAt: finishedAt},
+			Status:        status,
+		}
+	}
+
+	status, finishedAt := evaluateTaskSla(deadline, warn, nil, deadline.Add(-time.Hour))
+	suite.Equal(oesmodel.TaskSlaPending, status)
+	suite.Nil(finishedAt)
+
+	status, _ = evaluateTaskSla(deadline, warn, record(1, deadline), deadline.Add(-10*time.Minute))
+	suite.Equal(oesmodel.TaskSlaAtRisk, status, "临近截止时间仍在执行应该即将超时")
+
+	status, _ = evaluateTaskSla(deadline, 0, nil, deadline.Add(-time.Minute))
+	suite.Equal(oesmodel.TaskSlaPending, status, "未配置提前告警时不会即将超时")
+
+	status, _ = evaluateTaskSla(deadline, warn, record(3, deadline.Add(-time.Hour)), deadline.Add(time.Minute))
+	suite.Equal(oesmodel.TaskSlaBreached, status, "执行失败且已过截止时间应该已超时")
+
+	status, finishedAt = evaluateTaskSla(deadline, warn, record(2, deadline), deadline.Add(time.Hour))
+	suite.Equal(oesmodel.TaskSlaMet, status, "截止时间当时完成应该达成")
+	suite.Equal(deadline, *finishedAt)
+
+	status, _ = evaluateTaskSla(deadline, warn, record(2, deadline.Add(time.Second)), deadline.Add(time.Hour))
+	suite.Equal(oesmodel.TaskSlaLate, status)
+}
+
+func (suite *TaskSlaTestSuite) TestResolveColonySlas() {
+	slas := []oesmodel.OesTaskSlaModel{
+		{TaskName: "counter_distribute", OesColonyID: 2, Deadline: "07:00"},
+		{TaskName: "counter_distribute", OesColonyID: 0, Deadline: "07:30"},
+		{TaskName: "counter_fetch", OesColonyID: 0, Deadline: "07:00"},
+		{TaskName: "bse", OesColonyID: 0, Deadline: "08:00"},
+		{TaskName: "sse", OesColonyID: 3, Deadline: "08:00"},
+	}
+
+	tasks := groupTaskCatalog(oesmodel.DefaultOesTaskCatalog())
+	got := resolveColonySlas(slas, 2, tasks["OPT"])
+	suite.Require().Len(got, 2, "OPT集群不执行bse任务, 其他集群的SLA不生效")
+	suite.Equal("counter_fetch", got[0].TaskName)
+	suite.Equal("counter_distribute", got[1].TaskName)
+	suite.Equal("07:00", got[1].Deadline, "集群单独定义的SLA优先")
+
+	got = resolveColonySlas(slas, 1, tasks["STK"])
+	suite.Require().Len(got, 3)
+	suite.Equal("07:30", got[1].Deadline)
+
+	suite.Empty(resolveColonySlas(slas, 1, tasks[""]))
+}
+
+func (suite *TaskSlaTestSuite) TestTaskSlaAlertLevel() {
+	suite.Equal(taskSlaAlertNone, taskSlaAlertLevel(oesmodel.TaskSlaMet))
+	suite.Equal(taskSlaAlertAtRisk, taskSlaAlertLevel(oesmodel.TaskSlaAtRisk))
+	suite.Equal(taskSlaAlertBreached, taskSlaAlertLevel(oesmodel.TaskSlaLate))
+	suite.Greater(taskSlaAlertRank(taskSlaAlertBreached), taskSlaAlertRank(taskSlaAlertAtRisk))
+	suite.Greater(taskSlaAlertRank(taskSlaAlertAtRisk), taskSlaAlertRank(taskSlaAlertNone))
+}
+
+func TestTaskSlaTestSuite(t *testing.T) {
+	suite.Run(t, new(TaskSlaTestSuite))
+}
@@ -0,0 +1,188 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"go.uber.org/zap"
+
+	oesmodel "gin-artweb/internal/model/oes"
+	oesrepo "gin-artweb/internal/repository/oes"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+)
+
+// taskNamePattern 任务名称同时是任务标识文件的名称, 只允许小写字母、数字和下划线
+var taskNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+type OesTaskCatalogService struct {
+	log         *zap.Logger
+	catalogRepo *oesrepo.OesTaskCatalogRepo
+}
+
+func NewOesTaskCatalogService(
+	log *zap.Logger,
+	catalogRepo *oesrepo.OesTaskCatalogRepo,
+) *OesTaskCatalogService {
+	return &OesTaskCatalogService{
+		log:         log,
+		catalogRepo: catalogRepo,
+	}
+}
+
+func checkTaskName(taskName string) *errors.Error {
+	if !taskNamePattern.MatchString(taskName) {
+		return errors.ErrValidationFailed.WithField("task_name", taskName).WithCause(
+			fmt.Errorf("任务名称只能包含小写字母、数字和下划线, 且以小写字母开头: %s", taskName),
+		)
+	}
+	return nil
+}
+
+func (s *OesTaskCatalogService) CreateTaskCatalog(
+	ctx context.Context,
+	m oesmodel.OesTaskCatalogModel,
+) (*oesmodel.OesTaskCatalogModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	if rErr := checkTaskName(m.TaskName); rErr != nil {
+		return nil, rErr
+	}
+	if err := s.catalogRepo.CreateModel(ctx, &m); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"创建oes日常任务目录失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, &m),
+		)
+		return nil, errors.NewGormError(err, map[string]any{
+			"system_type": m.SystemType,
+			"task_name":   m.TaskName,
+		})
+	}
+	return &m, nil
+}
+
+func (s *OesTaskCatalogService) UpdateTaskCatalogByID(
+	ctx context.Context,
+	m oesmodel.OesTaskCatalogModel,
+) (*oesmodel.OesTaskCatalogModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	if rErr := checkTaskName(m.TaskName); rErr != nil {
+		return nil, rErr
+	}
+	data := map[string]any{
+		"system_type": m.SystemType,
+		"task_name":   m.TaskName,
+		"sort":        m.Sort,
+		"is_enabled":  m.IsEnabled,
+		"description": m.Description,
+	}
+	if err := s.catalogRepo.UpdateModel(ctx, data, "id = ?", m.ID); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"更新oes日常任务目录失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, &m),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"id": m.ID})
+	}
+	return s.FindTaskCatalogByID(ctx, m.ID)
+}
+
+func (s *OesTaskCatalogService) DeleteTaskCatalogByID(
+	ctx context.Context,
+	catalogID uint32,
+) *errors.Error {
+	if ctx.Err() != nil {
+		return errors.FromError(ctx.Err())
+	}
+
+	if _, rErr := s.FindTaskCatalogByID(ctx, catalogID); rErr != nil {
+		return rErr
+	}
+	if err := s.catalogRepo.DeleteModel(ctx, catalogID); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"删除oes日常任务目录失败",
+			zap.Error(err),
+			zap.Uint32("catalog_id", catalogID),
+		)
+		return errors.NewGormError(err, map[string]any{"id": catalogID})
+	}
+	return nil
+}
+
+func (s *OesTaskCatalogService) FindTaskCatalogByID(
+	ctx context.Context,
+	catalogID uint32,
+) (*oesmodel.OesTaskCatalogModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	m, err := s.catalogRepo.GetModel(ctx, nil, catalogID)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询oes日常任务目录失败",
+			zap.Error(err),
+			zap.Uint32("catalog_id", catalogID),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"id": catalogID})
+	}
+	return m, nil
+}
+
+func (s *OesTaskCatalogService) ListTaskCatalog(
+	ctx context.Context,
+	qp database.QueryParams,
+) (int64, *[]oesmodel.OesTaskCatalogModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return 0, nil, errors.FromError(ctx.Err())
+	}
+
+	count, ms, err := s.catalogRepo.ListModel(ctx, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询oes日常任务目录列表失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		return 0, nil, errors.NewGormError(err, nil)
+	}
+	return count, ms, nil
+}
+
+// EnabledTasks 按系统类型分组返回启用的日常任务, 组内按排序顺序排列
+func (s *OesTaskCatalogService) EnabledTasks(ctx context.Context) (map[string][]string, *errors.Error) {
+	_, ms, rErr := s.ListTaskCatalog(ctx, database.QueryParams{
+		OrderBy: []string{"sort ASC", "id ASC"},
+		Query:   map[string]any{"is_enabled = ?": true},
+	})
+	if rErr != nil {
+		return nil, rErr
+	}
+	return groupTaskCatalog(*ms), nil
+}
+
+// TaskNames 返回系统类型的集群需要执行的日常任务
+func (s *OesTaskCatalogService) TaskNames(ctx context.Context, systemType string) ([]string, *errors.Error) {
+	tasks, rErr := s.EnabledTasks(ctx)
+	if rErr != nil {
+		return nil, rErr
+	}
+	return tasks[systemType], nil
+}
+
+// groupTaskCatalog 按系统类型分组任务名称, 保持任务目录的顺序
+func groupTaskCatalog(ms []oesmodel.OesTaskCatalogModel) map[string][]string {
+	tasks := make(map[string][]string)
+	for _, m := range ms {
+		tasks[m.SystemType] = append(tasks[m.SystemType], m.TaskName)
+	}
+	return tasks
+}
@@ -0,0 +1,125 @@
+package biz
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	jobsmodel "gin-artweb/internal/model/jobs"
+	oesmodel "gin-artweb/internal/model/oes"
+	jobsrepo "gin-artweb/internal/repository/jobs"
+	oesrepo "gin-artweb/internal/repository/oes"
+	jobsvc "gin-artweb/internal/service/jobs"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/errors"
+	"gin-artweb/internal/shared/test"
+)
+
+type TaskCatalogTestSuite struct {
+	suite.Suite
+	oldStorageDir  string
+	recordRepo     *jobsrepo.RecordRepo
+	catalogService *OesTaskCatalogService
+	taskUsecase    *ColonyTaskExecutionInfoUsecase
+	slaService     *OesTaskSlaService
+}
+
+func (suite *TaskCatalogTestSuite) SetupTest() {
+	suite.oldStorageDir = config.StorageDir
+	config.StorageDir = suite.T().TempDir()
+
+	db := test.NewTestGormDBWithConfig(nil)
+	db.AutoMigrate(&jobsmodel.ScriptModel{}, &jobsmodel.ScriptRecordModel{}, &oesmodel.OesTaskCatalogModel{})
+	dbTimeout := test.NewTestDBTimeouts()
+	logger := test.NewTestZapLogger()
+	scriptRepo := jobsrepo.NewScriptRepo(logger, db, dbTimeout)
+	suite.recordRepo = jobsrepo.NewRecordRepo(logger, db, dbTimeout)
+	recordService := jobsvc.NewScriptRecordService(logger, scriptRepo, suite.recordRepo, &config.JobsConfig{}, nil)
+
+	catalogRepo := oesrepo.NewOesTaskCatalogRepo(logger, db, dbTimeout)
+	for _, m := range oesmodel.DefaultOesTaskCatalog() {
+		suite.Require().NoError(catalogRepo.CreateModel(context.Background(), &m))
+	}
+	suite.catalogService = NewOesTaskCatalogService(logger, catalogRepo)
+	ucRecord := &JobsService{log: logger, ucRecord: recordService}
+	suite.taskUsecase = NewColonyTaskExecutionInfoUsecase(logger, ucRecord, suite.catalogService)
+	suite.slaService = &OesTaskSlaService{log: logger, ucCatalog: suite.catalogService}
+}
+
+func (suite *TaskCatalogTestSuite) TearDownTest() {
+	config.StorageDir = suite.oldStorageDir
+}
+
+func (suite *TaskCatalogTestSuite) writeFlag(colonyNum, taskName string, recordID uint32) {
+	dir := filepath.Join(config.StorageDir, "oes", "flags", colonyNum)
+	suite.Require().NoError(os.MkdirAll(dir, 0o755))
+	content := strconv.FormatUint(uint64(recordID), 10)
+	suite.Require().NoError(os.WriteFile(filepath.Join(dir, "."+taskName), []byte(content), 0o644))
+}
+
+func (suite *TaskCatalogTestSuite) TestCreateTaskCatalog() {
+	ctx := context.Background()
+	m, rErr := suite.catalogService.CreateTaskCatalog(ctx, oesmodel.OesTaskCatalogModel{
+		SystemType: "STK", TaskName: "bj_fetch", Sort: 5, IsEnabled: true,
+	})
+	suite.Require().Nil(rErr)
+
+	tasks, rErr := suite.catalogService.TaskNames(ctx, "STK")
+	suite.Require().Nil(rErr)
+	suite.Equal([]string{"bj_fetch", "mon", "counter_fetch", "counter_distribute", "bse", "sse", "szse", "csde"}, tasks,
+		"新增的任务按排序插入")
+
+	m.IsEnabled = false
+	_, rErr = suite.catalogService.UpdateTaskCatalogByID(ctx, *m)
+	suite.Require().Nil(rErr)
+	tasks, _ = suite.catalogService.TaskNames(ctx, "STK")
+	suite.NotContains(tasks, "bj_fetch", "停用的任务不再返回")
+
+	for _, name := range []string{"../mon", "Mon", "1mon", ""} {
+		_, rErr = suite.catalogService.CreateTaskCatalog(ctx, oesmodel.OesTaskCatalogModel{SystemType: "STK", TaskName: name})
+		suite.Require().NotNil(rErr, "任务名称不能用作标识文件名称: %s", name)
+		suite.Equal(errors.ErrValidationFailed.Reason, rErr.Reason)
+	}
+}
+
+func (suite *TaskCatalogTestSuite) TestBuildTaskExecutionInfos() {
+	ctx := context.Background()
+	record := &jobsmodel.ScriptRecordModel{Status: 2, TriggerType: "cron"}
+	suite.Require().NoError(suite.recordRepo.CreateModel(ctx, record))
+	suite.writeFlag("02", "sse_late", record.ID)
+
+	colonies := []oesmodel.OesColonyModel{
+		{SystemType: "CRD", ColonyNum: "02"},
+		{SystemType: "STK", ColonyNum: "01"},
+	}
+	infos, rErr := suite.taskUsecase.BuildTaskExecutionInfos(ctx, "CRD", colonies)
+	suite.Require().Nil(rErr)
+	suite.Require().Len(*infos, 1, "只返回指定系统类型的集群")
+	info := (*infos)[0]
+	suite.Equal("02", info.ColonyNum)
+	suite.Require().Len(info.Tasks, 8)
+	for _, task := range info.Tasks {
+		if task.TaskName == "sse_late" {
+			suite.Require().NotNil(task.Record)
+			suite.Equal(record.ID, task.Record.ID)
+		} else {
+			suite.Nil(task.Record, "没有标识文件的任务视为未执行: %s", task.TaskName)
+		}
+	}
+}
+
+func (suite *TaskCatalogTestSuite) TestSlaTaskName() {
+	ctx := context.Background()
+	suite.Nil(suite.slaService.checkTaskName(ctx, "szse_late"))
+	rErr := suite.slaService.checkTaskName(ctx, "bj_fetch")
+	suite.Require().NotNil(rErr, "任务目录中不存在的任务不能定义SLA")
+	suite.Equal(errors.ErrValidationFailed.Reason, rErr.Reason)
+}
+
+func TestTaskCatalogTestSuite(t *testing.T) {
+	suite.Run(t, new(TaskCatalogTestSuite))
+}
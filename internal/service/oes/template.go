@@ -0,0 +1,526 @@
+package biz
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"path"
+	"slices"
+	"strconv"
+	"text/template"
+
+	"go.uber.org/zap"
+
+	oesmodel "gin-artweb/internal/model/oes"
+	sysmodel "gin-artweb/internal/model/system"
+	oesrepo "gin-artweb/internal/repository/oes"
+	sysrepo "gin-artweb/internal/repository/system"
+	resosvc "gin-artweb/internal/service/resource"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+	"gin-artweb/pkg/textdiff"
+)
+
+const (
+	// 读取目标主机上当前配置文件的大小上限
+	confTemplateMaxFileSize = 4 * 1024 * 1024
+	// 渲染预览差异中变更块前后保留的行数
+	confTemplateDiffContext = 3
+)
+
+// confTemplateFuncs 配置模板可用的函数, 用于按集群号计算端口等场景
+var confTemplateFuncs = template.FuncMap{
+	"add": func(a, b int) int { return a + b },
+	"mul": func(a, b int) int { return a * b },
+	"int": strconv.Atoi,
+}
+
+// parseConfTemplate 解析配置模板, 引用不存在的变量时渲染失败
+func parseConfTemplate(name, text string) (*template.Template, error) {
+	return template.New(name).Option("missingkey=error").Funcs(confTemplateFuncs).Parse(text)
+}
+
+// renderConfTemplate 使用变量渲染配置模板
+func renderConfTemplate(name, text string, vars oesmodel.OesConfTemplateVars) (string, error) {
+	t, err := parseConfTemplate(name, text)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, vars); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// renderedConf 配置模板在一个节点上的渲染结果
+type renderedConf struct {
+	node       oesmodel.OesNodeModel
+	remotePath string
+	content    string
+}
+
+// OesConfTemplateService oes配置模板服务
+//
+// 按集群和节点记录渲染配置文件, 预览与目标主机上当前文件的差异, 并通过SFTP下发到节点主机
+type OesConfTemplateService struct {
+	log          *zap.Logger
+	templateRepo *oesrepo.OesConfTemplateRepo
+	colonyRepo   *oesrepo.OesColonyRepo
+	nodeRepo     *oesrepo.OesNodeRepo
+	auditRepo    *sysrepo.AuditRecordRepo
+	ucFile       *resosvc.HostFileService
+}
+
+func NewOesConfTemplateService(
+	log *zap.Logger,
+	templateRepo *oesrepo.OesConfTemplateRepo,
+	colonyRepo *oesrepo.OesColonyRepo,
+	nodeRepo *oesrepo.OesNodeRepo,
+	auditRepo *sysrepo.AuditRecordRepo,
+	ucFile *resosvc.HostFileService,
+) *OesConfTemplateService {
+	return &OesConfTemplateService{
+		log:          log,
+		templateRepo: templateRepo,
+		colonyRepo:   colonyRepo,
+		nodeRepo:     nodeRepo,
+		auditRepo:    auditRepo,
+		ucFile:       ucFile,
+	}
+}
+
+// validate 检查模板内容和目标路径的语法
+func (s *OesConfTemplateService) validate(m *oesmodel.OesConfTemplateModel) *errors.Error {
+	if _, err := parseConfTemplate("remote_path", m.RemotePath); err != nil {
+		return errors.ErrConfTemplateRenderFailed.WithField("field", "remote_path").WithCause(err)
+	}
+	if _, err := parseConfTemplate(m.Name, m.Content); err != nil {
+		return errors.ErrConfTemplateRenderFailed.WithField("field", "content").WithCause(err)
+	}
+	return nil
+}
+
+func (s *OesConfTemplateService) CreateOesConfTemplate(
+	ctx context.Context,
+	m oesmodel.OesConfTemplateModel,
+) (*oesmodel.OesConfTemplateModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	if rErr := s.validate(&m); rErr != nil {
+		return nil, rErr
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始创建oes配置模板",
+		zap.Object(database.ModelKey, &m),
+	)
+
+	if err := s.templateRepo.CreateModel(ctx, &m); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"创建oes配置模板失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, &m),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"name": m.Name})
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"创建oes配置模板成功",
+		zap.Object(database.ModelKey, &m),
+	)
+	return &m, nil
+}
+
+func (s *OesConfTemplateService) UpdateOesConfTemplateById(
+	ctx context.Context,
+	m oesmodel.OesConfTemplateModel,
+) (*oesmodel.OesConfTemplateModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	if rErr := s.validate(&m); rErr != nil {
+		return nil, rErr
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始更新oes配置模板",
+		zap.Object(database.ModelKey, &m),
+	)
+
+	data := map[string]any{
+		"name":        m.Name,
+		"dir_name":    m.DirName,
+		"remote_path": m.RemotePath,
+		"content":     m.Content,
+		"remark":      m.Remark,
+	}
+	if err := s.templateRepo.UpdateModel(ctx, data, "id = ?", m.ID); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"更新oes配置模板失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, &m),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"id": m.ID})
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"更新oes配置模板成功",
+		zap.Object(database.ModelKey, &m),
+	)
+	return s.FindOesConfTemplateById(ctx, m.ID)
+}
+
+func (s *OesConfTemplateService) DeleteOesConfTemplateById(
+	ctx context.Context,
+	templateId uint32,
+) *errors.Error {
+	if ctx.Err() != nil {
+		return errors.FromError(ctx.Err())
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始删除oes配置模板",
+		zap.Uint32("template_id", templateId),
+	)
+
+	if err := s.templateRepo.DeleteModel(ctx, templateId); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"删除oes配置模板失败",
+			zap.Error(err),
+			zap.Uint32("template_id", templateId),
+		)
+		return errors.NewGormError(err, map[string]any{"id": templateId})
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"删除oes配置模板成功",
+		zap.Uint32("template_id", templateId),
+	)
+	return nil
+}
+
+func (s *OesConfTemplateService) FindOesConfTemplateById(
+	ctx context.Context,
+	templateId uint32,
+) (*oesmodel.OesConfTemplateModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	m, err := s.templateRepo.GetModel(ctx, templateId)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询oes配置模板失败",
+			zap.Error(err),
+			zap.Uint32("template_id", templateId),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"id": templateId})
+	}
+	return m, nil
+}
+
+func (s *OesConfTemplateService) ListOesConfTemplate(
+	ctx context.Context,
+	qp database.QueryParams,
+) (int64, *[]oesmodel.OesConfTemplateModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return 0, nil, errors.FromError(ctx.Err())
+	}
+
+	count, ms, err := s.templateRepo.ListModel(ctx, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询oes配置模板列表失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		return 0, nil, errors.NewGormError(err, nil)
+	}
+	return count, ms, nil
+}
+
+// loadColony 查询集群及其启用的节点, 用于生成模板变量
+func (s *OesConfTemplateService) loadColony(
+	ctx context.Context,
+	colonyId uint32,
+) (*oesmodel.OesColonyModel, []oesmodel.OesNodeModel, *errors.Error) {
+	colony, err := s.colonyRepo.GetModel(ctx, []string{"Package", "XCounter", "MonNode"}, colonyId)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询oes集群失败",
+			zap.Error(err),
+			zap.Uint32("oes_colony_id", colonyId),
+		)
+		return nil, nil, errors.NewGormError(err, map[string]any{"id": colonyId})
+	}
+	_, nodes, err := s.nodeRepo.ListModel(ctx, database.QueryParams{
+		Preloads: []string{"Host"},
+		OrderBy:  []string{"id ASC"},
+		Query:    map[string]any{"oes_colony_id = ?": colony.ID, "is_enable = ?": true},
+	})
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询oes节点列表失败",
+			zap.Error(err),
+			zap.Uint32("oes_colony_id", colonyId),
+		)
+		return nil, nil, errors.NewGormError(err, nil)
+	}
+	return colony, *nodes, nil
+}
+
+// newConfTemplateVars 生成集群的模板变量, Node在渲染每个节点时设置
+func newConfTemplateVars(colony *oesmodel.OesColonyModel, nodes []oesmodel.OesNodeModel) oesmodel.OesConfTemplateVars {
+	vars := oesmodel.OesConfTemplateVars{
+		Colony: oesmodel.NewOesConfColonyVars(*colony),
+		Nodes:  make([]oesmodel.OesConfNodeVars, 0, len(nodes)),
+	}
+	for _, node := range nodes {
+		vars.Nodes = append(vars.Nodes, oesmodel.NewOesConfNodeVars(node))
+	}
+	return vars
+}
+
+// renderNodes 在模板适用的节点上渲染模板, nodeIds不为空时只渲染其中的节点
+func renderNodes(
+	tmpl *oesmodel.OesConfTemplateModel,
+	colony *oesmodel.OesColonyModel,
+	nodes []oesmodel.OesNodeModel,
+	nodeIds []uint32,
+) ([]renderedConf, *errors.Error) {
+	var targets []oesmodel.OesNodeModel
+	for _, node := range nodes {
+		if tmpl.DirName != oesmodel.ConfTemplateDirAll && tmpl.DirName != oesmodel.NodeSpecdir(node.NodeRole) {
+			continue
+		}
+		if len(nodeIds) > 0 && !slices.Contains(nodeIds, node.ID) {
+			continue
+		}
+		targets = append(targets, node)
+	}
+	for _, id := range nodeIds {
+		if !slices.ContainsFunc(targets, func(n oesmodel.OesNodeModel) bool { return n.ID == id }) {
+			return nil, errors.ErrValidationFailed.WithFields(map[string]any{
+				"node_id":  id,
+				"dir_name": tmpl.DirName,
+			})
+		}
+	}
+
+	vars := newConfTemplateVars(colony, nodes)
+	results := make([]renderedConf, 0, len(targets))
+	for _, node := range targets {
+		vars.Node = oesmodel.NewOesConfNodeVars(node)
+		remotePath, err := renderConfTemplate("remote_path", tmpl.RemotePath, vars)
+		if err == nil && !path.IsAbs(remotePath) {
+			err = errors.ErrValidationFailed.WithField("remote_path", remotePath)
+		}
+		if err != nil {
+			return nil, errors.ErrConfTemplateRenderFailed.WithFields(map[string]any{
+				"field":   "remote_path",
+				"node_id": node.ID,
+			}).WithCause(err)
+		}
+		content, err := renderConfTemplate(tmpl.Name, tmpl.Content, vars)
+		if err != nil {
+			return nil, errors.ErrConfTemplateRenderFailed.WithFields(map[string]any{
+				"field":   "content",
+				"node_id": node.ID,
+			}).WithCause(err)
+		}
+		results = append(results, renderedConf{
+			node:       node,
+			remotePath: path.Clean(remotePath),
+			content:    content,
+		})
+	}
+	return results, nil
+}
+
+// render 按集群的启用节点渲染模板, nodeIds不为空时只渲染其中的节点
+func (s *OesConfTemplateService) render(
+	ctx context.Context,
+	templateId, colonyId uint32,
+	nodeIds []uint32,
+) (*oesmodel.OesConfTemplateModel, *oesmodel.OesColonyModel, []renderedConf, *errors.Error) {
+	tmpl, rErr := s.FindOesConfTemplateById(ctx, templateId)
+	if rErr != nil {
+		return nil, nil, nil, rErr
+	}
+	colony, nodes, rErr := s.loadColony(ctx, colonyId)
+	if rErr != nil {
+		return nil, nil, nil, rErr
+	}
+	results, rErr := renderNodes(tmpl, colony, nodes, nodeIds)
+	if rErr != nil {
+		return nil, nil, nil, rErr
+	}
+	if len(results) == 0 {
+		return nil, nil, nil, errors.ErrValidationFailed.WithFields(map[string]any{
+			"oes_colony_id": colonyId,
+			"dir_name":      tmpl.DirName,
+		})
+	}
+	return tmpl, colony, results, nil
+}
+
+// RenderOesConfTemplate 预览模板在集群各节点上的渲染结果及与当前文件的差异
+func (s *OesConfTemplateService) RenderOesConfTemplate(
+	ctx context.Context,
+	templateId, colonyId uint32,
+) ([]oesmodel.OesConfRenderOut, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始预览oes配置模板",
+		zap.Uint32("template_id", templateId),
+		zap.Uint32("oes_colony_id", colonyId),
+	)
+
+	_, _, results, rErr := s.render(ctx, templateId, colonyId, nil)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	outs := make([]oesmodel.OesConfRenderOut, 0, len(results))
+	for _, r := range results {
+		current, exists, rErr := s.ucFile.ReadFile(ctx, r.node.HostID, r.remotePath, confTemplateMaxFileSize)
+		if rErr != nil {
+			return nil, rErr
+		}
+		outs = append(outs, oesmodel.OesConfRenderOut{
+			NodeID:     r.node.ID,
+			HostID:     r.node.HostID,
+			HostName:   r.node.Host.Name,
+			RemotePath: r.remotePath,
+			Content:    r.content,
+			Exists:     exists,
+			Diff:       textdiff.Unified(r.remotePath, r.remotePath, string(current), r.content, confTemplateDiffContext),
+		})
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"预览oes配置模板成功",
+		zap.Uint32("template_id", templateId),
+		zap.Uint32("oes_colony_id", colonyId),
+		zap.Int("node_count", len(outs)),
+	)
+	return outs, nil
+}
+
+// DeployOesConfTemplate 将渲染后的配置文件下发到集群节点
+//
+// 全部节点渲染成功后才开始下发, 内容未变化的节点不写入, 单个节点失败不影响其他节点
+func (s *OesConfTemplateService) DeployOesConfTemplate(
+	ctx context.Context,
+	templateId, colonyId uint32,
+	nodeIds []uint32,
+	username string,
+) ([]oesmodel.OesConfDeployOut, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始下发oes配置文件",
+		zap.Uint32("template_id", templateId),
+		zap.Uint32("oes_colony_id", colonyId),
+		zap.Uint32s("node_ids", nodeIds),
+	)
+
+	tmpl, colony, results, rErr := s.render(ctx, templateId, colonyId, nodeIds)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	outs := make([]oesmodel.OesConfDeployOut, 0, len(results))
+	for _, r := range results {
+		out := oesmodel.OesConfDeployOut{
+			NodeID:     r.node.ID,
+			HostID:     r.node.HostID,
+			RemotePath: r.remotePath,
+		}
+		if rErr := s.deploy(ctx, tmpl, colony, r, username, &out); rErr != nil {
+			ctxutil.Logger(ctx, s.log).Error(
+				"下发oes配置文件失败",
+				zap.Error(rErr),
+				zap.Uint32("template_id", templateId),
+				zap.Uint32("node_id", r.node.ID),
+				zap.String("path", r.remotePath),
+			)
+			out.Error = rErr.Error()
+		}
+		outs = append(outs, out)
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"下发oes配置文件完成",
+		zap.Uint32("template_id", templateId),
+		zap.Uint32("oes_colony_id", colonyId),
+		zap.Int("node_count", len(outs)),
+	)
+	return outs, nil
+}
+
+// deploy 下发一个节点的配置文件, 内容变化时写入审计记录
+func (s *OesConfTemplateService) deploy(
+	ctx context.Context,
+	tmpl *oesmodel.OesConfTemplateModel,
+	colony *oesmodel.OesColonyModel,
+	r renderedConf,
+	username string,
+	out *oesmodel.OesConfDeployOut,
+) *errors.Error {
+	current, exists, rErr := s.ucFile.ReadFile(ctx, r.node.HostID, r.remotePath, confTemplateMaxFileSize)
+	if rErr != nil {
+		return rErr
+	}
+	if exists && string(current) == r.content {
+		return nil
+	}
+	if rErr := s.ucFile.WriteFile(ctx, r.node.HostID, r.remotePath, []byte(r.content)); rErr != nil {
+		return rErr
+	}
+	out.Changed = true
+
+	var before any
+	if exists {
+		before = map[string]any{"path": r.remotePath, "sha256": confChecksum(current)}
+	}
+	after := map[string]any{
+		"template_id": tmpl.ID,
+		"node_id":     r.node.ID,
+		"host_id":     r.node.HostID,
+		"path":        r.remotePath,
+		"sha256":      confChecksum([]byte(r.content)),
+	}
+	record, err := sysmodel.NewAuditRecord(
+		"oes", oesmodel.ConfTemplateAuditResource, colony.ID, oesmodel.ConfTemplateAuditDeploy,
+		before, after, username, ctxutil.GetTraceID(ctx),
+	)
+	if err == nil {
+		err = s.auditRepo.CreateModel(ctx, record)
+	}
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"记录配置下发审计记录失败",
+			zap.Error(err),
+			zap.Uint32("oes_colony_id", colony.ID),
+			zap.Uint32("node_id", r.node.ID),
+		)
+	}
+	return nil
+}
+
+func confChecksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
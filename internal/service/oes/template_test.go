@@ -0,0 +1,63 @@
+package biz
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	oesmodel "gin-artweb/internal/model/oes"
+)
+
+type ConfTemplateTestSuite struct {
+	suite.Suite
+	vars oesmodel.OesConfTemplateVars
+}
+
+func (suite *ConfTemplateTestSuite) SetupTest() {
+	master := oesmodel.NewOesConfNodeVars(oesmodel.OesNodeModel{NodeRole: "master", HostID: 1})
+	follow := oesmodel.NewOesConfNodeVars(oesmodel.OesNodeModel{NodeRole: "follow", HostID: 2})
+	suite.vars = oesmodel.OesConfTemplateVars{
+		Colony: oesmodel.OesConfColonyVars{ColonyNum: "07", SystemType: "STK"},
+		Node:   master,
+		Nodes:  []oesmodel.OesConfNodeVars{master, follow},
+	}
+}
+
+func (suite *ConfTemplateTestSuite) TestRender() {
+	text := `colony_num = {{ .Colony.ColonyNum }}
+port = {{ add 9000 (mul (int .Colony.ColonyNum) 10) }}
+specdir = {{ .Node.Specdir }}
+{{- range .Nodes }}
+peer = {{ .Specdir }}
+{{- end }}
+`
+	out, err := renderConfTemplate("oes.conf", text, suite.vars)
+	suite.Require().NoError(err)
+	suite.Equal("colony_num = 07\nport = 9070\nspecdir = host_01\npeer = host_01\npeer = host_02\n", out)
+}
+
+func (suite *ConfTemplateTestSuite) TestRenderUnknownField() {
+	_, err := renderConfTemplate("oes.conf", "{{ .Colony.Missing }}", suite.vars)
+	suite.Error(err)
+
+	_, err = renderConfTemplate("oes.conf", "{{ int .Colony.SystemType }}", suite.vars)
+	suite.Error(err, "非数字字符串转换失败时渲染失败")
+}
+
+func (suite *ConfTemplateTestSuite) TestValidate() {
+	svc := &OesConfTemplateService{}
+	suite.Nil(svc.validate(&oesmodel.OesConfTemplateModel{
+		Name:       "oes.conf",
+		RemotePath: "/home/quant/oes_{{ .Colony.ColonyNum }}/oes.conf",
+		Content:    "a = {{ .Node.SSHIP }}",
+	}))
+	suite.NotNil(svc.validate(&oesmodel.OesConfTemplateModel{
+		Name:       "oes.conf",
+		RemotePath: "/home/quant/oes.conf",
+		Content:    "a = {{ .Node.SSHIP ",
+	}))
+}
+
+func TestConfTemplateTestSuite(t *testing.T) {
+	suite.Run(t, new(ConfTemplateTestSuite))
+}
@@ -0,0 +1,416 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	jobsmodel "gin-artweb/internal/model/jobs"
+	oesmodel "gin-artweb/internal/model/oes"
+	oesrepo "gin-artweb/internal/repository/oes"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+	"gin-artweb/pkg/dag"
+	"gin-artweb/pkg/serializer"
+)
+
+const (
+	// 编排步骤的默认超时时间(秒)
+	workflowStepDefaultTimeout = 3600
+	// 脚本执行记录的触发类型
+	workflowTriggerType = "workflow"
+	// 脚本执行成功的状态
+	workflowRecordSuccess = 2
+)
+
+// workflowStepResult 单个步骤的执行结果
+type workflowStepResult struct {
+	name    string
+	success bool
+}
+
+// OesWorkflowService oes日常任务编排服务
+// 按config/workflow.yaml中各系统类型的步骤依赖关系调度内置脚本, 依赖步骤全部成功后才执行下游步骤
+type OesWorkflowService struct {
+	log        *zap.Logger
+	runRepo    *oesrepo.OesWorkflowRunRepo
+	colonyRepo *oesrepo.OesColonyRepo
+	ucRecord   *JobsService
+	confPath   string
+	running    sync.Map
+}
+
+func NewOesWorkflowService(
+	log *zap.Logger,
+	runRepo *oesrepo.OesWorkflowRunRepo,
+	colonyRepo *oesrepo.OesColonyRepo,
+	ucRecord *JobsService,
+) *OesWorkflowService {
+	return &OesWorkflowService{
+		log:        log,
+		runRepo:    runRepo,
+		colonyRepo: colonyRepo,
+		ucRecord:   ucRecord,
+		confPath:   filepath.Join(config.ConfigDir, "workflow.yaml"),
+	}
+}
+
+// LoadWorkflowDefinition 加载系统类型对应的编排定义并校验依赖关系
+// 每次调用都重新读取配置文件, 修改配置后对新发起的编排立即生效
+func (s *OesWorkflowService) LoadWorkflowDefinition(
+	ctx context.Context,
+	systemType string,
+) ([]oesmodel.OesWorkflowStepConf, *dag.Graph, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, nil, errors.FromError(ctx.Err())
+	}
+
+	if _, err := os.Stat(s.confPath); os.IsNotExist(err) {
+		ctxutil.Logger(ctx, s.log).Error(
+			"oes任务编排配置文件不存在",
+			zap.String("path", s.confPath),
+		)
+		return nil, nil, errors.ErrWorkflowNotDefined.WithField("system_type", systemType)
+	}
+
+	var conf oesmodel.OesWorkflowConf
+	if _, err := serializer.ReadYAML(s.confPath, &conf, serializer.WithContext(ctx)); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"读取oes任务编排配置文件失败",
+			zap.Error(err),
+			zap.String("path", s.confPath),
+		)
+		return nil, nil, errors.ErrWorkflowInvalid.WithCause(err)
+	}
+
+	steps := conf[strings.ToLower(systemType)]
+	if len(steps) == 0 {
+		return nil, nil, errors.ErrWorkflowNotDefined.WithField("system_type", systemType)
+	}
+
+	nodes := make(map[string][]string, len(steps))
+	for _, step := range steps {
+		if step.Script == "" {
+			return nil, nil, errors.ErrWorkflowInvalid.WithField("step", step.Name)
+		}
+		if _, ok := nodes[step.Name]; ok {
+			return nil, nil, errors.ErrWorkflowInvalid.WithField("duplicate_step", step.Name)
+		}
+		nodes[step.Name] = step.DependsOn
+	}
+	graph, err := dag.New(nodes)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"oes任务编排依赖关系无效",
+			zap.Error(err),
+			zap.String("system_type", systemType),
+		)
+		return nil, nil, errors.ErrWorkflowInvalid.WithCause(err)
+	}
+	return steps, graph, nil
+}
+
+// StartWorkflow 为集群发起一次日常任务编排, 编排在后台执行
+func (s *OesWorkflowService) StartWorkflow(
+	ctx context.Context,
+	oesColonyID uint32,
+	username string,
+) (*oesmodel.OesWorkflowRunModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始发起oes任务编排",
+		zap.Uint32("oes_colony_id", oesColonyID),
+	)
+
+	colony, err := s.colonyRepo.GetModel(ctx, nil, oesColonyID)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询oes集群失败",
+			zap.Error(err),
+			zap.Uint32("oes_colony_id", oesColonyID),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"id": oesColonyID})
+	}
+
+	steps, graph, rErr := s.LoadWorkflowDefinition(ctx, colony.SystemType)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	names := make([]string, 0, len(steps))
+	for _, step := range steps {
+		names = append(names, step.Script)
+	}
+	scripts, rErr := s.ucRecord.FindCmdScripts(ctx, names)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	stepConfs := make(map[string]oesmodel.OesWorkflowStepConf, len(steps))
+	for _, step := range steps {
+		stepConfs[step.Name] = step
+	}
+	run := &oesmodel.OesWorkflowRunModel{
+		OesColonyID: colony.ID,
+		ColonyNum:   colony.ColonyNum,
+		SystemType:  colony.SystemType,
+		Status:      oesmodel.WorkflowStatusPending,
+		Username:    username,
+	}
+	for i, name := range graph.Order() {
+		step := stepConfs[name]
+		script, ok := scripts[step.Script]
+		if !ok {
+			return nil, errors.ErrScriptNotFound.WithField("script", step.Script)
+		}
+		timeout := step.Timeout
+		if timeout <= 0 {
+			timeout = workflowStepDefaultTimeout
+		}
+		run.Steps = append(run.Steps, oesmodel.OesWorkflowRunStepModel{
+			Name:      name,
+			ScriptID:  script.ID,
+			DependsOn: strings.Join(graph.Deps(name), ","),
+			Timeout:   timeout,
+			Sort:      i,
+			Status:    oesmodel.WorkflowStatusPending,
+		})
+	}
+
+	// 同一集群同时只允许一个编排执行
+	if _, loaded := s.running.LoadOrStore(colony.ID, struct{}{}); loaded {
+		return nil, errors.ErrWorkflowRunning.WithField("oes_colony_id", colony.ID)
+	}
+
+	if err := s.runRepo.CreateModel(ctx, run); err != nil {
+		s.running.Delete(colony.ID)
+		ctxutil.Logger(ctx, s.log).Error(
+			"创建oes任务编排执行记录失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, run),
+		)
+		return nil, errors.NewGormError(err, nil)
+	}
+
+	go s.runWorkflow(*run, graph)
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"发起oes任务编排成功",
+		zap.Uint32("oes_colony_id", oesColonyID),
+		zap.Uint32("workflow_run_id", run.ID),
+	)
+	return run, nil
+}
+
+// FindWorkflowRunByID 查询编排执行详情
+func (s *OesWorkflowService) FindWorkflowRunByID(
+	ctx context.Context,
+	runID uint32,
+) (*oesmodel.OesWorkflowRunModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	m, err := s.runRepo.GetModel(ctx, []string{"Steps"}, runID)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询oes任务编排执行记录失败",
+			zap.Error(err),
+			zap.Uint32("workflow_run_id", runID),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"id": runID})
+	}
+	return m, nil
+}
+
+// ListWorkflowRun 查询编排执行记录列表
+func (s *OesWorkflowService) ListWorkflowRun(
+	ctx context.Context,
+	qp database.QueryParams,
+) (int64, *[]oesmodel.OesWorkflowRunModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return 0, nil, errors.FromError(ctx.Err())
+	}
+
+	count, ms, err := s.runRepo.ListModel(ctx, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询oes任务编排执行记录列表失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		return 0, nil, errors.NewGormError(err, nil)
+	}
+	return count, ms, nil
+}
+
+// runWorkflow 按依赖关系调度编排中的步骤, 无依赖关系的步骤并行执行
+func (s *OesWorkflowService) runWorkflow(run oesmodel.OesWorkflowRunModel, graph *dag.Graph) {
+	ctx := context.Background()
+	defer s.running.Delete(run.OesColonyID)
+
+	defer func() {
+		if r := recover(); r != nil {
+			s.log.Error(
+				"oes任务编排发生panic",
+				zap.Any("panic", r),
+				zap.String("stack", string(debug.Stack())),
+				zap.Uint32("workflow_run_id", run.ID),
+			)
+			s.updateRun(ctx, run.ID, map[string]any{
+				"status":      oesmodel.WorkflowStatusFailed,
+				"finished_at": time.Now(),
+			})
+		}
+	}()
+
+	steps := make(map[string]oesmodel.OesWorkflowRunStepModel, len(run.Steps))
+	for _, step := range run.Steps {
+		steps[step.Name] = step
+	}
+
+	s.updateRun(ctx, run.ID, map[string]any{
+		"status":     oesmodel.WorkflowStatusRunning,
+		"started_at": time.Now(),
+	})
+
+	done := make(map[string]bool, len(steps))
+	started := make(map[string]bool, len(steps))
+	results := make(chan workflowStepResult)
+	inflight := 0
+	failed := false
+	for {
+		for _, name := range graph.Ready(done, started) {
+			started[name] = true
+			inflight++
+			go func(step oesmodel.OesWorkflowRunStepModel) {
+				results <- workflowStepResult{name: step.Name, success: s.runStep(ctx, run, step)}
+			}(steps[name])
+		}
+		if inflight == 0 {
+			break
+		}
+
+		r := <-results
+		inflight--
+		if r.success {
+			done[r.name] = true
+			continue
+		}
+
+		// 步骤失败后跳过全部下游步骤
+		failed = true
+		for _, name := range graph.Downstream(r.name) {
+			if started[name] {
+				continue
+			}
+			started[name] = true
+			s.updateStep(ctx, run.ID, name, map[string]any{
+				"status":        oesmodel.WorkflowStatusSkipped,
+				"error_message": fmt.Sprintf("依赖的步骤%s执行失败", r.name),
+			})
+		}
+	}
+
+	status := oesmodel.WorkflowStatusSuccess
+	if failed {
+		status = oesmodel.WorkflowStatusFailed
+	}
+	s.updateRun(ctx, run.ID, map[string]any{
+		"status":      status,
+		"finished_at": time.Now(),
+	})
+	s.log.Info(
+		"oes任务编排执行结束",
+		zap.Uint32("workflow_run_id", run.ID),
+		zap.String("colony_num", run.ColonyNum),
+		zap.String("status", status),
+	)
+}
+
+// runStep 执行单个步骤并记录执行状态, 返回是否执行成功
+func (s *OesWorkflowService) runStep(
+	ctx context.Context,
+	run oesmodel.OesWorkflowRunModel,
+	step oesmodel.OesWorkflowRunStepModel,
+) bool {
+	s.updateStep(ctx, run.ID, step.Name, map[string]any{
+		"status":     oesmodel.WorkflowStatusRunning,
+		"started_at": time.Now(),
+	})
+
+	record, rErr := s.ucRecord.CreateRecord(ctx, jobsmodel.ExecuteRequest{
+		TriggerType: workflowTriggerType,
+		ScriptID:    step.ScriptID,
+		CommandArgs: run.ColonyNum,
+		EnvVars:     "{}",
+		Timeout:     step.Timeout,
+		Username:    run.Username,
+	})
+	if rErr != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"创建oes任务编排步骤的执行记录失败",
+			zap.Error(rErr),
+			zap.Uint32("workflow_run_id", run.ID),
+			zap.String("step", step.Name),
+		)
+		s.updateStep(ctx, run.ID, step.Name, map[string]any{
+			"status":        oesmodel.WorkflowStatusFailed,
+			"error_message": rErr.Msg,
+			"finished_at":   time.Now(),
+		})
+		return false
+	}
+	s.updateStep(ctx, run.ID, step.Name, map[string]any{"record_id": record.ID})
+
+	taskinfo := s.ucRecord.ExecuteRecord(record)
+	success := taskinfo.Status == workflowRecordSuccess
+	data := map[string]any{
+		"status":      oesmodel.WorkflowStatusSuccess,
+		"finished_at": time.Now(),
+	}
+	if !success {
+		data["status"] = oesmodel.WorkflowStatusFailed
+		data["error_message"] = taskinfo.ErrMSG
+		if taskinfo.ErrMSG == "" && taskinfo.Error != nil {
+			data["error_message"] = taskinfo.Error.Error()
+		}
+	}
+	s.updateStep(ctx, run.ID, step.Name, data)
+	return success
+}
+
+func (s *OesWorkflowService) updateRun(ctx context.Context, runID uint32, data map[string]any) {
+	if err := s.runRepo.UpdateModel(ctx, data, "id = ?", runID); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"更新oes任务编排执行记录失败",
+			zap.Error(err),
+			zap.Uint32("workflow_run_id", runID),
+			zap.Any(database.UpdateDataKey, data),
+		)
+	}
+}
+
+func (s *OesWorkflowService) updateStep(ctx context.Context, runID uint32, name string, data map[string]any) {
+	if err := s.runRepo.UpdateStepModel(ctx, data, "run_id = ? AND name = ?", runID, name); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"更新oes任务编排步骤失败",
+			zap.Error(err),
+			zap.Uint32("workflow_run_id", runID),
+			zap.String("step", name),
+			zap.Any(database.UpdateDataKey, data),
+		)
+	}
+}
@@ -0,0 +1,277 @@
+package resource
+
+import (
+	"cmp"
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	resomodel "gin-artweb/internal/model/resource"
+	resorepo "gin-artweb/internal/repository/resource"
+	syssvc "gin-artweb/internal/service/system"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+)
+
+// HostAgentService 主机代理服务
+//
+// 代理注册时上报主机信息并自动创建或更新主机, 之后定时上报心跳;
+// 超过失联时间未上报心跳的主机标记为离线并发出告警, 恢复心跳后立即标记为在线
+type HostAgentService struct {
+	log         *zap.Logger
+	agentRepo   *resorepo.HostAgentRepo
+	hostService *HostService
+	alerts      *syssvc.AlertPublisher
+	conf        config.AgentConfig
+	recorder    HostMetricRecorder
+}
+
+// HostMetricRecorder 保存代理随心跳上报的主机指标, 由mon模块注册
+type HostMetricRecorder interface {
+	RecordHostMetric(ctx context.Context, hostID uint32, metrics resomodel.HostAgentMetrics, at time.Time) *errors.Error
+}
+
+func NewHostAgentService(
+	log *zap.Logger,
+	agentRepo *resorepo.HostAgentRepo,
+	hostService *HostService,
+	alerts *syssvc.AlertPublisher,
+	conf *config.AgentConfig,
+) *HostAgentService {
+	var c config.AgentConfig
+	if conf != nil {
+		c = *conf
+	}
+	c.StaleSeconds = cmp.Or(c.StaleSeconds, 180)
+	c.SSHPort = cmp.Or(c.SSHPort, 22)
+	c.SSHUser = cmp.Or(c.SSHUser, "root")
+	c.Label = cmp.Or(c.Label, "agent")
+	return &HostAgentService{
+		log:         log,
+		agentRepo:   agentRepo,
+		hostService: hostService,
+		alerts:      alerts,
+		conf:        c,
+	}
+}
+
+// RegisterMetricRecorder 注册主机指标的保存方式, 未注册时忽略心跳中的指标, 需要在开始处理请求前调用
+func (s *HostAgentService) RegisterMetricRecorder(recorder HostMetricRecorder) {
+	s.recorder = recorder
+}
+
+// Register 注册主机代理, 返回的代理记录包含关联的主机, 代理使用其中的主机ID上报心跳
+func (s *HostAgentService) Register(
+	ctx context.Context,
+	req resomodel.RegisterHostAgentRequest,
+	clientIP string,
+) (*resomodel.HostAgentModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	host := resomodel.HostModel{
+		Name:    req.Hostname,
+		Label:   s.conf.Label,
+		SSHIP:   cmp.Or(req.SSHIP, clientIP),
+		SSHPort: cmp.Or(req.SSHPort, s.conf.SSHPort),
+		SSHUser: cmp.Or(req.SSHUser, s.conf.SSHUser),
+		Remark:  "主机代理自动注册",
+	}
+	m := resomodel.HostAgentModel{
+		Hostname:        req.Hostname,
+		Serial:          req.Serial,
+		OS:              req.OS,
+		Kernel:          req.Kernel,
+		Arch:            req.Arch,
+		CPUCores:        req.CPUCores,
+		MemoryMB:        req.MemoryMB,
+		Disks:           req.Disks,
+		IPs:             req.IPs,
+		AgentVersion:    req.AgentVersion,
+		Status:          resomodel.HostAgentOnline,
+		LastHeartbeatAt: time.Now(),
+	}
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始注册主机代理",
+		zap.Object(database.ModelKey, &host),
+		zap.String("agent_version", req.AgentVersion),
+	)
+
+	agent, created, err := s.agentRepo.Register(ctx, host, m)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"注册主机代理失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, &host),
+		)
+		return nil, errors.NewGormError(err, map[string]any{
+			"name":     host.Name,
+			"ssh_ip":   host.SSHIP,
+			"ssh_port": host.SSHPort,
+			"ssh_user": host.SSHUser,
+		})
+	}
+	if created {
+		// 新建的主机与接口创建的主机一样导出ansible主机变量
+		if rErr := s.hostService.ExportHost(ctx, agent.Host); rErr != nil {
+			return nil, rErr
+		}
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"注册主机代理成功",
+		zap.Uint32("host_id", agent.HostID),
+		zap.Bool("created", created),
+	)
+	return agent, nil
+}
+
+// Heartbeat 更新主机代理的最近心跳时间, 离线的主机恢复为在线, 上报了主机指标时一并保存
+func (s *HostAgentService) Heartbeat(
+	ctx context.Context,
+	hostID uint32,
+	metrics *resomodel.HostAgentMetrics,
+) *errors.Error {
+	if ctx.Err() != nil {
+		return errors.FromError(ctx.Err())
+	}
+
+	agent, err := s.agentRepo.GetModel(ctx, []string{"Host"}, "host_id = ?", hostID)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询主机代理失败",
+			zap.Error(err),
+			zap.Uint32("host_id", hostID),
+		)
+		return errors.NewGormError(err, map[string]any{"host_id": hostID})
+	}
+
+	now := time.Now()
+	if metrics != nil && s.recorder != nil {
+		// 指标保存失败不影响心跳
+		if rErr := s.recorder.RecordHostMetric(ctx, hostID, *metrics, now); rErr != nil {
+			ctxutil.Logger(ctx, s.log).Error(
+				"保存主机代理上报的指标失败",
+				zap.Error(rErr),
+				zap.Uint32("host_id", hostID),
+			)
+		}
+	}
+	if agent.Status == resomodel.HostAgentOffline {
+		ok, err := s.agentRepo.UpdateStatus(ctx, agent.ID, resomodel.HostAgentOffline, map[string]any{
+			"status":            resomodel.HostAgentOnline,
+			"last_heartbeat_at": now,
+		})
+		if err != nil {
+			return errors.NewGormError(err, map[string]any{"host_id": hostID})
+		}
+		if ok {
+			s.notifyStatusChange(ctx, agent, resomodel.HostAgentOnline, now)
+			return nil
+		}
+	}
+	data := map[string]any{"last_heartbeat_at": now}
+	if err := s.agentRepo.UpdateModel(ctx, data, "id = ?", agent.ID); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"更新主机代理心跳失败",
+			zap.Error(err),
+			zap.Uint32("host_id", hostID),
+		)
+		return errors.NewGormError(err, data)
+	}
+	return nil
+}
+
+func (s *HostAgentService) ListHostAgent(
+	ctx context.Context,
+	qp database.QueryParams,
+) (int64, *[]resomodel.HostAgentModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return 0, nil, errors.FromError(ctx.Err())
+	}
+
+	count, ms, err := s.agentRepo.ListModel(ctx, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询主机代理列表失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		return 0, nil, errors.NewGormError(err, nil)
+	}
+	return count, ms, nil
+}
+
+// CheckStale 将超过失联时间未上报心跳的在线主机标记为离线并发出告警
+func (s *HostAgentService) CheckStale(ctx context.Context) *errors.Error {
+	if ctx.Err() != nil {
+		return errors.FromError(ctx.Err())
+	}
+
+	now := time.Now()
+	cutoff := now.Add(-time.Duration(s.conf.StaleSeconds) * time.Second)
+	_, ms, rErr := s.ListHostAgent(ctx, database.QueryParams{
+		Preloads: []string{"Host"},
+		Query: map[string]any{
+			"status = ?":            resomodel.HostAgentOnline,
+			"last_heartbeat_at < ?": cutoff,
+		},
+		OrderBy: []string{"id ASC"},
+	})
+	if rErr != nil {
+		return rErr
+	}
+	for _, m := range *ms {
+		ok, err := s.agentRepo.UpdateStatus(ctx, m.ID, resomodel.HostAgentOnline, map[string]any{
+			"status": resomodel.HostAgentOffline,
+		})
+		if err != nil || !ok {
+			// 其他实例已标记或主机已恢复心跳
+			continue
+		}
+		s.notifyStatusChange(ctx, &m, resomodel.HostAgentOffline, now)
+	}
+	return nil
+}
+
+// notifyStatusChange 发出主机代理在线状态变化告警, 处于全局维护窗口内时屏蔽告警并记录审计
+func (s *HostAgentService) notifyStatusChange(
+	ctx context.Context,
+	m *resomodel.HostAgentModel,
+	status string,
+	now time.Time,
+) {
+	ctxutil.Logger(ctx, s.log).Info(
+		"主机代理在线状态变化",
+		zap.Uint32("host_id", m.HostID),
+		zap.String("from", m.Status),
+		zap.String("to", status),
+		zap.Time("last_heartbeat_at", m.LastHeartbeatAt),
+	)
+	s.alerts.Publish(ctx, syssvc.Alert{
+		Kind:     resomodel.AlertKindHostAgent,
+		Status:   status,
+		Scope:    syssvc.AlertScopeGlobal,
+		TargetID: m.HostID,
+		At:       now,
+		Event: resomodel.HostAgentStatusEvent{
+			Kind:            resomodel.AlertKindHostAgent,
+			HostID:          m.HostID,
+			HostName:        m.Host.Name,
+			SSHIP:           m.Host.SSHIP,
+			From:            m.Status,
+			To:              status,
+			LastHeartbeatAt: m.LastHeartbeatAt.Format(time.DateTime),
+			CheckedAt:       now.Format(time.DateTime),
+		},
+		Detail: map[string]any{
+			"host_id": m.HostID,
+			"from":    m.Status,
+			"to":      status,
+		},
+	})
+}
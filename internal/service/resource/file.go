@@ -0,0 +1,686 @@
+package resource
+
+import (
+	"context"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	emperror "emperror.dev/errors"
+	"github.com/casbin/casbin/v2"
+	"github.com/pkg/sftp"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/ssh"
+
+	resomodel "gin-artweb/internal/model/resource"
+	sysmodel "gin-artweb/internal/model/system"
+	resorepo "gin-artweb/internal/repository/resource"
+	sysrepo "gin-artweb/internal/repository/system"
+	"gin-artweb/internal/shared/auth"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+)
+
+// HostFileService 主机文件管理服务
+//
+// 通过SFTP浏览、下载和上传资源主机上的文件, 访问范围由用户有效角色的主机路径规则限定,
+// 路径中的符号链接解析后仍须在允许的目录内
+type HostFileService struct {
+	log        *zap.Logger
+	hostRepo   *resorepo.HostRepo
+	ruleRepo   *resorepo.HostPathRuleRepo
+	enforcer   *casbin.Enforcer
+	auditRepo  *sysrepo.AuditRecordRepo
+	sshTimeout time.Duration
+	authMethod ssh.AuthMethod
+}
+
+func NewHostFileService(
+	log *zap.Logger,
+	hostRepo *resorepo.HostRepo,
+	ruleRepo *resorepo.HostPathRuleRepo,
+	enforcer *casbin.Enforcer,
+	auditRepo *sysrepo.AuditRecordRepo,
+	sshTimeout time.Duration,
+	authMethod ssh.AuthMethod,
+) *HostFileService {
+	return &HostFileService{
+		log:        log,
+		hostRepo:   hostRepo,
+		ruleRepo:   ruleRepo,
+		enforcer:   enforcer,
+		auditRepo:  auditRepo,
+		sshTimeout: sshTimeout,
+		authMethod: authMethod,
+	}
+}
+
+func (s *HostFileService) CreateHostPathRule(
+	ctx context.Context,
+	m resomodel.HostPathRuleModel,
+) (*resomodel.HostPathRuleModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	m.Path = path.Clean(m.Path)
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始创建主机路径规则",
+		zap.Object(database.ModelKey, &m),
+	)
+
+	if err := s.ruleRepo.CreateModel(ctx, &m); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"创建主机路径规则失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, &m),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"role_id": m.RoleID, "path": m.Path})
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"创建主机路径规则成功",
+		zap.Object(database.ModelKey, &m),
+	)
+	return &m, nil
+}
+
+func (s *HostFileService) UpdateHostPathRuleById(
+	ctx context.Context,
+	m resomodel.HostPathRuleModel,
+) (*resomodel.HostPathRuleModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	m.Path = path.Clean(m.Path)
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始更新主机路径规则",
+		zap.Object(database.ModelKey, &m),
+	)
+
+	data := map[string]any{
+		"role_id":  m.RoleID,
+		"path":     m.Path,
+		"writable": m.Writable,
+		"remark":   m.Remark,
+	}
+	if err := s.ruleRepo.UpdateModel(ctx, data, "id = ?", m.ID); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"更新主机路径规则失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, &m),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"id": m.ID})
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"更新主机路径规则成功",
+		zap.Object(database.ModelKey, &m),
+	)
+	return s.FindHostPathRuleById(ctx, m.ID)
+}
+
+func (s *HostFileService) DeleteHostPathRuleById(
+	ctx context.Context,
+	ruleId uint32,
+) *errors.Error {
+	if ctx.Err() != nil {
+		return errors.FromError(ctx.Err())
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始删除主机路径规则",
+		zap.Uint32("rule_id", ruleId),
+	)
+
+	if err := s.ruleRepo.DeleteModel(ctx, ruleId); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"删除主机路径规则失败",
+			zap.Error(err),
+			zap.Uint32("rule_id", ruleId),
+		)
+		return errors.NewGormError(err, map[string]any{"id": ruleId})
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"删除主机路径规则成功",
+		zap.Uint32("rule_id", ruleId),
+	)
+	return nil
+}
+
+func (s *HostFileService) FindHostPathRuleById(
+	ctx context.Context,
+	ruleId uint32,
+) (*resomodel.HostPathRuleModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	m, err := s.ruleRepo.GetModel(ctx, ruleId)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询主机路径规则失败",
+			zap.Error(err),
+			zap.Uint32("rule_id", ruleId),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"id": ruleId})
+	}
+	return m, nil
+}
+
+func (s *HostFileService) ListHostPathRule(
+	ctx context.Context,
+	qp database.QueryParams,
+) (int64, *[]resomodel.HostPathRuleModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return 0, nil, errors.FromError(ctx.Err())
+	}
+
+	count, ms, err := s.ruleRepo.ListModel(ctx, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询主机路径规则列表失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		return 0, nil, errors.NewGormError(err, nil)
+	}
+	return count, ms, nil
+}
+
+// hostFileConn 到主机的SFTP连接
+type hostFileConn struct {
+	host *resomodel.HostModel
+	ssh  *ssh.Client
+	sftp *sftp.Client
+}
+
+func (c *hostFileConn) Close() error {
+	c.sftp.Close()
+	return c.ssh.Close()
+}
+
+// connect 使用平台SSH密钥连接主机并打开SFTP会话
+func (s *HostFileService) connect(ctx context.Context, hostId uint32) (*hostFileConn, *errors.Error) {
+	host, err := s.hostRepo.GetModel(ctx, nil, hostId)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询主机失败",
+			zap.Error(err),
+			zap.Uint32("host_id", hostId),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"id": hostId})
+	}
+
+	sshClient, err := s.hostRepo.NewSSHClient(ctx, host.SSHIP, host.SSHPort, host.SSHUser, []ssh.AuthMethod{s.authMethod}, s.sshTimeout)
+	if err != nil {
+		return nil, errors.ErrSSHConnectionFailed.WithCause(err)
+	}
+	sftpClient, err := sftp.NewClient(sshClient)
+	if err != nil {
+		sshClient.Close()
+		ctxutil.Logger(ctx, s.log).Error(
+			"创建sftp会话失败",
+			zap.Error(err),
+			zap.Uint32("host_id", hostId),
+		)
+		return nil, errors.ErrSSHConnectionFailed.WithCause(err)
+	}
+	return &hostFileConn{host: host, ssh: sshClient, sftp: sftpClient}, nil
+}
+
+// pathRules 查询用户有效角色的主机路径规则, 返回有效角色ID和规则
+//
+// 有效角色与接口鉴权相同, 包含直属角色和通过组策略继承的所属用户组的角色
+func (s *HostFileService) pathRules(
+	ctx context.Context,
+	userId, roleId uint32,
+) ([]uint32, []resomodel.HostPathRuleModel, *errors.Error) {
+	roleIds, err := auth.UserRoleIDs(s.enforcer, userId, roleId)
+	if err != nil {
+		return nil, nil, errors.FromError(err)
+	}
+	qp := database.QueryParams{
+		Query: map[string]any{"role_id IN ?": roleIds},
+	}
+	_, rules, err := s.ruleRepo.ListModel(ctx, qp)
+	if err != nil {
+		return nil, nil, errors.NewGormError(err, nil)
+	}
+	return roleIds, *rules, nil
+}
+
+// checkPath 检查用户的有效角色是否允许访问路径, 返回解析符号链接后的绝对路径
+//
+// 先按请求的路径检查, 避免通过不允许访问的路径探测文件是否存在
+func (s *HostFileService) checkPath(
+	ctx context.Context,
+	conn *hostFileConn,
+	userId, roleId uint32,
+	p string,
+	write bool,
+) (string, *errors.Error) {
+	roleIds, rules, rErr := s.pathRules(ctx, userId, roleId)
+	if rErr != nil {
+		return "", rErr
+	}
+	allowed := func(p string) bool {
+		for _, rule := range rules {
+			if (rule.Writable || !write) && rule.Allows(p) {
+				return true
+			}
+		}
+		return false
+	}
+
+	cleaned := path.Clean(p)
+	if !path.IsAbs(cleaned) || !allowed(cleaned) {
+		ctxutil.Logger(ctx, s.log).Warn(
+			"主机路径不在允许访问的范围内",
+			zap.Uint32("user_id", userId),
+			zap.Uint32s("role_ids", roleIds),
+			zap.String("path", p),
+			zap.Bool("write", write),
+		)
+		return "", errors.ErrHostPathForbidden.WithField("path", p)
+	}
+
+	real, err := conn.sftp.RealPath(cleaned)
+	if err != nil {
+		return "", s.fileError(ctx, err, cleaned)
+	}
+	real = path.Clean(real)
+	if !allowed(real) {
+		ctxutil.Logger(ctx, s.log).Warn(
+			"主机路径解析符号链接后不在允许访问的范围内",
+			zap.Uint32("user_id", userId),
+			zap.Uint32s("role_ids", roleIds),
+			zap.String("path", cleaned),
+			zap.String("real_path", real),
+		)
+		return "", errors.ErrHostPathForbidden.WithField("path", p)
+	}
+	return real, nil
+}
+
+// fileError 将SFTP错误转换为业务错误
+func (s *HostFileService) fileError(ctx context.Context, err error, p string) *errors.Error {
+	if emperror.Is(err, os.ErrNotExist) {
+		return errors.ErrHostFileNotFound.WithField("path", p)
+	}
+	ctxutil.Logger(ctx, s.log).Error(
+		"主机文件操作失败",
+		zap.Error(err),
+		zap.String("path", p),
+	)
+	return errors.ErrHostFileOperationFailed.WithCause(err)
+}
+
+// ListHostDir 列出目录内容, 目录在前并按名称排序
+func (s *HostFileService) ListHostDir(
+	ctx context.Context,
+	hostId, userId, roleId uint32,
+	p string,
+) (string, []os.FileInfo, *errors.Error) {
+	if ctx.Err() != nil {
+		return "", nil, errors.FromError(ctx.Err())
+	}
+
+	conn, rErr := s.connect(ctx, hostId)
+	if rErr != nil {
+		return "", nil, rErr
+	}
+	defer conn.Close()
+
+	dir, rErr := s.checkPath(ctx, conn, userId, roleId, p, false)
+	if rErr != nil {
+		return "", nil, rErr
+	}
+	fi, err := conn.sftp.Stat(dir)
+	if err != nil {
+		return "", nil, s.fileError(ctx, err, dir)
+	}
+	if !fi.IsDir() {
+		return "", nil, errors.ErrValidationFailed.WithField("path", p)
+	}
+	fis, err := conn.sftp.ReadDir(dir)
+	if err != nil {
+		return "", nil, s.fileError(ctx, err, dir)
+	}
+	sort.Slice(fis, func(i, j int) bool {
+		if fis[i].IsDir() != fis[j].IsDir() {
+			return fis[i].IsDir()
+		}
+		return fis[i].Name() < fis[j].Name()
+	})
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"查询主机目录成功",
+		zap.Uint32("host_id", hostId),
+		zap.String("path", dir),
+		zap.Int("count", len(fis)),
+	)
+	return dir, fis, nil
+}
+
+// StatHostFile 查询文件或目录信息
+func (s *HostFileService) StatHostFile(
+	ctx context.Context,
+	hostId, userId, roleId uint32,
+	p string,
+) (string, os.FileInfo, *errors.Error) {
+	if ctx.Err() != nil {
+		return "", nil, errors.FromError(ctx.Err())
+	}
+
+	conn, rErr := s.connect(ctx, hostId)
+	if rErr != nil {
+		return "", nil, rErr
+	}
+	defer conn.Close()
+
+	real, rErr := s.checkPath(ctx, conn, userId, roleId, p, false)
+	if rErr != nil {
+		return "", nil, rErr
+	}
+	fi, err := conn.sftp.Stat(real)
+	if err != nil {
+		return "", nil, s.fileError(ctx, err, real)
+	}
+	return real, fi, nil
+}
+
+// hostFileReader 读取完成后关闭文件和SFTP连接
+type hostFileReader struct {
+	*sftp.File
+	conn *hostFileConn
+}
+
+func (r *hostFileReader) Close() error {
+	err := r.File.Close()
+	if cErr := r.conn.Close(); err == nil {
+		err = cErr
+	}
+	return err
+}
+
+// OpenHostFile 打开文件用于下载, 调用方负责关闭返回的ReadCloser
+func (s *HostFileService) OpenHostFile(
+	ctx context.Context,
+	hostId, userId, roleId uint32,
+	p, username string,
+) (io.ReadCloser, os.FileInfo, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, nil, errors.FromError(ctx.Err())
+	}
+
+	conn, rErr := s.connect(ctx, hostId)
+	if rErr != nil {
+		return nil, nil, rErr
+	}
+
+	real, rErr := s.checkPath(ctx, conn, userId, roleId, p, false)
+	if rErr != nil {
+		conn.Close()
+		return nil, nil, rErr
+	}
+	f, err := conn.sftp.Open(real)
+	if err != nil {
+		conn.Close()
+		return nil, nil, s.fileError(ctx, err, real)
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		conn.Close()
+		return nil, nil, s.fileError(ctx, err, real)
+	}
+	if !fi.Mode().IsRegular() {
+		f.Close()
+		conn.Close()
+		return nil, nil, errors.ErrValidationFailed.WithField("path", p)
+	}
+
+	s.audit(ctx, conn.host.ID, resomodel.HostFileAuditDownload, real, fi.Size(), username)
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始下载主机文件",
+		zap.Uint32("host_id", hostId),
+		zap.String("path", real),
+		zap.Int64("size", fi.Size()),
+		zap.String("username", username),
+	)
+	return &hostFileReader{File: f, conn: conn}, fi, nil
+}
+
+// UploadHostFile 上传文件到主机的指定目录, overwrite为false时目标文件已存在返回冲突错误
+func (s *HostFileService) UploadHostFile(
+	ctx context.Context,
+	hostId, userId, roleId uint32,
+	dir, name string,
+	src io.Reader,
+	overwrite bool,
+	username string,
+) (string, os.FileInfo, *errors.Error) {
+	if ctx.Err() != nil {
+		return "", nil, errors.FromError(ctx.Err())
+	}
+
+	name = path.Base(strings.ReplaceAll(name, "\\", "/"))
+	if name == "." || name == ".." || name == "/" {
+		return "", nil, errors.ErrValidationFailed.WithField("filename", name)
+	}
+
+	conn, rErr := s.connect(ctx, hostId)
+	if rErr != nil {
+		return "", nil, rErr
+	}
+	defer conn.Close()
+
+	realDir, rErr := s.checkPath(ctx, conn, userId, roleId, dir, true)
+	if rErr != nil {
+		return "", nil, rErr
+	}
+	target := path.Join(realDir, name)
+	// 目标为已存在的符号链接时, 按链接指向的路径重新检查
+	if fi, err := conn.sftp.Lstat(target); err == nil {
+		if !overwrite {
+			return "", nil, errors.ErrHostFileExists.WithField("path", target)
+		}
+		if fi.Mode()&os.ModeSymlink != 0 {
+			if target, rErr = s.checkPath(ctx, conn, userId, roleId, target, true); rErr != nil {
+				return "", nil, rErr
+			}
+		}
+	}
+
+	flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if !overwrite {
+		flags |= os.O_EXCL
+	}
+	f, err := conn.sftp.OpenFile(target, flags)
+	if err != nil {
+		if emperror.Is(err, os.ErrExist) {
+			return "", nil, errors.ErrHostFileExists.WithField("path", target)
+		}
+		return "", nil, s.fileError(ctx, err, target)
+	}
+	n, err := f.ReadFrom(src)
+	if cErr := f.Close(); err == nil {
+		err = cErr
+	}
+	if err != nil {
+		return "", nil, s.fileError(ctx, err, target)
+	}
+	fi, err := conn.sftp.Stat(target)
+	if err != nil {
+		return "", nil, s.fileError(ctx, err, target)
+	}
+
+	s.audit(ctx, conn.host.ID, resomodel.HostFileAuditUpload, target, n, username)
+	ctxutil.Logger(ctx, s.log).Info(
+		"上传主机文件成功",
+		zap.Uint32("host_id", hostId),
+		zap.String("path", target),
+		zap.Int64("size", n),
+		zap.Bool("overwrite", overwrite),
+		zap.String("username", username),
+	)
+	return target, fi, nil
+}
+
+// ReadFile 读取主机文件内容, 文件不存在时返回nil和false
+//
+// 不检查角色路径规则, 供配置下发等由平台确定路径的功能使用
+func (s *HostFileService) ReadFile(
+	ctx context.Context,
+	hostId uint32,
+	p string,
+	maxSize int64,
+) ([]byte, bool, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, false, errors.FromError(ctx.Err())
+	}
+
+	conn, rErr := s.connect(ctx, hostId)
+	if rErr != nil {
+		return nil, false, rErr
+	}
+	defer conn.Close()
+
+	f, err := conn.sftp.Open(p)
+	if err != nil {
+		if emperror.Is(err, os.ErrNotExist) {
+			return nil, false, nil
+		}
+		return nil, false, s.fileError(ctx, err, p)
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(io.LimitReader(f, maxSize+1))
+	if err != nil {
+		return nil, false, s.fileError(ctx, err, p)
+	}
+	if int64(len(data)) > maxSize {
+		return nil, false, errors.ErrUploadFileTooLarge.WithFields(map[string]any{
+			"path":     p,
+			"max_size": maxSize,
+		})
+	}
+	return data, true, nil
+}
+
+// WriteFile 写入主机文件, 先写入同目录的临时文件再替换, 目标文件已存在时保留其权限
+//
+// 不检查角色路径规则, 供配置下发等由平台确定路径的功能使用
+func (s *HostFileService) WriteFile(
+	ctx context.Context,
+	hostId uint32,
+	p string,
+	data []byte,
+) *errors.Error {
+	if ctx.Err() != nil {
+		return errors.FromError(ctx.Err())
+	}
+
+	conn, rErr := s.connect(ctx, hostId)
+	if rErr != nil {
+		return rErr
+	}
+	defer conn.Close()
+
+	mode := os.FileMode(0o644)
+	if fi, err := conn.sftp.Stat(p); err == nil {
+		mode = fi.Mode().Perm()
+	}
+	if err := conn.sftp.MkdirAll(path.Dir(p)); err != nil {
+		return s.fileError(ctx, err, path.Dir(p))
+	}
+
+	tmp := p + ".artweb-tmp"
+	f, err := conn.sftp.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC)
+	if err != nil {
+		return s.fileError(ctx, err, tmp)
+	}
+	_, err = f.Write(data)
+	if cErr := f.Close(); err == nil {
+		err = cErr
+	}
+	if err == nil {
+		err = conn.sftp.Chmod(tmp, mode)
+	}
+	if err == nil {
+		// posix-rename@openssh.com可以覆盖已存在的文件, 服务端不支持时先删除再重命名
+		if err = conn.sftp.PosixRename(tmp, p); err != nil {
+			conn.sftp.Remove(p)
+			err = conn.sftp.Rename(tmp, p)
+		}
+	}
+	if err != nil {
+		conn.sftp.Remove(tmp)
+		return s.fileError(ctx, err, p)
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"写入主机文件成功",
+		zap.Uint32("host_id", hostId),
+		zap.String("path", p),
+		zap.Int("size", len(data)),
+	)
+	return nil
+}
+
+// WithSFTP 使用平台SSH密钥连接主机, 在同一个SFTP会话中执行fn, fn返回的错误按文件操作错误处理
+//
+// 不检查角色路径规则, 供主机指标采集等由平台确定路径的功能使用
+func (s *HostFileService) WithSFTP(
+	ctx context.Context,
+	hostId uint32,
+	fn func(client *sftp.Client) error,
+) *errors.Error {
+	if ctx.Err() != nil {
+		return errors.FromError(ctx.Err())
+	}
+
+	conn, rErr := s.connect(ctx, hostId)
+	if rErr != nil {
+		return rErr
+	}
+	defer conn.Close()
+
+	if err := fn(conn.sftp); err != nil {
+		return s.fileError(ctx, err, "")
+	}
+	return nil
+}
+
+// audit 记录主机文件下载和上传的审计记录, 审计失败不影响文件操作
+func (s *HostFileService) audit(
+	ctx context.Context,
+	hostId uint32,
+	action, p string,
+	size int64,
+	username string,
+) {
+	record, err := sysmodel.NewAuditRecord(
+		"resource", resomodel.HostFileAuditResource, hostId, action,
+		nil, map[string]any{"path": p, "size": size}, username, ctxutil.GetTraceID(ctx),
+	)
+	if err == nil {
+		err = s.auditRepo.CreateModel(ctx, record)
+	}
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"记录主机文件审计记录失败",
+			zap.Error(err),
+			zap.Uint32("host_id", hostId),
+			zap.String("action", action),
+			zap.String("path", p),
+		)
+	}
+}
@@ -0,0 +1,102 @@
+package resource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/casbin/casbin/v2"
+	"github.com/stretchr/testify/suite"
+
+	resomodel "gin-artweb/internal/model/resource"
+	resorepo "gin-artweb/internal/repository/resource"
+	"gin-artweb/internal/shared/auth"
+	"gin-artweb/internal/shared/errors"
+	"gin-artweb/internal/shared/test"
+)
+
+type HostFileServiceTestSuite struct {
+	suite.Suite
+	svc      *HostFileService
+	enforcer *casbin.Enforcer
+}
+
+func (suite *HostFileServiceTestSuite) SetupTest() {
+	db := test.NewTestGormDBWithConfig(nil)
+	db.AutoMigrate(&resomodel.HostPathRuleModel{})
+	logger := test.NewTestZapLogger()
+	ruleRepo := resorepo.NewHostPathRuleRepo(logger, db, test.NewTestDBTimeouts())
+	enforcer, err := auth.NewCasbinEnforcer()
+	suite.Require().NoError(err)
+	suite.enforcer = enforcer
+	suite.svc = NewHostFileService(logger, nil, ruleRepo, enforcer, nil, 0, nil)
+}
+
+func (suite *HostFileServiceTestSuite) TestRuleAllows() {
+	rule := resomodel.HostPathRuleModel{Path: "/home/quant/data/"}
+	suite.True(rule.Allows("/home/quant/data"))
+	suite.True(rule.Allows("/home/quant/data/counter.csv"))
+	suite.False(rule.Allows("/home/quant/data2"), "前缀相同的兄弟目录不应允许访问")
+	suite.False(rule.Allows("/home/quant"))
+
+	root := resomodel.HostPathRuleModel{Path: "/"}
+	suite.True(root.Allows("/etc/passwd"))
+}
+
+func (suite *HostFileServiceTestSuite) TestCheckPathForbidden() {
+	ctx := context.Background()
+	_, rErr := suite.svc.CreateHostPathRule(ctx, resomodel.HostPathRuleModel{RoleID: 2, Path: "/data/counter/"})
+	suite.Require().Nil(rErr)
+	_, rErr = suite.svc.CreateHostPathRule(ctx, resomodel.HostPathRuleModel{RoleID: 2, Path: "/data/conf", Writable: true})
+	suite.Require().Nil(rErr)
+
+	_, rErr = suite.svc.CreateHostPathRule(ctx, resomodel.HostPathRuleModel{RoleID: 2, Path: "/data/counter"})
+	suite.NotNil(rErr, "清理后相同的路径不能重复配置")
+
+	// 不允许的路径在连接主机前拒绝
+	cases := []struct {
+		roleId uint32
+		path   string
+		write  bool
+	}{
+		{2, "/data/counter/../../etc/passwd", false},
+		{2, "/data/counter2", false},
+		{2, "/data/counter/x.csv", true},
+		{3, "/data/counter", false},
+	}
+	for _, c := range cases {
+		_, rErr := suite.svc.checkPath(ctx, nil, 1, c.roleId, c.path, c.write)
+		suite.Require().NotNil(rErr, c.path)
+		suite.Equal(errors.ErrHostPathForbidden.Reason, rErr.Reason, c.path)
+	}
+}
+
+func (suite *HostFileServiceTestSuite) TestPathRulesFromGroup() {
+	ctx := context.Background()
+	_, rErr := suite.svc.CreateHostPathRule(ctx, resomodel.HostPathRuleModel{RoleID: 2, Path: "/data/counter/"})
+	suite.Require().Nil(rErr)
+	_, rErr = suite.svc.CreateHostPathRule(ctx, resomodel.HostPathRuleModel{RoleID: 4, Path: "/data/other/"})
+	suite.Require().Nil(rErr)
+
+	roleIds, rules, rErr := suite.svc.pathRules(ctx, 5, 3)
+	suite.Require().Nil(rErr)
+	suite.Equal([]uint32{3}, roleIds)
+	suite.Empty(rules, "不在用户组中时只有直属角色的规则")
+
+	// 用户5通过用户组7继承角色2
+	suite.Require().NoError(auth.AddGroupPolicies(ctx, suite.enforcer, [][]string{
+		{auth.UserGroupToSubject(7), auth.RoleToSubject(2)},
+		{auth.UserToSubject(5), auth.UserGroupToSubject(7)},
+	}))
+	roleIds, rules, rErr = suite.svc.pathRules(ctx, 5, 3)
+	suite.Require().Nil(rErr)
+	suite.ElementsMatch([]uint32{3, 2}, roleIds)
+	suite.Require().Len(rules, 1, "包含用户组继承角色的规则")
+	suite.Equal("/data/counter", rules[0].Path)
+
+	_, rErr = suite.svc.checkPath(ctx, nil, 5, 3, "/data/counter/x.csv", true)
+	suite.Equal(errors.ErrHostPathForbidden.Reason, rErr.Reason, "继承的规则同样区分读写")
+}
+
+func TestHostFileServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(HostFileServiceTestSuite))
+}
@@ -1,16 +1,21 @@
 package resource
 
 import (
+	"bytes"
 	"context"
+	"net"
 	"os"
 	"time"
 
+	emperror "emperror.dev/errors"
 	"go.uber.org/zap"
 	"golang.org/x/crypto/ssh"
 
+	commodel "gin-artweb/internal/model/common"
 	resomodel "gin-artweb/internal/model/resource"
 	resorepo "gin-artweb/internal/repository/resource"
 	"gin-artweb/internal/shared/common"
+	"gin-artweb/internal/shared/config"
 	"gin-artweb/internal/shared/ctxutil"
 	"gin-artweb/internal/shared/database"
 	"gin-artweb/internal/shared/errors"
@@ -23,6 +28,7 @@ type HostService struct {
 	sshTimeout time.Duration
 	authMethod ssh.AuthMethod
 	pubKeyB64s []string
+	remoteHost bool
 }
 
 func NewHostService(
@@ -31,6 +37,7 @@ func NewHostService(
 	sshTimeout time.Duration,
 	authMethod ssh.AuthMethod,
 	pubKeyB64s []string,
+	remoteHost bool,
 ) *HostService {
 	return &HostService{
 		log:        log,
@@ -38,6 +45,7 @@ func NewHostService(
 		sshTimeout: sshTimeout,
 		authMethod: authMethod,
 		pubKeyB64s: pubKeyB64s,
+		remoteHost: remoteHost,
 	}
 }
 
@@ -50,22 +58,24 @@ func (s *HostService) CreateHost(
 		return nil, errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始创建主机",
 		zap.Object(database.ModelKey, &m),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
+	if err := s.checkHostAddress(ctx, m.SSHIP); err != nil {
+		return nil, err
+	}
+
 	if err := s.TestSSHConnection(ctx, m.SSHIP, m.SSHPort, m.SSHUser, password); err != nil {
 		return nil, err
 	}
 
 	if err := s.hostRepo.CreateModel(ctx, &m); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"创建主机失败",
 			zap.Error(err),
 			zap.Object(database.ModelKey, &m),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.NewGormError(err, nil)
 	}
@@ -74,10 +84,9 @@ func (s *HostService) CreateHost(
 		return nil, err
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"主机创建成功",
 		zap.Object(database.ModelKey, &m),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return &m, nil
 }
@@ -91,13 +100,25 @@ func (s *HostService) UpdateHostById(
 		return nil, errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始更新主机",
 		zap.Uint32("host_id", m.ID),
 		zap.Object(database.ModelKey, &m),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
+	// 没有查看敏感字段权限时查询接口返回的用户名已掩码, 编辑时回传的掩码保持原值
+	if m.SSHUser == commodel.MaskedValue {
+		om, rErr := s.FindHostById(ctx, m.ID)
+		if rErr != nil {
+			return nil, rErr
+		}
+		m.SSHUser = om.SSHUser
+	}
+
+	if err := s.checkHostAddress(ctx, m.SSHIP); err != nil {
+		return nil, err
+	}
+
 	if err := s.TestSSHConnection(ctx, m.SSHIP, m.SSHPort, m.SSHUser, password); err != nil {
 		return nil, err
 	}
@@ -112,12 +133,11 @@ func (s *HostService) UpdateHostById(
 		"remark":   m.Remark,
 	}
 	if err := s.hostRepo.UpdateModel(ctx, data, "id = ?", m.ID); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"更新主机失败",
 			zap.Error(err),
 			zap.Uint32("host_id", m.ID),
 			zap.Any(database.UpdateDataKey, data),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.NewGormError(err, data)
 	}
@@ -126,10 +146,9 @@ func (s *HostService) UpdateHostById(
 		return nil, err
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"更新主机成功",
 		zap.Object(database.ModelKey, &m),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return s.FindHostById(ctx, m.ID)
 }
@@ -142,38 +161,34 @@ func (s *HostService) DeleteHostById(
 		return errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始删除主机",
 		zap.Uint32("host_id", hostId),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	if err := s.hostRepo.DeleteModel(ctx, hostId); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"删除主机失败",
 			zap.Error(err),
 			zap.Uint32("host_id", hostId),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return errors.NewGormError(err, map[string]any{"id": hostId})
 	}
 
 	path := common.GetHostVarsExportPath(hostId)
 	if err := os.RemoveAll(path); err != nil && !os.IsNotExist(err) {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"删除ansible主机变量文件失败",
 			zap.Error(err),
 			zap.String("path", path),
 			zap.Uint32("host_id", hostId),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return errors.ErrDeleteCacheFileFailed.WithCause(err)
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"删除主机成功",
 		zap.Uint32("host_id", hostId),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return nil
 }
@@ -186,27 +201,24 @@ func (s *HostService) FindHostById(
 		return nil, errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始查询主机",
 		zap.Uint32("host_id", hostId),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	m, err := s.hostRepo.GetModel(ctx, nil, hostId)
 	if err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"查询主机失败",
 			zap.Error(err),
 			zap.Uint32("host_id", hostId),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.NewGormError(err, map[string]any{"id": hostId})
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"查询主机成功",
 		zap.Uint32("host_id", hostId),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return m, nil
 }
@@ -219,31 +231,40 @@ func (s *HostService) ListHost(
 		return 0, nil, errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始查询主机列表",
 		zap.Object(database.QueryParamsKey, &qp),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	count, ms, err := s.hostRepo.ListModel(ctx, qp)
 	if err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"查询主机列表失败",
 			zap.Error(err),
 			zap.Object(database.QueryParamsKey, &qp),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return 0, nil, errors.NewGormError(err, nil)
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"查询主机列表成功",
 		zap.Object(database.QueryParamsKey, &qp),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return count, ms, nil
 }
 
+// checkHostAddress 禁用远程主机管理时(单主机内嵌部署), 只允许登记本机地址
+func (s *HostService) checkHostAddress(ctx context.Context, sshIP string) *errors.Error {
+	if s.remoteHost || isLocalAddress(sshIP) {
+		return nil
+	}
+	ctxutil.Logger(ctx, s.log).Warn(
+		"当前部署模式不支持管理远程主机",
+		zap.String("ssh_ip", sshIP),
+	)
+	return errors.ErrFeatureDisabled.WithField("feature", config.FeatureRemoteHost)
+}
+
 func (s *HostService) TestSSHConnection(
 	ctx context.Context,
 	sshIP string,
@@ -254,22 +275,20 @@ func (s *HostService) TestSSHConnection(
 		return errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始测试ssh连接",
 		zap.String("ssh_ip", sshIP),
 		zap.Uint16("ssh_port", sshPort),
 		zap.String("ssh_user", sshUser),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	cli, err := s.hostRepo.NewSSHClient(ctx, sshIP, sshPort, sshUser, []ssh.AuthMethod{s.authMethod}, s.sshTimeout)
 	if err == nil {
-		s.log.Info(
+		ctxutil.Logger(ctx, s.log).Info(
 			"主机已认证并部署密钥的主机",
 			zap.String("ssh_ip", sshIP),
 			zap.Uint16("ssh_port", sshPort),
 			zap.String("ssh_user", sshUser),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		cli.Close()
 		return nil
@@ -281,13 +300,12 @@ func (s *HostService) TestSSHConnection(
 
 	client, err := s.hostRepo.NewSSHClient(ctx, sshIP, sshPort, sshUser, sshAuths, s.sshTimeout)
 	if err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"创建ssh连接失败",
 			zap.Error(err),
 			zap.String("ssh_ip", sshIP),
 			zap.Uint16("ssh_port", sshPort),
 			zap.String("ssh_user", sshUser),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return errors.ErrSSHConnectionFailed.WithCause(err)
 	}
@@ -295,13 +313,12 @@ func (s *HostService) TestSSHConnection(
 
 	session, err := client.NewSession()
 	if err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"创建ssh session失败",
 			zap.Error(err),
 			zap.String("ssh_ip", sshIP),
 			zap.Uint16("ssh_port", sshPort),
 			zap.String("ssh_user", sshUser),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return errors.ErrSSHConnectionFailed.WithCause(err)
 	}
@@ -320,37 +337,99 @@ func (s *HostService) TestSSHConnection(
 			chmod 600 ~/.ssh/authorized_keys
 		`
 		if err := s.hostRepo.ExecuteCommand(ctx, session, script); err != nil {
-			s.log.Error(
+			ctxutil.Logger(ctx, s.log).Error(
 				"部署ssh公钥失败",
 				zap.Error(err),
 				zap.String("ssh_ip", sshIP),
 				zap.Uint16("ssh_port", sshPort),
 				zap.String("ssh_user", sshUser),
-				zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 			)
 			return errors.ErrSSHKeyDeployFailed.WithCause(err)
 		}
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"测试ssh连接通过",
 		zap.String("ssh_ip", sshIP),
 		zap.Uint16("ssh_port", sshPort),
 		zap.String("ssh_user", sshUser),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return nil
 }
 
+// commandOutputLimit 执行命令时保留的输出长度, 超过的部分丢弃
+const commandOutputLimit = 1024
+
+// limitedBuffer 超过limit的内容丢弃, 不影响被执行命令的写入
+type limitedBuffer struct {
+	buf   bytes.Buffer
+	limit int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	n := len(p)
+	if remain := b.limit - b.buf.Len(); remain < len(p) {
+		p = p[:max(remain, 0)]
+	}
+	b.buf.Write(p)
+	return n, nil
+}
+
+// RunCommand 使用平台SSH密钥在主机上执行命令, 返回退出码和合并的标准输出与标准错误
+//
+// 命令执行完成但退出码非0时不返回错误; 上下文取消时关闭连接中止命令
+func (s *HostService) RunCommand(
+	ctx context.Context,
+	m resomodel.HostModel,
+	command string,
+) (int, string, *errors.Error) {
+	if ctx.Err() != nil {
+		return 0, "", errors.FromError(ctx.Err())
+	}
+
+	client, err := s.hostRepo.NewSSHClient(ctx, m.SSHIP, m.SSHPort, m.SSHUser, []ssh.AuthMethod{s.authMethod}, s.sshTimeout)
+	if err != nil {
+		return 0, "", errors.ErrSSHConnectionFailed.WithCause(err)
+	}
+	defer client.Close()
+	stop := context.AfterFunc(ctx, func() { client.Close() })
+	defer stop()
+
+	session, err := client.NewSession()
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"创建ssh session失败",
+			zap.Error(err),
+			zap.Uint32("host_id", m.ID),
+		)
+		return 0, "", errors.ErrSSHConnectionFailed.WithCause(err)
+	}
+	defer session.Close()
+
+	output := &limitedBuffer{limit: commandOutputLimit}
+	session.Stdout = output
+	session.Stderr = output
+	if err := s.hostRepo.ExecuteCommand(ctx, session, command); err != nil {
+		var exitErr *ssh.ExitError
+		if emperror.As(err, &exitErr) {
+			return exitErr.ExitStatus(), output.buf.String(), nil
+		}
+		if ctx.Err() != nil {
+			return 0, "", errors.FromError(ctx.Err())
+		}
+		return 0, "", errors.ErrSSHConnectionFailed.WithCause(err)
+	}
+	return 0, output.buf.String(), nil
+}
+
 func (s *HostService) ExportHost(ctx context.Context, m resomodel.HostModel) *errors.Error {
 	if ctx.Err() != nil {
 		return errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始导出ansible主机变量文件",
 		zap.Object(database.ModelKey, &m),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	ansibleHost := resomodel.AnsibleHostVars{
@@ -363,21 +442,43 @@ func (s *HostService) ExportHost(ctx context.Context, m resomodel.HostModel) *er
 
 	path := common.GetHostVarsExportPath(m.ID)
 	if _, err := serializer.WriteYAML(path, ansibleHost); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"导出ansible主机变量文件失败",
 			zap.Error(err),
 			zap.String("path", path),
 			zap.Object("ansible_host", &ansibleHost),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return errors.ErrExportCacheFileFailed.WithCause(err)
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"导出ansible主机变量文件成功",
 		zap.String("path", path),
 		zap.Object("ansible_host", &ansibleHost),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return nil
 }
+
+// isLocalAddress 判断地址是否为本机回环地址或本机网卡地址
+func isLocalAddress(addr string) bool {
+	if addr == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+	if ip.IsLoopback() {
+		return true
+	}
+	ifAddrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return false
+	}
+	for _, a := range ifAddrs {
+		if ipNet, ok := a.(*net.IPNet); ok && ipNet.IP.Equal(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,298 @@
+package resource
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"slices"
+	"strings"
+
+	"go.uber.org/zap"
+
+	mdsmodel "gin-artweb/internal/model/mds"
+	monmodel "gin-artweb/internal/model/mon"
+	oesmodel "gin-artweb/internal/model/oes"
+	resomodel "gin-artweb/internal/model/resource"
+	sysmodel "gin-artweb/internal/model/system"
+	resorepo "gin-artweb/internal/repository/resource"
+	sysrepo "gin-artweb/internal/repository/system"
+	"gin-artweb/internal/shared/common"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+)
+
+// hostReferences 引用主机ID的数据表字段, 包括集群节点、进程守护和主机的历史记录
+//
+// 计划任务通过集群号绑定集群, 集群通过节点引用主机, 改写节点后计划任务随之使用保留的主机.
+// 主机代理每台主机只有一条记录, 由仓库单独处理
+var hostReferences = []resomodel.HostReference{
+	{Table: (&oesmodel.OesNodeModel{}).TableName(), Column: "host_id"},
+	{Table: (&mdsmodel.MdsNodeModel{}).TableName(), Column: "host_id"},
+	{Table: (&monmodel.MonNodeModel{}).TableName(), Column: "host_id"},
+	{Table: (&resomodel.WatchdogModel{}).TableName(), Column: "host_id"},
+	{Table: (&resomodel.TerminalSessionModel{}).TableName(), Column: "host_id"},
+	{Table: (&oesmodel.OesLinkModel{}).TableName(), Column: "source_host_id"},
+	{Table: (&oesmodel.OesColonyDriftModel{}).TableName(), Column: "host_id"},
+	{Table: (&oesmodel.OesReconcileFileModel{}).TableName(), Column: "host_id"},
+	{Table: (&mdsmodel.MdsIngestSourceModel{}).TableName(), Column: "host_id"},
+	{Table: (&monmodel.HostMetricModel{}).TableName(), Column: "host_id"},
+}
+
+// hostDuplicateReasons 疑似重复主机的匹配依据, 返回的分组按该顺序排列
+var hostDuplicateReasons = []string{
+	resomodel.HostDuplicateSerial,
+	resomodel.HostDuplicateSSHIP,
+	resomodel.HostDuplicateAgentIP,
+	resomodel.HostDuplicateHostname,
+}
+
+type HostMergeService struct {
+	log       *zap.Logger
+	hostRepo  *resorepo.HostRepo
+	agentRepo *resorepo.HostAgentRepo
+	auditRepo *sysrepo.AuditRecordRepo
+	tx        *database.TxManager
+}
+
+func NewHostMergeService(
+	log *zap.Logger,
+	hostRepo *resorepo.HostRepo,
+	agentRepo *resorepo.HostAgentRepo,
+	auditRepo *sysrepo.AuditRecordRepo,
+	tx *database.TxManager,
+) *HostMergeService {
+	return &HostMergeService{
+		log:       log,
+		hostRepo:  hostRepo,
+		agentRepo: agentRepo,
+		auditRepo: auditRepo,
+		tx:        tx,
+	}
+}
+
+// FindDuplicates 按SSH地址、代理上报的IP地址、主机名和机器序列号查找疑似重复登记的主机
+func (s *HostMergeService) FindDuplicates(ctx context.Context) (*[]resomodel.HostDuplicateGroup, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	_, hosts, err := s.hostRepo.ListModel(ctx, database.QueryParams{OrderBy: []string{"id ASC"}})
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询主机列表失败",
+			zap.Error(err),
+		)
+		return nil, errors.NewGormError(err, nil)
+	}
+	_, agents, err := s.agentRepo.ListModel(ctx, database.QueryParams{})
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询主机代理列表失败",
+			zap.Error(err),
+		)
+		return nil, errors.NewGormError(err, nil)
+	}
+
+	gs := findHostDuplicates(*hosts, *agents)
+	ctxutil.Logger(ctx, s.log).Info(
+		"查询疑似重复主机成功",
+		zap.Int("groups", len(gs)),
+	)
+	return &gs, nil
+}
+
+// findHostDuplicates 按匹配依据和匹配值分组主机, 返回包含两台及以上主机的分组
+func findHostDuplicates(
+	hosts []resomodel.HostModel,
+	agents []resomodel.HostAgentModel,
+) []resomodel.HostDuplicateGroup {
+	byID := make(map[uint32]resomodel.HostModel, len(hosts))
+	for _, h := range hosts {
+		byID[h.ID] = h
+	}
+
+	// 匹配依据 -> 匹配值 -> 主机ID
+	keys := make(map[string]map[string][]uint32, len(hostDuplicateReasons))
+	add := func(reason, value string, hostID uint32) {
+		if value == "" {
+			return
+		}
+		if keys[reason] == nil {
+			keys[reason] = make(map[string][]uint32)
+		}
+		if !slices.Contains(keys[reason][value], hostID) {
+			keys[reason][value] = append(keys[reason][value], hostID)
+		}
+	}
+	for _, h := range hosts {
+		add(resomodel.HostDuplicateSSHIP, h.SSHIP, h.ID)
+		if isDuplicateIP(h.SSHIP) {
+			add(resomodel.HostDuplicateAgentIP, h.SSHIP, h.ID)
+		}
+		add(resomodel.HostDuplicateHostname, strings.ToLower(h.Name), h.ID)
+	}
+	for _, a := range agents {
+		if _, ok := byID[a.HostID]; !ok {
+			continue
+		}
+		add(resomodel.HostDuplicateSerial, a.Serial, a.HostID)
+		add(resomodel.HostDuplicateHostname, strings.ToLower(a.Hostname), a.HostID)
+		for _, ip := range a.IPs {
+			if isDuplicateIP(ip) {
+				add(resomodel.HostDuplicateAgentIP, ip, a.HostID)
+			}
+		}
+	}
+
+	var gs []resomodel.HostDuplicateGroup
+	for _, reason := range hostDuplicateReasons {
+		values := make([]string, 0, len(keys[reason]))
+		for value, ids := range keys[reason] {
+			slices.Sort(ids)
+			if len(ids) > 1 {
+				values = append(values, value)
+			}
+		}
+		slices.Sort(values)
+		for _, value := range values {
+			ids := keys[reason][value]
+			// 只有SSH地址相同的主机已按SSH地址分组
+			if reason == resomodel.HostDuplicateAgentIP && slices.Equal(ids, keys[resomodel.HostDuplicateSSHIP][value]) {
+				continue
+			}
+			g := resomodel.HostDuplicateGroup{Reason: reason, Value: value}
+			for _, id := range ids {
+				g.Hosts = append(g.Hosts, byID[id])
+			}
+			gs = append(gs, g)
+		}
+	}
+	return gs
+}
+
+// isDuplicateIP 回环地址和链路本地地址在每台主机上都相同, 不作为重复主机的匹配依据
+func isDuplicateIP(addr string) bool {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+	return !ip.IsLoopback() && !ip.IsLinkLocalUnicast() && !ip.IsUnspecified()
+}
+
+// MergeHost 将重复主机的引用改为保留的主机并删除重复主机, 合并和审计记录在同一事务中执行
+func (s *HostMergeService) MergeHost(
+	ctx context.Context,
+	hostID, duplicateID uint32,
+	username string,
+) (*resomodel.HostMergeResult, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	if hostID == duplicateID {
+		return nil, errors.ErrValidationFailed.WithField("duplicate_id", duplicateID).WithCause(
+			fmt.Errorf("重复主机不能是保留的主机: %d", duplicateID),
+		)
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始合并主机",
+		zap.Uint32("host_id", hostID),
+		zap.Uint32("duplicate_id", duplicateID),
+	)
+
+	var result *resomodel.HostMergeResult
+	rErr := database.Transactional(ctx, s.tx, func(ctx context.Context) *errors.Error {
+		host, rErr := s.findHost(ctx, hostID)
+		if rErr != nil {
+			return rErr
+		}
+		duplicate, rErr := s.findHost(ctx, duplicateID)
+		if rErr != nil {
+			return rErr
+		}
+
+		counts, err := s.hostRepo.MergeReferences(ctx, duplicateID, hostID, hostReferences)
+		if err != nil {
+			ctxutil.Logger(ctx, s.log).Error(
+				"合并主机引用失败",
+				zap.Error(err),
+				zap.Uint32("host_id", hostID),
+				zap.Uint32("duplicate_id", duplicateID),
+			)
+			return errors.NewGormError(err, nil)
+		}
+		if err := s.hostRepo.DeleteModel(ctx, duplicateID); err != nil {
+			ctxutil.Logger(ctx, s.log).Error(
+				"删除重复主机失败",
+				zap.Error(err),
+				zap.Uint32("duplicate_id", duplicateID),
+			)
+			return errors.NewGormError(err, map[string]any{"id": duplicateID})
+		}
+		result = &resomodel.HostMergeResult{
+			HostID:      hostID,
+			DuplicateID: duplicateID,
+			References:  counts,
+		}
+
+		// 审计记录与合并同时提交, 审计失败时不合并
+		record, err := sysmodel.NewAuditRecord(
+			"resource", resomodel.HostFileAuditResource, hostID, resomodel.HostMergeAuditAction,
+			map[string]any{
+				"host":      resomodel.HostModelToBaseOut(*host),
+				"duplicate": resomodel.HostModelToBaseOut(*duplicate),
+			},
+			resomodel.HostMergeResultToOut(*result), username, ctxutil.GetTraceID(ctx),
+		)
+		if err == nil {
+			err = s.auditRepo.CreateModel(ctx, record)
+		}
+		if err != nil {
+			ctxutil.Logger(ctx, s.log).Error(
+				"记录合并主机审计记录失败",
+				zap.Error(err),
+				zap.Object("result", result),
+			)
+			return errors.NewGormError(err, nil)
+		}
+		return nil
+	}, func(err error) *errors.Error {
+		return errors.NewGormError(err, nil)
+	})
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	path := common.GetHostVarsExportPath(duplicateID)
+	if err := os.RemoveAll(path); err != nil && !os.IsNotExist(err) {
+		// 主机已合并, 残留的变量文件不影响合并结果
+		ctxutil.Logger(ctx, s.log).Warn(
+			"删除重复主机的ansible主机变量文件失败",
+			zap.Error(err),
+			zap.String("path", path),
+			zap.Uint32("duplicate_id", duplicateID),
+		)
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"合并主机成功",
+		zap.Object("result", result),
+	)
+	return result, nil
+}
+
+func (s *HostMergeService) findHost(ctx context.Context, hostID uint32) (*resomodel.HostModel, *errors.Error) {
+	m, err := s.hostRepo.GetModel(ctx, nil, hostID)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询主机失败",
+			zap.Error(err),
+			zap.Uint32("host_id", hostID),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"id": hostID})
+	}
+	return m, nil
+}
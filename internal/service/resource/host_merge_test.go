@@ -0,0 +1,162 @@
+package resource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	"gin-artweb/internal/model"
+	monmodel "gin-artweb/internal/model/mon"
+	resomodel "gin-artweb/internal/model/resource"
+	sysmodel "gin-artweb/internal/model/system"
+	resorepo "gin-artweb/internal/repository/resource"
+	sysrepo "gin-artweb/internal/repository/system"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+	"gin-artweb/internal/shared/test"
+)
+
+type HostMergeServiceTestSuite struct {
+	suite.Suite
+	db       *gorm.DB
+	hostRepo *resorepo.HostRepo
+	svc      *HostMergeService
+}
+
+func (suite *HostMergeServiceTestSuite) SetupTest() {
+	suite.db = test.NewTestGormDBWithConfig(nil)
+	suite.Require().NoError(suite.db.AutoMigrate(model.Models()...))
+	logger := test.NewTestZapLogger()
+	dbTimeout := test.NewTestDBTimeouts()
+	suite.hostRepo = resorepo.NewHostRepo(logger, suite.db, dbTimeout, nil)
+	suite.svc = NewHostMergeService(
+		logger, suite.hostRepo,
+		resorepo.NewHostAgentRepo(logger, suite.db, dbTimeout),
+		sysrepo.NewAuditRecordRepo(logger, suite.db, dbTimeout),
+		database.NewTxManager(suite.db),
+	)
+}
+
+func (suite *HostMergeServiceTestSuite) createHost(name, sshIP string, sshPort uint16) *resomodel.HostModel {
+	m := &resomodel.HostModel{Name: name, SSHIP: sshIP, SSHPort: sshPort, SSHUser: "root"}
+	suite.Require().NoError(suite.hostRepo.CreateModel(context.Background(), m))
+	return m
+}
+
+func (suite *HostMergeServiceTestSuite) createAgent(hostID uint32, hostname, serial string, ips ...string) {
+	suite.Require().NoError(suite.db.Omit("Host").Create(&resomodel.HostAgentModel{
+		HostID:   hostID,
+		Hostname: hostname,
+		Serial:   serial,
+		IPs:      ips,
+		Status:   resomodel.HostAgentOnline,
+	}).Error)
+}
+
+func (suite *HostMergeServiceTestSuite) TestFindDuplicates() {
+	h1 := suite.createHost("node01", "10.0.0.1", 22)
+	h2 := suite.createHost("node01-ssh", "10.0.0.1", 2222)
+	h3 := suite.createHost("db01", "10.0.0.3", 22)
+	h4 := suite.createHost("192.168.0.4", "192.168.0.4", 22)
+	h5 := suite.createHost("web01", "10.0.0.5", 22)
+	suite.createAgent(h3.ID, "db01", "SN-001", "127.0.0.1", "192.168.0.4")
+	suite.createAgent(h5.ID, "NODE01", "SN-001", "127.0.0.1")
+
+	gs, rErr := suite.svc.FindDuplicates(context.Background())
+	suite.Require().Nil(rErr)
+
+	type group struct {
+		reason, value string
+		ids           []uint32
+	}
+	var got []group
+	for _, g := range *gs {
+		var ids []uint32
+		for _, h := range g.Hosts {
+			ids = append(ids, h.ID)
+		}
+		got = append(got, group{g.Reason, g.Value, ids})
+	}
+	suite.Equal([]group{
+		{resomodel.HostDuplicateSerial, "SN-001", []uint32{h3.ID, h5.ID}},
+		{resomodel.HostDuplicateSSHIP, "10.0.0.1", []uint32{h1.ID, h2.ID}},
+		{resomodel.HostDuplicateAgentIP, "192.168.0.4", []uint32{h3.ID, h4.ID}},
+		{resomodel.HostDuplicateHostname, "node01", []uint32{h1.ID, h5.ID}},
+	}, got, "回环地址不作为匹配依据, SSH地址相同的主机不重复按IP地址分组")
+}
+
+func (suite *HostMergeServiceTestSuite) TestMergeHost() {
+	ctx := context.Background()
+	host := suite.createHost("node01", "10.0.0.1", 22)
+	duplicate := suite.createHost("node01-agent", "10.0.0.2", 22)
+	other := suite.createHost("node02", "10.0.0.3", 22)
+	suite.createAgent(duplicate.ID, "node01", "SN-001", "10.0.0.1", "10.0.0.2")
+	suite.Require().NoError(suite.db.Omit("Host").Create(&resomodel.WatchdogModel{
+		Name: "oes", HostID: duplicate.ID, Kind: resomodel.WatchdogKindSystemd, Target: "oes",
+	}).Error)
+	suite.Require().NoError(suite.db.Create(&[]monmodel.HostMetricModel{
+		{HostID: duplicate.ID, Ts: 60},
+		{HostID: other.ID, Ts: 60},
+	}).Error)
+
+	result, rErr := suite.svc.MergeHost(ctx, host.ID, duplicate.ID, "admin")
+	suite.Require().Nil(rErr)
+	suite.Equal(int64(1), result.References["resource_watchdog"])
+	suite.Equal(int64(1), result.References["mon_host_metric"])
+	suite.Equal(int64(1), result.References["resource_host_agent"], "保留的主机没有代理时使用重复主机的代理")
+
+	var n int64
+	suite.db.Model(&resomodel.WatchdogModel{}).Where("host_id = ?", host.ID).Count(&n)
+	suite.Equal(int64(1), n)
+	suite.db.Model(&monmodel.HostMetricModel{}).Where("host_id = ?", other.ID).Count(&n)
+	suite.Equal(int64(1), n, "其他主机的记录不受影响")
+	suite.db.Model(&resomodel.HostModel{}).Where("id = ?", duplicate.ID).Count(&n)
+	suite.Equal(int64(0), n, "重复主机已删除")
+
+	var records []sysmodel.AuditRecordModel
+	suite.Require().NoError(suite.db.Find(&records).Error)
+	suite.Require().Len(records, 1)
+	suite.Equal(host.ID, records[0].ResourceID)
+	suite.Equal(resomodel.HostMergeAuditAction, records[0].Action)
+	suite.Equal("admin", records[0].Username)
+}
+
+func (suite *HostMergeServiceTestSuite) TestMergeHostKeepsAgent() {
+	ctx := context.Background()
+	host := suite.createHost("node01", "10.0.0.1", 22)
+	duplicate := suite.createHost("node01-agent", "10.0.0.2", 22)
+	suite.createAgent(host.ID, "node01", "SN-001")
+	suite.createAgent(duplicate.ID, "node01", "SN-001")
+
+	result, rErr := suite.svc.MergeHost(ctx, host.ID, duplicate.ID, "admin")
+	suite.Require().Nil(rErr)
+	suite.Zero(result.References["resource_host_agent"])
+
+	var agents []resomodel.HostAgentModel
+	suite.Require().NoError(suite.db.Find(&agents).Error)
+	suite.Require().Len(agents, 1, "每台主机只保留一条代理记录")
+	suite.Equal(host.ID, agents[0].HostID)
+}
+
+func (suite *HostMergeServiceTestSuite) TestMergeHostInvalid() {
+	ctx := context.Background()
+	host := suite.createHost("node01", "10.0.0.1", 22)
+
+	_, rErr := suite.svc.MergeHost(ctx, host.ID, host.ID, "admin")
+	suite.Require().NotNil(rErr)
+	suite.Equal(errors.ErrValidationFailed.Reason, rErr.Reason, "不能合并到自身")
+
+	_, rErr = suite.svc.MergeHost(ctx, host.ID, host.ID+1, "admin")
+	suite.Require().NotNil(rErr)
+	suite.Equal(errors.ErrRecordNotFound.Reason, rErr.Reason)
+
+	var n int64
+	suite.db.Model(&sysmodel.AuditRecordModel{}).Count(&n)
+	suite.Zero(n, "合并失败时不记录审计")
+}
+
+func TestHostMergeServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(HostMergeServiceTestSuite))
+}
@@ -2,8 +2,16 @@ package resource
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"io"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
+	emperror "emperror.dev/errors"
 	"go.uber.org/zap"
 
 	resomodel "gin-artweb/internal/model/resource"
@@ -12,20 +20,28 @@ import (
 	"gin-artweb/internal/shared/ctxutil"
 	"gin-artweb/internal/shared/database"
 	"gin-artweb/internal/shared/errors"
+	"gin-artweb/pkg/storage"
 )
 
 type PackageService struct {
-	log     *zap.Logger
-	pkgRepo *resorepo.PackageRepo
+	log            *zap.Logger
+	pkgRepo        *resorepo.PackageRepo
+	store          storage.Storage
+	presignExpires time.Duration
 }
 
+// NewPackageService 创建程序包服务, presignExpires为0时不使用预签名下载地址
 func NewPackageService(
 	log *zap.Logger,
 	pkgRepo *resorepo.PackageRepo,
+	store storage.Storage,
+	presignExpires time.Duration,
 ) *PackageService {
 	return &PackageService{
-		log:     log,
-		pkgRepo: pkgRepo,
+		log:            log,
+		pkgRepo:        pkgRepo,
+		store:          store,
+		presignExpires: presignExpires,
 	}
 }
 
@@ -37,26 +53,23 @@ func (s *PackageService) CreatePackage(
 		return nil, errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始创建程序包",
 		zap.Object(database.ModelKey, &m),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	if err := s.pkgRepo.CreateModel(ctx, &m); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"创建程序包失败",
 			zap.Error(err),
 			zap.Object(database.ModelKey, &m),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.NewGormError(err, nil)
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"创建程序包成功",
 		zap.Object(database.ModelKey, &m),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return &m, nil
 }
@@ -69,10 +82,9 @@ func (s *PackageService) DeletePackageById(
 		return errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始删除程序包",
 		zap.Uint32("package_id", pkgId),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	m, err := s.FindPackageById(ctx, pkgId)
@@ -82,11 +94,10 @@ func (s *PackageService) DeletePackageById(
 
 	// 先从数据库删除
 	if err := s.pkgRepo.DeleteModel(ctx, pkgId); err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"删除程序包失败",
 			zap.Error(err),
 			zap.Uint32("package_id", pkgId),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return errors.NewGormError(err, map[string]any{"id": pkgId})
 	}
@@ -96,10 +107,9 @@ func (s *PackageService) DeletePackageById(
 		return rmErr
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"删除程序包成功",
 		zap.Uint32("package_id", pkgId),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return nil
 }
@@ -112,27 +122,24 @@ func (s *PackageService) FindPackageById(
 		return nil, errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始查询程序包",
 		zap.Uint32("package_id", pkgId),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	m, err := s.pkgRepo.GetModel(ctx, nil, pkgId)
 	if err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"查询程序包失败",
 			zap.Error(err),
 			zap.Uint32("package_id", pkgId),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return nil, errors.NewGormError(err, map[string]any{"id": pkgId})
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"查询程序包成功",
 		zap.Uint32("package_id", pkgId),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return m, nil
 }
@@ -145,27 +152,24 @@ func (s *PackageService) ListPackage(
 		return 0, nil, errors.FromError(ctx.Err())
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始查询程序包列表",
 		zap.Object(database.QueryParamsKey, &qp),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
 	count, ms, err := s.pkgRepo.ListModel(ctx, qp)
 	if err != nil {
-		s.log.Error(
+		ctxutil.Logger(ctx, s.log).Error(
 			"查询程序包列表失败",
 			zap.Error(err),
 			zap.Object(database.QueryParamsKey, &qp),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
 		return 0, nil, errors.NewGormError(err, nil)
 	}
 
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"查询程序包列表成功",
 		zap.Object(database.QueryParamsKey, &qp),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 	return count, ms, nil
 }
@@ -175,51 +179,263 @@ func (s *PackageService) RemovePackage(ctx context.Context, m resomodel.PackageM
 		return errors.FromError(ctx.Err())
 	}
 
-	savePath := common.GetPackageStoragePath(m.StorageFilename)
-
-	s.log.Info(
+	ctxutil.Logger(ctx, s.log).Info(
 		"开始删除程序包文件",
-		zap.String("path", savePath),
+		zap.String("storage", s.store.Type()),
+		zap.String("key", m.StorageFilename),
 		zap.Uint32("package_id", m.ID),
-		zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 	)
 
-	// 检查文件是否存在
-	if _, statErr := os.Stat(savePath); os.IsNotExist(statErr) {
-		// 文件不存在，视为删除成功
-		s.log.Warn(
-			"程序包文件不存在，无需删除",
-			zap.String("path", savePath),
+	// 文件不存在时视为删除成功
+	if err := s.store.Delete(ctx, m.StorageFilename); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"删除程序包文件失败",
+			zap.Error(err),
+			zap.String("storage", s.store.Type()),
+			zap.String("key", m.StorageFilename),
 			zap.Uint32("package_id", m.ID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
-		return nil
-	} else if statErr != nil {
-		// 其他 stat 错误
-		s.log.Error(
-			"检查程序包文件状态失败",
-			zap.Error(statErr),
-			zap.String("path", savePath),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
+		return errors.ErrStorageUnavailable.WithCause(err)
+	}
+
+	// 远程存储时同时清理本地缓存
+	if _, ok := s.store.(storage.LocalPather); !ok {
+		cachePath := common.GetPackageStoragePath(m.StorageFilename)
+		if err := os.Remove(cachePath); err != nil && !os.IsNotExist(err) {
+			ctxutil.Logger(ctx, s.log).Warn(
+				"删除程序包本地缓存失败",
+				zap.Error(err),
+				zap.String("path", cachePath),
+			)
+		}
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"删除程序包文件成功",
+		zap.String("key", m.StorageFilename),
+		zap.Uint32("package_id", m.ID),
+	)
+	return nil
+}
+
+// UploadPackage 将程序包文件写入存储并创建记录
+//
+// 写入时计算SHA256校验和, checksum不为空时与之比对, 不一致时删除已写入的文件
+func (s *PackageService) UploadPackage(
+	ctx context.Context,
+	m resomodel.PackageModel,
+	r io.Reader,
+	size int64,
+	checksum string,
+) (*resomodel.PackageModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始上传程序包文件",
+		zap.Object(database.ModelKey, &m),
+		zap.String("storage", s.store.Type()),
+		zap.Int64("size", size),
+	)
+
+	hr := newHashReader(r)
+	if err := s.store.Put(ctx, m.StorageFilename, hr, size); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"写入程序包文件失败",
+			zap.Error(err),
+			zap.String("storage", s.store.Type()),
+			zap.String("key", m.StorageFilename),
 		)
-		return errors.FromError(statErr)
+		return nil, errors.ErrSaveUploadFileFailed.WithCause(err)
 	}
+	m.Size = hr.n
+	m.Checksum = hr.Sum()
 
-	// 执行删除操作
-	if rmErr := os.Remove(savePath); rmErr != nil {
-		s.log.Error(
-			"删除程序包失败",
-			zap.Error(rmErr),
-			zap.String("path", savePath),
+	if checksum != "" && !strings.EqualFold(checksum, m.Checksum) {
+		ctxutil.Logger(ctx, s.log).Error(
+			"程序包文件校验和不一致",
+			zap.String("expected", checksum),
+			zap.String("actual", m.Checksum),
+		)
+		s.deleteObject(ctx, m.StorageFilename)
+		return nil, errors.ErrFileChecksumMismatch.WithFields(map[string]any{
+			"expected": checksum,
+			"actual":   m.Checksum,
+		})
+	}
+
+	pm, rErr := s.CreatePackage(ctx, m)
+	if rErr != nil {
+		s.deleteObject(ctx, m.StorageFilename)
+		return nil, rErr
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"上传程序包文件成功",
+		zap.Object(database.ModelKey, pm),
+	)
+	return pm, nil
+}
+
+// deleteObject 清理写入失败或无效的文件, 失败时只记录日志
+func (s *PackageService) deleteObject(ctx context.Context, key string) {
+	if err := s.store.Delete(context.WithoutCancel(ctx), key); err != nil {
+		ctxutil.Logger(ctx, s.log).Warn(
+			"清理程序包文件失败",
+			zap.Error(err),
+			zap.String("key", key),
+		)
+	}
+}
+
+// OpenPackage 读取程序包文件, 调用方负责关闭
+func (s *PackageService) OpenPackage(
+	ctx context.Context,
+	m resomodel.PackageModel,
+) (io.ReadCloser, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	rc, err := s.store.Get(ctx, m.StorageFilename)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"读取程序包文件失败",
+			zap.Error(err),
+			zap.String("storage", s.store.Type()),
+			zap.String("key", m.StorageFilename),
 			zap.Uint32("package_id", m.ID),
-			zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(ctx)),
 		)
-		return errors.FromError(rmErr)
+		if emperror.Is(err, storage.ErrNotExist) {
+			return nil, errors.ErrDownloadFileNotFound.WithField("key", m.StorageFilename)
+		}
+		return nil, errors.ErrStorageUnavailable.WithCause(err)
 	}
+	return rc, nil
+}
 
-	s.log.Info(
-		"删除程序包文件成功",
-		zap.String("path", savePath),
-		zap.Uint32("package_id", m.ID))
-	return nil
+// PresignPackage 生成程序包的预签名下载地址, 存储后端不支持或未开启时返回空地址
+func (s *PackageService) PresignPackage(
+	ctx context.Context,
+	m resomodel.PackageModel,
+) (string, time.Time, *errors.Error) {
+	if ctx.Err() != nil {
+		return "", time.Time{}, errors.FromError(ctx.Err())
+	}
+	if s.presignExpires <= 0 {
+		return "", time.Time{}, nil
+	}
+
+	expiresAt := time.Now().Add(s.presignExpires)
+	u, err := storage.PresignGet(ctx, s.store, m.StorageFilename, m.OriginFilename, s.presignExpires)
+	if emperror.Is(err, storage.ErrPresignNotSupported) {
+		return "", time.Time{}, nil
+	}
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"生成程序包预签名下载地址失败",
+			zap.Error(err),
+			zap.String("key", m.StorageFilename),
+			zap.Uint32("package_id", m.ID),
+		)
+		return "", time.Time{}, errors.ErrStorageUnavailable.WithCause(err)
+	}
+	return u, expiresAt, nil
+}
+
+// LocalPackagePath 获取程序包在本地磁盘的路径, 用于解压部署
+//
+// 远程存储时下载到本地缓存目录, 已缓存且校验和一致时直接使用
+func (s *PackageService) LocalPackagePath(
+	ctx context.Context,
+	m resomodel.PackageModel,
+) (string, *errors.Error) {
+	if ctx.Err() != nil {
+		return "", errors.FromError(ctx.Err())
+	}
+	if lp, ok := s.store.(storage.LocalPather); ok {
+		return lp.LocalPath(m.StorageFilename), nil
+	}
+
+	cachePath := common.GetPackageStoragePath(m.StorageFilename)
+	if sum, err := fileChecksum(cachePath); err == nil && (m.Checksum == "" || sum == m.Checksum) {
+		return cachePath, nil
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始下载程序包到本地缓存",
+		zap.String("storage", s.store.Type()),
+		zap.String("key", m.StorageFilename),
+		zap.String("path", cachePath),
+	)
+	rc, rErr := s.OpenPackage(ctx, m)
+	if rErr != nil {
+		return "", rErr
+	}
+	defer rc.Close()
+
+	hr := newHashReader(rc)
+	if err := storage.NewLocalStorage(filepath.Dir(cachePath)).Put(ctx, filepath.Base(cachePath), hr, -1); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"下载程序包到本地缓存失败",
+			zap.Error(err),
+			zap.String("path", cachePath),
+		)
+		return "", errors.ErrDownloadFileFailed.WithCause(err)
+	}
+	if m.Checksum != "" && hr.Sum() != m.Checksum {
+		_ = os.Remove(cachePath)
+		ctxutil.Logger(ctx, s.log).Error(
+			"程序包文件校验和不一致",
+			zap.String("expected", m.Checksum),
+			zap.String("actual", hr.Sum()),
+		)
+		return "", errors.ErrFileChecksumMismatch.WithFields(map[string]any{
+			"expected": m.Checksum,
+			"actual":   hr.Sum(),
+		})
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"下载程序包到本地缓存成功",
+		zap.String("path", cachePath),
+	)
+	return cachePath, nil
+}
+
+// hashReader 读取时计算SHA256校验和和长度
+type hashReader struct {
+	r io.Reader
+	h hash.Hash
+	n int64
+}
+
+func newHashReader(r io.Reader) *hashReader {
+	return &hashReader{r: r, h: sha256.New()}
+}
+
+func (r *hashReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.h.Write(p[:n])
+	r.n += int64(n)
+	return n, err
+}
+
+func (r *hashReader) Sum() string {
+	return hex.EncodeToString(r.h.Sum(nil))
+}
+
+// fileChecksum 计算本地文件的SHA256校验和
+func fileChecksum(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	hr := newHashReader(f)
+	if _, err := io.Copy(io.Discard, hr); err != nil {
+		return "", err
+	}
+	return hr.Sum(), nil
 }
@@ -0,0 +1,141 @@
+package resource
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	resomodel "gin-artweb/internal/model/resource"
+	resorepo "gin-artweb/internal/repository/resource"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/errors"
+	"gin-artweb/internal/shared/test"
+	"gin-artweb/pkg/storage"
+)
+
+// remoteStorage 隐藏本地路径, 模拟远程存储
+type remoteStorage struct {
+	s *storage.LocalStorage
+}
+
+func (r remoteStorage) Type() string { return storage.TypeSFTP }
+
+func (r remoteStorage) Put(ctx context.Context, key string, rd io.Reader, size int64) error {
+	return r.s.Put(ctx, key, rd, size)
+}
+
+func (r remoteStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	return r.s.Get(ctx, key)
+}
+
+func (r remoteStorage) Stat(ctx context.Context, key string) (*storage.ObjectInfo, error) {
+	return r.s.Stat(ctx, key)
+}
+
+func (r remoteStorage) Delete(ctx context.Context, key string) error {
+	return r.s.Delete(ctx, key)
+}
+
+type PackageServiceTestSuite struct {
+	suite.Suite
+	pkgRepo *resorepo.PackageRepo
+	root    string
+}
+
+func (suite *PackageServiceTestSuite) SetupTest() {
+	db := test.NewTestGormDBWithConfig(nil)
+	db.AutoMigrate(&resomodel.PackageModel{})
+	suite.pkgRepo = resorepo.NewPackageRepo(test.NewTestZapLogger(), db, test.NewTestDBTimeouts())
+	suite.root = suite.T().TempDir()
+}
+
+func (suite *PackageServiceTestSuite) newModel(name string) resomodel.PackageModel {
+	return resomodel.PackageModel{
+		Label:           "mds",
+		Version:         name,
+		StorageFilename: name + ".tar.gz",
+		OriginFilename:  "mds.tar.gz",
+	}
+}
+
+func checksumOf(data string) string {
+	sum := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(sum[:])
+}
+
+func (suite *PackageServiceTestSuite) TestUploadPackageChecksum() {
+	store := storage.NewLocalStorage(suite.root)
+	svc := NewPackageService(test.NewTestZapLogger(), suite.pkgRepo, store, 0)
+	ctx := context.Background()
+	data := "package content"
+
+	m, rErr := svc.UploadPackage(ctx, suite.newModel("v1"), strings.NewReader(data), int64(len(data)), checksumOf(data))
+	suite.Nil(rErr, "校验和一致时上传应该成功")
+	suite.Equal(int64(len(data)), m.Size)
+	suite.Equal(checksumOf(data), m.Checksum)
+
+	rc, rErr := svc.OpenPackage(ctx, *m)
+	suite.Nil(rErr)
+	got, _ := io.ReadAll(rc)
+	rc.Close()
+	suite.Equal(data, string(got))
+
+	bad := suite.newModel("v2")
+	_, rErr = svc.UploadPackage(ctx, bad, strings.NewReader(data), int64(len(data)), checksumOf("other"))
+	suite.NotNil(rErr)
+	suite.Equal(errors.ErrFileChecksumMismatch.Reason, rErr.Reason)
+	_, err := store.Stat(ctx, bad.StorageFilename)
+	suite.ErrorIs(err, storage.ErrNotExist, "校验和不一致时应该删除已写入的文件")
+
+	u, _, rErr := svc.PresignPackage(ctx, *m)
+	suite.Nil(rErr)
+	suite.Empty(u, "本地存储不支持预签名地址")
+}
+
+func (suite *PackageServiceTestSuite) TestLocalPackagePathFromRemote() {
+	oldDir := config.StorageDir
+	config.StorageDir = suite.T().TempDir()
+	defer func() { config.StorageDir = oldDir }()
+
+	store := remoteStorage{s: storage.NewLocalStorage(suite.root)}
+	svc := NewPackageService(test.NewTestZapLogger(), suite.pkgRepo, store, 0)
+	ctx := context.Background()
+	data := "remote package"
+
+	m, rErr := svc.UploadPackage(ctx, suite.newModel("v1"), strings.NewReader(data), int64(len(data)), "")
+	suite.Nil(rErr)
+
+	p, rErr := svc.LocalPackagePath(ctx, *m)
+	suite.Nil(rErr, "远程存储时应该下载到本地缓存")
+	got, err := os.ReadFile(p)
+	suite.NoError(err)
+	suite.Equal(data, string(got))
+
+	// 缓存内容被篡改时重新下载
+	suite.NoError(os.WriteFile(p, []byte("tampered"), 0o644))
+	p, rErr = svc.LocalPackagePath(ctx, *m)
+	suite.Nil(rErr)
+	got, _ = os.ReadFile(p)
+	suite.Equal(data, string(got))
+
+	suite.Nil(svc.RemovePackage(ctx, *m))
+	_, err = os.Stat(p)
+	suite.True(os.IsNotExist(err), "删除程序包时应该清理本地缓存")
+}
+
+func (suite *PackageServiceTestSuite) TestOpenMissingPackage() {
+	svc := NewPackageService(test.NewTestZapLogger(), suite.pkgRepo, storage.NewLocalStorage(suite.root), 0)
+	_, rErr := svc.OpenPackage(context.Background(), suite.newModel("missing"))
+	suite.NotNil(rErr)
+	suite.Equal(errors.ErrDownloadFileNotFound.Reason, rErr.Reason)
+}
+
+func TestPackageServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(PackageServiceTestSuite))
+}
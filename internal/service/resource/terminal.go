@@ -0,0 +1,504 @@
+package resource
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/ssh"
+
+	resomodel "gin-artweb/internal/model/resource"
+	sysmodel "gin-artweb/internal/model/system"
+	resorepo "gin-artweb/internal/repository/resource"
+	sysrepo "gin-artweb/internal/repository/system"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+	"gin-artweb/pkg/asciicast"
+)
+
+// FeatureTerminal 网页终端功能名称, 未启用时返回功能禁用错误
+const FeatureTerminal = "terminal"
+
+const (
+	defaultTerminalCols = 80
+	defaultTerminalRows = 24
+)
+
+// TerminalService 网页终端服务, 使用平台SSH密钥登录资源主机并转发终端输入输出
+type TerminalService struct {
+	log         *zap.Logger
+	hostRepo    *resorepo.HostRepo
+	sessionRepo *resorepo.TerminalSessionRepo
+	auditRepo   *sysrepo.AuditRecordRepo
+	sshTimeout  time.Duration
+	authMethod  ssh.AuthMethod
+	enable      bool
+	idleTimeout time.Duration
+	maxDuration time.Duration
+	record      bool
+	recordDir   string
+
+	mu        sync.Mutex
+	terminals map[string]*Terminal
+}
+
+func NewTerminalService(
+	log *zap.Logger,
+	hostRepo *resorepo.HostRepo,
+	sessionRepo *resorepo.TerminalSessionRepo,
+	auditRepo *sysrepo.AuditRecordRepo,
+	sshTimeout time.Duration,
+	authMethod ssh.AuthMethod,
+	conf *config.TerminalConfig,
+	recordDir string,
+) *TerminalService {
+	s := &TerminalService{
+		log:         log,
+		hostRepo:    hostRepo,
+		sessionRepo: sessionRepo,
+		auditRepo:   auditRepo,
+		sshTimeout:  sshTimeout,
+		authMethod:  authMethod,
+		recordDir:   recordDir,
+		terminals:   make(map[string]*Terminal),
+	}
+	if conf != nil {
+		s.enable = conf.Enable
+		s.idleTimeout = time.Duration(conf.IdleTimeout) * time.Second
+		s.maxDuration = time.Duration(conf.MaxDuration) * time.Second
+		s.record = conf.Record
+	}
+	return s
+}
+
+// Terminal 已打开的终端会话
+//
+// Read读取远程终端输出并写入录像, Write发送键盘输入, 会话结束后Done返回的通道关闭
+type Terminal struct {
+	svc     *TerminalService
+	ctx     context.Context
+	model   resomodel.TerminalSessionModel
+	client  *ssh.Client
+	session *ssh.Session
+	stdin   io.WriteCloser
+	stdout  io.Reader
+
+	recFile *os.File
+	rec     *asciicast.Writer
+
+	bytesOut  atomic.Int64
+	idleTimer *time.Timer
+	maxTimer  *time.Timer
+
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+// OpenTerminal 连接主机并打开交互式shell
+func (s *TerminalService) OpenTerminal(
+	ctx context.Context,
+	hostId uint32,
+	cols, rows int,
+	username, clientIP string,
+) (*Terminal, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+	if !s.enable {
+		return nil, errors.ErrFeatureDisabled.WithField("feature", FeatureTerminal)
+	}
+	if cols <= 0 {
+		cols = defaultTerminalCols
+	}
+	if rows <= 0 {
+		rows = defaultTerminalRows
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始打开网页终端",
+		zap.Uint32("host_id", hostId),
+		zap.String("username", username),
+		zap.String("client_ip", clientIP),
+	)
+
+	host, err := s.hostRepo.GetModel(ctx, nil, hostId)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询主机失败",
+			zap.Error(err),
+			zap.Uint32("host_id", hostId),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"id": hostId})
+	}
+
+	client, err := s.hostRepo.NewSSHClient(ctx, host.SSHIP, host.SSHPort, host.SSHUser, []ssh.AuthMethod{s.authMethod}, s.sshTimeout)
+	if err != nil {
+		return nil, errors.ErrSSHConnectionFailed.WithCause(err)
+	}
+
+	t := &Terminal{
+		svc:    s,
+		ctx:    context.WithoutCancel(ctx),
+		client: client,
+		done:   make(chan struct{}),
+		model: resomodel.TerminalSessionModel{
+			SessionID: uuid.NewString(),
+			HostID:    host.ID,
+			HostName:  host.Name,
+			SSHIP:     host.SSHIP,
+			SSHUser:   host.SSHUser,
+			Username:  username,
+			ClientIP:  clientIP,
+			StartedAt: time.Now(),
+		},
+	}
+	if err := t.startShell(cols, rows); err != nil {
+		client.Close()
+		ctxutil.Logger(ctx, s.log).Error(
+			"打开远程shell失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, &t.model),
+		)
+		return nil, errors.ErrSSHConnectionFailed.WithCause(err)
+	}
+
+	if s.record {
+		if err := t.startRecord(cols, rows); err != nil {
+			// 录像失败不影响终端使用, 会话记录中不关联录像文件
+			ctxutil.Logger(ctx, s.log).Error(
+				"创建终端录像失败",
+				zap.Error(err),
+				zap.Object(database.ModelKey, &t.model),
+			)
+		}
+	}
+
+	if err := s.sessionRepo.CreateModel(ctx, &t.model); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"创建终端会话记录失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, &t.model),
+		)
+		t.release()
+		return nil, errors.NewGormError(err, nil)
+	}
+	s.audit(t.ctx, &t.model, resomodel.TerminalAuditOpen)
+
+	s.mu.Lock()
+	s.terminals[t.model.SessionID] = t
+	s.mu.Unlock()
+
+	if s.idleTimeout > 0 {
+		t.idleTimer = time.AfterFunc(s.idleTimeout, func() { t.Close(resomodel.TerminalCloseIdle) })
+	}
+	if s.maxDuration > 0 {
+		t.maxTimer = time.AfterFunc(s.maxDuration, func() { t.Close(resomodel.TerminalCloseDuration) })
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"网页终端已打开",
+		zap.Object(database.ModelKey, &t.model),
+	)
+	return t, nil
+}
+
+func (t *Terminal) startShell(cols, rows int) error {
+	session, err := t.client.NewSession()
+	if err != nil {
+		return err
+	}
+	modes := ssh.TerminalModes{
+		ssh.ECHO:          1,
+		ssh.TTY_OP_ISPEED: 14400,
+		ssh.TTY_OP_OSPEED: 14400,
+	}
+	if err := session.RequestPty("xterm-256color", rows, cols, modes); err != nil {
+		session.Close()
+		return err
+	}
+	stdin, err := session.StdinPipe()
+	if err != nil {
+		session.Close()
+		return err
+	}
+	stdout, err := session.StdoutPipe()
+	if err != nil {
+		session.Close()
+		return err
+	}
+	if err := session.Shell(); err != nil {
+		session.Close()
+		return err
+	}
+	t.session = session
+	t.stdin = stdin
+	t.stdout = stdout
+	return nil
+}
+
+// startRecord 创建录像文件, 只录制终端输出, 键盘输入(包括输入的密码)不会写入录像
+func (t *Terminal) startRecord(cols, rows int) error {
+	if err := os.MkdirAll(t.svc.recordDir, 0o750); err != nil {
+		return err
+	}
+	name := t.model.SessionID + ".cast"
+	f, err := os.OpenFile(filepath.Join(t.svc.recordDir, name), os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0o640)
+	if err != nil {
+		return err
+	}
+	rec, err := asciicast.NewWriter(f, asciicast.Header{
+		Width:     cols,
+		Height:    rows,
+		Timestamp: t.model.StartedAt.Unix(),
+		Title:     t.model.SSHUser + "@" + t.model.HostName,
+		Env:       map[string]string{"TERM": "xterm-256color"},
+	})
+	if err != nil {
+		f.Close()
+		return err
+	}
+	t.recFile = f
+	t.rec = rec
+	t.model.RecordFile = name
+	return nil
+}
+
+// SessionID 返回会话标识
+func (t *Terminal) SessionID() string {
+	return t.model.SessionID
+}
+
+// Read 读取远程终端输出, 远程shell退出后返回io.EOF
+func (t *Terminal) Read(p []byte) (int, error) {
+	n, err := t.stdout.Read(p)
+	if n > 0 {
+		t.bytesOut.Add(int64(n))
+		if t.rec != nil {
+			if rErr := t.rec.Output(p[:n]); rErr != nil {
+				t.svc.log.Warn(
+					"写入终端录像失败",
+					zap.Error(rErr),
+					zap.String("session_id", t.model.SessionID),
+				)
+			}
+		}
+	}
+	return n, err
+}
+
+// Write 发送键盘输入并重新计算空闲时间
+func (t *Terminal) Write(p []byte) (int, error) {
+	if t.idleTimer != nil {
+		t.idleTimer.Reset(t.svc.idleTimeout)
+	}
+	return t.stdin.Write(p)
+}
+
+// Resize 调整远程终端大小
+func (t *Terminal) Resize(cols, rows int) error {
+	if cols <= 0 || rows <= 0 {
+		return nil
+	}
+	if t.rec != nil {
+		if err := t.rec.Resize(cols, rows); err != nil {
+			t.svc.log.Warn(
+				"写入终端录像失败",
+				zap.Error(err),
+				zap.String("session_id", t.model.SessionID),
+			)
+		}
+	}
+	return t.session.WindowChange(rows, cols)
+}
+
+// Done 返回会话结束时关闭的通道
+func (t *Terminal) Done() <-chan struct{} {
+	return t.done
+}
+
+// Close 结束会话并记录结束原因, 重复调用时只有第一次生效
+func (t *Terminal) Close(reason string) {
+	t.closeOnce.Do(func() {
+		close(t.done)
+		if t.idleTimer != nil {
+			t.idleTimer.Stop()
+		}
+		if t.maxTimer != nil {
+			t.maxTimer.Stop()
+		}
+		t.release()
+
+		s := t.svc
+		s.mu.Lock()
+		delete(s.terminals, t.model.SessionID)
+		s.mu.Unlock()
+
+		now := time.Now()
+		t.model.EndedAt = &now
+		t.model.CloseReason = reason
+		t.model.BytesOut = t.bytesOut.Load()
+		data := map[string]any{
+			"ended_at":     now,
+			"close_reason": reason,
+			"bytes_out":    t.model.BytesOut,
+			"record_file":  t.model.RecordFile,
+		}
+		if err := s.sessionRepo.UpdateModel(t.ctx, data, "id = ?", t.model.ID); err != nil {
+			ctxutil.Logger(t.ctx, s.log).Error(
+				"更新终端会话记录失败",
+				zap.Error(err),
+				zap.Object(database.ModelKey, &t.model),
+			)
+		}
+		s.audit(t.ctx, &t.model, resomodel.TerminalAuditClose)
+
+		ctxutil.Logger(t.ctx, s.log).Info(
+			"网页终端已关闭",
+			zap.Object(database.ModelKey, &t.model),
+			zap.Duration("duration", now.Sub(t.model.StartedAt)),
+		)
+	})
+}
+
+// release 关闭ssh连接和录像文件
+func (t *Terminal) release() {
+	if t.session != nil {
+		t.session.Close()
+	}
+	t.client.Close()
+	if t.recFile != nil {
+		if err := t.recFile.Close(); err != nil {
+			t.svc.log.Warn(
+				"关闭终端录像文件失败",
+				zap.Error(err),
+				zap.String("session_id", t.model.SessionID),
+			)
+		}
+	}
+}
+
+// audit 记录终端会话的审计记录, 审计失败不影响终端使用
+func (s *TerminalService) audit(ctx context.Context, m *resomodel.TerminalSessionModel, action string) {
+	var before, after any
+	if action == resomodel.TerminalAuditOpen {
+		after = m
+	} else {
+		before = m
+	}
+	record, err := sysmodel.NewAuditRecord(
+		"resource", resomodel.TerminalAuditResource, m.HostID, action,
+		before, after, m.Username, ctxutil.GetTraceID(ctx),
+	)
+	if err == nil {
+		err = s.auditRepo.CreateModel(ctx, record)
+	}
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"记录终端会话审计记录失败",
+			zap.Error(err),
+			zap.String("session_id", m.SessionID),
+			zap.String("action", action),
+		)
+	}
+}
+
+// Shutdown 结束全部进行中的会话, 服务关闭时调用
+func (s *TerminalService) Shutdown(ctx context.Context) {
+	s.mu.Lock()
+	terminals := make([]*Terminal, 0, len(s.terminals))
+	for _, t := range s.terminals {
+		terminals = append(terminals, t)
+	}
+	s.mu.Unlock()
+
+	for _, t := range terminals {
+		if ctx.Err() != nil {
+			ctxutil.Logger(ctx, s.log).Warn("关闭网页终端超时", zap.Int("remaining", len(terminals)))
+			return
+		}
+		t.Close(resomodel.TerminalCloseShutdown)
+	}
+}
+
+func (s *TerminalService) FindTerminalSessionById(
+	ctx context.Context,
+	sessionId uint32,
+) (*resomodel.TerminalSessionModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始查询终端会话记录",
+		zap.Uint32("terminal_session_id", sessionId),
+	)
+
+	m, err := s.sessionRepo.GetModel(ctx, sessionId)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询终端会话记录失败",
+			zap.Error(err),
+			zap.Uint32("terminal_session_id", sessionId),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"id": sessionId})
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"查询终端会话记录成功",
+		zap.Uint32("terminal_session_id", sessionId),
+	)
+	return m, nil
+}
+
+func (s *TerminalService) ListTerminalSession(
+	ctx context.Context,
+	qp database.QueryParams,
+) (int64, *[]resomodel.TerminalSessionModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return 0, nil, errors.FromError(ctx.Err())
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始查询终端会话记录列表",
+		zap.Object(database.QueryParamsKey, &qp),
+	)
+
+	count, ms, err := s.sessionRepo.ListModel(ctx, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询终端会话记录列表失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		return 0, nil, errors.NewGormError(err, nil)
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"查询终端会话记录列表成功",
+		zap.Object(database.QueryParamsKey, &qp),
+	)
+	return count, ms, nil
+}
+
+// TerminalRecordPath 返回会话录像文件路径, 会话没有录像时返回文件不存在
+func (s *TerminalService) TerminalRecordPath(
+	ctx context.Context,
+	sessionId uint32,
+) (string, *errors.Error) {
+	m, rErr := s.FindTerminalSessionById(ctx, sessionId)
+	if rErr != nil {
+		return "", rErr
+	}
+	if m.RecordFile == "" {
+		return "", errors.ErrDownloadFileNotFound.WithField("id", sessionId)
+	}
+	return filepath.Join(s.recordDir, m.RecordFile), nil
+}
@@ -0,0 +1,484 @@
+package resource
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	emperror "emperror.dev/errors"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	resomodel "gin-artweb/internal/model/resource"
+	resorepo "gin-artweb/internal/repository/resource"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+	"gin-artweb/pkg/archive"
+)
+
+// PackageUploadService 程序包分片上传服务
+//
+// 分片按顺序追加到本地临时文件, 客户端通过查询会话获取已接收的字节数后续传,
+// 全部上传后校验压缩包结构和校验和, 再写入程序包存储
+type PackageUploadService struct {
+	log        *zap.Logger
+	uploadRepo *resorepo.PackageUploadRepo
+	pkgService *PackageService
+	dir        string
+	maxSize    int64
+	chunkSize  int64
+	ttl        time.Duration
+
+	// 同一会话的分片串行写入, 最后一个持有者释放后删除
+	locksMu sync.Mutex
+	locks   map[string]*uploadLock
+}
+
+// uploadLock 会话锁, refs为持有和等待该锁的请求数
+type uploadLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// NewPackageUploadService 创建程序包分片上传服务, dir为分片临时文件目录
+func NewPackageUploadService(
+	log *zap.Logger,
+	uploadRepo *resorepo.PackageUploadRepo,
+	pkgService *PackageService,
+	dir string,
+	maxSize int64,
+	chunkSize int64,
+	ttl time.Duration,
+) *PackageUploadService {
+	return &PackageUploadService{
+		log:        log,
+		uploadRepo: uploadRepo,
+		pkgService: pkgService,
+		dir:        dir,
+		maxSize:    maxSize,
+		chunkSize:  chunkSize,
+		ttl:        ttl,
+		locks:      make(map[string]*uploadLock),
+	}
+}
+
+// ChunkSize 分片大小上限(字节)
+func (s *PackageUploadService) ChunkSize() int64 {
+	return s.chunkSize
+}
+
+func (s *PackageUploadService) partPath(uploadID string) string {
+	return filepath.Join(s.dir, uploadID+".part")
+}
+
+// lock 锁定会话, 返回的函数释放锁, 没有其他请求持有或等待时删除会话锁,
+// 会话完成、取消、过期或不存在时不会残留
+func (s *PackageUploadService) lock(uploadID string) func() {
+	s.locksMu.Lock()
+	l, ok := s.locks[uploadID]
+	if !ok {
+		l = &uploadLock{}
+		s.locks[uploadID] = l
+	}
+	l.refs++
+	s.locksMu.Unlock()
+
+	l.mu.Lock()
+	return func() {
+		l.mu.Unlock()
+		s.locksMu.Lock()
+		l.refs--
+		if l.refs == 0 {
+			delete(s.locks, uploadID)
+		}
+		s.locksMu.Unlock()
+	}
+}
+
+// InitUpload 创建分片上传会话
+func (s *PackageUploadService) InitUpload(
+	ctx context.Context,
+	m resomodel.PackageUploadModel,
+) (*resomodel.PackageUploadModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	if m.TotalSize > s.maxSize {
+		ctxutil.Logger(ctx, s.log).Error(
+			"分片上传的程序包文件过大",
+			zap.Int64("file_size", m.TotalSize),
+			zap.Int64("max_size", s.maxSize),
+		)
+		return nil, errors.ErrUploadFileTooLarge.WithFields(map[string]any{
+			"file_size": m.TotalSize,
+			"max_size":  s.maxSize,
+		})
+	}
+	if _, err := archive.DetectFormat(m.OriginFilename); err != nil {
+		return nil, errors.ErrZIPFileIsNotValid.WithCause(err)
+	}
+
+	m.UploadID = uuid.NewString()
+	m.Received = 0
+	m.Checksum = strings.ToLower(m.Checksum)
+	m.ExpiresAt = time.Now().Add(s.ttl)
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始创建分片上传会话",
+		zap.Object(database.ModelKey, &m),
+	)
+
+	partPath := s.partPath(m.UploadID)
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return nil, errors.ErrSaveUploadFileFailed.WithCause(err).WithField("save_path", s.dir)
+	}
+	f, err := os.OpenFile(partPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"创建分片临时文件失败",
+			zap.Error(err),
+			zap.String("path", partPath),
+		)
+		return nil, errors.ErrSaveUploadFileFailed.WithCause(err).WithField("save_path", partPath)
+	}
+	f.Close()
+
+	if err := s.uploadRepo.CreateModel(ctx, &m); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"创建分片上传会话失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, &m),
+		)
+		_ = os.Remove(partPath)
+		return nil, errors.NewGormError(err, nil)
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"创建分片上传会话成功",
+		zap.String("upload_id", m.UploadID),
+	)
+	return &m, nil
+}
+
+// GetUpload 查询未过期的分片上传会话
+func (s *PackageUploadService) GetUpload(
+	ctx context.Context,
+	uploadID string,
+) (*resomodel.PackageUploadModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	m, err := s.uploadRepo.GetModel(ctx, "upload_id = ?", uploadID)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询分片上传会话失败",
+			zap.Error(err),
+			zap.String("upload_id", uploadID),
+		)
+		if emperror.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.ErrUploadSessionNotFound.WithField("upload_id", uploadID)
+		}
+		return nil, errors.NewGormError(err, map[string]any{"upload_id": uploadID})
+	}
+	if time.Now().After(m.ExpiresAt) {
+		return nil, errors.ErrUploadSessionNotFound.WithField("upload_id", uploadID)
+	}
+	return m, nil
+}
+
+// UploadChunk 写入一个分片, offset必须等于已接收的字节数
+//
+// 写入失败或校验和不一致时丢弃该分片, 客户端可以从同一位置重新上传
+func (s *PackageUploadService) UploadChunk(
+	ctx context.Context,
+	uploadID string,
+	offset int64,
+	r io.Reader,
+	length int64,
+	checksum string,
+) (*resomodel.PackageUploadModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	unlock := s.lock(uploadID)
+	defer unlock()
+
+	m, rErr := s.GetUpload(ctx, uploadID)
+	if rErr != nil {
+		return nil, rErr
+	}
+	if offset != m.Received {
+		return nil, errors.ErrUploadOffsetMismatch.WithFields(map[string]any{
+			"offset":   offset,
+			"received": m.Received,
+		})
+	}
+	if length <= 0 || length > s.chunkSize || offset+length > m.TotalSize {
+		return nil, errors.ErrValidationFailed.WithFields(map[string]any{
+			"length":     length,
+			"chunk_size": s.chunkSize,
+			"remaining":  m.TotalSize - offset,
+		})
+	}
+
+	partPath := s.partPath(uploadID)
+	f, err := os.OpenFile(partPath, os.O_WRONLY, 0o600)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"打开分片临时文件失败",
+			zap.Error(err),
+			zap.String("path", partPath),
+		)
+		return nil, errors.ErrSaveUploadFileFailed.WithCause(err).WithField("save_path", partPath)
+	}
+	defer f.Close()
+
+	// 丢弃上一次写入失败时残留的内容
+	if err := f.Truncate(offset); err != nil {
+		return nil, errors.ErrSaveUploadFileFailed.WithCause(err).WithField("save_path", partPath)
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return nil, errors.ErrSaveUploadFileFailed.WithCause(err).WithField("save_path", partPath)
+	}
+
+	hr := newHashReader(io.LimitReader(r, length))
+	n, err := io.Copy(f, hr)
+	if err == nil && n != length {
+		err = io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		_ = f.Truncate(offset)
+		ctxutil.Logger(ctx, s.log).Error(
+			"写入分片失败",
+			zap.Error(err),
+			zap.String("upload_id", uploadID),
+			zap.Int64("offset", offset),
+			zap.Int64("length", length),
+			zap.Int64("written", n),
+		)
+		return nil, errors.ErrSaveUploadFileFailed.WithCause(err).WithField("save_path", partPath)
+	}
+	if checksum != "" && !strings.EqualFold(checksum, hr.Sum()) {
+		_ = f.Truncate(offset)
+		ctxutil.Logger(ctx, s.log).Error(
+			"分片校验和不一致",
+			zap.String("upload_id", uploadID),
+			zap.Int64("offset", offset),
+			zap.String("expected", checksum),
+			zap.String("actual", hr.Sum()),
+		)
+		return nil, errors.ErrFileChecksumMismatch.WithFields(map[string]any{
+			"offset":   offset,
+			"expected": checksum,
+			"actual":   hr.Sum(),
+		})
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Truncate(offset)
+		return nil, errors.ErrSaveUploadFileFailed.WithCause(err).WithField("save_path", partPath)
+	}
+
+	received := offset + n
+	expiresAt := time.Now().Add(s.ttl)
+	if err := s.uploadRepo.UpdateModel(ctx, map[string]any{
+		"received":   received,
+		"expires_at": expiresAt,
+		"version":    m.Version,
+	}, "upload_id = ?", uploadID); err != nil {
+		_ = f.Truncate(offset)
+		ctxutil.Logger(ctx, s.log).Error(
+			"更新分片上传进度失败",
+			zap.Error(err),
+			zap.String("upload_id", uploadID),
+			zap.Int64("received", received),
+		)
+		if database.IsVersionConflict(err) {
+			return nil, errors.ErrUploadOffsetMismatch.WithField("offset", offset)
+		}
+		return nil, errors.NewGormError(err, map[string]any{"upload_id": uploadID})
+	}
+
+	m.Received = received
+	m.ExpiresAt = expiresAt
+	m.Version++
+	ctxutil.Logger(ctx, s.log).Debug(
+		"写入分片成功",
+		zap.String("upload_id", uploadID),
+		zap.Int64("offset", offset),
+		zap.Int64("received", received),
+		zap.Int64("total_size", m.TotalSize),
+	)
+	return m, nil
+}
+
+// CompleteUpload 完成分片上传, 校验压缩包后写入程序包存储并创建程序包记录
+func (s *PackageUploadService) CompleteUpload(
+	ctx context.Context,
+	uploadID string,
+) (*resomodel.PackageModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	unlock := s.lock(uploadID)
+	defer unlock()
+
+	m, rErr := s.GetUpload(ctx, uploadID)
+	if rErr != nil {
+		return nil, rErr
+	}
+	if m.Received != m.TotalSize {
+		return nil, errors.ErrUploadIncomplete.WithFields(map[string]any{
+			"received":   m.Received,
+			"total_size": m.TotalSize,
+		})
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始完成分片上传",
+		zap.Object(database.ModelKey, m),
+	)
+
+	partPath := s.partPath(uploadID)
+	if rErr := s.validateArchive(ctx, m.OriginFilename, partPath); rErr != nil {
+		return nil, rErr
+	}
+
+	f, err := os.Open(partPath)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"打开分片临时文件失败",
+			zap.Error(err),
+			zap.String("path", partPath),
+		)
+		return nil, errors.ErrSaveUploadFileFailed.WithCause(err).WithField("save_path", partPath)
+	}
+	defer f.Close()
+
+	pkg, rErr := s.pkgService.UploadPackage(ctx, resomodel.PackageModel{
+		Label:           m.Label,
+		Version:         m.PkgVersion,
+		StorageFilename: uuid.NewString() + filepath.Ext(m.OriginFilename),
+		OriginFilename:  m.OriginFilename,
+	}, f, m.TotalSize, m.Checksum)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	s.removeUpload(ctx, uploadID)
+	ctxutil.Logger(ctx, s.log).Info(
+		"完成分片上传成功",
+		zap.String("upload_id", uploadID),
+		zap.Uint32("package_id", pkg.ID),
+	)
+	return pkg, nil
+}
+
+// validateArchive 校验压缩包只包含一个顶层目录
+func (s *PackageUploadService) validateArchive(ctx context.Context, filename, path string) *errors.Error {
+	format, err := archive.DetectFormat(filename)
+	if err != nil {
+		return errors.ErrZIPFileIsNotValid.WithCause(err)
+	}
+	archiver, err := archive.NewArchiver(format)
+	if err != nil {
+		return errors.ErrZIPFileIsNotValid.WithCause(err)
+	}
+	if _, err := archiver.ValidateSingleDir(path, archive.WithContext(ctx)); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"分片上传的程序包校验失败",
+			zap.Error(err),
+			zap.String("filename", filename),
+			zap.String("path", path),
+		)
+		if archive.IsLimitError(err) {
+			return errors.ErrArchiveLimitExceeded.WithCause(err)
+		}
+		return errors.ErrZIPFileIsNotValid.WithCause(err)
+	}
+	return nil
+}
+
+// AbortUpload 取消分片上传, 删除会话和已上传的分片
+func (s *PackageUploadService) AbortUpload(ctx context.Context, uploadID string) *errors.Error {
+	if ctx.Err() != nil {
+		return errors.FromError(ctx.Err())
+	}
+
+	unlock := s.lock(uploadID)
+	defer unlock()
+
+	if _, rErr := s.GetUpload(ctx, uploadID); rErr != nil {
+		return rErr
+	}
+	s.removeUpload(ctx, uploadID)
+	ctxutil.Logger(ctx, s.log).Info(
+		"取消分片上传成功",
+		zap.String("upload_id", uploadID),
+	)
+	return nil
+}
+
+// CleanupExpired 清理已过期的分片上传会话和没有对应会话的分片临时文件, 返回清理的会话数量
+func (s *PackageUploadService) CleanupExpired(ctx context.Context) int {
+	_, ms, err := s.uploadRepo.ListModel(ctx, database.QueryParams{
+		Query: map[string]any{"expires_at < ?": time.Now()},
+	})
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error("查询过期的分片上传会话失败", zap.Error(err))
+		return 0
+	}
+	for _, m := range *ms {
+		s.removeUpload(ctx, m.UploadID)
+	}
+	if len(*ms) > 0 {
+		ctxutil.Logger(ctx, s.log).Info("清理过期的分片上传会话", zap.Int("count", len(*ms)))
+	}
+
+	// 会话记录已删除但临时文件删除失败时, 超过过期时间后再次清理
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return len(*ms)
+	}
+	for _, entry := range entries {
+		uploadID, ok := strings.CutSuffix(entry.Name(), ".part")
+		if !ok || entry.IsDir() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || time.Since(info.ModTime()) < s.ttl {
+			continue
+		}
+		if _, err := s.uploadRepo.GetModel(ctx, "upload_id = ?", uploadID); emperror.Is(err, gorm.ErrRecordNotFound) {
+			s.removeUpload(ctx, uploadID)
+		}
+	}
+	return len(*ms)
+}
+
+// removeUpload 删除会话和分片临时文件, 失败时只记录日志
+func (s *PackageUploadService) removeUpload(ctx context.Context, uploadID string) {
+	if err := s.uploadRepo.DeleteModel(ctx, "upload_id = ?", uploadID); err != nil {
+		ctxutil.Logger(ctx, s.log).Warn(
+			"删除分片上传会话失败",
+			zap.Error(err),
+			zap.String("upload_id", uploadID),
+		)
+	}
+	if err := os.Remove(s.partPath(uploadID)); err != nil && !os.IsNotExist(err) {
+		ctxutil.Logger(ctx, s.log).Warn(
+			"删除分片临时文件失败",
+			zap.Error(err),
+			zap.String("upload_id", uploadID),
+		)
+	}
+}
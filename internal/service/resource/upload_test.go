@@ -0,0 +1,198 @@
+package resource
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	resomodel "gin-artweb/internal/model/resource"
+	resorepo "gin-artweb/internal/repository/resource"
+	"gin-artweb/internal/shared/errors"
+	"gin-artweb/internal/shared/test"
+	"gin-artweb/pkg/archive"
+	"gin-artweb/pkg/storage"
+)
+
+type PackageUploadServiceTestSuite struct {
+	suite.Suite
+	uploadRepo *resorepo.PackageUploadRepo
+	svc        *PackageUploadService
+	store      *storage.LocalStorage
+	dir        string
+}
+
+func (suite *PackageUploadServiceTestSuite) SetupTest() {
+	db := test.NewTestGormDBWithConfig(nil)
+	db.AutoMigrate(&resomodel.PackageModel{}, &resomodel.PackageUploadModel{})
+	logger := test.NewTestZapLogger()
+	suite.uploadRepo = resorepo.NewPackageUploadRepo(logger, db, test.NewTestDBTimeouts())
+	pkgRepo := resorepo.NewPackageRepo(logger, db, test.NewTestDBTimeouts())
+	suite.store = storage.NewLocalStorage(suite.T().TempDir())
+	pkgService := NewPackageService(logger, pkgRepo, suite.store, 0)
+	suite.dir = suite.T().TempDir()
+	suite.svc = NewPackageUploadService(logger, suite.uploadRepo, pkgService, suite.dir, 1<<20, 64, time.Hour)
+}
+
+// newTarGz 创建只包含一个顶层目录的tar.gz文件
+func (suite *PackageUploadServiceTestSuite) newTarGz() []byte {
+	src := filepath.Join(suite.T().TempDir(), "oes")
+	suite.Require().NoError(os.MkdirAll(filepath.Join(src, "bin"), 0o755))
+	suite.Require().NoError(os.WriteFile(filepath.Join(src, "bin", "oes"), bytes.Repeat([]byte("oes"), 100), 0o755))
+	dst := filepath.Join(suite.T().TempDir(), "oes.tar.gz")
+	suite.Require().NoError(archive.TarGz(src, dst))
+	data, err := os.ReadFile(dst)
+	suite.Require().NoError(err)
+	return data
+}
+
+func (suite *PackageUploadServiceTestSuite) initUpload(data []byte, checksum string) *resomodel.PackageUploadModel {
+	m, rErr := suite.svc.InitUpload(context.Background(), resomodel.PackageUploadModel{
+		Label:          "oes",
+		PkgVersion:     "0.17",
+		OriginFilename: "oes.tar.gz",
+		TotalSize:      int64(len(data)),
+		Checksum:       checksum,
+	})
+	suite.Require().Nil(rErr)
+	return m
+}
+
+// uploadAll 按分片大小上限依次上传
+func (suite *PackageUploadServiceTestSuite) uploadAll(uploadID string, data []byte) {
+	for offset := 0; offset < len(data); offset += 64 {
+		chunk := data[offset:min(offset+64, len(data))]
+		_, rErr := suite.svc.UploadChunk(context.Background(), uploadID, int64(offset), bytes.NewReader(chunk), int64(len(chunk)), checksumOf(string(chunk)))
+		suite.Require().Nil(rErr)
+	}
+}
+
+func (suite *PackageUploadServiceTestSuite) TestUploadAndComplete() {
+	ctx := context.Background()
+	data := suite.newTarGz()
+	m := suite.initUpload(data, checksumOf(string(data)))
+
+	_, rErr := suite.svc.CompleteUpload(ctx, m.UploadID)
+	suite.Equal(errors.ErrUploadIncomplete.Reason, rErr.Reason, "未全部上传时不能完成")
+
+	suite.uploadAll(m.UploadID, data)
+	pkg, rErr := suite.svc.CompleteUpload(ctx, m.UploadID)
+	suite.Require().Nil(rErr)
+	suite.Equal(int64(len(data)), pkg.Size)
+	suite.Equal(checksumOf(string(data)), pkg.Checksum)
+
+	_, err := os.Stat(filepath.Join(suite.dir, m.UploadID+".part"))
+	suite.True(os.IsNotExist(err), "完成后应该删除分片临时文件")
+	_, rErr = suite.svc.GetUpload(ctx, m.UploadID)
+	suite.Equal(errors.ErrUploadSessionNotFound.Reason, rErr.Reason, "完成后应该删除上传会话")
+	suite.Empty(suite.svc.locks, "完成后应该删除会话锁")
+}
+
+func (suite *PackageUploadServiceTestSuite) TestResumeAfterFailedChunk() {
+	ctx := context.Background()
+	data := suite.newTarGz()
+	m := suite.initUpload(data, "")
+
+	chunk := data[:64]
+	_, rErr := suite.svc.UploadChunk(ctx, m.UploadID, 0, bytes.NewReader(chunk), 64, checksumOf("other"))
+	suite.Equal(errors.ErrFileChecksumMismatch.Reason, rErr.Reason)
+
+	// 连接中断时分片不完整
+	_, rErr = suite.svc.UploadChunk(ctx, m.UploadID, 0, bytes.NewReader(chunk[:10]), 64, "")
+	suite.NotNil(rErr)
+
+	cur, rErr := suite.svc.GetUpload(ctx, m.UploadID)
+	suite.Require().Nil(rErr)
+	suite.Zero(cur.Received, "失败的分片不应该计入已接收的字节数")
+
+	_, rErr = suite.svc.UploadChunk(ctx, m.UploadID, 64, bytes.NewReader(chunk), 64, "")
+	suite.Equal(errors.ErrUploadOffsetMismatch.Reason, rErr.Reason)
+
+	_, rErr = suite.svc.UploadChunk(ctx, m.UploadID, 0, bytes.NewReader(data[:65]), 65, "")
+	suite.Equal(errors.ErrValidationFailed.Reason, rErr.Reason, "分片不能超过大小上限")
+
+	suite.uploadAll(m.UploadID, data)
+	_, rErr = suite.svc.CompleteUpload(ctx, m.UploadID)
+	suite.Nil(rErr)
+}
+
+func (suite *PackageUploadServiceTestSuite) TestCompleteRejectsInvalidArchive() {
+	data := bytes.Repeat([]byte("x"), 100)
+	m := suite.initUpload(data, "")
+	suite.uploadAll(m.UploadID, data)
+
+	_, rErr := suite.svc.CompleteUpload(context.Background(), m.UploadID)
+	suite.Equal(errors.ErrZIPFileIsNotValid.Reason, rErr.Reason)
+
+	_, rErr = suite.svc.InitUpload(context.Background(), resomodel.PackageUploadModel{
+		OriginFilename: "oes.bin",
+		TotalSize:      100,
+	})
+	suite.Equal(errors.ErrZIPFileIsNotValid.Reason, rErr.Reason, "不支持的压缩格式")
+
+	_, rErr = suite.svc.InitUpload(context.Background(), resomodel.PackageUploadModel{
+		OriginFilename: "oes.tar.gz",
+		TotalSize:      2 << 20,
+	})
+	suite.Equal(errors.ErrUploadFileTooLarge.Reason, rErr.Reason)
+}
+
+func (suite *PackageUploadServiceTestSuite) TestAbortAndCleanup() {
+	ctx := context.Background()
+	data := suite.newTarGz()
+
+	aborted := suite.initUpload(data, "")
+	suite.Nil(suite.svc.AbortUpload(ctx, aborted.UploadID))
+	_, err := os.Stat(filepath.Join(suite.dir, aborted.UploadID+".part"))
+	suite.True(os.IsNotExist(err))
+
+	expired := suite.initUpload(data, "")
+	active := suite.initUpload(data, "")
+	suite.NoError(suite.uploadRepo.UpdateModel(ctx, map[string]any{"expires_at": time.Now().Add(-time.Minute)}, "upload_id = ?", expired.UploadID))
+
+	suite.Equal(1, suite.svc.CleanupExpired(ctx))
+	_, err = os.Stat(filepath.Join(suite.dir, expired.UploadID+".part"))
+	suite.True(os.IsNotExist(err), "应该删除过期会话的分片临时文件")
+	_, rErr := suite.svc.GetUpload(ctx, active.UploadID)
+	suite.Nil(rErr, "未过期的会话应该保留")
+
+	_, rErr = suite.svc.UploadChunk(ctx, expired.UploadID, 0, bytes.NewReader(data[:64]), 64, "")
+	suite.Equal(errors.ErrUploadSessionNotFound.Reason, rErr.Reason)
+	suite.Nil(suite.svc.AbortUpload(ctx, active.UploadID))
+	suite.Empty(suite.svc.locks, "会话取消、过期或不存在时不应该残留会话锁")
+}
+
+func (suite *PackageUploadServiceTestSuite) TestConcurrentChunks() {
+	ctx := context.Background()
+	data := suite.newTarGz()
+	m := suite.initUpload(data, "")
+
+	var wg sync.WaitGroup
+	results := make([]*errors.Error, 8)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, results[i] = suite.svc.UploadChunk(ctx, m.UploadID, 0, bytes.NewReader(data[:64]), 64, "")
+		}()
+	}
+	wg.Wait()
+
+	var ok int
+	for _, rErr := range results {
+		if rErr == nil {
+			ok++
+		}
+	}
+	suite.Equal(1, ok, "同一位置的分片只有一个写入成功")
+	suite.Empty(suite.svc.locks)
+}
+
+func TestPackageUploadServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(PackageUploadServiceTestSuite))
+}
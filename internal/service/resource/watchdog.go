@@ -0,0 +1,462 @@
+package resource
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	resomodel "gin-artweb/internal/model/resource"
+	resorepo "gin-artweb/internal/repository/resource"
+	syssvc "gin-artweb/internal/service/system"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+)
+
+// watchdogRestartSettle 执行重启命令后等待进程启动的时间
+const watchdogRestartSettle = 2 * time.Second
+
+// WatchdogService 进程守护服务
+//
+// 定时通过SSH检查主机上的进程或systemd服务是否处于期望状态, 主机代理离线时跳过检查;
+// 期望运行但未运行时按重启策略重启, 重启窗口内的重启次数达到上限后不再重启.
+// 状态变化和重启记录到历史并发出告警, 处于全局维护窗口内时屏蔽告警
+type WatchdogService struct {
+	log          *zap.Logger
+	watchdogRepo *resorepo.WatchdogRepo
+	eventRepo    *resorepo.WatchdogEventRepo
+	agentRepo    *resorepo.HostAgentRepo
+	hostService  *HostService
+	alerts       *syssvc.AlertPublisher
+	timeout      time.Duration
+}
+
+func NewWatchdogService(
+	log *zap.Logger,
+	watchdogRepo *resorepo.WatchdogRepo,
+	eventRepo *resorepo.WatchdogEventRepo,
+	agentRepo *resorepo.HostAgentRepo,
+	hostService *HostService,
+	alerts *syssvc.AlertPublisher,
+	conf *config.WatchdogConfig,
+) *WatchdogService {
+	var c config.WatchdogConfig
+	if conf != nil {
+		c = *conf
+	}
+	return &WatchdogService{
+		log:          log,
+		watchdogRepo: watchdogRepo,
+		eventRepo:    eventRepo,
+		agentRepo:    agentRepo,
+		hostService:  hostService,
+		alerts:       alerts,
+		timeout:      time.Duration(cmp.Or(c.CommandTimeout, 30)) * time.Second,
+	}
+}
+
+func (s *WatchdogService) CreateWatchdog(
+	ctx context.Context,
+	m resomodel.WatchdogModel,
+) (*resomodel.WatchdogModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	if err := m.Validate(); err != nil {
+		return nil, errors.ErrWatchdogInvalid.WithCause(err)
+	}
+	m.Status = resomodel.WatchdogUnknown
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始创建进程守护",
+		zap.Object(database.ModelKey, &m),
+	)
+
+	if err := s.watchdogRepo.CreateModel(ctx, &m); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"创建进程守护失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, &m),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"name": m.Name})
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"创建进程守护成功",
+		zap.Object(database.ModelKey, &m),
+	)
+	return s.FindWatchdogById(ctx, m.ID)
+}
+
+// UpdateWatchdogById 更新进程守护定义, 检查状态保留到下一次检查
+func (s *WatchdogService) UpdateWatchdogById(
+	ctx context.Context,
+	m resomodel.WatchdogModel,
+) (*resomodel.WatchdogModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	if err := m.Validate(); err != nil {
+		return nil, errors.ErrWatchdogInvalid.WithCause(err)
+	}
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始更新进程守护",
+		zap.Object(database.ModelKey, &m),
+	)
+
+	data := map[string]any{
+		"name":            m.Name,
+		"host_id":         m.HostID,
+		"kind":            m.Kind,
+		"target":          m.Target,
+		"expected_state":  m.ExpectedState,
+		"restart_policy":  m.RestartPolicy,
+		"restart_command": m.RestartCommand,
+		"max_restarts":    m.MaxRestarts,
+		"restart_window":  m.RestartWindow,
+		"is_enabled":      m.IsEnabled,
+		"remark":          m.Remark,
+	}
+	if err := s.watchdogRepo.UpdateModel(ctx, data, "id = ?", m.ID); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"更新进程守护失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, &m),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"id": m.ID, "name": m.Name})
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"更新进程守护成功",
+		zap.Object(database.ModelKey, &m),
+	)
+	return s.FindWatchdogById(ctx, m.ID)
+}
+
+func (s *WatchdogService) DeleteWatchdogById(
+	ctx context.Context,
+	watchdogId uint32,
+) *errors.Error {
+	if ctx.Err() != nil {
+		return errors.FromError(ctx.Err())
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始删除进程守护",
+		zap.Uint32("watchdog_id", watchdogId),
+	)
+
+	if err := s.watchdogRepo.DeleteModel(ctx, watchdogId); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"删除进程守护失败",
+			zap.Error(err),
+			zap.Uint32("watchdog_id", watchdogId),
+		)
+		return errors.NewGormError(err, map[string]any{"id": watchdogId})
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"删除进程守护成功",
+		zap.Uint32("watchdog_id", watchdogId),
+	)
+	return nil
+}
+
+func (s *WatchdogService) FindWatchdogById(
+	ctx context.Context,
+	watchdogId uint32,
+) (*resomodel.WatchdogModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	m, err := s.watchdogRepo.GetModel(ctx, []string{"Host"}, watchdogId)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询进程守护失败",
+			zap.Error(err),
+			zap.Uint32("watchdog_id", watchdogId),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"id": watchdogId})
+	}
+	return m, nil
+}
+
+func (s *WatchdogService) ListWatchdog(
+	ctx context.Context,
+	qp database.QueryParams,
+) (int64, *[]resomodel.WatchdogModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return 0, nil, errors.FromError(ctx.Err())
+	}
+
+	count, ms, err := s.watchdogRepo.ListModel(ctx, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询进程守护列表失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		return 0, nil, errors.NewGormError(err, nil)
+	}
+	return count, ms, nil
+}
+
+func (s *WatchdogService) ListWatchdogEvent(
+	ctx context.Context,
+	watchdogId uint32,
+	qp database.QueryParams,
+) (int64, *[]resomodel.WatchdogEventModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return 0, nil, errors.FromError(ctx.Err())
+	}
+
+	if _, rErr := s.FindWatchdogById(ctx, watchdogId); rErr != nil {
+		return 0, nil, rErr
+	}
+	if qp.Query == nil {
+		qp.Query = make(map[string]any)
+	}
+	qp.Query["watchdog_id = ?"] = watchdogId
+	count, ms, err := s.eventRepo.ListModel(ctx, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询进程守护历史失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		return 0, nil, errors.NewGormError(err, nil)
+	}
+	return count, ms, nil
+}
+
+// CheckWatchdogById 立即检查进程守护, 返回检查后的进程守护
+func (s *WatchdogService) CheckWatchdogById(
+	ctx context.Context,
+	watchdogId uint32,
+) (*resomodel.WatchdogModel, *errors.Error) {
+	m, rErr := s.FindWatchdogById(ctx, watchdogId)
+	if rErr != nil {
+		return nil, rErr
+	}
+	if rErr := s.check(ctx, m); rErr != nil {
+		return nil, rErr
+	}
+	return s.FindWatchdogById(ctx, watchdogId)
+}
+
+// CheckAll 检查全部启用的进程守护
+func (s *WatchdogService) CheckAll(ctx context.Context) *errors.Error {
+	if ctx.Err() != nil {
+		return errors.FromError(ctx.Err())
+	}
+
+	_, ms, rErr := s.ListWatchdog(ctx, database.QueryParams{
+		Preloads: []string{"Host"},
+		Query:    map[string]any{"is_enabled = ?": true},
+		OrderBy:  []string{"id ASC"},
+	})
+	if rErr != nil {
+		return rErr
+	}
+	for i := range *ms {
+		if rErr := s.check(ctx, &(*ms)[i]); rErr != nil {
+			ctxutil.Logger(ctx, s.log).Error(
+				"检查进程守护失败",
+				zap.Error(rErr),
+				zap.Uint32("watchdog_id", (*ms)[i].ID),
+			)
+		}
+	}
+	return nil
+}
+
+// check 检查进程守护并保存检查状态, 状态变化和重启记录到历史并发出告警
+//
+// 重启次数达到上限只在第一次记录和告警, 避免每次检查重复告警
+func (s *WatchdogService) check(ctx context.Context, m *resomodel.WatchdogModel) *errors.Error {
+	if ctx.Err() != nil {
+		return errors.FromError(ctx.Err())
+	}
+
+	now := time.Now()
+	status, action, message := s.evaluate(ctx, m, now)
+	message = truncateMessage(message)
+	data := map[string]any{
+		"status":          status,
+		"last_action":     action,
+		"last_message":    message,
+		"last_checked_at": now,
+	}
+	if err := s.watchdogRepo.UpdateModel(ctx, data, "id = ?", m.ID); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"更新进程守护检查状态失败",
+			zap.Error(err),
+			zap.Uint32("watchdog_id", m.ID),
+		)
+		return errors.NewGormError(err, data)
+	}
+
+	repeated := action == resomodel.WatchdogActionRestartLimited && m.LastAction == action
+	if status == m.Status && (action == "" || repeated) {
+		return nil
+	}
+	if repeated {
+		action = ""
+	}
+	event := resomodel.WatchdogEventModel{
+		WatchdogID: m.ID,
+		Status:     status,
+		Action:     cmp.Or(action, resomodel.WatchdogActionStateChange),
+		Message:    message,
+	}
+	if err := s.eventRepo.CreateModel(ctx, &event); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"记录进程守护历史失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, &event),
+		)
+	}
+	// 主机不可达由主机代理失联告警, 未知状态不单独告警
+	if action != "" || (status != resomodel.WatchdogUnknown && m.Status != resomodel.WatchdogUnknown) {
+		s.notify(ctx, m, status, event.Action, message, now)
+	}
+	return nil
+}
+
+// evaluate 检查进程守护是否处于期望状态, 需要时按重启策略重启, 返回检查状态、执行的动作和信息
+func (s *WatchdogService) evaluate(
+	ctx context.Context,
+	m *resomodel.WatchdogModel,
+	now time.Time,
+) (string, string, string) {
+	agent, err := s.agentRepo.GetModel(ctx, nil, "host_id = ?", m.HostID)
+	if err == nil && agent.Status == resomodel.HostAgentOffline {
+		return resomodel.WatchdogUnknown, "", "主机代理离线, 跳过检查"
+	}
+
+	running, rErr := s.isRunning(ctx, m)
+	if rErr != nil {
+		return resomodel.WatchdogUnknown, "", rErr.Error()
+	}
+	expectRunning := m.ExpectedState == resomodel.WatchdogExpectRunning
+	if running == expectRunning {
+		return resomodel.WatchdogOK, "", ""
+	}
+	message := fmt.Sprintf("%s期望%s, 实际%s", m.Target, m.ExpectedState, runningState(running))
+	if m.RestartPolicy != resomodel.WatchdogRestartOnFailure {
+		return resomodel.WatchdogFailed, "", message
+	}
+
+	window := time.Duration(m.RestartWindow) * time.Minute
+	restarts, err := s.eventRepo.CountRestarts(ctx, m.ID, now.Add(-window))
+	if err != nil {
+		return resomodel.WatchdogFailed, "", message
+	}
+	if restarts >= int64(m.MaxRestarts) {
+		return resomodel.WatchdogFailed, resomodel.WatchdogActionRestartLimited,
+			fmt.Sprintf("%s; %d分钟内已重启%d次, 不再重启", message, m.RestartWindow, restarts)
+	}
+
+	ctxutil.Logger(ctx, s.log).Warn(
+		"进程守护开始重启",
+		zap.Uint32("watchdog_id", m.ID),
+		zap.Uint32("host_id", m.HostID),
+		zap.String("target", m.Target),
+	)
+	cmdCtx, cancel := context.WithTimeout(ctx, s.timeout)
+	code, output, rErr := s.hostService.RunCommand(cmdCtx, m.Host, m.StartCommand())
+	cancel()
+	if rErr != nil {
+		return resomodel.WatchdogFailed, resomodel.WatchdogActionRestartFailed,
+			fmt.Sprintf("%s; 执行重启命令失败: %s", message, rErr.Error())
+	}
+	if code != 0 {
+		return resomodel.WatchdogFailed, resomodel.WatchdogActionRestartFailed,
+			fmt.Sprintf("%s; 重启命令退出码%d: %s", message, code, strings.TrimSpace(output))
+	}
+
+	select {
+	case <-ctx.Done():
+		return resomodel.WatchdogFailed, resomodel.WatchdogActionRestartFailed, message
+	case <-time.After(watchdogRestartSettle):
+	}
+	if running, rErr = s.isRunning(ctx, m); rErr == nil && running {
+		return resomodel.WatchdogOK, resomodel.WatchdogActionRestart, message + "; 已重启"
+	}
+	return resomodel.WatchdogFailed, resomodel.WatchdogActionRestartFailed, message + "; 重启后仍未运行"
+}
+
+// isRunning 在主机上执行检查命令, 退出码为0表示正在运行
+func (s *WatchdogService) isRunning(ctx context.Context, m *resomodel.WatchdogModel) (bool, *errors.Error) {
+	cmdCtx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	code, _, rErr := s.hostService.RunCommand(cmdCtx, m.Host, m.CheckCommand())
+	if rErr != nil {
+		return false, rErr
+	}
+	return code == 0, nil
+}
+
+// notify 发出进程守护告警, 处于全局维护窗口内时屏蔽告警并记录审计
+func (s *WatchdogService) notify(
+	ctx context.Context,
+	m *resomodel.WatchdogModel,
+	status, action, message string,
+	now time.Time,
+) {
+	ctxutil.Logger(ctx, s.log).Info(
+		"进程守护状态变化",
+		zap.Uint32("watchdog_id", m.ID),
+		zap.String("from", m.Status),
+		zap.String("to", status),
+		zap.String("action", action),
+	)
+	s.alerts.Publish(ctx, syssvc.Alert{
+		Kind:     resomodel.AlertKindWatchdog,
+		Status:   action,
+		Scope:    syssvc.AlertScopeGlobal,
+		TargetID: m.ID,
+		At:       now,
+		Event: resomodel.WatchdogAlertEvent{
+			Kind:       resomodel.AlertKindWatchdog,
+			WatchdogID: m.ID,
+			Name:       m.Name,
+			HostID:     m.HostID,
+			HostName:   m.Host.Name,
+			Target:     m.Target,
+			From:       m.Status,
+			To:         status,
+			Action:     action,
+			Message:    message,
+			CheckedAt:  now.Format(time.DateTime),
+		},
+		Detail: map[string]any{
+			"watchdog_id": m.ID,
+			"action":      action,
+			"to":          status,
+		},
+	})
+}
+
+func runningState(running bool) string {
+	if running {
+		return resomodel.WatchdogExpectRunning
+	}
+	return resomodel.WatchdogExpectStopped
+}
+
+// truncateMessage 截断检查信息, 不超过数据库字段长度
+func truncateMessage(s string) string {
+	r := []rune(s)
+	if len(r) > 1024 {
+		return string(r[:1024])
+	}
+	return s
+}
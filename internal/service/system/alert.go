@@ -0,0 +1,127 @@
+package system
+
+import (
+	"context"
+	"maps"
+	"time"
+
+	"go.uber.org/zap"
+
+	sysmodel "gin-artweb/internal/model/system"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/errors"
+	"gin-artweb/internal/shared/events"
+	"gin-artweb/internal/shared/metrics"
+)
+
+// AlertScope 告警按哪些维护窗口屏蔽
+type AlertScope int
+
+const (
+	AlertScopeGlobal  AlertScope = iota // 只有全局维护窗口屏蔽
+	AlertScopeColony                    // 全局窗口和所属集群的集群窗口屏蔽
+	AlertScopeMonNode                   // 全局窗口、该节点的节点窗口和使用该节点的集群窗口屏蔽
+	AlertScopeNone                      // 不受维护窗口屏蔽, 用于安全告警
+)
+
+// Alert 待发出的告警
+type Alert struct {
+	Kind     string         // 告警类型
+	Status   string         // 告警状态, 与告警类型一起作为告警次数指标的标签
+	Scope    AlertScope     // 匹配维护窗口的范围
+	Module   string         // 集群所属模块, 用于AlertScopeColony
+	TargetID uint32         // 集群ID或mon节点ID
+	At       time.Time      // 告警时间, 用于匹配维护窗口
+	Event    events.Payload // 发出告警时写入发件箱的事件
+	Detail   map[string]any // 屏蔽告警时写入审计记录的详情
+}
+
+// AlertPublisher 发出告警, 处于维护窗口内时屏蔽告警并记录审计
+//
+// 告警事件没有对应的业务数据写入, 单独写入发件箱
+type AlertPublisher struct {
+	log         *zap.Logger
+	maintenance *MaintenanceService
+	outbox      *events.Outbox
+}
+
+func NewAlertPublisher(
+	log *zap.Logger,
+	maintenance *MaintenanceService,
+	outbox *events.Outbox,
+) *AlertPublisher {
+	return &AlertPublisher{
+		log:         log,
+		maintenance: maintenance,
+		outbox:      outbox,
+	}
+}
+
+// Publish 发出告警, 告警被维护窗口屏蔽时返回false
+//
+// 查询维护窗口失败时照常发出告警; 写入发件箱失败只记录日志, 不影响调用方的巡检流程
+func (p *AlertPublisher) Publish(ctx context.Context, a Alert) bool {
+	if p == nil {
+		return true
+	}
+
+	if window := p.match(ctx, a); window != nil {
+		ctxutil.Logger(ctx, p.log).Info(
+			"维护窗口生效中, 已屏蔽告警",
+			zap.String("kind", a.Kind),
+			zap.String("status", a.Status),
+			zap.Uint32("target_id", a.TargetID),
+			zap.Uint32("window_id", window.ID),
+			zap.String("window_name", window.Name),
+		)
+		detail := maps.Clone(a.Detail)
+		if detail == nil {
+			detail = make(map[string]any, 1)
+		}
+		detail["kind"] = a.Kind
+		p.maintenance.RecordSuppression(ctx, window, sysmodel.MaintenanceActionMuteAlert, detail)
+		metrics.AlertsTotal.WithLabelValues(a.Kind, a.Status, metrics.AlertMuted).Inc()
+		return false
+	}
+
+	metrics.AlertsTotal.WithLabelValues(a.Kind, a.Status, metrics.AlertFired).Inc()
+	if err := p.outbox.Add(ctx, a.Event); err != nil {
+		ctxutil.Logger(ctx, p.log).Error(
+			"写入告警事件失败",
+			zap.Error(err),
+			zap.String("kind", a.Kind),
+			zap.String("status", a.Status),
+			zap.Uint32("target_id", a.TargetID),
+		)
+	}
+	return true
+}
+
+// match 查询屏蔽告警的维护窗口, 未命中或查询失败时返回nil
+func (p *AlertPublisher) match(ctx context.Context, a Alert) *sysmodel.MaintenanceWindowModel {
+	if p.maintenance == nil || a.Scope == AlertScopeNone {
+		return nil
+	}
+	var (
+		window *sysmodel.MaintenanceWindowModel
+		rErr   *errors.Error
+	)
+	switch a.Scope {
+	case AlertScopeColony:
+		window, rErr = p.maintenance.MatchColony(ctx, a.Module, a.TargetID, a.At)
+	case AlertScopeMonNode:
+		window, rErr = p.maintenance.MatchMonNode(ctx, a.TargetID, a.At)
+	default:
+		window, rErr = p.maintenance.MatchGlobal(ctx, a.At)
+	}
+	if rErr != nil {
+		ctxutil.Logger(ctx, p.log).Error(
+			"查询维护窗口失败, 照常发出告警",
+			zap.Error(rErr),
+			zap.String("kind", a.Kind),
+			zap.Uint32("target_id", a.TargetID),
+		)
+		return nil
+	}
+	return window
+}
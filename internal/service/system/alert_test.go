@@ -0,0 +1,145 @@
+package system
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	sysmodel "gin-artweb/internal/model/system"
+	sysrepo "gin-artweb/internal/repository/system"
+	"gin-artweb/internal/shared/events"
+	"gin-artweb/internal/shared/metrics"
+	"gin-artweb/internal/shared/test"
+)
+
+const testAlertKind = "test_alert"
+
+type AlertPublisherTestSuite struct {
+	suite.Suite
+	db         *gorm.DB
+	windowRepo *sysrepo.MaintenanceWindowRepo
+	publisher  *AlertPublisher
+	now        time.Time
+}
+
+func (suite *AlertPublisherTestSuite) SetupTest() {
+	suite.db = test.NewTestGormDBWithConfig(nil)
+	suite.Require().NoError(suite.db.AutoMigrate(
+		&sysmodel.MaintenanceWindowModel{}, &sysmodel.AuditRecordModel{}, &events.OutboxModel{},
+	))
+	logger := test.NewTestZapLogger()
+	dbTimeout := test.NewTestDBTimeouts()
+	suite.windowRepo = sysrepo.NewMaintenanceWindowRepo(logger, suite.db, dbTimeout)
+	maintenance := NewMaintenanceService(
+		logger, suite.windowRepo, sysrepo.NewAuditRecordRepo(logger, suite.db, dbTimeout), nil, nil,
+	)
+	outbox := events.NewOutbox(logger, suite.db, events.NewBus(logger), nil)
+	suite.publisher = NewAlertPublisher(logger, maintenance, outbox)
+	suite.now = time.Date(2025, 1, 4, 21, 0, 0, 0, time.Local)
+}
+
+func (suite *AlertPublisherTestSuite) createWindow(scope, module string, targetID uint32) *sysmodel.MaintenanceWindowModel {
+	start := suite.now.Add(-time.Hour)
+	m := &sysmodel.MaintenanceWindowModel{
+		Name:       scope + module,
+		Scope:      scope,
+		Module:     module,
+		TargetID:   targetID,
+		StartAt:    start,
+		EndAt:      start.Add(2 * time.Hour),
+		Recurrence: sysmodel.MaintenanceRecurrenceNone,
+		IsEnabled:  true,
+	}
+	suite.Require().NoError(suite.windowRepo.CreateModel(context.Background(), m))
+	return m
+}
+
+func (suite *AlertPublisherTestSuite) alert(scope AlertScope, targetID uint32) Alert {
+	return Alert{
+		Kind:     testAlertKind,
+		Status:   "failed",
+		Scope:    scope,
+		Module:   "oes",
+		TargetID: targetID,
+		At:       suite.now,
+		Event:    sysmodel.CertExpiryEvent{Kind: testAlertKind, MonitorID: targetID},
+		Detail:   map[string]any{"target_id": targetID},
+	}
+}
+
+func (suite *AlertPublisherTestSuite) count(model any) int64 {
+	var n int64
+	suite.Require().NoError(suite.db.Model(model).Count(&n).Error)
+	return n
+}
+
+func (suite *AlertPublisherTestSuite) alertsTotal(result string) float64 {
+	var m dto.Metric
+	suite.Require().NoError(metrics.AlertsTotal.WithLabelValues(testAlertKind, "failed", result).Write(&m))
+	return m.GetCounter().GetValue()
+}
+
+func (suite *AlertPublisherTestSuite) TestPublishFired() {
+	fired, muted := suite.alertsTotal(metrics.AlertFired), suite.alertsTotal(metrics.AlertMuted)
+
+	suite.True(suite.publisher.Publish(context.Background(), suite.alert(AlertScopeGlobal, 1)))
+	suite.Equal(int64(1), suite.count(&events.OutboxModel{}), "告警事件写入发件箱")
+	suite.Zero(suite.count(&sysmodel.AuditRecordModel{}))
+	suite.Equal(fired+1, suite.alertsTotal(metrics.AlertFired))
+	suite.Equal(muted, suite.alertsTotal(metrics.AlertMuted))
+}
+
+func (suite *AlertPublisherTestSuite) TestPublishMuted() {
+	window := suite.createWindow(sysmodel.MaintenanceScopeGlobal, "", 0)
+	fired, muted := suite.alertsTotal(metrics.AlertFired), suite.alertsTotal(metrics.AlertMuted)
+
+	suite.False(suite.publisher.Publish(context.Background(), suite.alert(AlertScopeGlobal, 1)))
+	suite.Zero(suite.count(&events.OutboxModel{}), "屏蔽的告警不写入发件箱")
+	suite.Equal(fired, suite.alertsTotal(metrics.AlertFired))
+	suite.Equal(muted+1, suite.alertsTotal(metrics.AlertMuted))
+
+	var records []sysmodel.AuditRecordModel
+	suite.Require().NoError(suite.db.Find(&records).Error)
+	suite.Require().Len(records, 1)
+	suite.Equal(window.ID, records[0].ResourceID)
+	suite.Equal(sysmodel.MaintenanceActionMuteAlert, records[0].Action)
+	var detail map[string]any
+	suite.Require().NoError(json.Unmarshal([]byte(records[0].After), &detail))
+	suite.Equal(testAlertKind, detail["kind"], "审计详情记录告警类型")
+	suite.EqualValues(1, detail["target_id"])
+}
+
+func (suite *AlertPublisherTestSuite) TestPublishScope() {
+	suite.createWindow(sysmodel.MaintenanceScopeColony, "oes", 1)
+	ctx := context.Background()
+
+	suite.False(suite.publisher.Publish(ctx, suite.alert(AlertScopeColony, 1)), "集群窗口屏蔽所属集群的告警")
+	suite.True(suite.publisher.Publish(ctx, suite.alert(AlertScopeColony, 2)), "集群窗口不屏蔽其他集群的告警")
+	suite.True(suite.publisher.Publish(ctx, suite.alert(AlertScopeGlobal, 1)), "集群窗口不屏蔽只受全局窗口屏蔽的告警")
+
+	suite.createWindow(sysmodel.MaintenanceScopeGlobal, "", 0)
+	suite.True(suite.publisher.Publish(ctx, suite.alert(AlertScopeNone, 1)), "安全告警不受维护窗口屏蔽")
+	suite.Equal(int64(3), suite.count(&events.OutboxModel{}))
+
+	a := suite.alert(AlertScopeGlobal, 1)
+	a.At = suite.now.Add(2 * time.Hour)
+	suite.True(suite.publisher.Publish(ctx, a), "按告警时间匹配维护窗口")
+}
+
+func (suite *AlertPublisherTestSuite) TestPublishWithoutMaintenance() {
+	suite.createWindow(sysmodel.MaintenanceScopeGlobal, "", 0)
+	publisher := NewAlertPublisher(test.NewTestZapLogger(), nil, nil)
+	suite.True(publisher.Publish(context.Background(), suite.alert(AlertScopeGlobal, 1)), "未配置维护窗口时照常发出告警")
+
+	var nilPublisher *AlertPublisher
+	suite.True(nilPublisher.Publish(context.Background(), suite.alert(AlertScopeGlobal, 1)))
+}
+
+func TestAlertPublisherTestSuite(t *testing.T) {
+	suite.Run(t, new(AlertPublisherTestSuite))
+}
@@ -0,0 +1,258 @@
+package system
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	sysmodel "gin-artweb/internal/model/system"
+	sysrepo "gin-artweb/internal/repository/system"
+	"gin-artweb/internal/shared/auth"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+)
+
+const (
+	defaultAnalyticsBufferSize    = 1024
+	defaultAnalyticsRetentionDays = 90
+	analyticsFlushSize            = 100
+	analyticsFlushInterval        = 5 * time.Second
+)
+
+type AnalyticsService struct {
+	log       *zap.Logger
+	eventRepo *sysrepo.AnalyticsEventRepo
+	conf      config.AnalyticsConfig
+	events    chan sysmodel.AnalyticsEventModel
+
+	// 后台写入协程的停止信号, 停止前写入缓冲区中剩余的事件
+	stop chan struct{}
+	done chan struct{}
+}
+
+func NewAnalyticsService(
+	log *zap.Logger,
+	eventRepo *sysrepo.AnalyticsEventRepo,
+	conf *config.AnalyticsConfig,
+) *AnalyticsService {
+	c := config.AnalyticsConfig{}
+	if conf != nil {
+		c = *conf
+	}
+	if c.BufferSize <= 0 {
+		c.BufferSize = defaultAnalyticsBufferSize
+	}
+	if c.RetentionDays <= 0 {
+		c.RetentionDays = defaultAnalyticsRetentionDays
+	}
+	switch c.IdentityMode {
+	case sysmodel.IdentityModeHash, sysmodel.IdentityModePlain:
+	default:
+		c.IdentityMode = sysmodel.IdentityModeNone
+	}
+
+	s := &AnalyticsService{
+		log:       log,
+		eventRepo: eventRepo,
+		conf:      c,
+		events:    make(chan sysmodel.AnalyticsEventModel, c.BufferSize),
+	}
+	if c.Enable {
+		s.stop = make(chan struct{})
+		s.done = make(chan struct{})
+		go s.run()
+	}
+	return s
+}
+
+// Stop 停止后台写入协程, 等待缓冲区中剩余的事件写入数据库
+func (s *AnalyticsService) Stop(ctx context.Context) {
+	if s.stop == nil {
+		return
+	}
+	close(s.stop)
+	select {
+	case <-s.done:
+	case <-ctx.Done():
+		ctxutil.Logger(ctx, s.log).Warn("等待统计事件写入完成超时")
+	}
+}
+
+// Enabled 是否启用使用统计
+func (s *AnalyticsService) Enabled() bool {
+	return s.conf.Enable
+}
+
+// RecordUsage 记录一次功能使用事件
+// 事件通过缓冲区异步写入数据库，缓冲区已满时直接丢弃，不影响正常请求
+func (s *AnalyticsService) RecordUsage(
+	ctx context.Context,
+	feature, method string,
+	claims *auth.UserClaims,
+	statusCode int,
+) {
+	if !s.conf.Enable || feature == "" {
+		return
+	}
+
+	m := sysmodel.AnalyticsEventModel{
+		Feature:    feature,
+		Method:     method,
+		Module:     FeatureModule(feature),
+		StatusCode: statusCode,
+		OccurredAt: time.Now(),
+	}
+	if claims != nil {
+		m.RoleID = claims.RoleID
+		m.Subject = s.anonymize(claims.Username)
+	}
+
+	select {
+	case s.events <- m:
+	default:
+		ctxutil.Logger(ctx, s.log).Warn(
+			"统计事件缓冲区已满，丢弃事件",
+			zap.Object(database.ModelKey, &m),
+		)
+	}
+}
+
+// anonymize 根据配置对用户标识进行匿名化处理
+func (s *AnalyticsService) anonymize(username string) string {
+	if username == "" {
+		return ""
+	}
+	switch s.conf.IdentityMode {
+	case sysmodel.IdentityModePlain:
+		return username
+	case sysmodel.IdentityModeHash:
+		sum := sha256.Sum256([]byte(s.conf.HashSalt + username))
+		return hex.EncodeToString(sum[:16])
+	default:
+		return ""
+	}
+}
+
+// run 批量写入统计事件, 达到批量大小或定时写入, 停止时写入缓冲区中剩余的事件后退出
+func (s *AnalyticsService) run() {
+	defer close(s.done)
+	ticker := time.NewTicker(analyticsFlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]sysmodel.AnalyticsEventModel, 0, analyticsFlushSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := s.eventRepo.CreateModels(context.Background(), batch); err != nil {
+			s.log.Error(
+				"写入统计事件失败",
+				zap.Error(err),
+				zap.Int("count", len(batch)),
+			)
+		}
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case m := <-s.events:
+			batch = append(batch, m)
+			if len(batch) >= analyticsFlushSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-s.stop:
+			for {
+				select {
+				case m := <-s.events:
+					batch = append(batch, m)
+					if len(batch) >= analyticsFlushSize {
+						flush()
+					}
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+func (s *AnalyticsService) ListUsage(
+	ctx context.Context,
+	groupBy []string,
+	query map[string]any,
+	limit int,
+) (*[]sysmodel.AnalyticsUsage, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始查询功能使用统计",
+		zap.Strings("group_by", groupBy),
+		zap.Any(database.ConditionsKey, query),
+	)
+
+	us, err := s.eventRepo.AggregateModel(ctx, groupBy, query, limit)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询功能使用统计失败",
+			zap.Error(err),
+			zap.Strings("group_by", groupBy),
+			zap.Any(database.ConditionsKey, query),
+		)
+		return nil, errors.NewGormError(err, nil)
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"查询功能使用统计成功",
+		zap.Strings("group_by", groupBy),
+	)
+	return us, nil
+}
+
+// PurgeExpiredEvents 清理超过保留天数的统计事件
+func (s *AnalyticsService) PurgeExpiredEvents(ctx context.Context) *errors.Error {
+	if ctx.Err() != nil {
+		return errors.FromError(ctx.Err())
+	}
+
+	before := time.Now().AddDate(0, 0, -s.conf.RetentionDays)
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始清理过期统计事件",
+		zap.Time("before", before),
+	)
+
+	if err := s.eventRepo.DeleteModel(ctx, "occurred_at < ?", before); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"清理过期统计事件失败",
+			zap.Error(err),
+			zap.Time("before", before),
+		)
+		return errors.NewGormError(err, nil)
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"清理过期统计事件成功",
+		zap.Time("before", before),
+	)
+	return nil
+}
+
+// FeatureModule 从路由路径中解析所属模块, 如 /api/v1/mon/node -> mon
+func FeatureModule(feature string) string {
+	parts := strings.Split(strings.Trim(feature, "/"), "/")
+	if len(parts) >= 3 && parts[0] == "api" {
+		return parts[2]
+	}
+	return ""
+}
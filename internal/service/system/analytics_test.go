@@ -0,0 +1,54 @@
+package system
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	sysmodel "gin-artweb/internal/model/system"
+	sysrepo "gin-artweb/internal/repository/system"
+	"gin-artweb/internal/shared/auth"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/test"
+)
+
+type AnalyticsServiceTestSuite struct {
+	suite.Suite
+	db        *gorm.DB
+	eventRepo *sysrepo.AnalyticsEventRepo
+}
+
+func (suite *AnalyticsServiceTestSuite) SetupTest() {
+	suite.db = test.NewTestGormDBWithConfig(nil)
+	suite.Require().NoError(suite.db.AutoMigrate(&sysmodel.AnalyticsEventModel{}))
+	suite.eventRepo = sysrepo.NewAnalyticsEventRepo(test.NewTestZapLogger(), suite.db, test.NewTestDBTimeouts())
+}
+
+func (suite *AnalyticsServiceTestSuite) TestStopFlushesPending() {
+	service := NewAnalyticsService(test.NewTestZapLogger(), suite.eventRepo, &config.AnalyticsConfig{Enable: true})
+	claims := &auth.UserClaims{UserInfo: auth.UserInfo{Username: "admin"}}
+	for range 3 {
+		service.RecordUsage(context.Background(), "oes.colony", http.MethodGet, claims, http.StatusOK)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	service.Stop(ctx)
+
+	var count int64
+	suite.Require().NoError(suite.db.Model(&sysmodel.AnalyticsEventModel{}).Count(&count).Error)
+	suite.Equal(int64(3), count, "停止前写入缓冲区中剩余的事件")
+}
+
+func (suite *AnalyticsServiceTestSuite) TestStopDisabled() {
+	service := NewAnalyticsService(test.NewTestZapLogger(), suite.eventRepo, nil)
+	suite.NotPanics(func() { service.Stop(context.Background()) }, "未启用时没有后台协程")
+}
+
+func TestAnalyticsServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(AnalyticsServiceTestSuite))
+}
@@ -0,0 +1,93 @@
+package system
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	sysmodel "gin-artweb/internal/model/system"
+	sysrepo "gin-artweb/internal/repository/system"
+	"gin-artweb/internal/shared/auth"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+)
+
+type AuditService struct {
+	log       *zap.Logger
+	auditRepo *sysrepo.AuditRecordRepo
+}
+
+func NewAuditService(
+	log *zap.Logger,
+	auditRepo *sysrepo.AuditRecordRepo,
+) *AuditService {
+	return &AuditService{
+		log:       log,
+		auditRepo: auditRepo,
+	}
+}
+
+func (s *AuditService) ListAuditRecord(
+	ctx context.Context,
+	qp database.QueryParams,
+) (int64, *[]sysmodel.AuditRecordModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return 0, nil, errors.FromError(ctx.Err())
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始查询审计记录列表",
+		zap.Object(database.QueryParamsKey, &qp),
+	)
+
+	count, ms, err := s.auditRepo.ListModel(ctx, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询审计记录列表失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		return 0, nil, errors.NewGormError(err, nil)
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"查询审计记录列表成功",
+		zap.Object(database.QueryParamsKey, &qp),
+	)
+	return count, ms, nil
+}
+
+// RecordImpersonatedRequest 记录使用代登录令牌的请求, 操作用户为代为登录的管理员, 实现middleware.ImpersonationRecorder
+func (s *AuditService) RecordImpersonatedRequest(
+	ctx context.Context,
+	claims *auth.UserClaims,
+	method string,
+	route string,
+	statusCode int,
+) {
+	action := method + " " + route
+	if len(action) > 50 {
+		action = action[:50]
+	}
+	record, err := sysmodel.NewAuditRecord(
+		sysmodel.ImpersonationAuditModule, sysmodel.ImpersonationAuditResource, claims.UserID, action,
+		nil, map[string]any{
+			"username":    claims.Username,
+			"session_id":  claims.SessionID,
+			"status_code": statusCode,
+		}, claims.ImpersonatorName, ctxutil.GetTraceID(ctx),
+	)
+	if err == nil {
+		err = s.auditRepo.CreateModel(ctx, record)
+	}
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"记录代登录请求审计记录失败",
+			zap.Error(err),
+			zap.String("impersonator", claims.ImpersonatorName),
+			zap.Uint32("user_id", claims.UserID),
+			zap.String("action", action),
+		)
+	}
+}
@@ -0,0 +1,637 @@
+package system
+
+import (
+	"bufio"
+	"cmp"
+	"context"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	emperror "emperror.dev/errors"
+	"go.uber.org/zap"
+
+	sysmodel "gin-artweb/internal/model/system"
+	sysrepo "gin-artweb/internal/repository/system"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+	"gin-artweb/pkg/archive"
+	"gin-artweb/pkg/storage"
+)
+
+func init() {
+	// 数据库驱动读取的时间字段为time.Time, 需要注册后才能作为interface值编码
+	gob.Register(time.Time{})
+}
+
+const (
+	backupManifestFile = "manifest.json"
+	backupDBDir        = "db"
+	backupFilesDir     = "files"
+)
+
+// BackupService 数据备份服务
+//
+// 备份时整表导出数据库中的全部数据, 连同上传文件目录打包为tar.gz文件, 保存到配置的存储后端;
+// 恢复通过命令行参数-restore执行, 只能恢复到没有数据的数据库, 数据库结构版本必须与备份时一致
+type BackupService struct {
+	log           *zap.Logger
+	backupRepo    *sysrepo.BackupRepo
+	dumpRepo      *sysrepo.DumpRepo
+	tx            *database.TxManager
+	models        []any
+	schemaVersion string
+	store         storage.Storage
+	keyPrefix     string
+	fileRoot      string   // 上传文件的根目录
+	dirs          []string // 备份的上传文件目录, 相对于fileRoot
+	workDir       string   // 打包和解压备份文件的临时目录
+	keep          int
+	batchSize     int
+	// running 同一时间只执行一个备份
+	running sync.Mutex
+}
+
+func NewBackupService(
+	log *zap.Logger,
+	backupRepo *sysrepo.BackupRepo,
+	dumpRepo *sysrepo.DumpRepo,
+	models []any,
+	schemaVersion string,
+	store storage.Storage,
+	fileRoot string,
+	dirs []string,
+	conf *config.BackupConfig,
+	tx *database.TxManager,
+) *BackupService {
+	var c config.BackupConfig
+	if conf != nil {
+		c = *conf
+	}
+	return &BackupService{
+		log:           log,
+		backupRepo:    backupRepo,
+		dumpRepo:      dumpRepo,
+		tx:            tx,
+		models:        models,
+		schemaVersion: schemaVersion,
+		store:         store,
+		fileRoot:      fileRoot,
+		dirs:          dirs,
+		workDir:       filepath.Join(fileRoot, "tmp"),
+		keep:          c.Keep,
+		batchSize:     cmp.Or(c.BatchSize, 1000),
+	}
+}
+
+// SetStore 使用指定的存储后端保存备份文件, keyPrefix为备份文件key的前缀
+//
+// 资源模块在程序包使用S3或SFTP存储时调用, 需要在服务启动前调用
+func (s *BackupService) SetStore(store storage.Storage, keyPrefix string) {
+	s.store = store
+	s.keyPrefix = keyPrefix
+}
+
+// Backup 执行一次备份, report不为空时上报进度
+//
+// 已有备份正在执行时返回ErrBackupRunning, 备份失败时备份记录的状态为失败并返回错误
+func (s *BackupService) Backup(
+	ctx context.Context,
+	trigger string,
+	username string,
+	report func(percent int, message string),
+) (*sysmodel.BackupModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+	if !s.running.TryLock() {
+		return nil, errors.ErrBackupRunning
+	}
+	defer s.running.Unlock()
+	if report == nil {
+		report = func(int, string) {}
+	}
+
+	name := fmt.Sprintf("backup-%s.tar.gz", time.Now().Format("20060102-150405"))
+	m := &sysmodel.BackupModel{
+		Name:          name,
+		StorageKey:    path.Join(s.keyPrefix, name),
+		StorageType:   s.store.Type(),
+		Trigger:       trigger,
+		Status:        sysmodel.BackupRunning,
+		SchemaVersion: s.schemaVersion,
+		Username:      username,
+	}
+	if err := s.backupRepo.CreateModel(ctx, m); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"创建备份记录失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, m),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"name": name})
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始备份",
+		zap.Object(database.ModelKey, m),
+	)
+	startTime := time.Now()
+	manifest, size, err := s.writeBackup(ctx, m.StorageKey, report)
+	finishedAt := time.Now()
+	data := map[string]any{"finished_at": finishedAt}
+	if err != nil {
+		data["status"] = sysmodel.BackupFailed
+		data["error"] = truncateBackupError(err.Error())
+	} else {
+		data["status"] = sysmodel.BackupSucceeded
+		data["size"] = size
+		data["table_count"] = len(manifest.Tables)
+		data["row_count"] = manifest.RowCount()
+		data["file_count"] = manifest.FileCount
+	}
+	// 备份被取消时仍然需要更新备份记录
+	uctx := context.WithoutCancel(ctx)
+	if uErr := s.backupRepo.UpdateModel(uctx, data, "id = ?", m.ID); uErr != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"更新备份记录失败",
+			zap.Error(uErr),
+			zap.Uint32("backup_id", m.ID),
+		)
+	}
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"备份失败",
+			zap.Error(err),
+			zap.Uint32("backup_id", m.ID),
+			zap.Duration("duration", time.Since(startTime)),
+		)
+		return nil, errors.ErrBackupFailed.WithCause(err)
+	}
+	ctxutil.Logger(ctx, s.log).Info(
+		"备份成功",
+		zap.Uint32("backup_id", m.ID),
+		zap.String("key", m.StorageKey),
+		zap.Int64("size", size),
+		zap.Duration("duration", time.Since(startTime)),
+	)
+
+	if rErr := s.cleanup(uctx); rErr != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"清理过期备份失败",
+			zap.Error(rErr),
+		)
+	}
+	return s.FindBackupByID(uctx, m.ID)
+}
+
+// writeBackup 导出数据和上传文件, 打包后写入存储, 返回备份清单和备份文件大小
+func (s *BackupService) writeBackup(
+	ctx context.Context,
+	key string,
+	report func(percent int, message string),
+) (*sysmodel.BackupManifest, int64, error) {
+	if err := os.MkdirAll(s.workDir, 0o755); err != nil {
+		return nil, 0, err
+	}
+	staging, err := os.MkdirTemp(s.workDir, "backup-")
+	if err != nil {
+		return nil, 0, err
+	}
+	defer os.RemoveAll(staging)
+	contentDir := filepath.Join(staging, "content")
+
+	manifest := &sysmodel.BackupManifest{
+		Version:       sysmodel.BackupManifestVersion,
+		SchemaVersion: s.schemaVersion,
+		Dialect:       s.dumpRepo.Dialect(),
+		CreatedAt:     time.Now(),
+	}
+	tables, err := s.dumpRepo.Tables(ctx, s.models)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := os.MkdirAll(filepath.Join(contentDir, backupDBDir), 0o755); err != nil {
+		return nil, 0, err
+	}
+	for i, table := range tables {
+		report(i*70/len(tables), "导出数据表 "+table)
+		rows, err := s.dumpTable(ctx, table, filepath.Join(contentDir, backupDBDir, table+".gob"))
+		if err != nil {
+			return nil, 0, err
+		}
+		manifest.Tables = append(manifest.Tables, sysmodel.BackupTableInfo{Name: table, Rows: rows})
+	}
+
+	report(70, "复制上传文件")
+	for _, dir := range s.dirs {
+		n, err := copyBackupDir(filepath.Join(s.fileRoot, dir), filepath.Join(contentDir, backupFilesDir, dir))
+		if err != nil {
+			return nil, 0, err
+		}
+		if n > 0 {
+			manifest.Dirs = append(manifest.Dirs, dir)
+			manifest.FileCount += n
+		}
+	}
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := os.WriteFile(filepath.Join(contentDir, backupManifestFile), data, 0o644); err != nil {
+		return nil, 0, err
+	}
+
+	report(80, "压缩备份文件")
+	archivePath := filepath.Join(staging, path.Base(key))
+	if err := archive.TarGz(contentDir, archivePath, backupArchiveOptions(ctx)...); err != nil {
+		return nil, 0, err
+	}
+
+	report(90, "上传备份文件")
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := s.store.Put(ctx, key, f, info.Size()); err != nil {
+		return nil, 0, emperror.WrapIf(err, "写入备份文件失败")
+	}
+	return manifest, info.Size(), nil
+}
+
+// dumpTable 将表中的数据按批写入gob文件, 每批为一个[]map[string]any
+func (s *BackupService) dumpTable(ctx context.Context, table, filename string) (int64, error) {
+	f, err := os.Create(filename)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	w := bufio.NewWriter(f)
+	enc := gob.NewEncoder(w)
+	rows, err := s.dumpRepo.Dump(ctx, table, s.batchSize, func(rows []map[string]any) error {
+		return emperror.WrapIff(enc.Encode(rows), "写入表%s的数据失败", table)
+	})
+	if err != nil {
+		return 0, err
+	}
+	if err := w.Flush(); err != nil {
+		return 0, err
+	}
+	return rows, f.Close()
+}
+
+// cleanup 只保留最近keep个成功的备份, 删除更早的备份文件和备份记录
+func (s *BackupService) cleanup(ctx context.Context) *errors.Error {
+	if s.keep <= 0 {
+		return nil
+	}
+	_, ms, rErr := s.ListBackup(ctx, database.QueryParams{
+		OrderBy: []string{"id DESC"},
+		Query:   map[string]any{"status = ?": sysmodel.BackupSucceeded},
+	})
+	if rErr != nil {
+		return rErr
+	}
+	if len(*ms) <= s.keep {
+		return nil
+	}
+	for _, m := range (*ms)[s.keep:] {
+		if rErr := s.DeleteBackupByID(ctx, m.ID); rErr != nil {
+			return rErr
+		}
+		ctxutil.Logger(ctx, s.log).Info(
+			"删除过期备份",
+			zap.Uint32("backup_id", m.ID),
+			zap.String("name", m.Name),
+		)
+	}
+	return nil
+}
+
+func (s *BackupService) FindBackupByID(
+	ctx context.Context,
+	backupID uint32,
+) (*sysmodel.BackupModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	m, err := s.backupRepo.GetModel(ctx, nil, backupID)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询备份失败",
+			zap.Error(err),
+			zap.Uint32("backup_id", backupID),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"id": backupID})
+	}
+	return m, nil
+}
+
+func (s *BackupService) ListBackup(
+	ctx context.Context,
+	qp database.QueryParams,
+) (int64, *[]sysmodel.BackupModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return 0, nil, errors.FromError(ctx.Err())
+	}
+
+	count, ms, err := s.backupRepo.ListModel(ctx, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询备份列表失败",
+			zap.Error(err),
+		)
+		return 0, nil, errors.NewGormError(err, nil)
+	}
+	return count, ms, nil
+}
+
+// DeleteBackupByID 删除备份文件和备份记录, 不能删除正在执行的备份
+func (s *BackupService) DeleteBackupByID(ctx context.Context, backupID uint32) *errors.Error {
+	if ctx.Err() != nil {
+		return errors.FromError(ctx.Err())
+	}
+
+	m, rErr := s.FindBackupByID(ctx, backupID)
+	if rErr != nil {
+		return rErr
+	}
+	if m.Status == sysmodel.BackupRunning {
+		return errors.ErrBackupRunning
+	}
+	if m.StorageKey != "" {
+		if err := s.store.Delete(ctx, m.StorageKey); err != nil {
+			ctxutil.Logger(ctx, s.log).Error(
+				"删除备份文件失败",
+				zap.Error(err),
+				zap.String("key", m.StorageKey),
+				zap.Uint32("backup_id", backupID),
+			)
+			return errors.ErrStorageUnavailable.WithCause(err)
+		}
+	}
+	if err := s.backupRepo.DeleteModel(ctx, backupID); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"删除备份记录失败",
+			zap.Error(err),
+			zap.Uint32("backup_id", backupID),
+		)
+		return errors.NewGormError(err, map[string]any{"id": backupID})
+	}
+	return nil
+}
+
+// OpenBackup 打开备份文件, 调用方负责关闭, 只能下载成功的备份
+func (s *BackupService) OpenBackup(
+	ctx context.Context,
+	m sysmodel.BackupModel,
+) (io.ReadCloser, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+	if m.Status != sysmodel.BackupSucceeded {
+		return nil, errors.ErrBackupNotReady.WithField("status", m.Status)
+	}
+
+	rc, err := s.store.Get(ctx, m.StorageKey)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"读取备份文件失败",
+			zap.Error(err),
+			zap.String("storage", s.store.Type()),
+			zap.String("key", m.StorageKey),
+			zap.Uint32("backup_id", m.ID),
+		)
+		if emperror.Is(err, storage.ErrNotExist) {
+			return nil, errors.ErrDownloadFileNotFound.WithField("key", m.StorageKey)
+		}
+		return nil, errors.ErrStorageUnavailable.WithCause(err)
+	}
+	return rc, nil
+}
+
+// FailInterrupted 将上次服务关闭时未结束的备份标记为失败, 在服务启动时调用
+func (s *BackupService) FailInterrupted(ctx context.Context) *errors.Error {
+	if ctx.Err() != nil {
+		return errors.FromError(ctx.Err())
+	}
+
+	err := s.backupRepo.UpdateModel(ctx, map[string]any{
+		"status":      sysmodel.BackupFailed,
+		"error":       "服务关闭时备份未完成",
+		"finished_at": time.Now(),
+	}, "status = ?", sysmodel.BackupRunning)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"标记未完成的备份失败",
+			zap.Error(err),
+		)
+		return errors.NewGormError(err, nil)
+	}
+	return nil
+}
+
+// Restore 将备份文件恢复到当前数据库和上传文件目录, 返回备份清单
+//
+// 调用前需要执行数据库迁移, 备份的数据库结构版本必须与程序一致;
+// 数据库中的表必须都没有数据, 避免与已有数据混合; 加密字段按密文恢复, 需要使用与备份时相同的字段加密密钥.
+// 数据导入和上传文件复制在同一事务中执行, 任一步骤失败时回滚已导入的数据, 数据库保持为空;
+// 已复制的上传文件不会删除, 重新恢复时会覆盖
+func (s *BackupService) Restore(ctx context.Context, filename string) (*sysmodel.BackupManifest, error) {
+	if s.dumpRepo.Dialect() == database.DialectSQLServer {
+		return nil, emperror.New("SQL Server不支持恢复备份")
+	}
+	if err := os.MkdirAll(s.workDir, 0o755); err != nil {
+		return nil, err
+	}
+	staging, err := os.MkdirTemp(s.workDir, "restore-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(staging)
+	if err := archive.UntarGz(filename, staging, backupArchiveOptions(ctx)...); err != nil {
+		return nil, emperror.WrapIf(err, "解压备份文件失败")
+	}
+
+	data, err := os.ReadFile(filepath.Join(staging, backupManifestFile))
+	if err != nil {
+		return nil, emperror.WrapIf(err, "读取备份清单失败")
+	}
+	var manifest sysmodel.BackupManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, emperror.WrapIf(err, "解析备份清单失败")
+	}
+	if manifest.Version != sysmodel.BackupManifestVersion {
+		return nil, emperror.Errorf("不支持的备份文件版本: %d", manifest.Version)
+	}
+	if manifest.SchemaVersion != s.schemaVersion {
+		return nil, emperror.Errorf(
+			"备份的数据库结构版本%s与程序的版本%s不一致, 请使用备份时的程序版本恢复",
+			manifest.SchemaVersion, s.schemaVersion,
+		)
+	}
+
+	// 只导入数据库中存在的表, 表名不直接使用备份文件中的内容
+	tables, err := s.dumpRepo.Tables(ctx, s.models)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range manifest.Tables {
+		if !slices.Contains(tables, t.Name) {
+			return nil, emperror.Errorf("数据库中不存在备份的表%s, 请先执行数据库迁移", t.Name)
+		}
+	}
+	for _, table := range tables {
+		count, err := s.dumpRepo.Count(ctx, table)
+		if err != nil {
+			return nil, err
+		}
+		if count > 0 {
+			return nil, emperror.Errorf("表%s中已有%d条数据, 只能恢复到没有数据的数据库", table, count)
+		}
+	}
+
+	for _, dir := range manifest.Dirs {
+		if !slices.Contains(s.dirs, dir) {
+			return nil, emperror.Errorf("不支持恢复的文件目录: %s", dir)
+		}
+	}
+
+	err = database.Transactional(ctx, s.tx, func(ctx context.Context) error {
+		for _, t := range manifest.Tables {
+			rows, err := s.restoreTable(ctx, t.Name, filepath.Join(staging, backupDBDir, t.Name+".gob"))
+			if err != nil {
+				return err
+			}
+			if rows != t.Rows {
+				return emperror.Errorf("表%s导入%d条数据, 与备份的%d条不一致", t.Name, rows, t.Rows)
+			}
+			if err := s.dumpRepo.ResetSequence(ctx, t.Name); err != nil {
+				return err
+			}
+			ctxutil.Logger(ctx, s.log).Info("恢复数据表成功", zap.String("table", t.Name), zap.Int64("rows", rows))
+		}
+
+		for _, dir := range manifest.Dirs {
+			if _, err := copyBackupDir(filepath.Join(staging, backupFilesDir, dir), filepath.Join(s.fileRoot, dir)); err != nil {
+				return err
+			}
+			ctxutil.Logger(ctx, s.log).Info("恢复上传文件成功", zap.String("dir", dir))
+		}
+		return nil
+	}, func(err error) error {
+		return emperror.WrapIf(err, "提交恢复数据的事务失败")
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &manifest, nil
+}
+
+// restoreTable 按批导入gob文件中的数据, 返回导入的记录数
+func (s *BackupService) restoreTable(ctx context.Context, table, filename string) (int64, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return 0, emperror.WrapIff(err, "读取表%s的备份数据失败", table)
+	}
+	defer f.Close()
+	dec := gob.NewDecoder(bufio.NewReader(f))
+	var total int64
+	for {
+		var rows []map[string]any
+		if err := dec.Decode(&rows); err != nil {
+			if emperror.Is(err, io.EOF) {
+				return total, nil
+			}
+			return total, emperror.WrapIff(err, "读取表%s的备份数据失败", table)
+		}
+		if err := s.dumpRepo.Restore(ctx, table, rows); err != nil {
+			return total, err
+		}
+		total += int64(len(rows))
+	}
+}
+
+// backupArchiveOptions 备份文件包含全部数据和上传文件, 不限制文件数量和大小
+func backupArchiveOptions(ctx context.Context) []archive.ArchiveOption {
+	return []archive.ArchiveOption{
+		archive.WithContext(ctx),
+		archive.WithMaxFileSize(0),
+		archive.WithMaxFiles(0),
+		archive.WithMaxTotalSize(0),
+		archive.WithMaxCompressionRatio(0),
+		archive.WithMaxDepth(0),
+	}
+}
+
+// copyBackupDir 复制目录下的普通文件, 优先使用硬链接, 源目录不存在时返回0, 返回复制的文件数
+func copyBackupDir(src, dst string) (int, error) {
+	if _, err := os.Stat(src); os.IsNotExist(err) {
+		return 0, nil
+	}
+	count := 0
+	err := filepath.WalkDir(src, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if d.IsDir() {
+			return os.MkdirAll(target, 0o755)
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		if err := os.Link(p, target); err != nil {
+			if err := copyBackupFile(p, target); err != nil {
+				return err
+			}
+		}
+		count++
+		return nil
+	})
+	return count, err
+}
+
+func copyBackupFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+func truncateBackupError(s string) string {
+	r := []rune(s)
+	if len(r) > 1024 {
+		return string(r[:1024])
+	}
+	return s
+}
@@ -0,0 +1,197 @@
+package system
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	sysmodel "gin-artweb/internal/model/system"
+	sysrepo "gin-artweb/internal/repository/system"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+	"gin-artweb/internal/shared/test"
+	"gin-artweb/pkg/storage"
+)
+
+var backupTestModels = []any{&sysmodel.CertMonitorModel{}, &sysmodel.BackupModel{}}
+
+type BackupServiceTestSuite struct {
+	suite.Suite
+	db       *gorm.DB
+	fileRoot string
+	store    storage.Storage
+	service  *BackupService
+}
+
+func (suite *BackupServiceTestSuite) SetupTest() {
+	suite.db = test.NewTestGormDBWithConfig(nil)
+	suite.Require().NoError(suite.db.AutoMigrate(backupTestModels...))
+	suite.fileRoot = suite.T().TempDir()
+	suite.store = storage.NewLocalStorage(filepath.Join(suite.fileRoot, "backups"))
+	suite.service = suite.newService(suite.db, suite.fileRoot, &config.BackupConfig{Keep: 2, BatchSize: 2})
+}
+
+func (suite *BackupServiceTestSuite) newService(db *gorm.DB, fileRoot string, conf *config.BackupConfig) *BackupService {
+	logger := test.NewTestZapLogger()
+	timeouts := test.NewTestDBTimeouts()
+	return NewBackupService(
+		logger,
+		sysrepo.NewBackupRepo(logger, db, timeouts),
+		sysrepo.NewDumpRepo(logger, db, timeouts),
+		backupTestModels,
+		"000033",
+		suite.store,
+		fileRoot,
+		[]string{"script"},
+		conf,
+		database.NewTxManager(db),
+	)
+}
+
+func (suite *BackupServiceTestSuite) TestBackupAndRestore() {
+	ctx := context.Background()
+	for _, name := range []string{"a", "b", "c"} {
+		suite.Require().NoError(suite.db.Create(&sysmodel.CertMonitorModel{
+			Name:    name,
+			Kind:    sysmodel.CertKindFile,
+			Address: "/etc/ssl/" + name + ".crt",
+			Status:  sysmodel.CertUnknown,
+		}).Error)
+	}
+	scriptDir := filepath.Join(suite.fileRoot, "script", "mds")
+	suite.Require().NoError(os.MkdirAll(scriptDir, 0o755))
+	suite.Require().NoError(os.WriteFile(filepath.Join(scriptDir, "start.sh"), []byte("echo start"), 0o644))
+
+	var percents []int
+	m, rErr := suite.service.Backup(ctx, sysmodel.BackupTriggerManual, "admin", func(percent int, _ string) {
+		percents = append(percents, percent)
+	})
+	suite.Require().Nil(rErr)
+	suite.Equal(sysmodel.BackupSucceeded, m.Status)
+	suite.Equal(2, m.TableCount)
+	suite.Equal(int64(4), m.RowCount, "应该包含证书监控和正在执行的备份记录")
+	suite.Equal(1, m.FileCount)
+	suite.Positive(m.Size)
+	suite.NotEmpty(percents)
+
+	rc, rErr := suite.service.OpenBackup(ctx, *m)
+	suite.Require().Nil(rErr)
+	archivePath := filepath.Join(suite.T().TempDir(), m.Name)
+	f, err := os.Create(archivePath)
+	suite.Require().NoError(err)
+	_, err = io.Copy(f, rc)
+	suite.Require().NoError(err)
+	suite.Require().NoError(f.Close())
+	suite.Require().NoError(rc.Close())
+
+	_, err = suite.service.Restore(ctx, archivePath)
+	suite.Error(err, "已有数据的数据库不应该恢复")
+
+	db := test.NewTestGormDBWithConfig(nil)
+	suite.Require().NoError(db.AutoMigrate(backupTestModels...))
+	restoreRoot := suite.T().TempDir()
+	manifest, err := suite.newService(db, restoreRoot, nil).Restore(ctx, archivePath)
+	suite.Require().NoError(err)
+	suite.Equal(int64(4), manifest.RowCount())
+
+	var ms []sysmodel.CertMonitorModel
+	suite.Require().NoError(db.Order("id").Find(&ms).Error)
+	suite.Require().Len(ms, 3)
+	suite.Equal("a", ms[0].Name)
+	suite.Nil(ms[0].NotAfter)
+	data, err := os.ReadFile(filepath.Join(restoreRoot, "script", "mds", "start.sh"))
+	suite.Require().NoError(err)
+	suite.Equal("echo start", string(data))
+
+	other := suite.newService(db, restoreRoot, nil)
+	other.schemaVersion = "000034"
+	_, err = other.Restore(ctx, archivePath)
+	suite.ErrorContains(err, "版本", "数据库结构版本不一致时不应该恢复")
+}
+
+func (suite *BackupServiceTestSuite) TestRestoreRollback() {
+	ctx := context.Background()
+	suite.Require().NoError(suite.db.Create(&sysmodel.CertMonitorModel{
+		Name:    "a",
+		Kind:    sysmodel.CertKindFile,
+		Address: "/etc/ssl/a.crt",
+		Status:  sysmodel.CertUnknown,
+	}).Error)
+	m, rErr := suite.service.Backup(ctx, sysmodel.BackupTriggerManual, "admin", nil)
+	suite.Require().Nil(rErr)
+	rc, rErr := suite.service.OpenBackup(ctx, *m)
+	suite.Require().Nil(rErr)
+	archivePath := filepath.Join(suite.T().TempDir(), m.Name)
+	f, err := os.Create(archivePath)
+	suite.Require().NoError(err)
+	_, err = io.Copy(f, rc)
+	suite.Require().NoError(err)
+	suite.Require().NoError(f.Close())
+	suite.Require().NoError(rc.Close())
+
+	// 备份记录表缺少字段, 证书监控表导入后备份记录表导入失败
+	db := test.NewTestGormDBWithConfig(nil)
+	suite.Require().NoError(db.AutoMigrate(&sysmodel.CertMonitorModel{}))
+	suite.Require().NoError(db.Exec("CREATE TABLE system_backup (id integer PRIMARY KEY)").Error)
+	_, err = suite.newService(db, suite.T().TempDir(), nil).Restore(ctx, archivePath)
+	suite.Require().Error(err)
+
+	var count int64
+	suite.Require().NoError(db.Model(&sysmodel.CertMonitorModel{}).Count(&count).Error)
+	suite.Zero(count, "恢复失败时回滚已导入的数据")
+}
+
+func (suite *BackupServiceTestSuite) TestCleanup() {
+	ctx := context.Background()
+	var ids []uint32
+	for range 3 {
+		m := &sysmodel.BackupModel{
+			Name:       suite.T().Name() + string(rune('a'+len(ids))),
+			Trigger:    sysmodel.BackupTriggerScheduled,
+			Status:     sysmodel.BackupSucceeded,
+			StorageKey: "old",
+		}
+		suite.Require().NoError(suite.db.Create(m).Error)
+		ids = append(ids, m.ID)
+	}
+
+	m, rErr := suite.service.Backup(ctx, sysmodel.BackupTriggerScheduled, "", nil)
+	suite.Require().Nil(rErr)
+
+	_, ms, rErr := suite.service.ListBackup(ctx, database.QueryParams{OrderBy: []string{"id DESC"}})
+	suite.Require().Nil(rErr)
+	suite.Len(*ms, 2, "应该只保留最近的2个成功的备份")
+	suite.Equal(m.ID, (*ms)[0].ID)
+	suite.Equal(ids[2], (*ms)[1].ID)
+}
+
+func (suite *BackupServiceTestSuite) TestNotReady() {
+	ctx := context.Background()
+	m := &sysmodel.BackupModel{
+		Name:    "running.tar.gz",
+		Trigger: sysmodel.BackupTriggerManual,
+		Status:  sysmodel.BackupRunning,
+	}
+	suite.Require().NoError(suite.db.Create(m).Error)
+
+	_, rErr := suite.service.OpenBackup(ctx, *m)
+	suite.Equal(errors.ErrBackupNotReady.Reason, rErr.Reason)
+	rErr = suite.service.DeleteBackupByID(ctx, m.ID)
+	suite.Equal(errors.ErrBackupRunning.Reason, rErr.Reason, "不能删除正在执行的备份")
+
+	suite.Require().Nil(suite.service.FailInterrupted(ctx))
+	fm, rErr := suite.service.FindBackupByID(ctx, m.ID)
+	suite.Require().Nil(rErr)
+	suite.Equal(sysmodel.BackupFailed, fm.Status)
+	suite.NotNil(fm.FinishedAt)
+}
+
+func TestBackupServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(BackupServiceTestSuite))
+}
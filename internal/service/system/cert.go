@@ -0,0 +1,492 @@
+package system
+
+import (
+	"cmp"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	sysmodel "gin-artweb/internal/model/system"
+	sysrepo "gin-artweb/internal/repository/system"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+)
+
+// defaultCertThresholds 未配置告警阈值时使用的剩余天数阈值
+var defaultCertThresholds = []int{30, 14, 3}
+
+// CertMonitorService 证书过期监控服务
+//
+// 定时连接TLS服务或读取平台服务器上的证书文件, 记录证书的过期时间并校验证书链.
+// 剩余天数每到达一个告警阈值告警一次, 证书过期、证书链无效或无法读取证书时同样告警,
+// 证书更新后重新计算, 处于全局维护窗口内时屏蔽告警
+type CertMonitorService struct {
+	log        *zap.Logger
+	certRepo   *sysrepo.CertMonitorRepo
+	alerts     *AlertPublisher
+	thresholds []int
+	timeout    time.Duration
+	// mu 串行化定时检查和立即检查
+	mu sync.Mutex
+}
+
+func NewCertMonitorService(
+	log *zap.Logger,
+	certRepo *sysrepo.CertMonitorRepo,
+	alerts *AlertPublisher,
+	conf *config.CertMonitorConfig,
+) *CertMonitorService {
+	var c config.CertMonitorConfig
+	if conf != nil {
+		c = *conf
+	}
+	thresholds := c.Thresholds
+	if len(thresholds) == 0 {
+		thresholds = defaultCertThresholds
+	}
+	return &CertMonitorService{
+		log:        log,
+		certRepo:   certRepo,
+		alerts:     alerts,
+		thresholds: thresholds,
+		timeout:    time.Duration(cmp.Or(c.Timeout, 5)) * time.Second,
+	}
+}
+
+func (s *CertMonitorService) CreateCertMonitor(
+	ctx context.Context,
+	m sysmodel.CertMonitorModel,
+) (*sysmodel.CertMonitorModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	m.Status = sysmodel.CertUnknown
+	if err := s.certRepo.CreateModel(ctx, &m); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"创建证书监控失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, &m),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"name": m.Name})
+	}
+	return &m, nil
+}
+
+// UpdateCertMonitorByID 更新证书监控, 证书来源变化时清空已读取的证书信息
+func (s *CertMonitorService) UpdateCertMonitorByID(
+	ctx context.Context,
+	m sysmodel.CertMonitorModel,
+) (*sysmodel.CertMonitorModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	old, rErr := s.FindCertMonitorByID(ctx, m.ID)
+	if rErr != nil {
+		return nil, rErr
+	}
+	data := map[string]any{
+		"name":        m.Name,
+		"kind":        m.Kind,
+		"address":     m.Address,
+		"server_name": m.ServerName,
+		"file_path":   m.FilePath,
+		"ca_file":     m.CAFile,
+		"is_enabled":  m.IsEnabled,
+		"remark":      m.Remark,
+	}
+	if old.Kind != m.Kind || old.Address != m.Address || old.FilePath != m.FilePath {
+		data["status"] = sysmodel.CertUnknown
+		data["subject"] = ""
+		data["issuer"] = ""
+		data["not_before"] = nil
+		data["not_after"] = nil
+		data["chain_error"] = ""
+		data["last_error"] = ""
+		data["alerted_threshold"] = 0
+	}
+	if err := s.certRepo.UpdateModel(ctx, data, "id = ?", m.ID); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"更新证书监控失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, &m),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"id": m.ID})
+	}
+	return s.FindCertMonitorByID(ctx, m.ID)
+}
+
+func (s *CertMonitorService) DeleteCertMonitorByID(
+	ctx context.Context,
+	monitorID uint32,
+) *errors.Error {
+	if ctx.Err() != nil {
+		return errors.FromError(ctx.Err())
+	}
+
+	if _, rErr := s.FindCertMonitorByID(ctx, monitorID); rErr != nil {
+		return rErr
+	}
+	if err := s.certRepo.DeleteModel(ctx, monitorID); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"删除证书监控失败",
+			zap.Error(err),
+			zap.Uint32("monitor_id", monitorID),
+		)
+		return errors.NewGormError(err, map[string]any{"id": monitorID})
+	}
+	return nil
+}
+
+func (s *CertMonitorService) FindCertMonitorByID(
+	ctx context.Context,
+	monitorID uint32,
+) (*sysmodel.CertMonitorModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	m, err := s.certRepo.GetModel(ctx, nil, monitorID)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询证书监控失败",
+			zap.Error(err),
+			zap.Uint32("monitor_id", monitorID),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"id": monitorID})
+	}
+	return m, nil
+}
+
+func (s *CertMonitorService) ListCertMonitor(
+	ctx context.Context,
+	qp database.QueryParams,
+) (int64, *[]sysmodel.CertMonitorModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return 0, nil, errors.FromError(ctx.Err())
+	}
+
+	count, ms, err := s.certRepo.ListModel(ctx, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询证书监控列表失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		return 0, nil, errors.NewGormError(err, nil)
+	}
+	return count, ms, nil
+}
+
+// EnsureFileMonitor 证书文件尚未被监控时创建证书监控, 用于启用HTTPS时自动监控本服务的证书
+func (s *CertMonitorService) EnsureFileMonitor(ctx context.Context, name, path string) *errors.Error {
+	if ctx.Err() != nil {
+		return errors.FromError(ctx.Err())
+	}
+
+	total, _, rErr := s.ListCertMonitor(ctx, database.QueryParams{
+		IsCount: true,
+		Size:    1,
+		Query: map[string]any{
+			"kind = ?":      sysmodel.CertKindFile,
+			"file_path = ?": path,
+		},
+	})
+	if rErr != nil {
+		return rErr
+	}
+	if total > 0 {
+		return nil
+	}
+	_, rErr = s.CreateCertMonitor(ctx, sysmodel.CertMonitorModel{
+		Name:      name,
+		Kind:      sysmodel.CertKindFile,
+		FilePath:  path,
+		IsEnabled: true,
+		Remark:    "启用HTTPS时自动创建",
+	})
+	if rErr != nil && !rErr.Is(errors.ErrDuplicatedKey) {
+		return rErr
+	}
+	return nil
+}
+
+// CheckCertMonitorByID 立即检查证书, 与定时检查相同, 到达告警阈值时发出告警
+func (s *CertMonitorService) CheckCertMonitorByID(
+	ctx context.Context,
+	monitorID uint32,
+) (*sysmodel.CertMonitorModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	m, rErr := s.FindCertMonitorByID(ctx, monitorID)
+	if rErr != nil {
+		return nil, rErr
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if rErr := s.check(ctx, m, time.Now()); rErr != nil {
+		return nil, rErr
+	}
+	return s.FindCertMonitorByID(ctx, monitorID)
+}
+
+// CheckAll 检查全部启用的证书监控, 单个证书检查失败不影响其他证书
+func (s *CertMonitorService) CheckAll(ctx context.Context) *errors.Error {
+	if ctx.Err() != nil {
+		return errors.FromError(ctx.Err())
+	}
+
+	_, ms, rErr := s.ListCertMonitor(ctx, database.QueryParams{
+		OrderBy: []string{"id ASC"},
+		Query:   map[string]any{"is_enabled = ?": true},
+	})
+	if rErr != nil {
+		return rErr
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	for i := range *ms {
+		m := &(*ms)[i]
+		if rErr := s.check(ctx, m, now); rErr != nil {
+			ctxutil.Logger(ctx, s.log).Error(
+				"检查证书失败",
+				zap.Error(rErr),
+				zap.Uint32("monitor_id", m.ID),
+			)
+		}
+	}
+	return nil
+}
+
+// check 读取证书并保存检查结果, 到达新的告警阈值或变为无效时发出告警
+func (s *CertMonitorService) check(ctx context.Context, m *sysmodel.CertMonitorModel, now time.Time) *errors.Error {
+	data := map[string]any{"last_checked_at": now}
+	status := sysmodel.CertOK
+	threshold := 0
+	var message string
+
+	certs, err := s.fetchCerts(ctx, m)
+	if err != nil {
+		status = sysmodel.CertError
+		message = truncateCertMessage(err.Error())
+		data["last_error"] = message
+	} else {
+		leaf := certs[0]
+		data["subject"] = truncateCertMessage(leaf.Subject.String())
+		data["issuer"] = truncateCertMessage(leaf.Issuer.String())
+		data["not_before"] = leaf.NotBefore
+		data["not_after"] = leaf.NotAfter
+		data["last_error"] = ""
+		m.Subject = leaf.Subject.String()
+		m.NotAfter = &leaf.NotAfter
+
+		chainErr := ""
+		if err := s.verifyChain(m, certs, now); err != nil {
+			chainErr = truncateCertMessage(err.Error())
+		}
+		data["chain_error"] = chainErr
+		message = chainErr
+
+		threshold = sysmodel.CertAlertThreshold(*m.DaysRemaining(now), s.thresholds)
+		switch {
+		case threshold < 0:
+			status = sysmodel.CertExpired
+		case chainErr != "":
+			status = sysmodel.CertInvalid
+		case threshold > 0:
+			status = sysmodel.CertExpiring
+		}
+	}
+	data["status"] = status
+
+	// 剩余天数每到达一个更小的阈值告警一次, 过期时再告警一次, 证书更新后不再命中阈值时重新计算
+	notify := false
+	alerted := m.AlertedThreshold
+	switch {
+	case threshold < 0 && alerted >= 0, threshold > 0 && (alerted <= 0 || threshold < alerted):
+		data["alerted_threshold"] = threshold
+		notify = true
+	case threshold == 0 && alerted != 0 && status != sysmodel.CertError:
+		data["alerted_threshold"] = 0
+	}
+	if (status == sysmodel.CertInvalid || status == sysmodel.CertError) && m.Status != status {
+		notify = true
+	}
+
+	if err := s.certRepo.UpdateModel(ctx, data, "id = ?", m.ID); err != nil {
+		return errors.NewGormError(err, map[string]any{"id": m.ID})
+	}
+	if notify {
+		s.notify(ctx, m, status, threshold, message, now)
+	}
+	return nil
+}
+
+// fetchCerts 读取证书, 第一个为服务端或文件中的证书, 其余为中间证书
+func (s *CertMonitorService) fetchCerts(ctx context.Context, m *sysmodel.CertMonitorModel) ([]*x509.Certificate, error) {
+	if m.Kind == sysmodel.CertKindFile {
+		return readPEMCerts(resolveCertPath(m.FilePath))
+	}
+
+	host, _, err := net.SplitHostPort(m.Address)
+	if err != nil {
+		return nil, err
+	}
+	dialer := tls.Dialer{
+		NetDialer: &net.Dialer{Timeout: s.timeout},
+		// 只读取服务端证书, 证书链由verifyChain单独校验并记录失败原因
+		Config: &tls.Config{InsecureSkipVerify: true, ServerName: cmp.Or(m.ServerName, host)},
+	}
+	dialCtx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	conn, err := dialer.DialContext(dialCtx, "tcp", m.Address)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	certs := conn.(*tls.Conn).ConnectionState().PeerCertificates
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("服务端没有返回证书")
+	}
+	return certs, nil
+}
+
+// verifyChain 使用配置的根证书或系统根证书校验证书链, 服务地址的证书同时校验域名
+func (s *CertMonitorService) verifyChain(m *sysmodel.CertMonitorModel, certs []*x509.Certificate, now time.Time) error {
+	opts := x509.VerifyOptions{
+		Intermediates: x509.NewCertPool(),
+		CurrentTime:   now,
+	}
+	for _, c := range certs[1:] {
+		opts.Intermediates.AddCert(c)
+	}
+	if m.CAFile != "" {
+		roots, err := readPEMCerts(resolveCertPath(m.CAFile))
+		if err != nil {
+			return fmt.Errorf("读取根证书失败: %w", err)
+		}
+		opts.Roots = x509.NewCertPool()
+		for _, c := range roots {
+			opts.Roots.AddCert(c)
+		}
+	}
+	if m.Kind == sysmodel.CertKindEndpoint {
+		host, _, err := net.SplitHostPort(m.Address)
+		if err != nil {
+			return err
+		}
+		opts.DNSName = cmp.Or(m.ServerName, host)
+	}
+	_, err := certs[0].Verify(opts)
+	return err
+}
+
+// notify 发出证书告警, 处于全局维护窗口内时屏蔽告警并记录审计
+func (s *CertMonitorService) notify(
+	ctx context.Context,
+	m *sysmodel.CertMonitorModel,
+	status string,
+	threshold int,
+	message string,
+	now time.Time,
+) {
+	event := sysmodel.CertExpiryEvent{
+		Kind:       sysmodel.AlertKindCertExpiry,
+		MonitorID:  m.ID,
+		Name:       m.Name,
+		Source:     cmp.Or(m.Address, m.FilePath),
+		Status:     status,
+		Subject:    m.Subject,
+		Threshold:  threshold,
+		ChainError: message,
+		CheckedAt:  now.Format(time.DateTime),
+	}
+	if days := m.DaysRemaining(now); days != nil {
+		event.NotAfter = m.NotAfter.Format(time.DateTime)
+		event.DaysRemaining = *days
+	}
+	if !s.alerts.Publish(ctx, Alert{
+		Kind:     sysmodel.AlertKindCertExpiry,
+		Status:   status,
+		Scope:    AlertScopeGlobal,
+		TargetID: m.ID,
+		At:       now,
+		Event:    event,
+		Detail: map[string]any{
+			"monitor_id": m.ID,
+			"status":     status,
+			"threshold":  threshold,
+			"checked_at": now.Format(time.DateTime),
+		},
+	}) {
+		return
+	}
+	ctxutil.Logger(ctx, s.log).Warn(
+		"证书即将过期或无效",
+		zap.Uint32("monitor_id", m.ID),
+		zap.String("name", m.Name),
+		zap.String("status", status),
+		zap.Int("threshold", threshold),
+		zap.String("message", message),
+	)
+}
+
+// readPEMCerts 读取文件中全部PEM格式的证书
+func readPEMCerts(path string) ([]*x509.Certificate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		c, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, c)
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("文件%s中没有PEM格式的证书", filepath.Base(path))
+	}
+	return certs, nil
+}
+
+// resolveCertPath 相对路径基于配置目录, 与服务的SSL证书路径一致
+func resolveCertPath(path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(config.ConfigDir, path)
+}
+
+func truncateCertMessage(s string) string {
+	r := []rune(s)
+	if len(r) > 512 {
+		return string(r[:512])
+	}
+	return s
+}
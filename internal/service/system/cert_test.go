@@ -0,0 +1,180 @@
+package system
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	sysmodel "gin-artweb/internal/model/system"
+	sysrepo "gin-artweb/internal/repository/system"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/events"
+	"gin-artweb/internal/shared/test"
+)
+
+type CertMonitorServiceTestSuite struct {
+	suite.Suite
+	db      *gorm.DB
+	service *CertMonitorService
+	dir     string
+}
+
+func (suite *CertMonitorServiceTestSuite) SetupTest() {
+	suite.db = test.NewTestGormDBWithConfig(nil)
+	suite.db.AutoMigrate(&sysmodel.CertMonitorModel{}, &events.OutboxModel{})
+	logger := test.NewTestZapLogger()
+	certRepo := sysrepo.NewCertMonitorRepo(logger, suite.db, test.NewTestDBTimeouts())
+	outbox := events.NewOutbox(logger, suite.db, events.NewBus(logger), nil)
+	suite.service = NewCertMonitorService(logger, certRepo, NewAlertPublisher(logger, nil, outbox), &config.CertMonitorConfig{
+		Thresholds: []int{30, 14, 3},
+		Timeout:    2,
+	})
+	suite.dir = suite.T().TempDir()
+}
+
+// writeCert 生成自签名证书写入临时目录, 返回证书文件路径和证书
+func (suite *CertMonitorServiceTestSuite) writeCert(name string, notAfter time.Time) (string, tls.Certificate) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	suite.Require().NoError(err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: "localhost"},
+		DNSNames:              []string{"localhost"},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	suite.Require().NoError(err)
+
+	path := filepath.Join(suite.dir, name)
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	suite.Require().NoError(os.WriteFile(path, data, 0o600))
+	return path, tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+func (suite *CertMonitorServiceTestSuite) countAlerts() int64 {
+	var count int64
+	suite.Require().NoError(suite.db.Model(&events.OutboxModel{}).Count(&count).Error)
+	return count
+}
+
+func (suite *CertMonitorServiceTestSuite) createMonitor(m sysmodel.CertMonitorModel) *sysmodel.CertMonitorModel {
+	m.IsEnabled = true
+	cm, rErr := suite.service.CreateCertMonitor(context.Background(), m)
+	suite.Require().Nil(rErr)
+	return cm
+}
+
+func (suite *CertMonitorServiceTestSuite) TestCheckFileExpiring() {
+	ctx := context.Background()
+	path, _ := suite.writeCert("server.crt", time.Now().AddDate(0, 0, 10))
+	m := suite.createMonitor(sysmodel.CertMonitorModel{
+		Name: "server", Kind: sysmodel.CertKindFile, FilePath: path, CAFile: path,
+	})
+
+	cm, rErr := suite.service.CheckCertMonitorByID(ctx, m.ID)
+	suite.Require().Nil(rErr)
+	suite.Equal(sysmodel.CertExpiring, cm.Status)
+	suite.Equal(14, cm.AlertedThreshold)
+	suite.Empty(cm.ChainError, "使用自身作为根证书时证书链应该有效")
+	suite.Equal("CN=localhost", cm.Subject)
+	suite.Equal(int64(1), suite.countAlerts())
+
+	suite.Nil(suite.service.CheckAll(ctx))
+	suite.Equal(int64(1), suite.countAlerts(), "同一阈值只告警一次")
+
+	// 证书更新后不再命中阈值, 重置告警阈值
+	suite.writeCert("server.crt", time.Now().AddDate(1, 0, 0))
+	cm, rErr = suite.service.CheckCertMonitorByID(ctx, m.ID)
+	suite.Require().Nil(rErr)
+	suite.Equal(sysmodel.CertOK, cm.Status)
+	suite.Zero(cm.AlertedThreshold)
+}
+
+func (suite *CertMonitorServiceTestSuite) TestCheckFileInvalid() {
+	ctx := context.Background()
+	path, _ := suite.writeCert("server.crt", time.Now().AddDate(1, 0, 0))
+	m := suite.createMonitor(sysmodel.CertMonitorModel{
+		Name: "server", Kind: sysmodel.CertKindFile, FilePath: path,
+	})
+
+	cm, rErr := suite.service.CheckCertMonitorByID(ctx, m.ID)
+	suite.Require().Nil(rErr)
+	suite.Equal(sysmodel.CertInvalid, cm.Status, "自签名证书不被系统根证书信任")
+	suite.NotEmpty(cm.ChainError)
+	suite.Equal(int64(1), suite.countAlerts())
+
+	suite.Require().NoError(os.Remove(path))
+	cm, rErr = suite.service.CheckCertMonitorByID(ctx, m.ID)
+	suite.Require().Nil(rErr)
+	suite.Equal(sysmodel.CertError, cm.Status)
+	suite.NotEmpty(cm.LastError)
+	suite.NotNil(cm.NotAfter, "读取失败时保留上一次的证书信息")
+	suite.Equal(int64(2), suite.countAlerts())
+}
+
+func (suite *CertMonitorServiceTestSuite) TestCheckEndpoint() {
+	ctx := context.Background()
+	path, cert := suite.writeCert("server.crt", time.Now().AddDate(0, 0, 2))
+	srv := httptest.NewUnstartedServer(http.NotFoundHandler())
+	srv.TLS = &tls.Config{Certificates: []tls.Certificate{cert}}
+	srv.StartTLS()
+	defer srv.Close()
+
+	m := suite.createMonitor(sysmodel.CertMonitorModel{
+		Name:       "endpoint",
+		Kind:       sysmodel.CertKindEndpoint,
+		Address:    srv.Listener.Addr().String(),
+		ServerName: "localhost",
+		CAFile:     path,
+	})
+	cm, rErr := suite.service.CheckCertMonitorByID(ctx, m.ID)
+	suite.Require().Nil(rErr)
+	suite.Equal(sysmodel.CertExpiring, cm.Status)
+	suite.Equal(3, cm.AlertedThreshold)
+	suite.Empty(cm.ChainError)
+
+	cm.ServerName = "example.com"
+	_, rErr = suite.service.UpdateCertMonitorByID(ctx, *cm)
+	suite.Require().Nil(rErr)
+	cm, rErr = suite.service.CheckCertMonitorByID(ctx, m.ID)
+	suite.Require().Nil(rErr)
+	suite.Equal(sysmodel.CertInvalid, cm.Status, "域名不匹配时证书链校验失败")
+}
+
+func (suite *CertMonitorServiceTestSuite) TestEnsureFileMonitor() {
+	ctx := context.Background()
+	path, _ := suite.writeCert("server.crt", time.Now().AddDate(1, 0, 0))
+	suite.Nil(suite.service.EnsureFileMonitor(ctx, "本服务HTTPS证书", path))
+	suite.Nil(suite.service.EnsureFileMonitor(ctx, "本服务HTTPS证书", path), "重复创建应该忽略")
+
+	total, _, rErr := suite.service.ListCertMonitor(ctx, database.QueryParams{IsCount: true})
+	suite.Nil(rErr)
+	suite.Equal(int64(1), total)
+}
+
+func TestCertMonitorServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(CertMonitorServiceTestSuite))
+}
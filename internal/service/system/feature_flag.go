@@ -0,0 +1,240 @@
+package system
+
+import (
+	"context"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+
+	sysmodel "gin-artweb/internal/model/system"
+	sysrepo "gin-artweb/internal/repository/system"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+)
+
+// FeatureFlagService 功能开关服务
+//
+// 开关全部缓存在内存中, 判断开关时不查询数据库; 本实例修改开关后立即刷新缓存,
+// 其他实例的修改通过定时调用Reload同步
+type FeatureFlagService struct {
+	log      *zap.Logger
+	flagRepo *sysrepo.FeatureFlagRepo
+	flags    atomic.Pointer[map[string]sysmodel.FeatureFlagModel]
+}
+
+func NewFeatureFlagService(
+	log *zap.Logger,
+	flagRepo *sysrepo.FeatureFlagRepo,
+) *FeatureFlagService {
+	s := &FeatureFlagService{
+		log:      log,
+		flagRepo: flagRepo,
+	}
+	s.flags.Store(&map[string]sysmodel.FeatureFlagModel{})
+	return s
+}
+
+// Reload 从数据库重新加载全部开关
+func (s *FeatureFlagService) Reload(ctx context.Context) *errors.Error {
+	if ctx.Err() != nil {
+		return errors.FromError(ctx.Err())
+	}
+
+	_, ms, err := s.flagRepo.ListModel(ctx, database.QueryParams{})
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"加载功能开关失败",
+			zap.Error(err),
+		)
+		return errors.NewGormError(err, nil)
+	}
+	flags := make(map[string]sysmodel.FeatureFlagModel, len(*ms))
+	for _, m := range *ms {
+		flags[m.Name] = m
+	}
+	s.flags.Store(&flags)
+	return nil
+}
+
+// FeatureEnabled 判断功能是否对当前用户开放, 用户从ctx中的令牌获取, 未登录时按匿名用户判断
+// 开关不存在时返回false, 新功能在创建开关之前保持关闭
+func (s *FeatureFlagService) FeatureEnabled(ctx context.Context, name string) bool {
+	m, ok := (*s.flags.Load())[name]
+	if !ok {
+		return false
+	}
+	var userID, roleID uint32
+	if claims, rErr := ctxutil.GetUserClaims(ctx); rErr == nil {
+		userID, roleID = claims.UserID, claims.RoleID
+	}
+	return m.EnabledFor(userID, roleID)
+}
+
+// EnabledFeatures 返回全部开关对当前用户是否开放, 供前端决定是否展示入口
+func (s *FeatureFlagService) EnabledFeatures(ctx context.Context) map[string]bool {
+	flags := *s.flags.Load()
+	out := make(map[string]bool, len(flags))
+	for name := range flags {
+		out[name] = s.FeatureEnabled(ctx, name)
+	}
+	return out
+}
+
+// reload 修改开关后刷新缓存, 刷新失败时等待定时同步
+func (s *FeatureFlagService) reload(ctx context.Context) {
+	if rErr := s.Reload(ctx); rErr != nil {
+		ctxutil.Logger(ctx, s.log).Warn(
+			"刷新功能开关缓存失败",
+			zap.Error(rErr),
+		)
+	}
+}
+
+func (s *FeatureFlagService) CreateFeatureFlag(
+	ctx context.Context,
+	m sysmodel.FeatureFlagModel,
+) (*sysmodel.FeatureFlagModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始创建功能开关",
+		zap.Object(database.ModelKey, &m),
+	)
+
+	if err := s.flagRepo.CreateModel(ctx, &m); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"创建功能开关失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, &m),
+		)
+		return nil, errors.NewGormError(err, nil)
+	}
+	s.reload(ctx)
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"创建功能开关成功",
+		zap.Object(database.ModelKey, &m),
+	)
+	return &m, nil
+}
+
+func (s *FeatureFlagService) UpdateFeatureFlagByID(
+	ctx context.Context,
+	flagID uint32,
+	m sysmodel.FeatureFlagModel,
+) (*sysmodel.FeatureFlagModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始更新功能开关",
+		zap.Uint32("flag_id", flagID),
+		zap.Object(database.ModelKey, &m),
+	)
+
+	data := map[string]any{
+		"name":        m.Name,
+		"is_enabled":  m.IsEnabled,
+		"role_ids":    m.RoleIDs,
+		"percentage":  m.Percentage,
+		"description": m.Description,
+		"username":    m.Username,
+	}
+	if err := s.flagRepo.UpdateModel(ctx, data, "id = ?", flagID); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"更新功能开关失败",
+			zap.Error(err),
+			zap.Uint32("flag_id", flagID),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"id": flagID})
+	}
+	s.reload(ctx)
+
+	nm, rErr := s.FindFeatureFlagByID(ctx, flagID)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"更新功能开关成功",
+		zap.Uint32("flag_id", flagID),
+	)
+	return nm, nil
+}
+
+func (s *FeatureFlagService) DeleteFeatureFlagByID(
+	ctx context.Context,
+	flagID uint32,
+) *errors.Error {
+	if ctx.Err() != nil {
+		return errors.FromError(ctx.Err())
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始删除功能开关",
+		zap.Uint32("flag_id", flagID),
+	)
+
+	if _, rErr := s.FindFeatureFlagByID(ctx, flagID); rErr != nil {
+		return rErr
+	}
+	if err := s.flagRepo.DeleteModel(ctx, flagID); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"删除功能开关失败",
+			zap.Error(err),
+			zap.Uint32("flag_id", flagID),
+		)
+		return errors.NewGormError(err, map[string]any{"id": flagID})
+	}
+	s.reload(ctx)
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"删除功能开关成功",
+		zap.Uint32("flag_id", flagID),
+	)
+	return nil
+}
+
+func (s *FeatureFlagService) FindFeatureFlagByID(
+	ctx context.Context,
+	flagID uint32,
+) (*sysmodel.FeatureFlagModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	m, err := s.flagRepo.GetModel(ctx, flagID)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询功能开关失败",
+			zap.Error(err),
+			zap.Uint32("flag_id", flagID),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"id": flagID})
+	}
+	return m, nil
+}
+
+func (s *FeatureFlagService) ListFeatureFlag(
+	ctx context.Context,
+	qp database.QueryParams,
+) (int64, *[]sysmodel.FeatureFlagModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return 0, nil, errors.FromError(ctx.Err())
+	}
+
+	count, ms, err := s.flagRepo.ListModel(ctx, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询功能开关列表失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		return 0, nil, errors.NewGormError(err, nil)
+	}
+	return count, ms, nil
+}
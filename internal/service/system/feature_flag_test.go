@@ -0,0 +1,89 @@
+package system
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	sysmodel "gin-artweb/internal/model/system"
+	sysrepo "gin-artweb/internal/repository/system"
+	"gin-artweb/internal/shared/auth"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/test"
+)
+
+func userContext(userID, roleID uint32) context.Context {
+	claims := &auth.UserClaims{UserInfo: auth.UserInfo{UserID: userID, RoleID: roleID}}
+	return context.WithValue(context.Background(), ctxutil.UserClaimsKey, claims)
+}
+
+type FeatureFlagServiceTestSuite struct {
+	suite.Suite
+	svc *FeatureFlagService
+}
+
+func (suite *FeatureFlagServiceTestSuite) SetupTest() {
+	db := test.NewTestGormDBWithConfig(nil)
+	db.AutoMigrate(&sysmodel.FeatureFlagModel{})
+	repo := sysrepo.NewFeatureFlagRepo(test.NewTestZapLogger(), db, test.NewTestDBTimeouts())
+	suite.svc = NewFeatureFlagService(test.NewTestZapLogger(), repo)
+}
+
+func (suite *FeatureFlagServiceTestSuite) create(m sysmodel.FeatureFlagModel) *sysmodel.FeatureFlagModel {
+	nm, rErr := suite.svc.CreateFeatureFlag(context.Background(), m)
+	suite.Require().Nil(rErr)
+	return nm
+}
+
+func (suite *FeatureFlagServiceTestSuite) TestUnknownFlag() {
+	suite.False(suite.svc.FeatureEnabled(userContext(1, 1), "oes.workflow.dag"), "开关不存在时应该关闭")
+}
+
+func (suite *FeatureFlagServiceTestSuite) TestRoleRollout() {
+	m := suite.create(sysmodel.FeatureFlagModel{Name: "oes.workflow.dag", IsEnabled: true, RoleIDs: "1,3"})
+
+	suite.True(suite.svc.FeatureEnabled(userContext(10, 1), m.Name))
+	suite.True(suite.svc.FeatureEnabled(userContext(11, 3), m.Name))
+	suite.False(suite.svc.FeatureEnabled(userContext(12, 2), m.Name), "未开放的角色应该关闭")
+	suite.False(suite.svc.FeatureEnabled(context.Background(), m.Name), "未登录时应该关闭")
+
+	m.IsEnabled = false
+	_, rErr := suite.svc.UpdateFeatureFlagByID(context.Background(), m.ID, *m)
+	suite.Require().Nil(rErr)
+	suite.False(suite.svc.FeatureEnabled(userContext(10, 1), m.Name), "开关关闭后应该立即对所有用户关闭")
+}
+
+func (suite *FeatureFlagServiceTestSuite) TestPercentageRollout() {
+	m := suite.create(sysmodel.FeatureFlagModel{Name: "jobs.dag", IsEnabled: true, Percentage: 30})
+
+	enabled := make(map[uint32]bool)
+	for id := uint32(1); id <= 1000; id++ {
+		if suite.svc.FeatureEnabled(userContext(id, 0), m.Name) {
+			enabled[id] = true
+		}
+	}
+	suite.InDelta(300, len(enabled), 60, "开放的用户数应该接近比例")
+
+	m.Percentage = 60
+	_, rErr := suite.svc.UpdateFeatureFlagByID(context.Background(), m.ID, *m)
+	suite.Require().Nil(rErr)
+	for id := range enabled {
+		suite.True(suite.svc.FeatureEnabled(userContext(id, 0), m.Name), "调大比例后已开放的用户应该保持开放")
+	}
+
+	m.Percentage = 100
+	_, rErr = suite.svc.UpdateFeatureFlagByID(context.Background(), m.ID, *m)
+	suite.Require().Nil(rErr)
+	suite.True(suite.svc.FeatureEnabled(context.Background(), m.Name), "全量开放后未登录也应该开放")
+
+	features := suite.svc.EnabledFeatures(userContext(1, 0))
+	suite.Equal(map[string]bool{m.Name: true}, features)
+
+	suite.Require().Nil(suite.svc.DeleteFeatureFlagByID(context.Background(), m.ID))
+	suite.False(suite.svc.FeatureEnabled(userContext(1, 0), m.Name), "删除开关后应该关闭")
+}
+
+func TestFeatureFlagServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(FeatureFlagServiceTestSuite))
+}
@@ -0,0 +1,350 @@
+package system
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	sysmodel "gin-artweb/internal/model/system"
+	sysrepo "gin-artweb/internal/repository/system"
+	"gin-artweb/internal/shared/auth"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+)
+
+// ChangeFreezeService 变更冻结服务
+//
+// 未结束的冻结缓存在内存中, 中间件判断冻结时不查询数据库; 本实例修改冻结后立即刷新缓存,
+// 其他实例的修改通过定时调用Reload同步
+type ChangeFreezeService struct {
+	log        *zap.Logger
+	freezeRepo *sysrepo.ChangeFreezeRepo
+	auditRepo  *sysrepo.AuditRecordRepo
+	freezes    atomic.Pointer[[]sysmodel.ChangeFreezeModel]
+}
+
+func NewChangeFreezeService(
+	log *zap.Logger,
+	freezeRepo *sysrepo.ChangeFreezeRepo,
+	auditRepo *sysrepo.AuditRecordRepo,
+) *ChangeFreezeService {
+	s := &ChangeFreezeService{
+		log:        log,
+		freezeRepo: freezeRepo,
+		auditRepo:  auditRepo,
+	}
+	s.freezes.Store(&[]sysmodel.ChangeFreezeModel{})
+	return s
+}
+
+// Reload 从数据库重新加载启用且未结束的变更冻结
+func (s *ChangeFreezeService) Reload(ctx context.Context) *errors.Error {
+	if ctx.Err() != nil {
+		return errors.FromError(ctx.Err())
+	}
+
+	_, ms, err := s.freezeRepo.ListModel(ctx, database.QueryParams{
+		Query: map[string]any{
+			"is_enabled = ?": true,
+			"end_at > ?":     time.Now(),
+		},
+		OrderBy: []string{"start_at ASC"},
+	})
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"加载变更冻结失败",
+			zap.Error(err),
+		)
+		return errors.NewGormError(err, nil)
+	}
+	s.freezes.Store(ms)
+	return nil
+}
+
+// reload 修改冻结后刷新缓存, 刷新失败时由定时同步兜底
+func (s *ChangeFreezeService) reload(ctx context.Context) {
+	if rErr := s.Reload(ctx); rErr != nil {
+		ctxutil.Logger(ctx, s.log).Warn(
+			"刷新变更冻结缓存失败",
+			zap.Error(rErr),
+		)
+	}
+}
+
+// validate 校验变更冻结的作用范围和时间范围
+func (s *ChangeFreezeService) validate(m *sysmodel.ChangeFreezeModel) *errors.Error {
+	if !m.EndAt.After(m.StartAt) {
+		return errors.ErrChangeFreezeInvalid.WithFields(map[string]any{
+			"start_at": m.StartAt.Format(time.DateTime),
+			"end_at":   m.EndAt.Format(time.DateTime),
+		})
+	}
+	switch m.Scope {
+	case sysmodel.ChangeFreezeScopeGlobal:
+		m.Module = ""
+	case sysmodel.ChangeFreezeScopeModule:
+		if m.Module == "" {
+			return errors.ErrChangeFreezeInvalid.WithFields(map[string]any{
+				"scope":  m.Scope,
+				"module": m.Module,
+			})
+		}
+	default:
+		return errors.ErrChangeFreezeInvalid.WithField("scope", m.Scope)
+	}
+	return nil
+}
+
+// audit 记录变更冻结的审计记录, 审计失败不影响业务操作
+func (s *ChangeFreezeService) audit(
+	ctx context.Context,
+	freezeID uint32,
+	action string,
+	before, after any,
+	username string,
+) {
+	record, err := sysmodel.NewAuditRecord(
+		"system", sysmodel.ChangeFreezeAuditResource, freezeID, action,
+		before, after, username, ctxutil.GetTraceID(ctx),
+	)
+	if err == nil {
+		err = s.auditRepo.CreateModel(ctx, record)
+	}
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"记录变更冻结审计记录失败",
+			zap.Error(err),
+			zap.Uint32("freeze_id", freezeID),
+			zap.String("action", action),
+		)
+	}
+}
+
+func (s *ChangeFreezeService) CreateChangeFreeze(
+	ctx context.Context,
+	m sysmodel.ChangeFreezeModel,
+) (*sysmodel.ChangeFreezeModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始创建变更冻结",
+		zap.Object(database.ModelKey, &m),
+	)
+
+	if rErr := s.validate(&m); rErr != nil {
+		return nil, rErr
+	}
+
+	if err := s.freezeRepo.CreateModel(ctx, &m); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"创建变更冻结失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, &m),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"name": m.Name})
+	}
+	s.audit(ctx, m.ID, "create", nil, sysmodel.ChangeFreezeAuditSnapshot(m), m.Username)
+	s.reload(ctx)
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"创建变更冻结成功",
+		zap.Object(database.ModelKey, &m),
+	)
+	return &m, nil
+}
+
+func (s *ChangeFreezeService) UpdateChangeFreezeByID(
+	ctx context.Context,
+	freezeID uint32,
+	m sysmodel.ChangeFreezeModel,
+) (*sysmodel.ChangeFreezeModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始更新变更冻结",
+		zap.Uint32("freeze_id", freezeID),
+		zap.Object(database.ModelKey, &m),
+	)
+
+	before, rErr := s.FindChangeFreezeByID(ctx, freezeID)
+	if rErr != nil {
+		return nil, rErr
+	}
+	if rErr := s.validate(&m); rErr != nil {
+		return nil, rErr
+	}
+
+	data := map[string]any{
+		"name":       m.Name,
+		"scope":      m.Scope,
+		"module":     m.Module,
+		"start_at":   m.StartAt,
+		"end_at":     m.EndAt,
+		"is_enabled": m.IsEnabled,
+		"reason":     m.Reason,
+		"username":   m.Username,
+	}
+	if err := s.freezeRepo.UpdateModel(ctx, data, "id = ?", freezeID); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"更新变更冻结失败",
+			zap.Error(err),
+			zap.Uint32("freeze_id", freezeID),
+		)
+		return nil, errors.NewGormError(err, data)
+	}
+
+	after, rErr := s.FindChangeFreezeByID(ctx, freezeID)
+	if rErr != nil {
+		return nil, rErr
+	}
+	s.audit(ctx, freezeID, "update",
+		sysmodel.ChangeFreezeAuditSnapshot(*before), sysmodel.ChangeFreezeAuditSnapshot(*after), m.Username)
+	s.reload(ctx)
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"更新变更冻结成功",
+		zap.Uint32("freeze_id", freezeID),
+	)
+	return after, nil
+}
+
+func (s *ChangeFreezeService) DeleteChangeFreezeByID(
+	ctx context.Context,
+	freezeID uint32,
+	username string,
+) *errors.Error {
+	if ctx.Err() != nil {
+		return errors.FromError(ctx.Err())
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始删除变更冻结",
+		zap.Uint32("freeze_id", freezeID),
+	)
+
+	before, rErr := s.FindChangeFreezeByID(ctx, freezeID)
+	if rErr != nil {
+		return rErr
+	}
+	if err := s.freezeRepo.DeleteModel(ctx, freezeID); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"删除变更冻结失败",
+			zap.Error(err),
+			zap.Uint32("freeze_id", freezeID),
+		)
+		return errors.NewGormError(err, map[string]any{"id": freezeID})
+	}
+	s.audit(ctx, freezeID, "delete", sysmodel.ChangeFreezeAuditSnapshot(*before), nil, username)
+	s.reload(ctx)
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"删除变更冻结成功",
+		zap.Uint32("freeze_id", freezeID),
+	)
+	return nil
+}
+
+func (s *ChangeFreezeService) FindChangeFreezeByID(
+	ctx context.Context,
+	freezeID uint32,
+) (*sysmodel.ChangeFreezeModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	m, err := s.freezeRepo.GetModel(ctx, freezeID)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询变更冻结失败",
+			zap.Error(err),
+			zap.Uint32("freeze_id", freezeID),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"id": freezeID})
+	}
+	return m, nil
+}
+
+func (s *ChangeFreezeService) ListChangeFreeze(
+	ctx context.Context,
+	qp database.QueryParams,
+) (int64, *[]sysmodel.ChangeFreezeModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return 0, nil, errors.FromError(ctx.Err())
+	}
+
+	count, ms, err := s.freezeRepo.ListModel(ctx, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询变更冻结列表失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		return 0, nil, errors.NewGormError(err, nil)
+	}
+	return count, ms, nil
+}
+
+// ActiveFreezes 返回缓存中指定时间生效的变更冻结
+func (s *ChangeFreezeService) ActiveFreezes(t time.Time) []sysmodel.ChangeFreezeModel {
+	active := make([]sysmodel.ChangeFreezeModel, 0)
+	for _, m := range *s.freezes.Load() {
+		if m.ActiveAt(t) {
+			active = append(active, m)
+		}
+	}
+	return active
+}
+
+// matchFreeze 查询当前覆盖请求路径的变更冻结, 未命中时返回nil
+func (s *ChangeFreezeService) matchFreeze(path string) *sysmodel.ChangeFreezeModel {
+	for _, m := range s.ActiveFreezes(time.Now()) {
+		if m.Covers(path) {
+			return &m
+		}
+	}
+	return nil
+}
+
+// FrozenBy 查询当前覆盖请求路径的变更冻结名称和结束时间, 供变更冻结中间件使用
+func (s *ChangeFreezeService) FrozenBy(_ context.Context, path string) (string, time.Time, bool) {
+	m := s.matchFreeze(path)
+	if m == nil {
+		return "", time.Time{}, false
+	}
+	return m.Name, m.EndAt, true
+}
+
+// RecordFreezeOverride 记录工作人员越过变更冻结修改配置的审计记录
+func (s *ChangeFreezeService) RecordFreezeOverride(
+	ctx context.Context,
+	path string,
+	claims *auth.UserClaims,
+	method, route, justification string,
+) {
+	m := s.matchFreeze(path)
+	if m == nil {
+		return
+	}
+	ctxutil.Logger(ctx, s.log).Warn(
+		"工作人员越过变更冻结修改配置",
+		zap.String("freeze", m.Name),
+		zap.String("username", claims.Username),
+		zap.String("method", method),
+		zap.String("route", route),
+		zap.String("justification", justification),
+	)
+	s.audit(ctx, m.ID, sysmodel.ChangeFreezeActionOverride, nil, map[string]any{
+		"freeze":        m.Name,
+		"method":        method,
+		"route":         route,
+		"path":          path,
+		"justification": justification,
+	}, claims.Username)
+}
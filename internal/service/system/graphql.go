@@ -0,0 +1,322 @@
+package system
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/casbin/casbin/v2"
+	"github.com/graphql-go/graphql"
+	"go.uber.org/zap"
+
+	sysmodel "gin-artweb/internal/model/system"
+	"gin-artweb/internal/shared/auth"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+	"gin-artweb/internal/shared/i18n"
+	"gin-artweb/pkg/dataloader"
+)
+
+// GraphQLService 只读GraphQL查询服务
+//
+// 各模块加载时注册对象类型和查询字段, 全部模块加载完成后由Build生成schema, 停用的模块不出现在schema中.
+// 查询字段按对应REST接口的权限鉴权, 无权访问的字段返回null和禁止访问错误, 不影响其他字段;
+// 关联数据通过GraphQLLoader在单次查询内合并为批量查询
+type GraphQLService struct {
+	log      *zap.Logger
+	enforcer *casbin.Enforcer
+	objects  map[string]*graphql.Object
+	loads    map[string]GraphQLLoadFunc
+	queries  graphql.Fields
+	schema   *graphql.Schema
+}
+
+func NewGraphQLService(
+	log *zap.Logger,
+	enforcer *casbin.Enforcer,
+) *GraphQLService {
+	return &GraphQLService{
+		log:      log,
+		enforcer: enforcer,
+		objects:  make(map[string]*graphql.Object),
+		loads:    make(map[string]GraphQLLoadFunc),
+		queries:  make(graphql.Fields),
+	}
+}
+
+// GraphQLLoadFunc 按ID加载对象, 返回字段解析函数可以直接返回的取值函数
+type GraphQLLoadFunc func(ctx context.Context, id uint32) func() (any, error)
+
+// RegisterObject 注册其他模块可以引用的对象类型, load按ID加载该类型的对象, 只能在服务启动时调用
+func (s *GraphQLService) RegisterObject(obj *graphql.Object, load GraphQLLoadFunc) {
+	s.objects[obj.Name()] = obj
+	s.loads[obj.Name()] = load
+}
+
+// RegisterQuery 注册查询字段, api为对应的REST接口, 格式为"方法 路径", 用户有权访问该接口时才能查询该字段
+func (s *GraphQLService) RegisterQuery(name, api string, field *graphql.Field) {
+	field.Resolve = s.Authorize(api, field.Resolve)
+	s.queries[name] = field
+}
+
+// Build 生成schema, 全部模块加载完成后调用, 没有模块注册查询字段时查询接口返回功能未启用
+func (s *GraphQLService) Build() error {
+	if len(s.queries) == 0 {
+		s.log.Warn("没有注册GraphQL查询字段, 不生成schema")
+		return nil
+	}
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name:   "Query",
+			Fields: s.queries,
+		}),
+	})
+	if err != nil {
+		return err
+	}
+	s.schema = &schema
+	return nil
+}
+
+// Execute 执行查询, 查询语句不合法或字段出错时在结果的errors中返回
+func (s *GraphQLService) Execute(ctx context.Context, req sysmodel.GraphQLRequest) (*graphql.Result, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+	if s.schema == nil {
+		return nil, errors.ErrFeatureDisabled.WithField("feature", "graphql")
+	}
+
+	ctx = context.WithValue(ctx, graphQLLoadersKey{}, &graphQLLoaders{loaders: make(map[string]any)})
+	result := graphql.Do(graphql.Params{
+		Schema:         *s.schema,
+		RequestString:  req.Query,
+		VariableValues: req.Variables,
+		OperationName:  req.OperationName,
+		Context:        ctx,
+	})
+	if len(result.Errors) > 0 {
+		ctxutil.Logger(ctx, s.log).Warn(
+			"GraphQL查询存在错误",
+			zap.Int("errors", len(result.Errors)),
+			zap.String("error", result.Errors[0].Message),
+		)
+	}
+	return result, nil
+}
+
+// Authorize 返回鉴权后再解析的字段解析函数, api为对应的REST接口, 格式为"方法 路径", 为空时只转换错误
+//
+// resolve返回的*errors.Error转换为带reason和data扩展字段的GraphQL错误, 与REST接口的错误响应一致
+func (s *GraphQLService) Authorize(api string, resolve graphql.FieldResolveFn) graphql.FieldResolveFn {
+	if resolve == nil {
+		resolve = graphql.DefaultResolveFn
+	}
+	method, path, _ := strings.Cut(api, " ")
+	return func(p graphql.ResolveParams) (any, error) {
+		if api != "" {
+			if rErr := s.enforce(p.Context, method, path); rErr != nil {
+				return nil, graphQLError(p.Context, rErr)
+			}
+		}
+		v, err := resolve(p)
+		if err != nil {
+			return nil, graphQLError(p.Context, err)
+		}
+		// 使用数据加载器的字段返回取值函数, 取值时的错误同样需要转换
+		if thunk, ok := v.(func() (any, error)); ok {
+			return func() (any, error) {
+				v, err := thunk()
+				if err != nil {
+					return nil, graphQLError(p.Context, err)
+				}
+				return v, nil
+			}, nil
+		}
+		return v, nil
+	}
+}
+
+func (s *GraphQLService) enforce(ctx context.Context, method, path string) *errors.Error {
+	claims, rErr := ctxutil.GetUserClaims(ctx)
+	if rErr != nil {
+		return rErr
+	}
+	ok, err := auth.EnforceUser(s.enforcer, claims.UserID, claims.RoleID, path, method)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"GraphQL字段权限校验失败",
+			zap.Error(err),
+			zap.String(auth.SubKey, auth.RoleToSubject(claims.RoleID)),
+			zap.String(auth.ObjKey, path),
+			zap.String(auth.ActKey, method),
+		)
+		return errors.FromError(err)
+	}
+	if !ok {
+		ctxutil.Logger(ctx, s.log).Warn(
+			"GraphQL字段权限被拒绝",
+			zap.String(auth.SubKey, auth.RoleToSubject(claims.RoleID)),
+			zap.String(auth.ObjKey, path),
+			zap.String(auth.ActKey, method),
+		)
+		return errors.ErrForbidden
+	}
+	return nil
+}
+
+// GraphQLArgs 返回列表查询字段的分页参数
+func GraphQLArgs(args graphql.FieldConfigArgument) graphql.FieldConfigArgument {
+	if args == nil {
+		args = make(graphql.FieldConfigArgument, 2)
+	}
+	args["page"] = &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 1, Description: "分页页码"}
+	args["size"] = &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 10, Description: "分页大小, 最大100"}
+	return args
+}
+
+// graphQLMaxSize 列表查询字段的最大分页大小
+const graphQLMaxSize = 100
+
+// GraphQLPage 返回列表查询字段的分页页码和大小
+func GraphQLPage(p graphql.ResolveParams) (int, int, *errors.Error) {
+	page, _ := p.Args["page"].(int)
+	size, _ := p.Args["size"].(int)
+	if page < 1 || size < 1 || size > graphQLMaxSize {
+		return 0, 0, errors.ErrValidationFailed.WithFields(map[string]any{"page": page, "size": size})
+	}
+	return page, size, nil
+}
+
+// GraphQLValue 返回从上级对象取值的字段, 上级对象为解析函数返回的T
+func GraphQLValue[T any](typ graphql.Output, description string, get func(T) any) *graphql.Field {
+	return &graphql.Field{
+		Type:        typ,
+		Description: description,
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			m, ok := p.Source.(T)
+			if !ok {
+				return nil, nil
+			}
+			return get(m), nil
+		},
+	}
+}
+
+// GraphQLByID 返回按ID批量查询的加载函数, list为服务层的列表查询, 查询结果通过convert转换为字段的上级对象
+func GraphQLByID[M any, V any](
+	list func(context.Context, database.QueryParams) (int64, *[]M, *errors.Error),
+	id func(M) uint32,
+	convert func(context.Context, M) V,
+) dataloader.BatchFunc[uint32, V] {
+	return func(ctx context.Context, ids []uint32) (map[uint32]V, error) {
+		_, ms, rErr := list(ctx, database.QueryParams{Query: map[string]any{"id IN ?": ids}})
+		if rErr != nil {
+			return nil, rErr
+		}
+		results := make(map[uint32]V, len(*ms))
+		for _, m := range *ms {
+			results[id(m)] = convert(ctx, m)
+		}
+		return results, nil
+	}
+}
+
+// graphQLErr 带reason和data扩展字段的GraphQL错误
+type graphQLErr struct {
+	err *errors.Error
+}
+
+func (e graphQLErr) Error() string { return e.err.Msg }
+
+func (e graphQLErr) Extensions() map[string]any {
+	return map[string]any{"reason": e.err.Reason, "data": e.err.Data}
+}
+
+// graphQLError 将错误转换为GraphQL错误, 错误信息按请求语言本地化, 不返回内部错误的原因
+func graphQLError(ctx context.Context, err error) error {
+	rErr, ok := err.(*errors.Error)
+	if !ok {
+		rErr = errors.FromError(err)
+	}
+	return graphQLErr{err: rErr.Localize(i18n.Lang(ctx))}
+}
+
+type graphQLLoadersKey struct{}
+
+// graphQLLoaders 单次查询内共用的数据加载器
+type graphQLLoaders struct {
+	mu      sync.Mutex
+	loaders map[string]any
+}
+
+// GraphQLLoader 返回本次查询中名为name的数据加载器, 同一次查询中的字段共用加载器, 关联数据合并为一次批量查询
+//
+// 不同类型的加载器需要使用不同的name; 不在查询中调用时返回新的加载器
+func GraphQLLoader[K comparable, V any](
+	ctx context.Context,
+	name string,
+	batch dataloader.BatchFunc[K, V],
+) *dataloader.Loader[K, V] {
+	ls, ok := ctx.Value(graphQLLoadersKey{}).(*graphQLLoaders)
+	if !ok {
+		return dataloader.New(batch)
+	}
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+	if l, ok := ls.loaders[name].(*dataloader.Loader[K, V]); ok {
+		return l
+	}
+	l := dataloader.New(batch)
+	ls.loaders[name] = l
+	return l
+}
+
+// GraphQLLoadByID 返回使用本次查询中名为name的数据加载器按ID加载对象的函数
+func GraphQLLoadByID[V any](name string, batch dataloader.BatchFunc[uint32, V]) GraphQLLoadFunc {
+	return func(ctx context.Context, id uint32) func() (any, error) {
+		thunk := GraphQLLoader(ctx, name, batch).Load(ctx, id)
+		return func() (any, error) {
+			v, err := thunk()
+			if err != nil {
+				return nil, err
+			}
+			return v, nil
+		}
+	}
+}
+
+// GraphQLRef 返回引用已注册对象类型的字段, 上级对象为解析函数返回的T, id为引用对象的ID, 为0时字段为null
+//
+// 需要在GraphQLFields中调用, 此时全部模块已注册完成; 对象类型所属模块未启用时返回nil, 该字段不出现在schema中
+func GraphQLRef[T any](s *GraphQLService, name, description string, id func(T) uint32) *graphql.Field {
+	obj, load := s.objects[name], s.loads[name]
+	if obj == nil {
+		return nil
+	}
+	return &graphql.Field{
+		Type:        obj,
+		Description: description,
+		Resolve: s.Authorize("", func(p graphql.ResolveParams) (any, error) {
+			m, ok := p.Source.(T)
+			if !ok || id(m) == 0 {
+				return nil, nil
+			}
+			return load(p.Context, id(m)), nil
+		}),
+	}
+}
+
+// GraphQLFields 返回在生成schema时才创建的字段, 用于引用其他模块注册的对象类型, 值为nil的字段不出现在schema中
+func GraphQLFields(fields func() graphql.Fields) graphql.FieldsThunk {
+	return func() graphql.Fields {
+		fs := fields()
+		for name, f := range fs {
+			if f == nil {
+				delete(fs, name)
+			}
+		}
+		return fs
+	}
+}
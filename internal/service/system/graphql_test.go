@@ -0,0 +1,172 @@
+package system
+
+import (
+	"context"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/suite"
+
+	sysmodel "gin-artweb/internal/model/system"
+	"gin-artweb/internal/shared/auth"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+	"gin-artweb/internal/shared/test"
+)
+
+type testColony struct {
+	ID        uint32
+	ColonyNum string
+	PackageID uint32
+}
+
+type testPackage struct {
+	ID      uint32
+	Version string
+}
+
+type GraphQLServiceTestSuite struct {
+	suite.Suite
+	svc      *GraphQLService
+	pkgCalls [][]uint32
+}
+
+func (suite *GraphQLServiceTestSuite) SetupTest() {
+	enforcer, err := auth.NewCasbinEnforcer()
+	suite.Require().NoError(err)
+	suite.Require().NoError(auth.AddPolicies(context.Background(), enforcer, [][]string{
+		{auth.RoleToSubject(1), "/api/v1/oes/colony", "GET"},
+		{auth.RoleToSubject(1), "/api/v1/resource/package", "GET"},
+		{auth.RoleToSubject(2), "/api/v1/resource/package", "GET"},
+	}))
+
+	colonies := []testColony{{1, "01", 10}, {2, "02", 11}, {3, "03", 10}, {4, "04", 0}}
+	pkgs := []testPackage{{10, "0.17.0"}, {11, "0.18.0"}}
+	suite.pkgCalls = nil
+	listPackage := func(_ context.Context, qp database.QueryParams) (int64, *[]testPackage, *errors.Error) {
+		ids := qp.Query["id IN ?"].([]uint32)
+		suite.pkgCalls = append(suite.pkgCalls, ids)
+		var ms []testPackage
+		for _, m := range pkgs {
+			for _, id := range ids {
+				if m.ID == id {
+					ms = append(ms, m)
+				}
+			}
+		}
+		return int64(len(ms)), &ms, nil
+	}
+
+	suite.svc = NewGraphQLService(test.NewTestZapLogger(), enforcer)
+	pkgType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Package",
+		Fields: graphql.Fields{
+			"id":      GraphQLValue(graphql.Int, "", func(m *testPackage) any { return m.ID }),
+			"version": GraphQLValue(graphql.String, "", func(m *testPackage) any { return m.Version }),
+		},
+	})
+	suite.svc.RegisterObject(pkgType, GraphQLLoadByID("package", GraphQLByID(
+		listPackage,
+		func(m testPackage) uint32 { return m.ID },
+		func(_ context.Context, m testPackage) *testPackage { return &m },
+	)))
+	colonyType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Colony",
+		Fields: GraphQLFields(func() graphql.Fields {
+			return graphql.Fields{
+				"colony_num": GraphQLValue(graphql.String, "", func(m testColony) any { return m.ColonyNum }),
+				"package":    GraphQLRef(suite.svc, "Package", "", func(m testColony) uint32 { return m.PackageID }),
+				"mon_node":   GraphQLRef(suite.svc, "MonNode", "", func(m testColony) uint32 { return 1 }),
+			}
+		}),
+	})
+	suite.svc.RegisterQuery("colonies", "GET /api/v1/oes/colony", &graphql.Field{
+		Type: graphql.NewList(colonyType),
+		Args: GraphQLArgs(nil),
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			if _, _, rErr := GraphQLPage(p); rErr != nil {
+				return nil, rErr
+			}
+			return colonies, nil
+		},
+	})
+	suite.svc.RegisterQuery("packages", "GET /api/v1/resource/package", &graphql.Field{
+		Type: graphql.NewList(pkgType),
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			_, ms, rErr := listPackage(p.Context, database.QueryParams{Query: map[string]any{"id IN ?": []uint32{10, 11}}})
+			if rErr != nil {
+				return nil, rErr
+			}
+			results := make([]*testPackage, len(*ms))
+			for i := range *ms {
+				results[i] = &(*ms)[i]
+			}
+			return results, nil
+		},
+	})
+	suite.Require().NoError(suite.svc.Build())
+}
+
+func (suite *GraphQLServiceTestSuite) TestExecuteBatchesRefs() {
+	result, rErr := suite.svc.Execute(userContext(1, 1), sysmodel.GraphQLRequest{
+		Query: "{ colonies { colony_num package { version } } }",
+	})
+	suite.Require().Nil(rErr)
+	suite.Require().Empty(result.Errors)
+	colonies := result.Data.(map[string]any)["colonies"].([]any)
+	suite.Require().Len(colonies, 4)
+	suite.Equal(map[string]any{"version": "0.17.0"}, colonies[0].(map[string]any)["package"])
+	suite.Equal(map[string]any{"version": "0.18.0"}, colonies[1].(map[string]any)["package"])
+	suite.Equal(map[string]any{"version": "0.17.0"}, colonies[2].(map[string]any)["package"])
+	suite.Nil(colonies[3].(map[string]any)["package"], "未关联程序包时应该返回null")
+	suite.Equal([][]uint32{{10, 11}}, suite.pkgCalls, "关联的程序包应该合并为一次查询")
+}
+
+func (suite *GraphQLServiceTestSuite) TestExecuteRBAC() {
+	result, rErr := suite.svc.Execute(userContext(2, 2), sysmodel.GraphQLRequest{
+		Query: "{ colonies { colony_num } packages { version } }",
+	})
+	suite.Require().Nil(rErr)
+	data := result.Data.(map[string]any)
+	suite.Nil(data["colonies"], "无权访问的字段应该返回null")
+	suite.Len(data["packages"], 2, "有权访问的字段不受影响")
+	suite.Require().Len(result.Errors, 1)
+	suite.Equal([]any{"colonies"}, result.Errors[0].Path)
+	suite.Equal(errors.ReasonForbidden, result.Errors[0].Extensions["reason"])
+
+	_, rErr = suite.svc.Execute(context.Background(), sysmodel.GraphQLRequest{Query: "{ packages { version } }"})
+	suite.Nil(rErr)
+}
+
+func (suite *GraphQLServiceTestSuite) TestExecuteValidation() {
+	result, rErr := suite.svc.Execute(userContext(1, 1), sysmodel.GraphQLRequest{
+		Query: "{ colonies(size: 1000) { colony_num } }",
+	})
+	suite.Require().Nil(rErr)
+	suite.Require().Len(result.Errors, 1)
+	suite.Equal(errors.ReasonValidationFailed, result.Errors[0].Extensions["reason"])
+
+	result, rErr = suite.svc.Execute(userContext(1, 1), sysmodel.GraphQLRequest{
+		Query: "{ colonies { mon_node { id } } }",
+	})
+	suite.Require().Nil(rErr)
+	suite.NotEmpty(result.Errors, "引用未启用模块的字段不应该出现在schema中")
+
+	result, rErr = suite.svc.Execute(userContext(1, 1), sysmodel.GraphQLRequest{
+		Query: "mutation { colonies { colony_num } }",
+	})
+	suite.Require().Nil(rErr)
+	suite.NotEmpty(result.Errors, "不支持mutation操作")
+}
+
+func (suite *GraphQLServiceTestSuite) TestExecuteWithoutSchema() {
+	svc := NewGraphQLService(test.NewTestZapLogger(), nil)
+	suite.Require().NoError(svc.Build())
+	_, rErr := svc.Execute(userContext(1, 1), sysmodel.GraphQLRequest{Query: "{ colonies { colony_num } }"})
+	suite.Require().NotNil(rErr)
+	suite.Equal(errors.ReasonFeatureDisabled, rErr.Reason)
+}
+
+func TestGraphQLServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(GraphQLServiceTestSuite))
+}
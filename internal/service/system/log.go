@@ -0,0 +1,123 @@
+package system
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/deadline"
+	"gin-artweb/internal/shared/errors"
+	"gin-artweb/pkg/logsearch"
+)
+
+const (
+	defaultLogQueryTimeout  = 10 * time.Second
+	defaultLogQueryMaxLines = 500
+)
+
+// LogQueryService 运行日志查询服务
+// 根据配置读取本地轮转的日志文件或查询Loki, 供运维人员在页面上排查问题
+type LogQueryService struct {
+	log      *zap.Logger
+	searcher logsearch.Searcher
+	backend  string
+	timeout  time.Duration
+	maxLines int
+}
+
+func NewLogQueryService(
+	log *zap.Logger,
+	conf config.LogQueryConfig,
+	logDir string,
+) (*LogQueryService, error) {
+	timeout := time.Duration(conf.Timeout) * time.Second
+	if timeout <= 0 {
+		timeout = defaultLogQueryTimeout
+	}
+	maxLines := conf.MaxLines
+	if maxLines <= 0 {
+		maxLines = defaultLogQueryMaxLines
+	}
+
+	var (
+		searcher logsearch.Searcher
+		backend  = conf.Backend
+	)
+	switch backend {
+	case config.LogQueryBackendLoki:
+		s, err := logsearch.NewLokiSearcher(conf.LokiURL, conf.LokiLabel, ctxutil.TraceIDKey, timeout)
+		if err != nil {
+			return nil, err
+		}
+		searcher = s
+	default:
+		backend = config.LogQueryBackendFile
+		searcher = logsearch.NewFileSearcher(logDir, ctxutil.TraceIDKey)
+	}
+
+	return &LogQueryService{
+		log:      log,
+		searcher: searcher,
+		backend:  backend,
+		timeout:  timeout,
+		maxLines: maxLines,
+	}, nil
+}
+
+// Search 在多个日志源中检索日志, 合并后按时间倒序返回
+func (s *LogQueryService) Search(
+	ctx context.Context,
+	sources []string,
+	q logsearch.Query,
+) ([]logsearch.Entry, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+	if !q.Start.IsZero() && !q.End.IsZero() && q.End.Before(q.Start) {
+		return nil, errors.ErrValidationFailed.WithField("reason", "结束时间不能早于开始时间")
+	}
+	if q.Limit <= 0 || q.Limit > s.maxLines {
+		q.Limit = s.maxLines
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始查询运行日志",
+		zap.String("backend", s.backend),
+		zap.Strings("sources", sources),
+		zap.Time("start", q.Start),
+		zap.Time("end", q.End),
+		zap.String("level", q.Level),
+		zap.String("trace_id", q.TraceID),
+		zap.String("keyword", q.Keyword),
+		zap.Int("limit", q.Limit),
+	)
+
+	sctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	defer deadline.Enter(ctx, deadline.StageLogSearch)()
+
+	var entries []logsearch.Entry
+	for _, source := range sources {
+		es, err := s.searcher.Search(sctx, source, q)
+		if err != nil {
+			ctxutil.Logger(ctx, s.log).Error(
+				"查询运行日志失败",
+				zap.Error(err),
+				zap.String("backend", s.backend),
+				zap.String("source", source),
+			)
+			return nil, errors.ErrLogQueryFailed.WithField("source", source).WithCause(err)
+		}
+		entries = append(entries, es...)
+	}
+	entries = logsearch.SortAndLimit(entries, q.Limit)
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"查询运行日志成功",
+		zap.Int("count", len(entries)),
+	)
+	return entries, nil
+}
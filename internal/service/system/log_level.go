@@ -0,0 +1,100 @@
+package system
+
+import (
+	"context"
+
+	emperror "emperror.dev/errors"
+	"go.uber.org/zap"
+
+	sysmodel "gin-artweb/internal/model/system"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/errors"
+	"gin-artweb/internal/shared/log"
+)
+
+// LogLevelService 运行时日志级别调整服务
+//
+// 调整只对当前实例生效, 重启后恢复配置文件中的级别
+type LogLevelService struct {
+	log    *zap.Logger
+	levels *log.Levels
+}
+
+func NewLogLevelService(
+	log *zap.Logger,
+	levels *log.Levels,
+) *LogLevelService {
+	return &LogLevelService{
+		log:    log,
+		levels: levels,
+	}
+}
+
+// ListLevels 查询全部日志记录器的级别, 按名称排序
+func (s *LogLevelService) ListLevels(ctx context.Context) []sysmodel.LogLevelOut {
+	names := s.levels.Names()
+	out := make([]sysmodel.LogLevelOut, 0, len(names))
+	for _, name := range names {
+		level, defaultLevel, _ := s.levels.Get(name)
+		out = append(out, sysmodel.LogLevelOut{
+			Logger:       name,
+			Level:        level,
+			DefaultLevel: defaultLevel,
+		})
+	}
+	return out
+}
+
+// SetLevel 调整日志记录器的级别, logger为空时调整全部日志记录器
+func (s *LogLevelService) SetLevel(
+	ctx context.Context,
+	logger, level string,
+) ([]sysmodel.LogLevelOut, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	if err := s.levels.Set(logger, level); err != nil {
+		return nil, s.levelError(ctx, err, logger)
+	}
+
+	// 调整后的级别可能高于Info, 使用Warn保证调整记录不被过滤
+	ctxutil.Logger(ctx, s.log).Warn(
+		"调整日志级别",
+		zap.String("logger", logger),
+		zap.String("level", level),
+	)
+	return s.ListLevels(ctx), nil
+}
+
+// ResetLevel 恢复日志记录器启动时的级别, logger为空时恢复全部日志记录器
+func (s *LogLevelService) ResetLevel(
+	ctx context.Context,
+	logger string,
+) ([]sysmodel.LogLevelOut, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	if err := s.levels.Reset(logger); err != nil {
+		return nil, s.levelError(ctx, err, logger)
+	}
+
+	ctxutil.Logger(ctx, s.log).Warn(
+		"恢复日志级别",
+		zap.String("logger", logger),
+	)
+	return s.ListLevels(ctx), nil
+}
+
+func (s *LogLevelService) levelError(ctx context.Context, err error, logger string) *errors.Error {
+	if emperror.Is(err, log.ErrLoggerNotFound) {
+		return errors.ErrLoggerNotFound.WithField("logger", logger)
+	}
+	ctxutil.Logger(ctx, s.log).Error(
+		"调整日志级别失败",
+		zap.Error(err),
+		zap.String("logger", logger),
+	)
+	return errors.ErrValidationFailed.WithCause(err)
+}
@@ -0,0 +1,102 @@
+package system
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/errors"
+	"gin-artweb/internal/shared/log"
+	"gin-artweb/internal/shared/test"
+)
+
+type LogLevelServiceTestSuite struct {
+	suite.Suite
+	levels *log.Levels
+	biz    *zap.Logger
+	buf    *bytes.Buffer
+	svc    *LogLevelService
+}
+
+func (suite *LogLevelServiceTestSuite) SetupTest() {
+	conf := &config.LogConfig{
+		Level:  "info",
+		Levels: map[string]string{log.LoggerData: "warn"},
+	}
+	suite.levels = log.NewLevels()
+	suite.buf = &bytes.Buffer{}
+	biz, err := log.NewLevelLogger(conf, suite.levels, log.LoggerBiz, suite.buf)
+	suite.Require().NoError(err)
+	suite.biz = biz
+	_, err = log.NewLevelLogger(conf, suite.levels, log.LoggerData, &bytes.Buffer{})
+	suite.Require().NoError(err)
+	suite.svc = NewLogLevelService(test.NewTestZapLogger(), suite.levels)
+}
+
+func (suite *LogLevelServiceTestSuite) TestListLevels() {
+	levels := suite.svc.ListLevels(context.Background())
+	suite.Require().Len(levels, 2)
+	suite.Equal(log.LoggerBiz, levels[0].Logger)
+	suite.Equal("info", levels[0].Level)
+	suite.Equal(log.LoggerData, levels[1].Logger)
+	suite.Equal("warn", levels[1].Level, "配置中按名称覆盖的级别应该生效")
+}
+
+func (suite *LogLevelServiceTestSuite) TestSetAndResetLevel() {
+	suite.biz.Debug("调整前")
+	suite.Empty(suite.buf.String())
+
+	levels, rErr := suite.svc.SetLevel(context.Background(), log.LoggerBiz, "debug")
+	suite.Require().Nil(rErr)
+	suite.Equal("debug", levels[0].Level)
+	suite.Equal("info", levels[0].DefaultLevel)
+	suite.Equal("warn", levels[1].Level, "只调整指定的日志记录器")
+
+	suite.biz.Debug("调整后")
+	suite.Contains(suite.buf.String(), "调整后", "调整后立即生效")
+
+	levels, rErr = suite.svc.SetLevel(context.Background(), "", "error")
+	suite.Require().Nil(rErr)
+	suite.Equal("error", levels[0].Level)
+	suite.Equal("error", levels[1].Level)
+
+	levels, rErr = suite.svc.ResetLevel(context.Background(), "")
+	suite.Require().Nil(rErr)
+	suite.Equal("info", levels[0].Level)
+	suite.Equal("warn", levels[1].Level)
+}
+
+func (suite *LogLevelServiceTestSuite) TestUnknownLogger() {
+	_, rErr := suite.svc.SetLevel(context.Background(), "unknown", "debug")
+	suite.Require().NotNil(rErr)
+	suite.Equal(errors.ErrLoggerNotFound.Reason, rErr.Reason)
+
+	_, rErr = suite.svc.ResetLevel(context.Background(), "unknown")
+	suite.Require().NotNil(rErr)
+	suite.Equal(errors.ErrLoggerNotFound.Reason, rErr.Reason)
+}
+
+func (suite *LogLevelServiceTestSuite) TestSampling() {
+	conf := &config.LogConfig{
+		Level:    "info",
+		Sampling: map[string]config.LogSamplingConfig{log.LoggerService: {Tick: 60, Initial: 2, Thereafter: 0}},
+	}
+	buf := &bytes.Buffer{}
+	logger, err := log.NewLevelLogger(conf, log.NewLevels(), log.LoggerService, buf)
+	suite.Require().NoError(err)
+	for range 5 {
+		logger.Info("查询成功")
+	}
+	logger.Info("其他日志")
+	suite.Equal(2, strings.Count(buf.String(), "查询成功"), "超出采样条数的相同日志应该丢弃")
+	suite.Contains(buf.String(), "其他日志")
+}
+
+func TestLogLevelServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(LogLevelServiceTestSuite))
+}
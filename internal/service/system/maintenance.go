@@ -0,0 +1,462 @@
+package system
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	sysmodel "gin-artweb/internal/model/system"
+	mdsrepo "gin-artweb/internal/repository/mds"
+	oesrepo "gin-artweb/internal/repository/oes"
+	sysrepo "gin-artweb/internal/repository/system"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+)
+
+// maintenanceSystemUser 维护窗口自动暂停任务和屏蔽告警时审计记录的操作用户
+const maintenanceSystemUser = "system"
+
+type MaintenanceService struct {
+	log        *zap.Logger
+	windowRepo *sysrepo.MaintenanceWindowRepo
+	auditRepo  *sysrepo.AuditRecordRepo
+	oesColony  *oesrepo.OesColonyRepo
+	mdsColony  *mdsrepo.MdsColonyRepo
+}
+
+func NewMaintenanceService(
+	log *zap.Logger,
+	windowRepo *sysrepo.MaintenanceWindowRepo,
+	auditRepo *sysrepo.AuditRecordRepo,
+	oesColony *oesrepo.OesColonyRepo,
+	mdsColony *mdsrepo.MdsColonyRepo,
+) *MaintenanceService {
+	return &MaintenanceService{
+		log:        log,
+		windowRepo: windowRepo,
+		auditRepo:  auditRepo,
+		oesColony:  oesColony,
+		mdsColony:  mdsColony,
+	}
+}
+
+// colonyTarget 集群范围维护窗口关联的集群信息
+type colonyTarget struct {
+	colonyNum string
+	monNodeID uint32
+}
+
+func (s *MaintenanceService) resolveColony(
+	ctx context.Context,
+	module string,
+	colonyID uint32,
+) (*colonyTarget, *errors.Error) {
+	switch module {
+	case "oes":
+		m, err := s.oesColony.GetModel(ctx, nil, colonyID)
+		if err != nil {
+			return nil, errors.NewGormError(err, map[string]any{"id": colonyID})
+		}
+		return &colonyTarget{colonyNum: m.ColonyNum, monNodeID: m.MonNodeID}, nil
+	case "mds":
+		m, err := s.mdsColony.GetModel(ctx, nil, colonyID)
+		if err != nil {
+			return nil, errors.NewGormError(err, map[string]any{"id": colonyID})
+		}
+		return &colonyTarget{colonyNum: m.ColonyNum, monNodeID: m.MonNodeID}, nil
+	}
+	return nil, errors.ErrMaintenanceWindowInvalid.WithField("module", module)
+}
+
+// validate 校验维护窗口的作用范围和时间范围
+func (s *MaintenanceService) validate(
+	ctx context.Context,
+	m *sysmodel.MaintenanceWindowModel,
+) *errors.Error {
+	if m.Recurrence == "" {
+		m.Recurrence = sysmodel.MaintenanceRecurrenceNone
+	}
+	if !m.ValidRange() {
+		return errors.ErrMaintenanceWindowInvalid.WithFields(map[string]any{
+			"start_at":   m.StartAt.Format(time.DateTime),
+			"end_at":     m.EndAt.Format(time.DateTime),
+			"recurrence": m.Recurrence,
+		})
+	}
+
+	switch m.Scope {
+	case sysmodel.MaintenanceScopeGlobal:
+		m.Module = ""
+		m.TargetID = 0
+	case sysmodel.MaintenanceScopeColony:
+		if m.TargetID == 0 || (m.Module != "oes" && m.Module != "mds") {
+			return errors.ErrMaintenanceWindowInvalid.WithFields(map[string]any{
+				"scope":     m.Scope,
+				"module":    m.Module,
+				"target_id": m.TargetID,
+			})
+		}
+		if _, rErr := s.resolveColony(ctx, m.Module, m.TargetID); rErr != nil {
+			return rErr
+		}
+	case sysmodel.MaintenanceScopeNode:
+		if m.TargetID == 0 || m.Module != "mon" {
+			return errors.ErrMaintenanceWindowInvalid.WithFields(map[string]any{
+				"scope":     m.Scope,
+				"module":    m.Module,
+				"target_id": m.TargetID,
+			})
+		}
+	default:
+		return errors.ErrMaintenanceWindowInvalid.WithField("scope", m.Scope)
+	}
+	return nil
+}
+
+// audit 记录维护窗口的审计记录, 审计失败不影响业务操作
+func (s *MaintenanceService) audit(
+	ctx context.Context,
+	windowID uint32,
+	action string,
+	before, after any,
+	username string,
+) {
+	record, err := sysmodel.NewAuditRecord(
+		"system", sysmodel.MaintenanceAuditResource, windowID, action,
+		before, after, username, ctxutil.GetTraceID(ctx),
+	)
+	if err == nil {
+		err = s.auditRepo.CreateModel(ctx, record)
+	}
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"记录维护窗口审计记录失败",
+			zap.Error(err),
+			zap.Uint32("window_id", windowID),
+			zap.String("action", action),
+		)
+	}
+}
+
+func (s *MaintenanceService) CreateMaintenanceWindow(
+	ctx context.Context,
+	m sysmodel.MaintenanceWindowModel,
+) (*sysmodel.MaintenanceWindowModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始创建维护窗口",
+		zap.Object(database.ModelKey, &m),
+	)
+
+	if rErr := s.validate(ctx, &m); rErr != nil {
+		return nil, rErr
+	}
+
+	if err := s.windowRepo.CreateModel(ctx, &m); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"创建维护窗口失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, &m),
+		)
+		return nil, errors.NewGormError(err, nil)
+	}
+	s.audit(ctx, m.ID, "create", nil, sysmodel.MaintenanceAuditSnapshot(m), m.Username)
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"创建维护窗口成功",
+		zap.Object(database.ModelKey, &m),
+	)
+	return &m, nil
+}
+
+func (s *MaintenanceService) UpdateMaintenanceWindowByID(
+	ctx context.Context,
+	windowID uint32,
+	m sysmodel.MaintenanceWindowModel,
+) (*sysmodel.MaintenanceWindowModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始更新维护窗口",
+		zap.Uint32("window_id", windowID),
+		zap.Object(database.ModelKey, &m),
+	)
+
+	before, rErr := s.FindMaintenanceWindowByID(ctx, windowID)
+	if rErr != nil {
+		return nil, rErr
+	}
+	if rErr := s.validate(ctx, &m); rErr != nil {
+		return nil, rErr
+	}
+
+	data := map[string]any{
+		"name":        m.Name,
+		"scope":       m.Scope,
+		"module":      m.Module,
+		"target_id":   m.TargetID,
+		"start_at":    m.StartAt,
+		"end_at":      m.EndAt,
+		"recurrence":  m.Recurrence,
+		"is_enabled":  m.IsEnabled,
+		"description": m.Description,
+		"username":    m.Username,
+	}
+	if err := s.windowRepo.UpdateModel(ctx, data, "id = ?", windowID); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"更新维护窗口失败",
+			zap.Error(err),
+			zap.Uint32("window_id", windowID),
+		)
+		return nil, errors.NewGormError(err, data)
+	}
+
+	after, rErr := s.FindMaintenanceWindowByID(ctx, windowID)
+	if rErr != nil {
+		return nil, rErr
+	}
+	s.audit(ctx, windowID, "update",
+		sysmodel.MaintenanceAuditSnapshot(*before), sysmodel.MaintenanceAuditSnapshot(*after), m.Username)
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"更新维护窗口成功",
+		zap.Uint32("window_id", windowID),
+	)
+	return after, nil
+}
+
+func (s *MaintenanceService) DeleteMaintenanceWindowByID(
+	ctx context.Context,
+	windowID uint32,
+	username string,
+) *errors.Error {
+	if ctx.Err() != nil {
+		return errors.FromError(ctx.Err())
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始删除维护窗口",
+		zap.Uint32("window_id", windowID),
+	)
+
+	before, rErr := s.FindMaintenanceWindowByID(ctx, windowID)
+	if rErr != nil {
+		return rErr
+	}
+	if err := s.windowRepo.DeleteModel(ctx, windowID); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"删除维护窗口失败",
+			zap.Error(err),
+			zap.Uint32("window_id", windowID),
+		)
+		return errors.NewGormError(err, map[string]any{"id": windowID})
+	}
+	s.audit(ctx, windowID, "delete", sysmodel.MaintenanceAuditSnapshot(*before), nil, username)
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"删除维护窗口成功",
+		zap.Uint32("window_id", windowID),
+	)
+	return nil
+}
+
+func (s *MaintenanceService) FindMaintenanceWindowByID(
+	ctx context.Context,
+	windowID uint32,
+) (*sysmodel.MaintenanceWindowModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	m, err := s.windowRepo.GetModel(ctx, windowID)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询维护窗口失败",
+			zap.Error(err),
+			zap.Uint32("window_id", windowID),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"id": windowID})
+	}
+	return m, nil
+}
+
+func (s *MaintenanceService) ListMaintenanceWindow(
+	ctx context.Context,
+	qp database.QueryParams,
+) (int64, *[]sysmodel.MaintenanceWindowModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return 0, nil, errors.FromError(ctx.Err())
+	}
+
+	count, ms, err := s.windowRepo.ListModel(ctx, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询维护窗口列表失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		return 0, nil, errors.NewGormError(err, nil)
+	}
+	return count, ms, nil
+}
+
+// ActiveWindows 查询指定时间生效的维护窗口
+func (s *MaintenanceService) ActiveWindows(
+	ctx context.Context,
+	t time.Time,
+) ([]sysmodel.MaintenanceWindowModel, *errors.Error) {
+	qp := database.QueryParams{
+		Query:   map[string]any{"is_enabled = ?": true},
+		OrderBy: []string{"id ASC"},
+	}
+	_, ms, rErr := s.ListMaintenanceWindow(ctx, qp)
+	if rErr != nil {
+		return nil, rErr
+	}
+	active := make([]sysmodel.MaintenanceWindowModel, 0)
+	for _, m := range *ms {
+		if m.ActiveAt(t) {
+			active = append(active, m)
+		}
+	}
+	return active, nil
+}
+
+// MatchSchedule 查询命中计划任务的维护窗口, 未命中时返回nil
+//
+// 全局窗口命中全部计划任务; 集群窗口命中脚本所属项目与窗口模块一致,
+// 且第一个命令行参数为该集群号的计划任务
+func (s *MaintenanceService) MatchSchedule(
+	ctx context.Context,
+	project string,
+	commandArgs string,
+	t time.Time,
+) (*sysmodel.MaintenanceWindowModel, *errors.Error) {
+	windows, rErr := s.ActiveWindows(ctx, t)
+	if rErr != nil {
+		return nil, rErr
+	}
+	args := strings.Fields(commandArgs)
+	for _, w := range windows {
+		switch w.Scope {
+		case sysmodel.MaintenanceScopeGlobal:
+			return &w, nil
+		case sysmodel.MaintenanceScopeColony:
+			if w.Module != project || len(args) == 0 {
+				continue
+			}
+			target, rErr := s.resolveColony(ctx, w.Module, w.TargetID)
+			if rErr != nil {
+				ctxutil.Logger(ctx, s.log).Warn(
+					"维护窗口关联的集群不存在",
+					zap.Error(rErr),
+					zap.Uint32("window_id", w.ID),
+				)
+				continue
+			}
+			if target.colonyNum == args[0] {
+				return &w, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// MatchMonNode 查询命中mon节点告警的维护窗口, 未命中时返回nil
+//
+// 全局窗口、该节点的节点窗口以及使用该节点的集群窗口均会命中
+func (s *MaintenanceService) MatchMonNode(
+	ctx context.Context,
+	monNodeID uint32,
+	t time.Time,
+) (*sysmodel.MaintenanceWindowModel, *errors.Error) {
+	windows, rErr := s.ActiveWindows(ctx, t)
+	if rErr != nil {
+		return nil, rErr
+	}
+	for _, w := range windows {
+		switch w.Scope {
+		case sysmodel.MaintenanceScopeGlobal:
+			return &w, nil
+		case sysmodel.MaintenanceScopeNode:
+			if w.TargetID == monNodeID {
+				return &w, nil
+			}
+		case sysmodel.MaintenanceScopeColony:
+			target, rErr := s.resolveColony(ctx, w.Module, w.TargetID)
+			if rErr != nil {
+				ctxutil.Logger(ctx, s.log).Warn(
+					"维护窗口关联的集群不存在",
+					zap.Error(rErr),
+					zap.Uint32("window_id", w.ID),
+				)
+				continue
+			}
+			if target.monNodeID == monNodeID {
+				return &w, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// MatchColony 查询命中集群告警的维护窗口, 未命中时返回nil
+//
+// 全局窗口以及模块和集群ID一致的集群窗口会命中
+func (s *MaintenanceService) MatchColony(
+	ctx context.Context,
+	module string,
+	colonyID uint32,
+	t time.Time,
+) (*sysmodel.MaintenanceWindowModel, *errors.Error) {
+	windows, rErr := s.ActiveWindows(ctx, t)
+	if rErr != nil {
+		return nil, rErr
+	}
+	for _, w := range windows {
+		switch w.Scope {
+		case sysmodel.MaintenanceScopeGlobal:
+			return &w, nil
+		case sysmodel.MaintenanceScopeColony:
+			if w.Module == module && w.TargetID == colonyID {
+				return &w, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// MatchGlobal 查询生效的全局维护窗口, 未命中时返回nil, 用于不属于集群和mon节点的告警
+func (s *MaintenanceService) MatchGlobal(
+	ctx context.Context,
+	t time.Time,
+) (*sysmodel.MaintenanceWindowModel, *errors.Error) {
+	windows, rErr := s.ActiveWindows(ctx, t)
+	if rErr != nil {
+		return nil, rErr
+	}
+	for _, w := range windows {
+		if w.Scope == sysmodel.MaintenanceScopeGlobal {
+			return &w, nil
+		}
+	}
+	return nil, nil
+}
+
+// RecordSuppression 记录维护窗口暂停计划任务或屏蔽告警的审计记录
+func (s *MaintenanceService) RecordSuppression(
+	ctx context.Context,
+	w *sysmodel.MaintenanceWindowModel,
+	action string,
+	detail map[string]any,
+) {
+	s.audit(ctx, w.ID, action, nil, detail, maintenanceSystemUser)
+}
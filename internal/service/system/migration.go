@@ -0,0 +1,46 @@
+package system
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	sysmodel "gin-artweb/internal/model/system"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+)
+
+// MigrationService 数据库迁移状态服务
+// 只提供查询, 迁移需要通过命令行 -migrate 执行
+type MigrationService struct {
+	log      *zap.Logger
+	migrator *database.Migrator
+}
+
+func NewMigrationService(
+	log *zap.Logger,
+	migrator *database.Migrator,
+) *MigrationService {
+	return &MigrationService{
+		log:      log,
+		migrator: migrator,
+	}
+}
+
+// SchemaStatus 查询数据库结构版本和各迁移的执行状态
+func (s *MigrationService) SchemaStatus(ctx context.Context) (*sysmodel.SchemaStatusOut, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	statuses, err := s.migrator.Status(ctx)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询数据库迁移状态失败",
+			zap.Error(err),
+		)
+		return nil, errors.NewGormError(err, nil)
+	}
+	return sysmodel.SchemaStatusToOut(s.migrator.Latest(), statuses), nil
+}
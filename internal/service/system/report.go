@@ -0,0 +1,482 @@
+package system
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"go.uber.org/zap"
+
+	jobsmodel "gin-artweb/internal/model/jobs"
+	sysmodel "gin-artweb/internal/model/system"
+	sysrepo "gin-artweb/internal/repository/system"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/crontab"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/errors"
+	"gin-artweb/internal/shared/events"
+	"gin-artweb/pkg/xlsx"
+)
+
+// ReportService 报表服务
+//
+// 按日或按周统计各集群任务的执行成功率、登录情况和告警次数, 支持导出为csv或xlsx文件,
+// 定时报表生成后保存在报表目录中供下载, 并通过发件箱发布报表生成事件推送到订阅的webhook
+type ReportService struct {
+	log       *zap.Logger
+	repo      *sysrepo.ReportRepo
+	schedules []config.ReportSchedule
+	dir       string
+	outbox    *events.Outbox
+}
+
+func NewReportService(
+	log *zap.Logger,
+	repo *sysrepo.ReportRepo,
+	conf *config.ReportConfig,
+	dir string,
+	outbox *events.Outbox,
+) (*ReportService, error) {
+	var schedules []config.ReportSchedule
+	if conf != nil {
+		schedules = conf.Schedules
+	}
+
+	seen := make(map[string]bool, len(schedules))
+	for _, schedule := range schedules {
+		if schedule.Name == "" || filepath.Base(schedule.Name) != schedule.Name || strings.HasPrefix(schedule.Name, ".") {
+			return nil, fmt.Errorf("定时报表名称%q不合法", schedule.Name)
+		}
+		if seen[schedule.Name] {
+			return nil, fmt.Errorf("定时报表%s重复配置", schedule.Name)
+		}
+		seen[schedule.Name] = true
+		if _, err := crontab.ValidateCronExpression(schedule.Cron, false); err != nil {
+			return nil, fmt.Errorf("定时报表%s的cron表达式不合法: %w", schedule.Name, err)
+		}
+		switch schedule.Period {
+		case sysmodel.ReportPeriodDaily, sysmodel.ReportPeriodWeekly:
+		default:
+			return nil, fmt.Errorf("定时报表%s的周期%s不支持", schedule.Name, schedule.Period)
+		}
+		switch schedule.Format {
+		case sysmodel.ReportFormatCSV, sysmodel.ReportFormatXLSX:
+		default:
+			return nil, fmt.Errorf("定时报表%s的文件格式%s不支持", schedule.Name, schedule.Format)
+		}
+	}
+
+	return &ReportService{
+		log:       log,
+		repo:      repo,
+		schedules: schedules,
+		dir:       dir,
+		outbox:    outbox,
+	}, nil
+}
+
+// Schedules 返回配置的定时报表
+func (s *ReportService) Schedules() []config.ReportSchedule {
+	return s.schedules
+}
+
+// Generate 生成报表, date为空时日报统计昨天, 周报统计上周
+func (s *ReportService) Generate(ctx context.Context, period, date string) (*sysmodel.ReportOut, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	start, end, err := reportRange(period, date, time.Now())
+	if err != nil {
+		return nil, errors.ErrValidationFailed.WithCause(err)
+	}
+
+	ctxutil.Logger(ctx, s.log).Debug(
+		"开始生成报表",
+		zap.String("period", period),
+		zap.Time("start", start),
+		zap.Time("end", end),
+	)
+
+	counts, err := s.repo.CountJobs(ctx, start, end)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"统计集群任务执行记录失败",
+			zap.Error(err),
+			zap.String("period", period),
+		)
+		return nil, errors.NewGormError(err, nil)
+	}
+
+	login, err := s.repo.CountLogins(ctx, start, end)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"统计登录记录失败",
+			zap.Error(err),
+			zap.String("period", period),
+		)
+		return nil, errors.NewGormError(err, nil)
+	}
+
+	alerts, err := s.repo.ListAlerts(ctx, start, end)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"统计告警失败",
+			zap.Error(err),
+			zap.String("period", period),
+		)
+		return nil, errors.NewGormError(err, nil)
+	}
+
+	return &sysmodel.ReportOut{
+		Period:      period,
+		Start:       start.Format(time.DateTime),
+		End:         end.Format(time.DateTime),
+		GeneratedAt: time.Now().Format(time.DateTime),
+		Jobs:        reportJobs(counts),
+		Login:       sysmodel.ReportLoginCountToOut(*login),
+		Alerts:      reportAlerts(alerts),
+	}, nil
+}
+
+// Export 将报表渲染为指定格式的文件内容, 返回文件内容和文件名
+func (s *ReportService) Export(ctx context.Context, report *sysmodel.ReportOut, format string) ([]byte, string, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, "", errors.FromError(ctx.Err())
+	}
+
+	var (
+		buf bytes.Buffer
+		err error
+	)
+	switch format {
+	case sysmodel.ReportFormatCSV:
+		err = writeReportCSV(&buf, report)
+	case sysmodel.ReportFormatXLSX:
+		err = xlsx.Write(&buf, reportSheets(report))
+	default:
+		err = fmt.Errorf("报表格式%s不支持", format)
+	}
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"导出报表失败",
+			zap.Error(err),
+			zap.String("period", report.Period),
+			zap.String("format", format),
+		)
+		return nil, "", errors.ErrExportCacheFileFailed.WithCause(err)
+	}
+
+	start, _ := time.ParseInLocation(time.DateTime, report.Start, time.Local)
+	return buf.Bytes(), fmt.Sprintf("%s-%s.%s", report.Period, start.Format("20060102"), format), nil
+}
+
+// RunSchedule 生成上一个周期的定时报表并保存到报表目录, 返回文件路径
+//
+// 报表保存后发布报表生成事件, 由webhook推送给订阅了该事件的地址;
+// 写入发件箱失败只记录日志, 报表文件仍可下载
+func (s *ReportService) RunSchedule(ctx context.Context, schedule config.ReportSchedule) (string, *errors.Error) {
+	path, report, rErr := s.save(ctx, schedule.Name, schedule.Period, "", schedule.Format, nil)
+	if rErr != nil {
+		return "", rErr
+	}
+
+	if err := s.outbox.Add(ctx, sysmodel.ReportGeneratedEvent{
+		Name:   schedule.Name,
+		Format: schedule.Format,
+		File:   filepath.Base(path),
+		Report: *report,
+	}); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"写入报表生成事件失败",
+			zap.Error(err),
+			zap.String("name", schedule.Name),
+			zap.String("path", path),
+		)
+	}
+	return path, nil
+}
+
+// SaveFile 生成报表并保存到报表目录, 文件名以prefix开头, 返回文件路径
+//
+// progress不为空时在各步骤开始时上报进度, 用于后台任务
+func (s *ReportService) SaveFile(
+	ctx context.Context,
+	prefix, period, date, format string,
+	progress func(percent int, message string),
+) (string, *errors.Error) {
+	path, _, rErr := s.save(ctx, prefix, period, date, format, progress)
+	return path, rErr
+}
+
+// save 生成报表并保存到报表目录, 返回文件路径和报表数据
+func (s *ReportService) save(
+	ctx context.Context,
+	prefix, period, date, format string,
+	progress func(percent int, message string),
+) (string, *sysmodel.ReportOut, *errors.Error) {
+	if progress == nil {
+		progress = func(int, string) {}
+	}
+
+	progress(10, "统计报表数据")
+	report, rErr := s.Generate(ctx, period, date)
+	if rErr != nil {
+		return "", nil, rErr
+	}
+	progress(60, "导出报表文件")
+	data, filename, rErr := s.Export(ctx, report, format)
+	if rErr != nil {
+		return "", nil, rErr
+	}
+	if ctx.Err() != nil {
+		return "", nil, errors.FromError(ctx.Err())
+	}
+
+	progress(90, "保存报表文件")
+	path := filepath.Join(s.dir, prefix+"-"+filename)
+	if err := writeFileAtomic(path, data); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"保存报表文件失败",
+			zap.Error(err),
+			zap.String("prefix", prefix),
+			zap.String("path", path),
+		)
+		return "", nil, errors.ErrExportCacheFileFailed.WithCause(err)
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"保存报表文件成功",
+		zap.String("prefix", prefix),
+		zap.String("path", path),
+	)
+	return path, report, nil
+}
+
+// ListFiles 查询已生成的定时报表文件, 按修改时间倒序
+func (s *ReportService) ListFiles(ctx context.Context) ([]sysmodel.ReportFileOut, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	entries, err := os.ReadDir(s.dir)
+	if os.IsNotExist(err) {
+		return []sysmodel.ReportFileOut{}, nil
+	}
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"读取报表目录失败",
+			zap.Error(err),
+			zap.String("dir", s.dir),
+		)
+		return nil, errors.FromError(err)
+	}
+
+	type file struct {
+		out     sysmodel.ReportFileOut
+		modTime time.Time
+	}
+	files := make([]file, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, file{
+			out: sysmodel.ReportFileOut{
+				Name:    entry.Name(),
+				Size:    info.Size(),
+				ModTime: info.ModTime().Format(time.DateTime),
+			},
+			modTime: info.ModTime(),
+		})
+	}
+	slices.SortFunc(files, func(a, b file) int {
+		return b.modTime.Compare(a.modTime)
+	})
+
+	outs := make([]sysmodel.ReportFileOut, 0, len(files))
+	for _, f := range files {
+		outs = append(outs, f.out)
+	}
+	return outs, nil
+}
+
+// FilePath 返回定时报表文件的路径, 文件名不能包含路径
+func (s *ReportService) FilePath(ctx context.Context, name string) (string, *errors.Error) {
+	if ctx.Err() != nil {
+		return "", errors.FromError(ctx.Err())
+	}
+	if name == "" || filepath.Base(name) != name || strings.HasPrefix(name, ".") || strings.ContainsFunc(name, unicode.IsControl) {
+		return "", errors.ErrValidationFailed.WithField("name", name)
+	}
+	return filepath.Join(s.dir, name), nil
+}
+
+// reportRange 计算报表的统计时间范围[start, end)
+func reportRange(period, date string, now time.Time) (time.Time, time.Time, error) {
+	var day time.Time
+	if date != "" {
+		d, err := time.ParseInLocation(time.DateOnly, date, time.Local)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		day = d
+	} else {
+		day = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+		if period == sysmodel.ReportPeriodWeekly {
+			day = day.AddDate(0, 0, -7)
+		} else {
+			day = day.AddDate(0, 0, -1)
+		}
+	}
+
+	switch period {
+	case sysmodel.ReportPeriodDaily:
+		return day, day.AddDate(0, 0, 1), nil
+	case sysmodel.ReportPeriodWeekly:
+		// 周一为一周的第一天
+		monday := day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+		return monday, monday.AddDate(0, 0, 7), nil
+	default:
+		return time.Time{}, time.Time{}, fmt.Errorf("报表周期%s不支持", period)
+	}
+}
+
+// reportJobs 将按状态分组的执行记录数汇总为各集群的执行统计
+func reportJobs(counts []sysmodel.ReportJobCount) []sysmodel.ReportJobOut {
+	jobs := make([]sysmodel.ReportJobOut, 0)
+	index := make(map[string]int)
+	for _, c := range counts {
+		key := c.Project + "/" + c.ColonyNum
+		i, ok := index[key]
+		if !ok {
+			i = len(jobs)
+			index[key] = i
+			jobs = append(jobs, sysmodel.ReportJobOut{Project: c.Project, ColonyNum: c.ColonyNum})
+		}
+		jobs[i].Total += c.Count
+		switch c.Status {
+		case jobsmodel.RecordStatusSuccess:
+			jobs[i].Success += c.Count
+		case jobsmodel.RecordStatusFailed, jobsmodel.RecordStatusTimeout,
+			jobsmodel.RecordStatusCrashed, jobsmodel.RecordStatusInterrupted:
+			jobs[i].Failed += c.Count
+		}
+	}
+	for i := range jobs {
+		if finished := jobs[i].Success + jobs[i].Failed; finished > 0 {
+			jobs[i].SuccessRate = math.Round(float64(jobs[i].Success)/float64(finished)*10000) / 10000
+		}
+	}
+	return jobs
+}
+
+// reportAlerts 将告警按类型汇总为发出次数和屏蔽次数, 按告警类型排序
+func reportAlerts(records []sysmodel.ReportAlertRecord) []sysmodel.ReportAlertOut {
+	alerts := make([]sysmodel.ReportAlertOut, 0)
+	index := make(map[string]int)
+	for _, r := range records {
+		var payload struct {
+			Kind string `json:"kind"`
+		}
+		// 告警内容由发出方写入, 解析失败时计入类型为空的告警
+		_ = json.Unmarshal([]byte(r.Payload), &payload)
+		i, ok := index[payload.Kind]
+		if !ok {
+			i = len(alerts)
+			index[payload.Kind] = i
+			alerts = append(alerts, sysmodel.ReportAlertOut{Kind: payload.Kind})
+		}
+		if r.Muted {
+			alerts[i].Muted++
+		} else {
+			alerts[i].Fired++
+		}
+	}
+	slices.SortFunc(alerts, func(a, b sysmodel.ReportAlertOut) int {
+		return strings.Compare(a.Kind, b.Kind)
+	})
+	return alerts
+}
+
+// reportSheets 将报表转换为工作表, csv按顺序写入各工作表并以空行分隔
+func reportSheets(report *sysmodel.ReportOut) []xlsx.Sheet {
+	summary := [][]any{
+		{"报表周期", report.Period},
+		{"开始时间", report.Start},
+		{"结束时间", report.End},
+		{"生成时间", report.GeneratedAt},
+		{},
+		{"登录总次数", "登录成功次数", "登录失败次数", "登录用户数"},
+		{report.Login.Total, report.Login.Success, report.Login.Failure, report.Login.Users},
+	}
+
+	jobs := [][]any{{"项目", "集群号", "执行总数", "成功数", "失败数", "成功率"}}
+	for _, job := range report.Jobs {
+		jobs = append(jobs, []any{job.Project, job.ColonyNum, job.Total, job.Success, job.Failed, job.SuccessRate})
+	}
+
+	alerts := [][]any{{"告警类型", "发出次数", "屏蔽次数"}}
+	for _, alert := range report.Alerts {
+		alerts = append(alerts, []any{alert.Kind, alert.Fired, alert.Muted})
+	}
+
+	return []xlsx.Sheet{
+		{Name: "概览", Rows: summary},
+		{Name: "任务执行", Rows: jobs},
+		{Name: "告警", Rows: alerts},
+	}
+}
+
+func writeReportCSV(buf *bytes.Buffer, report *sysmodel.ReportOut) error {
+	// 写入UTF-8 BOM, 避免Excel打开时中文乱码
+	buf.WriteString("\ufeff")
+	w := csv.NewWriter(buf)
+	for i, sheet := range reportSheets(report) {
+		if i > 0 {
+			if err := w.Write(nil); err != nil {
+				return err
+			}
+		}
+		for _, row := range sheet.Rows {
+			record := make([]string, len(row))
+			for j, v := range row {
+				record[j] = fmt.Sprint(v)
+			}
+			if err := w.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// writeFileAtomic 先写入临时文件再重命名, 避免下载到写入一半的报表
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp := filepath.Join(filepath.Dir(path), "."+filepath.Base(path)+".tmp")
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
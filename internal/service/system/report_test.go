@@ -0,0 +1,226 @@
+package system
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	custmodel "gin-artweb/internal/model/customer"
+	jobsmodel "gin-artweb/internal/model/jobs"
+	sysmodel "gin-artweb/internal/model/system"
+	sysrepo "gin-artweb/internal/repository/system"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/events"
+	"gin-artweb/internal/shared/test"
+)
+
+type ReportServiceTestSuite struct {
+	suite.Suite
+	db     *gorm.DB
+	repo   *sysrepo.ReportRepo
+	outbox *events.Outbox
+	dir    string
+}
+
+func (suite *ReportServiceTestSuite) SetupTest() {
+	suite.db = test.NewTestGormDBWithConfig(nil)
+	suite.db.AutoMigrate(
+		&jobsmodel.ScriptModel{}, &jobsmodel.ScriptRecordModel{}, &custmodel.LoginRecordModel{},
+		&sysmodel.AuditRecordModel{}, &events.OutboxModel{},
+	)
+	logger := test.NewTestZapLogger()
+	suite.repo = sysrepo.NewReportRepo(logger, suite.db, test.NewTestDBTimeouts())
+	suite.outbox = events.NewOutbox(logger, suite.db, events.NewBus(logger), nil)
+	suite.dir = suite.T().TempDir()
+}
+
+func (suite *ReportServiceTestSuite) newService(schedules ...config.ReportSchedule) *ReportService {
+	svc, err := NewReportService(test.NewTestZapLogger(), suite.repo, &config.ReportConfig{Schedules: schedules}, suite.dir, suite.outbox)
+	suite.Require().NoError(err)
+	return svc
+}
+
+func (suite *ReportServiceTestSuite) TestNewReportServiceInvalid() {
+	cases := []config.ReportSchedule{
+		{Name: "../daily", Cron: "0 7 * * *", Period: "daily", Format: "xlsx"},
+		{Name: "daily", Cron: "0 0 7 * * *", Period: "daily", Format: "xlsx"},
+		{Name: "daily", Cron: "0 7 * * *", Period: "monthly", Format: "xlsx"},
+		{Name: "daily", Cron: "0 7 * * *", Period: "daily", Format: "pdf"},
+	}
+	for _, schedule := range cases {
+		_, err := NewReportService(test.NewTestZapLogger(), suite.repo, &config.ReportConfig{
+			Schedules: []config.ReportSchedule{schedule},
+		}, suite.dir, nil)
+		suite.Error(err, "定时报表配置不合法时应该返回错误: %+v", schedule)
+	}
+
+	schedule := config.ReportSchedule{Name: "daily", Cron: "0 7 * * *", Period: "daily", Format: "csv"}
+	_, err := NewReportService(test.NewTestZapLogger(), suite.repo, &config.ReportConfig{
+		Schedules: []config.ReportSchedule{schedule, schedule},
+	}, suite.dir, nil)
+	suite.Error(err, "定时报表名称重复时应该返回错误")
+}
+
+func (suite *ReportServiceTestSuite) TestReportRange() {
+	// 2024-01-10为周三
+	now := time.Date(2024, 1, 10, 15, 30, 0, 0, time.Local)
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.Local) }
+
+	cases := []struct {
+		period     string
+		date       string
+		start, end time.Time
+	}{
+		{sysmodel.ReportPeriodDaily, "", day(9), day(10)},
+		{sysmodel.ReportPeriodDaily, "2024-01-03", day(3), day(4)},
+		{sysmodel.ReportPeriodWeekly, "", day(1), day(8)},
+		{sysmodel.ReportPeriodWeekly, "2024-01-14", day(8), day(15)},
+		{sysmodel.ReportPeriodWeekly, "2024-01-08", day(8), day(15)},
+	}
+	for _, c := range cases {
+		start, end, err := reportRange(c.period, c.date, now)
+		suite.NoError(err)
+		suite.Equal(c.start, start, "period=%s, date=%s", c.period, c.date)
+		suite.Equal(c.end, end, "period=%s, date=%s", c.period, c.date)
+	}
+
+	_, _, err := reportRange("monthly", "", now)
+	suite.Error(err)
+	_, _, err = reportRange(sysmodel.ReportPeriodDaily, "2024/01/03", now)
+	suite.Error(err)
+}
+
+func (suite *ReportServiceTestSuite) TestGenerate() {
+	ctx := context.Background()
+	start := time.Date(2024, 1, 3, 0, 0, 0, 0, time.Local)
+
+	script := jobsmodel.ScriptModel{Name: "start.sh", Project: "oes", Label: "cron"}
+	suite.Require().NoError(suite.db.Create(&script).Error)
+	for _, status := range []int{
+		jobsmodel.RecordStatusSuccess, jobsmodel.RecordStatusSuccess, jobsmodel.RecordStatusSuccess,
+		jobsmodel.RecordStatusCrashed, jobsmodel.RecordStatusRunning,
+	} {
+		m := jobsmodel.ScriptRecordModel{ScriptID: script.ID, CommandArgs: "01", Status: status}
+		m.CreatedAt = start.Add(time.Hour)
+		suite.Require().NoError(suite.db.Omit("Script").Create(&m).Error)
+	}
+	suite.Require().NoError(suite.db.Create(&[]custmodel.LoginRecordModel{
+		{Username: "admin", Status: true, LoginAt: start.Add(time.Hour)},
+		{Username: "admin", Status: false, LoginAt: start.Add(time.Hour)},
+	}).Error)
+	suite.Require().NoError(suite.db.Create(&[]events.OutboxModel{
+		{EventID: "e1", EventType: events.AlertFired, OccurredAt: start.Add(time.Hour), Payload: `{"kind":"mon_node_health"}`},
+		{EventID: "e2", EventType: events.AlertFired, OccurredAt: start.Add(2 * time.Hour), Payload: `{"kind":"mon_node_health"}`},
+		{EventID: "e3", EventType: events.AlertFired, OccurredAt: start.Add(time.Hour), Payload: `{"kind":"cert_expiry"}`},
+		{EventID: "e4", EventType: events.AlertFired, OccurredAt: start.Add(-time.Hour), Payload: `{"kind":"cert_expiry"}`},
+		{EventID: "e5", EventType: events.JobFinished, OccurredAt: start.Add(time.Hour), Payload: `{}`},
+	}).Error)
+	muted := sysmodel.AuditRecordModel{
+		Module: "system", Resource: sysmodel.MaintenanceAuditResource, Action: sysmodel.MaintenanceActionMuteAlert,
+		After: `{"kind":"mon_node_health"}`, CreatedAt: start.Add(time.Hour),
+	}
+	suite.Require().NoError(suite.db.Create(&muted).Error)
+
+	svc := suite.newService()
+	report, rErr := svc.Generate(ctx, sysmodel.ReportPeriodDaily, "2024-01-03")
+	suite.Require().Nil(rErr)
+	suite.Equal("2024-01-03 00:00:00", report.Start)
+	suite.Equal("2024-01-04 00:00:00", report.End)
+	suite.Equal([]sysmodel.ReportJobOut{
+		{Project: "oes", ColonyNum: "01", Total: 5, Success: 3, Failed: 1, SuccessRate: 0.75},
+	}, report.Jobs, "执行中的记录不计入成功率")
+	suite.Equal(sysmodel.ReportLoginOut{Total: 2, Success: 1, Failure: 1, Users: 1}, report.Login)
+	suite.Equal([]sysmodel.ReportAlertOut{
+		{Kind: "cert_expiry", Fired: 1},
+		{Kind: "mon_node_health", Fired: 2, Muted: 1},
+	}, report.Alerts, "只统计时间范围内的告警")
+
+	_, rErr = svc.Generate(ctx, sysmodel.ReportPeriodDaily, "20240103")
+	suite.NotNil(rErr, "日期格式不合法时应该返回错误")
+}
+
+func (suite *ReportServiceTestSuite) TestExport() {
+	ctx := context.Background()
+	report := &sysmodel.ReportOut{
+		Period: sysmodel.ReportPeriodWeekly,
+		Start:  "2024-01-08 00:00:00",
+		End:    "2024-01-15 00:00:00",
+		Jobs:   []sysmodel.ReportJobOut{{Project: "mds", ColonyNum: "02", Total: 4, Success: 4, SuccessRate: 1}},
+		Login:  sysmodel.ReportLoginOut{Total: 3, Success: 3, Users: 2},
+		Alerts: []sysmodel.ReportAlertOut{{Kind: "cert_expiry", Fired: 2, Muted: 1}},
+	}
+	svc := suite.newService()
+
+	data, filename, rErr := svc.Export(ctx, report, sysmodel.ReportFormatCSV)
+	suite.Require().Nil(rErr)
+	suite.Equal("weekly-20240108.csv", filename)
+	suite.True(strings.HasPrefix(string(data), "\ufeff"), "csv应该以BOM开头")
+	suite.Contains(string(data), "mds,02,4,4,0,1\n")
+	suite.Contains(string(data), "cert_expiry,2,1\n")
+
+	data, filename, rErr = svc.Export(ctx, report, sysmodel.ReportFormatXLSX)
+	suite.Require().Nil(rErr)
+	suite.Equal("weekly-20240108.xlsx", filename)
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	suite.Require().NoError(err)
+	var names []string
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+	}
+	suite.Contains(names, "xl/worksheets/sheet3.xml", "xlsx应该包含概览、任务执行和告警三个工作表")
+
+	_, _, rErr = svc.Export(ctx, report, "pdf")
+	suite.NotNil(rErr)
+}
+
+func (suite *ReportServiceTestSuite) TestRunScheduleAndFiles() {
+	ctx := context.Background()
+	schedule := config.ReportSchedule{Name: "ops", Cron: "0 7 * * 1", Period: sysmodel.ReportPeriodWeekly, Format: sysmodel.ReportFormatXLSX}
+	svc := suite.newService(schedule)
+
+	files, rErr := svc.ListFiles(ctx)
+	suite.Require().Nil(rErr)
+	suite.Empty(files, "报表目录不存在时返回空列表")
+
+	path, rErr := svc.RunSchedule(ctx, schedule)
+	suite.Require().Nil(rErr)
+	suite.Equal(suite.dir, filepath.Dir(path))
+	suite.True(strings.HasPrefix(filepath.Base(path), "ops-weekly-"))
+	_, err := os.Stat(path)
+	suite.NoError(err)
+
+	var outbox []events.OutboxModel
+	suite.Require().NoError(suite.db.Where("event_type = ?", events.ReportGenerated).Find(&outbox).Error)
+	suite.Require().Len(outbox, 1, "定时报表生成后应该发布报表生成事件")
+	var event sysmodel.ReportGeneratedEvent
+	suite.Require().NoError(json.Unmarshal([]byte(outbox[0].Payload), &event))
+	suite.Equal("ops", event.Name)
+	suite.Equal(filepath.Base(path), event.File)
+	suite.Equal(sysmodel.ReportPeriodWeekly, event.Report.Period)
+
+	files, rErr = svc.ListFiles(ctx)
+	suite.Require().Nil(rErr)
+	suite.Require().Len(files, 1)
+	suite.Equal(filepath.Base(path), files[0].Name)
+
+	filePath, rErr := svc.FilePath(ctx, files[0].Name)
+	suite.Nil(rErr)
+	suite.Equal(path, filePath)
+	for _, name := range []string{"../system.yaml", "a/b.xlsx", ".hidden", ""} {
+		_, rErr = svc.FilePath(ctx, name)
+		suite.NotNil(rErr, "文件名%q包含路径时应该返回错误", name)
+	}
+}
+
+func TestReportServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(ReportServiceTestSuite))
+}
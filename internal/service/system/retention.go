@@ -0,0 +1,396 @@
+package system
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	jobsmodel "gin-artweb/internal/model/jobs"
+	sysmodel "gin-artweb/internal/model/system"
+	sysrepo "gin-artweb/internal/repository/system"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/errors"
+	"gin-artweb/internal/shared/events"
+	"gin-artweb/internal/shared/metrics"
+	"gin-artweb/pkg/archive"
+)
+
+const (
+	defaultRetentionBatchSize = 1000
+
+	RetentionArchiveCSV    = "csv"
+	RetentionArchiveNDJSON = "ndjson"
+)
+
+// retentionTable 支持配置保留策略的数据表
+type retentionTable struct {
+	timeColumn string // 按保留天数清理时比较的时间字段
+	extra      string // 额外的清理条件, 为空表示无
+	extraArgs  []any
+}
+
+// retentionTables 支持配置保留策略的数据表白名单
+var retentionTables = map[string]retentionTable{
+	"customer_login_record": {timeColumn: "login_at"},
+	"system_audit_record":   {timeColumn: "created_at"},
+	// 待执行和执行中的记录仍会被更新, 不能清理
+	"jobs_script_record": {
+		timeColumn: "created_at",
+		extra:      "status NOT IN ?",
+		extraArgs:  []any{[]int{jobsmodel.RecordStatusPending, jobsmodel.RecordStatusRunning}},
+	},
+	// 待推送的记录仍会重试, 不能清理
+	"system_webhook_delivery": {
+		timeColumn: "created_at",
+		extra:      "status <> ?",
+		extraArgs:  []any{sysmodel.WebhookDeliveryPending},
+	},
+	// 待发布的事件仍会重试, 不能清理
+	"system_event_outbox": {
+		timeColumn: "created_at",
+		extra:      "status <> ?",
+		extraArgs:  []any{events.OutboxPending},
+	},
+}
+
+// RetentionService 数据保留策略服务
+//
+// 按表配置保留天数或保留行数, 定时分批删除过期记录, 删除前可以归档为csv或ndjson文件并压缩为tar.gz
+type RetentionService struct {
+	log        *zap.Logger
+	repo       *sysrepo.RetentionRepo
+	policies   []config.RetentionPolicy
+	batchSize  int
+	archiveDir string
+}
+
+func NewRetentionService(
+	log *zap.Logger,
+	repo *sysrepo.RetentionRepo,
+	conf *config.RetentionConfig,
+	archiveDir string,
+) (*RetentionService, error) {
+	c := config.RetentionConfig{}
+	if conf != nil {
+		c = *conf
+	}
+	if c.BatchSize <= 0 {
+		c.BatchSize = defaultRetentionBatchSize
+	}
+
+	seen := make(map[string]bool, len(c.Policies))
+	for _, policy := range c.Policies {
+		if _, ok := retentionTables[policy.Table]; !ok {
+			return nil, fmt.Errorf("数据表%s不支持配置保留策略", policy.Table)
+		}
+		if seen[policy.Table] {
+			return nil, fmt.Errorf("数据表%s重复配置保留策略", policy.Table)
+		}
+		seen[policy.Table] = true
+		if policy.KeepDays < 0 || policy.KeepRows < 0 {
+			return nil, fmt.Errorf("数据表%s的保留天数和保留行数不能小于0", policy.Table)
+		}
+		switch policy.Archive {
+		case "", RetentionArchiveCSV, RetentionArchiveNDJSON:
+		default:
+			return nil, fmt.Errorf("数据表%s的归档格式%s不支持", policy.Table, policy.Archive)
+		}
+	}
+
+	return &RetentionService{
+		log:        log,
+		repo:       repo,
+		policies:   c.Policies,
+		batchSize:  c.BatchSize,
+		archiveDir: archiveDir,
+	}, nil
+}
+
+// Run 按配置依次清理各数据表, 单个表清理失败不影响其他表, 返回第一个错误
+func (s *RetentionService) Run(ctx context.Context) *errors.Error {
+	var firstErr *errors.Error
+	for _, policy := range s.policies {
+		if _, rErr := s.Purge(ctx, policy); rErr != nil && firstErr == nil {
+			firstErr = rErr
+		}
+	}
+	return firstErr
+}
+
+// Purge 按保留策略清理单个数据表, 返回删除的行数
+func (s *RetentionService) Purge(ctx context.Context, policy config.RetentionPolicy) (int64, *errors.Error) {
+	if ctx.Err() != nil {
+		return 0, errors.FromError(ctx.Err())
+	}
+
+	purged, archivePath, rErr := s.purge(ctx, policy)
+	if rErr != nil {
+		metrics.RetentionRunsTotal.WithLabelValues(policy.Table, metrics.RetentionFailure).Inc()
+		ctxutil.Logger(ctx, s.log).Error(
+			"清理过期数据失败",
+			zap.Error(rErr),
+			zap.String("table", policy.Table),
+			zap.Int64("purged", purged),
+			zap.String("archive", archivePath),
+		)
+		return purged, rErr
+	}
+
+	metrics.RetentionRunsTotal.WithLabelValues(policy.Table, metrics.RetentionSuccess).Inc()
+	ctxutil.Logger(ctx, s.log).Info(
+		"清理过期数据成功",
+		zap.String("table", policy.Table),
+		zap.Int64("purged", purged),
+		zap.String("archive", archivePath),
+	)
+	return purged, nil
+}
+
+func (s *RetentionService) purge(ctx context.Context, policy config.RetentionPolicy) (int64, string, *errors.Error) {
+	conds, err := s.purgeConds(ctx, policy)
+	if err != nil {
+		return 0, "", errors.NewGormError(err, nil)
+	}
+	if conds == nil {
+		return 0, "", nil
+	}
+
+	var (
+		purged int64
+		arc    *retentionArchive
+	)
+	for {
+		if ctx.Err() != nil {
+			return purged, "", errors.FromError(ctx.Err())
+		}
+
+		var ids []uint32
+		if policy.Archive == "" {
+			ids, err = s.repo.ListBatchIDs(ctx, policy.Table, s.batchSize, conds...)
+			if err != nil {
+				return purged, "", errors.NewGormError(err, nil)
+			}
+		} else {
+			rows, err := s.repo.ListBatch(ctx, policy.Table, s.batchSize, conds...)
+			if err != nil {
+				return purged, "", errors.NewGormError(err, nil)
+			}
+			if len(rows) > 0 {
+				if arc == nil {
+					if arc, err = newRetentionArchive(s.archiveDir, policy.Table, policy.Archive); err != nil {
+						return purged, "", errors.ErrExportCacheFileFailed.WithCause(err)
+					}
+					defer arc.abort()
+				}
+				// 先写入归档再删除, 归档失败时不删除这一批记录
+				if err := arc.write(rows); err != nil {
+					return purged, arc.path, errors.ErrExportCacheFileFailed.WithCause(err)
+				}
+			}
+			ids = rowIDs(rows)
+		}
+		if len(ids) == 0 {
+			break
+		}
+
+		n, err := s.repo.DeleteByIDs(ctx, policy.Table, ids)
+		if err != nil {
+			return purged, "", errors.NewGormError(err, nil)
+		}
+		purged += n
+		metrics.RetentionPurgedRowsTotal.WithLabelValues(policy.Table).Add(float64(n))
+
+		// 没有删除任何记录时退出, 避免同一批记录反复查询
+		if n == 0 || len(ids) < s.batchSize {
+			break
+		}
+	}
+
+	if arc == nil {
+		return purged, "", nil
+	}
+	archivePath, err := arc.finish()
+	if err != nil {
+		return purged, arc.path, errors.ErrZIPFailed.WithCause(err)
+	}
+	return purged, archivePath, nil
+}
+
+// purgeConds 构造清理条件, 没有需要清理的记录时返回nil
+func (s *RetentionService) purgeConds(ctx context.Context, policy config.RetentionPolicy) ([]any, error) {
+	table := retentionTables[policy.Table]
+
+	var (
+		clauses []string
+		args    []any
+	)
+	if policy.KeepDays > 0 {
+		clauses = append(clauses, table.timeColumn+" < ?")
+		args = append(args, time.Now().AddDate(0, 0, -policy.KeepDays))
+	}
+	if policy.KeepRows > 0 {
+		cutoff, err := s.repo.CutoffID(ctx, policy.Table, policy.KeepRows)
+		if err != nil {
+			return nil, err
+		}
+		if cutoff > 0 {
+			clauses = append(clauses, "id < ?")
+			args = append(args, cutoff)
+		}
+	}
+	if len(clauses) == 0 {
+		return nil, nil
+	}
+
+	// 超出任一保留限制的记录都会被清理
+	query := "(" + strings.Join(clauses, " OR ") + ")"
+	if table.extra != "" {
+		query += " AND " + table.extra
+		args = append(args, table.extraArgs...)
+	}
+	return append([]any{query}, args...), nil
+}
+
+// rowIDs 提取记录的ID
+func rowIDs(rows []map[string]any) []uint32 {
+	ids := make([]uint32, 0, len(rows))
+	for _, row := range rows {
+		var id uint64
+		switch v := row["id"].(type) {
+		case int64:
+			id = uint64(v)
+		case int32:
+			id = uint64(v)
+		case int:
+			id = uint64(v)
+		case uint32:
+			id = uint64(v)
+		case uint64:
+			id = v
+		case []byte:
+			id, _ = strconv.ParseUint(string(v), 10, 32)
+		case string:
+			id, _ = strconv.ParseUint(v, 10, 32)
+		}
+		if id > 0 {
+			ids = append(ids, uint32(id))
+		}
+	}
+	return ids
+}
+
+// retentionArchive 清理前的归档文件, 全部写入后压缩为tar.gz
+type retentionArchive struct {
+	file    *os.File
+	path    string
+	format  string
+	columns []string
+	csv     *csv.Writer
+	json    *json.Encoder
+}
+
+func newRetentionArchive(dir, table, format string) (*retentionArchive, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	path := filepath.Join(dir, fmt.Sprintf("%s-%s.%s", table, time.Now().Format("20060102150405"), format))
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	a := &retentionArchive{file: f, path: path, format: format}
+	if format == RetentionArchiveCSV {
+		a.csv = csv.NewWriter(f)
+	} else {
+		a.json = json.NewEncoder(f)
+	}
+	return a, nil
+}
+
+// write 写入一批记录并落盘
+func (a *retentionArchive) write(rows []map[string]any) error {
+	for _, row := range rows {
+		if a.json != nil {
+			normalized := make(map[string]any, len(row))
+			for k, v := range row {
+				normalized[k] = archiveValue(v)
+			}
+			if err := a.json.Encode(normalized); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// csv的列以第一条记录为准
+		if a.columns == nil {
+			for k := range row {
+				a.columns = append(a.columns, k)
+			}
+			slices.Sort(a.columns)
+			if err := a.csv.Write(a.columns); err != nil {
+				return err
+			}
+		}
+		record := make([]string, len(a.columns))
+		for i, col := range a.columns {
+			if v := archiveValue(row[col]); v != nil {
+				record[i] = fmt.Sprint(v)
+			}
+		}
+		if err := a.csv.Write(record); err != nil {
+			return err
+		}
+	}
+	if a.csv != nil {
+		a.csv.Flush()
+		if err := a.csv.Error(); err != nil {
+			return err
+		}
+	}
+	return a.file.Sync()
+}
+
+// finish 关闭归档文件并压缩, 返回压缩后的文件路径
+func (a *retentionArchive) finish() (string, error) {
+	if err := a.file.Close(); err != nil {
+		return "", err
+	}
+	a.file = nil
+	dst := a.path + ".tar.gz"
+	if err := archive.TarGz(a.path, dst); err != nil {
+		return "", err
+	}
+	if err := os.Remove(a.path); err != nil {
+		return "", err
+	}
+	return dst, nil
+}
+
+// abort 清理失败时关闭归档文件, 已写入的记录保留在未压缩的文件中
+func (a *retentionArchive) abort() {
+	if a.file != nil {
+		a.file.Close()
+	}
+}
+
+// archiveValue 将数据库返回的值转换为可读的归档值
+func archiveValue(v any) any {
+	switch val := v.(type) {
+	case []byte:
+		return string(val)
+	case time.Time:
+		return val.Format(time.RFC3339Nano)
+	default:
+		return val
+	}
+}
@@ -0,0 +1,135 @@
+package system
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	custmodel "gin-artweb/internal/model/customer"
+	sysmodel "gin-artweb/internal/model/system"
+	sysrepo "gin-artweb/internal/repository/system"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/test"
+)
+
+type RetentionServiceTestSuite struct {
+	suite.Suite
+	auditRepo *sysrepo.AuditRecordRepo
+	repo      *sysrepo.RetentionRepo
+	dir       string
+}
+
+func (suite *RetentionServiceTestSuite) SetupTest() {
+	db := test.NewTestGormDBWithConfig(nil)
+	db.AutoMigrate(&sysmodel.AuditRecordModel{}, &custmodel.LoginRecordModel{})
+	logger := test.NewTestZapLogger()
+	suite.auditRepo = sysrepo.NewAuditRecordRepo(logger, db, test.NewTestDBTimeouts())
+	suite.repo = sysrepo.NewRetentionRepo(logger, db, test.NewTestDBTimeouts())
+	suite.dir = suite.T().TempDir()
+}
+
+func (suite *RetentionServiceTestSuite) newService(batchSize int, policies ...config.RetentionPolicy) *RetentionService {
+	svc, err := NewRetentionService(test.NewTestZapLogger(), suite.repo, &config.RetentionConfig{
+		BatchSize: batchSize,
+		Policies:  policies,
+	}, suite.dir)
+	suite.Require().NoError(err)
+	return svc
+}
+
+func (suite *RetentionServiceTestSuite) createAudits(n int, at time.Time) {
+	for range n {
+		m := sysmodel.AuditRecordModel{Module: "mon", Resource: "node", Action: "delete", CreatedAt: at}
+		suite.Require().NoError(suite.auditRepo.CreateModel(context.Background(), &m))
+	}
+}
+
+// readArchive 读取tar.gz归档中唯一文件的内容
+func (suite *RetentionServiceTestSuite) readArchive(pattern string) string {
+	matches, err := filepath.Glob(filepath.Join(suite.dir, pattern))
+	suite.Require().NoError(err)
+	suite.Require().Len(matches, 1)
+
+	f, err := os.Open(matches[0])
+	suite.Require().NoError(err)
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	suite.Require().NoError(err)
+	tr := tar.NewReader(gz)
+	_, err = tr.Next()
+	suite.Require().NoError(err)
+	data, err := io.ReadAll(tr)
+	suite.Require().NoError(err)
+	return string(data)
+}
+
+func (suite *RetentionServiceTestSuite) TestPurgeByDaysWithArchive() {
+	ctx := context.Background()
+	suite.createAudits(5, time.Now().AddDate(0, 0, -40))
+	suite.createAudits(2, time.Now())
+
+	svc := suite.newService(2, config.RetentionPolicy{Table: "system_audit_record", KeepDays: 30, Archive: RetentionArchiveNDJSON})
+	purged, rErr := svc.Purge(ctx, svc.policies[0])
+	suite.Require().Nil(rErr)
+	suite.Equal(int64(5), purged, "应该分批删除全部过期记录")
+
+	ids, err := suite.repo.ListBatchIDs(ctx, "system_audit_record", 10)
+	suite.NoError(err)
+	suite.Equal([]uint32{6, 7}, ids)
+
+	content := suite.readArchive("system_audit_record-*.ndjson.tar.gz")
+	suite.Equal(5, strings.Count(content, "\n"), "删除前应该归档全部过期记录")
+	suite.Contains(content, `"action":"delete"`)
+	matches, _ := filepath.Glob(filepath.Join(suite.dir, "*.ndjson"))
+	suite.Empty(matches, "压缩后应该删除未压缩的归档文件")
+}
+
+func (suite *RetentionServiceTestSuite) TestPurgeByRowsWithCSV() {
+	ctx := context.Background()
+	suite.createAudits(6, time.Now())
+
+	svc := suite.newService(100, config.RetentionPolicy{Table: "system_audit_record", KeepRows: 4, Archive: RetentionArchiveCSV})
+	suite.Nil(svc.Run(ctx))
+
+	ids, err := suite.repo.ListBatchIDs(ctx, "system_audit_record", 10)
+	suite.NoError(err)
+	suite.Equal([]uint32{3, 4, 5, 6}, ids, "应该只保留最近的4条记录")
+
+	lines := strings.Split(strings.TrimSpace(suite.readArchive("system_audit_record-*.csv.tar.gz")), "\n")
+	suite.Len(lines, 3, "csv应该包含表头和2条记录")
+	suite.True(strings.HasPrefix(lines[0], "action,"), "csv表头应该按列名排序")
+
+	// 没有需要清理的记录时不生成归档
+	purged, rErr := svc.Purge(ctx, svc.policies[0])
+	suite.Nil(rErr)
+	suite.Zero(purged)
+}
+
+func (suite *RetentionServiceTestSuite) TestInvalidPolicy() {
+	logger := test.NewTestZapLogger()
+	_, err := NewRetentionService(logger, suite.repo, &config.RetentionConfig{
+		Policies: []config.RetentionPolicy{{Table: "customer_user", KeepDays: 1}},
+	}, suite.dir)
+	suite.Error(err, "不在白名单中的表不能配置保留策略")
+
+	_, err = NewRetentionService(logger, suite.repo, &config.RetentionConfig{
+		Policies: []config.RetentionPolicy{{Table: "customer_login_record", KeepDays: 1, Archive: "xml"}},
+	}, suite.dir)
+	suite.Error(err)
+
+	svc, err := NewRetentionService(logger, suite.repo, nil, suite.dir)
+	suite.NoError(err)
+	suite.Nil(svc.Run(context.Background()))
+}
+
+func TestRetentionServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(RetentionServiceTestSuite))
+}
@@ -0,0 +1,221 @@
+package system
+
+import (
+	"context"
+	"html"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/casbin/casbin/v2"
+	"go.uber.org/zap"
+
+	sysmodel "gin-artweb/internal/model/system"
+	"gin-artweb/internal/shared/auth"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+)
+
+// defaultSearchLimit 每组默认返回的结果数
+const defaultSearchLimit = 5
+
+// SearchDocument 搜索源返回的记录
+type SearchDocument struct {
+	ID          uint32
+	Title       string
+	Description string
+}
+
+// SearchSource 一类资源的搜索源
+//
+// 默认实现DBSearchSource使用数据库LIKE查询, 接入Elasticsearch等搜索引擎时实现本接口替换即可
+type SearchSource interface {
+	// Kind 资源类型, 在结果中作为分组标识
+	Kind() string
+
+	// Label 资源名称
+	Label() string
+
+	// Permission 资源列表接口的方法和路径, 角色有权访问该接口时才返回该组结果
+	Permission() (method, path string)
+
+	// Search 返回匹配的总数和前limit条记录
+	Search(ctx context.Context, q string, limit int) (int64, []SearchDocument, error)
+}
+
+// DBSearchSource 使用数据库LIKE查询的搜索源
+type DBSearchSource[T any] struct {
+	kind    string
+	label   string
+	method  string
+	path    string
+	columns []string
+	list    func(context.Context, database.QueryParams) (int64, *[]T, error)
+	toDoc   func(T) SearchDocument
+}
+
+// NewDBSearchSource 创建数据库搜索源
+// api: 资源列表接口, 格式为"方法 路径"
+// columns: 匹配的列, 任意一列包含关键字即匹配
+// list: 资源仓库的ListModel
+func NewDBSearchSource[T any](
+	kind, label, api string,
+	columns []string,
+	list func(context.Context, database.QueryParams) (int64, *[]T, error),
+	toDoc func(T) SearchDocument,
+) *DBSearchSource[T] {
+	method, path, _ := strings.Cut(api, " ")
+	return &DBSearchSource[T]{
+		kind:    kind,
+		label:   label,
+		method:  method,
+		path:    path,
+		columns: columns,
+		list:    list,
+		toDoc:   toDoc,
+	}
+}
+
+func (s *DBSearchSource[T]) Kind() string { return s.kind }
+
+func (s *DBSearchSource[T]) Label() string { return s.label }
+
+func (s *DBSearchSource[T]) Permission() (string, string) { return s.method, s.path }
+
+func (s *DBSearchSource[T]) Search(ctx context.Context, q string, limit int) (int64, []SearchDocument, error) {
+	qp := database.QueryParams{
+		Filter:  database.ContainsFilter(q, s.columns...),
+		OrderBy: []string{"id ASC"},
+		Size:    limit,
+		IsCount: true,
+	}
+	total, ms, err := s.list(ctx, qp)
+	if err != nil {
+		return 0, nil, err
+	}
+	docs := make([]SearchDocument, 0, len(*ms))
+	for _, m := range *ms {
+		docs = append(docs, s.toDoc(m))
+	}
+	return total, docs, nil
+}
+
+// SearchService 跨模块搜索服务
+//
+// 各模块加载时通过Register注册搜索源, 搜索时按当前用户角色过滤无权访问的资源
+type SearchService struct {
+	log      *zap.Logger
+	enforcer *casbin.Enforcer
+	sources  []SearchSource
+}
+
+func NewSearchService(
+	log *zap.Logger,
+	enforcer *casbin.Enforcer,
+) *SearchService {
+	return &SearchService{
+		log:      log,
+		enforcer: enforcer,
+	}
+}
+
+// Register 注册搜索源, 只能在服务启动时调用, 结果按注册顺序分组
+func (s *SearchService) Register(sources ...SearchSource) {
+	s.sources = append(s.sources, sources...)
+}
+
+// Search 在当前用户有权访问的搜索源中搜索关键字, 没有匹配结果的分组不返回
+// 单个搜索源查询失败时跳过该组, 不影响其他分组
+func (s *SearchService) Search(
+	ctx context.Context,
+	q string,
+	limit int,
+) ([]sysmodel.SearchGroupOut, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	claims, rErr := ctxutil.GetUserClaims(ctx)
+	if rErr != nil {
+		return nil, rErr
+	}
+	q = strings.TrimSpace(q)
+	if q == "" {
+		return []sysmodel.SearchGroupOut{}, nil
+	}
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+
+	role := auth.RoleToSubject(claims.RoleID)
+	groups := make([]sysmodel.SearchGroupOut, 0, len(s.sources))
+	for _, source := range s.sources {
+		method, path := source.Permission()
+		ok, err := auth.EnforceUser(s.enforcer, claims.UserID, claims.RoleID, path, method)
+		if err != nil {
+			ctxutil.Logger(ctx, s.log).Error(
+				"搜索权限校验失败",
+				zap.Error(err),
+				zap.String("kind", source.Kind()),
+				zap.String(auth.SubKey, role),
+			)
+			return nil, errors.FromError(err)
+		}
+		if !ok {
+			continue
+		}
+
+		total, docs, err := source.Search(ctx, q, limit)
+		if err != nil {
+			ctxutil.Logger(ctx, s.log).Error(
+				"搜索失败",
+				zap.Error(err),
+				zap.String("kind", source.Kind()),
+				zap.String("q", q),
+			)
+			continue
+		}
+		if total == 0 {
+			continue
+		}
+
+		hits := make([]sysmodel.SearchHitOut, 0, len(docs))
+		for _, doc := range docs {
+			hits = append(hits, sysmodel.SearchHitOut{
+				ID:                   doc.ID,
+				Title:                doc.Title,
+				Description:          doc.Description,
+				TitleHighlight:       highlight(doc.Title, q),
+				DescriptionHighlight: highlight(doc.Description, q),
+			})
+		}
+		groups = append(groups, sysmodel.SearchGroupOut{
+			Kind:  source.Kind(),
+			Label: source.Label(),
+			Total: total,
+			Hits:  hits,
+		})
+	}
+	return groups, nil
+}
+
+// highlight 转义HTML后用<em>标记text中不区分大小写匹配q的部分
+func highlight(text, q string) string {
+	var b strings.Builder
+	last := 0
+	for i := 0; i+len(q) <= len(text); {
+		if strings.EqualFold(text[i:i+len(q)], q) {
+			b.WriteString(html.EscapeString(text[last:i]))
+			b.WriteString("<em>")
+			b.WriteString(html.EscapeString(text[i : i+len(q)]))
+			b.WriteString("</em>")
+			i += len(q)
+			last = i
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(text[i:])
+		i += size
+	}
+	b.WriteString(html.EscapeString(text[last:]))
+	return b.String()
+}
@@ -0,0 +1,90 @@
+package system
+
+import (
+	"context"
+	"testing"
+
+	"github.com/casbin/casbin/v2"
+	"github.com/stretchr/testify/suite"
+
+	jobsmodel "gin-artweb/internal/model/jobs"
+	jobsrepo "gin-artweb/internal/repository/jobs"
+	"gin-artweb/internal/shared/auth"
+	"gin-artweb/internal/shared/test"
+)
+
+type SearchServiceTestSuite struct {
+	suite.Suite
+	svc      *SearchService
+	enforcer *casbin.Enforcer
+}
+
+func (suite *SearchServiceTestSuite) SetupTest() {
+	db := test.NewTestGormDBWithConfig(nil)
+	db.AutoMigrate(&jobsmodel.ScriptModel{})
+	scriptRepo := jobsrepo.NewScriptRepo(test.NewTestZapLogger(), db, test.NewTestDBTimeouts())
+	for _, m := range []jobsmodel.ScriptModel{
+		{Name: "settle.sh", Descr: "日终清算<v2>", Project: "oes", Label: "daily"},
+		{Name: "backup.sh", Descr: "日间备份", Project: "oes", Label: "daily"},
+		{Name: "quote.sh", Descr: "行情采集", Project: "mds", Label: "SETTLE"},
+	} {
+		suite.Require().NoError(scriptRepo.CreateModel(context.Background(), &m))
+	}
+
+	enforcer, err := auth.NewCasbinEnforcer()
+	suite.Require().NoError(err)
+	suite.Require().NoError(auth.AddPolicies(context.Background(), enforcer, [][]string{
+		{auth.RoleToSubject(1), "/api/v1/jobs/script", "GET"},
+	}))
+	suite.enforcer = enforcer
+
+	suite.svc = NewSearchService(test.NewTestZapLogger(), enforcer)
+	suite.svc.Register(NewDBSearchSource("script", "脚本", "GET /api/v1/jobs/script",
+		[]string{"name", "descr", "label"}, scriptRepo.ListModel,
+		func(m jobsmodel.ScriptModel) SearchDocument {
+			return SearchDocument{ID: m.ID, Title: m.Name, Description: m.Descr}
+		},
+	))
+}
+
+func (suite *SearchServiceTestSuite) TestSearch() {
+	groups, rErr := suite.svc.Search(userContext(1, 1), "日终", 0)
+	suite.Require().Nil(rErr)
+	suite.Require().Len(groups, 1)
+	suite.Equal("script", groups[0].Kind)
+	suite.Equal(int64(1), groups[0].Total)
+	suite.Require().Len(groups[0].Hits, 1)
+	hit := groups[0].Hits[0]
+	suite.Equal("settle.sh", hit.Title)
+	suite.Equal("<em>日终</em>清算&lt;v2&gt;", hit.DescriptionHighlight)
+
+	groups, rErr = suite.svc.Search(userContext(1, 1), "settle", 1)
+	suite.Require().Nil(rErr)
+	suite.Require().Len(groups, 1)
+	suite.Equal(int64(2), groups[0].Total, "名称和标签都应该参与匹配")
+	suite.Len(groups[0].Hits, 1, "每组最多返回limit条")
+	suite.Equal("<em>settle</em>.sh", groups[0].Hits[0].TitleHighlight)
+
+	groups, rErr = suite.svc.Search(userContext(1, 1), "不存在", 0)
+	suite.Require().Nil(rErr)
+	suite.Empty(groups, "没有匹配结果的分组不返回")
+}
+
+func (suite *SearchServiceTestSuite) TestSearchRBAC() {
+	groups, rErr := suite.svc.Search(userContext(2, 2), "日终", 0)
+	suite.Require().Nil(rErr)
+	suite.Empty(groups, "无权查看列表的资源不应该返回")
+
+	_, rErr = suite.svc.Search(context.Background(), "日终", 0)
+	suite.NotNil(rErr, "未登录时应该返回错误")
+}
+
+func (suite *SearchServiceTestSuite) TestSearchEscapesWildcard() {
+	groups, rErr := suite.svc.Search(userContext(1, 1), "%", 0)
+	suite.Require().Nil(rErr)
+	suite.Empty(groups, "关键字中的通配符应该按字面匹配")
+}
+
+func TestSearchServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(SearchServiceTestSuite))
+}
@@ -0,0 +1,122 @@
+package system
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/zap"
+
+	sysmodel "gin-artweb/internal/model/system"
+	sysrepo "gin-artweb/internal/repository/system"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+)
+
+// maxPendingSlowQueries 两次写入之间最多缓存的语句指纹数, 超出后丢弃新的指纹
+const maxPendingSlowQueries = 1000
+
+// SlowQueryService 汇总慢查询并定期写入数据库
+//
+// Record由GORM插件在SQL执行后同步调用, 只在内存中累加, 由Flush批量写入
+type SlowQueryService struct {
+	log           *zap.Logger
+	slowQueryRepo *sysrepo.SlowQueryRepo
+
+	mu      sync.Mutex
+	pending map[string]*sysmodel.SlowQueryModel
+}
+
+func NewSlowQueryService(
+	log *zap.Logger,
+	slowQueryRepo *sysrepo.SlowQueryRepo,
+) *SlowQueryService {
+	return &SlowQueryService{
+		log:           log,
+		slowQueryRepo: slowQueryRepo,
+		pending:       make(map[string]*sysmodel.SlowQueryModel),
+	}
+}
+
+// Record 实现database.SlowQueryRecorder
+func (s *SlowQueryService) Record(q database.SlowQuery) {
+	// 写入慢查询统计本身的语句不再记录, 避免循环
+	if q.Table == (&sysmodel.SlowQueryModel{}).TableName() {
+		return
+	}
+
+	s.log.Warn(
+		"检测到慢查询",
+		zap.String("fingerprint", q.Fingerprint),
+		zap.String("sql", q.SQL),
+		zap.String("table", q.Table),
+		zap.Int64("rows", q.Rows),
+		zap.Duration("duration", q.Duration),
+		zap.String(ctxutil.TraceIDKey, q.TraceID),
+	)
+
+	ms := q.Duration.Milliseconds()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	m, ok := s.pending[q.Fingerprint]
+	if !ok {
+		if len(s.pending) >= maxPendingSlowQueries {
+			return
+		}
+		m = &sysmodel.SlowQueryModel{
+			Fingerprint: q.Fingerprint,
+			Statement:   q.SQL,
+			Table:       q.Table,
+			FirstSeenAt: q.At,
+		}
+		s.pending[q.Fingerprint] = m
+	}
+	m.Count++
+	m.TotalMs += ms
+	m.MaxMs = max(m.MaxMs, ms)
+	m.LastTraceID = q.TraceID
+	m.LastSeenAt = q.At
+}
+
+// Flush 将内存中汇总的慢查询写入数据库, 写入失败时丢弃本批数据
+func (s *SlowQueryService) Flush(ctx context.Context) {
+	s.mu.Lock()
+	pending := s.pending
+	s.pending = make(map[string]*sysmodel.SlowQueryModel)
+	s.mu.Unlock()
+
+	if len(pending) == 0 {
+		return
+	}
+	ms := make([]sysmodel.SlowQueryModel, 0, len(pending))
+	for _, m := range pending {
+		ms = append(ms, *m)
+	}
+	if err := s.slowQueryRepo.MergeModels(ctx, ms); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"写入慢查询统计失败",
+			zap.Error(err),
+			zap.Int("count", len(ms)),
+		)
+	}
+}
+
+func (s *SlowQueryService) ListSlowQuery(
+	ctx context.Context,
+	qp database.QueryParams,
+) (int64, *[]sysmodel.SlowQueryModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return 0, nil, errors.FromError(ctx.Err())
+	}
+
+	count, ms, err := s.slowQueryRepo.ListModel(ctx, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询慢查询统计列表失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		return 0, nil, errors.NewGormError(err, nil)
+	}
+	return count, ms, nil
+}
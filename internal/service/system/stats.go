@@ -0,0 +1,216 @@
+package system
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/patrickmn/go-cache"
+	"go.uber.org/zap"
+
+	jobsmodel "gin-artweb/internal/model/jobs"
+	sysmodel "gin-artweb/internal/model/system"
+	sysrepo "gin-artweb/internal/repository/system"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/errors"
+)
+
+const (
+	defaultStatsCacheTTL    = time.Minute
+	defaultStatsActiveDays  = 7
+	defaultStatsJobDays     = 7
+	defaultStatsAlertsLimit = 10
+)
+
+// StatsService 首页统计服务
+//
+// 统计结果按参数缓存, 缓存期间内的请求不查询数据库
+type StatsService struct {
+	log        *zap.Logger
+	statsRepo  *sysrepo.StatsRepo
+	cache      *cache.Cache
+	activeDays int
+}
+
+func NewStatsService(
+	log *zap.Logger,
+	statsRepo *sysrepo.StatsRepo,
+	conf *config.StatsConfig,
+) *StatsService {
+	ttl, activeDays := defaultStatsCacheTTL, defaultStatsActiveDays
+	if conf != nil {
+		if conf.CacheSeconds > 0 {
+			ttl = time.Duration(conf.CacheSeconds) * time.Second
+		}
+		if conf.ActiveDays > 0 {
+			activeDays = conf.ActiveDays
+		}
+	}
+	return &StatsService{
+		log:        log,
+		statsRepo:  statsRepo,
+		cache:      cache.New(ttl, 2*ttl),
+		activeDays: activeDays,
+	}
+}
+
+// cached 返回key对应的缓存结果, 没有缓存时调用load并缓存成功的结果
+func cached[T any](s *StatsService, key string, load func() (T, *errors.Error)) (T, *errors.Error) {
+	if v, ok := s.cache.Get(key); ok {
+		return v.(T), nil
+	}
+	v, rErr := load()
+	if rErr != nil {
+		return v, rErr
+	}
+	s.cache.SetDefault(key, v)
+	return v, nil
+}
+
+// JobStats 最近days天(含今天)每天的脚本执行成功和失败数, 没有执行记录的日期返回0
+func (s *StatsService) JobStats(ctx context.Context, days int) ([]sysmodel.StatsJobDayOut, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+	if days <= 0 {
+		days = defaultStatsJobDays
+	}
+
+	return cached(s, fmt.Sprintf("jobs:%d", days), func() ([]sysmodel.StatsJobDayOut, *errors.Error) {
+		now := time.Now()
+		start := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()).AddDate(0, 0, 1-days)
+		counts, err := s.statsRepo.CountJobsByDay(ctx, start)
+		if err != nil {
+			ctxutil.Logger(ctx, s.log).Error(
+				"统计脚本执行记录失败",
+				zap.Error(err),
+			)
+			return nil, errors.NewGormError(err, nil)
+		}
+
+		outs := make([]sysmodel.StatsJobDayOut, days)
+		index := make(map[string]int, days)
+		for i := range outs {
+			date := start.AddDate(0, 0, i).Format(time.DateOnly)
+			outs[i].Date = date
+			index[date] = i
+		}
+		for _, c := range counts {
+			// 不同数据库返回的日期格式不同, 只取日期部分
+			i, ok := index[c.Day[:min(len(c.Day), len(time.DateOnly))]]
+			if !ok {
+				continue
+			}
+			switch c.Status {
+			case jobsmodel.RecordStatusSuccess:
+				outs[i].Success += c.Count
+			case jobsmodel.RecordStatusPending, jobsmodel.RecordStatusRunning:
+				outs[i].Running += c.Count
+			default:
+				outs[i].Failure += c.Count
+			}
+		}
+		return outs, nil
+	})
+}
+
+// ColonyStats 各项目启用和停用的集群数
+func (s *StatsService) ColonyStats(ctx context.Context) ([]sysmodel.StatsColonyOut, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	return cached(s, "colonies", func() ([]sysmodel.StatsColonyOut, *errors.Error) {
+		counts, err := s.statsRepo.CountColonies(ctx)
+		if err != nil {
+			ctxutil.Logger(ctx, s.log).Error(
+				"统计集群启用情况失败",
+				zap.Error(err),
+			)
+			return nil, errors.NewGormError(err, nil)
+		}
+
+		outs := []sysmodel.StatsColonyOut{{Project: "oes"}, {Project: "mds"}}
+		for _, c := range counts {
+			for i := range outs {
+				if outs[i].Project != c.Project {
+					continue
+				}
+				if c.IsEnable {
+					outs[i].Enabled += c.Count
+				} else {
+					outs[i].Disabled += c.Count
+				}
+			}
+		}
+		return outs, nil
+	})
+}
+
+// UserStats 用户总数、已激活用户数和最近活跃的用户数
+func (s *StatsService) UserStats(ctx context.Context) (*sysmodel.StatsUserOut, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	return cached(s, "users", func() (*sysmodel.StatsUserOut, *errors.Error) {
+		since := time.Now().AddDate(0, 0, -s.activeDays)
+		m, err := s.statsRepo.CountUsers(ctx, since)
+		if err != nil {
+			ctxutil.Logger(ctx, s.log).Error(
+				"统计用户数失败",
+				zap.Error(err),
+			)
+			return nil, errors.NewGormError(err, nil)
+		}
+		return &sysmodel.StatsUserOut{
+			Total:  m.Total,
+			Active: m.Active,
+			Login:  m.Login,
+			Days:   s.activeDays,
+		}, nil
+	})
+}
+
+// RecentAlerts 最近发出的告警
+func (s *StatsService) RecentAlerts(ctx context.Context, limit int) ([]sysmodel.StatsAlertOut, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+	if limit <= 0 {
+		limit = defaultStatsAlertsLimit
+	}
+
+	return cached(s, fmt.Sprintf("alerts:%d", limit), func() ([]sysmodel.StatsAlertOut, *errors.Error) {
+		ms, err := s.statsRepo.ListAlerts(ctx, limit)
+		if err != nil {
+			ctxutil.Logger(ctx, s.log).Error(
+				"查询最近告警失败",
+				zap.Error(err),
+			)
+			return nil, errors.NewGormError(err, nil)
+		}
+
+		outs := make([]sysmodel.StatsAlertOut, 0, len(ms))
+		for _, m := range ms {
+			var payload struct {
+				Kind string `json:"kind"`
+			}
+			// 告警内容由发出方写入, 解析失败时只返回原始内容
+			_ = json.Unmarshal([]byte(m.Payload), &payload)
+			data := json.RawMessage(m.Payload)
+			if !json.Valid(data) {
+				data = json.RawMessage("null")
+			}
+			outs = append(outs, sysmodel.StatsAlertOut{
+				EventID:    m.EventID,
+				Kind:       payload.Kind,
+				OccurredAt: m.OccurredAt.Format(time.DateTime),
+				Data:       data,
+			})
+		}
+		return outs, nil
+	})
+}
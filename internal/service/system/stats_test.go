@@ -0,0 +1,84 @@
+package system
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	jobsmodel "gin-artweb/internal/model/jobs"
+	sysrepo "gin-artweb/internal/repository/system"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/events"
+	"gin-artweb/internal/shared/test"
+)
+
+type StatsServiceTestSuite struct {
+	suite.Suite
+	db  *gorm.DB
+	svc *StatsService
+}
+
+func (suite *StatsServiceTestSuite) SetupTest() {
+	suite.db = test.NewTestGormDBWithConfig(nil)
+	suite.db.AutoMigrate(&jobsmodel.ScriptRecordModel{}, &events.OutboxModel{})
+	repo := sysrepo.NewStatsRepo(test.NewTestZapLogger(), suite.db, test.NewTestDBTimeouts())
+	suite.svc = NewStatsService(test.NewTestZapLogger(), repo, &config.StatsConfig{CacheSeconds: 60})
+}
+
+func (suite *StatsServiceTestSuite) createRecord(status int) {
+	m := jobsmodel.ScriptRecordModel{ScriptID: 1, Status: status}
+	suite.Require().NoError(suite.db.Omit("Script").Create(&m).Error)
+}
+
+func (suite *StatsServiceTestSuite) TestJobStats() {
+	ctx := context.Background()
+	suite.createRecord(jobsmodel.RecordStatusSuccess)
+	suite.createRecord(jobsmodel.RecordStatusTimeout)
+	suite.createRecord(jobsmodel.RecordStatusCrashed)
+	suite.createRecord(jobsmodel.RecordStatusRunning)
+
+	outs, rErr := suite.svc.JobStats(ctx, 3)
+	suite.Require().Nil(rErr)
+	suite.Require().Len(outs, 3, "没有执行记录的日期也返回")
+	suite.Equal(time.Now().Format(time.DateOnly), outs[2].Date)
+	suite.Equal(time.Now().AddDate(0, 0, -2).Format(time.DateOnly), outs[0].Date)
+
+	var success, failure, running int64
+	for _, out := range outs {
+		success += out.Success
+		failure += out.Failure
+		running += out.Running
+	}
+	suite.Equal([]int64{1, 2, 1}, []int64{success, failure, running})
+
+	// 缓存期间内不重新统计
+	suite.createRecord(jobsmodel.RecordStatusSuccess)
+	cached, rErr := suite.svc.JobStats(ctx, 3)
+	suite.Require().Nil(rErr)
+	suite.Equal(outs, cached)
+}
+
+func (suite *StatsServiceTestSuite) TestRecentAlerts() {
+	ctx := context.Background()
+	ms := []events.OutboxModel{
+		{EventID: "e1", EventType: events.AlertFired, OccurredAt: time.Now(), Payload: `{"kind":"colony_drift","id":1}`},
+		{EventID: "e2", EventType: events.AlertFired, OccurredAt: time.Now().Add(-time.Minute), Payload: `broken`},
+	}
+	suite.Require().NoError(suite.db.Create(&ms).Error)
+
+	outs, rErr := suite.svc.RecentAlerts(ctx, 0)
+	suite.Require().Nil(rErr)
+	suite.Require().Len(outs, 2)
+	suite.Equal("colony_drift", outs[0].Kind)
+	suite.JSONEq(`{"kind":"colony_drift","id":1}`, string(outs[0].Data))
+	suite.Equal("", outs[1].Kind)
+	suite.Equal(json.RawMessage("null"), outs[1].Data, "无法解析的告警内容返回null")
+}
+
+func TestStatsServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(StatsServiceTestSuite))
+}
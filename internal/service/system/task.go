@@ -0,0 +1,354 @@
+package system
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	sysmodel "gin-artweb/internal/model/system"
+	sysrepo "gin-artweb/internal/repository/system"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+)
+
+// taskUpdateTimeout 更新任务状态和进度的超时时间, 不受任务上下文取消的影响
+const taskUpdateTimeout = 5 * time.Second
+
+// TaskFunc 后台任务的执行函数
+//
+// ctx在任务被取消或服务关闭时取消, 执行函数应在检查点检查ctx并尽快返回;
+// 返回值保存为任务结果(JSON), 返回错误时任务失败
+type TaskFunc func(ctx context.Context, p *TaskProgress) (any, error)
+
+// TaskProgress 上报后台任务的进度
+type TaskProgress struct {
+	svc *TaskService
+	ctx context.Context
+	id  uint32
+
+	mu       sync.Mutex
+	progress int
+	message  string
+}
+
+// TaskID 任务ID
+func (p *TaskProgress) TaskID() uint32 {
+	return p.id
+}
+
+// Report 更新任务进度, percent超出0~99时截断, 任务成功后进度才为100
+func (p *TaskProgress) Report(percent int, message string) {
+	percent = min(max(percent, 0), 99)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if percent == p.progress && message == p.message {
+		return
+	}
+	p.progress, p.message = percent, message
+	p.svc.update(p.ctx, p.id, map[string]any{"progress": percent, "message": message})
+}
+
+// runningTask 在本进程中执行的任务
+type runningTask struct {
+	cancel   context.CancelFunc
+	canceled bool // 是否由用户取消
+}
+
+// TaskService 后台任务服务
+//
+// 耗时操作提交为后台任务后立即返回任务ID, 调用方通过任务接口查询进度和结果;
+// 任务在提交它的进程内执行, 服务重启后未结束的任务标记为失败
+type TaskService struct {
+	log      *zap.Logger
+	taskRepo *sysrepo.TaskRepo
+
+	ctx  context.Context // 服务关闭时取消, 用于取消所有任务
+	stop context.CancelFunc
+	wg   sync.WaitGroup
+
+	mu      sync.Mutex
+	running map[uint32]*runningTask
+}
+
+func NewTaskService(
+	log *zap.Logger,
+	taskRepo *sysrepo.TaskRepo,
+) *TaskService {
+	ctx, stop := context.WithCancel(context.Background())
+	return &TaskService{
+		log:      log,
+		taskRepo: taskRepo,
+		ctx:      ctx,
+		stop:     stop,
+		running:  make(map[uint32]*runningTask),
+	}
+}
+
+// Submit 创建后台任务并在新协程中执行fn, 返回创建的任务
+//
+// m需要设置Kind、Name和提交用户, 任务上下文保留ctx中的值(如链路ID), 但不随请求结束而取消
+func (s *TaskService) Submit(
+	ctx context.Context,
+	m *sysmodel.TaskModel,
+	fn TaskFunc,
+) (*sysmodel.TaskModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+	if s.ctx.Err() != nil {
+		return nil, errors.ErrTaskServiceStopped
+	}
+
+	m.Status = sysmodel.TaskStatusPending
+	m.Progress = 0
+	if err := s.taskRepo.CreateModel(ctx, m); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"创建后台任务失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, m),
+		)
+		return nil, errors.NewGormError(err, nil)
+	}
+
+	taskCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	s.mu.Lock()
+	s.running[m.ID] = &runningTask{cancel: cancel}
+	s.mu.Unlock()
+
+	s.wg.Add(1)
+	go s.run(taskCtx, cancel, *m, fn)
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"提交后台任务成功",
+		zap.Object(database.ModelKey, m),
+	)
+	return m, nil
+}
+
+func (s *TaskService) run(ctx context.Context, cancel context.CancelFunc, m sysmodel.TaskModel, fn TaskFunc) {
+	defer s.wg.Done()
+	// 服务关闭时取消任务
+	stop := context.AfterFunc(s.ctx, cancel)
+	defer stop()
+	defer cancel()
+
+	started := time.Now()
+	s.update(ctx, m.ID, map[string]any{"status": sysmodel.TaskStatusRunning, "started_at": started})
+
+	result, err := s.call(ctx, &TaskProgress{svc: s, ctx: ctx, id: m.ID}, fn)
+
+	s.mu.Lock()
+	canceled := s.running[m.ID].canceled
+	delete(s.running, m.ID)
+	s.mu.Unlock()
+
+	data := map[string]any{"finished_at": time.Now()}
+	switch {
+	case err == nil:
+		data["status"] = sysmodel.TaskStatusSucceeded
+		data["progress"] = 100
+		if result != nil {
+			b, mErr := json.Marshal(result)
+			if mErr != nil {
+				b, _ = json.Marshal(fmt.Sprint(result))
+			}
+			data["result"] = string(b)
+		}
+	case ctx.Err() != nil:
+		// 用户取消或服务关闭
+		data["status"] = sysmodel.TaskStatusCanceled
+		reason := errors.ErrCanceled
+		if !canceled {
+			reason = errors.ErrTaskServiceStopped
+		}
+		data["error"] = taskError(reason)
+	default:
+		data["status"] = sysmodel.TaskStatusFailed
+		data["error"] = taskError(errors.FromError(err))
+	}
+	s.update(ctx, m.ID, data)
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"后台任务结束",
+		zap.Uint32("task_id", m.ID),
+		zap.String("kind", m.Kind),
+		zap.Any("status", data["status"]),
+		zap.NamedError("task_error", err),
+		zap.Duration("duration", time.Since(started)),
+	)
+}
+
+// call 执行任务函数, 将panic转为错误, 避免单个任务导致服务退出
+func (s *TaskService) call(ctx context.Context, p *TaskProgress, fn TaskFunc) (result any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			ctxutil.Logger(ctx, s.log).Error(
+				"后台任务panic",
+				zap.Uint32("task_id", p.id),
+				zap.Any("panic", r),
+				zap.Stack("stack"),
+			)
+			err = fmt.Errorf("任务异常退出: %v", r)
+		}
+	}()
+	result, err = fn(ctx, p)
+	// 返回值为nil的*errors.Error时不能按非nil的error判断
+	if rErr, ok := err.(*errors.Error); ok && rErr == nil {
+		err = nil
+	}
+	return result, err
+}
+
+// update 更新任务记录, 任务上下文已取消时仍然写入
+func (s *TaskService) update(ctx context.Context, id uint32, data map[string]any) {
+	dbCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), taskUpdateTimeout)
+	defer cancel()
+	if err := s.taskRepo.UpdateModel(dbCtx, data, "id = ?", id); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"更新后台任务失败",
+			zap.Error(err),
+			zap.Uint32("task_id", id),
+			zap.Any(database.UpdateDataKey, data),
+		)
+	}
+}
+
+// taskError 将错误转为保存在任务记录中的JSON
+func taskError(rErr *errors.Error) string {
+	b, err := json.Marshal(rErr)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+// CancelTask 取消后台任务
+//
+// 本进程中执行的任务通过取消任务上下文通知执行函数, 执行函数返回后任务状态变为已取消;
+// 不在本进程中执行的未结束任务直接标记为已取消
+func (s *TaskService) CancelTask(ctx context.Context, taskID uint32) *errors.Error {
+	if ctx.Err() != nil {
+		return errors.FromError(ctx.Err())
+	}
+
+	m, rErr := s.FindTaskByID(ctx, taskID)
+	if rErr != nil {
+		return rErr
+	}
+	if m.Finished() {
+		return errors.ErrTaskFinished.WithFields(map[string]any{"id": taskID, "status": m.Status})
+	}
+
+	s.mu.Lock()
+	rt, ok := s.running[taskID]
+	if ok {
+		rt.canceled = true
+		rt.cancel()
+	}
+	s.mu.Unlock()
+
+	if !ok {
+		data := map[string]any{
+			"status":      sysmodel.TaskStatusCanceled,
+			"error":       taskError(errors.ErrCanceled),
+			"finished_at": time.Now(),
+		}
+		if err := s.taskRepo.UpdateModel(ctx, data, "id = ?", taskID); err != nil {
+			ctxutil.Logger(ctx, s.log).Error(
+				"取消后台任务失败",
+				zap.Error(err),
+				zap.Uint32("task_id", taskID),
+			)
+			return errors.NewGormError(err, map[string]any{"id": taskID})
+		}
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"取消后台任务成功",
+		zap.Uint32("task_id", taskID),
+		zap.Bool("running", ok),
+	)
+	return nil
+}
+
+func (s *TaskService) FindTaskByID(
+	ctx context.Context,
+	taskID uint32,
+) (*sysmodel.TaskModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	m, err := s.taskRepo.GetModel(ctx, taskID)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询后台任务失败",
+			zap.Error(err),
+			zap.Uint32("task_id", taskID),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"id": taskID})
+	}
+	return m, nil
+}
+
+func (s *TaskService) ListTask(
+	ctx context.Context,
+	qp database.QueryParams,
+) (int64, *[]sysmodel.TaskModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return 0, nil, errors.FromError(ctx.Err())
+	}
+
+	count, ms, err := s.taskRepo.ListModel(ctx, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询后台任务列表失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		return 0, nil, errors.NewGormError(err, nil)
+	}
+	return count, ms, nil
+}
+
+// FailInterrupted 将上次服务关闭时未结束的任务标记为失败, 在服务启动时调用
+func (s *TaskService) FailInterrupted(ctx context.Context) *errors.Error {
+	if ctx.Err() != nil {
+		return errors.FromError(ctx.Err())
+	}
+
+	data := map[string]any{
+		"status":      sysmodel.TaskStatusFailed,
+		"error":       taskError(errors.ErrTaskInterrupted),
+		"finished_at": time.Now(),
+	}
+	statuses := []string{sysmodel.TaskStatusPending, sysmodel.TaskStatusRunning}
+	if err := s.taskRepo.UpdateModel(ctx, data, "status IN ?", statuses); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"标记中断的后台任务失败",
+			zap.Error(err),
+		)
+		return errors.NewGormError(err, nil)
+	}
+	return nil
+}
+
+// Stop 取消所有执行中的任务并等待执行函数返回
+func (s *TaskService) Stop(ctx context.Context) {
+	s.stop()
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		ctxutil.Logger(ctx, s.log).Warn("等待后台任务退出超时")
+	}
+}
@@ -0,0 +1,186 @@
+package system
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	emperror "emperror.dev/errors"
+	"github.com/stretchr/testify/suite"
+
+	sysmodel "gin-artweb/internal/model/system"
+	sysrepo "gin-artweb/internal/repository/system"
+	"gin-artweb/internal/shared/errors"
+	"gin-artweb/internal/shared/test"
+)
+
+type TaskServiceTestSuite struct {
+	suite.Suite
+	taskRepo *sysrepo.TaskRepo
+	svc      *TaskService
+}
+
+func (suite *TaskServiceTestSuite) SetupTest() {
+	db := test.NewTestGormDBWithConfig(nil)
+	db.AutoMigrate(&sysmodel.TaskModel{})
+	// 内存数据库每个连接是独立的库, 任务协程和测试需要共用同一个连接
+	sqlDB, err := db.DB()
+	suite.Require().NoError(err)
+	sqlDB.SetMaxOpenConns(1)
+	suite.taskRepo = sysrepo.NewTaskRepo(test.NewTestZapLogger(), db, test.NewTestDBTimeouts())
+	suite.svc = NewTaskService(test.NewTestZapLogger(), suite.taskRepo)
+}
+
+func (suite *TaskServiceTestSuite) TearDownTest() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	suite.svc.Stop(ctx)
+}
+
+func (suite *TaskServiceTestSuite) submit(fn TaskFunc) *sysmodel.TaskModel {
+	m, rErr := suite.svc.Submit(context.Background(), &sysmodel.TaskModel{
+		Kind:     sysmodel.TaskKindReport,
+		Name:     "测试任务",
+		Username: "admin",
+	}, fn)
+	suite.Require().Nil(rErr)
+	suite.Equal(sysmodel.TaskStatusPending, m.Status)
+	return m
+}
+
+// waitFinished 等待任务结束并返回任务记录
+func (suite *TaskServiceTestSuite) waitFinished(id uint32) *sysmodel.TaskModel {
+	var m *sysmodel.TaskModel
+	suite.Require().Eventually(func() bool {
+		var rErr *errors.Error
+		m, rErr = suite.svc.FindTaskByID(context.Background(), id)
+		return rErr == nil && m.Finished()
+	}, 5*time.Second, 10*time.Millisecond, "任务应该结束")
+	return m
+}
+
+func (suite *TaskServiceTestSuite) TestSucceeded() {
+	reported := make(chan struct{})
+	proceed := make(chan struct{})
+	m := suite.submit(func(ctx context.Context, p *TaskProgress) (any, error) {
+		p.Report(150, "导出报表文件")
+		close(reported)
+		<-proceed
+		return map[string]string{"name": "daily.csv"}, nil
+	})
+
+	<-reported
+	running, rErr := suite.svc.FindTaskByID(context.Background(), m.ID)
+	suite.Require().Nil(rErr)
+	suite.Equal(sysmodel.TaskStatusRunning, running.Status)
+	suite.Equal(99, running.Progress, "任务成功前进度不应该超过99")
+	suite.Equal("导出报表文件", running.Message)
+	suite.NotNil(running.StartedAt)
+	close(proceed)
+
+	done := suite.waitFinished(m.ID)
+	suite.Equal(sysmodel.TaskStatusSucceeded, done.Status)
+	suite.Equal(100, done.Progress)
+	suite.JSONEq(`{"name":"daily.csv"}`, done.Result)
+	suite.Empty(done.Error)
+	suite.NotNil(done.FinishedAt)
+
+	rErr = suite.svc.CancelTask(context.Background(), m.ID)
+	suite.Require().NotNil(rErr)
+	suite.Equal(errors.ErrTaskFinished.Reason, rErr.Reason, "已结束的任务不能取消")
+}
+
+func (suite *TaskServiceTestSuite) TestFailed() {
+	m := suite.submit(func(ctx context.Context, p *TaskProgress) (any, error) {
+		return nil, emperror.New("磁盘已满")
+	})
+	done := suite.waitFinished(m.ID)
+	suite.Equal(sysmodel.TaskStatusFailed, done.Status)
+	suite.Empty(done.Result)
+	suite.True(json.Valid([]byte(done.Error)), "错误信息应该保存为JSON")
+
+	m = suite.submit(func(ctx context.Context, p *TaskProgress) (any, error) {
+		panic("boom")
+	})
+	done = suite.waitFinished(m.ID)
+	suite.Equal(sysmodel.TaskStatusFailed, done.Status, "执行函数panic时任务应该失败")
+}
+
+func (suite *TaskServiceTestSuite) TestNilError() {
+	m := suite.submit(func(ctx context.Context, p *TaskProgress) (any, error) {
+		var rErr *errors.Error
+		return nil, rErr
+	})
+	done := suite.waitFinished(m.ID)
+	suite.Equal(sysmodel.TaskStatusSucceeded, done.Status, "返回nil的*errors.Error时任务应该成功")
+}
+
+func (suite *TaskServiceTestSuite) TestCancel() {
+	started := make(chan struct{})
+	m := suite.submit(func(ctx context.Context, p *TaskProgress) (any, error) {
+		close(started)
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+
+	<-started
+	suite.Nil(suite.svc.CancelTask(context.Background(), m.ID))
+	done := suite.waitFinished(m.ID)
+	suite.Equal(sysmodel.TaskStatusCanceled, done.Status)
+	suite.Contains(done.Error, errors.ErrCanceled.Reason)
+}
+
+func (suite *TaskServiceTestSuite) TestCancelNotRunning() {
+	// 其他进程提交的任务或服务重启前的任务
+	m := &sysmodel.TaskModel{Kind: sysmodel.TaskKindReport, Name: "测试任务", Status: sysmodel.TaskStatusPending}
+	suite.Require().NoError(suite.taskRepo.CreateModel(context.Background(), m))
+
+	suite.Nil(suite.svc.CancelTask(context.Background(), m.ID))
+	done, rErr := suite.svc.FindTaskByID(context.Background(), m.ID)
+	suite.Require().Nil(rErr)
+	suite.Equal(sysmodel.TaskStatusCanceled, done.Status)
+	suite.NotNil(done.FinishedAt)
+}
+
+func (suite *TaskServiceTestSuite) TestStop() {
+	started := make(chan struct{})
+	m := suite.submit(func(ctx context.Context, p *TaskProgress) (any, error) {
+		close(started)
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+
+	<-started
+	suite.svc.Stop(context.Background())
+	done, rErr := suite.svc.FindTaskByID(context.Background(), m.ID)
+	suite.Require().Nil(rErr)
+	suite.Equal(sysmodel.TaskStatusCanceled, done.Status, "服务关闭时执行中的任务应该取消")
+	suite.Contains(done.Error, errors.ErrTaskServiceStopped.Reason)
+
+	_, rErr = suite.svc.Submit(context.Background(), &sysmodel.TaskModel{Kind: sysmodel.TaskKindReport}, nil)
+	suite.Require().NotNil(rErr)
+	suite.Equal(errors.ErrTaskServiceStopped.Reason, rErr.Reason, "服务关闭后不能提交任务")
+}
+
+func (suite *TaskServiceTestSuite) TestFailInterrupted() {
+	statuses := []string{sysmodel.TaskStatusPending, sysmodel.TaskStatusRunning, sysmodel.TaskStatusSucceeded}
+	ids := make([]uint32, 0, len(statuses))
+	for _, status := range statuses {
+		m := &sysmodel.TaskModel{Kind: sysmodel.TaskKindReport, Name: "测试任务", Status: status}
+		suite.Require().NoError(suite.taskRepo.CreateModel(context.Background(), m))
+		ids = append(ids, m.ID)
+	}
+
+	suite.Nil(suite.svc.FailInterrupted(context.Background()))
+	want := []string{sysmodel.TaskStatusFailed, sysmodel.TaskStatusFailed, sysmodel.TaskStatusSucceeded}
+	for i, id := range ids {
+		m, rErr := suite.svc.FindTaskByID(context.Background(), id)
+		suite.Require().Nil(rErr)
+		suite.Equal(want[i], m.Status)
+	}
+}
+
+func TestTaskServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(TaskServiceTestSuite))
+}
@@ -0,0 +1,629 @@
+package system
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	sysmodel "gin-artweb/internal/model/system"
+	sysrepo "gin-artweb/internal/repository/system"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+	"gin-artweb/internal/shared/events"
+)
+
+const (
+	defaultWebhookTimeout     = 10 * time.Second
+	defaultWebhookMaxAttempts = 6
+	defaultWebhookInterval    = 30 * time.Second
+
+	// 重试间隔从30秒开始按指数增长, 最长1小时
+	webhookRetryBase = 30 * time.Second
+	webhookRetryMax  = time.Hour
+
+	// 每次检查最多推送的记录数
+	webhookDueBatch = 100
+)
+
+// webhook推送请求头
+const (
+	WebhookEventHeader     = "X-Artweb-Event"
+	WebhookDeliveryHeader  = "X-Artweb-Delivery"
+	WebhookTimestampHeader = "X-Artweb-Timestamp"
+	WebhookSignatureHeader = "X-Artweb-Signature"
+)
+
+// SignWebhookPayload 计算推送内容的签名
+//
+// 签名为 sha256=hex(HMAC-SHA256(secret, timestamp + "." + body)),
+// 接收方使用相同的密钥和X-Artweb-Timestamp请求头重新计算后比较
+func SignWebhookPayload(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// WebhookService webhook订阅和推送服务
+//
+// 订阅事件总线, 事件发布时为匹配的订阅创建推送记录, 由后台协程推送,
+// 推送失败时按指数退避重试, 超过最大次数后标记为失败
+type WebhookService struct {
+	log          *zap.Logger
+	subRepo      *sysrepo.WebhookSubscriptionRepo
+	deliveryRepo *sysrepo.WebhookDeliveryRepo
+	client       *http.Client
+	maxAttempts  int
+	interval     time.Duration
+
+	// 推送协程同一时间只处理一批记录
+	mu   sync.Mutex
+	wake chan struct{}
+	stop chan struct{}
+	done chan struct{}
+}
+
+func NewWebhookService(
+	log *zap.Logger,
+	subRepo *sysrepo.WebhookSubscriptionRepo,
+	deliveryRepo *sysrepo.WebhookDeliveryRepo,
+	conf *config.WebhookConfig,
+) *WebhookService {
+	timeout, maxAttempts, interval := defaultWebhookTimeout, defaultWebhookMaxAttempts, defaultWebhookInterval
+	if conf != nil {
+		if conf.Timeout > 0 {
+			timeout = time.Duration(conf.Timeout) * time.Second
+		}
+		if conf.MaxAttempts > 0 {
+			maxAttempts = conf.MaxAttempts
+		}
+		if conf.Interval > 0 {
+			interval = time.Duration(conf.Interval) * time.Second
+		}
+	}
+	return &WebhookService{
+		log:          log,
+		subRepo:      subRepo,
+		deliveryRepo: deliveryRepo,
+		client:       &http.Client{Timeout: timeout},
+		maxAttempts:  maxAttempts,
+		interval:     interval,
+		wake:         make(chan struct{}, 1),
+	}
+}
+
+func (s *WebhookService) validate(m *sysmodel.WebhookSubscriptionModel) *errors.Error {
+	u, err := url.Parse(m.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.ErrValidationFailed.WithField("url", m.URL)
+	}
+	return nil
+}
+
+func (s *WebhookService) CreateWebhookSubscription(
+	ctx context.Context,
+	m sysmodel.WebhookSubscriptionModel,
+) (*sysmodel.WebhookSubscriptionModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始创建webhook订阅",
+		zap.Object(database.ModelKey, &m),
+	)
+
+	if rErr := s.validate(&m); rErr != nil {
+		return nil, rErr
+	}
+	if m.Secret == "" {
+		return nil, errors.ErrValidationFailed.WithField("secret", "创建webhook订阅时签名密钥不能为空")
+	}
+
+	if err := s.subRepo.CreateModel(ctx, &m); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"创建webhook订阅失败",
+			zap.Error(err),
+			zap.Object(database.ModelKey, &m),
+		)
+		return nil, errors.NewGormError(err, nil)
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"创建webhook订阅成功",
+		zap.Object(database.ModelKey, &m),
+	)
+	return &m, nil
+}
+
+func (s *WebhookService) UpdateWebhookSubscriptionByID(
+	ctx context.Context,
+	subID uint32,
+	m sysmodel.WebhookSubscriptionModel,
+) (*sysmodel.WebhookSubscriptionModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始更新webhook订阅",
+		zap.Uint32("webhook_id", subID),
+		zap.Object(database.ModelKey, &m),
+	)
+
+	if rErr := s.validate(&m); rErr != nil {
+		return nil, rErr
+	}
+
+	data := map[string]any{
+		"name":        m.Name,
+		"url":         m.URL,
+		"events":      m.Events,
+		"is_enabled":  m.IsEnabled,
+		"description": m.Description,
+		"username":    m.Username,
+	}
+	// 签名密钥为空时保留原密钥
+	if m.Secret != "" {
+		data["secret"] = m.Secret
+	}
+	if err := s.subRepo.UpdateModel(ctx, data, "id = ?", subID); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"更新webhook订阅失败",
+			zap.Error(err),
+			zap.Uint32("webhook_id", subID),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"id": subID})
+	}
+
+	nm, rErr := s.FindWebhookSubscriptionByID(ctx, subID)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"更新webhook订阅成功",
+		zap.Uint32("webhook_id", subID),
+	)
+	return nm, nil
+}
+
+// DeleteWebhookSubscriptionByID 删除webhook订阅及其推送记录
+func (s *WebhookService) DeleteWebhookSubscriptionByID(
+	ctx context.Context,
+	subID uint32,
+) *errors.Error {
+	if ctx.Err() != nil {
+		return errors.FromError(ctx.Err())
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"开始删除webhook订阅",
+		zap.Uint32("webhook_id", subID),
+	)
+
+	if _, rErr := s.FindWebhookSubscriptionByID(ctx, subID); rErr != nil {
+		return rErr
+	}
+	if err := s.deliveryRepo.DeleteModel(ctx, "subscription_id = ?", subID); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"删除webhook推送记录失败",
+			zap.Error(err),
+			zap.Uint32("webhook_id", subID),
+		)
+		return errors.NewGormError(err, map[string]any{"subscription_id": subID})
+	}
+	if err := s.subRepo.DeleteModel(ctx, subID); err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"删除webhook订阅失败",
+			zap.Error(err),
+			zap.Uint32("webhook_id", subID),
+		)
+		return errors.NewGormError(err, map[string]any{"id": subID})
+	}
+
+	ctxutil.Logger(ctx, s.log).Info(
+		"删除webhook订阅成功",
+		zap.Uint32("webhook_id", subID),
+	)
+	return nil
+}
+
+func (s *WebhookService) FindWebhookSubscriptionByID(
+	ctx context.Context,
+	subID uint32,
+) (*sysmodel.WebhookSubscriptionModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	m, err := s.subRepo.GetModel(ctx, subID)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询webhook订阅失败",
+			zap.Error(err),
+			zap.Uint32("webhook_id", subID),
+		)
+		return nil, errors.NewGormError(err, map[string]any{"id": subID})
+	}
+	return m, nil
+}
+
+func (s *WebhookService) ListWebhookSubscription(
+	ctx context.Context,
+	qp database.QueryParams,
+) (int64, *[]sysmodel.WebhookSubscriptionModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return 0, nil, errors.FromError(ctx.Err())
+	}
+
+	count, ms, err := s.subRepo.ListModel(ctx, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询webhook订阅列表失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		return 0, nil, errors.NewGormError(err, nil)
+	}
+	return count, ms, nil
+}
+
+func (s *WebhookService) ListWebhookDelivery(
+	ctx context.Context,
+	qp database.QueryParams,
+) (int64, *[]sysmodel.WebhookDeliveryModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return 0, nil, errors.FromError(ctx.Err())
+	}
+
+	count, ms, err := s.deliveryRepo.ListModel(ctx, qp)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询webhook推送记录列表失败",
+			zap.Error(err),
+			zap.Object(database.QueryParamsKey, &qp),
+		)
+		return 0, nil, errors.NewGormError(err, nil)
+	}
+	return count, ms, nil
+}
+
+// HandleEvent 为订阅了该事件的webhook创建推送记录并通知推送协程, 用于订阅事件总线
+//
+// 发件箱按至少一次的语义发布事件, 已为该事件创建过推送记录的订阅会跳过;
+// 创建推送记录失败时返回错误, 由发件箱重试该事件
+func (s *WebhookService) HandleEvent(ctx context.Context, event events.Event) error {
+	_, subs, err := s.subRepo.ListModel(ctx, database.QueryParams{
+		Query: map[string]any{"is_enabled = ?": true},
+	})
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询webhook订阅失败",
+			zap.Error(err),
+			zap.String("event_id", event.ID),
+			zap.String("event_type", event.Type),
+		)
+		return err
+	}
+	_, existing, err := s.deliveryRepo.ListModel(ctx, database.QueryParams{
+		Query: map[string]any{"event_id = ?": event.ID},
+	})
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询webhook推送记录失败",
+			zap.Error(err),
+			zap.String("event_id", event.ID),
+		)
+		return err
+	}
+	delivered := make(map[uint32]bool, len(*existing))
+	for _, d := range *existing {
+		delivered[d.SubscriptionID] = true
+	}
+
+	created := 0
+	var lastErr error
+	for _, sub := range *subs {
+		if !sub.Subscribes(event.Type) || delivered[sub.ID] {
+			continue
+		}
+		if _, err := s.createDelivery(ctx, sub.ID, event); err != nil {
+			ctxutil.Logger(ctx, s.log).Error(
+				"创建webhook推送记录失败",
+				zap.Error(err),
+				zap.Uint32("webhook_id", sub.ID),
+				zap.String("event_id", event.ID),
+				zap.String("event_type", event.Type),
+			)
+			lastErr = err
+			continue
+		}
+		created++
+	}
+	if created > 0 {
+		s.notify()
+	}
+	return lastErr
+}
+
+func (s *WebhookService) createDelivery(
+	ctx context.Context,
+	subID uint32,
+	event events.Event,
+) (*sysmodel.WebhookDeliveryModel, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	m := sysmodel.WebhookDeliveryModel{
+		SubscriptionID: subID,
+		EventID:        event.ID,
+		EventType:      event.Type,
+		Payload:        string(payload),
+		Status:         sysmodel.WebhookDeliveryPending,
+		NextRetryAt:    &now,
+	}
+	if err := s.deliveryRepo.CreateModel(ctx, &m); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// TestWebhookSubscription 向webhook推送一条测试事件并立即返回推送结果, 停用的订阅也可以测试
+func (s *WebhookService) TestWebhookSubscription(
+	ctx context.Context,
+	subID uint32,
+	username string,
+) (*sysmodel.WebhookDeliveryModel, *errors.Error) {
+	if ctx.Err() != nil {
+		return nil, errors.FromError(ctx.Err())
+	}
+
+	sub, rErr := s.FindWebhookSubscriptionByID(ctx, subID)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	event, err := events.NewEvent(sysmodel.WebhookTestEvent{WebhookID: sub.ID, Name: sub.Name, Username: username})
+	if err != nil {
+		return nil, errors.FromError(err)
+	}
+	d, err := s.createDelivery(ctx, sub.ID, event)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"创建webhook测试推送记录失败",
+			zap.Error(err),
+			zap.Uint32("webhook_id", subID),
+		)
+		return nil, errors.NewGormError(err, nil)
+	}
+
+	// 测试推送只推送一次, 失败时不重试
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.deliver(ctx, sub, d, true), nil
+}
+
+// DeliverDue 推送到期的待推送记录, 返回推送的记录数
+func (s *WebhookService) DeliverDue(ctx context.Context) (int, *errors.Error) {
+	if ctx.Err() != nil {
+		return 0, errors.FromError(ctx.Err())
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, ds, err := s.deliveryRepo.ListModel(ctx, database.QueryParams{
+		Query: map[string]any{
+			"status = ?":         sysmodel.WebhookDeliveryPending,
+			"next_retry_at <= ?": time.Now(),
+		},
+		OrderBy: []string{"id ASC"},
+		Size:    webhookDueBatch,
+	})
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"查询待推送的webhook记录失败",
+			zap.Error(err),
+		)
+		return 0, errors.NewGormError(err, nil)
+	}
+
+	subs := make(map[uint32]*sysmodel.WebhookSubscriptionModel)
+	for i := range *ds {
+		if ctx.Err() != nil {
+			return i, errors.FromError(ctx.Err())
+		}
+		d := &(*ds)[i]
+		sub, ok := subs[d.SubscriptionID]
+		if !ok {
+			m, err := s.subRepo.GetModel(ctx, d.SubscriptionID)
+			if err != nil {
+				// 订阅不存在时sub为nil, 推送记录标记为失败, 其他错误等待下次推送
+				if rErr := errors.NewGormError(err, nil); !rErr.Is(errors.ErrRecordNotFound) {
+					continue
+				}
+			}
+			sub = m
+			subs[d.SubscriptionID] = sub
+		}
+		s.deliver(ctx, sub, d, false)
+	}
+	return len(*ds), nil
+}
+
+// deliver 执行一次推送并更新推送记录, once为true时失败不再重试
+func (s *WebhookService) deliver(
+	ctx context.Context,
+	sub *sysmodel.WebhookSubscriptionModel,
+	d *sysmodel.WebhookDeliveryModel,
+	once bool,
+) *sysmodel.WebhookDeliveryModel {
+	now := time.Now()
+	d.Attempts++
+
+	var code int
+	var err error
+	switch {
+	case sub == nil:
+		err = fmt.Errorf("webhook订阅不存在")
+	case !sub.IsEnabled && d.EventType != sysmodel.WebhookEventTest:
+		err = fmt.Errorf("webhook订阅已停用")
+	default:
+		code, err = s.post(ctx, sub, d, now)
+	}
+
+	data := map[string]any{
+		"attempts":      d.Attempts,
+		"response_code": code,
+	}
+	d.ResponseCode = code
+	switch {
+	case err == nil:
+		d.Status = sysmodel.WebhookDeliverySuccess
+		d.ErrorMessage = ""
+		d.NextRetryAt = nil
+		d.DeliveredAt = &now
+	case once || sub == nil || !sub.IsEnabled || d.Attempts >= s.maxAttempts:
+		d.Status = sysmodel.WebhookDeliveryFailed
+		d.ErrorMessage = truncate(err.Error(), 512)
+		d.NextRetryAt = nil
+	default:
+		next := now.Add(webhookBackoff(d.Attempts))
+		d.ErrorMessage = truncate(err.Error(), 512)
+		d.NextRetryAt = &next
+	}
+	data["status"] = d.Status
+	data["error_message"] = d.ErrorMessage
+	data["next_retry_at"] = d.NextRetryAt
+	data["delivered_at"] = d.DeliveredAt
+
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Warn(
+			"webhook推送失败",
+			zap.Error(err),
+			zap.Uint32("webhook_delivery_id", d.ID),
+			zap.Uint32("webhook_id", d.SubscriptionID),
+			zap.String("event_type", d.EventType),
+			zap.Int("attempts", d.Attempts),
+			zap.Int("status", d.Status),
+		)
+	}
+	if uErr := s.deliveryRepo.UpdateModel(ctx, data, "id = ?", d.ID); uErr != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"更新webhook推送记录失败",
+			zap.Error(uErr),
+			zap.Uint32("webhook_delivery_id", d.ID),
+		)
+	}
+	return d
+}
+
+// post 发送推送请求, 2xx响应视为成功
+func (s *WebhookService) post(
+	ctx context.Context,
+	sub *sysmodel.WebhookSubscriptionModel,
+	d *sysmodel.WebhookDeliveryModel,
+	now time.Time,
+) (int, error) {
+	body := []byte(d.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := now.Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "artweb-webhook")
+	req.Header.Set(WebhookEventHeader, d.EventType)
+	req.Header.Set(WebhookDeliveryHeader, strconv.FormatUint(uint64(d.ID), 10))
+	req.Header.Set(WebhookTimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(WebhookSignatureHeader, SignWebhookPayload(sub.Secret, timestamp, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	snippet, _ := io.ReadAll(io.LimitReader(resp.Body, 256))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(snippet)))
+	}
+	return resp.StatusCode, nil
+}
+
+// Start 启动后台推送协程
+func (s *WebhookService) Start() {
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+	go func() {
+		defer close(s.done)
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-s.stop:
+				return
+			case <-ticker.C:
+			case <-s.wake:
+			}
+			if _, rErr := s.DeliverDue(context.Background()); rErr != nil {
+				s.log.Error("推送webhook失败", zap.Error(rErr))
+			}
+		}
+	}()
+}
+
+// Stop 停止后台推送协程, 等待正在推送的批次完成
+func (s *WebhookService) Stop(ctx context.Context) {
+	if s.stop == nil {
+		return
+	}
+	close(s.stop)
+	select {
+	case <-s.done:
+	case <-ctx.Done():
+		ctxutil.Logger(ctx, s.log).Warn("等待webhook推送协程退出超时")
+	}
+}
+
+// notify 通知推送协程有新的推送记录
+func (s *WebhookService) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// webhookBackoff 第attempts次推送失败后的重试间隔
+func webhookBackoff(attempts int) time.Duration {
+	d := webhookRetryBase
+	for i := 1; i < attempts && d < webhookRetryMax; i++ {
+		d *= 2
+	}
+	return min(d, webhookRetryMax)
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n]
+}
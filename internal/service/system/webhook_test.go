@@ -0,0 +1,251 @@
+package system
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	sysmodel "gin-artweb/internal/model/system"
+	sysrepo "gin-artweb/internal/repository/system"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/errors"
+	"gin-artweb/internal/shared/events"
+	"gin-artweb/internal/shared/test"
+)
+
+const testWebhookSecret = "0123456789abcdef"
+
+// webhookReceiver 记录收到的推送请求
+type webhookReceiver struct {
+	mu      sync.Mutex
+	status  int
+	headers []http.Header
+	bodies  [][]byte
+}
+
+func (r *webhookReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.headers = append(r.headers, req.Header.Clone())
+	r.bodies = append(r.bodies, body)
+	w.WriteHeader(r.status)
+}
+
+func (r *webhookReceiver) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.bodies)
+}
+
+type WebhookServiceTestSuite struct {
+	suite.Suite
+	deliveryRepo *sysrepo.WebhookDeliveryRepo
+	svc          *WebhookService
+	receiver     *webhookReceiver
+	server       *httptest.Server
+}
+
+func (suite *WebhookServiceTestSuite) SetupTest() {
+	db := test.NewTestGormDBWithConfig(nil)
+	db.AutoMigrate(&sysmodel.WebhookSubscriptionModel{}, &sysmodel.WebhookDeliveryModel{})
+	logger := test.NewTestZapLogger()
+	subRepo := sysrepo.NewWebhookSubscriptionRepo(logger, db, test.NewTestDBTimeouts())
+	suite.deliveryRepo = sysrepo.NewWebhookDeliveryRepo(logger, db, test.NewTestDBTimeouts())
+	suite.svc = NewWebhookService(logger, subRepo, suite.deliveryRepo, &config.WebhookConfig{MaxAttempts: 3})
+
+	suite.receiver = &webhookReceiver{status: http.StatusOK}
+	suite.server = httptest.NewServer(suite.receiver)
+}
+
+func (suite *WebhookServiceTestSuite) TearDownTest() {
+	suite.server.Close()
+}
+
+func (suite *WebhookServiceTestSuite) createSubscription(name, eventList string, enabled bool) *sysmodel.WebhookSubscriptionModel {
+	m, rErr := suite.svc.CreateWebhookSubscription(context.Background(), sysmodel.WebhookSubscriptionModel{
+		Name:      name,
+		URL:       suite.server.URL,
+		Secret:    testWebhookSecret,
+		Events:    eventList,
+		IsEnabled: enabled,
+	})
+	suite.Require().Nil(rErr)
+	return m
+}
+
+func (suite *WebhookServiceTestSuite) listDeliveries() []sysmodel.WebhookDeliveryModel {
+	_, ms, err := suite.deliveryRepo.ListModel(context.Background(), database.QueryParams{OrderBy: []string{"id ASC"}})
+	suite.Require().NoError(err)
+	return *ms
+}
+
+// makeDue 将待推送记录的下次推送时间提前, 模拟退避时间已到
+func (suite *WebhookServiceTestSuite) makeDue() {
+	past := time.Now().Add(-time.Second)
+	err := suite.deliveryRepo.UpdateModel(context.Background(), map[string]any{"next_retry_at": past}, "status = ?", sysmodel.WebhookDeliveryPending)
+	suite.Require().NoError(err)
+}
+
+func (suite *WebhookServiceTestSuite) TestCreateWebhookSubscriptionValidation() {
+	ctx := context.Background()
+	_, rErr := suite.svc.CreateWebhookSubscription(ctx, sysmodel.WebhookSubscriptionModel{
+		Name: "ftp", URL: "ftp://example.com/hook", Secret: testWebhookSecret, Events: "*",
+	})
+	suite.Require().NotNil(rErr)
+	suite.Equal(errors.ErrValidationFailed.Reason, rErr.Reason, "只支持http和https推送地址")
+
+	_, rErr = suite.svc.CreateWebhookSubscription(ctx, sysmodel.WebhookSubscriptionModel{
+		Name: "nosecret", URL: suite.server.URL, Events: "*",
+	})
+	suite.Require().NotNil(rErr)
+	suite.Equal(errors.ErrValidationFailed.Reason, rErr.Reason, "创建时必须设置签名密钥")
+}
+
+func (suite *WebhookServiceTestSuite) TestUpdateKeepsSecret() {
+	ctx := context.Background()
+	sub := suite.createSubscription("ops", "*", true)
+
+	m, rErr := suite.svc.UpdateWebhookSubscriptionByID(ctx, sub.ID, sysmodel.WebhookSubscriptionModel{
+		Name: "ops2", URL: suite.server.URL, Events: "job.finished", IsEnabled: true,
+	})
+	suite.Require().Nil(rErr)
+	suite.Equal("ops2", m.Name)
+	suite.Equal(testWebhookSecret, m.Secret, "签名密钥为空时应该保留原密钥")
+}
+
+func (suite *WebhookServiceTestSuite) TestHandleEventFiltersSubscriptions() {
+	ctx := context.Background()
+	all := suite.createSubscription("all", "*", true)
+	jobs := suite.createSubscription("jobs", "job.finished", true)
+	suite.createSubscription("users", "user.created", true)
+	suite.createSubscription("disabled", "*", false)
+
+	event := events.Event{
+		ID: "evt-1", Type: events.JobFinished, OccurredAt: time.Now(), Data: json.RawMessage(`{"record_id":1}`),
+	}
+	suite.Require().NoError(suite.svc.HandleEvent(ctx, event))
+	// 发件箱重复发布同一事件时不重复创建推送记录
+	suite.Require().NoError(suite.svc.HandleEvent(ctx, event))
+
+	ds := suite.listDeliveries()
+	suite.Require().Len(ds, 2, "只为启用且订阅了该事件的webhook创建推送记录")
+	suite.Equal(all.ID, ds[0].SubscriptionID)
+	suite.Equal(jobs.ID, ds[1].SubscriptionID)
+	suite.Equal(sysmodel.WebhookDeliveryPending, ds[0].Status)
+	suite.Contains(ds[0].Payload, `"type":"job.finished"`)
+}
+
+func (suite *WebhookServiceTestSuite) TestDeliverSignsPayload() {
+	ctx := context.Background()
+	suite.createSubscription("ops", "*", true)
+	suite.Require().NoError(suite.svc.HandleEvent(ctx, events.Event{ID: "evt-1", Type: events.UserCreated, OccurredAt: time.Now()}))
+
+	n, rErr := suite.svc.DeliverDue(ctx)
+	suite.Require().Nil(rErr)
+	suite.Equal(1, n)
+	suite.Require().Equal(1, suite.receiver.count())
+
+	header, body := suite.receiver.headers[0], suite.receiver.bodies[0]
+	suite.Equal(events.UserCreated, header.Get(WebhookEventHeader))
+	ts, err := strconv.ParseInt(header.Get(WebhookTimestampHeader), 10, 64)
+	suite.Require().NoError(err)
+	suite.Equal(SignWebhookPayload(testWebhookSecret, ts, body), header.Get(WebhookSignatureHeader))
+
+	var event events.Event
+	suite.Require().NoError(json.Unmarshal(body, &event))
+	suite.Equal("evt-1", event.ID)
+
+	ds := suite.listDeliveries()
+	suite.Equal(sysmodel.WebhookDeliverySuccess, ds[0].Status)
+	suite.Equal(http.StatusOK, ds[0].ResponseCode)
+	suite.NotNil(ds[0].DeliveredAt)
+	suite.Nil(ds[0].NextRetryAt)
+}
+
+func (suite *WebhookServiceTestSuite) TestRetryThenFail() {
+	ctx := context.Background()
+	suite.receiver.status = http.StatusInternalServerError
+	suite.createSubscription("ops", "*", true)
+	suite.Require().NoError(suite.svc.HandleEvent(ctx, events.Event{ID: "evt-1", Type: events.AlertFired, OccurredAt: time.Now()}))
+
+	_, rErr := suite.svc.DeliverDue(ctx)
+	suite.Require().Nil(rErr)
+	d := suite.listDeliveries()[0]
+	suite.Equal(sysmodel.WebhookDeliveryPending, d.Status, "推送失败后应该等待重试")
+	suite.Equal(1, d.Attempts)
+	suite.Equal(http.StatusInternalServerError, d.ResponseCode)
+	suite.Require().NotNil(d.NextRetryAt)
+	suite.WithinDuration(time.Now().Add(webhookRetryBase), *d.NextRetryAt, 5*time.Second)
+
+	// 退避时间未到时不推送
+	n, rErr := suite.svc.DeliverDue(ctx)
+	suite.Require().Nil(rErr)
+	suite.Equal(0, n)
+
+	for range 2 {
+		suite.makeDue()
+		_, rErr = suite.svc.DeliverDue(ctx)
+		suite.Require().Nil(rErr)
+	}
+	d = suite.listDeliveries()[0]
+	suite.Equal(sysmodel.WebhookDeliveryFailed, d.Status, "超过最大推送次数后应该标记为失败")
+	suite.Equal(3, d.Attempts)
+	suite.Nil(d.NextRetryAt)
+	suite.Contains(d.ErrorMessage, "HTTP 500")
+	suite.Equal(3, suite.receiver.count())
+}
+
+func (suite *WebhookServiceTestSuite) TestTestWebhookSubscription() {
+	ctx := context.Background()
+	sub := suite.createSubscription("ops", "job.finished", false)
+
+	d, rErr := suite.svc.TestWebhookSubscription(ctx, sub.ID, "admin")
+	suite.Require().Nil(rErr)
+	suite.Equal(sysmodel.WebhookEventTest, d.EventType)
+	suite.Equal(sysmodel.WebhookDeliverySuccess, d.Status, "停用的订阅也可以测试")
+	suite.Equal(1, suite.receiver.count())
+
+	suite.receiver.status = http.StatusBadGateway
+	d, rErr = suite.svc.TestWebhookSubscription(ctx, sub.ID, "admin")
+	suite.Require().Nil(rErr)
+	suite.Equal(sysmodel.WebhookDeliveryFailed, d.Status, "测试推送失败时不重试")
+}
+
+func (suite *WebhookServiceTestSuite) TestDeleteRemovesDeliveries() {
+	ctx := context.Background()
+	sub := suite.createSubscription("ops", "*", true)
+	suite.Require().NoError(suite.svc.HandleEvent(ctx, events.Event{ID: "evt-1", Type: events.ColonyUpdated, OccurredAt: time.Now()}))
+	suite.Require().Len(suite.listDeliveries(), 1)
+
+	suite.Require().Nil(suite.svc.DeleteWebhookSubscriptionByID(ctx, sub.ID))
+	suite.Empty(suite.listDeliveries())
+}
+
+func TestWebhookBackoff(t *testing.T) {
+	cases := map[int]time.Duration{
+		1:  30 * time.Second,
+		2:  time.Minute,
+		3:  2 * time.Minute,
+		8:  time.Hour,
+		20: time.Hour,
+	}
+	for attempts, want := range cases {
+		if got := webhookBackoff(attempts); got != want {
+			t.Errorf("webhookBackoff(%d) = %s, want %s", attempts, got, want)
+		}
+	}
+}
+
+func TestWebhookServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(WebhookServiceTestSuite))
+}
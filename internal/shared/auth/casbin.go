@@ -3,10 +3,11 @@ package auth
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"slices"
 
 	"emperror.dev/errors"
 	"github.com/casbin/casbin/v2"
-	"github.com/casbin/casbin/v2/model"
 	stringadapter "github.com/casbin/casbin/v2/persist/string-adapter"
 )
 
@@ -19,11 +20,18 @@ const (
 	GroupObjKey = "group_child"  // 组策略对象键
 )
 
+// SensitiveObj 查看敏感字段明文的权限对应的接口
+//
+// 拥有此接口GET权限的角色在查询结果中看到完整的敏感字段, 其余角色按字段的mask标签掩码
+const SensitiveObj = "/api/v1/customer/sensitive/reveal"
+
 const (
 	apiSubjectFormat    = "api_%d"
 	menuSubjectFormat   = "menu_%d"
 	buttonSubjectFormat = "button_%d"
 	roleSubjectFormat   = "role_%d"
+	userSubjectFormat   = "user_%d"
+	groupSubjectFormat  = "group_%d"
 )
 
 // ApiToSubject 将ApiID转换为对应的Casbin主体
@@ -46,23 +54,52 @@ func RoleToSubject(pk uint32) string {
 	return fmt.Sprintf(roleSubjectFormat, pk)
 }
 
-func NewCasbinEnforcer() (*casbin.Enforcer, error) {
-	cm, err := model.NewModelFromString(`
-		[request_definition]
-		r = sub, obj, act
+// UserToSubject 将用户ID转换为对应的Casbin主体
+func UserToSubject(pk uint32) string {
+	return fmt.Sprintf(userSubjectFormat, pk)
+}
 
-		[policy_definition]
-		p = sub, obj, act
+// UserGroupToSubject 将用户组ID转换为对应的Casbin主体
+func UserGroupToSubject(pk uint32) string {
+	return fmt.Sprintf(groupSubjectFormat, pk)
+}
 
-		[role_definition]
-		g = _, _
+// EnforceUser 按用户的有效角色校验权限
+// 先校验用户直属角色, 未通过时再校验用户主体, 用户主体通过组策略继承所属用户组的角色
+func EnforceUser(enf *casbin.Enforcer, userID, roleID uint32, obj, act string) (bool, error) {
+	ok, err := enf.Enforce(RoleToSubject(roleID), obj, act)
+	if err != nil || ok {
+		return ok, err
+	}
+	return enf.Enforce(UserToSubject(userID), obj, act)
+}
 
-		[policy_effect]
-		e = some(where (p.eft == allow))
+// UserRoleIDs 返回用户的有效角色ID, 与EnforceUser相同包含直属角色和通过组策略继承的所属用户组的角色
+func UserRoleIDs(enf *casbin.Enforcer, userID, roleID uint32) ([]uint32, error) {
+	roleIDs := []uint32{roleID}
+	subjects, err := enf.GetImplicitRolesForUser(UserToSubject(userID))
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "查询用户继承的角色失败", "user_id", userID)
+	}
+	for _, sub := range subjects {
+		var id uint32
+		if _, err := fmt.Sscanf(sub, roleSubjectFormat, &id); err != nil {
+			continue
+		}
+		if !slices.Contains(roleIDs, id) {
+			roleIDs = append(roleIDs, id)
+		}
+	}
+	return roleIDs, nil
+}
 
-		[matchers]
-		m = g(r.sub, p.sub) && r.obj == p.obj && r.act == p.act
-	`)
+// EnforceSensitive 校验用户是否可以查看敏感字段明文
+func EnforceSensitive(enf *casbin.Enforcer, userID, roleID uint32) (bool, error) {
+	return EnforceUser(enf, userID, roleID, SensitiveObj, http.MethodGet)
+}
+
+func NewCasbinEnforcer() (*casbin.Enforcer, error) {
+	cm, err := NewCasbinModel(DefaultCasbinModel)
 	if err != nil {
 		return nil, errors.WrapIf(err, "创建Casbin模型失败")
 	}
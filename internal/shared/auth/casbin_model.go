@@ -0,0 +1,198 @@
+package auth
+
+import (
+	"context"
+	"strings"
+
+	"emperror.dev/errors"
+	"github.com/casbin/casbin/v2"
+	"github.com/casbin/casbin/v2/model"
+	stringadapter "github.com/casbin/casbin/v2/persist/string-adapter"
+)
+
+// DefaultCasbinModel 系统内置的Casbin模型配置
+const DefaultCasbinModel = `[request_definition]
+r = sub, obj, act
+
+[policy_definition]
+p = sub, obj, act
+
+[role_definition]
+g = _, _
+
+[policy_effect]
+e = some(where (p.eft == allow))
+
+[matchers]
+m = g(r.sub, p.sub) && r.obj == p.obj && r.act == p.act
+`
+
+// KeyMatchCasbinModel 使用keyMatch2匹配对象的内置模型配置
+// 策略中的gin路由模式(/api/v1/customer/user/:id)和通配符(/api/v1/jobs/*)可以匹配实际请求路径
+const KeyMatchCasbinModel = `[request_definition]
+r = sub, obj, act
+
+[policy_definition]
+p = sub, obj, act
+
+[role_definition]
+g = _, _
+
+[policy_effect]
+e = some(where (p.eft == allow))
+
+[matchers]
+m = g(r.sub, p.sub) && keyMatch2(r.obj, p.obj) && r.act == p.act
+`
+
+// UsesKeyMatch 判断模型的匹配器是否使用keyMatch系列函数匹配对象
+func UsesKeyMatch(enf *casbin.Enforcer) bool {
+	assertion, ok := enf.GetModel()["m"]["m"]
+	if !ok {
+		return false
+	}
+	return strings.Contains(assertion.Value, "keyMatch")
+}
+
+// CasbinSample 模型校验使用的样例请求
+type CasbinSample struct {
+	Sub    string `json:"sub" binding:"required"`
+	Obj    string `json:"obj" binding:"required"`
+	Act    string `json:"act" binding:"required"`
+	Expect bool   `json:"expect"`
+}
+
+// CasbinSampleResult 样例请求的校验结果
+type CasbinSampleResult struct {
+	CasbinSample
+	Actual bool `json:"actual"`
+	Passed bool `json:"passed"`
+}
+
+// NewCasbinModel 解析Casbin模型配置，并校验其与系统策略结构是否兼容
+// 系统中的策略固定为 p = sub, obj, act 与 g = _, _，模型必须保持该结构
+func NewCasbinModel(text string) (model.Model, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, errors.New("解析Casbin模型失败: 模型配置为空")
+	}
+	m, err := model.NewModelFromString(text)
+	if err != nil {
+		return nil, errors.WrapIf(err, "解析Casbin模型失败")
+	}
+
+	checks := []struct {
+		sec, key string
+		tokens   int
+	}{
+		{"r", "r", 3},
+		{"p", "p", 3},
+		{"g", "g", 2},
+	}
+	for _, c := range checks {
+		ast, aErr := m.GetAssertion(c.sec, c.key)
+		if aErr != nil {
+			return nil, errors.WrapIfWithDetails(aErr, "Casbin模型缺少必要定义", "section", c.sec)
+		}
+		if c.sec == "g" {
+			if strings.Count(ast.Value, "_") != c.tokens {
+				return nil, errors.NewWithDetails("Casbin模型角色定义必须为 g = _, _", "value", ast.Value)
+			}
+			continue
+		}
+		if len(ast.Tokens) != c.tokens {
+			return nil, errors.NewWithDetails(
+				"Casbin模型定义必须包含 sub, obj, act 三个字段",
+				"section", c.sec,
+				"value", ast.Value,
+			)
+		}
+	}
+	if _, aErr := m.GetAssertion("e", "e"); aErr != nil {
+		return nil, errors.WrapIf(aErr, "Casbin模型缺少策略效果定义")
+	}
+	if _, aErr := m.GetAssertion("m", "m"); aErr != nil {
+		return nil, errors.WrapIf(aErr, "Casbin模型缺少匹配器定义")
+	}
+	return m, nil
+}
+
+// EvaluateCasbinModel 使用当前生效的策略和新模型构造临时enforcer，对样例请求逐一求值
+// 不会修改正在使用的enforcer
+func EvaluateCasbinModel(
+	ctx context.Context,
+	enf *casbin.Enforcer,
+	text string,
+	samples []CasbinSample,
+) ([]CasbinSampleResult, error) {
+	m, err := NewCasbinModel(text)
+	if err != nil {
+		return nil, err
+	}
+	tmp, err := casbin.NewEnforcer(m, stringadapter.NewAdapter("p, api_0, /api/v1/login, POST"))
+	if err != nil {
+		return nil, errors.WrapIf(err, "创建临时Casbin enforcer失败")
+	}
+	if err := copyCasbinPolicies(ctx, enf, tmp); err != nil {
+		return nil, err
+	}
+
+	results := make([]CasbinSampleResult, 0, len(samples))
+	for _, sample := range samples {
+		ok, eErr := tmp.Enforce(sample.Sub, sample.Obj, sample.Act)
+		if eErr != nil {
+			return nil, errors.WrapIfWithDetails(
+				eErr, "Casbin模型样例请求求值失败",
+				SubKey, sample.Sub,
+				ObjKey, sample.Obj,
+				ActKey, sample.Act,
+			)
+		}
+		results = append(results, CasbinSampleResult{
+			CasbinSample: sample,
+			Actual:       ok,
+			Passed:       ok == sample.Expect,
+		})
+	}
+	return results, nil
+}
+
+// ApplyCasbinModel 将新的模型应用到正在使用的enforcer，并保留已加载的全部策略
+func ApplyCasbinModel(ctx context.Context, enf *casbin.Enforcer, text string) error {
+	m, err := NewCasbinModel(text)
+	if err != nil {
+		return err
+	}
+
+	policies, err := enf.GetPolicy()
+	if err != nil {
+		return errors.WrapIf(err, "查询Casbin策略失败")
+	}
+	groupPolicies, err := enf.GetGroupingPolicy()
+	if err != nil {
+		return errors.WrapIf(err, "查询Casbin组策略失败")
+	}
+
+	enf.SetModel(m)
+	if err := AddPolicies(ctx, enf, policies); err != nil {
+		return err
+	}
+	if err := AddGroupPolicies(ctx, enf, groupPolicies); err != nil {
+		return err
+	}
+	return errors.WrapIf(enf.BuildRoleLinks(), "重建Casbin角色关系失败")
+}
+
+func copyCasbinPolicies(ctx context.Context, src, dst *casbin.Enforcer) error {
+	policies, err := src.GetPolicy()
+	if err != nil {
+		return errors.WrapIf(err, "查询Casbin策略失败")
+	}
+	groupPolicies, err := src.GetGroupingPolicy()
+	if err != nil {
+		return errors.WrapIf(err, "查询Casbin组策略失败")
+	}
+	if err := AddPolicies(ctx, dst, policies); err != nil {
+		return err
+	}
+	return AddGroupPolicies(ctx, dst, groupPolicies)
+}
@@ -0,0 +1,130 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+
+	"gin-artweb/internal/shared/config"
+)
+
+// Cookie名称
+const (
+	AccessCookieName  = "artweb_access"  // 访问令牌, HttpOnly
+	RefreshCookieName = "artweb_refresh" // 刷新令牌, HttpOnly
+	CSRFCookieName    = "artweb_csrf"    // CSRF令牌, 由前端脚本读取后放入请求头
+)
+
+const defaultCSRFHeader = "X-CSRF-Token"
+
+// CookieAuth 通过Cookie下发和读取令牌, nil表示未开启Cookie认证
+type CookieAuth struct {
+	secure     bool
+	sameSite   http.SameSite
+	domain     string
+	csrfHeader string
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+}
+
+// NewCookieAuth 创建Cookie认证, 未开启时返回nil
+func NewCookieAuth(conf config.CookieConfig, accessTTL, refreshTTL time.Duration) *CookieAuth {
+	if !conf.Enable {
+		return nil
+	}
+	sameSite := http.SameSiteLaxMode
+	switch strings.ToLower(conf.SameSite) {
+	case "strict":
+		sameSite = http.SameSiteStrictMode
+	case "none":
+		sameSite = http.SameSiteNoneMode
+	}
+	csrfHeader := conf.CSRFHeader
+	if csrfHeader == "" {
+		csrfHeader = defaultCSRFHeader
+	}
+	return &CookieAuth{
+		secure:     conf.Secure,
+		sameSite:   sameSite,
+		domain:     conf.Domain,
+		csrfHeader: csrfHeader,
+		accessTTL:  accessTTL,
+		refreshTTL: refreshTTL,
+	}
+}
+
+// CSRFHeader 携带CSRF令牌的请求头
+func (a *CookieAuth) CSRFHeader() string {
+	return a.csrfHeader
+}
+
+// SetTokens 将令牌写入HttpOnly Cookie, 并下发新的CSRF令牌
+func (a *CookieAuth) SetTokens(w http.ResponseWriter, accessToken, refreshToken string) error {
+	csrf, err := newCSRFToken()
+	if err != nil {
+		return err
+	}
+	http.SetCookie(w, a.cookie(AccessCookieName, accessToken, a.accessTTL, true))
+	http.SetCookie(w, a.cookie(RefreshCookieName, refreshToken, a.refreshTTL, true))
+	http.SetCookie(w, a.cookie(CSRFCookieName, csrf, a.refreshTTL, false))
+	return nil
+}
+
+// Clear 删除令牌和CSRF令牌Cookie
+func (a *CookieAuth) Clear(w http.ResponseWriter) {
+	for _, name := range []string{AccessCookieName, RefreshCookieName, CSRFCookieName} {
+		c := a.cookie(name, "", 0, name != CSRFCookieName)
+		c.MaxAge = -1
+		http.SetCookie(w, c)
+	}
+}
+
+func (a *CookieAuth) cookie(name, value string, ttl time.Duration, httpOnly bool) *http.Cookie {
+	return &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     "/",
+		Domain:   a.domain,
+		MaxAge:   int(ttl.Seconds()),
+		Secure:   a.secure,
+		HttpOnly: httpOnly,
+		SameSite: a.sameSite,
+	}
+}
+
+// UsesCookie 请求是否携带了令牌Cookie
+func UsesCookie(r *http.Request) bool {
+	for _, name := range []string{AccessCookieName, RefreshCookieName} {
+		if c, err := r.Cookie(name); err == nil && c.Value != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// CookieValue 读取Cookie的值, 不存在时返回空字符串
+func CookieValue(r *http.Request, name string) string {
+	c, err := r.Cookie(name)
+	if err != nil {
+		return ""
+	}
+	return c.Value
+}
+
+// ValidCSRF 校验请求头中的CSRF令牌与CSRF Cookie一致
+func (a *CookieAuth) ValidCSRF(r *http.Request) bool {
+	cookie := CookieValue(r, CSRFCookieName)
+	header := r.Header.Get(a.csrfHeader)
+	return cookie != "" && subtle.ConstantTimeCompare([]byte(cookie), []byte(header)) == 1
+}
+
+func newCSRFToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
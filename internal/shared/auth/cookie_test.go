@@ -0,0 +1,142 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gin-artweb/internal/shared/config"
+)
+
+func TestNewCookieAuth(t *testing.T) {
+	assert.Nil(t, NewCookieAuth(config.CookieConfig{}, time.Minute, time.Hour), "未开启时返回nil")
+
+	tests := []struct {
+		name     string
+		conf     config.CookieConfig
+		sameSite http.SameSite
+		header   string
+	}{
+		{
+			name:     "默认值",
+			conf:     config.CookieConfig{Enable: true},
+			sameSite: http.SameSiteLaxMode,
+			header:   defaultCSRFHeader,
+		},
+		{
+			name:     "strict",
+			conf:     config.CookieConfig{Enable: true, SameSite: "Strict", CSRFHeader: "X-XSRF-Token"},
+			sameSite: http.SameSiteStrictMode,
+			header:   "X-XSRF-Token",
+		},
+		{
+			name:     "none",
+			conf:     config.CookieConfig{Enable: true, SameSite: "none", Secure: true},
+			sameSite: http.SameSiteNoneMode,
+			header:   defaultCSRFHeader,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewCookieAuth(tt.conf, time.Minute, time.Hour)
+			require.NotNil(t, a)
+			assert.Equal(t, tt.sameSite, a.sameSite)
+			assert.Equal(t, tt.header, a.CSRFHeader())
+		})
+	}
+}
+
+func TestCookieAuthSetTokensAndClear(t *testing.T) {
+	a := NewCookieAuth(config.CookieConfig{Enable: true, Secure: true, Domain: "example.com"}, time.Minute, time.Hour)
+	w := httptest.NewRecorder()
+	require.NoError(t, a.SetTokens(w, "access", "refresh"))
+
+	cookies := map[string]*http.Cookie{}
+	for _, c := range w.Result().Cookies() {
+		cookies[c.Name] = c
+	}
+	require.Len(t, cookies, 3)
+	assert.Equal(t, "access", cookies[AccessCookieName].Value)
+	assert.Equal(t, 60, cookies[AccessCookieName].MaxAge)
+	assert.Equal(t, "refresh", cookies[RefreshCookieName].Value)
+	assert.Equal(t, 3600, cookies[RefreshCookieName].MaxAge)
+	assert.Len(t, cookies[CSRFCookieName].Value, 64)
+	for name, c := range cookies {
+		assert.Equal(t, name != CSRFCookieName, c.HttpOnly, "CSRF令牌需要由前端脚本读取, 其他令牌只能HttpOnly")
+		assert.True(t, c.Secure)
+		assert.Equal(t, "example.com", c.Domain)
+		assert.Equal(t, "/", c.Path)
+	}
+
+	w2 := httptest.NewRecorder()
+	require.NoError(t, a.SetTokens(w2, "access", "refresh"))
+	for _, c := range w2.Result().Cookies() {
+		if c.Name == CSRFCookieName {
+			assert.NotEqual(t, cookies[CSRFCookieName].Value, c.Value, "每次下发新的CSRF令牌")
+		}
+	}
+
+	w = httptest.NewRecorder()
+	a.Clear(w)
+	cleared := w.Result().Cookies()
+	require.Len(t, cleared, 3)
+	for _, c := range cleared {
+		assert.Empty(t, c.Value)
+		assert.Negative(t, c.MaxAge, "%s应该被删除", c.Name)
+	}
+}
+
+func TestUsesCookie(t *testing.T) {
+	tests := []struct {
+		name    string
+		cookies []*http.Cookie
+		want    bool
+	}{
+		{name: "没有Cookie"},
+		{name: "访问令牌", cookies: []*http.Cookie{{Name: AccessCookieName, Value: "a"}}, want: true},
+		{name: "刷新令牌", cookies: []*http.Cookie{{Name: RefreshCookieName, Value: "r"}}, want: true},
+		{name: "空的令牌", cookies: []*http.Cookie{{Name: AccessCookieName, Value: ""}}},
+		{name: "只有CSRF令牌", cookies: []*http.Cookie{{Name: CSRFCookieName, Value: "c"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/", nil)
+			for _, c := range tt.cookies {
+				r.AddCookie(c)
+			}
+			assert.Equal(t, tt.want, UsesCookie(r))
+		})
+	}
+}
+
+func TestValidCSRF(t *testing.T) {
+	a := NewCookieAuth(config.CookieConfig{Enable: true}, time.Minute, time.Hour)
+	tests := []struct {
+		name   string
+		cookie string
+		header string
+		want   bool
+	}{
+		{name: "一致", cookie: "token", header: "token", want: true},
+		{name: "不一致", cookie: "token", header: "other"},
+		{name: "缺少请求头", cookie: "token"},
+		{name: "缺少Cookie", header: "token"},
+		{name: "都为空"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/", nil)
+			if tt.cookie != "" {
+				r.AddCookie(&http.Cookie{Name: CSRFCookieName, Value: tt.cookie})
+			}
+			if tt.header != "" {
+				r.Header.Set(a.CSRFHeader(), tt.header)
+			}
+			assert.Equal(t, tt.want, a.ValidCSRF(r))
+		})
+	}
+}
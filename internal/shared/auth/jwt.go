@@ -8,6 +8,7 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 
+	"gin-artweb/internal/shared/config"
 	"gin-artweb/internal/shared/errors"
 )
 
@@ -19,10 +20,19 @@ const (
 )
 
 type UserInfo struct {
-	UserID   uint32 `json:"uid"` // 用户ID
-	Username string `json:"un"`  // 用户名
-	RoleID   uint32 `json:"rid"` // 角色
-	IsStaff  bool   `json:"isf"` // 是否是工作人员
+	UserID             uint32 `json:"uid"`           // 用户ID
+	Username           string `json:"un"`            // 用户名
+	RoleID             uint32 `json:"rid"`           // 角色
+	IsStaff            bool   `json:"isf"`           // 是否是工作人员
+	MustChangePassword bool   `json:"mcp,omitempty"` // 是否需要先修改密码
+	SessionID          string `json:"sid,omitempty"` // 会话ID, 同一次登录签发和刷新的令牌属于同一个会话
+	ImpersonatorID     uint32 `json:"iid,omitempty"` // 代为登录的管理员ID, 前端据此显示代登录提示
+	ImpersonatorName   string `json:"iun,omitempty"` // 代为登录的管理员用户名
+}
+
+// Impersonated 是否为管理员代为登录签发的令牌
+func (u UserInfo) Impersonated() bool {
+	return u.ImpersonatorID != 0
 }
 
 // UserClaims 用户Claims
@@ -32,16 +42,39 @@ type UserClaims struct {
 	Type TokenType `json:"typ"` // 令牌类型
 }
 
+// SessionValidator 校验令牌所属的会话是否仍然有效
+type SessionValidator interface {
+	ValidateSession(ctx context.Context, claims *UserClaims) *errors.Error
+}
+
+// TokenPair 同一次签发的访问令牌和刷新令牌
+type TokenPair struct {
+	AccessToken      string
+	RefreshToken     string
+	SessionID        string    // 会话ID
+	AccessID         string    // 访问令牌ID
+	RefreshID        string    // 刷新令牌ID
+	IssuedAt         time.Time // 签发时间
+	AccessExpiresAt  time.Time // 访问令牌过期时间
+	RefreshExpiresAt time.Time // 刷新令牌过期时间
+}
+
 type JWTConfig struct {
-	Issuer                 string            // 令牌签发者
-	AccessTokenExpiration  time.Duration     // 访问令牌过期时间
-	RefreshTokenExpiration time.Duration     // 刷新令牌过期时间
-	AccessSecret           []byte            // 访问令牌密钥
-	RefreshSecret          []byte            // 刷新令牌密钥
-	AccessMethod           jwt.SigningMethod // 访问令牌签名方法
-	RefreshMethod          jwt.SigningMethod // 刷新令牌签名方法
+	Issuer                 string                // 令牌签发者
+	AccessTokenExpiration  time.Duration         // 访问令牌过期时间
+	RefreshTokenExpiration time.Duration         // 刷新令牌过期时间
+	AccessMethod           jwt.SigningMethod     // 访问令牌签名方法, 轮换时按此方法生成新密钥
+	RefreshMethod          jwt.SigningMethod     // 刷新令牌签名方法, 轮换时按此方法生成新密钥
+	AccessKeys             *KeySet               // 访问令牌签名密钥
+	RefreshKeys            *KeySet               // 刷新令牌签名密钥
+	Sessions               SessionValidator      // 会话校验, 为空时不校验令牌所属的会话
+	Cookie                 *CookieAuth           // Cookie认证, 为空时只从请求头读取令牌
+	Secrets                *config.SecretManager // 登记内存中的签名密钥, 为空时不登记
 }
 
+// NewJWTConfig 创建JWT配置
+// HMAC签名方法使用环境变量中的密钥作为初始密钥, 非对称签名方法忽略该密钥,
+// 由密钥轮换服务在启动时从数据库加载或生成密钥
 func NewJWTConfig(
 	accessExpiration, refreshExpiration time.Duration,
 	accessMethodstr, refreshMethodstr string,
@@ -55,17 +88,49 @@ func NewJWTConfig(
 	if refreshMethod == nil {
 		panic("invalid refresh method")
 	}
-	if len(accessSecret) == 0 || len(refreshSecret) == 0 {
+	if (IsSymmetric(accessMethod) && len(accessSecret) == 0) ||
+		(IsSymmetric(refreshMethod) && len(refreshSecret) == 0) {
 		panic("JWT_ACCESS_SECRET or JWT_REFRESH_SECRET is empty")
 	}
 	return &JWTConfig{
 		AccessTokenExpiration:  accessExpiration,
 		RefreshTokenExpiration: refreshExpiration,
-		AccessSecret:           accessSecret,
-		RefreshSecret:          refreshSecret,
 		AccessMethod:           accessMethod,
 		RefreshMethod:          refreshMethod,
+		AccessKeys:             newSecretKeySet(accessMethod, accessSecret),
+		RefreshKeys:            newSecretKeySet(refreshMethod, refreshSecret),
+	}
+}
+
+func newSecretKeySet(method jwt.SigningMethod, secret []byte) *KeySet {
+	if !IsSymmetric(method) || len(secret) == 0 {
+		return NewKeySet(nil)
+	}
+	return NewKeySet(NewSecretKey(method, secret))
+}
+
+// Keys 返回指定令牌类型的签名密钥集合
+func (c *JWTConfig) Keys(tt TokenType) *KeySet {
+	if tt == TokenTypeRefresh {
+		return c.RefreshKeys
+	}
+	return c.AccessKeys
+}
+
+// Method 返回指定令牌类型的签名方法
+func (c *JWTConfig) Method(tt TokenType) jwt.SigningMethod {
+	if tt == TokenTypeRefresh {
+		return c.RefreshMethod
+	}
+	return c.AccessMethod
+}
+
+// Expiration 返回指定令牌类型的有效期, 轮换后旧密钥保留该时长
+func (c *JWTConfig) Expiration(tt TokenType) time.Duration {
+	if tt == TokenTypeRefresh {
+		return c.RefreshTokenExpiration
 	}
+	return c.AccessTokenExpiration
 }
 
 func newUserClaims(c *JWTConfig, u UserInfo, tt TokenType) UserClaims {
@@ -74,7 +139,7 @@ func newUserClaims(c *JWTConfig, u UserInfo, tt TokenType) UserClaims {
 		RegisteredClaims: jwt.RegisteredClaims{
 			Issuer:    c.Issuer,
 			Subject:   u.Username,
-			ExpiresAt: jwt.NewNumericDate(now.Add(c.AccessTokenExpiration)),
+			ExpiresAt: jwt.NewNumericDate(now.Add(c.Expiration(tt))),
 			IssuedAt:  jwt.NewNumericDate(now),
 			NotBefore: jwt.NewNumericDate(now),
 			ID:        uuid.NewString(),
@@ -90,8 +155,7 @@ func NewAccessJWT(ctx context.Context, c *JWTConfig, u UserInfo) (string, error)
 		return "", emperror.WrapIf(ctx.Err(), "上下文已取消/超时")
 	}
 	claims := newUserClaims(c, u, TokenTypeAccess)
-	token := jwt.NewWithClaims(c.AccessMethod, claims)
-	tokenString, err := token.SignedString(c.AccessSecret)
+	tokenString, err := c.AccessKeys.sign(claims)
 	if err != nil {
 		return "", emperror.WrapIf(err, "创建jwt失败")
 	}
@@ -104,14 +168,61 @@ func NewRefreshJWT(ctx context.Context, c *JWTConfig, u UserInfo) (string, error
 		return "", emperror.WrapIf(ctx.Err(), "上下文已取消/超时")
 	}
 	claims := newUserClaims(c, u, TokenTypeRefresh)
-	token := jwt.NewWithClaims(c.RefreshMethod, claims)
-	tokenString, err := token.SignedString(c.RefreshSecret)
+	tokenString, err := c.RefreshKeys.sign(claims)
 	if err != nil {
 		return "", emperror.WrapIf(err, "创建刷新jwt失败")
 	}
 	return tokenString, nil
 }
 
+// NewTokenPair 同时创建访问令牌和刷新令牌
+func NewTokenPair(ctx context.Context, c *JWTConfig, u UserInfo) (*TokenPair, error) {
+	if ctx.Err() != nil {
+		return nil, emperror.WrapIf(ctx.Err(), "上下文已取消/超时")
+	}
+	access := newUserClaims(c, u, TokenTypeAccess)
+	accessToken, err := c.AccessKeys.sign(access)
+	if err != nil {
+		return nil, emperror.WrapIf(err, "创建jwt失败")
+	}
+	refresh := newUserClaims(c, u, TokenTypeRefresh)
+	refreshToken, err := c.RefreshKeys.sign(refresh)
+	if err != nil {
+		return nil, emperror.WrapIf(err, "创建刷新jwt失败")
+	}
+	return &TokenPair{
+		AccessToken:      accessToken,
+		RefreshToken:     refreshToken,
+		SessionID:        u.SessionID,
+		AccessID:         access.ID,
+		RefreshID:        refresh.ID,
+		IssuedAt:         access.IssuedAt.Time,
+		AccessExpiresAt:  access.ExpiresAt.Time,
+		RefreshExpiresAt: refresh.ExpiresAt.Time,
+	}, nil
+}
+
+// NewImpersonationToken 创建代为登录的访问令牌, 有效期为ttl, 不签发刷新令牌
+func NewImpersonationToken(ctx context.Context, c *JWTConfig, u UserInfo, ttl time.Duration) (*TokenPair, error) {
+	if ctx.Err() != nil {
+		return nil, emperror.WrapIf(ctx.Err(), "上下文已取消/超时")
+	}
+	access := newUserClaims(c, u, TokenTypeAccess)
+	access.ExpiresAt = jwt.NewNumericDate(access.IssuedAt.Add(ttl))
+	accessToken, err := c.AccessKeys.sign(access)
+	if err != nil {
+		return nil, emperror.WrapIf(err, "创建jwt失败")
+	}
+	return &TokenPair{
+		AccessToken:      accessToken,
+		SessionID:        u.SessionID,
+		AccessID:         access.ID,
+		IssuedAt:         access.IssuedAt.Time,
+		AccessExpiresAt:  access.ExpiresAt.Time,
+		RefreshExpiresAt: access.ExpiresAt.Time,
+	}, nil
+}
+
 // ParseAccessToken 解析并验证JWT令牌
 func ParseAccessToken(ctx context.Context, c *JWTConfig, tokenString string) (*UserClaims, *errors.Error) {
 	if ctx.Err() != nil {
@@ -120,9 +231,7 @@ func ParseAccessToken(ctx context.Context, c *JWTConfig, tokenString string) (*U
 	token, err := jwt.ParseWithClaims(
 		tokenString,
 		&UserClaims{},
-		func(token *jwt.Token) (any, error) {
-			return c.AccessSecret, nil
-		},
+		c.AccessKeys.keyFunc,
 	)
 
 	if err != nil {
@@ -133,6 +242,11 @@ func ParseAccessToken(ctx context.Context, c *JWTConfig, tokenString string) (*U
 		if claims.Type != TokenTypeAccess {
 			return nil, errors.ErrTokenTypeMismatch
 		}
+		if c.Sessions != nil {
+			if rErr := c.Sessions.ValidateSession(ctx, claims); rErr != nil {
+				return nil, rErr
+			}
+		}
 		return claims, nil
 	}
 
@@ -147,9 +261,7 @@ func ParseRefreshToken(ctx context.Context, c *JWTConfig, tokenString string) (*
 	token, err := jwt.ParseWithClaims(
 		tokenString,
 		&UserClaims{},
-		func(token *jwt.Token) (any, error) {
-			return c.RefreshSecret, nil
-		},
+		c.RefreshKeys.keyFunc,
 	)
 
 	if err != nil {
@@ -0,0 +1,320 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"math/big"
+	"sync"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/golang-jwt/jwt/v5"
+
+	"gin-artweb/internal/shared/config"
+)
+
+const (
+	// KeyIDHeader JWT头部中标识签名密钥的字段
+	KeyIDHeader = "kid"
+
+	hmacKeySize = 64   // 生成的HMAC密钥长度(字节)
+	rsaKeyBits  = 2048 // 生成的RSA密钥长度(位)
+)
+
+// SigningKey JWT签名密钥
+type SigningKey struct {
+	ID        string            // 密钥标识, 签发时写入kid头部
+	Method    jwt.SigningMethod // 签名方法
+	SignKey   any               // 签名使用的密钥, HMAC为[]byte, 非对称算法为私钥
+	VerifyKey any               // 验签使用的密钥, HMAC为[]byte, 非对称算法为公钥
+	RetireAt  time.Time         // 停用时间, 零值表示不停用
+}
+
+// Retired 密钥在指定时间是否已停用
+func (k *SigningKey) Retired(t time.Time) bool {
+	return !k.RetireAt.IsZero() && !t.Before(k.RetireAt)
+}
+
+// IsSymmetric 是否为对称签名方法
+func IsSymmetric(method jwt.SigningMethod) bool {
+	_, ok := method.(*jwt.SigningMethodHMAC)
+	return ok
+}
+
+// NewSecretKey 使用环境变量中配置的密钥创建HMAC签名密钥
+// 密钥标识由密钥摘要生成, 同一密钥在每次启动时标识不变
+func NewSecretKey(method jwt.SigningMethod, secret []byte) *SigningKey {
+	sum := sha256.Sum256(secret)
+	return &SigningKey{
+		ID:        "env-" + hex.EncodeToString(sum[:6]),
+		Method:    method,
+		SignKey:   secret,
+		VerifyKey: secret,
+	}
+}
+
+// GenerateSigningKey 按签名方法生成新的签名密钥, 支持HS*、RS*、PS*和EdDSA
+func GenerateSigningKey(method jwt.SigningMethod) (*SigningKey, error) {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return nil, errors.WrapIf(err, "生成密钥标识失败")
+	}
+	k := &SigningKey{
+		ID:     hex.EncodeToString(id),
+		Method: method,
+	}
+	switch method.(type) {
+	case *jwt.SigningMethodHMAC:
+		secret := make([]byte, hmacKeySize)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, errors.WrapIf(err, "生成HMAC密钥失败")
+		}
+		k.SignKey, k.VerifyKey = secret, secret
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodRSAPSS:
+		priv, err := rsa.GenerateKey(rand.Reader, rsaKeyBits)
+		if err != nil {
+			return nil, errors.WrapIf(err, "生成RSA密钥失败")
+		}
+		k.SignKey, k.VerifyKey = priv, &priv.PublicKey
+	case *jwt.SigningMethodEd25519:
+		pub, priv, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, errors.WrapIf(err, "生成Ed25519密钥失败")
+		}
+		k.SignKey, k.VerifyKey = priv, pub
+	default:
+		return nil, errors.Errorf("不支持的签名方法: %s", method.Alg())
+	}
+	return k, nil
+}
+
+// EncodeSigningKey 将签名密钥编码为可保存的文本
+// HMAC密钥返回base64编码的密钥和空公钥, 非对称密钥返回PKCS8私钥和PKIX公钥的PEM
+func EncodeSigningKey(k *SigningKey) (string, string, error) {
+	if secret, ok := k.SignKey.([]byte); ok {
+		return base64.StdEncoding.EncodeToString(secret), "", nil
+	}
+	privDER, err := x509.MarshalPKCS8PrivateKey(k.SignKey)
+	if err != nil {
+		return "", "", errors.WrapIf(err, "编码私钥失败")
+	}
+	pubDER, err := x509.MarshalPKIXPublicKey(k.VerifyKey)
+	if err != nil {
+		return "", "", errors.WrapIf(err, "编码公钥失败")
+	}
+	priv := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privDER})
+	pub := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER})
+	return string(priv), string(pub), nil
+}
+
+// DecodeSigningKey 从EncodeSigningKey编码的文本还原签名密钥
+func DecodeSigningKey(id, alg, private string) (*SigningKey, error) {
+	method := jwt.GetSigningMethod(alg)
+	if method == nil {
+		return nil, errors.Errorf("不支持的签名方法: %s", alg)
+	}
+	k := &SigningKey{ID: id, Method: method}
+	if IsSymmetric(method) {
+		secret, err := base64.StdEncoding.DecodeString(private)
+		if err != nil {
+			return nil, errors.WrapIf(err, "解码HMAC密钥失败")
+		}
+		k.SignKey, k.VerifyKey = secret, secret
+		return k, nil
+	}
+
+	block, _ := pem.Decode([]byte(private))
+	if block == nil {
+		return nil, errors.New("解码私钥失败: 不是有效的PEM格式")
+	}
+	priv, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, errors.WrapIf(err, "解析私钥失败")
+	}
+	switch p := priv.(type) {
+	case *rsa.PrivateKey:
+		k.SignKey, k.VerifyKey = p, &p.PublicKey
+	case ed25519.PrivateKey:
+		k.SignKey, k.VerifyKey = p, p.Public()
+	default:
+		return nil, errors.Errorf("不支持的私钥类型: %T", priv)
+	}
+	return k, nil
+}
+
+// KeySet 一种令牌的签名密钥集合
+//
+// 新令牌使用当前密钥签发, 验签时按kid头部查找密钥, 已停用的密钥不再通过验证。
+// 轮换后旧密钥保留到其签发的令牌全部过期, 因此轮换不会使已登录用户下线
+type KeySet struct {
+	mu      sync.RWMutex
+	current *SigningKey
+	keys    map[string]*SigningKey
+	secret  *SigningKey
+}
+
+// NewKeySet 创建密钥集合, secret为环境变量配置的密钥, 可以为空
+// 升级前签发的令牌没有kid头部, 使用secret验签
+func NewKeySet(secret *SigningKey) *KeySet {
+	ks := &KeySet{
+		keys:   make(map[string]*SigningKey),
+		secret: secret,
+	}
+	if secret != nil {
+		ks.current = secret
+		ks.keys[secret.ID] = secret
+	}
+	return ks
+}
+
+// Secret 返回环境变量配置的密钥
+func (ks *KeySet) Secret() *SigningKey {
+	return ks.secret
+}
+
+// Current 返回当前用于签发令牌的密钥
+func (ks *KeySet) Current() *SigningKey {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+	return ks.current
+}
+
+// Keys 返回全部可用于验签的密钥
+func (ks *KeySet) Keys() []*SigningKey {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+	keys := make([]*SigningKey, 0, len(ks.keys))
+	for _, k := range ks.keys {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// Replace 替换当前密钥和可用于验签的密钥, 返回被移除的密钥标识
+//
+// 集合中已有的密钥沿用原来的密钥内容, 传入的同一密钥的副本立即清零, 内存中每个密钥只保留一份;
+// 被移除的密钥内容清零, 环境变量配置的密钥除外
+func (ks *KeySet) Replace(current *SigningKey, keys []*SigningKey) []string {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	m := make(map[string]*SigningKey, len(keys)+1)
+	for _, k := range keys {
+		m[k.ID] = ks.reuse(k)
+	}
+	if current != nil {
+		current = ks.reuse(current)
+		m[current.ID] = current
+	}
+	var removed []string
+	for id, k := range ks.keys {
+		if _, ok := m[id]; ok {
+			continue
+		}
+		removed = append(removed, id)
+		if !ks.isSecret(k) {
+			k.Zeroize()
+		}
+	}
+	ks.current = current
+	ks.keys = m
+	return removed
+}
+
+// reuse 集合中已有同一标识的密钥时返回使用原密钥内容的新密钥, 并清零传入的副本
+func (ks *KeySet) reuse(k *SigningKey) *SigningKey {
+	prev, ok := ks.keys[k.ID]
+	if !ok || prev == k || ks.isSecret(k) || sameKeyMaterial(prev, k) {
+		return k
+	}
+	reused := *k
+	reused.SignKey, reused.VerifyKey = prev.SignKey, prev.VerifyKey
+	k.Zeroize()
+	return &reused
+}
+
+// isSecret 是否为环境变量配置的密钥, 其内容由调用方管理
+func (ks *KeySet) isSecret(k *SigningKey) bool {
+	return ks.secret != nil && k.ID == ks.secret.ID
+}
+
+// Zeroize 清零签名密钥的内容, 清零后的密钥不能再使用
+func (k *SigningKey) Zeroize() {
+	switch key := k.SignKey.(type) {
+	case []byte:
+		config.Zeroize(key)
+	case ed25519.PrivateKey:
+		config.Zeroize(key)
+	case *rsa.PrivateKey:
+		clear(key.D.Bits())
+		for _, p := range key.Primes {
+			clear(p.Bits())
+		}
+		for _, v := range []*big.Int{key.Precomputed.Dp, key.Precomputed.Dq, key.Precomputed.Qinv} {
+			if v != nil {
+				clear(v.Bits())
+			}
+		}
+	}
+}
+
+// sameKeyMaterial 两个密钥是否共用同一份密钥内容
+func sameKeyMaterial(a, b *SigningKey) bool {
+	switch ka := a.SignKey.(type) {
+	case []byte:
+		kb, ok := b.SignKey.([]byte)
+		return ok && len(ka) > 0 && len(kb) > 0 && &ka[0] == &kb[0]
+	case ed25519.PrivateKey:
+		kb, ok := b.SignKey.(ed25519.PrivateKey)
+		return ok && len(ka) > 0 && len(kb) > 0 && &ka[0] == &kb[0]
+	default:
+		return a.SignKey == b.SignKey
+	}
+}
+
+// Lookup 按kid查找未停用的密钥, kid为空时查找环境变量配置的密钥
+func (ks *KeySet) Lookup(kid string) (*SigningKey, bool) {
+	if kid == "" {
+		if ks.secret == nil {
+			return nil, false
+		}
+		kid = ks.secret.ID
+	}
+	ks.mu.RLock()
+	k, ok := ks.keys[kid]
+	ks.mu.RUnlock()
+	if !ok || k.Retired(time.Now()) {
+		return nil, false
+	}
+	return k, true
+}
+
+// sign 使用当前密钥签发令牌
+func (ks *KeySet) sign(claims jwt.Claims) (string, error) {
+	k := ks.Current()
+	if k == nil {
+		return "", errors.New("没有可用的签名密钥")
+	}
+	token := jwt.NewWithClaims(k.Method, claims)
+	token.Header[KeyIDHeader] = k.ID
+	return token.SignedString(k.SignKey)
+}
+
+// keyFunc 按kid头部返回验签密钥, 令牌的签名方法必须与密钥一致
+func (ks *KeySet) keyFunc(token *jwt.Token) (any, error) {
+	kid, _ := token.Header[KeyIDHeader].(string)
+	k, ok := ks.Lookup(kid)
+	if !ok {
+		return nil, errors.Errorf("签名密钥不存在或已停用: %s", kid)
+	}
+	if token.Method.Alg() != k.Method.Alg() {
+		return nil, errors.Errorf("签名方法不匹配: %s", token.Method.Alg())
+	}
+	return k.VerifyKey, nil
+}
@@ -0,0 +1,220 @@
+package common
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/errors"
+	"gin-artweb/pkg/xlsx"
+)
+
+const (
+	ExportBatchSize = 500    // 导出时每批查询的记录数
+	ExportMaxRows   = 100000 // 单次导出的最大行数, 超出的记录不导出
+)
+
+// ExportColumn 导出的列
+type ExportColumn[T any] struct {
+	Key    string      // 列标识, 与columns参数对应, 一般与json字段名一致
+	Header string      // 表头
+	Value  func(T) any // 单元格的值
+}
+
+// ExportList 按批次查询列表数据并流式导出为csv或xlsx文件, 内存占用与导出行数无关
+//
+// name: 文件名和工作表名称; format: csv或xlsx
+// selected: 导出的列标识(多个用,隔开), 为空时导出全部列
+// fetch: 查询第page页的数据, 每页size条
+//
+// 开始写入文件之前出错时返回错误, 由调用方返回错误响应; 写入过程中出错时记录日志并中止, 客户端得到不完整的文件
+func ExportList[T any](
+	ctx *gin.Context,
+	logger *zap.Logger,
+	name, format, selected string,
+	columns []ExportColumn[T],
+	fetch func(page, size int) ([]T, *errors.Error),
+) *errors.Error {
+	cols, rErr := selectExportColumns(columns, selected)
+	if rErr != nil {
+		return rErr
+	}
+
+	rows, rErr := fetch(1, ExportBatchSize)
+	if rErr != nil {
+		return rErr
+	}
+
+	filename := fmt.Sprintf("%s_%s.%s", name, time.Now().Format("20060102150405"), format)
+	ctx.Header("Content-Disposition", "attachment; filename="+url.QueryEscape(filename))
+	ctx.Header("Content-Type", exportContentTypes[format])
+	ctx.Header("Content-Transfer-Encoding", "binary")
+	w, err := newExportWriter(ctx.Writer, format, name)
+	if err != nil {
+		ctxutil.Logger(ctx, logger).Error(
+			"创建导出文件失败",
+			zap.Error(err),
+			zap.String("format", format),
+		)
+		return errors.FromError(err)
+	}
+	ctx.Status(http.StatusOK)
+
+	header := make([]any, 0, len(cols))
+	for _, col := range cols {
+		header = append(header, col.Header)
+	}
+	total := 0
+	err = w.writeRow(header)
+	for page := 1; err == nil; page++ {
+		if page > 1 {
+			if rows, rErr = fetch(page, ExportBatchSize); rErr != nil {
+				err = rErr
+				break
+			}
+		}
+		for _, row := range rows {
+			if total >= ExportMaxRows {
+				break
+			}
+			values := make([]any, 0, len(cols))
+			for _, col := range cols {
+				values = append(values, exportValue(col.Value(row)))
+			}
+			if err = w.writeRow(values); err != nil {
+				break
+			}
+			total++
+		}
+		ctx.Writer.Flush()
+		if len(rows) < ExportBatchSize || total >= ExportMaxRows {
+			break
+		}
+	}
+	if err == nil {
+		err = w.close()
+	}
+	if err != nil {
+		ctxutil.Logger(ctx, logger).Error(
+			"导出文件中止",
+			zap.Error(err),
+			zap.String("filename", filename),
+			zap.Int("rows", total),
+		)
+		ctx.Abort()
+		return nil
+	}
+
+	ctxutil.Logger(ctx, logger).Info(
+		"导出文件成功",
+		zap.String("filename", filename),
+		zap.Int("rows", total),
+		zap.Bool("truncated", total >= ExportMaxRows),
+	)
+	return nil
+}
+
+func selectExportColumns[T any](columns []ExportColumn[T], selected string) ([]ExportColumn[T], *errors.Error) {
+	if strings.TrimSpace(selected) == "" {
+		return columns, nil
+	}
+	keys := strings.Split(selected, ",")
+	cols := make([]ExportColumn[T], 0, len(keys))
+	for _, key := range keys {
+		key = strings.TrimSpace(key)
+		found := false
+		for _, col := range columns {
+			if col.Key == key {
+				cols = append(cols, col)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, errors.ErrValidationFailed.WithField("columns", key)
+		}
+	}
+	return cols, nil
+}
+
+var exportContentTypes = map[string]string{
+	"csv":  "text/csv; charset=utf-8",
+	"xlsx": "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+// exportWriter 导出文件的逐行写入器
+type exportWriter struct {
+	writeRow func([]any) error
+	close    func() error
+}
+
+func newExportWriter(w io.Writer, format, name string) (*exportWriter, error) {
+	switch format {
+	case "csv":
+		// 写入BOM, 否则Excel按本地编码打开时中文乱码
+		if _, err := io.WriteString(w, "\ufeff"); err != nil {
+			return nil, err
+		}
+		cw := csv.NewWriter(w)
+		return &exportWriter{
+			writeRow: func(row []any) error {
+				record := make([]string, 0, len(row))
+				for _, v := range row {
+					record = append(record, csvCell(v))
+				}
+				return cw.Write(record)
+			},
+			close: func() error {
+				cw.Flush()
+				return cw.Error()
+			},
+		}, nil
+	case "xlsx":
+		sw, err := xlsx.NewStreamWriter(w, name)
+		if err != nil {
+			return nil, err
+		}
+		return &exportWriter{
+			writeRow: sw.WriteRow,
+			close:    sw.Close,
+		}, nil
+	default:
+		return nil, fmt.Errorf("不支持的导出格式: %s", format)
+	}
+}
+
+// exportValue 布尔值导出为是/否
+func exportValue(v any) any {
+	if b, ok := v.(bool); ok {
+		if b {
+			return "是"
+		}
+		return "否"
+	}
+	return v
+}
+
+// csvCell 转换为csv单元格, 以=+-@开头的文本前加单引号, 避免在Excel中被当作公式执行
+func csvCell(v any) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		if t != "" && strings.ContainsRune("=+-@", rune(t[0])) {
+			return "'" + t
+		}
+		return t
+	case time.Time:
+		return t.Format(time.DateTime)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -1,10 +1,13 @@
 package common
 
 import (
+	"io"
 	"mime/multipart"
+	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
@@ -22,11 +25,10 @@ func UploadFile(
 	filePerm os.FileMode,
 ) *errors.Error {
 	if upFile.Size > maxSize {
-		logger.Error(
+		ctxutil.Logger(ctx, logger).Error(
 			"上传的程序包文件过大",
 			zap.Int64("file_size", upFile.Size),
 			zap.Int64("max_size", maxSize),
-			zap.String(string(ctxutil.TraceIDKey), ctxutil.GetTraceID(ctx)),
 		)
 		return errors.ErrUploadFileTooLarge.WithFields(
 			map[string]any{
@@ -37,32 +39,29 @@ func UploadFile(
 	}
 
 	if err := os.MkdirAll(filepath.Dir(savePath), 0o755); err != nil {
-		logger.Error(
+		ctxutil.Logger(ctx, logger).Error(
 			"创建上传文件目录失败",
 			zap.Error(err),
 			zap.String("save_path", savePath),
-			zap.String(string(ctxutil.TraceIDKey), ctxutil.GetTraceID(ctx)),
 		)
 		return errors.ErrSaveUploadFileFailed.WithCause(err).WithField("save_path", savePath)
 	}
 
 	if err := ctx.SaveUploadedFile(upFile, savePath); err != nil {
-		logger.Error(
+		ctxutil.Logger(ctx, logger).Error(
 			"保存上传文件失败",
 			zap.Error(err),
 			zap.String("save_path", savePath),
-			zap.String(string(ctxutil.TraceIDKey), ctxutil.GetTraceID(ctx)),
 		)
 		return errors.ErrSaveUploadFileFailed.WithCause(err).WithField("save_path", savePath)
 	}
 
 	if err := os.Chmod(savePath, filePerm); err != nil {
-		logger.Error(
+		ctxutil.Logger(ctx, logger).Error(
 			"设置文件权限失败",
 			zap.Error(err),
 			zap.String("save_path", savePath),
 			zap.String("file_perm", filePerm.String()),
-			zap.String(string(ctxutil.TraceIDKey), ctxutil.GetTraceID(ctx)),
 		)
 		return errors.ErrSetUploadFilePermissionFailed.WithCause(err)
 	}
@@ -72,17 +71,15 @@ func UploadFile(
 func DownloadFile(ctx *gin.Context, logger *zap.Logger, filePath, rename string) *errors.Error {
 	// 检查文件是否存在
 	if _, statErr := os.Stat(filePath); os.IsNotExist(statErr) {
-		logger.Error(
+		ctxutil.Logger(ctx, logger).Error(
 			"文件不存在",
 			zap.String("file_path", filePath),
-			zap.String(string(ctxutil.TraceIDKey), ctxutil.GetTraceID(ctx)),
 		)
 		return errors.ErrDownloadFileNotFound.WithField("file_path", filePath)
 	} else if statErr != nil {
-		logger.Error(
+		ctxutil.Logger(ctx, logger).Error(
 			"文件状态检查失败",
 			zap.String("file_path", filePath),
-			zap.String(string(ctxutil.TraceIDKey), ctxutil.GetTraceID(ctx)),
 			zap.Error(statErr),
 		)
 		return errors.ErrDownloadFileFailed.WithCause(statErr)
@@ -104,3 +101,16 @@ func DownloadFile(ctx *gin.Context, logger *zap.Logger, filePath, rename string)
 	ctx.File(filePath)
 	return nil
 }
+
+// StreamFile 将读取器中的内容作为附件发送, 支持Seek时处理Range请求
+func StreamFile(ctx *gin.Context, r io.Reader, size int64, rename string, modTime time.Time) {
+	ctx.Header("Content-Type", "application/octet-stream")
+	ctx.Header("Content-Disposition", "attachment; filename="+url.QueryEscape(rename))
+	ctx.Header("Content-Transfer-Encoding", "binary")
+
+	if rs, ok := r.(io.ReadSeeker); ok {
+		http.ServeContent(ctx.Writer, ctx.Request, rename, modTime, rs)
+		return
+	}
+	ctx.DataFromReader(http.StatusOK, size, "application/octet-stream", r, nil)
+}
@@ -1,19 +1,56 @@
 package common
 
 import (
+	"context"
+	"sync"
+
 	"github.com/casbin/casbin/v2"
 	"github.com/robfig/cron/v3"
 	"gorm.io/gorm"
 
 	"gin-artweb/internal/shared/auth"
 	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/events"
+	"gin-artweb/internal/shared/rpc"
 )
 
+// ShutdownHook 服务关闭时执行的清理函数
+// 在HTTP服务停止之后、计划任务和数据库等资源释放之前执行
+type ShutdownHook func(ctx context.Context)
+
 type Initialize struct {
 	Conf      *config.SystemConf
 	DB        *gorm.DB
 	DBTimeout *config.DBTimeout
+	Tx        *database.TxManager
 	Enforcer  *casbin.Enforcer
 	Crontab   *cron.Cron
 	JwtConf   *auth.JWTConfig
+	Events    *events.Bus
+	Outbox    *events.Outbox
+	DBHealth  *database.Health
+	RPC       *rpc.Server // gRPC服务器, 未启用gRPC端口时为nil, 各模块加载路由时注册服务
+
+	hookMu sync.Mutex
+	hooks  []ShutdownHook
+}
+
+// OnShutdown 注册服务关闭时执行的清理函数
+func (i *Initialize) OnShutdown(hook ShutdownHook) {
+	i.hookMu.Lock()
+	defer i.hookMu.Unlock()
+	i.hooks = append(i.hooks, hook)
+}
+
+// Shutdown 按注册的相反顺序执行清理函数
+func (i *Initialize) Shutdown(ctx context.Context) {
+	i.hookMu.Lock()
+	hooks := i.hooks
+	i.hooks = nil
+	i.hookMu.Unlock()
+
+	for idx := len(hooks) - 1; idx >= 0; idx-- {
+		hooks[idx](ctx)
+	}
 }
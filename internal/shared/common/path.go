@@ -54,6 +54,15 @@ func GetOesColonyConfigDir(colonyNum string) string {
 	return filepath.Join(config.StorageDir, "oes", "config", colonyNum)
 }
 
+// GetOesColonyCounterDir 返回counter_fetch.sh从柜台获取的数据文件的本地目录
+func GetOesColonyCounterDir(colonyNum string) string {
+	return filepath.Join(config.StorageDir, "oes", "counter", colonyNum, "data", "broker")
+}
+
+func GetOesColonyExportPath(filename string) string {
+	return filepath.Join(config.StorageDir, "oes", "export", filename)
+}
+
 // readUint32FromFile 从指定文件读取单个数字并转换为uint32
 func ReadUint32FromFile(filePath string) (uint32, error) {
 	if _, err := os.Stat(filePath); err != nil {
@@ -0,0 +1,11 @@
+package config
+
+// AgentConfig 主机代理配置
+type AgentConfig struct {
+	Enable       bool   `yaml:"enable"`        // 是否开放代理注册和心跳接口
+	TokenEnv     string `yaml:"token_env"`     // 保存代理令牌的环境变量, 默认AGENT_TOKEN
+	StaleSeconds int    `yaml:"stale_seconds"` // 超过该时间未上报心跳视为失联(秒), 默认180
+	SSHPort      uint16 `yaml:"ssh_port"`      // 代理未上报SSH端口时使用的端口, 默认22
+	SSHUser      string `yaml:"ssh_user"`      // 代理未上报SSH用户时使用的用户, 默认root
+	Label        string `yaml:"label"`         // 自动创建的主机使用的标签, 默认agent
+}
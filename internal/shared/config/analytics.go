@@ -0,0 +1,10 @@
+package config
+
+// AnalyticsConfig 平台使用统计配置
+type AnalyticsConfig struct {
+	Enable        bool   `yaml:"enable"`         // 是否启用使用统计
+	IdentityMode  string `yaml:"identity_mode"`  // 用户身份记录方式(none:不记录, hash:哈希匿名, plain:明文)
+	HashSalt      string `yaml:"hash_salt"`      // 哈希匿名时使用的盐值
+	RetentionDays int    `yaml:"retention_days"` // 统计事件保留天数
+	BufferSize    int    `yaml:"buffer_size"`    // 异步写入缓冲区大小
+}
@@ -0,0 +1,8 @@
+package config
+
+// BackupConfig 数据备份配置
+type BackupConfig struct {
+	Cron      string `yaml:"cron"`       // 定时备份的cron表达式, 为空时只能手动备份
+	Keep      int    `yaml:"keep"`       // 保留的成功备份数量, 超过时删除最早的备份, 0表示全部保留
+	BatchSize int    `yaml:"batch_size"` // 导出和导入数据时每批的记录数, 默认1000
+}
@@ -0,0 +1,16 @@
+package config
+
+import "time"
+
+// BreakerConfig 下游调用熔断配置, 数据库、每个SSH主机和每个Prometheus数据源分别熔断
+type BreakerConfig struct {
+	Enable           bool `yaml:"enable"`             // 是否启用熔断
+	FailureThreshold int  `yaml:"failure_threshold"`  // 连续失败多少次后熔断
+	OpenSeconds      int  `yaml:"open_seconds"`       // 熔断持续时间(秒), 之后放行试探请求
+	HalfOpenRequests int  `yaml:"half_open_requests"` // 试探请求数, 全部成功后恢复
+}
+
+// OpenTimeout 熔断持续时间
+func (c *BreakerConfig) OpenTimeout() time.Duration {
+	return time.Duration(c.OpenSeconds) * time.Second
+}
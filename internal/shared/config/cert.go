@@ -0,0 +1,8 @@
+package config
+
+// CertMonitorConfig 证书过期监控配置
+type CertMonitorConfig struct {
+	Cron       string `yaml:"cron"`       // 检查频率(cron表达式), 为空时不定时检查
+	Thresholds []int  `yaml:"thresholds"` // 剩余天数告警阈值, 每个阈值只告警一次, 证书更新后重新计算
+	Timeout    int    `yaml:"timeout"`    // 连接TLS服务的超时时间(秒)
+}
@@ -6,8 +6,6 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
-
-	"github.com/goccy/go-yaml"
 )
 
 type PathConf struct {
@@ -56,38 +54,79 @@ var (
 
 // SystemConf 系统配置结构体
 type SystemConf struct {
-	Server   *ServerConfig   `yaml:"server"`
-	Database *DBConf         `yaml:"database"`
-	Log      *LogConfig      `yaml:"log"`
-	CORS     *AllowConfig    `yaml:"cors"`
-	Security *SecurityConfig `yaml:"security"`
-	SSH      *SSHConfig      `yaml:"ssh"`
-	Upload   *UploadConfig   `yaml:"upload"`
+	Server       *ServerConfig       `yaml:"server"`
+	Database     *DBConf             `yaml:"database"`
+	Log          *LogConfig          `yaml:"log"`
+	CORS         *AllowConfig        `yaml:"cors"`
+	Security     *SecurityConfig     `yaml:"security"`
+	SSH          *SSHConfig          `yaml:"ssh"`
+	Upload       *UploadConfig       `yaml:"upload"`
+	Analytics    *AnalyticsConfig    `yaml:"analytics"`
+	Monitor      *MonitorConfig      `yaml:"monitor"`
+	Deploy       *DeployConfig       `yaml:"deploy"`
+	Jobs         *JobsConfig         `yaml:"jobs"`
+	Storage      *StorageConfig      `yaml:"storage"`
+	Retention    *RetentionConfig    `yaml:"retention"`
+	Report       *ReportConfig       `yaml:"report"`
+	Webhook      *WebhookConfig      `yaml:"webhook"`
+	Events       *EventsConfig       `yaml:"events"`
+	Terminal     *TerminalConfig     `yaml:"terminal"`
+	Agent        *AgentConfig        `yaml:"agent"`
+	Watchdog     *WatchdogConfig     `yaml:"watchdog"`
+	Drift        *DriftConfig        `yaml:"drift"`
+	Reconcile    *ReconcileConfig    `yaml:"reconcile"`
+	Sla          *SlaConfig          `yaml:"sla"`
+	Connectivity *ConnectivityConfig `yaml:"connectivity"`
+	CertMonitor  *CertMonitorConfig  `yaml:"cert_monitor"`
+	Backup       *BackupConfig       `yaml:"backup"`
+	Preference   *PreferenceConfig   `yaml:"preference"`
+	Breaker      *BreakerConfig      `yaml:"breaker"`
+	Modules      *ModulesConfig      `yaml:"modules"`
+	Stats        *StatsConfig        `yaml:"stats"`
+
+	Env    string        `yaml:"-"` // 运行环境
+	Source *ConfigSource `yaml:"-"` // 配置来源
+	Fake   bool          `yaml:"-"` // 是否以演示模式启动
 }
 
 // NewSystemConf 加载系统配置文件
-func NewSystemConf(configPath string) *SystemConf {
+//
+// 依次加载基础配置文件、运行环境对应的配置文件(如system.prod.yaml)和GIN_ARTWEB__开头的环境变量, 后加载的覆盖先加载的,
+// env为空时不加载环境配置文件
+func NewSystemConf(configPath, env string) *SystemConf {
 	// 检查配置文件是否存在
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
 		log.Fatalf("FATAL: 配置文件不存在,请检查: %s", configPath)
 	}
 
-	// 读取配置文件
-	data, err := os.ReadFile(configPath)
+	conf, err := loadLayeredConfig(configPath, env, os.Environ())
 	if err != nil {
-		log.Fatalf("FATAL: 读取配置文件失败: %v", err)
+		log.Fatalf("FATAL: %v", err)
 	}
-
-	conf := &SystemConf{}
-
-	// 解析YAML配置
-	if err := yaml.Unmarshal(data, conf); err != nil {
-		log.Fatalf("FATAL: 配置文件解析失败: %v", err)
+	if err := conf.Validate(env); err != nil {
+		log.Fatalf("FATAL: 配置校验失败: %v", err)
 	}
 
 	if conf.Database.Type == "sqlite" && conf.Database.Dns == "file::memory:" && !filepath.IsAbs(conf.Database.Dns) {
 		conf.Database.Dns = filepath.Join(BaseDir, conf.Database.Dns)
 	}
 
+	// 未配置部署模式时按标准部署处理
+	if conf.Deploy == nil {
+		conf.Deploy = &DeployConfig{Mode: DeployModeStandard}
+	}
+	if err := conf.Deploy.Validate(conf.Database); err != nil {
+		log.Fatalf("FATAL: 部署模式配置错误: %v", err)
+	}
+
+	if conf.Jobs == nil {
+		conf.Jobs = &JobsConfig{}
+	}
+
+	// 未配置文件存储时使用本地磁盘
+	if conf.Storage == nil {
+		conf.Storage = &StorageConfig{Type: "local"}
+	}
+
 	return conf
 }
@@ -0,0 +1,9 @@
+package config
+
+// ConnectivityConfig oes集群组件之间的网络链路探测配置
+type ConnectivityConfig struct {
+	Interval      int    `yaml:"interval"`       // 探测周期(秒), 0表示不定时探测
+	PreOpenCron   string `yaml:"pre_open_cron"`  // 开盘前检查时间(cron表达式), 交易日对仍不可达的链路再次告警
+	Concurrency   int    `yaml:"concurrency"`    // 同时探测的链路数
+	RetentionDays int    `yaml:"retention_days"` // 探测记录保留天数
+}
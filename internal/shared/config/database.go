@@ -14,6 +14,27 @@ type DBConf struct {
 	ReadTimeout     int    `yaml:"read_timeout" json:"read_timeout"`             // 查询单条数据超时
 	WriteTimeout    int    `yaml:"write_timeout" json:"write_timeout"`           // 写操作超时
 	ListTimeout     int    `yaml:"list_timeout" json:"list_timeout"`             // 查询列表超时
+	SlowThreshold   int    `yaml:"slow_threshold" json:"slow_threshold"`         // 慢查询阈值(毫秒), 0表示不记录
+
+	Retry  DBRetryConfig  `yaml:"retry" json:"retry"`   // 启动时连接数据库的重试配置
+	Outage DBOutageConfig `yaml:"outage" json:"outage"` // 数据库不可用时的降级配置
+}
+
+// DBRetryConfig 启动时连接数据库失败的重试配置, 重试间隔从initial_interval开始每次翻倍, 不超过max_interval
+type DBRetryConfig struct {
+	Attempts        int `yaml:"attempts" json:"attempts"`                 // 最多尝试次数, 小于等于1时不重试
+	InitialInterval int `yaml:"initial_interval" json:"initial_interval"` // 首次重试间隔(秒)
+	MaxInterval     int `yaml:"max_interval" json:"max_interval"`         // 最大重试间隔(秒)
+}
+
+// DBOutageConfig 数据库不可用时的降级配置
+//
+// 定时探测数据库连接, 探测失败后进入降级状态: 写操作直接返回数据库不可用,
+// cache_routes中的查询接口返回最近一次成功的响应, 探测成功后自动恢复
+type DBOutageConfig struct {
+	ProbeInterval int      `yaml:"probe_interval" json:"probe_interval"` // 探测间隔(秒), 小于等于0时不探测, 也不会进入降级状态
+	CacheSeconds  int      `yaml:"cache_seconds" json:"cache_seconds"`   // 查询接口响应的缓存时间(秒)
+	CacheRoutes   []string `yaml:"cache_routes" json:"cache_routes"`     // 降级时使用缓存响应的GET接口路径前缀
 }
 
 // DBTimeout 数据库操作超时参数
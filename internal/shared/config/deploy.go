@@ -0,0 +1,77 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+)
+
+// 部署模式
+const (
+	DeployModeStandard = "standard" // 标准部署, 支持多主机集群
+	DeployModeEmbedded = "embedded" // 内嵌部署, 单主机离线安装
+)
+
+// 可按部署模式启停的功能
+const (
+	FeatureRemoteHost  = "remote_host"  // 通过ssh管理本机以外的主机
+	FeatureMonitorSync = "monitor_sync" // 定时从Prometheus同步mon节点健康状态
+)
+
+// AllFeatures 可按部署模式启停的全部功能
+var AllFeatures = []string{FeatureRemoteHost, FeatureMonitorSync}
+
+// 内嵌部署时默认禁用的集群功能
+var embeddedDisabledFeatures = []string{FeatureRemoteHost, FeatureMonitorSync}
+
+// DeployConfig 部署模式配置
+type DeployConfig struct {
+	Mode             string   `yaml:"mode"`              // 部署模式(standard:标准部署, embedded:单主机内嵌部署)
+	DisabledFeatures []string `yaml:"disabled_features"` // 额外禁用的功能
+}
+
+// IsEmbedded 是否为单主机内嵌部署
+func (c *DeployConfig) IsEmbedded() bool {
+	return c.Mode == DeployModeEmbedded
+}
+
+// FeatureEnabled 判断功能在当前部署模式下是否可用
+func (c *DeployConfig) FeatureEnabled(feature string) bool {
+	if slices.Contains(c.DisabledFeatures, feature) {
+		return false
+	}
+	if c.IsEmbedded() && slices.Contains(embeddedDisabledFeatures, feature) {
+		return false
+	}
+	return true
+}
+
+// Features 返回全部功能的启用状态
+func (c *DeployConfig) Features() map[string]bool {
+	features := make(map[string]bool, len(AllFeatures))
+	for _, f := range AllFeatures {
+		features[f] = c.FeatureEnabled(f)
+	}
+	return features
+}
+
+// Validate 校验部署模式与其他配置是否匹配
+// 内嵌部署只支持sqlite数据库, 确保安装包不依赖外部服务
+func (c *DeployConfig) Validate(db *DBConf) error {
+	switch c.Mode {
+	case "":
+		c.Mode = DeployModeStandard
+	case DeployModeStandard:
+	case DeployModeEmbedded:
+		if db.Type != "sqlite" {
+			return fmt.Errorf("内嵌部署模式只支持sqlite数据库, 当前为: %s", db.Type)
+		}
+	default:
+		return fmt.Errorf("不支持的部署模式: %s", c.Mode)
+	}
+	for _, f := range c.DisabledFeatures {
+		if !slices.Contains(AllFeatures, f) {
+			return fmt.Errorf("未知的功能名称: %s", f)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,8 @@
+package config
+
+// DriftConfig oes集群配置漂移检测配置
+type DriftConfig struct {
+	Enable      bool   `yaml:"enable"`       // 是否定时检测
+	Cron        string `yaml:"cron"`         // 检测时间(cron表达式)
+	VersionFile string `yaml:"version_file"` // 节点上记录程序包版本的文件路径(配置模板语法), 为空时不检测程序包版本
+}
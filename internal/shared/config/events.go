@@ -0,0 +1,8 @@
+package config
+
+// EventsConfig 事件发件箱配置
+type EventsConfig struct {
+	Interval    int `yaml:"interval"`     // 检查待发布事件的间隔(秒)
+	MaxAttempts int `yaml:"max_attempts"` // 最大发布次数, 超过后标记为失败
+	BatchSize   int `yaml:"batch_size"`   // 每次最多发布的事件数
+}
@@ -0,0 +1,43 @@
+package config
+
+// JobsConfig 脚本执行配置
+type JobsConfig struct {
+	ShutdownWait    int                   `yaml:"shutdown_wait"`     // 服务关闭时等待执行中脚本完成的最长时间(秒), 0表示直接中断
+	ResumeOnStartup bool                  `yaml:"resume_on_startup"` // 启动时是否续跑被中断的可续跑脚本
+	MaxResume       int                   `yaml:"max_resume"`        // 同一任务最多续跑的次数
+	Validate        *ScriptValidateConfig `yaml:"validate"`          // 脚本检查
+	Isolation       *IsolationConfig      `yaml:"isolation"`         // 隔离执行, 为空时不允许脚本和计划任务配置隔离执行
+	Artifacts       ArtifactConfig        `yaml:"artifacts"`         // 执行产物
+}
+
+// ArtifactConfig 执行产物配置
+//
+// 脚本执行结束后在工作目录中按脚本配置的匹配规则收集文件, 保存到程序包使用的文件存储,
+// 超出大小或数量限制的文件不收集
+type ArtifactConfig struct {
+	MaxFileSize  int `yaml:"max_file_size"`  // 单个产物文件的大小上限(MB), 0表示使用默认的100MB
+	MaxTotalSize int `yaml:"max_total_size"` // 单次执行产物的总大小上限(MB), 0表示使用默认的500MB
+	MaxFiles     int `yaml:"max_files"`      // 单次执行最多收集的文件数, 0表示使用默认的100个
+	KeepDays     int `yaml:"keep_days"`      // 产物保留天数, 0表示不清理
+}
+
+// IsolationConfig 脚本隔离执行配置
+//
+// 脚本和计划任务可以配置在容器中执行, 或在限制CPU、内存的cgroup中以专用用户执行,
+// 超出内存等资源限制被终止时执行记录标记对应的失败原因
+type IsolationConfig struct {
+	Runtime      string   `yaml:"runtime"`       // 容器运行时命令, 支持docker、podman, 默认docker
+	DefaultImage string   `yaml:"default_image"` // 容器方式未指定镜像时使用的镜像
+	Network      string   `yaml:"network"`       // 容器使用的网络, 默认none禁用网络
+	CgroupRoot   string   `yaml:"cgroup_root"`   // cgroup方式创建控制组的父目录, 需要cgroup v2且服务有写权限
+	DefaultUser  string   `yaml:"default_user"`  // 未指定执行用户时使用的专用用户, 为空时容器使用镜像的默认用户, cgroup使用服务的用户
+	AllowedUsers []string `yaml:"allowed_users"` // 允许脚本和计划任务指定的执行用户, 默认用户总是允许
+}
+
+// ScriptValidateConfig 脚本检查配置
+type ScriptValidateConfig struct {
+	Timeout    int      `yaml:"timeout"`    // 单项检查和试运行的超时时间(秒), 0表示使用默认的10秒
+	Shellcheck bool     `yaml:"shellcheck"` // 安装了shellcheck时是否使用shellcheck检查shell脚本
+	Sandbox    []string `yaml:"sandbox"`    // 试运行使用的沙箱命令, {script}替换为脚本路径, 为空时不允许试运行
+	MaxOutput  int      `yaml:"max_output"` // 试运行最多返回的输出(KB), 0表示使用默认的64KB
+}
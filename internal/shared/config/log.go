@@ -8,4 +8,44 @@ type LogConfig struct {
 	MaxBackups int    `mapstructure:"max_backups" json:"max_backups" yaml:"max_backups"`
 	LocalTime  bool   `mapstructure:"local_time" json:"local_time" yaml:"local_time"`
 	Compress   bool   `mapstructure:"compress" json:"compress" yaml:"compress"`
+	Format     string `mapstructure:"format" json:"format" yaml:"format"` // 日志格式(console/json/ecs), 默认json
+
+	Query LogQueryConfig `mapstructure:"query" json:"query" yaml:"query"`
+
+	// 按日志记录器(server/service/biz/data/cron)覆盖日志级别, 未配置的使用Level
+	Levels map[string]string `mapstructure:"levels" json:"levels" yaml:"levels"`
+
+	// 按日志记录器配置采样, 未配置的不采样
+	Sampling map[string]LogSamplingConfig `mapstructure:"sampling" json:"sampling" yaml:"sampling"`
+}
+
+// LogSamplingConfig 日志采样配置
+//
+// 每个周期内相同级别和内容的日志先完整记录Initial条, 之后每Thereafter条记录一条
+type LogSamplingConfig struct {
+	Tick       int `mapstructure:"tick" json:"tick" yaml:"tick"`                   // 采样周期(秒), 默认1秒
+	Initial    int `mapstructure:"initial" json:"initial" yaml:"initial"`          // 每个周期内完整记录的条数
+	Thereafter int `mapstructure:"thereafter" json:"thereafter" yaml:"thereafter"` // 超出后每多少条记录一条, 为0时丢弃超出的日志
+}
+
+// 日志格式
+const (
+	LogFormatConsole = "console" // 便于阅读的文本格式
+	LogFormatJSON    = "json"    // JSON格式
+	LogFormatECS     = "ecs"     // Elastic Common Schema格式的JSON
+)
+
+// 日志查询后端
+const (
+	LogQueryBackendFile = "file" // 读取本地轮转的日志文件
+	LogQueryBackendLoki = "loki" // 查询Loki
+)
+
+// LogQueryConfig 日志查询配置
+type LogQueryConfig struct {
+	Backend   string `mapstructure:"backend" json:"backend" yaml:"backend"`          // 查询后端(file/loki), 默认file
+	LokiURL   string `mapstructure:"loki_url" json:"loki_url" yaml:"loki_url"`       // Loki服务地址
+	LokiLabel string `mapstructure:"loki_label" json:"loki_label" yaml:"loki_label"` // 区分日志源的流标签名, 默认job
+	Timeout   int    `mapstructure:"timeout" json:"timeout" yaml:"timeout"`          // 查询超时时间(秒)
+	MaxLines  int    `mapstructure:"max_lines" json:"max_lines" yaml:"max_lines"`    // 单次查询最多返回的条数
 }
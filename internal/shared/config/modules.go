@@ -0,0 +1,6 @@
+package config
+
+// ModulesConfig 业务模块配置
+type ModulesConfig struct {
+	Disabled []string `yaml:"disabled"` // 停用的模块, 可选mon、mds、oes、jobs、resource, system和customer不能停用
+}
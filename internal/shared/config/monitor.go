@@ -0,0 +1,12 @@
+package config
+
+// MonitorConfig 监控数据源配置
+type MonitorConfig struct {
+	QueryTimeout       int    `yaml:"query_timeout"`        // Prometheus查询超时时间(秒)
+	HealthSyncInterval int    `yaml:"health_sync_interval"` // 健康状态同步间隔(秒), 0表示不同步
+	MetricInterval     int    `yaml:"metric_interval"`      // 通过SSH采集mon节点主机指标的间隔(秒), 0表示只保存主机代理上报的指标
+	MetricDiskPath     string `yaml:"metric_disk_path"`     // 统计磁盘使用率的挂载点, 默认/
+	MetricRawHours     int    `yaml:"metric_raw_hours"`     // 原始指标保留时间(小时), 默认24
+	Metric5MinDays     int    `yaml:"metric_5min_days"`     // 5分钟平均值保留时间(天), 默认7
+	MetricHourDays     int    `yaml:"metric_hour_days"`     // 1小时平均值保留时间(天), 默认90
+}
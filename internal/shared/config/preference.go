@@ -0,0 +1,7 @@
+package config
+
+// PreferenceConfig 用户偏好配置
+type PreferenceConfig struct {
+	MaxSize       int `yaml:"max_size"`       // 每个命名空间的偏好内容大小上限(字节), 默认16384
+	MaxNamespaces int `yaml:"max_namespaces"` // 每个用户的命名空间数量上限, 默认50
+}
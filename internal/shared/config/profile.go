@@ -0,0 +1,395 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-yaml"
+)
+
+// 运行环境, 通过-env参数指定
+const (
+	EnvDev     = "dev"
+	EnvStaging = "staging"
+	EnvProd    = "prod"
+)
+
+// EnvOverridePrefix 覆盖配置项的环境变量前缀, 层级之间用__分隔
+//
+// 例如GIN_ARTWEB__SERVER__PORT=8080覆盖server.port, GIN_ARTWEB__DATABASE__LOG_SQL=false覆盖database.log_sql,
+// 值按配置项的类型解析, 字符串配置项保留原始值, 字符串列表可写为[a, b]或a,b
+const EnvOverridePrefix = "GIN_ARTWEB__"
+
+// maskedValue 脱敏后的配置值
+const maskedValue = "******"
+
+// secretKeyPattern 需要脱敏的配置项名称
+var secretKeyPattern = regexp.MustCompile(`(?i)(password|secret|salt|dns|dsn|token$|access_key|private_key)`)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// ConfigSource 配置的来源, 按加载顺序排列, 后加载的覆盖先加载的
+type ConfigSource struct {
+	Files     []string // 加载的配置文件
+	Overrides []string // 环境变量覆盖的配置项, 如server.port
+}
+
+// loadLayeredConfig 依次加载基础配置文件、环境配置文件和环境变量覆盖, 返回合并后的配置
+//
+// 环境配置文件与基础配置文件在同一目录下, 名称为"基础文件名.{env}.扩展名", 如system.prod.yaml, 不存在时跳过.
+// 配置文件按YAML合并后解析, 环境变量在解析后按配置项的类型写入, 不经过YAML解析
+func loadLayeredConfig(configPath, env string, environ []string) (*SystemConf, error) {
+	source := &ConfigSource{}
+	merged, err := readConfigMap(configPath)
+	if err != nil {
+		return nil, err
+	}
+	source.Files = append(source.Files, configPath)
+
+	if env != "" {
+		ext := filepath.Ext(configPath)
+		envPath := strings.TrimSuffix(configPath, ext) + "." + env + ext
+		if _, err := os.Stat(envPath); err == nil {
+			overlay, err := readConfigMap(envPath)
+			if err != nil {
+				return nil, err
+			}
+			mergeConfigMap(merged, overlay)
+			source.Files = append(source.Files, envPath)
+		} else if !os.IsNotExist(err) {
+			return nil, fmt.Errorf("读取环境配置文件失败: %w", err)
+		}
+	}
+
+	// 合并的值都来自YAML配置文件, 重新编码时字符串会按需加引号, 不会改变类型
+	data, err := yaml.Marshal(merged)
+	if err != nil {
+		return nil, fmt.Errorf("合并配置失败: %w", err)
+	}
+	conf := &SystemConf{Env: env, Source: source}
+	if err := yaml.Unmarshal(data, conf); err != nil {
+		return nil, fmt.Errorf("配置文件解析失败: %w", err)
+	}
+
+	if source.Overrides, err = applyEnvOverrides(conf, environ); err != nil {
+		return nil, err
+	}
+	return conf, nil
+}
+
+func readConfigMap(path string) (map[string]any, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取配置文件失败: %w", err)
+	}
+	m := map[string]any{}
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("配置文件%s解析失败: %w", filepath.Base(path), err)
+	}
+	if m == nil {
+		m = map[string]any{}
+	}
+	return m, nil
+}
+
+// mergeConfigMap 将src深度合并到dst, 同名的字典逐项合并, 其他值(包括列表)整体替换
+func mergeConfigMap(dst, src map[string]any) {
+	for k, v := range src {
+		if sm, ok := v.(map[string]any); ok {
+			if dm, ok := dst[k].(map[string]any); ok {
+				mergeConfigMap(dm, sm)
+				continue
+			}
+		}
+		dst[k] = v
+	}
+}
+
+// applyEnvOverrides 使用EnvOverridePrefix开头的环境变量覆盖配置项, 返回覆盖的配置项路径
+//
+// 按yaml标签查找配置项, 配置项不存在或值不能转换为配置项的类型时返回错误
+func applyEnvOverrides(conf *SystemConf, environ []string) ([]string, error) {
+	var keys []string
+	for _, kv := range environ {
+		name, value, ok := strings.Cut(kv, "=")
+		if !ok || !strings.HasPrefix(name, EnvOverridePrefix) {
+			continue
+		}
+		segments := strings.Split(strings.ToLower(strings.TrimPrefix(name, EnvOverridePrefix)), "__")
+		if slices.Contains(segments, "") {
+			continue
+		}
+
+		key := strings.Join(segments, ".")
+		if err := setConfigValue(reflect.ValueOf(conf).Elem(), segments, value); err != nil {
+			return nil, fmt.Errorf("环境变量%s覆盖配置项%s失败: %w", name, key, err)
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// setConfigValue 沿segments查找配置项并写入value, 未初始化的结构体指针和字典按需创建
+func setConfigValue(v reflect.Value, segments []string, value string) error {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+	if len(segments) == 0 {
+		return decodeEnvValue(v, value)
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		for i := range v.NumField() {
+			f := v.Type().Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			name, _, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+			if name == "" {
+				name = strings.ToLower(f.Name)
+			}
+			if name == segments[0] {
+				return setConfigValue(v.Field(i), segments[1:], value)
+			}
+		}
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			break
+		}
+		if v.IsNil() {
+			v.Set(reflect.MakeMap(v.Type()))
+		}
+		key := reflect.ValueOf(segments[0]).Convert(v.Type().Key())
+		elem := reflect.New(v.Type().Elem()).Elem()
+		if cur := v.MapIndex(key); cur.IsValid() {
+			elem.Set(cur)
+		}
+		if err := setConfigValue(elem, segments[1:], value); err != nil {
+			return err
+		}
+		v.SetMapIndex(key, elem)
+		return nil
+	}
+	return fmt.Errorf("配置项不存在")
+}
+
+// decodeEnvValue 按配置项的类型解析环境变量的值
+//
+// 字符串保留原始值, 数字只按十进制解析, 字符串列表按逗号分隔, 其他复合类型按YAML解析
+func decodeEnvValue(v reflect.Value, value string) error {
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(value, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(n)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.String {
+			items := parseEnvList(value)
+			list := reflect.MakeSlice(v.Type(), len(items), len(items))
+			for i, item := range items {
+				list.Index(i).SetString(item)
+			}
+			v.Set(list)
+			return nil
+		}
+		fallthrough
+	default:
+		ptr := reflect.New(v.Type())
+		if err := yaml.Unmarshal([]byte(value), ptr.Interface()); err != nil {
+			return err
+		}
+		v.Set(ptr.Elem())
+	}
+	return nil
+}
+
+// parseEnvList 解析[a, b]或a,b格式的字符串列表, 去掉每一项两端的空白和引号
+func parseEnvList(value string) []string {
+	value = strings.TrimSpace(value)
+	if strings.HasPrefix(value, "[") && strings.HasSuffix(value, "]") {
+		value = strings.TrimSpace(value[1 : len(value)-1])
+	}
+	if value == "" {
+		return []string{}
+	}
+	items := strings.Split(value, ",")
+	for i, item := range items {
+		item = strings.TrimSpace(item)
+		if len(item) >= 2 && (item[0] == '"' || item[0] == '\'') && item[len(item)-1] == item[0] {
+			item = item[1 : len(item)-1]
+		}
+		items[i] = item
+	}
+	return items
+}
+
+// Validate 检查运行环境要求的配置项, env为空时只做基础检查
+func (c *SystemConf) Validate(env string) error {
+	switch env {
+	case "", EnvDev, EnvStaging, EnvProd:
+	default:
+		return fmt.Errorf("不支持的运行环境: %s, 可选dev/staging/prod", env)
+	}
+
+	if c.Server == nil {
+		return fmt.Errorf("缺少server配置")
+	}
+	if c.Server.Port <= 0 || c.Server.Port > 65535 {
+		return fmt.Errorf("server.port必须在1-65535之间")
+	}
+	if c.Server.SSL.Enable && (c.Server.SSL.CrtPath == "" || c.Server.SSL.KeyPath == "") {
+		return fmt.Errorf("开启SSL时必须配置server.ssl.crt_path和server.ssl.key_path")
+	}
+	if admin := c.Server.Admin; admin.Enable {
+		if admin.Port <= 0 || admin.Port > 65535 || admin.Port == c.Server.Port || admin.Port == c.Server.SSL.RedirectPort {
+			return fmt.Errorf("server.admin.port必须在1-65535之间且不能与server.port、server.ssl.redirect_port相同")
+		}
+	}
+	if g := c.Server.GRPC; g.Enable {
+		if g.Port <= 0 || g.Port > 65535 || g.Port == c.Server.Port || g.Port == c.Server.SSL.RedirectPort ||
+			(c.Server.Admin.Enable && g.Port == c.Server.Admin.Port) {
+			return fmt.Errorf("server.grpc.port必须在1-65535之间且不能与server.port、server.ssl.redirect_port、server.admin.port相同")
+		}
+		if (g.CrtPath == "") != (g.KeyPath == "") {
+			return fmt.Errorf("server.grpc.crt_path和server.grpc.key_path必须同时配置")
+		}
+		if g.ClientCAPath != "" && g.CrtPath == "" {
+			return fmt.Errorf("配置server.grpc.client_ca_path时必须同时配置server.grpc.crt_path和server.grpc.key_path")
+		}
+	}
+	for _, enc := range c.Server.Compress.Encodings {
+		if enc != "zstd" && enc != "gzip" && enc != "deflate" {
+			return fmt.Errorf("server.compress.encodings只支持zstd、gzip、deflate")
+		}
+	}
+	if mode := c.Server.Unix.Mode; mode != "" {
+		if _, err := strconv.ParseUint(mode, 8, 32); err != nil {
+			return fmt.Errorf("server.unix.mode必须是八进制的文件权限, 如0660")
+		}
+	}
+	if c.Server.SSL.ReloadInterval < 0 {
+		return fmt.Errorf("server.ssl.reload_interval不能小于0")
+	}
+	if p := c.Server.SSL.RedirectPort; p != 0 && (p < 0 || p > 65535 || p == c.Server.Port) {
+		return fmt.Errorf("server.ssl.redirect_port必须在1-65535之间且不能与server.port相同")
+	}
+	if c.Database == nil || c.Database.Type == "" || c.Database.Dns == "" {
+		return fmt.Errorf("必须配置database.type和database.dns")
+	}
+	if c.Log == nil {
+		return fmt.Errorf("缺少log配置")
+	}
+	if c.Security == nil {
+		return fmt.Errorf("缺少security配置")
+	}
+	switch c.Security.Password.Hash.Algorithm {
+	case "", "bcrypt", "argon2id", "scrypt":
+	default:
+		return fmt.Errorf("security.password.hash.algorithm只支持bcrypt、argon2id、scrypt")
+	}
+	if cost := c.Security.Password.Hash.BcryptCost; cost != 0 && (cost < 4 || cost > 31) {
+		return fmt.Errorf("security.password.hash.bcrypt_cost必须在4-31之间")
+	}
+	if ln := c.Security.Password.Hash.ScryptLogN; ln > 30 {
+		return fmt.Errorf("security.password.hash.scrypt_log_n不能大于30")
+	}
+	if jobs := c.Jobs; jobs != nil {
+		a := jobs.Artifacts
+		if a.MaxFileSize < 0 || a.MaxTotalSize < 0 || a.MaxFiles < 0 || a.KeepDays < 0 {
+			return fmt.Errorf("jobs.artifacts的大小、数量和保留天数不能小于0")
+		}
+	}
+
+	if env == EnvStaging || env == EnvProd {
+		if strings.Contains(c.Database.Dns, ":memory:") {
+			return fmt.Errorf("%s环境不能使用内存数据库", env)
+		}
+	}
+	if env == EnvProd {
+		if c.Server.Swagger {
+			return fmt.Errorf("prod环境必须关闭server.swagger")
+		}
+		if c.Database.LogSQL {
+			return fmt.Errorf("prod环境必须关闭database.log_sql")
+		}
+	}
+	return nil
+}
+
+// Masked 返回合并后的有效配置, 密码、密钥和数据库连接等敏感配置项已脱敏, 用于启动时记录日志
+func (c *SystemConf) Masked() map[string]any {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return nil
+	}
+	m := map[string]any{}
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return nil
+	}
+	maskConfigMap(m)
+	return m
+}
+
+func maskConfigMap(m map[string]any) {
+	for k, v := range m {
+		switch t := v.(type) {
+		case map[string]any:
+			maskConfigMap(t)
+		case []any:
+			for _, item := range t {
+				if im, ok := item.(map[string]any); ok {
+					maskConfigMap(im)
+				}
+			}
+		case string:
+			// 以_env结尾的配置项是保存密钥的环境变量名, 不需要脱敏
+			if t != "" && secretKeyPattern.MatchString(k) && !strings.HasSuffix(k, "_env") {
+				m[k] = maskedValue
+			}
+		}
+	}
+}
@@ -0,0 +1,265 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testBaseConfig = `
+server:
+  port: 8621
+  compress:
+    encodings: [gzip]
+database:
+  type: sqlite
+  dns: "00123"
+  log_sql: true
+log:
+  levels:
+    http: info
+`
+
+func TestLoadLayeredConfig(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, "system.yaml")
+	require.NoError(t, os.WriteFile(base, []byte(testBaseConfig), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "system.prod.yaml"), []byte("database:\n  log_sql: false\n"), 0o600))
+
+	tests := []struct {
+		name      string
+		env       string
+		environ   []string
+		files     []string
+		overrides []string
+		check     func(t *testing.T, conf *SystemConf)
+	}{
+		{
+			name:  "只有基础配置文件",
+			files: []string{base},
+			check: func(t *testing.T, conf *SystemConf) {
+				assert.Equal(t, 8621, conf.Server.Port)
+				assert.Equal(t, "00123", conf.Database.Dns, "配置文件中加引号的字符串不应该变成数字")
+				assert.True(t, conf.Database.LogSQL)
+			},
+		},
+		{
+			name:  "环境配置文件逐项覆盖",
+			env:   EnvProd,
+			files: []string{base, filepath.Join(dir, "system.prod.yaml")},
+			check: func(t *testing.T, conf *SystemConf) {
+				assert.False(t, conf.Database.LogSQL)
+				assert.Equal(t, "sqlite", conf.Database.Type, "未覆盖的配置项保留基础配置")
+				assert.Equal(t, EnvProd, conf.Env)
+			},
+		},
+		{
+			name:  "环境配置文件不存在时跳过",
+			env:   EnvStaging,
+			files: []string{base},
+		},
+		{
+			name:      "环境变量覆盖环境配置文件",
+			env:       EnvProd,
+			environ:   []string{"GIN_ARTWEB__DATABASE__LOG_SQL=true", "GIN_ARTWEB__SERVER__PORT=9000", "PATH=/usr/bin"},
+			files:     []string{base, filepath.Join(dir, "system.prod.yaml")},
+			overrides: []string{"database.log_sql", "server.port"},
+			check: func(t *testing.T, conf *SystemConf) {
+				assert.True(t, conf.Database.LogSQL)
+				assert.Equal(t, 9000, conf.Server.Port)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf, err := loadLayeredConfig(base, tt.env, tt.environ)
+			require.NoError(t, err)
+			assert.Equal(t, tt.files, conf.Source.Files)
+			assert.Equal(t, tt.overrides, conf.Source.Overrides)
+			if tt.check != nil {
+				tt.check(t, conf)
+			}
+		})
+	}
+
+	_, err := loadLayeredConfig(base, "", []string{"GIN_ARTWEB__SERVER__PORT=abc"})
+	assert.Error(t, err, "环境变量的值不能转换为配置项的类型时返回错误")
+}
+
+func TestApplyEnvOverrides(t *testing.T) {
+	tests := []struct {
+		name  string
+		env   string
+		check func(t *testing.T, conf *SystemConf)
+		err   bool
+	}{
+		{
+			name:  "字符串保留前导零",
+			env:   "GIN_ARTWEB__DATABASE__DNS=00123",
+			check: func(t *testing.T, conf *SystemConf) { assert.Equal(t, "00123", conf.Database.Dns) },
+		},
+		{
+			name:  "字符串不按十六进制解析",
+			env:   "GIN_ARTWEB__DATABASE__DNS=0x1F",
+			check: func(t *testing.T, conf *SystemConf) { assert.Equal(t, "0x1F", conf.Database.Dns) },
+		},
+		{
+			name:  "字符串null和~不视为空值",
+			env:   "GIN_ARTWEB__DATABASE__TYPE=null",
+			check: func(t *testing.T, conf *SystemConf) { assert.Equal(t, "null", conf.Database.Type) },
+		},
+		{
+			name:  "字符串~",
+			env:   "GIN_ARTWEB__DATABASE__DNS=~",
+			check: func(t *testing.T, conf *SystemConf) { assert.Equal(t, "~", conf.Database.Dns) },
+		},
+		{
+			name:  "空字符串",
+			env:   "GIN_ARTWEB__DATABASE__DNS=",
+			check: func(t *testing.T, conf *SystemConf) { assert.Equal(t, "", conf.Database.Dns) },
+		},
+		{
+			name:  "整数",
+			env:   "GIN_ARTWEB__SERVER__PORT=8080",
+			check: func(t *testing.T, conf *SystemConf) { assert.Equal(t, 8080, conf.Server.Port) },
+		},
+		{
+			name: "整数不按八进制解析",
+			env:  "GIN_ARTWEB__SERVER__PORT=0123",
+			check: func(t *testing.T, conf *SystemConf) {
+				assert.Equal(t, 123, conf.Server.Port)
+			},
+		},
+		{
+			name: "整数格式错误",
+			env:  "GIN_ARTWEB__SERVER__PORT=0x1F",
+			err:  true,
+		},
+		{
+			name:  "布尔值",
+			env:   "GIN_ARTWEB__DATABASE__LOG_SQL=true",
+			check: func(t *testing.T, conf *SystemConf) { assert.True(t, conf.Database.LogSQL) },
+		},
+		{
+			name: "布尔值格式错误",
+			env:  "GIN_ARTWEB__DATABASE__LOG_SQL=yes",
+			err:  true,
+		},
+		{
+			name: "字符串列表",
+			env:  "GIN_ARTWEB__SERVER__COMPRESS__ENCODINGS=[zstd, \"gzip\"]",
+			check: func(t *testing.T, conf *SystemConf) {
+				assert.Equal(t, []string{"zstd", "gzip"}, conf.Server.Compress.Encodings)
+			},
+		},
+		{
+			name: "逗号分隔的字符串列表保留原始值",
+			env:  "GIN_ARTWEB__DATABASE__OUTAGE__CACHE_ROUTES=/api/v1/oes,007",
+			check: func(t *testing.T, conf *SystemConf) {
+				assert.Equal(t, []string{"/api/v1/oes", "007"}, conf.Database.Outage.CacheRoutes)
+			},
+		},
+		{
+			name: "字典新增配置项",
+			env:  "GIN_ARTWEB__LOG__LEVELS__GORM=debug",
+			check: func(t *testing.T, conf *SystemConf) {
+				assert.Equal(t, map[string]string{"gorm": "debug"}, conf.Log.Levels)
+			},
+		},
+		{
+			name: "字典中的结构体",
+			env:  "GIN_ARTWEB__LOG__SAMPLING__HTTP__INITIAL=10",
+			check: func(t *testing.T, conf *SystemConf) {
+				assert.Equal(t, 10, conf.Log.Sampling["http"].Initial)
+			},
+		},
+		{
+			name:  "未配置的结构体指针按需创建",
+			env:   "GIN_ARTWEB__BACKUP__CRON=0 2 * * *",
+			check: func(t *testing.T, conf *SystemConf) { assert.Equal(t, "0 2 * * *", conf.Backup.Cron) },
+		},
+		{
+			name: "配置项不存在",
+			env:  "GIN_ARTWEB__SERVER__NOT_EXIST=1",
+			err:  true,
+		},
+		{
+			name: "标量配置项不能继续向下查找",
+			env:  "GIN_ARTWEB__SERVER__PORT__VALUE=1",
+			err:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &SystemConf{}
+			keys, err := applyEnvOverrides(conf, []string{tt.env})
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, keys, 1)
+			tt.check(t, conf)
+		})
+	}
+
+	keys, err := applyEnvOverrides(&SystemConf{}, []string{"GIN_ARTWEB__=1", "GIN_ARTWEB__SERVER____PORT=1", "OTHER=1"})
+	require.NoError(t, err)
+	assert.Empty(t, keys, "跳过空的层级和其他前缀的环境变量")
+}
+
+func TestDecodeEnvValueDuration(t *testing.T) {
+	var timeouts DBTimeout
+	require.NoError(t, setConfigValue(reflect.ValueOf(&timeouts).Elem(), []string{"readtimeout"}, "1m30s"))
+	assert.Equal(t, 90*time.Second, timeouts.ReadTimeout)
+	assert.Error(t, setConfigValue(reflect.ValueOf(&timeouts).Elem(), []string{"readtimeout"}, "90"), "时长必须带单位")
+}
+
+func TestMaskConfigMap(t *testing.T) {
+	tests := []struct {
+		name string
+		in   map[string]any
+		want map[string]any
+	}{
+		{
+			name: "脱敏密码和连接字符串",
+			in:   map[string]any{"password": "p", "dns": "user:pass@tcp(db)/app", "port": 8621},
+			want: map[string]any{"password": maskedValue, "dns": maskedValue, "port": 8621},
+		},
+		{
+			name: "空值不脱敏",
+			in:   map[string]any{"secret": ""},
+			want: map[string]any{"secret": ""},
+		},
+		{
+			name: "保存密钥的环境变量名不脱敏",
+			in:   map[string]any{"secret_env": "APP_SECRET"},
+			want: map[string]any{"secret_env": "APP_SECRET"},
+		},
+		{
+			name: "嵌套字典和列表",
+			in: map[string]any{
+				"webhook": map[string]any{"targets": []any{map[string]any{"url": "http://a", "token": "t"}, "plain"}},
+			},
+			want: map[string]any{
+				"webhook": map[string]any{"targets": []any{map[string]any{"url": "http://a", "token": maskedValue}, "plain"}},
+			},
+		},
+		{
+			name: "名称只在结尾匹配token",
+			in:   map[string]any{"token_ttl": "1h", "access_key": "ak", "private_key": "pk"},
+			want: map[string]any{"token_ttl": "1h", "access_key": maskedValue, "private_key": maskedValue},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			maskConfigMap(tt.in)
+			assert.Equal(t, tt.want, tt.in)
+		})
+	}
+}
@@ -0,0 +1,8 @@
+package config
+
+// ReconcileConfig oes柜台数据对账配置
+type ReconcileConfig struct {
+	Enable    bool   `yaml:"enable"`     // 是否定时对账
+	Cron      string `yaml:"cron"`       // 对账时间(cron表达式)
+	RemoteDir string `yaml:"remote_dir"` // 节点上柜台数据文件的下发目录(配置模板语法)
+}
@@ -0,0 +1,14 @@
+package config
+
+// ReportConfig 报表配置
+type ReportConfig struct {
+	Schedules []ReportSchedule `yaml:"schedules"` // 定时报表
+}
+
+// ReportSchedule 定时报表, 按cron表达式生成上一个周期的报表并保存到报表目录
+type ReportSchedule struct {
+	Name   string `yaml:"name"`   // 报表名称, 用作文件名前缀
+	Cron   string `yaml:"cron"`   // 生成时间(cron表达式)
+	Period string `yaml:"period"` // 报表周期(daily/weekly)
+	Format string `yaml:"format"` // 文件格式(csv/xlsx)
+}
@@ -0,0 +1,17 @@
+package config
+
+// RetentionConfig 数据保留策略配置
+type RetentionConfig struct {
+	Enable    bool              `yaml:"enable"`     // 是否启用过期数据清理
+	Cron      string            `yaml:"cron"`       // 清理任务执行时间(cron表达式)
+	BatchSize int               `yaml:"batch_size"` // 每批删除的行数
+	Policies  []RetentionPolicy `yaml:"policies"`   // 各数据表的保留策略
+}
+
+// RetentionPolicy 单个数据表的保留策略, 同时配置保留天数和保留行数时超出任一限制的记录都会被删除
+type RetentionPolicy struct {
+	Table    string `yaml:"table"`     // 数据表名
+	KeepDays int    `yaml:"keep_days"` // 保留天数, 0表示不按时间清理
+	KeepRows int    `yaml:"keep_rows"` // 保留最近的行数, 0表示不按行数清理
+	Archive  string `yaml:"archive"`   // 删除前归档的文件格式(csv/ndjson), 为空表示不归档
+}
@@ -0,0 +1,277 @@
+package config
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// minRedactLen 参与日志脱敏的最短密钥长度, 过短的值容易误伤正常日志内容
+const minRedactLen = 6
+
+// SecretID 由密钥摘要生成的标识, 同一密钥的标识不变, 不能还原密钥内容
+func SecretID(value []byte) string {
+	sum := sha256.Sum256(value)
+	return hex.EncodeToString(sum[:6])
+}
+
+// Zeroize 将密钥内容清零
+func Zeroize(value []byte) {
+	clear(value)
+}
+
+// Secret 内存中的一个密钥
+//
+// 格式化输出、日志和序列化时只输出密钥标识, 不输出密钥内容
+type Secret struct {
+	name  string
+	id    string
+	value []byte
+}
+
+// Name 密钥名称, 如JWT_ACCESS_SECRET
+func (s *Secret) Name() string {
+	return s.name
+}
+
+// ID 密钥标识
+func (s *Secret) ID() string {
+	return s.id
+}
+
+func (s *Secret) String() string {
+	return fmt.Sprintf("%s(%s)", maskedValue, s.id)
+}
+
+func (s *Secret) GoString() string {
+	return s.String()
+}
+
+func (s *Secret) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+func (s *Secret) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if s == nil {
+		return nil
+	}
+	enc.AddString("name", s.name)
+	enc.AddString("id", s.id)
+	return nil
+}
+
+// SecretManager 管理内存中密钥的生命周期
+//
+// 配置重新加载或密钥轮换后, 被替换和释放的密钥内容立即清零;
+// 登记的密钥不会出现在经过WrapCore包装的日志中, 对外只暴露当前密钥的标识。
+// 只能清零登记时传入的字节切片, 调用方不能再保留其他副本
+type SecretManager struct {
+	mu      sync.RWMutex
+	secrets map[string]*Secret
+}
+
+func NewSecretManager() *SecretManager {
+	return &SecretManager{secrets: make(map[string]*Secret)}
+}
+
+// Track 登记密钥, 返回密钥标识, value为空时等同于Release
+//
+// 同名密钥的内容变化时清零旧的密钥, 传入同一个字节切片时不做处理
+func (m *SecretManager) Track(name string, value []byte) string {
+	if m == nil {
+		return ""
+	}
+	if len(value) == 0 {
+		m.Release(name)
+		return ""
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if old, ok := m.secrets[name]; ok {
+		if &old.value[0] == &value[0] && len(old.value) == len(value) {
+			return old.id
+		}
+		Zeroize(old.value)
+	}
+	s := &Secret{name: name, id: SecretID(value), value: value}
+	m.secrets[name] = s
+	return s.id
+}
+
+// Get 返回登记的密钥
+func (m *SecretManager) Get(name string) (*Secret, bool) {
+	if m == nil {
+		return nil, false
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	s, ok := m.secrets[name]
+	return s, ok
+}
+
+// Release 清零并移除密钥
+func (m *SecretManager) Release(name string) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if s, ok := m.secrets[name]; ok {
+		Zeroize(s.value)
+		delete(m.secrets, name)
+	}
+}
+
+// ActiveIDs 返回当前登记的密钥名称和标识
+func (m *SecretManager) ActiveIDs() map[string]string {
+	ids := map[string]string{}
+	if m == nil {
+		return ids
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for name, s := range m.secrets {
+		ids[name] = s.id
+	}
+	return ids
+}
+
+// Redact 将文本中出现的密钥替换为脱敏值
+func (m *SecretManager) Redact(s string) string {
+	if m == nil || len(s) < minRedactLen {
+		return s
+	}
+	b, changed := m.redact([]byte(s))
+	if !changed {
+		return s
+	}
+	return string(b)
+}
+
+func (m *SecretManager) redact(b []byte) ([]byte, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	changed := false
+	for _, s := range m.secrets {
+		if len(s.value) < minRedactLen || !bytes.Contains(b, s.value) {
+			continue
+		}
+		b = bytes.ReplaceAll(b, s.value, []byte(maskedValue))
+		changed = true
+	}
+	return b, changed
+}
+
+func (m *SecretManager) empty() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.secrets) == 0
+}
+
+// WrapCore 包装日志核心, 写入前将消息和字段中出现的密钥替换为脱敏值
+//
+// 处理字符串、字节串和错误类型的字段, 对象和反射类型的字段需要自行实现脱敏
+func (m *SecretManager) WrapCore(core zapcore.Core) zapcore.Core {
+	if m == nil {
+		return core
+	}
+	return &secretFilterCore{Core: core, secrets: m}
+}
+
+// secretFilterCore 过滤日志中密钥的日志核心
+type secretFilterCore struct {
+	zapcore.Core
+	secrets *SecretManager
+}
+
+func (c *secretFilterCore) With(fields []zapcore.Field) zapcore.Core {
+	return &secretFilterCore{Core: c.Core.With(c.filter(fields)), secrets: c.secrets}
+}
+
+func (c *secretFilterCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *secretFilterCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	if c.secrets.empty() {
+		return c.Core.Write(ent, fields)
+	}
+	ent.Message = c.secrets.Redact(ent.Message)
+	return c.Core.Write(ent, c.filter(fields))
+}
+
+// filter 返回脱敏后的字段, 没有需要脱敏的字段时返回原切片
+func (c *secretFilterCore) filter(fields []zapcore.Field) []zapcore.Field {
+	if c.secrets.empty() {
+		return fields
+	}
+	var out []zapcore.Field
+	for i, f := range fields {
+		nf, changed := c.filterField(f)
+		if !changed {
+			continue
+		}
+		if out == nil {
+			out = slices.Clone(fields)
+		}
+		out[i] = nf
+	}
+	if out == nil {
+		return fields
+	}
+	return out
+}
+
+func (c *secretFilterCore) filterField(f zapcore.Field) (zapcore.Field, bool) {
+	var text string
+	switch f.Type {
+	case zapcore.StringType:
+		text = f.String
+	case zapcore.ByteStringType, zapcore.BinaryType:
+		b, ok := f.Interface.([]byte)
+		if !ok {
+			return f, false
+		}
+		text = string(b)
+	case zapcore.ErrorType:
+		err, ok := f.Interface.(error)
+		if !ok || err == nil {
+			return f, false
+		}
+		text = err.Error()
+	default:
+		return f, false
+	}
+	redacted := c.secrets.Redact(text)
+	if redacted == text {
+		return f, false
+	}
+	return zapcore.Field{Key: f.Key, Type: zapcore.StringType, String: redacted}, true
+}
+
+// TrackSecrets 登记配置和环境变量中的密钥, 重新加载配置后再次调用时替换并清零旧的密钥
+//
+// 环境变量和配置中的字符串无法清零, 这里登记的是用于日志脱敏的副本
+func (c *SystemConf) TrackSecrets(m *SecretManager) {
+	if c.Database != nil {
+		m.Track("database.dns", []byte(c.Database.Dns))
+	}
+	m.Track("S3_ACCESS_KEY", []byte(os.Getenv("S3_ACCESS_KEY")))
+	m.Track("S3_SECRET_KEY", []byte(os.Getenv("S3_SECRET_KEY")))
+	if c.Security != nil && c.Security.OIDC != nil {
+		for _, p := range c.Security.OIDC.Providers {
+			if p.ClientSecretEnv != "" {
+				m.Track(p.ClientSecretEnv, []byte(os.Getenv(p.ClientSecretEnv)))
+			}
+		}
+	}
+}
@@ -14,28 +14,140 @@ type TimestampConfig struct {
 
 // TokenConfig Token配置
 type TokenConfig struct {
-	AccessMinutes  int    `yaml:"access_minutes"`  // Token过期时间(分钟)
-	RefreshMinutes int    `yaml:"refresh_minutes"` // 刷新令牌过期时间(分钟)
-	AccessMethod   string `yaml:"access_method"`   // 访问令牌签名方法
-	RefreshMethod  string `yaml:"refresh_method"`  // 刷新令牌签名方法
+	AccessMinutes      int     `yaml:"access_minutes"`      // Token过期时间(分钟)
+	RefreshMinutes     int     `yaml:"refresh_minutes"`     // 刷新令牌过期时间(分钟)
+	AccessMethod       string  `yaml:"access_method"`       // 访问令牌签名方法
+	RefreshMethod      string  `yaml:"refresh_method"`      // 刷新令牌签名方法
+	RotateCron         string  `yaml:"rotate_cron"`         // 签名密钥自动轮换周期, 为空时不自动轮换
+	RefreshRPS         float64 `yaml:"refresh_rps"`         // 刷新令牌接口每个IP每秒允许的请求数, 为0时不单独限流
+	RefreshBurst       int     `yaml:"refresh_burst"`       // 刷新令牌接口每个IP允许的突发请求数
+	ImpersonateMinutes int     `yaml:"impersonate_minutes"` // 管理员代为登录令牌的有效期(分钟), 默认15分钟
 }
 
 // LoginSecurityConfig 登录安全配置
 type LoginSecurityConfig struct {
 	MaxFailedAttempts int `yaml:"max_failed_attempts"` // 最大登录失败次数
 	LockMinutes       int `yaml:"lock_minutes"`        // 锁定时长(分钟)
+	CaptchaThreshold  int `yaml:"captcha_threshold"`   // 登录失败达到该次数后需要验证码, 为0时不启用
+	CaptchaSeconds    int `yaml:"captcha_seconds"`     // 验证码有效期(秒)
 }
 
 // PasswordConfig 密码配置
 type PasswordConfig struct {
-	StrengthLevel int `yaml:"strength_level"` // 密码强度等级
+	StrengthLevel int                `yaml:"strength_level"` // 密码强度等级
+	Hash          PasswordHashConfig `yaml:"hash"`           // 密码哈希配置
+}
+
+// PasswordHashConfig 密码哈希配置, 为0的参数使用默认值
+//
+// 修改算法或参数后已有的密码仍然可以验证, 用户下次登录成功时使用新的算法和参数重新哈希
+type PasswordHashConfig struct {
+	Algorithm     string `yaml:"algorithm"`      // 哈希算法, 支持bcrypt、argon2id、scrypt, 默认bcrypt
+	BcryptCost    int    `yaml:"bcrypt_cost"`    // bcrypt开销, 默认12
+	Argon2Memory  uint32 `yaml:"argon2_memory"`  // argon2id内存大小(KiB), 默认65536
+	Argon2Time    uint32 `yaml:"argon2_time"`    // argon2id迭代次数, 默认3
+	Argon2Threads uint8  `yaml:"argon2_threads"` // argon2id并行度, 默认2
+	ScryptLogN    uint8  `yaml:"scrypt_log_n"`   // scrypt参数N的以2为底的对数, 默认15
+	ScryptR       int    `yaml:"scrypt_r"`       // scrypt参数r, 默认8
+	ScryptP       int    `yaml:"scrypt_p"`       // scrypt参数p, 默认1
+}
+
+// ApiSyncConfig API目录同步配置
+type ApiSyncConfig struct {
+	OnStartup bool `yaml:"on_startup"` // 启动时按已注册路由同步API目录
+	TagModule bool `yaml:"tag_module"` // 同步时按模块名重新设置已有API的标签
+}
+
+// AuthzConfig 接口统一鉴权配置, 全部/api接口始终统一认证和鉴权
+//
+// 登录、刷新令牌、单点登录和主机代理等接口内置为公开接口, 个人接口内置为只需登录的接口, 配置中的接口在内置接口之外追加
+type AuthzConfig struct {
+	MatchPath    bool     `yaml:"match_path"`    // 使用实际请求路径作为Casbin对象, 需要模型使用keyMatch2, 否则使用路由模式
+	PublicRoutes []string `yaml:"public_routes"` // 无需鉴权的接口, 支持keyMatch2模式, 可用"METHOD /path"限定请求方法
+	LoginRoutes  []string `yaml:"login_routes"`  // 只需登录不校验接口权限的接口, 格式同public_routes
+}
+
+// CookieConfig 通过Cookie传递令牌的配置, 用于Web界面
+//
+// 开启后登录、刷新令牌和单点登录接口同时将令牌写入HttpOnly Cookie, 并下发脚本可读的CSRF令牌Cookie;
+// 使用Cookie认证的写请求必须在请求头中携带与CSRF Cookie相同的值(双重提交),
+// 通过Authorization请求头认证的API客户端不受影响
+type CookieConfig struct {
+	Enable     bool     `yaml:"enable"`      // 是否开启Cookie认证
+	Secure     bool     `yaml:"secure"`      // 是否只通过HTTPS发送Cookie, SameSite为none时必须开启
+	SameSite   string   `yaml:"same_site"`   // Cookie的SameSite属性(lax/strict/none), 默认lax
+	Domain     string   `yaml:"domain"`      // Cookie的域名, 为空时为当前域名
+	CSRFHeader string   `yaml:"csrf_header"` // 携带CSRF令牌的请求头, 默认X-CSRF-Token
+	CSRFExempt []string `yaml:"csrf_exempt"` // 不校验CSRF令牌的接口, 格式同public_routes
+}
+
+// HeadersConfig 页面安全响应头配置, 作用于前端页面、静态文件和Swagger文档
+type HeadersConfig struct {
+	Enable                bool   `yaml:"enable"`                  // 是否设置安全响应头
+	HSTSMaxAge            int    `yaml:"hsts_max_age"`            // Strict-Transport-Security的max-age(秒), 为0时不设置, 只对HTTPS请求生效
+	HSTSIncludeSubdomains bool   `yaml:"hsts_include_subdomains"` // HSTS是否包含子域名
+	FrameOptions          string `yaml:"frame_options"`           // X-Frame-Options, 默认DENY
+	ReferrerPolicy        string `yaml:"referrer_policy"`         // Referrer-Policy, 默认strict-origin-when-cross-origin
+	CSP                   string `yaml:"csp"`                     // Content-Security-Policy, 为空时不设置
+	CSPReportOnly         bool   `yaml:"csp_report_only"`         // 只上报不拦截, 使用Content-Security-Policy-Report-Only
+	CSPReportURI          string `yaml:"csp_report_uri"`          // 违规上报地址, 以/开头时由本服务接收并记录日志
+}
+
+// EncryptionConfig 敏感字段加密配置
+type EncryptionConfig struct {
+	Enable  bool   `yaml:"enable"`   // 是否加密数据库中的敏感字段
+	KeyFile string `yaml:"key_file"` // 密钥文件路径(相对配置目录), 为空时使用环境变量FIELD_ENCRYPTION_KEYS
+}
+
+// OIDCRoleMapping 按ID令牌声明映射角色的规则
+type OIDCRoleMapping struct {
+	Claim string `yaml:"claim"` // 声明名称, 声明为数组时包含该值即匹配
+	Value string `yaml:"value"` // 声明的值
+	Role  string `yaml:"role"`  // 匹配时使用的角色名称
+}
+
+// OIDCProviderConfig OIDC身份提供方配置
+type OIDCProviderConfig struct {
+	Name            string            `yaml:"name"`              // 标识, 用于登录接口的provider参数
+	DisplayName     string            `yaml:"display_name"`      // 登录页显示的名称
+	Issuer          string            `yaml:"issuer"`            // 签发者地址
+	ClientID        string            `yaml:"client_id"`         // 客户端标识
+	ClientSecretEnv string            `yaml:"client_secret_env"` // 保存客户端密钥的环境变量, 为空时作为公共客户端仅使用PKCE
+	RedirectURL     string            `yaml:"redirect_url"`      // 授权回调地址
+	Scopes          []string          `yaml:"scopes"`            // 申请的权限范围
+	UsernameClaim   string            `yaml:"username_claim"`    // 作为用户名的声明, 默认为preferred_username
+	AutoCreate      bool              `yaml:"auto_create"`       // 首次登录时是否自动创建用户
+	SyncRole        bool              `yaml:"sync_role"`         // 每次登录时是否按映射规则更新已关联用户的角色
+	DefaultRole     string            `yaml:"default_role"`      // 没有匹配的映射规则时使用的角色名称, 为空时拒绝自动创建
+	RoleMappings    []OIDCRoleMapping `yaml:"role_mappings"`     // 角色映射规则, 按顺序使用第一条匹配的规则
+}
+
+// OIDCConfig OIDC单点登录配置
+type OIDCConfig struct {
+	DisableLocalLogin bool                 `yaml:"disable_local_login"` // 是否禁用本地用户名密码登录
+	StateMinutes      int                  `yaml:"state_minutes"`       // 发起登录后完成授权的有效期(分钟)
+	Timeout           int                  `yaml:"timeout"`             // 请求身份提供方的超时时间(秒)
+	Providers         []OIDCProviderConfig `yaml:"providers"`           // 身份提供方
+}
+
+// FreezeConfig 变更冻结配置
+type FreezeConfig struct {
+	Enable bool     `yaml:"enable"` // 是否在变更冻结期间拒绝修改接口
+	Routes []string `yaml:"routes"` // 受变更冻结限制的接口, 格式同public_routes, 只对修改请求生效
 }
 
 // SecurityConfig 安全配置
 type SecurityConfig struct {
-	HostGuard HostGuardConfig     `yaml:"host_guard"` // host请求头配置
-	Timestamp TimestampConfig     `yaml:"timestamp"`  // 时间戳验证配置
-	Token     TokenConfig         `yaml:"token"`      // Token配置
-	Login     LoginSecurityConfig `yaml:"login"`      // 登录安全配置
-	Password  PasswordConfig      `yaml:"password"`   // 密码配置
+	HostGuard  HostGuardConfig     `yaml:"host_guard"` // host请求头配置
+	Timestamp  TimestampConfig     `yaml:"timestamp"`  // 时间戳验证配置
+	Token      TokenConfig         `yaml:"token"`      // Token配置
+	Login      LoginSecurityConfig `yaml:"login"`      // 登录安全配置
+	Password   PasswordConfig      `yaml:"password"`   // 密码配置
+	ApiSync    ApiSyncConfig       `yaml:"api_sync"`   // API目录同步配置
+	Authz      AuthzConfig         `yaml:"authz"`      // 接口统一鉴权配置
+	Cookie     CookieConfig        `yaml:"cookie"`     // Cookie认证配置
+	Headers    HeadersConfig       `yaml:"headers"`    // 页面安全响应头配置
+	Encryption EncryptionConfig    `yaml:"encryption"` // 敏感字段加密配置
+	Freeze     FreezeConfig        `yaml:"freeze"`     // 变更冻结配置
+	OIDC       *OIDCConfig         `yaml:"oidc"`       // OIDC单点登录配置, 为空时不启用
 }
@@ -6,6 +6,17 @@ type SSLConfig struct {
 	KeyPath  string `yaml:"key_path"`
 	CrtPath  string `yaml:"crt_path"`
 	Password string `yaml:"password"`
+
+	// 最低TLS版本, 支持1.0、1.1、1.2、1.3, 为空时为1.2
+	MinVersion string `yaml:"min_version"`
+	// 允许的加密套件名称, 只对TLS1.2及以下版本生效, 为空时使用Go的默认套件
+	CipherSuites []string `yaml:"cipher_suites"`
+	// 是否禁用HTTP/2, 默认启用
+	DisableHTTP2 bool `yaml:"disable_http2"`
+	// 检查证书文件变化的间隔(秒), 文件变化时重新加载证书, 为0时只在收到SIGHUP信号时重新加载
+	ReloadInterval int `yaml:"reload_interval"`
+	// 将HTTP请求重定向到HTTPS的监听端口, 为0时不启用
+	RedirectPort int `yaml:"redirect_port"`
 }
 
 // RateLimitConfig 限流配置
@@ -19,16 +30,109 @@ type RateLimitConfig struct {
 
 // TimeoutConfig 超时配置
 type TimeoutConfig struct {
-	Request  int `yaml:"request"`  // 请求处理超时时间(秒)
-	Shutdown int `yaml:"shutdown"` // 服务关闭超时时间(秒)
+	Request  int                  `yaml:"request"`  // 请求处理超时时间(秒)
+	Shutdown int                  `yaml:"shutdown"` // 服务关闭超时时间(秒)
+	Routes   []RouteTimeoutConfig `yaml:"routes"`   // 按路径前缀覆盖请求处理超时时间
+}
+
+// RouteTimeoutConfig 路径前缀的请求处理超时时间, 多个前缀匹配时使用最长的前缀
+type RouteTimeoutConfig struct {
+	Prefix  string `yaml:"prefix"`  // 路径前缀, 如/api/v1/jobs
+	Request int    `yaml:"request"` // 请求处理超时时间(秒)
+}
+
+// BodyLimitConfig 请求体大小限制配置
+//
+// 上传请求指multipart/form-data和application/octet-stream请求, 其他请求按普通接口限制;
+// 超出限制时返回413, 上传的文件仍需满足upload中按类型配置的大小限制
+type BodyLimitConfig struct {
+	Default   int `yaml:"default"`    // 普通接口的请求体大小上限(MB), 小于等于0时为4MB
+	Upload    int `yaml:"upload"`     // 上传请求的请求体大小上限(MB), 小于等于0时取upload中各类文件大小上限的最大值再加1MB
+	MaxMemory int `yaml:"max_memory"` // 解析multipart表单时在内存中缓冲的大小上限(MB), 超出部分直接写入临时文件, 小于等于0时为8MB
+}
+
+// UnixSocketConfig unix域套接字监听配置
+//
+// 用于部署在本机nginx等反向代理之后, 套接字上只提供HTTP服务, 不启用TLS
+type UnixSocketConfig struct {
+	Path string `yaml:"path"` // 套接字文件路径, 为空时不监听
+	Mode string `yaml:"mode"` // 套接字文件权限(八进制), 如0660, 为空时不修改
+}
+
+// StaticConfig 前端静态文件配置
+//
+// 使用-tags embedhtml构建时前端文件内嵌在二进制中, 配置dir后优先使用磁盘上的文件,
+// 不重新构建也可以替换前端
+type StaticConfig struct {
+	Dir    string `yaml:"dir"`     // 磁盘上的前端文件目录, 相对路径基于程序根目录, 为空时使用内嵌文件, 未内嵌时使用html目录
+	MaxAge int    `yaml:"max_age"` // /static下文件的缓存时间(秒), 小于等于0时为一年, 前端打包的文件名带内容哈希, 可以长期缓存
+}
+
+// HTTPCacheRoute 路径前缀的缓存策略
+type HTTPCacheRoute struct {
+	Prefix       string `yaml:"prefix"`        // 路径前缀, 如/api/v1/customer/menu
+	CacheControl string `yaml:"cache_control"` // Cache-Control响应头, 为空时为private, no-cache
+}
+
+// HTTPCacheConfig 条件请求配置
+//
+// routes中GET接口的成功响应按响应体计算ETag, 请求的If-None-Match匹配时返回304, 不返回响应体,
+// 用于减少前端轮询的流量
+type HTTPCacheConfig struct {
+	Enable  bool             `yaml:"enable"`
+	MaxBody int              `yaml:"max_body"` // 计算ETag的响应体上限(KB), 超出时直接返回响应, 小于等于0时为1024
+	Routes  []HTTPCacheRoute `yaml:"routes"`
+}
+
+// CompressConfig 响应压缩配置
+type CompressConfig struct {
+	Enable    bool     `yaml:"enable"`
+	Encodings []string `yaml:"encodings"` // 按优先顺序排列的压缩算法, 支持zstd、gzip、deflate, 为空时为gzip、deflate
+	MinSize   int      `yaml:"min_size"`  // 响应体小于该大小(字节)时不压缩, 小于等于0时为1024
+	Level     int      `yaml:"level"`     // 压缩级别1-9, 小于等于0时使用各算法的默认级别
+	Types     []string `yaml:"types"`     // 压缩的响应类型, 以/*结尾时匹配整个大类, 为空时压缩JSON、文本、JS、CSS、XML和SVG
+	Exclude   []string `yaml:"exclude"`   // 不压缩的路径前缀
+}
+
+// AdminConfig 管理端口配置
+//
+// 启用后/metrics、/debug/pprof和管理接口只在管理端口上提供, 公共端口不再注册这些路由,
+// 即使反向代理配置错误也不会对外暴露性能分析和监控数据
+type AdminConfig struct {
+	Enable bool   `yaml:"enable"`
+	Host   string `yaml:"host"` // 监听地址, 为空时为127.0.0.1, 应只绑定内网地址
+	Port   int    `yaml:"port"`
+}
+
+// GRPCConfig gRPC端口配置
+//
+// gRPC接口使用与REST接口相同的访问令牌和角色权限, 客户端在authorization元数据中携带访问令牌;
+// 配置client_ca_path后要求客户端提供该CA签发的证书(mTLS)
+type GRPCConfig struct {
+	Enable       bool   `yaml:"enable"`
+	Host         string `yaml:"host"` // 监听地址, 为空时为127.0.0.1
+	Port         int    `yaml:"port"`
+	CrtPath      string `yaml:"crt_path"`       // 证书文件, 相对路径基于配置目录, 为空时不启用TLS
+	KeyPath      string `yaml:"key_path"`       // 密钥文件, 相对路径基于配置目录
+	ClientCAPath string `yaml:"client_ca_path"` // 校验客户端证书的CA文件, 相对路径基于配置目录, 为空时不校验客户端证书
 }
 
 // ServerConfig 服务器配置
+//
+// 除host:port外, 还可以同时监听unix域套接字和systemd套接字激活传入的套接字
 type ServerConfig struct {
-	Host    string          `yaml:"host"`
-	Port    int             `yaml:"port"`
-	SSL     SSLConfig       `yaml:"ssl"`
-	Rate    RateLimitConfig `yaml:"rate"`
-	Timeout TimeoutConfig   `yaml:"timeout"`
-	Swagger bool            `yaml:"swagger"`
+	Host     string           `yaml:"host"`
+	Port     int              `yaml:"port"`
+	Admin    AdminConfig      `yaml:"admin"`
+	GRPC     GRPCConfig       `yaml:"grpc"`
+	Unix     UnixSocketConfig `yaml:"unix"`
+	Static   StaticConfig     `yaml:"static"`
+	Cache    HTTPCacheConfig  `yaml:"http_cache"`
+	Compress CompressConfig   `yaml:"compress"`
+	Systemd  bool             `yaml:"systemd_socket"` // 是否接收systemd套接字激活传入的套接字, 只提供HTTP服务
+	SSL      SSLConfig        `yaml:"ssl"`
+	Rate     RateLimitConfig  `yaml:"rate"`
+	Timeout  TimeoutConfig    `yaml:"timeout"`
+	Body     BodyLimitConfig  `yaml:"body"`
+	Swagger  bool             `yaml:"swagger"`
 }
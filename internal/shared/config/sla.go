@@ -0,0 +1,7 @@
+package config
+
+// SlaConfig oes日常任务SLA检查配置
+type SlaConfig struct {
+	Enable bool   `yaml:"enable"` // 是否定时检查
+	Cron   string `yaml:"cron"`   // 检查频率(cron表达式)
+}
@@ -0,0 +1,7 @@
+package config
+
+// StatsConfig 首页统计配置
+type StatsConfig struct {
+	CacheSeconds int `yaml:"cache_seconds"` // 统计结果缓存时间(秒), 0表示使用默认的60秒
+	ActiveDays   int `yaml:"active_days"`   // 活跃用户的统计天数, 0表示使用默认的7天
+}
@@ -0,0 +1,27 @@
+package config
+
+// S3StorageConfig S3兼容对象存储配置
+type S3StorageConfig struct {
+	Endpoint  string `yaml:"endpoint"`   // 服务地址
+	Region    string `yaml:"region"`     // 区域
+	Bucket    string `yaml:"bucket"`     // 存储桶
+	Prefix    string `yaml:"prefix"`     // 对象key前缀
+	PathStyle bool   `yaml:"path_style"` // 使用路径风格访问存储桶(MinIO需要开启)
+}
+
+// SFTPStorageConfig SFTP存储配置
+type SFTPStorageConfig struct {
+	Host          string `yaml:"host"`            // 主机地址
+	Port          int    `yaml:"port"`            // 端口
+	User          string `yaml:"user"`            // 用户名
+	Root          string `yaml:"root"`            // 存储根目录
+	UseKnownHosts bool   `yaml:"use_known_hosts"` // 是否使用known_hosts校验主机密钥
+}
+
+// StorageConfig 程序包文件存储配置
+type StorageConfig struct {
+	Type           string            `yaml:"type"`            // 存储类型(local/s3/sftp)
+	PresignMinutes int               `yaml:"presign_minutes"` // 预签名下载地址有效期(分钟), 仅s3支持
+	S3             S3StorageConfig   `yaml:"s3"`              // S3兼容对象存储配置, 访问密钥使用环境变量S3_ACCESS_KEY/S3_SECRET_KEY
+	SFTP           SFTPStorageConfig `yaml:"sftp"`            // SFTP存储配置, 使用平台SSH密钥或环境变量SFTP_PASSWORD认证
+}
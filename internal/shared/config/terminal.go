@@ -0,0 +1,9 @@
+package config
+
+// TerminalConfig 网页终端配置
+type TerminalConfig struct {
+	Enable      bool `yaml:"enable"`       // 是否启用网页终端
+	IdleTimeout int  `yaml:"idle_timeout"` // 无输入自动断开时间(秒), 0表示不限制
+	MaxDuration int  `yaml:"max_duration"` // 单个会话最长时间(秒), 0表示不限制
+	Record      bool `yaml:"record"`       // 是否录制终端输出
+}
@@ -5,4 +5,10 @@ type UploadConfig struct {
 	MaxPkgSize    int `yaml:"max_pkg_size"`    // 最大上传程序包大小(MB)
 	MaxScriptSize int `yaml:"max_script_size"` // 脚本最大上传大小(MB)
 	MaxConfSize   int `yaml:"max_conf_size"`   // 配置文件最大上传大小(MB)
+
+	MaxHostFileSize int `yaml:"max_host_file_size"` // 上传到资源主机的文件大小限制(MB)
+
+	MaxChunkedPkgSize int `yaml:"max_chunked_pkg_size"` // 分片上传程序包大小限制(MB)
+	ChunkSize         int `yaml:"chunk_size"`           // 分片大小上限(MB)
+	SessionTTL        int `yaml:"session_ttl"`          // 分片上传会话空闲过期时间(小时)
 }
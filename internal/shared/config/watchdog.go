@@ -0,0 +1,7 @@
+package config
+
+// WatchdogConfig 进程守护配置
+type WatchdogConfig struct {
+	Interval       int `yaml:"interval"`        // 检查间隔(秒), 0表示不定时检查
+	CommandTimeout int `yaml:"command_timeout"` // 检查和重启命令的超时时间(秒), 默认30
+}
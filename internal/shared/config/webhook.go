@@ -0,0 +1,8 @@
+package config
+
+// WebhookConfig webhook推送配置
+type WebhookConfig struct {
+	Timeout     int `yaml:"timeout"`      // 单次推送超时时间(秒)
+	MaxAttempts int `yaml:"max_attempts"` // 最大推送次数, 超过后标记为失败
+	Interval    int `yaml:"interval"`     // 检查待重试推送的间隔(秒)
+}
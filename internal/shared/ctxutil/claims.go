@@ -10,6 +10,7 @@ import (
 const (
 	UserIDKey     = "user_id"
 	UserClaimsKey = "user_claims"
+	SensitiveKey  = "view_sensitive" // 是否可以查看敏感字段明文, 由鉴权中间件设置为bool或按需校验角色权限的func() bool
 )
 
 func GetUserClaims(ctx context.Context) (*auth.UserClaims, *errors.Error) {
@@ -25,3 +26,17 @@ func GetUserClaims(ctx context.Context) (*auth.UserClaims, *errors.Error) {
 	}
 	return nil, errors.ErrMissingAuth
 }
+
+// CanViewSensitive 当前用户是否可以查看敏感字段明文, 未经过鉴权的请求返回false
+func CanViewSensitive(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+	switch v := ctx.Value(SensitiveKey).(type) {
+	case bool:
+		return v
+	case func() bool:
+		return v()
+	}
+	return false
+}
@@ -0,0 +1,37 @@
+package ctxutil
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanViewSensitive(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	newCtx := func(value any) *gin.Context {
+		ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+		if value != nil {
+			ctx.Set(SensitiveKey, value)
+		}
+		return ctx
+	}
+
+	assert.False(t, CanViewSensitive(nil), "空上下文")
+	assert.False(t, CanViewSensitive(context.Background()), "未经过鉴权")
+	assert.False(t, CanViewSensitive(newCtx(nil)), "未设置权限")
+	assert.True(t, CanViewSensitive(newCtx(true)))
+	assert.False(t, CanViewSensitive(newCtx(false)))
+	assert.False(t, CanViewSensitive(newCtx("true")), "未知类型按无权限处理")
+
+	calls := 0
+	ctx := newCtx(func() bool {
+		calls++
+		return true
+	})
+	assert.Zero(t, calls, "未读取时不校验")
+	assert.True(t, CanViewSensitive(ctx))
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,129 @@
+package ctxutil
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+const (
+	LoggerKey     = "request_logger"
+	RouteKey      = "route"
+	RequestURIKey = "request_uri"
+)
+
+// RequestLogger 请求关联的日志记录器
+//
+// 由中间件在请求开始时构建, 认证通过追加用户ID时再构建一次, 保存在请求上下文中;
+// 服务、仓库等各层使用不同的日志记录器写入不同的日志文件,
+// 每个日志记录器附加关联字段后的结果在请求内只构建一次
+type RequestLogger struct {
+	fields  []zap.Field
+	mu      sync.Mutex
+	loggers map[*zap.Logger]*zap.Logger
+}
+
+// NewRequestLogger 构建附加了fields的请求日志记录器, 并预先构建bases附加字段后的日志记录器
+func NewRequestLogger(fields []zap.Field, bases ...*zap.Logger) *RequestLogger {
+	l := &RequestLogger{
+		fields:  slices.Clip(fields),
+		loggers: make(map[*zap.Logger]*zap.Logger, len(bases)),
+	}
+	for _, base := range bases {
+		if base != nil {
+			l.loggers[base] = base.With(l.fields...)
+		}
+	}
+	return l
+}
+
+// Fields 返回请求关联的日志字段
+func (l *RequestLogger) Fields() []zap.Field {
+	if l == nil {
+		return nil
+	}
+	return l.fields
+}
+
+// With 返回追加了字段的新请求日志记录器, 原记录器已构建的日志记录器在新记录器中同样预先构建
+func (l *RequestLogger) With(fields ...zap.Field) *RequestLogger {
+	l.mu.Lock()
+	bases := make([]*zap.Logger, 0, len(l.loggers))
+	for base := range l.loggers {
+		bases = append(bases, base)
+	}
+	l.mu.Unlock()
+	return NewRequestLogger(append(slices.Clip(l.fields), fields...), bases...)
+}
+
+// For 返回base附加了请求关联字段的日志记录器, 同一个base只构建一次
+func (l *RequestLogger) For(base *zap.Logger) *zap.Logger {
+	if l == nil || len(l.fields) == 0 {
+		return base
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	logger, ok := l.loggers[base]
+	if !ok {
+		logger = base.With(l.fields...)
+		l.loggers[base] = logger
+	}
+	return logger
+}
+
+// GetRequestLogger 返回上下文中的请求日志记录器, 没有时返回nil
+func GetRequestLogger(ctx context.Context) *RequestLogger {
+	if ctx == nil {
+		return nil
+	}
+	l, _ := ctx.Value(LoggerKey).(*RequestLogger)
+	return l
+}
+
+// LogFields 返回上下文中请求关联的日志字段
+//
+// 请求上下文中的字段由中间件写入, 包含trace_id、路由、请求URI和用户ID;
+// 后台任务等没有请求日志记录器的上下文只返回trace_id
+func LogFields(ctx context.Context) []zap.Field {
+	if l := GetRequestLogger(ctx); l != nil {
+		return l.Fields()
+	}
+	if traceID := GetTraceID(ctx); traceID != "" {
+		return []zap.Field{zap.String(TraceIDKey, traceID)}
+	}
+	return nil
+}
+
+// WithLogFields 在上下文中追加请求关联的日志字段, 不修改原上下文中的字段
+func WithLogFields(ctx context.Context, fields ...zap.Field) context.Context {
+	return context.WithValue(ctx, LoggerKey, AppendLogFields(ctx, fields...))
+}
+
+// AppendLogFields 返回追加了字段的新请求日志记录器, 供中间件写回gin上下文
+func AppendLogFields(ctx context.Context, fields ...zap.Field) *RequestLogger {
+	if l := GetRequestLogger(ctx); l != nil {
+		return l.With(fields...)
+	}
+	return NewRequestLogger(append(LogFields(ctx), fields...))
+}
+
+// HasLogField 上下文中请求关联的日志字段是否已包含key
+func HasLogField(ctx context.Context, key string) bool {
+	return slices.ContainsFunc(LogFields(ctx), func(f zap.Field) bool { return f.Key == key })
+}
+
+// Logger 返回附加了请求关联字段的日志记录器
+//
+// 服务和仓库使用各自的日志记录器写入不同的日志文件, 通过Logger取得附加了同一组关联字段的记录器,
+// 不需要在每次记录日志时传入trace_id; 请求上下文中直接返回中间件构建的记录器
+func Logger(ctx context.Context, base *zap.Logger) *zap.Logger {
+	if l := GetRequestLogger(ctx); l != nil {
+		return l.For(base)
+	}
+	if traceID := GetTraceID(ctx); traceID != "" {
+		return base.With(zap.String(TraceIDKey, traceID))
+	}
+	return base
+}
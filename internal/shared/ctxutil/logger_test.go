@@ -0,0 +1,83 @@
+package ctxutil
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestLogger(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	base := zap.New(core)
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want map[string]any
+	}{
+		{"空上下文", nil, map[string]any{}},
+		{"没有关联字段", context.Background(), map[string]any{}},
+		{"后台任务只有trace_id", SetTraceID(context.Background(), "t1"), map[string]any{TraceIDKey: "t1"}},
+		{
+			"中间件写入的字段",
+			WithLogFields(SetTraceID(context.Background(), "t1"), zap.String(RouteKey, "/api/v1/oes/colony"), zap.Uint32(UserIDKey, 7)),
+			map[string]any{TraceIDKey: "t1", RouteKey: "/api/v1/oes/colony", UserIDKey: uint32(7)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Logger(tt.ctx, base).Info("msg", zap.String("k", "v"))
+			entries := logs.TakeAll()
+			assert.Len(t, entries, 1)
+			fields := entries[0].ContextMap()
+			assert.Equal(t, "v", fields["k"])
+			delete(fields, "k")
+			assert.Equal(t, tt.want, fields)
+		})
+	}
+
+	assert.Same(t, base, Logger(context.Background(), base), "没有关联字段时直接返回原日志记录器")
+}
+
+func TestLogFieldsGinContext(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Set(LoggerKey, NewRequestLogger([]zap.Field{zap.String(TraceIDKey, "t2")}))
+	assert.False(t, HasLogField(c, UserIDKey))
+
+	c.Set(LoggerKey, AppendLogFields(c, zap.Uint32(UserIDKey, 1)))
+	assert.True(t, HasLogField(c, UserIDKey))
+	assert.Len(t, LogFields(c), 2)
+
+	// 追加字段不修改原上下文中的字段
+	parent := WithLogFields(context.Background(), zap.String(TraceIDKey, "t3"))
+	child := WithLogFields(parent, zap.Uint32(UserIDKey, 1))
+	assert.Len(t, LogFields(parent), 1)
+	assert.Len(t, LogFields(child), 2)
+}
+
+func TestRequestLoggerBuiltOnce(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	service, data := zap.New(core), zap.New(core)
+	l := NewRequestLogger([]zap.Field{zap.String(TraceIDKey, "t4")}, service)
+	ctx := context.WithValue(context.Background(), LoggerKey, l)
+
+	first := Logger(ctx, service)
+	assert.Same(t, first, Logger(ctx, service), "同一个请求内不重新构建日志记录器")
+	assert.Same(t, Logger(ctx, data), Logger(ctx, data), "未预先构建的日志记录器只构建一次")
+
+	child := WithLogFields(ctx, zap.Uint32(UserIDKey, 7))
+	assert.NotSame(t, first, Logger(child, service), "追加字段后使用新的日志记录器")
+	assert.Same(t, first, Logger(ctx, service), "追加字段不影响原上下文")
+
+	Logger(child, service).Info("msg")
+	entries := logs.TakeAll()
+	assert.Len(t, entries, 1)
+	assert.Equal(t, map[string]any{TraceIDKey: "t4", UserIDKey: uint32(7)}, entries[0].ContextMap())
+}
@@ -0,0 +1,87 @@
+package database
+
+import (
+	"gorm.io/gorm"
+
+	"gin-artweb/pkg/breaker"
+)
+
+const (
+	breakerPluginName = "artweb:breaker"
+	breakerDoneKey    = "artweb:breaker_done"
+)
+
+// BreakerPlugin 通过熔断器执行SQL的GORM插件
+//
+// 数据库连续连接失败或超时后熔断, 熔断期间SQL不再发往数据库, 直接返回breaker.ErrOpen,
+// 避免请求堆积在连接池上等待超时
+type BreakerPlugin struct {
+	breaker *breaker.Breaker
+}
+
+func NewBreakerPlugin(b *breaker.Breaker) *BreakerPlugin {
+	return &BreakerPlugin{breaker: b}
+}
+
+func (p *BreakerPlugin) Name() string {
+	return breakerPluginName
+}
+
+// Initialize 在各类操作的回调前申请熔断器, 回调后记录执行结果
+func (p *BreakerPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	if err := cb.Create().Before("gorm:create").Register(breakerPluginName+":before_create", p.before); err != nil {
+		return err
+	}
+	if err := cb.Create().After("gorm:create").Register(breakerPluginName+":after_create", p.after); err != nil {
+		return err
+	}
+	if err := cb.Query().Before("gorm:query").Register(breakerPluginName+":before_query", p.before); err != nil {
+		return err
+	}
+	if err := cb.Query().After("gorm:query").Register(breakerPluginName+":after_query", p.after); err != nil {
+		return err
+	}
+	if err := cb.Update().Before("gorm:update").Register(breakerPluginName+":before_update", p.before); err != nil {
+		return err
+	}
+	if err := cb.Update().After("gorm:update").Register(breakerPluginName+":after_update", p.after); err != nil {
+		return err
+	}
+	if err := cb.Delete().Before("gorm:delete").Register(breakerPluginName+":before_delete", p.before); err != nil {
+		return err
+	}
+	if err := cb.Delete().After("gorm:delete").Register(breakerPluginName+":after_delete", p.after); err != nil {
+		return err
+	}
+	if err := cb.Row().Before("gorm:row").Register(breakerPluginName+":before_row", p.before); err != nil {
+		return err
+	}
+	if err := cb.Row().After("gorm:row").Register(breakerPluginName+":after_row", p.after); err != nil {
+		return err
+	}
+	if err := cb.Raw().Before("gorm:raw").Register(breakerPluginName+":before_raw", p.before); err != nil {
+		return err
+	}
+	return cb.Raw().After("gorm:raw").Register(breakerPluginName+":after_raw", p.after)
+}
+
+// before 熔断时设置错误, gorm的执行回调检查到错误后不再执行SQL
+func (p *BreakerPlugin) before(db *gorm.DB) {
+	done, err := p.breaker.Allow()
+	if err != nil {
+		_ = db.AddError(err)
+		return
+	}
+	db.InstanceSet(breakerDoneKey, done)
+}
+
+func (p *BreakerPlugin) after(db *gorm.DB) {
+	v, ok := db.InstanceGet(breakerDoneKey)
+	if !ok {
+		return
+	}
+	if done, ok := v.(func(error)); ok {
+		done(db.Error)
+	}
+}
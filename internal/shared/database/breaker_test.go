@@ -0,0 +1,34 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gin-artweb/internal/shared/test"
+	"gin-artweb/pkg/breaker"
+)
+
+func TestBreakerPlugin(t *testing.T) {
+	db := test.NewTestGormDBWithConfig(nil)
+	require.NoError(t, db.AutoMigrate(&migrateTestModel{}))
+
+	b := breaker.New("database", breaker.Settings{FailureThreshold: 1})
+	require.NoError(t, db.Use(NewBreakerPlugin(b)))
+
+	require.NoError(t, db.Create(&migrateTestModel{Name: "a"}).Error)
+	var ms []migrateTestModel
+	require.NoError(t, db.Find(&ms).Error)
+	assert.Equal(t, breaker.StateClosed, b.State())
+
+	err := db.Table("not_exists").Find(&ms).Error
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, breaker.ErrOpen)
+	assert.Equal(t, breaker.StateOpen, b.State(), "失败次数达到阈值后应该熔断")
+
+	err = db.Create(&migrateTestModel{Name: "b"}).Error
+	assert.ErrorIs(t, err, breaker.ErrOpen, "熔断期间应该直接返回错误")
+	err = db.Find(&ms).Error
+	assert.ErrorIs(t, err, breaker.ErrOpen)
+}
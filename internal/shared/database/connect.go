@@ -7,15 +7,10 @@ import (
 	"time"
 
 	"emperror.dev/errors"
-	"github.com/glebarez/sqlite"
-	"gorm.io/driver/mysql"
-	"gorm.io/driver/postgres"
-	"gorm.io/driver/sqlserver"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 
 	"gin-artweb/internal/shared/config"
-	"gin-artweb/internal/shared/database/driver/opengauss"
 )
 
 // NewGormConfig 创建 gorm 配置
@@ -41,27 +36,12 @@ func NewGormConfig(dbLog *log.Logger) *gorm.Config {
 // gc: GORM配置信息
 // 返回GORM数据库实例和可能的错误
 func NewGormDB(c *config.DBConf, gc *gorm.Config) (*gorm.DB, error) {
-	var (
-		db      *gorm.DB // GORM数据库实例
-		openErr error    // 数据库打开错误
-	)
-
 	// 根据数据库类型选择相应的驱动并建立连接
-	switch c.Type {
-	case "mysql":
-		db, openErr = gorm.Open(mysql.Open(c.Dns), gc)
-	case "postgres":
-		db, openErr = gorm.Open(postgres.Open(c.Dns), gc)
-	case "sqlite":
-		db, openErr = gorm.Open(sqlite.Open(c.Dns), gc)
-	case "sqlserver":
-		db, openErr = gorm.Open(sqlserver.Open(c.Dns), gc)
-	case "opengauss":
-		db, openErr = gorm.Open(opengauss.Open(c.Dns), gc)
-	default:
-		// 不支持的数据库驱动类型
-		return nil, errors.NewWithDetails("不支持的数据库驱动类型: %s", c.Type)
+	dialector, err := NewDialector(c.Type, c.Dns)
+	if err != nil {
+		return nil, err
 	}
+	db, openErr := gorm.Open(dialector, gc)
 
 	// 如果数据库连接打开失败，返回错误
 	if openErr != nil {
@@ -4,8 +4,10 @@ package database
 
 import (
 	"context"
+	"reflect"
 	"runtime/debug"
 	"strings"
+	"time"
 
 	"emperror.dev/errors"
 	"go.uber.org/zap/zapcore"
@@ -68,8 +70,13 @@ func DBPanic(ctx context.Context, db *gorm.DB) error {
 // value: 要创建的数据
 // 返回操作可能产生的错误
 func DBCreate(ctx context.Context, db *gorm.DB, model, value any, upmap map[string]any) error {
+	// 上下文中已有事务时在该事务中执行, 由开启事务的一方提交
+	if tx, ok := TxFromContext(ctx); ok {
+		return createWith(ctx, tx.WithContext(ctx), model, value, upmap)
+	}
+
 	// 使用GORM的Create方法创建记录
-	if len(upmap) == 0 {
+	if len(upmap) == 0 && len(txHooks(ctx)) == 0 {
 		err := db.WithContext(ctx).Model(model).Create(value).Error
 		return errors.WrapIf(err, "创建数据库记录失败")
 	}
@@ -84,24 +91,36 @@ func DBCreate(ctx context.Context, db *gorm.DB, model, value any, upmap map[stri
 	// 设置panic处理
 	defer DBPanic(ctx, tx)
 
+	if err := createWith(ctx, tx, model, value, upmap); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// 提交事务
+	if err := tx.Commit().Error; err != nil {
+		tx.Rollback()
+		return errors.WrapIf(err, "数据库事务提交失败")
+	}
+	return nil
+}
+
+// createWith 在事务中创建主表数据、追加关联关系并执行事务回调
+func createWith(ctx context.Context, tx *gorm.DB, model, value any, upmap map[string]any) error {
 	// 创建主表数据
 	if err := tx.Model(model).Create(value).Error; err != nil {
-		tx.Rollback()
 		return errors.WrapIf(err, "创建数据库记录失败")
 	}
 
 	// 遍历关联关系映射，逐个更新关联字段
 	for k, v := range upmap {
 		if err := tx.Model(value).Association(k).Append(v); err != nil {
-			tx.Rollback()
 			return errors.WrapIf(err, "更新关联关系失败")
 		}
 	}
 
-	// 提交事务
-	if err := tx.Commit().Error; err != nil {
-		tx.Rollback()
-		return errors.WrapIf(err, "数据库事务提交失败")
+	// 执行上下文中的事务回调
+	if err := runTxHooks(ctx, tx); err != nil {
+		return errors.WrapIf(err, "执行事务回调失败")
 	}
 	return nil
 }
@@ -125,13 +144,20 @@ func DBUpdate(ctx context.Context, db *gorm.DB, m any, data map[string]any, upma
 		return errors.WithStack(gorm.ErrMissingWhereClause)
 	}
 
-	// 如果没有关联关系更新，直接执行更新操作（无需事务）
-	if len(upmap) == 0 {
-		err := db.WithContext(ctx).Model(m).Where(conds[0], conds[1:]...).Updates(data).Error
-		return errors.WrapIf(err, "更新数据库记录失败")
+	// 带版本号的模型在更新时递增版本号, 传入期望版本号时作为乐观锁条件
+	data, version, checkVersion := versionedData(db, m, data)
+
+	// 上下文中已有事务时在该事务中执行, 由开启事务的一方提交
+	if tx, ok := TxFromContext(ctx); ok {
+		return updateWith(ctx, tx.WithContext(ctx), m, data, upmap, version, checkVersion, conds)
 	}
 
-	// 开启事务处理（有关联关系更新时必须使用事务）
+	// 如果没有关联关系更新和事务回调，直接执行更新操作（无需事务）
+	if len(upmap) == 0 && len(txHooks(ctx)) == 0 {
+		return updateWith(ctx, db.WithContext(ctx), m, data, nil, version, checkVersion, conds)
+	}
+
+	// 开启事务处理（有关联关系更新或事务回调时必须使用事务）
 	tx := db.WithContext(ctx).Begin()
 	if tx.Error != nil {
 		return errors.WrapIf(tx.Error, "数据库事务开启失败")
@@ -140,29 +166,57 @@ func DBUpdate(ctx context.Context, db *gorm.DB, m any, data map[string]any, upma
 	// 设置panic处理
 	defer DBPanic(ctx, tx)
 
+	if err := updateWith(ctx, tx, m, data, upmap, version, checkVersion, conds); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	// 提交事务
+	if err := tx.Commit().Error; err != nil {
+		// 提交失败时回滚并返回提交错误
+		tx.Rollback()
+		return errors.WrapIf(err, "数据库事务提交失败")
+	}
+
+	return nil
+}
+
+// updateWith 更新主表数据、替换关联关系并执行事务回调, 没有关联关系和事务回调时tx可以不是事务
+func updateWith(
+	ctx context.Context,
+	tx *gorm.DB,
+	m any,
+	data, upmap map[string]any,
+	version any,
+	checkVersion bool,
+	conds []any,
+) error {
 	// 更新主表数据
 	if len(data) > 0 {
-		if err := tx.Model(m).Where(conds[0], conds[1:]...).Updates(data).Error; err != nil {
-			tx.Rollback()
-			return errors.WrapIf(err, "更新数据库记录失败")
+		query := tx.Model(m).Where(conds[0], conds[1:]...)
+		if checkVersion {
+			query = query.Where(VersionKey+" = ?", version)
+		}
+		result := query.Updates(data)
+		if result.Error != nil {
+			return errors.WrapIf(result.Error, "更新数据库记录失败")
+		}
+		if checkVersion && result.RowsAffected == 0 {
+			return errors.WithStack(ErrVersionConflict)
 		}
 	}
 
 	// 遍历关联关系映射，逐个更新关联字段
 	for k, v := range upmap {
 		if err := tx.Model(m).Association(k).Replace(v); err != nil {
-			tx.Rollback()
 			return errors.WrapIf(err, "更新关联关系失败")
 		}
 	}
 
-	// 提交事务
-	if err := tx.Commit().Error; err != nil {
-		// 提交失败时回滚并返回提交错误
-		tx.Rollback()
-		return errors.WrapIf(err, "数据库事务提交失败")
+	// 执行上下文中的事务回调
+	if err := runTxHooks(ctx, tx); err != nil {
+		return errors.WrapIf(err, "执行事务回调失败")
 	}
-
 	return nil
 }
 
@@ -179,7 +233,7 @@ func DBDelete(ctx context.Context, db *gorm.DB, model any, conds ...any) error {
 	}
 
 	// 执行删除操作
-	err := db.WithContext(ctx).Delete(model, conds...).Error
+	err := Conn(ctx, db).Delete(model, conds...).Error
 	return errors.WrapIf(err, "删除数据库记录失败")
 }
 
@@ -191,7 +245,7 @@ func DBDelete(ctx context.Context, db *gorm.DB, model any, conds ...any) error {
 // conds: 查询条件
 // 返回操作可能产生的错误
 func DBGet(ctx context.Context, db *gorm.DB, preloads []string, m any, conds ...any) error {
-	dbCtx := db.WithContext(ctx)
+	dbCtx := Conn(ctx, db)
 
 	// 预加载关联关系
 	for _, preload := range preloads {
@@ -209,19 +263,24 @@ func DBGet(ctx context.Context, db *gorm.DB, preloads []string, m any, conds ...
 // model: 目标模型
 // value: 查询结果存储对象
 // query: 查询参数
-// 返回记录总数和操作可能产生的错误
+// 返回记录总数和操作可能产生的错误, 分页查询跳过总数(SkipCount)时返回当前页之前的记录数加上本页查询到的记录数,
+// 本页多查询一条记录, 返回值大于页码乘以分页大小时表示有下一页
 func DBList(ctx context.Context, db *gorm.DB, model, value any, query QueryParams) (int64, error) {
 	// 初始化查询构建器
-	mdb := db.WithContext(ctx).Model(model)
+	mdb := Conn(ctx, db).Model(model)
 
 	// 添加查询条件
 	for k, v := range query.Query {
 		mdb = mdb.Where(k, v)
 	}
+	if sql, args := query.Filter.Clause(); sql != "" {
+		mdb = mdb.Where(sql, args...)
+	}
 
 	// 查询总数
 	var count int64 = 0
-	if query.IsCount {
+	skipCount := query.IsCount && query.SkipCount && query.Size > 0
+	if query.IsCount && !skipCount {
 		if err := mdb.Count(&count).Error; err != nil {
 			return 0, errors.WrapIf(err, "查询数据库记录总数失败")
 		}
@@ -242,11 +301,16 @@ func DBList(ctx context.Context, db *gorm.DB, model, value any, query QueryParam
 		mdb = mdb.Order(orderByStr)
 	}
 
-	// 添加分页条件
+	// 添加分页条件, 跳过总数时多查询一条记录判断是否有下一页
+	var offset int
 	if query.Size > 0 {
-		mdb = mdb.Limit(query.Size)
+		if skipCount {
+			mdb = mdb.Limit(query.Size + 1)
+		} else {
+			mdb = mdb.Limit(query.Size)
+		}
 		if query.Page > 0 {
-			offset := (query.Page - 1) * query.Size
+			offset = (query.Page - 1) * query.Size
 			mdb = mdb.Offset(offset)
 		}
 	}
@@ -266,6 +330,14 @@ func DBList(ctx context.Context, db *gorm.DB, model, value any, query QueryParam
 	if !query.IsCount {
 		count = result.RowsAffected
 	}
+	if skipCount {
+		count = int64(offset) + result.RowsAffected
+		if rv := reflect.ValueOf(value); rv.Kind() == reflect.Pointer && rv.Elem().Kind() == reflect.Slice {
+			if items := rv.Elem(); items.Len() > query.Size {
+				items.Set(items.Slice(0, query.Size))
+			}
+		}
+	}
 
 	return count, nil
 }
@@ -281,14 +353,17 @@ const (
 
 // QueryParams 查询参数结构体，用于配置列表查询的各种参数
 type QueryParams struct {
-	Preloads []string       // 需要预加载的关联关系列表
-	Query    map[string]any // 查询条件映射
-	OrderBy  []string       // 排序字段列表
-	Size     int            // 分页大小
-	Page     int            // 分页页码
-	IsCount  bool           // 是否查询总数
-	Omit     []string       // 需要忽略的字段列表
-	Columns  []string       // 查询字段列表
+	Preloads  []string       // 需要预加载的关联关系列表
+	Query     map[string]any // 查询条件映射
+	Filter    *Filter        // 客户端提交的过滤条件, 与Query之间为AND
+	OrderBy   []string       // 排序字段列表
+	Size      int            // 分页大小
+	Page      int            // 分页页码
+	IsCount   bool           // 是否查询总数
+	SkipCount bool           // 分页查询时跳过总数, 多查询一条记录判断是否有下一页, 仅在IsCount为true时生效
+	Omit      []string       // 需要忽略的字段列表
+	Columns   []string       // 查询字段列表
+	Timeout   time.Duration  // 本次查询的超时, 不大于0时使用配置的默认超时
 }
 
 func (q *QueryParams) MarshalLogObject(enc zapcore.ObjectEncoder) error {
@@ -301,6 +376,9 @@ func (q *QueryParams) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 
 	// 记录查询条件
 	enc.AddReflected("query", q.Query)
+	if q.Filter != nil {
+		enc.AddString("filter", q.Filter.Expr)
+	}
 
 	// 记录排序字段
 	if len(q.OrderBy) > 0 {
@@ -315,6 +393,9 @@ func (q *QueryParams) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 
 	// 记录是否查询总数
 	enc.AddBool("is_count", q.IsCount)
+	if q.SkipCount {
+		enc.AddBool("skip_count", q.SkipCount)
+	}
 
 	// 忽略字段
 	if len(q.Omit) > 0 {
@@ -329,5 +410,10 @@ func (q *QueryParams) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 	} else {
 		enc.AddString("columns", "")
 	}
+
+	// 指定的超时
+	if q.Timeout > 0 {
+		enc.AddDuration("timeout", q.Timeout)
+	}
 	return nil
 }
@@ -0,0 +1,65 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gin-artweb/internal/shared/test"
+)
+
+type listItem struct {
+	ID   uint32 `gorm:"primaryKey"`
+	Name string
+}
+
+func TestDBListSkipCount(t *testing.T) {
+	db := test.NewTestGormDBWithConfig(nil)
+	require.NoError(t, db.AutoMigrate(&listItem{}))
+	items := make([]listItem, 0, 5)
+	for i := 1; i <= 5; i++ {
+		items = append(items, listItem{ID: uint32(i), Name: "item"})
+	}
+	require.NoError(t, db.Create(&items).Error)
+
+	cases := []struct {
+		page, size int
+		want       []uint32
+		count      int64
+		hasNext    bool
+	}{
+		{1, 2, []uint32{1, 2}, 3, true},
+		{2, 2, []uint32{3, 4}, 5, true},
+		{3, 2, []uint32{5}, 5, false},
+		{1, 5, []uint32{1, 2, 3, 4, 5}, 5, false},
+	}
+	for _, c := range cases {
+		var ms []listItem
+		qp := QueryParams{
+			OrderBy:   []string{"id ASC"},
+			Page:      c.page,
+			Size:      c.size,
+			IsCount:   true,
+			SkipCount: true,
+		}
+		count, err := DBList(context.Background(), db, &listItem{}, &ms, qp)
+		require.NoError(t, err)
+
+		var ids []uint32
+		for _, m := range ms {
+			ids = append(ids, m.ID)
+		}
+		assert.Equal(t, c.want, ids, "多查询的一条记录不返回: page=%d", c.page)
+		assert.Equal(t, c.count, count, "page=%d", c.page)
+		assert.Equal(t, c.hasNext, count > int64(c.page*c.size), "page=%d", c.page)
+	}
+
+	// 查询总数时返回真实的总数
+	var ms []listItem
+	count, err := DBList(context.Background(), db, &listItem{}, &ms, QueryParams{Page: 1, Size: 2, IsCount: true})
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), count)
+	assert.Len(t, ms, 2)
+}
@@ -0,0 +1,52 @@
+package database
+
+import (
+	"emperror.dev/errors"
+	"github.com/glebarez/sqlite"
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlserver"
+	"gorm.io/gorm"
+
+	"gin-artweb/internal/shared/database/driver/opengauss"
+)
+
+// 支持的数据库类型, 对应配置项database.type
+const (
+	DialectMySQL     = "mysql"
+	DialectPostgres  = "postgres"
+	DialectSQLite    = "sqlite"
+	DialectSQLServer = "sqlserver"
+	DialectOpenGauss = "opengauss"
+)
+
+// NewDialector 按数据库类型创建GORM方言, postgresql作为postgres的别名
+func NewDialector(typ, dns string) (gorm.Dialector, error) {
+	switch typ {
+	case DialectMySQL:
+		return mysql.Open(dns), nil
+	case DialectPostgres, "postgresql":
+		return postgres.Open(dns), nil
+	case DialectSQLite:
+		return sqlite.Open(dns), nil
+	case DialectSQLServer:
+		return sqlserver.Open(dns), nil
+	case DialectOpenGauss:
+		return opengauss.Open(dns), nil
+	default:
+		return nil, errors.NewWithDetails("不支持的数据库驱动类型", "type", typ)
+	}
+}
+
+// DateExpr 返回截取column日期部分的SQL表达式
+//
+// MySQL和SQLite使用DATE函数, 其他数据库使用CAST(... AS DATE);
+// SQLite中CAST(... AS DATE)按数值处理只会得到年份, 因此不能统一使用CAST
+func DateExpr(db *gorm.DB, column string) string {
+	switch db.Dialector.Name() {
+	case DialectMySQL, DialectSQLite:
+		return "DATE(" + column + ")"
+	default:
+		return "CAST(" + column + " AS DATE)"
+	}
+}
@@ -0,0 +1,37 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gin-artweb/internal/shared/test"
+)
+
+func TestNewDialector(t *testing.T) {
+	for _, typ := range []string{DialectMySQL, DialectPostgres, "postgresql", DialectSQLite, DialectSQLServer, DialectOpenGauss} {
+		d, err := NewDialector(typ, "")
+		require.NoError(t, err, typ)
+		assert.NotNil(t, d, typ)
+	}
+
+	_, err := NewDialector("oracle", "")
+	assert.Error(t, err)
+}
+
+type dateTestModel struct {
+	ID        uint32 `gorm:"primaryKey"`
+	CreatedAt time.Time
+}
+
+func TestDateExpr(t *testing.T) {
+	db := test.NewTestGormDBWithConfig(nil)
+	require.NoError(t, db.AutoMigrate(&dateTestModel{}))
+	require.NoError(t, db.Create(&dateTestModel{}).Error)
+
+	var day string
+	require.NoError(t, db.Model(&dateTestModel{}).Select(DateExpr(db, "created_at")).Scan(&day).Error)
+	assert.Equal(t, time.Now().Format(time.DateOnly), day[:len(time.DateOnly)])
+}
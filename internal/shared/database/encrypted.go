@@ -0,0 +1,248 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"sync/atomic"
+
+	"emperror.dev/errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+
+	"gin-artweb/pkg/crypto"
+)
+
+const (
+	// EncryptedSerializerName 加密字段的序列化器名称, 在模型字段上使用 `gorm:"serializer:encrypted"` 开启
+	EncryptedSerializerName = "encrypted"
+
+	encryptedPluginName = "artweb:encrypted_field"
+
+	// encryptBatchSize 存量数据加密时每批处理的行数
+	encryptBatchSize = 500
+)
+
+var fieldEncryptor atomic.Pointer[crypto.FieldEncryptor]
+
+func init() {
+	schema.RegisterSerializer(EncryptedSerializerName, EncryptedSerializer{})
+}
+
+// SetFieldEncryptor 设置全局的字段加密器, 为nil时加密字段按明文读写
+func SetFieldEncryptor(e *crypto.FieldEncryptor) {
+	fieldEncryptor.Store(e)
+}
+
+// GetFieldEncryptor 获取全局的字段加密器
+func GetFieldEncryptor() *crypto.FieldEncryptor {
+	return fieldEncryptor.Load()
+}
+
+// EncryptedSerializer 加密字段的GORM序列化器, 只支持字符串字段
+//
+// 写入时使用当前密钥加密, 读取时按密文中的密钥标识解密; 未加密的存量数据按明文读取
+type EncryptedSerializer struct{}
+
+func (EncryptedSerializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue any) error {
+	var value string
+	switch v := dbValue.(type) {
+	case nil:
+	case string:
+		value = v
+	case []byte:
+		value = string(v)
+	default:
+		return errors.Errorf("加密字段%s的类型%T不支持", field.Name, dbValue)
+	}
+
+	plaintext, err := decryptField(ctx, value)
+	if err != nil {
+		return errors.WrapIff(err, "解密字段%s失败", field.Name)
+	}
+	fieldValue := field.ReflectValueOf(ctx, dst)
+	if fieldValue.Kind() != reflect.String {
+		return errors.Errorf("加密字段%s的类型%s不支持", field.Name, fieldValue.Kind())
+	}
+	fieldValue.SetString(plaintext)
+	return nil
+}
+
+func (EncryptedSerializer) Value(ctx context.Context, field *schema.Field, dst reflect.Value, fieldValue any) (any, error) {
+	value, ok := fieldValue.(string)
+	if !ok {
+		return nil, errors.Errorf("加密字段%s的类型%T不支持", field.Name, fieldValue)
+	}
+	return encryptField(ctx, value)
+}
+
+func encryptField(ctx context.Context, value string) (string, error) {
+	e := GetFieldEncryptor()
+	if e == nil || crypto.IsFieldEncrypted(value) {
+		return value, nil
+	}
+	return e.Encrypt(ctx, value)
+}
+
+func decryptField(ctx context.Context, value string) (string, error) {
+	e := GetFieldEncryptor()
+	if e == nil {
+		if crypto.IsFieldEncrypted(value) {
+			return "", errors.New("字段已加密但未配置字段加密密钥")
+		}
+		return value, nil
+	}
+	return e.Decrypt(ctx, value)
+}
+
+// EncryptedFieldPlugin 加密以map方式更新的加密字段
+//
+// 以map更新时GORM不会调用字段的序列化器, 需要在更新前单独处理
+type EncryptedFieldPlugin struct{}
+
+func NewEncryptedFieldPlugin() *EncryptedFieldPlugin {
+	return &EncryptedFieldPlugin{}
+}
+
+func (p *EncryptedFieldPlugin) Name() string {
+	return encryptedPluginName
+}
+
+func (p *EncryptedFieldPlugin) Initialize(db *gorm.DB) error {
+	return db.Callback().Update().Before("gorm:update").Register(encryptedPluginName+":update", p.encryptUpdates)
+}
+
+func (p *EncryptedFieldPlugin) encryptUpdates(db *gorm.DB) {
+	if db.Error != nil || db.Statement.Schema == nil {
+		return
+	}
+	values, ok := db.Statement.Dest.(map[string]any)
+	if !ok {
+		return
+	}
+
+	var encrypted map[string]any
+	for k, v := range values {
+		field := db.Statement.Schema.LookUpField(k)
+		if field == nil || field.TagSettings["SERIALIZER"] != EncryptedSerializerName {
+			continue
+		}
+		s, ok := v.(string)
+		if !ok {
+			continue
+		}
+		value, err := encryptField(db.Statement.Context, s)
+		if err != nil {
+			db.AddError(errors.WrapIff(err, "加密字段%s失败", field.Name))
+			return
+		}
+		if encrypted == nil {
+			// 复制一份, 避免修改调用方的map
+			encrypted = make(map[string]any, len(values))
+			for ck, cv := range values {
+				encrypted[ck] = cv
+			}
+		}
+		encrypted[k] = value
+	}
+	if encrypted != nil {
+		db.Statement.Dest = encrypted
+	}
+}
+
+// EncryptColumns 使用当前密钥加密模型中未加密或由旧密钥加密的字段, 返回更新的行数
+//
+// 用于开启字段加密后迁移存量数据以及轮换字段加密密钥, 直接按列更新, 不触发钩子和更新时间
+func EncryptColumns(ctx context.Context, db *gorm.DB, m any, fields ...string) (int64, error) {
+	e := GetFieldEncryptor()
+	if e == nil {
+		return 0, errors.New("未配置字段加密密钥")
+	}
+
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(m); err != nil {
+		return 0, errors.WrapIf(err, "解析模型失败")
+	}
+	if stmt.Schema.PrioritizedPrimaryField == nil {
+		return 0, errors.Errorf("模型%s没有主键", stmt.Schema.Name)
+	}
+	pk := stmt.Schema.PrioritizedPrimaryField.DBName
+	table := stmt.Schema.Table
+
+	columns := make([]string, 0, len(fields))
+	for _, name := range fields {
+		field := stmt.Schema.LookUpField(name)
+		if field == nil || field.TagSettings["SERIALIZER"] != EncryptedSerializerName {
+			return 0, errors.Errorf("模型%s的字段%s不是加密字段", stmt.Schema.Name, name)
+		}
+		columns = append(columns, field.DBName)
+	}
+
+	var total int64
+	var lastID int64
+	for {
+		rows, err := db.WithContext(ctx).Table(table).
+			Select(append([]string{pk}, columns...)).
+			Where(pk+" > ?", lastID).
+			Order(pk).
+			Limit(encryptBatchSize).
+			Rows()
+		if err != nil {
+			return total, errors.WrapIf(err, "查询待加密数据失败")
+		}
+
+		type pending struct {
+			id     int64
+			values map[string]any
+		}
+		var updates []pending
+		count := 0
+		for rows.Next() {
+			count++
+			var id int64
+			raw := make([]sql.NullString, len(columns))
+			dest := []any{&id}
+			for i := range raw {
+				dest = append(dest, &raw[i])
+			}
+			if err := rows.Scan(dest...); err != nil {
+				rows.Close()
+				return total, errors.WrapIf(err, "读取待加密数据失败")
+			}
+			lastID = id
+
+			values := map[string]any{}
+			for i, col := range columns {
+				if !e.NeedsReencrypt(raw[i].String) {
+					continue
+				}
+				plaintext, err := e.Decrypt(ctx, raw[i].String)
+				if err != nil {
+					rows.Close()
+					return total, errors.WrapIff(err, "解密%s.%s(%d)失败", table, col, id)
+				}
+				if values[col], err = e.Encrypt(ctx, plaintext); err != nil {
+					rows.Close()
+					return total, err
+				}
+			}
+			if len(values) > 0 {
+				updates = append(updates, pending{id: id, values: values})
+			}
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return total, errors.WrapIf(err, "读取待加密数据失败")
+		}
+
+		for _, u := range updates {
+			if err := db.WithContext(ctx).Table(table).Where(pk+" = ?", u.id).UpdateColumns(u.values).Error; err != nil {
+				return total, errors.WrapIff(err, "加密%s(%d)失败", table, u.id)
+			}
+			total++
+		}
+		if count < encryptBatchSize {
+			return total, nil
+		}
+	}
+}
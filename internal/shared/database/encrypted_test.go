@@ -0,0 +1,114 @@
+package database
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"gin-artweb/internal/shared/test"
+	"gin-artweb/pkg/crypto"
+)
+
+type encryptedTestModel struct {
+	ID     uint32 `gorm:"primarykey"`
+	Name   string `gorm:"type:varchar(50)"`
+	Secret string `gorm:"type:text;serializer:encrypted"`
+}
+
+func newTestFieldEncryptor(t *testing.T, ids ...string) *crypto.FieldEncryptor {
+	items := make([]string, 0, len(ids))
+	for _, id := range ids {
+		items = append(items, id+":"+base64.StdEncoding.EncodeToString([]byte(strings.Repeat(id[:1], 32))))
+	}
+	keys, err := crypto.ParseFieldKeys(strings.Join(items, ","))
+	require.NoError(t, err)
+	e, err := crypto.NewFieldEncryptor(keys)
+	require.NoError(t, err)
+	return e
+}
+
+func newEncryptedTestDB(t *testing.T) *gorm.DB {
+	db := test.NewTestGormDBWithConfig(nil)
+	require.NoError(t, db.Use(NewEncryptedFieldPlugin()))
+	require.NoError(t, db.AutoMigrate(&encryptedTestModel{}))
+	t.Cleanup(func() {
+		SetFieldEncryptor(nil)
+		test.CloseTestGormDB(db)
+	})
+	return db
+}
+
+func rawSecret(t *testing.T, db *gorm.DB, id uint32) string {
+	var secret string
+	require.NoError(t, db.Table("encrypted_test_models").Select("secret").Where("id = ?", id).Scan(&secret).Error)
+	return secret
+}
+
+func TestEncryptedSerializer(t *testing.T) {
+	db := newEncryptedTestDB(t)
+	SetFieldEncryptor(newTestFieldEncryptor(t, "a1"))
+	ctx := context.Background()
+
+	m := encryptedTestModel{Name: "node", Secret: "password"}
+	require.NoError(t, db.WithContext(ctx).Create(&m).Error)
+	assert.True(t, strings.HasPrefix(rawSecret(t, db, m.ID), crypto.FieldCipherPrefix+"a1:"), "写入数据库的字段应该为密文")
+
+	var got encryptedTestModel
+	require.NoError(t, db.WithContext(ctx).First(&got, m.ID).Error)
+	assert.Equal(t, "password", got.Secret, "读取时应该解密")
+
+	// map更新同样加密, 且不修改调用方的map
+	data := map[string]any{"secret": "changed"}
+	require.NoError(t, db.WithContext(ctx).Model(&encryptedTestModel{}).Where("id = ?", m.ID).Updates(data).Error)
+	assert.Equal(t, "changed", data["secret"])
+	assert.True(t, crypto.IsFieldEncrypted(rawSecret(t, db, m.ID)), "map更新的字段应该为密文")
+	require.NoError(t, db.WithContext(ctx).First(&got, m.ID).Error)
+	assert.Equal(t, "changed", got.Secret)
+}
+
+func TestEncryptColumns(t *testing.T) {
+	db := newEncryptedTestDB(t)
+	ctx := context.Background()
+
+	// 开启加密前写入的明文数据
+	for _, name := range []string{"a", "b", "c"} {
+		require.NoError(t, db.Create(&encryptedTestModel{Name: name, Secret: "secret-" + name}).Error)
+	}
+	require.NoError(t, db.Create(&encryptedTestModel{Name: "empty"}).Error)
+	_, err := EncryptColumns(ctx, db, &encryptedTestModel{}, "Secret")
+	assert.Error(t, err, "未配置密钥时不能加密存量数据")
+
+	SetFieldEncryptor(newTestFieldEncryptor(t, "a1"))
+	var legacy encryptedTestModel
+	require.NoError(t, db.First(&legacy, 1).Error)
+	assert.Equal(t, "secret-a", legacy.Secret, "未加密的存量数据应该按明文读取")
+
+	n, err := EncryptColumns(ctx, db, &encryptedTestModel{}, "Secret")
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), n, "空值不需要加密")
+	assert.True(t, crypto.IsFieldEncrypted(rawSecret(t, db, 1)))
+
+	n, err = EncryptColumns(ctx, db, &encryptedTestModel{}, "Secret")
+	require.NoError(t, err)
+	assert.Zero(t, n, "已使用当前密钥加密的数据不需要重新加密")
+
+	// 轮换密钥后使用新密钥重新加密
+	SetFieldEncryptor(newTestFieldEncryptor(t, "b2", "a1"))
+	n, err = EncryptColumns(ctx, db, &encryptedTestModel{}, "Secret")
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), n)
+	assert.True(t, strings.HasPrefix(rawSecret(t, db, 2), crypto.FieldCipherPrefix+"b2:"))
+
+	SetFieldEncryptor(newTestFieldEncryptor(t, "b2"))
+	var got encryptedTestModel
+	require.NoError(t, db.First(&got, 2).Error)
+	assert.Equal(t, "secret-b", got.Secret)
+
+	_, err = EncryptColumns(ctx, db, &encryptedTestModel{}, "Name")
+	assert.Error(t, err, "非加密字段不能加密")
+}
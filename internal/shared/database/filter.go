@@ -0,0 +1,272 @@
+package database
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 过滤条件的限制, 避免客户端构造过大的查询
+const (
+	maxFilterGroups     = 10  // OR分组数
+	maxFilterConditions = 20  // 每组的条件数
+	maxFilterValues     = 100 // in的值个数
+)
+
+// FilterOp 过滤运算符
+type FilterOp string
+
+const (
+	FilterEq       FilterOp = "eq"       // 等于
+	FilterNe       FilterOp = "ne"       // 不等于
+	FilterGt       FilterOp = "gt"       // 大于
+	FilterGte      FilterOp = "gte"      // 大于等于
+	FilterLt       FilterOp = "lt"       // 小于
+	FilterLte      FilterOp = "lte"      // 小于等于
+	FilterContains FilterOp = "contains" // 包含, 仅用于字符串字段
+	FilterIn       FilterOp = "in"       // 在列表中, 多个值用,隔开
+	FilterBetween  FilterOp = "between"  // 在范围内(包含边界), 两个值用,隔开
+	FilterIsNull   FilterOp = "is_null"  // 为空, 不需要值
+	FilterNotNull  FilterOp = "not_null" // 不为空, 不需要值
+)
+
+// FilterType 字段值的类型, 解析时按类型转换值
+type FilterType int
+
+const (
+	FilterString FilterType = iota
+	FilterInt
+	FilterBool
+	FilterTime // 支持2006-01-02 15:04:05、2006-01-02和RFC3339格式, 不带时区时按本地时间
+)
+
+// FilterField 允许过滤的字段
+type FilterField struct {
+	Column string // 数据库列名
+	Type   FilterType
+}
+
+// FilterFields 允许过滤的字段, 键为客户端使用的字段名, 不在其中的字段不能过滤
+type FilterFields map[string]FilterField
+
+// FilterCond 单个过滤条件, 字段和值已经过校验
+type FilterCond struct {
+	Column string
+	Op     FilterOp
+	Values []any
+}
+
+// Filter 客户端提交的过滤条件
+//
+// 格式为"字段:运算符:值", 同一组内的条件用;隔开, 之间为AND;
+// 多组条件用|隔开, 之间为OR. 值中的特殊字符(\ ; | ,)需要用\转义.
+// 例如"status:in:0,1;created_at:gte:2024-01-01|name:contains:日终"
+type Filter struct {
+	Expr   string // 原始表达式, 用于记录日志
+	Groups [][]FilterCond
+}
+
+// ParseFilter 按允许的字段解析过滤表达式, 表达式为空时返回nil
+func ParseFilter(expr string, fields FilterFields) (*Filter, error) {
+	if strings.TrimSpace(expr) == "" {
+		return nil, nil
+	}
+
+	groups := splitEscaped(expr, '|')
+	if len(groups) > maxFilterGroups {
+		return nil, fmt.Errorf("过滤条件最多%d组", maxFilterGroups)
+	}
+	f := &Filter{Expr: expr, Groups: make([][]FilterCond, 0, len(groups))}
+	for _, group := range groups {
+		items := splitEscaped(group, ';')
+		if len(items) > maxFilterConditions {
+			return nil, fmt.Errorf("每组过滤条件最多%d个", maxFilterConditions)
+		}
+		conds := make([]FilterCond, 0, len(items))
+		for _, item := range items {
+			if strings.TrimSpace(item) == "" {
+				continue
+			}
+			cond, err := parseFilterCond(item, fields)
+			if err != nil {
+				return nil, err
+			}
+			conds = append(conds, cond)
+		}
+		if len(conds) == 0 {
+			return nil, fmt.Errorf("过滤条件分组为空")
+		}
+		f.Groups = append(f.Groups, conds)
+	}
+	return f, nil
+}
+
+func parseFilterCond(item string, fields FilterFields) (FilterCond, error) {
+	parts := strings.SplitN(item, ":", 3)
+	if len(parts) < 2 {
+		return FilterCond{}, fmt.Errorf("过滤条件格式错误: %s", item)
+	}
+	name, op := strings.TrimSpace(parts[0]), FilterOp(strings.TrimSpace(parts[1]))
+	field, ok := fields[name]
+	if !ok {
+		return FilterCond{}, fmt.Errorf("不支持过滤的字段: %s", name)
+	}
+	var raw string
+	if len(parts) == 3 {
+		raw = parts[2]
+	}
+
+	cond := FilterCond{Column: field.Column, Op: op}
+	var values []string
+	switch op {
+	case FilterIsNull, FilterNotNull:
+		if raw != "" {
+			return FilterCond{}, fmt.Errorf("运算符%s不需要值: %s", op, name)
+		}
+		return cond, nil
+	case FilterContains:
+		if field.Type != FilterString {
+			return FilterCond{}, fmt.Errorf("字段%s不支持运算符%s", name, op)
+		}
+		values = []string{unescape(raw)}
+	case FilterIn:
+		for _, v := range splitEscaped(raw, ',') {
+			values = append(values, unescape(v))
+		}
+		if len(values) > maxFilterValues {
+			return FilterCond{}, fmt.Errorf("运算符in最多%d个值: %s", maxFilterValues, name)
+		}
+	case FilterBetween:
+		for _, v := range splitEscaped(raw, ',') {
+			values = append(values, unescape(v))
+		}
+		if len(values) != 2 {
+			return FilterCond{}, fmt.Errorf("运算符between需要两个值: %s", name)
+		}
+	case FilterEq, FilterNe, FilterGt, FilterGte, FilterLt, FilterLte:
+		values = []string{unescape(raw)}
+	default:
+		return FilterCond{}, fmt.Errorf("不支持的运算符: %s", op)
+	}
+
+	for _, v := range values {
+		value, err := convertFilterValue(v, field.Type)
+		if err != nil {
+			return FilterCond{}, fmt.Errorf("字段%s的值%q格式错误", name, v)
+		}
+		cond.Values = append(cond.Values, value)
+	}
+	return cond, nil
+}
+
+func convertFilterValue(v string, t FilterType) (any, error) {
+	switch t {
+	case FilterInt:
+		return strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+	case FilterBool:
+		return strconv.ParseBool(strings.TrimSpace(v))
+	case FilterTime:
+		v = strings.TrimSpace(v)
+		for _, layout := range []string{time.DateTime, time.DateOnly} {
+			if t, err := time.ParseInLocation(layout, v, time.Local); err == nil {
+				return t, nil
+			}
+		}
+		return time.Parse(time.RFC3339, v)
+	default:
+		return v, nil
+	}
+}
+
+// Clause 转换为SQL条件和参数, 列名来自允许的字段, 值全部作为参数传递
+func (f *Filter) Clause() (string, []any) {
+	if f == nil || len(f.Groups) == 0 {
+		return "", nil
+	}
+	var args []any
+	groups := make([]string, 0, len(f.Groups))
+	for _, conds := range f.Groups {
+		parts := make([]string, 0, len(conds))
+		for _, c := range conds {
+			sql, cargs := c.clause()
+			parts = append(parts, sql)
+			args = append(args, cargs...)
+		}
+		groups = append(groups, "("+strings.Join(parts, " AND ")+")")
+	}
+	return "(" + strings.Join(groups, " OR ") + ")", args
+}
+
+func (c FilterCond) clause() (string, []any) {
+	switch c.Op {
+	case FilterNe:
+		return c.Column + " <> ?", c.Values
+	case FilterGt:
+		return c.Column + " > ?", c.Values
+	case FilterGte:
+		return c.Column + " >= ?", c.Values
+	case FilterLt:
+		return c.Column + " < ?", c.Values
+	case FilterLte:
+		return c.Column + " <= ?", c.Values
+	case FilterContains:
+		// 使用!作为转义符, 各数据库对\的处理不一致
+		return c.Column + " LIKE ? ESCAPE '!'", []any{"%" + escapeLike(c.Values[0].(string)) + "%"}
+	case FilterIn:
+		return c.Column + " IN ?", []any{c.Values}
+	case FilterBetween:
+		return c.Column + " BETWEEN ? AND ?", c.Values
+	case FilterIsNull:
+		return c.Column + " IS NULL", nil
+	case FilterNotNull:
+		return c.Column + " IS NOT NULL", nil
+	default:
+		return c.Column + " = ?", c.Values
+	}
+}
+
+// splitEscaped 按未转义的分隔符拆分, 保留转义符
+func splitEscaped(s string, sep byte) []string {
+	var parts []string
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case sep:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// unescape 去掉转义符
+func unescape(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// escapeLike 转义LIKE中的通配符
+func escapeLike(s string) string {
+	return strings.NewReplacer("!", "!!", "%", "!%", "_", "!_").Replace(s)
+}
+
+// ContainsFilter 任意一列包含q的过滤条件, 用于关键字搜索
+func ContainsFilter(q string, columns ...string) *Filter {
+	f := &Filter{Expr: "contains:" + q, Groups: make([][]FilterCond, 0, len(columns))}
+	for _, column := range columns {
+		f.Groups = append(f.Groups, []FilterCond{{Column: column, Op: FilterContains, Values: []any{q}}})
+	}
+	return f
+}
@@ -0,0 +1,111 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gin-artweb/internal/shared/test"
+)
+
+type filterItem struct {
+	ID        uint32 `gorm:"primaryKey"`
+	Name      string
+	Status    int
+	Remark    *string
+	CreatedAt time.Time
+}
+
+var filterItemFields = FilterFields{
+	"name":       {Column: "name", Type: FilterString},
+	"status":     {Column: "status", Type: FilterInt},
+	"remark":     {Column: "remark", Type: FilterString},
+	"created_at": {Column: "created_at", Type: FilterTime},
+}
+
+func TestParseFilterErrors(t *testing.T) {
+	cases := []string{
+		"password:eq:1",     // 未允许的字段
+		"name:like:a",       // 未知运算符
+		"name",              // 缺少运算符
+		"status:eq:abc",     // 类型错误
+		"status:contains:1", // contains仅用于字符串
+		"status:between:1",  // between需要两个值
+		"remark:is_null:1",  // is_null不需要值
+		"created_at:gte:yesterday",
+		"name:eq:a|", // 空分组
+	}
+	for _, expr := range cases {
+		_, err := ParseFilter(expr, filterItemFields)
+		assert.Error(t, err, expr)
+	}
+
+	f, err := ParseFilter("  ", filterItemFields)
+	assert.NoError(t, err)
+	assert.Nil(t, f)
+}
+
+func TestFilterClause(t *testing.T) {
+	f, err := ParseFilter(`status:in:1,2;name:contains:a\,b|remark:is_null`, filterItemFields)
+	require.NoError(t, err)
+	sql, args := f.Clause()
+	assert.Equal(t, "((status IN ? AND name LIKE ? ESCAPE '!') OR (remark IS NULL))", sql)
+	assert.Equal(t, []any{[]any{int64(1), int64(2)}, "%a,b%"}, args)
+}
+
+func TestDBListFilter(t *testing.T) {
+	db := test.NewTestGormDBWithConfig(nil)
+	require.NoError(t, db.AutoMigrate(&filterItem{}))
+
+	remark := "50%_off"
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)
+	items := []filterItem{
+		{ID: 1, Name: "日终清算", Status: 0, CreatedAt: base},
+		{ID: 2, Name: "日间备份", Status: 1, CreatedAt: base.AddDate(0, 0, 1)},
+		{ID: 3, Name: "行情采集", Status: 2, Remark: &remark, CreatedAt: base.AddDate(0, 0, 2)},
+		{ID: 4, Name: "日志清理", Status: 3, CreatedAt: base.AddDate(0, 0, 3)},
+	}
+	require.NoError(t, db.Create(&items).Error)
+
+	cases := []struct {
+		expr string
+		want []uint32
+	}{
+		{"name:eq:日终清算", []uint32{1}},
+		{"status:ne:0", []uint32{2, 3, 4}},
+		{"name:contains:日", []uint32{1, 2, 4}},
+		{"remark:contains:%_", []uint32{3}},
+		{"remark:contains:_x", nil},
+		{"status:in:0,2", []uint32{1, 3}},
+		{"status:gte:2", []uint32{3, 4}},
+		{"status:lt:1", []uint32{1}},
+		{"status:between:1,2", []uint32{2, 3}},
+		{"created_at:gte:2024-01-02;created_at:lte:2024-01-03 00:00:00", []uint32{2, 3}},
+		{"remark:not_null", []uint32{3}},
+		{"status:eq:0|status:eq:3;name:contains:清理", []uint32{1, 4}},
+	}
+	for _, c := range cases {
+		f, err := ParseFilter(c.expr, filterItemFields)
+		require.NoError(t, err, c.expr)
+
+		var ms []filterItem
+		qp := QueryParams{
+			Query:   map[string]any{"id <> ?": 99},
+			Filter:  f,
+			OrderBy: []string{"id ASC"},
+			IsCount: true,
+		}
+		count, err := DBList(context.Background(), db, &filterItem{}, &ms, qp)
+		require.NoError(t, err, c.expr)
+
+		var ids []uint32
+		for _, m := range ms {
+			ids = append(ids, m.ID)
+		}
+		assert.Equal(t, c.want, ids, c.expr)
+		assert.Equal(t, int64(len(c.want)), count, c.expr)
+	}
+}
@@ -0,0 +1,100 @@
+package database
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Health 定时探测数据库连接的健康状态
+//
+// 探测失败后进入降级状态, 连接池在下次使用时自动重新建立连接, 探测成功后恢复正常状态.
+// nil实例始终处于正常状态
+type Health struct {
+	db       *gorm.DB
+	interval time.Duration
+	onChange func(down bool, err error)
+
+	down  atomic.Bool
+	since atomic.Int64 // 进入当前状态的时间(unix纳秒)
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// NewHealth 创建数据库健康探测器, 状态变化时调用onChange, down为true表示进入降级状态
+func NewHealth(db *gorm.DB, interval time.Duration, onChange func(down bool, err error)) *Health {
+	h := &Health{
+		db:       db,
+		interval: interval,
+		onChange: onChange,
+		stop:     make(chan struct{}),
+	}
+	h.since.Store(time.Now().UnixNano())
+	return h
+}
+
+// Start 启动后台探测, interval小于等于0时不探测
+func (h *Health) Start() {
+	if h == nil || h.interval <= 0 {
+		return
+	}
+	h.wg.Add(1)
+	go func() {
+		defer h.wg.Done()
+		ticker := time.NewTicker(h.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-h.stop:
+				return
+			case <-ticker.C:
+				ctx, cancel := context.WithTimeout(context.Background(), h.interval)
+				_ = h.Probe(ctx)
+				cancel()
+			}
+		}
+	}()
+}
+
+// Stop 停止后台探测并等待正在进行的探测结束
+func (h *Health) Stop() {
+	if h == nil {
+		return
+	}
+	h.stopOnce.Do(func() { close(h.stop) })
+	h.wg.Wait()
+}
+
+// Probe 立即探测一次数据库连接并更新状态
+func (h *Health) Probe(ctx context.Context) error {
+	sqlDB, err := h.db.DB()
+	if err == nil {
+		err = sqlDB.PingContext(ctx)
+	}
+	down := err != nil
+	if h.down.CompareAndSwap(!down, down) {
+		h.since.Store(time.Now().UnixNano())
+		if h.onChange != nil {
+			h.onChange(down, err)
+		}
+	}
+	return err
+}
+
+// Degraded 数据库是否处于不可用的降级状态
+func (h *Health) Degraded() bool {
+	return h != nil && h.down.Load()
+}
+
+// Since 进入当前状态的时间
+func (h *Health) Since() time.Time {
+	if h == nil {
+		return time.Time{}
+	}
+	return time.Unix(0, h.since.Load())
+}
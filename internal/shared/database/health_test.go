@@ -0,0 +1,86 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"gin-artweb/internal/shared/test"
+)
+
+func TestOpenWithRetry(t *testing.T) {
+	db := test.NewTestGormDBWithConfig(nil)
+	openErr := errors.New("connection refused")
+
+	calls := 0
+	var waits []time.Duration
+	got, err := OpenWithRetry(
+		context.Background(),
+		RetrySettings{Attempts: 4, InitialInterval: time.Millisecond, MaxInterval: 3 * time.Millisecond},
+		func() (*gorm.DB, error) {
+			calls++
+			if calls < 4 {
+				return nil, openErr
+			}
+			return db, nil
+		},
+		func(attempt int, wait time.Duration, err error) {
+			waits = append(waits, wait)
+		},
+	)
+	require.NoError(t, err)
+	assert.Same(t, db, got)
+	assert.Equal(t, []time.Duration{time.Millisecond, 2 * time.Millisecond, 3 * time.Millisecond}, waits, "重试间隔应该翻倍且不超过最大间隔")
+
+	calls = 0
+	_, err = OpenWithRetry(
+		context.Background(),
+		RetrySettings{Attempts: 2, InitialInterval: time.Millisecond},
+		func() (*gorm.DB, error) {
+			calls++
+			return nil, openErr
+		},
+		nil,
+	)
+	assert.ErrorIs(t, err, openErr)
+	assert.Equal(t, 2, calls, "达到最多尝试次数后应该返回最后一次的错误")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	calls = 0
+	_, err = OpenWithRetry(ctx, RetrySettings{Attempts: 5, InitialInterval: time.Hour}, func() (*gorm.DB, error) {
+		calls++
+		return nil, openErr
+	}, nil)
+	assert.ErrorIs(t, err, openErr)
+	assert.Equal(t, 1, calls, "ctx取消后应该停止重试")
+}
+
+func TestHealth(t *testing.T) {
+	db := test.NewTestGormDBWithConfig(nil)
+
+	var changes []bool
+	h := NewHealth(db, 0, func(down bool, err error) {
+		changes = append(changes, down)
+	})
+	require.NoError(t, h.Probe(context.Background()))
+	assert.False(t, h.Degraded())
+	assert.Empty(t, changes, "状态没有变化时不应该回调")
+
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	require.NoError(t, sqlDB.Close())
+	assert.Error(t, h.Probe(context.Background()))
+	assert.True(t, h.Degraded())
+	assert.Equal(t, []bool{true}, changes)
+
+	var nilHealth *Health
+	assert.False(t, nilHealth.Degraded(), "nil实例应该始终处于正常状态")
+	nilHealth.Start()
+	nilHealth.Stop()
+}
@@ -0,0 +1,201 @@
+package database
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"emperror.dev/errors"
+	"gorm.io/gorm"
+)
+
+// ErrSchemaMismatch 数据库结构版本与程序不一致
+var ErrSchemaMismatch = errors.Sentinel("数据库结构版本与程序不一致")
+
+// Migration 一个版本化的数据库迁移
+//
+// ID为迁移版本号, 按字典序决定执行顺序, 一经发布不能修改;
+// 结构变化必须新增迁移, 不能修改已发布的迁移
+type Migration struct {
+	ID          string
+	Description string
+	Migrate     func(tx *gorm.DB) error
+	Rollback    func(tx *gorm.DB) error
+}
+
+// SchemaMigrationModel 已执行的迁移记录
+type SchemaMigrationModel struct {
+	ID          string    `gorm:"column:id;type:varchar(64);primaryKey;comment:迁移版本号" json:"id"`
+	Description string    `gorm:"column:description;type:varchar(254);comment:说明" json:"description"`
+	AppliedAt   time.Time `gorm:"column:applied_at;comment:执行时间" json:"applied_at"`
+}
+
+func (m *SchemaMigrationModel) TableName() string {
+	return "schema_migrations"
+}
+
+// MigrationStatus 迁移的执行状态
+type MigrationStatus struct {
+	ID          string     `json:"id"`
+	Description string     `json:"description"`
+	Applied     bool       `json:"applied"`
+	AppliedAt   *time.Time `json:"applied_at"`
+	Unknown     bool       `json:"unknown"` // 数据库中存在但程序中没有的迁移, 说明数据库由更新的程序迁移过
+}
+
+// Migrator 版本化迁移执行器
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+// NewMigrator 创建迁移执行器, 迁移按ID排序
+func NewMigrator(db *gorm.DB, migrations []Migration) *Migrator {
+	ms := make([]Migration, len(migrations))
+	copy(ms, migrations)
+	sort.SliceStable(ms, func(i, j int) bool { return ms[i].ID < ms[j].ID })
+	return &Migrator{db: db, migrations: ms}
+}
+
+// Latest 返回程序中最新的迁移版本号
+func (m *Migrator) Latest() string {
+	if len(m.migrations) == 0 {
+		return ""
+	}
+	return m.migrations[len(m.migrations)-1].ID
+}
+
+// applied 查询已执行的迁移, 迁移记录表不存在时返回空
+func (m *Migrator) applied(ctx context.Context) (map[string]SchemaMigrationModel, error) {
+	db := m.db.WithContext(ctx)
+	records := map[string]SchemaMigrationModel{}
+	if !db.Migrator().HasTable(&SchemaMigrationModel{}) {
+		return records, nil
+	}
+	var ms []SchemaMigrationModel
+	if err := db.Order("id ASC").Find(&ms).Error; err != nil {
+		return nil, errors.WrapIf(err, "查询已执行的迁移失败")
+	}
+	for _, r := range ms {
+		records[r.ID] = r
+	}
+	return records, nil
+}
+
+// Status 返回全部迁移的执行状态
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	records, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]MigrationStatus, 0, len(m.migrations)+len(records))
+	for _, mg := range m.migrations {
+		st := MigrationStatus{ID: mg.ID, Description: mg.Description}
+		if r, ok := records[mg.ID]; ok {
+			st.Applied = true
+			st.AppliedAt = &r.AppliedAt
+			delete(records, mg.ID)
+		}
+		statuses = append(statuses, st)
+	}
+	for _, r := range records {
+		statuses = append(statuses, MigrationStatus{
+			ID:          r.ID,
+			Description: r.Description,
+			Applied:     true,
+			AppliedAt:   &r.AppliedAt,
+			Unknown:     true,
+		})
+	}
+	sort.SliceStable(statuses, func(i, j int) bool { return statuses[i].ID < statuses[j].ID })
+	return statuses, nil
+}
+
+// Check 校验数据库已执行全部迁移且没有程序未知的迁移
+func (m *Migrator) Check(ctx context.Context) error {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+	var pending, unknown []string
+	for _, st := range statuses {
+		switch {
+		case st.Unknown:
+			unknown = append(unknown, st.ID)
+		case !st.Applied:
+			pending = append(pending, st.ID)
+		}
+	}
+	if len(pending) > 0 || len(unknown) > 0 {
+		return errors.WithDetails(
+			errors.WithStack(ErrSchemaMismatch),
+			"pending", pending,
+			"unknown", unknown,
+		)
+	}
+	return nil
+}
+
+// Up 按顺序执行未执行的迁移, 每个迁移在独立的事务中执行, 返回本次执行的迁移版本号
+func (m *Migrator) Up(ctx context.Context) ([]string, error) {
+	db := m.db.WithContext(ctx)
+	if err := db.AutoMigrate(&SchemaMigrationModel{}); err != nil {
+		return nil, errors.WrapIf(err, "创建迁移记录表失败")
+	}
+	records, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []string
+	for _, mg := range m.migrations {
+		if _, ok := records[mg.ID]; ok {
+			continue
+		}
+		err := db.Transaction(func(tx *gorm.DB) error {
+			if mg.Migrate != nil {
+				if err := mg.Migrate(tx); err != nil {
+					return err
+				}
+			}
+			return tx.Create(&SchemaMigrationModel{
+				ID:          mg.ID,
+				Description: mg.Description,
+				AppliedAt:   time.Now(),
+			}).Error
+		})
+		if err != nil {
+			return done, errors.WrapIfWithDetails(err, "执行迁移失败", "id", mg.ID)
+		}
+		done = append(done, mg.ID)
+	}
+	return done, nil
+}
+
+// Down 回滚最近执行的一个迁移, 没有可回滚的迁移时返回空字符串
+func (m *Migrator) Down(ctx context.Context) (string, error) {
+	records, err := m.applied(ctx)
+	if err != nil {
+		return "", err
+	}
+	for i := len(m.migrations) - 1; i >= 0; i-- {
+		mg := m.migrations[i]
+		if _, ok := records[mg.ID]; !ok {
+			continue
+		}
+		if mg.Rollback == nil {
+			return "", errors.Errorf("迁移不支持回滚, id=%s", mg.ID)
+		}
+		err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := mg.Rollback(tx); err != nil {
+				return err
+			}
+			return tx.Delete(&SchemaMigrationModel{}, "id = ?", mg.ID).Error
+		})
+		if err != nil {
+			return "", errors.WrapIfWithDetails(err, "回滚迁移失败", "id", mg.ID)
+		}
+		return mg.ID, nil
+	}
+	return "", nil
+}
@@ -0,0 +1,106 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	"gin-artweb/internal/shared/test"
+)
+
+type migrateTestModel struct {
+	ID   uint32 `gorm:"primaryKey"`
+	Name string
+}
+
+func (m *migrateTestModel) TableName() string {
+	return "migrate_test"
+}
+
+type MigratorTestSuite struct {
+	suite.Suite
+	db *gorm.DB
+}
+
+func (suite *MigratorTestSuite) SetupTest() {
+	suite.db = test.NewTestGormDBWithConfig(nil)
+}
+
+func (suite *MigratorTestSuite) migrations() []Migration {
+	return []Migration{
+		{
+			ID:          "000002",
+			Description: "新增字段",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.Exec("ALTER TABLE migrate_test ADD COLUMN descr varchar(50)").Error
+			},
+			Rollback: func(tx *gorm.DB) error {
+				return tx.Exec("ALTER TABLE migrate_test DROP COLUMN descr").Error
+			},
+		},
+		{
+			ID:          "000001",
+			Description: "创建表",
+			Migrate: func(tx *gorm.DB) error {
+				return tx.AutoMigrate(&migrateTestModel{})
+			},
+		},
+	}
+}
+
+func (suite *MigratorTestSuite) TestUpAndCheck() {
+	m := NewMigrator(suite.db, suite.migrations())
+	suite.Equal("000002", m.Latest())
+
+	err := m.Check(context.Background())
+	suite.ErrorIs(err, ErrSchemaMismatch, "未执行迁移时校验应该失败")
+
+	done, err := m.Up(context.Background())
+	suite.NoError(err)
+	suite.Equal([]string{"000001", "000002"}, done, "迁移应该按版本号顺序执行")
+	suite.True(suite.db.Migrator().HasColumn(&migrateTestModel{}, "descr"))
+	suite.NoError(m.Check(context.Background()))
+
+	done, err = m.Up(context.Background())
+	suite.NoError(err)
+	suite.Empty(done, "重复执行时不应该再执行已执行的迁移")
+}
+
+func (suite *MigratorTestSuite) TestStatusWithUnknownMigration() {
+	_, err := NewMigrator(suite.db, suite.migrations()).Up(context.Background())
+	suite.NoError(err)
+
+	// 旧版本程序只知道第一个迁移
+	old := NewMigrator(suite.db, suite.migrations()[1:])
+	statuses, err := old.Status(context.Background())
+	suite.NoError(err)
+	suite.Len(statuses, 2)
+	suite.False(statuses[0].Unknown)
+	suite.True(statuses[1].Unknown, "数据库中存在程序未知的迁移")
+	suite.ErrorIs(old.Check(context.Background()), ErrSchemaMismatch)
+}
+
+func (suite *MigratorTestSuite) TestDown() {
+	m := NewMigrator(suite.db, suite.migrations())
+	_, err := m.Up(context.Background())
+	suite.NoError(err)
+
+	id, err := m.Down(context.Background())
+	suite.NoError(err)
+	suite.Equal("000002", id)
+	suite.False(suite.db.Migrator().HasColumn(&migrateTestModel{}, "descr"))
+
+	_, err = m.Down(context.Background())
+	suite.Error(err, "不支持回滚的迁移应该返回错误")
+
+	statuses, err := m.Status(context.Background())
+	suite.NoError(err)
+	suite.True(statuses[0].Applied)
+	suite.False(statuses[1].Applied)
+}
+
+func TestMigratorTestSuite(t *testing.T) {
+	suite.Run(t, new(MigratorTestSuite))
+}
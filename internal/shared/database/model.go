@@ -21,6 +21,7 @@ type StandardModel struct {
 
 	CreatedAt time.Time `gorm:"column:created_at;autoCreateTime;comment:创建时间" json:"created_at"`
 	UpdatedAt time.Time `gorm:"column:updated_at;autoUpdateTime;comment:修改时间" json:"updated_at"`
+	Version   uint32    `gorm:"column:version;not null;default:1;comment:版本号" json:"version"`
 	// DeletedAt gorm.DeletedAt `gorm:"column:deleted_at;index;comment:删除时间" json:"deleted_at,omitzero"`
 }
 
@@ -40,6 +41,7 @@ func (m *StandardModel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 	}
 	enc.AddTime("created_at", m.CreatedAt)
 	enc.AddTime("updated_at", m.UpdatedAt)
+	enc.AddUint32("version", m.Version)
 	// if m.DeletedAt.Valid {
 	// 	enc.AddTime("deleted_at", m.DeletedAt.Time)
 	// }
@@ -0,0 +1,51 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// RetrySettings 连接数据库失败的重试参数
+type RetrySettings struct {
+	Attempts        int           // 最多尝试次数, 小于等于1时不重试
+	InitialInterval time.Duration // 首次重试间隔, 之后每次翻倍, 小于等于0时为1秒
+	MaxInterval     time.Duration // 最大重试间隔, 小于等于0时不限制
+}
+
+// OpenWithRetry 调用open连接数据库, 失败时按指数退避重试, 返回最后一次的错误
+//
+// 每次重试前以本次的失败次数、等待时间和错误调用onRetry, 用于记录日志; ctx取消时停止重试
+func OpenWithRetry(
+	ctx context.Context,
+	s RetrySettings,
+	open func() (*gorm.DB, error),
+	onRetry func(attempt int, wait time.Duration, err error),
+) (*gorm.DB, error) {
+	wait := s.InitialInterval
+	if wait <= 0 {
+		wait = time.Second
+	}
+	for attempt := 1; ; attempt++ {
+		db, err := open()
+		if err == nil || attempt >= s.Attempts {
+			return db, err
+		}
+		if onRetry != nil {
+			onRetry(attempt, wait, err)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, err
+		case <-timer.C:
+		}
+		wait *= 2
+		if s.MaxInterval > 0 && wait > s.MaxInterval {
+			wait = s.MaxInterval
+		}
+	}
+}
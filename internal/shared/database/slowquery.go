@@ -0,0 +1,156 @@
+package database
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"regexp"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"gin-artweb/internal/shared/ctxutil"
+)
+
+const (
+	slowQueryPluginName = "artweb:slow_query"
+	slowQueryStartKey   = "artweb:slow_query_start"
+
+	// maxSlowQuerySQLLen 记录的SQL语句最大长度
+	maxSlowQuerySQLLen = 2000
+)
+
+// SlowQuery 一次超过阈值的SQL执行
+type SlowQuery struct {
+	Fingerprint string        // 归一化后SQL的摘要
+	SQL         string        // 归一化后的SQL
+	Table       string        // 操作的表名
+	Duration    time.Duration // 执行耗时
+	Rows        int64         // 影响或返回的行数
+	TraceID     string        // 调用链路ID
+	At          time.Time     // 执行完成时间
+}
+
+// SlowQueryRecorder 慢查询的接收方, Record在SQL执行的协程中调用, 不能阻塞
+type SlowQueryRecorder interface {
+	Record(q SlowQuery)
+}
+
+// SlowQueryPlugin 记录执行时间超过阈值的SQL的GORM插件
+type SlowQueryPlugin struct {
+	threshold time.Duration
+	recorder  SlowQueryRecorder
+}
+
+func NewSlowQueryPlugin(threshold time.Duration, recorder SlowQueryRecorder) *SlowQueryPlugin {
+	return &SlowQueryPlugin{
+		threshold: threshold,
+		recorder:  recorder,
+	}
+}
+
+func (p *SlowQueryPlugin) Name() string {
+	return slowQueryPluginName
+}
+
+// Initialize 在各类操作的回调前后记录执行时间
+func (p *SlowQueryPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	if err := cb.Create().Before("gorm:create").Register(slowQueryPluginName+":before_create", p.before); err != nil {
+		return err
+	}
+	if err := cb.Create().After("gorm:create").Register(slowQueryPluginName+":after_create", p.after); err != nil {
+		return err
+	}
+	if err := cb.Query().Before("gorm:query").Register(slowQueryPluginName+":before_query", p.before); err != nil {
+		return err
+	}
+	if err := cb.Query().After("gorm:query").Register(slowQueryPluginName+":after_query", p.after); err != nil {
+		return err
+	}
+	if err := cb.Update().Before("gorm:update").Register(slowQueryPluginName+":before_update", p.before); err != nil {
+		return err
+	}
+	if err := cb.Update().After("gorm:update").Register(slowQueryPluginName+":after_update", p.after); err != nil {
+		return err
+	}
+	if err := cb.Delete().Before("gorm:delete").Register(slowQueryPluginName+":before_delete", p.before); err != nil {
+		return err
+	}
+	if err := cb.Delete().After("gorm:delete").Register(slowQueryPluginName+":after_delete", p.after); err != nil {
+		return err
+	}
+	if err := cb.Row().Before("gorm:row").Register(slowQueryPluginName+":before_row", p.before); err != nil {
+		return err
+	}
+	if err := cb.Row().After("gorm:row").Register(slowQueryPluginName+":after_row", p.after); err != nil {
+		return err
+	}
+	if err := cb.Raw().Before("gorm:raw").Register(slowQueryPluginName+":before_raw", p.before); err != nil {
+		return err
+	}
+	return cb.Raw().After("gorm:raw").Register(slowQueryPluginName+":after_raw", p.after)
+}
+
+func (p *SlowQueryPlugin) before(db *gorm.DB) {
+	db.InstanceSet(slowQueryStartKey, time.Now())
+}
+
+func (p *SlowQueryPlugin) after(db *gorm.DB) {
+	v, ok := db.InstanceGet(slowQueryStartKey)
+	if !ok {
+		return
+	}
+	start, ok := v.(time.Time)
+	if !ok {
+		return
+	}
+	elapsed := time.Since(start)
+	if elapsed < p.threshold {
+		return
+	}
+
+	sql := NormalizeSQL(db.Statement.SQL.String())
+	if sql == "" {
+		return
+	}
+	p.recorder.Record(SlowQuery{
+		Fingerprint: SQLFingerprint(sql),
+		SQL:         sql,
+		Table:       db.Statement.Table,
+		Duration:    elapsed,
+		Rows:        db.Statement.RowsAffected,
+		TraceID:     ctxutil.GetTraceID(db.Statement.Context),
+		At:          time.Now(),
+	})
+}
+
+var (
+	sqlStringPattern = regexp.MustCompile(`'(?:[^']|'')*'`)
+	sqlNumberPattern = regexp.MustCompile(`\b\d+(?:\.\d+)?\b`)
+	sqlInListPattern = regexp.MustCompile(`(?i)\bIN\s*\(\s*\?(?:\s*,\s*\?)*\s*\)`)
+	sqlValuesPattern = regexp.MustCompile(`(?i)\bVALUES\s*\([^)]*\)(?:\s*,\s*\([^)]*\))*`)
+	sqlSpacePattern  = regexp.MustCompile(`\s+`)
+	sqlDollarPattern = regexp.MustCompile(`\$\d+`)
+)
+
+// NormalizeSQL 将SQL中的字面量和占位符统一替换为?, 使同一语句的不同参数归为一类
+func NormalizeSQL(sql string) string {
+	sql = strings.TrimSpace(sql)
+	sql = sqlStringPattern.ReplaceAllString(sql, "?")
+	sql = sqlDollarPattern.ReplaceAllString(sql, "?")
+	sql = sqlNumberPattern.ReplaceAllString(sql, "?")
+	sql = sqlSpacePattern.ReplaceAllString(sql, " ")
+	sql = sqlInListPattern.ReplaceAllString(sql, "IN (?)")
+	sql = sqlValuesPattern.ReplaceAllString(sql, "VALUES (?)")
+	if len(sql) > maxSlowQuerySQLLen {
+		sql = sql[:maxSlowQuerySQLLen]
+	}
+	return sql
+}
+
+// SQLFingerprint 计算归一化SQL的摘要
+func SQLFingerprint(normalized string) string {
+	sum := sha1.Sum([]byte(strings.ToLower(normalized)))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,79 @@
+package database
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/test"
+)
+
+type slowQueryCollector struct {
+	mu sync.Mutex
+	qs []SlowQuery
+}
+
+func (c *slowQueryCollector) Record(q SlowQuery) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.qs = append(c.qs, q)
+}
+
+func TestNormalizeSQL(t *testing.T) {
+	cases := []struct {
+		sql  string
+		want string
+	}{
+		{
+			sql:  "SELECT * FROM `customer_user` WHERE username = 'admin' AND id = 12 LIMIT 1",
+			want: "SELECT * FROM `customer_user` WHERE username = ? AND id = ? LIMIT ?",
+		},
+		{
+			sql:  "SELECT * FROM jobs_script WHERE id IN (1,2, 3)\n\tORDER BY id",
+			want: "SELECT * FROM jobs_script WHERE id IN (?) ORDER BY id",
+		},
+		{
+			sql:  `UPDATE "mon_node" SET "name"=$1,"updated_at"=$2 WHERE "id" = $3`,
+			want: `UPDATE "mon_node" SET "name"=?,"updated_at"=? WHERE "id" = ?`,
+		},
+		{
+			sql:  "INSERT INTO t (a,b) VALUES ('x',1),('it''s',2)",
+			want: "INSERT INTO t (a,b) VALUES (?)",
+		},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.want, NormalizeSQL(c.sql))
+	}
+}
+
+func TestSQLFingerprint(t *testing.T) {
+	a := SQLFingerprint(NormalizeSQL("select * from t where id = 1"))
+	b := SQLFingerprint(NormalizeSQL("SELECT *  FROM t WHERE id = 2"))
+	assert.Equal(t, a, b, "仅参数和大小写不同的语句应该有相同的指纹")
+	assert.Len(t, a, 40)
+	assert.NotEqual(t, a, SQLFingerprint(NormalizeSQL("select * from t where name = 'a'")))
+}
+
+func TestSlowQueryPlugin(t *testing.T) {
+	db := test.NewTestGormDBWithConfig(nil)
+	require.NoError(t, db.AutoMigrate(&migrateTestModel{}))
+
+	c := &slowQueryCollector{}
+	require.NoError(t, db.Use(NewSlowQueryPlugin(0, c)))
+
+	ctx := context.WithValue(context.Background(), ctxutil.TraceIDKey, "trace-1")
+	require.NoError(t, db.WithContext(ctx).Create(&migrateTestModel{Name: "a"}).Error)
+	var ms []migrateTestModel
+	require.NoError(t, db.WithContext(ctx).Where("name = ?", "a").Find(&ms).Error)
+
+	require.Len(t, c.qs, 2, "阈值为0时应该记录每一条语句")
+	q := c.qs[1]
+	assert.Equal(t, "migrate_test", q.Table)
+	assert.Contains(t, q.SQL, "WHERE name = ?")
+	assert.Equal(t, "trace-1", q.TraceID)
+	assert.Equal(t, SQLFingerprint(q.SQL), q.Fingerprint)
+}
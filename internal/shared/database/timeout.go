@@ -0,0 +1,120 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+	"gorm.io/gorm"
+
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/deadline"
+)
+
+const timeoutPluginName = "artweb:timeout"
+
+type queryTimeoutKey struct{}
+
+// WithQueryTimeout 为上下文中后续的数据库操作指定超时, 覆盖配置的默认超时
+//
+// 用于导出等耗时较长或交互场景要求更快失败的操作, timeout不大于0时不覆盖
+func WithQueryTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	if timeout <= 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, queryTimeoutKey{}, timeout)
+}
+
+// QueryTimeout 返回上下文中指定的数据库操作超时
+func QueryTimeout(ctx context.Context) (time.Duration, bool) {
+	timeout, ok := ctx.Value(queryTimeoutKey{}).(time.Duration)
+	return timeout, ok && timeout > 0
+}
+
+// QueryContext 从调用方上下文派生数据库操作的上下文
+//
+// 上下文中通过WithQueryTimeout指定了超时时优先使用, 否则使用默认超时.
+// 派生的上下文随调用方上下文一起取消, 请求的客户端断开时查询随之取消;
+// 客户端指定了请求处理时限时登记为数据库阶段, 取消函数恢复为之前的阶段
+func QueryContext(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if t, ok := QueryTimeout(ctx); ok {
+		timeout = t
+	}
+	leave := deadline.Enter(ctx, deadline.StageDatabase)
+	var (
+		dbCtx  context.Context
+		cancel context.CancelFunc
+	)
+	if timeout <= 0 {
+		dbCtx, cancel = context.WithCancel(ctx)
+	} else {
+		dbCtx, cancel = context.WithTimeout(ctx, timeout)
+	}
+	return dbCtx, func() {
+		cancel()
+		leave()
+	}
+}
+
+// ReadContext 派生查询单条数据的上下文
+func ReadContext(ctx context.Context, t *config.DBTimeout) (context.Context, context.CancelFunc) {
+	return QueryContext(ctx, t.ReadTimeout)
+}
+
+// WriteContext 派生写操作的上下文
+func WriteContext(ctx context.Context, t *config.DBTimeout) (context.Context, context.CancelFunc) {
+	return QueryContext(ctx, t.WriteTimeout)
+}
+
+// ListContext 派生查询列表的上下文
+func ListContext(ctx context.Context, t *config.DBTimeout) (context.Context, context.CancelFunc) {
+	return QueryContext(ctx, t.ListTimeout)
+}
+
+// IsTimeout 判断数据库操作是否因超时失败
+func IsTimeout(err error) bool {
+	return errors.Is(err, context.DeadlineExceeded)
+}
+
+// TimeoutPlugin 统计数据库操作超时次数的GORM插件
+type TimeoutPlugin struct {
+	onTimeout func(op string)
+}
+
+// NewTimeoutPlugin 创建超时统计插件, 操作因超时失败时以操作类型调用onTimeout
+func NewTimeoutPlugin(onTimeout func(op string)) *TimeoutPlugin {
+	return &TimeoutPlugin{onTimeout: onTimeout}
+}
+
+func (p *TimeoutPlugin) Name() string {
+	return timeoutPluginName
+}
+
+// Initialize 在各类操作的回调后检查是否超时
+func (p *TimeoutPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	if err := cb.Create().After("gorm:create").Register(timeoutPluginName+":create", p.after("create")); err != nil {
+		return err
+	}
+	if err := cb.Query().After("gorm:query").Register(timeoutPluginName+":query", p.after("query")); err != nil {
+		return err
+	}
+	if err := cb.Update().After("gorm:update").Register(timeoutPluginName+":update", p.after("update")); err != nil {
+		return err
+	}
+	if err := cb.Delete().After("gorm:delete").Register(timeoutPluginName+":delete", p.after("delete")); err != nil {
+		return err
+	}
+	if err := cb.Row().After("gorm:row").Register(timeoutPluginName+":row", p.after("row")); err != nil {
+		return err
+	}
+	return cb.Raw().After("gorm:raw").Register(timeoutPluginName+":raw", p.after("raw"))
+}
+
+func (p *TimeoutPlugin) after(op string) func(db *gorm.DB) {
+	return func(db *gorm.DB) {
+		if p.onTimeout != nil && IsTimeout(db.Error) {
+			p.onTimeout(op)
+		}
+	}
+}
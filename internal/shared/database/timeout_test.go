@@ -0,0 +1,71 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/test"
+)
+
+func TestQueryContext(t *testing.T) {
+	timeouts := &config.DBTimeout{ReadTimeout: time.Second, WriteTimeout: 2 * time.Second, ListTimeout: 3 * time.Second}
+	remaining := func(ctx context.Context) time.Duration {
+		deadline, ok := ctx.Deadline()
+		require.True(t, ok)
+		return time.Until(deadline)
+	}
+
+	ctx, cancel := ReadContext(context.Background(), timeouts)
+	assert.InDelta(t, time.Second, remaining(ctx), float64(100*time.Millisecond))
+	cancel()
+
+	ctx, cancel = ListContext(WithQueryTimeout(context.Background(), time.Minute), timeouts)
+	assert.InDelta(t, time.Minute, remaining(ctx), float64(100*time.Millisecond), "指定的超时应该覆盖默认超时")
+	cancel()
+
+	ctx, cancel = WriteContext(WithQueryTimeout(context.Background(), 0), timeouts)
+	assert.InDelta(t, 2*time.Second, remaining(ctx), float64(100*time.Millisecond), "不大于0的超时不覆盖默认超时")
+	cancel()
+
+	ctx, cancel = QueryContext(context.Background(), 0)
+	_, ok := ctx.Deadline()
+	assert.False(t, ok, "没有配置超时时不设置截止时间")
+	cancel()
+
+	parent, parentCancel := context.WithCancel(context.Background())
+	ctx, cancel = ReadContext(parent, timeouts)
+	defer cancel()
+	parentCancel()
+	assert.ErrorIs(t, ctx.Err(), context.Canceled, "调用方取消时数据库操作的上下文应该随之取消")
+}
+
+func TestTimeoutPlugin(t *testing.T) {
+	db := test.NewTestGormDBWithConfig(nil)
+	require.NoError(t, db.AutoMigrate(&migrateTestModel{}))
+
+	var ops []string
+	require.NoError(t, db.Use(NewTimeoutPlugin(func(op string) { ops = append(ops, op) })))
+
+	require.NoError(t, db.Create(&migrateTestModel{Name: "a"}).Error)
+	assert.Empty(t, ops)
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	var ms []migrateTestModel
+	err := db.WithContext(ctx).Find(&ms).Error
+	require.Error(t, err)
+	assert.True(t, IsTimeout(err))
+	assert.Equal(t, []string{"query"}, ops)
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = db.WithContext(canceled).Find(&ms).Error
+	require.Error(t, err)
+	assert.False(t, IsTimeout(err))
+	assert.Equal(t, []string{"query"}, ops, "取消不计入超时")
+}
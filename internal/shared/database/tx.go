@@ -0,0 +1,85 @@
+package database
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+	"gorm.io/gorm"
+)
+
+type txKey struct{}
+
+// WithTx 在上下文中附加事务
+//
+// 使用该上下文调用DB*方法和仓库时在该事务中执行, 由开启事务的一方负责提交或回滚
+func WithTx(ctx context.Context, tx *gorm.DB) context.Context {
+	return context.WithValue(ctx, txKey{}, tx)
+}
+
+// TxFromContext 返回上下文中的事务
+func TxFromContext(ctx context.Context) (*gorm.DB, bool) {
+	tx, ok := ctx.Value(txKey{}).(*gorm.DB)
+	return tx, ok && tx != nil
+}
+
+// Conn 返回绑定上下文的数据库连接, 上下文中有事务时返回该事务
+//
+// 仓库直接使用GORM时应通过Conn获取连接, 以便加入调用方开启的事务
+func Conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := TxFromContext(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
+
+// TxManager 事务管理器, 让跨多个仓库的业务操作在同一个事务中执行
+type TxManager struct {
+	db *gorm.DB
+}
+
+func NewTxManager(db *gorm.DB) *TxManager {
+	return &TxManager{db: db}
+}
+
+// Transaction 在事务中执行fn, fn返回错误或panic时回滚, 否则提交
+//
+// fn必须使用传入的上下文调用仓库, 仓库的操作才会在该事务中执行.
+// 上下文中已有事务时直接加入该事务, 由最外层负责提交或回滚;
+// 事务管理器为nil时不开启事务, 直接执行fn
+func (m *TxManager) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if m == nil {
+		return fn(ctx)
+	}
+	if _, ok := TxFromContext(ctx); ok {
+		return fn(ctx)
+	}
+	return m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(WithTx(ctx, tx))
+	})
+}
+
+// Transactional 在事务中执行返回业务错误的fn, fn返回的错误原样返回, 事务本身的错误由wrap转换
+//
+// 用于返回值不是error接口的业务方法, 避免将nil的具体错误类型作为非nil的error返回
+func Transactional[E interface {
+	comparable
+	error
+}](ctx context.Context, m *TxManager, fn func(ctx context.Context) E, wrap func(err error) E) E {
+	var (
+		zero E
+		fErr E
+	)
+	err := m.Transaction(ctx, func(ctx context.Context) error {
+		if fErr = fn(ctx); fErr != zero {
+			return fErr
+		}
+		return nil
+	})
+	if fErr != zero {
+		return fErr
+	}
+	if err != nil {
+		return wrap(errors.WrapIf(err, "数据库事务执行失败"))
+	}
+	return zero
+}
@@ -0,0 +1,114 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"emperror.dev/errors"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	"gin-artweb/internal/shared/test"
+)
+
+type txTestModel struct {
+	ID   uint32 `gorm:"primaryKey"`
+	Name string `gorm:"uniqueIndex"`
+}
+
+func (m *txTestModel) TableName() string {
+	return "tx_test"
+}
+
+type TxManagerTestSuite struct {
+	suite.Suite
+	db *gorm.DB
+	tm *TxManager
+}
+
+func (suite *TxManagerTestSuite) SetupTest() {
+	suite.db = test.NewTestGormDBWithConfig(nil)
+	suite.Require().NoError(suite.db.AutoMigrate(&txTestModel{}))
+	suite.tm = NewTxManager(suite.db)
+}
+
+func (suite *TxManagerTestSuite) count() int64 {
+	var count int64
+	suite.Require().NoError(suite.db.Model(&txTestModel{}).Count(&count).Error)
+	return count
+}
+
+func (suite *TxManagerTestSuite) TestRollback() {
+	ctx := context.Background()
+	err := suite.tm.Transaction(ctx, func(ctx context.Context) error {
+		if err := DBCreate(ctx, suite.db, &txTestModel{}, &txTestModel{Name: "a"}, nil); err != nil {
+			return err
+		}
+		m := &txTestModel{Name: "b"}
+		if err := DBCreate(ctx, suite.db, &txTestModel{}, m, nil); err != nil {
+			return err
+		}
+		if err := DBUpdate(ctx, suite.db, &txTestModel{}, map[string]any{"name": "c"}, nil, "id = ?", m.ID); err != nil {
+			return err
+		}
+		var fm txTestModel
+		if err := DBGet(ctx, suite.db, nil, &fm, "name = ?", "c"); err != nil {
+			return errors.WrapIf(err, "事务中应该能读到未提交的数据")
+		}
+		// 唯一索引冲突, 之前的写操作应该一起回滚
+		return DBCreate(ctx, suite.db, &txTestModel{}, &txTestModel{Name: "a"}, nil)
+	})
+	suite.Error(err)
+	suite.Zero(suite.count())
+}
+
+func (suite *TxManagerTestSuite) TestCommitAndJoin() {
+	ctx := context.Background()
+	err := suite.tm.Transaction(ctx, func(ctx context.Context) error {
+		outer, _ := TxFromContext(ctx)
+		if err := DBCreate(ctx, suite.db, &txTestModel{}, &txTestModel{Name: "a"}, nil); err != nil {
+			return err
+		}
+		return suite.tm.Transaction(ctx, func(ctx context.Context) error {
+			inner, _ := TxFromContext(ctx)
+			suite.Same(outer, inner, "嵌套调用应该加入外层事务")
+			_, err := DBList(ctx, suite.db, &txTestModel{}, &[]txTestModel{}, QueryParams{})
+			if err != nil {
+				return err
+			}
+			return Conn(ctx, suite.db).Create(&txTestModel{Name: "b"}).Error
+		})
+	})
+	suite.Require().NoError(err)
+	suite.Equal(int64(2), suite.count())
+}
+
+func (suite *TxManagerTestSuite) TestNilManager() {
+	var tm *TxManager
+	err := tm.Transaction(context.Background(), func(ctx context.Context) error {
+		_, ok := TxFromContext(ctx)
+		suite.False(ok)
+		return DBCreate(ctx, suite.db, &txTestModel{}, &txTestModel{Name: "a"}, nil)
+	})
+	suite.Require().NoError(err)
+	suite.Equal(int64(1), suite.count())
+}
+
+func (suite *TxManagerTestSuite) TestTxHookInTransaction() {
+	ctx := context.Background()
+	err := suite.tm.Transaction(ctx, func(ctx context.Context) error {
+		hookCtx := WithTxHook(ctx, func(tx *gorm.DB) error {
+			return tx.Create(&txTestModel{Name: "hook"}).Error
+		})
+		if err := DBCreate(hookCtx, suite.db, &txTestModel{}, &txTestModel{Name: "a"}, nil); err != nil {
+			return err
+		}
+		return errors.New("业务失败")
+	})
+	suite.Error(err)
+	suite.Zero(suite.count(), "事务回调写入的数据也应该回滚")
+}
+
+func TestTxManagerTestSuite(t *testing.T) {
+	suite.Run(t, new(TxManagerTestSuite))
+}
@@ -0,0 +1,37 @@
+package database
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// TxHook 写操作提交前在同一事务中执行的回调, 返回错误时回滚整个事务
+type TxHook func(tx *gorm.DB) error
+
+type txHooksKey struct{}
+
+// WithTxHook 在上下文中附加事务回调
+//
+// 使用该上下文调用DBCreate和DBUpdate时在事务中执行写操作, 并在提交前依次执行回调,
+// 如事务发件箱在业务数据所在的事务中写入事件. 回调对该上下文的每次写操作都会执行,
+// 因此只应把返回的上下文传给一次写操作
+func WithTxHook(ctx context.Context, hook TxHook) context.Context {
+	hooks := txHooks(ctx)
+	return context.WithValue(ctx, txHooksKey{}, append(hooks[:len(hooks):len(hooks)], hook))
+}
+
+func txHooks(ctx context.Context) []TxHook {
+	hooks, _ := ctx.Value(txHooksKey{}).([]TxHook)
+	return hooks
+}
+
+// runTxHooks 执行上下文中的事务回调
+func runTxHooks(ctx context.Context, tx *gorm.DB) error {
+	for _, hook := range txHooks(ctx) {
+		if err := hook(tx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package database
+
+import (
+	"maps"
+
+	"emperror.dev/errors"
+	"gorm.io/gorm"
+)
+
+// VersionKey 乐观锁版本号字段
+//
+// 更新数据中携带该字段时表示客户端读取到的版本号, 仅当数据库中的版本号一致时才会更新
+const VersionKey = "version"
+
+// ErrVersionConflict 数据已被其他请求修改, 期望的版本号与当前版本号不一致
+var ErrVersionConflict = errors.Sentinel("数据版本冲突")
+
+// IsVersionConflict 判断错误是否为版本冲突
+func IsVersionConflict(err error) bool {
+	return errors.Is(err, ErrVersionConflict)
+}
+
+// hasVersionField 判断模型是否包含版本号字段
+func hasVersionField(db *gorm.DB, m any) bool {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(m); err != nil || stmt.Schema == nil {
+		return false
+	}
+	return stmt.Schema.LookUpField(VersionKey) != nil
+}
+
+// versionedData 处理更新数据中的版本号
+//
+// 模型包含版本号字段时, 返回的更新数据会递增版本号; 如果原更新数据中携带了版本号,
+// 则同时返回该期望版本号, 由调用方作为更新条件。原更新数据不会被修改
+func versionedData(db *gorm.DB, m any, data map[string]any) (map[string]any, any, bool) {
+	if len(data) == 0 || !hasVersionField(db, m) {
+		return data, nil, false
+	}
+	nd := make(map[string]any, len(data)+1)
+	maps.Copy(nd, data)
+	version, ok := nd[VersionKey]
+	nd[VersionKey] = gorm.Expr(VersionKey + " + 1")
+	return nd, version, ok
+}
@@ -0,0 +1,112 @@
+// Package deadline 客户端指定的请求处理时限
+//
+// 客户端通过X-Request-Deadline请求头指定本次请求最多等待的毫秒数, 中间件将其转换为请求上下文的截止时间,
+// 数据库操作、ssh连接和下游服务的请求从请求上下文派生, 时限随之传递; 各阶段开始时通过Enter登记阶段名称,
+// 超过时限后响应中返回当时正在执行的阶段, 便于客户端区分是哪一步耗时过长
+package deadline
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Header 客户端指定请求处理时限的请求头, 值为毫秒数
+const Header = "X-Request-Deadline"
+
+// 请求处理的阶段
+const (
+	StageHandler    = "handler"    // 接口处理逻辑
+	StageDatabase   = "database"   // 数据库操作
+	StageSSH        = "ssh"        // 通过ssh连接主机
+	StagePrometheus = "prometheus" // 查询Prometheus
+	StageLogSearch  = "log_search" // 查询日志平台
+)
+
+type budgetKey struct{}
+
+// Budget 请求的处理时限, 记录超时时正在执行的阶段
+type Budget struct {
+	Total    time.Duration // 客户端指定的时限, 已按服务端的超时时间截断
+	Deadline time.Time     // 截止时间
+
+	mu       sync.Mutex
+	stage    string
+	exceeded string // 超时时正在执行的阶段, 未超时为空
+}
+
+// ParseHeader 解析请求头中的时限, 未设置时返回0, 不是正整数时返回错误
+func ParseHeader(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	ms, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	if ms <= 0 {
+		return 0, strconv.ErrRange
+	}
+	return time.Duration(ms) * time.Millisecond, nil
+}
+
+// WithBudget 为上下文设置处理时限
+func WithBudget(ctx context.Context, total time.Duration) (context.Context, context.CancelFunc) {
+	b := &Budget{
+		Total:    total,
+		Deadline: time.Now().Add(total),
+		stage:    StageHandler,
+	}
+	return context.WithDeadline(context.WithValue(ctx, budgetKey{}, b), b.Deadline)
+}
+
+// FromContext 返回上下文中的处理时限, 客户端未指定时返回nil
+func FromContext(ctx context.Context) *Budget {
+	if ctx == nil {
+		return nil
+	}
+	b, _ := ctx.Value(budgetKey{}).(*Budget)
+	return b
+}
+
+// Enter 登记进入的阶段, 返回的函数恢复为之前的阶段
+//
+// 上下文中没有处理时限时不做任何处理
+func Enter(ctx context.Context, stage string) func() {
+	b := FromContext(ctx)
+	if b == nil {
+		return func() {}
+	}
+	b.mu.Lock()
+	b.check()
+	prev := b.stage
+	b.stage = stage
+	b.mu.Unlock()
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.check()
+		b.stage = prev
+	}
+}
+
+// check 已到截止时间且尚未记录时, 将当前阶段记录为超时的阶段, 调用方需要持有锁
+//
+// 阶段切换和查询结果时都会检查, 保证退出超时的阶段之前已经记录
+func (b *Budget) check() {
+	if b.exceeded == "" && !time.Now().Before(b.Deadline) {
+		b.exceeded = b.stage
+	}
+}
+
+// Exceeded 返回是否已超过时限以及超时时正在执行的阶段
+func (b *Budget) Exceeded() (string, bool) {
+	if b == nil {
+		return "", false
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.check()
+	return b.exceeded, b.exceeded != ""
+}
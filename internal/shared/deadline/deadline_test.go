@@ -0,0 +1,70 @@
+package deadline
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestParseHeader(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"", 0, false},
+		{"1500", 1500 * time.Millisecond, false},
+		{"0", 0, true},
+		{"-1", 0, true},
+		{"1.5", 0, true},
+		{"abc", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseHeader(tt.value)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseHeader(%q) = %v, %v, want %v, wantErr %v", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestBudgetStage(t *testing.T) {
+	// 没有处理时限时Enter不做任何处理
+	Enter(context.Background(), StageDatabase)()
+	if _, ok := FromContext(context.Background()).Exceeded(); ok {
+		t.Fatal("expected no budget")
+	}
+
+	ctx, cancel := WithBudget(context.Background(), time.Hour)
+	b := FromContext(ctx)
+	if b == nil || b.Total != time.Hour {
+		t.Fatalf("unexpected budget: %+v", b)
+	}
+	leave := Enter(ctx, StageDatabase)
+	if b.stage != StageDatabase {
+		t.Errorf("expected stage %s, got %s", StageDatabase, b.stage)
+	}
+	leave()
+	if b.stage != StageHandler {
+		t.Errorf("expected stage %s after leave, got %s", StageHandler, b.stage)
+	}
+
+	// 主动取消不视为超时
+	cancel()
+	if _, ok := b.Exceeded(); ok {
+		t.Error("expected cancel not to be treated as exceeded")
+	}
+}
+
+func TestBudgetExceeded(t *testing.T) {
+	ctx, cancel := WithBudget(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	leave := Enter(ctx, StageSSH)
+	<-ctx.Done()
+	// 超时后退出阶段仍然记录为该阶段超时
+	leave()
+	stage, ok := FromContext(ctx).Exceeded()
+	if !ok || stage != StageSSH {
+		t.Errorf("expected exceeded in stage %s, got %q, %v", StageSSH, stage, ok)
+	}
+}
@@ -1,7 +1,12 @@
 package errors
 
 import (
+	"context"
+	"errors"
 	"net/http"
+
+	"gin-artweb/internal/shared/deadline"
+	"gin-artweb/internal/shared/i18n"
 )
 
 func FromReason(reason ErrorReason) *Error {
@@ -43,6 +48,9 @@ func ErrorResponseWithCode(code int, err *Error) map[string]any {
 }
 
 // RespondWithError 在Gin等框架中直接使用，返回错误响应
+//
+// c同时是上下文时按上下文中的请求语言翻译错误消息, 参数校验失败时在data.fields中返回各字段的校验消息;
+// 超过客户端指定的处理时限导致的超时错误统一返回504, 在data.stage中说明超时时正在执行的阶段
 func RespondWithError(c interface {
 	AbortWithStatusJSON(code int, obj any)
 }, err *Error) {
@@ -50,6 +58,49 @@ func RespondWithError(c interface {
 		c.AbortWithStatusJSON(http.StatusOK, nil)
 		return
 	}
+	// 读取请求体时超出大小限制, 无论在哪一步读取都返回413
+	var mbe *http.MaxBytesError
+	if err.Reason != ReasonRequestBodyTooLarge && errors.As(err, &mbe) {
+		err = ErrRequestBodyTooLarge.WithField("max_size", mbe.Limit)
+	}
+	if ctx, ok := c.(context.Context); ok {
+		err = deadlineError(ctx, err)
+		lang := i18n.Lang(ctx)
+		err = err.Localize(lang)
+		if err.Reason == ReasonValidationFailed {
+			if fields := i18n.ValidationMessages(err.cause, lang); fields != nil {
+				err = err.WithField("fields", fields)
+			}
+		}
+		if h, ok := c.(interface{ Header(key, value string) }); ok {
+			h.Header("Content-Language", lang)
+		}
+	}
 	status := GetHTTPStatus(err.Reason)
 	c.AbortWithStatusJSON(status, ErrorResponse(err))
 }
+
+// deadlineError 请求超过客户端指定的处理时限时, 将超时导致的错误转换为ErrRequestDeadlineExceeded
+func deadlineError(ctx context.Context, err *Error) *Error {
+	b := deadline.FromContext(ctx)
+	stage, exceeded := b.Exceeded()
+	if !exceeded {
+		return err
+	}
+	switch {
+	case err.Reason == ReasonRequestDeadlineExceeded,
+		err.Reason == ReasonDeadlineExceeded,
+		err.Reason == ReasonDBTimeout,
+		errors.Is(err, context.DeadlineExceeded):
+	default:
+		return err
+	}
+	out := ErrRequestDeadlineExceeded.WithFields(map[string]any{
+		"stage":     stage,
+		"budget_ms": b.Total.Milliseconds(),
+	})
+	if cause := err.Unwrap(); cause != nil {
+		out = out.WithCause(cause)
+	}
+	return out
+}
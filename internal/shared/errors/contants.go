@@ -69,21 +69,182 @@ const (
 	ReasonDownloadFileNotFound          ErrorReason = "DOWNLOAD_FILE_NOT_FOUND"           // 下载的文件未找到
 	ReasonDownloadFilePermissionDenied  ErrorReason = "DOWNLOAD_FILE_PERMISSION_DENIED"   // 下载文件权限被拒绝
 	ReasonDownloadFileFailed            ErrorReason = "DOWNLOAD_FILE_FAILED"              // 下载文件失败
+	ReasonRequestBodyTooLarge           ErrorReason = "REQUEST_BODY_TOO_LARGE"            // 请求体超出大小限制
 
 	// 压缩解压文件
-	ReasonUnZIPFailed       ErrorReason = "UNZIP_FAILED"          // 解压文件失败
-	ReasonZIPFailed         ErrorReason = "ZIP_FAILED"            // 压缩文件失败
-	ReasonZIPFileNotFound   ErrorReason = "ZIP_FILE_NOT_FOUND"    // 压缩文件未找到
-	ReasonZIPFileIsEmpty    ErrorReason = "ZIP_FILE_IS_EMPTY"     // 压缩文件为空
-	ReasonZIPFileIsNotValid ErrorReason = "ZIP_FILE_IS_NOT_VALID" // 压缩文件无效
+	ReasonUnZIPFailed          ErrorReason = "UNZIP_FAILED"           // 解压文件失败
+	ReasonZIPFailed            ErrorReason = "ZIP_FAILED"             // 压缩文件失败
+	ReasonZIPFileNotFound      ErrorReason = "ZIP_FILE_NOT_FOUND"     // 压缩文件未找到
+	ReasonZIPFileIsEmpty       ErrorReason = "ZIP_FILE_IS_EMPTY"      // 压缩文件为空
+	ReasonZIPFileIsNotValid    ErrorReason = "ZIP_FILE_IS_NOT_VALID"  // 压缩文件无效
+	ReasonArchiveLimitExceeded ErrorReason = "ARCHIVE_LIMIT_EXCEEDED" // 压缩文件超出解压限制
 
 	// 缓存文件相关
 	ReasonExportCacheFileFailed ErrorReason = "EXPORT_CACHE_FILE_FAILED" // 导出缓存文件失败
 	ReasonDeleteCacheFileFailed ErrorReason = "DELETE_CACHE_FILE_FAILED" // 删除缓存文件失败
 
 	// 脚本相关
-	ReasonScriptNotFound    ErrorReason = "SCRIPT_NOT_FOUND"     // 脚本未找到
-	ReasonScriptIsBuiltin   ErrorReason = "SCRIPT_IS_BUILTIN"    // 脚本为内置脚本
-	ReasonScriptIsDisabled  ErrorReason = "SCRIPT_IS_DISABLED"   // 脚本已禁用
-	ReasonScriptLogNotFound ErrorReason = "SCRIPT_LOG_NOT_FOUND" // 脚本日志未找到
+	ReasonScriptNotFound       ErrorReason = "SCRIPT_NOT_FOUND"        // 脚本未找到
+	ReasonScriptIsBuiltin      ErrorReason = "SCRIPT_IS_BUILTIN"       // 脚本为内置脚本
+	ReasonScriptIsDisabled     ErrorReason = "SCRIPT_IS_DISABLED"      // 脚本已禁用
+	ReasonScriptLogNotFound    ErrorReason = "SCRIPT_LOG_NOT_FOUND"    // 脚本日志未找到
+	ReasonScriptDryRunDisabled ErrorReason = "SCRIPT_DRY_RUN_DISABLED" // 未配置试运行沙箱
+
+	// Casbin模型相关
+	ReasonCasbinModelInvalid      ErrorReason = "CASBIN_MODEL_INVALID"       // Casbin模型配置无效
+	ReasonCasbinModelSampleFailed ErrorReason = "CASBIN_MODEL_SAMPLE_FAILED" // Casbin模型样例请求校验未通过
+	ReasonCasbinModelApplyFailed  ErrorReason = "CASBIN_MODEL_APPLY_FAILED"  // Casbin模型应用失败
+	ReasonCasbinModelNoHistory    ErrorReason = "CASBIN_MODEL_NO_HISTORY"    // 没有可回滚的Casbin模型历史版本
+
+	// Prometheus数据源相关
+	ReasonPromDatasourceNotConfigured ErrorReason = "PROM_DATASOURCE_NOT_CONFIGURED" // 未配置Prometheus数据源
+	ReasonPromQueryFailed             ErrorReason = "PROM_QUERY_FAILED"              // Prometheus查询失败
+
+	// 集群导出相关错误
+	ReasonColonyExportNotReady ErrorReason = "COLONY_EXPORT_NOT_READY" // 集群导出尚未完成
+
+	// 部署模式相关
+	ReasonFeatureDisabled ErrorReason = "FEATURE_DISABLED" // 当前部署模式不支持该功能
+
+	// 任务编排相关
+	ReasonWorkflowNotDefined ErrorReason = "WORKFLOW_NOT_DEFINED" // 未配置该系统类型的任务编排
+	ReasonWorkflowInvalid    ErrorReason = "WORKFLOW_INVALID"     // 任务编排配置无效
+	ReasonWorkflowRunning    ErrorReason = "WORKFLOW_RUNNING"     // 集群已有正在执行的任务编排
+
+	// 交易日历相关
+	ReasonTradingCalendarInvalid ErrorReason = "TRADING_CALENDAR_INVALID" // 交易日历数据无效
+
+	// 维护窗口相关
+	ReasonMaintenanceWindowInvalid ErrorReason = "MAINTENANCE_WINDOW_INVALID" // 维护窗口配置无效
+
+	// 并发控制相关
+	ReasonVersionConflict ErrorReason = "VERSION_CONFLICT" // 数据已被其他用户修改
+	ReasonVersionRequired ErrorReason = "VERSION_REQUIRED" // 缺少数据版本号
+
+	// 日志查询相关
+	ReasonLogQueryFailed ErrorReason = "LOG_QUERY_FAILED" // 日志查询失败
+
+	// 脚本执行相关
+	ReasonJobsShuttingDown ErrorReason = "JOBS_SHUTTING_DOWN" // 服务正在关闭, 暂不接受新的脚本执行
+
+	// 系统初始化相关
+	ReasonSystemInitialized      ErrorReason = "SYSTEM_INITIALIZED"       // 系统已完成初始化
+	ReasonPasswordChangeRequired ErrorReason = "PASSWORD_CHANGE_REQUIRED" // 请先修改初始密码
+
+	// 签名密钥相关
+	ReasonSigningKeyGenerateFailed ErrorReason = "SIGNING_KEY_GENERATE_FAILED" // 生成签名密钥失败
+
+	// 文件存储相关
+	ReasonFileChecksumMismatch ErrorReason = "FILE_CHECKSUM_MISMATCH" // 文件校验和不一致
+	ReasonStorageUnavailable   ErrorReason = "STORAGE_UNAVAILABLE"    // 文件存储服务不可用
+
+	// 分片上传相关
+	ReasonUploadSessionNotFound ErrorReason = "UPLOAD_SESSION_NOT_FOUND" // 上传会话不存在或已过期
+	ReasonUploadOffsetMismatch  ErrorReason = "UPLOAD_OFFSET_MISMATCH"   // 分片起始位置与已接收的字节数不一致
+	ReasonUploadIncomplete      ErrorReason = "UPLOAD_INCOMPLETE"        // 文件尚未全部上传
+
+	// 查询网关相关
+	ReasonGatewayResponseNotJSON ErrorReason = "GATEWAY_RESPONSE_NOT_JSON" // 子查询接口的响应不是JSON
+
+	// 主机文件管理相关
+	ReasonHostPathForbidden       ErrorReason = "HOST_PATH_FORBIDDEN"        // 主机路径不在允许访问的范围内
+	ReasonHostFileNotFound        ErrorReason = "HOST_FILE_NOT_FOUND"        // 主机文件不存在
+	ReasonHostFileExists          ErrorReason = "HOST_FILE_EXISTS"           // 主机文件已存在
+	ReasonHostFileOperationFailed ErrorReason = "HOST_FILE_OPERATION_FAILED" // 主机文件操作失败
+
+	// 配置模板相关
+	ReasonConfTemplateRenderFailed ErrorReason = "CONF_TEMPLATE_RENDER_FAILED" // 配置模板渲染失败
+
+	// 权限配置导入导出
+	ReasonRbacDocumentInvalid ErrorReason = "RBAC_DOCUMENT_INVALID" // 权限配置文档无效
+	ReasonRbacImportConflict  ErrorReason = "RBAC_IMPORT_CONFLICT"  // 导入的权限配置与已有数据冲突
+
+	// OIDC单点登录
+	ReasonOidcProviderNotFound ErrorReason = "OIDC_PROVIDER_NOT_FOUND" // 身份提供方不存在
+	ReasonOidcStateInvalid     ErrorReason = "OIDC_STATE_INVALID"      // 登录状态无效或已过期
+	ReasonOidcLoginFailed      ErrorReason = "OIDC_LOGIN_FAILED"       // 单点登录失败
+	ReasonOidcRoleNotMapped    ErrorReason = "OIDC_ROLE_NOT_MAPPED"    // 没有匹配的角色映射规则
+	ReasonOidcUserNotLinked    ErrorReason = "OIDC_USER_NOT_LINKED"    // 外部身份未关联用户
+	ReasonOidcIdentityLinked   ErrorReason = "OIDC_IDENTITY_LINKED"    // 外部身份已关联其他用户
+	ReasonLocalLoginDisabled   ErrorReason = "LOCAL_LOGIN_DISABLED"    // 本地用户名密码登录已禁用
+
+	// 登录会话
+	ReasonSessionRevoked         ErrorReason = "SESSION_REVOKED"         // 会话已失效, 请重新登录
+	ReasonSessionNotFound        ErrorReason = "SESSION_NOT_FOUND"       // 会话不存在或已失效
+	ReasonCSRFTokenInvalid       ErrorReason = "CSRF_TOKEN_INVALID"      // CSRF令牌缺失或不匹配
+	ReasonRefreshTokenReused     ErrorReason = "REFRESH_TOKEN_REUSED"    // 刷新令牌已被使用, 会话已终止
+	ReasonImpersonationForbidden ErrorReason = "IMPERSONATION_FORBIDDEN" // 不允许代为登录该用户
+
+	// 登录验证码
+	ReasonCaptchaRequired ErrorReason = "CAPTCHA_REQUIRED" // 登录失败次数过多, 请输入验证码
+	ReasonCaptchaInvalid  ErrorReason = "CAPTCHA_INVALID"  // 验证码错误或已过期
+
+	// 熔断
+	ReasonCircuitOpen         ErrorReason = "ERROR_CIRCUIT_OPEN"   // 下游服务暂时不可用, 请稍后重试
+	ReasonDatabaseUnavailable ErrorReason = "DATABASE_UNAVAILABLE" // 数据库暂时不可用, 请稍后重试
+
+	// 后台任务
+	ReasonTaskFinished       ErrorReason = "TASK_FINISHED"        // 任务已结束
+	ReasonTaskServiceStopped ErrorReason = "TASK_SERVICE_STOPPED" // 服务正在关闭
+	ReasonTaskInterrupted    ErrorReason = "TASK_INTERRUPTED"     // 服务重启, 任务中断
+
+	// 功能开关相关错误
+	ReasonFeatureNotReleased ErrorReason = "FEATURE_NOT_RELEASED" // 功能未开放
+
+	// 组织架构
+	ReasonDepartmentMoveInvalid ErrorReason = "DEPARTMENT_MOVE_INVALID" // 不能将部门移动到自身或下级部门下
+	ReasonDepartmentNotEmpty    ErrorReason = "DEPARTMENT_NOT_EMPTY"    // 部门下存在下级部门或用户
+
+	// 行情数据采集
+	ReasonMdsIngestRunning ErrorReason = "MDS_INGEST_RUNNING" // 该数据源当日的采集正在进行
+
+	// 行情数据回补
+	ReasonMdsBackfillRunning ErrorReason = "MDS_BACKFILL_RUNNING" // 集群已有正在执行的数据回补
+	ReasonMdsBackfillState   ErrorReason = "MDS_BACKFILL_STATE"   // 数据回补的当前状态不支持该操作
+
+	// 运维检查单
+	ReasonRunbookRunning   ErrorReason = "RUNBOOK_RUNNING"    // 集群已有正在执行的该检查单
+	ReasonRunbookStepState ErrorReason = "RUNBOOK_STEP_STATE" // 检查单步骤的当前状态不支持该操作
+
+	// 变更冻结
+	ReasonChangeFreezeInvalid  ErrorReason = "CHANGE_FREEZE_INVALID"  // 变更冻结配置无效
+	ReasonChangeFrozen         ErrorReason = "CHANGE_FROZEN"          // 变更冻结期间拒绝修改
+	ReasonFreezeOverrideDenied ErrorReason = "FREEZE_OVERRIDE_DENIED" // 非工作人员越过变更冻结
+
+	// 主机代理
+	ReasonAgentTokenInvalid ErrorReason = "AGENT_TOKEN_INVALID" // 主机代理令牌无效
+
+	// 进程守护
+	ReasonWatchdogInvalid ErrorReason = "WATCHDOG_INVALID" // 进程守护定义无效
+
+	// 备份
+	ReasonBackupRunning  ErrorReason = "BACKUP_RUNNING"   // 备份正在执行
+	ReasonBackupNotReady ErrorReason = "BACKUP_NOT_READY" // 备份未成功
+	ReasonBackupFailed   ErrorReason = "BACKUP_FAILED"    // 备份失败
+
+	// 用户偏好
+	ReasonPreferenceNamespaceInvalid ErrorReason = "PREFERENCE_NAMESPACE_INVALID" // 不支持的偏好命名空间
+	ReasonPreferenceInvalid          ErrorReason = "PREFERENCE_INVALID"           // 偏好内容格式错误
+	ReasonPreferenceTooLarge         ErrorReason = "PREFERENCE_TOO_LARGE"         // 偏好内容过大
+	ReasonPreferenceLimitExceeded    ErrorReason = "PREFERENCE_LIMIT_EXCEEDED"    // 偏好数量超过上限
+
+	// 批量删除
+	ReasonDeleteBlocked        ErrorReason = "DELETE_BLOCKED"          // 存在被引用的记录
+	ReasonDeleteTargetNotFound ErrorReason = "DELETE_TARGET_NOT_FOUND" // 要删除的记录不存在
+
+	// 数据库操作超时
+	ReasonDBTimeout ErrorReason = "DB_QUERY_TIMEOUT" // 数据库操作超过允许的时间
+
+	// 日志级别
+	ReasonLoggerNotFound ErrorReason = "LOGGER_NOT_FOUND" // 调整级别的日志记录器不存在
+
+	// 请求处理时限
+	ReasonRequestDeadlineInvalid  ErrorReason = "REQUEST_DEADLINE_INVALID"  // X-Request-Deadline不是正整数
+	ReasonRequestDeadlineExceeded ErrorReason = "REQUEST_DEADLINE_EXCEEDED" // 超过X-Request-Deadline指定的时限
+
+	// 用户停用
+	ReasonUserDeactivateSelf    ErrorReason = "USER_DEACTIVATE_SELF"    // 不能停用当前登录的用户
+	ReasonUserDisabled          ErrorReason = "USER_DISABLED"           // 用户已永久停用
+	ReasonHandoverTargetInvalid ErrorReason = "HANDOVER_TARGET_INVALID" // 交接对象无效
+	ReasonHandoverKindInvalid   ErrorReason = "HANDOVER_KIND_INVALID"   // 不支持的交接记录类型
 )
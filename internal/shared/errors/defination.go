@@ -64,21 +64,182 @@ var (
 	ErrDownloadFileNotFound          = FromReason(ReasonDownloadFileNotFound)          // 下载文件不存在
 	ErrDownloadFilePermissionDenied  = FromReason(ReasonDownloadFilePermissionDenied)  // 下载文件权限被拒绝
 	ErrDownloadFileFailed            = FromReason(ReasonDownloadFileFailed)            // 下载文件失败
+	ErrRequestBodyTooLarge           = FromReason(ReasonRequestBodyTooLarge)           // 请求体超出大小限制
 
 	// 压缩解压文件
-	ErrUnZIPFailed       = FromReason(ReasonUnZIPFailed)       // 解压文件失败
-	ErrZIPFailed         = FromReason(ReasonZIPFailed)         // 压缩文件失败
-	ErrZIPFileNotFound   = FromReason(ReasonZIPFileNotFound)   // 压缩文件未找到
-	ErrZIPFileIsEmpty    = FromReason(ReasonZIPFileIsEmpty)    // 压缩文件为空
-	ErrZIPFileIsNotValid = FromReason(ReasonZIPFileIsNotValid) // 压缩文件无效
+	ErrUnZIPFailed          = FromReason(ReasonUnZIPFailed)          // 解压文件失败
+	ErrZIPFailed            = FromReason(ReasonZIPFailed)            // 压缩文件失败
+	ErrZIPFileNotFound      = FromReason(ReasonZIPFileNotFound)      // 压缩文件未找到
+	ErrZIPFileIsEmpty       = FromReason(ReasonZIPFileIsEmpty)       // 压缩文件为空
+	ErrZIPFileIsNotValid    = FromReason(ReasonZIPFileIsNotValid)    // 压缩文件无效
+	ErrArchiveLimitExceeded = FromReason(ReasonArchiveLimitExceeded) // 压缩文件超出解压限制
 
 	// 缓存文件
 	ErrExportCacheFileFailed = FromReason(ReasonExportCacheFileFailed) // 缓存文件导出失败
 	ErrDeleteCacheFileFailed = FromReason(ReasonDeleteCacheFileFailed) // 缓存文件删除失败
 
 	// 脚本相关
-	ErrScriptNotFound    = FromReason(ReasonScriptNotFound)    // 脚本不存在
-	ErrScriptIsBuiltin   = FromReason(ReasonScriptIsBuiltin)   // 脚本为内置脚本
-	ErrScriptIsDisabled  = FromReason(ReasonScriptIsDisabled)  // 脚本已禁用
-	ErrScriptLogNotFound = FromReason(ReasonScriptLogNotFound) // 脚本日志不存在
+	ErrScriptNotFound       = FromReason(ReasonScriptNotFound)       // 脚本不存在
+	ErrScriptIsBuiltin      = FromReason(ReasonScriptIsBuiltin)      // 脚本为内置脚本
+	ErrScriptIsDisabled     = FromReason(ReasonScriptIsDisabled)     // 脚本已禁用
+	ErrScriptLogNotFound    = FromReason(ReasonScriptLogNotFound)    // 脚本日志不存在
+	ErrScriptDryRunDisabled = FromReason(ReasonScriptDryRunDisabled) // 未配置试运行沙箱
+
+	// Casbin模型相关
+	ErrCasbinModelInvalid      = FromReason(ReasonCasbinModelInvalid)      // Casbin模型配置无效
+	ErrCasbinModelSampleFailed = FromReason(ReasonCasbinModelSampleFailed) // Casbin模型样例请求校验未通过
+	ErrCasbinModelApplyFailed  = FromReason(ReasonCasbinModelApplyFailed)  // Casbin模型应用失败
+	ErrCasbinModelNoHistory    = FromReason(ReasonCasbinModelNoHistory)    // 没有可回滚的Casbin模型历史版本
+
+	// Prometheus数据源相关
+	ErrPromDatasourceNotConfigured = FromReason(ReasonPromDatasourceNotConfigured) // 未配置Prometheus数据源
+	ErrPromQueryFailed             = FromReason(ReasonPromQueryFailed)             // Prometheus查询失败
+
+	// 集群导出相关错误
+	ErrColonyExportNotReady = FromReason(ReasonColonyExportNotReady) // 集群导出尚未完成
+
+	// 部署模式相关
+	ErrFeatureDisabled = FromReason(ReasonFeatureDisabled) // 当前部署模式不支持该功能
+
+	// 任务编排相关
+	ErrWorkflowNotDefined = FromReason(ReasonWorkflowNotDefined) // 未配置该系统类型的任务编排
+	ErrWorkflowInvalid    = FromReason(ReasonWorkflowInvalid)    // 任务编排配置无效
+	ErrWorkflowRunning    = FromReason(ReasonWorkflowRunning)    // 集群已有正在执行的任务编排
+
+	// 交易日历相关
+	ErrTradingCalendarInvalid = FromReason(ReasonTradingCalendarInvalid) // 交易日历数据无效
+
+	// 维护窗口相关
+	ErrMaintenanceWindowInvalid = FromReason(ReasonMaintenanceWindowInvalid) // 维护窗口配置无效
+
+	// 并发控制相关
+	ErrVersionConflict = FromReason(ReasonVersionConflict) // 数据已被其他用户修改
+	ErrVersionRequired = FromReason(ReasonVersionRequired) // 缺少数据版本号
+
+	// 日志查询相关
+	ErrLogQueryFailed = FromReason(ReasonLogQueryFailed) // 日志查询失败
+
+	// 脚本执行相关
+	ErrJobsShuttingDown = FromReason(ReasonJobsShuttingDown) // 服务正在关闭, 暂不接受新的脚本执行
+
+	// 系统初始化相关
+	ErrSystemInitialized      = FromReason(ReasonSystemInitialized)      // 系统已完成初始化
+	ErrPasswordChangeRequired = FromReason(ReasonPasswordChangeRequired) // 请先修改初始密码
+
+	// 签名密钥相关
+	ErrSigningKeyGenerateFailed = FromReason(ReasonSigningKeyGenerateFailed) // 生成签名密钥失败
+
+	// 文件存储相关
+	ErrFileChecksumMismatch = FromReason(ReasonFileChecksumMismatch) // 文件校验和不一致
+	ErrStorageUnavailable   = FromReason(ReasonStorageUnavailable)   // 文件存储服务不可用
+
+	// 分片上传相关
+	ErrUploadSessionNotFound = FromReason(ReasonUploadSessionNotFound) // 上传会话不存在或已过期
+	ErrUploadOffsetMismatch  = FromReason(ReasonUploadOffsetMismatch)  // 分片起始位置与已接收的字节数不一致
+	ErrUploadIncomplete      = FromReason(ReasonUploadIncomplete)      // 文件尚未全部上传
+
+	// 查询网关相关
+	ErrGatewayResponseNotJSON = FromReason(ReasonGatewayResponseNotJSON) // 子查询接口的响应不是JSON
+
+	// 主机文件管理相关
+	ErrHostPathForbidden       = FromReason(ReasonHostPathForbidden)       // 主机路径不在允许访问的范围内
+	ErrHostFileNotFound        = FromReason(ReasonHostFileNotFound)        // 主机文件不存在
+	ErrHostFileExists          = FromReason(ReasonHostFileExists)          // 主机文件已存在
+	ErrHostFileOperationFailed = FromReason(ReasonHostFileOperationFailed) // 主机文件操作失败
+
+	// 配置模板相关
+	ErrConfTemplateRenderFailed = FromReason(ReasonConfTemplateRenderFailed) // 配置模板渲染失败
+
+	// 权限配置导入导出
+	ErrRbacDocumentInvalid = FromReason(ReasonRbacDocumentInvalid) // 权限配置文档无效
+	ErrRbacImportConflict  = FromReason(ReasonRbacImportConflict)  // 导入的权限配置与已有数据冲突
+
+	// OIDC单点登录
+	ErrOidcProviderNotFound = FromReason(ReasonOidcProviderNotFound) // 身份提供方不存在
+	ErrOidcStateInvalid     = FromReason(ReasonOidcStateInvalid)     // 登录状态无效或已过期
+	ErrOidcLoginFailed      = FromReason(ReasonOidcLoginFailed)      // 单点登录失败
+	ErrOidcRoleNotMapped    = FromReason(ReasonOidcRoleNotMapped)    // 没有匹配的角色映射规则
+	ErrOidcUserNotLinked    = FromReason(ReasonOidcUserNotLinked)    // 外部身份未关联用户
+	ErrOidcIdentityLinked   = FromReason(ReasonOidcIdentityLinked)   // 外部身份已关联其他用户
+	ErrLocalLoginDisabled   = FromReason(ReasonLocalLoginDisabled)   // 本地用户名密码登录已禁用
+
+	// 登录会话
+	ErrSessionRevoked         = FromReason(ReasonSessionRevoked)         // 会话已失效, 请重新登录
+	ErrSessionNotFound        = FromReason(ReasonSessionNotFound)        // 会话不存在或已失效
+	ErrCSRFTokenInvalid       = FromReason(ReasonCSRFTokenInvalid)       // CSRF令牌缺失或不匹配
+	ErrRefreshTokenReused     = FromReason(ReasonRefreshTokenReused)     // 刷新令牌已被使用, 会话已终止
+	ErrImpersonationForbidden = FromReason(ReasonImpersonationForbidden) // 不允许代为登录该用户
+
+	// 登录验证码
+	ErrCaptchaRequired = FromReason(ReasonCaptchaRequired) // 登录失败次数过多, 请输入验证码
+	ErrCaptchaInvalid  = FromReason(ReasonCaptchaInvalid)  // 验证码错误或已过期
+
+	// 熔断
+	ErrCircuitOpen         = FromReason(ReasonCircuitOpen)         // 下游服务暂时不可用, 请稍后重试
+	ErrDatabaseUnavailable = FromReason(ReasonDatabaseUnavailable) // 数据库暂时不可用, 请稍后重试
+
+	// 后台任务
+	ErrTaskFinished       = FromReason(ReasonTaskFinished)       // 任务已结束
+	ErrTaskServiceStopped = FromReason(ReasonTaskServiceStopped) // 服务正在关闭
+	ErrTaskInterrupted    = FromReason(ReasonTaskInterrupted)    // 服务重启, 任务中断
+
+	// 功能开关相关错误
+	ErrFeatureNotReleased = FromReason(ReasonFeatureNotReleased) // 功能未开放
+
+	// 组织架构
+	ErrDepartmentMoveInvalid = FromReason(ReasonDepartmentMoveInvalid) // 不能将部门移动到自身或下级部门下
+	ErrDepartmentNotEmpty    = FromReason(ReasonDepartmentNotEmpty)    // 部门下存在下级部门或用户
+
+	// 行情数据采集
+	ErrMdsIngestRunning = FromReason(ReasonMdsIngestRunning) // 该数据源当日的采集正在进行
+
+	// 行情数据回补
+	ErrMdsBackfillRunning = FromReason(ReasonMdsBackfillRunning) // 集群已有正在执行的数据回补
+	ErrMdsBackfillState   = FromReason(ReasonMdsBackfillState)   // 数据回补的当前状态不支持该操作
+
+	// 运维检查单
+	ErrRunbookRunning   = FromReason(ReasonRunbookRunning)   // 集群已有正在执行的该检查单
+	ErrRunbookStepState = FromReason(ReasonRunbookStepState) // 检查单步骤的当前状态不支持该操作
+
+	// 变更冻结
+	ErrChangeFreezeInvalid  = FromReason(ReasonChangeFreezeInvalid)  // 变更冻结配置无效
+	ErrChangeFrozen         = FromReason(ReasonChangeFrozen)         // 变更冻结期间拒绝修改
+	ErrFreezeOverrideDenied = FromReason(ReasonFreezeOverrideDenied) // 非工作人员越过变更冻结
+
+	// 主机代理
+	ErrAgentTokenInvalid = FromReason(ReasonAgentTokenInvalid) // 主机代理令牌无效
+
+	// 进程守护
+	ErrWatchdogInvalid = FromReason(ReasonWatchdogInvalid) // 进程守护定义无效
+
+	// 备份
+	ErrBackupRunning  = FromReason(ReasonBackupRunning)  // 备份正在执行
+	ErrBackupNotReady = FromReason(ReasonBackupNotReady) // 备份未成功
+	ErrBackupFailed   = FromReason(ReasonBackupFailed)   // 备份失败
+
+	// 用户偏好
+	ErrPreferenceNamespaceInvalid = FromReason(ReasonPreferenceNamespaceInvalid) // 不支持的偏好命名空间
+	ErrPreferenceInvalid          = FromReason(ReasonPreferenceInvalid)          // 偏好内容格式错误
+	ErrPreferenceTooLarge         = FromReason(ReasonPreferenceTooLarge)         // 偏好内容过大
+	ErrPreferenceLimitExceeded    = FromReason(ReasonPreferenceLimitExceeded)    // 偏好数量超过上限
+
+	// 批量删除
+	ErrDeleteBlocked        = FromReason(ReasonDeleteBlocked)        // 存在被引用的记录
+	ErrDeleteTargetNotFound = FromReason(ReasonDeleteTargetNotFound) // 要删除的记录不存在
+
+	// 数据库操作超时
+	ErrDBTimeout = FromReason(ReasonDBTimeout) // 数据库操作超过允许的时间
+
+	// 日志级别
+	ErrLoggerNotFound = FromReason(ReasonLoggerNotFound) // 调整级别的日志记录器不存在
+
+	// 请求处理时限
+	ErrRequestDeadlineInvalid  = FromReason(ReasonRequestDeadlineInvalid)  // X-Request-Deadline不是正整数
+	ErrRequestDeadlineExceeded = FromReason(ReasonRequestDeadlineExceeded) // 超过X-Request-Deadline指定的时限
+
+	// 用户停用
+	ErrUserDeactivateSelf    = FromReason(ReasonUserDeactivateSelf)    // 不能停用当前登录的用户
+	ErrUserDisabled          = FromReason(ReasonUserDisabled)          // 用户已永久停用
+	ErrHandoverTargetInvalid = FromReason(ReasonHandoverTargetInvalid) // 交接对象无效
+	ErrHandoverKindInvalid   = FromReason(ReasonHandoverKindInvalid)   // 不支持的交接记录类型
 )
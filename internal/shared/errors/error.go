@@ -7,6 +7,9 @@ import (
 	"maps"
 
 	emperror "emperror.dev/errors"
+
+	"gin-artweb/internal/shared/i18n"
+	"gin-artweb/pkg/breaker"
 )
 
 type Error struct {
@@ -132,6 +135,22 @@ func (e *Error) Fields() map[string]any {
 	}
 }
 
+// Localize 返回消息翻译为指定语言的错误
+//
+// 只翻译错误原因的默认消息, 创建时传入的自定义消息原样保留; 消息目录中没有该语言的消息时返回原错误
+func (e *Error) Localize(lang string) *Error {
+	if e == nil || e.Msg != defaultErrorMessages[e.Reason] {
+		return e
+	}
+	msg, ok := i18n.Message(lang, string(e.Reason))
+	if !ok || msg == e.Msg {
+		return e
+	}
+	err := Clone(e)
+	err.Msg = msg
+	return err
+}
+
 func Clone(err *Error) *Error {
 	if err == nil {
 		return nil
@@ -160,6 +179,9 @@ func FromError(err error) *Error {
 	if errors.Is(err, context.DeadlineExceeded) {
 		reason = ReasonDeadlineExceeded
 	}
+	if errors.Is(err, breaker.ErrOpen) {
+		reason = ReasonCircuitOpen
+	}
 	return &Error{
 		Reason: reason,
 		Msg:    defaultErrorMessages[reason],
@@ -3,8 +3,13 @@ package errors
 import (
 	"context"
 	std_errors "errors"
+	"net/http"
 	"strings"
 	"testing"
+	"time"
+
+	"gin-artweb/internal/shared/deadline"
+	"gin-artweb/internal/shared/i18n"
 )
 
 func TestNewError(t *testing.T) {
@@ -317,3 +322,127 @@ func TestErrorChain(t *testing.T) {
 		t.Error("expected std_errors.Is(err, cause2) to be true")
 	}
 }
+
+type recordResponder struct {
+	code int
+	obj  any
+}
+
+func (r *recordResponder) AbortWithStatusJSON(code int, obj any) {
+	r.code, r.obj = code, obj
+}
+
+func TestRespondWithErrorBodyTooLarge(t *testing.T) {
+	// 读取请求体超出限制的错误无论包装成哪种错误都返回413
+	cause := &http.MaxBytesError{Limit: 1024}
+	r := &recordResponder{}
+	RespondWithError(r, ErrValidationFailed.WithCause(cause))
+	if r.code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("expected status 413, got %d", r.code)
+	}
+	resp := r.obj.(map[string]any)
+	if resp["reason"] != ReasonRequestBodyTooLarge {
+		t.Errorf("expected reason %v, got %v", ReasonRequestBodyTooLarge, resp["reason"])
+	}
+
+	// 其他错误保持原状态码
+	r = &recordResponder{}
+	RespondWithError(r, ErrValidationFailed.WithCause(std_errors.New("bad input")))
+	if r.code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", r.code)
+	}
+}
+
+func TestEnglishMessages(t *testing.T) {
+	// 每个错误原因都需要英文消息
+	for reason := range defaultErrorMessages {
+		if enErrorMessages[reason] == "" {
+			t.Errorf("missing english message for %v", reason)
+		}
+	}
+	for reason := range enErrorMessages {
+		if _, ok := defaultErrorMessages[reason]; !ok {
+			t.Errorf("english message for unknown reason %v", reason)
+		}
+	}
+}
+
+func TestLocalize(t *testing.T) {
+	// 默认消息翻译为英文, 原错误不变
+	err := ErrRecordNotFound.WithField("id", 1)
+	en := err.Localize(i18n.EnUS)
+	if en.Msg != "Record not found" {
+		t.Errorf("expected english message, got '%s'", en.Msg)
+	}
+	if en.Data["id"] != 1 {
+		t.Errorf("expected data to be kept, got %v", en.Data)
+	}
+	if err.Msg != defaultErrorMessages[ReasonRecordNotFound] {
+		t.Errorf("expected original message unchanged, got '%s'", err.Msg)
+	}
+
+	// 自定义消息原样保留
+	custom := New(ReasonRecordNotFound, "自定义错误消息", nil)
+	if got := custom.Localize(i18n.EnUS); got.Msg != "自定义错误消息" {
+		t.Errorf("expected custom message kept, got '%s'", got.Msg)
+	}
+
+	// 不支持的语言保持默认消息
+	if got := err.Localize("fr-FR"); got.Msg != err.Msg {
+		t.Errorf("expected default message, got '%s'", got.Msg)
+	}
+}
+
+type langResponder struct {
+	context.Context
+	recordResponder
+	headers map[string]string
+}
+
+func (r *langResponder) Header(key, value string) {
+	r.headers[key] = value
+}
+
+func TestRespondWithErrorLanguage(t *testing.T) {
+	r := &langResponder{
+		Context: i18n.WithLang(context.Background(), i18n.EnUS),
+		headers: map[string]string{},
+	}
+	RespondWithError(r, ErrForbidden)
+	resp := r.obj.(map[string]any)
+	if resp["msg"] != "Access forbidden" {
+		t.Errorf("expected english message, got %v", resp["msg"])
+	}
+	if r.headers["Content-Language"] != i18n.EnUS {
+		t.Errorf("expected Content-Language %s, got %q", i18n.EnUS, r.headers["Content-Language"])
+	}
+}
+
+func TestRespondWithErrorDeadline(t *testing.T) {
+	ctx, cancel := deadline.WithBudget(context.Background(), time.Millisecond)
+	defer cancel()
+	leave := deadline.Enter(ctx, deadline.StageDatabase)
+	<-ctx.Done()
+	leave()
+
+	r := &langResponder{Context: ctx, headers: map[string]string{}}
+	RespondWithError(r, ErrDBTimeout.WithCause(ctx.Err()))
+	if r.code != http.StatusGatewayTimeout {
+		t.Fatalf("expected status 504, got %d", r.code)
+	}
+	resp := r.obj.(map[string]any)
+	if resp["reason"] != ReasonRequestDeadlineExceeded {
+		t.Errorf("expected reason %s, got %v", ReasonRequestDeadlineExceeded, resp["reason"])
+	}
+	data := resp["data"].(map[string]any)
+	if data["stage"] != deadline.StageDatabase {
+		t.Errorf("expected stage %s, got %v", deadline.StageDatabase, data["stage"])
+	}
+
+	// 与超时无关的错误保持不变
+	r = &langResponder{Context: ctx, headers: map[string]string{}}
+	RespondWithError(r, ErrForbidden)
+	if r.code != http.StatusForbidden {
+		t.Errorf("expected status 403, got %d", r.code)
+	}
+}
@@ -1,6 +1,8 @@
 package errors
 
 import (
+	"context"
+
 	"emperror.dev/errors"
 	"gorm.io/gorm"
 )
@@ -19,5 +21,9 @@ func NewGormError(err error, data map[string]any) *Error {
 	if errors.Is(err, gorm.ErrRegistered) {
 		return ErrRegistered.WithFields(data)
 	}
+	// 数据库操作超时单独返回错误码, 客户端断开等导致的取消仍按FromError处理
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ErrDBTimeout.WithCause(err).WithFields(data)
+	}
 	return FromError(err).WithFields(data)
 }
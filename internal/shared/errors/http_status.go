@@ -70,21 +70,182 @@ var reasonToStatus = map[ErrorReason]int{
 	ReasonDownloadFileNotFound:          http.StatusNotFound,
 	ReasonDownloadFilePermissionDenied:  http.StatusForbidden,
 	ReasonDownloadFileFailed:            http.StatusInternalServerError,
+	ReasonRequestBodyTooLarge:           http.StatusRequestEntityTooLarge,
 
 	// 压缩解压文件
-	ReasonUnZIPFailed:       http.StatusInternalServerError,
-	ReasonZIPFailed:         http.StatusInternalServerError,
-	ReasonZIPFileNotFound:   http.StatusNotFound,
-	ReasonZIPFileIsEmpty:    http.StatusBadRequest,
-	ReasonZIPFileIsNotValid: http.StatusBadRequest,
+	ReasonUnZIPFailed:          http.StatusInternalServerError,
+	ReasonZIPFailed:            http.StatusInternalServerError,
+	ReasonZIPFileNotFound:      http.StatusNotFound,
+	ReasonZIPFileIsEmpty:       http.StatusBadRequest,
+	ReasonZIPFileIsNotValid:    http.StatusBadRequest,
+	ReasonArchiveLimitExceeded: http.StatusRequestEntityTooLarge,
 
 	// 缓存文件
 	ReasonExportCacheFileFailed: http.StatusInternalServerError,
 	ReasonDeleteCacheFileFailed: http.StatusInternalServerError,
 
 	// 脚本相关
-	ReasonScriptNotFound:    http.StatusNotFound,
-	ReasonScriptIsBuiltin:   http.StatusBadRequest,
-	ReasonScriptIsDisabled:  http.StatusBadRequest,
-	ReasonScriptLogNotFound: http.StatusNotFound,
+	ReasonScriptNotFound:       http.StatusNotFound,
+	ReasonScriptIsBuiltin:      http.StatusBadRequest,
+	ReasonScriptIsDisabled:     http.StatusBadRequest,
+	ReasonScriptLogNotFound:    http.StatusNotFound,
+	ReasonScriptDryRunDisabled: http.StatusBadRequest,
+
+	// Casbin模型相关
+	ReasonCasbinModelInvalid:      http.StatusBadRequest,
+	ReasonCasbinModelSampleFailed: http.StatusBadRequest,
+	ReasonCasbinModelApplyFailed:  http.StatusInternalServerError,
+	ReasonCasbinModelNoHistory:    http.StatusBadRequest,
+
+	// Prometheus数据源相关
+	ReasonPromDatasourceNotConfigured: http.StatusBadRequest,
+	ReasonPromQueryFailed:             http.StatusBadGateway,
+
+	// 集群导出相关错误
+	ReasonColonyExportNotReady: http.StatusConflict,
+
+	// 部署模式相关
+	ReasonFeatureDisabled: http.StatusForbidden,
+
+	// 任务编排相关
+	ReasonWorkflowNotDefined: http.StatusNotFound,
+	ReasonWorkflowInvalid:    http.StatusUnprocessableEntity,
+	ReasonWorkflowRunning:    http.StatusConflict,
+
+	// 交易日历相关
+	ReasonTradingCalendarInvalid: http.StatusUnprocessableEntity,
+
+	// 维护窗口相关
+	ReasonMaintenanceWindowInvalid: http.StatusUnprocessableEntity,
+
+	// 并发控制相关
+	ReasonVersionConflict: http.StatusConflict,
+	ReasonVersionRequired: http.StatusPreconditionRequired,
+
+	// 日志查询相关
+	ReasonLogQueryFailed: http.StatusInternalServerError,
+
+	// 脚本执行相关
+	ReasonJobsShuttingDown: http.StatusServiceUnavailable,
+
+	// 系统初始化相关
+	ReasonSystemInitialized:      http.StatusConflict,
+	ReasonPasswordChangeRequired: http.StatusForbidden,
+
+	// 签名密钥相关
+	ReasonSigningKeyGenerateFailed: http.StatusInternalServerError,
+
+	// 文件存储相关
+	ReasonFileChecksumMismatch: http.StatusBadRequest,
+	ReasonStorageUnavailable:   http.StatusServiceUnavailable,
+
+	// 分片上传相关
+	ReasonUploadSessionNotFound: http.StatusNotFound,
+	ReasonUploadOffsetMismatch:  http.StatusConflict,
+	ReasonUploadIncomplete:      http.StatusConflict,
+
+	// 查询网关相关
+	ReasonGatewayResponseNotJSON: http.StatusNotAcceptable,
+
+	// 主机文件管理相关
+	ReasonHostPathForbidden:       http.StatusForbidden,
+	ReasonHostFileNotFound:        http.StatusNotFound,
+	ReasonHostFileExists:          http.StatusConflict,
+	ReasonHostFileOperationFailed: http.StatusInternalServerError,
+
+	// 配置模板相关
+	ReasonConfTemplateRenderFailed: http.StatusBadRequest,
+
+	// 权限配置导入导出
+	ReasonRbacDocumentInvalid: http.StatusBadRequest,
+	ReasonRbacImportConflict:  http.StatusConflict,
+
+	// OIDC单点登录
+	ReasonOidcProviderNotFound: http.StatusNotFound,
+	ReasonOidcStateInvalid:     http.StatusBadRequest,
+	ReasonOidcLoginFailed:      http.StatusUnauthorized,
+	ReasonOidcRoleNotMapped:    http.StatusForbidden,
+	ReasonOidcUserNotLinked:    http.StatusForbidden,
+	ReasonOidcIdentityLinked:   http.StatusConflict,
+	ReasonLocalLoginDisabled:   http.StatusForbidden,
+
+	// 登录会话
+	ReasonSessionRevoked:         http.StatusUnauthorized,
+	ReasonSessionNotFound:        http.StatusNotFound,
+	ReasonCSRFTokenInvalid:       http.StatusForbidden,
+	ReasonRefreshTokenReused:     http.StatusUnauthorized,
+	ReasonImpersonationForbidden: http.StatusForbidden,
+
+	// 登录验证码
+	ReasonCaptchaRequired: http.StatusBadRequest,
+	ReasonCaptchaInvalid:  http.StatusBadRequest,
+
+	// 熔断
+	ReasonCircuitOpen:         http.StatusServiceUnavailable,
+	ReasonDatabaseUnavailable: http.StatusServiceUnavailable,
+
+	// 后台任务
+	ReasonTaskFinished:       http.StatusConflict,
+	ReasonTaskServiceStopped: http.StatusServiceUnavailable,
+	ReasonTaskInterrupted:    http.StatusInternalServerError,
+
+	// 功能开关相关错误
+	ReasonFeatureNotReleased: http.StatusNotFound,
+
+	// 组织架构
+	ReasonDepartmentMoveInvalid: http.StatusBadRequest,
+	ReasonDepartmentNotEmpty:    http.StatusConflict,
+
+	// 行情数据采集
+	ReasonMdsIngestRunning: http.StatusConflict,
+
+	// 行情数据回补
+	ReasonMdsBackfillRunning: http.StatusConflict,
+	ReasonMdsBackfillState:   http.StatusConflict,
+
+	// 运维检查单
+	ReasonRunbookRunning:   http.StatusConflict,
+	ReasonRunbookStepState: http.StatusConflict,
+
+	// 变更冻结
+	ReasonChangeFreezeInvalid:  http.StatusUnprocessableEntity,
+	ReasonChangeFrozen:         http.StatusLocked,
+	ReasonFreezeOverrideDenied: http.StatusForbidden,
+
+	// 主机代理
+	ReasonAgentTokenInvalid: http.StatusUnauthorized,
+
+	// 进程守护
+	ReasonWatchdogInvalid: http.StatusUnprocessableEntity,
+
+	// 备份
+	ReasonBackupRunning:  http.StatusConflict,
+	ReasonBackupNotReady: http.StatusConflict,
+	ReasonBackupFailed:   http.StatusInternalServerError,
+
+	// 用户偏好
+	ReasonPreferenceNamespaceInvalid: http.StatusBadRequest,
+	ReasonPreferenceInvalid:          http.StatusUnprocessableEntity,
+	ReasonPreferenceTooLarge:         http.StatusRequestEntityTooLarge,
+	ReasonPreferenceLimitExceeded:    http.StatusConflict,
+
+	// 批量删除
+	ReasonDeleteBlocked:        http.StatusConflict,
+	ReasonDeleteTargetNotFound: http.StatusNotFound,
+
+	// 数据库操作超时
+	ReasonDBTimeout: http.StatusGatewayTimeout,
+
+	// 日志级别
+	ReasonLoggerNotFound: http.StatusNotFound,
+
+	// 请求处理时限
+	ReasonRequestDeadlineInvalid:  http.StatusBadRequest,
+	ReasonRequestDeadlineExceeded: http.StatusGatewayTimeout,
+
+	// 用户停用
+	ReasonUserDeactivateSelf:    http.StatusBadRequest,
+	ReasonUserDisabled:          http.StatusConflict,
+	ReasonHandoverTargetInvalid: http.StatusBadRequest,
+	ReasonHandoverKindInvalid:   http.StatusBadRequest,
 }
@@ -68,21 +68,182 @@ var defaultErrorMessages = map[ErrorReason]string{
 	ReasonDownloadFileNotFound:          "下载的文件未找到",
 	ReasonDownloadFilePermissionDenied:  "下载文件权限被拒绝",
 	ReasonDownloadFileFailed:            "下载文件失败",
+	ReasonRequestBodyTooLarge:           "请求体超出大小限制",
 
 	// 压缩解压文件
-	ReasonUnZIPFailed:       "解压文件失败",
-	ReasonZIPFailed:         "压缩文件失败",
-	ReasonZIPFileNotFound:   "压缩文件未找到",
-	ReasonZIPFileIsEmpty:    "压缩文件为空",
-	ReasonZIPFileIsNotValid: "压缩文件无效",
+	ReasonUnZIPFailed:          "解压文件失败",
+	ReasonZIPFailed:            "压缩文件失败",
+	ReasonZIPFileNotFound:      "压缩文件未找到",
+	ReasonZIPFileIsEmpty:       "压缩文件为空",
+	ReasonZIPFileIsNotValid:    "压缩文件无效",
+	ReasonArchiveLimitExceeded: "压缩文件超出解压限制(文件数量、大小、压缩比或目录深度)",
 
 	// 缓存文件
 	ReasonExportCacheFileFailed: "缓存文件导出失败",
 	ReasonDeleteCacheFileFailed: "缓存文件删除失败",
 
 	// 脚本相关
-	ReasonScriptNotFound:    "脚本未找到",
-	ReasonScriptIsBuiltin:   "脚本为内置脚本",
-	ReasonScriptIsDisabled:  "脚本已禁用",
-	ReasonScriptLogNotFound: "脚本日志未找到",
+	ReasonScriptNotFound:       "脚本未找到",
+	ReasonScriptIsBuiltin:      "脚本为内置脚本",
+	ReasonScriptIsDisabled:     "脚本已禁用",
+	ReasonScriptLogNotFound:    "脚本日志未找到",
+	ReasonScriptDryRunDisabled: "未配置试运行沙箱",
+
+	// Casbin模型相关
+	ReasonCasbinModelInvalid:      "Casbin模型配置无效",
+	ReasonCasbinModelSampleFailed: "Casbin模型样例请求校验未通过",
+	ReasonCasbinModelApplyFailed:  "Casbin模型应用失败",
+	ReasonCasbinModelNoHistory:    "没有可回滚的Casbin模型历史版本",
+
+	// Prometheus数据源相关
+	ReasonPromDatasourceNotConfigured: "未配置Prometheus数据源",
+	ReasonPromQueryFailed:             "Prometheus查询失败",
+
+	// 集群导出相关错误
+	ReasonColonyExportNotReady: "集群导出尚未完成",
+
+	// 部署模式相关
+	ReasonFeatureDisabled: "当前部署模式不支持该功能",
+
+	// 任务编排相关
+	ReasonWorkflowNotDefined: "未配置该系统类型的任务编排",
+	ReasonWorkflowInvalid:    "任务编排配置无效",
+	ReasonWorkflowRunning:    "集群已有正在执行的任务编排",
+
+	// 交易日历相关
+	ReasonTradingCalendarInvalid: "交易日历数据无效",
+
+	// 维护窗口相关
+	ReasonMaintenanceWindowInvalid: "维护窗口配置无效",
+
+	// 并发控制相关
+	ReasonVersionConflict: "数据已被其他用户修改",
+	ReasonVersionRequired: "缺少数据版本号",
+
+	// 日志查询相关
+	ReasonLogQueryFailed: "日志查询失败",
+
+	// 脚本执行相关
+	ReasonJobsShuttingDown: "服务正在关闭, 暂不接受新的脚本执行",
+
+	// 系统初始化相关
+	ReasonSystemInitialized:      "系统已完成初始化",
+	ReasonPasswordChangeRequired: "请先修改初始密码",
+
+	// 签名密钥相关
+	ReasonSigningKeyGenerateFailed: "生成签名密钥失败",
+
+	// 文件存储相关
+	ReasonFileChecksumMismatch: "文件校验和不一致",
+	ReasonStorageUnavailable:   "文件存储服务不可用",
+
+	// 分片上传相关
+	ReasonUploadSessionNotFound: "上传会话不存在或已过期",
+	ReasonUploadOffsetMismatch:  "分片起始位置与已接收的字节数不一致",
+	ReasonUploadIncomplete:      "文件尚未全部上传",
+
+	// 查询网关相关
+	ReasonGatewayResponseNotJSON: "子查询接口的响应不是JSON",
+
+	// 主机文件管理相关
+	ReasonHostPathForbidden:       "主机路径不在允许访问的范围内",
+	ReasonHostFileNotFound:        "主机文件不存在",
+	ReasonHostFileExists:          "主机文件已存在",
+	ReasonHostFileOperationFailed: "主机文件操作失败",
+
+	// 配置模板相关
+	ReasonConfTemplateRenderFailed: "配置模板渲染失败",
+
+	// 权限配置导入导出
+	ReasonRbacDocumentInvalid: "权限配置文档无效",
+	ReasonRbacImportConflict:  "导入的权限配置与已有数据冲突",
+
+	// OIDC单点登录
+	ReasonOidcProviderNotFound: "身份提供方不存在",
+	ReasonOidcStateInvalid:     "登录状态无效或已过期",
+	ReasonOidcLoginFailed:      "单点登录失败",
+	ReasonOidcRoleNotMapped:    "没有匹配的角色映射规则",
+	ReasonOidcUserNotLinked:    "外部身份未关联用户",
+	ReasonOidcIdentityLinked:   "外部身份已关联其他用户",
+	ReasonLocalLoginDisabled:   "本地用户名密码登录已禁用",
+
+	// 登录会话
+	ReasonSessionRevoked:         "会话已失效, 请重新登录",
+	ReasonSessionNotFound:        "会话不存在或已失效",
+	ReasonCSRFTokenInvalid:       "CSRF令牌缺失或不匹配",
+	ReasonRefreshTokenReused:     "刷新令牌已被使用, 会话已终止, 请重新登录",
+	ReasonImpersonationForbidden: "不允许代为登录该用户",
+
+	// 登录验证码
+	ReasonCaptchaRequired: "登录失败次数过多, 请输入验证码",
+	ReasonCaptchaInvalid:  "验证码错误或已过期",
+
+	// 熔断
+	ReasonCircuitOpen:         "下游服务暂时不可用, 请稍后重试",
+	ReasonDatabaseUnavailable: "数据库暂时不可用, 请稍后重试",
+
+	// 后台任务
+	ReasonTaskFinished:       "任务已结束",
+	ReasonTaskServiceStopped: "服务正在关闭",
+	ReasonTaskInterrupted:    "服务重启, 任务中断",
+
+	// 功能开关相关错误
+	ReasonFeatureNotReleased: "功能未开放",
+
+	// 组织架构
+	ReasonDepartmentMoveInvalid: "不能将部门移动到自身或下级部门下",
+	ReasonDepartmentNotEmpty:    "部门下存在下级部门或用户, 不能删除",
+
+	// 行情数据采集
+	ReasonMdsIngestRunning: "该数据源当日的采集正在进行, 请稍后重试",
+
+	// 行情数据回补
+	ReasonMdsBackfillRunning: "集群已有正在执行的数据回补",
+	ReasonMdsBackfillState:   "数据回补的当前状态不支持该操作",
+
+	// 运维检查单
+	ReasonRunbookRunning:   "集群已有正在执行的该检查单",
+	ReasonRunbookStepState: "检查单步骤的当前状态不支持该操作",
+
+	// 变更冻结
+	ReasonChangeFreezeInvalid:  "变更冻结配置无效",
+	ReasonChangeFrozen:         "变更冻结期间不允许修改, 紧急变更由工作人员在X-Freeze-Override请求头中填写理由",
+	ReasonFreezeOverrideDenied: "只有工作人员可以越过变更冻结",
+
+	// 主机代理
+	ReasonAgentTokenInvalid: "主机代理令牌无效",
+
+	// 进程守护
+	ReasonWatchdogInvalid: "进程守护定义无效",
+
+	// 备份
+	ReasonBackupRunning:  "已有备份正在执行, 请稍后重试",
+	ReasonBackupNotReady: "备份未成功, 不能下载",
+	ReasonBackupFailed:   "备份失败",
+
+	// 用户偏好
+	ReasonPreferenceNamespaceInvalid: "不支持的偏好命名空间",
+	ReasonPreferenceInvalid:          "偏好内容不符合命名空间的格式要求",
+	ReasonPreferenceTooLarge:         "偏好内容超过大小限制",
+	ReasonPreferenceLimitExceeded:    "偏好命名空间数量已达上限",
+
+	// 批量删除
+	ReasonDeleteBlocked:        "存在被引用的记录, 不能删除, 请先解除引用",
+	ReasonDeleteTargetNotFound: "要删除的记录不存在",
+
+	// 数据库操作超时
+	ReasonDBTimeout: "数据库操作超时, 请缩小查询范围后重试",
+
+	// 日志级别
+	ReasonLoggerNotFound: "日志记录器不存在",
+
+	// 请求处理时限
+	ReasonRequestDeadlineInvalid:  "请求处理时限格式错误",
+	ReasonRequestDeadlineExceeded: "请求处理超过客户端指定的时限",
+
+	// 用户停用
+	ReasonUserDeactivateSelf:    "不能停用当前登录的用户",
+	ReasonUserDisabled:          "用户已永久停用, 不能重新启用",
+	ReasonHandoverTargetInvalid: "交接对象必须是其他已启用的用户",
+	ReasonHandoverKindInvalid:   "不支持的交接记录类型",
 }
@@ -0,0 +1,265 @@
+package errors
+
+import "gin-artweb/internal/shared/i18n"
+
+// 错误原因即错误消息在消息目录中的键
+func init() {
+	for lang, messages := range map[string]map[ErrorReason]string{
+		i18n.ZhCN: defaultErrorMessages,
+		i18n.EnUS: enErrorMessages,
+	} {
+		catalog := make(map[string]string, len(messages))
+		for reason, msg := range messages {
+			catalog[string(reason)] = msg
+		}
+		i18n.Register(lang, catalog)
+	}
+}
+
+// 英文错误消息映射, 与defaultErrorMessages一一对应
+var enErrorMessages = map[ErrorReason]string{
+	// 通用错误
+	ReasonUnknown:           "Unknown error",
+	ReasonValidationFailed:  "Parameter validation failed",
+	ReasonRequestTimeout:    "Request timed out",
+	ReasonRateLimitExceeded: "Too many requests, rate limit exceeded",
+
+	// 上下文相关
+	ReasonNoContext:        "Context is empty",
+	ReasonCanceled:         "Request canceled",
+	ReasonDeadlineExceeded: "Request timed out",
+
+	// 安全认证
+	ReasonHostHeaderInvalid:      "Invalid Host header",
+	ReasonNonceNotFound:          "Missing nonce in request headers",
+	ReasonReplayAttack:           "Replay attack detected",
+	ReasonTimestampNotFound:      "Missing timestamp in request headers",
+	ReasonTimestampInvalid:       "Invalid timestamp",
+	ReasonTimestampExpired:       "Timestamp expired",
+	ReasonPasswordStrengthFailed: "Password is not strong enough",
+
+	// 身份权限认证
+	ReasonUnauthorized:      "Unauthorized",
+	ReasonTokenExpired:      "Login expired, please log in again",
+	ReasonTokenInvalid:      "Invalid login credentials",
+	ReasonMissingAuth:       "Missing authentication information",
+	ReasonTokenTypeMismatch: "Token type mismatch",
+	ReasonAuthFailed:        "Authentication failed",
+	ReasonAccountLocked:     "Account is locked",
+	ReasonForbidden:         "Access forbidden",
+
+	// 数据库服务
+	ReasonRecordNotFound:                "Record not found",
+	ReasonInvalidTransaction:            "Transaction error",
+	ReasonNotImplemented:                "Not implemented",
+	ReasonMissingWhereClause:            "Missing where clause",
+	ReasonUnsupportedRelation:           "Unsupported relation",
+	ReasonPrimaryKeyRequired:            "Primary key is required",
+	ReasonModelValueRequired:            "Model value is required",
+	ReasonModelAccessibleFieldsRequired: "Model fields are not accessible",
+	ReasonSubQueryRequired:              "Sub query is required",
+	ReasonInvalidData:                   "Invalid data",
+	ReasonUnsupportedDriver:             "Unsupported database driver",
+	ReasonRegistered:                    "Model already registered",
+	ReasonInvalidField:                  "Invalid field",
+	ReasonEmptySlice:                    "Array must not be empty",
+	ReasonDryRunModeUnsupported:         "Dry run mode is not supported",
+	ReasonInvalidDB:                     "Invalid database connection",
+	ReasonInvalidValue:                  "Invalid value type",
+	ReasonInvalidValueOfLength:          "Invalid association values, length mismatch",
+	ReasonPreloadNotAllowed:             "Preload is not allowed when counting",
+	ReasonDuplicatedKey:                 "Unique constraint violated",
+	ReasonForeignKeyViolated:            "Foreign key constraint violated",
+	ReasonCheckConstraintViolated:       "Check constraint violated",
+
+	// ssh服务
+	ReasonSSHConnectionFailed: "SSH connection failed",
+	ReasonSSHKeyDeployFailed:  "Failed to deploy SSH key",
+
+	// 上传下载文件
+	ReasonUploadFileNotFound:            "Uploaded file not found",
+	ReasonUploadFileTooLarge:            "Uploaded file exceeds the size limit",
+	ReasonSaveUploadFileFailed:          "Failed to save uploaded file",
+	ReasonSetUploadFilePermissionFailed: "Failed to set uploaded file permissions",
+	ReasonDownloadFileNotFound:          "File to download not found",
+	ReasonDownloadFilePermissionDenied:  "Permission denied for file download",
+	ReasonDownloadFileFailed:            "Failed to download file",
+	ReasonRequestBodyTooLarge:           "Request body exceeds the size limit",
+
+	// 压缩解压文件
+	ReasonUnZIPFailed:          "Failed to extract archive",
+	ReasonZIPFailed:            "Failed to create archive",
+	ReasonZIPFileNotFound:      "Archive not found",
+	ReasonZIPFileIsEmpty:       "Archive is empty",
+	ReasonZIPFileIsNotValid:    "Invalid archive",
+	ReasonArchiveLimitExceeded: "Archive exceeds extraction limits (file count, size, compression ratio or directory depth)",
+
+	// 缓存文件
+	ReasonExportCacheFileFailed: "Failed to export cache file",
+	ReasonDeleteCacheFileFailed: "Failed to delete cache file",
+
+	// 脚本相关
+	ReasonScriptNotFound:       "Script not found",
+	ReasonScriptIsBuiltin:      "Script is built in",
+	ReasonScriptIsDisabled:     "Script is disabled",
+	ReasonScriptLogNotFound:    "Script log not found",
+	ReasonScriptDryRunDisabled: "Dry run sandbox is not configured",
+
+	// Casbin模型相关
+	ReasonCasbinModelInvalid:      "Invalid Casbin model",
+	ReasonCasbinModelSampleFailed: "Casbin model sample requests failed validation",
+	ReasonCasbinModelApplyFailed:  "Failed to apply Casbin model",
+	ReasonCasbinModelNoHistory:    "No Casbin model history to roll back to",
+
+	// Prometheus数据源相关
+	ReasonPromDatasourceNotConfigured: "Prometheus datasource is not configured",
+	ReasonPromQueryFailed:             "Prometheus query failed",
+
+	// 集群导出相关错误
+	ReasonColonyExportNotReady: "Colony export is not finished yet",
+
+	// 部署模式相关
+	ReasonFeatureDisabled: "This feature is not supported in the current deploy mode",
+
+	// 任务编排相关
+	ReasonWorkflowNotDefined: "No workflow is configured for this system type",
+	ReasonWorkflowInvalid:    "Invalid workflow configuration",
+	ReasonWorkflowRunning:    "A workflow is already running for this colony",
+
+	// 交易日历相关
+	ReasonTradingCalendarInvalid: "Invalid trading calendar data",
+
+	// 维护窗口相关
+	ReasonMaintenanceWindowInvalid: "Invalid maintenance window configuration",
+
+	// 并发控制相关
+	ReasonVersionConflict: "The data has been modified by another user",
+	ReasonVersionRequired: "Missing data version",
+
+	// 日志查询相关
+	ReasonLogQueryFailed: "Log query failed",
+
+	// 脚本执行相关
+	ReasonJobsShuttingDown: "The service is shutting down and does not accept new script executions",
+
+	// 系统初始化相关
+	ReasonSystemInitialized:      "The system has already been initialized",
+	ReasonPasswordChangeRequired: "Please change the initial password first",
+
+	// 签名密钥相关
+	ReasonSigningKeyGenerateFailed: "Failed to generate signing key",
+
+	// 文件存储相关
+	ReasonFileChecksumMismatch: "File checksum mismatch",
+	ReasonStorageUnavailable:   "File storage is unavailable",
+
+	// 分片上传相关
+	ReasonUploadSessionNotFound: "Upload session does not exist or has expired",
+	ReasonUploadOffsetMismatch:  "Chunk offset does not match the number of bytes received",
+	ReasonUploadIncomplete:      "The file has not been fully uploaded",
+
+	// 查询网关相关
+	ReasonGatewayResponseNotJSON: "The sub query response is not JSON",
+
+	// 主机文件管理相关
+	ReasonHostPathForbidden:       "The host path is outside the allowed range",
+	ReasonHostFileNotFound:        "Host file not found",
+	ReasonHostFileExists:          "Host file already exists",
+	ReasonHostFileOperationFailed: "Host file operation failed",
+
+	// 配置模板相关
+	ReasonConfTemplateRenderFailed: "Failed to render configuration template",
+
+	// 权限配置导入导出
+	ReasonRbacDocumentInvalid: "Invalid permission configuration document",
+	ReasonRbacImportConflict:  "The imported permission configuration conflicts with existing data",
+
+	// OIDC单点登录
+	ReasonOidcProviderNotFound: "Identity provider not found",
+	ReasonOidcStateInvalid:     "Login state is invalid or has expired",
+	ReasonOidcLoginFailed:      "Single sign-on failed",
+	ReasonOidcRoleNotMapped:    "No matching role mapping rule",
+	ReasonOidcUserNotLinked:    "The external identity is not linked to a user",
+	ReasonOidcIdentityLinked:   "The external identity is linked to another user",
+	ReasonLocalLoginDisabled:   "Local username and password login is disabled",
+
+	// 登录会话
+	ReasonSessionRevoked:         "Session is no longer valid, please log in again",
+	ReasonSessionNotFound:        "Session does not exist or is no longer valid",
+	ReasonCSRFTokenInvalid:       "CSRF token is missing or does not match",
+	ReasonRefreshTokenReused:     "Refresh token was already used, the session has been terminated, please log in again",
+	ReasonImpersonationForbidden: "Impersonating this user is not allowed",
+
+	// 登录验证码
+	ReasonCaptchaRequired: "Too many failed logins, please enter the captcha",
+	ReasonCaptchaInvalid:  "Captcha is wrong or has expired",
+
+	// 熔断
+	ReasonCircuitOpen:         "Downstream service is temporarily unavailable, please retry later",
+	ReasonDatabaseUnavailable: "Database is temporarily unavailable, please retry later",
+
+	// 后台任务
+	ReasonTaskFinished:       "Task has finished",
+	ReasonTaskServiceStopped: "The service is shutting down",
+	ReasonTaskInterrupted:    "Task interrupted by service restart",
+
+	// 功能开关相关错误
+	ReasonFeatureNotReleased: "Feature is not available",
+
+	// 组织架构
+	ReasonDepartmentMoveInvalid: "A department cannot be moved under itself or its sub departments",
+	ReasonDepartmentNotEmpty:    "The department has sub departments or users and cannot be deleted",
+
+	// 行情数据采集
+	ReasonMdsIngestRunning: "Ingestion for this source and day is already running, please retry later",
+
+	// 行情数据回补
+	ReasonMdsBackfillRunning: "A backfill is already running for this colony",
+	ReasonMdsBackfillState:   "The current backfill state does not allow this operation",
+
+	// 运维检查单
+	ReasonRunbookRunning:   "This runbook is already running for the colony",
+	ReasonRunbookStepState: "The current runbook step state does not allow this operation",
+
+	// 变更冻结
+	ReasonChangeFreezeInvalid:  "Invalid change freeze configuration",
+	ReasonChangeFrozen:         "Changes are not allowed during a change freeze, staff may give a reason in the X-Freeze-Override header for emergency changes",
+	ReasonFreezeOverrideDenied: "Only staff can override a change freeze",
+
+	// 主机代理
+	ReasonAgentTokenInvalid: "Invalid host agent token",
+
+	// 进程守护
+	ReasonWatchdogInvalid: "Invalid watchdog definition",
+
+	// 备份
+	ReasonBackupRunning:  "A backup is already running, please retry later",
+	ReasonBackupNotReady: "The backup did not succeed and cannot be downloaded",
+	ReasonBackupFailed:   "Backup failed",
+
+	// 用户偏好
+	ReasonPreferenceNamespaceInvalid: "Unsupported preference namespace",
+	ReasonPreferenceInvalid:          "The preference does not match the namespace format",
+	ReasonPreferenceTooLarge:         "The preference exceeds the size limit",
+	ReasonPreferenceLimitExceeded:    "The number of preference namespaces has reached the limit",
+
+	// 批量删除
+	ReasonDeleteBlocked:        "Some records are still referenced and cannot be deleted, remove the references first",
+	ReasonDeleteTargetNotFound: "The records to delete do not exist",
+
+	// 数据库操作超时
+	ReasonDBTimeout: "Database operation timed out, please narrow the query and retry",
+
+	// 日志级别
+	ReasonLoggerNotFound: "Logger not found",
+
+	// 请求处理时限
+	ReasonRequestDeadlineInvalid:  "Invalid request deadline",
+	ReasonRequestDeadlineExceeded: "Request exceeded the client deadline",
+
+	// 用户停用
+	ReasonUserDeactivateSelf:    "Cannot deactivate the current user",
+	ReasonUserDisabled:          "User has been permanently disabled and cannot be reactivated",
+	ReasonHandoverTargetInvalid: "Handover target must be another active user",
+	ReasonHandoverKindInvalid:   "Unsupported handover record kind",
+}
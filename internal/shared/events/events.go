@@ -0,0 +1,153 @@
+// Package events 进程内的领域事件总线和事务发件箱
+//
+// 业务层通过发件箱在业务数据所在的事务中写入事件, 事务提交后由分发协程发布到事件总线,
+// 订阅方(如webhook推送)按至少一次的语义收到事件, 处理失败时整个事件会重试, 因此订阅方需要幂等.
+// 订阅方应尽快返回, 耗时的处理需要自行异步执行
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"gin-artweb/internal/shared/ctxutil"
+)
+
+// 事件类型, 事件内容由各模块在model包中定义
+const (
+	UserCreated     = "user.created"     // 用户创建
+	ColonyUpdated   = "colony.updated"   // 集群更新
+	JobFinished     = "job.finished"     // 脚本执行结束
+	AlertFired      = "alert.fired"      // 告警发出
+	ReportGenerated = "report.generated" // 定时报表生成
+)
+
+// Types 返回全部事件类型
+func Types() []string {
+	return []string{UserCreated, ColonyUpdated, JobFinished, AlertFired, ReportGenerated}
+}
+
+// Payload 事件内容, 序列化为JSON后作为事件的data字段
+type Payload interface {
+	EventType() string
+}
+
+// Event 领域事件
+type Event struct {
+	ID         string          `json:"id"`
+	Type       string          `json:"type"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// NewEvent 根据事件内容创建事件
+func NewEvent(p Payload) (Event, error) {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return Event{}, errors.WrapIf(err, "序列化事件内容失败")
+	}
+	return Event{
+		ID:         uuid.NewString(),
+		Type:       p.EventType(),
+		OccurredAt: time.Now(),
+		Data:       data,
+	}, nil
+}
+
+// Decode 将事件内容解析到对应模块定义的事件结构体
+func (e Event) Decode(v any) error {
+	return json.Unmarshal(e.Data, v)
+}
+
+// Handler 事件处理函数, 返回错误时发件箱会重试该事件
+type Handler func(ctx context.Context, event Event) error
+
+// Bus 事件总线, 为nil时发布和订阅均不做任何处理
+type Bus struct {
+	log      *zap.Logger
+	mu       sync.RWMutex
+	handlers []Handler
+}
+
+func NewBus(log *zap.Logger) *Bus {
+	return &Bus{log: log}
+}
+
+// Subscribe 订阅全部事件
+func (b *Bus) Subscribe(h Handler) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers = append(b.handlers, h)
+}
+
+// Publish 立即发布事件, 不经过发件箱, 处理失败时只记录日志
+//
+// 用于不需要可靠投递的事件, 需要在事务提交后可靠投递的事件使用Outbox
+func (b *Bus) Publish(ctx context.Context, p Payload) {
+	if b == nil {
+		return
+	}
+	event, err := NewEvent(p)
+	if err != nil {
+		ctxutil.Logger(ctx, b.log).Error(
+			"创建事件失败",
+			zap.Error(err),
+			zap.String("event_type", p.EventType()),
+		)
+		return
+	}
+	if err := b.Deliver(ctx, event); err != nil {
+		ctxutil.Logger(ctx, b.log).Error(
+			"处理事件失败",
+			zap.Error(err),
+			zap.String("event_id", event.ID),
+			zap.String("event_type", event.Type),
+		)
+	}
+}
+
+// Deliver 依次调用订阅方处理事件, 单个订阅方失败或panic不影响其他订阅方, 返回全部订阅方的错误
+func (b *Bus) Deliver(ctx context.Context, event Event) error {
+	if b == nil {
+		return nil
+	}
+	b.mu.RLock()
+	handlers := b.handlers
+	b.mu.RUnlock()
+
+	// 订阅方的处理不应随请求结束而取消
+	ctx = context.WithoutCancel(ctx)
+	var errs []error
+	for _, h := range handlers {
+		if err := b.dispatch(ctx, h, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Combine(errs...)
+}
+
+func (b *Bus) dispatch(ctx context.Context, h Handler, event Event) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			ctxutil.Logger(ctx, b.log).Error(
+				"处理事件发生panic",
+				zap.Any("panic", r),
+				zap.String("event_id", event.ID),
+				zap.String("event_type", event.Type),
+				zap.String("stack", string(debug.Stack())),
+			)
+			err = fmt.Errorf("处理事件发生panic: %v", r)
+		}
+	}()
+	return h(ctx, event)
+}
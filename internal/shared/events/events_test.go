@@ -0,0 +1,85 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+type testPayload struct {
+	ID int `json:"id"`
+}
+
+func (p testPayload) EventType() string {
+	return UserCreated
+}
+
+func TestBusPublish(t *testing.T) {
+	bus := NewBus(zap.NewNop())
+
+	var got []Event
+	bus.Subscribe(func(ctx context.Context, event Event) error {
+		panic("boom")
+	})
+	bus.Subscribe(func(ctx context.Context, event Event) error {
+		if ctx.Err() != nil {
+			t.Errorf("handler context should not be canceled with the publisher, got %v", ctx.Err())
+		}
+		got = append(got, event)
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	bus.Publish(ctx, testPayload{ID: 1})
+
+	if len(got) != 1 {
+		t.Fatalf("expected 1 event after panic in other handler, got %d", len(got))
+	}
+	if got[0].Type != UserCreated || got[0].ID == "" || got[0].OccurredAt.IsZero() {
+		t.Errorf("unexpected event: %+v", got[0])
+	}
+	var p testPayload
+	if err := got[0].Decode(&p); err != nil || p.ID != 1 {
+		t.Errorf("expected payload to be passed through, got %s (%v)", got[0].Data, err)
+	}
+}
+
+func TestBusDeliverErrors(t *testing.T) {
+	bus := NewBus(zap.NewNop())
+	calls := 0
+	bus.Subscribe(func(ctx context.Context, event Event) error {
+		calls++
+		return errors.New("failed")
+	})
+	bus.Subscribe(func(ctx context.Context, event Event) error {
+		calls++
+		panic("boom")
+	})
+	bus.Subscribe(func(ctx context.Context, event Event) error {
+		calls++
+		return nil
+	})
+
+	event, err := NewEvent(testPayload{ID: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := bus.Deliver(context.Background(), event); err == nil {
+		t.Error("expected handler errors and panics to be returned")
+	}
+	if calls != 3 {
+		t.Errorf("expected every handler to be called, got %d", calls)
+	}
+}
+
+func TestNilBus(t *testing.T) {
+	var bus *Bus
+	bus.Subscribe(func(ctx context.Context, event Event) error {
+		t.Fatal("nil bus should not call handlers")
+		return nil
+	})
+	bus.Publish(context.Background(), testPayload{})
+}
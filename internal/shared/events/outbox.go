@@ -0,0 +1,296 @@
+package events
+
+import (
+	"context"
+	"time"
+
+	"emperror.dev/errors"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"gorm.io/gorm"
+
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/database"
+)
+
+const (
+	defaultOutboxInterval    = 5 * time.Second
+	defaultOutboxMaxAttempts = 10
+	defaultOutboxBatchSize   = 100
+
+	// 重试间隔最长10分钟
+	outboxRetryMax = 10 * time.Minute
+
+	// 发布事件前先将下次发布时间推迟, 多实例部署时其他实例在此期间不会重复发布
+	outboxLease = time.Minute
+)
+
+// 发件箱事件状态
+const (
+	OutboxPending   = 0 // 待发布, 包括等待重试
+	OutboxPublished = 1 // 已发布
+	OutboxFailed    = 2 // 重试次数用尽后发布失败
+)
+
+// OutboxModel 发件箱中的事件
+type OutboxModel struct {
+	database.StandardModel
+	EventID       string     `gorm:"column:event_id;type:varchar(36);not null;uniqueIndex;comment:事件ID" json:"event_id"`
+	EventType     string     `gorm:"column:event_type;type:varchar(50);not null;comment:事件类型" json:"event_type"`
+	OccurredAt    time.Time  `gorm:"column:occurred_at;comment:发生时间" json:"occurred_at"`
+	Payload       string     `gorm:"column:payload;type:text;comment:事件内容" json:"payload"`
+	Status        int        `gorm:"column:status;type:tinyint;not null;default:0;index:idx_event_outbox_status_next;comment:状态(0-待发布,1-已发布,2-失败)" json:"status"`
+	Attempts      int        `gorm:"column:attempts;not null;default:0;comment:已发布次数" json:"attempts"`
+	LastError     string     `gorm:"column:last_error;type:varchar(512);comment:最近一次错误信息" json:"last_error"`
+	NextAttemptAt time.Time  `gorm:"column:next_attempt_at;index:idx_event_outbox_status_next;comment:下次发布时间" json:"next_attempt_at"`
+	PublishedAt   *time.Time `gorm:"column:published_at;comment:发布时间" json:"published_at"`
+}
+
+func (m *OutboxModel) TableName() string {
+	return "system_event_outbox"
+}
+
+func (m *OutboxModel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if m == nil {
+		return nil
+	}
+	if err := m.StandardModel.MarshalLogObject(enc); err != nil {
+		return err
+	}
+	enc.AddString("event_id", m.EventID)
+	enc.AddString("event_type", m.EventType)
+	enc.AddInt("status", m.Status)
+	enc.AddInt("attempts", m.Attempts)
+	return nil
+}
+
+// Event 还原为事件
+func (m *OutboxModel) Event() Event {
+	return Event{
+		ID:         m.EventID,
+		Type:       m.EventType,
+		OccurredAt: m.OccurredAt,
+		Data:       []byte(m.Payload),
+	}
+}
+
+// Outbox 事务发件箱, 为nil时不写入也不发布事件
+//
+// 事件在业务数据所在的事务中写入发件箱表, 事务回滚时事件随之丢弃,
+// 提交后由分发协程发布到事件总线, 发布失败时按指数退避重试
+type Outbox struct {
+	log         *zap.Logger
+	db          *gorm.DB
+	bus         *Bus
+	interval    time.Duration
+	maxAttempts int
+	batchSize   int
+
+	wake chan struct{}
+	stop chan struct{}
+	done chan struct{}
+}
+
+func NewOutbox(log *zap.Logger, db *gorm.DB, bus *Bus, conf *config.EventsConfig) *Outbox {
+	o := &Outbox{
+		log:         log,
+		db:          db,
+		bus:         bus,
+		interval:    defaultOutboxInterval,
+		maxAttempts: defaultOutboxMaxAttempts,
+		batchSize:   defaultOutboxBatchSize,
+		wake:        make(chan struct{}, 1),
+	}
+	if conf != nil {
+		if conf.Interval > 0 {
+			o.interval = time.Duration(conf.Interval) * time.Second
+		}
+		if conf.MaxAttempts > 0 {
+			o.maxAttempts = conf.MaxAttempts
+		}
+		if conf.BatchSize > 0 {
+			o.batchSize = conf.BatchSize
+		}
+	}
+	return o
+}
+
+// Stage 返回在写操作的事务中写入事件的上下文
+//
+// build在写操作完成后、事务提交前调用, 可以使用写操作生成的ID等数据;
+// 返回的上下文只应传给一次写操作, 写操作成功后调用Notify尽快发布
+func (o *Outbox) Stage(ctx context.Context, build func() Payload) context.Context {
+	if o == nil {
+		return ctx
+	}
+	return database.WithTxHook(ctx, func(tx *gorm.DB) error {
+		return o.insert(tx, build())
+	})
+}
+
+// Add 在单独的写操作中写入事件并通知分发协程, 用于没有业务数据写入的事件
+func (o *Outbox) Add(ctx context.Context, p Payload) error {
+	if o == nil {
+		return nil
+	}
+	if err := o.insert(o.db.WithContext(ctx), p); err != nil {
+		return err
+	}
+	o.Notify()
+	return nil
+}
+
+func (o *Outbox) insert(db *gorm.DB, p Payload) error {
+	event, err := NewEvent(p)
+	if err != nil {
+		return err
+	}
+	m := OutboxModel{
+		EventID:       event.ID,
+		EventType:     event.Type,
+		OccurredAt:    event.OccurredAt,
+		Payload:       string(event.Data),
+		Status:        OutboxPending,
+		NextAttemptAt: event.OccurredAt,
+	}
+	return errors.WrapIf(db.Create(&m).Error, "写入发件箱失败")
+}
+
+// Notify 通知分发协程有新的事件
+func (o *Outbox) Notify() {
+	if o == nil {
+		return
+	}
+	select {
+	case o.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Dispatch 发布到期的待发布事件, 返回发布成功的事件数
+func (o *Outbox) Dispatch(ctx context.Context) (int, error) {
+	var ms []OutboxModel
+	err := o.db.WithContext(ctx).
+		Where("status = ? AND next_attempt_at <= ?", OutboxPending, time.Now()).
+		Order("id ASC").
+		Limit(o.batchSize).
+		Find(&ms).Error
+	if err != nil {
+		return 0, errors.WrapIf(err, "查询待发布事件失败")
+	}
+
+	published := 0
+	for i := range ms {
+		if ctx.Err() != nil {
+			return published, ctx.Err()
+		}
+		ok, err := o.publish(ctx, &ms[i])
+		if err != nil {
+			return published, err
+		}
+		if ok {
+			published++
+		}
+	}
+	return published, nil
+}
+
+// publish 发布单个事件并更新状态, 事件已被其他实例处理时跳过
+func (o *Outbox) publish(ctx context.Context, m *OutboxModel) (bool, error) {
+	now := time.Now()
+	claim := o.db.WithContext(ctx).Model(&OutboxModel{}).
+		Where("id = ? AND status = ? AND attempts = ?", m.ID, OutboxPending, m.Attempts).
+		Updates(map[string]any{
+			"attempts":        m.Attempts + 1,
+			"next_attempt_at": now.Add(outboxLease),
+		})
+	if claim.Error != nil {
+		return false, errors.WrapIf(claim.Error, "锁定待发布事件失败")
+	}
+	if claim.RowsAffected == 0 {
+		return false, nil
+	}
+	m.Attempts++
+
+	data := map[string]any{}
+	deliverErr := o.bus.Deliver(ctx, m.Event())
+	switch {
+	case deliverErr == nil:
+		data["status"] = OutboxPublished
+		data["published_at"] = time.Now()
+		data["last_error"] = ""
+	case m.Attempts >= o.maxAttempts:
+		data["status"] = OutboxFailed
+		data["last_error"] = truncate(deliverErr.Error(), 512)
+	default:
+		data["next_attempt_at"] = time.Now().Add(o.backoff(m.Attempts))
+		data["last_error"] = truncate(deliverErr.Error(), 512)
+	}
+	if deliverErr != nil {
+		ctxutil.Logger(ctx, o.log).Warn(
+			"发布事件失败",
+			zap.Error(deliverErr),
+			zap.Object("event", m),
+		)
+	}
+
+	if err := o.db.WithContext(ctx).Model(&OutboxModel{}).Where("id = ?", m.ID).Updates(data).Error; err != nil {
+		return false, errors.WrapIf(err, "更新发件箱事件状态失败")
+	}
+	return deliverErr == nil, nil
+}
+
+// backoff 第attempts次发布失败后的重试间隔
+func (o *Outbox) backoff(attempts int) time.Duration {
+	d := o.interval
+	for i := 1; i < attempts && d < outboxRetryMax; i++ {
+		d *= 2
+	}
+	return min(d, outboxRetryMax)
+}
+
+// Start 启动分发协程
+func (o *Outbox) Start() {
+	if o == nil {
+		return
+	}
+	o.stop = make(chan struct{})
+	o.done = make(chan struct{})
+	go func() {
+		defer close(o.done)
+		ticker := time.NewTicker(o.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-o.stop:
+				return
+			case <-ticker.C:
+			case <-o.wake:
+			}
+			if _, err := o.Dispatch(context.Background()); err != nil {
+				o.log.Error("发布发件箱事件失败", zap.Error(err))
+			}
+		}
+	}()
+}
+
+// Stop 停止分发协程, 等待正在发布的批次完成, 未发布的事件在下次启动后继续发布
+func (o *Outbox) Stop(ctx context.Context) {
+	if o == nil || o.stop == nil {
+		return
+	}
+	close(o.stop)
+	select {
+	case <-o.done:
+	case <-ctx.Done():
+		ctxutil.Logger(ctx, o.log).Warn("等待事件分发协程退出超时")
+	}
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n]
+}
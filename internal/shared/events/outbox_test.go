@@ -0,0 +1,150 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/test"
+)
+
+type outboxTestModel struct {
+	ID   uint32 `gorm:"primaryKey"`
+	Name string `gorm:"uniqueIndex"`
+}
+
+func newTestOutbox(t *testing.T, maxAttempts int) (*gorm.DB, *Bus, *Outbox) {
+	db := test.NewTestGormDBWithConfig(nil)
+	if err := db.AutoMigrate(&OutboxModel{}, &outboxTestModel{}); err != nil {
+		t.Fatal(err)
+	}
+	bus := NewBus(zap.NewNop())
+	return db, bus, NewOutbox(zap.NewNop(), db, bus, &config.EventsConfig{MaxAttempts: maxAttempts})
+}
+
+func listOutbox(t *testing.T, db *gorm.DB) []OutboxModel {
+	var ms []OutboxModel
+	if err := db.Order("id ASC").Find(&ms).Error; err != nil {
+		t.Fatal(err)
+	}
+	return ms
+}
+
+func TestOutboxStageCommitsWithWrite(t *testing.T) {
+	db, _, outbox := newTestOutbox(t, 3)
+	ctx := context.Background()
+
+	m := outboxTestModel{Name: "a"}
+	txCtx := outbox.Stage(ctx, func() Payload { return testPayload{ID: int(m.ID)} })
+	if err := database.DBCreate(txCtx, db, &outboxTestModel{}, &m, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// 写操作失败时事件随事务回滚
+	dup := outboxTestModel{Name: "a"}
+	txCtx = outbox.Stage(ctx, func() Payload { return testPayload{ID: 99} })
+	if err := database.DBCreate(txCtx, db, &outboxTestModel{}, &dup, nil); err == nil {
+		t.Fatal("expected unique constraint violation")
+	}
+
+	ms := listOutbox(t, db)
+	if len(ms) != 1 {
+		t.Fatalf("expected only the committed event in outbox, got %d", len(ms))
+	}
+	var p testPayload
+	if err := ms[0].Event().Decode(&p); err != nil || p.ID != int(m.ID) || p.ID == 0 {
+		t.Errorf("expected payload built after the write, got %s (%v)", ms[0].Payload, err)
+	}
+	if ms[0].Status != OutboxPending || ms[0].EventType != UserCreated {
+		t.Errorf("unexpected outbox row: %+v", ms[0])
+	}
+}
+
+func TestOutboxDispatch(t *testing.T) {
+	db, bus, outbox := newTestOutbox(t, 3)
+	ctx := context.Background()
+
+	var got []Event
+	bus.Subscribe(func(ctx context.Context, event Event) error {
+		got = append(got, event)
+		return nil
+	})
+	if err := outbox.Add(ctx, testPayload{ID: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	n, err := outbox.Dispatch(ctx)
+	if err != nil || n != 1 {
+		t.Fatalf("expected 1 event published, got %d (%v)", n, err)
+	}
+	if len(got) != 1 || got[0].Type != UserCreated {
+		t.Fatalf("expected event delivered to bus, got %+v", got)
+	}
+
+	ms := listOutbox(t, db)
+	if ms[0].Status != OutboxPublished || ms[0].PublishedAt == nil || ms[0].Attempts != 1 {
+		t.Errorf("expected published event, got %+v", ms[0])
+	}
+	if n, _ := outbox.Dispatch(ctx); n != 0 {
+		t.Errorf("published event should not be dispatched again, got %d", n)
+	}
+}
+
+func TestOutboxRetryThenFail(t *testing.T) {
+	db, bus, outbox := newTestOutbox(t, 2)
+	ctx := context.Background()
+
+	calls := 0
+	bus.Subscribe(func(ctx context.Context, event Event) error {
+		calls++
+		return errors.New("subscriber unavailable")
+	})
+	if err := outbox.Add(ctx, testPayload{ID: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := outbox.Dispatch(ctx); err != nil {
+		t.Fatal(err)
+	}
+	ms := listOutbox(t, db)
+	if ms[0].Status != OutboxPending || ms[0].Attempts != 1 || ms[0].LastError == "" {
+		t.Fatalf("expected event waiting for retry, got %+v", ms[0])
+	}
+	if !ms[0].NextAttemptAt.After(time.Now()) {
+		t.Errorf("expected next attempt to be delayed, got %s", ms[0].NextAttemptAt)
+	}
+
+	// 退避时间未到时不重试
+	if _, err := outbox.Dispatch(ctx); err != nil || calls != 1 {
+		t.Fatalf("expected no retry before backoff, calls=%d (%v)", calls, err)
+	}
+
+	db.Model(&OutboxModel{}).Where("id = ?", ms[0].ID).Update("next_attempt_at", time.Now().Add(-time.Second))
+	if _, err := outbox.Dispatch(ctx); err != nil {
+		t.Fatal(err)
+	}
+	ms = listOutbox(t, db)
+	if ms[0].Status != OutboxFailed || ms[0].Attempts != 2 || calls != 2 {
+		t.Errorf("expected event failed after max attempts, got %+v calls=%d", ms[0], calls)
+	}
+}
+
+func TestNilOutbox(t *testing.T) {
+	var outbox *Outbox
+	ctx := context.Background()
+	if got := outbox.Stage(ctx, func() Payload { return testPayload{} }); got != ctx {
+		t.Error("nil outbox should not attach hooks")
+	}
+	if err := outbox.Add(ctx, testPayload{}); err != nil {
+		t.Error(err)
+	}
+	outbox.Notify()
+	outbox.Start()
+	outbox.Stop(ctx)
+}
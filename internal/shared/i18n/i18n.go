@@ -0,0 +1,125 @@
+// Package i18n 提供接口响应的多语言支持
+//
+// 消息按键保存在各语言的消息目录中, 错误消息以错误原因作为键.
+// 请求使用的语言优先取用户在界面偏好中选择的语言, 其次按Accept-Language协商, 都没有时使用默认语言
+package i18n
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// 支持的语言
+const (
+	ZhCN = "zh-CN"
+	EnUS = "en-US"
+
+	// Default 默认语言
+	Default = ZhCN
+
+	// ContextKey gin上下文中保存请求语言的键, 值为语言或返回语言的函数
+	ContextKey = "lang"
+)
+
+// Supported 支持的语言列表
+var Supported = []string{ZhCN, EnUS}
+
+var (
+	mu       sync.RWMutex
+	catalogs = make(map[string]map[string]string)
+)
+
+// Register 将消息合并到语言的消息目录中, 已有的键会被覆盖
+func Register(lang string, messages map[string]string) {
+	mu.Lock()
+	defer mu.Unlock()
+	catalog, ok := catalogs[lang]
+	if !ok {
+		catalog = make(map[string]string, len(messages))
+		catalogs[lang] = catalog
+	}
+	for k, v := range messages {
+		catalog[k] = v
+	}
+}
+
+// Message 返回语言消息目录中键对应的消息
+func Message(lang, key string) (string, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	msg, ok := catalogs[lang][key]
+	return msg, ok
+}
+
+// Normalize 将语言标签转换为支持的语言, 不支持时返回空字符串
+//
+// 按主语言匹配, 例如zh、zh-Hans、zh_TW都视为zh-CN, en、en-GB都视为en-US
+func Normalize(tag string) string {
+	primary, _, _ := strings.Cut(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"), "-")
+	switch strings.ToLower(primary) {
+	case "zh":
+		return ZhCN
+	case "en":
+		return EnUS
+	}
+	return ""
+}
+
+// Negotiate 按Accept-Language请求头的权重选择支持的语言, 没有匹配的语言时返回空字符串
+func Negotiate(acceptLanguage string) string {
+	type candidate struct {
+		lang string
+		q    float64
+	}
+	var candidates []candidate
+	for part := range strings.SplitSeq(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = f
+		}
+		if lang := Normalize(tag); lang != "" && q > 0 {
+			candidates = append(candidates, candidate{lang: lang, q: q})
+		}
+	}
+	if len(candidates) == 0 {
+		return ""
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].q > candidates[j].q })
+	return candidates[0].lang
+}
+
+type langKey struct{}
+
+// WithLang 在上下文中附加请求使用的语言
+func WithLang(ctx context.Context, lang string) context.Context {
+	return context.WithValue(ctx, langKey{}, lang)
+}
+
+// Lang 返回上下文中请求使用的语言, 未设置时返回默认语言
+func Lang(ctx context.Context) string {
+	if ctx == nil {
+		return Default
+	}
+	if lang, ok := ctx.Value(langKey{}).(string); ok && lang != "" {
+		return lang
+	}
+	switch v := ctx.Value(ContextKey).(type) {
+	case string:
+		if v != "" {
+			return v
+		}
+	case func() string:
+		if lang := v(); lang != "" {
+			return lang
+		}
+	}
+	return Default
+}
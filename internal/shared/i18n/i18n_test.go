@@ -0,0 +1,104 @@
+package i18n
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+)
+
+func TestNormalize(t *testing.T) {
+	cases := map[string]string{
+		"zh":      ZhCN,
+		"zh-CN":   ZhCN,
+		"zh_TW":   ZhCN,
+		"zh-Hans": ZhCN,
+		"en":      EnUS,
+		"EN-gb":   EnUS,
+		"fr-FR":   "",
+		"":        "",
+	}
+	for tag, want := range cases {
+		if got := Normalize(tag); got != want {
+			t.Errorf("Normalize(%q) = %q, want %q", tag, got, want)
+		}
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	cases := map[string]string{
+		"en-US,en;q=0.9":            EnUS,
+		"fr-FR, zh;q=0.8, en;q=0.5": ZhCN,
+		"zh;q=0.3, en-GB;q=0.7":     EnUS,
+		"en;q=0, zh":                ZhCN,
+		"fr, de":                    "",
+		"":                          "",
+	}
+	for header, want := range cases {
+		if got := Negotiate(header); got != want {
+			t.Errorf("Negotiate(%q) = %q, want %q", header, got, want)
+		}
+	}
+}
+
+func TestLang(t *testing.T) {
+	if got := Lang(context.Background()); got != Default {
+		t.Errorf("expected default language, got %q", got)
+	}
+	if got := Lang(WithLang(context.Background(), EnUS)); got != EnUS {
+		t.Errorf("expected %q, got %q", EnUS, got)
+	}
+	ctx := context.WithValue(context.Background(), ContextKey, func() string { return EnUS })
+	if got := Lang(ctx); got != EnUS {
+		t.Errorf("expected %q from lazy value, got %q", EnUS, got)
+	}
+}
+
+func TestMessage(t *testing.T) {
+	Register("xx-TEST", map[string]string{"greeting": "hello"})
+	if msg, ok := Message("xx-TEST", "greeting"); !ok || msg != "hello" {
+		t.Errorf("expected registered message, got %q %v", msg, ok)
+	}
+	if _, ok := Message("xx-TEST", "missing"); ok {
+		t.Error("expected missing key not found")
+	}
+}
+
+func TestValidationMessages(t *testing.T) {
+	type item struct {
+		Name string `json:"name" validate:"required"`
+	}
+	type request struct {
+		Username string `json:"username" validate:"required"`
+		Age      int    `form:"age" validate:"gte=18"`
+		Items    []item `json:"items" validate:"dive"`
+	}
+	v := validator.New()
+	if err := RegisterValidator(v); err != nil {
+		t.Fatal(err)
+	}
+	err := v.Struct(request{Age: 10, Items: []item{{}}})
+
+	en := ValidationMessages(err, EnUS)
+	if len(en) != 3 {
+		t.Fatalf("expected 3 fields, got %v", en)
+	}
+	if en["username"] != "username is a required field" {
+		t.Errorf("unexpected english message %q", en["username"])
+	}
+	if _, ok := en["items[0].name"]; !ok {
+		t.Errorf("expected nested field name, got %v", en)
+	}
+	if _, ok := en["age"]; !ok {
+		t.Errorf("expected form tag as field name, got %v", en)
+	}
+
+	zh := ValidationMessages(err, ZhCN)
+	if zh["username"] != "username为必填字段" {
+		t.Errorf("unexpected chinese message %q", zh["username"])
+	}
+
+	if ValidationMessages(context.Canceled, EnUS) != nil {
+		t.Error("expected nil for non validation error")
+	}
+}
@@ -0,0 +1,88 @@
+package i18n
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/zh"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	entrans "github.com/go-playground/validator/v10/translations/en"
+	zhtrans "github.com/go-playground/validator/v10/translations/zh"
+)
+
+var (
+	validationMu    sync.RWMutex
+	validationTrans *ut.UniversalTranslator
+)
+
+// RegisterValidator 为校验器注册中英文的校验消息, 并使用请求中的字段名作为校验错误的字段名
+//
+// 字段名依次取json、form、uri标签, 都没有时使用结构体字段名
+func RegisterValidator(v *validator.Validate) error {
+	v.RegisterTagNameFunc(fieldName)
+
+	zhLocale := zh.New()
+	uni := ut.New(zhLocale, zhLocale, en.New())
+	zhT, _ := uni.GetTranslator("zh")
+	if err := zhtrans.RegisterDefaultTranslations(v, zhT); err != nil {
+		return err
+	}
+	enT, _ := uni.GetTranslator("en")
+	if err := entrans.RegisterDefaultTranslations(v, enT); err != nil {
+		return err
+	}
+
+	validationMu.Lock()
+	defer validationMu.Unlock()
+	validationTrans = uni
+	return nil
+}
+
+func fieldName(f reflect.StructField) string {
+	for _, tag := range []string{"json", "form", "uri"} {
+		name, _, _ := strings.Cut(f.Tag.Get(tag), ",")
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return f.Name
+}
+
+// ValidationMessages 将校验错误翻译为字段名到校验消息的映射, err不是校验错误或未注册校验器时返回nil
+//
+// 嵌套字段的字段名包含上级字段, 如items[0].name
+func ValidationMessages(err error, lang string) map[string]string {
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) {
+		return nil
+	}
+	validationMu.RLock()
+	uni := validationTrans
+	validationMu.RUnlock()
+	if uni == nil {
+		return nil
+	}
+
+	locale := "zh"
+	if lang == EnUS {
+		locale = "en"
+	}
+	trans, _ := uni.GetTranslator(locale)
+	messages := make(map[string]string, len(verrs))
+	for _, fe := range verrs {
+		// 去掉命名空间中的顶层结构体名
+		field := fe.Namespace()
+		if _, rest, ok := strings.Cut(field, "."); ok {
+			field = rest
+		}
+		messages[field] = fe.Translate(trans)
+	}
+	return messages
+}
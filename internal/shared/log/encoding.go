@@ -0,0 +1,215 @@
+package log
+
+import (
+	"fmt"
+	"strings"
+
+	"emperror.dev/errors"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"gin-artweb/internal/shared/config"
+)
+
+// ECSVersion 输出的Elastic Common Schema版本
+const ECSVersion = "8.11.0"
+
+// ecsFieldNames 字段名到ECS字段名的映射, 未列出的字段保持原名
+var ecsFieldNames = map[string]string{
+	"request_id":  "trace.id",
+	"user_id":     "user.id",
+	"username":    "user.name",
+	"client_ip":   "client.ip",
+	"method":      "http.request.method",
+	"status_code": "http.response.status_code",
+	"path":        "url.path",
+}
+
+// ECSFieldName 返回字段在ECS格式日志中的名称
+func ECSFieldName(key string) string {
+	if name, ok := ecsFieldNames[key]; ok {
+		return name
+	}
+	return key
+}
+
+func newEncoder(format string) zapcore.Encoder {
+	encoderConfig := zapcore.EncoderConfig{
+		TimeKey:        "time",
+		LevelKey:       "level",
+		NameKey:        "logger",
+		CallerKey:      "caller",
+		FunctionKey:    zapcore.OmitKey,
+		MessageKey:     "msg",
+		StacktraceKey:  "stacktrace",
+		LineEnding:     zapcore.DefaultLineEnding,
+		EncodeLevel:    zapcore.LowercaseLevelEncoder,
+		EncodeTime:     zapcore.ISO8601TimeEncoder,
+		EncodeDuration: zapcore.SecondsDurationEncoder,
+		EncodeCaller:   zapcore.ShortCallerEncoder,
+	}
+	switch format {
+	case config.LogFormatConsole:
+		return zapcore.NewConsoleEncoder(encoderConfig)
+	case config.LogFormatECS:
+		encoderConfig.TimeKey = "@timestamp"
+		encoderConfig.LevelKey = "log.level"
+		encoderConfig.NameKey = "log.logger"
+		encoderConfig.MessageKey = "message"
+		return zapcore.NewJSONEncoder(encoderConfig)
+	default:
+		return zapcore.NewJSONEncoder(encoderConfig)
+	}
+}
+
+// structuredCore 将错误和堆栈写成结构化字段, 避免JSON日志中出现多行字符串
+//
+// 错误写成包含message、type、causes和stack_trace的对象, 日志的堆栈拆分为逐帧的数组;
+// ECS格式下同时按ECS重命名字段, 调用位置写入log.origin
+type structuredCore struct {
+	zapcore.Core
+	ecs bool
+}
+
+func newStructuredCore(core zapcore.Core, format string) zapcore.Core {
+	switch format {
+	case config.LogFormatConsole:
+		return core
+	case config.LogFormatECS:
+		return (&structuredCore{Core: core, ecs: true}).With([]zapcore.Field{zap.String("ecs.version", ECSVersion)})
+	default:
+		return &structuredCore{Core: core}
+	}
+}
+
+func (c *structuredCore) With(fields []zapcore.Field) zapcore.Core {
+	return &structuredCore{Core: c.Core.With(c.fields(fields)), ecs: c.ecs}
+}
+
+func (c *structuredCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *structuredCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	fields = c.fields(fields)
+	if ent.Stack != "" {
+		key := "stacktrace"
+		if c.ecs {
+			key = "error.stack_trace"
+		}
+		fields = append(fields, zap.Array(key, stackFrames(ent.Stack)))
+		ent.Stack = ""
+	}
+	if c.ecs && ent.Caller.Defined {
+		fields = append(fields, zap.Object("log.origin", logOrigin(ent.Caller)))
+		ent.Caller = zapcore.EntryCaller{}
+	}
+	return c.Core.Write(ent, fields)
+}
+
+// fields 将错误字段转换为结构化对象, ECS格式下重命名字段
+func (c *structuredCore) fields(fields []zapcore.Field) []zapcore.Field {
+	out := make([]zapcore.Field, 0, len(fields))
+	for _, f := range fields {
+		if f.Type == zapcore.ErrorType {
+			if err, ok := f.Interface.(error); ok {
+				f = zap.Object(f.Key, errorObject{err: err})
+			}
+		}
+		if c.ecs {
+			f.Key = ECSFieldName(f.Key)
+		}
+		out = append(out, f)
+	}
+	return out
+}
+
+// errorObject 错误的结构化表示
+type errorObject struct {
+	err error
+}
+
+func (o errorObject) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("message", o.err.Error())
+	enc.AddString("type", fmt.Sprintf("%T", o.err))
+
+	// 逐层展开被包装的错误, 只记录与外层消息不同的原因
+	var causes []string
+	last := o.err.Error()
+	for cause := errors.Unwrap(o.err); cause != nil; cause = errors.Unwrap(cause) {
+		if msg := cause.Error(); msg != last && !strings.HasSuffix(last, ": "+msg) {
+			causes = append(causes, msg)
+		}
+		last = cause.Error()
+	}
+	if len(causes) > 0 {
+		if err := enc.AddArray("causes", stringArray(causes)); err != nil {
+			return err
+		}
+	}
+
+	// 使用最内层的调用栈, 即错误产生的位置
+	if st := innermostStackTrace(o.err); len(st) > 0 {
+		frames := make([]string, 0, len(st))
+		for _, f := range st {
+			frames = append(frames, fmt.Sprintf("%n (%s:%d)", f, f, f))
+		}
+		return enc.AddArray("stack_trace", stringArray(frames))
+	}
+	return nil
+}
+
+type stackTracer interface {
+	StackTrace() errors.StackTrace
+}
+
+func innermostStackTrace(err error) errors.StackTrace {
+	var st errors.StackTrace
+	for ; err != nil; err = errors.Unwrap(err) {
+		if tracer, ok := err.(stackTracer); ok {
+			st = tracer.StackTrace()
+		}
+	}
+	return st
+}
+
+type stringArray []string
+
+func (a stringArray) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	for _, s := range a {
+		enc.AppendString(s)
+	}
+	return nil
+}
+
+// stackFrames 将zap的多行堆栈按帧拆分, 每帧为"函数 (文件:行号)"
+func stackFrames(stack string) stringArray {
+	lines := strings.Split(strings.TrimSpace(stack), "\n")
+	frames := make(stringArray, 0, len(lines)/2+1)
+	for i := 0; i < len(lines); i++ {
+		fn := strings.TrimSpace(lines[i])
+		if i+1 < len(lines) && strings.HasPrefix(lines[i+1], "\t") {
+			fn += " (" + strings.TrimSpace(lines[i+1]) + ")"
+			i++
+		}
+		frames = append(frames, fn)
+	}
+	return frames
+}
+
+// logOrigin ECS的log.origin字段
+type logOrigin zapcore.EntryCaller
+
+func (o logOrigin) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if o.Function != "" {
+		enc.AddString("function", o.Function)
+	}
+	return enc.AddObject("file", zapcore.ObjectMarshalerFunc(func(enc zapcore.ObjectEncoder) error {
+		enc.AddString("name", zapcore.EntryCaller(o).TrimmedPath())
+		enc.AddInt("line", o.Line)
+		return nil
+	}))
+}
@@ -0,0 +1,124 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"emperror.dev/errors"
+	"go.uber.org/zap"
+
+	"gin-artweb/internal/shared/config"
+)
+
+func newTestLogger(t *testing.T, format string) (*zap.Logger, *bytes.Buffer) {
+	t.Helper()
+	buf := &bytes.Buffer{}
+	conf := &config.LogConfig{Level: "debug", Format: format}
+	logger, err := NewLevelLogger(conf, NewLevels(), LoggerBiz, buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return logger, buf
+}
+
+func decodeLine(t *testing.T, buf *bytes.Buffer) map[string]any {
+	t.Helper()
+	if n := strings.Count(strings.TrimSpace(buf.String()), "\n"); n != 0 {
+		t.Fatalf("expected one line, got %d extra lines: %s", n, buf.String())
+	}
+	m := map[string]any{}
+	if err := json.Unmarshal(buf.Bytes(), &m); err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestJSONErrorFields(t *testing.T) {
+	logger, buf := newTestLogger(t, config.LogFormatJSON)
+	err := errors.WrapIf(errors.New("connection refused"), "查询数据库失败")
+	logger.Error("查询失败", zap.Error(err), zap.String("request_id", "t-1"))
+
+	m := decodeLine(t, buf)
+	if _, ok := m["errorVerbose"]; ok {
+		t.Error("errorVerbose should not be written")
+	}
+	e, ok := m["error"].(map[string]any)
+	if !ok {
+		t.Fatalf("error should be an object, got %v", m["error"])
+	}
+	if e["message"] != "查询数据库失败: connection refused" {
+		t.Errorf("message = %v", e["message"])
+	}
+	if causes, _ := e["causes"].([]any); len(causes) != 0 {
+		t.Errorf("causes contained in message should be skipped, got %v", causes)
+	}
+	frames, _ := e["stack_trace"].([]any)
+	if len(frames) == 0 || !strings.Contains(frames[0].(string), "encoding_test.go") {
+		t.Errorf("stack_trace = %v, want frames starting at the test", frames)
+	}
+	if m["request_id"] != "t-1" || m["msg"] != "查询失败" || m["logger"] != LoggerBiz {
+		t.Errorf("unexpected fields %v", m)
+	}
+}
+
+func TestJSONStacktrace(t *testing.T) {
+	logger, buf := newTestLogger(t, config.LogFormatJSON)
+	func() {
+		defer func() { _ = recover() }()
+		logger.DPanic("异常")
+	}()
+
+	m := decodeLine(t, buf)
+	frames, ok := m["stacktrace"].([]any)
+	if !ok || len(frames) == 0 {
+		t.Fatalf("stacktrace should be an array, got %v", m["stacktrace"])
+	}
+	for _, f := range frames {
+		if strings.Contains(f.(string), "\n") {
+			t.Errorf("frame should be a single line: %q", f)
+		}
+	}
+}
+
+func TestECSFields(t *testing.T) {
+	logger, buf := newTestLogger(t, config.LogFormatECS)
+	logger.With(zap.String("request_id", "t-1")).Warn(
+		"登录失败",
+		zap.Uint32("user_id", 7),
+		zap.Error(errors.New("密码错误")),
+	)
+
+	m := decodeLine(t, buf)
+	for key, want := range map[string]any{
+		"message":     "登录失败",
+		"log.level":   "warn",
+		"log.logger":  LoggerBiz,
+		"trace.id":    "t-1",
+		"user.id":     float64(7),
+		"ecs.version": ECSVersion,
+	} {
+		if m[key] != want {
+			t.Errorf("%s = %v, want %v", key, m[key], want)
+		}
+	}
+	if _, ok := m["@timestamp"]; !ok {
+		t.Error("@timestamp missing")
+	}
+	origin, ok := m["log.origin"].(map[string]any)
+	if !ok {
+		t.Fatalf("log.origin should be an object, got %v", m["log.origin"])
+	}
+	if file, _ := origin["file"].(map[string]any); file == nil || !strings.Contains(file["name"].(string), "encoding_test.go") {
+		t.Errorf("log.origin = %v", origin)
+	}
+	if e, _ := m["error"].(map[string]any); e == nil || e["message"] != "密码错误" {
+		t.Errorf("error = %v", m["error"])
+	}
+	for _, key := range []string{"time", "msg", "caller", "request_id", "user_id"} {
+		if _, ok := m[key]; ok {
+			t.Errorf("%s should be renamed", key)
+		}
+	}
+}
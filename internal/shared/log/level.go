@@ -0,0 +1,114 @@
+package log
+
+import (
+	"maps"
+	"slices"
+	"sync"
+
+	"emperror.dev/errors"
+	"go.uber.org/zap"
+)
+
+// 日志记录器名称
+const (
+	LoggerServer  = "server"
+	LoggerService = "service"
+	LoggerBiz     = "biz"
+	LoggerData    = "data"
+	LoggerCron    = "cron"
+)
+
+// ErrLoggerNotFound 调整级别的日志记录器不存在
+var ErrLoggerNotFound = errors.Sentinel("日志记录器不存在")
+
+// Levels 按名称管理日志记录器的级别, 运行时调整后立即生效
+type Levels struct {
+	mu       sync.RWMutex
+	levels   map[string]zap.AtomicLevel
+	defaults map[string]string
+}
+
+func NewLevels() *Levels {
+	return &Levels{
+		levels:   make(map[string]zap.AtomicLevel),
+		defaults: make(map[string]string),
+	}
+}
+
+// Register 注册日志记录器并返回其级别, 同名的日志记录器共用同一个级别
+func (l *Levels) Register(name, level string) (zap.AtomicLevel, error) {
+	atomicLevel, err := zap.ParseAtomicLevel(level)
+	if err != nil {
+		return atomicLevel, errors.WrapIfWithDetails(err, "解析日志级别失败", "logger", name, "level", level)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if existing, ok := l.levels[name]; ok {
+		return existing, nil
+	}
+	l.levels[name] = atomicLevel
+	l.defaults[name] = atomicLevel.String()
+	return atomicLevel, nil
+}
+
+// Set 调整日志记录器的级别, name为空时调整全部日志记录器
+func (l *Levels) Set(name, level string) error {
+	parsed, err := zap.ParseAtomicLevel(level)
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "解析日志级别失败", "level", level)
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if name == "" {
+		for _, atomicLevel := range l.levels {
+			atomicLevel.SetLevel(parsed.Level())
+		}
+		return nil
+	}
+	atomicLevel, ok := l.levels[name]
+	if !ok {
+		return errors.WithDetails(ErrLoggerNotFound, "logger", name)
+	}
+	atomicLevel.SetLevel(parsed.Level())
+	return nil
+}
+
+// Reset 恢复日志记录器启动时的级别, name为空时恢复全部日志记录器
+func (l *Levels) Reset(name string) error {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if name != "" {
+		if _, ok := l.levels[name]; !ok {
+			return errors.WithDetails(ErrLoggerNotFound, "logger", name)
+		}
+	}
+	for n, atomicLevel := range l.levels {
+		if name != "" && n != name {
+			continue
+		}
+		if err := atomicLevel.UnmarshalText([]byte(l.defaults[n])); err != nil {
+			return errors.WrapIf(err, "恢复日志级别失败")
+		}
+	}
+	return nil
+}
+
+// Names 返回已注册的日志记录器名称, 按名称排序
+func (l *Levels) Names() []string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return slices.Sorted(maps.Keys(l.levels))
+}
+
+// Get 返回日志记录器的当前级别和启动时的级别
+func (l *Levels) Get(name string) (level, defaultLevel string, ok bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	atomicLevel, ok := l.levels[name]
+	if !ok {
+		return "", "", false
+	}
+	return atomicLevel.String(), l.defaults[name], true
+}
@@ -2,10 +2,13 @@ package log
 
 import (
 	"io"
+	"time"
 
 	"emperror.dev/errors"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+
+	"gin-artweb/internal/shared/config"
 )
 
 const (
@@ -19,26 +22,33 @@ func NewZapLogger(level string, w io.Writer) (*zap.Logger, error) {
 	if err := atomicLevel.UnmarshalText([]byte(level)); err != nil {
 		return nil, errors.WrapWithDetails(err, "解析日志级别失败", "level", level)
 	}
+	return newZapLogger(atomicLevel, w, config.LogFormatJSON, nil), nil
+}
 
-	// 编码器配置
-	encoderConfig := zapcore.EncoderConfig{
-		TimeKey:        "time",
-		LevelKey:       "level",
-		NameKey:        "logger",
-		CallerKey:      "caller",
-		FunctionKey:    zapcore.OmitKey,
-		MessageKey:     "msg",
-		StacktraceKey:  "stacktrace",
-		LineEnding:     zapcore.DefaultLineEnding,
-		EncodeLevel:    zapcore.LowercaseLevelEncoder,
-		EncodeTime:     zapcore.ISO8601TimeEncoder,
-		EncodeDuration: zapcore.SecondsDurationEncoder,
-		EncodeCaller:   zapcore.ShortCallerEncoder,
+// NewLevelLogger 按日志配置初始化名为name的日志, 级别注册到levels以便运行时调整
+//
+// 配置中按名称覆盖的级别优先于全局级别, 配置了采样时按采样参数丢弃重复的日志,
+// 日志格式由conf.Format指定, 默认json
+func NewLevelLogger(conf *config.LogConfig, levels *Levels, name string, w io.Writer) (*zap.Logger, error) {
+	level := conf.Level
+	if l, ok := conf.Levels[name]; ok && l != "" {
+		level = l
+	}
+	atomicLevel, err := levels.Register(name, level)
+	if err != nil {
+		return nil, err
 	}
+	var sampling *config.LogSamplingConfig
+	if sc, ok := conf.Sampling[name]; ok {
+		sampling = &sc
+	}
+	return newZapLogger(atomicLevel, w, conf.Format, sampling).Named(name), nil
+}
 
+func newZapLogger(atomicLevel zap.AtomicLevel, w io.Writer, format string, sampling *config.LogSamplingConfig) *zap.Logger {
 	// 创建核心
-	core := zapcore.NewCore(
-		zapcore.NewJSONEncoder(encoderConfig),
+	core := newStructuredCore(zapcore.NewCore(
+		newEncoder(format),
 		zapcore.AddSync(w),
 		// zapcore.NewMultiWriteSyncer(
 		// 	zapcore.AddSync(os.Stdout),
@@ -46,10 +56,16 @@ func NewZapLogger(level string, w io.Writer) (*zap.Logger, error) {
 		// 	zapcore.AddSync(w),
 		// ),
 		atomicLevel,
-	)
+	), format)
+
+	// 相同级别和内容的日志超出采样参数后丢弃
+	if sampling != nil && (sampling.Initial > 0 || sampling.Thereafter > 0) {
+		tick := time.Duration(max(sampling.Tick, 1)) * time.Second
+		core = zapcore.NewSamplerWithOptions(core, tick, sampling.Initial, sampling.Thereafter)
+	}
 
 	// 添加调用者信息和堆栈跟踪
-	return zap.New(core, zap.AddCaller(), zap.AddStacktrace(zap.DPanicLevel)), nil
+	return zap.New(core, zap.AddCaller(), zap.AddStacktrace(zap.DPanicLevel))
 }
 
 func NewZapLoggerMust(level string, w io.Writer) *zap.Logger {
@@ -65,4 +81,5 @@ type Loggers struct {
 	Service *zap.Logger
 	Biz     *zap.Logger
 	Data    *zap.Logger
+	Levels  *Levels // 运行时调整日志级别, 为nil时不支持调整
 }
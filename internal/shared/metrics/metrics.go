@@ -0,0 +1,237 @@
+// Package metrics 定义平台暴露给Prometheus的指标
+//
+// HTTP指标使用路由模式作为标签, 避免路径参数导致标签数量膨胀
+package metrics
+
+import (
+	"strconv"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"gin-artweb/pkg/breaker"
+)
+
+const namespace = "artweb"
+
+var (
+	// HTTPRequestsTotal 接口请求次数
+	HTTPRequestsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "接口请求次数",
+		},
+		[]string{"route", "method", "status"},
+	)
+
+	// HTTPRequestDuration 接口请求耗时
+	HTTPRequestDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "接口请求耗时(秒)",
+			Buckets:   prometheus.DefBuckets,
+		},
+		[]string{"route", "method", "status"},
+	)
+
+	// HTTPRequestsInFlight 正在处理的接口请求数
+	HTTPRequestsInFlight = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "http_requests_in_flight",
+			Help:      "正在处理的接口请求数",
+		},
+		[]string{"route", "method"},
+	)
+
+	// AuthzDeniedTotal 被拒绝的接口访问次数
+	AuthzDeniedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "authz_denied_total",
+			Help:      "被拒绝的接口访问次数",
+		},
+		[]string{"role", "method", "route", "reason"},
+	)
+
+	// LoginTotal 用户登录次数
+	LoginTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "login_total",
+			Help:      "用户登录次数",
+		},
+		[]string{"result"},
+	)
+
+	// JobRunsTotal 脚本执行次数
+	JobRunsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "job_runs_total",
+			Help:      "脚本执行次数",
+		},
+		[]string{"trigger", "status"},
+	)
+
+	// JobRunDuration 脚本执行耗时
+	JobRunDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "job_run_duration_seconds",
+			Help:      "脚本执行耗时(秒)",
+			Buckets:   []float64{1, 5, 15, 30, 60, 300, 900, 1800, 3600},
+		},
+		[]string{"trigger", "status"},
+	)
+
+	// AlertsTotal 告警次数, result为fired表示已发出, muted表示被维护窗口屏蔽
+	AlertsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "alerts_total",
+			Help:      "告警次数",
+		},
+		[]string{"kind", "state", "result"},
+	)
+
+	// RetentionPurgedRowsTotal 数据保留策略清理的行数
+	RetentionPurgedRowsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "retention_purged_rows_total",
+			Help:      "数据保留策略清理的行数",
+		},
+		[]string{"table"},
+	)
+
+	// RetentionRunsTotal 数据保留策略清理次数
+	RetentionRunsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "retention_runs_total",
+			Help:      "数据保留策略清理次数",
+		},
+		[]string{"table", "result"},
+	)
+
+	// DatabaseUp 数据库连接探测结果
+	DatabaseUp = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "database_up",
+			Help:      "数据库连接探测结果(1:正常, 0:不可用)",
+		},
+	)
+
+	// DBQueryTimeoutsTotal 数据库操作超时次数
+	DBQueryTimeoutsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "db_query_timeouts_total",
+			Help:      "数据库操作超时次数",
+		},
+		[]string{"op"},
+	)
+
+	// CircuitBreakerTransitionsTotal 熔断器状态变化次数
+	CircuitBreakerTransitionsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "circuit_breaker_transitions_total",
+			Help:      "熔断器状态变化次数",
+		},
+		[]string{"kind", "name", "state"},
+	)
+
+	// CircuitBreakerRejectedTotal 熔断器拒绝的下游调用次数
+	CircuitBreakerRejectedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "circuit_breaker_rejected_total",
+			Help:      "熔断器拒绝的下游调用次数",
+		},
+		[]string{"kind", "name"},
+	)
+)
+
+// 登录结果
+const (
+	LoginSuccess = "success"
+	LoginFailure = "failure"
+)
+
+// 告警结果
+const (
+	AlertFired = "fired"
+	AlertMuted = "muted"
+)
+
+// 数据保留策略清理结果
+const (
+	RetentionSuccess = "success"
+	RetentionFailure = "failure"
+)
+
+// 熔断的下游类型
+const (
+	BreakerDatabase   = "database"
+	BreakerSSH        = "ssh"
+	BreakerPrometheus = "prometheus"
+)
+
+var circuitBreakerStateDesc = prometheus.NewDesc(
+	prometheus.BuildFQName(namespace, "", "circuit_breaker_state"),
+	"熔断器状态(0:关闭, 1:半开, 2:打开)",
+	[]string{"kind", "name"},
+	nil,
+)
+
+// breakerCollector 在采集时读取熔断器的当前状态, 打开状态超时转为半开不需要等待下一次调用
+type breakerCollector struct {
+	mu     sync.Mutex
+	groups map[string]*breaker.Group
+}
+
+var breakers = &breakerCollector{groups: make(map[string]*breaker.Group)}
+
+func init() {
+	prometheus.MustRegister(breakers)
+}
+
+func (c *breakerCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- circuitBreakerStateDesc
+}
+
+func (c *breakerCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for kind, g := range c.groups {
+		for name, state := range g.States() {
+			ch <- prometheus.MustNewConstMetric(
+				circuitBreakerStateDesc, prometheus.GaugeValue, float64(state), kind, name,
+			)
+		}
+	}
+}
+
+// RegisterBreakerGroup 暴露熔断器分组的状态, 同一类型重复注册时替换
+func RegisterBreakerGroup(kind string, g *breaker.Group) {
+	if g == nil {
+		return
+	}
+	breakers.mu.Lock()
+	defer breakers.mu.Unlock()
+	breakers.groups[kind] = g
+}
+
+// StatusClass 将HTTP状态码归类为1xx-5xx
+func StatusClass(code int) string {
+	if code < 100 || code > 599 {
+		return "unknown"
+	}
+	return strconv.Itoa(code/100) + "xx"
+}
@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"crypto/subtle"
+
+	"github.com/gin-gonic/gin"
+
+	"gin-artweb/internal/shared/errors"
+)
+
+// AgentTokenHeader 主机代理携带令牌的请求头
+const AgentTokenHeader = "X-Agent-Token"
+
+// AgentTokenMiddleware 主机代理令牌认证中间件
+// 代理接口不使用用户令牌, 所有代理共用同一个令牌, 令牌为空时拒绝全部请求
+func AgentTokenMiddleware(token string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		header := ctx.GetHeader(AgentTokenHeader)
+		if token == "" || subtle.ConstantTimeCompare([]byte(header), []byte(token)) != 1 {
+			errors.RespondWithError(ctx, errors.ErrAgentTokenInvalid)
+			return
+		}
+		ctx.Next()
+	}
+}
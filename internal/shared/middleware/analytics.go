@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"gin-artweb/internal/shared/auth"
+	"gin-artweb/internal/shared/ctxutil"
+)
+
+// UsageRecorder 功能使用事件记录器
+type UsageRecorder interface {
+	RecordUsage(ctx context.Context, feature, method string, claims *auth.UserClaims, statusCode int)
+}
+
+// AnalyticsMiddleware 平台使用统计中间件
+// 以路由模板作为功能标识，仅记录已认证的请求，用户身份的记录方式由记录器决定
+func AnalyticsMiddleware(recorder UsageRecorder) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Next()
+
+		feature := ctx.FullPath()
+		if feature == "" {
+			return
+		}
+		claims, ucErr := ctxutil.GetUserClaims(ctx)
+		if ucErr != nil {
+			return
+		}
+		recorder.RecordUsage(ctx, feature, ctx.Request.Method, claims, ctx.Writer.Status())
+	}
+}
@@ -1,6 +1,8 @@
 package middleware
 
 import (
+	"sync"
+
 	"github.com/casbin/casbin/v2"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
@@ -13,8 +15,8 @@ import (
 // extractToken 从不同位置提取 token
 func extractToken(c *gin.Context) string {
 	// 检查是否为 WebSocket 升级请求
-	if c.GetHeader("Connection") == "upgrade" &&
-		c.GetHeader("Upgrade") == "websocket" {
+	// 浏览器发送的Connection头部通常为"Upgrade"或"keep-alive, Upgrade", 需要忽略大小写匹配
+	if isWebSocketRequest(c) {
 		// WebSocket 请求优先从查询参数获取，其次从头部获取
 		if token := c.Query("Authorization"); token != "" {
 			return token
@@ -29,66 +31,128 @@ func extractToken(c *gin.Context) string {
 	return c.GetHeader("Authorization")
 }
 
-func JWTAuthMiddleware(c *auth.JWTConfig, logger *zap.Logger) gin.HandlerFunc {
-	return func(ctx *gin.Context) {
-		// 从请求头获取token
-		token := extractToken(ctx)
-		if token == "" {
-			errors.RespondWithError(ctx, errors.ErrUnauthorized)
-			return
-		}
+// authenticate 解析访问令牌并写入上下文, 未通过时写入错误响应并返回false
+func authenticate(ctx *gin.Context, c *auth.JWTConfig, logger *zap.Logger) (*auth.UserClaims, bool) {
+	// 从请求头获取token, 开启Cookie认证时请求头中没有令牌再从Cookie获取
+	token := extractToken(ctx)
+	if token == "" && c.Cookie != nil {
+		token = auth.CookieValue(ctx.Request, auth.AccessCookieName)
+	}
+	if token == "" {
+		recordAuthzDenied(ctx, "", authzReasonUnauthenticated)
+		errors.RespondWithError(ctx, errors.ErrUnauthorized)
+		return nil, false
+	}
 
-		// 身份认证
-		claims, pErr := auth.ParseAccessToken(ctx, c, token)
-		if pErr != nil {
-			logger.Error(
-				"身份认证失败",
-				zap.Error(pErr),
-			)
-			errors.RespondWithError(ctx, pErr)
-			return
-		}
+	// 身份认证
+	claims, pErr := auth.ParseAccessToken(ctx, c, token)
+	if pErr != nil {
+		ctxutil.Logger(ctx, logger).Error(
+			"身份认证失败",
+			zap.Error(pErr),
+		)
+		recordAuthzDenied(ctx, "", authzReasonUnauthenticated)
+		errors.RespondWithError(ctx, pErr)
+		return nil, false
+	}
 
-		ctx.Set(ctxutil.UserClaimsKey, claims)
-		ctx.Next()
+	if claims.Impersonated() {
+		ctxutil.Logger(ctx, logger).Info(
+			"使用代登录令牌访问",
+			zap.String("impersonator", claims.ImpersonatorName),
+			zap.String("username", claims.Username),
+			zap.String("method", ctx.Request.Method),
+			zap.String("path", ctx.Request.URL.Path),
+		)
+		ctx.Header(ImpersonatedByHeader, claims.ImpersonatorName)
+	}
+
+	ctx.Set(ctxutil.UserClaimsKey, claims)
+	if !ctxutil.HasLogField(ctx, ctxutil.UserIDKey) {
+		ctx.Set(ctxutil.LoggerKey, ctxutil.AppendLogFields(ctx, zap.Uint32(ctxutil.UserIDKey, claims.UserID)))
 	}
+	return claims, true
 }
 
-func CasbinAuthMiddleware(enforcer *casbin.Enforcer, logger *zap.Logger) gin.HandlerFunc {
-	return func(ctx *gin.Context) {
-		claims, ucErr := ctxutil.GetUserClaims(ctx)
-		if ucErr != nil {
-			logger.Error(
-				"获取用户声明失败",
-				zap.Error(ucErr),
-			)
-			errors.RespondWithError(ctx, ucErr)
-			return
-		}
+// authorize 按用户直属角色及所属用户组的角色校验接口访问权限, 未通过时写入错误响应并返回false
+func authorize(
+	ctx *gin.Context,
+	enforcer *casbin.Enforcer,
+	logger *zap.Logger,
+	claims *auth.UserClaims,
+	obj string,
+) bool {
+	role := auth.RoleToSubject(claims.RoleID)
+
+	// 初始密码未修改前只能访问不经过权限校验的个人接口
+	if claims.MustChangePassword {
+		ctxutil.Logger(ctx, logger).Warn(
+			"用户需要先修改初始密码",
+			zap.String("username", claims.Username),
+			zap.String(auth.ObjKey, obj),
+		)
+		recordAuthzDenied(ctx, role, authzReasonPasswordChange)
+		errors.RespondWithError(ctx, errors.ErrPasswordChangeRequired)
+		return false
+	}
 
-		role := auth.RoleToSubject(claims.RoleID)
-		fullPath := ctx.FullPath()
+	// 访问鉴权
+	hasPerm, err := auth.EnforceUser(enforcer, claims.UserID, claims.RoleID, obj, ctx.Request.Method)
+	if err != nil {
+		ctxutil.Logger(ctx, logger).Error(
+			"权限校验失败",
+			zap.Error(err),
+			zap.String(auth.SubKey, role),
+			zap.String(auth.ObjKey, obj),
+			zap.String(auth.ActKey, ctx.Request.Method),
+		)
+		errors.RespondWithError(ctx, errors.FromError(err))
+		return false
+	}
+	if !hasPerm {
+		ctxutil.Logger(ctx, logger).Error(
+			"权限被拒绝",
+			zap.String(auth.SubKey, role),
+			zap.String(auth.ObjKey, obj),
+			zap.String(auth.ActKey, ctx.Request.Method),
+		)
+		recordAuthzDenied(ctx, role, authzReasonForbidden)
+		errors.RespondWithError(ctx, errors.ErrForbidden)
+		return false
+	}
 
-		// 访问鉴权
-		hasPerm, err := enforcer.Enforce(role, fullPath, ctx.Request.Method)
+	// 查询接口按此权限决定是否掩码敏感字段, 只在接口读取时校验且每个请求只校验一次, 校验出错时按无权限处理
+	ctx.Set(ctxutil.SensitiveKey, sync.OnceValue(func() bool {
+		sensitive, err := auth.EnforceSensitive(enforcer, claims.UserID, claims.RoleID)
 		if err != nil {
-			logger.Error(
-				"权限校验失败",
+			ctxutil.Logger(ctx, logger).Error(
+				"敏感字段权限校验失败",
 				zap.Error(err),
 				zap.String(auth.SubKey, role),
-				zap.String(auth.ObjKey, fullPath),
-				zap.String(auth.ActKey, ctx.Request.Method),
 			)
-			errors.RespondWithError(ctx, errors.FromError(err))
+			return false
+		}
+		return sensitive
+	}))
+	return true
+}
+
+// StaffOnlyMiddleware 只允许工作人员访问, 必须在身份认证之后注册
+func StaffOnlyMiddleware(logger *zap.Logger) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		claims, ucErr := ctxutil.GetUserClaims(ctx)
+		if ucErr != nil {
+			errors.RespondWithError(ctx, ucErr)
 			return
 		}
-		if !hasPerm {
-			logger.Error(
-				"权限被拒绝",
-				zap.String(auth.SubKey, role),
-				zap.String(auth.ObjKey, fullPath),
+		if !claims.IsStaff {
+			ctxutil.Logger(ctx, logger).Warn(
+				"非工作人员访问仅限工作人员的接口",
+				zap.String("username", claims.Username),
+				zap.String(auth.ObjKey, ctx.FullPath()),
 				zap.String(auth.ActKey, ctx.Request.Method),
 			)
+			recordAuthzDenied(ctx, auth.RoleToSubject(claims.RoleID), authzReasonStaffOnly)
 			errors.RespondWithError(ctx, errors.ErrForbidden)
 			return
 		}
@@ -0,0 +1,107 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/casbin/casbin/v2"
+	"github.com/casbin/casbin/v2/util"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"gin-artweb/internal/shared/auth"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/metrics"
+)
+
+// 接口访问被拒绝的原因
+const (
+	authzReasonUnauthenticated = "unauthenticated" // 未登录或令牌无效
+	authzReasonForbidden       = "forbidden"       // 没有访问权限
+	authzReasonPasswordChange  = "password_change" // 需要先修改初始密码
+	authzReasonCSRF            = "csrf"            // CSRF令牌缺失或不匹配
+	authzReasonStaffOnly       = "staff_only"      // 仅限工作人员访问
+)
+
+// recordAuthzDenied 记录被拒绝的访问, 使用路由模式作为标签避免路径参数导致标签数量膨胀
+func recordAuthzDenied(ctx *gin.Context, role, reason string) {
+	metrics.AuthzDeniedTotal.WithLabelValues(role, ctx.Request.Method, ctx.FullPath(), reason).Inc()
+}
+
+// publicRoute 无需鉴权的接口
+type publicRoute struct {
+	method  string // 为空时匹配全部请求方法
+	pattern string // keyMatch2模式
+}
+
+func parsePublicRoutes(routes []string) []publicRoute {
+	prs := make([]publicRoute, 0, len(routes))
+	for _, r := range routes {
+		r = strings.TrimSpace(r)
+		if r == "" {
+			continue
+		}
+		if method, pattern, ok := strings.Cut(r, " "); ok {
+			prs = append(prs, publicRoute{method: strings.ToUpper(method), pattern: strings.TrimSpace(pattern)})
+			continue
+		}
+		prs = append(prs, publicRoute{pattern: r})
+	}
+	return prs
+}
+
+func (pr publicRoute) match(method, path string) bool {
+	if pr.method != "" && pr.method != method {
+		return false
+	}
+	return util.KeyMatch2(path, pr.pattern)
+}
+
+// RouteAuthMiddleware 对路由组下的全部接口统一进行身份认证和Casbin鉴权
+//
+// 公开接口直接放行, 只需登录的接口认证后放行, 其余接口根据JWT中的角色校验权限。conf.MatchPath为true时使用实际请求路径作为
+// Casbin对象, 由模型中的keyMatch2匹配策略中的路由模式, 否则直接使用路由模式匹配.
+// 注册在路由组上后, 模块的路由和后续中间件通过ctxutil.GetUserClaims读取登录信息, 不需要再次认证
+func RouteAuthMiddleware(
+	enforcer *casbin.Enforcer,
+	jwtConf *auth.JWTConfig,
+	logger *zap.Logger,
+	conf config.AuthzConfig,
+) gin.HandlerFunc {
+	publicRoutes := parsePublicRoutes(conf.PublicRoutes)
+	loginRoutes := parsePublicRoutes(conf.LoginRoutes)
+	return func(ctx *gin.Context) {
+		// 未匹配到路由时交由gin返回404
+		if ctx.FullPath() == "" {
+			ctx.Next()
+			return
+		}
+
+		path := ctx.Request.URL.Path
+		for _, pr := range publicRoutes {
+			if pr.match(ctx.Request.Method, path) {
+				ctx.Next()
+				return
+			}
+		}
+
+		claims, ok := authenticate(ctx, jwtConf, logger)
+		if !ok {
+			return
+		}
+		for _, lr := range loginRoutes {
+			if lr.match(ctx.Request.Method, path) {
+				ctx.Next()
+				return
+			}
+		}
+
+		obj := ctx.FullPath()
+		if conf.MatchPath {
+			obj = path
+		}
+		if !authorize(ctx, enforcer, logger, claims, obj) {
+			return
+		}
+		ctx.Next()
+	}
+}
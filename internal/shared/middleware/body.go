@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"mime"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"gin-artweb/internal/shared/errors"
+)
+
+// isUploadRequest 判断是否为上传请求, 即multipart表单或二进制流
+func isUploadRequest(c *gin.Context) bool {
+	mediaType, _, _ := mime.ParseMediaType(c.GetHeader("Content-Type"))
+	return mediaType == "multipart/form-data" || mediaType == "application/octet-stream"
+}
+
+// BodyLimitMiddleware 限制请求体大小
+//
+// 上传请求使用uploadLimit, 其他请求使用defaultLimit; Content-Length超出限制时直接返回413,
+// 否则限制读取的字节数, 读取超出限制时由handler返回的错误统一转换为413
+func BodyLimitMiddleware(defaultLimit, uploadLimit int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Body == nil || c.Request.Body == http.NoBody {
+			c.Next()
+			return
+		}
+
+		limit := defaultLimit
+		if isUploadRequest(c) {
+			limit = uploadLimit
+		}
+		if c.Request.ContentLength > limit {
+			errors.RespondWithError(c, errors.ErrRequestBodyTooLarge.WithFields(map[string]any{
+				"body_size": c.Request.ContentLength,
+				"max_size":  limit,
+			}))
+			return
+		}
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit)
+		c.Next()
+	}
+}
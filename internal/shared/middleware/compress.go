@@ -0,0 +1,320 @@
+package middleware
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/klauspost/compress/flate"
+	"github.com/klauspost/compress/gzip"
+	"github.com/klauspost/compress/zstd"
+)
+
+// 支持的响应压缩算法
+const (
+	EncodingZstd    = "zstd"
+	EncodingGzip    = "gzip"
+	EncodingDeflate = "deflate"
+)
+
+const defaultCompressMinSize = 1024
+
+// DefaultCompressTypes 默认压缩的响应类型, 以/*结尾时匹配该大类下的全部子类型
+//
+// 不包含text/event-stream, 服务端推送的事件需要立即送达, 不经过压缩缓冲
+var DefaultCompressTypes = []string{
+	"application/json",
+	"application/javascript",
+	"application/xml",
+	"image/svg+xml",
+	"text/plain",
+	"text/html",
+	"text/css",
+	"text/csv",
+	"text/javascript",
+	"text/xml",
+}
+
+// CompressOptions 响应压缩选项
+type CompressOptions struct {
+	Encodings []string // 按优先顺序排列的压缩算法, 为空时为gzip、deflate
+	MinSize   int      // 响应体小于该大小(字节)时不压缩, 小于等于0时为1024
+	Level     int      // 压缩级别1-9, 小于等于0时使用各算法的默认级别
+	Types     []string // 压缩的响应类型, 为空时为DefaultCompressTypes
+	Exclude   []string // 不压缩的路径前缀, 如文件下载和流式接口
+}
+
+// compressEncoder 可以复用的压缩写入器
+type compressEncoder interface {
+	io.WriteCloser
+	Flush() error
+	Reset(w io.Writer)
+}
+
+// zstdEncoder 适配zstd.Encoder的Reset签名
+type zstdEncoder struct{ *zstd.Encoder }
+
+func (e zstdEncoder) Reset(w io.Writer) { e.Encoder.Reset(w) }
+
+// compressor 按算法缓存压缩写入器
+type compressor struct {
+	opts  CompressOptions
+	pools map[string]*sync.Pool
+}
+
+func newCompressor(opts CompressOptions) *compressor {
+	c := &compressor{opts: opts, pools: make(map[string]*sync.Pool)}
+	for _, enc := range opts.Encodings {
+		var newFn func() any
+		switch enc {
+		case EncodingGzip:
+			level := gzip.DefaultCompression
+			if opts.Level > 0 {
+				level = opts.Level
+			}
+			newFn = func() any {
+				w, _ := gzip.NewWriterLevel(io.Discard, level)
+				return w
+			}
+		case EncodingDeflate:
+			level := flate.DefaultCompression
+			if opts.Level > 0 {
+				level = opts.Level
+			}
+			newFn = func() any {
+				w, _ := flate.NewWriter(io.Discard, level)
+				return w
+			}
+		case EncodingZstd:
+			level := zstd.SpeedDefault
+			if opts.Level > 0 {
+				level = zstd.EncoderLevelFromZstd(opts.Level)
+			}
+			newFn = func() any {
+				// 单线程压缩, 避免每个写入器各自启动后台goroutine
+				w, _ := zstd.NewWriter(nil, zstd.WithEncoderLevel(level), zstd.WithEncoderConcurrency(1))
+				return zstdEncoder{w}
+			}
+		default:
+			continue
+		}
+		c.pools[enc] = &sync.Pool{New: newFn}
+	}
+	return c
+}
+
+func (c *compressor) get(encoding string, w io.Writer) compressEncoder {
+	enc := c.pools[encoding].Get().(compressEncoder)
+	enc.Reset(w)
+	return enc
+}
+
+func (c *compressor) put(encoding string, enc compressEncoder) {
+	c.pools[encoding].Put(enc)
+}
+
+// negotiate 按配置的优先顺序选择客户端接受的压缩算法, 没有可用的算法时返回空字符串
+func (c *compressor) negotiate(acceptEncoding string) string {
+	if acceptEncoding == "" {
+		return ""
+	}
+	accepted := make(map[string]float64)
+	for part := range strings.SplitSeq(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				q = f
+			}
+		}
+		accepted[strings.ToLower(strings.TrimSpace(name))] = q
+	}
+	for _, enc := range c.opts.Encodings {
+		if _, ok := c.pools[enc]; !ok {
+			continue
+		}
+		q, ok := accepted[enc]
+		if !ok {
+			q, ok = accepted["*"]
+		}
+		if ok && q > 0 {
+			return enc
+		}
+	}
+	return ""
+}
+
+// compressible 判断响应类型是否在允许压缩的列表中
+func (c *compressor) compressible(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	for _, t := range c.opts.Types {
+		if t == mediaType {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(t, "/*"); ok && strings.HasPrefix(mediaType, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// compressWriter 缓冲响应体直到达到最小压缩大小, 再决定是否压缩
+type compressWriter struct {
+	gin.ResponseWriter
+	c           *compressor
+	encoding    string
+	buf         []byte
+	decided     bool
+	wroteHeader bool
+	enc         compressEncoder
+}
+
+func (w *compressWriter) Write(data []byte) (int, error) {
+	if !w.decided {
+		w.buf = append(w.buf, data...)
+		if len(w.buf) >= w.c.opts.MinSize {
+			if err := w.decide(); err != nil {
+				return 0, err
+			}
+		}
+		return len(data), nil
+	}
+	if w.enc != nil {
+		return w.enc.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *compressWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *compressWriter) WriteHeaderNow() {
+	w.wroteHeader = true
+	if w.decided {
+		w.ResponseWriter.WriteHeaderNow()
+	}
+}
+
+func (w *compressWriter) Written() bool {
+	return w.wroteHeader || len(w.buf) > 0 || w.ResponseWriter.Written()
+}
+
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		_ = w.decide()
+	}
+	if w.enc != nil {
+		_ = w.enc.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// shouldCompress 根据状态码、响应头和已缓冲的响应体判断是否压缩
+func (w *compressWriter) shouldCompress(buf []byte) bool {
+	status := w.Status()
+	if status < http.StatusOK || status == http.StatusNoContent ||
+		status == http.StatusPartialContent || status == http.StatusNotModified {
+		return false
+	}
+	h := w.Header()
+	if h.Get("Content-Encoding") != "" || len(buf) < w.c.opts.MinSize {
+		return false
+	}
+	contentType := h.Get("Content-Type")
+	if contentType == "" {
+		// 压缩后无法再按内容推断类型, 在压缩前推断并设置
+		contentType = http.DetectContentType(buf)
+		h.Set("Content-Type", contentType)
+	}
+	return w.c.compressible(contentType)
+}
+
+// decide 决定是否压缩并写出已缓冲的响应体
+func (w *compressWriter) decide() error {
+	w.decided = true
+	buf := w.buf
+	w.buf = nil
+	if w.shouldCompress(buf) {
+		h := w.Header()
+		h.Set("Content-Encoding", w.encoding)
+		h.Add("Vary", "Accept-Encoding")
+		h.Del("Content-Length")
+		h.Del("Accept-Ranges")
+		if etag := h.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+			// 压缩后的内容与原内容不同, 强ETag改为弱ETag
+			h.Set("ETag", "W/"+etag)
+		}
+		w.ResponseWriter.WriteHeaderNow()
+		w.enc = w.c.get(w.encoding, w.ResponseWriter)
+		_, err := w.enc.Write(buf)
+		return err
+	}
+	if len(buf) > 0 {
+		_, err := w.ResponseWriter.Write(buf)
+		return err
+	}
+	if w.wroteHeader {
+		w.ResponseWriter.WriteHeaderNow()
+	}
+	return nil
+}
+
+// close 写出剩余的响应体并归还压缩写入器
+func (w *compressWriter) close() {
+	if !w.decided {
+		_ = w.decide()
+	}
+	if w.enc != nil {
+		_ = w.enc.Close()
+		w.enc.Reset(io.Discard)
+		w.c.put(w.encoding, w.enc)
+		w.enc = nil
+	}
+}
+
+// CompressMiddleware 响应压缩中间件
+//
+// 按Accept-Encoding和配置的优先顺序选择压缩算法, 只压缩允许列表中的响应类型且不小于最小大小的响应;
+// HEAD请求、WebSocket升级请求和排除的路径不压缩, 已设置Content-Encoding的响应原样返回.
+// 流式响应调用Flush时随之刷新压缩数据
+func CompressMiddleware(opts CompressOptions) gin.HandlerFunc {
+	if len(opts.Encodings) == 0 {
+		opts.Encodings = []string{EncodingGzip, EncodingDeflate}
+	}
+	if opts.MinSize <= 0 {
+		opts.MinSize = defaultCompressMinSize
+	}
+	if len(opts.Types) == 0 {
+		opts.Types = DefaultCompressTypes
+	}
+	c := newCompressor(opts)
+
+	return func(ctx *gin.Context) {
+		if ctx.Request.Method == http.MethodHead || isWebSocketRequest(ctx) ||
+			matchAnyPrefix(ctx.Request.URL.Path, opts.Exclude) {
+			ctx.Next()
+			return
+		}
+		encoding := c.negotiate(ctx.GetHeader("Accept-Encoding"))
+		if encoding == "" {
+			ctx.Next()
+			return
+		}
+
+		w := &compressWriter{ResponseWriter: ctx.Writer, c: c, encoding: encoding}
+		ctx.Writer = w
+		defer func() {
+			w.close()
+			ctx.Writer = w.ResponseWriter
+		}()
+		ctx.Next()
+	}
+}
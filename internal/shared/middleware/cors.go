@@ -17,7 +17,7 @@ func CorsMiddleware(cfg *config.AllowConfig) gin.HandlerFunc {
 			AllowOrigins:     []string{"*"},
 			AllowCredentials: false,
 			AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-			AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization"},
+			AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", "X-Request-Deadline"},
 		}
 	}
 
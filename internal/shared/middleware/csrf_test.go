@@ -0,0 +1,89 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"gin-artweb/internal/shared/auth"
+	"gin-artweb/internal/shared/config"
+)
+
+func TestCSRFMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cookieAuth := auth.NewCookieAuth(config.CookieConfig{Enable: true}, time.Minute, time.Hour)
+	r := gin.New()
+	r.Use(CSRFMiddleware(cookieAuth, []string{"POST /api/v1/login", "/api/v1/agent/*"}))
+	ok := func(ctx *gin.Context) { ctx.Status(http.StatusOK) }
+	r.GET("/api/v1/user", ok)
+	r.HEAD("/api/v1/user", ok)
+	r.OPTIONS("/api/v1/user", ok)
+	r.POST("/api/v1/user", ok)
+	r.DELETE("/api/v1/user", ok)
+	r.POST("/api/v1/login", ok)
+	r.PUT("/api/v1/login", ok)
+	r.POST("/api/v1/agent/heartbeat", ok)
+
+	tests := []struct {
+		name          string
+		method        string
+		path          string
+		authorization string
+		accessCookie  bool
+		csrfCookie    string
+		csrfHeader    string
+		want          int
+	}{
+		{name: "GET不校验", method: http.MethodGet, path: "/api/v1/user", accessCookie: true, want: http.StatusOK},
+		{name: "HEAD不校验", method: http.MethodHead, path: "/api/v1/user", accessCookie: true, want: http.StatusOK},
+		{name: "OPTIONS不校验", method: http.MethodOptions, path: "/api/v1/user", accessCookie: true, want: http.StatusOK},
+		{name: "没有令牌Cookie不校验", method: http.MethodPost, path: "/api/v1/user", want: http.StatusOK},
+		{
+			name: "Authorization请求头认证不校验", method: http.MethodPost, path: "/api/v1/user",
+			authorization: "token", accessCookie: true, want: http.StatusOK,
+		},
+		{name: "缺少CSRF令牌", method: http.MethodPost, path: "/api/v1/user", accessCookie: true, want: http.StatusForbidden},
+		{
+			name: "缺少CSRF请求头", method: http.MethodDelete, path: "/api/v1/user",
+			accessCookie: true, csrfCookie: "csrf", want: http.StatusForbidden,
+		},
+		{
+			name: "CSRF令牌不一致", method: http.MethodPost, path: "/api/v1/user",
+			accessCookie: true, csrfCookie: "csrf", csrfHeader: "other", want: http.StatusForbidden,
+		},
+		{
+			name: "CSRF令牌一致", method: http.MethodPost, path: "/api/v1/user",
+			accessCookie: true, csrfCookie: "csrf", csrfHeader: "csrf", want: http.StatusOK,
+		},
+		{name: "豁免的接口", method: http.MethodPost, path: "/api/v1/login", accessCookie: true, want: http.StatusOK},
+		{name: "豁免接口按请求方法匹配", method: http.MethodPut, path: "/api/v1/login", accessCookie: true, want: http.StatusForbidden},
+		{name: "豁免的通配接口", method: http.MethodPost, path: "/api/v1/agent/heartbeat", accessCookie: true, want: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			if tt.accessCookie {
+				req.AddCookie(&http.Cookie{Name: auth.AccessCookieName, Value: "access"})
+			}
+			if tt.csrfCookie != "" {
+				req.AddCookie(&http.Cookie{Name: auth.CSRFCookieName, Value: tt.csrfCookie})
+			}
+			if tt.csrfHeader != "" {
+				req.Header.Set(cookieAuth.CSRFHeader(), tt.csrfHeader)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			assert.Equal(t, tt.want, w.Code)
+			if tt.want == http.StatusForbidden {
+				assert.Contains(t, w.Body.String(), "CSRF_TOKEN_INVALID")
+			}
+		})
+	}
+}
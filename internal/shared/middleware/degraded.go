@@ -0,0 +1,128 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/patrickmn/go-cache"
+
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/errors"
+)
+
+// DegradedHeader 降级状态下返回缓存响应时设置的响应头
+const DegradedHeader = "X-Degraded"
+
+// degradedMaxBody 缓存的单个响应体上限, 超出时不缓存
+const degradedMaxBody = 1 << 20
+
+// DegradedChecker 判断数据库是否处于不可用的降级状态
+type DegradedChecker interface {
+	Degraded() bool
+}
+
+// degradedResponse 缓存的查询响应
+type degradedResponse struct {
+	status      int
+	contentType string
+	body        []byte
+}
+
+// degradedWriter 在写出响应的同时复制响应体
+type degradedWriter struct {
+	gin.ResponseWriter
+	buf      bytes.Buffer
+	overflow bool
+}
+
+func (w *degradedWriter) Write(data []byte) (int, error) {
+	w.copy(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *degradedWriter) WriteString(s string) (int, error) {
+	w.copy([]byte(s))
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *degradedWriter) copy(data []byte) {
+	if w.overflow {
+		return
+	}
+	if w.buf.Len()+len(data) > degradedMaxBody {
+		w.overflow = true
+		w.buf.Reset()
+		return
+	}
+	w.buf.Write(data)
+}
+
+// DegradedMiddleware 数据库降级中间件
+//
+// 正常状态下缓存routes(路径前缀)中GET接口的成功响应; 降级状态下这些接口返回缓存的响应并设置X-Degraded响应头,
+// 没有缓存时继续处理请求, 写操作直接返回数据库不可用. 缓存按用户隔离, 需要注册在认证中间件之后
+func DegradedMiddleware(checker DegradedChecker, c *cache.Cache, routes []string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		method := ctx.Request.Method
+		readOnly := method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+		degraded := checker.Degraded()
+		if degraded && !readOnly {
+			errors.RespondWithError(ctx, errors.ErrDatabaseUnavailable)
+			return
+		}
+		if method != http.MethodGet || !matchAnyPrefix(ctx.Request.URL.Path, routes) {
+			ctx.Next()
+			return
+		}
+
+		key := degradedCacheKey(ctx)
+		if degraded {
+			if v, ok := c.Get(key); ok {
+				resp := v.(*degradedResponse)
+				ctx.Header(DegradedHeader, "true")
+				ctx.Data(resp.status, resp.contentType, resp.body)
+				ctx.Abort()
+				return
+			}
+			ctx.Next()
+			return
+		}
+
+		w := &degradedWriter{ResponseWriter: ctx.Writer}
+		ctx.Writer = w
+		ctx.Next()
+		ctx.Writer = w.ResponseWriter
+		if w.Status() == http.StatusOK && !w.overflow && !ctx.IsAborted() {
+			c.SetDefault(key, &degradedResponse{
+				status:      w.Status(),
+				contentType: w.Header().Get("Content-Type"),
+				body:        bytes.Clone(w.buf.Bytes()),
+			})
+		}
+	}
+}
+
+// degradedCacheKey 按用户和请求URI区分缓存, 未认证的请求按Authorization请求头区分
+func degradedCacheKey(ctx *gin.Context) string {
+	identity := ""
+	if claims, rErr := ctxutil.GetUserClaims(ctx); rErr == nil {
+		identity = strconv.FormatUint(uint64(claims.UserID), 10) + ":" + strconv.FormatUint(uint64(claims.RoleID), 10)
+	} else if auth := ctx.GetHeader("Authorization"); auth != "" {
+		sum := sha256.Sum256([]byte(auth))
+		identity = hex.EncodeToString(sum[:])
+	}
+	return identity + "|" + ctx.Request.URL.RequestURI()
+}
+
+func matchAnyPrefix(path string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if matchPathPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 
+	"gin-artweb/internal/shared/ctxutil"
 	"gin-artweb/internal/shared/errors"
 )
 
@@ -42,7 +43,7 @@ func ErrorMiddleware(logger *zap.Logger) gin.HandlerFunc {
 					"user_agent", c.Request.UserAgent(),
 				)
 
-				logger.Error("panic recovered",
+				ctxutil.Logger(c, logger).Error("panic recovered",
 					zap.Error(err),
 					zap.Any("panic", r),
 					zap.String("stack", string(stack)),
@@ -0,0 +1,179 @@
+package middleware
+
+import (
+	"bytes"
+	"cmp"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// defaultETagCacheControl 默认要求客户端每次使用前向服务器确认, 响应包含用户数据, 不允许共享缓存
+	defaultETagCacheControl = "private, no-cache"
+	// defaultETagMaxBody 计算ETag的响应体上限, 超出时直接写出响应
+	defaultETagMaxBody = 1 << 20
+)
+
+// ETagRoute 路径前缀的缓存策略
+type ETagRoute struct {
+	Prefix       string // 路径前缀
+	CacheControl string // Cache-Control响应头, 为空时为private, no-cache
+}
+
+// etagWriter 缓冲响应体, 计算ETag后再写出; 响应体超出上限或需要刷新时改为直接写出
+type etagWriter struct {
+	gin.ResponseWriter
+	buf         bytes.Buffer
+	max         int
+	wroteHeader bool
+	passthrough bool
+}
+
+func (w *etagWriter) Write(data []byte) (int, error) {
+	if !w.passthrough && w.buf.Len()+len(data) <= w.max {
+		return w.buf.Write(data)
+	}
+	if err := w.bypass(); err != nil {
+		return 0, err
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *etagWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *etagWriter) WriteHeaderNow() {
+	w.wroteHeader = true
+	if w.passthrough {
+		w.ResponseWriter.WriteHeaderNow()
+	}
+}
+
+func (w *etagWriter) Written() bool {
+	return w.wroteHeader || w.buf.Len() > 0 || w.ResponseWriter.Written()
+}
+
+func (w *etagWriter) Flush() {
+	_ = w.bypass()
+	w.ResponseWriter.Flush()
+}
+
+// bypass 写出已缓冲的内容, 之后的写入直接写出
+func (w *etagWriter) bypass() error {
+	if w.passthrough {
+		return nil
+	}
+	w.passthrough = true
+	if w.buf.Len() > 0 {
+		_, err := w.ResponseWriter.Write(w.buf.Bytes())
+		w.buf.Reset()
+		return err
+	}
+	if w.wroteHeader {
+		w.ResponseWriter.WriteHeaderNow()
+	}
+	return nil
+}
+
+// ETagMiddleware 条件请求中间件
+//
+// routes(路径前缀)中GET接口的成功响应按响应体计算弱ETag并设置Cache-Control,
+// 请求的If-None-Match匹配时返回304且不返回响应体; 处理器设置了Last-Modified时同样支持If-Modified-Since.
+// 需要缓冲完整的响应体, 只应用于列表和详情等普通JSON接口, maxBody小于等于0时为1MB
+func ETagMiddleware(routes []ETagRoute, maxBody int) gin.HandlerFunc {
+	if maxBody <= 0 {
+		maxBody = defaultETagMaxBody
+	}
+	return func(ctx *gin.Context) {
+		method := ctx.Request.Method
+		if method != http.MethodGet && method != http.MethodHead {
+			ctx.Next()
+			return
+		}
+		route, ok := matchETagRoute(ctx.Request.URL.Path, routes)
+		if !ok || isWebSocketRequest(ctx) {
+			ctx.Next()
+			return
+		}
+
+		w := &etagWriter{ResponseWriter: ctx.Writer, max: maxBody}
+		ctx.Writer = w
+		ctx.Next()
+		ctx.Writer = w.ResponseWriter
+		if w.passthrough {
+			return
+		}
+
+		if w.Status() == http.StatusOK {
+			h := w.Header()
+			h.Set("ETag", computeETag(w.buf.Bytes()))
+			h.Set("Cache-Control", cmp.Or(route.CacheControl, defaultETagCacheControl))
+			h.Add("Vary", "Authorization")
+			if notModified(ctx.Request, h) {
+				h.Del("Content-Type")
+				h.Del("Content-Length")
+				w.ResponseWriter.WriteHeader(http.StatusNotModified)
+				w.ResponseWriter.WriteHeaderNow()
+				return
+			}
+		}
+		if w.buf.Len() > 0 {
+			_, _ = w.ResponseWriter.Write(w.buf.Bytes())
+		} else if w.wroteHeader {
+			w.ResponseWriter.WriteHeaderNow()
+		}
+	}
+}
+
+// matchETagRoute 返回匹配的最长路径前缀的缓存策略
+func matchETagRoute(path string, routes []ETagRoute) (ETagRoute, bool) {
+	var (
+		matched ETagRoute
+		found   bool
+	)
+	for _, r := range routes {
+		if matchPathPrefix(path, r.Prefix) && (!found || len(r.Prefix) > len(matched.Prefix)) {
+			matched, found = r, true
+		}
+	}
+	return matched, found
+}
+
+// computeETag 按响应体计算弱ETag, 响应可能被压缩, 使用弱ETag表示语义相同
+func computeETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `W/"` + base64.RawURLEncoding.EncodeToString(sum[:16]) + `"`
+}
+
+// notModified 判断条件请求是否命中, 有If-None-Match时忽略If-Modified-Since
+func notModified(r *http.Request, h http.Header) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		etag := strings.TrimPrefix(h.Get("ETag"), "W/")
+		for _, candidate := range strings.Split(inm, ",") {
+			candidate = strings.TrimSpace(candidate)
+			if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+				return true
+			}
+		}
+		return false
+	}
+	ims := r.Header.Get("If-Modified-Since")
+	lm := h.Get("Last-Modified")
+	if ims == "" || lm == "" {
+		return false
+	}
+	since, err := http.ParseTime(ims)
+	if err != nil {
+		return false
+	}
+	modified, err := http.ParseTime(lm)
+	if err != nil {
+		return false
+	}
+	return !modified.After(since)
+}
@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"gin-artweb/internal/shared/errors"
+)
+
+// FeatureChecker 功能开关判断器
+type FeatureChecker interface {
+	FeatureEnabled(ctx context.Context, name string) bool
+}
+
+// FeatureFlagMiddleware 功能开关中间件
+// 功能未对当前用户开放时按接口不存在处理, 需要注册在认证中间件之后才能按用户判断
+func FeatureFlagMiddleware(checker FeatureChecker, name string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if !checker.FeatureEnabled(ctx, name) {
+			errors.RespondWithError(ctx, errors.ErrFeatureNotReleased.WithField("feature", name))
+			return
+		}
+		ctx.Next()
+	}
+}
@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"gin-artweb/internal/shared/auth"
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/errors"
+)
+
+// FreezeOverrideHeader 越过变更冻结的请求头, 值为变更理由, 非ASCII字符需要URL编码
+const FreezeOverrideHeader = "X-Freeze-Override"
+
+// freezeJustificationMaxLen 变更理由的最大长度
+const freezeJustificationMaxLen = 254
+
+// ChangeFreezeChecker 变更冻结检查器
+type ChangeFreezeChecker interface {
+	FrozenBy(ctx context.Context, path string) (name string, endAt time.Time, frozen bool)
+	RecordFreezeOverride(ctx context.Context, path string, claims *auth.UserClaims, method, route, justification string)
+}
+
+// ChangeFreezeMiddleware 变更冻结中间件
+//
+// 冻结期间拒绝配置的修改接口, 只读请求不受影响; 工作人员可以在请求头中填写变更理由越过冻结,
+// 越过冻结的请求记录审计。必须注册在RouteAuthMiddleware之后, 从上下文读取登录信息
+func ChangeFreezeMiddleware(
+	checker ChangeFreezeChecker,
+	logger *zap.Logger,
+	conf config.FreezeConfig,
+) gin.HandlerFunc {
+	routes := parsePublicRoutes(conf.Routes)
+	return func(ctx *gin.Context) {
+		method := ctx.Request.Method
+		if ctx.FullPath() == "" || method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions {
+			ctx.Next()
+			return
+		}
+		path := ctx.Request.URL.Path
+		matched := false
+		for _, r := range routes {
+			if r.match(method, path) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			ctx.Next()
+			return
+		}
+		name, endAt, frozen := checker.FrozenBy(ctx, path)
+		if !frozen {
+			ctx.Next()
+			return
+		}
+
+		fields := map[string]any{
+			"freeze": name,
+			"end_at": endAt.Format(time.DateTime),
+		}
+		justification := strings.TrimSpace(ctx.GetHeader(FreezeOverrideHeader))
+		if justification == "" {
+			errors.RespondWithError(ctx, errors.ErrChangeFrozen.WithFields(fields))
+			return
+		}
+		if unescaped, err := url.PathUnescape(justification); err == nil {
+			justification = unescaped
+		}
+		if utf8.RuneCountInString(justification) > freezeJustificationMaxLen {
+			errors.RespondWithError(ctx, errors.ErrValidationFailed.WithField("header", FreezeOverrideHeader))
+			return
+		}
+
+		claims, rErr := ctxutil.GetUserClaims(ctx)
+		if rErr != nil {
+			errors.RespondWithError(ctx, rErr)
+			return
+		}
+		if !claims.IsStaff {
+			ctxutil.Logger(ctx, logger).Warn(
+				"非工作人员尝试越过变更冻结",
+				zap.String("freeze", name),
+				zap.String("username", claims.Username),
+				zap.String("method", method),
+				zap.String("path", path),
+			)
+			errors.RespondWithError(ctx, errors.ErrFreezeOverrideDenied.WithFields(fields))
+			return
+		}
+		checker.RecordFreezeOverride(ctx, path, claims, method, ctx.FullPath(), justification)
+		ctx.Next()
+	}
+}
@@ -0,0 +1,111 @@
+package middleware
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"gin-artweb/internal/shared/config"
+	"gin-artweb/internal/shared/ctxutil"
+)
+
+// cspReportMaxSize CSP违规报告的最大长度, 超出部分不记录
+const cspReportMaxSize = 16 << 10
+
+// SecurityHeadersMiddleware 设置页面安全响应头
+//
+// HSTS只对HTTPS请求(包括反向代理通过X-Forwarded-Proto标识的请求)设置, 配置了上报地址时在CSP末尾追加report-uri
+func SecurityHeadersMiddleware(conf config.HeadersConfig) gin.HandlerFunc {
+	frameOptions := conf.FrameOptions
+	if frameOptions == "" {
+		frameOptions = "DENY"
+	}
+	referrerPolicy := conf.ReferrerPolicy
+	if referrerPolicy == "" {
+		referrerPolicy = "strict-origin-when-cross-origin"
+	}
+
+	hsts := ""
+	if conf.HSTSMaxAge > 0 {
+		hsts = "max-age=" + strconv.Itoa(conf.HSTSMaxAge)
+		if conf.HSTSIncludeSubdomains {
+			hsts += "; includeSubDomains"
+		}
+	}
+
+	csp := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(conf.CSP), ";"))
+	if csp != "" && conf.CSPReportURI != "" {
+		csp += "; report-uri " + conf.CSPReportURI
+	}
+	cspHeader := "Content-Security-Policy"
+	if conf.CSPReportOnly {
+		cspHeader = "Content-Security-Policy-Report-Only"
+	}
+
+	return func(c *gin.Context) {
+		h := c.Writer.Header()
+		h.Set("X-Content-Type-Options", "nosniff")
+		h.Set("X-Frame-Options", frameOptions)
+		h.Set("Referrer-Policy", referrerPolicy)
+		if hsts != "" && (c.Request.TLS != nil || strings.EqualFold(c.GetHeader("X-Forwarded-Proto"), "https")) {
+			h.Set("Strict-Transport-Security", hsts)
+		}
+		if csp != "" {
+			h.Set(cspHeader, csp)
+		}
+		c.Next()
+	}
+}
+
+// CSPReportHandler 接收浏览器上报的CSP违规报告并记录日志
+//
+// 兼容report-uri的{"csp-report": {...}}格式, 其他格式记录原始内容
+func CSPReportHandler(logger *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		body, err := io.ReadAll(io.LimitReader(c.Request.Body, cspReportMaxSize))
+		if err != nil {
+			c.Status(http.StatusBadRequest)
+			return
+		}
+
+		var report struct {
+			CSPReport struct {
+				DocumentURI        string `json:"document-uri"`
+				ViolatedDirective  string `json:"violated-directive"`
+				EffectiveDirective string `json:"effective-directive"`
+				BlockedURI         string `json:"blocked-uri"`
+				SourceFile         string `json:"source-file"`
+				LineNumber         int    `json:"line-number"`
+				Disposition        string `json:"disposition"`
+			} `json:"csp-report"`
+		}
+		if err := json.Unmarshal(body, &report); err == nil && report.CSPReport.ViolatedDirective != "" {
+			r := report.CSPReport
+			ctxutil.Logger(c, logger).Warn(
+				"CSP违规",
+				zap.String("document_uri", r.DocumentURI),
+				zap.String("violated_directive", r.ViolatedDirective),
+				zap.String("effective_directive", r.EffectiveDirective),
+				zap.String("blocked_uri", r.BlockedURI),
+				zap.String("source_file", r.SourceFile),
+				zap.Int("line_number", r.LineNumber),
+				zap.String("disposition", r.Disposition),
+				zap.String("remote_ip", c.ClientIP()),
+				zap.String("user_agent", c.Request.UserAgent()),
+			)
+		} else {
+			ctxutil.Logger(c, logger).Warn(
+				"CSP违规",
+				zap.ByteString("report", body),
+				zap.String("remote_ip", c.ClientIP()),
+				zap.String("user_agent", c.Request.UserAgent()),
+			)
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
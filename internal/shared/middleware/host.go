@@ -4,6 +4,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 
+	"gin-artweb/internal/shared/ctxutil"
 	"gin-artweb/internal/shared/errors"
 )
 
@@ -17,7 +18,7 @@ func HostGuard(logger *zap.Logger, allowedHosts ...string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		host := c.Request.Host
 		if !allowed[host] {
-			logger.Warn(
+			ctxutil.Logger(c, logger).Warn(
 				"请求头不被允许",
 				zap.String("host", host),
 				zap.String("remote_ip", c.ClientIP()),
@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"context"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/i18n"
+)
+
+// LanguageMiddleware 请求语言中间件
+//
+// 请求语言优先取已登录用户在界面偏好中选择的语言(userLang), 其次按Accept-Language协商, 都没有时使用默认语言.
+// 用户信息在鉴权中间件中才写入上下文, 因此语言在第一次使用时才确定; userLang为nil时只按请求头协商
+func LanguageMiddleware(userLang func(ctx context.Context, userID uint32) string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Writer.Header().Add("Vary", "Accept-Language")
+		acceptLanguage := ctx.GetHeader("Accept-Language")
+		ctx.Set(i18n.ContextKey, sync.OnceValue(func() string {
+			if userLang != nil {
+				if claims, rErr := ctxutil.GetUserClaims(ctx); rErr == nil {
+					if lang := i18n.Normalize(userLang(ctx, claims.UserID)); lang != "" {
+						return lang
+					}
+				}
+			}
+			if lang := i18n.Negotiate(acceptLanguage); lang != "" {
+				return lang
+			}
+			return i18n.Default
+		}))
+		ctx.Next()
+	}
+}
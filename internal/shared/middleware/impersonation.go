@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"gin-artweb/internal/shared/auth"
+	"gin-artweb/internal/shared/ctxutil"
+)
+
+// ImpersonatedByHeader 使用代登录令牌的响应头, 值为代为登录的管理员用户名, 前端据此显示代登录提示
+const ImpersonatedByHeader = "X-Impersonated-By"
+
+// ImpersonationRecorder 代登录请求记录器
+type ImpersonationRecorder interface {
+	RecordImpersonatedRequest(ctx context.Context, claims *auth.UserClaims, method, route string, statusCode int)
+}
+
+// ImpersonationAuditMiddleware 代登录审计中间件
+// 记录使用代登录令牌的全部请求, 以路由模板作为操作类型, 操作用户为代为登录的管理员
+func ImpersonationAuditMiddleware(recorder ImpersonationRecorder) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Next()
+
+		route := ctx.FullPath()
+		if route == "" {
+			return
+		}
+		claims, ucErr := ctxutil.GetUserClaims(ctx)
+		if ucErr != nil || !claims.Impersonated() {
+			return
+		}
+		recorder.RecordImpersonatedRequest(ctx, claims, ctx.Request.Method, route, ctx.Writer.Status())
+	}
+}
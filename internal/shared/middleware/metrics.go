@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"gin-artweb/internal/shared/metrics"
+)
+
+// unmatchedRoute 未匹配到路由的请求使用的标签
+const unmatchedRoute = "unmatched"
+
+// MetricsMiddleware 按路由模式、请求方法和状态码类别记录请求次数、耗时和处理中的请求数
+func MetricsMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		route := ctx.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		method := ctx.Request.Method
+
+		inFlight := metrics.HTTPRequestsInFlight.WithLabelValues(route, method)
+		inFlight.Inc()
+		defer inFlight.Dec()
+
+		start := time.Now()
+		ctx.Next()
+
+		status := metrics.StatusClass(ctx.Writer.Status())
+		metrics.HTTPRequestsTotal.WithLabelValues(route, method, status).Inc()
+		metrics.HTTPRequestDuration.WithLabelValues(route, method, status).Observe(time.Since(start).Seconds())
+	}
+}
@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"gin-artweb/internal/shared/ctxutil"
+)
+
+// RequestLogMiddleware 在请求上下文中写入请求日志记录器
+//
+// 必须在TracingMiddleware之后注册, 附加trace_id、路由和请求URI, 认证通过后再追加用户ID.
+// loggers为各层使用的日志记录器, 在请求开始时构建一次附加字段后的记录器,
+// 服务通过ctxutil.Logger直接取得, 不需要在每次记录日志时重新构建
+func RequestLogMiddleware(loggers ...*zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		fields := []zap.Field{zap.String(ctxutil.TraceIDKey, ctxutil.GetTraceID(c))}
+		if route := c.FullPath(); route != "" {
+			fields = append(fields, zap.String(ctxutil.RouteKey, route))
+		}
+		fields = append(fields, zap.String(ctxutil.RequestURIKey, c.Request.RequestURI))
+		c.Set(ctxutil.LoggerKey, ctxutil.NewRequestLogger(fields, loggers...))
+		c.Next()
+	}
+}
@@ -2,10 +2,14 @@ package middleware
 
 import (
 	"context"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
+
+	"gin-artweb/internal/shared/deadline"
+	"gin-artweb/internal/shared/errors"
 )
 
 func isWebSocketRequest(c *gin.Context) bool {
@@ -18,16 +22,76 @@ func isWebSocketRequest(c *gin.Context) bool {
 		strings.Contains(strings.ToLower(connection), "upgrade")
 }
 
-func TimeoutMiddleware(timeout time.Duration) gin.HandlerFunc {
+// RouteTimeout 路径前缀的请求处理超时时间
+type RouteTimeout struct {
+	Prefix  string
+	Timeout time.Duration
+}
+
+// TimeoutMiddleware 为请求上下文设置超时时间
+//
+// routes按路径前缀覆盖默认超时时间, 多个前缀匹配时使用最长的前缀,
+// 子路由组中再次设置超时只能缩短而不能延长, 因此需要在此统一配置.
+// 客户端通过X-Request-Deadline请求头指定处理时限(毫秒)时使用其中较短的一个,
+// 超过客户端的时限且接口未返回响应时返回504
+func TimeoutMiddleware(timeout time.Duration, routes ...RouteTimeout) gin.HandlerFunc {
+	routes = append([]RouteTimeout(nil), routes...)
+	sort.SliceStable(routes, func(i, j int) bool {
+		return len(routes[i].Prefix) > len(routes[j].Prefix)
+	})
+
 	return func(c *gin.Context) {
-		if !isWebSocketRequest(c) {
-			// 创建带超时的 context
-			ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
-			defer cancel()
+		if isWebSocketRequest(c) {
+			c.Next()
+			return
+		}
+		serverTimeout := routeTimeout(c.Request.URL.Path, timeout, routes)
+		budget, err := deadline.ParseHeader(c.GetHeader(deadline.Header))
+		if err != nil {
+			errors.RespondWithError(c, errors.ErrRequestDeadlineInvalid.WithField("header", deadline.Header))
+			return
+		}
 
-			// 替换请求的 context
-			c.Request = c.Request.WithContext(ctx)
+		// 创建带超时的 context
+		var (
+			ctx    context.Context
+			cancel context.CancelFunc
+		)
+		if budget > 0 {
+			if serverTimeout > 0 {
+				budget = min(budget, serverTimeout)
+			}
+			ctx, cancel = deadline.WithBudget(c.Request.Context(), budget)
+		} else {
+			ctx, cancel = context.WithTimeout(c.Request.Context(), serverTimeout)
 		}
+		defer cancel()
+
+		// 替换请求的 context
+		c.Request = c.Request.WithContext(ctx)
 		c.Next()
+
+		if _, exceeded := deadline.FromContext(ctx).Exceeded(); exceeded && !c.Writer.Written() {
+			errors.RespondWithError(c, errors.ErrRequestDeadlineExceeded)
+		}
+	}
+}
+
+// routeTimeout 返回最长匹配前缀的超时时间, routes已按前缀长度降序排列
+func routeTimeout(path string, timeout time.Duration, routes []RouteTimeout) time.Duration {
+	for _, r := range routes {
+		if r.Timeout > 0 && matchPathPrefix(path, r.Prefix) {
+			return r.Timeout
+		}
+	}
+	return timeout
+}
+
+// matchPathPrefix 按路径段匹配前缀, /api/v1/jobs不匹配/api/v1/jobsx
+func matchPathPrefix(path, prefix string) bool {
+	prefix = strings.TrimRight(prefix, "/")
+	if !strings.HasPrefix(path, prefix) {
+		return false
 	}
+	return len(path) == len(prefix) || path[len(prefix)] == '/'
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/patrickmn/go-cache"
 	"go.uber.org/zap"
 
+	"gin-artweb/internal/shared/ctxutil"
 	"gin-artweb/internal/shared/errors"
 )
 
@@ -35,7 +36,7 @@ func TimestampMiddleware(nonceStore *cache.Cache, logger *zap.Logger, tolerance,
 		// 从请求头获取 X-Timestamp
 		timestampStr := c.GetHeader("X-Timestamp")
 		if timestampStr == "" {
-			logger.Error("请求缺少 X-Timestamp 头")
+			ctxutil.Logger(c, logger).Error("请求缺少 X-Timestamp 头")
 			errors.RespondWithError(c, errors.ErrTimestampNotFound)
 			return
 		}
@@ -43,7 +44,7 @@ func TimestampMiddleware(nonceStore *cache.Cache, logger *zap.Logger, tolerance,
 		// 从请求头获取 X-Nonce
 		nonce := c.GetHeader("X-Nonce")
 		if nonce == "" {
-			logger.Error("请求缺少 X-Nonce 头")
+			ctxutil.Logger(c, logger).Error("请求缺少 X-Nonce 头")
 			errors.RespondWithError(c, errors.ErrNonceNotFound)
 			return
 		}
@@ -51,7 +52,7 @@ func TimestampMiddleware(nonceStore *cache.Cache, logger *zap.Logger, tolerance,
 		// 解析时间戳
 		timestamp, err := strconv.ParseInt(timestampStr, 10, 64)
 		if err != nil {
-			logger.Error(
+			ctxutil.Logger(c, logger).Error(
 				"请求时间戳解释失败",
 				zap.String("timestamp", timestampStr),
 				zap.String("error", err.Error()),
@@ -65,7 +66,7 @@ func TimestampMiddleware(nonceStore *cache.Cache, logger *zap.Logger, tolerance,
 
 		// 检查时间戳是否过期
 		if abs(now-timestamp) > tolerance || timestamp > now+futureTolerance {
-			logger.Error(
+			ctxutil.Logger(c, logger).Error(
 				"时间戳超出允许范围",
 				zap.Int64("current", now),
 				zap.Int64("received", timestamp),
@@ -77,7 +78,7 @@ func TimestampMiddleware(nonceStore *cache.Cache, logger *zap.Logger, tolerance,
 		}
 
 		if _, exists := nonceStore.Get(nonce); exists {
-			logger.Error(
+			ctxutil.Logger(c, logger).Error(
 				"检测到重复的请求，可能存在重放攻击",
 				zap.String("nonce", nonce),
 				zap.Int64("timestamp", timestamp),
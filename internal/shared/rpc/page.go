@@ -0,0 +1,22 @@
+package rpc
+
+import "gin-artweb/internal/shared/errors"
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// Page 返回列表查询的分页页码和大小, 未传时为第1页每页20条, 每页最多100条
+func Page(page, size int32) (int, int, *errors.Error) {
+	if page == 0 {
+		page = 1
+	}
+	if size == 0 {
+		size = defaultPageSize
+	}
+	if page < 1 || size < 1 || size > maxPageSize {
+		return 0, 0, errors.ErrValidationFailed.WithFields(map[string]any{"page": page, "size": size})
+	}
+	return int(page), int(size), nil
+}
@@ -0,0 +1,247 @@
+package rpc
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"maps"
+	"net"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/casbin/casbin/v2"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+
+	"gin-artweb/internal/shared/auth"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/errors"
+)
+
+// AuthorizationKey 携带访问令牌的元数据, 与REST接口的Authorization请求头相同
+const AuthorizationKey = "authorization"
+
+// Server gRPC服务器
+//
+// 各模块通过Register注册服务实现, 并为每个方法指定对应的REST接口("METHOD /path"),
+// 拦截器校验访问令牌后按该接口的角色权限鉴权, 与REST接口共用同一套权限配置;
+// 权限依赖请求参数的方法指定为空, 由实现通过Authorize自行鉴权
+type Server struct {
+	log      *zap.Logger
+	jwtConf  *auth.JWTConfig
+	enforcer *casbin.Enforcer
+	server   *grpc.Server
+	apis     map[string]string
+}
+
+func NewServer(
+	logger *zap.Logger,
+	jwtConf *auth.JWTConfig,
+	enforcer *casbin.Enforcer,
+	opts ...grpc.ServerOption,
+) *Server {
+	s := &Server{
+		log:      logger,
+		jwtConf:  jwtConf,
+		enforcer: enforcer,
+		apis:     make(map[string]string),
+	}
+	opts = append(opts, grpc.ChainUnaryInterceptor(s.unaryInterceptor))
+	s.server = grpc.NewServer(opts...)
+	return s
+}
+
+// Register 注册服务实现, apis为方法名对应的REST接口, 缺少方法的接口时panic
+func (s *Server) Register(desc *grpc.ServiceDesc, impl any, apis map[string]string) {
+	for _, m := range desc.Methods {
+		api, ok := apis[m.MethodName]
+		if !ok {
+			panic(fmt.Sprintf("gRPC方法%s/%s未指定对应的REST接口", desc.ServiceName, m.MethodName))
+		}
+		s.apis["/"+desc.ServiceName+"/"+m.MethodName] = api
+	}
+	s.server.RegisterService(desc, impl)
+}
+
+// APIs 返回已注册方法对应的REST接口, key为方法全名
+func (s *Server) APIs() map[string]string {
+	return maps.Clone(s.apis)
+}
+
+// Serve 在ln上提供gRPC服务, 直到调用Shutdown
+func (s *Server) Serve(ln net.Listener) error {
+	return s.server.Serve(ln)
+}
+
+// Shutdown 等待执行中的请求完成后关闭服务器, ctx结束时强制关闭
+func (s *Server) Shutdown(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		s.server.Stop()
+		return ctx.Err()
+	}
+}
+
+// Authorize 按REST接口的角色权限校验当前用户, api格式为"METHOD /path"
+func (s *Server) Authorize(ctx context.Context, api string) *errors.Error {
+	claims, rErr := ctxutil.GetUserClaims(ctx)
+	if rErr != nil {
+		return rErr
+	}
+	method, obj, _ := strings.Cut(api, " ")
+	role := auth.RoleToSubject(claims.RoleID)
+	hasPerm, err := auth.EnforceUser(s.enforcer, claims.UserID, claims.RoleID, obj, method)
+	if err != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"权限校验失败",
+			zap.Error(err),
+			zap.String(auth.SubKey, role),
+			zap.String(auth.ObjKey, obj),
+			zap.String(auth.ActKey, method),
+		)
+		return errors.FromError(err)
+	}
+	if !hasPerm {
+		ctxutil.Logger(ctx, s.log).Error(
+			"权限被拒绝",
+			zap.String(auth.SubKey, role),
+			zap.String(auth.ObjKey, obj),
+			zap.String(auth.ActKey, method),
+		)
+		return errors.ErrForbidden
+	}
+	return nil
+}
+
+// unaryInterceptor 校验访问令牌和接口权限, 将服务层返回的错误转换为gRPC状态
+func (s *Server) unaryInterceptor(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (resp any, err error) {
+	ctx = ctxutil.SetTraceID(ctx, uuid.NewString())
+	ctx = ctxutil.WithLogFields(ctx, zap.String(ctxutil.RouteKey, info.FullMethod))
+	defer func() {
+		if r := recover(); r != nil {
+			ctxutil.Logger(ctx, s.log).Error(
+				"gRPC请求处理发生panic",
+				zap.Any("panic", r),
+				zap.Stack("stack"),
+			)
+			err = Status(errors.FromReason(errors.ReasonUnknown))
+		}
+	}()
+
+	// 未登记REST接口的方法拒绝访问
+	api, ok := s.apis[info.FullMethod]
+	if !ok {
+		return nil, Status(errors.ErrForbidden)
+	}
+
+	ctx, rErr := s.authenticate(ctx)
+	if rErr != nil {
+		return nil, Status(rErr)
+	}
+	if api != "" {
+		if rErr := s.Authorize(ctx, api); rErr != nil {
+			return nil, Status(rErr)
+		}
+	}
+
+	resp, err = handler(ctx, req)
+	if err != nil {
+		return nil, statusFromError(err)
+	}
+	return resp, nil
+}
+
+// authenticate 解析元数据中的访问令牌, 返回写入了用户信息和敏感字段权限的上下文
+func (s *Server) authenticate(ctx context.Context) (context.Context, *errors.Error) {
+	var token string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(AuthorizationKey); len(values) > 0 {
+			token = strings.TrimPrefix(values[0], "Bearer ")
+		}
+	}
+	if token == "" {
+		return ctx, errors.ErrUnauthorized
+	}
+
+	claims, rErr := auth.ParseAccessToken(ctx, s.jwtConf, token)
+	if rErr != nil {
+		ctxutil.Logger(ctx, s.log).Error(
+			"身份认证失败",
+			zap.Error(rErr),
+		)
+		return ctx, rErr
+	}
+	ctx = context.WithValue(ctx, ctxutil.UserClaimsKey, claims)
+	ctx = ctxutil.WithLogFields(ctx, zap.Uint32(ctxutil.UserIDKey, claims.UserID))
+
+	// 初始密码未修改前不能访问任何gRPC接口
+	if claims.MustChangePassword {
+		ctxutil.Logger(ctx, s.log).Warn(
+			"用户需要先修改初始密码",
+			zap.String("username", claims.Username),
+		)
+		return ctx, errors.ErrPasswordChangeRequired
+	}
+
+	// 与REST接口相同, 查询接口按此权限决定是否掩码敏感字段, 校验出错时按无权限处理
+	ctx = context.WithValue(ctx, ctxutil.SensitiveKey, sync.OnceValue(func() bool {
+		sensitive, err := auth.EnforceSensitive(s.enforcer, claims.UserID, claims.RoleID)
+		if err != nil {
+			ctxutil.Logger(ctx, s.log).Error(
+				"敏感字段权限校验失败",
+				zap.Error(err),
+				zap.String(auth.SubKey, auth.RoleToSubject(claims.RoleID)),
+			)
+			return false
+		}
+		return sensitive
+	}))
+	return ctx, nil
+}
+
+// TLSConfig 加载gRPC端口的证书, clientCAPath不为空时要求客户端提供该CA签发的证书
+func TLSConfig(crtPath, keyPath, clientCAPath string) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(crtPath, keyPath)
+	if err != nil {
+		return nil, err
+	}
+	conf := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if clientCAPath != "" {
+		pem, err := os.ReadFile(clientCAPath)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("客户端CA文件中没有有效的证书: %s", clientCAPath)
+		}
+		conf.ClientCAs = pool
+		conf.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return conf, nil
+}
+
+// Creds 返回使用conf的TLS传输凭证
+func Creds(conf *tls.Config) grpc.ServerOption {
+	return grpc.Creds(credentials.NewTLS(conf))
+}
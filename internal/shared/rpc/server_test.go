@@ -0,0 +1,197 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"gin-artweb/internal/shared/auth"
+	"gin-artweb/internal/shared/ctxutil"
+	"gin-artweb/internal/shared/errors"
+	"gin-artweb/pkg/rpc/artwebv1"
+)
+
+// testResourceServer 返回当前用户和敏感字段权限, ListPackages由实现自行鉴权
+type testResourceServer struct {
+	artwebv1.UnimplementedResourceServiceServer
+	server *Server
+}
+
+func (s *testResourceServer) ListHosts(ctx context.Context, req *artwebv1.ListHostsRequest) (*artwebv1.ListHostsResponse, error) {
+	if _, _, rErr := Page(req.GetPage(), req.GetSize()); rErr != nil {
+		return nil, rErr
+	}
+	claims, rErr := ctxutil.GetUserClaims(ctx)
+	if rErr != nil {
+		return nil, rErr
+	}
+	user := "******"
+	if ctxutil.CanViewSensitive(ctx) {
+		user = "root"
+	}
+	return &artwebv1.ListHostsResponse{Hosts: []*artwebv1.Host{{Name: claims.Subject, SshUser: user}}}, nil
+}
+
+func (s *testResourceServer) ListPackages(ctx context.Context, req *artwebv1.ListPackagesRequest) (*artwebv1.ListPackagesResponse, error) {
+	if rErr := s.server.Authorize(ctx, "GET /api/v1/resource/package/"+req.GetLabel()); rErr != nil {
+		return nil, rErr
+	}
+	if req.GetLabel() == "panic" {
+		panic("test")
+	}
+	return &artwebv1.ListPackagesResponse{Total: 1}, nil
+}
+
+func newTestServer(t *testing.T) (artwebv1.ResourceServiceClient, *auth.JWTConfig) {
+	t.Helper()
+	enforcer, err := auth.NewCasbinEnforcer()
+	require.NoError(t, err)
+	require.NoError(t, auth.AddPolicies(context.Background(), enforcer, [][]string{
+		{auth.RoleToSubject(1), "/api/v1/resource/host", "GET"},
+		{auth.RoleToSubject(1), "/api/v1/resource/package/artweb", "GET"},
+		{auth.RoleToSubject(1), "/api/v1/resource/package/panic", "GET"},
+		{auth.RoleToSubject(1), auth.SensitiveObj, "GET"},
+		{auth.RoleToSubject(2), "/api/v1/resource/host", "GET"},
+	}))
+	jwtConf := auth.NewJWTConfig(
+		10*time.Minute, 10*time.Minute, "HS256", "HS512",
+		[]byte("rpc-access"), []byte("rpc-refresh"),
+	)
+
+	s := NewServer(zap.NewNop(), jwtConf, enforcer)
+	s.Register(&artwebv1.ResourceService_ServiceDesc, &testResourceServer{server: s}, map[string]string{
+		"ListHosts":    "GET /api/v1/resource/host",
+		"ListPackages": "",
+	})
+	ln := bufconn.Listen(1 << 20)
+	go s.Serve(ln)
+	t.Cleanup(func() { s.Shutdown(context.Background()) })
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return ln.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return artwebv1.NewResourceServiceClient(conn), jwtConf
+}
+
+func withToken(t *testing.T, c *auth.JWTConfig, u auth.UserInfo) context.Context {
+	t.Helper()
+	token, err := auth.NewAccessJWT(context.Background(), c, u)
+	require.NoError(t, err)
+	return metadata.AppendToOutgoingContext(context.Background(), AuthorizationKey, token)
+}
+
+func assertStatus(t *testing.T, err error, code codes.Code, reason errors.ErrorReason) {
+	t.Helper()
+	st, ok := status.FromError(err)
+	require.True(t, ok, "应该返回gRPC状态")
+	assert.Equal(t, code, st.Code())
+	require.Len(t, st.Details(), 1, "应该携带错误详情")
+	info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+	require.True(t, ok)
+	assert.Equal(t, string(reason), info.GetReason())
+}
+
+func TestServerAuth(t *testing.T) {
+	client, jwtConf := newTestServer(t)
+	admin := auth.UserInfo{UserID: 1, Username: "admin", RoleID: 1}
+	viewer := auth.UserInfo{UserID: 2, Username: "viewer", RoleID: 2}
+
+	_, err := client.ListHosts(context.Background(), &artwebv1.ListHostsRequest{})
+	assertStatus(t, err, codes.Unauthenticated, errors.ReasonUnauthorized)
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), AuthorizationKey, "invalid")
+	_, err = client.ListHosts(ctx, &artwebv1.ListHostsRequest{})
+	assertStatus(t, err, codes.Unauthenticated, errors.ReasonTokenInvalid)
+
+	reply, err := client.ListHosts(withToken(t, jwtConf, admin), &artwebv1.ListHostsRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "admin", reply.GetHosts()[0].GetName(), "服务实现应该能取得当前用户")
+	assert.Equal(t, "root", reply.GetHosts()[0].GetSshUser(), "有敏感字段权限时应该返回明文")
+
+	reply, err = client.ListHosts(withToken(t, jwtConf, viewer), &artwebv1.ListHostsRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "******", reply.GetHosts()[0].GetSshUser(), "没有敏感字段权限时应该掩码")
+
+	_, err = client.ListHosts(withToken(t, jwtConf, viewer), &artwebv1.ListHostsRequest{Size: 1000})
+	assertStatus(t, err, codes.InvalidArgument, errors.ReasonValidationFailed)
+
+	mustChange := admin
+	mustChange.MustChangePassword = true
+	_, err = client.ListHosts(withToken(t, jwtConf, mustChange), &artwebv1.ListHostsRequest{})
+	assertStatus(t, err, codes.PermissionDenied, errors.ReasonPasswordChangeRequired)
+}
+
+func TestServerAuthorize(t *testing.T) {
+	client, jwtConf := newTestServer(t)
+	admin := withToken(t, jwtConf, auth.UserInfo{UserID: 1, Username: "admin", RoleID: 1})
+	viewer := withToken(t, jwtConf, auth.UserInfo{UserID: 2, Username: "viewer", RoleID: 2})
+
+	_, err := client.ListPackages(admin, &artwebv1.ListPackagesRequest{Label: "artweb"})
+	require.NoError(t, err)
+
+	_, err = client.ListPackages(admin, &artwebv1.ListPackagesRequest{Label: "other"})
+	assertStatus(t, err, codes.PermissionDenied, errors.ReasonForbidden)
+
+	_, err = client.ListPackages(viewer, &artwebv1.ListPackagesRequest{Label: "artweb"})
+	assertStatus(t, err, codes.PermissionDenied, errors.ReasonForbidden)
+
+	_, err = client.ListPackages(admin, &artwebv1.ListPackagesRequest{Label: "panic"})
+	assertStatus(t, err, codes.Internal, errors.ReasonUnknown)
+
+	// panic后服务器应该继续处理请求
+	_, err = client.ListPackages(admin, &artwebv1.ListPackagesRequest{Label: "artweb"})
+	require.NoError(t, err)
+}
+
+func TestServerRegister(t *testing.T) {
+	s := NewServer(zap.NewNop(), nil, nil)
+	assert.Panics(t, func() {
+		s.Register(&artwebv1.ResourceService_ServiceDesc, &testResourceServer{}, map[string]string{
+			"ListHosts": "GET /api/v1/resource/host",
+		})
+	}, "方法未指定REST接口时应该panic")
+}
+
+func TestPage(t *testing.T) {
+	tests := []struct {
+		name       string
+		page, size int32
+		wantPage   int
+		wantSize   int
+		wantErr    bool
+	}{
+		{"未传时使用默认值", 0, 0, 1, 20, false},
+		{"指定分页", 3, 50, 3, 50, false},
+		{"超出每页上限", 1, 101, 0, 0, true},
+		{"页码为负数", -1, 10, 0, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, size, rErr := Page(tt.page, tt.size)
+			if tt.wantErr {
+				require.NotNil(t, rErr)
+				assert.Equal(t, errors.ReasonValidationFailed, rErr.Reason)
+				return
+			}
+			require.Nil(t, rErr)
+			assert.Equal(t, tt.wantPage, page)
+			assert.Equal(t, tt.wantSize, size)
+		})
+	}
+}
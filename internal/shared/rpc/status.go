@@ -0,0 +1,72 @@
+package rpc
+
+import (
+	"fmt"
+	"net/http"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"gin-artweb/internal/shared/errors"
+)
+
+// ErrorDomain gRPC错误详情中的错误域
+const ErrorDomain = "gin-artweb"
+
+// httpToCode REST接口状态码对应的gRPC状态码
+var httpToCode = map[int]codes.Code{
+	http.StatusBadRequest:            codes.InvalidArgument,
+	http.StatusUnprocessableEntity:   codes.InvalidArgument,
+	http.StatusRequestEntityTooLarge: codes.InvalidArgument,
+	http.StatusUnauthorized:          codes.Unauthenticated,
+	http.StatusForbidden:             codes.PermissionDenied,
+	http.StatusNotFound:              codes.NotFound,
+	http.StatusConflict:              codes.Aborted,
+	http.StatusLocked:                codes.FailedPrecondition,
+	http.StatusPreconditionRequired:  codes.FailedPrecondition,
+	http.StatusNotAcceptable:         codes.FailedPrecondition,
+	http.StatusRequestTimeout:        codes.DeadlineExceeded,
+	http.StatusGatewayTimeout:        codes.DeadlineExceeded,
+	http.StatusTooManyRequests:       codes.ResourceExhausted,
+	http.StatusNotImplemented:        codes.Unimplemented,
+	http.StatusServiceUnavailable:    codes.Unavailable,
+	http.StatusBadGateway:            codes.Unavailable,
+}
+
+// Status 将服务层错误转换为gRPC状态, 错误原因和数据写入ErrorInfo详情
+func Status(rErr *errors.Error) error {
+	code := codes.Internal
+	switch rErr.Reason {
+	case errors.ReasonCanceled:
+		code = codes.Canceled
+	case errors.ReasonDeadlineExceeded:
+		code = codes.DeadlineExceeded
+	default:
+		if c, ok := httpToCode[errors.GetHTTPStatus(rErr.Reason)]; ok {
+			code = c
+		}
+	}
+
+	st := status.New(code, rErr.Msg)
+	info := &errdetails.ErrorInfo{
+		Reason:   string(rErr.Reason),
+		Domain:   ErrorDomain,
+		Metadata: make(map[string]string, len(rErr.Data)),
+	}
+	for k, v := range rErr.Data {
+		info.Metadata[k] = fmt.Sprint(v)
+	}
+	if detailed, err := st.WithDetails(info); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+// statusFromError 转换服务实现返回的错误, 已经是gRPC状态的错误原样返回
+func statusFromError(err error) error {
+	if _, ok := err.(interface{ GRPCStatus() *status.Status }); ok {
+		return err
+	}
+	return Status(errors.FromError(err))
+}
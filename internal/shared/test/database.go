@@ -1,11 +1,31 @@
 package test
 
 import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 )
 
+// 选择测试数据库的环境变量, 未设置时使用SQLite内存数据库
+//
+// 例如TEST_DB_TYPE=postgres TEST_DB_DNS="host=127.0.0.1 user=artweb password=*** dbname=artweb_test sslmode=disable",
+// 每次创建测试数据库时新建独立的schema(PostgreSQL)或database(MySQL), 测试之间互不影响
+const (
+	TestDBTypeEnv = "TEST_DB_TYPE"
+	TestDBDnsEnv  = "TEST_DB_DNS"
+)
+
+// testDBCleanups 测试数据库关闭时删除新建的schema或database
+var testDBCleanups sync.Map
+
 func NewTestGormDBWithConfig(config *gorm.Config) *gorm.DB {
 	if config == nil {
 		config = &gorm.Config{
@@ -13,13 +33,98 @@ func NewTestGormDBWithConfig(config *gorm.Config) *gorm.DB {
 		}
 	}
 
-	db, err := gorm.Open(sqlite.Open("file::memory:"), config)
+	var (
+		db      *gorm.DB
+		cleanup func()
+		err     error
+	)
+	switch typ := os.Getenv(TestDBTypeEnv); typ {
+	case "", "sqlite":
+		db, err = gorm.Open(sqlite.Open("file::memory:"), config)
+	case "postgres":
+		db, cleanup, err = openIsolatedTestDB(config, postgres.Open, postgresTestDns)
+	case "mysql":
+		db, cleanup, err = openIsolatedTestDB(config, mysql.Open, mysqlTestDns)
+	default:
+		err = fmt.Errorf("不支持的测试数据库类型: %s", typ)
+	}
 	if err != nil {
 		panic(err)
 	}
+	if cleanup != nil {
+		testDBCleanups.Store(db, cleanup)
+	}
 	return db
 }
 
+// openIsolatedTestDB 在TEST_DB_DNS指定的数据库中新建独立的schema或database并连接
+//
+// dnsFor返回创建语句、删除语句和连接新建库的连接字符串
+func openIsolatedTestDB(
+	config *gorm.Config,
+	open func(dns string) gorm.Dialector,
+	dnsFor func(dns, name string) (string, string, string),
+) (*gorm.DB, func(), error) {
+	dns := os.Getenv(TestDBDnsEnv)
+	if dns == "" {
+		return nil, nil, fmt.Errorf("未设置环境变量%s", TestDBDnsEnv)
+	}
+	admin, err := gorm.Open(open(dns), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	name := fmt.Sprintf("artweb_test_%d", time.Now().UnixNano())
+	create, drop, isolatedDns := dnsFor(dns, name)
+	if err := admin.Exec(create).Error; err != nil {
+		closeDB(admin)
+		return nil, nil, err
+	}
+	cleanup := func() {
+		admin.Exec(drop)
+		closeDB(admin)
+	}
+
+	db, err := gorm.Open(open(isolatedDns), config)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	return db, cleanup, nil
+}
+
+// postgresTestDns 新建schema并通过search_path使用, 支持key=value和URL两种连接字符串
+func postgresTestDns(dns, name string) (string, string, string) {
+	create, drop := "CREATE SCHEMA "+name, "DROP SCHEMA "+name+" CASCADE"
+	if strings.Contains(dns, "://") {
+		sep := "?"
+		if strings.Contains(dns, "?") {
+			sep = "&"
+		}
+		return create, drop, dns + sep + "search_path=" + name
+	}
+	return create, drop, dns + " search_path=" + name
+}
+
+// mysqlTestDns 新建database并替换连接字符串中的库名, 连接字符串格式为user:pass@tcp(host:port)/dbname?params
+func mysqlTestDns(dns, name string) (string, string, string) {
+	create, drop := "CREATE DATABASE "+name, "DROP DATABASE "+name
+	params := ""
+	if i := strings.Index(dns, "?"); i >= 0 {
+		dns, params = dns[:i], dns[i:]
+	}
+	if i := strings.LastIndex(dns, "/"); i >= 0 {
+		dns = dns[:i]
+	}
+	return create, drop, dns + "/" + name + params
+}
+
+func closeDB(db *gorm.DB) {
+	if sqlDB, err := db.DB(); err == nil {
+		sqlDB.Close()
+	}
+}
+
 func CloseTestGormDB(db *gorm.DB) error {
 	if db == nil {
 		return nil
@@ -28,5 +133,9 @@ func CloseTestGormDB(db *gorm.DB) error {
 	if err != nil {
 		return err
 	}
-	return sqlDB.Close()
+	err = sqlDB.Close()
+	if cleanup, ok := testDBCleanups.LoadAndDelete(db); ok {
+		cleanup.(func())()
+	}
+	return err
 }
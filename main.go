@@ -1,20 +1,27 @@
 package main
 
 import (
+	"cmp"
 	"context"
+	"crypto/tls"
 	"flag"
 	"fmt"
+	"io/fs"
 	golog "log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
 	"gorm.io/gorm"
 
 	"gin-artweb/internal/model"
@@ -24,7 +31,13 @@ import (
 	"gin-artweb/internal/shared/config"
 	"gin-artweb/internal/shared/crontab"
 	"gin-artweb/internal/shared/database"
+	"gin-artweb/internal/shared/events"
 	"gin-artweb/internal/shared/log"
+	"gin-artweb/internal/shared/metrics"
+	"gin-artweb/internal/shared/rpc"
+	"gin-artweb/pkg/crypto"
+	"gin-artweb/pkg/listener"
+	"gin-artweb/pkg/tlsreload"
 )
 
 var (
@@ -43,14 +56,30 @@ func main() {
 	// 定义并解析命令行参数，指定配置文件路径，默认为 "../config/system.yaml"
 	var (
 		configPath  string
+		env         string
 		showVersion bool
 		migrator    bool
+		migrate     string
 		execSqlPath string
+		bootstrap   bool
+		adminName   string
+		encrypt     bool
+		restorePath string
+		fake        bool
+		pwdReport   bool
 	)
 	flag.StringVar(&configPath, "config", "system.yaml", "系统配置文件的路径")
+	flag.StringVar(&env, "env", "", "运行环境(dev/staging/prod), 加载对应的system.{env}.yaml覆盖基础配置, 未指定时使用环境变量GIN_ARTWEB_ENV")
 	flag.BoolVar(&showVersion, "v", false, "展示版本信息")
-	flag.BoolVar(&migrator, "migrator", false, "迁移数据库")
+	flag.BoolVar(&migrator, "migrator", false, "迁移数据库, 等同于 -migrate up")
+	flag.StringVar(&migrate, "migrate", "", "执行版本化数据库迁移(up/down/status)")
 	flag.StringVar(&execSqlPath, "exec-sql", "", "执行SQL文件路径")
+	flag.BoolVar(&bootstrap, "bootstrap", false, "首次部署初始化默认角色、API目录和管理员")
+	flag.StringVar(&adminName, "admin", "admin", "初始化时创建的管理员用户名, 密码可通过环境变量ADMIN_PASSWORD指定")
+	flag.BoolVar(&encrypt, "encrypt-fields", false, "使用当前字段加密密钥加密数据库中的敏感字段, 用于开启加密或轮换密钥后处理存量数据")
+	flag.StringVar(&restorePath, "restore", "", "恢复备份文件到没有数据的数据库, 先执行数据库迁移再导入数据和上传文件")
+	flag.BoolVar(&pwdReport, "password-report", false, "统计用户密码哈希使用的算法, 查看仍使用旧算法或旧参数的用户数量")
+	flag.BoolVar(&fake, "fake", false, "以演示模式启动: 使用内存数据库和固定的演示数据, 用于前端开发和其他项目的集成测试")
 	flag.Parse()
 
 	if showVersion {
@@ -69,22 +98,115 @@ func main() {
 	if err := godotenv.Load(filepath.Join(config.BaseDir, ".env")); err != nil {
 		golog.Fatalf("加载环境变量失败: %v", err)
 	}
+	if env == "" {
+		env = os.Getenv("GIN_ARTWEB_ENV")
+	}
 	// 加载系统配置
-	sysConf := config.NewSystemConf(filepath.Join(config.ConfigDir, configPath))
+	sysConf := config.NewSystemConf(filepath.Join(config.ConfigDir, configPath), env)
+	// 登记配置中的密钥, 日志中出现时替换为脱敏值
+	secrets := config.NewSecretManager()
+	sysConf.TrackSecrets(secrets)
 	// 初始化服务器日志记录器
-	loggers := NewLoggers(sysConf.Log)
+	loggers := NewLoggers(sysConf.Log, secrets)
+	loggers.Server.Info(
+		"加载系统配置成功",
+		zap.String("env", sysConf.Env),
+		zap.Strings("files", sysConf.Source.Files),
+		zap.Strings("overrides", sysConf.Source.Overrides),
+		zap.Any("config", sysConf.Masked()),
+	)
+
+	if migrator && migrate == "" {
+		migrate = "up"
+	}
+	if migrate != "" {
+		db, err := initGromDB(sysConf)
+		if err != nil {
+			golog.Fatalf("数据库初始化失败: %v", err)
+		}
+		defer database.CloseGormDB(db)
+
+		if err := runMigrate(routers.NewMigrator(db), migrate); err != nil {
+			golog.Panicf("数据库迁移失败: %v", err)
+		}
+		return
+	}
+
+	if encrypt {
+		if !sysConf.Security.Encryption.Enable {
+			golog.Fatalf("未开启敏感字段加密, 请先配置security.encryption")
+		}
+		db, err := initGromDB(sysConf)
+		if err != nil {
+			golog.Fatalf("数据库初始化失败: %v", err)
+		}
+		defer database.CloseGormDB(db)
+
+		n, err := model.EncryptFields(context.Background(), db)
+		if err != nil {
+			golog.Panicf("加密敏感字段失败: %v", err)
+		}
+		fmt.Printf("加密敏感字段成功, 更新记录数: %d\n", n)
+		return
+	}
 
-	if migrator {
+	if pwdReport {
 		db, err := initGromDB(sysConf)
 		if err != nil {
 			golog.Fatalf("数据库初始化失败: %v", err)
 		}
 		defer database.CloseGormDB(db)
 
-		if err := model.DBAutoMigrate(db); err != nil {
+		dbTimeout := config.DBTimeout{
+			ListTimeout:  time.Duration(sysConf.Database.ListTimeout) * time.Second,
+			ReadTimeout:  time.Duration(sysConf.Database.ReadTimeout) * time.Second,
+			WriteTimeout: time.Duration(sysConf.Database.WriteTimeout) * time.Second,
+		}
+		out, err := routers.PasswordHashReport(context.Background(), db, &dbTimeout, loggers, sysConf)
+		if err != nil {
+			golog.Panicf("统计密码哈希失败: %v", err)
+		}
+		fmt.Println("===== 密码哈希统计 =====")
+		fmt.Printf("当前算法  : %s\n", out.Algorithm)
+		fmt.Printf("用户总数  : %d\n", out.Total)
+		fmt.Printf("当前参数  : %d\n", out.Current)
+		fmt.Printf("待重新哈希: %d\n", out.Legacy)
+		for _, c := range out.Algorithms {
+			fmt.Printf("  %-9s: %d (旧参数 %d)\n", c.Algorithm, c.Total, c.Legacy)
+		}
+		fmt.Println("========================")
+		return
+	}
+
+	if restorePath != "" {
+		if _, err := os.Stat(restorePath); err != nil {
+			golog.Fatalf("备份文件不存在: %s", restorePath)
+		}
+		db, err := initGromDB(sysConf)
+		if err != nil {
+			golog.Fatalf("数据库初始化失败: %v", err)
+		}
+		defer database.CloseGormDB(db)
+
+		if err := runMigrate(routers.NewMigrator(db), "up"); err != nil {
 			golog.Panicf("数据库迁移失败: %v", err)
 		}
-		golog.Println("数据库迁移成功")
+		dbTimeout := config.DBTimeout{
+			ListTimeout:  time.Duration(sysConf.Database.ListTimeout) * time.Second,
+			ReadTimeout:  time.Duration(sysConf.Database.ReadTimeout) * time.Second,
+			WriteTimeout: time.Duration(sysConf.Database.WriteTimeout) * time.Second,
+		}
+		manifest, err := routers.RestoreBackup(context.Background(), db, &dbTimeout, loggers, sysConf, restorePath)
+		if err != nil {
+			golog.Panicf("恢复备份失败: %v", err)
+		}
+		fmt.Println("===== 恢复完成 =====")
+		fmt.Printf("结构版本  : %s\n", manifest.SchemaVersion)
+		fmt.Printf("备份时间  : %s\n", manifest.CreatedAt.Format(time.DateTime))
+		fmt.Printf("表数量    : %d\n", len(manifest.Tables))
+		fmt.Printf("记录数量  : %d\n", manifest.RowCount())
+		fmt.Printf("文件数量  : %d\n", manifest.FileCount)
+		fmt.Println("====================")
 		return
 	}
 
@@ -114,8 +236,66 @@ func main() {
 		return
 	}
 
+	if bootstrap {
+		// 先执行数据库迁移, 保证新部署的数据库结构与程序一致
+		db, err := initGromDB(sysConf)
+		if err != nil {
+			golog.Fatalf("数据库初始化失败: %v", err)
+		}
+		if err := runMigrate(routers.NewMigrator(db), "up"); err != nil {
+			golog.Panicf("数据库迁移失败: %v", err)
+		}
+		database.CloseGormDB(db)
+
+		i, clearFunc, err := newInitialize(sysConf, loggers, secrets)
+		if err != nil {
+			golog.Panicf("系统初始化失败: %v", err)
+		}
+		defer clearFunc()
+
+		// 注册全部路由后才能生成API目录
+		gin.SetMode(gin.ReleaseMode)
+		r := routers.NewRouter(loggers, i, version, frontendFS(loggers.Server, &i.Conf.Server.Static))
+		out, rErr := routers.Bootstrap(context.Background(), r, i, loggers, adminName, os.Getenv("ADMIN_PASSWORD"))
+		if rErr != nil {
+			golog.Panicf("初始化基础数据失败: %v", rErr)
+		}
+		fmt.Println("===== 初始化完成 =====")
+		fmt.Printf("导入API数量 : %d\n", out.ApiCount)
+		fmt.Printf("创建角色    : %s\n", strings.Join(out.Roles, ", "))
+		fmt.Printf("管理员      : %s\n", out.Username)
+		if out.Password != "" {
+			fmt.Printf("初始密码    : %s\n", out.Password)
+		}
+		fmt.Println("首次登录后需修改密码")
+		fmt.Println("======================")
+		return
+	}
+
+	if fake {
+		if sysConf.Env == config.EnvProd {
+			golog.Fatalf("prod环境不能使用演示模式")
+		}
+		routers.FakeConfig(sysConf)
+		// 未配置令牌密钥时使用固定值, 重启后已签发的令牌仍然有效
+		for _, name := range []string{"JWT_ACCESS_SECRET", "JWT_REFRESH_SECRET"} {
+			if os.Getenv(name) == "" {
+				os.Setenv(name, "gin-artweb-fake-"+strings.ToLower(name))
+			}
+		}
+		// 内存数据库在最后一个连接关闭后释放, 该连接保持到程序退出
+		db, err := initGromDB(sysConf)
+		if err != nil {
+			golog.Fatalf("数据库初始化失败: %v", err)
+		}
+		defer database.CloseGormDB(db)
+		if err := runMigrate(routers.NewMigrator(db), "up"); err != nil {
+			golog.Panicf("数据库迁移失败: %v", err)
+		}
+	}
+
 	// 初始化系统资源（如配置、数据库等），获取清理函数和错误信息
-	i, clearFunc, err := newInitialize(sysConf, loggers)
+	i, clearFunc, err := newInitialize(sysConf, loggers, secrets)
 	if err != nil {
 		panic(err)
 	}
@@ -127,45 +307,57 @@ func main() {
 	gin.DisableConsoleColor()
 
 	// 创建 Gin 路由引擎
-	r := routers.NewRouter(loggers, i, version, filepath.Join(config.BaseDir, "html"))
+	r := routers.NewRouter(loggers, i, version, frontendFS(loggers.Server, &i.Conf.Server.Static))
 
-	// 启动定时任务
-	if i.Crontab != nil {
+	if fake {
+		if err := routers.SeedFake(context.Background(), r, i, loggers); err != nil {
+			golog.Panicf("初始化演示数据失败: %v", err)
+		}
+		fmt.Println("===== 演示模式 =====")
+		for _, u := range routers.FakeUsers {
+			fmt.Printf("%-10s: %s\n", u.Username, u.Role)
+		}
+		fmt.Printf("密码      : %s\n", routers.FakePassword)
+		fmt.Println("数据保存在内存中, 重启后恢复为初始数据")
+		fmt.Println("====================")
+	}
+
+	// 启动定时任务, 演示模式下的主机和集群不是真实的机器, 不执行定时任务
+	if i.Crontab != nil && !fake {
 		i.Crontab.Start()
 	}
 
+	// 启动事件分发, 各模块已在加载路由时订阅事件
+	i.Outbox.Start()
+	i.OnShutdown(i.Outbox.Stop)
+
 	// 构建 HTTP 服务器结构体
 	srv := &http.Server{
 		Addr:    fmt.Sprintf("%s:%d", i.Conf.Server.Host, i.Conf.Server.Port),
 		Handler: r,
 	}
 
+	// 启用 SSL 时由证书重新加载器提供证书, 更换证书不需要重启服务
+	var (
+		reloader    *tlsreload.Reloader
+		redirectSrv *http.Server
+	)
+	watchCtx, stopWatch := context.WithCancel(context.Background())
+	defer stopWatch()
+	if i.Conf.Server.SSL.Enable {
+		reloader, redirectSrv = setupTLS(watchCtx, loggers.Server, i.Conf.Server, srv)
+	}
+
 	// 启动一个 goroutine 来异步启动 HTTP/HTTPS 服务
 	go func() {
 		var err error
 		// 判断是否启用 SSL/TLS 加密传输
-		if i.Conf.Server.SSL.Enable {
-			// 构造证书和私钥的完整路径
-			crtPath := filepath.Join(config.ConfigDir, i.Conf.Server.SSL.CrtPath)
-			keyPath := filepath.Join(config.ConfigDir, i.Conf.Server.SSL.KeyPath)
-
-			// 校验证书文件是否存在
-			if _, statErr := os.Stat(crtPath); os.IsNotExist(statErr) {
-				loggers.Server.Error("SSL CRT 文件不存在", zap.String("path", crtPath))
-				panic(statErr)
-			}
-			// 校验私钥文件是否存在
-			if _, statErr := os.Stat(keyPath); os.IsNotExist(statErr) {
-				loggers.Server.Error("SSL KEY 文件不存在", zap.String("path", keyPath))
-				panic(statErr)
-			}
-
-			// 输出 HTTPS 启动信息并开始监听
+		if reloader != nil {
+			// 输出 HTTPS 启动信息并开始监听, 证书由 TLSConfig.GetCertificate 提供
 			loggers.Server.Info("正在启动 HTTPS 服务器...",
 				zap.String("addr", srv.Addr),
-				zap.String("crt", crtPath),
-				zap.String("key", keyPath))
-			err = srv.ListenAndServeTLS(crtPath, keyPath)
+				zap.Bool("http2", !i.Conf.Server.SSL.DisableHTTP2))
+			err = srv.ListenAndServeTLS("", "")
 		} else {
 			// 输出 HTTP 启动信息并开始监听
 			loggers.Server.Info("正在启动 HTTP 服务器...", zap.String("addr", srv.Addr))
@@ -179,12 +371,73 @@ func main() {
 		}
 	}()
 
+	// 在 unix 套接字和 systemd 传入的套接字上同时提供 HTTP 服务, 关闭服务器时一起关闭
+	for _, ln := range extraListeners(loggers.Server, i.Conf.Server) {
+		go func(ln net.Listener) {
+			loggers.Server.Info("正在启动 HTTP 服务器...", zap.String("addr", ln.Addr().String()))
+			if err := srv.Serve(ln); err != nil && err != http.ErrServerClosed {
+				loggers.Server.Error("服务器启动失败", zap.Error(err), zap.String("addr", ln.Addr().String()))
+				panic(err)
+			}
+		}(ln)
+	}
+
+	// 启动管理端口服务, 指标和性能分析接口只在管理端口上提供
+	var adminSrv *http.Server
+	if admin := i.Conf.Server.Admin; admin.Enable {
+		adminSrv = &http.Server{
+			Addr:              fmt.Sprintf("%s:%d", cmp.Or(admin.Host, "127.0.0.1"), admin.Port),
+			Handler:           routers.NewAdminRouter(loggers, i, version),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+		go func() {
+			loggers.Server.Info("正在启动管理端口服务器...", zap.String("addr", adminSrv.Addr))
+			if err := adminSrv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				loggers.Server.Error("管理端口服务器启动失败", zap.Error(err))
+				panic(err)
+			}
+		}()
+	}
+
+	// 启动 gRPC 服务, 与 HTTP 服务共用访问令牌和角色权限
+	if i.RPC != nil {
+		g := i.Conf.Server.GRPC
+		addr := fmt.Sprintf("%s:%d", cmp.Or(g.Host, "127.0.0.1"), g.Port)
+		ln, err := net.Listen("tcp", addr)
+		if err != nil {
+			loggers.Server.Error("gRPC 服务器启动失败", zap.Error(err))
+			panic(err)
+		}
+		go func() {
+			loggers.Server.Info("正在启动 gRPC 服务器...",
+				zap.String("addr", addr),
+				zap.Bool("tls", g.CrtPath != ""),
+				zap.Bool("mtls", g.ClientCAPath != ""))
+			if err := i.RPC.Serve(ln); err != nil {
+				loggers.Server.Error("gRPC 服务器启动失败", zap.Error(err))
+				panic(err)
+			}
+		}()
+	}
+
+	// 启动 HTTP 到 HTTPS 的重定向服务
+	if redirectSrv != nil {
+		go func() {
+			loggers.Server.Info("正在启动 HTTP 重定向服务器...", zap.String("addr", redirectSrv.Addr))
+			if err := redirectSrv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				loggers.Server.Error("HTTP 重定向服务器启动失败", zap.Error(err))
+				panic(err)
+			}
+		}()
+	}
+
 	// 打印服务器启动信息
 	loggers.Server.Info(
 		"服务器启动ing ...",
 		zap.String("host", i.Conf.Server.Host),
 		zap.Int("port", i.Conf.Server.Port),
 		zap.Bool("ssl", i.Conf.Server.SSL.Enable),
+		zap.String("deploy_mode", i.Conf.Deploy.Mode),
 		zap.String("version", version),
 		zap.String("commit", commitID),
 		zap.String("build_time", buildTime),
@@ -204,28 +457,204 @@ func main() {
 	defer cancel()
 
 	// 执行服务器优雅关闭逻辑
+	stopWatch()
+	if redirectSrv != nil {
+		if err := redirectSrv.Shutdown(ctx); err != nil {
+			loggers.Server.Error("HTTP 重定向服务器强制关闭", zap.Error(err))
+		}
+	}
 	if err := srv.Shutdown(ctx); err != nil {
 		loggers.Server.Error("服务器强制关闭", zap.Error(err))
 	}
+	if adminSrv != nil {
+		if err := adminSrv.Shutdown(ctx); err != nil {
+			loggers.Server.Error("管理端口服务器强制关闭", zap.Error(err))
+		}
+	}
+	if i.RPC != nil {
+		if err := i.RPC.Shutdown(ctx); err != nil {
+			loggers.Server.Error("gRPC 服务器强制关闭", zap.Error(err))
+		}
+	}
+	if path := i.Conf.Server.Unix.Path; path != "" {
+		if err := listener.RemoveSocket(path); err != nil {
+			loggers.Server.Error("清理 unix 套接字文件失败", zap.Error(err), zap.String("path", path))
+		}
+	}
+
+	// 处理执行中的脚本等需要在释放资源前完成的清理
+	i.Shutdown(context.Background())
 
 	// 最终确认服务器已经退出
 	loggers.Server.Info("服务器已退出")
 }
 
+// setupTLS 为服务器配置TLS, 返回证书重新加载器和HTTP重定向服务器(未启用时为nil)
+//
+// 证书在收到SIGHUP信号时重新加载, 配置了reload_interval时还会定期检查证书文件的变化;
+// 重新加载只影响新的握手, 已建立的连接不会断开, 加载失败时继续使用原证书
+func setupTLS(
+	ctx context.Context,
+	logger *zap.Logger,
+	conf *config.ServerConfig,
+	srv *http.Server,
+) (*tlsreload.Reloader, *http.Server) {
+	// 构造证书和私钥的完整路径
+	crtPath := filepath.Join(config.ConfigDir, conf.SSL.CrtPath)
+	keyPath := filepath.Join(config.ConfigDir, conf.SSL.KeyPath)
+
+	reloader, err := tlsreload.New(crtPath, keyPath)
+	if err != nil {
+		logger.Error("加载 SSL 证书失败", zap.Error(err), zap.String("crt", crtPath), zap.String("key", keyPath))
+		panic(err)
+	}
+	tlsConf, err := reloader.TLSConfig(conf.SSL.MinVersion, conf.SSL.CipherSuites)
+	if err != nil {
+		logger.Error("SSL 配置错误", zap.Error(err))
+		panic(err)
+	}
+	srv.TLSConfig = tlsConf
+	if conf.SSL.DisableHTTP2 {
+		// TLSNextProto 不为nil时 net/http 不会自动启用 HTTP/2
+		srv.TLSNextProto = make(map[string]func(*http.Server, *tls.Conn, http.Handler))
+	}
+
+	onReload := func(source string) func(err error) {
+		return func(err error) {
+			if err != nil {
+				logger.Error("重新加载 SSL 证书失败, 继续使用原证书", zap.Error(err), zap.String("source", source))
+				return
+			}
+			logger.Info("重新加载 SSL 证书成功", zap.String("source", source), zap.String("crt", crtPath))
+		}
+	}
+
+	// 收到 SIGHUP 信号时重新加载证书
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	go func() {
+		defer signal.Stop(hup)
+		report := onReload("signal")
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-hup:
+				report(reloader.Reload())
+			}
+		}
+	}()
+
+	// 定期检查证书文件, 文件变化时重新加载
+	if conf.SSL.ReloadInterval > 0 {
+		go reloader.Watch(ctx, time.Duration(conf.SSL.ReloadInterval)*time.Second, onReload("file"))
+	}
+
+	if conf.SSL.RedirectPort == 0 {
+		return reloader, nil
+	}
+	return reloader, &http.Server{
+		Addr:              fmt.Sprintf("%s:%d", conf.Host, conf.SSL.RedirectPort),
+		Handler:           httpsRedirectHandler(conf.Port),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+}
+
+// frontendFS 返回前端文件: 配置了磁盘目录时使用该目录, 否则使用内嵌的文件, 未内嵌时使用html目录
+func frontendFS(logger *zap.Logger, conf *config.StaticConfig) fs.FS {
+	if conf.Dir != "" {
+		dir := conf.Dir
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(config.BaseDir, dir)
+		}
+		logger.Info("使用磁盘上的前端文件", zap.String("dir", dir))
+		return os.DirFS(dir)
+	}
+	if fsys := embeddedFrontend(); fsys != nil {
+		logger.Info("使用内嵌的前端文件")
+		return fsys
+	}
+	return os.DirFS(filepath.Join(config.BaseDir, "html"))
+}
+
+// extraListeners 返回host:port以外的监听器: 配置的unix套接字和systemd套接字激活传入的套接字
+func extraListeners(logger *zap.Logger, conf *config.ServerConfig) []net.Listener {
+	var listeners []net.Listener
+	if conf.Unix.Path != "" {
+		var mode os.FileMode
+		if conf.Unix.Mode != "" {
+			// 加载配置时已校验格式
+			m, _ := strconv.ParseUint(conf.Unix.Mode, 8, 32)
+			mode = os.FileMode(m)
+		}
+		ln, err := listener.ListenUnix(conf.Unix.Path, mode)
+		if err != nil {
+			logger.Error("监听 unix 套接字失败", zap.Error(err), zap.String("path", conf.Unix.Path))
+			panic(err)
+		}
+		listeners = append(listeners, ln)
+	}
+	if conf.Systemd {
+		ls, err := listener.Systemd()
+		if err != nil {
+			logger.Error("读取 systemd 套接字失败", zap.Error(err))
+			panic(err)
+		}
+		if len(ls) == 0 {
+			logger.Warn("已开启 systemd_socket 但进程不是通过 systemd 套接字激活启动的")
+		}
+		listeners = append(listeners, ls...)
+	}
+	return listeners
+}
+
+// httpsRedirectHandler 将HTTP请求永久重定向到指定端口的HTTPS地址, 保留请求方法、路径和查询参数
+func httpsRedirectHandler(port int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		host = strings.Trim(host, "[]")
+		if host == "" {
+			http.Error(w, "missing host", http.StatusBadRequest)
+			return
+		}
+		if port != 443 {
+			host = net.JoinHostPort(host, strconv.Itoa(port))
+		} else if strings.Contains(host, ":") {
+			host = "[" + host + "]"
+		}
+		http.Redirect(w, r, "https://"+host+r.URL.RequestURI(), http.StatusPermanentRedirect)
+	})
+}
+
 // newInitialize 初始化系统组件
 // path: 配置文件路径
 // 返回值1: 初始化结构体指针，包含配置、数据库、缓存和日志组件
 // 返回值2: 清理函数，用于关闭数据库连接
 // 返回值3: 初始化过程中发生的错误
-func newInitialize(conf *config.SystemConf, loggers *log.Loggers) (*common.Initialize, func(), error) {
+func newInitialize(
+	conf *config.SystemConf,
+	loggers *log.Loggers,
+	secrets *config.SecretManager,
+) (*common.Initialize, func(), error) {
+	accessSecret := []byte(os.Getenv("JWT_ACCESS_SECRET"))
+	refreshSecret := []byte(os.Getenv("JWT_REFRESH_SECRET"))
+	secrets.Track("JWT_ACCESS_SECRET", accessSecret)
+	secrets.Track("JWT_REFRESH_SECRET", refreshSecret)
 	jwtConf := auth.NewJWTConfig(
 		time.Duration(conf.Security.Token.AccessMinutes)*time.Minute,
 		time.Duration(conf.Security.Token.RefreshMinutes)*time.Minute,
 		conf.Security.Token.AccessMethod,
 		conf.Security.Token.RefreshMethod,
-		[]byte(os.Getenv("JWT_ACCESS_SECRET")),
-		[]byte(os.Getenv("JWT_REFRESH_SECRET")),
+		accessSecret,
+		refreshSecret,
 	)
+	jwtConf.Secrets = secrets
+	// 只记录密钥标识, 用于确认各实例加载的密钥一致
+	loggers.Server.Info("登记内存中的密钥", zap.Any("secrets", secrets.ActiveIDs()))
+	jwtConf.Cookie = auth.NewCookieAuth(conf.Security.Cookie, jwtConf.AccessTokenExpiration, jwtConf.RefreshTokenExpiration)
 
 	// 初始化casbin 权限管理
 	enf, err := auth.NewCasbinEnforcer()
@@ -236,7 +665,11 @@ func newInitialize(conf *config.SystemConf, loggers *log.Loggers) (*common.Initi
 
 	// 初始化计划任务
 	cronWrite := log.NewLumLogger(conf.Log, filepath.Join(config.LogDir, "cron.log"))
-	cronLogger := log.NewZapLoggerMust(conf.Log.Level, cronWrite)
+	cronLogger, err := log.NewLevelLogger(conf.Log, loggers.Levels, log.LoggerCron, cronWrite)
+	if err != nil {
+		loggers.Server.Error("计划任务日志初始化失败", zap.Error(err))
+		return nil, nil, err
+	}
 	ct := crontab.NewCron(cronLogger)
 
 	// 初始化数据库超时配置
@@ -253,14 +686,72 @@ func newInitialize(conf *config.SystemConf, loggers *log.Loggers) (*common.Initi
 		return nil, nil, err
 	}
 
+	// 校验数据库结构版本, 不一致时拒绝启动
+	if err := routers.NewMigrator(db).Check(context.Background()); err != nil {
+		loggers.Server.Error("数据库结构版本校验失败, 请先执行 -migrate up", zap.Error(err))
+		database.CloseGormDB(db)
+		return nil, nil, err
+	}
+
+	// 业务事件写入发件箱, 事务提交后发布到事件总线
+	bus := events.NewBus(loggers.Biz)
+	outbox := events.NewOutbox(loggers.Biz, db, bus, conf.Events)
+
+	// 定时探测数据库连接, 不可用时进入降级状态
+	metrics.DatabaseUp.Set(1)
+	dbHealth := database.NewHealth(
+		db,
+		time.Duration(conf.Database.Outage.ProbeInterval)*time.Second,
+		func(down bool, err error) {
+			if down {
+				metrics.DatabaseUp.Set(0)
+				loggers.Server.Error("数据库连接不可用, 进入降级状态", zap.Error(err))
+				return
+			}
+			metrics.DatabaseUp.Set(1)
+			loggers.Server.Info("数据库连接已恢复, 退出降级状态")
+		},
+	)
+	dbHealth.Start()
+
+	// 启用gRPC端口时创建gRPC服务器, 各模块加载路由时注册服务
+	var rpcSrv *rpc.Server
+	if g := conf.Server.GRPC; g.Enable {
+		var opts []grpc.ServerOption
+		if g.CrtPath != "" {
+			var caPath string
+			if g.ClientCAPath != "" {
+				caPath = filepath.Join(config.ConfigDir, g.ClientCAPath)
+			}
+			tlsConf, err := rpc.TLSConfig(
+				filepath.Join(config.ConfigDir, g.CrtPath),
+				filepath.Join(config.ConfigDir, g.KeyPath),
+				caPath,
+			)
+			if err != nil {
+				loggers.Server.Error("加载 gRPC 证书失败", zap.Error(err))
+				dbHealth.Stop()
+				database.CloseGormDB(db)
+				return nil, nil, err
+			}
+			opts = append(opts, rpc.Creds(tlsConf))
+		}
+		rpcSrv = rpc.NewServer(loggers.Service, jwtConf, enf, opts...)
+	}
+
 	// 返回初始化结构体和清理函数
 	return &common.Initialize{
 			Conf:      conf,
 			DB:        db,
 			DBTimeout: &dbTimeout,
+			Tx:        database.NewTxManager(db),
 			Enforcer:  enf,
 			Crontab:   ct,
 			JwtConf:   jwtConf,
+			Events:    bus,
+			Outbox:    outbox,
+			DBHealth:  dbHealth,
+			RPC:       rpcSrv,
 		}, func() {
 			// 关闭计划任务
 			if ct != nil {
@@ -276,6 +767,8 @@ func newInitialize(conf *config.SystemConf, loggers *log.Loggers) (*common.Initi
 				}
 			}
 
+			dbHealth.Stop()
+
 			// 关闭数据库连接
 			if db != nil {
 				loggers.Server.Info("正在释放数据库资源...")
@@ -288,6 +781,48 @@ func newInitialize(conf *config.SystemConf, loggers *log.Loggers) (*common.Initi
 		}, nil
 }
 
+// runMigrate 执行版本化数据库迁移命令
+func runMigrate(m *database.Migrator, action string) error {
+	ctx := context.Background()
+	switch action {
+	case "up":
+		ids, err := m.Up(ctx)
+		if err != nil {
+			return err
+		}
+		for _, id := range ids {
+			fmt.Printf("已执行迁移: %s\n", id)
+		}
+		fmt.Printf("数据库迁移成功, 当前版本: %s\n", m.Latest())
+	case "down":
+		id, err := m.Down(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("已回滚迁移: %s\n", id)
+	case "status":
+		statuses, err := m.Status(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Println("===== 迁移状态 =====")
+		for _, st := range statuses {
+			state := "未执行"
+			switch {
+			case st.Unknown:
+				state = "程序未知"
+			case st.Applied:
+				state = "已执行 " + st.AppliedAt.Format(time.DateTime)
+			}
+			fmt.Printf("%s  %-24s %s\n", st.ID, state, st.Description)
+		}
+		fmt.Println("====================")
+	default:
+		return fmt.Errorf("不支持的迁移操作: %s", action)
+	}
+	return nil
+}
+
 func initGromDB(conf *config.SystemConf) (*gorm.DB, error) {
 	// 创建GORM数据库配置并连接数据库
 	var dbLog *golog.Logger
@@ -296,18 +831,69 @@ func initGromDB(conf *config.SystemConf) (*gorm.DB, error) {
 		dbLog = golog.New(dbWrite, " ", golog.LstdFlags)
 	}
 	dbConf := database.NewGormConfig(dbLog)
-	return database.NewGormDB(conf.Database, dbConf)
+	retry := conf.Database.Retry
+	db, err := database.OpenWithRetry(
+		context.Background(),
+		database.RetrySettings{
+			Attempts:        retry.Attempts,
+			InitialInterval: time.Duration(retry.InitialInterval) * time.Second,
+			MaxInterval:     time.Duration(retry.MaxInterval) * time.Second,
+		},
+		func() (*gorm.DB, error) {
+			return database.NewGormDB(conf.Database, dbConf)
+		},
+		func(attempt int, wait time.Duration, err error) {
+			golog.Printf("第%d次连接数据库失败, %s后重试: %v", attempt, wait, err)
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// 开启敏感字段加密
+	if conf.Security.Encryption.Enable {
+		var provider crypto.FieldKeyProvider = crypto.EnvKeyProvider{Name: "FIELD_ENCRYPTION_KEYS"}
+		if conf.Security.Encryption.KeyFile != "" {
+			provider = crypto.FileKeyProvider{Path: filepath.Join(config.ConfigDir, conf.Security.Encryption.KeyFile)}
+		}
+		e, err := crypto.NewFieldEncryptorFromProvider(context.Background(), provider)
+		if err != nil {
+			database.CloseGormDB(db)
+			return nil, err
+		}
+		database.SetFieldEncryptor(e)
+		if err := db.Use(database.NewEncryptedFieldPlugin()); err != nil {
+			database.CloseGormDB(db)
+			return nil, err
+		}
+	}
+
+	// 统计数据库操作超时次数
+	onTimeout := func(op string) {
+		metrics.DBQueryTimeoutsTotal.WithLabelValues(op).Inc()
+	}
+	if err := db.Use(database.NewTimeoutPlugin(onTimeout)); err != nil {
+		database.CloseGormDB(db)
+		return nil, err
+	}
+	return db, nil
 }
 
-func NewLoggers(conf *config.LogConfig) *log.Loggers {
-	serverWrite := log.NewLumLogger(conf, filepath.Join(config.LogDir, "server.log"))
-	serviceWrire := log.NewLumLogger(conf, filepath.Join(config.LogDir, "service.log"))
-	bizWrire := log.NewLumLogger(conf, filepath.Join(config.LogDir, "biz.log"))
-	dataWrire := log.NewLumLogger(conf, filepath.Join(config.LogDir, "data.log"))
+func NewLoggers(conf *config.LogConfig, secrets *config.SecretManager) *log.Loggers {
+	levels := log.NewLevels()
+	newLogger := func(name string) *zap.Logger {
+		w := log.NewLumLogger(conf, filepath.Join(config.LogDir, name+".log"))
+		logger, err := log.NewLevelLogger(conf, levels, name, w)
+		if err != nil {
+			panic(err)
+		}
+		return logger.WithOptions(zap.WrapCore(secrets.WrapCore))
+	}
 	return &log.Loggers{
-		Server:  log.NewZapLoggerMust(conf.Level, serverWrite),
-		Service: log.NewZapLoggerMust(conf.Level, serviceWrire),
-		Biz:     log.NewZapLoggerMust(conf.Level, bizWrire),
-		Data:    log.NewZapLoggerMust(conf.Level, dataWrire),
+		Server:  newLogger(log.LoggerServer),
+		Service: newLogger(log.LoggerService),
+		Biz:     newLogger(log.LoggerBiz),
+		Data:    newLogger(log.LoggerData),
+		Levels:  levels,
 	}
 }
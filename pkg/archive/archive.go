@@ -2,6 +2,7 @@ package archive
 
 import (
 	"io"
+	"strings"
 
 	"emperror.dev/errors"
 )
@@ -14,6 +15,8 @@ const (
 	FormatZip ArchiveFormat = "zip"
 	// FormatTarGz TAR.GZ格式
 	FormatTarGz ArchiveFormat = "tar.gz"
+	// FormatTarZst TAR.ZST格式
+	FormatTarZst ArchiveFormat = "tar.zst"
 )
 
 // Archiver 压缩器接口
@@ -33,11 +36,28 @@ func NewArchiver(format ArchiveFormat) (Archiver, error) {
 		return &zipArchiver{}, nil
 	case FormatTarGz:
 		return &tarGzArchiver{}, nil
+	case FormatTarZst:
+		return &tarZstArchiver{}, nil
 	default:
 		return nil, errors.Errorf("不支持的压缩格式: %s", format)
 	}
 }
 
+// DetectFormat 根据文件名后缀判断压缩格式
+func DetectFormat(filename string) (ArchiveFormat, error) {
+	name := strings.ToLower(filename)
+	switch {
+	case strings.HasSuffix(name, ".tar.gz"), strings.HasSuffix(name, ".tgz"):
+		return FormatTarGz, nil
+	case strings.HasSuffix(name, ".tar.zst"), strings.HasSuffix(name, ".tzst"):
+		return FormatTarZst, nil
+	case strings.HasSuffix(name, ".zip"):
+		return FormatZip, nil
+	default:
+		return "", errors.Errorf("无法识别的压缩格式: %s", filename)
+	}
+}
+
 // zipArchiver ZIP格式压缩器
 type zipArchiver struct{}
 
@@ -68,6 +88,21 @@ func (t *tarGzArchiver) ValidateSingleDir(src string, opts ...ArchiveOption) (st
 	return ValidateSingleDirTarGz(src, opts...)
 }
 
+// tarZstArchiver TAR.ZST格式压缩器
+type tarZstArchiver struct{}
+
+func (t *tarZstArchiver) Compress(src string, dst string, opts ...ArchiveOption) error {
+	return TarZst(src, dst, opts...)
+}
+
+func (t *tarZstArchiver) Decompress(src string, dst string, opts ...ArchiveOption) error {
+	return UntarZst(src, dst, opts...)
+}
+
+func (t *tarZstArchiver) ValidateSingleDir(src string, opts ...ArchiveOption) (string, error) {
+	return ValidateSingleDirTarZst(src, opts...)
+}
+
 // StreamArchiver 流式压缩器接口
 type StreamArchiver interface {
 	// CompressStream 从流压缩到流
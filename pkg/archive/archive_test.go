@@ -1,6 +1,7 @@
 package archive
 
 import (
+	"archive/zip"
 	"bytes"
 	"context"
 	"fmt"
@@ -23,6 +24,7 @@ func TestNewArchiver(t *testing.T) {
 	}{
 		{"ZIP格式", FormatZip, true, false},
 		{"TAR.GZ格式", FormatTarGz, true, false},
+		{"TAR.ZST格式", FormatTarZst, true, false},
 		{"不支持的格式", "unknown", false, true},
 	}
 
@@ -40,9 +42,38 @@ func TestNewArchiver(t *testing.T) {
 	}
 }
 
+// TestDetectFormat 测试根据文件名判断压缩格式
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		filename string
+		expected ArchiveFormat
+		wantErr  bool
+	}{
+		{"oes-0.17.tar.gz", FormatTarGz, false},
+		{"OES-0.17.TGZ", FormatTarGz, false},
+		{"mds.zip", FormatZip, false},
+		{"mds-0.17.tar.zst", FormatTarZst, false},
+		{"mds.tzst", FormatTarZst, false},
+		{"mds.tar", "", true},
+		{"mds", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filename, func(t *testing.T) {
+			format, err := DetectFormat(tt.filename)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, format)
+		})
+	}
+}
+
 // TestArchiverInterface 测试压缩器接口实现
 func TestArchiverInterface(t *testing.T) {
-	formats := []ArchiveFormat{FormatZip, FormatTarGz}
+	formats := []ArchiveFormat{FormatZip, FormatTarGz, FormatTarZst}
 
 	for _, format := range formats {
 		t.Run(string(format), func(t *testing.T) {
@@ -370,6 +401,89 @@ func TestUntarGzStream(t *testing.T) {
 	assert.Equal(t, "Hello, Stream!", dstBuffer.String())
 }
 
+// TestTarZst 测试TAR.ZST压缩和解压
+func TestTarZst(t *testing.T) {
+	tempDir := t.TempDir()
+	srcDir := filepath.Join(tempDir, "src")
+	tarZstFile := filepath.Join(tempDir, "test.tar.zst")
+	dstDir := filepath.Join(tempDir, "dst")
+
+	err := os.MkdirAll(filepath.Join(srcDir, "sub"), 0755)
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(srcDir, "file.txt"), []byte("Hello, World!"), 0644)
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(srcDir, "sub", "nested.txt"), []byte("nested"), 0644)
+	require.NoError(t, err)
+
+	err = TarZst(srcDir, tarZstFile)
+	require.NoError(t, err)
+
+	err = UntarZst(tarZstFile, dstDir)
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(dstDir, "file.txt"))
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello, World!", string(content))
+	content, err = os.ReadFile(filepath.Join(dstDir, "sub", "nested.txt"))
+	assert.NoError(t, err)
+	assert.Equal(t, "nested", string(content))
+
+	// 测试文件数量和大小限制
+	assert.Error(t, UntarZst(tarZstFile, filepath.Join(tempDir, "limit1"), WithMaxFiles(1)))
+	assert.Error(t, UntarZst(tarZstFile, filepath.Join(tempDir, "limit2"), WithMaxFileSize(5)))
+
+	// gzip格式的文件不能按zstd解压
+	tarGzFile := filepath.Join(tempDir, "test.tar.gz")
+	require.NoError(t, TarGz(srcDir, tarGzFile))
+	assert.Error(t, UntarZst(tarGzFile, filepath.Join(tempDir, "gz")))
+}
+
+// TestValidateSingleDirTarZst 测试验证TAR.ZST文件是否只包含一个顶层目录
+func TestValidateSingleDirTarZst(t *testing.T) {
+	tempDir := t.TempDir()
+
+	srcDir1 := filepath.Join(tempDir, "src1")
+	err := os.MkdirAll(filepath.Join(srcDir1, "mydir"), 0755)
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(srcDir1, "mydir", "file.txt"), []byte("test"), 0644)
+	require.NoError(t, err)
+
+	tarZstFile1 := filepath.Join(tempDir, "single_dir.tar.zst")
+	require.NoError(t, TarZst(srcDir1, tarZstFile1))
+	dirName, err := ValidateSingleDirTarZst(tarZstFile1)
+	assert.NoError(t, err)
+	assert.Equal(t, "mydir", dirName)
+
+	srcDir2 := filepath.Join(tempDir, "src2")
+	err = os.MkdirAll(srcDir2, 0755)
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(srcDir2, "file1.txt"), []byte("test1"), 0644)
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(srcDir2, "file2.txt"), []byte("test2"), 0644)
+	require.NoError(t, err)
+
+	tarZstFile2 := filepath.Join(tempDir, "multiple_entries.tar.zst")
+	require.NoError(t, TarZst(srcDir2, tarZstFile2))
+	dirName, err = ValidateSingleDirTarZst(tarZstFile2)
+	assert.Error(t, err)
+	assert.Empty(t, dirName)
+}
+
+// TestTarZstStream 测试TAR.ZST流式压缩和解压
+func TestTarZstStream(t *testing.T) {
+	var tarZstBuffer bytes.Buffer
+	err := TarZstStream(bytes.NewReader([]byte("Hello, Stream!")), &tarZstBuffer, "test.txt")
+	require.NoError(t, err)
+
+	var dstBuffer bytes.Buffer
+	err = UntarZstStream(bytes.NewReader(tarZstBuffer.Bytes()), &dstBuffer)
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello, Stream!", dstBuffer.String())
+
+	assert.Error(t, TarZstStream(nil, &dstBuffer, "test.txt"))
+	assert.Error(t, UntarZstStream(strings.NewReader("test"), &dstBuffer))
+}
+
 // TestArchiveOptions 测试压缩选项
 func TestArchiveOptions(t *testing.T) {
 	// 创建临时目录
@@ -442,6 +556,83 @@ func TestContextCancellation(t *testing.T) {
 	assert.Contains(t, err.Error(), "context canceled")
 }
 
+// assertLimitError 断言错误由指定的解压限制引起
+func assertLimitError(t *testing.T, err error, kind LimitKind) {
+	t.Helper()
+	var limitErr *LimitError
+	require.ErrorAs(t, err, &limitErr)
+	assert.Equal(t, kind, limitErr.Kind)
+	assert.True(t, IsLimitError(err))
+}
+
+// writeDeflateZip 写入只包含一个deflate压缩条目的zip文件
+func writeDeflateZip(t *testing.T, dst, name string, data []byte) {
+	t.Helper()
+	f, err := os.Create(dst)
+	require.NoError(t, err)
+	defer f.Close()
+	zw := zip.NewWriter(f)
+	w, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate})
+	require.NoError(t, err)
+	_, err = w.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+}
+
+// TestExtractionLimits 测试默认开启的解压限制
+func TestExtractionLimits(t *testing.T) {
+	formats := []ArchiveFormat{FormatZip, FormatTarGz, FormatTarZst}
+
+	for _, format := range formats {
+		t.Run(string(format), func(t *testing.T) {
+			tempDir := t.TempDir()
+			archiver, err := NewArchiver(format)
+			require.NoError(t, err)
+
+			// 压缩炸弹: 20MB的0压缩后只有几十KB
+			bombDir := filepath.Join(tempDir, "bomb")
+			require.NoError(t, os.MkdirAll(filepath.Join(bombDir, "pkg"), 0755))
+			require.NoError(t, os.WriteFile(filepath.Join(bombDir, "pkg", "zero.bin"), make([]byte, 20<<20), 0644))
+			bombFile := filepath.Join(tempDir, "bomb."+string(format))
+			if format == FormatZip {
+				// Zip 按存储方式写入不压缩, 这里手动构造deflate压缩的条目
+				writeDeflateZip(t, bombFile, "pkg/zero.bin", make([]byte, 20<<20))
+			} else {
+				require.NoError(t, archiver.Compress(bombDir, bombFile))
+			}
+
+			err = archiver.Decompress(bombFile, filepath.Join(tempDir, "bomb_dst"))
+			assertLimitError(t, err, LimitMaxCompressionRatio)
+
+			err = archiver.Decompress(bombFile, filepath.Join(tempDir, "bomb_ratio_off"), WithMaxCompressionRatio(0))
+			assert.NoError(t, err, "关闭压缩比限制后应该可以解压")
+
+			err = archiver.Decompress(bombFile, filepath.Join(tempDir, "bomb_total"), WithMaxTotalSize(1<<20))
+			assertLimitError(t, err, LimitMaxTotalSize)
+
+			// 目录深度和文件数量
+			deepDir := filepath.Join(tempDir, "deep")
+			nested := filepath.Join(deepDir, "a", "b", "c", "d")
+			require.NoError(t, os.MkdirAll(nested, 0755))
+			require.NoError(t, os.WriteFile(filepath.Join(nested, "file.txt"), []byte("deep"), 0644))
+			deepFile := filepath.Join(tempDir, "deep."+string(format))
+			require.NoError(t, archiver.Compress(deepDir, deepFile))
+
+			err = archiver.Decompress(deepFile, filepath.Join(tempDir, "deep_dst"), WithMaxDepth(3))
+			assertLimitError(t, err, LimitMaxDepth)
+
+			err = archiver.Decompress(deepFile, filepath.Join(tempDir, "files_dst"), WithMaxFiles(2))
+			assertLimitError(t, err, LimitMaxFiles)
+
+			_, err = archiver.ValidateSingleDir(deepFile, WithMaxDepth(3))
+			assertLimitError(t, err, LimitMaxDepth)
+
+			err = archiver.Decompress(deepFile, filepath.Join(tempDir, "deep_ok"))
+			assert.NoError(t, err)
+		})
+	}
+}
+
 // TestPathSecurity 测试路径安全性
 func TestPathSecurity(t *testing.T) {
 	// 创建临时目录
@@ -475,7 +666,7 @@ func TestPathSecurity(t *testing.T) {
 
 // TestArchiverCompressDecompress 测试压缩器接口的Compress和Decompress方法
 func TestArchiverCompressDecompress(t *testing.T) {
-	formats := []ArchiveFormat{FormatZip, FormatTarGz}
+	formats := []ArchiveFormat{FormatZip, FormatTarGz, FormatTarZst}
 
 	for _, format := range formats {
 		t.Run(string(format), func(t *testing.T) {
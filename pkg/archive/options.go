@@ -10,28 +10,34 @@ import (
 
 // ArchiveOptions 压缩/解压选项配置
 type ArchiveOptions struct {
-	Context          context.Context // 上下文用于控制操作取消和超时
-	MaxFileSize      int64           // 最大文件大小限制(字节)，0表示无限制
-	MaxFiles         int             // 最大文件数量限制，0表示无限制
-	ExcludePatterns  []string        // 排除文件模式列表
-	IncludeOnly      []string        // 包含文件模式列表
-	FollowSymlinks   bool            // 是否跟随符号链接
-	BufferSize       int             // 复制缓冲区大小(字节)
-	CompressionLevel int             // 压缩级别(0-9)，0表示无压缩
-	PermissionsMask  int             // 权限掩码，用于控制解压时的权限
-	Concurrency      int             // 并发处理数量，0表示不使用并发
+	Context             context.Context // 上下文用于控制操作取消和超时
+	MaxFileSize         int64           // 最大文件大小限制(字节)，0表示无限制
+	MaxFiles            int             // 最大文件数量限制，0表示无限制
+	MaxTotalSize        int64           // 解压总大小限制(字节)，0表示无限制
+	MaxCompressionRatio int             // 解压时最大压缩比(解压大小/压缩大小)，0表示无限制
+	MaxDepth            int             // 解压时最大目录深度，0表示无限制
+	ExcludePatterns     []string        // 排除文件模式列表
+	IncludeOnly         []string        // 包含文件模式列表
+	FollowSymlinks      bool            // 是否跟随符号链接
+	BufferSize          int             // 复制缓冲区大小(字节)
+	CompressionLevel    int             // 压缩级别(0-9)，0表示无压缩
+	PermissionsMask     int             // 权限掩码，用于控制解压时的权限
+	Concurrency         int             // 并发处理数量，0表示不使用并发
 }
 
 // DefaultArchiveOptions 默认压缩选项配置
 var DefaultArchiveOptions = ArchiveOptions{
-	Context:          context.Background(),
-	MaxFileSize:      100 << 20, // 100MB
-	MaxFiles:         10000,     // 10000个文件
-	BufferSize:       64 * 1024, // 64KB
-	FollowSymlinks:   false,
-	CompressionLevel: 6,    // 默认压缩级别
-	PermissionsMask:  0755, // 默认权限掩码
-	Concurrency:      0,    // 默认不使用并发
+	Context:             context.Background(),
+	MaxFileSize:         100 << 20, // 100MB
+	MaxFiles:            10000,     // 10000个文件
+	MaxTotalSize:        4 << 30,   // 4GB
+	MaxCompressionRatio: 100,       // 100:1
+	MaxDepth:            32,        // 32层目录
+	BufferSize:          64 * 1024, // 64KB
+	FollowSymlinks:      false,
+	CompressionLevel:    6,    // 默认压缩级别
+	PermissionsMask:     0755, // 默认权限掩码
+	Concurrency:         0,    // 默认不使用并发
 }
 
 // ArchiveOption 函数选项模式类型定义
@@ -64,6 +70,33 @@ func WithMaxFiles(count int) ArchiveOption {
 	}
 }
 
+// WithMaxTotalSize 设置解压总大小限制
+func WithMaxTotalSize(size int64) ArchiveOption {
+	return func(opts *ArchiveOptions) {
+		if size >= 0 {
+			opts.MaxTotalSize = size
+		}
+	}
+}
+
+// WithMaxCompressionRatio 设置解压时最大压缩比，用于拒绝压缩炸弹
+func WithMaxCompressionRatio(ratio int) ArchiveOption {
+	return func(opts *ArchiveOptions) {
+		if ratio >= 0 {
+			opts.MaxCompressionRatio = ratio
+		}
+	}
+}
+
+// WithMaxDepth 设置解压时最大目录深度
+func WithMaxDepth(depth int) ArchiveOption {
+	return func(opts *ArchiveOptions) {
+		if depth >= 0 {
+			opts.MaxDepth = depth
+		}
+	}
+}
+
 // WithBufferSize 设置复制缓冲区大小（增加合理范围校验）
 func WithBufferSize(size int) ArchiveOption {
 	return func(opts *ArchiveOptions) {
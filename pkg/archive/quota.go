@@ -0,0 +1,161 @@
+package archive
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"emperror.dev/errors"
+)
+
+// compressionRatioThreshold 解压总大小超过该值后才检查压缩比, 避免小文件因压缩率高被误判
+const compressionRatioThreshold = 10 << 20 // 10MB
+
+// LimitKind 压缩/解压限制类型
+type LimitKind string
+
+const (
+	LimitMaxFiles            LimitKind = "max_files"             // 文件数量
+	LimitMaxFileSize         LimitKind = "max_file_size"         // 单个文件大小
+	LimitMaxTotalSize        LimitKind = "max_total_size"        // 解压总大小
+	LimitMaxCompressionRatio LimitKind = "max_compression_ratio" // 压缩比
+	LimitMaxDepth            LimitKind = "max_depth"             // 目录深度
+)
+
+// LimitError 超过压缩/解压限制时返回的错误, 调用方可以通过 IsLimitError 或 errors.As 判断
+type LimitError struct {
+	Kind   LimitKind // 限制类型
+	Entry  string    // 触发限制的条目, 可能为空
+	Max    int64     // 限制值
+	Actual int64     // 实际值
+}
+
+func (e *LimitError) Error() string {
+	var desc string
+	switch e.Kind {
+	case LimitMaxFiles:
+		desc = "文件数量超过限制"
+	case LimitMaxFileSize:
+		desc = "文件大小超过限制"
+	case LimitMaxTotalSize:
+		desc = "解压总大小超过限制"
+	case LimitMaxCompressionRatio:
+		desc = "压缩比超过限制(疑似压缩炸弹)"
+	case LimitMaxDepth:
+		desc = "目录深度超过限制"
+	default:
+		desc = "超过限制"
+	}
+	if e.Entry != "" {
+		return fmt.Sprintf("%s, entry=%s, max=%d, current=%d", desc, e.Entry, e.Max, e.Actual)
+	}
+	return fmt.Sprintf("%s, max=%d, current=%d", desc, e.Max, e.Actual)
+}
+
+// IsLimitError 判断错误是否由压缩/解压限制引起
+func IsLimitError(err error) bool {
+	var limitErr *LimitError
+	return errors.As(err, &limitErr)
+}
+
+// extractQuota 解压配额, 在解压过程中累计检查条目数、目录深度、总大小和压缩比
+type extractQuota struct {
+	options        ArchiveOptions
+	compressedSize func() int64 // 已读取的压缩数据大小, 为nil时不检查压缩比
+	entries        int
+	declaredSize   int64 // 条目头中声明的大小之和
+	totalSize      int64 // 实际写入的大小之和
+}
+
+func newExtractQuota(options ArchiveOptions, compressedSize func() int64) *extractQuota {
+	return &extractQuota{
+		options:        options,
+		compressedSize: compressedSize,
+	}
+}
+
+// checkEntry 在解压条目前按条目头信息检查限制
+func (q *extractQuota) checkEntry(name string, declaredSize int64) error {
+	q.entries++
+	if q.options.MaxFiles > 0 && q.entries > q.options.MaxFiles {
+		return &LimitError{Kind: LimitMaxFiles, Entry: name, Max: int64(q.options.MaxFiles), Actual: int64(q.entries)}
+	}
+
+	if q.options.MaxDepth > 0 {
+		if depth := entryDepth(name); depth > q.options.MaxDepth {
+			return &LimitError{Kind: LimitMaxDepth, Entry: name, Max: int64(q.options.MaxDepth), Actual: int64(depth)}
+		}
+	}
+
+	if q.options.MaxFileSize > 0 && declaredSize > q.options.MaxFileSize {
+		return &LimitError{Kind: LimitMaxFileSize, Entry: name, Max: q.options.MaxFileSize, Actual: declaredSize}
+	}
+
+	// 声明的大小可能被伪造, 这里只用于提前拒绝, 实际写入时仍会再次检查
+	q.declaredSize += max(declaredSize, 0)
+	if q.options.MaxTotalSize > 0 && q.declaredSize > q.options.MaxTotalSize {
+		return &LimitError{Kind: LimitMaxTotalSize, Entry: name, Max: q.options.MaxTotalSize, Actual: q.declaredSize}
+	}
+	return nil
+}
+
+// add 累计实际写入的大小并检查总大小和压缩比
+func (q *extractQuota) add(name string, n int64) error {
+	q.totalSize += n
+	if q.options.MaxTotalSize > 0 && q.totalSize > q.options.MaxTotalSize {
+		return &LimitError{Kind: LimitMaxTotalSize, Entry: name, Max: q.options.MaxTotalSize, Actual: q.totalSize}
+	}
+
+	if q.options.MaxCompressionRatio > 0 && q.compressedSize != nil && q.totalSize > compressionRatioThreshold {
+		compressed := max(q.compressedSize(), 1)
+		if ratio := q.totalSize / compressed; ratio > int64(q.options.MaxCompressionRatio) {
+			return &LimitError{Kind: LimitMaxCompressionRatio, Entry: name, Max: int64(q.options.MaxCompressionRatio), Actual: ratio}
+		}
+	}
+	return nil
+}
+
+// writer 返回写入前检查配额的写入器
+func (q *extractQuota) writer(w io.Writer, name string) io.Writer {
+	return &quotaWriter{w: w, quota: q, name: name}
+}
+
+// quotaWriter 写入前检查解压配额, 超过限制时不写入数据
+type quotaWriter struct {
+	w     io.Writer
+	quota *extractQuota
+	name  string
+}
+
+func (w *quotaWriter) Write(p []byte) (int, error) {
+	if err := w.quota.add(w.name, int64(len(p))); err != nil {
+		return 0, err
+	}
+	return w.w.Write(p)
+}
+
+// countingReader 统计已读取的字节数, 用于计算流式解压的压缩比
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n += int64(n)
+	return n, err
+}
+
+func (r *countingReader) Count() int64 {
+	return r.n
+}
+
+// entryDepth 计算条目路径的层级数, 如 a/b/c.txt 为3
+func entryDepth(name string) int {
+	cleanName := strings.Trim(path.Clean(strings.ReplaceAll(name, "\\", "/")), "/")
+	if cleanName == "" || cleanName == "." {
+		return 0
+	}
+	return strings.Count(cleanName, "/") + 1
+}
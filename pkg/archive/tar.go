@@ -0,0 +1,345 @@
+package archive
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"emperror.dev/errors"
+)
+
+// tar.gz 与 tar.zst 共用的 tar 读写逻辑, 各格式只负责外层压缩流
+
+// writeTarEntries 将文件或目录写入tar流
+func writeTarEntries(cleanSrc string, srcInfo os.FileInfo, tarWriter *tar.Writer, options ArchiveOptions) error {
+	// 统一处理文件/目录
+	fileCount := 0
+	totalSize := int64(0)
+
+	var processErr error
+
+	if srcInfo.IsDir() {
+		processErr = filepath.Walk(cleanSrc, func(filePath string, info os.FileInfo, walkErr error) error {
+			if walkErr != nil {
+				return errors.Wrapf(walkErr, "遍历目录失败, filepath=%s", filePath)
+			}
+
+			// 安全检查：确保文件路径在源目录内
+			relPath, err := filepath.Rel(cleanSrc, filePath)
+			if err != nil {
+				return errors.Wrapf(err, "计算相对路径失败, base=%s, target=%s", cleanSrc, filePath)
+			}
+			if strings.HasPrefix(relPath, "..") {
+				return errors.Errorf("路径超出源目录范围: %s", filePath)
+			}
+
+			// 检查是否应该排除
+			exclude, err := options.ShouldExclude(filePath)
+			if err != nil {
+				return err
+			}
+			if exclude {
+				return nil
+			}
+
+			// 检查是否应该包含
+			include, err := options.ShouldInclude(filePath)
+			if err != nil {
+				return err
+			}
+			if !include {
+				return nil
+			}
+
+			entryErr := processTarEntry(filePath, cleanSrc, info, tarWriter, &fileCount, &totalSize, options)
+			if entryErr != nil {
+				return errors.Wrapf(entryErr, "处理文件条目失败, filepath=%s", filePath)
+			}
+			return nil
+		})
+	} else {
+		// 检查是否应该排除
+		exclude, err := options.ShouldExclude(cleanSrc)
+		if err != nil {
+			return err
+		}
+		if exclude {
+			return nil
+		}
+
+		// 检查是否应该包含
+		include, err := options.ShouldInclude(cleanSrc)
+		if err != nil {
+			return err
+		}
+		if !include {
+			return nil
+		}
+
+		// 处理单个文件
+		parentDir := filepath.Dir(cleanSrc)
+		processErr = processTarEntry(cleanSrc, parentDir, srcInfo, tarWriter, &fileCount, &totalSize, options)
+	}
+
+	return processErr
+}
+
+// processTarEntry 处理单个tar条目（解耦核心逻辑）
+func processTarEntry(filePath, baseDir string, info os.FileInfo, tarWriter *tar.Writer, fileCount *int, totalSize *int64, options ArchiveOptions) error {
+	// 上下文检查
+	if options.Context.Err() != nil {
+		return errors.Wrap(options.Context.Err(), "处理单个tar条目:上下文检查失败")
+	}
+
+	// 跳过基础目录
+	if filePath == baseDir {
+		return nil
+	}
+
+	// 文件数量限制
+	*fileCount++
+	if options.MaxFiles > 0 && *fileCount > options.MaxFiles {
+		return &LimitError{Kind: LimitMaxFiles, Entry: filePath, Max: int64(options.MaxFiles), Actual: int64(*fileCount)}
+	}
+
+	// 文件大小限制
+	if options.MaxFileSize > 0 && info.Size() > options.MaxFileSize {
+		return &LimitError{Kind: LimitMaxFileSize, Entry: filePath, Max: options.MaxFileSize, Actual: info.Size()}
+	}
+
+	// 创建tar头
+	relPath, err := filepath.Rel(baseDir, filePath)
+	if err != nil {
+		return errors.Wrapf(err, "计算相对路径失败, filepath=%s", filePath)
+	}
+
+	// 对于符号链接，获取目标路径
+	linkTarget := ""
+	if info.Mode()&os.ModeSymlink != 0 {
+		linkTarget, err = os.Readlink(filePath)
+		if err != nil {
+			return errors.Wrapf(err, "读取符号链接目标失败, filepath=%s", filePath)
+		}
+	}
+
+	header, err := tar.FileInfoHeader(info, linkTarget)
+	if err != nil {
+		return errors.Wrapf(err, "创建tar头失败, filepath=%s", filePath)
+	}
+	header.Name = relPath
+
+	// 写入tar头
+	if err := tarWriter.WriteHeader(header); err != nil {
+		return errors.Wrapf(err, "写入tar头失败, filepath=%s", filePath)
+	}
+
+	// 写入文件内容（仅普通文件）
+	if info.Mode().IsRegular() {
+		file, err := os.Open(filePath)
+		if err != nil {
+			return errors.Wrapf(err, "打开文件失败, filepath=%s", filePath)
+		}
+		defer closeWithError(file, "关闭文件失败")
+
+		written, err := safeCopy(options.Context, tarWriter, file, options.MaxFileSize, options.BufferSize)
+		if err != nil {
+			return errors.Wrapf(err, "复制文件内容失败, filepath=%s", filePath)
+		}
+
+		*totalSize += written
+	}
+
+	return nil
+}
+
+// extractTarEntries 遍历tar条目并解压到目标目录
+// 条目数、目录深度、总大小和压缩比由 quota 统一检查
+func extractTarEntries(tarReader *tar.Reader, dst string, quota *extractQuota, options ArchiveOptions) error {
+	for {
+		if options.Context.Err() != nil {
+			return errors.Wrap(options.Context.Err(), "解压遍历tar文件:上下文检查失败")
+		}
+
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return errors.Wrap(err, "读取tar条目失败")
+		}
+
+		if err := quota.checkEntry(header.Name, header.Size); err != nil {
+			return err
+		}
+
+		if _, err := processUntarEntry(header, tarReader, dst, quota, options); err != nil {
+			return errors.Wrapf(err, "处理tar条目失败, entry=%s", header.Name)
+		}
+	}
+
+	return nil
+}
+
+// processUntarEntry 处理单个解压条目（解耦核心逻辑）
+func processUntarEntry(header *tar.Header, tarReader *tar.Reader, dst string, quota *extractQuota, options ArchiveOptions) (int64, error) {
+	// 构造目标路径并检查安全性
+	target := filepath.Join(dst, header.Name)
+	if !isPathSafe(target, dst) {
+		return 0, errors.Errorf("非法路径（路径遍历攻击）, target=%s, base=%s", target, dst)
+	}
+
+	// 按类型处理
+	switch header.Typeflag {
+	case tar.TypeDir:
+		// 设置合适的目录权限
+		dirMode := os.FileMode(header.Mode)
+		if dirMode == 0 {
+			dirMode = 0755
+		}
+		// 应用权限掩码
+		dirMode = validatePermissions(dirMode, options.PermissionsMask)
+
+		if err := os.MkdirAll(target, dirMode); err != nil {
+			return 0, err
+		}
+		return 0, nil
+
+	case tar.TypeReg:
+		// 创建父目录
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return 0, errors.Wrap(err, "创建父目录失败")
+		}
+
+		// 写入文件
+		fileMode := os.FileMode(header.Mode)
+		if fileMode == 0 {
+			fileMode = 0644
+		}
+		// 清除特殊位以提高安全性
+		fileMode &= ^(os.ModeSetuid | os.ModeSetgid | os.ModeSticky)
+		// 应用权限掩码
+		fileMode = validatePermissions(fileMode, options.PermissionsMask)
+
+		file, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, fileMode)
+		if err != nil {
+			return 0, errors.Wrap(err, "创建目标文件失败")
+		}
+		defer closeWithError(file, "关闭目标文件失败")
+
+		written, err := safeCopy(options.Context, quota.writer(file, header.Name), tarReader, options.MaxFileSize, options.BufferSize)
+		if err != nil {
+			return written, err
+		}
+
+		return written, nil
+
+	case tar.TypeSymlink:
+		// 符号链接安全检查
+		if filepath.IsAbs(header.Linkname) {
+			return 0, errors.New("拒绝绝对路径符号链接")
+		}
+
+		linkTarget := filepath.Join(filepath.Dir(target), header.Linkname)
+		if !isPathSafe(linkTarget, dst) {
+			return 0, errors.New("符号链接指向基础目录外")
+		}
+
+		// 确保父目录存在
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return 0, errors.Wrap(err, "创建符号链接父目录失败")
+		}
+
+		if !options.FollowSymlinks {
+			if err := os.Symlink(header.Linkname, target); err != nil {
+				return 0, err
+			}
+			return 0, nil
+		}
+		return 0, errors.New("不允许跟随符号链接")
+
+	default:
+		// 忽略不支持的类型
+		return 0, nil
+	}
+}
+
+// validateSingleDirTar 校验tar流是否只包含一个顶层目录
+// 同时按条目头检查解压限制, 使超限的压缩包在上传校验时即被拒绝
+func validateSingleDirTar(tarReader *tar.Reader, options ArchiveOptions) (string, error) {
+	topLevelEntries := make(map[string]bool, 1) // 初始容量1，减少扩容
+	var firstDirName string
+	quota := newExtractQuota(options, nil)
+
+	for {
+		if options.Context.Err() != nil {
+			return "", errors.Wrap(options.Context.Err(), "遍历tar文件条目:上下文检查失败")
+		}
+
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", errors.Wrap(err, "读取tar条目失败")
+		}
+		if err := quota.checkEntry(header.Name, header.Size); err != nil {
+			return "", err
+		}
+
+		// 提取顶层目录
+		cleanName := filepath.Clean(header.Name)
+		cleanName = strings.TrimPrefix(cleanName, "/")
+		parts := strings.Split(cleanName, "/")
+		if len(parts) == 0 || parts[0] == "" {
+			continue
+		}
+		topLevelName := parts[0]
+
+		// 记录顶层目录
+		topLevelEntries[topLevelName] = true
+		if firstDirName == "" {
+			firstDirName = topLevelName
+		}
+
+		// 提前终止:超过1个顶层目录直接返回错误
+		if len(topLevelEntries) > 1 {
+			return "", createMultipleEntriesError(topLevelEntries)
+		}
+	}
+
+	// 结果校验
+	if len(topLevelEntries) == 0 {
+		return "", errors.New("压缩文件为空")
+	}
+	if len(topLevelEntries) > 1 {
+		return "", createMultipleEntriesError(topLevelEntries)
+	}
+
+	return firstDirName, nil
+}
+
+// writeTarStream 将流数据作为单个文件写入tar流
+func writeTarStream(tarWriter *tar.Writer, fileName string, buffer *bytes.Buffer) error {
+	// 创建文件头
+	header := &tar.Header{
+		Name:     fileName,
+		Size:     int64(buffer.Len()),
+		Mode:     0644,
+		Typeflag: tar.TypeReg,
+	}
+
+	// 写入tar头
+	if err := tarWriter.WriteHeader(header); err != nil {
+		return errors.Wrap(err, "写入tar头失败")
+	}
+
+	// 复制内容
+	if _, err := buffer.WriteTo(tarWriter); err != nil {
+		return errors.Wrap(err, "复制流内容失败")
+	}
+
+	return nil
+}
@@ -0,0 +1,237 @@
+package archive
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+
+	"emperror.dev/errors"
+	"github.com/klauspost/compress/zstd"
+)
+
+// newZstdWriter 按压缩级别创建zstd写入器, 级别沿用gzip的1-9语义并映射到zstd的级别
+func newZstdWriter(w io.Writer, level int) (*zstd.Encoder, error) {
+	return zstd.NewWriter(w, zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(level)))
+}
+
+// newZstdReader 创建zstd读取器, 单线程解码避免解压大文件时占用过多内存
+func newZstdReader(r io.Reader) (io.ReadCloser, error) {
+	decoder, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+	if err != nil {
+		return nil, err
+	}
+	return decoder.IOReadCloser(), nil
+}
+
+// TarZst 将指定路径的文件或目录压缩为 tar.zst 格式
+func TarZst(src, dst string, opts ...ArchiveOption) (resultErr error) {
+	options := applyOptions(opts...)
+
+	// 前置检查
+	if options.Context.Err() != nil {
+		return errors.Wrap(options.Context.Err(), "tar.zst压缩:上下文检查失败")
+	}
+	if src == "" || dst == "" {
+		return errors.New("源路径/目标路径不能为空")
+	}
+
+	// 路径安全检查，防止路径遍历攻击
+	cleanSrc := filepath.Clean(src)
+	cleanDst := filepath.Clean(dst)
+	if !filepath.IsAbs(cleanSrc) {
+		absSrc, err := filepath.Abs(cleanSrc)
+		if err != nil {
+			return errors.Wrapf(err, "获取源路径绝对路径失败, src=%s", cleanSrc)
+		}
+		cleanSrc = absSrc
+	}
+
+	srcInfo, err := os.Stat(cleanSrc)
+	if err != nil {
+		return errors.Wrapf(err, "获取源文件信息失败, src=%s", cleanSrc)
+	}
+
+	dstDir := filepath.Dir(cleanDst)
+	if err := os.MkdirAll(dstDir, 0755); err != nil {
+		return errors.Wrapf(err, "创建目标目录失败, dir=%s", dstDir)
+	}
+
+	dstFile, err := os.Create(cleanDst)
+	if err != nil {
+		return errors.Wrapf(err, "创建目标文件失败, dst=%s", cleanDst)
+	}
+
+	bufferedWriter := bufio.NewWriterSize(dstFile, options.BufferSize)
+	var closeErrors []error
+	var zstWriter *zstd.Encoder
+	var tarWriter *tar.Writer
+
+	// 资源清理函数, 关闭顺序与 TarGz 一致
+	defer func() {
+		if tarWriter != nil {
+			if closeErr := tarWriter.Close(); closeErr != nil {
+				closeErrors = append(closeErrors, errors.Wrap(closeErr, "关闭tar写入器失败"))
+			}
+		}
+		if zstWriter != nil {
+			if closeErr := zstWriter.Close(); closeErr != nil {
+				closeErrors = append(closeErrors, errors.Wrap(closeErr, "关闭zstd写入器失败"))
+			}
+		}
+		if flushErr := bufferedWriter.Flush(); flushErr != nil {
+			closeErrors = append(closeErrors, errors.Wrap(flushErr, "刷新缓冲区失败"))
+		}
+		if closeErr := dstFile.Close(); closeErr != nil {
+			closeErrors = append(closeErrors, errors.Wrap(closeErr, "关闭目标文件失败"))
+		}
+		if len(closeErrors) > 0 && resultErr == nil {
+			resultErr = closeErrors[0]
+		}
+	}()
+
+	zstWriter, err = newZstdWriter(bufferedWriter, options.CompressionLevel)
+	if err != nil {
+		return errors.Wrap(err, "创建zstd写入器失败")
+	}
+	tarWriter = tar.NewWriter(zstWriter)
+
+	if err := writeTarEntries(cleanSrc, srcInfo, tarWriter, options); err != nil {
+		return errors.Wrap(err, "tar.zst压缩失败")
+	}
+
+	return nil
+}
+
+// UntarZst 解压 tar.zst 文件到指定目录
+func UntarZst(src, dst string, opts ...ArchiveOption) error {
+	options := applyOptions(opts...)
+
+	// 前置检查
+	if options.Context.Err() != nil {
+		return errors.Wrap(options.Context.Err(), "tar.zst解压:上下文检查失败")
+	}
+	if src == "" || dst == "" {
+		return errors.New("源路径/目标路径不能为空")
+	}
+
+	srcFile, err := os.Open(src)
+	if err != nil {
+		return errors.Wrapf(err, "打开源文件失败, src=%s", src)
+	}
+	defer closeWithError(srcFile, "关闭源文件失败")
+
+	counter := &countingReader{r: srcFile}
+	zstReader, err := newZstdReader(counter)
+	if err != nil {
+		return errors.Wrapf(err, "创建zstd读取器失败, src=%s", src)
+	}
+	defer closeWithError(zstReader, "关闭zstd读取器失败")
+
+	if err := os.MkdirAll(dst, 0755); err != nil {
+		return errors.Wrapf(err, "创建目标目录失败, dst=%s", dst)
+	}
+
+	return extractTarEntries(tar.NewReader(zstReader), dst, newExtractQuota(options, counter.Count), options)
+}
+
+// ValidateSingleDirTarZst 校验 tar.zst 文件是否只包含一个顶层目录
+func ValidateSingleDirTarZst(src string, opts ...ArchiveOption) (string, error) {
+	options := applyOptions(opts...)
+
+	if options.Context.Err() != nil {
+		return "", errors.Wrap(options.Context.Err(), "校验 tar.zst 文件是否只包含一个顶层目录:上下文检查失败")
+	}
+
+	srcFile, err := os.Open(src)
+	if err != nil {
+		return "", errors.Wrapf(err, "打开源文件失败, src=%s", src)
+	}
+	defer closeWithError(srcFile, "关闭源文件失败")
+
+	zstReader, err := newZstdReader(srcFile)
+	if err != nil {
+		return "", errors.Wrapf(err, "创建zstd读取器失败, src=%s", src)
+	}
+	defer closeWithError(zstReader, "关闭zstd读取器失败")
+
+	return validateSingleDirTar(tar.NewReader(zstReader), options)
+}
+
+// TarZstStream 从流压缩到流
+func TarZstStream(src io.Reader, dst io.Writer, fileName string, opts ...ArchiveOption) error {
+	options := applyOptions(opts...)
+
+	// 前置检查
+	if options.Context.Err() != nil {
+		return errors.Wrap(options.Context.Err(), "tar.zst流压缩:上下文检查失败")
+	}
+	if src == nil || dst == nil {
+		return errors.New("源/目标流不能为空")
+	}
+	if fileName == "" {
+		fileName = "data"
+	}
+
+	// 对于tar格式，需要先读取所有数据以计算大小
+	var buffer bytes.Buffer
+	if _, err := safeCopy(options.Context, &buffer, src, options.MaxFileSize, options.BufferSize); err != nil {
+		return errors.Wrap(err, "读取流数据失败")
+	}
+
+	zstWriter, err := newZstdWriter(dst, options.CompressionLevel)
+	if err != nil {
+		return errors.Wrap(err, "创建zstd写入器失败")
+	}
+	tarWriter := tar.NewWriter(zstWriter)
+
+	defer func() {
+		closeWithError(tarWriter, "关闭tar写入器失败")
+		closeWithError(zstWriter, "关闭zstd写入器失败")
+	}()
+
+	return writeTarStream(tarWriter, fileName, &buffer)
+}
+
+// UntarZstStream 从流解压到流
+func UntarZstStream(src io.Reader, dst io.Writer, opts ...ArchiveOption) error {
+	options := applyOptions(opts...)
+
+	// 前置检查
+	if options.Context.Err() != nil {
+		return errors.Wrap(options.Context.Err(), "tar.zst流解压:上下文检查失败")
+	}
+	if src == nil || dst == nil {
+		return errors.New("源/目标流不能为空")
+	}
+
+	counter := &countingReader{r: src}
+	zstReader, err := newZstdReader(counter)
+	if err != nil {
+		return errors.Wrap(err, "创建zstd读取器失败")
+	}
+	defer closeWithError(zstReader, "关闭zstd读取器失败")
+
+	tarReader := tar.NewReader(zstReader)
+
+	// 只处理第一个文件
+	header, err := tarReader.Next()
+	if err == io.EOF {
+		return errors.New("tar.zst流为空")
+	}
+	if err != nil {
+		return errors.Wrap(err, "读取tar条目失败")
+	}
+	quota := newExtractQuota(options, counter.Count)
+	if err := quota.checkEntry(header.Name, header.Size); err != nil {
+		return err
+	}
+
+	if _, err := safeCopy(options.Context, quota.writer(dst, header.Name), tarReader, options.MaxFileSize, options.BufferSize); err != nil {
+		return errors.Wrap(err, "复制流内容失败")
+	}
+
+	return nil
+}
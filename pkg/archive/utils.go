@@ -71,7 +71,7 @@ func safeCopy(ctx context.Context, dst io.Writer, src io.Reader, maxSize int64,
 			// 大小限制检查（提前计算）
 			nextSize := written + int64(n)
 			if maxSize > 0 && nextSize > maxSize {
-				return written, errors.Errorf("文件大小超过限制, max=%d, current=%d", maxSize, nextSize)
+				return written, &LimitError{Kind: LimitMaxFileSize, Max: maxSize, Actual: nextSize}
 			}
 
 			// 写入数据
@@ -189,7 +189,7 @@ func processZipEntry(filePath, baseDir string, info os.FileInfo, zipWriter *zip.
 	// 文件数量限制
 	*fileCount++
 	if options.MaxFiles > 0 && *fileCount > options.MaxFiles {
-		return errors.Errorf("文件数量超过限制, max=%d, current=%d", options.MaxFiles, *fileCount)
+		return &LimitError{Kind: LimitMaxFiles, Entry: filePath, Max: int64(options.MaxFiles), Actual: int64(*fileCount)}
 	}
 
 	// 创建zip头
@@ -214,7 +214,7 @@ func processZipEntry(filePath, baseDir string, info os.FileInfo, zipWriter *zip.
 	if !info.IsDir() && info.Mode().IsRegular() {
 		// 大小限制
 		if options.MaxFileSize > 0 && info.Size() > options.MaxFileSize {
-			return errors.Errorf("文件大小超过限制, filepath=%s, max=%d, current=%d", filePath, options.MaxFileSize, info.Size())
+			return &LimitError{Kind: LimitMaxFileSize, Entry: filePath, Max: options.MaxFileSize, Actual: info.Size()}
 		}
 
 		// 读取并写入文件
@@ -253,7 +253,8 @@ func Unzip(src, dst string, opts ...ArchiveOption) error {
 	cleanDst := filepath.Clean(dst)
 
 	// 验证源文件是否存在
-	if _, err := os.Stat(cleanSrc); err != nil {
+	srcInfo, err := os.Stat(cleanSrc)
+	if err != nil {
 		return errors.WithMessagef(err, "源文件不存在或无法访问, src=%s", cleanSrc)
 	}
 
@@ -269,10 +270,8 @@ func Unzip(src, dst string, opts ...ArchiveOption) error {
 		return errors.WithMessagef(err, "创建目标目录失败, dst=%s", cleanDst)
 	}
 
-	// 处理条目
-	fileCount := 0
-	totalSize := int64(0)
-
+	// 处理条目, 压缩比按整个zip文件大小计算
+	quota := newExtractQuota(options, srcInfo.Size)
 	for i, file := range reader.File {
 		// 批量上下文检查（每100个条目检查一次，减少开销）
 		if i%100 == 0 {
@@ -281,24 +280,20 @@ func Unzip(src, dst string, opts ...ArchiveOption) error {
 			}
 		}
 
-		fileCount++
-		if options.MaxFiles > 0 && fileCount > options.MaxFiles {
-			return errors.Errorf("文件数量超过限制, max=%d, current=%d", options.MaxFiles, fileCount)
+		if err := quota.checkEntry(file.Name, int64(file.UncompressedSize64)); err != nil {
+			return err
 		}
 
-		entrySize, err := processUnzipEntry(file, cleanDst, options)
-		if err != nil {
+		if _, err := processUnzipEntry(file, cleanDst, quota, options); err != nil {
 			return errors.WithMessagef(err, "处理zip条目失败, entry=%s", file.Name)
 		}
-
-		totalSize += entrySize
 	}
 
 	return nil
 }
 
 // processUnzipEntry 处理单个解压条目（解耦核心逻辑）
-func processUnzipEntry(zipFile *zip.File, dst string, options ArchiveOptions) (int64, error) {
+func processUnzipEntry(zipFile *zip.File, dst string, quota *extractQuota, options ArchiveOptions) (int64, error) {
 	// 构造目标路径并检查安全性
 	target := filepath.Join(dst, filepath.FromSlash(zipFile.Name))
 
@@ -333,16 +328,12 @@ func processUnzipEntry(zipFile *zip.File, dst string, options ArchiveOptions) (i
 	}
 
 	// 处理文件
-	return unzipFile(zipFile, target, options)
+	return unzipFile(zipFile, target, quota, options)
 }
 
 // unzipFile 解压单个ZIP文件条目（优化资源释放）
-func unzipFile(zipFile *zip.File, target string, options ArchiveOptions) (int64, error) {
-	// 大小限制
-	if options.MaxFileSize > 0 && zipFile.FileInfo().Size() > options.MaxFileSize {
-		return 0, errors.Errorf("文件大小超过限制, filepath=%s, max=%d, current=%d", target, options.MaxFileSize, zipFile.FileInfo().Size())
-	}
-
+// 条目头中声明的大小可能被伪造, 写入时由 quota 按实际大小检查
+func unzipFile(zipFile *zip.File, target string, quota *extractQuota, options ArchiveOptions) (int64, error) {
 	// 创建父目录
 	parentDir := filepath.Dir(target)
 	if err := os.MkdirAll(parentDir, 0755); err != nil {
@@ -373,7 +364,7 @@ func unzipFile(zipFile *zip.File, target string, options ArchiveOptions) (int64,
 	defer closeWithError(targetFile, "关闭目标文件失败")
 
 	// 复制内容
-	written, err := safeCopy(options.Context, targetFile, srcFile, options.MaxFileSize, options.BufferSize)
+	written, err := safeCopy(options.Context, quota.writer(targetFile, zipFile.Name), srcFile, options.MaxFileSize, options.BufferSize)
 	if err != nil {
 		return written, errors.WithMessagef(err, "复制文件内容失败, target=%s", target)
 	}
@@ -400,6 +391,7 @@ func ValidateSingleDirZip(src string, opts ...ArchiveOption) (string, error) {
 	defer closeWithError(reader, "关闭zip读取器失败")
 
 	topLevelEntries := make(map[string]bool, 1) // 初始容量1
+	quota := newExtractQuota(options, nil)
 
 	for i, file := range reader.File {
 		// 批量上下文检查
@@ -409,6 +401,11 @@ func ValidateSingleDirZip(src string, opts ...ArchiveOption) (string, error) {
 			}
 		}
 
+		// 按条目头检查解压限制, 使超限的压缩包在上传校验时即被拒绝
+		if err := quota.checkEntry(file.Name, int64(file.UncompressedSize64)); err != nil {
+			return "", err
+		}
+
 		// 清理路径
 		name := filepath.Clean(file.Name)
 		name = strings.TrimPrefix(name, "./")
@@ -521,6 +518,10 @@ func UnzipStream(src io.Reader, dst io.Writer, opts ...ArchiveOption) error {
 	}
 
 	file := reader.File[0]
+	quota := newExtractQuota(options, func() int64 { return int64(len(buf)) })
+	if err := quota.checkEntry(file.Name, int64(file.UncompressedSize64)); err != nil {
+		return err
+	}
 
 	// 打开zip内文件
 	srcFile, err := file.Open()
@@ -530,6 +531,6 @@ func UnzipStream(src io.Reader, dst io.Writer, opts ...ArchiveOption) error {
 	defer closeWithError(srcFile, "关闭zip内文件失败")
 
 	// 复制内容
-	_, err = safeCopy(options.Context, dst, srcFile, options.MaxFileSize, options.BufferSize)
+	_, err = safeCopy(options.Context, quota.writer(dst, file.Name), srcFile, options.MaxFileSize, options.BufferSize)
 	return errors.WithMessage(err, "复制流内容失败")
 }
@@ -0,0 +1,111 @@
+// Package asciicast 写入asciinema v2格式的终端录像
+//
+// 文件第一行为JSON格式的头部, 之后每行为一个事件 [经过秒数, 事件类型, 数据],
+// 可以直接使用asciinema play或asciinema-player回放
+package asciicast
+
+import (
+	"encoding/json"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// 事件类型
+const (
+	EventOutput = "o" // 终端输出
+	EventInput  = "i" // 键盘输入
+	EventResize = "r" // 终端窗口大小变化, 数据格式为"列数x行数"
+)
+
+// Header 录像文件头部
+type Header struct {
+	Version   int               `json:"version"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Timestamp int64             `json:"timestamp,omitempty"`
+	Title     string            `json:"title,omitempty"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// Writer 录像写入器, 可以并发调用
+type Writer struct {
+	mu      sync.Mutex
+	w       io.Writer
+	start   time.Time
+	pending []byte // 末尾不完整的UTF-8字符, 与下一次输出合并写入
+}
+
+// NewWriter 写入头部并返回写入器, 事件时间从调用时开始计算
+func NewWriter(w io.Writer, header Header) (*Writer, error) {
+	start := time.Now()
+	header.Version = 2
+	if header.Timestamp == 0 {
+		header.Timestamp = start.Unix()
+	}
+	bs, err := json.Marshal(header)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(append(bs, '\n')); err != nil {
+		return nil, err
+	}
+	return &Writer{w: w, start: start}, nil
+}
+
+// Output 记录终端输出
+//
+// 终端输出可能在多字节字符中间被截断, 末尾不完整的字符留到下一次输出一起记录
+func (cw *Writer) Output(data []byte) error {
+	cw.mu.Lock()
+	defer cw.mu.Unlock()
+
+	if len(cw.pending) > 0 {
+		data = append(cw.pending, data...)
+		cw.pending = nil
+	}
+	n := completeLen(data)
+	if n < len(data) {
+		cw.pending = append([]byte(nil), data[n:]...)
+		data = data[:n]
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	return cw.write(EventOutput, string(data))
+}
+
+// Resize 记录终端窗口大小变化
+func (cw *Writer) Resize(width, height int) error {
+	cw.mu.Lock()
+	defer cw.mu.Unlock()
+	return cw.write(EventResize, strconv.Itoa(width)+"x"+strconv.Itoa(height))
+}
+
+func (cw *Writer) write(typ, data string) error {
+	elapsed := time.Since(cw.start).Seconds()
+	bs, err := json.Marshal([]any{json.Number(strconv.FormatFloat(elapsed, 'f', 6, 64)), typ, data})
+	if err != nil {
+		return err
+	}
+	_, err = cw.w.Write(append(bs, '\n'))
+	return err
+}
+
+// completeLen 返回data中不含末尾不完整UTF-8字符的长度
+func completeLen(data []byte) int {
+	// UTF-8字符最长4个字节, 只需检查末尾3个字节
+	for i := 1; i <= 3 && i <= len(data); i++ {
+		b := data[len(data)-i]
+		if !utf8.RuneStart(b) {
+			continue
+		}
+		if !utf8.FullRune(data[len(data)-i:]) {
+			return len(data) - i
+		}
+		break
+	}
+	return len(data)
+}
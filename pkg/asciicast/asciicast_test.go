@@ -0,0 +1,58 @@
+package asciicast
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, Header{Width: 80, Height: 24, Title: "host"})
+	if err != nil {
+		t.Fatalf("写入头部失败: %v", err)
+	}
+
+	// "中"的UTF-8编码被拆成两次输出
+	zh := []byte("中")
+	if err := w.Output(append([]byte("a"), zh[:1]...)); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Output(zh[1:]); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Resize(120, 40); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("期望4行, 实际%d行: %q", len(lines), buf.String())
+	}
+
+	var header Header
+	if err := json.Unmarshal([]byte(lines[0]), &header); err != nil {
+		t.Fatalf("解析头部失败: %v", err)
+	}
+	if header.Version != 2 || header.Width != 80 || header.Height != 24 || header.Timestamp == 0 {
+		t.Errorf("头部不正确: %+v", header)
+	}
+
+	want := [][2]string{{EventOutput, "a"}, {EventOutput, "中"}, {EventResize, "120x40"}}
+	for i, line := range lines[1:] {
+		var event []any
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			t.Fatalf("解析事件%d失败: %v", i, err)
+		}
+		if len(event) != 3 {
+			t.Fatalf("事件%d格式不正确: %s", i, line)
+		}
+		if _, ok := event[0].(float64); !ok {
+			t.Errorf("事件%d时间不是数字: %s", i, line)
+		}
+		if event[1] != want[i][0] || event[2] != want[i][1] {
+			t.Errorf("事件%d期望%v, 实际%s", i, want[i], line)
+		}
+	}
+}
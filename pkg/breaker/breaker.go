@@ -0,0 +1,296 @@
+// Package breaker 实现保护下游调用的熔断器
+//
+// 熔断器有关闭、打开、半开三种状态:
+//   - 关闭: 正常放行, 连续失败次数达到阈值后进入打开状态
+//   - 打开: 直接拒绝调用并返回ErrOpen, 持续一段时间后进入半开状态
+//   - 半开: 放行有限的试探调用, 全部成功后恢复关闭, 任意一次失败重新打开
+package breaker
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"net"
+	"sync"
+	"time"
+
+	"emperror.dev/errors"
+)
+
+// ErrOpen 熔断器处于打开状态或半开状态的试探名额已用完
+var ErrOpen = errors.New("熔断器已打开")
+
+// IsUnavailable 判断错误是否表示下游不可用, 即连接失败或超时, 可作为Settings.IsFailure
+//
+// 调用方主动取消不计为下游不可用
+func IsUnavailable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, sql.ErrConnDone) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// State 熔断器状态
+type State int
+
+const (
+	StateClosed   State = iota // 关闭
+	StateHalfOpen              // 半开
+	StateOpen                  // 打开
+)
+
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateHalfOpen:
+		return "half_open"
+	case StateOpen:
+		return "open"
+	default:
+		return "unknown"
+	}
+}
+
+// Settings 熔断器配置
+type Settings struct {
+	// FailureThreshold 连续失败多少次后打开, 小于等于0时为5
+	FailureThreshold int
+
+	// OpenTimeout 打开状态持续时间, 之后进入半开状态, 小于等于0时为30秒
+	OpenTimeout time.Duration
+
+	// HalfOpenRequests 半开状态放行的试探调用数, 全部成功后关闭, 小于等于0时为1
+	HalfOpenRequests int
+
+	// IsFailure 判断调用结果是否计为失败, 为空时所有非nil错误都计为失败
+	// 业务错误(如记录不存在)不代表下游异常, 不应计为失败
+	IsFailure func(err error) bool
+
+	// OnStateChange 状态变化时调用, 在持有锁时调用, 不能阻塞
+	OnStateChange func(name string, from, to State)
+
+	// OnReject 调用被拒绝时调用
+	OnReject func(name string)
+}
+
+func (s Settings) withDefaults() Settings {
+	if s.FailureThreshold <= 0 {
+		s.FailureThreshold = 5
+	}
+	if s.OpenTimeout <= 0 {
+		s.OpenTimeout = 30 * time.Second
+	}
+	if s.HalfOpenRequests <= 0 {
+		s.HalfOpenRequests = 1
+	}
+	if s.IsFailure == nil {
+		s.IsFailure = func(err error) bool { return err != nil }
+	}
+	return s
+}
+
+// Breaker 熔断器, nil熔断器放行所有调用
+type Breaker struct {
+	name     string
+	settings Settings
+	now      func() time.Time
+
+	mu         sync.Mutex
+	state      State
+	generation uint64 // 每次状态变化加1, 用于丢弃旧状态下发起的调用结果
+	failures   int    // 关闭状态的连续失败次数
+	inFlight   int    // 半开状态已放行的试探调用数
+	successes  int    // 半开状态成功的试探调用数
+	openedAt   time.Time
+}
+
+// New 创建熔断器
+func New(name string, s Settings) *Breaker {
+	return &Breaker{
+		name:     name,
+		settings: s.withDefaults(),
+		now:      time.Now,
+	}
+}
+
+// Name 熔断器名称
+func (b *Breaker) Name() string {
+	if b == nil {
+		return ""
+	}
+	return b.name
+}
+
+// State 当前状态, 打开状态超时后返回半开
+func (b *Breaker) State() State {
+	if b == nil {
+		return StateClosed
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refresh()
+	return b.state
+}
+
+// Allow 申请一次调用, 返回的done必须在调用结束后以调用结果执行一次
+//
+// 熔断器拒绝时返回ErrOpen
+func (b *Breaker) Allow() (done func(err error), err error) {
+	if b == nil {
+		return func(error) {}, nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.refresh()
+	switch b.state {
+	case StateOpen:
+		b.reject()
+		return nil, ErrOpen
+	case StateHalfOpen:
+		if b.inFlight >= b.settings.HalfOpenRequests {
+			b.reject()
+			return nil, ErrOpen
+		}
+		b.inFlight++
+	}
+
+	generation := b.generation
+	var once sync.Once
+	return func(err error) {
+		once.Do(func() { b.done(generation, err) })
+	}, nil
+}
+
+// Execute 通过熔断器执行fn, 熔断器拒绝时不执行fn并返回ErrOpen
+func (b *Breaker) Execute(fn func() error) error {
+	done, err := b.Allow()
+	if err != nil {
+		return err
+	}
+	err = fn()
+	done(err)
+	return err
+}
+
+func (b *Breaker) done(generation uint64, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// 调用期间状态已变化, 结果不再影响当前状态
+	if generation != b.generation {
+		return
+	}
+
+	failed := b.settings.IsFailure(err)
+	switch b.state {
+	case StateClosed:
+		if !failed {
+			b.failures = 0
+			return
+		}
+		b.failures++
+		if b.failures >= b.settings.FailureThreshold {
+			b.setState(StateOpen)
+		}
+	case StateHalfOpen:
+		if failed {
+			b.setState(StateOpen)
+			return
+		}
+		b.successes++
+		if b.successes >= b.settings.HalfOpenRequests {
+			b.setState(StateClosed)
+		}
+	}
+}
+
+// refresh 打开状态超时后进入半开, 调用方需持有锁
+func (b *Breaker) refresh() {
+	if b.state == StateOpen && b.now().Sub(b.openedAt) >= b.settings.OpenTimeout {
+		b.setState(StateHalfOpen)
+	}
+}
+
+func (b *Breaker) setState(to State) {
+	from := b.state
+	if from == to {
+		return
+	}
+	b.state = to
+	b.generation++
+	b.failures = 0
+	b.inFlight = 0
+	b.successes = 0
+	if to == StateOpen {
+		b.openedAt = b.now()
+	}
+	if b.settings.OnStateChange != nil {
+		b.settings.OnStateChange(b.name, from, to)
+	}
+}
+
+func (b *Breaker) reject() {
+	if b.settings.OnReject != nil {
+		b.settings.OnReject(b.name)
+	}
+}
+
+// Group 按名称懒创建的一组熔断器, 如每个SSH主机一个熔断器
+//
+// nil分组返回nil熔断器, 即不启用熔断
+type Group struct {
+	settings Settings
+
+	mu       sync.Mutex
+	breakers map[string]*Breaker
+}
+
+// NewGroup 创建熔断器分组, 分组内的熔断器使用相同配置
+func NewGroup(s Settings) *Group {
+	return &Group{
+		settings: s,
+		breakers: make(map[string]*Breaker),
+	}
+}
+
+// Get 获取指定名称的熔断器, 不存在时创建
+func (g *Group) Get(name string) *Breaker {
+	if g == nil {
+		return nil
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	b, ok := g.breakers[name]
+	if !ok {
+		b = New(name, g.settings)
+		g.breakers[name] = b
+	}
+	return b
+}
+
+// States 分组内所有熔断器的当前状态
+func (g *Group) States() map[string]State {
+	if g == nil {
+		return nil
+	}
+	g.mu.Lock()
+	breakers := make([]*Breaker, 0, len(g.breakers))
+	for _, b := range g.breakers {
+		breakers = append(breakers, b)
+	}
+	g.mu.Unlock()
+
+	states := make(map[string]State, len(breakers))
+	for _, b := range breakers {
+		states[b.name] = b.State()
+	}
+	return states
+}
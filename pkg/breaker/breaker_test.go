@@ -0,0 +1,197 @@
+package breaker
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+)
+
+var errDown = errors.New("down")
+
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time { return c.t }
+
+func newTestBreaker(s Settings) (*Breaker, *fakeClock) {
+	clock := &fakeClock{t: time.Unix(0, 0)}
+	b := New("test", s)
+	b.now = clock.now
+	return b, clock
+}
+
+func TestOpenAfterThreshold(t *testing.T) {
+	var rejected int
+	b, _ := newTestBreaker(Settings{
+		FailureThreshold: 3,
+		OnReject:         func(string) { rejected++ },
+	})
+
+	_ = b.Execute(func() error { return errDown })
+	_ = b.Execute(func() error { return errDown })
+	_ = b.Execute(func() error { return nil }) // 成功后重新计数
+	_ = b.Execute(func() error { return errDown })
+	_ = b.Execute(func() error { return errDown })
+	if b.State() != StateClosed {
+		t.Fatalf("连续失败未达到阈值时应该保持关闭, state=%s", b.State())
+	}
+
+	_ = b.Execute(func() error { return errDown })
+	if b.State() != StateOpen {
+		t.Fatalf("连续失败达到阈值后应该打开, state=%s", b.State())
+	}
+
+	called := false
+	err := b.Execute(func() error { called = true; return nil })
+	if !errors.Is(err, ErrOpen) || called {
+		t.Errorf("打开状态应该拒绝调用, err=%v called=%v", err, called)
+	}
+	if rejected != 1 {
+		t.Errorf("rejected = %d", rejected)
+	}
+}
+
+func TestHalfOpenRecovery(t *testing.T) {
+	var transitions []string
+	b, clock := newTestBreaker(Settings{
+		FailureThreshold: 1,
+		OpenTimeout:      time.Minute,
+		HalfOpenRequests: 2,
+		OnStateChange: func(_ string, from, to State) {
+			transitions = append(transitions, from.String()+"->"+to.String())
+		},
+	})
+
+	_ = b.Execute(func() error { return errDown })
+	clock.t = clock.t.Add(59 * time.Second)
+	if b.State() != StateOpen {
+		t.Fatalf("未到打开持续时间时应该保持打开, state=%s", b.State())
+	}
+	clock.t = clock.t.Add(time.Second)
+	if b.State() != StateHalfOpen {
+		t.Fatalf("到达打开持续时间后应该半开, state=%s", b.State())
+	}
+
+	done1, err := b.Allow()
+	if err != nil {
+		t.Fatalf("半开状态应该放行试探调用: %v", err)
+	}
+	done2, err := b.Allow()
+	if err != nil {
+		t.Fatalf("半开状态应该放行试探调用: %v", err)
+	}
+	if _, err := b.Allow(); !errors.Is(err, ErrOpen) {
+		t.Fatalf("试探名额用完后应该拒绝, err=%v", err)
+	}
+
+	done1(nil)
+	done1(errDown) // 重复调用不生效
+	if b.State() != StateHalfOpen {
+		t.Fatalf("试探调用未全部成功时应该保持半开, state=%s", b.State())
+	}
+	done2(nil)
+	if b.State() != StateClosed {
+		t.Fatalf("试探调用全部成功后应该关闭, state=%s", b.State())
+	}
+
+	want := []string{"closed->open", "open->half_open", "half_open->closed"}
+	if len(transitions) != len(want) {
+		t.Fatalf("transitions = %v", transitions)
+	}
+	for i := range want {
+		if transitions[i] != want[i] {
+			t.Errorf("transitions = %v, want %v", transitions, want)
+		}
+	}
+}
+
+func TestHalfOpenFailure(t *testing.T) {
+	b, clock := newTestBreaker(Settings{FailureThreshold: 1, OpenTimeout: time.Second})
+
+	_ = b.Execute(func() error { return errDown })
+	clock.t = clock.t.Add(time.Second)
+	_ = b.Execute(func() error { return errDown })
+	if b.State() != StateOpen {
+		t.Fatalf("试探调用失败后应该重新打开, state=%s", b.State())
+	}
+	clock.t = clock.t.Add(500 * time.Millisecond)
+	if b.State() != StateOpen {
+		t.Errorf("重新打开后应该重新计时, state=%s", b.State())
+	}
+}
+
+func TestStaleResult(t *testing.T) {
+	b, _ := newTestBreaker(Settings{FailureThreshold: 1})
+
+	slow, _ := b.Allow()
+	_ = b.Execute(func() error { return errDown })
+	// 打开前发起的调用成功不应该关闭熔断器
+	slow(nil)
+	if b.State() != StateOpen {
+		t.Errorf("旧状态的调用结果不应该影响当前状态, state=%s", b.State())
+	}
+}
+
+func TestIsFailure(t *testing.T) {
+	errNotFound := errors.New("not found")
+	b, _ := newTestBreaker(Settings{
+		FailureThreshold: 1,
+		IsFailure:        func(err error) bool { return err != nil && err != errNotFound },
+	})
+	_ = b.Execute(func() error { return errNotFound })
+	if b.State() != StateClosed {
+		t.Errorf("不计为失败的错误不应该打开熔断器, state=%s", b.State())
+	}
+}
+
+func TestNil(t *testing.T) {
+	var g *Group
+	b := g.Get("x")
+	if b != nil {
+		t.Fatal("nil分组应该返回nil熔断器")
+	}
+	called := false
+	if err := b.Execute(func() error { called = true; return nil }); err != nil || !called {
+		t.Errorf("nil熔断器应该放行调用, err=%v", err)
+	}
+	if b.State() != StateClosed {
+		t.Errorf("nil熔断器应该处于关闭状态")
+	}
+}
+
+func TestGroup(t *testing.T) {
+	g := NewGroup(Settings{FailureThreshold: 1})
+	if g.Get("a") != g.Get("a") {
+		t.Fatal("同名熔断器应该复用")
+	}
+	_ = g.Get("a").Execute(func() error { return errDown })
+	_ = g.Get("b").Execute(func() error { return nil })
+
+	states := g.States()
+	if states["a"] != StateOpen || states["b"] != StateClosed {
+		t.Errorf("States() = %v", states)
+	}
+}
+
+func TestIsUnavailable(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{errDown, false},
+		{context.Canceled, false},
+		{fmt.Errorf("query: %w", context.DeadlineExceeded), true},
+		{driver.ErrBadConn, true},
+		{&net.OpError{Op: "dial", Net: "tcp", Err: errDown}, true},
+		{&net.OpError{Op: "dial", Net: "tcp", Err: context.Canceled}, false},
+	}
+	for _, tt := range tests {
+		if got := IsUnavailable(tt.err); got != tt.want {
+			t.Errorf("IsUnavailable(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package captcha
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+	"time"
+)
+
+func TestNew(t *testing.T) {
+	c, err := New(4)
+	if err != nil {
+		t.Fatalf("New() err = %v", err)
+	}
+	if len(c.Answer) != 4 || c.ID == "" {
+		t.Fatalf("New() = %+v", c)
+	}
+	for _, r := range c.Answer {
+		if r < '0' || r > '9' {
+			t.Errorf("答案应该只包含数字: %q", c.Answer)
+		}
+	}
+	img, err := png.Decode(bytes.NewReader(c.Image))
+	if err != nil {
+		t.Fatalf("图片应该是有效的PNG: %v", err)
+	}
+	if b := img.Bounds(); b.Dx() != Width || b.Dy() != Height {
+		t.Errorf("图片尺寸 = %v", b)
+	}
+
+	for _, n := range []int{0, 5} {
+		if _, err := New(n); err == nil {
+			t.Errorf("New(%d) 应该返回错误", n)
+		}
+	}
+}
+
+func TestMemoryStore(t *testing.T) {
+	s := NewMemoryStore(time.Minute)
+	_ = s.Set("a", "1234", time.Minute)
+	_ = s.Set("b", "5678", time.Minute)
+	_ = s.Set("c", "0000", time.Millisecond)
+
+	if ok, _ := s.Verify("a", "1234"); !ok {
+		t.Error("答案正确时应该校验通过")
+	}
+	if ok, _ := s.Verify("a", "1234"); ok {
+		t.Error("挑战只能使用一次")
+	}
+	if ok, _ := s.Verify("b", "0000"); ok {
+		t.Error("答案错误时应该校验失败")
+	}
+	if ok, _ := s.Verify("b", "5678"); ok {
+		t.Error("答案错误后挑战应该失效")
+	}
+	time.Sleep(5 * time.Millisecond)
+	if ok, _ := s.Verify("c", "0000"); ok {
+		t.Error("过期的挑战应该校验失败")
+	}
+}
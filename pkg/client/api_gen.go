@@ -0,0 +1,14678 @@
+// Code generated by sdkgen. DO NOT EDIT.
+
+package client
+
+import (
+	"context"
+	"io"
+	"net/url"
+)
+
+// AuthCasbinSample 对应auth.CasbinSample
+type AuthCasbinSample struct {
+	Act    string `json:"act"`
+	Expect bool   `json:"expect,omitempty"`
+	Obj    string `json:"obj"`
+	Sub    string `json:"sub"`
+}
+
+// AuthCasbinSampleResult 对应auth.CasbinSampleResult
+type AuthCasbinSampleResult struct {
+	Act    string `json:"act"`
+	Actual bool   `json:"actual,omitempty"`
+	Expect bool   `json:"expect,omitempty"`
+	Obj    string `json:"obj"`
+	Passed bool   `json:"passed,omitempty"`
+	Sub    string `json:"sub"`
+}
+
+// CommonMapAPIReply 对应common.MapAPIReply
+type CommonMapAPIReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data map[string]any `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// CommonPagCustomerApiStandardOut 对应common.Pag-customer_ApiStandardOut
+type CommonPagCustomerApiStandardOut struct {
+	// 是否有下一页
+	// Example: true
+	HasNext bool `json:"has_next,omitempty"`
+	// 对象数组
+	Items []*CustomerApiStandardOut `json:"items,omitempty"`
+	// 当前页码
+	// Example: 1
+	Page int64 `json:"page,omitempty"`
+	// 总页数, 请求with_total=false跳过总数时不返回
+	// Example: 10
+	Pages int64 `json:"pages,omitempty"`
+	// 每页数量
+	// Example: 10
+	Size int64 `json:"size,omitempty"`
+	// 总记录数, 请求with_total=false跳过总数时不返回
+	// Example: 100
+	Total int64 `json:"total,omitempty"`
+}
+
+// CommonPagCustomerButtonStandardOut 对应common.Pag-customer_ButtonStandardOut
+type CommonPagCustomerButtonStandardOut struct {
+	// 是否有下一页
+	// Example: true
+	HasNext bool `json:"has_next,omitempty"`
+	// 对象数组
+	Items []*CustomerButtonStandardOut `json:"items,omitempty"`
+	// 当前页码
+	// Example: 1
+	Page int64 `json:"page,omitempty"`
+	// 总页数, 请求with_total=false跳过总数时不返回
+	// Example: 10
+	Pages int64 `json:"pages,omitempty"`
+	// 每页数量
+	// Example: 10
+	Size int64 `json:"size,omitempty"`
+	// 总记录数, 请求with_total=false跳过总数时不返回
+	// Example: 100
+	Total int64 `json:"total,omitempty"`
+}
+
+// CommonPagCustomerCasbinModelOut 对应common.Pag-customer_CasbinModelOut
+type CommonPagCustomerCasbinModelOut struct {
+	// 是否有下一页
+	// Example: true
+	HasNext bool `json:"has_next,omitempty"`
+	// 对象数组
+	Items []*CustomerCasbinModelOut `json:"items,omitempty"`
+	// 当前页码
+	// Example: 1
+	Page int64 `json:"page,omitempty"`
+	// 总页数, 请求with_total=false跳过总数时不返回
+	// Example: 10
+	Pages int64 `json:"pages,omitempty"`
+	// 每页数量
+	// Example: 10
+	Size int64 `json:"size,omitempty"`
+	// 总记录数, 请求with_total=false跳过总数时不返回
+	// Example: 100
+	Total int64 `json:"total,omitempty"`
+}
+
+// CommonPagCustomerDepartmentStandardOut 对应common.Pag-customer_DepartmentStandardOut
+type CommonPagCustomerDepartmentStandardOut struct {
+	// 是否有下一页
+	// Example: true
+	HasNext bool `json:"has_next,omitempty"`
+	// 对象数组
+	Items []*CustomerDepartmentStandardOut `json:"items,omitempty"`
+	// 当前页码
+	// Example: 1
+	Page int64 `json:"page,omitempty"`
+	// 总页数, 请求with_total=false跳过总数时不返回
+	// Example: 10
+	Pages int64 `json:"pages,omitempty"`
+	// 每页数量
+	// Example: 10
+	Size int64 `json:"size,omitempty"`
+	// 总记录数, 请求with_total=false跳过总数时不返回
+	// Example: 100
+	Total int64 `json:"total,omitempty"`
+}
+
+// CommonPagCustomerLoginRecordStandardOut 对应common.Pag-customer_LoginRecordStandardOut
+type CommonPagCustomerLoginRecordStandardOut struct {
+	// 是否有下一页
+	// Example: true
+	HasNext bool `json:"has_next,omitempty"`
+	// 对象数组
+	Items []*CustomerLoginRecordStandardOut `json:"items,omitempty"`
+	// 当前页码
+	// Example: 1
+	Page int64 `json:"page,omitempty"`
+	// 总页数, 请求with_total=false跳过总数时不返回
+	// Example: 10
+	Pages int64 `json:"pages,omitempty"`
+	// 每页数量
+	// Example: 10
+	Size int64 `json:"size,omitempty"`
+	// 总记录数, 请求with_total=false跳过总数时不返回
+	// Example: 100
+	Total int64 `json:"total,omitempty"`
+}
+
+// CommonPagCustomerMenuStandardOut 对应common.Pag-customer_MenuStandardOut
+type CommonPagCustomerMenuStandardOut struct {
+	// 是否有下一页
+	// Example: true
+	HasNext bool `json:"has_next,omitempty"`
+	// 对象数组
+	Items []*CustomerMenuStandardOut `json:"items,omitempty"`
+	// 当前页码
+	// Example: 1
+	Page int64 `json:"page,omitempty"`
+	// 总页数, 请求with_total=false跳过总数时不返回
+	// Example: 10
+	Pages int64 `json:"pages,omitempty"`
+	// 每页数量
+	// Example: 10
+	Size int64 `json:"size,omitempty"`
+	// 总记录数, 请求with_total=false跳过总数时不返回
+	// Example: 100
+	Total int64 `json:"total,omitempty"`
+}
+
+// CommonPagCustomerRoleStandardOut 对应common.Pag-customer_RoleStandardOut
+type CommonPagCustomerRoleStandardOut struct {
+	// 是否有下一页
+	// Example: true
+	HasNext bool `json:"has_next,omitempty"`
+	// 对象数组
+	Items []*CustomerRoleStandardOut `json:"items,omitempty"`
+	// 当前页码
+	// Example: 1
+	Page int64 `json:"page,omitempty"`
+	// 总页数, 请求with_total=false跳过总数时不返回
+	// Example: 10
+	Pages int64 `json:"pages,omitempty"`
+	// 每页数量
+	// Example: 10
+	Size int64 `json:"size,omitempty"`
+	// 总记录数, 请求with_total=false跳过总数时不返回
+	// Example: 100
+	Total int64 `json:"total,omitempty"`
+}
+
+// CommonPagCustomerSigningKeyOut 对应common.Pag-customer_SigningKeyOut
+type CommonPagCustomerSigningKeyOut struct {
+	// 是否有下一页
+	// Example: true
+	HasNext bool `json:"has_next,omitempty"`
+	// 对象数组
+	Items []*CustomerSigningKeyOut `json:"items,omitempty"`
+	// 当前页码
+	// Example: 1
+	Page int64 `json:"page,omitempty"`
+	// 总页数, 请求with_total=false跳过总数时不返回
+	// Example: 10
+	Pages int64 `json:"pages,omitempty"`
+	// 每页数量
+	// Example: 10
+	Size int64 `json:"size,omitempty"`
+	// 总记录数, 请求with_total=false跳过总数时不返回
+	// Example: 100
+	Total int64 `json:"total,omitempty"`
+}
+
+// CommonPagCustomerUserDetailOut 对应common.Pag-customer_UserDetailOut
+type CommonPagCustomerUserDetailOut struct {
+	// 是否有下一页
+	// Example: true
+	HasNext bool `json:"has_next,omitempty"`
+	// 对象数组
+	Items []*CustomerUserDetailOut `json:"items,omitempty"`
+	// 当前页码
+	// Example: 1
+	Page int64 `json:"page,omitempty"`
+	// 总页数, 请求with_total=false跳过总数时不返回
+	// Example: 10
+	Pages int64 `json:"pages,omitempty"`
+	// 每页数量
+	// Example: 10
+	Size int64 `json:"size,omitempty"`
+	// 总记录数, 请求with_total=false跳过总数时不返回
+	// Example: 100
+	Total int64 `json:"total,omitempty"`
+}
+
+// CommonPagCustomerUserGroupStandardOut 对应common.Pag-customer_UserGroupStandardOut
+type CommonPagCustomerUserGroupStandardOut struct {
+	// 是否有下一页
+	// Example: true
+	HasNext bool `json:"has_next,omitempty"`
+	// 对象数组
+	Items []*CustomerUserGroupStandardOut `json:"items,omitempty"`
+	// 当前页码
+	// Example: 1
+	Page int64 `json:"page,omitempty"`
+	// 总页数, 请求with_total=false跳过总数时不返回
+	// Example: 10
+	Pages int64 `json:"pages,omitempty"`
+	// 每页数量
+	// Example: 10
+	Size int64 `json:"size,omitempty"`
+	// 总记录数, 请求with_total=false跳过总数时不返回
+	// Example: 100
+	Total int64 `json:"total,omitempty"`
+}
+
+// CommonPagJobsScheduleDetailOut 对应common.Pag-jobs_ScheduleDetailOut
+type CommonPagJobsScheduleDetailOut struct {
+	// 是否有下一页
+	// Example: true
+	HasNext bool `json:"has_next,omitempty"`
+	// 对象数组
+	Items []*JobsScheduleDetailOut `json:"items,omitempty"`
+	// 当前页码
+	// Example: 1
+	Page int64 `json:"page,omitempty"`
+	// 总页数, 请求with_total=false跳过总数时不返回
+	// Example: 10
+	Pages int64 `json:"pages,omitempty"`
+	// 每页数量
+	// Example: 10
+	Size int64 `json:"size,omitempty"`
+	// 总记录数, 请求with_total=false跳过总数时不返回
+	// Example: 100
+	Total int64 `json:"total,omitempty"`
+}
+
+// CommonPagJobsScheduleSkipOut 对应common.Pag-jobs_ScheduleSkipOut
+type CommonPagJobsScheduleSkipOut struct {
+	// 是否有下一页
+	// Example: true
+	HasNext bool `json:"has_next,omitempty"`
+	// 对象数组
+	Items []*JobsScheduleSkipOut `json:"items,omitempty"`
+	// 当前页码
+	// Example: 1
+	Page int64 `json:"page,omitempty"`
+	// 总页数, 请求with_total=false跳过总数时不返回
+	// Example: 10
+	Pages int64 `json:"pages,omitempty"`
+	// 每页数量
+	// Example: 10
+	Size int64 `json:"size,omitempty"`
+	// 总记录数, 请求with_total=false跳过总数时不返回
+	// Example: 100
+	Total int64 `json:"total,omitempty"`
+}
+
+// CommonPagJobsScriptRecordDetailOut 对应common.Pag-jobs_ScriptRecordDetailOut
+type CommonPagJobsScriptRecordDetailOut struct {
+	// 是否有下一页
+	// Example: true
+	HasNext bool `json:"has_next,omitempty"`
+	// 对象数组
+	Items []*JobsScriptRecordDetailOut `json:"items,omitempty"`
+	// 当前页码
+	// Example: 1
+	Page int64 `json:"page,omitempty"`
+	// 总页数, 请求with_total=false跳过总数时不返回
+	// Example: 10
+	Pages int64 `json:"pages,omitempty"`
+	// 每页数量
+	// Example: 10
+	Size int64 `json:"size,omitempty"`
+	// 总记录数, 请求with_total=false跳过总数时不返回
+	// Example: 100
+	Total int64 `json:"total,omitempty"`
+}
+
+// CommonPagJobsScriptStandardOut 对应common.Pag-jobs_ScriptStandardOut
+type CommonPagJobsScriptStandardOut struct {
+	// 是否有下一页
+	// Example: true
+	HasNext bool `json:"has_next,omitempty"`
+	// 对象数组
+	Items []*JobsScriptStandardOut `json:"items,omitempty"`
+	// 当前页码
+	// Example: 1
+	Page int64 `json:"page,omitempty"`
+	// 总页数, 请求with_total=false跳过总数时不返回
+	// Example: 10
+	Pages int64 `json:"pages,omitempty"`
+	// 每页数量
+	// Example: 10
+	Size int64 `json:"size,omitempty"`
+	// 总记录数, 请求with_total=false跳过总数时不返回
+	// Example: 100
+	Total int64 `json:"total,omitempty"`
+}
+
+// CommonPagJobsTradingHolidayOut 对应common.Pag-jobs_TradingHolidayOut
+type CommonPagJobsTradingHolidayOut struct {
+	// 是否有下一页
+	// Example: true
+	HasNext bool `json:"has_next,omitempty"`
+	// 对象数组
+	Items []*JobsTradingHolidayOut `json:"items,omitempty"`
+	// 当前页码
+	// Example: 1
+	Page int64 `json:"page,omitempty"`
+	// 总页数, 请求with_total=false跳过总数时不返回
+	// Example: 10
+	Pages int64 `json:"pages,omitempty"`
+	// 每页数量
+	// Example: 10
+	Size int64 `json:"size,omitempty"`
+	// 总记录数, 请求with_total=false跳过总数时不返回
+	// Example: 100
+	Total int64 `json:"total,omitempty"`
+}
+
+// CommonPagMdsIngestRecordOut 对应common.Pag-mds_IngestRecordOut
+type CommonPagMdsIngestRecordOut struct {
+	// 是否有下一页
+	// Example: true
+	HasNext bool `json:"has_next,omitempty"`
+	// 对象数组
+	Items []*MdsIngestRecordOut `json:"items,omitempty"`
+	// 当前页码
+	// Example: 1
+	Page int64 `json:"page,omitempty"`
+	// 总页数, 请求with_total=false跳过总数时不返回
+	// Example: 10
+	Pages int64 `json:"pages,omitempty"`
+	// 每页数量
+	// Example: 10
+	Size int64 `json:"size,omitempty"`
+	// 总记录数, 请求with_total=false跳过总数时不返回
+	// Example: 100
+	Total int64 `json:"total,omitempty"`
+}
+
+// CommonPagMdsIngestSourceOut 对应common.Pag-mds_IngestSourceOut
+type CommonPagMdsIngestSourceOut struct {
+	// 是否有下一页
+	// Example: true
+	HasNext bool `json:"has_next,omitempty"`
+	// 对象数组
+	Items []*MdsIngestSourceOut `json:"items,omitempty"`
+	// 当前页码
+	// Example: 1
+	Page int64 `json:"page,omitempty"`
+	// 总页数, 请求with_total=false跳过总数时不返回
+	// Example: 10
+	Pages int64 `json:"pages,omitempty"`
+	// 每页数量
+	// Example: 10
+	Size int64 `json:"size,omitempty"`
+	// 总记录数, 请求with_total=false跳过总数时不返回
+	// Example: 100
+	Total int64 `json:"total,omitempty"`
+}
+
+// CommonPagMdsMdsBackfillRunOut 对应common.Pag-mds_MdsBackfillRunOut
+type CommonPagMdsMdsBackfillRunOut struct {
+	// 是否有下一页
+	// Example: true
+	HasNext bool `json:"has_next,omitempty"`
+	// 对象数组
+	Items []*MdsMdsBackfillRunOut `json:"items,omitempty"`
+	// 当前页码
+	// Example: 1
+	Page int64 `json:"page,omitempty"`
+	// 总页数, 请求with_total=false跳过总数时不返回
+	// Example: 10
+	Pages int64 `json:"pages,omitempty"`
+	// 每页数量
+	// Example: 10
+	Size int64 `json:"size,omitempty"`
+	// 总记录数, 请求with_total=false跳过总数时不返回
+	// Example: 100
+	Total int64 `json:"total,omitempty"`
+}
+
+// CommonPagMdsMdsColonyDetailOut 对应common.Pag-mds_MdsColonyDetailOut
+type CommonPagMdsMdsColonyDetailOut struct {
+	// 是否有下一页
+	// Example: true
+	HasNext bool `json:"has_next,omitempty"`
+	// 对象数组
+	Items []*MdsMdsColonyDetailOut `json:"items,omitempty"`
+	// 当前页码
+	// Example: 1
+	Page int64 `json:"page,omitempty"`
+	// 总页数, 请求with_total=false跳过总数时不返回
+	// Example: 10
+	Pages int64 `json:"pages,omitempty"`
+	// 每页数量
+	// Example: 10
+	Size int64 `json:"size,omitempty"`
+	// 总记录数, 请求with_total=false跳过总数时不返回
+	// Example: 100
+	Total int64 `json:"total,omitempty"`
+}
+
+// CommonPagMdsMdsNodeDetailOut 对应common.Pag-mds_MdsNodeDetailOut
+type CommonPagMdsMdsNodeDetailOut struct {
+	// 是否有下一页
+	// Example: true
+	HasNext bool `json:"has_next,omitempty"`
+	// 对象数组
+	Items []*MdsMdsNodeDetailOut `json:"items,omitempty"`
+	// 当前页码
+	// Example: 1
+	Page int64 `json:"page,omitempty"`
+	// 总页数, 请求with_total=false跳过总数时不返回
+	// Example: 10
+	Pages int64 `json:"pages,omitempty"`
+	// 每页数量
+	// Example: 10
+	Size int64 `json:"size,omitempty"`
+	// 总记录数, 请求with_total=false跳过总数时不返回
+	// Example: 100
+	Total int64 `json:"total,omitempty"`
+}
+
+// CommonPagMonMonNodeDetailOut 对应common.Pag-mon_MonNodeDetailOut
+type CommonPagMonMonNodeDetailOut struct {
+	// 是否有下一页
+	// Example: true
+	HasNext bool `json:"has_next,omitempty"`
+	// 对象数组
+	Items []*MonMonNodeDetailOut `json:"items,omitempty"`
+	// 当前页码
+	// Example: 1
+	Page int64 `json:"page,omitempty"`
+	// 总页数, 请求with_total=false跳过总数时不返回
+	// Example: 10
+	Pages int64 `json:"pages,omitempty"`
+	// 每页数量
+	// Example: 10
+	Size int64 `json:"size,omitempty"`
+	// 总记录数, 请求with_total=false跳过总数时不返回
+	// Example: 100
+	Total int64 `json:"total,omitempty"`
+}
+
+// CommonPagOesOesColonyDetailOut 对应common.Pag-oes_OesColonyDetailOut
+type CommonPagOesOesColonyDetailOut struct {
+	// 是否有下一页
+	// Example: true
+	HasNext bool `json:"has_next,omitempty"`
+	// 对象数组
+	Items []*OesOesColonyDetailOut `json:"items,omitempty"`
+	// 当前页码
+	// Example: 1
+	Page int64 `json:"page,omitempty"`
+	// 总页数, 请求with_total=false跳过总数时不返回
+	// Example: 10
+	Pages int64 `json:"pages,omitempty"`
+	// 每页数量
+	// Example: 10
+	Size int64 `json:"size,omitempty"`
+	// 总记录数, 请求with_total=false跳过总数时不返回
+	// Example: 100
+	Total int64 `json:"total,omitempty"`
+}
+
+// CommonPagOesOesConfTemplateOut 对应common.Pag-oes_OesConfTemplateOut
+type CommonPagOesOesConfTemplateOut struct {
+	// 是否有下一页
+	// Example: true
+	HasNext bool `json:"has_next,omitempty"`
+	// 对象数组
+	Items []*OesOesConfTemplateOut `json:"items,omitempty"`
+	// 当前页码
+	// Example: 1
+	Page int64 `json:"page,omitempty"`
+	// 总页数, 请求with_total=false跳过总数时不返回
+	// Example: 10
+	Pages int64 `json:"pages,omitempty"`
+	// 每页数量
+	// Example: 10
+	Size int64 `json:"size,omitempty"`
+	// 总记录数, 请求with_total=false跳过总数时不返回
+	// Example: 100
+	Total int64 `json:"total,omitempty"`
+}
+
+// CommonPagOesOesLinkOut 对应common.Pag-oes_OesLinkOut
+type CommonPagOesOesLinkOut struct {
+	// 是否有下一页
+	// Example: true
+	HasNext bool `json:"has_next,omitempty"`
+	// 对象数组
+	Items []*OesOesLinkOut `json:"items,omitempty"`
+	// 当前页码
+	// Example: 1
+	Page int64 `json:"page,omitempty"`
+	// 总页数, 请求with_total=false跳过总数时不返回
+	// Example: 10
+	Pages int64 `json:"pages,omitempty"`
+	// 每页数量
+	// Example: 10
+	Size int64 `json:"size,omitempty"`
+	// 总记录数, 请求with_total=false跳过总数时不返回
+	// Example: 100
+	Total int64 `json:"total,omitempty"`
+}
+
+// CommonPagOesOesLinkProbeOut 对应common.Pag-oes_OesLinkProbeOut
+type CommonPagOesOesLinkProbeOut struct {
+	// 是否有下一页
+	// Example: true
+	HasNext bool `json:"has_next,omitempty"`
+	// 对象数组
+	Items []*OesOesLinkProbeOut `json:"items,omitempty"`
+	// 当前页码
+	// Example: 1
+	Page int64 `json:"page,omitempty"`
+	// 总页数, 请求with_total=false跳过总数时不返回
+	// Example: 10
+	Pages int64 `json:"pages,omitempty"`
+	// 每页数量
+	// Example: 10
+	Size int64 `json:"size,omitempty"`
+	// 总记录数, 请求with_total=false跳过总数时不返回
+	// Example: 100
+	Total int64 `json:"total,omitempty"`
+}
+
+// CommonPagOesOesNodeDetailOut 对应common.Pag-oes_OesNodeDetailOut
+type CommonPagOesOesNodeDetailOut struct {
+	// 是否有下一页
+	// Example: true
+	HasNext bool `json:"has_next,omitempty"`
+	// 对象数组
+	Items []*OesOesNodeDetailOut `json:"items,omitempty"`
+	// 当前页码
+	// Example: 1
+	Page int64 `json:"page,omitempty"`
+	// 总页数, 请求with_total=false跳过总数时不返回
+	// Example: 10
+	Pages int64 `json:"pages,omitempty"`
+	// 每页数量
+	// Example: 10
+	Size int64 `json:"size,omitempty"`
+	// 总记录数, 请求with_total=false跳过总数时不返回
+	// Example: 100
+	Total int64 `json:"total,omitempty"`
+}
+
+// CommonPagOesOesReconcileReportOut 对应common.Pag-oes_OesReconcileReportOut
+type CommonPagOesOesReconcileReportOut struct {
+	// 是否有下一页
+	// Example: true
+	HasNext bool `json:"has_next,omitempty"`
+	// 对象数组
+	Items []*OesOesReconcileReportOut `json:"items,omitempty"`
+	// 当前页码
+	// Example: 1
+	Page int64 `json:"page,omitempty"`
+	// 总页数, 请求with_total=false跳过总数时不返回
+	// Example: 10
+	Pages int64 `json:"pages,omitempty"`
+	// 每页数量
+	// Example: 10
+	Size int64 `json:"size,omitempty"`
+	// 总记录数, 请求with_total=false跳过总数时不返回
+	// Example: 100
+	Total int64 `json:"total,omitempty"`
+}
+
+// CommonPagOesOesRunbookExecOut 对应common.Pag-oes_OesRunbookExecOut
+type CommonPagOesOesRunbookExecOut struct {
+	// 是否有下一页
+	// Example: true
+	HasNext bool `json:"has_next,omitempty"`
+	// 对象数组
+	Items []*OesOesRunbookExecOut `json:"items,omitempty"`
+	// 当前页码
+	// Example: 1
+	Page int64 `json:"page,omitempty"`
+	// 总页数, 请求with_total=false跳过总数时不返回
+	// Example: 10
+	Pages int64 `json:"pages,omitempty"`
+	// 每页数量
+	// Example: 10
+	Size int64 `json:"size,omitempty"`
+	// 总记录数, 请求with_total=false跳过总数时不返回
+	// Example: 100
+	Total int64 `json:"total,omitempty"`
+}
+
+// CommonPagOesOesRunbookOut 对应common.Pag-oes_OesRunbookOut
+type CommonPagOesOesRunbookOut struct {
+	// 是否有下一页
+	// Example: true
+	HasNext bool `json:"has_next,omitempty"`
+	// 对象数组
+	Items []*OesOesRunbookOut `json:"items,omitempty"`
+	// 当前页码
+	// Example: 1
+	Page int64 `json:"page,omitempty"`
+	// 总页数, 请求with_total=false跳过总数时不返回
+	// Example: 10
+	Pages int64 `json:"pages,omitempty"`
+	// 每页数量
+	// Example: 10
+	Size int64 `json:"size,omitempty"`
+	// 总记录数, 请求with_total=false跳过总数时不返回
+	// Example: 100
+	Total int64 `json:"total,omitempty"`
+}
+
+// CommonPagOesOesTaskCatalogOut 对应common.Pag-oes_OesTaskCatalogOut
+type CommonPagOesOesTaskCatalogOut struct {
+	// 是否有下一页
+	// Example: true
+	HasNext bool `json:"has_next,omitempty"`
+	// 对象数组
+	Items []*OesOesTaskCatalogOut `json:"items,omitempty"`
+	// 当前页码
+	// Example: 1
+	Page int64 `json:"page,omitempty"`
+	// 总页数, 请求with_total=false跳过总数时不返回
+	// Example: 10
+	Pages int64 `json:"pages,omitempty"`
+	// 每页数量
+	// Example: 10
+	Size int64 `json:"size,omitempty"`
+	// 总记录数, 请求with_total=false跳过总数时不返回
+	// Example: 100
+	Total int64 `json:"total,omitempty"`
+}
+
+// CommonPagOesOesTaskSlaOut 对应common.Pag-oes_OesTaskSlaOut
+type CommonPagOesOesTaskSlaOut struct {
+	// 是否有下一页
+	// Example: true
+	HasNext bool `json:"has_next,omitempty"`
+	// 对象数组
+	Items []*OesOesTaskSlaOut `json:"items,omitempty"`
+	// 当前页码
+	// Example: 1
+	Page int64 `json:"page,omitempty"`
+	// 总页数, 请求with_total=false跳过总数时不返回
+	// Example: 10
+	Pages int64 `json:"pages,omitempty"`
+	// 每页数量
+	// Example: 10
+	Size int64 `json:"size,omitempty"`
+	// 总记录数, 请求with_total=false跳过总数时不返回
+	// Example: 100
+	Total int64 `json:"total,omitempty"`
+}
+
+// CommonPagOesOesWorkflowRunOut 对应common.Pag-oes_OesWorkflowRunOut
+type CommonPagOesOesWorkflowRunOut struct {
+	// 是否有下一页
+	// Example: true
+	HasNext bool `json:"has_next,omitempty"`
+	// 对象数组
+	Items []*OesOesWorkflowRunOut `json:"items,omitempty"`
+	// 当前页码
+	// Example: 1
+	Page int64 `json:"page,omitempty"`
+	// 总页数, 请求with_total=false跳过总数时不返回
+	// Example: 10
+	Pages int64 `json:"pages,omitempty"`
+	// 每页数量
+	// Example: 10
+	Size int64 `json:"size,omitempty"`
+	// 总记录数, 请求with_total=false跳过总数时不返回
+	// Example: 100
+	Total int64 `json:"total,omitempty"`
+}
+
+// CommonPagResourceHostAgentOut 对应common.Pag-resource_HostAgentOut
+type CommonPagResourceHostAgentOut struct {
+	// 是否有下一页
+	// Example: true
+	HasNext bool `json:"has_next,omitempty"`
+	// 对象数组
+	Items []*ResourceHostAgentOut `json:"items,omitempty"`
+	// 当前页码
+	// Example: 1
+	Page int64 `json:"page,omitempty"`
+	// 总页数, 请求with_total=false跳过总数时不返回
+	// Example: 10
+	Pages int64 `json:"pages,omitempty"`
+	// 每页数量
+	// Example: 10
+	Size int64 `json:"size,omitempty"`
+	// 总记录数, 请求with_total=false跳过总数时不返回
+	// Example: 100
+	Total int64 `json:"total,omitempty"`
+}
+
+// CommonPagResourceHostPathRuleOut 对应common.Pag-resource_HostPathRuleOut
+type CommonPagResourceHostPathRuleOut struct {
+	// 是否有下一页
+	// Example: true
+	HasNext bool `json:"has_next,omitempty"`
+	// 对象数组
+	Items []*ResourceHostPathRuleOut `json:"items,omitempty"`
+	// 当前页码
+	// Example: 1
+	Page int64 `json:"page,omitempty"`
+	// 总页数, 请求with_total=false跳过总数时不返回
+	// Example: 10
+	Pages int64 `json:"pages,omitempty"`
+	// 每页数量
+	// Example: 10
+	Size int64 `json:"size,omitempty"`
+	// 总记录数, 请求with_total=false跳过总数时不返回
+	// Example: 100
+	Total int64 `json:"total,omitempty"`
+}
+
+// CommonPagResourceHostStandardOut 对应common.Pag-resource_HostStandardOut
+type CommonPagResourceHostStandardOut struct {
+	// 是否有下一页
+	// Example: true
+	HasNext bool `json:"has_next,omitempty"`
+	// 对象数组
+	Items []*ResourceHostStandardOut `json:"items,omitempty"`
+	// 当前页码
+	// Example: 1
+	Page int64 `json:"page,omitempty"`
+	// 总页数, 请求with_total=false跳过总数时不返回
+	// Example: 10
+	Pages int64 `json:"pages,omitempty"`
+	// 每页数量
+	// Example: 10
+	Size int64 `json:"size,omitempty"`
+	// 总记录数, 请求with_total=false跳过总数时不返回
+	// Example: 100
+	Total int64 `json:"total,omitempty"`
+}
+
+// CommonPagResourcePackageStandardOut 对应common.Pag-resource_PackageStandardOut
+type CommonPagResourcePackageStandardOut struct {
+	// 是否有下一页
+	// Example: true
+	HasNext bool `json:"has_next,omitempty"`
+	// 对象数组
+	Items []*ResourcePackageStandardOut `json:"items,omitempty"`
+	// 当前页码
+	// Example: 1
+	Page int64 `json:"page,omitempty"`
+	// 总页数, 请求with_total=false跳过总数时不返回
+	// Example: 10
+	Pages int64 `json:"pages,omitempty"`
+	// 每页数量
+	// Example: 10
+	Size int64 `json:"size,omitempty"`
+	// 总记录数, 请求with_total=false跳过总数时不返回
+	// Example: 100
+	Total int64 `json:"total,omitempty"`
+}
+
+// CommonPagResourceTerminalSessionOut 对应common.Pag-resource_TerminalSessionOut
+type CommonPagResourceTerminalSessionOut struct {
+	// 是否有下一页
+	// Example: true
+	HasNext bool `json:"has_next,omitempty"`
+	// 对象数组
+	Items []*ResourceTerminalSessionOut `json:"items,omitempty"`
+	// 当前页码
+	// Example: 1
+	Page int64 `json:"page,omitempty"`
+	// 总页数, 请求with_total=false跳过总数时不返回
+	// Example: 10
+	Pages int64 `json:"pages,omitempty"`
+	// 每页数量
+	// Example: 10
+	Size int64 `json:"size,omitempty"`
+	// 总记录数, 请求with_total=false跳过总数时不返回
+	// Example: 100
+	Total int64 `json:"total,omitempty"`
+}
+
+// CommonPagResourceWatchdogEventOut 对应common.Pag-resource_WatchdogEventOut
+type CommonPagResourceWatchdogEventOut struct {
+	// 是否有下一页
+	// Example: true
+	HasNext bool `json:"has_next,omitempty"`
+	// 对象数组
+	Items []*ResourceWatchdogEventOut `json:"items,omitempty"`
+	// 当前页码
+	// Example: 1
+	Page int64 `json:"page,omitempty"`
+	// 总页数, 请求with_total=false跳过总数时不返回
+	// Example: 10
+	Pages int64 `json:"pages,omitempty"`
+	// 每页数量
+	// Example: 10
+	Size int64 `json:"size,omitempty"`
+	// 总记录数, 请求with_total=false跳过总数时不返回
+	// Example: 100
+	Total int64 `json:"total,omitempty"`
+}
+
+// CommonPagResourceWatchdogOut 对应common.Pag-resource_WatchdogOut
+type CommonPagResourceWatchdogOut struct {
+	// 是否有下一页
+	// Example: true
+	HasNext bool `json:"has_next,omitempty"`
+	// 对象数组
+	Items []*ResourceWatchdogOut `json:"items,omitempty"`
+	// 当前页码
+	// Example: 1
+	Page int64 `json:"page,omitempty"`
+	// 总页数, 请求with_total=false跳过总数时不返回
+	// Example: 10
+	Pages int64 `json:"pages,omitempty"`
+	// 每页数量
+	// Example: 10
+	Size int64 `json:"size,omitempty"`
+	// 总记录数, 请求with_total=false跳过总数时不返回
+	// Example: 100
+	Total int64 `json:"total,omitempty"`
+}
+
+// CommonPagSystemAuditRecordOut 对应common.Pag-system_AuditRecordOut
+type CommonPagSystemAuditRecordOut struct {
+	// 是否有下一页
+	// Example: true
+	HasNext bool `json:"has_next,omitempty"`
+	// 对象数组
+	Items []*SystemAuditRecordOut `json:"items,omitempty"`
+	// 当前页码
+	// Example: 1
+	Page int64 `json:"page,omitempty"`
+	// 总页数, 请求with_total=false跳过总数时不返回
+	// Example: 10
+	Pages int64 `json:"pages,omitempty"`
+	// 每页数量
+	// Example: 10
+	Size int64 `json:"size,omitempty"`
+	// 总记录数, 请求with_total=false跳过总数时不返回
+	// Example: 100
+	Total int64 `json:"total,omitempty"`
+}
+
+// CommonPagSystemBackupOut 对应common.Pag-system_BackupOut
+type CommonPagSystemBackupOut struct {
+	// 是否有下一页
+	// Example: true
+	HasNext bool `json:"has_next,omitempty"`
+	// 对象数组
+	Items []*SystemBackupOut `json:"items,omitempty"`
+	// 当前页码
+	// Example: 1
+	Page int64 `json:"page,omitempty"`
+	// 总页数, 请求with_total=false跳过总数时不返回
+	// Example: 10
+	Pages int64 `json:"pages,omitempty"`
+	// 每页数量
+	// Example: 10
+	Size int64 `json:"size,omitempty"`
+	// 总记录数, 请求with_total=false跳过总数时不返回
+	// Example: 100
+	Total int64 `json:"total,omitempty"`
+}
+
+// CommonPagSystemCertMonitorOut 对应common.Pag-system_CertMonitorOut
+type CommonPagSystemCertMonitorOut struct {
+	// 是否有下一页
+	// Example: true
+	HasNext bool `json:"has_next,omitempty"`
+	// 对象数组
+	Items []*SystemCertMonitorOut `json:"items,omitempty"`
+	// 当前页码
+	// Example: 1
+	Page int64 `json:"page,omitempty"`
+	// 总页数, 请求with_total=false跳过总数时不返回
+	// Example: 10
+	Pages int64 `json:"pages,omitempty"`
+	// 每页数量
+	// Example: 10
+	Size int64 `json:"size,omitempty"`
+	// 总记录数, 请求with_total=false跳过总数时不返回
+	// Example: 100
+	Total int64 `json:"total,omitempty"`
+}
+
+// CommonPagSystemChangeFreezeOut 对应common.Pag-system_ChangeFreezeOut
+type CommonPagSystemChangeFreezeOut struct {
+	// 是否有下一页
+	// Example: true
+	HasNext bool `json:"has_next,omitempty"`
+	// 对象数组
+	Items []*SystemChangeFreezeOut `json:"items,omitempty"`
+	// 当前页码
+	// Example: 1
+	Page int64 `json:"page,omitempty"`
+	// 总页数, 请求with_total=false跳过总数时不返回
+	// Example: 10
+	Pages int64 `json:"pages,omitempty"`
+	// 每页数量
+	// Example: 10
+	Size int64 `json:"size,omitempty"`
+	// 总记录数, 请求with_total=false跳过总数时不返回
+	// Example: 100
+	Total int64 `json:"total,omitempty"`
+}
+
+// CommonPagSystemFeatureFlagOut 对应common.Pag-system_FeatureFlagOut
+type CommonPagSystemFeatureFlagOut struct {
+	// 是否有下一页
+	// Example: true
+	HasNext bool `json:"has_next,omitempty"`
+	// 对象数组
+	Items []*SystemFeatureFlagOut `json:"items,omitempty"`
+	// 当前页码
+	// Example: 1
+	Page int64 `json:"page,omitempty"`
+	// 总页数, 请求with_total=false跳过总数时不返回
+	// Example: 10
+	Pages int64 `json:"pages,omitempty"`
+	// 每页数量
+	// Example: 10
+	Size int64 `json:"size,omitempty"`
+	// 总记录数, 请求with_total=false跳过总数时不返回
+	// Example: 100
+	Total int64 `json:"total,omitempty"`
+}
+
+// CommonPagSystemMaintenanceWindowOut 对应common.Pag-system_MaintenanceWindowOut
+type CommonPagSystemMaintenanceWindowOut struct {
+	// 是否有下一页
+	// Example: true
+	HasNext bool `json:"has_next,omitempty"`
+	// 对象数组
+	Items []*SystemMaintenanceWindowOut `json:"items,omitempty"`
+	// 当前页码
+	// Example: 1
+	Page int64 `json:"page,omitempty"`
+	// 总页数, 请求with_total=false跳过总数时不返回
+	// Example: 10
+	Pages int64 `json:"pages,omitempty"`
+	// 每页数量
+	// Example: 10
+	Size int64 `json:"size,omitempty"`
+	// 总记录数, 请求with_total=false跳过总数时不返回
+	// Example: 100
+	Total int64 `json:"total,omitempty"`
+}
+
+// CommonPagSystemSlowQueryOut 对应common.Pag-system_SlowQueryOut
+type CommonPagSystemSlowQueryOut struct {
+	// 是否有下一页
+	// Example: true
+	HasNext bool `json:"has_next,omitempty"`
+	// 对象数组
+	Items []*SystemSlowQueryOut `json:"items,omitempty"`
+	// 当前页码
+	// Example: 1
+	Page int64 `json:"page,omitempty"`
+	// 总页数, 请求with_total=false跳过总数时不返回
+	// Example: 10
+	Pages int64 `json:"pages,omitempty"`
+	// 每页数量
+	// Example: 10
+	Size int64 `json:"size,omitempty"`
+	// 总记录数, 请求with_total=false跳过总数时不返回
+	// Example: 100
+	Total int64 `json:"total,omitempty"`
+}
+
+// CommonPagSystemTaskOut 对应common.Pag-system_TaskOut
+type CommonPagSystemTaskOut struct {
+	// 是否有下一页
+	// Example: true
+	HasNext bool `json:"has_next,omitempty"`
+	// 对象数组
+	Items []*SystemTaskOut `json:"items,omitempty"`
+	// 当前页码
+	// Example: 1
+	Page int64 `json:"page,omitempty"`
+	// 总页数, 请求with_total=false跳过总数时不返回
+	// Example: 10
+	Pages int64 `json:"pages,omitempty"`
+	// 每页数量
+	// Example: 10
+	Size int64 `json:"size,omitempty"`
+	// 总记录数, 请求with_total=false跳过总数时不返回
+	// Example: 100
+	Total int64 `json:"total,omitempty"`
+}
+
+// CommonPagSystemWebhookDeliveryOut 对应common.Pag-system_WebhookDeliveryOut
+type CommonPagSystemWebhookDeliveryOut struct {
+	// 是否有下一页
+	// Example: true
+	HasNext bool `json:"has_next,omitempty"`
+	// 对象数组
+	Items []*SystemWebhookDeliveryOut `json:"items,omitempty"`
+	// 当前页码
+	// Example: 1
+	Page int64 `json:"page,omitempty"`
+	// 总页数, 请求with_total=false跳过总数时不返回
+	// Example: 10
+	Pages int64 `json:"pages,omitempty"`
+	// 每页数量
+	// Example: 10
+	Size int64 `json:"size,omitempty"`
+	// 总记录数, 请求with_total=false跳过总数时不返回
+	// Example: 100
+	Total int64 `json:"total,omitempty"`
+}
+
+// CommonPagSystemWebhookSubscriptionOut 对应common.Pag-system_WebhookSubscriptionOut
+type CommonPagSystemWebhookSubscriptionOut struct {
+	// 是否有下一页
+	// Example: true
+	HasNext bool `json:"has_next,omitempty"`
+	// 对象数组
+	Items []*SystemWebhookSubscriptionOut `json:"items,omitempty"`
+	// 当前页码
+	// Example: 1
+	Page int64 `json:"page,omitempty"`
+	// 总页数, 请求with_total=false跳过总数时不返回
+	// Example: 10
+	Pages int64 `json:"pages,omitempty"`
+	// 每页数量
+	// Example: 10
+	Size int64 `json:"size,omitempty"`
+	// 总记录数, 请求with_total=false跳过总数时不返回
+	// Example: 100
+	Total int64 `json:"total,omitempty"`
+}
+
+// CommonTaskInfo 对应common.TaskInfo
+type CommonTaskInfo struct {
+	// 更新时间
+	EndTime string `json:"end_time,omitempty"`
+	// 执行记录ID(0表示非正常执行的任务)
+	RecordID int64 `json:"record_id,omitempty"`
+	// 创建时间
+	StartTime string `json:"start_time,omitempty"`
+	// 执行状态(0-待执行,1-执行中,2-成功,3-失败,4-超时,5-崩溃)
+	Status int64 `json:"status,omitempty"`
+	// 任务名称
+	TaskName string `json:"task_name,omitempty"`
+	// 触发类型(cron/api,未执行为空)
+	TriggerType string `json:"trigger_type,omitempty"`
+}
+
+// CustomerApiReply 对应customer.ApiReply
+type CustomerApiReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CustomerApiStandardOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// CustomerApiStandardOut 对应customer.ApiStandardOut
+type CustomerApiStandardOut struct {
+	// 创建时间
+	CreatedAt string `json:"created_at,omitempty"`
+	// 描述
+	Descr string `json:"descr,omitempty"`
+	// 唯一标识
+	ID int64 `json:"id,omitempty"`
+	// 是否失效
+	IsOrphan bool `json:"is_orphan,omitempty"`
+	// 标签
+	Label string `json:"label,omitempty"`
+	// 请求方法
+	Method string `json:"method,omitempty"`
+	// 更新时间
+	UpdatedAt string `json:"updated_at,omitempty"`
+	// HTTP路径
+	URL string `json:"url,omitempty"`
+}
+
+// CustomerApiSyncOut 对应customer.ApiSyncOut
+type CustomerApiSyncOut struct {
+	// 新增的API
+	Created []*CustomerApiStandardOut `json:"created,omitempty"`
+	// 路由中已不存在的API
+	Orphans []*CustomerApiStandardOut `json:"orphans,omitempty"`
+	// 重新出现在路由中的失效API数量
+	Restored int64 `json:"restored,omitempty"`
+	// 重新设置标签的API数量
+	Retagged int64 `json:"retagged,omitempty"`
+}
+
+// CustomerApiSyncReply 对应customer.ApiSyncReply
+type CustomerApiSyncReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CustomerApiSyncOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// CustomerAssignDepartmentRequest 对应customer.AssignDepartmentRequest
+type CustomerAssignDepartmentRequest struct {
+	// 部门ID, 为空或0时移出部门
+	DepartmentID int64 `json:"department_id,omitempty"`
+}
+
+// CustomerBulkDeleteRequest 对应customer.BulkDeleteRequest
+type CustomerBulkDeleteRequest struct {
+	// 要删除的记录ID列表
+	IDs []int64 `json:"ids"`
+}
+
+// CustomerButtonBaseOut 对应customer.ButtonBaseOut
+type CustomerButtonBaseOut struct {
+	// 描述
+	Descr string `json:"descr,omitempty"`
+	// 唯一标识
+	ID int64 `json:"id,omitempty"`
+	// 是否激活
+	IsActive bool `json:"is_active,omitempty"`
+	// 名称
+	Name string `json:"name,omitempty"`
+	// 排序字段
+	Sort int64 `json:"sort,omitempty"`
+}
+
+// CustomerButtonDetailOut 对应customer.ButtonDetailOut
+type CustomerButtonDetailOut struct {
+	// API ID列表
+	APIIDs []int64 `json:"api_ids,omitempty"`
+	// 创建时间
+	CreatedAt string `json:"created_at,omitempty"`
+	// 描述
+	Descr string `json:"descr,omitempty"`
+	// 唯一标识
+	ID int64 `json:"id,omitempty"`
+	// 是否激活
+	IsActive bool `json:"is_active,omitempty"`
+	// 菜单
+	Menu *CustomerMenuStandardOut `json:"menu,omitempty"`
+	// 名称
+	Name string `json:"name,omitempty"`
+	// 排序字段
+	Sort int64 `json:"sort,omitempty"`
+	// 更新时间
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+// CustomerButtonReply 对应customer.ButtonReply
+type CustomerButtonReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CustomerButtonDetailOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// CustomerButtonStandardOut 对应customer.ButtonStandardOut
+type CustomerButtonStandardOut struct {
+	// 创建时间
+	CreatedAt string `json:"created_at,omitempty"`
+	// 描述
+	Descr string `json:"descr,omitempty"`
+	// 唯一标识
+	ID int64 `json:"id,omitempty"`
+	// 是否激活
+	IsActive bool `json:"is_active,omitempty"`
+	// 名称
+	Name string `json:"name,omitempty"`
+	// 排序字段
+	Sort int64 `json:"sort,omitempty"`
+	// 更新时间
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+// CustomerCaptchaOut 对应customer.CaptchaOut
+type CustomerCaptchaOut struct {
+	// 挑战ID, 登录时随答案一起提交
+	CaptchaID string `json:"captcha_id,omitempty"`
+	// 有效期(秒)
+	ExpiresIn int64 `json:"expires_in,omitempty"`
+	// PNG图片的data URI
+	Image string `json:"image,omitempty"`
+}
+
+// CustomerCaptchaReply 对应customer.CaptchaReply
+type CustomerCaptchaReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CustomerCaptchaOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// CustomerCasbinModelOut 对应customer.CasbinModelOut
+type CustomerCasbinModelOut struct {
+	// 模型配置
+	Content string `json:"content,omitempty"`
+	// 创建时间
+	CreatedAt string `json:"created_at,omitempty"`
+	// 唯一标识, 内置模型为0
+	ID int64 `json:"id,omitempty"`
+	// 是否生效
+	IsActive bool `json:"is_active,omitempty"`
+	// 操作人
+	Operator string `json:"operator,omitempty"`
+	// 变更说明
+	Remark string `json:"remark,omitempty"`
+	// 版本号, 内置模型为0
+	Version int64 `json:"version,omitempty"`
+}
+
+// CustomerCasbinModelReply 对应customer.CasbinModelReply
+type CustomerCasbinModelReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CustomerCasbinModelOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// CustomerCasbinModelValidateOut 对应customer.CasbinModelValidateOut
+type CustomerCasbinModelValidateOut struct {
+	// 是否全部通过
+	Passed bool `json:"passed,omitempty"`
+	// 样例请求结果
+	Results []*AuthCasbinSampleResult `json:"results,omitempty"`
+}
+
+// CustomerCasbinModelValidateReply 对应customer.CasbinModelValidateReply
+type CustomerCasbinModelValidateReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CustomerCasbinModelValidateOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// CustomerCreateApiRequest 对应customer.CreateApiRequest
+type CustomerCreateApiRequest struct {
+	// 描述信息
+	Descr string `json:"descr,omitempty"`
+	// 唯一标识
+	ID int64 `json:"id"`
+	// 标签
+	Label string `json:"label"`
+	// 请求方法
+	Method string `json:"method"`
+	// URL地址
+	URL string `json:"url"`
+}
+
+// CustomerCreateButtonRequest 对应customer.CreateButtonRequest
+type CustomerCreateButtonRequest struct {
+	// API ID列表
+	APIIDs []int64 `json:"api_ids,omitempty"`
+	// 描述信息
+	Descr string `json:"descr,omitempty"`
+	// 唯一标识
+	ID int64 `json:"id"`
+	// 是否激活
+	IsActive bool `json:"is_active"`
+	// 菜单ID
+	MenuID int64 `json:"menu_id"`
+	// 名称
+	Name string `json:"name"`
+	// 排序字段
+	Sort int64 `json:"sort"`
+}
+
+// CustomerCreateMenuRequest 对应customer.CreateMenuRequest
+type CustomerCreateMenuRequest struct {
+	// 权限ID列表
+	APIIDs []int64 `json:"api_ids,omitempty"`
+	// 组件路径
+	Component string `json:"component"`
+	// 描述
+	Descr string `json:"descr,omitempty"`
+	// 唯一标识
+	ID int64 `json:"id"`
+	// 是否激活
+	IsActive bool `json:"is_active,omitempty"`
+	// 菜单元信息
+	Meta *CustomerMetaSchemas `json:"meta"`
+	// 名称
+	Name string `json:"name"`
+	// 父级菜单ID
+	ParentID int64 `json:"parent_id,omitempty"`
+	// 前端路由路径
+	Path string `json:"path"`
+	// 排序字段
+	Sort int64 `json:"sort"`
+}
+
+// CustomerCreateOrUpdateDepartmentRequest 对应customer.CreateOrUpdateDepartmentRequest
+type CustomerCreateOrUpdateDepartmentRequest struct {
+	// 描述信息
+	Descr string `json:"descr,omitempty"`
+	// 名称
+	Name string `json:"name"`
+	// 父部门ID, 仅创建时生效, 调整上级部门使用移动接口
+	ParentID int64 `json:"parent_id,omitempty"`
+	// 排序字段
+	Sort int64 `json:"sort,omitempty"`
+}
+
+// CustomerCreateOrUpdateRoleRequest 对应customer.CreateOrUpdateRoleRequest
+type CustomerCreateOrUpdateRoleRequest struct {
+	// APIID列表
+	APIIDs []int64 `json:"api_ids,omitempty"`
+	// 按钮ID列表
+	ButtonIDs []int64 `json:"button_ids,omitempty"`
+	// 数据范围, all:全部数据, department:本部门及下级部门, self:仅本人, 为空时为all
+	DataScope string `json:"data_scope,omitempty"`
+	// 描述信息
+	Descr string `json:"descr,omitempty"`
+	// 菜单ID列表
+	MenuIDs []int64 `json:"menu_ids,omitempty"`
+	// 名称
+	Name string `json:"name"`
+	// 版本号, 更新时必填(也可通过If-Match请求头传递)
+	Version int64 `json:"version,omitempty"`
+}
+
+// CustomerCreateOrUpdateUserGroupRequest 对应customer.CreateOrUpdateUserGroupRequest
+type CustomerCreateOrUpdateUserGroupRequest struct {
+	// 描述信息
+	Descr string `json:"descr,omitempty"`
+	// 名称
+	Name string `json:"name"`
+	// 角色ID列表
+	RoleIDs []int64 `json:"role_ids,omitempty"`
+	// 用户ID列表
+	UserIDs []int64 `json:"user_ids,omitempty"`
+}
+
+// CustomerCreateUserRequest 对应customer.CreateUserRequest
+type CustomerCreateUserRequest struct {
+	// 部门ID
+	DepartmentID int64 `json:"department_id,omitempty"`
+	// 是否激活
+	IsActive bool `json:"is_active,omitempty"`
+	// 是否是工作人员
+	IsStaff bool `json:"is_staff,omitempty"`
+	// 密码
+	Password string `json:"password"`
+	// 角色ID
+	RoleID int64 `json:"role_id"`
+	// 用户名
+	Username string `json:"username"`
+}
+
+// CustomerDeactivateUserOut 对应customer.DeactivateUserOut
+type CustomerDeactivateUserOut struct {
+	// 用户名下仍有的记录, 需要在永久停用前转移给其他用户
+	Owned []*CustomerOwnedGroupOut `json:"owned,omitempty"`
+	// 终止的会话数量
+	RevokedSessions int64 `json:"revoked_sessions,omitempty"`
+	// 停用后的用户信息
+	User *CustomerUserStandardOut `json:"user,omitempty"`
+}
+
+// CustomerDeactivateUserReply 对应customer.DeactivateUserReply
+type CustomerDeactivateUserReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CustomerDeactivateUserOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// CustomerDeactivateUserRequest 对应customer.DeactivateUserRequest
+type CustomerDeactivateUserRequest struct {
+	// 宽限天数, 为空时使用默认的30天, 为0时在下一次定时检查时永久停用
+	GraceDays int64 `json:"grace_days,omitempty"`
+}
+
+// CustomerDeleteDependenciesOut 对应customer.DeleteDependenciesOut
+type CustomerDeleteDependenciesOut struct {
+	// 阻止删除的引用, 需要先解除引用或一起删除
+	Blocking []*CustomerDeleteDependencyOut `json:"blocking,omitempty"`
+	// 随之删除或解除关联的记录
+	Cascades []*CustomerDeleteDependencyOut `json:"cascades,omitempty"`
+	// 是否可以删除
+	Deletable bool `json:"deletable,omitempty"`
+	// 要删除的记录ID列表
+	IDs []int64 `json:"ids,omitempty"`
+	// 随之移除的Casbin组策略
+	Policies [][]string `json:"policies,omitempty"`
+}
+
+// CustomerDeleteDependenciesReply 对应customer.DeleteDependenciesReply
+type CustomerDeleteDependenciesReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CustomerDeleteDependenciesOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// CustomerDeleteDependencyOut 对应customer.DeleteDependencyOut
+type CustomerDeleteDependencyOut struct {
+	// 引用记录ID
+	ID int64 `json:"id,omitempty"`
+	// 引用记录类型, user:用户, user_group:用户组, role:角色, menu:菜单, button:按钮
+	Kind string `json:"kind,omitempty"`
+	// 引用记录名称
+	Name string `json:"name,omitempty"`
+	// 受影响的原因
+	Reason string `json:"reason,omitempty"`
+}
+
+// CustomerDeleteSessionOut 对应customer.DeleteSessionOut
+type CustomerDeleteSessionOut struct {
+	// 终止的会话数量
+	Deleted int64 `json:"deleted,omitempty"`
+}
+
+// CustomerDeleteSessionReply 对应customer.DeleteSessionReply
+type CustomerDeleteSessionReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CustomerDeleteSessionOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// CustomerDepartmentBaseOut 对应customer.DepartmentBaseOut
+type CustomerDepartmentBaseOut struct {
+	// 描述
+	Descr string `json:"descr,omitempty"`
+	// 唯一标识
+	ID int64 `json:"id,omitempty"`
+	// 名称
+	Name string `json:"name,omitempty"`
+	// 父部门ID
+	ParentID int64 `json:"parent_id,omitempty"`
+	// 排序字段
+	Sort int64 `json:"sort,omitempty"`
+}
+
+// CustomerDepartmentDetailOut 对应customer.DepartmentDetailOut
+type CustomerDepartmentDetailOut struct {
+	// 创建时间
+	CreatedAt string `json:"created_at,omitempty"`
+	// 描述
+	Descr string `json:"descr,omitempty"`
+	// 唯一标识
+	ID int64 `json:"id,omitempty"`
+	// 名称
+	Name string `json:"name,omitempty"`
+	// 父部门
+	Parent *CustomerDepartmentBaseOut `json:"parent,omitempty"`
+	// 父部门ID
+	ParentID int64 `json:"parent_id,omitempty"`
+	// 部门路径
+	Path string `json:"path,omitempty"`
+	// 排序字段
+	Sort int64 `json:"sort,omitempty"`
+	// 更新时间
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+// CustomerDepartmentReply 对应customer.DepartmentReply
+type CustomerDepartmentReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CustomerDepartmentDetailOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// CustomerDepartmentStandardOut 对应customer.DepartmentStandardOut
+type CustomerDepartmentStandardOut struct {
+	// 创建时间
+	CreatedAt string `json:"created_at,omitempty"`
+	// 描述
+	Descr string `json:"descr,omitempty"`
+	// 唯一标识
+	ID int64 `json:"id,omitempty"`
+	// 名称
+	Name string `json:"name,omitempty"`
+	// 父部门ID
+	ParentID int64 `json:"parent_id,omitempty"`
+	// 部门路径
+	Path string `json:"path,omitempty"`
+	// 排序字段
+	Sort int64 `json:"sort,omitempty"`
+	// 更新时间
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+// CustomerDepartmentTreeNode 对应customer.DepartmentTreeNode
+type CustomerDepartmentTreeNode struct {
+	// 下级部门
+	Children []*CustomerDepartmentTreeNode `json:"children,omitempty"`
+	// 描述
+	Descr string `json:"descr,omitempty"`
+	// 唯一标识
+	ID int64 `json:"id,omitempty"`
+	// 名称
+	Name string `json:"name,omitempty"`
+	// 父部门ID
+	ParentID int64 `json:"parent_id,omitempty"`
+	// 排序字段
+	Sort int64 `json:"sort,omitempty"`
+}
+
+// CustomerDepartmentTreeReply 对应customer.DepartmentTreeReply
+type CustomerDepartmentTreeReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data []*CustomerDepartmentTreeNode `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// CustomerEffectiveRoleReply 对应customer.EffectiveRoleReply
+type CustomerEffectiveRoleReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data []*CustomerRoleBaseOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// CustomerHandoverGroupOut 对应customer.HandoverGroupOut
+type CustomerHandoverGroupOut struct {
+	// 记录类型
+	Kind string `json:"kind,omitempty"`
+	// 记录类型名称
+	Label string `json:"label,omitempty"`
+	// 转移的记录数量
+	Reassigned int64 `json:"reassigned,omitempty"`
+}
+
+// CustomerHandoverReply 对应customer.HandoverReply
+type CustomerHandoverReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data []*CustomerHandoverGroupOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// CustomerHandoverRequest 对应customer.HandoverRequest
+type CustomerHandoverRequest struct {
+	// 转移的记录类型, 为空时转移全部类型
+	Kinds []string `json:"kinds"`
+	// 接收记录的用户ID
+	ToUserID int64 `json:"to_user_id"`
+}
+
+// CustomerImpersonateOut 对应customer.ImpersonateOut
+type CustomerImpersonateOut struct {
+	// 代登录访问令牌, 到期后不能刷新
+	AccessToken string `json:"access_token,omitempty"`
+	// 令牌过期时间
+	ExpiresAt string `json:"expires_at,omitempty"`
+	// 代为登录的管理员用户名
+	Impersonator string `json:"impersonator,omitempty"`
+	// 代登录会话ID, 可通过终止会话提前结束代登录
+	SessionID string `json:"session_id,omitempty"`
+	// 被代登录的用户名
+	Username string `json:"username,omitempty"`
+}
+
+// CustomerImpersonateReply 对应customer.ImpersonateReply
+type CustomerImpersonateReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CustomerImpersonateOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// CustomerLoginOut 对应customer.LoginOut
+type CustomerLoginOut struct {
+	// 登录令牌
+	AccessToken string `json:"access_token,omitempty"`
+	// 刷新令牌
+	RefreshToken string `json:"refresh_token,omitempty"`
+}
+
+// CustomerLoginRecordStandardOut 对应customer.LoginRecordStandardOut
+type CustomerLoginRecordStandardOut struct {
+	// 唯一标识
+	ID int64 `json:"id,omitempty"`
+	// IP地址, 没有查看敏感字段权限时掩码
+	IPAddress string `json:"ip_address,omitempty"`
+	// 登录状态
+	IsActive bool `json:"is_active,omitempty"`
+	// 登录时间
+	LoginAt string `json:"login_at,omitempty"`
+	// 用户浏览器信息
+	UserAgent string `json:"user_agent,omitempty"`
+	// 名称
+	Username string `json:"username,omitempty"`
+}
+
+// CustomerLoginReply 对应customer.LoginReply
+type CustomerLoginReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CustomerLoginOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// CustomerLoginRequest 对应customer.LoginRequest
+type CustomerLoginRequest struct {
+	// 验证码答案
+	CaptchaAnswer string `json:"captcha_answer,omitempty"`
+	// 验证码挑战ID, 登录失败次数过多时必填
+	CaptchaID string `json:"captcha_id,omitempty"`
+	// 密码
+	Password string `json:"password"`
+	// 用户名
+	Username string `json:"username"`
+}
+
+// CustomerMenuDetailOut 对应customer.MenuDetailOut
+type CustomerMenuDetailOut struct {
+	// API ID列表
+	APIIDs []int64 `json:"api_ids,omitempty"`
+	// 组件路径
+	Component string `json:"component,omitempty"`
+	// 创建时间
+	CreatedAt string `json:"created_at,omitempty"`
+	// 描述
+	Descr string `json:"descr,omitempty"`
+	// 唯一标识
+	ID int64 `json:"id,omitempty"`
+	// 是否激活
+	IsActive bool `json:"is_active,omitempty"`
+	// 菜单信息
+	Meta *CustomerMetaSchemas `json:"meta,omitempty"`
+	// 名称
+	Name string `json:"name,omitempty"`
+	// 父级菜单
+	Parent *CustomerMenuStandardOut `json:"parent,omitempty"`
+	// 前端路由
+	Path string `json:"path,omitempty"`
+	// 排序字段
+	Sort int64 `json:"sort,omitempty"`
+	// 更新时间
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+// CustomerMenuReply 对应customer.MenuReply
+type CustomerMenuReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CustomerMenuDetailOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// CustomerMenuStandardOut 对应customer.MenuStandardOut
+type CustomerMenuStandardOut struct {
+	// 组件路径
+	Component string `json:"component,omitempty"`
+	// 创建时间
+	CreatedAt string `json:"created_at,omitempty"`
+	// 描述
+	Descr string `json:"descr,omitempty"`
+	// 唯一标识
+	ID int64 `json:"id,omitempty"`
+	// 是否激活
+	IsActive bool `json:"is_active,omitempty"`
+	// 菜单信息
+	Meta *CustomerMetaSchemas `json:"meta,omitempty"`
+	// 名称
+	Name string `json:"name,omitempty"`
+	// 前端路由
+	Path string `json:"path,omitempty"`
+	// 排序字段
+	Sort int64 `json:"sort,omitempty"`
+	// 更新时间
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+// CustomerMenuTreeNode 对应customer.MenuTreeNode
+type CustomerMenuTreeNode struct {
+	// 按钮
+	Buttons []*CustomerButtonBaseOut `json:"buttons,omitempty"`
+	// 子菜单
+	Children []*CustomerMenuTreeNode `json:"children,omitempty"`
+	// 组件路径
+	Component string `json:"component,omitempty"`
+	// 描述
+	Descr string `json:"descr,omitempty"`
+	// 唯一标识
+	ID int64 `json:"id,omitempty"`
+	// 是否激活
+	IsActive bool `json:"is_active,omitempty"`
+	// 菜单信息
+	Meta *CustomerMetaSchemas `json:"meta,omitempty"`
+	// 名称
+	Name string `json:"name,omitempty"`
+	// 前端路由
+	Path string `json:"path,omitempty"`
+	// 排序字段
+	Sort int64 `json:"sort,omitempty"`
+}
+
+// CustomerMetaSchemas 对应customer.MetaSchemas
+type CustomerMetaSchemas struct {
+	// 图标
+	Icon string `json:"icon,omitempty"`
+	// 标题
+	Title string `json:"title,omitempty"`
+}
+
+// CustomerMoveDepartmentRequest 对应customer.MoveDepartmentRequest
+type CustomerMoveDepartmentRequest struct {
+	// 新的父部门ID, 为空或0时移动为根部门
+	ParentID int64 `json:"parent_id,omitempty"`
+}
+
+// CustomerOidcLoginOut 对应customer.OidcLoginOut
+type CustomerOidcLoginOut struct {
+	// 授权地址
+	AuthURL string `json:"auth_url,omitempty"`
+}
+
+// CustomerOidcLoginReply 对应customer.OidcLoginReply
+type CustomerOidcLoginReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CustomerOidcLoginOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// CustomerOidcProviderOut 对应customer.OidcProviderOut
+type CustomerOidcProviderOut struct {
+	// 显示名称
+	DisplayName string `json:"display_name,omitempty"`
+	// 标识
+	Name string `json:"name,omitempty"`
+}
+
+// CustomerOidcProvidersOut 对应customer.OidcProvidersOut
+type CustomerOidcProvidersOut struct {
+	// 是否允许本地用户名密码登录
+	LocalLogin bool `json:"local_login,omitempty"`
+	// 身份提供方
+	Providers []*CustomerOidcProviderOut `json:"providers,omitempty"`
+}
+
+// CustomerOidcProvidersReply 对应customer.OidcProvidersReply
+type CustomerOidcProvidersReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CustomerOidcProvidersOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// CustomerOwnedGroupOut 对应customer.OwnedGroupOut
+type CustomerOwnedGroupOut struct {
+	// 记录列表, 最多列出50条
+	Items []*CustomerOwnedObjectOut `json:"items,omitempty"`
+	// 记录类型
+	Kind string `json:"kind,omitempty"`
+	// 记录类型名称
+	Label string `json:"label,omitempty"`
+	// 记录总数
+	Total int64 `json:"total,omitempty"`
+}
+
+// CustomerOwnedObjectOut 对应customer.OwnedObjectOut
+type CustomerOwnedObjectOut struct {
+	// 记录ID
+	ID int64 `json:"id,omitempty"`
+	// 记录名称
+	Name string `json:"name,omitempty"`
+}
+
+// CustomerOwnedReply 对应customer.OwnedReply
+type CustomerOwnedReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data []*CustomerOwnedGroupOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// CustomerPagApiReply 对应customer.PagApiReply
+type CustomerPagApiReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CommonPagCustomerApiStandardOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// CustomerPagButtonReply 对应customer.PagButtonReply
+type CustomerPagButtonReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CommonPagCustomerButtonStandardOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// CustomerPagCasbinModelReply 对应customer.PagCasbinModelReply
+type CustomerPagCasbinModelReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CommonPagCustomerCasbinModelOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// CustomerPagDepartmentReply 对应customer.PagDepartmentReply
+type CustomerPagDepartmentReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CommonPagCustomerDepartmentStandardOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// CustomerPagLoginRecordReply 对应customer.PagLoginRecordReply
+type CustomerPagLoginRecordReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CommonPagCustomerLoginRecordStandardOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// CustomerPagMenuReply 对应customer.PagMenuReply
+type CustomerPagMenuReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CommonPagCustomerMenuStandardOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// CustomerPagRoleReply 对应customer.PagRoleReply
+type CustomerPagRoleReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CommonPagCustomerRoleStandardOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// CustomerPagSigningKeyReply 对应customer.PagSigningKeyReply
+type CustomerPagSigningKeyReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CommonPagCustomerSigningKeyOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// CustomerPagUserGroupReply 对应customer.PagUserGroupReply
+type CustomerPagUserGroupReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CommonPagCustomerUserGroupStandardOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// CustomerPagUserReply 对应customer.PagUserReply
+type CustomerPagUserReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CommonPagCustomerUserDetailOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// CustomerPasswordHashCountOut 对应customer.PasswordHashCountOut
+type CustomerPasswordHashCountOut struct {
+	// 哈希算法, 无法识别时为unknown
+	Algorithm string `json:"algorithm,omitempty"`
+	// 未使用当前算法和参数的用户数量
+	Legacy int64 `json:"legacy,omitempty"`
+	// 用户数量
+	Total int64 `json:"total,omitempty"`
+}
+
+// CustomerPasswordHashReportOut 对应customer.PasswordHashReportOut
+type CustomerPasswordHashReportOut struct {
+	// 当前配置的哈希算法
+	Algorithm string `json:"algorithm,omitempty"`
+	// 按算法分组的统计
+	Algorithms []*CustomerPasswordHashCountOut `json:"algorithms,omitempty"`
+	// 已使用当前算法和参数的用户数量
+	Current int64 `json:"current,omitempty"`
+	// 仍使用旧算法或旧参数的用户数量, 这些用户下次登录成功时重新哈希
+	Legacy int64 `json:"legacy,omitempty"`
+	// 用户总数
+	Total int64 `json:"total,omitempty"`
+}
+
+// CustomerPasswordHashReportReply 对应customer.PasswordHashReportReply
+type CustomerPasswordHashReportReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CustomerPasswordHashReportOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// CustomerPatchPasswordRequest 对应customer.PatchPasswordRequest
+type CustomerPatchPasswordRequest struct {
+	// 确认密码
+	ConfirmPassword string `json:"confirm_password"`
+	// 新密码
+	NewPassword string `json:"new_password"`
+	// 原密码
+	OldPassword string `json:"old_password"`
+}
+
+// CustomerPreferenceOut 对应customer.PreferenceOut
+type CustomerPreferenceOut struct {
+	// 命名空间
+	Namespace string `json:"namespace,omitempty"`
+	// 更新时间
+	UpdatedAt string `json:"updated_at,omitempty"`
+	// 偏好内容
+	Value map[string]any `json:"value,omitempty"`
+}
+
+// CustomerPreferenceReply 对应customer.PreferenceReply
+type CustomerPreferenceReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CustomerPreferenceOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// CustomerPreferencesReply 对应customer.PreferencesReply
+type CustomerPreferencesReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data []*CustomerPreferenceOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// CustomerRbacImportItemOut 对应customer.RbacImportItemOut
+type CustomerRbacImportItemOut struct {
+	// 处理动作(create/update/unchanged/skip/conflict)
+	Action string `json:"action,omitempty"`
+	// 与已有数据不同的字段
+	Fields []string `json:"fields,omitempty"`
+	// 引用标识
+	Key string `json:"key,omitempty"`
+	// 类型(casbin_model/api/menu/button/role)
+	Kind string `json:"kind,omitempty"`
+}
+
+// CustomerRbacImportOut 对应customer.RbacImportOut
+type CustomerRbacImportOut struct {
+	// 是否已写入
+	Applied bool `json:"applied,omitempty"`
+	// 冲突处理方式
+	Conflict string `json:"conflict,omitempty"`
+	// 是否只比较差异
+	DryRun bool `json:"dry_run,omitempty"`
+	// 各条目的处理结果, 不包含未变化的条目
+	Items []*CustomerRbacImportItemOut `json:"items,omitempty"`
+	// 导入前后Casbin策略的差异(unified diff)
+	PolicyDiff string `json:"policy_diff,omitempty"`
+	// 各处理动作的条目数
+	Summary map[string]int64 `json:"summary,omitempty"`
+}
+
+// CustomerRbacImportReply 对应customer.RbacImportReply
+type CustomerRbacImportReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CustomerRbacImportOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// CustomerRefreshTokenRequest 对应customer.RefreshTokenRequest
+type CustomerRefreshTokenRequest struct {
+	// 刷新令牌, 开启Cookie认证时可以为空, 从Cookie中读取
+	RefreshToken string `json:"refresh_token,omitempty"`
+}
+
+// CustomerResetPasswordRequest 对应customer.ResetPasswordRequest
+type CustomerResetPasswordRequest struct {
+	// 确认密码
+	ConfirmPassword string `json:"confirm_password"`
+	// 新密码
+	NewPassword string `json:"new_password"`
+}
+
+// CustomerRoleBaseOut 对应customer.RoleBaseOut
+type CustomerRoleBaseOut struct {
+	// 数据范围
+	DataScope string `json:"data_scope,omitempty"`
+	// 描述
+	Descr string `json:"descr,omitempty"`
+	// 唯一标识
+	ID int64 `json:"id,omitempty"`
+	// 名称
+	Name string `json:"name,omitempty"`
+}
+
+// CustomerRoleDetailOut 对应customer.RoleDetailOut
+type CustomerRoleDetailOut struct {
+	// APIID列表
+	APIIDs []int64 `json:"api_ids,omitempty"`
+	// 按钮ID列表
+	ButtonIDs []int64 `json:"button_ids,omitempty"`
+	// 创建时间
+	CreatedAt string `json:"created_at,omitempty"`
+	// 数据范围
+	DataScope string `json:"data_scope,omitempty"`
+	// 描述
+	Descr string `json:"descr,omitempty"`
+	// 唯一标识
+	ID int64 `json:"id,omitempty"`
+	// 菜单ID列表
+	MenuIDs []int64 `json:"menu_ids,omitempty"`
+	// 名称
+	Name string `json:"name,omitempty"`
+	// 更新时间
+	UpdatedAt string `json:"updated_at,omitempty"`
+	// 版本号
+	Version int64 `json:"version,omitempty"`
+}
+
+// CustomerRoleMenuTreeReply 对应customer.RoleMenuTreeReply
+type CustomerRoleMenuTreeReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data []*CustomerMenuTreeNode `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// CustomerRoleReply 对应customer.RoleReply
+type CustomerRoleReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CustomerRoleDetailOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// CustomerRoleStandardOut 对应customer.RoleStandardOut
+type CustomerRoleStandardOut struct {
+	// 创建时间
+	CreatedAt string `json:"created_at,omitempty"`
+	// 数据范围
+	DataScope string `json:"data_scope,omitempty"`
+	// 描述
+	Descr string `json:"descr,omitempty"`
+	// 唯一标识
+	ID int64 `json:"id,omitempty"`
+	// 名称
+	Name string `json:"name,omitempty"`
+	// 更新时间
+	UpdatedAt string `json:"updated_at,omitempty"`
+	// 版本号
+	Version int64 `json:"version,omitempty"`
+}
+
+// CustomerRotateSigningKeyRequest 对应customer.RotateSigningKeyRequest
+type CustomerRotateSigningKeyRequest struct {
+	// 是否立即停用旧密钥, 用于密钥泄露的场景, 旧密钥签发的令牌将立即失效
+	RevokePrevious bool `json:"revoke_previous,omitempty"`
+	// 令牌类型(access/refresh), 为空时同时轮换两种令牌的密钥
+	TokenType string `json:"token_type,omitempty"`
+}
+
+// CustomerSigningKeyOut 对应customer.SigningKeyOut
+type CustomerSigningKeyOut struct {
+	// 签名方法
+	Algorithm string `json:"algorithm,omitempty"`
+	// 创建时间
+	CreatedAt string `json:"created_at,omitempty"`
+	// ID
+	ID int64 `json:"id,omitempty"`
+	// 密钥标识
+	Kid string `json:"kid,omitempty"`
+	// 操作人
+	Operator string `json:"operator,omitempty"`
+	// 公钥, HMAC密钥为空
+	PublicKey string `json:"public_key,omitempty"`
+	// 停用时间
+	RetireAt string `json:"retire_at,omitempty"`
+	// 状态(current/retiring/retired)
+	Status string `json:"status,omitempty"`
+	// 令牌类型
+	TokenType string `json:"token_type,omitempty"`
+}
+
+// CustomerSigningKeyRotateOut 对应customer.SigningKeyRotateOut
+type CustomerSigningKeyRotateOut struct {
+	// 新生成的密钥
+	Keys []*CustomerSigningKeyOut `json:"keys,omitempty"`
+}
+
+// CustomerSigningKeyRotateReply 对应customer.SigningKeyRotateReply
+type CustomerSigningKeyRotateReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CustomerSigningKeyRotateOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// CustomerSyncApiRequest 对应customer.SyncApiRequest
+type CustomerSyncApiRequest struct {
+	// 是否按模块名重新设置已有API的标签
+	TagModule bool `json:"tag_module,omitempty"`
+}
+
+// CustomerUpdateApiRequest 对应customer.UpdateApiRequest
+type CustomerUpdateApiRequest struct {
+	// 描述信息
+	Descr string `json:"descr,omitempty"`
+	// 标签
+	Label string `json:"label"`
+	// 请求方法
+	Method string `json:"method"`
+	// URL地址
+	URL string `json:"url"`
+}
+
+// CustomerUpdateButtonRequest 对应customer.UpdateButtonRequest
+type CustomerUpdateButtonRequest struct {
+	// API ID列表
+	APIIDs []int64 `json:"api_ids,omitempty"`
+	// 描述信息
+	Descr string `json:"descr,omitempty"`
+	// 是否激活
+	IsActive bool `json:"is_active,omitempty"`
+	// 菜单ID
+	MenuID int64 `json:"menu_id"`
+	// 名称
+	Name string `json:"name"`
+	// 排序字段
+	Sort int64 `json:"sort,omitempty"`
+}
+
+// CustomerUpdateCasbinModelRequest 对应customer.UpdateCasbinModelRequest
+type CustomerUpdateCasbinModelRequest struct {
+	// 模型配置
+	Content string `json:"content"`
+	// 变更说明
+	Remark string `json:"remark,omitempty"`
+	// 样例请求，使用当前策略和新模型求值并与期望结果比对
+	Samples []*AuthCasbinSample `json:"samples,omitempty"`
+}
+
+// CustomerUpdateMenuRequest 对应customer.UpdateMenuRequest
+type CustomerUpdateMenuRequest struct {
+	// API ID列表
+	APIIDs []int64 `json:"api_ids,omitempty"`
+	// 组件路径
+	Component string `json:"component"`
+	// 描述信息
+	Descr string `json:"descr,omitempty"`
+	// 是否激活
+	IsActive bool `json:"is_active,omitempty"`
+	// 菜单元信息
+	Meta *CustomerMetaSchemas `json:"meta"`
+	// 名称
+	Name string `json:"name"`
+	// 父级菜单ID
+	ParentID int64 `json:"parent_id,omitempty"`
+	// 前端路由路径
+	Path string `json:"path"`
+	// 排序字段
+	Sort int64 `json:"sort"`
+}
+
+// CustomerUpdateUserRequest 对应customer.UpdateUserRequest
+type CustomerUpdateUserRequest struct {
+	// 是否激活
+	IsActive bool `json:"is_active,omitempty"`
+	// 是否是工作人员
+	IsStaff bool `json:"is_staff,omitempty"`
+	// 角色ID
+	RoleID int64 `json:"role_id"`
+	// 用户名
+	Username string `json:"username"`
+}
+
+// CustomerUserDetailOut 对应customer.UserDetailOut
+type CustomerUserDetailOut struct {
+	// 创建时间
+	CreatedAt string `json:"created_at,omitempty"`
+	// 停用时间, 未停用时为空
+	DeactivatedAt string `json:"deactivated_at,omitempty"`
+	// 部门
+	Department *CustomerDepartmentBaseOut `json:"department,omitempty"`
+	// 部门ID
+	DepartmentID int64 `json:"department_id,omitempty"`
+	// 计划永久停用时间, 此前可以重新启用用户
+	DisableAt string `json:"disable_at,omitempty"`
+	// 永久停用时间, 永久停用后不能重新启用
+	DisabledAt string `json:"disabled_at,omitempty"`
+	// 唯一标识
+	ID int64 `json:"id,omitempty"`
+	// 是否激活
+	IsActive bool `json:"is_active,omitempty"`
+	// 是否是工作人员
+	IsStaff bool `json:"is_staff,omitempty"`
+	// 是否需要修改密码
+	MustChangePassword bool `json:"must_change_password,omitempty"`
+	// 角色
+	Role *CustomerRoleBaseOut `json:"role,omitempty"`
+	// 更新时间
+	UpdatedAt string `json:"updated_at,omitempty"`
+	// 用户名
+	Username string `json:"username,omitempty"`
+}
+
+// CustomerUserGroupDetailOut 对应customer.UserGroupDetailOut
+type CustomerUserGroupDetailOut struct {
+	// 创建时间
+	CreatedAt string `json:"created_at,omitempty"`
+	// 描述
+	Descr string `json:"descr,omitempty"`
+	// 唯一标识
+	ID int64 `json:"id,omitempty"`
+	// 名称
+	Name string `json:"name,omitempty"`
+	// 角色ID列表
+	RoleIDs []int64 `json:"role_ids,omitempty"`
+	// 更新时间
+	UpdatedAt string `json:"updated_at,omitempty"`
+	// 用户ID列表
+	UserIDs []int64 `json:"user_ids,omitempty"`
+}
+
+// CustomerUserGroupMemberRequest 对应customer.UserGroupMemberRequest
+type CustomerUserGroupMemberRequest struct {
+	// 用户ID列表
+	UserIDs []int64 `json:"user_ids"`
+}
+
+// CustomerUserGroupReply 对应customer.UserGroupReply
+type CustomerUserGroupReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CustomerUserGroupDetailOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// CustomerUserGroupStandardOut 对应customer.UserGroupStandardOut
+type CustomerUserGroupStandardOut struct {
+	// 创建时间
+	CreatedAt string `json:"created_at,omitempty"`
+	// 描述
+	Descr string `json:"descr,omitempty"`
+	// 唯一标识
+	ID int64 `json:"id,omitempty"`
+	// 名称
+	Name string `json:"name,omitempty"`
+	// 更新时间
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+// CustomerUserIdentitiesReply 对应customer.UserIdentitiesReply
+type CustomerUserIdentitiesReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data []*CustomerUserIdentityOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// CustomerUserIdentityOut 对应customer.UserIdentityOut
+type CustomerUserIdentityOut struct {
+	// 关联时间
+	CreatedAt string `json:"created_at,omitempty"`
+	// 邮箱
+	Email string `json:"email,omitempty"`
+	// 最近登录时间
+	LastLoginAt string `json:"last_login_at,omitempty"`
+	// 身份提供方
+	Provider string `json:"provider,omitempty"`
+	// 外部身份标识
+	Subject string `json:"subject,omitempty"`
+}
+
+// CustomerUserReply 对应customer.UserReply
+type CustomerUserReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CustomerUserDetailOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// CustomerUserSessionOut 对应customer.UserSessionOut
+type CustomerUserSessionOut struct {
+	// 访问令牌过期时间
+	AccessExpiresAt string `json:"access_expires_at,omitempty"`
+	// 访问令牌ID
+	AccessID string `json:"access_id,omitempty"`
+	// 是否为当前请求所属的会话
+	Current bool `json:"current,omitempty"`
+	// 设备
+	Device string `json:"device,omitempty"`
+	// 会话过期时间
+	ExpiresAt string `json:"expires_at,omitempty"`
+	// IP地址, 没有查看敏感字段权限时掩码
+	IPAddress string `json:"ip_address,omitempty"`
+	// 登录时间
+	IssuedAt string `json:"issued_at,omitempty"`
+	// 登录方式
+	Method string `json:"method,omitempty"`
+	// 刷新令牌ID
+	RefreshID string `json:"refresh_id,omitempty"`
+	// 最近签发令牌时间
+	RefreshedAt string `json:"refreshed_at,omitempty"`
+	// 会话ID
+	SessionID string `json:"session_id,omitempty"`
+	// 客户端信息
+	UserAgent string `json:"user_agent,omitempty"`
+}
+
+// CustomerUserSessionsReply 对应customer.UserSessionsReply
+type CustomerUserSessionsReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data []*CustomerUserSessionOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// CustomerUserStandardOut 对应customer.UserStandardOut
+type CustomerUserStandardOut struct {
+	// 创建时间
+	CreatedAt string `json:"created_at,omitempty"`
+	// 停用时间, 未停用时为空
+	DeactivatedAt string `json:"deactivated_at,omitempty"`
+	// 部门ID
+	DepartmentID int64 `json:"department_id,omitempty"`
+	// 计划永久停用时间, 此前可以重新启用用户
+	DisableAt string `json:"disable_at,omitempty"`
+	// 永久停用时间, 永久停用后不能重新启用
+	DisabledAt string `json:"disabled_at,omitempty"`
+	// 唯一标识
+	ID int64 `json:"id,omitempty"`
+	// 是否激活
+	IsActive bool `json:"is_active,omitempty"`
+	// 是否是工作人员
+	IsStaff bool `json:"is_staff,omitempty"`
+	// 是否需要修改密码
+	MustChangePassword bool `json:"must_change_password,omitempty"`
+	// 更新时间
+	UpdatedAt string `json:"updated_at,omitempty"`
+	// 用户名
+	Username string `json:"username,omitempty"`
+}
+
+// CustomerValidateCasbinModelRequest 对应customer.ValidateCasbinModelRequest
+type CustomerValidateCasbinModelRequest struct {
+	// 模型配置
+	Content string `json:"content"`
+	// 样例请求，使用当前策略和新模型求值并与期望结果比对
+	Samples []*AuthCasbinSample `json:"samples,omitempty"`
+}
+
+// FileutilFileInfo 对应fileutil.FileInfo
+type FileutilFileInfo struct {
+	Children []*FileutilFileInfo `json:"children,omitempty"`
+	IsDir    bool                `json:"is_dir,omitempty"`
+	// 标准化时间格式（RFC3339）
+	ModTime string `json:"mod_time,omitempty"`
+	Name    string `json:"name,omitempty"`
+	Size    int64  `json:"size,omitempty"`
+}
+
+// JobsArtifactOut 对应jobs.ArtifactOut
+type JobsArtifactOut struct {
+	// SHA256校验和
+	Checksum string `json:"checksum,omitempty"`
+	// 收集时间
+	CreatedAt string `json:"created_at,omitempty"`
+	// ID
+	ID int64 `json:"id,omitempty"`
+	// 相对于工作目录的文件路径
+	Name string `json:"name,omitempty"`
+	// 执行记录ID
+	RecordID int64 `json:"record_id,omitempty"`
+	// 文件大小(字节)
+	Size int64 `json:"size,omitempty"`
+}
+
+// JobsCreateScheduleRequest 对应jobs.CreateScheduleRequest
+type JobsCreateScheduleRequest struct {
+	// 交易日历选项(trading_day: 仅交易日执行, skip_holiday: 跳过节假日), 为空时不受限制
+	CalendarMode string `json:"calendar_mode,omitempty"`
+	// 绑定的oes集群号, 触发时注入集群上下文的环境变量(COLONY_NUM、SYSTEM_TYPE、MON_NODE_IP、PACKAGE_VERSION)
+	Colony string `json:"colony,omitempty"`
+	// 命令行参数
+	CommandArgs string `json:"command_args,omitempty"`
+	// 环境变量(JSON对象)
+	EnvVars string `json:"env_vars,omitempty"`
+	// 是否启用
+	IsEnabled bool `json:"is_enabled,omitempty"`
+	// 是否重试
+	IsRetry bool `json:"is_retry,omitempty"`
+	// 隔离执行配置, 为空时使用脚本的配置
+	Isolation *JobsIsolationSpec `json:"isolation,omitempty"`
+	// 最大重试次数
+	MaxRetries int64 `json:"max_retries,omitempty"`
+	// 计划任务名称
+	Name string `json:"name"`
+	// 脚本参数, 按脚本的参数定义校验, 日期参数的默认值today在每次触发时取当天
+	Params map[string]string `json:"params,omitempty"`
+	// 重试间隔时间(秒)
+	RetryInterval int64 `json:"retry_interval,omitempty"`
+	// 脚本ID
+	ScriptID int64 `json:"script_id"`
+	// Cron 表达式
+	Specification string `json:"specification"`
+	// 超时时间(秒)
+	Timeout int64 `json:"timeout,omitempty"`
+	// 工作目录
+	WorkDir string `json:"work_dir,omitempty"`
+}
+
+// JobsCreateScriptRecordRequest 对应jobs.CreateScriptRecordRequest
+type JobsCreateScriptRecordRequest struct {
+	// 命令行参数
+	CommandArgs string `json:"command_args,omitempty"`
+	// 环境变量 (JSON对象)
+	EnvVars string `json:"env_vars,omitempty"`
+	// 脚本参数, 按脚本的参数定义校验, 未提交的参数使用默认值
+	Params map[string]string `json:"params,omitempty"`
+	// 脚本ID
+	ScriptID int64 `json:"script_id"`
+	// 超时时间(秒)
+	Timeout int64 `json:"timeout"`
+	// 工作目录
+	WorkDir string `json:"work_dir,omitempty"`
+}
+
+// JobsEnvPreviewItemOut 对应jobs.EnvPreviewItemOut
+type JobsEnvPreviewItemOut struct {
+	// 变量名
+	Name string `json:"name,omitempty"`
+	// 来源(colony: 集群上下文, schedule: 计划任务配置)
+	Source string `json:"source,omitempty"`
+	// 计划任务配置的变量值是否引用了集群上下文变量(${NAME})
+	Templated bool `json:"templated,omitempty"`
+	// 变量值, 计划任务配置的变量值已掩码
+	Value string `json:"value,omitempty"`
+}
+
+// JobsEnvPreviewOut 对应jobs.EnvPreviewOut
+type JobsEnvPreviewOut struct {
+	// 绑定的oes集群号
+	Colony string `json:"colony,omitempty"`
+	// 触发时注入的环境变量, 按变量名排序
+	Env []*JobsEnvPreviewItemOut `json:"env,omitempty"`
+	// 计划任务ID
+	ScheduleID int64 `json:"schedule_id,omitempty"`
+}
+
+// JobsEnvPreviewReply 对应jobs.EnvPreviewReply
+type JobsEnvPreviewReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *JobsEnvPreviewOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// JobsEnvVarsOut 对应jobs.EnvVarsOut
+type JobsEnvVarsOut struct {
+	// 环境变量(JSON对象)
+	EnvVars string `json:"env_vars,omitempty"`
+}
+
+// JobsEnvVarsReply 对应jobs.EnvVarsReply
+type JobsEnvVarsReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *JobsEnvVarsOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// JobsImportTradingCalendarRequest 对应jobs.ImportTradingCalendarRequest
+type JobsImportTradingCalendarRequest struct {
+	// 节假日列表
+	Holidays []*JobsTradingHolidayItem `json:"holidays"`
+	// 年份
+	Year int64 `json:"year"`
+}
+
+// JobsIsolationSpec 对应jobs.IsolationSpec
+type JobsIsolationSpec struct {
+	// CPU核数上限, 为0时不限制
+	Cpus float64 `json:"cpus,omitempty"`
+	// 容器镜像, 为空时使用配置的默认镜像, 仅container方式
+	Image string `json:"image,omitempty"`
+	// 内存上限(MB), 为0时不限制
+	MemoryMb int64 `json:"memory_mb,omitempty"`
+	// 隔离方式(container: 容器, cgroup: 限制资源), 为空时不隔离
+	Mode string `json:"mode,omitempty"`
+	// 进程数上限, 为0时不限制
+	PidsLimit int64 `json:"pids_limit,omitempty"`
+	// 执行用户, 为空时使用配置的默认用户, 只能使用配置允许的用户
+	User string `json:"user,omitempty"`
+}
+
+// JobsListArtifactReply 对应jobs.ListArtifactReply
+type JobsListArtifactReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data []*JobsArtifactOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// JobsListLableReply 对应jobs.ListLableReply
+type JobsListLableReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data []string `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// JobsListProjectReply 对应jobs.ListProjectReply
+type JobsListProjectReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data []string `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// JobsPagScheduleReply 对应jobs.PagScheduleReply
+type JobsPagScheduleReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CommonPagJobsScheduleDetailOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// JobsPagScheduleSkipReply 对应jobs.PagScheduleSkipReply
+type JobsPagScheduleSkipReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CommonPagJobsScheduleSkipOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// JobsPagScriptRecordReply 对应jobs.PagScriptRecordReply
+type JobsPagScriptRecordReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CommonPagJobsScriptRecordDetailOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// JobsPagScriptReply 对应jobs.PagScriptReply
+type JobsPagScriptReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CommonPagJobsScriptStandardOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// JobsPagTradingHolidayReply 对应jobs.PagTradingHolidayReply
+type JobsPagTradingHolidayReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CommonPagJobsTradingHolidayOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// JobsScheduleDetailOut 对应jobs.ScheduleDetailOut
+type JobsScheduleDetailOut struct {
+	// 交易日历选项
+	CalendarMode string `json:"calendar_mode,omitempty"`
+	// 绑定的oes集群号
+	Colony string `json:"colony,omitempty"`
+	// 命令行参数
+	CommandArgs string `json:"command_args,omitempty"`
+	// 创建时间
+	CreatedAt string `json:"created_at,omitempty"`
+	// 环境变量(JSON对象), 变量值已掩码
+	EnvVars string `json:"env_vars,omitempty"`
+	// 计划任务ID
+	ID int64 `json:"id,omitempty"`
+	// 是否启用
+	IsEnabled bool `json:"is_enabled,omitempty"`
+	// 是否重试
+	IsRetry bool `json:"is_retry,omitempty"`
+	// 隔离执行配置, 为空时使用脚本的配置
+	Isolation *JobsIsolationSpec `json:"isolation,omitempty"`
+	// 最大重试次数
+	MaxRetries int64 `json:"max_retries,omitempty"`
+	// 名称
+	Name string `json:"name,omitempty"`
+	// 脚本参数
+	Params map[string]string `json:"params,omitempty"`
+	// 重试间隔时间(秒)
+	RetryInterval int64 `json:"retry_interval,omitempty"`
+	// 脚本
+	Script *JobsScriptStandardOut `json:"script,omitempty"`
+	// Cron 表达式
+	Specification string `json:"specification,omitempty"`
+	// 超时时间(秒)
+	Timeout int64 `json:"timeout,omitempty"`
+	// 更新时间
+	UpdatedAt string `json:"updated_at,omitempty"`
+	// 用户名
+	Username string `json:"username,omitempty"`
+	// 工作目录
+	WorkDir string `json:"work_dir,omitempty"`
+}
+
+// JobsScheduleReply 对应jobs.ScheduleReply
+type JobsScheduleReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *JobsScheduleDetailOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// JobsScheduleSkipOut 对应jobs.ScheduleSkipOut
+type JobsScheduleSkipOut struct {
+	// 交易日历选项
+	CalendarMode string `json:"calendar_mode,omitempty"`
+	// 跳过说明
+	Detail string `json:"detail,omitempty"`
+	// ID
+	ID int64 `json:"id,omitempty"`
+	// 跳过原因(weekend/holiday)
+	Reason string `json:"reason,omitempty"`
+	// 计划任务ID
+	ScheduleID int64 `json:"schedule_id,omitempty"`
+	// 计划任务名称
+	ScheduleName string `json:"schedule_name,omitempty"`
+	// 跳过时间
+	SkippedAt string `json:"skipped_at,omitempty"`
+}
+
+// JobsScriptDiagnosticOut 对应jobs.ScriptDiagnosticOut
+type JobsScriptDiagnosticOut struct {
+	// 规则编号
+	Code string `json:"code,omitempty"`
+	// 列号, 0表示未知
+	Column int64 `json:"column,omitempty"`
+	// 级别(error, warning, info)
+	Level string `json:"level,omitempty"`
+	// 行号, 0表示与具体行无关
+	Line int64 `json:"line,omitempty"`
+	// 说明
+	Message string `json:"message,omitempty"`
+	// 检查来源(builtin, bash, sh, python3, shellcheck)
+	Source string `json:"source,omitempty"`
+}
+
+// JobsScriptDryRunOut 对应jobs.ScriptDryRunOut
+type JobsScriptDryRunOut struct {
+	// 耗时(秒)
+	Duration float64 `json:"duration,omitempty"`
+	// 退出码, 超时时为-1
+	ExitCode int64 `json:"exit_code,omitempty"`
+	// 标准输出和标准错误
+	Output string `json:"output,omitempty"`
+	// 是否超时
+	TimedOut bool `json:"timed_out,omitempty"`
+	// 输出是否被截断
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// JobsScriptParam 对应jobs.ScriptParam
+type JobsScriptParam struct {
+	// 默认值
+	Default string `json:"default,omitempty"`
+	// 可选值, 类型为enum时必填
+	Enum []string `json:"enum,omitempty"`
+	// 传入方式(env, arg), 默认为env
+	Inject string `json:"inject,omitempty"`
+	// 显示名称
+	Label string `json:"label,omitempty"`
+	// 参数名, 小写字母开头, 只能包含小写字母、数字和下划线
+	Name string `json:"name,omitempty"`
+	// 是否必填
+	Required bool `json:"required,omitempty"`
+	// 类型(string, int, bool, date, enum)
+	Type string `json:"type,omitempty"`
+}
+
+// JobsScriptParamValue 对应jobs.ScriptParamValue
+type JobsScriptParamValue struct {
+	// 传入方式(env, arg)
+	Inject string `json:"inject,omitempty"`
+	// 参数名
+	Name string `json:"name,omitempty"`
+	// 参数值
+	Value string `json:"value,omitempty"`
+}
+
+// JobsScriptRecordDetailOut 对应jobs.ScriptRecordDetailOut
+type JobsScriptRecordDetailOut struct {
+	// 命令行参数
+	CommandArgs string `json:"command_args,omitempty"`
+	// 创建时间
+	CreatedAt string `json:"created_at,omitempty"`
+	// 环境变量(JSON对象), 变量值已掩码
+	EnvVars string `json:"env_vars,omitempty"`
+	// 错误信息
+	ErrorMessage string `json:"error_message,omitempty"`
+	// 退出码
+	ExitCode int64 `json:"exit_code,omitempty"`
+	// 失败原因(oom_killed: 超出内存限制, limit_killed: 超出其他资源限制), 不是因资源限制失败时为空
+	FailureReason string `json:"failure_reason,omitempty"`
+	// 脚本执行记录ID
+	ID int64 `json:"id,omitempty"`
+	// 实际使用的隔离执行配置, 不隔离时为空
+	Isolation *JobsIsolationSpec `json:"isolation,omitempty"`
+	// 解析后的脚本参数
+	Params []*JobsScriptParamValue `json:"params,omitempty"`
+	// 续跑次数
+	ResumeCount int64 `json:"resume_count,omitempty"`
+	// 续跑的原执行记录ID
+	ResumeOf int64 `json:"resume_of,omitempty"`
+	// 续跑生成的执行记录ID
+	ResumedID int64 `json:"resumed_id,omitempty"`
+	// 脚本信息
+	Script *JobsScriptStandardOut `json:"script,omitempty"`
+	// 执行状态(0-待执行,1-执行中,2-成功,3-失败,4-超时,5-崩溃,6-中断)
+	Status int64 `json:"status,omitempty"`
+	// 超时时间(秒)
+	Timeout int64 `json:"timeout,omitempty"`
+	// 触发类型(cron/api)
+	TriggerType string `json:"trigger_type,omitempty"`
+	// 更新时间
+	UpdatedAt string `json:"updated_at,omitempty"`
+	// 用户名
+	Username string `json:"username,omitempty"`
+	// 工作目录
+	WorkDir string `json:"work_dir,omitempty"`
+}
+
+// JobsScriptRecordReply 对应jobs.ScriptRecordReply
+type JobsScriptRecordReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *JobsScriptRecordDetailOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// JobsScriptReply 对应jobs.ScriptReply
+type JobsScriptReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *JobsScriptStandardOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// JobsScriptStandardOut 对应jobs.ScriptStandardOut
+type JobsScriptStandardOut struct {
+	// 产物文件匹配规则
+	Artifacts []string `json:"artifacts,omitempty"`
+	// 创建时间
+	CreatedAt string `json:"created_at,omitempty"`
+	// 描述信息
+	Descr string `json:"descr,omitempty"`
+	// 唯一标识
+	ID int64 `json:"id,omitempty"`
+	// 是否是内置脚本
+	IsBuiltin bool `json:"is_builtin,omitempty"`
+	// 隔离执行配置, 不隔离时为空
+	Isolation *JobsIsolationSpec `json:"isolation,omitempty"`
+	// 标签
+	Label string `json:"label,omitempty"`
+	// 脚本语言
+	Language string `json:"language,omitempty"`
+	// 名称
+	Name string `json:"name,omitempty"`
+	// 参数定义, 用于生成触发执行时的参数表单
+	Params []*JobsScriptParam `json:"params,omitempty"`
+	// 项目
+	Project string `json:"project,omitempty"`
+	// 中断后是否续跑
+	Resumable bool `json:"resumable,omitempty"`
+	// 状态
+	Status bool `json:"status,omitempty"`
+	// 更新时间
+	UpdatedAt string `json:"updated_at,omitempty"`
+	// 用户名
+	Username string `json:"username,omitempty"`
+}
+
+// JobsScriptValidateOut 对应jobs.ScriptValidateOut
+type JobsScriptValidateOut struct {
+	// 发现的问题
+	Diagnostics []*JobsScriptDiagnosticOut `json:"diagnostics,omitempty"`
+	// 试运行结果, 未试运行时为空
+	DryRun *JobsScriptDryRunOut `json:"dry_run,omitempty"`
+	// 是否通过检查, 存在error级别的问题或试运行失败时为false
+	Valid bool `json:"valid,omitempty"`
+}
+
+// JobsScriptValidateReply 对应jobs.ScriptValidateReply
+type JobsScriptValidateReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *JobsScriptValidateOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// JobsTradingDayOut 对应jobs.TradingDayOut
+type JobsTradingDayOut struct {
+	// 日期
+	Date string `json:"date,omitempty"`
+	// 节假日名称
+	HolidayName string `json:"holiday_name,omitempty"`
+	// 是否为节假日
+	IsHoliday bool `json:"is_holiday,omitempty"`
+	// 是否为交易日
+	IsTradingDay bool `json:"is_trading_day,omitempty"`
+}
+
+// JobsTradingDayReply 对应jobs.TradingDayReply
+type JobsTradingDayReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *JobsTradingDayOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// JobsTradingHolidayItem 对应jobs.TradingHolidayItem
+type JobsTradingHolidayItem struct {
+	// 日期(YYYY-MM-DD)
+	Date string `json:"date"`
+	// 节假日名称
+	Name string `json:"name,omitempty"`
+}
+
+// JobsTradingHolidayOut 对应jobs.TradingHolidayOut
+type JobsTradingHolidayOut struct {
+	// 日期
+	Date string `json:"date,omitempty"`
+	// ID
+	ID int64 `json:"id,omitempty"`
+	// 节假日名称
+	Name string `json:"name,omitempty"`
+	// 用户名
+	Username string `json:"username,omitempty"`
+	// 年份
+	Year int64 `json:"year,omitempty"`
+}
+
+// JobsUpdateScheduleRequest 对应jobs.UpdateScheduleRequest
+type JobsUpdateScheduleRequest struct {
+	// 交易日历选项(trading_day: 仅交易日执行, skip_holiday: 跳过节假日), 为空时不受限制
+	CalendarMode string `json:"calendar_mode,omitempty"`
+	// 绑定的oes集群号, 触发时注入集群上下文的环境变量(COLONY_NUM、SYSTEM_TYPE、MON_NODE_IP、PACKAGE_VERSION)
+	Colony string `json:"colony,omitempty"`
+	// 命令行参数
+	CommandArgs string `json:"command_args,omitempty"`
+	// 环境变量(JSON对象)
+	EnvVars string `json:"env_vars,omitempty"`
+	// 是否启用
+	IsEnabled bool `json:"is_enabled,omitempty"`
+	// 是否重试
+	IsRetry bool `json:"is_retry,omitempty"`
+	// 隔离执行配置, 为空时使用脚本的配置
+	Isolation *JobsIsolationSpec `json:"isolation,omitempty"`
+	// 最大重试次数
+	MaxRetries int64 `json:"max_retries,omitempty"`
+	// 计划任务名称
+	Name string `json:"name"`
+	// 脚本参数, 按脚本的参数定义校验, 日期参数的默认值today在每次触发时取当天
+	Params map[string]string `json:"params,omitempty"`
+	// 重试间隔时间(秒)
+	RetryInterval int64 `json:"retry_interval,omitempty"`
+	// 脚本ID
+	ScriptID int64 `json:"script_id"`
+	// Cron 表达式
+	Specification string `json:"specification"`
+	// 超时时间(秒)
+	Timeout int64 `json:"timeout,omitempty"`
+	// 工作目录
+	WorkDir string `json:"work_dir,omitempty"`
+}
+
+// JobsValidateScriptRequest 对应jobs.ValidateScriptRequest
+type JobsValidateScriptRequest struct {
+	// 试运行的命令参数
+	CommandArgs string `json:"command_args,omitempty"`
+	// 是否在沙箱中试运行, 需要配置试运行沙箱
+	DryRun bool `json:"dry_run,omitempty"`
+}
+
+// MdsCreateMdsBackfillRequest 对应mds.CreateMdsBackfillRequest
+type MdsCreateMdsBackfillRequest struct {
+	// 同时回放的交易日数, 默认为1
+	Concurrency int64 `json:"concurrency,omitempty"`
+	// 结束日期, 格式为2006-01-02
+	EndDate string `json:"end_date"`
+	// mds集群ID
+	MdsColonyID int64 `json:"mds_colony_id"`
+	// 回放脚本名称, 需为mds的内置命令脚本, 执行参数为集群号和交易日
+	Script string `json:"script"`
+	// 是否按日期顺序回放, 开启后每个交易日依赖前一个交易日回放成功
+	Sequential bool `json:"sequential,omitempty"`
+	// 开始日期, 格式为2006-01-02
+	StartDate string `json:"start_date"`
+	// 每日回放的超时时间(秒), 默认为3600
+	Timeout int64 `json:"timeout,omitempty"`
+}
+
+// MdsCreateOrUpdateIngestSourceRequest 对应mds.CreateOrUpdateIngestSourceRequest
+type MdsCreateOrUpdateIngestSourceRequest struct {
+	// 校验文件后缀, 如.md5, 为空时不核对校验文件
+	ChecksumSuffix string `json:"checksum_suffix,omitempty"`
+	// 字段定义, 每行一个"名称:类型"
+	Columns string `json:"columns"`
+	// 字段分隔符, 为空时为逗号
+	Delimiter string `json:"delimiter,omitempty"`
+	// 是否包含表头
+	HasHeader bool `json:"has_header,omitempty"`
+	// SFTP主机ID, 类型为sftp时必填
+	HostID int64 `json:"host_id,omitempty"`
+	// 是否启用
+	IsEnabled bool `json:"is_enabled,omitempty"`
+	// 数据源类型, sftp:主机文件, local:本地文件, http:HTTP下载
+	Kind string `json:"kind"`
+	// 文件位置, 路径或URL, 支持{date}和{day}占位符
+	Location string `json:"location"`
+	// 文件大小上限(MB), 为0时为100
+	MaxSize int64 `json:"max_size,omitempty"`
+	// 名称
+	Name string `json:"name"`
+	// 定时采集的crontab表达式, 为空时仅手动采集
+	Specification string `json:"specification,omitempty"`
+	// 仅交易日采集
+	TradingDayOnly bool `json:"trading_day_only,omitempty"`
+}
+
+// MdsCreateOrUpdateMdsColonyRequest 对应mds.CreateOrUpdateMdsColonyRequest
+type MdsCreateOrUpdateMdsColonyRequest struct {
+	// 集群号
+	ColonyNum string `json:"colony_num"`
+	// 解压后名称
+	ExtractedName string `json:"extracted_name"`
+	// 是否启用
+	IsEnable bool `json:"is_enable"`
+	// mon节点ID
+	MonNodeID int64 `json:"mon_node_id"`
+	// 程序包ID
+	PackageID int64 `json:"package_id"`
+	// 版本号, 更新时必填(也可通过If-Match请求头传递)
+	Version int64 `json:"version,omitempty"`
+}
+
+// MdsCreateOrUpdateMdsNodeRequest 对应mds.CreateOrUpdateMdsNodeRequest
+type MdsCreateOrUpdateMdsNodeRequest struct {
+	// 主机ID
+	HostID int64 `json:"host_id"`
+	// 是否启用
+	IsEnable bool `json:"is_enable,omitempty"`
+	// mds集群ID
+	MdsColonyID int64 `json:"mds_colony_id"`
+	// 节点角色
+	NodeRole string `json:"node_role"`
+}
+
+// MdsIngestColumn 对应mds.IngestColumn
+type MdsIngestColumn struct {
+	Name string `json:"name,omitempty"`
+	Type string `json:"type,omitempty"`
+}
+
+// MdsIngestDayReply 对应mds.IngestDayReply
+type MdsIngestDayReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data []*MdsIngestRecordOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// MdsIngestRecordOut 对应mds.IngestRecordOut
+type MdsIngestRecordOut struct {
+	// 采集次数
+	Attempts int64 `json:"attempts,omitempty"`
+	// 文件MD5
+	Checksum string `json:"checksum,omitempty"`
+	// 校验错误
+	Errors []string `json:"errors,omitempty"`
+	// 校验文件中的MD5
+	ExpectedChecksum string `json:"expected_checksum,omitempty"`
+	// 校验文件中的行数
+	ExpectedRows int64 `json:"expected_rows,omitempty"`
+	// 文件大小
+	FileSize int64 `json:"file_size,omitempty"`
+	// 结束时间
+	FinishedAt string `json:"finished_at,omitempty"`
+	// 唯一标识
+	ID int64 `json:"id,omitempty"`
+	// 文件位置
+	Location string `json:"location,omitempty"`
+	// 数据行数
+	RowCount int64 `json:"row_count,omitempty"`
+	// 数据源ID
+	SourceID int64 `json:"source_id,omitempty"`
+	// 数据源名称
+	SourceName string `json:"source_name,omitempty"`
+	// 开始时间
+	StartedAt string `json:"started_at,omitempty"`
+	// 状态, running/success/failed/pending
+	Status string `json:"status,omitempty"`
+	// 交易日
+	TradingDay string `json:"trading_day,omitempty"`
+	// 触发方式, cron/manual
+	TriggerType string `json:"trigger_type,omitempty"`
+	// 操作用户
+	Username string `json:"username,omitempty"`
+}
+
+// MdsIngestRecordReply 对应mds.IngestRecordReply
+type MdsIngestRecordReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *MdsIngestRecordOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// MdsIngestSourceOut 对应mds.IngestSourceOut
+type MdsIngestSourceOut struct {
+	// 校验文件后缀
+	ChecksumSuffix string `json:"checksum_suffix,omitempty"`
+	// 字段定义
+	Columns []*MdsIngestColumn `json:"columns,omitempty"`
+	// 创建时间
+	CreatedAt string `json:"created_at,omitempty"`
+	// 字段分隔符
+	Delimiter string `json:"delimiter,omitempty"`
+	// 是否包含表头
+	HasHeader bool `json:"has_header,omitempty"`
+	// SFTP主机ID
+	HostID int64 `json:"host_id,omitempty"`
+	// 唯一标识
+	ID int64 `json:"id,omitempty"`
+	// 是否启用
+	IsEnabled bool `json:"is_enabled,omitempty"`
+	// 数据源类型
+	Kind string `json:"kind,omitempty"`
+	// 文件位置
+	Location string `json:"location,omitempty"`
+	// 文件大小上限(MB)
+	MaxSize int64 `json:"max_size,omitempty"`
+	// 名称
+	Name string `json:"name,omitempty"`
+	// 定时采集表达式
+	Specification string `json:"specification,omitempty"`
+	// 仅交易日采集
+	TradingDayOnly bool `json:"trading_day_only,omitempty"`
+	// 更新时间
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+// MdsIngestSourceReply 对应mds.IngestSourceReply
+type MdsIngestSourceReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *MdsIngestSourceOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// MdsListMdsTasksInfoReply 对应mds.ListMdsTasksInfoReply
+type MdsListMdsTasksInfoReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data []*MdsMdsColonyTaskInfo `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// MdsMdsBackfillDayOut 对应mds.MdsBackfillDayOut
+type MdsMdsBackfillDayOut struct {
+	// 执行次数
+	Attempts int64 `json:"attempts,omitempty"`
+	// 依赖的交易日
+	DependsOn string `json:"depends_on,omitempty"`
+	// 错误信息
+	ErrorMessage string `json:"error_message,omitempty"`
+	// 结束时间
+	FinishedAt string `json:"finished_at,omitempty"`
+	// 脚本执行记录ID
+	RecordID int64 `json:"record_id,omitempty"`
+	// 开始时间
+	StartedAt string `json:"started_at,omitempty"`
+	// 执行状态(pending/running/success/failed/skipped/cancelled)
+	Status string `json:"status,omitempty"`
+	// 交易日
+	TradingDay string `json:"trading_day,omitempty"`
+}
+
+// MdsMdsBackfillRunDetailOut 对应mds.MdsBackfillRunDetailOut
+type MdsMdsBackfillRunDetailOut struct {
+	// 集群号
+	ColonyNum string `json:"colony_num,omitempty"`
+	// 并发数
+	Concurrency int64 `json:"concurrency,omitempty"`
+	// 创建时间
+	CreatedAt string `json:"created_at,omitempty"`
+	// 每个交易日的回放状态
+	Days []*MdsMdsBackfillDayOut `json:"days,omitempty"`
+	// 结束日期
+	EndDate string `json:"end_date,omitempty"`
+	// 结束时间
+	FinishedAt string `json:"finished_at,omitempty"`
+	// ID
+	ID int64 `json:"id,omitempty"`
+	// mds集群ID
+	MdsColonyID int64 `json:"mds_colony_id,omitempty"`
+	// 回放脚本名称
+	Script string `json:"script,omitempty"`
+	// 是否按日期顺序回放
+	Sequential bool `json:"sequential,omitempty"`
+	// 开始日期
+	StartDate string `json:"start_date,omitempty"`
+	// 开始时间
+	StartedAt string `json:"started_at,omitempty"`
+	// 执行状态(pending/running/success/failed/cancelled)
+	Status string `json:"status,omitempty"`
+	// 各状态的交易日数
+	Summary *MdsMdsBackfillSummaryOut `json:"summary,omitempty"`
+	// 每日回放超时时间(秒)
+	Timeout int64 `json:"timeout,omitempty"`
+	// 用户名
+	Username string `json:"username,omitempty"`
+}
+
+// MdsMdsBackfillRunOut 对应mds.MdsBackfillRunOut
+type MdsMdsBackfillRunOut struct {
+	// 集群号
+	ColonyNum string `json:"colony_num,omitempty"`
+	// 并发数
+	Concurrency int64 `json:"concurrency,omitempty"`
+	// 创建时间
+	CreatedAt string `json:"created_at,omitempty"`
+	// 结束日期
+	EndDate string `json:"end_date,omitempty"`
+	// 结束时间
+	FinishedAt string `json:"finished_at,omitempty"`
+	// ID
+	ID int64 `json:"id,omitempty"`
+	// mds集群ID
+	MdsColonyID int64 `json:"mds_colony_id,omitempty"`
+	// 回放脚本名称
+	Script string `json:"script,omitempty"`
+	// 是否按日期顺序回放
+	Sequential bool `json:"sequential,omitempty"`
+	// 开始日期
+	StartDate string `json:"start_date,omitempty"`
+	// 开始时间
+	StartedAt string `json:"started_at,omitempty"`
+	// 执行状态(pending/running/success/failed/cancelled)
+	Status string `json:"status,omitempty"`
+	// 各状态的交易日数
+	Summary *MdsMdsBackfillSummaryOut `json:"summary,omitempty"`
+	// 每日回放超时时间(秒)
+	Timeout int64 `json:"timeout,omitempty"`
+	// 用户名
+	Username string `json:"username,omitempty"`
+}
+
+// MdsMdsBackfillRunReply 对应mds.MdsBackfillRunReply
+type MdsMdsBackfillRunReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *MdsMdsBackfillRunDetailOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// MdsMdsBackfillSummaryOut 对应mds.MdsBackfillSummaryOut
+type MdsMdsBackfillSummaryOut struct {
+	// 已取消
+	Cancelled int64 `json:"cancelled,omitempty"`
+	// 执行失败
+	Failed int64 `json:"failed,omitempty"`
+	// 等待执行
+	Pending int64 `json:"pending,omitempty"`
+	// 执行中
+	Running int64 `json:"running,omitempty"`
+	// 已跳过
+	Skipped int64 `json:"skipped,omitempty"`
+	// 执行成功
+	Success int64 `json:"success,omitempty"`
+	// 交易日总数
+	Total int64 `json:"total,omitempty"`
+}
+
+// MdsMdsColonyBaseOut 对应mds.MdsColonyBaseOut
+type MdsMdsColonyBaseOut struct {
+	// 集群号
+	ColonyNum string `json:"colony_num,omitempty"`
+	// 解压后名称
+	ExtractedName string `json:"extracted_name,omitempty"`
+	// ID
+	ID int64 `json:"id,omitempty"`
+	// 是否启用
+	IsEnable bool `json:"is_enable,omitempty"`
+}
+
+// MdsMdsColonyDetailOut 对应mds.MdsColonyDetailOut
+type MdsMdsColonyDetailOut struct {
+	// 集群号
+	ColonyNum string `json:"colony_num,omitempty"`
+	// 创建时间
+	CreatedAt string `json:"created_at,omitempty"`
+	// 解压后名称
+	ExtractedName string `json:"extracted_name,omitempty"`
+	// ID
+	ID int64 `json:"id,omitempty"`
+	// 是否启用
+	IsEnable bool `json:"is_enable,omitempty"`
+	// mon节点
+	MonNode *MonMonNodeBaseOut `json:"mon_node,omitempty"`
+	// mds程序包
+	Package *ResourcePackageStandardOut `json:"package,omitempty"`
+	// 更新时间
+	UpdatedAt string `json:"updated_at,omitempty"`
+	// 版本号
+	Version int64 `json:"version,omitempty"`
+}
+
+// MdsMdsColonyReply 对应mds.MdsColonyReply
+type MdsMdsColonyReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *MdsMdsColonyDetailOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// MdsMdsColonyTaskInfo 对应mds.MdsColonyTaskInfo
+type MdsMdsColonyTaskInfo struct {
+	// 集群号
+	ColonyNum string `json:"colony_num,omitempty"`
+	// 任务状态
+	Tasks []*CommonTaskInfo `json:"tasks,omitempty"`
+}
+
+// MdsMdsNodeDetailOut 对应mds.MdsNodeDetailOut
+type MdsMdsNodeDetailOut struct {
+	// 创建时间
+	CreatedAt string `json:"created_at,omitempty"`
+	// 主机
+	Host *ResourceHostBaseOut `json:"host,omitempty"`
+	// ID
+	ID int64 `json:"id,omitempty"`
+	// 是否启用
+	IsEnable bool `json:"is_enable,omitempty"`
+	// mds集群
+	MdsColony *MdsMdsColonyBaseOut `json:"mds_colony,omitempty"`
+	// 节点角色
+	NodeRole string `json:"node_role,omitempty"`
+	// 更新时间
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+// MdsMdsNodeReply 对应mds.MdsNodeReply
+type MdsMdsNodeReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *MdsMdsNodeDetailOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// MdsPagIngestRecordReply 对应mds.PagIngestRecordReply
+type MdsPagIngestRecordReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CommonPagMdsIngestRecordOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// MdsPagIngestSourceReply 对应mds.PagIngestSourceReply
+type MdsPagIngestSourceReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CommonPagMdsIngestSourceOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// MdsPagMdsBackfillRunReply 对应mds.PagMdsBackfillRunReply
+type MdsPagMdsBackfillRunReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CommonPagMdsMdsBackfillRunOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// MdsPagMdsColonyReply 对应mds.PagMdsColonyReply
+type MdsPagMdsColonyReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CommonPagMdsMdsColonyDetailOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// MdsPagMdsConfReply 对应mds.PagMdsConfReply
+type MdsPagMdsConfReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *FileutilFileInfo `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// MdsPagMdsNodeReply 对应mds.PagMdsNodeReply
+type MdsPagMdsNodeReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CommonPagMdsMdsNodeDetailOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// MdsRunIngestRequest 对应mds.RunIngestRequest
+type MdsRunIngestRequest struct {
+	// 交易日, 格式为2006-01-02
+	TradingDay string `json:"trading_day"`
+}
+
+// MonCreateOrUpdateMonNodeRequest 对应mon.CreateOrUpdateMonNodeRequest
+type MonCreateOrUpdateMonNodeRequest struct {
+	// 部署路径
+	DeployPath string `json:"deploy_path"`
+	// 主机ID
+	HostID int64 `json:"host_id"`
+	// JAVA_HOME
+	JavaHome string `json:"java_home,omitempty"`
+	// 名称
+	Name string `json:"name"`
+	// 导出路径
+	OutportPath string `json:"outport_path"`
+	// Prometheus认证令牌, 更新时为空表示不修改
+	PromBearerToken string `json:"prom_bearer_token,omitempty"`
+	// Prometheus认证密码, 更新时为空表示不修改
+	PromPassword string `json:"prom_password,omitempty"`
+	// Prometheus地址
+	PromURL string `json:"prom_url,omitempty"`
+	// Prometheus认证用户名
+	PromUsername string `json:"prom_username,omitempty"`
+	// URL地址
+	URL string `json:"url,omitempty"`
+}
+
+// MonHostMetricPointOut 对应mon.HostMetricPointOut
+type MonHostMetricPointOut struct {
+	// CPU使用率(%)
+	CPUPercent float64 `json:"cpu_percent,omitempty"`
+	// 磁盘使用率(%)
+	DiskPercent float64 `json:"disk_percent,omitempty"`
+	// 1分钟平均负载
+	Load1 float64 `json:"load1,omitempty"`
+	// 15分钟平均负载
+	Load15 float64 `json:"load15,omitempty"`
+	// 5分钟平均负载
+	Load5 float64 `json:"load5,omitempty"`
+	// 内存使用率(%)
+	MemPercent float64 `json:"mem_percent,omitempty"`
+	// 时间(Unix秒)
+	Ts int64 `json:"ts,omitempty"`
+}
+
+// MonHostMetricSeriesOut 对应mon.HostMetricSeriesOut
+type MonHostMetricSeriesOut struct {
+	// 结束时间(Unix秒)
+	End int64 `json:"end,omitempty"`
+	// 主机ID
+	HostID int64 `json:"host_id,omitempty"`
+	// mon节点ID
+	MonNodeID int64 `json:"mon_node_id,omitempty"`
+	// 数据点, 按时间升序
+	Points []*MonHostMetricPointOut `json:"points,omitempty"`
+	// 时间范围
+	Range string `json:"range,omitempty"`
+	// 开始时间(Unix秒)
+	Start int64 `json:"start,omitempty"`
+	// 聚合粒度(秒, 0表示原始数据)
+	Step int64 `json:"step,omitempty"`
+}
+
+// MonHostMetricSeriesReply 对应mon.HostMetricSeriesReply
+type MonHostMetricSeriesReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *MonHostMetricSeriesOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// MonMonNodeBaseOut 对应mon.MonNodeBaseOut
+type MonMonNodeBaseOut struct {
+	// 部署路径
+	DeployPath string `json:"deploy_path,omitempty"`
+	// 健康状态
+	Health string `json:"health,omitempty"`
+	// 最近一次健康检查时间
+	HealthCheckedAt string `json:"health_checked_at,omitempty"`
+	// 健康检查信息
+	HealthMessage string `json:"health_message,omitempty"`
+	// 计划任务ID
+	ID int64 `json:"id,omitempty"`
+	// JAVA_HOME
+	JavaHome string `json:"java_home,omitempty"`
+	// 最近一次采集时间
+	LastScrapeAt string `json:"last_scrape_at,omitempty"`
+	// 名称
+	Name string `json:"name,omitempty"`
+	// 导出路径
+	OutportPath string `json:"outport_path,omitempty"`
+	// Prometheus地址
+	PromURL string `json:"prom_url,omitempty"`
+	// URL地址
+	URL string `json:"url,omitempty"`
+}
+
+// MonMonNodeDetailOut 对应mon.MonNodeDetailOut
+type MonMonNodeDetailOut struct {
+	// 创建时间
+	CreatedAt string `json:"created_at,omitempty"`
+	// 部署路径
+	DeployPath string `json:"deploy_path,omitempty"`
+	// 健康状态
+	Health string `json:"health,omitempty"`
+	// 最近一次健康检查时间
+	HealthCheckedAt string `json:"health_checked_at,omitempty"`
+	// 健康检查信息
+	HealthMessage string `json:"health_message,omitempty"`
+	// 主机
+	Host *ResourceHostBaseOut `json:"host,omitempty"`
+	// 计划任务ID
+	ID int64 `json:"id,omitempty"`
+	// JAVA_HOME
+	JavaHome string `json:"java_home,omitempty"`
+	// 最近一次采集时间
+	LastScrapeAt string `json:"last_scrape_at,omitempty"`
+	// 名称
+	Name string `json:"name,omitempty"`
+	// 导出路径
+	OutportPath string `json:"outport_path,omitempty"`
+	// Prometheus地址
+	PromURL string `json:"prom_url,omitempty"`
+	// 更新时间
+	UpdatedAt string `json:"updated_at,omitempty"`
+	// URL地址
+	URL string `json:"url,omitempty"`
+}
+
+// MonMonNodeQueryReply 对应mon.MonNodeQueryReply
+type MonMonNodeQueryReply map[string]any
+
+// MonMonNodeReply 对应mon.MonNodeReply
+type MonMonNodeReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *MonMonNodeDetailOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// MonPagMonNodeReply 对应mon.PagMonNodeReply
+type MonPagMonNodeReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CommonPagMonMonNodeDetailOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// OesBatchOesColonyReply 对应oes.BatchOesColonyReply
+type OesBatchOesColonyReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data []*OesOesColonyBatchResultOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// OesBatchUpdateOesColonyRequest 对应oes.BatchUpdateOesColonyRequest
+type OesBatchUpdateOesColonyRequest struct {
+	// oes集群ID列表
+	IDs []int64 `json:"ids"`
+	// 是否启用
+	IsEnable bool `json:"is_enable,omitempty"`
+	// mon节点ID
+	MonNodeID int64 `json:"mon_node_id,omitempty"`
+	// 程序包ID
+	PackageID int64 `json:"package_id,omitempty"`
+}
+
+// OesCreateOrUpdateOesColonyRequest 对应oes.CreateOrUpdateOesColonyRequest
+type OesCreateOrUpdateOesColonyRequest struct {
+	// 集群号
+	ColonyNum string `json:"colony_num"`
+	// 解压后名称
+	ExtractedName string `json:"extracted_name"`
+	// 是否启用
+	IsEnable bool `json:"is_enable"`
+	// mon节点ID
+	MonNodeID int64 `json:"mon_node_id"`
+	// 程序包ID
+	PackageID int64 `json:"package_id"`
+	// 系统类型
+	SystemType string `json:"system_type"`
+	// 版本号, 更新时必填(也可通过If-Match请求头传递)
+	Version int64 `json:"version,omitempty"`
+	// xcounter包ID
+	XcounterID int64 `json:"xcounter_id"`
+}
+
+// OesCreateOrUpdateOesNodeRequest 对应oes.CreateOrUpdateOesNodeRequest
+type OesCreateOrUpdateOesNodeRequest struct {
+	// 主机ID
+	// required: true
+	// example: 1
+	HostID int64 `json:"host_id"`
+	// 是否启用
+	// required: true
+	// example: true
+	IsEnable bool `json:"is_enable,omitempty"`
+	// 节点角色
+	// required: true
+	// example: "01"
+	NodeRole string `json:"node_role"`
+	// oes集群ID
+	// required: true
+	// example: 1
+	OesColonyID int64 `json:"oes_colony_id"`
+}
+
+// OesDeployOesConfTemplateRequest 对应oes.DeployOesConfTemplateRequest
+type OesDeployOesConfTemplateRequest struct {
+	// 下发的节点ID, 为空时下发到模板适用的全部节点
+	NodeIDs []int64 `json:"node_ids,omitempty"`
+	// oes集群ID
+	OesColonyID int64 `json:"oes_colony_id"`
+}
+
+// OesListOesTasksInfoReply 对应oes.ListOesTasksInfoReply
+type OesListOesTasksInfoReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data []*OesOesColonyTaskInfo `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// OesOesColonyBaseOut 对应oes.OesColonyBaseOut
+type OesOesColonyBaseOut struct {
+	// 集群号
+	ColonyNum string `json:"colony_num,omitempty"`
+	// 解压后名称
+	ExtractedName string `json:"extracted_name,omitempty"`
+	// ID
+	ID int64 `json:"id,omitempty"`
+	// 是否启用
+	IsEnable bool `json:"is_enable,omitempty"`
+	// 系统类型
+	SystemType string `json:"system_type,omitempty"`
+}
+
+// OesOesColonyBatchResultOut 对应oes.OesColonyBatchResultOut
+type OesOesColonyBatchResultOut struct {
+	// oes集群ID
+	ID int64 `json:"id,omitempty"`
+	// 失败原因
+	Message string `json:"message,omitempty"`
+	// 是否成功
+	Success bool `json:"success,omitempty"`
+}
+
+// OesOesColonyDetailOut 对应oes.OesColonyDetailOut
+type OesOesColonyDetailOut struct {
+	// 集群号
+	ColonyNum string `json:"colony_num,omitempty"`
+	// 创建时间
+	CreatedAt string `json:"created_at,omitempty"`
+	// 解压后名称
+	ExtractedName string `json:"extracted_name,omitempty"`
+	// ID
+	ID int64 `json:"id,omitempty"`
+	// 是否启用
+	IsEnable bool `json:"is_enable,omitempty"`
+	// mon节点
+	MonNode *MonMonNodeBaseOut `json:"mon_node,omitempty"`
+	// oes程序包
+	Package *ResourcePackageStandardOut `json:"package,omitempty"`
+	// 系统类型
+	SystemType string `json:"system_type,omitempty"`
+	// 更新时间
+	UpdatedAt string `json:"updated_at,omitempty"`
+	// 版本号
+	Version int64 `json:"version,omitempty"`
+	// xcounter程序包
+	Xcounter *ResourcePackageStandardOut `json:"xcounter,omitempty"`
+}
+
+// OesOesColonyDriftOut 对应oes.OesColonyDriftOut
+type OesOesColonyDriftOut struct {
+	// 实际的校验和或版本号, missing表示文件不存在, error表示检测失败
+	Actual string `json:"actual,omitempty"`
+	// 最近检测时间
+	CheckedAt string `json:"checked_at,omitempty"`
+	// 首次发现时间
+	DetectedAt string `json:"detected_at,omitempty"`
+	// 期望的校验和或版本号
+	Expected string `json:"expected,omitempty"`
+	// 主机ID
+	HostID int64 `json:"host_id,omitempty"`
+	// 漂移类型(conf/package)
+	Kind string `json:"kind,omitempty"`
+	// 说明
+	Message string `json:"message,omitempty"`
+	// 配置模板名称或程序包标签
+	Name string `json:"name,omitempty"`
+	// 节点ID
+	NodeID int64 `json:"node_id,omitempty"`
+	// 目标文件路径
+	RemotePath string `json:"remote_path,omitempty"`
+}
+
+// OesOesColonyDriftReply 对应oes.OesColonyDriftReply
+type OesOesColonyDriftReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data []*OesOesColonyDriftOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// OesOesColonyExportOut 对应oes.OesColonyExportOut
+type OesOesColonyExportOut struct {
+	// 集群号
+	ColonyNum string `json:"colony_num,omitempty"`
+	// 创建时间
+	CreatedAt string `json:"created_at,omitempty"`
+	// 下载地址, 导出成功后返回
+	DownloadURL string `json:"download_url,omitempty"`
+	// 错误信息
+	ErrorMessage string `json:"error_message,omitempty"`
+	// 归档文件名
+	Filename string `json:"filename,omitempty"`
+	// ID
+	ID int64 `json:"id,omitempty"`
+	// oes集群ID
+	OesColonyID int64 `json:"oes_colony_id,omitempty"`
+	// 归档文件大小(字节)
+	Size int64 `json:"size,omitempty"`
+	// 导出状态(pending/running/success/failed)
+	Status string `json:"status,omitempty"`
+	// 更新时间
+	UpdatedAt string `json:"updated_at,omitempty"`
+	// 用户名
+	Username string `json:"username,omitempty"`
+}
+
+// OesOesColonyExportReply 对应oes.OesColonyExportReply
+type OesOesColonyExportReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *OesOesColonyExportOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// OesOesColonyReply 对应oes.OesColonyReply
+type OesOesColonyReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *OesOesColonyDetailOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// OesOesColonyTaskInfo 对应oes.OesColonyTaskInfo
+type OesOesColonyTaskInfo struct {
+	// 集群号
+	ColonyNum string `json:"colony_num,omitempty"`
+	// 任务状态
+	Tasks []*CommonTaskInfo `json:"tasks,omitempty"`
+}
+
+// OesOesColonyTopologyOut 对应oes.OesColonyTopologyOut
+type OesOesColonyTopologyOut struct {
+	// 连线列表
+	Edges []*OesTopologyEdgeOut `json:"edges,omitempty"`
+	// 节点列表
+	Nodes []*OesTopologyNodeOut `json:"nodes,omitempty"`
+}
+
+// OesOesColonyTopologyReply 对应oes.OesColonyTopologyReply
+type OesOesColonyTopologyReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *OesOesColonyTopologyOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// OesOesConfDeployOut 对应oes.OesConfDeployOut
+type OesOesConfDeployOut struct {
+	// 文件内容是否发生变化, 未变化时不写入
+	Changed bool `json:"changed,omitempty"`
+	// 下发失败的原因, 成功时为空
+	Error string `json:"error,omitempty"`
+	// 主机ID
+	HostID int64 `json:"host_id,omitempty"`
+	// 节点ID
+	NodeID int64 `json:"node_id,omitempty"`
+	// 目标文件路径
+	RemotePath string `json:"remote_path,omitempty"`
+}
+
+// OesOesConfDeployReply 对应oes.OesConfDeployReply
+type OesOesConfDeployReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data []*OesOesConfDeployOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// OesOesConfRenderOut 对应oes.OesConfRenderOut
+type OesOesConfRenderOut struct {
+	// 渲染后的内容
+	Content string `json:"content,omitempty"`
+	// 与目标文件当前内容的差异(unified格式), 内容相同时为空
+	Diff string `json:"diff,omitempty"`
+	// 目标文件是否已存在
+	Exists bool `json:"exists,omitempty"`
+	// 主机ID
+	HostID int64 `json:"host_id,omitempty"`
+	// 主机名称
+	HostName string `json:"host_name,omitempty"`
+	// 节点ID
+	NodeID int64 `json:"node_id,omitempty"`
+	// 目标文件路径
+	RemotePath string `json:"remote_path,omitempty"`
+}
+
+// OesOesConfRenderReply 对应oes.OesConfRenderReply
+type OesOesConfRenderReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data []*OesOesConfRenderOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// OesOesConfTemplateOut 对应oes.OesConfTemplateOut
+type OesOesConfTemplateOut struct {
+	// 模板内容
+	Content string `json:"content,omitempty"`
+	// 创建时间
+	CreatedAt string `json:"created_at,omitempty"`
+	// 适用节点目录
+	DirName string `json:"dir_name,omitempty"`
+	// ID
+	ID int64 `json:"id,omitempty"`
+	// 名称
+	Name string `json:"name,omitempty"`
+	// 备注
+	Remark string `json:"remark,omitempty"`
+	// 目标文件路径模板
+	RemotePath string `json:"remote_path,omitempty"`
+	// 更新时间
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+// OesOesConfTemplateReply 对应oes.OesConfTemplateReply
+type OesOesConfTemplateReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *OesOesConfTemplateOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// OesOesConfTemplateRequest 对应oes.OesConfTemplateRequest
+type OesOesConfTemplateRequest struct {
+	// 模板内容
+	Content string `json:"content,omitempty"`
+	// 适用节点目录, all表示集群的全部启用节点
+	DirName string `json:"dir_name"`
+	// 名称
+	Name string `json:"name"`
+	// 备注
+	Remark string `json:"remark,omitempty"`
+	// 目标文件路径模板, 渲染后必须为绝对路径
+	// example: /home/quant/oes_{{ .Colony.ColonyNum }}/conf/oes.conf
+	RemotePath string `json:"remote_path"`
+}
+
+// OesOesLinkMatrixCellOut 对应oes.OesLinkMatrixCellOut
+type OesOesLinkMatrixCellOut struct {
+	// 探测时间
+	LastCheckedAt string `json:"last_checked_at,omitempty"`
+	// 探测失败原因
+	LastError string `json:"last_error,omitempty"`
+	// 建立连接的耗时(毫秒)
+	LatencyMs float64 `json:"latency_ms,omitempty"`
+	// 链路ID
+	LinkID int64 `json:"link_id,omitempty"`
+	// 名称
+	Name string `json:"name,omitempty"`
+	// 探测发起方, 对应矩阵的行
+	Source string `json:"source,omitempty"`
+	// 探测状态
+	Status string `json:"status,omitempty"`
+	// 目标名称和地址, 对应矩阵的列
+	Target string `json:"target,omitempty"`
+}
+
+// OesOesLinkMatrixOut 对应oes.OesLinkMatrixOut
+type OesOesLinkMatrixOut struct {
+	// 矩阵中有链路的单元格
+	Cells []*OesOesLinkMatrixCellOut `json:"cells,omitempty"`
+	// 可达的链路数
+	Reachable int64 `json:"reachable,omitempty"`
+	// 矩阵的行, 探测发起方
+	Sources []string `json:"sources,omitempty"`
+	// 矩阵的列, 目标名称和地址
+	Targets []string `json:"targets,omitempty"`
+	// 链路数
+	Total int64 `json:"total,omitempty"`
+	// 未探测的链路数
+	Unknown int64 `json:"unknown,omitempty"`
+	// 不可达的链路数
+	Unreachable int64 `json:"unreachable,omitempty"`
+}
+
+// OesOesLinkMatrixReply 对应oes.OesLinkMatrixReply
+type OesOesLinkMatrixReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *OesOesLinkMatrixOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// OesOesLinkOut 对应oes.OesLinkOut
+type OesOesLinkOut struct {
+	// 创建时间
+	CreatedAt string `json:"created_at,omitempty"`
+	// ID
+	ID int64 `json:"id,omitempty"`
+	// 是否启用
+	IsEnabled bool `json:"is_enabled,omitempty"`
+	// 最近一次探测时间
+	LastCheckedAt string `json:"last_checked_at,omitempty"`
+	// 最近一次探测失败原因
+	LastError string `json:"last_error,omitempty"`
+	// 最近一次建立连接的耗时(毫秒)
+	LatencyMs float64 `json:"latency_ms,omitempty"`
+	// 名称
+	Name string `json:"name,omitempty"`
+	// oes集群ID
+	OesColonyID int64 `json:"oes_colony_id,omitempty"`
+	// 备注
+	Remark string `json:"remark,omitempty"`
+	// 探测发起方, 主机名称或platform
+	Source string `json:"source,omitempty"`
+	// 源主机ID, 0表示平台
+	SourceHostID int64 `json:"source_host_id,omitempty"`
+	// 探测状态(unknown/reachable/unreachable)
+	Status string `json:"status,omitempty"`
+	// 目标地址
+	TargetHost string `json:"target_host,omitempty"`
+	// 目标名称
+	TargetName string `json:"target_name,omitempty"`
+	// 目标端口
+	TargetPort int64 `json:"target_port,omitempty"`
+	// 连接超时(毫秒)
+	TimeoutMs int64 `json:"timeout_ms,omitempty"`
+	// 更新时间
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+// OesOesLinkProbeOut 对应oes.OesLinkProbeOut
+type OesOesLinkProbeOut struct {
+	// 探测时间
+	CreatedAt string `json:"created_at,omitempty"`
+	// 探测失败原因
+	Error string `json:"error,omitempty"`
+	// ID
+	ID int64 `json:"id,omitempty"`
+	// 建立连接的耗时(毫秒)
+	LatencyMs float64 `json:"latency_ms,omitempty"`
+	// 链路ID
+	LinkID int64 `json:"link_id,omitempty"`
+	// 探测状态
+	Status string `json:"status,omitempty"`
+}
+
+// OesOesLinkReply 对应oes.OesLinkReply
+type OesOesLinkReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *OesOesLinkOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// OesOesLinkRequest 对应oes.OesLinkRequest
+type OesOesLinkRequest struct {
+	// 是否启用
+	IsEnabled bool `json:"is_enabled,omitempty"`
+	// 名称
+	Name string `json:"name"`
+	// oes集群ID, 0表示不属于集群
+	OesColonyID int64 `json:"oes_colony_id,omitempty"`
+	// 备注
+	Remark string `json:"remark,omitempty"`
+	// 源主机ID, 0表示由平台探测
+	SourceHostID int64 `json:"source_host_id,omitempty"`
+	// 目标地址, 主机名或IP
+	TargetHost string `json:"target_host"`
+	// 目标名称, 例如XCounter、上交所网关
+	TargetName string `json:"target_name"`
+	// 目标端口
+	TargetPort int64 `json:"target_port"`
+	// 连接超时(毫秒), 默认3000
+	TimeoutMs int64 `json:"timeout_ms,omitempty"`
+}
+
+// OesOesNodeDetailOut 对应oes.OesNodeDetailOut
+type OesOesNodeDetailOut struct {
+	// 创建时间
+	CreatedAt string               `json:"created_at,omitempty"`
+	Host      *ResourceHostBaseOut `json:"host,omitempty"`
+	// ID
+	ID int64 `json:"id,omitempty"`
+	// 是否启用
+	IsEnable bool `json:"is_enable,omitempty"`
+	// 节点角色
+	NodeRole  string               `json:"node_role,omitempty"`
+	OesColony *OesOesColonyBaseOut `json:"oes_colony,omitempty"`
+	// 更新时间
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+// OesOesNodeReply 对应oes.OesNodeReply
+type OesOesNodeReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *OesOesNodeDetailOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// OesOesReconcileFileOut 对应oes.OesReconcileFileOut
+type OesOesReconcileFileOut struct {
+	// 下发数据记录数
+	DistributeRows int64 `json:"distribute_rows,omitempty"`
+	// 节点多余的记录数
+	Extra int64 `json:"extra,omitempty"`
+	// 柜台数据记录数
+	FetchRows int64 `json:"fetch_rows,omitempty"`
+	// 数据文件名称
+	FileName string `json:"file_name,omitempty"`
+	// 主机ID
+	HostID int64 `json:"host_id,omitempty"`
+	// 说明
+	Message string `json:"message,omitempty"`
+	// 内容不一致的记录数
+	Mismatched int64 `json:"mismatched,omitempty"`
+	// 节点缺少的记录数
+	Missing int64 `json:"missing,omitempty"`
+	// 节点ID
+	NodeID int64 `json:"node_id,omitempty"`
+	// 节点上的文件路径
+	RemotePath string `json:"remote_path,omitempty"`
+	// 对账状态(matched/mismatched/failed)
+	Status string `json:"status,omitempty"`
+}
+
+// OesOesReconcileReportDetailOut 对应oes.OesReconcileReportDetailOut
+type OesOesReconcileReportDetailOut struct {
+	// 对账时间
+	CheckedAt string `json:"checked_at,omitempty"`
+	// 集群号
+	ColonyNum string `json:"colony_num,omitempty"`
+	// 节点多余的记录数
+	Extra int64 `json:"extra,omitempty"`
+	// 柜台数据文件数
+	FileCount int64 `json:"file_count,omitempty"`
+	// 每个节点上每个数据文件的对账结果
+	Files []*OesOesReconcileFileOut `json:"files,omitempty"`
+	// ID
+	ID int64 `json:"id,omitempty"`
+	// 说明
+	Message string `json:"message,omitempty"`
+	// 内容不一致的记录数
+	Mismatched int64 `json:"mismatched,omitempty"`
+	// 节点缺少的记录数
+	Missing int64 `json:"missing,omitempty"`
+	// 对账节点数
+	NodeCount int64 `json:"node_count,omitempty"`
+	// oes集群ID
+	OesColonyID int64 `json:"oes_colony_id,omitempty"`
+	// 对账状态(matched/mismatched/failed)
+	Status string `json:"status,omitempty"`
+	// 交易日
+	TradingDay string `json:"trading_day,omitempty"`
+	// 发起对账的用户名, 定时对账为空
+	Username string `json:"username,omitempty"`
+}
+
+// OesOesReconcileReportOut 对应oes.OesReconcileReportOut
+type OesOesReconcileReportOut struct {
+	// 对账时间
+	CheckedAt string `json:"checked_at,omitempty"`
+	// 集群号
+	ColonyNum string `json:"colony_num,omitempty"`
+	// 节点多余的记录数
+	Extra int64 `json:"extra,omitempty"`
+	// 柜台数据文件数
+	FileCount int64 `json:"file_count,omitempty"`
+	// ID
+	ID int64 `json:"id,omitempty"`
+	// 说明
+	Message string `json:"message,omitempty"`
+	// 内容不一致的记录数
+	Mismatched int64 `json:"mismatched,omitempty"`
+	// 节点缺少的记录数
+	Missing int64 `json:"missing,omitempty"`
+	// 对账节点数
+	NodeCount int64 `json:"node_count,omitempty"`
+	// oes集群ID
+	OesColonyID int64 `json:"oes_colony_id,omitempty"`
+	// 对账状态(matched/mismatched/failed)
+	Status string `json:"status,omitempty"`
+	// 交易日
+	TradingDay string `json:"trading_day,omitempty"`
+	// 发起对账的用户名, 定时对账为空
+	Username string `json:"username,omitempty"`
+}
+
+// OesOesReconcileReportReply 对应oes.OesReconcileReportReply
+type OesOesReconcileReportReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *OesOesReconcileReportDetailOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// OesOesRunbookExecDetailOut 对应oes.OesRunbookExecDetailOut
+type OesOesRunbookExecDetailOut struct {
+	// 集群号
+	ColonyNum string `json:"colony_num,omitempty"`
+	// 发起时间
+	CreatedAt string `json:"created_at,omitempty"`
+	// 结束时间
+	FinishedAt string `json:"finished_at,omitempty"`
+	// ID
+	ID int64 `json:"id,omitempty"`
+	// oes集群ID
+	OesColonyID int64 `json:"oes_colony_id,omitempty"`
+	// 执行进度
+	Progress *OesOesRunbookProgressOut `json:"progress,omitempty"`
+	// 检查单ID
+	RunbookID int64 `json:"runbook_id,omitempty"`
+	// 检查单名称
+	RunbookName string `json:"runbook_name,omitempty"`
+	// 执行状态(running/blocked/success/aborted)
+	Status string `json:"status,omitempty"`
+	// 步骤执行详情
+	Steps []*OesOesRunbookExecStepOut `json:"steps,omitempty"`
+	// 发起人
+	Username string `json:"username,omitempty"`
+}
+
+// OesOesRunbookExecOut 对应oes.OesRunbookExecOut
+type OesOesRunbookExecOut struct {
+	// 集群号
+	ColonyNum string `json:"colony_num,omitempty"`
+	// 发起时间
+	CreatedAt string `json:"created_at,omitempty"`
+	// 结束时间
+	FinishedAt string `json:"finished_at,omitempty"`
+	// ID
+	ID int64 `json:"id,omitempty"`
+	// oes集群ID
+	OesColonyID int64 `json:"oes_colony_id,omitempty"`
+	// 执行进度
+	Progress *OesOesRunbookProgressOut `json:"progress,omitempty"`
+	// 检查单ID
+	RunbookID int64 `json:"runbook_id,omitempty"`
+	// 检查单名称
+	RunbookName string `json:"runbook_name,omitempty"`
+	// 执行状态(running/blocked/success/aborted)
+	Status string `json:"status,omitempty"`
+	// 发起人
+	Username string `json:"username,omitempty"`
+}
+
+// OesOesRunbookExecReply 对应oes.OesRunbookExecReply
+type OesOesRunbookExecReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *OesOesRunbookExecDetailOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// OesOesRunbookExecStepOut 对应oes.OesRunbookExecStepOut
+type OesOesRunbookExecStepOut struct {
+	// 脚本执行次数
+	Attempts int64 `json:"attempts,omitempty"`
+	// 错误信息
+	ErrorMessage string `json:"error_message,omitempty"`
+	// 完成时间
+	FinishedAt string `json:"finished_at,omitempty"`
+	// 步骤ID
+	ID int64 `json:"id,omitempty"`
+	// 操作说明
+	Instruction string `json:"instruction,omitempty"`
+	// 步骤类型(manual/script)
+	Kind string `json:"kind,omitempty"`
+	// 步骤名称
+	Name string `json:"name,omitempty"`
+	// 确认或执行步骤的操作员
+	Operator string `json:"operator,omitempty"`
+	// 脚本执行记录ID
+	RecordID int64 `json:"record_id,omitempty"`
+	// 操作员备注
+	Remark string `json:"remark,omitempty"`
+	// 脚本ID
+	ScriptID int64 `json:"script_id,omitempty"`
+	// 开始时间
+	StartedAt string `json:"started_at,omitempty"`
+	// 执行状态(pending/waiting/running/success/failed/skipped)
+	Status string `json:"status,omitempty"`
+}
+
+// OesOesRunbookOut 对应oes.OesRunbookOut
+type OesOesRunbookOut struct {
+	// 创建时间
+	CreatedAt string `json:"created_at,omitempty"`
+	// 说明
+	Description string `json:"description,omitempty"`
+	// ID
+	ID int64 `json:"id,omitempty"`
+	// 是否启用
+	IsEnabled bool `json:"is_enabled,omitempty"`
+	// 名称
+	Name string `json:"name,omitempty"`
+	// 按顺序执行的步骤
+	Steps []*OesOesRunbookStepOut `json:"steps,omitempty"`
+	// 适用的系统类型
+	SystemType string `json:"system_type,omitempty"`
+	// 更新时间
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+// OesOesRunbookProgressOut 对应oes.OesRunbookProgressOut
+type OesOesRunbookProgressOut struct {
+	// 当前步骤名称
+	Current string `json:"current,omitempty"`
+	// 已完成的步骤数
+	Done int64 `json:"done,omitempty"`
+	// 步骤总数
+	Total int64 `json:"total,omitempty"`
+}
+
+// OesOesRunbookReply 对应oes.OesRunbookReply
+type OesOesRunbookReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *OesOesRunbookOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// OesOesRunbookRequest 对应oes.OesRunbookRequest
+type OesOesRunbookRequest struct {
+	// 说明
+	Description string `json:"description,omitempty"`
+	// 是否启用
+	IsEnabled bool `json:"is_enabled,omitempty"`
+	// 名称
+	Name string `json:"name"`
+	// 按顺序执行的步骤
+	Steps []*OesOesRunbookStepRequest `json:"steps"`
+	// 适用的系统类型, 为空时适用全部集群
+	SystemType string `json:"system_type,omitempty"`
+}
+
+// OesOesRunbookStepOut 对应oes.OesRunbookStepOut
+type OesOesRunbookStepOut struct {
+	// 操作说明
+	Instruction string `json:"instruction,omitempty"`
+	// 步骤类型(manual/script)
+	Kind string `json:"kind,omitempty"`
+	// 步骤名称
+	Name string `json:"name,omitempty"`
+	// 脚本名称
+	Script string `json:"script,omitempty"`
+	// 脚本超时时间(秒)
+	Timeout int64 `json:"timeout,omitempty"`
+}
+
+// OesOesRunbookStepRequest 对应oes.OesRunbookStepRequest
+type OesOesRunbookStepRequest struct {
+	// 操作说明
+	Instruction string `json:"instruction,omitempty"`
+	// 步骤类型, manual为人工确认, script为执行内置脚本
+	Kind string `json:"kind"`
+	// 步骤名称
+	Name string `json:"name"`
+	// 脚本名称, 需为oes的内置命令脚本, 执行参数为集群号
+	Script string `json:"script,omitempty"`
+	// 脚本超时时间(秒), 默认为3600
+	Timeout int64 `json:"timeout,omitempty"`
+}
+
+// OesOesTaskCatalogOut 对应oes.OesTaskCatalogOut
+type OesOesTaskCatalogOut struct {
+	// 创建时间
+	CreatedAt string `json:"created_at,omitempty"`
+	// 说明
+	Description string `json:"description,omitempty"`
+	// ID
+	ID int64 `json:"id,omitempty"`
+	// 是否启用
+	IsEnabled bool `json:"is_enabled,omitempty"`
+	// 排序
+	Sort int64 `json:"sort,omitempty"`
+	// 系统类型
+	SystemType string `json:"system_type,omitempty"`
+	// 任务名称
+	TaskName string `json:"task_name,omitempty"`
+	// 更新时间
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+// OesOesTaskCatalogReply 对应oes.OesTaskCatalogReply
+type OesOesTaskCatalogReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *OesOesTaskCatalogOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// OesOesTaskCatalogRequest 对应oes.OesTaskCatalogRequest
+type OesOesTaskCatalogRequest struct {
+	// 说明
+	Description string `json:"description,omitempty"`
+	// 是否启用
+	IsEnabled bool `json:"is_enabled,omitempty"`
+	// 排序, 越小越靠前
+	Sort int64 `json:"sort,omitempty"`
+	// 系统类型
+	SystemType string `json:"system_type"`
+	// 任务名称, 只能包含小写字母、数字和下划线, 以小写字母开头
+	TaskName string `json:"task_name"`
+}
+
+// OesOesTaskSlaOut 对应oes.OesTaskSlaOut
+type OesOesTaskSlaOut struct {
+	// 创建时间
+	CreatedAt string `json:"created_at,omitempty"`
+	// 完成截止时间
+	Deadline string `json:"deadline,omitempty"`
+	// 说明
+	Description string `json:"description,omitempty"`
+	// ID
+	ID int64 `json:"id,omitempty"`
+	// 是否启用
+	IsEnabled bool `json:"is_enabled,omitempty"`
+	// oes集群ID, 0表示全部集群
+	OesColonyID int64 `json:"oes_colony_id,omitempty"`
+	// 任务名称
+	TaskName string `json:"task_name,omitempty"`
+	// 更新时间
+	UpdatedAt string `json:"updated_at,omitempty"`
+	// 截止前多少分钟发出即将超时告警
+	WarnMinutes int64 `json:"warn_minutes,omitempty"`
+}
+
+// OesOesTaskSlaReply 对应oes.OesTaskSlaReply
+type OesOesTaskSlaReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *OesOesTaskSlaOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// OesOesTaskSlaRequest 对应oes.OesTaskSlaRequest
+type OesOesTaskSlaRequest struct {
+	// 完成截止时间, 格式为15:04
+	Deadline string `json:"deadline"`
+	// 说明
+	Description string `json:"description,omitempty"`
+	// 是否启用
+	IsEnabled bool `json:"is_enabled,omitempty"`
+	// oes集群ID, 0表示全部集群
+	OesColonyID int64 `json:"oes_colony_id,omitempty"`
+	// 任务名称, 需要在日常任务目录中
+	TaskName string `json:"task_name"`
+	// 截止前多少分钟发出即将超时告警, 0表示不告警
+	WarnMinutes int64 `json:"warn_minutes,omitempty"`
+}
+
+// OesOesTaskSlaResultOut 对应oes.OesTaskSlaResultOut
+type OesOesTaskSlaResultOut struct {
+	// 检查时间
+	CheckedAt string `json:"checked_at,omitempty"`
+	// 集群号
+	ColonyNum string `json:"colony_num,omitempty"`
+	// 完成截止时间
+	Deadline string `json:"deadline,omitempty"`
+	// 任务执行成功的时间
+	FinishedAt string `json:"finished_at,omitempty"`
+	// oes集群ID
+	OesColonyID int64 `json:"oes_colony_id,omitempty"`
+	// 任务的执行记录ID, 0表示当天未执行
+	RecordID int64 `json:"record_id,omitempty"`
+	// 任务的执行状态(0-待执行,1-执行中,2-成功,3-失败,4-超时,5-崩溃,6-中断)
+	RecordStatus int64 `json:"record_status,omitempty"`
+	// SLA状态(pending/at_risk/met/late/breached)
+	Status string `json:"status,omitempty"`
+	// 任务名称
+	TaskName string `json:"task_name,omitempty"`
+}
+
+// OesOesWorkflowDefinitionReply 对应oes.OesWorkflowDefinitionReply
+type OesOesWorkflowDefinitionReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data []*OesOesWorkflowStepConf `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// OesOesWorkflowRunDetailOut 对应oes.OesWorkflowRunDetailOut
+type OesOesWorkflowRunDetailOut struct {
+	// 集群号
+	ColonyNum string `json:"colony_num,omitempty"`
+	// 创建时间
+	CreatedAt string `json:"created_at,omitempty"`
+	// 耗时(秒)
+	Duration float64 `json:"duration,omitempty"`
+	// 结束时间
+	FinishedAt string `json:"finished_at,omitempty"`
+	// ID
+	ID int64 `json:"id,omitempty"`
+	// oes集群ID
+	OesColonyID int64 `json:"oes_colony_id,omitempty"`
+	// 开始时间
+	StartedAt string `json:"started_at,omitempty"`
+	// 执行状态(pending/running/success/failed)
+	Status string `json:"status,omitempty"`
+	// 步骤执行详情
+	Steps []*OesOesWorkflowRunStepOut `json:"steps,omitempty"`
+	// 系统类型
+	SystemType string `json:"system_type,omitempty"`
+	// 用户名
+	Username string `json:"username,omitempty"`
+}
+
+// OesOesWorkflowRunOut 对应oes.OesWorkflowRunOut
+type OesOesWorkflowRunOut struct {
+	// 集群号
+	ColonyNum string `json:"colony_num,omitempty"`
+	// 创建时间
+	CreatedAt string `json:"created_at,omitempty"`
+	// 耗时(秒)
+	Duration float64 `json:"duration,omitempty"`
+	// 结束时间
+	FinishedAt string `json:"finished_at,omitempty"`
+	// ID
+	ID int64 `json:"id,omitempty"`
+	// oes集群ID
+	OesColonyID int64 `json:"oes_colony_id,omitempty"`
+	// 开始时间
+	StartedAt string `json:"started_at,omitempty"`
+	// 执行状态(pending/running/success/failed)
+	Status string `json:"status,omitempty"`
+	// 系统类型
+	SystemType string `json:"system_type,omitempty"`
+	// 用户名
+	Username string `json:"username,omitempty"`
+}
+
+// OesOesWorkflowRunReply 对应oes.OesWorkflowRunReply
+type OesOesWorkflowRunReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *OesOesWorkflowRunDetailOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// OesOesWorkflowRunStepOut 对应oes.OesWorkflowRunStepOut
+type OesOesWorkflowRunStepOut struct {
+	// 依赖的步骤
+	DependsOn []string `json:"depends_on,omitempty"`
+	// 耗时(秒)
+	Duration float64 `json:"duration,omitempty"`
+	// 错误信息
+	ErrorMessage string `json:"error_message,omitempty"`
+	// 结束时间
+	FinishedAt string `json:"finished_at,omitempty"`
+	// 步骤名称
+	Name string `json:"name,omitempty"`
+	// 脚本执行记录ID
+	RecordID int64 `json:"record_id,omitempty"`
+	// 脚本ID
+	ScriptID int64 `json:"script_id,omitempty"`
+	// 开始时间
+	StartedAt string `json:"started_at,omitempty"`
+	// 执行状态(pending/running/success/failed/skipped)
+	Status string `json:"status,omitempty"`
+}
+
+// OesOesWorkflowStepConf 对应oes.OesWorkflowStepConf
+type OesOesWorkflowStepConf struct {
+	DependsOn []string `json:"depends_on,omitempty"`
+	Name      string   `json:"name,omitempty"`
+	Script    string   `json:"script,omitempty"`
+	Timeout   int64    `json:"timeout,omitempty"`
+}
+
+// OesPagOesColonyReply 对应oes.PagOesColonyReply
+type OesPagOesColonyReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CommonPagOesOesColonyDetailOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// OesPagOesConfReply 对应oes.PagOesConfReply
+type OesPagOesConfReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *FileutilFileInfo `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// OesPagOesConfTemplateReply 对应oes.PagOesConfTemplateReply
+type OesPagOesConfTemplateReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CommonPagOesOesConfTemplateOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// OesPagOesLinkProbeReply 对应oes.PagOesLinkProbeReply
+type OesPagOesLinkProbeReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CommonPagOesOesLinkProbeOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// OesPagOesLinkReply 对应oes.PagOesLinkReply
+type OesPagOesLinkReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CommonPagOesOesLinkOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// OesPagOesNodeReply 对应oes.PagOesNodeReply
+type OesPagOesNodeReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CommonPagOesOesNodeDetailOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// OesPagOesReconcileReportReply 对应oes.PagOesReconcileReportReply
+type OesPagOesReconcileReportReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CommonPagOesOesReconcileReportOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// OesPagOesRunbookExecReply 对应oes.PagOesRunbookExecReply
+type OesPagOesRunbookExecReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CommonPagOesOesRunbookExecOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// OesPagOesRunbookReply 对应oes.PagOesRunbookReply
+type OesPagOesRunbookReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CommonPagOesOesRunbookOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// OesPagOesTaskCatalogReply 对应oes.PagOesTaskCatalogReply
+type OesPagOesTaskCatalogReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CommonPagOesOesTaskCatalogOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// OesPagOesTaskSlaReply 对应oes.PagOesTaskSlaReply
+type OesPagOesTaskSlaReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CommonPagOesOesTaskSlaOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// OesPagOesWorkflowRunReply 对应oes.PagOesWorkflowRunReply
+type OesPagOesWorkflowRunReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CommonPagOesOesWorkflowRunOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// OesReconcileColonyRequest 对应oes.ReconcileColonyRequest
+type OesReconcileColonyRequest struct {
+	// 交易日, 格式为2006-01-02, 默认为当天
+	TradingDay string `json:"trading_day,omitempty"`
+}
+
+// OesRenderOesConfTemplateRequest 对应oes.RenderOesConfTemplateRequest
+type OesRenderOesConfTemplateRequest struct {
+	// oes集群ID
+	OesColonyID int64 `json:"oes_colony_id"`
+}
+
+// OesSignOffRunbookStepRequest 对应oes.SignOffRunbookStepRequest
+type OesSignOffRunbookStepRequest struct {
+	// 备注
+	Remark string `json:"remark,omitempty"`
+}
+
+// OesStartOesRunbookRequest 对应oes.StartOesRunbookRequest
+type OesStartOesRunbookRequest struct {
+	// 检查单ID
+	RunbookID int64 `json:"runbook_id"`
+}
+
+// OesTaskSlaComplianceOut 对应oes.TaskSlaComplianceOut
+type OesTaskSlaComplianceOut struct {
+	// 即将超时的任务数
+	AtRisk int64 `json:"at_risk,omitempty"`
+	// 已超时未完成的任务数
+	Breached int64 `json:"breached,omitempty"`
+	// 达成率(%), 截止前完成的任务数占已到截止时间的任务数的比例, 没有已到截止时间的任务时为100
+	ComplianceRate float64 `json:"compliance_rate,omitempty"`
+	// 截止后才完成的任务数
+	Late int64 `json:"late,omitempty"`
+	// 截止前完成的任务数
+	Met int64 `json:"met,omitempty"`
+	// 未到截止时间的任务数
+	Pending int64 `json:"pending,omitempty"`
+	// 每个集群每个任务的SLA检查结果
+	Results []*OesOesTaskSlaResultOut `json:"results,omitempty"`
+	// 任务数
+	Total int64 `json:"total,omitempty"`
+	// 交易日
+	TradingDay string `json:"trading_day,omitempty"`
+}
+
+// OesTaskSlaComplianceReply 对应oes.TaskSlaComplianceReply
+type OesTaskSlaComplianceReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *OesTaskSlaComplianceOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// OesTopologyEdgeOut 对应oes.TopologyEdgeOut
+type OesTopologyEdgeOut struct {
+	// 起点节点标识
+	Source string `json:"source,omitempty"`
+	// 终点节点标识
+	Target string `json:"target,omitempty"`
+	// 连线类型
+	Type string `json:"type,omitempty"`
+}
+
+// OesTopologyNodeOut 对应oes.TopologyNodeOut
+type OesTopologyNodeOut struct {
+	// 节点详情
+	Data any `json:"data,omitempty"`
+	// 节点标识, 格式为 类型:ID
+	ID string `json:"id,omitempty"`
+	// 显示名称
+	Label string `json:"label,omitempty"`
+	// 节点类型(colony/package/xcounter/mon_node/oes_node/host/task)
+	Type string `json:"type,omitempty"`
+}
+
+// ResourceCreateOrUpdateHosrRequest 对应resource.CreateOrUpdateHosrRequest
+type ResourceCreateOrUpdateHosrRequest struct {
+	// 标签
+	Label string `json:"label"`
+	// 名称
+	Name string `json:"name"`
+	// python路径
+	PyPath string `json:"py_path,omitempty"`
+	// 备注
+	Remark string `json:"remark,omitempty"`
+	// ip地址
+	SSHIP string `json:"ssh_ip"`
+	// 密码
+	SSHPassword string `json:"ssh_password"`
+	// 端口
+	SSHPort int64 `json:"ssh_port"`
+	// 用户名
+	SSHUser string `json:"ssh_user"`
+}
+
+// ResourceHostAgentDisk 对应resource.HostAgentDisk
+type ResourceHostAgentDisk struct {
+	FsType  string `json:"fs_type,omitempty"`
+	Mount   string `json:"mount"`
+	TotalMb int64  `json:"total_mb,omitempty"`
+	UsedMb  int64  `json:"used_mb,omitempty"`
+}
+
+// ResourceHostAgentHeartbeatRequest 对应resource.HostAgentHeartbeatRequest
+type ResourceHostAgentHeartbeatRequest struct {
+	// 注册时返回的主机ID
+	HostID int64 `json:"host_id"`
+	// 主机基础指标, 上报后mon模块不再通过SSH采集该主机的指标
+	Metrics *ResourceHostAgentMetrics `json:"metrics,omitempty"`
+}
+
+// ResourceHostAgentMetrics 对应resource.HostAgentMetrics
+type ResourceHostAgentMetrics struct {
+	// CPU使用率(%)
+	CPUPercent float64 `json:"cpu_percent,omitempty"`
+	// 根文件系统使用率(%)
+	DiskPercent float64 `json:"disk_percent,omitempty"`
+	// 1分钟平均负载
+	Load1 float64 `json:"load1,omitempty"`
+	// 15分钟平均负载
+	Load15 float64 `json:"load15,omitempty"`
+	// 5分钟平均负载
+	Load5 float64 `json:"load5,omitempty"`
+	// 内存使用率(%)
+	MemPercent float64 `json:"mem_percent,omitempty"`
+}
+
+// ResourceHostAgentOut 对应resource.HostAgentOut
+type ResourceHostAgentOut struct {
+	// 代理版本
+	AgentVersion string `json:"agent_version,omitempty"`
+	// CPU架构
+	Arch string `json:"arch,omitempty"`
+	// CPU核数
+	CPUCores int64 `json:"cpu_cores,omitempty"`
+	// 注册时间
+	CreatedAt string `json:"created_at,omitempty"`
+	// 磁盘
+	Disks []*ResourceHostAgentDisk `json:"disks,omitempty"`
+	// 主机
+	Host *ResourceHostBaseOut `json:"host,omitempty"`
+	// 主机名
+	Hostname string `json:"hostname,omitempty"`
+	// ID
+	ID int64 `json:"id,omitempty"`
+	// IP地址
+	Ips []string `json:"ips,omitempty"`
+	// 内核版本
+	Kernel string `json:"kernel,omitempty"`
+	// 最近心跳时间
+	LastHeartbeatAt string `json:"last_heartbeat_at,omitempty"`
+	// 内存(MB)
+	MemoryMb int64 `json:"memory_mb,omitempty"`
+	// 操作系统
+	OS string `json:"os,omitempty"`
+	// 机器序列号
+	Serial string `json:"serial,omitempty"`
+	// 在线状态
+	Status string `json:"status,omitempty"`
+	// 更新时间
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+// ResourceHostAgentReply 对应resource.HostAgentReply
+type ResourceHostAgentReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *ResourceHostAgentOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// ResourceHostBaseOut 对应resource.HostBaseOut
+type ResourceHostBaseOut struct {
+	// 主机ID
+	ID int64 `json:"id,omitempty"`
+	// 标签
+	Label string `json:"label,omitempty"`
+	// 名称
+	Name string `json:"name,omitempty"`
+	// Python路径
+	PyPath string `json:"py_path,omitempty"`
+	// 备注
+	Remark string `json:"remark,omitempty"`
+	// IP地址
+	SSHIP string `json:"ssh_ip,omitempty"`
+	// 端口
+	SSHPort int64 `json:"ssh_port,omitempty"`
+	// 用户名, 没有查看敏感字段权限时掩码
+	SSHUser string `json:"ssh_user,omitempty"`
+}
+
+// ResourceHostDuplicateGroupOut 对应resource.HostDuplicateGroupOut
+type ResourceHostDuplicateGroupOut struct {
+	// 疑似重复的主机
+	Hosts []*ResourceHostStandardOut `json:"hosts,omitempty"`
+	// 匹配依据
+	Reason string `json:"reason,omitempty"`
+	// 匹配值
+	Value string `json:"value,omitempty"`
+}
+
+// ResourceHostDuplicateReply 对应resource.HostDuplicateReply
+type ResourceHostDuplicateReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data []*ResourceHostDuplicateGroupOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// ResourceHostFileOut 对应resource.HostFileOut
+type ResourceHostFileOut struct {
+	// 是否为目录
+	IsDir bool `json:"is_dir,omitempty"`
+	// 是否为符号链接
+	IsLink bool `json:"is_link,omitempty"`
+	// 修改时间
+	ModTime string `json:"mod_time,omitempty"`
+	// 权限
+	Mode string `json:"mode,omitempty"`
+	// 文件名
+	Name string `json:"name,omitempty"`
+	// 绝对路径
+	Path string `json:"path,omitempty"`
+	// 文件大小(字节)
+	Size int64 `json:"size,omitempty"`
+}
+
+// ResourceHostFileReply 对应resource.HostFileReply
+type ResourceHostFileReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *ResourceHostFileOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// ResourceHostMergeOut 对应resource.HostMergeOut
+type ResourceHostMergeOut struct {
+	// 已删除的重复主机ID
+	DuplicateID int64 `json:"duplicate_id,omitempty"`
+	// 保留的主机ID
+	HostID int64 `json:"host_id,omitempty"`
+	// 各数据表改为引用保留主机的记录数
+	References map[string]int64 `json:"references,omitempty"`
+}
+
+// ResourceHostMergeReply 对应resource.HostMergeReply
+type ResourceHostMergeReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *ResourceHostMergeOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// ResourceHostPathRuleOut 对应resource.HostPathRuleOut
+type ResourceHostPathRuleOut struct {
+	// 创建时间
+	CreatedAt string `json:"created_at,omitempty"`
+	// ID
+	ID int64 `json:"id,omitempty"`
+	// 允许访问的目录
+	Path string `json:"path,omitempty"`
+	// 备注
+	Remark string `json:"remark,omitempty"`
+	// 角色ID
+	RoleID int64 `json:"role_id,omitempty"`
+	// 更新时间
+	UpdatedAt string `json:"updated_at,omitempty"`
+	// 是否允许上传
+	Writable bool `json:"writable,omitempty"`
+}
+
+// ResourceHostPathRuleReply 对应resource.HostPathRuleReply
+type ResourceHostPathRuleReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *ResourceHostPathRuleOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// ResourceHostPathRuleRequest 对应resource.HostPathRuleRequest
+type ResourceHostPathRuleRequest struct {
+	// 允许访问的目录, 必须为绝对路径
+	Path string `json:"path"`
+	// 备注
+	Remark string `json:"remark,omitempty"`
+	// 角色ID
+	RoleID int64 `json:"role_id"`
+	// 是否允许上传
+	Writable bool `json:"writable,omitempty"`
+}
+
+// ResourceHostReply 对应resource.HostReply
+type ResourceHostReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *ResourceHostStandardOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// ResourceHostStandardOut 对应resource.HostStandardOut
+type ResourceHostStandardOut struct {
+	// 创建时间
+	CreatedAt string `json:"created_at,omitempty"`
+	// 主机ID
+	ID int64 `json:"id,omitempty"`
+	// 标签
+	Label string `json:"label,omitempty"`
+	// 名称
+	Name string `json:"name,omitempty"`
+	// Python路径
+	PyPath string `json:"py_path,omitempty"`
+	// 备注
+	Remark string `json:"remark,omitempty"`
+	// IP地址
+	SSHIP string `json:"ssh_ip,omitempty"`
+	// 端口
+	SSHPort int64 `json:"ssh_port,omitempty"`
+	// 用户名, 没有查看敏感字段权限时掩码
+	SSHUser string `json:"ssh_user,omitempty"`
+	// 更新时间
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+// ResourceInitPackageUploadRequest 对应resource.InitPackageUploadRequest
+type ResourceInitPackageUploadRequest struct {
+	// 文件SHA256校验和(十六进制), 不为空时完成上传时校验整个文件
+	Checksum string `json:"checksum,omitempty"`
+	// 原始文件名
+	Filename string `json:"filename"`
+	// 程序包标签
+	Label string `json:"label"`
+	// 文件大小(字节)
+	Size int64 `json:"size"`
+	// 程序包版本
+	Version string `json:"version"`
+}
+
+// ResourceListHostFileReply 对应resource.ListHostFileReply
+type ResourceListHostFileReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data []*ResourceHostFileOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// ResourceMergeHostRequest 对应resource.MergeHostRequest
+type ResourceMergeHostRequest struct {
+	// 重复的主机ID
+	DuplicateID int64 `json:"duplicate_id"`
+}
+
+// ResourcePackageDownloadURLOut 对应resource.PackageDownloadURLOut
+type ResourcePackageDownloadURLOut struct {
+	// 文件SHA256校验和
+	Checksum string `json:"checksum,omitempty"`
+	// 预签名地址的过期时间
+	ExpiresAt string `json:"expires_at,omitempty"`
+	// 是否为对象存储的预签名地址, 预签名地址无需携带令牌
+	Presigned bool `json:"presigned,omitempty"`
+	// 下载地址
+	URL string `json:"url,omitempty"`
+}
+
+// ResourcePackageDownloadURLReply 对应resource.PackageDownloadURLReply
+type ResourcePackageDownloadURLReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *ResourcePackageDownloadURLOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// ResourcePackageReply 对应resource.PackageReply
+type ResourcePackageReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *ResourcePackageStandardOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// ResourcePackageStandardOut 对应resource.PackageStandardOut
+type ResourcePackageStandardOut struct {
+	// 文件SHA256校验和
+	Checksum string `json:"checksum,omitempty"`
+	// 名称
+	Filename string `json:"filename,omitempty"`
+	// 主机ID
+	ID int64 `json:"id,omitempty"`
+	// 标签
+	Label string `json:"label,omitempty"`
+	// 文件大小(字节)
+	Size int64 `json:"size,omitempty"`
+	// 上传时间
+	UploadedAt string `json:"uploaded_at,omitempty"`
+	// IP地址
+	Version string `json:"version,omitempty"`
+}
+
+// ResourcePackageUploadOut 对应resource.PackageUploadOut
+type ResourcePackageUploadOut struct {
+	// 分片大小上限(字节)
+	ChunkSize int64 `json:"chunk_size,omitempty"`
+	// 过期时间, 过期后会话和已上传的分片会被清理
+	ExpiresAt string `json:"expires_at,omitempty"`
+	// 原始文件名
+	Filename string `json:"filename,omitempty"`
+	// 程序包标签
+	Label string `json:"label,omitempty"`
+	// 已接收字节数, 续传时作为下一个分片的起始位置
+	Received int64 `json:"received,omitempty"`
+	// 文件大小(字节)
+	Size int64 `json:"size,omitempty"`
+	// 上传会话标识
+	UploadID string `json:"upload_id,omitempty"`
+	// 程序包版本
+	Version string `json:"version,omitempty"`
+}
+
+// ResourcePackageUploadReply 对应resource.PackageUploadReply
+type ResourcePackageUploadReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *ResourcePackageUploadOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// ResourcePagHostAgentReply 对应resource.PagHostAgentReply
+type ResourcePagHostAgentReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CommonPagResourceHostAgentOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// ResourcePagHostPathRuleReply 对应resource.PagHostPathRuleReply
+type ResourcePagHostPathRuleReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CommonPagResourceHostPathRuleOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// ResourcePagHostReply 对应resource.PagHostReply
+type ResourcePagHostReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CommonPagResourceHostStandardOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// ResourcePagPackageReply 对应resource.PagPackageReply
+type ResourcePagPackageReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CommonPagResourcePackageStandardOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// ResourcePagTerminalSessionReply 对应resource.PagTerminalSessionReply
+type ResourcePagTerminalSessionReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CommonPagResourceTerminalSessionOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// ResourcePagWatchdogEventReply 对应resource.PagWatchdogEventReply
+type ResourcePagWatchdogEventReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CommonPagResourceWatchdogEventOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// ResourcePagWatchdogReply 对应resource.PagWatchdogReply
+type ResourcePagWatchdogReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CommonPagResourceWatchdogOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// ResourceRegisterHostAgentRequest 对应resource.RegisterHostAgentRequest
+type ResourceRegisterHostAgentRequest struct {
+	// 代理版本
+	AgentVersion string `json:"agent_version,omitempty"`
+	// CPU架构
+	Arch string `json:"arch,omitempty"`
+	// CPU核数
+	CPUCores int64 `json:"cpu_cores,omitempty"`
+	// 磁盘
+	Disks []*ResourceHostAgentDisk `json:"disks,omitempty"`
+	// 主机名, 自动创建主机时作为主机名称
+	Hostname string `json:"hostname"`
+	// IP地址
+	Ips []string `json:"ips,omitempty"`
+	// 内核版本
+	Kernel string `json:"kernel,omitempty"`
+	// 内存(MB)
+	MemoryMb int64 `json:"memory_mb,omitempty"`
+	// 操作系统
+	OS string `json:"os,omitempty"`
+	// 机器序列号, 用于识别重复登记的主机
+	Serial string `json:"serial,omitempty"`
+	// SSH地址, 为空时使用请求的来源地址
+	SSHIP string `json:"ssh_ip,omitempty"`
+	// SSH端口, 为空时使用配置的默认端口
+	SSHPort int64 `json:"ssh_port,omitempty"`
+	// SSH用户, 为空时使用配置的默认用户
+	SSHUser string `json:"ssh_user,omitempty"`
+}
+
+// ResourceTerminalSessionOut 对应resource.TerminalSessionOut
+type ResourceTerminalSessionOut struct {
+	// 输出字节数
+	BytesOut int64 `json:"bytes_out,omitempty"`
+	// 客户端IP
+	ClientIP string `json:"client_ip,omitempty"`
+	// 结束原因(client/exit/idle_timeout/max_duration/shutdown)
+	CloseReason string `json:"close_reason,omitempty"`
+	// 结束时间, 为空表示会话进行中
+	EndedAt string `json:"ended_at,omitempty"`
+	// 主机ID
+	HostID int64 `json:"host_id,omitempty"`
+	// 主机名称
+	HostName string `json:"host_name,omitempty"`
+	// ID
+	ID int64 `json:"id,omitempty"`
+	// 是否有录像
+	Recorded bool `json:"recorded,omitempty"`
+	// 会话标识
+	SessionID string `json:"session_id,omitempty"`
+	// IP地址
+	SSHIP string `json:"ssh_ip,omitempty"`
+	// 主机用户名
+	SSHUser string `json:"ssh_user,omitempty"`
+	// 开始时间
+	StartedAt string `json:"started_at,omitempty"`
+	// 操作用户
+	Username string `json:"username,omitempty"`
+}
+
+// ResourceWatchdogEventOut 对应resource.WatchdogEventOut
+type ResourceWatchdogEventOut struct {
+	// 动作
+	Action string `json:"action,omitempty"`
+	// 创建时间
+	CreatedAt string `json:"created_at,omitempty"`
+	// ID
+	ID int64 `json:"id,omitempty"`
+	// 信息
+	Message string `json:"message,omitempty"`
+	// 检查状态
+	Status string `json:"status,omitempty"`
+	// 进程守护ID
+	WatchdogID int64 `json:"watchdog_id,omitempty"`
+}
+
+// ResourceWatchdogOut 对应resource.WatchdogOut
+type ResourceWatchdogOut struct {
+	// 创建时间
+	CreatedAt string `json:"created_at,omitempty"`
+	// 期望状态
+	ExpectedState string `json:"expected_state,omitempty"`
+	// 主机
+	Host *ResourceHostBaseOut `json:"host,omitempty"`
+	// ID
+	ID int64 `json:"id,omitempty"`
+	// 是否启用
+	IsEnabled bool `json:"is_enabled,omitempty"`
+	// 检查对象类型
+	Kind string `json:"kind,omitempty"`
+	// 最近一次动作
+	LastAction string `json:"last_action,omitempty"`
+	// 最近一次检查时间
+	LastCheckedAt string `json:"last_checked_at,omitempty"`
+	// 最近一次检查信息
+	LastMessage string `json:"last_message,omitempty"`
+	// 重启窗口内最多重启次数
+	MaxRestarts int64 `json:"max_restarts,omitempty"`
+	// 名称
+	Name string `json:"name,omitempty"`
+	// 备注
+	Remark string `json:"remark,omitempty"`
+	// 重启命令
+	RestartCommand string `json:"restart_command,omitempty"`
+	// 重启策略
+	RestartPolicy string `json:"restart_policy,omitempty"`
+	// 重启窗口(分钟)
+	RestartWindow int64 `json:"restart_window,omitempty"`
+	// 检查状态
+	Status string `json:"status,omitempty"`
+	// 服务单元或进程名
+	Target string `json:"target,omitempty"`
+	// 更新时间
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+// ResourceWatchdogReply 对应resource.WatchdogReply
+type ResourceWatchdogReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *ResourceWatchdogOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// ResourceWatchdogRequest 对应resource.WatchdogRequest
+type ResourceWatchdogRequest struct {
+	// 期望状态
+	ExpectedState string `json:"expected_state"`
+	// 主机ID
+	HostID int64 `json:"host_id"`
+	// 是否启用
+	IsEnabled bool `json:"is_enabled,omitempty"`
+	// 检查对象类型(systemd:服务单元, process:进程名)
+	Kind string `json:"kind"`
+	// 重启窗口内最多重启次数, 默认3
+	MaxRestarts int64 `json:"max_restarts,omitempty"`
+	// 名称
+	Name string `json:"name"`
+	// 备注
+	Remark string `json:"remark,omitempty"`
+	// 重启命令, systemd服务为空时使用systemctl restart, 进程自动重启时必填
+	RestartCommand string `json:"restart_command,omitempty"`
+	// 重启策略(never:只告警, on_failure:期望运行但未运行时重启)
+	RestartPolicy string `json:"restart_policy"`
+	// 重启窗口(分钟), 默认60
+	RestartWindow int64 `json:"restart_window,omitempty"`
+	// 服务单元或进程名
+	Target string `json:"target"`
+}
+
+// SystemAnalyticsUsageOut 对应system.AnalyticsUsageOut
+type SystemAnalyticsUsageOut struct {
+	// 使用次数
+	Count int64 `json:"count,omitempty"`
+	// 功能标识
+	Feature string `json:"feature,omitempty"`
+	// 请求方法
+	Method string `json:"method,omitempty"`
+	// 所属模块
+	Module string `json:"module,omitempty"`
+	// 角色ID
+	RoleID int64 `json:"role_id,omitempty"`
+}
+
+// SystemAnalyticsUsageReply 对应system.AnalyticsUsageReply
+type SystemAnalyticsUsageReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data []*SystemAnalyticsUsageOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// SystemAuditRecordOut 对应system.AuditRecordOut
+type SystemAuditRecordOut struct {
+	// 操作类型
+	Action string `json:"action,omitempty"`
+	// 变更后数据(JSON)
+	After string `json:"after,omitempty"`
+	// 变更前数据(JSON)
+	Before string `json:"before,omitempty"`
+	// 创建时间
+	CreatedAt string `json:"created_at,omitempty"`
+	// ID
+	ID int64 `json:"id,omitempty"`
+	// 所属模块
+	Module string `json:"module,omitempty"`
+	// 资源类型
+	Resource string `json:"resource,omitempty"`
+	// 资源ID
+	ResourceID int64 `json:"resource_id,omitempty"`
+	// 链路ID
+	TraceID string `json:"trace_id,omitempty"`
+	// 操作用户
+	Username string `json:"username,omitempty"`
+}
+
+// SystemBackupOut 对应system.BackupOut
+type SystemBackupOut struct {
+	// 创建时间
+	CreatedAt string `json:"created_at,omitempty"`
+	// 失败原因
+	Error string `json:"error,omitempty"`
+	// 文件数量
+	FileCount int64 `json:"file_count,omitempty"`
+	// 结束时间
+	FinishedAt string `json:"finished_at,omitempty"`
+	// ID
+	ID int64 `json:"id,omitempty"`
+	// 备份文件名
+	Name string `json:"name,omitempty"`
+	// 记录数量
+	RowCount int64 `json:"row_count,omitempty"`
+	// 数据库结构版本
+	SchemaVersion string `json:"schema_version,omitempty"`
+	// 文件大小(字节)
+	Size int64 `json:"size,omitempty"`
+	// 状态(running-备份中,succeeded-成功,failed-失败)
+	Status string `json:"status,omitempty"`
+	// 存储类型
+	StorageType string `json:"storage_type,omitempty"`
+	// 表数量
+	TableCount int64 `json:"table_count,omitempty"`
+	// 触发方式(manual-手动,scheduled-定时)
+	Trigger string `json:"trigger,omitempty"`
+	// 操作用户名, 定时备份为空
+	Username string `json:"username,omitempty"`
+}
+
+// SystemBackupReply 对应system.BackupReply
+type SystemBackupReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *SystemBackupOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// SystemCertMonitorOut 对应system.CertMonitorOut
+type SystemCertMonitorOut struct {
+	// TLS服务地址
+	Address string `json:"address,omitempty"`
+	// 根证书文件路径
+	CaFile string `json:"ca_file,omitempty"`
+	// 证书链校验失败原因
+	ChainError string `json:"chain_error,omitempty"`
+	// 创建时间
+	CreatedAt string `json:"created_at,omitempty"`
+	// 距离过期的天数, 已过期时为负数, 未读取到证书时为空
+	DaysRemaining int64 `json:"days_remaining,omitempty"`
+	// 证书文件路径
+	FilePath string `json:"file_path,omitempty"`
+	// ID
+	ID int64 `json:"id,omitempty"`
+	// 是否启用
+	IsEnabled bool `json:"is_enabled,omitempty"`
+	// 证书签发者
+	Issuer string `json:"issuer,omitempty"`
+	// 证书来源(endpoint/file)
+	Kind string `json:"kind,omitempty"`
+	// 最近一次检查时间
+	LastCheckedAt string `json:"last_checked_at,omitempty"`
+	// 最近一次读取证书失败原因
+	LastError string `json:"last_error,omitempty"`
+	// 名称
+	Name string `json:"name,omitempty"`
+	// 过期时间
+	NotAfter string `json:"not_after,omitempty"`
+	// 生效时间
+	NotBefore string `json:"not_before,omitempty"`
+	// 备注
+	Remark string `json:"remark,omitempty"`
+	// 校验证书的域名
+	ServerName string `json:"server_name,omitempty"`
+	// 检查状态(unknown/ok/expiring/expired/invalid/error)
+	Status string `json:"status,omitempty"`
+	// 证书主题
+	Subject string `json:"subject,omitempty"`
+	// 更新时间
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+// SystemCertMonitorReply 对应system.CertMonitorReply
+type SystemCertMonitorReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *SystemCertMonitorOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// SystemCertMonitorRequest 对应system.CertMonitorRequest
+type SystemCertMonitorRequest struct {
+	// TLS服务地址(host:port), 证书来源为endpoint时必填
+	Address string `json:"address,omitempty"`
+	// 根证书文件路径, 相对路径基于配置目录, 为空时使用系统根证书
+	CaFile string `json:"ca_file,omitempty"`
+	// 证书文件路径, 相对路径基于配置目录, 证书来源为file时必填
+	FilePath string `json:"file_path,omitempty"`
+	// 是否启用
+	IsEnabled bool `json:"is_enabled,omitempty"`
+	// 证书来源(endpoint/file)
+	Kind string `json:"kind"`
+	// 名称
+	Name string `json:"name"`
+	// 备注
+	Remark string `json:"remark,omitempty"`
+	// 校验证书的域名, 为空时使用服务地址中的主机名
+	ServerName string `json:"server_name,omitempty"`
+}
+
+// SystemChangeFreezeOut 对应system.ChangeFreezeOut
+type SystemChangeFreezeOut struct {
+	// 创建时间
+	CreatedAt string `json:"created_at,omitempty"`
+	// 结束时间
+	EndAt string `json:"end_at,omitempty"`
+	// ID
+	ID int64 `json:"id,omitempty"`
+	// 当前是否生效
+	IsActive bool `json:"is_active,omitempty"`
+	// 是否启用
+	IsEnabled bool `json:"is_enabled,omitempty"`
+	// 冻结的模块
+	Module string `json:"module,omitempty"`
+	// 名称
+	Name string `json:"name,omitempty"`
+	// 冻结原因
+	Reason string `json:"reason,omitempty"`
+	// 作用范围
+	Scope string `json:"scope,omitempty"`
+	// 开始时间
+	StartAt string `json:"start_at,omitempty"`
+	// 更新时间
+	UpdatedAt string `json:"updated_at,omitempty"`
+	// 用户名
+	Username string `json:"username,omitempty"`
+}
+
+// SystemChangeFreezeReply 对应system.ChangeFreezeReply
+type SystemChangeFreezeReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *SystemChangeFreezeOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// SystemChangeFreezeRequest 对应system.ChangeFreezeRequest
+type SystemChangeFreezeRequest struct {
+	// 结束时间
+	EndAt string `json:"end_at"`
+	// 是否启用
+	IsEnabled bool `json:"is_enabled,omitempty"`
+	// 冻结的模块, 模块范围时必填
+	Module string `json:"module,omitempty"`
+	// 名称
+	Name string `json:"name"`
+	// 冻结原因
+	Reason string `json:"reason,omitempty"`
+	// 作用范围(global/module)
+	Scope string `json:"scope"`
+	// 开始时间
+	StartAt string `json:"start_at"`
+}
+
+// SystemDeployInfoOut 对应system.DeployInfoOut
+type SystemDeployInfoOut struct {
+	// 功能启用状态, 前端据此隐藏不可用的功能入口
+	Features map[string]bool `json:"features,omitempty"`
+	// 部署模式(standard/embedded)
+	Mode string `json:"mode,omitempty"`
+}
+
+// SystemDeployInfoReply 对应system.DeployInfoReply
+type SystemDeployInfoReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *SystemDeployInfoOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// SystemExportReportRequest 对应system.ExportReportRequest
+type SystemExportReportRequest struct {
+	// 统计日期, 日报为当天, 周报为所在的周, 为空时日报统计昨天, 周报统计上周
+	Date string `json:"date,omitempty"`
+	// 导出格式(csv, xlsx)
+	Format string `json:"format"`
+	// 报表周期(daily:日报, weekly:周报)
+	Period string `json:"period"`
+}
+
+// SystemFeatureFlagOut 对应system.FeatureFlagOut
+type SystemFeatureFlagOut struct {
+	// 创建时间
+	CreatedAt string `json:"created_at,omitempty"`
+	// 说明
+	Description string `json:"description,omitempty"`
+	// ID
+	ID int64 `json:"id,omitempty"`
+	// 是否启用
+	IsEnabled bool `json:"is_enabled,omitempty"`
+	// 开关名称
+	Name string `json:"name,omitempty"`
+	// 按用户开放的百分比
+	Percentage int64 `json:"percentage,omitempty"`
+	// 开放的角色ID
+	RoleIDs []int64 `json:"role_ids,omitempty"`
+	// 更新时间
+	UpdatedAt string `json:"updated_at,omitempty"`
+	// 用户名
+	Username string `json:"username,omitempty"`
+}
+
+// SystemFeatureFlagReply 对应system.FeatureFlagReply
+type SystemFeatureFlagReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *SystemFeatureFlagOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// SystemFeatureFlagRequest 对应system.FeatureFlagRequest
+type SystemFeatureFlagRequest struct {
+	// 说明
+	Description string `json:"description,omitempty"`
+	// 是否启用, 关闭时对所有用户关闭
+	IsEnabled bool `json:"is_enabled,omitempty"`
+	// 开关名称, 如oes.workflow.dag
+	Name string `json:"name"`
+	// 按用户开放的百分比(0~100)
+	Percentage int64 `json:"percentage,omitempty"`
+	// 开放的角色ID
+	RoleIDs []int64 `json:"role_ids,omitempty"`
+}
+
+// SystemGatewayQuery 对应system.GatewayQuery
+type SystemGatewayQuery struct {
+	// 接口路径, 如/api/v1/oes/colony
+	Path string `json:"path"`
+	// 查询参数
+	Query map[string]string `json:"query,omitempty"`
+}
+
+// SystemGatewayReply 对应system.GatewayReply
+type SystemGatewayReply map[string]any
+
+// SystemGatewayRequest 对应system.GatewayRequest
+type SystemGatewayRequest struct {
+	// 子查询, 键为结果中的名称
+	Queries map[string]*SystemGatewayQuery `json:"queries"`
+}
+
+// SystemListChangeFreezeReply 对应system.ListChangeFreezeReply
+type SystemListChangeFreezeReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data []*SystemChangeFreezeOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// SystemListLogEntryReply 对应system.ListLogEntryReply
+type SystemListLogEntryReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data []*SystemLogEntryOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// SystemListLogLevelReply 对应system.ListLogLevelReply
+type SystemListLogLevelReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data []*SystemLogLevelOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// SystemListMaintenanceWindowReply 对应system.ListMaintenanceWindowReply
+type SystemListMaintenanceWindowReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data []*SystemMaintenanceWindowOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// SystemListReportFileReply 对应system.ListReportFileReply
+type SystemListReportFileReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data []*SystemReportFileOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// SystemLogEntryOut 对应system.LogEntryOut
+type SystemLogEntryOut struct {
+	// 调用位置
+	Caller string `json:"caller,omitempty"`
+	// 其他字段
+	Fields map[string]any `json:"fields,omitempty"`
+	// 日志级别
+	Level string `json:"level,omitempty"`
+	// 日志内容
+	Msg string `json:"msg,omitempty"`
+	// 日志源
+	Source string `json:"source,omitempty"`
+	// 时间
+	Time string `json:"time,omitempty"`
+	// 链路ID
+	TraceID string `json:"trace_id,omitempty"`
+}
+
+// SystemLogLevelOut 对应system.LogLevelOut
+type SystemLogLevelOut struct {
+	// 启动时的日志级别
+	DefaultLevel string `json:"default_level,omitempty"`
+	// 当前日志级别
+	Level string `json:"level,omitempty"`
+	// 日志记录器
+	Logger string `json:"logger,omitempty"`
+}
+
+// SystemMaintenanceWindowOut 对应system.MaintenanceWindowOut
+type SystemMaintenanceWindowOut struct {
+	// 创建时间
+	CreatedAt string `json:"created_at,omitempty"`
+	// 说明
+	Description string `json:"description,omitempty"`
+	// 结束时间
+	EndAt string `json:"end_at,omitempty"`
+	// ID
+	ID int64 `json:"id,omitempty"`
+	// 当前是否生效
+	IsActive bool `json:"is_active,omitempty"`
+	// 是否启用
+	IsEnabled bool `json:"is_enabled,omitempty"`
+	// 所属模块
+	Module string `json:"module,omitempty"`
+	// 名称
+	Name string `json:"name,omitempty"`
+	// 重复周期
+	Recurrence string `json:"recurrence,omitempty"`
+	// 作用范围
+	Scope string `json:"scope,omitempty"`
+	// 开始时间
+	StartAt string `json:"start_at,omitempty"`
+	// 集群或节点ID
+	TargetID int64 `json:"target_id,omitempty"`
+	// 更新时间
+	UpdatedAt string `json:"updated_at,omitempty"`
+	// 用户名
+	Username string `json:"username,omitempty"`
+}
+
+// SystemMaintenanceWindowReply 对应system.MaintenanceWindowReply
+type SystemMaintenanceWindowReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *SystemMaintenanceWindowOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// SystemMaintenanceWindowRequest 对应system.MaintenanceWindowRequest
+type SystemMaintenanceWindowRequest struct {
+	// 说明
+	Description string `json:"description,omitempty"`
+	// 结束时间
+	EndAt string `json:"end_at"`
+	// 是否启用
+	IsEnabled bool `json:"is_enabled,omitempty"`
+	// 所属模块, 集群范围为oes/mds, 节点范围为mon
+	Module string `json:"module,omitempty"`
+	// 名称
+	Name string `json:"name"`
+	// 重复周期(none/daily/weekly)
+	Recurrence string `json:"recurrence,omitempty"`
+	// 作用范围(global/colony/node)
+	Scope string `json:"scope"`
+	// 开始时间
+	StartAt string `json:"start_at"`
+	// 集群或节点ID
+	TargetID int64 `json:"target_id,omitempty"`
+}
+
+// SystemMigrationOut 对应system.MigrationOut
+type SystemMigrationOut struct {
+	// 是否已执行
+	Applied bool `json:"applied,omitempty"`
+	// 执行时间
+	AppliedAt string `json:"applied_at,omitempty"`
+	// 说明
+	Description string `json:"description,omitempty"`
+	// 迁移版本号
+	ID string `json:"id,omitempty"`
+	// 是否为程序未知的迁移
+	Unknown bool `json:"unknown,omitempty"`
+}
+
+// SystemMyFeatureFlagReply 对应system.MyFeatureFlagReply
+type SystemMyFeatureFlagReply map[string]any
+
+// SystemPagAuditRecordReply 对应system.PagAuditRecordReply
+type SystemPagAuditRecordReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CommonPagSystemAuditRecordOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// SystemPagBackupReply 对应system.PagBackupReply
+type SystemPagBackupReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CommonPagSystemBackupOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// SystemPagCertMonitorReply 对应system.PagCertMonitorReply
+type SystemPagCertMonitorReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CommonPagSystemCertMonitorOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// SystemPagChangeFreezeReply 对应system.PagChangeFreezeReply
+type SystemPagChangeFreezeReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CommonPagSystemChangeFreezeOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// SystemPagFeatureFlagReply 对应system.PagFeatureFlagReply
+type SystemPagFeatureFlagReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CommonPagSystemFeatureFlagOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// SystemPagMaintenanceWindowReply 对应system.PagMaintenanceWindowReply
+type SystemPagMaintenanceWindowReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CommonPagSystemMaintenanceWindowOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// SystemPagSlowQueryReply 对应system.PagSlowQueryReply
+type SystemPagSlowQueryReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CommonPagSystemSlowQueryOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// SystemPagTaskReply 对应system.PagTaskReply
+type SystemPagTaskReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CommonPagSystemTaskOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// SystemPagWebhookDeliveryReply 对应system.PagWebhookDeliveryReply
+type SystemPagWebhookDeliveryReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CommonPagSystemWebhookDeliveryOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// SystemPagWebhookSubscriptionReply 对应system.PagWebhookSubscriptionReply
+type SystemPagWebhookSubscriptionReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *CommonPagSystemWebhookSubscriptionOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// SystemReportAlertOut 对应system.ReportAlertOut
+type SystemReportAlertOut struct {
+	// 发出次数
+	Fired int64 `json:"fired,omitempty"`
+	// 告警类型
+	Kind string `json:"kind,omitempty"`
+	// 被维护窗口屏蔽的次数
+	Muted int64 `json:"muted,omitempty"`
+}
+
+// SystemReportFileOut 对应system.ReportFileOut
+type SystemReportFileOut struct {
+	// 修改时间
+	ModTime string `json:"mod_time,omitempty"`
+	// 文件名
+	Name string `json:"name,omitempty"`
+	// 文件大小(字节)
+	Size int64 `json:"size,omitempty"`
+}
+
+// SystemReportJobOut 对应system.ReportJobOut
+type SystemReportJobOut struct {
+	// 集群号
+	ColonyNum string `json:"colony_num,omitempty"`
+	// 失败数, 包括失败、超时、崩溃和中断
+	Failed int64 `json:"failed,omitempty"`
+	// 项目(oes/mds)
+	Project string `json:"project,omitempty"`
+	// 成功数
+	Success int64 `json:"success,omitempty"`
+	// 成功率, 按已结束的执行计算, 没有已结束的执行时为0
+	SuccessRate float64 `json:"success_rate,omitempty"`
+	// 执行总数
+	Total int64 `json:"total,omitempty"`
+}
+
+// SystemReportLoginOut 对应system.ReportLoginOut
+type SystemReportLoginOut struct {
+	// 登录失败次数
+	Failure int64 `json:"failure,omitempty"`
+	// 登录成功次数
+	Success int64 `json:"success,omitempty"`
+	// 登录总次数
+	Total int64 `json:"total,omitempty"`
+	// 登录成功的用户数
+	Users int64 `json:"users,omitempty"`
+}
+
+// SystemReportOut 对应system.ReportOut
+type SystemReportOut struct {
+	// 各类型的告警统计
+	Alerts []*SystemReportAlertOut `json:"alerts,omitempty"`
+	// 统计结束时间(不包含)
+	End string `json:"end,omitempty"`
+	// 生成时间
+	GeneratedAt string `json:"generated_at,omitempty"`
+	// 各集群的任务执行统计
+	Jobs []*SystemReportJobOut `json:"jobs,omitempty"`
+	// 登录统计
+	Login *SystemReportLoginOut `json:"login,omitempty"`
+	// 报表周期
+	Period string `json:"period,omitempty"`
+	// 统计开始时间(包含)
+	Start string `json:"start,omitempty"`
+}
+
+// SystemReportReply 对应system.ReportReply
+type SystemReportReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *SystemReportOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// SystemSchemaStatusOut 对应system.SchemaStatusOut
+type SystemSchemaStatusOut struct {
+	// 数据库当前版本
+	Current string `json:"current,omitempty"`
+	// 程序要求的数据库版本
+	Latest string `json:"latest,omitempty"`
+	// 迁移列表
+	Migrations []*SystemMigrationOut `json:"migrations,omitempty"`
+	// 未执行的迁移数量
+	Pending int64 `json:"pending,omitempty"`
+	// 数据库结构版本是否与程序一致
+	UpToDate bool `json:"up_to_date,omitempty"`
+}
+
+// SystemSchemaStatusReply 对应system.SchemaStatusReply
+type SystemSchemaStatusReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *SystemSchemaStatusOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// SystemSearchGroupOut 对应system.SearchGroupOut
+type SystemSearchGroupOut struct {
+	// 搜索结果
+	Hits []*SystemSearchHitOut `json:"hits,omitempty"`
+	// 资源类型, 如user、script
+	Kind string `json:"kind,omitempty"`
+	// 资源名称
+	Label string `json:"label,omitempty"`
+	// 匹配的总数
+	Total int64 `json:"total,omitempty"`
+}
+
+// SystemSearchHitOut 对应system.SearchHitOut
+type SystemSearchHitOut struct {
+	// 描述
+	Description string `json:"description,omitempty"`
+	// 高亮的描述, 已转义HTML, 匹配部分用<em>标记
+	DescriptionHighlight string `json:"description_highlight,omitempty"`
+	// 记录ID
+	ID int64 `json:"id,omitempty"`
+	// 标题
+	Title string `json:"title,omitempty"`
+	// 高亮的标题, 已转义HTML, 匹配部分用<em>标记
+	TitleHighlight string `json:"title_highlight,omitempty"`
+}
+
+// SystemSearchReply 对应system.SearchReply
+type SystemSearchReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data []*SystemSearchGroupOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// SystemSetLogLevelRequest 对应system.SetLogLevelRequest
+type SystemSetLogLevelRequest struct {
+	// 日志级别
+	Level string `json:"level"`
+	// 日志记录器(server/service/biz/data/cron), 为空时调整全部日志记录器
+	Logger string `json:"logger,omitempty"`
+}
+
+// SystemSlowQueryOut 对应system.SlowQueryOut
+type SystemSlowQueryOut struct {
+	// 平均耗时(毫秒)
+	AvgMs int64 `json:"avg_ms,omitempty"`
+	// 次数
+	Count int64 `json:"count,omitempty"`
+	// 语句指纹
+	Fingerprint string `json:"fingerprint,omitempty"`
+	// 首次出现时间
+	FirstSeenAt string `json:"first_seen_at,omitempty"`
+	// ID
+	ID int64 `json:"id,omitempty"`
+	// 最近出现时间
+	LastSeenAt string `json:"last_seen_at,omitempty"`
+	// 最近一次的链路ID
+	LastTraceID string `json:"last_trace_id,omitempty"`
+	// 最大耗时(毫秒)
+	MaxMs int64 `json:"max_ms,omitempty"`
+	// 归一化后的语句
+	Statement string `json:"statement,omitempty"`
+	// 表名
+	TableName string `json:"table_name,omitempty"`
+}
+
+// SystemStatsAlertOut 对应system.StatsAlertOut
+type SystemStatsAlertOut struct {
+	// 告警内容, 不同类型的告警字段不同
+	Data map[string]any `json:"data,omitempty"`
+	// 事件ID
+	EventID string `json:"event_id,omitempty"`
+	// 告警类型
+	Kind string `json:"kind,omitempty"`
+	// 发生时间
+	OccurredAt string `json:"occurred_at,omitempty"`
+}
+
+// SystemStatsAlertReply 对应system.StatsAlertReply
+type SystemStatsAlertReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data []*SystemStatsAlertOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// SystemStatsColonyOut 对应system.StatsColonyOut
+type SystemStatsColonyOut struct {
+	// 停用的集群数
+	Disabled int64 `json:"disabled,omitempty"`
+	// 启用的集群数
+	Enabled int64 `json:"enabled,omitempty"`
+	// 项目(oes, mds)
+	Project string `json:"project,omitempty"`
+}
+
+// SystemStatsColonyReply 对应system.StatsColonyReply
+type SystemStatsColonyReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data []*SystemStatsColonyOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// SystemStatsJobDayOut 对应system.StatsJobDayOut
+type SystemStatsJobDayOut struct {
+	// 日期
+	Date string `json:"date,omitempty"`
+	// 失败数, 包括失败、超时、崩溃和中断
+	Failure int64 `json:"failure,omitempty"`
+	// 待执行和执行中的数量
+	Running int64 `json:"running,omitempty"`
+	// 成功数
+	Success int64 `json:"success,omitempty"`
+}
+
+// SystemStatsJobReply 对应system.StatsJobReply
+type SystemStatsJobReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data []*SystemStatsJobDayOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// SystemStatsUserOut 对应system.StatsUserOut
+type SystemStatsUserOut struct {
+	// 已激活的用户数
+	Active int64 `json:"active,omitempty"`
+	// 统计天数
+	Days int64 `json:"days,omitempty"`
+	// 统计期间内登录成功的用户数
+	Login int64 `json:"login,omitempty"`
+	// 用户总数
+	Total int64 `json:"total,omitempty"`
+}
+
+// SystemStatsUserReply 对应system.StatsUserReply
+type SystemStatsUserReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *SystemStatsUserOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// SystemTaskOut 对应system.TaskOut
+type SystemTaskOut struct {
+	// 创建时间
+	CreatedAt string `json:"created_at,omitempty"`
+	// 错误信息
+	Error map[string]any `json:"error,omitempty"`
+	// 结束时间
+	FinishedAt string `json:"finished_at,omitempty"`
+	// ID
+	ID int64 `json:"id,omitempty"`
+	// 任务类型
+	Kind string `json:"kind,omitempty"`
+	// 当前步骤
+	Message string `json:"message,omitempty"`
+	// 任务名称
+	Name string `json:"name,omitempty"`
+	// 进度百分比
+	Progress int64 `json:"progress,omitempty"`
+	// 执行结果
+	Result map[string]any `json:"result,omitempty"`
+	// 开始执行时间
+	StartedAt string `json:"started_at,omitempty"`
+	// 状态(pending-等待执行,running-执行中,succeeded-成功,failed-失败,canceled-已取消)
+	Status string `json:"status,omitempty"`
+	// 提交用户名
+	Username string `json:"username,omitempty"`
+}
+
+// SystemTaskReply 对应system.TaskReply
+type SystemTaskReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *SystemTaskOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// SystemWebhookDeliveryOut 对应system.WebhookDeliveryOut
+type SystemWebhookDeliveryOut struct {
+	// 已推送次数
+	Attempts int64 `json:"attempts,omitempty"`
+	// 创建时间
+	CreatedAt string `json:"created_at,omitempty"`
+	// 推送成功时间
+	DeliveredAt string `json:"delivered_at,omitempty"`
+	// 最近一次错误信息
+	ErrorMessage string `json:"error_message,omitempty"`
+	// 事件ID
+	EventID string `json:"event_id,omitempty"`
+	// 事件类型
+	EventType string `json:"event_type,omitempty"`
+	// ID
+	ID int64 `json:"id,omitempty"`
+	// 下次推送时间
+	NextRetryAt string `json:"next_retry_at,omitempty"`
+	// 推送内容
+	Payload string `json:"payload,omitempty"`
+	// 最近一次响应状态码
+	ResponseCode int64 `json:"response_code,omitempty"`
+	// 推送状态(0-待推送,1-成功,2-失败)
+	Status int64 `json:"status,omitempty"`
+	// 订阅ID
+	SubscriptionID int64 `json:"subscription_id,omitempty"`
+}
+
+// SystemWebhookDeliveryReply 对应system.WebhookDeliveryReply
+type SystemWebhookDeliveryReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *SystemWebhookDeliveryOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// SystemWebhookSubscriptionOut 对应system.WebhookSubscriptionOut
+type SystemWebhookSubscriptionOut struct {
+	// 创建时间
+	CreatedAt string `json:"created_at,omitempty"`
+	// 说明
+	Description string `json:"description,omitempty"`
+	// 订阅的事件类型
+	Events []string `json:"events,omitempty"`
+	// ID
+	ID int64 `json:"id,omitempty"`
+	// 是否启用
+	IsEnabled bool `json:"is_enabled,omitempty"`
+	// 名称
+	Name string `json:"name,omitempty"`
+	// 更新时间
+	UpdatedAt string `json:"updated_at,omitempty"`
+	// 推送地址
+	URL string `json:"url,omitempty"`
+	// 用户名
+	Username string `json:"username,omitempty"`
+}
+
+// SystemWebhookSubscriptionReply 对应system.WebhookSubscriptionReply
+type SystemWebhookSubscriptionReply struct {
+	// 状态码
+	// Example: 200
+	Code int64 `json:"code,omitempty"`
+	// 数据
+	// 可以是任意类型的数据
+	Data *SystemWebhookSubscriptionOut `json:"data,omitempty"`
+	// 信息
+	// Example: "success"
+	Msg string `json:"msg,omitempty"`
+}
+
+// SystemWebhookSubscriptionRequest 对应system.WebhookSubscriptionRequest
+type SystemWebhookSubscriptionRequest struct {
+	// 说明
+	Description string `json:"description,omitempty"`
+	// 订阅的事件类型, *表示全部
+	Events []string `json:"events"`
+	// 是否启用
+	IsEnabled bool `json:"is_enabled,omitempty"`
+	// 名称
+	Name string `json:"name"`
+	// 签名密钥, 更新时为空表示不修改
+	Secret string `json:"secret,omitempty"`
+	// 推送地址
+	URL string `json:"url"`
+}
+
+// ListAdminBackupParams ListAdminBackup的查询参数
+type ListAdminBackupParams struct {
+	// 创建时间之后的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	AfterCreatedAt *string
+	// 更新时间之后的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	AfterUpdatedAt *string
+	// 创建时间之前的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	BeforeCreatedAt *string
+	// 更新时间之前的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	BeforeUpdatedAt *string
+	// 导出的列(多个用,隔开), 默认导出全部列
+	Columns *string
+	// 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据
+	Export *string
+	// 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+	// 运算符: eq ne gt gte lt lte contains in between is_null not_null
+	// example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+	Filter *string
+	// 唯一标识
+	ID *int64
+	// "唯一标识列表(多个用,隔开)"
+	IDs *string
+	// 分页页码
+	Page *int64
+	// 分页大小
+	Size *int64
+	// 状态
+	Status *string
+	// 触发方式
+	Trigger *string
+	// 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表
+	WithTotal *bool
+}
+
+// ListAdminBackup 查询备份列表
+//
+// 本接口用于查询备份列表, 按备份时间倒序
+//
+// GET /api/v1/admin/backup
+func (c *Client) ListAdminBackup(ctx context.Context, params *ListAdminBackupParams) (*SystemPagBackupReply, error) {
+	req := &request{method: "GET", path: "/api/v1/admin/backup"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "after_created_at", params.AfterCreatedAt)
+		addPtr(req.query, "after_updated_at", params.AfterUpdatedAt)
+		addPtr(req.query, "before_created_at", params.BeforeCreatedAt)
+		addPtr(req.query, "before_updated_at", params.BeforeUpdatedAt)
+		addPtr(req.query, "columns", params.Columns)
+		addPtr(req.query, "export", params.Export)
+		addPtr(req.query, "filter", params.Filter)
+		addPtr(req.query, "id", params.ID)
+		addPtr(req.query, "ids", params.IDs)
+		addPtr(req.query, "page", params.Page)
+		addPtr(req.query, "size", params.Size)
+		addPtr(req.query, "status", params.Status)
+		addPtr(req.query, "trigger", params.Trigger)
+		addPtr(req.query, "with_total", params.WithTotal)
+	}
+	out := new(SystemPagBackupReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateAdminBackup 立即备份
+//
+// 本接口用于提交备份数据的后台任务, 备份数据库全部数据和上传文件, 通过/api/v1/tasks/{id}查询进度, 任务成功后结果中的backup_id为备份ID
+//
+// POST /api/v1/admin/backup
+func (c *Client) CreateAdminBackup(ctx context.Context) (*SystemTaskReply, error) {
+	req := &request{method: "POST", path: "/api/v1/admin/backup"}
+	out := new(SystemTaskReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetAdminBackup 查询备份详情
+//
+// 本接口用于查询指定ID的备份
+//
+// GET /api/v1/admin/backup/{id}
+func (c *Client) GetAdminBackup(ctx context.Context, id int32) (*SystemBackupReply, error) {
+	req := &request{method: "GET", path: "/api/v1/admin/backup/" + pathValue(id)}
+	out := new(SystemBackupReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteAdminBackup 删除备份
+//
+// 本接口用于删除指定ID的备份文件和备份记录, 不能删除正在执行的备份
+//
+// DELETE /api/v1/admin/backup/{id}
+func (c *Client) DeleteAdminBackup(ctx context.Context, id int32) (*CommonMapAPIReply, error) {
+	req := &request{method: "DELETE", path: "/api/v1/admin/backup/" + pathValue(id)}
+	out := new(CommonMapAPIReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetAdminBackupDownload 下载备份文件
+//
+// 本接口用于下载指定ID的备份文件, 恢复方法见程序的 -restore 参数
+//
+// 返回原始响应体, 调用方读取完毕后需要关闭
+//
+// GET /api/v1/admin/backup/{id}/download
+func (c *Client) GetAdminBackupDownload(ctx context.Context, id int32) (io.ReadCloser, error) {
+	req := &request{method: "GET", path: "/api/v1/admin/backup/" + pathValue(id) + "/download"}
+	return c.doStream(ctx, req)
+}
+
+// ListAdminDbSlowQueriesParams ListAdminDbSlowQueries的查询参数
+type ListAdminDbSlowQueriesParams struct {
+	// 导出的列(多个用,隔开), 默认导出全部列
+	Columns *string
+	// 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据
+	Export *string
+	// 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+	// 运算符: eq ne gt gte lt lte contains in between is_null not_null
+	// example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+	Filter *string
+	// 唯一标识
+	ID *int64
+	// "唯一标识列表(多个用,隔开)"
+	IDs *string
+	// 最小的最大耗时(毫秒)
+	MinMs *int64
+	// 排序方式(max:最大耗时, count:次数, avg:平均耗时, last:最近出现)
+	OrderBy *string
+	// 分页页码
+	Page *int64
+	// 分页大小
+	Size *int64
+	// 表名
+	TableName *string
+	// 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表
+	WithTotal *bool
+}
+
+// ListAdminDbSlowQueries 查询慢查询统计
+//
+// 本接口用于分页查询按语句指纹汇总的慢查询, 统计数据每分钟写入一次
+//
+// GET /api/v1/admin/db/slow-queries
+func (c *Client) ListAdminDbSlowQueries(ctx context.Context, params *ListAdminDbSlowQueriesParams) (*SystemPagSlowQueryReply, error) {
+	req := &request{method: "GET", path: "/api/v1/admin/db/slow-queries"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "columns", params.Columns)
+		addPtr(req.query, "export", params.Export)
+		addPtr(req.query, "filter", params.Filter)
+		addPtr(req.query, "id", params.ID)
+		addPtr(req.query, "ids", params.IDs)
+		addPtr(req.query, "min_ms", params.MinMs)
+		addPtr(req.query, "order_by", params.OrderBy)
+		addPtr(req.query, "page", params.Page)
+		addPtr(req.query, "size", params.Size)
+		addPtr(req.query, "table_name", params.TableName)
+		addPtr(req.query, "with_total", params.WithTotal)
+	}
+	out := new(SystemPagSlowQueryReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListAdminFeatureFlagParams ListAdminFeatureFlag的查询参数
+type ListAdminFeatureFlagParams struct {
+	// 导出的列(多个用,隔开), 默认导出全部列
+	Columns *string
+	// 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据
+	Export *string
+	// 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+	// 运算符: eq ne gt gte lt lte contains in between is_null not_null
+	// example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+	Filter *string
+	// 唯一标识
+	ID *int64
+	// "唯一标识列表(多个用,隔开)"
+	IDs *string
+	// 是否启用
+	IsEnabled *bool
+	// 开关名称
+	Name *string
+	// 分页页码
+	Page *int64
+	// 分页大小
+	Size *int64
+	// 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表
+	WithTotal *bool
+}
+
+// ListAdminFeatureFlag 查询功能开关列表
+//
+// 本接口用于分页查询功能开关
+//
+// GET /api/v1/admin/feature-flag
+func (c *Client) ListAdminFeatureFlag(ctx context.Context, params *ListAdminFeatureFlagParams) (*SystemPagFeatureFlagReply, error) {
+	req := &request{method: "GET", path: "/api/v1/admin/feature-flag"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "columns", params.Columns)
+		addPtr(req.query, "export", params.Export)
+		addPtr(req.query, "filter", params.Filter)
+		addPtr(req.query, "id", params.ID)
+		addPtr(req.query, "ids", params.IDs)
+		addPtr(req.query, "is_enabled", params.IsEnabled)
+		addPtr(req.query, "name", params.Name)
+		addPtr(req.query, "page", params.Page)
+		addPtr(req.query, "size", params.Size)
+		addPtr(req.query, "with_total", params.WithTotal)
+	}
+	out := new(SystemPagFeatureFlagReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateAdminFeatureFlag 创建功能开关
+//
+// 本接口用于创建功能开关, 开关名称不存在时功能对所有用户关闭
+//
+// POST /api/v1/admin/feature-flag
+func (c *Client) CreateAdminFeatureFlag(ctx context.Context, body *SystemFeatureFlagRequest) (*SystemFeatureFlagReply, error) {
+	req := &request{method: "POST", path: "/api/v1/admin/feature-flag"}
+	req.body = body
+	out := new(SystemFeatureFlagReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetAdminFeatureFlag 查询功能开关详情
+//
+// 本接口用于查询指定ID的功能开关
+//
+// GET /api/v1/admin/feature-flag/{id}
+func (c *Client) GetAdminFeatureFlag(ctx context.Context, id int64) (*SystemFeatureFlagReply, error) {
+	req := &request{method: "GET", path: "/api/v1/admin/feature-flag/" + pathValue(id)}
+	out := new(SystemFeatureFlagReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateAdminFeatureFlag 更新功能开关
+//
+// 本接口用于更新指定ID的功能开关, 修改后本实例立即生效, 其他实例在1分钟内生效
+//
+// PUT /api/v1/admin/feature-flag/{id}
+func (c *Client) UpdateAdminFeatureFlag(ctx context.Context, id int64, body *SystemFeatureFlagRequest) (*SystemFeatureFlagReply, error) {
+	req := &request{method: "PUT", path: "/api/v1/admin/feature-flag/" + pathValue(id)}
+	req.body = body
+	out := new(SystemFeatureFlagReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteAdminFeatureFlag 删除功能开关
+//
+// 本接口用于删除指定ID的功能开关, 删除后功能对所有用户关闭
+//
+// DELETE /api/v1/admin/feature-flag/{id}
+func (c *Client) DeleteAdminFeatureFlag(ctx context.Context, id int64) (*CommonMapAPIReply, error) {
+	req := &request{method: "DELETE", path: "/api/v1/admin/feature-flag/" + pathValue(id)}
+	out := new(CommonMapAPIReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListAdminLogLevel 查询日志级别
+//
+// 本接口用于查询各日志记录器的当前级别和启动时的级别, 仅限工作人员访问
+//
+// GET /api/v1/admin/log-level
+func (c *Client) ListAdminLogLevel(ctx context.Context) (*SystemListLogLevelReply, error) {
+	req := &request{method: "GET", path: "/api/v1/admin/log-level"}
+	out := new(SystemListLogLevelReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateAdminLogLevel 调整日志级别
+//
+// 本接口用于在运行时调整日志级别, 立即生效, 只对当前实例生效且重启后恢复配置文件中的级别, 仅限工作人员访问
+//
+// PUT /api/v1/admin/log-level
+func (c *Client) UpdateAdminLogLevel(ctx context.Context, body *SystemSetLogLevelRequest) (*SystemListLogLevelReply, error) {
+	req := &request{method: "PUT", path: "/api/v1/admin/log-level"}
+	req.body = body
+	out := new(SystemListLogLevelReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteAdminLogLevelParams DeleteAdminLogLevel的查询参数
+type DeleteAdminLogLevelParams struct {
+	// 日志记录器(server/service/biz/data/cron), 为空时恢复全部日志记录器
+	Logger *string
+}
+
+// DeleteAdminLogLevel 恢复日志级别
+//
+// 本接口用于将日志级别恢复为启动时的级别, 仅限工作人员访问
+//
+// DELETE /api/v1/admin/log-level
+func (c *Client) DeleteAdminLogLevel(ctx context.Context, params *DeleteAdminLogLevelParams) (*SystemListLogLevelReply, error) {
+	req := &request{method: "DELETE", path: "/api/v1/admin/log-level"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "logger", params.Logger)
+	}
+	out := new(SystemListLogLevelReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListAdminLogsParams ListAdminLogs的查询参数
+type ListAdminLogsParams struct {
+	// 结束时间
+	EndTime *string
+	// 关键字
+	Keyword *string
+	// 最低日志级别
+	Level *string
+	// 返回条数
+	Size *int64
+	// 日志源(service/biz/data/server), 为空时查询service、biz和data
+	Source *string
+	// 开始时间
+	StartTime *string
+	// 链路ID
+	TraceID *string
+}
+
+// ListAdminLogs 查询运行日志
+//
+// 本接口用于按时间范围、日志级别、链路ID和关键字检索服务运行日志, 结果按时间倒序返回
+//
+// GET /api/v1/admin/logs
+func (c *Client) ListAdminLogs(ctx context.Context, params *ListAdminLogsParams) (*SystemListLogEntryReply, error) {
+	req := &request{method: "GET", path: "/api/v1/admin/logs"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "end_time", params.EndTime)
+		addPtr(req.query, "keyword", params.Keyword)
+		addPtr(req.query, "level", params.Level)
+		addPtr(req.query, "size", params.Size)
+		addPtr(req.query, "source", params.Source)
+		addPtr(req.query, "start_time", params.StartTime)
+		addPtr(req.query, "trace_id", params.TraceID)
+	}
+	out := new(SystemListLogEntryReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetAdminMigrations 查询数据库迁移状态
+//
+// 本接口用于查询数据库结构版本以及各版本迁移的执行情况
+//
+// GET /api/v1/admin/migrations
+func (c *Client) GetAdminMigrations(ctx context.Context) (*SystemSchemaStatusReply, error) {
+	req := &request{method: "GET", path: "/api/v1/admin/migrations"}
+	out := new(SystemSchemaStatusReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateAgentHeartbeat 主机代理心跳
+//
+// 本接口供主机代理定时调用, 超过失联时间未上报心跳的主机标记为离线, 主机不存在时代理需要重新注册; 可同时上报主机基础指标供mon模块绘制图表
+//
+// POST /api/v1/agent/heartbeat
+func (c *Client) CreateAgentHeartbeat(ctx context.Context, body *ResourceHostAgentHeartbeatRequest) (*CommonMapAPIReply, error) {
+	req := &request{method: "POST", path: "/api/v1/agent/heartbeat"}
+	req.body = body
+	out := new(CommonMapAPIReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateAgentRegister 注册主机代理
+//
+// 本接口供主机代理启动时调用, 上报主机信息, 按SSH地址、端口和用户匹配已有主机, 不存在时自动创建主机
+//
+// POST /api/v1/agent/register
+func (c *Client) CreateAgentRegister(ctx context.Context, body *ResourceRegisterHostAgentRequest) (*ResourceHostAgentReply, error) {
+	req := &request{method: "POST", path: "/api/v1/agent/register"}
+	req.body = body
+	out := new(ResourceHostAgentReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetAuthOidcCallbackParams GetAuthOidcCallback的查询参数
+type GetAuthOidcCallbackParams struct {
+	// 授权码
+	Code *string
+	// 身份提供方返回的错误
+	Error *string
+	// 身份提供方返回的错误描述
+	ErrorDescription *string
+	// 发起登录时生成的state
+	State string
+}
+
+// GetAuthOidcCallback 授权回调
+//
+// 本接口用于前端将身份提供方回调地址中的参数原样转交, 校验通过后返回令牌
+//
+// GET /api/v1/auth/oidc/callback
+func (c *Client) GetAuthOidcCallback(ctx context.Context, params *GetAuthOidcCallbackParams) (*CustomerLoginReply, error) {
+	req := &request{method: "GET", path: "/api/v1/auth/oidc/callback"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "code", params.Code)
+		addPtr(req.query, "error", params.Error)
+		addPtr(req.query, "error_description", params.ErrorDescription)
+		addValue(req.query, "state", params.State)
+	}
+	out := new(CustomerLoginReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetAuthOidcLoginParams GetAuthOidcLogin的查询参数
+type GetAuthOidcLoginParams struct {
+	// 身份提供方标识
+	Provider string
+}
+
+// GetAuthOidcLogin 发起单点登录
+//
+// 本接口用于生成身份提供方的授权地址, 前端跳转到该地址完成登录
+//
+// GET /api/v1/auth/oidc/login
+func (c *Client) GetAuthOidcLogin(ctx context.Context, params *GetAuthOidcLoginParams) (*CustomerOidcLoginReply, error) {
+	req := &request{method: "GET", path: "/api/v1/auth/oidc/login"}
+	if params != nil {
+		req.query = url.Values{}
+		addValue(req.query, "provider", params.Provider)
+	}
+	out := new(CustomerOidcLoginReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetAuthOidcProviders 查询登录方式
+//
+// 本接口用于登录页查询可用的身份提供方以及是否允许本地用户名密码登录
+//
+// GET /api/v1/auth/oidc/providers
+func (c *Client) GetAuthOidcProviders(ctx context.Context) (*CustomerOidcProvidersReply, error) {
+	req := &request{method: "GET", path: "/api/v1/auth/oidc/providers"}
+	out := new(CustomerOidcProvidersReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetCaptcha 获取登录验证码
+//
+// 本接口用于登录失败次数过多时获取验证码, 登录时提交captcha_id和captcha_answer
+//
+// GET /api/v1/captcha
+func (c *Client) GetCaptcha(ctx context.Context) (*CustomerCaptchaReply, error) {
+	req := &request{method: "GET", path: "/api/v1/captcha"}
+	out := new(CustomerCaptchaReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListCustomerAPIParams ListCustomerAPI的查询参数
+type ListCustomerAPIParams struct {
+	// 创建时间之后的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	AfterCreatedAt *string
+	// 更新时间之后的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	AfterUpdatedAt *string
+	// 创建时间之前的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	BeforeCreatedAt *string
+	// 更新时间之前的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	BeforeUpdatedAt *string
+	// 导出的列(多个用,隔开), 默认导出全部列
+	Columns *string
+	// 描述信息
+	Descr *string
+	// 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据
+	Export *string
+	// 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+	// 运算符: eq ne gt gte lt lte contains in between is_null not_null
+	// example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+	Filter *string
+	// 唯一标识
+	ID *int64
+	// "唯一标识列表(多个用,隔开)"
+	IDs *string
+	// 是否失效
+	IsOrphan *bool
+	// 标签
+	Label *string
+	// 请求方法
+	Method *string
+	// 分页页码
+	Page *int64
+	// 分页大小
+	Size *int64
+	// URL地址
+	URL *string
+	// 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表
+	WithTotal *bool
+}
+
+// ListCustomerAPI 查询API列表
+//
+// 本接口用于查询API列表
+//
+// GET /api/v1/customer/api
+func (c *Client) ListCustomerAPI(ctx context.Context, params *ListCustomerAPIParams) (*CustomerPagApiReply, error) {
+	req := &request{method: "GET", path: "/api/v1/customer/api"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "after_created_at", params.AfterCreatedAt)
+		addPtr(req.query, "after_updated_at", params.AfterUpdatedAt)
+		addPtr(req.query, "before_created_at", params.BeforeCreatedAt)
+		addPtr(req.query, "before_updated_at", params.BeforeUpdatedAt)
+		addPtr(req.query, "columns", params.Columns)
+		addPtr(req.query, "descr", params.Descr)
+		addPtr(req.query, "export", params.Export)
+		addPtr(req.query, "filter", params.Filter)
+		addPtr(req.query, "id", params.ID)
+		addPtr(req.query, "ids", params.IDs)
+		addPtr(req.query, "is_orphan", params.IsOrphan)
+		addPtr(req.query, "label", params.Label)
+		addPtr(req.query, "method", params.Method)
+		addPtr(req.query, "page", params.Page)
+		addPtr(req.query, "size", params.Size)
+		addPtr(req.query, "url", params.URL)
+		addPtr(req.query, "with_total", params.WithTotal)
+	}
+	out := new(CustomerPagApiReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateCustomerAPI 新增API
+//
+// 本接口用于新增API
+//
+// POST /api/v1/customer/api
+func (c *Client) CreateCustomerAPI(ctx context.Context, body *CustomerCreateApiRequest) (*CustomerApiReply, error) {
+	req := &request{method: "POST", path: "/api/v1/customer/api"}
+	req.body = body
+	out := new(CustomerApiReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateCustomerAPISync 同步API目录
+//
+// 本接口用于按已注册的路由同步API目录, 新增缺失的API并标记路由中已不存在的API
+//
+// POST /api/v1/customer/api/sync
+func (c *Client) CreateCustomerAPISync(ctx context.Context, body *CustomerSyncApiRequest) (*CustomerApiSyncReply, error) {
+	req := &request{method: "POST", path: "/api/v1/customer/api/sync"}
+	req.body = body
+	out := new(CustomerApiSyncReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetCustomerAPI 查询API
+//
+// 本接口用于查询指定ID的API
+//
+// GET /api/v1/customer/api/{id}
+func (c *Client) GetCustomerAPI(ctx context.Context, id int64) (*CustomerApiReply, error) {
+	req := &request{method: "GET", path: "/api/v1/customer/api/" + pathValue(id)}
+	out := new(CustomerApiReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateCustomerAPI 更新API
+//
+// 本接口用于更新指定ID的API
+//
+// PUT /api/v1/customer/api/{id}
+func (c *Client) UpdateCustomerAPI(ctx context.Context, id int64, body *CustomerUpdateApiRequest) (*CustomerApiReply, error) {
+	req := &request{method: "PUT", path: "/api/v1/customer/api/" + pathValue(id)}
+	req.body = body
+	out := new(CustomerApiReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteCustomerAPI 删除API
+//
+// 本接口用于删除指定ID的API
+//
+// DELETE /api/v1/customer/api/{id}
+func (c *Client) DeleteCustomerAPI(ctx context.Context, id int64) (*CommonMapAPIReply, error) {
+	req := &request{method: "DELETE", path: "/api/v1/customer/api/" + pathValue(id)}
+	out := new(CommonMapAPIReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListCustomerButtonParams ListCustomerButton的查询参数
+type ListCustomerButtonParams struct {
+	// 创建时间之后的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	AfterCreatedAt *string
+	// 更新时间之后的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	AfterUpdatedAt *string
+	// 创建时间之前的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	BeforeCreatedAt *string
+	// 更新时间之前的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	BeforeUpdatedAt *string
+	// 导出的列(多个用,隔开), 默认导出全部列
+	Columns *string
+	// 描述信息
+	Descr *string
+	// 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据
+	Export *string
+	// 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+	// 运算符: eq ne gt gte lt lte contains in between is_null not_null
+	// example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+	Filter *string
+	// 唯一标识
+	ID *int64
+	// "唯一标识列表(多个用,隔开)"
+	IDs *string
+	// 是否激活
+	IsActive *bool
+	// 菜单ID
+	MenuID *int64
+	// 按钮名称
+	Name *string
+	// 分页页码
+	Page *int64
+	// 分页大小
+	Size *int64
+	// 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表
+	WithTotal *bool
+}
+
+// ListCustomerButton 查询按钮列表
+//
+// 本接口用于查询按钮列表
+//
+// GET /api/v1/customer/button
+func (c *Client) ListCustomerButton(ctx context.Context, params *ListCustomerButtonParams) (*CustomerPagButtonReply, error) {
+	req := &request{method: "GET", path: "/api/v1/customer/button"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "after_created_at", params.AfterCreatedAt)
+		addPtr(req.query, "after_updated_at", params.AfterUpdatedAt)
+		addPtr(req.query, "before_created_at", params.BeforeCreatedAt)
+		addPtr(req.query, "before_updated_at", params.BeforeUpdatedAt)
+		addPtr(req.query, "columns", params.Columns)
+		addPtr(req.query, "descr", params.Descr)
+		addPtr(req.query, "export", params.Export)
+		addPtr(req.query, "filter", params.Filter)
+		addPtr(req.query, "id", params.ID)
+		addPtr(req.query, "ids", params.IDs)
+		addPtr(req.query, "is_active", params.IsActive)
+		addPtr(req.query, "menu_id", params.MenuID)
+		addPtr(req.query, "name", params.Name)
+		addPtr(req.query, "page", params.Page)
+		addPtr(req.query, "size", params.Size)
+		addPtr(req.query, "with_total", params.WithTotal)
+	}
+	out := new(CustomerPagButtonReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateCustomerButton 新增按钮
+//
+// 本接口用于新增按钮
+//
+// POST /api/v1/customer/button
+func (c *Client) CreateCustomerButton(ctx context.Context, body *CustomerCreateButtonRequest) (*CustomerButtonReply, error) {
+	req := &request{method: "POST", path: "/api/v1/customer/button"}
+	req.body = body
+	out := new(CustomerButtonReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetCustomerButton 查询按钮
+//
+// 本接口用于查询指定ID的按钮
+//
+// GET /api/v1/customer/button/{id}
+func (c *Client) GetCustomerButton(ctx context.Context, id int64) (*CustomerButtonReply, error) {
+	req := &request{method: "GET", path: "/api/v1/customer/button/" + pathValue(id)}
+	out := new(CustomerButtonReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateCustomerButton 更新按钮
+//
+// 本接口用于更新指定ID的按钮
+//
+// PUT /api/v1/customer/button/{id}
+func (c *Client) UpdateCustomerButton(ctx context.Context, id int64, body *CustomerUpdateButtonRequest) (*CustomerButtonReply, error) {
+	req := &request{method: "PUT", path: "/api/v1/customer/button/" + pathValue(id)}
+	req.body = body
+	out := new(CustomerButtonReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteCustomerButton 删除按钮
+//
+// 本接口用于删除指定ID的按钮
+//
+// DELETE /api/v1/customer/button/{id}
+func (c *Client) DeleteCustomerButton(ctx context.Context, id int64) (*CommonMapAPIReply, error) {
+	req := &request{method: "DELETE", path: "/api/v1/customer/button/" + pathValue(id)}
+	out := new(CommonMapAPIReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetCustomerCasbinModel 查询生效的Casbin模型
+//
+// 本接口用于查询当前生效的Casbin模型配置
+//
+// GET /api/v1/customer/casbin/model
+func (c *Client) GetCustomerCasbinModel(ctx context.Context) (*CustomerCasbinModelReply, error) {
+	req := &request{method: "GET", path: "/api/v1/customer/casbin/model"}
+	out := new(CustomerCasbinModelReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateCustomerCasbinModel 更新Casbin模型
+//
+// 本接口用于更新Casbin模型配置，校验全部通过后立即生效并保存为新版本
+//
+// PUT /api/v1/customer/casbin/model
+func (c *Client) UpdateCustomerCasbinModel(ctx context.Context, body *CustomerUpdateCasbinModelRequest) (*CustomerCasbinModelReply, error) {
+	req := &request{method: "PUT", path: "/api/v1/customer/casbin/model"}
+	req.body = body
+	out := new(CustomerCasbinModelReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListCustomerCasbinModelHistoryParams ListCustomerCasbinModelHistory的查询参数
+type ListCustomerCasbinModelHistoryParams struct {
+	// 创建时间之后的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	AfterCreatedAt *string
+	// 更新时间之后的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	AfterUpdatedAt *string
+	// 创建时间之前的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	BeforeCreatedAt *string
+	// 更新时间之前的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	BeforeUpdatedAt *string
+	// 导出的列(多个用,隔开), 默认导出全部列
+	Columns *string
+	// 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据
+	Export *string
+	// 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+	// 运算符: eq ne gt gte lt lte contains in between is_null not_null
+	// example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+	Filter *string
+	// 唯一标识
+	ID *int64
+	// "唯一标识列表(多个用,隔开)"
+	IDs *string
+	// 分页页码
+	Page *int64
+	// 分页大小
+	Size *int64
+	// 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表
+	WithTotal *bool
+}
+
+// ListCustomerCasbinModelHistory 查询Casbin模型历史版本
+//
+// 本接口用于分页查询Casbin模型的历史版本
+//
+// GET /api/v1/customer/casbin/model/history
+func (c *Client) ListCustomerCasbinModelHistory(ctx context.Context, params *ListCustomerCasbinModelHistoryParams) (*CustomerPagCasbinModelReply, error) {
+	req := &request{method: "GET", path: "/api/v1/customer/casbin/model/history"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "after_created_at", params.AfterCreatedAt)
+		addPtr(req.query, "after_updated_at", params.AfterUpdatedAt)
+		addPtr(req.query, "before_created_at", params.BeforeCreatedAt)
+		addPtr(req.query, "before_updated_at", params.BeforeUpdatedAt)
+		addPtr(req.query, "columns", params.Columns)
+		addPtr(req.query, "export", params.Export)
+		addPtr(req.query, "filter", params.Filter)
+		addPtr(req.query, "id", params.ID)
+		addPtr(req.query, "ids", params.IDs)
+		addPtr(req.query, "page", params.Page)
+		addPtr(req.query, "size", params.Size)
+		addPtr(req.query, "with_total", params.WithTotal)
+	}
+	out := new(CustomerPagCasbinModelReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateCustomerCasbinModelRollback 回滚Casbin模型
+//
+// 本接口用于将Casbin模型回滚到上一个版本
+//
+// POST /api/v1/customer/casbin/model/rollback
+func (c *Client) CreateCustomerCasbinModelRollback(ctx context.Context) (*CustomerCasbinModelReply, error) {
+	req := &request{method: "POST", path: "/api/v1/customer/casbin/model/rollback"}
+	out := new(CustomerCasbinModelReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateCustomerCasbinModelValidate 校验Casbin模型
+//
+// 本接口用于在应用前校验Casbin模型配置，使用当前策略对样例请求求值，不会修改生效的模型
+//
+// POST /api/v1/customer/casbin/model/validate
+func (c *Client) CreateCustomerCasbinModelValidate(ctx context.Context, body *CustomerValidateCasbinModelRequest) (*CustomerCasbinModelValidateReply, error) {
+	req := &request{method: "POST", path: "/api/v1/customer/casbin/model/validate"}
+	req.body = body
+	out := new(CustomerCasbinModelValidateReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListCustomerDepartmentParams ListCustomerDepartment的查询参数
+type ListCustomerDepartmentParams struct {
+	// 创建时间之后的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	AfterCreatedAt *string
+	// 更新时间之后的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	AfterUpdatedAt *string
+	// 创建时间之前的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	BeforeCreatedAt *string
+	// 更新时间之前的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	BeforeUpdatedAt *string
+	// 导出的列(多个用,隔开), 默认导出全部列
+	Columns *string
+	// 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据
+	Export *string
+	// 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+	// 运算符: eq ne gt gte lt lte contains in between is_null not_null
+	// example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+	Filter *string
+	// 唯一标识
+	ID *int64
+	// "唯一标识列表(多个用,隔开)"
+	IDs *string
+	// 名称
+	Name *string
+	// 分页页码
+	Page *int64
+	// 父部门ID
+	ParentID *int64
+	// 分页大小
+	Size *int64
+	// 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表
+	WithTotal *bool
+}
+
+// ListCustomerDepartment 查询部门列表
+//
+// 本接口用于查询部门列表
+//
+// GET /api/v1/customer/department
+func (c *Client) ListCustomerDepartment(ctx context.Context, params *ListCustomerDepartmentParams) (*CustomerPagDepartmentReply, error) {
+	req := &request{method: "GET", path: "/api/v1/customer/department"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "after_created_at", params.AfterCreatedAt)
+		addPtr(req.query, "after_updated_at", params.AfterUpdatedAt)
+		addPtr(req.query, "before_created_at", params.BeforeCreatedAt)
+		addPtr(req.query, "before_updated_at", params.BeforeUpdatedAt)
+		addPtr(req.query, "columns", params.Columns)
+		addPtr(req.query, "export", params.Export)
+		addPtr(req.query, "filter", params.Filter)
+		addPtr(req.query, "id", params.ID)
+		addPtr(req.query, "ids", params.IDs)
+		addPtr(req.query, "name", params.Name)
+		addPtr(req.query, "page", params.Page)
+		addPtr(req.query, "parent_id", params.ParentID)
+		addPtr(req.query, "size", params.Size)
+		addPtr(req.query, "with_total", params.WithTotal)
+	}
+	out := new(CustomerPagDepartmentReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateCustomerDepartment 新增部门
+//
+// 本接口用于新增部门, 未指定父部门时创建根部门
+//
+// POST /api/v1/customer/department
+func (c *Client) CreateCustomerDepartment(ctx context.Context, body *CustomerCreateOrUpdateDepartmentRequest) (*CustomerDepartmentReply, error) {
+	req := &request{method: "POST", path: "/api/v1/customer/department"}
+	req.body = body
+	out := new(CustomerDepartmentReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetCustomerDepartmentTree 查询组织架构树
+//
+// 本接口用于查询完整的部门树, 同级部门按排序字段排序
+//
+// GET /api/v1/customer/department/tree
+func (c *Client) GetCustomerDepartmentTree(ctx context.Context) (*CustomerDepartmentTreeReply, error) {
+	req := &request{method: "GET", path: "/api/v1/customer/department/tree"}
+	out := new(CustomerDepartmentTreeReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetCustomerDepartment 查询部门
+//
+// 本接口用于查询指定ID的部门
+//
+// GET /api/v1/customer/department/{id}
+func (c *Client) GetCustomerDepartment(ctx context.Context, id int64) (*CustomerDepartmentReply, error) {
+	req := &request{method: "GET", path: "/api/v1/customer/department/" + pathValue(id)}
+	out := new(CustomerDepartmentReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateCustomerDepartment 更新部门
+//
+// 本接口用于更新指定ID的部门, 请求中的父部门ID不生效, 调整上级部门使用移动部门接口
+//
+// PUT /api/v1/customer/department/{id}
+func (c *Client) UpdateCustomerDepartment(ctx context.Context, id int64, body *CustomerCreateOrUpdateDepartmentRequest) (*CustomerDepartmentReply, error) {
+	req := &request{method: "PUT", path: "/api/v1/customer/department/" + pathValue(id)}
+	req.body = body
+	out := new(CustomerDepartmentReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteCustomerDepartment 删除部门
+//
+// 本接口用于删除指定ID的部门, 部门下存在下级部门或用户时不能删除
+//
+// DELETE /api/v1/customer/department/{id}
+func (c *Client) DeleteCustomerDepartment(ctx context.Context, id int64) (*CommonMapAPIReply, error) {
+	req := &request{method: "DELETE", path: "/api/v1/customer/department/" + pathValue(id)}
+	out := new(CommonMapAPIReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PatchCustomerDepartmentMove 移动部门
+//
+// 本接口用于将部门及其下级部门整体移动到新的父部门下, 父部门为空或0时移动为根部门,
+// 不能移动到部门自身或其下级部门下
+//
+// PATCH /api/v1/customer/department/{id}/move
+func (c *Client) PatchCustomerDepartmentMove(ctx context.Context, id int64, body *CustomerMoveDepartmentRequest) (*CustomerDepartmentReply, error) {
+	req := &request{method: "PATCH", path: "/api/v1/customer/department/" + pathValue(id) + "/move"}
+	req.body = body
+	out := new(CustomerDepartmentReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListCustomerGroupParams ListCustomerGroup的查询参数
+type ListCustomerGroupParams struct {
+	// 创建时间之后的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	AfterCreatedAt *string
+	// 更新时间之后的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	AfterUpdatedAt *string
+	// 创建时间之前的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	BeforeCreatedAt *string
+	// 更新时间之前的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	BeforeUpdatedAt *string
+	// 导出的列(多个用,隔开), 默认导出全部列
+	Columns *string
+	// 描述信息
+	Descr *string
+	// 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据
+	Export *string
+	// 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+	// 运算符: eq ne gt gte lt lte contains in between is_null not_null
+	// example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+	Filter *string
+	// 唯一标识
+	ID *int64
+	// "唯一标识列表(多个用,隔开)"
+	IDs *string
+	// 名称
+	Name *string
+	// 分页页码
+	Page *int64
+	// 分页大小
+	Size *int64
+	// 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表
+	WithTotal *bool
+}
+
+// ListCustomerGroup 查询用户组列表
+//
+// 本接口用于查询用户组列表
+//
+// GET /api/v1/customer/group
+func (c *Client) ListCustomerGroup(ctx context.Context, params *ListCustomerGroupParams) (*CustomerPagUserGroupReply, error) {
+	req := &request{method: "GET", path: "/api/v1/customer/group"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "after_created_at", params.AfterCreatedAt)
+		addPtr(req.query, "after_updated_at", params.AfterUpdatedAt)
+		addPtr(req.query, "before_created_at", params.BeforeCreatedAt)
+		addPtr(req.query, "before_updated_at", params.BeforeUpdatedAt)
+		addPtr(req.query, "columns", params.Columns)
+		addPtr(req.query, "descr", params.Descr)
+		addPtr(req.query, "export", params.Export)
+		addPtr(req.query, "filter", params.Filter)
+		addPtr(req.query, "id", params.ID)
+		addPtr(req.query, "ids", params.IDs)
+		addPtr(req.query, "name", params.Name)
+		addPtr(req.query, "page", params.Page)
+		addPtr(req.query, "size", params.Size)
+		addPtr(req.query, "with_total", params.WithTotal)
+	}
+	out := new(CustomerPagUserGroupReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateCustomerGroup 新增用户组
+//
+// 本接口用于新增用户组, 组内用户继承用户组关联的全部角色
+//
+// POST /api/v1/customer/group
+func (c *Client) CreateCustomerGroup(ctx context.Context, body *CustomerCreateOrUpdateUserGroupRequest) (*CustomerUserGroupReply, error) {
+	req := &request{method: "POST", path: "/api/v1/customer/group"}
+	req.body = body
+	out := new(CustomerUserGroupReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetCustomerGroup 查询用户组
+//
+// 本接口用于查询指定ID的用户组及其角色和成员
+//
+// GET /api/v1/customer/group/{id}
+func (c *Client) GetCustomerGroup(ctx context.Context, id int64) (*CustomerUserGroupReply, error) {
+	req := &request{method: "GET", path: "/api/v1/customer/group/" + pathValue(id)}
+	out := new(CustomerUserGroupReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateCustomerGroup 更新用户组
+//
+// 本接口用于更新指定ID的用户组, 角色和成员按请求整体替换
+//
+// PUT /api/v1/customer/group/{id}
+func (c *Client) UpdateCustomerGroup(ctx context.Context, id int64, body *CustomerCreateOrUpdateUserGroupRequest) (*CustomerUserGroupReply, error) {
+	req := &request{method: "PUT", path: "/api/v1/customer/group/" + pathValue(id)}
+	req.body = body
+	out := new(CustomerUserGroupReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteCustomerGroup 删除用户组
+//
+// 本接口用于删除指定ID的用户组, 组内用户不再继承该用户组的角色
+//
+// DELETE /api/v1/customer/group/{id}
+func (c *Client) DeleteCustomerGroup(ctx context.Context, id int64) (*CommonMapAPIReply, error) {
+	req := &request{method: "DELETE", path: "/api/v1/customer/group/" + pathValue(id)}
+	out := new(CommonMapAPIReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateCustomerGroupMembers 添加用户组成员
+//
+// 本接口用于将用户加入指定的用户组, 已在组内的用户保持不变
+//
+// POST /api/v1/customer/group/{id}/members
+func (c *Client) CreateCustomerGroupMembers(ctx context.Context, id int64, body *CustomerUserGroupMemberRequest) (*CustomerUserGroupReply, error) {
+	req := &request{method: "POST", path: "/api/v1/customer/group/" + pathValue(id) + "/members"}
+	req.body = body
+	out := new(CustomerUserGroupReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteCustomerGroupMembers 移除用户组成员
+//
+// 本接口用于将用户移出指定的用户组
+//
+// DELETE /api/v1/customer/group/{id}/members
+func (c *Client) DeleteCustomerGroupMembers(ctx context.Context, id int64, body *CustomerUserGroupMemberRequest) (*CustomerUserGroupReply, error) {
+	req := &request{method: "DELETE", path: "/api/v1/customer/group/" + pathValue(id) + "/members"}
+	req.body = body
+	out := new(CustomerUserGroupReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetCustomerMeMenuTree 获取当前用户菜单树
+//
+// 本接口用于获取当前登录用户的菜单权限树, 合并直属角色和所属用户组角色的菜单
+//
+// GET /api/v1/customer/me/menu/tree
+func (c *Client) GetCustomerMeMenuTree(ctx context.Context) (*CustomerRoleMenuTreeReply, error) {
+	req := &request{method: "GET", path: "/api/v1/customer/me/menu/tree"}
+	out := new(CustomerRoleMenuTreeReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetCustomerMeOidc 查询个人关联的外部身份
+//
+// 本接口用于查询当前用户关联的外部身份
+//
+// GET /api/v1/customer/me/oidc
+func (c *Client) GetCustomerMeOidc(ctx context.Context) (*CustomerUserIdentitiesReply, error) {
+	req := &request{method: "GET", path: "/api/v1/customer/me/oidc"}
+	out := new(CustomerUserIdentitiesReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetCustomerMeOidcLinkParams GetCustomerMeOidcLink的查询参数
+type GetCustomerMeOidcLinkParams struct {
+	// 身份提供方标识
+	Provider string
+}
+
+// GetCustomerMeOidcLink 关联外部身份
+//
+// 本接口用于生成授权地址, 授权回调成功后将外部身份关联到当前用户
+//
+// GET /api/v1/customer/me/oidc/link
+func (c *Client) GetCustomerMeOidcLink(ctx context.Context, params *GetCustomerMeOidcLinkParams) (*CustomerOidcLoginReply, error) {
+	req := &request{method: "GET", path: "/api/v1/customer/me/oidc/link"}
+	if params != nil {
+		req.query = url.Values{}
+		addValue(req.query, "provider", params.Provider)
+	}
+	out := new(CustomerOidcLoginReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteCustomerMeOidc 解除外部身份关联
+//
+// 本接口用于解除当前用户与指定身份提供方的关联
+//
+// DELETE /api/v1/customer/me/oidc/{provider}
+func (c *Client) DeleteCustomerMeOidc(ctx context.Context, provider string) (*CommonMapAPIReply, error) {
+	req := &request{method: "DELETE", path: "/api/v1/customer/me/oidc/" + pathValue(provider)}
+	out := new(CommonMapAPIReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PatchCustomerMePassword 修改当前用户密码
+//
+// 本接口用于修改当前登录用户的密码
+//
+// PATCH /api/v1/customer/me/password
+func (c *Client) PatchCustomerMePassword(ctx context.Context, body *CustomerPatchPasswordRequest) (*CommonMapAPIReply, error) {
+	req := &request{method: "PATCH", path: "/api/v1/customer/me/password"}
+	req.body = body
+	out := new(CommonMapAPIReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetCustomerMePreferences 查询个人全部偏好
+//
+// 本接口用于查询当前用户保存的全部界面偏好, 按命名空间排序
+//
+// GET /api/v1/customer/me/preferences
+func (c *Client) GetCustomerMePreferences(ctx context.Context) (*CustomerPreferencesReply, error) {
+	req := &request{method: "GET", path: "/api/v1/customer/me/preferences"}
+	out := new(CustomerPreferencesReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetCustomerMePreference 查询个人偏好
+//
+// 本接口用于查询当前用户在指定命名空间下的界面偏好
+//
+// GET /api/v1/customer/me/preferences/{namespace}
+func (c *Client) GetCustomerMePreference(ctx context.Context, namespace string) (*CustomerPreferenceReply, error) {
+	req := &request{method: "GET", path: "/api/v1/customer/me/preferences/" + pathValue(namespace)}
+	out := new(CustomerPreferenceReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateCustomerMePreferences 保存个人偏好
+//
+// 本接口用于保存当前用户在指定命名空间下的界面偏好, 已有时整体替换。
+// 请求体为JSON对象, 按命名空间的格式校验, 不接受未定义的字段, 大小和命名空间数量受配置限制
+//
+// PUT /api/v1/customer/me/preferences/{namespace}
+func (c *Client) UpdateCustomerMePreferences(ctx context.Context, namespace string, body map[string]any) (*CustomerPreferenceReply, error) {
+	req := &request{method: "PUT", path: "/api/v1/customer/me/preferences/" + pathValue(namespace)}
+	req.body = body
+	out := new(CustomerPreferenceReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteCustomerMePreferences 删除个人偏好
+//
+// 本接口用于删除当前用户在指定命名空间下的界面偏好, 恢复为默认设置
+//
+// DELETE /api/v1/customer/me/preferences/{namespace}
+func (c *Client) DeleteCustomerMePreferences(ctx context.Context, namespace string) (*CommonMapAPIReply, error) {
+	req := &request{method: "DELETE", path: "/api/v1/customer/me/preferences/" + pathValue(namespace)}
+	out := new(CommonMapAPIReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListCustomerMeRecordLoginParams ListCustomerMeRecordLogin的查询参数
+type ListCustomerMeRecordLoginParams struct {
+	// 登陆时间之后的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	AfterLoginAt *string
+	// 登陆时间之前的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	BeforeLoginAt *string
+	// 导出的列(多个用,隔开), 默认导出全部列
+	Columns *string
+	// 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据
+	Export *string
+	// 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+	// 运算符: eq ne gt gte lt lte contains in between is_null not_null
+	// example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+	Filter *string
+	// 唯一标识
+	ID *int64
+	// "唯一标识列表(多个用,隔开)"
+	IDs *string
+	// IP 地址
+	IPAddress *string
+	// 用户名
+	Name *string
+	// 分页页码
+	Page *int64
+	// 分页大小
+	Size *int64
+	// 登陆状态
+	Status *bool
+	// 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表
+	WithTotal *bool
+}
+
+// ListCustomerMeRecordLogin 查询当前用户的登录记录列表
+//
+// 本接口用于查询当前登录用户的登录记录列表
+//
+// GET /api/v1/customer/me/record/login
+func (c *Client) ListCustomerMeRecordLogin(ctx context.Context, params *ListCustomerMeRecordLoginParams) (*CustomerPagLoginRecordReply, error) {
+	req := &request{method: "GET", path: "/api/v1/customer/me/record/login"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "after_login_at", params.AfterLoginAt)
+		addPtr(req.query, "before_login_at", params.BeforeLoginAt)
+		addPtr(req.query, "columns", params.Columns)
+		addPtr(req.query, "export", params.Export)
+		addPtr(req.query, "filter", params.Filter)
+		addPtr(req.query, "id", params.ID)
+		addPtr(req.query, "ids", params.IDs)
+		addPtr(req.query, "ip_address", params.IPAddress)
+		addPtr(req.query, "name", params.Name)
+		addPtr(req.query, "page", params.Page)
+		addPtr(req.query, "size", params.Size)
+		addPtr(req.query, "status", params.Status)
+		addPtr(req.query, "with_total", params.WithTotal)
+	}
+	out := new(CustomerPagLoginRecordReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetCustomerMeRoles 获取当前用户的有效角色
+//
+// 本接口用于获取当前登录用户的有效角色, 包括直属角色和所属用户组的角色
+//
+// GET /api/v1/customer/me/roles
+func (c *Client) GetCustomerMeRoles(ctx context.Context) (*CustomerEffectiveRoleReply, error) {
+	req := &request{method: "GET", path: "/api/v1/customer/me/roles"}
+	out := new(CustomerEffectiveRoleReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetCustomerMeSessions 查询个人登录会话
+//
+// 本接口用于查询当前用户未过期的登录会话, current标记当前请求所属的会话
+//
+// GET /api/v1/customer/me/sessions
+func (c *Client) GetCustomerMeSessions(ctx context.Context) (*CustomerUserSessionsReply, error) {
+	req := &request{method: "GET", path: "/api/v1/customer/me/sessions"}
+	out := new(CustomerUserSessionsReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteCustomerMeSessions 终止个人其他登录会话
+//
+// 本接口用于终止当前用户除当前会话以外的所有会话
+//
+// DELETE /api/v1/customer/me/sessions
+func (c *Client) DeleteCustomerMeSessions(ctx context.Context) (*CustomerDeleteSessionReply, error) {
+	req := &request{method: "DELETE", path: "/api/v1/customer/me/sessions"}
+	out := new(CustomerDeleteSessionReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteCustomerMeSession 终止个人登录会话
+//
+// 本接口用于终止当前用户的指定会话, 会话下的令牌立即失效
+//
+// DELETE /api/v1/customer/me/sessions/{session_id}
+func (c *Client) DeleteCustomerMeSession(ctx context.Context, sessionID string) (*CommonMapAPIReply, error) {
+	req := &request{method: "DELETE", path: "/api/v1/customer/me/sessions/" + pathValue(sessionID)}
+	out := new(CommonMapAPIReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListCustomerMenuParams ListCustomerMenu的查询参数
+type ListCustomerMenuParams struct {
+	// 创建时间之后的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	AfterCreatedAt *string
+	// 更新时间之后的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	AfterUpdatedAt *string
+	// 创建时间之前的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	BeforeCreatedAt *string
+	// 更新时间之前的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	BeforeUpdatedAt *string
+	// 导出的列(多个用,隔开), 默认导出全部列
+	Columns *string
+	// 组件路径
+	Component *string
+	// 菜单描述
+	Descr *string
+	// 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据
+	Export *string
+	// 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+	// 运算符: eq ne gt gte lt lte contains in between is_null not_null
+	// example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+	Filter *string
+	// 唯一标识
+	ID *int64
+	// "唯一标识列表(多个用,隔开)"
+	IDs *string
+	// 是否激活
+	IsActive *bool
+	// 名称
+	Name *string
+	// 分页页码
+	Page *int64
+	// 父级菜单ID
+	ParentID *int64
+	// 前端路由路径
+	Path *string
+	// 分页大小
+	Size *int64
+	// 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表
+	WithTotal *bool
+}
+
+// ListCustomerMenu 查询菜单列表
+//
+// 本接口用于查询菜单列表
+//
+// GET /api/v1/customer/menu
+func (c *Client) ListCustomerMenu(ctx context.Context, params *ListCustomerMenuParams) (*CustomerPagMenuReply, error) {
+	req := &request{method: "GET", path: "/api/v1/customer/menu"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "after_created_at", params.AfterCreatedAt)
+		addPtr(req.query, "after_updated_at", params.AfterUpdatedAt)
+		addPtr(req.query, "before_created_at", params.BeforeCreatedAt)
+		addPtr(req.query, "before_updated_at", params.BeforeUpdatedAt)
+		addPtr(req.query, "columns", params.Columns)
+		addPtr(req.query, "component", params.Component)
+		addPtr(req.query, "descr", params.Descr)
+		addPtr(req.query, "export", params.Export)
+		addPtr(req.query, "filter", params.Filter)
+		addPtr(req.query, "id", params.ID)
+		addPtr(req.query, "ids", params.IDs)
+		addPtr(req.query, "is_active", params.IsActive)
+		addPtr(req.query, "name", params.Name)
+		addPtr(req.query, "page", params.Page)
+		addPtr(req.query, "parent_id", params.ParentID)
+		addPtr(req.query, "path", params.Path)
+		addPtr(req.query, "size", params.Size)
+		addPtr(req.query, "with_total", params.WithTotal)
+	}
+	out := new(CustomerPagMenuReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateCustomerMenu 新增菜单
+//
+// 本接口用于新增菜单
+//
+// POST /api/v1/customer/menu
+func (c *Client) CreateCustomerMenu(ctx context.Context, body *CustomerCreateMenuRequest) (*CustomerMenuReply, error) {
+	req := &request{method: "POST", path: "/api/v1/customer/menu"}
+	req.body = body
+	out := new(CustomerMenuReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateCustomerMenuBulkDelete 批量删除菜单
+//
+// 本接口用于在一个事务中批量删除菜单和菜单下的按钮, 存在不在删除范围内的下级菜单时不能删除, 角色授权和Casbin组策略随之清理
+//
+// POST /api/v1/customer/menu/bulk-delete
+func (c *Client) CreateCustomerMenuBulkDelete(ctx context.Context, body *CustomerBulkDeleteRequest) (*CustomerDeleteDependenciesReply, error) {
+	req := &request{method: "POST", path: "/api/v1/customer/menu/bulk-delete"}
+	req.body = body
+	out := new(CustomerDeleteDependenciesReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetCustomerMenuDependenciesParams GetCustomerMenuDependencies的查询参数
+type GetCustomerMenuDependenciesParams struct {
+	// 菜单编号列表
+	IDs []int64
+}
+
+// GetCustomerMenuDependencies 检查删除菜单的影响
+//
+// 本接口用于删除菜单前检查引用关系, 返回阻止删除的下级菜单、随之删除的按钮、随之解除授权的角色和随之移除的Casbin组策略, 不修改数据
+//
+// GET /api/v1/customer/menu/dependencies
+func (c *Client) GetCustomerMenuDependencies(ctx context.Context, params *GetCustomerMenuDependenciesParams) (*CustomerDeleteDependenciesReply, error) {
+	req := &request{method: "GET", path: "/api/v1/customer/menu/dependencies"}
+	if params != nil {
+		req.query = url.Values{}
+		addMulti(req.query, "ids", params.IDs)
+	}
+	out := new(CustomerDeleteDependenciesReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetCustomerMenu 查询菜单
+//
+// 本接口用于查询指定ID的菜单
+//
+// GET /api/v1/customer/menu/{id}
+func (c *Client) GetCustomerMenu(ctx context.Context, id int64) (*CustomerMenuReply, error) {
+	req := &request{method: "GET", path: "/api/v1/customer/menu/" + pathValue(id)}
+	out := new(CustomerMenuReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateCustomerMenu 更新菜单
+//
+// 本接口用于更新指定ID的菜单
+//
+// PUT /api/v1/customer/menu/{id}
+func (c *Client) UpdateCustomerMenu(ctx context.Context, id int64, body *CustomerUpdateMenuRequest) (*CustomerMenuReply, error) {
+	req := &request{method: "PUT", path: "/api/v1/customer/menu/" + pathValue(id)}
+	req.body = body
+	out := new(CustomerMenuReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteCustomerMenu 删除菜单
+//
+// 本接口用于删除指定ID的菜单
+//
+// DELETE /api/v1/customer/menu/{id}
+func (c *Client) DeleteCustomerMenu(ctx context.Context, id int64) (*CommonMapAPIReply, error) {
+	req := &request{method: "DELETE", path: "/api/v1/customer/menu/" + pathValue(id)}
+	out := new(CommonMapAPIReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetCustomerRbacExport 导出权限配置
+//
+// 本接口用于将角色、菜单、按钮、API、Casbin模型和策略导出为一个YAML文档，对象之间使用名称引用，可导入到其他环境
+//
+// 返回原始响应体, 调用方读取完毕后需要关闭
+//
+// GET /api/v1/customer/rbac/export
+func (c *Client) GetCustomerRbacExport(ctx context.Context) (io.ReadCloser, error) {
+	req := &request{method: "GET", path: "/api/v1/customer/rbac/export"}
+	return c.doStream(ctx, req)
+}
+
+// CreateCustomerRbacImportParams CreateCustomerRbacImport的查询参数
+type CreateCustomerRbacImportParams struct {
+	// 是否只比较差异
+	DryRun *bool
+	// 冲突处理方式
+	Conflict *string
+}
+
+// CreateCustomerRbacImport 导入权限配置
+//
+// 本接口用于导入导出接口生成的YAML文档，dry_run为true时只返回差异；与已有数据不一致的对象按conflict处理(fail:不导入任何数据, skip:保留已有数据, overwrite:覆盖)，文档未包含的对象保持不变
+//
+// POST /api/v1/customer/rbac/import
+func (c *Client) CreateCustomerRbacImport(ctx context.Context, params *CreateCustomerRbacImportParams, body string) (*CustomerRbacImportReply, error) {
+	req := &request{method: "POST", path: "/api/v1/customer/rbac/import"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "dry_run", params.DryRun)
+		addPtr(req.query, "conflict", params.Conflict)
+	}
+	req.body = body
+	out := new(CustomerRbacImportReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListCustomerRoleParams ListCustomerRole的查询参数
+type ListCustomerRoleParams struct {
+	// 创建时间之后的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	AfterCreatedAt *string
+	// 更新时间之后的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	AfterUpdatedAt *string
+	// 创建时间之前的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	BeforeCreatedAt *string
+	// 更新时间之前的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	BeforeUpdatedAt *string
+	// 导出的列(多个用,隔开), 默认导出全部列
+	Columns *string
+	// 描述信息
+	Descr *string
+	// 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据
+	Export *string
+	// 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+	// 运算符: eq ne gt gte lt lte contains in between is_null not_null
+	// example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+	Filter *string
+	// 唯一标识
+	ID *int64
+	// "唯一标识列表(多个用,隔开)"
+	IDs *string
+	// 名称
+	Name *string
+	// 分页页码
+	Page *int64
+	// 分页大小
+	Size *int64
+	// 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表
+	WithTotal *bool
+}
+
+// ListCustomerRole 查询角色列表
+//
+// 本接口用于查询角色列表
+//
+// GET /api/v1/customer/role
+func (c *Client) ListCustomerRole(ctx context.Context, params *ListCustomerRoleParams) (*CustomerPagRoleReply, error) {
+	req := &request{method: "GET", path: "/api/v1/customer/role"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "after_created_at", params.AfterCreatedAt)
+		addPtr(req.query, "after_updated_at", params.AfterUpdatedAt)
+		addPtr(req.query, "before_created_at", params.BeforeCreatedAt)
+		addPtr(req.query, "before_updated_at", params.BeforeUpdatedAt)
+		addPtr(req.query, "columns", params.Columns)
+		addPtr(req.query, "descr", params.Descr)
+		addPtr(req.query, "export", params.Export)
+		addPtr(req.query, "filter", params.Filter)
+		addPtr(req.query, "id", params.ID)
+		addPtr(req.query, "ids", params.IDs)
+		addPtr(req.query, "name", params.Name)
+		addPtr(req.query, "page", params.Page)
+		addPtr(req.query, "size", params.Size)
+		addPtr(req.query, "with_total", params.WithTotal)
+	}
+	out := new(CustomerPagRoleReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateCustomerRole 新增角色
+//
+// 本接口用于新增角色
+//
+// POST /api/v1/customer/role
+func (c *Client) CreateCustomerRole(ctx context.Context, body *CustomerCreateOrUpdateRoleRequest) (*CustomerRoleReply, error) {
+	req := &request{method: "POST", path: "/api/v1/customer/role"}
+	req.body = body
+	out := new(CustomerRoleReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateCustomerRoleBulkDelete 批量删除角色
+//
+// 本接口用于在一个事务中批量删除角色, 仍是用户主角色的角色不能删除, 用户组关联和Casbin组策略随之清理
+//
+// POST /api/v1/customer/role/bulk-delete
+func (c *Client) CreateCustomerRoleBulkDelete(ctx context.Context, body *CustomerBulkDeleteRequest) (*CustomerDeleteDependenciesReply, error) {
+	req := &request{method: "POST", path: "/api/v1/customer/role/bulk-delete"}
+	req.body = body
+	out := new(CustomerDeleteDependenciesReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetCustomerRoleDependenciesParams GetCustomerRoleDependencies的查询参数
+type GetCustomerRoleDependenciesParams struct {
+	// 角色编号列表
+	IDs []int64
+}
+
+// GetCustomerRoleDependencies 检查删除角色的影响
+//
+// 本接口用于删除角色前检查引用关系, 返回阻止删除的引用、随之解除关联的记录和随之移除的Casbin组策略, 不修改数据
+//
+// GET /api/v1/customer/role/dependencies
+func (c *Client) GetCustomerRoleDependencies(ctx context.Context, params *GetCustomerRoleDependenciesParams) (*CustomerDeleteDependenciesReply, error) {
+	req := &request{method: "GET", path: "/api/v1/customer/role/dependencies"}
+	if params != nil {
+		req.query = url.Values{}
+		addMulti(req.query, "ids", params.IDs)
+	}
+	out := new(CustomerDeleteDependenciesReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetCustomerRole 查询角色
+//
+// 本接口用于查询指定ID的角色
+//
+// GET /api/v1/customer/role/{id}
+func (c *Client) GetCustomerRole(ctx context.Context, id int64) (*CustomerRoleReply, error) {
+	req := &request{method: "GET", path: "/api/v1/customer/role/" + pathValue(id)}
+	out := new(CustomerRoleReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateCustomerRole 更新角色
+//
+// 本接口用于更新指定ID的角色
+//
+// PUT /api/v1/customer/role/{id}
+func (c *Client) UpdateCustomerRole(ctx context.Context, id int64, body *CustomerCreateOrUpdateRoleRequest) (*CustomerRoleReply, error) {
+	req := &request{method: "PUT", path: "/api/v1/customer/role/" + pathValue(id)}
+	req.body = body
+	out := new(CustomerRoleReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteCustomerRole 删除角色
+//
+// 本接口用于删除指定ID的角色
+//
+// DELETE /api/v1/customer/role/{id}
+func (c *Client) DeleteCustomerRole(ctx context.Context, id int64) (*CommonMapAPIReply, error) {
+	req := &request{method: "DELETE", path: "/api/v1/customer/role/" + pathValue(id)}
+	out := new(CommonMapAPIReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetCustomerSensitiveReveal 校验查看敏感字段的权限
+//
+// 本接口用于校验当前用户是否可以查看敏感字段明文, 授予此接口权限的角色在查询结果中看到完整的IP地址、主机用户名等敏感字段
+//
+// GET /api/v1/customer/sensitive/reveal
+func (c *Client) GetCustomerSensitiveReveal(ctx context.Context) (*CommonMapAPIReply, error) {
+	req := &request{method: "GET", path: "/api/v1/customer/sensitive/reveal"}
+	out := new(CommonMapAPIReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListCustomerSigningKeyParams ListCustomerSigningKey的查询参数
+type ListCustomerSigningKeyParams struct {
+	// 创建时间之后的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	AfterCreatedAt *string
+	// 更新时间之后的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	AfterUpdatedAt *string
+	// 创建时间之前的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	BeforeCreatedAt *string
+	// 更新时间之前的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	BeforeUpdatedAt *string
+	// 导出的列(多个用,隔开), 默认导出全部列
+	Columns *string
+	// 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据
+	Export *string
+	// 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+	// 运算符: eq ne gt gte lt lte contains in between is_null not_null
+	// example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+	Filter *string
+	// 唯一标识
+	ID *int64
+	// "唯一标识列表(多个用,隔开)"
+	IDs *string
+	// 分页页码
+	Page *int64
+	// 分页大小
+	Size *int64
+	// 令牌类型
+	TokenType *string
+	// 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表
+	WithTotal *bool
+}
+
+// ListCustomerSigningKey 查询签名密钥
+//
+// 本接口用于分页查询JWT签名密钥, 不返回私钥和HMAC密钥
+//
+// GET /api/v1/customer/signing-key
+func (c *Client) ListCustomerSigningKey(ctx context.Context, params *ListCustomerSigningKeyParams) (*CustomerPagSigningKeyReply, error) {
+	req := &request{method: "GET", path: "/api/v1/customer/signing-key"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "after_created_at", params.AfterCreatedAt)
+		addPtr(req.query, "after_updated_at", params.AfterUpdatedAt)
+		addPtr(req.query, "before_created_at", params.BeforeCreatedAt)
+		addPtr(req.query, "before_updated_at", params.BeforeUpdatedAt)
+		addPtr(req.query, "columns", params.Columns)
+		addPtr(req.query, "export", params.Export)
+		addPtr(req.query, "filter", params.Filter)
+		addPtr(req.query, "id", params.ID)
+		addPtr(req.query, "ids", params.IDs)
+		addPtr(req.query, "page", params.Page)
+		addPtr(req.query, "size", params.Size)
+		addPtr(req.query, "token_type", params.TokenType)
+		addPtr(req.query, "with_total", params.WithTotal)
+	}
+	out := new(CustomerPagSigningKeyReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateCustomerSigningKeyRotate 轮换签名密钥
+//
+// 本接口用于生成新的JWT签名密钥, 旧密钥保留到令牌有效期结束, 密钥泄露时可立即停用旧密钥
+//
+// POST /api/v1/customer/signing-key/rotate
+func (c *Client) CreateCustomerSigningKeyRotate(ctx context.Context, body *CustomerRotateSigningKeyRequest) (*CustomerSigningKeyRotateReply, error) {
+	req := &request{method: "POST", path: "/api/v1/customer/signing-key/rotate"}
+	req.body = body
+	out := new(CustomerSigningKeyRotateReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListCustomerUserParams ListCustomerUser的查询参数
+type ListCustomerUserParams struct {
+	// 创建时间之后的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	AfterCreatedAt *string
+	// 更新时间之后的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	AfterUpdatedAt *string
+	// 创建时间之前的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	BeforeCreatedAt *string
+	// 更新时间之前的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	BeforeUpdatedAt *string
+	// 导出的列(多个用,隔开), 默认导出全部列
+	Columns *string
+	// 部门ID
+	DepartmentID *int64
+	// 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据
+	Export *string
+	// 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+	// 运算符: eq ne gt gte lt lte contains in between is_null not_null
+	// example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+	Filter *string
+	// 唯一标识
+	ID *int64
+	// "唯一标识列表(多个用,隔开)"
+	IDs *string
+	// 是否包含下级部门的用户, 由服务层展开为部门ID列表
+	IncludeSub *bool
+	// 是否激活
+	IsActive *bool
+	// 是否是工作人员
+	IsStaff *bool
+	// 分页页码
+	Page *int64
+	// 角色ID
+	RoleID *int64
+	// 分页大小
+	Size *int64
+	// 用户名
+	Username *string
+	// 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表
+	WithTotal *bool
+}
+
+// ListCustomerUser 查询用户列表
+//
+// 本接口用于查询用户列表, 支持按部门筛选(include_sub包含下级部门), 非工作人员只能查看角色数据范围内的用户
+//
+// GET /api/v1/customer/user
+func (c *Client) ListCustomerUser(ctx context.Context, params *ListCustomerUserParams) (*CustomerPagUserReply, error) {
+	req := &request{method: "GET", path: "/api/v1/customer/user"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "after_created_at", params.AfterCreatedAt)
+		addPtr(req.query, "after_updated_at", params.AfterUpdatedAt)
+		addPtr(req.query, "before_created_at", params.BeforeCreatedAt)
+		addPtr(req.query, "before_updated_at", params.BeforeUpdatedAt)
+		addPtr(req.query, "columns", params.Columns)
+		addPtr(req.query, "department_id", params.DepartmentID)
+		addPtr(req.query, "export", params.Export)
+		addPtr(req.query, "filter", params.Filter)
+		addPtr(req.query, "id", params.ID)
+		addPtr(req.query, "ids", params.IDs)
+		addPtr(req.query, "include_sub", params.IncludeSub)
+		addPtr(req.query, "is_active", params.IsActive)
+		addPtr(req.query, "is_staff", params.IsStaff)
+		addPtr(req.query, "page", params.Page)
+		addPtr(req.query, "role_id", params.RoleID)
+		addPtr(req.query, "size", params.Size)
+		addPtr(req.query, "username", params.Username)
+		addPtr(req.query, "with_total", params.WithTotal)
+	}
+	out := new(CustomerPagUserReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateCustomerUser 新增用户
+//
+// 本接口用于新增用户
+//
+// POST /api/v1/customer/user
+func (c *Client) CreateCustomerUser(ctx context.Context, body *CustomerCreateUserRequest) (*CustomerUserReply, error) {
+	req := &request{method: "POST", path: "/api/v1/customer/user"}
+	req.body = body
+	out := new(CustomerUserReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetCustomerUserPasswordReport 统计密码哈希算法
+//
+// 本接口用于统计用户密码哈希使用的算法, 以及仍使用旧算法或旧参数的用户数量,
+// 这些用户下次登录成功时按当前配置的算法和参数重新哈希
+//
+// GET /api/v1/customer/user/password/report
+func (c *Client) GetCustomerUserPasswordReport(ctx context.Context) (*CustomerPasswordHashReportReply, error) {
+	req := &request{method: "GET", path: "/api/v1/customer/user/password/report"}
+	out := new(CustomerPasswordHashReportReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PatchCustomerUserPassword 重置用户密码
+//
+// 本接口用于重置指定ID的用户密码
+//
+// PATCH /api/v1/customer/user/password/{id}
+func (c *Client) PatchCustomerUserPassword(ctx context.Context, id int64, body *CustomerResetPasswordRequest) (*CommonMapAPIReply, error) {
+	req := &request{method: "PATCH", path: "/api/v1/customer/user/password/" + pathValue(id)}
+	req.body = body
+	out := new(CommonMapAPIReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListCustomerUserRecordLoginParams ListCustomerUserRecordLogin的查询参数
+type ListCustomerUserRecordLoginParams struct {
+	// 登陆时间之后的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	AfterLoginAt *string
+	// 登陆时间之前的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	BeforeLoginAt *string
+	// 导出的列(多个用,隔开), 默认导出全部列
+	Columns *string
+	// 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据
+	Export *string
+	// 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+	// 运算符: eq ne gt gte lt lte contains in between is_null not_null
+	// example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+	Filter *string
+	// 唯一标识
+	ID *int64
+	// "唯一标识列表(多个用,隔开)"
+	IDs *string
+	// IP 地址
+	IPAddress *string
+	// 用户名
+	Name *string
+	// 分页页码
+	Page *int64
+	// 分页大小
+	Size *int64
+	// 登陆状态
+	Status *bool
+	// 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表
+	WithTotal *bool
+}
+
+// ListCustomerUserRecordLogin 查询用户的登录记录列表
+//
+// 本接口用于查询用户登录记录列表, 指定export参数时按columns选择的列导出为csv或xlsx文件
+//
+// GET /api/v1/customer/user/record/login
+func (c *Client) ListCustomerUserRecordLogin(ctx context.Context, params *ListCustomerUserRecordLoginParams) (*CustomerPagLoginRecordReply, error) {
+	req := &request{method: "GET", path: "/api/v1/customer/user/record/login"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "after_login_at", params.AfterLoginAt)
+		addPtr(req.query, "before_login_at", params.BeforeLoginAt)
+		addPtr(req.query, "columns", params.Columns)
+		addPtr(req.query, "export", params.Export)
+		addPtr(req.query, "filter", params.Filter)
+		addPtr(req.query, "id", params.ID)
+		addPtr(req.query, "ids", params.IDs)
+		addPtr(req.query, "ip_address", params.IPAddress)
+		addPtr(req.query, "name", params.Name)
+		addPtr(req.query, "page", params.Page)
+		addPtr(req.query, "size", params.Size)
+		addPtr(req.query, "status", params.Status)
+		addPtr(req.query, "with_total", params.WithTotal)
+	}
+	out := new(CustomerPagLoginRecordReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetCustomerUser 查询用户
+//
+// 本接口用于查询指定ID的用户
+//
+// GET /api/v1/customer/user/{id}
+func (c *Client) GetCustomerUser(ctx context.Context, id int64) (*CustomerUserReply, error) {
+	req := &request{method: "GET", path: "/api/v1/customer/user/" + pathValue(id)}
+	out := new(CustomerUserReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateCustomerUser 更新用户
+//
+// 本接口用于更新指定ID的用户
+//
+// PUT /api/v1/customer/user/{id}
+func (c *Client) UpdateCustomerUser(ctx context.Context, id int64, body *CustomerUpdateUserRequest) (*CustomerUserReply, error) {
+	req := &request{method: "PUT", path: "/api/v1/customer/user/" + pathValue(id)}
+	req.body = body
+	out := new(CustomerUserReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteCustomerUser 删除用户
+//
+// 本接口用于删除指定ID的用户
+//
+// DELETE /api/v1/customer/user/{id}
+func (c *Client) DeleteCustomerUser(ctx context.Context, id int64) (*CommonMapAPIReply, error) {
+	req := &request{method: "DELETE", path: "/api/v1/customer/user/" + pathValue(id)}
+	out := new(CommonMapAPIReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateCustomerUserDeactivate 停用用户
+//
+// 本接口用于停用离职用户, 停用后立即终止用户的全部会话, 宽限期内可以通过更新用户重新启用,
+// 到期后永久停用. 响应中返回用户名下仍有的记录, 需要在永久停用前转移给其他用户. 不能停用当前登录的用户
+//
+// POST /api/v1/customer/user/{id}/deactivate
+func (c *Client) CreateCustomerUserDeactivate(ctx context.Context, id int64, body *CustomerDeactivateUserRequest) (*CustomerDeactivateUserReply, error) {
+	req := &request{method: "POST", path: "/api/v1/customer/user/" + pathValue(id) + "/deactivate"}
+	req.body = body
+	out := new(CustomerDeactivateUserReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateCustomerUserDepartment 设置用户所属部门
+//
+// 本接口用于设置指定用户所属的部门, 部门ID为空或0时移出部门
+//
+// PUT /api/v1/customer/user/{id}/department
+func (c *Client) UpdateCustomerUserDepartment(ctx context.Context, id int64, body *CustomerAssignDepartmentRequest) (*CustomerUserReply, error) {
+	req := &request{method: "PUT", path: "/api/v1/customer/user/" + pathValue(id) + "/department"}
+	req.body = body
+	out := new(CustomerUserReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateCustomerUserHandover 转移用户名下的记录
+//
+// 本接口用于将用户名下的记录转移给其他已启用的用户, 转移后计划任务以接收用户的身份执行
+//
+// POST /api/v1/customer/user/{id}/handover
+func (c *Client) CreateCustomerUserHandover(ctx context.Context, id int64, body *CustomerHandoverRequest) (*CustomerHandoverReply, error) {
+	req := &request{method: "POST", path: "/api/v1/customer/user/" + pathValue(id) + "/handover"}
+	req.body = body
+	out := new(CustomerHandoverReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateCustomerUserImpersonate 代为登录用户
+//
+// 本接口用于工作人员代为登录指定用户以复现权限问题, 签发的访问令牌有效期较短且不能刷新,
+// 令牌中携带管理员身份, 使用该令牌的响应带有X-Impersonated-By响应头, 全部请求记录审计记录.
+// 不能代为登录自己、其他工作人员和未激活的用户
+//
+// POST /api/v1/customer/user/{id}/impersonate
+func (c *Client) CreateCustomerUserImpersonate(ctx context.Context, id int64) (*CustomerImpersonateReply, error) {
+	req := &request{method: "POST", path: "/api/v1/customer/user/" + pathValue(id) + "/impersonate"}
+	out := new(CustomerImpersonateReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetCustomerUserOwned 查询用户名下的记录
+//
+// 本接口用于查询用户创建且仍归属于该用户的计划任务、webhook订阅等记录, 每类最多列出50条
+//
+// GET /api/v1/customer/user/{id}/owned
+func (c *Client) GetCustomerUserOwned(ctx context.Context, id int64) (*CustomerOwnedReply, error) {
+	req := &request{method: "GET", path: "/api/v1/customer/user/" + pathValue(id) + "/owned"}
+	out := new(CustomerOwnedReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetCustomerUserSessions 查询用户登录会话
+//
+// 本接口用于管理员查询指定用户未过期的登录会话
+//
+// GET /api/v1/customer/user/{id}/sessions
+func (c *Client) GetCustomerUserSessions(ctx context.Context, id int64) (*CustomerUserSessionsReply, error) {
+	req := &request{method: "GET", path: "/api/v1/customer/user/" + pathValue(id) + "/sessions"}
+	out := new(CustomerUserSessionsReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteCustomerUserSessions 终止用户全部登录会话
+//
+// 本接口用于管理员终止指定用户的全部会话, 管理员自己的当前会话除外
+//
+// DELETE /api/v1/customer/user/{id}/sessions
+func (c *Client) DeleteCustomerUserSessions(ctx context.Context, id int64) (*CustomerDeleteSessionReply, error) {
+	req := &request{method: "DELETE", path: "/api/v1/customer/user/" + pathValue(id) + "/sessions"}
+	out := new(CustomerDeleteSessionReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteCustomerUserSession 终止用户登录会话
+//
+// 本接口用于管理员终止指定用户的指定会话
+//
+// DELETE /api/v1/customer/user/{id}/sessions/{session_id}
+func (c *Client) DeleteCustomerUserSession(ctx context.Context, id int64, sessionID string) (*CommonMapAPIReply, error) {
+	req := &request{method: "DELETE", path: "/api/v1/customer/user/" + pathValue(id) + "/sessions/" + pathValue(sessionID)}
+	out := new(CommonMapAPIReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetJobsCalendarCheckParams GetJobsCalendarCheck的查询参数
+type GetJobsCalendarCheckParams struct {
+	// 日期(YYYY-MM-DD), 默认当天
+	Date *string
+}
+
+// GetJobsCalendarCheck 查询是否为交易日
+//
+// 本接口用于查询指定日期是否为交易日, 未指定日期时查询当天
+//
+// GET /api/v1/jobs/calendar/check
+func (c *Client) GetJobsCalendarCheck(ctx context.Context, params *GetJobsCalendarCheckParams) (*JobsTradingDayReply, error) {
+	req := &request{method: "GET", path: "/api/v1/jobs/calendar/check"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "date", params.Date)
+	}
+	out := new(JobsTradingDayReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListJobsCalendarHolidayParams ListJobsCalendarHoliday的查询参数
+type ListJobsCalendarHolidayParams struct {
+	// 导出的列(多个用,隔开), 默认导出全部列
+	Columns *string
+	// 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据
+	Export *string
+	// 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+	// 运算符: eq ne gt gte lt lte contains in between is_null not_null
+	// example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+	Filter *string
+	// 唯一标识
+	ID *int64
+	// "唯一标识列表(多个用,隔开)"
+	IDs *string
+	// 节假日名称
+	Name *string
+	// 分页页码
+	Page *int64
+	// 分页大小
+	Size *int64
+	// 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表
+	WithTotal *bool
+	// 年份
+	Year *int64
+}
+
+// ListJobsCalendarHoliday 查询交易日历节假日列表
+//
+// 本接口用于分页查询交易日历中的节假日
+//
+// GET /api/v1/jobs/calendar/holiday
+func (c *Client) ListJobsCalendarHoliday(ctx context.Context, params *ListJobsCalendarHolidayParams) (*JobsPagTradingHolidayReply, error) {
+	req := &request{method: "GET", path: "/api/v1/jobs/calendar/holiday"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "columns", params.Columns)
+		addPtr(req.query, "export", params.Export)
+		addPtr(req.query, "filter", params.Filter)
+		addPtr(req.query, "id", params.ID)
+		addPtr(req.query, "ids", params.IDs)
+		addPtr(req.query, "name", params.Name)
+		addPtr(req.query, "page", params.Page)
+		addPtr(req.query, "size", params.Size)
+		addPtr(req.query, "with_total", params.WithTotal)
+		addPtr(req.query, "year", params.Year)
+	}
+	out := new(JobsPagTradingHolidayReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteJobsCalendarHoliday 删除交易日历节假日
+//
+// 本接口用于删除指定ID的节假日
+//
+// DELETE /api/v1/jobs/calendar/holiday/{id}
+func (c *Client) DeleteJobsCalendarHoliday(ctx context.Context, id int64) (*CommonMapAPIReply, error) {
+	req := &request{method: "DELETE", path: "/api/v1/jobs/calendar/holiday/" + pathValue(id)}
+	out := new(CommonMapAPIReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateJobsCalendarImport 导入交易日历
+//
+// 本接口用于导入交易所发布的年度休市安排, 导入时整体替换该年份的节假日
+//
+// POST /api/v1/jobs/calendar/import
+func (c *Client) CreateJobsCalendarImport(ctx context.Context, body *JobsImportTradingCalendarRequest) (*CommonMapAPIReply, error) {
+	req := &request{method: "POST", path: "/api/v1/jobs/calendar/import"}
+	req.body = body
+	out := new(CommonMapAPIReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListJobsCalendarSkipParams ListJobsCalendarSkip的查询参数
+type ListJobsCalendarSkipParams struct {
+	// 导出的列(多个用,隔开), 默认导出全部列
+	Columns *string
+	// 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据
+	Export *string
+	// 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+	// 运算符: eq ne gt gte lt lte contains in between is_null not_null
+	// example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+	Filter *string
+	// 唯一标识
+	ID *int64
+	// "唯一标识列表(多个用,隔开)"
+	IDs *string
+	// 分页页码
+	Page *int64
+	// 跳过原因
+	Reason *string
+	// 计划任务ID
+	ScheduleID *int64
+	// 分页大小
+	Size *int64
+	// 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表
+	WithTotal *bool
+}
+
+// ListJobsCalendarSkip 查询计划任务跳过记录
+//
+// 本接口用于分页查询计划任务因非交易日被跳过的记录
+//
+// GET /api/v1/jobs/calendar/skip
+func (c *Client) ListJobsCalendarSkip(ctx context.Context, params *ListJobsCalendarSkipParams) (*JobsPagScheduleSkipReply, error) {
+	req := &request{method: "GET", path: "/api/v1/jobs/calendar/skip"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "columns", params.Columns)
+		addPtr(req.query, "export", params.Export)
+		addPtr(req.query, "filter", params.Filter)
+		addPtr(req.query, "id", params.ID)
+		addPtr(req.query, "ids", params.IDs)
+		addPtr(req.query, "page", params.Page)
+		addPtr(req.query, "reason", params.Reason)
+		addPtr(req.query, "schedule_id", params.ScheduleID)
+		addPtr(req.query, "size", params.Size)
+		addPtr(req.query, "with_total", params.WithTotal)
+	}
+	out := new(JobsPagScheduleSkipReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListJobsRecordParams ListJobsRecord的查询参数
+type ListJobsRecordParams struct {
+	// 创建时间之后的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	AfterCreatedAt *string
+	// 更新时间之后的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	AfterUpdatedAt *string
+	// 创建时间之前的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	BeforeCreatedAt *string
+	// 更新时间之前的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	BeforeUpdatedAt *string
+	// 导出的列(多个用,隔开), 默认导出全部列
+	Columns *string
+	// 按脚本退出码筛选
+	ExitCode *int64
+	// 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据
+	Export *string
+	// 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+	// 运算符: eq ne gt gte lt lte contains in between is_null not_null
+	// example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+	Filter *string
+	// 唯一标识
+	ID *int64
+	// "唯一标识列表(多个用,隔开)"
+	IDs *string
+	// 分页页码
+	Page *int64
+	// 按脚本ID筛选
+	ScriptID *int64
+	// 分页大小
+	Size *int64
+	// 筛选脚本执行的任务状态
+	Status *int64
+	// 筛选计划任务触发类型
+	TriggerType *string
+	// 按用户名筛选
+	Username *string
+	// 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表
+	WithTotal *bool
+}
+
+// ListJobsRecord 查询脚本执行记录列表
+//
+// 本接口用于查询脚本执行记录列表, 支持通过filter参数按字段组合过滤, 指定export参数时按columns选择的列导出为csv或xlsx文件
+//
+// GET /api/v1/jobs/record
+func (c *Client) ListJobsRecord(ctx context.Context, params *ListJobsRecordParams) (*JobsPagScriptRecordReply, error) {
+	req := &request{method: "GET", path: "/api/v1/jobs/record"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "after_created_at", params.AfterCreatedAt)
+		addPtr(req.query, "after_updated_at", params.AfterUpdatedAt)
+		addPtr(req.query, "before_created_at", params.BeforeCreatedAt)
+		addPtr(req.query, "before_updated_at", params.BeforeUpdatedAt)
+		addPtr(req.query, "columns", params.Columns)
+		addPtr(req.query, "exit_code", params.ExitCode)
+		addPtr(req.query, "export", params.Export)
+		addPtr(req.query, "filter", params.Filter)
+		addPtr(req.query, "id", params.ID)
+		addPtr(req.query, "ids", params.IDs)
+		addPtr(req.query, "page", params.Page)
+		addPtr(req.query, "script_id", params.ScriptID)
+		addPtr(req.query, "size", params.Size)
+		addPtr(req.query, "status", params.Status)
+		addPtr(req.query, "trigger_type", params.TriggerType)
+		addPtr(req.query, "username", params.Username)
+		addPtr(req.query, "with_total", params.WithTotal)
+	}
+	out := new(JobsPagScriptRecordReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateJobsRecord 执行脚本
+//
+// 本接口用于执行指定的脚本并记录执行结果
+//
+// POST /api/v1/jobs/record
+func (c *Client) CreateJobsRecord(ctx context.Context, body *JobsCreateScriptRecordRequest) (*JobsScriptRecordReply, error) {
+	req := &request{method: "POST", path: "/api/v1/jobs/record"}
+	req.body = body
+	out := new(JobsScriptRecordReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetJobsRecord 查询脚本执行记录详情
+//
+// 本接口用于查询指定ID的脚本执行记录详情
+//
+// GET /api/v1/jobs/record/{id}
+func (c *Client) GetJobsRecord(ctx context.Context, id int64) (*JobsScriptRecordReply, error) {
+	req := &request{method: "GET", path: "/api/v1/jobs/record/" + pathValue(id)}
+	out := new(JobsScriptRecordReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteJobsRecord 对正在执行的脚本发送终止信号
+//
+// 本接口用于通过执行记录的id号,对正在执行的脚本发送终止信号
+//
+// DELETE /api/v1/jobs/record/{id}
+func (c *Client) DeleteJobsRecord(ctx context.Context, id int64) (*CommonMapAPIReply, error) {
+	req := &request{method: "DELETE", path: "/api/v1/jobs/record/" + pathValue(id)}
+	out := new(CommonMapAPIReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListJobsRecordArtifacts 查询执行产物列表
+//
+// 本接口用于查询指定执行记录收集的产物文件
+//
+// GET /api/v1/jobs/record/{id}/artifacts
+func (c *Client) ListJobsRecordArtifacts(ctx context.Context, id int64) (*JobsListArtifactReply, error) {
+	req := &request{method: "GET", path: "/api/v1/jobs/record/" + pathValue(id) + "/artifacts"}
+	out := new(JobsListArtifactReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetJobsRecordArtifacts 下载执行产物
+//
+// 本接口用于下载指定执行记录收集的产物文件
+//
+// 返回原始响应体, 调用方读取完毕后需要关闭
+//
+// GET /api/v1/jobs/record/{id}/artifacts/{artifact_id}
+func (c *Client) GetJobsRecordArtifacts(ctx context.Context, id int64, artifactID int64) (io.ReadCloser, error) {
+	req := &request{method: "GET", path: "/api/v1/jobs/record/" + pathValue(id) + "/artifacts/" + pathValue(artifactID)}
+	return c.doStream(ctx, req)
+}
+
+// GetJobsRecordLog 下载脚本执行日志
+//
+// 本接口用于下载指定执行记录的日志文件
+//
+// 返回原始响应体, 调用方读取完毕后需要关闭
+//
+// GET /api/v1/jobs/record/{id}/log
+func (c *Client) GetJobsRecordLog(ctx context.Context, id int64) (io.ReadCloser, error) {
+	req := &request{method: "GET", path: "/api/v1/jobs/record/" + pathValue(id) + "/log"}
+	return c.doStream(ctx, req)
+}
+
+// GetJobsRecordLogStream 实时获取脚本执行日志
+//
+// 本接口用于实时获取指定执行记录的日志内容
+//
+// 返回原始响应体, 调用方读取完毕后需要关闭
+//
+// GET /api/v1/jobs/record/{id}/log/stream
+func (c *Client) GetJobsRecordLogStream(ctx context.Context, id int64) (io.ReadCloser, error) {
+	req := &request{method: "GET", path: "/api/v1/jobs/record/" + pathValue(id) + "/log/stream"}
+	return c.doStream(ctx, req)
+}
+
+// GetJobsRecordReveal 查看脚本执行记录环境变量
+//
+// 本接口用于查看指定ID的脚本执行记录环境变量明文, 需要单独授权
+//
+// GET /api/v1/jobs/record/{id}/reveal
+func (c *Client) GetJobsRecordReveal(ctx context.Context, id int64) (*JobsEnvVarsReply, error) {
+	req := &request{method: "GET", path: "/api/v1/jobs/record/" + pathValue(id) + "/reveal"}
+	out := new(JobsEnvVarsReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListJobsScheduleParams ListJobsSchedule的查询参数
+type ListJobsScheduleParams struct {
+	// 创建时间之后的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	AfterCreatedAt *string
+	// 更新时间之后的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	AfterUpdatedAt *string
+	// 创建时间之前的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	BeforeCreatedAt *string
+	// 更新时间之前的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	BeforeUpdatedAt *string
+	// 导出的列(多个用,隔开), 默认导出全部列
+	Columns *string
+	// 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据
+	Export *string
+	// 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+	// 运算符: eq ne gt gte lt lte contains in between is_null not_null
+	// example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+	Filter *string
+	// 唯一标识
+	ID *int64
+	// "唯一标识列表(多个用,隔开)"
+	IDs *string
+	// 是否启用
+	IsEnabled *bool
+	// 名称
+	Name *string
+	// 分页页码
+	Page *int64
+	// 脚本ID
+	ScriptID *int64
+	// 分页大小
+	Size *int64
+	// 用户名
+	Username *string
+	// 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表
+	WithTotal *bool
+}
+
+// ListJobsSchedule 查询计划任务列表
+//
+// 本接口用于查询计划任务列表
+//
+// GET /api/v1/jobs/schedule
+func (c *Client) ListJobsSchedule(ctx context.Context, params *ListJobsScheduleParams) (*JobsPagScheduleReply, error) {
+	req := &request{method: "GET", path: "/api/v1/jobs/schedule"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "after_created_at", params.AfterCreatedAt)
+		addPtr(req.query, "after_updated_at", params.AfterUpdatedAt)
+		addPtr(req.query, "before_created_at", params.BeforeCreatedAt)
+		addPtr(req.query, "before_updated_at", params.BeforeUpdatedAt)
+		addPtr(req.query, "columns", params.Columns)
+		addPtr(req.query, "export", params.Export)
+		addPtr(req.query, "filter", params.Filter)
+		addPtr(req.query, "id", params.ID)
+		addPtr(req.query, "ids", params.IDs)
+		addPtr(req.query, "is_enabled", params.IsEnabled)
+		addPtr(req.query, "name", params.Name)
+		addPtr(req.query, "page", params.Page)
+		addPtr(req.query, "script_id", params.ScriptID)
+		addPtr(req.query, "size", params.Size)
+		addPtr(req.query, "username", params.Username)
+		addPtr(req.query, "with_total", params.WithTotal)
+	}
+	out := new(JobsPagScheduleReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateJobsSchedule 创建计划任务
+//
+// 本接口用于创建新的计划任务
+//
+// POST /api/v1/jobs/schedule
+func (c *Client) CreateJobsSchedule(ctx context.Context, body *JobsCreateScheduleRequest) (*JobsScheduleReply, error) {
+	req := &request{method: "POST", path: "/api/v1/jobs/schedule"}
+	req.body = body
+	out := new(JobsScheduleReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetJobsSchedule 查询计划任务详情
+//
+// 本接口用于查询指定ID的计划任务详情
+//
+// GET /api/v1/jobs/schedule/{id}
+func (c *Client) GetJobsSchedule(ctx context.Context, id int64) (*JobsScheduleReply, error) {
+	req := &request{method: "GET", path: "/api/v1/jobs/schedule/" + pathValue(id)}
+	out := new(JobsScheduleReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateJobsSchedule 更新计划任务
+//
+// 本接口用于更新指定ID的计划任务
+//
+// PUT /api/v1/jobs/schedule/{id}
+func (c *Client) UpdateJobsSchedule(ctx context.Context, id int64, body *JobsUpdateScheduleRequest) (*JobsScheduleReply, error) {
+	req := &request{method: "PUT", path: "/api/v1/jobs/schedule/" + pathValue(id)}
+	req.body = body
+	out := new(JobsScheduleReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteJobsSchedule 删除计划任务
+//
+// 本接口用于删除指定ID的计划任务
+//
+// DELETE /api/v1/jobs/schedule/{id}
+func (c *Client) DeleteJobsSchedule(ctx context.Context, id int64) (*CommonMapAPIReply, error) {
+	req := &request{method: "DELETE", path: "/api/v1/jobs/schedule/" + pathValue(id)}
+	out := new(CommonMapAPIReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetJobsScheduleEnvPreview 预览计划任务的环境变量
+//
+// 本接口用于预览计划任务触发时注入的环境变量, 绑定oes集群时按当前的集群数据解析集群上下文变量
+//
+// GET /api/v1/jobs/schedule/{id}/env/preview
+func (c *Client) GetJobsScheduleEnvPreview(ctx context.Context, id int64) (*JobsEnvPreviewReply, error) {
+	req := &request{method: "GET", path: "/api/v1/jobs/schedule/" + pathValue(id) + "/env/preview"}
+	out := new(JobsEnvPreviewReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetJobsScheduleReveal 查看计划任务环境变量
+//
+// 本接口用于查看指定ID的计划任务环境变量明文, 需要单独授权
+//
+// GET /api/v1/jobs/schedule/{id}/reveal
+func (c *Client) GetJobsScheduleReveal(ctx context.Context, id int64) (*JobsEnvVarsReply, error) {
+	req := &request{method: "GET", path: "/api/v1/jobs/schedule/" + pathValue(id) + "/reveal"}
+	out := new(JobsEnvVarsReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListJobsScriptParams ListJobsScript的查询参数
+type ListJobsScriptParams struct {
+	// 创建时间之后的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	AfterCreatedAt *string
+	// 更新时间之后的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	AfterUpdatedAt *string
+	// 创建时间之前的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	BeforeCreatedAt *string
+	// 更新时间之前的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	BeforeUpdatedAt *string
+	// 导出的列(多个用,隔开), 默认导出全部列
+	Columns *string
+	// 描述信息
+	Descr *string
+	// 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据
+	Export *string
+	// 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+	// 运算符: eq ne gt gte lt lte contains in between is_null not_null
+	// example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+	Filter *string
+	// 唯一标识
+	ID *int64
+	// "唯一标识列表(多个用,隔开)"
+	IDs *string
+	// 是否是内置脚本
+	IsBuiltin *bool
+	// 标签
+	Label *string
+	// 脚本语言
+	Language *string
+	// 名称
+	Name *string
+	// 分页页码
+	Page *int64
+	// 项目
+	Project *string
+	// 分页大小
+	Size *int64
+	// 状态
+	Status *bool
+	// 最后修改的用户
+	UserID *int64
+	// 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表
+	WithTotal *bool
+}
+
+// ListJobsScript 查询脚本列表
+//
+// 本接口用于查询脚本列表
+//
+// GET /api/v1/jobs/script
+func (c *Client) ListJobsScript(ctx context.Context, params *ListJobsScriptParams) (*JobsPagScriptReply, error) {
+	req := &request{method: "GET", path: "/api/v1/jobs/script"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "after_created_at", params.AfterCreatedAt)
+		addPtr(req.query, "after_updated_at", params.AfterUpdatedAt)
+		addPtr(req.query, "before_created_at", params.BeforeCreatedAt)
+		addPtr(req.query, "before_updated_at", params.BeforeUpdatedAt)
+		addPtr(req.query, "columns", params.Columns)
+		addPtr(req.query, "descr", params.Descr)
+		addPtr(req.query, "export", params.Export)
+		addPtr(req.query, "filter", params.Filter)
+		addPtr(req.query, "id", params.ID)
+		addPtr(req.query, "ids", params.IDs)
+		addPtr(req.query, "is_builtin", params.IsBuiltin)
+		addPtr(req.query, "label", params.Label)
+		addPtr(req.query, "language", params.Language)
+		addPtr(req.query, "name", params.Name)
+		addPtr(req.query, "page", params.Page)
+		addPtr(req.query, "project", params.Project)
+		addPtr(req.query, "size", params.Size)
+		addPtr(req.query, "status", params.Status)
+		addPtr(req.query, "user_id", params.UserID)
+		addPtr(req.query, "with_total", params.WithTotal)
+	}
+	out := new(JobsPagScriptReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateJobsScriptForm CreateJobsScript的表单
+type CreateJobsScriptForm struct {
+	// 脚本文件
+	File *File
+	// 脚本描述
+	Descr *string
+	// 项目名称
+	Project string
+	// 标签
+	Label *string
+	// 脚本语言
+	Language string
+	// 脚本状态
+	Status bool
+	// 参数定义(JSON数组)
+	Params *string
+	// 隔离执行配置(JSON对象)
+	Isolation *string
+	// 产物文件匹配规则(JSON数组)
+	Artifacts *string
+}
+
+// CreateJobsScript 上传脚本
+//
+// 本接口用于上传新的脚本文件
+//
+// POST /api/v1/jobs/script
+func (c *Client) CreateJobsScript(ctx context.Context, form *CreateJobsScriptForm) (*JobsScriptReply, error) {
+	req := &request{method: "POST", path: "/api/v1/jobs/script"}
+	req.form = &multipartForm{values: url.Values{}}
+	if form != nil {
+		req.form.addFile("file", form.File)
+		addPtr(req.form.values, "descr", form.Descr)
+		addValue(req.form.values, "project", form.Project)
+		addPtr(req.form.values, "label", form.Label)
+		addValue(req.form.values, "language", form.Language)
+		addValue(req.form.values, "status", form.Status)
+		addPtr(req.form.values, "params", form.Params)
+		addPtr(req.form.values, "isolation", form.Isolation)
+		addPtr(req.form.values, "artifacts", form.Artifacts)
+	}
+	out := new(JobsScriptReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListJobsScriptLabelParams ListJobsScriptLabel的查询参数
+type ListJobsScriptLabelParams struct {
+	// 创建时间之后的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	AfterCreatedAt *string
+	// 更新时间之后的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	AfterUpdatedAt *string
+	// 创建时间之前的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	BeforeCreatedAt *string
+	// 更新时间之前的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	BeforeUpdatedAt *string
+	// 导出的列(多个用,隔开), 默认导出全部列
+	Columns *string
+	// 描述信息
+	Descr *string
+	// 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据
+	Export *string
+	// 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+	// 运算符: eq ne gt gte lt lte contains in between is_null not_null
+	// example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+	Filter *string
+	// 唯一标识
+	ID *int64
+	// "唯一标识列表(多个用,隔开)"
+	IDs *string
+	// 是否是内置脚本
+	IsBuiltin *bool
+	// 标签
+	Label *string
+	// 脚本语言
+	Language *string
+	// 名称
+	Name *string
+	// 分页页码
+	Page *int64
+	// 项目
+	Project *string
+	// 分页大小
+	Size *int64
+	// 状态
+	Status *bool
+	// 最后修改的用户
+	UserID *int64
+	// 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表
+	WithTotal *bool
+}
+
+// ListJobsScriptLabel 查询标签列表
+//
+// 本接口用于查询标签列表
+//
+// GET /api/v1/jobs/script/label
+func (c *Client) ListJobsScriptLabel(ctx context.Context, params *ListJobsScriptLabelParams) (*JobsListLableReply, error) {
+	req := &request{method: "GET", path: "/api/v1/jobs/script/label"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "after_created_at", params.AfterCreatedAt)
+		addPtr(req.query, "after_updated_at", params.AfterUpdatedAt)
+		addPtr(req.query, "before_created_at", params.BeforeCreatedAt)
+		addPtr(req.query, "before_updated_at", params.BeforeUpdatedAt)
+		addPtr(req.query, "columns", params.Columns)
+		addPtr(req.query, "descr", params.Descr)
+		addPtr(req.query, "export", params.Export)
+		addPtr(req.query, "filter", params.Filter)
+		addPtr(req.query, "id", params.ID)
+		addPtr(req.query, "ids", params.IDs)
+		addPtr(req.query, "is_builtin", params.IsBuiltin)
+		addPtr(req.query, "label", params.Label)
+		addPtr(req.query, "language", params.Language)
+		addPtr(req.query, "name", params.Name)
+		addPtr(req.query, "page", params.Page)
+		addPtr(req.query, "project", params.Project)
+		addPtr(req.query, "size", params.Size)
+		addPtr(req.query, "status", params.Status)
+		addPtr(req.query, "user_id", params.UserID)
+		addPtr(req.query, "with_total", params.WithTotal)
+	}
+	out := new(JobsListLableReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListJobsScriptProjectParams ListJobsScriptProject的查询参数
+type ListJobsScriptProjectParams struct {
+	// 创建时间之后的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	AfterCreatedAt *string
+	// 更新时间之后的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	AfterUpdatedAt *string
+	// 创建时间之前的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	BeforeCreatedAt *string
+	// 更新时间之前的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	BeforeUpdatedAt *string
+	// 导出的列(多个用,隔开), 默认导出全部列
+	Columns *string
+	// 描述信息
+	Descr *string
+	// 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据
+	Export *string
+	// 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+	// 运算符: eq ne gt gte lt lte contains in between is_null not_null
+	// example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+	Filter *string
+	// 唯一标识
+	ID *int64
+	// "唯一标识列表(多个用,隔开)"
+	IDs *string
+	// 是否是内置脚本
+	IsBuiltin *bool
+	// 标签
+	Label *string
+	// 脚本语言
+	Language *string
+	// 名称
+	Name *string
+	// 分页页码
+	Page *int64
+	// 项目
+	Project *string
+	// 分页大小
+	Size *int64
+	// 状态
+	Status *bool
+	// 最后修改的用户
+	UserID *int64
+	// 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表
+	WithTotal *bool
+}
+
+// ListJobsScriptProject 查询项目列表
+//
+// 本接口用于查询项目列表
+//
+// GET /api/v1/jobs/script/project
+func (c *Client) ListJobsScriptProject(ctx context.Context, params *ListJobsScriptProjectParams) (*JobsListProjectReply, error) {
+	req := &request{method: "GET", path: "/api/v1/jobs/script/project"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "after_created_at", params.AfterCreatedAt)
+		addPtr(req.query, "after_updated_at", params.AfterUpdatedAt)
+		addPtr(req.query, "before_created_at", params.BeforeCreatedAt)
+		addPtr(req.query, "before_updated_at", params.BeforeUpdatedAt)
+		addPtr(req.query, "columns", params.Columns)
+		addPtr(req.query, "descr", params.Descr)
+		addPtr(req.query, "export", params.Export)
+		addPtr(req.query, "filter", params.Filter)
+		addPtr(req.query, "id", params.ID)
+		addPtr(req.query, "ids", params.IDs)
+		addPtr(req.query, "is_builtin", params.IsBuiltin)
+		addPtr(req.query, "label", params.Label)
+		addPtr(req.query, "language", params.Language)
+		addPtr(req.query, "name", params.Name)
+		addPtr(req.query, "page", params.Page)
+		addPtr(req.query, "project", params.Project)
+		addPtr(req.query, "size", params.Size)
+		addPtr(req.query, "status", params.Status)
+		addPtr(req.query, "user_id", params.UserID)
+		addPtr(req.query, "with_total", params.WithTotal)
+	}
+	out := new(JobsListProjectReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetJobsScript 查询脚本详情
+//
+// 本接口用于查询指定ID的脚本详情
+//
+// GET /api/v1/jobs/script/{id}
+func (c *Client) GetJobsScript(ctx context.Context, id int64) (*JobsScriptReply, error) {
+	req := &request{method: "GET", path: "/api/v1/jobs/script/" + pathValue(id)}
+	out := new(JobsScriptReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateJobsScriptForm UpdateJobsScript的表单
+type UpdateJobsScriptForm struct {
+	// 脚本文件
+	File *File
+	// 脚本描述
+	Descr *string
+	// 项目名称
+	Project string
+	// 标签
+	Label *string
+	// 脚本语言
+	Language string
+	// 脚本状态
+	Status bool
+	// 参数定义(JSON数组)
+	Params *string
+	// 隔离执行配置(JSON对象)
+	Isolation *string
+	// 产物文件匹配规则(JSON数组)
+	Artifacts *string
+}
+
+// UpdateJobsScript 更新脚本
+//
+// 本接口用于更新指定ID的脚本文件
+//
+// PUT /api/v1/jobs/script/{id}
+func (c *Client) UpdateJobsScript(ctx context.Context, id int64, form *UpdateJobsScriptForm) (*JobsScriptReply, error) {
+	req := &request{method: "PUT", path: "/api/v1/jobs/script/" + pathValue(id)}
+	req.form = &multipartForm{values: url.Values{}}
+	if form != nil {
+		req.form.addFile("file", form.File)
+		addPtr(req.form.values, "descr", form.Descr)
+		addValue(req.form.values, "project", form.Project)
+		addPtr(req.form.values, "label", form.Label)
+		addValue(req.form.values, "language", form.Language)
+		addValue(req.form.values, "status", form.Status)
+		addPtr(req.form.values, "params", form.Params)
+		addPtr(req.form.values, "isolation", form.Isolation)
+		addPtr(req.form.values, "artifacts", form.Artifacts)
+	}
+	out := new(JobsScriptReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteJobsScript 删除脚本
+//
+// 本接口用于删除指定ID的脚本
+//
+// DELETE /api/v1/jobs/script/{id}
+func (c *Client) DeleteJobsScript(ctx context.Context, id int64) (*CommonMapAPIReply, error) {
+	req := &request{method: "DELETE", path: "/api/v1/jobs/script/" + pathValue(id)}
+	out := new(CommonMapAPIReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetJobsScriptDownload 下载脚本
+//
+// 本接口用于下载指定ID的脚本文件
+//
+// 返回原始响应体, 调用方读取完毕后需要关闭
+//
+// GET /api/v1/jobs/script/{id}/download
+func (c *Client) GetJobsScriptDownload(ctx context.Context, id int64) (io.ReadCloser, error) {
+	req := &request{method: "GET", path: "/api/v1/jobs/script/" + pathValue(id) + "/download"}
+	return c.doStream(ctx, req)
+}
+
+// CreateJobsScriptValidate 检查脚本
+//
+// 本接口用于在调度到生产环境之前检查脚本, 包括解释器行、换行符、解释器语法检查和shellcheck(已安装时)
+// dry_run为true时在配置的沙箱中试运行脚本, 试运行设置环境变量JOBS_DRY_RUN=1, 未配置沙箱时返回错误
+//
+// POST /api/v1/jobs/script/{id}/validate
+func (c *Client) CreateJobsScriptValidate(ctx context.Context, id int64, body *JobsValidateScriptRequest) (*JobsScriptValidateReply, error) {
+	req := &request{method: "POST", path: "/api/v1/jobs/script/" + pathValue(id) + "/validate"}
+	req.body = body
+	out := new(JobsScriptValidateReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateLogin 登陆接口
+//
+// 本接口用于登陆, 开启Cookie认证时令牌同时写入HttpOnly Cookie
+//
+// POST /api/v1/login
+func (c *Client) CreateLogin(ctx context.Context, body *CustomerLoginRequest) (*CustomerLoginReply, error) {
+	req := &request{method: "POST", path: "/api/v1/login"}
+	req.body = body
+	out := new(CustomerLoginReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateLogout 退出登录接口
+//
+// 本接口用于退出登录, 终止刷新令牌或访问令牌所属的会话, 会话的令牌立即失效; 开启Cookie认证时请求参数可以为空, 从Cookie中读取令牌, 并删除令牌和CSRF令牌Cookie
+//
+// POST /api/v1/logout
+func (c *Client) CreateLogout(ctx context.Context, body *CustomerRefreshTokenRequest) (*CommonMapAPIReply, error) {
+	req := &request{method: "POST", path: "/api/v1/logout"}
+	req.body = body
+	out := new(CommonMapAPIReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListMdsBackfillParams ListMdsBackfill的查询参数
+type ListMdsBackfillParams struct {
+	// 集群号
+	ColonyNum *string
+	// 导出的列(多个用,隔开), 默认导出全部列
+	Columns *string
+	// 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据
+	Export *string
+	// 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+	// 运算符: eq ne gt gte lt lte contains in between is_null not_null
+	// example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+	Filter *string
+	// 唯一标识
+	ID *int64
+	// "唯一标识列表(多个用,隔开)"
+	IDs *string
+	// mds集群ID
+	MdsColonyID *int64
+	// 分页页码
+	Page *int64
+	// 分页大小
+	Size *int64
+	// 执行状态
+	Status *string
+	// 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表
+	WithTotal *bool
+}
+
+// ListMdsBackfill 查询mds数据回补列表
+//
+// 本接口用于分页查询数据回补及各状态的交易日数
+//
+// GET /api/v1/mds/backfill
+func (c *Client) ListMdsBackfill(ctx context.Context, params *ListMdsBackfillParams) (*MdsPagMdsBackfillRunReply, error) {
+	req := &request{method: "GET", path: "/api/v1/mds/backfill"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "colony_num", params.ColonyNum)
+		addPtr(req.query, "columns", params.Columns)
+		addPtr(req.query, "export", params.Export)
+		addPtr(req.query, "filter", params.Filter)
+		addPtr(req.query, "id", params.ID)
+		addPtr(req.query, "ids", params.IDs)
+		addPtr(req.query, "mds_colony_id", params.MdsColonyID)
+		addPtr(req.query, "page", params.Page)
+		addPtr(req.query, "size", params.Size)
+		addPtr(req.query, "status", params.Status)
+		addPtr(req.query, "with_total", params.WithTotal)
+	}
+	out := new(MdsPagMdsBackfillRunReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateMdsBackfill 发起mds数据回补
+//
+// 本接口用于按日期范围为集群发起数据回补, 日期范围内的每个交易日执行一次回放脚本, 回补在后台执行
+//
+// POST /api/v1/mds/backfill
+func (c *Client) CreateMdsBackfill(ctx context.Context, body *MdsCreateMdsBackfillRequest) (*MdsMdsBackfillRunReply, error) {
+	req := &request{method: "POST", path: "/api/v1/mds/backfill"}
+	req.body = body
+	out := new(MdsMdsBackfillRunReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetMdsBackfill 查询mds数据回补详情
+//
+// 本接口用于查询数据回补的汇总状态及每个交易日的回放状态
+//
+// GET /api/v1/mds/backfill/{id}
+func (c *Client) GetMdsBackfill(ctx context.Context, id int64) (*MdsMdsBackfillRunReply, error) {
+	req := &request{method: "GET", path: "/api/v1/mds/backfill/" + pathValue(id)}
+	out := new(MdsMdsBackfillRunReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateMdsBackfillCancel 取消mds数据回补
+//
+// 本接口用于取消正在执行的数据回补, 正在执行的回放脚本会被终止, 未开始的交易日标记为已取消
+//
+// POST /api/v1/mds/backfill/{id}/cancel
+func (c *Client) CreateMdsBackfillCancel(ctx context.Context, id int64) (*CommonMapAPIReply, error) {
+	req := &request{method: "POST", path: "/api/v1/mds/backfill/" + pathValue(id) + "/cancel"}
+	out := new(CommonMapAPIReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateMdsBackfillRetry 重试mds数据回补
+//
+// 本接口用于重新执行已结束的数据回补中失败、跳过或取消的交易日, 已成功的交易日不再执行
+//
+// POST /api/v1/mds/backfill/{id}/retry
+func (c *Client) CreateMdsBackfillRetry(ctx context.Context, id int64) (*MdsMdsBackfillRunReply, error) {
+	req := &request{method: "POST", path: "/api/v1/mds/backfill/" + pathValue(id) + "/retry"}
+	out := new(MdsMdsBackfillRunReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListMdsColonyParams ListMdsColony的查询参数
+type ListMdsColonyParams struct {
+	// 页码
+	Page *int64
+	// 每页数量
+	Size *int64
+	// mds集群名称
+	Name *string
+	// 是否启用
+	IsEnabled *bool
+	// 创建用户名
+	Username *string
+	// 导出格式
+	Export *string
+	// 导出的列(多个用,隔开)
+	Columns *string
+}
+
+// ListMdsColony 查询mds集群列表
+//
+// 本接口用于查询mds集群列表, 指定export参数时按columns选择的列导出为csv或xlsx文件
+//
+// GET /api/v1/mds/colony
+func (c *Client) ListMdsColony(ctx context.Context, params *ListMdsColonyParams) (*MdsPagMdsColonyReply, error) {
+	req := &request{method: "GET", path: "/api/v1/mds/colony"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "page", params.Page)
+		addPtr(req.query, "size", params.Size)
+		addPtr(req.query, "name", params.Name)
+		addPtr(req.query, "is_enabled", params.IsEnabled)
+		addPtr(req.query, "username", params.Username)
+		addPtr(req.query, "export", params.Export)
+		addPtr(req.query, "columns", params.Columns)
+	}
+	out := new(MdsPagMdsColonyReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateMdsColony 创建mds集群
+//
+// 本接口用于创建新的mds集群
+//
+// POST /api/v1/mds/colony
+func (c *Client) CreateMdsColony(ctx context.Context, body *MdsCreateOrUpdateMdsColonyRequest) (*MdsMdsColonyReply, error) {
+	req := &request{method: "POST", path: "/api/v1/mds/colony"}
+	req.body = body
+	out := new(MdsMdsColonyReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListMdsColonyStatusParams ListMdsColonyStatus的查询参数
+type ListMdsColonyStatusParams struct {
+	// 创建时间之后的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	AfterCreatedAt *string
+	// 更新时间之后的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	AfterUpdatedAt *string
+	// 创建时间之前的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	BeforeCreatedAt *string
+	// 更新时间之前的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	BeforeUpdatedAt *string
+	// 集群号
+	ColonyNum *string
+	// 导出的列(多个用,隔开), 默认导出全部列
+	Columns *string
+	// 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据
+	Export *string
+	// 解压后名称
+	ExtractedName *string
+	// 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+	// 运算符: eq ne gt gte lt lte contains in between is_null not_null
+	// example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+	Filter *string
+	// 唯一标识
+	ID *int64
+	// "唯一标识列表(多个用,隔开)"
+	IDs *string
+	// 是否启用
+	IsEnable *bool
+	// mon节点ID
+	MonNodeID *int64
+	// 程序包ID
+	PackageID *int64
+	// 分页页码
+	Page *int64
+	// 分页大小
+	Size *int64
+	// 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表
+	WithTotal *bool
+}
+
+// ListMdsColonyStatus 查询mds集群列表的任务状态
+//
+// 本接口用于查询mds集群列表的任务状态
+//
+// GET /api/v1/mds/colony/status
+func (c *Client) ListMdsColonyStatus(ctx context.Context, params *ListMdsColonyStatusParams) (*MdsListMdsTasksInfoReply, error) {
+	req := &request{method: "GET", path: "/api/v1/mds/colony/status"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "after_created_at", params.AfterCreatedAt)
+		addPtr(req.query, "after_updated_at", params.AfterUpdatedAt)
+		addPtr(req.query, "before_created_at", params.BeforeCreatedAt)
+		addPtr(req.query, "before_updated_at", params.BeforeUpdatedAt)
+		addPtr(req.query, "colony_num", params.ColonyNum)
+		addPtr(req.query, "columns", params.Columns)
+		addPtr(req.query, "export", params.Export)
+		addPtr(req.query, "extracted_name", params.ExtractedName)
+		addPtr(req.query, "filter", params.Filter)
+		addPtr(req.query, "id", params.ID)
+		addPtr(req.query, "ids", params.IDs)
+		addPtr(req.query, "is_enable", params.IsEnable)
+		addPtr(req.query, "mon_node_id", params.MonNodeID)
+		addPtr(req.query, "package_id", params.PackageID)
+		addPtr(req.query, "page", params.Page)
+		addPtr(req.query, "size", params.Size)
+		addPtr(req.query, "with_total", params.WithTotal)
+	}
+	out := new(MdsListMdsTasksInfoReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetMdsColony 查询mds集群详情
+//
+// 本接口用于查询指定ID的mds集群详情
+//
+// GET /api/v1/mds/colony/{id}
+func (c *Client) GetMdsColony(ctx context.Context, id int64) (*MdsMdsColonyReply, error) {
+	req := &request{method: "GET", path: "/api/v1/mds/colony/" + pathValue(id)}
+	out := new(MdsMdsColonyReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateMdsColony 更新mds集群
+//
+// 本接口用于更新指定ID的mds集群
+//
+// PUT /api/v1/mds/colony/{id}
+func (c *Client) UpdateMdsColony(ctx context.Context, id int64, body *MdsCreateOrUpdateMdsColonyRequest) (*MdsMdsColonyReply, error) {
+	req := &request{method: "PUT", path: "/api/v1/mds/colony/" + pathValue(id)}
+	req.body = body
+	out := new(MdsMdsColonyReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteMdsColony 删除mds集群
+//
+// 本接口用于删除指定ID的mds集群
+//
+// DELETE /api/v1/mds/colony/{id}
+func (c *Client) DeleteMdsColony(ctx context.Context, id int64) (*CommonMapAPIReply, error) {
+	req := &request{method: "DELETE", path: "/api/v1/mds/colony/" + pathValue(id)}
+	out := new(CommonMapAPIReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetMdsIngestDay 查询交易日采集状态
+//
+// 本接口用于查询指定交易日每个启用的数据源的采集状态, 尚未采集的数据源状态为pending
+//
+// GET /api/v1/mds/ingest/day/{trading_day}
+func (c *Client) GetMdsIngestDay(ctx context.Context, tradingDay string) (*MdsIngestDayReply, error) {
+	req := &request{method: "GET", path: "/api/v1/mds/ingest/day/" + pathValue(tradingDay)}
+	out := new(MdsIngestDayReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListMdsIngestRecordParams ListMdsIngestRecord的查询参数
+type ListMdsIngestRecordParams struct {
+	// 创建时间之后的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	AfterCreatedAt *string
+	// 更新时间之后的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	AfterUpdatedAt *string
+	// 创建时间之前的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	BeforeCreatedAt *string
+	// 更新时间之前的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	BeforeUpdatedAt *string
+	// 导出的列(多个用,隔开), 默认导出全部列
+	Columns *string
+	// 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据
+	Export *string
+	// 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+	// 运算符: eq ne gt gte lt lte contains in between is_null not_null
+	// example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+	Filter *string
+	// 唯一标识
+	ID *int64
+	// "唯一标识列表(多个用,隔开)"
+	IDs *string
+	// 分页页码
+	Page *int64
+	// 分页大小
+	Size *int64
+	// 数据源ID
+	SourceID *int64
+	// 状态
+	Status *string
+	// 交易日
+	TradingDay *string
+	// 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表
+	WithTotal *bool
+}
+
+// ListMdsIngestRecord 查询采集记录列表
+//
+// 本接口用于按数据源、交易日和状态查询采集记录列表
+//
+// GET /api/v1/mds/ingest/record
+func (c *Client) ListMdsIngestRecord(ctx context.Context, params *ListMdsIngestRecordParams) (*MdsPagIngestRecordReply, error) {
+	req := &request{method: "GET", path: "/api/v1/mds/ingest/record"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "after_created_at", params.AfterCreatedAt)
+		addPtr(req.query, "after_updated_at", params.AfterUpdatedAt)
+		addPtr(req.query, "before_created_at", params.BeforeCreatedAt)
+		addPtr(req.query, "before_updated_at", params.BeforeUpdatedAt)
+		addPtr(req.query, "columns", params.Columns)
+		addPtr(req.query, "export", params.Export)
+		addPtr(req.query, "filter", params.Filter)
+		addPtr(req.query, "id", params.ID)
+		addPtr(req.query, "ids", params.IDs)
+		addPtr(req.query, "page", params.Page)
+		addPtr(req.query, "size", params.Size)
+		addPtr(req.query, "source_id", params.SourceID)
+		addPtr(req.query, "status", params.Status)
+		addPtr(req.query, "trading_day", params.TradingDay)
+		addPtr(req.query, "with_total", params.WithTotal)
+	}
+	out := new(MdsPagIngestRecordReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetMdsIngestRecord 查询采集记录详情
+//
+// 本接口用于查询指定ID的采集记录, 包含校验错误和核对结果
+//
+// GET /api/v1/mds/ingest/record/{id}
+func (c *Client) GetMdsIngestRecord(ctx context.Context, id int64) (*MdsIngestRecordReply, error) {
+	req := &request{method: "GET", path: "/api/v1/mds/ingest/record/" + pathValue(id)}
+	out := new(MdsIngestRecordReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListMdsIngestSourceParams ListMdsIngestSource的查询参数
+type ListMdsIngestSourceParams struct {
+	// 创建时间之后的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	AfterCreatedAt *string
+	// 更新时间之后的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	AfterUpdatedAt *string
+	// 创建时间之前的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	BeforeCreatedAt *string
+	// 更新时间之前的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	BeforeUpdatedAt *string
+	// 导出的列(多个用,隔开), 默认导出全部列
+	Columns *string
+	// 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据
+	Export *string
+	// 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+	// 运算符: eq ne gt gte lt lte contains in between is_null not_null
+	// example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+	Filter *string
+	// 唯一标识
+	ID *int64
+	// "唯一标识列表(多个用,隔开)"
+	IDs *string
+	// 是否启用
+	IsEnabled *bool
+	// 数据源类型
+	Kind *string
+	// 名称
+	Name *string
+	// 分页页码
+	Page *int64
+	// 分页大小
+	Size *int64
+	// 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表
+	WithTotal *bool
+}
+
+// ListMdsIngestSource 查询行情数据源列表
+//
+// 本接口用于查询行情数据源列表
+//
+// GET /api/v1/mds/ingest/source
+func (c *Client) ListMdsIngestSource(ctx context.Context, params *ListMdsIngestSourceParams) (*MdsPagIngestSourceReply, error) {
+	req := &request{method: "GET", path: "/api/v1/mds/ingest/source"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "after_created_at", params.AfterCreatedAt)
+		addPtr(req.query, "after_updated_at", params.AfterUpdatedAt)
+		addPtr(req.query, "before_created_at", params.BeforeCreatedAt)
+		addPtr(req.query, "before_updated_at", params.BeforeUpdatedAt)
+		addPtr(req.query, "columns", params.Columns)
+		addPtr(req.query, "export", params.Export)
+		addPtr(req.query, "filter", params.Filter)
+		addPtr(req.query, "id", params.ID)
+		addPtr(req.query, "ids", params.IDs)
+		addPtr(req.query, "is_enabled", params.IsEnabled)
+		addPtr(req.query, "kind", params.Kind)
+		addPtr(req.query, "name", params.Name)
+		addPtr(req.query, "page", params.Page)
+		addPtr(req.query, "size", params.Size)
+		addPtr(req.query, "with_total", params.WithTotal)
+	}
+	out := new(MdsPagIngestSourceReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateMdsIngestSource 创建行情数据源
+//
+// 本接口用于创建行情数据源, 设置定时采集表达式后按计划采集每个交易日的行情文件
+//
+// POST /api/v1/mds/ingest/source
+func (c *Client) CreateMdsIngestSource(ctx context.Context, body *MdsCreateOrUpdateIngestSourceRequest) (*MdsIngestSourceReply, error) {
+	req := &request{method: "POST", path: "/api/v1/mds/ingest/source"}
+	req.body = body
+	out := new(MdsIngestSourceReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetMdsIngestSource 查询行情数据源详情
+//
+// 本接口用于查询指定ID的行情数据源详情
+//
+// GET /api/v1/mds/ingest/source/{id}
+func (c *Client) GetMdsIngestSource(ctx context.Context, id int64) (*MdsIngestSourceReply, error) {
+	req := &request{method: "GET", path: "/api/v1/mds/ingest/source/" + pathValue(id)}
+	out := new(MdsIngestSourceReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateMdsIngestSource 更新行情数据源
+//
+// 本接口用于更新指定ID的行情数据源, 并重新注册定时采集任务
+//
+// PUT /api/v1/mds/ingest/source/{id}
+func (c *Client) UpdateMdsIngestSource(ctx context.Context, id int64, body *MdsCreateOrUpdateIngestSourceRequest) (*MdsIngestSourceReply, error) {
+	req := &request{method: "PUT", path: "/api/v1/mds/ingest/source/" + pathValue(id)}
+	req.body = body
+	out := new(MdsIngestSourceReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteMdsIngestSource 删除行情数据源
+//
+// 本接口用于删除指定ID的行情数据源及其采集记录
+//
+// DELETE /api/v1/mds/ingest/source/{id}
+func (c *Client) DeleteMdsIngestSource(ctx context.Context, id int64) (*CommonMapAPIReply, error) {
+	req := &request{method: "DELETE", path: "/api/v1/mds/ingest/source/" + pathValue(id)}
+	out := new(CommonMapAPIReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateMdsIngestSourceRun 采集行情数据
+//
+// 本接口用于手动采集或重新采集数据源指定交易日的行情文件, 采集在后台进行, 通过采集记录查询结果
+//
+// POST /api/v1/mds/ingest/source/{id}/run
+func (c *Client) CreateMdsIngestSourceRun(ctx context.Context, id int64, body *MdsRunIngestRequest) (*MdsIngestRecordReply, error) {
+	req := &request{method: "POST", path: "/api/v1/mds/ingest/source/" + pathValue(id) + "/run"}
+	req.body = body
+	out := new(MdsIngestRecordReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListMdsNodeParams ListMdsNode的查询参数
+type ListMdsNodeParams struct {
+	// 创建时间之后的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	AfterCreatedAt *string
+	// 更新时间之后的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	AfterUpdatedAt *string
+	// 创建时间之前的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	BeforeCreatedAt *string
+	// 更新时间之前的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	BeforeUpdatedAt *string
+	// 导出的列(多个用,隔开), 默认导出全部列
+	Columns *string
+	// 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据
+	Export *string
+	// 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+	// 运算符: eq ne gt gte lt lte contains in between is_null not_null
+	// example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+	Filter *string
+	// 主机ID
+	HostID *int64
+	// 唯一标识
+	ID *int64
+	// "唯一标识列表(多个用,隔开)"
+	IDs *string
+	// 是否启用
+	IsEnable *bool
+	// mds集群ID
+	MdsColonyID *int64
+	// 节点角色
+	NodeRole *string
+	// 分页页码
+	Page *int64
+	// 分页大小
+	Size *int64
+	// 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表
+	WithTotal *bool
+}
+
+// ListMdsNode 查询mds节点列表
+//
+// 本接口用于查询mds节点列表
+//
+// GET /api/v1/mds/node
+func (c *Client) ListMdsNode(ctx context.Context, params *ListMdsNodeParams) (*MdsPagMdsNodeReply, error) {
+	req := &request{method: "GET", path: "/api/v1/mds/node"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "after_created_at", params.AfterCreatedAt)
+		addPtr(req.query, "after_updated_at", params.AfterUpdatedAt)
+		addPtr(req.query, "before_created_at", params.BeforeCreatedAt)
+		addPtr(req.query, "before_updated_at", params.BeforeUpdatedAt)
+		addPtr(req.query, "columns", params.Columns)
+		addPtr(req.query, "export", params.Export)
+		addPtr(req.query, "filter", params.Filter)
+		addPtr(req.query, "host_id", params.HostID)
+		addPtr(req.query, "id", params.ID)
+		addPtr(req.query, "ids", params.IDs)
+		addPtr(req.query, "is_enable", params.IsEnable)
+		addPtr(req.query, "mds_colony_id", params.MdsColonyID)
+		addPtr(req.query, "node_role", params.NodeRole)
+		addPtr(req.query, "page", params.Page)
+		addPtr(req.query, "size", params.Size)
+		addPtr(req.query, "with_total", params.WithTotal)
+	}
+	out := new(MdsPagMdsNodeReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateMdsNode 创建mds节点
+//
+// 本接口用于创建新的mds节点
+//
+// POST /api/v1/mds/node
+func (c *Client) CreateMdsNode(ctx context.Context, body *MdsCreateOrUpdateMdsNodeRequest) (*MdsMdsNodeReply, error) {
+	req := &request{method: "POST", path: "/api/v1/mds/node"}
+	req.body = body
+	out := new(MdsMdsNodeReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetMdsNode 查询mds节点详情
+//
+// 本接口用于查询指定ID的mds节点详情
+//
+// GET /api/v1/mds/node/{id}
+func (c *Client) GetMdsNode(ctx context.Context, id int64) (*MdsMdsNodeReply, error) {
+	req := &request{method: "GET", path: "/api/v1/mds/node/" + pathValue(id)}
+	out := new(MdsMdsNodeReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateMdsNode 更新mds节点
+//
+// 本接口用于更新指定ID的mds节点
+//
+// PUT /api/v1/mds/node/{id}
+func (c *Client) UpdateMdsNode(ctx context.Context, id int64, body *MdsCreateOrUpdateMdsNodeRequest) (*MdsMdsNodeReply, error) {
+	req := &request{method: "PUT", path: "/api/v1/mds/node/" + pathValue(id)}
+	req.body = body
+	out := new(MdsMdsNodeReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteMdsNode 删除mds节点
+//
+// 本接口用于删除指定ID的mds节点
+//
+// DELETE /api/v1/mds/node/{id}
+func (c *Client) DeleteMdsNode(ctx context.Context, id int64) (*CommonMapAPIReply, error) {
+	req := &request{method: "DELETE", path: "/api/v1/mds/node/" + pathValue(id)}
+	out := new(CommonMapAPIReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListMdsConf 获取mds配置文件列表
+//
+// 获取指定目录下的mds配置文件列表
+//
+// GET /api/v1/mds/{colony_num}/conf
+func (c *Client) ListMdsConf(ctx context.Context, colonyNum string) (*MdsPagMdsConfReply, error) {
+	req := &request{method: "GET", path: "/api/v1/mds/" + pathValue(colonyNum) + "/conf"}
+	out := new(MdsPagMdsConfReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateMdsConfForm CreateMdsConf的表单
+type CreateMdsConfForm struct {
+	// 配置文件
+	File *File
+}
+
+// CreateMdsConf 上传mds配置文件
+//
+// 上传mds配置文件到指定目录
+//
+// POST /api/v1/mds/{colony_num}/conf/{dir_name}
+func (c *Client) CreateMdsConf(ctx context.Context, colonyNum string, dirName string, form *CreateMdsConfForm) (*CommonMapAPIReply, error) {
+	req := &request{method: "POST", path: "/api/v1/mds/" + pathValue(colonyNum) + "/conf/" + pathValue(dirName)}
+	req.form = &multipartForm{values: url.Values{}}
+	if form != nil {
+		req.form.addFile("file", form.File)
+	}
+	out := new(CommonMapAPIReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetMdsConf 下载mds配置文件
+//
+// 下载指定的mds配置文件
+//
+// 返回原始响应体, 调用方读取完毕后需要关闭
+//
+// GET /api/v1/mds/{colony_num}/conf/{dir_name}/{filename}
+func (c *Client) GetMdsConf(ctx context.Context, colonyNum string, dirName string, filename string) (io.ReadCloser, error) {
+	req := &request{method: "GET", path: "/api/v1/mds/" + pathValue(colonyNum) + "/conf/" + pathValue(dirName) + "/" + pathValue(filename)}
+	return c.doStream(ctx, req)
+}
+
+// DeleteMdsConf 删除mds配置文件
+//
+// 删除指定的mds配置文件
+//
+// DELETE /api/v1/mds/{colony_num}/conf/{dir_name}/{filename}
+func (c *Client) DeleteMdsConf(ctx context.Context, colonyNum string, dirName string, filename string) (*CommonMapAPIReply, error) {
+	req := &request{method: "DELETE", path: "/api/v1/mds/" + pathValue(colonyNum) + "/conf/" + pathValue(dirName) + "/" + pathValue(filename)}
+	out := new(CommonMapAPIReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListMonHostAgentParams ListMonHostAgent的查询参数
+type ListMonHostAgentParams struct {
+	// 导出的列(多个用,隔开), 默认导出全部列
+	Columns *string
+	// 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据
+	Export *string
+	// 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+	// 运算符: eq ne gt gte lt lte contains in between is_null not_null
+	// example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+	Filter *string
+	// 主机ID
+	HostID *int64
+	// 唯一标识
+	ID *int64
+	// "唯一标识列表(多个用,隔开)"
+	IDs *string
+	// 分页页码
+	Page *int64
+	// 分页大小
+	Size *int64
+	// 在线状态
+	Status *string
+	// 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表
+	WithTotal *bool
+}
+
+// ListMonHostAgent 查询主机代理列表
+//
+// 本接口用于查询资源主机的代理上报信息和在线状态, 可按status=offline查询失联的主机
+//
+// GET /api/v1/mon/host/agent
+func (c *Client) ListMonHostAgent(ctx context.Context, params *ListMonHostAgentParams) (*ResourcePagHostAgentReply, error) {
+	req := &request{method: "GET", path: "/api/v1/mon/host/agent"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "columns", params.Columns)
+		addPtr(req.query, "export", params.Export)
+		addPtr(req.query, "filter", params.Filter)
+		addPtr(req.query, "host_id", params.HostID)
+		addPtr(req.query, "id", params.ID)
+		addPtr(req.query, "ids", params.IDs)
+		addPtr(req.query, "page", params.Page)
+		addPtr(req.query, "size", params.Size)
+		addPtr(req.query, "status", params.Status)
+		addPtr(req.query, "with_total", params.WithTotal)
+	}
+	out := new(ResourcePagHostAgentReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListMonNodeParams ListMonNode的查询参数
+type ListMonNodeParams struct {
+	// 创建时间之后的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	AfterCreatedAt *string
+	// 更新时间之后的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	AfterUpdatedAt *string
+	// 创建时间之前的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	BeforeCreatedAt *string
+	// 更新时间之前的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	BeforeUpdatedAt *string
+	// 导出的列(多个用,隔开), 默认导出全部列
+	Columns *string
+	// 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据
+	Export *string
+	// 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+	// 运算符: eq ne gt gte lt lte contains in between is_null not_null
+	// example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+	Filter *string
+	// 主机ID
+	HostID *int64
+	// 唯一标识
+	ID *int64
+	// "唯一标识列表(多个用,隔开)"
+	IDs *string
+	// 名称
+	Name *string
+	// 分页页码
+	Page *int64
+	// 分页大小
+	Size *int64
+	// 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表
+	WithTotal *bool
+}
+
+// ListMonNode 查询mon节点列表
+//
+// 本接口用于查询mon节点列表
+//
+// GET /api/v1/mon/node
+func (c *Client) ListMonNode(ctx context.Context, params *ListMonNodeParams) (*MonPagMonNodeReply, error) {
+	req := &request{method: "GET", path: "/api/v1/mon/node"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "after_created_at", params.AfterCreatedAt)
+		addPtr(req.query, "after_updated_at", params.AfterUpdatedAt)
+		addPtr(req.query, "before_created_at", params.BeforeCreatedAt)
+		addPtr(req.query, "before_updated_at", params.BeforeUpdatedAt)
+		addPtr(req.query, "columns", params.Columns)
+		addPtr(req.query, "export", params.Export)
+		addPtr(req.query, "filter", params.Filter)
+		addPtr(req.query, "host_id", params.HostID)
+		addPtr(req.query, "id", params.ID)
+		addPtr(req.query, "ids", params.IDs)
+		addPtr(req.query, "name", params.Name)
+		addPtr(req.query, "page", params.Page)
+		addPtr(req.query, "size", params.Size)
+		addPtr(req.query, "with_total", params.WithTotal)
+	}
+	out := new(MonPagMonNodeReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateMonNode 创建mon节点
+//
+// 本接口用于创建新的mon节点
+//
+// POST /api/v1/mon/node
+func (c *Client) CreateMonNode(ctx context.Context, body *MonCreateOrUpdateMonNodeRequest) (*MonMonNodeReply, error) {
+	req := &request{method: "POST", path: "/api/v1/mon/node"}
+	req.body = body
+	out := new(MonMonNodeReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetMonNode 查询mon节点详情
+//
+// 本接口用于查询指定ID的mon节点详情
+//
+// GET /api/v1/mon/node/{id}
+func (c *Client) GetMonNode(ctx context.Context, id int64) (*MonMonNodeReply, error) {
+	req := &request{method: "GET", path: "/api/v1/mon/node/" + pathValue(id)}
+	out := new(MonMonNodeReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateMonNode 更新mon节点
+//
+// 本接口用于更新指定ID的mon节点
+//
+// PUT /api/v1/mon/node/{id}
+func (c *Client) UpdateMonNode(ctx context.Context, id int64, body *MonCreateOrUpdateMonNodeRequest) (*MonMonNodeReply, error) {
+	req := &request{method: "PUT", path: "/api/v1/mon/node/" + pathValue(id)}
+	req.body = body
+	out := new(MonMonNodeReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteMonNode 删除mon节点
+//
+// 本接口用于删除指定ID的mon节点
+//
+// DELETE /api/v1/mon/node/{id}
+func (c *Client) DeleteMonNode(ctx context.Context, id int64) (*CommonMapAPIReply, error) {
+	req := &request{method: "DELETE", path: "/api/v1/mon/node/" + pathValue(id)}
+	out := new(CommonMapAPIReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetMonNodeMetricsParams GetMonNodeMetrics的查询参数
+type GetMonNodeMetricsParams struct {
+	// 时间范围(1h、6h使用原始数据, 24h、7d使用5分钟平均值, 30d、90d使用1小时平均值), 默认1h
+	Range *string
+}
+
+// GetMonNodeMetrics 查询mon节点主机指标
+//
+// 本接口用于查询mon节点所在主机的CPU、内存、磁盘使用率和平均负载, 用于绘制图表; 指标由主机代理上报或通过SSH采集, 不需要配置Prometheus数据源
+//
+// GET /api/v1/mon/node/{id}/metrics
+func (c *Client) GetMonNodeMetrics(ctx context.Context, id int64, params *GetMonNodeMetricsParams) (*MonHostMetricSeriesReply, error) {
+	req := &request{method: "GET", path: "/api/v1/mon/node/" + pathValue(id) + "/metrics"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "range", params.Range)
+	}
+	out := new(MonHostMetricSeriesReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetMonNodeQueryParams GetMonNodeQuery的查询参数
+type GetMonNodeQueryParams struct {
+	// 范围查询结束时间(RFC3339或Unix时间戳)
+	End *string
+	// PromQL查询语句
+	Query string
+	// 范围查询开始时间(RFC3339或Unix时间戳)
+	Start *string
+	// 范围查询步长, 如 15s
+	Step *string
+	// 即时查询时间(RFC3339或Unix时间戳)
+	Time *string
+}
+
+// GetMonNodeQuery 查询mon节点监控数据
+//
+// 本接口通过mon节点配置的Prometheus数据源执行PromQL查询，提供start、end、step时执行范围查询
+//
+// GET /api/v1/mon/node/{id}/query
+func (c *Client) GetMonNodeQuery(ctx context.Context, id int64, params *GetMonNodeQueryParams) (MonMonNodeQueryReply, error) {
+	req := &request{method: "GET", path: "/api/v1/mon/node/" + pathValue(id) + "/query"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "end", params.End)
+		addValue(req.query, "query", params.Query)
+		addPtr(req.query, "start", params.Start)
+		addPtr(req.query, "step", params.Step)
+		addPtr(req.query, "time", params.Time)
+	}
+	var out MonMonNodeQueryReply
+	if err := c.doJSON(ctx, req, &out); err != nil {
+		return out, err
+	}
+	return out, nil
+}
+
+// ListOesColonyParams ListOesColony的查询参数
+type ListOesColonyParams struct {
+	// 创建时间之后的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	AfterCreatedAt *string
+	// 更新时间之后的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	AfterUpdatedAt *string
+	// 创建时间之前的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	BeforeCreatedAt *string
+	// 更新时间之前的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	BeforeUpdatedAt *string
+	// 集群号
+	ColonyNum *string
+	// 导出的列(多个用,隔开), 默认导出全部列
+	Columns *string
+	// 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据
+	Export *string
+	// 解压后名称
+	ExtractedName *string
+	// 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+	// 运算符: eq ne gt gte lt lte contains in between is_null not_null
+	// example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+	Filter *string
+	// 唯一标识
+	ID *int64
+	// "唯一标识列表(多个用,隔开)"
+	IDs *string
+	// 是否启用
+	IsEnable *bool
+	// mon节点ID
+	MonNodeID *int64
+	// 程序包ID
+	PackageID *int64
+	// 分页页码
+	Page *int64
+	// 分页大小
+	Size *int64
+	// 系统类型
+	SystemType *string
+	// 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表
+	WithTotal *bool
+	// xcounter包ID
+	XcounterID *int64
+}
+
+// ListOesColony 查询oes集群列表
+//
+// 本接口用于查询oes集群列表, 指定export参数时按columns选择的列导出为csv或xlsx文件
+//
+// GET /api/v1/oes/colony
+func (c *Client) ListOesColony(ctx context.Context, params *ListOesColonyParams) (*OesPagOesColonyReply, error) {
+	req := &request{method: "GET", path: "/api/v1/oes/colony"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "after_created_at", params.AfterCreatedAt)
+		addPtr(req.query, "after_updated_at", params.AfterUpdatedAt)
+		addPtr(req.query, "before_created_at", params.BeforeCreatedAt)
+		addPtr(req.query, "before_updated_at", params.BeforeUpdatedAt)
+		addPtr(req.query, "colony_num", params.ColonyNum)
+		addPtr(req.query, "columns", params.Columns)
+		addPtr(req.query, "export", params.Export)
+		addPtr(req.query, "extracted_name", params.ExtractedName)
+		addPtr(req.query, "filter", params.Filter)
+		addPtr(req.query, "id", params.ID)
+		addPtr(req.query, "ids", params.IDs)
+		addPtr(req.query, "is_enable", params.IsEnable)
+		addPtr(req.query, "mon_node_id", params.MonNodeID)
+		addPtr(req.query, "package_id", params.PackageID)
+		addPtr(req.query, "page", params.Page)
+		addPtr(req.query, "size", params.Size)
+		addPtr(req.query, "system_type", params.SystemType)
+		addPtr(req.query, "with_total", params.WithTotal)
+		addPtr(req.query, "xcounter_id", params.XcounterID)
+	}
+	out := new(OesPagOesColonyReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateOesColony 创建oes集群
+//
+// 本接口用于创建新的oes集群
+//
+// POST /api/v1/oes/colony
+func (c *Client) CreateOesColony(ctx context.Context, body *OesCreateOrUpdateOesColonyRequest) (*OesOesColonyReply, error) {
+	req := &request{method: "POST", path: "/api/v1/oes/colony"}
+	req.body = body
+	out := new(OesOesColonyReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PatchOesColonyBatch 批量更新oes集群
+//
+// 本接口用于在同一个事务中批量更新oes集群的启用状态、程序包或mon节点，返回每个集群的执行结果并记录审计日志
+//
+// PATCH /api/v1/oes/colony/batch
+func (c *Client) PatchOesColonyBatch(ctx context.Context, body *OesBatchUpdateOesColonyRequest) (*OesBatchOesColonyReply, error) {
+	req := &request{method: "PATCH", path: "/api/v1/oes/colony/batch"}
+	req.body = body
+	out := new(OesBatchOesColonyReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetOesColonyExport 查询oes集群导出状态
+//
+// 本接口用于查询oes集群导出记录的生成状态
+//
+// GET /api/v1/oes/colony/export/{id}
+func (c *Client) GetOesColonyExport(ctx context.Context, id int64) (*OesOesColonyExportReply, error) {
+	req := &request{method: "GET", path: "/api/v1/oes/colony/export/" + pathValue(id)}
+	out := new(OesOesColonyExportReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetOesColonyExportDownload 下载oes集群导出归档
+//
+// 本接口用于下载已生成的oes集群导出归档
+//
+// 返回原始响应体, 调用方读取完毕后需要关闭
+//
+// GET /api/v1/oes/colony/export/{id}/download
+func (c *Client) GetOesColonyExportDownload(ctx context.Context, id int64) (io.ReadCloser, error) {
+	req := &request{method: "GET", path: "/api/v1/oes/colony/export/" + pathValue(id) + "/download"}
+	return c.doStream(ctx, req)
+}
+
+// ListOesColonyStatusCrdParams ListOesColonyStatusCrd的查询参数
+type ListOesColonyStatusCrdParams struct {
+	// 创建时间之后的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	AfterCreatedAt *string
+	// 更新时间之后的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	AfterUpdatedAt *string
+	// 创建时间之前的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	BeforeCreatedAt *string
+	// 更新时间之前的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	BeforeUpdatedAt *string
+	// 集群号
+	ColonyNum *string
+	// 导出的列(多个用,隔开), 默认导出全部列
+	Columns *string
+	// 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据
+	Export *string
+	// 解压后名称
+	ExtractedName *string
+	// 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+	// 运算符: eq ne gt gte lt lte contains in between is_null not_null
+	// example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+	Filter *string
+	// 唯一标识
+	ID *int64
+	// "唯一标识列表(多个用,隔开)"
+	IDs *string
+	// 是否启用
+	IsEnable *bool
+	// mon节点ID
+	MonNodeID *int64
+	// 程序包ID
+	PackageID *int64
+	// 分页页码
+	Page *int64
+	// 分页大小
+	Size *int64
+	// 系统类型
+	SystemType *string
+	// 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表
+	WithTotal *bool
+	// xcounter包ID
+	XcounterID *int64
+}
+
+// ListOesColonyStatusCrd 查询oes两融集群列表的任务状态
+//
+// 本接口用于查询oes两融集群列表的任务状态
+//
+// GET /api/v1/oes/colony/status/crd
+func (c *Client) ListOesColonyStatusCrd(ctx context.Context, params *ListOesColonyStatusCrdParams) (*OesListOesTasksInfoReply, error) {
+	req := &request{method: "GET", path: "/api/v1/oes/colony/status/crd"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "after_created_at", params.AfterCreatedAt)
+		addPtr(req.query, "after_updated_at", params.AfterUpdatedAt)
+		addPtr(req.query, "before_created_at", params.BeforeCreatedAt)
+		addPtr(req.query, "before_updated_at", params.BeforeUpdatedAt)
+		addPtr(req.query, "colony_num", params.ColonyNum)
+		addPtr(req.query, "columns", params.Columns)
+		addPtr(req.query, "export", params.Export)
+		addPtr(req.query, "extracted_name", params.ExtractedName)
+		addPtr(req.query, "filter", params.Filter)
+		addPtr(req.query, "id", params.ID)
+		addPtr(req.query, "ids", params.IDs)
+		addPtr(req.query, "is_enable", params.IsEnable)
+		addPtr(req.query, "mon_node_id", params.MonNodeID)
+		addPtr(req.query, "package_id", params.PackageID)
+		addPtr(req.query, "page", params.Page)
+		addPtr(req.query, "size", params.Size)
+		addPtr(req.query, "system_type", params.SystemType)
+		addPtr(req.query, "with_total", params.WithTotal)
+		addPtr(req.query, "xcounter_id", params.XcounterID)
+	}
+	out := new(OesListOesTasksInfoReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListOesColonyStatusOptParams ListOesColonyStatusOpt的查询参数
+type ListOesColonyStatusOptParams struct {
+	// 创建时间之后的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	AfterCreatedAt *string
+	// 更新时间之后的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	AfterUpdatedAt *string
+	// 创建时间之前的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	BeforeCreatedAt *string
+	// 更新时间之前的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	BeforeUpdatedAt *string
+	// 集群号
+	ColonyNum *string
+	// 导出的列(多个用,隔开), 默认导出全部列
+	Columns *string
+	// 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据
+	Export *string
+	// 解压后名称
+	ExtractedName *string
+	// 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+	// 运算符: eq ne gt gte lt lte contains in between is_null not_null
+	// example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+	Filter *string
+	// 唯一标识
+	ID *int64
+	// "唯一标识列表(多个用,隔开)"
+	IDs *string
+	// 是否启用
+	IsEnable *bool
+	// mon节点ID
+	MonNodeID *int64
+	// 程序包ID
+	PackageID *int64
+	// 分页页码
+	Page *int64
+	// 分页大小
+	Size *int64
+	// 系统类型
+	SystemType *string
+	// 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表
+	WithTotal *bool
+	// xcounter包ID
+	XcounterID *int64
+}
+
+// ListOesColonyStatusOpt 查询oes期权集群列表的任务状态
+//
+// 本接口用于查询oes期权集群列表的任务状态
+//
+// GET /api/v1/oes/colony/status/opt
+func (c *Client) ListOesColonyStatusOpt(ctx context.Context, params *ListOesColonyStatusOptParams) (*OesListOesTasksInfoReply, error) {
+	req := &request{method: "GET", path: "/api/v1/oes/colony/status/opt"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "after_created_at", params.AfterCreatedAt)
+		addPtr(req.query, "after_updated_at", params.AfterUpdatedAt)
+		addPtr(req.query, "before_created_at", params.BeforeCreatedAt)
+		addPtr(req.query, "before_updated_at", params.BeforeUpdatedAt)
+		addPtr(req.query, "colony_num", params.ColonyNum)
+		addPtr(req.query, "columns", params.Columns)
+		addPtr(req.query, "export", params.Export)
+		addPtr(req.query, "extracted_name", params.ExtractedName)
+		addPtr(req.query, "filter", params.Filter)
+		addPtr(req.query, "id", params.ID)
+		addPtr(req.query, "ids", params.IDs)
+		addPtr(req.query, "is_enable", params.IsEnable)
+		addPtr(req.query, "mon_node_id", params.MonNodeID)
+		addPtr(req.query, "package_id", params.PackageID)
+		addPtr(req.query, "page", params.Page)
+		addPtr(req.query, "size", params.Size)
+		addPtr(req.query, "system_type", params.SystemType)
+		addPtr(req.query, "with_total", params.WithTotal)
+		addPtr(req.query, "xcounter_id", params.XcounterID)
+	}
+	out := new(OesListOesTasksInfoReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListOesColonyStatusStkParams ListOesColonyStatusStk的查询参数
+type ListOesColonyStatusStkParams struct {
+	// 创建时间之后的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	AfterCreatedAt *string
+	// 更新时间之后的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	AfterUpdatedAt *string
+	// 创建时间之前的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	BeforeCreatedAt *string
+	// 更新时间之前的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	BeforeUpdatedAt *string
+	// 集群号
+	ColonyNum *string
+	// 导出的列(多个用,隔开), 默认导出全部列
+	Columns *string
+	// 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据
+	Export *string
+	// 解压后名称
+	ExtractedName *string
+	// 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+	// 运算符: eq ne gt gte lt lte contains in between is_null not_null
+	// example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+	Filter *string
+	// 唯一标识
+	ID *int64
+	// "唯一标识列表(多个用,隔开)"
+	IDs *string
+	// 是否启用
+	IsEnable *bool
+	// mon节点ID
+	MonNodeID *int64
+	// 程序包ID
+	PackageID *int64
+	// 分页页码
+	Page *int64
+	// 分页大小
+	Size *int64
+	// 系统类型
+	SystemType *string
+	// 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表
+	WithTotal *bool
+	// xcounter包ID
+	XcounterID *int64
+}
+
+// ListOesColonyStatusStk 查询oes现货集群列表的任务状态
+//
+// 本接口用于查询oes现货集群列表的任务状态
+//
+// GET /api/v1/oes/colony/status/stk
+func (c *Client) ListOesColonyStatusStk(ctx context.Context, params *ListOesColonyStatusStkParams) (*OesListOesTasksInfoReply, error) {
+	req := &request{method: "GET", path: "/api/v1/oes/colony/status/stk"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "after_created_at", params.AfterCreatedAt)
+		addPtr(req.query, "after_updated_at", params.AfterUpdatedAt)
+		addPtr(req.query, "before_created_at", params.BeforeCreatedAt)
+		addPtr(req.query, "before_updated_at", params.BeforeUpdatedAt)
+		addPtr(req.query, "colony_num", params.ColonyNum)
+		addPtr(req.query, "columns", params.Columns)
+		addPtr(req.query, "export", params.Export)
+		addPtr(req.query, "extracted_name", params.ExtractedName)
+		addPtr(req.query, "filter", params.Filter)
+		addPtr(req.query, "id", params.ID)
+		addPtr(req.query, "ids", params.IDs)
+		addPtr(req.query, "is_enable", params.IsEnable)
+		addPtr(req.query, "mon_node_id", params.MonNodeID)
+		addPtr(req.query, "package_id", params.PackageID)
+		addPtr(req.query, "page", params.Page)
+		addPtr(req.query, "size", params.Size)
+		addPtr(req.query, "system_type", params.SystemType)
+		addPtr(req.query, "with_total", params.WithTotal)
+		addPtr(req.query, "xcounter_id", params.XcounterID)
+	}
+	out := new(OesListOesTasksInfoReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetOesColony 查询oes集群详情
+//
+// 本接口用于查询指定ID的oes集群详情
+//
+// GET /api/v1/oes/colony/{id}
+func (c *Client) GetOesColony(ctx context.Context, id int64) (*OesOesColonyReply, error) {
+	req := &request{method: "GET", path: "/api/v1/oes/colony/" + pathValue(id)}
+	out := new(OesOesColonyReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateOesColony 更新oes集群
+//
+// 本接口用于更新指定ID的oes集群
+//
+// PUT /api/v1/oes/colony/{id}
+func (c *Client) UpdateOesColony(ctx context.Context, id int64, body *OesCreateOrUpdateOesColonyRequest) (*OesOesColonyReply, error) {
+	req := &request{method: "PUT", path: "/api/v1/oes/colony/" + pathValue(id)}
+	req.body = body
+	out := new(OesOesColonyReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteOesColony 删除oes集群
+//
+// 本接口用于删除指定ID的oes集群
+//
+// DELETE /api/v1/oes/colony/{id}
+func (c *Client) DeleteOesColony(ctx context.Context, id int64) (*CommonMapAPIReply, error) {
+	req := &request{method: "DELETE", path: "/api/v1/oes/colony/" + pathValue(id)}
+	out := new(CommonMapAPIReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetOesColonyDrift 查询集群配置漂移
+//
+// 本接口返回集群最近一次检测发现的配置漂移，包括配置文件与模板渲染结果不一致以及程序包版本与集群记录不一致
+//
+// GET /api/v1/oes/colony/{id}/drift
+func (c *Client) GetOesColonyDrift(ctx context.Context, id int32) (*OesOesColonyDriftReply, error) {
+	req := &request{method: "GET", path: "/api/v1/oes/colony/" + pathValue(id) + "/drift"}
+	out := new(OesOesColonyDriftReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateOesColonyDriftCheck 立即检测集群配置漂移
+//
+// 本接口立即检测集群的配置漂移并保存结果，出现新的漂移时发出告警
+//
+// POST /api/v1/oes/colony/{id}/drift/check
+func (c *Client) CreateOesColonyDriftCheck(ctx context.Context, id int32) (*OesOesColonyDriftReply, error) {
+	req := &request{method: "POST", path: "/api/v1/oes/colony/" + pathValue(id) + "/drift/check"}
+	out := new(OesOesColonyDriftReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateOesColonyExport 导出oes集群运维历史
+//
+// 本接口用于异步导出oes集群的配置、节点部署、配置文件、任务执行记录及日志，返回导出记录，完成后通过下载地址获取归档
+//
+// POST /api/v1/oes/colony/{id}/export
+func (c *Client) CreateOesColonyExport(ctx context.Context, id int64) (*OesOesColonyExportReply, error) {
+	req := &request{method: "POST", path: "/api/v1/oes/colony/" + pathValue(id) + "/export"}
+	out := new(OesOesColonyExportReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListOesColonyReconciliationParams ListOesColonyReconciliation的查询参数
+type ListOesColonyReconciliationParams struct {
+	// 导出的列(多个用,隔开), 默认导出全部列
+	Columns *string
+	// 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据
+	Export *string
+	// 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+	// 运算符: eq ne gt gte lt lte contains in between is_null not_null
+	// example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+	Filter *string
+	// 唯一标识
+	ID *int64
+	// "唯一标识列表(多个用,隔开)"
+	IDs *string
+	// 分页页码
+	Page *int64
+	// 分页大小
+	Size *int64
+	// 对账状态
+	Status *string
+	// 交易日, 格式为2006-01-02
+	TradingDay *string
+	// 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表
+	WithTotal *bool
+}
+
+// ListOesColonyReconciliation 查询集群柜台数据对账报告列表
+//
+// 本接口分页查询集群每个交易日的柜台数据对账报告，报告统计下发到各节点的数据相对柜台数据缺少、多余和内容不一致的记录数
+//
+// GET /api/v1/oes/colony/{id}/reconciliation
+func (c *Client) ListOesColonyReconciliation(ctx context.Context, id int32, params *ListOesColonyReconciliationParams) (*OesPagOesReconcileReportReply, error) {
+	req := &request{method: "GET", path: "/api/v1/oes/colony/" + pathValue(id) + "/reconciliation"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "columns", params.Columns)
+		addPtr(req.query, "export", params.Export)
+		addPtr(req.query, "filter", params.Filter)
+		addPtr(req.query, "id", params.ID)
+		addPtr(req.query, "ids", params.IDs)
+		addPtr(req.query, "page", params.Page)
+		addPtr(req.query, "size", params.Size)
+		addPtr(req.query, "status", params.Status)
+		addPtr(req.query, "trading_day", params.TradingDay)
+		addPtr(req.query, "with_total", params.WithTotal)
+	}
+	out := new(OesPagOesReconcileReportReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateOesColonyReconciliation 立即对账集群柜台数据
+//
+// 本接口立即比较counter_fetch.sh获取的柜台数据与counter_distribute.sh下发到各节点的数据并保存报告，替换该交易日已有的报告
+//
+// POST /api/v1/oes/colony/{id}/reconciliation
+func (c *Client) CreateOesColonyReconciliation(ctx context.Context, id int32, body *OesReconcileColonyRequest) (*OesOesReconcileReportReply, error) {
+	req := &request{method: "POST", path: "/api/v1/oes/colony/" + pathValue(id) + "/reconciliation"}
+	req.body = body
+	out := new(OesOesReconcileReportReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetOesColonyReconciliation 查询集群指定交易日的柜台数据对账报告
+//
+// 本接口查询集群指定交易日的柜台数据对账报告及每个节点上每个数据文件的对账结果
+//
+// GET /api/v1/oes/colony/{id}/reconciliation/{trading_day}
+func (c *Client) GetOesColonyReconciliation(ctx context.Context, id int32, tradingDay string) (*OesOesReconcileReportReply, error) {
+	req := &request{method: "GET", path: "/api/v1/oes/colony/" + pathValue(id) + "/reconciliation/" + pathValue(tradingDay)}
+	out := new(OesOesReconcileReportReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateOesColonyRunbook 为集群发起检查单
+//
+// 本接口用于为集群发起检查单，第一个步骤立即开始，同一集群同一检查单同时只允许一个未结束的执行
+//
+// POST /api/v1/oes/colony/{id}/runbook
+func (c *Client) CreateOesColonyRunbook(ctx context.Context, id int32, body *OesStartOesRunbookRequest) (*OesOesRunbookExecReply, error) {
+	req := &request{method: "POST", path: "/api/v1/oes/colony/" + pathValue(id) + "/runbook"}
+	req.body = body
+	out := new(OesOesRunbookExecReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetOesColonyTopology 查询oes集群拓扑图
+//
+// 本接口用于一次性查询oes集群及其程序包、xcounter、mon节点、oes节点、主机和最近任务状态，以节点和连线的形式返回
+//
+// GET /api/v1/oes/colony/{id}/topology
+func (c *Client) GetOesColonyTopology(ctx context.Context, id int64) (*OesOesColonyTopologyReply, error) {
+	req := &request{method: "GET", path: "/api/v1/oes/colony/" + pathValue(id) + "/topology"}
+	out := new(OesOesColonyTopologyReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateOesColonyWorkflow 发起oes任务编排
+//
+// 本接口用于按集群系统类型的编排定义发起日常任务, 依赖步骤成功后自动执行下游步骤
+//
+// POST /api/v1/oes/colony/{id}/workflow
+func (c *Client) CreateOesColonyWorkflow(ctx context.Context, id int64) (*OesOesWorkflowRunReply, error) {
+	req := &request{method: "POST", path: "/api/v1/oes/colony/" + pathValue(id) + "/workflow"}
+	out := new(OesOesWorkflowRunReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListOesConfTemplateParams ListOesConfTemplate的查询参数
+type ListOesConfTemplateParams struct {
+	// 页码
+	Page *int64
+	// 每页数量
+	Size *int64
+	// 名称
+	Name *string
+	// 适用节点目录
+	DirName *string
+}
+
+// ListOesConfTemplate 查询配置模板列表
+//
+// 本接口用于查询oes配置文件模板列表，支持按名称和适用节点目录过滤
+//
+// GET /api/v1/oes/conf/template
+func (c *Client) ListOesConfTemplate(ctx context.Context, params *ListOesConfTemplateParams) (*OesPagOesConfTemplateReply, error) {
+	req := &request{method: "GET", path: "/api/v1/oes/conf/template"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "page", params.Page)
+		addPtr(req.query, "size", params.Size)
+		addPtr(req.query, "name", params.Name)
+		addPtr(req.query, "dir_name", params.DirName)
+	}
+	out := new(OesPagOesConfTemplateReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateOesConfTemplate 创建配置模板
+//
+// 本接口用于创建oes配置文件模板，内容和目标路径使用Go模板语法。
+// 可用变量：.Colony(ID/SystemType/ColonyNum/ExtractedName/Version/XCounterVersion/MonNodeName/MonNodeURL)，
+// .Node(ID/NodeRole/Specdir/HostID/HostName/SSHIP/SSHPort/SSHUser)，.Nodes为集群全部启用节点；可用函数add、mul、int。
+//
+// POST /api/v1/oes/conf/template
+func (c *Client) CreateOesConfTemplate(ctx context.Context, body *OesOesConfTemplateRequest) (*OesOesConfTemplateReply, error) {
+	req := &request{method: "POST", path: "/api/v1/oes/conf/template"}
+	req.body = body
+	out := new(OesOesConfTemplateReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetOesConfTemplate 查询配置模板详情
+//
+// 本接口用于查询指定ID的oes配置文件模板
+//
+// GET /api/v1/oes/conf/template/{id}
+func (c *Client) GetOesConfTemplate(ctx context.Context, id int32) (*OesOesConfTemplateReply, error) {
+	req := &request{method: "GET", path: "/api/v1/oes/conf/template/" + pathValue(id)}
+	out := new(OesOesConfTemplateReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateOesConfTemplate 更新配置模板
+//
+// 本接口用于更新指定ID的oes配置文件模板
+//
+// PUT /api/v1/oes/conf/template/{id}
+func (c *Client) UpdateOesConfTemplate(ctx context.Context, id int32, body *OesOesConfTemplateRequest) (*OesOesConfTemplateReply, error) {
+	req := &request{method: "PUT", path: "/api/v1/oes/conf/template/" + pathValue(id)}
+	req.body = body
+	out := new(OesOesConfTemplateReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteOesConfTemplate 删除配置模板
+//
+// 本接口用于删除指定ID的oes配置文件模板，不影响已下发的文件
+//
+// DELETE /api/v1/oes/conf/template/{id}
+func (c *Client) DeleteOesConfTemplate(ctx context.Context, id int32) (*CommonMapAPIReply, error) {
+	req := &request{method: "DELETE", path: "/api/v1/oes/conf/template/" + pathValue(id)}
+	out := new(CommonMapAPIReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateOesConfTemplateDeploy 下发配置文件
+//
+// 本接口使用指定集群的记录渲染模板并通过SFTP写入目标节点主机，内容未变化的节点不写入。
+// 全部节点渲染成功后才开始下发，单个节点下发失败时在结果中返回错误，不影响其他节点。
+//
+// POST /api/v1/oes/conf/template/{id}/deploy
+func (c *Client) CreateOesConfTemplateDeploy(ctx context.Context, id int32, body *OesDeployOesConfTemplateRequest) (*OesOesConfDeployReply, error) {
+	req := &request{method: "POST", path: "/api/v1/oes/conf/template/" + pathValue(id) + "/deploy"}
+	req.body = body
+	out := new(OesOesConfDeployReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateOesConfTemplateRender 预览配置模板渲染结果
+//
+// 本接口使用指定集群的记录渲染模板，返回每个目标节点的渲染内容及与主机上当前文件的差异(unified格式)，不修改主机文件
+//
+// POST /api/v1/oes/conf/template/{id}/render
+func (c *Client) CreateOesConfTemplateRender(ctx context.Context, id int32, body *OesRenderOesConfTemplateRequest) (*OesOesConfRenderReply, error) {
+	req := &request{method: "POST", path: "/api/v1/oes/conf/template/" + pathValue(id) + "/render"}
+	req.body = body
+	out := new(OesOesConfRenderReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListOesLinkParams ListOesLink的查询参数
+type ListOesLinkParams struct {
+	// 创建时间之后的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	AfterCreatedAt *string
+	// 更新时间之后的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	AfterUpdatedAt *string
+	// 创建时间之前的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	BeforeCreatedAt *string
+	// 更新时间之前的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	BeforeUpdatedAt *string
+	// 导出的列(多个用,隔开), 默认导出全部列
+	Columns *string
+	// 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据
+	Export *string
+	// 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+	// 运算符: eq ne gt gte lt lte contains in between is_null not_null
+	// example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+	Filter *string
+	// 唯一标识
+	ID *int64
+	// "唯一标识列表(多个用,隔开)"
+	IDs *string
+	// 是否启用
+	IsEnabled *bool
+	// 名称
+	Name *string
+	// oes集群ID
+	OesColonyID *int64
+	// 分页页码
+	Page *int64
+	// 分页大小
+	Size *int64
+	// 源主机ID, 0表示平台
+	SourceHostID *int64
+	// 探测状态
+	Status *string
+	// 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表
+	WithTotal *bool
+}
+
+// ListOesLink 查询链路列表
+//
+// 本接口用于查询链路列表，可按status=unreachable查询不可达的链路
+//
+// GET /api/v1/oes/link
+func (c *Client) ListOesLink(ctx context.Context, params *ListOesLinkParams) (*OesPagOesLinkReply, error) {
+	req := &request{method: "GET", path: "/api/v1/oes/link"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "after_created_at", params.AfterCreatedAt)
+		addPtr(req.query, "after_updated_at", params.AfterUpdatedAt)
+		addPtr(req.query, "before_created_at", params.BeforeCreatedAt)
+		addPtr(req.query, "before_updated_at", params.BeforeUpdatedAt)
+		addPtr(req.query, "columns", params.Columns)
+		addPtr(req.query, "export", params.Export)
+		addPtr(req.query, "filter", params.Filter)
+		addPtr(req.query, "id", params.ID)
+		addPtr(req.query, "ids", params.IDs)
+		addPtr(req.query, "is_enabled", params.IsEnabled)
+		addPtr(req.query, "name", params.Name)
+		addPtr(req.query, "oes_colony_id", params.OesColonyID)
+		addPtr(req.query, "page", params.Page)
+		addPtr(req.query, "size", params.Size)
+		addPtr(req.query, "source_host_id", params.SourceHostID)
+		addPtr(req.query, "status", params.Status)
+		addPtr(req.query, "with_total", params.WithTotal)
+	}
+	out := new(OesPagOesLinkReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateOesLink 创建链路
+//
+// 本接口用于定义集群组件之间期望可达的TCP链路，例如节点到XCounter端口、节点到交易所网关，源主机ID为0时由平台探测
+//
+// POST /api/v1/oes/link
+func (c *Client) CreateOesLink(ctx context.Context, body *OesOesLinkRequest) (*OesOesLinkReply, error) {
+	req := &request{method: "POST", path: "/api/v1/oes/link"}
+	req.body = body
+	out := new(OesOesLinkReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetOesLinkMatrixParams GetOesLinkMatrix的查询参数
+type GetOesLinkMatrixParams struct {
+	// oes集群ID, 为空时返回全部链路
+	OesColonyID *int64
+}
+
+// GetOesLinkMatrix 查询链路矩阵
+//
+// 本接口用于按探测发起方(行)和目标(列)汇总启用链路的最近一次探测结果和连接耗时
+//
+// GET /api/v1/oes/link/matrix
+func (c *Client) GetOesLinkMatrix(ctx context.Context, params *GetOesLinkMatrixParams) (*OesOesLinkMatrixReply, error) {
+	req := &request{method: "GET", path: "/api/v1/oes/link/matrix"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "oes_colony_id", params.OesColonyID)
+	}
+	out := new(OesOesLinkMatrixReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetOesLink 查询链路详情
+//
+// 本接口用于查询指定ID的链路和最近一次探测结果
+//
+// GET /api/v1/oes/link/{id}
+func (c *Client) GetOesLink(ctx context.Context, id int32) (*OesOesLinkReply, error) {
+	req := &request{method: "GET", path: "/api/v1/oes/link/" + pathValue(id)}
+	out := new(OesOesLinkReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateOesLink 更新链路
+//
+// 本接口用于更新指定ID的链路，源主机或目标变化时探测状态重置为未探测
+//
+// PUT /api/v1/oes/link/{id}
+func (c *Client) UpdateOesLink(ctx context.Context, id int32, body *OesOesLinkRequest) (*OesOesLinkReply, error) {
+	req := &request{method: "PUT", path: "/api/v1/oes/link/" + pathValue(id)}
+	req.body = body
+	out := new(OesOesLinkReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteOesLink 删除链路
+//
+// 本接口用于删除指定ID的链路及其探测记录
+//
+// DELETE /api/v1/oes/link/{id}
+func (c *Client) DeleteOesLink(ctx context.Context, id int32) (*CommonMapAPIReply, error) {
+	req := &request{method: "DELETE", path: "/api/v1/oes/link/" + pathValue(id)}
+	out := new(CommonMapAPIReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListOesLinkProbeParams ListOesLinkProbe的查询参数
+type ListOesLinkProbeParams struct {
+	// 导出的列(多个用,隔开), 默认导出全部列
+	Columns *string
+	// 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据
+	Export *string
+	// 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+	// 运算符: eq ne gt gte lt lte contains in between is_null not_null
+	// example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+	Filter *string
+	// 唯一标识
+	ID *int64
+	// "唯一标识列表(多个用,隔开)"
+	IDs *string
+	// 分页页码
+	Page *int64
+	// 分页大小
+	Size *int64
+	// 探测状态
+	Status *string
+	// 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表
+	WithTotal *bool
+}
+
+// ListOesLinkProbe 查询链路探测记录
+//
+// 本接口用于查询指定ID的链路的探测记录和连接耗时，按时间倒序
+//
+// GET /api/v1/oes/link/{id}/probe
+func (c *Client) ListOesLinkProbe(ctx context.Context, id int32, params *ListOesLinkProbeParams) (*OesPagOesLinkProbeReply, error) {
+	req := &request{method: "GET", path: "/api/v1/oes/link/" + pathValue(id) + "/probe"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "columns", params.Columns)
+		addPtr(req.query, "export", params.Export)
+		addPtr(req.query, "filter", params.Filter)
+		addPtr(req.query, "id", params.ID)
+		addPtr(req.query, "ids", params.IDs)
+		addPtr(req.query, "page", params.Page)
+		addPtr(req.query, "size", params.Size)
+		addPtr(req.query, "status", params.Status)
+		addPtr(req.query, "with_total", params.WithTotal)
+	}
+	out := new(OesPagOesLinkProbeReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateOesLinkProbe 立即探测链路
+//
+// 本接口用于立即探测指定ID的链路，与定时探测相同，变为不可达时发出告警
+//
+// POST /api/v1/oes/link/{id}/probe
+func (c *Client) CreateOesLinkProbe(ctx context.Context, id int32) (*OesOesLinkReply, error) {
+	req := &request{method: "POST", path: "/api/v1/oes/link/" + pathValue(id) + "/probe"}
+	out := new(OesOesLinkReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListOesNodeParams ListOesNode的查询参数
+type ListOesNodeParams struct {
+	// 创建时间之后的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	AfterCreatedAt *string
+	// 更新时间之后的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	AfterUpdatedAt *string
+	// 创建时间之前的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	BeforeCreatedAt *string
+	// 更新时间之前的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	BeforeUpdatedAt *string
+	// 导出的列(多个用,隔开), 默认导出全部列
+	Columns *string
+	// 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据
+	Export *string
+	// 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+	// 运算符: eq ne gt gte lt lte contains in between is_null not_null
+	// example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+	Filter *string
+	// 主机ID
+	// required: false
+	// example: 1
+	HostID *int64
+	// 唯一标识
+	ID *int64
+	// "唯一标识列表(多个用,隔开)"
+	IDs *string
+	// 是否启用
+	// required: false
+	// example: true
+	IsEnable *bool
+	// 节点角色
+	// example: "master"
+	NodeRole *string
+	// oes集群ID
+	// required: false
+	// example: 1
+	OesColonyID *int64
+	// 分页页码
+	Page *int64
+	// 分页大小
+	Size *int64
+	// 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表
+	WithTotal *bool
+}
+
+// ListOesNode 查询oes节点列表
+//
+// 本接口用于查询oes节点列表
+//
+// GET /api/v1/oes/node
+func (c *Client) ListOesNode(ctx context.Context, params *ListOesNodeParams) (*OesPagOesNodeReply, error) {
+	req := &request{method: "GET", path: "/api/v1/oes/node"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "after_created_at", params.AfterCreatedAt)
+		addPtr(req.query, "after_updated_at", params.AfterUpdatedAt)
+		addPtr(req.query, "before_created_at", params.BeforeCreatedAt)
+		addPtr(req.query, "before_updated_at", params.BeforeUpdatedAt)
+		addPtr(req.query, "columns", params.Columns)
+		addPtr(req.query, "export", params.Export)
+		addPtr(req.query, "filter", params.Filter)
+		addPtr(req.query, "host_id", params.HostID)
+		addPtr(req.query, "id", params.ID)
+		addPtr(req.query, "ids", params.IDs)
+		addPtr(req.query, "is_enable", params.IsEnable)
+		addPtr(req.query, "node_role", params.NodeRole)
+		addPtr(req.query, "oes_colony_id", params.OesColonyID)
+		addPtr(req.query, "page", params.Page)
+		addPtr(req.query, "size", params.Size)
+		addPtr(req.query, "with_total", params.WithTotal)
+	}
+	out := new(OesPagOesNodeReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateOesNode 创建oes节点
+//
+// 本接口用于创建新的oes节点
+//
+// POST /api/v1/oes/node
+func (c *Client) CreateOesNode(ctx context.Context, body *OesCreateOrUpdateOesNodeRequest) (*OesOesNodeReply, error) {
+	req := &request{method: "POST", path: "/api/v1/oes/node"}
+	req.body = body
+	out := new(OesOesNodeReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetOesNode 查询oes节点详情
+//
+// 本接口用于查询指定ID的oes节点详情
+//
+// GET /api/v1/oes/node/{id}
+func (c *Client) GetOesNode(ctx context.Context, id int64) (*OesOesNodeReply, error) {
+	req := &request{method: "GET", path: "/api/v1/oes/node/" + pathValue(id)}
+	out := new(OesOesNodeReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateOesNode 更新oes节点
+//
+// 本接口用于更新指定ID的oes节点
+//
+// PUT /api/v1/oes/node/{id}
+func (c *Client) UpdateOesNode(ctx context.Context, id int64, body *OesCreateOrUpdateOesNodeRequest) (*OesOesNodeReply, error) {
+	req := &request{method: "PUT", path: "/api/v1/oes/node/" + pathValue(id)}
+	req.body = body
+	out := new(OesOesNodeReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteOesNode 删除oes节点
+//
+// 本接口用于删除指定ID的oes节点
+//
+// DELETE /api/v1/oes/node/{id}
+func (c *Client) DeleteOesNode(ctx context.Context, id int64) (*CommonMapAPIReply, error) {
+	req := &request{method: "DELETE", path: "/api/v1/oes/node/" + pathValue(id)}
+	out := new(CommonMapAPIReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListOesRunbookParams ListOesRunbook的查询参数
+type ListOesRunbookParams struct {
+	// 导出的列(多个用,隔开), 默认导出全部列
+	Columns *string
+	// 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据
+	Export *string
+	// 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+	// 运算符: eq ne gt gte lt lte contains in between is_null not_null
+	// example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+	Filter *string
+	// 唯一标识
+	ID *int64
+	// "唯一标识列表(多个用,隔开)"
+	IDs *string
+	// 是否启用
+	IsEnabled *bool
+	// 名称
+	Name *string
+	// 分页页码
+	Page *int64
+	// 分页大小
+	Size *int64
+	// 适用的系统类型
+	SystemType *string
+	// 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表
+	WithTotal *bool
+}
+
+// ListOesRunbook 查询检查单列表
+//
+// 本接口用于查询检查单列表，支持按名称、适用的系统类型和启用状态过滤
+//
+// GET /api/v1/oes/runbook
+func (c *Client) ListOesRunbook(ctx context.Context, params *ListOesRunbookParams) (*OesPagOesRunbookReply, error) {
+	req := &request{method: "GET", path: "/api/v1/oes/runbook"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "columns", params.Columns)
+		addPtr(req.query, "export", params.Export)
+		addPtr(req.query, "filter", params.Filter)
+		addPtr(req.query, "id", params.ID)
+		addPtr(req.query, "ids", params.IDs)
+		addPtr(req.query, "is_enabled", params.IsEnabled)
+		addPtr(req.query, "name", params.Name)
+		addPtr(req.query, "page", params.Page)
+		addPtr(req.query, "size", params.Size)
+		addPtr(req.query, "system_type", params.SystemType)
+		addPtr(req.query, "with_total", params.WithTotal)
+	}
+	out := new(OesPagOesRunbookReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateOesRunbook 创建检查单
+//
+// 本接口用于创建oes运维检查单，步骤按顺序执行，人工步骤由操作员确认完成，脚本步骤以集群号为参数执行oes的内置命令脚本
+//
+// POST /api/v1/oes/runbook
+func (c *Client) CreateOesRunbook(ctx context.Context, body *OesOesRunbookRequest) (*OesOesRunbookReply, error) {
+	req := &request{method: "POST", path: "/api/v1/oes/runbook"}
+	req.body = body
+	out := new(OesOesRunbookReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListOesRunbookExecParams ListOesRunbookExec的查询参数
+type ListOesRunbookExecParams struct {
+	// 导出的列(多个用,隔开), 默认导出全部列
+	Columns *string
+	// 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据
+	Export *string
+	// 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+	// 运算符: eq ne gt gte lt lte contains in between is_null not_null
+	// example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+	Filter *string
+	// 唯一标识
+	ID *int64
+	// "唯一标识列表(多个用,隔开)"
+	IDs *string
+	// oes集群ID
+	OesColonyID *int64
+	// 分页页码
+	Page *int64
+	// 检查单ID
+	RunbookID *int64
+	// 分页大小
+	Size *int64
+	// 执行状态
+	Status *string
+	// 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表
+	WithTotal *bool
+}
+
+// ListOesRunbookExec 查询检查单执行列表
+//
+// 本接口用于分页查询检查单执行及其进度
+//
+// GET /api/v1/oes/runbook/exec
+func (c *Client) ListOesRunbookExec(ctx context.Context, params *ListOesRunbookExecParams) (*OesPagOesRunbookExecReply, error) {
+	req := &request{method: "GET", path: "/api/v1/oes/runbook/exec"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "columns", params.Columns)
+		addPtr(req.query, "export", params.Export)
+		addPtr(req.query, "filter", params.Filter)
+		addPtr(req.query, "id", params.ID)
+		addPtr(req.query, "ids", params.IDs)
+		addPtr(req.query, "oes_colony_id", params.OesColonyID)
+		addPtr(req.query, "page", params.Page)
+		addPtr(req.query, "runbook_id", params.RunbookID)
+		addPtr(req.query, "size", params.Size)
+		addPtr(req.query, "status", params.Status)
+		addPtr(req.query, "with_total", params.WithTotal)
+	}
+	out := new(OesPagOesRunbookExecReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetOesRunbookExec 查询检查单执行详情
+//
+// 本接口用于查询检查单执行的进度及每个步骤的状态、操作员签核和脚本执行记录
+//
+// GET /api/v1/oes/runbook/exec/{id}
+func (c *Client) GetOesRunbookExec(ctx context.Context, id int32) (*OesOesRunbookExecReply, error) {
+	req := &request{method: "GET", path: "/api/v1/oes/runbook/exec/" + pathValue(id)}
+	out := new(OesOesRunbookExecReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateOesRunbookExecAbort 终止检查单执行
+//
+// 本接口用于终止未结束的检查单执行，未完成的步骤标记为跳过，脚本步骤执行中时不能终止
+//
+// POST /api/v1/oes/runbook/exec/{id}/abort
+func (c *Client) CreateOesRunbookExecAbort(ctx context.Context, id int32) (*OesOesRunbookExecReply, error) {
+	req := &request{method: "POST", path: "/api/v1/oes/runbook/exec/" + pathValue(id) + "/abort"}
+	out := new(OesOesRunbookExecReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateOesRunbookExecStepConfirm 确认检查单人工步骤
+//
+// 本接口用于操作员签核当前等待确认的人工步骤，确认后检查单进入下一步
+//
+// POST /api/v1/oes/runbook/exec/{id}/step/{step_id}/confirm
+func (c *Client) CreateOesRunbookExecStepConfirm(ctx context.Context, id int32, stepID int32, body *OesSignOffRunbookStepRequest) (*OesOesRunbookExecReply, error) {
+	req := &request{method: "POST", path: "/api/v1/oes/runbook/exec/" + pathValue(id) + "/step/" + pathValue(stepID) + "/confirm"}
+	req.body = body
+	out := new(OesOesRunbookExecReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateOesRunbookExecStepRetry 重试检查单脚本步骤
+//
+// 本接口用于操作员重新执行失败的脚本步骤，脚本在后台执行，成功后检查单进入下一步
+//
+// POST /api/v1/oes/runbook/exec/{id}/step/{step_id}/retry
+func (c *Client) CreateOesRunbookExecStepRetry(ctx context.Context, id int32, stepID int32, body *OesSignOffRunbookStepRequest) (*OesOesRunbookExecReply, error) {
+	req := &request{method: "POST", path: "/api/v1/oes/runbook/exec/" + pathValue(id) + "/step/" + pathValue(stepID) + "/retry"}
+	req.body = body
+	out := new(OesOesRunbookExecReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetOesRunbook 查询检查单详情
+//
+// 本接口用于查询指定ID的检查单及其步骤
+//
+// GET /api/v1/oes/runbook/{id}
+func (c *Client) GetOesRunbook(ctx context.Context, id int32) (*OesOesRunbookReply, error) {
+	req := &request{method: "GET", path: "/api/v1/oes/runbook/" + pathValue(id)}
+	out := new(OesOesRunbookReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateOesRunbook 更新检查单
+//
+// 本接口用于更新指定ID的检查单并替换全部步骤，不影响进行中的执行
+//
+// PUT /api/v1/oes/runbook/{id}
+func (c *Client) UpdateOesRunbook(ctx context.Context, id int32, body *OesOesRunbookRequest) (*OesOesRunbookReply, error) {
+	req := &request{method: "PUT", path: "/api/v1/oes/runbook/" + pathValue(id)}
+	req.body = body
+	out := new(OesOesRunbookReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteOesRunbook 删除检查单
+//
+// 本接口用于删除指定ID的检查单，不影响已发起的执行
+//
+// DELETE /api/v1/oes/runbook/{id}
+func (c *Client) DeleteOesRunbook(ctx context.Context, id int32) (*CommonMapAPIReply, error) {
+	req := &request{method: "DELETE", path: "/api/v1/oes/runbook/" + pathValue(id)}
+	out := new(CommonMapAPIReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListOesSlaParams ListOesSla的查询参数
+type ListOesSlaParams struct {
+	// 导出的列(多个用,隔开), 默认导出全部列
+	Columns *string
+	// 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据
+	Export *string
+	// 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+	// 运算符: eq ne gt gte lt lte contains in between is_null not_null
+	// example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+	Filter *string
+	// 唯一标识
+	ID *int64
+	// "唯一标识列表(多个用,隔开)"
+	IDs *string
+	// 是否启用
+	IsEnabled *bool
+	// oes集群ID, 0表示全部集群
+	OesColonyID *int64
+	// 分页页码
+	Page *int64
+	// 分页大小
+	Size *int64
+	// 任务名称
+	TaskName *string
+	// 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表
+	WithTotal *bool
+}
+
+// ListOesSla 查询任务SLA列表
+//
+// 本接口用于查询任务SLA列表，支持按任务名称、集群和启用状态过滤
+//
+// GET /api/v1/oes/sla
+func (c *Client) ListOesSla(ctx context.Context, params *ListOesSlaParams) (*OesPagOesTaskSlaReply, error) {
+	req := &request{method: "GET", path: "/api/v1/oes/sla"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "columns", params.Columns)
+		addPtr(req.query, "export", params.Export)
+		addPtr(req.query, "filter", params.Filter)
+		addPtr(req.query, "id", params.ID)
+		addPtr(req.query, "ids", params.IDs)
+		addPtr(req.query, "is_enabled", params.IsEnabled)
+		addPtr(req.query, "oes_colony_id", params.OesColonyID)
+		addPtr(req.query, "page", params.Page)
+		addPtr(req.query, "size", params.Size)
+		addPtr(req.query, "task_name", params.TaskName)
+		addPtr(req.query, "with_total", params.WithTotal)
+	}
+	out := new(OesPagOesTaskSlaReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateOesSla 创建任务SLA
+//
+// 本接口用于创建oes日常任务的SLA，定义任务每个交易日的完成截止时间，集群ID为0时适用全部集群
+//
+// POST /api/v1/oes/sla
+func (c *Client) CreateOesSla(ctx context.Context, body *OesOesTaskSlaRequest) (*OesOesTaskSlaReply, error) {
+	req := &request{method: "POST", path: "/api/v1/oes/sla"}
+	req.body = body
+	out := new(OesOesTaskSlaReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateOesSlaCheck 立即检查任务SLA
+//
+// 本接口用于立即检查全部启用集群当天日常任务的SLA并返回当天的达成情况，非交易日不检查
+//
+// POST /api/v1/oes/sla/check
+func (c *Client) CreateOesSlaCheck(ctx context.Context) (*OesTaskSlaComplianceReply, error) {
+	req := &request{method: "POST", path: "/api/v1/oes/sla/check"}
+	out := new(OesTaskSlaComplianceReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetOesSlaComplianceParams GetOesSlaCompliance的查询参数
+type GetOesSlaComplianceParams struct {
+	// oes集群ID
+	OesColonyID *int64
+	// 交易日, 格式为2006-01-02, 默认为当天
+	TradingDay *string
+}
+
+// GetOesSlaCompliance 查询每日SLA达成情况
+//
+// 本接口用于查询交易日各集群日常任务的SLA检查结果，统计按时完成、延迟完成、已超时和即将超时的任务数及达成率
+//
+// GET /api/v1/oes/sla/compliance
+func (c *Client) GetOesSlaCompliance(ctx context.Context, params *GetOesSlaComplianceParams) (*OesTaskSlaComplianceReply, error) {
+	req := &request{method: "GET", path: "/api/v1/oes/sla/compliance"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "oes_colony_id", params.OesColonyID)
+		addPtr(req.query, "trading_day", params.TradingDay)
+	}
+	out := new(OesTaskSlaComplianceReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetOesSla 查询任务SLA详情
+//
+// 本接口用于查询指定ID的任务SLA
+//
+// GET /api/v1/oes/sla/{id}
+func (c *Client) GetOesSla(ctx context.Context, id int32) (*OesOesTaskSlaReply, error) {
+	req := &request{method: "GET", path: "/api/v1/oes/sla/" + pathValue(id)}
+	out := new(OesOesTaskSlaReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateOesSla 更新任务SLA
+//
+// 本接口用于更新指定ID的任务SLA，下次检查时生效
+//
+// PUT /api/v1/oes/sla/{id}
+func (c *Client) UpdateOesSla(ctx context.Context, id int32, body *OesOesTaskSlaRequest) (*OesOesTaskSlaReply, error) {
+	req := &request{method: "PUT", path: "/api/v1/oes/sla/" + pathValue(id)}
+	req.body = body
+	out := new(OesOesTaskSlaReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteOesSla 删除任务SLA
+//
+// 本接口用于删除指定ID的任务SLA，已保存的检查结果保留
+//
+// DELETE /api/v1/oes/sla/{id}
+func (c *Client) DeleteOesSla(ctx context.Context, id int32) (*CommonMapAPIReply, error) {
+	req := &request{method: "DELETE", path: "/api/v1/oes/sla/" + pathValue(id)}
+	out := new(CommonMapAPIReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListOesTaskCatalogParams ListOesTaskCatalog的查询参数
+type ListOesTaskCatalogParams struct {
+	// 导出的列(多个用,隔开), 默认导出全部列
+	Columns *string
+	// 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据
+	Export *string
+	// 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+	// 运算符: eq ne gt gte lt lte contains in between is_null not_null
+	// example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+	Filter *string
+	// 唯一标识
+	ID *int64
+	// "唯一标识列表(多个用,隔开)"
+	IDs *string
+	// 是否启用
+	IsEnabled *bool
+	// 分页页码
+	Page *int64
+	// 分页大小
+	Size *int64
+	// 系统类型
+	SystemType *string
+	// 任务名称
+	TaskName *string
+	// 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表
+	WithTotal *bool
+}
+
+// ListOesTaskCatalog 查询日常任务目录
+//
+// 本接口用于查询日常任务目录，支持按系统类型、任务名称和启用状态过滤，按系统类型和排序顺序返回
+//
+// GET /api/v1/oes/task/catalog
+func (c *Client) ListOesTaskCatalog(ctx context.Context, params *ListOesTaskCatalogParams) (*OesPagOesTaskCatalogReply, error) {
+	req := &request{method: "GET", path: "/api/v1/oes/task/catalog"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "columns", params.Columns)
+		addPtr(req.query, "export", params.Export)
+		addPtr(req.query, "filter", params.Filter)
+		addPtr(req.query, "id", params.ID)
+		addPtr(req.query, "ids", params.IDs)
+		addPtr(req.query, "is_enabled", params.IsEnabled)
+		addPtr(req.query, "page", params.Page)
+		addPtr(req.query, "size", params.Size)
+		addPtr(req.query, "system_type", params.SystemType)
+		addPtr(req.query, "task_name", params.TaskName)
+		addPtr(req.query, "with_total", params.WithTotal)
+	}
+	out := new(OesPagOesTaskCatalogReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateOesTaskCatalog 创建日常任务
+//
+// 本接口用于在日常任务目录中新增系统类型的集群需要执行的任务，任务名称同时是集群任务标识文件的名称
+//
+// POST /api/v1/oes/task/catalog
+func (c *Client) CreateOesTaskCatalog(ctx context.Context, body *OesOesTaskCatalogRequest) (*OesOesTaskCatalogReply, error) {
+	req := &request{method: "POST", path: "/api/v1/oes/task/catalog"}
+	req.body = body
+	out := new(OesOesTaskCatalogReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetOesTaskCatalog 查询日常任务详情
+//
+// 本接口用于查询日常任务目录中指定ID的任务
+//
+// GET /api/v1/oes/task/catalog/{id}
+func (c *Client) GetOesTaskCatalog(ctx context.Context, id int32) (*OesOesTaskCatalogReply, error) {
+	req := &request{method: "GET", path: "/api/v1/oes/task/catalog/" + pathValue(id)}
+	out := new(OesOesTaskCatalogReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateOesTaskCatalog 更新日常任务
+//
+// 本接口用于更新日常任务目录中指定ID的任务，下次查询任务状态和检查SLA时生效
+//
+// PUT /api/v1/oes/task/catalog/{id}
+func (c *Client) UpdateOesTaskCatalog(ctx context.Context, id int32, body *OesOesTaskCatalogRequest) (*OesOesTaskCatalogReply, error) {
+	req := &request{method: "PUT", path: "/api/v1/oes/task/catalog/" + pathValue(id)}
+	req.body = body
+	out := new(OesOesTaskCatalogReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteOesTaskCatalog 删除日常任务
+//
+// 本接口用于从日常任务目录中删除指定ID的任务，已定义的SLA不再检查
+//
+// DELETE /api/v1/oes/task/catalog/{id}
+func (c *Client) DeleteOesTaskCatalog(ctx context.Context, id int32) (*CommonMapAPIReply, error) {
+	req := &request{method: "DELETE", path: "/api/v1/oes/task/catalog/" + pathValue(id)}
+	out := new(CommonMapAPIReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListOesWorkflowRunParams ListOesWorkflowRun的查询参数
+type ListOesWorkflowRunParams struct {
+	// 集群号
+	ColonyNum *string
+	// 导出的列(多个用,隔开), 默认导出全部列
+	Columns *string
+	// 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据
+	Export *string
+	// 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+	// 运算符: eq ne gt gte lt lte contains in between is_null not_null
+	// example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+	Filter *string
+	// 唯一标识
+	ID *int64
+	// "唯一标识列表(多个用,隔开)"
+	IDs *string
+	// oes集群ID
+	OesColonyID *int64
+	// 分页页码
+	Page *int64
+	// 分页大小
+	Size *int64
+	// 执行状态
+	Status *string
+	// 系统类型
+	SystemType *string
+	// 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表
+	WithTotal *bool
+}
+
+// ListOesWorkflowRun 查询oes任务编排执行记录列表
+//
+// 本接口用于分页查询oes任务编排执行记录
+//
+// GET /api/v1/oes/workflow/run
+func (c *Client) ListOesWorkflowRun(ctx context.Context, params *ListOesWorkflowRunParams) (*OesPagOesWorkflowRunReply, error) {
+	req := &request{method: "GET", path: "/api/v1/oes/workflow/run"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "colony_num", params.ColonyNum)
+		addPtr(req.query, "columns", params.Columns)
+		addPtr(req.query, "export", params.Export)
+		addPtr(req.query, "filter", params.Filter)
+		addPtr(req.query, "id", params.ID)
+		addPtr(req.query, "ids", params.IDs)
+		addPtr(req.query, "oes_colony_id", params.OesColonyID)
+		addPtr(req.query, "page", params.Page)
+		addPtr(req.query, "size", params.Size)
+		addPtr(req.query, "status", params.Status)
+		addPtr(req.query, "system_type", params.SystemType)
+		addPtr(req.query, "with_total", params.WithTotal)
+	}
+	out := new(OesPagOesWorkflowRunReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetOesWorkflowRun 查询oes任务编排执行详情
+//
+// 本接口用于查询编排执行记录及各步骤的状态和耗时
+//
+// GET /api/v1/oes/workflow/run/{id}
+func (c *Client) GetOesWorkflowRun(ctx context.Context, id int64) (*OesOesWorkflowRunReply, error) {
+	req := &request{method: "GET", path: "/api/v1/oes/workflow/run/" + pathValue(id)}
+	out := new(OesOesWorkflowRunReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetOesWorkflow 查询oes任务编排定义
+//
+// 本接口用于查询指定系统类型的日常任务步骤及依赖关系
+//
+// GET /api/v1/oes/workflow/{system_type}
+func (c *Client) GetOesWorkflow(ctx context.Context, systemType string) (*OesOesWorkflowDefinitionReply, error) {
+	req := &request{method: "GET", path: "/api/v1/oes/workflow/" + pathValue(systemType)}
+	out := new(OesOesWorkflowDefinitionReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListOesConf 获取oes配置文件列表
+//
+// 获取指定目录下的oes配置文件列表
+//
+// GET /api/v1/oes/{colony_num}/conf
+func (c *Client) ListOesConf(ctx context.Context, colonyNum string) (*OesPagOesConfReply, error) {
+	req := &request{method: "GET", path: "/api/v1/oes/" + pathValue(colonyNum) + "/conf"}
+	out := new(OesPagOesConfReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateOesConfForm CreateOesConf的表单
+type CreateOesConfForm struct {
+	// 配置文件
+	File *File
+}
+
+// CreateOesConf 上传oes配置文件
+//
+// 上传oes配置文件到指定目录
+//
+// POST /api/v1/oes/{colony_num}/conf/{dir_name}
+func (c *Client) CreateOesConf(ctx context.Context, colonyNum string, dirName string, form *CreateOesConfForm) (*CommonMapAPIReply, error) {
+	req := &request{method: "POST", path: "/api/v1/oes/" + pathValue(colonyNum) + "/conf/" + pathValue(dirName)}
+	req.form = &multipartForm{values: url.Values{}}
+	if form != nil {
+		req.form.addFile("file", form.File)
+	}
+	out := new(CommonMapAPIReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetOesConf 下载oes配置文件
+//
+// 下载指定的oes配置文件
+//
+// 返回原始响应体, 调用方读取完毕后需要关闭
+//
+// GET /api/v1/oes/{colony_num}/conf/{dir_name}/{filename}
+func (c *Client) GetOesConf(ctx context.Context, colonyNum string, dirName string, filename string) (io.ReadCloser, error) {
+	req := &request{method: "GET", path: "/api/v1/oes/" + pathValue(colonyNum) + "/conf/" + pathValue(dirName) + "/" + pathValue(filename)}
+	return c.doStream(ctx, req)
+}
+
+// DeleteOesConf 删除oes配置文件
+//
+// 删除指定的oes配置文件
+//
+// DELETE /api/v1/oes/{colony_num}/conf/{dir_name}/{filename}
+func (c *Client) DeleteOesConf(ctx context.Context, colonyNum string, dirName string, filename string) (*CommonMapAPIReply, error) {
+	req := &request{method: "DELETE", path: "/api/v1/oes/" + pathValue(colonyNum) + "/conf/" + pathValue(dirName) + "/" + pathValue(filename)}
+	out := new(CommonMapAPIReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateRefreshToken 刷新令牌接口
+//
+// 本接口用于刷新令牌, 开启Cookie认证时请求参数可以为空, 从Cookie中读取刷新令牌
+//
+// POST /api/v1/refresh/token
+func (c *Client) CreateRefreshToken(ctx context.Context, body *CustomerRefreshTokenRequest) (*CustomerLoginReply, error) {
+	req := &request{method: "POST", path: "/api/v1/refresh/token"}
+	req.body = body
+	out := new(CustomerLoginReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListResourceFileRuleParams ListResourceFileRule的查询参数
+type ListResourceFileRuleParams struct {
+	// 页码
+	Page *int64
+	// 每页数量
+	Size *int64
+	// 角色ID
+	RoleID *int32
+}
+
+// ListResourceFileRule 查询主机路径规则列表
+//
+// 本接口用于查询主机路径规则列表，支持按角色过滤
+//
+// GET /api/v1/resource/file/rule
+func (c *Client) ListResourceFileRule(ctx context.Context, params *ListResourceFileRuleParams) (*ResourcePagHostPathRuleReply, error) {
+	req := &request{method: "GET", path: "/api/v1/resource/file/rule"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "page", params.Page)
+		addPtr(req.query, "size", params.Size)
+		addPtr(req.query, "role_id", params.RoleID)
+	}
+	out := new(ResourcePagHostPathRuleReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateResourceFileRule 创建主机路径规则
+//
+// 本接口用于为角色配置允许通过文件管理访问的主机目录，规则对全部主机生效
+//
+// POST /api/v1/resource/file/rule
+func (c *Client) CreateResourceFileRule(ctx context.Context, body *ResourceHostPathRuleRequest) (*ResourceHostPathRuleReply, error) {
+	req := &request{method: "POST", path: "/api/v1/resource/file/rule"}
+	req.body = body
+	out := new(ResourceHostPathRuleReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateResourceFileRule 更新主机路径规则
+//
+// 本接口用于更新指定ID的主机路径规则
+//
+// PUT /api/v1/resource/file/rule/{id}
+func (c *Client) UpdateResourceFileRule(ctx context.Context, id int32, body *ResourceHostPathRuleRequest) (*ResourceHostPathRuleReply, error) {
+	req := &request{method: "PUT", path: "/api/v1/resource/file/rule/" + pathValue(id)}
+	req.body = body
+	out := new(ResourceHostPathRuleReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteResourceFileRule 删除主机路径规则
+//
+// 本接口用于删除指定ID的主机路径规则
+//
+// DELETE /api/v1/resource/file/rule/{id}
+func (c *Client) DeleteResourceFileRule(ctx context.Context, id int32) (*CommonMapAPIReply, error) {
+	req := &request{method: "DELETE", path: "/api/v1/resource/file/rule/" + pathValue(id)}
+	out := new(CommonMapAPIReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListResourceHostParams ListResourceHost的查询参数
+type ListResourceHostParams struct {
+	// 创建时间之后的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	AfterCreatedAt *string
+	// 更新时间之后的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	AfterUpdatedAt *string
+	// 创建时间之前的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	BeforeCreatedAt *string
+	// 更新时间之前的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	BeforeUpdatedAt *string
+	// 导出的列(多个用,隔开), 默认导出全部列
+	Columns *string
+	// 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据
+	Export *string
+	// 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+	// 运算符: eq ne gt gte lt lte contains in between is_null not_null
+	// example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+	Filter *string
+	// 唯一标识
+	ID *int64
+	// "唯一标识列表(多个用,隔开)"
+	IDs *string
+	// 标签
+	Label *string
+	// 名称
+	Name *string
+	// 分页页码
+	Page *int64
+	// python路径
+	PyPath *string
+	// 备注
+	Remark *string
+	// 分页大小
+	Size *int64
+	// ip地址
+	SSHIP *string
+	// 端口
+	SSHPort *int64
+	// 用户名
+	SSHUser *string
+	// 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表
+	WithTotal *bool
+}
+
+// ListResourceHost 查询主机列表
+//
+// 本接口用于查询主机配置信息列表, 支持通过filter参数按字段组合过滤
+//
+// GET /api/v1/resource/host
+func (c *Client) ListResourceHost(ctx context.Context, params *ListResourceHostParams) (*ResourcePagHostReply, error) {
+	req := &request{method: "GET", path: "/api/v1/resource/host"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "after_created_at", params.AfterCreatedAt)
+		addPtr(req.query, "after_updated_at", params.AfterUpdatedAt)
+		addPtr(req.query, "before_created_at", params.BeforeCreatedAt)
+		addPtr(req.query, "before_updated_at", params.BeforeUpdatedAt)
+		addPtr(req.query, "columns", params.Columns)
+		addPtr(req.query, "export", params.Export)
+		addPtr(req.query, "filter", params.Filter)
+		addPtr(req.query, "id", params.ID)
+		addPtr(req.query, "ids", params.IDs)
+		addPtr(req.query, "label", params.Label)
+		addPtr(req.query, "name", params.Name)
+		addPtr(req.query, "page", params.Page)
+		addPtr(req.query, "py_path", params.PyPath)
+		addPtr(req.query, "remark", params.Remark)
+		addPtr(req.query, "size", params.Size)
+		addPtr(req.query, "ssh_ip", params.SSHIP)
+		addPtr(req.query, "ssh_port", params.SSHPort)
+		addPtr(req.query, "ssh_user", params.SSHUser)
+		addPtr(req.query, "with_total", params.WithTotal)
+	}
+	out := new(ResourcePagHostReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateResourceHost 创建主机
+//
+// 本接口用于创建新的主机配置信息
+//
+// POST /api/v1/resource/host
+func (c *Client) CreateResourceHost(ctx context.Context, body *ResourceCreateOrUpdateHosrRequest) (*ResourceHostReply, error) {
+	req := &request{method: "POST", path: "/api/v1/resource/host"}
+	req.body = body
+	out := new(ResourceHostReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetResourceHostDuplicates 查询疑似重复的主机
+//
+// 本接口用于查找重复登记的主机, 按机器序列号、SSH地址、代理上报的IP地址和主机名匹配, 回环地址和链路本地地址不作为匹配依据
+//
+// GET /api/v1/resource/host/duplicates
+func (c *Client) GetResourceHostDuplicates(ctx context.Context) (*ResourceHostDuplicateReply, error) {
+	req := &request{method: "GET", path: "/api/v1/resource/host/duplicates"}
+	out := new(ResourceHostDuplicateReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetResourceHost 查询主机详情
+//
+// 本接口用于查询指定ID的主机详细信息
+//
+// GET /api/v1/resource/host/{id}
+func (c *Client) GetResourceHost(ctx context.Context, id int64) (*ResourceHostReply, error) {
+	req := &request{method: "GET", path: "/api/v1/resource/host/" + pathValue(id)}
+	out := new(ResourceHostReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateResourceHost 更新主机
+//
+// 本接口用于更新指定ID的主机配置信息
+//
+// PUT /api/v1/resource/host/{id}
+func (c *Client) UpdateResourceHost(ctx context.Context, id int64, body *ResourceCreateOrUpdateHosrRequest) (*ResourceHostReply, error) {
+	req := &request{method: "PUT", path: "/api/v1/resource/host/" + pathValue(id)}
+	req.body = body
+	out := new(ResourceHostReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteResourceHost 删除主机
+//
+// 本接口用于删除指定ID的主机配置信息
+//
+// DELETE /api/v1/resource/host/{id}
+func (c *Client) DeleteResourceHost(ctx context.Context, id int64) (*CommonMapAPIReply, error) {
+	req := &request{method: "DELETE", path: "/api/v1/resource/host/" + pathValue(id)}
+	out := new(CommonMapAPIReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListResourceHostFileParams ListResourceHostFile的查询参数
+type ListResourceHostFileParams struct {
+	// 目录的绝对路径
+	Path string
+}
+
+// ListResourceHostFile 列出主机目录
+//
+// 本接口用于列出主机上指定目录的内容，目录须在当前角色的主机路径规则允许的范围内
+//
+// GET /api/v1/resource/host/{id}/file
+func (c *Client) ListResourceHostFile(ctx context.Context, id int32, params *ListResourceHostFileParams) (*ResourceListHostFileReply, error) {
+	req := &request{method: "GET", path: "/api/v1/resource/host/" + pathValue(id) + "/file"}
+	if params != nil {
+		req.query = url.Values{}
+		addValue(req.query, "path", params.Path)
+	}
+	out := new(ResourceListHostFileReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetResourceHostFileDownloadParams GetResourceHostFileDownload的查询参数
+type GetResourceHostFileDownloadParams struct {
+	// 文件的绝对路径
+	Path string
+}
+
+// GetResourceHostFileDownload 下载主机文件
+//
+// 本接口用于下载主机上的文件，下载操作会记录审计日志
+//
+// 返回原始响应体, 调用方读取完毕后需要关闭
+//
+// GET /api/v1/resource/host/{id}/file/download
+func (c *Client) GetResourceHostFileDownload(ctx context.Context, id int32, params *GetResourceHostFileDownloadParams) (io.ReadCloser, error) {
+	req := &request{method: "GET", path: "/api/v1/resource/host/" + pathValue(id) + "/file/download"}
+	if params != nil {
+		req.query = url.Values{}
+		addValue(req.query, "path", params.Path)
+	}
+	return c.doStream(ctx, req)
+}
+
+// GetResourceHostFileStatParams GetResourceHostFileStat的查询参数
+type GetResourceHostFileStatParams struct {
+	// 文件或目录的绝对路径
+	Path string
+}
+
+// GetResourceHostFileStat 查询主机文件信息
+//
+// 本接口用于查询主机上指定文件或目录的信息，路径中的符号链接会被解析
+//
+// GET /api/v1/resource/host/{id}/file/stat
+func (c *Client) GetResourceHostFileStat(ctx context.Context, id int32, params *GetResourceHostFileStatParams) (*ResourceHostFileReply, error) {
+	req := &request{method: "GET", path: "/api/v1/resource/host/" + pathValue(id) + "/file/stat"}
+	if params != nil {
+		req.query = url.Values{}
+		addValue(req.query, "path", params.Path)
+	}
+	out := new(ResourceHostFileReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateResourceHostFileUploadForm CreateResourceHostFileUpload的表单
+type CreateResourceHostFileUploadForm struct {
+	// 目标目录的绝对路径
+	Path string
+	// 目标文件已存在时是否覆盖
+	Overwrite *bool
+	// 上传的文件
+	File *File
+}
+
+// CreateResourceHostFileUpload 上传文件到主机
+//
+// 本接口用于上传文件到主机的指定目录，目录须在当前角色允许上传的主机路径规则范围内，上传操作会记录审计日志
+//
+// POST /api/v1/resource/host/{id}/file/upload
+func (c *Client) CreateResourceHostFileUpload(ctx context.Context, id int32, form *CreateResourceHostFileUploadForm) (*ResourceHostFileReply, error) {
+	req := &request{method: "POST", path: "/api/v1/resource/host/" + pathValue(id) + "/file/upload"}
+	req.form = &multipartForm{values: url.Values{}}
+	if form != nil {
+		addValue(req.form.values, "path", form.Path)
+		addPtr(req.form.values, "overwrite", form.Overwrite)
+		req.form.addFile("file", form.File)
+	}
+	out := new(ResourceHostFileReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateResourceHostMerge 合并重复的主机
+//
+// 本接口用于将重复主机的集群节点、进程守护、主机代理和历史记录改为引用路径中的主机, 然后删除重复主机, 合并在同一事务中执行并记录审计
+//
+// POST /api/v1/resource/host/{id}/merge
+func (c *Client) CreateResourceHostMerge(ctx context.Context, id int64, body *ResourceMergeHostRequest) (*ResourceHostMergeReply, error) {
+	req := &request{method: "POST", path: "/api/v1/resource/host/" + pathValue(id) + "/merge"}
+	req.body = body
+	out := new(ResourceHostMergeReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetResourceHostTerminalParams GetResourceHostTerminal的查询参数
+type GetResourceHostTerminalParams struct {
+	// 终端列数
+	Cols *int64
+	// 终端行数
+	Rows *int64
+}
+
+// GetResourceHostTerminal 打开网页终端
+//
+// 本接口通过WebSocket打开指定主机的交互式终端，使用平台SSH密钥登录。
+// 浏览器可通过查询参数Authorization或Sec-WebSocket-Protocol传递令牌。
+// 服务端以二进制帧发送终端输出；客户端发送JSON文本帧，{"type":"input","data":"ls\r"}为键盘输入，{"type":"resize","cols":120,"rows":40}为调整终端大小。
+// 超过空闲时间无输入或超过最长会话时间后服务端主动断开连接。
+//
+// GET /api/v1/resource/host/{id}/terminal
+func (c *Client) GetResourceHostTerminal(ctx context.Context, id int32, params *GetResourceHostTerminalParams) error {
+	req := &request{method: "GET", path: "/api/v1/resource/host/" + pathValue(id) + "/terminal"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "cols", params.Cols)
+		addPtr(req.query, "rows", params.Rows)
+	}
+	return c.doJSON(ctx, req, nil)
+}
+
+// ListResourcePackageParams ListResourcePackage的查询参数
+type ListResourcePackageParams struct {
+	// 上传时间之后的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	AfterUploadedAt *string
+	// 上传时间之前的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	BeforeUploadedAt *string
+	// 导出的列(多个用,隔开), 默认导出全部列
+	Columns *string
+	// 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据
+	Export *string
+	// 文件名
+	Filename *string
+	// 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+	// 运算符: eq ne gt gte lt lte contains in between is_null not_null
+	// example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+	Filter *string
+	// 唯一标识
+	ID *int64
+	// "唯一标识列表(多个用,隔开)"
+	IDs *string
+	// 标签
+	Label *string
+	// 标签组(多个用,隔开)
+	Labels *string
+	// 分页页码
+	Page *int64
+	// 分页大小
+	Size *int64
+	// 版本号
+	Version *string
+	// 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表
+	WithTotal *bool
+}
+
+// ListResourcePackage 查询程序包列表
+//
+// 本接口用于查询程序包列表
+//
+// GET /api/v1/resource/package
+func (c *Client) ListResourcePackage(ctx context.Context, params *ListResourcePackageParams) (*ResourcePagPackageReply, error) {
+	req := &request{method: "GET", path: "/api/v1/resource/package"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "after_uploaded_at", params.AfterUploadedAt)
+		addPtr(req.query, "before_uploaded_at", params.BeforeUploadedAt)
+		addPtr(req.query, "columns", params.Columns)
+		addPtr(req.query, "export", params.Export)
+		addPtr(req.query, "filename", params.Filename)
+		addPtr(req.query, "filter", params.Filter)
+		addPtr(req.query, "id", params.ID)
+		addPtr(req.query, "ids", params.IDs)
+		addPtr(req.query, "label", params.Label)
+		addPtr(req.query, "labels", params.Labels)
+		addPtr(req.query, "page", params.Page)
+		addPtr(req.query, "size", params.Size)
+		addPtr(req.query, "version", params.Version)
+		addPtr(req.query, "with_total", params.WithTotal)
+	}
+	out := new(ResourcePagPackageReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateResourcePackageForm CreateResourcePackage的表单
+type CreateResourcePackageForm struct {
+	// 程序包标签，长度限制：1-50个字符
+	Label string
+	// 程序包版本，长度限制：1-50个字符
+	Version string
+	// 程序包文件
+	File *File
+	// 文件SHA256校验和，不为空时校验上传的文件
+	Checksum *string
+}
+
+// CreateResourcePackage 上传程序包
+//
+// 上传一个新的程序包文件并创建记录
+//
+// POST /api/v1/resource/package
+func (c *Client) CreateResourcePackage(ctx context.Context, form *CreateResourcePackageForm) (*ResourcePackageReply, error) {
+	req := &request{method: "POST", path: "/api/v1/resource/package"}
+	req.form = &multipartForm{values: url.Values{}}
+	if form != nil {
+		addValue(req.form.values, "label", form.Label)
+		addValue(req.form.values, "version", form.Version)
+		req.form.addFile("file", form.File)
+		addPtr(req.form.values, "checksum", form.Checksum)
+	}
+	out := new(ResourcePackageReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateResourcePackageUpload 创建分片上传会话
+//
+// 本接口用于创建程序包分片上传会话，适用于大文件上传和断点续传
+//
+// POST /api/v1/resource/package/upload
+func (c *Client) CreateResourcePackageUpload(ctx context.Context, body *ResourceInitPackageUploadRequest) (*ResourcePackageUploadReply, error) {
+	req := &request{method: "POST", path: "/api/v1/resource/package/upload"}
+	req.body = body
+	out := new(ResourcePackageUploadReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetResourcePackageUpload 查询分片上传会话
+//
+// 本接口用于查询分片上传会话，续传时以返回的已接收字节数作为下一个分片的起始位置
+//
+// GET /api/v1/resource/package/upload/{upload_id}
+func (c *Client) GetResourcePackageUpload(ctx context.Context, uploadID string) (*ResourcePackageUploadReply, error) {
+	req := &request{method: "GET", path: "/api/v1/resource/package/upload/" + pathValue(uploadID)}
+	out := new(ResourcePackageUploadReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateResourcePackageUploadParams UpdateResourcePackageUpload的查询参数
+type UpdateResourcePackageUploadParams struct {
+	// 分片在文件中的起始位置
+	Offset int64
+	// 分片SHA256校验和
+	Checksum *string
+}
+
+// UpdateResourcePackageUpload 上传分片
+//
+// 本接口用于上传一个分片，请求体为分片内容，offset必须等于已接收的字节数
+//
+// PUT /api/v1/resource/package/upload/{upload_id}
+func (c *Client) UpdateResourcePackageUpload(ctx context.Context, uploadID string, params *UpdateResourcePackageUploadParams) (*ResourcePackageUploadReply, error) {
+	req := &request{method: "PUT", path: "/api/v1/resource/package/upload/" + pathValue(uploadID)}
+	if params != nil {
+		req.query = url.Values{}
+		addValue(req.query, "offset", params.Offset)
+		addPtr(req.query, "checksum", params.Checksum)
+	}
+	out := new(ResourcePackageUploadReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteResourcePackageUpload 取消分片上传
+//
+// 本接口用于取消分片上传，删除上传会话和已上传的分片
+//
+// DELETE /api/v1/resource/package/upload/{upload_id}
+func (c *Client) DeleteResourcePackageUpload(ctx context.Context, uploadID string) (*CommonMapAPIReply, error) {
+	req := &request{method: "DELETE", path: "/api/v1/resource/package/upload/" + pathValue(uploadID)}
+	out := new(CommonMapAPIReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateResourcePackageUploadComplete 完成分片上传
+//
+// 本接口用于完成分片上传，校验压缩包结构和校验和后创建程序包
+//
+// POST /api/v1/resource/package/upload/{upload_id}/complete
+func (c *Client) CreateResourcePackageUploadComplete(ctx context.Context, uploadID string) (*ResourcePackageReply, error) {
+	req := &request{method: "POST", path: "/api/v1/resource/package/upload/" + pathValue(uploadID) + "/complete"}
+	out := new(ResourcePackageReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetResourcePackage 查询程序包详情
+//
+// 本接口用于查询指定ID的程序包详细信息
+//
+// GET /api/v1/resource/package/{id}
+func (c *Client) GetResourcePackage(ctx context.Context, id int32) (*ResourcePackageReply, error) {
+	req := &request{method: "GET", path: "/api/v1/resource/package/" + pathValue(id)}
+	out := new(ResourcePackageReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteResourcePackage 删除程序包
+//
+// 本接口用于删除指定ID的程序包
+//
+// DELETE /api/v1/resource/package/{id}
+func (c *Client) DeleteResourcePackage(ctx context.Context, id int32) (*CommonMapAPIReply, error) {
+	req := &request{method: "DELETE", path: "/api/v1/resource/package/" + pathValue(id)}
+	out := new(CommonMapAPIReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetResourcePackageDownload 下载程序包
+//
+// 本接口用于下载指定ID的程序包文件
+//
+// 返回原始响应体, 调用方读取完毕后需要关闭
+//
+// GET /api/v1/resource/package/{id}/download
+func (c *Client) GetResourcePackageDownload(ctx context.Context, id int32) (io.ReadCloser, error) {
+	req := &request{method: "GET", path: "/api/v1/resource/package/" + pathValue(id) + "/download"}
+	return c.doStream(ctx, req)
+}
+
+// GetResourcePackageDownloadURL 获取程序包下载地址
+//
+// 本接口用于获取指定ID的程序包下载地址，存储后端支持时返回预签名地址
+//
+// GET /api/v1/resource/package/{id}/download-url
+func (c *Client) GetResourcePackageDownloadURL(ctx context.Context, id int32) (*ResourcePackageDownloadURLReply, error) {
+	req := &request{method: "GET", path: "/api/v1/resource/package/" + pathValue(id) + "/download-url"}
+	out := new(ResourcePackageDownloadURLReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListResourceTerminalParams ListResourceTerminal的查询参数
+type ListResourceTerminalParams struct {
+	// 页码
+	Page *int64
+	// 每页数量
+	Size *int64
+	// 主机ID
+	HostID *int32
+	// 操作用户
+	Username *string
+}
+
+// ListResourceTerminal 查询终端会话记录列表
+//
+// 本接口用于查询网页终端会话记录列表，支持按主机和操作用户过滤
+//
+// GET /api/v1/resource/terminal
+func (c *Client) ListResourceTerminal(ctx context.Context, params *ListResourceTerminalParams) (*ResourcePagTerminalSessionReply, error) {
+	req := &request{method: "GET", path: "/api/v1/resource/terminal"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "page", params.Page)
+		addPtr(req.query, "size", params.Size)
+		addPtr(req.query, "host_id", params.HostID)
+		addPtr(req.query, "username", params.Username)
+	}
+	out := new(ResourcePagTerminalSessionReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetResourceTerminalRecord 下载终端会话录像
+//
+// 本接口用于下载网页终端会话录像，格式为asciinema v2，可使用asciinema play回放
+//
+// 返回原始响应体, 调用方读取完毕后需要关闭
+//
+// GET /api/v1/resource/terminal/{id}/record
+func (c *Client) GetResourceTerminalRecord(ctx context.Context, id int32) (io.ReadCloser, error) {
+	req := &request{method: "GET", path: "/api/v1/resource/terminal/" + pathValue(id) + "/record"}
+	return c.doStream(ctx, req)
+}
+
+// ListResourceWatchdogParams ListResourceWatchdog的查询参数
+type ListResourceWatchdogParams struct {
+	// 创建时间之后的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	AfterCreatedAt *string
+	// 更新时间之后的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	AfterUpdatedAt *string
+	// 创建时间之前的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	BeforeCreatedAt *string
+	// 更新时间之前的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	BeforeUpdatedAt *string
+	// 导出的列(多个用,隔开), 默认导出全部列
+	Columns *string
+	// 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据
+	Export *string
+	// 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+	// 运算符: eq ne gt gte lt lte contains in between is_null not_null
+	// example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+	Filter *string
+	// 主机ID
+	HostID *int64
+	// 唯一标识
+	ID *int64
+	// "唯一标识列表(多个用,隔开)"
+	IDs *string
+	// 是否启用
+	IsEnabled *bool
+	// 名称
+	Name *string
+	// 分页页码
+	Page *int64
+	// 分页大小
+	Size *int64
+	// 检查状态
+	Status *string
+	// 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表
+	WithTotal *bool
+}
+
+// ListResourceWatchdog 查询进程守护列表
+//
+// 本接口用于查询进程守护列表，可按status=failed查询不符合期望状态的进程
+//
+// GET /api/v1/resource/watchdog
+func (c *Client) ListResourceWatchdog(ctx context.Context, params *ListResourceWatchdogParams) (*ResourcePagWatchdogReply, error) {
+	req := &request{method: "GET", path: "/api/v1/resource/watchdog"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "after_created_at", params.AfterCreatedAt)
+		addPtr(req.query, "after_updated_at", params.AfterUpdatedAt)
+		addPtr(req.query, "before_created_at", params.BeforeCreatedAt)
+		addPtr(req.query, "before_updated_at", params.BeforeUpdatedAt)
+		addPtr(req.query, "columns", params.Columns)
+		addPtr(req.query, "export", params.Export)
+		addPtr(req.query, "filter", params.Filter)
+		addPtr(req.query, "host_id", params.HostID)
+		addPtr(req.query, "id", params.ID)
+		addPtr(req.query, "ids", params.IDs)
+		addPtr(req.query, "is_enabled", params.IsEnabled)
+		addPtr(req.query, "name", params.Name)
+		addPtr(req.query, "page", params.Page)
+		addPtr(req.query, "size", params.Size)
+		addPtr(req.query, "status", params.Status)
+		addPtr(req.query, "with_total", params.WithTotal)
+	}
+	out := new(ResourcePagWatchdogReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateResourceWatchdog 创建进程守护
+//
+// 本接口用于为主机配置需要守护的进程或systemd服务，定时检查是否处于期望状态，期望运行但未运行时按重启策略重启并告警
+//
+// POST /api/v1/resource/watchdog
+func (c *Client) CreateResourceWatchdog(ctx context.Context, body *ResourceWatchdogRequest) (*ResourceWatchdogReply, error) {
+	req := &request{method: "POST", path: "/api/v1/resource/watchdog"}
+	req.body = body
+	out := new(ResourceWatchdogReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetResourceWatchdog 查询进程守护详情
+//
+// 本接口用于查询指定ID的进程守护和最近一次检查结果
+//
+// GET /api/v1/resource/watchdog/{id}
+func (c *Client) GetResourceWatchdog(ctx context.Context, id int32) (*ResourceWatchdogReply, error) {
+	req := &request{method: "GET", path: "/api/v1/resource/watchdog/" + pathValue(id)}
+	out := new(ResourceWatchdogReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateResourceWatchdog 更新进程守护
+//
+// 本接口用于更新指定ID的进程守护，检查状态保留到下一次检查
+//
+// PUT /api/v1/resource/watchdog/{id}
+func (c *Client) UpdateResourceWatchdog(ctx context.Context, id int32, body *ResourceWatchdogRequest) (*ResourceWatchdogReply, error) {
+	req := &request{method: "PUT", path: "/api/v1/resource/watchdog/" + pathValue(id)}
+	req.body = body
+	out := new(ResourceWatchdogReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteResourceWatchdog 删除进程守护
+//
+// 本接口用于删除指定ID的进程守护及其历史
+//
+// DELETE /api/v1/resource/watchdog/{id}
+func (c *Client) DeleteResourceWatchdog(ctx context.Context, id int32) (*CommonMapAPIReply, error) {
+	req := &request{method: "DELETE", path: "/api/v1/resource/watchdog/" + pathValue(id)}
+	out := new(CommonMapAPIReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateResourceWatchdogCheck 立即检查进程守护
+//
+// 本接口用于立即检查指定ID的进程守护，与定时检查相同，不符合期望状态时按重启策略重启
+//
+// POST /api/v1/resource/watchdog/{id}/check
+func (c *Client) CreateResourceWatchdogCheck(ctx context.Context, id int32) (*ResourceWatchdogReply, error) {
+	req := &request{method: "POST", path: "/api/v1/resource/watchdog/" + pathValue(id) + "/check"}
+	out := new(ResourceWatchdogReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListResourceWatchdogEventParams ListResourceWatchdogEvent的查询参数
+type ListResourceWatchdogEventParams struct {
+	// 动作
+	Action *string
+	// 导出的列(多个用,隔开), 默认导出全部列
+	Columns *string
+	// 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据
+	Export *string
+	// 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+	// 运算符: eq ne gt gte lt lte contains in between is_null not_null
+	// example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+	Filter *string
+	// 唯一标识
+	ID *int64
+	// "唯一标识列表(多个用,隔开)"
+	IDs *string
+	// 分页页码
+	Page *int64
+	// 分页大小
+	Size *int64
+	// 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表
+	WithTotal *bool
+}
+
+// ListResourceWatchdogEvent 查询进程守护历史
+//
+// 本接口用于查询指定ID的进程守护的状态变化和重启记录，按时间倒序
+//
+// GET /api/v1/resource/watchdog/{id}/event
+func (c *Client) ListResourceWatchdogEvent(ctx context.Context, id int32, params *ListResourceWatchdogEventParams) (*ResourcePagWatchdogEventReply, error) {
+	req := &request{method: "GET", path: "/api/v1/resource/watchdog/" + pathValue(id) + "/event"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "action", params.Action)
+		addPtr(req.query, "columns", params.Columns)
+		addPtr(req.query, "export", params.Export)
+		addPtr(req.query, "filter", params.Filter)
+		addPtr(req.query, "id", params.ID)
+		addPtr(req.query, "ids", params.IDs)
+		addPtr(req.query, "page", params.Page)
+		addPtr(req.query, "size", params.Size)
+		addPtr(req.query, "with_total", params.WithTotal)
+	}
+	out := new(ResourcePagWatchdogEventReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetSearchParams GetSearch的查询参数
+type GetSearchParams struct {
+	// 每组最多返回的结果数, 默认5
+	Limit *int64
+	// 关键字, 匹配名称和描述
+	Q string
+}
+
+// GetSearch 跨模块搜索
+//
+// 本接口用于按名称和描述同时搜索用户、集群、程序包、脚本和菜单, 结果按资源类型分组, 只返回当前角色有权查看列表的资源
+//
+// GET /api/v1/search
+func (c *Client) GetSearch(ctx context.Context, params *GetSearchParams) (*SystemSearchReply, error) {
+	req := &request{method: "GET", path: "/api/v1/search"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "limit", params.Limit)
+		addValue(req.query, "q", params.Q)
+	}
+	out := new(SystemSearchReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetStatsAlertsParams GetStatsAlerts的查询参数
+type GetStatsAlertsParams struct {
+	// 返回的告警数, 默认10条
+	Limit *int64
+}
+
+// GetStatsAlerts 最近告警
+//
+// 本接口用于查询最近发出的告警, 包括监控节点状态变化和集群配置漂移, 结果会缓存一段时间
+//
+// GET /api/v1/stats/alerts
+func (c *Client) GetStatsAlerts(ctx context.Context, params *GetStatsAlertsParams) (*SystemStatsAlertReply, error) {
+	req := &request{method: "GET", path: "/api/v1/stats/alerts"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "limit", params.Limit)
+	}
+	out := new(SystemStatsAlertReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetStatsColonies 集群启用统计
+//
+// 本接口用于查询oes和mds项目启用和停用的集群数, 结果会缓存一段时间
+//
+// GET /api/v1/stats/colonies
+func (c *Client) GetStatsColonies(ctx context.Context) (*SystemStatsColonyReply, error) {
+	req := &request{method: "GET", path: "/api/v1/stats/colonies"}
+	out := new(SystemStatsColonyReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetStatsJobsParams GetStatsJobs的查询参数
+type GetStatsJobsParams struct {
+	// 统计最近多少天(含今天), 默认7天
+	Days *int64
+}
+
+// GetStatsJobs 任务执行统计
+//
+// 本接口用于查询最近若干天每天的脚本执行成功和失败数, 结果会缓存一段时间
+//
+// GET /api/v1/stats/jobs
+func (c *Client) GetStatsJobs(ctx context.Context, params *GetStatsJobsParams) (*SystemStatsJobReply, error) {
+	req := &request{method: "GET", path: "/api/v1/stats/jobs"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "days", params.Days)
+	}
+	out := new(SystemStatsJobReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetStatsUsers 用户统计
+//
+// 本接口用于查询用户总数、已激活用户数和最近活跃的用户数, 活跃天数由配置指定, 结果会缓存一段时间
+//
+// GET /api/v1/stats/users
+func (c *Client) GetStatsUsers(ctx context.Context) (*SystemStatsUserReply, error) {
+	req := &request{method: "GET", path: "/api/v1/stats/users"}
+	out := new(SystemStatsUserReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateSystemAnalyticsPurge 清理过期统计事件
+//
+// 本接口用于立即清理超过保留天数的统计事件
+//
+// POST /api/v1/system/analytics/purge
+func (c *Client) CreateSystemAnalyticsPurge(ctx context.Context) (*CommonMapAPIReply, error) {
+	req := &request{method: "POST", path: "/api/v1/system/analytics/purge"}
+	out := new(CommonMapAPIReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetSystemAnalyticsUsageParams GetSystemAnalyticsUsage的查询参数
+type GetSystemAnalyticsUsageParams struct {
+	// 结束时间 (RFC3339格式)
+	// example: 2023-01-02T00:00:00Z
+	EndAt *string
+	// 聚合维度(feature:按功能, module:按模块, role:按角色, feature_role:按功能和角色)
+	GroupBy *string
+	// 返回条数上限
+	Limit *int64
+	// 所属模块
+	Module *string
+	// 角色ID
+	RoleID *int64
+	// 开始时间 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	StartAt *string
+}
+
+// GetSystemAnalyticsUsage 查询功能使用统计
+//
+// 本接口用于按功能、模块或角色聚合查询平台使用情况，不返回用户身份信息
+//
+// GET /api/v1/system/analytics/usage
+func (c *Client) GetSystemAnalyticsUsage(ctx context.Context, params *GetSystemAnalyticsUsageParams) (*SystemAnalyticsUsageReply, error) {
+	req := &request{method: "GET", path: "/api/v1/system/analytics/usage"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "end_at", params.EndAt)
+		addPtr(req.query, "group_by", params.GroupBy)
+		addPtr(req.query, "limit", params.Limit)
+		addPtr(req.query, "module", params.Module)
+		addPtr(req.query, "role_id", params.RoleID)
+		addPtr(req.query, "start_at", params.StartAt)
+	}
+	out := new(SystemAnalyticsUsageReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListSystemAuditParams ListSystemAudit的查询参数
+type ListSystemAuditParams struct {
+	// 操作类型
+	Action *string
+	// 导出的列(多个用,隔开), 默认导出全部列
+	Columns *string
+	// 结束时间 (RFC3339格式)
+	// example: 2023-01-02T00:00:00Z
+	EndAt *string
+	// 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据
+	Export *string
+	// 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+	// 运算符: eq ne gt gte lt lte contains in between is_null not_null
+	// example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+	Filter *string
+	// 唯一标识
+	ID *int64
+	// "唯一标识列表(多个用,隔开)"
+	IDs *string
+	// 所属模块
+	Module *string
+	// 分页页码
+	Page *int64
+	// 资源类型
+	Resource *string
+	// 资源ID
+	ResourceID *int64
+	// 分页大小
+	Size *int64
+	// 开始时间 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	StartAt *string
+	// 操作用户
+	Username *string
+	// 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表
+	WithTotal *bool
+}
+
+// ListSystemAudit 查询审计记录列表
+//
+// 本接口用于分页查询数据变更审计记录, 支持通过filter参数按字段组合过滤
+//
+// GET /api/v1/system/audit
+func (c *Client) ListSystemAudit(ctx context.Context, params *ListSystemAuditParams) (*SystemPagAuditRecordReply, error) {
+	req := &request{method: "GET", path: "/api/v1/system/audit"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "action", params.Action)
+		addPtr(req.query, "columns", params.Columns)
+		addPtr(req.query, "end_at", params.EndAt)
+		addPtr(req.query, "export", params.Export)
+		addPtr(req.query, "filter", params.Filter)
+		addPtr(req.query, "id", params.ID)
+		addPtr(req.query, "ids", params.IDs)
+		addPtr(req.query, "module", params.Module)
+		addPtr(req.query, "page", params.Page)
+		addPtr(req.query, "resource", params.Resource)
+		addPtr(req.query, "resource_id", params.ResourceID)
+		addPtr(req.query, "size", params.Size)
+		addPtr(req.query, "start_at", params.StartAt)
+		addPtr(req.query, "username", params.Username)
+		addPtr(req.query, "with_total", params.WithTotal)
+	}
+	out := new(SystemPagAuditRecordReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListSystemCertParams ListSystemCert的查询参数
+type ListSystemCertParams struct {
+	// 创建时间之后的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	AfterCreatedAt *string
+	// 更新时间之后的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	AfterUpdatedAt *string
+	// 创建时间之前的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	BeforeCreatedAt *string
+	// 更新时间之前的记录 (RFC3339格式)
+	// example: 2023-01-01T00:00:00Z
+	BeforeUpdatedAt *string
+	// 导出的列(多个用,隔开), 默认导出全部列
+	Columns *string
+	// 剩余天数不超过该值, 按过期时间筛选
+	ExpireWithinDays *int64
+	// 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据
+	Export *string
+	// 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+	// 运算符: eq ne gt gte lt lte contains in between is_null not_null
+	// example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+	Filter *string
+	// 唯一标识
+	ID *int64
+	// "唯一标识列表(多个用,隔开)"
+	IDs *string
+	// 是否启用
+	IsEnabled *bool
+	// 证书来源
+	Kind *string
+	// 名称
+	Name *string
+	// 分页页码
+	Page *int64
+	// 分页大小
+	Size *int64
+	// 检查状态
+	Status *string
+	// 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表
+	WithTotal *bool
+}
+
+// ListSystemCert 查询证书监控列表
+//
+// 本接口用于查询证书监控列表，可按expire_within_days查询即将过期的证书，结果按过期时间升序排列
+//
+// GET /api/v1/system/cert
+func (c *Client) ListSystemCert(ctx context.Context, params *ListSystemCertParams) (*SystemPagCertMonitorReply, error) {
+	req := &request{method: "GET", path: "/api/v1/system/cert"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "after_created_at", params.AfterCreatedAt)
+		addPtr(req.query, "after_updated_at", params.AfterUpdatedAt)
+		addPtr(req.query, "before_created_at", params.BeforeCreatedAt)
+		addPtr(req.query, "before_updated_at", params.BeforeUpdatedAt)
+		addPtr(req.query, "columns", params.Columns)
+		addPtr(req.query, "expire_within_days", params.ExpireWithinDays)
+		addPtr(req.query, "export", params.Export)
+		addPtr(req.query, "filter", params.Filter)
+		addPtr(req.query, "id", params.ID)
+		addPtr(req.query, "ids", params.IDs)
+		addPtr(req.query, "is_enabled", params.IsEnabled)
+		addPtr(req.query, "kind", params.Kind)
+		addPtr(req.query, "name", params.Name)
+		addPtr(req.query, "page", params.Page)
+		addPtr(req.query, "size", params.Size)
+		addPtr(req.query, "status", params.Status)
+		addPtr(req.query, "with_total", params.WithTotal)
+	}
+	out := new(SystemPagCertMonitorReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateSystemCert 创建证书监控
+//
+// 本接口用于新增需要监控的TLS端点或证书文件，定时检查证书有效期和证书链，剩余天数到达告警阈值时告警
+//
+// POST /api/v1/system/cert
+func (c *Client) CreateSystemCert(ctx context.Context, body *SystemCertMonitorRequest) (*SystemCertMonitorReply, error) {
+	req := &request{method: "POST", path: "/api/v1/system/cert"}
+	req.body = body
+	out := new(SystemCertMonitorReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetSystemCert 查询证书监控详情
+//
+// 本接口用于查询指定ID的证书监控和最近一次检查结果
+//
+// GET /api/v1/system/cert/{id}
+func (c *Client) GetSystemCert(ctx context.Context, id int32) (*SystemCertMonitorReply, error) {
+	req := &request{method: "GET", path: "/api/v1/system/cert/" + pathValue(id)}
+	out := new(SystemCertMonitorReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateSystemCert 更新证书监控
+//
+// 本接口用于更新指定ID的证书监控，更换地址或证书文件时清空上一次的检查结果
+//
+// PUT /api/v1/system/cert/{id}
+func (c *Client) UpdateSystemCert(ctx context.Context, id int32, body *SystemCertMonitorRequest) (*SystemCertMonitorReply, error) {
+	req := &request{method: "PUT", path: "/api/v1/system/cert/" + pathValue(id)}
+	req.body = body
+	out := new(SystemCertMonitorReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteSystemCert 删除证书监控
+//
+// 本接口用于删除指定ID的证书监控
+//
+// DELETE /api/v1/system/cert/{id}
+func (c *Client) DeleteSystemCert(ctx context.Context, id int32) (*CommonMapAPIReply, error) {
+	req := &request{method: "DELETE", path: "/api/v1/system/cert/" + pathValue(id)}
+	out := new(CommonMapAPIReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateSystemCertCheck 立即检查证书监控
+//
+// 本接口用于立即检查指定ID的证书监控，与定时检查相同，到达告警阈值时告警
+//
+// POST /api/v1/system/cert/{id}/check
+func (c *Client) CreateSystemCertCheck(ctx context.Context, id int32) (*SystemCertMonitorReply, error) {
+	req := &request{method: "POST", path: "/api/v1/system/cert/" + pathValue(id) + "/check"}
+	out := new(SystemCertMonitorReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetSystemDeploy 查询部署模式
+//
+// 本接口用于查询当前部署模式及各功能的启用状态
+//
+// GET /api/v1/system/deploy
+func (c *Client) GetSystemDeploy(ctx context.Context) (*SystemDeployInfoReply, error) {
+	req := &request{method: "GET", path: "/api/v1/system/deploy"}
+	out := new(SystemDeployInfoReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListSystemFreezeParams ListSystemFreeze的查询参数
+type ListSystemFreezeParams struct {
+	// 导出的列(多个用,隔开), 默认导出全部列
+	Columns *string
+	// 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据
+	Export *string
+	// 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+	// 运算符: eq ne gt gte lt lte contains in between is_null not_null
+	// example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+	Filter *string
+	// 唯一标识
+	ID *int64
+	// "唯一标识列表(多个用,隔开)"
+	IDs *string
+	// 是否启用
+	IsEnabled *bool
+	// 冻结的模块
+	Module *string
+	// 名称
+	Name *string
+	// 分页页码
+	Page *int64
+	// 作用范围
+	Scope *string
+	// 分页大小
+	Size *int64
+	// 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表
+	WithTotal *bool
+}
+
+// ListSystemFreeze 查询变更冻结列表
+//
+// 本接口用于分页查询变更冻结
+//
+// GET /api/v1/system/freeze
+func (c *Client) ListSystemFreeze(ctx context.Context, params *ListSystemFreezeParams) (*SystemPagChangeFreezeReply, error) {
+	req := &request{method: "GET", path: "/api/v1/system/freeze"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "columns", params.Columns)
+		addPtr(req.query, "export", params.Export)
+		addPtr(req.query, "filter", params.Filter)
+		addPtr(req.query, "id", params.ID)
+		addPtr(req.query, "ids", params.IDs)
+		addPtr(req.query, "is_enabled", params.IsEnabled)
+		addPtr(req.query, "module", params.Module)
+		addPtr(req.query, "name", params.Name)
+		addPtr(req.query, "page", params.Page)
+		addPtr(req.query, "scope", params.Scope)
+		addPtr(req.query, "size", params.Size)
+		addPtr(req.query, "with_total", params.WithTotal)
+	}
+	out := new(SystemPagChangeFreezeReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateSystemFreeze 创建变更冻结
+//
+// 本接口用于创建变更冻结, 生效期间拒绝覆盖范围内的修改接口
+//
+// POST /api/v1/system/freeze
+func (c *Client) CreateSystemFreeze(ctx context.Context, body *SystemChangeFreezeRequest) (*SystemChangeFreezeReply, error) {
+	req := &request{method: "POST", path: "/api/v1/system/freeze"}
+	req.body = body
+	out := new(SystemChangeFreezeReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListSystemFreezeActive 查询当前生效的变更冻结
+//
+// 本接口用于查询当前时间生效的全部变更冻结
+//
+// GET /api/v1/system/freeze/active
+func (c *Client) ListSystemFreezeActive(ctx context.Context) (*SystemListChangeFreezeReply, error) {
+	req := &request{method: "GET", path: "/api/v1/system/freeze/active"}
+	out := new(SystemListChangeFreezeReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetSystemFreeze 查询变更冻结详情
+//
+// 本接口用于查询指定ID的变更冻结
+//
+// GET /api/v1/system/freeze/{id}
+func (c *Client) GetSystemFreeze(ctx context.Context, id int64) (*SystemChangeFreezeReply, error) {
+	req := &request{method: "GET", path: "/api/v1/system/freeze/" + pathValue(id)}
+	out := new(SystemChangeFreezeReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateSystemFreeze 更新变更冻结
+//
+// 本接口用于更新指定ID的变更冻结
+//
+// PUT /api/v1/system/freeze/{id}
+func (c *Client) UpdateSystemFreeze(ctx context.Context, id int64, body *SystemChangeFreezeRequest) (*SystemChangeFreezeReply, error) {
+	req := &request{method: "PUT", path: "/api/v1/system/freeze/" + pathValue(id)}
+	req.body = body
+	out := new(SystemChangeFreezeReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteSystemFreeze 删除变更冻结
+//
+// 本接口用于删除指定ID的变更冻结
+//
+// DELETE /api/v1/system/freeze/{id}
+func (c *Client) DeleteSystemFreeze(ctx context.Context, id int64) (*CommonMapAPIReply, error) {
+	req := &request{method: "DELETE", path: "/api/v1/system/freeze/" + pathValue(id)}
+	out := new(CommonMapAPIReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateSystemGatewayQuery 批量查询接口
+//
+// 本接口用于在一次请求中查询多个GET接口, 各子查询分别鉴权, 子查询失败不影响其他子查询
+//
+// POST /api/v1/system/gateway/query
+func (c *Client) CreateSystemGatewayQuery(ctx context.Context, body *SystemGatewayRequest) (SystemGatewayReply, error) {
+	req := &request{method: "POST", path: "/api/v1/system/gateway/query"}
+	req.body = body
+	var out SystemGatewayReply
+	if err := c.doJSON(ctx, req, &out); err != nil {
+		return out, err
+	}
+	return out, nil
+}
+
+// ListSystemMaintenanceParams ListSystemMaintenance的查询参数
+type ListSystemMaintenanceParams struct {
+	// 导出的列(多个用,隔开), 默认导出全部列
+	Columns *string
+	// 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据
+	Export *string
+	// 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+	// 运算符: eq ne gt gte lt lte contains in between is_null not_null
+	// example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+	Filter *string
+	// 唯一标识
+	ID *int64
+	// "唯一标识列表(多个用,隔开)"
+	IDs *string
+	// 是否启用
+	IsEnabled *bool
+	// 所属模块
+	Module *string
+	// 名称
+	Name *string
+	// 分页页码
+	Page *int64
+	// 作用范围
+	Scope *string
+	// 分页大小
+	Size *int64
+	// 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表
+	WithTotal *bool
+}
+
+// ListSystemMaintenance 查询维护窗口列表
+//
+// 本接口用于分页查询维护窗口
+//
+// GET /api/v1/system/maintenance
+func (c *Client) ListSystemMaintenance(ctx context.Context, params *ListSystemMaintenanceParams) (*SystemPagMaintenanceWindowReply, error) {
+	req := &request{method: "GET", path: "/api/v1/system/maintenance"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "columns", params.Columns)
+		addPtr(req.query, "export", params.Export)
+		addPtr(req.query, "filter", params.Filter)
+		addPtr(req.query, "id", params.ID)
+		addPtr(req.query, "ids", params.IDs)
+		addPtr(req.query, "is_enabled", params.IsEnabled)
+		addPtr(req.query, "module", params.Module)
+		addPtr(req.query, "name", params.Name)
+		addPtr(req.query, "page", params.Page)
+		addPtr(req.query, "scope", params.Scope)
+		addPtr(req.query, "size", params.Size)
+		addPtr(req.query, "with_total", params.WithTotal)
+	}
+	out := new(SystemPagMaintenanceWindowReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateSystemMaintenance 创建维护窗口
+//
+// 本接口用于创建维护窗口, 生效期间暂停命中的计划任务并屏蔽告警
+//
+// POST /api/v1/system/maintenance
+func (c *Client) CreateSystemMaintenance(ctx context.Context, body *SystemMaintenanceWindowRequest) (*SystemMaintenanceWindowReply, error) {
+	req := &request{method: "POST", path: "/api/v1/system/maintenance"}
+	req.body = body
+	out := new(SystemMaintenanceWindowReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListSystemMaintenanceActive 查询当前生效的维护窗口
+//
+// 本接口用于查询当前时间生效的全部维护窗口
+//
+// GET /api/v1/system/maintenance/active
+func (c *Client) ListSystemMaintenanceActive(ctx context.Context) (*SystemListMaintenanceWindowReply, error) {
+	req := &request{method: "GET", path: "/api/v1/system/maintenance/active"}
+	out := new(SystemListMaintenanceWindowReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetSystemMaintenance 查询维护窗口详情
+//
+// 本接口用于查询指定ID的维护窗口
+//
+// GET /api/v1/system/maintenance/{id}
+func (c *Client) GetSystemMaintenance(ctx context.Context, id int64) (*SystemMaintenanceWindowReply, error) {
+	req := &request{method: "GET", path: "/api/v1/system/maintenance/" + pathValue(id)}
+	out := new(SystemMaintenanceWindowReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateSystemMaintenance 更新维护窗口
+//
+// 本接口用于更新指定ID的维护窗口
+//
+// PUT /api/v1/system/maintenance/{id}
+func (c *Client) UpdateSystemMaintenance(ctx context.Context, id int64, body *SystemMaintenanceWindowRequest) (*SystemMaintenanceWindowReply, error) {
+	req := &request{method: "PUT", path: "/api/v1/system/maintenance/" + pathValue(id)}
+	req.body = body
+	out := new(SystemMaintenanceWindowReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteSystemMaintenance 删除维护窗口
+//
+// 本接口用于删除指定ID的维护窗口
+//
+// DELETE /api/v1/system/maintenance/{id}
+func (c *Client) DeleteSystemMaintenance(ctx context.Context, id int64) (*CommonMapAPIReply, error) {
+	req := &request{method: "DELETE", path: "/api/v1/system/maintenance/" + pathValue(id)}
+	out := new(CommonMapAPIReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetSystemMeFeatureFlag 查询当前用户可用的功能
+//
+// 本接口用于查询全部功能开关对当前用户是否开放, 前端据此决定是否展示功能入口
+//
+// GET /api/v1/system/me/feature-flag
+func (c *Client) GetSystemMeFeatureFlag(ctx context.Context) (SystemMyFeatureFlagReply, error) {
+	req := &request{method: "GET", path: "/api/v1/system/me/feature-flag"}
+	var out SystemMyFeatureFlagReply
+	if err := c.doJSON(ctx, req, &out); err != nil {
+		return out, err
+	}
+	return out, nil
+}
+
+// GetSystemReportParams GetSystemReport的查询参数
+type GetSystemReportParams struct {
+	// 统计日期, 日报为当天, 周报为所在的周, 为空时日报统计昨天, 周报统计上周
+	Date *string
+	// 报表周期(daily:日报, weekly:周报)
+	Period string
+}
+
+// GetSystemReport 生成报表
+//
+// 本接口用于生成日报或周报, 统计各集群任务的执行成功率和登录情况
+//
+// GET /api/v1/system/report
+func (c *Client) GetSystemReport(ctx context.Context, params *GetSystemReportParams) (*SystemReportReply, error) {
+	req := &request{method: "GET", path: "/api/v1/system/report"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "date", params.Date)
+		addValue(req.query, "period", params.Period)
+	}
+	out := new(SystemReportReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetSystemReportExportParams GetSystemReportExport的查询参数
+type GetSystemReportExportParams struct {
+	// 统计日期, 日报为当天, 周报为所在的周, 为空时日报统计昨天, 周报统计上周
+	Date *string
+	// 导出格式(csv, xlsx)
+	Format string
+	// 报表周期(daily:日报, weekly:周报)
+	Period string
+}
+
+// GetSystemReportExport 导出报表
+//
+// 本接口用于生成日报或周报并导出为csv或xlsx文件
+//
+// 返回原始响应体, 调用方读取完毕后需要关闭
+//
+// GET /api/v1/system/report/export
+func (c *Client) GetSystemReportExport(ctx context.Context, params *GetSystemReportExportParams) (io.ReadCloser, error) {
+	req := &request{method: "GET", path: "/api/v1/system/report/export"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "date", params.Date)
+		addValue(req.query, "format", params.Format)
+		addValue(req.query, "period", params.Period)
+	}
+	return c.doStream(ctx, req)
+}
+
+// ListSystemReportFile 查询定时报表文件
+//
+// 本接口用于查询定时任务生成的报表文件, 按生成时间倒序
+//
+// GET /api/v1/system/report/file
+func (c *Client) ListSystemReportFile(ctx context.Context) (*SystemListReportFileReply, error) {
+	req := &request{method: "GET", path: "/api/v1/system/report/file"}
+	out := new(SystemListReportFileReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetSystemReportFile 下载定时报表文件
+//
+// 本接口用于下载定时任务生成的报表文件
+//
+// 返回原始响应体, 调用方读取完毕后需要关闭
+//
+// GET /api/v1/system/report/file/{name}
+func (c *Client) GetSystemReportFile(ctx context.Context, name string) (io.ReadCloser, error) {
+	req := &request{method: "GET", path: "/api/v1/system/report/file/" + pathValue(name)}
+	return c.doStream(ctx, req)
+}
+
+// CreateSystemReportTask 后台生成报表文件
+//
+// 本接口用于提交生成报表文件的后台任务, 通过/api/v1/tasks/{id}查询进度, 任务成功后结果中的name为报表文件名
+//
+// POST /api/v1/system/report/task
+func (c *Client) CreateSystemReportTask(ctx context.Context, body *SystemExportReportRequest) (*SystemTaskReply, error) {
+	req := &request{method: "POST", path: "/api/v1/system/report/task"}
+	req.body = body
+	out := new(SystemTaskReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListSystemWebhookParams ListSystemWebhook的查询参数
+type ListSystemWebhookParams struct {
+	// 导出的列(多个用,隔开), 默认导出全部列
+	Columns *string
+	// 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据
+	Export *string
+	// 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+	// 运算符: eq ne gt gte lt lte contains in between is_null not_null
+	// example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+	Filter *string
+	// 唯一标识
+	ID *int64
+	// "唯一标识列表(多个用,隔开)"
+	IDs *string
+	// 是否启用
+	IsEnabled *bool
+	// 名称
+	Name *string
+	// 分页页码
+	Page *int64
+	// 分页大小
+	Size *int64
+	// 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表
+	WithTotal *bool
+}
+
+// ListSystemWebhook 查询webhook订阅列表
+//
+// 本接口用于分页查询webhook订阅
+//
+// GET /api/v1/system/webhook
+func (c *Client) ListSystemWebhook(ctx context.Context, params *ListSystemWebhookParams) (*SystemPagWebhookSubscriptionReply, error) {
+	req := &request{method: "GET", path: "/api/v1/system/webhook"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "columns", params.Columns)
+		addPtr(req.query, "export", params.Export)
+		addPtr(req.query, "filter", params.Filter)
+		addPtr(req.query, "id", params.ID)
+		addPtr(req.query, "ids", params.IDs)
+		addPtr(req.query, "is_enabled", params.IsEnabled)
+		addPtr(req.query, "name", params.Name)
+		addPtr(req.query, "page", params.Page)
+		addPtr(req.query, "size", params.Size)
+		addPtr(req.query, "with_total", params.WithTotal)
+	}
+	out := new(SystemPagWebhookSubscriptionReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateSystemWebhook 创建webhook订阅
+//
+// 本接口用于创建webhook订阅, 订阅的事件发生时向推送地址发送带签名的POST请求
+//
+// POST /api/v1/system/webhook
+func (c *Client) CreateSystemWebhook(ctx context.Context, body *SystemWebhookSubscriptionRequest) (*SystemWebhookSubscriptionReply, error) {
+	req := &request{method: "POST", path: "/api/v1/system/webhook"}
+	req.body = body
+	out := new(SystemWebhookSubscriptionReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetSystemWebhook 查询webhook订阅详情
+//
+// 本接口用于查询指定ID的webhook订阅
+//
+// GET /api/v1/system/webhook/{id}
+func (c *Client) GetSystemWebhook(ctx context.Context, id int64) (*SystemWebhookSubscriptionReply, error) {
+	req := &request{method: "GET", path: "/api/v1/system/webhook/" + pathValue(id)}
+	out := new(SystemWebhookSubscriptionReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateSystemWebhook 更新webhook订阅
+//
+// 本接口用于更新指定ID的webhook订阅, 签名密钥为空时不修改
+//
+// PUT /api/v1/system/webhook/{id}
+func (c *Client) UpdateSystemWebhook(ctx context.Context, id int64, body *SystemWebhookSubscriptionRequest) (*SystemWebhookSubscriptionReply, error) {
+	req := &request{method: "PUT", path: "/api/v1/system/webhook/" + pathValue(id)}
+	req.body = body
+	out := new(SystemWebhookSubscriptionReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteSystemWebhook 删除webhook订阅
+//
+// 本接口用于删除指定ID的webhook订阅及其推送记录
+//
+// DELETE /api/v1/system/webhook/{id}
+func (c *Client) DeleteSystemWebhook(ctx context.Context, id int64) (*CommonMapAPIReply, error) {
+	req := &request{method: "DELETE", path: "/api/v1/system/webhook/" + pathValue(id)}
+	out := new(CommonMapAPIReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListSystemWebhookDeliveryParams ListSystemWebhookDelivery的查询参数
+type ListSystemWebhookDeliveryParams struct {
+	// 导出的列(多个用,隔开), 默认导出全部列
+	Columns *string
+	// 事件类型
+	EventType *string
+	// 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据
+	Export *string
+	// 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+	// 运算符: eq ne gt gte lt lte contains in between is_null not_null
+	// example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+	Filter *string
+	// 唯一标识
+	ID *int64
+	// "唯一标识列表(多个用,隔开)"
+	IDs *string
+	// 分页页码
+	Page *int64
+	// 分页大小
+	Size *int64
+	// 推送状态(0-待推送,1-成功,2-失败)
+	Status *int64
+	// 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表
+	WithTotal *bool
+}
+
+// ListSystemWebhookDelivery 查询webhook推送记录
+//
+// 本接口用于分页查询指定ID的webhook订阅的推送记录
+//
+// GET /api/v1/system/webhook/{id}/delivery
+func (c *Client) ListSystemWebhookDelivery(ctx context.Context, id int64, params *ListSystemWebhookDeliveryParams) (*SystemPagWebhookDeliveryReply, error) {
+	req := &request{method: "GET", path: "/api/v1/system/webhook/" + pathValue(id) + "/delivery"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "columns", params.Columns)
+		addPtr(req.query, "event_type", params.EventType)
+		addPtr(req.query, "export", params.Export)
+		addPtr(req.query, "filter", params.Filter)
+		addPtr(req.query, "id", params.ID)
+		addPtr(req.query, "ids", params.IDs)
+		addPtr(req.query, "page", params.Page)
+		addPtr(req.query, "size", params.Size)
+		addPtr(req.query, "status", params.Status)
+		addPtr(req.query, "with_total", params.WithTotal)
+	}
+	out := new(SystemPagWebhookDeliveryReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateSystemWebhookTest 测试webhook订阅
+//
+// 本接口用于向指定ID的webhook推送一条测试事件并返回推送结果, 测试推送失败时不重试
+//
+// POST /api/v1/system/webhook/{id}/test
+func (c *Client) CreateSystemWebhookTest(ctx context.Context, id int64) (*SystemWebhookDeliveryReply, error) {
+	req := &request{method: "POST", path: "/api/v1/system/webhook/" + pathValue(id) + "/test"}
+	out := new(SystemWebhookDeliveryReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListTasksParams ListTasks的查询参数
+type ListTasksParams struct {
+	// 导出的列(多个用,隔开), 默认导出全部列
+	Columns *string
+	// 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据
+	Export *string
+	// 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+	// 运算符: eq ne gt gte lt lte contains in between is_null not_null
+	// example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+	Filter *string
+	// 唯一标识
+	ID *int64
+	// "唯一标识列表(多个用,隔开)"
+	IDs *string
+	// 任务类型
+	Kind *string
+	// 分页页码
+	Page *int64
+	// 分页大小
+	Size *int64
+	// 状态
+	Status *string
+	// 提交用户名
+	Username *string
+	// 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表
+	WithTotal *bool
+}
+
+// ListTasks 查询后台任务列表
+//
+// 本接口用于分页查询后台任务, 支持通过filter参数按字段组合过滤
+//
+// GET /api/v1/tasks
+func (c *Client) ListTasks(ctx context.Context, params *ListTasksParams) (*SystemPagTaskReply, error) {
+	req := &request{method: "GET", path: "/api/v1/tasks"}
+	if params != nil {
+		req.query = url.Values{}
+		addPtr(req.query, "columns", params.Columns)
+		addPtr(req.query, "export", params.Export)
+		addPtr(req.query, "filter", params.Filter)
+		addPtr(req.query, "id", params.ID)
+		addPtr(req.query, "ids", params.IDs)
+		addPtr(req.query, "kind", params.Kind)
+		addPtr(req.query, "page", params.Page)
+		addPtr(req.query, "size", params.Size)
+		addPtr(req.query, "status", params.Status)
+		addPtr(req.query, "username", params.Username)
+		addPtr(req.query, "with_total", params.WithTotal)
+	}
+	out := new(SystemPagTaskReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetTasks 查询后台任务详情
+//
+// 本接口用于查询后台任务的状态、进度和结果, 任务结束前可轮询本接口
+//
+// GET /api/v1/tasks/{id}
+func (c *Client) GetTasks(ctx context.Context, id int64) (*SystemTaskReply, error) {
+	req := &request{method: "GET", path: "/api/v1/tasks/" + pathValue(id)}
+	out := new(SystemTaskReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CreateTasksCancel 取消后台任务
+//
+// 本接口用于取消未结束的后台任务, 执行中的任务在下一个检查点退出后状态变为canceled
+//
+// POST /api/v1/tasks/{id}/cancel
+func (c *Client) CreateTasksCancel(ctx context.Context, id int64) (*CommonMapAPIReply, error) {
+	req := &request{method: "POST", path: "/api/v1/tasks/" + pathValue(id) + "/cancel"}
+	out := new(CommonMapAPIReply)
+	if err := c.doJSON(ctx, req, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,283 @@
+// Package client 访问gin-artweb接口的Go客户端
+//
+// 请求响应类型和接口方法由sdkgen根据swagger文档生成(api_gen.go), 接口变更后执行make sdk重新生成
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// maxErrorBody 读取错误响应体的上限
+const maxErrorBody = 1 << 20
+
+// RequestEditor 发送前修改请求, 如附加防重放的时间戳和随机数请求头
+type RequestEditor func(req *http.Request) error
+
+// Option 客户端选项
+type Option func(c *Client)
+
+// WithHTTPClient 使用指定的http.Client发送请求
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) { c.httpClient = hc }
+}
+
+// WithToken 设置访问令牌, 作为Authorization请求头发送
+func WithToken(token string) Option {
+	return func(c *Client) { c.token = token }
+}
+
+// WithRequestEditor 添加请求修改函数, 按添加顺序执行
+func WithRequestEditor(fn RequestEditor) Option {
+	return func(c *Client) { c.editors = append(c.editors, fn) }
+}
+
+// Client 接口客户端, 可以并发使用
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	editors    []RequestEditor
+
+	mu    sync.RWMutex
+	token string
+}
+
+// New 创建客户端, baseURL为服务地址, 如https://artweb.example.com
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// SetToken 更新访问令牌, 如登录或刷新令牌之后
+func (c *Client) SetToken(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.token = token
+}
+
+func (c *Client) getToken() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.token
+}
+
+// Error 接口返回的错误响应
+type Error struct {
+	StatusCode int            `json:"-"`
+	Code       int            `json:"code"`
+	Reason     string         `json:"reason"`
+	Msg        string         `json:"msg"`
+	Data       map[string]any `json:"data"`
+}
+
+func (e *Error) Error() string {
+	if e.Reason == "" {
+		return fmt.Sprintf("http status %d: %s", e.StatusCode, e.Msg)
+	}
+	return fmt.Sprintf("http status %d: %s: %s", e.StatusCode, e.Reason, e.Msg)
+}
+
+// File 上传的文件
+type File struct {
+	Name    string    // 文件名
+	Content io.Reader // 文件内容
+}
+
+// request 生成的接口方法构造的请求
+type request struct {
+	method string
+	path   string
+	query  url.Values
+	body   any            // JSON请求体
+	form   *multipartForm // multipart/form-data请求体
+}
+
+// multipartForm 表单字段和文件
+type multipartForm struct {
+	values url.Values
+	files  []formFile
+}
+
+type formFile struct {
+	field string
+	file  *File
+}
+
+func (f *multipartForm) addFile(field string, file *File) {
+	if file != nil {
+		f.files = append(f.files, formFile{field: field, file: file})
+	}
+}
+
+// write 写入表单内容, 文件内容直接从File.Content复制, 不在内存中缓冲
+func (f *multipartForm) write(mw *multipart.Writer) error {
+	for key, values := range f.values {
+		for _, v := range values {
+			if err := mw.WriteField(key, v); err != nil {
+				return err
+			}
+		}
+	}
+	for _, ff := range f.files {
+		part, err := mw.CreateFormFile(ff.field, ff.file.Name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(part, ff.file.Content); err != nil {
+			return err
+		}
+	}
+	return mw.Close()
+}
+
+func (c *Client) newRequest(ctx context.Context, r *request) (*http.Request, error) {
+	u := c.baseURL + r.path
+	if len(r.query) > 0 {
+		u += "?" + r.query.Encode()
+	}
+
+	var (
+		body        io.Reader
+		contentType string
+	)
+	switch {
+	case r.form != nil:
+		pr, pw := io.Pipe()
+		mw := multipart.NewWriter(pw)
+		go func() { pw.CloseWithError(r.form.write(mw)) }()
+		body, contentType = pr, mw.FormDataContentType()
+	case r.body != nil:
+		data, err := json.Marshal(r.body)
+		if err != nil {
+			return nil, fmt.Errorf("编码请求体失败: %w", err)
+		}
+		body, contentType = bytes.NewReader(data), "application/json"
+	}
+
+	req, err := http.NewRequestWithContext(ctx, r.method, u, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if token := c.getToken(); token != "" {
+		req.Header.Set("Authorization", token)
+	}
+	for _, edit := range c.editors {
+		if err := edit(req); err != nil {
+			return nil, err
+		}
+	}
+	return req, nil
+}
+
+// send 发送请求, 响应状态码不是2xx时解析错误响应并关闭响应体
+func (c *Client) send(ctx context.Context, r *request) (*http.Response, error) {
+	req, err := c.newRequest(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+
+	apiErr := &Error{StatusCode: resp.StatusCode}
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	if json.Unmarshal(data, apiErr) != nil || apiErr.Msg == "" {
+		apiErr.Msg = strings.TrimSpace(string(data))
+		if apiErr.Msg == "" {
+			apiErr.Msg = http.StatusText(resp.StatusCode)
+		}
+	}
+	return nil, apiErr
+}
+
+// doJSON 发送请求并将JSON响应解析到out, out为nil时丢弃响应体
+func (c *Client) doJSON(ctx context.Context, r *request, out any) error {
+	resp, err := c.send(ctx, r)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if out == nil {
+		_, err = io.Copy(io.Discard, resp.Body)
+		return err
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil && err != io.EOF {
+		return fmt.Errorf("解析响应失败: %w", err)
+	}
+	return nil
+}
+
+// doStream 发送请求并返回原始响应体
+func (c *Client) doStream(ctx context.Context, r *request) (io.ReadCloser, error) {
+	resp, err := c.send(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// scalar 查询参数和表单字段支持的类型
+type scalar interface {
+	~string | ~bool | ~int32 | ~int64 | ~float32 | ~float64
+}
+
+func addValue[T scalar](values url.Values, key string, v T) {
+	values.Add(key, fmt.Sprint(v))
+}
+
+func addPtr[T scalar](values url.Values, key string, v *T) {
+	if v != nil {
+		values.Add(key, fmt.Sprint(*v))
+	}
+}
+
+// addCSV 数组参数以逗号连接为一个参数
+func addCSV[T scalar](values url.Values, key string, vs []T) {
+	if len(vs) == 0 {
+		return
+	}
+	parts := make([]string, len(vs))
+	for i, v := range vs {
+		parts[i] = fmt.Sprint(v)
+	}
+	values.Add(key, strings.Join(parts, ","))
+}
+
+// addMulti 数组参数按多个同名参数传递
+func addMulti[T scalar](values url.Values, key string, vs []T) {
+	for _, v := range vs {
+		values.Add(key, fmt.Sprint(v))
+	}
+}
+
+func pathValue[T scalar](v T) string {
+	return url.PathEscape(fmt.Sprint(v))
+}
+
+// Ptr 返回值的指针, 用于设置可选的查询参数和表单字段
+func Ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,172 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"gin-artweb/pkg/sdkgen"
+)
+
+// TestGeneratedUpToDate 生成的代码需要与swagger文档一致, 不一致时执行make sdk重新生成
+func TestGeneratedUpToDate(t *testing.T) {
+	data, err := os.ReadFile("../../docs/swagger.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	api, err := sdkgen.Load(data, "/api/v1/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := sdkgen.GenerateGo(api, "client")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile("api_gen.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(want) {
+		t.Error("api_gen.go与swagger文档不一致, 请执行make sdk重新生成")
+	}
+}
+
+func TestListWithQuery(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/customer/api" {
+			t.Errorf("请求路径错误: %s", r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "token-1" {
+			t.Errorf("Authorization请求头错误: %q", got)
+		}
+		if got := r.URL.RawQuery; got != "method=GET&page=2" {
+			t.Errorf("查询参数错误: %s", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"code":200,"msg":"success","data":{"total":1,"items":[{"id":7,"url":"/x"}]}}`))
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL+"/", WithToken("token-1"))
+	reply, err := c.ListCustomerAPI(context.Background(), &ListCustomerAPIParams{
+		Method: Ptr("GET"),
+		Page:   Ptr[int64](2),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reply.Data.Total != 1 || len(reply.Data.Items) != 1 || reply.Data.Items[0].ID != 7 {
+		t.Errorf("响应解析错误: %+v", reply.Data)
+	}
+}
+
+func TestCreateWithBody(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("请求错误: %s %s", r.Method, r.Header.Get("Content-Type"))
+		}
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		if body["url"] != "/api/v1/x" || body["method"] != "GET" {
+			t.Errorf("请求体错误: %v", body)
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"code":201,"data":{"id":3}}`))
+	}))
+	defer srv.Close()
+
+	reply, err := New(srv.URL).CreateCustomerAPI(context.Background(), &CustomerCreateApiRequest{
+		URL: "/api/v1/x", Method: "GET", Label: "x",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reply.Data.ID != 3 {
+		t.Errorf("响应解析错误: %+v", reply.Data)
+	}
+}
+
+func TestMultipartUpload(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.EscapedPath(); got != "/api/v1/mds/mds%2001/conf/bin" {
+			t.Errorf("路径参数未转义: %s", got)
+		}
+		f, fh, err := r.FormFile("file")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		data, _ := io.ReadAll(f)
+		if fh.Filename != "a.conf" || string(data) != "k=v" {
+			t.Errorf("上传的文件错误: %s %q", fh.Filename, data)
+		}
+		_, _ = w.Write([]byte(`{"code":200}`))
+	}))
+	defer srv.Close()
+
+	_, err := New(srv.URL).CreateMdsConf(context.Background(), "mds 01", "bin", &CreateMdsConfForm{
+		File: &File{Name: "a.conf", Content: strings.NewReader("k=v")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestErrorResponse(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"code":404,"reason":"DB_RECORD_NOT_FOUND","msg":"记录未找到","data":{"id":9}}`))
+	}))
+	defer srv.Close()
+
+	_, err := New(srv.URL).GetCustomerAPI(context.Background(), 9)
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("应返回*Error, 实际: %v", err)
+	}
+	if apiErr.StatusCode != http.StatusNotFound || apiErr.Reason != "DB_RECORD_NOT_FOUND" || apiErr.Data["id"] != float64(9) {
+		t.Errorf("错误解析错误: %+v", apiErr)
+	}
+
+	// 非JSON的错误响应使用响应体作为错误消息
+	srv2 := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bad gateway", http.StatusBadGateway)
+	}))
+	defer srv2.Close()
+	_, err = New(srv2.URL).DeleteCustomerAPI(context.Background(), 1)
+	if !errors.As(err, &apiErr) || apiErr.Msg != "bad gateway" {
+		t.Errorf("错误解析错误: %v", err)
+	}
+}
+
+func TestStreamAndEditor(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Nonce") != "n1" {
+			t.Errorf("请求修改函数未生效")
+		}
+		_, _ = w.Write([]byte("file content"))
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL, WithRequestEditor(func(req *http.Request) error {
+		req.Header.Set("X-Nonce", "n1")
+		return nil
+	}))
+	body, err := c.GetResourcePackageDownload(context.Background(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer body.Close()
+	data, _ := io.ReadAll(body)
+	if string(data) != "file content" {
+		t.Errorf("响应体错误: %q", data)
+	}
+}
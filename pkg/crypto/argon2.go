@@ -0,0 +1,124 @@
+package crypto
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"emperror.dev/errors"
+	"golang.org/x/crypto/argon2"
+)
+
+// argon2idPrefix argon2id哈希值的前缀, 哈希值使用PHC格式: $argon2id$v=19$m=65536,t=3,p=2$<盐值>$<哈希>
+const argon2idPrefix = "$argon2id$"
+
+// Argon2idHasher argon2id哈希实现
+type Argon2idHasher struct {
+	memory  uint32 // 内存大小(KiB)
+	time    uint32 // 迭代次数
+	threads uint8  // 并行度
+	saltLen int
+	keyLen  uint32
+}
+
+// NewArgon2idHasher 创建argon2id哈希, 参数为0时使用默认值: 64MiB内存、3次迭代、并行度2
+func NewArgon2idHasher(memory, time uint32, threads uint8) *Argon2idHasher {
+	if memory == 0 {
+		memory = 64 * 1024
+	}
+	if time == 0 {
+		time = 3
+	}
+	if threads == 0 {
+		threads = 2
+	}
+	return &Argon2idHasher{
+		memory:  memory,
+		time:    time,
+		threads: threads,
+		saltLen: 16,
+		keyLen:  32,
+	}
+}
+
+func (h *Argon2idHasher) Hash(ctx context.Context, data string) (string, error) {
+	// 检查context是否已取消
+	if ctx.Err() != nil {
+		return "", errors.Wrap(ctx.Err(), "上下文已取消")
+	}
+
+	salt := make([]byte, h.saltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", errors.Wrap(err, "生成盐值错误")
+	}
+	key := argon2.IDKey([]byte(data), salt, h.time, h.memory, h.threads, h.keyLen)
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2idPrefix, argon2.Version, h.memory, h.time, h.threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+func (h *Argon2idHasher) Verify(ctx context.Context, data, hash string) (bool, error) {
+	// 检查context是否已取消
+	if ctx.Err() != nil {
+		return false, errors.Wrap(ctx.Err(), "上下文已取消")
+	}
+
+	p, err := parseArgon2id(hash)
+	if err != nil {
+		return false, err
+	}
+	key := argon2.IDKey([]byte(data), p.salt, p.time, p.memory, p.threads, uint32(len(p.key)))
+	return subtle.ConstantTimeCompare(key, p.key) == 1, nil
+}
+
+func (h *Argon2idHasher) Algorithm() string { return AlgorithmArgon2id }
+
+func (h *Argon2idHasher) Match(hash string) bool { return strings.HasPrefix(hash, argon2idPrefix) }
+
+func (h *Argon2idHasher) Current(hash string) bool {
+	p, err := parseArgon2id(hash)
+	if err != nil {
+		return false
+	}
+	return p.version == argon2.Version && p.memory == h.memory && p.time == h.time && p.threads == h.threads
+}
+
+// argon2idParams 从哈希值中解析出的参数
+type argon2idParams struct {
+	version int
+	memory  uint32
+	time    uint32
+	threads uint8
+	salt    []byte
+	key     []byte
+}
+
+func parseArgon2id(hash string) (*argon2idParams, error) {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != AlgorithmArgon2id {
+		return nil, errors.New("无效的argon2id哈希格式")
+	}
+	var p argon2idParams
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &p.version); err != nil {
+		return nil, errors.Wrap(err, "解析argon2id版本错误")
+	}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.memory, &p.time, &p.threads); err != nil {
+		return nil, errors.Wrap(err, "解析argon2id参数错误")
+	}
+	var err error
+	if p.salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return nil, errors.Wrap(err, "解码盐值错误")
+	}
+	if p.key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil {
+		return nil, errors.Wrap(err, "解码哈希错误")
+	}
+	if len(p.key) == 0 {
+		return nil, errors.New("无效的argon2id哈希格式")
+	}
+	return &p, nil
+}
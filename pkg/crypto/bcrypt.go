@@ -2,6 +2,7 @@ package crypto
 
 import (
 	"context"
+	"strings"
 
 	"emperror.dev/errors"
 	"golang.org/x/crypto/bcrypt"
@@ -44,3 +45,15 @@ func (h *BcryptHasher) Verify(ctx context.Context, data, hash string) (bool, err
 	}
 	return true, nil
 }
+
+func (h *BcryptHasher) Algorithm() string { return AlgorithmBcrypt }
+
+// Match bcrypt哈希值的前缀为$2a$、$2b$或$2y$
+func (h *BcryptHasher) Match(hash string) bool {
+	return strings.HasPrefix(hash, "$2a$") || strings.HasPrefix(hash, "$2b$") || strings.HasPrefix(hash, "$2y$")
+}
+
+func (h *BcryptHasher) Current(hash string) bool {
+	cost, err := bcrypt.Cost([]byte(hash))
+	return err == nil && cost == h.cost
+}
@@ -0,0 +1,187 @@
+package crypto
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"emperror.dev/errors"
+)
+
+// FieldCipherPrefix 字段密文前缀, 完整格式为 enc:v1:<密钥标识>:<base64密文>
+const FieldCipherPrefix = "enc:v1:"
+
+// FieldKey 字段加密密钥
+type FieldKey struct {
+	ID  string // 密钥标识, 写入密文用于解密时选择密钥
+	Key []byte // AES密钥, 长度为16/24/32字节
+}
+
+// FieldKeyProvider 字段加密密钥来源
+//
+// 返回的第一个密钥为当前密钥, 用于加密; 其余密钥仅用于解密轮换前的数据.
+// 接入KMS时实现该接口, 从KMS解密数据密钥后返回即可.
+type FieldKeyProvider interface {
+	Keys(ctx context.Context) ([]FieldKey, error)
+}
+
+// StaticKeyProvider 固定的字段加密密钥
+type StaticKeyProvider []FieldKey
+
+func (p StaticKeyProvider) Keys(ctx context.Context) ([]FieldKey, error) {
+	return p, nil
+}
+
+// EnvKeyProvider 从环境变量读取字段加密密钥, 格式参见 ParseFieldKeys
+type EnvKeyProvider struct {
+	Name string
+}
+
+func (p EnvKeyProvider) Keys(ctx context.Context) ([]FieldKey, error) {
+	value := os.Getenv(p.Name)
+	if value == "" {
+		return nil, errors.Errorf("环境变量%s未设置", p.Name)
+	}
+	return ParseFieldKeys(value)
+}
+
+// FileKeyProvider 从文件读取字段加密密钥, 格式参见 ParseFieldKeys
+type FileKeyProvider struct {
+	Path string
+}
+
+func (p FileKeyProvider) Keys(ctx context.Context) ([]FieldKey, error) {
+	data, err := os.ReadFile(p.Path)
+	if err != nil {
+		return nil, errors.Wrap(err, "读取字段加密密钥文件错误")
+	}
+	return ParseFieldKeys(string(data))
+}
+
+// ParseFieldKeys 解析字段加密密钥
+//
+// 格式为 "<密钥标识>:<base64密钥>", 多个密钥以逗号或换行分隔, 第一个为当前密钥
+func ParseFieldKeys(value string) ([]FieldKey, error) {
+	var bc BaseCipher
+	var keys []FieldKey
+	for _, item := range strings.FieldsFunc(value, func(r rune) bool {
+		return r == ',' || r == '\n' || r == '\r'
+	}) {
+		item = strings.TrimSpace(item)
+		if item == "" || strings.HasPrefix(item, "#") {
+			continue
+		}
+		id, encoded, ok := strings.Cut(item, ":")
+		if !ok || id == "" {
+			return nil, errors.Errorf("字段加密密钥格式错误: 缺少密钥标识")
+		}
+		key, err := bc.DecodeString(strings.TrimSpace(encoded))
+		if err != nil {
+			return nil, errors.Wrapf(err, "字段加密密钥%s解码错误", id)
+		}
+		keys = append(keys, FieldKey{ID: strings.TrimSpace(id), Key: key})
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("字段加密密钥为空")
+	}
+	return keys, nil
+}
+
+// FieldEncryptor 字段级加密器, 使用AES-GCM加密数据库中的敏感字段
+type FieldEncryptor struct {
+	current string
+	ciphers map[string]Cipher
+}
+
+// NewFieldEncryptor 创建字段级加密器, 第一个密钥为当前密钥
+func NewFieldEncryptor(keys []FieldKey) (*FieldEncryptor, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("字段加密密钥为空")
+	}
+	e := &FieldEncryptor{
+		current: keys[0].ID,
+		ciphers: make(map[string]Cipher, len(keys)),
+	}
+	for _, k := range keys {
+		if strings.Contains(k.ID, ":") {
+			return nil, errors.Errorf("字段加密密钥标识%s不能包含冒号", k.ID)
+		}
+		if _, ok := e.ciphers[k.ID]; ok {
+			return nil, errors.Errorf("字段加密密钥标识%s重复", k.ID)
+		}
+		c, err := NewAESGCMCipher(k.Key)
+		if err != nil {
+			return nil, errors.Wrapf(err, "字段加密密钥%s无效", k.ID)
+		}
+		e.ciphers[k.ID] = c
+	}
+	return e, nil
+}
+
+// NewFieldEncryptorFromProvider 从密钥来源创建字段级加密器
+func NewFieldEncryptorFromProvider(ctx context.Context, p FieldKeyProvider) (*FieldEncryptor, error) {
+	keys, err := p.Keys(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "获取字段加密密钥错误")
+	}
+	return NewFieldEncryptor(keys)
+}
+
+// CurrentKeyID 当前用于加密的密钥标识
+func (e *FieldEncryptor) CurrentKeyID() string {
+	return e.current
+}
+
+// Encrypt 使用当前密钥加密字段, 空字符串不加密
+func (e *FieldEncryptor) Encrypt(ctx context.Context, plaintext string) (string, error) {
+	if plaintext == "" {
+		return "", nil
+	}
+	ciphertext, err := e.ciphers[e.current].Encrypt(ctx, plaintext)
+	if err != nil {
+		return "", errors.Wrap(err, "字段加密错误")
+	}
+	return FieldCipherPrefix + e.current + ":" + ciphertext, nil
+}
+
+// Decrypt 解密字段
+//
+// 不带密文前缀的值视为加密前写入的明文并原样返回, 以便存量数据迁移前仍可读取
+func (e *FieldEncryptor) Decrypt(ctx context.Context, value string) (string, error) {
+	kid, ciphertext, ok := splitFieldCipher(value)
+	if !ok {
+		return value, nil
+	}
+	c, exists := e.ciphers[kid]
+	if !exists {
+		return "", errors.Errorf("字段加密密钥%s不存在", kid)
+	}
+	plaintext, err := c.Decrypt(ctx, ciphertext)
+	if err != nil {
+		return "", errors.Wrap(err, "字段解密错误")
+	}
+	return plaintext, nil
+}
+
+// NeedsReencrypt 字段是否需要使用当前密钥重新加密
+func (e *FieldEncryptor) NeedsReencrypt(value string) bool {
+	if value == "" {
+		return false
+	}
+	kid, _, ok := splitFieldCipher(value)
+	return !ok || kid != e.current
+}
+
+// IsFieldEncrypted 字段值是否为密文
+func IsFieldEncrypted(value string) bool {
+	_, _, ok := splitFieldCipher(value)
+	return ok
+}
+
+func splitFieldCipher(value string) (string, string, bool) {
+	rest, ok := strings.CutPrefix(value, FieldCipherPrefix)
+	if !ok {
+		return "", "", false
+	}
+	return strings.Cut(rest, ":")
+}
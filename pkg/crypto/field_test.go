@@ -0,0 +1,106 @@
+package crypto
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+func testFieldKey(id string, b byte) string {
+	return id + ":" + base64.StdEncoding.EncodeToString([]byte(strings.Repeat(string(b), 32)))
+}
+
+// 测试字段加密解密
+func TestFieldEncryptor(t *testing.T) {
+	ctx := context.Background()
+	keys, err := ParseFieldKeys(testFieldKey("k1", 'a'))
+	if err != nil {
+		t.Fatalf("解析字段加密密钥错误: %+v", err)
+	}
+	e, err := NewFieldEncryptor(keys)
+	if err != nil {
+		t.Fatalf("创建字段加密器错误: %+v", err)
+	}
+
+	ciphertext, err := e.Encrypt(ctx, "password")
+	if err != nil {
+		t.Fatalf("加密错误: %+v", err)
+	}
+	if !strings.HasPrefix(ciphertext, FieldCipherPrefix+"k1:") {
+		t.Errorf("密文格式错误: %s", ciphertext)
+	}
+	if e.NeedsReencrypt(ciphertext) {
+		t.Errorf("当前密钥加密的字段不需要重新加密")
+	}
+
+	plaintext, err := e.Decrypt(ctx, ciphertext)
+	if err != nil {
+		t.Fatalf("解密错误: %+v", err)
+	}
+	if plaintext != "password" {
+		t.Errorf("解密文本与原始文本不匹配: 得到 %s", plaintext)
+	}
+
+	// 明文原样返回
+	plaintext, err = e.Decrypt(ctx, "legacy")
+	if err != nil || plaintext != "legacy" {
+		t.Errorf("未加密的字段应该原样返回: %s, %v", plaintext, err)
+	}
+	if !e.NeedsReencrypt("legacy") {
+		t.Errorf("未加密的字段需要加密")
+	}
+
+	// 空字符串不加密
+	empty, err := e.Encrypt(ctx, "")
+	if err != nil || empty != "" {
+		t.Errorf("空字符串不应该加密: %s, %v", empty, err)
+	}
+}
+
+// 测试字段加密密钥轮换
+func TestFieldEncryptorRotation(t *testing.T) {
+	ctx := context.Background()
+	oldKeys, _ := ParseFieldKeys(testFieldKey("k1", 'a'))
+	old, _ := NewFieldEncryptor(oldKeys)
+	ciphertext, err := old.Encrypt(ctx, "secret")
+	if err != nil {
+		t.Fatalf("加密错误: %+v", err)
+	}
+
+	keys, err := ParseFieldKeys(testFieldKey("k2", 'b') + "\n" + testFieldKey("k1", 'a'))
+	if err != nil {
+		t.Fatalf("解析字段加密密钥错误: %+v", err)
+	}
+	e, _ := NewFieldEncryptor(keys)
+	if e.CurrentKeyID() != "k2" {
+		t.Errorf("第一个密钥应该为当前密钥: %s", e.CurrentKeyID())
+	}
+	if !e.NeedsReencrypt(ciphertext) {
+		t.Errorf("旧密钥加密的字段需要重新加密")
+	}
+	plaintext, err := e.Decrypt(ctx, ciphertext)
+	if err != nil || plaintext != "secret" {
+		t.Errorf("旧密钥加密的字段应该可以解密: %s, %v", plaintext, err)
+	}
+
+	// 移除旧密钥后无法解密
+	newKeys, _ := ParseFieldKeys(testFieldKey("k2", 'b'))
+	onlyNew, _ := NewFieldEncryptor(newKeys)
+	if _, err := onlyNew.Decrypt(ctx, ciphertext); err == nil {
+		t.Errorf("密钥不存在时解密应该失败")
+	}
+}
+
+// 测试无效的字段加密密钥
+func TestParseFieldKeysInvalid(t *testing.T) {
+	for _, value := range []string{"", "nokey", ":YWJj", "k1:!!!"} {
+		if _, err := ParseFieldKeys(value); err == nil {
+			t.Errorf("无效的密钥%q应该解析失败", value)
+		}
+	}
+	keys, _ := ParseFieldKeys("k1:" + base64.StdEncoding.EncodeToString([]byte("short")))
+	if _, err := NewFieldEncryptor(keys); err == nil {
+		t.Errorf("长度无效的密钥应该创建失败")
+	}
+}
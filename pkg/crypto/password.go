@@ -0,0 +1,114 @@
+package crypto
+
+import (
+	"context"
+
+	"emperror.dev/errors"
+)
+
+// 密码哈希算法
+const (
+	AlgorithmBcrypt   = "bcrypt"
+	AlgorithmArgon2id = "argon2id"
+	AlgorithmScrypt   = "scrypt"
+	AlgorithmUnknown  = "unknown" // 无法识别前缀的哈希值
+)
+
+// defaultBcryptCost 密码哈希默认的bcrypt开销
+const defaultBcryptCost = 12
+
+// PasswordHashOptions 密码哈希的算法和参数, 为空或为0时使用默认值
+type PasswordHashOptions struct {
+	Algorithm     string // 新哈希值使用的算法, 默认bcrypt
+	BcryptCost    int    // bcrypt开销, 默认12
+	Argon2Memory  uint32 // argon2id内存大小(KiB), 默认65536
+	Argon2Time    uint32 // argon2id迭代次数, 默认3
+	Argon2Threads uint8  // argon2id并行度, 默认2
+	ScryptLogN    uint8  // scrypt参数N的以2为底的对数, 默认15
+	ScryptR       int    // scrypt参数r, 默认8
+	ScryptP       int    // scrypt参数p, 默认1
+}
+
+// Rehasher 可以判断哈希值是否需要使用当前算法和参数重新生成的哈希
+type Rehasher interface {
+	Hasher
+
+	// NeedsRehash 哈希值不是使用当前的算法和参数生成时返回true
+	NeedsRehash(hash string) bool
+}
+
+// versionedHasher 哈希值中带有算法前缀和参数的哈希实现
+type versionedHasher interface {
+	Hasher
+
+	// Algorithm 算法名称
+	Algorithm() string
+
+	// Match 哈希值是否由该算法生成
+	Match(hash string) bool
+
+	// Current 哈希值是否使用当前的参数生成
+	Current(hash string) bool
+}
+
+// PasswordHasher 按配置选择算法的密码哈希
+//
+// 新的哈希值使用配置的算法和参数, 验证时按哈希值的前缀选择算法, 切换算法或调整参数后已有的哈希值仍然可以验证,
+// 验证成功后可通过NeedsRehash判断是否需要使用当前的算法和参数重新生成
+type PasswordHasher struct {
+	current versionedHasher
+	all     []versionedHasher
+}
+
+// NewPasswordHasher 创建密码哈希, 算法不支持时返回错误
+func NewPasswordHasher(opts PasswordHashOptions) (*PasswordHasher, error) {
+	cost := opts.BcryptCost
+	if cost == 0 {
+		cost = defaultBcryptCost
+	}
+	all := []versionedHasher{
+		&BcryptHasher{cost: cost},
+		NewArgon2idHasher(opts.Argon2Memory, opts.Argon2Time, opts.Argon2Threads),
+		NewPHCScryptHasher(opts.ScryptLogN, opts.ScryptR, opts.ScryptP),
+	}
+	algorithm := opts.Algorithm
+	if algorithm == "" {
+		algorithm = AlgorithmBcrypt
+	}
+	for _, h := range all {
+		if h.Algorithm() == algorithm {
+			return &PasswordHasher{current: h, all: all}, nil
+		}
+	}
+	return nil, errors.Errorf("不支持的密码哈希算法: %s", opts.Algorithm)
+}
+
+// Algorithm 新哈希值使用的算法
+func (h *PasswordHasher) Algorithm() string { return h.current.Algorithm() }
+
+func (h *PasswordHasher) Hash(ctx context.Context, data string) (string, error) {
+	return h.current.Hash(ctx, data)
+}
+
+func (h *PasswordHasher) Verify(ctx context.Context, data, hash string) (bool, error) {
+	for _, v := range h.all {
+		if v.Match(hash) {
+			return v.Verify(ctx, data, hash)
+		}
+	}
+	return false, errors.New("无法识别的密码哈希格式")
+}
+
+func (h *PasswordHasher) NeedsRehash(hash string) bool {
+	return !h.current.Match(hash) || !h.current.Current(hash)
+}
+
+// Inspect 返回哈希值使用的算法以及是否使用当前的算法和参数生成
+func (h *PasswordHasher) Inspect(hash string) (string, bool) {
+	for _, v := range h.all {
+		if v.Match(hash) {
+			return v.Algorithm(), v == h.current && v.Current(hash)
+		}
+	}
+	return AlgorithmUnknown, false
+}
@@ -0,0 +1,124 @@
+package crypto
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func newTestPasswordHasher(t *testing.T, algorithm string, cost int) *PasswordHasher {
+	t.Helper()
+	h, err := NewPasswordHasher(PasswordHashOptions{
+		Algorithm:    algorithm,
+		BcryptCost:   cost,
+		Argon2Memory: 1024,
+		Argon2Time:   1,
+		ScryptLogN:   10,
+	})
+	if err != nil {
+		t.Fatalf("创建密码哈希错误: %+v", err)
+	}
+	return h
+}
+
+// 测试各算法的哈希前缀和验证
+func TestPasswordHasher(t *testing.T) {
+	ctx := context.Background()
+	cases := []struct {
+		algorithm string
+		prefix    string
+	}{
+		{AlgorithmBcrypt, "$2a$04$"},
+		{AlgorithmArgon2id, "$argon2id$v=19$m=1024,t=1,p=2$"},
+		{AlgorithmScrypt, "$scrypt$ln=10,r=8,p=1$"},
+	}
+	for _, c := range cases {
+		h := newTestPasswordHasher(t, c.algorithm, 4)
+		hash, err := h.Hash(ctx, "Passw0rd!")
+		if err != nil {
+			t.Fatalf("%s哈希错误: %+v", c.algorithm, err)
+		}
+		if !strings.HasPrefix(hash, c.prefix) {
+			t.Errorf("%s哈希值前缀错误: %s", c.algorithm, hash)
+		}
+
+		ok, err := h.Verify(ctx, "Passw0rd!", hash)
+		if err != nil || !ok {
+			t.Errorf("%s验证正确密码失败: %v", c.algorithm, err)
+		}
+		ok, err = h.Verify(ctx, "wrong", hash)
+		if err != nil || ok {
+			t.Errorf("%s验证错误密码应该失败: %v", c.algorithm, err)
+		}
+
+		if h.NeedsRehash(hash) {
+			t.Errorf("%s使用当前参数的哈希值不需要重新哈希", c.algorithm)
+		}
+		if algorithm, current := h.Inspect(hash); algorithm != c.algorithm || !current {
+			t.Errorf("%s识别哈希值错误: %s %v", c.algorithm, algorithm, current)
+		}
+	}
+}
+
+// 测试切换算法和参数后验证旧的哈希值并判断需要重新哈希
+func TestPasswordHasherMigration(t *testing.T) {
+	ctx := context.Background()
+	old := newTestPasswordHasher(t, AlgorithmBcrypt, 4)
+	hash, err := old.Hash(ctx, "Passw0rd!")
+	if err != nil {
+		t.Fatalf("哈希错误: %+v", err)
+	}
+
+	for _, h := range []*PasswordHasher{
+		newTestPasswordHasher(t, AlgorithmBcrypt, 5),
+		newTestPasswordHasher(t, AlgorithmArgon2id, 4),
+		newTestPasswordHasher(t, AlgorithmScrypt, 4),
+	} {
+		ok, err := h.Verify(ctx, "Passw0rd!", hash)
+		if err != nil || !ok {
+			t.Errorf("%s应该可以验证旧的bcrypt哈希值: %v", h.Algorithm(), err)
+		}
+		if !h.NeedsRehash(hash) {
+			t.Errorf("%s应该判断旧的哈希值需要重新哈希", h.Algorithm())
+		}
+		if algorithm, current := h.Inspect(hash); algorithm != AlgorithmBcrypt || current {
+			t.Errorf("%s识别旧的哈希值错误: %s %v", h.Algorithm(), algorithm, current)
+		}
+	}
+
+	// 参数变化的argon2id哈希值需要重新哈希
+	argon, _ := newTestPasswordHasher(t, AlgorithmArgon2id, 4).Hash(ctx, "Passw0rd!")
+	h, err := NewPasswordHasher(PasswordHashOptions{Algorithm: AlgorithmArgon2id, Argon2Memory: 2048, Argon2Time: 1})
+	if err != nil {
+		t.Fatalf("创建密码哈希错误: %+v", err)
+	}
+	if !h.NeedsRehash(argon) {
+		t.Error("argon2id参数变化后应该需要重新哈希")
+	}
+	if ok, err := h.Verify(ctx, "Passw0rd!", argon); err != nil || !ok {
+		t.Errorf("argon2id应该可以验证旧参数的哈希值: %v", err)
+	}
+}
+
+// 测试无法识别的算法和哈希值
+func TestPasswordHasherInvalid(t *testing.T) {
+	if _, err := NewPasswordHasher(PasswordHashOptions{Algorithm: "md5"}); err == nil {
+		t.Error("不支持的算法应该返回错误")
+	}
+
+	h := newTestPasswordHasher(t, "", 4)
+	if h.Algorithm() != AlgorithmBcrypt {
+		t.Errorf("默认算法应该是bcrypt: %s", h.Algorithm())
+	}
+	for _, hash := range []string{"plain", "$argon2id$v=19$bad", "$scrypt$ln=0,r=8,p=1$c2FsdA$a2V5"} {
+		if ok, err := h.Verify(context.Background(), "Passw0rd!", hash); err == nil || ok {
+			t.Errorf("无效的哈希值%q应该返回错误", hash)
+		}
+		if !h.NeedsRehash(hash) {
+			t.Errorf("无效的哈希值%q应该需要重新哈希", hash)
+		}
+	}
+	if algorithm, _ := h.Inspect("plain"); algorithm != AlgorithmUnknown {
+		t.Errorf("无法识别的哈希值应该返回unknown: %s", algorithm)
+	}
+}
@@ -3,8 +3,11 @@ package crypto
 import (
 	"context"
 	"crypto/rand"
+	"crypto/subtle"
 	"encoding/base64"
 	"encoding/hex"
+	"fmt"
+	"strings"
 
 	"emperror.dev/errors"
 	"golang.org/x/crypto/scrypt"
@@ -107,3 +110,113 @@ func (h *ScryptHasher) Verify(ctx context.Context, data, hash string) (bool, err
 	// 比较哈希值
 	return string(expectedHash) == string(computedHash), nil
 }
+
+// scryptPrefix 带参数的scrypt哈希值的前缀, 哈希值使用PHC格式: $scrypt$ln=15,r=8,p=1$<盐值>$<哈希>
+const scryptPrefix = "$scrypt$"
+
+// PHCScryptHasher 哈希值中记录参数的scrypt哈希实现, 修改参数后已有的哈希值仍然可以验证
+type PHCScryptHasher struct {
+	logN    uint8 // CPU/内存开销参数N的以2为底的对数
+	r       int
+	p       int
+	saltLen int
+	keyLen  int
+}
+
+// NewPHCScryptHasher 创建带参数的scrypt哈希, 参数为0时使用默认值: N=2^15、r=8、p=1
+func NewPHCScryptHasher(logN uint8, r, p int) *PHCScryptHasher {
+	if logN == 0 {
+		logN = 15
+	}
+	if r == 0 {
+		r = 8
+	}
+	if p == 0 {
+		p = 1
+	}
+	return &PHCScryptHasher{logN: logN, r: r, p: p, saltLen: 16, keyLen: 32}
+}
+
+func (h *PHCScryptHasher) Hash(ctx context.Context, data string) (string, error) {
+	// 检查context是否已取消
+	if ctx.Err() != nil {
+		return "", errors.Wrap(ctx.Err(), "上下文已取消")
+	}
+
+	salt := make([]byte, h.saltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", errors.Wrap(err, "生成盐值错误")
+	}
+	key, err := scrypt.Key([]byte(data), salt, 1<<h.logN, h.r, h.p, h.keyLen)
+	if err != nil {
+		return "", errors.Wrap(err, "Scrypt密钥生成错误")
+	}
+	return fmt.Sprintf("%sln=%d,r=%d,p=%d$%s$%s",
+		scryptPrefix, h.logN, h.r, h.p,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+func (h *PHCScryptHasher) Verify(ctx context.Context, data, hash string) (bool, error) {
+	// 检查context是否已取消
+	if ctx.Err() != nil {
+		return false, errors.Wrap(ctx.Err(), "上下文已取消")
+	}
+
+	p, err := parseScrypt(hash)
+	if err != nil {
+		return false, err
+	}
+	key, err := scrypt.Key([]byte(data), p.salt, 1<<p.logN, p.r, p.p, len(p.key))
+	if err != nil {
+		return false, errors.Wrap(err, "Scrypt密钥生成错误")
+	}
+	return subtle.ConstantTimeCompare(key, p.key) == 1, nil
+}
+
+func (h *PHCScryptHasher) Algorithm() string { return AlgorithmScrypt }
+
+func (h *PHCScryptHasher) Match(hash string) bool { return strings.HasPrefix(hash, scryptPrefix) }
+
+func (h *PHCScryptHasher) Current(hash string) bool {
+	p, err := parseScrypt(hash)
+	if err != nil {
+		return false
+	}
+	return p.logN == h.logN && p.r == h.r && p.p == h.p
+}
+
+// scryptParams 从哈希值中解析出的参数
+type scryptParams struct {
+	logN uint8
+	r    int
+	p    int
+	salt []byte
+	key  []byte
+}
+
+func parseScrypt(hash string) (*scryptParams, error) {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 5 || parts[1] != AlgorithmScrypt {
+		return nil, errors.New("无效的scrypt哈希格式")
+	}
+	var p scryptParams
+	if _, err := fmt.Sscanf(parts[2], "ln=%d,r=%d,p=%d", &p.logN, &p.r, &p.p); err != nil {
+		return nil, errors.Wrap(err, "解析scrypt参数错误")
+	}
+	if p.logN == 0 || p.logN > 30 {
+		return nil, errors.Errorf("无效的scrypt参数ln: %d", p.logN)
+	}
+	var err error
+	if p.salt, err = base64.RawStdEncoding.DecodeString(parts[3]); err != nil {
+		return nil, errors.Wrap(err, "解码盐值错误")
+	}
+	if p.key, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return nil, errors.Wrap(err, "解码哈希错误")
+	}
+	if len(p.key) == 0 {
+		return nil, errors.New("无效的scrypt哈希格式")
+	}
+	return &p, nil
+}
@@ -0,0 +1,158 @@
+// Package dag 提供有向无环图的校验与调度辅助
+// 用于任务编排时计算执行顺序、可执行节点和失败后需要跳过的下游节点
+package dag
+
+import (
+	"sort"
+
+	"emperror.dev/errors"
+)
+
+// Graph 有向无环图, 边由依赖关系表示: 节点只有在其依赖全部完成后才能执行
+type Graph struct {
+	deps       map[string][]string
+	dependents map[string][]string
+	order      []string
+}
+
+// New 根据节点及其依赖创建有向无环图
+// nodes: 节点名称到其依赖节点名称列表的映射
+// 依赖不存在的节点、依赖自身或存在环时返回错误
+func New(nodes map[string][]string) (*Graph, error) {
+	g := &Graph{
+		deps:       make(map[string][]string, len(nodes)),
+		dependents: make(map[string][]string, len(nodes)),
+	}
+	for name, deps := range nodes {
+		if name == "" {
+			return nil, errors.New("节点名称不能为空")
+		}
+		seen := make(map[string]struct{}, len(deps))
+		for _, dep := range deps {
+			if dep == name {
+				return nil, errors.Errorf("节点不能依赖自身, node=%s", name)
+			}
+			if _, ok := nodes[dep]; !ok {
+				return nil, errors.Errorf("依赖的节点不存在, node=%s, depends_on=%s", name, dep)
+			}
+			if _, ok := seen[dep]; ok {
+				continue
+			}
+			seen[dep] = struct{}{}
+			g.deps[name] = append(g.deps[name], dep)
+			g.dependents[dep] = append(g.dependents[dep], name)
+		}
+		if _, ok := g.deps[name]; !ok {
+			g.deps[name] = nil
+		}
+	}
+
+	order, err := g.topoSort()
+	if err != nil {
+		return nil, err
+	}
+	g.order = order
+	return g, nil
+}
+
+// topoSort 按Kahn算法计算拓扑序, 同一层级内按名称排序保证结果稳定
+func (g *Graph) topoSort() ([]string, error) {
+	inDegree := make(map[string]int, len(g.deps))
+	for name, deps := range g.deps {
+		inDegree[name] = len(deps)
+	}
+
+	var queue []string
+	for name, d := range inDegree {
+		if d == 0 {
+			queue = append(queue, name)
+		}
+	}
+	sort.Strings(queue)
+
+	order := make([]string, 0, len(g.deps))
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		order = append(order, name)
+
+		var next []string
+		for _, child := range g.dependents[name] {
+			inDegree[child]--
+			if inDegree[child] == 0 {
+				next = append(next, child)
+			}
+		}
+		sort.Strings(next)
+		queue = append(queue, next...)
+	}
+
+	if len(order) != len(g.deps) {
+		var cycle []string
+		for name, d := range inDegree {
+			if d > 0 {
+				cycle = append(cycle, name)
+			}
+		}
+		sort.Strings(cycle)
+		return nil, errors.Errorf("节点之间存在循环依赖, nodes=%v", cycle)
+	}
+	return order, nil
+}
+
+// Order 返回拓扑序, 每个节点都排在其依赖之后
+func (g *Graph) Order() []string {
+	return append([]string(nil), g.order...)
+}
+
+// Deps 返回节点的直接依赖
+func (g *Graph) Deps(name string) []string {
+	return append([]string(nil), g.deps[name]...)
+}
+
+// Ready 返回可以开始执行的节点: 尚未开始且依赖全部完成
+// done: 已成功完成的节点
+// started: 已开始执行(包括执行中、已完成、已跳过)的节点
+func (g *Graph) Ready(done, started map[string]bool) []string {
+	var ready []string
+	for _, name := range g.order {
+		if started[name] {
+			continue
+		}
+		ok := true
+		for _, dep := range g.deps[name] {
+			if !done[dep] {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			ready = append(ready, name)
+		}
+	}
+	return ready
+}
+
+// Downstream 返回直接或间接依赖该节点的全部节点, 按拓扑序排列
+// 用于节点失败后跳过其下游节点
+func (g *Graph) Downstream(name string) []string {
+	visited := make(map[string]bool)
+	stack := append([]string(nil), g.dependents[name]...)
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if visited[n] {
+			continue
+		}
+		visited[n] = true
+		stack = append(stack, g.dependents[n]...)
+	}
+
+	result := make([]string, 0, len(visited))
+	for _, n := range g.order {
+		if visited[n] {
+			result = append(result, n)
+		}
+	}
+	return result
+}
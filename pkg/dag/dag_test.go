@@ -0,0 +1,84 @@
+package dag
+
+import (
+	"reflect"
+	"testing"
+)
+
+func newStkGraph(t *testing.T) *Graph {
+	t.Helper()
+	g, err := New(map[string][]string{
+		"mon":                nil,
+		"counter_fetch":      {"mon"},
+		"counter_distribute": {"counter_fetch"},
+		"sse":                {"counter_distribute"},
+		"szse":               {"counter_distribute"},
+		"csdc":               {"sse", "szse"},
+	})
+	if err != nil {
+		t.Fatalf("创建有向无环图失败: %v", err)
+	}
+	return g
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		nodes   map[string][]string
+		wantErr bool
+	}{
+		{"空图", map[string][]string{}, false},
+		{"单节点", map[string][]string{"a": nil}, false},
+		{"重复依赖", map[string][]string{"a": nil, "b": {"a", "a"}}, false},
+		{"依赖不存在", map[string][]string{"a": {"b"}}, true},
+		{"依赖自身", map[string][]string{"a": {"a"}}, true},
+		{"存在环", map[string][]string{"a": {"c"}, "b": {"a"}, "c": {"b"}}, true},
+		{"空节点名", map[string][]string{"": nil}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.nodes)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOrder(t *testing.T) {
+	g := newStkGraph(t)
+	want := []string{"mon", "counter_fetch", "counter_distribute", "sse", "szse", "csdc"}
+	if got := g.Order(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Order() = %v, want %v", got, want)
+	}
+}
+
+func TestReady(t *testing.T) {
+	g := newStkGraph(t)
+
+	if got := g.Ready(nil, nil); !reflect.DeepEqual(got, []string{"mon"}) {
+		t.Errorf("初始可执行节点 = %v, want [mon]", got)
+	}
+
+	done := map[string]bool{"mon": true, "counter_fetch": true, "counter_distribute": true}
+	started := map[string]bool{"mon": true, "counter_fetch": true, "counter_distribute": true}
+	if got := g.Ready(done, started); !reflect.DeepEqual(got, []string{"sse", "szse"}) {
+		t.Errorf("并行可执行节点 = %v, want [sse szse]", got)
+	}
+
+	done["sse"], started["sse"], started["szse"] = true, true, true
+	if got := g.Ready(done, started); len(got) != 0 {
+		t.Errorf("依赖未全部完成时不应有可执行节点, got %v", got)
+	}
+}
+
+func TestDownstream(t *testing.T) {
+	g := newStkGraph(t)
+	want := []string{"counter_distribute", "sse", "szse", "csdc"}
+	if got := g.Downstream("counter_fetch"); !reflect.DeepEqual(got, want) {
+		t.Errorf("Downstream() = %v, want %v", got, want)
+	}
+	if got := g.Downstream("csdc"); len(got) != 0 {
+		t.Errorf("末端节点不应有下游节点, got %v", got)
+	}
+}
@@ -0,0 +1,98 @@
+// Package dataloader 合并同一次请求中逐条加载的关联数据查询
+// 用于GraphQL等按字段逐条解析关联数据的场景, 避免列表中每一项都单独查询一次(N+1查询)
+package dataloader
+
+import (
+	"context"
+	"sync"
+)
+
+// BatchFunc 一次查询多个key对应的数据, 返回结果中不存在的key加载为零值
+type BatchFunc[K comparable, V any] func(ctx context.Context, keys []K) (map[K]V, error)
+
+// Loader 批量数据加载器
+//
+// Load只登记key, 第一次调用任一取值函数时一次查询全部已登记且未查询过的key,
+// 查询结果在加载器内缓存, 加载器应只在单次请求内使用
+type Loader[K comparable, V any] struct {
+	batch   BatchFunc[K, V]
+	mu      sync.Mutex
+	pending []K
+	queued  map[K]struct{}
+	results map[K]V
+	errs    map[K]error
+}
+
+// New 创建批量数据加载器
+func New[K comparable, V any](batch BatchFunc[K, V]) *Loader[K, V] {
+	return &Loader[K, V]{
+		batch:   batch,
+		queued:  make(map[K]struct{}),
+		results: make(map[K]V),
+		errs:    make(map[K]error),
+	}
+}
+
+// Load 登记待查询的key, 返回取值函数
+func (l *Loader[K, V]) Load(ctx context.Context, key K) func() (V, error) {
+	l.mu.Lock()
+	if _, ok := l.queued[key]; !ok {
+		l.queued[key] = struct{}{}
+		l.pending = append(l.pending, key)
+	}
+	l.mu.Unlock()
+
+	return func() (V, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if len(l.pending) > 0 {
+			l.dispatch(ctx)
+		}
+		return l.results[key], l.errs[key]
+	}
+}
+
+// LoadMany 登记多个待查询的key, 返回按keys顺序取值的函数, 不存在的key不出现在结果中
+func (l *Loader[K, V]) LoadMany(ctx context.Context, keys []K) func() ([]V, error) {
+	thunks := make([]func() (V, error), len(keys))
+	for i, key := range keys {
+		thunks[i] = l.Load(ctx, key)
+	}
+	return func() ([]V, error) {
+		values := make([]V, 0, len(keys))
+		for i, thunk := range thunks {
+			v, err := thunk()
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := l.loaded(keys[i]); ok {
+				values = append(values, v)
+			}
+		}
+		return values, nil
+	}
+}
+
+// loaded 返回key是否查询到了数据
+func (l *Loader[K, V]) loaded(key K) (V, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	v, ok := l.results[key]
+	return v, ok
+}
+
+// dispatch 查询全部已登记的key, 调用方需要持有锁
+func (l *Loader[K, V]) dispatch(ctx context.Context) {
+	keys := l.pending
+	l.pending = nil
+	results, err := l.batch(ctx, keys)
+	for _, key := range keys {
+		if err != nil {
+			l.errs[key] = err
+			continue
+		}
+		if v, ok := results[key]; ok {
+			l.results[key] = v
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package dataloader
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestLoaderBatch(t *testing.T) {
+	var calls [][]int
+	l := New(func(_ context.Context, keys []int) (map[int]string, error) {
+		calls = append(calls, slices.Clone(keys))
+		results := make(map[int]string, len(keys))
+		for _, key := range keys {
+			if key > 0 {
+				results[key] = string(rune('a' + key - 1))
+			}
+		}
+		return results, nil
+	})
+
+	ctx := context.Background()
+	thunks := []func() (string, error){
+		l.Load(ctx, 1),
+		l.Load(ctx, 2),
+		l.Load(ctx, 1),
+		l.Load(ctx, -1),
+	}
+	var got []string
+	for _, thunk := range thunks {
+		v, err := thunk()
+		if err != nil {
+			t.Fatalf("加载失败: %v", err)
+		}
+		got = append(got, v)
+	}
+	if want := []string{"a", "b", "a", ""}; !slices.Equal(got, want) {
+		t.Errorf("加载结果 = %v, want %v", got, want)
+	}
+	if want := [][]int{{1, 2, -1}}; len(calls) != 1 || !slices.Equal(calls[0], want[0]) {
+		t.Errorf("批量查询 = %v, want %v", calls, want)
+	}
+
+	// 已查询过的key不再查询
+	if v, _ := l.Load(ctx, 2)(); v != "b" {
+		t.Errorf("缓存的加载结果 = %q, want %q", v, "b")
+	}
+	if len(calls) != 1 {
+		t.Errorf("已查询过的key不应该再次查询, calls = %v", calls)
+	}
+
+	values, err := l.LoadMany(ctx, []int{3, 0, 1})()
+	if err != nil {
+		t.Fatalf("加载失败: %v", err)
+	}
+	if want := []string{"c", "a"}; !slices.Equal(values, want) {
+		t.Errorf("LoadMany = %v, want %v", values, want)
+	}
+	if want := []int{3, 0}; len(calls) != 2 || !slices.Equal(calls[1], want) {
+		t.Errorf("第二次批量查询 = %v, want %v", calls, want)
+	}
+}
+
+func TestLoaderError(t *testing.T) {
+	errBatch := errors.New("查询失败")
+	l := New(func(context.Context, []int) (map[int]int, error) {
+		return nil, errBatch
+	})
+
+	ctx := context.Background()
+	first, second := l.Load(ctx, 1), l.Load(ctx, 2)
+	if _, err := first(); !errors.Is(err, errBatch) {
+		t.Errorf("err = %v, want %v", err, errBatch)
+	}
+	if _, err := second(); !errors.Is(err, errBatch) {
+		t.Errorf("同一批次的key应该返回相同的错误, err = %v", err)
+	}
+}
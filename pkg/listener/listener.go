@@ -0,0 +1,109 @@
+// Package listener 提供TCP端口以外的监听方式: unix域套接字和systemd套接字激活
+package listener
+
+import (
+	"net"
+	"os"
+	"strconv"
+	"time"
+
+	"emperror.dev/errors"
+)
+
+// ErrSocketInUse 套接字文件已被其他正在运行的进程监听
+var ErrSocketInUse = errors.Sentinel("unix套接字正在被其他进程使用")
+
+// systemd传递给服务的第一个文件描述符, 见sd_listen_fds(3)
+const listenFdsStart = 3
+
+// ListenUnix 在path上监听unix域套接字并设置文件权限
+//
+// path上残留的套接字文件(上次异常退出未清理)会被删除后重新创建;
+// 如果该套接字仍有进程在监听则返回ErrSocketInUse, path为其他类型的文件时返回错误.
+// 关闭返回的监听器时会删除套接字文件
+func ListenUnix(path string, mode os.FileMode) (net.Listener, error) {
+	if err := removeStale(path); err != nil {
+		return nil, err
+	}
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "监听unix套接字失败", "path", path)
+	}
+	if mode != 0 {
+		if err := os.Chmod(path, mode); err != nil {
+			_ = ln.Close()
+			return nil, errors.WrapIfWithDetails(err, "设置unix套接字权限失败", "path", path)
+		}
+	}
+	return ln, nil
+}
+
+// removeStale 删除残留的套接字文件
+func removeStale(path string) error {
+	info, err := os.Lstat(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "读取unix套接字文件信息失败", "path", path)
+	}
+	if info.Mode()&os.ModeSocket == 0 {
+		return errors.NewWithDetails("unix套接字路径已存在且不是套接字文件", "path", path)
+	}
+	if conn, err := net.DialTimeout("unix", path, time.Second); err == nil {
+		_ = conn.Close()
+		return errors.WithDetails(ErrSocketInUse, "path", path)
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return errors.WrapIfWithDetails(err, "删除残留的unix套接字文件失败", "path", path)
+	}
+	return nil
+}
+
+// RemoveSocket 删除unix套接字文件, 文件不存在或不是套接字时忽略
+//
+// 正常关闭监听器时套接字文件已被删除, 用于进程退出前兜底清理
+func RemoveSocket(path string) error {
+	info, err := os.Lstat(path)
+	if err != nil || info.Mode()&os.ModeSocket == 0 {
+		return nil
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return errors.WrapIfWithDetails(err, "删除unix套接字文件失败", "path", path)
+	}
+	return nil
+}
+
+// Systemd 返回systemd套接字激活传入的监听器, 未通过套接字激活启动时返回nil
+//
+// 按sd_listen_fds(3)的约定读取LISTEN_PID和LISTEN_FDS, 读取后清除这两个环境变量,
+// 避免子进程误认为自己是被激活的服务
+func Systemd() ([]net.Listener, error) {
+	pid, err := strconv.Atoi(os.Getenv("LISTEN_PID"))
+	if err != nil || pid != os.Getpid() {
+		return nil, nil
+	}
+	n, err := strconv.Atoi(os.Getenv("LISTEN_FDS"))
+	if err != nil || n <= 0 {
+		return nil, nil
+	}
+	_ = os.Unsetenv("LISTEN_PID")
+	_ = os.Unsetenv("LISTEN_FDS")
+	_ = os.Unsetenv("LISTEN_FDNAMES")
+
+	listeners := make([]net.Listener, 0, n)
+	for fd := listenFdsStart; fd < listenFdsStart+n; fd++ {
+		f := os.NewFile(uintptr(fd), "LISTEN_FD_"+strconv.Itoa(fd))
+		// FileListener复制了文件描述符, 原文件可以直接关闭
+		ln, err := net.FileListener(f)
+		_ = f.Close()
+		if err != nil {
+			for _, l := range listeners {
+				_ = l.Close()
+			}
+			return nil, errors.WrapIfWithDetails(err, "读取systemd套接字失败", "fd", fd)
+		}
+		listeners = append(listeners, ln)
+	}
+	return listeners, nil
+}
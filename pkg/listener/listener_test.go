@@ -0,0 +1,109 @@
+package listener
+
+import (
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+// socketPath 返回较短的套接字路径, unix套接字路径长度有限制
+func socketPath(t *testing.T) string {
+	t.Helper()
+	dir, err := os.MkdirTemp("", "sock")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	return filepath.Join(dir, "s.sock")
+}
+
+func TestListenUnix(t *testing.T) {
+	path := socketPath(t)
+	ln, err := ListenUnix(path, 0o660)
+	if err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o660 {
+		t.Fatalf("权限=%o, 期望660", perm)
+	}
+
+	go func() {
+		if conn, err := ln.Accept(); err == nil {
+			_ = conn.Close()
+		}
+	}()
+	conn, err := net.Dial("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = conn.Close()
+
+	if err := ln.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Lstat(path); !os.IsNotExist(err) {
+		t.Fatalf("关闭监听器后应该删除套接字文件, err=%v", err)
+	}
+}
+
+func TestListenUnixInUse(t *testing.T) {
+	path := socketPath(t)
+	ln, err := ListenUnix(path, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	if _, err := ListenUnix(path, 0); !errors.Is(err, ErrSocketInUse) {
+		t.Fatalf("套接字正在使用时应该返回ErrSocketInUse, err=%v", err)
+	}
+}
+
+func TestListenUnixStale(t *testing.T) {
+	path := socketPath(t)
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 模拟异常退出: 关闭监听但保留套接字文件
+	ln.(*net.UnixListener).SetUnlinkOnClose(false)
+	_ = ln.Close()
+
+	ln, err = ListenUnix(path, 0)
+	if err != nil {
+		t.Fatalf("残留的套接字文件应该被删除, err=%v", err)
+	}
+	_ = ln.Close()
+}
+
+func TestListenUnixNotSocket(t *testing.T) {
+	path := socketPath(t)
+	if err := os.WriteFile(path, []byte("data"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ListenUnix(path, 0); err == nil {
+		t.Fatal("路径为普通文件时应该返回错误")
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("普通文件不应该被删除, err=%v", err)
+	}
+}
+
+func TestSystemdNotActivated(t *testing.T) {
+	t.Setenv("LISTEN_PID", strconv.Itoa(os.Getpid()+1))
+	t.Setenv("LISTEN_FDS", "1")
+	ls, err := Systemd()
+	if err != nil || ls != nil {
+		t.Fatalf("LISTEN_PID不是当前进程时应该返回nil, ls=%v err=%v", ls, err)
+	}
+	if os.Getenv("LISTEN_FDS") != "1" {
+		t.Fatal("不是当前进程的环境变量不应该被清除")
+	}
+}
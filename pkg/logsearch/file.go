@@ -0,0 +1,144 @@
+package logsearch
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+)
+
+// 单行日志的最大长度
+const maxLineSize = 1024 * 1024
+
+// FileSearcher 读取lumberjack轮转后的日志文件
+//
+// 日志源source对应Dir下的 <source>.log 以及备份文件 <source>-<时间戳>.log(.gz),
+// 备份文件名中的时间戳按字典序即为时间顺序
+type FileSearcher struct {
+	Dir        string
+	TraceField string
+}
+
+func NewFileSearcher(dir, traceField string) *FileSearcher {
+	return &FileSearcher{Dir: dir, TraceField: traceField}
+}
+
+// Search 由新到旧读取日志文件, 满足条数后不再读取更早的文件
+func (s *FileSearcher) Search(ctx context.Context, source string, q Query) ([]Entry, error) {
+	files, err := s.files(source)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	for _, f := range files {
+		if err := ctx.Err(); err != nil {
+			return nil, errors.WithStack(err)
+		}
+		// 文件的修改时间即为其中最后一条日志的时间
+		if !q.Start.IsZero() && f.modTime.Before(q.Start) {
+			break
+		}
+		matched, err := s.searchFile(ctx, source, f.path, q)
+		if err != nil {
+			return nil, err
+		}
+		// 同一个文件内的日志按时间顺序写入, 只保留最新的部分
+		if q.Limit > 0 && len(entries)+len(matched) > q.Limit {
+			matched = matched[len(entries)+len(matched)-q.Limit:]
+		}
+		entries = append(entries, matched...)
+		if q.Limit > 0 && len(entries) >= q.Limit {
+			break
+		}
+	}
+	return SortAndLimit(entries, q.Limit), nil
+}
+
+type fileInfo struct {
+	path    string
+	modTime time.Time
+}
+
+// files 返回日志源对应的文件, 当前文件在前, 备份文件由新到旧
+func (s *FileSearcher) files(source string) ([]fileInfo, error) {
+	if source == "" || strings.ContainsAny(source, `/\`) || strings.Contains(source, "..") {
+		return nil, errors.Errorf("日志源名称无效, source=%s", source)
+	}
+	backups, err := filepath.Glob(filepath.Join(s.Dir, source+"-*.log*"))
+	if err != nil {
+		return nil, errors.WrapIf(err, "查找日志备份文件失败")
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(backups)))
+
+	paths := append([]string{filepath.Join(s.Dir, source+".log")}, backups...)
+	files := make([]fileInfo, 0, len(paths))
+	for _, p := range paths {
+		if !strings.HasSuffix(p, ".log") && !strings.HasSuffix(p, ".log.gz") {
+			continue
+		}
+		st, err := os.Stat(p)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, errors.WrapIfWithDetails(err, "读取日志文件信息失败", "path", p)
+		}
+		files = append(files, fileInfo{path: p, modTime: st.ModTime()})
+	}
+	return files, nil
+}
+
+func (s *FileSearcher) searchFile(ctx context.Context, source, path string, q Query) ([]Entry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.WrapIfWithDetails(err, "打开日志文件失败", "path", path)
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if strings.HasSuffix(path, ".gz") {
+		gr, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, errors.WrapIfWithDetails(err, "解压日志文件失败", "path", path)
+		}
+		defer gr.Close()
+		r = gr
+	}
+
+	var (
+		entries []Entry
+		lines   int
+	)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	for scanner.Scan() {
+		lines++
+		if lines%1000 == 0 && ctx.Err() != nil {
+			return nil, errors.WithStack(ctx.Err())
+		}
+		line := scanner.Bytes()
+		if q.Keyword != "" && !strings.Contains(string(line), q.Keyword) {
+			continue
+		}
+		e, err := ParseEntry(source, line, s.TraceField)
+		if err != nil {
+			// 非JSON格式的行(如panic输出)直接跳过
+			continue
+		}
+		if q.Match(e, line) {
+			entries = append(entries, *e)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.WrapIfWithDetails(err, "读取日志文件失败", "path", path)
+	}
+	return entries, nil
+}
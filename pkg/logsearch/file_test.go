@@ -0,0 +1,150 @@
+package logsearch
+
+import (
+	"compress/gzip"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeLogFile(t *testing.T, path string, lines []string, modTime time.Time) {
+	t.Helper()
+	content := strings.Join(lines, "\n") + "\n"
+	if strings.HasSuffix(path, ".gz") {
+		f, err := os.Create(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		gw := gzip.NewWriter(f)
+		if _, err := gw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+		gw.Close()
+		f.Close()
+	} else if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func newTestLogDir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	writeLogFile(t, filepath.Join(dir, "service-2024-01-01T00-00-00.000.log.gz"), []string{
+		`{"level":"info","time":"2024-01-01T09:00:00.000+0800","caller":"a.go:1","msg":"备份压缩日志","request_id":"t-1"}`,
+	}, time.Date(2024, 1, 1, 9, 0, 0, 0, time.FixedZone("", 8*3600)))
+	writeLogFile(t, filepath.Join(dir, "service-2024-01-02T00-00-00.000.log"), []string{
+		`{"level":"error","time":"2024-01-02T09:00:00.000+0800","caller":"a.go:2","msg":"备份日志错误","request_id":"t-2"}`,
+		`panic: not json`,
+	}, time.Date(2024, 1, 2, 9, 0, 0, 0, time.FixedZone("", 8*3600)))
+	writeLogFile(t, filepath.Join(dir, "service.log"), []string{
+		`{"level":"debug","time":"2024-01-03T09:00:00.000+0800","caller":"a.go:3","msg":"当前日志调试","request_id":"t-3"}`,
+		`{"level":"warn","time":"2024-01-03T10:00:00.000+0800","caller":"a.go:4","msg":"当前日志告警","request_id":"t-1","user":"admin"}`,
+	}, time.Date(2024, 1, 3, 10, 0, 0, 0, time.FixedZone("", 8*3600)))
+	writeLogFile(t, filepath.Join(dir, "biz.log"), []string{
+		`{"level":"info","time":"2024-01-03T09:00:00.000+0800","msg":"其他日志源"}`,
+	}, time.Now())
+	return dir
+}
+
+func TestFileSearcherSearch(t *testing.T) {
+	dir := newTestLogDir(t)
+	s := NewFileSearcher(dir, "request_id")
+	loc := time.FixedZone("", 8*3600)
+
+	tests := []struct {
+		name string
+		q    Query
+		msgs []string
+	}{
+		{"全部日志按时间倒序", Query{}, []string{"当前日志告警", "当前日志调试", "备份日志错误", "备份压缩日志"}},
+		{"限制条数", Query{Limit: 3}, []string{"当前日志告警", "当前日志调试", "备份日志错误"}},
+		{"最低级别", Query{Level: "warn"}, []string{"当前日志告警", "备份日志错误"}},
+		{"链路ID", Query{TraceID: "t-1"}, []string{"当前日志告警", "备份压缩日志"}},
+		{"关键字", Query{Keyword: "admin"}, []string{"当前日志告警"}},
+		{
+			"时间范围",
+			Query{Start: time.Date(2024, 1, 2, 0, 0, 0, 0, loc), End: time.Date(2024, 1, 3, 9, 30, 0, 0, loc)},
+			[]string{"当前日志调试", "备份日志错误"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := s.Search(context.Background(), "service", tt.q)
+			if err != nil {
+				t.Fatalf("Search() error = %v", err)
+			}
+			msgs := make([]string, 0, len(entries))
+			for _, e := range entries {
+				if e.Source != "service" {
+					t.Errorf("Source = %s, want service", e.Source)
+				}
+				msgs = append(msgs, e.Msg)
+			}
+			if strings.Join(msgs, ",") != strings.Join(tt.msgs, ",") {
+				t.Errorf("Search() = %v, want %v", msgs, tt.msgs)
+			}
+		})
+	}
+}
+
+func TestFileSearcherFields(t *testing.T) {
+	s := NewFileSearcher(newTestLogDir(t), "request_id")
+	entries, err := s.Search(context.Background(), "service", Query{Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("len = %d, want 1", len(entries))
+	}
+	e := entries[0]
+	if e.TraceID != "t-1" || e.Caller != "a.go:4" || e.Level != "warn" {
+		t.Errorf("unexpected entry %+v", e)
+	}
+	if e.Fields["user"] != "admin" {
+		t.Errorf("Fields = %v, want user=admin", e.Fields)
+	}
+	if _, ok := e.Fields["msg"]; ok {
+		t.Error("Fields should not contain msg")
+	}
+}
+
+func TestFileSearcherInvalidSource(t *testing.T) {
+	s := NewFileSearcher(t.TempDir(), "request_id")
+	for _, source := range []string{"", "../service", "a/b"} {
+		if _, err := s.Search(context.Background(), source, Query{}); err == nil {
+			t.Errorf("Search(%q) expected error", source)
+		}
+	}
+	entries, err := s.Search(context.Background(), "missing", Query{})
+	if err != nil || len(entries) != 0 {
+		t.Errorf("Search(missing) = %v, %v, want empty", entries, err)
+	}
+}
+
+func TestParseEntryECS(t *testing.T) {
+	line := `{"log.level":"error","@timestamp":"2024-01-03T10:00:00.000+0800","log.logger":"biz","log.origin":{"function":"a.b","file":{"name":"a.go","line":4}},"message":"查询失败","trace.id":"t-1","error":{"message":"boom"}}`
+	e, err := ParseEntry("biz", []byte(line), "request_id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.TraceID != "t-1" || e.Caller != "a.go:4" || e.Level != "error" || e.Msg != "查询失败" {
+		t.Errorf("unexpected entry %+v", e)
+	}
+	if e.Time.IsZero() {
+		t.Error("Time should be parsed from @timestamp")
+	}
+	for _, k := range []string{"message", "log.level", "log.origin", "@timestamp"} {
+		if _, ok := e.Fields[k]; ok {
+			t.Errorf("Fields should not contain %s", k)
+		}
+	}
+	if _, ok := e.Fields["error"]; !ok {
+		t.Error("Fields should contain error")
+	}
+}
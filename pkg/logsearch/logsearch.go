@@ -0,0 +1,147 @@
+// Package logsearch 提供zap JSON日志的检索
+// 支持直接读取lumberjack轮转后的日志文件, 或查询Loki中采集的日志
+package logsearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+)
+
+// zap ISO8601TimeEncoder输出的时间格式
+const timeLayout = "2006-01-02T15:04:05.000Z0700"
+
+// 日志级别由低到高的顺序, 与zapcore.Level的文本形式一致
+var levelRank = map[string]int{
+	"debug":  0,
+	"info":   1,
+	"warn":   2,
+	"error":  3,
+	"dpanic": 4,
+	"panic":  5,
+	"fatal":  6,
+}
+
+// Query 日志检索条件, 零值字段表示不限制
+type Query struct {
+	Start   time.Time // 开始时间(包含)
+	End     time.Time // 结束时间(包含)
+	Level   string    // 最低日志级别
+	TraceID string    // 链路ID
+	Keyword string    // 关键字, 匹配整行日志
+	Limit   int       // 最多返回的条数
+}
+
+// Entry 一条日志
+type Entry struct {
+	Source  string         `json:"source"`
+	Time    time.Time      `json:"time"`
+	Level   string         `json:"level"`
+	Caller  string         `json:"caller"`
+	Msg     string         `json:"msg"`
+	TraceID string         `json:"trace_id"`
+	Fields  map[string]any `json:"fields"`
+}
+
+// Searcher 日志检索接口, 结果按时间倒序排列
+type Searcher interface {
+	Search(ctx context.Context, source string, q Query) ([]Entry, error)
+}
+
+// ECS格式日志中对应的字段名
+const (
+	ecsTimeField    = "@timestamp"
+	ecsLevelField   = "log.level"
+	ecsMsgField     = "message"
+	ecsLoggerField  = "log.logger"
+	ecsOriginField  = "log.origin"
+	ecsTraceIDField = "trace.id"
+)
+
+// ParseEntry 解析一行zap JSON日志, traceField为日志中链路ID的字段名
+//
+// 同时支持ECS格式的日志, 字段名不同时按ECS的字段名读取
+func ParseEntry(source string, line []byte, traceField string) (*Entry, error) {
+	fields := map[string]any{}
+	if err := json.Unmarshal(line, &fields); err != nil {
+		return nil, errors.WrapIf(err, "解析日志行失败")
+	}
+	e := &Entry{Source: source}
+	if v, ok := firstString(fields, "time", ecsTimeField); ok {
+		t, err := time.Parse(timeLayout, v)
+		if err != nil {
+			return nil, errors.WrapIfWithDetails(err, "解析日志时间失败", "time", v)
+		}
+		e.Time = t
+	}
+	e.Level, _ = firstString(fields, "level", ecsLevelField)
+	e.Msg, _ = firstString(fields, "msg", ecsMsgField)
+	e.Caller, _ = fields["caller"].(string)
+	if origin, ok := fields[ecsOriginField].(map[string]any); ok && e.Caller == "" {
+		if file, ok := origin["file"].(map[string]any); ok {
+			name, _ := file["name"].(string)
+			line, _ := file["line"].(float64)
+			e.Caller = fmt.Sprintf("%s:%d", name, int(line))
+		}
+	}
+	if traceField != "" {
+		e.TraceID, _ = firstString(fields, traceField, ecsTraceIDField)
+	}
+	for _, k := range []string{"time", "level", "caller", "msg", "logger", ecsTimeField, ecsLevelField, ecsMsgField, ecsLoggerField, ecsOriginField} {
+		delete(fields, k)
+	}
+	e.Fields = fields
+	return e, nil
+}
+
+// firstString 返回第一个存在的字符串字段
+func firstString(fields map[string]any, keys ...string) (string, bool) {
+	for _, k := range keys {
+		if v, ok := fields[k].(string); ok {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// Match 判断日志是否满足检索条件, line为原始日志行
+func (q Query) Match(e *Entry, line []byte) bool {
+	if !q.Start.IsZero() && e.Time.Before(q.Start) {
+		return false
+	}
+	if !q.End.IsZero() && e.Time.After(q.End) {
+		return false
+	}
+	if q.Level != "" && levelRank[strings.ToLower(e.Level)] < levelRank[strings.ToLower(q.Level)] {
+		return false
+	}
+	if q.TraceID != "" && e.TraceID != q.TraceID {
+		return false
+	}
+	if q.Keyword != "" && !strings.Contains(string(line), q.Keyword) {
+		return false
+	}
+	return true
+}
+
+// ValidLevel 判断日志级别是否有效
+func ValidLevel(level string) bool {
+	_, ok := levelRank[strings.ToLower(level)]
+	return ok
+}
+
+// SortAndLimit 按时间倒序排列日志, 并截取前limit条, limit小于等于0时不截取
+func SortAndLimit(entries []Entry, limit int) []Entry {
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Time.After(entries[j].Time)
+	})
+	if limit > 0 && len(entries) > limit {
+		entries = entries[:limit]
+	}
+	return entries
+}
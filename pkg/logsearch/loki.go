@@ -0,0 +1,160 @@
+package logsearch
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+)
+
+// Loki单次查询的默认条数限制
+const defaultLokiLimit = 1000
+
+// 默认的响应体大小限制
+const defaultMaxBodySize int64 = 20 * 1024 * 1024
+
+// LokiSearcher 通过Loki的query_range接口检索日志
+//
+// 日志源通过流标签区分, 例如采集时为service.log设置标签 job="service"
+type LokiSearcher struct {
+	baseURL     string
+	label       string
+	traceField  string
+	httpClient  *http.Client
+	maxBodySize int64
+}
+
+// NewLokiSearcher 创建Loki日志检索
+// baseURL: Loki服务地址, 如 http://127.0.0.1:3100
+// label: 区分日志源的流标签名, 为空时使用job
+// timeout: 单次请求超时时间, 小于等于0时不设置超时
+func NewLokiSearcher(baseURL, label, traceField string, timeout time.Duration) (*LokiSearcher, error) {
+	u, err := url.Parse(strings.TrimSpace(baseURL))
+	if err != nil {
+		return nil, errors.WrapIf(err, "解析Loki地址失败")
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, errors.Errorf("Loki地址协议必须为http或https, url=%s", baseURL)
+	}
+	if u.Host == "" {
+		return nil, errors.Errorf("Loki地址缺少主机, url=%s", baseURL)
+	}
+	if label == "" {
+		label = "job"
+	}
+	return &LokiSearcher{
+		baseURL:     strings.TrimRight(u.String(), "/"),
+		label:       label,
+		traceField:  traceField,
+		httpClient:  &http.Client{Timeout: timeout},
+		maxBodySize: defaultMaxBodySize,
+	}, nil
+}
+
+// LogQL 根据检索条件生成LogQL, 链路ID和关键字使用行过滤, 日志级别在解析后过滤
+func (s *LokiSearcher) LogQL(source string, q Query) string {
+	var b strings.Builder
+	b.WriteString("{")
+	b.WriteString(s.label)
+	b.WriteString("=")
+	b.WriteString(strconv.Quote(source))
+	b.WriteString("}")
+	if q.TraceID != "" {
+		b.WriteString(" |= ")
+		b.WriteString(strconv.Quote(q.TraceID))
+	}
+	if q.Keyword != "" {
+		b.WriteString(" |= ")
+		b.WriteString(strconv.Quote(q.Keyword))
+	}
+	return b.String()
+}
+
+type lokiResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+	Data   struct {
+		ResultType string `json:"resultType"`
+		Result     []struct {
+			Values [][2]string `json:"values"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+func (s *LokiSearcher) Search(ctx context.Context, source string, q Query) ([]Entry, error) {
+	limit := q.Limit
+	if limit <= 0 {
+		limit = defaultLokiLimit
+	}
+	params := url.Values{}
+	params.Set("query", s.LogQL(source, q))
+	params.Set("limit", strconv.Itoa(limit))
+	params.Set("direction", "backward")
+	if !q.Start.IsZero() {
+		params.Set("start", strconv.FormatInt(q.Start.UnixNano(), 10))
+	}
+	if !q.End.IsZero() {
+		params.Set("end", strconv.FormatInt(q.End.UnixNano(), 10))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.baseURL+"/loki/api/v1/query_range?"+params.Encode(), nil)
+	if err != nil {
+		return nil, errors.WrapIf(err, "创建Loki请求失败")
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.WrapIf(err, "请求Loki失败")
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, s.maxBodySize+1))
+	if err != nil {
+		return nil, errors.WrapIf(err, "读取Loki响应失败")
+	}
+	if int64(len(body)) > s.maxBodySize {
+		return nil, errors.Errorf("Loki响应超出大小限制, limit=%d", s.maxBodySize)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.NewWithDetails(
+			"Loki查询失败",
+			"status_code", resp.StatusCode,
+			"body", strings.TrimSpace(string(body)),
+		)
+	}
+
+	var r lokiResponse
+	if err := json.Unmarshal(body, &r); err != nil {
+		return nil, errors.WrapIf(err, "解析Loki响应失败")
+	}
+	if r.Status != "success" {
+		return nil, errors.NewWithDetails("Loki查询失败", "error", r.Error)
+	}
+
+	var entries []Entry
+	for _, stream := range r.Data.Result {
+		for _, v := range stream.Values {
+			line := []byte(v[1])
+			e, err := ParseEntry(source, line, s.traceField)
+			if err != nil {
+				continue
+			}
+			if e.Time.IsZero() {
+				if ns, err := strconv.ParseInt(v[0], 10, 64); err == nil {
+					e.Time = time.Unix(0, ns)
+				}
+			}
+			if q.Match(e, line) {
+				entries = append(entries, *e)
+			}
+		}
+	}
+	return SortAndLimit(entries, q.Limit), nil
+}
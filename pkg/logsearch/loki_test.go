@@ -0,0 +1,75 @@
+package logsearch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNewLokiSearcher(t *testing.T) {
+	for _, u := range []string{"ftp://loki:3100", "http://", "://bad"} {
+		if _, err := NewLokiSearcher(u, "", "request_id", time.Second); err == nil {
+			t.Errorf("NewLokiSearcher(%q) expected error", u)
+		}
+	}
+	s, err := NewLokiSearcher("http://loki:3100/", "", "request_id", time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := s.LogQL("service", Query{TraceID: "t-1", Keyword: `say "hi"`}); got != `{job="service"} |= "t-1" |= "say \"hi\""` {
+		t.Errorf("LogQL() = %s", got)
+	}
+}
+
+func TestLokiSearcherSearch(t *testing.T) {
+	var gotQuery string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/loki/api/v1/query_range" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		gotQuery = r.URL.Query().Get("query")
+		if r.URL.Query().Get("direction") != "backward" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte("bad direction"))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"status":"success","data":{"resultType":"streams","result":[` +
+			`{"stream":{"app":"biz"},"values":[` +
+			`["1704247200000000000","{\"level\":\"error\",\"time\":\"2024-01-03T10:00:00.000+0800\",\"msg\":\"错误\",\"request_id\":\"t-1\"}"],` +
+			`["1704243600000000000","{\"level\":\"info\",\"time\":\"2024-01-03T09:00:00.000+0800\",\"msg\":\"信息\",\"request_id\":\"t-1\"}"],` +
+			`["1704240000000000000","not json"]]}]}}`))
+	}))
+	defer srv.Close()
+
+	s, err := NewLokiSearcher(srv.URL, "app", "request_id", time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries, err := s.Search(context.Background(), "biz", Query{TraceID: "t-1"})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if gotQuery != `{app="biz"} |= "t-1"` {
+		t.Errorf("query = %s", gotQuery)
+	}
+	if len(entries) != 2 || entries[0].Msg != "错误" || entries[1].Msg != "信息" {
+		t.Errorf("Search() = %+v", entries)
+	}
+
+	entries, err = s.Search(context.Background(), "biz", Query{Level: "error"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].Level != "error" {
+		t.Errorf("Search(level=error) = %+v", entries)
+	}
+
+	bad, _ := NewLokiSearcher(srv.URL+"/missing", "app", "request_id", time.Second)
+	if _, err := bad.Search(context.Background(), "biz", Query{}); err == nil {
+		t.Error("Search() expected error for 404")
+	}
+}
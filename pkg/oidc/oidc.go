@@ -0,0 +1,430 @@
+// Package oidc 提供OpenID Connect授权码模式(PKCE)的轻量客户端
+// 仅覆盖登录需要的服务发现、授权地址、令牌交换和ID令牌校验
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// 默认的响应体大小限制
+const defaultMaxBodySize int64 = 1024 * 1024
+
+// 签名密钥缓存时间, 未找到令牌中的密钥标识时立即重新获取
+const keysTTL = time.Hour
+
+// ID令牌允许的签名方法, 不接受HMAC和none
+var validMethods = []string{
+	"RS256", "RS384", "RS512",
+	"PS256", "PS384", "PS512",
+	"ES256", "ES384", "ES512",
+	"EdDSA",
+}
+
+// Config 身份提供方配置
+type Config struct {
+	Issuer       string   // 签发者地址, 服务发现地址为 {Issuer}/.well-known/openid-configuration
+	ClientID     string   // 客户端标识
+	ClientSecret string   // 客户端密钥, 为空时作为公共客户端仅使用PKCE
+	RedirectURL  string   // 授权回调地址
+	Scopes       []string // 申请的权限范围, 总是包含openid
+}
+
+// Metadata 服务发现文档中需要的部分
+type Metadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JwksURI               string `json:"jwks_uri"`
+}
+
+// Token 令牌端点的响应
+type Token struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	IDToken     string `json:"id_token"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+// Claims ID令牌中的声明
+type Claims map[string]any
+
+// String 返回字符串类型的声明, 不存在或类型不符时返回空字符串
+func (c Claims) String(name string) string {
+	v, _ := c[name].(string)
+	return v
+}
+
+// Strings 返回字符串或字符串数组类型的声明
+func (c Claims) Strings(name string) []string {
+	switch v := c[name].(type) {
+	case string:
+		return []string{v}
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
+
+// Provider 身份提供方客户端, 服务发现文档和签名密钥在首次使用时获取并缓存
+type Provider struct {
+	conf        Config
+	httpClient  *http.Client
+	maxBodySize int64
+
+	mu     sync.Mutex
+	meta   *Metadata
+	keys   map[string]any
+	keysAt time.Time
+}
+
+// NewProvider 创建身份提供方客户端
+// timeout: 单次请求超时时间, 小于等于0时不设置超时
+func NewProvider(conf Config, timeout time.Duration) (*Provider, error) {
+	u, err := url.Parse(strings.TrimSpace(conf.Issuer))
+	if err != nil {
+		return nil, errors.WrapIf(err, "解析OIDC签发者地址失败")
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, errors.Errorf("OIDC签发者地址协议必须为http或https, issuer=%s", conf.Issuer)
+	}
+	if u.Host == "" {
+		return nil, errors.Errorf("OIDC签发者地址缺少主机, issuer=%s", conf.Issuer)
+	}
+	if conf.ClientID == "" {
+		return nil, errors.New("OIDC客户端标识不能为空")
+	}
+	if conf.RedirectURL == "" {
+		return nil, errors.New("OIDC回调地址不能为空")
+	}
+	conf.Issuer = strings.TrimRight(conf.Issuer, "/")
+	return &Provider{
+		conf:        conf,
+		httpClient:  &http.Client{Timeout: timeout},
+		maxBodySize: defaultMaxBodySize,
+	}, nil
+}
+
+// NewPKCE 生成PKCE校验码和对应的S256质询码
+func NewPKCE() (verifier, challenge string, err error) {
+	verifier, err = RandomString(32)
+	if err != nil {
+		return "", "", err
+	}
+	sum := sha256.Sum256([]byte(verifier))
+	return verifier, base64.RawURLEncoding.EncodeToString(sum[:]), nil
+}
+
+// RandomString 生成n字节随机数的base64url编码, 用作state和nonce
+func RandomString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.WrapIf(err, "生成随机数失败")
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// AuthCodeURL 生成授权地址
+func (p *Provider) AuthCodeURL(ctx context.Context, state, nonce, challenge string) (string, error) {
+	meta, err := p.Discover(ctx)
+	if err != nil {
+		return "", err
+	}
+	scopes := p.conf.Scopes
+	hasOpenID := false
+	for _, scope := range scopes {
+		if scope == "openid" {
+			hasOpenID = true
+			break
+		}
+	}
+	if !hasOpenID {
+		scopes = append([]string{"openid"}, scopes...)
+	}
+
+	params := url.Values{}
+	params.Set("response_type", "code")
+	params.Set("client_id", p.conf.ClientID)
+	params.Set("redirect_uri", p.conf.RedirectURL)
+	params.Set("scope", strings.Join(scopes, " "))
+	params.Set("state", state)
+	params.Set("nonce", nonce)
+	params.Set("code_challenge", challenge)
+	params.Set("code_challenge_method", "S256")
+
+	sep := "?"
+	if strings.Contains(meta.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+	return meta.AuthorizationEndpoint + sep + params.Encode(), nil
+}
+
+// Exchange 使用授权码和PKCE校验码换取令牌
+func (p *Provider) Exchange(ctx context.Context, code, verifier string) (*Token, error) {
+	meta, err := p.Discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.conf.RedirectURL)
+	form.Set("code_verifier", verifier)
+	if p.conf.ClientSecret == "" {
+		form.Set("client_id", p.conf.ClientID)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, meta.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, errors.WrapIf(err, "创建OIDC令牌请求失败")
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if p.conf.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(p.conf.ClientID), url.QueryEscape(p.conf.ClientSecret))
+	}
+
+	var token Token
+	if err := p.do(req, &token); err != nil {
+		return nil, errors.WrapIf(err, "OIDC令牌交换失败")
+	}
+	if token.IDToken == "" {
+		return nil, errors.New("OIDC令牌响应中缺少id_token")
+	}
+	return &token, nil
+}
+
+// VerifyIDToken 校验ID令牌的签名、签发者、受众、有效期和nonce, 返回其中的声明
+func (p *Provider) VerifyIDToken(ctx context.Context, raw, nonce string) (Claims, error) {
+	meta, err := p.Discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(raw, claims, func(t *jwt.Token) (any, error) {
+		kid, _ := t.Header["kid"].(string)
+		return p.key(ctx, kid)
+	},
+		jwt.WithValidMethods(validMethods),
+		jwt.WithIssuer(meta.Issuer),
+		jwt.WithAudience(p.conf.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(time.Minute),
+	)
+	if err != nil {
+		return nil, errors.WrapIf(err, "校验OIDC ID令牌失败")
+	}
+
+	c := Claims(claims)
+	if c.String("nonce") != nonce {
+		return nil, errors.New("OIDC ID令牌的nonce不一致")
+	}
+	if c.String("sub") == "" {
+		return nil, errors.New("OIDC ID令牌缺少sub")
+	}
+	return c, nil
+}
+
+// Discover 获取并缓存服务发现文档
+func (p *Provider) Discover(ctx context.Context) (*Metadata, error) {
+	p.mu.Lock()
+	meta := p.meta
+	p.mu.Unlock()
+	if meta != nil {
+		return meta, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.conf.Issuer+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, errors.WrapIf(err, "创建OIDC服务发现请求失败")
+	}
+	var m Metadata
+	if err := p.do(req, &m); err != nil {
+		return nil, errors.WrapIf(err, "获取OIDC服务发现文档失败")
+	}
+	if strings.TrimRight(m.Issuer, "/") != p.conf.Issuer {
+		return nil, errors.Errorf("OIDC服务发现文档的签发者不一致, issuer=%s", m.Issuer)
+	}
+	if m.AuthorizationEndpoint == "" || m.TokenEndpoint == "" || m.JwksURI == "" {
+		return nil, errors.New("OIDC服务发现文档缺少必要的端点")
+	}
+
+	p.mu.Lock()
+	p.meta = &m
+	p.mu.Unlock()
+	return &m, nil
+}
+
+// key 按密钥标识查找签名公钥, 缓存过期或未找到时重新获取
+func (p *Provider) key(ctx context.Context, kid string) (any, error) {
+	p.mu.Lock()
+	keys, fresh := p.keys, time.Since(p.keysAt) < keysTTL
+	p.mu.Unlock()
+	if k, ok := lookupKey(keys, kid); ok && fresh {
+		return k, nil
+	}
+
+	keys, err := p.fetchKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	p.mu.Lock()
+	p.keys, p.keysAt = keys, time.Now()
+	p.mu.Unlock()
+
+	if k, ok := lookupKey(keys, kid); ok {
+		return k, nil
+	}
+	return nil, errors.Errorf("未找到OIDC签名密钥, kid=%s", kid)
+}
+
+// lookupKey 令牌未指定密钥标识时只有一个密钥才能确定
+func lookupKey(keys map[string]any, kid string) (any, bool) {
+	if kid == "" && len(keys) == 1 {
+		for _, k := range keys {
+			return k, true
+		}
+	}
+	k, ok := keys[kid]
+	return k, ok
+}
+
+type jsonWebKey struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (p *Provider) fetchKeys(ctx context.Context) (map[string]any, error) {
+	meta, err := p.Discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, meta.JwksURI, nil)
+	if err != nil {
+		return nil, errors.WrapIf(err, "创建OIDC签名密钥请求失败")
+	}
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := p.do(req, &set); err != nil {
+		return nil, errors.WrapIf(err, "获取OIDC签名密钥失败")
+	}
+
+	keys := make(map[string]any, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		k, err := parseJWK(jwk)
+		if err != nil {
+			// 不支持的密钥类型不影响其他密钥
+			continue
+		}
+		keys[jwk.Kid] = k
+	}
+	return keys, nil
+}
+
+func parseJWK(jwk jsonWebKey) (any, error) {
+	switch jwk.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			return nil, errors.WrapIf(err, "解析RSA密钥失败")
+		}
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil {
+			return nil, errors.WrapIf(err, "解析RSA密钥失败")
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch jwk.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, errors.Errorf("不支持的EC曲线, crv=%s", jwk.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil {
+			return nil, errors.WrapIf(err, "解析EC密钥失败")
+		}
+		y, err := base64.RawURLEncoding.DecodeString(jwk.Y)
+		if err != nil {
+			return nil, errors.WrapIf(err, "解析EC密钥失败")
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+	case "OKP":
+		if jwk.Crv != "Ed25519" {
+			return nil, errors.Errorf("不支持的OKP曲线, crv=%s", jwk.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("解析Ed25519密钥失败")
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, errors.Errorf("不支持的密钥类型, kty=%s", jwk.Kty)
+	}
+}
+
+func (p *Provider) do(req *http.Request, v any) error {
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return errors.WrapIf(err, "请求OIDC服务失败")
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, p.maxBodySize))
+	if err != nil {
+		return errors.WrapIf(err, "读取OIDC响应失败")
+	}
+	if resp.StatusCode != http.StatusOK {
+		var oerr struct {
+			Error       string `json:"error"`
+			Description string `json:"error_description"`
+		}
+		_ = json.Unmarshal(body, &oerr)
+		return errors.Errorf("OIDC服务返回错误, status=%d, error=%s, description=%s", resp.StatusCode, oerr.Error, oerr.Description)
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return errors.WrapIf(err, "解析OIDC响应失败")
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package oidc_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"gin-artweb/pkg/oidc"
+	"gin-artweb/pkg/oidc/oidctest"
+)
+
+func newTestProvider(t *testing.T, srv *oidctest.Server, clientID string) *oidc.Provider {
+	t.Helper()
+	p, err := oidc.NewProvider(oidc.Config{
+		Issuer:      srv.URL,
+		ClientID:    clientID,
+		RedirectURL: "http://127.0.0.1/oidc/callback",
+		Scopes:      []string{"profile"},
+	}, time.Second)
+	if err != nil {
+		t.Fatalf("NewProvider() err = %v", err)
+	}
+	return p
+}
+
+func TestNewProvider(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    oidc.Config
+		wantErr bool
+	}{
+		{"有效配置", oidc.Config{Issuer: "https://idp.example.com/realms/a", ClientID: "artweb", RedirectURL: "https://a/cb"}, false},
+		{"不支持的协议", oidc.Config{Issuer: "ftp://idp", ClientID: "artweb", RedirectURL: "https://a/cb"}, true},
+		{"缺少客户端标识", oidc.Config{Issuer: "https://idp", RedirectURL: "https://a/cb"}, true},
+		{"缺少回调地址", oidc.Config{Issuer: "https://idp", ClientID: "artweb"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := oidc.NewProvider(tt.conf, time.Second)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewProvider() err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAuthorizationCodeFlow(t *testing.T) {
+	srv := oidctest.NewServer("artweb")
+	defer srv.Close()
+	srv.SetClaims(map[string]any{"sub": "u-1", "preferred_username": "alice", "groups": []string{"ops", "dev"}})
+	p := newTestProvider(t, srv, "artweb")
+	ctx := context.Background()
+
+	verifier, challenge, err := oidc.NewPKCE()
+	if err != nil {
+		t.Fatalf("NewPKCE() err = %v", err)
+	}
+	authURL, err := p.AuthCodeURL(ctx, "state-1", "nonce-1", challenge)
+	if err != nil {
+		t.Fatalf("AuthCodeURL() err = %v", err)
+	}
+	u, _ := url.Parse(authURL)
+	if got := u.Query().Get("scope"); got != "openid profile" {
+		t.Errorf("scope = %q, want openid profile", got)
+	}
+
+	code, state, err := srv.Authorize(authURL)
+	if err != nil || state != "state-1" {
+		t.Fatalf("Authorize() state = %q, err = %v", state, err)
+	}
+	if _, err := p.Exchange(ctx, code, "wrong-verifier"); err == nil {
+		t.Fatal("PKCE校验码错误时应该交换失败")
+	}
+
+	code, _, _ = srv.Authorize(authURL)
+	token, err := p.Exchange(ctx, code, verifier)
+	if err != nil {
+		t.Fatalf("Exchange() err = %v", err)
+	}
+	if _, err := p.VerifyIDToken(ctx, token.IDToken, "other-nonce"); err == nil {
+		t.Error("nonce不一致时应该校验失败")
+	}
+	claims, err := p.VerifyIDToken(ctx, token.IDToken, "nonce-1")
+	if err != nil {
+		t.Fatalf("VerifyIDToken() err = %v", err)
+	}
+	if claims.String("sub") != "u-1" || claims.String("preferred_username") != "alice" {
+		t.Errorf("claims = %v", claims)
+	}
+	if got := claims.Strings("groups"); len(got) != 2 || got[0] != "ops" {
+		t.Errorf("groups = %v", got)
+	}
+}
+
+func TestVerifyIDTokenAudience(t *testing.T) {
+	srv := oidctest.NewServer("other-client")
+	defer srv.Close()
+	srv.SetClaims(map[string]any{"sub": "u-1"})
+	ctx := context.Background()
+
+	// 令牌签发给other-client, 使用artweb校验时受众不符
+	issuer := newTestProvider(t, srv, "other-client")
+	verifier, challenge, _ := oidc.NewPKCE()
+	authURL, _ := issuer.AuthCodeURL(ctx, "s", "n", challenge)
+	code, _, _ := srv.Authorize(authURL)
+	token, err := issuer.Exchange(ctx, code, verifier)
+	if err != nil {
+		t.Fatalf("Exchange() err = %v", err)
+	}
+
+	p := newTestProvider(t, srv, "artweb")
+	if _, err := p.VerifyIDToken(ctx, token.IDToken, "n"); err == nil {
+		t.Error("受众不符时应该校验失败")
+	}
+}
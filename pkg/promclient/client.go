@@ -0,0 +1,164 @@
+// Package promclient 提供访问Prometheus HTTP API的轻量客户端
+// 仅覆盖平台需要的即时查询、范围查询和采集目标查询
+package promclient
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+)
+
+// 默认的响应体大小限制
+const defaultMaxBodySize int64 = 10 * 1024 * 1024
+
+// Auth 数据源认证信息, 同时设置时优先使用BearerToken
+type Auth struct {
+	Username    string
+	Password    string
+	BearerToken string
+}
+
+// Client Prometheus HTTP API客户端
+type Client struct {
+	baseURL     string
+	auth        Auth
+	httpClient  *http.Client
+	maxBodySize int64
+}
+
+// NewClient 创建Prometheus客户端
+// baseURL: Prometheus服务地址, 如 http://127.0.0.1:9090
+// timeout: 单次请求超时时间, 小于等于0时不设置超时
+func NewClient(baseURL string, auth Auth, timeout time.Duration) (*Client, error) {
+	u, err := url.Parse(strings.TrimSpace(baseURL))
+	if err != nil {
+		return nil, errors.WrapIf(err, "解析Prometheus地址失败")
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, errors.Errorf("Prometheus地址协议必须为http或https, url=%s", baseURL)
+	}
+	if u.Host == "" {
+		return nil, errors.Errorf("Prometheus地址缺少主机, url=%s", baseURL)
+	}
+	return &Client{
+		baseURL:     strings.TrimRight(u.String(), "/"),
+		auth:        auth,
+		httpClient:  &http.Client{Timeout: timeout},
+		maxBodySize: defaultMaxBodySize,
+	}, nil
+}
+
+// Response Prometheus API通用响应
+type Response struct {
+	Status    string          `json:"status"`
+	Data      json.RawMessage `json:"data,omitempty"`
+	ErrorType string          `json:"errorType,omitempty"`
+	Error     string          `json:"error,omitempty"`
+	Warnings  []string        `json:"warnings,omitempty"`
+}
+
+// Query 执行即时查询, ts为空时使用服务端当前时间
+func (c *Client) Query(ctx context.Context, query, ts string) (*Response, error) {
+	params := url.Values{}
+	params.Set("query", query)
+	if ts != "" {
+		params.Set("time", ts)
+	}
+	setTimeout(ctx, params)
+	return c.get(ctx, "/api/v1/query", params)
+}
+
+// QueryRange 执行范围查询
+func (c *Client) QueryRange(ctx context.Context, query, start, end, step string) (*Response, error) {
+	params := url.Values{}
+	params.Set("query", query)
+	params.Set("start", start)
+	params.Set("end", end)
+	params.Set("step", step)
+	setTimeout(ctx, params)
+	return c.get(ctx, "/api/v1/query_range", params)
+}
+
+// setTimeout 将上下文的剩余时间作为查询超时传给Prometheus, 调用方放弃等待后服务端也随之停止计算
+func setTimeout(ctx context.Context, params url.Values) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return
+	}
+	ms := max(time.Until(deadline).Milliseconds(), 1)
+	params.Set("timeout", strconv.FormatInt(ms, 10)+"ms")
+}
+
+// Target 采集目标
+type Target struct {
+	ScrapeURL  string    `json:"scrapeUrl"`
+	Health     string    `json:"health"`
+	LastError  string    `json:"lastError"`
+	LastScrape time.Time `json:"lastScrape"`
+}
+
+// Targets 查询活跃的采集目标
+func (c *Client) Targets(ctx context.Context) ([]Target, error) {
+	params := url.Values{}
+	params.Set("state", "active")
+	resp, err := c.get(ctx, "/api/v1/targets", params)
+	if err != nil {
+		return nil, err
+	}
+	var data struct {
+		ActiveTargets []Target `json:"activeTargets"`
+	}
+	if err := json.Unmarshal(resp.Data, &data); err != nil {
+		return nil, errors.WrapIf(err, "解析Prometheus采集目标失败")
+	}
+	return data.ActiveTargets, nil
+}
+
+func (c *Client) get(ctx context.Context, path string, params url.Values) (*Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, errors.WrapIf(err, "创建Prometheus请求失败")
+	}
+	switch {
+	case c.auth.BearerToken != "":
+		req.Header.Set("Authorization", "Bearer "+c.auth.BearerToken)
+	case c.auth.Username != "":
+		req.SetBasicAuth(c.auth.Username, c.auth.Password)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.WrapIf(err, "请求Prometheus失败")
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, c.maxBodySize+1))
+	if err != nil {
+		return nil, errors.WrapIf(err, "读取Prometheus响应失败")
+	}
+	if int64(len(body)) > c.maxBodySize {
+		return nil, errors.Errorf("Prometheus响应超出大小限制, limit=%d", c.maxBodySize)
+	}
+
+	var r Response
+	if err := json.Unmarshal(body, &r); err != nil {
+		return nil, errors.WrapIfWithDetails(err, "解析Prometheus响应失败", "status_code", resp.StatusCode)
+	}
+	if r.Status != "success" {
+		return &r, errors.NewWithDetails(
+			"Prometheus查询失败",
+			"status_code", resp.StatusCode,
+			"error_type", r.ErrorType,
+			"error", r.Error,
+		)
+	}
+	return &r, nil
+}
@@ -0,0 +1,150 @@
+package promclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if user, pass, ok := r.BasicAuth(); ok && (user != "admin" || pass != "secret") {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"status":"error","errorType":"unauthorized","error":"bad auth"}`))
+			return
+		}
+		switch r.URL.Path {
+		case "/api/v1/query":
+			if r.URL.Query().Get("query") == "" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"status":"error","errorType":"bad_data","error":"empty query"}`))
+				return
+			}
+			w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[]}}`))
+		case "/api/v1/targets":
+			w.Write([]byte(`{"status":"success","data":{"activeTargets":[` +
+				`{"scrapeUrl":"http://a:9100/metrics","health":"up","lastScrape":"2024-01-01T00:00:00Z"},` +
+				`{"scrapeUrl":"http://b:9100/metrics","health":"down","lastError":"timeout","lastScrape":"2024-01-01T00:00:05Z"}]}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`not found`))
+		}
+	}))
+}
+
+func TestNewClient(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		wantErr bool
+	}{
+		{"http地址", "http://127.0.0.1:9090", false},
+		{"https地址带路径", "https://prom.example.com/prometheus/", false},
+		{"不支持的协议", "ftp://127.0.0.1", true},
+		{"缺少主机", "http://", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewClient(tt.url, Auth{}, time.Second)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewClient(%q) err = %v, wantErr %v", tt.url, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestQuery(t *testing.T) {
+	srv := newTestServer(t)
+	defer srv.Close()
+
+	c, err := NewClient(srv.URL, Auth{Username: "admin", Password: "secret"}, time.Second)
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	resp, err := c.Query(context.Background(), "up", "")
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if resp.Status != "success" {
+		t.Errorf("expected status success, got %s", resp.Status)
+	}
+
+	// 查询失败时应该返回错误和原始响应
+	resp, err = c.Query(context.Background(), "", "")
+	if err == nil {
+		t.Fatal("expected error for empty query")
+	}
+	if resp == nil || resp.ErrorType != "bad_data" {
+		t.Errorf("expected bad_data response, got %+v", resp)
+	}
+
+	// 认证失败
+	bad, _ := NewClient(srv.URL, Auth{Username: "admin", Password: "wrong"}, time.Second)
+	if _, err := bad.Query(context.Background(), "up", ""); err == nil {
+		t.Error("expected error for bad auth")
+	}
+}
+
+func TestTargets(t *testing.T) {
+	srv := newTestServer(t)
+	defer srv.Close()
+
+	c, _ := NewClient(srv.URL, Auth{}, time.Second)
+	targets, err := c.Targets(context.Background())
+	if err != nil {
+		t.Fatalf("Targets failed: %v", err)
+	}
+	if len(targets) != 2 {
+		t.Fatalf("expected 2 targets, got %d", len(targets))
+	}
+	if targets[0].Health != "up" || targets[1].LastError != "timeout" {
+		t.Errorf("unexpected targets: %+v", targets)
+	}
+	if !targets[1].LastScrape.After(targets[0].LastScrape) {
+		t.Error("expected lastScrape to be parsed")
+	}
+}
+
+func TestNonJSONResponse(t *testing.T) {
+	srv := newTestServer(t)
+	defer srv.Close()
+
+	c, _ := NewClient(srv.URL+"/missing", Auth{}, time.Second)
+	if _, err := c.Query(context.Background(), "up", ""); err == nil {
+		t.Error("expected error for non json response")
+	}
+}
+
+func TestQueryTimeout(t *testing.T) {
+	var timeouts []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		timeouts = append(timeouts, r.URL.Query().Get("timeout"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[]}}`))
+	}))
+	defer srv.Close()
+
+	c, _ := NewClient(srv.URL, Auth{}, time.Second)
+	if _, err := c.Query(context.Background(), "up", ""); err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+
+	// 上下文有截止时间时将剩余时间作为查询超时
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := c.QueryRange(ctx, "up", "1", "2", "1"); err != nil {
+		t.Fatalf("QueryRange failed: %v", err)
+	}
+
+	if len(timeouts) != 2 || timeouts[0] != "" {
+		t.Fatalf("unexpected timeouts: %v", timeouts)
+	}
+	d, err := time.ParseDuration(timeouts[1])
+	if err != nil || d <= 0 || d > 5*time.Second {
+		t.Errorf("expected timeout within 5s, got %q", timeouts[1])
+	}
+}
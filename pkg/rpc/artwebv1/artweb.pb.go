@@ -0,0 +1,1735 @@
+// artweb的gRPC接口定义
+//
+// 修改后在项目根目录执行 go generate ./pkg/rpc/... 重新生成代码, 需要安装protoc、protoc-gen-go和protoc-gen-go-grpc
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: artweb.proto
+
+package artwebv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// TaskInfo 集群日常任务的最近一次执行状态
+type TaskInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskName      string                 `protobuf:"bytes,1,opt,name=task_name,json=taskName,proto3" json:"task_name,omitempty"`          // 任务名称
+	RecordId      uint32                 `protobuf:"varint,2,opt,name=record_id,json=recordId,proto3" json:"record_id,omitempty"`         // 执行记录ID, 0表示未执行
+	Status        int32                  `protobuf:"varint,3,opt,name=status,proto3" json:"status,omitempty"`                             // 执行状态(0-待执行,1-执行中,2-成功,3-失败,4-超时,5-崩溃,6-中断)
+	StartTime     string                 `protobuf:"bytes,4,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`       // 开始时间
+	EndTime       string                 `protobuf:"bytes,5,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`             // 结束时间
+	TriggerType   string                 `protobuf:"bytes,6,opt,name=trigger_type,json=triggerType,proto3" json:"trigger_type,omitempty"` // 触发类型
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskInfo) Reset() {
+	*x = TaskInfo{}
+	mi := &file_artweb_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaskInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskInfo) ProtoMessage() {}
+
+func (x *TaskInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_artweb_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskInfo.ProtoReflect.Descriptor instead.
+func (*TaskInfo) Descriptor() ([]byte, []int) {
+	return file_artweb_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TaskInfo) GetTaskName() string {
+	if x != nil {
+		return x.TaskName
+	}
+	return ""
+}
+
+func (x *TaskInfo) GetRecordId() uint32 {
+	if x != nil {
+		return x.RecordId
+	}
+	return 0
+}
+
+func (x *TaskInfo) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *TaskInfo) GetStartTime() string {
+	if x != nil {
+		return x.StartTime
+	}
+	return ""
+}
+
+func (x *TaskInfo) GetEndTime() string {
+	if x != nil {
+		return x.EndTime
+	}
+	return ""
+}
+
+func (x *TaskInfo) GetTriggerType() string {
+	if x != nil {
+		return x.TriggerType
+	}
+	return ""
+}
+
+// ColonyTasks 集群的日常任务状态
+type ColonyTasks struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ColonyNum     string                 `protobuf:"bytes,1,opt,name=colony_num,json=colonyNum,proto3" json:"colony_num,omitempty"` // 集群号
+	Tasks         []*TaskInfo            `protobuf:"bytes,2,rep,name=tasks,proto3" json:"tasks,omitempty"`                          // 任务状态
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ColonyTasks) Reset() {
+	*x = ColonyTasks{}
+	mi := &file_artweb_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ColonyTasks) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ColonyTasks) ProtoMessage() {}
+
+func (x *ColonyTasks) ProtoReflect() protoreflect.Message {
+	mi := &file_artweb_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ColonyTasks.ProtoReflect.Descriptor instead.
+func (*ColonyTasks) Descriptor() ([]byte, []int) {
+	return file_artweb_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ColonyTasks) GetColonyNum() string {
+	if x != nil {
+		return x.ColonyNum
+	}
+	return ""
+}
+
+func (x *ColonyTasks) GetTasks() []*TaskInfo {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+// ListColonyTasksResponse 集群列表的日常任务状态
+type ListColonyTasksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Colonies      []*ColonyTasks         `protobuf:"bytes,1,rep,name=colonies,proto3" json:"colonies,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListColonyTasksResponse) Reset() {
+	*x = ListColonyTasksResponse{}
+	mi := &file_artweb_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListColonyTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListColonyTasksResponse) ProtoMessage() {}
+
+func (x *ListColonyTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_artweb_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListColonyTasksResponse.ProtoReflect.Descriptor instead.
+func (*ListColonyTasksResponse) Descriptor() ([]byte, []int) {
+	return file_artweb_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListColonyTasksResponse) GetColonies() []*ColonyTasks {
+	if x != nil {
+		return x.Colonies
+	}
+	return nil
+}
+
+// OesColony oes集群
+type OesColony struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	SystemType    string                 `protobuf:"bytes,2,opt,name=system_type,json=systemType,proto3" json:"system_type,omitempty"`          // 系统类型(STK/CRD/OPT)
+	ColonyNum     string                 `protobuf:"bytes,3,opt,name=colony_num,json=colonyNum,proto3" json:"colony_num,omitempty"`             // 集群号
+	ExtractedName string                 `protobuf:"bytes,4,opt,name=extracted_name,json=extractedName,proto3" json:"extracted_name,omitempty"` // 解压后名称
+	IsEnable      bool                   `protobuf:"varint,5,opt,name=is_enable,json=isEnable,proto3" json:"is_enable,omitempty"`               // 是否启用
+	PackageId     uint32                 `protobuf:"varint,6,opt,name=package_id,json=packageId,proto3" json:"package_id,omitempty"`            // oes程序包ID
+	XcounterId    uint32                 `protobuf:"varint,7,opt,name=xcounter_id,json=xcounterId,proto3" json:"xcounter_id,omitempty"`         // xcounter程序包ID
+	MonNodeId     uint32                 `protobuf:"varint,8,opt,name=mon_node_id,json=monNodeId,proto3" json:"mon_node_id,omitempty"`          // mon节点ID
+	CreatedAt     string                 `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     string                 `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OesColony) Reset() {
+	*x = OesColony{}
+	mi := &file_artweb_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OesColony) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OesColony) ProtoMessage() {}
+
+func (x *OesColony) ProtoReflect() protoreflect.Message {
+	mi := &file_artweb_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OesColony.ProtoReflect.Descriptor instead.
+func (*OesColony) Descriptor() ([]byte, []int) {
+	return file_artweb_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *OesColony) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *OesColony) GetSystemType() string {
+	if x != nil {
+		return x.SystemType
+	}
+	return ""
+}
+
+func (x *OesColony) GetColonyNum() string {
+	if x != nil {
+		return x.ColonyNum
+	}
+	return ""
+}
+
+func (x *OesColony) GetExtractedName() string {
+	if x != nil {
+		return x.ExtractedName
+	}
+	return ""
+}
+
+func (x *OesColony) GetIsEnable() bool {
+	if x != nil {
+		return x.IsEnable
+	}
+	return false
+}
+
+func (x *OesColony) GetPackageId() uint32 {
+	if x != nil {
+		return x.PackageId
+	}
+	return 0
+}
+
+func (x *OesColony) GetXcounterId() uint32 {
+	if x != nil {
+		return x.XcounterId
+	}
+	return 0
+}
+
+func (x *OesColony) GetMonNodeId() uint32 {
+	if x != nil {
+		return x.MonNodeId
+	}
+	return 0
+}
+
+func (x *OesColony) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *OesColony) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+type ListOesColoniesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`                               // 页码, 为0时为1
+	Size          int32                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`                               // 每页数量, 为0时为20, 最大100
+	SystemType    string                 `protobuf:"bytes,3,opt,name=system_type,json=systemType,proto3" json:"system_type,omitempty"`  // 系统类型, 为空时不过滤
+	IsEnable      *bool                  `protobuf:"varint,4,opt,name=is_enable,json=isEnable,proto3,oneof" json:"is_enable,omitempty"` // 是否启用, 不传时不过滤
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOesColoniesRequest) Reset() {
+	*x = ListOesColoniesRequest{}
+	mi := &file_artweb_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOesColoniesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOesColoniesRequest) ProtoMessage() {}
+
+func (x *ListOesColoniesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_artweb_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOesColoniesRequest.ProtoReflect.Descriptor instead.
+func (*ListOesColoniesRequest) Descriptor() ([]byte, []int) {
+	return file_artweb_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListOesColoniesRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListOesColoniesRequest) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *ListOesColoniesRequest) GetSystemType() string {
+	if x != nil {
+		return x.SystemType
+	}
+	return ""
+}
+
+func (x *ListOesColoniesRequest) GetIsEnable() bool {
+	if x != nil && x.IsEnable != nil {
+		return *x.IsEnable
+	}
+	return false
+}
+
+type ListOesColoniesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Total         int64                  `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Colonies      []*OesColony           `protobuf:"bytes,2,rep,name=colonies,proto3" json:"colonies,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOesColoniesResponse) Reset() {
+	*x = ListOesColoniesResponse{}
+	mi := &file_artweb_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOesColoniesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOesColoniesResponse) ProtoMessage() {}
+
+func (x *ListOesColoniesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_artweb_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOesColoniesResponse.ProtoReflect.Descriptor instead.
+func (*ListOesColoniesResponse) Descriptor() ([]byte, []int) {
+	return file_artweb_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListOesColoniesResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListOesColoniesResponse) GetColonies() []*OesColony {
+	if x != nil {
+		return x.Colonies
+	}
+	return nil
+}
+
+type ListOesColonyTasksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SystemType    string                 `protobuf:"bytes,1,opt,name=system_type,json=systemType,proto3" json:"system_type,omitempty"` // 系统类型(STK/CRD/OPT), 按对应的REST任务状态接口鉴权
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	Size          int32                  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOesColonyTasksRequest) Reset() {
+	*x = ListOesColonyTasksRequest{}
+	mi := &file_artweb_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOesColonyTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOesColonyTasksRequest) ProtoMessage() {}
+
+func (x *ListOesColonyTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_artweb_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOesColonyTasksRequest.ProtoReflect.Descriptor instead.
+func (*ListOesColonyTasksRequest) Descriptor() ([]byte, []int) {
+	return file_artweb_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListOesColonyTasksRequest) GetSystemType() string {
+	if x != nil {
+		return x.SystemType
+	}
+	return ""
+}
+
+func (x *ListOesColonyTasksRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListOesColonyTasksRequest) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+// MdsColony mds集群
+type MdsColony struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ColonyNum     string                 `protobuf:"bytes,2,opt,name=colony_num,json=colonyNum,proto3" json:"colony_num,omitempty"`
+	ExtractedName string                 `protobuf:"bytes,3,opt,name=extracted_name,json=extractedName,proto3" json:"extracted_name,omitempty"`
+	IsEnable      bool                   `protobuf:"varint,4,opt,name=is_enable,json=isEnable,proto3" json:"is_enable,omitempty"`
+	PackageId     uint32                 `protobuf:"varint,5,opt,name=package_id,json=packageId,proto3" json:"package_id,omitempty"`
+	MonNodeId     uint32                 `protobuf:"varint,6,opt,name=mon_node_id,json=monNodeId,proto3" json:"mon_node_id,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     string                 `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MdsColony) Reset() {
+	*x = MdsColony{}
+	mi := &file_artweb_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MdsColony) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MdsColony) ProtoMessage() {}
+
+func (x *MdsColony) ProtoReflect() protoreflect.Message {
+	mi := &file_artweb_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MdsColony.ProtoReflect.Descriptor instead.
+func (*MdsColony) Descriptor() ([]byte, []int) {
+	return file_artweb_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *MdsColony) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *MdsColony) GetColonyNum() string {
+	if x != nil {
+		return x.ColonyNum
+	}
+	return ""
+}
+
+func (x *MdsColony) GetExtractedName() string {
+	if x != nil {
+		return x.ExtractedName
+	}
+	return ""
+}
+
+func (x *MdsColony) GetIsEnable() bool {
+	if x != nil {
+		return x.IsEnable
+	}
+	return false
+}
+
+func (x *MdsColony) GetPackageId() uint32 {
+	if x != nil {
+		return x.PackageId
+	}
+	return 0
+}
+
+func (x *MdsColony) GetMonNodeId() uint32 {
+	if x != nil {
+		return x.MonNodeId
+	}
+	return 0
+}
+
+func (x *MdsColony) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *MdsColony) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+type ListMdsColoniesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	Size          int32                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	IsEnable      *bool                  `protobuf:"varint,3,opt,name=is_enable,json=isEnable,proto3,oneof" json:"is_enable,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMdsColoniesRequest) Reset() {
+	*x = ListMdsColoniesRequest{}
+	mi := &file_artweb_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMdsColoniesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMdsColoniesRequest) ProtoMessage() {}
+
+func (x *ListMdsColoniesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_artweb_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMdsColoniesRequest.ProtoReflect.Descriptor instead.
+func (*ListMdsColoniesRequest) Descriptor() ([]byte, []int) {
+	return file_artweb_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListMdsColoniesRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListMdsColoniesRequest) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *ListMdsColoniesRequest) GetIsEnable() bool {
+	if x != nil && x.IsEnable != nil {
+		return *x.IsEnable
+	}
+	return false
+}
+
+type ListMdsColoniesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Total         int64                  `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Colonies      []*MdsColony           `protobuf:"bytes,2,rep,name=colonies,proto3" json:"colonies,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMdsColoniesResponse) Reset() {
+	*x = ListMdsColoniesResponse{}
+	mi := &file_artweb_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMdsColoniesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMdsColoniesResponse) ProtoMessage() {}
+
+func (x *ListMdsColoniesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_artweb_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMdsColoniesResponse.ProtoReflect.Descriptor instead.
+func (*ListMdsColoniesResponse) Descriptor() ([]byte, []int) {
+	return file_artweb_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListMdsColoniesResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListMdsColoniesResponse) GetColonies() []*MdsColony {
+	if x != nil {
+		return x.Colonies
+	}
+	return nil
+}
+
+type ListMdsColonyTasksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	Size          int32                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMdsColonyTasksRequest) Reset() {
+	*x = ListMdsColonyTasksRequest{}
+	mi := &file_artweb_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMdsColonyTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMdsColonyTasksRequest) ProtoMessage() {}
+
+func (x *ListMdsColonyTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_artweb_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMdsColonyTasksRequest.ProtoReflect.Descriptor instead.
+func (*ListMdsColonyTasksRequest) Descriptor() ([]byte, []int) {
+	return file_artweb_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListMdsColonyTasksRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListMdsColonyTasksRequest) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+// Host 主机
+type Host struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Label         string                 `protobuf:"bytes,3,opt,name=label,proto3" json:"label,omitempty"`
+	SshIp         string                 `protobuf:"bytes,4,opt,name=ssh_ip,json=sshIp,proto3" json:"ssh_ip,omitempty"`
+	SshPort       uint32                 `protobuf:"varint,5,opt,name=ssh_port,json=sshPort,proto3" json:"ssh_port,omitempty"`
+	SshUser       string                 `protobuf:"bytes,6,opt,name=ssh_user,json=sshUser,proto3" json:"ssh_user,omitempty"` // 没有查看敏感字段权限时掩码
+	PyPath        string                 `protobuf:"bytes,7,opt,name=py_path,json=pyPath,proto3" json:"py_path,omitempty"`
+	Remark        string                 `protobuf:"bytes,8,opt,name=remark,proto3" json:"remark,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     string                 `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Host) Reset() {
+	*x = Host{}
+	mi := &file_artweb_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Host) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Host) ProtoMessage() {}
+
+func (x *Host) ProtoReflect() protoreflect.Message {
+	mi := &file_artweb_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Host.ProtoReflect.Descriptor instead.
+func (*Host) Descriptor() ([]byte, []int) {
+	return file_artweb_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Host) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Host) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Host) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *Host) GetSshIp() string {
+	if x != nil {
+		return x.SshIp
+	}
+	return ""
+}
+
+func (x *Host) GetSshPort() uint32 {
+	if x != nil {
+		return x.SshPort
+	}
+	return 0
+}
+
+func (x *Host) GetSshUser() string {
+	if x != nil {
+		return x.SshUser
+	}
+	return ""
+}
+
+func (x *Host) GetPyPath() string {
+	if x != nil {
+		return x.PyPath
+	}
+	return ""
+}
+
+func (x *Host) GetRemark() string {
+	if x != nil {
+		return x.Remark
+	}
+	return ""
+}
+
+func (x *Host) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Host) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+type ListHostsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	Size          int32                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	Label         string                 `protobuf:"bytes,3,opt,name=label,proto3" json:"label,omitempty"` // 标签, 为空时不过滤
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListHostsRequest) Reset() {
+	*x = ListHostsRequest{}
+	mi := &file_artweb_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListHostsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListHostsRequest) ProtoMessage() {}
+
+func (x *ListHostsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_artweb_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListHostsRequest.ProtoReflect.Descriptor instead.
+func (*ListHostsRequest) Descriptor() ([]byte, []int) {
+	return file_artweb_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListHostsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListHostsRequest) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *ListHostsRequest) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+type ListHostsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Total         int64                  `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Hosts         []*Host                `protobuf:"bytes,2,rep,name=hosts,proto3" json:"hosts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListHostsResponse) Reset() {
+	*x = ListHostsResponse{}
+	mi := &file_artweb_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListHostsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListHostsResponse) ProtoMessage() {}
+
+func (x *ListHostsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_artweb_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListHostsResponse.ProtoReflect.Descriptor instead.
+func (*ListHostsResponse) Descriptor() ([]byte, []int) {
+	return file_artweb_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListHostsResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListHostsResponse) GetHosts() []*Host {
+	if x != nil {
+		return x.Hosts
+	}
+	return nil
+}
+
+// Package 程序包
+type Package struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Filename      string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
+	Label         string                 `protobuf:"bytes,3,opt,name=label,proto3" json:"label,omitempty"`
+	Version       string                 `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
+	Size          int64                  `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`        // 文件大小(字节)
+	Checksum      string                 `protobuf:"bytes,6,opt,name=checksum,proto3" json:"checksum,omitempty"` // 文件SHA256校验和
+	UploadedAt    string                 `protobuf:"bytes,7,opt,name=uploaded_at,json=uploadedAt,proto3" json:"uploaded_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Package) Reset() {
+	*x = Package{}
+	mi := &file_artweb_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Package) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Package) ProtoMessage() {}
+
+func (x *Package) ProtoReflect() protoreflect.Message {
+	mi := &file_artweb_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Package.ProtoReflect.Descriptor instead.
+func (*Package) Descriptor() ([]byte, []int) {
+	return file_artweb_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Package) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Package) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *Package) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *Package) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *Package) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *Package) GetChecksum() string {
+	if x != nil {
+		return x.Checksum
+	}
+	return ""
+}
+
+func (x *Package) GetUploadedAt() string {
+	if x != nil {
+		return x.UploadedAt
+	}
+	return ""
+}
+
+type ListPackagesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	Size          int32                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	Label         string                 `protobuf:"bytes,3,opt,name=label,proto3" json:"label,omitempty"` // 标签, 为空时不过滤
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPackagesRequest) Reset() {
+	*x = ListPackagesRequest{}
+	mi := &file_artweb_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPackagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPackagesRequest) ProtoMessage() {}
+
+func (x *ListPackagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_artweb_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPackagesRequest.ProtoReflect.Descriptor instead.
+func (*ListPackagesRequest) Descriptor() ([]byte, []int) {
+	return file_artweb_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListPackagesRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListPackagesRequest) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *ListPackagesRequest) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+type ListPackagesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Total         int64                  `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Packages      []*Package             `protobuf:"bytes,2,rep,name=packages,proto3" json:"packages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPackagesResponse) Reset() {
+	*x = ListPackagesResponse{}
+	mi := &file_artweb_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPackagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPackagesResponse) ProtoMessage() {}
+
+func (x *ListPackagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_artweb_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPackagesResponse.ProtoReflect.Descriptor instead.
+func (*ListPackagesResponse) Descriptor() ([]byte, []int) {
+	return file_artweb_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListPackagesResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListPackagesResponse) GetPackages() []*Package {
+	if x != nil {
+		return x.Packages
+	}
+	return nil
+}
+
+// ScriptRecord 脚本执行记录
+type ScriptRecord struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ScriptId      uint32                 `protobuf:"varint,2,opt,name=script_id,json=scriptId,proto3" json:"script_id,omitempty"`
+	TriggerType   string                 `protobuf:"bytes,3,opt,name=trigger_type,json=triggerType,proto3" json:"trigger_type,omitempty"`
+	Status        int32                  `protobuf:"varint,4,opt,name=status,proto3" json:"status,omitempty"` // 执行状态(0-待执行,1-执行中,2-成功,3-失败,4-超时,5-崩溃,6-中断)
+	ExitCode      int32                  `protobuf:"varint,5,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	EnvVars       string                 `protobuf:"bytes,6,opt,name=env_vars,json=envVars,proto3" json:"env_vars,omitempty"` // 环境变量(JSON对象), 值始终掩码
+	CommandArgs   string                 `protobuf:"bytes,7,opt,name=command_args,json=commandArgs,proto3" json:"command_args,omitempty"`
+	WorkDir       string                 `protobuf:"bytes,8,opt,name=work_dir,json=workDir,proto3" json:"work_dir,omitempty"`
+	Timeout       int32                  `protobuf:"varint,9,opt,name=timeout,proto3" json:"timeout,omitempty"` // 超时时间(秒)
+	ErrorMessage  string                 `protobuf:"bytes,10,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	FailureReason string                 `protobuf:"bytes,11,opt,name=failure_reason,json=failureReason,proto3" json:"failure_reason,omitempty"`
+	Username      string                 `protobuf:"bytes,12,opt,name=username,proto3" json:"username,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,13,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     string                 `protobuf:"bytes,14,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScriptRecord) Reset() {
+	*x = ScriptRecord{}
+	mi := &file_artweb_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScriptRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScriptRecord) ProtoMessage() {}
+
+func (x *ScriptRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_artweb_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScriptRecord.ProtoReflect.Descriptor instead.
+func (*ScriptRecord) Descriptor() ([]byte, []int) {
+	return file_artweb_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ScriptRecord) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ScriptRecord) GetScriptId() uint32 {
+	if x != nil {
+		return x.ScriptId
+	}
+	return 0
+}
+
+func (x *ScriptRecord) GetTriggerType() string {
+	if x != nil {
+		return x.TriggerType
+	}
+	return ""
+}
+
+func (x *ScriptRecord) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *ScriptRecord) GetExitCode() int32 {
+	if x != nil {
+		return x.ExitCode
+	}
+	return 0
+}
+
+func (x *ScriptRecord) GetEnvVars() string {
+	if x != nil {
+		return x.EnvVars
+	}
+	return ""
+}
+
+func (x *ScriptRecord) GetCommandArgs() string {
+	if x != nil {
+		return x.CommandArgs
+	}
+	return ""
+}
+
+func (x *ScriptRecord) GetWorkDir() string {
+	if x != nil {
+		return x.WorkDir
+	}
+	return ""
+}
+
+func (x *ScriptRecord) GetTimeout() int32 {
+	if x != nil {
+		return x.Timeout
+	}
+	return 0
+}
+
+func (x *ScriptRecord) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *ScriptRecord) GetFailureReason() string {
+	if x != nil {
+		return x.FailureReason
+	}
+	return ""
+}
+
+func (x *ScriptRecord) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *ScriptRecord) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *ScriptRecord) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+type TriggerScriptRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ScriptId      uint32                 `protobuf:"varint,1,opt,name=script_id,json=scriptId,proto3" json:"script_id,omitempty"`
+	CommandArgs   string                 `protobuf:"bytes,2,opt,name=command_args,json=commandArgs,proto3" json:"command_args,omitempty"`                                              // 命令行参数(JSON数组)
+	EnvVars       string                 `protobuf:"bytes,3,opt,name=env_vars,json=envVars,proto3" json:"env_vars,omitempty"`                                                          // 环境变量(JSON对象)
+	Params        map[string]string      `protobuf:"bytes,4,rep,name=params,proto3" json:"params,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // 脚本参数, 按脚本的参数定义校验, 未提交的参数使用默认值
+	Timeout       int32                  `protobuf:"varint,5,opt,name=timeout,proto3" json:"timeout,omitempty"`                                                                        // 超时时间(秒)
+	WorkDir       string                 `protobuf:"bytes,6,opt,name=work_dir,json=workDir,proto3" json:"work_dir,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TriggerScriptRequest) Reset() {
+	*x = TriggerScriptRequest{}
+	mi := &file_artweb_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TriggerScriptRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TriggerScriptRequest) ProtoMessage() {}
+
+func (x *TriggerScriptRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_artweb_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TriggerScriptRequest.ProtoReflect.Descriptor instead.
+func (*TriggerScriptRequest) Descriptor() ([]byte, []int) {
+	return file_artweb_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *TriggerScriptRequest) GetScriptId() uint32 {
+	if x != nil {
+		return x.ScriptId
+	}
+	return 0
+}
+
+func (x *TriggerScriptRequest) GetCommandArgs() string {
+	if x != nil {
+		return x.CommandArgs
+	}
+	return ""
+}
+
+func (x *TriggerScriptRequest) GetEnvVars() string {
+	if x != nil {
+		return x.EnvVars
+	}
+	return ""
+}
+
+func (x *TriggerScriptRequest) GetParams() map[string]string {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+func (x *TriggerScriptRequest) GetTimeout() int32 {
+	if x != nil {
+		return x.Timeout
+	}
+	return 0
+}
+
+func (x *TriggerScriptRequest) GetWorkDir() string {
+	if x != nil {
+		return x.WorkDir
+	}
+	return ""
+}
+
+type GetScriptRecordRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetScriptRecordRequest) Reset() {
+	*x = GetScriptRecordRequest{}
+	mi := &file_artweb_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetScriptRecordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetScriptRecordRequest) ProtoMessage() {}
+
+func (x *GetScriptRecordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_artweb_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetScriptRecordRequest.ProtoReflect.Descriptor instead.
+func (*GetScriptRecordRequest) Descriptor() ([]byte, []int) {
+	return file_artweb_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetScriptRecordRequest) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+var File_artweb_proto protoreflect.FileDescriptor
+
+const file_artweb_proto_rawDesc = "" +
+	"\n" +
+	"\fartweb.proto\x12\tartweb.v1\"\xb9\x01\n" +
+	"\bTaskInfo\x12\x1b\n" +
+	"\ttask_name\x18\x01 \x01(\tR\btaskName\x12\x1b\n" +
+	"\trecord_id\x18\x02 \x01(\rR\brecordId\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\x05R\x06status\x12\x1d\n" +
+	"\n" +
+	"start_time\x18\x04 \x01(\tR\tstartTime\x12\x19\n" +
+	"\bend_time\x18\x05 \x01(\tR\aendTime\x12!\n" +
+	"\ftrigger_type\x18\x06 \x01(\tR\vtriggerType\"W\n" +
+	"\vColonyTasks\x12\x1d\n" +
+	"\n" +
+	"colony_num\x18\x01 \x01(\tR\tcolonyNum\x12)\n" +
+	"\x05tasks\x18\x02 \x03(\v2\x13.artweb.v1.TaskInfoR\x05tasks\"M\n" +
+	"\x17ListColonyTasksResponse\x122\n" +
+	"\bcolonies\x18\x01 \x03(\v2\x16.artweb.v1.ColonyTasksR\bcolonies\"\xbd\x02\n" +
+	"\tOesColony\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x1f\n" +
+	"\vsystem_type\x18\x02 \x01(\tR\n" +
+	"systemType\x12\x1d\n" +
+	"\n" +
+	"colony_num\x18\x03 \x01(\tR\tcolonyNum\x12%\n" +
+	"\x0eextracted_name\x18\x04 \x01(\tR\rextractedName\x12\x1b\n" +
+	"\tis_enable\x18\x05 \x01(\bR\bisEnable\x12\x1d\n" +
+	"\n" +
+	"package_id\x18\x06 \x01(\rR\tpackageId\x12\x1f\n" +
+	"\vxcounter_id\x18\a \x01(\rR\n" +
+	"xcounterId\x12\x1e\n" +
+	"\vmon_node_id\x18\b \x01(\rR\tmonNodeId\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\t \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\n" +
+	" \x01(\tR\tupdatedAt\"\x91\x01\n" +
+	"\x16ListOesColoniesRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x05R\x04size\x12\x1f\n" +
+	"\vsystem_type\x18\x03 \x01(\tR\n" +
+	"systemType\x12 \n" +
+	"\tis_enable\x18\x04 \x01(\bH\x00R\bisEnable\x88\x01\x01B\f\n" +
+	"\n" +
+	"_is_enable\"a\n" +
+	"\x17ListOesColoniesResponse\x12\x14\n" +
+	"\x05total\x18\x01 \x01(\x03R\x05total\x120\n" +
+	"\bcolonies\x18\x02 \x03(\v2\x14.artweb.v1.OesColonyR\bcolonies\"d\n" +
+	"\x19ListOesColonyTasksRequest\x12\x1f\n" +
+	"\vsystem_type\x18\x01 \x01(\tR\n" +
+	"systemType\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\x05R\x04size\"\xfb\x01\n" +
+	"\tMdsColony\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x1d\n" +
+	"\n" +
+	"colony_num\x18\x02 \x01(\tR\tcolonyNum\x12%\n" +
+	"\x0eextracted_name\x18\x03 \x01(\tR\rextractedName\x12\x1b\n" +
+	"\tis_enable\x18\x04 \x01(\bR\bisEnable\x12\x1d\n" +
+	"\n" +
+	"package_id\x18\x05 \x01(\rR\tpackageId\x12\x1e\n" +
+	"\vmon_node_id\x18\x06 \x01(\rR\tmonNodeId\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\a \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\tR\tupdatedAt\"p\n" +
+	"\x16ListMdsColoniesRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x05R\x04size\x12 \n" +
+	"\tis_enable\x18\x03 \x01(\bH\x00R\bisEnable\x88\x01\x01B\f\n" +
+	"\n" +
+	"_is_enable\"a\n" +
+	"\x17ListMdsColoniesResponse\x12\x14\n" +
+	"\x05total\x18\x01 \x01(\x03R\x05total\x120\n" +
+	"\bcolonies\x18\x02 \x03(\v2\x14.artweb.v1.MdsColonyR\bcolonies\"C\n" +
+	"\x19ListMdsColonyTasksRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x05R\x04size\"\xfc\x01\n" +
+	"\x04Host\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05label\x18\x03 \x01(\tR\x05label\x12\x15\n" +
+	"\x06ssh_ip\x18\x04 \x01(\tR\x05sshIp\x12\x19\n" +
+	"\bssh_port\x18\x05 \x01(\rR\asshPort\x12\x19\n" +
+	"\bssh_user\x18\x06 \x01(\tR\asshUser\x12\x17\n" +
+	"\apy_path\x18\a \x01(\tR\x06pyPath\x12\x16\n" +
+	"\x06remark\x18\b \x01(\tR\x06remark\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\t \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\n" +
+	" \x01(\tR\tupdatedAt\"P\n" +
+	"\x10ListHostsRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x05R\x04size\x12\x14\n" +
+	"\x05label\x18\x03 \x01(\tR\x05label\"P\n" +
+	"\x11ListHostsResponse\x12\x14\n" +
+	"\x05total\x18\x01 \x01(\x03R\x05total\x12%\n" +
+	"\x05hosts\x18\x02 \x03(\v2\x0f.artweb.v1.HostR\x05hosts\"\xb6\x01\n" +
+	"\aPackage\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x1a\n" +
+	"\bfilename\x18\x02 \x01(\tR\bfilename\x12\x14\n" +
+	"\x05label\x18\x03 \x01(\tR\x05label\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\tR\aversion\x12\x12\n" +
+	"\x04size\x18\x05 \x01(\x03R\x04size\x12\x1a\n" +
+	"\bchecksum\x18\x06 \x01(\tR\bchecksum\x12\x1f\n" +
+	"\vuploaded_at\x18\a \x01(\tR\n" +
+	"uploadedAt\"S\n" +
+	"\x13ListPackagesRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x05R\x04size\x12\x14\n" +
+	"\x05label\x18\x03 \x01(\tR\x05label\"\\\n" +
+	"\x14ListPackagesResponse\x12\x14\n" +
+	"\x05total\x18\x01 \x01(\x03R\x05total\x12.\n" +
+	"\bpackages\x18\x02 \x03(\v2\x12.artweb.v1.PackageR\bpackages\"\xac\x03\n" +
+	"\fScriptRecord\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x1b\n" +
+	"\tscript_id\x18\x02 \x01(\rR\bscriptId\x12!\n" +
+	"\ftrigger_type\x18\x03 \x01(\tR\vtriggerType\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\x05R\x06status\x12\x1b\n" +
+	"\texit_code\x18\x05 \x01(\x05R\bexitCode\x12\x19\n" +
+	"\benv_vars\x18\x06 \x01(\tR\aenvVars\x12!\n" +
+	"\fcommand_args\x18\a \x01(\tR\vcommandArgs\x12\x19\n" +
+	"\bwork_dir\x18\b \x01(\tR\aworkDir\x12\x18\n" +
+	"\atimeout\x18\t \x01(\x05R\atimeout\x12#\n" +
+	"\rerror_message\x18\n" +
+	" \x01(\tR\ferrorMessage\x12%\n" +
+	"\x0efailure_reason\x18\v \x01(\tR\rfailureReason\x12\x1a\n" +
+	"\busername\x18\f \x01(\tR\busername\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\r \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x0e \x01(\tR\tupdatedAt\"\xa6\x02\n" +
+	"\x14TriggerScriptRequest\x12\x1b\n" +
+	"\tscript_id\x18\x01 \x01(\rR\bscriptId\x12!\n" +
+	"\fcommand_args\x18\x02 \x01(\tR\vcommandArgs\x12\x19\n" +
+	"\benv_vars\x18\x03 \x01(\tR\aenvVars\x12C\n" +
+	"\x06params\x18\x04 \x03(\v2+.artweb.v1.TriggerScriptRequest.ParamsEntryR\x06params\x12\x18\n" +
+	"\atimeout\x18\x05 \x01(\x05R\atimeout\x12\x19\n" +
+	"\bwork_dir\x18\x06 \x01(\tR\aworkDir\x1a9\n" +
+	"\vParamsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"(\n" +
+	"\x16GetScriptRecordRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id2\xc0\x01\n" +
+	"\n" +
+	"OesService\x12U\n" +
+	"\fListColonies\x12!.artweb.v1.ListOesColoniesRequest\x1a\".artweb.v1.ListOesColoniesResponse\x12[\n" +
+	"\x0fListColonyTasks\x12$.artweb.v1.ListOesColonyTasksRequest\x1a\".artweb.v1.ListColonyTasksResponse2\xc0\x01\n" +
+	"\n" +
+	"MdsService\x12U\n" +
+	"\fListColonies\x12!.artweb.v1.ListMdsColoniesRequest\x1a\".artweb.v1.ListMdsColoniesResponse\x12[\n" +
+	"\x0fListColonyTasks\x12$.artweb.v1.ListMdsColonyTasksRequest\x1a\".artweb.v1.ListColonyTasksResponse2\xaa\x01\n" +
+	"\x0fResourceService\x12F\n" +
+	"\tListHosts\x12\x1b.artweb.v1.ListHostsRequest\x1a\x1c.artweb.v1.ListHostsResponse\x12O\n" +
+	"\fListPackages\x12\x1e.artweb.v1.ListPackagesRequest\x1a\x1f.artweb.v1.ListPackagesResponse2\xa7\x01\n" +
+	"\vJobsService\x12I\n" +
+	"\rTriggerScript\x12\x1f.artweb.v1.TriggerScriptRequest\x1a\x17.artweb.v1.ScriptRecord\x12M\n" +
+	"\x0fGetScriptRecord\x12!.artweb.v1.GetScriptRecordRequest\x1a\x17.artweb.v1.ScriptRecordB&Z$gin-artweb/pkg/rpc/artwebv1;artwebv1b\x06proto3"
+
+var (
+	file_artweb_proto_rawDescOnce sync.Once
+	file_artweb_proto_rawDescData []byte
+)
+
+func file_artweb_proto_rawDescGZIP() []byte {
+	file_artweb_proto_rawDescOnce.Do(func() {
+		file_artweb_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_artweb_proto_rawDesc), len(file_artweb_proto_rawDesc)))
+	})
+	return file_artweb_proto_rawDescData
+}
+
+var file_artweb_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
+var file_artweb_proto_goTypes = []any{
+	(*TaskInfo)(nil),                  // 0: artweb.v1.TaskInfo
+	(*ColonyTasks)(nil),               // 1: artweb.v1.ColonyTasks
+	(*ListColonyTasksResponse)(nil),   // 2: artweb.v1.ListColonyTasksResponse
+	(*OesColony)(nil),                 // 3: artweb.v1.OesColony
+	(*ListOesColoniesRequest)(nil),    // 4: artweb.v1.ListOesColoniesRequest
+	(*ListOesColoniesResponse)(nil),   // 5: artweb.v1.ListOesColoniesResponse
+	(*ListOesColonyTasksRequest)(nil), // 6: artweb.v1.ListOesColonyTasksRequest
+	(*MdsColony)(nil),                 // 7: artweb.v1.MdsColony
+	(*ListMdsColoniesRequest)(nil),    // 8: artweb.v1.ListMdsColoniesRequest
+	(*ListMdsColoniesResponse)(nil),   // 9: artweb.v1.ListMdsColoniesResponse
+	(*ListMdsColonyTasksRequest)(nil), // 10: artweb.v1.ListMdsColonyTasksRequest
+	(*Host)(nil),                      // 11: artweb.v1.Host
+	(*ListHostsRequest)(nil),          // 12: artweb.v1.ListHostsRequest
+	(*ListHostsResponse)(nil),         // 13: artweb.v1.ListHostsResponse
+	(*Package)(nil),                   // 14: artweb.v1.Package
+	(*ListPackagesRequest)(nil),       // 15: artweb.v1.ListPackagesRequest
+	(*ListPackagesResponse)(nil),      // 16: artweb.v1.ListPackagesResponse
+	(*ScriptRecord)(nil),              // 17: artweb.v1.ScriptRecord
+	(*TriggerScriptRequest)(nil),      // 18: artweb.v1.TriggerScriptRequest
+	(*GetScriptRecordRequest)(nil),    // 19: artweb.v1.GetScriptRecordRequest
+	nil,                               // 20: artweb.v1.TriggerScriptRequest.ParamsEntry
+}
+var file_artweb_proto_depIdxs = []int32{
+	0,  // 0: artweb.v1.ColonyTasks.tasks:type_name -> artweb.v1.TaskInfo
+	1,  // 1: artweb.v1.ListColonyTasksResponse.colonies:type_name -> artweb.v1.ColonyTasks
+	3,  // 2: artweb.v1.ListOesColoniesResponse.colonies:type_name -> artweb.v1.OesColony
+	7,  // 3: artweb.v1.ListMdsColoniesResponse.colonies:type_name -> artweb.v1.MdsColony
+	11, // 4: artweb.v1.ListHostsResponse.hosts:type_name -> artweb.v1.Host
+	14, // 5: artweb.v1.ListPackagesResponse.packages:type_name -> artweb.v1.Package
+	20, // 6: artweb.v1.TriggerScriptRequest.params:type_name -> artweb.v1.TriggerScriptRequest.ParamsEntry
+	4,  // 7: artweb.v1.OesService.ListColonies:input_type -> artweb.v1.ListOesColoniesRequest
+	6,  // 8: artweb.v1.OesService.ListColonyTasks:input_type -> artweb.v1.ListOesColonyTasksRequest
+	8,  // 9: artweb.v1.MdsService.ListColonies:input_type -> artweb.v1.ListMdsColoniesRequest
+	10, // 10: artweb.v1.MdsService.ListColonyTasks:input_type -> artweb.v1.ListMdsColonyTasksRequest
+	12, // 11: artweb.v1.ResourceService.ListHosts:input_type -> artweb.v1.ListHostsRequest
+	15, // 12: artweb.v1.ResourceService.ListPackages:input_type -> artweb.v1.ListPackagesRequest
+	18, // 13: artweb.v1.JobsService.TriggerScript:input_type -> artweb.v1.TriggerScriptRequest
+	19, // 14: artweb.v1.JobsService.GetScriptRecord:input_type -> artweb.v1.GetScriptRecordRequest
+	5,  // 15: artweb.v1.OesService.ListColonies:output_type -> artweb.v1.ListOesColoniesResponse
+	2,  // 16: artweb.v1.OesService.ListColonyTasks:output_type -> artweb.v1.ListColonyTasksResponse
+	9,  // 17: artweb.v1.MdsService.ListColonies:output_type -> artweb.v1.ListMdsColoniesResponse
+	2,  // 18: artweb.v1.MdsService.ListColonyTasks:output_type -> artweb.v1.ListColonyTasksResponse
+	13, // 19: artweb.v1.ResourceService.ListHosts:output_type -> artweb.v1.ListHostsResponse
+	16, // 20: artweb.v1.ResourceService.ListPackages:output_type -> artweb.v1.ListPackagesResponse
+	17, // 21: artweb.v1.JobsService.TriggerScript:output_type -> artweb.v1.ScriptRecord
+	17, // 22: artweb.v1.JobsService.GetScriptRecord:output_type -> artweb.v1.ScriptRecord
+	15, // [15:23] is the sub-list for method output_type
+	7,  // [7:15] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_artweb_proto_init() }
+func file_artweb_proto_init() {
+	if File_artweb_proto != nil {
+		return
+	}
+	file_artweb_proto_msgTypes[4].OneofWrappers = []any{}
+	file_artweb_proto_msgTypes[8].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_artweb_proto_rawDesc), len(file_artweb_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   21,
+			NumExtensions: 0,
+			NumServices:   4,
+		},
+		GoTypes:           file_artweb_proto_goTypes,
+		DependencyIndexes: file_artweb_proto_depIdxs,
+		MessageInfos:      file_artweb_proto_msgTypes,
+	}.Build()
+	File_artweb_proto = out.File
+	file_artweb_proto_goTypes = nil
+	file_artweb_proto_depIdxs = nil
+}
@@ -0,0 +1,605 @@
+// artweb的gRPC接口定义
+//
+// 修改后在项目根目录执行 go generate ./pkg/rpc/... 重新生成代码, 需要安装protoc、protoc-gen-go和protoc-gen-go-grpc
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: artweb.proto
+
+package artwebv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	OesService_ListColonies_FullMethodName    = "/artweb.v1.OesService/ListColonies"
+	OesService_ListColonyTasks_FullMethodName = "/artweb.v1.OesService/ListColonyTasks"
+)
+
+// OesServiceClient is the client API for OesService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// OesService oes集群查询
+type OesServiceClient interface {
+	ListColonies(ctx context.Context, in *ListOesColoniesRequest, opts ...grpc.CallOption) (*ListOesColoniesResponse, error)
+	// ListColonyTasks 查询已启用集群的日常任务状态
+	ListColonyTasks(ctx context.Context, in *ListOesColonyTasksRequest, opts ...grpc.CallOption) (*ListColonyTasksResponse, error)
+}
+
+type oesServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewOesServiceClient(cc grpc.ClientConnInterface) OesServiceClient {
+	return &oesServiceClient{cc}
+}
+
+func (c *oesServiceClient) ListColonies(ctx context.Context, in *ListOesColoniesRequest, opts ...grpc.CallOption) (*ListOesColoniesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListOesColoniesResponse)
+	err := c.cc.Invoke(ctx, OesService_ListColonies_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oesServiceClient) ListColonyTasks(ctx context.Context, in *ListOesColonyTasksRequest, opts ...grpc.CallOption) (*ListColonyTasksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListColonyTasksResponse)
+	err := c.cc.Invoke(ctx, OesService_ListColonyTasks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OesServiceServer is the server API for OesService service.
+// All implementations must embed UnimplementedOesServiceServer
+// for forward compatibility.
+//
+// OesService oes集群查询
+type OesServiceServer interface {
+	ListColonies(context.Context, *ListOesColoniesRequest) (*ListOesColoniesResponse, error)
+	// ListColonyTasks 查询已启用集群的日常任务状态
+	ListColonyTasks(context.Context, *ListOesColonyTasksRequest) (*ListColonyTasksResponse, error)
+	mustEmbedUnimplementedOesServiceServer()
+}
+
+// UnimplementedOesServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedOesServiceServer struct{}
+
+func (UnimplementedOesServiceServer) ListColonies(context.Context, *ListOesColoniesRequest) (*ListOesColoniesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListColonies not implemented")
+}
+func (UnimplementedOesServiceServer) ListColonyTasks(context.Context, *ListOesColonyTasksRequest) (*ListColonyTasksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListColonyTasks not implemented")
+}
+func (UnimplementedOesServiceServer) mustEmbedUnimplementedOesServiceServer() {}
+func (UnimplementedOesServiceServer) testEmbeddedByValue()                    {}
+
+// UnsafeOesServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to OesServiceServer will
+// result in compilation errors.
+type UnsafeOesServiceServer interface {
+	mustEmbedUnimplementedOesServiceServer()
+}
+
+func RegisterOesServiceServer(s grpc.ServiceRegistrar, srv OesServiceServer) {
+	// If the following call pancis, it indicates UnimplementedOesServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&OesService_ServiceDesc, srv)
+}
+
+func _OesService_ListColonies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOesColoniesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OesServiceServer).ListColonies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OesService_ListColonies_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OesServiceServer).ListColonies(ctx, req.(*ListOesColoniesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OesService_ListColonyTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOesColonyTasksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OesServiceServer).ListColonyTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OesService_ListColonyTasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OesServiceServer).ListColonyTasks(ctx, req.(*ListOesColonyTasksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// OesService_ServiceDesc is the grpc.ServiceDesc for OesService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var OesService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "artweb.v1.OesService",
+	HandlerType: (*OesServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListColonies",
+			Handler:    _OesService_ListColonies_Handler,
+		},
+		{
+			MethodName: "ListColonyTasks",
+			Handler:    _OesService_ListColonyTasks_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "artweb.proto",
+}
+
+const (
+	MdsService_ListColonies_FullMethodName    = "/artweb.v1.MdsService/ListColonies"
+	MdsService_ListColonyTasks_FullMethodName = "/artweb.v1.MdsService/ListColonyTasks"
+)
+
+// MdsServiceClient is the client API for MdsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// MdsService mds集群查询
+type MdsServiceClient interface {
+	ListColonies(ctx context.Context, in *ListMdsColoniesRequest, opts ...grpc.CallOption) (*ListMdsColoniesResponse, error)
+	// ListColonyTasks 查询已启用集群的日常任务状态
+	ListColonyTasks(ctx context.Context, in *ListMdsColonyTasksRequest, opts ...grpc.CallOption) (*ListColonyTasksResponse, error)
+}
+
+type mdsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMdsServiceClient(cc grpc.ClientConnInterface) MdsServiceClient {
+	return &mdsServiceClient{cc}
+}
+
+func (c *mdsServiceClient) ListColonies(ctx context.Context, in *ListMdsColoniesRequest, opts ...grpc.CallOption) (*ListMdsColoniesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMdsColoniesResponse)
+	err := c.cc.Invoke(ctx, MdsService_ListColonies_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mdsServiceClient) ListColonyTasks(ctx context.Context, in *ListMdsColonyTasksRequest, opts ...grpc.CallOption) (*ListColonyTasksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListColonyTasksResponse)
+	err := c.cc.Invoke(ctx, MdsService_ListColonyTasks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MdsServiceServer is the server API for MdsService service.
+// All implementations must embed UnimplementedMdsServiceServer
+// for forward compatibility.
+//
+// MdsService mds集群查询
+type MdsServiceServer interface {
+	ListColonies(context.Context, *ListMdsColoniesRequest) (*ListMdsColoniesResponse, error)
+	// ListColonyTasks 查询已启用集群的日常任务状态
+	ListColonyTasks(context.Context, *ListMdsColonyTasksRequest) (*ListColonyTasksResponse, error)
+	mustEmbedUnimplementedMdsServiceServer()
+}
+
+// UnimplementedMdsServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedMdsServiceServer struct{}
+
+func (UnimplementedMdsServiceServer) ListColonies(context.Context, *ListMdsColoniesRequest) (*ListMdsColoniesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListColonies not implemented")
+}
+func (UnimplementedMdsServiceServer) ListColonyTasks(context.Context, *ListMdsColonyTasksRequest) (*ListColonyTasksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListColonyTasks not implemented")
+}
+func (UnimplementedMdsServiceServer) mustEmbedUnimplementedMdsServiceServer() {}
+func (UnimplementedMdsServiceServer) testEmbeddedByValue()                    {}
+
+// UnsafeMdsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MdsServiceServer will
+// result in compilation errors.
+type UnsafeMdsServiceServer interface {
+	mustEmbedUnimplementedMdsServiceServer()
+}
+
+func RegisterMdsServiceServer(s grpc.ServiceRegistrar, srv MdsServiceServer) {
+	// If the following call pancis, it indicates UnimplementedMdsServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&MdsService_ServiceDesc, srv)
+}
+
+func _MdsService_ListColonies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMdsColoniesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MdsServiceServer).ListColonies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MdsService_ListColonies_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MdsServiceServer).ListColonies(ctx, req.(*ListMdsColoniesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MdsService_ListColonyTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMdsColonyTasksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MdsServiceServer).ListColonyTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MdsService_ListColonyTasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MdsServiceServer).ListColonyTasks(ctx, req.(*ListMdsColonyTasksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// MdsService_ServiceDesc is the grpc.ServiceDesc for MdsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var MdsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "artweb.v1.MdsService",
+	HandlerType: (*MdsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListColonies",
+			Handler:    _MdsService_ListColonies_Handler,
+		},
+		{
+			MethodName: "ListColonyTasks",
+			Handler:    _MdsService_ListColonyTasks_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "artweb.proto",
+}
+
+const (
+	ResourceService_ListHosts_FullMethodName    = "/artweb.v1.ResourceService/ListHosts"
+	ResourceService_ListPackages_FullMethodName = "/artweb.v1.ResourceService/ListPackages"
+)
+
+// ResourceServiceClient is the client API for ResourceService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ResourceService 主机和程序包查询
+type ResourceServiceClient interface {
+	ListHosts(ctx context.Context, in *ListHostsRequest, opts ...grpc.CallOption) (*ListHostsResponse, error)
+	ListPackages(ctx context.Context, in *ListPackagesRequest, opts ...grpc.CallOption) (*ListPackagesResponse, error)
+}
+
+type resourceServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewResourceServiceClient(cc grpc.ClientConnInterface) ResourceServiceClient {
+	return &resourceServiceClient{cc}
+}
+
+func (c *resourceServiceClient) ListHosts(ctx context.Context, in *ListHostsRequest, opts ...grpc.CallOption) (*ListHostsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListHostsResponse)
+	err := c.cc.Invoke(ctx, ResourceService_ListHosts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *resourceServiceClient) ListPackages(ctx context.Context, in *ListPackagesRequest, opts ...grpc.CallOption) (*ListPackagesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPackagesResponse)
+	err := c.cc.Invoke(ctx, ResourceService_ListPackages_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ResourceServiceServer is the server API for ResourceService service.
+// All implementations must embed UnimplementedResourceServiceServer
+// for forward compatibility.
+//
+// ResourceService 主机和程序包查询
+type ResourceServiceServer interface {
+	ListHosts(context.Context, *ListHostsRequest) (*ListHostsResponse, error)
+	ListPackages(context.Context, *ListPackagesRequest) (*ListPackagesResponse, error)
+	mustEmbedUnimplementedResourceServiceServer()
+}
+
+// UnimplementedResourceServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedResourceServiceServer struct{}
+
+func (UnimplementedResourceServiceServer) ListHosts(context.Context, *ListHostsRequest) (*ListHostsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListHosts not implemented")
+}
+func (UnimplementedResourceServiceServer) ListPackages(context.Context, *ListPackagesRequest) (*ListPackagesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPackages not implemented")
+}
+func (UnimplementedResourceServiceServer) mustEmbedUnimplementedResourceServiceServer() {}
+func (UnimplementedResourceServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeResourceServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ResourceServiceServer will
+// result in compilation errors.
+type UnsafeResourceServiceServer interface {
+	mustEmbedUnimplementedResourceServiceServer()
+}
+
+func RegisterResourceServiceServer(s grpc.ServiceRegistrar, srv ResourceServiceServer) {
+	// If the following call pancis, it indicates UnimplementedResourceServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ResourceService_ServiceDesc, srv)
+}
+
+func _ResourceService_ListHosts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListHostsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResourceServiceServer).ListHosts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ResourceService_ListHosts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResourceServiceServer).ListHosts(ctx, req.(*ListHostsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ResourceService_ListPackages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPackagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ResourceServiceServer).ListPackages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ResourceService_ListPackages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ResourceServiceServer).ListPackages(ctx, req.(*ListPackagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ResourceService_ServiceDesc is the grpc.ServiceDesc for ResourceService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ResourceService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "artweb.v1.ResourceService",
+	HandlerType: (*ResourceServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListHosts",
+			Handler:    _ResourceService_ListHosts_Handler,
+		},
+		{
+			MethodName: "ListPackages",
+			Handler:    _ResourceService_ListPackages_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "artweb.proto",
+}
+
+const (
+	JobsService_TriggerScript_FullMethodName   = "/artweb.v1.JobsService/TriggerScript"
+	JobsService_GetScriptRecord_FullMethodName = "/artweb.v1.JobsService/GetScriptRecord"
+)
+
+// JobsServiceClient is the client API for JobsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// JobsService 脚本执行
+type JobsServiceClient interface {
+	// TriggerScript 异步执行脚本, 返回创建的执行记录, 与REST接口相同记录为api触发
+	TriggerScript(ctx context.Context, in *TriggerScriptRequest, opts ...grpc.CallOption) (*ScriptRecord, error)
+	GetScriptRecord(ctx context.Context, in *GetScriptRecordRequest, opts ...grpc.CallOption) (*ScriptRecord, error)
+}
+
+type jobsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewJobsServiceClient(cc grpc.ClientConnInterface) JobsServiceClient {
+	return &jobsServiceClient{cc}
+}
+
+func (c *jobsServiceClient) TriggerScript(ctx context.Context, in *TriggerScriptRequest, opts ...grpc.CallOption) (*ScriptRecord, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScriptRecord)
+	err := c.cc.Invoke(ctx, JobsService_TriggerScript_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobsServiceClient) GetScriptRecord(ctx context.Context, in *GetScriptRecordRequest, opts ...grpc.CallOption) (*ScriptRecord, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScriptRecord)
+	err := c.cc.Invoke(ctx, JobsService_GetScriptRecord_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// JobsServiceServer is the server API for JobsService service.
+// All implementations must embed UnimplementedJobsServiceServer
+// for forward compatibility.
+//
+// JobsService 脚本执行
+type JobsServiceServer interface {
+	// TriggerScript 异步执行脚本, 返回创建的执行记录, 与REST接口相同记录为api触发
+	TriggerScript(context.Context, *TriggerScriptRequest) (*ScriptRecord, error)
+	GetScriptRecord(context.Context, *GetScriptRecordRequest) (*ScriptRecord, error)
+	mustEmbedUnimplementedJobsServiceServer()
+}
+
+// UnimplementedJobsServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedJobsServiceServer struct{}
+
+func (UnimplementedJobsServiceServer) TriggerScript(context.Context, *TriggerScriptRequest) (*ScriptRecord, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TriggerScript not implemented")
+}
+func (UnimplementedJobsServiceServer) GetScriptRecord(context.Context, *GetScriptRecordRequest) (*ScriptRecord, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetScriptRecord not implemented")
+}
+func (UnimplementedJobsServiceServer) mustEmbedUnimplementedJobsServiceServer() {}
+func (UnimplementedJobsServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeJobsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to JobsServiceServer will
+// result in compilation errors.
+type UnsafeJobsServiceServer interface {
+	mustEmbedUnimplementedJobsServiceServer()
+}
+
+func RegisterJobsServiceServer(s grpc.ServiceRegistrar, srv JobsServiceServer) {
+	// If the following call pancis, it indicates UnimplementedJobsServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&JobsService_ServiceDesc, srv)
+}
+
+func _JobsService_TriggerScript_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TriggerScriptRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobsServiceServer).TriggerScript(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobsService_TriggerScript_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobsServiceServer).TriggerScript(ctx, req.(*TriggerScriptRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobsService_GetScriptRecord_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetScriptRecordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobsServiceServer).GetScriptRecord(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobsService_GetScriptRecord_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobsServiceServer).GetScriptRecord(ctx, req.(*GetScriptRecordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// JobsService_ServiceDesc is the grpc.ServiceDesc for JobsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var JobsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "artweb.v1.JobsService",
+	HandlerType: (*JobsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "TriggerScript",
+			Handler:    _JobsService_TriggerScript_Handler,
+		},
+		{
+			MethodName: "GetScriptRecord",
+			Handler:    _JobsService_GetScriptRecord_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "artweb.proto",
+}
@@ -0,0 +1,4 @@
+// Package artwebv1 artweb的gRPC接口, 由artweb.proto生成, 供服务端注册和客户端调用
+package artwebv1
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative artweb.proto
@@ -0,0 +1,107 @@
+package scriptcheck
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// ScriptPlaceholder 沙箱命令中替换为脚本路径的占位符
+const ScriptPlaceholder = "{script}"
+
+// ErrNoSandbox 未配置沙箱命令
+var ErrNoSandbox = errors.New("未配置试运行沙箱")
+
+// DryRunOptions 试运行选项
+type DryRunOptions struct {
+	Sandbox   []string      // 沙箱命令, 参数中的{script}替换为脚本路径, 不含占位符时脚本路径追加在末尾
+	Args      []string      // 脚本参数
+	Env       []string      // 环境变量, 不继承服务进程的环境变量
+	Timeout   time.Duration // 超时时间, 0表示不限制
+	MaxOutput int           // 最多保留的输出字节数, 0表示不限制
+}
+
+// DryRunResult 试运行结果
+type DryRunResult struct {
+	ExitCode  int           // 退出码, 超时或无法启动时为-1
+	Output    string        // 标准输出和标准错误
+	Truncated bool          // 输出是否超过MaxOutput被截断
+	TimedOut  bool          // 是否超时
+	Duration  time.Duration // 耗时
+}
+
+// DryRun 在沙箱中执行脚本
+//
+// 脚本的隔离(网络、文件系统、权限)完全由沙箱命令负责, 没有配置沙箱时返回ErrNoSandbox, 不会直接执行脚本
+func DryRun(ctx context.Context, script string, opts DryRunOptions) (*DryRunResult, error) {
+	if len(opts.Sandbox) == 0 {
+		return nil, ErrNoSandbox
+	}
+	args := make([]string, 0, len(opts.Sandbox)+len(opts.Args)+1)
+	placed := false
+	for _, arg := range opts.Sandbox {
+		if strings.Contains(arg, ScriptPlaceholder) {
+			arg = strings.ReplaceAll(arg, ScriptPlaceholder, script)
+			placed = true
+		}
+		args = append(args, arg)
+	}
+	if !placed {
+		args = append(args, script)
+	}
+	args = append(args, opts.Args...)
+
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+	out := &limitedBuffer{limit: opts.MaxOutput}
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Env = opts.Env
+	cmd.Stdout = out
+	cmd.Stderr = out
+	cmd.WaitDelay = time.Second
+
+	start := time.Now()
+	err := cmd.Run()
+	result := &DryRunResult{
+		Output:    out.buf.String(),
+		Truncated: out.truncated,
+		Duration:  time.Since(start),
+	}
+	var exitErr *exec.ExitError
+	switch {
+	case ctx.Err() != nil:
+		result.ExitCode = -1
+		result.TimedOut = true
+	case err == nil:
+	case errors.As(err, &exitErr):
+		result.ExitCode = exitErr.ExitCode()
+	default:
+		return nil, err
+	}
+	return result, nil
+}
+
+// limitedBuffer 超过limit的内容丢弃, 不影响被执行命令的写入
+type limitedBuffer struct {
+	buf       bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	n := len(p)
+	if b.limit > 0 {
+		if remain := b.limit - b.buf.Len(); remain < len(p) {
+			p = p[:max(remain, 0)]
+			b.truncated = true
+		}
+	}
+	b.buf.Write(p)
+	return n, nil
+}
@@ -0,0 +1,272 @@
+// Package scriptcheck 在执行前检查脚本, 包括静态检查、解释器语法检查和沙箱试运行
+package scriptcheck
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	LevelError   = "error"
+	LevelWarning = "warning"
+	LevelInfo    = "info"
+)
+
+// Diagnostic 检查发现的问题
+type Diagnostic struct {
+	Line    int    // 行号, 0表示与具体行无关
+	Column  int    // 列号, 0表示未知
+	Level   string // 级别(error, warning, info)
+	Code    string // 规则编号, 内置规则以AW开头, shellcheck规则以SC开头
+	Message string // 说明
+	Source  string // 检查来源(builtin, bash, sh, python3, shellcheck)
+}
+
+// Options 检查选项
+type Options struct {
+	Timeout    time.Duration // 单个外部检查命令的超时时间, 0表示不限制
+	Shellcheck bool          // 安装了shellcheck时是否对shell脚本执行shellcheck
+}
+
+// HasError 是否存在error级别的问题
+func HasError(ds []Diagnostic) bool {
+	for _, d := range ds {
+		if d.Level == LevelError {
+			return true
+		}
+	}
+	return false
+}
+
+// Check 检查path指向的脚本, language为脚本语言
+//
+// 找不到解释器或不支持的语言只返回info级别的提示, 不视为错误; 只有读取脚本失败时返回error
+func Check(ctx context.Context, path, language string, opts Options) ([]Diagnostic, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	ds := Lint(content, language)
+
+	interpreter := interpreterOf(language)
+	switch interpreter {
+	case "bash", "sh":
+		ds = append(ds, runCheck(ctx, opts.Timeout, interpreter, parseShellOutput, interpreter, "-n", path)...)
+		if opts.Shellcheck {
+			if _, err := exec.LookPath("shellcheck"); err == nil {
+				ds = append(ds, runCheck(ctx, opts.Timeout, "shellcheck", parseShellcheckOutput,
+					"shellcheck", "-f", "json1", "-s", interpreter, path)...)
+			}
+		}
+	case "python3":
+		ds = append(ds, runCheck(ctx, opts.Timeout, interpreter, parsePythonOutput, interpreter, "-c", pythonCheckCode, path)...)
+	default:
+		ds = append(ds, Diagnostic{
+			Level:   LevelInfo,
+			Code:    "AW100",
+			Message: fmt.Sprintf("不支持检查%s脚本的语法", language),
+			Source:  "builtin",
+		})
+	}
+	return ds, nil
+}
+
+// interpreterOf 返回脚本语言对应的语法检查解释器, 不支持时返回空字符串
+func interpreterOf(language string) string {
+	switch strings.ToLower(strings.TrimSpace(language)) {
+	case "bash", "shell":
+		return "bash"
+	case "sh":
+		return "sh"
+	case "python", "python3":
+		return "python3"
+	default:
+		return ""
+	}
+}
+
+// Lint 不依赖外部命令的内置检查
+func Lint(content []byte, language string) []Diagnostic {
+	var ds []Diagnostic
+	if bytes.HasPrefix(content, []byte("\xef\xbb\xbf")) {
+		ds = append(ds, Diagnostic{
+			Line: 1, Level: LevelError, Code: "AW001", Source: "builtin",
+			Message: "文件以UTF-8 BOM开头, 解释器行无法识别",
+		})
+		content = content[3:]
+	}
+	if i := bytes.Index(content, []byte("\r\n")); i >= 0 {
+		ds = append(ds, Diagnostic{
+			Line: bytes.Count(content[:i], []byte("\n")) + 1, Level: LevelError, Code: "AW002", Source: "builtin",
+			Message: "使用了Windows换行符(CRLF), 请转换为LF",
+		})
+	}
+
+	firstLine, _, _ := bytes.Cut(content, []byte("\n"))
+	firstLine = bytes.TrimRight(firstLine, "\r")
+	if !bytes.HasPrefix(firstLine, []byte("#!")) {
+		// 脚本直接作为可执行文件执行, 没有解释器行时无法执行
+		ds = append(ds, Diagnostic{
+			Line: 1, Level: LevelError, Code: "AW003", Source: "builtin",
+			Message: "缺少解释器行(#!), 例如#!/bin/bash",
+		})
+		return ds
+	}
+	if want := interpreterOf(language); want != "" {
+		got := shebangInterpreter(string(firstLine))
+		if !interpreterMatches(want, got) {
+			ds = append(ds, Diagnostic{
+				Line: 1, Level: LevelWarning, Code: "AW004", Source: "builtin",
+				Message: fmt.Sprintf("解释器行%q与脚本语言%s不一致", string(firstLine), language),
+			})
+		}
+	}
+	return ds
+}
+
+// shebangInterpreter 返回解释器行中的解释器名称, 支持#!/usr/bin/env xxx的写法
+func shebangInterpreter(line string) string {
+	fields := strings.Fields(strings.TrimPrefix(line, "#!"))
+	if len(fields) == 0 {
+		return ""
+	}
+	name := filepath.Base(fields[0])
+	if name == "env" {
+		for _, f := range fields[1:] {
+			if !strings.HasPrefix(f, "-") {
+				return filepath.Base(f)
+			}
+		}
+		return ""
+	}
+	return name
+}
+
+func interpreterMatches(want, got string) bool {
+	switch want {
+	case "bash":
+		return got == "bash"
+	case "sh":
+		return got == "sh" || got == "bash" || got == "dash"
+	case "python3":
+		return strings.HasPrefix(got, "python")
+	}
+	return true
+}
+
+// runCheck 执行外部检查命令并解析输出, 命令无法执行时返回info提示
+func runCheck(
+	ctx context.Context,
+	timeout time.Duration,
+	source string,
+	parse func(source string, out []byte) []Diagnostic,
+	name string, args ...string,
+) []Diagnostic {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	var out bytes.Buffer
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		return parse(source, out.Bytes())
+	case ctx.Err() != nil:
+		return []Diagnostic{{Level: LevelWarning, Code: "AW101", Source: source, Message: "检查超时"}}
+	case errors.As(err, &exitErr):
+		ds := parse(source, out.Bytes())
+		if len(ds) == 0 && source != "shellcheck" {
+			ds = append(ds, Diagnostic{Level: LevelError, Code: "AW102", Source: source, Message: strings.TrimSpace(out.String())})
+		}
+		return ds
+	default:
+		return []Diagnostic{{Level: LevelInfo, Code: "AW103", Source: source, Message: fmt.Sprintf("无法执行%s, 跳过检查: %v", name, err)}}
+	}
+}
+
+var shellLineRe = regexp.MustCompile(`line (\d+): (.+)$`)
+
+// parseShellOutput 解析bash -n和sh -n的输出, 例如 "x.sh: line 3: syntax error near unexpected token `fi'"
+func parseShellOutput(source string, out []byte) []Diagnostic {
+	var ds []Diagnostic
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		m := shellLineRe.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		n, _ := strconv.Atoi(m[1])
+		ds = append(ds, Diagnostic{Line: n, Level: LevelError, Code: "AW110", Message: m[2], Source: source})
+	}
+	return ds
+}
+
+// pythonCheckCode 只解析语法树, 不执行脚本也不生成pyc文件
+const pythonCheckCode = `import ast, sys
+try:
+    ast.parse(open(sys.argv[1], "rb").read(), sys.argv[1])
+except SyntaxError as e:
+    print("%s:%s:%s" % (e.lineno or 0, e.offset or 0, e.msg))
+    sys.exit(1)`
+
+func parsePythonOutput(source string, out []byte) []Diagnostic {
+	var ds []Diagnostic
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		parts := strings.SplitN(line, ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		n, err1 := strconv.Atoi(parts[0])
+		col, err2 := strconv.Atoi(parts[1])
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		ds = append(ds, Diagnostic{Line: n, Column: col, Level: LevelError, Code: "AW111", Message: parts[2], Source: source})
+	}
+	return ds
+}
+
+func parseShellcheckOutput(source string, out []byte) []Diagnostic {
+	var result struct {
+		Comments []struct {
+			Line    int    `json:"line"`
+			Column  int    `json:"column"`
+			Level   string `json:"level"`
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"comments"`
+	}
+	if err := json.Unmarshal(out, &result); err != nil {
+		return nil
+	}
+	ds := make([]Diagnostic, 0, len(result.Comments))
+	for _, c := range result.Comments {
+		level := c.Level
+		if level == "style" {
+			level = LevelInfo
+		}
+		ds = append(ds, Diagnostic{
+			Line:    c.Line,
+			Column:  c.Column,
+			Level:   level,
+			Code:    fmt.Sprintf("SC%d", c.Code),
+			Message: c.Message,
+			Source:  source,
+		})
+	}
+	return ds
+}
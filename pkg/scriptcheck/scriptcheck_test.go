@@ -0,0 +1,149 @@
+package scriptcheck
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeScript(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "test.sh")
+	if err := os.WriteFile(path, []byte(content), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func codes(ds []Diagnostic) []string {
+	var cs []string
+	for _, d := range ds {
+		cs = append(cs, d.Code)
+	}
+	return cs
+}
+
+func TestLint(t *testing.T) {
+	cases := []struct {
+		name     string
+		content  string
+		language string
+		want     []string
+	}{
+		{"正常", "#!/bin/bash\necho ok\n", "bash", nil},
+		{"env写法", "#!/usr/bin/env python3\nprint(1)\n", "python", nil},
+		{"sh脚本使用bash", "#!/bin/bash\necho ok\n", "sh", nil},
+		{"缺少解释器行", "echo ok\n", "bash", []string{"AW003"}},
+		{"BOM", "\xef\xbb\xbf#!/bin/bash\necho ok\n", "bash", []string{"AW001"}},
+		{"CRLF", "#!/bin/bash\r\necho ok\r\n", "bash", []string{"AW002"}},
+		{"解释器不一致", "#!/usr/bin/python3\nprint(1)\n", "bash", []string{"AW004"}},
+		{"未知语言不检查解释器", "#!/usr/bin/perl\nprint 1;\n", "perl", nil},
+	}
+	for _, c := range cases {
+		got := codes(Lint([]byte(c.content), c.language))
+		if strings.Join(got, ",") != strings.Join(c.want, ",") {
+			t.Errorf("%s: 期望%v, 实际%v", c.name, c.want, got)
+		}
+	}
+}
+
+func TestCheckBashSyntax(t *testing.T) {
+	if _, err := exec.LookPath("bash"); err != nil {
+		t.Skip("未安装bash")
+	}
+	path := writeScript(t, "#!/bin/bash\nif true; then\n  echo ok\n")
+	ds, err := Check(context.Background(), path, "bash", Options{Timeout: 5 * time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !HasError(ds) {
+		t.Fatalf("语法错误的脚本应返回error, 实际%+v", ds)
+	}
+	for _, d := range ds {
+		if d.Source == "bash" && d.Line == 0 {
+			t.Errorf("应解析出行号: %+v", d)
+		}
+	}
+
+	path = writeScript(t, "#!/bin/bash\nif true; then\n  echo ok\nfi\n")
+	ds, err = Check(context.Background(), path, "bash", Options{Timeout: 5 * time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if HasError(ds) {
+		t.Errorf("正确的脚本不应返回error, 实际%+v", ds)
+	}
+}
+
+func TestCheckUnsupported(t *testing.T) {
+	path := writeScript(t, "#!/usr/bin/perl\nprint 1;\n")
+	ds, err := Check(context.Background(), path, "perl", Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if HasError(ds) || len(ds) != 1 || ds[0].Code != "AW100" {
+		t.Errorf("不支持的语言应只返回提示, 实际%+v", ds)
+	}
+
+	if _, err := Check(context.Background(), filepath.Join(t.TempDir(), "missing.sh"), "bash", Options{}); err == nil {
+		t.Error("脚本不存在时应返回错误")
+	}
+}
+
+func TestParseShellcheckOutput(t *testing.T) {
+	out := `{"comments":[{"file":"a.sh","line":3,"column":6,"level":"warning","code":2086,"message":"Double quote to prevent globbing."},{"line":5,"column":1,"level":"style","code":2006,"message":"Use $(...)"}]}`
+	ds := parseShellcheckOutput("shellcheck", []byte(out))
+	if len(ds) != 2 {
+		t.Fatalf("期望2条, 实际%+v", ds)
+	}
+	if ds[0].Code != "SC2086" || ds[0].Line != 3 || ds[0].Column != 6 || ds[0].Level != LevelWarning {
+		t.Errorf("解析结果错误: %+v", ds[0])
+	}
+	if ds[1].Level != LevelInfo {
+		t.Errorf("style级别应转换为info: %+v", ds[1])
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("未安装sh")
+	}
+	path := writeScript(t, "#!/bin/sh\necho \"args:$1 $JOBS_DRY_RUN\"\nexit 3\n")
+
+	if _, err := DryRun(context.Background(), path, DryRunOptions{}); err != ErrNoSandbox {
+		t.Fatalf("未配置沙箱时应返回ErrNoSandbox, 实际%v", err)
+	}
+
+	result, err := DryRun(context.Background(), path, DryRunOptions{
+		Sandbox: []string{"sh", ScriptPlaceholder},
+		Args:    []string{"x"},
+		Env:     []string{"JOBS_DRY_RUN=1"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.ExitCode != 3 || strings.TrimSpace(result.Output) != "args:x 1" {
+		t.Errorf("试运行结果错误: %+v", result)
+	}
+
+	result, err = DryRun(context.Background(), path, DryRunOptions{Sandbox: []string{"sh"}, MaxOutput: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Truncated || result.Output != "args" {
+		t.Errorf("输出应被截断: %+v", result)
+	}
+
+	slow := writeScript(t, "#!/bin/sh\nsleep 5\n")
+	result, err = DryRun(context.Background(), slow, DryRunOptions{Sandbox: []string{"sh"}, Timeout: 100 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.TimedOut || result.ExitCode != -1 {
+		t.Errorf("应超时: %+v", result)
+	}
+}
@@ -0,0 +1,84 @@
+package sdkgen
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// routerAnnotation 接口注释中的@Router, 如// @Router /api/v1/customer/user/{id} [get]
+var routerAnnotation = regexp.MustCompile(`^\s*//\s*@Router\s+(\S+)\s+\[(\w+)\]`)
+
+// ScanRouters 扫描目录下Go源文件中的@Router注释, 返回按"METHOD path"格式排序去重的接口
+func ScanRouters(root string) ([]string, error) {
+	seen := make(map[string]bool)
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		sc := bufio.NewScanner(f)
+		for sc.Scan() {
+			if m := routerAnnotation.FindStringSubmatch(sc.Text()); m != nil {
+				seen[strings.ToUpper(m[2])+" "+m[1]] = true
+			}
+		}
+		return sc.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sortedKeys(seen), nil
+}
+
+// DocRouters 返回swagger文档中按"METHOD path"格式排序的接口
+func DocRouters(data []byte) ([]string, error) {
+	var doc swaggerDoc
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("解析swagger文档失败: %w", err)
+	}
+	seen := make(map[string]bool)
+	for path, ops := range doc.Paths {
+		for method := range ops {
+			seen[strings.ToUpper(method)+" "+path] = true
+		}
+	}
+	return sortedKeys(seen), nil
+}
+
+// DiffRouters 比较注释和文档中的接口, 返回文档缺少的和文档多余的接口
+func DiffRouters(annotated, documented []string) (missing, extra []string) {
+	for _, r := range annotated {
+		if !slices.Contains(documented, r) {
+			missing = append(missing, r)
+		}
+	}
+	for _, r := range documented {
+		if !slices.Contains(annotated, r) {
+			extra = append(extra, r)
+		}
+	}
+	return missing, extra
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,314 @@
+package sdkgen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Header 生成文件的第一行, 工具和代码审查据此识别生成的文件
+const Header = "Code generated by sdkgen. DO NOT EDIT."
+
+// GenerateGo 生成Go客户端的类型和接口方法, 运行时(Client、File、Error等)由包中手写的代码提供
+func GenerateGo(api *API, pkg string) ([]byte, error) {
+	g := &goWriter{imports: map[string]bool{"context": true}, structs: make(map[string]bool)}
+	for _, t := range api.Types {
+		g.structs[t.Name] = t.Underlying == nil
+	}
+	for _, t := range api.Types {
+		g.typeDecl(t)
+	}
+	for _, op := range api.Operations {
+		g.params(op)
+		g.method(op)
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// %s\n\npackage %s\n\n", Header, pkg)
+	imports := make([]string, 0, len(g.imports))
+	for imp := range g.imports {
+		imports = append(imports, imp)
+	}
+	sort.Strings(imports)
+	out.WriteString("import (\n")
+	for _, imp := range imports {
+		fmt.Fprintf(&out, "\t%q\n", imp)
+	}
+	out.WriteString(")\n")
+	out.Write(g.buf.Bytes())
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("格式化生成的Go代码失败: %w", err)
+	}
+	return src, nil
+}
+
+type goWriter struct {
+	buf     bytes.Buffer
+	imports map[string]bool
+	structs map[string]bool // 类型名是否为结构体
+}
+
+func (g *goWriter) printf(format string, args ...any) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+// comment 输出注释, 第一行以name开头
+func (g *goWriter) comment(indent, name, text string) {
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	if name != "" {
+		lines[0] = strings.TrimSpace(name + " " + lines[0])
+	}
+	for _, line := range lines {
+		g.printf("%s// %s\n", indent, strings.TrimRight(line, " \t"))
+	}
+}
+
+func (g *goWriter) typeDecl(t *Type) {
+	g.printf("\n")
+	g.comment("", t.Name, firstNonEmpty(t.Description, "对应"+t.Source))
+	if t.Underlying != nil {
+		g.printf("type %s %s\n", t.Name, g.goType(t.Underlying))
+		return
+	}
+	g.printf("type %s struct {\n", t.Name)
+	for _, f := range t.Fields {
+		if f.Description != "" {
+			g.comment("\t", "", f.Description)
+		}
+		tag := f.Name
+		if !f.Required {
+			tag += ",omitempty"
+		}
+		g.printf("\t%s %s `json:%q`\n", exportedName(f.Name), g.goType(f.Type), tag)
+	}
+	g.printf("}\n")
+}
+
+// goType 返回类型对应的Go类型
+func (g *goWriter) goType(t *TypeRef) string {
+	switch t.Kind {
+	case KindString:
+		return "string"
+	case KindInteger:
+		if t.Format == "int32" {
+			return "int32"
+		}
+		return "int64"
+	case KindNumber:
+		if t.Format == "float" {
+			return "float32"
+		}
+		return "float64"
+	case KindBoolean:
+		return "bool"
+	case KindArray:
+		return "[]" + g.goType(t.Elem)
+	case KindMap:
+		return "map[string]" + g.goType(t.Elem)
+	case KindRef:
+		// 结构体可能引用自身或者较大, 统一使用指针
+		if g.structs[t.Ref] {
+			return "*" + t.Ref
+		}
+		return t.Ref
+	case KindFile:
+		return "*File"
+	}
+	return "any"
+}
+
+// paramType 返回查询参数和表单字段的Go类型, 可选参数使用指针以区分零值和未设置
+func (g *goWriter) paramType(p *Param) string {
+	t := g.goType(p.Type)
+	if p.Required || p.Type.Kind == KindArray || p.Type.Kind == KindFile {
+		return t
+	}
+	return "*" + t
+}
+
+// params 生成查询参数和表单的结构体
+func (g *goWriter) params(op *Operation) {
+	for _, group := range []struct {
+		suffix string
+		what   string
+		params []*Param
+	}{
+		{"Params", "查询参数", op.Query},
+		{"Form", "表单", op.Form},
+	} {
+		if len(group.params) == 0 {
+			continue
+		}
+		g.printf("\n// %s%s %s的%s\n", op.Name, group.suffix, op.Name, group.what)
+		g.printf("type %s%s struct {\n", op.Name, group.suffix)
+		for _, p := range group.params {
+			if p.Description != "" {
+				g.comment("\t", "", p.Description)
+			}
+			g.printf("\t%s %s\n", exportedName(p.Name), g.paramType(p))
+		}
+		g.printf("}\n")
+	}
+}
+
+func (g *goWriter) method(op *Operation) {
+	args := []string{"ctx context.Context"}
+	vars := make(map[string]string)
+	for _, p := range op.PathParams {
+		name := goArgName(p.Name)
+		vars[p.Name] = name
+		args = append(args, name+" "+g.goType(p.Type))
+	}
+	if len(op.Query) > 0 {
+		args = append(args, "params *"+op.Name+"Params")
+	}
+	if op.Body != nil {
+		args = append(args, "body "+g.goType(op.Body))
+	}
+	if len(op.Form) > 0 {
+		args = append(args, "form *"+op.Name+"Form")
+	}
+
+	var results string
+	switch op.Result {
+	case ResultJSON:
+		results = "(" + g.goType(op.ResultType) + ", error)"
+	case ResultStream:
+		g.imports["io"] = true
+		results = "(io.ReadCloser, error)"
+	default:
+		results = "error"
+	}
+
+	g.printf("\n")
+	g.comment("", op.Name, firstNonEmpty(op.Summary, op.Description))
+	if op.Summary != "" && op.Description != "" && op.Description != op.Summary {
+		g.printf("//\n")
+		g.comment("", "", op.Description)
+	}
+	if op.Result == ResultStream {
+		g.printf("//\n// 返回原始响应体, 调用方读取完毕后需要关闭\n")
+	}
+	g.printf("//\n// %s %s\n", op.Method, op.Path)
+	g.printf("func (c *Client) %s(%s) %s {\n", op.Name, strings.Join(args, ", "), results)
+	g.printf("\treq := &request{method: %q, path: %s}\n", op.Method, g.pathExpr(op.Path, vars))
+
+	if len(op.Query) > 0 {
+		g.imports["net/url"] = true
+		g.printf("\tif params != nil {\n\t\treq.query = url.Values{}\n")
+		for _, p := range op.Query {
+			g.setValue("req.query", "params", p)
+		}
+		g.printf("\t}\n")
+	}
+	if op.Body != nil {
+		g.printf("\treq.body = body\n")
+	}
+	if len(op.Form) > 0 {
+		g.imports["net/url"] = true
+		g.printf("\treq.form = &multipartForm{values: url.Values{}}\n")
+		g.printf("\tif form != nil {\n")
+		for _, p := range op.Form {
+			if p.Type.Kind == KindFile {
+				g.printf("\t\treq.form.addFile(%q, form.%s)\n", p.Name, exportedName(p.Name))
+				continue
+			}
+			g.setValue("req.form.values", "form", p)
+		}
+		g.printf("\t}\n")
+	}
+
+	switch op.Result {
+	case ResultJSON:
+		if op.ResultType.Kind == KindRef && g.structs[op.ResultType.Ref] {
+			g.printf("\tout := new(%s)\n", op.ResultType.Ref)
+			g.printf("\tif err := c.doJSON(ctx, req, out); err != nil {\n\t\treturn nil, err\n\t}\n")
+		} else {
+			g.printf("\tvar out %s\n", g.goType(op.ResultType))
+			g.printf("\tif err := c.doJSON(ctx, req, &out); err != nil {\n\t\treturn out, err\n\t}\n")
+		}
+		g.printf("\treturn out, nil\n")
+	case ResultStream:
+		g.printf("\treturn c.doStream(ctx, req)\n")
+	default:
+		g.printf("\treturn c.doJSON(ctx, req, nil)\n")
+	}
+	g.printf("}\n")
+}
+
+// setValue 生成将参数写入url.Values的代码
+func (g *goWriter) setValue(values, owner string, p *Param) {
+	field := owner + "." + exportedName(p.Name)
+	switch {
+	case p.Type.Kind == KindArray:
+		fn := "addCSV"
+		if p.Multi {
+			fn = "addMulti"
+		}
+		g.printf("\t\t%s(%s, %q, %s)\n", fn, values, p.Name, field)
+	case p.Required:
+		g.printf("\t\taddValue(%s, %q, %s)\n", values, p.Name, field)
+	default:
+		g.printf("\t\taddPtr(%s, %q, %s)\n", values, p.Name, field)
+	}
+}
+
+// pathExpr 生成拼接请求路径的表达式, 路径参数经过转义
+func (g *goWriter) pathExpr(path string, vars map[string]string) string {
+	var parts []string
+	var literal strings.Builder
+	for i := 0; i < len(path); i++ {
+		if path[i] != '{' {
+			literal.WriteByte(path[i])
+			continue
+		}
+		end := strings.IndexByte(path[i:], '}')
+		if end < 0 {
+			literal.WriteString(path[i:])
+			break
+		}
+		if literal.Len() > 0 {
+			parts = append(parts, strconv.Quote(literal.String()))
+			literal.Reset()
+		}
+		parts = append(parts, "pathValue("+vars[path[i+1:i+end]]+")")
+		i += end
+	}
+	if literal.Len() > 0 {
+		parts = append(parts, strconv.Quote(literal.String()))
+	}
+	return strings.Join(parts, " + ")
+}
+
+// goArgNameReserved 生成的方法中已经使用的变量名
+var goArgNameReserved = map[string]bool{
+	"ctx": true, "c": true, "req": true, "out": true, "params": true, "body": true, "form": true,
+}
+
+// goArgName 将路径参数名转换为方法参数名, 如colony_num转换为colonyNum
+func goArgName(name string) string {
+	words := splitWords(name)
+	if len(words) == 0 {
+		return "param"
+	}
+	arg := strings.ToLower(words[0]) + exportedName(strings.Join(words[1:], "_"))
+	if token.IsKeyword(arg) || goArgNameReserved[arg] || !token.IsIdentifier(arg) {
+		arg += "Param"
+	}
+	return arg
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
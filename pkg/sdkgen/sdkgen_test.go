@@ -0,0 +1,181 @@
+package sdkgen
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "更新golden文件")
+
+func loadTestAPI(t *testing.T) *API {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", "swagger.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	api, err := Load(data, "/api/v1/")
+	if err != nil {
+		t.Fatalf("解析文档失败: %v", err)
+	}
+	return api
+}
+
+// checkGolden 比较生成的内容与golden文件, 使用-update参数运行时更新golden文件
+func checkGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("读取golden文件失败, 使用-update生成: %v", err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("生成的内容与%s不一致, 确认后使用-update更新\n%s", path, firstDiff(string(want), string(got)))
+	}
+}
+
+func firstDiff(want, got string) string {
+	wl, gl := strings.Split(want, "\n"), strings.Split(got, "\n")
+	for i := 0; i < len(wl) || i < len(gl); i++ {
+		var w, g string
+		if i < len(wl) {
+			w = wl[i]
+		}
+		if i < len(gl) {
+			g = gl[i]
+		}
+		if w != g {
+			return fmt.Sprintf("第%d行:\n- %s\n+ %s", i+1, w, g)
+		}
+	}
+	return ""
+}
+
+func TestLoad(t *testing.T) {
+	api := loadTestAPI(t)
+
+	var names []string
+	for _, op := range api.Operations {
+		names = append(names, op.Name)
+	}
+	want := "ListDemoItem,CreateDemoItem,GetDemoItem,RemoveItem,GetDemoLogStream,GetDemoFile,CreateDemoFile"
+	if got := strings.Join(names, ","); got != want {
+		t.Errorf("方法名 = %s, 期望 %s", got, want)
+	}
+
+	for _, tp := range api.Types {
+		if tp.Source == "demo.Unused" {
+			t.Error("前缀之外的接口引用的定义不应该生成")
+		}
+		if tp.Source == "errors.Error" {
+			t.Error("只在错误响应中引用的定义不应该生成")
+		}
+	}
+}
+
+func TestLoadDuplicateName(t *testing.T) {
+	doc := `{"swagger": "2.0", "paths": {
+		"/api/v1/a/x": {"post": {"responses": {}}},
+		"/api/v1/a/{id}/x": {"post": {"parameters": [{"name": "id", "in": "path", "type": "integer", "required": true}], "responses": {}}}
+	}}`
+	if _, err := Load([]byte(doc), "/api/v1/"); err == nil || !strings.Contains(err.Error(), "CreateAX") {
+		t.Errorf("方法名重复时应返回错误, 实际: %v", err)
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	cases := map[string]string{
+		"版本":    `{"swagger": "3.0", "paths": {}}`,
+		"路径参数":  `{"swagger": "2.0", "paths": {"/api/v1/a/{id}": {"get": {"responses": {}}}}}`,
+		"引用不存在": `{"swagger": "2.0", "paths": {"/api/v1/a": {"get": {"responses": {"200": {"schema": {"$ref": "#/definitions/x.Y"}}}}}}}`,
+	}
+	for name, doc := range cases {
+		if _, err := Load([]byte(doc), "/api/v1/"); err == nil {
+			t.Errorf("%s: 应返回错误", name)
+		}
+	}
+}
+
+func TestGenerateGo(t *testing.T) {
+	src, err := GenerateGo(loadTestAPI(t), "client")
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkGolden(t, "client_gen.go.golden", src)
+}
+
+func TestGenerateTS(t *testing.T) {
+	src, err := GenerateTS(loadTestAPI(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkGolden(t, "client.ts.golden", src)
+}
+
+func TestNames(t *testing.T) {
+	cases := []struct {
+		fn       func(string) string
+		in, want string
+	}{
+		{TypeName, "common.Pag-customer_ApiStandardOut", "CommonPagCustomerApiStandardOut"},
+		{exportedName, "api_ids", "APIIDs"},
+		{exportedName, "xcounter_id", "XcounterID"},
+		{goArgName, "colony_num", "colonyNum"},
+		{goArgName, "id", "id"},
+		{goArgName, "type", "typeParam"},
+		{goArgName, "body", "bodyParam"},
+	}
+	for _, c := range cases {
+		if got := c.fn(c.in); got != c.want {
+			t.Errorf("%s => %s, 期望 %s", c.in, got, c.want)
+		}
+	}
+}
+
+func TestDiffRouters(t *testing.T) {
+	dir := t.TempDir()
+	src := `package demo
+
+// @Router /api/v1/demo/item [get]
+func ListItem() {}
+
+// @Router  /api/v1/demo/item/{id}  [DELETE]
+func DeleteItem() {}
+
+// @Router /api/v1/demo/item [get]
+func ListItemAgain() {}
+`
+	if err := os.WriteFile(filepath.Join(dir, "demo.go"), []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	annotated, err := ScanRouters(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(annotated, ","); got != "DELETE /api/v1/demo/item/{id},GET /api/v1/demo/item" {
+		t.Errorf("注释中的接口 = %s", got)
+	}
+
+	documented, err := DocRouters([]byte(`{"swagger": "2.0", "paths": {
+		"/api/v1/demo/item": {"get": {}, "post": {}}
+	}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	missing, extra := DiffRouters(annotated, documented)
+	if strings.Join(missing, ",") != "DELETE /api/v1/demo/item/{id}" {
+		t.Errorf("文档缺少的接口 = %v", missing)
+	}
+	if strings.Join(extra, ",") != "POST /api/v1/demo/item" {
+		t.Errorf("文档多余的接口 = %v", extra)
+	}
+}
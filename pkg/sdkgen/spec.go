@@ -0,0 +1,561 @@
+// Package sdkgen 根据swagger 2.0文档生成接口客户端代码
+//
+// 文档中的定义生成为请求和响应类型, 指定前缀下的接口生成为客户端方法, 目前支持Go和TypeScript.
+// 接口没有operationId时按请求方法和路径生成方法名, 如GET /api/v1/customer/user/{id}生成为GetCustomerUser
+package sdkgen
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// 类型种类
+const (
+	KindString  = "string"
+	KindInteger = "integer"
+	KindNumber  = "number"
+	KindBoolean = "boolean"
+	KindArray   = "array"
+	KindMap     = "map"
+	KindAny     = "any"
+	KindRef     = "ref"
+	KindFile    = "file"
+)
+
+// 接口响应种类
+const (
+	ResultNone   = ""       // 没有响应内容
+	ResultJSON   = "json"   // JSON响应, 解析为Result类型
+	ResultStream = "stream" // 文件下载、日志流等原始响应
+)
+
+// API 生成代码使用的接口描述
+type API struct {
+	Types      []*Type      // 按名称排序
+	Operations []*Operation // 按路径和请求方法排序
+}
+
+// Type 文档定义生成的命名类型
+type Type struct {
+	Name        string // 类型名, 如customer.ApiReply生成为CustomerApiReply
+	Source      string // 文档中的定义名
+	Description string
+	Fields      []*Field // 对象类型的字段, 按JSON字段名排序
+	Underlying  *TypeRef // 非对象类型的底层类型
+}
+
+// Field 对象类型的字段
+type Field struct {
+	Name        string // JSON字段名
+	Description string
+	Type        *TypeRef
+	Required    bool
+}
+
+// TypeRef 字段、参数和响应的类型
+type TypeRef struct {
+	Kind   string
+	Format string   // 文档中的格式, 如int32
+	Ref    string   // KindRef时为引用的类型名
+	Elem   *TypeRef // KindArray和KindMap的元素类型
+	Enum   []string // 字符串枚举值
+}
+
+// Param 接口参数
+type Param struct {
+	Name        string
+	Description string
+	Type        *TypeRef
+	Required    bool
+	Multi       bool // 数组查询参数是否按多个同名参数传递, 否则以逗号连接
+}
+
+// Operation 接口
+type Operation struct {
+	Name        string // 方法名, 如ListCustomerApi
+	Method      string // 请求方法
+	Path        string // 请求路径, 路径参数为{name}形式
+	Summary     string
+	Description string
+	PathParams  []*Param // 按在路径中出现的顺序
+	Query       []*Param
+	Form        []*Param // multipart/form-data表单字段
+	Body        *TypeRef // JSON请求体
+	Result      string   // 响应种类
+	ResultType  *TypeRef // ResultJSON时的响应类型
+}
+
+// swagger 2.0文档中生成代码需要的部分
+type swaggerDoc struct {
+	Swagger     string                                 `json:"swagger"`
+	Paths       map[string]map[string]swaggerOperation `json:"paths"`
+	Definitions map[string]*swaggerSchema              `json:"definitions"`
+}
+
+type swaggerOperation struct {
+	OperationID string                     `json:"operationId"`
+	Summary     string                     `json:"summary"`
+	Description string                     `json:"description"`
+	Produces    []string                   `json:"produces"`
+	Parameters  []swaggerParameter         `json:"parameters"`
+	Responses   map[string]swaggerResponse `json:"responses"`
+}
+
+type swaggerParameter struct {
+	Name             string         `json:"name"`
+	In               string         `json:"in"`
+	Description      string         `json:"description"`
+	Required         bool           `json:"required"`
+	Type             string         `json:"type"`
+	Format           string         `json:"format"`
+	Items            *swaggerSchema `json:"items"`
+	Enum             []any          `json:"enum"`
+	CollectionFormat string         `json:"collectionFormat"`
+	Schema           *swaggerSchema `json:"schema"`
+}
+
+type swaggerResponse struct {
+	Description string         `json:"description"`
+	Schema      *swaggerSchema `json:"schema"`
+}
+
+type swaggerSchema struct {
+	Ref                  string                    `json:"$ref"`
+	Type                 string                    `json:"type"`
+	Format               string                    `json:"format"`
+	Description          string                    `json:"description"`
+	Items                *swaggerSchema            `json:"items"`
+	AllOf                []*swaggerSchema          `json:"allOf"`
+	Properties           map[string]*swaggerSchema `json:"properties"`
+	AdditionalProperties json.RawMessage           `json:"additionalProperties"`
+	Required             []string                  `json:"required"`
+	Enum                 []any                     `json:"enum"`
+}
+
+// methodOrder 同一路径下接口的排列顺序
+var methodOrder = []string{
+	http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete,
+}
+
+// Load 解析swagger 2.0文档, 只保留路径以prefix开头的接口, 以及这些接口直接或间接引用的定义
+func Load(data []byte, prefix string) (*API, error) {
+	var doc swaggerDoc
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("解析swagger文档失败: %w", err)
+	}
+	if doc.Swagger != "2.0" {
+		return nil, fmt.Errorf("不支持的swagger版本: %q", doc.Swagger)
+	}
+
+	l := &loader{doc: &doc, used: make(map[string]bool)}
+	api := &API{}
+	names := make(map[string]string)
+	for path, ops := range doc.Paths {
+		if !strings.HasPrefix(path, prefix) {
+			continue
+		}
+		for method, sop := range ops {
+			method = strings.ToUpper(method)
+			if !slices.Contains(methodOrder, method) {
+				continue
+			}
+			op, err := l.operation(method, path, prefix, sop)
+			if err != nil {
+				return nil, fmt.Errorf("%s %s: %w", method, path, err)
+			}
+			if prev, ok := names[op.Name]; ok {
+				return nil, fmt.Errorf("%s %s与%s的方法名都是%s, 请使用@ID指定operationId", method, path, prev, op.Name)
+			}
+			names[op.Name] = method + " " + path
+			api.Operations = append(api.Operations, op)
+		}
+	}
+	sort.Slice(api.Operations, func(i, j int) bool {
+		a, b := api.Operations[i], api.Operations[j]
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		return slices.Index(methodOrder, a.Method) < slices.Index(methodOrder, b.Method)
+	})
+
+	// 引用的定义中可能继续引用其他定义, 直到没有新的定义
+	done := make(map[string]bool)
+	typeNames := make(map[string]string)
+	for {
+		var pending []string
+		for source := range l.used {
+			if !done[source] {
+				pending = append(pending, source)
+			}
+		}
+		if len(pending) == 0 {
+			break
+		}
+		sort.Strings(pending)
+		for _, source := range pending {
+			done[source] = true
+			t, err := l.definition(source)
+			if err != nil {
+				return nil, fmt.Errorf("定义%s: %w", source, err)
+			}
+			if prev, ok := typeNames[t.Name]; ok {
+				return nil, fmt.Errorf("定义%s与%s的类型名都是%s", source, prev, t.Name)
+			}
+			typeNames[t.Name] = source
+			api.Types = append(api.Types, t)
+		}
+	}
+	sort.Slice(api.Types, func(i, j int) bool { return api.Types[i].Name < api.Types[j].Name })
+	return api, nil
+}
+
+type loader struct {
+	doc  *swaggerDoc
+	used map[string]bool // 引用的定义名
+}
+
+func (l *loader) operation(method, path, prefix string, sop swaggerOperation) (*Operation, error) {
+	op := &Operation{
+		Method:      method,
+		Path:        path,
+		Summary:     strings.TrimSpace(sop.Summary),
+		Description: strings.TrimSpace(sop.Description),
+	}
+
+	byName := make(map[string]*Param)
+	for _, sp := range sop.Parameters {
+		switch sp.In {
+		case "path", "query", "formData":
+			t, err := l.paramType(sp)
+			if err != nil {
+				return nil, fmt.Errorf("参数%s: %w", sp.Name, err)
+			}
+			p := &Param{
+				Name:        sp.Name,
+				Description: strings.TrimSpace(sp.Description),
+				Type:        t,
+				Required:    sp.Required || sp.In == "path",
+				Multi:       sp.CollectionFormat == "multi",
+			}
+			switch sp.In {
+			case "path":
+				byName[sp.Name] = p
+			case "query":
+				op.Query = append(op.Query, p)
+			default:
+				op.Form = append(op.Form, p)
+			}
+		case "body":
+			t, err := l.schemaType(sp.Schema)
+			if err != nil {
+				return nil, fmt.Errorf("请求体: %w", err)
+			}
+			op.Body = t
+		}
+	}
+	for _, name := range pathParamNames(path) {
+		p, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("路径参数%s没有定义", name)
+		}
+		op.PathParams = append(op.PathParams, p)
+	}
+	if op.Body != nil && len(op.Form) > 0 {
+		return nil, fmt.Errorf("不能同时包含JSON请求体和表单参数")
+	}
+
+	if err := l.result(op, sop); err != nil {
+		return nil, err
+	}
+
+	if sop.OperationID != "" {
+		op.Name = exportedName(sop.OperationID)
+	} else {
+		op.Name = operationName(op, prefix)
+	}
+	return op, nil
+}
+
+// result 按最小的2xx响应确定响应种类
+func (l *loader) result(op *Operation, sop swaggerOperation) error {
+	var codes []string
+	for code := range sop.Responses {
+		if strings.HasPrefix(code, "2") {
+			codes = append(codes, code)
+		}
+	}
+	if len(codes) == 0 {
+		return nil
+	}
+	sort.Strings(codes)
+	schema := sop.Responses[codes[0]].Schema
+	if schema != nil && (schema.Type == "file" || schema.Type == "string") {
+		op.Result = ResultStream
+		return nil
+	}
+	if schema != nil {
+		t, err := l.schemaType(schema)
+		if err != nil {
+			return fmt.Errorf("响应: %w", err)
+		}
+		op.Result, op.ResultType = ResultJSON, t
+		return nil
+	}
+	for _, p := range sop.Produces {
+		if !strings.Contains(p, "json") {
+			op.Result = ResultStream
+			return nil
+		}
+	}
+	return nil
+}
+
+func (l *loader) paramType(sp swaggerParameter) (*TypeRef, error) {
+	switch sp.Type {
+	case "file":
+		return &TypeRef{Kind: KindFile}, nil
+	case "array":
+		if sp.Items == nil {
+			return nil, fmt.Errorf("数组参数缺少items")
+		}
+		elem, err := l.schemaType(sp.Items)
+		if err != nil {
+			return nil, err
+		}
+		return &TypeRef{Kind: KindArray, Elem: elem}, nil
+	}
+	return l.schemaType(&swaggerSchema{Type: sp.Type, Format: sp.Format, Enum: sp.Enum})
+}
+
+// schemaType 将文档中的类型转换为TypeRef, 引用的定义记录到used中
+func (l *loader) schemaType(s *swaggerSchema) (*TypeRef, error) {
+	if s == nil {
+		return &TypeRef{Kind: KindAny}, nil
+	}
+	if s.Ref != "" {
+		source, ok := strings.CutPrefix(s.Ref, "#/definitions/")
+		if !ok {
+			return nil, fmt.Errorf("不支持的引用: %s", s.Ref)
+		}
+		if _, ok := l.doc.Definitions[source]; !ok {
+			return nil, fmt.Errorf("引用的定义不存在: %s", source)
+		}
+		l.used[source] = true
+		return &TypeRef{Kind: KindRef, Ref: TypeName(source)}, nil
+	}
+	// swag对带注释的引用字段使用只有一个元素的allOf
+	if len(s.AllOf) == 1 {
+		return l.schemaType(s.AllOf[0])
+	}
+	if len(s.AllOf) > 1 {
+		return nil, fmt.Errorf("不支持多个元素的allOf")
+	}
+
+	switch s.Type {
+	case "string":
+		return &TypeRef{Kind: KindString, Format: s.Format, Enum: enumStrings(s.Enum)}, nil
+	case "integer":
+		return &TypeRef{Kind: KindInteger, Format: s.Format}, nil
+	case "number":
+		return &TypeRef{Kind: KindNumber, Format: s.Format}, nil
+	case "boolean":
+		return &TypeRef{Kind: KindBoolean}, nil
+	case "array":
+		elem, err := l.schemaType(s.Items)
+		if err != nil {
+			return nil, err
+		}
+		return &TypeRef{Kind: KindArray, Elem: elem}, nil
+	case "object", "":
+		if len(s.Properties) > 0 {
+			return nil, fmt.Errorf("不支持匿名对象, 请使用命名的结构体")
+		}
+		elem := &TypeRef{Kind: KindAny}
+		if ap := strings.TrimSpace(string(s.AdditionalProperties)); ap != "" && ap != "{}" && ap != "true" && ap != "false" {
+			var as swaggerSchema
+			if err := json.Unmarshal(s.AdditionalProperties, &as); err != nil {
+				return nil, err
+			}
+			t, err := l.schemaType(&as)
+			if err != nil {
+				return nil, err
+			}
+			elem = t
+		}
+		if s.Type == "" && len(s.AdditionalProperties) == 0 {
+			return elem, nil
+		}
+		return &TypeRef{Kind: KindMap, Elem: elem}, nil
+	}
+	return nil, fmt.Errorf("不支持的类型: %s", s.Type)
+}
+
+func (l *loader) definition(source string) (*Type, error) {
+	s := l.doc.Definitions[source]
+	t := &Type{
+		Name:        TypeName(source),
+		Source:      source,
+		Description: strings.TrimSpace(s.Description),
+	}
+	if len(s.Properties) == 0 || len(s.AllOf) > 0 {
+		u, err := l.schemaType(s)
+		if err != nil {
+			return nil, err
+		}
+		t.Underlying = u
+		return t, nil
+	}
+
+	names := make([]string, 0, len(s.Properties))
+	for name := range s.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		prop := s.Properties[name]
+		ft, err := l.schemaType(prop)
+		if err != nil {
+			return nil, fmt.Errorf("字段%s: %w", name, err)
+		}
+		t.Fields = append(t.Fields, &Field{
+			Name:        name,
+			Description: strings.TrimSpace(prop.Description),
+			Type:        ft,
+			Required:    slices.Contains(s.Required, name),
+		})
+	}
+	return t, nil
+}
+
+func enumStrings(values []any) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		out = append(out, fmt.Sprint(v))
+	}
+	return out
+}
+
+// pathParamNames 按出现顺序返回路径中的参数名
+func pathParamNames(path string) []string {
+	var names []string
+	for seg := range strings.SplitSeq(path, "/") {
+		if name, ok := strings.CutPrefix(seg, "{"); ok {
+			names = append(names, strings.TrimSuffix(name, "}"))
+		}
+	}
+	return names
+}
+
+// operationName 按请求方法和路径生成方法名
+//
+// 路径中去掉前缀和路径参数后的各段组成资源名; GET接口返回分页或列表响应时为List, 其余按请求方法分别为
+// Get、Create、Update、Patch、Delete
+func operationName(op *Operation, prefix string) string {
+	var resource strings.Builder
+	for seg := range strings.SplitSeq(strings.TrimPrefix(op.Path, prefix), "/") {
+		if seg == "" || strings.HasPrefix(seg, "{") {
+			continue
+		}
+		resource.WriteString(exportedName(seg))
+	}
+
+	var verb string
+	switch op.Method {
+	case http.MethodGet:
+		verb = "Get"
+		if op.ResultType != nil && op.ResultType.Kind == KindRef && isListReply(op.ResultType.Ref) {
+			verb = "List"
+		}
+	case http.MethodPost:
+		verb = "Create"
+	case http.MethodPut:
+		verb = "Update"
+	case http.MethodPatch:
+		verb = "Patch"
+	case http.MethodDelete:
+		verb = "Delete"
+	}
+	return verb + resource.String()
+}
+
+// isListReply 按类型名判断是否为分页或列表响应, 如CustomerPagApiReply、JobsListLableReply
+func isListReply(name string) bool {
+	// 去掉模块名, 模块名是类型名的第一个单词
+	for i, r := range name {
+		if i > 0 && unicode.IsUpper(r) {
+			rest := name[i:]
+			return strings.HasPrefix(rest, "Pag") || strings.HasPrefix(rest, "List")
+		}
+	}
+	return false
+}
+
+// TypeName 将文档中的定义名转换为类型名, 如common.Pag-customer_ApiStandardOut转换为CommonPagCustomerApiStandardOut
+func TypeName(source string) string {
+	var b strings.Builder
+	for _, part := range splitWords(source) {
+		b.WriteString(upperFirst(part))
+	}
+	return b.String()
+}
+
+// exportedName 将蛇形或短横线分隔的名称转换为首字母大写的驼峰名称, 常见缩写全部大写
+func exportedName(name string) string {
+	var b strings.Builder
+	for _, part := range splitWords(name) {
+		if initialism, ok := initialisms[strings.ToLower(part)]; ok {
+			b.WriteString(initialism)
+			continue
+		}
+		b.WriteString(upperFirst(part))
+	}
+	return b.String()
+}
+
+// initialisms 生成Go字段名时全部大写的缩写
+var initialisms = map[string]string{
+	"api":  "API",
+	"id":   "ID",
+	"ids":  "IDs",
+	"ip":   "IP",
+	"url":  "URL",
+	"uri":  "URI",
+	"ssh":  "SSH",
+	"http": "HTTP",
+	"json": "JSON",
+	"uuid": "UUID",
+	"cpu":  "CPU",
+	"os":   "OS",
+}
+
+func splitWords(name string) []string {
+	return strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func upperFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	r := []rune(s)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	r := []rune(s)
+	r[0] = unicode.ToLower(r[0])
+	return string(r)
+}
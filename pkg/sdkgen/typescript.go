@@ -0,0 +1,330 @@
+package sdkgen
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// tsIdentifier 可以不加引号作为属性名的名称
+var tsIdentifier = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// tsRuntime TypeScript客户端的运行时, 与生成的类型和方法输出在同一个文件中, 使用方不需要额外依赖
+const tsRuntime = `/** 上传的文件, name为空时使用Blob自身的文件名 */
+export interface UploadFile {
+  name?: string;
+  content: Blob;
+}
+
+/** 客户端选项 */
+export interface ClientOptions {
+  /** 服务地址, 如https://artweb.example.com, 为空时使用当前页面的地址 */
+  baseURL?: string;
+  /** 访问令牌, 作为Authorization请求头发送 */
+  token?: string | (() => string | undefined);
+  /** 附加的请求头 */
+  headers?: Record<string, string>;
+  /** 自定义fetch实现 */
+  fetch?: typeof fetch;
+}
+
+/** 接口返回的错误 */
+export class ApiError extends Error {
+  readonly status: number;
+  readonly reason?: string;
+  readonly data?: Record<string, unknown>;
+
+  constructor(status: number, body?: { reason?: string; msg?: string; data?: Record<string, unknown> }) {
+    super(body?.msg || ` + "`HTTP ${status}`" + `);
+    this.name = "ApiError";
+    this.status = status;
+    this.reason = body?.reason;
+    this.data = body?.data;
+  }
+}
+
+type QueryValue = string | number | boolean | Array<string | number | boolean> | null | undefined;
+
+interface RequestOptions {
+  query?: Record<string, QueryValue>;
+  multi?: string[];
+  body?: unknown;
+  form?: Record<string, unknown>;
+  raw?: boolean;
+}
+
+export class Client {
+  private readonly baseURL: string;
+  private readonly options: ClientOptions;
+  private readonly fetchFn: typeof fetch;
+
+  constructor(options: ClientOptions = {}) {
+    this.baseURL = (options.baseURL ?? "").replace(/\/+$/, "");
+    this.options = options;
+    this.fetchFn = options.fetch ?? fetch.bind(globalThis);
+  }
+
+  private async request<T>(method: string, path: string, opts: RequestOptions = {}): Promise<T> {
+    const search = new URLSearchParams();
+    for (const [key, value] of Object.entries(opts.query ?? {})) {
+      if (value === undefined || value === null) {
+        continue;
+      }
+      if (Array.isArray(value)) {
+        if (opts.multi?.includes(key)) {
+          value.forEach((v) => search.append(key, String(v)));
+        } else if (value.length > 0) {
+          search.set(key, value.join(","));
+        }
+        continue;
+      }
+      search.set(key, String(value));
+    }
+    const qs = search.toString();
+
+    const headers: Record<string, string> = { Accept: "application/json", ...this.options.headers };
+    const token = typeof this.options.token === "function" ? this.options.token() : this.options.token;
+    if (token) {
+      headers["Authorization"] = token;
+    }
+    let body: BodyInit | undefined;
+    if (opts.form) {
+      const fd = new FormData();
+      for (const [key, value] of Object.entries(opts.form)) {
+        if (value === undefined || value === null) {
+          continue;
+        }
+        if (typeof value === "object" && "content" in (value as UploadFile)) {
+          const file = value as UploadFile;
+          fd.append(key, file.content, file.name ?? (file.content instanceof File ? file.content.name : key));
+        } else {
+          fd.append(key, String(value));
+        }
+      }
+      body = fd;
+    } else if (opts.body !== undefined) {
+      headers["Content-Type"] = "application/json";
+      body = JSON.stringify(opts.body);
+    }
+
+    const resp = await this.fetchFn(this.baseURL + path + (qs ? "?" + qs : ""), { method, headers, body });
+    if (!resp.ok) {
+      let errBody: { reason?: string; msg?: string; data?: Record<string, unknown> } | undefined;
+      try {
+        errBody = await resp.json();
+      } catch {
+        errBody = undefined;
+      }
+      throw new ApiError(resp.status, errBody);
+    }
+    if (opts.raw) {
+      return resp as unknown as T;
+    }
+    const text = await resp.text();
+    return (text ? JSON.parse(text) : undefined) as T;
+  }
+`
+
+// GenerateTS 生成TypeScript客户端, 包含类型定义、运行时和Client类的接口方法
+func GenerateTS(api *API) ([]byte, error) {
+	w := &tsWriter{}
+	w.printf("// %s\n/* eslint-disable */\n", Header)
+	for _, t := range api.Types {
+		w.typeDecl(t)
+	}
+	for _, op := range api.Operations {
+		w.params(op)
+	}
+	w.printf("\n%s", tsRuntime)
+	for _, op := range api.Operations {
+		w.method(op)
+	}
+	w.printf("}\n")
+	return w.buf.Bytes(), nil
+}
+
+type tsWriter struct {
+	buf bytes.Buffer
+}
+
+func (w *tsWriter) printf(format string, args ...any) {
+	fmt.Fprintf(&w.buf, format, args...)
+}
+
+// doc 输出JSDoc注释
+func (w *tsWriter) doc(indent string, lines ...string) {
+	var text []string
+	for _, l := range lines {
+		for line := range strings.SplitSeq(strings.TrimSpace(l), "\n") {
+			text = append(text, strings.ReplaceAll(strings.TrimRight(line, " \t"), "*/", "*\\/"))
+		}
+	}
+	for len(text) > 0 && text[len(text)-1] == "" {
+		text = text[:len(text)-1]
+	}
+	switch len(text) {
+	case 0:
+		return
+	case 1:
+		if text[0] == "" {
+			return
+		}
+		w.printf("%s/** %s */\n", indent, text[0])
+		return
+	}
+	w.printf("%s/**\n", indent)
+	for _, line := range text {
+		w.printf("%s *%s\n", indent, strings.TrimRight(" "+line, " "))
+	}
+	w.printf("%s */\n", indent)
+}
+
+func (w *tsWriter) typeDecl(t *Type) {
+	w.printf("\n")
+	w.doc("", firstNonEmpty(t.Description, t.Source))
+	if t.Underlying != nil {
+		w.printf("export type %s = %s;\n", t.Name, tsType(t.Underlying))
+		return
+	}
+	w.printf("export interface %s {\n", t.Name)
+	for _, f := range t.Fields {
+		w.doc("  ", f.Description)
+		opt := "?"
+		if f.Required {
+			opt = ""
+		}
+		w.printf("  %s%s: %s;\n", tsProperty(f.Name), opt, tsType(f.Type))
+	}
+	w.printf("}\n")
+}
+
+func (w *tsWriter) params(op *Operation) {
+	for _, group := range []struct {
+		suffix string
+		what   string
+		params []*Param
+	}{
+		{"Params", "查询参数", op.Query},
+		{"Form", "表单", op.Form},
+	} {
+		if len(group.params) == 0 {
+			continue
+		}
+		w.printf("\n/** %s的%s */\n", lowerFirst(op.Name), group.what)
+		w.printf("export interface %s%s {\n", op.Name, group.suffix)
+		for _, p := range group.params {
+			w.doc("  ", p.Description)
+			opt := "?"
+			if p.Required {
+				opt = ""
+			}
+			w.printf("  %s%s: %s;\n", tsProperty(p.Name), opt, tsType(p.Type))
+		}
+		w.printf("}\n")
+	}
+}
+
+func (w *tsWriter) method(op *Operation) {
+	var args []string
+	path := op.Path
+	for _, p := range op.PathParams {
+		name := goArgName(p.Name)
+		args = append(args, name+": "+tsType(p.Type))
+		path = strings.ReplaceAll(path, "{"+p.Name+"}", "${encodeURIComponent(String("+name+"))}")
+	}
+	var opts []string
+	if len(op.Query) > 0 {
+		required := false
+		var multi []string
+		for _, p := range op.Query {
+			required = required || p.Required
+			if p.Multi {
+				multi = append(multi, strconv.Quote(p.Name))
+			}
+		}
+		if required {
+			args = append(args, "params: "+op.Name+"Params")
+		} else {
+			args = append(args, "params?: "+op.Name+"Params")
+		}
+		opts = append(opts, "query: params as unknown as Record<string, QueryValue> | undefined")
+		if len(multi) > 0 {
+			opts = append(opts, "multi: ["+strings.Join(multi, ", ")+"]")
+		}
+	}
+	if op.Body != nil {
+		args = append(args, "body: "+tsType(op.Body))
+		opts = append(opts, "body")
+	}
+	if len(op.Form) > 0 {
+		args = append(args, "form: "+op.Name+"Form")
+		opts = append(opts, "form: form as unknown as Record<string, unknown>")
+	}
+
+	result := "void"
+	switch op.Result {
+	case ResultJSON:
+		result = tsType(op.ResultType)
+	case ResultStream:
+		result = "Response"
+		opts = append(opts, "raw: true")
+	}
+
+	w.printf("\n")
+	var lines []string
+	lines = append(lines, firstNonEmpty(op.Summary, op.Description))
+	if op.Summary != "" && op.Description != "" && op.Description != op.Summary {
+		lines = append(lines, "", op.Description)
+	}
+	if op.Result == ResultStream {
+		lines = append(lines, "", "返回原始响应, 由调用方读取响应体")
+	}
+	lines = append(lines, "", op.Method+" "+op.Path)
+	w.doc("  ", strings.Join(lines, "\n"))
+	w.printf("  %s(%s): Promise<%s> {\n", lowerFirst(op.Name), strings.Join(args, ", "), result)
+	call := fmt.Sprintf("this.request<%s>(%q, `%s`", result, op.Method, path)
+	if len(opts) > 0 {
+		call += ", { " + strings.Join(opts, ", ") + " }"
+	}
+	w.printf("    return %s);\n  }\n", call)
+}
+
+func tsType(t *TypeRef) string {
+	switch t.Kind {
+	case KindString:
+		if len(t.Enum) > 0 {
+			values := make([]string, len(t.Enum))
+			for i, v := range t.Enum {
+				values[i] = strconv.Quote(v)
+			}
+			return strings.Join(values, " | ")
+		}
+		return "string"
+	case KindInteger, KindNumber:
+		return "number"
+	case KindBoolean:
+		return "boolean"
+	case KindArray:
+		elem := tsType(t.Elem)
+		if strings.Contains(elem, " | ") {
+			elem = "(" + elem + ")"
+		}
+		return elem + "[]"
+	case KindMap:
+		return "Record<string, " + tsType(t.Elem) + ">"
+	case KindRef:
+		return t.Ref
+	case KindFile:
+		return "UploadFile"
+	}
+	return "unknown"
+}
+
+func tsProperty(name string) string {
+	if tsIdentifier.MatchString(name) {
+		return name
+	}
+	return strconv.Quote(name)
+}
@@ -0,0 +1,139 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+
+	"emperror.dev/errors"
+)
+
+// LocalStorage 本地磁盘存储
+type LocalStorage struct {
+	root string
+}
+
+func NewLocalStorage(root string) *LocalStorage {
+	return &LocalStorage{root: root}
+}
+
+func (s *LocalStorage) Type() string {
+	return TypeLocal
+}
+
+// LocalPath 对象在本地磁盘的路径, key无效时返回空字符串
+func (s *LocalStorage) LocalPath(key string) string {
+	p, _ := s.path(key)
+	return p
+}
+
+func (s *LocalStorage) path(key string) (string, error) {
+	cleaned, err := cleanKey(key)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(s.root, filepath.FromSlash(cleaned)), nil
+}
+
+// Put 先写入同目录下的临时文件再重命名, 写入失败时不会留下不完整的文件
+func (s *LocalStorage) Put(ctx context.Context, key string, r io.Reader, size int64) error {
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+	dst, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return errors.WithMessagef(err, "创建目录失败，路径: %s", filepath.Dir(dst))
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(dst), "."+filepath.Base(dst)+".*")
+	if err != nil {
+		return errors.WithMessagef(err, "创建临时文件失败，路径: %s", dst)
+	}
+	tmpName := tmp.Name()
+	defer os.Remove(tmpName)
+
+	n, err := io.Copy(tmp, &contextReader{ctx: ctx, r: r})
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return errors.WithMessagef(err, "写入文件失败，路径: %s", dst)
+	}
+	if size >= 0 && n != size {
+		return errors.Errorf("写入文件长度不一致，期望: %d，实际: %d", size, n)
+	}
+	if err := os.Chmod(tmpName, 0o644); err != nil {
+		return errors.WithMessagef(err, "设置文件权限失败，路径: %s", dst)
+	}
+	if err := os.Rename(tmpName, dst); err != nil {
+		return errors.WithMessagef(err, "重命名文件失败，路径: %s", dst)
+	}
+	return nil
+}
+
+func (s *LocalStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+	p, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(p)
+	if os.IsNotExist(err) {
+		return nil, errors.WithStack(ErrNotExist)
+	}
+	if err != nil {
+		return nil, errors.WithMessagef(err, "打开文件失败，路径: %s", p)
+	}
+	return f, nil
+}
+
+func (s *LocalStorage) Stat(ctx context.Context, key string) (*ObjectInfo, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+	p, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(p)
+	if os.IsNotExist(err) {
+		return nil, errors.WithStack(ErrNotExist)
+	}
+	if err != nil {
+		return nil, errors.WithMessagef(err, "获取文件状态失败，路径: %s", p)
+	}
+	return &ObjectInfo{Key: key, Size: info.Size(), ModTime: info.ModTime()}, nil
+}
+
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+	p, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+		return errors.WithMessagef(err, "删除文件失败，路径: %s", p)
+	}
+	return nil
+}
+
+// contextReader 上下文取消后停止读取
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (r *contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.r.Read(p)
+}
@@ -0,0 +1,198 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"os"
+	"path"
+	"strconv"
+	"sync"
+	"time"
+
+	"emperror.dev/errors"
+	"github.com/pkg/sftp"
+)
+
+// SFTPDialer 建立SFTP连接, 返回的Closer用于关闭底层连接
+type SFTPDialer func(ctx context.Context) (*sftp.Client, io.Closer, error)
+
+// SFTPStorage SFTP存储
+//
+// 复用同一个连接, 连接断开后在下一次操作时重新建立
+type SFTPStorage struct {
+	root   string
+	dial   SFTPDialer
+	mu     sync.Mutex
+	client *sftp.Client
+	closer io.Closer
+}
+
+func NewSFTPStorage(root string, dial SFTPDialer) *SFTPStorage {
+	return &SFTPStorage{
+		root: root,
+		dial: dial,
+	}
+}
+
+func (s *SFTPStorage) Type() string {
+	return TypeSFTP
+}
+
+// Close 关闭连接
+func (s *SFTPStorage) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.resetLocked(s.client)
+}
+
+func (s *SFTPStorage) getClient(ctx context.Context) (*sftp.Client, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.client != nil {
+		return s.client, nil
+	}
+	client, closer, err := s.dial(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.client, s.closer = client, closer
+	go func() {
+		// 连接断开后丢弃客户端
+		_ = client.Wait()
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		_ = s.resetLocked(client)
+	}()
+	return client, nil
+}
+
+func (s *SFTPStorage) resetLocked(client *sftp.Client) error {
+	if client == nil || s.client != client {
+		return nil
+	}
+	// 先关闭底层连接, SFTP客户端关闭时会等待读取结束
+	var err error
+	if s.closer != nil {
+		err = s.closer.Close()
+	}
+	if closeErr := s.client.Close(); err == nil {
+		err = closeErr
+	}
+	s.client, s.closer = nil, nil
+	return err
+}
+
+func (s *SFTPStorage) path(key string) (string, error) {
+	cleaned, err := cleanKey(key)
+	if err != nil {
+		return "", err
+	}
+	return path.Join(s.root, cleaned), nil
+}
+
+// Put 先写入临时文件再重命名, 写入失败时不会留下不完整的文件
+func (s *SFTPStorage) Put(ctx context.Context, key string, r io.Reader, size int64) error {
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+	dst, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	client, err := s.getClient(ctx)
+	if err != nil {
+		return err
+	}
+	if err := client.MkdirAll(path.Dir(dst)); err != nil {
+		return errors.WithMessagef(err, "创建远程目录失败，路径: %s", path.Dir(dst))
+	}
+
+	tmpName := path.Join(path.Dir(dst), "."+path.Base(dst)+"."+strconv.FormatInt(time.Now().UnixNano(), 36))
+	f, err := client.Create(tmpName)
+	if err != nil {
+		return errors.WithMessagef(err, "创建远程临时文件失败，路径: %s", tmpName)
+	}
+	n, err := f.ReadFrom(&contextReader{ctx: ctx, r: r})
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil && size >= 0 && n != size {
+		err = errors.Errorf("写入文件长度不一致，期望: %d，实际: %d", size, n)
+	}
+	if err != nil {
+		_ = client.Remove(tmpName)
+		return errors.WithMessagef(err, "写入远程文件失败，路径: %s", dst)
+	}
+
+	if err := client.PosixRename(tmpName, dst); err != nil {
+		// 服务端不支持posix-rename扩展时先删除再重命名
+		_ = client.Remove(dst)
+		if err := client.Rename(tmpName, dst); err != nil {
+			_ = client.Remove(tmpName)
+			return errors.WithMessagef(err, "重命名远程文件失败，路径: %s", dst)
+		}
+	}
+	return nil
+}
+
+func (s *SFTPStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+	p, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	client, err := s.getClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	f, err := client.Open(p)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, errors.WithStack(ErrNotExist)
+	}
+	if err != nil {
+		return nil, errors.WithMessagef(err, "打开远程文件失败，路径: %s", p)
+	}
+	return f, nil
+}
+
+func (s *SFTPStorage) Stat(ctx context.Context, key string) (*ObjectInfo, error) {
+	if err := checkContext(ctx); err != nil {
+		return nil, err
+	}
+	p, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	client, err := s.getClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	info, err := client.Stat(p)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, errors.WithStack(ErrNotExist)
+	}
+	if err != nil {
+		return nil, errors.WithMessagef(err, "获取远程文件状态失败，路径: %s", p)
+	}
+	return &ObjectInfo{Key: key, Size: info.Size(), ModTime: info.ModTime()}, nil
+}
+
+func (s *SFTPStorage) Delete(ctx context.Context, key string) error {
+	if err := checkContext(ctx); err != nil {
+		return err
+	}
+	p, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	client, err := s.getClient(ctx)
+	if err != nil {
+		return err
+	}
+	if err := client.Remove(p); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return errors.WithMessagef(err, "删除远程文件失败，路径: %s", p)
+	}
+	return nil
+}
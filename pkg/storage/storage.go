@@ -0,0 +1,93 @@
+// Package storage 文件存储抽象, 支持本地磁盘、SFTP和S3兼容的对象存储
+package storage
+
+import (
+	"context"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+)
+
+// 存储类型
+const (
+	TypeLocal = "local"
+	TypeSFTP  = "sftp"
+	TypeS3    = "s3"
+)
+
+var (
+	// ErrNotExist 对象不存在
+	ErrNotExist = errors.New("对象不存在")
+
+	// ErrPresignNotSupported 存储后端不支持预签名下载地址
+	ErrPresignNotSupported = errors.New("存储后端不支持预签名下载地址")
+)
+
+// ObjectInfo 对象信息
+type ObjectInfo struct {
+	Key     string
+	Size    int64
+	ModTime time.Time
+}
+
+// Storage 文件存储
+//
+// key为以/分隔的相对路径, 由调用方保证唯一
+type Storage interface {
+	// Type 存储类型
+	Type() string
+
+	// Put 写入对象, size为-1时表示长度未知
+	Put(ctx context.Context, key string, r io.Reader, size int64) error
+
+	// Get 读取对象, 对象不存在时返回ErrNotExist
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// Stat 获取对象信息, 对象不存在时返回ErrNotExist
+	Stat(ctx context.Context, key string) (*ObjectInfo, error)
+
+	// Delete 删除对象, 对象不存在时不返回错误
+	Delete(ctx context.Context, key string) error
+}
+
+// Presigner 支持生成预签名下载地址的存储
+type Presigner interface {
+	// PresignGet 生成预签名下载地址, filename不为空时下载时使用该文件名
+	PresignGet(ctx context.Context, key, filename string, expires time.Duration) (string, error)
+}
+
+// LocalPather 对象保存在本地磁盘的存储
+type LocalPather interface {
+	// LocalPath 对象在本地磁盘的路径
+	LocalPath(key string) string
+}
+
+// PresignGet 生成预签名下载地址, 存储后端不支持时返回ErrPresignNotSupported
+func PresignGet(ctx context.Context, s Storage, key, filename string, expires time.Duration) (string, error) {
+	p, ok := s.(Presigner)
+	if !ok {
+		return "", ErrPresignNotSupported
+	}
+	return p.PresignGet(ctx, key, filename, expires)
+}
+
+// cleanKey 校验并规范化对象key, 不允许跳出存储根目录
+func cleanKey(key string) (string, error) {
+	cleaned := path.Clean("/" + strings.ReplaceAll(key, "\\", "/"))
+	cleaned = strings.TrimPrefix(cleaned, "/")
+	if cleaned == "" || cleaned == "." {
+		return "", errors.Errorf("对象key无效: %q", key)
+	}
+	return cleaned, nil
+}
+
+// checkContext 检查上下文是否已取消
+func checkContext(ctx context.Context) error {
+	if ctx.Err() != nil {
+		return errors.WithMessage(ctx.Err(), "上下文已取消")
+	}
+	return nil
+}
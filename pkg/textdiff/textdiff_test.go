@@ -0,0 +1,55 @@
+package textdiff
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUnifiedEqual(t *testing.T) {
+	if got := Unified("a", "b", "x\ny\n", "x\ny\n", 3); got != "" {
+		t.Errorf("相同内容应返回空字符串, 实际: %q", got)
+	}
+}
+
+func TestUnified(t *testing.T) {
+	var oldLines, newLines []string
+	for i := 1; i <= 20; i++ {
+		line := "line" + string(rune('a'+i-1))
+		oldLines = append(oldLines, line)
+		newLines = append(newLines, line)
+	}
+	newLines[1] = "changed-b"
+	newLines = append(newLines[:15], newLines[16:]...)
+	newLines = append(newLines, "added")
+
+	got := Unified("old.conf", "new.conf", strings.Join(oldLines, "\n")+"\n", strings.Join(newLines, "\n")+"\n", 2)
+	want := `--- old.conf
++++ new.conf
+@@ -1,4 +1,4 @@
+ linea
+-lineb
++changed-b
+ linec
+ lined
+@@ -14,7 +14,7 @@
+ linen
+ lineo
+-linep
+ lineq
+ liner
+ lines
+ linet
++added
+`
+	if got != want {
+		t.Errorf("差异不正确:\n%s\n期望:\n%s", got, want)
+	}
+}
+
+func TestUnifiedEmpty(t *testing.T) {
+	got := Unified("old", "new", "", "a\nb\n", 3)
+	want := "--- old\n+++ new\n@@ -0,0 +1,2 @@\n+a\n+b\n"
+	if got != want {
+		t.Errorf("差异不正确: %q", got)
+	}
+}
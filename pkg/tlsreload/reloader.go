@@ -0,0 +1,178 @@
+// Package tlsreload 在不重启服务、不断开已有连接的情况下重新加载TLS证书
+//
+// Reloader通过tls.Config.GetCertificate为每次握手提供当前证书, 重新加载成功后
+// 新的握手使用新证书, 已建立的连接不受影响; 加载失败时继续使用原证书
+package tlsreload
+
+import (
+	"context"
+	"crypto/tls"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"emperror.dev/errors"
+)
+
+// Reloader 证书重新加载器
+type Reloader struct {
+	crtPath string
+	keyPath string
+
+	mu      sync.RWMutex
+	cert    *tls.Certificate
+	crtTime time.Time
+	keyTime time.Time
+}
+
+// New 加载证书和私钥, 返回证书重新加载器
+func New(crtPath, keyPath string) (*Reloader, error) {
+	r := &Reloader{crtPath: crtPath, keyPath: keyPath}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Reload 重新读取证书和私钥, 失败时保留原证书
+func (r *Reloader) Reload() error {
+	crtTime, keyTime, err := r.modTimes()
+	if err != nil {
+		return err
+	}
+	cert, err := tls.LoadX509KeyPair(r.crtPath, r.keyPath)
+	if err != nil {
+		return errors.WrapIfWithDetails(err, "加载TLS证书失败", "crt", r.crtPath, "key", r.keyPath)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cert = &cert
+	r.crtTime = crtTime
+	r.keyTime = keyTime
+	return nil
+}
+
+// GetCertificate 返回当前证书, 用作tls.Config.GetCertificate
+func (r *Reloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert, nil
+}
+
+// Certificate 返回当前证书
+func (r *Reloader) Certificate() *tls.Certificate {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert
+}
+
+// Changed 判断证书或私钥文件的修改时间是否与上次加载时不同
+func (r *Reloader) Changed() (bool, error) {
+	crtTime, keyTime, err := r.modTimes()
+	if err != nil {
+		return false, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return !crtTime.Equal(r.crtTime) || !keyTime.Equal(r.keyTime), nil
+}
+
+// Watch 按interval检查证书文件, 文件变化时重新加载, 直到ctx取消
+//
+// 每次重新加载后调用onReload, 加载失败时err不为nil;
+// 检查文件失败(例如证书正在被替换)时同样调用onReload, 下个周期再次检查
+func (r *Reloader) Watch(ctx context.Context, interval time.Duration, onReload func(err error)) {
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			changed, err := r.Changed()
+			if err == nil && !changed {
+				continue
+			}
+			if err == nil {
+				err = r.Reload()
+			}
+			if onReload != nil {
+				onReload(err)
+			}
+		}
+	}
+}
+
+func (r *Reloader) modTimes() (crtTime, keyTime time.Time, err error) {
+	crtInfo, err := os.Stat(r.crtPath)
+	if err != nil {
+		return crtTime, keyTime, errors.WrapIfWithDetails(err, "读取TLS证书文件信息失败", "path", r.crtPath)
+	}
+	keyInfo, err := os.Stat(r.keyPath)
+	if err != nil {
+		return crtTime, keyTime, errors.WrapIfWithDetails(err, "读取TLS私钥文件信息失败", "path", r.keyPath)
+	}
+	return crtInfo.ModTime(), keyInfo.ModTime(), nil
+}
+
+// ParseVersion 解析TLS版本, 支持1.0、1.1、1.2、1.3, 为空时返回TLS1.2
+func ParseVersion(version string) (uint16, error) {
+	switch strings.TrimPrefix(strings.ToLower(strings.TrimSpace(version)), "tls") {
+	case "":
+		return tls.VersionTLS12, nil
+	case "1.0", "10":
+		return tls.VersionTLS10, nil
+	case "1.1", "11":
+		return tls.VersionTLS11, nil
+	case "1.2", "12":
+		return tls.VersionTLS12, nil
+	case "1.3", "13":
+		return tls.VersionTLS13, nil
+	}
+	return 0, errors.NewWithDetails("不支持的TLS版本", "version", version)
+}
+
+// ParseCipherSuites 按名称解析加密套件, 名称为tls.CipherSuiteName返回的标准名称
+//
+// 只接受Go认为安全的套件, 为空时返回nil, 即使用Go的默认套件;
+// 加密套件只对TLS1.2及以下版本生效, TLS1.3的套件不可配置
+func ParseCipherSuites(names []string) ([]uint16, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	known := make(map[string]uint16)
+	for _, s := range tls.CipherSuites() {
+		known[s.Name] = s.ID
+	}
+	ids := make([]uint16, 0, len(names))
+	for _, name := range names {
+		id, ok := known[strings.TrimSpace(name)]
+		if !ok {
+			return nil, errors.NewWithDetails("不支持的TLS加密套件", "cipher_suite", name)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// TLSConfig 返回使用Reloader提供证书的TLS配置
+func (r *Reloader) TLSConfig(minVersion string, cipherSuites []string) (*tls.Config, error) {
+	version, err := ParseVersion(minVersion)
+	if err != nil {
+		return nil, err
+	}
+	suites, err := ParseCipherSuites(cipherSuites)
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		MinVersion:     version,
+		CipherSuites:   suites,
+		GetCertificate: r.GetCertificate,
+	}, nil
+}
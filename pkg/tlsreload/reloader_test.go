@@ -0,0 +1,184 @@
+package tlsreload
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeCert 生成自签名证书写入dir, 返回证书和私钥路径
+func writeCert(t *testing.T, dir, cn string, modTime time.Time) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	crtPath := filepath.Join(dir, "server.crt")
+	keyPath := filepath.Join(dir, "server.key")
+	if err := os.WriteFile(crtPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range []string{crtPath, keyPath} {
+		if err := os.Chtimes(p, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return crtPath, keyPath
+}
+
+func commonName(t *testing.T, r *Reloader) string {
+	t.Helper()
+	cert, err := r.GetCertificate(&tls.ClientHelloInfo{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return leaf.Subject.CommonName
+}
+
+func TestReload(t *testing.T) {
+	dir := t.TempDir()
+	base := time.Now().Add(-time.Minute)
+	crtPath, keyPath := writeCert(t, dir, "old", base)
+
+	r, err := New(crtPath, keyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cn := commonName(t, r); cn != "old" {
+		t.Fatalf("CN=%s, 期望old", cn)
+	}
+	if changed, err := r.Changed(); err != nil || changed {
+		t.Fatalf("文件未修改时不应该判断为变化, changed=%v err=%v", changed, err)
+	}
+
+	writeCert(t, dir, "new", base.Add(time.Second))
+	if changed, err := r.Changed(); err != nil || !changed {
+		t.Fatalf("文件修改后应该判断为变化, changed=%v err=%v", changed, err)
+	}
+	if err := r.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	if cn := commonName(t, r); cn != "new" {
+		t.Fatalf("CN=%s, 期望new", cn)
+	}
+}
+
+func TestReloadKeepsOldOnError(t *testing.T) {
+	dir := t.TempDir()
+	crtPath, keyPath := writeCert(t, dir, "old", time.Now())
+
+	r, err := New(crtPath, keyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyPath, []byte("broken"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Reload(); err == nil {
+		t.Fatal("私钥损坏时应该返回错误")
+	}
+	if cn := commonName(t, r); cn != "old" {
+		t.Fatalf("加载失败时应该保留原证书, CN=%s", cn)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	dir := t.TempDir()
+	base := time.Now().Add(-time.Minute)
+	crtPath, keyPath := writeCert(t, dir, "old", base)
+
+	r, err := New(crtPath, keyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	reloaded := make(chan error, 1)
+	go r.Watch(ctx, 10*time.Millisecond, func(err error) {
+		select {
+		case reloaded <- err:
+		default:
+		}
+	})
+
+	writeCert(t, dir, "new", base.Add(time.Second))
+	select {
+	case err := <-reloaded:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("文件变化后没有重新加载")
+	}
+	if cn := commonName(t, r); cn != "new" {
+		t.Fatalf("CN=%s, 期望new", cn)
+	}
+}
+
+func TestParseVersion(t *testing.T) {
+	cases := map[string]uint16{
+		"":       tls.VersionTLS12,
+		"1.2":    tls.VersionTLS12,
+		"TLS1.3": tls.VersionTLS13,
+		"1.0":    tls.VersionTLS10,
+	}
+	for in, want := range cases {
+		got, err := ParseVersion(in)
+		if err != nil || got != want {
+			t.Fatalf("ParseVersion(%q)=%x,%v, 期望%x", in, got, err, want)
+		}
+	}
+	if _, err := ParseVersion("2.0"); err == nil {
+		t.Fatal("不支持的版本应该返回错误")
+	}
+}
+
+func TestParseCipherSuites(t *testing.T) {
+	ids, err := ParseCipherSuites([]string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 1 || ids[0] != tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256 {
+		t.Fatalf("ids=%v", ids)
+	}
+	if ids, err := ParseCipherSuites(nil); err != nil || ids != nil {
+		t.Fatalf("为空时应该返回nil, ids=%v err=%v", ids, err)
+	}
+	// 不安全的套件不在tls.CipherSuites中
+	if _, err := ParseCipherSuites([]string{"TLS_RSA_WITH_RC4_128_SHA"}); err == nil {
+		t.Fatal("不安全的套件应该返回错误")
+	}
+}
@@ -0,0 +1,268 @@
+// Package xlsx 生成只包含数据的简单xlsx文件
+//
+// 只支持多个工作表的字符串和数值单元格, 不支持样式、公式和合并单元格,
+// 用于报表导出等不需要引入完整Office库的场景
+package xlsx
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"emperror.dev/errors"
+)
+
+// 工作表名称的最大长度, 超出时Excel会拒绝打开文件
+const maxSheetNameLen = 31
+
+// Sheet 工作表
+type Sheet struct {
+	Name string  // 工作表名称
+	Rows [][]any // 行数据, 数值类型写为数值单元格, 其余按字符串写入
+}
+
+// Write 将工作表写为xlsx文件
+func Write(w io.Writer, sheets []Sheet) error {
+	if len(sheets) == 0 {
+		return errors.New("xlsx至少需要一个工作表")
+	}
+	names := make(map[string]bool, len(sheets))
+	for i, sheet := range sheets {
+		if !validSheetName(sheet.Name) {
+			return errors.Errorf("工作表名称不合法, index=%d, name=%s", i, sheet.Name)
+		}
+		if names[sheet.Name] {
+			return errors.Errorf("工作表名称重复, name=%s", sheet.Name)
+		}
+		names[sheet.Name] = true
+	}
+
+	zw := zip.NewWriter(w)
+	if err := writeWorkbook(zw, sheets); err != nil {
+		return err
+	}
+	for i, sheet := range sheets {
+		fw, err := zw.Create(fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1))
+		if err != nil {
+			return errors.Wrapf(err, "创建工作表失败, name=%s", sheet.Name)
+		}
+		if err := writeSheet(fw, sheet.Rows); err != nil {
+			return errors.Wrapf(err, "写入工作表失败, name=%s", sheet.Name)
+		}
+	}
+	return errors.Wrap(zw.Close(), "关闭xlsx文件失败")
+}
+
+// StreamWriter 逐行写入单个工作表的xlsx文件, 内存占用与行数无关, 用于导出大量数据
+type StreamWriter struct {
+	zw  *zip.Writer
+	fw  io.Writer
+	row int
+	buf strings.Builder
+}
+
+// NewStreamWriter 创建只有一个工作表的xlsx流式写入器, 写完后必须调用Close
+func NewStreamWriter(w io.Writer, sheetName string) (*StreamWriter, error) {
+	if !validSheetName(sheetName) {
+		return nil, errors.Errorf("工作表名称不合法, name=%s", sheetName)
+	}
+	zw := zip.NewWriter(w)
+	if err := writeWorkbook(zw, []Sheet{{Name: sheetName}}); err != nil {
+		return nil, err
+	}
+	fw, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, errors.Wrapf(err, "创建工作表失败, name=%s", sheetName)
+	}
+	if _, err := io.WriteString(fw, sheetHeader); err != nil {
+		return nil, errors.Wrapf(err, "写入工作表失败, name=%s", sheetName)
+	}
+	return &StreamWriter{zw: zw, fw: fw}, nil
+}
+
+// WriteRow 写入一行
+func (s *StreamWriter) WriteRow(row []any) error {
+	s.buf.Reset()
+	if err := writeRow(&s.buf, s.row, row); err != nil {
+		return err
+	}
+	s.row++
+	_, err := io.WriteString(s.fw, s.buf.String())
+	return errors.Wrap(err, "写入工作表失败")
+}
+
+// Close 结束工作表并写入zip目录, 不关闭底层的io.Writer
+func (s *StreamWriter) Close() error {
+	if _, err := io.WriteString(s.fw, sheetFooter); err != nil {
+		return errors.Wrap(err, "写入工作表失败")
+	}
+	return errors.Wrap(s.zw.Close(), "关闭xlsx文件失败")
+}
+
+func validSheetName(name string) bool {
+	return name != "" && len([]rune(name)) <= maxSheetNameLen && !strings.ContainsAny(name, `[]:*?/\`)
+}
+
+// writeWorkbook 写入工作表数据以外的条目
+func writeWorkbook(zw *zip.Writer, sheets []Sheet) error {
+	files := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", contentTypes(len(sheets))},
+		{"_rels/.rels", rootRels},
+		{"xl/workbook.xml", workbook(sheets)},
+		{"xl/_rels/workbook.xml.rels", workbookRels(len(sheets))},
+	}
+	for _, f := range files {
+		if err := writeEntry(zw, f.name, f.content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeEntry(zw *zip.Writer, name, content string) error {
+	fw, err := zw.Create(name)
+	if err != nil {
+		return errors.Wrapf(err, "创建xlsx条目失败, name=%s", name)
+	}
+	if _, err := io.WriteString(fw, content); err != nil {
+		return errors.Wrapf(err, "写入xlsx条目失败, name=%s", name)
+	}
+	return nil
+}
+
+const (
+	sheetHeader = xml.Header + `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+	sheetFooter = `</sheetData></worksheet>`
+)
+
+// writeSheet 写入工作表数据
+func writeSheet(w io.Writer, rows [][]any) error {
+	var b strings.Builder
+	b.WriteString(sheetHeader)
+	for r, row := range rows {
+		if err := writeRow(&b, r, row); err != nil {
+			return err
+		}
+	}
+	b.WriteString(sheetFooter)
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeRow 写入从0开始的第r行, 字符串使用内联字符串避免维护共享字符串表
+func writeRow(b *strings.Builder, r int, row []any) error {
+	fmt.Fprintf(b, `<row r="%d">`, r+1)
+	for c, v := range row {
+		ref := CellName(c, r)
+		if num, ok := number(v); ok {
+			fmt.Fprintf(b, `<c r="%s"><v>%s</v></c>`, ref, num)
+			continue
+		}
+		s := text(v)
+		if s == "" {
+			continue
+		}
+		fmt.Fprintf(b, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">`, ref)
+		if err := xml.EscapeText(b, []byte(s)); err != nil {
+			return err
+		}
+		b.WriteString(`</t></is></c>`)
+	}
+	b.WriteString(`</row>`)
+	return nil
+}
+
+// CellName 返回从0开始的列号和行号对应的单元格名称, 如(0,0)为A1, (27,1)为AB2
+func CellName(col, row int) string {
+	name := ""
+	for col >= 0 {
+		name = string(rune('A'+col%26)) + name
+		col = col/26 - 1
+	}
+	return name + strconv.Itoa(row+1)
+}
+
+func number(v any) (string, bool) {
+	switch n := v.(type) {
+	case int:
+		return strconv.Itoa(n), true
+	case int32:
+		return strconv.FormatInt(int64(n), 10), true
+	case int64:
+		return strconv.FormatInt(n, 10), true
+	case uint32:
+		return strconv.FormatUint(uint64(n), 10), true
+	case uint64:
+		return strconv.FormatUint(n, 10), true
+	case float32:
+		return strconv.FormatFloat(float64(n), 'f', -1, 32), true
+	case float64:
+		return strconv.FormatFloat(n, 'f', -1, 64), true
+	default:
+		return "", false
+	}
+}
+
+func text(v any) string {
+	switch s := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return s
+	case time.Time:
+		return s.Format(time.DateTime)
+	case fmt.Stringer:
+		return s.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+const rootRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+func contentTypes(n int) string {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">`)
+	b.WriteString(`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>`)
+	b.WriteString(`<Default Extension="xml" ContentType="application/xml"/>`)
+	b.WriteString(`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>`)
+	for i := 1; i <= n; i++ {
+		fmt.Fprintf(&b, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, i)
+	}
+	b.WriteString(`</Types>`)
+	return b.String()
+}
+
+func workbook(sheets []Sheet) string {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`)
+	for i, sheet := range sheets {
+		b.WriteString(`<sheet name="`)
+		xml.EscapeText(&b, []byte(sheet.Name))
+		fmt.Fprintf(&b, `" sheetId="%d" r:id="rId%d"/>`, i+1, i+1)
+	}
+	b.WriteString(`</sheets></workbook>`)
+	return b.String()
+}
+
+func workbookRels(n int) string {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	for i := 1; i <= n; i++ {
+		fmt.Fprintf(&b, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, i, i)
+	}
+	b.WriteString(`</Relationships>`)
+	return b.String()
+}
@@ -0,0 +1,134 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func readEntry(t *testing.T, data []byte, name string) string {
+	t.Helper()
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("打开xlsx失败: %v", err)
+	}
+	f, err := zr.Open(name)
+	if err != nil {
+		t.Fatalf("打开条目%s失败: %v", name, err)
+	}
+	defer f.Close()
+	content, err := io.ReadAll(f)
+	if err != nil {
+		t.Fatalf("读取条目%s失败: %v", name, err)
+	}
+	return string(content)
+}
+
+func TestWrite(t *testing.T) {
+	var buf bytes.Buffer
+	err := Write(&buf, []Sheet{
+		{Name: "任务", Rows: [][]any{{"集群", "成功率"}, {"01", 0.5}, {"<02>", nil, int64(3)}}},
+		{Name: "登录", Rows: [][]any{{"总数", 10}}},
+	})
+	if err != nil {
+		t.Fatalf("写入xlsx失败: %v", err)
+	}
+
+	workbook := readEntry(t, buf.Bytes(), "xl/workbook.xml")
+	if !strings.Contains(workbook, `name="任务"`) || !strings.Contains(workbook, `name="登录"`) {
+		t.Errorf("工作簿缺少工作表: %s", workbook)
+	}
+	sheet := readEntry(t, buf.Bytes(), "xl/worksheets/sheet1.xml")
+	for _, want := range []string{
+		`<c r="A1" t="inlineStr"><is><t xml:space="preserve">集群</t></is></c>`,
+		`<c r="B2"><v>0.5</v></c>`,
+		`&lt;02&gt;`,
+		`<c r="C3"><v>3</v></c>`,
+	} {
+		if !strings.Contains(sheet, want) {
+			t.Errorf("工作表缺少%s: %s", want, sheet)
+		}
+	}
+	if strings.Contains(sheet, `r="B3"`) {
+		t.Error("空值不应该写入单元格")
+	}
+	readEntry(t, buf.Bytes(), "xl/worksheets/sheet2.xml")
+	readEntry(t, buf.Bytes(), "[Content_Types].xml")
+}
+
+func TestWriteInvalidSheet(t *testing.T) {
+	tests := []struct {
+		name   string
+		sheets []Sheet
+	}{
+		{"没有工作表", nil},
+		{"名称为空", []Sheet{{Name: ""}}},
+		{"名称包含非法字符", []Sheet{{Name: "a/b"}}},
+		{"名称过长", []Sheet{{Name: strings.Repeat("a", 32)}}},
+		{"名称重复", []Sheet{{Name: "a"}, {Name: "a"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := Write(io.Discard, tt.sheets); err == nil {
+				t.Error("期望返回错误")
+			}
+		})
+	}
+}
+
+func TestCellName(t *testing.T) {
+	tests := []struct {
+		col, row int
+		want     string
+	}{
+		{0, 0, "A1"},
+		{25, 9, "Z10"},
+		{26, 0, "AA1"},
+		{27, 1, "AB2"},
+		{701, 0, "ZZ1"},
+		{702, 0, "AAA1"},
+	}
+	for _, tt := range tests {
+		if got := CellName(tt.col, tt.row); got != tt.want {
+			t.Errorf("CellName(%d, %d) = %s, want %s", tt.col, tt.row, got, tt.want)
+		}
+	}
+}
+
+func TestStreamWriter(t *testing.T) {
+	var buf bytes.Buffer
+	sw, err := NewStreamWriter(&buf, "登录记录")
+	if err != nil {
+		t.Fatalf("创建xlsx失败: %v", err)
+	}
+	for _, row := range [][]any{{"用户名", "状态"}, {"admin", 1}, {"<guest>", 0}} {
+		if err := sw.WriteRow(row); err != nil {
+			t.Fatalf("写入行失败: %v", err)
+		}
+	}
+	if err := sw.Close(); err != nil {
+		t.Fatalf("关闭xlsx失败: %v", err)
+	}
+
+	workbook := readEntry(t, buf.Bytes(), "xl/workbook.xml")
+	if !strings.Contains(workbook, `name="登录记录"`) {
+		t.Errorf("工作簿缺少工作表: %s", workbook)
+	}
+	sheet := readEntry(t, buf.Bytes(), "xl/worksheets/sheet1.xml")
+	for _, want := range []string{
+		`<row r="1"><c r="A1" t="inlineStr"><is><t xml:space="preserve">用户名</t></is></c>`,
+		`<c r="B2"><v>1</v></c>`,
+		`&lt;guest&gt;`,
+		`</row></sheetData></worksheet>`,
+	} {
+		if !strings.Contains(sheet, want) {
+			t.Errorf("工作表缺少%s: %s", want, sheet)
+		}
+	}
+
+	if _, err := NewStreamWriter(io.Discard, "a/b"); err == nil {
+		t.Error("工作表名称不合法时应该返回错误")
+	}
+}
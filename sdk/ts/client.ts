@@ -0,0 +1,14492 @@
+// Code generated by sdkgen. DO NOT EDIT.
+/* eslint-disable */
+
+/** auth.CasbinSample */
+export interface AuthCasbinSample {
+  act: string;
+  expect?: boolean;
+  obj: string;
+  sub: string;
+}
+
+/** auth.CasbinSampleResult */
+export interface AuthCasbinSampleResult {
+  act: string;
+  actual?: boolean;
+  expect?: boolean;
+  obj: string;
+  passed?: boolean;
+  sub: string;
+}
+
+/** common.MapAPIReply */
+export interface CommonMapAPIReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: Record<string, unknown>;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** common.Pag-customer_ApiStandardOut */
+export interface CommonPagCustomerApiStandardOut {
+  /**
+   * 是否有下一页
+   * Example: true
+   */
+  has_next?: boolean;
+  /** 对象数组 */
+  items?: CustomerApiStandardOut[];
+  /**
+   * 当前页码
+   * Example: 1
+   */
+  page?: number;
+  /**
+   * 总页数, 请求with_total=false跳过总数时不返回
+   * Example: 10
+   */
+  pages?: number;
+  /**
+   * 每页数量
+   * Example: 10
+   */
+  size?: number;
+  /**
+   * 总记录数, 请求with_total=false跳过总数时不返回
+   * Example: 100
+   */
+  total?: number;
+}
+
+/** common.Pag-customer_ButtonStandardOut */
+export interface CommonPagCustomerButtonStandardOut {
+  /**
+   * 是否有下一页
+   * Example: true
+   */
+  has_next?: boolean;
+  /** 对象数组 */
+  items?: CustomerButtonStandardOut[];
+  /**
+   * 当前页码
+   * Example: 1
+   */
+  page?: number;
+  /**
+   * 总页数, 请求with_total=false跳过总数时不返回
+   * Example: 10
+   */
+  pages?: number;
+  /**
+   * 每页数量
+   * Example: 10
+   */
+  size?: number;
+  /**
+   * 总记录数, 请求with_total=false跳过总数时不返回
+   * Example: 100
+   */
+  total?: number;
+}
+
+/** common.Pag-customer_CasbinModelOut */
+export interface CommonPagCustomerCasbinModelOut {
+  /**
+   * 是否有下一页
+   * Example: true
+   */
+  has_next?: boolean;
+  /** 对象数组 */
+  items?: CustomerCasbinModelOut[];
+  /**
+   * 当前页码
+   * Example: 1
+   */
+  page?: number;
+  /**
+   * 总页数, 请求with_total=false跳过总数时不返回
+   * Example: 10
+   */
+  pages?: number;
+  /**
+   * 每页数量
+   * Example: 10
+   */
+  size?: number;
+  /**
+   * 总记录数, 请求with_total=false跳过总数时不返回
+   * Example: 100
+   */
+  total?: number;
+}
+
+/** common.Pag-customer_DepartmentStandardOut */
+export interface CommonPagCustomerDepartmentStandardOut {
+  /**
+   * 是否有下一页
+   * Example: true
+   */
+  has_next?: boolean;
+  /** 对象数组 */
+  items?: CustomerDepartmentStandardOut[];
+  /**
+   * 当前页码
+   * Example: 1
+   */
+  page?: number;
+  /**
+   * 总页数, 请求with_total=false跳过总数时不返回
+   * Example: 10
+   */
+  pages?: number;
+  /**
+   * 每页数量
+   * Example: 10
+   */
+  size?: number;
+  /**
+   * 总记录数, 请求with_total=false跳过总数时不返回
+   * Example: 100
+   */
+  total?: number;
+}
+
+/** common.Pag-customer_LoginRecordStandardOut */
+export interface CommonPagCustomerLoginRecordStandardOut {
+  /**
+   * 是否有下一页
+   * Example: true
+   */
+  has_next?: boolean;
+  /** 对象数组 */
+  items?: CustomerLoginRecordStandardOut[];
+  /**
+   * 当前页码
+   * Example: 1
+   */
+  page?: number;
+  /**
+   * 总页数, 请求with_total=false跳过总数时不返回
+   * Example: 10
+   */
+  pages?: number;
+  /**
+   * 每页数量
+   * Example: 10
+   */
+  size?: number;
+  /**
+   * 总记录数, 请求with_total=false跳过总数时不返回
+   * Example: 100
+   */
+  total?: number;
+}
+
+/** common.Pag-customer_MenuStandardOut */
+export interface CommonPagCustomerMenuStandardOut {
+  /**
+   * 是否有下一页
+   * Example: true
+   */
+  has_next?: boolean;
+  /** 对象数组 */
+  items?: CustomerMenuStandardOut[];
+  /**
+   * 当前页码
+   * Example: 1
+   */
+  page?: number;
+  /**
+   * 总页数, 请求with_total=false跳过总数时不返回
+   * Example: 10
+   */
+  pages?: number;
+  /**
+   * 每页数量
+   * Example: 10
+   */
+  size?: number;
+  /**
+   * 总记录数, 请求with_total=false跳过总数时不返回
+   * Example: 100
+   */
+  total?: number;
+}
+
+/** common.Pag-customer_RoleStandardOut */
+export interface CommonPagCustomerRoleStandardOut {
+  /**
+   * 是否有下一页
+   * Example: true
+   */
+  has_next?: boolean;
+  /** 对象数组 */
+  items?: CustomerRoleStandardOut[];
+  /**
+   * 当前页码
+   * Example: 1
+   */
+  page?: number;
+  /**
+   * 总页数, 请求with_total=false跳过总数时不返回
+   * Example: 10
+   */
+  pages?: number;
+  /**
+   * 每页数量
+   * Example: 10
+   */
+  size?: number;
+  /**
+   * 总记录数, 请求with_total=false跳过总数时不返回
+   * Example: 100
+   */
+  total?: number;
+}
+
+/** common.Pag-customer_SigningKeyOut */
+export interface CommonPagCustomerSigningKeyOut {
+  /**
+   * 是否有下一页
+   * Example: true
+   */
+  has_next?: boolean;
+  /** 对象数组 */
+  items?: CustomerSigningKeyOut[];
+  /**
+   * 当前页码
+   * Example: 1
+   */
+  page?: number;
+  /**
+   * 总页数, 请求with_total=false跳过总数时不返回
+   * Example: 10
+   */
+  pages?: number;
+  /**
+   * 每页数量
+   * Example: 10
+   */
+  size?: number;
+  /**
+   * 总记录数, 请求with_total=false跳过总数时不返回
+   * Example: 100
+   */
+  total?: number;
+}
+
+/** common.Pag-customer_UserDetailOut */
+export interface CommonPagCustomerUserDetailOut {
+  /**
+   * 是否有下一页
+   * Example: true
+   */
+  has_next?: boolean;
+  /** 对象数组 */
+  items?: CustomerUserDetailOut[];
+  /**
+   * 当前页码
+   * Example: 1
+   */
+  page?: number;
+  /**
+   * 总页数, 请求with_total=false跳过总数时不返回
+   * Example: 10
+   */
+  pages?: number;
+  /**
+   * 每页数量
+   * Example: 10
+   */
+  size?: number;
+  /**
+   * 总记录数, 请求with_total=false跳过总数时不返回
+   * Example: 100
+   */
+  total?: number;
+}
+
+/** common.Pag-customer_UserGroupStandardOut */
+export interface CommonPagCustomerUserGroupStandardOut {
+  /**
+   * 是否有下一页
+   * Example: true
+   */
+  has_next?: boolean;
+  /** 对象数组 */
+  items?: CustomerUserGroupStandardOut[];
+  /**
+   * 当前页码
+   * Example: 1
+   */
+  page?: number;
+  /**
+   * 总页数, 请求with_total=false跳过总数时不返回
+   * Example: 10
+   */
+  pages?: number;
+  /**
+   * 每页数量
+   * Example: 10
+   */
+  size?: number;
+  /**
+   * 总记录数, 请求with_total=false跳过总数时不返回
+   * Example: 100
+   */
+  total?: number;
+}
+
+/** common.Pag-jobs_ScheduleDetailOut */
+export interface CommonPagJobsScheduleDetailOut {
+  /**
+   * 是否有下一页
+   * Example: true
+   */
+  has_next?: boolean;
+  /** 对象数组 */
+  items?: JobsScheduleDetailOut[];
+  /**
+   * 当前页码
+   * Example: 1
+   */
+  page?: number;
+  /**
+   * 总页数, 请求with_total=false跳过总数时不返回
+   * Example: 10
+   */
+  pages?: number;
+  /**
+   * 每页数量
+   * Example: 10
+   */
+  size?: number;
+  /**
+   * 总记录数, 请求with_total=false跳过总数时不返回
+   * Example: 100
+   */
+  total?: number;
+}
+
+/** common.Pag-jobs_ScheduleSkipOut */
+export interface CommonPagJobsScheduleSkipOut {
+  /**
+   * 是否有下一页
+   * Example: true
+   */
+  has_next?: boolean;
+  /** 对象数组 */
+  items?: JobsScheduleSkipOut[];
+  /**
+   * 当前页码
+   * Example: 1
+   */
+  page?: number;
+  /**
+   * 总页数, 请求with_total=false跳过总数时不返回
+   * Example: 10
+   */
+  pages?: number;
+  /**
+   * 每页数量
+   * Example: 10
+   */
+  size?: number;
+  /**
+   * 总记录数, 请求with_total=false跳过总数时不返回
+   * Example: 100
+   */
+  total?: number;
+}
+
+/** common.Pag-jobs_ScriptRecordDetailOut */
+export interface CommonPagJobsScriptRecordDetailOut {
+  /**
+   * 是否有下一页
+   * Example: true
+   */
+  has_next?: boolean;
+  /** 对象数组 */
+  items?: JobsScriptRecordDetailOut[];
+  /**
+   * 当前页码
+   * Example: 1
+   */
+  page?: number;
+  /**
+   * 总页数, 请求with_total=false跳过总数时不返回
+   * Example: 10
+   */
+  pages?: number;
+  /**
+   * 每页数量
+   * Example: 10
+   */
+  size?: number;
+  /**
+   * 总记录数, 请求with_total=false跳过总数时不返回
+   * Example: 100
+   */
+  total?: number;
+}
+
+/** common.Pag-jobs_ScriptStandardOut */
+export interface CommonPagJobsScriptStandardOut {
+  /**
+   * 是否有下一页
+   * Example: true
+   */
+  has_next?: boolean;
+  /** 对象数组 */
+  items?: JobsScriptStandardOut[];
+  /**
+   * 当前页码
+   * Example: 1
+   */
+  page?: number;
+  /**
+   * 总页数, 请求with_total=false跳过总数时不返回
+   * Example: 10
+   */
+  pages?: number;
+  /**
+   * 每页数量
+   * Example: 10
+   */
+  size?: number;
+  /**
+   * 总记录数, 请求with_total=false跳过总数时不返回
+   * Example: 100
+   */
+  total?: number;
+}
+
+/** common.Pag-jobs_TradingHolidayOut */
+export interface CommonPagJobsTradingHolidayOut {
+  /**
+   * 是否有下一页
+   * Example: true
+   */
+  has_next?: boolean;
+  /** 对象数组 */
+  items?: JobsTradingHolidayOut[];
+  /**
+   * 当前页码
+   * Example: 1
+   */
+  page?: number;
+  /**
+   * 总页数, 请求with_total=false跳过总数时不返回
+   * Example: 10
+   */
+  pages?: number;
+  /**
+   * 每页数量
+   * Example: 10
+   */
+  size?: number;
+  /**
+   * 总记录数, 请求with_total=false跳过总数时不返回
+   * Example: 100
+   */
+  total?: number;
+}
+
+/** common.Pag-mds_IngestRecordOut */
+export interface CommonPagMdsIngestRecordOut {
+  /**
+   * 是否有下一页
+   * Example: true
+   */
+  has_next?: boolean;
+  /** 对象数组 */
+  items?: MdsIngestRecordOut[];
+  /**
+   * 当前页码
+   * Example: 1
+   */
+  page?: number;
+  /**
+   * 总页数, 请求with_total=false跳过总数时不返回
+   * Example: 10
+   */
+  pages?: number;
+  /**
+   * 每页数量
+   * Example: 10
+   */
+  size?: number;
+  /**
+   * 总记录数, 请求with_total=false跳过总数时不返回
+   * Example: 100
+   */
+  total?: number;
+}
+
+/** common.Pag-mds_IngestSourceOut */
+export interface CommonPagMdsIngestSourceOut {
+  /**
+   * 是否有下一页
+   * Example: true
+   */
+  has_next?: boolean;
+  /** 对象数组 */
+  items?: MdsIngestSourceOut[];
+  /**
+   * 当前页码
+   * Example: 1
+   */
+  page?: number;
+  /**
+   * 总页数, 请求with_total=false跳过总数时不返回
+   * Example: 10
+   */
+  pages?: number;
+  /**
+   * 每页数量
+   * Example: 10
+   */
+  size?: number;
+  /**
+   * 总记录数, 请求with_total=false跳过总数时不返回
+   * Example: 100
+   */
+  total?: number;
+}
+
+/** common.Pag-mds_MdsBackfillRunOut */
+export interface CommonPagMdsMdsBackfillRunOut {
+  /**
+   * 是否有下一页
+   * Example: true
+   */
+  has_next?: boolean;
+  /** 对象数组 */
+  items?: MdsMdsBackfillRunOut[];
+  /**
+   * 当前页码
+   * Example: 1
+   */
+  page?: number;
+  /**
+   * 总页数, 请求with_total=false跳过总数时不返回
+   * Example: 10
+   */
+  pages?: number;
+  /**
+   * 每页数量
+   * Example: 10
+   */
+  size?: number;
+  /**
+   * 总记录数, 请求with_total=false跳过总数时不返回
+   * Example: 100
+   */
+  total?: number;
+}
+
+/** common.Pag-mds_MdsColonyDetailOut */
+export interface CommonPagMdsMdsColonyDetailOut {
+  /**
+   * 是否有下一页
+   * Example: true
+   */
+  has_next?: boolean;
+  /** 对象数组 */
+  items?: MdsMdsColonyDetailOut[];
+  /**
+   * 当前页码
+   * Example: 1
+   */
+  page?: number;
+  /**
+   * 总页数, 请求with_total=false跳过总数时不返回
+   * Example: 10
+   */
+  pages?: number;
+  /**
+   * 每页数量
+   * Example: 10
+   */
+  size?: number;
+  /**
+   * 总记录数, 请求with_total=false跳过总数时不返回
+   * Example: 100
+   */
+  total?: number;
+}
+
+/** common.Pag-mds_MdsNodeDetailOut */
+export interface CommonPagMdsMdsNodeDetailOut {
+  /**
+   * 是否有下一页
+   * Example: true
+   */
+  has_next?: boolean;
+  /** 对象数组 */
+  items?: MdsMdsNodeDetailOut[];
+  /**
+   * 当前页码
+   * Example: 1
+   */
+  page?: number;
+  /**
+   * 总页数, 请求with_total=false跳过总数时不返回
+   * Example: 10
+   */
+  pages?: number;
+  /**
+   * 每页数量
+   * Example: 10
+   */
+  size?: number;
+  /**
+   * 总记录数, 请求with_total=false跳过总数时不返回
+   * Example: 100
+   */
+  total?: number;
+}
+
+/** common.Pag-mon_MonNodeDetailOut */
+export interface CommonPagMonMonNodeDetailOut {
+  /**
+   * 是否有下一页
+   * Example: true
+   */
+  has_next?: boolean;
+  /** 对象数组 */
+  items?: MonMonNodeDetailOut[];
+  /**
+   * 当前页码
+   * Example: 1
+   */
+  page?: number;
+  /**
+   * 总页数, 请求with_total=false跳过总数时不返回
+   * Example: 10
+   */
+  pages?: number;
+  /**
+   * 每页数量
+   * Example: 10
+   */
+  size?: number;
+  /**
+   * 总记录数, 请求with_total=false跳过总数时不返回
+   * Example: 100
+   */
+  total?: number;
+}
+
+/** common.Pag-oes_OesColonyDetailOut */
+export interface CommonPagOesOesColonyDetailOut {
+  /**
+   * 是否有下一页
+   * Example: true
+   */
+  has_next?: boolean;
+  /** 对象数组 */
+  items?: OesOesColonyDetailOut[];
+  /**
+   * 当前页码
+   * Example: 1
+   */
+  page?: number;
+  /**
+   * 总页数, 请求with_total=false跳过总数时不返回
+   * Example: 10
+   */
+  pages?: number;
+  /**
+   * 每页数量
+   * Example: 10
+   */
+  size?: number;
+  /**
+   * 总记录数, 请求with_total=false跳过总数时不返回
+   * Example: 100
+   */
+  total?: number;
+}
+
+/** common.Pag-oes_OesConfTemplateOut */
+export interface CommonPagOesOesConfTemplateOut {
+  /**
+   * 是否有下一页
+   * Example: true
+   */
+  has_next?: boolean;
+  /** 对象数组 */
+  items?: OesOesConfTemplateOut[];
+  /**
+   * 当前页码
+   * Example: 1
+   */
+  page?: number;
+  /**
+   * 总页数, 请求with_total=false跳过总数时不返回
+   * Example: 10
+   */
+  pages?: number;
+  /**
+   * 每页数量
+   * Example: 10
+   */
+  size?: number;
+  /**
+   * 总记录数, 请求with_total=false跳过总数时不返回
+   * Example: 100
+   */
+  total?: number;
+}
+
+/** common.Pag-oes_OesLinkOut */
+export interface CommonPagOesOesLinkOut {
+  /**
+   * 是否有下一页
+   * Example: true
+   */
+  has_next?: boolean;
+  /** 对象数组 */
+  items?: OesOesLinkOut[];
+  /**
+   * 当前页码
+   * Example: 1
+   */
+  page?: number;
+  /**
+   * 总页数, 请求with_total=false跳过总数时不返回
+   * Example: 10
+   */
+  pages?: number;
+  /**
+   * 每页数量
+   * Example: 10
+   */
+  size?: number;
+  /**
+   * 总记录数, 请求with_total=false跳过总数时不返回
+   * Example: 100
+   */
+  total?: number;
+}
+
+/** common.Pag-oes_OesLinkProbeOut */
+export interface CommonPagOesOesLinkProbeOut {
+  /**
+   * 是否有下一页
+   * Example: true
+   */
+  has_next?: boolean;
+  /** 对象数组 */
+  items?: OesOesLinkProbeOut[];
+  /**
+   * 当前页码
+   * Example: 1
+   */
+  page?: number;
+  /**
+   * 总页数, 请求with_total=false跳过总数时不返回
+   * Example: 10
+   */
+  pages?: number;
+  /**
+   * 每页数量
+   * Example: 10
+   */
+  size?: number;
+  /**
+   * 总记录数, 请求with_total=false跳过总数时不返回
+   * Example: 100
+   */
+  total?: number;
+}
+
+/** common.Pag-oes_OesNodeDetailOut */
+export interface CommonPagOesOesNodeDetailOut {
+  /**
+   * 是否有下一页
+   * Example: true
+   */
+  has_next?: boolean;
+  /** 对象数组 */
+  items?: OesOesNodeDetailOut[];
+  /**
+   * 当前页码
+   * Example: 1
+   */
+  page?: number;
+  /**
+   * 总页数, 请求with_total=false跳过总数时不返回
+   * Example: 10
+   */
+  pages?: number;
+  /**
+   * 每页数量
+   * Example: 10
+   */
+  size?: number;
+  /**
+   * 总记录数, 请求with_total=false跳过总数时不返回
+   * Example: 100
+   */
+  total?: number;
+}
+
+/** common.Pag-oes_OesReconcileReportOut */
+export interface CommonPagOesOesReconcileReportOut {
+  /**
+   * 是否有下一页
+   * Example: true
+   */
+  has_next?: boolean;
+  /** 对象数组 */
+  items?: OesOesReconcileReportOut[];
+  /**
+   * 当前页码
+   * Example: 1
+   */
+  page?: number;
+  /**
+   * 总页数, 请求with_total=false跳过总数时不返回
+   * Example: 10
+   */
+  pages?: number;
+  /**
+   * 每页数量
+   * Example: 10
+   */
+  size?: number;
+  /**
+   * 总记录数, 请求with_total=false跳过总数时不返回
+   * Example: 100
+   */
+  total?: number;
+}
+
+/** common.Pag-oes_OesRunbookExecOut */
+export interface CommonPagOesOesRunbookExecOut {
+  /**
+   * 是否有下一页
+   * Example: true
+   */
+  has_next?: boolean;
+  /** 对象数组 */
+  items?: OesOesRunbookExecOut[];
+  /**
+   * 当前页码
+   * Example: 1
+   */
+  page?: number;
+  /**
+   * 总页数, 请求with_total=false跳过总数时不返回
+   * Example: 10
+   */
+  pages?: number;
+  /**
+   * 每页数量
+   * Example: 10
+   */
+  size?: number;
+  /**
+   * 总记录数, 请求with_total=false跳过总数时不返回
+   * Example: 100
+   */
+  total?: number;
+}
+
+/** common.Pag-oes_OesRunbookOut */
+export interface CommonPagOesOesRunbookOut {
+  /**
+   * 是否有下一页
+   * Example: true
+   */
+  has_next?: boolean;
+  /** 对象数组 */
+  items?: OesOesRunbookOut[];
+  /**
+   * 当前页码
+   * Example: 1
+   */
+  page?: number;
+  /**
+   * 总页数, 请求with_total=false跳过总数时不返回
+   * Example: 10
+   */
+  pages?: number;
+  /**
+   * 每页数量
+   * Example: 10
+   */
+  size?: number;
+  /**
+   * 总记录数, 请求with_total=false跳过总数时不返回
+   * Example: 100
+   */
+  total?: number;
+}
+
+/** common.Pag-oes_OesTaskCatalogOut */
+export interface CommonPagOesOesTaskCatalogOut {
+  /**
+   * 是否有下一页
+   * Example: true
+   */
+  has_next?: boolean;
+  /** 对象数组 */
+  items?: OesOesTaskCatalogOut[];
+  /**
+   * 当前页码
+   * Example: 1
+   */
+  page?: number;
+  /**
+   * 总页数, 请求with_total=false跳过总数时不返回
+   * Example: 10
+   */
+  pages?: number;
+  /**
+   * 每页数量
+   * Example: 10
+   */
+  size?: number;
+  /**
+   * 总记录数, 请求with_total=false跳过总数时不返回
+   * Example: 100
+   */
+  total?: number;
+}
+
+/** common.Pag-oes_OesTaskSlaOut */
+export interface CommonPagOesOesTaskSlaOut {
+  /**
+   * 是否有下一页
+   * Example: true
+   */
+  has_next?: boolean;
+  /** 对象数组 */
+  items?: OesOesTaskSlaOut[];
+  /**
+   * 当前页码
+   * Example: 1
+   */
+  page?: number;
+  /**
+   * 总页数, 请求with_total=false跳过总数时不返回
+   * Example: 10
+   */
+  pages?: number;
+  /**
+   * 每页数量
+   * Example: 10
+   */
+  size?: number;
+  /**
+   * 总记录数, 请求with_total=false跳过总数时不返回
+   * Example: 100
+   */
+  total?: number;
+}
+
+/** common.Pag-oes_OesWorkflowRunOut */
+export interface CommonPagOesOesWorkflowRunOut {
+  /**
+   * 是否有下一页
+   * Example: true
+   */
+  has_next?: boolean;
+  /** 对象数组 */
+  items?: OesOesWorkflowRunOut[];
+  /**
+   * 当前页码
+   * Example: 1
+   */
+  page?: number;
+  /**
+   * 总页数, 请求with_total=false跳过总数时不返回
+   * Example: 10
+   */
+  pages?: number;
+  /**
+   * 每页数量
+   * Example: 10
+   */
+  size?: number;
+  /**
+   * 总记录数, 请求with_total=false跳过总数时不返回
+   * Example: 100
+   */
+  total?: number;
+}
+
+/** common.Pag-resource_HostAgentOut */
+export interface CommonPagResourceHostAgentOut {
+  /**
+   * 是否有下一页
+   * Example: true
+   */
+  has_next?: boolean;
+  /** 对象数组 */
+  items?: ResourceHostAgentOut[];
+  /**
+   * 当前页码
+   * Example: 1
+   */
+  page?: number;
+  /**
+   * 总页数, 请求with_total=false跳过总数时不返回
+   * Example: 10
+   */
+  pages?: number;
+  /**
+   * 每页数量
+   * Example: 10
+   */
+  size?: number;
+  /**
+   * 总记录数, 请求with_total=false跳过总数时不返回
+   * Example: 100
+   */
+  total?: number;
+}
+
+/** common.Pag-resource_HostPathRuleOut */
+export interface CommonPagResourceHostPathRuleOut {
+  /**
+   * 是否有下一页
+   * Example: true
+   */
+  has_next?: boolean;
+  /** 对象数组 */
+  items?: ResourceHostPathRuleOut[];
+  /**
+   * 当前页码
+   * Example: 1
+   */
+  page?: number;
+  /**
+   * 总页数, 请求with_total=false跳过总数时不返回
+   * Example: 10
+   */
+  pages?: number;
+  /**
+   * 每页数量
+   * Example: 10
+   */
+  size?: number;
+  /**
+   * 总记录数, 请求with_total=false跳过总数时不返回
+   * Example: 100
+   */
+  total?: number;
+}
+
+/** common.Pag-resource_HostStandardOut */
+export interface CommonPagResourceHostStandardOut {
+  /**
+   * 是否有下一页
+   * Example: true
+   */
+  has_next?: boolean;
+  /** 对象数组 */
+  items?: ResourceHostStandardOut[];
+  /**
+   * 当前页码
+   * Example: 1
+   */
+  page?: number;
+  /**
+   * 总页数, 请求with_total=false跳过总数时不返回
+   * Example: 10
+   */
+  pages?: number;
+  /**
+   * 每页数量
+   * Example: 10
+   */
+  size?: number;
+  /**
+   * 总记录数, 请求with_total=false跳过总数时不返回
+   * Example: 100
+   */
+  total?: number;
+}
+
+/** common.Pag-resource_PackageStandardOut */
+export interface CommonPagResourcePackageStandardOut {
+  /**
+   * 是否有下一页
+   * Example: true
+   */
+  has_next?: boolean;
+  /** 对象数组 */
+  items?: ResourcePackageStandardOut[];
+  /**
+   * 当前页码
+   * Example: 1
+   */
+  page?: number;
+  /**
+   * 总页数, 请求with_total=false跳过总数时不返回
+   * Example: 10
+   */
+  pages?: number;
+  /**
+   * 每页数量
+   * Example: 10
+   */
+  size?: number;
+  /**
+   * 总记录数, 请求with_total=false跳过总数时不返回
+   * Example: 100
+   */
+  total?: number;
+}
+
+/** common.Pag-resource_TerminalSessionOut */
+export interface CommonPagResourceTerminalSessionOut {
+  /**
+   * 是否有下一页
+   * Example: true
+   */
+  has_next?: boolean;
+  /** 对象数组 */
+  items?: ResourceTerminalSessionOut[];
+  /**
+   * 当前页码
+   * Example: 1
+   */
+  page?: number;
+  /**
+   * 总页数, 请求with_total=false跳过总数时不返回
+   * Example: 10
+   */
+  pages?: number;
+  /**
+   * 每页数量
+   * Example: 10
+   */
+  size?: number;
+  /**
+   * 总记录数, 请求with_total=false跳过总数时不返回
+   * Example: 100
+   */
+  total?: number;
+}
+
+/** common.Pag-resource_WatchdogEventOut */
+export interface CommonPagResourceWatchdogEventOut {
+  /**
+   * 是否有下一页
+   * Example: true
+   */
+  has_next?: boolean;
+  /** 对象数组 */
+  items?: ResourceWatchdogEventOut[];
+  /**
+   * 当前页码
+   * Example: 1
+   */
+  page?: number;
+  /**
+   * 总页数, 请求with_total=false跳过总数时不返回
+   * Example: 10
+   */
+  pages?: number;
+  /**
+   * 每页数量
+   * Example: 10
+   */
+  size?: number;
+  /**
+   * 总记录数, 请求with_total=false跳过总数时不返回
+   * Example: 100
+   */
+  total?: number;
+}
+
+/** common.Pag-resource_WatchdogOut */
+export interface CommonPagResourceWatchdogOut {
+  /**
+   * 是否有下一页
+   * Example: true
+   */
+  has_next?: boolean;
+  /** 对象数组 */
+  items?: ResourceWatchdogOut[];
+  /**
+   * 当前页码
+   * Example: 1
+   */
+  page?: number;
+  /**
+   * 总页数, 请求with_total=false跳过总数时不返回
+   * Example: 10
+   */
+  pages?: number;
+  /**
+   * 每页数量
+   * Example: 10
+   */
+  size?: number;
+  /**
+   * 总记录数, 请求with_total=false跳过总数时不返回
+   * Example: 100
+   */
+  total?: number;
+}
+
+/** common.Pag-system_AuditRecordOut */
+export interface CommonPagSystemAuditRecordOut {
+  /**
+   * 是否有下一页
+   * Example: true
+   */
+  has_next?: boolean;
+  /** 对象数组 */
+  items?: SystemAuditRecordOut[];
+  /**
+   * 当前页码
+   * Example: 1
+   */
+  page?: number;
+  /**
+   * 总页数, 请求with_total=false跳过总数时不返回
+   * Example: 10
+   */
+  pages?: number;
+  /**
+   * 每页数量
+   * Example: 10
+   */
+  size?: number;
+  /**
+   * 总记录数, 请求with_total=false跳过总数时不返回
+   * Example: 100
+   */
+  total?: number;
+}
+
+/** common.Pag-system_BackupOut */
+export interface CommonPagSystemBackupOut {
+  /**
+   * 是否有下一页
+   * Example: true
+   */
+  has_next?: boolean;
+  /** 对象数组 */
+  items?: SystemBackupOut[];
+  /**
+   * 当前页码
+   * Example: 1
+   */
+  page?: number;
+  /**
+   * 总页数, 请求with_total=false跳过总数时不返回
+   * Example: 10
+   */
+  pages?: number;
+  /**
+   * 每页数量
+   * Example: 10
+   */
+  size?: number;
+  /**
+   * 总记录数, 请求with_total=false跳过总数时不返回
+   * Example: 100
+   */
+  total?: number;
+}
+
+/** common.Pag-system_CertMonitorOut */
+export interface CommonPagSystemCertMonitorOut {
+  /**
+   * 是否有下一页
+   * Example: true
+   */
+  has_next?: boolean;
+  /** 对象数组 */
+  items?: SystemCertMonitorOut[];
+  /**
+   * 当前页码
+   * Example: 1
+   */
+  page?: number;
+  /**
+   * 总页数, 请求with_total=false跳过总数时不返回
+   * Example: 10
+   */
+  pages?: number;
+  /**
+   * 每页数量
+   * Example: 10
+   */
+  size?: number;
+  /**
+   * 总记录数, 请求with_total=false跳过总数时不返回
+   * Example: 100
+   */
+  total?: number;
+}
+
+/** common.Pag-system_ChangeFreezeOut */
+export interface CommonPagSystemChangeFreezeOut {
+  /**
+   * 是否有下一页
+   * Example: true
+   */
+  has_next?: boolean;
+  /** 对象数组 */
+  items?: SystemChangeFreezeOut[];
+  /**
+   * 当前页码
+   * Example: 1
+   */
+  page?: number;
+  /**
+   * 总页数, 请求with_total=false跳过总数时不返回
+   * Example: 10
+   */
+  pages?: number;
+  /**
+   * 每页数量
+   * Example: 10
+   */
+  size?: number;
+  /**
+   * 总记录数, 请求with_total=false跳过总数时不返回
+   * Example: 100
+   */
+  total?: number;
+}
+
+/** common.Pag-system_FeatureFlagOut */
+export interface CommonPagSystemFeatureFlagOut {
+  /**
+   * 是否有下一页
+   * Example: true
+   */
+  has_next?: boolean;
+  /** 对象数组 */
+  items?: SystemFeatureFlagOut[];
+  /**
+   * 当前页码
+   * Example: 1
+   */
+  page?: number;
+  /**
+   * 总页数, 请求with_total=false跳过总数时不返回
+   * Example: 10
+   */
+  pages?: number;
+  /**
+   * 每页数量
+   * Example: 10
+   */
+  size?: number;
+  /**
+   * 总记录数, 请求with_total=false跳过总数时不返回
+   * Example: 100
+   */
+  total?: number;
+}
+
+/** common.Pag-system_MaintenanceWindowOut */
+export interface CommonPagSystemMaintenanceWindowOut {
+  /**
+   * 是否有下一页
+   * Example: true
+   */
+  has_next?: boolean;
+  /** 对象数组 */
+  items?: SystemMaintenanceWindowOut[];
+  /**
+   * 当前页码
+   * Example: 1
+   */
+  page?: number;
+  /**
+   * 总页数, 请求with_total=false跳过总数时不返回
+   * Example: 10
+   */
+  pages?: number;
+  /**
+   * 每页数量
+   * Example: 10
+   */
+  size?: number;
+  /**
+   * 总记录数, 请求with_total=false跳过总数时不返回
+   * Example: 100
+   */
+  total?: number;
+}
+
+/** common.Pag-system_SlowQueryOut */
+export interface CommonPagSystemSlowQueryOut {
+  /**
+   * 是否有下一页
+   * Example: true
+   */
+  has_next?: boolean;
+  /** 对象数组 */
+  items?: SystemSlowQueryOut[];
+  /**
+   * 当前页码
+   * Example: 1
+   */
+  page?: number;
+  /**
+   * 总页数, 请求with_total=false跳过总数时不返回
+   * Example: 10
+   */
+  pages?: number;
+  /**
+   * 每页数量
+   * Example: 10
+   */
+  size?: number;
+  /**
+   * 总记录数, 请求with_total=false跳过总数时不返回
+   * Example: 100
+   */
+  total?: number;
+}
+
+/** common.Pag-system_TaskOut */
+export interface CommonPagSystemTaskOut {
+  /**
+   * 是否有下一页
+   * Example: true
+   */
+  has_next?: boolean;
+  /** 对象数组 */
+  items?: SystemTaskOut[];
+  /**
+   * 当前页码
+   * Example: 1
+   */
+  page?: number;
+  /**
+   * 总页数, 请求with_total=false跳过总数时不返回
+   * Example: 10
+   */
+  pages?: number;
+  /**
+   * 每页数量
+   * Example: 10
+   */
+  size?: number;
+  /**
+   * 总记录数, 请求with_total=false跳过总数时不返回
+   * Example: 100
+   */
+  total?: number;
+}
+
+/** common.Pag-system_WebhookDeliveryOut */
+export interface CommonPagSystemWebhookDeliveryOut {
+  /**
+   * 是否有下一页
+   * Example: true
+   */
+  has_next?: boolean;
+  /** 对象数组 */
+  items?: SystemWebhookDeliveryOut[];
+  /**
+   * 当前页码
+   * Example: 1
+   */
+  page?: number;
+  /**
+   * 总页数, 请求with_total=false跳过总数时不返回
+   * Example: 10
+   */
+  pages?: number;
+  /**
+   * 每页数量
+   * Example: 10
+   */
+  size?: number;
+  /**
+   * 总记录数, 请求with_total=false跳过总数时不返回
+   * Example: 100
+   */
+  total?: number;
+}
+
+/** common.Pag-system_WebhookSubscriptionOut */
+export interface CommonPagSystemWebhookSubscriptionOut {
+  /**
+   * 是否有下一页
+   * Example: true
+   */
+  has_next?: boolean;
+  /** 对象数组 */
+  items?: SystemWebhookSubscriptionOut[];
+  /**
+   * 当前页码
+   * Example: 1
+   */
+  page?: number;
+  /**
+   * 总页数, 请求with_total=false跳过总数时不返回
+   * Example: 10
+   */
+  pages?: number;
+  /**
+   * 每页数量
+   * Example: 10
+   */
+  size?: number;
+  /**
+   * 总记录数, 请求with_total=false跳过总数时不返回
+   * Example: 100
+   */
+  total?: number;
+}
+
+/** common.TaskInfo */
+export interface CommonTaskInfo {
+  /** 更新时间 */
+  end_time?: string;
+  /** 执行记录ID(0表示非正常执行的任务) */
+  record_id?: number;
+  /** 创建时间 */
+  start_time?: string;
+  /** 执行状态(0-待执行,1-执行中,2-成功,3-失败,4-超时,5-崩溃) */
+  status?: number;
+  /** 任务名称 */
+  task_name?: string;
+  /** 触发类型(cron/api,未执行为空) */
+  trigger_type?: string;
+}
+
+/** customer.ApiReply */
+export interface CustomerApiReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CustomerApiStandardOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** customer.ApiStandardOut */
+export interface CustomerApiStandardOut {
+  /** 创建时间 */
+  created_at?: string;
+  /** 描述 */
+  descr?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** 是否失效 */
+  is_orphan?: boolean;
+  /** 标签 */
+  label?: string;
+  /** 请求方法 */
+  method?: string;
+  /** 更新时间 */
+  updated_at?: string;
+  /** HTTP路径 */
+  url?: string;
+}
+
+/** customer.ApiSyncOut */
+export interface CustomerApiSyncOut {
+  /** 新增的API */
+  created?: CustomerApiStandardOut[];
+  /** 路由中已不存在的API */
+  orphans?: CustomerApiStandardOut[];
+  /** 重新出现在路由中的失效API数量 */
+  restored?: number;
+  /** 重新设置标签的API数量 */
+  retagged?: number;
+}
+
+/** customer.ApiSyncReply */
+export interface CustomerApiSyncReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CustomerApiSyncOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** customer.AssignDepartmentRequest */
+export interface CustomerAssignDepartmentRequest {
+  /** 部门ID, 为空或0时移出部门 */
+  department_id?: number;
+}
+
+/** customer.BulkDeleteRequest */
+export interface CustomerBulkDeleteRequest {
+  /** 要删除的记录ID列表 */
+  ids: number[];
+}
+
+/** customer.ButtonBaseOut */
+export interface CustomerButtonBaseOut {
+  /** 描述 */
+  descr?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** 是否激活 */
+  is_active?: boolean;
+  /** 名称 */
+  name?: string;
+  /** 排序字段 */
+  sort?: number;
+}
+
+/** customer.ButtonDetailOut */
+export interface CustomerButtonDetailOut {
+  /** API ID列表 */
+  api_ids?: number[];
+  /** 创建时间 */
+  created_at?: string;
+  /** 描述 */
+  descr?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** 是否激活 */
+  is_active?: boolean;
+  /** 菜单 */
+  menu?: CustomerMenuStandardOut;
+  /** 名称 */
+  name?: string;
+  /** 排序字段 */
+  sort?: number;
+  /** 更新时间 */
+  updated_at?: string;
+}
+
+/** customer.ButtonReply */
+export interface CustomerButtonReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CustomerButtonDetailOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** customer.ButtonStandardOut */
+export interface CustomerButtonStandardOut {
+  /** 创建时间 */
+  created_at?: string;
+  /** 描述 */
+  descr?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** 是否激活 */
+  is_active?: boolean;
+  /** 名称 */
+  name?: string;
+  /** 排序字段 */
+  sort?: number;
+  /** 更新时间 */
+  updated_at?: string;
+}
+
+/** customer.CaptchaOut */
+export interface CustomerCaptchaOut {
+  /** 挑战ID, 登录时随答案一起提交 */
+  captcha_id?: string;
+  /** 有效期(秒) */
+  expires_in?: number;
+  /** PNG图片的data URI */
+  image?: string;
+}
+
+/** customer.CaptchaReply */
+export interface CustomerCaptchaReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CustomerCaptchaOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** customer.CasbinModelOut */
+export interface CustomerCasbinModelOut {
+  /** 模型配置 */
+  content?: string;
+  /** 创建时间 */
+  created_at?: string;
+  /** 唯一标识, 内置模型为0 */
+  id?: number;
+  /** 是否生效 */
+  is_active?: boolean;
+  /** 操作人 */
+  operator?: string;
+  /** 变更说明 */
+  remark?: string;
+  /** 版本号, 内置模型为0 */
+  version?: number;
+}
+
+/** customer.CasbinModelReply */
+export interface CustomerCasbinModelReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CustomerCasbinModelOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** customer.CasbinModelValidateOut */
+export interface CustomerCasbinModelValidateOut {
+  /** 是否全部通过 */
+  passed?: boolean;
+  /** 样例请求结果 */
+  results?: AuthCasbinSampleResult[];
+}
+
+/** customer.CasbinModelValidateReply */
+export interface CustomerCasbinModelValidateReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CustomerCasbinModelValidateOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** customer.CreateApiRequest */
+export interface CustomerCreateApiRequest {
+  /** 描述信息 */
+  descr?: string;
+  /** 唯一标识 */
+  id: number;
+  /** 标签 */
+  label: string;
+  /** 请求方法 */
+  method: "GET" | "POST" | "PUT" | "DELETE" | "PATCH" | "WS";
+  /** URL地址 */
+  url: string;
+}
+
+/** customer.CreateButtonRequest */
+export interface CustomerCreateButtonRequest {
+  /** API ID列表 */
+  api_ids?: number[];
+  /** 描述信息 */
+  descr?: string;
+  /** 唯一标识 */
+  id: number;
+  /** 是否激活 */
+  is_active: boolean;
+  /** 菜单ID */
+  menu_id: number;
+  /** 名称 */
+  name: string;
+  /** 排序字段 */
+  sort: number;
+}
+
+/** customer.CreateMenuRequest */
+export interface CustomerCreateMenuRequest {
+  /** 权限ID列表 */
+  api_ids?: number[];
+  /** 组件路径 */
+  component: string;
+  /** 描述 */
+  descr?: string;
+  /** 唯一标识 */
+  id: number;
+  /** 是否激活 */
+  is_active?: boolean;
+  /** 菜单元信息 */
+  meta: CustomerMetaSchemas;
+  /** 名称 */
+  name: string;
+  /** 父级菜单ID */
+  parent_id?: number;
+  /** 前端路由路径 */
+  path: string;
+  /** 排序字段 */
+  sort: number;
+}
+
+/** customer.CreateOrUpdateDepartmentRequest */
+export interface CustomerCreateOrUpdateDepartmentRequest {
+  /** 描述信息 */
+  descr?: string;
+  /** 名称 */
+  name: string;
+  /** 父部门ID, 仅创建时生效, 调整上级部门使用移动接口 */
+  parent_id?: number;
+  /** 排序字段 */
+  sort?: number;
+}
+
+/** customer.CreateOrUpdateRoleRequest */
+export interface CustomerCreateOrUpdateRoleRequest {
+  /** APIID列表 */
+  api_ids?: number[];
+  /** 按钮ID列表 */
+  button_ids?: number[];
+  /** 数据范围, all:全部数据, department:本部门及下级部门, self:仅本人, 为空时为all */
+  data_scope?: "all" | "department" | "self";
+  /** 描述信息 */
+  descr?: string;
+  /** 菜单ID列表 */
+  menu_ids?: number[];
+  /** 名称 */
+  name: string;
+  /** 版本号, 更新时必填(也可通过If-Match请求头传递) */
+  version?: number;
+}
+
+/** customer.CreateOrUpdateUserGroupRequest */
+export interface CustomerCreateOrUpdateUserGroupRequest {
+  /** 描述信息 */
+  descr?: string;
+  /** 名称 */
+  name: string;
+  /** 角色ID列表 */
+  role_ids?: number[];
+  /** 用户ID列表 */
+  user_ids?: number[];
+}
+
+/** customer.CreateUserRequest */
+export interface CustomerCreateUserRequest {
+  /** 部门ID */
+  department_id?: number;
+  /** 是否激活 */
+  is_active?: boolean;
+  /** 是否是工作人员 */
+  is_staff?: boolean;
+  /** 密码 */
+  password: string;
+  /** 角色ID */
+  role_id: number;
+  /** 用户名 */
+  username: string;
+}
+
+/** customer.DeactivateUserOut */
+export interface CustomerDeactivateUserOut {
+  /** 用户名下仍有的记录, 需要在永久停用前转移给其他用户 */
+  owned?: CustomerOwnedGroupOut[];
+  /** 终止的会话数量 */
+  revoked_sessions?: number;
+  /** 停用后的用户信息 */
+  user?: CustomerUserStandardOut;
+}
+
+/** customer.DeactivateUserReply */
+export interface CustomerDeactivateUserReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CustomerDeactivateUserOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** customer.DeactivateUserRequest */
+export interface CustomerDeactivateUserRequest {
+  /** 宽限天数, 为空时使用默认的30天, 为0时在下一次定时检查时永久停用 */
+  grace_days?: number;
+}
+
+/** customer.DeleteDependenciesOut */
+export interface CustomerDeleteDependenciesOut {
+  /** 阻止删除的引用, 需要先解除引用或一起删除 */
+  blocking?: CustomerDeleteDependencyOut[];
+  /** 随之删除或解除关联的记录 */
+  cascades?: CustomerDeleteDependencyOut[];
+  /** 是否可以删除 */
+  deletable?: boolean;
+  /** 要删除的记录ID列表 */
+  ids?: number[];
+  /** 随之移除的Casbin组策略 */
+  policies?: string[][];
+}
+
+/** customer.DeleteDependenciesReply */
+export interface CustomerDeleteDependenciesReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CustomerDeleteDependenciesOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** customer.DeleteDependencyOut */
+export interface CustomerDeleteDependencyOut {
+  /** 引用记录ID */
+  id?: number;
+  /** 引用记录类型, user:用户, user_group:用户组, role:角色, menu:菜单, button:按钮 */
+  kind?: string;
+  /** 引用记录名称 */
+  name?: string;
+  /** 受影响的原因 */
+  reason?: string;
+}
+
+/** customer.DeleteSessionOut */
+export interface CustomerDeleteSessionOut {
+  /** 终止的会话数量 */
+  deleted?: number;
+}
+
+/** customer.DeleteSessionReply */
+export interface CustomerDeleteSessionReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CustomerDeleteSessionOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** customer.DepartmentBaseOut */
+export interface CustomerDepartmentBaseOut {
+  /** 描述 */
+  descr?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** 名称 */
+  name?: string;
+  /** 父部门ID */
+  parent_id?: number;
+  /** 排序字段 */
+  sort?: number;
+}
+
+/** customer.DepartmentDetailOut */
+export interface CustomerDepartmentDetailOut {
+  /** 创建时间 */
+  created_at?: string;
+  /** 描述 */
+  descr?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** 名称 */
+  name?: string;
+  /** 父部门 */
+  parent?: CustomerDepartmentBaseOut;
+  /** 父部门ID */
+  parent_id?: number;
+  /** 部门路径 */
+  path?: string;
+  /** 排序字段 */
+  sort?: number;
+  /** 更新时间 */
+  updated_at?: string;
+}
+
+/** customer.DepartmentReply */
+export interface CustomerDepartmentReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CustomerDepartmentDetailOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** customer.DepartmentStandardOut */
+export interface CustomerDepartmentStandardOut {
+  /** 创建时间 */
+  created_at?: string;
+  /** 描述 */
+  descr?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** 名称 */
+  name?: string;
+  /** 父部门ID */
+  parent_id?: number;
+  /** 部门路径 */
+  path?: string;
+  /** 排序字段 */
+  sort?: number;
+  /** 更新时间 */
+  updated_at?: string;
+}
+
+/** customer.DepartmentTreeNode */
+export interface CustomerDepartmentTreeNode {
+  /** 下级部门 */
+  children?: CustomerDepartmentTreeNode[];
+  /** 描述 */
+  descr?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** 名称 */
+  name?: string;
+  /** 父部门ID */
+  parent_id?: number;
+  /** 排序字段 */
+  sort?: number;
+}
+
+/** customer.DepartmentTreeReply */
+export interface CustomerDepartmentTreeReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CustomerDepartmentTreeNode[];
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** customer.EffectiveRoleReply */
+export interface CustomerEffectiveRoleReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CustomerRoleBaseOut[];
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** customer.HandoverGroupOut */
+export interface CustomerHandoverGroupOut {
+  /** 记录类型 */
+  kind?: string;
+  /** 记录类型名称 */
+  label?: string;
+  /** 转移的记录数量 */
+  reassigned?: number;
+}
+
+/** customer.HandoverReply */
+export interface CustomerHandoverReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CustomerHandoverGroupOut[];
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** customer.HandoverRequest */
+export interface CustomerHandoverRequest {
+  /** 转移的记录类型, 为空时转移全部类型 */
+  kinds: string[];
+  /** 接收记录的用户ID */
+  to_user_id: number;
+}
+
+/** customer.ImpersonateOut */
+export interface CustomerImpersonateOut {
+  /** 代登录访问令牌, 到期后不能刷新 */
+  access_token?: string;
+  /** 令牌过期时间 */
+  expires_at?: string;
+  /** 代为登录的管理员用户名 */
+  impersonator?: string;
+  /** 代登录会话ID, 可通过终止会话提前结束代登录 */
+  session_id?: string;
+  /** 被代登录的用户名 */
+  username?: string;
+}
+
+/** customer.ImpersonateReply */
+export interface CustomerImpersonateReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CustomerImpersonateOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** customer.LoginOut */
+export interface CustomerLoginOut {
+  /** 登录令牌 */
+  access_token?: string;
+  /** 刷新令牌 */
+  refresh_token?: string;
+}
+
+/** customer.LoginRecordStandardOut */
+export interface CustomerLoginRecordStandardOut {
+  /** 唯一标识 */
+  id?: number;
+  /** IP地址, 没有查看敏感字段权限时掩码 */
+  ip_address?: string;
+  /** 登录状态 */
+  is_active?: boolean;
+  /** 登录时间 */
+  login_at?: string;
+  /** 用户浏览器信息 */
+  user_agent?: string;
+  /** 名称 */
+  username?: string;
+}
+
+/** customer.LoginReply */
+export interface CustomerLoginReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CustomerLoginOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** customer.LoginRequest */
+export interface CustomerLoginRequest {
+  /** 验证码答案 */
+  captcha_answer?: string;
+  /** 验证码挑战ID, 登录失败次数过多时必填 */
+  captcha_id?: string;
+  /** 密码 */
+  password: string;
+  /** 用户名 */
+  username: string;
+}
+
+/** customer.MenuDetailOut */
+export interface CustomerMenuDetailOut {
+  /** API ID列表 */
+  api_ids?: number[];
+  /** 组件路径 */
+  component?: string;
+  /** 创建时间 */
+  created_at?: string;
+  /** 描述 */
+  descr?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** 是否激活 */
+  is_active?: boolean;
+  /** 菜单信息 */
+  meta?: CustomerMetaSchemas;
+  /** 名称 */
+  name?: string;
+  /** 父级菜单 */
+  parent?: CustomerMenuStandardOut;
+  /** 前端路由 */
+  path?: string;
+  /** 排序字段 */
+  sort?: number;
+  /** 更新时间 */
+  updated_at?: string;
+}
+
+/** customer.MenuReply */
+export interface CustomerMenuReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CustomerMenuDetailOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** customer.MenuStandardOut */
+export interface CustomerMenuStandardOut {
+  /** 组件路径 */
+  component?: string;
+  /** 创建时间 */
+  created_at?: string;
+  /** 描述 */
+  descr?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** 是否激活 */
+  is_active?: boolean;
+  /** 菜单信息 */
+  meta?: CustomerMetaSchemas;
+  /** 名称 */
+  name?: string;
+  /** 前端路由 */
+  path?: string;
+  /** 排序字段 */
+  sort?: number;
+  /** 更新时间 */
+  updated_at?: string;
+}
+
+/** customer.MenuTreeNode */
+export interface CustomerMenuTreeNode {
+  /** 按钮 */
+  buttons?: CustomerButtonBaseOut[];
+  /** 子菜单 */
+  children?: CustomerMenuTreeNode[];
+  /** 组件路径 */
+  component?: string;
+  /** 描述 */
+  descr?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** 是否激活 */
+  is_active?: boolean;
+  /** 菜单信息 */
+  meta?: CustomerMetaSchemas;
+  /** 名称 */
+  name?: string;
+  /** 前端路由 */
+  path?: string;
+  /** 排序字段 */
+  sort?: number;
+}
+
+/** customer.MetaSchemas */
+export interface CustomerMetaSchemas {
+  /** 图标 */
+  icon?: string;
+  /** 标题 */
+  title?: string;
+}
+
+/** customer.MoveDepartmentRequest */
+export interface CustomerMoveDepartmentRequest {
+  /** 新的父部门ID, 为空或0时移动为根部门 */
+  parent_id?: number;
+}
+
+/** customer.OidcLoginOut */
+export interface CustomerOidcLoginOut {
+  /** 授权地址 */
+  auth_url?: string;
+}
+
+/** customer.OidcLoginReply */
+export interface CustomerOidcLoginReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CustomerOidcLoginOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** customer.OidcProviderOut */
+export interface CustomerOidcProviderOut {
+  /** 显示名称 */
+  display_name?: string;
+  /** 标识 */
+  name?: string;
+}
+
+/** customer.OidcProvidersOut */
+export interface CustomerOidcProvidersOut {
+  /** 是否允许本地用户名密码登录 */
+  local_login?: boolean;
+  /** 身份提供方 */
+  providers?: CustomerOidcProviderOut[];
+}
+
+/** customer.OidcProvidersReply */
+export interface CustomerOidcProvidersReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CustomerOidcProvidersOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** customer.OwnedGroupOut */
+export interface CustomerOwnedGroupOut {
+  /** 记录列表, 最多列出50条 */
+  items?: CustomerOwnedObjectOut[];
+  /** 记录类型 */
+  kind?: string;
+  /** 记录类型名称 */
+  label?: string;
+  /** 记录总数 */
+  total?: number;
+}
+
+/** customer.OwnedObjectOut */
+export interface CustomerOwnedObjectOut {
+  /** 记录ID */
+  id?: number;
+  /** 记录名称 */
+  name?: string;
+}
+
+/** customer.OwnedReply */
+export interface CustomerOwnedReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CustomerOwnedGroupOut[];
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** customer.PagApiReply */
+export interface CustomerPagApiReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CommonPagCustomerApiStandardOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** customer.PagButtonReply */
+export interface CustomerPagButtonReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CommonPagCustomerButtonStandardOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** customer.PagCasbinModelReply */
+export interface CustomerPagCasbinModelReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CommonPagCustomerCasbinModelOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** customer.PagDepartmentReply */
+export interface CustomerPagDepartmentReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CommonPagCustomerDepartmentStandardOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** customer.PagLoginRecordReply */
+export interface CustomerPagLoginRecordReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CommonPagCustomerLoginRecordStandardOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** customer.PagMenuReply */
+export interface CustomerPagMenuReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CommonPagCustomerMenuStandardOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** customer.PagRoleReply */
+export interface CustomerPagRoleReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CommonPagCustomerRoleStandardOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** customer.PagSigningKeyReply */
+export interface CustomerPagSigningKeyReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CommonPagCustomerSigningKeyOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** customer.PagUserGroupReply */
+export interface CustomerPagUserGroupReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CommonPagCustomerUserGroupStandardOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** customer.PagUserReply */
+export interface CustomerPagUserReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CommonPagCustomerUserDetailOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** customer.PasswordHashCountOut */
+export interface CustomerPasswordHashCountOut {
+  /** 哈希算法, 无法识别时为unknown */
+  algorithm?: string;
+  /** 未使用当前算法和参数的用户数量 */
+  legacy?: number;
+  /** 用户数量 */
+  total?: number;
+}
+
+/** customer.PasswordHashReportOut */
+export interface CustomerPasswordHashReportOut {
+  /** 当前配置的哈希算法 */
+  algorithm?: string;
+  /** 按算法分组的统计 */
+  algorithms?: CustomerPasswordHashCountOut[];
+  /** 已使用当前算法和参数的用户数量 */
+  current?: number;
+  /** 仍使用旧算法或旧参数的用户数量, 这些用户下次登录成功时重新哈希 */
+  legacy?: number;
+  /** 用户总数 */
+  total?: number;
+}
+
+/** customer.PasswordHashReportReply */
+export interface CustomerPasswordHashReportReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CustomerPasswordHashReportOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** customer.PatchPasswordRequest */
+export interface CustomerPatchPasswordRequest {
+  /** 确认密码 */
+  confirm_password: string;
+  /** 新密码 */
+  new_password: string;
+  /** 原密码 */
+  old_password: string;
+}
+
+/** customer.PreferenceOut */
+export interface CustomerPreferenceOut {
+  /** 命名空间 */
+  namespace?: string;
+  /** 更新时间 */
+  updated_at?: string;
+  /** 偏好内容 */
+  value?: Record<string, unknown>;
+}
+
+/** customer.PreferenceReply */
+export interface CustomerPreferenceReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CustomerPreferenceOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** customer.PreferencesReply */
+export interface CustomerPreferencesReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CustomerPreferenceOut[];
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** customer.RbacImportItemOut */
+export interface CustomerRbacImportItemOut {
+  /** 处理动作(create/update/unchanged/skip/conflict) */
+  action?: string;
+  /** 与已有数据不同的字段 */
+  fields?: string[];
+  /** 引用标识 */
+  key?: string;
+  /** 类型(casbin_model/api/menu/button/role) */
+  kind?: string;
+}
+
+/** customer.RbacImportOut */
+export interface CustomerRbacImportOut {
+  /** 是否已写入 */
+  applied?: boolean;
+  /** 冲突处理方式 */
+  conflict?: string;
+  /** 是否只比较差异 */
+  dry_run?: boolean;
+  /** 各条目的处理结果, 不包含未变化的条目 */
+  items?: CustomerRbacImportItemOut[];
+  /** 导入前后Casbin策略的差异(unified diff) */
+  policy_diff?: string;
+  /** 各处理动作的条目数 */
+  summary?: Record<string, number>;
+}
+
+/** customer.RbacImportReply */
+export interface CustomerRbacImportReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CustomerRbacImportOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** customer.RefreshTokenRequest */
+export interface CustomerRefreshTokenRequest {
+  /** 刷新令牌, 开启Cookie认证时可以为空, 从Cookie中读取 */
+  refresh_token?: string;
+}
+
+/** customer.ResetPasswordRequest */
+export interface CustomerResetPasswordRequest {
+  /** 确认密码 */
+  confirm_password: string;
+  /** 新密码 */
+  new_password: string;
+}
+
+/** customer.RoleBaseOut */
+export interface CustomerRoleBaseOut {
+  /** 数据范围 */
+  data_scope?: string;
+  /** 描述 */
+  descr?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** 名称 */
+  name?: string;
+}
+
+/** customer.RoleDetailOut */
+export interface CustomerRoleDetailOut {
+  /** APIID列表 */
+  api_ids?: number[];
+  /** 按钮ID列表 */
+  button_ids?: number[];
+  /** 创建时间 */
+  created_at?: string;
+  /** 数据范围 */
+  data_scope?: string;
+  /** 描述 */
+  descr?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** 菜单ID列表 */
+  menu_ids?: number[];
+  /** 名称 */
+  name?: string;
+  /** 更新时间 */
+  updated_at?: string;
+  /** 版本号 */
+  version?: number;
+}
+
+/** customer.RoleMenuTreeReply */
+export interface CustomerRoleMenuTreeReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CustomerMenuTreeNode[];
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** customer.RoleReply */
+export interface CustomerRoleReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CustomerRoleDetailOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** customer.RoleStandardOut */
+export interface CustomerRoleStandardOut {
+  /** 创建时间 */
+  created_at?: string;
+  /** 数据范围 */
+  data_scope?: string;
+  /** 描述 */
+  descr?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** 名称 */
+  name?: string;
+  /** 更新时间 */
+  updated_at?: string;
+  /** 版本号 */
+  version?: number;
+}
+
+/** customer.RotateSigningKeyRequest */
+export interface CustomerRotateSigningKeyRequest {
+  /** 是否立即停用旧密钥, 用于密钥泄露的场景, 旧密钥签发的令牌将立即失效 */
+  revoke_previous?: boolean;
+  /** 令牌类型(access/refresh), 为空时同时轮换两种令牌的密钥 */
+  token_type?: "access" | "refresh";
+}
+
+/** customer.SigningKeyOut */
+export interface CustomerSigningKeyOut {
+  /** 签名方法 */
+  algorithm?: string;
+  /** 创建时间 */
+  created_at?: string;
+  /** ID */
+  id?: number;
+  /** 密钥标识 */
+  kid?: string;
+  /** 操作人 */
+  operator?: string;
+  /** 公钥, HMAC密钥为空 */
+  public_key?: string;
+  /** 停用时间 */
+  retire_at?: string;
+  /** 状态(current/retiring/retired) */
+  status?: string;
+  /** 令牌类型 */
+  token_type?: string;
+}
+
+/** customer.SigningKeyRotateOut */
+export interface CustomerSigningKeyRotateOut {
+  /** 新生成的密钥 */
+  keys?: CustomerSigningKeyOut[];
+}
+
+/** customer.SigningKeyRotateReply */
+export interface CustomerSigningKeyRotateReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CustomerSigningKeyRotateOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** customer.SyncApiRequest */
+export interface CustomerSyncApiRequest {
+  /** 是否按模块名重新设置已有API的标签 */
+  tag_module?: boolean;
+}
+
+/** customer.UpdateApiRequest */
+export interface CustomerUpdateApiRequest {
+  /** 描述信息 */
+  descr?: string;
+  /** 标签 */
+  label: string;
+  /** 请求方法 */
+  method: "GET" | "POST" | "PUT" | "DELETE" | "PATCH" | "WS";
+  /** URL地址 */
+  url: string;
+}
+
+/** customer.UpdateButtonRequest */
+export interface CustomerUpdateButtonRequest {
+  /** API ID列表 */
+  api_ids?: number[];
+  /** 描述信息 */
+  descr?: string;
+  /** 是否激活 */
+  is_active?: boolean;
+  /** 菜单ID */
+  menu_id: number;
+  /** 名称 */
+  name: string;
+  /** 排序字段 */
+  sort?: number;
+}
+
+/** customer.UpdateCasbinModelRequest */
+export interface CustomerUpdateCasbinModelRequest {
+  /** 模型配置 */
+  content: string;
+  /** 变更说明 */
+  remark?: string;
+  /** 样例请求，使用当前策略和新模型求值并与期望结果比对 */
+  samples?: AuthCasbinSample[];
+}
+
+/** customer.UpdateMenuRequest */
+export interface CustomerUpdateMenuRequest {
+  /** API ID列表 */
+  api_ids?: number[];
+  /** 组件路径 */
+  component: string;
+  /** 描述信息 */
+  descr?: string;
+  /** 是否激活 */
+  is_active?: boolean;
+  /** 菜单元信息 */
+  meta: CustomerMetaSchemas;
+  /** 名称 */
+  name: string;
+  /** 父级菜单ID */
+  parent_id?: number;
+  /** 前端路由路径 */
+  path: string;
+  /** 排序字段 */
+  sort: number;
+}
+
+/** customer.UpdateUserRequest */
+export interface CustomerUpdateUserRequest {
+  /** 是否激活 */
+  is_active?: boolean;
+  /** 是否是工作人员 */
+  is_staff?: boolean;
+  /** 角色ID */
+  role_id: number;
+  /** 用户名 */
+  username: string;
+}
+
+/** customer.UserDetailOut */
+export interface CustomerUserDetailOut {
+  /** 创建时间 */
+  created_at?: string;
+  /** 停用时间, 未停用时为空 */
+  deactivated_at?: string;
+  /** 部门 */
+  department?: CustomerDepartmentBaseOut;
+  /** 部门ID */
+  department_id?: number;
+  /** 计划永久停用时间, 此前可以重新启用用户 */
+  disable_at?: string;
+  /** 永久停用时间, 永久停用后不能重新启用 */
+  disabled_at?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** 是否激活 */
+  is_active?: boolean;
+  /** 是否是工作人员 */
+  is_staff?: boolean;
+  /** 是否需要修改密码 */
+  must_change_password?: boolean;
+  /** 角色 */
+  role?: CustomerRoleBaseOut;
+  /** 更新时间 */
+  updated_at?: string;
+  /** 用户名 */
+  username?: string;
+}
+
+/** customer.UserGroupDetailOut */
+export interface CustomerUserGroupDetailOut {
+  /** 创建时间 */
+  created_at?: string;
+  /** 描述 */
+  descr?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** 名称 */
+  name?: string;
+  /** 角色ID列表 */
+  role_ids?: number[];
+  /** 更新时间 */
+  updated_at?: string;
+  /** 用户ID列表 */
+  user_ids?: number[];
+}
+
+/** customer.UserGroupMemberRequest */
+export interface CustomerUserGroupMemberRequest {
+  /** 用户ID列表 */
+  user_ids: number[];
+}
+
+/** customer.UserGroupReply */
+export interface CustomerUserGroupReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CustomerUserGroupDetailOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** customer.UserGroupStandardOut */
+export interface CustomerUserGroupStandardOut {
+  /** 创建时间 */
+  created_at?: string;
+  /** 描述 */
+  descr?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** 名称 */
+  name?: string;
+  /** 更新时间 */
+  updated_at?: string;
+}
+
+/** customer.UserIdentitiesReply */
+export interface CustomerUserIdentitiesReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CustomerUserIdentityOut[];
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** customer.UserIdentityOut */
+export interface CustomerUserIdentityOut {
+  /** 关联时间 */
+  created_at?: string;
+  /** 邮箱 */
+  email?: string;
+  /** 最近登录时间 */
+  last_login_at?: string;
+  /** 身份提供方 */
+  provider?: string;
+  /** 外部身份标识 */
+  subject?: string;
+}
+
+/** customer.UserReply */
+export interface CustomerUserReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CustomerUserDetailOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** customer.UserSessionOut */
+export interface CustomerUserSessionOut {
+  /** 访问令牌过期时间 */
+  access_expires_at?: string;
+  /** 访问令牌ID */
+  access_id?: string;
+  /** 是否为当前请求所属的会话 */
+  current?: boolean;
+  /** 设备 */
+  device?: string;
+  /** 会话过期时间 */
+  expires_at?: string;
+  /** IP地址, 没有查看敏感字段权限时掩码 */
+  ip_address?: string;
+  /** 登录时间 */
+  issued_at?: string;
+  /** 登录方式 */
+  method?: string;
+  /** 刷新令牌ID */
+  refresh_id?: string;
+  /** 最近签发令牌时间 */
+  refreshed_at?: string;
+  /** 会话ID */
+  session_id?: string;
+  /** 客户端信息 */
+  user_agent?: string;
+}
+
+/** customer.UserSessionsReply */
+export interface CustomerUserSessionsReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CustomerUserSessionOut[];
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** customer.UserStandardOut */
+export interface CustomerUserStandardOut {
+  /** 创建时间 */
+  created_at?: string;
+  /** 停用时间, 未停用时为空 */
+  deactivated_at?: string;
+  /** 部门ID */
+  department_id?: number;
+  /** 计划永久停用时间, 此前可以重新启用用户 */
+  disable_at?: string;
+  /** 永久停用时间, 永久停用后不能重新启用 */
+  disabled_at?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** 是否激活 */
+  is_active?: boolean;
+  /** 是否是工作人员 */
+  is_staff?: boolean;
+  /** 是否需要修改密码 */
+  must_change_password?: boolean;
+  /** 更新时间 */
+  updated_at?: string;
+  /** 用户名 */
+  username?: string;
+}
+
+/** customer.ValidateCasbinModelRequest */
+export interface CustomerValidateCasbinModelRequest {
+  /** 模型配置 */
+  content: string;
+  /** 样例请求，使用当前策略和新模型求值并与期望结果比对 */
+  samples?: AuthCasbinSample[];
+}
+
+/** fileutil.FileInfo */
+export interface FileutilFileInfo {
+  children?: FileutilFileInfo[];
+  is_dir?: boolean;
+  /** 标准化时间格式（RFC3339） */
+  mod_time?: string;
+  name?: string;
+  size?: number;
+}
+
+/** jobs.ArtifactOut */
+export interface JobsArtifactOut {
+  /** SHA256校验和 */
+  checksum?: string;
+  /** 收集时间 */
+  created_at?: string;
+  /** ID */
+  id?: number;
+  /** 相对于工作目录的文件路径 */
+  name?: string;
+  /** 执行记录ID */
+  record_id?: number;
+  /** 文件大小(字节) */
+  size?: number;
+}
+
+/** jobs.CreateScheduleRequest */
+export interface JobsCreateScheduleRequest {
+  /** 交易日历选项(trading_day: 仅交易日执行, skip_holiday: 跳过节假日), 为空时不受限制 */
+  calendar_mode?: "trading_day" | "skip_holiday";
+  /** 绑定的oes集群号, 触发时注入集群上下文的环境变量(COLONY_NUM、SYSTEM_TYPE、MON_NODE_IP、PACKAGE_VERSION) */
+  colony?: string;
+  /** 命令行参数 */
+  command_args?: string;
+  /** 环境变量(JSON对象) */
+  env_vars?: string;
+  /** 是否启用 */
+  is_enabled?: boolean;
+  /** 是否重试 */
+  is_retry?: boolean;
+  /** 隔离执行配置, 为空时使用脚本的配置 */
+  isolation?: JobsIsolationSpec;
+  /** 最大重试次数 */
+  max_retries?: number;
+  /** 计划任务名称 */
+  name: string;
+  /** 脚本参数, 按脚本的参数定义校验, 日期参数的默认值today在每次触发时取当天 */
+  params?: Record<string, string>;
+  /** 重试间隔时间(秒) */
+  retry_interval?: number;
+  /** 脚本ID */
+  script_id: number;
+  /** Cron 表达式 */
+  specification: string;
+  /** 超时时间(秒) */
+  timeout?: number;
+  /** 工作目录 */
+  work_dir?: string;
+}
+
+/** jobs.CreateScriptRecordRequest */
+export interface JobsCreateScriptRecordRequest {
+  /** 命令行参数 */
+  command_args?: string;
+  /** 环境变量 (JSON对象) */
+  env_vars?: string;
+  /** 脚本参数, 按脚本的参数定义校验, 未提交的参数使用默认值 */
+  params?: Record<string, string>;
+  /** 脚本ID */
+  script_id: number;
+  /** 超时时间(秒) */
+  timeout: number;
+  /** 工作目录 */
+  work_dir?: string;
+}
+
+/** jobs.EnvPreviewItemOut */
+export interface JobsEnvPreviewItemOut {
+  /** 变量名 */
+  name?: string;
+  /** 来源(colony: 集群上下文, schedule: 计划任务配置) */
+  source?: string;
+  /** 计划任务配置的变量值是否引用了集群上下文变量(${NAME}) */
+  templated?: boolean;
+  /** 变量值, 计划任务配置的变量值已掩码 */
+  value?: string;
+}
+
+/** jobs.EnvPreviewOut */
+export interface JobsEnvPreviewOut {
+  /** 绑定的oes集群号 */
+  colony?: string;
+  /** 触发时注入的环境变量, 按变量名排序 */
+  env?: JobsEnvPreviewItemOut[];
+  /** 计划任务ID */
+  schedule_id?: number;
+}
+
+/** jobs.EnvPreviewReply */
+export interface JobsEnvPreviewReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: JobsEnvPreviewOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** jobs.EnvVarsOut */
+export interface JobsEnvVarsOut {
+  /** 环境变量(JSON对象) */
+  env_vars?: string;
+}
+
+/** jobs.EnvVarsReply */
+export interface JobsEnvVarsReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: JobsEnvVarsOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** jobs.ImportTradingCalendarRequest */
+export interface JobsImportTradingCalendarRequest {
+  /** 节假日列表 */
+  holidays: JobsTradingHolidayItem[];
+  /** 年份 */
+  year: number;
+}
+
+/** jobs.IsolationSpec */
+export interface JobsIsolationSpec {
+  /** CPU核数上限, 为0时不限制 */
+  cpus?: number;
+  /** 容器镜像, 为空时使用配置的默认镜像, 仅container方式 */
+  image?: string;
+  /** 内存上限(MB), 为0时不限制 */
+  memory_mb?: number;
+  /** 隔离方式(container: 容器, cgroup: 限制资源), 为空时不隔离 */
+  mode?: "container" | "cgroup";
+  /** 进程数上限, 为0时不限制 */
+  pids_limit?: number;
+  /** 执行用户, 为空时使用配置的默认用户, 只能使用配置允许的用户 */
+  user?: string;
+}
+
+/** jobs.ListArtifactReply */
+export interface JobsListArtifactReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: JobsArtifactOut[];
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** jobs.ListLableReply */
+export interface JobsListLableReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: string[];
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** jobs.ListProjectReply */
+export interface JobsListProjectReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: string[];
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** jobs.PagScheduleReply */
+export interface JobsPagScheduleReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CommonPagJobsScheduleDetailOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** jobs.PagScheduleSkipReply */
+export interface JobsPagScheduleSkipReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CommonPagJobsScheduleSkipOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** jobs.PagScriptRecordReply */
+export interface JobsPagScriptRecordReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CommonPagJobsScriptRecordDetailOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** jobs.PagScriptReply */
+export interface JobsPagScriptReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CommonPagJobsScriptStandardOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** jobs.PagTradingHolidayReply */
+export interface JobsPagTradingHolidayReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CommonPagJobsTradingHolidayOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** jobs.ScheduleDetailOut */
+export interface JobsScheduleDetailOut {
+  /** 交易日历选项 */
+  calendar_mode?: string;
+  /** 绑定的oes集群号 */
+  colony?: string;
+  /** 命令行参数 */
+  command_args?: string;
+  /** 创建时间 */
+  created_at?: string;
+  /** 环境变量(JSON对象), 变量值已掩码 */
+  env_vars?: string;
+  /** 计划任务ID */
+  id?: number;
+  /** 是否启用 */
+  is_enabled?: boolean;
+  /** 是否重试 */
+  is_retry?: boolean;
+  /** 隔离执行配置, 为空时使用脚本的配置 */
+  isolation?: JobsIsolationSpec;
+  /** 最大重试次数 */
+  max_retries?: number;
+  /** 名称 */
+  name?: string;
+  /** 脚本参数 */
+  params?: Record<string, string>;
+  /** 重试间隔时间(秒) */
+  retry_interval?: number;
+  /** 脚本 */
+  script?: JobsScriptStandardOut;
+  /** Cron 表达式 */
+  specification?: string;
+  /** 超时时间(秒) */
+  timeout?: number;
+  /** 更新时间 */
+  updated_at?: string;
+  /** 用户名 */
+  username?: string;
+  /** 工作目录 */
+  work_dir?: string;
+}
+
+/** jobs.ScheduleReply */
+export interface JobsScheduleReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: JobsScheduleDetailOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** jobs.ScheduleSkipOut */
+export interface JobsScheduleSkipOut {
+  /** 交易日历选项 */
+  calendar_mode?: string;
+  /** 跳过说明 */
+  detail?: string;
+  /** ID */
+  id?: number;
+  /** 跳过原因(weekend/holiday) */
+  reason?: string;
+  /** 计划任务ID */
+  schedule_id?: number;
+  /** 计划任务名称 */
+  schedule_name?: string;
+  /** 跳过时间 */
+  skipped_at?: string;
+}
+
+/** jobs.ScriptDiagnosticOut */
+export interface JobsScriptDiagnosticOut {
+  /** 规则编号 */
+  code?: string;
+  /** 列号, 0表示未知 */
+  column?: number;
+  /** 级别(error, warning, info) */
+  level?: string;
+  /** 行号, 0表示与具体行无关 */
+  line?: number;
+  /** 说明 */
+  message?: string;
+  /** 检查来源(builtin, bash, sh, python3, shellcheck) */
+  source?: string;
+}
+
+/** jobs.ScriptDryRunOut */
+export interface JobsScriptDryRunOut {
+  /** 耗时(秒) */
+  duration?: number;
+  /** 退出码, 超时时为-1 */
+  exit_code?: number;
+  /** 标准输出和标准错误 */
+  output?: string;
+  /** 是否超时 */
+  timed_out?: boolean;
+  /** 输出是否被截断 */
+  truncated?: boolean;
+}
+
+/** jobs.ScriptParam */
+export interface JobsScriptParam {
+  /** 默认值 */
+  default?: string;
+  /** 可选值, 类型为enum时必填 */
+  enum?: string[];
+  /** 传入方式(env, arg), 默认为env */
+  inject?: string;
+  /** 显示名称 */
+  label?: string;
+  /** 参数名, 小写字母开头, 只能包含小写字母、数字和下划线 */
+  name?: string;
+  /** 是否必填 */
+  required?: boolean;
+  /** 类型(string, int, bool, date, enum) */
+  type?: string;
+}
+
+/** jobs.ScriptParamValue */
+export interface JobsScriptParamValue {
+  /** 传入方式(env, arg) */
+  inject?: string;
+  /** 参数名 */
+  name?: string;
+  /** 参数值 */
+  value?: string;
+}
+
+/** jobs.ScriptRecordDetailOut */
+export interface JobsScriptRecordDetailOut {
+  /** 命令行参数 */
+  command_args?: string;
+  /** 创建时间 */
+  created_at?: string;
+  /** 环境变量(JSON对象), 变量值已掩码 */
+  env_vars?: string;
+  /** 错误信息 */
+  error_message?: string;
+  /** 退出码 */
+  exit_code?: number;
+  /** 失败原因(oom_killed: 超出内存限制, limit_killed: 超出其他资源限制), 不是因资源限制失败时为空 */
+  failure_reason?: string;
+  /** 脚本执行记录ID */
+  id?: number;
+  /** 实际使用的隔离执行配置, 不隔离时为空 */
+  isolation?: JobsIsolationSpec;
+  /** 解析后的脚本参数 */
+  params?: JobsScriptParamValue[];
+  /** 续跑次数 */
+  resume_count?: number;
+  /** 续跑的原执行记录ID */
+  resume_of?: number;
+  /** 续跑生成的执行记录ID */
+  resumed_id?: number;
+  /** 脚本信息 */
+  script?: JobsScriptStandardOut;
+  /** 执行状态(0-待执行,1-执行中,2-成功,3-失败,4-超时,5-崩溃,6-中断) */
+  status?: number;
+  /** 超时时间(秒) */
+  timeout?: number;
+  /** 触发类型(cron/api) */
+  trigger_type?: string;
+  /** 更新时间 */
+  updated_at?: string;
+  /** 用户名 */
+  username?: string;
+  /** 工作目录 */
+  work_dir?: string;
+}
+
+/** jobs.ScriptRecordReply */
+export interface JobsScriptRecordReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: JobsScriptRecordDetailOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** jobs.ScriptReply */
+export interface JobsScriptReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: JobsScriptStandardOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** jobs.ScriptStandardOut */
+export interface JobsScriptStandardOut {
+  /** 产物文件匹配规则 */
+  artifacts?: string[];
+  /** 创建时间 */
+  created_at?: string;
+  /** 描述信息 */
+  descr?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** 是否是内置脚本 */
+  is_builtin?: boolean;
+  /** 隔离执行配置, 不隔离时为空 */
+  isolation?: JobsIsolationSpec;
+  /** 标签 */
+  label?: string;
+  /** 脚本语言 */
+  language?: string;
+  /** 名称 */
+  name?: string;
+  /** 参数定义, 用于生成触发执行时的参数表单 */
+  params?: JobsScriptParam[];
+  /** 项目 */
+  project?: string;
+  /** 中断后是否续跑 */
+  resumable?: boolean;
+  /** 状态 */
+  status?: boolean;
+  /** 更新时间 */
+  updated_at?: string;
+  /** 用户名 */
+  username?: string;
+}
+
+/** jobs.ScriptValidateOut */
+export interface JobsScriptValidateOut {
+  /** 发现的问题 */
+  diagnostics?: JobsScriptDiagnosticOut[];
+  /** 试运行结果, 未试运行时为空 */
+  dry_run?: JobsScriptDryRunOut;
+  /** 是否通过检查, 存在error级别的问题或试运行失败时为false */
+  valid?: boolean;
+}
+
+/** jobs.ScriptValidateReply */
+export interface JobsScriptValidateReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: JobsScriptValidateOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** jobs.TradingDayOut */
+export interface JobsTradingDayOut {
+  /** 日期 */
+  date?: string;
+  /** 节假日名称 */
+  holiday_name?: string;
+  /** 是否为节假日 */
+  is_holiday?: boolean;
+  /** 是否为交易日 */
+  is_trading_day?: boolean;
+}
+
+/** jobs.TradingDayReply */
+export interface JobsTradingDayReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: JobsTradingDayOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** jobs.TradingHolidayItem */
+export interface JobsTradingHolidayItem {
+  /** 日期(YYYY-MM-DD) */
+  date: string;
+  /** 节假日名称 */
+  name?: string;
+}
+
+/** jobs.TradingHolidayOut */
+export interface JobsTradingHolidayOut {
+  /** 日期 */
+  date?: string;
+  /** ID */
+  id?: number;
+  /** 节假日名称 */
+  name?: string;
+  /** 用户名 */
+  username?: string;
+  /** 年份 */
+  year?: number;
+}
+
+/** jobs.UpdateScheduleRequest */
+export interface JobsUpdateScheduleRequest {
+  /** 交易日历选项(trading_day: 仅交易日执行, skip_holiday: 跳过节假日), 为空时不受限制 */
+  calendar_mode?: "trading_day" | "skip_holiday";
+  /** 绑定的oes集群号, 触发时注入集群上下文的环境变量(COLONY_NUM、SYSTEM_TYPE、MON_NODE_IP、PACKAGE_VERSION) */
+  colony?: string;
+  /** 命令行参数 */
+  command_args?: string;
+  /** 环境变量(JSON对象) */
+  env_vars?: string;
+  /** 是否启用 */
+  is_enabled?: boolean;
+  /** 是否重试 */
+  is_retry?: boolean;
+  /** 隔离执行配置, 为空时使用脚本的配置 */
+  isolation?: JobsIsolationSpec;
+  /** 最大重试次数 */
+  max_retries?: number;
+  /** 计划任务名称 */
+  name: string;
+  /** 脚本参数, 按脚本的参数定义校验, 日期参数的默认值today在每次触发时取当天 */
+  params?: Record<string, string>;
+  /** 重试间隔时间(秒) */
+  retry_interval?: number;
+  /** 脚本ID */
+  script_id: number;
+  /** Cron 表达式 */
+  specification: string;
+  /** 超时时间(秒) */
+  timeout?: number;
+  /** 工作目录 */
+  work_dir?: string;
+}
+
+/** jobs.ValidateScriptRequest */
+export interface JobsValidateScriptRequest {
+  /** 试运行的命令参数 */
+  command_args?: string;
+  /** 是否在沙箱中试运行, 需要配置试运行沙箱 */
+  dry_run?: boolean;
+}
+
+/** mds.CreateMdsBackfillRequest */
+export interface MdsCreateMdsBackfillRequest {
+  /** 同时回放的交易日数, 默认为1 */
+  concurrency?: number;
+  /** 结束日期, 格式为2006-01-02 */
+  end_date: string;
+  /** mds集群ID */
+  mds_colony_id: number;
+  /** 回放脚本名称, 需为mds的内置命令脚本, 执行参数为集群号和交易日 */
+  script: string;
+  /** 是否按日期顺序回放, 开启后每个交易日依赖前一个交易日回放成功 */
+  sequential?: boolean;
+  /** 开始日期, 格式为2006-01-02 */
+  start_date: string;
+  /** 每日回放的超时时间(秒), 默认为3600 */
+  timeout?: number;
+}
+
+/** mds.CreateOrUpdateIngestSourceRequest */
+export interface MdsCreateOrUpdateIngestSourceRequest {
+  /** 校验文件后缀, 如.md5, 为空时不核对校验文件 */
+  checksum_suffix?: string;
+  /** 字段定义, 每行一个"名称:类型" */
+  columns: string;
+  /** 字段分隔符, 为空时为逗号 */
+  delimiter?: string;
+  /** 是否包含表头 */
+  has_header?: boolean;
+  /** SFTP主机ID, 类型为sftp时必填 */
+  host_id?: number;
+  /** 是否启用 */
+  is_enabled?: boolean;
+  /** 数据源类型, sftp:主机文件, local:本地文件, http:HTTP下载 */
+  kind: "sftp" | "local" | "http";
+  /** 文件位置, 路径或URL, 支持{date}和{day}占位符 */
+  location: string;
+  /** 文件大小上限(MB), 为0时为100 */
+  max_size?: number;
+  /** 名称 */
+  name: string;
+  /** 定时采集的crontab表达式, 为空时仅手动采集 */
+  specification?: string;
+  /** 仅交易日采集 */
+  trading_day_only?: boolean;
+}
+
+/** mds.CreateOrUpdateMdsColonyRequest */
+export interface MdsCreateOrUpdateMdsColonyRequest {
+  /** 集群号 */
+  colony_num: string;
+  /** 解压后名称 */
+  extracted_name: string;
+  /** 是否启用 */
+  is_enable: boolean;
+  /** mon节点ID */
+  mon_node_id: number;
+  /** 程序包ID */
+  package_id: number;
+  /** 版本号, 更新时必填(也可通过If-Match请求头传递) */
+  version?: number;
+}
+
+/** mds.CreateOrUpdateMdsNodeRequest */
+export interface MdsCreateOrUpdateMdsNodeRequest {
+  /** 主机ID */
+  host_id: number;
+  /** 是否启用 */
+  is_enable?: boolean;
+  /** mds集群ID */
+  mds_colony_id: number;
+  /** 节点角色 */
+  node_role: "master" | "follow" | "arbiter";
+}
+
+/** mds.IngestColumn */
+export interface MdsIngestColumn {
+  name?: string;
+  type?: string;
+}
+
+/** mds.IngestDayReply */
+export interface MdsIngestDayReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: MdsIngestRecordOut[];
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** mds.IngestRecordOut */
+export interface MdsIngestRecordOut {
+  /** 采集次数 */
+  attempts?: number;
+  /** 文件MD5 */
+  checksum?: string;
+  /** 校验错误 */
+  errors?: string[];
+  /** 校验文件中的MD5 */
+  expected_checksum?: string;
+  /** 校验文件中的行数 */
+  expected_rows?: number;
+  /** 文件大小 */
+  file_size?: number;
+  /** 结束时间 */
+  finished_at?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** 文件位置 */
+  location?: string;
+  /** 数据行数 */
+  row_count?: number;
+  /** 数据源ID */
+  source_id?: number;
+  /** 数据源名称 */
+  source_name?: string;
+  /** 开始时间 */
+  started_at?: string;
+  /** 状态, running/success/failed/pending */
+  status?: string;
+  /** 交易日 */
+  trading_day?: string;
+  /** 触发方式, cron/manual */
+  trigger_type?: string;
+  /** 操作用户 */
+  username?: string;
+}
+
+/** mds.IngestRecordReply */
+export interface MdsIngestRecordReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: MdsIngestRecordOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** mds.IngestSourceOut */
+export interface MdsIngestSourceOut {
+  /** 校验文件后缀 */
+  checksum_suffix?: string;
+  /** 字段定义 */
+  columns?: MdsIngestColumn[];
+  /** 创建时间 */
+  created_at?: string;
+  /** 字段分隔符 */
+  delimiter?: string;
+  /** 是否包含表头 */
+  has_header?: boolean;
+  /** SFTP主机ID */
+  host_id?: number;
+  /** 唯一标识 */
+  id?: number;
+  /** 是否启用 */
+  is_enabled?: boolean;
+  /** 数据源类型 */
+  kind?: string;
+  /** 文件位置 */
+  location?: string;
+  /** 文件大小上限(MB) */
+  max_size?: number;
+  /** 名称 */
+  name?: string;
+  /** 定时采集表达式 */
+  specification?: string;
+  /** 仅交易日采集 */
+  trading_day_only?: boolean;
+  /** 更新时间 */
+  updated_at?: string;
+}
+
+/** mds.IngestSourceReply */
+export interface MdsIngestSourceReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: MdsIngestSourceOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** mds.ListMdsTasksInfoReply */
+export interface MdsListMdsTasksInfoReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: MdsMdsColonyTaskInfo[];
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** mds.MdsBackfillDayOut */
+export interface MdsMdsBackfillDayOut {
+  /** 执行次数 */
+  attempts?: number;
+  /** 依赖的交易日 */
+  depends_on?: string;
+  /** 错误信息 */
+  error_message?: string;
+  /** 结束时间 */
+  finished_at?: string;
+  /** 脚本执行记录ID */
+  record_id?: number;
+  /** 开始时间 */
+  started_at?: string;
+  /** 执行状态(pending/running/success/failed/skipped/cancelled) */
+  status?: string;
+  /** 交易日 */
+  trading_day?: string;
+}
+
+/** mds.MdsBackfillRunDetailOut */
+export interface MdsMdsBackfillRunDetailOut {
+  /** 集群号 */
+  colony_num?: string;
+  /** 并发数 */
+  concurrency?: number;
+  /** 创建时间 */
+  created_at?: string;
+  /** 每个交易日的回放状态 */
+  days?: MdsMdsBackfillDayOut[];
+  /** 结束日期 */
+  end_date?: string;
+  /** 结束时间 */
+  finished_at?: string;
+  /** ID */
+  id?: number;
+  /** mds集群ID */
+  mds_colony_id?: number;
+  /** 回放脚本名称 */
+  script?: string;
+  /** 是否按日期顺序回放 */
+  sequential?: boolean;
+  /** 开始日期 */
+  start_date?: string;
+  /** 开始时间 */
+  started_at?: string;
+  /** 执行状态(pending/running/success/failed/cancelled) */
+  status?: string;
+  /** 各状态的交易日数 */
+  summary?: MdsMdsBackfillSummaryOut;
+  /** 每日回放超时时间(秒) */
+  timeout?: number;
+  /** 用户名 */
+  username?: string;
+}
+
+/** mds.MdsBackfillRunOut */
+export interface MdsMdsBackfillRunOut {
+  /** 集群号 */
+  colony_num?: string;
+  /** 并发数 */
+  concurrency?: number;
+  /** 创建时间 */
+  created_at?: string;
+  /** 结束日期 */
+  end_date?: string;
+  /** 结束时间 */
+  finished_at?: string;
+  /** ID */
+  id?: number;
+  /** mds集群ID */
+  mds_colony_id?: number;
+  /** 回放脚本名称 */
+  script?: string;
+  /** 是否按日期顺序回放 */
+  sequential?: boolean;
+  /** 开始日期 */
+  start_date?: string;
+  /** 开始时间 */
+  started_at?: string;
+  /** 执行状态(pending/running/success/failed/cancelled) */
+  status?: string;
+  /** 各状态的交易日数 */
+  summary?: MdsMdsBackfillSummaryOut;
+  /** 每日回放超时时间(秒) */
+  timeout?: number;
+  /** 用户名 */
+  username?: string;
+}
+
+/** mds.MdsBackfillRunReply */
+export interface MdsMdsBackfillRunReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: MdsMdsBackfillRunDetailOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** mds.MdsBackfillSummaryOut */
+export interface MdsMdsBackfillSummaryOut {
+  /** 已取消 */
+  cancelled?: number;
+  /** 执行失败 */
+  failed?: number;
+  /** 等待执行 */
+  pending?: number;
+  /** 执行中 */
+  running?: number;
+  /** 已跳过 */
+  skipped?: number;
+  /** 执行成功 */
+  success?: number;
+  /** 交易日总数 */
+  total?: number;
+}
+
+/** mds.MdsColonyBaseOut */
+export interface MdsMdsColonyBaseOut {
+  /** 集群号 */
+  colony_num?: string;
+  /** 解压后名称 */
+  extracted_name?: string;
+  /** ID */
+  id?: number;
+  /** 是否启用 */
+  is_enable?: boolean;
+}
+
+/** mds.MdsColonyDetailOut */
+export interface MdsMdsColonyDetailOut {
+  /** 集群号 */
+  colony_num?: string;
+  /** 创建时间 */
+  created_at?: string;
+  /** 解压后名称 */
+  extracted_name?: string;
+  /** ID */
+  id?: number;
+  /** 是否启用 */
+  is_enable?: boolean;
+  /** mon节点 */
+  mon_node?: MonMonNodeBaseOut;
+  /** mds程序包 */
+  package?: ResourcePackageStandardOut;
+  /** 更新时间 */
+  updated_at?: string;
+  /** 版本号 */
+  version?: number;
+}
+
+/** mds.MdsColonyReply */
+export interface MdsMdsColonyReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: MdsMdsColonyDetailOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** mds.MdsColonyTaskInfo */
+export interface MdsMdsColonyTaskInfo {
+  /** 集群号 */
+  colony_num?: string;
+  /** 任务状态 */
+  tasks?: CommonTaskInfo[];
+}
+
+/** mds.MdsNodeDetailOut */
+export interface MdsMdsNodeDetailOut {
+  /** 创建时间 */
+  created_at?: string;
+  /** 主机 */
+  host?: ResourceHostBaseOut;
+  /** ID */
+  id?: number;
+  /** 是否启用 */
+  is_enable?: boolean;
+  /** mds集群 */
+  mds_colony?: MdsMdsColonyBaseOut;
+  /** 节点角色 */
+  node_role?: string;
+  /** 更新时间 */
+  updated_at?: string;
+}
+
+/** mds.MdsNodeReply */
+export interface MdsMdsNodeReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: MdsMdsNodeDetailOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** mds.PagIngestRecordReply */
+export interface MdsPagIngestRecordReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CommonPagMdsIngestRecordOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** mds.PagIngestSourceReply */
+export interface MdsPagIngestSourceReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CommonPagMdsIngestSourceOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** mds.PagMdsBackfillRunReply */
+export interface MdsPagMdsBackfillRunReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CommonPagMdsMdsBackfillRunOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** mds.PagMdsColonyReply */
+export interface MdsPagMdsColonyReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CommonPagMdsMdsColonyDetailOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** mds.PagMdsConfReply */
+export interface MdsPagMdsConfReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: FileutilFileInfo;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** mds.PagMdsNodeReply */
+export interface MdsPagMdsNodeReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CommonPagMdsMdsNodeDetailOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** mds.RunIngestRequest */
+export interface MdsRunIngestRequest {
+  /** 交易日, 格式为2006-01-02 */
+  trading_day: string;
+}
+
+/** mon.CreateOrUpdateMonNodeRequest */
+export interface MonCreateOrUpdateMonNodeRequest {
+  /** 部署路径 */
+  deploy_path: string;
+  /** 主机ID */
+  host_id: number;
+  /** JAVA_HOME */
+  java_home?: string;
+  /** 名称 */
+  name: string;
+  /** 导出路径 */
+  outport_path: string;
+  /** Prometheus认证令牌, 更新时为空表示不修改 */
+  prom_bearer_token?: string;
+  /** Prometheus认证密码, 更新时为空表示不修改 */
+  prom_password?: string;
+  /** Prometheus地址 */
+  prom_url?: string;
+  /** Prometheus认证用户名 */
+  prom_username?: string;
+  /** URL地址 */
+  url?: string;
+}
+
+/** mon.HostMetricPointOut */
+export interface MonHostMetricPointOut {
+  /** CPU使用率(%) */
+  cpu_percent?: number;
+  /** 磁盘使用率(%) */
+  disk_percent?: number;
+  /** 1分钟平均负载 */
+  load1?: number;
+  /** 15分钟平均负载 */
+  load15?: number;
+  /** 5分钟平均负载 */
+  load5?: number;
+  /** 内存使用率(%) */
+  mem_percent?: number;
+  /** 时间(Unix秒) */
+  ts?: number;
+}
+
+/** mon.HostMetricSeriesOut */
+export interface MonHostMetricSeriesOut {
+  /** 结束时间(Unix秒) */
+  end?: number;
+  /** 主机ID */
+  host_id?: number;
+  /** mon节点ID */
+  mon_node_id?: number;
+  /** 数据点, 按时间升序 */
+  points?: MonHostMetricPointOut[];
+  /** 时间范围 */
+  range?: string;
+  /** 开始时间(Unix秒) */
+  start?: number;
+  /** 聚合粒度(秒, 0表示原始数据) */
+  step?: number;
+}
+
+/** mon.HostMetricSeriesReply */
+export interface MonHostMetricSeriesReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: MonHostMetricSeriesOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** mon.MonNodeBaseOut */
+export interface MonMonNodeBaseOut {
+  /** 部署路径 */
+  deploy_path?: string;
+  /** 健康状态 */
+  health?: string;
+  /** 最近一次健康检查时间 */
+  health_checked_at?: string;
+  /** 健康检查信息 */
+  health_message?: string;
+  /** 计划任务ID */
+  id?: number;
+  /** JAVA_HOME */
+  java_home?: string;
+  /** 最近一次采集时间 */
+  last_scrape_at?: string;
+  /** 名称 */
+  name?: string;
+  /** 导出路径 */
+  outport_path?: string;
+  /** Prometheus地址 */
+  prom_url?: string;
+  /** URL地址 */
+  url?: string;
+}
+
+/** mon.MonNodeDetailOut */
+export interface MonMonNodeDetailOut {
+  /** 创建时间 */
+  created_at?: string;
+  /** 部署路径 */
+  deploy_path?: string;
+  /** 健康状态 */
+  health?: string;
+  /** 最近一次健康检查时间 */
+  health_checked_at?: string;
+  /** 健康检查信息 */
+  health_message?: string;
+  /** 主机 */
+  host?: ResourceHostBaseOut;
+  /** 计划任务ID */
+  id?: number;
+  /** JAVA_HOME */
+  java_home?: string;
+  /** 最近一次采集时间 */
+  last_scrape_at?: string;
+  /** 名称 */
+  name?: string;
+  /** 导出路径 */
+  outport_path?: string;
+  /** Prometheus地址 */
+  prom_url?: string;
+  /** 更新时间 */
+  updated_at?: string;
+  /** URL地址 */
+  url?: string;
+}
+
+/** mon.MonNodeQueryReply */
+export type MonMonNodeQueryReply = Record<string, unknown>;
+
+/** mon.MonNodeReply */
+export interface MonMonNodeReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: MonMonNodeDetailOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** mon.PagMonNodeReply */
+export interface MonPagMonNodeReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CommonPagMonMonNodeDetailOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** oes.BatchOesColonyReply */
+export interface OesBatchOesColonyReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: OesOesColonyBatchResultOut[];
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** oes.BatchUpdateOesColonyRequest */
+export interface OesBatchUpdateOesColonyRequest {
+  /** oes集群ID列表 */
+  ids: number[];
+  /** 是否启用 */
+  is_enable?: boolean;
+  /** mon节点ID */
+  mon_node_id?: number;
+  /** 程序包ID */
+  package_id?: number;
+}
+
+/** oes.CreateOrUpdateOesColonyRequest */
+export interface OesCreateOrUpdateOesColonyRequest {
+  /** 集群号 */
+  colony_num: string;
+  /** 解压后名称 */
+  extracted_name: string;
+  /** 是否启用 */
+  is_enable: boolean;
+  /** mon节点ID */
+  mon_node_id: number;
+  /** 程序包ID */
+  package_id: number;
+  /** 系统类型 */
+  system_type: "STK" | "CRD" | "OPT";
+  /** 版本号, 更新时必填(也可通过If-Match请求头传递) */
+  version?: number;
+  /** xcounter包ID */
+  xcounter_id: number;
+}
+
+/** oes.CreateOrUpdateOesNodeRequest */
+export interface OesCreateOrUpdateOesNodeRequest {
+  /**
+   * 主机ID
+   * required: true
+   * example: 1
+   */
+  host_id: number;
+  /**
+   * 是否启用
+   * required: true
+   * example: true
+   */
+  is_enable?: boolean;
+  /**
+   * 节点角色
+   * required: true
+   * example: "01"
+   */
+  node_role: "master" | "follow" | "arbiter";
+  /**
+   * oes集群ID
+   * required: true
+   * example: 1
+   */
+  oes_colony_id: number;
+}
+
+/** oes.DeployOesConfTemplateRequest */
+export interface OesDeployOesConfTemplateRequest {
+  /** 下发的节点ID, 为空时下发到模板适用的全部节点 */
+  node_ids?: number[];
+  /** oes集群ID */
+  oes_colony_id: number;
+}
+
+/** oes.ListOesTasksInfoReply */
+export interface OesListOesTasksInfoReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: OesOesColonyTaskInfo[];
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** oes.OesColonyBaseOut */
+export interface OesOesColonyBaseOut {
+  /** 集群号 */
+  colony_num?: string;
+  /** 解压后名称 */
+  extracted_name?: string;
+  /** ID */
+  id?: number;
+  /** 是否启用 */
+  is_enable?: boolean;
+  /** 系统类型 */
+  system_type?: string;
+}
+
+/** oes.OesColonyBatchResultOut */
+export interface OesOesColonyBatchResultOut {
+  /** oes集群ID */
+  id?: number;
+  /** 失败原因 */
+  message?: string;
+  /** 是否成功 */
+  success?: boolean;
+}
+
+/** oes.OesColonyDetailOut */
+export interface OesOesColonyDetailOut {
+  /** 集群号 */
+  colony_num?: string;
+  /** 创建时间 */
+  created_at?: string;
+  /** 解压后名称 */
+  extracted_name?: string;
+  /** ID */
+  id?: number;
+  /** 是否启用 */
+  is_enable?: boolean;
+  /** mon节点 */
+  mon_node?: MonMonNodeBaseOut;
+  /** oes程序包 */
+  package?: ResourcePackageStandardOut;
+  /** 系统类型 */
+  system_type?: string;
+  /** 更新时间 */
+  updated_at?: string;
+  /** 版本号 */
+  version?: number;
+  /** xcounter程序包 */
+  xcounter?: ResourcePackageStandardOut;
+}
+
+/** oes.OesColonyDriftOut */
+export interface OesOesColonyDriftOut {
+  /** 实际的校验和或版本号, missing表示文件不存在, error表示检测失败 */
+  actual?: string;
+  /** 最近检测时间 */
+  checked_at?: string;
+  /** 首次发现时间 */
+  detected_at?: string;
+  /** 期望的校验和或版本号 */
+  expected?: string;
+  /** 主机ID */
+  host_id?: number;
+  /** 漂移类型(conf/package) */
+  kind?: string;
+  /** 说明 */
+  message?: string;
+  /** 配置模板名称或程序包标签 */
+  name?: string;
+  /** 节点ID */
+  node_id?: number;
+  /** 目标文件路径 */
+  remote_path?: string;
+}
+
+/** oes.OesColonyDriftReply */
+export interface OesOesColonyDriftReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: OesOesColonyDriftOut[];
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** oes.OesColonyExportOut */
+export interface OesOesColonyExportOut {
+  /** 集群号 */
+  colony_num?: string;
+  /** 创建时间 */
+  created_at?: string;
+  /** 下载地址, 导出成功后返回 */
+  download_url?: string;
+  /** 错误信息 */
+  error_message?: string;
+  /** 归档文件名 */
+  filename?: string;
+  /** ID */
+  id?: number;
+  /** oes集群ID */
+  oes_colony_id?: number;
+  /** 归档文件大小(字节) */
+  size?: number;
+  /** 导出状态(pending/running/success/failed) */
+  status?: string;
+  /** 更新时间 */
+  updated_at?: string;
+  /** 用户名 */
+  username?: string;
+}
+
+/** oes.OesColonyExportReply */
+export interface OesOesColonyExportReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: OesOesColonyExportOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** oes.OesColonyReply */
+export interface OesOesColonyReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: OesOesColonyDetailOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** oes.OesColonyTaskInfo */
+export interface OesOesColonyTaskInfo {
+  /** 集群号 */
+  colony_num?: string;
+  /** 任务状态 */
+  tasks?: CommonTaskInfo[];
+}
+
+/** oes.OesColonyTopologyOut */
+export interface OesOesColonyTopologyOut {
+  /** 连线列表 */
+  edges?: OesTopologyEdgeOut[];
+  /** 节点列表 */
+  nodes?: OesTopologyNodeOut[];
+}
+
+/** oes.OesColonyTopologyReply */
+export interface OesOesColonyTopologyReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: OesOesColonyTopologyOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** oes.OesConfDeployOut */
+export interface OesOesConfDeployOut {
+  /** 文件内容是否发生变化, 未变化时不写入 */
+  changed?: boolean;
+  /** 下发失败的原因, 成功时为空 */
+  error?: string;
+  /** 主机ID */
+  host_id?: number;
+  /** 节点ID */
+  node_id?: number;
+  /** 目标文件路径 */
+  remote_path?: string;
+}
+
+/** oes.OesConfDeployReply */
+export interface OesOesConfDeployReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: OesOesConfDeployOut[];
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** oes.OesConfRenderOut */
+export interface OesOesConfRenderOut {
+  /** 渲染后的内容 */
+  content?: string;
+  /** 与目标文件当前内容的差异(unified格式), 内容相同时为空 */
+  diff?: string;
+  /** 目标文件是否已存在 */
+  exists?: boolean;
+  /** 主机ID */
+  host_id?: number;
+  /** 主机名称 */
+  host_name?: string;
+  /** 节点ID */
+  node_id?: number;
+  /** 目标文件路径 */
+  remote_path?: string;
+}
+
+/** oes.OesConfRenderReply */
+export interface OesOesConfRenderReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: OesOesConfRenderOut[];
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** oes.OesConfTemplateOut */
+export interface OesOesConfTemplateOut {
+  /** 模板内容 */
+  content?: string;
+  /** 创建时间 */
+  created_at?: string;
+  /** 适用节点目录 */
+  dir_name?: string;
+  /** ID */
+  id?: number;
+  /** 名称 */
+  name?: string;
+  /** 备注 */
+  remark?: string;
+  /** 目标文件路径模板 */
+  remote_path?: string;
+  /** 更新时间 */
+  updated_at?: string;
+}
+
+/** oes.OesConfTemplateReply */
+export interface OesOesConfTemplateReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: OesOesConfTemplateOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** oes.OesConfTemplateRequest */
+export interface OesOesConfTemplateRequest {
+  /** 模板内容 */
+  content?: string;
+  /** 适用节点目录, all表示集群的全部启用节点 */
+  dir_name: "all" | "host_01" | "host_02" | "host_03";
+  /** 名称 */
+  name: string;
+  /** 备注 */
+  remark?: string;
+  /**
+   * 目标文件路径模板, 渲染后必须为绝对路径
+   * example: /home/quant/oes_{{ .Colony.ColonyNum }}/conf/oes.conf
+   */
+  remote_path: string;
+}
+
+/** oes.OesLinkMatrixCellOut */
+export interface OesOesLinkMatrixCellOut {
+  /** 探测时间 */
+  last_checked_at?: string;
+  /** 探测失败原因 */
+  last_error?: string;
+  /** 建立连接的耗时(毫秒) */
+  latency_ms?: number;
+  /** 链路ID */
+  link_id?: number;
+  /** 名称 */
+  name?: string;
+  /** 探测发起方, 对应矩阵的行 */
+  source?: string;
+  /** 探测状态 */
+  status?: string;
+  /** 目标名称和地址, 对应矩阵的列 */
+  target?: string;
+}
+
+/** oes.OesLinkMatrixOut */
+export interface OesOesLinkMatrixOut {
+  /** 矩阵中有链路的单元格 */
+  cells?: OesOesLinkMatrixCellOut[];
+  /** 可达的链路数 */
+  reachable?: number;
+  /** 矩阵的行, 探测发起方 */
+  sources?: string[];
+  /** 矩阵的列, 目标名称和地址 */
+  targets?: string[];
+  /** 链路数 */
+  total?: number;
+  /** 未探测的链路数 */
+  unknown?: number;
+  /** 不可达的链路数 */
+  unreachable?: number;
+}
+
+/** oes.OesLinkMatrixReply */
+export interface OesOesLinkMatrixReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: OesOesLinkMatrixOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** oes.OesLinkOut */
+export interface OesOesLinkOut {
+  /** 创建时间 */
+  created_at?: string;
+  /** ID */
+  id?: number;
+  /** 是否启用 */
+  is_enabled?: boolean;
+  /** 最近一次探测时间 */
+  last_checked_at?: string;
+  /** 最近一次探测失败原因 */
+  last_error?: string;
+  /** 最近一次建立连接的耗时(毫秒) */
+  latency_ms?: number;
+  /** 名称 */
+  name?: string;
+  /** oes集群ID */
+  oes_colony_id?: number;
+  /** 备注 */
+  remark?: string;
+  /** 探测发起方, 主机名称或platform */
+  source?: string;
+  /** 源主机ID, 0表示平台 */
+  source_host_id?: number;
+  /** 探测状态(unknown/reachable/unreachable) */
+  status?: string;
+  /** 目标地址 */
+  target_host?: string;
+  /** 目标名称 */
+  target_name?: string;
+  /** 目标端口 */
+  target_port?: number;
+  /** 连接超时(毫秒) */
+  timeout_ms?: number;
+  /** 更新时间 */
+  updated_at?: string;
+}
+
+/** oes.OesLinkProbeOut */
+export interface OesOesLinkProbeOut {
+  /** 探测时间 */
+  created_at?: string;
+  /** 探测失败原因 */
+  error?: string;
+  /** ID */
+  id?: number;
+  /** 建立连接的耗时(毫秒) */
+  latency_ms?: number;
+  /** 链路ID */
+  link_id?: number;
+  /** 探测状态 */
+  status?: string;
+}
+
+/** oes.OesLinkReply */
+export interface OesOesLinkReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: OesOesLinkOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** oes.OesLinkRequest */
+export interface OesOesLinkRequest {
+  /** 是否启用 */
+  is_enabled?: boolean;
+  /** 名称 */
+  name: string;
+  /** oes集群ID, 0表示不属于集群 */
+  oes_colony_id?: number;
+  /** 备注 */
+  remark?: string;
+  /** 源主机ID, 0表示由平台探测 */
+  source_host_id?: number;
+  /** 目标地址, 主机名或IP */
+  target_host: string;
+  /** 目标名称, 例如XCounter、上交所网关 */
+  target_name: string;
+  /** 目标端口 */
+  target_port: number;
+  /** 连接超时(毫秒), 默认3000 */
+  timeout_ms?: number;
+}
+
+/** oes.OesNodeDetailOut */
+export interface OesOesNodeDetailOut {
+  /** 创建时间 */
+  created_at?: string;
+  host?: ResourceHostBaseOut;
+  /** ID */
+  id?: number;
+  /** 是否启用 */
+  is_enable?: boolean;
+  /** 节点角色 */
+  node_role?: string;
+  oes_colony?: OesOesColonyBaseOut;
+  /** 更新时间 */
+  updated_at?: string;
+}
+
+/** oes.OesNodeReply */
+export interface OesOesNodeReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: OesOesNodeDetailOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** oes.OesReconcileFileOut */
+export interface OesOesReconcileFileOut {
+  /** 下发数据记录数 */
+  distribute_rows?: number;
+  /** 节点多余的记录数 */
+  extra?: number;
+  /** 柜台数据记录数 */
+  fetch_rows?: number;
+  /** 数据文件名称 */
+  file_name?: string;
+  /** 主机ID */
+  host_id?: number;
+  /** 说明 */
+  message?: string;
+  /** 内容不一致的记录数 */
+  mismatched?: number;
+  /** 节点缺少的记录数 */
+  missing?: number;
+  /** 节点ID */
+  node_id?: number;
+  /** 节点上的文件路径 */
+  remote_path?: string;
+  /** 对账状态(matched/mismatched/failed) */
+  status?: string;
+}
+
+/** oes.OesReconcileReportDetailOut */
+export interface OesOesReconcileReportDetailOut {
+  /** 对账时间 */
+  checked_at?: string;
+  /** 集群号 */
+  colony_num?: string;
+  /** 节点多余的记录数 */
+  extra?: number;
+  /** 柜台数据文件数 */
+  file_count?: number;
+  /** 每个节点上每个数据文件的对账结果 */
+  files?: OesOesReconcileFileOut[];
+  /** ID */
+  id?: number;
+  /** 说明 */
+  message?: string;
+  /** 内容不一致的记录数 */
+  mismatched?: number;
+  /** 节点缺少的记录数 */
+  missing?: number;
+  /** 对账节点数 */
+  node_count?: number;
+  /** oes集群ID */
+  oes_colony_id?: number;
+  /** 对账状态(matched/mismatched/failed) */
+  status?: string;
+  /** 交易日 */
+  trading_day?: string;
+  /** 发起对账的用户名, 定时对账为空 */
+  username?: string;
+}
+
+/** oes.OesReconcileReportOut */
+export interface OesOesReconcileReportOut {
+  /** 对账时间 */
+  checked_at?: string;
+  /** 集群号 */
+  colony_num?: string;
+  /** 节点多余的记录数 */
+  extra?: number;
+  /** 柜台数据文件数 */
+  file_count?: number;
+  /** ID */
+  id?: number;
+  /** 说明 */
+  message?: string;
+  /** 内容不一致的记录数 */
+  mismatched?: number;
+  /** 节点缺少的记录数 */
+  missing?: number;
+  /** 对账节点数 */
+  node_count?: number;
+  /** oes集群ID */
+  oes_colony_id?: number;
+  /** 对账状态(matched/mismatched/failed) */
+  status?: string;
+  /** 交易日 */
+  trading_day?: string;
+  /** 发起对账的用户名, 定时对账为空 */
+  username?: string;
+}
+
+/** oes.OesReconcileReportReply */
+export interface OesOesReconcileReportReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: OesOesReconcileReportDetailOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** oes.OesRunbookExecDetailOut */
+export interface OesOesRunbookExecDetailOut {
+  /** 集群号 */
+  colony_num?: string;
+  /** 发起时间 */
+  created_at?: string;
+  /** 结束时间 */
+  finished_at?: string;
+  /** ID */
+  id?: number;
+  /** oes集群ID */
+  oes_colony_id?: number;
+  /** 执行进度 */
+  progress?: OesOesRunbookProgressOut;
+  /** 检查单ID */
+  runbook_id?: number;
+  /** 检查单名称 */
+  runbook_name?: string;
+  /** 执行状态(running/blocked/success/aborted) */
+  status?: string;
+  /** 步骤执行详情 */
+  steps?: OesOesRunbookExecStepOut[];
+  /** 发起人 */
+  username?: string;
+}
+
+/** oes.OesRunbookExecOut */
+export interface OesOesRunbookExecOut {
+  /** 集群号 */
+  colony_num?: string;
+  /** 发起时间 */
+  created_at?: string;
+  /** 结束时间 */
+  finished_at?: string;
+  /** ID */
+  id?: number;
+  /** oes集群ID */
+  oes_colony_id?: number;
+  /** 执行进度 */
+  progress?: OesOesRunbookProgressOut;
+  /** 检查单ID */
+  runbook_id?: number;
+  /** 检查单名称 */
+  runbook_name?: string;
+  /** 执行状态(running/blocked/success/aborted) */
+  status?: string;
+  /** 发起人 */
+  username?: string;
+}
+
+/** oes.OesRunbookExecReply */
+export interface OesOesRunbookExecReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: OesOesRunbookExecDetailOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** oes.OesRunbookExecStepOut */
+export interface OesOesRunbookExecStepOut {
+  /** 脚本执行次数 */
+  attempts?: number;
+  /** 错误信息 */
+  error_message?: string;
+  /** 完成时间 */
+  finished_at?: string;
+  /** 步骤ID */
+  id?: number;
+  /** 操作说明 */
+  instruction?: string;
+  /** 步骤类型(manual/script) */
+  kind?: string;
+  /** 步骤名称 */
+  name?: string;
+  /** 确认或执行步骤的操作员 */
+  operator?: string;
+  /** 脚本执行记录ID */
+  record_id?: number;
+  /** 操作员备注 */
+  remark?: string;
+  /** 脚本ID */
+  script_id?: number;
+  /** 开始时间 */
+  started_at?: string;
+  /** 执行状态(pending/waiting/running/success/failed/skipped) */
+  status?: string;
+}
+
+/** oes.OesRunbookOut */
+export interface OesOesRunbookOut {
+  /** 创建时间 */
+  created_at?: string;
+  /** 说明 */
+  description?: string;
+  /** ID */
+  id?: number;
+  /** 是否启用 */
+  is_enabled?: boolean;
+  /** 名称 */
+  name?: string;
+  /** 按顺序执行的步骤 */
+  steps?: OesOesRunbookStepOut[];
+  /** 适用的系统类型 */
+  system_type?: string;
+  /** 更新时间 */
+  updated_at?: string;
+}
+
+/** oes.OesRunbookProgressOut */
+export interface OesOesRunbookProgressOut {
+  /** 当前步骤名称 */
+  current?: string;
+  /** 已完成的步骤数 */
+  done?: number;
+  /** 步骤总数 */
+  total?: number;
+}
+
+/** oes.OesRunbookReply */
+export interface OesOesRunbookReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: OesOesRunbookOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** oes.OesRunbookRequest */
+export interface OesOesRunbookRequest {
+  /** 说明 */
+  description?: string;
+  /** 是否启用 */
+  is_enabled?: boolean;
+  /** 名称 */
+  name: string;
+  /** 按顺序执行的步骤 */
+  steps: OesOesRunbookStepRequest[];
+  /** 适用的系统类型, 为空时适用全部集群 */
+  system_type?: "STK" | "CRD" | "OPT";
+}
+
+/** oes.OesRunbookStepOut */
+export interface OesOesRunbookStepOut {
+  /** 操作说明 */
+  instruction?: string;
+  /** 步骤类型(manual/script) */
+  kind?: string;
+  /** 步骤名称 */
+  name?: string;
+  /** 脚本名称 */
+  script?: string;
+  /** 脚本超时时间(秒) */
+  timeout?: number;
+}
+
+/** oes.OesRunbookStepRequest */
+export interface OesOesRunbookStepRequest {
+  /** 操作说明 */
+  instruction?: string;
+  /** 步骤类型, manual为人工确认, script为执行内置脚本 */
+  kind: "manual" | "script";
+  /** 步骤名称 */
+  name: string;
+  /** 脚本名称, 需为oes的内置命令脚本, 执行参数为集群号 */
+  script?: string;
+  /** 脚本超时时间(秒), 默认为3600 */
+  timeout?: number;
+}
+
+/** oes.OesTaskCatalogOut */
+export interface OesOesTaskCatalogOut {
+  /** 创建时间 */
+  created_at?: string;
+  /** 说明 */
+  description?: string;
+  /** ID */
+  id?: number;
+  /** 是否启用 */
+  is_enabled?: boolean;
+  /** 排序 */
+  sort?: number;
+  /** 系统类型 */
+  system_type?: string;
+  /** 任务名称 */
+  task_name?: string;
+  /** 更新时间 */
+  updated_at?: string;
+}
+
+/** oes.OesTaskCatalogReply */
+export interface OesOesTaskCatalogReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: OesOesTaskCatalogOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** oes.OesTaskCatalogRequest */
+export interface OesOesTaskCatalogRequest {
+  /** 说明 */
+  description?: string;
+  /** 是否启用 */
+  is_enabled?: boolean;
+  /** 排序, 越小越靠前 */
+  sort?: number;
+  /** 系统类型 */
+  system_type: "STK" | "CRD" | "OPT";
+  /** 任务名称, 只能包含小写字母、数字和下划线, 以小写字母开头 */
+  task_name: string;
+}
+
+/** oes.OesTaskSlaOut */
+export interface OesOesTaskSlaOut {
+  /** 创建时间 */
+  created_at?: string;
+  /** 完成截止时间 */
+  deadline?: string;
+  /** 说明 */
+  description?: string;
+  /** ID */
+  id?: number;
+  /** 是否启用 */
+  is_enabled?: boolean;
+  /** oes集群ID, 0表示全部集群 */
+  oes_colony_id?: number;
+  /** 任务名称 */
+  task_name?: string;
+  /** 更新时间 */
+  updated_at?: string;
+  /** 截止前多少分钟发出即将超时告警 */
+  warn_minutes?: number;
+}
+
+/** oes.OesTaskSlaReply */
+export interface OesOesTaskSlaReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: OesOesTaskSlaOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** oes.OesTaskSlaRequest */
+export interface OesOesTaskSlaRequest {
+  /** 完成截止时间, 格式为15:04 */
+  deadline: string;
+  /** 说明 */
+  description?: string;
+  /** 是否启用 */
+  is_enabled?: boolean;
+  /** oes集群ID, 0表示全部集群 */
+  oes_colony_id?: number;
+  /** 任务名称, 需要在日常任务目录中 */
+  task_name: string;
+  /** 截止前多少分钟发出即将超时告警, 0表示不告警 */
+  warn_minutes?: number;
+}
+
+/** oes.OesTaskSlaResultOut */
+export interface OesOesTaskSlaResultOut {
+  /** 检查时间 */
+  checked_at?: string;
+  /** 集群号 */
+  colony_num?: string;
+  /** 完成截止时间 */
+  deadline?: string;
+  /** 任务执行成功的时间 */
+  finished_at?: string;
+  /** oes集群ID */
+  oes_colony_id?: number;
+  /** 任务的执行记录ID, 0表示当天未执行 */
+  record_id?: number;
+  /** 任务的执行状态(0-待执行,1-执行中,2-成功,3-失败,4-超时,5-崩溃,6-中断) */
+  record_status?: number;
+  /** SLA状态(pending/at_risk/met/late/breached) */
+  status?: string;
+  /** 任务名称 */
+  task_name?: string;
+}
+
+/** oes.OesWorkflowDefinitionReply */
+export interface OesOesWorkflowDefinitionReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: OesOesWorkflowStepConf[];
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** oes.OesWorkflowRunDetailOut */
+export interface OesOesWorkflowRunDetailOut {
+  /** 集群号 */
+  colony_num?: string;
+  /** 创建时间 */
+  created_at?: string;
+  /** 耗时(秒) */
+  duration?: number;
+  /** 结束时间 */
+  finished_at?: string;
+  /** ID */
+  id?: number;
+  /** oes集群ID */
+  oes_colony_id?: number;
+  /** 开始时间 */
+  started_at?: string;
+  /** 执行状态(pending/running/success/failed) */
+  status?: string;
+  /** 步骤执行详情 */
+  steps?: OesOesWorkflowRunStepOut[];
+  /** 系统类型 */
+  system_type?: string;
+  /** 用户名 */
+  username?: string;
+}
+
+/** oes.OesWorkflowRunOut */
+export interface OesOesWorkflowRunOut {
+  /** 集群号 */
+  colony_num?: string;
+  /** 创建时间 */
+  created_at?: string;
+  /** 耗时(秒) */
+  duration?: number;
+  /** 结束时间 */
+  finished_at?: string;
+  /** ID */
+  id?: number;
+  /** oes集群ID */
+  oes_colony_id?: number;
+  /** 开始时间 */
+  started_at?: string;
+  /** 执行状态(pending/running/success/failed) */
+  status?: string;
+  /** 系统类型 */
+  system_type?: string;
+  /** 用户名 */
+  username?: string;
+}
+
+/** oes.OesWorkflowRunReply */
+export interface OesOesWorkflowRunReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: OesOesWorkflowRunDetailOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** oes.OesWorkflowRunStepOut */
+export interface OesOesWorkflowRunStepOut {
+  /** 依赖的步骤 */
+  depends_on?: string[];
+  /** 耗时(秒) */
+  duration?: number;
+  /** 错误信息 */
+  error_message?: string;
+  /** 结束时间 */
+  finished_at?: string;
+  /** 步骤名称 */
+  name?: string;
+  /** 脚本执行记录ID */
+  record_id?: number;
+  /** 脚本ID */
+  script_id?: number;
+  /** 开始时间 */
+  started_at?: string;
+  /** 执行状态(pending/running/success/failed/skipped) */
+  status?: string;
+}
+
+/** oes.OesWorkflowStepConf */
+export interface OesOesWorkflowStepConf {
+  depends_on?: string[];
+  name?: string;
+  script?: string;
+  timeout?: number;
+}
+
+/** oes.PagOesColonyReply */
+export interface OesPagOesColonyReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CommonPagOesOesColonyDetailOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** oes.PagOesConfReply */
+export interface OesPagOesConfReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: FileutilFileInfo;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** oes.PagOesConfTemplateReply */
+export interface OesPagOesConfTemplateReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CommonPagOesOesConfTemplateOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** oes.PagOesLinkProbeReply */
+export interface OesPagOesLinkProbeReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CommonPagOesOesLinkProbeOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** oes.PagOesLinkReply */
+export interface OesPagOesLinkReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CommonPagOesOesLinkOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** oes.PagOesNodeReply */
+export interface OesPagOesNodeReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CommonPagOesOesNodeDetailOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** oes.PagOesReconcileReportReply */
+export interface OesPagOesReconcileReportReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CommonPagOesOesReconcileReportOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** oes.PagOesRunbookExecReply */
+export interface OesPagOesRunbookExecReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CommonPagOesOesRunbookExecOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** oes.PagOesRunbookReply */
+export interface OesPagOesRunbookReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CommonPagOesOesRunbookOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** oes.PagOesTaskCatalogReply */
+export interface OesPagOesTaskCatalogReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CommonPagOesOesTaskCatalogOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** oes.PagOesTaskSlaReply */
+export interface OesPagOesTaskSlaReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CommonPagOesOesTaskSlaOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** oes.PagOesWorkflowRunReply */
+export interface OesPagOesWorkflowRunReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CommonPagOesOesWorkflowRunOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** oes.ReconcileColonyRequest */
+export interface OesReconcileColonyRequest {
+  /** 交易日, 格式为2006-01-02, 默认为当天 */
+  trading_day?: string;
+}
+
+/** oes.RenderOesConfTemplateRequest */
+export interface OesRenderOesConfTemplateRequest {
+  /** oes集群ID */
+  oes_colony_id: number;
+}
+
+/** oes.SignOffRunbookStepRequest */
+export interface OesSignOffRunbookStepRequest {
+  /** 备注 */
+  remark?: string;
+}
+
+/** oes.StartOesRunbookRequest */
+export interface OesStartOesRunbookRequest {
+  /** 检查单ID */
+  runbook_id: number;
+}
+
+/** oes.TaskSlaComplianceOut */
+export interface OesTaskSlaComplianceOut {
+  /** 即将超时的任务数 */
+  at_risk?: number;
+  /** 已超时未完成的任务数 */
+  breached?: number;
+  /** 达成率(%), 截止前完成的任务数占已到截止时间的任务数的比例, 没有已到截止时间的任务时为100 */
+  compliance_rate?: number;
+  /** 截止后才完成的任务数 */
+  late?: number;
+  /** 截止前完成的任务数 */
+  met?: number;
+  /** 未到截止时间的任务数 */
+  pending?: number;
+  /** 每个集群每个任务的SLA检查结果 */
+  results?: OesOesTaskSlaResultOut[];
+  /** 任务数 */
+  total?: number;
+  /** 交易日 */
+  trading_day?: string;
+}
+
+/** oes.TaskSlaComplianceReply */
+export interface OesTaskSlaComplianceReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: OesTaskSlaComplianceOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** oes.TopologyEdgeOut */
+export interface OesTopologyEdgeOut {
+  /** 起点节点标识 */
+  source?: string;
+  /** 终点节点标识 */
+  target?: string;
+  /** 连线类型 */
+  type?: string;
+}
+
+/** oes.TopologyNodeOut */
+export interface OesTopologyNodeOut {
+  /** 节点详情 */
+  data?: unknown;
+  /** 节点标识, 格式为 类型:ID */
+  id?: string;
+  /** 显示名称 */
+  label?: string;
+  /** 节点类型(colony/package/xcounter/mon_node/oes_node/host/task) */
+  type?: string;
+}
+
+/** resource.CreateOrUpdateHosrRequest */
+export interface ResourceCreateOrUpdateHosrRequest {
+  /** 标签 */
+  label: string;
+  /** 名称 */
+  name: string;
+  /** python路径 */
+  py_path?: string;
+  /** 备注 */
+  remark?: string;
+  /** ip地址 */
+  ssh_ip: string;
+  /** 密码 */
+  ssh_password: string;
+  /** 端口 */
+  ssh_port: number;
+  /** 用户名 */
+  ssh_user: string;
+}
+
+/** resource.HostAgentDisk */
+export interface ResourceHostAgentDisk {
+  fs_type?: string;
+  mount: string;
+  total_mb?: number;
+  used_mb?: number;
+}
+
+/** resource.HostAgentHeartbeatRequest */
+export interface ResourceHostAgentHeartbeatRequest {
+  /** 注册时返回的主机ID */
+  host_id: number;
+  /** 主机基础指标, 上报后mon模块不再通过SSH采集该主机的指标 */
+  metrics?: ResourceHostAgentMetrics;
+}
+
+/** resource.HostAgentMetrics */
+export interface ResourceHostAgentMetrics {
+  /** CPU使用率(%) */
+  cpu_percent?: number;
+  /** 根文件系统使用率(%) */
+  disk_percent?: number;
+  /** 1分钟平均负载 */
+  load1?: number;
+  /** 15分钟平均负载 */
+  load15?: number;
+  /** 5分钟平均负载 */
+  load5?: number;
+  /** 内存使用率(%) */
+  mem_percent?: number;
+}
+
+/** resource.HostAgentOut */
+export interface ResourceHostAgentOut {
+  /** 代理版本 */
+  agent_version?: string;
+  /** CPU架构 */
+  arch?: string;
+  /** CPU核数 */
+  cpu_cores?: number;
+  /** 注册时间 */
+  created_at?: string;
+  /** 磁盘 */
+  disks?: ResourceHostAgentDisk[];
+  /** 主机 */
+  host?: ResourceHostBaseOut;
+  /** 主机名 */
+  hostname?: string;
+  /** ID */
+  id?: number;
+  /** IP地址 */
+  ips?: string[];
+  /** 内核版本 */
+  kernel?: string;
+  /** 最近心跳时间 */
+  last_heartbeat_at?: string;
+  /** 内存(MB) */
+  memory_mb?: number;
+  /** 操作系统 */
+  os?: string;
+  /** 机器序列号 */
+  serial?: string;
+  /** 在线状态 */
+  status?: string;
+  /** 更新时间 */
+  updated_at?: string;
+}
+
+/** resource.HostAgentReply */
+export interface ResourceHostAgentReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: ResourceHostAgentOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** resource.HostBaseOut */
+export interface ResourceHostBaseOut {
+  /** 主机ID */
+  id?: number;
+  /** 标签 */
+  label?: string;
+  /** 名称 */
+  name?: string;
+  /** Python路径 */
+  py_path?: string;
+  /** 备注 */
+  remark?: string;
+  /** IP地址 */
+  ssh_ip?: string;
+  /** 端口 */
+  ssh_port?: number;
+  /** 用户名, 没有查看敏感字段权限时掩码 */
+  ssh_user?: string;
+}
+
+/** resource.HostDuplicateGroupOut */
+export interface ResourceHostDuplicateGroupOut {
+  /** 疑似重复的主机 */
+  hosts?: ResourceHostStandardOut[];
+  /** 匹配依据 */
+  reason?: string;
+  /** 匹配值 */
+  value?: string;
+}
+
+/** resource.HostDuplicateReply */
+export interface ResourceHostDuplicateReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: ResourceHostDuplicateGroupOut[];
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** resource.HostFileOut */
+export interface ResourceHostFileOut {
+  /** 是否为目录 */
+  is_dir?: boolean;
+  /** 是否为符号链接 */
+  is_link?: boolean;
+  /** 修改时间 */
+  mod_time?: string;
+  /** 权限 */
+  mode?: string;
+  /** 文件名 */
+  name?: string;
+  /** 绝对路径 */
+  path?: string;
+  /** 文件大小(字节) */
+  size?: number;
+}
+
+/** resource.HostFileReply */
+export interface ResourceHostFileReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: ResourceHostFileOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** resource.HostMergeOut */
+export interface ResourceHostMergeOut {
+  /** 已删除的重复主机ID */
+  duplicate_id?: number;
+  /** 保留的主机ID */
+  host_id?: number;
+  /** 各数据表改为引用保留主机的记录数 */
+  references?: Record<string, number>;
+}
+
+/** resource.HostMergeReply */
+export interface ResourceHostMergeReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: ResourceHostMergeOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** resource.HostPathRuleOut */
+export interface ResourceHostPathRuleOut {
+  /** 创建时间 */
+  created_at?: string;
+  /** ID */
+  id?: number;
+  /** 允许访问的目录 */
+  path?: string;
+  /** 备注 */
+  remark?: string;
+  /** 角色ID */
+  role_id?: number;
+  /** 更新时间 */
+  updated_at?: string;
+  /** 是否允许上传 */
+  writable?: boolean;
+}
+
+/** resource.HostPathRuleReply */
+export interface ResourceHostPathRuleReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: ResourceHostPathRuleOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** resource.HostPathRuleRequest */
+export interface ResourceHostPathRuleRequest {
+  /** 允许访问的目录, 必须为绝对路径 */
+  path: string;
+  /** 备注 */
+  remark?: string;
+  /** 角色ID */
+  role_id: number;
+  /** 是否允许上传 */
+  writable?: boolean;
+}
+
+/** resource.HostReply */
+export interface ResourceHostReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: ResourceHostStandardOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** resource.HostStandardOut */
+export interface ResourceHostStandardOut {
+  /** 创建时间 */
+  created_at?: string;
+  /** 主机ID */
+  id?: number;
+  /** 标签 */
+  label?: string;
+  /** 名称 */
+  name?: string;
+  /** Python路径 */
+  py_path?: string;
+  /** 备注 */
+  remark?: string;
+  /** IP地址 */
+  ssh_ip?: string;
+  /** 端口 */
+  ssh_port?: number;
+  /** 用户名, 没有查看敏感字段权限时掩码 */
+  ssh_user?: string;
+  /** 更新时间 */
+  updated_at?: string;
+}
+
+/** resource.InitPackageUploadRequest */
+export interface ResourceInitPackageUploadRequest {
+  /** 文件SHA256校验和(十六进制), 不为空时完成上传时校验整个文件 */
+  checksum?: string;
+  /** 原始文件名 */
+  filename: string;
+  /** 程序包标签 */
+  label: string;
+  /** 文件大小(字节) */
+  size: number;
+  /** 程序包版本 */
+  version: string;
+}
+
+/** resource.ListHostFileReply */
+export interface ResourceListHostFileReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: ResourceHostFileOut[];
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** resource.MergeHostRequest */
+export interface ResourceMergeHostRequest {
+  /** 重复的主机ID */
+  duplicate_id: number;
+}
+
+/** resource.PackageDownloadURLOut */
+export interface ResourcePackageDownloadURLOut {
+  /** 文件SHA256校验和 */
+  checksum?: string;
+  /** 预签名地址的过期时间 */
+  expires_at?: string;
+  /** 是否为对象存储的预签名地址, 预签名地址无需携带令牌 */
+  presigned?: boolean;
+  /** 下载地址 */
+  url?: string;
+}
+
+/** resource.PackageDownloadURLReply */
+export interface ResourcePackageDownloadURLReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: ResourcePackageDownloadURLOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** resource.PackageReply */
+export interface ResourcePackageReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: ResourcePackageStandardOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** resource.PackageStandardOut */
+export interface ResourcePackageStandardOut {
+  /** 文件SHA256校验和 */
+  checksum?: string;
+  /** 名称 */
+  filename?: string;
+  /** 主机ID */
+  id?: number;
+  /** 标签 */
+  label?: string;
+  /** 文件大小(字节) */
+  size?: number;
+  /** 上传时间 */
+  uploaded_at?: string;
+  /** IP地址 */
+  version?: string;
+}
+
+/** resource.PackageUploadOut */
+export interface ResourcePackageUploadOut {
+  /** 分片大小上限(字节) */
+  chunk_size?: number;
+  /** 过期时间, 过期后会话和已上传的分片会被清理 */
+  expires_at?: string;
+  /** 原始文件名 */
+  filename?: string;
+  /** 程序包标签 */
+  label?: string;
+  /** 已接收字节数, 续传时作为下一个分片的起始位置 */
+  received?: number;
+  /** 文件大小(字节) */
+  size?: number;
+  /** 上传会话标识 */
+  upload_id?: string;
+  /** 程序包版本 */
+  version?: string;
+}
+
+/** resource.PackageUploadReply */
+export interface ResourcePackageUploadReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: ResourcePackageUploadOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** resource.PagHostAgentReply */
+export interface ResourcePagHostAgentReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CommonPagResourceHostAgentOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** resource.PagHostPathRuleReply */
+export interface ResourcePagHostPathRuleReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CommonPagResourceHostPathRuleOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** resource.PagHostReply */
+export interface ResourcePagHostReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CommonPagResourceHostStandardOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** resource.PagPackageReply */
+export interface ResourcePagPackageReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CommonPagResourcePackageStandardOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** resource.PagTerminalSessionReply */
+export interface ResourcePagTerminalSessionReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CommonPagResourceTerminalSessionOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** resource.PagWatchdogEventReply */
+export interface ResourcePagWatchdogEventReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CommonPagResourceWatchdogEventOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** resource.PagWatchdogReply */
+export interface ResourcePagWatchdogReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CommonPagResourceWatchdogOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** resource.RegisterHostAgentRequest */
+export interface ResourceRegisterHostAgentRequest {
+  /** 代理版本 */
+  agent_version?: string;
+  /** CPU架构 */
+  arch?: string;
+  /** CPU核数 */
+  cpu_cores?: number;
+  /** 磁盘 */
+  disks?: ResourceHostAgentDisk[];
+  /** 主机名, 自动创建主机时作为主机名称 */
+  hostname: string;
+  /** IP地址 */
+  ips?: string[];
+  /** 内核版本 */
+  kernel?: string;
+  /** 内存(MB) */
+  memory_mb?: number;
+  /** 操作系统 */
+  os?: string;
+  /** 机器序列号, 用于识别重复登记的主机 */
+  serial?: string;
+  /** SSH地址, 为空时使用请求的来源地址 */
+  ssh_ip?: string;
+  /** SSH端口, 为空时使用配置的默认端口 */
+  ssh_port?: number;
+  /** SSH用户, 为空时使用配置的默认用户 */
+  ssh_user?: string;
+}
+
+/** resource.TerminalSessionOut */
+export interface ResourceTerminalSessionOut {
+  /** 输出字节数 */
+  bytes_out?: number;
+  /** 客户端IP */
+  client_ip?: string;
+  /** 结束原因(client/exit/idle_timeout/max_duration/shutdown) */
+  close_reason?: string;
+  /** 结束时间, 为空表示会话进行中 */
+  ended_at?: string;
+  /** 主机ID */
+  host_id?: number;
+  /** 主机名称 */
+  host_name?: string;
+  /** ID */
+  id?: number;
+  /** 是否有录像 */
+  recorded?: boolean;
+  /** 会话标识 */
+  session_id?: string;
+  /** IP地址 */
+  ssh_ip?: string;
+  /** 主机用户名 */
+  ssh_user?: string;
+  /** 开始时间 */
+  started_at?: string;
+  /** 操作用户 */
+  username?: string;
+}
+
+/** resource.WatchdogEventOut */
+export interface ResourceWatchdogEventOut {
+  /** 动作 */
+  action?: string;
+  /** 创建时间 */
+  created_at?: string;
+  /** ID */
+  id?: number;
+  /** 信息 */
+  message?: string;
+  /** 检查状态 */
+  status?: string;
+  /** 进程守护ID */
+  watchdog_id?: number;
+}
+
+/** resource.WatchdogOut */
+export interface ResourceWatchdogOut {
+  /** 创建时间 */
+  created_at?: string;
+  /** 期望状态 */
+  expected_state?: string;
+  /** 主机 */
+  host?: ResourceHostBaseOut;
+  /** ID */
+  id?: number;
+  /** 是否启用 */
+  is_enabled?: boolean;
+  /** 检查对象类型 */
+  kind?: string;
+  /** 最近一次动作 */
+  last_action?: string;
+  /** 最近一次检查时间 */
+  last_checked_at?: string;
+  /** 最近一次检查信息 */
+  last_message?: string;
+  /** 重启窗口内最多重启次数 */
+  max_restarts?: number;
+  /** 名称 */
+  name?: string;
+  /** 备注 */
+  remark?: string;
+  /** 重启命令 */
+  restart_command?: string;
+  /** 重启策略 */
+  restart_policy?: string;
+  /** 重启窗口(分钟) */
+  restart_window?: number;
+  /** 检查状态 */
+  status?: string;
+  /** 服务单元或进程名 */
+  target?: string;
+  /** 更新时间 */
+  updated_at?: string;
+}
+
+/** resource.WatchdogReply */
+export interface ResourceWatchdogReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: ResourceWatchdogOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** resource.WatchdogRequest */
+export interface ResourceWatchdogRequest {
+  /** 期望状态 */
+  expected_state: "running" | "stopped";
+  /** 主机ID */
+  host_id: number;
+  /** 是否启用 */
+  is_enabled?: boolean;
+  /** 检查对象类型(systemd:服务单元, process:进程名) */
+  kind: "systemd" | "process";
+  /** 重启窗口内最多重启次数, 默认3 */
+  max_restarts?: number;
+  /** 名称 */
+  name: string;
+  /** 备注 */
+  remark?: string;
+  /** 重启命令, systemd服务为空时使用systemctl restart, 进程自动重启时必填 */
+  restart_command?: string;
+  /** 重启策略(never:只告警, on_failure:期望运行但未运行时重启) */
+  restart_policy: "never" | "on_failure";
+  /** 重启窗口(分钟), 默认60 */
+  restart_window?: number;
+  /** 服务单元或进程名 */
+  target: string;
+}
+
+/** system.AnalyticsUsageOut */
+export interface SystemAnalyticsUsageOut {
+  /** 使用次数 */
+  count?: number;
+  /** 功能标识 */
+  feature?: string;
+  /** 请求方法 */
+  method?: string;
+  /** 所属模块 */
+  module?: string;
+  /** 角色ID */
+  role_id?: number;
+}
+
+/** system.AnalyticsUsageReply */
+export interface SystemAnalyticsUsageReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: SystemAnalyticsUsageOut[];
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** system.AuditRecordOut */
+export interface SystemAuditRecordOut {
+  /** 操作类型 */
+  action?: string;
+  /** 变更后数据(JSON) */
+  after?: string;
+  /** 变更前数据(JSON) */
+  before?: string;
+  /** 创建时间 */
+  created_at?: string;
+  /** ID */
+  id?: number;
+  /** 所属模块 */
+  module?: string;
+  /** 资源类型 */
+  resource?: string;
+  /** 资源ID */
+  resource_id?: number;
+  /** 链路ID */
+  trace_id?: string;
+  /** 操作用户 */
+  username?: string;
+}
+
+/** system.BackupOut */
+export interface SystemBackupOut {
+  /** 创建时间 */
+  created_at?: string;
+  /** 失败原因 */
+  error?: string;
+  /** 文件数量 */
+  file_count?: number;
+  /** 结束时间 */
+  finished_at?: string;
+  /** ID */
+  id?: number;
+  /** 备份文件名 */
+  name?: string;
+  /** 记录数量 */
+  row_count?: number;
+  /** 数据库结构版本 */
+  schema_version?: string;
+  /** 文件大小(字节) */
+  size?: number;
+  /** 状态(running-备份中,succeeded-成功,failed-失败) */
+  status?: string;
+  /** 存储类型 */
+  storage_type?: string;
+  /** 表数量 */
+  table_count?: number;
+  /** 触发方式(manual-手动,scheduled-定时) */
+  trigger?: string;
+  /** 操作用户名, 定时备份为空 */
+  username?: string;
+}
+
+/** system.BackupReply */
+export interface SystemBackupReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: SystemBackupOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** system.CertMonitorOut */
+export interface SystemCertMonitorOut {
+  /** TLS服务地址 */
+  address?: string;
+  /** 根证书文件路径 */
+  ca_file?: string;
+  /** 证书链校验失败原因 */
+  chain_error?: string;
+  /** 创建时间 */
+  created_at?: string;
+  /** 距离过期的天数, 已过期时为负数, 未读取到证书时为空 */
+  days_remaining?: number;
+  /** 证书文件路径 */
+  file_path?: string;
+  /** ID */
+  id?: number;
+  /** 是否启用 */
+  is_enabled?: boolean;
+  /** 证书签发者 */
+  issuer?: string;
+  /** 证书来源(endpoint/file) */
+  kind?: string;
+  /** 最近一次检查时间 */
+  last_checked_at?: string;
+  /** 最近一次读取证书失败原因 */
+  last_error?: string;
+  /** 名称 */
+  name?: string;
+  /** 过期时间 */
+  not_after?: string;
+  /** 生效时间 */
+  not_before?: string;
+  /** 备注 */
+  remark?: string;
+  /** 校验证书的域名 */
+  server_name?: string;
+  /** 检查状态(unknown/ok/expiring/expired/invalid/error) */
+  status?: string;
+  /** 证书主题 */
+  subject?: string;
+  /** 更新时间 */
+  updated_at?: string;
+}
+
+/** system.CertMonitorReply */
+export interface SystemCertMonitorReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: SystemCertMonitorOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** system.CertMonitorRequest */
+export interface SystemCertMonitorRequest {
+  /** TLS服务地址(host:port), 证书来源为endpoint时必填 */
+  address?: string;
+  /** 根证书文件路径, 相对路径基于配置目录, 为空时使用系统根证书 */
+  ca_file?: string;
+  /** 证书文件路径, 相对路径基于配置目录, 证书来源为file时必填 */
+  file_path?: string;
+  /** 是否启用 */
+  is_enabled?: boolean;
+  /** 证书来源(endpoint/file) */
+  kind: "endpoint" | "file";
+  /** 名称 */
+  name: string;
+  /** 备注 */
+  remark?: string;
+  /** 校验证书的域名, 为空时使用服务地址中的主机名 */
+  server_name?: string;
+}
+
+/** system.ChangeFreezeOut */
+export interface SystemChangeFreezeOut {
+  /** 创建时间 */
+  created_at?: string;
+  /** 结束时间 */
+  end_at?: string;
+  /** ID */
+  id?: number;
+  /** 当前是否生效 */
+  is_active?: boolean;
+  /** 是否启用 */
+  is_enabled?: boolean;
+  /** 冻结的模块 */
+  module?: string;
+  /** 名称 */
+  name?: string;
+  /** 冻结原因 */
+  reason?: string;
+  /** 作用范围 */
+  scope?: string;
+  /** 开始时间 */
+  start_at?: string;
+  /** 更新时间 */
+  updated_at?: string;
+  /** 用户名 */
+  username?: string;
+}
+
+/** system.ChangeFreezeReply */
+export interface SystemChangeFreezeReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: SystemChangeFreezeOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** system.ChangeFreezeRequest */
+export interface SystemChangeFreezeRequest {
+  /** 结束时间 */
+  end_at: string;
+  /** 是否启用 */
+  is_enabled?: boolean;
+  /** 冻结的模块, 模块范围时必填 */
+  module?: "oes" | "mds" | "mon" | "jobs" | "resource" | "customer";
+  /** 名称 */
+  name: string;
+  /** 冻结原因 */
+  reason?: string;
+  /** 作用范围(global/module) */
+  scope: "global" | "module";
+  /** 开始时间 */
+  start_at: string;
+}
+
+/** system.DeployInfoOut */
+export interface SystemDeployInfoOut {
+  /** 功能启用状态, 前端据此隐藏不可用的功能入口 */
+  features?: Record<string, boolean>;
+  /** 部署模式(standard/embedded) */
+  mode?: string;
+}
+
+/** system.DeployInfoReply */
+export interface SystemDeployInfoReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: SystemDeployInfoOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** system.ExportReportRequest */
+export interface SystemExportReportRequest {
+  /** 统计日期, 日报为当天, 周报为所在的周, 为空时日报统计昨天, 周报统计上周 */
+  date?: string;
+  /** 导出格式(csv, xlsx) */
+  format: "csv" | "xlsx";
+  /** 报表周期(daily:日报, weekly:周报) */
+  period: "daily" | "weekly";
+}
+
+/** system.FeatureFlagOut */
+export interface SystemFeatureFlagOut {
+  /** 创建时间 */
+  created_at?: string;
+  /** 说明 */
+  description?: string;
+  /** ID */
+  id?: number;
+  /** 是否启用 */
+  is_enabled?: boolean;
+  /** 开关名称 */
+  name?: string;
+  /** 按用户开放的百分比 */
+  percentage?: number;
+  /** 开放的角色ID */
+  role_ids?: number[];
+  /** 更新时间 */
+  updated_at?: string;
+  /** 用户名 */
+  username?: string;
+}
+
+/** system.FeatureFlagReply */
+export interface SystemFeatureFlagReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: SystemFeatureFlagOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** system.FeatureFlagRequest */
+export interface SystemFeatureFlagRequest {
+  /** 说明 */
+  description?: string;
+  /** 是否启用, 关闭时对所有用户关闭 */
+  is_enabled?: boolean;
+  /** 开关名称, 如oes.workflow.dag */
+  name: string;
+  /** 按用户开放的百分比(0~100) */
+  percentage?: number;
+  /** 开放的角色ID */
+  role_ids?: number[];
+}
+
+/** system.GatewayQuery */
+export interface SystemGatewayQuery {
+  /** 接口路径, 如/api/v1/oes/colony */
+  path: string;
+  /** 查询参数 */
+  query?: Record<string, string>;
+}
+
+/** system.GatewayReply */
+export type SystemGatewayReply = Record<string, unknown>;
+
+/** system.GatewayRequest */
+export interface SystemGatewayRequest {
+  /** 子查询, 键为结果中的名称 */
+  queries: Record<string, SystemGatewayQuery>;
+}
+
+/** system.ListChangeFreezeReply */
+export interface SystemListChangeFreezeReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: SystemChangeFreezeOut[];
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** system.ListLogEntryReply */
+export interface SystemListLogEntryReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: SystemLogEntryOut[];
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** system.ListLogLevelReply */
+export interface SystemListLogLevelReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: SystemLogLevelOut[];
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** system.ListMaintenanceWindowReply */
+export interface SystemListMaintenanceWindowReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: SystemMaintenanceWindowOut[];
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** system.ListReportFileReply */
+export interface SystemListReportFileReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: SystemReportFileOut[];
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** system.LogEntryOut */
+export interface SystemLogEntryOut {
+  /** 调用位置 */
+  caller?: string;
+  /** 其他字段 */
+  fields?: Record<string, unknown>;
+  /** 日志级别 */
+  level?: string;
+  /** 日志内容 */
+  msg?: string;
+  /** 日志源 */
+  source?: string;
+  /** 时间 */
+  time?: string;
+  /** 链路ID */
+  trace_id?: string;
+}
+
+/** system.LogLevelOut */
+export interface SystemLogLevelOut {
+  /** 启动时的日志级别 */
+  default_level?: string;
+  /** 当前日志级别 */
+  level?: string;
+  /** 日志记录器 */
+  logger?: string;
+}
+
+/** system.MaintenanceWindowOut */
+export interface SystemMaintenanceWindowOut {
+  /** 创建时间 */
+  created_at?: string;
+  /** 说明 */
+  description?: string;
+  /** 结束时间 */
+  end_at?: string;
+  /** ID */
+  id?: number;
+  /** 当前是否生效 */
+  is_active?: boolean;
+  /** 是否启用 */
+  is_enabled?: boolean;
+  /** 所属模块 */
+  module?: string;
+  /** 名称 */
+  name?: string;
+  /** 重复周期 */
+  recurrence?: string;
+  /** 作用范围 */
+  scope?: string;
+  /** 开始时间 */
+  start_at?: string;
+  /** 集群或节点ID */
+  target_id?: number;
+  /** 更新时间 */
+  updated_at?: string;
+  /** 用户名 */
+  username?: string;
+}
+
+/** system.MaintenanceWindowReply */
+export interface SystemMaintenanceWindowReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: SystemMaintenanceWindowOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** system.MaintenanceWindowRequest */
+export interface SystemMaintenanceWindowRequest {
+  /** 说明 */
+  description?: string;
+  /** 结束时间 */
+  end_at: string;
+  /** 是否启用 */
+  is_enabled?: boolean;
+  /** 所属模块, 集群范围为oes/mds, 节点范围为mon */
+  module?: "oes" | "mds" | "mon";
+  /** 名称 */
+  name: string;
+  /** 重复周期(none/daily/weekly) */
+  recurrence?: "none" | "daily" | "weekly";
+  /** 作用范围(global/colony/node) */
+  scope: "global" | "colony" | "node";
+  /** 开始时间 */
+  start_at: string;
+  /** 集群或节点ID */
+  target_id?: number;
+}
+
+/** system.MigrationOut */
+export interface SystemMigrationOut {
+  /** 是否已执行 */
+  applied?: boolean;
+  /** 执行时间 */
+  applied_at?: string;
+  /** 说明 */
+  description?: string;
+  /** 迁移版本号 */
+  id?: string;
+  /** 是否为程序未知的迁移 */
+  unknown?: boolean;
+}
+
+/** system.MyFeatureFlagReply */
+export type SystemMyFeatureFlagReply = Record<string, unknown>;
+
+/** system.PagAuditRecordReply */
+export interface SystemPagAuditRecordReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CommonPagSystemAuditRecordOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** system.PagBackupReply */
+export interface SystemPagBackupReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CommonPagSystemBackupOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** system.PagCertMonitorReply */
+export interface SystemPagCertMonitorReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CommonPagSystemCertMonitorOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** system.PagChangeFreezeReply */
+export interface SystemPagChangeFreezeReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CommonPagSystemChangeFreezeOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** system.PagFeatureFlagReply */
+export interface SystemPagFeatureFlagReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CommonPagSystemFeatureFlagOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** system.PagMaintenanceWindowReply */
+export interface SystemPagMaintenanceWindowReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CommonPagSystemMaintenanceWindowOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** system.PagSlowQueryReply */
+export interface SystemPagSlowQueryReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CommonPagSystemSlowQueryOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** system.PagTaskReply */
+export interface SystemPagTaskReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CommonPagSystemTaskOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** system.PagWebhookDeliveryReply */
+export interface SystemPagWebhookDeliveryReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CommonPagSystemWebhookDeliveryOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** system.PagWebhookSubscriptionReply */
+export interface SystemPagWebhookSubscriptionReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: CommonPagSystemWebhookSubscriptionOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** system.ReportAlertOut */
+export interface SystemReportAlertOut {
+  /** 发出次数 */
+  fired?: number;
+  /** 告警类型 */
+  kind?: string;
+  /** 被维护窗口屏蔽的次数 */
+  muted?: number;
+}
+
+/** system.ReportFileOut */
+export interface SystemReportFileOut {
+  /** 修改时间 */
+  mod_time?: string;
+  /** 文件名 */
+  name?: string;
+  /** 文件大小(字节) */
+  size?: number;
+}
+
+/** system.ReportJobOut */
+export interface SystemReportJobOut {
+  /** 集群号 */
+  colony_num?: string;
+  /** 失败数, 包括失败、超时、崩溃和中断 */
+  failed?: number;
+  /** 项目(oes/mds) */
+  project?: string;
+  /** 成功数 */
+  success?: number;
+  /** 成功率, 按已结束的执行计算, 没有已结束的执行时为0 */
+  success_rate?: number;
+  /** 执行总数 */
+  total?: number;
+}
+
+/** system.ReportLoginOut */
+export interface SystemReportLoginOut {
+  /** 登录失败次数 */
+  failure?: number;
+  /** 登录成功次数 */
+  success?: number;
+  /** 登录总次数 */
+  total?: number;
+  /** 登录成功的用户数 */
+  users?: number;
+}
+
+/** system.ReportOut */
+export interface SystemReportOut {
+  /** 各类型的告警统计 */
+  alerts?: SystemReportAlertOut[];
+  /** 统计结束时间(不包含) */
+  end?: string;
+  /** 生成时间 */
+  generated_at?: string;
+  /** 各集群的任务执行统计 */
+  jobs?: SystemReportJobOut[];
+  /** 登录统计 */
+  login?: SystemReportLoginOut;
+  /** 报表周期 */
+  period?: string;
+  /** 统计开始时间(包含) */
+  start?: string;
+}
+
+/** system.ReportReply */
+export interface SystemReportReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: SystemReportOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** system.SchemaStatusOut */
+export interface SystemSchemaStatusOut {
+  /** 数据库当前版本 */
+  current?: string;
+  /** 程序要求的数据库版本 */
+  latest?: string;
+  /** 迁移列表 */
+  migrations?: SystemMigrationOut[];
+  /** 未执行的迁移数量 */
+  pending?: number;
+  /** 数据库结构版本是否与程序一致 */
+  up_to_date?: boolean;
+}
+
+/** system.SchemaStatusReply */
+export interface SystemSchemaStatusReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: SystemSchemaStatusOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** system.SearchGroupOut */
+export interface SystemSearchGroupOut {
+  /** 搜索结果 */
+  hits?: SystemSearchHitOut[];
+  /** 资源类型, 如user、script */
+  kind?: string;
+  /** 资源名称 */
+  label?: string;
+  /** 匹配的总数 */
+  total?: number;
+}
+
+/** system.SearchHitOut */
+export interface SystemSearchHitOut {
+  /** 描述 */
+  description?: string;
+  /** 高亮的描述, 已转义HTML, 匹配部分用<em>标记 */
+  description_highlight?: string;
+  /** 记录ID */
+  id?: number;
+  /** 标题 */
+  title?: string;
+  /** 高亮的标题, 已转义HTML, 匹配部分用<em>标记 */
+  title_highlight?: string;
+}
+
+/** system.SearchReply */
+export interface SystemSearchReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: SystemSearchGroupOut[];
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** system.SetLogLevelRequest */
+export interface SystemSetLogLevelRequest {
+  /** 日志级别 */
+  level: "debug" | "info" | "warn" | "error";
+  /** 日志记录器(server/service/biz/data/cron), 为空时调整全部日志记录器 */
+  logger?: string;
+}
+
+/** system.SlowQueryOut */
+export interface SystemSlowQueryOut {
+  /** 平均耗时(毫秒) */
+  avg_ms?: number;
+  /** 次数 */
+  count?: number;
+  /** 语句指纹 */
+  fingerprint?: string;
+  /** 首次出现时间 */
+  first_seen_at?: string;
+  /** ID */
+  id?: number;
+  /** 最近出现时间 */
+  last_seen_at?: string;
+  /** 最近一次的链路ID */
+  last_trace_id?: string;
+  /** 最大耗时(毫秒) */
+  max_ms?: number;
+  /** 归一化后的语句 */
+  statement?: string;
+  /** 表名 */
+  table_name?: string;
+}
+
+/** system.StatsAlertOut */
+export interface SystemStatsAlertOut {
+  /** 告警内容, 不同类型的告警字段不同 */
+  data?: Record<string, unknown>;
+  /** 事件ID */
+  event_id?: string;
+  /** 告警类型 */
+  kind?: string;
+  /** 发生时间 */
+  occurred_at?: string;
+}
+
+/** system.StatsAlertReply */
+export interface SystemStatsAlertReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: SystemStatsAlertOut[];
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** system.StatsColonyOut */
+export interface SystemStatsColonyOut {
+  /** 停用的集群数 */
+  disabled?: number;
+  /** 启用的集群数 */
+  enabled?: number;
+  /** 项目(oes, mds) */
+  project?: string;
+}
+
+/** system.StatsColonyReply */
+export interface SystemStatsColonyReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: SystemStatsColonyOut[];
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** system.StatsJobDayOut */
+export interface SystemStatsJobDayOut {
+  /** 日期 */
+  date?: string;
+  /** 失败数, 包括失败、超时、崩溃和中断 */
+  failure?: number;
+  /** 待执行和执行中的数量 */
+  running?: number;
+  /** 成功数 */
+  success?: number;
+}
+
+/** system.StatsJobReply */
+export interface SystemStatsJobReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: SystemStatsJobDayOut[];
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** system.StatsUserOut */
+export interface SystemStatsUserOut {
+  /** 已激活的用户数 */
+  active?: number;
+  /** 统计天数 */
+  days?: number;
+  /** 统计期间内登录成功的用户数 */
+  login?: number;
+  /** 用户总数 */
+  total?: number;
+}
+
+/** system.StatsUserReply */
+export interface SystemStatsUserReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: SystemStatsUserOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** system.TaskOut */
+export interface SystemTaskOut {
+  /** 创建时间 */
+  created_at?: string;
+  /** 错误信息 */
+  error?: Record<string, unknown>;
+  /** 结束时间 */
+  finished_at?: string;
+  /** ID */
+  id?: number;
+  /** 任务类型 */
+  kind?: string;
+  /** 当前步骤 */
+  message?: string;
+  /** 任务名称 */
+  name?: string;
+  /** 进度百分比 */
+  progress?: number;
+  /** 执行结果 */
+  result?: Record<string, unknown>;
+  /** 开始执行时间 */
+  started_at?: string;
+  /** 状态(pending-等待执行,running-执行中,succeeded-成功,failed-失败,canceled-已取消) */
+  status?: string;
+  /** 提交用户名 */
+  username?: string;
+}
+
+/** system.TaskReply */
+export interface SystemTaskReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: SystemTaskOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** system.WebhookDeliveryOut */
+export interface SystemWebhookDeliveryOut {
+  /** 已推送次数 */
+  attempts?: number;
+  /** 创建时间 */
+  created_at?: string;
+  /** 推送成功时间 */
+  delivered_at?: string;
+  /** 最近一次错误信息 */
+  error_message?: string;
+  /** 事件ID */
+  event_id?: string;
+  /** 事件类型 */
+  event_type?: string;
+  /** ID */
+  id?: number;
+  /** 下次推送时间 */
+  next_retry_at?: string;
+  /** 推送内容 */
+  payload?: string;
+  /** 最近一次响应状态码 */
+  response_code?: number;
+  /** 推送状态(0-待推送,1-成功,2-失败) */
+  status?: number;
+  /** 订阅ID */
+  subscription_id?: number;
+}
+
+/** system.WebhookDeliveryReply */
+export interface SystemWebhookDeliveryReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: SystemWebhookDeliveryOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** system.WebhookSubscriptionOut */
+export interface SystemWebhookSubscriptionOut {
+  /** 创建时间 */
+  created_at?: string;
+  /** 说明 */
+  description?: string;
+  /** 订阅的事件类型 */
+  events?: string[];
+  /** ID */
+  id?: number;
+  /** 是否启用 */
+  is_enabled?: boolean;
+  /** 名称 */
+  name?: string;
+  /** 更新时间 */
+  updated_at?: string;
+  /** 推送地址 */
+  url?: string;
+  /** 用户名 */
+  username?: string;
+}
+
+/** system.WebhookSubscriptionReply */
+export interface SystemWebhookSubscriptionReply {
+  /**
+   * 状态码
+   * Example: 200
+   */
+  code?: number;
+  /**
+   * 数据
+   * 可以是任意类型的数据
+   */
+  data?: SystemWebhookSubscriptionOut;
+  /**
+   * 信息
+   * Example: "success"
+   */
+  msg?: string;
+}
+
+/** system.WebhookSubscriptionRequest */
+export interface SystemWebhookSubscriptionRequest {
+  /** 说明 */
+  description?: string;
+  /** 订阅的事件类型, *表示全部 */
+  events: string[];
+  /** 是否启用 */
+  is_enabled?: boolean;
+  /** 名称 */
+  name: string;
+  /** 签名密钥, 更新时为空表示不修改 */
+  secret?: string;
+  /** 推送地址 */
+  url: string;
+}
+
+/** listAdminBackup的查询参数 */
+export interface ListAdminBackupParams {
+  /**
+   * 创建时间之后的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  after_created_at?: string;
+  /**
+   * 更新时间之后的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  after_updated_at?: string;
+  /**
+   * 创建时间之前的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  before_created_at?: string;
+  /**
+   * 更新时间之前的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  before_updated_at?: string;
+  /** 导出的列(多个用,隔开), 默认导出全部列 */
+  columns?: string;
+  /** 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据 */
+  export?: "csv" | "xlsx";
+  /**
+   * 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+   * 运算符: eq ne gt gte lt lte contains in between is_null not_null
+   * example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+   */
+  filter?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** "唯一标识列表(多个用,隔开)" */
+  ids?: string;
+  /** 分页页码 */
+  page?: number;
+  /** 分页大小 */
+  size?: number;
+  /** 状态 */
+  status?: "running" | "succeeded" | "failed";
+  /** 触发方式 */
+  trigger?: "manual" | "scheduled";
+  /** 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表 */
+  with_total?: boolean;
+}
+
+/** listAdminDbSlowQueries的查询参数 */
+export interface ListAdminDbSlowQueriesParams {
+  /** 导出的列(多个用,隔开), 默认导出全部列 */
+  columns?: string;
+  /** 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据 */
+  export?: "csv" | "xlsx";
+  /**
+   * 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+   * 运算符: eq ne gt gte lt lte contains in between is_null not_null
+   * example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+   */
+  filter?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** "唯一标识列表(多个用,隔开)" */
+  ids?: string;
+  /** 最小的最大耗时(毫秒) */
+  min_ms?: number;
+  /** 排序方式(max:最大耗时, count:次数, avg:平均耗时, last:最近出现) */
+  order_by?: "max" | "count" | "avg" | "last";
+  /** 分页页码 */
+  page?: number;
+  /** 分页大小 */
+  size?: number;
+  /** 表名 */
+  table_name?: string;
+  /** 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表 */
+  with_total?: boolean;
+}
+
+/** listAdminFeatureFlag的查询参数 */
+export interface ListAdminFeatureFlagParams {
+  /** 导出的列(多个用,隔开), 默认导出全部列 */
+  columns?: string;
+  /** 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据 */
+  export?: "csv" | "xlsx";
+  /**
+   * 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+   * 运算符: eq ne gt gte lt lte contains in between is_null not_null
+   * example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+   */
+  filter?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** "唯一标识列表(多个用,隔开)" */
+  ids?: string;
+  /** 是否启用 */
+  is_enabled?: boolean;
+  /** 开关名称 */
+  name?: string;
+  /** 分页页码 */
+  page?: number;
+  /** 分页大小 */
+  size?: number;
+  /** 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表 */
+  with_total?: boolean;
+}
+
+/** deleteAdminLogLevel的查询参数 */
+export interface DeleteAdminLogLevelParams {
+  /** 日志记录器(server/service/biz/data/cron), 为空时恢复全部日志记录器 */
+  logger?: string;
+}
+
+/** listAdminLogs的查询参数 */
+export interface ListAdminLogsParams {
+  /** 结束时间 */
+  end_time?: string;
+  /** 关键字 */
+  keyword?: string;
+  /** 最低日志级别 */
+  level?: "debug" | "info" | "warn" | "error" | "dpanic" | "panic" | "fatal";
+  /** 返回条数 */
+  size?: number;
+  /** 日志源(service/biz/data/server), 为空时查询service、biz和data */
+  source?: "service" | "biz" | "data" | "server";
+  /** 开始时间 */
+  start_time?: string;
+  /** 链路ID */
+  trace_id?: string;
+}
+
+/** getAuthOidcCallback的查询参数 */
+export interface GetAuthOidcCallbackParams {
+  /** 授权码 */
+  code?: string;
+  /** 身份提供方返回的错误 */
+  error?: string;
+  /** 身份提供方返回的错误描述 */
+  error_description?: string;
+  /** 发起登录时生成的state */
+  state: string;
+}
+
+/** getAuthOidcLogin的查询参数 */
+export interface GetAuthOidcLoginParams {
+  /** 身份提供方标识 */
+  provider: string;
+}
+
+/** listCustomerAPI的查询参数 */
+export interface ListCustomerAPIParams {
+  /**
+   * 创建时间之后的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  after_created_at?: string;
+  /**
+   * 更新时间之后的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  after_updated_at?: string;
+  /**
+   * 创建时间之前的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  before_created_at?: string;
+  /**
+   * 更新时间之前的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  before_updated_at?: string;
+  /** 导出的列(多个用,隔开), 默认导出全部列 */
+  columns?: string;
+  /** 描述信息 */
+  descr?: string;
+  /** 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据 */
+  export?: "csv" | "xlsx";
+  /**
+   * 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+   * 运算符: eq ne gt gte lt lte contains in between is_null not_null
+   * example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+   */
+  filter?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** "唯一标识列表(多个用,隔开)" */
+  ids?: string;
+  /** 是否失效 */
+  is_orphan?: boolean;
+  /** 标签 */
+  label?: string;
+  /** 请求方法 */
+  method?: "GET" | "POST" | "PUT" | "DELETE" | "PATCH" | "WS";
+  /** 分页页码 */
+  page?: number;
+  /** 分页大小 */
+  size?: number;
+  /** URL地址 */
+  url?: string;
+  /** 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表 */
+  with_total?: boolean;
+}
+
+/** listCustomerButton的查询参数 */
+export interface ListCustomerButtonParams {
+  /**
+   * 创建时间之后的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  after_created_at?: string;
+  /**
+   * 更新时间之后的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  after_updated_at?: string;
+  /**
+   * 创建时间之前的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  before_created_at?: string;
+  /**
+   * 更新时间之前的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  before_updated_at?: string;
+  /** 导出的列(多个用,隔开), 默认导出全部列 */
+  columns?: string;
+  /** 描述信息 */
+  descr?: string;
+  /** 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据 */
+  export?: "csv" | "xlsx";
+  /**
+   * 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+   * 运算符: eq ne gt gte lt lte contains in between is_null not_null
+   * example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+   */
+  filter?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** "唯一标识列表(多个用,隔开)" */
+  ids?: string;
+  /** 是否激活 */
+  is_active?: boolean;
+  /** 菜单ID */
+  menu_id?: number;
+  /** 按钮名称 */
+  name?: string;
+  /** 分页页码 */
+  page?: number;
+  /** 分页大小 */
+  size?: number;
+  /** 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表 */
+  with_total?: boolean;
+}
+
+/** listCustomerCasbinModelHistory的查询参数 */
+export interface ListCustomerCasbinModelHistoryParams {
+  /**
+   * 创建时间之后的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  after_created_at?: string;
+  /**
+   * 更新时间之后的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  after_updated_at?: string;
+  /**
+   * 创建时间之前的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  before_created_at?: string;
+  /**
+   * 更新时间之前的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  before_updated_at?: string;
+  /** 导出的列(多个用,隔开), 默认导出全部列 */
+  columns?: string;
+  /** 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据 */
+  export?: "csv" | "xlsx";
+  /**
+   * 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+   * 运算符: eq ne gt gte lt lte contains in between is_null not_null
+   * example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+   */
+  filter?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** "唯一标识列表(多个用,隔开)" */
+  ids?: string;
+  /** 分页页码 */
+  page?: number;
+  /** 分页大小 */
+  size?: number;
+  /** 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表 */
+  with_total?: boolean;
+}
+
+/** listCustomerDepartment的查询参数 */
+export interface ListCustomerDepartmentParams {
+  /**
+   * 创建时间之后的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  after_created_at?: string;
+  /**
+   * 更新时间之后的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  after_updated_at?: string;
+  /**
+   * 创建时间之前的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  before_created_at?: string;
+  /**
+   * 更新时间之前的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  before_updated_at?: string;
+  /** 导出的列(多个用,隔开), 默认导出全部列 */
+  columns?: string;
+  /** 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据 */
+  export?: "csv" | "xlsx";
+  /**
+   * 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+   * 运算符: eq ne gt gte lt lte contains in between is_null not_null
+   * example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+   */
+  filter?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** "唯一标识列表(多个用,隔开)" */
+  ids?: string;
+  /** 名称 */
+  name?: string;
+  /** 分页页码 */
+  page?: number;
+  /** 父部门ID */
+  parent_id?: number;
+  /** 分页大小 */
+  size?: number;
+  /** 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表 */
+  with_total?: boolean;
+}
+
+/** listCustomerGroup的查询参数 */
+export interface ListCustomerGroupParams {
+  /**
+   * 创建时间之后的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  after_created_at?: string;
+  /**
+   * 更新时间之后的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  after_updated_at?: string;
+  /**
+   * 创建时间之前的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  before_created_at?: string;
+  /**
+   * 更新时间之前的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  before_updated_at?: string;
+  /** 导出的列(多个用,隔开), 默认导出全部列 */
+  columns?: string;
+  /** 描述信息 */
+  descr?: string;
+  /** 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据 */
+  export?: "csv" | "xlsx";
+  /**
+   * 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+   * 运算符: eq ne gt gte lt lte contains in between is_null not_null
+   * example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+   */
+  filter?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** "唯一标识列表(多个用,隔开)" */
+  ids?: string;
+  /** 名称 */
+  name?: string;
+  /** 分页页码 */
+  page?: number;
+  /** 分页大小 */
+  size?: number;
+  /** 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表 */
+  with_total?: boolean;
+}
+
+/** getCustomerMeOidcLink的查询参数 */
+export interface GetCustomerMeOidcLinkParams {
+  /** 身份提供方标识 */
+  provider: string;
+}
+
+/** listCustomerMeRecordLogin的查询参数 */
+export interface ListCustomerMeRecordLoginParams {
+  /**
+   * 登陆时间之后的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  after_login_at?: string;
+  /**
+   * 登陆时间之前的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  before_login_at?: string;
+  /** 导出的列(多个用,隔开), 默认导出全部列 */
+  columns?: string;
+  /** 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据 */
+  export?: "csv" | "xlsx";
+  /**
+   * 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+   * 运算符: eq ne gt gte lt lte contains in between is_null not_null
+   * example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+   */
+  filter?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** "唯一标识列表(多个用,隔开)" */
+  ids?: string;
+  /** IP 地址 */
+  ip_address?: string;
+  /** 用户名 */
+  name?: string;
+  /** 分页页码 */
+  page?: number;
+  /** 分页大小 */
+  size?: number;
+  /** 登陆状态 */
+  status?: boolean;
+  /** 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表 */
+  with_total?: boolean;
+}
+
+/** listCustomerMenu的查询参数 */
+export interface ListCustomerMenuParams {
+  /**
+   * 创建时间之后的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  after_created_at?: string;
+  /**
+   * 更新时间之后的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  after_updated_at?: string;
+  /**
+   * 创建时间之前的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  before_created_at?: string;
+  /**
+   * 更新时间之前的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  before_updated_at?: string;
+  /** 导出的列(多个用,隔开), 默认导出全部列 */
+  columns?: string;
+  /** 组件路径 */
+  component?: string;
+  /** 菜单描述 */
+  descr?: string;
+  /** 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据 */
+  export?: "csv" | "xlsx";
+  /**
+   * 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+   * 运算符: eq ne gt gte lt lte contains in between is_null not_null
+   * example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+   */
+  filter?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** "唯一标识列表(多个用,隔开)" */
+  ids?: string;
+  /** 是否激活 */
+  is_active?: boolean;
+  /** 名称 */
+  name?: string;
+  /** 分页页码 */
+  page?: number;
+  /** 父级菜单ID */
+  parent_id?: number;
+  /** 前端路由路径 */
+  path?: string;
+  /** 分页大小 */
+  size?: number;
+  /** 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表 */
+  with_total?: boolean;
+}
+
+/** getCustomerMenuDependencies的查询参数 */
+export interface GetCustomerMenuDependenciesParams {
+  /** 菜单编号列表 */
+  ids: number[];
+}
+
+/** createCustomerRbacImport的查询参数 */
+export interface CreateCustomerRbacImportParams {
+  /** 是否只比较差异 */
+  dry_run?: boolean;
+  /** 冲突处理方式 */
+  conflict?: "fail" | "skip" | "overwrite";
+}
+
+/** listCustomerRole的查询参数 */
+export interface ListCustomerRoleParams {
+  /**
+   * 创建时间之后的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  after_created_at?: string;
+  /**
+   * 更新时间之后的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  after_updated_at?: string;
+  /**
+   * 创建时间之前的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  before_created_at?: string;
+  /**
+   * 更新时间之前的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  before_updated_at?: string;
+  /** 导出的列(多个用,隔开), 默认导出全部列 */
+  columns?: string;
+  /** 描述信息 */
+  descr?: string;
+  /** 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据 */
+  export?: "csv" | "xlsx";
+  /**
+   * 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+   * 运算符: eq ne gt gte lt lte contains in between is_null not_null
+   * example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+   */
+  filter?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** "唯一标识列表(多个用,隔开)" */
+  ids?: string;
+  /** 名称 */
+  name?: string;
+  /** 分页页码 */
+  page?: number;
+  /** 分页大小 */
+  size?: number;
+  /** 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表 */
+  with_total?: boolean;
+}
+
+/** getCustomerRoleDependencies的查询参数 */
+export interface GetCustomerRoleDependenciesParams {
+  /** 角色编号列表 */
+  ids: number[];
+}
+
+/** listCustomerSigningKey的查询参数 */
+export interface ListCustomerSigningKeyParams {
+  /**
+   * 创建时间之后的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  after_created_at?: string;
+  /**
+   * 更新时间之后的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  after_updated_at?: string;
+  /**
+   * 创建时间之前的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  before_created_at?: string;
+  /**
+   * 更新时间之前的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  before_updated_at?: string;
+  /** 导出的列(多个用,隔开), 默认导出全部列 */
+  columns?: string;
+  /** 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据 */
+  export?: "csv" | "xlsx";
+  /**
+   * 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+   * 运算符: eq ne gt gte lt lte contains in between is_null not_null
+   * example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+   */
+  filter?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** "唯一标识列表(多个用,隔开)" */
+  ids?: string;
+  /** 分页页码 */
+  page?: number;
+  /** 分页大小 */
+  size?: number;
+  /** 令牌类型 */
+  token_type?: "access" | "refresh";
+  /** 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表 */
+  with_total?: boolean;
+}
+
+/** listCustomerUser的查询参数 */
+export interface ListCustomerUserParams {
+  /**
+   * 创建时间之后的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  after_created_at?: string;
+  /**
+   * 更新时间之后的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  after_updated_at?: string;
+  /**
+   * 创建时间之前的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  before_created_at?: string;
+  /**
+   * 更新时间之前的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  before_updated_at?: string;
+  /** 导出的列(多个用,隔开), 默认导出全部列 */
+  columns?: string;
+  /** 部门ID */
+  department_id?: number;
+  /** 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据 */
+  export?: "csv" | "xlsx";
+  /**
+   * 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+   * 运算符: eq ne gt gte lt lte contains in between is_null not_null
+   * example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+   */
+  filter?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** "唯一标识列表(多个用,隔开)" */
+  ids?: string;
+  /** 是否包含下级部门的用户, 由服务层展开为部门ID列表 */
+  include_sub?: boolean;
+  /** 是否激活 */
+  is_active?: boolean;
+  /** 是否是工作人员 */
+  is_staff?: boolean;
+  /** 分页页码 */
+  page?: number;
+  /** 角色ID */
+  role_id?: number;
+  /** 分页大小 */
+  size?: number;
+  /** 用户名 */
+  username?: string;
+  /** 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表 */
+  with_total?: boolean;
+}
+
+/** listCustomerUserRecordLogin的查询参数 */
+export interface ListCustomerUserRecordLoginParams {
+  /**
+   * 登陆时间之后的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  after_login_at?: string;
+  /**
+   * 登陆时间之前的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  before_login_at?: string;
+  /** 导出的列(多个用,隔开), 默认导出全部列 */
+  columns?: string;
+  /** 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据 */
+  export?: "csv" | "xlsx";
+  /**
+   * 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+   * 运算符: eq ne gt gte lt lte contains in between is_null not_null
+   * example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+   */
+  filter?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** "唯一标识列表(多个用,隔开)" */
+  ids?: string;
+  /** IP 地址 */
+  ip_address?: string;
+  /** 用户名 */
+  name?: string;
+  /** 分页页码 */
+  page?: number;
+  /** 分页大小 */
+  size?: number;
+  /** 登陆状态 */
+  status?: boolean;
+  /** 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表 */
+  with_total?: boolean;
+}
+
+/** getJobsCalendarCheck的查询参数 */
+export interface GetJobsCalendarCheckParams {
+  /** 日期(YYYY-MM-DD), 默认当天 */
+  date?: string;
+}
+
+/** listJobsCalendarHoliday的查询参数 */
+export interface ListJobsCalendarHolidayParams {
+  /** 导出的列(多个用,隔开), 默认导出全部列 */
+  columns?: string;
+  /** 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据 */
+  export?: "csv" | "xlsx";
+  /**
+   * 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+   * 运算符: eq ne gt gte lt lte contains in between is_null not_null
+   * example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+   */
+  filter?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** "唯一标识列表(多个用,隔开)" */
+  ids?: string;
+  /** 节假日名称 */
+  name?: string;
+  /** 分页页码 */
+  page?: number;
+  /** 分页大小 */
+  size?: number;
+  /** 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表 */
+  with_total?: boolean;
+  /** 年份 */
+  year?: number;
+}
+
+/** listJobsCalendarSkip的查询参数 */
+export interface ListJobsCalendarSkipParams {
+  /** 导出的列(多个用,隔开), 默认导出全部列 */
+  columns?: string;
+  /** 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据 */
+  export?: "csv" | "xlsx";
+  /**
+   * 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+   * 运算符: eq ne gt gte lt lte contains in between is_null not_null
+   * example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+   */
+  filter?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** "唯一标识列表(多个用,隔开)" */
+  ids?: string;
+  /** 分页页码 */
+  page?: number;
+  /** 跳过原因 */
+  reason?: "weekend" | "holiday";
+  /** 计划任务ID */
+  schedule_id?: number;
+  /** 分页大小 */
+  size?: number;
+  /** 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表 */
+  with_total?: boolean;
+}
+
+/** listJobsRecord的查询参数 */
+export interface ListJobsRecordParams {
+  /**
+   * 创建时间之后的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  after_created_at?: string;
+  /**
+   * 更新时间之后的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  after_updated_at?: string;
+  /**
+   * 创建时间之前的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  before_created_at?: string;
+  /**
+   * 更新时间之前的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  before_updated_at?: string;
+  /** 导出的列(多个用,隔开), 默认导出全部列 */
+  columns?: string;
+  /** 按脚本退出码筛选 */
+  exit_code?: number;
+  /** 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据 */
+  export?: "csv" | "xlsx";
+  /**
+   * 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+   * 运算符: eq ne gt gte lt lte contains in between is_null not_null
+   * example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+   */
+  filter?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** "唯一标识列表(多个用,隔开)" */
+  ids?: string;
+  /** 分页页码 */
+  page?: number;
+  /** 按脚本ID筛选 */
+  script_id?: number;
+  /** 分页大小 */
+  size?: number;
+  /** 筛选脚本执行的任务状态 */
+  status?: number;
+  /** 筛选计划任务触发类型 */
+  trigger_type?: string;
+  /** 按用户名筛选 */
+  username?: string;
+  /** 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表 */
+  with_total?: boolean;
+}
+
+/** listJobsSchedule的查询参数 */
+export interface ListJobsScheduleParams {
+  /**
+   * 创建时间之后的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  after_created_at?: string;
+  /**
+   * 更新时间之后的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  after_updated_at?: string;
+  /**
+   * 创建时间之前的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  before_created_at?: string;
+  /**
+   * 更新时间之前的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  before_updated_at?: string;
+  /** 导出的列(多个用,隔开), 默认导出全部列 */
+  columns?: string;
+  /** 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据 */
+  export?: "csv" | "xlsx";
+  /**
+   * 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+   * 运算符: eq ne gt gte lt lte contains in between is_null not_null
+   * example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+   */
+  filter?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** "唯一标识列表(多个用,隔开)" */
+  ids?: string;
+  /** 是否启用 */
+  is_enabled?: boolean;
+  /** 名称 */
+  name?: string;
+  /** 分页页码 */
+  page?: number;
+  /** 脚本ID */
+  script_id?: number;
+  /** 分页大小 */
+  size?: number;
+  /** 用户名 */
+  username?: string;
+  /** 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表 */
+  with_total?: boolean;
+}
+
+/** listJobsScript的查询参数 */
+export interface ListJobsScriptParams {
+  /**
+   * 创建时间之后的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  after_created_at?: string;
+  /**
+   * 更新时间之后的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  after_updated_at?: string;
+  /**
+   * 创建时间之前的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  before_created_at?: string;
+  /**
+   * 更新时间之前的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  before_updated_at?: string;
+  /** 导出的列(多个用,隔开), 默认导出全部列 */
+  columns?: string;
+  /** 描述信息 */
+  descr?: string;
+  /** 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据 */
+  export?: "csv" | "xlsx";
+  /**
+   * 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+   * 运算符: eq ne gt gte lt lte contains in between is_null not_null
+   * example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+   */
+  filter?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** "唯一标识列表(多个用,隔开)" */
+  ids?: string;
+  /** 是否是内置脚本 */
+  is_builtin?: boolean;
+  /** 标签 */
+  label?: string;
+  /** 脚本语言 */
+  language?: string;
+  /** 名称 */
+  name?: string;
+  /** 分页页码 */
+  page?: number;
+  /** 项目 */
+  project?: string;
+  /** 分页大小 */
+  size?: number;
+  /** 状态 */
+  status?: boolean;
+  /** 最后修改的用户 */
+  user_id?: number;
+  /** 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表 */
+  with_total?: boolean;
+}
+
+/** createJobsScript的表单 */
+export interface CreateJobsScriptForm {
+  /** 脚本文件 */
+  file: UploadFile;
+  /** 脚本描述 */
+  descr?: string;
+  /** 项目名称 */
+  project: string;
+  /** 标签 */
+  label?: string;
+  /** 脚本语言 */
+  language: string;
+  /** 脚本状态 */
+  status: boolean;
+  /** 参数定义(JSON数组) */
+  params?: string;
+  /** 隔离执行配置(JSON对象) */
+  isolation?: string;
+  /** 产物文件匹配规则(JSON数组) */
+  artifacts?: string;
+}
+
+/** listJobsScriptLabel的查询参数 */
+export interface ListJobsScriptLabelParams {
+  /**
+   * 创建时间之后的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  after_created_at?: string;
+  /**
+   * 更新时间之后的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  after_updated_at?: string;
+  /**
+   * 创建时间之前的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  before_created_at?: string;
+  /**
+   * 更新时间之前的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  before_updated_at?: string;
+  /** 导出的列(多个用,隔开), 默认导出全部列 */
+  columns?: string;
+  /** 描述信息 */
+  descr?: string;
+  /** 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据 */
+  export?: "csv" | "xlsx";
+  /**
+   * 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+   * 运算符: eq ne gt gte lt lte contains in between is_null not_null
+   * example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+   */
+  filter?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** "唯一标识列表(多个用,隔开)" */
+  ids?: string;
+  /** 是否是内置脚本 */
+  is_builtin?: boolean;
+  /** 标签 */
+  label?: string;
+  /** 脚本语言 */
+  language?: string;
+  /** 名称 */
+  name?: string;
+  /** 分页页码 */
+  page?: number;
+  /** 项目 */
+  project?: string;
+  /** 分页大小 */
+  size?: number;
+  /** 状态 */
+  status?: boolean;
+  /** 最后修改的用户 */
+  user_id?: number;
+  /** 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表 */
+  with_total?: boolean;
+}
+
+/** listJobsScriptProject的查询参数 */
+export interface ListJobsScriptProjectParams {
+  /**
+   * 创建时间之后的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  after_created_at?: string;
+  /**
+   * 更新时间之后的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  after_updated_at?: string;
+  /**
+   * 创建时间之前的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  before_created_at?: string;
+  /**
+   * 更新时间之前的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  before_updated_at?: string;
+  /** 导出的列(多个用,隔开), 默认导出全部列 */
+  columns?: string;
+  /** 描述信息 */
+  descr?: string;
+  /** 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据 */
+  export?: "csv" | "xlsx";
+  /**
+   * 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+   * 运算符: eq ne gt gte lt lte contains in between is_null not_null
+   * example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+   */
+  filter?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** "唯一标识列表(多个用,隔开)" */
+  ids?: string;
+  /** 是否是内置脚本 */
+  is_builtin?: boolean;
+  /** 标签 */
+  label?: string;
+  /** 脚本语言 */
+  language?: string;
+  /** 名称 */
+  name?: string;
+  /** 分页页码 */
+  page?: number;
+  /** 项目 */
+  project?: string;
+  /** 分页大小 */
+  size?: number;
+  /** 状态 */
+  status?: boolean;
+  /** 最后修改的用户 */
+  user_id?: number;
+  /** 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表 */
+  with_total?: boolean;
+}
+
+/** updateJobsScript的表单 */
+export interface UpdateJobsScriptForm {
+  /** 脚本文件 */
+  file: UploadFile;
+  /** 脚本描述 */
+  descr?: string;
+  /** 项目名称 */
+  project: string;
+  /** 标签 */
+  label?: string;
+  /** 脚本语言 */
+  language: string;
+  /** 脚本状态 */
+  status: boolean;
+  /** 参数定义(JSON数组) */
+  params?: string;
+  /** 隔离执行配置(JSON对象) */
+  isolation?: string;
+  /** 产物文件匹配规则(JSON数组) */
+  artifacts?: string;
+}
+
+/** listMdsBackfill的查询参数 */
+export interface ListMdsBackfillParams {
+  /** 集群号 */
+  colony_num?: string;
+  /** 导出的列(多个用,隔开), 默认导出全部列 */
+  columns?: string;
+  /** 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据 */
+  export?: "csv" | "xlsx";
+  /**
+   * 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+   * 运算符: eq ne gt gte lt lte contains in between is_null not_null
+   * example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+   */
+  filter?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** "唯一标识列表(多个用,隔开)" */
+  ids?: string;
+  /** mds集群ID */
+  mds_colony_id?: number;
+  /** 分页页码 */
+  page?: number;
+  /** 分页大小 */
+  size?: number;
+  /** 执行状态 */
+  status?: "pending" | "running" | "success" | "failed" | "cancelled";
+  /** 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表 */
+  with_total?: boolean;
+}
+
+/** listMdsColony的查询参数 */
+export interface ListMdsColonyParams {
+  /** 页码 */
+  page?: number;
+  /** 每页数量 */
+  size?: number;
+  /** mds集群名称 */
+  name?: string;
+  /** 是否启用 */
+  is_enabled?: boolean;
+  /** 创建用户名 */
+  username?: string;
+  /** 导出格式 */
+  export?: "csv" | "xlsx";
+  /** 导出的列(多个用,隔开) */
+  columns?: string;
+}
+
+/** listMdsColonyStatus的查询参数 */
+export interface ListMdsColonyStatusParams {
+  /**
+   * 创建时间之后的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  after_created_at?: string;
+  /**
+   * 更新时间之后的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  after_updated_at?: string;
+  /**
+   * 创建时间之前的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  before_created_at?: string;
+  /**
+   * 更新时间之前的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  before_updated_at?: string;
+  /** 集群号 */
+  colony_num?: string;
+  /** 导出的列(多个用,隔开), 默认导出全部列 */
+  columns?: string;
+  /** 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据 */
+  export?: "csv" | "xlsx";
+  /** 解压后名称 */
+  extracted_name?: string;
+  /**
+   * 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+   * 运算符: eq ne gt gte lt lte contains in between is_null not_null
+   * example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+   */
+  filter?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** "唯一标识列表(多个用,隔开)" */
+  ids?: string;
+  /** 是否启用 */
+  is_enable?: boolean;
+  /** mon节点ID */
+  mon_node_id?: number;
+  /** 程序包ID */
+  package_id?: number;
+  /** 分页页码 */
+  page?: number;
+  /** 分页大小 */
+  size?: number;
+  /** 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表 */
+  with_total?: boolean;
+}
+
+/** listMdsIngestRecord的查询参数 */
+export interface ListMdsIngestRecordParams {
+  /**
+   * 创建时间之后的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  after_created_at?: string;
+  /**
+   * 更新时间之后的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  after_updated_at?: string;
+  /**
+   * 创建时间之前的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  before_created_at?: string;
+  /**
+   * 更新时间之前的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  before_updated_at?: string;
+  /** 导出的列(多个用,隔开), 默认导出全部列 */
+  columns?: string;
+  /** 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据 */
+  export?: "csv" | "xlsx";
+  /**
+   * 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+   * 运算符: eq ne gt gte lt lte contains in between is_null not_null
+   * example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+   */
+  filter?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** "唯一标识列表(多个用,隔开)" */
+  ids?: string;
+  /** 分页页码 */
+  page?: number;
+  /** 分页大小 */
+  size?: number;
+  /** 数据源ID */
+  source_id?: number;
+  /** 状态 */
+  status?: "running" | "success" | "failed";
+  /** 交易日 */
+  trading_day?: string;
+  /** 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表 */
+  with_total?: boolean;
+}
+
+/** listMdsIngestSource的查询参数 */
+export interface ListMdsIngestSourceParams {
+  /**
+   * 创建时间之后的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  after_created_at?: string;
+  /**
+   * 更新时间之后的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  after_updated_at?: string;
+  /**
+   * 创建时间之前的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  before_created_at?: string;
+  /**
+   * 更新时间之前的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  before_updated_at?: string;
+  /** 导出的列(多个用,隔开), 默认导出全部列 */
+  columns?: string;
+  /** 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据 */
+  export?: "csv" | "xlsx";
+  /**
+   * 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+   * 运算符: eq ne gt gte lt lte contains in between is_null not_null
+   * example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+   */
+  filter?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** "唯一标识列表(多个用,隔开)" */
+  ids?: string;
+  /** 是否启用 */
+  is_enabled?: boolean;
+  /** 数据源类型 */
+  kind?: "sftp" | "local" | "http";
+  /** 名称 */
+  name?: string;
+  /** 分页页码 */
+  page?: number;
+  /** 分页大小 */
+  size?: number;
+  /** 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表 */
+  with_total?: boolean;
+}
+
+/** listMdsNode的查询参数 */
+export interface ListMdsNodeParams {
+  /**
+   * 创建时间之后的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  after_created_at?: string;
+  /**
+   * 更新时间之后的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  after_updated_at?: string;
+  /**
+   * 创建时间之前的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  before_created_at?: string;
+  /**
+   * 更新时间之前的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  before_updated_at?: string;
+  /** 导出的列(多个用,隔开), 默认导出全部列 */
+  columns?: string;
+  /** 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据 */
+  export?: "csv" | "xlsx";
+  /**
+   * 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+   * 运算符: eq ne gt gte lt lte contains in between is_null not_null
+   * example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+   */
+  filter?: string;
+  /** 主机ID */
+  host_id?: number;
+  /** 唯一标识 */
+  id?: number;
+  /** "唯一标识列表(多个用,隔开)" */
+  ids?: string;
+  /** 是否启用 */
+  is_enable?: boolean;
+  /** mds集群ID */
+  mds_colony_id?: number;
+  /** 节点角色 */
+  node_role?: string;
+  /** 分页页码 */
+  page?: number;
+  /** 分页大小 */
+  size?: number;
+  /** 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表 */
+  with_total?: boolean;
+}
+
+/** createMdsConf的表单 */
+export interface CreateMdsConfForm {
+  /** 配置文件 */
+  file: UploadFile;
+}
+
+/** listMonHostAgent的查询参数 */
+export interface ListMonHostAgentParams {
+  /** 导出的列(多个用,隔开), 默认导出全部列 */
+  columns?: string;
+  /** 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据 */
+  export?: "csv" | "xlsx";
+  /**
+   * 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+   * 运算符: eq ne gt gte lt lte contains in between is_null not_null
+   * example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+   */
+  filter?: string;
+  /** 主机ID */
+  host_id?: number;
+  /** 唯一标识 */
+  id?: number;
+  /** "唯一标识列表(多个用,隔开)" */
+  ids?: string;
+  /** 分页页码 */
+  page?: number;
+  /** 分页大小 */
+  size?: number;
+  /** 在线状态 */
+  status?: "online" | "offline";
+  /** 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表 */
+  with_total?: boolean;
+}
+
+/** listMonNode的查询参数 */
+export interface ListMonNodeParams {
+  /**
+   * 创建时间之后的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  after_created_at?: string;
+  /**
+   * 更新时间之后的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  after_updated_at?: string;
+  /**
+   * 创建时间之前的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  before_created_at?: string;
+  /**
+   * 更新时间之前的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  before_updated_at?: string;
+  /** 导出的列(多个用,隔开), 默认导出全部列 */
+  columns?: string;
+  /** 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据 */
+  export?: "csv" | "xlsx";
+  /**
+   * 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+   * 运算符: eq ne gt gte lt lte contains in between is_null not_null
+   * example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+   */
+  filter?: string;
+  /** 主机ID */
+  host_id?: number;
+  /** 唯一标识 */
+  id?: number;
+  /** "唯一标识列表(多个用,隔开)" */
+  ids?: string;
+  /** 名称 */
+  name?: string;
+  /** 分页页码 */
+  page?: number;
+  /** 分页大小 */
+  size?: number;
+  /** 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表 */
+  with_total?: boolean;
+}
+
+/** getMonNodeMetrics的查询参数 */
+export interface GetMonNodeMetricsParams {
+  /** 时间范围(1h、6h使用原始数据, 24h、7d使用5分钟平均值, 30d、90d使用1小时平均值), 默认1h */
+  range?: "1h" | "6h" | "24h" | "7d" | "30d" | "90d";
+}
+
+/** getMonNodeQuery的查询参数 */
+export interface GetMonNodeQueryParams {
+  /** 范围查询结束时间(RFC3339或Unix时间戳) */
+  end?: string;
+  /** PromQL查询语句 */
+  query: string;
+  /** 范围查询开始时间(RFC3339或Unix时间戳) */
+  start?: string;
+  /** 范围查询步长, 如 15s */
+  step?: string;
+  /** 即时查询时间(RFC3339或Unix时间戳) */
+  time?: string;
+}
+
+/** listOesColony的查询参数 */
+export interface ListOesColonyParams {
+  /**
+   * 创建时间之后的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  after_created_at?: string;
+  /**
+   * 更新时间之后的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  after_updated_at?: string;
+  /**
+   * 创建时间之前的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  before_created_at?: string;
+  /**
+   * 更新时间之前的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  before_updated_at?: string;
+  /** 集群号 */
+  colony_num?: string;
+  /** 导出的列(多个用,隔开), 默认导出全部列 */
+  columns?: string;
+  /** 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据 */
+  export?: "csv" | "xlsx";
+  /** 解压后名称 */
+  extracted_name?: string;
+  /**
+   * 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+   * 运算符: eq ne gt gte lt lte contains in between is_null not_null
+   * example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+   */
+  filter?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** "唯一标识列表(多个用,隔开)" */
+  ids?: string;
+  /** 是否启用 */
+  is_enable?: boolean;
+  /** mon节点ID */
+  mon_node_id?: number;
+  /** 程序包ID */
+  package_id?: number;
+  /** 分页页码 */
+  page?: number;
+  /** 分页大小 */
+  size?: number;
+  /** 系统类型 */
+  system_type?: string;
+  /** 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表 */
+  with_total?: boolean;
+  /** xcounter包ID */
+  xcounter_id?: number;
+}
+
+/** listOesColonyStatusCrd的查询参数 */
+export interface ListOesColonyStatusCrdParams {
+  /**
+   * 创建时间之后的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  after_created_at?: string;
+  /**
+   * 更新时间之后的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  after_updated_at?: string;
+  /**
+   * 创建时间之前的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  before_created_at?: string;
+  /**
+   * 更新时间之前的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  before_updated_at?: string;
+  /** 集群号 */
+  colony_num?: string;
+  /** 导出的列(多个用,隔开), 默认导出全部列 */
+  columns?: string;
+  /** 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据 */
+  export?: "csv" | "xlsx";
+  /** 解压后名称 */
+  extracted_name?: string;
+  /**
+   * 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+   * 运算符: eq ne gt gte lt lte contains in between is_null not_null
+   * example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+   */
+  filter?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** "唯一标识列表(多个用,隔开)" */
+  ids?: string;
+  /** 是否启用 */
+  is_enable?: boolean;
+  /** mon节点ID */
+  mon_node_id?: number;
+  /** 程序包ID */
+  package_id?: number;
+  /** 分页页码 */
+  page?: number;
+  /** 分页大小 */
+  size?: number;
+  /** 系统类型 */
+  system_type?: string;
+  /** 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表 */
+  with_total?: boolean;
+  /** xcounter包ID */
+  xcounter_id?: number;
+}
+
+/** listOesColonyStatusOpt的查询参数 */
+export interface ListOesColonyStatusOptParams {
+  /**
+   * 创建时间之后的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  after_created_at?: string;
+  /**
+   * 更新时间之后的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  after_updated_at?: string;
+  /**
+   * 创建时间之前的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  before_created_at?: string;
+  /**
+   * 更新时间之前的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  before_updated_at?: string;
+  /** 集群号 */
+  colony_num?: string;
+  /** 导出的列(多个用,隔开), 默认导出全部列 */
+  columns?: string;
+  /** 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据 */
+  export?: "csv" | "xlsx";
+  /** 解压后名称 */
+  extracted_name?: string;
+  /**
+   * 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+   * 运算符: eq ne gt gte lt lte contains in between is_null not_null
+   * example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+   */
+  filter?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** "唯一标识列表(多个用,隔开)" */
+  ids?: string;
+  /** 是否启用 */
+  is_enable?: boolean;
+  /** mon节点ID */
+  mon_node_id?: number;
+  /** 程序包ID */
+  package_id?: number;
+  /** 分页页码 */
+  page?: number;
+  /** 分页大小 */
+  size?: number;
+  /** 系统类型 */
+  system_type?: string;
+  /** 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表 */
+  with_total?: boolean;
+  /** xcounter包ID */
+  xcounter_id?: number;
+}
+
+/** listOesColonyStatusStk的查询参数 */
+export interface ListOesColonyStatusStkParams {
+  /**
+   * 创建时间之后的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  after_created_at?: string;
+  /**
+   * 更新时间之后的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  after_updated_at?: string;
+  /**
+   * 创建时间之前的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  before_created_at?: string;
+  /**
+   * 更新时间之前的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  before_updated_at?: string;
+  /** 集群号 */
+  colony_num?: string;
+  /** 导出的列(多个用,隔开), 默认导出全部列 */
+  columns?: string;
+  /** 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据 */
+  export?: "csv" | "xlsx";
+  /** 解压后名称 */
+  extracted_name?: string;
+  /**
+   * 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+   * 运算符: eq ne gt gte lt lte contains in between is_null not_null
+   * example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+   */
+  filter?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** "唯一标识列表(多个用,隔开)" */
+  ids?: string;
+  /** 是否启用 */
+  is_enable?: boolean;
+  /** mon节点ID */
+  mon_node_id?: number;
+  /** 程序包ID */
+  package_id?: number;
+  /** 分页页码 */
+  page?: number;
+  /** 分页大小 */
+  size?: number;
+  /** 系统类型 */
+  system_type?: string;
+  /** 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表 */
+  with_total?: boolean;
+  /** xcounter包ID */
+  xcounter_id?: number;
+}
+
+/** listOesColonyReconciliation的查询参数 */
+export interface ListOesColonyReconciliationParams {
+  /** 导出的列(多个用,隔开), 默认导出全部列 */
+  columns?: string;
+  /** 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据 */
+  export?: "csv" | "xlsx";
+  /**
+   * 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+   * 运算符: eq ne gt gte lt lte contains in between is_null not_null
+   * example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+   */
+  filter?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** "唯一标识列表(多个用,隔开)" */
+  ids?: string;
+  /** 分页页码 */
+  page?: number;
+  /** 分页大小 */
+  size?: number;
+  /** 对账状态 */
+  status?: "matched" | "mismatched" | "failed";
+  /** 交易日, 格式为2006-01-02 */
+  trading_day?: string;
+  /** 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表 */
+  with_total?: boolean;
+}
+
+/** listOesConfTemplate的查询参数 */
+export interface ListOesConfTemplateParams {
+  /** 页码 */
+  page?: number;
+  /** 每页数量 */
+  size?: number;
+  /** 名称 */
+  name?: string;
+  /** 适用节点目录 */
+  dir_name?: string;
+}
+
+/** listOesLink的查询参数 */
+export interface ListOesLinkParams {
+  /**
+   * 创建时间之后的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  after_created_at?: string;
+  /**
+   * 更新时间之后的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  after_updated_at?: string;
+  /**
+   * 创建时间之前的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  before_created_at?: string;
+  /**
+   * 更新时间之前的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  before_updated_at?: string;
+  /** 导出的列(多个用,隔开), 默认导出全部列 */
+  columns?: string;
+  /** 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据 */
+  export?: "csv" | "xlsx";
+  /**
+   * 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+   * 运算符: eq ne gt gte lt lte contains in between is_null not_null
+   * example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+   */
+  filter?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** "唯一标识列表(多个用,隔开)" */
+  ids?: string;
+  /** 是否启用 */
+  is_enabled?: boolean;
+  /** 名称 */
+  name?: string;
+  /** oes集群ID */
+  oes_colony_id?: number;
+  /** 分页页码 */
+  page?: number;
+  /** 分页大小 */
+  size?: number;
+  /** 源主机ID, 0表示平台 */
+  source_host_id?: number;
+  /** 探测状态 */
+  status?: "unknown" | "reachable" | "unreachable";
+  /** 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表 */
+  with_total?: boolean;
+}
+
+/** getOesLinkMatrix的查询参数 */
+export interface GetOesLinkMatrixParams {
+  /** oes集群ID, 为空时返回全部链路 */
+  oes_colony_id?: number;
+}
+
+/** listOesLinkProbe的查询参数 */
+export interface ListOesLinkProbeParams {
+  /** 导出的列(多个用,隔开), 默认导出全部列 */
+  columns?: string;
+  /** 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据 */
+  export?: "csv" | "xlsx";
+  /**
+   * 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+   * 运算符: eq ne gt gte lt lte contains in between is_null not_null
+   * example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+   */
+  filter?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** "唯一标识列表(多个用,隔开)" */
+  ids?: string;
+  /** 分页页码 */
+  page?: number;
+  /** 分页大小 */
+  size?: number;
+  /** 探测状态 */
+  status?: "unknown" | "reachable" | "unreachable";
+  /** 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表 */
+  with_total?: boolean;
+}
+
+/** listOesNode的查询参数 */
+export interface ListOesNodeParams {
+  /**
+   * 创建时间之后的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  after_created_at?: string;
+  /**
+   * 更新时间之后的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  after_updated_at?: string;
+  /**
+   * 创建时间之前的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  before_created_at?: string;
+  /**
+   * 更新时间之前的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  before_updated_at?: string;
+  /** 导出的列(多个用,隔开), 默认导出全部列 */
+  columns?: string;
+  /** 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据 */
+  export?: "csv" | "xlsx";
+  /**
+   * 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+   * 运算符: eq ne gt gte lt lte contains in between is_null not_null
+   * example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+   */
+  filter?: string;
+  /**
+   * 主机ID
+   * required: false
+   * example: 1
+   */
+  host_id?: number;
+  /** 唯一标识 */
+  id?: number;
+  /** "唯一标识列表(多个用,隔开)" */
+  ids?: string;
+  /**
+   * 是否启用
+   * required: false
+   * example: true
+   */
+  is_enable?: boolean;
+  /**
+   * 节点角色
+   * example: "master"
+   */
+  node_role?: string;
+  /**
+   * oes集群ID
+   * required: false
+   * example: 1
+   */
+  oes_colony_id?: number;
+  /** 分页页码 */
+  page?: number;
+  /** 分页大小 */
+  size?: number;
+  /** 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表 */
+  with_total?: boolean;
+}
+
+/** listOesRunbook的查询参数 */
+export interface ListOesRunbookParams {
+  /** 导出的列(多个用,隔开), 默认导出全部列 */
+  columns?: string;
+  /** 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据 */
+  export?: "csv" | "xlsx";
+  /**
+   * 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+   * 运算符: eq ne gt gte lt lte contains in between is_null not_null
+   * example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+   */
+  filter?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** "唯一标识列表(多个用,隔开)" */
+  ids?: string;
+  /** 是否启用 */
+  is_enabled?: boolean;
+  /** 名称 */
+  name?: string;
+  /** 分页页码 */
+  page?: number;
+  /** 分页大小 */
+  size?: number;
+  /** 适用的系统类型 */
+  system_type?: "STK" | "CRD" | "OPT";
+  /** 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表 */
+  with_total?: boolean;
+}
+
+/** listOesRunbookExec的查询参数 */
+export interface ListOesRunbookExecParams {
+  /** 导出的列(多个用,隔开), 默认导出全部列 */
+  columns?: string;
+  /** 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据 */
+  export?: "csv" | "xlsx";
+  /**
+   * 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+   * 运算符: eq ne gt gte lt lte contains in between is_null not_null
+   * example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+   */
+  filter?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** "唯一标识列表(多个用,隔开)" */
+  ids?: string;
+  /** oes集群ID */
+  oes_colony_id?: number;
+  /** 分页页码 */
+  page?: number;
+  /** 检查单ID */
+  runbook_id?: number;
+  /** 分页大小 */
+  size?: number;
+  /** 执行状态 */
+  status?: "running" | "blocked" | "success" | "aborted";
+  /** 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表 */
+  with_total?: boolean;
+}
+
+/** listOesSla的查询参数 */
+export interface ListOesSlaParams {
+  /** 导出的列(多个用,隔开), 默认导出全部列 */
+  columns?: string;
+  /** 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据 */
+  export?: "csv" | "xlsx";
+  /**
+   * 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+   * 运算符: eq ne gt gte lt lte contains in between is_null not_null
+   * example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+   */
+  filter?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** "唯一标识列表(多个用,隔开)" */
+  ids?: string;
+  /** 是否启用 */
+  is_enabled?: boolean;
+  /** oes集群ID, 0表示全部集群 */
+  oes_colony_id?: number;
+  /** 分页页码 */
+  page?: number;
+  /** 分页大小 */
+  size?: number;
+  /** 任务名称 */
+  task_name?: string;
+  /** 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表 */
+  with_total?: boolean;
+}
+
+/** getOesSlaCompliance的查询参数 */
+export interface GetOesSlaComplianceParams {
+  /** oes集群ID */
+  oes_colony_id?: number;
+  /** 交易日, 格式为2006-01-02, 默认为当天 */
+  trading_day?: string;
+}
+
+/** listOesTaskCatalog的查询参数 */
+export interface ListOesTaskCatalogParams {
+  /** 导出的列(多个用,隔开), 默认导出全部列 */
+  columns?: string;
+  /** 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据 */
+  export?: "csv" | "xlsx";
+  /**
+   * 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+   * 运算符: eq ne gt gte lt lte contains in between is_null not_null
+   * example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+   */
+  filter?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** "唯一标识列表(多个用,隔开)" */
+  ids?: string;
+  /** 是否启用 */
+  is_enabled?: boolean;
+  /** 分页页码 */
+  page?: number;
+  /** 分页大小 */
+  size?: number;
+  /** 系统类型 */
+  system_type?: string;
+  /** 任务名称 */
+  task_name?: string;
+  /** 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表 */
+  with_total?: boolean;
+}
+
+/** listOesWorkflowRun的查询参数 */
+export interface ListOesWorkflowRunParams {
+  /** 集群号 */
+  colony_num?: string;
+  /** 导出的列(多个用,隔开), 默认导出全部列 */
+  columns?: string;
+  /** 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据 */
+  export?: "csv" | "xlsx";
+  /**
+   * 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+   * 运算符: eq ne gt gte lt lte contains in between is_null not_null
+   * example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+   */
+  filter?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** "唯一标识列表(多个用,隔开)" */
+  ids?: string;
+  /** oes集群ID */
+  oes_colony_id?: number;
+  /** 分页页码 */
+  page?: number;
+  /** 分页大小 */
+  size?: number;
+  /** 执行状态 */
+  status?: "pending" | "running" | "success" | "failed";
+  /** 系统类型 */
+  system_type?: "STK" | "CRD" | "OPT";
+  /** 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表 */
+  with_total?: boolean;
+}
+
+/** createOesConf的表单 */
+export interface CreateOesConfForm {
+  /** 配置文件 */
+  file: UploadFile;
+}
+
+/** listResourceFileRule的查询参数 */
+export interface ListResourceFileRuleParams {
+  /** 页码 */
+  page?: number;
+  /** 每页数量 */
+  size?: number;
+  /** 角色ID */
+  role_id?: number;
+}
+
+/** listResourceHost的查询参数 */
+export interface ListResourceHostParams {
+  /**
+   * 创建时间之后的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  after_created_at?: string;
+  /**
+   * 更新时间之后的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  after_updated_at?: string;
+  /**
+   * 创建时间之前的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  before_created_at?: string;
+  /**
+   * 更新时间之前的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  before_updated_at?: string;
+  /** 导出的列(多个用,隔开), 默认导出全部列 */
+  columns?: string;
+  /** 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据 */
+  export?: "csv" | "xlsx";
+  /**
+   * 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+   * 运算符: eq ne gt gte lt lte contains in between is_null not_null
+   * example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+   */
+  filter?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** "唯一标识列表(多个用,隔开)" */
+  ids?: string;
+  /** 标签 */
+  label?: string;
+  /** 名称 */
+  name?: string;
+  /** 分页页码 */
+  page?: number;
+  /** python路径 */
+  py_path?: string;
+  /** 备注 */
+  remark?: string;
+  /** 分页大小 */
+  size?: number;
+  /** ip地址 */
+  ssh_ip?: string;
+  /** 端口 */
+  ssh_port?: number;
+  /** 用户名 */
+  ssh_user?: string;
+  /** 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表 */
+  with_total?: boolean;
+}
+
+/** listResourceHostFile的查询参数 */
+export interface ListResourceHostFileParams {
+  /** 目录的绝对路径 */
+  path: string;
+}
+
+/** getResourceHostFileDownload的查询参数 */
+export interface GetResourceHostFileDownloadParams {
+  /** 文件的绝对路径 */
+  path: string;
+}
+
+/** getResourceHostFileStat的查询参数 */
+export interface GetResourceHostFileStatParams {
+  /** 文件或目录的绝对路径 */
+  path: string;
+}
+
+/** createResourceHostFileUpload的表单 */
+export interface CreateResourceHostFileUploadForm {
+  /** 目标目录的绝对路径 */
+  path: string;
+  /** 目标文件已存在时是否覆盖 */
+  overwrite?: boolean;
+  /** 上传的文件 */
+  file: UploadFile;
+}
+
+/** getResourceHostTerminal的查询参数 */
+export interface GetResourceHostTerminalParams {
+  /** 终端列数 */
+  cols?: number;
+  /** 终端行数 */
+  rows?: number;
+}
+
+/** listResourcePackage的查询参数 */
+export interface ListResourcePackageParams {
+  /**
+   * 上传时间之后的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  after_uploaded_at?: string;
+  /**
+   * 上传时间之前的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  before_uploaded_at?: string;
+  /** 导出的列(多个用,隔开), 默认导出全部列 */
+  columns?: string;
+  /** 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据 */
+  export?: "csv" | "xlsx";
+  /** 文件名 */
+  filename?: string;
+  /**
+   * 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+   * 运算符: eq ne gt gte lt lte contains in between is_null not_null
+   * example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+   */
+  filter?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** "唯一标识列表(多个用,隔开)" */
+  ids?: string;
+  /** 标签 */
+  label?: string;
+  /** 标签组(多个用,隔开) */
+  labels?: string;
+  /** 分页页码 */
+  page?: number;
+  /** 分页大小 */
+  size?: number;
+  /** 版本号 */
+  version?: string;
+  /** 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表 */
+  with_total?: boolean;
+}
+
+/** createResourcePackage的表单 */
+export interface CreateResourcePackageForm {
+  /** 程序包标签，长度限制：1-50个字符 */
+  label: string;
+  /** 程序包版本，长度限制：1-50个字符 */
+  version: string;
+  /** 程序包文件 */
+  file: UploadFile;
+  /** 文件SHA256校验和，不为空时校验上传的文件 */
+  checksum?: string;
+}
+
+/** updateResourcePackageUpload的查询参数 */
+export interface UpdateResourcePackageUploadParams {
+  /** 分片在文件中的起始位置 */
+  offset: number;
+  /** 分片SHA256校验和 */
+  checksum?: string;
+}
+
+/** listResourceTerminal的查询参数 */
+export interface ListResourceTerminalParams {
+  /** 页码 */
+  page?: number;
+  /** 每页数量 */
+  size?: number;
+  /** 主机ID */
+  host_id?: number;
+  /** 操作用户 */
+  username?: string;
+}
+
+/** listResourceWatchdog的查询参数 */
+export interface ListResourceWatchdogParams {
+  /**
+   * 创建时间之后的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  after_created_at?: string;
+  /**
+   * 更新时间之后的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  after_updated_at?: string;
+  /**
+   * 创建时间之前的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  before_created_at?: string;
+  /**
+   * 更新时间之前的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  before_updated_at?: string;
+  /** 导出的列(多个用,隔开), 默认导出全部列 */
+  columns?: string;
+  /** 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据 */
+  export?: "csv" | "xlsx";
+  /**
+   * 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+   * 运算符: eq ne gt gte lt lte contains in between is_null not_null
+   * example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+   */
+  filter?: string;
+  /** 主机ID */
+  host_id?: number;
+  /** 唯一标识 */
+  id?: number;
+  /** "唯一标识列表(多个用,隔开)" */
+  ids?: string;
+  /** 是否启用 */
+  is_enabled?: boolean;
+  /** 名称 */
+  name?: string;
+  /** 分页页码 */
+  page?: number;
+  /** 分页大小 */
+  size?: number;
+  /** 检查状态 */
+  status?: "unknown" | "ok" | "failed";
+  /** 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表 */
+  with_total?: boolean;
+}
+
+/** listResourceWatchdogEvent的查询参数 */
+export interface ListResourceWatchdogEventParams {
+  /** 动作 */
+  action?: "state_change" | "restart" | "restart_failed" | "restart_limited";
+  /** 导出的列(多个用,隔开), 默认导出全部列 */
+  columns?: string;
+  /** 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据 */
+  export?: "csv" | "xlsx";
+  /**
+   * 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+   * 运算符: eq ne gt gte lt lte contains in between is_null not_null
+   * example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+   */
+  filter?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** "唯一标识列表(多个用,隔开)" */
+  ids?: string;
+  /** 分页页码 */
+  page?: number;
+  /** 分页大小 */
+  size?: number;
+  /** 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表 */
+  with_total?: boolean;
+}
+
+/** getSearch的查询参数 */
+export interface GetSearchParams {
+  /** 每组最多返回的结果数, 默认5 */
+  limit?: number;
+  /** 关键字, 匹配名称和描述 */
+  q: string;
+}
+
+/** getStatsAlerts的查询参数 */
+export interface GetStatsAlertsParams {
+  /** 返回的告警数, 默认10条 */
+  limit?: number;
+}
+
+/** getStatsJobs的查询参数 */
+export interface GetStatsJobsParams {
+  /** 统计最近多少天(含今天), 默认7天 */
+  days?: number;
+}
+
+/** getSystemAnalyticsUsage的查询参数 */
+export interface GetSystemAnalyticsUsageParams {
+  /**
+   * 结束时间 (RFC3339格式)
+   * example: 2023-01-02T00:00:00Z
+   */
+  end_at?: string;
+  /** 聚合维度(feature:按功能, module:按模块, role:按角色, feature_role:按功能和角色) */
+  group_by?: "feature" | "module" | "role" | "feature_role";
+  /** 返回条数上限 */
+  limit?: number;
+  /** 所属模块 */
+  module?: string;
+  /** 角色ID */
+  role_id?: number;
+  /**
+   * 开始时间 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  start_at?: string;
+}
+
+/** listSystemAudit的查询参数 */
+export interface ListSystemAuditParams {
+  /** 操作类型 */
+  action?: string;
+  /** 导出的列(多个用,隔开), 默认导出全部列 */
+  columns?: string;
+  /**
+   * 结束时间 (RFC3339格式)
+   * example: 2023-01-02T00:00:00Z
+   */
+  end_at?: string;
+  /** 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据 */
+  export?: "csv" | "xlsx";
+  /**
+   * 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+   * 运算符: eq ne gt gte lt lte contains in between is_null not_null
+   * example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+   */
+  filter?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** "唯一标识列表(多个用,隔开)" */
+  ids?: string;
+  /** 所属模块 */
+  module?: string;
+  /** 分页页码 */
+  page?: number;
+  /** 资源类型 */
+  resource?: string;
+  /** 资源ID */
+  resource_id?: number;
+  /** 分页大小 */
+  size?: number;
+  /**
+   * 开始时间 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  start_at?: string;
+  /** 操作用户 */
+  username?: string;
+  /** 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表 */
+  with_total?: boolean;
+}
+
+/** listSystemCert的查询参数 */
+export interface ListSystemCertParams {
+  /**
+   * 创建时间之后的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  after_created_at?: string;
+  /**
+   * 更新时间之后的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  after_updated_at?: string;
+  /**
+   * 创建时间之前的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  before_created_at?: string;
+  /**
+   * 更新时间之前的记录 (RFC3339格式)
+   * example: 2023-01-01T00:00:00Z
+   */
+  before_updated_at?: string;
+  /** 导出的列(多个用,隔开), 默认导出全部列 */
+  columns?: string;
+  /** 剩余天数不超过该值, 按过期时间筛选 */
+  expire_within_days?: number;
+  /** 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据 */
+  export?: "csv" | "xlsx";
+  /**
+   * 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+   * 运算符: eq ne gt gte lt lte contains in between is_null not_null
+   * example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+   */
+  filter?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** "唯一标识列表(多个用,隔开)" */
+  ids?: string;
+  /** 是否启用 */
+  is_enabled?: boolean;
+  /** 证书来源 */
+  kind?: "endpoint" | "file";
+  /** 名称 */
+  name?: string;
+  /** 分页页码 */
+  page?: number;
+  /** 分页大小 */
+  size?: number;
+  /** 检查状态 */
+  status?: "unknown" | "ok" | "expiring" | "expired" | "invalid" | "error";
+  /** 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表 */
+  with_total?: boolean;
+}
+
+/** listSystemFreeze的查询参数 */
+export interface ListSystemFreezeParams {
+  /** 导出的列(多个用,隔开), 默认导出全部列 */
+  columns?: string;
+  /** 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据 */
+  export?: "csv" | "xlsx";
+  /**
+   * 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+   * 运算符: eq ne gt gte lt lte contains in between is_null not_null
+   * example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+   */
+  filter?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** "唯一标识列表(多个用,隔开)" */
+  ids?: string;
+  /** 是否启用 */
+  is_enabled?: boolean;
+  /** 冻结的模块 */
+  module?: "oes" | "mds" | "mon" | "jobs" | "resource" | "customer";
+  /** 名称 */
+  name?: string;
+  /** 分页页码 */
+  page?: number;
+  /** 作用范围 */
+  scope?: "global" | "module";
+  /** 分页大小 */
+  size?: number;
+  /** 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表 */
+  with_total?: boolean;
+}
+
+/** listSystemMaintenance的查询参数 */
+export interface ListSystemMaintenanceParams {
+  /** 导出的列(多个用,隔开), 默认导出全部列 */
+  columns?: string;
+  /** 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据 */
+  export?: "csv" | "xlsx";
+  /**
+   * 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+   * 运算符: eq ne gt gte lt lte contains in between is_null not_null
+   * example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+   */
+  filter?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** "唯一标识列表(多个用,隔开)" */
+  ids?: string;
+  /** 是否启用 */
+  is_enabled?: boolean;
+  /** 所属模块 */
+  module?: "oes" | "mds" | "mon";
+  /** 名称 */
+  name?: string;
+  /** 分页页码 */
+  page?: number;
+  /** 作用范围 */
+  scope?: "global" | "colony" | "node";
+  /** 分页大小 */
+  size?: number;
+  /** 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表 */
+  with_total?: boolean;
+}
+
+/** getSystemReport的查询参数 */
+export interface GetSystemReportParams {
+  /** 统计日期, 日报为当天, 周报为所在的周, 为空时日报统计昨天, 周报统计上周 */
+  date?: string;
+  /** 报表周期(daily:日报, weekly:周报) */
+  period: "daily" | "weekly";
+}
+
+/** getSystemReportExport的查询参数 */
+export interface GetSystemReportExportParams {
+  /** 统计日期, 日报为当天, 周报为所在的周, 为空时日报统计昨天, 周报统计上周 */
+  date?: string;
+  /** 导出格式(csv, xlsx) */
+  format: "csv" | "xlsx";
+  /** 报表周期(daily:日报, weekly:周报) */
+  period: "daily" | "weekly";
+}
+
+/** listSystemWebhook的查询参数 */
+export interface ListSystemWebhookParams {
+  /** 导出的列(多个用,隔开), 默认导出全部列 */
+  columns?: string;
+  /** 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据 */
+  export?: "csv" | "xlsx";
+  /**
+   * 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+   * 运算符: eq ne gt gte lt lte contains in between is_null not_null
+   * example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+   */
+  filter?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** "唯一标识列表(多个用,隔开)" */
+  ids?: string;
+  /** 是否启用 */
+  is_enabled?: boolean;
+  /** 名称 */
+  name?: string;
+  /** 分页页码 */
+  page?: number;
+  /** 分页大小 */
+  size?: number;
+  /** 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表 */
+  with_total?: boolean;
+}
+
+/** listSystemWebhookDelivery的查询参数 */
+export interface ListSystemWebhookDeliveryParams {
+  /** 导出的列(多个用,隔开), 默认导出全部列 */
+  columns?: string;
+  /** 事件类型 */
+  event_type?: string;
+  /** 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据 */
+  export?: "csv" | "xlsx";
+  /**
+   * 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+   * 运算符: eq ne gt gte lt lte contains in between is_null not_null
+   * example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+   */
+  filter?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** "唯一标识列表(多个用,隔开)" */
+  ids?: string;
+  /** 分页页码 */
+  page?: number;
+  /** 分页大小 */
+  size?: number;
+  /** 推送状态(0-待推送,1-成功,2-失败) */
+  status?: number;
+  /** 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表 */
+  with_total?: boolean;
+}
+
+/** listTasks的查询参数 */
+export interface ListTasksParams {
+  /** 导出的列(多个用,隔开), 默认导出全部列 */
+  columns?: string;
+  /** 导出格式, 支持导出的列表接口指定时返回文件而不是分页数据 */
+  export?: "csv" | "xlsx";
+  /**
+   * 过滤条件, 格式为"字段:运算符:值", 同组条件用;隔开(AND), 多组用|隔开(OR)
+   * 运算符: eq ne gt gte lt lte contains in between is_null not_null
+   * example: status:in:0,1;created_at:gte:2024-01-01|name:contains:日终
+   */
+  filter?: string;
+  /** 唯一标识 */
+  id?: number;
+  /** "唯一标识列表(多个用,隔开)" */
+  ids?: string;
+  /** 任务类型 */
+  kind?: string;
+  /** 分页页码 */
+  page?: number;
+  /** 分页大小 */
+  size?: number;
+  /** 状态 */
+  status?: "pending" | "running" | "succeeded" | "failed" | "canceled";
+  /** 提交用户名 */
+  username?: string;
+  /** 是否查询总数, 为false时不返回总数和总页数, 只返回是否有下一页, 用于数据量大的列表 */
+  with_total?: boolean;
+}
+
+/** 上传的文件, name为空时使用Blob自身的文件名 */
+export interface UploadFile {
+  name?: string;
+  content: Blob;
+}
+
+/** 客户端选项 */
+export interface ClientOptions {
+  /** 服务地址, 如https://artweb.example.com, 为空时使用当前页面的地址 */
+  baseURL?: string;
+  /** 访问令牌, 作为Authorization请求头发送 */
+  token?: string | (() => string | undefined);
+  /** 附加的请求头 */
+  headers?: Record<string, string>;
+  /** 自定义fetch实现 */
+  fetch?: typeof fetch;
+}
+
+/** 接口返回的错误 */
+export class ApiError extends Error {
+  readonly status: number;
+  readonly reason?: string;
+  readonly data?: Record<string, unknown>;
+
+  constructor(status: number, body?: { reason?: string; msg?: string; data?: Record<string, unknown> }) {
+    super(body?.msg || `HTTP ${status}`);
+    this.name = "ApiError";
+    this.status = status;
+    this.reason = body?.reason;
+    this.data = body?.data;
+  }
+}
+
+type QueryValue = string | number | boolean | Array<string | number | boolean> | null | undefined;
+
+interface RequestOptions {
+  query?: Record<string, QueryValue>;
+  multi?: string[];
+  body?: unknown;
+  form?: Record<string, unknown>;
+  raw?: boolean;
+}
+
+export class Client {
+  private readonly baseURL: string;
+  private readonly options: ClientOptions;
+  private readonly fetchFn: typeof fetch;
+
+  constructor(options: ClientOptions = {}) {
+    this.baseURL = (options.baseURL ?? "").replace(/\/+$/, "");
+    this.options = options;
+    this.fetchFn = options.fetch ?? fetch.bind(globalThis);
+  }
+
+  private async request<T>(method: string, path: string, opts: RequestOptions = {}): Promise<T> {
+    const search = new URLSearchParams();
+    for (const [key, value] of Object.entries(opts.query ?? {})) {
+      if (value === undefined || value === null) {
+        continue;
+      }
+      if (Array.isArray(value)) {
+        if (opts.multi?.includes(key)) {
+          value.forEach((v) => search.append(key, String(v)));
+        } else if (value.length > 0) {
+          search.set(key, value.join(","));
+        }
+        continue;
+      }
+      search.set(key, String(value));
+    }
+    const qs = search.toString();
+
+    const headers: Record<string, string> = { Accept: "application/json", ...this.options.headers };
+    const token = typeof this.options.token === "function" ? this.options.token() : this.options.token;
+    if (token) {
+      headers["Authorization"] = token;
+    }
+    let body: BodyInit | undefined;
+    if (opts.form) {
+      const fd = new FormData();
+      for (const [key, value] of Object.entries(opts.form)) {
+        if (value === undefined || value === null) {
+          continue;
+        }
+        if (typeof value === "object" && "content" in (value as UploadFile)) {
+          const file = value as UploadFile;
+          fd.append(key, file.content, file.name ?? (file.content instanceof File ? file.content.name : key));
+        } else {
+          fd.append(key, String(value));
+        }
+      }
+      body = fd;
+    } else if (opts.body !== undefined) {
+      headers["Content-Type"] = "application/json";
+      body = JSON.stringify(opts.body);
+    }
+
+    const resp = await this.fetchFn(this.baseURL + path + (qs ? "?" + qs : ""), { method, headers, body });
+    if (!resp.ok) {
+      let errBody: { reason?: string; msg?: string; data?: Record<string, unknown> } | undefined;
+      try {
+        errBody = await resp.json();
+      } catch {
+        errBody = undefined;
+      }
+      throw new ApiError(resp.status, errBody);
+    }
+    if (opts.raw) {
+      return resp as unknown as T;
+    }
+    const text = await resp.text();
+    return (text ? JSON.parse(text) : undefined) as T;
+  }
+
+  /**
+   * 查询备份列表
+   *
+   * 本接口用于查询备份列表, 按备份时间倒序
+   *
+   * GET /api/v1/admin/backup
+   */
+  listAdminBackup(params?: ListAdminBackupParams): Promise<SystemPagBackupReply> {
+    return this.request<SystemPagBackupReply>("GET", `/api/v1/admin/backup`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 立即备份
+   *
+   * 本接口用于提交备份数据的后台任务, 备份数据库全部数据和上传文件, 通过/api/v1/tasks/{id}查询进度, 任务成功后结果中的backup_id为备份ID
+   *
+   * POST /api/v1/admin/backup
+   */
+  createAdminBackup(): Promise<SystemTaskReply> {
+    return this.request<SystemTaskReply>("POST", `/api/v1/admin/backup`);
+  }
+
+  /**
+   * 查询备份详情
+   *
+   * 本接口用于查询指定ID的备份
+   *
+   * GET /api/v1/admin/backup/{id}
+   */
+  getAdminBackup(id: number): Promise<SystemBackupReply> {
+    return this.request<SystemBackupReply>("GET", `/api/v1/admin/backup/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 删除备份
+   *
+   * 本接口用于删除指定ID的备份文件和备份记录, 不能删除正在执行的备份
+   *
+   * DELETE /api/v1/admin/backup/{id}
+   */
+  deleteAdminBackup(id: number): Promise<CommonMapAPIReply> {
+    return this.request<CommonMapAPIReply>("DELETE", `/api/v1/admin/backup/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 下载备份文件
+   *
+   * 本接口用于下载指定ID的备份文件, 恢复方法见程序的 -restore 参数
+   *
+   * 返回原始响应, 由调用方读取响应体
+   *
+   * GET /api/v1/admin/backup/{id}/download
+   */
+  getAdminBackupDownload(id: number): Promise<Response> {
+    return this.request<Response>("GET", `/api/v1/admin/backup/${encodeURIComponent(String(id))}/download`, { raw: true });
+  }
+
+  /**
+   * 查询慢查询统计
+   *
+   * 本接口用于分页查询按语句指纹汇总的慢查询, 统计数据每分钟写入一次
+   *
+   * GET /api/v1/admin/db/slow-queries
+   */
+  listAdminDbSlowQueries(params?: ListAdminDbSlowQueriesParams): Promise<SystemPagSlowQueryReply> {
+    return this.request<SystemPagSlowQueryReply>("GET", `/api/v1/admin/db/slow-queries`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 查询功能开关列表
+   *
+   * 本接口用于分页查询功能开关
+   *
+   * GET /api/v1/admin/feature-flag
+   */
+  listAdminFeatureFlag(params?: ListAdminFeatureFlagParams): Promise<SystemPagFeatureFlagReply> {
+    return this.request<SystemPagFeatureFlagReply>("GET", `/api/v1/admin/feature-flag`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 创建功能开关
+   *
+   * 本接口用于创建功能开关, 开关名称不存在时功能对所有用户关闭
+   *
+   * POST /api/v1/admin/feature-flag
+   */
+  createAdminFeatureFlag(body: SystemFeatureFlagRequest): Promise<SystemFeatureFlagReply> {
+    return this.request<SystemFeatureFlagReply>("POST", `/api/v1/admin/feature-flag`, { body });
+  }
+
+  /**
+   * 查询功能开关详情
+   *
+   * 本接口用于查询指定ID的功能开关
+   *
+   * GET /api/v1/admin/feature-flag/{id}
+   */
+  getAdminFeatureFlag(id: number): Promise<SystemFeatureFlagReply> {
+    return this.request<SystemFeatureFlagReply>("GET", `/api/v1/admin/feature-flag/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 更新功能开关
+   *
+   * 本接口用于更新指定ID的功能开关, 修改后本实例立即生效, 其他实例在1分钟内生效
+   *
+   * PUT /api/v1/admin/feature-flag/{id}
+   */
+  updateAdminFeatureFlag(id: number, body: SystemFeatureFlagRequest): Promise<SystemFeatureFlagReply> {
+    return this.request<SystemFeatureFlagReply>("PUT", `/api/v1/admin/feature-flag/${encodeURIComponent(String(id))}`, { body });
+  }
+
+  /**
+   * 删除功能开关
+   *
+   * 本接口用于删除指定ID的功能开关, 删除后功能对所有用户关闭
+   *
+   * DELETE /api/v1/admin/feature-flag/{id}
+   */
+  deleteAdminFeatureFlag(id: number): Promise<CommonMapAPIReply> {
+    return this.request<CommonMapAPIReply>("DELETE", `/api/v1/admin/feature-flag/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 查询日志级别
+   *
+   * 本接口用于查询各日志记录器的当前级别和启动时的级别, 仅限工作人员访问
+   *
+   * GET /api/v1/admin/log-level
+   */
+  listAdminLogLevel(): Promise<SystemListLogLevelReply> {
+    return this.request<SystemListLogLevelReply>("GET", `/api/v1/admin/log-level`);
+  }
+
+  /**
+   * 调整日志级别
+   *
+   * 本接口用于在运行时调整日志级别, 立即生效, 只对当前实例生效且重启后恢复配置文件中的级别, 仅限工作人员访问
+   *
+   * PUT /api/v1/admin/log-level
+   */
+  updateAdminLogLevel(body: SystemSetLogLevelRequest): Promise<SystemListLogLevelReply> {
+    return this.request<SystemListLogLevelReply>("PUT", `/api/v1/admin/log-level`, { body });
+  }
+
+  /**
+   * 恢复日志级别
+   *
+   * 本接口用于将日志级别恢复为启动时的级别, 仅限工作人员访问
+   *
+   * DELETE /api/v1/admin/log-level
+   */
+  deleteAdminLogLevel(params?: DeleteAdminLogLevelParams): Promise<SystemListLogLevelReply> {
+    return this.request<SystemListLogLevelReply>("DELETE", `/api/v1/admin/log-level`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 查询运行日志
+   *
+   * 本接口用于按时间范围、日志级别、链路ID和关键字检索服务运行日志, 结果按时间倒序返回
+   *
+   * GET /api/v1/admin/logs
+   */
+  listAdminLogs(params?: ListAdminLogsParams): Promise<SystemListLogEntryReply> {
+    return this.request<SystemListLogEntryReply>("GET", `/api/v1/admin/logs`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 查询数据库迁移状态
+   *
+   * 本接口用于查询数据库结构版本以及各版本迁移的执行情况
+   *
+   * GET /api/v1/admin/migrations
+   */
+  getAdminMigrations(): Promise<SystemSchemaStatusReply> {
+    return this.request<SystemSchemaStatusReply>("GET", `/api/v1/admin/migrations`);
+  }
+
+  /**
+   * 主机代理心跳
+   *
+   * 本接口供主机代理定时调用, 超过失联时间未上报心跳的主机标记为离线, 主机不存在时代理需要重新注册; 可同时上报主机基础指标供mon模块绘制图表
+   *
+   * POST /api/v1/agent/heartbeat
+   */
+  createAgentHeartbeat(body: ResourceHostAgentHeartbeatRequest): Promise<CommonMapAPIReply> {
+    return this.request<CommonMapAPIReply>("POST", `/api/v1/agent/heartbeat`, { body });
+  }
+
+  /**
+   * 注册主机代理
+   *
+   * 本接口供主机代理启动时调用, 上报主机信息, 按SSH地址、端口和用户匹配已有主机, 不存在时自动创建主机
+   *
+   * POST /api/v1/agent/register
+   */
+  createAgentRegister(body: ResourceRegisterHostAgentRequest): Promise<ResourceHostAgentReply> {
+    return this.request<ResourceHostAgentReply>("POST", `/api/v1/agent/register`, { body });
+  }
+
+  /**
+   * 授权回调
+   *
+   * 本接口用于前端将身份提供方回调地址中的参数原样转交, 校验通过后返回令牌
+   *
+   * GET /api/v1/auth/oidc/callback
+   */
+  getAuthOidcCallback(params: GetAuthOidcCallbackParams): Promise<CustomerLoginReply> {
+    return this.request<CustomerLoginReply>("GET", `/api/v1/auth/oidc/callback`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 发起单点登录
+   *
+   * 本接口用于生成身份提供方的授权地址, 前端跳转到该地址完成登录
+   *
+   * GET /api/v1/auth/oidc/login
+   */
+  getAuthOidcLogin(params: GetAuthOidcLoginParams): Promise<CustomerOidcLoginReply> {
+    return this.request<CustomerOidcLoginReply>("GET", `/api/v1/auth/oidc/login`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 查询登录方式
+   *
+   * 本接口用于登录页查询可用的身份提供方以及是否允许本地用户名密码登录
+   *
+   * GET /api/v1/auth/oidc/providers
+   */
+  getAuthOidcProviders(): Promise<CustomerOidcProvidersReply> {
+    return this.request<CustomerOidcProvidersReply>("GET", `/api/v1/auth/oidc/providers`);
+  }
+
+  /**
+   * 获取登录验证码
+   *
+   * 本接口用于登录失败次数过多时获取验证码, 登录时提交captcha_id和captcha_answer
+   *
+   * GET /api/v1/captcha
+   */
+  getCaptcha(): Promise<CustomerCaptchaReply> {
+    return this.request<CustomerCaptchaReply>("GET", `/api/v1/captcha`);
+  }
+
+  /**
+   * 查询API列表
+   *
+   * 本接口用于查询API列表
+   *
+   * GET /api/v1/customer/api
+   */
+  listCustomerAPI(params?: ListCustomerAPIParams): Promise<CustomerPagApiReply> {
+    return this.request<CustomerPagApiReply>("GET", `/api/v1/customer/api`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 新增API
+   *
+   * 本接口用于新增API
+   *
+   * POST /api/v1/customer/api
+   */
+  createCustomerAPI(body: CustomerCreateApiRequest): Promise<CustomerApiReply> {
+    return this.request<CustomerApiReply>("POST", `/api/v1/customer/api`, { body });
+  }
+
+  /**
+   * 同步API目录
+   *
+   * 本接口用于按已注册的路由同步API目录, 新增缺失的API并标记路由中已不存在的API
+   *
+   * POST /api/v1/customer/api/sync
+   */
+  createCustomerAPISync(body: CustomerSyncApiRequest): Promise<CustomerApiSyncReply> {
+    return this.request<CustomerApiSyncReply>("POST", `/api/v1/customer/api/sync`, { body });
+  }
+
+  /**
+   * 查询API
+   *
+   * 本接口用于查询指定ID的API
+   *
+   * GET /api/v1/customer/api/{id}
+   */
+  getCustomerAPI(id: number): Promise<CustomerApiReply> {
+    return this.request<CustomerApiReply>("GET", `/api/v1/customer/api/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 更新API
+   *
+   * 本接口用于更新指定ID的API
+   *
+   * PUT /api/v1/customer/api/{id}
+   */
+  updateCustomerAPI(id: number, body: CustomerUpdateApiRequest): Promise<CustomerApiReply> {
+    return this.request<CustomerApiReply>("PUT", `/api/v1/customer/api/${encodeURIComponent(String(id))}`, { body });
+  }
+
+  /**
+   * 删除API
+   *
+   * 本接口用于删除指定ID的API
+   *
+   * DELETE /api/v1/customer/api/{id}
+   */
+  deleteCustomerAPI(id: number): Promise<CommonMapAPIReply> {
+    return this.request<CommonMapAPIReply>("DELETE", `/api/v1/customer/api/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 查询按钮列表
+   *
+   * 本接口用于查询按钮列表
+   *
+   * GET /api/v1/customer/button
+   */
+  listCustomerButton(params?: ListCustomerButtonParams): Promise<CustomerPagButtonReply> {
+    return this.request<CustomerPagButtonReply>("GET", `/api/v1/customer/button`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 新增按钮
+   *
+   * 本接口用于新增按钮
+   *
+   * POST /api/v1/customer/button
+   */
+  createCustomerButton(body: CustomerCreateButtonRequest): Promise<CustomerButtonReply> {
+    return this.request<CustomerButtonReply>("POST", `/api/v1/customer/button`, { body });
+  }
+
+  /**
+   * 查询按钮
+   *
+   * 本接口用于查询指定ID的按钮
+   *
+   * GET /api/v1/customer/button/{id}
+   */
+  getCustomerButton(id: number): Promise<CustomerButtonReply> {
+    return this.request<CustomerButtonReply>("GET", `/api/v1/customer/button/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 更新按钮
+   *
+   * 本接口用于更新指定ID的按钮
+   *
+   * PUT /api/v1/customer/button/{id}
+   */
+  updateCustomerButton(id: number, body: CustomerUpdateButtonRequest): Promise<CustomerButtonReply> {
+    return this.request<CustomerButtonReply>("PUT", `/api/v1/customer/button/${encodeURIComponent(String(id))}`, { body });
+  }
+
+  /**
+   * 删除按钮
+   *
+   * 本接口用于删除指定ID的按钮
+   *
+   * DELETE /api/v1/customer/button/{id}
+   */
+  deleteCustomerButton(id: number): Promise<CommonMapAPIReply> {
+    return this.request<CommonMapAPIReply>("DELETE", `/api/v1/customer/button/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 查询生效的Casbin模型
+   *
+   * 本接口用于查询当前生效的Casbin模型配置
+   *
+   * GET /api/v1/customer/casbin/model
+   */
+  getCustomerCasbinModel(): Promise<CustomerCasbinModelReply> {
+    return this.request<CustomerCasbinModelReply>("GET", `/api/v1/customer/casbin/model`);
+  }
+
+  /**
+   * 更新Casbin模型
+   *
+   * 本接口用于更新Casbin模型配置，校验全部通过后立即生效并保存为新版本
+   *
+   * PUT /api/v1/customer/casbin/model
+   */
+  updateCustomerCasbinModel(body: CustomerUpdateCasbinModelRequest): Promise<CustomerCasbinModelReply> {
+    return this.request<CustomerCasbinModelReply>("PUT", `/api/v1/customer/casbin/model`, { body });
+  }
+
+  /**
+   * 查询Casbin模型历史版本
+   *
+   * 本接口用于分页查询Casbin模型的历史版本
+   *
+   * GET /api/v1/customer/casbin/model/history
+   */
+  listCustomerCasbinModelHistory(params?: ListCustomerCasbinModelHistoryParams): Promise<CustomerPagCasbinModelReply> {
+    return this.request<CustomerPagCasbinModelReply>("GET", `/api/v1/customer/casbin/model/history`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 回滚Casbin模型
+   *
+   * 本接口用于将Casbin模型回滚到上一个版本
+   *
+   * POST /api/v1/customer/casbin/model/rollback
+   */
+  createCustomerCasbinModelRollback(): Promise<CustomerCasbinModelReply> {
+    return this.request<CustomerCasbinModelReply>("POST", `/api/v1/customer/casbin/model/rollback`);
+  }
+
+  /**
+   * 校验Casbin模型
+   *
+   * 本接口用于在应用前校验Casbin模型配置，使用当前策略对样例请求求值，不会修改生效的模型
+   *
+   * POST /api/v1/customer/casbin/model/validate
+   */
+  createCustomerCasbinModelValidate(body: CustomerValidateCasbinModelRequest): Promise<CustomerCasbinModelValidateReply> {
+    return this.request<CustomerCasbinModelValidateReply>("POST", `/api/v1/customer/casbin/model/validate`, { body });
+  }
+
+  /**
+   * 查询部门列表
+   *
+   * 本接口用于查询部门列表
+   *
+   * GET /api/v1/customer/department
+   */
+  listCustomerDepartment(params?: ListCustomerDepartmentParams): Promise<CustomerPagDepartmentReply> {
+    return this.request<CustomerPagDepartmentReply>("GET", `/api/v1/customer/department`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 新增部门
+   *
+   * 本接口用于新增部门, 未指定父部门时创建根部门
+   *
+   * POST /api/v1/customer/department
+   */
+  createCustomerDepartment(body: CustomerCreateOrUpdateDepartmentRequest): Promise<CustomerDepartmentReply> {
+    return this.request<CustomerDepartmentReply>("POST", `/api/v1/customer/department`, { body });
+  }
+
+  /**
+   * 查询组织架构树
+   *
+   * 本接口用于查询完整的部门树, 同级部门按排序字段排序
+   *
+   * GET /api/v1/customer/department/tree
+   */
+  getCustomerDepartmentTree(): Promise<CustomerDepartmentTreeReply> {
+    return this.request<CustomerDepartmentTreeReply>("GET", `/api/v1/customer/department/tree`);
+  }
+
+  /**
+   * 查询部门
+   *
+   * 本接口用于查询指定ID的部门
+   *
+   * GET /api/v1/customer/department/{id}
+   */
+  getCustomerDepartment(id: number): Promise<CustomerDepartmentReply> {
+    return this.request<CustomerDepartmentReply>("GET", `/api/v1/customer/department/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 更新部门
+   *
+   * 本接口用于更新指定ID的部门, 请求中的父部门ID不生效, 调整上级部门使用移动部门接口
+   *
+   * PUT /api/v1/customer/department/{id}
+   */
+  updateCustomerDepartment(id: number, body: CustomerCreateOrUpdateDepartmentRequest): Promise<CustomerDepartmentReply> {
+    return this.request<CustomerDepartmentReply>("PUT", `/api/v1/customer/department/${encodeURIComponent(String(id))}`, { body });
+  }
+
+  /**
+   * 删除部门
+   *
+   * 本接口用于删除指定ID的部门, 部门下存在下级部门或用户时不能删除
+   *
+   * DELETE /api/v1/customer/department/{id}
+   */
+  deleteCustomerDepartment(id: number): Promise<CommonMapAPIReply> {
+    return this.request<CommonMapAPIReply>("DELETE", `/api/v1/customer/department/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 移动部门
+   *
+   * 本接口用于将部门及其下级部门整体移动到新的父部门下, 父部门为空或0时移动为根部门,
+   * 不能移动到部门自身或其下级部门下
+   *
+   * PATCH /api/v1/customer/department/{id}/move
+   */
+  patchCustomerDepartmentMove(id: number, body: CustomerMoveDepartmentRequest): Promise<CustomerDepartmentReply> {
+    return this.request<CustomerDepartmentReply>("PATCH", `/api/v1/customer/department/${encodeURIComponent(String(id))}/move`, { body });
+  }
+
+  /**
+   * 查询用户组列表
+   *
+   * 本接口用于查询用户组列表
+   *
+   * GET /api/v1/customer/group
+   */
+  listCustomerGroup(params?: ListCustomerGroupParams): Promise<CustomerPagUserGroupReply> {
+    return this.request<CustomerPagUserGroupReply>("GET", `/api/v1/customer/group`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 新增用户组
+   *
+   * 本接口用于新增用户组, 组内用户继承用户组关联的全部角色
+   *
+   * POST /api/v1/customer/group
+   */
+  createCustomerGroup(body: CustomerCreateOrUpdateUserGroupRequest): Promise<CustomerUserGroupReply> {
+    return this.request<CustomerUserGroupReply>("POST", `/api/v1/customer/group`, { body });
+  }
+
+  /**
+   * 查询用户组
+   *
+   * 本接口用于查询指定ID的用户组及其角色和成员
+   *
+   * GET /api/v1/customer/group/{id}
+   */
+  getCustomerGroup(id: number): Promise<CustomerUserGroupReply> {
+    return this.request<CustomerUserGroupReply>("GET", `/api/v1/customer/group/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 更新用户组
+   *
+   * 本接口用于更新指定ID的用户组, 角色和成员按请求整体替换
+   *
+   * PUT /api/v1/customer/group/{id}
+   */
+  updateCustomerGroup(id: number, body: CustomerCreateOrUpdateUserGroupRequest): Promise<CustomerUserGroupReply> {
+    return this.request<CustomerUserGroupReply>("PUT", `/api/v1/customer/group/${encodeURIComponent(String(id))}`, { body });
+  }
+
+  /**
+   * 删除用户组
+   *
+   * 本接口用于删除指定ID的用户组, 组内用户不再继承该用户组的角色
+   *
+   * DELETE /api/v1/customer/group/{id}
+   */
+  deleteCustomerGroup(id: number): Promise<CommonMapAPIReply> {
+    return this.request<CommonMapAPIReply>("DELETE", `/api/v1/customer/group/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 添加用户组成员
+   *
+   * 本接口用于将用户加入指定的用户组, 已在组内的用户保持不变
+   *
+   * POST /api/v1/customer/group/{id}/members
+   */
+  createCustomerGroupMembers(id: number, body: CustomerUserGroupMemberRequest): Promise<CustomerUserGroupReply> {
+    return this.request<CustomerUserGroupReply>("POST", `/api/v1/customer/group/${encodeURIComponent(String(id))}/members`, { body });
+  }
+
+  /**
+   * 移除用户组成员
+   *
+   * 本接口用于将用户移出指定的用户组
+   *
+   * DELETE /api/v1/customer/group/{id}/members
+   */
+  deleteCustomerGroupMembers(id: number, body: CustomerUserGroupMemberRequest): Promise<CustomerUserGroupReply> {
+    return this.request<CustomerUserGroupReply>("DELETE", `/api/v1/customer/group/${encodeURIComponent(String(id))}/members`, { body });
+  }
+
+  /**
+   * 获取当前用户菜单树
+   *
+   * 本接口用于获取当前登录用户的菜单权限树, 合并直属角色和所属用户组角色的菜单
+   *
+   * GET /api/v1/customer/me/menu/tree
+   */
+  getCustomerMeMenuTree(): Promise<CustomerRoleMenuTreeReply> {
+    return this.request<CustomerRoleMenuTreeReply>("GET", `/api/v1/customer/me/menu/tree`);
+  }
+
+  /**
+   * 查询个人关联的外部身份
+   *
+   * 本接口用于查询当前用户关联的外部身份
+   *
+   * GET /api/v1/customer/me/oidc
+   */
+  getCustomerMeOidc(): Promise<CustomerUserIdentitiesReply> {
+    return this.request<CustomerUserIdentitiesReply>("GET", `/api/v1/customer/me/oidc`);
+  }
+
+  /**
+   * 关联外部身份
+   *
+   * 本接口用于生成授权地址, 授权回调成功后将外部身份关联到当前用户
+   *
+   * GET /api/v1/customer/me/oidc/link
+   */
+  getCustomerMeOidcLink(params: GetCustomerMeOidcLinkParams): Promise<CustomerOidcLoginReply> {
+    return this.request<CustomerOidcLoginReply>("GET", `/api/v1/customer/me/oidc/link`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 解除外部身份关联
+   *
+   * 本接口用于解除当前用户与指定身份提供方的关联
+   *
+   * DELETE /api/v1/customer/me/oidc/{provider}
+   */
+  deleteCustomerMeOidc(provider: string): Promise<CommonMapAPIReply> {
+    return this.request<CommonMapAPIReply>("DELETE", `/api/v1/customer/me/oidc/${encodeURIComponent(String(provider))}`);
+  }
+
+  /**
+   * 修改当前用户密码
+   *
+   * 本接口用于修改当前登录用户的密码
+   *
+   * PATCH /api/v1/customer/me/password
+   */
+  patchCustomerMePassword(body: CustomerPatchPasswordRequest): Promise<CommonMapAPIReply> {
+    return this.request<CommonMapAPIReply>("PATCH", `/api/v1/customer/me/password`, { body });
+  }
+
+  /**
+   * 查询个人全部偏好
+   *
+   * 本接口用于查询当前用户保存的全部界面偏好, 按命名空间排序
+   *
+   * GET /api/v1/customer/me/preferences
+   */
+  getCustomerMePreferences(): Promise<CustomerPreferencesReply> {
+    return this.request<CustomerPreferencesReply>("GET", `/api/v1/customer/me/preferences`);
+  }
+
+  /**
+   * 查询个人偏好
+   *
+   * 本接口用于查询当前用户在指定命名空间下的界面偏好
+   *
+   * GET /api/v1/customer/me/preferences/{namespace}
+   */
+  getCustomerMePreference(namespace: string): Promise<CustomerPreferenceReply> {
+    return this.request<CustomerPreferenceReply>("GET", `/api/v1/customer/me/preferences/${encodeURIComponent(String(namespace))}`);
+  }
+
+  /**
+   * 保存个人偏好
+   *
+   * 本接口用于保存当前用户在指定命名空间下的界面偏好, 已有时整体替换。
+   * 请求体为JSON对象, 按命名空间的格式校验, 不接受未定义的字段, 大小和命名空间数量受配置限制
+   *
+   * PUT /api/v1/customer/me/preferences/{namespace}
+   */
+  updateCustomerMePreferences(namespace: string, body: Record<string, unknown>): Promise<CustomerPreferenceReply> {
+    return this.request<CustomerPreferenceReply>("PUT", `/api/v1/customer/me/preferences/${encodeURIComponent(String(namespace))}`, { body });
+  }
+
+  /**
+   * 删除个人偏好
+   *
+   * 本接口用于删除当前用户在指定命名空间下的界面偏好, 恢复为默认设置
+   *
+   * DELETE /api/v1/customer/me/preferences/{namespace}
+   */
+  deleteCustomerMePreferences(namespace: string): Promise<CommonMapAPIReply> {
+    return this.request<CommonMapAPIReply>("DELETE", `/api/v1/customer/me/preferences/${encodeURIComponent(String(namespace))}`);
+  }
+
+  /**
+   * 查询当前用户的登录记录列表
+   *
+   * 本接口用于查询当前登录用户的登录记录列表
+   *
+   * GET /api/v1/customer/me/record/login
+   */
+  listCustomerMeRecordLogin(params?: ListCustomerMeRecordLoginParams): Promise<CustomerPagLoginRecordReply> {
+    return this.request<CustomerPagLoginRecordReply>("GET", `/api/v1/customer/me/record/login`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 获取当前用户的有效角色
+   *
+   * 本接口用于获取当前登录用户的有效角色, 包括直属角色和所属用户组的角色
+   *
+   * GET /api/v1/customer/me/roles
+   */
+  getCustomerMeRoles(): Promise<CustomerEffectiveRoleReply> {
+    return this.request<CustomerEffectiveRoleReply>("GET", `/api/v1/customer/me/roles`);
+  }
+
+  /**
+   * 查询个人登录会话
+   *
+   * 本接口用于查询当前用户未过期的登录会话, current标记当前请求所属的会话
+   *
+   * GET /api/v1/customer/me/sessions
+   */
+  getCustomerMeSessions(): Promise<CustomerUserSessionsReply> {
+    return this.request<CustomerUserSessionsReply>("GET", `/api/v1/customer/me/sessions`);
+  }
+
+  /**
+   * 终止个人其他登录会话
+   *
+   * 本接口用于终止当前用户除当前会话以外的所有会话
+   *
+   * DELETE /api/v1/customer/me/sessions
+   */
+  deleteCustomerMeSessions(): Promise<CustomerDeleteSessionReply> {
+    return this.request<CustomerDeleteSessionReply>("DELETE", `/api/v1/customer/me/sessions`);
+  }
+
+  /**
+   * 终止个人登录会话
+   *
+   * 本接口用于终止当前用户的指定会话, 会话下的令牌立即失效
+   *
+   * DELETE /api/v1/customer/me/sessions/{session_id}
+   */
+  deleteCustomerMeSession(sessionID: string): Promise<CommonMapAPIReply> {
+    return this.request<CommonMapAPIReply>("DELETE", `/api/v1/customer/me/sessions/${encodeURIComponent(String(sessionID))}`);
+  }
+
+  /**
+   * 查询菜单列表
+   *
+   * 本接口用于查询菜单列表
+   *
+   * GET /api/v1/customer/menu
+   */
+  listCustomerMenu(params?: ListCustomerMenuParams): Promise<CustomerPagMenuReply> {
+    return this.request<CustomerPagMenuReply>("GET", `/api/v1/customer/menu`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 新增菜单
+   *
+   * 本接口用于新增菜单
+   *
+   * POST /api/v1/customer/menu
+   */
+  createCustomerMenu(body: CustomerCreateMenuRequest): Promise<CustomerMenuReply> {
+    return this.request<CustomerMenuReply>("POST", `/api/v1/customer/menu`, { body });
+  }
+
+  /**
+   * 批量删除菜单
+   *
+   * 本接口用于在一个事务中批量删除菜单和菜单下的按钮, 存在不在删除范围内的下级菜单时不能删除, 角色授权和Casbin组策略随之清理
+   *
+   * POST /api/v1/customer/menu/bulk-delete
+   */
+  createCustomerMenuBulkDelete(body: CustomerBulkDeleteRequest): Promise<CustomerDeleteDependenciesReply> {
+    return this.request<CustomerDeleteDependenciesReply>("POST", `/api/v1/customer/menu/bulk-delete`, { body });
+  }
+
+  /**
+   * 检查删除菜单的影响
+   *
+   * 本接口用于删除菜单前检查引用关系, 返回阻止删除的下级菜单、随之删除的按钮、随之解除授权的角色和随之移除的Casbin组策略, 不修改数据
+   *
+   * GET /api/v1/customer/menu/dependencies
+   */
+  getCustomerMenuDependencies(params: GetCustomerMenuDependenciesParams): Promise<CustomerDeleteDependenciesReply> {
+    return this.request<CustomerDeleteDependenciesReply>("GET", `/api/v1/customer/menu/dependencies`, { query: params as unknown as Record<string, QueryValue> | undefined, multi: ["ids"] });
+  }
+
+  /**
+   * 查询菜单
+   *
+   * 本接口用于查询指定ID的菜单
+   *
+   * GET /api/v1/customer/menu/{id}
+   */
+  getCustomerMenu(id: number): Promise<CustomerMenuReply> {
+    return this.request<CustomerMenuReply>("GET", `/api/v1/customer/menu/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 更新菜单
+   *
+   * 本接口用于更新指定ID的菜单
+   *
+   * PUT /api/v1/customer/menu/{id}
+   */
+  updateCustomerMenu(id: number, body: CustomerUpdateMenuRequest): Promise<CustomerMenuReply> {
+    return this.request<CustomerMenuReply>("PUT", `/api/v1/customer/menu/${encodeURIComponent(String(id))}`, { body });
+  }
+
+  /**
+   * 删除菜单
+   *
+   * 本接口用于删除指定ID的菜单
+   *
+   * DELETE /api/v1/customer/menu/{id}
+   */
+  deleteCustomerMenu(id: number): Promise<CommonMapAPIReply> {
+    return this.request<CommonMapAPIReply>("DELETE", `/api/v1/customer/menu/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 导出权限配置
+   *
+   * 本接口用于将角色、菜单、按钮、API、Casbin模型和策略导出为一个YAML文档，对象之间使用名称引用，可导入到其他环境
+   *
+   * 返回原始响应, 由调用方读取响应体
+   *
+   * GET /api/v1/customer/rbac/export
+   */
+  getCustomerRbacExport(): Promise<Response> {
+    return this.request<Response>("GET", `/api/v1/customer/rbac/export`, { raw: true });
+  }
+
+  /**
+   * 导入权限配置
+   *
+   * 本接口用于导入导出接口生成的YAML文档，dry_run为true时只返回差异；与已有数据不一致的对象按conflict处理(fail:不导入任何数据, skip:保留已有数据, overwrite:覆盖)，文档未包含的对象保持不变
+   *
+   * POST /api/v1/customer/rbac/import
+   */
+  createCustomerRbacImport(params?: CreateCustomerRbacImportParams, body: string): Promise<CustomerRbacImportReply> {
+    return this.request<CustomerRbacImportReply>("POST", `/api/v1/customer/rbac/import`, { query: params as unknown as Record<string, QueryValue> | undefined, body });
+  }
+
+  /**
+   * 查询角色列表
+   *
+   * 本接口用于查询角色列表
+   *
+   * GET /api/v1/customer/role
+   */
+  listCustomerRole(params?: ListCustomerRoleParams): Promise<CustomerPagRoleReply> {
+    return this.request<CustomerPagRoleReply>("GET", `/api/v1/customer/role`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 新增角色
+   *
+   * 本接口用于新增角色
+   *
+   * POST /api/v1/customer/role
+   */
+  createCustomerRole(body: CustomerCreateOrUpdateRoleRequest): Promise<CustomerRoleReply> {
+    return this.request<CustomerRoleReply>("POST", `/api/v1/customer/role`, { body });
+  }
+
+  /**
+   * 批量删除角色
+   *
+   * 本接口用于在一个事务中批量删除角色, 仍是用户主角色的角色不能删除, 用户组关联和Casbin组策略随之清理
+   *
+   * POST /api/v1/customer/role/bulk-delete
+   */
+  createCustomerRoleBulkDelete(body: CustomerBulkDeleteRequest): Promise<CustomerDeleteDependenciesReply> {
+    return this.request<CustomerDeleteDependenciesReply>("POST", `/api/v1/customer/role/bulk-delete`, { body });
+  }
+
+  /**
+   * 检查删除角色的影响
+   *
+   * 本接口用于删除角色前检查引用关系, 返回阻止删除的引用、随之解除关联的记录和随之移除的Casbin组策略, 不修改数据
+   *
+   * GET /api/v1/customer/role/dependencies
+   */
+  getCustomerRoleDependencies(params: GetCustomerRoleDependenciesParams): Promise<CustomerDeleteDependenciesReply> {
+    return this.request<CustomerDeleteDependenciesReply>("GET", `/api/v1/customer/role/dependencies`, { query: params as unknown as Record<string, QueryValue> | undefined, multi: ["ids"] });
+  }
+
+  /**
+   * 查询角色
+   *
+   * 本接口用于查询指定ID的角色
+   *
+   * GET /api/v1/customer/role/{id}
+   */
+  getCustomerRole(id: number): Promise<CustomerRoleReply> {
+    return this.request<CustomerRoleReply>("GET", `/api/v1/customer/role/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 更新角色
+   *
+   * 本接口用于更新指定ID的角色
+   *
+   * PUT /api/v1/customer/role/{id}
+   */
+  updateCustomerRole(id: number, body: CustomerCreateOrUpdateRoleRequest): Promise<CustomerRoleReply> {
+    return this.request<CustomerRoleReply>("PUT", `/api/v1/customer/role/${encodeURIComponent(String(id))}`, { body });
+  }
+
+  /**
+   * 删除角色
+   *
+   * 本接口用于删除指定ID的角色
+   *
+   * DELETE /api/v1/customer/role/{id}
+   */
+  deleteCustomerRole(id: number): Promise<CommonMapAPIReply> {
+    return this.request<CommonMapAPIReply>("DELETE", `/api/v1/customer/role/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 校验查看敏感字段的权限
+   *
+   * 本接口用于校验当前用户是否可以查看敏感字段明文, 授予此接口权限的角色在查询结果中看到完整的IP地址、主机用户名等敏感字段
+   *
+   * GET /api/v1/customer/sensitive/reveal
+   */
+  getCustomerSensitiveReveal(): Promise<CommonMapAPIReply> {
+    return this.request<CommonMapAPIReply>("GET", `/api/v1/customer/sensitive/reveal`);
+  }
+
+  /**
+   * 查询签名密钥
+   *
+   * 本接口用于分页查询JWT签名密钥, 不返回私钥和HMAC密钥
+   *
+   * GET /api/v1/customer/signing-key
+   */
+  listCustomerSigningKey(params?: ListCustomerSigningKeyParams): Promise<CustomerPagSigningKeyReply> {
+    return this.request<CustomerPagSigningKeyReply>("GET", `/api/v1/customer/signing-key`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 轮换签名密钥
+   *
+   * 本接口用于生成新的JWT签名密钥, 旧密钥保留到令牌有效期结束, 密钥泄露时可立即停用旧密钥
+   *
+   * POST /api/v1/customer/signing-key/rotate
+   */
+  createCustomerSigningKeyRotate(body: CustomerRotateSigningKeyRequest): Promise<CustomerSigningKeyRotateReply> {
+    return this.request<CustomerSigningKeyRotateReply>("POST", `/api/v1/customer/signing-key/rotate`, { body });
+  }
+
+  /**
+   * 查询用户列表
+   *
+   * 本接口用于查询用户列表, 支持按部门筛选(include_sub包含下级部门), 非工作人员只能查看角色数据范围内的用户
+   *
+   * GET /api/v1/customer/user
+   */
+  listCustomerUser(params?: ListCustomerUserParams): Promise<CustomerPagUserReply> {
+    return this.request<CustomerPagUserReply>("GET", `/api/v1/customer/user`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 新增用户
+   *
+   * 本接口用于新增用户
+   *
+   * POST /api/v1/customer/user
+   */
+  createCustomerUser(body: CustomerCreateUserRequest): Promise<CustomerUserReply> {
+    return this.request<CustomerUserReply>("POST", `/api/v1/customer/user`, { body });
+  }
+
+  /**
+   * 统计密码哈希算法
+   *
+   * 本接口用于统计用户密码哈希使用的算法, 以及仍使用旧算法或旧参数的用户数量,
+   * 这些用户下次登录成功时按当前配置的算法和参数重新哈希
+   *
+   * GET /api/v1/customer/user/password/report
+   */
+  getCustomerUserPasswordReport(): Promise<CustomerPasswordHashReportReply> {
+    return this.request<CustomerPasswordHashReportReply>("GET", `/api/v1/customer/user/password/report`);
+  }
+
+  /**
+   * 重置用户密码
+   *
+   * 本接口用于重置指定ID的用户密码
+   *
+   * PATCH /api/v1/customer/user/password/{id}
+   */
+  patchCustomerUserPassword(id: number, body: CustomerResetPasswordRequest): Promise<CommonMapAPIReply> {
+    return this.request<CommonMapAPIReply>("PATCH", `/api/v1/customer/user/password/${encodeURIComponent(String(id))}`, { body });
+  }
+
+  /**
+   * 查询用户的登录记录列表
+   *
+   * 本接口用于查询用户登录记录列表, 指定export参数时按columns选择的列导出为csv或xlsx文件
+   *
+   * GET /api/v1/customer/user/record/login
+   */
+  listCustomerUserRecordLogin(params?: ListCustomerUserRecordLoginParams): Promise<CustomerPagLoginRecordReply> {
+    return this.request<CustomerPagLoginRecordReply>("GET", `/api/v1/customer/user/record/login`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 查询用户
+   *
+   * 本接口用于查询指定ID的用户
+   *
+   * GET /api/v1/customer/user/{id}
+   */
+  getCustomerUser(id: number): Promise<CustomerUserReply> {
+    return this.request<CustomerUserReply>("GET", `/api/v1/customer/user/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 更新用户
+   *
+   * 本接口用于更新指定ID的用户
+   *
+   * PUT /api/v1/customer/user/{id}
+   */
+  updateCustomerUser(id: number, body: CustomerUpdateUserRequest): Promise<CustomerUserReply> {
+    return this.request<CustomerUserReply>("PUT", `/api/v1/customer/user/${encodeURIComponent(String(id))}`, { body });
+  }
+
+  /**
+   * 删除用户
+   *
+   * 本接口用于删除指定ID的用户
+   *
+   * DELETE /api/v1/customer/user/{id}
+   */
+  deleteCustomerUser(id: number): Promise<CommonMapAPIReply> {
+    return this.request<CommonMapAPIReply>("DELETE", `/api/v1/customer/user/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 停用用户
+   *
+   * 本接口用于停用离职用户, 停用后立即终止用户的全部会话, 宽限期内可以通过更新用户重新启用,
+   * 到期后永久停用. 响应中返回用户名下仍有的记录, 需要在永久停用前转移给其他用户. 不能停用当前登录的用户
+   *
+   * POST /api/v1/customer/user/{id}/deactivate
+   */
+  createCustomerUserDeactivate(id: number, body: CustomerDeactivateUserRequest): Promise<CustomerDeactivateUserReply> {
+    return this.request<CustomerDeactivateUserReply>("POST", `/api/v1/customer/user/${encodeURIComponent(String(id))}/deactivate`, { body });
+  }
+
+  /**
+   * 设置用户所属部门
+   *
+   * 本接口用于设置指定用户所属的部门, 部门ID为空或0时移出部门
+   *
+   * PUT /api/v1/customer/user/{id}/department
+   */
+  updateCustomerUserDepartment(id: number, body: CustomerAssignDepartmentRequest): Promise<CustomerUserReply> {
+    return this.request<CustomerUserReply>("PUT", `/api/v1/customer/user/${encodeURIComponent(String(id))}/department`, { body });
+  }
+
+  /**
+   * 转移用户名下的记录
+   *
+   * 本接口用于将用户名下的记录转移给其他已启用的用户, 转移后计划任务以接收用户的身份执行
+   *
+   * POST /api/v1/customer/user/{id}/handover
+   */
+  createCustomerUserHandover(id: number, body: CustomerHandoverRequest): Promise<CustomerHandoverReply> {
+    return this.request<CustomerHandoverReply>("POST", `/api/v1/customer/user/${encodeURIComponent(String(id))}/handover`, { body });
+  }
+
+  /**
+   * 代为登录用户
+   *
+   * 本接口用于工作人员代为登录指定用户以复现权限问题, 签发的访问令牌有效期较短且不能刷新,
+   * 令牌中携带管理员身份, 使用该令牌的响应带有X-Impersonated-By响应头, 全部请求记录审计记录.
+   * 不能代为登录自己、其他工作人员和未激活的用户
+   *
+   * POST /api/v1/customer/user/{id}/impersonate
+   */
+  createCustomerUserImpersonate(id: number): Promise<CustomerImpersonateReply> {
+    return this.request<CustomerImpersonateReply>("POST", `/api/v1/customer/user/${encodeURIComponent(String(id))}/impersonate`);
+  }
+
+  /**
+   * 查询用户名下的记录
+   *
+   * 本接口用于查询用户创建且仍归属于该用户的计划任务、webhook订阅等记录, 每类最多列出50条
+   *
+   * GET /api/v1/customer/user/{id}/owned
+   */
+  getCustomerUserOwned(id: number): Promise<CustomerOwnedReply> {
+    return this.request<CustomerOwnedReply>("GET", `/api/v1/customer/user/${encodeURIComponent(String(id))}/owned`);
+  }
+
+  /**
+   * 查询用户登录会话
+   *
+   * 本接口用于管理员查询指定用户未过期的登录会话
+   *
+   * GET /api/v1/customer/user/{id}/sessions
+   */
+  getCustomerUserSessions(id: number): Promise<CustomerUserSessionsReply> {
+    return this.request<CustomerUserSessionsReply>("GET", `/api/v1/customer/user/${encodeURIComponent(String(id))}/sessions`);
+  }
+
+  /**
+   * 终止用户全部登录会话
+   *
+   * 本接口用于管理员终止指定用户的全部会话, 管理员自己的当前会话除外
+   *
+   * DELETE /api/v1/customer/user/{id}/sessions
+   */
+  deleteCustomerUserSessions(id: number): Promise<CustomerDeleteSessionReply> {
+    return this.request<CustomerDeleteSessionReply>("DELETE", `/api/v1/customer/user/${encodeURIComponent(String(id))}/sessions`);
+  }
+
+  /**
+   * 终止用户登录会话
+   *
+   * 本接口用于管理员终止指定用户的指定会话
+   *
+   * DELETE /api/v1/customer/user/{id}/sessions/{session_id}
+   */
+  deleteCustomerUserSession(id: number, sessionID: string): Promise<CommonMapAPIReply> {
+    return this.request<CommonMapAPIReply>("DELETE", `/api/v1/customer/user/${encodeURIComponent(String(id))}/sessions/${encodeURIComponent(String(sessionID))}`);
+  }
+
+  /**
+   * 查询是否为交易日
+   *
+   * 本接口用于查询指定日期是否为交易日, 未指定日期时查询当天
+   *
+   * GET /api/v1/jobs/calendar/check
+   */
+  getJobsCalendarCheck(params?: GetJobsCalendarCheckParams): Promise<JobsTradingDayReply> {
+    return this.request<JobsTradingDayReply>("GET", `/api/v1/jobs/calendar/check`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 查询交易日历节假日列表
+   *
+   * 本接口用于分页查询交易日历中的节假日
+   *
+   * GET /api/v1/jobs/calendar/holiday
+   */
+  listJobsCalendarHoliday(params?: ListJobsCalendarHolidayParams): Promise<JobsPagTradingHolidayReply> {
+    return this.request<JobsPagTradingHolidayReply>("GET", `/api/v1/jobs/calendar/holiday`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 删除交易日历节假日
+   *
+   * 本接口用于删除指定ID的节假日
+   *
+   * DELETE /api/v1/jobs/calendar/holiday/{id}
+   */
+  deleteJobsCalendarHoliday(id: number): Promise<CommonMapAPIReply> {
+    return this.request<CommonMapAPIReply>("DELETE", `/api/v1/jobs/calendar/holiday/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 导入交易日历
+   *
+   * 本接口用于导入交易所发布的年度休市安排, 导入时整体替换该年份的节假日
+   *
+   * POST /api/v1/jobs/calendar/import
+   */
+  createJobsCalendarImport(body: JobsImportTradingCalendarRequest): Promise<CommonMapAPIReply> {
+    return this.request<CommonMapAPIReply>("POST", `/api/v1/jobs/calendar/import`, { body });
+  }
+
+  /**
+   * 查询计划任务跳过记录
+   *
+   * 本接口用于分页查询计划任务因非交易日被跳过的记录
+   *
+   * GET /api/v1/jobs/calendar/skip
+   */
+  listJobsCalendarSkip(params?: ListJobsCalendarSkipParams): Promise<JobsPagScheduleSkipReply> {
+    return this.request<JobsPagScheduleSkipReply>("GET", `/api/v1/jobs/calendar/skip`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 查询脚本执行记录列表
+   *
+   * 本接口用于查询脚本执行记录列表, 支持通过filter参数按字段组合过滤, 指定export参数时按columns选择的列导出为csv或xlsx文件
+   *
+   * GET /api/v1/jobs/record
+   */
+  listJobsRecord(params?: ListJobsRecordParams): Promise<JobsPagScriptRecordReply> {
+    return this.request<JobsPagScriptRecordReply>("GET", `/api/v1/jobs/record`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 执行脚本
+   *
+   * 本接口用于执行指定的脚本并记录执行结果
+   *
+   * POST /api/v1/jobs/record
+   */
+  createJobsRecord(body: JobsCreateScriptRecordRequest): Promise<JobsScriptRecordReply> {
+    return this.request<JobsScriptRecordReply>("POST", `/api/v1/jobs/record`, { body });
+  }
+
+  /**
+   * 查询脚本执行记录详情
+   *
+   * 本接口用于查询指定ID的脚本执行记录详情
+   *
+   * GET /api/v1/jobs/record/{id}
+   */
+  getJobsRecord(id: number): Promise<JobsScriptRecordReply> {
+    return this.request<JobsScriptRecordReply>("GET", `/api/v1/jobs/record/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 对正在执行的脚本发送终止信号
+   *
+   * 本接口用于通过执行记录的id号,对正在执行的脚本发送终止信号
+   *
+   * DELETE /api/v1/jobs/record/{id}
+   */
+  deleteJobsRecord(id: number): Promise<CommonMapAPIReply> {
+    return this.request<CommonMapAPIReply>("DELETE", `/api/v1/jobs/record/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 查询执行产物列表
+   *
+   * 本接口用于查询指定执行记录收集的产物文件
+   *
+   * GET /api/v1/jobs/record/{id}/artifacts
+   */
+  listJobsRecordArtifacts(id: number): Promise<JobsListArtifactReply> {
+    return this.request<JobsListArtifactReply>("GET", `/api/v1/jobs/record/${encodeURIComponent(String(id))}/artifacts`);
+  }
+
+  /**
+   * 下载执行产物
+   *
+   * 本接口用于下载指定执行记录收集的产物文件
+   *
+   * 返回原始响应, 由调用方读取响应体
+   *
+   * GET /api/v1/jobs/record/{id}/artifacts/{artifact_id}
+   */
+  getJobsRecordArtifacts(id: number, artifactID: number): Promise<Response> {
+    return this.request<Response>("GET", `/api/v1/jobs/record/${encodeURIComponent(String(id))}/artifacts/${encodeURIComponent(String(artifactID))}`, { raw: true });
+  }
+
+  /**
+   * 下载脚本执行日志
+   *
+   * 本接口用于下载指定执行记录的日志文件
+   *
+   * 返回原始响应, 由调用方读取响应体
+   *
+   * GET /api/v1/jobs/record/{id}/log
+   */
+  getJobsRecordLog(id: number): Promise<Response> {
+    return this.request<Response>("GET", `/api/v1/jobs/record/${encodeURIComponent(String(id))}/log`, { raw: true });
+  }
+
+  /**
+   * 实时获取脚本执行日志
+   *
+   * 本接口用于实时获取指定执行记录的日志内容
+   *
+   * 返回原始响应, 由调用方读取响应体
+   *
+   * GET /api/v1/jobs/record/{id}/log/stream
+   */
+  getJobsRecordLogStream(id: number): Promise<Response> {
+    return this.request<Response>("GET", `/api/v1/jobs/record/${encodeURIComponent(String(id))}/log/stream`, { raw: true });
+  }
+
+  /**
+   * 查看脚本执行记录环境变量
+   *
+   * 本接口用于查看指定ID的脚本执行记录环境变量明文, 需要单独授权
+   *
+   * GET /api/v1/jobs/record/{id}/reveal
+   */
+  getJobsRecordReveal(id: number): Promise<JobsEnvVarsReply> {
+    return this.request<JobsEnvVarsReply>("GET", `/api/v1/jobs/record/${encodeURIComponent(String(id))}/reveal`);
+  }
+
+  /**
+   * 查询计划任务列表
+   *
+   * 本接口用于查询计划任务列表
+   *
+   * GET /api/v1/jobs/schedule
+   */
+  listJobsSchedule(params?: ListJobsScheduleParams): Promise<JobsPagScheduleReply> {
+    return this.request<JobsPagScheduleReply>("GET", `/api/v1/jobs/schedule`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 创建计划任务
+   *
+   * 本接口用于创建新的计划任务
+   *
+   * POST /api/v1/jobs/schedule
+   */
+  createJobsSchedule(body: JobsCreateScheduleRequest): Promise<JobsScheduleReply> {
+    return this.request<JobsScheduleReply>("POST", `/api/v1/jobs/schedule`, { body });
+  }
+
+  /**
+   * 查询计划任务详情
+   *
+   * 本接口用于查询指定ID的计划任务详情
+   *
+   * GET /api/v1/jobs/schedule/{id}
+   */
+  getJobsSchedule(id: number): Promise<JobsScheduleReply> {
+    return this.request<JobsScheduleReply>("GET", `/api/v1/jobs/schedule/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 更新计划任务
+   *
+   * 本接口用于更新指定ID的计划任务
+   *
+   * PUT /api/v1/jobs/schedule/{id}
+   */
+  updateJobsSchedule(id: number, body: JobsUpdateScheduleRequest): Promise<JobsScheduleReply> {
+    return this.request<JobsScheduleReply>("PUT", `/api/v1/jobs/schedule/${encodeURIComponent(String(id))}`, { body });
+  }
+
+  /**
+   * 删除计划任务
+   *
+   * 本接口用于删除指定ID的计划任务
+   *
+   * DELETE /api/v1/jobs/schedule/{id}
+   */
+  deleteJobsSchedule(id: number): Promise<CommonMapAPIReply> {
+    return this.request<CommonMapAPIReply>("DELETE", `/api/v1/jobs/schedule/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 预览计划任务的环境变量
+   *
+   * 本接口用于预览计划任务触发时注入的环境变量, 绑定oes集群时按当前的集群数据解析集群上下文变量
+   *
+   * GET /api/v1/jobs/schedule/{id}/env/preview
+   */
+  getJobsScheduleEnvPreview(id: number): Promise<JobsEnvPreviewReply> {
+    return this.request<JobsEnvPreviewReply>("GET", `/api/v1/jobs/schedule/${encodeURIComponent(String(id))}/env/preview`);
+  }
+
+  /**
+   * 查看计划任务环境变量
+   *
+   * 本接口用于查看指定ID的计划任务环境变量明文, 需要单独授权
+   *
+   * GET /api/v1/jobs/schedule/{id}/reveal
+   */
+  getJobsScheduleReveal(id: number): Promise<JobsEnvVarsReply> {
+    return this.request<JobsEnvVarsReply>("GET", `/api/v1/jobs/schedule/${encodeURIComponent(String(id))}/reveal`);
+  }
+
+  /**
+   * 查询脚本列表
+   *
+   * 本接口用于查询脚本列表
+   *
+   * GET /api/v1/jobs/script
+   */
+  listJobsScript(params?: ListJobsScriptParams): Promise<JobsPagScriptReply> {
+    return this.request<JobsPagScriptReply>("GET", `/api/v1/jobs/script`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 上传脚本
+   *
+   * 本接口用于上传新的脚本文件
+   *
+   * POST /api/v1/jobs/script
+   */
+  createJobsScript(form: CreateJobsScriptForm): Promise<JobsScriptReply> {
+    return this.request<JobsScriptReply>("POST", `/api/v1/jobs/script`, { form: form as unknown as Record<string, unknown> });
+  }
+
+  /**
+   * 查询标签列表
+   *
+   * 本接口用于查询标签列表
+   *
+   * GET /api/v1/jobs/script/label
+   */
+  listJobsScriptLabel(params?: ListJobsScriptLabelParams): Promise<JobsListLableReply> {
+    return this.request<JobsListLableReply>("GET", `/api/v1/jobs/script/label`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 查询项目列表
+   *
+   * 本接口用于查询项目列表
+   *
+   * GET /api/v1/jobs/script/project
+   */
+  listJobsScriptProject(params?: ListJobsScriptProjectParams): Promise<JobsListProjectReply> {
+    return this.request<JobsListProjectReply>("GET", `/api/v1/jobs/script/project`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 查询脚本详情
+   *
+   * 本接口用于查询指定ID的脚本详情
+   *
+   * GET /api/v1/jobs/script/{id}
+   */
+  getJobsScript(id: number): Promise<JobsScriptReply> {
+    return this.request<JobsScriptReply>("GET", `/api/v1/jobs/script/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 更新脚本
+   *
+   * 本接口用于更新指定ID的脚本文件
+   *
+   * PUT /api/v1/jobs/script/{id}
+   */
+  updateJobsScript(id: number, form: UpdateJobsScriptForm): Promise<JobsScriptReply> {
+    return this.request<JobsScriptReply>("PUT", `/api/v1/jobs/script/${encodeURIComponent(String(id))}`, { form: form as unknown as Record<string, unknown> });
+  }
+
+  /**
+   * 删除脚本
+   *
+   * 本接口用于删除指定ID的脚本
+   *
+   * DELETE /api/v1/jobs/script/{id}
+   */
+  deleteJobsScript(id: number): Promise<CommonMapAPIReply> {
+    return this.request<CommonMapAPIReply>("DELETE", `/api/v1/jobs/script/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 下载脚本
+   *
+   * 本接口用于下载指定ID的脚本文件
+   *
+   * 返回原始响应, 由调用方读取响应体
+   *
+   * GET /api/v1/jobs/script/{id}/download
+   */
+  getJobsScriptDownload(id: number): Promise<Response> {
+    return this.request<Response>("GET", `/api/v1/jobs/script/${encodeURIComponent(String(id))}/download`, { raw: true });
+  }
+
+  /**
+   * 检查脚本
+   *
+   * 本接口用于在调度到生产环境之前检查脚本, 包括解释器行、换行符、解释器语法检查和shellcheck(已安装时)
+   * dry_run为true时在配置的沙箱中试运行脚本, 试运行设置环境变量JOBS_DRY_RUN=1, 未配置沙箱时返回错误
+   *
+   * POST /api/v1/jobs/script/{id}/validate
+   */
+  createJobsScriptValidate(id: number, body: JobsValidateScriptRequest): Promise<JobsScriptValidateReply> {
+    return this.request<JobsScriptValidateReply>("POST", `/api/v1/jobs/script/${encodeURIComponent(String(id))}/validate`, { body });
+  }
+
+  /**
+   * 登陆接口
+   *
+   * 本接口用于登陆, 开启Cookie认证时令牌同时写入HttpOnly Cookie
+   *
+   * POST /api/v1/login
+   */
+  createLogin(body: CustomerLoginRequest): Promise<CustomerLoginReply> {
+    return this.request<CustomerLoginReply>("POST", `/api/v1/login`, { body });
+  }
+
+  /**
+   * 退出登录接口
+   *
+   * 本接口用于退出登录, 终止刷新令牌或访问令牌所属的会话, 会话的令牌立即失效; 开启Cookie认证时请求参数可以为空, 从Cookie中读取令牌, 并删除令牌和CSRF令牌Cookie
+   *
+   * POST /api/v1/logout
+   */
+  createLogout(body: CustomerRefreshTokenRequest): Promise<CommonMapAPIReply> {
+    return this.request<CommonMapAPIReply>("POST", `/api/v1/logout`, { body });
+  }
+
+  /**
+   * 查询mds数据回补列表
+   *
+   * 本接口用于分页查询数据回补及各状态的交易日数
+   *
+   * GET /api/v1/mds/backfill
+   */
+  listMdsBackfill(params?: ListMdsBackfillParams): Promise<MdsPagMdsBackfillRunReply> {
+    return this.request<MdsPagMdsBackfillRunReply>("GET", `/api/v1/mds/backfill`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 发起mds数据回补
+   *
+   * 本接口用于按日期范围为集群发起数据回补, 日期范围内的每个交易日执行一次回放脚本, 回补在后台执行
+   *
+   * POST /api/v1/mds/backfill
+   */
+  createMdsBackfill(body: MdsCreateMdsBackfillRequest): Promise<MdsMdsBackfillRunReply> {
+    return this.request<MdsMdsBackfillRunReply>("POST", `/api/v1/mds/backfill`, { body });
+  }
+
+  /**
+   * 查询mds数据回补详情
+   *
+   * 本接口用于查询数据回补的汇总状态及每个交易日的回放状态
+   *
+   * GET /api/v1/mds/backfill/{id}
+   */
+  getMdsBackfill(id: number): Promise<MdsMdsBackfillRunReply> {
+    return this.request<MdsMdsBackfillRunReply>("GET", `/api/v1/mds/backfill/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 取消mds数据回补
+   *
+   * 本接口用于取消正在执行的数据回补, 正在执行的回放脚本会被终止, 未开始的交易日标记为已取消
+   *
+   * POST /api/v1/mds/backfill/{id}/cancel
+   */
+  createMdsBackfillCancel(id: number): Promise<CommonMapAPIReply> {
+    return this.request<CommonMapAPIReply>("POST", `/api/v1/mds/backfill/${encodeURIComponent(String(id))}/cancel`);
+  }
+
+  /**
+   * 重试mds数据回补
+   *
+   * 本接口用于重新执行已结束的数据回补中失败、跳过或取消的交易日, 已成功的交易日不再执行
+   *
+   * POST /api/v1/mds/backfill/{id}/retry
+   */
+  createMdsBackfillRetry(id: number): Promise<MdsMdsBackfillRunReply> {
+    return this.request<MdsMdsBackfillRunReply>("POST", `/api/v1/mds/backfill/${encodeURIComponent(String(id))}/retry`);
+  }
+
+  /**
+   * 查询mds集群列表
+   *
+   * 本接口用于查询mds集群列表, 指定export参数时按columns选择的列导出为csv或xlsx文件
+   *
+   * GET /api/v1/mds/colony
+   */
+  listMdsColony(params?: ListMdsColonyParams): Promise<MdsPagMdsColonyReply> {
+    return this.request<MdsPagMdsColonyReply>("GET", `/api/v1/mds/colony`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 创建mds集群
+   *
+   * 本接口用于创建新的mds集群
+   *
+   * POST /api/v1/mds/colony
+   */
+  createMdsColony(body: MdsCreateOrUpdateMdsColonyRequest): Promise<MdsMdsColonyReply> {
+    return this.request<MdsMdsColonyReply>("POST", `/api/v1/mds/colony`, { body });
+  }
+
+  /**
+   * 查询mds集群列表的任务状态
+   *
+   * 本接口用于查询mds集群列表的任务状态
+   *
+   * GET /api/v1/mds/colony/status
+   */
+  listMdsColonyStatus(params?: ListMdsColonyStatusParams): Promise<MdsListMdsTasksInfoReply> {
+    return this.request<MdsListMdsTasksInfoReply>("GET", `/api/v1/mds/colony/status`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 查询mds集群详情
+   *
+   * 本接口用于查询指定ID的mds集群详情
+   *
+   * GET /api/v1/mds/colony/{id}
+   */
+  getMdsColony(id: number): Promise<MdsMdsColonyReply> {
+    return this.request<MdsMdsColonyReply>("GET", `/api/v1/mds/colony/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 更新mds集群
+   *
+   * 本接口用于更新指定ID的mds集群
+   *
+   * PUT /api/v1/mds/colony/{id}
+   */
+  updateMdsColony(id: number, body: MdsCreateOrUpdateMdsColonyRequest): Promise<MdsMdsColonyReply> {
+    return this.request<MdsMdsColonyReply>("PUT", `/api/v1/mds/colony/${encodeURIComponent(String(id))}`, { body });
+  }
+
+  /**
+   * 删除mds集群
+   *
+   * 本接口用于删除指定ID的mds集群
+   *
+   * DELETE /api/v1/mds/colony/{id}
+   */
+  deleteMdsColony(id: number): Promise<CommonMapAPIReply> {
+    return this.request<CommonMapAPIReply>("DELETE", `/api/v1/mds/colony/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 查询交易日采集状态
+   *
+   * 本接口用于查询指定交易日每个启用的数据源的采集状态, 尚未采集的数据源状态为pending
+   *
+   * GET /api/v1/mds/ingest/day/{trading_day}
+   */
+  getMdsIngestDay(tradingDay: string): Promise<MdsIngestDayReply> {
+    return this.request<MdsIngestDayReply>("GET", `/api/v1/mds/ingest/day/${encodeURIComponent(String(tradingDay))}`);
+  }
+
+  /**
+   * 查询采集记录列表
+   *
+   * 本接口用于按数据源、交易日和状态查询采集记录列表
+   *
+   * GET /api/v1/mds/ingest/record
+   */
+  listMdsIngestRecord(params?: ListMdsIngestRecordParams): Promise<MdsPagIngestRecordReply> {
+    return this.request<MdsPagIngestRecordReply>("GET", `/api/v1/mds/ingest/record`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 查询采集记录详情
+   *
+   * 本接口用于查询指定ID的采集记录, 包含校验错误和核对结果
+   *
+   * GET /api/v1/mds/ingest/record/{id}
+   */
+  getMdsIngestRecord(id: number): Promise<MdsIngestRecordReply> {
+    return this.request<MdsIngestRecordReply>("GET", `/api/v1/mds/ingest/record/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 查询行情数据源列表
+   *
+   * 本接口用于查询行情数据源列表
+   *
+   * GET /api/v1/mds/ingest/source
+   */
+  listMdsIngestSource(params?: ListMdsIngestSourceParams): Promise<MdsPagIngestSourceReply> {
+    return this.request<MdsPagIngestSourceReply>("GET", `/api/v1/mds/ingest/source`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 创建行情数据源
+   *
+   * 本接口用于创建行情数据源, 设置定时采集表达式后按计划采集每个交易日的行情文件
+   *
+   * POST /api/v1/mds/ingest/source
+   */
+  createMdsIngestSource(body: MdsCreateOrUpdateIngestSourceRequest): Promise<MdsIngestSourceReply> {
+    return this.request<MdsIngestSourceReply>("POST", `/api/v1/mds/ingest/source`, { body });
+  }
+
+  /**
+   * 查询行情数据源详情
+   *
+   * 本接口用于查询指定ID的行情数据源详情
+   *
+   * GET /api/v1/mds/ingest/source/{id}
+   */
+  getMdsIngestSource(id: number): Promise<MdsIngestSourceReply> {
+    return this.request<MdsIngestSourceReply>("GET", `/api/v1/mds/ingest/source/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 更新行情数据源
+   *
+   * 本接口用于更新指定ID的行情数据源, 并重新注册定时采集任务
+   *
+   * PUT /api/v1/mds/ingest/source/{id}
+   */
+  updateMdsIngestSource(id: number, body: MdsCreateOrUpdateIngestSourceRequest): Promise<MdsIngestSourceReply> {
+    return this.request<MdsIngestSourceReply>("PUT", `/api/v1/mds/ingest/source/${encodeURIComponent(String(id))}`, { body });
+  }
+
+  /**
+   * 删除行情数据源
+   *
+   * 本接口用于删除指定ID的行情数据源及其采集记录
+   *
+   * DELETE /api/v1/mds/ingest/source/{id}
+   */
+  deleteMdsIngestSource(id: number): Promise<CommonMapAPIReply> {
+    return this.request<CommonMapAPIReply>("DELETE", `/api/v1/mds/ingest/source/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 采集行情数据
+   *
+   * 本接口用于手动采集或重新采集数据源指定交易日的行情文件, 采集在后台进行, 通过采集记录查询结果
+   *
+   * POST /api/v1/mds/ingest/source/{id}/run
+   */
+  createMdsIngestSourceRun(id: number, body: MdsRunIngestRequest): Promise<MdsIngestRecordReply> {
+    return this.request<MdsIngestRecordReply>("POST", `/api/v1/mds/ingest/source/${encodeURIComponent(String(id))}/run`, { body });
+  }
+
+  /**
+   * 查询mds节点列表
+   *
+   * 本接口用于查询mds节点列表
+   *
+   * GET /api/v1/mds/node
+   */
+  listMdsNode(params?: ListMdsNodeParams): Promise<MdsPagMdsNodeReply> {
+    return this.request<MdsPagMdsNodeReply>("GET", `/api/v1/mds/node`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 创建mds节点
+   *
+   * 本接口用于创建新的mds节点
+   *
+   * POST /api/v1/mds/node
+   */
+  createMdsNode(body: MdsCreateOrUpdateMdsNodeRequest): Promise<MdsMdsNodeReply> {
+    return this.request<MdsMdsNodeReply>("POST", `/api/v1/mds/node`, { body });
+  }
+
+  /**
+   * 查询mds节点详情
+   *
+   * 本接口用于查询指定ID的mds节点详情
+   *
+   * GET /api/v1/mds/node/{id}
+   */
+  getMdsNode(id: number): Promise<MdsMdsNodeReply> {
+    return this.request<MdsMdsNodeReply>("GET", `/api/v1/mds/node/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 更新mds节点
+   *
+   * 本接口用于更新指定ID的mds节点
+   *
+   * PUT /api/v1/mds/node/{id}
+   */
+  updateMdsNode(id: number, body: MdsCreateOrUpdateMdsNodeRequest): Promise<MdsMdsNodeReply> {
+    return this.request<MdsMdsNodeReply>("PUT", `/api/v1/mds/node/${encodeURIComponent(String(id))}`, { body });
+  }
+
+  /**
+   * 删除mds节点
+   *
+   * 本接口用于删除指定ID的mds节点
+   *
+   * DELETE /api/v1/mds/node/{id}
+   */
+  deleteMdsNode(id: number): Promise<CommonMapAPIReply> {
+    return this.request<CommonMapAPIReply>("DELETE", `/api/v1/mds/node/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 获取mds配置文件列表
+   *
+   * 获取指定目录下的mds配置文件列表
+   *
+   * GET /api/v1/mds/{colony_num}/conf
+   */
+  listMdsConf(colonyNum: string): Promise<MdsPagMdsConfReply> {
+    return this.request<MdsPagMdsConfReply>("GET", `/api/v1/mds/${encodeURIComponent(String(colonyNum))}/conf`);
+  }
+
+  /**
+   * 上传mds配置文件
+   *
+   * 上传mds配置文件到指定目录
+   *
+   * POST /api/v1/mds/{colony_num}/conf/{dir_name}
+   */
+  createMdsConf(colonyNum: string, dirName: string, form: CreateMdsConfForm): Promise<CommonMapAPIReply> {
+    return this.request<CommonMapAPIReply>("POST", `/api/v1/mds/${encodeURIComponent(String(colonyNum))}/conf/${encodeURIComponent(String(dirName))}`, { form: form as unknown as Record<string, unknown> });
+  }
+
+  /**
+   * 下载mds配置文件
+   *
+   * 下载指定的mds配置文件
+   *
+   * 返回原始响应, 由调用方读取响应体
+   *
+   * GET /api/v1/mds/{colony_num}/conf/{dir_name}/{filename}
+   */
+  getMdsConf(colonyNum: string, dirName: string, filename: string): Promise<Response> {
+    return this.request<Response>("GET", `/api/v1/mds/${encodeURIComponent(String(colonyNum))}/conf/${encodeURIComponent(String(dirName))}/${encodeURIComponent(String(filename))}`, { raw: true });
+  }
+
+  /**
+   * 删除mds配置文件
+   *
+   * 删除指定的mds配置文件
+   *
+   * DELETE /api/v1/mds/{colony_num}/conf/{dir_name}/{filename}
+   */
+  deleteMdsConf(colonyNum: string, dirName: string, filename: string): Promise<CommonMapAPIReply> {
+    return this.request<CommonMapAPIReply>("DELETE", `/api/v1/mds/${encodeURIComponent(String(colonyNum))}/conf/${encodeURIComponent(String(dirName))}/${encodeURIComponent(String(filename))}`);
+  }
+
+  /**
+   * 查询主机代理列表
+   *
+   * 本接口用于查询资源主机的代理上报信息和在线状态, 可按status=offline查询失联的主机
+   *
+   * GET /api/v1/mon/host/agent
+   */
+  listMonHostAgent(params?: ListMonHostAgentParams): Promise<ResourcePagHostAgentReply> {
+    return this.request<ResourcePagHostAgentReply>("GET", `/api/v1/mon/host/agent`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 查询mon节点列表
+   *
+   * 本接口用于查询mon节点列表
+   *
+   * GET /api/v1/mon/node
+   */
+  listMonNode(params?: ListMonNodeParams): Promise<MonPagMonNodeReply> {
+    return this.request<MonPagMonNodeReply>("GET", `/api/v1/mon/node`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 创建mon节点
+   *
+   * 本接口用于创建新的mon节点
+   *
+   * POST /api/v1/mon/node
+   */
+  createMonNode(body: MonCreateOrUpdateMonNodeRequest): Promise<MonMonNodeReply> {
+    return this.request<MonMonNodeReply>("POST", `/api/v1/mon/node`, { body });
+  }
+
+  /**
+   * 查询mon节点详情
+   *
+   * 本接口用于查询指定ID的mon节点详情
+   *
+   * GET /api/v1/mon/node/{id}
+   */
+  getMonNode(id: number): Promise<MonMonNodeReply> {
+    return this.request<MonMonNodeReply>("GET", `/api/v1/mon/node/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 更新mon节点
+   *
+   * 本接口用于更新指定ID的mon节点
+   *
+   * PUT /api/v1/mon/node/{id}
+   */
+  updateMonNode(id: number, body: MonCreateOrUpdateMonNodeRequest): Promise<MonMonNodeReply> {
+    return this.request<MonMonNodeReply>("PUT", `/api/v1/mon/node/${encodeURIComponent(String(id))}`, { body });
+  }
+
+  /**
+   * 删除mon节点
+   *
+   * 本接口用于删除指定ID的mon节点
+   *
+   * DELETE /api/v1/mon/node/{id}
+   */
+  deleteMonNode(id: number): Promise<CommonMapAPIReply> {
+    return this.request<CommonMapAPIReply>("DELETE", `/api/v1/mon/node/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 查询mon节点主机指标
+   *
+   * 本接口用于查询mon节点所在主机的CPU、内存、磁盘使用率和平均负载, 用于绘制图表; 指标由主机代理上报或通过SSH采集, 不需要配置Prometheus数据源
+   *
+   * GET /api/v1/mon/node/{id}/metrics
+   */
+  getMonNodeMetrics(id: number, params?: GetMonNodeMetricsParams): Promise<MonHostMetricSeriesReply> {
+    return this.request<MonHostMetricSeriesReply>("GET", `/api/v1/mon/node/${encodeURIComponent(String(id))}/metrics`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 查询mon节点监控数据
+   *
+   * 本接口通过mon节点配置的Prometheus数据源执行PromQL查询，提供start、end、step时执行范围查询
+   *
+   * GET /api/v1/mon/node/{id}/query
+   */
+  getMonNodeQuery(id: number, params: GetMonNodeQueryParams): Promise<MonMonNodeQueryReply> {
+    return this.request<MonMonNodeQueryReply>("GET", `/api/v1/mon/node/${encodeURIComponent(String(id))}/query`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 查询oes集群列表
+   *
+   * 本接口用于查询oes集群列表, 指定export参数时按columns选择的列导出为csv或xlsx文件
+   *
+   * GET /api/v1/oes/colony
+   */
+  listOesColony(params?: ListOesColonyParams): Promise<OesPagOesColonyReply> {
+    return this.request<OesPagOesColonyReply>("GET", `/api/v1/oes/colony`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 创建oes集群
+   *
+   * 本接口用于创建新的oes集群
+   *
+   * POST /api/v1/oes/colony
+   */
+  createOesColony(body: OesCreateOrUpdateOesColonyRequest): Promise<OesOesColonyReply> {
+    return this.request<OesOesColonyReply>("POST", `/api/v1/oes/colony`, { body });
+  }
+
+  /**
+   * 批量更新oes集群
+   *
+   * 本接口用于在同一个事务中批量更新oes集群的启用状态、程序包或mon节点，返回每个集群的执行结果并记录审计日志
+   *
+   * PATCH /api/v1/oes/colony/batch
+   */
+  patchOesColonyBatch(body: OesBatchUpdateOesColonyRequest): Promise<OesBatchOesColonyReply> {
+    return this.request<OesBatchOesColonyReply>("PATCH", `/api/v1/oes/colony/batch`, { body });
+  }
+
+  /**
+   * 查询oes集群导出状态
+   *
+   * 本接口用于查询oes集群导出记录的生成状态
+   *
+   * GET /api/v1/oes/colony/export/{id}
+   */
+  getOesColonyExport(id: number): Promise<OesOesColonyExportReply> {
+    return this.request<OesOesColonyExportReply>("GET", `/api/v1/oes/colony/export/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 下载oes集群导出归档
+   *
+   * 本接口用于下载已生成的oes集群导出归档
+   *
+   * 返回原始响应, 由调用方读取响应体
+   *
+   * GET /api/v1/oes/colony/export/{id}/download
+   */
+  getOesColonyExportDownload(id: number): Promise<Response> {
+    return this.request<Response>("GET", `/api/v1/oes/colony/export/${encodeURIComponent(String(id))}/download`, { raw: true });
+  }
+
+  /**
+   * 查询oes两融集群列表的任务状态
+   *
+   * 本接口用于查询oes两融集群列表的任务状态
+   *
+   * GET /api/v1/oes/colony/status/crd
+   */
+  listOesColonyStatusCrd(params?: ListOesColonyStatusCrdParams): Promise<OesListOesTasksInfoReply> {
+    return this.request<OesListOesTasksInfoReply>("GET", `/api/v1/oes/colony/status/crd`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 查询oes期权集群列表的任务状态
+   *
+   * 本接口用于查询oes期权集群列表的任务状态
+   *
+   * GET /api/v1/oes/colony/status/opt
+   */
+  listOesColonyStatusOpt(params?: ListOesColonyStatusOptParams): Promise<OesListOesTasksInfoReply> {
+    return this.request<OesListOesTasksInfoReply>("GET", `/api/v1/oes/colony/status/opt`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 查询oes现货集群列表的任务状态
+   *
+   * 本接口用于查询oes现货集群列表的任务状态
+   *
+   * GET /api/v1/oes/colony/status/stk
+   */
+  listOesColonyStatusStk(params?: ListOesColonyStatusStkParams): Promise<OesListOesTasksInfoReply> {
+    return this.request<OesListOesTasksInfoReply>("GET", `/api/v1/oes/colony/status/stk`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 查询oes集群详情
+   *
+   * 本接口用于查询指定ID的oes集群详情
+   *
+   * GET /api/v1/oes/colony/{id}
+   */
+  getOesColony(id: number): Promise<OesOesColonyReply> {
+    return this.request<OesOesColonyReply>("GET", `/api/v1/oes/colony/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 更新oes集群
+   *
+   * 本接口用于更新指定ID的oes集群
+   *
+   * PUT /api/v1/oes/colony/{id}
+   */
+  updateOesColony(id: number, body: OesCreateOrUpdateOesColonyRequest): Promise<OesOesColonyReply> {
+    return this.request<OesOesColonyReply>("PUT", `/api/v1/oes/colony/${encodeURIComponent(String(id))}`, { body });
+  }
+
+  /**
+   * 删除oes集群
+   *
+   * 本接口用于删除指定ID的oes集群
+   *
+   * DELETE /api/v1/oes/colony/{id}
+   */
+  deleteOesColony(id: number): Promise<CommonMapAPIReply> {
+    return this.request<CommonMapAPIReply>("DELETE", `/api/v1/oes/colony/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 查询集群配置漂移
+   *
+   * 本接口返回集群最近一次检测发现的配置漂移，包括配置文件与模板渲染结果不一致以及程序包版本与集群记录不一致
+   *
+   * GET /api/v1/oes/colony/{id}/drift
+   */
+  getOesColonyDrift(id: number): Promise<OesOesColonyDriftReply> {
+    return this.request<OesOesColonyDriftReply>("GET", `/api/v1/oes/colony/${encodeURIComponent(String(id))}/drift`);
+  }
+
+  /**
+   * 立即检测集群配置漂移
+   *
+   * 本接口立即检测集群的配置漂移并保存结果，出现新的漂移时发出告警
+   *
+   * POST /api/v1/oes/colony/{id}/drift/check
+   */
+  createOesColonyDriftCheck(id: number): Promise<OesOesColonyDriftReply> {
+    return this.request<OesOesColonyDriftReply>("POST", `/api/v1/oes/colony/${encodeURIComponent(String(id))}/drift/check`);
+  }
+
+  /**
+   * 导出oes集群运维历史
+   *
+   * 本接口用于异步导出oes集群的配置、节点部署、配置文件、任务执行记录及日志，返回导出记录，完成后通过下载地址获取归档
+   *
+   * POST /api/v1/oes/colony/{id}/export
+   */
+  createOesColonyExport(id: number): Promise<OesOesColonyExportReply> {
+    return this.request<OesOesColonyExportReply>("POST", `/api/v1/oes/colony/${encodeURIComponent(String(id))}/export`);
+  }
+
+  /**
+   * 查询集群柜台数据对账报告列表
+   *
+   * 本接口分页查询集群每个交易日的柜台数据对账报告，报告统计下发到各节点的数据相对柜台数据缺少、多余和内容不一致的记录数
+   *
+   * GET /api/v1/oes/colony/{id}/reconciliation
+   */
+  listOesColonyReconciliation(id: number, params?: ListOesColonyReconciliationParams): Promise<OesPagOesReconcileReportReply> {
+    return this.request<OesPagOesReconcileReportReply>("GET", `/api/v1/oes/colony/${encodeURIComponent(String(id))}/reconciliation`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 立即对账集群柜台数据
+   *
+   * 本接口立即比较counter_fetch.sh获取的柜台数据与counter_distribute.sh下发到各节点的数据并保存报告，替换该交易日已有的报告
+   *
+   * POST /api/v1/oes/colony/{id}/reconciliation
+   */
+  createOesColonyReconciliation(id: number, body: OesReconcileColonyRequest): Promise<OesOesReconcileReportReply> {
+    return this.request<OesOesReconcileReportReply>("POST", `/api/v1/oes/colony/${encodeURIComponent(String(id))}/reconciliation`, { body });
+  }
+
+  /**
+   * 查询集群指定交易日的柜台数据对账报告
+   *
+   * 本接口查询集群指定交易日的柜台数据对账报告及每个节点上每个数据文件的对账结果
+   *
+   * GET /api/v1/oes/colony/{id}/reconciliation/{trading_day}
+   */
+  getOesColonyReconciliation(id: number, tradingDay: string): Promise<OesOesReconcileReportReply> {
+    return this.request<OesOesReconcileReportReply>("GET", `/api/v1/oes/colony/${encodeURIComponent(String(id))}/reconciliation/${encodeURIComponent(String(tradingDay))}`);
+  }
+
+  /**
+   * 为集群发起检查单
+   *
+   * 本接口用于为集群发起检查单，第一个步骤立即开始，同一集群同一检查单同时只允许一个未结束的执行
+   *
+   * POST /api/v1/oes/colony/{id}/runbook
+   */
+  createOesColonyRunbook(id: number, body: OesStartOesRunbookRequest): Promise<OesOesRunbookExecReply> {
+    return this.request<OesOesRunbookExecReply>("POST", `/api/v1/oes/colony/${encodeURIComponent(String(id))}/runbook`, { body });
+  }
+
+  /**
+   * 查询oes集群拓扑图
+   *
+   * 本接口用于一次性查询oes集群及其程序包、xcounter、mon节点、oes节点、主机和最近任务状态，以节点和连线的形式返回
+   *
+   * GET /api/v1/oes/colony/{id}/topology
+   */
+  getOesColonyTopology(id: number): Promise<OesOesColonyTopologyReply> {
+    return this.request<OesOesColonyTopologyReply>("GET", `/api/v1/oes/colony/${encodeURIComponent(String(id))}/topology`);
+  }
+
+  /**
+   * 发起oes任务编排
+   *
+   * 本接口用于按集群系统类型的编排定义发起日常任务, 依赖步骤成功后自动执行下游步骤
+   *
+   * POST /api/v1/oes/colony/{id}/workflow
+   */
+  createOesColonyWorkflow(id: number): Promise<OesOesWorkflowRunReply> {
+    return this.request<OesOesWorkflowRunReply>("POST", `/api/v1/oes/colony/${encodeURIComponent(String(id))}/workflow`);
+  }
+
+  /**
+   * 查询配置模板列表
+   *
+   * 本接口用于查询oes配置文件模板列表，支持按名称和适用节点目录过滤
+   *
+   * GET /api/v1/oes/conf/template
+   */
+  listOesConfTemplate(params?: ListOesConfTemplateParams): Promise<OesPagOesConfTemplateReply> {
+    return this.request<OesPagOesConfTemplateReply>("GET", `/api/v1/oes/conf/template`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 创建配置模板
+   *
+   * 本接口用于创建oes配置文件模板，内容和目标路径使用Go模板语法。
+   * 可用变量：.Colony(ID/SystemType/ColonyNum/ExtractedName/Version/XCounterVersion/MonNodeName/MonNodeURL)，
+   * .Node(ID/NodeRole/Specdir/HostID/HostName/SSHIP/SSHPort/SSHUser)，.Nodes为集群全部启用节点；可用函数add、mul、int。
+   *
+   * POST /api/v1/oes/conf/template
+   */
+  createOesConfTemplate(body: OesOesConfTemplateRequest): Promise<OesOesConfTemplateReply> {
+    return this.request<OesOesConfTemplateReply>("POST", `/api/v1/oes/conf/template`, { body });
+  }
+
+  /**
+   * 查询配置模板详情
+   *
+   * 本接口用于查询指定ID的oes配置文件模板
+   *
+   * GET /api/v1/oes/conf/template/{id}
+   */
+  getOesConfTemplate(id: number): Promise<OesOesConfTemplateReply> {
+    return this.request<OesOesConfTemplateReply>("GET", `/api/v1/oes/conf/template/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 更新配置模板
+   *
+   * 本接口用于更新指定ID的oes配置文件模板
+   *
+   * PUT /api/v1/oes/conf/template/{id}
+   */
+  updateOesConfTemplate(id: number, body: OesOesConfTemplateRequest): Promise<OesOesConfTemplateReply> {
+    return this.request<OesOesConfTemplateReply>("PUT", `/api/v1/oes/conf/template/${encodeURIComponent(String(id))}`, { body });
+  }
+
+  /**
+   * 删除配置模板
+   *
+   * 本接口用于删除指定ID的oes配置文件模板，不影响已下发的文件
+   *
+   * DELETE /api/v1/oes/conf/template/{id}
+   */
+  deleteOesConfTemplate(id: number): Promise<CommonMapAPIReply> {
+    return this.request<CommonMapAPIReply>("DELETE", `/api/v1/oes/conf/template/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 下发配置文件
+   *
+   * 本接口使用指定集群的记录渲染模板并通过SFTP写入目标节点主机，内容未变化的节点不写入。
+   * 全部节点渲染成功后才开始下发，单个节点下发失败时在结果中返回错误，不影响其他节点。
+   *
+   * POST /api/v1/oes/conf/template/{id}/deploy
+   */
+  createOesConfTemplateDeploy(id: number, body: OesDeployOesConfTemplateRequest): Promise<OesOesConfDeployReply> {
+    return this.request<OesOesConfDeployReply>("POST", `/api/v1/oes/conf/template/${encodeURIComponent(String(id))}/deploy`, { body });
+  }
+
+  /**
+   * 预览配置模板渲染结果
+   *
+   * 本接口使用指定集群的记录渲染模板，返回每个目标节点的渲染内容及与主机上当前文件的差异(unified格式)，不修改主机文件
+   *
+   * POST /api/v1/oes/conf/template/{id}/render
+   */
+  createOesConfTemplateRender(id: number, body: OesRenderOesConfTemplateRequest): Promise<OesOesConfRenderReply> {
+    return this.request<OesOesConfRenderReply>("POST", `/api/v1/oes/conf/template/${encodeURIComponent(String(id))}/render`, { body });
+  }
+
+  /**
+   * 查询链路列表
+   *
+   * 本接口用于查询链路列表，可按status=unreachable查询不可达的链路
+   *
+   * GET /api/v1/oes/link
+   */
+  listOesLink(params?: ListOesLinkParams): Promise<OesPagOesLinkReply> {
+    return this.request<OesPagOesLinkReply>("GET", `/api/v1/oes/link`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 创建链路
+   *
+   * 本接口用于定义集群组件之间期望可达的TCP链路，例如节点到XCounter端口、节点到交易所网关，源主机ID为0时由平台探测
+   *
+   * POST /api/v1/oes/link
+   */
+  createOesLink(body: OesOesLinkRequest): Promise<OesOesLinkReply> {
+    return this.request<OesOesLinkReply>("POST", `/api/v1/oes/link`, { body });
+  }
+
+  /**
+   * 查询链路矩阵
+   *
+   * 本接口用于按探测发起方(行)和目标(列)汇总启用链路的最近一次探测结果和连接耗时
+   *
+   * GET /api/v1/oes/link/matrix
+   */
+  getOesLinkMatrix(params?: GetOesLinkMatrixParams): Promise<OesOesLinkMatrixReply> {
+    return this.request<OesOesLinkMatrixReply>("GET", `/api/v1/oes/link/matrix`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 查询链路详情
+   *
+   * 本接口用于查询指定ID的链路和最近一次探测结果
+   *
+   * GET /api/v1/oes/link/{id}
+   */
+  getOesLink(id: number): Promise<OesOesLinkReply> {
+    return this.request<OesOesLinkReply>("GET", `/api/v1/oes/link/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 更新链路
+   *
+   * 本接口用于更新指定ID的链路，源主机或目标变化时探测状态重置为未探测
+   *
+   * PUT /api/v1/oes/link/{id}
+   */
+  updateOesLink(id: number, body: OesOesLinkRequest): Promise<OesOesLinkReply> {
+    return this.request<OesOesLinkReply>("PUT", `/api/v1/oes/link/${encodeURIComponent(String(id))}`, { body });
+  }
+
+  /**
+   * 删除链路
+   *
+   * 本接口用于删除指定ID的链路及其探测记录
+   *
+   * DELETE /api/v1/oes/link/{id}
+   */
+  deleteOesLink(id: number): Promise<CommonMapAPIReply> {
+    return this.request<CommonMapAPIReply>("DELETE", `/api/v1/oes/link/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 查询链路探测记录
+   *
+   * 本接口用于查询指定ID的链路的探测记录和连接耗时，按时间倒序
+   *
+   * GET /api/v1/oes/link/{id}/probe
+   */
+  listOesLinkProbe(id: number, params?: ListOesLinkProbeParams): Promise<OesPagOesLinkProbeReply> {
+    return this.request<OesPagOesLinkProbeReply>("GET", `/api/v1/oes/link/${encodeURIComponent(String(id))}/probe`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 立即探测链路
+   *
+   * 本接口用于立即探测指定ID的链路，与定时探测相同，变为不可达时发出告警
+   *
+   * POST /api/v1/oes/link/{id}/probe
+   */
+  createOesLinkProbe(id: number): Promise<OesOesLinkReply> {
+    return this.request<OesOesLinkReply>("POST", `/api/v1/oes/link/${encodeURIComponent(String(id))}/probe`);
+  }
+
+  /**
+   * 查询oes节点列表
+   *
+   * 本接口用于查询oes节点列表
+   *
+   * GET /api/v1/oes/node
+   */
+  listOesNode(params?: ListOesNodeParams): Promise<OesPagOesNodeReply> {
+    return this.request<OesPagOesNodeReply>("GET", `/api/v1/oes/node`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 创建oes节点
+   *
+   * 本接口用于创建新的oes节点
+   *
+   * POST /api/v1/oes/node
+   */
+  createOesNode(body: OesCreateOrUpdateOesNodeRequest): Promise<OesOesNodeReply> {
+    return this.request<OesOesNodeReply>("POST", `/api/v1/oes/node`, { body });
+  }
+
+  /**
+   * 查询oes节点详情
+   *
+   * 本接口用于查询指定ID的oes节点详情
+   *
+   * GET /api/v1/oes/node/{id}
+   */
+  getOesNode(id: number): Promise<OesOesNodeReply> {
+    return this.request<OesOesNodeReply>("GET", `/api/v1/oes/node/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 更新oes节点
+   *
+   * 本接口用于更新指定ID的oes节点
+   *
+   * PUT /api/v1/oes/node/{id}
+   */
+  updateOesNode(id: number, body: OesCreateOrUpdateOesNodeRequest): Promise<OesOesNodeReply> {
+    return this.request<OesOesNodeReply>("PUT", `/api/v1/oes/node/${encodeURIComponent(String(id))}`, { body });
+  }
+
+  /**
+   * 删除oes节点
+   *
+   * 本接口用于删除指定ID的oes节点
+   *
+   * DELETE /api/v1/oes/node/{id}
+   */
+  deleteOesNode(id: number): Promise<CommonMapAPIReply> {
+    return this.request<CommonMapAPIReply>("DELETE", `/api/v1/oes/node/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 查询检查单列表
+   *
+   * 本接口用于查询检查单列表，支持按名称、适用的系统类型和启用状态过滤
+   *
+   * GET /api/v1/oes/runbook
+   */
+  listOesRunbook(params?: ListOesRunbookParams): Promise<OesPagOesRunbookReply> {
+    return this.request<OesPagOesRunbookReply>("GET", `/api/v1/oes/runbook`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 创建检查单
+   *
+   * 本接口用于创建oes运维检查单，步骤按顺序执行，人工步骤由操作员确认完成，脚本步骤以集群号为参数执行oes的内置命令脚本
+   *
+   * POST /api/v1/oes/runbook
+   */
+  createOesRunbook(body: OesOesRunbookRequest): Promise<OesOesRunbookReply> {
+    return this.request<OesOesRunbookReply>("POST", `/api/v1/oes/runbook`, { body });
+  }
+
+  /**
+   * 查询检查单执行列表
+   *
+   * 本接口用于分页查询检查单执行及其进度
+   *
+   * GET /api/v1/oes/runbook/exec
+   */
+  listOesRunbookExec(params?: ListOesRunbookExecParams): Promise<OesPagOesRunbookExecReply> {
+    return this.request<OesPagOesRunbookExecReply>("GET", `/api/v1/oes/runbook/exec`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 查询检查单执行详情
+   *
+   * 本接口用于查询检查单执行的进度及每个步骤的状态、操作员签核和脚本执行记录
+   *
+   * GET /api/v1/oes/runbook/exec/{id}
+   */
+  getOesRunbookExec(id: number): Promise<OesOesRunbookExecReply> {
+    return this.request<OesOesRunbookExecReply>("GET", `/api/v1/oes/runbook/exec/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 终止检查单执行
+   *
+   * 本接口用于终止未结束的检查单执行，未完成的步骤标记为跳过，脚本步骤执行中时不能终止
+   *
+   * POST /api/v1/oes/runbook/exec/{id}/abort
+   */
+  createOesRunbookExecAbort(id: number): Promise<OesOesRunbookExecReply> {
+    return this.request<OesOesRunbookExecReply>("POST", `/api/v1/oes/runbook/exec/${encodeURIComponent(String(id))}/abort`);
+  }
+
+  /**
+   * 确认检查单人工步骤
+   *
+   * 本接口用于操作员签核当前等待确认的人工步骤，确认后检查单进入下一步
+   *
+   * POST /api/v1/oes/runbook/exec/{id}/step/{step_id}/confirm
+   */
+  createOesRunbookExecStepConfirm(id: number, stepID: number, body: OesSignOffRunbookStepRequest): Promise<OesOesRunbookExecReply> {
+    return this.request<OesOesRunbookExecReply>("POST", `/api/v1/oes/runbook/exec/${encodeURIComponent(String(id))}/step/${encodeURIComponent(String(stepID))}/confirm`, { body });
+  }
+
+  /**
+   * 重试检查单脚本步骤
+   *
+   * 本接口用于操作员重新执行失败的脚本步骤，脚本在后台执行，成功后检查单进入下一步
+   *
+   * POST /api/v1/oes/runbook/exec/{id}/step/{step_id}/retry
+   */
+  createOesRunbookExecStepRetry(id: number, stepID: number, body: OesSignOffRunbookStepRequest): Promise<OesOesRunbookExecReply> {
+    return this.request<OesOesRunbookExecReply>("POST", `/api/v1/oes/runbook/exec/${encodeURIComponent(String(id))}/step/${encodeURIComponent(String(stepID))}/retry`, { body });
+  }
+
+  /**
+   * 查询检查单详情
+   *
+   * 本接口用于查询指定ID的检查单及其步骤
+   *
+   * GET /api/v1/oes/runbook/{id}
+   */
+  getOesRunbook(id: number): Promise<OesOesRunbookReply> {
+    return this.request<OesOesRunbookReply>("GET", `/api/v1/oes/runbook/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 更新检查单
+   *
+   * 本接口用于更新指定ID的检查单并替换全部步骤，不影响进行中的执行
+   *
+   * PUT /api/v1/oes/runbook/{id}
+   */
+  updateOesRunbook(id: number, body: OesOesRunbookRequest): Promise<OesOesRunbookReply> {
+    return this.request<OesOesRunbookReply>("PUT", `/api/v1/oes/runbook/${encodeURIComponent(String(id))}`, { body });
+  }
+
+  /**
+   * 删除检查单
+   *
+   * 本接口用于删除指定ID的检查单，不影响已发起的执行
+   *
+   * DELETE /api/v1/oes/runbook/{id}
+   */
+  deleteOesRunbook(id: number): Promise<CommonMapAPIReply> {
+    return this.request<CommonMapAPIReply>("DELETE", `/api/v1/oes/runbook/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 查询任务SLA列表
+   *
+   * 本接口用于查询任务SLA列表，支持按任务名称、集群和启用状态过滤
+   *
+   * GET /api/v1/oes/sla
+   */
+  listOesSla(params?: ListOesSlaParams): Promise<OesPagOesTaskSlaReply> {
+    return this.request<OesPagOesTaskSlaReply>("GET", `/api/v1/oes/sla`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 创建任务SLA
+   *
+   * 本接口用于创建oes日常任务的SLA，定义任务每个交易日的完成截止时间，集群ID为0时适用全部集群
+   *
+   * POST /api/v1/oes/sla
+   */
+  createOesSla(body: OesOesTaskSlaRequest): Promise<OesOesTaskSlaReply> {
+    return this.request<OesOesTaskSlaReply>("POST", `/api/v1/oes/sla`, { body });
+  }
+
+  /**
+   * 立即检查任务SLA
+   *
+   * 本接口用于立即检查全部启用集群当天日常任务的SLA并返回当天的达成情况，非交易日不检查
+   *
+   * POST /api/v1/oes/sla/check
+   */
+  createOesSlaCheck(): Promise<OesTaskSlaComplianceReply> {
+    return this.request<OesTaskSlaComplianceReply>("POST", `/api/v1/oes/sla/check`);
+  }
+
+  /**
+   * 查询每日SLA达成情况
+   *
+   * 本接口用于查询交易日各集群日常任务的SLA检查结果，统计按时完成、延迟完成、已超时和即将超时的任务数及达成率
+   *
+   * GET /api/v1/oes/sla/compliance
+   */
+  getOesSlaCompliance(params?: GetOesSlaComplianceParams): Promise<OesTaskSlaComplianceReply> {
+    return this.request<OesTaskSlaComplianceReply>("GET", `/api/v1/oes/sla/compliance`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 查询任务SLA详情
+   *
+   * 本接口用于查询指定ID的任务SLA
+   *
+   * GET /api/v1/oes/sla/{id}
+   */
+  getOesSla(id: number): Promise<OesOesTaskSlaReply> {
+    return this.request<OesOesTaskSlaReply>("GET", `/api/v1/oes/sla/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 更新任务SLA
+   *
+   * 本接口用于更新指定ID的任务SLA，下次检查时生效
+   *
+   * PUT /api/v1/oes/sla/{id}
+   */
+  updateOesSla(id: number, body: OesOesTaskSlaRequest): Promise<OesOesTaskSlaReply> {
+    return this.request<OesOesTaskSlaReply>("PUT", `/api/v1/oes/sla/${encodeURIComponent(String(id))}`, { body });
+  }
+
+  /**
+   * 删除任务SLA
+   *
+   * 本接口用于删除指定ID的任务SLA，已保存的检查结果保留
+   *
+   * DELETE /api/v1/oes/sla/{id}
+   */
+  deleteOesSla(id: number): Promise<CommonMapAPIReply> {
+    return this.request<CommonMapAPIReply>("DELETE", `/api/v1/oes/sla/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 查询日常任务目录
+   *
+   * 本接口用于查询日常任务目录，支持按系统类型、任务名称和启用状态过滤，按系统类型和排序顺序返回
+   *
+   * GET /api/v1/oes/task/catalog
+   */
+  listOesTaskCatalog(params?: ListOesTaskCatalogParams): Promise<OesPagOesTaskCatalogReply> {
+    return this.request<OesPagOesTaskCatalogReply>("GET", `/api/v1/oes/task/catalog`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 创建日常任务
+   *
+   * 本接口用于在日常任务目录中新增系统类型的集群需要执行的任务，任务名称同时是集群任务标识文件的名称
+   *
+   * POST /api/v1/oes/task/catalog
+   */
+  createOesTaskCatalog(body: OesOesTaskCatalogRequest): Promise<OesOesTaskCatalogReply> {
+    return this.request<OesOesTaskCatalogReply>("POST", `/api/v1/oes/task/catalog`, { body });
+  }
+
+  /**
+   * 查询日常任务详情
+   *
+   * 本接口用于查询日常任务目录中指定ID的任务
+   *
+   * GET /api/v1/oes/task/catalog/{id}
+   */
+  getOesTaskCatalog(id: number): Promise<OesOesTaskCatalogReply> {
+    return this.request<OesOesTaskCatalogReply>("GET", `/api/v1/oes/task/catalog/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 更新日常任务
+   *
+   * 本接口用于更新日常任务目录中指定ID的任务，下次查询任务状态和检查SLA时生效
+   *
+   * PUT /api/v1/oes/task/catalog/{id}
+   */
+  updateOesTaskCatalog(id: number, body: OesOesTaskCatalogRequest): Promise<OesOesTaskCatalogReply> {
+    return this.request<OesOesTaskCatalogReply>("PUT", `/api/v1/oes/task/catalog/${encodeURIComponent(String(id))}`, { body });
+  }
+
+  /**
+   * 删除日常任务
+   *
+   * 本接口用于从日常任务目录中删除指定ID的任务，已定义的SLA不再检查
+   *
+   * DELETE /api/v1/oes/task/catalog/{id}
+   */
+  deleteOesTaskCatalog(id: number): Promise<CommonMapAPIReply> {
+    return this.request<CommonMapAPIReply>("DELETE", `/api/v1/oes/task/catalog/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 查询oes任务编排执行记录列表
+   *
+   * 本接口用于分页查询oes任务编排执行记录
+   *
+   * GET /api/v1/oes/workflow/run
+   */
+  listOesWorkflowRun(params?: ListOesWorkflowRunParams): Promise<OesPagOesWorkflowRunReply> {
+    return this.request<OesPagOesWorkflowRunReply>("GET", `/api/v1/oes/workflow/run`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 查询oes任务编排执行详情
+   *
+   * 本接口用于查询编排执行记录及各步骤的状态和耗时
+   *
+   * GET /api/v1/oes/workflow/run/{id}
+   */
+  getOesWorkflowRun(id: number): Promise<OesOesWorkflowRunReply> {
+    return this.request<OesOesWorkflowRunReply>("GET", `/api/v1/oes/workflow/run/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 查询oes任务编排定义
+   *
+   * 本接口用于查询指定系统类型的日常任务步骤及依赖关系
+   *
+   * GET /api/v1/oes/workflow/{system_type}
+   */
+  getOesWorkflow(systemType: string): Promise<OesOesWorkflowDefinitionReply> {
+    return this.request<OesOesWorkflowDefinitionReply>("GET", `/api/v1/oes/workflow/${encodeURIComponent(String(systemType))}`);
+  }
+
+  /**
+   * 获取oes配置文件列表
+   *
+   * 获取指定目录下的oes配置文件列表
+   *
+   * GET /api/v1/oes/{colony_num}/conf
+   */
+  listOesConf(colonyNum: string): Promise<OesPagOesConfReply> {
+    return this.request<OesPagOesConfReply>("GET", `/api/v1/oes/${encodeURIComponent(String(colonyNum))}/conf`);
+  }
+
+  /**
+   * 上传oes配置文件
+   *
+   * 上传oes配置文件到指定目录
+   *
+   * POST /api/v1/oes/{colony_num}/conf/{dir_name}
+   */
+  createOesConf(colonyNum: string, dirName: string, form: CreateOesConfForm): Promise<CommonMapAPIReply> {
+    return this.request<CommonMapAPIReply>("POST", `/api/v1/oes/${encodeURIComponent(String(colonyNum))}/conf/${encodeURIComponent(String(dirName))}`, { form: form as unknown as Record<string, unknown> });
+  }
+
+  /**
+   * 下载oes配置文件
+   *
+   * 下载指定的oes配置文件
+   *
+   * 返回原始响应, 由调用方读取响应体
+   *
+   * GET /api/v1/oes/{colony_num}/conf/{dir_name}/{filename}
+   */
+  getOesConf(colonyNum: string, dirName: string, filename: string): Promise<Response> {
+    return this.request<Response>("GET", `/api/v1/oes/${encodeURIComponent(String(colonyNum))}/conf/${encodeURIComponent(String(dirName))}/${encodeURIComponent(String(filename))}`, { raw: true });
+  }
+
+  /**
+   * 删除oes配置文件
+   *
+   * 删除指定的oes配置文件
+   *
+   * DELETE /api/v1/oes/{colony_num}/conf/{dir_name}/{filename}
+   */
+  deleteOesConf(colonyNum: string, dirName: string, filename: string): Promise<CommonMapAPIReply> {
+    return this.request<CommonMapAPIReply>("DELETE", `/api/v1/oes/${encodeURIComponent(String(colonyNum))}/conf/${encodeURIComponent(String(dirName))}/${encodeURIComponent(String(filename))}`);
+  }
+
+  /**
+   * 刷新令牌接口
+   *
+   * 本接口用于刷新令牌, 开启Cookie认证时请求参数可以为空, 从Cookie中读取刷新令牌
+   *
+   * POST /api/v1/refresh/token
+   */
+  createRefreshToken(body: CustomerRefreshTokenRequest): Promise<CustomerLoginReply> {
+    return this.request<CustomerLoginReply>("POST", `/api/v1/refresh/token`, { body });
+  }
+
+  /**
+   * 查询主机路径规则列表
+   *
+   * 本接口用于查询主机路径规则列表，支持按角色过滤
+   *
+   * GET /api/v1/resource/file/rule
+   */
+  listResourceFileRule(params?: ListResourceFileRuleParams): Promise<ResourcePagHostPathRuleReply> {
+    return this.request<ResourcePagHostPathRuleReply>("GET", `/api/v1/resource/file/rule`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 创建主机路径规则
+   *
+   * 本接口用于为角色配置允许通过文件管理访问的主机目录，规则对全部主机生效
+   *
+   * POST /api/v1/resource/file/rule
+   */
+  createResourceFileRule(body: ResourceHostPathRuleRequest): Promise<ResourceHostPathRuleReply> {
+    return this.request<ResourceHostPathRuleReply>("POST", `/api/v1/resource/file/rule`, { body });
+  }
+
+  /**
+   * 更新主机路径规则
+   *
+   * 本接口用于更新指定ID的主机路径规则
+   *
+   * PUT /api/v1/resource/file/rule/{id}
+   */
+  updateResourceFileRule(id: number, body: ResourceHostPathRuleRequest): Promise<ResourceHostPathRuleReply> {
+    return this.request<ResourceHostPathRuleReply>("PUT", `/api/v1/resource/file/rule/${encodeURIComponent(String(id))}`, { body });
+  }
+
+  /**
+   * 删除主机路径规则
+   *
+   * 本接口用于删除指定ID的主机路径规则
+   *
+   * DELETE /api/v1/resource/file/rule/{id}
+   */
+  deleteResourceFileRule(id: number): Promise<CommonMapAPIReply> {
+    return this.request<CommonMapAPIReply>("DELETE", `/api/v1/resource/file/rule/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 查询主机列表
+   *
+   * 本接口用于查询主机配置信息列表, 支持通过filter参数按字段组合过滤
+   *
+   * GET /api/v1/resource/host
+   */
+  listResourceHost(params?: ListResourceHostParams): Promise<ResourcePagHostReply> {
+    return this.request<ResourcePagHostReply>("GET", `/api/v1/resource/host`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 创建主机
+   *
+   * 本接口用于创建新的主机配置信息
+   *
+   * POST /api/v1/resource/host
+   */
+  createResourceHost(body: ResourceCreateOrUpdateHosrRequest): Promise<ResourceHostReply> {
+    return this.request<ResourceHostReply>("POST", `/api/v1/resource/host`, { body });
+  }
+
+  /**
+   * 查询疑似重复的主机
+   *
+   * 本接口用于查找重复登记的主机, 按机器序列号、SSH地址、代理上报的IP地址和主机名匹配, 回环地址和链路本地地址不作为匹配依据
+   *
+   * GET /api/v1/resource/host/duplicates
+   */
+  getResourceHostDuplicates(): Promise<ResourceHostDuplicateReply> {
+    return this.request<ResourceHostDuplicateReply>("GET", `/api/v1/resource/host/duplicates`);
+  }
+
+  /**
+   * 查询主机详情
+   *
+   * 本接口用于查询指定ID的主机详细信息
+   *
+   * GET /api/v1/resource/host/{id}
+   */
+  getResourceHost(id: number): Promise<ResourceHostReply> {
+    return this.request<ResourceHostReply>("GET", `/api/v1/resource/host/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 更新主机
+   *
+   * 本接口用于更新指定ID的主机配置信息
+   *
+   * PUT /api/v1/resource/host/{id}
+   */
+  updateResourceHost(id: number, body: ResourceCreateOrUpdateHosrRequest): Promise<ResourceHostReply> {
+    return this.request<ResourceHostReply>("PUT", `/api/v1/resource/host/${encodeURIComponent(String(id))}`, { body });
+  }
+
+  /**
+   * 删除主机
+   *
+   * 本接口用于删除指定ID的主机配置信息
+   *
+   * DELETE /api/v1/resource/host/{id}
+   */
+  deleteResourceHost(id: number): Promise<CommonMapAPIReply> {
+    return this.request<CommonMapAPIReply>("DELETE", `/api/v1/resource/host/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 列出主机目录
+   *
+   * 本接口用于列出主机上指定目录的内容，目录须在当前角色的主机路径规则允许的范围内
+   *
+   * GET /api/v1/resource/host/{id}/file
+   */
+  listResourceHostFile(id: number, params: ListResourceHostFileParams): Promise<ResourceListHostFileReply> {
+    return this.request<ResourceListHostFileReply>("GET", `/api/v1/resource/host/${encodeURIComponent(String(id))}/file`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 下载主机文件
+   *
+   * 本接口用于下载主机上的文件，下载操作会记录审计日志
+   *
+   * 返回原始响应, 由调用方读取响应体
+   *
+   * GET /api/v1/resource/host/{id}/file/download
+   */
+  getResourceHostFileDownload(id: number, params: GetResourceHostFileDownloadParams): Promise<Response> {
+    return this.request<Response>("GET", `/api/v1/resource/host/${encodeURIComponent(String(id))}/file/download`, { query: params as unknown as Record<string, QueryValue> | undefined, raw: true });
+  }
+
+  /**
+   * 查询主机文件信息
+   *
+   * 本接口用于查询主机上指定文件或目录的信息，路径中的符号链接会被解析
+   *
+   * GET /api/v1/resource/host/{id}/file/stat
+   */
+  getResourceHostFileStat(id: number, params: GetResourceHostFileStatParams): Promise<ResourceHostFileReply> {
+    return this.request<ResourceHostFileReply>("GET", `/api/v1/resource/host/${encodeURIComponent(String(id))}/file/stat`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 上传文件到主机
+   *
+   * 本接口用于上传文件到主机的指定目录，目录须在当前角色允许上传的主机路径规则范围内，上传操作会记录审计日志
+   *
+   * POST /api/v1/resource/host/{id}/file/upload
+   */
+  createResourceHostFileUpload(id: number, form: CreateResourceHostFileUploadForm): Promise<ResourceHostFileReply> {
+    return this.request<ResourceHostFileReply>("POST", `/api/v1/resource/host/${encodeURIComponent(String(id))}/file/upload`, { form: form as unknown as Record<string, unknown> });
+  }
+
+  /**
+   * 合并重复的主机
+   *
+   * 本接口用于将重复主机的集群节点、进程守护、主机代理和历史记录改为引用路径中的主机, 然后删除重复主机, 合并在同一事务中执行并记录审计
+   *
+   * POST /api/v1/resource/host/{id}/merge
+   */
+  createResourceHostMerge(id: number, body: ResourceMergeHostRequest): Promise<ResourceHostMergeReply> {
+    return this.request<ResourceHostMergeReply>("POST", `/api/v1/resource/host/${encodeURIComponent(String(id))}/merge`, { body });
+  }
+
+  /**
+   * 打开网页终端
+   *
+   * 本接口通过WebSocket打开指定主机的交互式终端，使用平台SSH密钥登录。
+   * 浏览器可通过查询参数Authorization或Sec-WebSocket-Protocol传递令牌。
+   * 服务端以二进制帧发送终端输出；客户端发送JSON文本帧，{"type":"input","data":"ls\r"}为键盘输入，{"type":"resize","cols":120,"rows":40}为调整终端大小。
+   * 超过空闲时间无输入或超过最长会话时间后服务端主动断开连接。
+   *
+   * GET /api/v1/resource/host/{id}/terminal
+   */
+  getResourceHostTerminal(id: number, params?: GetResourceHostTerminalParams): Promise<void> {
+    return this.request<void>("GET", `/api/v1/resource/host/${encodeURIComponent(String(id))}/terminal`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 查询程序包列表
+   *
+   * 本接口用于查询程序包列表
+   *
+   * GET /api/v1/resource/package
+   */
+  listResourcePackage(params?: ListResourcePackageParams): Promise<ResourcePagPackageReply> {
+    return this.request<ResourcePagPackageReply>("GET", `/api/v1/resource/package`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 上传程序包
+   *
+   * 上传一个新的程序包文件并创建记录
+   *
+   * POST /api/v1/resource/package
+   */
+  createResourcePackage(form: CreateResourcePackageForm): Promise<ResourcePackageReply> {
+    return this.request<ResourcePackageReply>("POST", `/api/v1/resource/package`, { form: form as unknown as Record<string, unknown> });
+  }
+
+  /**
+   * 创建分片上传会话
+   *
+   * 本接口用于创建程序包分片上传会话，适用于大文件上传和断点续传
+   *
+   * POST /api/v1/resource/package/upload
+   */
+  createResourcePackageUpload(body: ResourceInitPackageUploadRequest): Promise<ResourcePackageUploadReply> {
+    return this.request<ResourcePackageUploadReply>("POST", `/api/v1/resource/package/upload`, { body });
+  }
+
+  /**
+   * 查询分片上传会话
+   *
+   * 本接口用于查询分片上传会话，续传时以返回的已接收字节数作为下一个分片的起始位置
+   *
+   * GET /api/v1/resource/package/upload/{upload_id}
+   */
+  getResourcePackageUpload(uploadID: string): Promise<ResourcePackageUploadReply> {
+    return this.request<ResourcePackageUploadReply>("GET", `/api/v1/resource/package/upload/${encodeURIComponent(String(uploadID))}`);
+  }
+
+  /**
+   * 上传分片
+   *
+   * 本接口用于上传一个分片，请求体为分片内容，offset必须等于已接收的字节数
+   *
+   * PUT /api/v1/resource/package/upload/{upload_id}
+   */
+  updateResourcePackageUpload(uploadID: string, params: UpdateResourcePackageUploadParams): Promise<ResourcePackageUploadReply> {
+    return this.request<ResourcePackageUploadReply>("PUT", `/api/v1/resource/package/upload/${encodeURIComponent(String(uploadID))}`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 取消分片上传
+   *
+   * 本接口用于取消分片上传，删除上传会话和已上传的分片
+   *
+   * DELETE /api/v1/resource/package/upload/{upload_id}
+   */
+  deleteResourcePackageUpload(uploadID: string): Promise<CommonMapAPIReply> {
+    return this.request<CommonMapAPIReply>("DELETE", `/api/v1/resource/package/upload/${encodeURIComponent(String(uploadID))}`);
+  }
+
+  /**
+   * 完成分片上传
+   *
+   * 本接口用于完成分片上传，校验压缩包结构和校验和后创建程序包
+   *
+   * POST /api/v1/resource/package/upload/{upload_id}/complete
+   */
+  createResourcePackageUploadComplete(uploadID: string): Promise<ResourcePackageReply> {
+    return this.request<ResourcePackageReply>("POST", `/api/v1/resource/package/upload/${encodeURIComponent(String(uploadID))}/complete`);
+  }
+
+  /**
+   * 查询程序包详情
+   *
+   * 本接口用于查询指定ID的程序包详细信息
+   *
+   * GET /api/v1/resource/package/{id}
+   */
+  getResourcePackage(id: number): Promise<ResourcePackageReply> {
+    return this.request<ResourcePackageReply>("GET", `/api/v1/resource/package/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 删除程序包
+   *
+   * 本接口用于删除指定ID的程序包
+   *
+   * DELETE /api/v1/resource/package/{id}
+   */
+  deleteResourcePackage(id: number): Promise<CommonMapAPIReply> {
+    return this.request<CommonMapAPIReply>("DELETE", `/api/v1/resource/package/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 下载程序包
+   *
+   * 本接口用于下载指定ID的程序包文件
+   *
+   * 返回原始响应, 由调用方读取响应体
+   *
+   * GET /api/v1/resource/package/{id}/download
+   */
+  getResourcePackageDownload(id: number): Promise<Response> {
+    return this.request<Response>("GET", `/api/v1/resource/package/${encodeURIComponent(String(id))}/download`, { raw: true });
+  }
+
+  /**
+   * 获取程序包下载地址
+   *
+   * 本接口用于获取指定ID的程序包下载地址，存储后端支持时返回预签名地址
+   *
+   * GET /api/v1/resource/package/{id}/download-url
+   */
+  getResourcePackageDownloadURL(id: number): Promise<ResourcePackageDownloadURLReply> {
+    return this.request<ResourcePackageDownloadURLReply>("GET", `/api/v1/resource/package/${encodeURIComponent(String(id))}/download-url`);
+  }
+
+  /**
+   * 查询终端会话记录列表
+   *
+   * 本接口用于查询网页终端会话记录列表，支持按主机和操作用户过滤
+   *
+   * GET /api/v1/resource/terminal
+   */
+  listResourceTerminal(params?: ListResourceTerminalParams): Promise<ResourcePagTerminalSessionReply> {
+    return this.request<ResourcePagTerminalSessionReply>("GET", `/api/v1/resource/terminal`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 下载终端会话录像
+   *
+   * 本接口用于下载网页终端会话录像，格式为asciinema v2，可使用asciinema play回放
+   *
+   * 返回原始响应, 由调用方读取响应体
+   *
+   * GET /api/v1/resource/terminal/{id}/record
+   */
+  getResourceTerminalRecord(id: number): Promise<Response> {
+    return this.request<Response>("GET", `/api/v1/resource/terminal/${encodeURIComponent(String(id))}/record`, { raw: true });
+  }
+
+  /**
+   * 查询进程守护列表
+   *
+   * 本接口用于查询进程守护列表，可按status=failed查询不符合期望状态的进程
+   *
+   * GET /api/v1/resource/watchdog
+   */
+  listResourceWatchdog(params?: ListResourceWatchdogParams): Promise<ResourcePagWatchdogReply> {
+    return this.request<ResourcePagWatchdogReply>("GET", `/api/v1/resource/watchdog`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 创建进程守护
+   *
+   * 本接口用于为主机配置需要守护的进程或systemd服务，定时检查是否处于期望状态，期望运行但未运行时按重启策略重启并告警
+   *
+   * POST /api/v1/resource/watchdog
+   */
+  createResourceWatchdog(body: ResourceWatchdogRequest): Promise<ResourceWatchdogReply> {
+    return this.request<ResourceWatchdogReply>("POST", `/api/v1/resource/watchdog`, { body });
+  }
+
+  /**
+   * 查询进程守护详情
+   *
+   * 本接口用于查询指定ID的进程守护和最近一次检查结果
+   *
+   * GET /api/v1/resource/watchdog/{id}
+   */
+  getResourceWatchdog(id: number): Promise<ResourceWatchdogReply> {
+    return this.request<ResourceWatchdogReply>("GET", `/api/v1/resource/watchdog/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 更新进程守护
+   *
+   * 本接口用于更新指定ID的进程守护，检查状态保留到下一次检查
+   *
+   * PUT /api/v1/resource/watchdog/{id}
+   */
+  updateResourceWatchdog(id: number, body: ResourceWatchdogRequest): Promise<ResourceWatchdogReply> {
+    return this.request<ResourceWatchdogReply>("PUT", `/api/v1/resource/watchdog/${encodeURIComponent(String(id))}`, { body });
+  }
+
+  /**
+   * 删除进程守护
+   *
+   * 本接口用于删除指定ID的进程守护及其历史
+   *
+   * DELETE /api/v1/resource/watchdog/{id}
+   */
+  deleteResourceWatchdog(id: number): Promise<CommonMapAPIReply> {
+    return this.request<CommonMapAPIReply>("DELETE", `/api/v1/resource/watchdog/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 立即检查进程守护
+   *
+   * 本接口用于立即检查指定ID的进程守护，与定时检查相同，不符合期望状态时按重启策略重启
+   *
+   * POST /api/v1/resource/watchdog/{id}/check
+   */
+  createResourceWatchdogCheck(id: number): Promise<ResourceWatchdogReply> {
+    return this.request<ResourceWatchdogReply>("POST", `/api/v1/resource/watchdog/${encodeURIComponent(String(id))}/check`);
+  }
+
+  /**
+   * 查询进程守护历史
+   *
+   * 本接口用于查询指定ID的进程守护的状态变化和重启记录，按时间倒序
+   *
+   * GET /api/v1/resource/watchdog/{id}/event
+   */
+  listResourceWatchdogEvent(id: number, params?: ListResourceWatchdogEventParams): Promise<ResourcePagWatchdogEventReply> {
+    return this.request<ResourcePagWatchdogEventReply>("GET", `/api/v1/resource/watchdog/${encodeURIComponent(String(id))}/event`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 跨模块搜索
+   *
+   * 本接口用于按名称和描述同时搜索用户、集群、程序包、脚本和菜单, 结果按资源类型分组, 只返回当前角色有权查看列表的资源
+   *
+   * GET /api/v1/search
+   */
+  getSearch(params: GetSearchParams): Promise<SystemSearchReply> {
+    return this.request<SystemSearchReply>("GET", `/api/v1/search`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 最近告警
+   *
+   * 本接口用于查询最近发出的告警, 包括监控节点状态变化和集群配置漂移, 结果会缓存一段时间
+   *
+   * GET /api/v1/stats/alerts
+   */
+  getStatsAlerts(params?: GetStatsAlertsParams): Promise<SystemStatsAlertReply> {
+    return this.request<SystemStatsAlertReply>("GET", `/api/v1/stats/alerts`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 集群启用统计
+   *
+   * 本接口用于查询oes和mds项目启用和停用的集群数, 结果会缓存一段时间
+   *
+   * GET /api/v1/stats/colonies
+   */
+  getStatsColonies(): Promise<SystemStatsColonyReply> {
+    return this.request<SystemStatsColonyReply>("GET", `/api/v1/stats/colonies`);
+  }
+
+  /**
+   * 任务执行统计
+   *
+   * 本接口用于查询最近若干天每天的脚本执行成功和失败数, 结果会缓存一段时间
+   *
+   * GET /api/v1/stats/jobs
+   */
+  getStatsJobs(params?: GetStatsJobsParams): Promise<SystemStatsJobReply> {
+    return this.request<SystemStatsJobReply>("GET", `/api/v1/stats/jobs`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 用户统计
+   *
+   * 本接口用于查询用户总数、已激活用户数和最近活跃的用户数, 活跃天数由配置指定, 结果会缓存一段时间
+   *
+   * GET /api/v1/stats/users
+   */
+  getStatsUsers(): Promise<SystemStatsUserReply> {
+    return this.request<SystemStatsUserReply>("GET", `/api/v1/stats/users`);
+  }
+
+  /**
+   * 清理过期统计事件
+   *
+   * 本接口用于立即清理超过保留天数的统计事件
+   *
+   * POST /api/v1/system/analytics/purge
+   */
+  createSystemAnalyticsPurge(): Promise<CommonMapAPIReply> {
+    return this.request<CommonMapAPIReply>("POST", `/api/v1/system/analytics/purge`);
+  }
+
+  /**
+   * 查询功能使用统计
+   *
+   * 本接口用于按功能、模块或角色聚合查询平台使用情况，不返回用户身份信息
+   *
+   * GET /api/v1/system/analytics/usage
+   */
+  getSystemAnalyticsUsage(params?: GetSystemAnalyticsUsageParams): Promise<SystemAnalyticsUsageReply> {
+    return this.request<SystemAnalyticsUsageReply>("GET", `/api/v1/system/analytics/usage`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 查询审计记录列表
+   *
+   * 本接口用于分页查询数据变更审计记录, 支持通过filter参数按字段组合过滤
+   *
+   * GET /api/v1/system/audit
+   */
+  listSystemAudit(params?: ListSystemAuditParams): Promise<SystemPagAuditRecordReply> {
+    return this.request<SystemPagAuditRecordReply>("GET", `/api/v1/system/audit`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 查询证书监控列表
+   *
+   * 本接口用于查询证书监控列表，可按expire_within_days查询即将过期的证书，结果按过期时间升序排列
+   *
+   * GET /api/v1/system/cert
+   */
+  listSystemCert(params?: ListSystemCertParams): Promise<SystemPagCertMonitorReply> {
+    return this.request<SystemPagCertMonitorReply>("GET", `/api/v1/system/cert`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 创建证书监控
+   *
+   * 本接口用于新增需要监控的TLS端点或证书文件，定时检查证书有效期和证书链，剩余天数到达告警阈值时告警
+   *
+   * POST /api/v1/system/cert
+   */
+  createSystemCert(body: SystemCertMonitorRequest): Promise<SystemCertMonitorReply> {
+    return this.request<SystemCertMonitorReply>("POST", `/api/v1/system/cert`, { body });
+  }
+
+  /**
+   * 查询证书监控详情
+   *
+   * 本接口用于查询指定ID的证书监控和最近一次检查结果
+   *
+   * GET /api/v1/system/cert/{id}
+   */
+  getSystemCert(id: number): Promise<SystemCertMonitorReply> {
+    return this.request<SystemCertMonitorReply>("GET", `/api/v1/system/cert/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 更新证书监控
+   *
+   * 本接口用于更新指定ID的证书监控，更换地址或证书文件时清空上一次的检查结果
+   *
+   * PUT /api/v1/system/cert/{id}
+   */
+  updateSystemCert(id: number, body: SystemCertMonitorRequest): Promise<SystemCertMonitorReply> {
+    return this.request<SystemCertMonitorReply>("PUT", `/api/v1/system/cert/${encodeURIComponent(String(id))}`, { body });
+  }
+
+  /**
+   * 删除证书监控
+   *
+   * 本接口用于删除指定ID的证书监控
+   *
+   * DELETE /api/v1/system/cert/{id}
+   */
+  deleteSystemCert(id: number): Promise<CommonMapAPIReply> {
+    return this.request<CommonMapAPIReply>("DELETE", `/api/v1/system/cert/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 立即检查证书监控
+   *
+   * 本接口用于立即检查指定ID的证书监控，与定时检查相同，到达告警阈值时告警
+   *
+   * POST /api/v1/system/cert/{id}/check
+   */
+  createSystemCertCheck(id: number): Promise<SystemCertMonitorReply> {
+    return this.request<SystemCertMonitorReply>("POST", `/api/v1/system/cert/${encodeURIComponent(String(id))}/check`);
+  }
+
+  /**
+   * 查询部署模式
+   *
+   * 本接口用于查询当前部署模式及各功能的启用状态
+   *
+   * GET /api/v1/system/deploy
+   */
+  getSystemDeploy(): Promise<SystemDeployInfoReply> {
+    return this.request<SystemDeployInfoReply>("GET", `/api/v1/system/deploy`);
+  }
+
+  /**
+   * 查询变更冻结列表
+   *
+   * 本接口用于分页查询变更冻结
+   *
+   * GET /api/v1/system/freeze
+   */
+  listSystemFreeze(params?: ListSystemFreezeParams): Promise<SystemPagChangeFreezeReply> {
+    return this.request<SystemPagChangeFreezeReply>("GET", `/api/v1/system/freeze`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 创建变更冻结
+   *
+   * 本接口用于创建变更冻结, 生效期间拒绝覆盖范围内的修改接口
+   *
+   * POST /api/v1/system/freeze
+   */
+  createSystemFreeze(body: SystemChangeFreezeRequest): Promise<SystemChangeFreezeReply> {
+    return this.request<SystemChangeFreezeReply>("POST", `/api/v1/system/freeze`, { body });
+  }
+
+  /**
+   * 查询当前生效的变更冻结
+   *
+   * 本接口用于查询当前时间生效的全部变更冻结
+   *
+   * GET /api/v1/system/freeze/active
+   */
+  listSystemFreezeActive(): Promise<SystemListChangeFreezeReply> {
+    return this.request<SystemListChangeFreezeReply>("GET", `/api/v1/system/freeze/active`);
+  }
+
+  /**
+   * 查询变更冻结详情
+   *
+   * 本接口用于查询指定ID的变更冻结
+   *
+   * GET /api/v1/system/freeze/{id}
+   */
+  getSystemFreeze(id: number): Promise<SystemChangeFreezeReply> {
+    return this.request<SystemChangeFreezeReply>("GET", `/api/v1/system/freeze/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 更新变更冻结
+   *
+   * 本接口用于更新指定ID的变更冻结
+   *
+   * PUT /api/v1/system/freeze/{id}
+   */
+  updateSystemFreeze(id: number, body: SystemChangeFreezeRequest): Promise<SystemChangeFreezeReply> {
+    return this.request<SystemChangeFreezeReply>("PUT", `/api/v1/system/freeze/${encodeURIComponent(String(id))}`, { body });
+  }
+
+  /**
+   * 删除变更冻结
+   *
+   * 本接口用于删除指定ID的变更冻结
+   *
+   * DELETE /api/v1/system/freeze/{id}
+   */
+  deleteSystemFreeze(id: number): Promise<CommonMapAPIReply> {
+    return this.request<CommonMapAPIReply>("DELETE", `/api/v1/system/freeze/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 批量查询接口
+   *
+   * 本接口用于在一次请求中查询多个GET接口, 各子查询分别鉴权, 子查询失败不影响其他子查询
+   *
+   * POST /api/v1/system/gateway/query
+   */
+  createSystemGatewayQuery(body: SystemGatewayRequest): Promise<SystemGatewayReply> {
+    return this.request<SystemGatewayReply>("POST", `/api/v1/system/gateway/query`, { body });
+  }
+
+  /**
+   * 查询维护窗口列表
+   *
+   * 本接口用于分页查询维护窗口
+   *
+   * GET /api/v1/system/maintenance
+   */
+  listSystemMaintenance(params?: ListSystemMaintenanceParams): Promise<SystemPagMaintenanceWindowReply> {
+    return this.request<SystemPagMaintenanceWindowReply>("GET", `/api/v1/system/maintenance`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 创建维护窗口
+   *
+   * 本接口用于创建维护窗口, 生效期间暂停命中的计划任务并屏蔽告警
+   *
+   * POST /api/v1/system/maintenance
+   */
+  createSystemMaintenance(body: SystemMaintenanceWindowRequest): Promise<SystemMaintenanceWindowReply> {
+    return this.request<SystemMaintenanceWindowReply>("POST", `/api/v1/system/maintenance`, { body });
+  }
+
+  /**
+   * 查询当前生效的维护窗口
+   *
+   * 本接口用于查询当前时间生效的全部维护窗口
+   *
+   * GET /api/v1/system/maintenance/active
+   */
+  listSystemMaintenanceActive(): Promise<SystemListMaintenanceWindowReply> {
+    return this.request<SystemListMaintenanceWindowReply>("GET", `/api/v1/system/maintenance/active`);
+  }
+
+  /**
+   * 查询维护窗口详情
+   *
+   * 本接口用于查询指定ID的维护窗口
+   *
+   * GET /api/v1/system/maintenance/{id}
+   */
+  getSystemMaintenance(id: number): Promise<SystemMaintenanceWindowReply> {
+    return this.request<SystemMaintenanceWindowReply>("GET", `/api/v1/system/maintenance/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 更新维护窗口
+   *
+   * 本接口用于更新指定ID的维护窗口
+   *
+   * PUT /api/v1/system/maintenance/{id}
+   */
+  updateSystemMaintenance(id: number, body: SystemMaintenanceWindowRequest): Promise<SystemMaintenanceWindowReply> {
+    return this.request<SystemMaintenanceWindowReply>("PUT", `/api/v1/system/maintenance/${encodeURIComponent(String(id))}`, { body });
+  }
+
+  /**
+   * 删除维护窗口
+   *
+   * 本接口用于删除指定ID的维护窗口
+   *
+   * DELETE /api/v1/system/maintenance/{id}
+   */
+  deleteSystemMaintenance(id: number): Promise<CommonMapAPIReply> {
+    return this.request<CommonMapAPIReply>("DELETE", `/api/v1/system/maintenance/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 查询当前用户可用的功能
+   *
+   * 本接口用于查询全部功能开关对当前用户是否开放, 前端据此决定是否展示功能入口
+   *
+   * GET /api/v1/system/me/feature-flag
+   */
+  getSystemMeFeatureFlag(): Promise<SystemMyFeatureFlagReply> {
+    return this.request<SystemMyFeatureFlagReply>("GET", `/api/v1/system/me/feature-flag`);
+  }
+
+  /**
+   * 生成报表
+   *
+   * 本接口用于生成日报或周报, 统计各集群任务的执行成功率和登录情况
+   *
+   * GET /api/v1/system/report
+   */
+  getSystemReport(params: GetSystemReportParams): Promise<SystemReportReply> {
+    return this.request<SystemReportReply>("GET", `/api/v1/system/report`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 导出报表
+   *
+   * 本接口用于生成日报或周报并导出为csv或xlsx文件
+   *
+   * 返回原始响应, 由调用方读取响应体
+   *
+   * GET /api/v1/system/report/export
+   */
+  getSystemReportExport(params: GetSystemReportExportParams): Promise<Response> {
+    return this.request<Response>("GET", `/api/v1/system/report/export`, { query: params as unknown as Record<string, QueryValue> | undefined, raw: true });
+  }
+
+  /**
+   * 查询定时报表文件
+   *
+   * 本接口用于查询定时任务生成的报表文件, 按生成时间倒序
+   *
+   * GET /api/v1/system/report/file
+   */
+  listSystemReportFile(): Promise<SystemListReportFileReply> {
+    return this.request<SystemListReportFileReply>("GET", `/api/v1/system/report/file`);
+  }
+
+  /**
+   * 下载定时报表文件
+   *
+   * 本接口用于下载定时任务生成的报表文件
+   *
+   * 返回原始响应, 由调用方读取响应体
+   *
+   * GET /api/v1/system/report/file/{name}
+   */
+  getSystemReportFile(name: string): Promise<Response> {
+    return this.request<Response>("GET", `/api/v1/system/report/file/${encodeURIComponent(String(name))}`, { raw: true });
+  }
+
+  /**
+   * 后台生成报表文件
+   *
+   * 本接口用于提交生成报表文件的后台任务, 通过/api/v1/tasks/{id}查询进度, 任务成功后结果中的name为报表文件名
+   *
+   * POST /api/v1/system/report/task
+   */
+  createSystemReportTask(body: SystemExportReportRequest): Promise<SystemTaskReply> {
+    return this.request<SystemTaskReply>("POST", `/api/v1/system/report/task`, { body });
+  }
+
+  /**
+   * 查询webhook订阅列表
+   *
+   * 本接口用于分页查询webhook订阅
+   *
+   * GET /api/v1/system/webhook
+   */
+  listSystemWebhook(params?: ListSystemWebhookParams): Promise<SystemPagWebhookSubscriptionReply> {
+    return this.request<SystemPagWebhookSubscriptionReply>("GET", `/api/v1/system/webhook`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 创建webhook订阅
+   *
+   * 本接口用于创建webhook订阅, 订阅的事件发生时向推送地址发送带签名的POST请求
+   *
+   * POST /api/v1/system/webhook
+   */
+  createSystemWebhook(body: SystemWebhookSubscriptionRequest): Promise<SystemWebhookSubscriptionReply> {
+    return this.request<SystemWebhookSubscriptionReply>("POST", `/api/v1/system/webhook`, { body });
+  }
+
+  /**
+   * 查询webhook订阅详情
+   *
+   * 本接口用于查询指定ID的webhook订阅
+   *
+   * GET /api/v1/system/webhook/{id}
+   */
+  getSystemWebhook(id: number): Promise<SystemWebhookSubscriptionReply> {
+    return this.request<SystemWebhookSubscriptionReply>("GET", `/api/v1/system/webhook/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 更新webhook订阅
+   *
+   * 本接口用于更新指定ID的webhook订阅, 签名密钥为空时不修改
+   *
+   * PUT /api/v1/system/webhook/{id}
+   */
+  updateSystemWebhook(id: number, body: SystemWebhookSubscriptionRequest): Promise<SystemWebhookSubscriptionReply> {
+    return this.request<SystemWebhookSubscriptionReply>("PUT", `/api/v1/system/webhook/${encodeURIComponent(String(id))}`, { body });
+  }
+
+  /**
+   * 删除webhook订阅
+   *
+   * 本接口用于删除指定ID的webhook订阅及其推送记录
+   *
+   * DELETE /api/v1/system/webhook/{id}
+   */
+  deleteSystemWebhook(id: number): Promise<CommonMapAPIReply> {
+    return this.request<CommonMapAPIReply>("DELETE", `/api/v1/system/webhook/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 查询webhook推送记录
+   *
+   * 本接口用于分页查询指定ID的webhook订阅的推送记录
+   *
+   * GET /api/v1/system/webhook/{id}/delivery
+   */
+  listSystemWebhookDelivery(id: number, params?: ListSystemWebhookDeliveryParams): Promise<SystemPagWebhookDeliveryReply> {
+    return this.request<SystemPagWebhookDeliveryReply>("GET", `/api/v1/system/webhook/${encodeURIComponent(String(id))}/delivery`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 测试webhook订阅
+   *
+   * 本接口用于向指定ID的webhook推送一条测试事件并返回推送结果, 测试推送失败时不重试
+   *
+   * POST /api/v1/system/webhook/{id}/test
+   */
+  createSystemWebhookTest(id: number): Promise<SystemWebhookDeliveryReply> {
+    return this.request<SystemWebhookDeliveryReply>("POST", `/api/v1/system/webhook/${encodeURIComponent(String(id))}/test`);
+  }
+
+  /**
+   * 查询后台任务列表
+   *
+   * 本接口用于分页查询后台任务, 支持通过filter参数按字段组合过滤
+   *
+   * GET /api/v1/tasks
+   */
+  listTasks(params?: ListTasksParams): Promise<SystemPagTaskReply> {
+    return this.request<SystemPagTaskReply>("GET", `/api/v1/tasks`, { query: params as unknown as Record<string, QueryValue> | undefined });
+  }
+
+  /**
+   * 查询后台任务详情
+   *
+   * 本接口用于查询后台任务的状态、进度和结果, 任务结束前可轮询本接口
+   *
+   * GET /api/v1/tasks/{id}
+   */
+  getTasks(id: number): Promise<SystemTaskReply> {
+    return this.request<SystemTaskReply>("GET", `/api/v1/tasks/${encodeURIComponent(String(id))}`);
+  }
+
+  /**
+   * 取消后台任务
+   *
+   * 本接口用于取消未结束的后台任务, 执行中的任务在下一个检查点退出后状态变为canceled
+   *
+   * POST /api/v1/tasks/{id}/cancel
+   */
+  createTasksCancel(id: number): Promise<CommonMapAPIReply> {
+    return this.request<CommonMapAPIReply>("POST", `/api/v1/tasks/${encodeURIComponent(String(id))}/cancel`);
+  }
+}